@@ -99,6 +99,14 @@ go_t3c_check_refs_dir="$ccpath"/t3c-check-refs
 	cp "$TC_DIR"/"$ccdir"/t3c-check-refs/t3c-check-refs.1 .
 ) || { echo "Could not copy go program at $(pwd): $!"; exit 1; }
 
+# copy t3c-compat binary
+go_t3c_compat_dir="$ccpath"/t3c-compat
+( mkdir -p "$go_t3c_compat_dir" && \
+	cd "$go_t3c_compat_dir" && \
+	cp "$TC_DIR"/"$ccdir"/t3c-compat/t3c-compat .
+	cp "$TC_DIR"/"$ccdir"/t3c-compat/t3c-compat.1 .
+) || { echo "Could not copy go program at $(pwd): $!"; exit 1; }
+
 # copy t3c-diff binary
 go_t3c_diff_dir="$ccpath"/t3c-diff
 ( mkdir -p "$go_t3c_diff_dir" && \
@@ -123,6 +131,38 @@ go_t3c_preprocess_dir="$ccpath"/t3c-preprocess
 	cp "$TC_DIR"/"$ccdir"/t3c-preprocess/t3c-preprocess.1 .
 ) || { echo "Could not copy go program at $(pwd): $!"; exit 1; }
 
+# copy t3c-nic binary
+go_t3c_nic_dir="$ccpath"/t3c-nic
+( mkdir -p "$go_t3c_nic_dir" && \
+	cd "$go_t3c_nic_dir" && \
+	cp "$TC_DIR"/"$ccdir"/t3c-nic/t3c-nic .
+	cp "$TC_DIR"/"$ccdir"/t3c-nic/t3c-nic.1 .
+) || { echo "Could not copy go program at $(pwd): $!"; exit 1; }
+
+# copy t3c-log-agent binary
+go_t3c_log_agent_dir="$ccpath"/t3c-log-agent
+( mkdir -p "$go_t3c_log_agent_dir" && \
+	cd "$go_t3c_log_agent_dir" && \
+	cp "$TC_DIR"/"$ccdir"/t3c-log-agent/t3c-log-agent .
+	cp "$TC_DIR"/"$ccdir"/t3c-log-agent/t3c-log-agent.1 .
+) || { echo "Could not copy go program at $(pwd): $!"; exit 1; }
+
+# copy t3c-agent binary
+go_t3c_agent_dir="$ccpath"/t3c-agent
+( mkdir -p "$go_t3c_agent_dir" && \
+	cd "$go_t3c_agent_dir" && \
+	cp "$TC_DIR"/"$ccdir"/t3c-agent/t3c-agent .
+	cp "$TC_DIR"/"$ccdir"/t3c-agent/t3c-agent.1 .
+) || { echo "Could not copy go program at $(pwd): $!"; exit 1; }
+
+# copy t3c-ship-logs binary
+go_t3c_ship_logs_dir="$ccpath"/t3c-ship-logs
+( mkdir -p "$go_t3c_ship_logs_dir" && \
+	cd "$go_t3c_ship_logs_dir" && \
+	cp "$TC_DIR"/"$ccdir"/t3c-ship-logs/t3c-ship-logs .
+	cp "$TC_DIR"/"$ccdir"/t3c-ship-logs/t3c-ship-logs.1 .
+) || { echo "Could not copy go program at $(pwd): $!"; exit 1; }
+
 # copy t3c-tail binary
 go_t3c_tail_dir="$ccpath"/t3c-tail
 ( mkdir -p "$go_t3c_tail_dir" && \
@@ -169,6 +209,10 @@ to_upd_src=src/github.com/apache/trafficcontrol/"$ccdir"/t3c-update
 cp -p "$to_upd_src"/t3c-update ${RPM_BUILD_ROOT}/"$installdir"
 gzip -c -9 "$src"/t3c-update/t3c-update.1 > ${RPM_BUILD_ROOT}/"$mandir"/"$man1dir"/t3c-update.1.gz
 
+t3c_compat_src=src/github.com/apache/trafficcontrol/"$ccdir"/t3c-compat
+cp -p "$t3c_compat_src"/t3c-compat ${RPM_BUILD_ROOT}/"$installdir"
+gzip -c -9 "$src"/t3c-compat/t3c-compat.1 > ${RPM_BUILD_ROOT}/"$mandir"/"$man1dir"/t3c-compat.1.gz
+
 t3c_diff_src=src/github.com/apache/trafficcontrol/"$ccdir"/t3c-diff
 cp -p "$t3c_diff_src"/t3c-diff ${RPM_BUILD_ROOT}/"$installdir"
 gzip -c -9 "$src"/t3c-diff/t3c-diff.1 > ${RPM_BUILD_ROOT}/"$mandir"/"$man1dir"/t3c-diff.1.gz
@@ -193,6 +237,22 @@ t3c_preprocess_src=src/github.com/apache/trafficcontrol/"$ccdir"/t3c-preprocess
 cp -p "$t3c_preprocess_src"/t3c-preprocess ${RPM_BUILD_ROOT}/"$installdir"
 gzip -c -9 "$src"/t3c-preprocess/t3c-preprocess.1 > ${RPM_BUILD_ROOT}/"$mandir"/"$man1dir"/t3c-preprocess.1.gz
 
+t3c_nic_src=src/github.com/apache/trafficcontrol/"$ccdir"/t3c-nic
+cp -p "$t3c_nic_src"/t3c-nic ${RPM_BUILD_ROOT}/"$installdir"
+gzip -c -9 "$src"/t3c-nic/t3c-nic.1 > ${RPM_BUILD_ROOT}/"$mandir"/"$man1dir"/t3c-nic.1.gz
+
+t3c_log_agent_src=src/github.com/apache/trafficcontrol/"$ccdir"/t3c-log-agent
+cp -p "$t3c_log_agent_src"/t3c-log-agent ${RPM_BUILD_ROOT}/"$installdir"
+gzip -c -9 "$src"/t3c-log-agent/t3c-log-agent.1 > ${RPM_BUILD_ROOT}/"$mandir"/"$man1dir"/t3c-log-agent.1.gz
+
+t3c_agent_src=src/github.com/apache/trafficcontrol/"$ccdir"/t3c-agent
+cp -p "$t3c_agent_src"/t3c-agent ${RPM_BUILD_ROOT}/"$installdir"
+gzip -c -9 "$src"/t3c-agent/t3c-agent.1 > ${RPM_BUILD_ROOT}/"$mandir"/"$man1dir"/t3c-agent.1.gz
+
+t3c_ship_logs_src=src/github.com/apache/trafficcontrol/"$ccdir"/t3c-ship-logs
+cp -p "$t3c_ship_logs_src"/t3c-ship-logs ${RPM_BUILD_ROOT}/"$installdir"
+gzip -c -9 "$src"/t3c-ship-logs/t3c-ship-logs.1 > ${RPM_BUILD_ROOT}/"$mandir"/"$man1dir"/t3c-ship-logs.1.gz
+
 mkdir -p ${RPM_BUILD_ROOT}/var/lib/trafficcontrol-cache-config
 
 ls ${RPM_BUILD_ROOT}/"$mandir"/"$man1dir"/
@@ -228,25 +288,35 @@ fi
 /usr/bin/traffic_ops_ort.pl
 /usr/bin/supermicro_udev_mapper.pl
 /usr/bin/t3c
+/usr/bin/t3c-agent
 /usr/bin/t3c-apply
 /usr/bin/t3c-check
 /usr/bin/t3c-check-refs
 /usr/bin/t3c-check-reload
+/usr/bin/t3c-compat
 /usr/bin/t3c-diff
 /usr/bin/t3c-generate
+/usr/bin/t3c-log-agent
+/usr/bin/t3c-nic
 /usr/bin/t3c-preprocess
 /usr/bin/t3c-request
+/usr/bin/t3c-ship-logs
 /usr/bin/t3c-tail
 /usr/bin/t3c-update
 /usr/share/man/man1/t3c.1.gz
+/usr/share/man/man1/t3c-agent.1.gz
 /usr/share/man/man1/t3c-apply.1.gz
 /usr/share/man/man1/t3c-check.1.gz
 /usr/share/man/man1/t3c-check-refs.1.gz
 /usr/share/man/man1/t3c-check-reload.1.gz
+/usr/share/man/man1/t3c-compat.1.gz
 /usr/share/man/man1/t3c-diff.1.gz
 /usr/share/man/man1/t3c-generate.1.gz
+/usr/share/man/man1/t3c-log-agent.1.gz
+/usr/share/man/man1/t3c-nic.1.gz
 /usr/share/man/man1/t3c-preprocess.1.gz
 /usr/share/man/man1/t3c-request.1.gz
+/usr/share/man/man1/t3c-ship-logs.1.gz
 /usr/share/man/man1/t3c-tail.1.gz
 /usr/share/man/man1/t3c-update.1.gz
 
@@ -0,0 +1,152 @@
+package checks
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-agent/scheduler"
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+	toclient "github.com/apache/trafficcontrol/traffic_ops/v5-client"
+)
+
+// Check is a servercheck whose value is reported by running a command.
+type Check struct {
+	// Name is the short name of the servercheck, which must be that of a
+	// servercheck extension in Traffic Ops.
+	Name string `json:"name"`
+	// Command is the command, and its arguments, whose exit code determines
+	// the value of the check: 1 if it's 0, and 0 otherwise.
+	Command []string `json:"command"`
+}
+
+// Inserter inserts servercheck values into Traffic Ops. It's implemented by
+// the Traffic Ops client.
+type Inserter interface {
+	InsertServerCheckStatus(tc.ServercheckRequestNullable, toclient.RequestOptions) (tc.ServercheckPostResponse, toclientlib.ReqInf, error)
+}
+
+// Opts are the options of a Reporter.
+type Opts struct {
+	// HostName is the host name of the cache server whose checks are
+	// reported.
+	HostName string
+	// T3CCheck is the short name of the servercheck whose value is 1 if the
+	// last t3c run succeeded, and 0 otherwise. If it's empty, that isn't
+	// reported.
+	T3CCheck string
+	// Checks are the serverchecks whose values are reported by running
+	// commands.
+	Checks []Check
+	// Timeout is how long a check's command may run before it fails.
+	Timeout time.Duration
+}
+
+// Reporter reports the values of serverchecks to Traffic Ops.
+type Reporter struct {
+	opts    Opts
+	to      Inserter
+	lastT3C func() (scheduler.Result, bool)
+}
+
+// New returns a new Reporter, which reports servercheck values with the
+// given Traffic Ops client, and gets the result of the last t3c run from the
+// given function.
+func New(opts Opts, to Inserter, lastT3C func() (scheduler.Result, bool)) *Reporter {
+	return &Reporter{opts: opts, to: to, lastT3C: lastT3C}
+}
+
+// Run reports servercheck values every interval until the context is done.
+func (r *Reporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := r.Report(ctx); err != nil {
+			log.Errorln("reporting serverchecks: " + err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Report reports the current value of each servercheck. The t3c check isn't
+// reported until t3c has run.
+func (r *Reporter) Report(ctx context.Context) error {
+	values := map[string]bool{}
+	if r.opts.T3CCheck != "" {
+		if last, ok := r.lastT3C(); ok {
+			values[r.opts.T3CCheck] = last.ExitCode == 0
+		}
+	}
+	for _, check := range r.opts.Checks {
+		values[check.Name] = runCheck(ctx, check.Command, r.opts.Timeout)
+	}
+
+	errs := []error{}
+	for name, ok := range values {
+		value := 0
+		if ok {
+			value = 1
+		}
+		status := tc.ServercheckRequestNullable{
+			Name:     util.StrPtr(name),
+			HostName: util.StrPtr(r.opts.HostName),
+			Value:    util.IntPtr(value),
+		}
+		if _, _, err := r.to.InsertServerCheckStatus(status, toclient.RequestOptions{}); err != nil {
+			errs = append(errs, fmt.Errorf("servercheck '%s': %w", name, err))
+		}
+	}
+	return util.JoinErrs(errs)
+}
+
+// runCheck runs a check's command, and returns whether it exits with 0.
+func runCheck(ctx context.Context, command []string, timeout time.Duration) bool {
+	if len(command) == 0 {
+		return false
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	output, err := exec.CommandContext(ctx, command[0], command[1:]...).CombinedOutput()
+	if err != nil {
+		exitErr := (*exec.ExitError)(nil)
+		if !errors.As(err, &exitErr) {
+			log.Errorf("running check '%s': %s\n", strings.Join(command, " "), err.Error())
+		} else {
+			log.Infof("check '%s' failed: %s\n", strings.Join(command, " "), strings.TrimSpace(string(output)))
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package checks
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-agent/scheduler"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+	toclient "github.com/apache/trafficcontrol/traffic_ops/v5-client"
+)
+
+type fakeInserter struct {
+	values map[string]int
+	fail   bool
+}
+
+func (f *fakeInserter) InsertServerCheckStatus(status tc.ServercheckRequestNullable, _ toclient.RequestOptions) (tc.ServercheckPostResponse, toclientlib.ReqInf, error) {
+	if f.fail {
+		return tc.ServercheckPostResponse{}, toclientlib.ReqInf{}, errors.New("unavailable")
+	}
+	if *status.HostName != "edge" {
+		return tc.ServercheckPostResponse{}, toclientlib.ReqInf{}, errors.New("unexpected host name " + *status.HostName)
+	}
+	f.values[*status.Name] = *status.Value
+	return tc.ServercheckPostResponse{}, toclientlib.ReqInf{}, nil
+}
+
+func TestReport(t *testing.T) {
+	last := scheduler.Result{}
+	ran := false
+	lastT3C := func() (scheduler.Result, bool) { return last, ran }
+	to := &fakeInserter{values: map[string]int{}}
+	r := New(Opts{
+		HostName: "edge",
+		T3CCheck: "ORT",
+		Checks: []Check{
+			{Name: "ATS", Command: []string{"true"}},
+			{Name: "DSCP", Command: []string{"false"}},
+			{Name: "NONE", Command: []string{"/nonexistent/check"}},
+		},
+		Timeout: time.Second,
+	}, to, lastT3C)
+
+	if err := r.Report(context.Background()); err != nil {
+		t.Fatalf("unexpected error reporting: %v", err)
+	}
+	if _, ok := to.values["ORT"]; ok {
+		t.Error("expected the t3c check not to be reported before t3c runs")
+	}
+	expected := map[string]int{"ATS": 1, "DSCP": 0, "NONE": 0}
+	for name, value := range expected {
+		if to.values[name] != value {
+			t.Errorf("expected check '%s' to be %d, actual: %d", name, value, to.values[name])
+		}
+	}
+
+	ran = true
+	last.ExitCode = 139
+	r.Report(context.Background())
+	if to.values["ORT"] != 0 {
+		t.Errorf("expected the t3c check to be 0 after a failed run, actual: %d", to.values["ORT"])
+	}
+	last.ExitCode = 0
+	r.Report(context.Background())
+	if to.values["ORT"] != 1 {
+		t.Errorf("expected the t3c check to be 1 after a successful run, actual: %d", to.values["ORT"])
+	}
+
+	to.fail = true
+	if err := r.Report(context.Background()); err == nil {
+		t.Error("expected an error when Traffic Ops is unavailable, got none")
+	}
+}
@@ -0,0 +1,410 @@
+package config
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-agent/checks"
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/pborman/getopt/v2"
+)
+
+const AppName = "t3c-agent"
+
+const (
+	DefaultConfigFile                 = "/etc/trafficcontrol/t3c-agent.json"
+	DefaultT3CPath                    = "/usr/bin/t3c"
+	DefaultSyncDSIntervalSeconds      = 60
+	DefaultT3CTimeoutSeconds          = 600
+	DefaultServercheckIntervalSeconds = 60
+	DefaultServercheckTimeoutSeconds  = 30
+	DefaultTORequestTimeoutSeconds    = 30
+	DefaultChangeFeedRetrySeconds     = 5
+	DefaultTrafficCtlTimeoutSeconds   = 30
+	DefaultPrefetchIntervalSeconds    = 60
+	DefaultPrefetchConcurrency        = 4
+	DefaultPrefetchTimeoutSeconds     = 60
+	DefaultPrefetchHTTPAddr           = "127.0.0.1:80"
+	DefaultPrefetchHTTPSAddr          = "127.0.0.1:443"
+	DefaultControlSecretFile          = "/opt/trafficserver/etc/trafficserver/log-agent.secret"
+	DefaultAccessLog                  = "/opt/trafficserver/var/log/trafficserver/custom_ats_2.log"
+	DefaultDiagsLog                   = "/opt/trafficserver/var/log/trafficserver/diags.log"
+)
+
+// File is the agent's config file.
+type File struct {
+	TOURL                      string         `json:"to-url"`
+	TOUser                     string         `json:"to-user"`
+	TOPass                     string         `json:"to-pass"`
+	TOToken                    string         `json:"to-token"`
+	TOInsecure                 bool           `json:"to-insecure"`
+	TORequestTimeoutSeconds    int            `json:"to-request-timeout-seconds"`
+	CacheHostName              string         `json:"cache-host-name"`
+	T3CPath                    string         `json:"t3c-path"`
+	T3CArgs                    []string       `json:"t3c-args"`
+	T3CInitialMode             string         `json:"t3c-initial-mode"`
+	T3CTimeoutSeconds          int            `json:"t3c-timeout-seconds"`
+	SyncDSIntervalSeconds      int            `json:"syncds-interval-seconds"`
+	HealthClientConfigFile     string         `json:"health-client-config-file"`
+	ServercheckIntervalSeconds int            `json:"servercheck-interval-seconds"`
+	ServercheckTimeoutSeconds  int            `json:"servercheck-timeout-seconds"`
+	ServercheckT3C             string         `json:"servercheck-t3c"`
+	Serverchecks               []checks.Check `json:"serverchecks"`
+	ChangeFeed                 bool           `json:"change-feed"`
+	ControlPort                int            `json:"control-port"`
+	ControlSecretFile          string         `json:"control-secret-file"`
+	ControlTLSCert             string         `json:"control-tls-cert"`
+	ControlTLSKey              string         `json:"control-tls-key"`
+	AccessLog                  string         `json:"access-log"`
+	DiagsLog                   string         `json:"diags-log"`
+	MaxTailSeconds             int            `json:"max-tail-seconds"`
+	TrafficCtl                 string         `json:"traffic-ctl"`
+	TrafficCtlTimeoutSeconds   int            `json:"traffic-ctl-timeout-seconds"`
+	CacheHitWindowSeconds      int            `json:"cache-hit-window-seconds"`
+	PrefetchIntervalSeconds    int            `json:"prefetch-interval-seconds"`
+	PrefetchConcurrency        int            `json:"prefetch-concurrency"`
+	PrefetchTimeoutSeconds     int            `json:"prefetch-timeout-seconds"`
+	PrefetchHTTPAddr           string         `json:"prefetch-http-addr"`
+	PrefetchHTTPSAddr          string         `json:"prefetch-https-addr"`
+}
+
+type Cfg struct {
+	LogLocationDebug string
+	LogLocationWarn  string
+	LogLocationError string
+	LogLocationInfo  string
+	// ConfigFile is the path of the config file.
+	ConfigFile string
+	// TOURL, TOUser, and TOPass are the Traffic Ops URL and credentials,
+	// which are also given to t3c. If TOToken is not empty, it is used
+	// instead of TOUser and TOPass.
+	TOURL            string
+	TOUser           string
+	TOPass           string
+	TOToken          string
+	TOInsecure       bool
+	TORequestTimeout time.Duration
+	// CacheHostName is the host name of the cache server in Traffic Ops.
+	CacheHostName string
+	// T3CPath is the path of t3c, and T3CArgs the arguments given to
+	// 't3c apply' besides its run mode.
+	T3CPath string
+	T3CArgs []string
+	// T3CInitialMode is the mode in which t3c is run when the agent starts.
+	T3CInitialMode t3cutil.Mode
+	T3CTimeout     time.Duration
+	// SyncDSInterval is how often t3c is run in syncds mode.
+	SyncDSInterval time.Duration
+	// HealthClientConfigFile is the tc-health-client config file. If it's
+	// empty, the health client isn't run.
+	HealthClientConfigFile string
+	// ServercheckInterval is how often serverchecks are reported. If no
+	// checks are configured, none are reported.
+	ServercheckInterval time.Duration
+	ServercheckTimeout  time.Duration
+	// ServercheckT3C is the name of the servercheck to which the success of
+	// the last t3c run is reported. If it's empty, it isn't reported.
+	ServercheckT3C string
+	Serverchecks   []checks.Check
+	// ChangeFeed is whether t3c is run in syncds mode as soon as the
+	// Traffic Ops change feed shows the cache server changed.
+	ChangeFeed bool
+	// ControlPort is the port of the local control API, which also serves
+	// the log tails and traffic_ctl commands of Traffic Ops in place of
+	// t3c-log-agent. If it's 0, the control API isn't served.
+	ControlPort int
+	// ControlSecret is the bearer token with which the control API is
+	// authenticated, which must be the agent_secret of the log_tail section
+	// of the cdn.conf of Traffic Ops.
+	ControlSecret     string
+	ControlTLSCert    string
+	ControlTLSKey     string
+	AccessLog         string
+	DiagsLog          string
+	MaxTailSeconds    int
+	TrafficCtl        string
+	TrafficCtlTimeout time.Duration
+	// CacheHitWindow is the length of the windows in which the control API
+	// counts the cache hits and misses of the access log, as t3c-log-agent
+	// does. If it's 0, they aren't counted.
+	CacheHitWindow time.Duration
+	// PrefetchInterval is how often the prefetch jobs of the cache server
+	// are polled from Traffic Ops. If it's 0, they aren't done.
+	PrefetchInterval time.Duration
+	// PrefetchConcurrency is how many URLs of a prefetch job are fetched at
+	// once, and PrefetchTimeout how long fetching each may take.
+	PrefetchConcurrency int
+	PrefetchTimeout     time.Duration
+	// PrefetchHTTPAddr and PrefetchHTTPSAddr are the addresses of the cache
+	// to which the requests for the URLs of prefetch jobs are sent.
+	PrefetchHTTPAddr  string
+	PrefetchHTTPSAddr string
+	Version           string
+	GitRevision       string
+}
+
+func (cfg Cfg) AppVersion() string { return t3cutil.VersionStr(AppName, cfg.Version, cfg.GitRevision) }
+func (cfg Cfg) UserAgent() string  { return t3cutil.UserAgentStr(AppName, cfg.Version, cfg.GitRevision) }
+
+func (cfg Cfg) DebugLog() log.LogLocation   { return log.LogLocation(cfg.LogLocationDebug) }
+func (cfg Cfg) ErrorLog() log.LogLocation   { return log.LogLocation(cfg.LogLocationError) }
+func (cfg Cfg) InfoLog() log.LogLocation    { return log.LogLocation(cfg.LogLocationInfo) }
+func (cfg Cfg) WarningLog() log.LogLocation { return log.LogLocation(cfg.LogLocationWarn) }
+func (cfg Cfg) EventLog() log.LogLocation   { return log.LogLocation(log.LogLocationNull) } // event logging is not used.
+
+// Usage() writes command line options and usage to 'stderr'
+func Usage() {
+	getopt.PrintUsage(os.Stderr)
+	os.Exit(0)
+}
+
+// InitConfig() intializes the configuration variables and loggers.
+func InitConfig(appVersion string, gitRevision string) (Cfg, error) {
+	configFilePtr := getopt.StringLong("config-file", 'f', DefaultConfigFile, "Config file")
+	helpPtr := getopt.BoolLong("help", 'h', "Print usage information and exit")
+	versionPtr := getopt.BoolLong("version", 'V', "Print the app version")
+	verbosePtr := getopt.CounterLong("verbose", 'v', `Log verbosity. Logging is output to stderr. By default, errors are logged. To log warnings, pass '-v'. To log info, pass '-vv'. To omit error logging, see '-s'`)
+	silentPtr := getopt.BoolLong("silent", 's', `Silent. Errors are not logged, and the 'verbose' flag is ignored. If a fatal error occurs, the return code will be non-zero but no text will be output to stderr`)
+
+	getopt.Parse()
+
+	if *helpPtr == true {
+		Usage()
+	} else if *versionPtr == true {
+		cfg := &Cfg{Version: appVersion, GitRevision: gitRevision}
+		fmt.Println(cfg.AppVersion())
+		os.Exit(0)
+	}
+
+	logLocationError := log.LogLocationStderr
+	logLocationWarn := log.LogLocationNull
+	logLocationInfo := log.LogLocationNull
+	logLocationDebug := log.LogLocationNull
+	if *silentPtr {
+		logLocationError = log.LogLocationNull
+	} else {
+		if *verbosePtr >= 1 {
+			logLocationWarn = log.LogLocationStderr
+		}
+		if *verbosePtr >= 2 {
+			logLocationInfo = log.LogLocationStderr
+			logLocationDebug = log.LogLocationStderr // t3c only has 3 verbosity options: none (-s), error (default or --verbose=0), warning (-v), and info (-vv). Any code calling log.Debug is treated as Info.
+		}
+	}
+
+	if *verbosePtr > 2 {
+		return Cfg{}, errors.New("Too many verbose options. The maximum log verbosity level is 2 (-vv or --verbose=2) for errors (0), warnings (1), and info (2)")
+	}
+
+	bts, err := ioutil.ReadFile(*configFilePtr)
+	if err != nil {
+		return Cfg{}, errors.New("reading config file: " + err.Error())
+	}
+	file, err := ParseFile(bts)
+	if err != nil {
+		return Cfg{}, errors.New("config file '" + *configFilePtr + "': " + err.Error())
+	}
+	cfg, err := fromFile(file)
+	if err != nil {
+		return Cfg{}, errors.New("config file '" + *configFilePtr + "': " + err.Error())
+	}
+	cfg.LogLocationDebug = logLocationDebug
+	cfg.LogLocationError = logLocationError
+	cfg.LogLocationInfo = logLocationInfo
+	cfg.LogLocationWarn = logLocationWarn
+	cfg.ConfigFile = *configFilePtr
+	cfg.Version = appVersion
+	cfg.GitRevision = gitRevision
+
+	if err := log.InitCfg(cfg); err != nil {
+		return Cfg{}, errors.New("initializing loggers: " + err.Error())
+	}
+
+	return cfg, nil
+}
+
+// ParseFile parses a config file, filling in the defaults of the settings it
+// omits.
+func ParseFile(bts []byte) (File, error) {
+	file := File{
+		TORequestTimeoutSeconds:    DefaultTORequestTimeoutSeconds,
+		T3CPath:                    DefaultT3CPath,
+		T3CInitialMode:             string(t3cutil.ModeBadAss),
+		T3CTimeoutSeconds:          DefaultT3CTimeoutSeconds,
+		SyncDSIntervalSeconds:      DefaultSyncDSIntervalSeconds,
+		ServercheckIntervalSeconds: DefaultServercheckIntervalSeconds,
+		ServercheckTimeoutSeconds:  DefaultServercheckTimeoutSeconds,
+		ChangeFeed:                 true,
+		ControlPort:                tc.DefaultLogAgentPort,
+		ControlSecretFile:          DefaultControlSecretFile,
+		AccessLog:                  DefaultAccessLog,
+		DiagsLog:                   DefaultDiagsLog,
+		MaxTailSeconds:             tc.DefaultServerLogTailMaxSeconds,
+		TrafficCtlTimeoutSeconds:   DefaultTrafficCtlTimeoutSeconds,
+		PrefetchIntervalSeconds:    DefaultPrefetchIntervalSeconds,
+		PrefetchConcurrency:        DefaultPrefetchConcurrency,
+		PrefetchTimeoutSeconds:     DefaultPrefetchTimeoutSeconds,
+		PrefetchHTTPAddr:           DefaultPrefetchHTTPAddr,
+		PrefetchHTTPSAddr:          DefaultPrefetchHTTPSAddr,
+	}
+	if err := json.Unmarshal(bts, &file); err != nil {
+		return File{}, errors.New("parsing: " + err.Error())
+	}
+	return file, nil
+}
+
+// fromFile validates a config file, and returns the config it gives, reading
+// the control API secret from its file.
+func fromFile(file File) (Cfg, error) {
+	if file.TOURL == "" {
+		return Cfg{}, errors.New("to-url is required")
+	}
+	if file.TOToken == "" && (file.TOUser == "" || file.TOPass == "") {
+		return Cfg{}, errors.New("to-user and to-pass are required unless to-token is given")
+	}
+	if file.CacheHostName == "" {
+		hostName, err := os.Hostname()
+		if err != nil {
+			return Cfg{}, errors.New("cache-host-name not given, and getting the host name: " + err.Error())
+		}
+		file.CacheHostName = strings.Split(hostName, ".")[0]
+	}
+	mode := t3cutil.StrToMode(file.T3CInitialMode)
+	if mode != t3cutil.ModeBadAss && mode != t3cutil.ModeSyncDS {
+		return Cfg{}, errors.New("t3c-initial-mode must be badass or syncds")
+	}
+	if file.TORequestTimeoutSeconds <= 0 || file.T3CTimeoutSeconds <= 0 || file.SyncDSIntervalSeconds <= 0 || file.ServercheckIntervalSeconds <= 0 || file.ServercheckTimeoutSeconds <= 0 || file.TrafficCtlTimeoutSeconds <= 0 || file.MaxTailSeconds <= 0 {
+		return Cfg{}, errors.New("intervals, timeouts, and max-tail-seconds must be positive")
+	}
+	for _, check := range file.Serverchecks {
+		if check.Name == "" || len(check.Command) == 0 {
+			return Cfg{}, errors.New("serverchecks must have a name and command")
+		}
+	}
+	if file.ControlPort < 0 || file.ControlPort > 65535 {
+		return Cfg{}, errors.New("control-port must be from 0 to 65535")
+	}
+	if file.CacheHitWindowSeconds != 0 && (file.CacheHitWindowSeconds < 60 || file.CacheHitWindowSeconds > 3600) {
+		return Cfg{}, errors.New("cache-hit-window-seconds must be from 60 to 3600")
+	}
+	if file.PrefetchIntervalSeconds < 0 || file.PrefetchConcurrency <= 0 || file.PrefetchTimeoutSeconds <= 0 {
+		return Cfg{}, errors.New("prefetch-interval-seconds must not be negative, and prefetch-concurrency and prefetch-timeout-seconds must be positive")
+	}
+	if file.PrefetchIntervalSeconds > 0 && (file.PrefetchHTTPAddr == "" || file.PrefetchHTTPSAddr == "") {
+		return Cfg{}, errors.New("prefetch-http-addr and prefetch-https-addr are required unless prefetch-interval-seconds is 0")
+	}
+	if (file.ControlTLSCert == "") != (file.ControlTLSKey == "") {
+		return Cfg{}, errors.New("control-tls-cert and control-tls-key must be given together")
+	}
+
+	secret := ""
+	if file.ControlPort != 0 {
+		bts, err := ioutil.ReadFile(file.ControlSecretFile)
+		if err != nil {
+			return Cfg{}, errors.New("reading control-secret-file: " + err.Error())
+		}
+		if secret = strings.TrimSpace(string(bts)); secret == "" {
+			return Cfg{}, errors.New("control-secret-file '" + file.ControlSecretFile + "' is empty")
+		}
+	}
+
+	return Cfg{
+		TOURL:                  file.TOURL,
+		TOUser:                 file.TOUser,
+		TOPass:                 file.TOPass,
+		TOToken:                file.TOToken,
+		TOInsecure:             file.TOInsecure,
+		TORequestTimeout:       time.Second * time.Duration(file.TORequestTimeoutSeconds),
+		CacheHostName:          file.CacheHostName,
+		T3CPath:                file.T3CPath,
+		T3CArgs:                file.T3CArgs,
+		T3CInitialMode:         mode,
+		T3CTimeout:             time.Second * time.Duration(file.T3CTimeoutSeconds),
+		SyncDSInterval:         time.Second * time.Duration(file.SyncDSIntervalSeconds),
+		HealthClientConfigFile: file.HealthClientConfigFile,
+		ServercheckInterval:    time.Second * time.Duration(file.ServercheckIntervalSeconds),
+		ServercheckTimeout:     time.Second * time.Duration(file.ServercheckTimeoutSeconds),
+		ServercheckT3C:         file.ServercheckT3C,
+		Serverchecks:           file.Serverchecks,
+		ChangeFeed:             file.ChangeFeed,
+		ControlPort:            file.ControlPort,
+		ControlSecret:          secret,
+		ControlTLSCert:         file.ControlTLSCert,
+		ControlTLSKey:          file.ControlTLSKey,
+		AccessLog:              file.AccessLog,
+		DiagsLog:               file.DiagsLog,
+		MaxTailSeconds:         file.MaxTailSeconds,
+		TrafficCtl:             file.TrafficCtl,
+		TrafficCtlTimeout:      time.Second * time.Duration(file.TrafficCtlTimeoutSeconds),
+		CacheHitWindow:         time.Second * time.Duration(file.CacheHitWindowSeconds),
+		PrefetchInterval:       time.Second * time.Duration(file.PrefetchIntervalSeconds),
+		PrefetchConcurrency:    file.PrefetchConcurrency,
+		PrefetchTimeout:        time.Second * time.Duration(file.PrefetchTimeoutSeconds),
+		PrefetchHTTPAddr:       file.PrefetchHTTPAddr,
+		PrefetchHTTPSAddr:      file.PrefetchHTTPSAddr,
+	}, nil
+}
+
+func (cfg Cfg) PrintConfig() {
+	log.Debugf("LogLocationDebug: %s\n", cfg.LogLocationDebug)
+	log.Debugf("LogLocationError: %s\n", cfg.LogLocationError)
+	log.Debugf("LogLocationInfo: %s\n", cfg.LogLocationInfo)
+	log.Debugf("LogLocationWarn: %s\n", cfg.LogLocationWarn)
+	log.Debugf("ConfigFile: %s\n", cfg.ConfigFile)
+	log.Debugf("TOURL: %s\n", cfg.TOURL)
+	log.Debugf("TOUser: %s\n", cfg.TOUser)
+	log.Debugf("TOInsecure: %t\n", cfg.TOInsecure)
+	log.Debugf("TORequestTimeout: %s\n", cfg.TORequestTimeout)
+	log.Debugf("CacheHostName: %s\n", cfg.CacheHostName)
+	log.Debugf("T3CPath: %s\n", cfg.T3CPath)
+	log.Debugf("T3CArgs: %v\n", cfg.T3CArgs)
+	log.Debugf("T3CInitialMode: %s\n", cfg.T3CInitialMode)
+	log.Debugf("T3CTimeout: %s\n", cfg.T3CTimeout)
+	log.Debugf("SyncDSInterval: %s\n", cfg.SyncDSInterval)
+	log.Debugf("HealthClientConfigFile: %s\n", cfg.HealthClientConfigFile)
+	log.Debugf("ServercheckInterval: %s\n", cfg.ServercheckInterval)
+	log.Debugf("ServercheckTimeout: %s\n", cfg.ServercheckTimeout)
+	log.Debugf("ServercheckT3C: %s\n", cfg.ServercheckT3C)
+	log.Debugf("Serverchecks: %+v\n", cfg.Serverchecks)
+	log.Debugf("ChangeFeed: %t\n", cfg.ChangeFeed)
+	log.Debugf("ControlPort: %d\n", cfg.ControlPort)
+	log.Debugf("ControlTLSCert: %s\n", cfg.ControlTLSCert)
+	log.Debugf("ControlTLSKey: %s\n", cfg.ControlTLSKey)
+	log.Debugf("AccessLog: %s\n", cfg.AccessLog)
+	log.Debugf("DiagsLog: %s\n", cfg.DiagsLog)
+	log.Debugf("MaxTailSeconds: %d\n", cfg.MaxTailSeconds)
+	log.Debugf("TrafficCtl: %s\n", cfg.TrafficCtl)
+	log.Debugf("TrafficCtlTimeout: %s\n", cfg.TrafficCtlTimeout)
+	log.Debugf("CacheHitWindow: %s\n", cfg.CacheHitWindow)
+	log.Debugf("PrefetchInterval: %s\n", cfg.PrefetchInterval)
+	log.Debugf("PrefetchConcurrency: %d\n", cfg.PrefetchConcurrency)
+	log.Debugf("PrefetchTimeout: %s\n", cfg.PrefetchTimeout)
+	log.Debugf("PrefetchHTTPAddr: %s\n", cfg.PrefetchHTTPAddr)
+	log.Debugf("PrefetchHTTPSAddr: %s\n", cfg.PrefetchHTTPSAddr)
+}
@@ -0,0 +1,128 @@
+package control
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-agent/scheduler"
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-log"
+)
+
+const (
+	// StatusPath is the path of the agent's status.
+	StatusPath = "/status"
+	// ApplyPath is the path to which requests to run t3c are posted.
+	ApplyPath = "/t3c/apply"
+	// ModeQueryParam is the query parameter giving the mode in which to run
+	// t3c, syncds by default.
+	ModeQueryParam = "mode"
+)
+
+// Scheduler runs t3c. It's implemented by *scheduler.Scheduler.
+type Scheduler interface {
+	Trigger(mode t3cutil.Mode, reason string) bool
+	Last() (scheduler.Result, bool)
+}
+
+// Status is the status of the agent, served from StatusPath.
+type Status struct {
+	// LastT3C is the result of the last t3c run, or nil if t3c hasn't been
+	// run.
+	LastT3C *scheduler.Result `json:"lastT3c"`
+}
+
+// Handler is the http.Handler of the agent's local control API. It serves
+// the agent's status and requests to run t3c itself, and everything else -
+// the log tails and traffic_ctl commands of Traffic Ops - with the log agent
+// handler.
+type Handler struct {
+	secret   string
+	sched    Scheduler
+	logAgent http.Handler
+}
+
+// New returns a new Handler, authenticating requests for the agent's status
+// and t3c runs with the given bearer token.
+func New(secret string, sched Scheduler, logAgent http.Handler) *Handler {
+	return &Handler{secret: secret, sched: sched, logAgent: logAgent}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case StatusPath:
+		h.serve(w, r, http.MethodGet, h.serveStatus)
+	case ApplyPath:
+		h.serve(w, r, http.MethodPost, h.serveApply)
+	default:
+		h.logAgent.ServeHTTP(w, r)
+	}
+}
+
+// serve serves the request with the handler if it has the given method and
+// bears the secret.
+func (h *Handler) serve(w http.ResponseWriter, r *http.Request, method string, handler http.HandlerFunc) {
+	if r.Method != method {
+		w.Header().Set("Allow", method)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if h.secret == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.secret)) != 1 {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	handler(w, r)
+}
+
+// serveStatus serves GET requests to StatusPath.
+func (h *Handler) serveStatus(w http.ResponseWriter, r *http.Request) {
+	status := Status{}
+	if last, ok := h.sched.Last(); ok {
+		status.LastT3C = &last
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		log.Errorf("writing status to %s: %s\n", r.RemoteAddr, err.Error())
+	}
+}
+
+// serveApply serves POST requests to ApplyPath, which trigger a t3c run, or
+// fail with 409 Conflict if one is already pending.
+func (h *Handler) serveApply(w http.ResponseWriter, r *http.Request) {
+	mode := t3cutil.ModeSyncDS
+	if param := r.URL.Query().Get(ModeQueryParam); param != "" {
+		if mode = t3cutil.StrToMode(param); mode == t3cutil.ModeInvalid || mode == t3cutil.ModeReport {
+			http.Error(w, "invalid "+ModeQueryParam+" '"+param+"'", http.StatusBadRequest)
+			return
+		}
+	}
+	if !h.sched.Trigger(mode, "control API") {
+		http.Error(w, "a t3c run is already pending", http.StatusConflict)
+		return
+	}
+	log.Infof("%s requested t3c be run in %s mode\n", r.RemoteAddr, mode)
+	w.WriteHeader(http.StatusAccepted)
+}
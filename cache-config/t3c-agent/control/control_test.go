@@ -0,0 +1,101 @@
+package control
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-agent/scheduler"
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+)
+
+type fakeScheduler struct {
+	pending []t3cutil.Mode
+	last    *scheduler.Result
+}
+
+func (f *fakeScheduler) Trigger(mode t3cutil.Mode, _ string) bool {
+	if len(f.pending) > 0 {
+		return false
+	}
+	f.pending = append(f.pending, mode)
+	return true
+}
+
+func (f *fakeScheduler) Last() (scheduler.Result, bool) {
+	if f.last == nil {
+		return scheduler.Result{}, false
+	}
+	return *f.last, true
+}
+
+func request(h http.Handler, method, path, token string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestHandler(t *testing.T) {
+	sched := &fakeScheduler{}
+	logAgent := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusTeapot) })
+	h := New("secret", sched, logAgent)
+
+	tests := []struct {
+		method   string
+		path     string
+		token    string
+		expected int
+	}{
+		{http.MethodGet, StatusPath, "", http.StatusUnauthorized},
+		{http.MethodGet, StatusPath, "wrong", http.StatusUnauthorized},
+		{http.MethodPost, StatusPath, "secret", http.StatusMethodNotAllowed},
+		{http.MethodGet, ApplyPath, "secret", http.StatusMethodNotAllowed},
+		{http.MethodPost, ApplyPath + "?mode=report", "secret", http.StatusBadRequest},
+		{http.MethodPost, ApplyPath + "?mode=bogus", "secret", http.StatusBadRequest},
+		{http.MethodPost, ApplyPath + "?mode=badass", "secret", http.StatusAccepted},
+		{http.MethodPost, ApplyPath, "secret", http.StatusConflict},
+		{http.MethodGet, "/logs/diags", "", http.StatusTeapot},
+	}
+	for _, test := range tests {
+		if w := request(h, test.method, test.path, test.token); w.Code != test.expected {
+			t.Errorf("%s %s: expected status %d, actual %d", test.method, test.path, test.expected, w.Code)
+		}
+	}
+	if len(sched.pending) != 1 || sched.pending[0] != t3cutil.ModeBadAss {
+		t.Errorf("expected a badass run to be triggered, actual %v", sched.pending)
+	}
+
+	sched.last = &scheduler.Result{Mode: t3cutil.ModeSyncDS, Reason: "interval"}
+	w := request(h, http.MethodGet, StatusPath, "secret")
+	status := Status{}
+	if err := json.Unmarshal(w.Body.Bytes(), &status); err != nil {
+		t.Fatalf("decoding status: %s", err.Error())
+	}
+	if status.LastT3C == nil || status.LastT3C.Reason != "interval" {
+		t.Errorf("expected status of the last t3c run, actual %+v", status.LastT3C)
+	}
+}
@@ -0,0 +1,95 @@
+package feed
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+	toclient "github.com/apache/trafficcontrol/traffic_ops/v5-client"
+)
+
+// MaxRetryInterval is the longest the agent waits to reconnect to the change
+// feed after failing to.
+const MaxRetryInterval = 5 * time.Minute
+
+// Streamer streams the Traffic Ops change feed. It's implemented by the
+// Traffic Ops client.
+type Streamer interface {
+	StreamChangeFeed(toclient.RequestOptions, toclient.ChangeFeedHandlers) (int64, toclientlib.ReqInf, error)
+}
+
+// Watch streams the changes to the server with the given ID from the Traffic
+// Ops change feed until the context is done, calling trigger with the reason
+// for each change, such as updates being queued on the server. Changes which
+// were missed, because the feed no longer retains them, also call trigger.
+//
+// The feed is reconnected whenever it ends, resuming after the last change
+// received. If connecting fails, the feed is reconnected after the retry
+// interval, which doubles with each failure up to MaxRetryInterval.
+//
+// Streams can't be interrupted, so Watch returns once the stream open when
+// the context is done ends, or receives an event.
+func Watch(ctx context.Context, to Streamer, serverID int, retry time.Duration, trigger func(reason string)) {
+	id := strconv.Itoa(serverID)
+	handlers := toclient.ChangeFeedHandlers{
+		Change: func(change tc.ChangeEvent) bool {
+			if change.Type == "server" && change.ID == id {
+				trigger("change feed " + change.Action + " of the server")
+			}
+			return ctx.Err() == nil
+		},
+		Reset: func(tc.ChangeFeedReset) bool {
+			trigger("change feed reset")
+			return ctx.Err() == nil
+		},
+	}
+
+	since := int64(0)
+	wait := retry
+	for ctx.Err() == nil {
+		opts := toclient.NewRequestOptions()
+		opts.QueryParameters.Set(tc.ChangeFeedTypeQueryParam, "server")
+		if since > 0 {
+			opts.QueryParameters.Set(tc.ChangeFeedSinceQueryParam, strconv.FormatInt(since, 10))
+		}
+		last, _, err := to.StreamChangeFeed(opts, handlers)
+		if last > 0 {
+			since = last
+		}
+		if err == nil {
+			wait = retry
+			continue
+		}
+		log.Errorf("streaming Traffic Ops change feed, reconnecting in %s: %s\n", wait, err.Error())
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+		if wait *= 2; wait > MaxRetryInterval {
+			wait = MaxRetryInterval
+		}
+	}
+}
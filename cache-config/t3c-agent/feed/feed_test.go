@@ -0,0 +1,95 @@
+package feed
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+	toclient "github.com/apache/trafficcontrol/traffic_ops/v5-client"
+)
+
+// fakeStreamer sends one batch of events per stream, failing the second
+// stream, and cancels the context once it runs out of batches.
+type fakeStreamer struct {
+	batches [][]tc.ChangeEvent
+	since   []string
+	streams int
+	cancel  context.CancelFunc
+}
+
+func (f *fakeStreamer) StreamChangeFeed(opts toclient.RequestOptions, handlers toclient.ChangeFeedHandlers) (int64, toclientlib.ReqInf, error) {
+	f.since = append(f.since, opts.QueryParameters.Get(tc.ChangeFeedSinceQueryParam))
+	f.streams++
+	if f.streams == 2 {
+		return 0, toclientlib.ReqInf{}, errors.New("unavailable")
+	}
+	if len(f.batches) == 0 {
+		f.cancel()
+		handlers.Reset(tc.ChangeFeedReset{Sequence: 10})
+		return 0, toclientlib.ReqInf{}, nil
+	}
+	batch := f.batches[0]
+	f.batches = f.batches[1:]
+	last := int64(0)
+	for _, e := range batch {
+		last = e.Sequence
+		if !handlers.Change(e) {
+			break
+		}
+	}
+	return last, toclientlib.ReqInf{}, nil
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	to := &fakeStreamer{
+		batches: [][]tc.ChangeEvent{
+			{
+				{Sequence: 1, Type: "server", ID: "7", Action: tc.ChangeActionUpdate},
+				{Sequence: 2, Type: "server", ID: "8", Action: tc.ChangeActionUpdate},
+			},
+			{
+				{Sequence: 3, Type: "server", ID: "7", Action: tc.ChangeActionDelete},
+			},
+		},
+		cancel: cancel,
+	}
+	reasons := []string{}
+	Watch(ctx, to, 7, time.Millisecond, func(reason string) { reasons = append(reasons, reason) })
+
+	expected := []string{
+		"change feed " + tc.ChangeActionUpdate + " of the server",
+		"change feed " + tc.ChangeActionDelete + " of the server",
+		"change feed reset",
+	}
+	if !reflect.DeepEqual(reasons, expected) {
+		t.Errorf("expected triggers %v, actual %v", expected, reasons)
+	}
+	if expected := []string{"", "2", "2", "3"}; !reflect.DeepEqual(to.since, expected) {
+		t.Errorf("expected streams since %v, actual %v", expected, to.since)
+	}
+}
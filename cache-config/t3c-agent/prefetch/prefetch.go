@@ -0,0 +1,425 @@
+package prefetch
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+	toclient "github.com/apache/trafficcontrol/traffic_ops/v5-client"
+)
+
+// MaxManifestDepth is how deeply manifests listing other manifests - such as
+// HLS master playlists listing media playlists - are expanded.
+const MaxManifestDepth = 3
+
+// MaxExpandedURLs is the most URLs a prefetch job is expanded into, beyond
+// which the URLs its manifests list are ignored.
+const MaxExpandedURLs = 100000
+
+// DefaultReportInterval is how often progress on a prefetch job is reported
+// if Opts doesn't say.
+const DefaultReportInterval = 30 * time.Second
+
+// Jobs gets the prefetch jobs of a cache server, and reports its progress on
+// them, from Traffic Ops. It's implemented by the Traffic Ops client.
+type Jobs interface {
+	GetPendingPrefetchJobs(int, toclient.RequestOptions) (tc.PrefetchJobsResponse, toclientlib.ReqInf, error)
+	UpdatePrefetchJobProgress(int, tc.PrefetchJobProgress, toclient.RequestOptions) (tc.PrefetchJobResponse, toclientlib.ReqInf, error)
+}
+
+// Opts are the options of a Prefetcher.
+type Opts struct {
+	// ServerID is the ID of the cache server in Traffic Ops.
+	ServerID int
+	// HTTPAddr and HTTPSAddr are the addresses of the cache - e.g.
+	// 127.0.0.1:80 and 127.0.0.1:443 - to which requests for HTTP and HTTPS
+	// URLs are sent, with the hosts of the URLs.
+	HTTPAddr  string
+	HTTPSAddr string
+	// Concurrency is how many URLs are fetched at once.
+	Concurrency int
+	// Timeout is how long fetching a URL may take before it fails.
+	Timeout time.Duration
+	// ReportInterval is how often progress on a job is reported while it's
+	// done. Progress is also reported when it's finished.
+	ReportInterval time.Duration
+}
+
+// Prefetcher does the prefetch jobs of a cache server: it fetches their URLs,
+// and those listed by their manifests, through the cache, so that the cache
+// holds their content before it's requested.
+type Prefetcher struct {
+	opts   Opts
+	to     Jobs
+	client *http.Client
+}
+
+// New returns a new Prefetcher, which gets prefetch jobs and reports progress
+// on them with the given Traffic Ops client.
+func New(opts Opts, to Jobs) *Prefetcher {
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+	if opts.ReportInterval <= 0 {
+		opts.ReportInterval = DefaultReportInterval
+	}
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	transport := &http.Transport{
+		// every request goes to the cache, whatever the host of its URL,
+		// which is still used for the Host header and TLS server name
+		DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			if _, port, err := net.SplitHostPort(addr); err == nil && port == "443" {
+				return dialer.DialContext(ctx, network, opts.HTTPSAddr)
+			}
+			return dialer.DialContext(ctx, network, opts.HTTPAddr)
+		},
+		MaxIdleConnsPerHost: opts.Concurrency,
+		IdleConnTimeout:     time.Minute,
+	}
+	return &Prefetcher{
+		opts: opts,
+		to:   to,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   opts.Timeout,
+			// redirects are cached as they are, rather than followed
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+	}
+}
+
+// Run does the pending prefetch jobs of the cache server every interval until
+// the context is done.
+func (p *Prefetcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := p.Poll(ctx); err != nil {
+			log.Errorln("doing prefetch jobs: " + err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll gets the pending prefetch jobs of the cache server, and does each of
+// them in turn.
+func (p *Prefetcher) Poll(ctx context.Context) error {
+	opts := toclient.NewRequestOptions()
+	opts.Context = ctx
+	resp, _, err := p.to.GetPendingPrefetchJobs(p.opts.ServerID, opts)
+	if err != nil {
+		return errors.New("getting pending prefetch jobs: " + err.Error())
+	}
+	for _, job := range resp.Response {
+		if ctx.Err() != nil {
+			return nil
+		}
+		log.Infof("starting prefetch job #%d of Delivery Service %s\n", job.ID, job.DeliveryService)
+		progress, err := p.Do(ctx, job)
+		if err != nil {
+			log.Errorf("prefetch job #%d: %s\n", job.ID, err.Error())
+			continue
+		}
+		log.Infof("finished prefetch job #%d: fetched %d of %d URLs, %d failed\n", job.ID, progress.Fetched, progress.Total, progress.Failed)
+	}
+	return nil
+}
+
+// progress is the progress on a job, which is updated concurrently.
+type progress struct {
+	mutex sync.Mutex
+	tc.PrefetchJobProgress
+}
+
+func (p *progress) fetched() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.Fetched++
+}
+
+func (p *progress) failed(u string, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.Failed++
+	if len(p.FailedURLs) < tc.MaxPrefetchFailedURLs {
+		p.FailedURLs = append(p.FailedURLs, u+": "+err.Error())
+	}
+}
+
+func (p *progress) get() tc.PrefetchJobProgress {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	current := p.PrefetchJobProgress
+	current.FailedURLs = append([]string(nil), p.FailedURLs...)
+	return current
+}
+
+// Do does a prefetch job, reporting progress on it to Traffic Ops, and
+// returns the progress on it when it's finished. It stops early, without
+// error, if the context is done, or the job is deleted - which Traffic Ops
+// reports when progress on it is.
+func (p *Prefetcher) Do(ctx context.Context, job tc.PrefetchJob) (tc.PrefetchJobProgress, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	prog := &progress{PrefetchJobProgress: tc.PrefetchJobProgress{ServerID: p.opts.ServerID}}
+	urls := append([]string{}, job.URLs...)
+	for _, manifest := range job.ManifestURLs {
+		listed, err := p.expandManifest(ctx, manifest, MaxExpandedURLs-len(urls))
+		if err != nil {
+			// the manifest itself is counted as a URL to fetch, which failed
+			prog.Total++
+			prog.failed(manifest, err)
+			continue
+		}
+		urls = append(urls, listed...)
+	}
+	urls = dedupe(urls)
+	prog.Total += len(urls)
+	if ok := p.report(ctx, job.ID, prog.get()); !ok {
+		return prog.get(), nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p.fetchAll(ctx, urls, prog)
+	}()
+	ticker := time.NewTicker(p.opts.ReportInterval)
+	defer ticker.Stop()
+	for finished := false; !finished; {
+		select {
+		case <-done:
+			finished = true
+		case <-ticker.C:
+			if ok := p.report(ctx, job.ID, prog.get()); !ok {
+				cancel()
+			}
+		}
+	}
+	final := prog.get()
+	if ctx.Err() != nil {
+		return final, nil
+	}
+	final.Done = true
+	if ok := p.report(ctx, job.ID, final); !ok && ctx.Err() == nil {
+		return final, errors.New("reporting completion failed")
+	}
+	return final, nil
+}
+
+// report reports progress on a job, and returns whether it should go on,
+// which it shouldn't if the job was deleted. Progress which fails to be
+// reported for other reasons is reported again later.
+func (p *Prefetcher) report(ctx context.Context, jobID int, current tc.PrefetchJobProgress) bool {
+	opts := toclient.NewRequestOptions()
+	opts.Context = ctx
+	_, reqInf, err := p.to.UpdatePrefetchJobProgress(jobID, current, opts)
+	if err == nil {
+		return true
+	}
+	if reqInf.StatusCode == http.StatusNotFound {
+		log.Infof("prefetch job #%d was deleted, stopping it\n", jobID)
+		return false
+	}
+	log.Errorf("reporting progress on prefetch job #%d: %s\n", jobID, err.Error())
+	return ctx.Err() == nil
+}
+
+// fetchAll fetches the given URLs through the cache, Concurrency at a time,
+// until they're all fetched or the context is done.
+func (p *Prefetcher) fetchAll(ctx context.Context, urls []string, prog *progress) {
+	work := make(chan string)
+	wg := sync.WaitGroup{}
+	for i := 0; i < p.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for u := range work {
+				if err := p.fetch(ctx, u); err != nil {
+					prog.failed(u, err)
+				} else {
+					prog.fetched()
+				}
+			}
+		}()
+	}
+feed:
+	for _, u := range urls {
+		select {
+		case work <- u:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+}
+
+// fetch fetches a URL through the cache, discarding its content.
+func (p *Prefetcher) fetch(ctx context.Context, u string) error {
+	body, err := p.get(ctx, u)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	_, err = io.Copy(io.Discard, body)
+	return err
+}
+
+// get requests a URL through the cache, and returns its body if it succeeds.
+func (p *Prefetcher) get(ctx context.Context, u string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 400 {
+		resp.Body.Close()
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// expandManifest returns the URLs listed by the manifest with the given URL,
+// fetched through the cache, along with the manifest's own URL - up to the
+// given number of them. Manifests it lists are expanded in turn, up to
+// MaxManifestDepth deep.
+func (p *Prefetcher) expandManifest(ctx context.Context, manifest string, limit int) ([]string, error) {
+	urls := []string{}
+	seen := map[string]struct{}{}
+	var expand func(string, int) error
+	expand = func(u string, depth int) error {
+		if _, ok := seen[u]; ok || len(urls) >= limit {
+			return nil
+		}
+		seen[u] = struct{}{}
+		urls = append(urls, u)
+		base, err := url.Parse(u)
+		if err != nil {
+			return err
+		}
+		body, err := p.get(ctx, u)
+		if err != nil {
+			return err
+		}
+		listed, manifests, err := parseManifest(base, body)
+		body.Close()
+		if err != nil {
+			return err
+		}
+		for _, l := range listed {
+			if _, ok := seen[l]; !ok && len(urls) < limit {
+				seen[l] = struct{}{}
+				urls = append(urls, l)
+			}
+		}
+		if depth >= MaxManifestDepth {
+			return nil
+		}
+		for _, m := range manifests {
+			if err := expand(m, depth+1); err != nil {
+				log.Warnf("expanding manifest %s listed by %s: %s\n", m, u, err.Error())
+			}
+		}
+		return nil
+	}
+	if err := expand(manifest, 1); err != nil {
+		return nil, err
+	}
+	return urls, nil
+}
+
+// parseManifest returns the URLs listed by a manifest with the given URL,
+// resolved against it, separating those of other manifests. A manifest is an
+// HLS playlist, whose URIs are its lines not starting with "#" and the URI
+// attributes of its tags, or a plain list of URLs one per line. URLs ending
+// in ".m3u8" are those of other manifests.
+func parseManifest(base *url.URL, r io.Reader) ([]string, []string, error) {
+	urls := []string{}
+	manifests := []string{}
+	add := func(ref string) {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			return
+		}
+		u, err := base.Parse(ref)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return
+		}
+		u.Fragment = ""
+		if strings.HasSuffix(strings.ToLower(u.Path), ".m3u8") {
+			manifests = append(manifests, u.String())
+		} else {
+			urls = append(urls, u.String())
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "#") {
+			add(line)
+			continue
+		}
+		if i := strings.Index(line, `URI="`); i >= 0 {
+			uri := line[i+len(`URI="`):]
+			if end := strings.Index(uri, `"`); end >= 0 {
+				add(uri[:end])
+			}
+		}
+	}
+	return urls, manifests, scanner.Err()
+}
+
+// dedupe returns the given strings without duplicates, in their original
+// order.
+func dedupe(strs []string) []string {
+	seen := make(map[string]struct{}, len(strs))
+	deduped := strs[:0]
+	for _, s := range strs {
+		if _, ok := seen[s]; !ok {
+			seen[s] = struct{}{}
+			deduped = append(deduped, s)
+		}
+	}
+	return deduped
+}
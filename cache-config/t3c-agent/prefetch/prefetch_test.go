@@ -0,0 +1,169 @@
+package prefetch
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+	toclient "github.com/apache/trafficcontrol/traffic_ops/v5-client"
+)
+
+type fakeJobs struct {
+	mutex    sync.Mutex
+	jobs     []tc.PrefetchJob
+	reports  []tc.PrefetchJobProgress
+	deleted  bool
+	serverID int
+}
+
+func (f *fakeJobs) GetPendingPrefetchJobs(serverID int, _ toclient.RequestOptions) (tc.PrefetchJobsResponse, toclientlib.ReqInf, error) {
+	f.serverID = serverID
+	return tc.PrefetchJobsResponse{Response: f.jobs}, toclientlib.ReqInf{}, nil
+}
+
+func (f *fakeJobs) UpdatePrefetchJobProgress(_ int, progress tc.PrefetchJobProgress, _ toclient.RequestOptions) (tc.PrefetchJobResponse, toclientlib.ReqInf, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.deleted {
+		return tc.PrefetchJobResponse{}, toclientlib.ReqInf{StatusCode: http.StatusNotFound}, errors.New("not found")
+	}
+	f.reports = append(f.reports, progress)
+	return tc.PrefetchJobResponse{}, toclientlib.ReqInf{StatusCode: http.StatusOK}, nil
+}
+
+const testMasterPlaylist = `#EXTM3U
+#EXT-X-STREAM-INF:BANDWIDTH=1280000
+low/index.m3u8
+#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID="aac",URI="audio/index.m3u8"
+`
+
+const testMediaPlaylist = `#EXTM3U
+#EXT-X-TARGETDURATION:10
+#EXT-X-KEY:METHOD=AES-128,URI="https://keys.example.com/key"
+#EXTINF:10,
+seg1.ts
+#EXTINF:10,
+seg2.ts#t=0
+`
+
+func TestParseManifest(t *testing.T) {
+	base, _ := url.Parse("http://video.example.com/live/low/index.m3u8")
+	urls, manifests, err := parseManifest(base, strings.NewReader(testMediaPlaylist))
+	if err != nil {
+		t.Fatalf("unexpected error parsing manifest: %v", err)
+	}
+	expected := []string{"https://keys.example.com/key", "http://video.example.com/live/low/seg1.ts", "http://video.example.com/live/low/seg2.ts"}
+	if !reflect.DeepEqual(urls, expected) {
+		t.Errorf("expected URLs %v, got %v", expected, urls)
+	}
+	if len(manifests) != 0 {
+		t.Errorf("expected no manifests, got %v", manifests)
+	}
+
+	base, _ = url.Parse("http://video.example.com/live/master.m3u8")
+	urls, manifests, err = parseManifest(base, strings.NewReader(testMasterPlaylist))
+	if err != nil {
+		t.Fatalf("unexpected error parsing manifest: %v", err)
+	}
+	expected = []string{"http://video.example.com/live/low/index.m3u8", "http://video.example.com/live/audio/index.m3u8"}
+	if len(urls) != 0 || !reflect.DeepEqual(manifests, expected) {
+		t.Errorf("expected manifests %v and no URLs, got manifests %v and URLs %v", expected, manifests, urls)
+	}
+}
+
+func TestDo(t *testing.T) {
+	requested := sync.Map{}
+	cache := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "video.example.com" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		requested.Store(r.URL.Path, true)
+		switch r.URL.Path {
+		case "/live/master.m3u8":
+			w.Write([]byte(testMasterPlaylist))
+		case "/live/low/index.m3u8", "/live/audio/index.m3u8":
+			w.Write([]byte("#EXTM3U\n#EXTINF:10,\nseg1.ts\n"))
+		case "/missing.ts":
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Write([]byte("content"))
+		}
+	}))
+	defer cache.Close()
+
+	to := &fakeJobs{}
+	p := New(Opts{
+		ServerID:       5,
+		HTTPAddr:       cache.Listener.Addr().String(),
+		Concurrency:    2,
+		Timeout:        time.Second,
+		ReportInterval: time.Hour,
+	}, to)
+	job := tc.PrefetchJob{
+		ID:           1,
+		URLs:         []string{"http://video.example.com/a.ts", "http://video.example.com/missing.ts"},
+		ManifestURLs: []string{"http://video.example.com/live/master.m3u8"},
+	}
+	progress, err := p.Do(context.Background(), job)
+	if err != nil {
+		t.Fatalf("unexpected error doing job: %v", err)
+	}
+	// 2 URLs, the master playlist, 2 media playlists, and a segment of each
+	if progress.Total != 7 || progress.Fetched != 6 || progress.Failed != 1 || !progress.Done {
+		t.Errorf("expected 6 of 7 URLs fetched and 1 failed, got %+v", progress)
+	}
+	if len(progress.FailedURLs) != 1 || !strings.HasPrefix(progress.FailedURLs[0], "http://video.example.com/missing.ts") {
+		t.Errorf("expected the missing URL to have failed, got %v", progress.FailedURLs)
+	}
+	for _, path := range []string{"/a.ts", "/live/low/seg1.ts", "/live/audio/seg1.ts"} {
+		if _, ok := requested.Load(path); !ok {
+			t.Errorf("expected %s to be fetched through the cache", path)
+		}
+	}
+	if len(to.reports) != 2 || to.reports[0].Total != 7 || to.reports[0].Done || !to.reports[1].Done {
+		t.Errorf("expected a report of starting and one of finishing the job, got %+v", to.reports)
+	}
+	for _, report := range to.reports {
+		if report.ServerID != 5 {
+			t.Errorf("expected progress to be reported for server 5, got %d", report.ServerID)
+		}
+	}
+
+	to.deleted = true
+	progress, err = p.Do(context.Background(), job)
+	if err != nil {
+		t.Fatalf("unexpected error doing a deleted job: %v", err)
+	}
+	if progress.Done || progress.Fetched != 0 {
+		t.Errorf("expected a deleted job to be stopped, got %+v", progress)
+	}
+}
@@ -0,0 +1,177 @@
+package scheduler
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-log"
+)
+
+// Result is the result of a t3c run.
+type Result struct {
+	// Mode is the run mode of t3c.
+	Mode t3cutil.Mode `json:"mode"`
+	// Reason is why t3c was run, e.g. "interval" or "change feed".
+	Reason string `json:"reason"`
+	// Start is when t3c was started.
+	Start time.Time `json:"start"`
+	// DurationSeconds is how long t3c ran.
+	DurationSeconds float64 `json:"durationSeconds"`
+	// ExitCode is the exit code of t3c, or -1 if it couldn't be run or was
+	// killed.
+	ExitCode int `json:"exitCode"`
+	// Error describes why t3c couldn't be run, if it couldn't.
+	Error string `json:"error,omitempty"`
+}
+
+// Opts are the options of a Scheduler.
+type Opts struct {
+	// Command is the path of t3c.
+	Command string
+	// Args are the arguments given to 't3c apply' besides its run mode.
+	Args []string
+	// Env are the environment variables, of the form key=value, given to
+	// t3c besides those of the agent, e.g. its Traffic Ops credentials.
+	Env []string
+	// Timeout is how long t3c may run before it's killed. If it's 0, t3c
+	// isn't killed.
+	Timeout time.Duration
+}
+
+// run is a request to run t3c.
+type run struct {
+	mode   t3cutil.Mode
+	reason string
+}
+
+// Scheduler runs t3c, periodically and when triggered, never more than once
+// at a time.
+type Scheduler struct {
+	opts Opts
+	// runMu is held while t3c runs, and may be held by others who mustn't
+	// change the config of trafficserver while t3c does.
+	runMu   sync.Mutex
+	pending chan run
+
+	lastMu sync.Mutex
+	last   *Result
+}
+
+// New returns a new Scheduler with the given options.
+func New(opts Opts) *Scheduler {
+	return &Scheduler{opts: opts, pending: make(chan run, 1)}
+}
+
+// Locker returns the lock held while t3c runs. Holding it keeps t3c from
+// running.
+func (s *Scheduler) Locker() sync.Locker {
+	return &s.runMu
+}
+
+// Trigger requests that t3c be run in the given mode as soon as possible.
+// Requests made while one is pending are coalesced with it, in which case
+// false is returned.
+func (s *Scheduler) Trigger(mode t3cutil.Mode, reason string) bool {
+	select {
+	case s.pending <- run{mode: mode, reason: reason}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Last returns the result of the last t3c run, and false if t3c hasn't been
+// run.
+func (s *Scheduler) Last() (Result, bool) {
+	s.lastMu.Lock()
+	defer s.lastMu.Unlock()
+	if s.last == nil {
+		return Result{}, false
+	}
+	return *s.last, true
+}
+
+// Run runs t3c in the initial mode, and then in syncds mode every interval
+// and whenever triggered, until the context is done.
+func (s *Scheduler) Run(ctx context.Context, initial t3cutil.Mode, interval time.Duration) {
+	s.run(ctx, initial, "start")
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.run(ctx, t3cutil.ModeSyncDS, "interval")
+		case r := <-s.pending:
+			s.run(ctx, r.mode, r.reason)
+			// A triggered run makes the next periodic one unnecessary.
+			ticker.Reset(interval)
+		}
+	}
+}
+
+// run runs t3c once, and records its result.
+func (s *Scheduler) run(ctx context.Context, mode t3cutil.Mode, reason string) Result {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	if s.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.Timeout)
+		defer cancel()
+	}
+	args := append([]string{"apply", "--run-mode=" + string(mode)}, s.opts.Args...)
+	cmd := exec.CommandContext(ctx, s.opts.Command, args...)
+	cmd.Env = append(os.Environ(), s.opts.Env...)
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+
+	result := Result{Mode: mode, Reason: reason, Start: time.Now()}
+	log.Infof("running t3c in %s mode, because of %s\n", mode, reason)
+	err := cmd.Run()
+	result.DurationSeconds = time.Since(result.Start).Seconds()
+	if err != nil {
+		exitErr := (*exec.ExitError)(nil)
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.ExitCode()
+		} else {
+			result.ExitCode = -1
+			result.Error = err.Error()
+		}
+	}
+	if result.ExitCode != 0 {
+		log.Errorf("t3c in %s mode failed with exit code %d %s\n", mode, result.ExitCode, result.Error)
+	} else {
+		log.Infof("t3c in %s mode succeeded in %.1f seconds\n", mode, result.DurationSeconds)
+	}
+
+	s.lastMu.Lock()
+	s.last = &result
+	s.lastMu.Unlock()
+	return result
+}
@@ -0,0 +1,125 @@
+package scheduler
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+)
+
+// writeFakeT3C writes a t3c which appends its arguments and the TO_URL
+// environment variable to a file, and exits with the given status, and
+// returns its path and that of the file.
+func writeFakeT3C(t *testing.T, status string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "t3c")
+	out := filepath.Join(dir, "runs")
+	script := "#!/bin/sh\necho \"$@ $TO_URL\" >> " + out + "\nexit " + status + "\n"
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatalf("writing fake t3c: %v", err)
+	}
+	return path, out
+}
+
+func readRuns(t *testing.T, path string) []string {
+	t.Helper()
+	bts, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading runs: %v", err)
+	}
+	return strings.Split(strings.TrimSpace(string(bts)), "\n")
+}
+
+func TestSchedulerRun(t *testing.T) {
+	t3c, runs := writeFakeT3C(t, "0")
+	s := New(Opts{Command: t3c, Args: []string{"--cache-host-name=edge"}, Env: []string{"TO_URL=https://to.example.net"}})
+	if _, ok := s.Last(); ok {
+		t.Error("expected no result before t3c is run")
+	}
+	result := s.run(context.Background(), t3cutil.ModeBadAss, "start")
+	if result.ExitCode != 0 || result.Error != "" || result.Mode != t3cutil.ModeBadAss || result.Reason != "start" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if last, ok := s.Last(); !ok || last != result {
+		t.Errorf("expected the last result to be %+v, actual: %+v", result, last)
+	}
+	expected := "apply --run-mode=badass --cache-host-name=edge https://to.example.net"
+	if actual := readRuns(t, runs); len(actual) != 1 || actual[0] != expected {
+		t.Errorf("expected t3c to be run as '%s', actual: %v", expected, actual)
+	}
+
+	failing, _ := writeFakeT3C(t, "139")
+	if result := New(Opts{Command: failing}).run(context.Background(), t3cutil.ModeSyncDS, "interval"); result.ExitCode != 139 {
+		t.Errorf("expected exit code 139, actual: %+v", result)
+	}
+	if result := New(Opts{Command: "/nonexistent/t3c"}).run(context.Background(), t3cutil.ModeSyncDS, "interval"); result.ExitCode != -1 || result.Error == "" {
+		t.Errorf("expected an error running a missing t3c, actual: %+v", result)
+	}
+}
+
+func TestSchedulerTrigger(t *testing.T) {
+	t3c, runs := writeFakeT3C(t, "0")
+	s := New(Opts{Command: t3c})
+	if !s.Trigger(t3cutil.ModeSyncDS, "change feed") {
+		t.Error("expected the first trigger to be pending")
+	}
+	if s.Trigger(t3cutil.ModeRevalidate, "control API") {
+		t.Error("expected a trigger made while one is pending to be coalesced")
+	}
+
+	// Holding the lock keeps t3c from running.
+	s.Locker().Lock()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx, t3cutil.ModeBadAss, time.Hour)
+		close(done)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	if _, ok := s.Last(); ok {
+		t.Error("expected t3c not to run while the lock is held")
+	}
+	s.Locker().Unlock()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if last, ok := s.Last(); ok && last.Reason == "change feed" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the triggered run")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	actual := readRuns(t, runs)
+	if len(actual) != 2 || !strings.HasPrefix(actual[0], "apply --run-mode=badass") || !strings.HasPrefix(actual[1], "apply --run-mode=syncds") {
+		t.Errorf("expected a badass run at start then the triggered syncds run, actual: %v", actual)
+	}
+}
@@ -0,0 +1,225 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-agent/checks"
+	"github.com/apache/trafficcontrol/cache-config/t3c-agent/config"
+	"github.com/apache/trafficcontrol/cache-config/t3c-agent/control"
+	"github.com/apache/trafficcontrol/cache-config/t3c-agent/feed"
+	"github.com/apache/trafficcontrol/cache-config/t3c-agent/prefetch"
+	"github.com/apache/trafficcontrol/cache-config/t3c-agent/scheduler"
+	"github.com/apache/trafficcontrol/cache-config/t3c-log-agent/agent"
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	healthconfig "github.com/apache/trafficcontrol/tc-health-client/config"
+	"github.com/apache/trafficcontrol/tc-health-client/tmagent"
+	toclient "github.com/apache/trafficcontrol/traffic_ops/v5-client"
+)
+
+// Version is the application version.
+// This is overwritten by the build with the current project version.
+var Version = "0.4"
+
+// GitRevision is the git revision the application was built from.
+// This is overwritten by the build with the current project version.
+var GitRevision = "nogit"
+
+const ExitCodeSuccess = 0
+const ExitCodeConfigError = 1
+const ExitCodeServeError = 2
+const ExitCodeTrafficOpsError = 3
+const ExitCodeHealthClientError = 4
+
+func main() {
+	cfg, err := config.InitConfig(Version, GitRevision)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err.Error())
+		os.Exit(ExitCodeConfigError)
+	}
+	log.Infoln("configuration initialized")
+	cfg.PrintConfig()
+
+	var to *toclient.Session
+	if cfg.TOToken != "" {
+		to, _, err = toclient.LoginWithToken(cfg.TOURL, cfg.TOToken, cfg.TOInsecure, cfg.UserAgent(), false, cfg.TORequestTimeout)
+	} else {
+		to, _, err = toclient.LoginWithAgent(cfg.TOURL, cfg.TOUser, cfg.TOPass, cfg.TOInsecure, cfg.UserAgent(), false, cfg.TORequestTimeout)
+	}
+	if err != nil {
+		log.Errorf("logging in to Traffic Ops: %s\n", err.Error())
+		os.Exit(ExitCodeTrafficOpsError)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	args := cfg.T3CArgs
+	if cfg.TOInsecure {
+		args = append([]string{"--traffic-ops-insecure=true"}, args...)
+	}
+	sched := scheduler.New(scheduler.Opts{
+		Command: cfg.T3CPath,
+		Args:    args,
+		Env:     []string{"TO_URL=" + cfg.TOURL, "TO_USER=" + cfg.TOUser, "TO_PASS=" + cfg.TOPass, "TO_TOKEN=" + cfg.TOToken},
+		Timeout: cfg.T3CTimeout,
+	})
+	go sched.Run(ctx, cfg.T3CInitialMode, cfg.SyncDSInterval)
+
+	if cfg.HealthClientConfigFile != "" {
+		if err := startHealthClient(cfg.HealthClientConfigFile, sched); err != nil {
+			log.Errorf("starting health client: %s\n", err.Error())
+			os.Exit(ExitCodeHealthClientError)
+		}
+	}
+
+	if cfg.ServercheckT3C != "" || len(cfg.Serverchecks) > 0 {
+		reporter := checks.New(checks.Opts{
+			HostName: cfg.CacheHostName,
+			T3CCheck: cfg.ServercheckT3C,
+			Checks:   cfg.Serverchecks,
+			Timeout:  cfg.ServercheckTimeout,
+		}, to, sched.Last)
+		go reporter.Run(ctx, cfg.ServercheckInterval)
+	}
+
+	serverID := 0
+	if cfg.ChangeFeed || cfg.PrefetchInterval > 0 {
+		serverID, err = getServerID(to, cfg.CacheHostName)
+		if err != nil {
+			log.Errorf("getting the cache server from Traffic Ops: %s\n", err.Error())
+			os.Exit(ExitCodeTrafficOpsError)
+		}
+	}
+
+	if cfg.ChangeFeed {
+		trigger := func(reason string) { sched.Trigger(t3cutil.ModeSyncDS, reason) }
+		go feed.Watch(ctx, to, serverID, time.Second*config.DefaultChangeFeedRetrySeconds, trigger)
+	}
+
+	if cfg.PrefetchInterval > 0 {
+		prefetcher := prefetch.New(prefetch.Opts{
+			ServerID:    serverID,
+			HTTPAddr:    cfg.PrefetchHTTPAddr,
+			HTTPSAddr:   cfg.PrefetchHTTPSAddr,
+			Concurrency: cfg.PrefetchConcurrency,
+			Timeout:     cfg.PrefetchTimeout,
+		}, to)
+		go prefetcher.Run(ctx, cfg.PrefetchInterval)
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+
+	if cfg.ControlPort == 0 {
+		sig := <-sigs
+		log.Infof("received %s, exiting\n", sig)
+		os.Exit(ExitCodeSuccess)
+	}
+
+	go func() {
+		sig := <-sigs
+		log.Infof("received %s, exiting\n", sig)
+		os.Exit(ExitCodeSuccess)
+	}()
+
+	var hits *agent.HitCounter
+	if cfg.CacheHitWindow > 0 {
+		hits = agent.NewHitCounter(cfg.CacheHitWindow)
+		go func() {
+			err := hits.Run(cfg.AccessLog)
+			log.Errorf("counting cache hits: %s\n", err.Error())
+			os.Exit(ExitCodeServeError)
+		}()
+	}
+
+	// Tails are streamed for up to their requested duration, so the server
+	// has no write timeout.
+	srv := &http.Server{
+		Addr: ":" + strconv.Itoa(cfg.ControlPort),
+		Handler: control.New(cfg.ControlSecret, sched, agent.New(agent.Opts{
+			Secret: cfg.ControlSecret,
+			Logs: map[string]string{
+				tc.ServerLogAccess: cfg.AccessLog,
+				tc.ServerLogDiags:  cfg.DiagsLog,
+			},
+			MaxSeconds:        cfg.MaxTailSeconds,
+			TrafficCtl:        cfg.TrafficCtl,
+			TrafficCtlTimeout: cfg.TrafficCtlTimeout,
+			HitCounter:        hits,
+		})),
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       time.Minute,
+	}
+
+	log.Infof("listening on port %d\n", cfg.ControlPort)
+	if cfg.ControlTLSCert != "" {
+		err = srv.ListenAndServeTLS(cfg.ControlTLSCert, cfg.ControlTLSKey)
+	} else {
+		err = srv.ListenAndServe()
+	}
+	log.Errorf("serving: %s\n", err.Error())
+	os.Exit(ExitCodeServeError)
+}
+
+// startHealthClient starts the tc-health-client with the given config file,
+// holding the lock of the scheduler while it reads and marks parents, so
+// t3c doesn't change the parents meanwhile.
+func startHealthClient(configFile string, sched *scheduler.Scheduler) error {
+	hcfg, err := healthconfig.ReadConfig(configFile)
+	if err != nil {
+		return errors.New("reading config: " + err.Error())
+	}
+	if err := healthconfig.GetTrafficMonitors(&hcfg); err != nil {
+		return errors.New("getting Traffic Monitors: " + err.Error())
+	}
+	tmInfo, err := tmagent.NewParentInfo(hcfg)
+	if err != nil {
+		return errors.New("initializing parent info, check that trafficserver is running: " + err.Error())
+	}
+	tmInfo.Lock = sched.Locker()
+	go tmInfo.PollAndUpdateCacheStatus()
+	return nil
+}
+
+// getServerID returns the ID of the cache server with the given host name.
+func getServerID(to *toclient.Session, hostName string) (int, error) {
+	opts := toclient.NewRequestOptions()
+	opts.QueryParameters.Set("hostName", hostName)
+	resp, _, err := to.GetServers(opts)
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.Response) != 1 || resp.Response[0].ID == nil {
+		return 0, fmt.Errorf("expected one server with host name '%s', got %d", hostName, len(resp.Response))
+	}
+	return *resp.Response[0].ID, nil
+}
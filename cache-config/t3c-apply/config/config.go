@@ -23,7 +23,6 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
-	"net/url"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -91,6 +90,33 @@ type Cfg struct {
 	DNSLocalBind        bool
 	WaitForParents      bool
 	YumOptions          string
+	// TOToken is an authentication token used instead of TOUser and TOPass,
+	// if not empty. It's given to the other t3c commands in the TO_TOKEN
+	// environment variable, rather than as an argument, so it isn't exposed
+	// to other users of the cache server.
+	TOToken string
+	// TOClientCertFile and TOClientKeyFile are the paths to a TLS client
+	// certificate and its private key, used instead of TOUser and TOPass if
+	// TOClientCertFile is not empty and TOToken is.
+	TOClientCertFile string
+	TOClientKeyFile  string
+	// CDNSigningKeyFile is the path to the public signing key of the cache's
+	// CDN. If not empty, Traffic Ops responses without a valid signature by
+	// it are rejected.
+	CDNSigningKeyFile string
+	// UseMirror is whether to apply the config data last applied
+	// successfully if none of the Traffic Ops servers can be reached.
+	UseMirror bool
+	// Staged is whether to write the ATS config files into StagingDir, verify
+	// them, and only then move them into place, instead of writing them
+	// directly.
+	Staged bool
+	// StagingDir is the directory staged config is written to. It must be on
+	// the same filesystem as TsConfigDir.
+	StagingDir string
+	// CanaryURLs is a comma-delimited list of URLs requested after staged
+	// config is applied; if any fails, the staged config is rolled back.
+	CanaryURLs string
 	// UseGit is whether to create and maintain a git repo of config changes.
 	// Note this only applies to the ATS config directory inferred or set via the flag.
 	//      It does not do anything for config files generated outside that location.
@@ -116,6 +142,9 @@ type Cfg struct {
 	InstallPackages   bool
 	IgnoreUpdateFlag  bool
 	NoUnsetUpdateFlag bool
+	// NoReportConfigRun is whether to not report the outcome of the run to
+	// Traffic Ops.
+	NoReportConfigRun bool
 	UpdateIPAllow     bool
 	Version           string
 	GitRevision       string
@@ -235,14 +264,22 @@ func GetCfg(appVersion string, gitRevision string) (Cfg, error) {
 	skipOSCheckPtr := getopt.BoolLong("skip-os-check", 'C', "[false | true] skip os check, default is false")
 	toInsecurePtr := getopt.BoolLong("traffic-ops-insecure", 'I', "[true | false] ignore certificate errors from Traffic Ops")
 	toTimeoutMSPtr := getopt.IntLong("traffic-ops-timeout-milliseconds", 't', 30000, "Timeout in milli-seconds for Traffic Ops requests, default is 30000")
-	toURLPtr := getopt.StringLong("traffic-ops-url", 'u', "", "Traffic Ops URL. Must be the full URL, including the scheme. Required. May also be set with the environment variable TO_URL")
-	toUserPtr := getopt.StringLong("traffic-ops-user", 'U', "", "Traffic Ops username. Required. May also be set with the environment variable TO_USER")
-	toPassPtr := getopt.StringLong("traffic-ops-password", 'P', "", "Traffic Ops password. Required. May also be set with the environment variable TO_PASS")
+	toURLPtr := getopt.StringLong("traffic-ops-url", 'u', "", "Traffic Ops URL. Must be the full URL, including the scheme. May be a comma-delimited list of URLs, in order of preference, to fail over between. Required. May also be set with the environment variable TO_URL")
+	toUserPtr := getopt.StringLong("traffic-ops-user", 'U', "", "Traffic Ops username. Required unless a token or client certificate is given. May also be set with the environment variable TO_USER")
+	toPassPtr := getopt.StringLong("traffic-ops-password", 'P', "", "Traffic Ops password. Required unless a token or client certificate is given. May also be set with the environment variable TO_PASS")
+	toTokenPtr := getopt.StringLong("traffic-ops-token", 0, "", "Traffic Ops authentication token, used instead of the username and password. May also be set with the environment variable TO_TOKEN")
+	toClientCertPtr := getopt.StringLong("traffic-ops-client-cert", 0, "", "Path to a TLS client certificate with which to authenticate with Traffic Ops, instead of the username and password. Requires traffic-ops-client-key")
+	toClientKeyPtr := getopt.StringLong("traffic-ops-client-key", 0, "", "Path to the private key of the traffic-ops-client-cert certificate")
 	tsHomePtr := getopt.StringLong("trafficserver-home", 'R', "", "Trafficserver Package directory. May also be set with the environment variable TS_HOME")
 	dnsLocalBindPtr := getopt.BoolLong("dns-local-bind", 'b', "[true | false] whether to use the server's Service Addresses to set the ATS DNS local bind address")
 	help := getopt.BoolLong("help", 'h', "Print usage information and exit")
 	useGitStr := getopt.StringLong("git", 'g', "auto", "Create and use a git repo in the config directory. Options are yes, no, and auto. If yes, create and use. If auto, use if it exist. Default is auto.")
 	noCachePtr := getopt.BoolLong("no-cache", 'n', "Whether to not use a cache and make conditional requests to Traffic Ops")
+	cdnSigningKeyFilePtr := getopt.StringLong("cdn-signing-key-file", 0, "", "Path to the public signing key of the cache's CDN. If set, config data from Traffic Ops without a valid signature by the key is rejected, and no config is applied. Optional.")
+	useMirrorPtr := getopt.BoolLong("traffic-ops-unreachable-use-mirror", 0, "[true | false] If none of the Traffic Ops servers can be reached, apply the config data last applied successfully, without checking update flags or packages. For emergencies only. Default is false.")
+	stagedPtr := getopt.BoolLong("staged", 0, "[true | false] Write ATS config files to a staging directory, verify them with 'traffic_server -C verify_config', and only move them into place if verification succeeds. Default is false.")
+	stagingDirPtr := getopt.StringLong("staging-dir", 0, "", "Directory to stage ATS config in, with --staged. Must be on the same filesystem as the ATS config directory. Default is the ATS config directory with the suffix '.staged'.")
+	canaryURLsPtr := getopt.StringLong("staged-canary-url", 0, "", "Comma-delimited list of URLs to request after staged config is applied, with --staged. If any request fails or returns an error status, the staged config is rolled back. Optional.")
 	syncdsUpdatesIPAllowPtr := getopt.BoolLong("syncds-updates-ipallow", 'S', "Whether syncds mode will update ipallow. This exists because ATS had a bug where reloading after changing ipallow would block everything. Default is false.")
 	omitViaStringReleasePtr := getopt.BoolLong("omit-via-string-release", 'e', "Whether to set the records.config via header to the ATS release from the RPM. Default true.")
 	noOutgoingIP := getopt.BoolLong("no-outgoing-ip", 'i', "Whether to not set the records.config outgoing IP to the server's addresses in Traffic Ops. Default is false.")
@@ -276,6 +313,7 @@ func GetCfg(appVersion string, gitRevision string) (Cfg, error) {
 
 	const ignoreUpdateFlagName = "ignore-update-flag"
 	ignoreUpdateFlagPtr := getopt.BoolLong(ignoreUpdateFlagName, 'F', "Whether to ignore the upd_pending or reval_pending flag in Traffic Ops, and always generate and apply files. If true, the flag is still unset in Traffic Ops after files are applied. Default is false.")
+	noReportConfigRunPtr := getopt.BoolLong("no-report-config-run", 0, "Whether to not report the outcome of the run - whether it succeeded, the config files it changed, and any errors - to Traffic Ops. Default is false.")
 	noUnsetUpdateFlagPtr := getopt.BoolLong("no-unset-update-flag", 'd', "Whether to not unset the update flag in Traffic Ops after applying files. This option makes it possible to generate test or debug configuration from a production Traffic Ops without un-setting queue or reval flags. Default is false.")
 
 	const updateIPAllowFlagName = "update-ipallow"
@@ -456,6 +494,12 @@ If any of the related flags are also set, they override the mode's default behav
 	} else {
 		os.Setenv("TO_PASS", toPass)
 	}
+	toToken := *toTokenPtr
+	if toToken == "" {
+		toToken = os.Getenv("TO_TOKEN")
+	} else {
+		os.Setenv("TO_TOKEN", toToken)
+	}
 
 	// set TSHome
 	var tsHome = ""
@@ -485,6 +529,14 @@ If any of the related flags are also set, they override the mode's default behav
 		toInfoLog = append(toInfoLog, fmt.Sprintf("TSHome: %s, TSConfigDir: %s\n", TSHome, tsConfigDir))
 	}
 
+	stagingDir := *stagingDirPtr
+	if stagingDir == "" {
+		stagingDir = tsConfigDir + ".staged"
+	}
+	if !*stagedPtr && strings.TrimSpace(*canaryURLsPtr) != "" {
+		return Cfg{}, errors.New("--staged-canary-url requires --staged")
+	}
+
 	atsVersionStr := ""
 	if *useLocalATSVersionPtr {
 		atsVersionStr, err = GetATSVersionStr(tsHome)
@@ -498,21 +550,23 @@ If any of the related flags are also set, they override the mode's default behav
 	if strings.TrimSpace(toURL) == "" {
 		return Cfg{}, errors.New("Missing required argument --traffic-ops-url or TO_URL environment variable. " + usageStr)
 	}
-	if strings.TrimSpace(toUser) == "" {
-		return Cfg{}, errors.New("Missing required argument --traffic-ops-user or TO_USER environment variable. " + usageStr)
+	if *toClientCertPtr != "" && *toClientKeyPtr == "" {
+		return Cfg{}, errors.New("Missing required argument --traffic-ops-client-key, which is required by --traffic-ops-client-cert. " + usageStr)
 	}
-	if strings.TrimSpace(toPass) == "" {
-		return Cfg{}, errors.New("Missing required argument --traffic-ops-password or TO_PASS environment variable. " + usageStr)
+	if toToken == "" && *toClientCertPtr == "" {
+		if strings.TrimSpace(toUser) == "" {
+			return Cfg{}, errors.New("Missing required argument --traffic-ops-user or TO_USER environment variable. " + usageStr)
+		}
+		if strings.TrimSpace(toPass) == "" {
+			return Cfg{}, errors.New("Missing required argument --traffic-ops-password or TO_PASS environment variable. " + usageStr)
+		}
 	}
 	if strings.TrimSpace(cacheHostName) == "" {
 		return Cfg{}, errors.New("Missing required argument --cache-host-name. " + usageStr)
 	}
 
-	toURLParsed, err := url.Parse(toURL)
-	if err != nil {
+	if _, err := t3cutil.ParseURLs(toURL); err != nil {
 		return Cfg{}, errors.New("parsing Traffic Ops URL from " + urlSourceStr + " '" + toURL + "': " + err.Error())
-	} else if err = validateURL(toURLParsed); err != nil {
-		return Cfg{}, errors.New("invalid Traffic Ops URL from " + urlSourceStr + " '" + toURL + "': " + err.Error())
 	}
 
 	svcManagement := getOSSvcManagement()
@@ -533,7 +587,15 @@ If any of the related flags are also set, they override the mode's default behav
 		TOTimeoutMS:                 toTimeoutMS,
 		TOUser:                      toUser,
 		TOPass:                      toPass,
+		TOToken:                     toToken,
+		TOClientCertFile:            *toClientCertPtr,
+		TOClientKeyFile:             *toClientKeyPtr,
 		TOURL:                       toURL,
+		CDNSigningKeyFile:           *cdnSigningKeyFilePtr,
+		UseMirror:                   *useMirrorPtr,
+		Staged:                      *stagedPtr,
+		StagingDir:                  stagingDir,
+		CanaryURLs:                  strings.TrimSpace(*canaryURLsPtr),
 		DNSLocalBind:                dnsLocalBind,
 		WaitForParents:              *waitForParentsPtr,
 		YumOptions:                  yumOptions,
@@ -556,6 +618,7 @@ If any of the related flags are also set, they override the mode's default behav
 		InstallPackages:             *installPackagesPtr,
 		IgnoreUpdateFlag:            *ignoreUpdateFlagPtr,
 		NoUnsetUpdateFlag:           *noUnsetUpdateFlagPtr,
+		NoReportConfigRun:           *noReportConfigRunPtr,
 		Version:                     appVersion,
 		GitRevision:                 gitRevision,
 		LocalATSVersion:             atsVersionStr,
@@ -585,19 +648,6 @@ If any of the related flags are also set, they override the mode's default behav
 	return cfg, nil
 }
 
-func validateURL(u *url.URL) error {
-	if u == nil {
-		return errors.New("nil url")
-	}
-	if u.Scheme != "http" && u.Scheme != "https" {
-		return errors.New("scheme expected 'http' or 'https', actual '" + u.Scheme + "'")
-	}
-	if strings.TrimSpace(u.Host) == "" {
-		return errors.New("no host")
-	}
-	return nil
-}
-
 func isCommandAvailable(name string) bool {
 	command := name + " --version"
 	cmd := exec.Command("/bin/sh", "-c", command)
@@ -655,7 +705,16 @@ func printConfig(cfg Cfg) {
 	log.Debugf("TOTimeoutMS: %d\n", cfg.TOTimeoutMS)
 	log.Debugf("TOUser: %s\n", cfg.TOUser)
 	log.Debugf("TOPass: Pass len: '%d'\n", len(cfg.TOPass))
+	log.Debugf("TOToken: Token len: '%d'\n", len(cfg.TOToken))
+	log.Debugf("TOClientCertFile: %s\n", cfg.TOClientCertFile)
+	log.Debugf("TOClientKeyFile: %s\n", cfg.TOClientKeyFile)
 	log.Debugf("TOURL: %s\n", cfg.TOURL)
+	log.Debugf("CDNSigningKeyFile: %s\n", cfg.CDNSigningKeyFile)
+	log.Debugf("UseMirror: %v\n", cfg.UseMirror)
+	log.Debugf("Staged: %v\n", cfg.Staged)
+	log.Debugf("StagingDir: %s\n", cfg.StagingDir)
+	log.Debugf("CanaryURLs: %s\n", cfg.CanaryURLs)
+	log.Debugf("NoReportConfigRun: %v\n", cfg.NoReportConfigRun)
 	log.Debugf("TSHome: %s\n", TSHome)
 	log.Debugf("LocalATSVersion: %s\n", cfg.LocalATSVersion)
 	log.Debugf("WaitForParents: %v\n", cfg.WaitForParents)
@@ -88,6 +88,7 @@ func main() {
 // DO NOT call os.Exit within this function; return the code instead.
 // Returns the application exit code.
 func Main() int {
+	start := time.Now()
 
 	var syncdsUpdate torequest.UpdateStatus
 	var lock util.FileLock
@@ -158,6 +159,20 @@ func Main() int {
 
 	trops := torequest.NewTrafficOpsReq(cfg)
 
+	if !cfg.ReportOnly && !cfg.NoReportConfigRun {
+		// Deferred so every run is reported, however it ends.
+		// Note this runs after the final git commit, so it must not write the metadata.
+		defer func() {
+			if trops.UseMirror {
+				log.Warnln("applied the config data last applied successfully, because Traffic Ops is unreachable; not reporting the run to Traffic Ops")
+				return
+			}
+			if err := trops.ReportConfigRun(start, metaData); err != nil {
+				log.Errorf("failed to report the run to Traffic Ops: %s\n", err.Error())
+			}
+		}()
+	}
+
 	// if doing os checks, insure there is a 'systemctl' or 'service' and 'chkconfig' commands.
 	if !cfg.SkipOSCheck && cfg.SvcManagement == config.Unknown {
 		log.Errorln("OS checks are enabled and unable to find any know service management tools.")
@@ -199,7 +214,11 @@ func Main() int {
 
 	} else {
 		syncdsUpdate, err = trops.CheckSyncDSState(metaData)
-		if err != nil {
+		if err != nil && cfg.UseMirror && errors.Is(err, torequest.ErrTOUnreachable) {
+			log.Errorln("Checking syncds state: Traffic Ops is unreachable, applying the config data last applied successfully: " + err.Error())
+			trops.UseMirror = true
+			syncdsUpdate = torequest.UpdateTropsNeeded
+		} else if err != nil {
 			log.Errorln("Checking syncds state: " + err.Error())
 			return GitCommitAndExit(ExitCodeSyncDSError, FailureExitMsg, cfg, metaData, oldMetaData)
 		}
@@ -237,6 +256,8 @@ func Main() int {
 	if cfg.Files != t3cutil.ApplyFilesFlagAll {
 		// make sure we got the data necessary to check packages
 		log.Infoln("======== Didn't get all files, no package processing needed or possible ========")
+	} else if trops.UseMirror {
+		log.Warnln("======== Traffic Ops is unreachable, no package processing possible ========")
 	} else {
 		log.Infoln("======== Start processing packages  ========")
 		err = trops.ProcessPackages()
@@ -260,6 +281,7 @@ func Main() int {
 		return GitCommitAndExit(ExitCodeConfigFilesError, FailureExitMsg, cfg, metaData, oldMetaData)
 	}
 	syncdsUpdate, err = trops.ProcessConfigFiles(metaData)
+	configFilesProcessed := err == nil
 	if err != nil {
 		log.Errorf("Error while processing config files: %s\n", err.Error())
 		t3cutil.WriteActionLog(t3cutil.ActionLogActionUpdateFilesAll, t3cutil.ActionLogStatusFailure, metaData)
@@ -268,6 +290,22 @@ func Main() int {
 		t3cutil.WriteActionLog(t3cutil.ActionLogActionUpdateFilesAll, t3cutil.ActionLogStatusSuccess, metaData)
 	}
 
+	if cfg.Staged && trops.HasStagedFiles() {
+		if err := trops.VerifyStagedConfig(); err != nil {
+			log.Errorln("Verifying staged config, not applying it: " + err.Error())
+			t3cutil.WriteActionLog(t3cutil.ActionLogActionStagedVerify, t3cutil.ActionLogStatusFailure, metaData)
+			return GitCommitAndExit(ExitCodeConfigFilesError, FailureExitMsg, cfg, metaData, oldMetaData)
+		}
+		t3cutil.WriteActionLog(t3cutil.ActionLogActionStagedVerify, t3cutil.ActionLogStatusSuccess, metaData)
+
+		if err := trops.SwapStagedConfig(); err != nil {
+			log.Errorln("Swapping staged config into place: " + err.Error())
+			t3cutil.WriteActionLog(t3cutil.ActionLogActionStagedSwap, t3cutil.ActionLogStatusFailure, metaData)
+			return GitCommitAndExit(ExitCodeConfigFilesError, FailureExitMsg, cfg, metaData, oldMetaData)
+		}
+		t3cutil.WriteActionLog(t3cutil.ActionLogActionStagedSwap, t3cutil.ActionLogStatusSuccess, metaData)
+	}
+
 	// check for maxmind db updates
 	// If we've updated also reload remap to reload the plugin and pick up the new database
 	if CheckMaxmindUpdate(cfg) {
@@ -290,6 +328,34 @@ func Main() int {
 		return GitCommitAndExit(ExitCodeServicesError, PostConfigFailureExitMsg, cfg, metaData, oldMetaData)
 	}
 
+	if cfg.Staged && trops.HasStagedFiles() && cfg.CanaryURLs != "" {
+		if err := trops.CheckCanaries(); err != nil {
+			log.Errorln("Canary check failed, rolling back staged config: " + err.Error())
+			t3cutil.WriteActionLog(t3cutil.ActionLogActionStagedCanary, t3cutil.ActionLogStatusFailure, metaData)
+			if err := trops.RollbackStagedConfig(); err != nil {
+				log.Errorln("Rolling back staged config: " + err.Error())
+				t3cutil.WriteActionLog(t3cutil.ActionLogActionStagedRollback, t3cutil.ActionLogStatusFailure, metaData)
+			} else {
+				t3cutil.WriteActionLog(t3cutil.ActionLogActionStagedRollback, t3cutil.ActionLogStatusSuccess, metaData)
+				// the same files changed back, so the same reload or restart
+				// puts the previous config back into service.
+				rollbackUpdate := torequest.UpdateTropsNeeded
+				if err := trops.StartServices(&rollbackUpdate, metaData); err != nil {
+					log.Errorln("failed to start services after rolling back staged config: " + err.Error())
+				}
+			}
+			metaData.PartialSuccess = true
+			return GitCommitAndExit(ExitCodeServicesError, PostConfigFailureExitMsg, cfg, metaData, oldMetaData)
+		}
+		t3cutil.WriteActionLog(t3cutil.ActionLogActionStagedCanary, t3cutil.ActionLogStatusSuccess, metaData)
+	}
+
+	if configFilesProcessed {
+		if err := trops.WriteMirror(); err != nil {
+			log.Errorln(err.Error())
+		}
+	}
+
 	// start 'teakd' if installed.
 	if trops.IsPackageInstalled("teakd") {
 		svcStatus, pid, err := util.GetServiceStatus("teakd")
@@ -314,7 +380,9 @@ func Main() int {
 
 	trops.PrintWarnings()
 
-	if err := trops.UpdateTrafficOps(&syncdsUpdate); err != nil {
+	if trops.UseMirror {
+		log.Warnln("applied the config data last applied successfully, because Traffic Ops is unreachable; not updating Traffic Ops")
+	} else if err := trops.UpdateTrafficOps(&syncdsUpdate); err != nil {
 		log.Errorf("failed to update Traffic Ops: %s\n", err.Error())
 	}
 
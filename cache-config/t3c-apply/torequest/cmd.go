@@ -60,15 +60,15 @@ type ServerAndConfigs struct {
 	ConfigFiles json.RawMessage
 }
 
+// ErrTOUnreachable is wrapped by errors from requests to Traffic Ops which
+// failed because none of its servers could be connected to.
+var ErrTOUnreachable = errors.New("Traffic Ops is unreachable")
+
 var stripDate = regexp.MustCompile(`\[\w{3}\s{1,2}\d{1,2}\s\d{2}:\d{2}:\d{2}\.\d{3}\]\s`)
 var t3cpath string = filepath.Join(t3cutil.InstallDir(), `t3c`)
 
-// generate runs t3c-generate and returns the result.
-func generate(cfg config.Cfg) ([]t3cutil.ATSConfigFile, error) {
-	configData, err := requestConfig(cfg)
-	if err != nil {
-		return nil, errors.New("requesting: " + err.Error())
-	}
+// generate runs t3c-generate on the config data from 't3c-request --get-data=config' and returns the result.
+func generate(cfg config.Cfg, configData []byte) ([]t3cutil.ATSConfigFile, error) {
 	args := []string{
 		`generate`,
 		"--dir=" + cfg.TsConfigDir,
@@ -213,7 +213,7 @@ func getChkconfig(cfg config.Cfg) ([]map[string]string, error) {
 func getUpdateStatus(cfg config.Cfg) (*atscfg.ServerUpdateStatus, error) {
 	status := atscfg.ServerUpdateStatus{}
 	if err := requestJSON(cfg, "update-status", &status); err != nil {
-		return nil, errors.New("requesting json: " + err.Error())
+		return nil, fmt.Errorf("requesting json: %w", err)
 	}
 	return &status, nil
 }
@@ -237,15 +237,7 @@ func getPackages(cfg config.Cfg) ([]Package, error) {
 // sendUpdate updates the given cache's queue update and reval status in Traffic Ops.
 // Note the statuses are the value to be set, not whether to set the value.
 func sendUpdate(cfg config.Cfg, configApplyTime, revalApplyTime *time.Time, configApplyBool, revalApplyBool *bool) error {
-	args := []string{
-		`update`,
-		"--traffic-ops-timeout-milliseconds=" + strconv.FormatInt(int64(cfg.TOTimeoutMS), 10),
-		"--traffic-ops-user=" + cfg.TOUser,
-		"--traffic-ops-password=" + cfg.TOPass,
-		"--traffic-ops-url=" + cfg.TOURL,
-		"--traffic-ops-insecure=" + strconv.FormatBool(cfg.TOInsecure),
-		"--cache-host-name=" + cfg.CacheHostName,
-	}
+	args := t3cUpdateArgs(cfg)
 
 	if configApplyTime != nil {
 		args = append(args, "--set-config-apply-time="+(*configApplyTime).Format(time.RFC3339Nano))
@@ -263,6 +255,48 @@ func sendUpdate(cfg config.Cfg, configApplyTime, revalApplyTime *time.Time, conf
 	}
 	// ***
 
+	stdOut, stdErr, code := t3cutil.Do(t3cpath, args...)
+	if code != 0 {
+		logSubAppErr(t3cupd+` stdout`, stdOut)
+		logSubAppErr(t3cupd+` stderr`, stdErr)
+		return fmt.Errorf("%s returned non-zero exit code %v, see log for output", t3cupd, code)
+	}
+	logSubApp(t3cupd, stdErr)
+	log.Infoln(t3cupd + " succeeded")
+	return nil
+}
+
+// sendConfigRun reports the outcome of this run to Traffic Ops.
+func sendConfigRun(cfg config.Cfg, run tc.ServerConfigRunRequest) error {
+	runBts, err := json.Marshal(run)
+	if err != nil {
+		return errors.New("encoding config run: " + err.Error())
+	}
+
+	args := append(t3cUpdateArgs(cfg), "--report-config-run")
+	stdOut, stdErr, code := t3cutil.DoInput(runBts, t3cpath, args...)
+	if code != 0 {
+		logSubAppErr(t3cupd+` stdout`, stdOut)
+		logSubAppErr(t3cupd+` stderr`, stdErr)
+		return fmt.Errorf("%s returned non-zero exit code %v, see log for output", t3cupd, code)
+	}
+	logSubApp(t3cupd, stdErr)
+	log.Infoln(t3cupd + " --report-config-run succeeded")
+	return nil
+}
+
+// t3cUpdateArgs returns the arguments to t3c-update common to all of its uses.
+func t3cUpdateArgs(cfg config.Cfg) []string {
+	args := []string{
+		`update`,
+		"--traffic-ops-timeout-milliseconds=" + strconv.FormatInt(int64(cfg.TOTimeoutMS), 10),
+		"--traffic-ops-user=" + cfg.TOUser,
+		"--traffic-ops-password=" + cfg.TOPass,
+		"--traffic-ops-url=" + cfg.TOURL,
+		"--traffic-ops-insecure=" + strconv.FormatBool(cfg.TOInsecure),
+		"--cache-host-name=" + cfg.CacheHostName,
+	}
+
 	if cfg.LogLocationErr == log.LogLocationNull {
 		args = append(args, "-s")
 	}
@@ -283,15 +317,13 @@ func sendUpdate(cfg config.Cfg, configApplyTime, revalApplyTime *time.Time, conf
 	if _, used := os.LookupEnv("TO_URL"); !used {
 		args = append(args, "--traffic-ops-url="+cfg.TOURL)
 	}
-	stdOut, stdErr, code := t3cutil.Do(t3cpath, args...)
-	if code != 0 {
-		logSubAppErr(t3cupd+` stdout`, stdOut)
-		logSubAppErr(t3cupd+` stderr`, stdErr)
-		return fmt.Errorf("%s returned non-zero exit code %v, see log for output", t3cupd, code)
+	if cfg.CDNSigningKeyFile != "" {
+		args = append(args, "--cdn-signing-key-file="+cfg.CDNSigningKeyFile)
 	}
-	logSubApp(t3cupd, stdErr)
-	log.Infoln(t3cupd + " succeeded")
-	return nil
+	if cfg.TOClientCertFile != "" {
+		args = append(args, "--traffic-ops-client-cert="+cfg.TOClientCertFile, "--traffic-ops-client-key="+cfg.TOClientKeyFile)
+	}
+	return args
 }
 
 // doTail calls t3c-tail, which will read lines from the file at the provided
@@ -497,7 +529,7 @@ func checkReload(changedConfigFiles []string) (t3cutil.ServiceNeeds, error) {
 func requestJSON(cfg config.Cfg, command string, obj interface{}) error {
 	stdOut, err := request(cfg, command)
 	if err != nil {
-		return errors.New("requesting: " + err.Error())
+		return fmt.Errorf("requesting: %w", err)
 	}
 	if err := json.Unmarshal(stdOut, obj); err != nil {
 		return errors.New("unmarshalling '" + string(stdOut) + "': " + err.Error())
@@ -512,6 +544,7 @@ func request(cfg config.Cfg, command string) ([]byte, error) {
 		"--traffic-ops-insecure=" + strconv.FormatBool(cfg.TOInsecure),
 		"--traffic-ops-timeout-milliseconds=" + strconv.FormatInt(int64(cfg.TOTimeoutMS), 10),
 		"--cache-host-name=" + cfg.CacheHostName,
+		"--num-retries=" + strconv.Itoa(cfg.Retries),
 		`--get-data=` + command,
 	}
 
@@ -534,10 +567,19 @@ func request(cfg config.Cfg, command string) ([]byte, error) {
 	if _, used := os.LookupEnv("TO_URL"); !used {
 		args = append(args, "--traffic-ops-url="+cfg.TOURL)
 	}
+	if cfg.CDNSigningKeyFile != "" {
+		args = append(args, "--cdn-signing-key-file="+cfg.CDNSigningKeyFile)
+	}
+	if cfg.TOClientCertFile != "" {
+		args = append(args, "--traffic-ops-client-cert="+cfg.TOClientCertFile, "--traffic-ops-client-key="+cfg.TOClientKeyFile)
+	}
 	stdOut, stdErr, code := t3cutil.Do(t3cpath, args...)
 	if code != 0 {
 		logSubAppErr(t3creq+` stdout`, stdOut)
 		logSubAppErr(t3creq+` stderr`, stdErr)
+		if code == t3cutil.RequestExitCodeUnreachable {
+			return nil, fmt.Errorf("%w: %s returned exit code %v, see log for output", ErrTOUnreachable, t3creq, code)
+		}
 		return nil, fmt.Errorf("%s returned non-zero exit code %v, see log for output", t3creq, code)
 	}
 
@@ -570,6 +612,7 @@ func requestConfig(cfg config.Cfg) ([]byte, error) {
 		"--traffic-ops-insecure=" + strconv.FormatBool(cfg.TOInsecure),
 		"--traffic-ops-timeout-milliseconds=" + strconv.FormatInt(int64(cfg.TOTimeoutMS), 10),
 		"--cache-host-name=" + cfg.CacheHostName,
+		"--num-retries=" + strconv.Itoa(cfg.Retries),
 		`--get-data=config`,
 	}
 	if len(cacheBts) > 0 {
@@ -592,6 +635,12 @@ func requestConfig(cfg config.Cfg) ([]byte, error) {
 	if _, used := os.LookupEnv("TO_URL"); !used {
 		args = append(args, "--traffic-ops-url="+cfg.TOURL)
 	}
+	if cfg.CDNSigningKeyFile != "" {
+		args = append(args, "--cdn-signing-key-file="+cfg.CDNSigningKeyFile)
+	}
+	if cfg.TOClientCertFile != "" {
+		args = append(args, "--traffic-ops-client-cert="+cfg.TOClientCertFile, "--traffic-ops-client-key="+cfg.TOClientKeyFile)
+	}
 
 	stdOut := ([]byte)(nil)
 	stdErr := ([]byte)(nil)
@@ -604,6 +653,9 @@ func requestConfig(cfg config.Cfg) ([]byte, error) {
 	if code != 0 {
 		logSubAppErr(t3creq+` stdout`, stdOut)
 		logSubAppErr(t3creq+` stderr`, stdErr)
+		if code == t3cutil.RequestExitCodeUnreachable {
+			return nil, fmt.Errorf("%w: t3c returned exit code %v, see log for details", ErrTOUnreachable, code)
+		}
 		return nil, fmt.Errorf("t3c returned non-zero exit code %v, see log for details", code)
 	}
 	logSubApp(t3creq, stdErr)
@@ -615,6 +667,17 @@ func requestConfig(cfg config.Cfg) ([]byte, error) {
 	return stdOut, nil
 }
 
+// readMirror returns the config data last applied successfully, as written by
+// (*TrafficOpsReq).WriteMirror.
+func readMirror() ([]byte, error) {
+	configData, err := ioutil.ReadFile(t3cutil.ApplyMirrorPath)
+	if err != nil {
+		return nil, errors.New("reading mirror: " + err.Error())
+	}
+	log.Infof("mirror config bytes: %v\n", len(configData))
+	return configData, nil
+}
+
 func logSubApp(appName string, stdErr []byte)    { logSubAppWarnOrErr(appName, stdErr, false) }
 func logSubAppErr(appName string, stdErr []byte) { logSubAppWarnOrErr(appName, stdErr, true) }
 func logSubAppWarnOrErr(appName string, stdErr []byte, isErr bool) {
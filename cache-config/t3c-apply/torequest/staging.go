@@ -0,0 +1,213 @@
+package torequest
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-log"
+)
+
+const (
+	// stagedConfigSubDir is the directory within the staging directory that
+	// holds the complete staged copy of the ATS config directory.
+	stagedConfigSubDir = "config"
+
+	// stagedPreviousSubDir is the directory within the staging directory that
+	// holds the files the staged files replaced, for rollback.
+	stagedPreviousSubDir = "previous"
+
+	// CanaryTimeout is the timeout of each canary request made after staged
+	// config is swapped into place.
+	CanaryTimeout = 10 * time.Second
+)
+
+// stagedFile is a config file which has been written to the staging
+// directory, and will be swapped into place if the staged config is verified.
+type stagedFile struct {
+	// Path is the real path of the file.
+	Path string
+	// StagedPath is the path of the file in the staged copy of the ATS config
+	// directory.
+	StagedPath string
+	// PreviousPath is the path the file being replaced is saved to when
+	// swapping, so it can be rolled back.
+	PreviousPath string
+	// Existed is whether a file existed at Path before the swap.
+	Existed bool
+}
+
+func (r *TrafficOpsReq) stagedConfigDir() string {
+	return filepath.Join(r.Cfg.StagingDir, stagedConfigSubDir)
+}
+
+func (r *TrafficOpsReq) stagedPreviousDir() string {
+	return filepath.Join(r.Cfg.StagingDir, stagedPreviousSubDir)
+}
+
+// stagedPaths returns the paths the file at path is staged at and saved to
+// when swapped, and whether it is staged at all. Only files in the ATS config
+// directory are staged, because it is the only thing ATS can verify.
+func (r *TrafficOpsReq) stagedPaths(path string) (string, string, bool) {
+	rel, err := filepath.Rel(r.Cfg.TsConfigDir, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || rel == ".." {
+		return "", "", false
+	}
+	return filepath.Join(r.stagedConfigDir(), rel), filepath.Join(r.stagedPreviousDir(), rel), true
+}
+
+// isStaging returns whether config files are being written to the staging
+// directory, rather than directly into place.
+func (r *TrafficOpsReq) isStaging() bool {
+	return r.Cfg.Staged && !r.Cfg.ReportOnly
+}
+
+// HasStagedFiles returns whether any config files were written to the
+// staging directory, which must be verified and swapped into place.
+func (r *TrafficOpsReq) HasStagedFiles() bool {
+	return len(r.stagedFiles) > 0
+}
+
+// PrepareStaging removes anything left in the staging directory by a previous
+// run, and copies the current ATS config directory into it, so the changed
+// config files can be written over the copy and verified as a whole.
+func (r *TrafficOpsReq) PrepareStaging() error {
+	if r.Cfg.StagingDir == "" {
+		return errors.New("no staging directory")
+	}
+	if err := os.RemoveAll(r.Cfg.StagingDir); err != nil {
+		return errors.New("removing old staging directory '" + r.Cfg.StagingDir + "': " + err.Error())
+	}
+	if err := os.MkdirAll(r.stagedPreviousDir(), 0755); err != nil {
+		return errors.New("creating staging directory '" + r.Cfg.StagingDir + "': " + err.Error())
+	}
+	stdOut, stdErr, code := t3cutil.Do("cp", "-a", r.Cfg.TsConfigDir, r.stagedConfigDir())
+	if code != 0 {
+		return fmt.Errorf("copying '%s' to '%s' returned code %d stdout '%s' stderr '%s'", r.Cfg.TsConfigDir, r.stagedConfigDir(), code, stdOut, stdErr)
+	}
+	r.stagedFiles = nil
+	log.Infoln("Staging config files in '" + r.stagedConfigDir() + "'")
+	return nil
+}
+
+// VerifyStagedConfig runs the ATS config verification against the staged copy
+// of the ATS config directory.
+func (r *TrafficOpsReq) VerifyStagedConfig() error {
+	tsPath := filepath.Join(r.Cfg.TsHome, "bin", "traffic_server")
+	stdOut, stdErr, code := t3cutil.Do("env", "PROXY_CONFIG_CONFIG_DIR="+r.stagedConfigDir(), tsPath, "-C", "verify_config")
+	if code != 0 {
+		return fmt.Errorf("traffic_server verify_config returned code %d stdout '%s' stderr '%s'", code, stdOut, stdErr)
+	}
+	log.Infoln("Successfully verified staged config in '" + r.stagedConfigDir() + "'")
+	return nil
+}
+
+// SwapStagedConfig moves each staged config file into place. Each move is an
+// atomic rename; the files being replaced are hard-linked into the staging
+// directory first, so they can be restored with RollbackStagedConfig.
+//
+// If moving any file fails, the files already moved are rolled back before
+// returning the error.
+func (r *TrafficOpsReq) SwapStagedConfig() error {
+	for i, file := range r.stagedFiles {
+		if err := os.MkdirAll(filepath.Dir(file.PreviousPath), 0755); err != nil {
+			return r.abortSwap(i, errors.New("creating directory for previous '"+file.Path+"': "+err.Error()))
+		}
+		if err := os.Link(file.Path, file.PreviousPath); err == nil {
+			r.stagedFiles[i].Existed = true
+		} else if !os.IsNotExist(err) {
+			return r.abortSwap(i, errors.New("saving previous '"+file.Path+"': "+err.Error()))
+		}
+		if err := os.Rename(file.StagedPath, file.Path); err != nil {
+			return r.abortSwap(i+1, errors.New("moving staged '"+file.StagedPath+"' to '"+file.Path+"': "+err.Error()))
+		}
+		log.Infof("Swapped staged '%s' into place\n", file.Path)
+	}
+	return nil
+}
+
+// abortSwap rolls back the first n staged files, and returns err.
+func (r *TrafficOpsReq) abortSwap(n int, err error) error {
+	swapped := r.stagedFiles[:n]
+	if rbErr := rollback(swapped); rbErr != nil {
+		return errors.New(err.Error() + ", and rolling back failed: " + rbErr.Error())
+	}
+	return err
+}
+
+// RollbackStagedConfig restores the config files replaced by
+// SwapStagedConfig, and removes the files it created.
+func (r *TrafficOpsReq) RollbackStagedConfig() error {
+	return rollback(r.stagedFiles)
+}
+
+func rollback(files []stagedFile) error {
+	errs := []error{}
+	for _, file := range files {
+		if !file.Existed {
+			if err := os.Remove(file.Path); err != nil && !os.IsNotExist(err) {
+				errs = append(errs, errors.New("removing '"+file.Path+"': "+err.Error()))
+			}
+			continue
+		}
+		if err := os.Rename(file.PreviousPath, file.Path); err != nil {
+			errs = append(errs, errors.New("restoring '"+file.Path+"': "+err.Error()))
+			continue
+		}
+		log.Infof("Rolled back '%s'\n", file.Path)
+	}
+	if len(errs) > 0 {
+		msgs := []string{}
+		for _, err := range errs {
+			msgs = append(msgs, err.Error())
+		}
+		return errors.New(strings.Join(msgs, ", "))
+	}
+	return nil
+}
+
+// CheckCanaries requests each of the canary URLs, returning an error if any
+// request fails or has an error status.
+func (r *TrafficOpsReq) CheckCanaries() error {
+	client := &http.Client{Timeout: CanaryTimeout}
+	for _, url := range strings.Split(r.Cfg.CanaryURLs, ",") {
+		url = strings.TrimSpace(url)
+		if url == "" {
+			continue
+		}
+		resp, err := client.Get(url)
+		if err != nil {
+			return errors.New("canary request '" + url + "': " + err.Error())
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("canary request '%s' returned status %d", url, resp.StatusCode)
+		}
+		log.Infof("Canary request '%s' returned status %d\n", url, resp.StatusCode)
+	}
+	return nil
+}
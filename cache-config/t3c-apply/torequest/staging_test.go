@@ -0,0 +1,127 @@
+package torequest
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-apply/config"
+)
+
+func TestStagedPaths(t *testing.T) {
+	r := NewTrafficOpsReq(config.Cfg{TsConfigDir: "/opt/trafficserver/etc/trafficserver", StagingDir: "/opt/trafficserver/etc/trafficserver.staged"})
+
+	staged, previous, ok := r.stagedPaths("/opt/trafficserver/etc/trafficserver/ssl/foo.cer")
+	if !ok {
+		t.Fatal("expected file in the ATS config directory to be staged")
+	}
+	if expected := "/opt/trafficserver/etc/trafficserver.staged/config/ssl/foo.cer"; staged != expected {
+		t.Errorf("expected staged path '%s', actual '%s'", expected, staged)
+	}
+	if expected := "/opt/trafficserver/etc/trafficserver.staged/previous/ssl/foo.cer"; previous != expected {
+		t.Errorf("expected previous path '%s', actual '%s'", expected, previous)
+	}
+
+	for _, path := range []string{"/etc/sysctl.conf", "/opt/trafficserver/etc/trafficserver", "/opt/trafficserver/etc/trafficserver.staged/config/remap.config"} {
+		if _, _, ok := r.stagedPaths(path); ok {
+			t.Errorf("expected '%s' to not be staged", path)
+		}
+	}
+}
+
+func TestSwapAndRollbackStagedConfig(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.Cfg{
+		Staged:      true,
+		TsConfigDir: filepath.Join(dir, "trafficserver"),
+		StagingDir:  filepath.Join(dir, "trafficserver.staged"),
+	}
+	if err := os.MkdirAll(cfg.TsConfigDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	remapPath := filepath.Join(cfg.TsConfigDir, "remap.config")
+	if err := os.WriteFile(remapPath, []byte("old remap"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewTrafficOpsReq(cfg)
+	if err := r.PrepareStaging(); err != nil {
+		t.Fatalf("preparing staging: %v", err)
+	}
+
+	newPath := filepath.Join(cfg.TsConfigDir, "new.config")
+	for path, body := range map[string]string{remapPath: "new remap", newPath: "new file"} {
+		staged, previous, ok := r.stagedPaths(path)
+		if !ok {
+			t.Fatalf("expected '%s' to be staged", path)
+		}
+		if err := os.WriteFile(staged, []byte(body), 0644); err != nil {
+			t.Fatal(err)
+		}
+		r.stagedFiles = append(r.stagedFiles, stagedFile{Path: path, StagedPath: staged, PreviousPath: previous})
+	}
+
+	if body, err := os.ReadFile(remapPath); err != nil || string(body) != "old remap" {
+		t.Fatalf("expected staging to not change the live file, actual '%s' error %v", body, err)
+	}
+
+	if err := r.SwapStagedConfig(); err != nil {
+		t.Fatalf("swapping staged config: %v", err)
+	}
+	if body, err := os.ReadFile(remapPath); err != nil || string(body) != "new remap" {
+		t.Errorf("expected swapped file 'new remap', actual '%s' error %v", body, err)
+	}
+	if body, err := os.ReadFile(newPath); err != nil || string(body) != "new file" {
+		t.Errorf("expected swapped file 'new file', actual '%s' error %v", body, err)
+	}
+
+	if err := r.RollbackStagedConfig(); err != nil {
+		t.Fatalf("rolling back staged config: %v", err)
+	}
+	if body, err := os.ReadFile(remapPath); err != nil || string(body) != "old remap" {
+		t.Errorf("expected rolled back file 'old remap', actual '%s' error %v", body, err)
+	}
+	if _, err := os.Stat(newPath); !os.IsNotExist(err) {
+		t.Errorf("expected rollback to remove the file the staged config created, stat error %v", err)
+	}
+}
+
+func TestCheckCanaries(t *testing.T) {
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ok.Close()
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer bad.Close()
+
+	r := NewTrafficOpsReq(config.Cfg{CanaryURLs: ok.URL + "/a, " + ok.URL + "/b"})
+	if err := r.CheckCanaries(); err != nil {
+		t.Errorf("expected canaries returning 200 to pass, actual error: %v", err)
+	}
+
+	r = NewTrafficOpsReq(config.Cfg{CanaryURLs: ok.URL + "," + bad.URL})
+	if err := r.CheckCanaries(); err == nil {
+		t.Error("expected a canary returning 502 to fail")
+	}
+}
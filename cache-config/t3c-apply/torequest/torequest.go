@@ -36,6 +36,7 @@ import (
 	"github.com/apache/trafficcontrol/cache-config/t3c-apply/util"
 	"github.com/apache/trafficcontrol/cache-config/t3cutil"
 	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
 )
 
 type UpdateStatus int
@@ -72,6 +73,21 @@ type TrafficOpsReq struct {
 	configFiles        map[string]*ConfigFile
 	configFileWarnings map[string][]string
 
+	// configData is the config data the config files were generated from.
+	configData []byte
+
+	// UseMirror is whether Traffic Ops is unreachable, and the config data
+	// last applied successfully is applied instead of requesting it.
+	UseMirror bool
+
+	// stagedFiles are the config files written to the staging directory,
+	// when running with --staged.
+	stagedFiles []stagedFile
+
+	// configUpdateTime is the time updates were last queued on the server,
+	// if this run is applying queued updates.
+	configUpdateTime *time.Time
+
 	RestartData
 }
 
@@ -473,21 +489,21 @@ func (r *TrafficOpsReq) replaceCfgFile(cfg *ConfigFile) (*FileRestartData, error
 		return &FileRestartData{Name: cfg.Name}, nil
 	}
 
-	tmpFileName := cfg.Path + configFileTempSuffix
-	log.Infof("Writing temp file '%s' with file mode: '%#o' \n", tmpFileName, cfg.Perm)
-
-	// write a new file, then move to the real location
-	// because moving is atomic but writing is not.
-	// If we just wrote to the real location and the app or OS or anything crashed,
-	// we'd end up with malformed files.
-
-	if _, err := util.WriteFileWithOwner(tmpFileName, cfg.Body, &cfg.Uid, &cfg.Gid, cfg.Perm); err != nil {
-		return &FileRestartData{Name: cfg.Name}, errors.New("Failed to write temp config file '" + tmpFileName + "': " + err.Error())
-	}
-
-	log.Infof("Copying temp file '%s' to real '%s'\n", tmpFileName, cfg.Path)
-	if err := os.Rename(tmpFileName, cfg.Path); err != nil {
-		return &FileRestartData{Name: cfg.Name}, errors.New("Failed to move temp '" + tmpFileName + "' to real '" + cfg.Path + "': " + err.Error())
+	if stagedPath, previousPath, ok := r.stagedPaths(cfg.Path); ok && r.isStaging() {
+		// staged files aren't live, so they're written directly; they're
+		// moved into place after the whole staged config is verified.
+		log.Infof("Writing staged file '%s' with file mode: '%#o' \n", stagedPath, cfg.Perm)
+		if err := os.MkdirAll(filepath.Dir(stagedPath), 0755); err != nil {
+			return &FileRestartData{Name: cfg.Name}, errors.New("Failed to create staging directory for '" + stagedPath + "': " + err.Error())
+		}
+		if _, err := util.WriteFileWithOwner(stagedPath, cfg.Body, &cfg.Uid, &cfg.Gid, cfg.Perm); err != nil {
+			return &FileRestartData{Name: cfg.Name}, errors.New("Failed to write staged config file '" + stagedPath + "': " + err.Error())
+		}
+		r.stagedFiles = append(r.stagedFiles, stagedFile{Path: cfg.Path, StagedPath: stagedPath, PreviousPath: previousPath})
+	} else {
+		if err := r.writeCfgFile(cfg); err != nil {
+			return &FileRestartData{Name: cfg.Name}, err
+		}
 	}
 	cfg.ChangeApplied = true
 	r.changedFiles = append(r.changedFiles, cfg.Path)
@@ -495,6 +511,7 @@ func (r *TrafficOpsReq) replaceCfgFile(cfg *ConfigFile) (*FileRestartData, error
 	remapConfigReload := cfg.RemapPluginConfig ||
 		cfg.Name == "remap.config" ||
 		strings.HasPrefix(cfg.Name, "bg_fetch") ||
+		strings.HasPrefix(cfg.Name, "compress_") ||
 		strings.HasPrefix(cfg.Name, "hdr_rw_") ||
 		strings.HasPrefix(cfg.Name, "regex_remap_") ||
 		strings.HasPrefix(cfg.Name, "set_dscp_") ||
@@ -507,7 +524,8 @@ func (r *TrafficOpsReq) replaceCfgFile(cfg *ConfigFile) (*FileRestartData, error
 		cfg.Name == "ssl_multicert.config" ||
 		cfg.Name == "records.config" ||
 		(strings.HasSuffix(cfg.Dir, "ssl") && strings.HasSuffix(cfg.Name, ".cer")) ||
-		(strings.HasSuffix(cfg.Dir, "ssl") && strings.HasSuffix(cfg.Name, ".key"))
+		(strings.HasSuffix(cfg.Dir, "ssl") && strings.HasSuffix(cfg.Name, ".key")) ||
+		(strings.HasSuffix(cfg.Dir, "ssl") && strings.HasPrefix(cfg.Name, "origin_ca_"))
 
 	trafficServerRestart := cfg.Name == "plugin.config"
 	ntpdRestart := cfg.Name == "ntpd.conf"
@@ -528,6 +546,27 @@ func (r *TrafficOpsReq) replaceCfgFile(cfg *ConfigFile) (*FileRestartData, error
 	}, nil
 }
 
+// writeCfgFile writes a config file to its real location.
+func (r *TrafficOpsReq) writeCfgFile(cfg *ConfigFile) error {
+	tmpFileName := cfg.Path + configFileTempSuffix
+	log.Infof("Writing temp file '%s' with file mode: '%#o' \n", tmpFileName, cfg.Perm)
+
+	// write a new file, then move to the real location
+	// because moving is atomic but writing is not.
+	// If we just wrote to the real location and the app or OS or anything crashed,
+	// we'd end up with malformed files.
+
+	if _, err := util.WriteFileWithOwner(tmpFileName, cfg.Body, &cfg.Uid, &cfg.Gid, cfg.Perm); err != nil {
+		return errors.New("Failed to write temp config file '" + tmpFileName + "': " + err.Error())
+	}
+
+	log.Infof("Copying temp file '%s' to real '%s'\n", tmpFileName, cfg.Path)
+	if err := os.Rename(tmpFileName, cfg.Path); err != nil {
+		return errors.New("Failed to move temp '" + tmpFileName + "' to real '" + cfg.Path + "': " + err.Error())
+	}
+	return nil
+}
+
 // CheckSystemServices is used to verify that packages installed
 // are enabled for startup.
 func (r *TrafficOpsReq) CheckSystemServices() error {
@@ -636,10 +675,16 @@ func (r *TrafficOpsReq) GetConfigFileList() error {
 		}
 	}
 
-	allFiles, err := generate(r.Cfg)
+	configData, err := r.getConfigData()
 	if err != nil {
 		return errors.New("requesting data generating config files: " + err.Error())
 	}
+	r.configData = configData
+
+	allFiles, err := generate(r.Cfg, configData)
+	if err != nil {
+		return errors.New("generating config files: " + err.Error())
+	}
 
 	r.configFiles = map[string]*ConfigFile{}
 	r.configFileWarnings = map[string][]string{}
@@ -682,6 +727,34 @@ func (r *TrafficOpsReq) PrintWarnings() {
 	log.Infoln("======== End warning summary ========")
 }
 
+// getConfigData returns the config data to generate config files from. This is
+// requested from Traffic Ops, unless it's unreachable and UseMirror is set in
+// the config, in which case the config data last applied successfully is used.
+func (r *TrafficOpsReq) getConfigData() ([]byte, error) {
+	if !r.UseMirror {
+		configData, err := requestConfig(r.Cfg)
+		if err == nil || !r.Cfg.UseMirror || !errors.Is(err, ErrTOUnreachable) {
+			return configData, err
+		}
+		log.Errorln("Traffic Ops is unreachable, applying the config data last applied successfully: " + err.Error())
+		r.UseMirror = true
+	}
+	return readMirror()
+}
+
+// WriteMirror saves the config data the config files were generated from, as
+// the config data last applied successfully. It does nothing if the config data
+// came from the mirror.
+func (r *TrafficOpsReq) WriteMirror() error {
+	if r.UseMirror || len(r.configData) == 0 {
+		return nil
+	}
+	if err := ioutil.WriteFile(t3cutil.ApplyMirrorPath, r.configData, 0600); err != nil {
+		return errors.New("writing mirror: " + err.Error())
+	}
+	return nil
+}
+
 // CheckRevalidateState retrieves and returns the revalidate status from Traffic Ops.
 func (r *TrafficOpsReq) CheckRevalidateState(sleepOverride bool) (UpdateStatus, error) {
 	log.Infoln("Checking revalidate state.")
@@ -750,6 +823,7 @@ func (r *TrafficOpsReq) CheckSyncDSState(metaData *t3cutil.ApplyMetaData) (Updat
 
 		if serverStatus.UpdatePending {
 			updateStatus = UpdateTropsNeeded
+			r.configUpdateTime = serverStatus.ConfigUpdateTime
 			log.Errorln("Traffic Ops is signaling that an update is waiting to be applied")
 
 			if serverStatus.ParentPending && r.Cfg.WaitForParents {
@@ -788,6 +862,31 @@ func (r *TrafficOpsReq) CheckSyncDSState(metaData *t3cutil.ApplyMetaData) (Updat
 	return updateStatus, nil
 }
 
+// ReportConfigRun reports the outcome of this run to Traffic Ops: whether it
+// succeeded, the queued update it applied, if any, how long it took since
+// start, the config files it changed, and the actions which failed.
+// The metaData is this run's metadata. It must not be nil.
+func (r *TrafficOpsReq) ReportConfigRun(start time.Time, metaData *t3cutil.ApplyMetaData) error {
+	run := tc.ServerConfigRunRequest{
+		Succeeded:        metaData.Succeeded,
+		ConfigUpdateTime: r.configUpdateTime,
+		StartTime:        start,
+		DurationMS:       time.Since(start).Milliseconds(),
+		FilesChanged:     append([]string{}, r.changedFiles...),
+		Errors:           []string{},
+		T3CVersion:       r.Cfg.Version,
+	}
+	for _, action := range metaData.Actions {
+		if action.Status == string(t3cutil.ActionLogStatusFailure) {
+			run.Errors = append(run.Errors, "action '"+action.Action+"' failed")
+		}
+	}
+	if !run.Succeeded && len(run.Errors) == 0 {
+		run.Errors = append(run.Errors, "run failed, see the t3c-apply log")
+	}
+	return sendConfigRun(r.Cfg, run)
+}
+
 // CheckReloadRestart determines the final reload/restart state after all config files are processed.
 func (r *TrafficOpsReq) CheckReloadRestart(data []FileRestartData) RestartData {
 	rd := RestartData{}
@@ -808,6 +907,12 @@ func (r *TrafficOpsReq) ProcessConfigFiles(metaData *t3cutil.ApplyMetaData) (Upd
 
 	log.Infoln(" ======== Start processing config files ========")
 
+	if r.isStaging() {
+		if err := r.PrepareStaging(); err != nil {
+			return UpdateTropsFailed, errors.New("preparing staging directory: " + err.Error())
+		}
+	}
+
 	filesAdding := []string{} // list of file names being added, needed for verification.
 	for fileName, _ := range r.configFiles {
 		filesAdding = append(filesAdding, fileName)
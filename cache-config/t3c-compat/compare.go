@@ -0,0 +1,264 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Category is how much a difference between the configs generated by two
+// versions of t3c-generate matters.
+type Category string
+
+const (
+	// CategoryUnchanged is a file both versions generate identically.
+	CategoryUnchanged = Category("unchanged")
+	// CategoryCosmetic is a file whose text differs, but in ways ATS doesn't
+	// care about, e.g. comments, whitespace, or the order of lines in a file
+	// whose lines aren't ordered.
+	CategoryCosmetic = Category("cosmetic")
+	// CategorySemantic is a file which will change the behavior of ATS, or a
+	// file which only one of the versions generates.
+	CategorySemantic = Category("semantic")
+)
+
+// severity orders Categories from least to most important.
+func (c Category) severity() int {
+	switch c {
+	case CategoryUnchanged:
+		return 0
+	case CategoryCosmetic:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// unorderedFiles are the config files whose lines may be in any order
+// without changing their meaning.
+var unorderedFiles = map[string]struct{}{
+	"12M_facts":      {},
+	"astats.config":  {},
+	"records.config": {},
+	"sysctl.conf":    {},
+}
+
+// generatedHeaderRegex matches the header comment t3c-generate puts on
+// config files, which names the version that generated the file and when, and
+// so always differs between versions.
+var generatedHeaderRegex = regexp.MustCompile(`(?m)^.*DO NOT EDIT - Generated for .*$`)
+
+// FileDiff is the difference between the old and new versions of one config
+// file.
+type FileDiff struct {
+	// Name is the full path of the file.
+	Name     string   `json:"name"`
+	Category Category `json:"category"`
+	// Reason is a human-readable explanation of the Category.
+	Reason string `json:"reason"`
+	// Removed and Added are the meaningful lines only in the old and new
+	// versions of the file, respectively. They're never populated for
+	// secure files, so that keys don't end up in reports.
+	Removed []string `json:"removed,omitempty"`
+	Added   []string `json:"added,omitempty"`
+}
+
+// CompareConfigs compares the config files generated by the old and new
+// versions of t3c-generate for the same server, and returns the differences
+// sorted by file name. Unchanged files are included.
+func CompareConfigs(oldFiles, newFiles []t3cutil.ATSConfigFile) []FileDiff {
+	oldByName := configsByName(oldFiles)
+	newByName := configsByName(newFiles)
+
+	diffs := []FileDiff{}
+	for name, oldFile := range oldByName {
+		newFile, ok := newByName[name]
+		if !ok {
+			diffs = append(diffs, FileDiff{Name: name, Category: CategorySemantic, Reason: "no longer generated"})
+			continue
+		}
+		diffs = append(diffs, compareConfig(name, oldFile, newFile))
+	}
+	for name := range newByName {
+		if _, ok := oldByName[name]; !ok {
+			diffs = append(diffs, FileDiff{Name: name, Category: CategorySemantic, Reason: "newly generated"})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Name < diffs[j].Name })
+	return diffs
+}
+
+func configsByName(files []t3cutil.ATSConfigFile) map[string]t3cutil.ATSConfigFile {
+	byName := make(map[string]t3cutil.ATSConfigFile, len(files))
+	for _, file := range files {
+		byName[filepath.Join(file.Path, file.Name)] = file
+	}
+	return byName
+}
+
+func compareConfig(name string, oldFile, newFile t3cutil.ATSConfigFile) FileDiff {
+	fd := FileDiff{Name: name, Category: CategorySemantic}
+	if oldFile.Secure != newFile.Secure {
+		fd.Reason = "secure flag changed, which changes the file's permissions"
+		return fd
+	}
+	if generatedHeaderRegex.ReplaceAllString(oldFile.Text, "") == generatedHeaderRegex.ReplaceAllString(newFile.Text, "") {
+		fd.Category = CategoryUnchanged
+		return fd
+	}
+
+	lineComment := newFile.LineComment
+	if lineComment == "" {
+		lineComment = oldFile.LineComment
+	}
+	oldLines := meaningfulLines(oldFile.Text, lineComment)
+	newLines := meaningfulLines(newFile.Text, lineComment)
+
+	if reflect.DeepEqual(oldLines, newLines) {
+		fd.Category = CategoryCosmetic
+		fd.Reason = "only comments or whitespace changed"
+		return fd
+	}
+	if sameStructure(newFile.Name, oldFile.Text, newFile.Text) {
+		fd.Category = CategoryCosmetic
+		fd.Reason = "only formatting changed; the data is the same"
+		return fd
+	}
+
+	removed, added := lineDifference(oldLines, newLines)
+	if len(removed) == 0 && len(added) == 0 {
+		if _, ok := unorderedFiles[newFile.Name]; ok {
+			fd.Category = CategoryCosmetic
+			fd.Reason = "only the order of lines changed, which doesn't matter in this file"
+			return fd
+		}
+		fd.Reason = "the order of lines changed"
+		return fd
+	}
+
+	fd.Reason = "content changed"
+	if !newFile.Secure {
+		fd.Removed = removed
+		fd.Added = added
+	}
+	return fd
+}
+
+// meaningfulLines returns the lines of text which aren't blank or comments,
+// with runs of whitespace outside of double quotes collapsed to single
+// spaces.
+func meaningfulLines(text string, lineComment string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = collapseWhitespace(line)
+		if line == "" || (lineComment != "" && strings.HasPrefix(line, lineComment)) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// collapseWhitespace trims line and replaces each run of whitespace in it
+// with a single space, except within double quotes, where whitespace is
+// part of a value.
+func collapseWhitespace(line string) string {
+	sb := strings.Builder{}
+	quoted := false
+	space := false
+	for _, r := range strings.TrimSpace(line) {
+		if r == '"' {
+			quoted = !quoted
+		}
+		if !quoted && (r == ' ' || r == '\t') {
+			space = true
+			continue
+		}
+		if space {
+			sb.WriteRune(' ')
+			space = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// sameStructure returns whether the old and new text of a JSON or YAML file
+// decode to the same data. It's always false for other kinds of files, and
+// for files either version of which can't be decoded.
+func sameStructure(name string, oldText, newText string) bool {
+	var unmarshal func([]byte, interface{}) error
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		unmarshal = json.Unmarshal
+	case ".yaml", ".yml":
+		unmarshal = yaml.Unmarshal
+	default:
+		return false
+	}
+	var oldData, newData interface{}
+	if err := unmarshal([]byte(oldText), &oldData); err != nil {
+		return false
+	}
+	if err := unmarshal([]byte(newText), &newData); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldData, newData)
+}
+
+// lineDifference returns the lines only in oldLines and only in newLines,
+// counting duplicates, in the order they appear.
+func lineDifference(oldLines, newLines []string) ([]string, []string) {
+	counts := map[string]int{}
+	for _, line := range newLines {
+		counts[line]++
+	}
+	removed := []string{}
+	for _, line := range oldLines {
+		if counts[line] > 0 {
+			counts[line]--
+			continue
+		}
+		removed = append(removed, line)
+	}
+
+	counts = map[string]int{}
+	for _, line := range oldLines {
+		counts[line]++
+	}
+	added := []string{}
+	for _, line := range newLines {
+		if counts[line] > 0 {
+			counts[line]--
+			continue
+		}
+		added = append(added, line)
+	}
+	return removed, added
+}
@@ -0,0 +1,189 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+)
+
+const testDir = "/opt/trafficserver/etc/trafficserver"
+
+func makeFile(name string, text string) t3cutil.ATSConfigFile {
+	return t3cutil.ATSConfigFile{Name: name, Path: testDir, LineComment: "#", Text: text}
+}
+
+func TestCompareConfigs(t *testing.T) {
+	type expected struct {
+		category Category
+		removed  []string
+		added    []string
+	}
+	tests := map[string]struct {
+		old, new string
+		expected expected
+	}{
+		"remap.config": {
+			"# DO NOT EDIT - Generated for x by t3c-generate 6.1 on 1\nmap http://a/ http://b/\n",
+			"# DO NOT EDIT - Generated for x by t3c-generate 7.0 on 2\nmap http://a/ http://b/\n",
+			expected{category: CategoryUnchanged},
+		},
+		"regex_revalidate.config": {
+			"# generated\nhttp://a/.* 1\n",
+			"# generated for x\nhttp://a/.* 1\n",
+			expected{category: CategoryCosmetic},
+		},
+		"hosting.config": {
+			"hostname=*   volume=1\n",
+			"\nhostname=*\tvolume=1\n\n",
+			expected{category: CategoryCosmetic},
+		},
+		"header_rewrite.config": {
+			`set-header X-A "a  b"` + "\n",
+			`set-header X-A "a b"` + "\n",
+			expected{category: CategorySemantic, removed: []string{`set-header X-A "a  b"`}, added: []string{`set-header X-A "a b"`}},
+		},
+		"records.config": {
+			"CONFIG a INT 1\nCONFIG b INT 2\n",
+			"CONFIG b INT 2\nCONFIG a INT 1\n",
+			expected{category: CategoryCosmetic},
+		},
+		"parent.config": {
+			"dest_domain=a parent=x\ndest_domain=b parent=y\n",
+			"dest_domain=b parent=y\ndest_domain=a parent=x\n",
+			expected{category: CategorySemantic, removed: []string{}, added: []string{}},
+		},
+		"strategies.yaml": {
+			"a:\n  - 1\n  - 2\nb: x\n",
+			"b: x\na: [1, 2]\n",
+			expected{category: CategoryCosmetic},
+		},
+		"ip_allow.yaml": {
+			"a: 1\n",
+			"a: 2\n",
+			expected{category: CategorySemantic, removed: []string{"a: 1"}, added: []string{"a: 2"}},
+		},
+		"cache.config": {
+			"x\n",
+			"x\n",
+			expected{category: CategoryUnchanged},
+		},
+	}
+
+	oldFiles := []t3cutil.ATSConfigFile{makeFile("logging.yaml", "")}
+	newFiles := []t3cutil.ATSConfigFile{makeFile("sni.yaml", "")}
+	for name, test := range tests {
+		oldFiles = append(oldFiles, makeFile(name, test.old))
+		newFiles = append(newFiles, makeFile(name, test.new))
+	}
+
+	diffs := CompareConfigs(oldFiles, newFiles)
+	if len(diffs) != len(tests)+2 {
+		t.Fatalf("expected %d file differences, actual: %d", len(tests)+2, len(diffs))
+	}
+	for i := 1; i < len(diffs); i++ {
+		if diffs[i-1].Name >= diffs[i].Name {
+			t.Errorf("expected differences sorted by name, actual: '%s' before '%s'", diffs[i-1].Name, diffs[i].Name)
+		}
+	}
+
+	for _, fd := range diffs {
+		switch fd.Name {
+		case testDir + "/logging.yaml", testDir + "/sni.yaml":
+			if fd.Category != CategorySemantic {
+				t.Errorf("expected file generated by only one version to be semantic, actual: %s", fd.Category)
+			}
+			continue
+		}
+		name := fd.Name[len(testDir)+1:]
+		exp := tests[name].expected
+		if fd.Category != exp.category {
+			t.Errorf("%s: expected category %s, actual: %s (%s)", name, exp.category, fd.Category, fd.Reason)
+		}
+		if exp.category != CategorySemantic {
+			continue
+		}
+		if !reflect.DeepEqual(fd.Removed, exp.removed) && (len(fd.Removed) != 0 || len(exp.removed) != 0) {
+			t.Errorf("%s: expected removed lines %v, actual: %v", name, exp.removed, fd.Removed)
+		}
+		if !reflect.DeepEqual(fd.Added, exp.added) && (len(fd.Added) != 0 || len(exp.added) != 0) {
+			t.Errorf("%s: expected added lines %v, actual: %v", name, exp.added, fd.Added)
+		}
+	}
+}
+
+func TestCompareConfigsSecure(t *testing.T) {
+	oldFile := makeFile("a.key", "old key")
+	oldFile.Secure = true
+	newFile := makeFile("a.key", "new key")
+	newFile.Secure = true
+
+	diffs := CompareConfigs([]t3cutil.ATSConfigFile{oldFile}, []t3cutil.ATSConfigFile{newFile})
+	if len(diffs) != 1 {
+		t.Fatalf("expected 1 file difference, actual: %d", len(diffs))
+	}
+	if diffs[0].Category != CategorySemantic {
+		t.Errorf("expected changed secure file to be semantic, actual: %s", diffs[0].Category)
+	}
+	if len(diffs[0].Removed) != 0 || len(diffs[0].Added) != 0 {
+		t.Errorf("expected no lines reported for secure file, actual: -%v +%v", diffs[0].Removed, diffs[0].Added)
+	}
+
+	newFile.Text = oldFile.Text
+	newFile.Secure = false
+	diffs = CompareConfigs([]t3cutil.ATSConfigFile{oldFile}, []t3cutil.ATSConfigFile{newFile})
+	if diffs[0].Category != CategorySemantic {
+		t.Errorf("expected file no longer secure to be semantic, actual: %s", diffs[0].Category)
+	}
+}
+
+func TestReportWorst(t *testing.T) {
+	report := Report{Servers: []ServerReport{
+		NewServerReport("a.json", "a", []FileDiff{{Category: CategoryUnchanged}, {Category: CategoryCosmetic}}),
+	}}
+	if worst := report.Worst(); worst != CategoryCosmetic {
+		t.Errorf("expected worst category cosmetic, actual: %s", worst)
+	}
+	if report.Servers[0].Unchanged != 1 || len(report.Servers[0].Files) != 1 {
+		t.Errorf("expected 1 unchanged and 1 differing file, actual: %d unchanged, %d differing", report.Servers[0].Unchanged, len(report.Servers[0].Files))
+	}
+
+	report.Servers = append(report.Servers, NewServerReport("b.json", "b", []FileDiff{{Category: CategorySemantic}}))
+	if worst := report.Worst(); worst != CategorySemantic {
+		t.Errorf("expected worst category semantic, actual: %s", worst)
+	}
+}
+
+func TestLoadServerDataExpandsFixture(t *testing.T) {
+	servers, err := LoadServerData("../t3c-generate/cfgfile/testdata/fixtures/tiered.json")
+	if err != nil {
+		t.Fatalf("loading fixture CDN: %v", err)
+	}
+	if len(servers) < 2 {
+		t.Fatalf("expected fixture CDN to expand to its cache servers, actual: %d servers", len(servers))
+	}
+	for i := 1; i < len(servers); i++ {
+		if servers[i-1].HostName >= servers[i].HostName {
+			t.Errorf("expected servers sorted by host name, actual: '%s' before '%s'", servers[i-1].HostName, servers[i].HostName)
+		}
+	}
+}
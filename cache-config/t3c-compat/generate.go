@@ -0,0 +1,124 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// ServerData is the Traffic Ops data t3c-generate is given for one server.
+type ServerData struct {
+	HostName string
+	// Data is the t3cutil.ConfigData, as JSON.
+	Data []byte
+}
+
+// LoadServerData reads a Traffic Ops data dump, as written by
+// t3c-request --get-data=config, and returns the data to generate configs
+// from.
+//
+// If the dump is for a server, it's returned unchanged, so that it's given to
+// both versions of t3c-generate exactly as t3c-request wrote it. If it
+// doesn't have a server - like the fixture CDNs used by the t3c-generate
+// tests - it's expanded into one for each of the CDN's cache servers.
+func LoadServerData(path string) ([]ServerData, error) {
+	bts, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.New("reading data file: " + err.Error())
+	}
+	toData := t3cutil.ConfigData{}
+	if err := json.Unmarshal(bts, &toData); err != nil {
+		return nil, errors.New("decoding data file '" + path + "': " + err.Error())
+	}
+
+	if toData.Server != nil {
+		hostName := ""
+		if toData.Server.HostName != nil {
+			hostName = *toData.Server.HostName
+		}
+		return []ServerData{{HostName: hostName, Data: bts}}, nil
+	}
+
+	params := t3cutil.CombineParams(toData.ServerProfilesParams)
+	servers := []ServerData{}
+	for _, sv := range toData.Servers {
+		if sv.HostName == nil || !(strings.HasPrefix(sv.Type, tc.EdgeTypePrefix) || strings.HasPrefix(sv.Type, tc.MidTypePrefix)) {
+			continue
+		}
+		// The server must not point into Servers, which is sorted in place
+		// by some generators.
+		server := sv
+		serverData := toData
+		serverData.Server = &server
+		serverData.ServerParams, err = atscfg.GetServerParameters(&server, params)
+		if err != nil {
+			return nil, errors.New("layering parameters of server '" + *sv.HostName + "': " + err.Error())
+		}
+		serverBts, err := json.Marshal(serverData)
+		if err != nil {
+			return nil, errors.New("encoding data of server '" + *sv.HostName + "': " + err.Error())
+		}
+		servers = append(servers, ServerData{HostName: *sv.HostName, Data: serverBts})
+	}
+	if len(servers) == 0 {
+		return nil, errors.New("data file '" + path + "' has no server, and no cache servers to expand it to")
+	}
+	sort.Slice(servers, func(i, j int) bool { return servers[i].HostName < servers[j].HostName })
+	return servers, nil
+}
+
+// Generate runs the t3c-generate at bin with the given arguments on data, and
+// returns the config files it generated.
+func Generate(bin string, args []string, data []byte) ([]t3cutil.ATSConfigFile, error) {
+	stdout := bytes.Buffer{}
+	stderr := bytes.Buffer{}
+	cmd := exec.Command(bin, args...)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.New("running " + bin + ": " + err.Error() + ": " + strings.TrimSpace(stderr.String()))
+	}
+	files := []t3cutil.ATSConfigFile{}
+	if err := json.Unmarshal(stdout.Bytes(), &files); err != nil {
+		return nil, errors.New("decoding output of " + bin + ": " + err.Error())
+	}
+	return files, nil
+}
+
+// GeneratorVersion returns the version string of the t3c-generate at bin, or
+// an empty string if it can't be determined.
+func GeneratorVersion(bin string) string {
+	out, err := exec.Command(bin, "--version").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,110 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Report is the result of comparing the configs generated by two versions of
+// t3c-generate.
+type Report struct {
+	Old     string         `json:"old"`
+	New     string         `json:"new"`
+	Servers []ServerReport `json:"servers"`
+}
+
+// ServerReport is the result of comparing the configs generated for one
+// server.
+type ServerReport struct {
+	DataFile string `json:"dataFile"`
+	HostName string `json:"hostName"`
+	// Unchanged is the number of files generated identically.
+	Unchanged int `json:"unchanged"`
+	// Files are the files which differ.
+	Files []FileDiff `json:"files"`
+}
+
+// NewServerReport returns the report of the given differences in the
+// configs generated for a server.
+func NewServerReport(dataFile string, hostName string, diffs []FileDiff) ServerReport {
+	sr := ServerReport{DataFile: dataFile, HostName: hostName, Files: []FileDiff{}}
+	for _, fd := range diffs {
+		if fd.Category == CategoryUnchanged {
+			sr.Unchanged++
+			continue
+		}
+		sr.Files = append(sr.Files, fd)
+	}
+	return sr
+}
+
+// Counts returns the number of files in the report in each Category.
+func (r Report) Counts() map[Category]int {
+	counts := map[Category]int{CategoryUnchanged: 0, CategoryCosmetic: 0, CategorySemantic: 0}
+	for _, sr := range r.Servers {
+		counts[CategoryUnchanged] += sr.Unchanged
+		for _, fd := range sr.Files {
+			counts[fd.Category]++
+		}
+	}
+	return counts
+}
+
+// Worst returns the most important Category of any file in the report.
+func (r Report) Worst() Category {
+	worst := CategoryUnchanged
+	for _, sr := range r.Servers {
+		for _, fd := range sr.Files {
+			if fd.Category.severity() > worst.severity() {
+				worst = fd.Category
+			}
+		}
+	}
+	return worst
+}
+
+// WriteText writes the report in a form meant to be read by people.
+// Semantic differences are listed before cosmetic ones, with the lines that
+// changed.
+func (r Report) WriteText(w io.Writer) {
+	fmt.Fprintf(w, "old: %s\nnew: %s\n", r.Old, r.New)
+	for _, sr := range r.Servers {
+		fmt.Fprintf(w, "\n%s (%s): %d unchanged\n", sr.HostName, sr.DataFile, sr.Unchanged)
+		for _, category := range []Category{CategorySemantic, CategoryCosmetic} {
+			for _, fd := range sr.Files {
+				if fd.Category != category {
+					continue
+				}
+				fmt.Fprintf(w, "  %s %s: %s\n", strings.ToUpper(string(fd.Category)), fd.Name, fd.Reason)
+				for _, line := range fd.Removed {
+					fmt.Fprintf(w, "    -%s\n", line)
+				}
+				for _, line := range fd.Added {
+					fmt.Fprintf(w, "    +%s\n", line)
+				}
+			}
+		}
+	}
+	counts := r.Counts()
+	fmt.Fprintf(w, "\n%d servers: %d semantic, %d cosmetic, %d unchanged\n", len(r.Servers), counts[CategorySemantic], counts[CategoryCosmetic], counts[CategoryUnchanged])
+}
@@ -0,0 +1,146 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-log"
+
+	"github.com/pborman/getopt/v2"
+)
+
+const AppName = "t3c-compat"
+
+// Version is the application version.
+// This is overwritten by the build with the current project version.
+var Version = "0.4"
+
+// GitRevision is the git revision the application was built from.
+// This is overwritten by the build with the current project version.
+var GitRevision = "nogit"
+
+const (
+	ExitCodeSuccess = 0
+	ExitCodeDiff    = 1
+	ExitCodeUsage   = 2
+	ExitCodeError   = 3
+)
+
+const defaultGenerateArgs = "--dir=/opt/trafficserver/etc/trafficserver"
+
+func main() {
+	help := getopt.BoolLong("help", 'h', "Print usage info and exit")
+	version := getopt.BoolLong("version", 'V', "Print version information and exit")
+	oldBin := getopt.StringLong("old", 'o', "", "Path to the t3c-generate of the release being upgraded from")
+	newBin := getopt.StringLong("new", 'n', "t3c-generate", "Path to the t3c-generate of the release being upgraded to")
+	generateArgs := getopt.StringLong("generate-args", 'a', defaultGenerateArgs, "Arguments to give both versions of t3c-generate")
+	oldGenerateArgs := getopt.StringLong("old-generate-args", 'A', "", "Arguments to give the old t3c-generate, if they differ from --generate-args")
+	format := getopt.EnumLong("format", 'f', []string{"text", "json"}, "text", "Report format, text or json")
+	failOn := getopt.EnumLong("fail-on", 'F', []string{string(CategorySemantic), string(CategoryCosmetic), "none"}, string(CategorySemantic), "Exit 1 if any file differs at least this much: semantic, cosmetic, or none")
+	getopt.ParseV2()
+
+	log.Init(os.Stderr, os.Stderr, os.Stderr, os.Stderr, os.Stderr)
+
+	if *help {
+		log.Errorln(usageStr)
+		os.Exit(ExitCodeSuccess)
+	} else if *version {
+		fmt.Println(t3cutil.VersionStr(AppName, Version, GitRevision))
+		os.Exit(ExitCodeSuccess)
+	}
+
+	dataFiles := getopt.Args()
+	if strings.TrimSpace(*oldBin) == "" || len(dataFiles) == 0 {
+		log.Errorln(usageStr)
+		os.Exit(ExitCodeUsage)
+	}
+
+	newArgs := strings.Fields(*generateArgs)
+	oldArgs := newArgs
+	if strings.TrimSpace(*oldGenerateArgs) != "" {
+		oldArgs = strings.Fields(*oldGenerateArgs)
+	}
+
+	report := Report{Old: *oldBin, New: *newBin, Servers: []ServerReport{}}
+	if v := GeneratorVersion(*oldBin); v != "" {
+		report.Old = v
+	}
+	if v := GeneratorVersion(*newBin); v != "" {
+		report.New = v
+	}
+
+	for _, dataFile := range dataFiles {
+		servers, err := LoadServerData(dataFile)
+		if err != nil {
+			log.Errorln(err.Error())
+			os.Exit(ExitCodeError)
+		}
+		for _, server := range servers {
+			oldFiles, err := Generate(*oldBin, oldArgs, server.Data)
+			if err != nil {
+				log.Errorln("generating old configs for server '" + server.HostName + "': " + err.Error())
+				os.Exit(ExitCodeError)
+			}
+			newFiles, err := Generate(*newBin, newArgs, server.Data)
+			if err != nil {
+				log.Errorln("generating new configs for server '" + server.HostName + "': " + err.Error())
+				os.Exit(ExitCodeError)
+			}
+			report.Servers = append(report.Servers, NewServerReport(dataFile, server.HostName, CompareConfigs(oldFiles, newFiles)))
+		}
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			log.Errorln("writing report: " + err.Error())
+			os.Exit(ExitCodeError)
+		}
+	} else {
+		report.WriteText(os.Stdout)
+	}
+
+	if *failOn != "none" && report.Worst().severity() >= Category(*failOn).severity() {
+		os.Exit(ExitCodeDiff)
+	}
+	os.Exit(ExitCodeSuccess)
+}
+
+const usageStr = `usage: t3c-compat [--help] [--version]
+        --old <old t3c-generate> [--new <new t3c-generate>]
+        [--generate-args <args>] [--old-generate-args <args>]
+        [--format text|json] [--fail-on semantic|cosmetic|none]
+        <data file>...
+
+Generates the config files of each data file's server with both the old and
+new t3c-generate, and reports how the generated files differ, categorized as
+cosmetic or semantic.
+
+Data files are Traffic Ops data dumps, as written by t3c-request --get-data=config.
+A data file without a server is expanded to each of its CDN's cache servers.
+
+Returns the exit code 0 if no file differs as much as --fail-on, 1 if one does,
+2 for invalid usage, and 3 if configs couldn't be generated.`
@@ -42,6 +42,21 @@ func GetAllConfigs(
 		return nil, errors.New("server hostname is nil")
 	}
 
+	// Rewrite rules, cache policies, and transform scripts are compiled into
+	// Delivery Service fields, which also determine which config files are
+	// needed, so they must be applied first.
+	dses, warnings := atscfg.ApplyRewriteRules(toData.DeliveryServices, toData.DeliveryServiceRewriteRules)
+	logWarnings("applying delivery service rewrite rules: ", warnings)
+	toData.DeliveryServices = dses
+
+	dses, warnings = atscfg.ApplyCachePolicies(toData.DeliveryServices, toData.DeliveryServiceCachePolicies)
+	logWarnings("applying delivery service cache policies: ", warnings)
+	toData.DeliveryServices = dses
+
+	dses, transformScriptFiles, warnings := atscfg.ApplyTransformScripts(*toData.Server.HostName, toData.DeliveryServices, toData.DeliveryServiceTransformScripts, cfg.Dir)
+	logWarnings("applying delivery service transform scripts: ", warnings)
+	toData.DeliveryServices = dses
+
 	configFiles, warnings, err := MakeConfigFilesList(toData, cfg.Dir, cfg.ATSMajorVersion)
 	logWarnings("generating config files list: ", warnings)
 	if err != nil {
@@ -83,6 +98,11 @@ func GetAllConfigs(
 		configs = append(configs, sslConfigs...)
 	}
 
+	if !cfg.RevalOnly {
+		configs = append(configs, GetOriginCABundleFiles(toData, cfg.Dir)...)
+		configs = append(configs, GetTransformScriptFiles(transformScriptFiles, cfg.Dir)...)
+	}
+
 	return configs, nil
 }
 
@@ -0,0 +1,172 @@
+package cfgfile
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-generate/config"
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/test"
+)
+
+const goldenFixtureDir = "testdata/fixtures"
+const goldenDir = "testdata/golden"
+const goldenATSConfigDir = "/opt/trafficserver/etc/trafficserver"
+
+// headerTimeRegex matches the generation time in the header comments of
+// config files, which is the only part of them that changes between runs.
+var headerTimeRegex = regexp.MustCompile(`(DO NOT EDIT - Generated for .*) on \S+`)
+
+// TestGoldenConfigs generates the config files of every cache server in each
+// of the fixture CDNs in testdata/fixtures - which are t3cutil.ConfigData
+// objects without a "server" - and compares them to the ones in
+// testdata/golden/{{CDN}}/{{host name}}. Changes to generated configs which
+// are intended must be "blessed" by running the test with -update and
+// committing the golden files it rewrites.
+func TestGoldenConfigs(t *testing.T) {
+	fixtures, err := filepath.Glob(filepath.Join(goldenFixtureDir, "*.json"))
+	if err != nil {
+		t.Fatalf("listing fixture CDNs: %v", err)
+	}
+	if len(fixtures) == 0 {
+		t.Fatalf("no fixture CDNs found in %s", goldenFixtureDir)
+	}
+
+	for _, fixture := range fixtures {
+		cdn := strings.TrimSuffix(filepath.Base(fixture), ".json")
+		t.Run(cdn, func(t *testing.T) {
+			toData := loadGoldenFixture(t, fixture)
+			hosts := []string{}
+			for _, sv := range toData.Servers {
+				if sv.HostName == nil || !(strings.HasPrefix(sv.Type, tc.EdgeTypePrefix) || strings.HasPrefix(sv.Type, tc.MidTypePrefix)) {
+					continue
+				}
+				hosts = append(hosts, *sv.HostName)
+			}
+			for _, host := range hosts {
+				t.Run(host, func(t *testing.T) {
+					// GetAllConfigs modifies its data, so every server gets a fresh copy.
+					configs := generateGoldenConfigs(t, loadGoldenFixture(t, fixture), host)
+					checkGoldenConfigs(t, filepath.Join(goldenDir, cdn, host), configs)
+				})
+			}
+		})
+	}
+}
+
+func loadGoldenFixture(t *testing.T, path string) *t3cutil.ConfigData {
+	t.Helper()
+	bts, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading fixture CDN: %v", err)
+	}
+	toData := &t3cutil.ConfigData{}
+	if err := json.Unmarshal(bts, toData); err != nil {
+		t.Fatalf("decoding fixture CDN '%s': %v", path, err)
+	}
+	return toData
+}
+
+// generateGoldenConfigs generates the config files for the server with the
+// given host name, the way t3c-generate would after t3c-request fetched its
+// data.
+func generateGoldenConfigs(t *testing.T, toData *t3cutil.ConfigData, host string) map[string]string {
+	t.Helper()
+	// The server must not point into Servers, which is sorted in place by
+	// some generators.
+	for _, sv := range toData.Servers {
+		if sv.HostName != nil && *sv.HostName == host {
+			server := sv
+			toData.Server = &server
+			break
+		}
+	}
+
+	params := []tc.Parameter{}
+	seen := map[int]struct{}{}
+	for _, profile := range toData.Server.ProfileNames {
+		for _, param := range toData.ServerProfilesParams[atscfg.ProfileName(profile)] {
+			if _, ok := seen[param.ID]; ok {
+				continue
+			}
+			seen[param.ID] = struct{}{}
+			params = append(params, param)
+		}
+	}
+	serverParams, err := atscfg.GetServerParameters(toData.Server, params)
+	if err != nil {
+		t.Fatalf("layering server parameters: %v", err)
+	}
+	toData.ServerParams = serverParams
+
+	cfg := config.Cfg{}
+	cfg.Dir = goldenATSConfigDir
+	configs, err := GetAllConfigs(toData, cfg)
+	if err != nil {
+		t.Fatalf("generating configs: %v", err)
+	}
+
+	files := make(map[string]string, len(configs))
+	for _, cfg := range configs {
+		if _, ok := files[cfg.Name]; ok {
+			t.Errorf("config file '%s' was generated more than once", cfg.Name)
+		}
+		files[cfg.Name] = headerTimeRegex.ReplaceAllString(cfg.Text, "$1")
+	}
+	return files
+}
+
+// checkGoldenConfigs compares each of the configs to its golden file in dir,
+// and checks that there are no golden files in dir for configs which are no
+// longer generated.
+func checkGoldenConfigs(t *testing.T, dir string, configs map[string]string) {
+	t.Helper()
+	names := make([]string, 0, len(configs))
+	for name := range configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if *test.UpdateGolden {
+		if err := os.RemoveAll(dir); err != nil {
+			t.Fatalf("removing old golden files: %v", err)
+		}
+	} else if existing, err := ioutil.ReadDir(dir); err == nil {
+		for _, fi := range existing {
+			if _, ok := configs[fi.Name()]; !ok {
+				t.Errorf("golden file '%s' exists, but no such config file was generated", filepath.Join(dir, fi.Name()))
+			}
+		}
+	}
+
+	for _, name := range names {
+		test.Golden(t, filepath.Join(dir, name), []byte(configs[name]))
+	}
+}
@@ -0,0 +1,45 @@
+package cfgfile
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"path/filepath"
+
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+)
+
+// GetOriginCABundleFiles returns the CA bundle files used to verify the TLS
+// certificates of Delivery Service origins, placed under the ATS config
+// directory atsConfigDir.
+func GetOriginCABundleFiles(toData *t3cutil.ConfigData, atsConfigDir string) []t3cutil.ATSConfigFile {
+	bundles, warnings := atscfg.MakeOriginCABundles(toData.DeliveryServices, toData.GlobalParams)
+	logWarnings("Getting origin CA bundle files: ", warnings)
+
+	configs := []t3cutil.ATSConfigFile{}
+	for _, bundle := range bundles {
+		configs = append(configs, t3cutil.ATSConfigFile{
+			Name: bundle.FileName,
+			Path: filepath.Join(atsConfigDir, atscfg.OriginCABundleDirName) + "/",
+			Text: bundle.Text,
+		})
+	}
+	return configs
+}
@@ -89,6 +89,7 @@ var configFileLiteralFuncs = []ConfigFileLiteralFunc{
 	{"ip_allow.yaml", MakeIPAllowDotYAML},
 	{"logging.config", MakeLoggingDotConfig},
 	{"logging.yaml", MakeLoggingDotYAML},
+	{atscfg.LogShippingConfigFileName, MakeLogShippingConfig},
 	{"logs_xml.config", MakeLogsXMLDotConfig},
 	{"packages", MakePackages},
 	{"parent.config", MakeParentDotConfig},
@@ -111,6 +112,7 @@ var configFilePrefixSuffixFuncs = []ConfigFilePrefixSuffixFunc{
 	{atscfg.HeaderRewriteLastPrefix, ".config", MakeHeaderRewrite},
 	{"hdr_rw_mid_", ".config", MakeHeaderRewrite},
 	{"hdr_rw_", ".config", MakeHeaderRewrite},
+	{atscfg.CompressPrefix, ".config", MakeCompress},
 	{"regex_remap_", ".config", MakeRegexRemap},
 	{"set_dscp_", ".config", MakeSetDSCP},
 	{"url_sig_", ".config", MakeURLSigConfig},
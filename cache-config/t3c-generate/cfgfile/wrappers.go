@@ -20,6 +20,8 @@ package cfgfile
  */
 
 import (
+	"errors"
+
 	"github.com/apache/trafficcontrol/cache-config/t3c-generate/config"
 	"github.com/apache/trafficcontrol/cache-config/t3cutil"
 	"github.com/apache/trafficcontrol/lib/go-atscfg"
@@ -74,7 +76,7 @@ func MakeBGFetchDotConfig(toData *t3cutil.ConfigData, fileName string, hdrCommen
 
 func MakeCacheDotConfig(toData *t3cutil.ConfigData, fileName string, hdrCommentTxt string, cfg config.Cfg) (atscfg.Cfg, error) {
 	opts := &atscfg.CacheDotConfigOpts{HdrComment: hdrCommentTxt}
-	return atscfg.MakeCacheDotConfig(toData.Server, toData.Servers, toData.DeliveryServices, toData.DeliveryServiceServers, opts)
+	return atscfg.MakeCacheDotConfig(toData.Server, toData.Servers, toData.DeliveryServices, toData.DeliveryServiceServers, toData.DeliveryServiceCachePolicies, toData.DeliveryServicePathRules, opts)
 }
 
 func MakeChkconfig(toData *t3cutil.ConfigData, fileName string, hdrCommentTxt string, cfg config.Cfg) (atscfg.Cfg, error) {
@@ -121,14 +123,41 @@ func MakeLoggingDotConfig(toData *t3cutil.ConfigData, fileName string, hdrCommen
 }
 
 func MakeLoggingDotYAML(toData *t3cutil.ConfigData, fileName string, hdrCommentTxt string, cfg config.Cfg) (atscfg.Cfg, error) {
-	return atscfg.MakeLoggingDotYAML(
+	logShipping, warnings, err := atscfg.GetLogShippingDeliveryServices(
+		toData.Server,
+		toData.DeliveryServices,
+		toData.DeliveryServiceServers,
+		toData.CacheGroups,
+		toData.Topologies,
+		toData.DeliveryServiceLogShipping,
+	)
+	if err != nil {
+		return atscfg.Cfg{}, errors.New("getting log shipping delivery services: " + err.Error())
+	}
+	loggingCfg, err := atscfg.MakeLoggingDotYAML(
 		toData.Server,
 		toData.ServerParams,
 		&atscfg.LoggingDotYAMLOpts{
 			HdrComment:      hdrCommentTxt,
 			ATSMajorVersion: cfg.ATSMajorVersion,
+			LogShipping:     len(logShipping) > 0,
 		},
 	)
+	loggingCfg.Warnings = append(warnings, loggingCfg.Warnings...)
+	return loggingCfg, err
+}
+
+func MakeLogShippingConfig(toData *t3cutil.ConfigData, fileName string, hdrCommentTxt string, cfg config.Cfg) (atscfg.Cfg, error) {
+	return atscfg.MakeLogShippingConfig(
+		toData.Server,
+		toData.DeliveryServices,
+		toData.DeliveryServiceServers,
+		toData.DeliveryServiceRegexes,
+		toData.CacheGroups,
+		toData.Topologies,
+		toData.DeliveryServiceLogShipping,
+		&atscfg.LogShippingConfigOpts{HdrComment: hdrCommentTxt},
+	)
 }
 
 func MakeSSLServerNameYAML(toData *t3cutil.ConfigData, fileName string, hdrCommentTxt string, cfg config.Cfg) (atscfg.Cfg, error) {
@@ -194,6 +223,7 @@ func MakeParentDotConfig(toData *t3cutil.ConfigData, fileName string, hdrComment
 		toData.DSRequiredCapabilities,
 		toData.CacheGroups,
 		toData.DeliveryServiceServers,
+		toData.DeliveryServicePathRules,
 		toData.CDN,
 		&atscfg.ParentConfigOpts{
 			HdrComment:      hdrCommentTxt,
@@ -235,6 +265,7 @@ func MakeRemapDotConfig(toData *t3cutil.ConfigData, fileName string, hdrCommentT
 		toData.DeliveryServices,
 		toData.DeliveryServiceServers,
 		toData.DeliveryServiceRegexes,
+		toData.DeliveryServicePathRules,
 		toData.ServerParams,
 		toData.CDN,
 		remapAndCacheKeyParams,
@@ -297,6 +328,11 @@ func MakeRegexRemap(toData *t3cutil.ConfigData, fileName string, hdrCommentTxt s
 	return atscfg.MakeRegexRemapDotConfig(fileName, toData.Server, toData.DeliveryServices, opts)
 }
 
+func MakeCompress(toData *t3cutil.ConfigData, fileName string, hdrCommentTxt string, cfg config.Cfg) (atscfg.Cfg, error) {
+	opts := &atscfg.CompressDotConfigOpts{HdrComment: hdrCommentTxt}
+	return atscfg.MakeCompressDotConfig(fileName, toData.Server, toData.DeliveryServices, opts)
+}
+
 func MakeSetDSCP(toData *t3cutil.ConfigData, fileName string, hdrCommentTxt string, cfg config.Cfg) (atscfg.Cfg, error) {
 	opts := &atscfg.SetDSCPDotConfigOpts{HdrComment: hdrCommentTxt}
 	return atscfg.MakeSetDSCPDotConfig(fileName, toData.Server, opts)
@@ -304,7 +340,7 @@ func MakeSetDSCP(toData *t3cutil.ConfigData, fileName string, hdrCommentTxt stri
 
 func MakeURLSigConfig(toData *t3cutil.ConfigData, fileName string, hdrCommentTxt string, cfg config.Cfg) (atscfg.Cfg, error) {
 	opts := &atscfg.URLSigConfigOpts{HdrComment: hdrCommentTxt}
-	return atscfg.MakeURLSigConfig(fileName, toData.Server, toData.ServerParams, toData.URLSigKeys, opts)
+	return atscfg.MakeURLSigConfig(fileName, toData.Server, toData.ServerParams, toData.URLSigKeys, toData.DeliveryServiceTokenAuth, opts)
 }
 
 func MakeURISigningConfig(toData *t3cutil.ConfigData, fileName string, hdrCommentTxt string, cfg config.Cfg) (atscfg.Cfg, error) {
@@ -0,0 +1,202 @@
+package agent
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	"github.com/nxadm/tail"
+)
+
+// Opts are the options of an Agent.
+type Opts struct {
+	// Secret is the bearer token with which Traffic Ops authenticates.
+	Secret string
+	// Logs are the paths of the logs which may be tailed, keyed by their
+	// tc.ServerLog* names.
+	Logs map[string]string
+	// MaxSeconds is the longest a log may be tailed.
+	MaxSeconds int
+	// TrafficCtl is the path of traffic_ctl, with which whitelisted commands
+	// are run. If it's empty, commands can't be run.
+	TrafficCtl string
+	// TrafficCtlTimeout is how long a traffic_ctl command may run before
+	// it's killed.
+	TrafficCtlTimeout time.Duration
+	// HitCounter counts the cache hits and misses of the access log, which
+	// are collected by Traffic Ops. If it's nil, they aren't counted.
+	HitCounter *HitCounter
+}
+
+// Agent is an http.Handler which streams the lines appended to the logs of a
+// cache server to Traffic Ops, for the /servers/{{ID}}/logs/tail endpoint,
+// runs traffic_ctl commands for the /servers/{{ID}}/traffic_ctl endpoint, and
+// serves the cache hits and misses Traffic Ops collects for the
+// /deliveryservices/{{ID}}/cache_hit_ratio endpoint.
+type Agent struct {
+	opts Opts
+}
+
+// New returns a new Agent with the given options.
+func New(opts Opts) *Agent {
+	return &Agent{opts: opts}
+}
+
+// ServeHTTP implements http.Handler.
+func (a *Agent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if path, ok := a.opts.Logs[strings.TrimPrefix(r.URL.Path, tc.LogAgentLogsPath)]; ok && strings.HasPrefix(r.URL.Path, tc.LogAgentLogsPath) {
+		a.serveLog(w, r, path)
+		return
+	}
+	if r.URL.Path == tc.LogAgentTrafficCtlPath && a.opts.TrafficCtl != "" {
+		a.serveTrafficCtl(w, r)
+		return
+	}
+	if r.URL.Path == tc.LogAgentCacheHitsPath && a.opts.HitCounter != nil {
+		a.serveCacheHits(w, r)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+}
+
+// serveLog serves GET requests to tc.LogAgentLogsPath followed by the name of
+// a log, streaming the lines appended to the log at the given path after the
+// request which match at least one of its "match" query parameters, if it has
+// any, until its "seconds" have passed or the client goes away.
+func (a *Agent) serveLog(w http.ResponseWriter, r *http.Request, path string) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if !a.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	seconds, res, err := parseParams(r, a.opts.MaxSeconds)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	t, err := tail.TailFile(path, tail.Config{
+		Follow:   true,
+		ReOpen:   true,
+		Logger:   tail.DiscardingLogger,
+		Location: &tail.SeekInfo{Offset: 0, Whence: 2},
+	})
+	if err != nil {
+		log.Errorf("tailing log '%s': %s\n", path, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	defer t.Cleanup()
+	defer t.Stop()
+	log.Infof("tailing log '%s' for %s for %d seconds\n", path, r.RemoteAddr, seconds)
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	timer := time.NewTimer(time.Duration(seconds) * time.Second)
+	defer timer.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-timer.C:
+			return
+		case line, ok := <-t.Lines:
+			if !ok {
+				log.Errorf("tailing log '%s' stopped: %v\n", path, t.Err())
+				return
+			}
+			if line.Err != nil || !matches(line.Text, res) {
+				continue
+			}
+			if _, err := w.Write([]byte(line.Text + "\n")); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// authorized returns whether the request bears the agent's secret.
+func (a *Agent) authorized(r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return a.opts.Secret != "" && subtle.ConstantTimeCompare([]byte(token), []byte(a.opts.Secret)) == 1
+}
+
+// parseParams returns how many seconds to tail a log and the regular
+// expressions which tailed lines must match, from the request's query
+// parameters.
+func parseParams(r *http.Request, maxSeconds int) (int, []*regexp.Regexp, error) {
+	q := r.URL.Query()
+	seconds := tc.DefaultServerLogTailSeconds
+	if seconds > maxSeconds {
+		seconds = maxSeconds
+	}
+	if s := q.Get(tc.ServerLogTailSecondsQueryParam); s != "" {
+		var err error
+		if seconds, err = strconv.Atoi(s); err != nil || seconds < 1 || seconds > maxSeconds {
+			return 0, nil, fmt.Errorf("%s must be an integer from 1 to %d", tc.ServerLogTailSecondsQueryParam, maxSeconds)
+		}
+	}
+	res := []*regexp.Regexp{}
+	for _, m := range q[tc.LogAgentMatchQueryParam] {
+		re, err := regexp.Compile(m)
+		if err != nil {
+			return 0, nil, fmt.Errorf("%s '%s' is not a valid regular expression: %s", tc.LogAgentMatchQueryParam, m, err.Error())
+		}
+		res = append(res, re)
+	}
+	return seconds, res, nil
+}
+
+// matches returns whether the line matches any of the regular expressions, or
+// there are none.
+func matches(line string, res []*regexp.Regexp) bool {
+	if len(res) == 0 {
+		return true
+	}
+	for _, re := range res {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package agent
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestParseParams(t *testing.T) {
+	tests := []struct {
+		query   string
+		seconds int
+		res     int
+		err     bool
+	}{
+		{query: "", seconds: 30},
+		{query: "seconds=10", seconds: 10},
+		{query: "seconds=30&match=a&match=b", seconds: 30, res: 2},
+		{query: "seconds=0", err: true},
+		{query: "seconds=31", err: true},
+		{query: "seconds=x", err: true},
+		{query: "match=(", err: true},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/logs/access?"+test.query, nil)
+		seconds, res, err := parseParams(r, 30)
+		if test.err {
+			if err == nil {
+				t.Errorf("parsing '%s': expected an error, got none", test.query)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parsing '%s': unexpected error: %v", test.query, err)
+			continue
+		}
+		if seconds != test.seconds || len(res) != test.res {
+			t.Errorf("parsing '%s': expected %d seconds and %d expressions, got %d and %d", test.query, test.seconds, test.res, seconds, len(res))
+		}
+	}
+}
+
+func TestAgentRejects(t *testing.T) {
+	a := New(Opts{Secret: "secret", Logs: map[string]string{tc.ServerLogAccess: "/nonexistent"}, MaxSeconds: 30})
+	tests := []struct {
+		method string
+		path   string
+		auth   string
+		code   int
+	}{
+		{method: http.MethodGet, path: "/logs/other", auth: "Bearer secret", code: http.StatusNotFound},
+		{method: http.MethodGet, path: "/access", auth: "Bearer secret", code: http.StatusNotFound},
+		{method: http.MethodPost, path: "/logs/access", auth: "Bearer secret", code: http.StatusMethodNotAllowed},
+		{method: http.MethodGet, path: "/logs/access", auth: "", code: http.StatusUnauthorized},
+		{method: http.MethodGet, path: "/logs/access", auth: "Bearer wrong", code: http.StatusUnauthorized},
+		{method: http.MethodGet, path: "/logs/access?seconds=31", auth: "Bearer secret", code: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(test.method, test.path, nil)
+		if test.auth != "" {
+			r.Header.Set("Authorization", test.auth)
+		}
+		w := httptest.NewRecorder()
+		a.ServeHTTP(w, r)
+		if w.Code != test.code {
+			t.Errorf("%s %s with authorization '%s': expected status %d, got %d", test.method, test.path, test.auth, test.code, w.Code)
+		}
+	}
+}
+
+func TestAgentTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "custom_ats_2.log")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("creating log: %v", err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString("url=http://video.demo1.mycdn.ciab.test/ before the tail\n"); err != nil {
+		t.Fatalf("writing log: %v", err)
+	}
+
+	srv := httptest.NewServer(New(Opts{Secret: "secret", Logs: map[string]string{tc.ServerLogAccess: path}, MaxSeconds: 30}))
+	defer srv.Close()
+	req, err := http.NewRequest(http.MethodGet, srv.URL+"/logs/access?seconds=5&match="+url.QueryEscape(`\.demo1\.`), nil)
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("requesting tail: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+
+	// The log is tailed from its end once the tail starts, which may be
+	// after the response begins, so lines are written until one is read.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(50 * time.Millisecond):
+				f.WriteString("url=http://video.demo2.mycdn.ciab.test/ matched\n")
+				f.WriteString("url=http://video.demo1.mycdn.ciab.test/ matched\n")
+			}
+		}
+	}()
+
+	scanner := bufio.NewScanner(resp.Body)
+	if !scanner.Scan() {
+		t.Fatalf("expected a line, got none: %v", scanner.Err())
+	}
+	if line := scanner.Text(); line != "url=http://video.demo1.mycdn.ciab.test/ matched" {
+		t.Errorf("expected only lines matching the Delivery Service, got '%s'", line)
+	}
+}
@@ -0,0 +1,273 @@
+package agent
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	"github.com/nxadm/tail"
+)
+
+// CacheHitRetention is how long a HitCounter keeps the windows it has
+// counted, for Traffic Ops to collect.
+const CacheHitRetention = time.Hour
+
+// HitCounter counts the cache hits and misses of the requests in the ATS
+// access log, by the host requested, in windows of a fixed length, and
+// samples the most missed URLs of each host. Traffic Ops collects the
+// windows, and attributes them to Delivery Services by their hosts.
+//
+// Lines are expected in the key=value format of custom_ats_2.log, of which
+// the 'url' and 'crc' (cache result code) fields are read.
+//
+// A HitCounter is safe for concurrent use.
+type HitCounter struct {
+	window time.Duration
+	keep   int
+
+	mu   sync.Mutex
+	cur  windowCounts
+	done []tc.CacheHitWindow
+}
+
+// windowCounts are the counts of the window being counted.
+type windowCounts struct {
+	start time.Time
+	hosts map[string]*hostCounts
+}
+
+// hostCounts are the counts of the requests of one host in a window.
+type hostCounts struct {
+	hits   uint64
+	misses uint64
+	// samples are the estimated counts of the most missed URLs, kept with
+	// the Space-Saving algorithm: once full, a URL not yet sampled replaces
+	// the least missed, and inherits its count.
+	samples map[string]uint64
+}
+
+// NewHitCounter returns a HitCounter counting in windows of the given length,
+// which keeps those that ended within the CacheHitRetention.
+func NewHitCounter(window time.Duration) *HitCounter {
+	keep := int(CacheHitRetention / window)
+	if keep < 1 {
+		keep = 1
+	}
+	return &HitCounter{window: window, keep: keep}
+}
+
+// Run counts the lines appended to the access log at the given path, until
+// tailing it fails.
+func (h *HitCounter) Run(path string) error {
+	t, err := tail.TailFile(path, tail.Config{
+		Follow:   true,
+		ReOpen:   true,
+		Logger:   tail.DiscardingLogger,
+		Location: &tail.SeekInfo{Offset: 0, Whence: 2},
+	})
+	if err != nil {
+		return fmt.Errorf("tailing access log '%s': %w", path, err)
+	}
+	defer t.Cleanup()
+	log.Infof("counting cache hits of access log '%s' in %s windows\n", path, h.window)
+	for line := range t.Lines {
+		if line.Err != nil {
+			log.Warnf("reading access log: %s\n", line.Err.Error())
+			continue
+		}
+		h.Count(time.Now(), line.Text)
+	}
+	return fmt.Errorf("tailing access log '%s' stopped: %v", path, t.Err())
+}
+
+// Count counts the request of the given access log line, logged at the given
+// time. Lines which aren't of requests, or whose cache result is neither a
+// hit nor a miss, aren't counted.
+func (h *HitCounter) Count(now time.Time, line string) {
+	host, url, hit, miss := parseAccessLine(line)
+	if !hit && !miss {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.rotate(now)
+	counts, ok := h.cur.hosts[host]
+	if !ok {
+		counts = &hostCounts{samples: map[string]uint64{}}
+		h.cur.hosts[host] = counts
+	}
+	if hit {
+		counts.hits++
+		return
+	}
+	counts.misses++
+	counts.sample(url)
+}
+
+// Windows returns the counted windows which ended after the given time,
+// oldest first. The window being counted isn't returned until it ends.
+func (h *HitCounter) Windows(now time.Time, since time.Time) []tc.CacheHitWindow {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.rotate(now)
+	windows := []tc.CacheHitWindow{}
+	for _, w := range h.done {
+		if w.End.After(since) {
+			windows = append(windows, w)
+		}
+	}
+	return windows
+}
+
+// rotate ends the window being counted, if the given time is after it, and
+// starts counting the window of the given time. Windows in which nothing was
+// counted are skipped. h.mu must be held.
+func (h *HitCounter) rotate(now time.Time) {
+	start := now.Truncate(h.window)
+	if start.Equal(h.cur.start) && h.cur.hosts != nil {
+		return
+	}
+	if len(h.cur.hosts) > 0 {
+		h.done = append(h.done, h.cur.finish(h.window))
+		if over := len(h.done) - h.keep; over > 0 {
+			h.done = h.done[over:]
+		}
+	}
+	h.cur = windowCounts{start: start, hosts: map[string]*hostCounts{}}
+}
+
+// finish returns the counts of the window of the given length.
+func (w windowCounts) finish(length time.Duration) tc.CacheHitWindow {
+	window := tc.CacheHitWindow{
+		Start: w.start,
+		End:   w.start.Add(length),
+		Hosts: make(map[string]tc.CacheHitCounts, len(w.hosts)),
+	}
+	for host, counts := range w.hosts {
+		samples := make([]tc.CacheMissSample, 0, len(counts.samples))
+		for url, count := range counts.samples {
+			samples = append(samples, tc.CacheMissSample{URL: url, Count: count})
+		}
+		tc.SortCacheMissSamples(samples)
+		window.Hosts[host] = tc.CacheHitCounts{Hits: counts.hits, Misses: counts.misses, MissSamples: samples}
+	}
+	return window
+}
+
+// sample counts a miss of the given URL in the samples.
+func (c *hostCounts) sample(url string) {
+	if _, ok := c.samples[url]; ok || len(c.samples) < tc.MaxCacheMissSamples {
+		c.samples[url]++
+		return
+	}
+	leastURL, least := "", uint64(0)
+	for u, count := range c.samples {
+		if leastURL == "" || count < least || (count == least && u < leastURL) {
+			leastURL, least = u, count
+		}
+	}
+	delete(c.samples, leastURL)
+	c.samples[url] = least + 1
+}
+
+// parseAccessLine returns the host and URL - without its query string - of
+// the request of a custom_ats_2.log line, and whether its cache result was a
+// hit or a miss.
+func parseAccessLine(line string) (string, string, bool, bool) {
+	var rawURL, crc string
+	for _, field := range strings.Fields(line) {
+		if strings.HasPrefix(field, "url=") {
+			rawURL = strings.TrimPrefix(field, "url=")
+		} else if strings.HasPrefix(field, "crc=") {
+			crc = strings.TrimPrefix(field, "crc=")
+		}
+		if rawURL != "" && crc != "" {
+			break
+		}
+	}
+	hit, miss := classifyCacheResult(crc)
+	if !hit && !miss {
+		return "", "", false, false
+	}
+
+	_, rest, ok := strings.Cut(rawURL, "://")
+	if !ok {
+		return "", "", false, false
+	}
+	host, _, _ := strings.Cut(rest, "/")
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if host == "" {
+		return "", "", false, false
+	}
+	url, _, _ := strings.Cut(rawURL, "?")
+	return strings.ToLower(host), url, hit, miss
+}
+
+// classifyCacheResult returns whether the given ATS cache result code is a
+// hit or a miss. Results such as errors and denials are neither.
+func classifyCacheResult(crc string) (bool, bool) {
+	switch {
+	case strings.Contains(crc, "HIT"):
+		return true, false
+	case strings.Contains(crc, "MISS"), crc == "TCP_CLIENT_REFRESH", crc == "TCP_SWAPFAIL":
+		return false, true
+	}
+	return false, false
+}
+
+// serveCacheHits serves GET requests to tc.LogAgentCacheHitsPath, responding
+// with the counted windows which ended after the time of the request's
+// "since" query parameter, if it has one.
+func (a *Agent) serveCacheHits(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if !a.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	since := time.Time{}
+	if s := r.URL.Query().Get(tc.LogAgentCacheHitsSinceQueryParam); s != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339, s); err != nil {
+			http.Error(w, tc.LogAgentCacheHitsSinceQueryParam+" must be an RFC3339 time", http.StatusBadRequest)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(a.opts.HitCounter.Windows(time.Now(), since)); err != nil {
+		log.Errorf("writing cache hit windows: %s\n", err.Error())
+	}
+}
@@ -0,0 +1,181 @@
+package agent
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestParseAccessLine(t *testing.T) {
+	tests := []struct {
+		line string
+		host string
+		url  string
+		hit  bool
+		miss bool
+	}{
+		{
+			line: `1667393421.117 chi=172.16.127.1 rhi=172.16.127.5 phn=mid.infra.ciab.test php=80 shn=edge.infra.ciab.test url=http://Video.demo1.mycdn.ciab.test:8080/a/b.ts?token=x cqhm=GET cqhv=HTTP/1.1 pssc=200 ttms=3 b=1024 sssc=200 sscl=1024 cfsc=FIN pfsc=- crc=TCP_MISS phr=DIRECT pqsn=- uas="curl/7.61.1" xmt="-"`,
+			host: "video.demo1.mycdn.ciab.test",
+			url:  "http://Video.demo1.mycdn.ciab.test:8080/a/b.ts",
+			miss: true,
+		},
+		{line: "url=https://video.demo1.mycdn.ciab.test/ crc=TCP_MEM_HIT", host: "video.demo1.mycdn.ciab.test", url: "https://video.demo1.mycdn.ciab.test/", hit: true},
+		{line: "url=http://origin.infra.ciab.test crc=TCP_REFRESH_HIT", host: "origin.infra.ciab.test", url: "http://origin.infra.ciab.test", hit: true},
+		{line: "url=http://video.demo1.mycdn.ciab.test/ crc=TCP_CLIENT_REFRESH", host: "video.demo1.mycdn.ciab.test", url: "http://video.demo1.mycdn.ciab.test/", miss: true},
+		{line: "url=http://video.demo1.mycdn.ciab.test/ crc=ERR_CLIENT_ABORT"},
+		{line: "url=http://video.demo1.mycdn.ciab.test/ crc=TCP_DENIED"},
+		{line: "url=/relative crc=TCP_HIT"},
+		{line: "crc=TCP_HIT"},
+		{line: "not an access log line"},
+	}
+	for _, test := range tests {
+		host, url, hit, miss := parseAccessLine(test.line)
+		if host != test.host || url != test.url || hit != test.hit || miss != test.miss {
+			t.Errorf("parsing '%s': expected (%s, %s, %t, %t), got (%s, %s, %t, %t)", test.line, test.host, test.url, test.hit, test.miss, host, url, hit, miss)
+		}
+	}
+}
+
+func TestHitCounterWindows(t *testing.T) {
+	h := NewHitCounter(time.Minute)
+	start := time.Date(2022, 7, 4, 12, 0, 0, 0, time.UTC)
+	h.Count(start.Add(time.Second), "url=http://a.example/1 crc=TCP_HIT")
+	h.Count(start.Add(2*time.Second), "url=http://a.example/2?q=1 crc=TCP_MISS")
+	h.Count(start.Add(3*time.Second), "url=http://a.example/2?q=2 crc=TCP_MISS")
+	h.Count(start.Add(4*time.Second), "url=http://b.example/ crc=ERR_CONNECT_FAIL")
+
+	if windows := h.Windows(start.Add(30*time.Second), time.Time{}); len(windows) != 0 {
+		t.Fatalf("expected no windows before the first ends, got %v", windows)
+	}
+
+	// nothing is counted in the second window, which is skipped
+	h.Count(start.Add(2*time.Minute+time.Second), "url=http://b.example/ crc=TCP_HIT")
+	windows := h.Windows(start.Add(3*time.Minute), time.Time{})
+	expected := []tc.CacheHitWindow{
+		{
+			Start: start,
+			End:   start.Add(time.Minute),
+			Hosts: map[string]tc.CacheHitCounts{
+				"a.example": {Hits: 1, Misses: 2, MissSamples: []tc.CacheMissSample{{URL: "http://a.example/2", Count: 2}}},
+			},
+		},
+		{
+			Start: start.Add(2 * time.Minute),
+			End:   start.Add(3 * time.Minute),
+			Hosts: map[string]tc.CacheHitCounts{
+				"b.example": {Hits: 1, MissSamples: []tc.CacheMissSample{}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(windows, expected) {
+		t.Errorf("expected windows %+v, got %+v", expected, windows)
+	}
+	if windows := h.Windows(start.Add(3*time.Minute), start.Add(time.Minute)); len(windows) != 1 || !windows[0].Start.Equal(start.Add(2*time.Minute)) {
+		t.Errorf("expected only the window ending after the first, got %+v", windows)
+	}
+
+	// only the windows within the retention are kept
+	for i := 0; i < 70; i++ {
+		h.Count(start.Add(time.Duration(3+i)*time.Minute), "url=http://a.example/ crc=TCP_HIT")
+	}
+	if windows := h.Windows(start.Add(74*time.Minute), time.Time{}); len(windows) != int(CacheHitRetention/time.Minute) {
+		t.Errorf("expected %d windows, got %d", int(CacheHitRetention/time.Minute), len(windows))
+	}
+}
+
+func TestHitCounterSamples(t *testing.T) {
+	h := NewHitCounter(time.Minute)
+	start := time.Date(2022, 7, 4, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		h.Count(start, "url=http://a.example/popular crc=TCP_MISS")
+	}
+	for i := 0; i < tc.MaxCacheMissSamples+10; i++ {
+		h.Count(start, fmt.Sprintf("url=http://a.example/%d crc=TCP_MISS", i))
+	}
+
+	windows := h.Windows(start.Add(time.Minute), time.Time{})
+	if len(windows) != 1 {
+		t.Fatalf("expected 1 window, got %d", len(windows))
+	}
+	counts := windows[0].Hosts["a.example"]
+	if counts.Misses != uint64(tc.MaxCacheMissSamples+13) {
+		t.Errorf("expected %d misses, got %d", tc.MaxCacheMissSamples+13, counts.Misses)
+	}
+	if len(counts.MissSamples) != tc.MaxCacheMissSamples {
+		t.Errorf("expected %d samples, got %d", tc.MaxCacheMissSamples, len(counts.MissSamples))
+	}
+	if top := counts.MissSamples[0]; top.URL != "http://a.example/popular" || top.Count != 3 {
+		t.Errorf("expected the most missed URL to be sampled first, got %+v", top)
+	}
+}
+
+func TestAgentCacheHits(t *testing.T) {
+	h := NewHitCounter(time.Minute)
+	h.Count(time.Now().Add(-2*time.Minute), "url=http://a.example/ crc=TCP_HIT")
+	a := New(Opts{Secret: "secret", HitCounter: h})
+
+	tests := []struct {
+		method string
+		query  string
+		auth   string
+		code   int
+	}{
+		{method: http.MethodPost, auth: "Bearer secret", code: http.StatusMethodNotAllowed},
+		{method: http.MethodGet, auth: "Bearer wrong", code: http.StatusUnauthorized},
+		{method: http.MethodGet, query: "?since=yesterday", auth: "Bearer secret", code: http.StatusBadRequest},
+		{method: http.MethodGet, auth: "Bearer secret", code: http.StatusOK},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(test.method, tc.LogAgentCacheHitsPath+test.query, nil)
+		r.Header.Set("Authorization", test.auth)
+		w := httptest.NewRecorder()
+		a.ServeHTTP(w, r)
+		if w.Code != test.code {
+			t.Errorf("%s %s with authorization '%s': expected status %d, got %d", test.method, test.query, test.auth, test.code, w.Code)
+			continue
+		}
+		if w.Code != http.StatusOK {
+			continue
+		}
+		windows := []tc.CacheHitWindow{}
+		if err := json.NewDecoder(w.Body).Decode(&windows); err != nil {
+			t.Errorf("decoding response: %v", err)
+		} else if len(windows) != 1 || windows[0].Hosts["a.example"].Hits != 1 {
+			t.Errorf("expected the counted window, got %+v", windows)
+		}
+	}
+
+	r := httptest.NewRequest(http.MethodGet, tc.LogAgentCacheHitsPath, nil)
+	r.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	New(Opts{Secret: "secret"}).ServeHTTP(w, r)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 without counting cache hits, got %d", w.Code)
+	}
+}
@@ -0,0 +1,123 @@
+package agent
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os/exec"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// maxTrafficCtlRequestBytes is the largest request to run a traffic_ctl
+// command which is read.
+const maxTrafficCtlRequestBytes = 64 * 1024
+
+// maxTrafficCtlOutputBytes is the most output of a traffic_ctl command which
+// is returned. Any more is cut off.
+const maxTrafficCtlOutputBytes = 1 << 20
+
+// serveTrafficCtl serves POST requests to tc.LogAgentTrafficCtlPath, running
+// the whitelisted traffic_ctl command given as a tc.TrafficCtlRequest and
+// responding with its tc.TrafficCtlResult. Commands which run, whether or not
+// they succeed, are responded to with a 200 OK status.
+func (a *Agent) serveTrafficCtl(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if !a.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	req := tc.TrafficCtlRequest{}
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxTrafficCtlRequestBytes)).Decode(&req); err != nil {
+		http.Error(w, "decoding request: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Traffic Ops validates commands too, but the agent mustn't run anything
+	// else no matter who asks.
+	if err := req.Validate(nil); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := a.runTrafficCtl(r.Context(), req)
+	if err != nil {
+		log.Errorf("running '%s': %s\n", req, err.Error())
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	log.Infof("ran '%s' for %s, exit code %d\n", req, r.RemoteAddr, result.ExitCode)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		log.Errorln("writing traffic_ctl result: " + err.Error())
+	}
+}
+
+// runTrafficCtl runs the traffic_ctl command of the request, which must be
+// valid. An error is returned only if traffic_ctl couldn't be run; its exit
+// code is returned in the result otherwise.
+func (a *Agent) runTrafficCtl(ctx context.Context, req tc.TrafficCtlRequest) (tc.TrafficCtlResult, error) {
+	if a.opts.TrafficCtlTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.opts.TrafficCtlTimeout)
+		defer cancel()
+	}
+	output := &limitedBuffer{max: maxTrafficCtlOutputBytes}
+	cmd := exec.CommandContext(ctx, a.opts.TrafficCtl, req.CommandLine()...)
+	cmd.Stdout = output
+	cmd.Stderr = output
+	result := tc.TrafficCtlResult{Command: req.Command, Args: req.Args}
+	if err := cmd.Run(); err != nil {
+		exitErr := (*exec.ExitError)(nil)
+		if !errors.As(err, &exitErr) {
+			return result, err
+		}
+		result.ExitCode = exitErr.ExitCode()
+	}
+	result.Output = output.String()
+	return result, nil
+}
+
+// limitedBuffer is an io.Writer which keeps at most max bytes written to it,
+// silently discarding the rest.
+type limitedBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.Len(); room < len(p) {
+		if room > 0 {
+			b.Buffer.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
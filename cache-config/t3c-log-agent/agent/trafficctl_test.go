@@ -0,0 +1,107 @@
+package agent
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// writeFakeTrafficCtl writes a traffic_ctl which prints its arguments and
+// exits with the given status, and returns its path.
+func writeFakeTrafficCtl(t *testing.T, status string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "traffic_ctl")
+	script := "#!/bin/sh\necho \"$@\"\nexit " + status + "\n"
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatalf("writing fake traffic_ctl: %v", err)
+	}
+	return path
+}
+
+func TestAgentTrafficCtl(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   string
+		body     string
+		auth     string
+		code     int
+		exitCode int
+		output   string
+	}{
+		{name: "metric get", status: "0", body: `{"command":"metric get","args":["proxy.process.http.incoming_requests"]}`, auth: "Bearer secret", code: http.StatusOK, output: "metric get proxy.process.http.incoming_requests\n"},
+		{name: "failing command", status: "3", body: `{"command":"config reload"}`, auth: "Bearer secret", code: http.StatusOK, exitCode: 3, output: "config reload\n"},
+		{name: "unauthorized", status: "0", body: `{"command":"config reload"}`, auth: "Bearer wrong", code: http.StatusUnauthorized},
+		{name: "not whitelisted", status: "0", body: `{"command":"server stop"}`, auth: "Bearer secret", code: http.StatusBadRequest},
+		{name: "malformed", status: "0", body: `{"command":`, auth: "Bearer secret", code: http.StatusBadRequest},
+	}
+	for _, test := range tests {
+		a := New(Opts{Secret: "secret", TrafficCtl: writeFakeTrafficCtl(t, test.status)})
+		r := httptest.NewRequest(http.MethodPost, tc.LogAgentTrafficCtlPath, strings.NewReader(test.body))
+		r.Header.Set("Authorization", test.auth)
+		w := httptest.NewRecorder()
+		a.ServeHTTP(w, r)
+		if w.Code != test.code {
+			t.Errorf("%s: expected status %d, got %d: %s", test.name, test.code, w.Code, w.Body.String())
+			continue
+		}
+		if test.code != http.StatusOK {
+			continue
+		}
+		result := tc.TrafficCtlResult{}
+		if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+			t.Errorf("%s: decoding result: %v", test.name, err)
+			continue
+		}
+		if result.ExitCode != test.exitCode || result.Output != test.output {
+			t.Errorf("%s: expected exit code %d and output '%s', got %d and '%s'", test.name, test.exitCode, test.output, result.ExitCode, result.Output)
+		}
+	}
+}
+
+func TestAgentTrafficCtlDisabled(t *testing.T) {
+	a := New(Opts{Secret: "secret"})
+	r := httptest.NewRequest(http.MethodPost, tc.LogAgentTrafficCtlPath, strings.NewReader(`{"command":"config reload"}`))
+	r.Header.Set("Authorization", "Bearer secret")
+	w := httptest.NewRecorder()
+	a.ServeHTTP(w, r)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status %d when traffic_ctl isn't configured, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	b := &limitedBuffer{max: 5}
+	for _, s := range []string{"abc", "def", "ghi"} {
+		if n, err := b.Write([]byte(s)); n != len(s) || err != nil {
+			t.Errorf("expected writes to always succeed, got %d %v", n, err)
+		}
+	}
+	if b.String() != "abcde" {
+		t.Errorf("expected 'abcde', actual: '%s'", b.String())
+	}
+}
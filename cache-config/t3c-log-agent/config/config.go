@@ -0,0 +1,198 @@
+package config
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/pborman/getopt/v2"
+)
+
+const AppName = "t3c-log-agent"
+
+const DefaultSecretFile = "/opt/trafficserver/etc/trafficserver/log-agent.secret"
+const DefaultAccessLog = "/opt/trafficserver/var/log/trafficserver/custom_ats_2.log"
+const DefaultDiagsLog = "/opt/trafficserver/var/log/trafficserver/diags.log"
+
+type Cfg struct {
+	LogLocationDebug string
+	LogLocationWarn  string
+	LogLocationError string
+	LogLocationInfo  string
+	// Port is the port on which the agent listens.
+	Port int
+	// Secret is the bearer token with which Traffic Ops authenticates, which
+	// must be the agent_secret of the log_tail section of its cdn.conf.
+	Secret string
+	// TLSCertFile and TLSKeyFile are the certificate and key with which the
+	// agent serves HTTPS. If they're empty, it serves HTTP.
+	TLSCertFile string
+	TLSKeyFile  string
+	// AccessLog is the path of the ATS access log.
+	AccessLog string
+	// DiagsLog is the path of the ATS diagnostic log.
+	DiagsLog string
+	// MaxSeconds is the longest a log may be tailed.
+	MaxSeconds int
+	// TrafficCtl is the path of traffic_ctl. If it's empty, traffic_ctl
+	// commands can't be run.
+	TrafficCtl string
+	// TrafficCtlTimeout is how long a traffic_ctl command may run.
+	TrafficCtlTimeout time.Duration
+	// CacheHitWindow is the length of the windows in which the cache hits
+	// and misses of the access log are counted. If it's 0, they aren't
+	// counted.
+	CacheHitWindow time.Duration
+	Version        string
+	GitRevision    string
+}
+
+func (cfg Cfg) AppVersion() string { return t3cutil.VersionStr(AppName, cfg.Version, cfg.GitRevision) }
+func (cfg Cfg) UserAgent() string  { return t3cutil.UserAgentStr(AppName, cfg.Version, cfg.GitRevision) }
+
+func (cfg Cfg) DebugLog() log.LogLocation   { return log.LogLocation(cfg.LogLocationDebug) }
+func (cfg Cfg) ErrorLog() log.LogLocation   { return log.LogLocation(cfg.LogLocationError) }
+func (cfg Cfg) InfoLog() log.LogLocation    { return log.LogLocation(cfg.LogLocationInfo) }
+func (cfg Cfg) WarningLog() log.LogLocation { return log.LogLocation(cfg.LogLocationWarn) }
+func (cfg Cfg) EventLog() log.LogLocation   { return log.LogLocation(log.LogLocationNull) } // event logging is not used.
+
+// Usage() writes command line options and usage to 'stderr'
+func Usage() {
+	getopt.PrintUsage(os.Stderr)
+	os.Exit(0)
+}
+
+// InitConfig() intializes the configuration variables and loggers.
+func InitConfig(appVersion string, gitRevision string) (Cfg, error) {
+	portPtr := getopt.IntLong("port", 'p', tc.DefaultLogAgentPort, "Port on which to listen")
+	secretFilePtr := getopt.StringLong("secret-file", 'S', DefaultSecretFile, "File containing the secret with which Traffic Ops authenticates")
+	tlsCertPtr := getopt.StringLong("tls-cert", 'C', "", "TLS certificate file. If given with --tls-key, HTTPS is served")
+	tlsKeyPtr := getopt.StringLong("tls-key", 'K', "", "TLS key file. If given with --tls-cert, HTTPS is served")
+	accessLogPtr := getopt.StringLong("access-log", 'a', DefaultAccessLog, "ATS access log")
+	diagsLogPtr := getopt.StringLong("diags-log", 'd', DefaultDiagsLog, "ATS diagnostic log")
+	maxSecondsPtr := getopt.IntLong("max-seconds", 'm', tc.DefaultServerLogTailMaxSeconds, "Longest a log may be tailed, in seconds")
+	trafficCtlPtr := getopt.StringLong("traffic-ctl", 'T', "", "Path of traffic_ctl, e.g. /opt/trafficserver/bin/traffic_ctl. If given, Traffic Ops may run whitelisted traffic_ctl commands. Default is not to allow commands")
+	trafficCtlTimeoutPtr := getopt.IntLong("traffic-ctl-timeout-seconds", 't', 30, "How long a traffic_ctl command may run, in seconds")
+	cacheHitWindowPtr := getopt.IntLong("cache-hit-window-seconds", 'w', 0, "Length of the windows in which cache hits and misses of the access log are counted for Traffic Ops, in seconds, from 60 to 3600. Default is not to count them")
+	helpPtr := getopt.BoolLong("help", 'h', "Print usage information and exit")
+	versionPtr := getopt.BoolLong("version", 'V', "Print the app version")
+	verbosePtr := getopt.CounterLong("verbose", 'v', `Log verbosity. Logging is output to stderr. By default, errors are logged. To log warnings, pass '-v'. To log info, pass '-vv'. To omit error logging, see '-s'`)
+	silentPtr := getopt.BoolLong("silent", 's', `Silent. Errors are not logged, and the 'verbose' flag is ignored. If a fatal error occurs, the return code will be non-zero but no text will be output to stderr`)
+
+	getopt.Parse()
+
+	if *helpPtr == true {
+		Usage()
+	} else if *versionPtr == true {
+		cfg := &Cfg{Version: appVersion, GitRevision: gitRevision}
+		fmt.Println(cfg.AppVersion())
+		os.Exit(0)
+	}
+
+	logLocationError := log.LogLocationStderr
+	logLocationWarn := log.LogLocationNull
+	logLocationInfo := log.LogLocationNull
+	logLocationDebug := log.LogLocationNull
+	if *silentPtr {
+		logLocationError = log.LogLocationNull
+	} else {
+		if *verbosePtr >= 1 {
+			logLocationWarn = log.LogLocationStderr
+		}
+		if *verbosePtr >= 2 {
+			logLocationInfo = log.LogLocationStderr
+			logLocationDebug = log.LogLocationStderr // t3c only has 3 verbosity options: none (-s), error (default or --verbose=0), warning (-v), and info (-vv). Any code calling log.Debug is treated as Info.
+		}
+	}
+
+	if *verbosePtr > 2 {
+		return Cfg{}, errors.New("Too many verbose options. The maximum log verbosity level is 2 (-vv or --verbose=2) for errors (0), warnings (1), and info (2)")
+	}
+
+	if *portPtr <= 0 || *portPtr > 65535 {
+		return Cfg{}, errors.New("port must be from 1 to 65535")
+	}
+	if *maxSecondsPtr <= 0 || *trafficCtlTimeoutPtr <= 0 {
+		return Cfg{}, errors.New("max seconds and traffic_ctl timeout must be positive")
+	}
+	if *cacheHitWindowPtr != 0 && (*cacheHitWindowPtr < 60 || *cacheHitWindowPtr > 3600) {
+		return Cfg{}, errors.New("cache hit window seconds must be from 60 to 3600")
+	}
+	if (*tlsCertPtr == "") != (*tlsKeyPtr == "") {
+		return Cfg{}, errors.New("TLS certificate and key must be given together")
+	}
+
+	secret, err := ioutil.ReadFile(*secretFilePtr)
+	if err != nil {
+		return Cfg{}, errors.New("reading secret file: " + err.Error())
+	}
+	if len(strings.TrimSpace(string(secret))) == 0 {
+		return Cfg{}, errors.New("secret file '" + *secretFilePtr + "' is empty")
+	}
+
+	cfg := Cfg{
+		LogLocationDebug:  logLocationDebug,
+		LogLocationError:  logLocationError,
+		LogLocationInfo:   logLocationInfo,
+		LogLocationWarn:   logLocationWarn,
+		Port:              *portPtr,
+		Secret:            strings.TrimSpace(string(secret)),
+		TLSCertFile:       *tlsCertPtr,
+		TLSKeyFile:        *tlsKeyPtr,
+		AccessLog:         *accessLogPtr,
+		DiagsLog:          *diagsLogPtr,
+		MaxSeconds:        *maxSecondsPtr,
+		TrafficCtl:        *trafficCtlPtr,
+		TrafficCtlTimeout: time.Second * time.Duration(*trafficCtlTimeoutPtr),
+		CacheHitWindow:    time.Second * time.Duration(*cacheHitWindowPtr),
+		Version:           appVersion,
+		GitRevision:       gitRevision,
+	}
+
+	if err := log.InitCfg(cfg); err != nil {
+		return Cfg{}, errors.New("initializing loggers: " + err.Error())
+	}
+
+	return cfg, nil
+}
+
+func (cfg Cfg) PrintConfig() {
+	log.Debugf("LogLocationDebug: %s\n", cfg.LogLocationDebug)
+	log.Debugf("LogLocationError: %s\n", cfg.LogLocationError)
+	log.Debugf("LogLocationInfo: %s\n", cfg.LogLocationInfo)
+	log.Debugf("LogLocationWarn: %s\n", cfg.LogLocationWarn)
+	log.Debugf("Port: %d\n", cfg.Port)
+	log.Debugf("TLSCertFile: %s\n", cfg.TLSCertFile)
+	log.Debugf("TLSKeyFile: %s\n", cfg.TLSKeyFile)
+	log.Debugf("AccessLog: %s\n", cfg.AccessLog)
+	log.Debugf("DiagsLog: %s\n", cfg.DiagsLog)
+	log.Debugf("MaxSeconds: %d\n", cfg.MaxSeconds)
+	log.Debugf("TrafficCtl: %s\n", cfg.TrafficCtl)
+	log.Debugf("TrafficCtlTimeout: %s\n", cfg.TrafficCtlTimeout)
+	log.Debugf("CacheHitWindow: %s\n", cfg.CacheHitWindow)
+}
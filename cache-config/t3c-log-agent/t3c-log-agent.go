@@ -0,0 +1,103 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-log-agent/agent"
+	"github.com/apache/trafficcontrol/cache-config/t3c-log-agent/config"
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// Version is the application version.
+// This is overwritten by the build with the current project version.
+var Version = "0.4"
+
+// GitRevision is the git revision the application was built from.
+// This is overwritten by the build with the current project version.
+var GitRevision = "nogit"
+
+const ExitCodeSuccess = 0
+const ExitCodeConfigError = 1
+const ExitCodeServeError = 2
+
+func main() {
+	cfg, err := config.InitConfig(Version, GitRevision)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err.Error())
+		os.Exit(ExitCodeConfigError)
+	}
+	log.Infoln("configuration initialized")
+	cfg.PrintConfig()
+
+	var hits *agent.HitCounter
+	if cfg.CacheHitWindow > 0 {
+		hits = agent.NewHitCounter(cfg.CacheHitWindow)
+		go func() {
+			err := hits.Run(cfg.AccessLog)
+			log.Errorf("counting cache hits: %s\n", err.Error())
+			os.Exit(ExitCodeServeError)
+		}()
+	}
+
+	// Tails are streamed for up to their requested duration, so the server
+	// has no write timeout.
+	srv := &http.Server{
+		Addr: ":" + strconv.Itoa(cfg.Port),
+		Handler: agent.New(agent.Opts{
+			Secret: cfg.Secret,
+			Logs: map[string]string{
+				tc.ServerLogAccess: cfg.AccessLog,
+				tc.ServerLogDiags:  cfg.DiagsLog,
+			},
+			MaxSeconds:        cfg.MaxSeconds,
+			TrafficCtl:        cfg.TrafficCtl,
+			TrafficCtlTimeout: cfg.TrafficCtlTimeout,
+			HitCounter:        hits,
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       time.Minute,
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-sigs
+		log.Infof("received %s, exiting\n", sig)
+		os.Exit(ExitCodeSuccess)
+	}()
+
+	log.Infof("listening on port %d\n", cfg.Port)
+	if cfg.TLSCertFile != "" {
+		err = srv.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile)
+	} else {
+		err = srv.ListenAndServe()
+	}
+	log.Errorf("serving: %s\n", err.Error())
+	os.Exit(ExitCodeServeError)
+}
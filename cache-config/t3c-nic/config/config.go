@@ -0,0 +1,245 @@
+package config
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-nic/nic"
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/pborman/getopt/v2"
+)
+
+const AppName = "t3c-nic"
+
+const DefaultStateFile = "/var/lib/trafficcontrol-cache-config/nic-counters.json"
+
+const DefaultErrorCheck = "NICE"
+const DefaultDropCheck = "NICD"
+const DefaultLinkFlapCheck = "LNKF"
+const DefaultSpeedCheck = "LSPD"
+
+type Cfg struct {
+	LogLocationDebug string
+	LogLocationWarn  string
+	LogLocationError string
+	LogLocationInfo  string
+	// Interfaces is the network interfaces to report on. If empty, all
+	// physical interfaces are reported on.
+	Interfaces  []string
+	SysClassNet string
+	StateFile   string
+	DryRun      bool
+	// ErrorCheck, DropCheck, LinkFlapCheck, and SpeedCheck are the Server
+	// Check short names to report to. An empty name isn't reported.
+	ErrorCheck    string
+	DropCheck     string
+	LinkFlapCheck string
+	SpeedCheck    string
+	t3cutil.TCCfg
+	Version     string
+	GitRevision string
+}
+
+func (cfg Cfg) AppVersion() string { return t3cutil.VersionStr(AppName, cfg.Version, cfg.GitRevision) }
+func (cfg Cfg) UserAgent() string  { return t3cutil.UserAgentStr(AppName, cfg.Version, cfg.GitRevision) }
+
+func (cfg Cfg) DebugLog() log.LogLocation   { return log.LogLocation(cfg.LogLocationDebug) }
+func (cfg Cfg) ErrorLog() log.LogLocation   { return log.LogLocation(cfg.LogLocationError) }
+func (cfg Cfg) InfoLog() log.LogLocation    { return log.LogLocation(cfg.LogLocationInfo) }
+func (cfg Cfg) WarningLog() log.LogLocation { return log.LogLocation(cfg.LogLocationWarn) }
+func (cfg Cfg) EventLog() log.LogLocation   { return log.LogLocation(log.LogLocationNull) } // event logging is not used.
+
+// Usage() writes command line options and usage to 'stderr'
+func Usage() {
+	getopt.PrintUsage(os.Stderr)
+	os.Exit(0)
+}
+
+// InitConfig() intializes the configuration variables and loggers.
+func InitConfig(appVersion string, gitRevision string) (Cfg, error) {
+	cacheHostNamePtr := getopt.StringLong("cache-host-name", 'H', "", "Host name of the cache to report for. Must be the server host name in Traffic Ops, not a URL, and not the FQDN")
+	interfacesPtr := getopt.StringLong("interfaces", 'i', "", "Comma-separated network interfaces to report on. Default is all physical interfaces")
+	sysClassNetPtr := getopt.StringLong("sys-class-net", 'n', nic.DefaultSysClassNet, "Directory the kernel exposes network interfaces in")
+	stateFilePtr := getopt.StringLong("state-file", 'f', DefaultStateFile, "File to save counters in between runs")
+	dryRunPtr := getopt.BoolLong("dry-run", 'd', "[true | false] print the report without sending it to Traffic Ops or saving counters")
+	errorCheckPtr := getopt.StringLong("error-check", 0, DefaultErrorCheck, "Server Check short name to report interface errors to. Empty to not report")
+	dropCheckPtr := getopt.StringLong("drop-check", 0, DefaultDropCheck, "Server Check short name to report dropped packets to. Empty to not report")
+	linkFlapCheckPtr := getopt.StringLong("link-flap-check", 0, DefaultLinkFlapCheck, "Server Check short name to report link flaps to. Empty to not report")
+	speedCheckPtr := getopt.StringLong("speed-check", 0, DefaultSpeedCheck, "Server Check short name to report the lowest link speed to. Empty to not report")
+	toInsecurePtr := getopt.BoolLong("traffic-ops-insecure", 'I', "[true | false] ignore certificate errors from Traffic Ops")
+	toTimeoutMSPtr := getopt.IntLong("traffic-ops-timeout-milliseconds", 't', 30000, "Timeout in milli-seconds for Traffic Ops requests, default is 30000")
+	toURLPtr := getopt.StringLong("traffic-ops-url", 'u', "", "Traffic Ops URL. Must be the full URL, including the scheme. May be a comma-delimited list of URLs, in order of preference, to fail over between. Required unless dry-run. May also be set with the environment variable TO_URL")
+	toUserPtr := getopt.StringLong("traffic-ops-user", 'U', "", "Traffic Ops username. Required unless dry-run. May also be set with the environment variable TO_USER")
+	toPassPtr := getopt.StringLong("traffic-ops-password", 'P', "", "Traffic Ops password. Required unless dry-run. May also be set with the environment variable TO_PASS")
+	toTokenPtr := getopt.StringLong("traffic-ops-token", 0, "", "Traffic Ops authentication token, used instead of the username and password. May also be set with the environment variable TO_TOKEN")
+	toClientCertPtr := getopt.StringLong("traffic-ops-client-cert", 0, "", "Path to a TLS client certificate with which to authenticate with Traffic Ops, instead of the username and password. Requires traffic-ops-client-key")
+	toClientKeyPtr := getopt.StringLong("traffic-ops-client-key", 0, "", "Path to the private key of the traffic-ops-client-cert certificate")
+	helpPtr := getopt.BoolLong("help", 'h', "Print usage information and exit")
+	versionPtr := getopt.BoolLong("version", 'V', "Print the app version")
+	verbosePtr := getopt.CounterLong("verbose", 'v', `Log verbosity. Logging is output to stderr. By default, errors are logged. To log warnings, pass '-v'. To log info, pass '-vv'. To omit error logging, see '-s'`)
+	silentPtr := getopt.BoolLong("silent", 's', `Silent. Errors are not logged, and the 'verbose' flag is ignored. If a fatal error occurs, the return code will be non-zero but no text will be output to stderr`)
+
+	getopt.Parse()
+
+	if *helpPtr == true {
+		Usage()
+	} else if *versionPtr == true {
+		cfg := &Cfg{Version: appVersion, GitRevision: gitRevision}
+		fmt.Println(cfg.AppVersion())
+		os.Exit(0)
+	}
+
+	logLocationError := log.LogLocationStderr
+	logLocationWarn := log.LogLocationNull
+	logLocationInfo := log.LogLocationNull
+	logLocationDebug := log.LogLocationNull
+	if *silentPtr {
+		logLocationError = log.LogLocationNull
+	} else {
+		if *verbosePtr >= 1 {
+			logLocationWarn = log.LogLocationStderr
+		}
+		if *verbosePtr >= 2 {
+			logLocationInfo = log.LogLocationStderr
+			logLocationDebug = log.LogLocationStderr // t3c only has 3 verbosity options: none (-s), error (default or --verbose=0), warning (-v), and info (-vv). Any code calling log.Debug is treated as Info.
+		}
+	}
+
+	if *verbosePtr > 2 {
+		return Cfg{}, errors.New("Too many verbose options. The maximum log verbosity level is 2 (-vv or --verbose=2) for errors (0), warnings (1), and info (2)")
+	}
+
+	interfaces := []string{}
+	for _, iface := range strings.Split(*interfacesPtr, ",") {
+		if iface = strings.TrimSpace(iface); iface != "" {
+			interfaces = append(interfaces, iface)
+		}
+	}
+
+	toTimeoutMS := time.Millisecond * time.Duration(*toTimeoutMSPtr)
+	toURL := *toURLPtr
+	toUser := *toUserPtr
+	toPass := *toPassPtr
+
+	urlSourceStr := "argument" // for error messages
+	if toURL == "" {
+		urlSourceStr = "environment variable"
+		toURL = os.Getenv("TO_URL")
+	}
+	if toUser == "" {
+		toUser = os.Getenv("TO_USER")
+	}
+	if *toPassPtr == "" {
+		toPass = os.Getenv("TO_PASS")
+	}
+	toToken := *toTokenPtr
+	if toToken == "" {
+		toToken = os.Getenv("TO_TOKEN")
+	}
+
+	var toURLParsed *url.URL
+	var toURLs []*url.URL
+	if !*dryRunPtr {
+		var err error
+		toURLs, err = t3cutil.ParseURLs(toURL)
+		if err != nil {
+			return Cfg{}, errors.New("parsing Traffic Ops URL from " + urlSourceStr + " '" + toURL + "': " + err.Error())
+		}
+		toURLParsed = toURLs[0]
+	}
+
+	var cacheHostName string
+	if len(*cacheHostNamePtr) > 0 {
+		cacheHostName = *cacheHostNamePtr
+	} else {
+		var err error
+		cacheHostName, err = os.Hostname()
+		if err != nil {
+			return Cfg{}, errors.New("could not get the OS hostname, please supply a hostname: " + err.Error())
+		}
+	}
+
+	cfg := Cfg{
+		LogLocationDebug: logLocationDebug,
+		LogLocationError: logLocationError,
+		LogLocationInfo:  logLocationInfo,
+		LogLocationWarn:  logLocationWarn,
+		Interfaces:       interfaces,
+		SysClassNet:      *sysClassNetPtr,
+		StateFile:        *stateFilePtr,
+		DryRun:           *dryRunPtr,
+		ErrorCheck:       *errorCheckPtr,
+		DropCheck:        *dropCheckPtr,
+		LinkFlapCheck:    *linkFlapCheckPtr,
+		SpeedCheck:       *speedCheckPtr,
+		TCCfg: t3cutil.TCCfg{
+			CacheHostName:    cacheHostName,
+			TOInsecure:       *toInsecurePtr,
+			TOTimeoutMS:      toTimeoutMS,
+			TOUser:           toUser,
+			TOPass:           toPass,
+			TOToken:          toToken,
+			TOClientCertFile: *toClientCertPtr,
+			TOClientKeyFile:  *toClientKeyPtr,
+			TOURL:            toURLParsed,
+			TOURLs:           toURLs,
+			T3CVersion:       gitRevision,
+		},
+		Version:     appVersion,
+		GitRevision: gitRevision,
+	}
+
+	if err := log.InitCfg(cfg); err != nil {
+		return Cfg{}, errors.New("initializing loggers: " + err.Error())
+	}
+
+	return cfg, nil
+}
+
+func (cfg Cfg) PrintConfig() {
+	log.Debugf("LogLocationDebug: %s\n", cfg.LogLocationDebug)
+	log.Debugf("LogLocationError: %s\n", cfg.LogLocationError)
+	log.Debugf("LogLocationInfo: %s\n", cfg.LogLocationInfo)
+	log.Debugf("LogLocationWarn: %s\n", cfg.LogLocationWarn)
+	log.Debugf("Interfaces: %v\n", cfg.Interfaces)
+	log.Debugf("SysClassNet: %s\n", cfg.SysClassNet)
+	log.Debugf("StateFile: %s\n", cfg.StateFile)
+	log.Debugf("DryRun: %v\n", cfg.DryRun)
+	log.Debugf("ErrorCheck: %s\n", cfg.ErrorCheck)
+	log.Debugf("DropCheck: %s\n", cfg.DropCheck)
+	log.Debugf("LinkFlapCheck: %s\n", cfg.LinkFlapCheck)
+	log.Debugf("SpeedCheck: %s\n", cfg.SpeedCheck)
+	log.Debugf("CacheHostName: %s\n", cfg.CacheHostName)
+	log.Debugf("TOInsecure: %v\n", cfg.TOInsecure)
+	log.Debugf("TOTimeoutMS: %s\n", cfg.TOTimeoutMS)
+	log.Debugf("TOUser: %s\n", cfg.TOUser)
+	log.Debugf("TOPass: xxxxxx\n")
+	log.Debugf("TOToken: xxxxxx\n")
+	log.Debugf("TOClientCertFile: %s\n", cfg.TOClientCertFile)
+	log.Debugf("TOClientKeyFile: %s\n", cfg.TOClientKeyFile)
+	log.Debugf("TOURL: %s\n", cfg.TOURL)
+}
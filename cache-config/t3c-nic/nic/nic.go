@@ -0,0 +1,277 @@
+// Package nic reads network interface error counters and link state from the
+// kernel, and reports how they changed since they were last read.
+package nic
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultSysClassNet is the directory in which the kernel exposes network
+// interfaces.
+const DefaultSysClassNet = "/sys/class/net"
+
+// UnknownSpeed is the speed reported for a link whose speed can't be
+// determined, which is usually because it's down.
+const UnknownSpeed = -1
+
+// Counters are the error counters and link state of a network interface.
+type Counters struct {
+	RxErrors       uint64 `json:"rxErrors"`
+	TxErrors       uint64 `json:"txErrors"`
+	RxDropped      uint64 `json:"rxDropped"`
+	TxDropped      uint64 `json:"txDropped"`
+	RxCRCErrors    uint64 `json:"rxCrcErrors"`
+	CarrierChanges uint64 `json:"carrierChanges"`
+	// SpeedMbps is the link speed in megabits per second, or UnknownSpeed.
+	SpeedMbps int64 `json:"speedMbps"`
+}
+
+// State is the Counters of a set of network interfaces at a point in time. It
+// is persisted between runs, so counters can be compared to the last run.
+type State struct {
+	Time       time.Time           `json:"time"`
+	Interfaces map[string]Counters `json:"interfaces"`
+}
+
+// InterfaceReport is how the counters of a single network interface changed
+// since they were last read.
+type InterfaceReport struct {
+	Name        string `json:"name"`
+	Errors      uint64 `json:"errors"`
+	CRCErrors   uint64 `json:"crcErrors"`
+	Drops       uint64 `json:"drops"`
+	LinkFlaps   uint64 `json:"linkFlaps"`
+	SpeedMbps   int64  `json:"speedMbps"`
+	FirstReport bool   `json:"firstReport"`
+}
+
+// Report is how the counters of a cache server's network interfaces changed
+// since they were last read.
+type Report struct {
+	Interfaces []InterfaceReport `json:"interfaces"`
+	// Errors is the total of all interfaces' receive and transmit errors.
+	Errors uint64 `json:"errors"`
+	// Drops is the total of all interfaces' dropped received and transmitted
+	// packets.
+	Drops uint64 `json:"drops"`
+	// LinkFlaps is the total number of times any interface's carrier went up
+	// or down.
+	LinkFlaps uint64 `json:"linkFlaps"`
+	// MinSpeedMbps is the lowest link speed of all interfaces, or
+	// UnknownSpeed if any interface's speed is unknown.
+	MinSpeedMbps int64 `json:"minSpeedMbps"`
+}
+
+// PhysicalInterfaces returns the names of the network interfaces in
+// sysClassNet that are backed by a device, which excludes virtual interfaces
+// like loopback, bonds, and bridges.
+func PhysicalInterfaces(sysClassNet string) ([]string, error) {
+	entries, err := ioutil.ReadDir(sysClassNet)
+	if err != nil {
+		return nil, errors.New("reading network interfaces from '" + sysClassNet + "': " + err.Error())
+	}
+	ifaces := []string{}
+	for _, entry := range entries {
+		if _, err := os.Stat(filepath.Join(sysClassNet, entry.Name(), "device")); err != nil {
+			continue
+		}
+		ifaces = append(ifaces, entry.Name())
+	}
+	sort.Strings(ifaces)
+	return ifaces, nil
+}
+
+// ReadCounters reads the Counters of the given network interface from
+// sysClassNet.
+func ReadCounters(sysClassNet string, iface string) (Counters, error) {
+	dir := filepath.Join(sysClassNet, iface)
+	if _, err := os.Stat(dir); err != nil {
+		return Counters{}, errors.New("network interface '" + iface + "': " + err.Error())
+	}
+
+	counters := Counters{}
+	stats := []struct {
+		file string
+		val  *uint64
+	}{
+		{file: "statistics/rx_errors", val: &counters.RxErrors},
+		{file: "statistics/tx_errors", val: &counters.TxErrors},
+		{file: "statistics/rx_dropped", val: &counters.RxDropped},
+		{file: "statistics/tx_dropped", val: &counters.TxDropped},
+		{file: "statistics/rx_crc_errors", val: &counters.RxCRCErrors},
+		{file: "carrier_changes", val: &counters.CarrierChanges},
+	}
+	for _, stat := range stats {
+		val, err := readUint(filepath.Join(dir, stat.file))
+		if err != nil {
+			return Counters{}, errors.New("network interface '" + iface + "': " + err.Error())
+		}
+		*stat.val = val
+	}
+
+	// The kernel fails to read the speed of a link that's down, and drivers
+	// report -1 or garbage if they don't know it.
+	counters.SpeedMbps = UnknownSpeed
+	if raw, err := ioutil.ReadFile(filepath.Join(dir, "speed")); err == nil {
+		if speed, err := strconv.ParseInt(strings.TrimSpace(string(raw)), 10, 64); err == nil && speed > 0 && speed < math.MaxUint32 {
+			counters.SpeedMbps = speed
+		}
+	}
+	return counters, nil
+}
+
+// readUint reads an unsigned integer counter from the given file. Counters
+// the driver doesn't provide are treated as zero.
+func readUint(path string) (uint64, error) {
+	raw, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.New("reading '" + path + "': " + err.Error())
+	}
+	val, err := strconv.ParseUint(strings.TrimSpace(string(raw)), 10, 64)
+	if err != nil {
+		return 0, errors.New("parsing '" + path + "': " + err.Error())
+	}
+	return val, nil
+}
+
+// ReadAll reads the Counters of all the given network interfaces from
+// sysClassNet.
+func ReadAll(sysClassNet string, ifaces []string) (map[string]Counters, error) {
+	all := make(map[string]Counters, len(ifaces))
+	for _, iface := range ifaces {
+		counters, err := ReadCounters(sysClassNet, iface)
+		if err != nil {
+			return nil, err
+		}
+		all[iface] = counters
+	}
+	return all, nil
+}
+
+// LoadState loads the State saved at the given path. If no State has been
+// saved yet, it returns an empty State and no error.
+func LoadState(path string) (State, error) {
+	raw, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return State{Interfaces: map[string]Counters{}}, nil
+	} else if err != nil {
+		return State{}, errors.New("reading state file '" + path + "': " + err.Error())
+	}
+	st := State{}
+	if err := json.Unmarshal(raw, &st); err != nil {
+		return State{}, errors.New("decoding state file '" + path + "': " + err.Error())
+	}
+	if st.Interfaces == nil {
+		st.Interfaces = map[string]Counters{}
+	}
+	return st, nil
+}
+
+// SaveState saves the given State to the given path. The State is written to
+// a temporary file which is then renamed, so a failed write never leaves a
+// corrupt State behind.
+func SaveState(path string, st State) error {
+	raw, err := json.Marshal(st)
+	if err != nil {
+		return errors.New("encoding state: " + err.Error())
+	}
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, raw, 0644); err != nil {
+		return errors.New("writing state file '" + tmpPath + "': " + err.Error())
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.New("renaming state file '" + tmpPath + "' to '" + path + "': " + err.Error())
+	}
+	return nil
+}
+
+// MakeReport creates a Report of how the counters in cur changed from those
+// in prev.
+//
+// An interface that isn't in prev reports no change, because there's nothing
+// to compare it to. A counter that went down, which happens when a driver is
+// reloaded or a machine rebooted, is treated as having been reset to zero.
+func MakeReport(prev map[string]Counters, cur map[string]Counters) Report {
+	names := make([]string, 0, len(cur))
+	for name := range cur {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	report := Report{Interfaces: []InterfaceReport{}}
+	for _, name := range names {
+		counters := cur[name]
+		ifaceReport := InterfaceReport{Name: name, SpeedMbps: counters.SpeedMbps}
+		if prevCounters, ok := prev[name]; ok {
+			ifaceReport.Errors = delta(prevCounters.RxErrors, counters.RxErrors) + delta(prevCounters.TxErrors, counters.TxErrors)
+			ifaceReport.CRCErrors = delta(prevCounters.RxCRCErrors, counters.RxCRCErrors)
+			ifaceReport.Drops = delta(prevCounters.RxDropped, counters.RxDropped) + delta(prevCounters.TxDropped, counters.TxDropped)
+			ifaceReport.LinkFlaps = delta(prevCounters.CarrierChanges, counters.CarrierChanges)
+		} else {
+			ifaceReport.FirstReport = true
+		}
+		report.Interfaces = append(report.Interfaces, ifaceReport)
+
+		report.Errors += ifaceReport.Errors
+		report.Drops += ifaceReport.Drops
+		report.LinkFlaps += ifaceReport.LinkFlaps
+	}
+
+	report.MinSpeedMbps = UnknownSpeed
+	for i, ifaceReport := range report.Interfaces {
+		if ifaceReport.SpeedMbps == UnknownSpeed {
+			report.MinSpeedMbps = UnknownSpeed
+			break
+		}
+		if i == 0 || ifaceReport.SpeedMbps < report.MinSpeedMbps {
+			report.MinSpeedMbps = ifaceReport.SpeedMbps
+		}
+	}
+	return report
+}
+
+func delta(prev uint64, cur uint64) uint64 {
+	if cur < prev {
+		return cur
+	}
+	return cur - prev
+}
+
+// CheckValue converts a counter to a Server Check value, which the Traffic
+// Ops database stores as a 32-bit integer.
+func CheckValue(val uint64) int {
+	if val > math.MaxInt32 {
+		return math.MaxInt32
+	}
+	return int(val)
+}
@@ -0,0 +1,200 @@
+package nic
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeSysFile(t *testing.T, path string, val string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("creating directory for '%s': %v", path, err)
+	}
+	if err := ioutil.WriteFile(path, []byte(val+"\n"), 0644); err != nil {
+		t.Fatalf("writing '%s': %v", path, err)
+	}
+}
+
+func makeSysClassNet(t *testing.T) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "t3c-nic")
+	if err != nil {
+		t.Fatalf("creating temp dir: %v", err)
+	}
+
+	eth0 := filepath.Join(dir, "eth0")
+	writeSysFile(t, filepath.Join(eth0, "device", "vendor"), "0x8086")
+	writeSysFile(t, filepath.Join(eth0, "statistics", "rx_errors"), "12")
+	writeSysFile(t, filepath.Join(eth0, "statistics", "tx_errors"), "3")
+	writeSysFile(t, filepath.Join(eth0, "statistics", "rx_dropped"), "40")
+	writeSysFile(t, filepath.Join(eth0, "statistics", "tx_dropped"), "0")
+	writeSysFile(t, filepath.Join(eth0, "statistics", "rx_crc_errors"), "11")
+	writeSysFile(t, filepath.Join(eth0, "carrier_changes"), "4")
+	writeSysFile(t, filepath.Join(eth0, "speed"), "25000")
+
+	// no device, carrier_changes, or rx_crc_errors, and unknown speed
+	lo := filepath.Join(dir, "lo")
+	writeSysFile(t, filepath.Join(lo, "statistics", "rx_errors"), "0")
+	writeSysFile(t, filepath.Join(lo, "statistics", "tx_errors"), "0")
+	writeSysFile(t, filepath.Join(lo, "statistics", "rx_dropped"), "0")
+	writeSysFile(t, filepath.Join(lo, "statistics", "tx_dropped"), "0")
+	writeSysFile(t, filepath.Join(lo, "speed"), "-1")
+
+	return dir
+}
+
+func TestPhysicalInterfaces(t *testing.T) {
+	dir := makeSysClassNet(t)
+	defer os.RemoveAll(dir)
+
+	ifaces, err := PhysicalInterfaces(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(ifaces, []string{"eth0"}) {
+		t.Errorf("expected physical interfaces [eth0], actual: %v", ifaces)
+	}
+}
+
+func TestReadCounters(t *testing.T) {
+	dir := makeSysClassNet(t)
+	defer os.RemoveAll(dir)
+
+	counters, err := ReadAll(dir, []string{"eth0", "lo"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[string]Counters{
+		"eth0": {
+			RxErrors:       12,
+			TxErrors:       3,
+			RxDropped:      40,
+			RxCRCErrors:    11,
+			CarrierChanges: 4,
+			SpeedMbps:      25000,
+		},
+		"lo": {
+			SpeedMbps: UnknownSpeed,
+		},
+	}
+	if !reflect.DeepEqual(counters, expected) {
+		t.Errorf("expected counters %+v, actual: %+v", expected, counters)
+	}
+
+	if _, err := ReadCounters(dir, "eth1"); err == nil {
+		t.Error("expected an error reading the counters of a nonexistent interface, actual: nil")
+	}
+
+	writeSysFile(t, filepath.Join(dir, "eth0", "statistics", "rx_errors"), "lots")
+	if _, err := ReadCounters(dir, "eth0"); err == nil {
+		t.Error("expected an error reading a malformed counter, actual: nil")
+	}
+}
+
+func TestMakeReport(t *testing.T) {
+	prev := map[string]Counters{
+		"eth0": {RxErrors: 10, TxErrors: 1, RxDropped: 5, RxCRCErrors: 9, CarrierChanges: 2, SpeedMbps: 25000},
+		"eth1": {RxErrors: 500, TxDropped: 7, CarrierChanges: 8, SpeedMbps: 25000},
+	}
+	cur := map[string]Counters{
+		"eth0": {RxErrors: 15, TxErrors: 2, RxDropped: 5, RxCRCErrors: 13, CarrierChanges: 6, SpeedMbps: 10000},
+		// counters were reset
+		"eth1": {RxErrors: 3, TxDropped: 1, CarrierChanges: 1, SpeedMbps: 25000},
+		"eth2": {RxErrors: 100, SpeedMbps: 25000},
+	}
+
+	report := MakeReport(prev, cur)
+	expected := Report{
+		Interfaces: []InterfaceReport{
+			{Name: "eth0", Errors: 6, CRCErrors: 4, LinkFlaps: 4, SpeedMbps: 10000},
+			{Name: "eth1", Errors: 3, Drops: 1, LinkFlaps: 1, SpeedMbps: 25000},
+			{Name: "eth2", SpeedMbps: 25000, FirstReport: true},
+		},
+		Errors:       9,
+		Drops:        1,
+		LinkFlaps:    5,
+		MinSpeedMbps: 10000,
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("expected report %+v, actual: %+v", expected, report)
+	}
+
+	cur["eth2"] = Counters{SpeedMbps: UnknownSpeed}
+	if report := MakeReport(prev, cur); report.MinSpeedMbps != UnknownSpeed {
+		t.Errorf("expected minimum speed %d when an interface's speed is unknown, actual: %d", UnknownSpeed, report.MinSpeedMbps)
+	}
+
+	if report := MakeReport(prev, map[string]Counters{}); report.MinSpeedMbps != UnknownSpeed || len(report.Interfaces) != 0 {
+		t.Errorf("expected an empty report with unknown speed for no interfaces, actual: %+v", report)
+	}
+}
+
+func TestState(t *testing.T) {
+	dir, err := ioutil.TempDir("", "t3c-nic-state")
+	if err != nil {
+		t.Fatalf("creating temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "nic-counters.json")
+
+	st, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("expected no error loading a nonexistent state file, actual: %v", err)
+	}
+	if st.Interfaces == nil || len(st.Interfaces) != 0 {
+		t.Errorf("expected empty state, actual: %+v", st)
+	}
+
+	expected := State{
+		Time:       time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC),
+		Interfaces: map[string]Counters{"eth0": {RxErrors: 1, SpeedMbps: 10000}},
+	}
+	if err := SaveState(path, expected); err != nil {
+		t.Fatalf("unexpected error saving state: %v", err)
+	}
+	st, err = LoadState(path)
+	if err != nil {
+		t.Fatalf("unexpected error loading state: %v", err)
+	}
+	if !reflect.DeepEqual(st, expected) {
+		t.Errorf("expected state %+v, actual: %+v", expected, st)
+	}
+
+	writeSysFile(t, path, "{")
+	if _, err := LoadState(path); err == nil {
+		t.Error("expected an error loading a malformed state file, actual: nil")
+	}
+}
+
+func TestCheckValue(t *testing.T) {
+	if val := CheckValue(42); val != 42 {
+		t.Errorf("expected 42, actual: %d", val)
+	}
+	if val := CheckValue(math.MaxUint64); val != math.MaxInt32 {
+		t.Errorf("expected %d, actual: %d", math.MaxInt32, val)
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-nic/config"
+	"github.com/apache/trafficcontrol/cache-config/t3c-nic/nic"
+	"github.com/apache/trafficcontrol/cache-config/t3cutil/toreq"
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// Version is the application version.
+// This is overwritten by the build with the current project version.
+var Version = "0.4"
+
+// GitRevision is the git revision the application was built from.
+// This is overwritten by the build with the current project version.
+var GitRevision = "nogit"
+
+const ExitCodeSuccess = 0
+const ExitCodeConfigError = 1
+const ExitCodeReadError = 2
+const ExitCodeStateError = 3
+const ExitCodeTOError = 4
+
+func main() {
+	cfg, err := config.InitConfig(Version, GitRevision)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err.Error())
+		os.Exit(ExitCodeConfigError)
+	}
+	log.Infoln("configuration initialized")
+	cfg.PrintConfig()
+
+	ifaces := cfg.Interfaces
+	if len(ifaces) == 0 {
+		if ifaces, err = nic.PhysicalInterfaces(cfg.SysClassNet); err != nil {
+			log.Errorf("getting physical network interfaces: %s\n", err.Error())
+			os.Exit(ExitCodeReadError)
+		}
+		if len(ifaces) == 0 {
+			log.Warnf("no physical network interfaces found in '%s'\n", cfg.SysClassNet)
+		}
+	}
+
+	counters, err := nic.ReadAll(cfg.SysClassNet, ifaces)
+	if err != nil {
+		log.Errorf("reading network interface counters: %s\n", err.Error())
+		os.Exit(ExitCodeReadError)
+	}
+	now := time.Now()
+
+	prevState, err := nic.LoadState(cfg.StateFile)
+	if err != nil {
+		log.Errorf("loading previous network interface counters: %s\n", err.Error())
+		os.Exit(ExitCodeStateError)
+	}
+
+	report := nic.MakeReport(prevState.Interfaces, counters)
+	if err := json.NewEncoder(os.Stdout).Encode(report); err != nil {
+		log.Errorf("writing report: %s\n", err.Error())
+	}
+
+	if cfg.DryRun {
+		log.Infoln("dry run, not reporting to Traffic Ops")
+		os.Exit(ExitCodeSuccess)
+	}
+
+	cfg.TCCfg.TOClient, err = toreq.NewFailover(
+		cfg.TOURLs,
+		cfg.TOCredentials(),
+		cfg.TOInsecure,
+		cfg.TOTimeoutMS,
+		cfg.UserAgent(),
+		cfg.NumRetries,
+	)
+	if err != nil {
+		log.Errorf("%s\n", err)
+		os.Exit(ExitCodeTOError)
+	}
+	if cfg.TCCfg.TOClient.FellBack() {
+		log.Warnln("Traffic Ops does not support the latest version supported by this app! Falling back to previous major Traffic Ops API version!")
+	}
+
+	checks := []struct {
+		name  string
+		value int
+	}{
+		{name: cfg.ErrorCheck, value: nic.CheckValue(report.Errors)},
+		{name: cfg.DropCheck, value: nic.CheckValue(report.Drops)},
+		{name: cfg.LinkFlapCheck, value: nic.CheckValue(report.LinkFlaps)},
+		{name: cfg.SpeedCheck, value: int(report.MinSpeedMbps)},
+	}
+	for _, check := range checks {
+		if check.name == "" {
+			continue
+		}
+		if _, err := cfg.TCCfg.TOClient.SetServerCheck(tc.CacheName(cfg.CacheHostName), check.name, check.value); err != nil {
+			log.Errorf("reporting Server Check '%s': %s\n", check.name, err.Error())
+			os.Exit(ExitCodeTOError)
+		}
+		log.Infof("reported Server Check '%s' value %d\n", check.name, check.value)
+	}
+	cfg.TCCfg.TOClient.WriteFsCookie(cfg.TOCredentials().CookieCachePath())
+
+	// Counters are only saved once they've been reported, so a failure to
+	// report them is made up for by the next run.
+	if err := nic.SaveState(cfg.StateFile, nic.State{Time: now, Interfaces: counters}); err != nil {
+		log.Errorf("saving network interface counters: %s\n", err.Error())
+		os.Exit(ExitCodeStateError)
+	}
+}
@@ -23,7 +23,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -69,11 +68,16 @@ func InitConfig(appVersion string, gitRevision string) (Cfg, error) {
 	getDataPtr := getopt.StringLong("get-data", 'D', "system-info", "non-config-file Traffic Ops Data to get. Valid values are update-status, packages, chkconfig, system-info, and statuses")
 	toInsecurePtr := getopt.BoolLong("traffic-ops-insecure", 'I', "[true | false] ignore certificate errors from Traffic Ops")
 	toTimeoutMSPtr := getopt.IntLong("traffic-ops-timeout-milliseconds", 't', 30000, "Timeout in milli-seconds for Traffic Ops requests, default is 30000")
-	toURLPtr := getopt.StringLong("traffic-ops-url", 'u', "", "Traffic Ops URL. Must be the full URL, including the scheme. Required. May also be set with     the environment variable TO_URL")
-	toUserPtr := getopt.StringLong("traffic-ops-user", 'U', "", "Traffic Ops username. Required. May also be set with the environment variable TO_USER")
+	toURLPtr := getopt.StringLong("traffic-ops-url", 'u', "", "Traffic Ops URL. Must be the full URL, including the scheme. May be a comma-delimited list of URLs, in order of preference, to fail over between. Required. May also be set with the environment variable TO_URL")
+	toUserPtr := getopt.StringLong("traffic-ops-user", 'U', "", "Traffic Ops username. Required unless a token or client certificate is given. May also be set with the environment variable TO_USER")
 	revalOnlyPtr := getopt.BoolLong("reval-only", 'r', "[true | false] whether to only fetch data needed to revalidate, versus all config data. Only used if get-data is config")
 	disableProxyPtr := getopt.BoolLong("traffic-ops-disable-proxy", 'p', "[true | false] whether to not use any configure Traffic Ops proxy parameter. Only used if get-data is config")
-	toPassPtr := getopt.StringLong("traffic-ops-password", 'P', "", "Traffic Ops password. Required. May also be set with the environment variable TO_PASS    ")
+	toPassPtr := getopt.StringLong("traffic-ops-password", 'P', "", "Traffic Ops password. Required unless a token or client certificate is given. May also be set with the environment variable TO_PASS    ")
+	toTokenPtr := getopt.StringLong("traffic-ops-token", 0, "", "Traffic Ops authentication token, used instead of the username and password. May also be set with the environment variable TO_TOKEN")
+	toClientCertPtr := getopt.StringLong("traffic-ops-client-cert", 0, "", "Path to a TLS client certificate with which to authenticate with Traffic Ops, instead of the username and password. Requires traffic-ops-client-key")
+	toClientKeyPtr := getopt.StringLong("traffic-ops-client-key", 0, "", "Path to the private key of the traffic-ops-client-cert certificate")
+	numRetriesPtr := getopt.IntLong("num-retries", 0, 0, "[number] retry Traffic Ops requests [number] times, with exponential backoff, default is 0")
+	cdnSigningKeyFilePtr := getopt.StringLong("cdn-signing-key-file", 0, "", "Path to the public signing key of the cache's CDN. If set, Traffic Ops responses without a valid signature by the key are rejected. Optional.")
 	oldCfgPtr := getopt.StringLong("old-config", 'c', "", "Old config from a previous config request. Optional. May be a file path, or 'stdin' to read from stdin. Used to make conditional requests.")
 	helpPtr := getopt.BoolLong("help", 'h', "Print usage information and exit")
 	versionPtr := getopt.BoolLong("version", 'V', "Print the app version")
@@ -127,12 +131,14 @@ func InitConfig(appVersion string, gitRevision string) (Cfg, error) {
 	if *toPassPtr == "" {
 		toPass = os.Getenv("TO_PASS")
 	}
+	toToken := *toTokenPtr
+	if toToken == "" {
+		toToken = os.Getenv("TO_TOKEN")
+	}
 
-	toURLParsed, err := url.Parse(toURL)
+	toURLs, err := t3cutil.ParseURLs(toURL)
 	if err != nil {
 		return Cfg{}, errors.New("parsing Traffic Ops URL from " + urlSourceStr + " '" + toURL + "': " + err.Error())
-	} else if err := t3cutil.ValidateURL(toURLParsed); err != nil {
-		return Cfg{}, errors.New("invalid Traffic Ops URL from " + urlSourceStr + " '" + toURL + "': " + err.Error())
 	}
 
 	var cacheHostName string
@@ -153,16 +159,23 @@ func InitConfig(appVersion string, gitRevision string) (Cfg, error) {
 		LogLocationWarn:  logLocationWarn,
 		LoginDispersion:  dispersion,
 		TCCfg: t3cutil.TCCfg{
-			CacheHostName:  cacheHostName,
-			GetData:        *getDataPtr,
-			TOInsecure:     *toInsecurePtr,
-			TOTimeoutMS:    toTimeoutMS,
-			TOUser:         toUser,
-			TOPass:         toPass,
-			TOURL:          toURLParsed,
-			RevalOnly:      *revalOnlyPtr,
-			TODisableProxy: *disableProxyPtr,
-			T3CVersion:     gitRevision,
+			CacheHostName:    cacheHostName,
+			GetData:          *getDataPtr,
+			TOInsecure:       *toInsecurePtr,
+			TOTimeoutMS:      toTimeoutMS,
+			TOUser:           toUser,
+			TOPass:           toPass,
+			TOToken:          toToken,
+			TOClientCertFile: *toClientCertPtr,
+			TOClientKeyFile:  *toClientKeyPtr,
+			TOURL:            toURLs[0],
+			TOURLs:           toURLs,
+			NumRetries:       *numRetriesPtr,
+			RevalOnly:        *revalOnlyPtr,
+			TODisableProxy:   *disableProxyPtr,
+			T3CVersion:       gitRevision,
+
+			CDNSigningKeyFile: *cdnSigningKeyFilePtr,
 		},
 		Version:     appVersion,
 		GitRevision: gitRevision,
@@ -196,7 +209,12 @@ func (cfg Cfg) PrintConfig() {
 	log.Debugf("TOTimeoutMS: %s\n", cfg.TOTimeoutMS)
 	log.Debugf("TOUser: %s\n", cfg.TOUser)
 	log.Debugf("TOPass: xxxxxx\n")
-	log.Debugf("TOURL: %s\n", cfg.TOURL)
+	log.Debugf("TOToken: xxxxxx\n")
+	log.Debugf("TOClientCertFile: %s\n", cfg.TOClientCertFile)
+	log.Debugf("TOClientKeyFile: %s\n", cfg.TOClientKeyFile)
+	log.Debugf("TOURLs: %s\n", cfg.TOURLs)
+	log.Debugf("NumRetries: %d\n", cfg.NumRetries)
+	log.Debugf("CDNSigningKeyFile: %s\n", cfg.CDNSigningKeyFile)
 }
 
 func LoadOldCfg(path string) (*t3cutil.ConfigData, error) {
@@ -20,14 +20,15 @@ package main
  */
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/apache/trafficcontrol/cache-config/t3c-request/config"
 	"github.com/apache/trafficcontrol/cache-config/t3cutil"
 	"github.com/apache/trafficcontrol/cache-config/t3cutil/toreq"
-	"github.com/apache/trafficcontrol/cache-config/t3cutil/toreq/torequtil"
 	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc/signing"
 )
 
 // Version is the application version.
@@ -47,27 +48,41 @@ func main() {
 	log.Infoln("configuration initialized")
 
 	// login to traffic ops.
-	cfg.TCCfg.TOClient, err = toreq.New(
-		cfg.TOURL,
-		cfg.TOUser,
-		cfg.TOPass,
+	cfg.TCCfg.TOClient, err = toreq.NewFailover(
+		cfg.TOURLs,
+		cfg.TOCredentials(),
 		cfg.TOInsecure,
 		cfg.TOTimeoutMS,
 		cfg.UserAgent(),
+		cfg.NumRetries,
 	)
 	if err != nil {
 		log.Errorf("%s\n", err)
+		if errors.Is(err, toreq.ErrUnreachable) {
+			os.Exit(t3cutil.RequestExitCodeUnreachable)
+		}
 		os.Exit(2)
 	}
 	if cfg.TCCfg.TOClient.FellBack() {
 		log.Warnln("Traffic Ops does not support the latest version supported by this app! Falling back to previous major Traffic Ops API version!")
 	}
+	if cfg.CDNSigningKeyFile != "" {
+		verifier, err := signing.LoadVerifier(cfg.CDNSigningKeyFile)
+		if err != nil {
+			log.Errorf("loading CDN signing key: %s\n", err)
+			os.Exit(2)
+		}
+		cfg.TCCfg.TOClient.VerifySignatures(*verifier)
+	}
 
 	if cfg.GetData != "" {
 		if err := t3cutil.WriteData(cfg.TCCfg); err != nil {
 			log.Errorf("writing data: %s\n", err.Error())
+			if cfg.TCCfg.TOClient.Unreachable() {
+				os.Exit(t3cutil.RequestExitCodeUnreachable)
+			}
 			os.Exit(3)
 		}
 	}
-	cfg.TCCfg.TOClient.WriteFsCookie(torequtil.CookieCachePath(cfg.TOUser))
+	cfg.TCCfg.TOClient.WriteFsCookie(cfg.TOCredentials().CookieCachePath())
 }
@@ -0,0 +1,183 @@
+package config
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3cutil"
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/pborman/getopt/v2"
+)
+
+const AppName = "t3c-ship-logs"
+
+const DefaultConfigFile = "/opt/trafficserver/etc/trafficserver/" + atscfg.LogShippingConfigFileName
+const DefaultLogDir = "/opt/trafficserver/var/log/trafficserver"
+
+type Cfg struct {
+	LogLocationDebug string
+	LogLocationWarn  string
+	LogLocationError string
+	LogLocationInfo  string
+	// ConfigFile is the log shipping config generated by t3c.
+	ConfigFile string
+	// LogDir is the ATS log directory, which contains the access log named
+	// by the config file.
+	LogDir string
+	// CacheHostName names the objects and messages logs are shipped in, so
+	// that the logs of different cache servers can be told apart.
+	CacheHostName string
+	// FlushInterval is how often buffered logs are shipped.
+	FlushInterval time.Duration
+	// BatchLines is how many buffered lines of a Delivery Service cause them
+	// to be shipped before FlushInterval.
+	BatchLines int
+	// MaxBufferLines is how many lines of a Delivery Service are buffered
+	// while its destination is unavailable, before the oldest are dropped.
+	MaxBufferLines int
+	// ReloadInterval is how often the config file is checked for changes.
+	ReloadInterval time.Duration
+	// Timeout is the timeout of requests to destinations.
+	Timeout     time.Duration
+	Version     string
+	GitRevision string
+}
+
+func (cfg Cfg) AppVersion() string { return t3cutil.VersionStr(AppName, cfg.Version, cfg.GitRevision) }
+func (cfg Cfg) UserAgent() string  { return t3cutil.UserAgentStr(AppName, cfg.Version, cfg.GitRevision) }
+
+func (cfg Cfg) DebugLog() log.LogLocation   { return log.LogLocation(cfg.LogLocationDebug) }
+func (cfg Cfg) ErrorLog() log.LogLocation   { return log.LogLocation(cfg.LogLocationError) }
+func (cfg Cfg) InfoLog() log.LogLocation    { return log.LogLocation(cfg.LogLocationInfo) }
+func (cfg Cfg) WarningLog() log.LogLocation { return log.LogLocation(cfg.LogLocationWarn) }
+func (cfg Cfg) EventLog() log.LogLocation   { return log.LogLocation(log.LogLocationNull) } // event logging is not used.
+
+// Usage() writes command line options and usage to 'stderr'
+func Usage() {
+	getopt.PrintUsage(os.Stderr)
+	os.Exit(0)
+}
+
+// InitConfig() intializes the configuration variables and loggers.
+func InitConfig(appVersion string, gitRevision string) (Cfg, error) {
+	configFilePtr := getopt.StringLong("config-file", 'c', DefaultConfigFile, "Log shipping config file generated by t3c")
+	logDirPtr := getopt.StringLong("log-dir", 'l', DefaultLogDir, "ATS log directory")
+	cacheHostNamePtr := getopt.StringLong("cache-host-name", 'H', "", "Host name of the cache, which names shipped objects and messages. Default is the OS hostname")
+	flushIntervalPtr := getopt.IntLong("flush-interval-seconds", 'f', 60, "How often to ship buffered logs, in seconds")
+	batchLinesPtr := getopt.IntLong("batch-lines", 'b', 10000, "How many buffered lines of a Delivery Service to ship without waiting for the flush interval")
+	maxBufferLinesPtr := getopt.IntLong("max-buffer-lines", 'm', 100000, "How many lines of a Delivery Service to buffer while its destination is unavailable, before dropping the oldest")
+	reloadIntervalPtr := getopt.IntLong("reload-interval-seconds", 'r', 30, "How often to check the config file for changes, in seconds")
+	timeoutMSPtr := getopt.IntLong("timeout-milliseconds", 't', 30000, "Timeout in milli-seconds for requests to destinations")
+	helpPtr := getopt.BoolLong("help", 'h', "Print usage information and exit")
+	versionPtr := getopt.BoolLong("version", 'V', "Print the app version")
+	verbosePtr := getopt.CounterLong("verbose", 'v', `Log verbosity. Logging is output to stderr. By default, errors are logged. To log warnings, pass '-v'. To log info, pass '-vv'. To omit error logging, see '-s'`)
+	silentPtr := getopt.BoolLong("silent", 's', `Silent. Errors are not logged, and the 'verbose' flag is ignored. If a fatal error occurs, the return code will be non-zero but no text will be output to stderr`)
+
+	getopt.Parse()
+
+	if *helpPtr == true {
+		Usage()
+	} else if *versionPtr == true {
+		cfg := &Cfg{Version: appVersion, GitRevision: gitRevision}
+		fmt.Println(cfg.AppVersion())
+		os.Exit(0)
+	}
+
+	logLocationError := log.LogLocationStderr
+	logLocationWarn := log.LogLocationNull
+	logLocationInfo := log.LogLocationNull
+	logLocationDebug := log.LogLocationNull
+	if *silentPtr {
+		logLocationError = log.LogLocationNull
+	} else {
+		if *verbosePtr >= 1 {
+			logLocationWarn = log.LogLocationStderr
+		}
+		if *verbosePtr >= 2 {
+			logLocationInfo = log.LogLocationStderr
+			logLocationDebug = log.LogLocationStderr // t3c only has 3 verbosity options: none (-s), error (default or --verbose=0), warning (-v), and info (-vv). Any code calling log.Debug is treated as Info.
+		}
+	}
+
+	if *verbosePtr > 2 {
+		return Cfg{}, errors.New("Too many verbose options. The maximum log verbosity level is 2 (-vv or --verbose=2) for errors (0), warnings (1), and info (2)")
+	}
+
+	if *flushIntervalPtr <= 0 || *reloadIntervalPtr <= 0 || *timeoutMSPtr <= 0 {
+		return Cfg{}, errors.New("flush interval, reload interval, and timeout must be positive")
+	}
+	if *batchLinesPtr <= 0 || *maxBufferLinesPtr < *batchLinesPtr {
+		return Cfg{}, errors.New("batch lines must be positive, and max buffer lines must be at least batch lines")
+	}
+
+	var cacheHostName string
+	if len(*cacheHostNamePtr) > 0 {
+		cacheHostName = *cacheHostNamePtr
+	} else {
+		var err error
+		cacheHostName, err = os.Hostname()
+		if err != nil {
+			return Cfg{}, errors.New("could not get the OS hostname, please supply a hostname: " + err.Error())
+		}
+	}
+
+	cfg := Cfg{
+		LogLocationDebug: logLocationDebug,
+		LogLocationError: logLocationError,
+		LogLocationInfo:  logLocationInfo,
+		LogLocationWarn:  logLocationWarn,
+		ConfigFile:       *configFilePtr,
+		LogDir:           *logDirPtr,
+		CacheHostName:    cacheHostName,
+		FlushInterval:    time.Second * time.Duration(*flushIntervalPtr),
+		BatchLines:       *batchLinesPtr,
+		MaxBufferLines:   *maxBufferLinesPtr,
+		ReloadInterval:   time.Second * time.Duration(*reloadIntervalPtr),
+		Timeout:          time.Millisecond * time.Duration(*timeoutMSPtr),
+		Version:          appVersion,
+		GitRevision:      gitRevision,
+	}
+
+	if err := log.InitCfg(cfg); err != nil {
+		return Cfg{}, errors.New("initializing loggers: " + err.Error())
+	}
+
+	return cfg, nil
+}
+
+func (cfg Cfg) PrintConfig() {
+	log.Debugf("LogLocationDebug: %s\n", cfg.LogLocationDebug)
+	log.Debugf("LogLocationError: %s\n", cfg.LogLocationError)
+	log.Debugf("LogLocationInfo: %s\n", cfg.LogLocationInfo)
+	log.Debugf("LogLocationWarn: %s\n", cfg.LogLocationWarn)
+	log.Debugf("ConfigFile: %s\n", cfg.ConfigFile)
+	log.Debugf("LogDir: %s\n", cfg.LogDir)
+	log.Debugf("CacheHostName: %s\n", cfg.CacheHostName)
+	log.Debugf("FlushInterval: %s\n", cfg.FlushInterval)
+	log.Debugf("BatchLines: %d\n", cfg.BatchLines)
+	log.Debugf("MaxBufferLines: %d\n", cfg.MaxBufferLines)
+	log.Debugf("ReloadInterval: %s\n", cfg.ReloadInterval)
+	log.Debugf("Timeout: %s\n", cfg.Timeout)
+}
@@ -0,0 +1,293 @@
+package shipper
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	"github.com/Shopify/sarama"
+)
+
+// Destination is where the logs of a Delivery Service are shipped.
+type Destination interface {
+	// Send ships the lines, which have no trailing newlines. If it returns an
+	// error, none of the lines should be assumed to have been shipped.
+	Send(lines []string) error
+	Close() error
+}
+
+// DestinationOpts are the settings of destinations which don't come from the
+// log shipping config of a Delivery Service.
+type DestinationOpts struct {
+	// HostName is the name of the cache server, which names the objects and
+	// messages logs are shipped in.
+	HostName  string
+	UserAgent string
+	Timeout   time.Duration
+	// Client is the HTTP client of S3 and HTTPS destinations.
+	Client *http.Client
+}
+
+// NewDestination returns the destination of the given log shipping config.
+func NewDestination(ls tc.DeliveryServiceLogShipping, opts DestinationOpts) (Destination, error) {
+	credentials := ""
+	if ls.Credentials != nil {
+		credentials = *ls.Credentials
+	}
+	if credentials == tc.HiddenLogShippingCredentials {
+		return nil, errors.New("credentials are hidden; the config must be generated by a user who may see them")
+	}
+	switch ls.DestinationType {
+	case tc.LogShippingDestinationS3:
+		s3, err := tc.ParseLogShippingS3Destination(ls.Destination)
+		if err != nil {
+			return nil, errors.New("parsing destination: " + err.Error())
+		}
+		accessKey, secretKey, ok := strings.Cut(credentials, ":")
+		if !ok {
+			return nil, errors.New("S3 credentials must be an access key ID and secret access key separated by ':'")
+		}
+		return &s3Destination{dest: s3, accessKey: accessKey, secretKey: secretKey, opts: opts}, nil
+	case tc.LogShippingDestinationHTTPS:
+		return &httpsDestination{url: ls.Destination, authorization: credentials, opts: opts}, nil
+	case tc.LogShippingDestinationKafka:
+		return newKafkaDestination(ls.Destination, credentials, opts)
+	}
+	return nil, errors.New("unknown destination type '" + ls.DestinationType + "'")
+}
+
+// gzipLines returns the lines, separated and terminated by newlines, gzipped.
+func gzipLines(lines []string) ([]byte, error) {
+	buf := bytes.Buffer{}
+	zw := gzip.NewWriter(&buf)
+	for _, line := range lines {
+		if _, err := io.WriteString(zw, line+"\n"); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// do sends the request, returning an error if it fails or its response
+// status isn't a success.
+func do(client *http.Client, req *http.Request) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s %s returned status %d: %s", req.Method, req.URL.Redacted(), resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// httpsDestination POSTs gzipped newline-separated lines to a URL.
+type httpsDestination struct {
+	url           string
+	authorization string
+	opts          DestinationOpts
+}
+
+func (d *httpsDestination) Send(lines []string) error {
+	body, err := gzipLines(lines)
+	if err != nil {
+		return errors.New("compressing logs: " + err.Error())
+	}
+	req, err := http.NewRequest(http.MethodPost, d.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.New("creating request: " + err.Error())
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("User-Agent", d.opts.UserAgent)
+	req.Header.Set("X-Cache-Host", d.opts.HostName)
+	if d.authorization != "" {
+		req.Header.Set("Authorization", d.authorization)
+	}
+	return do(d.opts.Client, req)
+}
+
+func (d *httpsDestination) Close() error { return nil }
+
+// s3Destination PUTs gzipped newline-separated lines as objects in an S3
+// bucket, signing requests with AWS Signature Version 4.
+type s3Destination struct {
+	dest      tc.LogShippingS3Destination
+	accessKey string
+	secretKey string
+	opts      DestinationOpts
+	seq       uint64
+}
+
+// objectKey returns the key of a new object of logs shipped at the given
+// time. Keys are grouped by date and cache server, and are unique for each
+// run of the shipper.
+func (d *s3Destination) objectKey(now time.Time) string {
+	seq := atomic.AddUint64(&d.seq, 1)
+	key := now.Format("2006/01/02") + "/" + d.opts.HostName + "/" + now.Format("20060102T150405Z") + "-" + strconv.FormatUint(seq, 10) + ".log.gz"
+	if d.dest.Prefix != "" {
+		key = d.dest.Prefix + "/" + key
+	}
+	return key
+}
+
+func (d *s3Destination) Send(lines []string) error {
+	body, err := gzipLines(lines)
+	if err != nil {
+		return errors.New("compressing logs: " + err.Error())
+	}
+	now := time.Now().UTC()
+	req, err := http.NewRequest(http.MethodPut, d.dest.Endpoint+"/"+d.dest.Bucket+"/"+d.objectKey(now), bytes.NewReader(body))
+	if err != nil {
+		return errors.New("creating request: " + err.Error())
+	}
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+	req.Header.Set("Content-Encoding", "gzip")
+	req.Header.Set("User-Agent", d.opts.UserAgent)
+	signS3Request(req, body, d.accessKey, d.secretKey, d.dest.Region, now)
+	return do(d.opts.Client, req)
+}
+
+func (d *s3Destination) Close() error { return nil }
+
+// signS3Request signs a request to S3 with AWS Signature Version 4. Only the
+// Host, X-Amz-Content-Sha256, and X-Amz-Date headers are signed, and the
+// request may not have a query string.
+func signS3Request(req *http.Request, body []byte, accessKey string, secretKey string, region string, now time.Time) {
+	const algorithm = "AWS4-HMAC-SHA256"
+	const service = "s3"
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		"", // query string
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	stringToSign := algorithm + "\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+	signature := hex.EncodeToString(hmacSHA256(awsSigningKey(secretKey, date, region, service), stringToSign))
+	req.Header.Set("Authorization", algorithm+" Credential="+accessKey+"/"+scope+", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// awsSigningKey derives the AWS Signature Version 4 signing key of a secret
+// access key for the given date (YYYYMMDD), region, and service.
+func awsSigningKey(secretKey string, date string, region string, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// kafkaDestination produces each line as a message to a Kafka topic, keyed by
+// the cache server's host name.
+type kafkaDestination struct {
+	producer sarama.SyncProducer
+	topic    string
+	key      string
+}
+
+func newKafkaDestination(dest string, credentials string, opts DestinationOpts) (*kafkaDestination, error) {
+	brokers, topic, err := tc.ParseLogShippingKafkaDestination(dest)
+	if err != nil {
+		return nil, errors.New("parsing destination: " + err.Error())
+	}
+	cfg := sarama.NewConfig()
+	cfg.ClientID = "t3c-ship-logs"
+	cfg.Net.DialTimeout = opts.Timeout
+	cfg.Net.ReadTimeout = opts.Timeout
+	cfg.Net.WriteTimeout = opts.Timeout
+	cfg.Producer.Return.Successes = true // required by SyncProducer
+	cfg.Producer.RequiredAcks = sarama.WaitForAll
+	cfg.Producer.Compression = sarama.CompressionGZIP
+	if credentials != "" {
+		user, pass, _ := strings.Cut(credentials, ":")
+		cfg.Net.SASL.Enable = true
+		cfg.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+		cfg.Net.SASL.User = user
+		cfg.Net.SASL.Password = pass
+		// SASL/PLAIN sends the password in the clear, so it's only used over
+		// TLS.
+		cfg.Net.TLS.Enable = true
+		cfg.Net.TLS.Config = &tls.Config{}
+	}
+	producer, err := sarama.NewSyncProducer(brokers, cfg)
+	if err != nil {
+		return nil, errors.New("creating Kafka producer: " + err.Error())
+	}
+	return &kafkaDestination{producer: producer, topic: topic, key: opts.HostName}, nil
+}
+
+func (d *kafkaDestination) Send(lines []string) error {
+	msgs := make([]*sarama.ProducerMessage, 0, len(lines))
+	for _, line := range lines {
+		msgs = append(msgs, &sarama.ProducerMessage{
+			Topic: d.topic,
+			Key:   sarama.StringEncoder(d.key),
+			Value: sarama.StringEncoder(line),
+		})
+	}
+	return d.producer.SendMessages(msgs)
+}
+
+func (d *kafkaDestination) Close() error { return d.producer.Close() }
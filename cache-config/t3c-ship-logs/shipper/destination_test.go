@@ -0,0 +1,129 @@
+package shipper
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"compress/gzip"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestAWSSigningKey(t *testing.T) {
+	// from the AWS Signature Version 4 documentation's example of deriving a
+	// signing key.
+	key := awsSigningKey("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	expected := "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d"
+	if actual := hex.EncodeToString(key); actual != expected {
+		t.Errorf("expected signing key %s, actual: %s", expected, actual)
+	}
+}
+
+func readGzipBody(t *testing.T, r *http.Request) string {
+	t.Helper()
+	zr, err := gzip.NewReader(r.Body)
+	if err != nil {
+		t.Fatalf("reading gzipped body: %v", err)
+	}
+	bts, err := ioutil.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("reading gzipped body: %v", err)
+	}
+	return string(bts)
+}
+
+func TestS3DestinationSend(t *testing.T) {
+	var path, auth, body string
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		auth = r.Header.Get("Authorization")
+		body = readGzipBody(t, r)
+	}))
+	defer srv.Close()
+
+	dest, err := NewDestination(tc.DeliveryServiceLogShipping{
+		DestinationType: tc.LogShippingDestinationS3,
+		Destination:     "s3://logs/demo1/?region=us-east-1&endpoint=" + srv.URL,
+		Credentials:     util.StrPtr("AKID:SECRET"),
+	}, DestinationOpts{HostName: "edge1", Client: srv.Client()})
+	if err != nil {
+		t.Fatalf("unexpected error creating destination: %v", err)
+	}
+	if err := dest.Send([]string{"a", "b"}); err != nil {
+		t.Fatalf("unexpected error sending: %v", err)
+	}
+	if !strings.HasPrefix(path, "/logs/demo1/") || !strings.Contains(path, "/edge1/") || !strings.HasSuffix(path, "-1.log.gz") {
+		t.Errorf("unexpected object path: %s", path)
+	}
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(auth, "/us-east-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=") {
+		t.Errorf("unexpected Authorization header: %s", auth)
+	}
+	if body != "a\nb\n" {
+		t.Errorf("expected body 'a\\nb\\n', actual: '%s'", body)
+	}
+}
+
+func TestHTTPSDestinationSend(t *testing.T) {
+	var auth, body string
+	status := http.StatusNoContent
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		body = readGzipBody(t, r)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	dest, err := NewDestination(tc.DeliveryServiceLogShipping{
+		DestinationType: tc.LogShippingDestinationHTTPS,
+		Destination:     srv.URL + "/ingest",
+		Credentials:     util.StrPtr("Bearer abc"),
+	}, DestinationOpts{HostName: "edge1", Client: srv.Client()})
+	if err != nil {
+		t.Fatalf("unexpected error creating destination: %v", err)
+	}
+	if err := dest.Send([]string{"a"}); err != nil {
+		t.Fatalf("unexpected error sending: %v", err)
+	}
+	if auth != "Bearer abc" || body != "a\n" {
+		t.Errorf("expected Authorization 'Bearer abc' and body 'a\\n', actual: '%s' '%s'", auth, body)
+	}
+
+	status = http.StatusServiceUnavailable
+	if err := dest.Send([]string{"a"}); err == nil {
+		t.Error("expected an error sending to a failing destination, got none")
+	}
+}
+
+func TestNewDestinationHiddenCredentials(t *testing.T) {
+	_, err := NewDestination(tc.DeliveryServiceLogShipping{
+		DestinationType: tc.LogShippingDestinationHTTPS,
+		Destination:     "https://example.net/ingest",
+		Credentials:     util.StrPtr(tc.HiddenLogShippingCredentials),
+	}, DestinationOpts{})
+	if err == nil {
+		t.Error("expected an error creating a destination with hidden credentials, got none")
+	}
+}
@@ -0,0 +1,162 @@
+package shipper
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// Record is a request in the access log from which logs are shipped. Its
+// fields are those of atscfg.LogShippingLogFields, in order.
+type Record struct {
+	// Time is the time of the request, in fractional seconds since the
+	// epoch.
+	Time        string `json:"time"`
+	TimeTakenMS string `json:"timeTakenMs"`
+	ClientIP    string `json:"clientIp"`
+	Method      string `json:"method"`
+	URL         string `json:"url"`
+	Host        string `json:"host"`
+	Status      string `json:"status"`
+	Bytes       string `json:"bytes"`
+	CacheResult string `json:"cacheResult"`
+	UserAgent   string `json:"userAgent"`
+	Referer     string `json:"referer"`
+}
+
+// ParseRecord parses a line of the access log from which logs are shipped.
+// ATS logs empty fields as '-', which are parsed as empty strings.
+func ParseRecord(line string) (Record, error) {
+	fields := strings.Split(strings.TrimRight(line, "\r\n"), "\t")
+	if len(fields) != len(atscfg.LogShippingLogFields) {
+		return Record{}, errors.New("malformed line: expected " + strconv.Itoa(len(atscfg.LogShippingLogFields)) + " tab-separated fields, got " + strconv.Itoa(len(fields)))
+	}
+	for i, field := range fields {
+		if field == "-" {
+			fields[i] = ""
+		}
+	}
+	return Record{
+		Time:        fields[0],
+		TimeTakenMS: fields[1],
+		ClientIP:    fields[2],
+		Method:      fields[3],
+		URL:         fields[4],
+		Host:        fields[5],
+		Status:      fields[6],
+		Bytes:       fields[7],
+		CacheResult: fields[8],
+		UserAgent:   fields[9],
+		Referer:     fields[10],
+	}, nil
+}
+
+// Scrub applies the scrub rules of a Delivery Service to the record.
+func (r *Record) Scrub(rules []tc.LogShippingScrubRule) {
+	for _, rule := range rules {
+		switch rule.Field {
+		case tc.LogShippingFieldClientIP:
+			r.ClientIP = scrubField(r.ClientIP, rule.Action)
+		case tc.LogShippingFieldUserAgent:
+			r.UserAgent = scrubField(r.UserAgent, rule.Action)
+		case tc.LogShippingFieldReferer:
+			r.Referer = scrubField(r.Referer, rule.Action)
+		case tc.LogShippingFieldQuery:
+			path, query, ok := strings.Cut(r.URL, "?")
+			if !ok {
+				continue
+			}
+			if query = scrubField(query, rule.Action); query == "" {
+				r.URL = path
+			} else {
+				r.URL = path + "?" + query
+			}
+		}
+	}
+}
+
+func scrubField(val string, action string) string {
+	if val == "" {
+		return val
+	}
+	switch action {
+	case tc.LogShippingScrubDrop:
+		return ""
+	case tc.LogShippingScrubHash:
+		sum := sha256.Sum256([]byte(val))
+		return hex.EncodeToString(sum[:])
+	case tc.LogShippingScrubMask:
+		return maskIP(val)
+	}
+	return val
+}
+
+// maskIP zeroes the host bits of the IP address below its /24 (IPv4) or /48
+// (IPv6) network. Values which aren't IP addresses are dropped, rather than
+// risk shipping them unmasked.
+func maskIP(val string) string {
+	ip := net.ParseIP(val)
+	if ip == nil {
+		return ""
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(net.CIDRMask(24, 32)).String()
+	}
+	return ip.Mask(net.CIDRMask(48, 128)).String()
+}
+
+// Format returns the record as a line of the given log format, without a
+// trailing newline.
+func (r Record) Format(format string) string {
+	if format == tc.LogShippingFormatSquid {
+		return strings.Join([]string{
+			r.Time,
+			r.TimeTakenMS,
+			squidField(r.ClientIP),
+			squidField(r.CacheResult) + "/" + squidField(r.Status),
+			squidField(r.Bytes),
+			squidField(r.Method),
+			squidField(r.URL),
+			"-",
+			"NONE/-",
+			"-",
+		}, " ")
+	}
+	bts, _ := json.Marshal(r) // a struct of strings can't fail to marshal.
+	return string(bts)
+}
+
+// squidField returns the value as a space-separated field of the Squid log
+// format, in which empty fields are '-'.
+func squidField(val string) string {
+	if val == "" {
+		return "-"
+	}
+	return strings.ReplaceAll(val, " ", "%20")
+}
@@ -0,0 +1,85 @@
+package shipper
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+const testLine = "1652000000.123\t12\t192.0.2.57\tGET\thttp://video.demo1.mycdn.ciab.test/a/b?user=bob&x=1\tvideo.demo1.mycdn.ciab.test:8080\t200\t1234\tTCP_HIT\tcurl/7.61 (x86_64)\t-"
+
+func TestParseRecord(t *testing.T) {
+	rec, err := ParseRecord(testLine + "\n")
+	if err != nil {
+		t.Fatalf("unexpected error parsing record: %v", err)
+	}
+	if rec.ClientIP != "192.0.2.57" || rec.Host != "video.demo1.mycdn.ciab.test:8080" || rec.Status != "200" || rec.UserAgent != "curl/7.61 (x86_64)" {
+		t.Errorf("unexpected record: %+v", rec)
+	}
+	if rec.Referer != "" {
+		t.Errorf("expected '-' field to be parsed as empty, actual: '%s'", rec.Referer)
+	}
+
+	if _, err := ParseRecord("a\tb\tc"); err == nil {
+		t.Error("expected an error parsing a line with too few fields, got none")
+	}
+}
+
+func TestRecordScrub(t *testing.T) {
+	rec, _ := ParseRecord(testLine)
+	rec.Scrub([]tc.LogShippingScrubRule{
+		{Field: tc.LogShippingFieldClientIP, Action: tc.LogShippingScrubMask},
+		{Field: tc.LogShippingFieldUserAgent, Action: tc.LogShippingScrubHash},
+		{Field: tc.LogShippingFieldQuery, Action: tc.LogShippingScrubDrop},
+	})
+	if rec.ClientIP != "192.0.2.0" {
+		t.Errorf("expected masked client IP '192.0.2.0', actual: '%s'", rec.ClientIP)
+	}
+	if len(rec.UserAgent) != 64 || strings.Contains(rec.UserAgent, "curl") {
+		t.Errorf("expected hashed user agent, actual: '%s'", rec.UserAgent)
+	}
+	if rec.URL != "http://video.demo1.mycdn.ciab.test/a/b" {
+		t.Errorf("expected URL without query, actual: '%s'", rec.URL)
+	}
+
+	if masked := maskIP("2001:db8:1234:5678::1"); masked != "2001:db8:1234::" {
+		t.Errorf("expected masked IPv6 address '2001:db8:1234::', actual: '%s'", masked)
+	}
+	if masked := maskIP("not-an-ip"); masked != "" {
+		t.Errorf("expected a value which isn't an IP address to be dropped, actual: '%s'", masked)
+	}
+}
+
+func TestRecordFormat(t *testing.T) {
+	rec, _ := ParseRecord(testLine)
+	squid := rec.Format(tc.LogShippingFormatSquid)
+	expected := "1652000000.123 12 192.0.2.57 TCP_HIT/200 1234 GET http://video.demo1.mycdn.ciab.test/a/b?user=bob&x=1 - NONE/- -"
+	if squid != expected {
+		t.Errorf("expected squid line '%s', actual: '%s'", expected, squid)
+	}
+
+	js := rec.Format(tc.LogShippingFormatJSON)
+	if !strings.HasPrefix(js, `{"time":"1652000000.123",`) || !strings.Contains(js, `"referer":""`) {
+		t.Errorf("unexpected JSON line: %s", js)
+	}
+}
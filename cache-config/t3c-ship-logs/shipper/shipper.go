@@ -0,0 +1,258 @@
+package shipper
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"os"
+	"reflect"
+	"regexp"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// Opts are the settings of a Shipper.
+type Opts struct {
+	// ConfigFile is the log shipping config generated by t3c.
+	ConfigFile string
+	// BatchLines is the most lines sent to a destination at once, and how
+	// many buffered lines of a Delivery Service make it ready to be flushed.
+	BatchLines int
+	// MaxBufferLines is how many lines of a Delivery Service are buffered
+	// before the oldest are dropped.
+	MaxBufferLines int
+	Destination    DestinationOpts
+}
+
+// Shipper buffers the access log lines of Delivery Services, and ships them
+// to their destinations.
+//
+// A Shipper isn't safe for concurrent use.
+type Shipper struct {
+	opts    Opts
+	logFile string
+	dses    []*dsShipper
+	modTime time.Time
+	// newDestination creates destinations; it's a member so tests can fake
+	// them.
+	newDestination func(tc.DeliveryServiceLogShipping, DestinationOpts) (Destination, error)
+}
+
+// dsShipper is the buffer and destination of a Delivery Service.
+type dsShipper struct {
+	cfg         atscfg.LogShippingDeliveryService
+	hostRegexes []*regexp.Regexp
+	dest        Destination
+	buf         []string
+	dropped     uint64
+}
+
+// New returns a new Shipper, which ships nothing until its config is loaded.
+func New(opts Opts) *Shipper {
+	return &Shipper{opts: opts, newDestination: NewDestination}
+}
+
+// LogFile returns the name of the access log, in the ATS log directory, from
+// which logs are shipped. It's empty if the config hasn't been loaded, or
+// ships no logs.
+func (s *Shipper) LogFile() string { return s.logFile }
+
+// Reload loads the config file if it changed since it was last loaded, and
+// returns whether it did. A missing config file ships no logs.
+//
+// Buffered lines of Delivery Services which are still shipped are kept, even
+// if their destination changed. Buffered lines of Delivery Services which are
+// no longer shipped are flushed one last time, and dropped if that fails.
+func (s *Shipper) Reload() (bool, error) {
+	cfg := atscfg.LogShippingConfig{}
+	modTime := time.Time{}
+	if fi, err := os.Stat(s.opts.ConfigFile); err != nil {
+		if !os.IsNotExist(err) {
+			return false, errors.New("getting config file info: " + err.Error())
+		}
+	} else {
+		modTime = fi.ModTime()
+		if modTime.Equal(s.modTime) {
+			return false, nil
+		}
+		bts, err := ioutil.ReadFile(s.opts.ConfigFile)
+		if err != nil {
+			return false, errors.New("reading config file: " + err.Error())
+		}
+		if err := json.Unmarshal(bts, &cfg); err != nil {
+			return false, errors.New("parsing config file: " + err.Error())
+		}
+	}
+	if modTime.IsZero() && s.modTime.IsZero() && s.dses != nil {
+		return false, nil // still missing
+	}
+
+	old := map[string]*dsShipper{}
+	for _, ds := range s.dses {
+		old[ds.cfg.XMLID] = ds
+	}
+
+	dses := make([]*dsShipper, 0, len(cfg.DeliveryServices))
+	for _, dsCfg := range cfg.DeliveryServices {
+		ds := &dsShipper{cfg: dsCfg}
+		for _, reStr := range dsCfg.HostRegexes {
+			re, err := regexp.Compile(reStr)
+			if err != nil {
+				log.Errorf("Delivery Service '%s' host regex '%s' is invalid, skipping: %v\n", dsCfg.XMLID, reStr, err)
+				continue
+			}
+			ds.hostRegexes = append(ds.hostRegexes, re)
+		}
+		if prev, ok := old[dsCfg.XMLID]; ok {
+			ds.buf = prev.buf
+			if reflect.DeepEqual(prev.cfg.DeliveryServiceLogShipping, dsCfg.DeliveryServiceLogShipping) {
+				ds.dest = prev.dest
+			} else if prev.dest != nil {
+				closeDestination(prev)
+			}
+			delete(old, dsCfg.XMLID)
+		}
+		dses = append(dses, ds)
+	}
+
+	for _, ds := range old {
+		if len(ds.buf) > 0 {
+			if err := s.flushDS(ds); err != nil {
+				log.Errorf("Delivery Service '%s' no longer ships logs, dropping %d buffered lines which failed to ship: %v\n", ds.cfg.XMLID, len(ds.buf), err)
+			}
+		}
+		closeDestination(ds)
+	}
+
+	s.dses = dses
+	s.logFile = cfg.LogFile
+	s.modTime = modTime
+	return true, nil
+}
+
+func closeDestination(ds *dsShipper) {
+	if ds.dest == nil {
+		return
+	}
+	if err := ds.dest.Close(); err != nil {
+		log.Warnf("closing destination of Delivery Service '%s': %v\n", ds.cfg.XMLID, err)
+	}
+	ds.dest = nil
+}
+
+// Handle buffers a line of the access log, if it's a request of a Delivery
+// Service which ships logs and it's sampled. It returns whether the Delivery
+// Service has a full batch of lines, which should be flushed.
+func (s *Shipper) Handle(line string) bool {
+	if len(s.dses) == 0 {
+		return false
+	}
+	rec, err := ParseRecord(line)
+	if err != nil {
+		log.Warnf("skipping access log line: %v\n", err)
+		return false
+	}
+	ds := s.match(rec.Host)
+	if ds == nil {
+		return false
+	}
+	if ds.cfg.SampleRate < 1 && rand.Float64() >= ds.cfg.SampleRate {
+		return false
+	}
+	rec.Scrub(ds.cfg.ScrubRules)
+	ds.buf = append(ds.buf, rec.Format(ds.cfg.Format))
+	if over := len(ds.buf) - s.opts.MaxBufferLines; over > 0 {
+		if ds.dropped == 0 {
+			log.Warnf("Delivery Service '%s' log buffer is full, dropping its oldest lines until they can be shipped\n", ds.cfg.XMLID)
+		}
+		ds.dropped += uint64(over)
+		ds.buf = ds.buf[over:]
+	}
+	return len(ds.buf) >= s.opts.BatchLines
+}
+
+// match returns the Delivery Service whose host regexes match the request
+// Host header, or nil if none do.
+func (s *Shipper) match(host string) *dsShipper {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	for _, ds := range s.dses {
+		for _, re := range ds.hostRegexes {
+			if re.MatchString(host) {
+				return ds
+			}
+		}
+	}
+	return nil
+}
+
+// Flush ships the buffered lines of every Delivery Service. Lines which fail
+// to ship stay buffered, to be retried by the next Flush.
+func (s *Shipper) Flush() {
+	for _, ds := range s.dses {
+		if len(ds.buf) == 0 {
+			continue
+		}
+		if err := s.flushDS(ds); err != nil {
+			log.Errorf("shipping logs of Delivery Service '%s', %d lines remain buffered: %v\n", ds.cfg.XMLID, len(ds.buf), err)
+		}
+	}
+}
+
+// Close closes the destinations of every Delivery Service, without flushing.
+func (s *Shipper) Close() {
+	for _, ds := range s.dses {
+		closeDestination(ds)
+	}
+}
+
+func (s *Shipper) flushDS(ds *dsShipper) error {
+	if ds.dest == nil {
+		dest, err := s.newDestination(ds.cfg.DeliveryServiceLogShipping, s.opts.Destination)
+		if err != nil {
+			return errors.New("creating destination: " + err.Error())
+		}
+		ds.dest = dest
+	}
+	if ds.dropped > 0 {
+		log.Warnf("Delivery Service '%s' dropped %d lines while its log buffer was full\n", ds.cfg.XMLID, ds.dropped)
+		ds.dropped = 0
+	}
+	for len(ds.buf) > 0 {
+		n := s.opts.BatchLines
+		if n > len(ds.buf) {
+			n = len(ds.buf)
+		}
+		if err := ds.dest.Send(ds.buf[:n]); err != nil {
+			return err
+		}
+		ds.buf = ds.buf[n:]
+	}
+	ds.buf = nil
+	return nil
+}
@@ -0,0 +1,141 @@
+package shipper
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-atscfg"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+type fakeDestination struct {
+	sent   []string
+	fail   bool
+	closed bool
+}
+
+func (d *fakeDestination) Send(lines []string) error {
+	if d.fail {
+		return errors.New("unavailable")
+	}
+	d.sent = append(d.sent, lines...)
+	return nil
+}
+
+func (d *fakeDestination) Close() error {
+	d.closed = true
+	return nil
+}
+
+func writeTestConfig(t *testing.T, path string, dses ...atscfg.LogShippingDeliveryService) {
+	t.Helper()
+	bts, err := json.Marshal(atscfg.LogShippingConfig{LogFile: atscfg.LogShippingLogFileName, DeliveryServices: dses})
+	if err != nil {
+		t.Fatalf("marshalling config: %v", err)
+	}
+	if err := ioutil.WriteFile(path, bts, 0600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+}
+
+func TestShipper(t *testing.T) {
+	path := filepath.Join(t.TempDir(), atscfg.LogShippingConfigFileName)
+	demo1 := atscfg.LogShippingDeliveryService{
+		DeliveryServiceLogShipping: tc.DeliveryServiceLogShipping{
+			XMLID:           "demo1",
+			DestinationType: tc.LogShippingDestinationHTTPS,
+			Destination:     "https://example.net/ingest",
+			Format:          tc.LogShippingFormatSquid,
+			SampleRate:      1,
+			ScrubRules:      []tc.LogShippingScrubRule{{Field: tc.LogShippingFieldClientIP, Action: tc.LogShippingScrubMask}},
+		},
+		HostRegexes: []string{`.*\.demo1\..*`},
+	}
+	writeTestConfig(t, path, demo1)
+
+	dests := map[string]*fakeDestination{}
+	shp := New(Opts{ConfigFile: path, BatchLines: 2, MaxBufferLines: 3})
+	shp.newDestination = func(ls tc.DeliveryServiceLogShipping, opts DestinationOpts) (Destination, error) {
+		dests[ls.XMLID] = &fakeDestination{}
+		return dests[ls.XMLID], nil
+	}
+
+	if reloaded, err := shp.Reload(); err != nil || !reloaded {
+		t.Fatalf("expected config to be loaded, actual: %v %v", reloaded, err)
+	}
+	if shp.LogFile() != atscfg.LogShippingLogFileName {
+		t.Errorf("expected log file '%s', actual: '%s'", atscfg.LogShippingLogFileName, shp.LogFile())
+	}
+	if reloaded, _ := shp.Reload(); reloaded {
+		t.Error("expected an unchanged config not to be reloaded")
+	}
+
+	if shp.Handle(strings.Replace(testLine, "demo1", "demo2", -1)) {
+		t.Error("expected a request of a Delivery Service which doesn't ship logs not to fill a batch")
+	}
+	if shp.Handle(testLine) {
+		t.Error("expected one line not to fill a batch")
+	}
+	if !shp.Handle(testLine) {
+		t.Error("expected two lines to fill a batch")
+	}
+	shp.Flush()
+	dest := dests["demo1"]
+	if dest == nil || len(dest.sent) != 2 {
+		t.Fatalf("expected 2 lines shipped, actual: %+v", dest)
+	}
+	if !strings.HasPrefix(dest.sent[0], "1652000000.123 12 192.0.2.0 ") {
+		t.Errorf("expected a squid line with a masked client IP, actual: %s", dest.sent[0])
+	}
+
+	dest.fail = true
+	for i := 0; i < 5; i++ {
+		shp.Handle(testLine)
+	}
+	shp.Flush()
+	if len(shp.dses[0].buf) != 3 {
+		t.Errorf("expected lines which failed to ship to stay buffered up to the max, actual: %d", len(shp.dses[0].buf))
+	}
+
+	// removing the Delivery Service flushes its buffer one last time.
+	dest.fail = false
+	writeTestConfig(t, path)
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatalf("changing config modification time: %v", err)
+	}
+	if reloaded, err := shp.Reload(); err != nil || !reloaded {
+		t.Fatalf("expected changed config to be reloaded, actual: %v %v", reloaded, err)
+	}
+	if len(dest.sent) != 5 || !dest.closed {
+		t.Errorf("expected buffered lines to be shipped and the destination closed, actual: %d %v", len(dest.sent), dest.closed)
+	}
+	if shp.Handle(testLine) {
+		t.Error("expected no lines to be buffered once no Delivery Services ship logs")
+	}
+}
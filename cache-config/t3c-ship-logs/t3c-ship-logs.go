@@ -0,0 +1,149 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3c-ship-logs/config"
+	"github.com/apache/trafficcontrol/cache-config/t3c-ship-logs/shipper"
+	"github.com/apache/trafficcontrol/lib/go-log"
+
+	"github.com/nxadm/tail"
+)
+
+// Version is the application version.
+// This is overwritten by the build with the current project version.
+var Version = "0.4"
+
+// GitRevision is the git revision the application was built from.
+// This is overwritten by the build with the current project version.
+var GitRevision = "nogit"
+
+const ExitCodeSuccess = 0
+const ExitCodeConfigError = 1
+const ExitCodeTailError = 2
+
+func main() {
+	cfg, err := config.InitConfig(Version, GitRevision)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err.Error())
+		os.Exit(ExitCodeConfigError)
+	}
+	log.Infoln("configuration initialized")
+	cfg.PrintConfig()
+
+	shp := shipper.New(shipper.Opts{
+		ConfigFile:     cfg.ConfigFile,
+		BatchLines:     cfg.BatchLines,
+		MaxBufferLines: cfg.MaxBufferLines,
+		Destination: shipper.DestinationOpts{
+			HostName:  cfg.CacheHostName,
+			UserAgent: cfg.UserAgent(),
+			Timeout:   cfg.Timeout,
+			Client:    &http.Client{Timeout: cfg.Timeout},
+		},
+	})
+	if _, err := shp.Reload(); err != nil {
+		log.Errorf("loading log shipping config: %s\n", err.Error())
+		os.Exit(ExitCodeConfigError)
+	}
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	flushTicker := time.NewTicker(cfg.FlushInterval)
+	defer flushTicker.Stop()
+	reloadTicker := time.NewTicker(cfg.ReloadInterval)
+	defer reloadTicker.Stop()
+
+	// The access log is only tailed while logs are shipped, and is tailed
+	// from its end, so requests logged while nothing is shipped, or the
+	// shipper isn't running, are never shipped.
+	var t *tail.Tail
+	var lines chan *tail.Line
+	logFile := ""
+	retail := func() {
+		if shp.LogFile() == logFile {
+			return
+		}
+		if t != nil {
+			t.Stop()
+			t.Cleanup()
+			t, lines = nil, nil
+		}
+		logFile = shp.LogFile()
+		if logFile == "" {
+			log.Infoln("no Delivery Services ship logs, not tailing the access log")
+			return
+		}
+		path := filepath.Join(cfg.LogDir, logFile)
+		if t, err = tail.TailFile(path, tail.Config{
+			Follow:   true,
+			ReOpen:   true,
+			Logger:   tail.DiscardingLogger,
+			Location: &tail.SeekInfo{Offset: 0, Whence: 2},
+		}); err != nil {
+			log.Errorf("tailing access log '%s': %s\n", path, err.Error())
+			os.Exit(ExitCodeTailError)
+		}
+		lines = t.Lines
+		log.Infof("tailing access log '%s'\n", path)
+	}
+	retail()
+
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				log.Errorf("tailing access log stopped: %v\n", t.Err())
+				shp.Flush()
+				shp.Close()
+				os.Exit(ExitCodeTailError)
+			}
+			if line.Err != nil {
+				log.Warnf("reading access log: %s\n", line.Err.Error())
+				continue
+			}
+			if shp.Handle(line.Text) {
+				shp.Flush()
+			}
+		case <-flushTicker.C:
+			shp.Flush()
+		case <-reloadTicker.C:
+			if reloaded, err := shp.Reload(); err != nil {
+				log.Errorf("reloading log shipping config, continuing with the previous config: %s\n", err.Error())
+			} else if reloaded {
+				log.Infoln("reloaded log shipping config")
+				retail()
+			}
+		case sig := <-sigs:
+			log.Infof("received %s, shipping buffered logs and exiting\n", sig)
+			shp.Flush()
+			shp.Close()
+			os.Exit(ExitCodeSuccess)
+		}
+	}
+}
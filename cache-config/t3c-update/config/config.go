@@ -22,7 +22,6 @@ package config
 import (
 	"errors"
 	"fmt"
-	"net/url"
 	"os"
 	"time"
 
@@ -47,6 +46,9 @@ type Cfg struct {
 	RevalApplyTime   *time.Time
 	ConfigApplyBool  *bool
 	RevalApplyBool   *bool
+	// ReportConfigRun is whether to report the outcome of a t3c-apply run,
+	// read from stdin, rather than set update statuses.
+	ReportConfigRun bool
 	t3cutil.TCCfg
 	Version     string
 	GitRevision string
@@ -75,11 +77,16 @@ func InitConfig(appVersion string, gitRevision string) (Cfg, error) {
 	configApplyTimeStringPtr := getopt.StringLong(setConfigApplyTimeFlagName, 'q', "", "[RFC3339Nano Timestamp] sets the server's config apply time")
 	const setRevalApplyTimeFlagName = "set-reval-apply-time"
 	revalApplyTimeStringPtr := getopt.StringLong(setRevalApplyTimeFlagName, 'a', "", "[RFC3339Nano Timestamp] sets the server's reval apply time")
+	const reportConfigRunFlagName = "report-config-run"
+	reportConfigRunPtr := getopt.BoolLong(reportConfigRunFlagName, 'r', "Report the outcome of a t3c-apply run to Traffic Ops, read as JSON from stdin, instead of setting update statuses")
 	toInsecurePtr := getopt.BoolLong("traffic-ops-insecure", 'I', "[true | false] ignore certificate errors from Traffic Ops")
 	toTimeoutMSPtr := getopt.IntLong("traffic-ops-timeout-milliseconds", 't', 30000, "Timeout in milli-seconds for Traffic Ops requests, default is 30000")
-	toURLPtr := getopt.StringLong("traffic-ops-url", 'u', "", "Traffic Ops URL. Must be the full URL, including the scheme. Required. May also be set with     the environment variable TO_URL")
-	toUserPtr := getopt.StringLong("traffic-ops-user", 'U', "", "Traffic Ops username. Required. May also be set with the environment variable TO_USER")
-	toPassPtr := getopt.StringLong("traffic-ops-password", 'P', "", "Traffic Ops password. Required. May also be set with the environment variable TO_PASS    ")
+	toURLPtr := getopt.StringLong("traffic-ops-url", 'u', "", "Traffic Ops URL. Must be the full URL, including the scheme. May be a comma-delimited list of URLs, in order of preference, to fail over between. Required. May also be set with the environment variable TO_URL")
+	toUserPtr := getopt.StringLong("traffic-ops-user", 'U', "", "Traffic Ops username. Required unless a token or client certificate is given. May also be set with the environment variable TO_USER")
+	toPassPtr := getopt.StringLong("traffic-ops-password", 'P', "", "Traffic Ops password. Required unless a token or client certificate is given. May also be set with the environment variable TO_PASS    ")
+	toTokenPtr := getopt.StringLong("traffic-ops-token", 0, "", "Traffic Ops authentication token, used instead of the username and password. May also be set with the environment variable TO_TOKEN")
+	toClientCertPtr := getopt.StringLong("traffic-ops-client-cert", 0, "", "Path to a TLS client certificate with which to authenticate with Traffic Ops, instead of the username and password. Requires traffic-ops-client-key")
+	toClientKeyPtr := getopt.StringLong("traffic-ops-client-key", 0, "", "Path to the private key of the traffic-ops-client-cert certificate")
 	helpPtr := getopt.BoolLong("help", 'h', "Print usage information and exit")
 	versionPtr := getopt.BoolLong("version", 'V', "Print the version")
 	verbosePtr := getopt.CounterLong("verbose", 'v', `Log verbosity. Logging is output to stderr. By default, errors are logged. To log warnings, pass '-v'. To log info, pass '-vv'. To omit error logging, see '-s'`)
@@ -103,9 +110,9 @@ func InitConfig(appVersion string, gitRevision string) (Cfg, error) {
 	}
 
 	// Verify at least one flag is passed
-	if (!getopt.IsSet(setConfigApplyTimeFlagName) && !getopt.IsSet(setRevalApplyTimeFlagName)) &&
+	if !*reportConfigRunPtr && (!getopt.IsSet(setConfigApplyTimeFlagName) && !getopt.IsSet(setRevalApplyTimeFlagName)) &&
 		(!getopt.IsSet(setConfigApplyBoolFlagName) && !getopt.IsSet(setRevalApplyBoolFlagName)) { // TODO: Remove once ATC (v7.0+) is deployed
-		fmt.Printf("Must set either %s or %s. One is at least required.\n", setConfigApplyTimeFlagName, setRevalApplyTimeFlagName)
+		fmt.Printf("Must set either %s or %s, or %s. One is at least required.\n", setConfigApplyTimeFlagName, setRevalApplyTimeFlagName, reportConfigRunFlagName)
 		os.Exit(0)
 	}
 
@@ -192,12 +199,14 @@ func InitConfig(appVersion string, gitRevision string) (Cfg, error) {
 	if *toPassPtr == "" {
 		toPass = os.Getenv("TO_PASS")
 	}
+	toToken := *toTokenPtr
+	if toToken == "" {
+		toToken = os.Getenv("TO_TOKEN")
+	}
 
-	toURLParsed, err := url.Parse(toURL)
+	toURLs, err := t3cutil.ParseURLs(toURL)
 	if err != nil {
 		return Cfg{}, errors.New("parsing Traffic Ops URL from " + urlSourceStr + " '" + toURL + "': " + err.Error())
-	} else if err := t3cutil.ValidateURL(toURLParsed); err != nil {
-		return Cfg{}, errors.New("invalid Traffic Ops URL from " + urlSourceStr + " '" + toURL + "': " + err.Error())
 	}
 
 	var cacheHostName string
@@ -221,14 +230,19 @@ func InitConfig(appVersion string, gitRevision string) (Cfg, error) {
 		RevalApplyTime:   revalApplyTimePtr,
 		ConfigApplyBool:  configApplyBoolPtr,
 		RevalApplyBool:   revalApplyBoolPtr,
+		ReportConfigRun:  *reportConfigRunPtr,
 		TCCfg: t3cutil.TCCfg{
-			CacheHostName: cacheHostName,
-			GetData:       "update-status",
-			TOInsecure:    *toInsecurePtr,
-			TOTimeoutMS:   toTimeoutMS,
-			TOUser:        toUser,
-			TOPass:        toPass,
-			TOURL:         toURLParsed,
+			CacheHostName:    cacheHostName,
+			GetData:          "update-status",
+			TOInsecure:       *toInsecurePtr,
+			TOTimeoutMS:      toTimeoutMS,
+			TOUser:           toUser,
+			TOPass:           toPass,
+			TOToken:          toToken,
+			TOClientCertFile: *toClientCertPtr,
+			TOClientKeyFile:  *toClientKeyPtr,
+			TOURL:            toURLs[0],
+			TOURLs:           toURLs,
 		},
 		Version:     appVersion,
 		GitRevision: gitRevision,
@@ -20,6 +20,7 @@ package main
  */
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"time"
@@ -27,7 +28,6 @@ import (
 	"github.com/apache/trafficcontrol/cache-config/t3c-update/config"
 	"github.com/apache/trafficcontrol/cache-config/t3cutil"
 	"github.com/apache/trafficcontrol/cache-config/t3cutil/toreq"
-	"github.com/apache/trafficcontrol/cache-config/t3cutil/toreq/torequtil"
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-tc"
 )
@@ -49,13 +49,13 @@ func main() {
 		log.Infoln("configuration initialized")
 	}
 
-	cfg.TCCfg.TOClient, err = toreq.New(
-		cfg.TOURL,
-		cfg.TOUser,
-		cfg.TOPass,
+	cfg.TCCfg.TOClient, err = toreq.NewFailover(
+		cfg.TOURLs,
+		cfg.TOCredentials(),
 		cfg.TOInsecure,
 		cfg.TOTimeoutMS,
 		cfg.UserAgent(),
+		cfg.NumRetries,
 	)
 	if err != nil {
 		log.Errorf("%s\n", err)
@@ -65,6 +65,20 @@ func main() {
 		log.Warnln("Traffic Ops does not support the latest version supported by this app! Falling back to previous major Traffic Ops API version!")
 	}
 
+	if cfg.ReportConfigRun {
+		run := tc.ServerConfigRunRequest{}
+		if err := json.NewDecoder(os.Stdin).Decode(&run); err != nil {
+			log.Errorf("reading config run from stdin: %s\n", err)
+			os.Exit(5)
+		}
+		if err := t3cutil.SetServerConfigRun(cfg.TCCfg, tc.CacheName(cfg.TCCfg.CacheHostName), run); err != nil {
+			log.Errorf("%s, %s\n", err, cfg.TCCfg.CacheHostName)
+			os.Exit(3)
+		}
+		cfg.TCCfg.TOClient.WriteFsCookie(cfg.TOCredentials().CookieCachePath())
+		return
+	}
+
 	// *** Compatability requirement until ATC (v7.0+) is deployed with the timestamp features
 	// Use SetUpdateStatus is preferred
 	err = t3cutil.SetUpdateStatusCompat(cfg.TCCfg, tc.CacheName(cfg.TCCfg.CacheHostName), cfg.ConfigApplyTime, cfg.RevalApplyTime, cfg.ConfigApplyBool, cfg.RevalApplyBool)
@@ -90,5 +104,5 @@ func main() {
 	if cfg.RevalApplyTime != nil && !(*cfg.RevalApplyTime).Round(time.Microsecond).Equal((*cur_status.RevalidateApplyTime).Round(time.Microsecond)) {
 		log.Errorf("Failed to set reval_apply_time.\nSent: %v\nRecv: %v", *cfg.RevalApplyTime, *cur_status.RevalidateApplyTime)
 	}
-	cfg.TCCfg.TOClient.WriteFsCookie(torequtil.CookieCachePath(cfg.TOUser))
+	cfg.TCCfg.TOClient.WriteFsCookie(cfg.TOCredentials().CookieCachePath())
 }
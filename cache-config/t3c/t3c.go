@@ -42,12 +42,17 @@ var Version = "0.4"
 var GitRevision = "nogit"
 
 var commands = map[string]struct{}{
+	"agent":      struct{}{},
 	"apply":      struct{}{},
 	"check":      struct{}{},
+	"compat":     struct{}{},
 	"diff":       struct{}{},
 	"generate":   struct{}{},
+	"log-agent":  struct{}{},
+	"nic":        struct{}{},
 	"preprocess": struct{}{},
 	"request":    struct{}{},
+	"ship-logs":  struct{}{},
 	"tail":       struct{}{},
 	"update":     struct{}{},
 }
@@ -110,13 +115,18 @@ For the arguments of a command, see 't3c <command> --help'.
 
 These are the available commands:
 
+  agent      run t3c, the health client, and serverchecks as one daemon
   apply      generate and apply configuration
 
   check      check that new config can be applied
+  compat     compare the config generated by two versions of t3c-generate
   diff       diff config files, with logic like ignoring comments
   generate   generate configuration from Traffic Ops data
+  log-agent  serve log tails and traffic_ctl commands to Traffic Ops
+  nic        report network interface errors to Traffic Ops
   preprocess preprocess generated config files
   request    request Traffic Ops data
+  ship-logs  ship Delivery Service access logs to their tenants
   tail       tail a log file
   update     update a cache's queue and reval status in Traffic Ops
 `
@@ -53,6 +53,18 @@ const (
 	// ActionLogActionUpdateFilesReval is writing and updating only revalidate ATS config files.
 	ActionLogActionUpdateFilesReval = ActionLogAction("update-files-reval")
 
+	// ActionLogActionStagedVerify is verifying the staged ATS config, with --staged.
+	ActionLogActionStagedVerify = ActionLogAction("staged-verify")
+
+	// ActionLogActionStagedSwap is moving the staged ATS config files into place, with --staged.
+	ActionLogActionStagedSwap = ActionLogAction("staged-swap")
+
+	// ActionLogActionStagedCanary is checking the canary URLs after applying staged config, with --staged.
+	ActionLogActionStagedCanary = ActionLogAction("staged-canary")
+
+	// ActionLogActionStagedRollback is restoring the ATS config files replaced by staged config, with --staged.
+	ActionLogActionStagedRollback = ActionLogAction("staged-rollback")
+
 	// ActionLogActionATSReload is calling service reload on ATS.
 	ActionLogActionATSReload = ActionLogAction("ats-reload")
 
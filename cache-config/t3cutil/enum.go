@@ -25,6 +25,14 @@ import (
 
 const ApplyCachePath = `/var/lib/trafficcontrol-cache-config/config-data.json`
 
+// ApplyMirrorPath is where t3c-apply keeps the config data it last applied
+// successfully, to apply if Traffic Ops is unreachable.
+const ApplyMirrorPath = `/var/lib/trafficcontrol-cache-config/config-data-mirror.json`
+
+// RequestExitCodeUnreachable is the exit code of t3c-request when none of the
+// Traffic Ops servers could be connected to.
+const RequestExitCodeUnreachable = 4
+
 // ServiceNeeds represents whether we need to reload or restart Traffic Server,
 // as returned by t3c-check-reload.
 //
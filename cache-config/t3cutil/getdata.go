@@ -45,10 +45,33 @@ type TCCfg struct {
 	TOURL         *url.URL
 	UserAgent     string
 
+	// TOURLs is all the Traffic Ops URLs, in order of preference, to fail over
+	// between. TOURL is the first.
+	TOURLs []*url.URL
+
+	// TOToken is an authentication token used instead of TOUser and TOPass,
+	// if not empty.
+	TOToken string
+
+	// TOClientCertFile and TOClientKeyFile are the paths to a TLS client
+	// certificate and its private key, used instead of TOUser and TOPass if
+	// TOClientCertFile is not empty and TOToken is.
+	TOClientCertFile string
+	TOClientKeyFile  string
+
+	// NumRetries is the number of times to retry Traffic Ops requests, with
+	// exponential backoff, before giving up.
+	NumRetries int
+
 	// TODisableProxy is whether to not use a configured Traffic Ops Proxy.
 	// This is only used by WriteConfig, which is the only command that makes enough requests to matter.
 	TODisableProxy bool
 
+	// CDNSigningKeyFile is the path to the public signing key of the cache's
+	// CDN. If not empty, Traffic Ops responses without a valid signature by
+	// it are rejected.
+	CDNSigningKeyFile string
+
 	// RevalOnly is whether to only fetch config data necessary to revalidate, versus all data necessary to generate config. This is only used by WriteConfig
 	RevalOnly bool
 
@@ -60,6 +83,17 @@ type TCCfg struct {
 	T3CVersion string
 }
 
+// TOCredentials returns the credentials with which to authenticate with Traffic Ops.
+func (cfg TCCfg) TOCredentials() torequtil.Credentials {
+	return torequtil.Credentials{
+		User:           cfg.TOUser,
+		Pass:           cfg.TOPass,
+		Token:          cfg.TOToken,
+		ClientCertFile: cfg.TOClientCertFile,
+		ClientKeyFile:  cfg.TOClientKeyFile,
+	}
+}
+
 func GetDataFuncs() map[string]func(TCCfg, io.Writer) error {
 	return map[string]func(TCCfg, io.Writer) error{
 		`update-status`: WriteServerUpdateStatus,
@@ -257,6 +291,16 @@ func SetUpdateStatusCompat(cfg TCCfg, serverName tc.CacheName, configApply, reva
 	return nil
 }
 
+// SetServerConfigRun reports the outcome of a t3c-apply run on the given
+// server to Traffic Ops.
+func SetServerConfigRun(cfg TCCfg, serverName tc.CacheName, run tc.ServerConfigRunRequest) error {
+	reqInf, err := cfg.TOClient.SetServerConfigRun(serverName, run)
+	if err != nil {
+		return errors.New("reporting config run (Traffic Ops '" + torequtil.MaybeIPStr(reqInf.RemoteAddr) + "'): " + err.Error())
+	}
+	return nil
+}
+
 // WriteConfig writes the Traffic Ops data necessary to generate config to output.
 func WriteConfig(cfg TCCfg, output io.Writer) error {
 	cfgData, err := GetConfigData(cfg.TOClient, cfg.TODisableProxy, cfg.CacheHostName, cfg.RevalOnly, cfg.OldCfg, cfg.T3CVersion)
@@ -85,6 +85,24 @@ type ConfigData struct {
 	// CDN must be the CDN of the server.
 	CDN *tc.CDN `json:"cdn,omitempty"`
 
+	// DeliveryServiceRewriteRules must be the rewrite rules of all delivery services on this server's cdn which have any.
+	DeliveryServiceRewriteRules []tc.DeliveryServiceRewriteRules `json:"delivery_service_rewrite_rules,omitempty"`
+
+	// DeliveryServiceCachePolicies must be the cache policies of all delivery services on this server's cdn which have one.
+	DeliveryServiceCachePolicies []tc.DeliveryServiceCachePolicy `json:"delivery_service_cache_policies,omitempty"`
+
+	// DeliveryServicePathRules must be the path rules of all delivery services on this server's cdn which have any.
+	DeliveryServicePathRules []tc.DeliveryServicePathRules `json:"delivery_service_path_rules,omitempty"`
+
+	// DeliveryServiceTransformScripts must be the transformation scripts, with their content, of all delivery services on this server's cdn which have any.
+	DeliveryServiceTransformScripts []tc.DeliveryServiceTransformScripts `json:"delivery_service_transform_scripts,omitempty"`
+
+	// DeliveryServiceTokenAuth must be the token authentication settings of all delivery services on this server's cdn which have any.
+	DeliveryServiceTokenAuth []tc.DeliveryServiceTokenAuth `json:"delivery_service_token_auth,omitempty"`
+
+	// DeliveryServiceLogShipping must be the log shipping configuration of all delivery services on this server's cdn which have any.
+	DeliveryServiceLogShipping []tc.DeliveryServiceLogShipping `json:"delivery_service_log_shipping,omitempty"`
+
 	// DeliveryServiceRegexes must be all regexes on all delivery services on this server's cdn.
 	DeliveryServiceRegexes []tc.DeliveryServiceRegexes `json:"delivery_service_regexes,omitempty"`
 
@@ -129,6 +147,12 @@ type ConfigDataMetaData struct {
 	Jobs                   ReqMetaData                            `json:"jobs"`
 	CDN                    ReqMetaData                            `json:"cdn"`
 	DeliveryServiceRegexes ReqMetaData                            `json:"delivery_service_regexes"`
+	DSRewriteRules         ReqMetaData                            `json:"delivery_service_rewrite_rules"`
+	DSCachePolicies        ReqMetaData                            `json:"delivery_service_cache_policies"`
+	DSPathRules            ReqMetaData                            `json:"delivery_service_path_rules"`
+	DSTransformScripts     ReqMetaData                            `json:"delivery_service_transform_scripts"`
+	DSTokenAuth            ReqMetaData                            `json:"delivery_service_token_auth"`
+	DSLogShipping          ReqMetaData                            `json:"delivery_service_log_shipping"`
 	URISigningKeys         map[tc.DeliveryServiceName]ReqMetaData `json:"uri_signing_keys"`
 	URLSigKeys             map[tc.DeliveryServiceName]ReqMetaData `json:"url_sig_keys"`
 	ServerCapabilities     ReqMetaData                            `json:"server_capabilities"`
@@ -245,6 +269,17 @@ func GetConfigData(toClient *toreq.TOClient, disableProxy bool, cacheHostName st
 		log.Infoln("Traffic Ops proxy is disabled, not checking or using GLOBAL Parameter '" + TrafficOpsProxyParameterName)
 	}
 
+	if !revalOnly {
+		// Getting all the data in a single bundle is much faster than making
+		// every request, which are answered from it where possible.
+		reqInf, stopUsingCacheConfig := toClient.UseCacheConfig(cacheHostName)
+		defer stopUsingCacheConfig()
+		log.Infoln(toreq.RequestInfoStr(reqInf, "UseCacheConfig("+cacheHostName+")"))
+		if reqInf.RemoteAddr != nil {
+			toIPs.Store(reqInf.RemoteAddr, nil)
+		}
+	}
+
 	oldServer := &atscfg.Server{}
 	if oldCfg != nil {
 		for _, toServer := range oldCfg.Servers {
@@ -569,10 +604,178 @@ func GetConfigData(toClient *toreq.TOClient, disableProxy bool, cacheHostName st
 			}
 			return nil
 		}
+		rewriteRulesF := func() error {
+			defer func(start time.Time) { log.Infof("rewriteRulesF took %v\n", time.Since(start)) }(time.Now())
+			{
+				reqHdr := (http.Header)(nil)
+				if oldCfg != nil && oldServer.CDNName != nil && *oldServer.CDNName == *server.CDNName {
+					reqHdr = MakeReqHdr(oldCfg.MetaData.DSRewriteRules)
+				}
+				rules, reqInf, err := toClient.GetDeliveryServiceRewriteRules(*server.CDNName, reqHdr)
+				log.Infoln(toreq.RequestInfoStr(reqInf, "GetDeliveryServiceRewriteRules("+*server.CDNName+")"))
+				if err != nil {
+					if reqInf.StatusCode != http.StatusNotFound {
+						return errors.New("getting delivery service rewrite rules: " + err.Error())
+					}
+					log.Warnln("Traffic Ops doesn't support delivery service rewrite rules, continuing without them: " + err.Error())
+					return nil
+				}
+				if reqInf.StatusCode == http.StatusNotModified {
+					log.Infof("Getting config: %v not modified, using old config", "DeliveryServiceRewriteRules")
+					toData.DeliveryServiceRewriteRules = oldCfg.DeliveryServiceRewriteRules
+				} else {
+					log.Infof("Getting config: %v is modified, using new response", "DeliveryServiceRewriteRules")
+					toData.DeliveryServiceRewriteRules = rules
+				}
+				toData.MetaData.DSRewriteRules = MakeReqMetaData(reqInf.RespHeaders)
+				toIPs.Store(reqInf.RemoteAddr, nil)
+			}
+			return nil
+		}
+		tokenAuthF := func() error {
+			defer func(start time.Time) { log.Infof("tokenAuthF took %v\n", time.Since(start)) }(time.Now())
+			{
+				reqHdr := (http.Header)(nil)
+				if oldCfg != nil && oldServer.CDNName != nil && *oldServer.CDNName == *server.CDNName {
+					reqHdr = MakeReqHdr(oldCfg.MetaData.DSTokenAuth)
+				}
+				auths, reqInf, err := toClient.GetDeliveryServiceTokenAuth(*server.CDNName, reqHdr)
+				log.Infoln(toreq.RequestInfoStr(reqInf, "GetDeliveryServiceTokenAuth("+*server.CDNName+")"))
+				if err != nil {
+					if reqInf.StatusCode != http.StatusNotFound {
+						return errors.New("getting delivery service token auth: " + err.Error())
+					}
+					log.Warnln("Traffic Ops doesn't support delivery service token auth, continuing without it: " + err.Error())
+					return nil
+				}
+				if reqInf.StatusCode == http.StatusNotModified {
+					log.Infof("Getting config: %v not modified, using old config", "DeliveryServiceTokenAuth")
+					toData.DeliveryServiceTokenAuth = oldCfg.DeliveryServiceTokenAuth
+				} else {
+					log.Infof("Getting config: %v is modified, using new response", "DeliveryServiceTokenAuth")
+					toData.DeliveryServiceTokenAuth = auths
+				}
+				toData.MetaData.DSTokenAuth = MakeReqMetaData(reqInf.RespHeaders)
+				toIPs.Store(reqInf.RemoteAddr, nil)
+			}
+			return nil
+		}
+		logShippingF := func() error {
+			defer func(start time.Time) { log.Infof("logShippingF took %v\n", time.Since(start)) }(time.Now())
+			{
+				reqHdr := (http.Header)(nil)
+				if oldCfg != nil && oldServer.CDNName != nil && *oldServer.CDNName == *server.CDNName {
+					reqHdr = MakeReqHdr(oldCfg.MetaData.DSLogShipping)
+				}
+				all, reqInf, err := toClient.GetDeliveryServiceLogShipping(*server.CDNName, reqHdr)
+				log.Infoln(toreq.RequestInfoStr(reqInf, "GetDeliveryServiceLogShipping("+*server.CDNName+")"))
+				if err != nil {
+					if reqInf.StatusCode != http.StatusNotFound {
+						return errors.New("getting delivery service log shipping: " + err.Error())
+					}
+					log.Warnln("Traffic Ops doesn't support delivery service log shipping, continuing without it: " + err.Error())
+					return nil
+				}
+				if reqInf.StatusCode == http.StatusNotModified {
+					log.Infof("Getting config: %v not modified, using old config", "DeliveryServiceLogShipping")
+					toData.DeliveryServiceLogShipping = oldCfg.DeliveryServiceLogShipping
+				} else {
+					log.Infof("Getting config: %v is modified, using new response", "DeliveryServiceLogShipping")
+					toData.DeliveryServiceLogShipping = all
+				}
+				toData.MetaData.DSLogShipping = MakeReqMetaData(reqInf.RespHeaders)
+				toIPs.Store(reqInf.RemoteAddr, nil)
+			}
+			return nil
+		}
+		cachePoliciesF := func() error {
+			defer func(start time.Time) { log.Infof("cachePoliciesF took %v\n", time.Since(start)) }(time.Now())
+			{
+				reqHdr := (http.Header)(nil)
+				if oldCfg != nil && oldServer.CDNName != nil && *oldServer.CDNName == *server.CDNName {
+					reqHdr = MakeReqHdr(oldCfg.MetaData.DSCachePolicies)
+				}
+				policies, reqInf, err := toClient.GetDeliveryServiceCachePolicies(*server.CDNName, reqHdr)
+				log.Infoln(toreq.RequestInfoStr(reqInf, "GetDeliveryServiceCachePolicies("+*server.CDNName+")"))
+				if err != nil {
+					if reqInf.StatusCode != http.StatusNotFound {
+						return errors.New("getting delivery service cache policies: " + err.Error())
+					}
+					log.Warnln("Traffic Ops doesn't support delivery service cache policies, continuing without them: " + err.Error())
+					return nil
+				}
+				if reqInf.StatusCode == http.StatusNotModified {
+					log.Infof("Getting config: %v not modified, using old config", "DeliveryServiceCachePolicies")
+					toData.DeliveryServiceCachePolicies = oldCfg.DeliveryServiceCachePolicies
+				} else {
+					log.Infof("Getting config: %v is modified, using new response", "DeliveryServiceCachePolicies")
+					toData.DeliveryServiceCachePolicies = policies
+				}
+				toData.MetaData.DSCachePolicies = MakeReqMetaData(reqInf.RespHeaders)
+				toIPs.Store(reqInf.RemoteAddr, nil)
+			}
+			return nil
+		}
+		pathRulesF := func() error {
+			defer func(start time.Time) { log.Infof("pathRulesF took %v\n", time.Since(start)) }(time.Now())
+			{
+				reqHdr := (http.Header)(nil)
+				if oldCfg != nil && oldServer.CDNName != nil && *oldServer.CDNName == *server.CDNName {
+					reqHdr = MakeReqHdr(oldCfg.MetaData.DSPathRules)
+				}
+				rules, reqInf, err := toClient.GetDeliveryServicePathRules(*server.CDNName, reqHdr)
+				log.Infoln(toreq.RequestInfoStr(reqInf, "GetDeliveryServicePathRules("+*server.CDNName+")"))
+				if err != nil {
+					if reqInf.StatusCode != http.StatusNotFound {
+						return errors.New("getting delivery service path rules: " + err.Error())
+					}
+					log.Warnln("Traffic Ops doesn't support delivery service path rules, continuing without them: " + err.Error())
+					return nil
+				}
+				if reqInf.StatusCode == http.StatusNotModified {
+					log.Infof("Getting config: %v not modified, using old config", "DeliveryServicePathRules")
+					toData.DeliveryServicePathRules = oldCfg.DeliveryServicePathRules
+				} else {
+					log.Infof("Getting config: %v is modified, using new response", "DeliveryServicePathRules")
+					toData.DeliveryServicePathRules = rules
+				}
+				toData.MetaData.DSPathRules = MakeReqMetaData(reqInf.RespHeaders)
+				toIPs.Store(reqInf.RemoteAddr, nil)
+			}
+			return nil
+		}
+		transformScriptsF := func() error {
+			defer func(start time.Time) { log.Infof("transformScriptsF took %v\n", time.Since(start)) }(time.Now())
+			{
+				reqHdr := (http.Header)(nil)
+				if oldCfg != nil && oldServer.CDNName != nil && *oldServer.CDNName == *server.CDNName {
+					reqHdr = MakeReqHdr(oldCfg.MetaData.DSTransformScripts)
+				}
+				scripts, reqInf, err := toClient.GetDeliveryServiceTransformScripts(*server.CDNName, reqHdr)
+				log.Infoln(toreq.RequestInfoStr(reqInf, "GetDeliveryServiceTransformScripts("+*server.CDNName+")"))
+				if err != nil {
+					if reqInf.StatusCode != http.StatusNotFound {
+						return errors.New("getting delivery service transform scripts: " + err.Error())
+					}
+					log.Warnln("Traffic Ops doesn't support delivery service transform scripts, continuing without them: " + err.Error())
+					return nil
+				}
+				if reqInf.StatusCode == http.StatusNotModified {
+					log.Infof("Getting config: %v not modified, using old config", "DeliveryServiceTransformScripts")
+					toData.DeliveryServiceTransformScripts = oldCfg.DeliveryServiceTransformScripts
+				} else {
+					log.Infof("Getting config: %v is modified, using new response", "DeliveryServiceTransformScripts")
+					toData.DeliveryServiceTransformScripts = scripts
+				}
+				toData.MetaData.DSTransformScripts = MakeReqMetaData(reqInf.RespHeaders)
+				toIPs.Store(reqInf.RemoteAddr, nil)
+			}
+			return nil
+		}
 		fs := []func() error{dsF, cdnF, jobsF}
 		fs = append(fs, serverParamsFs...)
 		if !revalOnly {
-			fs = append([]func() error{sslF}, fs...) // skip ssl keys for reval only, which doesn't need them
+			fs = append([]func() error{sslF, rewriteRulesF, tokenAuthF, logShippingF, cachePoliciesF, pathRulesF, transformScriptsF}, fs...) // skip ssl keys, rewrite rules, token auth, log shipping, cache policies, path rules, and transform scripts for reval only, which doesn't need them
 		}
 		return util.JoinErrs(runParallel(fs))
 	}
@@ -786,7 +989,10 @@ func GetConfigData(toClient *toreq.TOClient, disableProxy bool, cacheHostName st
 
 	toAddrSet := map[string]struct{}{} // use a set to remove duplicates
 	toIPs.Range(func(key, val interface{}) bool {
-		toAddrSet[key.(net.Addr).String()] = struct{}{}
+		// requests answered from the cache config bundle have no address
+		if addr, ok := key.(net.Addr); ok && addr != nil {
+			toAddrSet[addr.String()] = struct{}{}
+		}
 		return true
 	})
 	for addr, _ := range toAddrSet {
@@ -812,7 +1018,7 @@ func GetConfigData(toClient *toreq.TOClient, disableProxy bool, cacheHostName st
 
 	if len(errs) == 0 && toData.Server != nil {
 		err := error(nil)
-		toData.ServerParams, err = atscfg.GetServerParameters(toData.Server, combineParams(toData.ServerProfilesParams))
+		toData.ServerParams, err = atscfg.GetServerParameters(toData.Server, CombineParams(toData.ServerProfilesParams))
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -821,9 +1027,9 @@ func GetConfigData(toClient *toreq.TOClient, disableProxy bool, cacheHostName st
 	return toData, util.JoinErrs(errs)
 }
 
-// combineParams combines all the params from different profiles into
+// CombineParams combines all the params from different profiles into
 // a single array of parameters.
-func combineParams(profileParams map[atscfg.ProfileName][]tc.Parameter) []tc.Parameter {
+func CombineParams(profileParams map[atscfg.ProfileName][]tc.Parameter) []tc.Parameter {
 	allParams := map[atscfg.ProfileID]tc.Parameter{}
 	for profileName, params := range profileParams {
 		for _, param := range params {
@@ -236,6 +236,31 @@ func ValidateURL(u *url.URL) error {
 	return nil
 }
 
+// ParseURLs parses and validates a comma-delimited list of Traffic Ops URLs,
+// as accepted by the --traffic-ops-url flag and the TO_URL environment
+// variable. The URLs are returned in the given order, which is the order of
+// preference.
+func ParseURLs(urls string) ([]*url.URL, error) {
+	parsed := []*url.URL{}
+	for _, urlStr := range strings.Split(urls, ",") {
+		urlStr = strings.TrimSpace(urlStr)
+		if urlStr == "" {
+			continue
+		}
+		u, err := url.Parse(urlStr)
+		if err != nil {
+			return nil, errors.New("parsing '" + urlStr + "': " + err.Error())
+		} else if err := ValidateURL(u); err != nil {
+			return nil, errors.New("invalid URL '" + urlStr + "': " + err.Error())
+		}
+		parsed = append(parsed, u)
+	}
+	if len(parsed) == 0 {
+		return nil, errors.New("no URLs")
+	}
+	return parsed, nil
+}
+
 // VersionStr returns a common version string format for all t3c apps.
 // The appName is the command itself, e.g. t3c-apply.
 // The versionNum is the version number from the build system. It should include the major, minor, git revision, and a monotonically increasing number, e.g. '4.2.1234.abc123'.
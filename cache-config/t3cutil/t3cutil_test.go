@@ -21,6 +21,7 @@ package t3cutil
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -70,3 +71,26 @@ func TestSortAndCombineStrs(t *testing.T) {
 		}
 	}
 }
+
+func TestParseURLs(t *testing.T) {
+	urls, err := ParseURLs("https://to-1.example.net, https://to-2.example.net:8443/,")
+	if err != nil {
+		t.Fatalf("ParseURLs expected no error, actual %v", err)
+	}
+	actual := []string{}
+	for _, u := range urls {
+		actual = append(actual, u.String())
+	}
+	expected := []string{"https://to-1.example.net", "https://to-2.example.net:8443/"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("ParseURLs expected %+v actual %+v", expected, actual)
+	}
+
+	for _, invalid := range []string{"", " , ", "https://to-1.example.net,ftp://to-2.example.net", "to.example.net"} {
+		if _, err := ParseURLs(invalid); err == nil {
+			t.Errorf("ParseURLs('%s') expected error, actual nil", invalid)
+		} else if !strings.Contains(err.Error(), "URL") {
+			t.Errorf("ParseURLs('%s') expected error about URLs, actual '%v'", invalid, err)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package toreq
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/cache-config/t3cutil/toreq/torequtil"
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+	toclient "github.com/apache/trafficcontrol/traffic_ops/v4-client"
+)
+
+// UseCacheConfig gets the bundle of all the data the cache server with the
+// given host name needs to generate its configuration from Traffic Ops in a
+// single request, and makes the client answer requests for that data from the
+// bundle instead of Traffic Ops.
+//
+// Requests for data not in the bundle are still sent to Traffic Ops, so if
+// Traffic Ops doesn't support bundles, or getting the bundle fails, the client
+// just makes all its requests as usual.
+//
+// Returns the ReqInf of the bundle request, and a func to stop using the
+// bundle, which must be called once the data has been requested.
+func (cl *TOClient) UseCacheConfig(hostName string) (toclientlib.ReqInf, func()) {
+	if cl.c == nil {
+		log.Infoln("Traffic Ops client fell back to the previous API, not requesting cache config bundle")
+		return toclientlib.ReqInf{}, func() {}
+	}
+	bundle, reqInf, err := cl.c.GetCacheConfig(hostName, toclient.RequestOptions{})
+	if err != nil {
+		log.Infof("getting cache config bundle from Traffic Ops '%s' code %d, requesting data individually: %v\n", torequtil.MaybeIPStr(reqInf.RemoteAddr), reqInf.StatusCode, err)
+		return reqInf, func() {}
+	}
+	log.Infof("got cache config bundle with %d responses\n", len(bundle.Response.Responses))
+
+	httpClient := cl.HTTPClient()
+	base := httpClient.Transport
+	httpClient.Transport = newCacheConfigTransport(base, bundle.Response, reqInf.RespHeaders.Get(rfc.Date))
+	return reqInf, func() { httpClient.Transport = base }
+}
+
+// apiVersionPathRe matches the API version prefix of a Traffic Ops API request
+// path, e.g. "/api/4.1/".
+var apiVersionPathRe = regexp.MustCompile(`^/api/[0-9]+\.[0-9]+/`)
+
+// cacheConfigTransport is an http.RoundTripper which answers GET requests for
+// data in a cache config bundle from the bundle, and sends all other requests
+// to its base.
+type cacheConfigTransport struct {
+	base    http.RoundTripper
+	entries map[string]tc.CacheConfigEntry
+	// date is the Date of the bundle response.
+	date string
+}
+
+func newCacheConfigTransport(base http.RoundTripper, bundle tc.CacheConfig, date string) *cacheConfigTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	entries := map[string]tc.CacheConfigEntry{}
+	for _, entry := range bundle.Responses {
+		entries[entry.Path] = entry
+	}
+	return &cacheConfigTransport{base: base, entries: entries, date: date}
+}
+
+func (t *cacheConfigTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.base.RoundTrip(req)
+	}
+	path := req.URL.EscapedPath()
+	prefix := apiVersionPathRe.FindString(path)
+	if prefix == "" {
+		return t.base.RoundTrip(req)
+	}
+	entry, ok := t.entries[tc.CacheConfigPath(path[len(prefix):], req.URL.Query())]
+	if !ok {
+		log.Infof("cache config bundle has no '%s', requesting it from Traffic Ops\n", req.URL.RequestURI())
+		return t.base.RoundTrip(req)
+	}
+
+	hdr := http.Header{}
+	hdr.Set(rfc.ContentType, rfc.ApplicationJSON)
+	if t.date != "" {
+		hdr.Set(rfc.Date, t.date)
+	}
+	if entry.LastModified != "" {
+		hdr.Set(rfc.LastModified, entry.LastModified)
+	}
+
+	code := http.StatusOK
+	body := []byte(entry.Body)
+	if notModified(req.Header, entry.LastModified) {
+		code = http.StatusNotModified
+		body = nil
+	}
+
+	return &http.Response{
+		Status:        strconv.Itoa(code) + " " + http.StatusText(code),
+		StatusCode:    code,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        hdr,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// notModified returns whether a response last modified at lastModified
+// satisfies the If-Modified-Since header in the request headers reqHdr.
+func notModified(reqHdr http.Header, lastModified string) bool {
+	ims, ok := rfc.ParseHTTPDate(reqHdr.Get(rfc.IfModifiedSince))
+	if !ok {
+		return false
+	}
+	lm, ok := rfc.ParseHTTPDate(lastModified)
+	if !ok {
+		return false
+	}
+	return !lm.After(ims)
+}
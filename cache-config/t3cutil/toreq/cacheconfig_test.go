@@ -0,0 +1,93 @@
+package toreq
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestCacheConfigTransport(t *testing.T) {
+	toRequests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		toRequests++
+		w.Write([]byte(`{"response": "from traffic ops"}`))
+	}))
+	defer srv.Close()
+
+	bundle := tc.CacheConfig{
+		Server: "edge",
+		Responses: []tc.CacheConfigEntry{
+			{Path: "servers", LastModified: "Mon, 02 Jan 2006 15:04:05 GMT", Body: []byte(`{"response": "servers"}`)},
+			{Path: "jobs?cdn=cdn1&maxRevalDurationDays=", Body: []byte(`{"response": "jobs"}`)},
+		},
+	}
+	client := &http.Client{Transport: newCacheConfigTransport(http.DefaultTransport, bundle, "Tue, 03 Jan 2006 15:04:05 GMT")}
+
+	get := func(path string, hdr http.Header) (int, string) {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, srv.URL+path, nil)
+		if err != nil {
+			t.Fatalf("creating request: %v", err)
+		}
+		if hdr != nil {
+			req.Header = hdr
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("requesting '%s': %v", path, err)
+		}
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		return resp.StatusCode, string(body)
+	}
+
+	if code, body := get("/api/4.1/servers", nil); code != http.StatusOK || body != `{"response": "servers"}` {
+		t.Errorf("expected bundled servers, actual: %d '%s'", code, body)
+	}
+	if code, body := get("/api/4.1/jobs?maxRevalDurationDays=&cdn=cdn1", nil); code != http.StatusOK || body != `{"response": "jobs"}` {
+		t.Errorf("expected bundled jobs regardless of query parameter order, actual: %d '%s'", code, body)
+	}
+	if toRequests != 0 {
+		t.Errorf("expected bundled requests not to be sent to Traffic Ops, actual: %d requests", toRequests)
+	}
+
+	ims := http.Header{}
+	ims.Set(rfc.IfModifiedSince, "Mon, 02 Jan 2006 15:04:06 GMT")
+	if code, _ := get("/api/4.1/servers", ims); code != http.StatusNotModified {
+		t.Errorf("expected bundled servers not modified since a later date, actual: %d", code)
+	}
+	ims.Set(rfc.IfModifiedSince, "Mon, 02 Jan 2006 15:04:04 GMT")
+	if code, _ := get("/api/4.1/servers", ims); code != http.StatusOK {
+		t.Errorf("expected bundled servers modified since an earlier date, actual: %d", code)
+	}
+
+	if code, body := get("/api/4.1/jobs?cdn=cdn2&maxRevalDurationDays=", nil); code != http.StatusOK || body != `{"response": "from traffic ops"}` {
+		t.Errorf("expected request not in the bundle to be sent to Traffic Ops, actual: %d '%s'", code, body)
+	}
+	if toRequests != 1 {
+		t.Errorf("expected 1 request to Traffic Ops, actual: %d", toRequests)
+	}
+}
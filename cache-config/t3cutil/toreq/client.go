@@ -42,6 +42,7 @@ import (
 	"github.com/apache/trafficcontrol/cache-config/t3cutil/toreq/torequtil"
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc/signing"
 	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
 	toclient "github.com/apache/trafficcontrol/traffic_ops/v4-client"
 )
@@ -64,6 +65,10 @@ type TOClient struct {
 	// NumRetries is the number of times to retry Traffic Ops server failures
 	// before giving up and returning an error to the caller.
 	NumRetries int
+
+	// failover is the transport failing over between Traffic Ops servers,
+	// if the client was created by NewFailover.
+	failover *failoverTransport
 }
 
 func (cl *TOClient) URL() string {
@@ -88,8 +93,27 @@ func (cl *TOClient) HTTPClient() *http.Client {
 	return cl.c.Client
 }
 
+// VerifySignatures makes the client request signed responses from Traffic Ops,
+// and reject successful GET responses without a valid signature by the
+// verifier's CDN signing key.
+func (cl *TOClient) VerifySignatures(verifier signing.Verifier) {
+	httpClient := cl.HTTPClient()
+	httpClient.Transport = signing.NewTransport(httpClient.Transport, verifier)
+}
+
 // New logs into Traffic Ops, returning the TOClient which contains the logged-in client.
-func New(url *url.URL, user string, pass string, insecure bool, timeout time.Duration, userAgent string) (*TOClient, error) {
+func New(url *url.URL, creds torequtil.Credentials, insecure bool, timeout time.Duration, userAgent string) (*TOClient, error) {
+	if creds.Token != "" {
+		log.Infoln("URL: '" + url.String() + "' Token len: '" + strconv.Itoa(len(creds.Token)) + "'")
+		return newWithToken(url, creds, insecure, timeout, userAgent)
+	}
+	if creds.ClientCertFile != "" {
+		log.Infoln("URL: '" + url.String() + "' Client Certificate: '" + creds.ClientCertFile + "'")
+		return newWithCertificate(url, creds, insecure, timeout, userAgent)
+	}
+
+	user := creds.User
+	pass := creds.Pass
 	log.Infoln("URL: '" + url.String() + "' User: '" + user + "' Pass len: '" + strconv.Itoa(len(pass)) + "'")
 
 	cookiePath := torequtil.CookieCachePath(user)
@@ -97,19 +121,49 @@ func New(url *url.URL, user string, pass string, insecure bool, timeout time.Dur
 	fsCookie, err := torequtil.GetFsCookie(cookiePath)
 	if err != nil {
 		log.Infof("Failed to retrieve cached cookie for user '%v' at '%v', using password login: %v", user, cookiePath, err)
-		return newWithPassword(url, user, pass, insecure, timeout, userAgent)
+		return newWithPassword(url, creds, insecure, timeout, userAgent)
 	}
 
 	if fsCookie.Cookies == nil {
 		log.Infof("Cached cookie for user '%v' at '%v' not found, using password login", user, cookiePath)
-		return newWithPassword(url, user, pass, insecure, timeout, userAgent)
+		return newWithPassword(url, creds, insecure, timeout, userAgent)
 	}
 
 	log.Infof("Cached cookie for user '%v' at '%v' found, attempting to reuse cookie to avoid login", user, cookiePath)
-	return newWithCookie(url, user, pass, insecure, timeout, userAgent, fsCookie)
+	return newWithCookie(url, creds, insecure, timeout, userAgent, fsCookie)
+}
+
+func newWithToken(url *url.URL, creds torequtil.Credentials, insecure bool, timeout time.Duration, userAgent string) (*TOClient, error) {
+	toURLStr := makeTOURLStr(url)
+	log.Infoln("Traffic Ops URL string: '" + toURLStr + "'")
+
+	toClient, toAddr, err := toclient.LoginWithToken(toURLStr, creds.Token, insecure, userAgent, false, timeout)
+	if err != nil {
+		log.Infof("toreqnew.New logging into Traffic Ops '%v' with token: %v, falling back to older client\n", torequtil.MaybeIPStr(toAddr), err)
+		return checkLatestAndFallBack(nil, url, creds, insecure, timeout, userAgent)
+	}
+	return checkLatestAndFallBack(toClient, url, creds, insecure, timeout, userAgent)
+}
+
+// newWithCertificate logs in with a client certificate. Unlike the other means
+// of authentication, this doesn't fall back to the older client, because
+// Traffic Ops only supports it in the latest API.
+func newWithCertificate(url *url.URL, creds torequtil.Credentials, insecure bool, timeout time.Duration, userAgent string) (*TOClient, error) {
+	toURLStr := makeTOURLStr(url)
+	log.Infoln("Traffic Ops URL string: '" + toURLStr + "'")
+
+	cert, err := tls.LoadX509KeyPair(creds.ClientCertFile, creds.ClientKeyFile)
+	if err != nil {
+		return nil, errors.New("loading Traffic Ops client certificate: " + err.Error())
+	}
+	toClient, toAddr, err := toclient.LoginWithCertificate(toURLStr, cert, insecure, userAgent, false, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("Logging in to Traffic Ops '%v' with client certificate: %v", torequtil.MaybeIPStr(toAddr), err)
+	}
+	return &TOClient{c: toClient}, nil
 }
 
-func newWithPassword(url *url.URL, user string, pass string, insecure bool, timeout time.Duration, userAgent string) (*TOClient, error) {
+func newWithPassword(url *url.URL, creds torequtil.Credentials, insecure bool, timeout time.Duration, userAgent string) (*TOClient, error) {
 	opts := toclient.Options{}
 	opts.Insecure = insecure
 	opts.UserAgent = userAgent
@@ -118,25 +172,25 @@ func newWithPassword(url *url.URL, user string, pass string, insecure bool, time
 	toURLStr := makeTOURLStr(url)
 	log.Infoln("Traffic Ops URL string: '" + toURLStr + "'")
 
-	toClient, inf, err := toclient.Login(toURLStr, user, pass, opts)
+	toClient, inf, err := toclient.Login(toURLStr, creds.User, creds.Pass, opts)
 	if err != nil {
 		if errIsUnsupportedVersion := inf.StatusCode == 404 || inf.StatusCode == 501; errIsUnsupportedVersion {
 			log.Infof("toreqnew.New logging into Traffic Ops '%v': got %v, falling back to older client\n", torequtil.MaybeIPStr(inf.RemoteAddr), inf.StatusCode)
-			return checkLatestAndFallBack(nil, url, user, pass, insecure, timeout, userAgent)
+			return checkLatestAndFallBack(nil, url, creds, insecure, timeout, userAgent)
 		}
 		return nil, fmt.Errorf("Logging in to Traffic Ops '%v' code %v: %v", torequtil.MaybeIPStr(inf.RemoteAddr), inf.StatusCode, err)
 	}
 
 	// we successfully logged in, but the login may not have used the latest API,
 	// double-check the client's API is supported.
-	return checkLatestAndFallBack(toClient, url, user, pass, insecure, timeout, userAgent)
+	return checkLatestAndFallBack(toClient, url, creds, insecure, timeout, userAgent)
 }
 
-func newWithCookie(url *url.URL, user string, pass string, insecure bool, timeout time.Duration, userAgent string, fsCookie torequtil.FsCookie) (*TOClient, error) {
+func newWithCookie(url *url.URL, creds torequtil.Credentials, insecure bool, timeout time.Duration, userAgent string, fsCookie torequtil.FsCookie) (*TOClient, error) {
 	toURLStr := makeTOURLStr(url)
 	log.Infoln("Traffic Ops URL string: '" + toURLStr + "'")
 
-	toClient := toclient.NewSession(user, pass, toURLStr, userAgent, &http.Client{
+	toClient := toclient.NewSession(creds.User, creds.Pass, toURLStr, userAgent, &http.Client{
 		Timeout: timeout,
 		Transport: &http.Transport{
 			TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
@@ -148,7 +202,7 @@ func newWithCookie(url *url.URL, user string, pass string, insecure bool, timeou
 		return nil, errors.New("error creating cookie jar: " + err.Error())
 	}
 	toClient.Client.Jar.SetCookies(url, fsCookie.GetHTTPCookies())
-	return checkLatestAndFallBack(toClient, url, user, pass, insecure, timeout, userAgent)
+	return checkLatestAndFallBack(toClient, url, creds, insecure, timeout, userAgent)
 }
 
 // checkLatestAndFallBack takes a client and checks if it supports the latest Traffic ops API.
@@ -158,7 +212,7 @@ func newWithCookie(url *url.URL, user string, pass string, insecure bool, timeou
 // the latest API isn't supported and fallback will be tried.
 //
 // Returns a TOClient which is the latest if supported or has fallen back to the previous API if not, and any error.
-func checkLatestAndFallBack(client *toclient.Session, url *url.URL, user string, pass string, insecure bool, timeout time.Duration, userAgent string) (*TOClient, error) {
+func checkLatestAndFallBack(client *toclient.Session, url *url.URL, creds torequtil.Credentials, insecure bool, timeout time.Duration, userAgent string) (*TOClient, error) {
 	latestSupported, toAddr, err := IsLatestSupported(client)
 	if err != nil {
 		return nil, errors.New("checking Traffic Ops '" + torequtil.MaybeIPStr(toAddr) + "' support: " + err.Error())
@@ -171,7 +225,7 @@ func checkLatestAndFallBack(client *toclient.Session, url *url.URL, user string,
 
 	log.Warnf("Traffic Ops '%v' does not support the latest client API version %v, falling back to the previous\n", torequtil.MaybeIPStr(toAddr), LatestKnownAPIVersion())
 
-	oldClient, err := toreqold.New(url, user, pass, insecure, timeout, userAgent)
+	oldClient, err := toreqold.New(url, creds, insecure, timeout, userAgent)
 	if err != nil {
 		return nil, errors.New("logging into old client: " + err.Error())
 	}
@@ -67,7 +67,12 @@ func (cl *TOClient) GetProfileByName(profileName string, reqHdr http.Header) (tc
 	return profile, reqInf, nil
 }
 
+// WriteFsCookie writes the client's session cookies to the file fileName, so
+// later runs can reuse them. If fileName is empty, nothing is written.
 func (cl *TOClient) WriteFsCookie(fileName string) {
+	if fileName == "" {
+		return
+	}
 	tmpFileName := fileName + ".tmp"
 	cookie := torequtil.FsCookie{}
 	u, err := url.Parse(cl.URL())
@@ -551,6 +556,168 @@ func (cl *TOClient) GetDeliveryServiceRegexes(reqHdr http.Header) ([]tc.Delivery
 	return regexes, reqInf, nil
 }
 
+// GetDeliveryServiceRewriteRules returns the rewrite rules of all Delivery
+// Services on the given CDN which have any.
+func (cl *TOClient) GetDeliveryServiceRewriteRules(cdnName string, reqHdr http.Header) ([]tc.DeliveryServiceRewriteRules, toclientlib.ReqInf, error) {
+	if cl.c == nil {
+		return nil, toclientlib.ReqInf{}, nil // Traffic Ops versions without APIv4 don't have rewrite rules
+	}
+
+	rules := []tc.DeliveryServiceRewriteRules{}
+	reqInf := toclientlib.ReqInf{}
+	err := torequtil.GetRetry(cl.NumRetries, "ds_rewrite_rules_cdn_"+cdnName, &rules, func(obj interface{}) error {
+		opts := *ReqOpts(reqHdr)
+		opts.QueryParameters.Set("cdn", cdnName)
+		toRules, toReqInf, err := cl.c.GetAllDeliveryServiceRewriteRules(opts)
+		reqInf = toReqInf
+		if err != nil {
+			return errors.New("getting ds rewrite rules from Traffic Ops '" + torequtil.MaybeIPStr(reqInf.RemoteAddr) + "': " + err.Error())
+		}
+		rules := obj.(*[]tc.DeliveryServiceRewriteRules)
+		*rules = toRules.Response
+		return nil
+	})
+	if err != nil {
+		return nil, reqInf, errors.New("getting ds rewrite rules: " + err.Error())
+	}
+	return rules, reqInf, nil
+}
+
+// GetDeliveryServiceTokenAuth returns the token authentication settings of
+// all Delivery Services on the given CDN which have any.
+func (cl *TOClient) GetDeliveryServiceTokenAuth(cdnName string, reqHdr http.Header) ([]tc.DeliveryServiceTokenAuth, toclientlib.ReqInf, error) {
+	if cl.c == nil {
+		return nil, toclientlib.ReqInf{}, nil // Traffic Ops versions without APIv4 don't have token authentication settings
+	}
+
+	auths := []tc.DeliveryServiceTokenAuth{}
+	reqInf := toclientlib.ReqInf{}
+	err := torequtil.GetRetry(cl.NumRetries, "ds_token_auth_cdn_"+cdnName, &auths, func(obj interface{}) error {
+		opts := *ReqOpts(reqHdr)
+		opts.QueryParameters.Set("cdn", cdnName)
+		toAuths, toReqInf, err := cl.c.GetAllDeliveryServiceTokenAuth(opts)
+		reqInf = toReqInf
+		if err != nil {
+			return errors.New("getting ds token auth from Traffic Ops '" + torequtil.MaybeIPStr(reqInf.RemoteAddr) + "': " + err.Error())
+		}
+		auths := obj.(*[]tc.DeliveryServiceTokenAuth)
+		*auths = toAuths.Response
+		return nil
+	})
+	if err != nil {
+		return nil, reqInf, errors.New("getting ds token auth: " + err.Error())
+	}
+	return auths, reqInf, nil
+}
+
+// GetDeliveryServiceLogShipping returns the log shipping configuration of all
+// Delivery Services on the given CDN which have any.
+func (cl *TOClient) GetDeliveryServiceLogShipping(cdnName string, reqHdr http.Header) ([]tc.DeliveryServiceLogShipping, toclientlib.ReqInf, error) {
+	if cl.c == nil {
+		return nil, toclientlib.ReqInf{}, nil // Traffic Ops versions without APIv4 don't have log shipping
+	}
+
+	all := []tc.DeliveryServiceLogShipping{}
+	reqInf := toclientlib.ReqInf{}
+	err := torequtil.GetRetry(cl.NumRetries, "ds_log_shipping_cdn_"+cdnName, &all, func(obj interface{}) error {
+		opts := *ReqOpts(reqHdr)
+		opts.QueryParameters.Set("cdn", cdnName)
+		toAll, toReqInf, err := cl.c.GetAllDeliveryServiceLogShipping(opts)
+		reqInf = toReqInf
+		if err != nil {
+			return errors.New("getting ds log shipping from Traffic Ops '" + torequtil.MaybeIPStr(reqInf.RemoteAddr) + "': " + err.Error())
+		}
+		all := obj.(*[]tc.DeliveryServiceLogShipping)
+		*all = toAll.Response
+		return nil
+	})
+	if err != nil {
+		return nil, reqInf, errors.New("getting ds log shipping: " + err.Error())
+	}
+	return all, reqInf, nil
+}
+
+// GetDeliveryServiceCachePolicies returns the cache policies of all Delivery
+// Services on the given CDN which have one.
+func (cl *TOClient) GetDeliveryServiceCachePolicies(cdnName string, reqHdr http.Header) ([]tc.DeliveryServiceCachePolicy, toclientlib.ReqInf, error) {
+	if cl.c == nil {
+		return nil, toclientlib.ReqInf{}, nil // Traffic Ops versions without APIv4 don't have cache policies
+	}
+
+	policies := []tc.DeliveryServiceCachePolicy{}
+	reqInf := toclientlib.ReqInf{}
+	err := torequtil.GetRetry(cl.NumRetries, "ds_cache_policies_cdn_"+cdnName, &policies, func(obj interface{}) error {
+		opts := *ReqOpts(reqHdr)
+		opts.QueryParameters.Set("cdn", cdnName)
+		toPolicies, toReqInf, err := cl.c.GetAllDeliveryServiceCachePolicies(opts)
+		reqInf = toReqInf
+		if err != nil {
+			return errors.New("getting ds cache policies from Traffic Ops '" + torequtil.MaybeIPStr(reqInf.RemoteAddr) + "': " + err.Error())
+		}
+		policies := obj.(*[]tc.DeliveryServiceCachePolicy)
+		*policies = toPolicies.Response
+		return nil
+	})
+	if err != nil {
+		return nil, reqInf, errors.New("getting ds cache policies: " + err.Error())
+	}
+	return policies, reqInf, nil
+}
+
+// GetDeliveryServicePathRules returns the path rules of all Delivery Services
+// on the given CDN which have any.
+func (cl *TOClient) GetDeliveryServicePathRules(cdnName string, reqHdr http.Header) ([]tc.DeliveryServicePathRules, toclientlib.ReqInf, error) {
+	if cl.c == nil {
+		return nil, toclientlib.ReqInf{}, nil // Traffic Ops versions without APIv4 don't have path rules
+	}
+
+	rules := []tc.DeliveryServicePathRules{}
+	reqInf := toclientlib.ReqInf{}
+	err := torequtil.GetRetry(cl.NumRetries, "ds_path_rules_cdn_"+cdnName, &rules, func(obj interface{}) error {
+		opts := *ReqOpts(reqHdr)
+		opts.QueryParameters.Set("cdn", cdnName)
+		toRules, toReqInf, err := cl.c.GetAllDeliveryServicePathRules(opts)
+		reqInf = toReqInf
+		if err != nil {
+			return errors.New("getting ds path rules from Traffic Ops '" + torequtil.MaybeIPStr(reqInf.RemoteAddr) + "': " + err.Error())
+		}
+		rules := obj.(*[]tc.DeliveryServicePathRules)
+		*rules = toRules.Response
+		return nil
+	})
+	if err != nil {
+		return nil, reqInf, errors.New("getting ds path rules: " + err.Error())
+	}
+	return rules, reqInf, nil
+}
+
+// GetDeliveryServiceTransformScripts returns the transformation scripts of all
+// Delivery Services on the given CDN which have any, with their content.
+func (cl *TOClient) GetDeliveryServiceTransformScripts(cdnName string, reqHdr http.Header) ([]tc.DeliveryServiceTransformScripts, toclientlib.ReqInf, error) {
+	if cl.c == nil {
+		return nil, toclientlib.ReqInf{}, nil // Traffic Ops versions without APIv4 don't have transformation scripts
+	}
+
+	scripts := []tc.DeliveryServiceTransformScripts{}
+	reqInf := toclientlib.ReqInf{}
+	err := torequtil.GetRetry(cl.NumRetries, "ds_transform_scripts_cdn_"+cdnName, &scripts, func(obj interface{}) error {
+		opts := *ReqOpts(reqHdr)
+		opts.QueryParameters.Set("cdn", cdnName)
+		toScripts, toReqInf, err := cl.c.GetAllDeliveryServiceTransformScripts(opts)
+		reqInf = toReqInf
+		if err != nil {
+			return errors.New("getting ds transform scripts from Traffic Ops '" + torequtil.MaybeIPStr(reqInf.RemoteAddr) + "': " + err.Error())
+		}
+		scripts := obj.(*[]tc.DeliveryServiceTransformScripts)
+		*scripts = toScripts.Response
+		return nil
+	})
+	if err != nil {
+		return nil, reqInf, errors.New("getting ds transform scripts: " + err.Error())
+	}
+	return scripts, reqInf, nil
+}
+
 func (cl *TOClient) GetJobs(reqHdr http.Header, cdnName string) ([]atscfg.InvalidationJob, toclientlib.ReqInf, error) {
 	if cl.c == nil {
 		oldJobs, inf, err := cl.old.GetJobs()
@@ -802,3 +969,48 @@ func (cl *TOClient) SetServerUpdateStatusBoolCompat(cacheHostName tc.CacheName,
 	}
 	return reqInf, nil
 }
+
+// SetServerConfigRun reports the outcome of a t3c-apply run on the given
+// server to Traffic Ops.
+func (cl *TOClient) SetServerConfigRun(cacheHostName tc.CacheName, run tc.ServerConfigRunRequest) (toclientlib.ReqInf, error) {
+	if cl.c == nil {
+		return toclientlib.ReqInf{}, errors.New("Traffic Ops versions without APIv4 don't support reporting config runs")
+	}
+
+	reqInf := toclientlib.ReqInf{}
+	err := torequtil.GetRetry(cl.NumRetries, "set_server_config_run_"+string(cacheHostName), nil, func(obj interface{}) error {
+		_, toReqInf, err := cl.c.SetServerConfigRun(string(cacheHostName), run, *ReqOpts(nil))
+		reqInf = toReqInf
+		if err != nil {
+			return errors.New("setting server config run in Traffic Ops '" + torequtil.MaybeIPStr(reqInf.RemoteAddr) + "': " + err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return reqInf, errors.New("setting server config run: " + err.Error())
+	}
+	return reqInf, nil
+}
+
+// SetServerCheck sets the value of the Server Check extension with the given
+// short name for the given server in Traffic Ops.
+func (cl *TOClient) SetServerCheck(cacheHostName tc.CacheName, checkName string, value int) (toclientlib.ReqInf, error) {
+	if cl.c == nil {
+		return cl.old.SetServerCheck(cacheHostName, checkName, value)
+	}
+
+	reqInf := toclientlib.ReqInf{}
+	err := torequtil.GetRetry(cl.NumRetries, "set_server_check_"+string(cacheHostName)+"_"+checkName, nil, func(obj interface{}) error {
+		hostName := string(cacheHostName)
+		_, toReqInf, err := cl.c.InsertServerCheckStatus(tc.ServercheckRequestNullable{Name: &checkName, HostName: &hostName, Value: &value}, *ReqOpts(nil))
+		reqInf = toReqInf
+		if err != nil {
+			return errors.New("setting server check in Traffic Ops '" + torequtil.MaybeIPStr(reqInf.RemoteAddr) + "': " + err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return reqInf, errors.New("setting server check: " + err.Error())
+	}
+	return reqInf, nil
+}
@@ -0,0 +1,301 @@
+package toreq
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/cache-config/t3cutil/toreq/torequtil"
+	"github.com/apache/trafficcontrol/lib/go-log"
+)
+
+// ErrUnreachable is wrapped by the error returned by NewFailover when none of
+// the Traffic Ops servers could be connected to.
+var ErrUnreachable = errors.New("no Traffic Ops server is reachable")
+
+// UnhealthyDuration is how long a Traffic Ops server is considered unhealthy
+// after a failed request. Unhealthy servers are only tried after healthy ones.
+const UnhealthyDuration = 5 * time.Minute
+
+// HealthCachePath is the file in which the health of Traffic Ops servers is
+// kept between runs.
+var HealthCachePath = filepath.Join(torequtil.CookieCacheDir, "traffic-ops-health.json")
+
+// NewFailover logs into the first of the given Traffic Ops servers it can,
+// returning a TOClient which fails over between them.
+//
+// Servers which failed within UnhealthyDuration, in this or a previous run,
+// are tried after the others. If none can be logged into, all are tried again
+// up to numRetries times, with exponential backoff. If none of them could even
+// be connected to, the returned error wraps ErrUnreachable.
+//
+// Requests made by the returned client go to the server it logged into, unless
+// that can't be connected to or responds with a 502, 503, or 504, in which
+// case the request is sent to the other servers in turn.
+func NewFailover(urls []*url.URL, creds torequtil.Credentials, insecure bool, timeout time.Duration, userAgent string, numRetries int) (*TOClient, error) {
+	if len(urls) == 0 {
+		return nil, errors.New("no Traffic Ops URLs")
+	}
+	health := loadTOHealth(HealthCachePath)
+	for currentRetry := 0; ; currentRetry++ {
+		urls = health.order(urls, time.Now())
+		reachable := false
+		errStrs := []string{}
+		for i, u := range urls {
+			cl, err := New(u, creds, insecure, timeout, userAgent)
+			if err == nil {
+				health.succeed(u)
+				others := append(append([]*url.URL{}, urls[:i]...), urls[i+1:]...)
+				cl.NumRetries = numRetries
+				cl.failover = &failoverTransport{
+					base:   cl.HTTPClient().Transport,
+					health: health,
+					origin: makeTOURLStr(u),
+					urls:   append([]*url.URL{u}, others...),
+				}
+				cl.HTTPClient().Transport = cl.failover
+				return cl, nil
+			}
+			health.fail(u)
+			if canConnect(u, timeout) {
+				reachable = true
+			}
+			log.Warnf("logging into Traffic Ops '%s': %v\n", makeTOURLStr(u), err)
+			errStrs = append(errStrs, makeTOURLStr(u)+": "+err.Error())
+		}
+		if currentRetry == numRetries {
+			if !reachable {
+				return nil, fmt.Errorf("%w: %s", ErrUnreachable, strings.Join(errStrs, "; "))
+			}
+			return nil, errors.New("logging into Traffic Ops: " + strings.Join(errStrs, "; "))
+		}
+		sleepSeconds := torequtil.RetryBackoffSeconds(currentRetry)
+		log.Warnf("logging into all Traffic Ops servers failed, sleeping for %v seconds\n", sleepSeconds)
+		time.Sleep(time.Second * time.Duration(sleepSeconds))
+	}
+}
+
+// Unreachable returns whether a request made by the client failed because
+// none of its Traffic Ops servers could be connected to.
+func (cl *TOClient) Unreachable() bool {
+	return cl.failover != nil && cl.failover.isUnreachable()
+}
+
+// canConnect returns whether a TCP connection can be made to the server at u.
+func canConnect(u *url.URL, timeout time.Duration) bool {
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(u.Hostname(), port), timeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// failoverTransport is an http.RoundTripper which sends requests for the
+// Traffic Ops server a client logged into to the first of its servers which
+// can be reached and is available.
+//
+// Requests for any other host, such as a Traffic Ops proxy, are sent as-is.
+type failoverTransport struct {
+	base   http.RoundTripper
+	health *toHealth
+	// origin is the scheme and host of the server the client logged into.
+	origin string
+
+	m sync.Mutex
+	// urls is all the servers, starting with the one which last succeeded.
+	urls        []*url.URL
+	unreachable bool
+}
+
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme+"://"+req.URL.Host != t.origin {
+		return t.base.RoundTrip(req)
+	}
+
+	t.m.Lock()
+	urls := t.urls
+	t.m.Unlock()
+
+	hasBody := req.Body != nil && req.Body != http.NoBody
+	if hasBody && req.GetBody == nil {
+		urls = urls[:1] // the body can't be sent again, so there's no failing over
+	}
+
+	err := error(nil)
+	for i, u := range urls {
+		tryReq := req.Clone(req.Context())
+		if i > 0 && hasBody {
+			if tryReq.Body, err = req.GetBody(); err != nil {
+				return nil, errors.New("getting request body to fail over: " + err.Error())
+			}
+		}
+		tryReq.URL.Scheme = u.Scheme
+		tryReq.URL.Host = u.Host
+		tryReq.Host = ""
+
+		resp := (*http.Response)(nil)
+		resp, err = t.base.RoundTrip(tryReq)
+		if err == nil && !isUnavailableStatus(resp.StatusCode) {
+			t.health.succeed(u)
+			t.use(u)
+			return resp, nil
+		}
+
+		t.health.fail(u)
+		if err == nil {
+			if i == len(urls)-1 {
+				return resp, nil // no other servers to try, so return the unavailable response
+			}
+			resp.Body.Close()
+			log.Warnf("Traffic Ops '%s' returned %v, failing over\n", makeTOURLStr(u), resp.StatusCode)
+			continue
+		}
+		log.Warnf("requesting Traffic Ops '%s': %v, failing over\n", makeTOURLStr(u), err)
+	}
+
+	t.m.Lock()
+	t.unreachable = true
+	t.m.Unlock()
+	return nil, err
+}
+
+// use makes u the first server tried by subsequent requests.
+func (t *failoverTransport) use(u *url.URL) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if t.urls[0] == u {
+		return
+	}
+	urls := []*url.URL{u}
+	for _, other := range t.urls {
+		if other != u {
+			urls = append(urls, other)
+		}
+	}
+	t.urls = urls
+}
+
+func (t *failoverTransport) isUnreachable() bool {
+	t.m.Lock()
+	defer t.m.Unlock()
+	return t.unreachable
+}
+
+// isUnavailableStatus returns whether the HTTP status code indicates the
+// server is unavailable, in which case another server may be able to serve the
+// request.
+func isUnavailableStatus(code int) bool {
+	return code == http.StatusBadGateway || code == http.StatusServiceUnavailable || code == http.StatusGatewayTimeout
+}
+
+// toHealth is the time each Traffic Ops server last failed, kept in a file
+// between runs. It's safe for concurrent use.
+type toHealth struct {
+	path     string
+	m        sync.Mutex
+	failures map[string]time.Time
+}
+
+// loadTOHealth loads the health of Traffic Ops servers from the file at path.
+// If the file doesn't exist or can't be read, all servers are healthy.
+func loadTOHealth(path string) *toHealth {
+	health := &toHealth{path: path, failures: map[string]time.Time{}}
+	bts, err := ioutil.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Warnf("reading Traffic Ops health file '%s', considering all servers healthy: %v\n", path, err)
+		}
+		return health
+	}
+	if err := json.Unmarshal(bts, &health.failures); err != nil {
+		log.Warnf("parsing Traffic Ops health file '%s', considering all servers healthy: %v\n", path, err)
+		health.failures = map[string]time.Time{}
+	}
+	return health
+}
+
+// order returns urls with the healthy servers first, in the given order,
+// followed by the unhealthy ones, least recently failed first.
+func (h *toHealth) order(urls []*url.URL, now time.Time) []*url.URL {
+	h.m.Lock()
+	defer h.m.Unlock()
+	healthy := []*url.URL{}
+	unhealthy := []*url.URL{}
+	for _, u := range urls {
+		if failed, ok := h.failures[makeTOURLStr(u)]; ok && now.Sub(failed) < UnhealthyDuration {
+			unhealthy = append(unhealthy, u)
+		} else {
+			healthy = append(healthy, u)
+		}
+	}
+	sort.SliceStable(unhealthy, func(i, j int) bool {
+		return h.failures[makeTOURLStr(unhealthy[i])].Before(h.failures[makeTOURLStr(unhealthy[j])])
+	})
+	return append(healthy, unhealthy...)
+}
+
+func (h *toHealth) fail(u *url.URL) {
+	h.m.Lock()
+	defer h.m.Unlock()
+	h.failures[makeTOURLStr(u)] = time.Now()
+	h.write()
+}
+
+func (h *toHealth) succeed(u *url.URL) {
+	h.m.Lock()
+	defer h.m.Unlock()
+	if _, ok := h.failures[makeTOURLStr(u)]; !ok {
+		return
+	}
+	delete(h.failures, makeTOURLStr(u))
+	h.write()
+}
+
+// write writes the health to its file. The caller must hold h.m.
+func (h *toHealth) write() {
+	bts, err := json.Marshal(h.failures)
+	if err != nil {
+		log.Warnln("encoding Traffic Ops health: " + err.Error())
+		return
+	}
+	if err := ioutil.WriteFile(h.path, bts, 0600); err != nil {
+		log.Warnln("writing Traffic Ops health file '" + h.path + "': " + err.Error())
+	}
+}
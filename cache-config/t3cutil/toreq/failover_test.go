@@ -0,0 +1,110 @@
+package toreq
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTOHealthOrder(t *testing.T) {
+	health := loadTOHealth(filepath.Join(t.TempDir(), "health.json"))
+	a, _ := url.Parse("https://to-a.example.net")
+	b, _ := url.Parse("https://to-b.example.net")
+	c, _ := url.Parse("https://to-c.example.net")
+	now := time.Now()
+	health.failures[makeTOURLStr(a)] = now.Add(-time.Minute)
+	health.failures[makeTOURLStr(b)] = now.Add(-2 * time.Minute)
+	health.failures[makeTOURLStr(c)] = now.Add(-UnhealthyDuration - time.Minute)
+
+	actual := health.order([]*url.URL{a, b, c}, now)
+	expected := []*url.URL{c, b, a}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("expected order %v, actual %v", expected, actual)
+		}
+	}
+
+	health.succeed(a)
+	loaded := loadTOHealth(health.path)
+	if _, ok := loaded.failures[makeTOURLStr(a)]; ok {
+		t.Errorf("expected success to be persisted, actual %s still failed", a)
+	}
+	if _, ok := loaded.failures[makeTOURLStr(b)]; !ok {
+		t.Errorf("expected failure of %s to be persisted, actual not failed", b)
+	}
+}
+
+func TestFailoverTransport(t *testing.T) {
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+	available := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer available.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	down.Close()
+
+	downURL, _ := url.Parse(down.URL)
+	unavailableURL, _ := url.Parse(unavailable.URL)
+	availableURL, _ := url.Parse(available.URL)
+
+	transport := &failoverTransport{
+		base:   http.DefaultTransport,
+		health: loadTOHealth(filepath.Join(t.TempDir(), "health.json")),
+		origin: makeTOURLStr(downURL),
+		urls:   []*url.URL{downURL, unavailableURL, availableURL},
+	}
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(down.URL + "/api/4.0/ping")
+	if err != nil {
+		t.Fatalf("expected request to fail over, actual error: %v", err)
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "ok" {
+		t.Errorf("expected response from available server, actual %v '%s'", resp.StatusCode, body)
+	}
+	if transport.urls[0] != availableURL {
+		t.Errorf("expected subsequent requests to go to %s first, actual %s", availableURL, transport.urls[0])
+	}
+	if len(transport.health.failures) != 2 {
+		t.Errorf("expected 2 servers to be unhealthy, actual %v", transport.health.failures)
+	}
+	if transport.isUnreachable() {
+		t.Error("expected transport to not be unreachable")
+	}
+
+	transport.urls = []*url.URL{downURL}
+	if _, err := client.Get(down.URL + "/api/4.0/ping"); err == nil {
+		t.Error("expected error requesting only a down server, actual nil")
+	}
+	if !transport.isUnreachable() {
+		t.Error("expected transport to be unreachable")
+	}
+}
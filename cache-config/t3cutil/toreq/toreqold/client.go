@@ -58,8 +58,11 @@ func (cl *TOClient) APIVersion() string {
 }
 
 // New logs into Traffic Ops, returning the TOClient which contains the logged-in client.
-func New(url *url.URL, user string, pass string, insecure bool, timeout time.Duration, userAgent string) (*TOClient, error) {
-	log.Infoln("URL: '" + url.String() + "' User: '" + user + "' Pass len: '" + strconv.Itoa(len(pass)) + "'")
+func New(url *url.URL, creds torequtil.Credentials, insecure bool, timeout time.Duration, userAgent string) (*TOClient, error) {
+	if creds.Token == "" && creds.ClientCertFile != "" {
+		return nil, errors.New("Traffic Ops does not support logging in with client certificates in this API version")
+	}
+	log.Infoln("URL: '" + url.String() + "' User: '" + creds.User + "' Pass len: '" + strconv.Itoa(len(creds.Pass)) + "'")
 
 	toURLStr := url.Scheme + "://" + url.Host
 	log.Infoln("TO URL string: '" + toURLStr + "'")
@@ -69,7 +72,14 @@ func New(url *url.URL, user string, pass string, insecure bool, timeout time.Dur
 	opts.Insecure = insecure
 	opts.UserAgent = userAgent
 	opts.RequestTimeout = timeout
-	toClient, inf, err := toclient.Login(toURLStr, user, pass, opts)
+	if creds.Token != "" {
+		toClient, toAddr, err := toclient.LoginWithToken(toURLStr, creds.Token, insecure, userAgent, false, timeout)
+		if err != nil {
+			return nil, errors.New("Logging in to Traffic Ops '" + torequtil.MaybeIPStr(toAddr) + "' with token: " + err.Error())
+		}
+		return &TOClient{c: toClient}, nil
+	}
+	toClient, inf, err := toclient.Login(toURLStr, creds.User, creds.Pass, opts)
 	if err != nil {
 		return nil, errors.New("Logging in to Traffic Ops '" + torequtil.MaybeIPStr(inf.RemoteAddr) + "': " + err.Error())
 	}
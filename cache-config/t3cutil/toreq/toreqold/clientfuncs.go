@@ -582,3 +582,22 @@ func (cl *TOClient) SetServerUpdateStatus(cacheHostName tc.CacheName, updateStat
 	}
 	return reqInf, nil
 }
+
+// SetServerCheck sets the value of the Server Check extension with the given
+// short name for the given server in Traffic Ops.
+func (cl *TOClient) SetServerCheck(cacheHostName tc.CacheName, checkName string, value int) (toclientlib.ReqInf, error) {
+	reqInf := toclientlib.ReqInf{}
+	err := torequtil.GetRetry(cl.NumRetries, "set_server_check_"+string(cacheHostName)+"_"+checkName, nil, func(obj interface{}) error {
+		hostName := string(cacheHostName)
+		_, toReqInf, err := cl.c.InsertServerCheckStatus(tc.ServercheckRequestNullable{Name: &checkName, HostName: &hostName, Value: &value})
+		reqInf = toReqInf
+		if err != nil {
+			return errors.New("setting server check in Traffic Ops '" + torequtil.MaybeIPStr(reqInf.RemoteAddr) + "': " + err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		return reqInf, errors.New("setting server check: " + err.Error())
+	}
+	return reqInf, nil
+}
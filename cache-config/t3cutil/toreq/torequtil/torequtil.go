@@ -46,6 +46,35 @@ func CookieCachePath(userName string) string {
 	return filepath.Join(CookieCacheDir, CookieCacheFileName(userName))
 }
 
+// Credentials are the means by which t3c authenticates with Traffic Ops. If
+// Token is set, it is used instead of User and Pass. Otherwise, if
+// ClientCertFile is set, the TLS client certificate it contains - with the
+// private key in ClientKeyFile - is used.
+type Credentials struct {
+	User           string
+	Pass           string
+	Token          string
+	ClientCertFile string
+	ClientKeyFile  string
+}
+
+// UsesPassword returns whether the credentials authenticate with User and Pass,
+// rather than a token or client certificate.
+func (c Credentials) UsesPassword() bool {
+	return c.Token == "" && c.ClientCertFile == ""
+}
+
+// CookieCachePath returns the path of the file in which the session cookie
+// obtained with the credentials is cached between runs. Cookies are only cached
+// for password authentication, because logging in with a token or client
+// certificate is cheap; for those, this returns an empty string.
+func (c Credentials) CookieCachePath() string {
+	if !c.UsesPassword() {
+		return ""
+	}
+	return CookieCachePath(c.User)
+}
+
 type Cookie struct {
 	Cookie *http.Cookie `json:"cookie"`
 }
@@ -79,3 +79,18 @@ func TestMaybeHdrStr(t *testing.T) {
 		t.Errorf("MaybeIPStr(val) expected '1001', actual '%v'", is)
 	}
 }
+
+func TestCredentialsCookieCachePath(t *testing.T) {
+	creds := Credentials{User: "cache", Pass: "pass"}
+	if path := creds.CookieCachePath(); path != CookieCachePath("cache") {
+		t.Errorf("CookieCachePath for password credentials expected '%v', actual '%v'", CookieCachePath("cache"), path)
+	}
+	creds.Token = "token"
+	if path := creds.CookieCachePath(); path != "" {
+		t.Errorf("CookieCachePath for token credentials expected '', actual '%v'", path)
+	}
+	creds = Credentials{ClientCertFile: "cache.crt", ClientKeyFile: "cache.key"}
+	if path := creds.CookieCachePath(); path != "" {
+		t.Errorf("CookieCachePath for client certificate credentials expected '', actual '%v'", path)
+	}
+}
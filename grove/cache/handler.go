@@ -218,6 +218,17 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if remappingProducer.WebSocket() && isWebSocketUpgrade(r) {
+		h.plugins.OnBeforeParentRequest(remappingProducer.PluginCfg(), pluginContext, plugin.BeforeParentRequestData{Req: r, RemapRule: remappingProducer.Name()})
+		h.serveWebSocket(w, r, remappingProducer, responder, reqID)
+		return
+	}
+	if remappingProducer.GRPC() && isGRPC(r) {
+		h.plugins.OnBeforeParentRequest(remappingProducer.PluginCfg(), pluginContext, plugin.BeforeParentRequestData{Req: r, RemapRule: remappingProducer.Name()})
+		h.serveGRPC(w, r, remappingProducer, responder, reqID)
+		return
+	}
+
 	reqCacheControl := rfc.ParseCacheControl(reqHeader)
 	log.Debugf("Serve got Cache-Control %+v (reqid %v)\n", reqCacheControl, reqID)
 
@@ -267,6 +278,9 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	reqHeaders := r.Header
 	canReuseStored := rfc.CanReuseStored(reqHeaders, cacheObj.RespHeaders, reqCacheControl, cacheObj.RespCacheControl, cacheObj.ReqHeaders, cacheObj.ReqRespTime, cacheObj.RespRespTime, h.strictRFC)
 
+	afterCacheLookUpData := plugin.AfterCacheLookUpData{Req: r, CacheObj: cacheObj, CanReuse: &canReuseStored, RemapRule: remappingProducer.Name()}
+	h.plugins.OnAfterCacheLookUp(remappingProducer.PluginCfg(), pluginContext, afterCacheLookUpData)
+
 	if canReuseStored != rfc.ReuseCan { // run the BeforeParentRequest hook for revalidations / ReuseCannot
 		beforeParentRequestData := plugin.BeforeParentRequestData{Req: r, RemapRule: remappingProducer.Name()}
 		h.plugins.OnBeforeParentRequest(remappingProducer.PluginCfg(), pluginContext, beforeParentRequestData)
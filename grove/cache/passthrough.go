@@ -0,0 +1,302 @@
+package cache
+
+/*
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/grove/remap"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+)
+
+// streamBufSize is the size of the buffer used to copy gRPC response bodies, each read of which is flushed to the client immediately.
+const streamBufSize = 32 * 1024
+
+// isWebSocketUpgrade returns whether r asks to upgrade its connection to a WebSocket.
+func isWebSocketUpgrade(r *http.Request) bool {
+	if r.ProtoMajor != 1 || !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return false
+	}
+	for _, v := range r.Header["Connection"] {
+		for _, token := range strings.Split(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isGRPC returns whether r is a gRPC request.
+func isGRPC(r *http.Request) bool {
+	return r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc")
+}
+
+// streamTimer closes a stream which has been idle, or open, for too long.
+type streamTimer struct {
+	idle        time.Duration
+	idleTimer   *time.Timer
+	activeTimer *time.Timer
+}
+
+// newStreamTimer returns a streamTimer which calls onTimeout once the stream has gone idle for the given idle duration, or been open for the given active duration.
+func newStreamTimer(idle time.Duration, active time.Duration, onTimeout func()) *streamTimer {
+	once := sync.Once{}
+	timeout := func() { once.Do(onTimeout) }
+	return &streamTimer{
+		idle:        idle,
+		idleTimer:   time.AfterFunc(idle, timeout),
+		activeTimer: time.AfterFunc(active, timeout),
+	}
+}
+
+// Touch marks the stream as not idle.
+func (t *streamTimer) Touch() { t.idleTimer.Reset(t.idle) }
+
+// Stop stops the timers, after the stream has ended.
+func (t *streamTimer) Stop() {
+	t.idleTimer.Stop()
+	t.activeTimer.Stop()
+}
+
+// touchReader is a Reader which touches its streamTimer whenever data is read.
+type touchReader struct {
+	io.Reader
+	timer *streamTimer
+}
+
+func (r touchReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.timer.Touch()
+	}
+	return n, err
+}
+
+// touchReadCloser is a touchReader which may also be closed, for request bodies.
+type touchReadCloser struct {
+	touchReader
+	io.Closer
+}
+
+// serveWebSocket passes the WebSocket upgrade request r through to a parent, and, if the parent accepts it, relays the resulting connection until either side closes it or it times out.
+func (h *Handler) serveWebSocket(w http.ResponseWriter, r *http.Request, remappingProducer *remap.RemappingProducer, responder *Responder, reqID uint64) {
+	remapping, _, err := remappingProducer.GetNext(r)
+	if err != nil {
+		log.Errorf("getting websocket remapping: %v (reqid %v)\n", err, reqID)
+		responder.Do()
+		return
+	}
+	responder.ProxyStr = remappingProducer.ProxyStr()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	resp, err := remapping.Transport.RoundTrip(remapping.Request.WithContext(ctx))
+	if err != nil {
+		log.Errorf("requesting websocket upgrade from parent: %v (reqid %v)\n", err, reqID)
+		*responder.ResponseCode = http.StatusBadGateway
+		responder.OriginConnectFailed = true
+		responder.Do()
+		return
+	}
+	responder.OriginCode = resp.StatusCode
+	responder.OriginReqSuccess = true
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		// the parent refused the upgrade, so its response is just a response
+		defer resp.Body.Close()
+		for name, vals := range resp.Header {
+			w.Header()[name] = vals
+		}
+		w.WriteHeader(resp.StatusCode)
+		n, err := io.Copy(w, resp.Body)
+		responder.DoStreamed(resp.StatusCode, uint64(n), err)
+		return
+	}
+
+	parentConn, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok {
+		resp.Body.Close()
+		log.Errorf("parent websocket upgrade response body is a %T, not a connection (reqid %v)\n", resp.Body, reqID)
+		*responder.ResponseCode = http.StatusBadGateway
+		responder.Do()
+		return
+	}
+	defer parentConn.Close()
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		log.Errorf("websocket upgrade response writer is a %T, which can't be hijacked (reqid %v)\n", w, reqID)
+		*responder.ResponseCode = http.StatusInternalServerError
+		responder.Do()
+		return
+	}
+	clientConn, clientBuf, err := hijacker.Hijack()
+	if err != nil {
+		log.Errorf("hijacking websocket client connection: %v (reqid %v)\n", err, reqID)
+		responder.DoStreamed(http.StatusSwitchingProtocols, 0, err)
+		return
+	}
+	defer clientConn.Close()
+
+	// the server's read and write timeouts are for requests; the stream timeouts apply to the connection now.
+	if err := clientConn.SetDeadline(time.Time{}); err != nil {
+		log.Errorf("clearing websocket client connection deadline: %v (reqid %v)\n", err, reqID)
+	}
+
+	bytesSent, err := relayWebSocket(clientConn, clientBuf, parentConn, resp, remappingProducer.StreamIdleTimeout(), remappingProducer.StreamActiveTimeout())
+	responder.DoStreamed(http.StatusSwitchingProtocols, bytesSent, err)
+}
+
+// relayWebSocket writes the parent's upgrade response resp to the client, and then copies data between the client and parent connections until either closes, or the stream times out. It returns the number of bytes sent to the client, and any error.
+func relayWebSocket(clientConn net.Conn, clientBuf *bufio.ReadWriter, parentConn io.ReadWriteCloser, resp *http.Response, idle time.Duration, active time.Duration) (uint64, error) {
+	header := fmt.Sprintf("HTTP/1.1 %03d %s\r\n", resp.StatusCode, http.StatusText(resp.StatusCode))
+	if _, err := clientBuf.WriteString(header); err != nil {
+		return 0, errors.New("writing upgrade response: " + err.Error())
+	}
+	if err := resp.Header.Write(clientBuf); err != nil {
+		return 0, errors.New("writing upgrade response header: " + err.Error())
+	}
+	if _, err := clientBuf.WriteString("\r\n"); err != nil {
+		return 0, errors.New("writing upgrade response: " + err.Error())
+	}
+	if err := clientBuf.Flush(); err != nil {
+		return 0, errors.New("writing upgrade response: " + err.Error())
+	}
+
+	timedOut := make(chan struct{})
+	timer := newStreamTimer(idle, active, func() {
+		close(timedOut)
+		clientConn.Close()
+		parentConn.Close()
+	})
+	defer timer.Stop()
+
+	toParentErr := make(chan error, 1)
+	go func() {
+		// the client may have sent frames along with its upgrade request, which are already buffered.
+		_, err := io.Copy(parentConn, touchReader{Reader: clientBuf.Reader, timer: timer})
+		parentConn.Close() // the client is done; unblock the copy to the client.
+		toParentErr <- err
+	}()
+	n, toClientErr := io.Copy(clientConn, touchReader{Reader: parentConn, timer: timer})
+	clientConn.Close() // the parent is done; unblock the copy to the parent.
+	err := <-toParentErr
+
+	select {
+	case <-timedOut:
+		return uint64(n), nil // timing out is the expected end of an abandoned stream, not an error.
+	default:
+	}
+	if toClientErr != nil && !isClosedErr(toClientErr) {
+		return uint64(n), errors.New("copying to client: " + toClientErr.Error())
+	}
+	if err != nil && !isClosedErr(err) {
+		return uint64(n), errors.New("copying to parent: " + err.Error())
+	}
+	return uint64(n), nil
+}
+
+// isClosedErr returns whether err is the result of using a connection after it was closed, which is how a relayed stream ends when the other side closes.
+func isClosedErr(err error) bool {
+	return errors.Is(err, net.ErrClosed) || errors.Is(err, io.ErrClosedPipe) || strings.Contains(err.Error(), "use of closed network connection")
+}
+
+// serveGRPC passes the gRPC request r through to a parent, streaming the request and response bodies and the response trailers, until the call ends or times out.
+//
+// Note the server's read and write timeouts still bound HTTP/2 streams, so they must be at least the rule's stream active timeout for calls to last that long.
+func (h *Handler) serveGRPC(w http.ResponseWriter, r *http.Request, remappingProducer *remap.RemappingProducer, responder *Responder, reqID uint64) {
+	remapping, _, err := remappingProducer.GetNext(r)
+	if err != nil {
+		log.Errorf("getting grpc remapping: %v (reqid %v)\n", err, reqID)
+		responder.Do()
+		return
+	}
+	responder.ProxyStr = remappingProducer.ProxyStr()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	timer := newStreamTimer(remappingProducer.StreamIdleTimeout(), remappingProducer.StreamActiveTimeout(), cancel)
+	defer timer.Stop()
+
+	req := remapping.Request.WithContext(ctx)
+	req.Body = touchReadCloser{touchReader: touchReader{Reader: r.Body, timer: timer}, Closer: r.Body}
+	req.ContentLength = r.ContentLength
+	req.Trailer = r.Trailer // the server fills in the client's trailers after its body is read, before the transport sends them.
+
+	resp, err := remapping.Transport.RoundTrip(req)
+	if err != nil {
+		log.Errorf("requesting grpc from parent: %v (reqid %v)\n", err, reqID)
+		*responder.ResponseCode = http.StatusBadGateway
+		responder.OriginConnectFailed = true
+		responder.Do()
+		return
+	}
+	defer resp.Body.Close()
+	responder.OriginCode = resp.StatusCode
+	responder.OriginReqSuccess = true
+
+	for name, vals := range resp.Header {
+		w.Header()[name] = vals
+	}
+	w.WriteHeader(resp.StatusCode)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	bytesSent := uint64(0)
+	buf := make([]byte, streamBufSize)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			timer.Touch()
+			written, err := w.Write(buf[:n])
+			bytesSent += uint64(written)
+			if err != nil {
+				responder.DoStreamed(resp.StatusCode, bytesSent, errors.New("writing to client: "+err.Error()))
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			responder.DoStreamed(resp.StatusCode, bytesSent, errors.New("reading from parent: "+readErr.Error()))
+			return
+		}
+	}
+
+	// gRPC sends its status in trailers, which are only known once the body has been read.
+	for name, vals := range resp.Trailer {
+		for _, val := range vals {
+			w.Header().Add(http.TrailerPrefix+name, val)
+		}
+	}
+	responder.DoStreamed(resp.StatusCode, bytesSent, nil)
+}
@@ -0,0 +1,137 @@
+package cache
+
+/*
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestIsWebSocketUpgrade(t *testing.T) {
+	r, err := http.NewRequest(http.MethodGet, "http://example.test/chat", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if isWebSocketUpgrade(r) {
+		t.Error("expected a plain request not to be a websocket upgrade")
+	}
+	r.Header.Set("Upgrade", "WebSocket")
+	r.Header.Set("Connection", "keep-alive, Upgrade")
+	if !isWebSocketUpgrade(r) {
+		t.Errorf("expected a request with headers %v to be a websocket upgrade", r.Header)
+	}
+	r.Header.Set("Connection", "keep-alive")
+	if isWebSocketUpgrade(r) {
+		t.Error("expected a request without an upgrade connection token not to be a websocket upgrade")
+	}
+}
+
+func TestIsGRPC(t *testing.T) {
+	r, err := http.NewRequest(http.MethodPost, "https://example.test/pkg.Service/Method", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set("Content-Type", "application/grpc+proto")
+	if isGRPC(r) {
+		t.Error("expected an HTTP/1.1 request not to be gRPC")
+	}
+	r.ProtoMajor, r.ProtoMinor = 2, 0
+	if !isGRPC(r) {
+		t.Error("expected an HTTP/2 request with a gRPC content type to be gRPC")
+	}
+	r.Header.Set("Content-Type", "application/json")
+	if isGRPC(r) {
+		t.Error("expected an HTTP/2 request with a JSON content type not to be gRPC")
+	}
+}
+
+func TestRelayWebSocket(t *testing.T) {
+	clientConn, client := net.Pipe()
+	parentConn, parent := net.Pipe()
+	clientBuf := bufio.NewReadWriter(bufio.NewReader(clientConn), bufio.NewWriter(clientConn))
+	resp := &http.Response{StatusCode: http.StatusSwitchingProtocols, Header: http.Header{"Upgrade": {"websocket"}, "Connection": {"Upgrade"}}}
+
+	type result struct {
+		n   uint64
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		n, err := relayWebSocket(clientConn, clientBuf, parentConn, resp, time.Minute, time.Minute)
+		done <- result{n: n, err: err}
+	}()
+
+	clientReader := bufio.NewReader(client)
+	upgrade, err := http.ReadResponse(clientReader, nil)
+	if err != nil {
+		t.Fatalf("reading upgrade response: %v", err)
+	}
+	if upgrade.StatusCode != http.StatusSwitchingProtocols || upgrade.Header.Get("Upgrade") != "websocket" {
+		t.Errorf("expected a websocket upgrade response, actual %v %v", upgrade.StatusCode, upgrade.Header)
+	}
+
+	go client.Write([]byte("ping"))
+	buf := make([]byte, 4)
+	if _, err := io.ReadFull(parent, buf); err != nil || string(buf) != "ping" {
+		t.Errorf("expected the parent to read 'ping', actual '%s' error %v", buf, err)
+	}
+	go parent.Write([]byte("pong"))
+	if _, err := io.ReadFull(clientReader, buf); err != nil || string(buf) != "pong" {
+		t.Errorf("expected the client to read 'pong', actual '%s' error %v", buf, err)
+	}
+
+	parent.Close()
+	select {
+	case res := <-done:
+		if res.err != nil {
+			t.Errorf("expected the relay to end without error when the parent closes, actual %v", res.err)
+		}
+		if res.n != 4 {
+			t.Errorf("expected 4 bytes sent to the client, actual %v", res.n)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the relay to end when the parent closes")
+	}
+}
+
+func TestRelayWebSocketIdleTimeout(t *testing.T) {
+	clientConn, client := net.Pipe()
+	parentConn, parent := net.Pipe()
+	defer parent.Close()
+	clientBuf := bufio.NewReadWriter(bufio.NewReader(clientConn), bufio.NewWriter(clientConn))
+	resp := &http.Response{StatusCode: http.StatusSwitchingProtocols, Header: http.Header{}}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := relayWebSocket(clientConn, clientBuf, parentConn, resp, 50*time.Millisecond, time.Minute)
+		done <- err
+	}()
+	if _, err := http.ReadResponse(bufio.NewReader(client), nil); err != nil {
+		t.Fatalf("reading upgrade response: %v", err)
+	}
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected an idle timeout not to be an error, actual %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the relay to end when the stream goes idle")
+	}
+}
@@ -105,6 +105,17 @@ func (r *Responder) Do() {
 	r.Plugins.OnAfterRespond(r.PluginCfg, r.PluginContext, arData)
 }
 
+// DoStreamed is Do for responses which have already been streamed to the client, such as WebSocket connections and gRPC calls, with the given code, bytes sent, and any error streaming them. It writes to the event log and adds statistics, as Do does.
+func (r *Responder) DoStreamed(code int, bytesSent uint64, err error) {
+	if err != nil {
+		log.Errorf("%s %s %s %v : streaming: %v", r.Req.RemoteAddr, r.Req.Method, r.Req.RequestURI, code, err.Error())
+	}
+	r.ResponseCode = &code
+	respData := cachedata.RespData{RespCode: code, BytesWritten: bytesSent, RespSuccess: err == nil, CacheHit: false}
+	arData := plugin.AfterRespondData{W: r.W, Stats: r.Stats, ReqData: r.ReqData, SrvrData: r.SrvrData, ParentRespData: r.ParentRespData, RespData: respData, RequestID: r.RequestID}
+	r.Plugins.OnAfterRespond(r.PluginCfg, r.PluginContext, arData)
+}
+
 func isCacheHit(reuse rfc.Reuse, originCode int) bool {
 	// TODO move to web? remap?
 	return reuse == rfc.ReuseCan || ((reuse == rfc.ReuseMustRevalidate || reuse == rfc.ReuseMustRevalidateCanStale) && originCode == http.StatusNotModified)
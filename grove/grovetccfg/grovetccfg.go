@@ -36,6 +36,7 @@ import (
 
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	to "github.com/apache/trafficcontrol/traffic_ops/v3-client"
+	toclient "github.com/apache/trafficcontrol/traffic_ops/v4-client"
 
 	"github.com/apache/trafficcontrol/grove/config"
 	"github.com/apache/trafficcontrol/grove/remap"
@@ -215,6 +216,12 @@ func main() {
 		fmt.Println(time.Now().Format(time.RFC3339Nano) + " Error connecting to Traffic Ops: " + err.Error())
 		os.Exit(ExitError)
 	}
+	// Surrogate key jobs are only available from API 4.1.
+	tocV4, _, err := toclient.LoginWithAgent(*toURL, *toUser, *toPass, *toInsecure, UserAgent, useCache, TrafficOpsTimeout)
+	if err != nil {
+		fmt.Println(time.Now().Format(time.RFC3339Nano) + " Error connecting to Traffic Ops API 4: " + err.Error())
+		os.Exit(ExitError)
+	}
 
 	revalPendingStatus := false
 	if !*ignoreUpdateFlag {
@@ -294,7 +301,7 @@ func main() {
 	// if *api == "1.3" {
 	// 	rules, err = createRulesNewAPI(toc, *host, *certDir)
 	// } else {
-	rules, err = createRulesOldAPI(toc, *host, *certDir, servers) // TODO remove once 1.3 / traffic_ops_golang is deployed to production.
+	rules, err = createRulesOldAPI(toc, tocV4, *host, *certDir, servers) // TODO remove once 1.3 / traffic_ops_golang is deployed to production.
 	// }
 	if err != nil {
 		fmt.Println(time.Now().Format(time.RFC3339Nano) + " Error creating rules: " + err.Error())
@@ -455,7 +462,7 @@ func setConfigParameter(cfg *config.Config, name string, value string) error {
 	return err
 }
 
-func createRulesOldAPI(toc *to.Session, host string, certDir string, servers map[string]tc.ServerV30) (remap.RemapRules, error) {
+func createRulesOldAPI(toc *to.Session, tocV4 *toclient.Session, host string, certDir string, servers map[string]tc.ServerV30) (remap.RemapRules, error) {
 	cachegroupsArr, _, err := toc.GetCacheGroupsNullableWithHdr(nil)
 	if err != nil {
 		fmt.Println(time.Now().Format(time.RFC3339Nano) + " Error getting Traffic Ops Cachegroups: " + err.Error())
@@ -522,7 +529,124 @@ func createRulesOldAPI(toc *to.Session, host string, certDir string, servers map
 	}
 	dsCerts := makeDSCertMap(cdnSSLKeys)
 
-	return createRulesOld(host, deliveryservices, parents, deliveryserviceRegexes, cdns, serverParameters, dsCerts, certDir)
+	surrogateKeyPurges, err := getSurrogateKeyPurges(toc, tocV4, *hostServer.CDNName, deliveryservices)
+	if err != nil {
+		fmt.Println(time.Now().Format(time.RFC3339Nano) + " Error getting surrogate key purges: " + err.Error())
+		os.Exit(1)
+	}
+
+	// Delivery Service fields newer than the API of the old client come from the APIv4 Delivery Services.
+	opts := toclient.NewRequestOptions()
+	opts.QueryParameters.Set("cdn", strconv.Itoa(*hostServer.CDNID))
+	deliveryservicesV4, _, err := tocV4.GetDeliveryServices(opts)
+	if err != nil {
+		fmt.Println(time.Now().Format(time.RFC3339Nano) + " Error getting Traffic Ops APIv4 Deliveryservices: " + err.Error())
+		os.Exit(1)
+	}
+	traceHeaders := getTraceHeaders(deliveryservicesV4.Response)
+	streams := getStreams(deliveryservicesV4.Response)
+
+	return createRulesOld(host, deliveryservices, parents, deliveryserviceRegexes, cdns, serverParameters, dsCerts, certDir, surrogateKeyPurges, traceHeaders, streams)
+}
+
+// getTraceHeaders returns the trace_header plugin config of each of the given Delivery Services with a trace header policy, by XMLID.
+func getTraceHeaders(dses []tc.DeliveryServiceV4) map[string]remapdata.TraceHeader {
+	traceHeaders := map[string]remapdata.TraceHeader{}
+	for _, ds := range dses {
+		if ds.XMLID == nil || !ds.TraceHeaderEnabled() {
+			continue
+		}
+		traceHeaders[*ds.XMLID] = remapdata.TraceHeader{Header: ds.TraceHeader(), Policy: string(*ds.TraceHeaderPolicy)}
+	}
+	return traceHeaders
+}
+
+// Stream is the WebSocket and gRPC pass-through config of a Delivery Service's remap rules.
+type Stream struct {
+	WebSocket       bool
+	GRPC            bool
+	IdleTimeoutMS   int
+	ActiveTimeoutMS int
+}
+
+// getStreams returns the WebSocket and gRPC pass-through config of each of the given Delivery Services which passes either through, by XMLID.
+func getStreams(dses []tc.DeliveryServiceV4) map[string]Stream {
+	streams := map[string]Stream{}
+	for _, ds := range dses {
+		if ds.XMLID == nil || !ds.StreamingEnabled() {
+			continue
+		}
+		streams[*ds.XMLID] = Stream{
+			WebSocket:       ds.WebSocketEnabled(),
+			GRPC:            ds.GRPCEnabled(),
+			IdleTimeoutMS:   ds.StreamIdleTimeoutSeconds() * 1000,
+			ActiveTimeoutMS: ds.StreamActiveTimeoutSeconds() * 1000,
+		}
+	}
+	return streams
+}
+
+// getSurrogateKeyPurges returns the surrogate_key_purge plugin config of each of the given Delivery Services whose Profile enables purging by surrogate key, by XMLID.
+func getSurrogateKeyPurges(toc *to.Session, tocV4 *toclient.Session, cdnName string, dses []tc.DeliveryServiceNullable) (map[string]remapdata.SurrogateKeyPurges, error) {
+	params, _, err := toc.GetParameterByNameAndConfigFileWithHdr(tc.SurrogateKeyHeaderParameterName, tc.SurrogateKeyPurgeConfigFile, nil)
+	if err != nil {
+		return nil, errors.New("getting surrogate key parameters: " + err.Error())
+	}
+	profileHeaders := map[string]string{}
+	for _, param := range params {
+		profiles := []string{}
+		if err := json.Unmarshal(param.Profiles, &profiles); err != nil {
+			return nil, fmt.Errorf("parsing profiles of parameter %d: %v", param.ID, err)
+		}
+		for _, profile := range profiles {
+			profileHeaders[profile] = param.Value
+		}
+	}
+
+	purges := map[string]remapdata.SurrogateKeyPurges{}
+	for _, ds := range dses {
+		if ds.XMLID == nil || ds.ProfileName == nil {
+			continue
+		}
+		if header, ok := profileHeaders[*ds.ProfileName]; ok {
+			purges[*ds.XMLID] = remapdata.SurrogateKeyPurges{Header: header, Purges: []remapdata.SurrogateKeyPurge{}}
+		}
+	}
+	if len(purges) == 0 {
+		return purges, nil
+	}
+
+	opts := toclient.NewRequestOptions()
+	opts.QueryParameters.Set("cdn", cdnName)
+	opts.QueryParameters.Set("maxRevalDurationDays", "")
+	jobs, _, err := tocV4.GetInvalidationJobs(opts)
+	if err != nil {
+		return nil, errors.New("getting invalidation jobs: " + err.Error())
+	}
+	now := time.Now()
+	for _, job := range jobs.Response {
+		dsPurges, ok := purges[job.DeliveryService]
+		if !ok || len(job.SurrogateKeys) == 0 {
+			continue
+		}
+		ttl := time.Duration(job.TTLHours) * time.Hour
+		if job.TTLOverrideMinutes != nil {
+			ttl = time.Duration(*job.TTLOverrideMinutes) * time.Minute
+		}
+		end := job.StartTime.Add(ttl)
+		if !end.After(now) {
+			continue
+		}
+		dsPurges.Purges = append(dsPurges.Purges, remapdata.SurrogateKeyPurge{
+			Keys:       job.SurrogateKeys,
+			Start:      job.StartTime,
+			End:        end,
+			Refetch:    job.InvalidationType == tc.REFETCH,
+			ServeStale: job.ServeStale,
+		})
+		purges[job.DeliveryService] = dsPurges
+	}
+	return purges, nil
 }
 
 // func createRulesNewAPI(toc *to.Session, host string, certDir string) (remap.RemapRules, error) {
@@ -863,6 +987,9 @@ func createRulesOld(
 	hostParams []tc.Parameter,
 	dsCerts map[string]tc.CDNSSLKeys,
 	certDir string,
+	surrogateKeyPurges map[string]remapdata.SurrogateKeyPurges,
+	traceHeaders map[string]remapdata.TraceHeader,
+	streams map[string]Stream,
 ) (remap.RemapRules, error) {
 	rules := []remapdata.RemapRule{}
 	allowedIPs, err := getAllowIP(hostParams)
@@ -954,6 +1081,12 @@ func createRulesOld(
 				}
 
 				rule.PluginsShared = map[string]json.RawMessage{}
+				if stream, ok := streams[*ds.XMLID]; ok {
+					rule.WebSocket = stream.WebSocket
+					rule.GRPC = stream.GRPC
+					rule.StreamIdleTimeoutMS = stream.IdleTimeoutMS
+					rule.StreamActiveTimeoutMS = stream.ActiveTimeoutMS
+				}
 				// if the delivery service skips the mid's ie, http_no_cache, http_live, and dns_live
 				// only add the url rule to the origin.
 				if dsTypeSkipsMid(dsType) {
@@ -983,6 +1116,12 @@ func createRulesOld(
 					rule.Plugins = map[string]interface{}{}
 					rule.Plugins["modify_headers"] = toClientHeaders
 					rule.Plugins["modify_parent_request_headers"] = toOriginHeaders
+					if purges, ok := surrogateKeyPurges[*ds.XMLID]; ok {
+						rule.Plugins["surrogate_key_purge"] = purges
+					}
+					if traceHeader, ok := traceHeaders[*ds.XMLID]; ok {
+						rule.Plugins["trace_header"] = traceHeader
+					}
 					remapTextJSON, err := json.Marshal(dsRemap)
 					if err != nil {
 						return remap.RemapRules{}, fmt.Errorf("parsing deliveryservice '%v' remap text '%v' marshalling JSON: %v", *ds.XMLID, dsRemap, err)
@@ -1019,6 +1158,12 @@ func createRulesOld(
 						rule.Plugins = map[string]interface{}{}
 						rule.Plugins["modify_headers"] = toClientHeaders
 						rule.Plugins["modify_parent_request_headers"] = toOriginHeaders
+						if purges, ok := surrogateKeyPurges[*ds.XMLID]; ok {
+							rule.Plugins["surrogate_key_purge"] = purges
+						}
+						if traceHeader, ok := traceHeaders[*ds.XMLID]; ok {
+							rule.Plugins["trace_header"] = traceHeader
+						}
 						remapTextJSON, err := json.Marshal(dsRemap)
 						if err != nil {
 							return remap.RemapRules{}, fmt.Errorf("parsing deliveryservice '%v' remap text '%v' marshalling JSON: %v", *ds.XMLID, dsRemap, err)
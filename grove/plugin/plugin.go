@@ -31,6 +31,8 @@ import (
 	"github.com/apache/trafficcontrol/grove/remapdata"
 	"github.com/apache/trafficcontrol/grove/stat"
 	"github.com/apache/trafficcontrol/grove/web"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
 )
 
 func AddPlugin(priority uint64, funcs Funcs) {
@@ -48,6 +50,7 @@ type Funcs struct {
 	startup             StartupFunc
 	onRequest           OnRequestFunc
 	beforeCacheLookUp   BeforeCacheLookupFunc
+	afterCacheLookUp    AfterCacheLookUpFunc
 	beforeParentRequest BeforeParentRequestFunc
 	beforeRespond       BeforeRespondFunc
 	afterRespond        AfterRespondFunc
@@ -99,6 +102,15 @@ type BeforeCacheLookUpData struct {
 	Context              *interface{}
 }
 
+// AfterCacheLookUpData holds the data passed to plugins when an object was found in the cache. Plugins may change the value pointed to by CanReuse, to make the object be revalidated or refetched rather than served, but the CacheObj MAY NOT be modified.
+type AfterCacheLookUpData struct {
+	Req       *http.Request
+	CacheObj  *cacheobj.CacheObj
+	CanReuse  *rfc.Reuse
+	RemapRule string
+	Context   *interface{}
+}
+
 type AfterRespondData struct {
 	W         http.ResponseWriter
 	Stats     stat.Stats
@@ -114,6 +126,7 @@ type LoadFunc func(json.RawMessage) interface{}
 type StartupFunc func(icfg interface{}, d StartupData)
 type OnRequestFunc func(icfg interface{}, d OnRequestData) bool
 type BeforeCacheLookupFunc func(icfg interface{}, d BeforeCacheLookUpData)
+type AfterCacheLookUpFunc func(icfg interface{}, d AfterCacheLookUpData)
 type BeforeParentRequestFunc func(icfg interface{}, d BeforeParentRequestData)
 type BeforeRespondFunc func(icfg interface{}, d BeforeRespondData)
 type AfterRespondFunc func(icfg interface{}, d AfterRespondData)
@@ -153,6 +166,7 @@ type Plugins interface {
 	OnStartup(cfgs map[string]interface{}, context map[string]*interface{}, d StartupData)
 	OnRequest(cfgs map[string]interface{}, context map[string]*interface{}, d OnRequestData) bool
 	OnBeforeCacheLookup(cfgs map[string]interface{}, context map[string]*interface{}, d BeforeCacheLookUpData)
+	OnAfterCacheLookUp(cfgs map[string]interface{}, context map[string]*interface{}, d AfterCacheLookUpData)
 	OnBeforeParentRequest(cfgs map[string]interface{}, context map[string]*interface{}, d BeforeParentRequestData)
 	OnBeforeRespond(cfgs map[string]interface{}, context map[string]*interface{}, d BeforeRespondData)
 	OnAfterRespond(cfgs map[string]interface{}, context map[string]*interface{}, d AfterRespondData)
@@ -206,6 +220,16 @@ func (ps pluginsSlice) OnBeforeCacheLookup(cfgs map[string]interface{}, context
 	}
 }
 
+func (ps pluginsSlice) OnAfterCacheLookUp(cfgs map[string]interface{}, context map[string]*interface{}, d AfterCacheLookUpData) {
+	for _, p := range ps {
+		if p.funcs.afterCacheLookUp == nil {
+			continue
+		}
+		d.Context = context[p.name]
+		p.funcs.afterCacheLookUp(cfgs[p.name], d)
+	}
+}
+
 func (ps pluginsSlice) OnBeforeParentRequest(cfgs map[string]interface{}, context map[string]*interface{}, d BeforeParentRequestData) {
 	for _, p := range ps {
 		if p.funcs.beforeParentRequest == nil {
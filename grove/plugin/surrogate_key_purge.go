@@ -0,0 +1,116 @@
+package plugin
+
+/*
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/grove/cacheobj"
+	"github.com/apache/trafficcontrol/grove/remapdata"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func init() {
+	AddPlugin(10000, Funcs{load: surrogateKeyPurgeLoad, afterCacheLookUp: surrogateKeyPurge})
+}
+
+func surrogateKeyPurgeLoad(b json.RawMessage) interface{} {
+	cfg := remapdata.SurrogateKeyPurges{}
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		log.Errorln("surrogate_key_purge loading config, unmarshalling JSON: " + err.Error())
+		return nil
+	}
+	if cfg.Header == "" {
+		cfg.Header = tc.DefaultSurrogateKeyHeader
+	}
+	cfg.Header = http.CanonicalHeaderKey(cfg.Header)
+	log.Debugf("surrogate_key_purge load success: %+v\n", cfg)
+	return &cfg
+}
+
+func surrogateKeyPurge(icfg interface{}, d AfterCacheLookUpData) {
+	if icfg == nil {
+		return
+	}
+	cfg, ok := icfg.(*remapdata.SurrogateKeyPurges)
+	if !ok {
+		// should never happen
+		log.Errorf("surrogate_key_purge config '%v' type '%T' expected *remapdata.SurrogateKeyPurges\n", icfg, icfg)
+		return
+	}
+	reuse := surrogateKeyPurgeReuse(cfg, d.CacheObj, time.Now())
+	if reuseStrictness[reuse] > reuseStrictness[*d.CanReuse] {
+		log.Debugf("surrogate_key_purge rule %v purged object, reuse %v\n", d.RemapRule, reuse)
+		*d.CanReuse = reuse
+	}
+}
+
+// reuseStrictness orders the ways of reusing a cached object from the least to the most requests to the parent they require.
+var reuseStrictness = map[rfc.Reuse]int{
+	rfc.ReuseCan:                    0,
+	rfc.ReuseMustRevalidateCanStale: 1,
+	rfc.ReuseMustRevalidate:         2,
+	rfc.ReuseCannot:                 3,
+}
+
+// surrogateKeyPurgeReuse returns how the given cached object may be reused at the given time, according to the purges of its surrogate keys in effect: ReuseCan if none are.
+func surrogateKeyPurgeReuse(cfg *remapdata.SurrogateKeyPurges, obj *cacheobj.CacheObj, now time.Time) rfc.Reuse {
+	reuse := rfc.ReuseCan
+	if obj == nil || len(cfg.Purges) == 0 {
+		return reuse
+	}
+	keys := map[string]struct{}{}
+	for _, val := range obj.RespHeaders[cfg.Header] {
+		for _, key := range strings.Fields(val) {
+			keys[key] = struct{}{}
+		}
+	}
+	if len(keys) == 0 {
+		return reuse
+	}
+	for _, purge := range cfg.Purges {
+		if now.Before(purge.Start) || !now.Before(purge.End) || !obj.ReqRespTime.Before(purge.Start) {
+			continue
+		}
+		if !hasAnyKey(keys, purge.Keys) {
+			continue
+		}
+		purgeReuse := rfc.ReuseMustRevalidate
+		if purge.ServeStale {
+			purgeReuse = rfc.ReuseMustRevalidateCanStale
+		} else if purge.Refetch {
+			purgeReuse = rfc.ReuseCannot
+		}
+		if reuseStrictness[purgeReuse] > reuseStrictness[reuse] {
+			reuse = purgeReuse
+		}
+	}
+	return reuse
+}
+
+func hasAnyKey(keys map[string]struct{}, purgeKeys []string) bool {
+	for _, key := range purgeKeys {
+		if _, ok := keys[key]; ok {
+			return true
+		}
+	}
+	return false
+}
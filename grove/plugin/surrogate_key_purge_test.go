@@ -0,0 +1,83 @@
+package plugin
+
+/*
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/grove/cacheobj"
+	"github.com/apache/trafficcontrol/grove/remapdata"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+)
+
+func TestSurrogateKeyPurge(t *testing.T) {
+	now := time.Now()
+	start := now.Add(-time.Hour)
+	end := now.Add(time.Hour)
+
+	icfg := surrogateKeyPurgeLoad(json.RawMessage(`{"header": "x-keys"}`))
+	cfg, ok := icfg.(*remapdata.SurrogateKeyPurges)
+	if !ok {
+		t.Fatalf("expected load to return *remapdata.SurrogateKeyPurges, actual: %T", icfg)
+	}
+	if cfg.Header != "X-Keys" {
+		t.Errorf("expected canonical header 'X-Keys', actual: '%s'", cfg.Header)
+	}
+
+	cfg.Purges = []remapdata.SurrogateKeyPurge{
+		{Keys: []string{"user-1"}, Start: start, End: end},
+		{Keys: []string{"user-2", "user-3"}, Start: start, End: end, Refetch: true},
+		{Keys: []string{"user-4"}, Start: start, End: end, Refetch: true, ServeStale: true},
+		{Keys: []string{"user-5"}, Start: now.Add(time.Minute), End: end, Refetch: true},
+		{Keys: []string{"user-6"}, Start: now.Add(-2 * time.Hour), End: start, Refetch: true},
+	}
+
+	cachedBefore := now.Add(-2 * time.Hour)
+	tests := []struct {
+		name     string
+		keys     []string
+		cachedAt time.Time
+		expected rfc.Reuse
+	}{
+		{"untagged", nil, cachedBefore, rfc.ReuseCan},
+		{"not purged", []string{"user-9"}, cachedBefore, rfc.ReuseCan},
+		{"refresh", []string{"page user-1"}, cachedBefore, rfc.ReuseMustRevalidate},
+		{"refetch", []string{"page", "user-3"}, cachedBefore, rfc.ReuseCannot},
+		{"refetch wins", []string{"user-1 user-2"}, cachedBefore, rfc.ReuseCannot},
+		{"serve stale", []string{"user-4"}, cachedBefore, rfc.ReuseMustRevalidateCanStale},
+		{"cached after start", []string{"user-2"}, now, rfc.ReuseCan},
+		{"not started", []string{"user-5"}, cachedBefore, rfc.ReuseCan},
+		{"expired", []string{"user-6"}, now.Add(-3 * time.Hour), rfc.ReuseCan},
+	}
+	for _, test := range tests {
+		obj := &cacheobj.CacheObj{RespHeaders: http.Header{"X-Keys": test.keys}, ReqRespTime: test.cachedAt}
+		reuse := rfc.ReuseCan
+		surrogateKeyPurge(cfg, AfterCacheLookUpData{CacheObj: obj, CanReuse: &reuse})
+		if reuse != test.expected {
+			t.Errorf("%s: expected %v, actual: %v", test.name, test.expected, reuse)
+		}
+	}
+
+	obj := &cacheobj.CacheObj{RespHeaders: http.Header{"X-Keys": {"user-1"}}, ReqRespTime: cachedBefore}
+	reuse := rfc.ReuseCannot
+	surrogateKeyPurge(cfg, AfterCacheLookUpData{CacheObj: obj, CanReuse: &reuse})
+	if reuse != rfc.ReuseCannot {
+		t.Errorf("expected purge not to loosen reuse %v, actual: %v", rfc.ReuseCannot, reuse)
+	}
+}
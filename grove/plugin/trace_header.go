@@ -0,0 +1,117 @@
+package plugin
+
+/*
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+
+	"github.com/apache/trafficcontrol/grove/remapdata"
+	"github.com/apache/trafficcontrol/grove/web"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func init() {
+	AddPlugin(10000, Funcs{load: traceHeaderLoad, beforeParentRequest: traceHeaderBeforeParentRequest, beforeRespond: traceHeaderBeforeRespond})
+}
+
+func traceHeaderLoad(b json.RawMessage) interface{} {
+	cfg := remapdata.TraceHeader{}
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		log.Errorln("trace_header loading config, unmarshalling JSON: " + err.Error())
+		return nil
+	}
+	policy, err := tc.TraceHeaderPolicyFromString(cfg.Policy)
+	if err != nil {
+		log.Errorln("trace_header loading config: " + err.Error())
+		return nil
+	}
+	cfg.Policy = string(policy)
+	if cfg.Header == "" {
+		cfg.Header = tc.DefaultTraceHeaderName
+	}
+	cfg.Header = http.CanonicalHeaderKey(cfg.Header)
+	log.Debugf("trace_header load success: %+v\n", cfg)
+	return &cfg
+}
+
+func getTraceHeaderCfg(icfg interface{}) *remapdata.TraceHeader {
+	if icfg == nil {
+		return nil
+	}
+	cfg, ok := icfg.(*remapdata.TraceHeader)
+	if !ok {
+		// should never happen
+		log.Errorf("trace_header config '%v' type '%T' expected *remapdata.TraceHeader\n", icfg, icfg)
+		return nil
+	}
+	return cfg
+}
+
+func traceHeaderBeforeParentRequest(icfg interface{}, d BeforeParentRequestData) {
+	cfg := getTraceHeaderCfg(icfg)
+	if cfg == nil {
+		return
+	}
+	traceHeaderRequest(cfg, d.Req.Header)
+}
+
+func traceHeaderBeforeRespond(icfg interface{}, d BeforeRespondData) {
+	cfg := getTraceHeaderCfg(icfg)
+	if cfg == nil {
+		return
+	}
+	// Cache hits never request from the parent, so the identifier may not have been generated yet.
+	traceHeaderRequest(cfg, d.Req.Header)
+	*d.Hdr = web.CopyHeader(*d.Hdr)
+	traceHeaderRespond(cfg, d.Req.Header, *d.Hdr)
+}
+
+// traceHeaderRequest applies the trace header policy to the header of a client request, which is also that of the request made to the parent.
+func traceHeaderRequest(cfg *remapdata.TraceHeader, reqHdr http.Header) {
+	switch tc.TraceHeaderPolicy(cfg.Policy) {
+	case tc.TraceHeaderPolicyGenerate:
+		if reqHdr.Get(cfg.Header) == "" {
+			reqHdr.Set(cfg.Header, newTraceID())
+		}
+	case tc.TraceHeaderPolicyStrip:
+		reqHdr.Del(cfg.Header)
+	}
+}
+
+// traceHeaderRespond applies the trace header policy to the header of the response to a client request with the given header.
+func traceHeaderRespond(cfg *remapdata.TraceHeader, reqHdr http.Header, respHdr http.Header) {
+	switch tc.TraceHeaderPolicy(cfg.Policy) {
+	case tc.TraceHeaderPolicyGenerate, tc.TraceHeaderPolicyPropagate:
+		if id := reqHdr.Get(cfg.Header); id != "" {
+			respHdr.Set(cfg.Header, id)
+		}
+	case tc.TraceHeaderPolicyStrip:
+		respHdr.Del(cfg.Header)
+	}
+}
+
+// newTraceID returns a new, random identifier for tracing a request.
+func newTraceID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		log.Errorln("trace_header generating identifier: " + err.Error())
+	}
+	return hex.EncodeToString(b)
+}
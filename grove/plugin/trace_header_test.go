@@ -0,0 +1,84 @@
+package plugin
+
+/*
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/apache/trafficcontrol/grove/remapdata"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestTraceHeaderLoad(t *testing.T) {
+	icfg := traceHeaderLoad(json.RawMessage(`{"policy": "Generate"}`))
+	cfg, ok := icfg.(*remapdata.TraceHeader)
+	if !ok {
+		t.Fatalf("expected load to return *remapdata.TraceHeader, actual: %T", icfg)
+	}
+	if cfg.Header != tc.DefaultTraceHeaderName {
+		t.Errorf("expected default header '%s', actual: '%s'", tc.DefaultTraceHeaderName, cfg.Header)
+	}
+	if cfg.Policy != string(tc.TraceHeaderPolicyGenerate) {
+		t.Errorf("expected policy '%s', actual: '%s'", tc.TraceHeaderPolicyGenerate, cfg.Policy)
+	}
+	if icfg := traceHeaderLoad(json.RawMessage(`{"policy": "forward"}`)); icfg != nil {
+		t.Errorf("expected load of an unknown policy to fail, actual: %+v", icfg)
+	}
+}
+
+func TestTraceHeader(t *testing.T) {
+	const hdr = "X-Trace-Id"
+
+	cfg := &remapdata.TraceHeader{Header: hdr, Policy: string(tc.TraceHeaderPolicyGenerate)}
+	reqHdr := http.Header{}
+	traceHeaderRequest(cfg, reqHdr)
+	id := reqHdr.Get(hdr)
+	if id == "" {
+		t.Fatal("expected generate policy to give a request without a trace header a new one")
+	}
+	traceHeaderRequest(cfg, reqHdr)
+	if reqHdr.Get(hdr) != id {
+		t.Errorf("expected generate policy to keep the request's trace header '%s', actual: '%s'", id, reqHdr.Get(hdr))
+	}
+	respHdr := http.Header{}
+	traceHeaderRespond(cfg, reqHdr, respHdr)
+	if respHdr.Get(hdr) != id {
+		t.Errorf("expected generate policy to return trace header '%s', actual: '%s'", id, respHdr.Get(hdr))
+	}
+
+	cfg.Policy = string(tc.TraceHeaderPolicyPropagate)
+	reqHdr = http.Header{}
+	traceHeaderRequest(cfg, reqHdr)
+	if reqHdr.Get(hdr) != "" {
+		t.Errorf("expected propagate policy not to generate a trace header, actual: '%s'", reqHdr.Get(hdr))
+	}
+	respHdr = http.Header{}
+	traceHeaderRespond(cfg, reqHdr, respHdr)
+	if _, ok := respHdr[hdr]; ok {
+		t.Error("expected propagate policy not to return a trace header the client didn't send")
+	}
+
+	cfg.Policy = string(tc.TraceHeaderPolicyStrip)
+	reqHdr = http.Header{hdr: {"abc"}}
+	respHdr = http.Header{hdr: {"abc"}}
+	traceHeaderRequest(cfg, reqHdr)
+	traceHeaderRespond(cfg, reqHdr, respHdr)
+	if len(reqHdr) != 0 || len(respHdr) != 0 {
+		t.Errorf("expected strip policy to remove the trace header from the request and response, actual: %v, %v", reqHdr, respHdr)
+	}
+}
@@ -126,6 +126,20 @@ func (p *RemappingProducer) Name() string                      { return p.rule.N
 func (p *RemappingProducer) DSCP() int                         { return p.rule.DSCP }
 func (p *RemappingProducer) PluginCfg() map[string]interface{} { return p.rule.Plugins }
 func (p *RemappingProducer) Cache() icache.Cache               { return p.rule.Cache }
+func (p *RemappingProducer) WebSocket() bool                   { return p.rule.WebSocket }
+func (p *RemappingProducer) GRPC() bool                        { return p.rule.GRPC }
+func (p *RemappingProducer) StreamIdleTimeout() time.Duration {
+	if p.rule.StreamIdleTimeoutMS <= 0 {
+		return remapdata.DefaultStreamIdleTimeout
+	}
+	return time.Duration(p.rule.StreamIdleTimeoutMS) * time.Millisecond
+}
+func (p *RemappingProducer) StreamActiveTimeout() time.Duration {
+	if p.rule.StreamActiveTimeoutMS <= 0 {
+		return remapdata.DefaultStreamActiveTimeout
+	}
+	return time.Duration(p.rule.StreamActiveTimeoutMS) * time.Millisecond
+}
 func (p *RemappingProducer) FirstFQDN() string {
 	// TODO verify To is not allowed to be constructed with < 1 element
 	return strings.TrimPrefix(strings.TrimPrefix(p.rule.To[0].URL, "http://"), "https://")
@@ -504,6 +518,12 @@ func makeTo(tosJSON []RemapRuleToJSON, rule remapdata.RemapRule, baseTransport *
 			}
 			to.Transport = &newTransport
 		}
+		if rule.GRPC {
+			// gRPC requires HTTP/2, which Transports with custom dialers don't attempt unless forced. Parents which don't negotiate it over TLS get HTTP/1.1, as before.
+			grpcTransport := to.Transport.Clone()
+			grpcTransport.ForceAttemptHTTP2 = true
+			to.Transport = grpcTransport
+		}
 
 		if toJSON.TimeoutMS != nil {
 			t := time.Duration(*toJSON.TimeoutMS) * time.Millisecond
@@ -98,8 +98,22 @@ type RemapRuleBase struct {
 	RetryNum               *int                       `json:"retry_num"`
 	DSCP                   int                        `json:"dscp"`
 	PluginsShared          map[string]json.RawMessage `json:"plugins_shared"`
+	// WebSocket is whether to pass WebSocket upgrade requests through to the parent, and relay the resulting connections.
+	WebSocket bool `json:"websocket"`
+	// GRPC is whether to pass gRPC requests through to the parent over HTTP/2, streaming bodies and trailers, instead of caching them.
+	GRPC bool `json:"grpc"`
+	// StreamIdleTimeoutMS is how long a WebSocket or gRPC stream may go without data in either direction before it's closed. If this is 0, DefaultStreamIdleTimeout is used.
+	StreamIdleTimeoutMS int `json:"stream_idle_timeout_ms"`
+	// StreamActiveTimeoutMS is the longest a WebSocket or gRPC stream may stay open. If this is 0, DefaultStreamActiveTimeout is used.
+	StreamActiveTimeoutMS int `json:"stream_active_timeout_ms"`
 }
 
+// DefaultStreamIdleTimeout is how long WebSocket and gRPC streams may go without data, for rules which don't set it.
+const DefaultStreamIdleTimeout = 5 * time.Minute
+
+// DefaultStreamActiveTimeout is the longest WebSocket and gRPC streams may stay open, for rules which don't set it.
+const DefaultStreamActiveTimeout = time.Hour
+
 type RemapRule struct {
 	RemapRuleBase
 	Timeout         *time.Duration
@@ -223,3 +237,28 @@ type QueryStringRule struct {
 	Remap bool `json:"remap"`
 	Cache bool `json:"cache"`
 }
+
+// SurrogateKeyPurges is the configuration of the surrogate_key_purge plugin for a remap rule.
+type SurrogateKeyPurges struct {
+	// Header is the origin response header containing the space-separated surrogate keys of an object.
+	Header string `json:"header"`
+	// Purges are the purges of the rule's objects by surrogate key.
+	Purges []SurrogateKeyPurge `json:"purges"`
+}
+
+// SurrogateKeyPurge is a purge of all objects tagged with any of its Keys, which were cached before its Start. From its Start until its End, such objects are revalidated rather than served, or requested again if Refetch is true. If ServeStale is true, they're always revalidated, and may be served stale if the parent can't be reached.
+type SurrogateKeyPurge struct {
+	Keys       []string  `json:"keys"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	Refetch    bool      `json:"refetch"`
+	ServeStale bool      `json:"serve_stale"`
+}
+
+// TraceHeader is the configuration of the trace_header plugin for a remap rule.
+type TraceHeader struct {
+	// Header is the request header carrying the identifier used to trace a request through the CDN.
+	Header string `json:"header"`
+	// Policy is how the header is treated: "generate", "propagate", or "strip", as a Traffic Ops Delivery Service's traceHeaderPolicy.
+	Policy string `json:"policy"`
+}
@@ -38,20 +38,27 @@ type CacheDotConfigOpts struct {
 }
 
 // MakeCacheDotConfig makes the ATS cache.config config file.
+//
+// The cachePolicies are the cache policies of the Delivery Services which have
+// one; their ignoreOriginNoCache and maxTTL are compiled into cache.config.
+// Likewise, the noCache and maxTTL of the path rules of the Delivery Services
+// in dsPathRules are compiled into cache.config.
 func MakeCacheDotConfig(
 	server *Server,
 	servers []Server,
 	deliveryServices []DeliveryService,
 	deliveryServiceServers []DeliveryServiceServer,
+	cachePolicies []tc.DeliveryServiceCachePolicy,
+	dsPathRules []tc.DeliveryServicePathRules,
 	opt *CacheDotConfigOpts,
 ) (Cfg, error) {
 	if opt == nil {
 		opt = &CacheDotConfigOpts{}
 	}
 	if tc.CacheTypeFromString(server.Type) == tc.CacheTypeMid {
-		return makeCacheDotConfigMid(server, deliveryServices, opt)
+		return makeCacheDotConfigMid(server, deliveryServices, cachePolicies, dsPathRules, opt)
 	} else {
-		return makeCacheDotConfigEdge(server, servers, deliveryServices, deliveryServiceServers, opt)
+		return makeCacheDotConfigEdge(server, servers, deliveryServices, deliveryServiceServers, cachePolicies, dsPathRules, opt)
 	}
 }
 
@@ -60,6 +67,8 @@ func makeCacheDotConfigEdge(
 	servers []Server,
 	deliveryServices []DeliveryService,
 	deliveryServiceServers []DeliveryServiceServer,
+	cachePolicies []tc.DeliveryServiceCachePolicy,
+	dsPathRules []tc.DeliveryServicePathRules,
 	opt *CacheDotConfigOpts,
 ) (Cfg, error) {
 	if opt == nil {
@@ -97,6 +106,9 @@ func makeCacheDotConfigEdge(
 		dsIDs[dss.DeliveryService] = struct{}{}
 	}
 
+	dsPolicies := makeDSCachePolicyMap(cachePolicies)
+	dsRules := makeDSPathRuleMap(dsPathRules)
+
 	profileDSes := []profileDS{}
 	for _, ds := range deliveryServices {
 		if ds.ID == nil {
@@ -122,19 +134,34 @@ func makeCacheDotConfigEdge(
 			continue
 		}
 		origin := *ds.OrgServerFQDN
-		profileDSes = append(profileDSes, profileDS{Type: *ds.Type, OriginFQDN: &origin})
+		pds := profileDS{Type: *ds.Type, OriginFQDN: &origin, PathRules: dsRules[*ds.ID]}
+		if policy, ok := dsPolicies[*ds.ID]; ok {
+			pds.CachePolicy = &policy
+		}
+		profileDSes = append(profileDSes, pds)
 	}
 
 	lines := map[string]struct{}{} // use a "set" for lines, to avoid duplicates, since we're looking up by profile
 	for _, ds := range profileDSes {
-		if ds.Type != tc.DSTypeHTTPNoCache {
+		if ds.Type != tc.DSTypeHTTPNoCache && ds.CachePolicy == nil && len(ds.PathRules) == 0 {
 			continue
 		}
 		if ds.OriginFQDN == nil || *ds.OriginFQDN == "" {
 			warnings = append(warnings, "profileCacheDotConfig ds has no origin fqdn, skipping!") // TODO add ds name to data loaded, to put it in the error here?
 			continue
 		}
+		for _, l := range makePathRuleCacheDotConfigLines(*ds.OriginFQDN, ds.PathRules) {
+			lines[l] = struct{}{}
+		}
 		originFQDN, originPort := getHostPortFromURI(*ds.OriginFQDN)
+		if ds.Type != tc.DSTypeHTTPNoCache {
+			if ds.CachePolicy != nil {
+				for _, l := range makeCachePolicyCacheDotConfigLines(*ds.CachePolicy, originFQDN, originPort) {
+					lines[l] = struct{}{}
+				}
+			}
+			continue
+		}
 		if originPort != "" {
 			l := "dest_domain=" + originFQDN + " port=" + originPort + " scheme=http action=never-cache\n"
 			lines[l] = struct{}{}
@@ -142,6 +169,14 @@ func makeCacheDotConfigEdge(
 			l := "dest_domain=" + originFQDN + " scheme=http action=never-cache\n"
 			lines[l] = struct{}{}
 		}
+		for _, origin := range pathRuleOrigins(*ds.OriginFQDN, ds.PathRules) {
+			ruleFQDN, rulePort := getHostPortFromURI(origin)
+			l := "dest_domain=" + ruleFQDN + " scheme=http action=never-cache\n"
+			if rulePort != "" {
+				l = "dest_domain=" + ruleFQDN + " port=" + rulePort + " scheme=http action=never-cache\n"
+			}
+			lines[l] = struct{}{}
+		}
 	}
 
 	linesArr := []string{}
@@ -165,8 +200,10 @@ func makeCacheDotConfigEdge(
 }
 
 type profileDS struct {
-	Type       tc.DSType
-	OriginFQDN *string
+	Type        tc.DSType
+	OriginFQDN  *string
+	CachePolicy *tc.DeliveryServiceCachePolicy
+	PathRules   []tc.DeliveryServicePathRule
 }
 
 // dsesToProfileDSes is a helper function to convert a []tc.DeliveryServiceNullable to []ProfileDS.
@@ -60,7 +60,7 @@ func TestMakeCacheDotConfig(t *testing.T) {
 
 	hdr := "myHeaderComment"
 
-	cfg, err := MakeCacheDotConfig(server, servers, dses, dss, &CacheDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeCacheDotConfig(server, servers, dses, dss, nil, nil, &CacheDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -79,3 +79,44 @@ func TestMakeCacheDotConfig(t *testing.T) {
 	}
 
 }
+
+func TestMakeCacheDotConfigCachePolicies(t *testing.T) {
+	server := makeGenericServer()
+	server.ProfileNames = []string{"myProfile"}
+	servers := []Server{*server}
+
+	ds := makeGenericDS()
+	ds.ID = util.IntPtr(420)
+	ds.XMLID = util.StrPtr("ds0")
+	ds.OrgServerFQDN = util.StrPtr("http://my.fqdn.example.net:8080")
+	dsType := tc.DSTypeHTTP
+	ds.Type = &dsType
+
+	dses := []DeliveryService{*ds}
+	dss := makeDSS(servers, dses)
+
+	policies := []tc.DeliveryServiceCachePolicy{
+		{
+			DeliveryServiceID:   420,
+			XMLID:               "ds0",
+			IgnoreOriginNoCache: true,
+			MaxTTL:              util.IntPtr(3600),
+		},
+	}
+
+	cfg, err := MakeCacheDotConfig(server, servers, dses, dss, policies, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txt := cfg.Text
+
+	if !strings.Contains(txt, "dest_domain=my.fqdn.example.net port=8080 action=ignore-server-no-cache\n") {
+		t.Errorf("expected ignore-server-no-cache line for the DS origin, actual: '%v'", txt)
+	}
+	if !strings.Contains(txt, "dest_domain=my.fqdn.example.net port=8080 revalidate=3600s\n") {
+		t.Errorf("expected revalidate line for the DS origin, actual: '%v'", txt)
+	}
+	if strings.Contains(txt, "never-cache") {
+		t.Errorf("expected no never-cache line for a cached DS, actual: '%v'", txt)
+	}
+}
@@ -0,0 +1,149 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// originVaryHeader is the internal header in which the origin's Vary header
+// is kept while it's filtered down to the honored request headers.
+const originVaryHeader = "@Origin-Vary"
+
+// ApplyCachePolicies compiles the default TTLs and honored Vary headers of the
+// given Delivery Service cache policies into the header rewrite text of their
+// Delivery Services, and returns the resulting Delivery Services, and any
+// warnings. The given deliveryServices are not modified.
+//
+// The rules act on the origin's response, so they are placed on the last
+// cache tier, before the Delivery Service's own LastHeaderRewrite if it uses
+// a Topology, its MidHeaderRewrite if its Type uses mid-tier cache servers,
+// or its EdgeHeaderRewrite otherwise. Cache servers of lower tiers then cache
+// the response as the last tier rewrote it.
+//
+// The rest of a policy is compiled into cache.config, by MakeCacheDotConfig.
+//
+// This must be called before generating any config file, because it changes
+// which config files are needed.
+func ApplyCachePolicies(deliveryServices []DeliveryService, cachePolicies []tc.DeliveryServiceCachePolicy) ([]DeliveryService, []string) {
+	warnings := []string{}
+	if len(cachePolicies) == 0 {
+		return deliveryServices, warnings
+	}
+
+	dsPolicies := makeDSCachePolicyMap(cachePolicies)
+
+	dses := make([]DeliveryService, 0, len(deliveryServices))
+	for _, ds := range deliveryServices {
+		if ds.ID == nil {
+			dses = append(dses, ds)
+			continue
+		}
+		policy, ok := dsPolicies[*ds.ID]
+		if !ok {
+			dses = append(dses, ds)
+			continue
+		}
+
+		hdrRw := compileCachePolicyHeaderRewrite(policy)
+		if hdrRw == "" {
+			dses = append(dses, ds)
+			continue
+		}
+		switch {
+		case ds.Topology != nil && *ds.Topology != "":
+			ds.LastHeaderRewrite = prependHeaderRewrite(hdrRw, ds.LastHeaderRewrite)
+		case ds.Type != nil && ds.Type.UsesMidCache():
+			ds.MidHeaderRewrite = prependHeaderRewrite(hdrRw, ds.MidHeaderRewrite)
+		default:
+			ds.EdgeHeaderRewrite = prependHeaderRewrite(hdrRw, ds.EdgeHeaderRewrite)
+		}
+		dses = append(dses, ds)
+	}
+	return dses, warnings
+}
+
+// makeDSCachePolicyMap returns the given cache policies, keyed by the IDs of
+// their Delivery Services.
+func makeDSCachePolicyMap(cachePolicies []tc.DeliveryServiceCachePolicy) map[int]tc.DeliveryServiceCachePolicy {
+	dsPolicies := make(map[int]tc.DeliveryServiceCachePolicy, len(cachePolicies))
+	for _, policy := range cachePolicies {
+		dsPolicies[policy.DeliveryServiceID] = policy
+	}
+	return dsPolicies
+}
+
+// compileCachePolicyHeaderRewrite returns the header_rewrite text of the
+// default TTLs and honored Vary headers of the given policy, which is empty
+// if it has neither.
+//
+// Default TTLs set a Cache-Control header on responses with their status
+// which don't have one. If only some Vary headers are honored, the origin's
+// Vary header is replaced by one which lists only those of them it has.
+func compileCachePolicyHeaderRewrite(policy tc.DeliveryServiceCachePolicy) string {
+	rules := []string{}
+
+	ttls := make([]tc.CachePolicyDefaultTTL, len(policy.DefaultTTLs))
+	copy(ttls, policy.DefaultTTLs)
+	sort.Slice(ttls, func(i, j int) bool { return ttls[i].StatusCode < ttls[j].StatusCode })
+	for _, ttl := range ttls {
+		rules = append(rules, "cond %{READ_RESPONSE_HDR_HOOK} [AND]\n"+
+			"cond %{STATUS} ="+strconv.Itoa(ttl.StatusCode)+" [AND]\n"+
+			`cond %{HEADER:Cache-Control} =""`+"\n"+
+			`set-header Cache-Control "max-age=`+strconv.Itoa(ttl.TTL)+`"`)
+	}
+
+	if policy.HonorVary != nil {
+		rules = append(rules, "cond %{READ_RESPONSE_HDR_HOOK} [AND]\n"+
+			"cond %{HEADER:Vary} /./\n"+
+			"set-header "+originVaryHeader+" %{HEADER:Vary}\n"+
+			"rm-header Vary")
+		for _, header := range policy.HonorVary {
+			rules = append(rules, "cond %{READ_RESPONSE_HDR_HOOK} [AND]\n"+
+				"cond %{HEADER:"+originVaryHeader+"} /(^|,)\\s*"+regexp.QuoteMeta(header)+"\\s*(,|$)/ [NOCASE]\n"+
+				`add-header Vary "`+header+`"`)
+		}
+	}
+	return strings.Join(rules, "\n\n")
+}
+
+// makeCachePolicyCacheDotConfigLines returns the cache.config lines of the
+// given cache policy of a Delivery Service with the given origin. These make
+// cache servers ignore the origin's no-cache directives, and revalidate
+// objects cached for longer than the policy's MaxTTL.
+func makeCachePolicyCacheDotConfigLines(policy tc.DeliveryServiceCachePolicy, originFQDN string, originPort string) []string {
+	dest := "dest_domain=" + originFQDN
+	if originPort != "" {
+		dest += " port=" + originPort
+	}
+	lines := []string{}
+	if policy.IgnoreOriginNoCache {
+		lines = append(lines, dest+" action=ignore-server-no-cache\n")
+	}
+	if policy.MaxTTL != nil {
+		lines = append(lines, dest+" revalidate="+strconv.Itoa(*policy.MaxTTL)+"s\n")
+	}
+	return lines
+}
@@ -0,0 +1,143 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestApplyCachePolicies(t *testing.T) {
+	dsType := tc.DSTypeHTTP
+	ds := DeliveryService{}
+	ds.ID = util.IntPtr(42)
+	ds.XMLID = util.StrPtr("myds")
+	ds.Type = &dsType
+	ds.MidHeaderRewrite = util.StrPtr(`set-header X-Foo bar`)
+
+	other := DeliveryService{}
+	other.ID = util.IntPtr(43)
+	other.XMLID = util.StrPtr("otherds")
+	other.Type = &dsType
+
+	policies := []tc.DeliveryServiceCachePolicy{
+		{
+			DeliveryServiceID: 42,
+			XMLID:             "myds",
+			DefaultTTLs: []tc.CachePolicyDefaultTTL{
+				{StatusCode: 404, TTL: 60},
+				{StatusCode: 200, TTL: 3600},
+			},
+			HonorVary: []string{"Accept-Encoding"},
+		},
+	}
+
+	dses, warnings := ApplyCachePolicies([]DeliveryService{ds, other}, policies)
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, actual: %+v", warnings)
+	}
+	if len(dses) != 2 {
+		t.Fatalf("expected 2 delivery services, actual: %d", len(dses))
+	}
+
+	expectedHdrRw := "cond %{READ_RESPONSE_HDR_HOOK} [AND]\n" +
+		"cond %{STATUS} =200 [AND]\n" +
+		`cond %{HEADER:Cache-Control} =""` + "\n" +
+		`set-header Cache-Control "max-age=3600"` + "\n" +
+		"\n" +
+		"cond %{READ_RESPONSE_HDR_HOOK} [AND]\n" +
+		"cond %{STATUS} =404 [AND]\n" +
+		`cond %{HEADER:Cache-Control} =""` + "\n" +
+		`set-header Cache-Control "max-age=60"` + "\n" +
+		"\n" +
+		"cond %{READ_RESPONSE_HDR_HOOK} [AND]\n" +
+		"cond %{HEADER:Vary} /./\n" +
+		"set-header @Origin-Vary %{HEADER:Vary}\n" +
+		"rm-header Vary\n" +
+		"\n" +
+		"cond %{READ_RESPONSE_HDR_HOOK} [AND]\n" +
+		`cond %{HEADER:@Origin-Vary} /(^|,)\s*Accept-Encoding\s*(,|$)/ [NOCASE]` + "\n" +
+		`add-header Vary "Accept-Encoding"` + "\n" +
+		"\n" +
+		"set-header X-Foo bar"
+	if dses[0].MidHeaderRewrite == nil || *dses[0].MidHeaderRewrite != expectedHdrRw {
+		t.Errorf("expected mid header rewrite '%s', actual: %+v", expectedHdrRw, dses[0].MidHeaderRewrite)
+	}
+	if dses[0].EdgeHeaderRewrite != nil {
+		t.Errorf("expected no edge header rewrite for a delivery service using mids, actual: %s", *dses[0].EdgeHeaderRewrite)
+	}
+
+	if *ds.MidHeaderRewrite != `set-header X-Foo bar` {
+		t.Error("expected the given delivery service not to be modified")
+	}
+	if dses[1].MidHeaderRewrite != nil || dses[1].EdgeHeaderRewrite != nil {
+		t.Errorf("expected delivery service without a policy to be unchanged, actual: %+v", dses[1])
+	}
+}
+
+func TestApplyCachePoliciesTopology(t *testing.T) {
+	ds := DeliveryService{}
+	ds.ID = util.IntPtr(42)
+	ds.XMLID = util.StrPtr("myds")
+	ds.Topology = util.StrPtr("mytopology")
+
+	policies := []tc.DeliveryServiceCachePolicy{
+		{
+			DeliveryServiceID: 42,
+			HonorVary:         []string{},
+		},
+	}
+
+	dses, warnings := ApplyCachePolicies([]DeliveryService{ds}, policies)
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, actual: %+v", warnings)
+	}
+	if dses[0].LastHeaderRewrite == nil || !strings.Contains(*dses[0].LastHeaderRewrite, "rm-header Vary") {
+		t.Errorf("expected last header rewrite to remove the Vary header, actual: %+v", dses[0].LastHeaderRewrite)
+	}
+	if strings.Contains(*dses[0].LastHeaderRewrite, "add-header Vary") {
+		t.Errorf("expected no Vary headers to be honored, actual: %s", *dses[0].LastHeaderRewrite)
+	}
+	if dses[0].EdgeHeaderRewrite != nil || dses[0].MidHeaderRewrite != nil {
+		t.Errorf("expected only the last header rewrite for a topology delivery service, actual: %+v", dses[0])
+	}
+}
+
+func TestApplyCachePoliciesNoRules(t *testing.T) {
+	ds := DeliveryService{}
+	ds.ID = util.IntPtr(42)
+	ds.XMLID = util.StrPtr("myds")
+
+	policies := []tc.DeliveryServiceCachePolicy{
+		{
+			DeliveryServiceID:   42,
+			IgnoreOriginNoCache: true,
+			MaxTTL:              util.IntPtr(60),
+		},
+	}
+
+	dses, _ := ApplyCachePolicies([]DeliveryService{ds}, policies)
+	if dses[0].EdgeHeaderRewrite != nil || dses[0].MidHeaderRewrite != nil || dses[0].LastHeaderRewrite != nil {
+		t.Errorf("expected a policy without default TTLs or honored Vary headers to leave header rewrites unchanged, actual: %+v", dses[0])
+	}
+}
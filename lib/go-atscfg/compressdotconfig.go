@@ -0,0 +1,160 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+const ContentTypeCompressDotConfig = ContentTypeTextASCII
+const LineCommentCompressDotConfig = LineCommentHash
+
+// CompressPrefix is the prefix of the names of the compress plugin config
+// files of Delivery Services.
+const CompressPrefix = "compress_"
+
+// DefaultCompressContentTypes are the MIME types of the responses which are
+// compressed for Delivery Services that don't give any.
+var DefaultCompressContentTypes = []string{
+	"text/*",
+	"application/javascript",
+	"application/json",
+	"application/xml",
+	"image/svg+xml",
+}
+
+// CompressConfigFileName returns the name of the compress plugin config file
+// of the Delivery Service with the given XMLID.
+func CompressConfigFileName(dsName string) string {
+	return CompressPrefix + dsName + ".config"
+}
+
+// CompressDotConfigOpts contains settings to configure generation options.
+type CompressDotConfigOpts struct {
+	// HdrComment is the header comment to include at the beginning of the file.
+	// This should be the text desired, without comment syntax (like # or //). The file's comment syntax will be added.
+	// To omit the header comment, pass the empty string.
+	HdrComment string
+}
+
+// MakeCompressDotConfig returns the config file of the ATS compress plugin,
+// named fileName, for the Delivery Service it names.
+func MakeCompressDotConfig(
+	fileName string,
+	server *Server,
+	deliveryServices []DeliveryService,
+	opt *CompressDotConfigOpts,
+) (Cfg, error) {
+	if opt == nil {
+		opt = &CompressDotConfigOpts{}
+	}
+	warnings := []string{}
+	if server.CDNName == nil {
+		return Cfg{}, makeErr(warnings, "server CDNName missing")
+	}
+
+	configSuffix := `.config`
+	if !strings.HasPrefix(fileName, CompressPrefix) || !strings.HasSuffix(fileName, configSuffix) {
+		return Cfg{}, makeErr(warnings, "file '"+fileName+"' not of the form 'compress_*.config! Please file a bug with Traffic Control, this should never happen")
+	}
+
+	dsName := strings.TrimSuffix(strings.TrimPrefix(fileName, CompressPrefix), configSuffix)
+	if dsName == "" {
+		return Cfg{}, makeErr(warnings, "file '"+fileName+"' has no delivery service name!")
+	}
+
+	ds := (*DeliveryService)(nil)
+	for i, dsesDS := range deliveryServices {
+		if dsesDS.XMLID != nil && *dsesDS.XMLID == dsName {
+			ds = &deliveryServices[i]
+			break
+		}
+	}
+	if ds == nil {
+		return Cfg{}, makeErr(warnings, "delivery service '"+dsName+"' not found! Do you have a compress_*.config location Parameter for a delivery service that doesn't exist?")
+	}
+
+	text := makeHdrComment(opt.HdrComment)
+	if !compressionEnabled(ds) {
+		warnings = append(warnings, "delivery service '"+dsName+"' doesn't compress responses, disabling compression")
+		text += "enabled false\n"
+	} else {
+		text += makeCompressConfigText(ds)
+	}
+
+	return Cfg{
+		Text:        text,
+		ContentType: ContentTypeCompressDotConfig,
+		LineComment: LineCommentCompressDotConfig,
+		Warnings:    warnings,
+	}, nil
+}
+
+// makeCompressConfigText returns the compress plugin settings of the given
+// Delivery Service, which must compress responses.
+//
+// Compressed responses are cached, and the client's Accept-Encoding is
+// removed from requests to parents and origins, so that the uncompressed
+// response is cached alongside each compressed variant.
+func makeCompressConfigText(ds *DeliveryService) string {
+	algorithms := []string{}
+	if ds.CompressGzip != nil && *ds.CompressGzip {
+		algorithms = append(algorithms, "gzip")
+	}
+	if ds.CompressBrotli != nil && *ds.CompressBrotli {
+		algorithms = append(algorithms, "br")
+	}
+
+	contentTypes := ds.CompressContentTypes
+	if len(contentTypes) == 0 {
+		contentTypes = DefaultCompressContentTypes
+	}
+
+	text := "enabled true\n"
+	text += "cache true\n"
+	text += "remove-accept-encoding true\n"
+	text += "supported-algorithms " + strings.Join(algorithms, ",") + "\n"
+	for _, contentType := range contentTypes {
+		text += "compressible-content-type " + contentType + "\n"
+	}
+	if ds.CompressMinSize != nil {
+		text += "minimum-content-length " + strconv.Itoa(*ds.CompressMinSize) + "\n"
+	}
+	return text
+}
+
+// compressionEnabled returns whether or not edge-tier cache servers compress
+// responses for the given Delivery Service.
+func compressionEnabled(ds *DeliveryService) bool {
+	return tc.DeliveryServiceV40(*ds).CompressionEnabled()
+}
+
+// compressRemapTxt returns the remap.config plugin text compressing the
+// responses of the given Delivery Service, which is empty if it doesn't
+// compress them.
+func compressRemapTxt(ds *DeliveryService) string {
+	if !compressionEnabled(ds) {
+		return ""
+	}
+	return ` @plugin=compress.so @pparam=` + CompressConfigFileName(*ds.XMLID)
+}
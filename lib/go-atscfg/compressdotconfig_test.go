@@ -0,0 +1,107 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestMakeCompressDotConfig(t *testing.T) {
+	hdr := "myHeaderComment"
+
+	server := makeGenericServer()
+	server.CDNName = util.StrPtr("mycdn")
+
+	ds := makeGenericDS()
+	ds.XMLID = util.StrPtr("myds")
+	ds.CompressGzip = util.BoolPtr(true)
+	ds.CompressBrotli = util.BoolPtr(true)
+	ds.CompressContentTypes = []string{"text/*", "application/json"}
+	ds.CompressMinSize = util.IntPtr(860)
+
+	cfg, err := MakeCompressDotConfig("compress_myds.config", server, []DeliveryService{*ds}, &CompressDotConfigOpts{HdrComment: hdr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txt := cfg.Text
+
+	testComment(t, txt, hdr)
+
+	for _, line := range []string{
+		"enabled true",
+		"supported-algorithms gzip,br",
+		"compressible-content-type text/*",
+		"compressible-content-type application/json",
+		"minimum-content-length 860",
+	} {
+		if !strings.Contains(txt, line+"\n") {
+			t.Errorf("expected line '%s', actual: '%s'", line, txt)
+		}
+	}
+	if strings.Contains(txt, "image/svg+xml") {
+		t.Errorf("expected only the Delivery Service's content types, actual: '%s'", txt)
+	}
+}
+
+func TestMakeCompressDotConfigDefaults(t *testing.T) {
+	server := makeGenericServer()
+	server.CDNName = util.StrPtr("mycdn")
+
+	ds := makeGenericDS()
+	ds.XMLID = util.StrPtr("myds")
+	ds.CompressGzip = util.BoolPtr(true)
+
+	cfg, err := MakeCompressDotConfig("compress_myds.config", server, []DeliveryService{*ds}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txt := cfg.Text
+
+	if !strings.Contains(txt, "supported-algorithms gzip\n") {
+		t.Errorf("expected only gzip, actual: '%s'", txt)
+	}
+	for _, contentType := range DefaultCompressContentTypes {
+		if !strings.Contains(txt, "compressible-content-type "+contentType+"\n") {
+			t.Errorf("expected default content type '%s', actual: '%s'", contentType, txt)
+		}
+	}
+	if strings.Contains(txt, "minimum-content-length") {
+		t.Errorf("expected the plugin's default minimum size, actual: '%s'", txt)
+	}
+
+	ds.CompressGzip = util.BoolPtr(false)
+	cfg, err = MakeCompressDotConfig("compress_myds.config", server, []DeliveryService{*ds}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(cfg.Text, "enabled false\n") {
+		t.Errorf("expected compression to be disabled for a Delivery Service that doesn't compress responses, actual: '%s'", cfg.Text)
+	}
+	if len(cfg.Warnings) == 0 {
+		t.Error("expected a warning for a Delivery Service that doesn't compress responses, actual: none")
+	}
+
+	if _, err := MakeCompressDotConfig("compress_nonexistent.config", server, []DeliveryService{*ds}, nil); err == nil {
+		t.Error("expected an error for a Delivery Service that doesn't exist, actual: nil")
+	}
+}
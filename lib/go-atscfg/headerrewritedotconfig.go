@@ -539,7 +539,8 @@ var returnRe = regexp.MustCompile(`\s*__RETURN__\s*`)
 // The headerRewriteTxt is the custom header rewrite from the Delivery Service. This should be used for any logic that depends on it. The various header rewrite fields (EdgeHeaderRewrite, InnerHeaderRewrite, etc should never be used inside this function, since this function doesn't know what tier the server is at. This function should not insert the headerRewriteText, but may use it to make decisions about what to insert.
 func makeATCHeaderRewriteDirectives(ds *DeliveryService, headerRewriteTxt *string, serverIsLastTier bool, numLastTierServers int, atsMajorVersion uint, atsRqstMaxHdrSize int) string {
 	return makeATCHeaderRewriteDirectiveMaxOriginConns(ds, headerRewriteTxt, serverIsLastTier, numLastTierServers, atsMajorVersion) +
-		makeATCHeaderRewriteDirectiveServiceCategoryHdr(ds, headerRewriteTxt) + makeATCHeaderRewriteDirectiveMaxRequestHeaderSize(ds, serverIsLastTier, atsRqstMaxHdrSize)
+		makeATCHeaderRewriteDirectiveServiceCategoryHdr(ds, headerRewriteTxt) + makeATCHeaderRewriteDirectiveMaxRequestHeaderSize(ds, serverIsLastTier, atsRqstMaxHdrSize) +
+		makeATCHeaderRewriteDirectiveTraceHeader(ds)
 }
 
 // makeATCHeaderRewriteDirectiveMaxOriginConns generates the Max Origin Connections header rewrite text, which may be empty.
@@ -603,3 +604,46 @@ func makeATCHeaderRewriteDirectiveMaxRequestHeaderSize(ds *DeliveryService, serv
 		return hdrTxt
 	}
 }
+
+// traceHeaderEnabled returns whether or not cache servers do anything with the
+// trace header of requests for the given Delivery Service.
+func traceHeaderEnabled(ds *DeliveryService) bool {
+	return tc.DeliveryServiceV40(*ds).TraceHeaderEnabled()
+}
+
+// makeATCHeaderRewriteDirectiveTraceHeader generates the header rewrite text
+// applying the Delivery Service's trace header policy, which may be empty.
+//
+// Every tier applies the policy, so a request given a new identifier by the
+// first tier keeps it all the way to the origin, and its identifier is
+// returned to the client by each tier on the way back.
+func makeATCHeaderRewriteDirectiveTraceHeader(ds *DeliveryService) string {
+	if !traceHeaderEnabled(ds) {
+		return ""
+	}
+	hdr := tc.DeliveryServiceV40(*ds).TraceHeader()
+	respond := `
+cond %{SEND_RESPONSE_HDR_HOOK}
+cond %{CLIENT-HEADER:` + hdr + `} ="" [NOT]
+set-header ` + hdr + ` %{CLIENT-HEADER:` + hdr + `}
+`
+	switch *ds.TraceHeaderPolicy {
+	case tc.TraceHeaderPolicyGenerate:
+		return `
+cond %{REMAP_PSEUDO_HOOK}
+cond %{CLIENT-HEADER:` + hdr + `} =""
+set-header ` + hdr + ` %{ID:UNIQUE}
+` + respond
+	case tc.TraceHeaderPolicyPropagate:
+		return respond
+	case tc.TraceHeaderPolicyStrip:
+		return `
+cond %{REMAP_PSEUDO_HOOK}
+rm-header ` + hdr + `
+
+cond %{SEND_RESPONSE_HDR_HOOK}
+rm-header ` + hdr + `
+`
+	}
+	return ""
+}
@@ -545,3 +545,67 @@ func makeHdrRwServerParams() []tc.Parameter {
 	}
 	return serverParams
 }
+
+func TestMakeHeaderRewriteDotConfigTraceHeader(t *testing.T) {
+	xmlID := "xml-id"
+	fileName := "hdr_rw_" + xmlID + ".config"
+	cdnName := "mycdn"
+
+	server := makeGenericServer()
+	server.CDNName = &cdnName
+	server.HostName = util.StrPtr("my-edge")
+	server.ID = util.IntPtr(990)
+	server.Status = util.StrPtr(string(tc.CacheStatusReported))
+
+	ds := makeGenericDS()
+	ds.ID = util.IntPtr(240)
+	ds.XMLID = &xmlID
+	ds.CDNName = &cdnName
+	dsType := tc.DSTypeHTTPLive
+	ds.Type = &dsType
+	ds.EdgeHeaderRewrite = nil
+	ds.MaxOriginConnections = nil
+	ds.ServiceCategory = nil
+	policy := tc.TraceHeaderPolicyGenerate
+	ds.TraceHeaderPolicy = &policy
+	ds.TraceHeaderName = util.StrPtr("X-Trace-Id")
+
+	servers := []Server{*server}
+	dses := []DeliveryService{*ds}
+	dss := makeDSS(servers, dses)
+	serverParams := makeHdrRwServerParams()
+
+	cfg, err := MakeHeaderRewriteDotConfig(fileName, dses, dss, server, servers, nil, serverParams, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error expected nil, actual '%v'", err)
+	}
+	txt := cfg.Text
+	if !strings.Contains(txt, "cond %{CLIENT-HEADER:X-Trace-Id} =\"\"\nset-header X-Trace-Id %{ID:UNIQUE}") {
+		t.Errorf("expected the trace header to be generated for requests without one, actual '%v'", txt)
+	}
+	if !strings.Contains(txt, "cond %{SEND_RESPONSE_HDR_HOOK}\ncond %{CLIENT-HEADER:X-Trace-Id} =\"\" [NOT]\nset-header X-Trace-Id %{CLIENT-HEADER:X-Trace-Id}") {
+		t.Errorf("expected the trace header to be returned to the client, actual '%v'", txt)
+	}
+
+	policy = tc.TraceHeaderPolicyPropagate
+	cfg, err = MakeHeaderRewriteDotConfig(fileName, dses, dss, server, servers, nil, serverParams, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error expected nil, actual '%v'", err)
+	}
+	if txt = cfg.Text; strings.Contains(txt, "%{ID:UNIQUE}") {
+		t.Errorf("expected the trace header not to be generated when propagated, actual '%v'", txt)
+	} else if !strings.Contains(txt, "set-header X-Trace-Id %{CLIENT-HEADER:X-Trace-Id}") {
+		t.Errorf("expected the propagated trace header to be returned to the client, actual '%v'", txt)
+	}
+
+	policy = tc.TraceHeaderPolicyStrip
+	ds.TraceHeaderName = nil
+	dses = []DeliveryService{*ds}
+	cfg, err = MakeHeaderRewriteDotConfig(fileName, dses, dss, server, servers, nil, serverParams, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("error expected nil, actual '%v'", err)
+	}
+	if txt = cfg.Text; strings.Count(txt, "rm-header "+tc.DefaultTraceHeaderName+"\n") != 2 {
+		t.Errorf("expected the default trace header to be removed from requests and responses, actual '%v'", txt)
+	}
+}
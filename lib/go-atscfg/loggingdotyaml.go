@@ -45,6 +45,10 @@ type LoggingDotYAMLOpts struct {
 	// This was the old Traffic Control behavior, before the version was specifiable externally.
 	//
 	ATSMajorVersion uint
+
+	// LogShipping is whether the server ships the access logs of any Delivery
+	// Service, in which case the log read by t3c-ship-logs is added.
+	LogShipping bool
 }
 
 func MakeLoggingDotYAML(
@@ -100,6 +104,11 @@ func MakeLoggingDotYAML(
 			text += indentSpaces + "   format: '" + format + "'\n"
 		}
 	}
+	if opt.LogShipping {
+		// The format is double-quoted, so that YAML turns the "\t" separators into tabs.
+		text += indentSpaces + " - name: " + LogShippingLogFormatName + "\n"
+		text += indentSpaces + "   format: \"" + strings.Join(LogShippingLogFields, `\t`) + "\"\n"
+	}
 
 	text += indentSpaces + "filters:\n"
 	for i := 0; i < maxLogObjects; i++ {
@@ -171,6 +180,20 @@ func MakeLoggingDotYAML(
 		}
 	}
 
+	if opt.LogShipping {
+		if firstObject {
+			text += "\n" + indentSpaces + "logs:\n"
+		}
+		text += indentSpaces + " - mode: ascii\n"
+		text += indentSpaces + "   filename: " + LogShippingLogFormatName + "\n"
+		text += indentSpaces + "   format: " + LogShippingLogFormatName + "\n"
+		text += indentSpaces + "   rolling_enabled: 2\n"
+		text += indentSpaces + "   rolling_size_mb: 256\n"
+		if atsMajorVersion >= 9 {
+			text += indentSpaces + "   rolling_max_count: 1\n"
+		}
+	}
+
 	return Cfg{
 		Text:        text,
 		ContentType: ContentTypeLoggingDotYAML,
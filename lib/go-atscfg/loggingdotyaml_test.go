@@ -172,3 +172,39 @@ func TestMakeLoggingDotYAMLMultiFormat(t *testing.T) {
 		}
 	}
 }
+
+func TestMakeLoggingDotYAMLLogShipping(t *testing.T) {
+	server := makeGenericServer()
+	server.ProfileNames = []string{"myProfile"}
+
+	cfg, err := MakeLoggingDotYAML(server, nil, &LoggingDotYAMLOpts{ATSMajorVersion: 9, LogShipping: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed := struct {
+		Logging struct {
+			Formats []struct {
+				Name   string `yaml:"name"`
+				Format string `yaml:"format"`
+			} `yaml:"formats"`
+			Logs []struct {
+				Filename string `yaml:"filename"`
+				Format   string `yaml:"format"`
+			} `yaml:"logs"`
+		} `yaml:"logging"`
+	}{}
+	if err := yaml.Unmarshal([]byte(cfg.Text), &parsed); err != nil {
+		t.Fatalf("expected valid YAML, got error '%v' parsing: %s", err, cfg.Text)
+	}
+
+	if len(parsed.Logging.Formats) != 1 || parsed.Logging.Formats[0].Name != LogShippingLogFormatName {
+		t.Fatalf("expected only the log shipping format, actual: %+v", parsed.Logging.Formats)
+	}
+	if fields := strings.Split(parsed.Logging.Formats[0].Format, "\t"); len(fields) != len(LogShippingLogFields) {
+		t.Errorf("expected %d tab-separated log shipping fields, actual: %q", len(LogShippingLogFields), parsed.Logging.Formats[0].Format)
+	}
+	if len(parsed.Logging.Logs) != 1 || parsed.Logging.Logs[0].Filename != LogShippingLogFormatName || parsed.Logging.Logs[0].Format != LogShippingLogFormatName {
+		t.Errorf("expected only the log shipping log, actual: %+v", parsed.Logging.Logs)
+	}
+}
@@ -0,0 +1,206 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// LogShippingConfigFileName is the name of the configuration file of
+// t3c-ship-logs, which ships the access logs of Delivery Services to their
+// tenants.
+const LogShippingConfigFileName = "log_shipping.json"
+
+const ContentTypeLogShippingConfig = "application/json"
+
+// LineCommentLogShippingConfig is empty, because JSON has no comments.
+const LineCommentLogShippingConfig = ""
+
+// LogShippingLogFormatName is the name of the ATS log format of the access log
+// from which t3c-ship-logs ships logs.
+const LogShippingLogFormatName = "tc_log_shipping"
+
+// LogShippingLogFileName is the name of the access log, in the ATS log
+// directory, from which t3c-ship-logs ships logs.
+const LogShippingLogFileName = LogShippingLogFormatName + ".log"
+
+// LogShippingLogFields are the ATS log fields of the access log from which
+// t3c-ship-logs ships logs, in order. They're separated by tabs. The free-text
+// header fields come last.
+var LogShippingLogFields = []string{
+	"%<cqtq>",            // client request time, in fractional seconds since the epoch
+	"%<ttms>",            // time taken, in milliseconds
+	"%<chi>",             // client IP address
+	"%<cqhm>",            // request method
+	"%<cquuc>",           // request URL, before remapping
+	"%<{Host}cqh>",       // request Host header
+	"%<pssc>",            // response status code
+	"%<pscl>",            // response body length
+	"%<crc>",             // cache result code
+	"%<{User-Agent}cqh>", // request User-Agent header
+	"%<{Referer}cqh>",    // request Referer header
+}
+
+// LogShippingConfig is the configuration of t3c-ship-logs.
+type LogShippingConfig struct {
+	// LogFile is the name of the access log, in the ATS log directory, from
+	// which logs are shipped.
+	LogFile string `json:"logFile"`
+	// DeliveryServices are the Delivery Services whose logs are shipped by
+	// the server.
+	DeliveryServices []LogShippingDeliveryService `json:"deliveryServices"`
+}
+
+// LogShippingDeliveryService is the log shipping configuration of a Delivery
+// Service, along with the regular expressions matching the hosts of its
+// requests.
+type LogShippingDeliveryService struct {
+	tc.DeliveryServiceLogShipping
+	// HostRegexes are the HOST_REGEXP regular expressions of the Delivery
+	// Service, which match the Host headers of its requests.
+	HostRegexes []string `json:"hostRegexes"`
+}
+
+// LogShippingConfigOpts contains settings to configure generation options.
+type LogShippingConfigOpts struct {
+	// HdrComment is ignored, because JSON has no comments. It exists for
+	// consistency with the options of other config files.
+	HdrComment string
+}
+
+// MakeLogShippingConfig returns the configuration of t3c-ship-logs for the
+// given server, which ships the logs of the Delivery Services with log
+// shipping for which it's the first cache tier.
+//
+// The config is generated for every server, so a server whose Delivery
+// Services stop shipping logs gets a config that ships nothing.
+func MakeLogShippingConfig(
+	server *Server,
+	deliveryServices []DeliveryService,
+	deliveryServiceServers []DeliveryServiceServer,
+	dsRegexes []tc.DeliveryServiceRegexes,
+	cacheGroupArr []tc.CacheGroupNullable,
+	topologies []tc.Topology,
+	logShipping []tc.DeliveryServiceLogShipping,
+	opt *LogShippingConfigOpts,
+) (Cfg, error) {
+	if opt == nil {
+		opt = &LogShippingConfigOpts{}
+	}
+	warnings := []string{}
+
+	shipped, shippedWarns, err := GetLogShippingDeliveryServices(server, deliveryServices, deliveryServiceServers, cacheGroupArr, topologies, logShipping)
+	warnings = append(warnings, shippedWarns...)
+	if err != nil {
+		return Cfg{}, makeErr(warnings, err.Error())
+	}
+
+	hostRegexes := map[string][]string{}
+	for _, dsRegexes := range dsRegexes {
+		for _, regex := range dsRegexes.Regexes {
+			if regex.Type == string(tc.DSMatchTypeHostRegex) {
+				hostRegexes[dsRegexes.DSName] = append(hostRegexes[dsRegexes.DSName], regex.Pattern)
+			}
+		}
+	}
+
+	cfg := LogShippingConfig{
+		LogFile:          LogShippingLogFileName,
+		DeliveryServices: []LogShippingDeliveryService{},
+	}
+	for _, shipping := range shipped {
+		regexes := hostRegexes[shipping.XMLID]
+		if len(regexes) == 0 {
+			warnings = append(warnings, "delivery service '"+shipping.XMLID+"' has log shipping but no host regexes, not shipping its logs!")
+			continue
+		}
+		sort.Strings(regexes)
+		cfg.DeliveryServices = append(cfg.DeliveryServices, LogShippingDeliveryService{DeliveryServiceLogShipping: shipping, HostRegexes: regexes})
+	}
+
+	bts, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return Cfg{}, makeErr(warnings, "encoding log shipping config: "+err.Error())
+	}
+
+	return Cfg{
+		Text:        string(bts) + "\n",
+		ContentType: ContentTypeLogShippingConfig,
+		LineComment: LineCommentLogShippingConfig,
+		Secure:      true,
+		Warnings:    warnings,
+	}, nil
+}
+
+// GetLogShippingDeliveryServices returns the log shipping of the Delivery
+// Services for which the given server is the first cache tier - those whose
+// client requests it logs - sorted by XMLID, along with any warnings.
+func GetLogShippingDeliveryServices(
+	server *Server,
+	deliveryServices []DeliveryService,
+	deliveryServiceServers []DeliveryServiceServer,
+	cacheGroupArr []tc.CacheGroupNullable,
+	topologies []tc.Topology,
+	logShipping []tc.DeliveryServiceLogShipping,
+) ([]tc.DeliveryServiceLogShipping, []string, error) {
+	warnings := []string{}
+	if len(logShipping) == 0 {
+		return []tc.DeliveryServiceLogShipping{}, warnings, nil
+	}
+	if server.Cachegroup == nil {
+		return nil, warnings, errors.New("server missing Cachegroup")
+	}
+
+	cacheGroups, err := makeCGMap(cacheGroupArr)
+	if err != nil {
+		return nil, warnings, errors.New("making CG map: " + err.Error())
+	}
+	nameTopologies := makeTopologyNameMap(topologies)
+
+	dses, dsWarns := filterConfigFileDSes(server, deliveryServices, deliveryServiceServers)
+	warnings = append(warnings, dsWarns...)
+
+	shipped := []tc.DeliveryServiceLogShipping{}
+	for _, shipping := range logShipping {
+		ds, ok := dses[tc.DeliveryServiceName(shipping.XMLID)]
+		if !ok {
+			continue
+		}
+		if ds.Topology != nil && *ds.Topology != "" {
+			placement, err := getTopologyPlacement(tc.CacheGroupName(*server.Cachegroup), nameTopologies[TopologyName(*ds.Topology)], cacheGroups, &ds)
+			if err != nil {
+				return nil, warnings, errors.New("getting topology placement: " + err.Error())
+			}
+			if !placement.InTopology || !placement.IsFirstCacheTier {
+				continue
+			}
+		} else if !strings.HasPrefix(server.Type, tc.EdgeTypePrefix) {
+			continue
+		}
+		shipped = append(shipped, shipping)
+	}
+	sort.Slice(shipped, func(i, j int) bool { return shipped[i].XMLID < shipped[j].XMLID })
+	return shipped, warnings, nil
+}
@@ -0,0 +1,96 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestMakeLogShippingConfig(t *testing.T) {
+	server := makeGenericServer()
+
+	ds0 := makeGenericDS()
+	ds1 := makeGenericDS()
+	ds1.ID = util.IntPtr(43)
+	ds1.XMLID = util.StrPtr("ds2")
+	unassigned := makeGenericDS()
+	unassigned.ID = util.IntPtr(44)
+	unassigned.XMLID = util.StrPtr("unassigned")
+
+	dses := []DeliveryService{*ds0, *ds1, *unassigned}
+	dss := makeDSS([]Server{*server}, []DeliveryService{*ds0, *ds1})
+	dsRegexes := []tc.DeliveryServiceRegexes{
+		{DSName: "ds1", Regexes: []tc.DeliveryServiceRegex{
+			{Type: string(tc.DSMatchTypeHostRegex), Pattern: `.*\.ds1\..*`},
+			{Type: string(tc.DSMatchTypePathRegex), Pattern: `/path`},
+		}},
+		{DSName: "unassigned", Regexes: []tc.DeliveryServiceRegex{{Type: string(tc.DSMatchTypeHostRegex), Pattern: `.*\.unassigned\..*`}}},
+	}
+	logShipping := []tc.DeliveryServiceLogShipping{
+		{XMLID: "unassigned", DestinationType: tc.LogShippingDestinationHTTPS, Destination: "https://logs.example.com/unassigned"},
+		{XMLID: "ds2", DestinationType: tc.LogShippingDestinationHTTPS, Destination: "https://logs.example.com/ds2"},
+		{XMLID: "ds1", DestinationType: tc.LogShippingDestinationHTTPS, Destination: "https://logs.example.com/ds1", Credentials: util.StrPtr("Bearer token")},
+	}
+
+	cfg, err := MakeLogShippingConfig(server, dses, dss, dsRegexes, nil, nil, logShipping, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Secure {
+		t.Error("expected the log shipping config to be secure, because it contains credentials")
+	}
+
+	parsed := LogShippingConfig{}
+	if err := json.Unmarshal([]byte(cfg.Text), &parsed); err != nil {
+		t.Fatalf("expected valid JSON, got error '%v' parsing: %s", err, cfg.Text)
+	}
+	if parsed.LogFile != LogShippingLogFileName {
+		t.Errorf("expected log file '%s', actual: '%s'", LogShippingLogFileName, parsed.LogFile)
+	}
+	if len(parsed.DeliveryServices) != 1 {
+		t.Fatalf("expected only the log shipping of assigned 'ds1', which has host regexes, actual: %+v", parsed.DeliveryServices)
+	}
+	shipped := parsed.DeliveryServices[0]
+	if shipped.XMLID != "ds1" || shipped.Credentials == nil || *shipped.Credentials != "Bearer token" {
+		t.Errorf("expected log shipping of 'ds1' with its credentials, actual: %+v", shipped)
+	}
+	if len(shipped.HostRegexes) != 1 || shipped.HostRegexes[0] != `.*\.ds1\..*` {
+		t.Errorf("expected only the host regex of 'ds1', actual: %v", shipped.HostRegexes)
+	}
+	if len(cfg.Warnings) != 1 {
+		t.Errorf("expected a warning about 'ds2' having no host regexes, actual: %v", cfg.Warnings)
+	}
+
+	server.Type = "MID"
+	cfg, err = MakeLogShippingConfig(server, dses, dss, dsRegexes, nil, nil, logShipping, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(cfg.Text), &parsed); err != nil {
+		t.Fatalf("expected valid JSON, got error '%v' parsing: %s", err, cfg.Text)
+	}
+	if len(parsed.DeliveryServices) != 0 {
+		t.Errorf("expected mids to ship no logs, actual: %+v", parsed.DeliveryServices)
+	}
+}
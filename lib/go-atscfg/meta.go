@@ -111,6 +111,7 @@ locationParamsFor:
 				"hdr_rw_mid_", // must come before hdr_rw_, to avoid thinking we have a "hdr_rw_" with a ds of "mid_x"
 				"hdr_rw_",
 				"regex_remap_",
+				CompressPrefix,
 				"url_sig_",
 				"uri_signing_",
 			}
@@ -205,6 +206,11 @@ func addMetaObjConfigDir(
 		configFilesM[fileName] = newFis
 	}
 
+	// The log shipping config is generated for every server, so that one whose Delivery Services stop shipping logs stops shipping them.
+	if configFilesM, err = ensureConfigFile(configFilesM, LogShippingConfigFileName, configDir); err != nil {
+		warnings = append(warnings, "ensuring config file '"+LogShippingConfigFileName+"': "+err.Error())
+	}
+
 	nameTopologies := makeTopologyNameMap(topologies)
 
 	for _, ds := range dses {
@@ -224,7 +230,7 @@ func addMetaObjConfigDir(
 				return nil, warnings, errors.New("getting topology placement: " + err.Error())
 			}
 			if placement.IsFirstCacheTier {
-				if (ds.FirstHeaderRewrite != nil && *ds.FirstHeaderRewrite != "") || ds.MaxOriginConnections != nil || ds.ServiceCategory != nil {
+				if (ds.FirstHeaderRewrite != nil && *ds.FirstHeaderRewrite != "") || ds.MaxOriginConnections != nil || ds.ServiceCategory != nil || traceHeaderEnabled(&ds) {
 					fileName := FirstHeaderRewriteConfigFileName(*ds.XMLID)
 					if configFilesM, err = ensureConfigFile(configFilesM, fileName, configDir); err != nil {
 						warnings = append(warnings, "ensuring config file '"+fileName+"': "+err.Error())
@@ -232,7 +238,7 @@ func addMetaObjConfigDir(
 				}
 			}
 			if placement.IsInnerCacheTier {
-				if (ds.InnerHeaderRewrite != nil && *ds.InnerHeaderRewrite != "") || ds.MaxOriginConnections != nil || ds.ServiceCategory != nil {
+				if (ds.InnerHeaderRewrite != nil && *ds.InnerHeaderRewrite != "") || ds.MaxOriginConnections != nil || ds.ServiceCategory != nil || traceHeaderEnabled(&ds) {
 					fileName := InnerHeaderRewriteConfigFileName(*ds.XMLID)
 					if configFilesM, err = ensureConfigFile(configFilesM, fileName, configDir); err != nil {
 						warnings = append(warnings, "ensuring config file '"+fileName+"': "+err.Error())
@@ -240,7 +246,7 @@ func addMetaObjConfigDir(
 				}
 			}
 			if placement.IsLastCacheTier {
-				if (ds.LastHeaderRewrite != nil && *ds.LastHeaderRewrite != "") || ds.MaxOriginConnections != nil || ds.ServiceCategory != nil {
+				if (ds.LastHeaderRewrite != nil && *ds.LastHeaderRewrite != "") || ds.MaxOriginConnections != nil || ds.ServiceCategory != nil || traceHeaderEnabled(&ds) {
 					fileName := LastHeaderRewriteConfigFileName(*ds.XMLID)
 					if configFilesM, err = ensureConfigFile(configFilesM, fileName, configDir); err != nil {
 						warnings = append(warnings, "ensuring config file '"+fileName+"': "+err.Error())
@@ -248,7 +254,7 @@ func addMetaObjConfigDir(
 				}
 			}
 		} else if strings.HasPrefix(server.Type, tc.EdgeTypePrefix) {
-			if (ds.EdgeHeaderRewrite != nil || ds.MaxOriginConnections != nil || ds.ServiceCategory != nil) &&
+			if (ds.EdgeHeaderRewrite != nil || ds.MaxOriginConnections != nil || ds.ServiceCategory != nil || traceHeaderEnabled(&ds)) &&
 				strings.HasPrefix(server.Type, tc.EdgeTypePrefix) {
 				fileName := "hdr_rw_" + *ds.XMLID + ".config"
 				if configFilesM, err = ensureConfigFile(configFilesM, fileName, configDir); err != nil {
@@ -256,7 +262,7 @@ func addMetaObjConfigDir(
 				}
 			}
 		} else if strings.HasPrefix(server.Type, tc.MidTypePrefix) {
-			if (ds.MidHeaderRewrite != nil || ds.MaxOriginConnections != nil || ds.ServiceCategory != nil) &&
+			if (ds.MidHeaderRewrite != nil || ds.MaxOriginConnections != nil || ds.ServiceCategory != nil || traceHeaderEnabled(&ds)) &&
 				ds.Type != nil && ds.Type.UsesMidCache() &&
 				strings.HasPrefix(server.Type, tc.MidTypePrefix) {
 				fileName := "hdr_rw_mid_" + *ds.XMLID + ".config"
@@ -265,6 +271,12 @@ func addMetaObjConfigDir(
 				}
 			}
 		}
+		if compressionEnabled(&ds) && strings.HasPrefix(server.Type, tc.EdgeTypePrefix) {
+			configFile := CompressConfigFileName(*ds.XMLID)
+			if configFilesM, err = ensureConfigFile(configFilesM, configFile, configDir); err != nil {
+				warnings = append(warnings, "ensuring config file '"+configFile+"': "+err.Error())
+			}
+		}
 		if ds.RegexRemap != nil {
 			configFile := "regex_remap_" + *ds.XMLID + ".config"
 			if configFilesM, err = ensureConfigFile(configFilesM, configFile, configDir); err != nil {
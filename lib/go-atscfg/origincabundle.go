@@ -0,0 +1,165 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// OriginCABundleDirName is the name of the directory, relative to the ATS
+// config directory, in which origin CA bundle files are placed.
+const OriginCABundleDirName = "ssl"
+
+// OriginCABundleFileName returns the name of the file holding the CA
+// certificates used to verify the TLS certificate of the origin of the
+// Delivery Service with the given XMLID.
+func OriginCABundleFileName(dsName string) string {
+	return "origin_ca_" + dsName + ".pem"
+}
+
+// OriginCABundle is the CA bundle file used to verify the TLS certificate of
+// a Delivery Service's origin.
+type OriginCABundle struct {
+	DeliveryService tc.DeliveryServiceName
+	FileName        string
+	Text            string
+}
+
+// MakeOriginCABundles returns the CA bundle files of the given Delivery
+// Services that verify the TLS certificates of their origins with a CA bundle,
+// sorted by file name, and any warnings.
+//
+// The CA bundles are the Values of the globalParams with the ConfigFile
+// tc.OriginCABundleConfigFile and the Name referenced by each Delivery Service.
+// If a Delivery Service has pinned SPKI hashes, its file only contains the
+// certificates of its bundle with one of those public keys.
+func MakeOriginCABundles(dses []DeliveryService, globalParams []tc.Parameter) ([]OriginCABundle, []string) {
+	warnings := []string{}
+
+	bundles := map[string]string{}
+	for _, param := range globalParams {
+		if param.ConfigFile != string(tc.OriginCABundleConfigFile) {
+			continue
+		}
+		bundles[param.Name] = param.Value
+	}
+
+	files := []OriginCABundle{}
+	for _, ds := range dses {
+		if ds.XMLID == nil || !originTLSVerifyEnabled(&ds) || ds.OriginTLSCABundle == nil || *ds.OriginTLSCABundle == "" {
+			continue
+		}
+		bundle, ok := bundles[*ds.OriginTLSCABundle]
+		if !ok {
+			warnings = append(warnings, "delivery service '"+*ds.XMLID+"' references origin CA bundle '"+*ds.OriginTLSCABundle+"' which doesn't exist, skipping!")
+			continue
+		}
+		txt, err := filterCABundle(bundle, ds.OriginTLSPinnedSPKIHashes)
+		if err != nil {
+			warnings = append(warnings, "delivery service '"+*ds.XMLID+"' origin CA bundle '"+*ds.OriginTLSCABundle+"': "+err.Error()+", skipping!")
+			continue
+		}
+		files = append(files, OriginCABundle{
+			DeliveryService: tc.DeliveryServiceName(*ds.XMLID),
+			FileName:        OriginCABundleFileName(*ds.XMLID),
+			Text:            txt,
+		})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].FileName < files[j].FileName })
+	return files, warnings
+}
+
+// filterCABundle returns the PEM-encoded certificates of the given bundle,
+// restricted to those whose SPKI hash is one of pins if any are given. It
+// returns an error if the bundle can't be parsed or no certificate remains.
+func filterCABundle(bundle string, pins []string) (string, error) {
+	pinned := make(map[string]struct{}, len(pins))
+	for _, pin := range pins {
+		pinned[pin] = struct{}{}
+	}
+
+	txt := strings.Builder{}
+	rest := []byte(bundle)
+	for {
+		block, remaining := pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		rest = remaining
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return "", errors.New("parsing certificate: " + err.Error())
+		}
+		if len(pinned) > 0 {
+			hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+			if _, ok := pinned[base64.StdEncoding.EncodeToString(hash[:])]; !ok {
+				continue
+			}
+		}
+		txt.Write(pem.EncodeToMemory(block))
+	}
+	if txt.Len() == 0 {
+		if len(pinned) > 0 {
+			return "", errors.New("no certificate matches a pinned SPKI hash")
+		}
+		return "", errors.New("no certificates")
+	}
+	return txt.String(), nil
+}
+
+// originTLSVerifyEnabled returns whether cache servers verify the TLS
+// certificate of the origin of the given Delivery Service.
+func originTLSVerifyEnabled(ds *DeliveryService) bool {
+	return ds.OriginTLSVerify != nil && *ds.OriginTLSVerify
+}
+
+// originTLSVerifyRemapTxt returns the remap.config plugin text enforcing the
+// verification of the TLS certificate of the origin of the given Delivery
+// Service, which is being mapped to mapTo. This is empty if the Delivery
+// Service doesn't verify its origin, or mapTo isn't its HTTPS origin, e.g.
+// because the server isn't the last cache tier.
+//
+// Otherwise, this overrides the global
+// proxy.config.ssl.client.verify.server.* settings - which disable origin
+// verification by default - for the remap rule.
+func originTLSVerifyRemapTxt(ds *DeliveryService, mapTo string, configDir string) string {
+	if !originTLSVerifyEnabled(ds) || !strings.HasPrefix(mapTo, "https://") {
+		return ""
+	}
+	txt := ` @plugin=conf_remap.so` +
+		` @pparam=proxy.config.ssl.client.verify.server.policy=ENFORCED` +
+		` @pparam=proxy.config.ssl.client.verify.server.properties=ALL`
+	if ds.OriginTLSCABundle != nil && *ds.OriginTLSCABundle != "" {
+		txt += ` @pparam=proxy.config.ssl.client.CA.cert.filename=` + filepath.Join(configDir, OriginCABundleDirName, OriginCABundleFileName(*ds.XMLID))
+	}
+	return txt
+}
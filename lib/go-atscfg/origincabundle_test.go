@@ -0,0 +1,133 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// makeTestCACert returns a new PEM-encoded self-signed CA certificate with the
+// given common name, and the base64-encoded SHA-256 hash of its SPKI.
+func makeTestCACert(t *testing.T, commonName string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("parsing certificate: %v", err)
+	}
+	hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), base64.StdEncoding.EncodeToString(hash[:])
+}
+
+func TestMakeOriginCABundles(t *testing.T) {
+	cert0, pin0 := makeTestCACert(t, "ca0")
+	cert1, _ := makeTestCACert(t, "ca1")
+
+	globalParams := []tc.Parameter{
+		{
+			Name:       "mybundle",
+			ConfigFile: string(tc.OriginCABundleConfigFile),
+			Value:      cert0 + cert1,
+		},
+		{
+			Name:       "mybundle",
+			ConfigFile: "global",
+			Value:      "notabundle",
+		},
+	}
+
+	ds0 := DeliveryService{}
+	ds0.XMLID = util.StrPtr("ds0")
+	ds0.OriginTLSVerify = util.BoolPtr(true)
+	ds0.OriginTLSCABundle = util.StrPtr("mybundle")
+
+	ds1 := DeliveryService{}
+	ds1.XMLID = util.StrPtr("ds1")
+	ds1.OriginTLSVerify = util.BoolPtr(true)
+	ds1.OriginTLSCABundle = util.StrPtr("mybundle")
+	ds1.OriginTLSPinnedSPKIHashes = []string{pin0}
+
+	ds2 := DeliveryService{}
+	ds2.XMLID = util.StrPtr("ds2")
+	ds2.OriginTLSVerify = util.BoolPtr(false)
+	ds2.OriginTLSCABundle = util.StrPtr("mybundle")
+
+	ds3 := DeliveryService{}
+	ds3.XMLID = util.StrPtr("ds3")
+	ds3.OriginTLSVerify = util.BoolPtr(true)
+	ds3.OriginTLSCABundle = util.StrPtr("nonexistent")
+
+	ds4 := DeliveryService{}
+	ds4.XMLID = util.StrPtr("ds4")
+	ds4.OriginTLSVerify = util.BoolPtr(true)
+	ds4.OriginTLSCABundle = util.StrPtr("mybundle")
+	ds4.OriginTLSPinnedSPKIHashes = []string{"47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="}
+
+	files, warnings := MakeOriginCABundles([]DeliveryService{ds4, ds3, ds2, ds1, ds0}, globalParams)
+	if len(warnings) != 2 {
+		t.Errorf("expected warnings for the nonexistent bundle and the bundle without pinned certificates, actual: %v", warnings)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected bundles for the 2 verifying Delivery Services with valid bundles, actual: %+v", files)
+	}
+
+	if files[0].FileName != "origin_ca_ds0.pem" {
+		t.Errorf("expected first file 'origin_ca_ds0.pem', actual '%s'", files[0].FileName)
+	}
+	if !strings.Contains(files[0].Text, cert0) || !strings.Contains(files[0].Text, cert1) {
+		t.Errorf("expected unpinned bundle to contain all certificates, actual '%s'", files[0].Text)
+	}
+
+	if files[1].FileName != "origin_ca_ds1.pem" {
+		t.Errorf("expected second file 'origin_ca_ds1.pem', actual '%s'", files[1].FileName)
+	}
+	if files[1].Text != cert0 {
+		t.Errorf("expected pinned bundle to contain only the pinned certificate, actual '%s'", files[1].Text)
+	}
+}
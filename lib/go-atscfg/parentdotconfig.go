@@ -120,6 +120,7 @@ func MakeParentDotConfig(
 	dsRequiredCapabilities map[int]map[ServerCapability]struct{},
 	cacheGroupArr []tc.CacheGroupNullable,
 	dss []DeliveryServiceServer,
+	dsPathRules []tc.DeliveryServicePathRules,
 	cdn *tc.CDN,
 	opt *ParentConfigOpts,
 ) (Cfg, error) {
@@ -137,6 +138,7 @@ func MakeParentDotConfig(
 		dsRequiredCapabilities,
 		cacheGroupArr,
 		dss,
+		dsPathRules,
 		cdn,
 		opt,
 		atsMajorVersion,
@@ -176,6 +178,7 @@ func makeParentDotConfigData(
 	dsRequiredCapabilities map[int]map[ServerCapability]struct{},
 	cacheGroupArr []tc.CacheGroupNullable,
 	dss []DeliveryServiceServer,
+	dsPathRules []tc.DeliveryServicePathRules,
 	cdn *tc.CDN,
 	opt *ParentConfigOpts,
 	atsMajorVersion uint,
@@ -327,6 +330,13 @@ func makeParentDotConfigData(
 		peer.Weight = 0.999
 		parentAbstraction.Peers = append(parentAbstraction.Peers, peer)
 	}
+	// cgPeers is a map, so the peers must be sorted for the config to be deterministic
+	sort.Slice(parentAbstraction.Peers, func(i, j int) bool {
+		if parentAbstraction.Peers[i].FQDN != parentAbstraction.Peers[j].FQDN {
+			return parentAbstraction.Peers[i].FQDN < parentAbstraction.Peers[j].FQDN
+		}
+		return parentAbstraction.Peers[i].Port < parentAbstraction.Peers[j].Port
+	})
 
 	cgServerIDs := map[int]struct{}{}
 	for serverID, _ := range cgServers {
@@ -355,7 +365,7 @@ func makeParentDotConfigData(
 	dsOrigins, dsOriginWarns := makeDSOrigins(dss, dses, servers)
 	warnings = append(warnings, dsOriginWarns...)
 
-	for _, ds := range dses {
+	for _, ds := range withPathRuleOrigins(dses, makeDSPathRuleMap(dsPathRules)) {
 
 		if ds.XMLID == nil || *ds.XMLID == "" {
 			warnings = append(warnings, "got ds with missing XMLID, skipping!")
@@ -704,6 +714,7 @@ type parentInfo struct {
 	PrimaryParent   bool
 	SecondaryParent bool
 	Capabilities    map[ServerCapability]struct{}
+	Interfaces      []tc.ServerInterfaceInfoV40
 }
 
 func (p parentInfo) Format() string {
@@ -730,6 +741,20 @@ func (p parentInfo) ToAbstract() *ParentAbstractionServiceParent {
 	}
 }
 
+// toAbstractForDS is like ToAbstract, but addresses the parent by the IPv4
+// address of the first of the Delivery Service's routing interfaces which has
+// one, if any does.
+func (p parentInfo) toAbstractForDS(ds *DeliveryService) *ParentAbstractionServiceParent {
+	abstract := p.ToAbstract()
+	if ds == nil {
+		return abstract
+	}
+	if ip, _ := tc.GetPreferredAddresses(p.Interfaces, ds.RoutingInterfaces); ip != "" {
+		abstract.FQDN = ip
+	}
+	return abstract
+}
+
 type parentInfos map[OriginHost]parentInfo
 
 type parentInfoSortByRank []parentInfo
@@ -1346,12 +1371,17 @@ func serverParentageParams(sv *Server, allParentConfigParams []parameterWithProf
 	return parentServerParams, warnings
 }
 
-func serverParentStr(sv *Server, svParams parentServerParams) (*ParentAbstractionServiceParent, error) {
+// serverParentStr returns the server as a parent of the given Delivery Service,
+// or nil if it's not a parent. It's addressed by the IPv4 address of the first
+// of the Delivery Service's routing interfaces which has one, if any does.
+func serverParentStr(sv *Server, svParams parentServerParams, ds *DeliveryService) (*ParentAbstractionServiceParent, error) {
 	if svParams.NotAParent {
 		return nil, nil
 	}
 	host := ""
-	if svParams.UseIP {
+	if ip, _ := tc.GetPreferredAddresses(sv.Interfaces, ds.RoutingInterfaces); ip != "" {
+		host = ip
+	} else if svParams.UseIP {
 		// TODO get service interface here
 		ip := getServerIPAddress(sv)
 		if ip == nil {
@@ -1481,7 +1511,7 @@ func getTopologyParents(
 			continue
 		}
 		if *sv.Cachegroup == parentCG {
-			parentStr, err := serverParentStr(&sv.Server, sv.Params)
+			parentStr, err := serverParentStr(&sv.Server, sv.Params, ds)
 			if err != nil {
 				return nil, nil, warnings, errors.New("getting server parent string: " + err.Error())
 			}
@@ -1490,7 +1520,7 @@ func getTopologyParents(
 			}
 		}
 		if *sv.Cachegroup == secondaryParentCG {
-			parentStr, err := serverParentStr(&sv.Server, sv.Params)
+			parentStr, err := serverParentStr(&sv.Server, sv.Params, ds)
 			if err != nil {
 				return nil, nil, warnings, errors.New("getting server parent string: " + err.Error())
 			}
@@ -1547,7 +1577,7 @@ func getParentStrs(
 			continue
 		}
 
-		pTxt := parent.ToAbstract()
+		pTxt := parent.toAbstractForDS(ds)
 		if parent.PrimaryParent {
 			parentInfo = append(parentInfo, pTxt)
 		} else if parent.SecondaryParent {
@@ -1606,11 +1636,11 @@ func getMSOParentStrs(
 	nullParentInfo := []*ParentAbstractionServiceParent{}
 	for _, parent := range ([]parentInfo)(rankedParents) {
 		if parent.PrimaryParent {
-			parentInfoTxt = append(parentInfoTxt, parent.ToAbstract())
+			parentInfoTxt = append(parentInfoTxt, parent.toAbstractForDS(ds))
 		} else if parent.SecondaryParent {
-			secondaryParentInfo = append(secondaryParentInfo, parent.ToAbstract())
+			secondaryParentInfo = append(secondaryParentInfo, parent.toAbstractForDS(ds))
 		} else {
-			nullParentInfo = append(nullParentInfo, parent.ToAbstract())
+			nullParentInfo = append(nullParentInfo, parent.toAbstractForDS(ds))
 		}
 	}
 
@@ -1702,6 +1732,7 @@ func makeParentInfo(
 				PrimaryParent:   serverParentCGData.ParentID == *sv.CachegroupID,
 				SecondaryParent: serverParentCGData.SecondaryParentID == *sv.CachegroupID,
 				Capabilities:    serverCapabilities[*sv.ID],
+				Interfaces:      sv.Interfaces,
 			}
 			if parentInf.Port < 1 {
 				parentInf.Port = *sv.TCPPort
@@ -126,7 +126,7 @@ func TestMakeParentDotConfig(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -248,7 +248,7 @@ func TestMakeParentDotConfigCapabilities(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -389,7 +389,7 @@ func TestMakeParentDotConfigMSOSecondaryParent(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -509,7 +509,7 @@ func TestMakeParentDotConfigMSONoPrimaryParent(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -638,7 +638,7 @@ func TestMakeParentDotConfigMSONoTopologyNoMid(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -777,7 +777,7 @@ func TestMakeParentDotConfigTopologies(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -800,6 +800,143 @@ func TestMakeParentDotConfigTopologies(t *testing.T) {
 	}
 }
 
+func TestMakeParentDotConfigRoutingInterfaces(t *testing.T) {
+	hdr := &ParentConfigOpts{AddComments: false, HdrComment: "myHeaderComment"}
+
+	ds0 := makeParentDS()
+	ds0Type := tc.DSTypeHTTP
+	ds0.Type = &ds0Type
+	ds0.OrgServerFQDN = util.StrPtr("http://ds0.example.net")
+	ds0.RoutingInterfaces = []string{"vlan100"}
+
+	ds1 := makeParentDS()
+	ds1.ID = util.IntPtr(43)
+	ds1.XMLID = util.StrPtr("ds1")
+	ds1.OrgServerFQDN = util.StrPtr("http://ds1.example.net")
+	ds1.Topology = util.StrPtr("t0")
+	ds1.RoutingInterfaces = []string{"vlan100"}
+
+	ds2 := makeParentDS()
+	ds2.ID = util.IntPtr(44)
+	ds2.XMLID = util.StrPtr("ds2")
+	ds2.OrgServerFQDN = util.StrPtr("http://ds2.example.net")
+	ds2.Topology = util.StrPtr("t0")
+
+	dses := []DeliveryService{*ds0, *ds1, *ds2}
+
+	parentConfigParams := []tc.Parameter{}
+	serverParams := []tc.Parameter{
+		tc.Parameter{
+			Name:       "trafficserver",
+			ConfigFile: "package",
+			Value:      "7",
+			Profiles:   []byte(`["global"]`),
+		},
+	}
+
+	server := makeTestParentServer()
+	server.Cachegroup = util.StrPtr("edgeCG")
+	server.CachegroupID = util.IntPtr(400)
+
+	mid0 := makeTestParentServer()
+	mid0.Cachegroup = util.StrPtr("midCG")
+	mid0.CachegroupID = util.IntPtr(500)
+	mid0.HostName = util.StrPtr("mymid")
+	mid0.ID = util.IntPtr(45)
+	mid0.Type = tc.MidTypePrefix
+	setIP(mid0, "192.168.2.2")
+	vlan := tc.ServerInterfaceInfoV40{}
+	vlan.Name = "vlan100"
+	vlan.IPAddresses = []tc.ServerIPAddress{{Address: "10.0.100.2/24"}}
+	mid0.Interfaces = append(mid0.Interfaces, vlan)
+
+	mid1 := makeTestParentServer()
+	mid1.Cachegroup = util.StrPtr("midCG")
+	mid1.CachegroupID = util.IntPtr(500)
+	mid1.HostName = util.StrPtr("mymid1")
+	mid1.ID = util.IntPtr(46)
+	mid1.Type = tc.MidTypePrefix
+	setIP(mid1, "192.168.2.3")
+
+	servers := []Server{*server, *mid0, *mid1}
+
+	topologies := []tc.Topology{
+		tc.Topology{
+			Name: "t0",
+			Nodes: []tc.TopologyNode{
+				tc.TopologyNode{
+					Cachegroup: "edgeCG",
+					Parents:    []int{1},
+				},
+				tc.TopologyNode{
+					Cachegroup: "midCG",
+				},
+			},
+		},
+	}
+
+	serverCapabilities := map[int]map[ServerCapability]struct{}{}
+	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
+
+	eCG := &tc.CacheGroupNullable{}
+	eCG.Name = server.Cachegroup
+	eCG.ID = server.CachegroupID
+	eCG.ParentName = mid0.Cachegroup
+	eCG.ParentCachegroupID = mid0.CachegroupID
+	eCGType := tc.CacheGroupEdgeTypeName
+	eCG.Type = &eCGType
+
+	mCG := &tc.CacheGroupNullable{}
+	mCG.Name = mid0.Cachegroup
+	mCG.ID = mid0.CachegroupID
+	mCGType := tc.CacheGroupMidTypeName
+	mCG.Type = &mCGType
+
+	cgs := []tc.CacheGroupNullable{*eCG, *mCG}
+
+	dss := []DeliveryServiceServer{
+		DeliveryServiceServer{
+			Server:          *server.ID,
+			DeliveryService: *ds0.ID,
+		},
+	}
+	cdn := &tc.CDN{
+		DomainName: "cdndomain.example",
+		Name:       "my-cdn-name",
+	}
+
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txt := cfg.Text
+
+	for _, dest := range []string{"ds0.example.net", "ds1.example.net"} {
+		line := parentLineWithDest(txt, dest)
+		if !strings.Contains(line, "10.0.100.2:80|") {
+			t.Errorf("expected parent line for '%s' to address mymid by its routing interface address, actual: '%v'", dest, line)
+		}
+		if !strings.Contains(line, "mymid1.mydomain.example.net:80|") {
+			t.Errorf("expected parent line for '%s' to address mymid1 without a routing interface by its FQDN, actual: '%v'", dest, line)
+		}
+	}
+	line := parentLineWithDest(txt, "ds2.example.net")
+	if !strings.Contains(line, "mymid.mydomain.example.net:80|") || strings.Contains(line, "10.0.100.2") {
+		t.Errorf("expected parent line for 'ds2.example.net' without routing interfaces to address mymid by its FQDN, actual: '%v'", line)
+	}
+}
+
+// parentLineWithDest returns the line of the parent.config text with the
+// given dest_domain, or an empty string if there is none.
+func parentLineWithDest(txt string, dest string) string {
+	for _, line := range strings.Split(txt, "\n") {
+		if strings.Contains(line, "dest_domain="+dest+" ") {
+			return line
+		}
+	}
+	return ""
+}
+
 // TestMakeParentDotConfigNotInTopologies tests when a given edge is NOT in a Topology, that it doesn't add a remap line.
 func TestMakeParentDotConfigNotInTopologies(t *testing.T) {
 	hdr := &ParentConfigOpts{AddComments: false, HdrComment: "myHeaderComment"}
@@ -915,7 +1052,7 @@ func TestMakeParentDotConfigNotInTopologies(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1070,7 +1207,7 @@ func TestMakeParentDotConfigTopologiesCapabilities(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1209,7 +1346,7 @@ func TestMakeParentDotConfigTopologiesOmitOfflineParents(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1353,7 +1490,7 @@ func TestMakeParentDotConfigTopologiesOmitDifferentCDNParents(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1478,7 +1615,7 @@ func TestMakeParentDotConfigTopologiesMSO(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1505,7 +1642,7 @@ func TestMakeParentDotConfigTopologiesMSO(t *testing.T) {
 	}
 
 	t.Run("MSO topologoies default qstring=ignore", func(t *testing.T) {
-		cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+		cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1522,7 +1659,7 @@ func TestMakeParentDotConfigTopologiesMSO(t *testing.T) {
 			Profiles:   []byte(`["serverprofile"]`),
 		})
 
-		cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParamsWithQstr, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+		cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParamsWithQstr, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1539,7 +1676,7 @@ func TestMakeParentDotConfigTopologiesMSO(t *testing.T) {
 			Profiles:   []byte(`["` + *ds1.ProfileName + `"]`),
 		})
 
-		cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParamsWithQstr, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+		cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParamsWithQstr, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1552,7 +1689,7 @@ func TestMakeParentDotConfigTopologiesMSO(t *testing.T) {
 		ds1.QStringIgnore = util.IntPtr(int(tc.QStringIgnoreUseInCacheKeyAndPassUp))
 		dses := []DeliveryService{*ds1}
 
-		cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+		cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -1684,7 +1821,7 @@ func TestMakeParentDotConfigTopologiesMSOWithCapabilities(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1811,7 +1948,7 @@ func TestMakeParentDotConfigMSOWithCapabilities(t *testing.T) {
 	}
 	topologies := []tc.Topology{}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1975,7 +2112,7 @@ func TestMakeParentDotConfigTopologiesMSOParams(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2151,7 +2288,7 @@ func TestMakeParentDotConfigTopologiesParams(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2302,7 +2439,7 @@ func TestMakeParentDotConfigTopologiesNonStandardServerTypes(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2471,7 +2608,7 @@ func TestMakeParentDotConfigSecondaryMode(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2626,7 +2763,7 @@ func TestMakeParentDotConfigNoSecondaryMode(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2754,7 +2891,7 @@ func TestMakeParentDotConfigComments(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2909,7 +3046,7 @@ func TestMakeParentDotConfigCommentTopology(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3034,7 +3171,7 @@ func TestMakeParentDotConfigHTTPSOrigin(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3180,7 +3317,7 @@ func TestMakeParentDotConfigHTTPSOriginTopology(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3281,7 +3418,7 @@ func TestMakeParentDotConfigNoParentNoTopology(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3444,7 +3581,7 @@ func TestMakeParentDotConfigHTTPSOriginTopologyNoPrimaryParent(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3630,7 +3767,7 @@ func TestMakeParentDotConfigMergeParentGroupTopology(t *testing.T) {
 	}
 
 	{ // test edge config
-		cfg, err := MakeParentDotConfig(dses, edge, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+		cfg, err := MakeParentDotConfig(dses, edge, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -3648,7 +3785,7 @@ func TestMakeParentDotConfigMergeParentGroupTopology(t *testing.T) {
 	}
 
 	{ // test mid config
-		cfg, err := MakeParentDotConfig(dses, mid0, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+		cfg, err := MakeParentDotConfig(dses, mid0, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -3839,7 +3976,7 @@ func TestMakeParentDotConfigTopologiesServerMultipleProfileParams(t *testing.T)
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -4099,7 +4236,7 @@ func TestMakeParentDotConfigFirstLastNoTopo(t *testing.T) {
 	}
 
 	{ // test edge config
-		cfg, err := MakeParentDotConfig(dses, edge, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+		cfg, err := MakeParentDotConfig(dses, edge, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -4135,7 +4272,7 @@ func TestMakeParentDotConfigFirstLastNoTopo(t *testing.T) {
 	}
 
 	{ // test mid config
-		cfg, err := MakeParentDotConfig(dses, mid0, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+		cfg, err := MakeParentDotConfig(dses, mid0, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -4467,7 +4604,7 @@ func TestMakeParentDotConfigFirstInnerLastTopology(t *testing.T) {
 	}
 
 	{ // test edge config
-		cfg, err := MakeParentDotConfig(dses, edge, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+		cfg, err := MakeParentDotConfig(dses, edge, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -4503,7 +4640,7 @@ func TestMakeParentDotConfigFirstInnerLastTopology(t *testing.T) {
 	}
 
 	{ // test mid config
-		cfg, err := MakeParentDotConfig(dses, mid0, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+		cfg, err := MakeParentDotConfig(dses, mid0, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -4538,7 +4675,7 @@ func TestMakeParentDotConfigFirstInnerLastTopology(t *testing.T) {
 	}
 
 	{ // test opl config
-		cfg, err := MakeParentDotConfig(dses, opl0, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+		cfg, err := MakeParentDotConfig(dses, opl0, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -4720,7 +4857,7 @@ func TestMakeParentDotConfigOptVersion(t *testing.T) {
 
 		opt := &ParentConfigOpts{AddComments: false, HdrComment: "myHeaderComment"}
 
-		cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, opt)
+		cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, opt)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -4745,7 +4882,7 @@ func TestMakeParentDotConfigOptVersion(t *testing.T) {
 
 		opt := &ParentConfigOpts{AddComments: false, HdrComment: "myHeaderComment"}
 
-		cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, opt)
+		cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, opt)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -4774,7 +4911,7 @@ func TestMakeParentDotConfigOptVersion(t *testing.T) {
 			ATSMajorVersion: 9,
 		}
 
-		cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, opt)
+		cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, opt)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -4803,7 +4940,7 @@ func TestMakeParentDotConfigOptVersion(t *testing.T) {
 			ATSMajorVersion: 5,
 		}
 
-		cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, opt)
+		cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, opt)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -4935,7 +5072,7 @@ func TestMakeParentDotConfigOriginIP(t *testing.T) {
 		Name:       "my-cdn-name",
 	}
 
-	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, cdn, hdr)
+	cfg, err := MakeParentDotConfig(dses, server, servers, topologies, serverParams, parentConfigParams, serverCapabilities, dsRequiredCapabilities, cgs, dss, nil, cdn, hdr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,151 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// makeDSPathRuleMap returns the path rules of the given Delivery Services,
+// keyed by their IDs. Each Delivery Service's rules are ordered by descending
+// path prefix length, so that the most specific rule matching a request comes
+// first.
+func makeDSPathRuleMap(dsPathRules []tc.DeliveryServicePathRules) map[int][]tc.DeliveryServicePathRule {
+	dsRules := make(map[int][]tc.DeliveryServicePathRule, len(dsPathRules))
+	for _, dsRule := range dsPathRules {
+		rules := make([]tc.DeliveryServicePathRule, len(dsRule.Rules))
+		copy(rules, dsRule.Rules)
+		sort.Slice(rules, func(i, j int) bool {
+			if len(rules[i].PathPrefix) != len(rules[j].PathPrefix) {
+				return len(rules[i].PathPrefix) > len(rules[j].PathPrefix)
+			}
+			return rules[i].PathPrefix < rules[j].PathPrefix
+		})
+		dsRules[dsRule.DeliveryServiceID] = rules
+	}
+	return dsRules
+}
+
+// pathRuleOrigin returns the origin URL to which the given path rule of a
+// Delivery Service with the given origin routes requests.
+func pathRuleOrigin(dsOrigin string, rule tc.DeliveryServicePathRule) string {
+	if rule.OriginURL != nil && *rule.OriginURL != "" {
+		return *rule.OriginURL
+	}
+	return dsOrigin
+}
+
+// pathRuleOrigins returns the distinct origin URLs of the given path rules
+// of a Delivery Service with the given origin, other than that origin.
+func pathRuleOrigins(dsOrigin string, rules []tc.DeliveryServicePathRule) []string {
+	origins := []string{}
+	seen := map[string]struct{}{dsOrigin: {}}
+	for _, rule := range rules {
+		origin := pathRuleOrigin(dsOrigin, rule)
+		if _, ok := seen[origin]; ok {
+			continue
+		}
+		seen[origin] = struct{}{}
+		origins = append(origins, origin)
+	}
+	return origins
+}
+
+// withPathRuleOrigins returns the given Delivery Services, followed by a copy
+// of each which has path rules for every other origin to which its path
+// rules route requests, with that origin as its OrgServerFQDN.
+//
+// Cache servers route requests to the parents of the Delivery Service by the
+// origin in the request after remapping, so the copies make path rule origins
+// get the same parents as their Delivery Service.
+func withPathRuleOrigins(dses []DeliveryService, dsPathRules map[int][]tc.DeliveryServicePathRule) []DeliveryService {
+	if len(dsPathRules) == 0 {
+		return dses
+	}
+	all := make([]DeliveryService, 0, len(dses))
+	all = append(all, dses...)
+	for _, ds := range dses {
+		if ds.ID == nil || ds.OrgServerFQDN == nil || *ds.OrgServerFQDN == "" {
+			continue
+		}
+		for _, origin := range pathRuleOrigins(*ds.OrgServerFQDN, dsPathRules[*ds.ID]) {
+			ruleDS := ds
+			ruleOrigin := origin
+			ruleDS.OrgServerFQDN = &ruleOrigin
+			all = append(all, ruleDS)
+		}
+	}
+	return all
+}
+
+// withPathRuleRemapLines returns the given remap lines of a Delivery Service,
+// each preceded by a line for each of its path rules, which maps the rule's
+// path prefix to the rule's origin.
+//
+// ATS uses the first remap rule which matches a request, so the path rule
+// lines must precede the line of the Delivery Service, and more specific
+// path rules must precede less specific ones.
+func withPathRuleRemapLines(ds DeliveryService, lines []remapLine, rules []tc.DeliveryServicePathRule) []remapLine {
+	if len(rules) == 0 {
+		return lines
+	}
+	all := []remapLine{}
+	for _, line := range lines {
+		for _, rule := range rules {
+			all = append(all, remapLine{
+				From:       line.From + strings.TrimPrefix(rule.PathPrefix, "/"),
+				To:         pathRuleOrigin(*ds.OrgServerFQDN, rule) + rule.PathPrefix,
+				PathPrefix: rule.PathPrefix,
+			})
+		}
+		all = append(all, line)
+	}
+	return all
+}
+
+// makePathRuleCacheDotConfigLines returns the cache.config lines of the given
+// path rules of a Delivery Service with the given origin. These make cache
+// servers not cache the responses to requests with the rule's path prefix, or
+// revalidate them with the origin when they've been cached for longer than
+// the rule's MaxTTL.
+func makePathRuleCacheDotConfigLines(dsOrigin string, rules []tc.DeliveryServicePathRule) []string {
+	lines := []string{}
+	for _, rule := range rules {
+		if !rule.NoCache && rule.MaxTTL == nil {
+			continue
+		}
+		originFQDN, originPort := getHostPortFromURI(pathRuleOrigin(dsOrigin, rule))
+		dest := "dest_domain=" + originFQDN
+		if originPort != "" {
+			dest += " port=" + originPort
+		}
+		dest += " prefix=" + strings.TrimPrefix(rule.PathPrefix, "/")
+		if rule.NoCache {
+			lines = append(lines, dest+" action=never-cache\n")
+		} else {
+			lines = append(lines, dest+" revalidate="+strconv.Itoa(*rule.MaxTTL)+"s\n")
+		}
+	}
+	return lines
+}
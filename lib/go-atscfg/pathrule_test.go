@@ -0,0 +1,277 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func makeTestPathRules() []tc.DeliveryServicePathRules {
+	return []tc.DeliveryServicePathRules{
+		{
+			DeliveryServiceID: 48,
+			XMLID:             "mydsname",
+			Rules: []tc.DeliveryServicePathRule{
+				{PathPrefix: "/live/", OriginURL: util.StrPtr("https://live.origin.example.test"), MaxTTL: util.IntPtr(2)},
+				{PathPrefix: "/live/manifests/", OriginURL: util.StrPtr("https://live.origin.example.test"), NoCache: true},
+				{PathPrefix: "/vod/", NoCache: true},
+			},
+		},
+	}
+}
+
+func makeTestPathRuleDS() DeliveryService {
+	ds := DeliveryService{}
+	ds.ID = util.IntPtr(48)
+	ds.XMLID = util.StrPtr("mydsname")
+	dsType := tc.DSTypeHTTP
+	ds.Type = &dsType
+	ds.OrgServerFQDN = util.StrPtr("https://origin.example.test")
+	ds.MidHeaderRewrite = util.StrPtr("mymidrewrite")
+	ds.DSCP = util.IntPtr(0)
+	ds.Protocol = util.IntPtr(int(tc.DSProtocolHTTP))
+	ds.MultiSiteOrigin = util.BoolPtr(false)
+	ds.Active = util.BoolPtr(true)
+	ds.Topology = util.StrPtr("")
+	return ds
+}
+
+func TestMakeDSPathRuleMap(t *testing.T) {
+	rules := makeDSPathRuleMap(makeTestPathRules())[48]
+	prefixes := []string{}
+	for _, rule := range rules {
+		prefixes = append(prefixes, rule.PathPrefix)
+	}
+	if expected := "/live/manifests/ /live/ /vod/"; strings.Join(prefixes, " ") != expected {
+		t.Errorf("expected rules ordered by descending prefix length '%s', actual: '%s'", expected, strings.Join(prefixes, " "))
+	}
+}
+
+func TestWithPathRuleOrigins(t *testing.T) {
+	ds := makeTestPathRuleDS()
+	dses := withPathRuleOrigins([]DeliveryService{ds}, makeDSPathRuleMap(makeTestPathRules()))
+	if len(dses) != 2 {
+		t.Fatalf("expected the delivery service and a copy for its one other path rule origin, actual: %d delivery services", len(dses))
+	}
+	if *dses[0].OrgServerFQDN != "https://origin.example.test" {
+		t.Errorf("expected the delivery service's origin to be unchanged, actual: '%s'", *dses[0].OrgServerFQDN)
+	}
+	if *dses[1].OrgServerFQDN != "https://live.origin.example.test" || *dses[1].ID != *ds.ID {
+		t.Errorf("expected a copy of the delivery service with origin 'https://live.origin.example.test', actual: %+v", dses[1])
+	}
+}
+
+func TestMakeRemapDotConfigPathRules(t *testing.T) {
+	server := makeTestRemapServer()
+	server.Type = "EDGE"
+	server.TCPPort = util.IntPtr(80)
+
+	ds := makeTestPathRuleDS()
+	dss := []DeliveryServiceServer{{Server: *server.ID, DeliveryService: *ds.ID}}
+	dsRegexes := []tc.DeliveryServiceRegexes{
+		{
+			DSName:  *ds.XMLID,
+			Regexes: []tc.DeliveryServiceRegex{{Type: string(tc.DSMatchTypeHostRegex), Pattern: "myds.example.test"}},
+		},
+	}
+	cdn := &tc.CDN{DomainName: "cdndomain.example", Name: "my-cdn-name"}
+
+	eCG := tc.CacheGroupNullable{}
+	eCG.Name = server.Cachegroup
+	eCG.ParentName = util.StrPtr("midCG")
+	mCG := tc.CacheGroupNullable{}
+	mCG.Name = util.StrPtr("midCG")
+	mCGType := tc.CacheGroupMidTypeName
+	mCG.Type = &mCGType
+	cgs := []tc.CacheGroupNullable{eCG, mCG}
+
+	cfg, err := MakeRemapDotConfig(server, []DeliveryService{ds}, dss, dsRegexes, makeTestPathRules(), nil, cdn, nil, nil, cgs, nil, nil, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := []string{}
+	for _, line := range strings.Split(cfg.Text, "\n") {
+		if strings.HasPrefix(line, "map") {
+			lines = append(lines, line)
+		}
+	}
+	expected := []string{
+		"map	http://myds.example.test/live/manifests/     http://live.origin.example.test/live/manifests/",
+		"map	http://myds.example.test/live/     http://live.origin.example.test/live/",
+		"map	http://myds.example.test/vod/     http://origin.example.test/vod/",
+		"map	http://myds.example.test/     http://origin.example.test/",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d remap lines, actual: '%s'", len(expected), cfg.Text)
+	}
+	for i, prefix := range expected {
+		if !strings.HasPrefix(lines[i], prefix+" ") {
+			t.Errorf("expected remap line %d to start with '%s', actual: '%s'", i, prefix, lines[i])
+		}
+	}
+	if !strings.Contains(lines[1], "# ds 'mydsname' path '/live/' topology ''") {
+		t.Errorf("expected path rule remap line to comment its path prefix, actual: '%s'", lines[1])
+	}
+}
+
+func TestMakeRemapDotConfigPathRulesStrategiesLastTier(t *testing.T) {
+	server := makeTestRemapServer()
+	server.Type = "EDGE"
+	server.TCPPort = util.IntPtr(80)
+
+	ds := makeTestPathRuleDS()
+	dss := []DeliveryServiceServer{{Server: *server.ID, DeliveryService: *ds.ID}}
+	dsRegexes := []tc.DeliveryServiceRegexes{
+		{
+			DSName:  *ds.XMLID,
+			Regexes: []tc.DeliveryServiceRegex{{Type: string(tc.DSMatchTypeHostRegex), Pattern: "myds.example.test"}},
+		},
+	}
+	cdn := &tc.CDN{DomainName: "cdndomain.example", Name: "my-cdn-name"}
+
+	cfg, err := MakeRemapDotConfig(server, []DeliveryService{ds}, dss, dsRegexes, makeTestPathRules(), nil, cdn, nil, nil, nil, nil, nil, "", &RemapDotConfigOpts{UseStrategies: true, UseStrategiesCore: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range strings.Split(cfg.Text, "\n") {
+		if !strings.HasPrefix(line, "map") {
+			continue
+		}
+		usesStrategy := strings.Contains(line, "@strategy=")
+		if strings.Contains(line, "live.origin.example.test") && usesStrategy {
+			t.Errorf("expected last tier path rule remap line to another origin to go to it directly, actual: '%s'", line)
+		} else if !strings.Contains(line, "live.origin.example.test") && !usesStrategy {
+			t.Errorf("expected remap line to the delivery service origin to use its strategy, actual: '%s'", line)
+		}
+	}
+}
+
+func TestMakeRemapDotConfigMidPathRules(t *testing.T) {
+	server := makeTestRemapServer()
+
+	ds := makeTestPathRuleDS()
+	dss := []DeliveryServiceServer{{Server: *server.ID, DeliveryService: *ds.ID}}
+	cdn := &tc.CDN{DomainName: "cdndomain.example", Name: "my-cdn-name"}
+
+	cfg, err := MakeRemapDotConfig(server, []DeliveryService{ds}, dss, nil, makeTestPathRules(), nil, cdn, nil, nil, nil, nil, nil, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txt := cfg.Text
+
+	if !strings.Contains(txt, "map http://origin.example.test https://origin.example.test @plugin=header_rewrite.so @pparam=hdr_rw_mid_mydsname.config") {
+		t.Errorf("expected mid remap line of the delivery service origin, actual: '%s'", txt)
+	}
+	if !strings.Contains(txt, "map http://live.origin.example.test https://live.origin.example.test @plugin=header_rewrite.so @pparam=hdr_rw_mid_mydsname.config") {
+		t.Errorf("expected mid remap line of the path rule origin with the delivery service's plugins, actual: '%s'", txt)
+	}
+	if strings.Count(txt, "live.origin.example.test @plugin") != 1 {
+		t.Errorf("expected one mid remap line of the path rule origin shared by two rules, actual: '%s'", txt)
+	}
+}
+
+func TestMakeParentDotConfigPathRules(t *testing.T) {
+	ds := makeTestPathRuleDS()
+	ds.ID = util.IntPtr(42)
+
+	server := makeTestParentServer()
+
+	mid0 := makeTestParentServer()
+	mid0.Cachegroup = util.StrPtr("midCG")
+	mid0.HostName = util.StrPtr("mymid0")
+	mid0.ID = util.IntPtr(45)
+	setIP(mid0, "192.168.2.2")
+
+	eCG := tc.CacheGroupNullable{}
+	eCG.Name = server.Cachegroup
+	eCG.ID = server.CachegroupID
+	eCG.ParentName = mid0.Cachegroup
+	eCG.ParentCachegroupID = mid0.CachegroupID
+	eCGType := tc.CacheGroupEdgeTypeName
+	eCG.Type = &eCGType
+
+	mCG := tc.CacheGroupNullable{}
+	mCG.Name = mid0.Cachegroup
+	mCG.ID = mid0.CachegroupID
+	mCGType := tc.CacheGroupMidTypeName
+	mCG.Type = &mCGType
+
+	serverParams := []tc.Parameter{{Name: "trafficserver", ConfigFile: "package", Value: "7", Profiles: []byte(`["global"]`)}}
+	dss := []DeliveryServiceServer{{Server: *server.ID, DeliveryService: *ds.ID}}
+	pathRules := makeTestPathRules()
+	pathRules[0].DeliveryServiceID = *ds.ID
+	cdn := &tc.CDN{DomainName: "cdndomain.example", Name: "my-cdn-name"}
+
+	cfg, err := MakeParentDotConfig([]DeliveryService{ds}, server, []Server{*server, *mid0}, nil, serverParams, nil, nil, nil, []tc.CacheGroupNullable{eCG, mCG}, dss, pathRules, cdn, &ParentConfigOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txt := cfg.Text
+
+	dsLine := ""
+	ruleLine := ""
+	for _, line := range strings.Split(txt, "\n") {
+		if strings.HasPrefix(line, "dest_domain=origin.example.test ") {
+			dsLine = line
+		} else if strings.HasPrefix(line, "dest_domain=live.origin.example.test ") {
+			ruleLine = line
+		}
+	}
+	if dsLine == "" || ruleLine == "" {
+		t.Fatalf("expected parent lines of the delivery service and path rule origins, actual: '%s'", txt)
+	}
+	if !strings.Contains(ruleLine, "mymid0") {
+		t.Errorf("expected path rule origin to have the delivery service's parents, actual: '%s'", ruleLine)
+	}
+	if strings.TrimPrefix(dsLine, "dest_domain=origin.example.test ") != strings.TrimPrefix(ruleLine, "dest_domain=live.origin.example.test ") {
+		t.Errorf("expected path rule origin parent line '%s' to match delivery service parent line '%s'", ruleLine, dsLine)
+	}
+}
+
+func TestMakeCacheDotConfigPathRules(t *testing.T) {
+	server := makeGenericServer()
+	server.ProfileNames = []string{"myProfile"}
+	server.Type = "EDGE"
+
+	ds := makeTestPathRuleDS()
+	dss := []DeliveryServiceServer{{Server: *server.ID, DeliveryService: *ds.ID}}
+
+	cfg, err := MakeCacheDotConfig(server, []Server{*server}, []DeliveryService{ds}, dss, nil, makeTestPathRules(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	txt := cfg.Text
+
+	for _, expected := range []string{
+		"dest_domain=live.origin.example.test prefix=live/ revalidate=2s\n",
+		"dest_domain=live.origin.example.test prefix=live/manifests/ action=never-cache\n",
+		"dest_domain=origin.example.test prefix=vod/ action=never-cache\n",
+	} {
+		if !strings.Contains(txt, expected) {
+			t.Errorf("expected cache.config line '%s', actual: '%s'", strings.TrimSpace(expected), txt)
+		}
+	}
+}
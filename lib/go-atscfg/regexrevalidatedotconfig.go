@@ -94,6 +94,12 @@ func MakeRegexRevalidateDotConfig(
 		if _, ok := dsNames[job.DeliveryService]; !ok {
 			continue
 		}
+		if len(job.SurrogateKeys) > 0 {
+			// Surrogate key jobs aren't regular expressions; ATS can't purge
+			// content by surrogate key.
+			warnings = append(warnings, "got surrogate key job for Delivery Service '"+job.DeliveryService+"', which ATS can't purge! Skipping!")
+			continue
+		}
 		dsJobs = append(dsJobs, job)
 	}
 
@@ -152,6 +158,10 @@ func (jb jobsSort) Less(i, j int) bool {
 //   - have a start time later than (now + maxReval days). That is, we don't query jobs older than maxReval in the past.
 //   - have a start_time+ttl > now. That is, jobs that haven't expired yet.
 //
+// The TTL of a job is its TTL override, if it has one, and its TTL in hours
+// otherwise, which is no less than minTTL. Either way, it's no more than
+// maxReval.
+//
 // Returns the filtered jobs.
 func filterJobs(tcJobs []InvalidationJob, maxReval time.Duration, minTTL time.Duration) []revalJob {
 
@@ -163,11 +173,14 @@ func filterJobs(tcJobs []InvalidationJob, maxReval time.Duration, minTTL time.Du
 		}
 
 		ttl := time.Duration(tcJob.TTLHours) * time.Hour
-		if ttl > maxReval {
-			ttl = maxReval
+		if tcJob.TTLOverrideMinutes != nil {
+			ttl = time.Duration(*tcJob.TTLOverrideMinutes) * time.Minute
 		} else if ttl < minTTL {
 			ttl = minTTL
 		}
+		if ttl > maxReval {
+			ttl = maxReval
+		}
 
 		if tcJob.StartTime.Add(maxReval).Before(time.Now()) {
 			continue
@@ -178,6 +191,11 @@ func filterJobs(tcJobs []InvalidationJob, maxReval time.Duration, minTTL time.Du
 		}
 
 		jobType, assetURL := processRefetch(tcJob.InvalidationType, tcJob.AssetURL)
+		if tcJob.ServeStale {
+			// Matching content is marked stale rather than missed, so it can
+			// be served while it's refetched.
+			jobType = RevalTypeStale
+		}
 
 		purgeEnd := tcJob.StartTime.Add(ttl)
 
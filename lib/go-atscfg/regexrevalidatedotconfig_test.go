@@ -20,6 +20,7 @@ package atscfg
  */
 
 import (
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -126,3 +127,85 @@ func TestMakeRegexRevalidateDotConfig(t *testing.T) {
 		t.Errorf("##REFRESH## directive not properly handled '%v'", txt)
 	}
 }
+
+func TestMakeRegexRevalidateDotConfigServeStaleAndTTLOverride(t *testing.T) {
+	cdnName := "mycdn"
+
+	server := makeGenericServer()
+	server.CDNName = &cdnName
+
+	ds := makeGenericDS()
+	ds.CDNName = &cdnName
+	ds.XMLID = util.StrPtr("myds")
+	dses := []DeliveryService{*ds}
+
+	params := makeParamsFromMapArr("GLOBAL", RegexRevalidateFileName, map[string][]string{
+		RegexRevalidateMaxRevalDurationDaysParamName: {"1"},
+	})
+
+	startTime := time.Now().Add(-time.Minute).Truncate(time.Second)
+	overrideMinutes := uint(10)
+	longOverrideMinutes := uint(3 * 24 * 60)
+	jobs := []InvalidationJob{
+		{
+			AssetURL:         "servestale",
+			StartTime:        startTime,
+			DeliveryService:  "myds",
+			TTLHours:         24,
+			InvalidationType: tc.REFETCH,
+			ServeStale:       true,
+		},
+		{
+			AssetURL:           "override",
+			StartTime:          startTime,
+			DeliveryService:    "myds",
+			TTLHours:           24,
+			InvalidationType:   tc.REFETCH,
+			TTLOverrideMinutes: &overrideMinutes,
+		},
+		{
+			AssetURL:           "longoverride",
+			StartTime:          startTime,
+			DeliveryService:    "myds",
+			TTLHours:           1,
+			InvalidationType:   tc.REFRESH,
+			TTLOverrideMinutes: &longOverrideMinutes,
+		},
+		{
+			AssetURL:         "http://origin.example",
+			StartTime:        startTime,
+			DeliveryService:  "myds",
+			TTLHours:         24,
+			InvalidationType: tc.REFRESH,
+			SurrogateKeys:    []string{"user-1234"},
+		},
+	}
+
+	cfg, err := MakeRegexRevalidateDotConfig(server, dses, params, jobs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"longoverride " + strconv.FormatInt(startTime.Add(24*time.Hour).Unix(), 10),
+		"override " + strconv.FormatInt(startTime.Add(10*time.Minute).Unix(), 10) + " MISS",
+		"servestale " + strconv.FormatInt(startTime.Add(24*time.Hour).Unix(), 10),
+	}
+	lines := []string{}
+	for _, line := range strings.Split(cfg.Text, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, LineCommentRegexRevalidateDotConfig) {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d lines, actual: '%s'", len(expected), cfg.Text)
+	}
+	for i, line := range lines {
+		if line != expected[i] {
+			t.Errorf("expected line %d to be '%s', actual: '%s'", i, expected[i], line)
+		}
+	}
+	if len(cfg.Warnings) != 1 {
+		t.Errorf("expected a warning about the skipped surrogate key job, actual: %v", cfg.Warnings)
+	}
+}
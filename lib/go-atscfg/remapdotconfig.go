@@ -71,6 +71,7 @@ func MakeRemapDotConfig(
 	unfilteredDSes []DeliveryService,
 	dss []DeliveryServiceServer,
 	dsRegexArr []tc.DeliveryServiceRegexes,
+	dsPathRuleArr []tc.DeliveryServicePathRules,
 	serverParams []tc.Parameter,
 	cdn *tc.CDN,
 	remapConfigParams []tc.Parameter, // includes cachekey.config
@@ -102,6 +103,7 @@ func MakeRemapDotConfig(
 
 	cdnDomain := cdn.DomainName
 	dsRegexes := makeDSRegexMap(dsRegexArr)
+	dsPathRules := makeDSPathRuleMap(dsPathRuleArr)
 	// Returned DSes are guaranteed to have a non-nil XMLID, Type, DSCP, ID, and Active.
 	dses, dsWarns := remapFilterDSes(server, dss, unfilteredDSes)
 	warnings = append(warnings, dsWarns...)
@@ -127,9 +129,9 @@ func MakeRemapDotConfig(
 	txt := ""
 	typeWarns := []string{}
 	if tc.CacheTypeFromString(server.Type) == tc.CacheTypeMid {
-		txt, typeWarns, err = getServerConfigRemapDotConfigForMid(atsMajorVersion, dsProfilesConfigParams, dses, dsRegexes, hdr, server, nameTopologies, cacheGroups, serverCapabilities, dsRequiredCapabilities, configDir, opt)
+		txt, typeWarns, err = getServerConfigRemapDotConfigForMid(atsMajorVersion, dsProfilesConfigParams, dses, dsRegexes, dsPathRules, hdr, server, nameTopologies, cacheGroups, serverCapabilities, dsRequiredCapabilities, configDir, opt)
 	} else {
-		txt, typeWarns, err = getServerConfigRemapDotConfigForEdge(dsProfilesConfigParams, serverPackageParamData, dses, dsRegexes, atsMajorVersion, hdr, server, nameTopologies, cacheGroups, serverCapabilities, dsRequiredCapabilities, cdnDomain, configDir, opt)
+		txt, typeWarns, err = getServerConfigRemapDotConfigForEdge(dsProfilesConfigParams, serverPackageParamData, dses, dsRegexes, dsPathRules, atsMajorVersion, hdr, server, nameTopologies, cacheGroups, serverCapabilities, dsRequiredCapabilities, cdnDomain, configDir, opt)
 	}
 	warnings = append(warnings, typeWarns...)
 	if err != nil {
@@ -258,6 +260,7 @@ func getServerConfigRemapDotConfigForMid(
 	profilesConfigParams map[int][]tc.Parameter,
 	dses []DeliveryService,
 	dsRegexes map[tc.DeliveryServiceName][]tc.DeliveryServiceRegex,
+	dsPathRules map[int][]tc.DeliveryServicePathRule,
 	header string,
 	server *Server,
 	nameTopologies map[TopologyName]tc.Topology,
@@ -304,7 +307,8 @@ func getServerConfigRemapDotConfigForMid(
 
 		midRemap := ""
 
-		midRemap += strategyDirective(getStrategyName(*ds.XMLID), configDir, opts)
+		strategyTxt := strategyDirective(getStrategyName(*ds.XMLID), configDir, opts)
+		midRemap += strategyTxt
 
 		if *ds.Topology != "" {
 			topoTxt, err := makeDSTopologyHeaderRewriteTxt(ds, tc.CacheGroupName(*server.Cachegroup), topology, cacheGroups)
@@ -312,7 +316,7 @@ func getServerConfigRemapDotConfigForMid(
 				return "", warnings, err
 			}
 			midRemap += topoTxt
-		} else if (ds.MidHeaderRewrite != nil && *ds.MidHeaderRewrite != "") || (ds.MaxOriginConnections != nil && *ds.MaxOriginConnections > 0) || (ds.ServiceCategory != nil && *ds.ServiceCategory != "") {
+		} else if (ds.MidHeaderRewrite != nil && *ds.MidHeaderRewrite != "") || (ds.MaxOriginConnections != nil && *ds.MaxOriginConnections > 0) || (ds.ServiceCategory != nil && *ds.ServiceCategory != "") || traceHeaderEnabled(&ds) {
 			midRemap += ` @plugin=header_rewrite.so @pparam=` + midHeaderRewriteConfigFileName(*ds.XMLID)
 		}
 
@@ -355,9 +359,39 @@ func getServerConfigRemapDotConfigForMid(
 			mapTo = strings.Replace(mapTo, `https://`, `http://`, -1)
 		}
 
+		pluginsTxt := midRemap
+		midRemap += originTLSVerifyRemapTxt(&ds, mapTo, configDir)
+
 		if midRemap != "" {
 			midRemaps[remapFrom] = mapTo + midRemap
 		}
+		if webSocketEnabled(&ds) {
+			wsFrom := webSocketURL(remapFrom)
+			midRemaps[wsFrom] = webSocketURL(mapTo) + pluginsTxt + originTLSVerifyRemapTxt(&ds, mapTo, configDir) + streamRemapTxt(&ds, wsFrom, mapTo, atsMajorVersion, &warnings)
+		}
+
+		// Path rules routing to other origins need the same remap plugins as their DS.
+		for _, origin := range pathRuleOrigins(*ds.OrgServerFQDN, dsPathRules[*ds.ID]) {
+			ruleFrom := strings.Replace(origin, `https://`, `http://`, -1)
+			if midRemaps[ruleFrom] != "" {
+				continue
+			}
+			ruleTo := origin
+			rulePluginsTxt := pluginsTxt
+			if !isLastCache {
+				ruleTo = strings.Replace(ruleTo, `https://`, `http://`, -1)
+			} else {
+				// the DS strategy's parent is the DS origin, so the last tier goes to other origins directly
+				rulePluginsTxt = strings.TrimPrefix(rulePluginsTxt, strategyTxt)
+			}
+			if ruleRemap := rulePluginsTxt + originTLSVerifyRemapTxt(&ds, ruleTo, configDir); ruleRemap != "" {
+				midRemaps[ruleFrom] = ruleTo + ruleRemap
+			}
+			if webSocketEnabled(&ds) {
+				wsFrom := webSocketURL(ruleFrom)
+				midRemaps[wsFrom] = webSocketURL(ruleTo) + rulePluginsTxt + originTLSVerifyRemapTxt(&ds, ruleTo, configDir) + streamRemapTxt(&ds, wsFrom, ruleTo, atsMajorVersion, &warnings)
+			}
+		}
 
 		// Any raw pre or post pend
 		dsPreRemaps, dsPostRemaps := lastPrePostRemapLinesFor(dsConfigParamsMap, *ds.XMLID)
@@ -397,6 +431,7 @@ func getServerConfigRemapDotConfigForEdge(
 	serverPackageParamData map[string]string, // map[paramName]paramVal for this server, config file 'package'
 	dses []DeliveryService,
 	dsRegexes map[tc.DeliveryServiceName][]tc.DeliveryServiceRegex,
+	dsPathRules map[int][]tc.DeliveryServicePathRule,
 	atsMajorVersion uint,
 	header string,
 	server *Server,
@@ -451,6 +486,7 @@ func getServerConfigRemapDotConfigForEdge(
 				warnings = append(warnings, "DS '"+*ds.XMLID+"' - skipping! : "+err.Error())
 				continue
 			}
+			remapLines = withWebSocketRemapLines(ds, withPathRuleRemapLines(ds, remapLines, dsPathRules[*ds.ID]))
 
 			for _, line := range remapLines {
 				profileremapConfigParams := []tc.Parameter{}
@@ -472,7 +508,11 @@ func getServerConfigRemapDotConfigForEdge(
 				if err != nil {
 					return "", warnings, err
 				}
-				remapText += ` # ds '` + *ds.XMLID + `' topology '`
+				remapText += ` # ds '` + *ds.XMLID + `' `
+				if line.PathPrefix != "" {
+					remapText += `path '` + line.PathPrefix + `' `
+				}
+				remapText += `topology '`
 				if hasTopology {
 					remapText += topology.Name
 				}
@@ -533,11 +573,22 @@ func buildEdgeRemapLine(
 	// cache-to-cache communication inside the CDN is always http (though that's likely to change in the future)
 	if !isLastCache {
 		mapTo = strings.Replace(mapTo, `https://`, `http://`, -1)
+		mapTo = strings.Replace(mapTo, `wss://`, `ws://`, -1)
 	}
 
 	text += "map	" + mapFrom + "     " + mapTo
 
-	text += strategyDirective(getStrategyName(*ds.XMLID), configDir, opts)
+	// WebSocket rules go to the same origins and parents as their HTTP rules.
+	isWebSocket := isWebSocketURL(mapFrom)
+	httpMapTo := httpURL(mapTo)
+
+	// The DS strategy's parent on the last tier is the DS origin, so path rules
+	// routing to other origins go to them directly from the last tier.
+	if !isLastCache || strings.HasPrefix(httpMapTo, *ds.OrgServerFQDN+"/") {
+		text += strategyDirective(getStrategyName(*ds.XMLID), configDir, opts)
+	}
+	text += originTLSVerifyRemapTxt(&ds, httpMapTo, configDir)
+	text += streamRemapTxt(&ds, mapFrom, mapTo, atsMajorVersion, &warnings)
 
 	if _, hasDSCPRemap := pData["dscp_remap"]; hasDSCPRemap {
 		text += ` @plugin=dscp_remap.so @pparam=` + strconv.Itoa(*ds.DSCP)
@@ -551,7 +602,7 @@ func buildEdgeRemapLine(
 			return remapLines, warnings, err
 		}
 		text += topoTxt
-	} else if (ds.EdgeHeaderRewrite != nil && *ds.EdgeHeaderRewrite != "") || (ds.ServiceCategory != nil && *ds.ServiceCategory != "") || (ds.MaxOriginConnections != nil && *ds.MaxOriginConnections != 0) {
+	} else if (ds.EdgeHeaderRewrite != nil && *ds.EdgeHeaderRewrite != "") || (ds.ServiceCategory != nil && *ds.ServiceCategory != "") || (ds.MaxOriginConnections != nil && *ds.MaxOriginConnections != 0) || traceHeaderEnabled(&ds) {
 		text += ` @plugin=header_rewrite.so @pparam=` + edgeHeaderRewriteConfigFileName(*ds.XMLID)
 	}
 
@@ -567,6 +618,11 @@ func buildEdgeRemapLine(
 		}
 	}
 
+	// Compressing or slicing the upgrade request of a WebSocket would break it.
+	if !isWebSocket {
+		text += compressRemapTxt(&ds)
+	}
+
 	// Raw remap text, this allows the directive hacks
 	remapText := ""
 	if ds.RemapText != nil {
@@ -617,7 +673,7 @@ func buildEdgeRemapLine(
 	}
 
 	rangeReqTxt := ""
-	if ds.RangeRequestHandling != nil {
+	if ds.RangeRequestHandling != nil && !isWebSocket {
 		crr := false
 
 		if *ds.RangeRequestHandling == tc.RangeRequestHandlingBackgroundFetch {
@@ -682,13 +738,13 @@ func makeDSTopologyHeaderRewriteTxt(ds DeliveryService, cg tc.CacheGroupName, to
 	}
 	txt := ""
 	const pluginTxt = ` @plugin=header_rewrite.so @pparam=`
-	if placement.IsFirstCacheTier && ((ds.FirstHeaderRewrite != nil && *ds.FirstHeaderRewrite != "") || (ds.ServiceCategory != nil && *ds.ServiceCategory != "")) {
+	if placement.IsFirstCacheTier && ((ds.FirstHeaderRewrite != nil && *ds.FirstHeaderRewrite != "") || (ds.ServiceCategory != nil && *ds.ServiceCategory != "") || traceHeaderEnabled(&ds)) {
 		txt += pluginTxt + FirstHeaderRewriteConfigFileName(*ds.XMLID) + ` `
 	}
-	if placement.IsInnerCacheTier && ((ds.InnerHeaderRewrite != nil && *ds.InnerHeaderRewrite != "") || (ds.ServiceCategory != nil && *ds.ServiceCategory != "")) {
+	if placement.IsInnerCacheTier && ((ds.InnerHeaderRewrite != nil && *ds.InnerHeaderRewrite != "") || (ds.ServiceCategory != nil && *ds.ServiceCategory != "") || traceHeaderEnabled(&ds)) {
 		txt += pluginTxt + InnerHeaderRewriteConfigFileName(*ds.XMLID) + ` `
 	}
-	if placement.IsLastCacheTier && ((ds.LastHeaderRewrite != nil && *ds.LastHeaderRewrite != "") || (ds.ServiceCategory != nil && *ds.ServiceCategory != "") || (ds.MaxOriginConnections != nil && *ds.MaxOriginConnections != 0)) {
+	if placement.IsLastCacheTier && ((ds.LastHeaderRewrite != nil && *ds.LastHeaderRewrite != "") || (ds.ServiceCategory != nil && *ds.ServiceCategory != "") || (ds.MaxOriginConnections != nil && *ds.MaxOriginConnections != 0) || traceHeaderEnabled(&ds)) {
 		txt += pluginTxt + LastHeaderRewriteConfigFileName(*ds.XMLID) + ` `
 	}
 	return txt, nil
@@ -697,6 +753,9 @@ func makeDSTopologyHeaderRewriteTxt(ds DeliveryService, cg tc.CacheGroupName, to
 type remapLine struct {
 	From string
 	To   string
+	// PathPrefix is the path prefix of the Delivery Service path rule of the
+	// line, or empty if it's the line of the Delivery Service itself.
+	PathPrefix string
 }
 
 // makeEdgeDSDataRemapLines returns the remap lines for the given server and delivery service.
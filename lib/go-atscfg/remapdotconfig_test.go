@@ -20,6 +20,7 @@ package atscfg
  */
 
 import (
+	"strconv"
 	"strings"
 	"testing"
 
@@ -128,7 +129,7 @@ func TestMakeRemapDotConfig0(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -162,6 +163,520 @@ func TestMakeRemapDotConfig0(t *testing.T) {
 	}
 }
 
+func TestMakeRemapDotConfigOriginTLSVerify(t *testing.T) {
+	hdr := "myHeaderComment"
+
+	server := makeTestRemapServer()
+	server.Type = "EDGE"
+
+	ds := DeliveryService{}
+	ds.ID = util.IntPtr(48)
+	dsType := tc.DSType("HTTP_LIVE")
+	ds.Type = &dsType
+	ds.OrgServerFQDN = util.StrPtr("https://origin.example.test")
+	ds.OriginTLSVerify = util.BoolPtr(true)
+	ds.OriginTLSCABundle = util.StrPtr("mybundle")
+	ds.MidHeaderRewrite = util.StrPtr("mymidrewrite")
+	ds.RangeRequestHandling = util.IntPtr(0)
+	ds.RemapText = util.StrPtr("myremaptext")
+	ds.EdgeHeaderRewrite = util.StrPtr("myedgeheaderrewrite")
+	ds.SigningAlgorithm = util.StrPtr("url_sig")
+	ds.XMLID = util.StrPtr("mydsname")
+	ds.QStringIgnore = util.IntPtr(0)
+	ds.RegexRemap = util.StrPtr("myregexremap")
+	ds.FQPacingRate = util.IntPtr(0)
+	ds.DSCP = util.IntPtr(0)
+	ds.RoutingName = util.StrPtr("myroutingname")
+	ds.MultiSiteOrigin = util.BoolPtr(false)
+	ds.OriginShield = util.StrPtr("myoriginshield")
+	ds.ProfileID = util.IntPtr(49)
+	ds.Protocol = util.IntPtr(0)
+	ds.AnonymousBlockingEnabled = util.BoolPtr(false)
+	ds.Active = util.BoolPtr(true)
+	dses := []DeliveryService{ds}
+
+	dss := []DeliveryServiceServer{
+		DeliveryServiceServer{
+			Server:          *server.ID,
+			DeliveryService: *ds.ID,
+		},
+	}
+
+	dsRegexes := []tc.DeliveryServiceRegexes{
+		tc.DeliveryServiceRegexes{
+			DSName: *ds.XMLID,
+			Regexes: []tc.DeliveryServiceRegex{
+				tc.DeliveryServiceRegex{
+					Type:      string(tc.DSMatchTypeHostRegex),
+					SetNumber: 0,
+					Pattern:   "myregexpattern",
+				},
+			},
+		},
+	}
+
+	serverParams := []tc.Parameter{
+		tc.Parameter{
+			Name:       "trafficserver",
+			ConfigFile: "package",
+			Value:      "7",
+			Profiles:   []byte(`["global"]`),
+		},
+	}
+
+	remapConfigParams := []tc.Parameter{
+		tc.Parameter{
+			Name:       "cachekey.pparam",
+			ConfigFile: "remap.config",
+			Value:      "--cachekeykey=cachekeyval",
+			Profiles:   []byte(`["dsprofile"]`),
+		},
+		tc.Parameter{
+			Name:       "not_location",
+			ConfigFile: "cachekey.config",
+			Value:      "notinconfig",
+			Profiles:   []byte(`["global"]`),
+		},
+	}
+
+	cdn := &tc.CDN{
+		DomainName: "cdndomain.example",
+		Name:       "my-cdn-name",
+	}
+
+	topologies := []tc.Topology{}
+	cgs := []tc.CacheGroupNullable{}
+	serverCapabilities := map[int]map[ServerCapability]struct{}{}
+	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
+	configDir := `/opt/trafficserver/etc/trafficserver`
+
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txt := cfg.Text
+
+	t.Logf("text: %v", txt)
+
+	txt = strings.TrimSpace(txt)
+
+	testComment(t, txt, hdr)
+
+	txtLines := strings.Split(txt, "\n")
+
+	if len(txtLines) != 3 {
+		t.Log(cfg.Warnings)
+		t.Fatalf("expected one line for each remap plus a comment and blank, actual: '%v' count %v", txt, len(txtLines))
+	}
+
+	remapLine := txtLines[2]
+
+	if !strings.Contains(remapLine, "@plugin=conf_remap.so @pparam=proxy.config.ssl.client.verify.server.policy=ENFORCED @pparam=proxy.config.ssl.client.verify.server.properties=ALL") {
+		t.Errorf("expected to enforce origin TLS verification, actual '%v'", txt)
+	}
+
+	if !strings.Contains(remapLine, "@pparam=proxy.config.ssl.client.CA.cert.filename=/opt/trafficserver/etc/trafficserver/ssl/origin_ca_mydsname.pem") {
+		t.Errorf("expected to use the origin CA bundle, actual '%v'", txt)
+	}
+
+	dses[0].OriginTLSVerify = util.BoolPtr(false)
+	cfg, err = MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(cfg.Text, "proxy.config.ssl.client") {
+		t.Errorf("expected no origin TLS verification overrides for a Delivery Service that doesn't verify its origin, actual '%v'", cfg.Text)
+	}
+}
+
+func TestMakeRemapDotConfigCompress(t *testing.T) {
+	hdr := "myHeaderComment"
+
+	server := makeTestRemapServer()
+	server.Type = "EDGE"
+
+	ds := DeliveryService{}
+	ds.ID = util.IntPtr(48)
+	dsType := tc.DSType("HTTP_LIVE")
+	ds.Type = &dsType
+	ds.OrgServerFQDN = util.StrPtr("https://origin.example.test")
+	ds.CompressGzip = util.BoolPtr(true)
+	ds.MidHeaderRewrite = util.StrPtr("mymidrewrite")
+	ds.RangeRequestHandling = util.IntPtr(0)
+	ds.RemapText = util.StrPtr("myremaptext")
+	ds.EdgeHeaderRewrite = util.StrPtr("myedgeheaderrewrite")
+	ds.SigningAlgorithm = util.StrPtr("url_sig")
+	ds.XMLID = util.StrPtr("mydsname")
+	ds.QStringIgnore = util.IntPtr(0)
+	ds.RegexRemap = util.StrPtr("myregexremap")
+	ds.FQPacingRate = util.IntPtr(0)
+	ds.DSCP = util.IntPtr(0)
+	ds.RoutingName = util.StrPtr("myroutingname")
+	ds.MultiSiteOrigin = util.BoolPtr(false)
+	ds.OriginShield = util.StrPtr("myoriginshield")
+	ds.ProfileID = util.IntPtr(49)
+	ds.Protocol = util.IntPtr(0)
+	ds.AnonymousBlockingEnabled = util.BoolPtr(false)
+	ds.Active = util.BoolPtr(true)
+	dses := []DeliveryService{ds}
+
+	dss := []DeliveryServiceServer{
+		DeliveryServiceServer{
+			Server:          *server.ID,
+			DeliveryService: *ds.ID,
+		},
+	}
+
+	dsRegexes := []tc.DeliveryServiceRegexes{
+		tc.DeliveryServiceRegexes{
+			DSName: *ds.XMLID,
+			Regexes: []tc.DeliveryServiceRegex{
+				tc.DeliveryServiceRegex{
+					Type:      string(tc.DSMatchTypeHostRegex),
+					SetNumber: 0,
+					Pattern:   "myregexpattern",
+				},
+			},
+		},
+	}
+
+	serverParams := []tc.Parameter{
+		tc.Parameter{
+			Name:       "trafficserver",
+			ConfigFile: "package",
+			Value:      "7",
+			Profiles:   []byte(`["global"]`),
+		},
+	}
+
+	remapConfigParams := []tc.Parameter{
+		tc.Parameter{
+			Name:       "cachekey.pparam",
+			ConfigFile: "remap.config",
+			Value:      "--cachekeykey=cachekeyval",
+			Profiles:   []byte(`["dsprofile"]`),
+		},
+		tc.Parameter{
+			Name:       "not_location",
+			ConfigFile: "cachekey.config",
+			Value:      "notinconfig",
+			Profiles:   []byte(`["global"]`),
+		},
+	}
+
+	cdn := &tc.CDN{
+		DomainName: "cdndomain.example",
+		Name:       "my-cdn-name",
+	}
+
+	topologies := []tc.Topology{}
+	cgs := []tc.CacheGroupNullable{}
+	serverCapabilities := map[int]map[ServerCapability]struct{}{}
+	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
+	configDir := `/opt/trafficserver/etc/trafficserver`
+
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txt := cfg.Text
+
+	t.Logf("text: %v", txt)
+
+	txt = strings.TrimSpace(txt)
+
+	testComment(t, txt, hdr)
+
+	txtLines := strings.Split(txt, "\n")
+
+	if len(txtLines) != 3 {
+		t.Log(cfg.Warnings)
+		t.Fatalf("expected one line for each remap plus a comment and blank, actual: '%v' count %v", txt, len(txtLines))
+	}
+
+	remapLine := txtLines[2]
+
+	if !strings.Contains(remapLine, "@plugin=compress.so @pparam=compress_mydsname.config") {
+		t.Errorf("expected to compress responses, actual '%v'", txt)
+	}
+
+	dses[0].CompressGzip = util.BoolPtr(false)
+	cfg, err = MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(cfg.Text, "compress.so") {
+		t.Errorf("expected no compression for a Delivery Service that doesn't compress responses, actual '%v'", cfg.Text)
+	}
+}
+
+func TestMakeRemapDotConfigWebSocket(t *testing.T) {
+	hdr := "myHeaderComment"
+
+	server := makeTestRemapServer()
+	server.Type = "EDGE"
+
+	ds := DeliveryService{}
+	ds.ID = util.IntPtr(48)
+	dsType := tc.DSType("HTTP_LIVE")
+	ds.Type = &dsType
+	ds.OrgServerFQDN = util.StrPtr("https://origin.example.test")
+	ds.CompressGzip = util.BoolPtr(true)
+	ds.WebSocket = util.BoolPtr(true)
+	ds.StreamIdleTimeout = util.IntPtr(30)
+	ds.MidHeaderRewrite = util.StrPtr("mymidrewrite")
+	ds.RangeRequestHandling = util.IntPtr(0)
+	ds.RemapText = util.StrPtr("myremaptext")
+	ds.EdgeHeaderRewrite = util.StrPtr("myedgeheaderrewrite")
+	ds.SigningAlgorithm = util.StrPtr("url_sig")
+	ds.XMLID = util.StrPtr("mydsname")
+	ds.QStringIgnore = util.IntPtr(0)
+	ds.RegexRemap = util.StrPtr("myregexremap")
+	ds.FQPacingRate = util.IntPtr(0)
+	ds.DSCP = util.IntPtr(0)
+	ds.RoutingName = util.StrPtr("myroutingname")
+	ds.MultiSiteOrigin = util.BoolPtr(false)
+	ds.OriginShield = util.StrPtr("myoriginshield")
+	ds.ProfileID = util.IntPtr(49)
+	ds.Protocol = util.IntPtr(0)
+	ds.AnonymousBlockingEnabled = util.BoolPtr(false)
+	ds.Active = util.BoolPtr(true)
+	dses := []DeliveryService{ds}
+
+	dss := []DeliveryServiceServer{
+		DeliveryServiceServer{
+			Server:          *server.ID,
+			DeliveryService: *ds.ID,
+		},
+	}
+
+	dsRegexes := []tc.DeliveryServiceRegexes{
+		tc.DeliveryServiceRegexes{
+			DSName: *ds.XMLID,
+			Regexes: []tc.DeliveryServiceRegex{
+				tc.DeliveryServiceRegex{
+					Type:      string(tc.DSMatchTypeHostRegex),
+					SetNumber: 0,
+					Pattern:   "myregexpattern",
+				},
+			},
+		},
+	}
+
+	serverParams := []tc.Parameter{
+		tc.Parameter{
+			Name:       "trafficserver",
+			ConfigFile: "package",
+			Value:      "7",
+			Profiles:   []byte(`["global"]`),
+		},
+	}
+
+	remapConfigParams := []tc.Parameter{
+		tc.Parameter{
+			Name:       "cachekey.pparam",
+			ConfigFile: "remap.config",
+			Value:      "--cachekeykey=cachekeyval",
+			Profiles:   []byte(`["dsprofile"]`),
+		},
+		tc.Parameter{
+			Name:       "not_location",
+			ConfigFile: "cachekey.config",
+			Value:      "notinconfig",
+			Profiles:   []byte(`["global"]`),
+		},
+	}
+
+	cdn := &tc.CDN{
+		DomainName: "cdndomain.example",
+		Name:       "my-cdn-name",
+	}
+
+	topologies := []tc.Topology{}
+	cgs := []tc.CacheGroupNullable{}
+	serverCapabilities := map[int]map[ServerCapability]struct{}{}
+	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
+	configDir := `/opt/trafficserver/etc/trafficserver`
+
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txt := cfg.Text
+
+	t.Logf("text: %v", txt)
+
+	txt = strings.TrimSpace(txt)
+
+	testComment(t, txt, hdr)
+
+	txtLines := strings.Split(txt, "\n")
+
+	if len(txtLines) != 4 {
+		t.Log(cfg.Warnings)
+		t.Fatalf("expected one line for each remap plus a comment and blank, actual: '%v' count %v", txt, len(txtLines))
+	}
+
+	httpLine, wsLine := txtLines[2], txtLines[3]
+	if strings.HasPrefix(httpLine, "map\tws://") {
+		httpLine, wsLine = wsLine, httpLine
+	}
+
+	if !strings.HasPrefix(wsLine, "map\tws://myregexpattern:12080/") || !strings.Contains(wsLine, " wss://origin.example.test/") {
+		t.Errorf("expected a WebSocket remap line to the origin, actual '%v'", txt)
+	}
+	if !strings.Contains(wsLine, "@plugin=conf_remap.so @pparam=proxy.config.websocket.no_activity_timeout=30 @pparam=proxy.config.websocket.active_timeout="+strconv.Itoa(tc.DefaultStreamActiveTimeout)) {
+		t.Errorf("expected the WebSocket remap line to set the stream timeouts, actual '%v'", wsLine)
+	}
+	if strings.Contains(wsLine, "compress.so") {
+		t.Errorf("expected the WebSocket remap line not to compress responses, actual '%v'", wsLine)
+	}
+	if strings.Contains(httpLine, "websocket") || !strings.Contains(httpLine, "compress.so") {
+		t.Errorf("expected the HTTP remap line to be unchanged, actual '%v'", httpLine)
+	}
+
+	dses[0].WebSocket = util.BoolPtr(false)
+	cfg, err = MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(cfg.Text, "ws://") {
+		t.Errorf("expected no WebSocket remap lines for a Delivery Service that doesn't pass them through, actual '%v'", cfg.Text)
+	}
+}
+
+func TestMakeRemapDotConfigGRPC(t *testing.T) {
+	hdr := "myHeaderComment"
+
+	server := makeTestRemapServer()
+	server.Type = "EDGE"
+
+	ds := DeliveryService{}
+	ds.ID = util.IntPtr(48)
+	dsType := tc.DSType("HTTP_LIVE")
+	ds.Type = &dsType
+	ds.OrgServerFQDN = util.StrPtr("https://origin.example.test")
+	ds.GRPC = util.BoolPtr(true)
+	ds.StreamActiveTimeout = util.IntPtr(600)
+	ds.MidHeaderRewrite = util.StrPtr("mymidrewrite")
+	ds.RangeRequestHandling = util.IntPtr(0)
+	ds.RemapText = util.StrPtr("myremaptext")
+	ds.EdgeHeaderRewrite = util.StrPtr("myedgeheaderrewrite")
+	ds.SigningAlgorithm = util.StrPtr("url_sig")
+	ds.XMLID = util.StrPtr("mydsname")
+	ds.QStringIgnore = util.IntPtr(0)
+	ds.RegexRemap = util.StrPtr("myregexremap")
+	ds.FQPacingRate = util.IntPtr(0)
+	ds.DSCP = util.IntPtr(0)
+	ds.RoutingName = util.StrPtr("myroutingname")
+	ds.MultiSiteOrigin = util.BoolPtr(false)
+	ds.OriginShield = util.StrPtr("myoriginshield")
+	ds.ProfileID = util.IntPtr(49)
+	ds.Protocol = util.IntPtr(1)
+	ds.AnonymousBlockingEnabled = util.BoolPtr(false)
+	ds.Active = util.BoolPtr(true)
+	dses := []DeliveryService{ds}
+
+	dss := []DeliveryServiceServer{
+		DeliveryServiceServer{
+			Server:          *server.ID,
+			DeliveryService: *ds.ID,
+		},
+	}
+
+	dsRegexes := []tc.DeliveryServiceRegexes{
+		tc.DeliveryServiceRegexes{
+			DSName: *ds.XMLID,
+			Regexes: []tc.DeliveryServiceRegex{
+				tc.DeliveryServiceRegex{
+					Type:      string(tc.DSMatchTypeHostRegex),
+					SetNumber: 0,
+					Pattern:   "myregexpattern",
+				},
+			},
+		},
+	}
+
+	serverParams := []tc.Parameter{
+		tc.Parameter{
+			Name:       "trafficserver",
+			ConfigFile: "package",
+			Value:      "10",
+			Profiles:   []byte(`["global"]`),
+		},
+	}
+
+	remapConfigParams := []tc.Parameter{
+		tc.Parameter{
+			Name:       "cachekey.pparam",
+			ConfigFile: "remap.config",
+			Value:      "--cachekeykey=cachekeyval",
+			Profiles:   []byte(`["dsprofile"]`),
+		},
+		tc.Parameter{
+			Name:       "not_location",
+			ConfigFile: "cachekey.config",
+			Value:      "notinconfig",
+			Profiles:   []byte(`["global"]`),
+		},
+	}
+
+	cdn := &tc.CDN{
+		DomainName: "cdndomain.example",
+		Name:       "my-cdn-name",
+	}
+
+	topologies := []tc.Topology{}
+	cgs := []tc.CacheGroupNullable{}
+	serverCapabilities := map[int]map[ServerCapability]struct{}{}
+	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
+	configDir := `/opt/trafficserver/etc/trafficserver`
+
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txt := cfg.Text
+
+	t.Logf("text: %v", txt)
+
+	txt = strings.TrimSpace(txt)
+
+	testComment(t, txt, hdr)
+
+	txtLines := strings.Split(txt, "\n")
+
+	if len(txtLines) != 3 {
+		t.Log(cfg.Warnings)
+		t.Fatalf("expected one line for each remap plus a comment and blank, actual: '%v' count %v", txt, len(txtLines))
+	}
+
+	remapLine := txtLines[2]
+
+	idle := strconv.Itoa(tc.DefaultStreamIdleTimeout)
+	expected := "@plugin=conf_remap.so" +
+		" @pparam=proxy.config.http.transaction_no_activity_timeout_in=" + idle +
+		" @pparam=proxy.config.http.transaction_no_activity_timeout_out=" + idle +
+		" @pparam=proxy.config.http.transaction_active_timeout_in=600" +
+		" @pparam=proxy.config.http.transaction_active_timeout_out=600" +
+		" @pparam=proxy.config.ssl.client.alpn_protocols=h2,http/1.1"
+	if !strings.Contains(remapLine, expected) {
+		t.Errorf("expected gRPC stream timeouts and HTTP/2 to the origin, actual '%v'", remapLine)
+	}
+
+	serverParams[0].Value = "9"
+	cfg, err = MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(cfg.Text, "alpn_protocols") {
+		t.Errorf("expected no HTTP/2 to the origin before ATS 10, actual '%v'", cfg.Text)
+	}
+	if len(cfg.Warnings) == 0 {
+		t.Error("expected a warning about gRPC before ATS 10, actual none")
+	}
+}
+
 func TestMakeRemapDotConfigMidLiveLocalExcluded(t *testing.T) {
 	hdr := "myHeaderComment"
 
@@ -246,7 +761,7 @@ func TestMakeRemapDotConfigMidLiveLocalExcluded(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -347,7 +862,7 @@ func TestMakeRemapDotConfigMid(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -462,7 +977,7 @@ func TestMakeRemapDotConfigNilOrigin(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -563,7 +1078,7 @@ func TestMakeRemapDotConfigEmptyOrigin(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -702,7 +1217,7 @@ func TestMakeRemapDotConfigDuplicateOrigins(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -802,7 +1317,7 @@ func TestMakeRemapDotConfigNilMidRewrite(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -904,7 +1419,7 @@ func TestMakeRemapDotConfigMidHasNoEdgeRewrite(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1019,7 +1534,7 @@ func TestMakeRemapDotConfigMidProfileCacheKey(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1170,7 +1685,7 @@ func TestMakeRemapDotConfigMidBgFetchHandling(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1287,7 +1802,7 @@ func TestMakeRemapDotConfigMidRangeRequestHandling(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1428,7 +1943,7 @@ func TestMakeRemapDotConfigMidSlicePluginRangeRequestHandling(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1570,7 +2085,7 @@ func TestMakeRemapDotConfigAnyMap(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -1968,7 +2483,7 @@ func TestMakeRemapDotConfigEdgeMissingRemapData(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2073,7 +2588,7 @@ func TestMakeRemapDotConfigEdgeHostRegexReplacement(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2195,7 +2710,7 @@ func TestMakeRemapDotConfigEdgeHostRegexReplacementHTTP(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2317,7 +2832,7 @@ func TestMakeRemapDotConfigEdgeHostRegexReplacementHTTPS(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2439,7 +2954,7 @@ func TestMakeRemapDotConfigEdgeHostRegexReplacementHTTPToHTTPS(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2561,7 +3076,7 @@ func TestMakeRemapDotConfigEdgeRemapUnderscoreHTTPReplace(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2685,7 +3200,7 @@ func TestMakeRemapDotConfigEdgeDSCPRemap(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2809,7 +3324,7 @@ func TestMakeRemapDotConfigEdgeNoDSCPRemap(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -2933,7 +3448,7 @@ func TestMakeRemapDotConfigEdgeHeaderRewrite(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3061,7 +3576,7 @@ func TestMakeRemapDotConfigEdgeHeaderRewriteEmpty(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3189,7 +3704,7 @@ func TestMakeRemapDotConfigEdgeHeaderRewriteNil(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3323,7 +3838,7 @@ func TestMakeRemapDotConfigEdgeSigningURLSig(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3451,7 +3966,7 @@ func TestMakeRemapDotConfigEdgeSigningURISigning(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3574,7 +4089,7 @@ func TestMakeRemapDotConfigEdgeSigningNone(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3697,7 +4212,7 @@ func TestMakeRemapDotConfigEdgeSigningEmpty(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3820,7 +4335,7 @@ func TestMakeRemapDotConfigEdgeSigningWrong(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -3943,7 +4458,7 @@ func TestMakeRemapDotConfigEdgeQStringDropAtEdge(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -4064,7 +4579,7 @@ func TestMakeRemapDotConfigEdgeQStringIgnorePassUp(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -4200,7 +4715,7 @@ func TestMakeRemapDotConfigEdgeQStringIgnorePassUpWithCacheKeyParameter(t *testi
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -4328,7 +4843,7 @@ func TestMakeRemapDotConfigEdgeQStringIgnorePassUpCacheURLParamCacheURL(t *testi
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -4464,7 +4979,7 @@ func TestMakeRemapDotConfigEdgeCacheKeyParams(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -4591,7 +5106,7 @@ func TestMakeRemapDotConfigEdgeRegexRemap(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -4715,7 +5230,7 @@ func TestMakeRemapDotConfigEdgeRegexRemapEmpty(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -4835,7 +5350,7 @@ func TestMakeRemapDotConfigEdgeRangeRequestNil(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -4975,7 +5490,7 @@ func TestMakeRemapDotConfigEdgeRangeRequestDontCache(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -5119,7 +5634,7 @@ func TestMakeRemapDotConfigEdgeRangeRequestBGFetch(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -5253,7 +5768,7 @@ func TestMakeRemapDotConfigEdgeRangeRequestSlice(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -5388,7 +5903,7 @@ func TestMakeRemapDotConfigMidRangeRequestSlicePparam(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -5533,7 +6048,7 @@ func TestMakeRemapDotConfigEdgeRangeRequestSlicePparam(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -5674,7 +6189,7 @@ func TestMakeRemapDotConfigRawRemapRangeDirective(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -5819,7 +6334,7 @@ func TestMakeRemapDotConfigRawRemapCachekeyDirective(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -5952,7 +6467,7 @@ func TestMakeRemapDotConfigRawRemapRegexRemapDirective(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -6086,7 +6601,7 @@ func TestMakeRemapDotConfigRawRemapWithoutRangeDirective(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -6225,7 +6740,7 @@ func TestMakeRemapDotConfigEdgeRangeRequestCache(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -6353,7 +6868,7 @@ func TestMakeRemapDotConfigEdgeFQPacingNil(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -6473,7 +6988,7 @@ func TestMakeRemapDotConfigEdgeFQPacingNegative(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -6593,7 +7108,7 @@ func TestMakeRemapDotConfigEdgeFQPacingZero(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -6713,7 +7228,7 @@ func TestMakeRemapDotConfigEdgeFQPacingPositive(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -6837,7 +7352,7 @@ func TestMakeRemapDotConfigEdgeDNS(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -6957,7 +7472,7 @@ func TestMakeRemapDotConfigEdgeDNSNoRoutingName(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -7067,7 +7582,7 @@ func TestMakeRemapDotConfigEdgeRegexTypeNil(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -7182,7 +7697,7 @@ func TestMakeRemapDotConfigNoHeaderRewrite(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -7302,7 +7817,7 @@ func TestMakeRemapDotConfigMidNoHeaderRewrite(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -7422,7 +7937,7 @@ func TestMakeRemapDotConfigMidNoNoCacheRemapLine(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -7538,7 +8053,7 @@ func TestMakeRemapDotConfigEdgeHTTPOriginHTTPRemap(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -7666,7 +8181,7 @@ func TestMakeRemapDotConfigEdgeHTTPSOriginHTTPRemap(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -7798,7 +8313,7 @@ func TestMakeRemapDotConfigMidHTTPSOriginHTTPRemap(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -7971,7 +8486,7 @@ func TestMakeRemapDotConfigEdgeHTTPSOriginHTTPRemapTopology(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -8156,7 +8671,7 @@ func TestMakeRemapDotConfigMidHTTPSOriginHTTPRemapTopology(t *testing.T) {
 	dsRequiredCapabilities := map[int]map[ServerCapability]struct{}{}
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -8354,7 +8869,7 @@ func TestMakeRemapDotConfigMidLastRawRemap(t *testing.T) {
 
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, &RemapDotConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -8572,7 +9087,7 @@ func TestMakeRemapDotConfigStrategies(t *testing.T) {
 
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, opt)
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, opt)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -8762,7 +9277,7 @@ func TestMakeRemapDotConfigStrategiesFalseButCoreUnused(t *testing.T) {
 
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, opt)
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, opt)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -8961,7 +9476,7 @@ func TestMakeRemapDotConfigMidCacheParentHTTPSOrigin(t *testing.T) {
 
 	configDir := `/opt/trafficserver/etc/trafficserver`
 
-	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, opt)
+	cfg, err := MakeRemapDotConfig(server, dses, dss, dsRegexes, nil, serverParams, cdn, remapConfigParams, topologies, cgs, serverCapabilities, dsRequiredCapabilities, configDir, opt)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,131 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// ApplyRewriteRules compiles the given Delivery Service rewrite rules into
+// the regex_remap and header rewrite text of their Delivery Services, and
+// returns the resulting Delivery Services, and any warnings. The given
+// deliveryServices are not modified.
+//
+// Terminal rules - redirects, and internal rules with the 'last' flag - are
+// compiled into regex_remap lines, which are applied in order until one
+// matches. They are placed before the Delivery Service's own RegexRemap.
+//
+// Other internal rules are compiled into header_rewrite rules on the
+// edge tier, which are all applied in order before regex_remap. They are
+// placed before the Delivery Service's own EdgeHeaderRewrite, or its
+// FirstHeaderRewrite if it uses a Topology.
+//
+// This must be called before generating any config file, because it changes
+// which config files are needed.
+func ApplyRewriteRules(deliveryServices []DeliveryService, rewriteRules []tc.DeliveryServiceRewriteRules) ([]DeliveryService, []string) {
+	warnings := []string{}
+	if len(rewriteRules) == 0 {
+		return deliveryServices, warnings
+	}
+
+	dsRules := map[int][]tc.DeliveryServiceRewriteRule{}
+	for _, rules := range rewriteRules {
+		dsRules[rules.DeliveryServiceID] = rules.Rules
+	}
+
+	dses := make([]DeliveryService, 0, len(deliveryServices))
+	for _, ds := range deliveryServices {
+		if ds.ID == nil || ds.XMLID == nil {
+			dses = append(dses, ds)
+			continue
+		}
+		rules, ok := dsRules[*ds.ID]
+		if !ok || len(rules) == 0 {
+			dses = append(dses, ds)
+			continue
+		}
+
+		regexRemap, hdrRw, ruleWarns := compileRewriteRules(*ds.XMLID, rules)
+		warnings = append(warnings, ruleWarns...)
+
+		if regexRemap != "" {
+			if ds.RegexRemap != nil && *ds.RegexRemap != "" {
+				regexRemap += "\n" + *ds.RegexRemap
+			}
+			ds.RegexRemap = &regexRemap
+		}
+		if hdrRw != "" {
+			if ds.Topology != nil && *ds.Topology != "" {
+				ds.FirstHeaderRewrite = prependHeaderRewrite(hdrRw, ds.FirstHeaderRewrite)
+			} else {
+				ds.EdgeHeaderRewrite = prependHeaderRewrite(hdrRw, ds.EdgeHeaderRewrite)
+			}
+		}
+		dses = append(dses, ds)
+	}
+	return dses, warnings
+}
+
+// compileRewriteRules returns the regex_remap text and header_rewrite text of
+// the given rules of the Delivery Service named dsName, and any warnings.
+// Invalid rules are skipped with a warning.
+func compileRewriteRules(dsName string, rules []tc.DeliveryServiceRewriteRule) (string, string, []string) {
+	warnings := []string{}
+	regexRemapLines := []string{}
+	hdrRwRules := []string{}
+	sawTerminal := false
+	for i, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			warnings = append(warnings, "delivery service '"+dsName+"' rewrite rule "+strconv.Itoa(i)+" is invalid, skipping: "+err.Error())
+			continue
+		}
+
+		switch {
+		case rule.HasFlag(tc.RewriteRuleFlagRedirect):
+			regexRemapLines = append(regexRemapLines, rule.Match+" "+rule.Replace+" @status=302")
+		case rule.IsTerminal():
+			regexRemapLines = append(regexRemapLines, rule.Match+" $s://$t"+rule.Replace)
+		default:
+			if sawTerminal {
+				warnings = append(warnings, "delivery service '"+dsName+"' rewrite rule "+strconv.Itoa(i)+" has no 'last' flag but follows a rule which does; it will be applied first")
+			}
+			// header_rewrite paths have no leading '/'.
+			hdrRwRules = append(hdrRwRules,
+				"cond %{CLIENT-URL:PATH} /^"+strings.TrimPrefix(rule.Match, "^/")+"/\n"+
+					"set-destination PATH "+strings.TrimPrefix(rule.Replace, "/"))
+		}
+		if rule.IsTerminal() {
+			sawTerminal = true
+		}
+	}
+	return strings.Join(regexRemapLines, "\n"), strings.Join(hdrRwRules, "\n\n"), warnings
+}
+
+// prependHeaderRewrite returns a pointer to the header rewrite text hdrRw,
+// followed by the existing header rewrite text, if any.
+func prependHeaderRewrite(hdrRw string, existing *string) *string {
+	if existing != nil && *existing != "" {
+		hdrRw += "\n\n" + *existing
+	}
+	return &hdrRw
+}
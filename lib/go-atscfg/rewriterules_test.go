@@ -0,0 +1,113 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestApplyRewriteRules(t *testing.T) {
+	ds := DeliveryService{}
+	ds.ID = util.IntPtr(42)
+	ds.XMLID = util.StrPtr("myds")
+	ds.RegexRemap = util.StrPtr(`^/legacy $s://$t/new`)
+	ds.EdgeHeaderRewrite = util.StrPtr(`set-header X-Foo bar`)
+
+	other := DeliveryService{}
+	other.ID = util.IntPtr(43)
+	other.XMLID = util.StrPtr("otherds")
+
+	rules := []tc.DeliveryServiceRewriteRules{
+		{
+			DeliveryServiceID: 42,
+			XMLID:             "myds",
+			Rules: []tc.DeliveryServiceRewriteRule{
+				{Match: `^/old/`, Replace: `/new/`, Flags: []string{tc.RewriteRuleFlagInternal}},
+				{Match: `^/go/(.*)`, Replace: `https://example.net/$1`, Flags: []string{tc.RewriteRuleFlagRedirect}},
+				{Match: `^/img/(.*)`, Replace: `/images/$1`, Flags: []string{tc.RewriteRuleFlagInternal, tc.RewriteRuleFlagLast}},
+				{Match: `^/bad/`, Replace: `relative`, Flags: []string{tc.RewriteRuleFlagInternal}},
+			},
+		},
+	}
+
+	dses, warnings := ApplyRewriteRules([]DeliveryService{ds, other}, rules)
+	if len(warnings) != 1 {
+		t.Errorf("expected 1 warning for the invalid rule, actual: %+v", warnings)
+	}
+	if len(dses) != 2 {
+		t.Fatalf("expected 2 delivery services, actual: %d", len(dses))
+	}
+
+	expectedRegexRemap := "^/go/(.*) https://example.net/$1 @status=302\n" +
+		"^/img/(.*) $s://$t/images/$1\n" +
+		"^/legacy $s://$t/new"
+	if dses[0].RegexRemap == nil || *dses[0].RegexRemap != expectedRegexRemap {
+		t.Errorf("expected regex remap '%s', actual: %+v", expectedRegexRemap, dses[0].RegexRemap)
+	}
+
+	expectedHdrRw := "cond %{CLIENT-URL:PATH} /^old//\n" +
+		"set-destination PATH new/\n" +
+		"\n" +
+		"set-header X-Foo bar"
+	if dses[0].EdgeHeaderRewrite == nil || *dses[0].EdgeHeaderRewrite != expectedHdrRw {
+		t.Errorf("expected edge header rewrite '%s', actual: %+v", expectedHdrRw, dses[0].EdgeHeaderRewrite)
+	}
+
+	if *ds.RegexRemap != `^/legacy $s://$t/new` || *ds.EdgeHeaderRewrite != `set-header X-Foo bar` {
+		t.Error("expected the given delivery service not to be modified")
+	}
+	if dses[1].RegexRemap != nil || dses[1].EdgeHeaderRewrite != nil {
+		t.Errorf("expected delivery service without rules to be unchanged, actual: %+v", dses[1])
+	}
+}
+
+func TestApplyRewriteRulesTopology(t *testing.T) {
+	ds := DeliveryService{}
+	ds.ID = util.IntPtr(42)
+	ds.XMLID = util.StrPtr("myds")
+	ds.Topology = util.StrPtr("mytopology")
+
+	rules := []tc.DeliveryServiceRewriteRules{
+		{
+			DeliveryServiceID: 42,
+			Rules: []tc.DeliveryServiceRewriteRule{
+				{Match: `^/old/`, Replace: `/new/`, Flags: []string{tc.RewriteRuleFlagInternal}},
+			},
+		},
+	}
+
+	dses, warnings := ApplyRewriteRules([]DeliveryService{ds}, rules)
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, actual: %+v", warnings)
+	}
+	if dses[0].EdgeHeaderRewrite != nil {
+		t.Errorf("expected no edge header rewrite for a topology delivery service, actual: %s", *dses[0].EdgeHeaderRewrite)
+	}
+	if dses[0].FirstHeaderRewrite == nil || !strings.Contains(*dses[0].FirstHeaderRewrite, "set-destination PATH new/") {
+		t.Errorf("expected first header rewrite to contain the rule, actual: %+v", dses[0].FirstHeaderRewrite)
+	}
+	if dses[0].RegexRemap != nil {
+		t.Errorf("expected no regex remap without terminal rules, actual: %s", *dses[0].RegexRemap)
+	}
+}
@@ -32,6 +32,8 @@ const ServerCacheDotConfigIncludeInactiveDSes = false
 func makeCacheDotConfigMid(
 	server *Server,
 	deliveryServices []DeliveryService,
+	cachePolicies []tc.DeliveryServiceCachePolicy,
+	dsPathRules []tc.DeliveryServicePathRules,
 	opt *CacheDotConfigOpts,
 ) (Cfg, error) {
 	if opt == nil {
@@ -46,6 +48,9 @@ func makeCacheDotConfigMid(
 		return Cfg{}, makeErr(warnings, "server cache.config generation called for non-Mid server, this is a code error and should never happen! Please file a bug.")
 	}
 
+	dsPolicies := makeDSCachePolicyMap(cachePolicies)
+	dsRules := makeDSPathRuleMap(dsPathRules)
+
 	dses := map[tc.DeliveryServiceName]serverCacheConfigDS{}
 	for _, ds := range deliveryServices {
 		if ds.XMLID == nil || ds.Active == nil || ds.OrgServerFQDN == nil || ds.Type == nil {
@@ -56,7 +61,14 @@ func makeCacheDotConfigMid(
 		if !ServerCacheDotConfigIncludeInactiveDSes && !*ds.Active {
 			continue
 		}
-		dses[tc.DeliveryServiceName(*ds.XMLID)] = serverCacheConfigDS{OrgServerFQDN: *ds.OrgServerFQDN, Type: *ds.Type}
+		scds := serverCacheConfigDS{OrgServerFQDN: *ds.OrgServerFQDN, Type: *ds.Type}
+		if ds.ID != nil {
+			if policy, ok := dsPolicies[*ds.ID]; ok {
+				scds.CachePolicy = &policy
+			}
+			scds.PathRules = dsRules[*ds.ID]
+		}
+		dses[tc.DeliveryServiceName(*ds.XMLID)] = scds
 	}
 
 	text := makeHdrComment(opt.HdrComment)
@@ -64,20 +76,45 @@ func makeCacheDotConfigMid(
 	lines := []string{}
 
 	seenOrigins := map[string]struct{}{}
+	seenPolicyLines := map[string]struct{}{}
 	for _, ds := range dses {
-		if ds.Type != tc.DSTypeHTTPNoCache {
-			continue
+		for _, l := range makePathRuleCacheDotConfigLines(ds.OrgServerFQDN, ds.PathRules) {
+			if _, ok := seenPolicyLines[l]; ok {
+				continue
+			}
+			seenPolicyLines[l] = struct{}{}
+			lines = append(lines, l)
 		}
-		if _, ok := seenOrigins[ds.OrgServerFQDN]; ok {
+		if ds.Type != tc.DSTypeHTTPNoCache {
+			if ds.CachePolicy == nil {
+				continue
+			}
+			originFQDN, originPort := getOriginFQDNAndPort(ds.OrgServerFQDN)
+			portStr := ""
+			if originPort != nil {
+				portStr = strconv.Itoa(*originPort)
+			}
+			for _, l := range makeCachePolicyCacheDotConfigLines(*ds.CachePolicy, originFQDN, portStr) {
+				if _, ok := seenPolicyLines[l]; ok {
+					continue
+				}
+				seenPolicyLines[l] = struct{}{}
+				lines = append(lines, l)
+			}
 			continue
 		}
-		seenOrigins[ds.OrgServerFQDN] = struct{}{}
-
-		originFQDN, originPort := getOriginFQDNAndPort(ds.OrgServerFQDN)
-		if originPort != nil {
-			lines = append(lines, `dest_domain=`+originFQDN+` port=`+strconv.Itoa(*originPort)+` scheme=http action=never-cache`+"\n")
-		} else {
-			lines = append(lines, `dest_domain=`+originFQDN+` scheme=http action=never-cache`+"\n")
+		for _, origin := range append([]string{ds.OrgServerFQDN}, pathRuleOrigins(ds.OrgServerFQDN, ds.PathRules)...) {
+			if _, ok := seenOrigins[origin]; ok {
+				continue
+			}
+			seenOrigins[origin] = struct{}{}
+
+			originFQDN, originPort := getOriginFQDNAndPort(origin)
+			if originPort != nil {
+				lines = append(lines, `dest_domain=`+originFQDN+` port=`+strconv.Itoa(*originPort)+` scheme=http action=never-cache`+"\n")
+			} else {
+				lines = append(lines, `dest_domain=`+originFQDN+` scheme=http action=never-cache`+"\n")
+			}
 		}
 	}
 	sort.Strings(lines)
@@ -125,4 +162,6 @@ func getOriginFQDNAndPort(origin string) (string, *int) {
 type serverCacheConfigDS struct {
 	OrgServerFQDN string
 	Type          tc.DSType
+	CachePolicy   *tc.DeliveryServiceCachePolicy
+	PathRules     []tc.DeliveryServicePathRule
 }
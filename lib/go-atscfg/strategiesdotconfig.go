@@ -83,6 +83,7 @@ func MakeStrategiesDotYAML(
 		dsRequiredCapabilities,
 		cacheGroupArr,
 		dss,
+		nil, // path rules use the strategies of their Delivery Services
 		cdn,
 		&ParentConfigOpts{
 			AddComments:     opt.VerboseComments,
@@ -0,0 +1,126 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// webSocketEnabled returns whether or not cache servers pass WebSocket
+// connections for the given Delivery Service through to its origin.
+func webSocketEnabled(ds *DeliveryService) bool {
+	return tc.DeliveryServiceV40(*ds).WebSocketEnabled()
+}
+
+// grpcEnabled returns whether or not cache servers pass gRPC requests for the
+// given Delivery Service through to its origin.
+func grpcEnabled(ds *DeliveryService) bool {
+	return tc.DeliveryServiceV40(*ds).GRPCEnabled()
+}
+
+// isWebSocketURL returns whether or not the given remap URL has a WebSocket
+// scheme.
+func isWebSocketURL(u string) bool {
+	return strings.HasPrefix(u, "ws://") || strings.HasPrefix(u, "wss://")
+}
+
+// webSocketURL returns the given HTTP remap URL with the equivalent WebSocket
+// scheme.
+func webSocketURL(u string) string {
+	if strings.HasPrefix(u, "https://") {
+		return "wss://" + strings.TrimPrefix(u, "https://")
+	}
+	if strings.HasPrefix(u, "http://") {
+		return "ws://" + strings.TrimPrefix(u, "http://")
+	}
+	return u
+}
+
+// httpURL returns the given WebSocket remap URL with the equivalent HTTP
+// scheme, so it can be compared with Delivery Service origins.
+func httpURL(u string) string {
+	if strings.HasPrefix(u, "wss://") {
+		return "https://" + strings.TrimPrefix(u, "wss://")
+	}
+	if strings.HasPrefix(u, "ws://") {
+		return "http://" + strings.TrimPrefix(u, "ws://")
+	}
+	return u
+}
+
+// withWebSocketRemapLines returns the given remap lines of the given Delivery
+// Service, followed by a WebSocket copy of each if the Delivery Service passes
+// WebSocket connections through.
+//
+// ATS only tunnels WebSocket upgrade requests that match a remap rule with a
+// ws:// or wss:// scheme.
+func withWebSocketRemapLines(ds DeliveryService, lines []remapLine) []remapLine {
+	if !webSocketEnabled(&ds) {
+		return lines
+	}
+	all := make([]remapLine, 0, len(lines)*2)
+	all = append(all, lines...)
+	for _, line := range lines {
+		all = append(all, remapLine{
+			From:       webSocketURL(line.From),
+			To:         webSocketURL(line.To),
+			PathPrefix: line.PathPrefix,
+		})
+	}
+	return all
+}
+
+// streamRemapTxt returns the remap.config plugin text bounding the streams of
+// the given Delivery Service, for the remap rule from mapFrom to mapTo. This
+// is empty unless mapFrom is a WebSocket rule, or the Delivery Service passes
+// gRPC requests through.
+//
+// The timeouts of gRPC requests are the transaction timeouts of the rule, so
+// that long-lived calls aren't cut off by the global ones, and on ATS 10 and
+// later HTTPS origins are offered HTTP/2, which gRPC requires.
+func streamRemapTxt(ds *DeliveryService, mapFrom string, mapTo string, atsMajorVersion uint, warnings *[]string) string {
+	v40 := tc.DeliveryServiceV40(*ds)
+	idle := strconv.Itoa(v40.StreamIdleTimeoutSeconds())
+	active := strconv.Itoa(v40.StreamActiveTimeoutSeconds())
+	if isWebSocketURL(mapFrom) {
+		return ` @plugin=conf_remap.so` +
+			` @pparam=proxy.config.websocket.no_activity_timeout=` + idle +
+			` @pparam=proxy.config.websocket.active_timeout=` + active
+	}
+	if !grpcEnabled(ds) {
+		return ""
+	}
+	txt := ` @plugin=conf_remap.so` +
+		` @pparam=proxy.config.http.transaction_no_activity_timeout_in=` + idle +
+		` @pparam=proxy.config.http.transaction_no_activity_timeout_out=` + idle +
+		` @pparam=proxy.config.http.transaction_active_timeout_in=` + active +
+		` @pparam=proxy.config.http.transaction_active_timeout_out=` + active
+	if !strings.HasPrefix(mapTo, "https://") {
+		return txt
+	}
+	if atsMajorVersion < 10 {
+		*warnings = append(*warnings, "delivery service '"+*ds.XMLID+"' passes gRPC requests through, which requires HTTP/2 to origins, which requires ATS 10 or later; not offering HTTP/2 to its origin!")
+		return txt
+	}
+	return txt + ` @pparam=proxy.config.ssl.client.alpn_protocols=h2,http/1.1`
+}
@@ -0,0 +1,146 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"hash/fnv"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// TransformScriptFileName returns the name of the file, in the ATS config
+// directory, holding the given version of the transformation script with the
+// given name.
+func TransformScriptFileName(script string, version int) string {
+	return "transform_" + script + "_v" + strconv.Itoa(version) + ".lua"
+}
+
+// TransformScriptFile is the file of a version of a transformation script.
+type TransformScriptFile struct {
+	Script   string
+	Version  int
+	FileName string
+	Text     string
+}
+
+// ApplyTransformScripts adds the transformation scripts of the given Delivery
+// Services to their raw remap text, and returns the resulting Delivery
+// Services, the files of the script versions run by the server with the given
+// host name sorted by file name, and any warnings. The given deliveryServices
+// are not modified.
+//
+// Scripts are run by the ts_lua plugin, in order, before any of the Delivery
+// Service's own raw remap text. Wherever a script is being rolled out, the
+// server runs the rollout version if it's in the rollout percentage of cache
+// servers, per InTransformScriptRollout.
+//
+// This must be called before generating any config file, because it changes
+// the Delivery Services' remap text.
+func ApplyTransformScripts(hostName string, deliveryServices []DeliveryService, dsScripts []tc.DeliveryServiceTransformScripts, configDir string) ([]DeliveryService, []TransformScriptFile, []string) {
+	warnings := []string{}
+	files := []TransformScriptFile{}
+	if len(dsScripts) == 0 {
+		return deliveryServices, files, warnings
+	}
+
+	scriptsByDS := map[int][]tc.DeliveryServiceTransformScript{}
+	for _, scripts := range dsScripts {
+		scriptsByDS[scripts.DeliveryServiceID] = scripts.Scripts
+	}
+
+	fileNames := map[string]struct{}{}
+	dses := make([]DeliveryService, 0, len(deliveryServices))
+	for _, ds := range deliveryServices {
+		if ds.ID == nil || ds.XMLID == nil {
+			dses = append(dses, ds)
+			continue
+		}
+		scripts, ok := scriptsByDS[*ds.ID]
+		if !ok || len(scripts) == 0 {
+			dses = append(dses, ds)
+			continue
+		}
+		if ds.Type != nil && *ds.Type == tc.DSTypeAnyMap {
+			warnings = append(warnings, "delivery service '"+*ds.XMLID+"' is ANY_MAP, which can't have transformation scripts, skipping them")
+			dses = append(dses, ds)
+			continue
+		}
+
+		plugins := []string{}
+		for _, script := range scripts {
+			if script.Language != tc.TransformScriptLanguageLua {
+				warnings = append(warnings, "delivery service '"+*ds.XMLID+"' transformation script '"+script.Script+"' has unsupported language '"+script.Language+"', skipping")
+				continue
+			}
+			version, content := script.Version, script.Content
+			if script.RolloutVersion != nil && InTransformScriptRollout(hostName, *ds.XMLID, script.Script, script.RolloutPercent) {
+				version, content = *script.RolloutVersion, script.RolloutContent
+			}
+			if content == "" {
+				warnings = append(warnings, "delivery service '"+*ds.XMLID+"' transformation script '"+script.Script+"' version "+strconv.Itoa(version)+" has no content, skipping")
+				continue
+			}
+
+			fileName := TransformScriptFileName(script.Script, version)
+			plugins = append(plugins, "@plugin=tslua.so @pparam="+filepath.Join(configDir, fileName))
+			if _, ok := fileNames[fileName]; ok {
+				continue
+			}
+			fileNames[fileName] = struct{}{}
+			files = append(files, TransformScriptFile{
+				Script:   script.Script,
+				Version:  version,
+				FileName: fileName,
+				Text:     content,
+			})
+		}
+		if len(plugins) == 0 {
+			dses = append(dses, ds)
+			continue
+		}
+
+		remapText := strings.Join(plugins, " ")
+		if ds.RemapText != nil && *ds.RemapText != "" {
+			remapText += " " + *ds.RemapText
+		}
+		ds.RemapText = &remapText
+		dses = append(dses, ds)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].FileName < files[j].FileName })
+	return dses, files, warnings
+}
+
+// InTransformScriptRollout returns whether the cache server with the given
+// host name is one of the given percentage of cache servers which run the
+// rollout version of the given transformation script of the given Delivery
+// Service.
+//
+// Each server is placed in a fixed bucket from 0 to 99 for each script of each
+// Delivery Service, so raising the percentage only adds servers to the
+// rollout, and different scripts are rolled out to different servers.
+func InTransformScriptRollout(hostName string, dsName string, script string, percent int) bool {
+	h := fnv.New32a()
+	h.Write([]byte(hostName + "\x00" + dsName + "\x00" + script))
+	return int(h.Sum32()%100) < percent
+}
@@ -0,0 +1,122 @@
+package atscfg
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestApplyTransformScripts(t *testing.T) {
+	ds1 := makeGenericDS()
+	ds1.ID = util.IntPtr(1)
+	ds1.XMLID = util.StrPtr("ds1")
+	ds1.RemapText = util.StrPtr("@plugin=foo.so")
+	ds2 := makeGenericDS()
+	ds2.ID = util.IntPtr(2)
+	ds2.XMLID = util.StrPtr("ds2")
+	ds2.RemapText = nil
+	ds3 := makeGenericDS()
+	ds3.ID = util.IntPtr(3)
+	ds3.XMLID = util.StrPtr("ds3")
+	ds3.RemapText = nil
+
+	scripts := []tc.DeliveryServiceTransformScripts{
+		{
+			DeliveryServiceID: 1,
+			XMLID:             "ds1",
+			Scripts: []tc.DeliveryServiceTransformScript{
+				{Script: "cors", Language: tc.TransformScriptLanguageLua, Version: 2, Content: "-- cors v2"},
+				{Script: "auth", Language: tc.TransformScriptLanguageLua, Version: 1, RolloutVersion: util.IntPtr(2), RolloutPercent: 100, Content: "-- auth v1", RolloutContent: "-- auth v2"},
+			},
+		},
+		{
+			DeliveryServiceID: 2,
+			XMLID:             "ds2",
+			Scripts: []tc.DeliveryServiceTransformScript{
+				{Script: "cors", Language: tc.TransformScriptLanguageLua, Version: 2, Content: "-- cors v2"},
+			},
+		},
+	}
+
+	dses, files, warnings := ApplyTransformScripts("edge0", []DeliveryService{*ds1, *ds2, *ds3}, scripts, "/opt/trafficserver/etc/trafficserver")
+	if len(warnings) != 0 {
+		t.Errorf("expected no warnings, actual: %v", warnings)
+	}
+	if *ds1.RemapText != "@plugin=foo.so" {
+		t.Errorf("expected the given delivery services not to be modified, actual remap text: '%s'", *ds1.RemapText)
+	}
+
+	expected := "@plugin=tslua.so @pparam=/opt/trafficserver/etc/trafficserver/transform_cors_v2.lua @plugin=tslua.so @pparam=/opt/trafficserver/etc/trafficserver/transform_auth_v2.lua @plugin=foo.so"
+	if dses[0].RemapText == nil {
+		t.Errorf("expected ds1 remap text '%s', actual: nil", expected)
+	} else if *dses[0].RemapText != expected {
+		t.Errorf("expected ds1 remap text '%s', actual: '%s'", expected, *dses[0].RemapText)
+	}
+	if dses[1].RemapText == nil {
+		t.Error("expected ds2 remap text to run 'cors' version 2, actual: nil")
+	} else if !strings.HasSuffix(*dses[1].RemapText, "transform_cors_v2.lua") {
+		t.Errorf("expected ds2 remap text to run 'cors' version 2, actual: '%s'", *dses[1].RemapText)
+	}
+	if dses[2].RemapText != nil {
+		t.Errorf("expected ds3 without scripts to have no remap text, actual: '%s'", *dses[2].RemapText)
+	}
+
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files of distinct script versions, actual: %+v", files)
+	}
+	if files[0].FileName != "transform_auth_v2.lua" || files[0].Text != "-- auth v2" {
+		t.Errorf("expected file of rolled out 'auth' version 2, actual: %+v", files[0])
+	}
+	if files[1].FileName != "transform_cors_v2.lua" || files[1].Text != "-- cors v2" {
+		t.Errorf("expected file of 'cors' version 2, actual: %+v", files[1])
+	}
+}
+
+func TestInTransformScriptRollout(t *testing.T) {
+	const servers = 1000
+	for _, percent := range []int{0, 10, 50, 100} {
+		in := 0
+		for i := 0; i < servers; i++ {
+			if InTransformScriptRollout("edge"+strconv.Itoa(i), "ds1", "auth", percent) {
+				in++
+			}
+		}
+		if percent == 0 && in != 0 {
+			t.Errorf("expected no servers in a 0%% rollout, actual: %d", in)
+		} else if percent == 100 && in != servers {
+			t.Errorf("expected all servers in a 100%% rollout, actual: %d", in)
+		} else if diff := in - percent*servers/100; diff < -50 || diff > 50 {
+			t.Errorf("expected about %d%% of %d servers in the rollout, actual: %d", percent, servers, in)
+		}
+	}
+
+	// raising the percentage only adds servers
+	for i := 0; i < 100; i++ {
+		host := "edge" + strconv.Itoa(i)
+		if InTransformScriptRollout(host, "ds1", "auth", 10) && !InTransformScriptRollout(host, "ds1", "auth", 20) {
+			t.Errorf("expected server '%s' in a 10%% rollout to be in a 20%% rollout", host)
+		}
+	}
+}
@@ -20,6 +20,7 @@ package atscfg
  */
 
 import (
+	"regexp"
 	"sort"
 	"strings"
 
@@ -29,6 +30,10 @@ import (
 const ContentTypeURLSig = ContentTypeTextASCII
 const LineCommentURLSig = LineCommentHash
 
+// URLSigExcludeRegexParamName is the url_sig config setting of the regular
+// expression of URLs which don't need to be signed.
+const URLSigExcludeRegexParamName = "excl_regex"
+
 // URLSigConfigOpts contains settings to configure generation options.
 type URLSigConfigOpts struct {
 	// HdrComment is the header comment to include at the beginning of the file.
@@ -42,6 +47,7 @@ func MakeURLSigConfig(
 	server *Server,
 	serverParams []tc.Parameter,
 	allURLSigKeys map[tc.DeliveryServiceName]tc.URLSigKeys,
+	allTokenAuth []tc.DeliveryServiceTokenAuth,
 	opt *URLSigConfigOpts,
 ) (Cfg, error) {
 	if opt == nil {
@@ -67,6 +73,17 @@ func MakeURLSigConfig(
 		urlSigKeys = tc.URLSigKeys{}
 	}
 
+	for _, auth := range allTokenAuth {
+		if auth.XMLID != dsName || len(auth.ExemptPaths) == 0 {
+			continue
+		}
+		if _, ok := paramData[URLSigExcludeRegexParamName]; ok {
+			warnings = append(warnings, "ds '"+dsName+"' has token authentication exempt paths, overriding its '"+URLSigExcludeRegexParamName+"' Parameter")
+		}
+		paramData[URLSigExcludeRegexParamName] = makeURLSigExcludeRegex(auth.ExemptPaths)
+		break
+	}
+
 	hdr := makeHdrComment(opt.HdrComment)
 
 	sep := " = "
@@ -98,6 +115,16 @@ func MakeURLSigConfig(
 	}, nil
 }
 
+// makeURLSigExcludeRegex returns the url_sig excl_regex which exempts URLs
+// whose paths begin with any of the given path prefixes from signing.
+func makeURLSigExcludeRegex(exemptPaths []string) string {
+	quoted := make([]string, 0, len(exemptPaths))
+	for _, path := range exemptPaths {
+		quoted = append(quoted, regexp.QuoteMeta(path))
+	}
+	return `^https?://[^/]+(?:` + strings.Join(quoted, "|") + `)`
+}
+
 // getDSFromURLSigConfigFileName returns the DS of a URLSig config file name.
 // For example, "url_sig_foobar.config" returns "foobar".
 // If the given string is shorter than len("url_sig_a.config"), the empty string is returned.
@@ -46,7 +46,7 @@ func TestMakeURLSigConfig(t *testing.T) {
 
 	params := makeParamsFromMap(server.ProfileNames[0], fileName, paramData)
 
-	cfg, err := MakeURLSigConfig(fileName, server, params, allURLSigKeys, &URLSigConfigOpts{HdrComment: hdr})
+	cfg, err := MakeURLSigConfig(fileName, server, params, allURLSigKeys, nil, &URLSigConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -68,7 +68,7 @@ func TestMakeURLSigConfig(t *testing.T) {
 		"myds": urlSigKeys,
 	}
 
-	cfg, err = MakeURLSigConfig(fileName, server, params, allURLSigKeys, &URLSigConfigOpts{HdrComment: hdr})
+	cfg, err = MakeURLSigConfig(fileName, server, params, allURLSigKeys, nil, &URLSigConfigOpts{HdrComment: hdr})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -90,6 +90,41 @@ func TestMakeURLSigConfig(t *testing.T) {
 	}
 }
 
+func TestMakeURLSigConfigTokenAuth(t *testing.T) {
+	server := makeGenericServer()
+	server.ProfileNames = []string{"myProfile"}
+
+	fileName := "url_sig_myds.config"
+	params := makeParamsFromMap(server.ProfileNames[0], fileName, map[string]string{"excl_regex": "foo"})
+	allURLSigKeys := map[tc.DeliveryServiceName]tc.URLSigKeys{
+		"myds": {"key0": "secret"},
+	}
+	allTokenAuth := []tc.DeliveryServiceTokenAuth{
+		{XMLID: "otherds", ExemptPaths: []string{"/other"}},
+		{XMLID: "myds", ExemptPaths: []string{"/health", "/robots.txt"}},
+	}
+
+	cfg, err := MakeURLSigConfig(fileName, server, params, allURLSigKeys, allTokenAuth, &URLSigConfigOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txt := cfg.Text
+
+	expected := `excl_regex = ^https?://[^/]+(?:/health|/robots\.txt)` + "\n"
+	if !strings.Contains(txt, expected) {
+		t.Errorf("expected exempt paths line '%s', actual '%v'", expected, txt)
+	}
+	if strings.Contains(txt, "excl_regex = foo") || strings.Contains(txt, "/other") {
+		t.Errorf("expected only the exempt paths of 'myds' to be excluded, actual '%v'", txt)
+	}
+	if len(cfg.Warnings) != 1 {
+		t.Errorf("expected a warning about overriding the excl_regex Parameter, actual: %v", cfg.Warnings)
+	}
+	if !strings.Contains(txt, "key0 = secret") {
+		t.Errorf("expected url sig key 'key0 = secret', actual '%v'", txt)
+	}
+}
+
 func TestGetDSFromURLSigConfigFileName(t *testing.T) {
 	expecteds := map[string]string{
 		"url_sig_foo.config":                        "foo",
@@ -1,6 +1,8 @@
 package rfc
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"net/http"
 	"strconv"
@@ -32,9 +34,11 @@ const (
 	LastModified      = "Last-Modified"     // RFC7232§2.2
 	ETagHeader        = "ETag"
 	IfMatch           = "If-Match"
+	IfNoneMatch       = "If-None-Match"
 	IfUnmodifiedSince = "If-Unmodified-Since"
 	Date              = "Date"
 	ETagVersion       = 1
+	StrongETagVersion = 2
 )
 
 // ETag takes the last time the object was modified, and returns an ETag string. Note the string is the complete header value, including quotes. ETags must be quoted strings.
@@ -42,7 +46,39 @@ func ETag(t time.Time) string {
 	return `"v` + strconv.Itoa(ETagVersion) + `-` + strconv.FormatInt(t.UnixNano(), 36) + `"`
 }
 
+// StrongETag takes the last time any part of a representation was modified, and the representation itself, and returns a strong ETag string, which changes whenever the representation does. Like ETag, the string is the complete header value, including quotes.
+// The modification time is encoded in the ETag, so that ParseETag can check preconditions against it.
+func StrongETag(t time.Time, body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"v` + strconv.Itoa(StrongETagVersion) + `-` + strconv.FormatInt(t.UnixNano(), 36) + `-` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// ETagsMatch returns whether any of the ETags in the given If-None-Match header value matches etag, by the weak comparison of RFC7232§2.3.2.
+func ETagsMatch(ifNoneMatch string, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, et := range strings.Split(ifNoneMatch, ",") {
+		if et = strings.TrimPrefix(strings.TrimSpace(et), "W/"); et == etag || et == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// ETagsMatchStrong returns whether any of the ETags in the given If-Match header value matches etag, by the strong comparison of RFC7232§2.3.2, which If-Match requires: weak ETags never match.
+func ETagsMatchStrong(ifMatch string, etag string) bool {
+	if strings.HasPrefix(etag, "W/") {
+		return false
+	}
+	for _, et := range strings.Split(ifMatch, ",") {
+		if et = strings.TrimSpace(et); et == etag || et == "*" {
+			return true
+		}
+	}
+	return false
+}
+
 // ParseETag takes a complete ETag header string, including the quotes (if the client correctly set them), and returns the last modified time encoded in the ETag.
+// Both the ETags of ETag and StrongETag may be parsed.
 func ParseETag(e string) (time.Time, error) {
 	if len(e) < 2 || e[0] != '"' || e[len(e)-1] != '"' {
 		return time.Time{}, errors.New("unquoted string, value must be quoted")
@@ -53,12 +89,22 @@ func ParseETag(e string) (time.Time, error) {
 		return time.Time{}, err
 	}
 	prefix := `v` + strconv.Itoa(ETagVersion) + `-`
-	if len(e) < len(prefix) || !strings.HasPrefix(e, prefix) {
+	strongPrefix := `v` + strconv.Itoa(StrongETagVersion) + `-`
+	timeStr := ""
+	switch {
+	case strings.HasPrefix(e, prefix):
+		timeStr = e[len(prefix):]
+	case strings.HasPrefix(e, strongPrefix):
+		timeStr = e[len(strongPrefix):]
+		i := strings.Index(timeStr, "-")
+		if i < 0 {
+			return time.Time{}, errors.New("malformed, no hash")
+		}
+		timeStr = timeStr[:i]
+	default:
 		return time.Time{}, errors.New("malformed, no version prefix")
 	}
 
-	timeStr := e[len(prefix):]
-
 	i, err := strconv.ParseInt(timeStr, 36, 64)
 	if err != nil {
 		return time.Time{}, err
@@ -42,3 +42,65 @@ func TestETag(t *testing.T) {
 		t.Errorf("Expected time %v, actual %v", "2020-08-06 18:11:22.278418 +0000 UTC", ans.UTC().String())
 	}
 }
+
+func TestStrongETag(t *testing.T) {
+	updatedAt := time.Date(2020, 8, 6, 18, 11, 22, 278418000, time.UTC)
+	etag := StrongETag(updatedAt, []byte(`{"response":[]}`))
+	if etag != StrongETag(updatedAt, []byte(`{"response":[]}`)) {
+		t.Error("expected the same representation to have the same ETag")
+	}
+	if etag == StrongETag(updatedAt, []byte(`{"response":[{}]}`)) {
+		t.Error("expected a different representation with the same modification time to have a different ETag")
+	}
+	ans, err := ParseETag(etag)
+	if err != nil {
+		t.Fatalf("Expected no error parsing the ETag, but got %v", err)
+	}
+	if !ans.Equal(updatedAt) {
+		t.Errorf("Expected time %v, actual %v", updatedAt, ans)
+	}
+	if _, err := ParseETag(`"v2-c4q474ughgls"`); err == nil {
+		t.Error("Expected an error parsing a strong ETag without a hash")
+	}
+}
+
+func TestETagsMatch(t *testing.T) {
+	tests := []struct {
+		ifNoneMatch string
+		expected    bool
+	}{
+		{``, false},
+		{`"abc"`, true},
+		{`W/"abc"`, true},
+		{`"xyz", "abc"`, true},
+		{`"xyz"`, false},
+		{`*`, true},
+	}
+	for _, test := range tests {
+		if actual := ETagsMatch(test.ifNoneMatch, `"abc"`); actual != test.expected {
+			t.Errorf("If-None-Match '%s': expected %v, actual %v", test.ifNoneMatch, test.expected, actual)
+		}
+	}
+}
+
+func TestETagsMatchStrong(t *testing.T) {
+	tests := []struct {
+		ifMatch  string
+		etag     string
+		expected bool
+	}{
+		{``, `"abc"`, false},
+		{`"abc"`, `"abc"`, true},
+		{`W/"abc"`, `"abc"`, false},
+		{`"abc"`, `W/"abc"`, false},
+		{`"xyz", "abc"`, `"abc"`, true},
+		{`"xyz", W/"abc"`, `"abc"`, false},
+		{`"xyz"`, `"abc"`, false},
+		{`*`, `"abc"`, true},
+	}
+	for _, test := range tests {
+		if actual := ETagsMatchStrong(test.ifMatch, test.etag); actual != test.expected {
+			t.Errorf("If-Match '%s', ETag '%s': expected %v, actual %v", test.ifMatch, test.etag, test.expected, actual)
+		}
+	}
+}
@@ -21,6 +21,7 @@ package rfc
 
 import (
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -32,9 +33,11 @@ import (
 // caught at compile-time.
 const (
 	AcceptEncoding     = "Accept-Encoding"     // RFC7231§5.3.4
+	AcceptLanguage     = "Accept-Language"     // RFC7231§5.3.5
 	CacheControl       = "Cache-Control"       // RFC7234§5.2
 	ContentDisposition = "Content-Disposition" // RFC6266
 	ContentEncoding    = "Content-Encoding"    // RFC7231§3.1.2.2
+	ContentLanguage    = "Content-Language"    // RFC7231§3.1.3.2
 	ContentType        = "Content-Type"        // RFC7231§3.1.1.5
 	PermissionsPolicy  = "Permissions-Policy"  // W3C "Permissions Policy"
 	Server             = "Server"              // RFC7231§7.4.2
@@ -146,6 +149,71 @@ func AcceptsGzip(r *http.Request) bool {
 	return false
 }
 
+// AcceptedLanguages returns the language ranges of the Accept-Language headers
+// of the given request headers, per RFC7231§5.3.5, in lower case and in order
+// of preference - the highest quality first, and ranges of equal quality in
+// the order in which they appear. Ranges with a quality of zero, or which
+// aren't well-formed, are omitted.
+func AcceptedLanguages(h http.Header) []string {
+	type weightedRange struct {
+		lang    string
+		quality float64
+	}
+	ranges := []weightedRange{}
+	for _, hdr := range h[AcceptLanguage] {
+		for _, part := range strings.Split(util.StripAllWhitespace(hdr), ",") {
+			params := strings.Split(part, ";")
+			lang := strings.ToLower(params[0])
+			if !isLanguageRange(lang) {
+				continue
+			}
+			quality := 1.0
+			for _, param := range params[1:] {
+				if !strings.HasPrefix(param, "q=") {
+					continue
+				}
+				q, err := strconv.ParseFloat(param[len("q="):], 64)
+				if err != nil || q < 0 || q > 1 {
+					quality = 0
+				} else {
+					quality = q
+				}
+			}
+			if quality == 0 {
+				continue
+			}
+			ranges = append(ranges, weightedRange{lang: lang, quality: quality})
+		}
+	}
+	sort.SliceStable(ranges, func(i, j int) bool { return ranges[i].quality > ranges[j].quality })
+
+	langs := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		langs = append(langs, r.lang)
+	}
+	return langs
+}
+
+// isLanguageRange returns whether s is a lower-case language range, per
+// RFC4647§2.1: "*", or subtags of one to eight letters or - after the
+// first - digits, separated by hyphens.
+func isLanguageRange(s string) bool {
+	if s == "*" {
+		return true
+	}
+	for i, subtag := range strings.Split(s, "-") {
+		if len(subtag) < 1 || len(subtag) > 8 {
+			return false
+		}
+		for _, c := range subtag {
+			if (c < 'a' || c > 'z') && (i == 0 || c < '0' || c > '9') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // GetHTTPDate is a helper function which gets an HTTP date from the given map
 // (which is typically a `http.Header` or `CacheControl`.
 //
@@ -18,8 +18,12 @@ package rfc
  * specific language governing permissions and limitations
  * under the License.
  */
-import "fmt"
-import "net/http"
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
 
 func ExampleGetHTTPDate() {
 	hdrs := http.Header{}
@@ -47,3 +51,40 @@ func ExampleParseHTTPDate() {
 
 	// Output: 2020-06-30 19:07:15 +0000 GMT
 }
+
+func ExampleAcceptedLanguages() {
+	hdrs := http.Header{}
+	hdrs.Set(AcceptLanguage, "fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7, *;q=0.5")
+
+	fmt.Println(AcceptedLanguages(hdrs))
+	// Output: [fr-ch fr en de *]
+}
+
+func TestAcceptedLanguages(t *testing.T) {
+	tests := []struct {
+		name     string
+		headers  []string
+		expected []string
+	}{
+		{name: "none", headers: nil, expected: []string{}},
+		{name: "single", headers: []string{"pt-BR"}, expected: []string{"pt-br"}},
+		{name: "quality order", headers: []string{"en;q=0.1, ja;q=0.8, de"}, expected: []string{"de", "ja", "en"}},
+		{name: "equal quality keeps order", headers: []string{"es, it, nl"}, expected: []string{"es", "it", "nl"}},
+		{name: "multiple headers", headers: []string{"ko;q=0.5", "zh-Hant"}, expected: []string{"zh-hant", "ko"}},
+		{name: "zero quality omitted", headers: []string{"fr;q=0, en"}, expected: []string{"en"}},
+		{name: "invalid quality omitted", headers: []string{"fr;q=2, en;q=abc, de"}, expected: []string{"de"}},
+		{name: "malformed ranges omitted", headers: []string{"e_n, 1en, en-, toolongsubtag, sr-Latn-RS"}, expected: []string{"sr-latn-rs"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hdrs := http.Header{}
+			for _, h := range test.headers {
+				hdrs.Add(AcceptLanguage, h)
+			}
+			actual := AcceptedLanguages(hdrs)
+			if !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,389 @@
+package rfc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// These are the names of the HTTP headers of the WebSocket opening handshake.
+const (
+	SecWebSocketAccept  = "Sec-WebSocket-Accept"  // RFC6455§11.3.3
+	SecWebSocketKey     = "Sec-WebSocket-Key"     // RFC6455§11.3.1
+	SecWebSocketVersion = "Sec-WebSocket-Version" // RFC6455§11.3.5
+)
+
+// WebSocketGUID is concatenated with the key of a WebSocket opening handshake
+// to compute its accept value, as defined by RFC6455§1.3.
+const WebSocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WebSocketVersion is the only version of the WebSocket protocol, as defined
+// by RFC6455§4.1.
+const WebSocketVersion = "13"
+
+// These are the opcodes of WebSocket frames, as defined by RFC6455§5.2.
+const (
+	WebSocketOpContinuation = 0x0
+	WebSocketOpText         = 0x1
+	WebSocketOpBinary       = 0x2
+	WebSocketOpClose        = 0x8
+	WebSocketOpPing         = 0x9
+	WebSocketOpPong         = 0xA
+)
+
+// These are the status codes with which WebSocket connections are closed, as
+// defined by RFC6455§7.4.1.
+const (
+	WebSocketCloseNormal        = 1000
+	WebSocketCloseGoingAway     = 1001
+	WebSocketCloseProtocolError = 1002
+	WebSocketCloseNoStatus      = 1005
+	WebSocketClosePolicy        = 1008
+	WebSocketCloseTooBig        = 1009
+	WebSocketCloseInternalError = 1011
+)
+
+// MaxWebSocketMessageBytes is the largest message a WebSocketConn reads.
+const MaxWebSocketMessageBytes = 1 << 20
+
+// maxWebSocketControlBytes is the largest payload of a control frame, as
+// defined by RFC6455§5.5.
+const maxWebSocketControlBytes = 125
+
+// WebSocketCloseError is returned by WebSocketConn.ReadMessage when the peer
+// closes the connection.
+type WebSocketCloseError struct {
+	Code   int
+	Reason string
+}
+
+func (e *WebSocketCloseError) Error() string {
+	return fmt.Sprintf("websocket closed with status %d: %s", e.Code, e.Reason)
+}
+
+// WebSocketAccept returns the value of the Sec-WebSocket-Accept header of a
+// response to a WebSocket opening handshake with the given
+// Sec-WebSocket-Key.
+func WebSocketAccept(key string) string {
+	sum := sha1.Sum([]byte(key + WebSocketGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// NewWebSocketKey returns a new random Sec-WebSocket-Key, with which a client
+// opens a WebSocket connection.
+func NewWebSocketKey() (string, error) {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// hasToken returns whether the comma-separated header value contains the
+// token, case-insensitively.
+func hasToken(val string, token string) bool {
+	for _, t := range strings.Split(val, ",") {
+		if strings.EqualFold(strings.TrimSpace(t), token) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWebSocketUpgrade returns whether the request asks to open a WebSocket
+// connection.
+func IsWebSocketUpgrade(r *http.Request) bool {
+	return hasToken(r.Header.Get("Connection"), "upgrade") && strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
+
+// CheckWebSocketUpgrade returns an error describing why the request isn't a
+// valid WebSocket opening handshake, if it isn't one.
+func CheckWebSocketUpgrade(r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return errors.New("WebSocket connections must be opened with GET requests")
+	}
+	if !IsWebSocketUpgrade(r) {
+		return errors.New("this endpoint requires a WebSocket connection")
+	}
+	if r.Header.Get(SecWebSocketVersion) != WebSocketVersion {
+		return errors.New("unsupported WebSocket version, must be " + WebSocketVersion)
+	}
+	if key, err := base64.StdEncoding.DecodeString(r.Header.Get(SecWebSocketKey)); err != nil || len(key) != 16 {
+		return errors.New("malformed " + SecWebSocketKey + " header")
+	}
+	return nil
+}
+
+// UpgradeWebSocket completes the WebSocket opening handshake of the request,
+// which must be valid as determined by CheckWebSocketUpgrade, and returns
+// the connection. Nothing else may be written to w afterward.
+//
+// The connection's deadlines are cleared, so the server's timeouts no longer
+// apply to it.
+func UpgradeWebSocket(w http.ResponseWriter, r *http.Request) (*WebSocketConn, error) {
+	if err := CheckWebSocketUpgrade(r); err != nil {
+		return nil, err
+	}
+	hj, ok := w.(http.Hijacker)
+	if !ok {
+		return nil, errors.New("response writer does not support hijacking")
+	}
+	conn, brw, err := hj.Hijack()
+	if err != nil {
+		return nil, errors.New("hijacking connection: " + err.Error())
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, errors.New("clearing connection deadlines: " + err.Error())
+	}
+	resp := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		SecWebSocketAccept + ": " + WebSocketAccept(r.Header.Get(SecWebSocketKey)) + "\r\n\r\n"
+	if _, err := brw.WriteString(resp); err != nil {
+		conn.Close()
+		return nil, errors.New("writing handshake response: " + err.Error())
+	}
+	if err := brw.Flush(); err != nil {
+		conn.Close()
+		return nil, errors.New("writing handshake response: " + err.Error())
+	}
+	return &WebSocketConn{rwc: conn, br: brw.Reader}, nil
+}
+
+// NewWebSocketClientConn returns the client side of a WebSocket connection
+// whose opening handshake succeeded. As of Go 1.12, the Body of a
+// "101 Switching Protocols" response from an http.Client is such a
+// connection.
+func NewWebSocketClientConn(rwc io.ReadWriteCloser) *WebSocketConn {
+	return &WebSocketConn{rwc: rwc, br: bufio.NewReader(rwc), client: true}
+}
+
+// WebSocketConn is a WebSocket connection. Messages may be written
+// concurrently with each other and with reading, but only one goroutine may
+// read at a time.
+type WebSocketConn struct {
+	rwc    io.ReadWriteCloser
+	br     *bufio.Reader
+	client bool
+
+	wmu       sync.Mutex
+	closeSent bool
+}
+
+// WriteText writes a text message.
+func (c *WebSocketConn) WriteText(msg string) error {
+	return c.writeFrame(WebSocketOpText, []byte(msg))
+}
+
+// WriteMessage writes a message with the given opcode.
+func (c *WebSocketConn) WriteMessage(opcode byte, payload []byte) error {
+	return c.writeFrame(opcode, payload)
+}
+
+// Close sends a close frame with the given status code and reason, unless
+// one was already sent, and closes the underlying connection.
+func (c *WebSocketConn) Close(code int, reason string) error {
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, uint16(code))
+	payload = append(payload, reason...)
+	if len(payload) > maxWebSocketControlBytes {
+		payload = payload[:maxWebSocketControlBytes]
+	}
+	err := c.writeFrame(WebSocketOpClose, payload)
+	if closeErr := c.rwc.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (c *WebSocketConn) writeFrame(opcode byte, payload []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	if c.closeSent {
+		if opcode == WebSocketOpClose {
+			return nil
+		}
+		return errors.New("websocket connection is closed")
+	}
+	if opcode == WebSocketOpClose {
+		c.closeSent = true
+	}
+
+	hdr := make([]byte, 2, 14)
+	hdr[0] = 0x80 | opcode // FIN; messages are never fragmented.
+	switch n := len(payload); {
+	case n < 126:
+		hdr[1] = byte(n)
+	case n <= 0xFFFF:
+		hdr[1] = 126
+		hdr = append(hdr, 0, 0)
+		binary.BigEndian.PutUint16(hdr[2:], uint16(n))
+	default:
+		hdr[1] = 127
+		hdr = append(hdr, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(hdr[2:], uint64(n))
+	}
+	// Clients must mask the frames they send, and servers must not.
+	if c.client {
+		hdr[1] |= 0x80
+		key := make([]byte, 4)
+		if _, err := rand.Read(key); err != nil {
+			return errors.New("generating mask key: " + err.Error())
+		}
+		hdr = append(hdr, key...)
+		masked := make([]byte, len(payload))
+		for i := range payload {
+			masked[i] = payload[i] ^ key[i%4]
+		}
+		payload = masked
+	}
+	if _, err := c.rwc.Write(append(hdr, payload...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReadMessage reads the next text or binary message, answering pings and
+// ignoring pongs. If the peer closes the connection, the close is answered
+// and a *WebSocketCloseError is returned.
+func (c *WebSocketConn) ReadMessage() (byte, []byte, error) {
+	var msgOp byte
+	var msg []byte
+	for {
+		fin, opcode, payload, err := c.readFrame()
+		if err != nil {
+			return 0, nil, err
+		}
+		switch opcode {
+		case WebSocketOpPing:
+			if err := c.writeFrame(WebSocketOpPong, payload); err != nil {
+				return 0, nil, err
+			}
+			continue
+		case WebSocketOpPong:
+			continue
+		case WebSocketOpClose:
+			closeErr := &WebSocketCloseError{Code: WebSocketCloseNoStatus}
+			if len(payload) >= 2 {
+				closeErr.Code = int(binary.BigEndian.Uint16(payload))
+				closeErr.Reason = string(payload[2:])
+			}
+			code := closeErr.Code
+			if code == WebSocketCloseNoStatus {
+				code = WebSocketCloseNormal
+			}
+			c.Close(code, "")
+			return 0, nil, closeErr
+		case WebSocketOpContinuation:
+			if msgOp == 0 {
+				return 0, nil, c.fail(WebSocketCloseProtocolError, "unexpected continuation frame")
+			}
+		case WebSocketOpText, WebSocketOpBinary:
+			if msgOp != 0 {
+				return 0, nil, c.fail(WebSocketCloseProtocolError, "expected continuation frame")
+			}
+			msgOp = opcode
+		default:
+			return 0, nil, c.fail(WebSocketCloseProtocolError, fmt.Sprintf("unknown opcode %d", opcode))
+		}
+		if len(msg)+len(payload) > MaxWebSocketMessageBytes {
+			return 0, nil, c.fail(WebSocketCloseTooBig, "message too big")
+		}
+		msg = append(msg, payload...)
+		if fin {
+			return msgOp, msg, nil
+		}
+	}
+}
+
+// fail closes the connection because the peer violated the protocol, and
+// returns an error describing the violation.
+func (c *WebSocketConn) fail(code int, reason string) error {
+	c.Close(code, reason)
+	return errors.New("websocket protocol error: " + reason)
+}
+
+func (c *WebSocketConn) readFrame() (bool, byte, []byte, error) {
+	hdr := make([]byte, 2)
+	if _, err := io.ReadFull(c.br, hdr); err != nil {
+		return false, 0, nil, err
+	}
+	fin := hdr[0]&0x80 != 0
+	opcode := hdr[0] & 0x0F
+	if hdr[0]&0x70 != 0 {
+		return false, 0, nil, c.fail(WebSocketCloseProtocolError, "reserved bits set")
+	}
+	masked := hdr[1]&0x80 != 0
+	if masked == c.client {
+		return false, 0, nil, c.fail(WebSocketCloseProtocolError, "frames from clients must be masked, and frames from servers must not be")
+	}
+
+	n := uint64(hdr[1] & 0x7F)
+	switch n {
+	case 126:
+		ext := make([]byte, 2)
+		if _, err := io.ReadFull(c.br, ext); err != nil {
+			return false, 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext))
+	case 127:
+		ext := make([]byte, 8)
+		if _, err := io.ReadFull(c.br, ext); err != nil {
+			return false, 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(ext)
+	}
+	if opcode >= WebSocketOpClose && (!fin || n > maxWebSocketControlBytes) {
+		return false, 0, nil, c.fail(WebSocketCloseProtocolError, "control frames must not be fragmented or longer than 125 bytes")
+	}
+	if n > MaxWebSocketMessageBytes {
+		return false, 0, nil, c.fail(WebSocketCloseTooBig, "message too big")
+	}
+
+	var key []byte
+	if masked {
+		key = make([]byte, 4)
+		if _, err := io.ReadFull(c.br, key); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= key[i%4]
+		}
+	}
+	return fin, opcode, payload, nil
+}
@@ -0,0 +1,121 @@
+package rfc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebSocketAccept(t *testing.T) {
+	// the example of RFC6455§1.3
+	if accept := WebSocketAccept("dGhlIHNhbXBsZSBub25jZQ=="); accept != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" {
+		t.Errorf("expected accept 's3pPLMBiTxaQ9kYGzzhZRbK+xOo=', actual: '%s'", accept)
+	}
+}
+
+func TestCheckWebSocketUpgrade(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	if err := CheckWebSocketUpgrade(r); err == nil {
+		t.Error("expected an error checking a request without upgrade headers, got none")
+	}
+	r.Header.Set("Connection", "keep-alive, Upgrade")
+	r.Header.Set("Upgrade", "websocket")
+	r.Header.Set(SecWebSocketVersion, WebSocketVersion)
+	r.Header.Set(SecWebSocketKey, "not a key")
+	if err := CheckWebSocketUpgrade(r); err == nil {
+		t.Error("expected an error checking a request with a malformed key, got none")
+	}
+	r.Header.Set(SecWebSocketKey, "dGhlIHNhbXBsZSBub25jZQ==")
+	if err := CheckWebSocketUpgrade(r); err != nil {
+		t.Errorf("expected no error checking a valid request, actual: %v", err)
+	}
+}
+
+func TestWebSocketConn(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := UpgradeWebSocket(w, r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// echo messages, in upper case, until the client closes the
+		// connection.
+		for {
+			_, msg, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteText(strings.ToUpper(string(msg))); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	key, err := NewWebSocketKey()
+	if err != nil {
+		t.Fatalf("unexpected error generating key: %v", err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set(SecWebSocketVersion, WebSocketVersion)
+	req.Header.Set(SecWebSocketKey, key)
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error opening connection: %v", err)
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols || resp.Header.Get(SecWebSocketAccept) != WebSocketAccept(key) {
+		t.Fatalf("expected a 101 response accepting the key, actual: %s %s", resp.Status, resp.Header.Get(SecWebSocketAccept))
+	}
+	rwc, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok {
+		t.Fatal("expected the response body to be writable")
+	}
+	conn := NewWebSocketClientConn(rwc)
+
+	long := strings.Repeat("a", 70000) // uses the 64-bit length encoding
+	for _, msg := range []string{"hello", strings.Repeat("b", 200), long} {
+		if err := conn.WriteText(msg); err != nil {
+			t.Fatalf("unexpected error writing: %v", err)
+		}
+		op, echo, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("unexpected error reading: %v", err)
+		}
+		if op != WebSocketOpText || string(echo) != strings.ToUpper(msg) {
+			t.Errorf("expected text echo of %d bytes, actual: opcode %d, %d bytes", len(msg), op, len(echo))
+		}
+	}
+
+	if err := conn.WriteMessage(WebSocketOpClose, []byte{0x03, 0xE8}); err != nil {
+		t.Fatalf("unexpected error closing: %v", err)
+	}
+	_, _, err = conn.ReadMessage()
+	closeErr := &WebSocketCloseError{}
+	if !errors.As(err, &closeErr) || closeErr.Code != WebSocketCloseNormal {
+		t.Errorf("expected the server to answer the close with status %d, actual: %v", WebSocketCloseNormal, err)
+	}
+}
@@ -20,7 +20,12 @@ package tc
  */
 
 import (
+	"errors"
+	"net/http"
+	"regexp"
 	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
 )
 
 // Alert represents an informational message, typically returned through the Traffic Ops API.
@@ -30,6 +35,13 @@ type Alert struct {
 	// Level describes what kind of message is being relayed. In practice, it should be the string
 	// representation of one of ErrorLevel, WarningLevel, InfoLevel or SuccessLevel.
 	Level string `json:"level"`
+	// Code is a stable, machine-readable identifier of the reason for an
+	// error-level Alert, e.g. "staticdnsentry.address.invalid_ipv4" or
+	// "not_found", which - unlike the Text - may be relied upon by automation.
+	Code string `json:"code,omitempty"`
+	// Field is the path of the request field to which the Alert pertains, if
+	// any.
+	Field string `json:"field,omitempty"`
 }
 
 // Alerts is merely a collection of arbitrary "Alert"s for ease of use in other structures, most
@@ -44,18 +56,110 @@ func CreateErrorAlerts(errs ...error) Alerts {
 	alerts := []Alert{}
 	for _, err := range errs {
 		if err != nil {
-			alerts = append(alerts, Alert{err.Error(), ErrorLevel.String()})
+			alerts = append(alerts, Alert{Text: err.Error(), Level: ErrorLevel.String()})
 		}
 	}
 	return Alerts{alerts}
 }
 
+// alertCoder is an error that carries a machine-readable Alert code, like
+// the validation errors of the tovalidate package.
+type alertCoder interface {
+	AlertCode() string
+}
+
+// alertFielder is an error that pertains to a request field.
+type alertFielder interface {
+	AlertField() string
+}
+
+var nonAlertCodeChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// AlertCodeForStatus returns the generic Alert code of errors that cause
+// responses with the given HTTP status code, which is its status text in
+// "snake_case", e.g. "bad_request" or "internal_server_error".
+func AlertCodeForStatus(statusCode int) string {
+	text := http.StatusText(statusCode)
+	if text == "" {
+		return "error"
+	}
+	return strings.Trim(nonAlertCodeChars.ReplaceAllString(strings.ToLower(text), "_"), "_")
+}
+
+// CreateCodedErrorAlerts creates and returns an Alerts structure of
+// ErrorLevel-level "Alert"s for the given error, which caused a response with
+// the given HTTP status code.
+//
+// Each of the errors joined in err by util.JoinErrs gets its own Alert. Errors
+// that carry a machine-readable code - like the validation errors of the
+// tovalidate package - give it, and the field to which they pertain, to their
+// Alerts; all others get the generic code of the status code.
+func CreateCodedErrorAlerts(statusCode int, err error) Alerts {
+	if err == nil {
+		return Alerts{[]Alert{}}
+	}
+	var joined util.JoinedErrors
+	if !errors.As(err, &joined) {
+		return Alerts{[]Alert{codedErrorAlert(statusCode, "", err)}}
+	}
+	// Errors wrapping the joined errors, e.g. with fmt.Errorf("validating: %w"),
+	// lend their context to each of their Alerts.
+	prefix := ""
+	if msg := err.Error(); strings.HasSuffix(msg, joined.Error()) {
+		prefix = strings.TrimSuffix(msg, joined.Error())
+	}
+	alerts := make([]Alert, 0, len(joined.Errors()))
+	for _, e := range joined.Errors() {
+		alerts = append(alerts, codedErrorAlert(statusCode, prefix, e))
+	}
+	return Alerts{alerts}
+}
+
+func codedErrorAlert(statusCode int, prefix string, err error) Alert {
+	alert := Alert{
+		Text:  prefix + err.Error(),
+		Level: ErrorLevel.String(),
+		Code:  AlertCodeForStatus(statusCode),
+	}
+	var coder alertCoder
+	if errors.As(err, &coder) {
+		alert.Code = coder.AlertCode()
+	}
+	var fielder alertFielder
+	if errors.As(err, &fielder) {
+		alert.Field = fielder.AlertField()
+	}
+	return alert
+}
+
+// SetErrorCodes sets the Code of each ErrorLevel-level Alert that doesn't have
+// one to the generic code of the given HTTP status code.
+func (self *Alerts) SetErrorCodes(statusCode int) {
+	for i, alert := range self.Alerts {
+		if alert.Level == ErrorLevel.String() && alert.Code == "" {
+			self.Alerts[i].Code = AlertCodeForStatus(statusCode)
+		}
+	}
+}
+
+// ErrorCodes returns the Codes of all of the ErrorLevel-level Alerts that have
+// one.
+func (self Alerts) ErrorCodes() []string {
+	codes := []string{}
+	for _, a := range self.Alerts {
+		if a.Level == ErrorLevel.String() && a.Code != "" {
+			codes = append(codes, a.Code)
+		}
+	}
+	return codes
+}
+
 // CreateAlerts creates and returns an Alerts structure filled with "Alert"s that are all of the
 // provided level, each having one of messages as text in turn.
 func CreateAlerts(level AlertLevel, messages ...string) Alerts {
 	alerts := []Alert{}
 	for _, message := range messages {
-		alerts = append(alerts, Alert{message, level.String()})
+		alerts = append(alerts, Alert{Text: message, Level: level.String()})
 	}
 	return Alerts{alerts}
 }
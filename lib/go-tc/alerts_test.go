@@ -22,14 +22,21 @@ package tc
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"reflect"
 	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/go-ozzo/ozzo-validation/is"
 )
 
 func ExampleCreateErrorAlerts() {
 	alerts := CreateErrorAlerts(errors.New("foo"))
 	fmt.Printf("%v\n", alerts)
-	// Output: {[{foo error}]}
+	// Output: {[{foo error  }]}
 }
 
 func ExampleCreateAlerts() {
@@ -127,9 +134,71 @@ func TestCreateAlerts(t *testing.T) {
 		t.Errorf("Expected %v Got %v", expected, alerts)
 	}
 
-	expected = Alerts{[]Alert{{"message 1", WarnLevel.String()}, {"message 2", WarnLevel.String()}, {"message 3", WarnLevel.String()}}}
+	expected = Alerts{[]Alert{{Text: "message 1", Level: WarnLevel.String()}, {Text: "message 2", Level: WarnLevel.String()}, {Text: "message 3", Level: WarnLevel.String()}}}
 	alerts = CreateAlerts(WarnLevel, "message 1", "message 2", "message 3")
 	if !reflect.DeepEqual(expected, alerts) {
 		t.Errorf("Expected %v Got %v", expected, alerts)
 	}
 }
+
+func ExampleAlertCodeForStatus() {
+	fmt.Println(AlertCodeForStatus(http.StatusBadRequest))
+	fmt.Println(AlertCodeForStatus(http.StatusInternalServerError))
+	fmt.Println(AlertCodeForStatus(http.StatusTeapot))
+	// Output: bad_request
+	// internal_server_error
+	// i_m_a_teapot
+}
+
+func TestCreateCodedErrorAlerts(t *testing.T) {
+	alerts := CreateCodedErrorAlerts(http.StatusNotFound, errors.New("no such thing"))
+	expected := Alerts{[]Alert{{Text: "no such thing", Level: ErrorLevel.String(), Code: "not_found"}}}
+	if !reflect.DeepEqual(expected, alerts) {
+		t.Errorf("Expected %v Got %v", expected, alerts)
+	}
+
+	errs := tovalidate.ToErrors(validation.Errors{
+		"address": validation.Validate("not an ip", is.IPv4),
+	})
+	errs = append(errs, errors.New("something else"))
+	err := util.JoinErrs(errs)
+	tovalidate.SetObject(err, "staticdnsentry")
+
+	alerts = CreateCodedErrorAlerts(http.StatusBadRequest, fmt.Errorf("validating: %w", err))
+	expected = Alerts{[]Alert{
+		{
+			Text:  "validating: 'address' must be a valid IPv4 address",
+			Level: ErrorLevel.String(),
+			Code:  "staticdnsentry.address.invalid_ipv4",
+			Field: "address",
+		},
+		{
+			Text:  "validating: something else",
+			Level: ErrorLevel.String(),
+			Code:  "bad_request",
+		},
+	}}
+	if !reflect.DeepEqual(expected, alerts) {
+		t.Errorf("Expected %+v Got %+v", expected, alerts)
+	}
+	if codes := alerts.ErrorCodes(); len(codes) != 2 || codes[0] != "staticdnsentry.address.invalid_ipv4" {
+		t.Errorf("Expected the codes of both alerts, got: %v", codes)
+	}
+}
+
+func TestAlertsSetErrorCodes(t *testing.T) {
+	alerts := CreateAlerts(ErrorLevel, "bad")
+	alerts.AddAlert(Alert{Text: "coded", Level: ErrorLevel.String(), Code: "foo.bar"})
+	alerts.AddNewAlert(WarnLevel, "careful")
+	alerts.SetErrorCodes(http.StatusConflict)
+
+	if alerts.Alerts[0].Code != "conflict" {
+		t.Errorf("Expected an uncoded error-level alert to get the generic code 'conflict', got '%s'", alerts.Alerts[0].Code)
+	}
+	if alerts.Alerts[1].Code != "foo.bar" {
+		t.Errorf("Expected a coded error-level alert to keep its code 'foo.bar', got '%s'", alerts.Alerts[1].Code)
+	}
+	if alerts.Alerts[2].Code != "" {
+		t.Errorf("Expected a warning-level alert to get no code, got '%s'", alerts.Alerts[2].Code)
+	}
+}
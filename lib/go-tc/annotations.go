@@ -0,0 +1,118 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// These are the query parameters of /annotations, beyond the names of the
+// Annotation properties by which it may be filtered.
+const (
+	// AnnotationActiveQueryParam restricts annotations to those whose time
+	// range contains the current time, if "true".
+	AnnotationActiveQueryParam = "active"
+	// AnnotationFromQueryParam restricts annotations to those which end
+	// after the given RFC3339 time.
+	AnnotationFromQueryParam = "from"
+	// AnnotationToQueryParam restricts annotations to those which start
+	// before the given RFC3339 time.
+	AnnotationToQueryParam = "to"
+)
+
+// AnnotationsResponse is the type of a response from Traffic Ops to a GET
+// request made to its /annotations API endpoint.
+type AnnotationsResponse struct {
+	Response []Annotation `json:"response"`
+	Alerts
+}
+
+// AnnotationResponse is the type of a response from Traffic Ops to a POST,
+// PUT, or DELETE request made to its /annotations API endpoint.
+type AnnotationResponse struct {
+	Response Annotation `json:"response"`
+	Alerts
+}
+
+// AnnotationRequest encodes the request data for creating or replacing an
+// Annotation. Exactly one of CDNID, CachegroupID, and DeliveryServiceID must
+// be given.
+type AnnotationRequest struct {
+	Description       string    `json:"description"`
+	StartTime         time.Time `json:"startTime"`
+	EndTime           time.Time `json:"endTime"`
+	CDNID             *int      `json:"cdnId"`
+	CachegroupID      *int      `json:"cachegroupId"`
+	DeliveryServiceID *int      `json:"deliveryServiceId"`
+}
+
+// Annotation is a time-ranged note, such as a planned maintenance window,
+// attached to a single CDN, Cache Group, or Delivery Service, so that
+// graphs and alerts can be correlated with known events.
+type Annotation struct {
+	ID                int       `json:"id" db:"id"`
+	Description       string    `json:"description" db:"description"`
+	StartTime         time.Time `json:"startTime" db:"start_time"`
+	EndTime           time.Time `json:"endTime" db:"end_time"`
+	CDN               *string   `json:"cdn" db:"cdn"`
+	CDNID             *int      `json:"cdnId" db:"cdn_id"`
+	Cachegroup        *string   `json:"cachegroup" db:"cachegroup"`
+	CachegroupID      *int      `json:"cachegroupId" db:"cachegroup_id"`
+	DeliveryService   *string   `json:"deliveryService" db:"deliveryservice"`
+	DeliveryServiceID *int      `json:"deliveryServiceId" db:"deliveryservice_id"`
+	CreatedBy         string    `json:"createdBy" db:"created_by"`
+	LastUpdated       time.Time `json:"lastUpdated" db:"last_updated"`
+}
+
+// ActiveAt returns whether the Annotation's time range contains t.
+func (a Annotation) ActiveAt(t time.Time) bool {
+	return !t.Before(a.StartTime) && t.Before(a.EndTime)
+}
+
+// Validate validates that the AnnotationRequest is valid for creation or
+// replacement of an Annotation.
+func (a *AnnotationRequest) Validate(tx *sql.Tx) error {
+	errs := tovalidate.ToErrors(validation.Errors{
+		"description": validation.Validate(a.Description, validation.Required),
+		"startTime":   validation.Validate(a.StartTime, validation.Required),
+		"endTime":     validation.Validate(a.EndTime, validation.Required),
+	})
+	if !a.StartTime.IsZero() && !a.EndTime.IsZero() && !a.EndTime.After(a.StartTime) {
+		errs = append(errs, errors.New("endTime: must be after startTime"))
+	}
+
+	targets := 0
+	for _, id := range []*int{a.CDNID, a.CachegroupID, a.DeliveryServiceID} {
+		if id != nil {
+			targets++
+		}
+	}
+	if targets != 1 {
+		errs = append(errs, errors.New("exactly one of 'cdnId', 'cachegroupId', and 'deliveryServiceId' must be given"))
+	}
+	return util.JoinErrs(errs)
+}
@@ -0,0 +1,80 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestAnnotationRequestValidate(t *testing.T) {
+	start := time.Date(2022, 5, 22, 2, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+
+	valid := AnnotationRequest{
+		Description:  "fiber maintenance in DEN",
+		StartTime:    start,
+		EndTime:      end,
+		CachegroupID: util.IntPtr(1),
+	}
+	if err := valid.Validate(nil); err != nil {
+		t.Errorf("expected valid request to pass validation, got: %v", err)
+	}
+
+	noTarget := valid
+	noTarget.CachegroupID = nil
+	if err := noTarget.Validate(nil); err == nil {
+		t.Error("expected request with no target to fail validation")
+	}
+
+	twoTargets := valid
+	twoTargets.CDNID = util.IntPtr(2)
+	if err := twoTargets.Validate(nil); err == nil {
+		t.Error("expected request with two targets to fail validation")
+	}
+
+	backwards := valid
+	backwards.StartTime, backwards.EndTime = end, start
+	if err := backwards.Validate(nil); err == nil {
+		t.Error("expected request ending before it starts to fail validation")
+	}
+
+	noDescription := valid
+	noDescription.Description = ""
+	if err := noDescription.Validate(nil); err == nil {
+		t.Error("expected request without a description to fail validation")
+	}
+}
+
+func TestAnnotationActiveAt(t *testing.T) {
+	start := time.Date(2022, 5, 22, 2, 0, 0, 0, time.UTC)
+	a := Annotation{StartTime: start, EndTime: start.Add(time.Hour)}
+	if a.ActiveAt(start.Add(-time.Second)) {
+		t.Error("expected annotation to be inactive before its start")
+	}
+	if !a.ActiveAt(start) || !a.ActiveAt(start.Add(30*time.Minute)) {
+		t.Error("expected annotation to be active within its range")
+	}
+	if a.ActiveAt(start.Add(time.Hour)) {
+		t.Error("expected annotation to be inactive at its end")
+	}
+}
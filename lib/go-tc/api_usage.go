@@ -0,0 +1,78 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import "time"
+
+// These are the kinds of credential with which users make requests to the
+// Traffic Ops API, by which their API usage is distinguished.
+const (
+	// APIUsageCredentialCookie is the session cookie set on login.
+	APIUsageCredentialCookie = "cookie"
+	// APIUsageCredentialAccessToken is the access_token cookie set on login.
+	APIUsageCredentialAccessToken = "access_token"
+	// APIUsageCredentialBearer is an access token given in an Authorization
+	// header, as is typical of automation.
+	APIUsageCredentialBearer = "bearer"
+)
+
+// These are the ways API usage may be grouped by the /system/api-usage
+// endpoint.
+const (
+	// APIUsageGroupByRoute groups API usage by user, credential and route.
+	APIUsageGroupByRoute = "route"
+	// APIUsageGroupByUser groups API usage by user and credential only.
+	APIUsageGroupByUser = "user"
+)
+
+// APIUsage is the usage of the Traffic Ops API by one user with one kind of
+// credential - and, unless grouped by user, of one route - over a range of
+// days, as returned by the /system/api-usage endpoint.
+type APIUsage struct {
+	// Username is the name of the user who made the requests.
+	Username string `json:"username"`
+	// Credential is the kind of credential with which the requests were
+	// made - one of the APIUsageCredential constants.
+	Credential string `json:"credential"`
+	// RouteID is the ID of the route, as used in the access log and in the
+	// routing_blacklist of the Traffic Ops configuration. It's omitted when
+	// usage is grouped by user.
+	RouteID *int `json:"routeId,omitempty"`
+	// Route is the method and path of the route, e.g.
+	// "GET servers/{id}/?$". It's omitted when usage is grouped by user.
+	Route *string `json:"route,omitempty"`
+	// Calls is the number of requests.
+	Calls int64 `json:"calls"`
+	// BytesIn is the total number of bytes in the bodies of the requests.
+	BytesIn int64 `json:"bytesIn"`
+	// BytesOut is the total number of bytes returned by the requests.
+	BytesOut int64 `json:"bytesOut"`
+	// ActiveDays is the number of days on which requests were made.
+	ActiveDays int64 `json:"activeDays"`
+	// LastCall is the time of the last request.
+	LastCall time.Time `json:"lastCall"`
+}
+
+// APIUsageResponse is the type of a response from Traffic Ops to requests
+// made to its /system/api-usage endpoint.
+type APIUsageResponse struct {
+	Response []APIUsage `json:"response"`
+	Alerts
+}
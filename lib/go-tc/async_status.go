@@ -23,6 +23,13 @@ import (
 	"time"
 )
 
+// These are the statuses of asynchronous jobs.
+const (
+	AsyncStatusSucceeded = "SUCCEEDED"
+	AsyncStatusFailed    = "FAILED"
+	AsyncStatusPending   = "PENDING"
+)
+
 // AsyncStatus represents an async job status.
 type AsyncStatus struct {
 	// Id is the integral, unique identifier for the asynchronous job status.
@@ -35,6 +42,14 @@ type AsyncStatus struct {
 	EndTime *time.Time `json:"end_time,omitempty" db:"end_time"`
 	// Message is the message about the job status.
 	Message *string `json:"message,omitempty" db:"message"`
+	// Progress is the percentage of the job's work that's done.
+	Progress int `json:"progress" db:"progress"`
+}
+
+// Finished returns whether the asynchronous job has completed, whether it
+// succeeded or failed.
+func (s AsyncStatus) Finished() bool {
+	return s.Status == AsyncStatusSucceeded || s.Status == AsyncStatusFailed
 }
 
 // AsyncStatusResponse represents the response from the GET /async_status/{id} API.
@@ -42,3 +57,16 @@ type AsyncStatusResponse struct {
 	Response AsyncStatus `json:"response"`
 	Alerts
 }
+
+// AsyncJob identifies an asynchronous job which Traffic Ops has started, the
+// status of which may be requested from /async_jobs/{id}.
+type AsyncJob struct {
+	JobID int `json:"jobId"`
+}
+
+// AsyncJobResponse represents the response from endpoints that start
+// asynchronous jobs, e.g. PUT /snapshot?async=true.
+type AsyncJobResponse struct {
+	Response AsyncJob `json:"response"`
+	Alerts
+}
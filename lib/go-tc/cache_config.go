@@ -0,0 +1,67 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// CacheConfig is the bundle of Traffic Ops API responses a cache server needs
+// to generate its configuration, as returned by the /cache-config/{{server}}
+// endpoint.
+type CacheConfig struct {
+	// Server is the host name of the cache server the bundle is for.
+	Server string `json:"server"`
+	// Responses are the successful responses to the API requests the
+	// bundle was assembled from.
+	Responses []CacheConfigEntry `json:"responses"`
+}
+
+// CacheConfigEntry is a single API response in a CacheConfig bundle.
+type CacheConfigEntry struct {
+	// Path is the request path relative to the API version, e.g.
+	// "servers", followed by its query string, if any. Query parameters are
+	// sorted by key, so requests can be matched with CacheConfigPath.
+	Path string `json:"path"`
+	// LastModified is the Last-Modified header of the response, if any.
+	LastModified string `json:"lastModified,omitempty"`
+	// Body is the JSON body of the response.
+	Body json.RawMessage `json:"body"`
+}
+
+// CacheConfigResponse is the type of a response from Traffic Ops to requests
+// made to its /cache-config/{{server}} endpoint.
+type CacheConfigResponse struct {
+	Response CacheConfig `json:"response"`
+	Alerts
+}
+
+// CacheConfigPath returns the Path of the CacheConfigEntry for a request to
+// the given path, relative to the API version, with the given query
+// parameters.
+func CacheConfigPath(path string, query url.Values) string {
+	path = strings.Trim(path, "/")
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
@@ -0,0 +1,97 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// These are the names of the cache storage statistics that Traffic Monitor
+// reads from the ATS stats of the cache servers it polls, through either
+// astats or stats_over_http.
+const (
+	// ATSStatCacheRAMBytesUsed is the number of bytes used by the RAM cache.
+	ATSStatCacheRAMBytesUsed = "proxy.process.cache.ram_cache.bytes_used"
+	// ATSStatCacheRAMBytesTotal is the size of the RAM cache, in bytes.
+	ATSStatCacheRAMBytesTotal = "proxy.process.cache.ram_cache.total_bytes"
+	// ATSStatCacheDiskBytesUsed is the number of bytes used by the disk
+	// cache, across all spans.
+	ATSStatCacheDiskBytesUsed = "proxy.process.cache.bytes_used"
+	// ATSStatCacheDiskBytesTotal is the size of the disk cache, across all
+	// spans, in bytes.
+	ATSStatCacheDiskBytesTotal = "proxy.process.cache.bytes_total"
+	// ATSStatCacheObjects is the number of cache directory entries in use,
+	// i.e. the number of objects in the cache.
+	ATSStatCacheObjects = "proxy.process.cache.direntries.used"
+	// ATSStatCacheObjectsTotal is the total number of cache directory
+	// entries, i.e. the number of objects the cache can hold.
+	ATSStatCacheObjectsTotal = "proxy.process.cache.direntries.total"
+	// ATSStatCacheWriteFailures is the number of failed cache writes since
+	// ATS started.
+	ATSStatCacheWriteFailures = "proxy.process.cache.write.failure"
+	// ATSStatCacheSpansFailing is the number of cache spans (disks) that are
+	// failing.
+	ATSStatCacheSpansFailing = "proxy.process.cache.span.failing"
+	// ATSStatCacheSpansOffline is the number of cache spans (disks) that are
+	// offline.
+	ATSStatCacheSpansOffline = "proxy.process.cache.span.offline"
+)
+
+// These are the names of the cache storage utilization statistics that
+// Traffic Monitor computes for the cache servers it polls, which can be used
+// in thresholds for server health.
+const (
+	// StatNameCacheRAMUsedPercent is the percentage of the RAM cache that is
+	// in use.
+	StatNameCacheRAMUsedPercent = "cache.storage.ram.used_percent"
+	// StatNameCacheDiskUsedPercent is the percentage of the disk cache that
+	// is in use.
+	StatNameCacheDiskUsedPercent = "cache.storage.disk.used_percent"
+)
+
+// CacheStorage is the cache storage utilization of a cache server, as polled
+// by Traffic Monitor.
+//
+// Each statistic is nil if the cache server didn't report it, e.g. because
+// Traffic Monitor polls it with a health check that excludes ATS stats.
+type CacheStorage struct {
+	HostName   string `json:"hostname"`
+	CacheGroup string `json:"cachegroup"`
+	CDN        string `json:"cdn"`
+	Profile    string `json:"profile"`
+	Status     string `json:"status"`
+	// Healthy is whether Traffic Monitor considers the cache server
+	// available.
+	Healthy         bool     `json:"healthy"`
+	RAMBytesUsed    *uint64  `json:"ramBytesUsed"`
+	RAMBytesTotal   *uint64  `json:"ramBytesTotal"`
+	RAMUsedPercent  *float64 `json:"ramUsedPercent"`
+	DiskBytesUsed   *uint64  `json:"diskBytesUsed"`
+	DiskBytesTotal  *uint64  `json:"diskBytesTotal"`
+	DiskUsedPercent *float64 `json:"diskUsedPercent"`
+	Objects         *uint64  `json:"objects"`
+	ObjectsTotal    *uint64  `json:"objectsTotal"`
+	WriteFailures   *uint64  `json:"writeFailures"`
+	SpansFailing    *uint64  `json:"spansFailing"`
+	SpansOffline    *uint64  `json:"spansOffline"`
+}
+
+// CacheStorageResponse is the type of a response from Traffic Ops to a GET
+// request to its /caches/storage endpoint.
+type CacheStorageResponse struct {
+	Response []CacheStorage `json:"response"`
+	Alerts
+}
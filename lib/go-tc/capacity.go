@@ -0,0 +1,98 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// These are the names of the Parameters used to model the capacity of a cache
+// server, in the CapacityParameterConfigFile config file. They are read from
+// the server's Profiles, in order of priority.
+const (
+	// CapacityParameterConfigFile is the ConfigFile of capacity Parameters.
+	CapacityParameterConfigFile = "capacity"
+	// CapacityMaxKbpsParameterName is the name of a Parameter which sets the
+	// maximum throughput of a cache server, in kilobits per second. If not
+	// set, the sum of the MaxBandwidth of the server's monitored interfaces
+	// is used.
+	CapacityMaxKbpsParameterName = "capacity.maxKbps"
+	// CapacityMaxConnectionsParameterName is the name of a Parameter which
+	// sets the maximum number of concurrent client connections a cache
+	// server can handle. If not set, the server's connection capacity is not
+	// modeled.
+	CapacityMaxConnectionsParameterName = "capacity.maxConnections"
+)
+
+// StatNameCurrentClientConnections is the name of the cache server stat
+// reported by Traffic Monitor that holds its number of open client
+// connections.
+const StatNameCurrentClientConnections = "ats.proxy.process.http.current_client_connections"
+
+// CapacityMetrics is the modeled capacity and current utilization of a set of
+// cache servers.
+type CapacityMetrics struct {
+	// Servers is the number of cache servers in the set which are eligible to
+	// serve traffic, i.e. have a status of ONLINE, REPORTED, or ADMIN_DOWN.
+	Servers int `json:"servers"`
+	// AvailableServers is the number of Servers which Traffic Monitor
+	// considers available, and which are not ADMIN_DOWN.
+	AvailableServers int `json:"availableServers"`
+
+	// MaxKbps is the modeled throughput ceiling of all Servers, less each
+	// server's health threshold bandwidth reservation.
+	MaxKbps float64 `json:"maxKbps"`
+	// AvailableMaxKbps is the portion of MaxKbps belonging to
+	// AvailableServers.
+	AvailableMaxKbps float64 `json:"availableMaxKbps"`
+	// UtilizedKbps is the current throughput of all Servers, as reported by
+	// Traffic Monitor.
+	UtilizedKbps float64 `json:"utilizedKbps"`
+	// UtilizedPercent is UtilizedKbps as a percentage of MaxKbps.
+	UtilizedPercent float64 `json:"utilizedPercent"`
+	// HeadroomKbps is the throughput which could still be served if every
+	// Server were available; MaxKbps less UtilizedKbps.
+	HeadroomKbps float64 `json:"headroomKbps"`
+	// ProjectedHeadroomKbps is the throughput which could still be served
+	// given the servers' current health: AvailableMaxKbps less UtilizedKbps,
+	// i.e. assuming traffic on unavailable servers moves to available ones.
+	ProjectedHeadroomKbps float64 `json:"projectedHeadroomKbps"`
+
+	// MaxConnections is the modeled connection ceiling of the Servers which
+	// have one; it is nil if no Server has a modeled connection ceiling.
+	MaxConnections *int64 `json:"maxConnections"`
+	// Connections is the current number of client connections to all
+	// Servers, as reported by Traffic Monitor.
+	Connections int64 `json:"connections"`
+	// ConnectionsPercent is Connections as a percentage of MaxConnections,
+	// counting only those Servers with a modeled connection ceiling. It is
+	// nil if MaxConnections is nil.
+	ConnectionsPercent *float64 `json:"connectionsPercent"`
+}
+
+// Capacity is the modeled capacity of the cache servers matching a request to
+// the /capacity endpoint, in total and per-Cache Group.
+type Capacity struct {
+	Total       CapacityMetrics            `json:"total"`
+	CacheGroups map[string]CapacityMetrics `json:"cacheGroups"`
+}
+
+// CapacityResponse is the type of a response from Traffic Ops to a GET
+// request to its /capacity endpoint.
+type CapacityResponse struct {
+	Response Capacity `json:"response"`
+	Alerts
+}
@@ -0,0 +1,57 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"time"
+)
+
+// CDNSigningKey is a CDN's key for signing the configuration it serves, as
+// stored in Traffic Vault.
+type CDNSigningKey struct {
+	CDN        string    `json:"cdn"`
+	PrivateKey string    `json:"privateKey"`
+	PublicKey  string    `json:"publicKey"`
+	Created    time.Time `json:"created"`
+}
+
+// CDNSigningPublicKey is the public part of a CDN's signing key, as returned
+// by the Traffic Ops API.
+type CDNSigningPublicKey struct {
+	CDN       string    `json:"cdn"`
+	PublicKey string    `json:"publicKey"`
+	Created   time.Time `json:"created"`
+}
+
+// Public returns the public part of the signing key.
+func (k CDNSigningKey) Public() CDNSigningPublicKey {
+	return CDNSigningPublicKey{
+		CDN:       k.CDN,
+		PublicKey: k.PublicKey,
+		Created:   k.Created,
+	}
+}
+
+// CDNSigningPublicKeyResponse is the type of a response from Traffic Ops to
+// requests made to its /cdns/{{name}}/signing_key endpoint.
+type CDNSigningPublicKeyResponse struct {
+	Response CDNSigningPublicKey `json:"response"`
+	Alerts
+}
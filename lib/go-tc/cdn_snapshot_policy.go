@@ -0,0 +1,121 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// MinCDNSnapshotPolicySeconds is the shortest interval or quiet period, in
+// seconds, that a CDN's snapshot policy may have.
+const MinCDNSnapshotPolicySeconds = 60
+
+// CDNSnapshotPolicy is a CDN's policy for taking snapshots automatically,
+// so that changes don't go unsnapshotted for long. A snapshot is taken when
+// the CDN has changes that aren't in its current snapshot, and either the
+// snapshot is at least IntervalSeconds old or no change has been made for
+// QuietPeriodSeconds, unless there are more than MaxChanges of them.
+//
+// Snapshots are taken on behalf of the user who last set the policy; they're
+// skipped while the CDN is locked by anyone else.
+type CDNSnapshotPolicy struct {
+	// CDNName is the name of the CDN to which the policy applies. It's
+	// ignored in requests, which identify the CDN in their path.
+	CDNName string `json:"cdnName"`
+	// IntervalSeconds is the age a snapshot must reach before changes made
+	// since it was taken are snapshotted. If nil, snapshots aren't taken on
+	// a schedule.
+	IntervalSeconds *int64 `json:"intervalSeconds"`
+	// QuietPeriodSeconds is how long after the latest change the changes
+	// are snapshotted. If nil, snapshots aren't taken when changes stop.
+	QuietPeriodSeconds *int64 `json:"quietPeriodSeconds"`
+	// MaxChanges is the most changes that may be snapshotted automatically.
+	// When more are pending, the snapshot is aborted and a CDN notification
+	// asks that they be reviewed and snapshotted by hand. If nil, there's
+	// no limit.
+	MaxChanges *int64 `json:"maxChanges"`
+	// Notify is whether a CDN notification is posted for each snapshot that
+	// is taken automatically, not only for those that are aborted.
+	Notify bool `json:"notify"`
+	// User is the name of the user on whose behalf snapshots are taken. It's
+	// ignored in requests; it's set to the user who sets the policy.
+	User *string `json:"user"`
+	// LastAutoSnapshot is the time at which a snapshot was last taken
+	// automatically, if ever. It's ignored in requests.
+	LastAutoSnapshot *time.Time `json:"lastAutoSnapshot"`
+	// LastAborted is the time at which an automatic snapshot was last
+	// aborted for having too many changes, if one has been since the last
+	// automatic snapshot. It's ignored in requests.
+	LastAborted *time.Time `json:"lastAborted"`
+	// LastUpdated is the time at which the policy, or the record of its
+	// snapshots, was last changed.
+	LastUpdated *time.Time `json:"lastUpdated,omitempty"`
+}
+
+// CDNSnapshotPolicyResponse is the type of a response from Traffic Ops to a
+// request for a CDN's snapshot policy.
+type CDNSnapshotPolicyResponse struct {
+	Response CDNSnapshotPolicy `json:"response"`
+	Alerts
+}
+
+// Validate validates that the CDNSnapshotPolicy takes snapshots in at least
+// one circumstance, and that its bounds are reasonable.
+func (p *CDNSnapshotPolicy) Validate(tx *sql.Tx) error {
+	errs := []error{}
+	if p.IntervalSeconds == nil && p.QuietPeriodSeconds == nil {
+		errs = append(errs, errors.New("intervalSeconds or quietPeriodSeconds is required"))
+	}
+	if p.IntervalSeconds != nil && *p.IntervalSeconds < MinCDNSnapshotPolicySeconds {
+		errs = append(errs, fmt.Errorf("intervalSeconds: must be at least %d", MinCDNSnapshotPolicySeconds))
+	}
+	if p.QuietPeriodSeconds != nil && *p.QuietPeriodSeconds < MinCDNSnapshotPolicySeconds {
+		errs = append(errs, fmt.Errorf("quietPeriodSeconds: must be at least %d", MinCDNSnapshotPolicySeconds))
+	}
+	if p.MaxChanges != nil && *p.MaxChanges <= 0 {
+		errs = append(errs, errors.New("maxChanges: must be positive"))
+	}
+	return util.JoinErrs(errs)
+}
+
+// SnapshotDue returns whether, with the given number of changes pending since
+// the CDN's last snapshot and the latest of them made at lastChange, a
+// snapshot should be taken at now. lastSnapshot is nil if the CDN has never
+// been snapshotted. Whether there are too many changes isn't considered.
+func (p CDNSnapshotPolicy) SnapshotDue(now time.Time, lastSnapshot *time.Time, changes int64, lastChange time.Time) bool {
+	if changes <= 0 {
+		return false
+	}
+	if p.IntervalSeconds != nil && (lastSnapshot == nil || now.Sub(*lastSnapshot) >= time.Duration(*p.IntervalSeconds)*time.Second) {
+		return true
+	}
+	return p.QuietPeriodSeconds != nil && now.Sub(lastChange) >= time.Duration(*p.QuietPeriodSeconds)*time.Second
+}
+
+// TooManyChanges returns whether the given number of pending changes is more
+// than may be snapshotted automatically.
+func (p CDNSnapshotPolicy) TooManyChanges(changes int64) bool {
+	return p.MaxChanges != nil && changes > *p.MaxChanges
+}
@@ -0,0 +1,88 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestCDNSnapshotPolicyValidate(t *testing.T) {
+	policy := CDNSnapshotPolicy{QuietPeriodSeconds: util.Int64Ptr(600), MaxChanges: util.Int64Ptr(50)}
+	if err := policy.Validate(nil); err != nil {
+		t.Errorf("unexpected error validating policy: %v", err)
+	}
+
+	invalid := map[string]CDNSnapshotPolicy{
+		"neither interval nor quiet period": {MaxChanges: util.Int64Ptr(50)},
+		"short interval":                    {IntervalSeconds: util.Int64Ptr(MinCDNSnapshotPolicySeconds - 1)},
+		"short quiet period":                {QuietPeriodSeconds: util.Int64Ptr(0)},
+		"zero max changes":                  {IntervalSeconds: util.Int64Ptr(3600), MaxChanges: util.Int64Ptr(0)},
+	}
+	for name, p := range invalid {
+		if err := p.Validate(nil); err == nil {
+			t.Errorf("expected an error validating a policy with %s", name)
+		}
+	}
+}
+
+func TestCDNSnapshotPolicySnapshotDue(t *testing.T) {
+	now := time.Now()
+	hourAgo := now.Add(-time.Hour)
+	minuteAgo := now.Add(-time.Minute)
+
+	policy := CDNSnapshotPolicy{IntervalSeconds: util.Int64Ptr(1800)}
+	if policy.SnapshotDue(now, &hourAgo, 0, hourAgo) {
+		t.Error("expected no snapshot to be due without changes")
+	}
+	if !policy.SnapshotDue(now, &hourAgo, 1, minuteAgo) {
+		t.Error("expected a snapshot to be due when the last is older than the interval")
+	}
+	if policy.SnapshotDue(now, &minuteAgo, 1, minuteAgo) {
+		t.Error("expected no snapshot to be due when the last is newer than the interval")
+	}
+	if !policy.SnapshotDue(now, nil, 1, minuteAgo) {
+		t.Error("expected a snapshot to be due for a CDN that's never been snapshotted")
+	}
+
+	policy = CDNSnapshotPolicy{QuietPeriodSeconds: util.Int64Ptr(600)}
+	if policy.SnapshotDue(now, &hourAgo, 3, minuteAgo) {
+		t.Error("expected no snapshot to be due within the quiet period of the latest change")
+	}
+	if !policy.SnapshotDue(now, &hourAgo, 3, now.Add(-20*time.Minute)) {
+		t.Error("expected a snapshot to be due after the quiet period of the latest change")
+	}
+}
+
+func TestCDNSnapshotPolicyTooManyChanges(t *testing.T) {
+	policy := CDNSnapshotPolicy{}
+	if policy.TooManyChanges(1000000) {
+		t.Error("expected a policy without maxChanges to allow any number of changes")
+	}
+	policy.MaxChanges = util.Int64Ptr(10)
+	if policy.TooManyChanges(10) {
+		t.Error("expected a policy with maxChanges 10 to allow 10 changes")
+	}
+	if !policy.TooManyChanges(11) {
+		t.Error("expected a policy with maxChanges 10 not to allow 11 changes")
+	}
+}
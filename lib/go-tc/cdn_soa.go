@@ -0,0 +1,184 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/asaskevich/govalidator"
+)
+
+// CDNSOASerialPolicy is the way in which the serial numbers of the SOA
+// records of a CDN's zones are chosen.
+type CDNSOASerialPolicy string
+
+const (
+	// CDNSOASerialPolicyDate leaves the choice of serial number to Traffic
+	// Router, which derives it from the date of the snapshot it's serving.
+	CDNSOASerialPolicyDate = CDNSOASerialPolicy("date")
+	// CDNSOASerialPolicyIncrement uses a counter kept by Traffic Ops, which
+	// is incremented each time the CDN is snapshotted.
+	CDNSOASerialPolicyIncrement = CDNSOASerialPolicy("increment")
+)
+
+// MaxCDNSOANegativeTTL is the largest negative caching TTL, in seconds, that
+// may be set for a CDN; RFC 2308 recommends no more than a few hours.
+const MaxCDNSOANegativeTTL = 86400
+
+// maxDNSTTL is the largest value of a DNS TTL or SOA timer (RFC 2181 §8).
+const maxDNSTTL = math.MaxInt32
+
+// CDNSOA is the content of the SOA records of a CDN's zones, along with the
+// TTLs of its SOA and NS records and any name servers which should be
+// published in addition to the CDN's Traffic Routers. When a CDN has one, it
+// takes the place of the tld.soa.* and tld.ttls.SOA/tld.ttls.NS Parameters
+// in the CDN's snapshots.
+type CDNSOA struct {
+	// CDNName is the name of the CDN to which the SOA applies. It's ignored
+	// in requests, which identify the CDN in their path.
+	CDNName string `json:"cdnName"`
+	// Admin is the mailbox of the person responsible for the zones, either
+	// as an email address or as a name relative to the CDN's domain.
+	Admin string `json:"admin"`
+	// SerialPolicy is how the SOA serial number is chosen.
+	SerialPolicy CDNSOASerialPolicy `json:"serialPolicy"`
+	// Serial is the serial number most recently used in a snapshot of the
+	// CDN under the "increment" policy. It's ignored in requests.
+	Serial int64 `json:"serial"`
+	// RefreshSeconds is the SOA refresh interval.
+	RefreshSeconds int64 `json:"refreshSeconds"`
+	// RetrySeconds is the SOA retry interval.
+	RetrySeconds int64 `json:"retrySeconds"`
+	// ExpireSeconds is the SOA expire interval.
+	ExpireSeconds int64 `json:"expireSeconds"`
+	// NegativeTTLSeconds is the SOA minimum field, which resolvers use as
+	// the TTL of negative (NXDOMAIN and NODATA) answers.
+	NegativeTTLSeconds int64 `json:"negativeTTLSeconds"`
+	// SOATTLSeconds is the TTL of the SOA records themselves.
+	SOATTLSeconds int64 `json:"soaTTLSeconds"`
+	// NSTTLSeconds is the TTL of the zones' NS records.
+	NSTTLSeconds int64 `json:"nsTTLSeconds"`
+	// Nameservers are the host names of any name servers which are
+	// authoritative for the CDN's zones besides its Traffic Routers.
+	Nameservers []string `json:"nameservers"`
+	// LastUpdated is the time at which the SOA was last changed.
+	LastUpdated *time.Time `json:"lastUpdated,omitempty"`
+}
+
+// CDNSOAResponse is the type of a response from Traffic Ops to a request for
+// a CDN's SOA.
+type CDNSOAResponse struct {
+	Response CDNSOA `json:"response"`
+	Alerts
+}
+
+// Validate validates that the CDNSOA's fields are consistent with each other
+// and within the bounds permitted in DNS. An empty SerialPolicy is treated as
+// "date".
+func (s *CDNSOA) Validate(tx *sql.Tx) error {
+	errs := []error{}
+	if s.Admin == "" {
+		errs = append(errs, errors.New("admin: cannot be blank"))
+	} else if strings.ContainsAny(s.Admin, " \t\r\n") {
+		errs = append(errs, errors.New("admin: cannot contain whitespace"))
+	} else if at := strings.Index(s.Admin, "@"); at >= 0 && (at == 0 || !govalidator.IsDNSName(s.Admin[at+1:])) {
+		errs = append(errs, errors.New("admin: must be a valid email address or a name relative to the CDN's domain"))
+	}
+
+	if s.SerialPolicy == "" {
+		s.SerialPolicy = CDNSOASerialPolicyDate
+	}
+	if s.SerialPolicy != CDNSOASerialPolicyDate && s.SerialPolicy != CDNSOASerialPolicyIncrement {
+		errs = append(errs, fmt.Errorf("serialPolicy: must be one of '%s' or '%s'", CDNSOASerialPolicyDate, CDNSOASerialPolicyIncrement))
+	}
+
+	for _, timer := range []struct {
+		name string
+		val  int64
+	}{
+		{"refreshSeconds", s.RefreshSeconds},
+		{"retrySeconds", s.RetrySeconds},
+		{"expireSeconds", s.ExpireSeconds},
+		{"soaTTLSeconds", s.SOATTLSeconds},
+		{"nsTTLSeconds", s.NSTTLSeconds},
+	} {
+		if timer.val <= 0 || timer.val > maxDNSTTL {
+			errs = append(errs, fmt.Errorf("%s: must be between 1 and %d", timer.name, maxDNSTTL))
+		}
+	}
+	if s.RetrySeconds > s.RefreshSeconds {
+		errs = append(errs, errors.New("retrySeconds: cannot be greater than refreshSeconds"))
+	}
+	if s.ExpireSeconds < s.RefreshSeconds+s.RetrySeconds {
+		errs = append(errs, errors.New("expireSeconds: cannot be less than the sum of refreshSeconds and retrySeconds"))
+	}
+	if s.NegativeTTLSeconds < 0 || s.NegativeTTLSeconds > MaxCDNSOANegativeTTL {
+		errs = append(errs, fmt.Errorf("negativeTTLSeconds: must be between 0 and %d", MaxCDNSOANegativeTTL))
+	}
+
+	if s.Nameservers == nil {
+		s.Nameservers = []string{}
+	}
+	seen := make(map[string]struct{}, len(s.Nameservers))
+	for i, ns := range s.Nameservers {
+		ns = strings.ToLower(strings.TrimSuffix(ns, "."))
+		if !govalidator.IsDNSName(ns) || !strings.Contains(ns, ".") {
+			errs = append(errs, fmt.Errorf("nameservers[%d]: '%s' is not a fully qualified host name", i, s.Nameservers[i]))
+			continue
+		}
+		if _, ok := seen[ns]; ok {
+			errs = append(errs, fmt.Errorf("nameservers[%d]: '%s' is duplicated", i, s.Nameservers[i]))
+		}
+		seen[ns] = struct{}{}
+		s.Nameservers[i] = ns
+	}
+	return util.JoinErrs(errs)
+}
+
+// SOA returns the SOA record content to use in a snapshot of the CDN. The
+// serial number is only set under the "increment" policy, in which case it's
+// the number the next snapshot will use.
+func (s CDNSOA) SOA() SOA {
+	admin := s.Admin
+	refresh := strconv.FormatInt(s.RefreshSeconds, 10)
+	retry := strconv.FormatInt(s.RetrySeconds, 10)
+	expire := strconv.FormatInt(s.ExpireSeconds, 10)
+	minimum := strconv.FormatInt(s.NegativeTTLSeconds, 10)
+	soa := SOA{
+		Admin:          &admin,
+		ExpireSeconds:  &expire,
+		MinimumSeconds: &minimum,
+		RefreshSeconds: &refresh,
+		RetrySeconds:   &retry,
+	}
+	if s.SerialPolicy == CDNSOASerialPolicyIncrement {
+		serial := strconv.FormatInt(s.Serial+1, 10)
+		soa.Serial = &serial
+	}
+	return soa
+}
@@ -0,0 +1,97 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+)
+
+func validCDNSOA() CDNSOA {
+	return CDNSOA{
+		Admin:              "hostmaster@example.com",
+		RefreshSeconds:     28800,
+		RetrySeconds:       7200,
+		ExpireSeconds:      604800,
+		NegativeTTLSeconds: 30,
+		SOATTLSeconds:      86400,
+		NSTTLSeconds:       3600,
+		Nameservers:        []string{"NS1.Example.com."},
+	}
+}
+
+func TestCDNSOAValidate(t *testing.T) {
+	soa := validCDNSOA()
+	if err := soa.Validate(nil); err != nil {
+		t.Fatalf("unexpected error validating SOA: %v", err)
+	}
+	if soa.SerialPolicy != CDNSOASerialPolicyDate {
+		t.Errorf("expected empty serial policy to default to '%s', got '%s'", CDNSOASerialPolicyDate, soa.SerialPolicy)
+	}
+	if soa.Nameservers[0] != "ns1.example.com" {
+		t.Errorf("expected name server to be normalized to 'ns1.example.com', got '%s'", soa.Nameservers[0])
+	}
+
+	invalid := map[string]func(*CDNSOA){
+		"blank admin":            func(s *CDNSOA) { s.Admin = "" },
+		"admin with whitespace":  func(s *CDNSOA) { s.Admin = "host master" },
+		"admin with bad domain":  func(s *CDNSOA) { s.Admin = "hostmaster@" },
+		"unknown serial policy":  func(s *CDNSOA) { s.SerialPolicy = "random" },
+		"zero refresh":           func(s *CDNSOA) { s.RefreshSeconds = 0 },
+		"retry above refresh":    func(s *CDNSOA) { s.RetrySeconds = s.RefreshSeconds + 1 },
+		"expire too short":       func(s *CDNSOA) { s.ExpireSeconds = s.RefreshSeconds },
+		"negative negative TTL":  func(s *CDNSOA) { s.NegativeTTLSeconds = -1 },
+		"negative TTL too large": func(s *CDNSOA) { s.NegativeTTLSeconds = MaxCDNSOANegativeTTL + 1 },
+		"zero NS TTL":            func(s *CDNSOA) { s.NSTTLSeconds = 0 },
+		"unqualified name server": func(s *CDNSOA) {
+			s.Nameservers = []string{"ns1"}
+		},
+		"duplicate name servers": func(s *CDNSOA) {
+			s.Nameservers = []string{"ns1.example.com", "NS1.example.com."}
+		},
+	}
+	for name, mutate := range invalid {
+		t.Run(name, func(t *testing.T) {
+			soa := validCDNSOA()
+			mutate(&soa)
+			if err := soa.Validate(nil); err == nil {
+				t.Error("expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestCDNSOASOA(t *testing.T) {
+	soa := validCDNSOA()
+	soa.SerialPolicy = CDNSOASerialPolicyDate
+	soa.Serial = 41
+	rec := soa.SOA()
+	if rec.Serial != nil {
+		t.Errorf("expected no serial under the '%s' policy, got %s", CDNSOASerialPolicyDate, *rec.Serial)
+	}
+	if rec.MinimumSeconds == nil || *rec.MinimumSeconds != "30" {
+		t.Errorf("expected minimum to be the negative TTL '30', got %v", rec.MinimumSeconds)
+	}
+
+	soa.SerialPolicy = CDNSOASerialPolicyIncrement
+	rec = soa.SOA()
+	if rec.Serial == nil || *rec.Serial != "42" {
+		t.Errorf("expected serial '42' under the '%s' policy, got %v", CDNSOASerialPolicyIncrement, rec.Serial)
+	}
+}
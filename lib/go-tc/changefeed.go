@@ -0,0 +1,108 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import "time"
+
+// ChangeFeedSinceQueryParam is the name of the query parameter of
+// /changefeed through which clients give the sequence number of the last
+// change event they received, to resume the feed after it.
+const ChangeFeedSinceQueryParam = "since"
+
+// ChangeFeedTypeQueryParam is the name of the query parameter of /changefeed
+// through which clients restrict the feed to a comma-separated list of
+// object types.
+const ChangeFeedTypeQueryParam = "type"
+
+// The names of the events sent on /changefeed.
+const (
+	// ChangeFeedEventChange is the name of the events describing a change,
+	// whose data is a ChangeEvent.
+	ChangeFeedEventChange = "change"
+	// ChangeFeedEventReset is the name of the event sent when the requested
+	// change events are no longer retained, whose data is a
+	// ChangeFeedReset. Clients should reload whatever they keep current
+	// using the feed.
+	ChangeFeedEventReset = "reset"
+)
+
+// Change event actions.
+const (
+	ChangeActionCreate = "create"
+	ChangeActionUpdate = "update"
+	ChangeActionDelete = "delete"
+)
+
+// ChangeObjectTypes are the types of the objects whose changes are recorded
+// as change events.
+var ChangeObjectTypes = []string{
+	"asn",
+	"cachegroup",
+	"cdn",
+	"coordinate",
+	"deliveryservice",
+	"division",
+	"federation",
+	"job",
+	"origin",
+	"parameter",
+	"phys_location",
+	"profile",
+	"region",
+	"server",
+	"service_category",
+	"staticdnsentry",
+	"status",
+	"tenant",
+	"topology",
+	"type",
+}
+
+// ChangeEvent is a change made to an object in Traffic Ops, as sent on
+// /changefeed.
+type ChangeEvent struct {
+	// Sequence is the position of the change in the feed. Changes are sent
+	// in ascending order of sequence numbers.
+	Sequence int64 `json:"sequence" db:"sequence"`
+	// Type is the type of the changed object, e.g. "server" or
+	// "deliveryservice".
+	Type string `json:"type" db:"object_type"`
+	// ID identifies the changed object - usually its integral ID, but the
+	// name of objects such as Topologies that have no ID.
+	ID string `json:"id" db:"object_id"`
+	// Action is one of ChangeActionCreate, ChangeActionUpdate, or
+	// ChangeActionDelete.
+	Action string `json:"action" db:"action"`
+	// CDN is the name of the CDN of the changed object, if it has one - e.g.
+	// that of a server, or of the Delivery Service of a Static DNS Entry.
+	CDN *string `json:"cdn,omitempty" db:"cdn"`
+	// ChangedBy is the username of the user who made the change, if known
+	// and visible to the client.
+	ChangedBy *string `json:"changedBy" db:"changed_by"`
+	// Time is when the change was made.
+	Time time.Time `json:"time" db:"occurred"`
+}
+
+// ChangeFeedReset is the data of a ChangeFeedEventReset event.
+type ChangeFeedReset struct {
+	// Sequence is the sequence number of the latest change, after which the
+	// feed continues.
+	Sequence int64 `json:"sequence"`
+}
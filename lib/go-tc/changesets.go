@@ -0,0 +1,250 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// Changeset statuses. A Changeset's edits may be changed only while it's
+// open; it's closed by committing or discarding it.
+const (
+	ChangesetStatusOpen      = "open"
+	ChangesetStatusCommitted = "committed"
+	ChangesetStatusDiscarded = "discarded"
+)
+
+// ChangesetObjectTypes are the types of the objects that Changesets can
+// edit, named as they are in change events.
+var ChangesetObjectTypes = []string{
+	"asn",
+	"cachegroup",
+	"cdn",
+	"division",
+	"origin",
+	"parameter",
+	"phys_location",
+	"profile",
+	"region",
+	"status",
+	"tenant",
+	"type",
+}
+
+// ChangesetRequest encodes the request data for creating a Changeset.
+type ChangesetRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Validate validates that the ChangesetRequest has a name.
+func (c *ChangesetRequest) Validate(*sql.Tx) error {
+	if strings.TrimSpace(c.Name) == "" {
+		return errors.New("name: cannot be blank")
+	}
+	return nil
+}
+
+// Changeset is a group of edits to Traffic Ops objects which are staged
+// together, so that they can be reviewed - see ChangesetPreview - before
+// they're all committed in a single transaction, or discarded.
+type Changeset struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Status is one of ChangesetStatusOpen, ChangesetStatusCommitted, or
+	// ChangesetStatusDiscarded.
+	Status string `json:"status"`
+	// Author is the username of the user who created the Changeset, if
+	// they still exist.
+	Author *string `json:"author"`
+	// ClosedBy is the username of the user who committed or discarded the
+	// Changeset, if it's closed and they still exist.
+	ClosedBy *string `json:"closedBy"`
+	// Closed is when the Changeset was committed or discarded, if it has
+	// been.
+	Closed *time.Time `json:"closed"`
+	// Edits are the Changeset's edits, in the order in which they're
+	// applied.
+	Edits       []ChangesetEdit `json:"edits"`
+	Created     time.Time       `json:"created"`
+	LastUpdated time.Time       `json:"lastUpdated"`
+}
+
+// ChangesetEditRequest encodes the request data for adding an edit to a
+// Changeset.
+type ChangesetEditRequest struct {
+	// ObjectType is the type of the edited object, from
+	// ChangesetObjectTypes.
+	ObjectType string `json:"objectType"`
+	// Action is one of ChangeActionCreate, ChangeActionUpdate, or
+	// ChangeActionDelete.
+	Action string `json:"action"`
+	// ObjectID is the integral, unique identifier of the object updated or
+	// deleted. It must be nil for creations.
+	ObjectID *int `json:"objectId"`
+	// Body is the object created, or the object as it is to be updated,
+	// exactly as it would be in a request to create or update it directly.
+	// It must be nil for deletions.
+	Body json.RawMessage `json:"body"`
+}
+
+// Validate validates that the ChangesetEditRequest is of an object type a
+// Changeset can edit, and that it has an object ID and body if and only if
+// its action needs them.
+func (e *ChangesetEditRequest) Validate(*sql.Tx) error {
+	errs := []error{}
+	if !util.ContainsStr(ChangesetObjectTypes, e.ObjectType) {
+		errs = append(errs, fmt.Errorf("objectType: must be one of: %s", strings.Join(ChangesetObjectTypes, ", ")))
+	}
+	hasBody := len(e.Body) > 0 && string(e.Body) != "null"
+	switch e.Action {
+	case ChangeActionCreate:
+		if e.ObjectID != nil {
+			errs = append(errs, errors.New("objectId: must not be given to create an object"))
+		}
+		if !hasBody {
+			errs = append(errs, errors.New("body: required to create an object"))
+		}
+	case ChangeActionUpdate:
+		if e.ObjectID == nil {
+			errs = append(errs, errors.New("objectId: required to update an object"))
+		}
+		if !hasBody {
+			errs = append(errs, errors.New("body: required to update an object"))
+		}
+	case ChangeActionDelete:
+		if e.ObjectID == nil {
+			errs = append(errs, errors.New("objectId: required to delete an object"))
+		}
+		if hasBody {
+			errs = append(errs, errors.New("body: must not be given to delete an object"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("action: must be one of: %s, %s, %s", ChangeActionCreate, ChangeActionUpdate, ChangeActionDelete))
+	}
+	if hasBody {
+		var obj map[string]interface{}
+		if err := json.Unmarshal(e.Body, &obj); err != nil {
+			errs = append(errs, errors.New("body: must be a JSON object"))
+		}
+	}
+	return util.JoinErrs(errs)
+}
+
+// ChangesetEdit is a staged edit of a Changeset.
+type ChangesetEdit struct {
+	ID int `json:"id"`
+	ChangesetEditRequest
+	Created time.Time `json:"created"`
+}
+
+// ChangesetFieldChange is a change to a property of an object.
+type ChangesetFieldChange struct {
+	Field string `json:"field"`
+	// Old is the property's value before the change, or nil if the object
+	// is created.
+	Old json.RawMessage `json:"old"`
+	// New is the property's value after the change, or nil if the object is
+	// deleted.
+	New json.RawMessage `json:"new"`
+}
+
+// ChangesetEditPreview is the result of applying an edit in a
+// ChangesetPreview.
+type ChangesetEditPreview struct {
+	EditID     int    `json:"editId"`
+	ObjectType string `json:"objectType"`
+	Action     string `json:"action"`
+	// ObjectID identifies the edited object - for creations, the ID it
+	// would be given if the Changeset were committed now.
+	ObjectID *int `json:"objectId"`
+	// Changes are the properties of the object that the edit changes.
+	Changes []ChangesetFieldChange `json:"changes"`
+	// Error describes why the edit can't be applied, if it can't. Edits
+	// following one that can't be applied aren't previewed.
+	Error *string `json:"error,omitempty"`
+}
+
+// CRConfigSectionDelta is the difference between two versions of a section
+// of a CDN's CRConfig, such as "contentServers", in terms of the keys of
+// its entries.
+type CRConfigSectionDelta struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+	Changed []string `json:"changed"`
+}
+
+// Empty returns whether the section is unchanged.
+func (d CRConfigSectionDelta) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// ChangesetSnapshotDelta is the difference between a CDN's current
+// Snapshot and the one that would be taken after a Changeset is committed.
+type ChangesetSnapshotDelta struct {
+	CDN string `json:"cdn"`
+	// Snapshotted is whether the CDN has a Snapshot. If it doesn't, every
+	// entry of the projected Snapshot is added.
+	Snapshotted bool `json:"snapshotted"`
+	// Sections are the changed sections of the CRConfig, keyed by name.
+	Sections map[string]CRConfigSectionDelta `json:"sections"`
+}
+
+// ChangesetPreview is the projected result of committing a Changeset: the
+// changes each of its edits would make, and the differences they'd make to
+// the Snapshots of the CDNs they affect.
+type ChangesetPreview struct {
+	ChangesetID int `json:"changesetId"`
+	// Valid is whether every edit can be applied, so the Changeset can be
+	// committed.
+	Valid          bool                     `json:"valid"`
+	Edits          []ChangesetEditPreview   `json:"edits"`
+	SnapshotDeltas []ChangesetSnapshotDelta `json:"snapshotDeltas"`
+}
+
+// ChangesetsResponse is the type of a response from Traffic Ops to a GET
+// request made to its /changesets API endpoint.
+type ChangesetsResponse struct {
+	Response []Changeset `json:"response"`
+	Alerts
+}
+
+// ChangesetResponse is the type of a response from Traffic Ops to a request
+// made to its /changesets API endpoint, or one of its subresources, that
+// responds with a single Changeset.
+type ChangesetResponse struct {
+	Response Changeset `json:"response"`
+	Alerts
+}
+
+// ChangesetPreviewResponse is the type of a response from Traffic Ops to a
+// GET request made to its /changesets/{{ID}}/preview API endpoint.
+type ChangesetPreviewResponse struct {
+	Response ChangesetPreview `json:"response"`
+	Alerts
+}
@@ -0,0 +1,69 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestChangesetEditRequestValidate(t *testing.T) {
+	valid := map[string]ChangesetEditRequest{
+		"create":                {ObjectType: "cdn", Action: ChangeActionCreate, Body: json.RawMessage(`{"name": "cdn"}`)},
+		"update":                {ObjectType: "cachegroup", Action: ChangeActionUpdate, ObjectID: util.IntPtr(1), Body: json.RawMessage(`{"name": "cg"}`)},
+		"delete":                {ObjectType: "parameter", Action: ChangeActionDelete, ObjectID: util.IntPtr(1)},
+		"delete with null body": {ObjectType: "parameter", Action: ChangeActionDelete, ObjectID: util.IntPtr(1), Body: json.RawMessage(`null`)},
+	}
+	for name, edit := range valid {
+		if err := edit.Validate(nil); err != nil {
+			t.Errorf("expected valid %s edit to be valid, got: %v", name, err)
+		}
+	}
+
+	invalid := map[string]ChangesetEditRequest{
+		"unknown type":          {ObjectType: "server", Action: ChangeActionCreate, Body: json.RawMessage(`{}`)},
+		"unknown action":        {ObjectType: "cdn", Action: "upsert", Body: json.RawMessage(`{}`)},
+		"create with ID":        {ObjectType: "cdn", Action: ChangeActionCreate, ObjectID: util.IntPtr(1), Body: json.RawMessage(`{}`)},
+		"create without body":   {ObjectType: "cdn", Action: ChangeActionCreate},
+		"update without ID":     {ObjectType: "cdn", Action: ChangeActionUpdate, Body: json.RawMessage(`{}`)},
+		"update without body":   {ObjectType: "cdn", Action: ChangeActionUpdate, ObjectID: util.IntPtr(1)},
+		"delete without ID":     {ObjectType: "cdn", Action: ChangeActionDelete},
+		"delete with body":      {ObjectType: "cdn", Action: ChangeActionDelete, ObjectID: util.IntPtr(1), Body: json.RawMessage(`{}`)},
+		"body that's no object": {ObjectType: "cdn", Action: ChangeActionCreate, Body: json.RawMessage(`[1, 2]`)},
+	}
+	for name, edit := range invalid {
+		if err := edit.Validate(nil); err == nil {
+			t.Errorf("expected edit with %s to be invalid, but it was valid", name)
+		}
+	}
+}
+
+func TestChangesetRequestValidate(t *testing.T) {
+	req := ChangesetRequest{Name: "maintenance"}
+	if err := req.Validate(nil); err != nil {
+		t.Errorf("expected changeset with a name to be valid, got: %v", err)
+	}
+	req.Name = " "
+	if err := req.Validate(nil); err == nil {
+		t.Error("expected changeset with a blank name to be invalid, but it was valid")
+	}
+}
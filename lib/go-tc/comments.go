@@ -0,0 +1,118 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// CommentMentionedQueryParam is the query parameter of /comments that
+// restricts comments to those which mention the user with the given
+// username.
+const CommentMentionedQueryParam = "mentioned"
+
+// CommentsResponse is the type of a response from Traffic Ops to a GET
+// request made to its /comments API endpoint.
+type CommentsResponse struct {
+	Response []Comment `json:"response"`
+	Alerts
+}
+
+// CommentResponse is the type of a response from Traffic Ops to a POST, PUT,
+// or DELETE request made to its /comments API endpoint.
+type CommentResponse struct {
+	Response Comment `json:"response"`
+	Alerts
+}
+
+// CommentRequest encodes the request data for creating a Comment. Exactly one
+// of DeliveryServiceID, ServerID, and CachegroupID must be given; a reply,
+// which has a ParentID, must be of the same object as its parent.
+type CommentRequest struct {
+	ParentID          *int   `json:"parentId"`
+	DeliveryServiceID *int   `json:"deliveryServiceId"`
+	ServerID          *int   `json:"serverId"`
+	CachegroupID      *int   `json:"cachegroupId"`
+	Value             string `json:"value"`
+}
+
+// CommentUpdateRequest encodes the request data for modifying a Comment,
+// whose object and parent can't be changed.
+type CommentUpdateRequest struct {
+	Value string `json:"value"`
+}
+
+// Comment is a note attached to a single Delivery Service, server, or Cache
+// Group, so that operational context is kept with the object. Comments are
+// threaded: a reply to another Comment has that Comment's ID as its
+// ParentID. Users are mentioned in the Value by "@" followed by their
+// usernames.
+type Comment struct {
+	ID                int       `json:"id" db:"id"`
+	ParentID          *int      `json:"parentId" db:"parent_id"`
+	DeliveryService   *string   `json:"deliveryService" db:"deliveryservice"`
+	DeliveryServiceID *int      `json:"deliveryServiceId" db:"deliveryservice_id"`
+	Server            *string   `json:"server" db:"server"`
+	ServerID          *int      `json:"serverId" db:"server_id"`
+	Cachegroup        *string   `json:"cachegroup" db:"cachegroup"`
+	CachegroupID      *int      `json:"cachegroupId" db:"cachegroup_id"`
+	Author            string    `json:"author" db:"author"`
+	Value             string    `json:"value" db:"value"`
+	Mentions          []string  `json:"mentions" db:"mentions"`
+	Created           time.Time `json:"created" db:"created"`
+	LastUpdated       time.Time `json:"lastUpdated" db:"last_updated"`
+}
+
+// Validate validates that the CommentRequest is valid for creation of a
+// Comment. It doesn't check that the parent is of the same object.
+func (c *CommentRequest) Validate(tx *sql.Tx) error {
+	errs := tovalidate.ToErrors(validation.Errors{
+		"value": validation.Validate(c.Value, validation.Required),
+	})
+	if c.ParentID != nil && *c.ParentID <= 0 {
+		errs = append(errs, errors.New("parentId: must be a valid Comment ID"))
+	}
+
+	targets := 0
+	for _, id := range []*int{c.DeliveryServiceID, c.ServerID, c.CachegroupID} {
+		if id != nil {
+			targets++
+		}
+	}
+	if targets != 1 {
+		errs = append(errs, errors.New("exactly one of 'deliveryServiceId', 'serverId', and 'cachegroupId' must be given"))
+	}
+	return util.JoinErrs(errs)
+}
+
+// Validate validates that the CommentUpdateRequest is valid for modification
+// of a Comment.
+func (c *CommentUpdateRequest) Validate(tx *sql.Tx) error {
+	return util.JoinErrs(tovalidate.ToErrors(validation.Errors{
+		"value": validation.Validate(c.Value, validation.Required),
+	}))
+}
@@ -0,0 +1,69 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestCommentRequestValidate(t *testing.T) {
+	valid := CommentRequest{
+		ServerID: util.IntPtr(1),
+		Value:    "don't reboot, flaky RAID controller",
+	}
+	if err := valid.Validate(nil); err != nil {
+		t.Errorf("expected valid request to pass validation, got: %v", err)
+	}
+
+	reply := valid
+	reply.ParentID = util.IntPtr(3)
+	if err := reply.Validate(nil); err != nil {
+		t.Errorf("expected valid reply to pass validation, got: %v", err)
+	}
+
+	badParent := valid
+	badParent.ParentID = util.IntPtr(0)
+	if err := badParent.Validate(nil); err == nil {
+		t.Error("expected request with invalid parent ID to fail validation")
+	}
+
+	noTarget := valid
+	noTarget.ServerID = nil
+	if err := noTarget.Validate(nil); err == nil {
+		t.Error("expected request with no target to fail validation")
+	}
+
+	twoTargets := valid
+	twoTargets.CachegroupID = util.IntPtr(2)
+	if err := twoTargets.Validate(nil); err == nil {
+		t.Error("expected request with two targets to fail validation")
+	}
+
+	empty := valid
+	empty.Value = ""
+	if err := empty.Validate(nil); err == nil {
+		t.Error("expected request with no value to fail validation")
+	}
+	if err := (&CommentUpdateRequest{}).Validate(nil); err == nil {
+		t.Error("expected update request with no value to fail validation")
+	}
+}
@@ -0,0 +1,99 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// These are the kinds of secret stored in Traffic Vault to which a
+// ConfigExport may refer.
+const (
+	// ConfigExportVaultSSLKeys refers to the SSL keys of a Delivery Service.
+	ConfigExportVaultSSLKeys = "ssl"
+	// ConfigExportVaultURLSigKeys refers to the URL signing keys of a
+	// Delivery Service, also used by its token authentication.
+	ConfigExportVaultURLSigKeys = "url_sig"
+	// ConfigExportVaultURISigningKeys refers to the URI signing keys of a
+	// Delivery Service.
+	ConfigExportVaultURISigningKeys = "uri_signing"
+	// ConfigExportVaultDNSSECKeys refers to the DNSSEC keys of a CDN.
+	ConfigExportVaultDNSSECKeys = "dnssec"
+)
+
+// ConfigExport is a logical export of the configuration of the CDNs managed
+// by Traffic Ops, as returned by the /system/export endpoint. It holds the
+// rows of each configuration table of the Traffic Ops Database, read in a
+// single consistent snapshot, in the order in which they may be imported.
+type ConfigExport struct {
+	// SchemaVersion is the version of the last migration run on the
+	// database from which the configuration was exported. Configuration
+	// may only be imported into a database with the same version.
+	SchemaVersion int64 `json:"schemaVersion"`
+	// Timestamp is the time at which the configuration was exported.
+	Timestamp time.Time `json:"timestamp"`
+	// ExportedBy is the name of the user who exported the configuration.
+	ExportedBy string `json:"exportedBy"`
+	// Tenant is the name of the Tenant of the user who exported the
+	// configuration; only the configuration of that Tenant and its
+	// descendants is exported.
+	Tenant string `json:"tenant"`
+	// IncludesSecrets is whether or not the export includes passwords,
+	// credentials and the values of secure Parameters.
+	IncludesSecrets bool `json:"includesSecrets"`
+	// Tables are the exported tables, in the order in which they may be
+	// imported.
+	Tables []ConfigExportTable `json:"tables"`
+	// VaultReferences are the secrets stored in Traffic Vault for the
+	// exported configuration, if they were requested. The secrets
+	// themselves are never exported.
+	VaultReferences []ConfigExportVaultReference `json:"vaultReferences,omitempty"`
+}
+
+// ConfigExportTable is a single table of a ConfigExport.
+type ConfigExportTable struct {
+	// Name is the name of the table.
+	Name string `json:"name"`
+	// Rows are the rows of the table, as an array of objects with a
+	// property for each column.
+	Rows json.RawMessage `json:"rows"`
+}
+
+// ConfigExportVaultReference refers to secrets stored in Traffic Vault which
+// belong to exported configuration.
+type ConfigExportVaultReference struct {
+	// Type is the kind of secret - one of the ConfigExportVault constants.
+	Type string `json:"type"`
+	// DeliveryService is the XMLID of the Delivery Service to which the
+	// secret belongs, if any.
+	DeliveryService *string `json:"deliveryService,omitempty"`
+	// CDN is the name of the CDN to which the secret belongs, if any.
+	CDN *string `json:"cdn,omitempty"`
+	// Version is the version of the secret, for those which are versioned.
+	Version *int64 `json:"version,omitempty"`
+}
+
+// ConfigExportResponse is the type of a response from Traffic Ops to requests
+// made to its /system/export endpoint.
+type ConfigExportResponse struct {
+	Response ConfigExport `json:"response"`
+	Alerts
+}
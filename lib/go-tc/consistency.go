@@ -0,0 +1,84 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"time"
+)
+
+// A ConsistencyCheck is the name of an invariant across the data in Traffic
+// Ops which foreign keys can't express, checked by its consistency checker.
+type ConsistencyCheck string
+
+// These are the consistency checks.
+const (
+	// ConsistencyCheckTopologyServerTypes checks that the Cache Groups of
+	// the Topologies used by Delivery Services have servers of the right
+	// types in the Delivery Services' CDNs: EDGE-type servers for the edge
+	// tier, and EDGE- or MID-type servers for parent tiers.
+	ConsistencyCheckTopologyServerTypes ConsistencyCheck = "topologyServerTypes"
+	// ConsistencyCheckRequiredCapabilities checks that the required
+	// capabilities of Delivery Services can be satisfied: that each edge
+	// tier Cache Group of a Topology-based Delivery Service has a server with
+	// all of them, and that each edge-tier server assigned to a Delivery
+	// Service without a Topology has all of them.
+	ConsistencyCheckRequiredCapabilities ConsistencyCheck = "requiredCapabilities"
+	// ConsistencyCheckSnapshotStaleness checks that the Snapshot of each CDN
+	// isn't older than a configured number of changes to its Delivery
+	// Services and servers, and that CDNs with Delivery Services or servers
+	// have Snapshots.
+	ConsistencyCheckSnapshotStaleness ConsistencyCheck = "snapshotStaleness"
+)
+
+// ConsistencyCheckQueryParam is the query parameter of /system/consistency
+// which restricts the violations returned to those of the given check.
+const ConsistencyCheckQueryParam = "check"
+
+// ConsistencyViolation is a violation of an invariant found by the
+// consistency checker.
+type ConsistencyViolation struct {
+	// Check is the check which found the violation.
+	Check ConsistencyCheck `json:"check"`
+	// Subject identifies the objects in violation, e.g.
+	// "Topology: demo1-top, Cache Group: CDN_in_a_Box_Edge, CDN: CDN-in-a-Box".
+	// A check finds at most one violation of each subject.
+	Subject string `json:"subject"`
+	// Message describes the violation.
+	Message string `json:"message"`
+	// FirstDetected is when the violation was first found by the checker.
+	FirstDetected time.Time `json:"firstDetected"`
+	// LastDetected is when the violation was last found by the checker.
+	LastDetected time.Time `json:"lastDetected"`
+}
+
+// ConsistencyReport is the result of the last run of the consistency checker.
+type ConsistencyReport struct {
+	// CheckedAt is when the checker last ran, or nil if it never has.
+	CheckedAt *time.Time `json:"checkedAt"`
+	// Violations are the violations the checker found when it last ran.
+	Violations []ConsistencyViolation `json:"violations"`
+}
+
+// ConsistencyReportResponse is the type of a response from Traffic Ops to a
+// GET request made to its /system/consistency endpoint.
+type ConsistencyReportResponse struct {
+	Response ConsistencyReport `json:"response"`
+	Alerts
+}
@@ -26,6 +26,7 @@ type CoordinatesResponse struct {
 	// in: body
 	Response []Coordinate `json:"response"`
 	Alerts
+	Paginated
 }
 
 // CoordinateResponse is a single Coordinate response for Update and Create to
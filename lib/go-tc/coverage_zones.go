@@ -0,0 +1,225 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// CoverageZoneCoordinates are the coordinates of a zone of a Coverage Zone
+// File.
+type CoverageZoneCoordinates struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// CDNCoverageZones is the Coverage Zone File of a CDN stored in Traffic Ops,
+// which its Traffic Routers can poll in place of one hosted elsewhere.
+type CDNCoverageZones struct {
+	// CDNName is the name of the CDN to which the file belongs.
+	CDNName string `json:"cdnName"`
+	// File is the Coverage Zone File.
+	File CoverageZoneFile `json:"file"`
+	// LastUpdated is the time at which the file was last replaced.
+	LastUpdated *time.Time `json:"lastUpdated,omitempty"`
+}
+
+// CDNCoverageZonesResponse is the type of a response from Traffic Ops to a
+// request for a CDN's Coverage Zone File.
+type CDNCoverageZonesResponse struct {
+	Response CDNCoverageZones `json:"response"`
+	Alerts
+}
+
+// Validate validates the Coverage Zone File, and rewrites the networks of its
+// zones in canonical form - masked to their prefix lengths, sorted, and
+// without duplicates - so that files with the same zones compare equal. IPv4
+// networks must be listed in "network" and IPv6 networks in "network6", and
+// no network may be listed by more than one zone, since Traffic Router would
+// have no way of choosing between them.
+func (f *CoverageZoneFile) Validate() error {
+	errs := []error{}
+	if len(f.CoverageZones) == 0 {
+		errs = append(errs, errors.New("coverageZones: cannot be empty"))
+	}
+	zoneOfNetwork := map[string]string{}
+	for _, name := range f.ZoneNames() {
+		zone := f.CoverageZones[name]
+		if strings.TrimSpace(name) == "" {
+			errs = append(errs, errors.New("coverageZones: zone names cannot be blank"))
+		}
+		var err error
+		if zone.Network, err = canonicalNetworks(zone.Network, true); err != nil {
+			errs = append(errs, fmt.Errorf("coverageZones.%s.network: %w", name, err))
+		}
+		if zone.Network6, err = canonicalNetworks(zone.Network6, false); err != nil {
+			errs = append(errs, fmt.Errorf("coverageZones.%s.network6: %w", name, err))
+		}
+		if len(zone.Network) == 0 && len(zone.Network6) == 0 {
+			errs = append(errs, fmt.Errorf("coverageZones.%s: must have at least one network", name))
+		}
+		for _, network := range append(append([]string{}, zone.Network...), zone.Network6...) {
+			if other, ok := zoneOfNetwork[network]; ok {
+				errs = append(errs, fmt.Errorf("coverageZones.%s: network %s is also in zone %s", name, network, other))
+			}
+			zoneOfNetwork[network] = name
+		}
+		if c := zone.Coordinates; c != nil && (c.Latitude < -90 || c.Latitude > 90 || c.Longitude < -180 || c.Longitude > 180) {
+			errs = append(errs, fmt.Errorf("coverageZones.%s.coordinates: latitude must be between -90 and 90, and longitude between -180 and 180", name))
+		}
+		f.CoverageZones[name] = zone
+	}
+	return util.JoinErrs(errs)
+}
+
+// ZoneNames returns the names of the zones of the Coverage Zone File, sorted.
+func (f CoverageZoneFile) ZoneNames() []string {
+	names := make([]string, 0, len(f.CoverageZones))
+	for name := range f.CoverageZones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// canonicalNetworks returns the given CIDR-notation networks masked to their
+// prefix lengths, sorted, and without duplicates. They must all be of the
+// given IP version.
+func canonicalNetworks(networks []string, ipv4 bool) ([]string, error) {
+	prefixes := make([]netip.Prefix, 0, len(networks))
+	for _, network := range networks {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(network))
+		if err != nil {
+			return nil, fmt.Errorf("'%s' is not a CIDR-notation network", network)
+		}
+		if prefix.Addr().Is4() != ipv4 {
+			if ipv4 {
+				return nil, fmt.Errorf("'%s' is not an IPv4 network", network)
+			}
+			return nil, fmt.Errorf("'%s' is not an IPv6 network", network)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	SortPrefixes(prefixes)
+	canonical := make([]string, 0, len(prefixes))
+	for i, prefix := range prefixes {
+		if i > 0 && prefix == prefixes[i-1] {
+			continue
+		}
+		canonical = append(canonical, prefix.String())
+	}
+	return canonical, nil
+}
+
+// SortPrefixes sorts the given network prefixes by address, and then by
+// prefix length, shortest first.
+func SortPrefixes(prefixes []netip.Prefix) {
+	sort.Slice(prefixes, func(i, j int) bool {
+		if c := prefixes[i].Addr().Compare(prefixes[j].Addr()); c != 0 {
+			return c < 0
+		}
+		return prefixes[i].Bits() < prefixes[j].Bits()
+	})
+}
+
+// CoverageZoneDiff is the difference between a zone of one Coverage Zone File
+// and the zone of the same name in another.
+type CoverageZoneDiff struct {
+	// Zone is the name of the zone.
+	Zone string `json:"zone"`
+	// Added is whether the zone is only in the new file.
+	Added bool `json:"added"`
+	// Removed is whether the zone is only in the old file.
+	Removed bool `json:"removed"`
+	// AddedNetworks are the IPv4 and IPv6 networks only in the new zone.
+	AddedNetworks []string `json:"addedNetworks"`
+	// RemovedNetworks are the IPv4 and IPv6 networks only in the old zone.
+	RemovedNetworks []string `json:"removedNetworks"`
+	// CoordinatesChanged is whether the coordinates of the zone differ.
+	CoordinatesChanged bool `json:"coordinatesChanged"`
+}
+
+// DiffCoverageZoneFiles returns the differences between the zones of two
+// Coverage Zone Files, in order of zone name. Zones which are the same in
+// both aren't included. Both files should be valid, so that their networks
+// are canonical.
+func DiffCoverageZoneFiles(oldFile, newFile CoverageZoneFile) []CoverageZoneDiff {
+	names := map[string]struct{}{}
+	for name := range oldFile.CoverageZones {
+		names[name] = struct{}{}
+	}
+	for name := range newFile.CoverageZones {
+		names[name] = struct{}{}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	diffs := []CoverageZoneDiff{}
+	for _, name := range sorted {
+		oldZone, inOld := oldFile.CoverageZones[name]
+		newZone, inNew := newFile.CoverageZones[name]
+		oldNetworks := append(append([]string{}, oldZone.Network...), oldZone.Network6...)
+		newNetworks := append(append([]string{}, newZone.Network...), newZone.Network6...)
+		diff := CoverageZoneDiff{
+			Zone:               name,
+			Added:              !inOld,
+			Removed:            !inNew,
+			AddedNetworks:      networksNotIn(newNetworks, oldNetworks),
+			RemovedNetworks:    networksNotIn(oldNetworks, newNetworks),
+			CoordinatesChanged: inOld && inNew && !sameCoordinates(oldZone.Coordinates, newZone.Coordinates),
+		}
+		if diff.Added || diff.Removed || len(diff.AddedNetworks) > 0 || len(diff.RemovedNetworks) > 0 || diff.CoordinatesChanged {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
+
+// networksNotIn returns the networks of a which aren't in b.
+func networksNotIn(a, b []string) []string {
+	inB := make(map[string]struct{}, len(b))
+	for _, network := range b {
+		inB[network] = struct{}{}
+	}
+	notIn := []string{}
+	for _, network := range a {
+		if _, ok := inB[network]; !ok {
+			notIn = append(notIn, network)
+		}
+	}
+	return notIn
+}
+
+func sameCoordinates(a, b *CoverageZoneCoordinates) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,91 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCoverageZoneFileValidate(t *testing.T) {
+	f := CoverageZoneFile{
+		CoverageZones: map[string]CoverageZoneLocation{
+			"us-east": {
+				Network:  []string{"192.0.2.0/24", "198.51.100.7/24", "192.0.2.0/24"},
+				Network6: []string{"2001:DB8:0:0::/32"},
+			},
+		},
+	}
+	if err := f.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	zone := f.CoverageZones["us-east"]
+	if expected := []string{"192.0.2.0/24", "198.51.100.0/24"}; !reflect.DeepEqual(zone.Network, expected) {
+		t.Errorf("expected networks %v, got %v", expected, zone.Network)
+	}
+	if expected := []string{"2001:db8::/32"}; !reflect.DeepEqual(zone.Network6, expected) {
+		t.Errorf("expected IPv6 networks %v, got %v", expected, zone.Network6)
+	}
+
+	invalid := map[string]CoverageZoneFile{
+		"no zones":         {},
+		"no networks":      {CoverageZones: map[string]CoverageZoneLocation{"a": {}}},
+		"bad network":      {CoverageZones: map[string]CoverageZoneLocation{"a": {Network: []string{"192.0.2.1"}}}},
+		"IPv6 in network":  {CoverageZones: map[string]CoverageZoneLocation{"a": {Network: []string{"2001:db8::/32"}}}},
+		"IPv4 in network6": {CoverageZones: map[string]CoverageZoneLocation{"a": {Network6: []string{"192.0.2.0/24"}}}},
+		"shared network": {CoverageZones: map[string]CoverageZoneLocation{
+			"a": {Network: []string{"192.0.2.0/24"}},
+			"b": {Network: []string{"192.0.2.128/24"}},
+		}},
+		"bad coordinates": {CoverageZones: map[string]CoverageZoneLocation{"a": {Network: []string{"192.0.2.0/24"}, Coordinates: &CoverageZoneCoordinates{Latitude: 91}}}},
+	}
+	for name, f := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if err := f.Validate(); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestDiffCoverageZoneFiles(t *testing.T) {
+	oldFile := CoverageZoneFile{CoverageZones: map[string]CoverageZoneLocation{
+		"same":    {Network: []string{"192.0.2.0/24"}},
+		"changed": {Network: []string{"198.51.100.0/24"}, Coordinates: &CoverageZoneCoordinates{Latitude: 1, Longitude: 2}},
+		"removed": {Network6: []string{"2001:db8::/32"}},
+	}}
+	newFile := CoverageZoneFile{CoverageZones: map[string]CoverageZoneLocation{
+		"same":    {Network: []string{"192.0.2.0/24"}},
+		"changed": {Network: []string{"203.0.113.0/24"}, Coordinates: &CoverageZoneCoordinates{Latitude: 1, Longitude: 2}},
+		"added":   {Network: []string{"198.51.100.0/25"}},
+	}}
+
+	expected := []CoverageZoneDiff{
+		{Zone: "added", Added: true, AddedNetworks: []string{"198.51.100.0/25"}, RemovedNetworks: []string{}},
+		{Zone: "changed", AddedNetworks: []string{"203.0.113.0/24"}, RemovedNetworks: []string{"198.51.100.0/24"}},
+		{Zone: "removed", Removed: true, AddedNetworks: []string{}, RemovedNetworks: []string{"2001:db8::/32"}},
+	}
+	if diffs := DiffCoverageZoneFiles(oldFile, newFile); !reflect.DeepEqual(diffs, expected) {
+		t.Errorf("expected differences %+v, got %+v", expected, diffs)
+	}
+	if diffs := DiffCoverageZoneFiles(oldFile, oldFile); len(diffs) != 0 {
+		t.Errorf("expected no differences between a file and itself, got %+v", diffs)
+	}
+}
@@ -128,15 +128,37 @@ type CRConfigTrafficOpsServer struct {
 	ServerType       *string               `json:"type,omitempty"`
 	DeliveryServices map[string][]string   `json:"deliveryServices,omitempty"`
 	RoutingDisabled  int64                 `json:"routingDisabled"`
+	// DeliveryServiceAddresses are the addresses to which clients are routed
+	// for the Delivery Services, by XMLID, with RoutingInterfaces which
+	// select addresses other than the server's Ip and Ip6.
+	DeliveryServiceAddresses map[string]CRConfigServerAddresses `json:"deliveryServiceAddresses,omitempty"`
+}
+
+// CRConfigServerAddresses are the addresses of a cache server to which
+// clients are routed for a Delivery Service, named with "CRConfig" for legacy
+// reasons. Either may be empty if the server has no address of that family
+// for the Delivery Service.
+type CRConfigServerAddresses struct {
+	IP  string `json:"ip"`
+	IP6 string `json:"ip6"`
 }
 
 // CRConfigDeliveryService represents a Delivery Service as they appear in CDN
 // Snapshots, named with "CRConfig" for legacy reasons.
+//
+// ActiveAt and InactiveAt are the times, in seconds since the Unix epoch, at
+// which a Delivery Service scheduled to become active or inactive may first,
+// or may no longer, be routed. ActiveAt is only set for Delivery Services that
+// are not yet active.
 type CRConfigDeliveryService struct {
+	ActiveAt                  *int64                                `json:"activeAt,omitempty"`
 	AnonymousBlockingEnabled  *string                               `json:"anonymousBlockingEnabled,omitempty"`
 	BypassDestination         map[string]*CRConfigBypassDestination `json:"bypassDestination,omitempty"`
+	ConsistentHashHeaders     []string                              `json:"consistentHashHeaders,omitempty"`
+	ConsistentHashIncludePath *bool                                 `json:"consistentHashIncludePath,omitempty"`
 	ConsistentHashQueryParams []string                              `json:"consistentHashQueryParams,omitempty"`
 	ConsistentHashRegex       *string                               `json:"consistentHashRegex,omitempty"`
+	ConsistentHashReplicas    *int                                  `json:"consistentHashReplicas,omitempty"`
 	CoverageZoneOnly          bool                                  `json:"coverageZoneOnly,string"`
 	DeepCachingType           *DeepCachingType                      `json:"deepCachingType"`
 	Dispersion                *CRConfigDispersion                   `json:"dispersion,omitempty"`
@@ -145,6 +167,7 @@ type CRConfigDeliveryService struct {
 	GeoEnabled                []CRConfigGeoEnabled                  `json:"geoEnabled,omitempty"`
 	GeoLimitRedirectURL       *string                               `json:"geoLimitRedirectURL,omitempty"`
 	GeoLocationProvider       *string                               `json:"geolocationProvider,omitempty"`
+	InactiveAt                *int64                                `json:"inactiveAt,omitempty"`
 	IP6RoutingEnabled         *bool                                 `json:"ip6RoutingEnabled,string,omitempty"`
 	MatchSets                 []*MatchSet                           `json:"matchsets,omitempty"`
 	MaxDNSIPsForLocation      *int                                  `json:"maxDnsIpsForLocation,omitempty"`
@@ -54,6 +54,21 @@ type CRSStatsStat struct {
 	RegionalAlternateCount uint64 `json:"regionalAlternateCount"`
 }
 
+// Total returns the total number of requests counted in the stat, regardless
+// of how they were routed.
+func (s CRSStatsStat) Total() uint64 {
+	return s.CZCount +
+		s.GeoCount +
+		s.DeepCZCount +
+		s.MissCount +
+		s.DSRCount +
+		s.ErrCount +
+		s.StaticRouteCount +
+		s.FedCount +
+		s.RegionalDeniedCount +
+		s.RegionalAlternateCount
+}
+
 // Routing represents the aggregated routing percentages across CDNs or for a DS.
 type Routing struct {
 	StaticRoute       float64 `json:"staticRoute"`
@@ -28,6 +28,22 @@ import (
 type CRStates struct {
 	Caches          map[CacheName]IsAvailable                       `json:"caches"`
 	DeliveryService map[DeliveryServiceName]CRStatesDeliveryService `json:"deliveryServices"`
+	ExternalTargets map[string]CRStatesExternalTarget               `json:"externalTargets,omitempty"`
+	Routers         map[string]CRStatesRouter                       `json:"routers,omitempty"`
+}
+
+// CRStatesExternalTarget contains data about the availability of a particular steering external target, keyed by its name.
+type CRStatesExternalTarget struct {
+	IsAvailable bool      `json:"isAvailable"`
+	LastPoll    time.Time `json:"lastPoll"`
+}
+
+// CRStatesRouter contains data about the availability and query rates of a particular Traffic Router, keyed by its hostname.
+type CRStatesRouter struct {
+	IsAvailable          bool      `json:"isAvailable"`
+	DNSQueriesPerSecond  float64   `json:"dnsQueriesPerSecond"`
+	HTTPQueriesPerSecond float64   `json:"httpQueriesPerSecond"`
+	LastPoll             time.Time `json:"lastPoll"`
 }
 
 // CRStatesDeliveryService contains data about the availability of a particular delivery service, and which caches in that delivery service have been marked as unavailable.
@@ -51,6 +67,8 @@ func NewCRStates(cacheCap, dsCap int) CRStates {
 	return CRStates{
 		Caches:          make(map[CacheName]IsAvailable, cacheCap),
 		DeliveryService: make(map[DeliveryServiceName]CRStatesDeliveryService, dsCap),
+		ExternalTargets: map[string]CRStatesExternalTarget{},
+		Routers:         map[string]CRStatesRouter{},
 	}
 }
 
@@ -63,6 +81,12 @@ func (a CRStates) Copy() CRStates {
 	for k, v := range a.DeliveryService {
 		b.DeliveryService[k] = v
 	}
+	for k, v := range a.ExternalTargets {
+		b.ExternalTargets[k] = v
+	}
+	for k, v := range a.Routers {
+		b.Routers[k] = v
+	}
 	return b
 }
 
@@ -0,0 +1,173 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"sort"
+	"time"
+)
+
+// LogAgentCacheHitsPath is the path of the t3c-log-agent endpoint which
+// serves the CacheHitWindows it has counted from the access log.
+const LogAgentCacheHitsPath = "/stats/cache_hits"
+
+// LogAgentCacheHitsSinceQueryParam is the query parameter of the RFC3339 time
+// after which the windows served by t3c-log-agent must end.
+const LogAgentCacheHitsSinceQueryParam = "since"
+
+// These are the query parameters of requests to
+// /deliveryservices/{{ID}}/cache_hit_ratio.
+const (
+	// CacheHitRatioStartQueryParam is the RFC3339 time from which hits and
+	// misses are counted.
+	CacheHitRatioStartQueryParam = "start"
+	// CacheHitRatioEndQueryParam is the RFC3339 time until which hits and
+	// misses are counted.
+	CacheHitRatioEndQueryParam = "end"
+	// CacheHitRatioTopMissesQueryParam is how many of the most missed URLs
+	// of each tier to return.
+	CacheHitRatioTopMissesQueryParam = "topMisses"
+)
+
+// DefaultCacheHitRatioHours is how many hours before its end a cache hit
+// ratio is counted from, if its start isn't given.
+const DefaultCacheHitRatioHours = 24
+
+// DefaultCacheHitRatioTopMisses is how many of the most missed URLs of each
+// tier are returned, if not given.
+const DefaultCacheHitRatioTopMisses = 10
+
+// MaxCacheMissSamples is the most missed URLs sampled for each host by
+// t3c-log-agent, and kept for each Delivery Service by Traffic Ops, in each
+// window. It's also the most that may be requested from
+// /deliveryservices/{{ID}}/cache_hit_ratio.
+const MaxCacheMissSamples = 100
+
+// CacheMissSample is a URL which was missed, and how many times.
+type CacheMissSample struct {
+	// URL is the URL, without its query string.
+	URL string `json:"url"`
+	// Count is how many times the URL was missed.
+	Count uint64 `json:"count"`
+}
+
+// CacheHitCounts are the cache hits and misses of the requests for a host,
+// or of a Delivery Service.
+type CacheHitCounts struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+	// MissSamples are the most missed URLs, most missed first. Their counts
+	// are estimates, which may be too high but not too low, and they're only
+	// some of the misses.
+	MissSamples []CacheMissSample `json:"missSamples"`
+}
+
+// CacheHitWindow is the cache hits and misses of the requests a cache server
+// logged in a period of time, by the hosts requested, as counted by
+// t3c-log-agent.
+type CacheHitWindow struct {
+	Start time.Time                 `json:"start"`
+	End   time.Time                 `json:"end"`
+	Hosts map[string]CacheHitCounts `json:"hosts"`
+}
+
+// MergeCacheMissSamples returns the given samples combined, with the counts
+// of the same URL summed, sorted from most to least missed, and limited to
+// the max most missed.
+func MergeCacheMissSamples(max int, samples ...[]CacheMissSample) []CacheMissSample {
+	counts := map[string]uint64{}
+	for _, s := range samples {
+		for _, sample := range s {
+			counts[sample.URL] += sample.Count
+		}
+	}
+	merged := make([]CacheMissSample, 0, len(counts))
+	for url, count := range counts {
+		merged = append(merged, CacheMissSample{URL: url, Count: count})
+	}
+	SortCacheMissSamples(merged)
+	if len(merged) > max {
+		merged = merged[:max]
+	}
+	return merged
+}
+
+// SortCacheMissSamples sorts samples from most to least missed, and then by
+// URL.
+func SortCacheMissSamples(samples []CacheMissSample) {
+	sort.Slice(samples, func(i, j int) bool {
+		if samples[i].Count != samples[j].Count {
+			return samples[i].Count > samples[j].Count
+		}
+		return samples[i].URL < samples[j].URL
+	})
+}
+
+// CacheHitTier is the cache hits and misses of a Delivery Service on the
+// cache servers of one tier.
+type CacheHitTier struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+	// HitRatio is the fraction of requests which were hits, or nil if there
+	// were none.
+	HitRatio *float64 `json:"hitRatio"`
+	// TopMisses are the most missed URLs, most missed first.
+	TopMisses []CacheMissSample `json:"topMisses"`
+}
+
+// NewCacheHitTier returns the tier with the given hits, misses, and most
+// missed URLs, and the hit ratio they make.
+func NewCacheHitTier(hits uint64, misses uint64, topMisses []CacheMissSample) CacheHitTier {
+	tier := CacheHitTier{Hits: hits, Misses: misses, TopMisses: topMisses}
+	if tier.TopMisses == nil {
+		tier.TopMisses = []CacheMissSample{}
+	}
+	if total := hits + misses; total > 0 {
+		ratio := float64(hits) / float64(total)
+		tier.HitRatio = &ratio
+	}
+	return tier
+}
+
+// DeliveryServiceCacheHitRatio is the cache hit ratio of a Delivery Service
+// on its edge and mid tiers over a period of time, counted by the
+// t3c-log-agents of cache servers from their access logs.
+type DeliveryServiceCacheHitRatio struct {
+	// DeliveryServiceID is the integral, unique identifier of the Delivery
+	// Service.
+	DeliveryServiceID int `json:"deliveryServiceId"`
+	// XMLID is the XMLID of the Delivery Service.
+	XMLID string `json:"xmlId"`
+	// Start and End are the period in which hits and misses were counted.
+	// Only windows wholly within it are counted.
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Edge is the hits and misses of the Delivery Service's edge tier.
+	Edge CacheHitTier `json:"edge"`
+	// Mid is the hits and misses of the Delivery Service's mid tier.
+	Mid CacheHitTier `json:"mid"`
+}
+
+// DeliveryServiceCacheHitRatioResponse is the type of a response from Traffic
+// Ops to a request to its /deliveryservices/{{ID}}/cache_hit_ratio endpoint.
+type DeliveryServiceCacheHitRatioResponse struct {
+	Response DeliveryServiceCacheHitRatio `json:"response"`
+	Alerts
+}
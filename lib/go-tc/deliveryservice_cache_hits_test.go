@@ -0,0 +1,53 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeCacheMissSamples(t *testing.T) {
+	a := []CacheMissSample{{URL: "http://a/1", Count: 5}, {URL: "http://a/2", Count: 1}}
+	b := []CacheMissSample{{URL: "http://a/2", Count: 3}, {URL: "http://a/3", Count: 4}, {URL: "http://a/0", Count: 4}}
+
+	merged := MergeCacheMissSamples(3, a, b)
+	expected := []CacheMissSample{{URL: "http://a/1", Count: 5}, {URL: "http://a/0", Count: 4}, {URL: "http://a/2", Count: 4}}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("expected %v, got %v", expected, merged)
+	}
+	if merged := MergeCacheMissSamples(10); len(merged) != 0 {
+		t.Errorf("expected no samples, got %v", merged)
+	}
+}
+
+func TestNewCacheHitTier(t *testing.T) {
+	tier := NewCacheHitTier(0, 0, nil)
+	if tier.HitRatio != nil {
+		t.Errorf("expected no hit ratio without requests, got %f", *tier.HitRatio)
+	}
+	if tier.TopMisses == nil {
+		t.Error("expected empty top misses, got nil")
+	}
+	tier = NewCacheHitTier(3, 1, nil)
+	if tier.HitRatio == nil || *tier.HitRatio != 0.75 {
+		t.Errorf("expected hit ratio 0.75, got %v", tier.HitRatio)
+	}
+}
@@ -0,0 +1,133 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// cachePolicyHeaderNameRe matches the "token" grammar of RFC 7230, to which
+// HTTP header names must conform.
+var cachePolicyHeaderNameRe = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+// CachePolicyDefaultTTL is the time for which responses with a certain status
+// code are cached when the origin doesn't say for how long they may be.
+type CachePolicyDefaultTTL struct {
+	// StatusCode is the HTTP status code of the responses.
+	StatusCode int `json:"statusCode"`
+	// TTL is the time, in seconds, for which the responses are cached.
+	TTL int `json:"ttl"`
+}
+
+// DeliveryServiceCachePolicy is the policy with which cache servers override
+// the caching instructions of a Delivery Service's origin.
+type DeliveryServiceCachePolicy struct {
+	// DeliveryServiceID is the integral, unique identifier of the Delivery
+	// Service.
+	DeliveryServiceID int `json:"deliveryServiceId"`
+	// XMLID is the XMLID of the Delivery Service.
+	XMLID string `json:"xmlId"`
+	// IgnoreOriginNoCache is whether or not cache servers cache responses
+	// even though the origin says they must not be, e.g. with
+	// "Cache-Control: no-cache".
+	IgnoreOriginNoCache bool `json:"ignoreOriginNoCache"`
+	// DefaultTTLs are the TTLs of responses, by status code, which don't
+	// have a Cache-Control header.
+	DefaultTTLs []CachePolicyDefaultTTL `json:"defaultTTLs"`
+	// MaxTTL, if set, is the longest time, in seconds, for which a response
+	// is served from cache without being revalidated with the origin.
+	MaxTTL *int `json:"maxTTL"`
+	// HonorVary, if not nil, is the list of the names of request headers in
+	// the origin's Vary response header which are honored; the others are
+	// ignored. If nil, all of them are honored.
+	HonorVary []string `json:"honorVary"`
+	// LastUpdated is the time at which the Delivery Service's cache policy
+	// was last modified, or nil if it doesn't have one.
+	LastUpdated *time.Time `json:"lastUpdated"`
+}
+
+// DeliveryServiceCachePolicyRequest is the type of a request to replace the
+// cache policy of a Delivery Service.
+type DeliveryServiceCachePolicyRequest struct {
+	IgnoreOriginNoCache bool                    `json:"ignoreOriginNoCache"`
+	DefaultTTLs         []CachePolicyDefaultTTL `json:"defaultTTLs"`
+	MaxTTL              *int                    `json:"maxTTL"`
+	HonorVary           []string                `json:"honorVary"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface.
+func (r *DeliveryServiceCachePolicyRequest) Validate(*sql.Tx) error {
+	errs := []error{}
+	if r.MaxTTL != nil && *r.MaxTTL <= 0 {
+		errs = append(errs, errors.New("maxTTL: must be greater than zero"))
+	}
+
+	statuses := map[int]struct{}{}
+	for i, ttl := range r.DefaultTTLs {
+		if ttl.StatusCode < 200 || ttl.StatusCode > 599 {
+			errs = append(errs, fmt.Errorf("defaultTTLs[%d].statusCode: must be between 200 and 599", i))
+		} else if _, ok := statuses[ttl.StatusCode]; ok {
+			errs = append(errs, fmt.Errorf("defaultTTLs[%d].statusCode: duplicate status code %d", i, ttl.StatusCode))
+		}
+		statuses[ttl.StatusCode] = struct{}{}
+		if ttl.TTL < 0 {
+			errs = append(errs, fmt.Errorf("defaultTTLs[%d].ttl: cannot be negative", i))
+		} else if r.MaxTTL != nil && ttl.TTL > *r.MaxTTL {
+			errs = append(errs, fmt.Errorf("defaultTTLs[%d].ttl: cannot exceed maxTTL", i))
+		}
+	}
+
+	headers := map[string]struct{}{}
+	for i, header := range r.HonorVary {
+		if !cachePolicyHeaderNameRe.MatchString(header) {
+			errs = append(errs, fmt.Errorf("honorVary[%d]: '%s' is not a valid header name", i, header))
+			continue
+		}
+		if _, ok := headers[strings.ToLower(header)]; ok {
+			errs = append(errs, fmt.Errorf("honorVary[%d]: duplicate header '%s'", i, header))
+		}
+		headers[strings.ToLower(header)] = struct{}{}
+	}
+	return util.JoinErrs(errs)
+}
+
+// DeliveryServiceCachePolicyResponse is the type of a response from Traffic
+// Ops to a request to its /deliveryservices/{{ID}}/cache-policy endpoint.
+type DeliveryServiceCachePolicyResponse struct {
+	Response DeliveryServiceCachePolicy `json:"response"`
+	Alerts
+}
+
+// CDNDeliveryServiceCachePoliciesResponse is the type of a response from
+// Traffic Ops to a GET request to its /deliveryservices/cache-policies
+// endpoint.
+type CDNDeliveryServiceCachePoliciesResponse struct {
+	Response []DeliveryServiceCachePolicy `json:"response"`
+	Alerts
+}
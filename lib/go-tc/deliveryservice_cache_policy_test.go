@@ -0,0 +1,58 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestDeliveryServiceCachePolicyRequestValidate(t *testing.T) {
+	req := DeliveryServiceCachePolicyRequest{
+		IgnoreOriginNoCache: true,
+		DefaultTTLs:         []CachePolicyDefaultTTL{{StatusCode: 200, TTL: 3600}, {StatusCode: 404, TTL: 60}},
+		MaxTTL:              util.IntPtr(86400),
+		HonorVary:           []string{"Accept-Encoding", "X-Device-Class"},
+	}
+	if err := req.Validate(nil); err != nil {
+		t.Errorf("expected valid request, got error: %v", err)
+	}
+
+	req = DeliveryServiceCachePolicyRequest{}
+	if err := req.Validate(nil); err != nil {
+		t.Errorf("expected empty request to be valid, got error: %v", err)
+	}
+
+	invalid := map[string]DeliveryServiceCachePolicyRequest{
+		"zero maxTTL":            {MaxTTL: util.IntPtr(0)},
+		"informational status":   {DefaultTTLs: []CachePolicyDefaultTTL{{StatusCode: 100, TTL: 60}}},
+		"duplicate status":       {DefaultTTLs: []CachePolicyDefaultTTL{{StatusCode: 404, TTL: 60}, {StatusCode: 404, TTL: 30}}},
+		"negative TTL":           {DefaultTTLs: []CachePolicyDefaultTTL{{StatusCode: 200, TTL: -1}}},
+		"TTL exceeding maxTTL":   {DefaultTTLs: []CachePolicyDefaultTTL{{StatusCode: 200, TTL: 120}}, MaxTTL: util.IntPtr(60)},
+		"invalid header name":    {HonorVary: []string{"Accept Encoding"}},
+		"duplicate header names": {HonorVary: []string{"Accept-Encoding", "accept-encoding"}},
+	}
+	for name, req := range invalid {
+		if err := req.Validate(nil); err == nil {
+			t.Errorf("expected request with %s to be invalid", name)
+		}
+	}
+}
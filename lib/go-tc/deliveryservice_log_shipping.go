@@ -0,0 +1,272 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// These are the types of destination to which the access logs of a Delivery
+// Service may be shipped.
+const (
+	LogShippingDestinationS3    = "S3"
+	LogShippingDestinationHTTPS = "HTTPS"
+	LogShippingDestinationKafka = "KAFKA"
+)
+
+// These are the formats in which the access logs of a Delivery Service may be
+// shipped.
+const (
+	LogShippingFormatJSON  = "json"
+	LogShippingFormatSquid = "squid"
+)
+
+// These are the access log fields which may be scrubbed of personally
+// identifying information before they're shipped.
+const (
+	LogShippingFieldClientIP  = "clientIp"
+	LogShippingFieldUserAgent = "userAgent"
+	LogShippingFieldReferer   = "referer"
+	LogShippingFieldQuery     = "query"
+)
+
+// These are the actions with which an access log field may be scrubbed.
+const (
+	// LogShippingScrubDrop removes the field's value.
+	LogShippingScrubDrop = "drop"
+	// LogShippingScrubHash replaces the field's value with its SHA-256 hash.
+	LogShippingScrubHash = "hash"
+	// LogShippingScrubMask zeroes the host bits of a client IP address below
+	// its /24 (IPv4) or /48 (IPv6) network. It may only be used on the
+	// clientIp field.
+	LogShippingScrubMask = "mask"
+)
+
+// HiddenLogShippingCredentials replaces the Credentials of log shipping
+// configuration shown to users who may not see them. A request to set log
+// shipping configuration which gives it keeps the current credentials.
+const HiddenLogShippingCredentials = "********"
+
+// DefaultLogShippingSampleRate is the fraction of requests whose logs are
+// shipped if a Delivery Service's log shipping doesn't give one.
+const DefaultLogShippingSampleRate = 1.0
+
+// DeliveryServiceLogShipping is the configuration with which cache servers
+// ship the access logs of a Delivery Service to a destination of its tenant's
+// choosing.
+type DeliveryServiceLogShipping struct {
+	// DeliveryServiceID is the integral, unique identifier of the Delivery
+	// Service.
+	DeliveryServiceID int `json:"deliveryServiceId"`
+	// XMLID is the XMLID of the Delivery Service.
+	XMLID string `json:"xmlId"`
+	// DestinationType is the LogShippingDestination* type of Destination.
+	DestinationType string `json:"destinationType"`
+	// Destination is the URL of the bucket, endpoint, or topic to which logs
+	// are shipped.
+	Destination string `json:"destination"`
+	// Credentials is the secret with which the shipper authenticates to the
+	// destination, if it needs one. It's hidden from users who may not read
+	// Delivery Service security keys.
+	Credentials *string `json:"credentials"`
+	// Format is the LogShippingFormat* format of shipped log lines.
+	Format string `json:"format"`
+	// SampleRate is the fraction of requests, greater than 0 and at most 1,
+	// whose logs are shipped.
+	SampleRate float64 `json:"sampleRate"`
+	// ScrubRules are the rules by which personally identifying information
+	// is removed from logs before they're shipped.
+	ScrubRules []LogShippingScrubRule `json:"scrubRules"`
+	// LastUpdated is the time at which the configuration was last modified.
+	LastUpdated *time.Time `json:"lastUpdated"`
+}
+
+// LogShippingScrubRule is a rule by which an access log field is scrubbed of
+// personally identifying information before it's shipped.
+type LogShippingScrubRule struct {
+	// Field is the LogShippingField* field to scrub.
+	Field string `json:"field"`
+	// Action is the LogShippingScrub* action with which the field is
+	// scrubbed.
+	Action string `json:"action"`
+}
+
+// DeliveryServiceLogShippingRequest is the type of a request to set the log
+// shipping configuration of a Delivery Service.
+type DeliveryServiceLogShippingRequest struct {
+	DestinationType string                 `json:"destinationType"`
+	Destination     string                 `json:"destination"`
+	Credentials     *string                `json:"credentials"`
+	Format          string                 `json:"format"`
+	SampleRate      *float64               `json:"sampleRate"`
+	ScrubRules      []LogShippingScrubRule `json:"scrubRules"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface. It sets the defaults of the optional properties of the request.
+func (r *DeliveryServiceLogShippingRequest) Validate(*sql.Tx) error {
+	errs := []error{}
+	keepCredentials := r.Credentials != nil && *r.Credentials == HiddenLogShippingCredentials
+	switch r.DestinationType {
+	case LogShippingDestinationS3:
+		if _, err := ParseLogShippingS3Destination(r.Destination); err != nil {
+			errs = append(errs, errors.New("destination: "+err.Error()))
+		}
+		if !keepCredentials && (r.Credentials == nil || !strings.Contains(*r.Credentials, ":")) {
+			errs = append(errs, errors.New("credentials: must be an access key ID and secret access key separated by ':' for S3 destinations"))
+		}
+	case LogShippingDestinationHTTPS:
+		if u, err := url.Parse(r.Destination); err != nil || u.Scheme != "https" || u.Host == "" {
+			errs = append(errs, errors.New("destination: must be an absolute 'https://' URL for HTTPS destinations"))
+		}
+	case LogShippingDestinationKafka:
+		if _, _, err := ParseLogShippingKafkaDestination(r.Destination); err != nil {
+			errs = append(errs, errors.New("destination: "+err.Error()))
+		}
+		if !keepCredentials && r.Credentials != nil && *r.Credentials != "" && !strings.Contains(*r.Credentials, ":") {
+			errs = append(errs, errors.New("credentials: must be a username and password separated by ':' for KAFKA destinations"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("destinationType: must be one of '%s', '%s', or '%s'", LogShippingDestinationS3, LogShippingDestinationHTTPS, LogShippingDestinationKafka))
+	}
+	if r.Credentials != nil && strings.ContainsAny(*r.Credentials, "\r\n") {
+		errs = append(errs, errors.New("credentials: cannot contain line breaks"))
+	}
+
+	if r.Format == "" {
+		r.Format = LogShippingFormatJSON
+	} else if r.Format != LogShippingFormatJSON && r.Format != LogShippingFormatSquid {
+		errs = append(errs, fmt.Errorf("format: must be one of '%s' or '%s'", LogShippingFormatJSON, LogShippingFormatSquid))
+	}
+
+	if r.SampleRate == nil {
+		rate := DefaultLogShippingSampleRate
+		r.SampleRate = &rate
+	} else if *r.SampleRate <= 0 || *r.SampleRate > 1 {
+		errs = append(errs, errors.New("sampleRate: must be greater than 0 and at most 1"))
+	}
+
+	seen := map[string]struct{}{}
+	for i, rule := range r.ScrubRules {
+		switch rule.Field {
+		case LogShippingFieldClientIP, LogShippingFieldUserAgent, LogShippingFieldReferer, LogShippingFieldQuery:
+		default:
+			errs = append(errs, fmt.Errorf("scrubRules[%d].field: must be one of '%s', '%s', '%s', or '%s'", i, LogShippingFieldClientIP, LogShippingFieldUserAgent, LogShippingFieldReferer, LogShippingFieldQuery))
+		}
+		switch rule.Action {
+		case LogShippingScrubDrop, LogShippingScrubHash:
+		case LogShippingScrubMask:
+			if rule.Field != LogShippingFieldClientIP {
+				errs = append(errs, fmt.Errorf("scrubRules[%d].action: '%s' can only be used on the '%s' field", i, LogShippingScrubMask, LogShippingFieldClientIP))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("scrubRules[%d].action: must be one of '%s', '%s', or '%s'", i, LogShippingScrubDrop, LogShippingScrubHash, LogShippingScrubMask))
+		}
+		if _, ok := seen[rule.Field]; ok {
+			errs = append(errs, fmt.Errorf("scrubRules[%d].field: duplicate rule for field '%s'", i, rule.Field))
+		}
+		seen[rule.Field] = struct{}{}
+	}
+	return util.JoinErrs(errs)
+}
+
+// LogShippingS3Destination is the location of an S3 log shipping destination,
+// given as a URL of the form s3://bucket/prefix?region=region&endpoint=URL.
+type LogShippingS3Destination struct {
+	// Endpoint is the URL of the S3 service, which defaults to that of AWS in
+	// the Region.
+	Endpoint string
+	// Bucket is the name of the bucket to which logs are written.
+	Bucket string
+	// Prefix is the prefix of the names of the objects to which logs are
+	// written, without leading or trailing slashes. It may be empty.
+	Prefix string
+	// Region is the region of the bucket.
+	Region string
+}
+
+// ParseLogShippingS3Destination parses the destination of S3 log shipping.
+func ParseLogShippingS3Destination(dest string) (LogShippingS3Destination, error) {
+	u, err := url.Parse(dest)
+	if err != nil || u.Scheme != "s3" || u.Host == "" {
+		return LogShippingS3Destination{}, errors.New("must be a URL of the form 's3://bucket/prefix?region=region' for S3 destinations")
+	}
+	s3 := LogShippingS3Destination{
+		Bucket:   u.Host,
+		Prefix:   strings.Trim(u.Path, "/"),
+		Region:   u.Query().Get("region"),
+		Endpoint: u.Query().Get("endpoint"),
+	}
+	if s3.Region == "" {
+		return LogShippingS3Destination{}, errors.New("must give the bucket's region in the 'region' query parameter for S3 destinations")
+	}
+	if s3.Endpoint == "" {
+		s3.Endpoint = "https://s3." + s3.Region + ".amazonaws.com"
+	} else if e, err := url.Parse(s3.Endpoint); err != nil || e.Scheme != "https" || e.Host == "" {
+		return LogShippingS3Destination{}, errors.New("the 'endpoint' query parameter must be an absolute 'https://' URL")
+	}
+	s3.Endpoint = strings.TrimSuffix(s3.Endpoint, "/")
+	return s3, nil
+}
+
+// ParseLogShippingKafkaDestination parses the destination of Kafka log
+// shipping, which is a URL of the form kafka://broker:port,broker:port/topic,
+// into its brokers' addresses and its topic.
+func ParseLogShippingKafkaDestination(dest string) ([]string, string, error) {
+	const scheme = "kafka://"
+	errMalformed := errors.New("must be of the form 'kafka://broker:port,broker:port/topic' for KAFKA destinations")
+	if !strings.HasPrefix(dest, scheme) {
+		return nil, "", errMalformed
+	}
+	hosts, topic, ok := strings.Cut(strings.TrimPrefix(dest, scheme), "/")
+	if !ok || topic == "" || strings.ContainsAny(topic, "/?#") {
+		return nil, "", errMalformed
+	}
+	brokers := strings.Split(hosts, ",")
+	for _, broker := range brokers {
+		if host, port, err := net.SplitHostPort(broker); err != nil || host == "" || port == "" {
+			return nil, "", fmt.Errorf("broker '%s' must be of the form 'host:port'", broker)
+		}
+	}
+	return brokers, topic, nil
+}
+
+// DeliveryServiceLogShippingResponse is the type of a response from Traffic
+// Ops to a request to its /deliveryservices/{{ID}}/log-shipping endpoint.
+type DeliveryServiceLogShippingResponse struct {
+	Response DeliveryServiceLogShipping `json:"response"`
+	Alerts
+}
+
+// CDNDeliveryServiceLogShippingResponse is the type of a response from
+// Traffic Ops to a request to its /deliveryservices/log-shipping endpoint.
+type CDNDeliveryServiceLogShippingResponse struct {
+	Response []DeliveryServiceLogShipping `json:"response"`
+	Alerts
+}
@@ -0,0 +1,94 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestDeliveryServiceLogShippingRequestValidate(t *testing.T) {
+	valid := DeliveryServiceLogShippingRequest{
+		DestinationType: LogShippingDestinationS3,
+		Destination:     "s3://logs/demo1?region=us-east-1",
+		Credentials:     util.StrPtr("AKIDEXAMPLE:secret"),
+		ScrubRules: []LogShippingScrubRule{
+			{Field: LogShippingFieldClientIP, Action: LogShippingScrubMask},
+			{Field: LogShippingFieldQuery, Action: LogShippingScrubDrop},
+		},
+	}
+	if err := valid.Validate(nil); err != nil {
+		t.Fatalf("expected valid request, got error: %v", err)
+	}
+	if valid.Format != LogShippingFormatJSON {
+		t.Errorf("expected default format '%s', actual: '%s'", LogShippingFormatJSON, valid.Format)
+	}
+	if valid.SampleRate == nil || *valid.SampleRate != DefaultLogShippingSampleRate {
+		t.Errorf("expected default sample rate %v, actual: %v", DefaultLogShippingSampleRate, valid.SampleRate)
+	}
+
+	kafka := DeliveryServiceLogShippingRequest{DestinationType: LogShippingDestinationKafka, Destination: "kafka://kafka1:9092,kafka2:9092/demo1-logs", Format: LogShippingFormatSquid, SampleRate: util.FloatPtr(0.5)}
+	if err := kafka.Validate(nil); err != nil {
+		t.Errorf("expected valid Kafka request, got error: %v", err)
+	}
+
+	invalid := map[string]DeliveryServiceLogShippingRequest{
+		"unknown destination type": {DestinationType: "FTP", Destination: "ftp://example.com/"},
+		"S3 without credentials":   {DestinationType: LogShippingDestinationS3, Destination: "s3://logs?region=us-east-1"},
+		"S3 without region":        {DestinationType: LogShippingDestinationS3, Destination: "s3://logs", Credentials: util.StrPtr("a:b")},
+		"plain HTTP":               {DestinationType: LogShippingDestinationHTTPS, Destination: "http://example.com/logs"},
+		"Kafka without topic":      {DestinationType: LogShippingDestinationKafka, Destination: "kafka://kafka1:9092"},
+		"Kafka without port":       {DestinationType: LogShippingDestinationKafka, Destination: "kafka://kafka1/logs"},
+		"unknown format":           {DestinationType: LogShippingDestinationHTTPS, Destination: "https://example.com/logs", Format: "csv"},
+		"zero sample rate":         {DestinationType: LogShippingDestinationHTTPS, Destination: "https://example.com/logs", SampleRate: util.FloatPtr(0)},
+		"sample rate above 1":      {DestinationType: LogShippingDestinationHTTPS, Destination: "https://example.com/logs", SampleRate: util.FloatPtr(1.5)},
+		"masked user agent":        {DestinationType: LogShippingDestinationHTTPS, Destination: "https://example.com/logs", ScrubRules: []LogShippingScrubRule{{Field: LogShippingFieldUserAgent, Action: LogShippingScrubMask}}},
+		"unknown field":            {DestinationType: LogShippingDestinationHTTPS, Destination: "https://example.com/logs", ScrubRules: []LogShippingScrubRule{{Field: "cookie", Action: LogShippingScrubDrop}}},
+		"duplicate field": {DestinationType: LogShippingDestinationHTTPS, Destination: "https://example.com/logs", ScrubRules: []LogShippingScrubRule{
+			{Field: LogShippingFieldReferer, Action: LogShippingScrubDrop},
+			{Field: LogShippingFieldReferer, Action: LogShippingScrubHash},
+		}},
+	}
+	for name, req := range invalid {
+		if err := req.Validate(nil); err == nil {
+			t.Errorf("expected an error for a request with %s, got none", name)
+		}
+	}
+}
+
+func TestParseLogShippingS3Destination(t *testing.T) {
+	s3, err := ParseLogShippingS3Destination("s3://logs/cdn/demo1/?region=eu-west-1")
+	if err != nil {
+		t.Fatalf("unexpected error parsing S3 destination: %v", err)
+	}
+	expected := LogShippingS3Destination{Endpoint: "https://s3.eu-west-1.amazonaws.com", Bucket: "logs", Prefix: "cdn/demo1", Region: "eu-west-1"}
+	if s3 != expected {
+		t.Errorf("expected %+v, actual: %+v", expected, s3)
+	}
+
+	s3, err = ParseLogShippingS3Destination("s3://logs?region=default&endpoint=https://minio.example.com/")
+	if err != nil {
+		t.Fatalf("unexpected error parsing S3 destination with an endpoint: %v", err)
+	}
+	if s3.Endpoint != "https://minio.example.com" || s3.Prefix != "" {
+		t.Errorf("expected endpoint 'https://minio.example.com' and no prefix, actual: %+v", s3)
+	}
+}
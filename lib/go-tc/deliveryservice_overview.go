@@ -0,0 +1,76 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"time"
+)
+
+// DeliveryServiceOverviewHealth is the number of edge-tier cache servers of a
+// Delivery Service which are available, and which aren't, according to its
+// CDN's Traffic Monitors.
+type DeliveryServiceOverviewHealth struct {
+	TotalOnline  uint64 `json:"totalOnline"`
+	TotalOffline uint64 `json:"totalOffline"`
+}
+
+// DeliveryServiceOverview is a summary of a Delivery Service for the overview
+// screens of user interfaces, which combines the Delivery Service's most
+// important properties with information that would otherwise take requests to
+// several other endpoints.
+type DeliveryServiceOverview struct {
+	// ID is the integral, unique identifier of the Delivery Service.
+	ID int `json:"id"`
+	// XMLID is the Delivery Service's XMLID.
+	XMLID string `json:"xmlId"`
+	// DisplayName is the Delivery Service's Display Name.
+	DisplayName string `json:"displayName"`
+	// Active is whether or not the Delivery Service is routed by Traffic
+	// Router.
+	Active bool `json:"active"`
+	// CDNName is the name of the CDN to which the Delivery Service belongs.
+	CDNName string `json:"cdnName"`
+	// Type is the name of the Delivery Service's Type.
+	Type string `json:"type"`
+	// Tenant is the name of the Delivery Service's Tenant.
+	Tenant string `json:"tenant"`
+	// Topology is the name of the Delivery Service's Topology, if any.
+	Topology *string `json:"topology"`
+	// Protocol is the Delivery Service's Protocol, if any.
+	Protocol *int `json:"protocol"`
+	// Health is the availability of the Delivery Service's edge-tier cache
+	// servers, or nil if it couldn't be determined, e.g. because its CDN has
+	// no online Traffic Monitor, or it isn't in the CDN's Snapshot.
+	Health *DeliveryServiceOverviewHealth `json:"health"`
+	// CertificateExpiration is the time at which the latest SSL certificate of
+	// the Delivery Service expires, or nil if it has none, or the user may not
+	// see SSL key expiration information.
+	CertificateExpiration *time.Time `json:"certificateExpiration"`
+	// PendingRequests is the number of Delivery Service Requests for the
+	// Delivery Service which haven't been completed or rejected.
+	PendingRequests int `json:"pendingRequests"`
+}
+
+// DeliveryServicesOverviewResponse is the type of a response from Traffic Ops
+// to a GET request to its /ui/deliveryservices-overview endpoint.
+type DeliveryServicesOverviewResponse struct {
+	Response []DeliveryServiceOverview `json:"response"`
+	Alerts
+}
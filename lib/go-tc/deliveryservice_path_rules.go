@@ -0,0 +1,163 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// DeliveryServicePathRule routes the requests for a Delivery Service whose
+// paths begin with a path prefix to a different origin, and/or caches their
+// responses differently, so that e.g. /live/ and /vod/ of the same FQDN can be
+// served by different origins with different caching.
+type DeliveryServicePathRule struct {
+	// PathPrefix is the prefix of the request paths to which the rule
+	// applies. It must begin and end with a '/'. When the prefixes of
+	// multiple rules match a request, the longest one applies.
+	PathPrefix string `json:"pathPrefix"`
+	// OriginURL, if not nil, is the URL of the origin - a scheme and
+	// authority, without a path - to which the requests are routed, instead
+	// of the Delivery Service's own origin.
+	OriginURL *string `json:"originUrl"`
+	// NoCache is whether or not the responses are never cached.
+	NoCache bool `json:"noCache"`
+	// MaxTTL, if set, is the longest time, in seconds, for which a response
+	// is served from cache without being revalidated with the origin.
+	MaxTTL *int `json:"maxTTL"`
+}
+
+// DeliveryServicePathRules are the path rules of a Delivery Service.
+type DeliveryServicePathRules struct {
+	// DeliveryServiceID is the integral, unique identifier of the Delivery
+	// Service.
+	DeliveryServiceID int `json:"deliveryServiceId"`
+	// XMLID is the XMLID of the Delivery Service.
+	XMLID string `json:"xmlId"`
+	// Rules are the Delivery Service's path rules, ordered by path prefix.
+	Rules []DeliveryServicePathRule `json:"rules"`
+	// LastUpdated is the time at which the Delivery Service's path rules were
+	// last modified, or nil if it doesn't have any.
+	LastUpdated *time.Time `json:"lastUpdated"`
+}
+
+// DeliveryServicePathRulesRequest is the type of a request to replace the
+// path rules of a Delivery Service.
+type DeliveryServicePathRulesRequest struct {
+	Rules []DeliveryServicePathRule `json:"rules"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface.
+//
+// Origin URLs are normalized to have no trailing '/'.
+func (r *DeliveryServicePathRulesRequest) Validate(*sql.Tx) error {
+	if r.Rules == nil {
+		return errors.New("rules: required")
+	}
+	errs := []error{}
+	prefixes := map[string]struct{}{}
+	for i, rule := range r.Rules {
+		if err := validatePathRulePrefix(rule.PathPrefix); err != nil {
+			errs = append(errs, fmt.Errorf("rules[%d].pathPrefix: %w", i, err))
+		} else if _, ok := prefixes[rule.PathPrefix]; ok {
+			errs = append(errs, fmt.Errorf("rules[%d].pathPrefix: duplicate path prefix '%s'", i, rule.PathPrefix))
+		}
+		prefixes[rule.PathPrefix] = struct{}{}
+
+		if rule.OriginURL != nil {
+			origin, err := normalizePathRuleOriginURL(*rule.OriginURL)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("rules[%d].originUrl: %w", i, err))
+			} else {
+				r.Rules[i].OriginURL = &origin
+			}
+		}
+		if rule.MaxTTL != nil && *rule.MaxTTL <= 0 {
+			errs = append(errs, fmt.Errorf("rules[%d].maxTTL: must be greater than zero", i))
+		}
+		if rule.NoCache && rule.MaxTTL != nil {
+			errs = append(errs, fmt.Errorf("rules[%d]: maxTTL cannot be set if noCache is", i))
+		}
+		if rule.OriginURL == nil && !rule.NoCache && rule.MaxTTL == nil {
+			errs = append(errs, fmt.Errorf("rules[%d]: must set at least one of originUrl, noCache, or maxTTL", i))
+		}
+	}
+	return util.JoinErrs(errs)
+}
+
+// validatePathRulePrefix returns an error if the given path prefix can't be
+// used as the path prefix of a Delivery Service path rule.
+func validatePathRulePrefix(prefix string) error {
+	if len(prefix) < 3 || !strings.HasPrefix(prefix, "/") || !strings.HasSuffix(prefix, "/") {
+		return errors.New("must begin and end with a '/', and not be only '/'")
+	}
+	if strings.Contains(prefix, "//") {
+		return errors.New("cannot contain empty path segments")
+	}
+	for _, c := range prefix {
+		if unicode.IsSpace(c) || unicode.IsControl(c) || c == '?' || c == '#' {
+			return errors.New("cannot contain whitespace, control characters, '?', or '#'")
+		}
+	}
+	return nil
+}
+
+// normalizePathRuleOriginURL returns the given origin URL of a Delivery
+// Service path rule, without a trailing '/', or an error if it isn't the
+// http or https URL of an origin.
+func normalizePathRuleOriginURL(originURL string) (string, error) {
+	u, err := url.Parse(originURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", errors.New("scheme must be 'http' or 'https'")
+	}
+	if u.Hostname() == "" {
+		return "", errors.New("must have a host")
+	}
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return "", errors.New("must be only a scheme, host, and optional port")
+	}
+	return u.Scheme + "://" + u.Host, nil
+}
+
+// DeliveryServicePathRulesResponse is the type of a response from Traffic
+// Ops to a request to its /deliveryservices/{{ID}}/path-rules endpoint.
+type DeliveryServicePathRulesResponse struct {
+	Response DeliveryServicePathRules `json:"response"`
+	Alerts
+}
+
+// CDNDeliveryServicePathRulesResponse is the type of a response from Traffic
+// Ops to a GET request to its /deliveryservices/path-rules endpoint.
+type CDNDeliveryServicePathRulesResponse struct {
+	Response []DeliveryServicePathRules `json:"response"`
+	Alerts
+}
@@ -0,0 +1,74 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestDeliveryServicePathRulesRequestValidate(t *testing.T) {
+	req := DeliveryServicePathRulesRequest{
+		Rules: []DeliveryServicePathRule{
+			{PathPrefix: "/live/", OriginURL: util.StrPtr("https://live.origin.example:8443/"), MaxTTL: util.IntPtr(2)},
+			{PathPrefix: "/vod/", OriginURL: util.StrPtr("http://vod.origin.example")},
+			{PathPrefix: "/live/manifests/", NoCache: true},
+		},
+	}
+	if err := req.Validate(nil); err != nil {
+		t.Fatalf("expected valid request, got error: %v", err)
+	}
+	if *req.Rules[0].OriginURL != "https://live.origin.example:8443" {
+		t.Errorf("expected origin URL to be normalized to 'https://live.origin.example:8443', actual: '%s'", *req.Rules[0].OriginURL)
+	}
+
+	req = DeliveryServicePathRulesRequest{Rules: []DeliveryServicePathRule{}}
+	if err := req.Validate(nil); err != nil {
+		t.Errorf("expected request without rules to be valid, got error: %v", err)
+	}
+
+	req = DeliveryServicePathRulesRequest{}
+	if err := req.Validate(nil); err == nil {
+		t.Error("expected request without a rules property to be invalid")
+	}
+
+	invalid := map[string][]DeliveryServicePathRule{
+		"root prefix":             {{PathPrefix: "/", NoCache: true}},
+		"prefix without slashes":  {{PathPrefix: "live", NoCache: true}},
+		"prefix without trailing": {{PathPrefix: "/live", NoCache: true}},
+		"empty path segment":      {{PathPrefix: "/live//", NoCache: true}},
+		"prefix with query":       {{PathPrefix: "/live?x/", NoCache: true}},
+		"prefix with whitespace":  {{PathPrefix: "/li ve/", NoCache: true}},
+		"duplicate prefixes":      {{PathPrefix: "/live/", NoCache: true}, {PathPrefix: "/live/", MaxTTL: util.IntPtr(1)}},
+		"origin without scheme":   {{PathPrefix: "/live/", OriginURL: util.StrPtr("live.origin.example")}},
+		"origin with path":        {{PathPrefix: "/live/", OriginURL: util.StrPtr("http://live.origin.example/live")}},
+		"ftp origin":              {{PathPrefix: "/live/", OriginURL: util.StrPtr("ftp://live.origin.example")}},
+		"zero maxTTL":             {{PathPrefix: "/live/", MaxTTL: util.IntPtr(0)}},
+		"noCache with maxTTL":     {{PathPrefix: "/live/", NoCache: true, MaxTTL: util.IntPtr(60)}},
+		"rule without effect":     {{PathPrefix: "/live/"}},
+	}
+	for name, rules := range invalid {
+		req := DeliveryServicePathRulesRequest{Rules: rules}
+		if err := req.Validate(nil); err == nil {
+			t.Errorf("expected request with %s to be invalid", name)
+		}
+	}
+}
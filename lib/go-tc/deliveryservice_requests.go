@@ -648,6 +648,25 @@ const (
 	DSRChangeTypeDelete = DSRChangeType("delete")
 )
 
+// DSRCertificateStatus is an "enumerated" string type that encodes the legal
+// values of the status of the certificate Traffic Ops obtains for the HTTPS
+// Delivery Service of a Delivery Service Request when it's completed.
+type DSRCertificateStatus string
+
+// These are the valid values for Delivery Service Request Certificate
+// Statuses.
+const (
+	// The certificate is being obtained, and the Delivery Service Request is
+	// pending until it has been.
+	DSRCertificateStatusIssuing = DSRCertificateStatus("issuing")
+	// The certificate was obtained, and the Delivery Service Request was
+	// completed.
+	DSRCertificateStatusIssued = DSRCertificateStatus("issued")
+	// The certificate couldn't be obtained, and the Delivery Service Request
+	// remains pending; completing it again tries again.
+	DSRCertificateStatusFailed = DSRCertificateStatus("failed")
+)
+
 // DSRChangeTypeFromString converts the passed string to a DSRChangeType
 // (case-insensitive), returning an error if the string is not a valid
 // Delivery Service Request Change Type.
@@ -710,6 +729,14 @@ type DeliveryServiceRequestV40 struct {
 	// AuthorID is the integral, unique identifier of the user who created the
 	// Delivery Service Request, if/when it is known.
 	AuthorID *int `json:"-" db:"author_id"`
+	// CertificateAsyncStatusID is the integral, unique identifier of the
+	// asynchronous job that obtains a certificate for the requested HTTPS
+	// Delivery Service when the Delivery Service Request is completed, if
+	// Traffic Ops has started one.
+	CertificateAsyncStatusID *int `json:"certificateAsyncStatusId,omitempty" db:"cert_async_status_id"`
+	// CertificateStatus is the status of that certificate, if Traffic Ops has
+	// started obtaining one.
+	CertificateStatus *DSRCertificateStatus `json:"certificateStatus,omitempty" db:"cert_status"`
 	// ChangeType represents the type of change being made, must be one of
 	// "create", "change" or "delete".
 	ChangeType DSRChangeType `json:"changeType" db:"change_type"`
@@ -0,0 +1,210 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// These are the flags which may be set on a DeliveryServiceRewriteRule.
+const (
+	// RewriteRuleFlagRedirect makes a rule answer matching requests with a
+	// 302 redirect to its Replace URL. Redirect rules are always the last
+	// rule applied to a request.
+	RewriteRuleFlagRedirect = "redirect"
+	// RewriteRuleFlagInternal makes a rule rewrite the path of matching
+	// requests before they are looked up in cache and forwarded upstream,
+	// invisibly to the client.
+	RewriteRuleFlagInternal = "internal"
+	// RewriteRuleFlagLast stops rule processing after the rule matches.
+	RewriteRuleFlagLast = "last"
+)
+
+// MaxDeliveryServiceRewriteRules is the maximum number of rewrite rules a
+// single Delivery Service may have.
+const MaxDeliveryServiceRewriteRules = 100
+
+// rewriteRuleCaptureRe matches a regular expression capture group reference,
+// e.g. "$1", in the Replace of a rewrite rule.
+var rewriteRuleCaptureRe = regexp.MustCompile(`\$[0-9]`)
+
+// DeliveryServiceRewriteRule is a single match/replace rule applied to the
+// request URLs of a Delivery Service by its edge-tier cache servers.
+type DeliveryServiceRewriteRule struct {
+	// Match is a regular expression tested against the request path,
+	// including its leading '/'.
+	Match string `json:"match"`
+	// Replace is the path (for internal rules) or absolute URL (for
+	// redirect rules) which replaces matching request URLs. Terminal rules
+	// may refer to the capture groups of Match as "$1" through "$9".
+	Replace string `json:"replace"`
+	// Flags is the set of RewriteRuleFlag* flags of the rule. Exactly one of
+	// RewriteRuleFlagRedirect and RewriteRuleFlagInternal must be set.
+	Flags []string `json:"flags"`
+}
+
+// HasFlag returns whether the rule has the given flag set.
+func (r DeliveryServiceRewriteRule) HasFlag(flag string) bool {
+	for _, f := range r.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTerminal returns whether rule processing stops after the rule matches,
+// i.e. whether it is a redirect rule or has the RewriteRuleFlagLast flag.
+func (r DeliveryServiceRewriteRule) IsTerminal() bool {
+	return r.HasFlag(RewriteRuleFlagRedirect) || r.HasFlag(RewriteRuleFlagLast)
+}
+
+// Validate returns an error describing every problem with the rule, or nil
+// if the rule is valid on its own. It does not consider the rule's position
+// in its Delivery Service's list of rules.
+func (r DeliveryServiceRewriteRule) Validate() error {
+	errs := []error{}
+	if r.Match == "" {
+		errs = append(errs, errors.New("match: cannot be blank"))
+	} else if strings.IndexFunc(r.Match, unicode.IsSpace) >= 0 {
+		errs = append(errs, errors.New("match: cannot contain whitespace; use '\\s' to match it"))
+	} else if _, err := regexp.Compile(r.Match); err != nil {
+		errs = append(errs, fmt.Errorf("match: invalid regular expression: %v", err))
+	}
+
+	seen := map[string]struct{}{}
+	for _, flag := range r.Flags {
+		switch flag {
+		case RewriteRuleFlagRedirect, RewriteRuleFlagInternal, RewriteRuleFlagLast:
+		default:
+			errs = append(errs, fmt.Errorf("flags: unknown flag '%s'", flag))
+			continue
+		}
+		if _, ok := seen[flag]; ok {
+			errs = append(errs, fmt.Errorf("flags: duplicate flag '%s'", flag))
+		}
+		seen[flag] = struct{}{}
+	}
+	redirect := r.HasFlag(RewriteRuleFlagRedirect)
+	internal := r.HasFlag(RewriteRuleFlagInternal)
+	if redirect == internal {
+		errs = append(errs, fmt.Errorf("flags: exactly one of '%s' and '%s' must be set", RewriteRuleFlagRedirect, RewriteRuleFlagInternal))
+	}
+
+	switch {
+	case r.Replace == "":
+		errs = append(errs, errors.New("replace: cannot be blank"))
+	case strings.IndexFunc(r.Replace, unicode.IsSpace) >= 0:
+		errs = append(errs, errors.New("replace: cannot contain whitespace"))
+	case redirect && !strings.HasPrefix(r.Replace, "http://") && !strings.HasPrefix(r.Replace, "https://"):
+		errs = append(errs, errors.New("replace: must be an absolute 'http://' or 'https://' URL for redirect rules"))
+	case internal && !strings.HasPrefix(r.Replace, "/"):
+		errs = append(errs, errors.New("replace: must be a path beginning with '/' for internal rules"))
+	}
+
+	// Non-terminal rules are applied by the header_rewrite ATS plugin, which
+	// matches the path without its leading '/' and can't substitute
+	// captures.
+	if internal && !r.IsTerminal() {
+		if !strings.HasPrefix(r.Match, "^/") {
+			errs = append(errs, errors.New("match: must begin with '^/' for rules without the 'last' flag"))
+		}
+		if rewriteRuleCaptureRe.MatchString(r.Replace) {
+			errs = append(errs, errors.New("replace: cannot refer to capture groups in rules without the 'last' flag"))
+		}
+	}
+	return util.JoinErrs(errs)
+}
+
+// DeliveryServiceRewriteRules is the ordered list of rewrite rules of a
+// Delivery Service.
+type DeliveryServiceRewriteRules struct {
+	// DeliveryServiceID is the integral, unique identifier of the Delivery
+	// Service.
+	DeliveryServiceID int `json:"deliveryServiceId"`
+	// XMLID is the XMLID of the Delivery Service.
+	XMLID string `json:"xmlId"`
+	// Rules is the rewrite rules of the Delivery Service, in the order in
+	// which they are applied.
+	Rules []DeliveryServiceRewriteRule `json:"rules"`
+	// LastUpdated is the time at which the Delivery Service's rules were last
+	// modified, or nil if it has never had any.
+	LastUpdated *time.Time `json:"lastUpdated"`
+}
+
+// DeliveryServiceRewriteRulesRequest is the type of a request to replace the
+// rewrite rules of a Delivery Service.
+type DeliveryServiceRewriteRulesRequest struct {
+	Rules []DeliveryServiceRewriteRule `json:"rules"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface.
+//
+// In addition to validating each rule, it ensures that no rule without the
+// 'last' flag comes after a terminal rule, because those rules are applied
+// before any terminal rule by cache servers.
+func (r *DeliveryServiceRewriteRulesRequest) Validate(*sql.Tx) error {
+	if r.Rules == nil {
+		return errors.New("rules: cannot be null/missing")
+	}
+	if len(r.Rules) > MaxDeliveryServiceRewriteRules {
+		return fmt.Errorf("rules: cannot have more than %d rules", MaxDeliveryServiceRewriteRules)
+	}
+	errs := []error{}
+	terminal := -1
+	for i, rule := range r.Rules {
+		if err := rule.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("rules[%d]: %v", i, err))
+		}
+		if rule.IsTerminal() {
+			if terminal < 0 {
+				terminal = i
+			}
+		} else if terminal >= 0 {
+			errs = append(errs, fmt.Errorf("rules[%d]: rules without the 'last' flag must come before every rule with the 'redirect' or 'last' flag, such as rules[%d]", i, terminal))
+		}
+	}
+	return util.JoinErrs(errs)
+}
+
+// DeliveryServiceRewriteRulesResponse is the type of a response from Traffic
+// Ops to a request to its /deliveryservices/{{ID}}/rewrite-rules endpoint.
+type DeliveryServiceRewriteRulesResponse struct {
+	Response DeliveryServiceRewriteRules `json:"response"`
+	Alerts
+}
+
+// CDNDeliveryServiceRewriteRulesResponse is the type of a response from
+// Traffic Ops to a GET request to its /deliveryservices/rewrite-rules
+// endpoint.
+type CDNDeliveryServiceRewriteRulesResponse struct {
+	Response []DeliveryServiceRewriteRules `json:"response"`
+	Alerts
+}
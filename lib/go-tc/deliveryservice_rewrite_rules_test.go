@@ -0,0 +1,81 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+)
+
+func TestDeliveryServiceRewriteRuleValidate(t *testing.T) {
+	valid := []DeliveryServiceRewriteRule{
+		{Match: `^/old/`, Replace: `/new/`, Flags: []string{RewriteRuleFlagInternal}},
+		{Match: `^/img/(.*)`, Replace: `/images/$1`, Flags: []string{RewriteRuleFlagInternal, RewriteRuleFlagLast}},
+		{Match: `^/go/(.*)`, Replace: `https://example.net/$1`, Flags: []string{RewriteRuleFlagRedirect}},
+	}
+	for i, rule := range valid {
+		if err := rule.Validate(); err != nil {
+			t.Errorf("expected rule %d to be valid, got error: %v", i, err)
+		}
+	}
+
+	invalid := map[string]DeliveryServiceRewriteRule{
+		"blank match":                {Replace: `/new/`, Flags: []string{RewriteRuleFlagInternal, RewriteRuleFlagLast}},
+		"bad regex":                  {Match: `^/(old`, Replace: `/new/`, Flags: []string{RewriteRuleFlagInternal, RewriteRuleFlagLast}},
+		"whitespace":                 {Match: `^/old path`, Replace: `/new/`, Flags: []string{RewriteRuleFlagInternal, RewriteRuleFlagLast}},
+		"no kind flag":               {Match: `^/old/`, Replace: `/new/`, Flags: []string{RewriteRuleFlagLast}},
+		"both kind flags":            {Match: `^/old/`, Replace: `/new/`, Flags: []string{RewriteRuleFlagInternal, RewriteRuleFlagRedirect}},
+		"unknown flag":               {Match: `^/old/`, Replace: `/new/`, Flags: []string{RewriteRuleFlagInternal, "permanent"}},
+		"duplicate flag":             {Match: `^/old/`, Replace: `/new/`, Flags: []string{RewriteRuleFlagInternal, RewriteRuleFlagInternal}},
+		"relative redirect":          {Match: `^/old/`, Replace: `/new/`, Flags: []string{RewriteRuleFlagRedirect}},
+		"internal URL":               {Match: `^/old/`, Replace: `https://example.net/`, Flags: []string{RewriteRuleFlagInternal, RewriteRuleFlagLast}},
+		"unanchored non-last":        {Match: `/old/`, Replace: `/new/`, Flags: []string{RewriteRuleFlagInternal}},
+		"non-last capture reference": {Match: `^/old/(.*)`, Replace: `/new/$1`, Flags: []string{RewriteRuleFlagInternal}},
+	}
+	for name, rule := range invalid {
+		if err := rule.Validate(); err == nil {
+			t.Errorf("expected rule with %s to be invalid", name)
+		}
+	}
+}
+
+func TestDeliveryServiceRewriteRulesRequestValidate(t *testing.T) {
+	nonLast := DeliveryServiceRewriteRule{Match: `^/old/`, Replace: `/new/`, Flags: []string{RewriteRuleFlagInternal}}
+	last := DeliveryServiceRewriteRule{Match: `^/img/(.*)`, Replace: `/images/$1`, Flags: []string{RewriteRuleFlagInternal, RewriteRuleFlagLast}}
+
+	req := DeliveryServiceRewriteRulesRequest{Rules: []DeliveryServiceRewriteRule{nonLast, last}}
+	if err := req.Validate(nil); err != nil {
+		t.Errorf("expected valid request, got error: %v", err)
+	}
+
+	req = DeliveryServiceRewriteRulesRequest{Rules: []DeliveryServiceRewriteRule{}}
+	if err := req.Validate(nil); err != nil {
+		t.Errorf("expected request with no rules to be valid, got error: %v", err)
+	}
+
+	req = DeliveryServiceRewriteRulesRequest{}
+	if err := req.Validate(nil); err == nil {
+		t.Error("expected request with null rules to be invalid")
+	}
+
+	req = DeliveryServiceRewriteRulesRequest{Rules: []DeliveryServiceRewriteRule{last, nonLast}}
+	if err := req.Validate(nil); err == nil {
+		t.Error("expected request with a non-last rule after a last rule to be invalid")
+	}
+}
@@ -0,0 +1,213 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// SLOObjective is a kind of service level objective of a Delivery Service.
+type SLOObjective string
+
+// These are the kinds of service level objectives of a Delivery Service.
+const (
+	// SLOObjectiveAvailability is the percentage of the time in which
+	// Traffic Monitor reported the Delivery Service available.
+	SLOObjectiveAvailability = SLOObjective("availability")
+	// SLOObjectiveTTFBP99 is the 99th percentile time to first byte, in
+	// milliseconds.
+	SLOObjectiveTTFBP99 = SLOObjective("ttfbP99")
+	// SLOObjectiveErrorRate is the percentage of requests answered with 5xx
+	// responses.
+	SLOObjectiveErrorRate = SLOObjective("errorRate")
+)
+
+// DefaultSLOWindowHours is the length, in hours, of the rolling window over
+// which service level objectives are evaluated if a Delivery Service's
+// objectives don't give one.
+const DefaultSLOWindowHours = 720
+
+// MaxSLOWindowHours is the longest rolling window, in hours, over which service
+// level objectives may be evaluated - the duration for which Traffic Stats
+// keeps its one-minute summaries of Delivery Service statistics.
+const MaxSLOWindowHours = 720
+
+// DeliveryServiceSLO is the set of service level objectives of a Delivery
+// Service, the compliance with which is regularly evaluated over a rolling
+// window. At least one of the targets is not nil.
+type DeliveryServiceSLO struct {
+	// DeliveryServiceID is the integral, unique identifier of the Delivery
+	// Service.
+	DeliveryServiceID int `json:"deliveryServiceId"`
+	// XMLID is the XMLID of the Delivery Service.
+	XMLID string `json:"xmlId"`
+	// AvailabilityTarget is the least percentage of the time in which the
+	// Delivery Service may be available.
+	AvailabilityTarget *float64 `json:"availabilityTarget"`
+	// TTFBP99TargetMS is the greatest the 99th percentile time to first
+	// byte, in milliseconds, may be.
+	TTFBP99TargetMS *int `json:"ttfbP99TargetMs"`
+	// ErrorRateTarget is the greatest percentage of requests that may be
+	// answered with 5xx responses.
+	ErrorRateTarget *float64 `json:"errorRateTarget"`
+	// WindowHours is the length, in hours, of the rolling window over which
+	// the objectives are evaluated.
+	WindowHours int `json:"windowHours"`
+	// LastUpdated is the time at which the objectives were last modified.
+	LastUpdated *time.Time `json:"lastUpdated"`
+}
+
+// DeliveryServiceSLORequest is the type of a request to set the service level
+// objectives of a Delivery Service.
+type DeliveryServiceSLORequest struct {
+	AvailabilityTarget *float64 `json:"availabilityTarget"`
+	TTFBP99TargetMS    *int     `json:"ttfbP99TargetMs"`
+	ErrorRateTarget    *float64 `json:"errorRateTarget"`
+	WindowHours        *int     `json:"windowHours"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface. It sets the defaults of the optional properties of the request.
+func (r *DeliveryServiceSLORequest) Validate(*sql.Tx) error {
+	errs := []error{}
+	if r.AvailabilityTarget == nil && r.TTFBP99TargetMS == nil && r.ErrorRateTarget == nil {
+		errs = append(errs, errors.New("at least one of 'availabilityTarget', 'ttfbP99TargetMs', or 'errorRateTarget' is required"))
+	}
+	if r.AvailabilityTarget != nil && (*r.AvailabilityTarget <= 0 || *r.AvailabilityTarget > 100) {
+		errs = append(errs, errors.New("availabilityTarget: must be a percentage greater than 0 and at most 100"))
+	}
+	if r.TTFBP99TargetMS != nil && *r.TTFBP99TargetMS <= 0 {
+		errs = append(errs, errors.New("ttfbP99TargetMs: must be a positive number of milliseconds"))
+	}
+	if r.ErrorRateTarget != nil && (*r.ErrorRateTarget < 0 || *r.ErrorRateTarget > 100) {
+		errs = append(errs, errors.New("errorRateTarget: must be a percentage from 0 to 100"))
+	}
+	if r.WindowHours == nil {
+		r.WindowHours = util.IntPtr(DefaultSLOWindowHours)
+	} else if *r.WindowHours <= 0 || *r.WindowHours > MaxSLOWindowHours {
+		errs = append(errs, fmt.Errorf("windowHours: must be a positive number of hours no greater than %d", MaxSLOWindowHours))
+	}
+	return util.JoinErrs(errs)
+}
+
+// SLOObjectiveCompliance is the compliance of a Delivery Service with one of
+// its service level objectives.
+type SLOObjectiveCompliance struct {
+	// Objective is the kind of objective.
+	Objective SLOObjective `json:"objective"`
+	// Target is the objective's target.
+	Target float64 `json:"target"`
+	// Actual is the value of the objective's measurement over the window,
+	// or nil if there was no data from which to measure it.
+	Actual *float64 `json:"actual"`
+	// Compliant is whether the Actual value met the Target, or nil if it's
+	// unknown.
+	Compliant *bool `json:"compliant"`
+}
+
+// DeliveryServiceSLOCompliance is the latest evaluation of the compliance of
+// a Delivery Service with its service level objectives.
+type DeliveryServiceSLOCompliance struct {
+	// DeliveryServiceID is the integral, unique identifier of the Delivery
+	// Service.
+	DeliveryServiceID int `json:"deliveryServiceId"`
+	// XMLID is the XMLID of the Delivery Service.
+	XMLID string `json:"xmlId"`
+	// EvaluatedAt is the time at which compliance was evaluated, which is
+	// the end of the window.
+	EvaluatedAt time.Time `json:"evaluatedAt"`
+	// WindowStart is the start of the window over which compliance was
+	// evaluated.
+	WindowStart time.Time `json:"windowStart"`
+	// Compliant is whether every objective was met - false if any wasn't,
+	// and nil if none is known not to have been met but any is unknown.
+	Compliant *bool `json:"compliant"`
+	// Objectives is the compliance with each objective.
+	Objectives []SLOObjectiveCompliance `json:"objectives"`
+}
+
+// SLOCompliant returns whether the given objectives were all met: false if
+// any wasn't, nil if none is known not to have been met but the compliance
+// with any is unknown, and true otherwise.
+func SLOCompliant(objectives []SLOObjectiveCompliance) *bool {
+	compliant := util.BoolPtr(true)
+	for _, o := range objectives {
+		if o.Compliant == nil {
+			compliant = nil
+		} else if !*o.Compliant {
+			return util.BoolPtr(false)
+		}
+	}
+	return compliant
+}
+
+// DeliveryServiceSLOViolation is a period in which a Delivery Service didn't
+// meet one of its service level objectives.
+type DeliveryServiceSLOViolation struct {
+	// ID is the integral, unique identifier of the violation.
+	ID int `json:"id"`
+	// DeliveryServiceID is the integral, unique identifier of the Delivery
+	// Service.
+	DeliveryServiceID int `json:"deliveryServiceId"`
+	// XMLID is the XMLID of the Delivery Service.
+	XMLID string `json:"xmlId"`
+	// Objective is the kind of objective that wasn't met.
+	Objective SLOObjective `json:"objective"`
+	// Target is the objective's target when the violation was last
+	// evaluated.
+	Target float64 `json:"target"`
+	// Actual is the value of the objective's measurement when the violation
+	// was last evaluated.
+	Actual float64 `json:"actual"`
+	// StartedAt is the time of the evaluation that found the objective
+	// wasn't met.
+	StartedAt time.Time `json:"startedAt"`
+	// EndedAt is the time of the evaluation that found the objective was
+	// met again, or at which the objective was removed, or nil if the
+	// violation is ongoing.
+	EndedAt *time.Time `json:"endedAt"`
+}
+
+// DeliveryServiceSLOResponse is the type of a response from Traffic Ops to a
+// request to its /deliveryservices/{{ID}}/slo endpoint.
+type DeliveryServiceSLOResponse struct {
+	Response DeliveryServiceSLO `json:"response"`
+	Alerts
+}
+
+// DeliveryServiceSLOComplianceResponse is the type of a response from Traffic
+// Ops to a request to its /deliveryservices/{{ID}}/slo/compliance endpoint.
+type DeliveryServiceSLOComplianceResponse struct {
+	Response DeliveryServiceSLOCompliance `json:"response"`
+	Alerts
+}
+
+// DeliveryServiceSLOViolationsResponse is the type of a response from Traffic
+// Ops to a request to its /deliveryservices/{{ID}}/slo/violations endpoint.
+type DeliveryServiceSLOViolationsResponse struct {
+	Response []DeliveryServiceSLOViolation `json:"response"`
+	Alerts
+}
@@ -0,0 +1,87 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestDeliveryServiceSLORequestValidate(t *testing.T) {
+	valid := DeliveryServiceSLORequest{AvailabilityTarget: util.FloatPtr(99.9), ErrorRateTarget: util.FloatPtr(0)}
+	if err := valid.Validate(nil); err != nil {
+		t.Fatalf("expected valid request, got error: %v", err)
+	}
+	if valid.WindowHours == nil || *valid.WindowHours != DefaultSLOWindowHours {
+		t.Errorf("expected default window of %d hours, actual: %v", DefaultSLOWindowHours, valid.WindowHours)
+	}
+
+	invalid := map[string]DeliveryServiceSLORequest{
+		"no targets":               {WindowHours: util.IntPtr(24)},
+		"zero availability":        {AvailabilityTarget: util.FloatPtr(0)},
+		"availability over 100":    {AvailabilityTarget: util.FloatPtr(100.1)},
+		"non-positive TTFB":        {TTFBP99TargetMS: util.IntPtr(0)},
+		"negative error rate":      {ErrorRateTarget: util.FloatPtr(-1)},
+		"error rate over 100":      {ErrorRateTarget: util.FloatPtr(101)},
+		"non-positive window":      {TTFBP99TargetMS: util.IntPtr(200), WindowHours: util.IntPtr(0)},
+		"window beyond statistics": {TTFBP99TargetMS: util.IntPtr(200), WindowHours: util.IntPtr(MaxSLOWindowHours + 1)},
+	}
+	for name, req := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if err := req.Validate(nil); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestSLOCompliant(t *testing.T) {
+	met := SLOObjectiveCompliance{Objective: SLOObjectiveAvailability, Target: 99, Actual: util.FloatPtr(99.5), Compliant: util.BoolPtr(true)}
+	missed := SLOObjectiveCompliance{Objective: SLOObjectiveErrorRate, Target: 1, Actual: util.FloatPtr(2), Compliant: util.BoolPtr(false)}
+	unknown := SLOObjectiveCompliance{Objective: SLOObjectiveTTFBP99, Target: 300}
+
+	tests := map[string]struct {
+		objectives []SLOObjectiveCompliance
+		expected   *bool
+	}{
+		"all met":           {objectives: []SLOObjectiveCompliance{met}, expected: util.BoolPtr(true)},
+		"any missed":        {objectives: []SLOObjectiveCompliance{unknown, met, missed}, expected: util.BoolPtr(false)},
+		"met and unknown":   {objectives: []SLOObjectiveCompliance{met, unknown}, expected: nil},
+		"only unknown":      {objectives: []SLOObjectiveCompliance{unknown}, expected: nil},
+		"missed and met":    {objectives: []SLOObjectiveCompliance{missed, met}, expected: util.BoolPtr(false)},
+		"missed is decided": {objectives: []SLOObjectiveCompliance{missed, unknown}, expected: util.BoolPtr(false)},
+	}
+	str := func(b *bool) string {
+		if b == nil {
+			return "nil"
+		}
+		return fmt.Sprint(*b)
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			actual := SLOCompliant(test.objectives)
+			if str(actual) != str(test.expected) {
+				t.Errorf("expected %s, got %s", str(test.expected), str(actual))
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// These are the algorithms with which the URLs of a Delivery Service with
+// token authentication may be signed.
+const (
+	TokenAuthAlgorithmHMACSHA1 = "HMAC-SHA1"
+	TokenAuthAlgorithmHMACMD5  = "HMAC-MD5"
+)
+
+// URLSigKeyCount is the number of keys in the URL signing keys of a Delivery
+// Service, named "key0" through "key15".
+const URLSigKeyCount = 16
+
+// MaxTokenAuthClockSkew is the largest allowed clock skew, in seconds, of the
+// token authentication settings of a Delivery Service.
+const MaxTokenAuthClockSkew = 3600
+
+// MaxTokenAuthExemptPaths is the maximum number of paths a Delivery Service
+// may exempt from token authentication.
+const MaxTokenAuthExemptPaths = 100
+
+// DeliveryServiceTokenAuth is the token authentication (URL signing)
+// settings of a Delivery Service which uses url_sig signing. The keys
+// themselves are kept in Traffic Vault.
+type DeliveryServiceTokenAuth struct {
+	// DeliveryServiceID is the integral, unique identifier of the Delivery
+	// Service.
+	DeliveryServiceID int `json:"deliveryServiceId"`
+	// XMLID is the XMLID of the Delivery Service.
+	XMLID string `json:"xmlId"`
+	// Algorithm is the TokenAuthAlgorithm* algorithm with which signers sign
+	// URLs.
+	Algorithm string `json:"algorithm"`
+	// KeyRotationDays is how many days pass between automatic rotations of
+	// the Delivery Service's keys, or 0 if they're only rotated on request.
+	KeyRotationDays int `json:"keyRotationDays"`
+	// ClockSkew is how many seconds signers extend the expiration of signed
+	// URLs by, to allow for the clocks of cache servers being behind theirs.
+	ClockSkew int `json:"clockSkew"`
+	// ExemptPaths is the set of request path prefixes which cache servers
+	// serve without a valid signature.
+	ExemptPaths []string `json:"exemptPaths"`
+	// CurrentKey is the index of the key with which signers should sign
+	// URLs - the most recently rotated one.
+	CurrentKey int `json:"currentKey"`
+	// LastKeyRotation is the time at which a key was last rotated, or nil if
+	// the keys have never been rotated.
+	LastKeyRotation *time.Time `json:"lastKeyRotation"`
+	// NextKeyRotation is the time at which the next key is due to be
+	// rotated, or nil if KeyRotationDays is 0.
+	NextKeyRotation *time.Time `json:"nextKeyRotation"`
+	// LastUpdated is the time at which the settings were last modified.
+	LastUpdated *time.Time `json:"lastUpdated"`
+}
+
+// SetNextKeyRotation sets the NextKeyRotation of the settings from their
+// KeyRotationDays and LastKeyRotation. If the keys have never been rotated,
+// the rotation is due from LastUpdated.
+func (a *DeliveryServiceTokenAuth) SetNextKeyRotation() {
+	a.NextKeyRotation = nil
+	if a.KeyRotationDays <= 0 {
+		return
+	}
+	from := a.LastKeyRotation
+	if from == nil {
+		from = a.LastUpdated
+	}
+	if from == nil {
+		return
+	}
+	next := from.AddDate(0, 0, a.KeyRotationDays)
+	a.NextKeyRotation = &next
+}
+
+// DeliveryServiceTokenAuthRequest is the type of a request to set the token
+// authentication settings of a Delivery Service.
+type DeliveryServiceTokenAuthRequest struct {
+	Algorithm       string   `json:"algorithm"`
+	KeyRotationDays int      `json:"keyRotationDays"`
+	ClockSkew       int      `json:"clockSkew"`
+	ExemptPaths     []string `json:"exemptPaths"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface.
+func (r *DeliveryServiceTokenAuthRequest) Validate(*sql.Tx) error {
+	errs := []error{}
+	switch r.Algorithm {
+	case TokenAuthAlgorithmHMACSHA1, TokenAuthAlgorithmHMACMD5:
+	default:
+		errs = append(errs, fmt.Errorf("algorithm: must be one of '%s' or '%s'", TokenAuthAlgorithmHMACSHA1, TokenAuthAlgorithmHMACMD5))
+	}
+	if r.KeyRotationDays < 0 {
+		errs = append(errs, errors.New("keyRotationDays: cannot be negative"))
+	}
+	if r.ClockSkew < 0 || r.ClockSkew > MaxTokenAuthClockSkew {
+		errs = append(errs, fmt.Errorf("clockSkew: must be between 0 and %d seconds", MaxTokenAuthClockSkew))
+	}
+	if len(r.ExemptPaths) > MaxTokenAuthExemptPaths {
+		errs = append(errs, fmt.Errorf("exemptPaths: cannot have more than %d paths", MaxTokenAuthExemptPaths))
+	}
+	seen := map[string]struct{}{}
+	for i, path := range r.ExemptPaths {
+		if !strings.HasPrefix(path, "/") {
+			errs = append(errs, fmt.Errorf("exemptPaths[%d]: must begin with '/'", i))
+		} else if strings.IndexFunc(path, unicode.IsSpace) >= 0 {
+			errs = append(errs, fmt.Errorf("exemptPaths[%d]: cannot contain whitespace", i))
+		}
+		if _, ok := seen[path]; ok {
+			errs = append(errs, fmt.Errorf("exemptPaths[%d]: duplicate path '%s'", i, path))
+		}
+		seen[path] = struct{}{}
+	}
+	return util.JoinErrs(errs)
+}
+
+// DeliveryServiceTokenAuthResponse is the type of a response from Traffic Ops
+// to a request to its /deliveryservices/{{ID}}/token-auth endpoint.
+type DeliveryServiceTokenAuthResponse struct {
+	Response DeliveryServiceTokenAuth `json:"response"`
+	Alerts
+}
+
+// CDNDeliveryServiceTokenAuthResponse is the type of a response from Traffic
+// Ops to a request to its /deliveryservices/token-auth endpoint, or to its
+// /deliveryservices/token-auth/rotate endpoint.
+type CDNDeliveryServiceTokenAuthResponse struct {
+	Response []DeliveryServiceTokenAuth `json:"response"`
+	Alerts
+}
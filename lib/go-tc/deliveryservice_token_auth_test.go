@@ -0,0 +1,73 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDeliveryServiceTokenAuthRequestValidate(t *testing.T) {
+	valid := DeliveryServiceTokenAuthRequest{
+		Algorithm:       TokenAuthAlgorithmHMACSHA1,
+		KeyRotationDays: 30,
+		ClockSkew:       60,
+		ExemptPaths:     []string{"/crossdomain.xml", "/public/"},
+	}
+	if err := valid.Validate(nil); err != nil {
+		t.Errorf("expected valid request, got error: %v", err)
+	}
+
+	invalid := map[string]DeliveryServiceTokenAuthRequest{
+		"unknown algorithm":     {Algorithm: "HMAC-SHA256"},
+		"negative rotation":     {Algorithm: TokenAuthAlgorithmHMACMD5, KeyRotationDays: -1},
+		"clock skew too large":  {Algorithm: TokenAuthAlgorithmHMACMD5, ClockSkew: MaxTokenAuthClockSkew + 1},
+		"relative exempt path":  {Algorithm: TokenAuthAlgorithmHMACMD5, ExemptPaths: []string{"public/"}},
+		"exempt path w/ space":  {Algorithm: TokenAuthAlgorithmHMACMD5, ExemptPaths: []string{"/a b"}},
+		"duplicate exempt path": {Algorithm: TokenAuthAlgorithmHMACMD5, ExemptPaths: []string{"/a", "/a"}},
+	}
+	for name, req := range invalid {
+		if err := req.Validate(nil); err == nil {
+			t.Errorf("expected an error for request with %s, got none", name)
+		}
+	}
+}
+
+func TestDeliveryServiceTokenAuthSetNextKeyRotation(t *testing.T) {
+	lastUpdated := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	auth := DeliveryServiceTokenAuth{KeyRotationDays: 30, LastUpdated: &lastUpdated}
+	auth.SetNextKeyRotation()
+	if auth.NextKeyRotation == nil || !auth.NextKeyRotation.Equal(lastUpdated.AddDate(0, 0, 30)) {
+		t.Errorf("expected next rotation 30 days after last update, got %v", auth.NextKeyRotation)
+	}
+
+	lastRotation := lastUpdated.AddDate(0, 0, 5)
+	auth.LastKeyRotation = &lastRotation
+	auth.SetNextKeyRotation()
+	if auth.NextKeyRotation == nil || !auth.NextKeyRotation.Equal(lastRotation.AddDate(0, 0, 30)) {
+		t.Errorf("expected next rotation 30 days after last rotation, got %v", auth.NextKeyRotation)
+	}
+
+	auth.KeyRotationDays = 0
+	auth.SetNextKeyRotation()
+	if auth.NextKeyRotation != nil {
+		t.Errorf("expected no next rotation without a rotation interval, got %v", *auth.NextKeyRotation)
+	}
+}
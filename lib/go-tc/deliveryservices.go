@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-util"
 )
@@ -44,6 +45,53 @@ const MinRangeSliceBlockSize = 262144
 // Range Slice Block Size, in bytes. This is 32MiB.
 const MaxRangeSliceBlockSize = 33554432
 
+// DefaultTraceHeaderName is the name of the request header that carries the
+// identifier used to trace a request through the CDN, for Delivery Services
+// that don't name one.
+const DefaultTraceHeaderName = "X-Request-Id"
+
+// DefaultStreamIdleTimeout is the number of seconds a WebSocket or gRPC
+// stream of a Delivery Service may go without any data being sent in either
+// direction before cache servers close it, for Delivery Services that don't
+// set one.
+const DefaultStreamIdleTimeout = 300
+
+// DefaultStreamActiveTimeout is the maximum number of seconds a WebSocket or
+// gRPC stream of a Delivery Service may stay open before cache servers close
+// it, for Delivery Services that don't set one.
+const DefaultStreamActiveTimeout = 3600
+
+// TraceHeaderPolicy is how the cache servers of a Delivery Service treat the
+// request header that carries the identifier used to trace a request from
+// Traffic Router through each cache tier to the origin.
+type TraceHeaderPolicy string
+
+const (
+	// TraceHeaderPolicyNone treats the trace header as any other header.
+	TraceHeaderPolicyNone = TraceHeaderPolicy("none")
+	// TraceHeaderPolicyGenerate gives requests without a trace header a new,
+	// unique identifier, which is passed on to parents and the origin and
+	// returned to the client along with those that clients sent.
+	TraceHeaderPolicyGenerate = TraceHeaderPolicy("generate")
+	// TraceHeaderPolicyPropagate passes on the trace header that clients send
+	// to parents and the origin and returns it to the client, but never
+	// creates one.
+	TraceHeaderPolicyPropagate = TraceHeaderPolicy("propagate")
+	// TraceHeaderPolicyStrip removes the trace header from requests before
+	// they're passed on to parents and the origin, and from responses.
+	TraceHeaderPolicyStrip = TraceHeaderPolicy("strip")
+)
+
+// TraceHeaderPolicyFromString returns the TraceHeaderPolicy with the given
+// name, case-insensitively, or an error if there is none.
+func TraceHeaderPolicyFromString(s string) (TraceHeaderPolicy, error) {
+	switch p := TraceHeaderPolicy(strings.ToLower(s)); p {
+	case TraceHeaderPolicyNone, TraceHeaderPolicyGenerate, TraceHeaderPolicyPropagate, TraceHeaderPolicyStrip:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown trace header policy '%s', must be one of '%s', '%s', '%s', or '%s'", s, TraceHeaderPolicyNone, TraceHeaderPolicyGenerate, TraceHeaderPolicyPropagate, TraceHeaderPolicyStrip)
+}
+
 // DeliveryServicesResponseV30 is the type of a response from the
 // /api/3.0/deliveryservices Traffic Ops endpoint.
 //
@@ -247,6 +295,146 @@ type DeliveryServiceV40 struct {
 	// servers serving the Delivery Service's content.
 	TLSVersions       []string              `json:"tlsVersions" db:"tls_versions"`
 	GeoLimitCountries GeoLimitCountriesType `json:"geoLimitCountries"`
+
+	// ActiveAt is the time at which the Delivery Service will automatically
+	// be made active, if it is set.
+	ActiveAt *time.Time `json:"activeAt" db:"active_at"`
+	// InactiveAt is the time at which the Delivery Service will automatically
+	// be made inactive, if it is set.
+	InactiveAt *time.Time `json:"inactiveAt" db:"inactive_at"`
+
+	// ConsistentHashIncludePath is whether or not the request path is part
+	// of the key on which Traffic Router consistently hashes requests for the
+	// Delivery Service. If not given, it defaults to true.
+	ConsistentHashIncludePath *bool `json:"consistentHashIncludePath" db:"consistent_hash_include_path"`
+	// ConsistentHashHeaders is the list of the names of the request headers
+	// the values of which are part of the consistent hash key.
+	ConsistentHashHeaders []string `json:"consistentHashHeaders" db:"consistent_hash_headers"`
+	// ConsistentHashReplicas, if set, overrides the number of points each
+	// cache server has on the consistent hash ring for the Delivery Service.
+	ConsistentHashReplicas *int `json:"consistentHashReplicas" db:"consistent_hash_replicas"`
+
+	// OriginTLSVerify is whether or not cache servers verify the TLS
+	// certificates of the Delivery Service's origin. If not given, it defaults
+	// to false.
+	OriginTLSVerify *bool `json:"originTLSVerify" db:"origin_tls_verify"`
+	// OriginTLSPinnedSPKIHashes is a list of base64-encoded SHA-256 hashes of
+	// Subject Public Key Info structures. If not empty, only certificates in
+	// the Delivery Service's OriginTLSCABundle with one of these keys are
+	// trusted to verify the origin's certificate.
+	OriginTLSPinnedSPKIHashes []string `json:"originTLSPinnedSPKIHashes" db:"origin_tls_pinned_spki_hashes"`
+	// OriginTLSCABundle is the Name of the Parameter with the ConfigFile
+	// OriginCABundleConfigFile on the GlobalProfileName Profile which holds the
+	// CA certificates used to verify the origin's certificate, instead of the
+	// cache servers' default trust store.
+	OriginTLSCABundle *string `json:"originTLSCABundle" db:"origin_tls_ca_bundle"`
+
+	// RoutingInterfaces is a list of the names of server interfaces, in order
+	// of preference, the addresses of which are used to route clients to,
+	// and to reach parents of, the cache servers of the Delivery Service,
+	// instead of their service addresses. For each of IPv4 and IPv6, the
+	// first address of that family on the first listed interface of a server
+	// that has one is used, and the service address of that family is used
+	// if none do.
+	RoutingInterfaces []string `json:"routingInterfaces" db:"routing_interfaces"`
+
+	// CompressGzip is whether or not edge-tier cache servers compress
+	// responses for the Delivery Service with gzip, for clients that accept
+	// it. If not given, it defaults to false.
+	CompressGzip *bool `json:"compressGzip" db:"compress_gzip"`
+	// CompressBrotli is whether or not edge-tier cache servers compress
+	// responses for the Delivery Service with brotli, for clients that accept
+	// it. If not given, it defaults to false.
+	CompressBrotli *bool `json:"compressBrotli" db:"compress_brotli"`
+	// CompressContentTypes is the list of MIME types - of which the subtype
+	// may be a '*' wildcard - of the responses which are compressed. If empty,
+	// a default list of common text types is used.
+	CompressContentTypes []string `json:"compressContentTypes" db:"compress_content_types"`
+	// CompressMinSize, if set, is the minimum size in bytes of the responses
+	// which are compressed.
+	CompressMinSize *int `json:"compressMinSize" db:"compress_min_size"`
+
+	// TraceHeaderPolicy is how cache servers treat the request header that
+	// carries the identifier used to trace a request through the CDN. If not
+	// given, the header is treated as any other.
+	TraceHeaderPolicy *TraceHeaderPolicy `json:"traceHeaderPolicy" db:"trace_header_policy"`
+	// TraceHeaderName is the name of the request header that carries the
+	// identifier used to trace a request through the CDN. If not given,
+	// DefaultTraceHeaderName is used.
+	TraceHeaderName *string `json:"traceHeaderName" db:"trace_header_name"`
+
+	// WebSocket is whether or not cache servers pass WebSocket upgrade
+	// requests for the Delivery Service through to its origin, and relay the
+	// resulting connections. If not given, it defaults to false.
+	WebSocket *bool `json:"websocket" db:"websocket"`
+	// GRPC is whether or not cache servers pass gRPC requests for the
+	// Delivery Service through to its origin over HTTP/2, trailers included.
+	// If not given, it defaults to false.
+	GRPC *bool `json:"grpc" db:"grpc"`
+	// StreamIdleTimeout is the number of seconds a WebSocket or gRPC stream
+	// may go without any data being sent in either direction before it's
+	// closed. If not given, DefaultStreamIdleTimeout is used.
+	StreamIdleTimeout *int `json:"streamIdleTimeout" db:"stream_idle_timeout"`
+	// StreamActiveTimeout is the maximum number of seconds a WebSocket or gRPC
+	// stream may stay open. If not given, DefaultStreamActiveTimeout is used.
+	StreamActiveTimeout *int `json:"streamActiveTimeout" db:"stream_active_timeout"`
+}
+
+// CompressionEnabled returns whether or not edge-tier cache servers compress
+// responses for the Delivery Service with any algorithm.
+func (ds DeliveryServiceV40) CompressionEnabled() bool {
+	return (ds.CompressGzip != nil && *ds.CompressGzip) || (ds.CompressBrotli != nil && *ds.CompressBrotli)
+}
+
+// TraceHeader returns the name of the request header that carries the
+// identifier used to trace a request for the Delivery Service through the CDN.
+func (ds DeliveryServiceV40) TraceHeader() string {
+	if ds.TraceHeaderName == nil || *ds.TraceHeaderName == "" {
+		return DefaultTraceHeaderName
+	}
+	return *ds.TraceHeaderName
+}
+
+// TraceHeaderEnabled returns whether or not cache servers do anything with
+// the trace header of requests for the Delivery Service.
+func (ds DeliveryServiceV40) TraceHeaderEnabled() bool {
+	return ds.TraceHeaderPolicy != nil && *ds.TraceHeaderPolicy != TraceHeaderPolicyNone
+}
+
+// WebSocketEnabled returns whether or not cache servers pass WebSocket
+// connections for the Delivery Service through to its origin.
+func (ds DeliveryServiceV40) WebSocketEnabled() bool {
+	return ds.WebSocket != nil && *ds.WebSocket
+}
+
+// GRPCEnabled returns whether or not cache servers pass gRPC requests for the
+// Delivery Service through to its origin.
+func (ds DeliveryServiceV40) GRPCEnabled() bool {
+	return ds.GRPC != nil && *ds.GRPC
+}
+
+// StreamingEnabled returns whether or not cache servers pass any kind of
+// long-lived stream for the Delivery Service through to its origin.
+func (ds DeliveryServiceV40) StreamingEnabled() bool {
+	return ds.WebSocketEnabled() || ds.GRPCEnabled()
+}
+
+// StreamIdleTimeoutSeconds returns the number of seconds a WebSocket or gRPC
+// stream of the Delivery Service may be idle before it's closed.
+func (ds DeliveryServiceV40) StreamIdleTimeoutSeconds() int {
+	if ds.StreamIdleTimeout == nil || *ds.StreamIdleTimeout <= 0 {
+		return DefaultStreamIdleTimeout
+	}
+	return *ds.StreamIdleTimeout
+}
+
+// StreamActiveTimeoutSeconds returns the maximum number of seconds a
+// WebSocket or gRPC stream of the Delivery Service may stay open.
+func (ds DeliveryServiceV40) StreamActiveTimeoutSeconds() int {
+	if ds.StreamActiveTimeout == nil || *ds.StreamActiveTimeout <= 0 {
+		return DefaultStreamActiveTimeout
+	}
+	return *ds.StreamActiveTimeout
 }
 
 // DeliveryServiceV4 is a Delivery Service as it appears in version 4 of the
@@ -845,3 +845,68 @@ func BenchmarkTLSVersionsAlerts(b *testing.B) {
 		ds.TLSVersionsAlerts()
 	}
 }
+
+func TestTraceHeaderPolicyFromString(t *testing.T) {
+	for _, s := range []string{"none", "generate", "Propagate", "STRIP"} {
+		p, err := TraceHeaderPolicyFromString(s)
+		if err != nil {
+			t.Errorf("unexpected error parsing trace header policy '%s': %v", s, err)
+		} else if string(p) != strings.ToLower(s) {
+			t.Errorf("expected trace header policy '%s', got '%s'", strings.ToLower(s), p)
+		}
+	}
+	if _, err := TraceHeaderPolicyFromString("forward"); err == nil {
+		t.Error("expected an error parsing an unknown trace header policy, got none")
+	}
+}
+
+func TestDeliveryServiceTraceHeader(t *testing.T) {
+	ds := DeliveryServiceV4{}
+	if ds.TraceHeaderEnabled() {
+		t.Error("expected a Delivery Service without a trace header policy not to have its trace header enabled")
+	}
+	if ds.TraceHeader() != DefaultTraceHeaderName {
+		t.Errorf("expected default trace header '%s', got '%s'", DefaultTraceHeaderName, ds.TraceHeader())
+	}
+	policy := TraceHeaderPolicyNone
+	ds.TraceHeaderPolicy = &policy
+	if ds.TraceHeaderEnabled() {
+		t.Errorf("expected a Delivery Service with trace header policy '%s' not to have its trace header enabled", policy)
+	}
+	policy = TraceHeaderPolicyStrip
+	name := "X-Trace"
+	ds.TraceHeaderName = &name
+	if !ds.TraceHeaderEnabled() {
+		t.Errorf("expected a Delivery Service with trace header policy '%s' to have its trace header enabled", policy)
+	}
+	if ds.TraceHeader() != name {
+		t.Errorf("expected trace header '%s', got '%s'", name, ds.TraceHeader())
+	}
+}
+
+func TestDeliveryServiceStreaming(t *testing.T) {
+	ds := DeliveryServiceV4{}
+	if ds.StreamingEnabled() {
+		t.Error("expected a Delivery Service without WebSocket or gRPC set not to have streaming enabled")
+	}
+	if ds.StreamIdleTimeoutSeconds() != DefaultStreamIdleTimeout {
+		t.Errorf("expected default stream idle timeout %d, got %d", DefaultStreamIdleTimeout, ds.StreamIdleTimeoutSeconds())
+	}
+	if ds.StreamActiveTimeoutSeconds() != DefaultStreamActiveTimeout {
+		t.Errorf("expected default stream active timeout %d, got %d", DefaultStreamActiveTimeout, ds.StreamActiveTimeoutSeconds())
+	}
+	grpc := true
+	ds.GRPC = &grpc
+	if !ds.StreamingEnabled() || !ds.GRPCEnabled() || ds.WebSocketEnabled() {
+		t.Error("expected a Delivery Service with only gRPC set to have gRPC, and only gRPC, streaming enabled")
+	}
+	idle, active := 30, 600
+	ds.StreamIdleTimeout = &idle
+	ds.StreamActiveTimeout = &active
+	if ds.StreamIdleTimeoutSeconds() != idle {
+		t.Errorf("expected stream idle timeout %d, got %d", idle, ds.StreamIdleTimeoutSeconds())
+	}
+	if ds.StreamActiveTimeoutSeconds() != active {
+		t.Errorf("expected stream active timeout %d, got %d", active, ds.StreamActiveTimeoutSeconds())
+	}
+}
@@ -258,6 +258,12 @@ type ConfigFileName string
 // particular Profile or Profiles or Cache Groups.
 const GlobalConfigFileName = ConfigFileName("global")
 
+// OriginCABundleConfigFile is the ConfigFile of the Parameters on the
+// GlobalProfileName Profile which hold CA certificate bundles - as
+// concatenated PEM-encoded certificates - that Delivery Services may reference
+// by Parameter Name to verify the TLS certificates of their origins.
+const OriginCABundleConfigFile = ConfigFileName("origin_ca_bundle")
+
 // The allowed values for a Delivery Service's Query String Handling.
 //
 // These are prefixed "QueryStringIgnore" even though the values don't always
@@ -1002,11 +1008,12 @@ type DSMatchType string
 // the Types assigned to Delivery Service Regexes will be representable
 // by these values.
 const (
-	DSMatchTypeHostRegex     DSMatchType = "HOST_REGEXP"
-	DSMatchTypePathRegex     DSMatchType = "PATH_REGEXP"
-	DSMatchTypeSteeringRegex DSMatchType = "STEERING_REGEXP"
-	DSMatchTypeHeaderRegex   DSMatchType = "HEADER_REGEXP"
-	DSMatchTypeInvalid       DSMatchType = ""
+	DSMatchTypeHostRegex         DSMatchType = "HOST_REGEXP"
+	DSMatchTypePathRegex         DSMatchType = "PATH_REGEXP"
+	DSMatchTypePathAndQueryRegex DSMatchType = "PATH_AND_QUERY_REGEXP"
+	DSMatchTypeSteeringRegex     DSMatchType = "STEERING_REGEXP"
+	DSMatchTypeHeaderRegex       DSMatchType = "HEADER_REGEXP"
+	DSMatchTypeInvalid           DSMatchType = ""
 )
 
 // String returns a string representation of this DSMatchType, implementing the
@@ -1017,6 +1024,8 @@ func (t DSMatchType) String() string {
 		fallthrough
 	case DSMatchTypePathRegex:
 		fallthrough
+	case DSMatchTypePathAndQueryRegex:
+		fallthrough
 	case DSMatchTypeSteeringRegex:
 		fallthrough
 	case DSMatchTypeHeaderRegex:
@@ -1035,6 +1044,8 @@ func DSMatchTypeFromString(s string) DSMatchType {
 		return DSMatchTypeHostRegex
 	case "pathregexp":
 		return DSMatchTypePathRegex
+	case "pathandqueryregexp":
+		return DSMatchTypePathAndQueryRegex
 	case "steeringregexp":
 		return DSMatchTypeSteeringRegex
 	case "headerregexp":
@@ -0,0 +1,74 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// These are the types of the objects whose fields can be protected by field
+// policies.
+const (
+	FieldPolicyObjectTypeDeliveryService = "deliveryservice"
+	FieldPolicyObjectTypeServer          = "server"
+)
+
+// FieldPolicy protects a field of the objects of a type from modification by
+// the users of a Role. The field is named as it is in the API representation
+// of the objects, e.g. "orgServerFqdn".
+type FieldPolicy struct {
+	ID          *int       `json:"id" db:"id"`
+	Role        *string    `json:"role" db:"role_name"`
+	ObjectType  *string    `json:"objectType" db:"object_type"`
+	Field       *string    `json:"field" db:"field"`
+	LastUpdated *TimeNoMod `json:"lastUpdated" db:"last_updated"`
+}
+
+// FieldPoliciesResponse is the type of a response from Traffic Ops to a GET
+// request made to its /policies API endpoint.
+type FieldPoliciesResponse struct {
+	Response []FieldPolicy `json:"response"`
+	Paginated
+	Alerts
+}
+
+// FieldPolicyResponse is the type of a response from Traffic Ops to a POST or
+// PUT request made to its /policies API endpoint.
+type FieldPolicyResponse struct {
+	Response FieldPolicy `json:"response"`
+	Alerts
+}
+
+// EffectiveFieldPolicy describes which fields of the objects of a type the
+// requesting user may modify.
+type EffectiveFieldPolicy struct {
+	ObjectType string `json:"objectType"`
+	// ModifiableFields are the fields the user may modify.
+	ModifiableFields []string `json:"modifiableFields"`
+	// ProtectedFields are the fields the user may not modify.
+	ProtectedFields []string `json:"protectedFields"`
+	// ReviewRequired is whether the user's changes to protected fields are
+	// submitted for review as Delivery Service Requests, rather than
+	// rejected.
+	ReviewRequired bool `json:"reviewRequired"`
+}
+
+// EffectiveFieldPoliciesResponse is the type of a response from Traffic Ops
+// to a GET request made to its /policies/effective API endpoint.
+type EffectiveFieldPoliciesResponse struct {
+	Response []EffectiveFieldPolicy `json:"response"`
+	Alerts
+}
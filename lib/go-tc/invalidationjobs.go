@@ -470,15 +470,67 @@ const (
 	REFETCH = "REFETCH"
 )
 
+// These are the name and configFile of the Parameter which, when assigned to
+// the Profile of a Delivery Service, enables purging its content by surrogate
+// key. Its Value is the name of the origin response header that carries the
+// surrogate keys of the content, or DefaultSurrogateKeyHeader if it's blank.
+const (
+	SurrogateKeyHeaderParameterName = "surrogate_key_header"
+	SurrogateKeyPurgeConfigFile     = "surrogate_key_purge"
+)
+
+// DefaultSurrogateKeyHeader is the origin response header that carries the
+// surrogate keys of content, unless a Delivery Service's Profile says
+// otherwise.
+const DefaultSurrogateKeyHeader = "Surrogate-Key"
+
 // InvalidationJobsResponse is the type of a response from Traffic Ops to a
 // request made to its /jobs API endpoint for v 4.0+
 type InvalidationJobsResponseV4 struct {
 	Response []InvalidationJobV4 `json:"response"`
 	Alerts
+	Paginated
 }
 
-// InvalidationJobCreateV4 is an alias for the InvalidationJobCreateV40 struct used for the latest minor version associated with api major version 4.
-type InvalidationJobCreateV4 InvalidationJobCreateV40
+// InvalidationJobCreateV4 is an alias for the InvalidationJobCreateV41 struct used for the latest minor version associated with api major version 4.
+type InvalidationJobCreateV4 InvalidationJobCreateV41
+
+// InvalidationJobCreateV41 represents user input intending to create a content
+// invalidation job in version 4.1 of the Traffic Ops API.
+type InvalidationJobCreateV41 struct {
+	// The Delivery Service XML-ID for which the Invalidation Job is to be applied.
+	DeliveryService string `json:"deliveryService"`
+
+	// Regex is a regular expression which not only must be valid, but should also start with '/'
+	// (or escaped: '\/')
+	Regex string `json:"regex"`
+
+	// StartTime is the time at which the job will come into effect. Must be in the future.
+	StartTime time.Time `json:"startTime"`
+
+	// TTLHours indicates the Time-to-Live of the job in hours. Must be a positive integer value.
+	TTLHours uint32 `json:"ttlHours"`
+
+	// InvalidationType must be either REFRESH (default behavior) or REFETCH. If REFETCH, must
+	// also comply with global parameter setting
+	InvalidationType string `json:"invalidationType"`
+
+	// ServeStale, if true, makes cache servers treat matching content as
+	// stale rather than discarding it - even for REFETCH jobs - so that it
+	// may be served while it's revalidated, or if it can't be.
+	ServeStale bool `json:"serveStale"`
+
+	// TTLOverrideMinutes, if not nil, is the number of minutes for which the
+	// job is in effect, in place of TTLHours. Unlike TTLHours, it may be
+	// less than an hour. Must be a positive integer value.
+	TTLOverrideMinutes *uint32 `json:"ttlOverrideMinutes"`
+
+	// SurrogateKeys, if not empty, makes the job invalidate the content
+	// which the origin tagged with any of these surrogate keys, instead of
+	// the content matching Regex - which must then be empty. The Delivery
+	// Service must have surrogate key purging enabled.
+	SurrogateKeys []string `json:"surrogateKeys"`
+}
 
 // InvalidationJobCreateV40 represents user input intending to create a content invalidation job.
 type InvalidationJobCreateV40 struct {
@@ -500,8 +552,38 @@ type InvalidationJobCreateV40 struct {
 	InvalidationType string `json:"invalidationType"`
 }
 
-// InvalidationJobV4 is an alias for the InvalidationJobV4 struct used for the latest minor version associated with api major version 4.
-type InvalidationJobV4 InvalidationJobV40
+// InvalidationJobV4 is an alias for the InvalidationJobV41 struct used for the latest minor version associated with api major version 4.
+type InvalidationJobV4 InvalidationJobV41
+
+// InvalidationJobV41 represents a content invalidation job as returned by
+// version 4.1 of the Traffic Ops API. Also used for Update calls.
+type InvalidationJobV41 struct {
+	ID                 uint64    `json:"id"`
+	AssetURL           string    `json:"assetUrl"`
+	CreatedBy          string    `json:"createdBy"`
+	DeliveryService    string    `json:"deliveryService"`
+	TTLHours           uint      `json:"ttlHours"`
+	InvalidationType   string    `json:"invalidationType"`
+	StartTime          time.Time `json:"startTime"`
+	ServeStale         bool      `json:"serveStale"`
+	TTLOverrideMinutes *uint     `json:"ttlOverrideMinutes"`
+	SurrogateKeys      []string  `json:"surrogateKeys"`
+}
+
+// Downgrade converts the InvalidationJobV41 to the representation used by
+// version 4.0 of the Traffic Ops API, which lacks its ServeStale,
+// TTLOverrideMinutes, and SurrogateKeys.
+func (job InvalidationJobV41) Downgrade() InvalidationJobV40 {
+	return InvalidationJobV40{
+		ID:               job.ID,
+		AssetURL:         job.AssetURL,
+		CreatedBy:        job.CreatedBy,
+		DeliveryService:  job.DeliveryService,
+		TTLHours:         job.TTLHours,
+		InvalidationType: job.InvalidationType,
+		StartTime:        job.StartTime,
+	}
+}
 
 // InvalidationJobV40 represents a content invalidation job as returned by the API.
 // Also used for Update calls.
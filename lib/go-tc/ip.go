@@ -0,0 +1,108 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"net/netip"
+	"strings"
+)
+
+// CanonicalIP returns the canonical text representation of the given IP
+// address. IPv4 addresses are written in dotted-decimal form, and IPv6
+// addresses as recommended by RFC 5952: in lowercase, without leading zeroes,
+// and with the longest run of two or more zero fields compressed to "::".
+// IPv4-mapped IPv6 addresses keep their IPv6 form, e.g. "::ffff:192.0.2.1".
+//
+// It returns an error if ip isn't an IP address, including if it has an
+// IPv6 zone, which is meaningless outside of the host that gave it.
+func CanonicalIP(ip string) (string, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ip))
+	if err != nil {
+		return "", errors.New("invalid IP address")
+	}
+	if addr.Zone() != "" {
+		return "", errors.New("IP addresses cannot have zones")
+	}
+	return addr.String(), nil
+}
+
+// CanonicalCIDR returns the canonical text representation of the given
+// CIDR-notation address and network prefix length, whose address is written
+// as it is by CanonicalIP, e.g. "2001:DB8:0:0::1/64" becomes "2001:db8::1/64".
+// The address isn't masked by the prefix, so the addresses of interfaces
+// keep the bits that identify the host.
+//
+// It returns an error if cidr isn't valid, including if its prefix length is
+// longer than its address.
+func CanonicalCIDR(cidr string) (string, error) {
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+	if err != nil {
+		return "", errors.New("invalid CIDR-notation address: " + err.Error())
+	}
+	return prefix.String(), nil
+}
+
+// CanonicalIPOrCIDR returns the canonical text representation of the given IP
+// address, or CIDR-notation address and network prefix length; see
+// CanonicalIP and CanonicalCIDR.
+func CanonicalIPOrCIDR(s string) (string, error) {
+	if strings.Contains(s, "/") {
+		return CanonicalCIDR(s)
+	}
+	return CanonicalIP(s)
+}
+
+// SameIP returns whether the given IP addresses - either of which may have a
+// CIDR-notation network prefix length, which is ignored - are the same
+// address, regardless of how they're written. Invalid addresses are the same
+// only if they're the same string.
+func SameIP(a, b string) bool {
+	addrA, errA := parseIPOrCIDRAddr(a)
+	addrB, errB := parseIPOrCIDRAddr(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return addrA == addrB
+}
+
+func parseIPOrCIDRAddr(s string) (netip.Addr, error) {
+	s = strings.TrimSpace(s)
+	if strings.Contains(s, "/") {
+		prefix, err := netip.ParsePrefix(s)
+		return prefix.Addr(), err
+	}
+	return netip.ParseAddr(s)
+}
+
+// Normalize replaces the address and gateway with their canonical text
+// representations - see CanonicalIPOrCIDR - so that the same address is
+// always stored the same way. Invalid addresses are left as they are, to be
+// reported by validation.
+func (a *ServerIPAddress) Normalize() {
+	if addr, err := CanonicalIPOrCIDR(a.Address); err == nil {
+		a.Address = addr
+	}
+	if a.Gateway != nil {
+		if gateway, err := CanonicalIP(*a.Gateway); err == nil {
+			a.Gateway = &gateway
+		}
+	}
+}
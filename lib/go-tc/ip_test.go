@@ -0,0 +1,83 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+)
+
+func TestCanonicalIPOrCIDR(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		valid    bool
+	}{
+		{"192.0.2.1", "192.0.2.1", true},
+		{" 192.0.2.1 ", "192.0.2.1", true},
+		{"192.0.2.1/24", "192.0.2.1/24", true},
+		{"2001:DB8:0:0:0:0:0:1", "2001:db8::1", true},
+		{"2001:0db8:0000:0000:0001:0000:0000:0001", "2001:db8::1:0:0:1", true},
+		{"2001:db8:0:1:1:1:1:1", "2001:db8:0:1:1:1:1:1", true},
+		{"2001:DB8::1/64", "2001:db8::1/64", true},
+		{"::ffff:192.0.2.1", "::ffff:192.0.2.1", true},
+		{"fe80::1%eth0", "", false},
+		{"192.0.2.1/33", "", false},
+		{"2001:db8::1/129", "", false},
+		{"192.0.2", "", false},
+		{"", "", false},
+	}
+	for _, test := range tests {
+		actual, err := CanonicalIPOrCIDR(test.input)
+		if test.valid && err != nil {
+			t.Errorf("'%s': expected no error, actual: %v", test.input, err)
+		} else if !test.valid && err == nil {
+			t.Errorf("'%s': expected an error, actual: '%s'", test.input, actual)
+		} else if actual != test.expected {
+			t.Errorf("'%s': expected '%s', actual: '%s'", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestSameIP(t *testing.T) {
+	if !SameIP("2001:DB8::0:1", "2001:db8::1/64") {
+		t.Error("expected differently written IPv6 addresses with and without a prefix to be the same")
+	}
+	if SameIP("2001:db8::1", "2001:db8::2") {
+		t.Error("expected different addresses not to be the same")
+	}
+	if SameIP("not an IP", "2001:db8::1") || !SameIP("not an IP", "not an IP") {
+		t.Error("expected invalid addresses to be the same only if they're the same string")
+	}
+}
+
+func TestServerIPAddressNormalize(t *testing.T) {
+	gateway := "2001:DB8:0::FFFF"
+	addr := ServerIPAddress{Address: "2001:DB8::0:1/64", Gateway: &gateway}
+	addr.Normalize()
+	if addr.Address != "2001:db8::1/64" || addr.Gateway == nil || *addr.Gateway != "2001:db8::ffff" {
+		t.Errorf("expected address '2001:db8::1/64' and gateway '2001:db8::ffff', actual: %+v (gateway %v)", addr, addr.Gateway)
+	}
+
+	addr = ServerIPAddress{Address: "not an IP"}
+	addr.Normalize()
+	if addr.Address != "not an IP" {
+		t.Errorf("expected invalid address to be left as it is, actual: '%s'", addr.Address)
+	}
+}
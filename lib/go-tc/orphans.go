@@ -0,0 +1,167 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// An OrphanType is the type of an unused, or orphaned, object reported by
+// the /reports/orphans endpoint.
+type OrphanType string
+
+// These are the types of orphaned objects.
+const (
+	// OrphanTypeParameter is the type of Parameters which aren't assigned to
+	// any Profile or Cache Group.
+	OrphanTypeParameter OrphanType = "parameter"
+	// OrphanTypeProfile is the type of Profiles which aren't assigned to any
+	// server, Delivery Service, or Origin.
+	OrphanTypeProfile OrphanType = "profile"
+	// OrphanTypeStaticDNSEntry is the type of Static DNS Entries of Delivery
+	// Services which are inactive, and not scheduled for activation, and so
+	// are never served.
+	OrphanTypeStaticDNSEntry OrphanType = "staticdnsentry"
+	// OrphanTypeCachegroup is the type of Cache Groups which have no
+	// servers, and aren't used by Topologies or as the parents of other
+	// Cache Groups.
+	OrphanTypeCachegroup OrphanType = "cachegroup"
+	// OrphanTypeRegex is the type of Delivery Service regular expressions
+	// which can never match: those of no Delivery Service, and those which
+	// are invalid.
+	OrphanTypeRegex OrphanType = "regex"
+)
+
+// OrphanTypes are all of the types of orphaned objects, in the order in
+// which they're reported.
+var OrphanTypes = []OrphanType{
+	OrphanTypeParameter,
+	OrphanTypeProfile,
+	OrphanTypeStaticDNSEntry,
+	OrphanTypeCachegroup,
+	OrphanTypeRegex,
+}
+
+// OrphanTypesQueryParam is the query parameter of /reports/orphans which
+// restricts the report to the given comma-separated types of objects.
+const OrphanTypesQueryParam = "types"
+
+// Validate returns an error if the OrphanType isn't one of OrphanTypes.
+func (t OrphanType) Validate() error {
+	for _, valid := range OrphanTypes {
+		if t == valid {
+			return nil
+		}
+	}
+	return fmt.Errorf("'%s' is not a valid type of orphaned object", t)
+}
+
+// Orphan is an unused, or orphaned, object, which can likely be deleted.
+type Orphan struct {
+	// Type is the type of the object.
+	Type OrphanType `json:"type"`
+	// ID is the integral, unique identifier of the object.
+	ID int `json:"id"`
+	// Name is a human-readable name of the object: the name of a Parameter,
+	// Profile, or Cache Group, the host of a Static DNS Entry, or the pattern
+	// of a regular expression.
+	Name string `json:"name"`
+	// Reason describes why the object is orphaned.
+	Reason string `json:"reason"`
+}
+
+// OrphansResponse is the type of a response from Traffic Ops to a GET request
+// made to its /reports/orphans endpoint.
+type OrphansResponse struct {
+	Response []Orphan `json:"response"`
+	Alerts
+}
+
+// OrphanDeletion is the deletion of an orphaned object staged for review.
+// Its ID isn't the ID of the object, which is ObjectID.
+type OrphanDeletion struct {
+	ID       int        `json:"id"`
+	Type     OrphanType `json:"type"`
+	ObjectID int        `json:"objectId"`
+	Name     string     `json:"name"`
+	// Reason describes why the object was orphaned when its deletion was
+	// staged.
+	Reason   string    `json:"reason"`
+	StagedBy string    `json:"stagedBy"`
+	Created  time.Time `json:"created"`
+}
+
+// OrphanDeletionsResponse is the type of a response from Traffic Ops to a
+// request made to its /reports/orphans/deletions endpoint, or to its
+// /reports/orphans/deletions/apply endpoint.
+type OrphanDeletionsResponse struct {
+	Response []OrphanDeletion `json:"response"`
+	Alerts
+}
+
+// OrphanDeletionsRequest is a request to stage the deletions of the orphaned
+// objects of the given types, or of all types if none are given.
+type OrphanDeletionsRequest struct {
+	Types []OrphanType `json:"types"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface. If no types are given, they're set to OrphanTypes.
+func (r *OrphanDeletionsRequest) Validate(*sql.Tx) error {
+	if len(r.Types) == 0 {
+		r.Types = OrphanTypes
+		return nil
+	}
+	errs := []error{}
+	for _, t := range r.Types {
+		if err := t.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("types: %w", err))
+		}
+	}
+	return util.JoinErrs(errs)
+}
+
+// OrphanDeletionsApplyRequest is a request to carry out the staged deletions
+// with the given IDs.
+type OrphanDeletionsApplyRequest struct {
+	IDs []int `json:"ids"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface.
+func (r *OrphanDeletionsApplyRequest) Validate(*sql.Tx) error {
+	if len(r.IDs) == 0 {
+		return errors.New("ids: at least one staged deletion must be given")
+	}
+	errs := []error{}
+	for _, id := range r.IDs {
+		if id <= 0 {
+			errs = append(errs, fmt.Errorf("ids: %d is not a valid staged deletion ID", id))
+		}
+	}
+	return util.JoinErrs(errs)
+}
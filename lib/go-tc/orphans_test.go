@@ -0,0 +1,52 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOrphanDeletionsRequestValidate(t *testing.T) {
+	var req OrphanDeletionsRequest
+	if err := req.Validate(nil); err != nil {
+		t.Errorf("expected request without types to pass validation, got: %v", err)
+	}
+	if !reflect.DeepEqual(req.Types, OrphanTypes) {
+		t.Errorf("expected request without types to be of all types, got: %v", req.Types)
+	}
+
+	req.Types = []OrphanType{OrphanTypeParameter, "server"}
+	if err := req.Validate(nil); err == nil {
+		t.Error("expected request with an invalid type to fail validation")
+	}
+}
+
+func TestOrphanDeletionsApplyRequestValidate(t *testing.T) {
+	if err := (&OrphanDeletionsApplyRequest{IDs: []int{1, 2}}).Validate(nil); err != nil {
+		t.Errorf("expected valid request to pass validation, got: %v", err)
+	}
+	if err := (&OrphanDeletionsApplyRequest{}).Validate(nil); err == nil {
+		t.Error("expected request without IDs to fail validation")
+	}
+	if err := (&OrphanDeletionsApplyRequest{IDs: []int{0}}).Validate(nil); err == nil {
+		t.Error("expected request with an invalid ID to fail validation")
+	}
+}
@@ -0,0 +1,44 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// CursorQueryParam is the name of the query parameter through which clients
+// pass the cursor of the page of a collection they want.
+const CursorQueryParam = "cursor"
+
+// Pagination holds the opaque cursors with which the pages before and after
+// the page of a collection in a response may be requested. Either is omitted
+// when there is no such page.
+type Pagination struct {
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
+
+// Paginated is embedded in the responses of collections that support cursor
+// pagination.
+type Paginated struct {
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+// Cursors returns the cursors of the pages adjacent to the one in the
+// response, or nil if it wasn't paginated with cursors.
+func (p Paginated) Cursors() *Pagination {
+	return p.Pagination
+}
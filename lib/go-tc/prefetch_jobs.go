@@ -0,0 +1,266 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// Limits of prefetch jobs.
+const (
+	// MaxPrefetchJobURLs is the most URLs a prefetch job may have.
+	MaxPrefetchJobURLs = 10000
+	// MaxPrefetchJobManifestURLs is the most manifest URLs a prefetch job may
+	// have.
+	MaxPrefetchJobManifestURLs = 100
+	// MaxPrefetchFailedURLs is the most failed URLs kept of each cache
+	// server's progress on a prefetch job.
+	MaxPrefetchFailedURLs = 20
+)
+
+// Query parameters of requests for prefetch jobs.
+const (
+	// PrefetchJobServerIDQueryParam is the query parameter naming the cache
+	// server whose pending prefetch jobs are requested.
+	PrefetchJobServerIDQueryParam = "serverId"
+)
+
+// The statuses of prefetch jobs.
+const (
+	// PrefetchJobStatusPending is the status of a prefetch job no cache
+	// server has started, because it hasn't reached its start time or none
+	// has polled for it yet.
+	PrefetchJobStatusPending = "PENDING"
+	// PrefetchJobStatusInProgress is the status of a prefetch job some cache
+	// servers are yet to finish.
+	PrefetchJobStatusInProgress = "IN_PROGRESS"
+	// PrefetchJobStatusComplete is the status of a prefetch job every cache
+	// server in its Cache Groups has finished.
+	PrefetchJobStatusComplete = "COMPLETE"
+)
+
+// PrefetchJobRequest is a request to create a prefetch job, which fetches
+// content into the cache servers of Cache Groups ahead of its being
+// requested - e.g. ahead of a launch.
+type PrefetchJobRequest struct {
+	// DeliveryServiceID is the ID of the Delivery Service whose content is
+	// fetched.
+	DeliveryServiceID int `json:"deliveryServiceId"`
+	// URLs are the URLs of the content fetched.
+	URLs []string `json:"urls"`
+	// ManifestURLs are the URLs of HLS playlists, or of plain lists of URLs
+	// one per line, which are fetched and expanded by cache servers into the
+	// URLs they list - relative to the manifest - all of which are fetched.
+	ManifestURLs []string `json:"manifestUrls"`
+	// CacheGroups are the names of the Cache Groups whose cache servers fetch
+	// the content.
+	CacheGroups []string `json:"cacheGroups"`
+	// StartTime is when cache servers may start fetching the content. If
+	// it's nil, they may start immediately.
+	StartTime *time.Time `json:"startTime"`
+}
+
+// Validate validates the request, less whether its Delivery Service and Cache
+// Groups exist and the hosts of its URLs are those of its Delivery Service,
+// which are checked against Traffic Ops's database. URLs must be absolute
+// HTTP or HTTPS URLs, and are rewritten without fragments.
+func (r *PrefetchJobRequest) Validate() error {
+	errs := []error{}
+	if r.DeliveryServiceID <= 0 {
+		errs = append(errs, errors.New("deliveryServiceId: required"))
+	}
+	if len(r.URLs) == 0 && len(r.ManifestURLs) == 0 {
+		errs = append(errs, errors.New("urls: required if manifestUrls is empty"))
+	}
+	if len(r.URLs) > MaxPrefetchJobURLs {
+		errs = append(errs, fmt.Errorf("urls: cannot have more than %d URLs", MaxPrefetchJobURLs))
+	}
+	if len(r.ManifestURLs) > MaxPrefetchJobManifestURLs {
+		errs = append(errs, fmt.Errorf("manifestUrls: cannot have more than %d URLs", MaxPrefetchJobManifestURLs))
+	}
+	var err error
+	if r.URLs, err = canonicalPrefetchURLs(r.URLs); err != nil {
+		errs = append(errs, errors.New("urls: "+err.Error()))
+	}
+	if r.ManifestURLs, err = canonicalPrefetchURLs(r.ManifestURLs); err != nil {
+		errs = append(errs, errors.New("manifestUrls: "+err.Error()))
+	}
+	if len(r.CacheGroups) == 0 {
+		errs = append(errs, errors.New("cacheGroups: required"))
+	}
+	for _, cg := range r.CacheGroups {
+		if strings.TrimSpace(cg) == "" {
+			errs = append(errs, errors.New("cacheGroups: names cannot be blank"))
+			break
+		}
+	}
+	return util.JoinErrs(errs)
+}
+
+// PrefetchURLHosts returns the hosts of the given URLs, lowercased and
+// without ports, without duplicates.
+func PrefetchURLHosts(urls ...[]string) []string {
+	seen := map[string]struct{}{}
+	hosts := []string{}
+	for _, list := range urls {
+		for _, raw := range list {
+			u, err := url.Parse(raw)
+			if err != nil {
+				continue
+			}
+			host := strings.ToLower(u.Hostname())
+			if _, ok := seen[host]; !ok {
+				seen[host] = struct{}{}
+				hosts = append(hosts, host)
+			}
+		}
+	}
+	return hosts
+}
+
+// canonicalPrefetchURLs returns the given URLs without their fragments and
+// without duplicates, in their original order. They must all be absolute
+// HTTP or HTTPS URLs.
+func canonicalPrefetchURLs(urls []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(urls))
+	canonical := make([]string, 0, len(urls))
+	for _, raw := range urls {
+		u, err := url.Parse(strings.TrimSpace(raw))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("'%s' is not an absolute HTTP or HTTPS URL", raw)
+		}
+		u.Fragment = ""
+		s := u.String()
+		if _, ok := seen[s]; !ok {
+			seen[s] = struct{}{}
+			canonical = append(canonical, s)
+		}
+	}
+	return canonical, nil
+}
+
+// PrefetchJob is a job which fetches content into the cache servers of Cache
+// Groups ahead of its being requested.
+type PrefetchJob struct {
+	// ID is the integral, unique identifier of the job.
+	ID int `json:"id"`
+	// DeliveryServiceID is the ID of the Delivery Service whose content is
+	// fetched.
+	DeliveryServiceID int `json:"deliveryServiceId"`
+	// DeliveryService is the XMLID of the Delivery Service.
+	DeliveryService string `json:"deliveryService"`
+	// URLs are the URLs of the content fetched.
+	URLs []string `json:"urls"`
+	// ManifestURLs are the URLs of manifests expanded into URLs fetched.
+	ManifestURLs []string `json:"manifestUrls"`
+	// CacheGroups are the names of the Cache Groups whose cache servers fetch
+	// the content.
+	CacheGroups []string `json:"cacheGroups"`
+	// StartTime is when cache servers may start fetching the content.
+	StartTime time.Time `json:"startTime"`
+	// CreatedBy is the username of the user who created the job.
+	CreatedBy string `json:"createdBy"`
+	// Status is the status of the job - PrefetchJobStatusPending,
+	// PrefetchJobStatusInProgress, or PrefetchJobStatusComplete.
+	Status string `json:"status"`
+	// Servers is how many cache servers in the job's Cache Groups fetch its
+	// content.
+	Servers int `json:"servers"`
+	// Progress is the progress of each cache server which has started the
+	// job.
+	Progress []PrefetchJobProgress `json:"progress"`
+	// LastUpdated is when the job was created.
+	LastUpdated time.Time `json:"lastUpdated"`
+}
+
+// PrefetchJobProgress is the progress of a cache server on a prefetch job,
+// as reported by it.
+type PrefetchJobProgress struct {
+	// ServerID is the ID of the cache server.
+	ServerID int `json:"serverId"`
+	// HostName is the host name of the cache server. It's ignored in
+	// reports of progress.
+	HostName string `json:"hostName"`
+	// Total is how many URLs the cache server has to fetch, including those
+	// expanded from manifests.
+	Total int `json:"total"`
+	// Fetched is how many URLs the cache server has fetched successfully.
+	Fetched int `json:"fetched"`
+	// Failed is how many URLs the cache server failed to fetch.
+	Failed int `json:"failed"`
+	// FailedURLs are up to MaxPrefetchFailedURLs of the URLs which failed,
+	// with the reasons they did.
+	FailedURLs []string `json:"failedUrls"`
+	// Done is whether the cache server has finished the job.
+	Done bool `json:"done"`
+	// LastUpdated is when the progress was last reported.
+	LastUpdated time.Time `json:"lastUpdated"`
+}
+
+// Validate validates a report of progress on a prefetch job.
+func (p PrefetchJobProgress) Validate() error {
+	errs := []error{}
+	if p.ServerID <= 0 {
+		errs = append(errs, errors.New("serverId: required"))
+	}
+	if p.Total < 0 || p.Fetched < 0 || p.Failed < 0 {
+		errs = append(errs, errors.New("total, fetched, failed: cannot be negative"))
+	}
+	if p.Fetched+p.Failed > p.Total {
+		errs = append(errs, errors.New("fetched, failed: cannot add up to more than total"))
+	}
+	if len(p.FailedURLs) > MaxPrefetchFailedURLs {
+		errs = append(errs, fmt.Errorf("failedUrls: cannot have more than %d URLs", MaxPrefetchFailedURLs))
+	}
+	return util.JoinErrs(errs)
+}
+
+// PrefetchJobStatus returns the status of a prefetch job which starts at the
+// given time, at the given time, given how many cache servers fetch its
+// content, how many have reported progress, and how many have finished.
+func PrefetchJobStatus(start, now time.Time, servers, reported, done int) string {
+	if servers > 0 && done >= servers {
+		return PrefetchJobStatusComplete
+	}
+	if now.Before(start) || reported == 0 {
+		return PrefetchJobStatusPending
+	}
+	return PrefetchJobStatusInProgress
+}
+
+// PrefetchJobsResponse is the type of a response from Traffic Ops to a
+// request for prefetch jobs.
+type PrefetchJobsResponse struct {
+	Response []PrefetchJob `json:"response"`
+	Alerts
+}
+
+// PrefetchJobResponse is the type of a response from Traffic Ops to a
+// request which creates a prefetch job, or reports progress on one.
+type PrefetchJobResponse struct {
+	Response PrefetchJob `json:"response"`
+	Alerts
+}
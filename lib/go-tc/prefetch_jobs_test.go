@@ -0,0 +1,103 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPrefetchJobRequestValidate(t *testing.T) {
+	req := PrefetchJobRequest{
+		DeliveryServiceID: 1,
+		URLs: []string{
+			"http://video.example.com/a.ts#start",
+			"http://video.example.com/a.ts",
+			" https://video.example.com/b.ts",
+		},
+		CacheGroups: []string{"edge-east"},
+	}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("unexpected error validating a valid request: %v", err)
+	}
+	expected := []string{"http://video.example.com/a.ts", "https://video.example.com/b.ts"}
+	if !reflect.DeepEqual(req.URLs, expected) {
+		t.Errorf("expected URLs to be canonicalized to %v, got %v", expected, req.URLs)
+	}
+
+	invalid := []PrefetchJobRequest{
+		{URLs: []string{"http://video.example.com/a.ts"}, CacheGroups: []string{"edge-east"}},
+		{DeliveryServiceID: 1, CacheGroups: []string{"edge-east"}},
+		{DeliveryServiceID: 1, URLs: []string{"/a.ts"}, CacheGroups: []string{"edge-east"}},
+		{DeliveryServiceID: 1, ManifestURLs: []string{"ftp://video.example.com/a.m3u8"}, CacheGroups: []string{"edge-east"}},
+		{DeliveryServiceID: 1, URLs: []string{"http://video.example.com/a.ts"}},
+		{DeliveryServiceID: 1, URLs: []string{"http://video.example.com/a.ts"}, CacheGroups: []string{" "}},
+	}
+	for i, req := range invalid {
+		if err := req.Validate(); err == nil {
+			t.Errorf("expected an error validating invalid request #%d, got nil", i)
+		}
+	}
+}
+
+func TestPrefetchURLHosts(t *testing.T) {
+	hosts := PrefetchURLHosts(
+		[]string{"http://Video.example.com:8080/a.ts", "http://video.example.com/b.ts"},
+		[]string{"https://manifests.example.com/a.m3u8"},
+	)
+	expected := []string{"video.example.com", "manifests.example.com"}
+	if !reflect.DeepEqual(hosts, expected) {
+		t.Errorf("expected hosts %v, got %v", expected, hosts)
+	}
+}
+
+func TestPrefetchJobProgressValidate(t *testing.T) {
+	if err := (PrefetchJobProgress{ServerID: 1, Total: 3, Fetched: 2, Failed: 1}).Validate(); err != nil {
+		t.Errorf("unexpected error validating valid progress: %v", err)
+	}
+	if err := (PrefetchJobProgress{Total: 3}).Validate(); err == nil {
+		t.Error("expected an error validating progress without a server, got nil")
+	}
+	if err := (PrefetchJobProgress{ServerID: 1, Total: 3, Fetched: 3, Failed: 1}).Validate(); err == nil {
+		t.Error("expected an error validating progress of more URLs than its total, got nil")
+	}
+}
+
+func TestPrefetchJobStatus(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		start                   time.Time
+		servers, reported, done int
+		expected                string
+	}{
+		{now.Add(time.Hour), 2, 0, 0, PrefetchJobStatusPending},
+		{now.Add(-time.Hour), 2, 0, 0, PrefetchJobStatusPending},
+		{now.Add(-time.Hour), 2, 1, 0, PrefetchJobStatusInProgress},
+		{now.Add(-time.Hour), 2, 2, 1, PrefetchJobStatusInProgress},
+		{now.Add(-time.Hour), 2, 2, 2, PrefetchJobStatusComplete},
+		{now.Add(-time.Hour), 0, 0, 0, PrefetchJobStatusPending},
+	}
+	for i, test := range tests {
+		if status := PrefetchJobStatus(test.start, now, test.servers, test.reported, test.done); status != test.expected {
+			t.Errorf("test #%d: expected status %s, got %s", i, test.expected, status)
+		}
+	}
+}
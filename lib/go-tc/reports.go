@@ -0,0 +1,93 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// BillingReportMonthFormat is the format of the month of a billing report, as
+// given in the 'month' query parameter of the /reports/billing endpoint.
+const BillingReportMonthFormat = "2006-01"
+
+// DeliveryServiceBillingReport is the usage, and cost, of a Delivery Service
+// in a billing report month.
+type DeliveryServiceBillingReport struct {
+	// ID is the integral, unique identifier of the Delivery Service.
+	ID int `json:"id"`
+	// XMLID is the XMLID of the Delivery Service.
+	XMLID string `json:"xmlId"`
+	// Days is the number of days of the month for which there are stats
+	// summaries of the Delivery Service.
+	Days int `json:"days"`
+	// BytesServedTB is the number of terabytes the Delivery Service served
+	// in the month.
+	BytesServedTB float64 `json:"bytesServedTB"`
+	// P95Gbps is the 95th percentile of the Delivery Service's daily 95th
+	// percentile bandwidth in the month, in gigabits per second.
+	P95Gbps float64 `json:"p95Gbps"`
+	// Cost is the cost of the Delivery Service's usage under its Tenant's
+	// rate card, before any monthly minimum, or nil if the Tenant has no
+	// rate card.
+	Cost *float64 `json:"cost"`
+}
+
+// TenantBillingReport is the usage, and cost, of the Delivery Services of a
+// Tenant in a billing report month.
+type TenantBillingReport struct {
+	// TenantID is the integral, unique identifier of the Tenant.
+	TenantID int `json:"tenantId"`
+	// Tenant is the name of the Tenant.
+	Tenant string `json:"tenant"`
+	// RateCard is the name of the rate card by which the Tenant is billed,
+	// or nil if it has none.
+	RateCard *string `json:"rateCard"`
+	// Currency is the currency of the Tenant's costs, or nil if it has no
+	// rate card.
+	Currency *string `json:"currency"`
+	// BytesServedTB is the total number of terabytes the Tenant's Delivery
+	// Services served in the month.
+	BytesServedTB float64 `json:"bytesServedTB"`
+	// P95Gbps is the sum of the P95Gbps of the Tenant's Delivery Services.
+	// Because percentiles aren't additive, this is an upper bound of the
+	// 95th percentile of their combined bandwidth.
+	P95Gbps float64 `json:"p95Gbps"`
+	// Cost is the total cost of the Tenant's Delivery Services, or its rate
+	// card's monthly minimum if that is greater, or nil if it has no rate
+	// card.
+	Cost *float64 `json:"cost"`
+	// DeliveryServices is the usage of each of the Tenant's Delivery Services
+	// with stats summaries in the month.
+	DeliveryServices []DeliveryServiceBillingReport `json:"deliveryServices"`
+}
+
+// BillingReport is a monthly report of the usage, and cost, of the Delivery
+// Services of each Tenant.
+type BillingReport struct {
+	// Month is the month of the report, in BillingReportMonthFormat.
+	Month string `json:"month"`
+	// Tenants is the usage of each Tenant with Delivery Service stats
+	// summaries in the month.
+	Tenants []TenantBillingReport `json:"tenants"`
+}
+
+// BillingReportResponse is the type of a response from Traffic Ops to a GET
+// request to its /reports/billing endpoint, when a JSON response is
+// requested.
+type BillingReportResponse struct {
+	Response BillingReport `json:"response"`
+	Alerts
+}
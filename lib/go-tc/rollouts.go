@@ -0,0 +1,136 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/go-ozzo/ozzo-validation"
+)
+
+// RolloutStatus is the state of a Rollout.
+type RolloutStatus string
+
+// These are the valid RolloutStatuses.
+const (
+	// RolloutStatusRunning means the Rollout releases updates to its servers
+	// step by step.
+	RolloutStatusRunning = RolloutStatus("running")
+	// RolloutStatusPaused means the Rollout was paused by a user, and
+	// releases no updates until it is resumed.
+	RolloutStatusPaused = RolloutStatus("paused")
+	// RolloutStatusHalted means the Rollout stopped itself because its
+	// servers failed to apply their updates, or became unhealthy, and
+	// releases no updates until it is resumed.
+	RolloutStatusHalted = RolloutStatus("halted")
+	// RolloutStatusAborted means the Rollout was aborted by a user; servers
+	// it had not released updates to will never get them from it.
+	RolloutStatusAborted = RolloutStatus("aborted")
+	// RolloutStatusCompleted means the Rollout released updates to all of
+	// its servers, all of which applied them.
+	RolloutStatusCompleted = RolloutStatus("completed")
+)
+
+// InProgress returns whether a Rollout with the status is still in progress,
+// i.e. neither aborted nor completed.
+func (s RolloutStatus) InProgress() bool {
+	return s == RolloutStatusRunning || s == RolloutStatusPaused || s == RolloutStatusHalted
+}
+
+// These are the defaults of the optional RolloutRequest properties.
+const (
+	RolloutDefaultSoakSeconds        = 300
+	RolloutDefaultStepTimeoutSeconds = 1800
+)
+
+// RolloutsResponse is the type of a response from Traffic Ops to a GET
+// request made to its /rollouts API endpoint.
+type RolloutsResponse struct {
+	Response []Rollout `json:"response"`
+	Alerts
+}
+
+// RolloutResponse is the type of a response from Traffic Ops to a POST
+// request made to its /rollouts API endpoint, or any of the
+// /rollouts/{id}/pause, /rollouts/{id}/resume and /rollouts/{id}/abort
+// API endpoints.
+type RolloutResponse struct {
+	Response Rollout `json:"response"`
+	Alerts
+}
+
+// RolloutRequest encodes the request data for creating a Rollout.
+type RolloutRequest struct {
+	CDNID int `json:"cdnId"`
+	// StepPercent is the percentage of each Cache Group's cache servers
+	// released updates in each step.
+	StepPercent int `json:"stepPercent"`
+	// SoakSeconds is how long the servers released updates in a step must
+	// stay healthy, after all of them applied the updates, before the next
+	// step is released.
+	SoakSeconds *int `json:"soakSeconds"`
+	// StepTimeoutSeconds is how long the servers released updates in a step
+	// have to apply them, before the Rollout halts.
+	StepTimeoutSeconds *int `json:"stepTimeoutSeconds"`
+}
+
+// Rollout is a gradual release of queued updates to the cache servers of a
+// CDN, a percentage of each Cache Group's servers at a time, which proceeds
+// only while the released servers apply their updates and remain healthy.
+type Rollout struct {
+	ID                  int           `json:"id" db:"id"`
+	CDN                 string        `json:"cdn" db:"cdn"`
+	CDNID               int           `json:"cdnId" db:"cdn_id"`
+	StepPercent         int           `json:"stepPercent" db:"step_percent"`
+	SoakSeconds         int           `json:"soakSeconds" db:"soak_seconds"`
+	StepTimeoutSeconds  int           `json:"stepTimeoutSeconds" db:"step_timeout_seconds"`
+	Status              RolloutStatus `json:"status" db:"status"`
+	CurrentStep         int           `json:"currentStep" db:"current_step"`
+	StepCount           int           `json:"stepCount" db:"step_count"`
+	StepStartedAt       *time.Time    `json:"stepStartedAt" db:"step_started_at"`
+	StepAppliedAt       *time.Time    `json:"stepAppliedAt" db:"step_applied_at"`
+	HaltReason          *string       `json:"haltReason" db:"halt_reason"`
+	ServerCount         int           `json:"serverCount" db:"server_count"`
+	ReleasedServerCount int           `json:"releasedServerCount" db:"released_server_count"`
+	AppliedServerCount  int           `json:"appliedServerCount" db:"applied_server_count"`
+	CreatedBy           string        `json:"createdBy" db:"created_by"`
+	LastUpdated         time.Time     `json:"lastUpdated" db:"last_updated"`
+}
+
+// Validate validates that the RolloutRequest is valid for creation of a
+// Rollout.
+func (r *RolloutRequest) Validate(tx *sql.Tx) error {
+	errs := tovalidate.ToErrors(validation.Errors{
+		"cdnId":       validation.Validate(r.CDNID, validation.Required),
+		"stepPercent": validation.Validate(r.StepPercent, validation.Required, validation.Min(1), validation.Max(100)),
+	})
+	if r.SoakSeconds != nil && *r.SoakSeconds < 0 {
+		errs = append(errs, errors.New("soakSeconds: must be no less than 0"))
+	}
+	if r.StepTimeoutSeconds != nil && *r.StepTimeoutSeconds < 1 {
+		errs = append(errs, errors.New("stepTimeoutSeconds: must be no less than 1"))
+	}
+	return util.JoinErrs(errs)
+}
@@ -0,0 +1,82 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// RoutingTraceGeolocation is a geographic location reported in a
+// RoutingTrace.
+type RoutingTraceGeolocation struct {
+	Latitude        float64 `json:"latitude"`
+	Longitude       float64 `json:"longitude"`
+	CountryCode     *string `json:"countryCode"`
+	City            *string `json:"city"`
+	PostalCode      *string `json:"postalCode"`
+	DefaultLocation bool    `json:"defaultLocation"`
+}
+
+// RoutingTrace is the account, given by a Traffic Router, of how it would
+// route an HTTP request from a client to a host and path.
+type RoutingTrace struct {
+	// Router is the FQDN of the Traffic Router which traced the request.
+	Router   string `json:"router"`
+	ClientIP string `json:"clientIp"`
+	Host     string `json:"host"`
+	Path     string `json:"path"`
+	Secure   bool   `json:"secure"`
+	// Geolocation is the result of looking up the client's location in the
+	// Geolocation database, or nil if the lookup failed.
+	Geolocation *RoutingTraceGeolocation `json:"geolocation"`
+	// CoverageZone is the name of the Cache Group to which the client's
+	// address is mapped by the Coverage Zone File, if any.
+	CoverageZone *string `json:"coverageZone"`
+	// DeepCoverageZone is the name of the Cache Group to which the client's
+	// address is mapped by the Deep Coverage Zone File, if any.
+	DeepCoverageZone *string `json:"deepCoverageZone"`
+	// DeliveryService is the XMLID of the Delivery Service matched by the
+	// request, if any.
+	DeliveryService *string `json:"deliveryService"`
+	// Cache is the host name of the cache server selected to serve the
+	// request, if any.
+	Cache *string `json:"cache"`
+	// URLs are the URLs to which the client would be redirected.
+	URLs []string `json:"urls"`
+	// Result is how the request was routed, e.g. "CZ" or "GEO", as recorded
+	// in Traffic Router's access log.
+	Result string `json:"result"`
+	// ReasonCodes are the result, result details, and regional geo-blocking
+	// result of routing the request, in that order, omitting any that
+	// weren't determined.
+	ReasonCodes []string `json:"reasonCodes"`
+	// ResultLocation is the location of the Cache Group selected to serve
+	// the request, if any.
+	ResultLocation       *RoutingTraceGeolocation `json:"resultLocation"`
+	FromBackupCacheGroup bool                     `json:"fromBackupCacheGroup"`
+	// ResponseCode is the HTTP response code Traffic Router would give, if
+	// it isn't the usual redirect.
+	ResponseCode *int `json:"responseCode"`
+	// Error is any error Traffic Router encountered routing the request.
+	Error *string `json:"error,omitempty"`
+}
+
+// RoutingTraceResponse is the type of a response from Traffic Ops to a
+// request to trace the routing of an HTTP request.
+type RoutingTraceResponse struct {
+	Response RoutingTrace `json:"response"`
+	Alerts
+}
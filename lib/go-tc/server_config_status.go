@@ -0,0 +1,119 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// ServerConfigApplyStatus is the state of a cache server's configuration, as
+// of the last t3c-apply run it reported to Traffic Ops.
+type ServerConfigApplyStatus string
+
+// These are the valid ServerConfigApplyStatuses.
+const (
+	// ServerConfigApplyStatusApplied is the status of a server whose last
+	// t3c-apply run succeeded, and which has no updates pending.
+	ServerConfigApplyStatusApplied = ServerConfigApplyStatus("applied")
+	// ServerConfigApplyStatusPending is the status of a server whose last
+	// t3c-apply run succeeded, but which has had updates queued since.
+	ServerConfigApplyStatusPending = ServerConfigApplyStatus("pending")
+	// ServerConfigApplyStatusFailed is the status of a server whose last
+	// t3c-apply run failed.
+	ServerConfigApplyStatusFailed = ServerConfigApplyStatus("failed")
+	// ServerConfigApplyStatusUnknown is the status of a server which has never
+	// reported a t3c-apply run.
+	ServerConfigApplyStatusUnknown = ServerConfigApplyStatus("unknown")
+)
+
+// ServerConfigStatusesResponse is the type of a response from Traffic Ops to
+// a GET request made to its /servers/config-status API endpoint.
+type ServerConfigStatusesResponse struct {
+	Response []ServerConfigStatus `json:"response"`
+	Alerts
+}
+
+// ServerConfigRunRequest is the outcome of a t3c-apply run on a cache
+// server, as reported to Traffic Ops in a POST request to its
+// /servers/{{ID or host name}}/config-status API endpoint.
+type ServerConfigRunRequest struct {
+	// Succeeded is whether the run succeeded.
+	Succeeded bool `json:"succeeded"`
+	// ConfigUpdateTime is the time updates were last queued on the server,
+	// as seen by the run, if it applied queued updates - that is, the
+	// version of the server's configuration it applied.
+	ConfigUpdateTime *time.Time `json:"configUpdateTime"`
+	// StartTime is when the run started.
+	StartTime time.Time `json:"startTime"`
+	// DurationMS is how long the run took, in milliseconds.
+	DurationMS int64 `json:"durationMs"`
+	// FilesChanged are the paths of the configuration files the run changed.
+	FilesChanged []string `json:"filesChanged"`
+	// Errors are the errors which made the run fail, if it did.
+	Errors []string `json:"errors"`
+	// T3CVersion is the version of t3c which made the run.
+	T3CVersion string `json:"t3cVersion"`
+}
+
+// ServerConfigRun is the outcome of the last t3c-apply run reported by a
+// cache server.
+type ServerConfigRun struct {
+	ServerConfigRunRequest
+	// ReportedAt is when the run was reported to Traffic Ops.
+	ReportedAt time.Time `json:"reportedAt"`
+}
+
+// ServerConfigStatus is the state of a cache server's configuration, for
+// the fleet-wide view of configuration application.
+type ServerConfigStatus struct {
+	ServerID   int    `json:"serverId"`
+	HostName   string `json:"hostName"`
+	CDN        string `json:"cdn"`
+	Cachegroup string `json:"cachegroup"`
+	// Status is the operational status of the server, e.g. ONLINE.
+	Status string `json:"status"`
+	// ApplyStatus is the state of the server's configuration.
+	ApplyStatus ServerConfigApplyStatus `json:"applyStatus"`
+	// ConfigUpdateTime is when updates were last queued on the server.
+	ConfigUpdateTime *time.Time `json:"configUpdateTime"`
+	// ConfigApplyTime is the update time the server last applied.
+	ConfigApplyTime *time.Time `json:"configApplyTime"`
+	// LastSuccessTime is when the server last reported a successful run.
+	LastSuccessTime *time.Time `json:"lastSuccessTime"`
+	// LastRun is the last run the server reported, if any.
+	LastRun *ServerConfigRun `json:"lastRun"`
+}
+
+// Validate validates that the ServerConfigRunRequest is a valid report of a
+// t3c-apply run.
+func (r *ServerConfigRunRequest) Validate(tx *sql.Tx) error {
+	errs := []error{}
+	if r.StartTime.IsZero() {
+		errs = append(errs, errors.New("startTime: required"))
+	}
+	if r.DurationMS < 0 {
+		errs = append(errs, errors.New("durationMs: must be no less than 0"))
+	}
+	return util.JoinErrs(errs)
+}
@@ -0,0 +1,63 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// These are the names of the logs of cache servers which can be tailed
+// through /servers/{{ID}}/logs/tail.
+const (
+	// ServerLogAccess is the ATS access log.
+	ServerLogAccess = "access"
+	// ServerLogDiags is the ATS diagnostic log.
+	ServerLogDiags = "diags"
+)
+
+// These are the query parameters of /servers/{{ID}}/logs/tail.
+const (
+	// ServerLogTailLogQueryParam names the log to tail.
+	ServerLogTailLogQueryParam = "log"
+	// ServerLogTailDeliveryServiceQueryParam is the ID of a Delivery
+	// Service, to which the tailed lines are restricted.
+	ServerLogTailDeliveryServiceQueryParam = "deliveryServiceId"
+	// ServerLogTailSecondsQueryParam is how long to tail the log, in seconds.
+	ServerLogTailSecondsQueryParam = "seconds"
+)
+
+// DefaultServerLogTailSeconds is how long a log is tailed if the request
+// doesn't say.
+const DefaultServerLogTailSeconds = 60
+
+// DefaultServerLogTailMaxSeconds is the longest a log may be tailed, unless
+// Traffic Ops is configured otherwise.
+const DefaultServerLogTailMaxSeconds = 300
+
+// DefaultLogAgentPort is the port on which t3c-log-agent listens, unless
+// configured otherwise.
+const DefaultLogAgentPort = 8099
+
+// LogAgentLogsPath is the path of the t3c-log-agent endpoint which streams
+// the lines appended to the log whose name follows it, e.g. /logs/access.
+// Its query parameters are "seconds", how long to stream the log, and
+// "match", regular expressions of which a line must match at least one to be
+// streamed, which may be given more than once.
+const LogAgentLogsPath = "/logs/"
+
+// LogAgentMatchQueryParam is the query parameter of regular expressions by
+// which t3c-log-agent filters the lines it streams.
+const LogAgentMatchQueryParam = "match"
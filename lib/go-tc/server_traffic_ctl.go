@@ -0,0 +1,120 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// These are the traffic_ctl commands which may be run on cache servers
+// through /servers/{{ID}}/traffic_ctl.
+const (
+	// TrafficCtlMetricGet gets the values of the metrics named by its
+	// arguments.
+	TrafficCtlMetricGet = "metric get"
+	// TrafficCtlConfigReload reloads the configuration of ATS. It takes no
+	// arguments.
+	TrafficCtlConfigReload = "config reload"
+	// TrafficCtlHostStatus gets the statuses of the parent hosts named by its
+	// arguments.
+	TrafficCtlHostStatus = "host status"
+)
+
+// MaxTrafficCtlArgs is the most arguments a traffic_ctl command may be given.
+const MaxTrafficCtlArgs = 20
+
+// LogAgentTrafficCtlPath is the path of the t3c-log-agent endpoint which runs
+// the traffic_ctl command POSTed to it as a TrafficCtlRequest, and responds
+// with a TrafficCtlResult.
+const LogAgentTrafficCtlPath = "/traffic_ctl"
+
+// trafficCtlArgRegex matches the arguments which may be given to traffic_ctl
+// commands - metric and host names. They may not start with a '-', so that
+// they can't be taken for options.
+var trafficCtlArgRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.\-]*$`)
+
+// TrafficCtlRequest is the type of a request to run a traffic_ctl command on
+// a cache server.
+type TrafficCtlRequest struct {
+	// Command is the TrafficCtl* command to run.
+	Command string `json:"command"`
+	// Args are the arguments of the command.
+	Args []string `json:"args"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface. Only the TrafficCtl* commands may be run, with arguments that
+// are metric or host names.
+func (r *TrafficCtlRequest) Validate(*sql.Tx) error {
+	switch r.Command {
+	case TrafficCtlMetricGet, TrafficCtlHostStatus:
+		if len(r.Args) == 0 || len(r.Args) > MaxTrafficCtlArgs {
+			return fmt.Errorf("args: '%s' must be given from 1 to %d arguments", r.Command, MaxTrafficCtlArgs)
+		}
+	case TrafficCtlConfigReload:
+		if len(r.Args) != 0 {
+			return fmt.Errorf("args: '%s' takes no arguments", r.Command)
+		}
+	default:
+		return fmt.Errorf("command: must be one of '%s', '%s', or '%s'", TrafficCtlMetricGet, TrafficCtlConfigReload, TrafficCtlHostStatus)
+	}
+	for i, arg := range r.Args {
+		if !trafficCtlArgRegex.MatchString(arg) {
+			return fmt.Errorf("args[%d]: must be a metric or host name", i)
+		}
+	}
+	return nil
+}
+
+// CommandLine returns the arguments with which traffic_ctl is run for the
+// request, which must be valid.
+func (r TrafficCtlRequest) CommandLine() []string {
+	return append(strings.Fields(r.Command), r.Args...)
+}
+
+// String returns the command line of the request, as it would be typed.
+func (r TrafficCtlRequest) String() string {
+	return "traffic_ctl " + strings.Join(r.CommandLine(), " ")
+}
+
+// TrafficCtlResult is the result of running a traffic_ctl command on a cache
+// server.
+type TrafficCtlResult struct {
+	// Command is the command that was run.
+	Command string `json:"command"`
+	// Args are the arguments of the command.
+	Args []string `json:"args"`
+	// ExitCode is the exit code of traffic_ctl.
+	ExitCode int `json:"exitCode"`
+	// Output is the combined standard output and standard error of
+	// traffic_ctl.
+	Output string `json:"output"`
+}
+
+// TrafficCtlResponse is the type of a response from Traffic Ops to a request
+// to its /servers/{{ID}}/traffic_ctl endpoint.
+type TrafficCtlResponse struct {
+	Response TrafficCtlResult `json:"response"`
+	Alerts
+}
@@ -0,0 +1,60 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+)
+
+func TestTrafficCtlRequestValidate(t *testing.T) {
+	valid := map[string]TrafficCtlRequest{
+		"traffic_ctl metric get proxy.process.http.incoming_requests proxy.process.cache.bytes_used": {Command: TrafficCtlMetricGet, Args: []string{"proxy.process.http.incoming_requests", "proxy.process.cache.bytes_used"}},
+		"traffic_ctl config reload":                      {Command: TrafficCtlConfigReload},
+		"traffic_ctl host status mid-01.infra.ciab.test": {Command: TrafficCtlHostStatus, Args: []string{"mid-01.infra.ciab.test"}},
+	}
+	for expected, req := range valid {
+		if err := req.Validate(nil); err != nil {
+			t.Errorf("expected '%s' to be valid, got error: %v", expected, err)
+		}
+		if actual := req.String(); actual != expected {
+			t.Errorf("expected command line '%s', actual: '%s'", expected, actual)
+		}
+	}
+
+	tooMany := make([]string, MaxTrafficCtlArgs+1)
+	for i := range tooMany {
+		tooMany[i] = "proxy.process.http.incoming_requests"
+	}
+	invalid := map[string]TrafficCtlRequest{
+		"unknown command":         {Command: "server stop"},
+		"metric set":              {Command: "metric set", Args: []string{"proxy.config.http.cache.http", "0"}},
+		"metric get without args": {Command: TrafficCtlMetricGet},
+		"too many args":           {Command: TrafficCtlMetricGet, Args: tooMany},
+		"config reload with args": {Command: TrafficCtlConfigReload, Args: []string{"now"}},
+		"option injection":        {Command: TrafficCtlHostStatus, Args: []string{"--help"}},
+		"shell injection":         {Command: TrafficCtlMetricGet, Args: []string{"proxy.node;reboot"}},
+		"whitespace":              {Command: TrafficCtlMetricGet, Args: []string{"proxy.node version"}},
+	}
+	for name, req := range invalid {
+		if err := req.Validate(nil); err == nil {
+			t.Errorf("expected %s to be invalid, got no error", name)
+		}
+	}
+}
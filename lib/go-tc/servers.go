@@ -39,6 +39,7 @@ type ServersV4Response struct {
 		Count uint64 `json:"count"`
 	} `json:"summary"`
 	Alerts
+	Paginated
 }
 
 // ServersV3Response is the format of a response to a GET request for /servers.
@@ -199,6 +200,43 @@ func (i *ServerInterfaceInfo) GetDefaultAddressOrCIDR() (string, string) {
 	return ipv4, ipv6
 }
 
+// GetPreferredAddresses returns the first IPv4 and IPv6 addresses, without
+// subnets, of the first of the given interfaces - in the order of their names
+// in preferred - which have addresses of each family, or empty strings for
+// families none of them have. Unlike GetDefaultAddress, the addresses needn't
+// be service addresses.
+func GetPreferredAddresses(interfaces []ServerInterfaceInfoV40, preferred []string) (string, string) {
+	var ipv4, ipv6 string
+	for _, name := range preferred {
+		for _, inf := range interfaces {
+			if inf.Name != name {
+				continue
+			}
+			for _, ip := range inf.IPAddresses {
+				address := net.ParseIP(ip.Address)
+				if address == nil {
+					var err error
+					address, _, err = net.ParseCIDR(ip.Address)
+					if err != nil || address == nil {
+						continue
+					}
+				}
+				if address.To4() != nil {
+					if ipv4 == "" {
+						ipv4 = address.String()
+					}
+				} else if ipv6 == "" {
+					ipv6 = address.String()
+				}
+			}
+		}
+		if ipv4 != "" && ipv6 != "" {
+			break
+		}
+	}
+	return ipv4, ipv6
+}
+
 // Value implements the driver.Valuer interface
 // marshals struct to json to pass back as a json.RawMessage.
 func (sii *ServerInterfaceInfo) Value() (driver.Value, error) {
@@ -1371,3 +1371,50 @@ func TestServerNullableV2_Upgrade(t *testing.T) {
 		t.Errorf("Incorrect XMPPPasswd after upgraded conversion; want: '%s', got: '%s'", *nullable.XMPPPasswd, *upgraded.XMPPPasswd)
 	}
 }
+
+func TestGetPreferredAddresses(t *testing.T) {
+	interfaces := []ServerInterfaceInfoV40{
+		{
+			ServerInterfaceInfo: ServerInterfaceInfo{
+				Name: "eth0",
+				IPAddresses: []ServerIPAddress{
+					{Address: "192.0.2.1/24", ServiceAddress: true},
+					{Address: "2001:db8::1/64", ServiceAddress: true},
+				},
+			},
+		},
+		{
+			ServerInterfaceInfo: ServerInterfaceInfo{
+				Name: "vlan100",
+				IPAddresses: []ServerIPAddress{
+					{Address: "198.51.100.1"},
+				},
+			},
+		},
+		{
+			ServerInterfaceInfo: ServerInterfaceInfo{
+				Name: "v6only",
+				IPAddresses: []ServerIPAddress{
+					{Address: "2001:db8:1::1/64"},
+				},
+			},
+		},
+	}
+
+	ipv4, ipv6 := GetPreferredAddresses(interfaces, []string{"v6only", "vlan100", "eth0"})
+	if ipv4 != "198.51.100.1" {
+		t.Errorf("Expected the IPv4 address of the first preferred interface with one to be '198.51.100.1', got '%s'", ipv4)
+	}
+	if ipv6 != "2001:db8:1::1" {
+		t.Errorf("Expected the IPv6 address of the first preferred interface with one to be '2001:db8:1::1', got '%s'", ipv6)
+	}
+
+	ipv4, ipv6 = GetPreferredAddresses(interfaces, []string{"vlan100", "missing"})
+	if ipv4 != "198.51.100.1" || ipv6 != "" {
+		t.Errorf("Expected only an IPv4 address '198.51.100.1', got '%s' and '%s'", ipv4, ipv6)
+	}
+
+	if ipv4, ipv6 = GetPreferredAddresses(interfaces, nil); ipv4 != "" || ipv6 != "" {
+		t.Errorf("Expected no addresses without preferred interfaces, got '%s' and '%s'", ipv4, ipv6)
+	}
+}
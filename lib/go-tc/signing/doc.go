@@ -0,0 +1,32 @@
+// Package signing provides the signing and verification of Traffic Ops API
+// responses with CDN signing keys, which protects the configuration of a CDN
+// from being tampered with by anything between Traffic Ops and the component
+// applying it, such as a compromised proxy or a stale mirror.
+//
+// A client asks Traffic Ops to sign a response by naming a CDN in the
+// RequestHeader header of its request. If that CDN has a signing key, Traffic
+// Ops signs the raw bytes of the "response" property of the response body -
+// or the entire body, if it has no such property - and returns the detached,
+// base64-encoded ECDSA P-256 SHA-256 signature in the ResponseHeader header.
+// Clients verify the signature with the CDN's public key, which they must
+// obtain out-of-band.
+package signing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
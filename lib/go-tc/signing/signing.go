@@ -0,0 +1,192 @@
+package signing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+)
+
+const (
+	// RequestHeader is the HTTP request header naming the CDN with whose
+	// signing key Traffic Ops should sign the response.
+	RequestHeader = "Response-Signature-CDN"
+	// ResponseHeader is the HTTP response header containing the signature of
+	// the response.
+	ResponseHeader = "Response-Signature"
+	// CDNPEMHeader is the PEM header of a signing key naming its CDN.
+	CDNPEMHeader = "CDN"
+)
+
+const (
+	privateKeyPEMType = "PRIVATE KEY"
+	publicKeyPEMType  = "PUBLIC KEY"
+)
+
+// GenerateKey generates a new signing key for the named CDN, returning the
+// PEM-encoded PKCS #8 private key and PKIX public key. Both PEM blocks name
+// the CDN in their CDNPEMHeader header.
+func GenerateKey(cdn string) (string, string, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", fmt.Errorf("generating key: %w", err)
+	}
+	privateDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", "", fmt.Errorf("marshalling private key: %w", err)
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return "", "", fmt.Errorf("marshalling public key: %w", err)
+	}
+	headers := map[string]string{CDNPEMHeader: cdn}
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: privateKeyPEMType, Headers: headers, Bytes: privateDER})
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: publicKeyPEMType, Headers: headers, Bytes: publicDER})
+	return string(privatePEM), string(publicPEM), nil
+}
+
+// ParsePrivateKey parses a PEM-encoded private signing key, as returned by
+// GenerateKey.
+func ParsePrivateKey(privatePEM string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privatePEM))
+	if block == nil || block.Type != privateKeyPEMType {
+		return nil, errors.New("no " + privateKeyPEMType + " PEM block found")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key: %w", err)
+	}
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an ECDSA private key, got %T", key)
+	}
+	return ecKey, nil
+}
+
+// ParsePublicKey parses a PEM-encoded public signing key, as returned by
+// GenerateKey, returning the name of its CDN and the key.
+func ParsePublicKey(publicPEM []byte) (string, *ecdsa.PublicKey, error) {
+	block, _ := pem.Decode(publicPEM)
+	if block == nil || block.Type != publicKeyPEMType {
+		return "", nil, errors.New("no " + publicKeyPEMType + " PEM block found")
+	}
+	cdn := block.Headers[CDNPEMHeader]
+	if cdn == "" {
+		return "", nil, errors.New("public key PEM block has no " + CDNPEMHeader + " header")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return "", nil, fmt.Errorf("parsing public key: %w", err)
+	}
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return "", nil, fmt.Errorf("expected an ECDSA public key, got %T", key)
+	}
+	return cdn, ecKey, nil
+}
+
+// LoadVerifier reads the PEM-encoded public signing key in the given file,
+// and returns a Verifier for it.
+func LoadVerifier(path string) (*Verifier, error) {
+	publicPEM, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading public key file: %w", err)
+	}
+	cdn, key, err := ParsePublicKey(publicPEM)
+	if err != nil {
+		return nil, fmt.Errorf("parsing public key file '%s': %w", path, err)
+	}
+	return &Verifier{CDN: cdn, Key: key}, nil
+}
+
+// Sign returns the base64-encoded ASN.1 ECDSA signature of the SHA-256 hash
+// of the given data.
+func Sign(key *ecdsa.PrivateKey, data []byte) (string, error) {
+	hash := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	if err != nil {
+		return "", fmt.Errorf("signing: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// Verify returns an error if the given signature, as returned by Sign, isn't
+// a valid signature of the data by the given key.
+func Verify(key *ecdsa.PublicKey, data []byte, signature string) error {
+	if signature == "" {
+		return errors.New("missing signature")
+	}
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("decoding signature: %w", err)
+	}
+	hash := sha256.Sum256(data)
+	if !ecdsa.VerifyASN1(key, hash[:], sig) {
+		return errors.New("invalid signature")
+	}
+	return nil
+}
+
+// SignedData returns the part of the given Traffic Ops API response body
+// which is signed: the raw bytes of its "response" property, or the entire
+// body if it has none.
+func SignedData(body []byte) ([]byte, error) {
+	var resp struct {
+		Response json.RawMessage `json:"response"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing response body: %w", err)
+	}
+	if resp.Response == nil {
+		return body, nil
+	}
+	return resp.Response, nil
+}
+
+// Verifier verifies the signatures of responses signed with the signing key of
+// a CDN.
+type Verifier struct {
+	// CDN is the name of the CDN whose key is used.
+	CDN string
+	// Key is the CDN's public key.
+	Key *ecdsa.PublicKey
+}
+
+// VerifyResponse verifies the given signature of the given Traffic Ops API
+// response body, returning the signed data.
+func (v Verifier) VerifyResponse(body []byte, signature string) ([]byte, error) {
+	data, err := SignedData(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := Verify(v.Key, data, signature); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
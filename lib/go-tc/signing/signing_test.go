@@ -0,0 +1,125 @@
+package signing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSignVerify(t *testing.T) {
+	privatePEM, publicPEM, err := GenerateKey("cdn1")
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	privateKey, err := ParsePrivateKey(privatePEM)
+	if err != nil {
+		t.Fatalf("parsing private key: %v", err)
+	}
+	cdn, publicKey, err := ParsePublicKey([]byte(publicPEM))
+	if err != nil {
+		t.Fatalf("parsing public key: %v", err)
+	}
+	if cdn != "cdn1" {
+		t.Errorf("expected public key CDN 'cdn1', actual: '%s'", cdn)
+	}
+
+	body := []byte(`{"response": {"foo": [1, 2]}, "alerts": []}`)
+	data, err := SignedData(body)
+	if err != nil {
+		t.Fatalf("getting signed data: %v", err)
+	}
+	if string(data) != `{"foo": [1, 2]}` {
+		t.Errorf("expected signed data to be the raw response value, actual: '%s'", data)
+	}
+	sig, err := Sign(privateKey, data)
+	if err != nil {
+		t.Fatalf("signing: %v", err)
+	}
+
+	verifier := Verifier{CDN: cdn, Key: publicKey}
+	if _, err := verifier.VerifyResponse(body, sig); err != nil {
+		t.Errorf("expected valid signature to verify, actual error: %v", err)
+	}
+	if _, err := verifier.VerifyResponse([]byte(`{"response": {"foo": [1, 3]}}`), sig); err == nil {
+		t.Error("expected signature of tampered response to fail verification")
+	}
+	if _, err := verifier.VerifyResponse(body, ""); err == nil {
+		t.Error("expected missing signature to fail verification")
+	}
+}
+
+func TestSignedDataWithoutResponse(t *testing.T) {
+	body := []byte(`{"ping": "pong"}`)
+	data, err := SignedData(body)
+	if err != nil {
+		t.Fatalf("getting signed data: %v", err)
+	}
+	if string(data) != string(body) {
+		t.Errorf("expected signed data of a body without a response to be the body, actual: '%s'", data)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	privatePEM, publicPEM, err := GenerateKey("cdn1")
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	privateKey, err := ParsePrivateKey(privatePEM)
+	if err != nil {
+		t.Fatalf("parsing private key: %v", err)
+	}
+	cdn, publicKey, err := ParsePublicKey([]byte(publicPEM))
+	if err != nil {
+		t.Fatalf("parsing public key: %v", err)
+	}
+
+	tamper := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(RequestHeader) != "cdn1" {
+			t.Errorf("expected request header %s 'cdn1', actual: '%s'", RequestHeader, r.Header.Get(RequestHeader))
+		}
+		sig, err := Sign(privateKey, []byte(`"bar"`))
+		if err != nil {
+			t.Errorf("signing: %v", err)
+		}
+		w.Header().Set(ResponseHeader, sig)
+		if tamper {
+			w.Write([]byte(`{"response":"baz"}`))
+			return
+		}
+		w.Write([]byte(`{"response":"bar"}`))
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: NewTransport(nil, Verifier{CDN: cdn, Key: publicKey})}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("expected signed response to verify, actual error: %v", err)
+	}
+	resp.Body.Close()
+
+	tamper = true
+	if resp, err = client.Get(srv.URL); err == nil {
+		resp.Body.Close()
+		t.Error("expected tampered response to fail verification")
+	}
+}
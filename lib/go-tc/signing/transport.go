@@ -0,0 +1,68 @@
+package signing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// Transport is an http.RoundTripper which asks Traffic Ops to sign each
+// response with the key of its Verifier's CDN, and verifies the signatures of
+// successful GET responses, returning an error for any response with a
+// missing or invalid signature.
+type Transport struct {
+	// Base is the RoundTripper which makes the requests.
+	Base     http.RoundTripper
+	Verifier Verifier
+}
+
+// NewTransport returns a Transport which verifies responses with the given
+// Verifier, making its requests with base - or http.DefaultTransport, if base
+// is nil.
+func NewTransport(base http.RoundTripper, verifier Verifier) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{Base: base, Verifier: verifier}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set(RequestHeader, t.Verifier.CDN)
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil || req.Method != http.MethodGet || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+	if _, err := t.Verifier.VerifyResponse(body, resp.Header.Get(ResponseHeader)); err != nil {
+		return nil, fmt.Errorf("verifying signature of response from %s: %w", req.URL, err)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
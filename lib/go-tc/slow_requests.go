@@ -0,0 +1,56 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// SlowRequest is the combined cost of the requests made by one user to one
+// Traffic Ops API route over some window of time, as returned by the
+// /system/slow-requests endpoint.
+type SlowRequest struct {
+	// RouteID is the ID of the route, as used in the access log and in the
+	// routing_blacklist of the Traffic Ops configuration.
+	RouteID int `json:"routeId"`
+	// Route is the method and path of the route, e.g.
+	// "GET servers/{id}/?$".
+	Route string `json:"route"`
+	// User is the name of the user who made the requests, or empty for
+	// unauthenticated requests.
+	User string `json:"user"`
+	// Count is the number of requests.
+	Count int64 `json:"count"`
+	// DBTimeMilliseconds is the total time spent by the requests querying
+	// the database.
+	DBTimeMilliseconds int64 `json:"dbTimeMilliseconds"`
+	// MaxDBTimeMilliseconds is the most time spent by a single request
+	// querying the database.
+	MaxDBTimeMilliseconds int64 `json:"maxDbTimeMilliseconds"`
+	// Rows is the total number of database rows read by the requests.
+	Rows int64 `json:"rows"`
+	// Bytes is the total number of bytes returned by the requests.
+	Bytes int64 `json:"bytes"`
+	// DurationMilliseconds is the total time taken to handle the requests.
+	DurationMilliseconds int64 `json:"durationMilliseconds"`
+}
+
+// SlowRequestsResponse is the type of a response from Traffic Ops to
+// requests made to its /system/slow-requests endpoint.
+type SlowRequestsResponse struct {
+	Response []SlowRequest `json:"response"`
+	Alerts
+}
@@ -19,16 +19,31 @@ package tc
  * under the License.
  */
 
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
 // StaticDNSEntriesResponse is a list of StaticDNSEntry as a response.
 type StaticDNSEntriesResponse struct {
 	Response []StaticDNSEntry `json:"response"`
 	Alerts
+	Paginated
 }
 
 // StaticDNSEntry holds information about a static DNS entry.
 type StaticDNSEntry struct {
 
-	// The static IP Address or fqdn of the static dns entry
+	// The static IP Address or fqdn of the static dns entry, the text of a
+	// TXT record, "{{priority}} {{weight}} {{port}} {{target}}" for an SRV
+	// record, or "{{preference}} {{exchange}}" for an MX record
 	//
 	// required: true
 	Address string `json:"address" db:"address"`
@@ -71,7 +86,7 @@ type StaticDNSEntry struct {
 
 	// The type of the static DNS entry
 	//
-	// enum: ["A_RECORD", "AAAA_RECORD", "CNAME_RECORD"]
+	// enum: ["A_RECORD", "AAAA_RECORD", "CNAME_RECORD", "MX_RECORD", "SRV_RECORD", "TXT_RECORD"]
 	Type string `json:"type"`
 
 	// The type id of the static DNS entry
@@ -84,7 +99,9 @@ type StaticDNSEntry struct {
 // are nullable.
 type StaticDNSEntryNullable struct {
 
-	// The static IP Address or fqdn of the static dns entry
+	// The static IP Address or fqdn of the static dns entry, the text of a
+	// TXT record, "{{priority}} {{weight}} {{port}} {{target}}" for an SRV
+	// record, or "{{preference}} {{exchange}}" for an MX record
 	//
 	// required: true
 	Address *string `json:"address" db:"address"`
@@ -127,7 +144,7 @@ type StaticDNSEntryNullable struct {
 
 	// The type of the static DNS entry
 	//
-	// enum: ["A_RECORD", "AAAA_RECORD", "CNAME_RECORD"]
+	// enum: ["A_RECORD", "AAAA_RECORD", "CNAME_RECORD", "MX_RECORD", "SRV_RECORD", "TXT_RECORD"]
 	Type *string `json:"type"`
 
 	// The type id of the static DNS entry
@@ -135,3 +152,392 @@ type StaticDNSEntryNullable struct {
 	// required: true
 	TypeID int `json:"typeId" db:"type_id"`
 }
+
+// StaticDNSEntryBulkAction is what a bulk Static DNS Entry request does with
+// one of its entries.
+type StaticDNSEntryBulkAction string
+
+// These are the valid StaticDNSEntryBulkActions.
+const (
+	StaticDNSEntryBulkCreate = StaticDNSEntryBulkAction("create")
+	StaticDNSEntryBulkUpdate = StaticDNSEntryBulkAction("update")
+	StaticDNSEntryBulkDelete = StaticDNSEntryBulkAction("delete")
+)
+
+// StaticDNSEntryBulkOperation is one of the changes made by a request to the
+// /staticdnsentries/bulk endpoint.
+type StaticDNSEntryBulkOperation struct {
+	Action StaticDNSEntryBulkAction `json:"action"`
+	// Entry is the Static DNS Entry to create or update, or - for deletions -
+	// whose ID identifies the Static DNS Entry to delete.
+	Entry StaticDNSEntryNullable `json:"entry"`
+}
+
+// StaticDNSEntryBulkResult is the result of one StaticDNSEntryBulkOperation.
+type StaticDNSEntryBulkResult struct {
+	Action StaticDNSEntryBulkAction `json:"action"`
+	// Entry is the Static DNS Entry as it was created or updated, or - for
+	// deletions - only its ID. If the operation wasn't made, it's the entry
+	// given in the request.
+	Entry StaticDNSEntryNullable `json:"entry"`
+	// Alerts describe whether the operation succeeded, and if not, why.
+	Alerts
+}
+
+// StaticDNSEntryBulkResponse is the type of a response from Traffic Ops to a
+// request to its /staticdnsentries/bulk endpoint. The results are in the
+// order of the operations in the request.
+type StaticDNSEntryBulkResponse struct {
+	Response []StaticDNSEntryBulkResult `json:"response"`
+	Alerts
+}
+
+// StaticDNSEntryImportRequest is a request to import the records of a zone
+// file as the Static DNS Entries of a Delivery Service.
+type StaticDNSEntryImportRequest struct {
+	// DeliveryServiceID identifies the Delivery Service to which the
+	// entries are imported.
+	DeliveryServiceID int `json:"deliveryServiceId"`
+	// ZoneFile is the zone file, or fragment of one, in the format of RFC
+	// 1035. Relative names are relative to the Delivery Service's domain.
+	ZoneFile string `json:"zoneFile"`
+	// DryRun is whether to only report the changes the import would make,
+	// without making them.
+	DryRun bool `json:"dryRun"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface.
+func (r StaticDNSEntryImportRequest) Validate(tx *sql.Tx) error {
+	errs := []error{}
+	if r.DeliveryServiceID <= 0 {
+		errs = append(errs, errors.New("deliveryServiceId: required"))
+	}
+	if strings.TrimSpace(r.ZoneFile) == "" {
+		errs = append(errs, errors.New("zoneFile: required"))
+	}
+	return util.JoinErrs(errs)
+}
+
+// StaticDNSEntryImportResult is the result of creating or updating the Static
+// DNS Entry of one of the records of an imported zone file.
+type StaticDNSEntryImportResult struct {
+	// Line is the line of the zone file on which the record starts.
+	Line int `json:"line"`
+	StaticDNSEntryBulkResult
+}
+
+// StaticDNSEntryImportResponse is the type of a response from Traffic Ops to
+// a request to its /staticdnsentries/import endpoint. The results are in the
+// order of the records in the zone file; records that needed no change
+// aren't included.
+type StaticDNSEntryImportResponse struct {
+	Response []StaticDNSEntryImportResult `json:"response"`
+	Alerts
+}
+
+// StaticDNSEntryTTLPolicy is a CDN's bounds on the TTLs of the Static DNS
+// Entries of its Delivery Services. Either bound may be nil, meaning the TTLs
+// are not bounded in that direction.
+type StaticDNSEntryTTLPolicy struct {
+	// CDNName is the name of the CDN to which the policy applies. It's
+	// ignored in requests, which identify the CDN in their path.
+	CDNName string `json:"cdnName"`
+	// MinTTL is the smallest TTL, in seconds, allowed for the Static DNS
+	// Entries.
+	MinTTL *int64 `json:"minTTL"`
+	// MaxTTL is the largest TTL, in seconds, allowed for the Static DNS
+	// Entries.
+	MaxTTL *int64 `json:"maxTTL"`
+	// LastUpdated is the time at which the policy was last changed.
+	LastUpdated *time.Time `json:"lastUpdated,omitempty"`
+}
+
+// StaticDNSEntryTTLPolicyResponse is the type of a response from Traffic Ops
+// to a request for a CDN's Static DNS Entry TTL policy.
+type StaticDNSEntryTTLPolicyResponse struct {
+	Response StaticDNSEntryTTLPolicy `json:"response"`
+	Alerts
+}
+
+// Validate validates that the StaticDNSEntryTTLPolicy's bounds are consistent.
+func (p *StaticDNSEntryTTLPolicy) Validate(tx *sql.Tx) error {
+	errs := []error{}
+	if p.MinTTL != nil && *p.MinTTL < 0 {
+		errs = append(errs, errors.New("minTTL: cannot be negative"))
+	}
+	if p.MaxTTL != nil && *p.MaxTTL < 0 {
+		errs = append(errs, errors.New("maxTTL: cannot be negative"))
+	}
+	if p.MinTTL != nil && p.MaxTTL != nil && *p.MinTTL > *p.MaxTTL {
+		errs = append(errs, errors.New("maxTTL: cannot be less than minTTL"))
+	}
+	return util.JoinErrs(errs)
+}
+
+// Permits returns whether ttl is within the policy's bounds.
+func (p StaticDNSEntryTTLPolicy) Permits(ttl int64) bool {
+	return (p.MinTTL == nil || ttl >= *p.MinTTL) && (p.MaxTTL == nil || ttl <= *p.MaxTTL)
+}
+
+// BoundsString returns a human-readable description of the policy's bounds,
+// for use in error messages.
+func (p StaticDNSEntryTTLPolicy) BoundsString() string {
+	switch {
+	case p.MinTTL != nil && p.MaxTTL != nil:
+		return fmt.Sprintf("between %d and %d seconds", *p.MinTTL, *p.MaxTTL)
+	case p.MinTTL != nil:
+		return fmt.Sprintf("at least %d seconds", *p.MinTTL)
+	case p.MaxTTL != nil:
+		return fmt.Sprintf("at most %d seconds", *p.MaxTTL)
+	}
+	return "any number of seconds"
+}
+
+// StaticDNSEntryZoneRecord is a resource record of a zone file of Static DNS
+// Entries.
+type StaticDNSEntryZoneRecord struct {
+	// Line is the line of the zone file on which the record starts.
+	Line int `json:"line"`
+	// Name is the fully qualified owner name of the record, with a trailing
+	// period.
+	Name  string `json:"name"`
+	TTL   int64  `json:"ttl"`
+	Class string `json:"class"`
+	// Type is the DNS type of the record, e.g. "A" - not the type of the
+	// Static DNS Entry, e.g. "A_RECORD".
+	Type string `json:"type"`
+	// Data is the record's data as it's written in a Static DNS Entry's
+	// address, e.g. the unquoted text of a TXT record.
+	Data string `json:"data"`
+}
+
+// StaticDNSEntryZoneExport is a zone file export of Static DNS Entries, both
+// as the text Traffic Ops returned and as the records parsed from it.
+type StaticDNSEntryZoneExport struct {
+	Text    string
+	Records []StaticDNSEntryZoneRecord
+}
+
+// zoneFileClasses are the classes a record of a zone file may have.
+var zoneFileClasses = map[string]struct{}{"IN": {}, "CS": {}, "CH": {}, "HS": {}}
+
+// ParseZoneFile parses the resource records of a zone file in the format of
+// RFC 1035, which BIND uses. Names are relative to origin - which must be
+// fully qualified, or empty if there is none - until a $ORIGIN directive
+// changes it. Records may omit their owner name, TTL, and class, as RFC 1035
+// allows, and may be split across lines with parentheses. The $INCLUDE
+// directive isn't supported.
+func ParseZoneFile(zone, origin string) ([]StaticDNSEntryZoneRecord, error) {
+	records := []StaticDNSEntryZoneRecord{}
+	// dirTTL is the TTL set by $TTL, and lastTTL that of the last record
+	// with one.
+	var dirTTL, lastTTL *int64
+	owner := ""
+	fields := []string{}
+	startLine := 0
+	ownerOmitted := false
+	depth := 0
+	for i, line := range strings.Split(zone, "\n") {
+		tokens, err := splitZoneFileLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		if depth == 0 {
+			if len(tokens) == 0 {
+				continue
+			}
+			startLine = i + 1
+			ownerOmitted = line[0] == ' ' || line[0] == '\t'
+			fields = fields[:0]
+		}
+		for _, t := range tokens {
+			switch {
+			case !t.quoted && t.text == "(":
+				depth++
+			case !t.quoted && t.text == ")":
+				depth--
+				if depth < 0 {
+					return nil, fmt.Errorf("line %d: unbalanced parentheses", i+1)
+				}
+			default:
+				fields = append(fields, t.text)
+			}
+		}
+		if depth > 0 || len(fields) == 0 {
+			continue
+		}
+
+		if !ownerOmitted && strings.HasPrefix(fields[0], "$") {
+			switch {
+			case strings.EqualFold(fields[0], "$ORIGIN") && len(fields) == 2:
+				if origin, err = zoneFileName(fields[1], origin); err != nil {
+					return nil, fmt.Errorf("line %d: %w", startLine, err)
+				}
+			case strings.EqualFold(fields[0], "$TTL") && len(fields) == 2:
+				ttl, ok := parseZoneFileTTL(fields[1])
+				if !ok {
+					return nil, fmt.Errorf("line %d: invalid TTL '%s'", startLine, fields[1])
+				}
+				dirTTL = &ttl
+			default:
+				return nil, fmt.Errorf("line %d: unsupported directive '%s'", startLine, strings.Join(fields, " "))
+			}
+			continue
+		}
+
+		rec := StaticDNSEntryZoneRecord{Line: startLine}
+		f := 0
+		if !ownerOmitted {
+			if owner, err = zoneFileName(fields[0], origin); err != nil {
+				return nil, fmt.Errorf("line %d: %w", startLine, err)
+			}
+			f++
+		} else if owner == "" {
+			return nil, fmt.Errorf("line %d: the first record must have a name", startLine)
+		}
+		rec.Name = owner
+
+		hasTTL := false
+		for ; f < len(fields) && (!hasTTL || rec.Class == ""); f++ {
+			if ttl, ok := parseZoneFileTTL(fields[f]); ok && !hasTTL {
+				rec.TTL, hasTTL = ttl, true
+			} else if _, ok := zoneFileClasses[strings.ToUpper(fields[f])]; ok && rec.Class == "" {
+				rec.Class = strings.ToUpper(fields[f])
+			} else {
+				break
+			}
+		}
+		if f+1 >= len(fields) {
+			return nil, fmt.Errorf("line %d: expected a type and data", startLine)
+		}
+		if hasTTL {
+			ttl := rec.TTL
+			lastTTL = &ttl
+		} else if dirTTL != nil {
+			rec.TTL = *dirTTL
+		} else if lastTTL != nil {
+			// Without a $TTL, a record's TTL defaults to that of the last
+			// record that has one.
+			rec.TTL = *lastTTL
+		} else {
+			return nil, fmt.Errorf("line %d: no TTL, and no $TTL directive or previous record to take it from", startLine)
+		}
+		if rec.Class == "" {
+			rec.Class = "IN"
+		}
+		rec.Type = strings.ToUpper(fields[f])
+		if rec.Type == "TXT" {
+			// a TXT record's character-strings are parts of the same text
+			rec.Data = strings.Join(fields[f+1:], "")
+		} else {
+			rec.Data = strings.Join(fields[f+1:], " ")
+		}
+		records = append(records, rec)
+	}
+	if depth > 0 {
+		return nil, fmt.Errorf("line %d: unbalanced parentheses", startLine)
+	}
+	return records, nil
+}
+
+// zoneFileName returns the fully qualified domain name of a name in a zone
+// file, which is relative to origin unless it has a trailing period.
+func zoneFileName(name, origin string) (string, error) {
+	switch {
+	case name == "@":
+		if origin == "" {
+			return "", errors.New("'@' used without an $ORIGIN")
+		}
+		return origin, nil
+	case strings.HasSuffix(name, "."):
+		return name, nil
+	case origin == "":
+		return "", fmt.Errorf("relative name '%s' without an $ORIGIN", name)
+	}
+	return name + "." + origin, nil
+}
+
+// zoneFileTTLUnits are the numbers of seconds in each of the units BIND
+// allows in TTLs, e.g. "1h30m".
+var zoneFileTTLUnits = map[rune]int64{'s': 1, 'm': 60, 'h': 60 * 60, 'd': 24 * 60 * 60, 'w': 7 * 24 * 60 * 60}
+
+// parseZoneFileTTL parses a TTL in a zone file, which is a number of seconds,
+// or a duration in the units BIND allows, e.g. "1h30m".
+func parseZoneFileTTL(field string) (int64, bool) {
+	if ttl, err := strconv.ParseInt(field, 10, 32); err == nil {
+		return ttl, ttl >= 0
+	}
+	var ttl, n int64
+	digits := false
+	for _, r := range strings.ToLower(field) {
+		if r >= '0' && r <= '9' {
+			n = n*10 + int64(r-'0')
+			digits = true
+			continue
+		}
+		unit, ok := zoneFileTTLUnits[r]
+		if !ok || !digits {
+			return 0, false
+		}
+		ttl += n * unit
+		n, digits = 0, false
+	}
+	return ttl, !digits && ttl > 0 && ttl <= math.MaxInt32
+}
+
+// zoneFileToken is a field of a line of a zone file, or a parenthesis.
+type zoneFileToken struct {
+	text string
+	// quoted is whether the field was a quoted string, and so can't be a
+	// parenthesis.
+	quoted bool
+}
+
+// splitZoneFileLine splits a line of a zone file into its fields, ignoring
+// any comment. Quoted fields are returned unquoted and unescaped, and
+// parentheses are separate tokens.
+func splitZoneFileLine(line string) ([]zoneFileToken, error) {
+	tokens := []zoneFileToken{}
+	field := strings.Builder{}
+	inField, inQuotes, wasQuoted, escaped := false, false, false, false
+	endField := func() {
+		if inField {
+			tokens = append(tokens, zoneFileToken{text: field.String(), quoted: wasQuoted})
+		}
+		field.Reset()
+		inField, wasQuoted = false, false
+	}
+	for _, r := range line {
+		switch {
+		case escaped:
+			field.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped, inField = true, true
+		case inQuotes && r == '"':
+			inQuotes = false
+		case inQuotes:
+			field.WriteRune(r)
+		case r == '"':
+			inQuotes, inField, wasQuoted = true, true, true
+		case r == ';':
+			endField()
+			return tokens, nil
+		case r == '(' || r == ')':
+			endField()
+			tokens = append(tokens, zoneFileToken{text: string(r)})
+		case r == ' ' || r == '\t' || r == '\r':
+			endField()
+		default:
+			field.WriteRune(r)
+			inField = true
+		}
+	}
+	if inQuotes || escaped {
+		return nil, errors.New("unterminated quoted string")
+	}
+	endField()
+	return tokens, nil
+}
@@ -0,0 +1,125 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestStaticDNSEntryTTLPolicyValidate(t *testing.T) {
+	policy := StaticDNSEntryTTLPolicy{MinTTL: util.Int64Ptr(30), MaxTTL: util.Int64Ptr(3600)}
+	if err := policy.Validate(nil); err != nil {
+		t.Errorf("unexpected error validating policy: %v", err)
+	}
+
+	policy.MaxTTL = util.Int64Ptr(10)
+	if err := policy.Validate(nil); err == nil {
+		t.Error("expected an error validating a policy with minTTL greater than maxTTL")
+	}
+
+	policy = StaticDNSEntryTTLPolicy{MinTTL: util.Int64Ptr(-1)}
+	if err := policy.Validate(nil); err == nil {
+		t.Error("expected an error validating a policy with a negative minTTL")
+	}
+}
+
+func TestStaticDNSEntryTTLPolicyPermits(t *testing.T) {
+	policy := StaticDNSEntryTTLPolicy{MinTTL: util.Int64Ptr(30)}
+	if policy.Permits(0) {
+		t.Error("expected policy with minTTL 30 not to permit TTL 0")
+	}
+	if !policy.Permits(86400) {
+		t.Error("expected policy with no maxTTL to permit TTL 86400")
+	}
+	policy.MaxTTL = util.Int64Ptr(3600)
+	if policy.Permits(86400) {
+		t.Error("expected policy with maxTTL 3600 not to permit TTL 86400")
+	}
+	if !policy.Permits(3600) {
+		t.Error("expected policy with maxTTL 3600 to permit TTL 3600")
+	}
+	if !(StaticDNSEntryTTLPolicy{}).Permits(0) {
+		t.Error("expected empty policy to permit any TTL")
+	}
+}
+
+func TestParseZoneFile(t *testing.T) {
+	zone := `; Static DNS entries of Delivery Service demo1 of CDN cdn1
+$ORIGIN demo1.mycdn.test.
+cname	300	IN	CNAME	target.example.com.
+txt	60	IN	TXT	"say \"hi\"; bye"
+_sip._tcp	60	IN	SRV	10 60 5060 sip.example.com. ; trailing comment
+abs.example.com.	60	IN	A	192.0.2.1
+`
+	expected := []StaticDNSEntryZoneRecord{
+		{Line: 3, Name: "cname.demo1.mycdn.test.", TTL: 300, Class: "IN", Type: "CNAME", Data: "target.example.com."},
+		{Line: 4, Name: "txt.demo1.mycdn.test.", TTL: 60, Class: "IN", Type: "TXT", Data: `say "hi"; bye`},
+		{Line: 5, Name: "_sip._tcp.demo1.mycdn.test.", TTL: 60, Class: "IN", Type: "SRV", Data: "10 60 5060 sip.example.com."},
+		{Line: 6, Name: "abs.example.com.", TTL: 60, Class: "IN", Type: "A", Data: "192.0.2.1"},
+	}
+	actual, err := ParseZoneFile(zone, "")
+	if err != nil {
+		t.Fatalf("unexpected error parsing zone file: %v", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected records %+v, actual: %+v", expected, actual)
+	}
+
+	// BIND's shorthands: omitted names, TTLs, and classes, TTL units, and
+	// records split across lines
+	zone = `$TTL 1h
+www	A	192.0.2.1
+	IN 30 AAAA 2001:db8::1
+@	MX	( 10 ; preference
+	mail.example.com. )
+$ORIGIN sub
+txt	5m	TXT	"a" "b"
+`
+	expected = []StaticDNSEntryZoneRecord{
+		{Line: 2, Name: "www.demo1.mycdn.test.", TTL: 3600, Class: "IN", Type: "A", Data: "192.0.2.1"},
+		{Line: 3, Name: "www.demo1.mycdn.test.", TTL: 30, Class: "IN", Type: "AAAA", Data: "2001:db8::1"},
+		{Line: 4, Name: "demo1.mycdn.test.", TTL: 3600, Class: "IN", Type: "MX", Data: "10 mail.example.com."},
+		{Line: 7, Name: "txt.sub.demo1.mycdn.test.", TTL: 300, Class: "IN", Type: "TXT", Data: "ab"},
+	}
+	actual, err = ParseZoneFile(zone, "demo1.mycdn.test.")
+	if err != nil {
+		t.Fatalf("unexpected error parsing zone file: %v", err)
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected records %+v, actual: %+v", expected, actual)
+	}
+
+	for _, invalid := range []string{
+		"www\t60\tIN\tA\t192.0.2.1\n",
+		"$ORIGIN a.test.\nwww\tsixty\tIN\tA\t192.0.2.1\n",
+		"$ORIGIN a.test.\nwww\tIN\tA\t192.0.2.1\n",
+		"$ORIGIN a.test.\ntxt\t60\tIN\tTXT\t\"unterminated\n",
+		"$ORIGIN a.test.\nmx\t60\tIN\tMX\t( 10\n",
+		"$ORIGIN a.test.\n\t60\tIN\tA\t192.0.2.1\n",
+		"$INCLUDE other.zone\n",
+	} {
+		if _, err := ParseZoneFile(invalid, ""); err == nil {
+			t.Errorf("expected an error parsing %q", invalid)
+		}
+	}
+}
@@ -32,6 +32,18 @@ import (
 
 const dateFormat = "2006-01-02"
 
+// These are the names of the daily per-Delivery Service stats summaries
+// written by Traffic Stats, from which Traffic Ops builds billing reports.
+const (
+	// StatNameDailyDSBytesServed is the name of the stat summarizing the
+	// number of terabytes a Delivery Service served in a day.
+	StatNameDailyDSBytesServed = "daily_ds_bytesserved"
+	// StatNameDailyDS95thGbps is the name of the stat summarizing the 95th
+	// percentile of a Delivery Service's 5-minute average bandwidth in a day,
+	// in gigabits per second.
+	StatNameDailyDS95thGbps = "daily_ds_95thgbps"
+)
+
 // StatsSummaryResponse is the structure of a response from Traffic Ops to
 // GET requests made to its /stats_summary API endpoint.
 type StatsSummaryResponse struct {
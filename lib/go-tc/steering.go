@@ -49,4 +49,11 @@ type SteeringSteeringTarget struct {
 	GeoOrder        *int                `json:"geoOrder,omitempty"`
 	Longitude       *float64            `json:"longitude,omitempty"`
 	Latitude        *float64            `json:"latitude,omitempty"`
+	// GeoLimitCountries, if not empty, limits the Target to clients located
+	// in one of the countries with these ISO 3166-1 alpha-2 codes.
+	GeoLimitCountries []string `json:"geoLimitCountries,omitempty"`
+	// ExternalFQDN is the host name of the external CDN endpoint of a
+	// SteeringExternalTarget, in which case DeliveryService is the name of
+	// the external target rather than the XMLID of a Delivery Service.
+	ExternalFQDN string `json:"externalFqdn,omitempty"`
 }
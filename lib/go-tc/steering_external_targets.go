@@ -0,0 +1,155 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/asaskevich/govalidator"
+)
+
+// MaxSteeringExternalTargets is the maximum number of external targets a
+// single steering Delivery Service may have.
+const MaxSteeringExternalTargets = 100
+
+// steeringExternalTargetNameRe matches valid names of steering external
+// targets.
+var steeringExternalTargetNameRe = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// A SteeringExternalTarget is a target of a CLIENT_STEERING Delivery Service
+// which is an endpoint of a CDN outside of Traffic Control, rather than
+// another Delivery Service. Traffic Monitor polls its health check URL, and
+// Traffic Router only presents it to clients while it's healthy.
+type SteeringExternalTarget struct {
+	// Name uniquely identifies the external target among those of every
+	// steering Delivery Service. It's the name under which Traffic Monitor
+	// reports the target's health.
+	Name string `json:"name"`
+	// FQDN is the host name to which Traffic Router sends clients for the
+	// external target - typically the CNAME target given by the external
+	// CDN.
+	FQDN string `json:"fqdn"`
+	// HealthCheckURL is the absolute HTTP(S) URL which Traffic Monitor
+	// requests to check the health of the external target. Any 2XX
+	// response means the target is healthy.
+	HealthCheckURL string `json:"healthCheckUrl"`
+	// Type is the Name of the steering Type of the target - one of
+	// STEERING_ORDER and STEERING_WEIGHT. It's ignored in requests.
+	Type string `json:"type"`
+	// TypeID is the integral, unique identifier of the steering Type of the
+	// target.
+	TypeID int `json:"typeId"`
+	// Value is the order or weight of the target, according to its Type.
+	Value int `json:"value"`
+}
+
+// Validate returns an error describing every problem with the target that can
+// be found without a database transaction, or nil if there are none.
+func (t SteeringExternalTarget) Validate() error {
+	errs := []error{}
+	if !steeringExternalTargetNameRe.MatchString(t.Name) {
+		errs = append(errs, errors.New("name: must consist of lower-case letters, digits and hyphens, and begin and end with a letter or digit"))
+	}
+	if t.FQDN == "" {
+		errs = append(errs, errors.New("fqdn: cannot be blank"))
+	} else if !govalidator.IsDNSName(t.FQDN) {
+		errs = append(errs, errors.New("fqdn: must be a valid host name"))
+	}
+	if t.HealthCheckURL == "" {
+		errs = append(errs, errors.New("healthCheckUrl: cannot be blank"))
+	} else if u, err := url.Parse(t.HealthCheckURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, errors.New("healthCheckUrl: must be an absolute 'http://' or 'https://' URL"))
+	}
+	if t.Value < 0 {
+		errs = append(errs, errors.New("value: cannot be negative"))
+	}
+	return util.JoinErrs(errs)
+}
+
+// SteeringExternalTargets is the list of external targets of a steering
+// Delivery Service.
+type SteeringExternalTargets struct {
+	// DeliveryServiceID is the integral, unique identifier of the steering
+	// Delivery Service.
+	DeliveryServiceID int `json:"deliveryServiceId"`
+	// XMLID is the XMLID of the steering Delivery Service.
+	XMLID string `json:"xmlId"`
+	// Targets is the external targets of the steering Delivery Service.
+	Targets []SteeringExternalTarget `json:"targets"`
+	// LastUpdated is the time at which the Delivery Service's external
+	// targets were last modified, or nil if it has never had any.
+	LastUpdated *time.Time `json:"lastUpdated"`
+}
+
+// SteeringExternalTargetsRequest is the type of a request to replace the
+// external targets of a steering Delivery Service.
+type SteeringExternalTargetsRequest struct {
+	Targets []SteeringExternalTarget `json:"targets"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface.
+//
+// In addition to validating each target, it ensures that their names are
+// unique and that their Types are STEERING_ORDER or STEERING_WEIGHT.
+func (r *SteeringExternalTargetsRequest) Validate(tx *sql.Tx) error {
+	if r.Targets == nil {
+		return errors.New("targets: cannot be null/missing")
+	}
+	if len(r.Targets) > MaxSteeringExternalTargets {
+		return fmt.Errorf("targets: cannot have more than %d targets", MaxSteeringExternalTargets)
+	}
+	errs := []error{}
+	names := map[string]int{}
+	for i, target := range r.Targets {
+		if err := target.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("targets[%d]: %v", i, err))
+		}
+		if j, ok := names[target.Name]; ok {
+			errs = append(errs, fmt.Errorf("targets[%d]: name: '%s' is also the name of targets[%d]", i, target.Name, j))
+		} else {
+			names[target.Name] = i
+		}
+
+		typeID := target.TypeID
+		typeName, err := ValidateTypeID(tx, &typeID, "steering_target")
+		if err != nil {
+			errs = append(errs, fmt.Errorf("targets[%d]: typeId: %v", i, err))
+		} else if SteeringTypeFromString(typeName) != SteeringTypeOrder && SteeringTypeFromString(typeName) != SteeringTypeWeight {
+			errs = append(errs, fmt.Errorf("targets[%d]: typeId: must identify the %s or %s Type", i, SteeringTypeOrder, SteeringTypeWeight))
+		}
+	}
+	return util.JoinErrs(errs)
+}
+
+// SteeringExternalTargetsResponse is the type of a response from Traffic Ops
+// to a request to its /steering/{{ID}}/external-targets endpoint.
+type SteeringExternalTargetsResponse struct {
+	Response SteeringExternalTargets `json:"response"`
+	Alerts
+}
@@ -0,0 +1,69 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+)
+
+func TestSteeringExternalTargetValidate(t *testing.T) {
+	valid := []SteeringExternalTarget{
+		{Name: "other-cdn", FQDN: "example.other-cdn.net", HealthCheckURL: "https://example.other-cdn.net/health", TypeID: 1, Value: 1},
+		{Name: "cdn2", FQDN: "cdn2.example", HealthCheckURL: "http://cdn2.example:8080/ping?x=1"},
+	}
+	for i, target := range valid {
+		if err := target.Validate(); err != nil {
+			t.Errorf("expected target %d to be valid, got error: %v", i, err)
+		}
+	}
+
+	base := valid[0]
+	invalid := map[string]func(*SteeringExternalTarget){
+		"blank name":         func(t *SteeringExternalTarget) { t.Name = "" },
+		"upper-case name":    func(t *SteeringExternalTarget) { t.Name = "Other" },
+		"trailing hyphen":    func(t *SteeringExternalTarget) { t.Name = "other-" },
+		"blank fqdn":         func(t *SteeringExternalTarget) { t.FQDN = "" },
+		"invalid fqdn":       func(t *SteeringExternalTarget) { t.FQDN = "not a host" },
+		"blank health URL":   func(t *SteeringExternalTarget) { t.HealthCheckURL = "" },
+		"relative URL":       func(t *SteeringExternalTarget) { t.HealthCheckURL = "/health" },
+		"non-HTTP URL":       func(t *SteeringExternalTarget) { t.HealthCheckURL = "ftp://example.other-cdn.net/health" },
+		"negative value":     func(t *SteeringExternalTarget) { t.Value = -1 },
+		"URL without a host": func(t *SteeringExternalTarget) { t.HealthCheckURL = "https:///health" },
+	}
+	for name, modify := range invalid {
+		target := base
+		modify(&target)
+		if err := target.Validate(); err == nil {
+			t.Errorf("expected target with %s to be invalid", name)
+		}
+	}
+}
+
+func TestSteeringExternalTargetsRequestValidateNil(t *testing.T) {
+	req := SteeringExternalTargetsRequest{}
+	if err := req.Validate(nil); err == nil {
+		t.Error("expected request with null targets to be invalid")
+	}
+
+	req.Targets = make([]SteeringExternalTarget, MaxSteeringExternalTargets+1)
+	if err := req.Validate(nil); err == nil {
+		t.Errorf("expected request with more than %d targets to be invalid", MaxSteeringExternalTargets)
+	}
+}
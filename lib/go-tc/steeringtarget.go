@@ -22,11 +22,14 @@ package tc
 import (
 	"database/sql"
 	"errors"
+	"regexp"
 	"strings"
 
 	"github.com/apache/trafficcontrol/lib/go-util"
 )
 
+var countryCodeRegex = regexp.MustCompile(`^[A-Z]{2}$`)
+
 // A SteeringTarget is a relationship between a Steering Delivery Service and
 // another Delivery Service which is one of its Targets.
 //
@@ -53,6 +56,13 @@ type SteeringTargetNullable struct {
 	Type              *string              `json:"type" db:"type_name"` // TODO enum?
 	TypeID            *int                 `json:"typeId" db:"type_id"` // TODO enum?
 	Value             *util.JSONIntStr     `json:"value" db:"value"`
+	// Latitude and Longitude, if set, are the coordinates used to order the
+	// Target relative to clients, overriding those of its primary Origin.
+	Latitude  *float64 `json:"latitude" db:"latitude"`
+	Longitude *float64 `json:"longitude" db:"longitude"`
+	// GeoLimitCountries, if not empty, limits the Target to clients located
+	// in one of the countries with these ISO 3166-1 alpha-2 codes.
+	GeoLimitCountries []string `json:"geoLimitCountries" db:"-"`
 }
 
 // Validate implements the
@@ -70,6 +80,20 @@ func (st SteeringTargetNullable) Validate(tx *sql.Tx) (error, error) {
 	if st.Value == nil {
 		errs = append(errs, "missing value")
 	}
+	if (st.Latitude == nil) != (st.Longitude == nil) {
+		errs = append(errs, "latitude and longitude must be given together")
+	}
+	if st.Latitude != nil && (*st.Latitude < -90 || *st.Latitude > 90) {
+		errs = append(errs, "latitude must be between -90 and 90")
+	}
+	if st.Longitude != nil && (*st.Longitude < -180 || *st.Longitude > 180) {
+		errs = append(errs, "longitude must be between -180 and 180")
+	}
+	for _, cc := range st.GeoLimitCountries {
+		if !countryCodeRegex.MatchString(cc) {
+			errs = append(errs, "geoLimitCountries must contain only two-letter upper-case country codes, got '"+cc+"'")
+		}
+	}
 	if len(errs) > 0 {
 		return errors.New(strings.Join(errs, "; ")), nil
 	}
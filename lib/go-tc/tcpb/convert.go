@@ -0,0 +1,582 @@
+package tcpb
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func str(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func integer(i *int) int64 {
+	if i == nil {
+		return 0
+	}
+	return int64(*i)
+}
+
+func optInteger(i *int) *int64 {
+	if i == nil {
+		return nil
+	}
+	i64 := int64(*i)
+	return &i64
+}
+
+func boolean(b *bool) bool {
+	return b != nil && *b
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func timeNoMod(t *tc.TimeNoMod) *timestamppb.Timestamp {
+	if t == nil || !t.Valid {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
+
+func topologies(ts map[string]tc.CRConfigTopology) map[string]*Topology {
+	if ts == nil {
+		return nil
+	}
+	converted := make(map[string]*Topology, len(ts))
+	for name, t := range ts {
+		converted[name] = &Topology{Nodes: t.Nodes}
+	}
+	return converted
+}
+
+func interfaces(infs []tc.ServerInterfaceInfo) []*ServerInterface {
+	converted := make([]*ServerInterface, 0, len(infs))
+	for _, inf := range infs {
+		ips := make([]*ServerIPAddress, 0, len(inf.IPAddresses))
+		for _, ip := range inf.IPAddresses {
+			ips = append(ips, &ServerIPAddress{
+				Address:        ip.Address,
+				Gateway:        ip.Gateway,
+				ServiceAddress: ip.ServiceAddress,
+			})
+		}
+		converted = append(converted, &ServerInterface{
+			Name:         inf.Name,
+			Mtu:          inf.MTU,
+			MaxBandwidth: inf.MaxBandwidth,
+			Monitor:      inf.Monitor,
+			IpAddresses:  ips,
+		})
+	}
+	return converted
+}
+
+// NewServer converts the given server to its Protocol Buffers representation.
+func NewServer(s tc.ServerV41) *Server {
+	infs := make([]tc.ServerInterfaceInfo, 0, len(s.Interfaces))
+	for _, inf := range s.Interfaces {
+		infs = append(infs, inf.ServerInterfaceInfo)
+	}
+	converted := &Server{
+		Id:                integer(s.ID),
+		HostName:          str(s.HostName),
+		DomainName:        str(s.DomainName),
+		Cachegroup:        str(s.Cachegroup),
+		CachegroupId:      integer(s.CachegroupID),
+		CdnName:           str(s.CDNName),
+		CdnId:             integer(s.CDNID),
+		Type:              s.Type,
+		TypeId:            integer(s.TypeID),
+		Status:            str(s.Status),
+		StatusId:          integer(s.StatusID),
+		ProfileNames:      s.ProfileNames,
+		PhysLocation:      str(s.PhysLocation),
+		PhysLocationId:    integer(s.PhysLocationID),
+		TcpPort:           optInteger(s.TCPPort),
+		HttpsPort:         optInteger(s.HTTPSPort),
+		Rack:              str(s.Rack),
+		OfflineReason:     str(s.OfflineReason),
+		XmppId:            str(s.XMPPID),
+		Guid:              str(s.GUID),
+		IloIpAddress:      str(s.ILOIPAddress),
+		IloIpGateway:      str(s.ILOIPGateway),
+		IloIpNetmask:      str(s.ILOIPNetmask),
+		IloUsername:       str(s.ILOUsername),
+		MgmtIpAddress:     str(s.MgmtIPAddress),
+		MgmtIpGateway:     str(s.MgmtIPGateway),
+		MgmtIpNetmask:     str(s.MgmtIPNetmask),
+		UpdPending:        boolean(s.UpdPending),
+		RevalPending:      boolean(s.RevalPending),
+		Interfaces:        interfaces(infs),
+		Asns:              s.ASNs,
+		LastUpdated:       timeNoMod(s.LastUpdated),
+		StatusLastUpdated: timestamp(s.StatusLastUpdated),
+		ConfigUpdateTime:  timestamp(s.ConfigUpdateTime),
+		ConfigApplyTime:   timestamp(s.ConfigApplyTime),
+		RevalUpdateTime:   timestamp(s.RevalUpdateTime),
+		RevalApplyTime:    timestamp(s.RevalApplyTime),
+	}
+	for i, inf := range s.Interfaces {
+		converted.Interfaces[i].RouterHostName = inf.RouterHostName
+		converted.Interfaces[i].RouterPortName = inf.RouterPortName
+	}
+	return converted
+}
+
+// NewDeliveryService converts the given Delivery Service to its Protocol
+// Buffers representation.
+func NewDeliveryService(ds tc.DeliveryServiceV4) *DeliveryService {
+	converted := &DeliveryService{
+		Id:                        integer(ds.ID),
+		XmlId:                     str(ds.XMLID),
+		DisplayName:               str(ds.DisplayName),
+		Active:                    boolean(ds.Active),
+		AnonymousBlockingEnabled:  boolean(ds.AnonymousBlockingEnabled),
+		CcrDnsTtl:                 optInteger(ds.CCRDNSTTL),
+		CdnId:                     integer(ds.CDNID),
+		CdnName:                   str(ds.CDNName),
+		CheckPath:                 str(ds.CheckPath),
+		DnsBypassCname:            str(ds.DNSBypassCNAME),
+		DnsBypassIp:               str(ds.DNSBypassIP),
+		DnsBypassIp6:              str(ds.DNSBypassIP6),
+		DnsBypassTtl:              optInteger(ds.DNSBypassTTL),
+		Dscp:                      integer(ds.DSCP),
+		EdgeHeaderRewrite:         str(ds.EdgeHeaderRewrite),
+		ExampleUrls:               ds.ExampleURLs,
+		GeoLimit:                  integer(ds.GeoLimit),
+		GeoLimitCountries:         ds.GeoLimitCountries,
+		GeoLimitRedirectUrl:       str(ds.GeoLimitRedirectURL),
+		GeoProvider:               integer(ds.GeoProvider),
+		GlobalMaxMbps:             optInteger(ds.GlobalMaxMBPS),
+		GlobalMaxTps:              optInteger(ds.GlobalMaxTPS),
+		HttpBypassFqdn:            str(ds.HTTPBypassFQDN),
+		InfoUrl:                   str(ds.InfoURL),
+		InitialDispersion:         optInteger(ds.InitialDispersion),
+		Ipv6RoutingEnabled:        boolean(ds.IPV6RoutingEnabled),
+		LastUpdated:               timeNoMod(ds.LastUpdated),
+		LogsEnabled:               boolean(ds.LogsEnabled),
+		LongDesc:                  str(ds.LongDesc),
+		MaxDnsAnswers:             optInteger(ds.MaxDNSAnswers),
+		MidHeaderRewrite:          str(ds.MidHeaderRewrite),
+		MissLat:                   ds.MissLat,
+		MissLong:                  ds.MissLong,
+		MultiSiteOrigin:           boolean(ds.MultiSiteOrigin),
+		OriginShield:              str(ds.OriginShield),
+		OrgServerFqdn:             str(ds.OrgServerFQDN),
+		ProfileDescription:        str(ds.ProfileDesc),
+		ProfileId:                 optInteger(ds.ProfileID),
+		ProfileName:               str(ds.ProfileName),
+		Protocol:                  optInteger(ds.Protocol),
+		QstringIgnore:             optInteger(ds.QStringIgnore),
+		RangeRequestHandling:      optInteger(ds.RangeRequestHandling),
+		RegexRemap:                str(ds.RegexRemap),
+		RegionalGeoBlocking:       boolean(ds.RegionalGeoBlocking),
+		RemapText:                 str(ds.RemapText),
+		RoutingName:               str(ds.RoutingName),
+		Signed:                    ds.Signed,
+		SslKeyVersion:             optInteger(ds.SSLKeyVersion),
+		TenantId:                  integer(ds.TenantID),
+		TypeId:                    integer(ds.TypeID),
+		FqPacingRate:              optInteger(ds.FQPacingRate),
+		SigningAlgorithm:          str(ds.SigningAlgorithm),
+		Tenant:                    str(ds.Tenant),
+		TrResponseHeaders:         str(ds.TRResponseHeaders),
+		TrRequestHeaders:          str(ds.TRRequestHeaders),
+		ConsistentHashRegex:       str(ds.ConsistentHashRegex),
+		ConsistentHashQueryParams: ds.ConsistentHashQueryParams,
+		MaxOriginConnections:      optInteger(ds.MaxOriginConnections),
+		EcsEnabled:                ds.EcsEnabled,
+		RangeSliceBlockSize:       optInteger(ds.RangeSliceBlockSize),
+		FirstHeaderRewrite:        str(ds.FirstHeaderRewrite),
+		InnerHeaderRewrite:        str(ds.InnerHeaderRewrite),
+		LastHeaderRewrite:         str(ds.LastHeaderRewrite),
+		ServiceCategory:           str(ds.ServiceCategory),
+		Topology:                  str(ds.Topology),
+		MaxRequestHeaderBytes:     optInteger(ds.MaxRequestHeaderBytes),
+		TlsVersions:               ds.TLSVersions,
+		ActiveAt:                  timestamp(ds.ActiveAt),
+		InactiveAt:                timestamp(ds.InactiveAt),
+		ConsistentHashIncludePath: ds.ConsistentHashIncludePath,
+		ConsistentHashHeaders:     ds.ConsistentHashHeaders,
+		ConsistentHashReplicas:    optInteger(ds.ConsistentHashReplicas),
+		OriginTlsVerify:           ds.OriginTLSVerify,
+		OriginTlsPinnedSpkiHashes: ds.OriginTLSPinnedSPKIHashes,
+		OriginTlsCaBundle:         str(ds.OriginTLSCABundle),
+		RoutingInterfaces:         ds.RoutingInterfaces,
+		CompressGzip:              ds.CompressGzip,
+		CompressBrotli:            ds.CompressBrotli,
+		CompressContentTypes:      ds.CompressContentTypes,
+		CompressMinSize:           optInteger(ds.CompressMinSize),
+		TraceHeaderName:           str(ds.TraceHeaderName),
+		Websocket:                 ds.WebSocket,
+		Grpc:                      ds.GRPC,
+		StreamIdleTimeout:         optInteger(ds.StreamIdleTimeout),
+		StreamActiveTimeout:       optInteger(ds.StreamActiveTimeout),
+	}
+	if ds.Type != nil {
+		converted.Type = ds.Type.String()
+	}
+	if ds.DeepCachingType != nil {
+		converted.DeepCachingType = ds.DeepCachingType.String()
+	}
+	if ds.TraceHeaderPolicy != nil {
+		converted.TraceHeaderPolicy = string(*ds.TraceHeaderPolicy)
+	}
+	if ds.MatchList != nil {
+		for _, m := range *ds.MatchList {
+			converted.MatchList = append(converted.MatchList, &DeliveryServiceMatch{
+				Type:      m.Type.String(),
+				SetNumber: int64(m.SetNumber),
+				Pattern:   m.Pattern,
+			})
+		}
+	}
+	return converted
+}
+
+// NewCRConfig converts the given CDN Snapshot to its Protocol Buffers
+// representation.
+func NewCRConfig(crc tc.CRConfig) (*CRConfig, error) {
+	config, err := structpb.NewStruct(crc.Config)
+	if err != nil {
+		return nil, err
+	}
+	converted := &CRConfig{
+		Config:           config,
+		ContentServers:   make(map[string]*SnapshotContentServer, len(crc.ContentServers)),
+		ContentRouters:   make(map[string]*SnapshotContentRouter, len(crc.ContentRouters)),
+		DeliveryServices: make(map[string]*SnapshotDeliveryService, len(crc.DeliveryServices)),
+		EdgeLocations:    make(map[string]*SnapshotLocation, len(crc.EdgeLocations)),
+		RouterLocations:  make(map[string]*SnapshotLocation, len(crc.RouterLocations)),
+		Monitors:         make(map[string]*SnapshotMonitor, len(crc.Monitors)),
+		Stats: &SnapshotStats{
+			CdnName:   str(crc.Stats.CDNName),
+			Date:      crc.Stats.DateUnixSeconds,
+			TmHost:    str(crc.Stats.TMHost),
+			TmPath:    str(crc.Stats.TMPath),
+			TmUser:    str(crc.Stats.TMUser),
+			TmVersion: str(crc.Stats.TMVersion),
+		},
+		Topologies: topologies(crc.Topologies),
+	}
+	for name, s := range crc.ContentServers {
+		server := &SnapshotContentServer{
+			CacheGroup:      str(s.CacheGroup),
+			Capabilities:    s.Capabilities,
+			Fqdn:            str(s.Fqdn),
+			HashCount:       optInteger(s.HashCount),
+			HashId:          str(s.HashId),
+			HttpsPort:       optInteger(s.HttpsPort),
+			InterfaceName:   str(s.InterfaceName),
+			Ip:              str(s.Ip),
+			Ip6:             str(s.Ip6),
+			LocationId:      str(s.LocationId),
+			Port:            optInteger(s.Port),
+			Profile:         str(s.Profile),
+			Type:            str(s.ServerType),
+			RoutingDisabled: s.RoutingDisabled,
+		}
+		if s.ServerStatus != nil {
+			server.Status = string(*s.ServerStatus)
+		}
+		if s.DeliveryServices != nil {
+			server.DeliveryServices = make(map[string]*StringList, len(s.DeliveryServices))
+			for xmlID, names := range s.DeliveryServices {
+				server.DeliveryServices[xmlID] = &StringList{Values: names}
+			}
+		}
+		if s.DeliveryServiceAddresses != nil {
+			server.DeliveryServiceAddresses = make(map[string]*SnapshotServerAddresses, len(s.DeliveryServiceAddresses))
+			for xmlID, addrs := range s.DeliveryServiceAddresses {
+				server.DeliveryServiceAddresses[xmlID] = &SnapshotServerAddresses{Ip: addrs.IP, Ip6: addrs.IP6}
+			}
+		}
+		converted.ContentServers[name] = server
+	}
+	for name, r := range crc.ContentRouters {
+		router := &SnapshotContentRouter{
+			ApiPort:       str(r.APIPort),
+			Fqdn:          str(r.FQDN),
+			HttpsPort:     optInteger(r.HTTPSPort),
+			HashCount:     optInteger(r.HashCount),
+			Ip:            str(r.IP),
+			Ip6:           str(r.IP6),
+			Location:      str(r.Location),
+			Port:          optInteger(r.Port),
+			Profile:       str(r.Profile),
+			SecureApiPort: str(r.SecureAPIPort),
+		}
+		if r.ServerStatus != nil {
+			router.Status = string(*r.ServerStatus)
+		}
+		converted.ContentRouters[name] = router
+	}
+	for xmlID, ds := range crc.DeliveryServices {
+		converted.DeliveryServices[xmlID] = newSnapshotDeliveryService(ds)
+	}
+	for name, l := range crc.EdgeLocations {
+		converted.EdgeLocations[name] = newSnapshotLocation(l)
+	}
+	for name, l := range crc.RouterLocations {
+		converted.RouterLocations[name] = newSnapshotLocation(l)
+	}
+	for name, m := range crc.Monitors {
+		monitor := &SnapshotMonitor{
+			Fqdn:      str(m.FQDN),
+			HttpsPort: optInteger(m.HTTPSPort),
+			Ip:        str(m.IP),
+			Ip6:       str(m.IP6),
+			Location:  str(m.Location),
+			Port:      optInteger(m.Port),
+			Profile:   str(m.Profile),
+		}
+		if m.ServerStatus != nil {
+			monitor.Status = string(*m.ServerStatus)
+		}
+		converted.Monitors[name] = monitor
+	}
+	return converted, nil
+}
+
+func newSnapshotLocation(l tc.CRConfigLatitudeLongitude) *SnapshotLocation {
+	methods := make([]string, 0, len(l.LocalizationMethods))
+	for _, m := range l.LocalizationMethods {
+		methods = append(methods, string(m))
+	}
+	return &SnapshotLocation{
+		Latitude:  l.Lat,
+		Longitude: l.Lon,
+		BackupLocations: &SnapshotBackupLocations{
+			FallbackToClosest: l.BackupLocations.FallbackToClosest,
+			List:              l.BackupLocations.List,
+		},
+		LocalizationMethods: methods,
+	}
+}
+
+func newSnapshotTTLs(ttls *tc.CRConfigTTL) *SnapshotTTLs {
+	if ttls == nil {
+		return nil
+	}
+	return &SnapshotTTLs{
+		A:      str(ttls.ASeconds),
+		Aaaa:   str(ttls.AAAASeconds),
+		Dnskey: str(ttls.DNSkeySeconds),
+		Ds:     str(ttls.DSSeconds),
+		Ns:     str(ttls.NSSeconds),
+		Soa:    str(ttls.SOASeconds),
+	}
+}
+
+func newSnapshotDeliveryService(ds tc.CRConfigDeliveryService) *SnapshotDeliveryService {
+	converted := &SnapshotDeliveryService{
+		ActiveAt:                  ds.ActiveAt,
+		AnonymousBlockingEnabled:  str(ds.AnonymousBlockingEnabled),
+		ConsistentHashHeaders:     ds.ConsistentHashHeaders,
+		ConsistentHashIncludePath: ds.ConsistentHashIncludePath,
+		ConsistentHashQueryParams: ds.ConsistentHashQueryParams,
+		ConsistentHashRegex:       str(ds.ConsistentHashRegex),
+		ConsistentHashReplicas:    optInteger(ds.ConsistentHashReplicas),
+		CoverageZoneOnly:          ds.CoverageZoneOnly,
+		Domains:                   ds.Domains,
+		EcsEnabled:                ds.EcsEnabled,
+		GeoLimitRedirectUrl:       str(ds.GeoLimitRedirectURL),
+		GeolocationProvider:       str(ds.GeoLocationProvider),
+		InactiveAt:                ds.InactiveAt,
+		Ip6RoutingEnabled:         ds.IP6RoutingEnabled,
+		MaxDnsIpsForLocation:      optInteger(ds.MaxDNSIPsForLocation),
+		RegionalGeoBlocking:       str(ds.RegionalGeoBlocking),
+		RequestHeaders:            ds.RequestHeaders,
+		RequiredCapabilities:      ds.RequiredCapabilities,
+		ResponseHeaders:           ds.ResponseHeaders,
+		RoutingName:               str(ds.RoutingName),
+		SslEnabled:                ds.SSLEnabled,
+		Topology:                  str(ds.Topology),
+		Ttl:                       optInteger(ds.TTL),
+		Ttls:                      newSnapshotTTLs(ds.TTLs),
+	}
+	if ds.BypassDestination != nil {
+		converted.BypassDestination = make(map[string]*SnapshotBypassDestination, len(ds.BypassDestination))
+		for protocol, d := range ds.BypassDestination {
+			if d == nil {
+				continue
+			}
+			converted.BypassDestination[protocol] = &SnapshotBypassDestination{
+				Ip:    str(d.IP),
+				Ip6:   str(d.IP6),
+				Cname: str(d.CName),
+				Ttl:   optInteger(d.TTL),
+				Fqdn:  str(d.FQDN),
+				Port:  str(d.Port),
+			}
+		}
+	}
+	if ds.DeepCachingType != nil {
+		converted.DeepCachingType = ds.DeepCachingType.String()
+	}
+	if ds.Dispersion != nil {
+		converted.Dispersion = &SnapshotDispersion{Limit: int64(ds.Dispersion.Limit), Shuffled: ds.Dispersion.Shuffled}
+	}
+	for _, g := range ds.GeoEnabled {
+		converted.GeoEnabled = append(converted.GeoEnabled, g.CountryCode)
+	}
+	for _, ms := range ds.MatchSets {
+		if ms == nil {
+			continue
+		}
+		set := &SnapshotMatchSet{Protocol: ms.Protocol, MatchList: make([]*SnapshotMatch, 0, len(ms.MatchList))}
+		for _, m := range ms.MatchList {
+			set.MatchList = append(set.MatchList, &SnapshotMatch{Regex: m.Regex, MatchType: m.MatchType})
+		}
+		converted.MatchSets = append(converted.MatchSets, set)
+	}
+	if ds.MissLocation != nil {
+		converted.MissLocation = &Coordinates{Latitude: ds.MissLocation.Lat, Longitude: ds.MissLocation.Lon}
+	}
+	if ds.Protocol != nil {
+		converted.Protocol = &SnapshotProtocol{
+			AcceptHttp:      ds.Protocol.AcceptHTTP,
+			AcceptHttps:     ds.Protocol.AcceptHTTPS,
+			RedirectToHttps: ds.Protocol.RedirectOnHTTPS,
+		}
+	}
+	if ds.Soa != nil {
+		converted.Soa = &SnapshotSOA{
+			Admin:   str(ds.Soa.Admin),
+			Expire:  str(ds.Soa.ExpireSeconds),
+			Minimum: str(ds.Soa.MinimumSeconds),
+			Refresh: str(ds.Soa.RefreshSeconds),
+			Retry:   str(ds.Soa.RetrySeconds),
+			Serial:  str(ds.Soa.Serial),
+		}
+	}
+	for _, e := range ds.StaticDNSEntries {
+		converted.StaticDnsEntries = append(converted.StaticDnsEntries, &SnapshotStaticDNSEntry{
+			Name:  e.Name,
+			Ttl:   int64(e.TTL),
+			Type:  e.Type,
+			Value: e.Value,
+		})
+	}
+	return converted
+}
+
+// NewTrafficMonitorConfig converts the given monitoring configuration snapshot
+// to its Protocol Buffers representation.
+func NewTrafficMonitorConfig(tmc tc.TrafficMonitorConfig) (*TrafficMonitorConfig, error) {
+	config, err := structpb.NewStruct(tmc.Config)
+	if err != nil {
+		return nil, err
+	}
+	converted := &TrafficMonitorConfig{
+		TrafficServers:   make([]*TrafficServer, 0, len(tmc.TrafficServers)),
+		CacheGroups:      make([]*MonitoredCacheGroup, 0, len(tmc.CacheGroups)),
+		Config:           config,
+		TrafficMonitors:  make([]*TrafficMonitor, 0, len(tmc.TrafficMonitors)),
+		DeliveryServices: make([]*MonitoredDeliveryService, 0, len(tmc.DeliveryServices)),
+		Profiles:         make([]*MonitoringProfile, 0, len(tmc.Profiles)),
+		Topologies:       topologies(tmc.Topologies),
+		ExternalTargets:  make([]*ExternalTarget, 0, len(tmc.ExternalTargets)),
+	}
+	for _, ts := range tmc.TrafficServers {
+		dses := make([]string, 0, len(ts.DeliveryServices))
+		for _, ds := range ts.DeliveryServices {
+			dses = append(dses, ds.XmlId)
+		}
+		converted.TrafficServers = append(converted.TrafficServers, &TrafficServer{
+			Cachegroup:       ts.CacheGroup,
+			DeliveryServices: dses,
+			Fqdn:             ts.FQDN,
+			HashId:           ts.HashID,
+			HostName:         ts.HostName,
+			HttpsPort:        int64(ts.HTTPSPort),
+			Interfaces:       interfaces(ts.Interfaces),
+			Port:             int64(ts.Port),
+			Profile:          ts.Profile,
+			Status:           ts.ServerStatus,
+			Type:             ts.Type,
+		})
+	}
+	for _, cg := range tmc.CacheGroups {
+		converted.CacheGroups = append(converted.CacheGroups, &MonitoredCacheGroup{
+			Name:        cg.Name,
+			Coordinates: &Coordinates{Latitude: cg.Coordinates.Latitude, Longitude: cg.Coordinates.Longitude},
+		})
+	}
+	for _, tm := range tmc.TrafficMonitors {
+		converted.TrafficMonitors = append(converted.TrafficMonitors, &TrafficMonitor{
+			Port:       int64(tm.Port),
+			Ip6:        tm.IP6,
+			Ip:         tm.IP,
+			HostName:   tm.HostName,
+			Fqdn:       tm.FQDN,
+			Profile:    tm.Profile,
+			Cachegroup: tm.Location,
+			Status:     tm.ServerStatus,
+		})
+	}
+	for _, ds := range tmc.DeliveryServices {
+		converted.DeliveryServices = append(converted.DeliveryServices, &MonitoredDeliveryService{
+			XmlId:              ds.XMLID,
+			TotalTpsThreshold:  ds.TotalTPSThreshold,
+			Status:             ds.ServerStatus,
+			TotalKbpsThreshold: ds.TotalKbpsThreshold,
+			Topology:           ds.Topology,
+			Type:               ds.Type,
+			HostRegexes:        ds.HostRegexes,
+		})
+	}
+	for _, p := range tmc.Profiles {
+		params := &MonitoringParameters{
+			HealthConnectionTimeout:   int64(p.Parameters.HealthConnectionTimeout),
+			HealthPollingUrl:          p.Parameters.HealthPollingURL,
+			HealthPollingFormat:       p.Parameters.HealthPollingFormat,
+			HealthPollingType:         p.Parameters.HealthPollingType,
+			HistoryCount:              int64(p.Parameters.HistoryCount),
+			MinFreeKbps:               p.Parameters.MinFreeKbps,
+			HealthAlgorithm:           p.Parameters.HealthAlgorithm,
+			HealthAlgorithmParameters: p.Parameters.HealthAlgorithmParameters,
+		}
+		if p.Parameters.Thresholds != nil {
+			params.Thresholds = make(map[string]*HealthThreshold, len(p.Parameters.Thresholds))
+			for name, t := range p.Parameters.Thresholds {
+				params.Thresholds[name] = &HealthThreshold{Value: t.Val, Comparator: t.Comparator}
+			}
+		}
+		converted.Profiles = append(converted.Profiles, &MonitoringProfile{Name: p.Name, Type: p.Type, Parameters: params})
+	}
+	for _, t := range tmc.ExternalTargets {
+		converted.ExternalTargets = append(converted.ExternalTargets, &ExternalTarget{Name: t.Name, HealthCheckUrl: t.HealthCheckURL})
+	}
+	return converted, nil
+}
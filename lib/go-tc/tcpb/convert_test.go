@@ -0,0 +1,108 @@
+package tcpb
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewServer(t *testing.T) {
+	now := time.Now()
+	srv := tc.ServerV41{}
+	srv.ID = util.IntPtr(1)
+	srv.HostName = util.StrPtr("edge")
+	srv.CDNName = util.StrPtr("cdn")
+	srv.TCPPort = util.IntPtr(80)
+	srv.StatusLastUpdated = &now
+	srv.Interfaces = []tc.ServerInterfaceInfoV40{{
+		ServerInterfaceInfo: tc.ServerInterfaceInfo{
+			Name:        "eth0",
+			Monitor:     true,
+			IPAddresses: []tc.ServerIPAddress{{Address: "192.0.2.1/24", ServiceAddress: true}},
+		},
+		RouterHostName: "router",
+	}}
+
+	converted := NewServer(srv)
+	if converted.Id != 1 || converted.HostName != "edge" || converted.CdnName != "cdn" {
+		t.Errorf("expected identifying fields to be converted, got %+v", converted)
+	}
+	if converted.TcpPort == nil || *converted.TcpPort != 80 || converted.HttpsPort != nil {
+		t.Errorf("expected only the set port to be converted, got TCP %v, HTTPS %v", converted.TcpPort, converted.HttpsPort)
+	}
+	if !converted.StatusLastUpdated.AsTime().Equal(now) || converted.LastUpdated != nil {
+		t.Errorf("expected only the set times to be converted, got %v, %v", converted.StatusLastUpdated, converted.LastUpdated)
+	}
+	if len(converted.Interfaces) != 1 || converted.Interfaces[0].RouterHostName != "router" || len(converted.Interfaces[0].IpAddresses) != 1 || !converted.Interfaces[0].IpAddresses[0].ServiceAddress {
+		t.Errorf("expected the interface to be converted, got %+v", converted.Interfaces)
+	}
+
+	if _, err := proto.Marshal(converted); err != nil {
+		t.Errorf("marshalling converted server: %v", err)
+	}
+}
+
+func TestNewCRConfig(t *testing.T) {
+	crc := tc.CRConfig{}
+	if err := json.Unmarshal([]byte(`{
+		"config": {"domain_name": "cdn.test", "soa": {"admin": "admin", "minimum": "30"}},
+		"contentServers": {"edge": {"cacheGroup": "cg", "fqdn": "edge.cdn.test", "deliveryServices": {"ds": ["edge.ds.cdn.test"]}}},
+		"stats": {"CDN_name": "cdn", "date": 1000}
+	}`), &crc); err != nil {
+		t.Fatalf("unmarshalling snapshot: %v", err)
+	}
+
+	converted, err := NewCRConfig(crc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if converted.Config.Fields["domain_name"].GetStringValue() != "cdn.test" {
+		t.Errorf("expected domain_name to be converted, got %v", converted.Config.Fields["domain_name"])
+	}
+	if soa := converted.Config.Fields["soa"].GetStructValue(); soa == nil || soa.Fields["minimum"].GetStringValue() != "30" {
+		t.Errorf("expected nested soa to be converted, got %v", converted.Config.Fields["soa"])
+	}
+	edge, ok := converted.ContentServers["edge"]
+	if !ok || edge.Fqdn != "edge.cdn.test" || edge.CacheGroup != "cg" {
+		t.Errorf("expected content server to be converted, got %+v", converted.ContentServers)
+	}
+	if converted.Stats.CdnName != "cdn" || converted.Stats.Date == nil || *converted.Stats.Date != 1000 {
+		t.Errorf("expected stats to be converted, got %+v", converted.Stats)
+	}
+
+	bts, err := proto.Marshal(converted)
+	if err != nil {
+		t.Fatalf("marshalling converted snapshot: %v", err)
+	}
+	roundTripped := &CRConfig{}
+	if err := proto.Unmarshal(bts, roundTripped); err != nil {
+		t.Fatalf("unmarshalling converted snapshot: %v", err)
+	}
+	if !proto.Equal(converted, roundTripped) {
+		t.Error("expected the converted snapshot to survive a round trip")
+	}
+}
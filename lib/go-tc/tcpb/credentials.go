@@ -0,0 +1,50 @@
+package tcpb
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// CookieCredentials are the per-RPC credentials with which clients
+// authenticate calls to the Traffic Ops gRPC service with the cookies of a
+// Traffic Ops session, such as those set by a successful login to the REST
+// API. They implement google.golang.org/grpc/credentials.PerRPCCredentials.
+type CookieCredentials struct {
+	Cookies []*http.Cookie
+}
+
+// GetRequestMetadata returns the "cookie" metadata of calls authenticated by
+// the credentials.
+func (c CookieCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	cookies := make([]string, 0, len(c.Cookies))
+	for _, cookie := range c.Cookies {
+		cookies = append(cookies, cookie.Name+"="+cookie.Value)
+	}
+	return map[string]string{"cookie": strings.Join(cookies, "; ")}, nil
+}
+
+// RequireTransportSecurity returns true, because session cookies must never
+// be sent in the clear.
+func (c CookieCredentials) RequireTransportSecurity() bool {
+	return true
+}
@@ -0,0 +1,30 @@
+// Package tcpb contains the Protocol Buffers representations of the Traffic
+// Control objects served by the Traffic Ops gRPC service - which is defined in
+// trafficops.proto - along with the generated client and server of that
+// service, and the functions that convert the lib/go-tc representations of
+// those objects to them.
+//
+// Regenerating the Go code requires protoc, protoc-gen-go, and
+// protoc-gen-go-grpc.
+package tcpb
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+//go:generate protoc --go_out=paths=source_relative:. --go-grpc_out=paths=source_relative:. trafficops.proto
@@ -0,0 +1,238 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// TenantOnboardingTemplate is the site-configured template from which new
+// Tenants are onboarded, along with their quotas, starter Delivery Services,
+// and invited users.
+type TenantOnboardingTemplate struct {
+	// Parent is the name of the Tenant under which onboarded Tenants are
+	// created.
+	Parent string `json:"parent"`
+	// DefaultRole is the name of the Role with which users are invited,
+	// unless their invitations name one of Roles.
+	DefaultRole string `json:"defaultRole"`
+	// Roles are the names of the other Roles with which users may be
+	// invited.
+	Roles []string `json:"roles"`
+	// Quota is the quota of onboarded Tenants. If it's nil, they have none.
+	Quota *TenantQuotaRequest `json:"quota"`
+	// DeliveryServices are the starter Delivery Services created in
+	// onboarded Tenants, in the same format as requests to create Delivery
+	// Services in version 4 of the API. Their string properties are Go
+	// text/template templates, expanded with a TenantOnboardingTemplateData.
+	// Their Tenants are always the onboarded Tenants.
+	DeliveryServices []json.RawMessage `json:"deliveryServices"`
+}
+
+// TenantOnboardingTemplateData is the data with which the string properties
+// of the Delivery Services of a TenantOnboardingTemplate are expanded, e.g.
+// "{{.Tenant}}-video" or "https://{{.Variables.origin}}".
+type TenantOnboardingTemplateData struct {
+	// Tenant is the name of the onboarded Tenant.
+	Tenant string
+	// Variables are the variables of the onboarding request. Referring to one
+	// the request doesn't have is an error.
+	Variables map[string]string
+}
+
+// Validate returns an error if the template isn't valid. It doesn't check
+// that the Tenant and Roles it names exist.
+func (t TenantOnboardingTemplate) Validate() error {
+	errs := []error{}
+	if strings.TrimSpace(t.Parent) == "" {
+		errs = append(errs, errors.New("parent: cannot be blank"))
+	}
+	if strings.TrimSpace(t.DefaultRole) == "" {
+		errs = append(errs, errors.New("defaultRole: cannot be blank"))
+	}
+	if t.Quota != nil {
+		if err := t.Quota.Validate(nil); err != nil {
+			errs = append(errs, fmt.Errorf("quota: %w", err))
+		} else if t.Quota.MaxDeliveryServices != nil && len(t.DeliveryServices) > *t.Quota.MaxDeliveryServices {
+			errs = append(errs, fmt.Errorf("deliveryServices: the %d Delivery Services exceed the quota of %d", len(t.DeliveryServices), *t.Quota.MaxDeliveryServices))
+		}
+	}
+	for i, raw := range t.DeliveryServices {
+		var ds DeliveryServiceV4
+		if err := json.Unmarshal(raw, &ds); err != nil {
+			errs = append(errs, fmt.Errorf("deliveryServices: Delivery Service %d: %w", i+1, err))
+			continue
+		}
+		if _, err := expandTemplates(raw, func(s string) (string, error) {
+			_, err := parseOnboardingTemplate(s)
+			return s, err
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("deliveryServices: Delivery Service %d: %w", i+1, err))
+		}
+	}
+	return util.JoinErrs(errs)
+}
+
+// RoleAllowed returns whether users may be invited to onboarded Tenants with
+// the Role of the given name.
+func (t TenantOnboardingTemplate) RoleAllowed(role string) bool {
+	return role == t.DefaultRole || util.ContainsStr(t.Roles, role)
+}
+
+// RenderDeliveryServices returns the starter Delivery Services of the
+// template, with their string properties expanded with the given data.
+func (t TenantOnboardingTemplate) RenderDeliveryServices(data TenantOnboardingTemplateData) ([]DeliveryServiceV4, error) {
+	dses := make([]DeliveryServiceV4, 0, len(t.DeliveryServices))
+	for i, raw := range t.DeliveryServices {
+		expanded, err := expandTemplates(raw, func(s string) (string, error) {
+			tmpl, err := parseOnboardingTemplate(s)
+			if err != nil {
+				return "", err
+			}
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, data); err != nil {
+				return "", err
+			}
+			return buf.String(), nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Delivery Service %d: %w", i+1, err)
+		}
+		var ds DeliveryServiceV4
+		if err := json.Unmarshal(expanded, &ds); err != nil {
+			return nil, fmt.Errorf("Delivery Service %d: %w", i+1, err)
+		}
+		dses = append(dses, ds)
+	}
+	return dses, nil
+}
+
+func parseOnboardingTemplate(s string) (*template.Template, error) {
+	return template.New("").Option("missingkey=error").Parse(s)
+}
+
+// expandTemplates returns the given JSON with expand applied to every string
+// within it - but not to the names of properties - so that the expanded
+// strings can't change its structure.
+func expandTemplates(raw json.RawMessage, expand func(string) (string, error)) (json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	var walk func(interface{}) (interface{}, error)
+	walk = func(v interface{}) (interface{}, error) {
+		switch v := v.(type) {
+		case string:
+			return expand(v)
+		case []interface{}:
+			for i, e := range v {
+				expanded, err := walk(e)
+				if err != nil {
+					return nil, err
+				}
+				v[i] = expanded
+			}
+		case map[string]interface{}:
+			for k, e := range v {
+				expanded, err := walk(e)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", k, err)
+				}
+				v[k] = expanded
+			}
+		}
+		return v, nil
+	}
+	expanded, err := walk(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(expanded)
+}
+
+// TenantOnboardingInvitation is an invitation of a user to an onboarded
+// Tenant, which is sent to them as a registration email.
+type TenantOnboardingInvitation struct {
+	Email rfc.EmailAddress `json:"email"`
+	// Role is the name of the Role the user is given. If it's empty in a
+	// request, the template's default Role is given.
+	Role string `json:"role"`
+}
+
+// TenantOnboardingRequest is the type of a request to onboard a Tenant.
+type TenantOnboardingRequest struct {
+	// Name is the name of the new Tenant.
+	Name string `json:"name"`
+	// Variables are the variables with which the template's Delivery
+	// Services are expanded.
+	Variables map[string]string `json:"variables"`
+	// Invitations are the users invited to the new Tenant.
+	Invitations []TenantOnboardingInvitation `json:"invitations"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface. It doesn't check the invitations' Roles against the template.
+func (r *TenantOnboardingRequest) Validate(*sql.Tx) error {
+	errs := []error{}
+	if strings.TrimSpace(r.Name) == "" {
+		errs = append(errs, errors.New("name: cannot be blank"))
+	}
+	seen := make(map[string]struct{}, len(r.Invitations))
+	for _, inv := range r.Invitations {
+		addr := strings.ToLower(inv.Email.Address.Address)
+		if addr == "" {
+			errs = append(errs, errors.New("invitations: email: required"))
+		} else if _, ok := seen[addr]; ok {
+			errs = append(errs, fmt.Errorf("invitations: duplicate email '%s'", inv.Email.Address.Address))
+		}
+		seen[addr] = struct{}{}
+	}
+	return util.JoinErrs(errs)
+}
+
+// TenantOnboarding is the result of onboarding a Tenant.
+type TenantOnboarding struct {
+	Tenant TenantNullable `json:"tenant"`
+	// Quota is the quota of the Tenant, or nil if it has none.
+	Quota            *TenantQuota        `json:"quota"`
+	DeliveryServices []DeliveryServiceV4 `json:"deliveryServices"`
+	// Invitations are the users invited to the Tenant, with the Roles they
+	// were given.
+	Invitations []TenantOnboardingInvitation `json:"invitations"`
+}
+
+// TenantOnboardingResponse is the type of a response from Traffic Ops to a
+// request to its /tenants/onboard endpoint.
+type TenantOnboardingResponse struct {
+	Response TenantOnboarding `json:"response"`
+	Alerts
+}
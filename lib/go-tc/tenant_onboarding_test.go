@@ -0,0 +1,137 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"net/mail"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+const onboardingDeliveryService = `{
+	"xmlId": "{{.Tenant}}-video",
+	"displayName": "{{.Tenant}} Video",
+	"orgServerFqdn": "https://{{.Variables.origin}}",
+	"consistentHashQueryParams": ["{{.Tenant}}"],
+	"cdnId": 2,
+	"tenantId": 1,
+	"active": false
+}`
+
+func TestTenantOnboardingTemplateValidate(t *testing.T) {
+	tmpl := TenantOnboardingTemplate{
+		Parent:           "customers",
+		DefaultRole:      "portal",
+		Quota:            &TenantQuotaRequest{MaxDeliveryServices: util.IntPtr(1)},
+		DeliveryServices: []json.RawMessage{json.RawMessage(onboardingDeliveryService)},
+	}
+	if err := tmpl.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	invalid := map[string]TenantOnboardingTemplate{
+		"no parent":       {DefaultRole: "portal"},
+		"no default role": {Parent: "customers"},
+		"invalid quota":   {Parent: "customers", DefaultRole: "portal", Quota: &TenantQuotaRequest{}},
+		"over quota": {
+			Parent:           "customers",
+			DefaultRole:      "portal",
+			Quota:            &TenantQuotaRequest{MaxDeliveryServices: util.IntPtr(0)},
+			DeliveryServices: []json.RawMessage{json.RawMessage(onboardingDeliveryService)},
+		},
+		"bad template": {
+			Parent:           "customers",
+			DefaultRole:      "portal",
+			DeliveryServices: []json.RawMessage{json.RawMessage(`{"xmlId": "{{.Tenant"}`)},
+		},
+		"bad Delivery Service": {
+			Parent:           "customers",
+			DefaultRole:      "portal",
+			DeliveryServices: []json.RawMessage{json.RawMessage(`{"cdnId": "two"}`)},
+		},
+	}
+	for name, tmpl := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if err := tmpl.Validate(); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestTenantOnboardingTemplateRenderDeliveryServices(t *testing.T) {
+	tmpl := TenantOnboardingTemplate{DeliveryServices: []json.RawMessage{json.RawMessage(onboardingDeliveryService)}}
+	dses, err := tmpl.RenderDeliveryServices(TenantOnboardingTemplateData{
+		Tenant:    "acme",
+		Variables: map[string]string{"origin": `origin.acme.test"`},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(dses) != 1 {
+		t.Fatalf("expected 1 Delivery Service, got %d", len(dses))
+	}
+	ds := dses[0]
+	if ds.XMLID == nil || *ds.XMLID != "acme-video" {
+		t.Errorf("expected XMLID 'acme-video', got %v", ds.XMLID)
+	}
+	if ds.OrgServerFQDN == nil || *ds.OrgServerFQDN != `https://origin.acme.test"` {
+		t.Errorf("expected variables to be expanded without changing the structure of the Delivery Service, got origin %v", ds.OrgServerFQDN)
+	}
+	if len(ds.ConsistentHashQueryParams) != 1 || ds.ConsistentHashQueryParams[0] != "acme" {
+		t.Errorf("expected strings in arrays to be expanded, got %v", ds.ConsistentHashQueryParams)
+	}
+	if ds.CDNID == nil || *ds.CDNID != 2 {
+		t.Errorf("expected CDN ID 2, got %v", ds.CDNID)
+	}
+
+	if _, err := tmpl.RenderDeliveryServices(TenantOnboardingTemplateData{Tenant: "acme"}); err == nil {
+		t.Error("expected an error for a missing variable, got nil")
+	}
+}
+
+func TestTenantOnboardingRequestValidate(t *testing.T) {
+	addr := func(s string) rfc.EmailAddress {
+		return rfc.EmailAddress{Address: mail.Address{Address: s}}
+	}
+	req := TenantOnboardingRequest{
+		Name:        "acme",
+		Invitations: []TenantOnboardingInvitation{{Email: addr("ops@acme.test")}, {Email: addr("dev@acme.test"), Role: "read-only"}},
+	}
+	if err := req.Validate(nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	invalid := map[string]TenantOnboardingRequest{
+		"no name":          {},
+		"no email":         {Name: "acme", Invitations: []TenantOnboardingInvitation{{}}},
+		"duplicate emails": {Name: "acme", Invitations: []TenantOnboardingInvitation{{Email: addr("ops@acme.test")}, {Email: addr("OPS@acme.test")}}},
+	}
+	for name, req := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if err := req.Validate(nil); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// TenantQuota limits the numbers of Delivery Services and users that a Tenant
+// may have, not counting those of its descendants. Users whose registration
+// is pending count toward the limit.
+type TenantQuota struct {
+	// TenantID is the integral, unique identifier of the Tenant whose quota
+	// it is.
+	TenantID int `json:"tenantId"`
+	// TenantName is the name of the Tenant whose quota it is.
+	TenantName string `json:"tenantName"`
+	// MaxDeliveryServices is the number of Delivery Services the Tenant may
+	// have, or nil if that isn't limited.
+	MaxDeliveryServices *int `json:"maxDeliveryServices"`
+	// MaxUsers is the number of users the Tenant may have, or nil if that
+	// isn't limited.
+	MaxUsers *int `json:"maxUsers"`
+	// DeliveryServices is the number of Delivery Services the Tenant has.
+	DeliveryServices int `json:"deliveryServices"`
+	// Users is the number of users the Tenant has.
+	Users int `json:"users"`
+	// LastUpdated is the time at which the quota was last modified.
+	LastUpdated *time.Time `json:"lastUpdated"`
+}
+
+// TenantQuotaRequest is the type of a request to set the quota of a Tenant.
+type TenantQuotaRequest struct {
+	MaxDeliveryServices *int `json:"maxDeliveryServices"`
+	MaxUsers            *int `json:"maxUsers"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface. A quota may be lower than a Tenant's current usage, in which case
+// nothing more can be added to the Tenant until it's back under its quota.
+func (r *TenantQuotaRequest) Validate(*sql.Tx) error {
+	errs := []error{}
+	if r.MaxDeliveryServices == nil && r.MaxUsers == nil {
+		errs = append(errs, errors.New("a quota must limit at least one of 'maxDeliveryServices' or 'maxUsers'"))
+	}
+	if r.MaxDeliveryServices != nil && *r.MaxDeliveryServices < 0 {
+		errs = append(errs, errors.New("maxDeliveryServices: cannot be negative"))
+	}
+	if r.MaxUsers != nil && *r.MaxUsers < 0 {
+		errs = append(errs, errors.New("maxUsers: cannot be negative"))
+	}
+	return util.JoinErrs(errs)
+}
+
+// TenantQuotaResponse is the type of a response from Traffic Ops to a request
+// to its /tenants/{{ID}}/quota endpoint.
+type TenantQuotaResponse struct {
+	Response TenantQuota `json:"response"`
+	Alerts
+}
@@ -0,0 +1,54 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestTenantQuotaRequestValidate(t *testing.T) {
+	valid := map[string]TenantQuotaRequest{
+		"both":                   {MaxDeliveryServices: util.IntPtr(10), MaxUsers: util.IntPtr(5)},
+		"only Delivery Services": {MaxDeliveryServices: util.IntPtr(10)},
+		"zero users":             {MaxUsers: util.IntPtr(0)},
+	}
+	for name, req := range valid {
+		t.Run(name, func(t *testing.T) {
+			if err := req.Validate(nil); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	invalid := map[string]TenantQuotaRequest{
+		"empty":                      {},
+		"negative Delivery Services": {MaxDeliveryServices: util.IntPtr(-1)},
+		"negative users":             {MaxUsers: util.IntPtr(-1), MaxDeliveryServices: util.IntPtr(1)},
+	}
+	for name, req := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if err := req.Validate(nil); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// TenantReadView is the read-only view that the users of a Tenant, and of its
+// descendants, have of Delivery Services and servers. Those users may read
+// the Delivery Services of the viewed Tenants, and of their descendants, as
+// well as those of their own Tenancy, but the redacted Delivery Service
+// fields are removed from those beyond their own Tenancy. The redacted server
+// fields are removed from every server they read.
+//
+// Fields are named as they are in the API representations of the objects,
+// e.g. "orgServerFqdn", and the fields of objects within them - or of the
+// objects in arrays within them - are named by their paths, separated by
+// periods, e.g. "interfaces.ipAddresses".
+type TenantReadView struct {
+	// TenantID is the integral, unique identifier of the Tenant whose users
+	// have the view.
+	TenantID int `json:"tenantId"`
+	// TenantName is the name of the Tenant whose users have the view.
+	TenantName string `json:"tenantName"`
+	// ViewedTenantIDs are the integral, unique identifiers of the Tenants
+	// whose Delivery Services the users may read.
+	ViewedTenantIDs []int `json:"viewedTenantIds"`
+	// RedactedDeliveryServiceFields are the fields removed from the Delivery
+	// Services of the viewed Tenants.
+	RedactedDeliveryServiceFields []string `json:"redactedDeliveryServiceFields"`
+	// RedactedServerFields are the fields removed from all servers.
+	RedactedServerFields []string `json:"redactedServerFields"`
+	// LastUpdated is the time at which the view was last modified.
+	LastUpdated *time.Time `json:"lastUpdated"`
+}
+
+// TenantReadViewRequest is the type of a request to set the read-only view
+// of a Tenant.
+type TenantReadViewRequest struct {
+	ViewedTenantIDs               []int    `json:"viewedTenantIds"`
+	RedactedDeliveryServiceFields []string `json:"redactedDeliveryServiceFields"`
+	RedactedServerFields          []string `json:"redactedServerFields"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface. It doesn't check that the Tenants exist, or that the fields are
+// fields of Delivery Services and servers. Omitted arrays are set to empty
+// arrays.
+func (r *TenantReadViewRequest) Validate(*sql.Tx) error {
+	if r.ViewedTenantIDs == nil {
+		r.ViewedTenantIDs = []int{}
+	}
+	if r.RedactedDeliveryServiceFields == nil {
+		r.RedactedDeliveryServiceFields = []string{}
+	}
+	if r.RedactedServerFields == nil {
+		r.RedactedServerFields = []string{}
+	}
+
+	errs := []error{}
+	if len(r.ViewedTenantIDs) == 0 && len(r.RedactedServerFields) == 0 {
+		errs = append(errs, errors.New("a read view must view at least one Tenant in 'viewedTenantIds' or redact at least one field in 'redactedServerFields'"))
+	}
+	seenIDs := make(map[int]struct{}, len(r.ViewedTenantIDs))
+	for _, id := range r.ViewedTenantIDs {
+		if id <= 0 {
+			errs = append(errs, fmt.Errorf("viewedTenantIds: %d is not a valid Tenant ID", id))
+		} else if _, ok := seenIDs[id]; ok {
+			errs = append(errs, fmt.Errorf("viewedTenantIds: duplicate Tenant ID %d", id))
+		}
+		seenIDs[id] = struct{}{}
+	}
+	errs = append(errs, validateRedactedFields("redactedDeliveryServiceFields", r.RedactedDeliveryServiceFields)...)
+	errs = append(errs, validateRedactedFields("redactedServerFields", r.RedactedServerFields)...)
+	return util.JoinErrs(errs)
+}
+
+func validateRedactedFields(name string, fields []string) []error {
+	errs := []error{}
+	seen := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		if field == "" {
+			errs = append(errs, fmt.Errorf("%s: fields cannot be blank", name))
+		} else if _, ok := seen[field]; ok {
+			errs = append(errs, fmt.Errorf("%s: duplicate field '%s'", name, field))
+		}
+		seen[field] = struct{}{}
+	}
+	return errs
+}
+
+// TenantReadViewResponse is the type of a response from Traffic Ops to a
+// request to its /tenants/{{ID}}/read-view endpoint.
+type TenantReadViewResponse struct {
+	Response TenantReadView `json:"response"`
+	Alerts
+}
@@ -0,0 +1,55 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+)
+
+func TestTenantReadViewRequestValidate(t *testing.T) {
+	req := TenantReadViewRequest{ViewedTenantIDs: []int{2, 3}}
+	if err := req.Validate(nil); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if req.RedactedDeliveryServiceFields == nil || req.RedactedServerFields == nil {
+		t.Error("expected omitted redacted fields to be set to empty arrays")
+	}
+
+	req = TenantReadViewRequest{RedactedServerFields: []string{"interfaces.ipAddresses"}}
+	if err := req.Validate(nil); err != nil {
+		t.Errorf("unexpected error for a view that only redacts server fields: %v", err)
+	}
+
+	invalid := map[string]TenantReadViewRequest{
+		"empty":                  {},
+		"only Delivery Services": {RedactedDeliveryServiceFields: []string{"orgServerFqdn"}},
+		"non-positive ID":        {ViewedTenantIDs: []int{0}},
+		"duplicate ID":           {ViewedTenantIDs: []int{2, 2}},
+		"blank field":            {ViewedTenantIDs: []int{2}, RedactedDeliveryServiceFields: []string{""}},
+		"duplicate field":        {RedactedServerFields: []string{"iloIpAddress", "iloIpAddress"}},
+	}
+	for name, req := range invalid {
+		t.Run(name, func(t *testing.T) {
+			if err := req.Validate(nil); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package tovalidate
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// FieldError is a validation error of a single field of an object. Besides
+// its human-readable message, it has a stable, machine-readable code that
+// identifies the object, the field, and the reason the field is invalid, e.g.
+// "staticdnsentry.address.invalid_ipv4".
+type FieldError struct {
+	// Object is the name of the kind of object being validated, e.g.
+	// "staticdnsentry". It may be empty, if unknown.
+	Object string
+	// Field is the path of the invalid field, as it appears in API requests.
+	Field string
+	// Reason is a machine-readable description of what is wrong with the
+	// field, e.g. "required" or "invalid_ipv4".
+	Reason string
+	// Err is the error of the field itself.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return "'" + e.Field + "' " + e.Err.Error()
+}
+
+// Unwrap returns the error of the field itself.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// AlertCode returns the machine-readable code of the error, which is the
+// lowercase Object, Field, and Reason, separated by periods and omitting any
+// that are empty.
+func (e *FieldError) AlertCode() string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{e.Object, e.Field, e.Reason} {
+		if part != "" {
+			parts = append(parts, strings.ToLower(part))
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// AlertField returns the path of the invalid field.
+func (e *FieldError) AlertField() string {
+	return e.Field
+}
+
+// NewFieldError returns a FieldError for the given field, with a reason
+// determined from err.
+func NewFieldError(field string, err error) *FieldError {
+	return &FieldError{Field: field, Reason: Reason(err), Err: err}
+}
+
+type reasonError struct {
+	error
+	reason string
+}
+
+func (e reasonError) Unwrap() error {
+	return e.error
+}
+
+// WithReason returns err with the given explicit machine-readable reason,
+// which Reason returns instead of one inferred from the error's message. This
+// should be used by custom validation rules, to give their errors stable
+// reasons.
+func WithReason(reason string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return reasonError{error: err, reason: reason}
+}
+
+// Reasons given to validation errors that have no explicit reason and aren't
+// one of the known errors of the validation library.
+const (
+	ReasonInvalid  = "invalid"
+	ReasonRequired = "required"
+)
+
+// knownReasons maps the messages of the errors of common validation rules to
+// their reasons.
+var knownReasons = map[string]string{
+	"cannot be blank":               ReasonRequired,
+	"is required":                   ReasonRequired,
+	"must be blank":                 "must_be_empty",
+	"must be nil":                   "must_be_empty",
+	"must be a valid IPv4 address":  "invalid_ipv4",
+	"must be a valid IPv6 address":  "invalid_ipv6",
+	"must be a valid IP address":    "invalid_ip",
+	"must be a valid DNS name":      "invalid_dns_name",
+	"must be a valid URL":           "invalid_url",
+	"must be a valid request URL":   "invalid_url",
+	"must be a valid email address": "invalid_email",
+	"must contain digits only":      "invalid_digits",
+	"must be in a valid format":     "invalid_format",
+	"must be a valid value":         "invalid_value",
+	"must not be in list":           "forbidden_value",
+	"must be greater than zero":     "too_small",
+	"must be a valid port number":   "invalid_port",
+}
+
+// knownReasonPrefixes maps the beginnings of the messages of the errors of
+// common validation rules with parameterized messages to their reasons.
+var knownReasonPrefixes = []struct {
+	prefix string
+	reason string
+}{
+	{"the length must be", "invalid_length"},
+	{"must be no less than", "too_small"},
+	{"must be greater than", "too_small"},
+	{"must be no greater than", "too_large"},
+	{"must be less than", "too_large"},
+	{"duplicate value found", "duplicate"},
+}
+
+// Reason returns the machine-readable reason of the given validation error.
+// This is the reason given with WithReason, if any, or else the reason of the
+// validation rule that produced the error if it's known, or else
+// ReasonInvalid.
+func Reason(err error) string {
+	var withReason reasonError
+	if errors.As(err, &withReason) {
+		return withReason.reason
+	}
+	msg := err.Error()
+	if reason, ok := knownReasons[msg]; ok {
+		return reason
+	}
+	for _, known := range knownReasonPrefixes {
+		if strings.HasPrefix(msg, known.prefix) {
+			return known.reason
+		}
+	}
+	return ReasonInvalid
+}
+
+// SetObject sets the Object of the FieldError err - or of all of the
+// FieldErrors among the errors joined in err by util.JoinErrs - to object,
+// if it isn't already set.
+func SetObject(err error, object string) {
+	var joined util.JoinedErrors
+	if errors.As(err, &joined) {
+		for _, e := range joined.Errors() {
+			SetObject(e, object)
+		}
+		return
+	}
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) && fieldErr.Object == "" {
+		fieldErr.Object = object
+	}
+}
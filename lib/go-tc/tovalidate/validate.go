@@ -14,15 +14,15 @@ package tovalidate
 
 import (
 	"errors"
-	"fmt"
 	"strings"
 )
 
 // ToErrors converts a map of strings to errors into an array of errors.
 //
-// This is accomplished using `fmt.Errorf("'%v' %v", key, value)` where 'key'
-// is the map key and 'value' is the error value to which it points - this
-// means that error identity is NOT preserved. For example:
+// Each error is a *FieldError for the field that is the map key, the message
+// of which is "'key' value". These carry machine-readable codes, which Traffic
+// Ops includes in the Alerts of its responses. Error identity is preserved
+// through wrapping, though the order of the errors is not. For example:
 //
 //	errMap := map[string]error{
 //	    "sql.ErrNoRows": sql.ErrNoRows,
@@ -34,13 +34,12 @@ import (
 //	    fmt.Println("false")
 //	}
 //
-// ... will output 'false'.
+// ... will output 'true'.
 func ToErrors(err map[string]error) []error {
 	vErrors := []error{}
 	for key, value := range err {
 		if value != nil {
-			errMsg := fmt.Errorf("'%v' %w", key, value)
-			vErrors = append(vErrors, errMsg)
+			vErrors = append(vErrors, NewFieldError(key, value))
 		}
 	}
 	return vErrors
@@ -49,3 +49,47 @@ func TestToError(t *testing.T) {
 		t.Error("an error map with no non-nil errors should yield a nil error, got:", err)
 	}
 }
+
+func TestToErrors(t *testing.T) {
+	errs := ToErrors(map[string]error{
+		"address": errors.New("must be a valid IPv4 address"),
+		"host":    WithReason("conflict", errors.New("already in use")),
+		"ttl":     nil,
+	})
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %d: %v", len(errs), errs)
+	}
+
+	codes := map[string]string{}
+	for _, err := range errs {
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) {
+			t.Fatalf("expected a FieldError, got %T", err)
+		}
+		fieldErr.Object = "staticDNSEntry"
+		codes[fieldErr.Field] = fieldErr.AlertCode()
+		if fieldErr.Field == "address" && err.Error() != "'address' must be a valid IPv4 address" {
+			t.Errorf("expected message \"'address' must be a valid IPv4 address\", got '%s'", err.Error())
+		}
+	}
+	if codes["address"] != "staticdnsentry.address.invalid_ipv4" {
+		t.Errorf("expected code 'staticdnsentry.address.invalid_ipv4', got '%s'", codes["address"])
+	}
+	if codes["host"] != "staticdnsentry.host.conflict" {
+		t.Errorf("expected code 'staticdnsentry.host.conflict', got '%s'", codes["host"])
+	}
+}
+
+func TestReason(t *testing.T) {
+	tests := map[string]string{
+		"cannot be blank":                    ReasonRequired,
+		"the length must be between 1 and 5": "invalid_length",
+		"must be no greater than 10":         "too_large",
+		"something unusual":                  ReasonInvalid,
+	}
+	for msg, expected := range tests {
+		if actual := Reason(errors.New(msg)); actual != expected {
+			t.Errorf("expected reason '%s' for error '%s', got '%s'", expected, msg, actual)
+		}
+	}
+}
@@ -34,12 +34,26 @@ import (
 // monitoring thresholds.
 const ThresholdPrefix = "health.threshold."
 
+// HealthAlgorithmParameterName is the Name of the Parameter that selects the
+// algorithm with which Traffic Monitor decides whether the cache servers using
+// a Profile are healthy.
+const HealthAlgorithmParameterName = "health.algorithm"
+
+// HealthAlgorithmPrefix is the prefix of all Names of Parameters used to
+// configure the algorithm selected by the HealthAlgorithmParameterName
+// Parameter.
+const HealthAlgorithmPrefix = HealthAlgorithmParameterName + "."
+
 // These are the names of statistics that can be used in thresholds for server
 // health.
 const (
 	StatNameKBPS      = "kbps"
 	StatNameMaxKBPS   = "maxKbps"
 	StatNameBandwidth = "bandwidth"
+	// StatNameLinkErrorPolls is the number of consecutive polls in which the
+	// number of errors on any of a cache server's monitored network
+	// interfaces grew.
+	StatNameLinkErrorPolls = "linkErrorPolls"
 )
 
 // TMConfigResponse is the response to requests made to the
@@ -49,6 +63,75 @@ type TMConfigResponse struct {
 	Alerts
 }
 
+// TrafficMonitorConfigSinceQueryParam is the query parameter of the
+// cdns/{{Name}}/configs/monitoring endpoint of the Traffic Ops API giving the
+// version of the monitoring configuration since which only the changed
+// sections should be returned, as a TrafficMonitorConfigDelta.
+const TrafficMonitorConfigSinceQueryParam = "since"
+
+// TrafficMonitorConfigDelta is the change to the monitoring configuration of
+// a CDN since some earlier version of it.
+//
+// Each Snapshot of a CDN gives its monitoring configuration a new, greater
+// version, even if no section of it changed, since its CRConfig may have.
+type TrafficMonitorConfigDelta struct {
+	// Version is the version of the current monitoring configuration.
+	Version int64 `json:"version"`
+	// Since is the version the delta is relative to.
+	Since int64 `json:"since"`
+	// Sections are the top-level properties of the TrafficMonitorConfig that
+	// changed since the earlier version, keyed by their names, e.g.
+	// "trafficServers". Changed sections are given whole.
+	Sections map[string]json.RawMessage `json:"sections"`
+}
+
+// TrafficMonitorConfigDeltaResponse is the response to requests made to the
+// cdns/{{Name}}/configs/monitoring endpoint of the Traffic Ops API with the
+// TrafficMonitorConfigSinceQueryParam.
+type TrafficMonitorConfigDeltaResponse struct {
+	Response TrafficMonitorConfigDelta `json:"response"`
+	Alerts
+}
+
+// Apply replaces the sections of cfg that changed with those of the delta.
+// Sections it doesn't know are ignored. If an error is returned, cfg may have
+// been partly changed, so deltas should be applied to a copy.
+func (d TrafficMonitorConfigDelta) Apply(cfg *TrafficMonitorConfig) error {
+	for name, section := range d.Sections {
+		var err error
+		switch name {
+		case "trafficServers":
+			cfg.TrafficServers = nil
+			err = json.Unmarshal(section, &cfg.TrafficServers)
+		case "cacheGroups":
+			cfg.CacheGroups = nil
+			err = json.Unmarshal(section, &cfg.CacheGroups)
+		case "config":
+			cfg.Config = nil
+			err = json.Unmarshal(section, &cfg.Config)
+		case "trafficMonitors":
+			cfg.TrafficMonitors = nil
+			err = json.Unmarshal(section, &cfg.TrafficMonitors)
+		case "deliveryServices":
+			cfg.DeliveryServices = nil
+			err = json.Unmarshal(section, &cfg.DeliveryServices)
+		case "profiles":
+			cfg.Profiles = nil
+			err = json.Unmarshal(section, &cfg.Profiles)
+		case "topologies":
+			cfg.Topologies = nil
+			err = json.Unmarshal(section, &cfg.Topologies)
+		case "externalTargets":
+			cfg.ExternalTargets = nil
+			err = json.Unmarshal(section, &cfg.ExternalTargets)
+		}
+		if err != nil {
+			return fmt.Errorf("section '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
 // LegacyTMConfigResponse was the response to requests made to the
 // cdns/{{Name}}/configs/monitoring endpoint of the Traffic Ops API in older
 // API versions.
@@ -87,6 +170,10 @@ type TrafficMonitorConfig struct {
 	// Topologies is the set of topologies defined in Traffic Ops, consisting
 	// of just the EDGE_LOC-type cachegroup nodes.
 	Topologies map[string]CRConfigTopology `json:"topologies"`
+	// ExternalTargets is the set of external CDN endpoints targeted by the
+	// steering Delivery Services of the monitored CDN, the health of which
+	// is polled by the Traffic Monitor.
+	ExternalTargets []TMExternalTarget `json:"externalTargets,omitempty"`
 }
 
 const healthThresholdAvailableBandwidthInKbps = "availableBandwidthInKbps"
@@ -198,6 +285,9 @@ type TrafficMonitorConfigMap struct {
 	Profile map[string]TMProfile
 	// Topology is a map of Topology names to CRConfigTopology structs.
 	Topology map[string]CRConfigTopology
+	// ExternalTarget is a map of steering external target names to
+	// TMExternalTarget objects.
+	ExternalTarget map[string]TMExternalTarget
 }
 
 // ToLegacy converts a Stats to a LegacyStats.
@@ -478,6 +568,16 @@ type TMDeliveryService struct {
 	HostRegexes        []string `json:"hostRegexes"`
 }
 
+// TMExternalTarget is an external CDN endpoint targeted by a steering Delivery
+// Service, as it appears in a TrafficMonitorConfig.
+type TMExternalTarget struct {
+	// Name is the unique name of the steering external target.
+	Name string `json:"name"`
+	// HealthCheckURL is the URL requested by Traffic Monitor to check the
+	// health of the target. Any 2XX response means the target is healthy.
+	HealthCheckURL string `json:"healthCheckUrl"`
+}
+
 // TMProfile is primarily a collection of the Parameters with special meaning
 // to Traffic Monitor for a Profile of one of the monitored cache servers
 // and/or other Traffic Monitors, along with some identifying information for
@@ -506,6 +606,14 @@ type TMParameters struct {
 	// Thresholds field, formatted as individual string Parameters, rather than as
 	// a JSON object.
 	Thresholds map[string]HealthThreshold `json:"health_threshold,omitempty"`
+	// HealthAlgorithm is the name of the algorithm with which the health of
+	// cache servers using the Profile is decided. Empty means the default,
+	// which compares their stats to the Thresholds.
+	HealthAlgorithm string `json:"health.algorithm,omitempty"`
+	// HealthAlgorithmParameters are the settings of the HealthAlgorithm, by
+	// the part of their Parameters' Names after the HealthAlgorithmPrefix,
+	// e.g. "ewma.alpha".
+	HealthAlgorithmParameters map[string]float64 `json:"health_algorithm,omitempty"`
 	HealthThresholdJSONParameters
 }
 
@@ -627,6 +735,27 @@ func (params *TMParameters) UnmarshalJSON(bytes []byte) (err error) {
 			}
 		}
 	}
+
+	if vi, ok := raw[HealthAlgorithmParameterName]; ok {
+		if v, ok := vi.(string); !ok {
+			return fmt.Errorf("Unmarshalling TMParameters %s expected string, got %v", HealthAlgorithmParameterName, vi)
+		} else {
+			params.HealthAlgorithm = v
+		}
+	}
+
+	params.HealthAlgorithmParameters = map[string]float64{}
+	for k, v := range raw {
+		if strings.HasPrefix(k, HealthAlgorithmPrefix) {
+			name := k[len(HealthAlgorithmPrefix):]
+			vStr := fmt.Sprintf("%v", v) // allows string or numeric JSON types, like thresholds.
+			if f, err := strconv.ParseFloat(vStr, 64); err != nil {
+				return fmt.Errorf("Unmarshalling TMParameters `%s` parameter value not a number: '%s' value '%v': %v", HealthAlgorithmPrefix, k, v, err)
+			} else {
+				params.HealthAlgorithmParameters[name] = f
+			}
+		}
+	}
 	return nil
 }
 
@@ -646,6 +775,7 @@ func TrafficMonitorTransformToMap(tmConfig *TrafficMonitorConfig) (*TrafficMonit
 	tm.DeliveryService = make(map[string]TMDeliveryService, len(tmConfig.DeliveryServices))
 	tm.Profile = make(map[string]TMProfile, len(tmConfig.Profiles))
 	tm.Topology = tmConfig.Topologies
+	tm.ExternalTarget = make(map[string]TMExternalTarget, len(tmConfig.ExternalTargets))
 
 	for _, trafficServer := range tmConfig.TrafficServers {
 		tm.TrafficServer[trafficServer.HostName] = trafficServer
@@ -673,6 +803,10 @@ func TrafficMonitorTransformToMap(tmConfig *TrafficMonitorConfig) (*TrafficMonit
 		tm.Profile[profile.Name] = profile
 	}
 
+	for _, externalTarget := range tmConfig.ExternalTargets {
+		tm.ExternalTarget[externalTarget.Name] = externalTarget
+	}
+
 	return &tm, tm.Valid()
 }
 
@@ -782,3 +916,34 @@ type HealthDataCacheGroup struct {
 	Online  int64          `json:"online"`
 	Name    CacheGroupName `json:"name"`
 }
+
+// MonitoredCDNConfigFile is the Config File of the Parameters which configure
+// Traffic Monitors, including MonitoredCDNParameterName.
+const MonitoredCDNConfigFile = "rascal-config.txt"
+
+// MonitoredCDNParameterName is the Name of the Parameters on a Traffic
+// Monitor's Profile which name the CDNs it monitors besides its own, each
+// with the port on which it serves that CDN's monitoring data, as
+// "<CDN name>:<port>".
+const MonitoredCDNParameterName = "monitored.cdn"
+
+// MonitoredCDN is a CDN monitored by a Traffic Monitor in addition to its own
+// CDN, and the port on which it serves that CDN's health and statistics.
+type MonitoredCDN struct {
+	CDNName CDNName
+	Port    int
+}
+
+// ParseMonitoredCDN parses the Value of a MonitoredCDNParameterName
+// Parameter.
+func ParseMonitoredCDN(value string) (MonitoredCDN, error) {
+	i := strings.LastIndex(value, ":")
+	if i < 1 {
+		return MonitoredCDN{}, fmt.Errorf("'%s' is not of the form '<CDN name>:<port>'", value)
+	}
+	port, err := strconv.Atoi(value[i+1:])
+	if err != nil || port < 1 || port > 65535 {
+		return MonitoredCDN{}, fmt.Errorf("'%s' has invalid port '%s'", value, value[i+1:])
+	}
+	return MonitoredCDN{CDNName: CDNName(value[:i]), Port: port}, nil
+}
@@ -195,7 +195,10 @@ func ExampleTMParameters_UnmarshalJSON() {
 		"health.polling.format": "stats_over_http",
 		"history.count": 1,
 		"health.threshold.bandwidth": ">50",
-		"health.threshold.foo": "<=500"
+		"health.threshold.foo": "<=500",
+		"health.algorithm": "ewma",
+		"health.algorithm.ewma.alpha": "0.5",
+		"health.algorithm.ewma.maxResponseTimeMs": 250
 	}`
 
 	var params TMParameters
@@ -208,12 +211,14 @@ func ExampleTMParameters_UnmarshalJSON() {
 	fmt.Printf("format: %s\n", params.HealthPollingFormat)
 	fmt.Printf("history: %d\n", params.HistoryCount)
 	fmt.Printf("# of Thresholds: %d - foo: %s, bandwidth: %s\n", len(params.Thresholds), params.Thresholds["foo"], params.Thresholds["bandwidth"])
+	fmt.Printf("algorithm: %s - alpha: %v, max response time: %vms\n", params.HealthAlgorithm, params.HealthAlgorithmParameters["ewma.alpha"], params.HealthAlgorithmParameters["ewma.maxResponseTimeMs"])
 
 	// Output: timeout: 5
 	// url: https://example.com/
 	// format: stats_over_http
 	// history: 1
 	// # of Thresholds: 2 - foo: <=500.000000, bandwidth: >50.000000
+	// algorithm: ewma - alpha: 0.5, max response time: 250ms
 }
 
 func ExampleTrafficMonitorConfigMap_Valid() {
@@ -612,3 +617,53 @@ func TestTrafficMonitorTransformToMap(t *testing.T) {
 		t.Errorf("Incorrect number of IP addresses on converted traffic server's interface; expected: 1, got: %d", len(converted.TrafficServer["testHostname"].Interfaces[0].IPAddresses))
 	}
 }
+
+func TestTrafficMonitorConfigDeltaApply(t *testing.T) {
+	cfg := TrafficMonitorConfig{
+		CacheGroups: []TMCacheGroup{{Name: "old"}},
+		Config:      map[string]interface{}{"stale": true, "kept": 1.0},
+		Profiles:    []TMProfile{{Name: "unchanged"}},
+	}
+	delta := TrafficMonitorConfigDelta{
+		Version: 2,
+		Since:   1,
+		Sections: map[string]json.RawMessage{
+			"cacheGroups": json.RawMessage(`[{"name":"new"}]`),
+			"config":      json.RawMessage(`{"kept":2}`),
+			"unknown":     json.RawMessage(`"ignored"`),
+		},
+	}
+	if err := delta.Apply(&cfg); err != nil {
+		t.Fatalf("unexpected error applying delta: %v", err)
+	}
+	if len(cfg.CacheGroups) != 1 || cfg.CacheGroups[0].Name != "new" {
+		t.Errorf("expected cacheGroups to be replaced, actual %+v", cfg.CacheGroups)
+	}
+	if _, ok := cfg.Config["stale"]; ok || cfg.Config["kept"] != 2.0 {
+		t.Errorf("expected config to be replaced rather than merged, actual %+v", cfg.Config)
+	}
+	if len(cfg.Profiles) != 1 || cfg.Profiles[0].Name != "unchanged" {
+		t.Errorf("expected unchanged profiles to be kept, actual %+v", cfg.Profiles)
+	}
+
+	delta.Sections = map[string]json.RawMessage{"profiles": json.RawMessage(`{}`)}
+	if err := delta.Apply(&cfg); err == nil {
+		t.Error("expected an error applying a malformed section, actual nil")
+	}
+}
+
+func TestParseMonitoredCDN(t *testing.T) {
+	mc, err := ParseMonitoredCDN("small-cdn:8081")
+	if err != nil {
+		t.Fatalf("unexpected error parsing monitored CDN: %v", err)
+	}
+	if mc.CDNName != "small-cdn" || mc.Port != 8081 {
+		t.Errorf("expected CDN 'small-cdn' on port 8081, actual: CDN '%s' on port %d", mc.CDNName, mc.Port)
+	}
+
+	for _, invalid := range []string{"small-cdn", ":8081", "small-cdn:", "small-cdn:http", "small-cdn:0", "small-cdn:65536"} {
+		if _, err := ParseMonitoredCDN(invalid); err == nil {
+			t.Errorf("expected an error parsing monitored CDN '%s'", invalid)
+		}
+	}
+}
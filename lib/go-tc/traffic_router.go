@@ -32,6 +32,9 @@ const CoverageZonePollingURL = CoverageZonePollingPrefix + "url"
 type CoverageZoneLocation struct {
 	Network  []string `json:"network,omitempty"`
 	Network6 []string `json:"network6,omitempty"`
+	// Coordinates are where Traffic Router locates clients in the zone, if
+	// not at its Cache Group.
+	Coordinates *CoverageZoneCoordinates `json:"coordinates,omitempty"`
 }
 
 func (c *CoverageZoneLocation) GetFirstIPAddressOfType(isIPv4 bool) string {
@@ -49,6 +52,8 @@ func (c *CoverageZoneLocation) GetFirstIPAddressOfType(isIPv4 bool) string {
 
 // CoverageZoneFile is used for unmarshalling a Coverage Zone File.
 type CoverageZoneFile struct {
+	Revision      string                          `json:"revision,omitempty"`
+	CustomerName  string                          `json:"customerName,omitempty"`
 	CoverageZones map[string]CoverageZoneLocation `json:"coverageZones,omitempty"`
 }
 
@@ -69,6 +74,9 @@ type SOA struct {
 	RefreshSecondsTime time.Time `json:"-"`
 	RetrySeconds       *string   `json:"retry,omitempty"`
 	RetrySecondsTime   time.Time `json:"-"`
+	// Serial is the SOA serial number. When it's nil, Traffic Router
+	// derives the serial number from the date of the snapshot.
+	Serial *string `json:"serial,omitempty"`
 }
 
 // MatchSet structures are a list of MatchList structures with an associated
@@ -63,6 +63,16 @@ func DurationLiteralToSeconds(d string) (int64, error) {
 	return -1, errors.New("Invalid duration literal, no recognized suffix")
 }
 
+// TrafficStatsAggregatePattern matches the names of the aggregations which can
+// be applied to the values in each interval of a Traffic Stats data series:
+// "mean", "min", "max", "sum", "median", or "p" followed by a percentile from 1
+// to 99, e.g. "p95".
+var TrafficStatsAggregatePattern = regexp.MustCompile(`^(mean|min|max|sum|median|p[1-9][0-9]?)$`)
+
+// TrafficStatsDefaultAggregate is the aggregation applied to the values in
+// each interval of a Traffic Stats data series if none is requested.
+const TrafficStatsDefaultAggregate = "mean"
+
 // TrafficStatsOrderable encodes what columns by which the data returned from a Traffic Stats query
 // may be ordered.
 type TrafficStatsOrderable string
@@ -114,16 +124,25 @@ func ExcludeFromString(v string) TrafficStatsExclude {
 // TrafficStatsConfig represents the configuration of a request made to Traffic Stats. This is
 // typically constructed by parsing a request body submitted to Traffic Ops.
 type TrafficStatsConfig struct {
+	// Aggregate is the aggregation applied to the values in each Interval,
+	// which must match TrafficStatsAggregatePattern.
+	Aggregate      string
 	End            time.Time
 	ExcludeSeries  bool
 	ExcludeSummary bool
-	Interval       string
-	Limit          *uint64
-	MetricType     string
-	Offset         *uint64
-	OrderBy        *TrafficStatsOrderable
-	Start          time.Time
-	Unix           bool
+	// GroupBy is the name of a tag by which the data series is split into
+	// groups, if any.
+	GroupBy  string
+	Interval string
+	Limit    *uint64
+	// MaxPoints is the greatest number of data points the series may have;
+	// Interval is widened as needed to keep within it.
+	MaxPoints  *uint64
+	MetricType string
+	Offset     *uint64
+	OrderBy    *TrafficStatsOrderable
+	Start      time.Time
+	Unix       bool
 }
 
 // TrafficDSStatsConfig represents the configuration of a request made to
@@ -140,6 +159,27 @@ type TrafficCacheStatsConfig struct {
 	TrafficStatsConfig
 }
 
+// Downsample widens the Interval of the TrafficStatsConfig, if necessary, so
+// that the requested time range spans no more than MaxPoints intervals. The
+// widened Interval is always a whole number of minutes.
+func (c *TrafficStatsConfig) Downsample() error {
+	if c.MaxPoints == nil || *c.MaxPoints == 0 {
+		return nil
+	}
+	iSecs, err := DurationLiteralToSeconds(c.Interval)
+	if err != nil {
+		return fmt.Errorf("parsing interval: %w", err)
+	}
+	rangeSecs := int64(c.End.Sub(c.Start) / time.Second)
+	maxPoints := int64(*c.MaxPoints)
+	if rangeSecs <= iSecs*maxPoints {
+		return nil
+	}
+	minutes := (rangeSecs + maxPoints*60 - 1) / (maxPoints * 60)
+	c.Interval = strconv.FormatInt(minutes, 10) + "m"
+	return nil
+}
+
 // OffsetString is a stupid, dirty hack to try to convince Influx to not
 // give back data that's outside of the range in a WHERE clause. It doesn't
 // work, but it helps.
@@ -157,6 +197,9 @@ func (c *TrafficStatsConfig) OffsetString() string {
 // TrafficDSStatsResponse represents a response from the
 // /deliveryservice_stats "Traffic Stats" endpoints.
 type TrafficDSStatsResponse struct {
+	// Groups holds the data split into one series per group, when grouping
+	// is requested, in place of Series.
+	Groups []TrafficStatsSeries `json:"groups,omitempty"`
 	// Series holds the actual data - it is NOT in general the same as a github.com/influxdata/influxdb1-client/models.Row
 	Series *TrafficStatsSeries `json:"series,omitempty"`
 	// Summary contains summary statistics of the data in Series
@@ -166,6 +209,9 @@ type TrafficDSStatsResponse struct {
 // TrafficStatsResponse represents the generic response from one of the "Traffic Stats endpoints" of the
 // Traffic Ops API, e.g. `/cache_stats`.
 type TrafficStatsResponse struct {
+	// Groups holds the data split into one series per group, when grouping
+	// is requested, in place of Series.
+	Groups []TrafficStatsSeries `json:"groups,omitempty"`
 	// Series holds the actual data - it is NOT in general the same as a github.com/influxdata/influxdb1-client/models.Row
 	Series *TrafficStatsSeries `json:"series,omitempty"`
 	// Summary contains summary statistics of the data in Series
@@ -0,0 +1,289 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// TransformScriptLanguageLua is the language of transformation scripts which
+// are Lua scripts run by the ATS ts_lua plugin. It is the only supported
+// language.
+const TransformScriptLanguageLua = "lua"
+
+// MaxTransformScriptBytes is the largest a transformation script's content may
+// be, in bytes.
+const MaxTransformScriptBytes = 256 * 1024
+
+// transformScriptNameRegexp matches valid transformation script names, which
+// are used in the names of the files to which cache servers write them.
+var transformScriptNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// TransformScript is a named, versioned script which transforms the requests
+// and/or responses of the Delivery Services to which it's attached.
+type TransformScript struct {
+	// ID is the integral, unique identifier of the script.
+	ID int `json:"id"`
+	// Name is the unique name of the script.
+	Name string `json:"name"`
+	// Language is the language in which the script is written.
+	Language string `json:"language"`
+	// Description is an optional description of what the script does.
+	Description string `json:"description"`
+	// LatestVersion is the latest version of the script's content.
+	LatestVersion int `json:"latestVersion"`
+	// LastUpdated is the time at which the script was last modified, or had
+	// a version added.
+	LastUpdated time.Time `json:"lastUpdated"`
+}
+
+// TransformScriptVersion is a version of the content of a transformation
+// script. Versions are immutable once created.
+type TransformScriptVersion struct {
+	// ScriptID is the integral, unique identifier of the script.
+	ScriptID int `json:"scriptId"`
+	// Version is the version number, starting from 1.
+	Version int `json:"version"`
+	// Content is the script's source code.
+	Content string `json:"content"`
+	// SHA256 is the hex-encoded SHA-256 digest of Content.
+	SHA256 string `json:"sha256"`
+	// Comment is an optional description of the changes in the version.
+	Comment string `json:"comment"`
+	// CreatedBy is the username of the user who created the version, if they
+	// still exist.
+	CreatedBy *string `json:"createdBy"`
+	// Created is the time at which the version was created.
+	Created time.Time `json:"created"`
+}
+
+// TransformScriptSHA256 returns the hex-encoded SHA-256 digest of the given
+// transformation script content.
+func TransformScriptSHA256(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// TransformScriptRequest is the type of a request to create a transformation
+// script, along with its first version.
+type TransformScriptRequest struct {
+	Name        string `json:"name"`
+	Language    string `json:"language"`
+	Description string `json:"description"`
+	Content     string `json:"content"`
+	Comment     string `json:"comment"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface.
+func (r TransformScriptRequest) Validate(*sql.Tx) error {
+	errs := []error{}
+	if !transformScriptNameRegexp.MatchString(r.Name) {
+		errs = append(errs, errors.New("name: required, and must contain only letters, digits, '_', and '-'"))
+	}
+	if r.Language != TransformScriptLanguageLua {
+		errs = append(errs, fmt.Errorf("language: must be '%s'", TransformScriptLanguageLua))
+	}
+	if err := validateTransformScriptContent(r.Content); err != nil {
+		errs = append(errs, fmt.Errorf("content: %w", err))
+	}
+	return util.JoinErrs(errs)
+}
+
+// TransformScriptVersionRequest is the type of a request to add a version to
+// a transformation script.
+type TransformScriptVersionRequest struct {
+	Content string `json:"content"`
+	Comment string `json:"comment"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface.
+func (r TransformScriptVersionRequest) Validate(*sql.Tx) error {
+	if err := validateTransformScriptContent(r.Content); err != nil {
+		return fmt.Errorf("content: %w", err)
+	}
+	return nil
+}
+
+// validateTransformScriptContent returns an error if the given content can't
+// be the content of a transformation script.
+func validateTransformScriptContent(content string) error {
+	if strings.TrimSpace(content) == "" {
+		return errors.New("required")
+	}
+	if len(content) > MaxTransformScriptBytes {
+		return fmt.Errorf("cannot be larger than %d bytes", MaxTransformScriptBytes)
+	}
+	if strings.ContainsRune(content, 0) {
+		return errors.New("cannot contain NUL characters")
+	}
+	return nil
+}
+
+// DeliveryServiceTransformScript is a transformation script attached to a
+// Delivery Service.
+//
+// A new version of the script can be rolled out gradually, by setting
+// RolloutVersion and RolloutPercent: that percentage of the Delivery Service's
+// cache servers run RolloutVersion, and the rest run Version. Which cache
+// servers run RolloutVersion is stable, so raising RolloutPercent only adds
+// cache servers to them.
+type DeliveryServiceTransformScript struct {
+	// Script is the name of the script.
+	Script string `json:"script"`
+	// Language is the language in which the script is written. It's ignored
+	// in requests.
+	Language string `json:"language"`
+	// Version is the version of the script run by the cache servers which
+	// don't run RolloutVersion.
+	Version int `json:"version"`
+	// RolloutVersion, if not nil, is the version of the script being rolled
+	// out.
+	RolloutVersion *int `json:"rolloutVersion"`
+	// RolloutPercent is the percentage of cache servers which run
+	// RolloutVersion. It must be between 1 and 100 if RolloutVersion is set,
+	// and 0 otherwise.
+	RolloutPercent int `json:"rolloutPercent"`
+	// Content is the content of Version. It's only included in responses
+	// meant for cache configuration generation, and ignored in requests.
+	Content string `json:"content,omitempty"`
+	// RolloutContent is the content of RolloutVersion. It's only included in
+	// responses meant for cache configuration generation, and ignored in
+	// requests.
+	RolloutContent string `json:"rolloutContent,omitempty"`
+}
+
+// DeliveryServiceTransformScripts are the transformation scripts attached to
+// a Delivery Service.
+type DeliveryServiceTransformScripts struct {
+	// DeliveryServiceID is the integral, unique identifier of the Delivery
+	// Service.
+	DeliveryServiceID int `json:"deliveryServiceId"`
+	// XMLID is the XMLID of the Delivery Service.
+	XMLID string `json:"xmlId"`
+	// Scripts are the Delivery Service's transformation scripts, in the
+	// order in which they're run.
+	Scripts []DeliveryServiceTransformScript `json:"scripts"`
+	// LastUpdated is the time at which the Delivery Service's transformation
+	// scripts were last modified, or nil if it doesn't have any.
+	LastUpdated *time.Time `json:"lastUpdated"`
+}
+
+// DeliveryServiceTransformScriptsRequest is the type of a request to replace
+// the transformation scripts attached to a Delivery Service.
+type DeliveryServiceTransformScriptsRequest struct {
+	Scripts []DeliveryServiceTransformScript `json:"scripts"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface.
+//
+// It doesn't check that the scripts or their versions exist.
+func (r DeliveryServiceTransformScriptsRequest) Validate(*sql.Tx) error {
+	if r.Scripts == nil {
+		return errors.New("scripts: required")
+	}
+	errs := []error{}
+	names := map[string]struct{}{}
+	for i, script := range r.Scripts {
+		if script.Script == "" {
+			errs = append(errs, fmt.Errorf("scripts[%d].script: required", i))
+		} else if _, ok := names[script.Script]; ok {
+			errs = append(errs, fmt.Errorf("scripts[%d].script: duplicate script '%s'", i, script.Script))
+		}
+		names[script.Script] = struct{}{}
+
+		if script.Version <= 0 {
+			errs = append(errs, fmt.Errorf("scripts[%d].version: must be greater than zero", i))
+		}
+		if script.RolloutVersion == nil {
+			if script.RolloutPercent != 0 {
+				errs = append(errs, fmt.Errorf("scripts[%d].rolloutPercent: must be 0 if rolloutVersion isn't set", i))
+			}
+			continue
+		}
+		if *script.RolloutVersion <= 0 {
+			errs = append(errs, fmt.Errorf("scripts[%d].rolloutVersion: must be greater than zero", i))
+		} else if *script.RolloutVersion == script.Version {
+			errs = append(errs, fmt.Errorf("scripts[%d].rolloutVersion: must differ from version", i))
+		}
+		if script.RolloutPercent < 1 || script.RolloutPercent > 100 {
+			errs = append(errs, fmt.Errorf("scripts[%d].rolloutPercent: must be between 1 and 100 if rolloutVersion is set", i))
+		}
+	}
+	return util.JoinErrs(errs)
+}
+
+// TransformScriptsResponse is the type of a response from Traffic Ops to a GET
+// request to its /transform-scripts endpoint.
+type TransformScriptsResponse struct {
+	Response []TransformScript `json:"response"`
+	Alerts
+}
+
+// TransformScriptResponse is the type of a response from Traffic Ops to a
+// request to create a transformation script.
+type TransformScriptResponse struct {
+	Response TransformScript `json:"response"`
+	Alerts
+}
+
+// TransformScriptVersionsResponse is the type of a response from Traffic Ops
+// to a GET request to its /transform-scripts/{{ID}}/versions endpoint.
+type TransformScriptVersionsResponse struct {
+	Response []TransformScriptVersion `json:"response"`
+	Alerts
+}
+
+// TransformScriptVersionResponse is the type of a response from Traffic Ops
+// to a POST request to its /transform-scripts/{{ID}}/versions endpoint.
+type TransformScriptVersionResponse struct {
+	Response TransformScriptVersion `json:"response"`
+	Alerts
+}
+
+// DeliveryServiceTransformScriptsResponse is the type of a response from
+// Traffic Ops to a request to its /deliveryservices/{{ID}}/transform-scripts
+// endpoint.
+type DeliveryServiceTransformScriptsResponse struct {
+	Response DeliveryServiceTransformScripts `json:"response"`
+	Alerts
+}
+
+// CDNDeliveryServiceTransformScriptsResponse is the type of a response from
+// Traffic Ops to a GET request to its /deliveryservices/transform-scripts
+// endpoint.
+type CDNDeliveryServiceTransformScriptsResponse struct {
+	Response []DeliveryServiceTransformScripts `json:"response"`
+	Alerts
+}
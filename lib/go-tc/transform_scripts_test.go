@@ -0,0 +1,92 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestTransformScriptRequestValidate(t *testing.T) {
+	req := TransformScriptRequest{Name: "add-cors_headers", Language: TransformScriptLanguageLua, Content: "function do_remap()\n  return 0\nend\n"}
+	if err := req.Validate(nil); err != nil {
+		t.Fatalf("expected valid request, got error: %v", err)
+	}
+
+	invalid := map[string]TransformScriptRequest{
+		"no name":         {Language: TransformScriptLanguageLua, Content: "x"},
+		"name with slash": {Name: "../x", Language: TransformScriptLanguageLua, Content: "x"},
+		"wasm":            {Name: "x", Language: "wasm", Content: "x"},
+		"blank content":   {Name: "x", Language: TransformScriptLanguageLua, Content: " \n"},
+		"huge content":    {Name: "x", Language: TransformScriptLanguageLua, Content: strings.Repeat("-", MaxTransformScriptBytes+1)},
+		"NUL in content":  {Name: "x", Language: TransformScriptLanguageLua, Content: "a\x00b"},
+	}
+	for name, req := range invalid {
+		if err := req.Validate(nil); err == nil {
+			t.Errorf("expected request with %s to be invalid", name)
+		}
+	}
+}
+
+func TestDeliveryServiceTransformScriptsRequestValidate(t *testing.T) {
+	req := DeliveryServiceTransformScriptsRequest{
+		Scripts: []DeliveryServiceTransformScript{
+			{Script: "a", Version: 1},
+			{Script: "b", Version: 2, RolloutVersion: util.IntPtr(3), RolloutPercent: 10},
+		},
+	}
+	if err := req.Validate(nil); err != nil {
+		t.Fatalf("expected valid request, got error: %v", err)
+	}
+
+	req = DeliveryServiceTransformScriptsRequest{Scripts: []DeliveryServiceTransformScript{}}
+	if err := req.Validate(nil); err != nil {
+		t.Errorf("expected request without scripts to be valid, got error: %v", err)
+	}
+
+	req = DeliveryServiceTransformScriptsRequest{}
+	if err := req.Validate(nil); err == nil {
+		t.Error("expected request without a scripts property to be invalid")
+	}
+
+	invalid := map[string][]DeliveryServiceTransformScript{
+		"no script name":           {{Version: 1}},
+		"duplicate scripts":        {{Script: "a", Version: 1}, {Script: "a", Version: 2}},
+		"zero version":             {{Script: "a"}},
+		"percent without rollout":  {{Script: "a", Version: 1, RolloutPercent: 5}},
+		"rollout without percent":  {{Script: "a", Version: 1, RolloutVersion: util.IntPtr(2)}},
+		"rollout over 100 percent": {{Script: "a", Version: 1, RolloutVersion: util.IntPtr(2), RolloutPercent: 101}},
+		"rollout of same version":  {{Script: "a", Version: 1, RolloutVersion: util.IntPtr(1), RolloutPercent: 50}},
+	}
+	for name, scripts := range invalid {
+		req := DeliveryServiceTransformScriptsRequest{Scripts: scripts}
+		if err := req.Validate(nil); err == nil {
+			t.Errorf("expected request with %s to be invalid", name)
+		}
+	}
+}
+
+func TestTransformScriptSHA256(t *testing.T) {
+	if actual := TransformScriptSHA256(""); actual != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Errorf("incorrect digest of empty content: %s", actual)
+	}
+}
@@ -0,0 +1,86 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// DefaultImpersonationDurationMinutes is the number of minutes for which an
+// impersonation session lasts if its duration isn't given.
+const DefaultImpersonationDurationMinutes = 30
+
+// MaxImpersonationDurationMinutes is the greatest number of minutes for which
+// an impersonation session may last.
+const MaxImpersonationDurationMinutes = 60
+
+// UserImpersonationRequest is a request to impersonate a user.
+type UserImpersonationRequest struct {
+	// Username is the username of the user to impersonate.
+	Username string `json:"username"`
+	// DurationMinutes is the number of minutes for which the impersonation
+	// session lasts. If not given, it's
+	// DefaultImpersonationDurationMinutes.
+	DurationMinutes *int `json:"durationMinutes"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface.
+func (r *UserImpersonationRequest) Validate(*sql.Tx) error {
+	errs := []error{}
+	if strings.TrimSpace(r.Username) == "" {
+		errs = append(errs, errors.New("username: required"))
+	}
+	if r.DurationMinutes != nil && (*r.DurationMinutes < 1 || *r.DurationMinutes > MaxImpersonationDurationMinutes) {
+		errs = append(errs, errors.New("durationMinutes: must be between 1 and 60"))
+	}
+	return util.JoinErrs(errs)
+}
+
+// Duration returns the duration of the requested impersonation session.
+func (r UserImpersonationRequest) Duration() time.Duration {
+	if r.DurationMinutes == nil {
+		return DefaultImpersonationDurationMinutes * time.Minute
+	}
+	return time.Duration(*r.DurationMinutes) * time.Minute
+}
+
+// UserImpersonation is an impersonation session started by a user.
+type UserImpersonation struct {
+	// Username is the username of the impersonated user.
+	Username string `json:"username"`
+	// ImpersonatedBy is the username of the impersonating user.
+	ImpersonatedBy string `json:"impersonatedBy"`
+	// Expires is the time at which the session ends.
+	Expires time.Time `json:"expires"`
+}
+
+// UserImpersonationResponse is the type of a response from Traffic Ops to a
+// POST request to its /user/impersonate endpoint.
+type UserImpersonationResponse struct {
+	Response UserImpersonation `json:"response"`
+	Alerts
+}
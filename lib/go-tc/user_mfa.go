@@ -0,0 +1,287 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// These are the methods by which a user may give a second authentication
+// factor when logging in.
+const (
+	// MFAMethodTOTP is a code generated by a TOTP authenticator app.
+	MFAMethodTOTP = "totp"
+	// MFAMethodWebAuthn is an assertion by a WebAuthn security key.
+	MFAMethodWebAuthn = "webauthn"
+	// MFAMethodRecoveryCode is one of the user's single-use recovery codes.
+	MFAMethodRecoveryCode = "recoveryCode"
+)
+
+// WebAuthnCredential is a WebAuthn security key registered to a user.
+type WebAuthnCredential struct {
+	// ID is the integral, unique identifier of the credential in Traffic Ops.
+	ID int `json:"id"`
+	// Name is the name given to the credential by its user.
+	Name string `json:"name"`
+	// CredentialID is the base64url-encoded ID of the credential given by
+	// its authenticator.
+	CredentialID string `json:"credentialId"`
+	// SignCount is the signature counter last reported by the authenticator.
+	SignCount int64 `json:"signCount"`
+	// LastUpdated is the time at which the credential was last used or
+	// modified.
+	LastUpdated time.Time `json:"lastUpdated"`
+}
+
+// UserMFA is the state of a user's second authentication factors.
+type UserMFA struct {
+	// Enabled is whether or not the user must give a second factor to log in,
+	// which is the case if they have any confirmed TOTP secret or WebAuthn
+	// credential.
+	Enabled bool `json:"enabled"`
+	// Required is whether or not the user's Role must use a second factor.
+	Required bool `json:"required"`
+	// TOTP is whether or not the user has a confirmed TOTP secret.
+	TOTP bool `json:"totp"`
+	// TOTPPending is whether or not the user has a TOTP secret which is
+	// waiting to be confirmed.
+	TOTPPending bool `json:"totpPending"`
+	// RecoveryCodesRemaining is the number of the user's recovery codes which
+	// haven't been used.
+	RecoveryCodesRemaining int `json:"recoveryCodesRemaining"`
+	// WebAuthnCredentials are the user's WebAuthn security keys.
+	WebAuthnCredentials []WebAuthnCredential `json:"webauthnCredentials"`
+}
+
+// UserMFAResponse is the type of a response from Traffic Ops to a GET request
+// to its /user/mfa endpoint.
+type UserMFAResponse struct {
+	Response UserMFA `json:"response"`
+	Alerts
+}
+
+// TOTPEnrollment is a new TOTP secret, which must be confirmed before it can
+// be used to log in.
+type TOTPEnrollment struct {
+	// Secret is the base32-encoded secret.
+	Secret string `json:"secret"`
+	// URI is the otpauth:// URI of the secret, which authenticator apps can
+	// read from a QR code.
+	URI string `json:"uri"`
+}
+
+// TOTPEnrollmentResponse is the type of a response from Traffic Ops to a POST
+// request to its /user/mfa/totp endpoint.
+type TOTPEnrollmentResponse struct {
+	Response TOTPEnrollment `json:"response"`
+	Alerts
+}
+
+// TOTPConfirmationRequest is the type of a request to confirm a user's pending
+// TOTP secret.
+type TOTPConfirmationRequest struct {
+	// Code is a code generated from the pending secret.
+	Code string `json:"code"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface.
+func (r *TOTPConfirmationRequest) Validate(*sql.Tx) error {
+	if strings.TrimSpace(r.Code) == "" {
+		return errors.New("code: required")
+	}
+	return nil
+}
+
+// MFARecoveryCodes are a user's new recovery codes. They are only ever shown
+// once.
+type MFARecoveryCodes struct {
+	Codes []string `json:"codes"`
+}
+
+// MFARecoveryCodesResponse is the type of a response from Traffic Ops to a
+// request which generates new recovery codes.
+type MFARecoveryCodesResponse struct {
+	Response MFARecoveryCodes `json:"response"`
+	Alerts
+}
+
+// WebAuthnRegistrationOptions are the options with which a client creates a
+// new WebAuthn credential, i.e. the parts of a PublicKeyCredentialCreationOptions
+// chosen by Traffic Ops.
+type WebAuthnRegistrationOptions struct {
+	// Challenge is the base64url-encoded challenge, which is valid for
+	// Timeout milliseconds.
+	Challenge string `json:"challenge"`
+	// RPID is the ID of the WebAuthn Relying Party.
+	RPID string `json:"rpId"`
+	// RPName is the name of the WebAuthn Relying Party.
+	RPName string `json:"rpName"`
+	// UserID is the base64url-encoded WebAuthn user handle of the user.
+	UserID string `json:"userId"`
+	// UserName is the username of the user.
+	UserName string `json:"userName"`
+	// Algorithms are the COSE identifiers of the supported public key
+	// algorithms.
+	Algorithms []int `json:"algorithms"`
+	// ExcludeCredentials are the base64url-encoded IDs of the user's existing
+	// credentials.
+	ExcludeCredentials []string `json:"excludeCredentials"`
+	// Timeout is the time, in milliseconds, for which the challenge is valid.
+	Timeout int `json:"timeout"`
+}
+
+// WebAuthnRegistrationOptionsResponse is the type of a response from Traffic
+// Ops to a GET request to its /user/mfa/webauthn endpoint.
+type WebAuthnRegistrationOptionsResponse struct {
+	Response WebAuthnRegistrationOptions `json:"response"`
+	Alerts
+}
+
+// WebAuthnRegistrationRequest is the type of a request to register a new
+// WebAuthn credential, made from the response of a client's
+// navigator.credentials.create() call.
+type WebAuthnRegistrationRequest struct {
+	// Name is a name by which the user may tell the credential apart from
+	// their others.
+	Name string `json:"name"`
+	// ClientDataJSON is the base64url-encoded clientDataJSON of the
+	// credential's AuthenticatorAttestationResponse.
+	ClientDataJSON string `json:"clientDataJSON"`
+	// AttestationObject is the base64url-encoded attestationObject of the
+	// credential's AuthenticatorAttestationResponse.
+	AttestationObject string `json:"attestationObject"`
+}
+
+// Validate implements the
+// github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api.ParseValidator
+// interface.
+func (r *WebAuthnRegistrationRequest) Validate(*sql.Tx) error {
+	errs := []error{}
+	if strings.TrimSpace(r.Name) == "" {
+		errs = append(errs, errors.New("name: required"))
+	}
+	if r.ClientDataJSON == "" {
+		errs = append(errs, errors.New("clientDataJSON: required"))
+	}
+	if r.AttestationObject == "" {
+		errs = append(errs, errors.New("attestationObject: required"))
+	}
+	return util.JoinErrs(errs)
+}
+
+// WebAuthnCredentialResponse is the type of a response from Traffic Ops to a
+// request which registers a WebAuthn credential.
+type WebAuthnCredentialResponse struct {
+	Response WebAuthnCredential `json:"response"`
+	Alerts
+}
+
+// WebAuthnAssertion is a WebAuthn credential's response to a login challenge,
+// made from the response of a client's navigator.credentials.get() call.
+type WebAuthnAssertion struct {
+	// CredentialID is the base64url-encoded ID of the credential.
+	CredentialID string `json:"credentialId"`
+	// ClientDataJSON is the base64url-encoded clientDataJSON of the
+	// AuthenticatorAssertionResponse.
+	ClientDataJSON string `json:"clientDataJSON"`
+	// AuthenticatorData is the base64url-encoded authenticatorData of the
+	// AuthenticatorAssertionResponse.
+	AuthenticatorData string `json:"authenticatorData"`
+	// Signature is the base64url-encoded signature of the
+	// AuthenticatorAssertionResponse.
+	Signature string `json:"signature"`
+}
+
+// WebAuthnAssertionOptions are the options with which a client gets an
+// assertion from one of a user's WebAuthn credentials.
+type WebAuthnAssertionOptions struct {
+	// Challenge is the base64url-encoded challenge.
+	Challenge string `json:"challenge"`
+	// RPID is the ID of the WebAuthn Relying Party.
+	RPID string `json:"rpId"`
+	// AllowCredentials are the base64url-encoded IDs of the user's
+	// credentials.
+	AllowCredentials []string `json:"allowCredentials"`
+	// Timeout is the time, in milliseconds, for which the challenge is valid.
+	Timeout int `json:"timeout"`
+}
+
+// MFALoginChallenge is returned by Traffic Ops in place of a session when a
+// user who must give a second factor logs in with their password.
+type MFALoginChallenge struct {
+	// Token identifies the login attempt, and must be given back with the
+	// second factor.
+	Token string `json:"token"`
+	// Methods are the methods by which the user may give a second factor.
+	Methods []string `json:"methods"`
+	// WebAuthn are the options with which to get an assertion from one of
+	// the user's WebAuthn credentials, if they have any.
+	WebAuthn *WebAuthnAssertionOptions `json:"webauthn,omitempty"`
+}
+
+// MFALoginChallengeResponse is the type of a response from Traffic Ops to a
+// POST request to its /user/login endpoint by a user who must give a second
+// factor.
+type MFALoginChallengeResponse struct {
+	Response MFALoginChallenge `json:"response"`
+	Alerts
+}
+
+// MFALoginRequest is the type of a request to finish logging in with a second
+// factor. Exactly one of TOTP, RecoveryCode, and WebAuthn must be given.
+type MFALoginRequest struct {
+	// Token is the Token of the MFALoginChallenge.
+	Token string `json:"token"`
+	// TOTP is a code generated by the user's TOTP authenticator app.
+	TOTP *string `json:"totp"`
+	// RecoveryCode is one of the user's recovery codes.
+	RecoveryCode *string `json:"recoveryCode"`
+	// WebAuthn is an assertion by one of the user's WebAuthn credentials.
+	WebAuthn *WebAuthnAssertion `json:"webauthn"`
+}
+
+// Validate returns an error if the request isn't valid.
+func (r MFALoginRequest) Validate() error {
+	errs := []error{}
+	if r.Token == "" {
+		errs = append(errs, errors.New("token: required"))
+	}
+	given := 0
+	if r.TOTP != nil {
+		given++
+	}
+	if r.RecoveryCode != nil {
+		given++
+	}
+	if r.WebAuthn != nil {
+		given++
+	}
+	if given != 1 {
+		errs = append(errs, errors.New("exactly one of totp, recoveryCode, and webauthn is required"))
+	}
+	return util.JoinErrs(errs)
+}
@@ -0,0 +1,206 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/lib/pq"
+)
+
+// The headers of the requests Traffic Ops makes to Webhooks.
+const (
+	// WebhookSignatureHeader is the header with the signature of the
+	// request body; see WebhookSignature.
+	WebhookSignatureHeader = "X-Traffic-Ops-Signature"
+	// WebhookEventHeader is the header with the type and action of the
+	// change, e.g. "server.update".
+	WebhookEventHeader = "X-Traffic-Ops-Event"
+	// WebhookDeliveryHeader is the header with the sequence number of the
+	// change, which identifies it when it's delivered more than once.
+	WebhookDeliveryHeader = "X-Traffic-Ops-Delivery"
+)
+
+// webhookSignaturePrefix names the algorithm of Webhook signatures.
+const webhookSignaturePrefix = "sha256="
+
+// MinWebhookSecretLength is the length of the shortest secret a Webhook may
+// have.
+const MinWebhookSecretLength = 16
+
+// WebhookRequest encodes the request data for creating or modifying a
+// Webhook. Empty filters match every change.
+type WebhookRequest struct {
+	// Name uniquely identifies the Webhook.
+	Name string `json:"name"`
+	// URL is the HTTP or HTTPS URL to which changes are posted.
+	URL string `json:"url"`
+	// Secret is the key with which the changes posted to the Webhook are
+	// signed. It's required to create a Webhook; if it's nil in an update,
+	// the secret is left as it was.
+	Secret *string `json:"secret"`
+	// ObjectTypes are the types of the objects whose changes are posted,
+	// from ChangeObjectTypes.
+	ObjectTypes []string `json:"objectTypes"`
+	// CDNs are the names of the CDNs whose objects' changes are posted.
+	// Changes of objects that don't belong to CDNs don't match this filter
+	// unless it's empty.
+	CDNs []string `json:"cdns"`
+	// Actions are the actions - ChangeActionCreate, ChangeActionUpdate, or
+	// ChangeActionDelete - of the changes that are posted.
+	Actions []string `json:"actions"`
+	// Active is whether changes are posted to the Webhook. If nil, it's
+	// true.
+	Active *bool `json:"active"`
+}
+
+// Webhook is a URL to which Traffic Ops posts the changes matching its
+// filters, as WebhookPayloads signed with its secret. Changes are posted in
+// order; one that can't be delivered is retried with an exponential backoff
+// until it's been attempted MaxWebhookAttempts times, and then it's skipped.
+type Webhook struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	URL         string   `json:"url"`
+	ObjectTypes []string `json:"objectTypes"`
+	CDNs        []string `json:"cdns"`
+	Actions     []string `json:"actions"`
+	Active      bool     `json:"active"`
+	// LastSequence is the sequence number of the last change delivered to
+	// the Webhook, or skipped.
+	LastSequence int64 `json:"lastSequence"`
+	// FailedAttempts is the number of times delivering the next change
+	// has failed.
+	FailedAttempts int `json:"failedAttempts"`
+	// NextAttempt is when delivering the next change will be retried, if
+	// it's failed.
+	NextAttempt *time.Time `json:"nextAttempt"`
+	// LastError describes why the last failed delivery failed, if any has.
+	LastError *string `json:"lastError"`
+	// LastDelivered is when a change was last delivered, if one has been.
+	LastDelivered *time.Time `json:"lastDelivered"`
+	LastUpdated   time.Time  `json:"lastUpdated"`
+}
+
+// MaxWebhookAttempts is the number of times delivering a change to a Webhook
+// is attempted before it's skipped.
+const MaxWebhookAttempts = 10
+
+// WebhooksResponse is the type of a response from Traffic Ops to a GET
+// request made to its /webhooks API endpoint.
+type WebhooksResponse struct {
+	Response []Webhook `json:"response"`
+	Alerts
+}
+
+// WebhookResponse is the type of a response from Traffic Ops to a POST, PUT,
+// or DELETE request made to its /webhooks API endpoint.
+type WebhookResponse struct {
+	Response Webhook `json:"response"`
+	Alerts
+}
+
+// WebhookPayload is the body of the requests Traffic Ops makes to Webhooks.
+type WebhookPayload struct {
+	// Webhook is the name of the Webhook to which the change is posted.
+	Webhook string `json:"webhook"`
+	// Event is the change.
+	Event ChangeEvent `json:"event"`
+}
+
+// Validate validates that the WebhookRequest has a name and an absolute HTTP
+// or HTTPS URL, that its secret, if given, is long enough, and that its
+// filters are of known object types, actions, and CDNs. CDNs are only
+// checked if tx isn't nil.
+func (w *WebhookRequest) Validate(tx *sql.Tx) error {
+	errs := []error{}
+	if strings.TrimSpace(w.Name) == "" {
+		errs = append(errs, errors.New("name: cannot be blank"))
+	}
+	if u, err := url.Parse(w.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = append(errs, errors.New("url: must be an absolute HTTP or HTTPS URL"))
+	}
+	if w.Secret != nil && len(*w.Secret) < MinWebhookSecretLength {
+		errs = append(errs, fmt.Errorf("secret: must be at least %d characters long", MinWebhookSecretLength))
+	}
+	for _, t := range w.ObjectTypes {
+		if !util.ContainsStr(ChangeObjectTypes, t) {
+			errs = append(errs, fmt.Errorf("objectTypes: unknown object type '%s'; must be one of: %s", t, strings.Join(ChangeObjectTypes, ", ")))
+		}
+	}
+	for _, a := range w.Actions {
+		if a != ChangeActionCreate && a != ChangeActionUpdate && a != ChangeActionDelete {
+			errs = append(errs, fmt.Errorf("actions: unknown action '%s'; must be one of: %s, %s, %s", a, ChangeActionCreate, ChangeActionUpdate, ChangeActionDelete))
+		}
+	}
+	if tx != nil && len(w.CDNs) > 0 {
+		found := []string{}
+		if err := tx.QueryRow(`SELECT ARRAY(SELECT name FROM cdn WHERE name = ANY($1))`, pq.Array(w.CDNs)).Scan(pq.Array(&found)); err != nil {
+			return fmt.Errorf("querying CDNs: %w", err)
+		}
+		for _, cdn := range w.CDNs {
+			if !util.ContainsStr(found, cdn) {
+				errs = append(errs, fmt.Errorf("cdns: no CDN exists by name '%s'", cdn))
+			}
+		}
+	}
+	return util.JoinErrs(errs)
+}
+
+// Matches returns whether the change matches the Webhook's filters.
+func (w Webhook) Matches(e ChangeEvent) bool {
+	if len(w.ObjectTypes) > 0 && !util.ContainsStr(w.ObjectTypes, e.Type) {
+		return false
+	}
+	if len(w.Actions) > 0 && !util.ContainsStr(w.Actions, e.Action) {
+		return false
+	}
+	if len(w.CDNs) > 0 && (e.CDN == nil || !util.ContainsStr(w.CDNs, *e.CDN)) {
+		return false
+	}
+	return true
+}
+
+// WebhookSignature returns the signature of the given request body posted to
+// a Webhook with the given secret, as sent in its WebhookSignatureHeader:
+// "sha256=" followed by the hex-encoded HMAC-SHA256 of the body, keyed by the
+// secret.
+func WebhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return webhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature returns whether the signature is that of the given
+// request body posted to a Webhook with the given secret. Receivers of
+// Webhook requests should verify them before trusting them.
+func VerifyWebhookSignature(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(WebhookSignature(secret, body)), []byte(signature))
+}
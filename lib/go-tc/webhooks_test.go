@@ -0,0 +1,101 @@
+package tc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestWebhookRequestValidate(t *testing.T) {
+	valid := WebhookRequest{
+		Name:        "ops",
+		URL:         "https://hooks.example.com/trafficops",
+		Secret:      util.StrPtr("0123456789abcdef"),
+		ObjectTypes: []string{"server", "deliveryservice"},
+		Actions:     []string{ChangeActionCreate, ChangeActionDelete},
+	}
+	if err := valid.Validate(nil); err != nil {
+		t.Errorf("expected valid webhook to be valid, got: %v", err)
+	}
+
+	tests := map[string]func(w *WebhookRequest){
+		"blank name":       func(w *WebhookRequest) { w.Name = " " },
+		"relative URL":     func(w *WebhookRequest) { w.URL = "/trafficops" },
+		"non-HTTP URL":     func(w *WebhookRequest) { w.URL = "ftp://hooks.example.com" },
+		"short secret":     func(w *WebhookRequest) { w.Secret = util.StrPtr("secret") },
+		"unknown type":     func(w *WebhookRequest) { w.ObjectTypes = []string{"widget"} },
+		"unknown action":   func(w *WebhookRequest) { w.Actions = []string{"snapshot"} },
+		"uppercase action": func(w *WebhookRequest) { w.Actions = []string{"CREATE"} },
+	}
+	for name, invalidate := range tests {
+		w := valid
+		invalidate(&w)
+		if err := w.Validate(nil); err == nil {
+			t.Errorf("%s: expected an error, got: nil", name)
+		}
+	}
+}
+
+func TestWebhookMatches(t *testing.T) {
+	event := ChangeEvent{Type: "server", Action: ChangeActionUpdate, CDN: util.StrPtr("cdn1")}
+	noCDN := ChangeEvent{Type: "tenant", Action: ChangeActionUpdate}
+
+	tests := []struct {
+		name    string
+		webhook Webhook
+		event   ChangeEvent
+		matches bool
+	}{
+		{"no filters", Webhook{}, event, true},
+		{"no filters, no CDN", Webhook{}, noCDN, true},
+		{"matching type", Webhook{ObjectTypes: []string{"deliveryservice", "server"}}, event, true},
+		{"other type", Webhook{ObjectTypes: []string{"deliveryservice"}}, event, false},
+		{"matching action", Webhook{Actions: []string{ChangeActionUpdate}}, event, true},
+		{"other action", Webhook{Actions: []string{ChangeActionCreate, ChangeActionDelete}}, event, false},
+		{"matching CDN", Webhook{CDNs: []string{"cdn1"}}, event, true},
+		{"other CDN", Webhook{CDNs: []string{"cdn2"}}, event, false},
+		{"CDN filter, no CDN", Webhook{CDNs: []string{"cdn1"}}, noCDN, false},
+	}
+	for _, test := range tests {
+		if matches := test.webhook.Matches(test.event); matches != test.matches {
+			t.Errorf("%s: expected match to be %t, got: %t", test.name, test.matches, matches)
+		}
+	}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	body := []byte(`{"webhook":"ops"}`)
+	sig := WebhookSignature("0123456789abcdef", body)
+	// echo -n '{"webhook":"ops"}' | openssl dgst -sha256 -hmac 0123456789abcdef
+	if expected := "sha256=92083721a186a999693eb77254f605f4df072396bdf8b5f6923320003f9f1ccc"; sig != expected {
+		t.Errorf("expected signature '%s', got: '%s'", expected, sig)
+	}
+	if !VerifyWebhookSignature("0123456789abcdef", body, sig) {
+		t.Errorf("expected signature '%s' to be verified", sig)
+	}
+	if VerifyWebhookSignature("fedcba9876543210", body, sig) {
+		t.Error("expected signature not to be verified with another secret")
+	}
+	if VerifyWebhookSignature("0123456789abcdef", []byte(`{"webhook":"dev"}`), sig) {
+		t.Error("expected signature not to be verified for another body")
+	}
+}
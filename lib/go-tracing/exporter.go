@@ -0,0 +1,196 @@
+package tracing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+)
+
+// An Exporter sends ended spans to where they're collected.
+type Exporter interface {
+	Export(spans []*Span) error
+}
+
+func logExportError(err error) {
+	log.Errorf("exporting trace spans: %v", err)
+}
+
+// OTLPExporter exports spans to an OpenTelemetry collector with the OTLP/HTTP
+// protocol, JSON-encoded.
+type OTLPExporter struct {
+	// Endpoint is the URL of the collector's traces endpoint, e.g.
+	// http://collector.example:4318/v1/traces.
+	Endpoint string
+	// ServiceName is the "service.name" resource attribute of the spans.
+	ServiceName string
+	// Headers are added to the export requests, e.g. for authentication.
+	Headers map[string]string
+	Client  *http.Client
+}
+
+// DefaultOTLPTimeout is the timeout of the export requests of an OTLPExporter
+// created by NewOTLPExporter.
+const DefaultOTLPTimeout = 10 * time.Second
+
+// NewOTLPExporter returns an OTLPExporter which exports spans of the named
+// service to the given collector traces endpoint.
+func NewOTLPExporter(endpoint, serviceName string) *OTLPExporter {
+	return &OTLPExporter{
+		Endpoint:    endpoint,
+		ServiceName: serviceName,
+		Client:      &http.Client{Timeout: DefaultOTLPTimeout},
+	}
+}
+
+// Export implements Exporter.
+func (e *OTLPExporter) Export(spans []*Span) error {
+	body, err := json.Marshal(OTLPRequest(e.ServiceName, spans))
+	if err != nil {
+		return fmt.Errorf("encoding spans: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, e.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating export request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range e.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending %d spans to %s: %w", len(spans), e.Endpoint, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("sending %d spans to %s: collector returned %d: %s", len(spans), e.Endpoint, resp.StatusCode, msg)
+	}
+	return nil
+}
+
+// OTLPRequest returns the body of an OTLP/HTTP JSON request exporting the
+// given spans of the named service.
+func OTLPRequest(serviceName string, spans []*Span) map[string]interface{} {
+	otlpSpans := make([]map[string]interface{}, 0, len(spans))
+	for _, span := range spans {
+		otlpSpans = append(otlpSpans, otlpSpan(span))
+	}
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": otlpAttributes(map[string]string{"service.name": serviceName}),
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{"name": "github.com/apache/trafficcontrol/lib/go-tracing"},
+						"spans": otlpSpans,
+					},
+				},
+			},
+		},
+	}
+}
+
+// The OpenTelemetry status codes.
+const (
+	otlpStatusUnset = 0
+	otlpStatusError = 2
+)
+
+func otlpSpan(span *Span) map[string]interface{} {
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+	s := map[string]interface{}{
+		"traceId":           span.Context.TraceID.String(),
+		"spanId":            span.Context.SpanID.String(),
+		"name":              span.Name,
+		"kind":              int(span.Kind),
+		"startTimeUnixNano": strconv.FormatInt(span.Start.UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(span.End.UnixNano(), 10),
+		"attributes":        otlpAttributes(span.Attributes),
+		"status":            map[string]interface{}{"code": otlpStatusUnset},
+	}
+	if span.Parent.IsValid() {
+		s["parentSpanId"] = span.Parent.String()
+	}
+	if span.Error != "" {
+		s["status"] = map[string]interface{}{"code": otlpStatusError, "message": span.Error}
+	}
+	return s
+}
+
+func otlpAttributes(attributes map[string]string) []interface{} {
+	keys := make([]string, 0, len(attributes))
+	for k := range attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	otlpAttrs := make([]interface{}, 0, len(keys))
+	for _, k := range keys {
+		otlpAttrs = append(otlpAttrs, map[string]interface{}{
+			"key":   k,
+			"value": map[string]interface{}{"stringValue": attributes[k]},
+		})
+	}
+	return otlpAttrs
+}
+
+// LogExporter exports spans by writing them, as lines of OTLP JSON, to a
+// writer - e.g. a log file, for debugging or for a log shipper to collect.
+type LogExporter struct {
+	ServiceName string
+
+	mutex sync.Mutex
+	w     io.Writer
+}
+
+// NewLogExporter returns a LogExporter which writes spans of the named
+// service to w.
+func NewLogExporter(w io.Writer, serviceName string) *LogExporter {
+	return &LogExporter{ServiceName: serviceName, w: w}
+}
+
+// Export implements Exporter.
+func (e *LogExporter) Export(spans []*Span) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	for _, span := range spans {
+		line, err := json.Marshal(OTLPRequest(e.ServiceName, []*Span{span}))
+		if err != nil {
+			return fmt.Errorf("encoding span: %w", err)
+		}
+		if _, err := e.w.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("writing span: %w", err)
+		}
+	}
+	return nil
+}
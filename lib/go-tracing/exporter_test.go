@@ -0,0 +1,95 @@
+package tracing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOTLPExporter(t *testing.T) {
+	var body struct {
+		ResourceSpans []struct {
+			Resource struct {
+				Attributes []struct {
+					Key   string `json:"key"`
+					Value struct {
+						StringValue string `json:"stringValue"`
+					} `json:"value"`
+				} `json:"attributes"`
+			} `json:"resource"`
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceID      string `json:"traceId"`
+					SpanID       string `json:"spanId"`
+					ParentSpanID string `json:"parentSpanId"`
+					Name         string `json:"name"`
+					Kind         int    `json:"kind"`
+					Status       struct {
+						Code    int    `json:"code"`
+						Message string `json:"message"`
+					} `json:"status"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	tracer := NewTracer(NewOTLPExporter(srv.URL+"/v1/traces", "traffic_ops"), 1)
+	ctx, parent := tracer.Start(context.Background(), "GET servers", SpanKindServer)
+	_, child := tracer.Start(ctx, "SQL SELECT", SpanKindClient)
+	child.SetError(context.DeadlineExceeded)
+	child.Finish()
+	parent.Finish()
+	tracer.Close()
+
+	if len(body.ResourceSpans) != 1 || len(body.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("expected one resource and scope, actual: %+v", body)
+	}
+	attrs := body.ResourceSpans[0].Resource.Attributes
+	if len(attrs) != 1 || attrs[0].Key != "service.name" || attrs[0].Value.StringValue != "traffic_ops" {
+		t.Errorf("expected the service name resource attribute, actual: %+v", attrs)
+	}
+	spans := body.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, actual: %+v", spans)
+	}
+	if spans[0].Name != "SQL SELECT" || spans[0].Kind != int(SpanKindClient) || spans[0].ParentSpanID != parent.Context.SpanID.String() || spans[0].TraceID != parent.Context.TraceID.String() {
+		t.Errorf("expected the child span, actual: %+v", spans[0])
+	}
+	if spans[0].Status.Code != otlpStatusError || spans[0].Status.Message != context.DeadlineExceeded.Error() {
+		t.Errorf("expected the child span to have an error status, actual: %+v", spans[0].Status)
+	}
+	if spans[1].SpanID != parent.Context.SpanID.String() || spans[1].ParentSpanID != "" || spans[1].Status.Code != otlpStatusUnset {
+		t.Errorf("expected the root span, actual: %+v", spans[1])
+	}
+}
@@ -0,0 +1,223 @@
+package tracing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+)
+
+// NewConnector returns a driver.Connector which connects with base, and
+// records a client span with the given Tracer for each query, statement
+// execution, and transaction.
+//
+// A query's parent is the span in its context or, for queries in a
+// transaction, that transaction's span - so the queries of a transaction
+// begun with a request's context are part of its trace, even though
+// database/sql doesn't pass that context to them.
+func NewConnector(base driver.Connector, t *Tracer) driver.Connector {
+	return connector{base: base, tracer: t}
+}
+
+type connector struct {
+	base   driver.Connector
+	tracer *Tracer
+}
+
+func (c connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.base.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cc, ok := conn.(ctxConn)
+	if !ok {
+		log.Warnf("database driver connection %T doesn't support contexts, queries will not be traced", conn)
+		return conn, nil
+	}
+	return &tracingConn{ctxConn: cc, tracer: c.tracer}, nil
+}
+
+func (c connector) Driver() driver.Driver {
+	return c.base.Driver()
+}
+
+// ctxConn is a driver.Conn supporting contexts, as the PostgreSQL driver's
+// connections do.
+type ctxConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.QueryerContext
+	driver.ExecerContext
+	driver.Pinger
+}
+
+// tracingConn is a connection which records spans of its queries.
+//
+// The database/sql package never uses a connection concurrently, so it needs
+// no locking.
+type tracingConn struct {
+	ctxConn
+	tracer *Tracer
+	// txSpan is the span of the transaction using the connection, if any.
+	txSpan *Span
+}
+
+// start starts the span of a database operation with the given context.
+func (c *tracingConn) start(ctx context.Context, name, query string) *Span {
+	if SpanFromContext(ctx) == nil && c.txSpan != nil {
+		ctx = ContextWithSpan(ctx, c.txSpan)
+	}
+	if _, ok := SpanContextFromContext(ctx); !ok {
+		// Queries outside any request - e.g. of background jobs - would
+		// each be a trace of their own.
+		return nil
+	}
+	_, span := c.tracer.Start(ctx, name, SpanKindClient)
+	span.SetAttribute("db.system", "postgresql")
+	if query != "" {
+		span.SetAttribute("db.statement", query)
+	}
+	return span
+}
+
+// queryName returns the name of the span of the given query, from its
+// command, e.g. "SELECT".
+func queryName(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "SQL"
+	}
+	cmd := strings.ToUpper(fields[0])
+	if cmd == "WITH" {
+		// A common table expression doesn't say what the query does,
+		// without parsing it.
+		return "SQL"
+	}
+	return "SQL " + cmd
+}
+
+func (c *tracingConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	span := c.start(ctx, "SQL transaction", "")
+	tx, err := c.ctxConn.BeginTx(ctx, opts)
+	if err != nil {
+		span.SetError(err)
+		span.Finish()
+		return nil, err
+	}
+	c.txSpan = span
+	return &tracingTx{Tx: tx, conn: c}, nil
+}
+
+func (c *tracingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	span := c.start(ctx, queryName(query), query)
+	rows, err := c.ctxConn.QueryContext(ctx, query, args)
+	if err == driver.ErrSkip {
+		// database/sql will prepare the query instead, so the span of
+		// the attempt is dropped unfinished.
+		return nil, err
+	}
+	span.SetError(err)
+	span.Finish()
+	return rows, err
+}
+
+func (c *tracingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	span := c.start(ctx, queryName(query), query)
+	res, err := c.ctxConn.ExecContext(ctx, query, args)
+	if err == driver.ErrSkip {
+		// database/sql will prepare the query instead, so the span of
+		// the attempt is dropped unfinished.
+		return nil, err
+	}
+	span.SetError(err)
+	span.Finish()
+	return res, err
+}
+
+func (c *tracingConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.ctxConn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if cs, ok := stmt.(ctxStmt); ok {
+		return &tracingStmt{ctxStmt: cs, conn: c, query: query}, nil
+	}
+	return stmt, nil
+}
+
+// tracingTx is a transaction which ends its span when it ends.
+type tracingTx struct {
+	driver.Tx
+	conn *tracingConn
+}
+
+func (t *tracingTx) Commit() error {
+	return t.end(t.Tx.Commit, "commit")
+}
+
+func (t *tracingTx) Rollback() error {
+	return t.end(t.Tx.Rollback, "rollback")
+}
+
+func (t *tracingTx) end(f func() error, outcome string) error {
+	err := f()
+	span := t.conn.txSpan
+	span.SetAttribute("db.transaction.outcome", outcome)
+	span.SetError(err)
+	span.Finish()
+	t.conn.txSpan = nil
+	return err
+}
+
+// ctxStmt is a driver.Stmt supporting contexts, as the PostgreSQL driver's
+// statements do.
+type ctxStmt interface {
+	driver.Stmt
+	driver.StmtQueryContext
+	driver.StmtExecContext
+}
+
+// tracingStmt is a prepared statement which records spans of its
+// executions.
+type tracingStmt struct {
+	ctxStmt
+	conn  *tracingConn
+	query string
+}
+
+func (s *tracingStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	span := s.conn.start(ctx, queryName(s.query), s.query)
+	rows, err := s.ctxStmt.QueryContext(ctx, args)
+	span.SetError(err)
+	span.Finish()
+	return rows, err
+}
+
+func (s *tracingStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	span := s.conn.start(ctx, queryName(s.query), s.query)
+	res, err := s.ctxStmt.ExecContext(ctx, args)
+	span.SetError(err)
+	span.Finish()
+	return res, err
+}
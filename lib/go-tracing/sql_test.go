@@ -0,0 +1,119 @@
+package tracing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+)
+
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn{}, nil }
+func (fakeConnector) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{}, nil }
+func (fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+func (fakeConn) PrepareContext(context.Context, string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+func (fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &fakeRows{}, nil
+}
+func (fakeConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+func (fakeConn) Ping(context.Context) error { return nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct{}
+
+func (*fakeRows) Columns() []string              { return []string{"id"} }
+func (*fakeRows) Close() error                   { return nil }
+func (*fakeRows) Next(dest []driver.Value) error { return io.EOF }
+
+func TestConnector(t *testing.T) {
+	rec := &recorder{}
+	tracer := NewTracer(rec, 1)
+	db := sql.OpenDB(NewConnector(fakeConnector{}, tracer))
+	defer db.Close()
+
+	// Queries outside a trace aren't traced.
+	if _, err := db.Exec("UPDATE job SET status = 'done'"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, request := tracer.Start(context.Background(), "GET servers", SpanKindServer)
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("unexpected error beginning a transaction: %v", err)
+	}
+	// database/sql doesn't pass the transaction's context to its queries.
+	rows, err := tx.Query("SELECT id FROM server")
+	if err != nil {
+		t.Fatalf("unexpected error querying: %v", err)
+	}
+	rows.Close()
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("unexpected error committing: %v", err)
+	}
+	request.Finish()
+	tracer.Close()
+
+	if len(rec.spans) != 3 {
+		t.Fatalf("expected the query, transaction, and request spans, actual: %v", rec.spans)
+	}
+	query, txn := rec.spans[0], rec.spans[1]
+	if query.Name != "SQL SELECT" || query.Attributes["db.statement"] != "SELECT id FROM server" || query.Kind != SpanKindClient {
+		t.Errorf("expected a span of the query, actual: %+v", query)
+	}
+	if query.Parent != txn.Context.SpanID {
+		t.Errorf("expected the query span to be a child of the transaction span")
+	}
+	if txn.Name != "SQL transaction" || txn.Parent != request.Context.SpanID || txn.Attributes["db.transaction.outcome"] != "commit" {
+		t.Errorf("expected a committed transaction span which is a child of the request span, actual: %+v", txn)
+	}
+}
+
+func TestQueryName(t *testing.T) {
+	for query, expected := range map[string]string{
+		"SELECT 1":                    "SQL SELECT",
+		"\n\tinsert into server (id)": "SQL INSERT",
+		"WITH x AS (SELECT 1) SELECT": "SQL",
+		"":                            "SQL",
+	} {
+		if actual := queryName(query); actual != expected {
+			t.Errorf("expected the span of %q to be named %q, actual: %q", query, expected, actual)
+		}
+	}
+}
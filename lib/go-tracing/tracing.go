@@ -0,0 +1,414 @@
+// Package tracing records spans of the work done for requests to Traffic
+// Control components, propagated between them by W3C Trace Context
+// traceparent headers, and exports them to OpenTelemetry collectors.
+package tracing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TraceParentHeader is the W3C Trace Context header which carries the trace
+// and span a request is part of.
+const TraceParentHeader = "traceparent"
+
+// A TraceID identifies a trace - all the spans of the work done for a single
+// original request.
+type TraceID [16]byte
+
+// String returns the trace ID in lowercase hexadecimal.
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// IsValid returns whether the trace ID is not all zeroes.
+func (t TraceID) IsValid() bool {
+	return t != TraceID{}
+}
+
+// A SpanID identifies a span within its trace.
+type SpanID [8]byte
+
+// String returns the span ID in lowercase hexadecimal.
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// IsValid returns whether the span ID is not all zeroes.
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+// SpanContext is the identity of a span which is propagated to the work it
+// causes, including in other processes.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	// Sampled is whether the trace is recorded.
+	Sampled bool
+}
+
+// IsValid returns whether both the trace and span IDs are valid.
+func (c SpanContext) IsValid() bool {
+	return c.TraceID.IsValid() && c.SpanID.IsValid()
+}
+
+// TraceParent returns the value of the traceparent header which propagates
+// the span context.
+func (c SpanContext) TraceParent() string {
+	flags := "00"
+	if c.Sampled {
+		flags = "01"
+	}
+	return "00-" + c.TraceID.String() + "-" + c.SpanID.String() + "-" + flags
+}
+
+// ParseTraceParent parses the value of a traceparent header. Versions after
+// 00 are accepted, as the specification requires, as long as they begin
+// with the fields of version 00.
+func ParseTraceParent(s string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(s), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || len(parts[1]) != 32 || len(parts[2]) != 16 || len(parts[3]) != 2 {
+		return SpanContext{}, errors.New("malformed traceparent")
+	}
+	if parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return SpanContext{}, errors.New("unsupported traceparent version " + parts[0])
+	}
+	c := SpanContext{}
+	if _, err := hex.Decode(c.TraceID[:], []byte(parts[1])); err != nil {
+		return SpanContext{}, errors.New("malformed traceparent trace ID")
+	}
+	if _, err := hex.Decode(c.SpanID[:], []byte(parts[2])); err != nil {
+		return SpanContext{}, errors.New("malformed traceparent parent ID")
+	}
+	flags, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return SpanContext{}, errors.New("malformed traceparent flags")
+	}
+	if !c.IsValid() {
+		return SpanContext{}, errors.New("traceparent has an all-zero trace or parent ID")
+	}
+	c.Sampled = flags[0]&1 == 1
+	return c, nil
+}
+
+// Extract returns the span context propagated by the traceparent header of
+// the given request headers, if it has a valid one.
+func Extract(header http.Header) (SpanContext, bool) {
+	c, err := ParseTraceParent(header.Get(TraceParentHeader))
+	return c, err == nil
+}
+
+// Inject sets the traceparent header of the given request headers to
+// propagate the span context of ctx, if it has one.
+func Inject(ctx context.Context, header http.Header) {
+	if c, ok := SpanContextFromContext(ctx); ok {
+		header.Set(TraceParentHeader, c.TraceParent())
+	}
+}
+
+// SpanKind is the relationship of a span to the others in its trace, as
+// defined by OpenTelemetry.
+type SpanKind int
+
+// The kinds of spans, with the values of their OpenTelemetry enumeration.
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+	SpanKindClient   SpanKind = 3
+)
+
+// A Span is a timed piece of work done for a trace. Its methods may be
+// called on a nil Span, and do nothing, so code need not check whether it's
+// traced.
+type Span struct {
+	Name    string
+	Kind    SpanKind
+	Context SpanContext
+	// Parent is the ID of the parent span, which is invalid for the root
+	// span of a trace.
+	Parent SpanID
+	Start  time.Time
+	End    time.Time
+	// Attributes describe the work, by the OpenTelemetry semantic
+	// conventions where they apply.
+	Attributes map[string]string
+	// Error is the error the work failed with, if it failed.
+	Error string
+
+	tracer *Tracer
+	mutex  sync.Mutex
+	ended  bool
+}
+
+// SetAttribute sets the attribute with the given key.
+func (s *Span) SetAttribute(key, value string) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.Attributes[key] = value
+}
+
+// SetError marks the span as failed with err, if err is not nil.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.Error = err.Error()
+}
+
+// Finish ends the span, and exports it if its trace is sampled. Only the
+// first call has any effect.
+func (s *Span) Finish() {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	if s.ended {
+		s.mutex.Unlock()
+		return
+	}
+	s.ended = true
+	s.End = time.Now()
+	s.mutex.Unlock()
+	if s.Context.Sampled {
+		s.tracer.export(s)
+	}
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpan returns a context carrying span, which becomes the parent
+// of spans started with it.
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, span)
+}
+
+// SpanFromContext returns the span carried by ctx, or nil if it has none.
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanContextKey{}).(*Span)
+	return span
+}
+
+type remoteContextKey struct{}
+
+// ContextWithRemoteParent returns a context carrying the span context of a
+// span in another process - e.g. one given by Extract - which becomes the
+// parent of spans started with it.
+func ContextWithRemoteParent(ctx context.Context, parent SpanContext) context.Context {
+	return context.WithValue(ctx, remoteContextKey{}, parent)
+}
+
+// SpanContextFromContext returns the span context of the span, or remote
+// parent, carried by ctx, if it has one.
+func SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.Context, true
+	}
+	c, ok := ctx.Value(remoteContextKey{}).(SpanContext)
+	return c, ok
+}
+
+// A Tracer starts spans, and exports the spans of sampled traces in batches.
+type Tracer struct {
+	exporter    Exporter
+	sampleRatio float64
+
+	spans  chan *Span
+	closed chan struct{}
+	done   chan struct{}
+	once   sync.Once
+}
+
+// Batching defaults of a Tracer.
+const (
+	// MaxBatchSpans is the most spans a Tracer exports at once.
+	MaxBatchSpans = 512
+	// BatchInterval is the longest a Tracer waits to export a span.
+	BatchInterval = 5 * time.Second
+	// MaxQueuedSpans is the most spans a Tracer queues for export, beyond
+	// which spans are dropped.
+	MaxQueuedSpans = 4096
+)
+
+// NewTracer returns a Tracer which exports spans with exporter, and samples
+// the given ratio, between 0 and 1, of the traces it starts. Traces
+// continued from other processes are sampled if their parents were.
+//
+// The Tracer must be closed to export its last spans.
+func NewTracer(exporter Exporter, sampleRatio float64) *Tracer {
+	t := &Tracer{
+		exporter:    exporter,
+		sampleRatio: math.Max(0, math.Min(1, sampleRatio)),
+		spans:       make(chan *Span, MaxQueuedSpans),
+		closed:      make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	go t.run()
+	return t
+}
+
+// Start starts a span with the given name and kind, whose parent is the span
+// or remote parent carried by ctx, if any, and returns it and a context
+// carrying it. If t is nil, ctx and a nil span are returned.
+func (t *Tracer) Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	if t == nil {
+		return ctx, nil
+	}
+	span := &Span{
+		Name:       name,
+		Kind:       kind,
+		Start:      time.Now(),
+		Attributes: map[string]string{},
+		tracer:     t,
+	}
+	if parent, ok := SpanContextFromContext(ctx); ok {
+		span.Context.TraceID = parent.TraceID
+		span.Context.Sampled = parent.Sampled
+		span.Parent = parent.SpanID
+	} else {
+		randomBytes(span.Context.TraceID[:])
+		span.Context.Sampled = t.sample(span.Context.TraceID)
+	}
+	randomBytes(span.Context.SpanID[:])
+	return ContextWithSpan(ctx, span), span
+}
+
+// sample returns whether to sample a new trace, from the last 8 bytes of its
+// random ID, as OpenTelemetry's ratio sampler does.
+func (t *Tracer) sample(id TraceID) bool {
+	if t.sampleRatio >= 1 {
+		return true
+	}
+	n := uint64(0)
+	for _, b := range id[8:] {
+		n = n<<8 | uint64(b)
+	}
+	return float64(n>>1) < t.sampleRatio*float64(uint64(1)<<63)
+}
+
+func randomBytes(b []byte) {
+	if _, err := rand.Read(b); err != nil {
+		// crypto/rand never fails on supported platforms, but an all-zero
+		// ID would be invalid.
+		for i := range b {
+			b[i] = byte(time.Now().UnixNano() >> (8 * (i % 8)))
+		}
+	}
+}
+
+// export queues span for export, or drops it if the queue is full.
+func (t *Tracer) export(span *Span) {
+	select {
+	case <-t.closed:
+	case t.spans <- span:
+	default:
+	}
+}
+
+func (t *Tracer) run() {
+	defer close(t.done)
+	ticker := time.NewTicker(BatchInterval)
+	defer ticker.Stop()
+	batch := make([]*Span, 0, MaxBatchSpans)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := t.exporter.Export(batch); err != nil {
+			logExportError(err)
+		}
+		batch = make([]*Span, 0, MaxBatchSpans)
+	}
+	for {
+		select {
+		case span := <-t.spans:
+			batch = append(batch, span)
+			if len(batch) >= MaxBatchSpans {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-t.closed:
+			for {
+				select {
+				case span := <-t.spans:
+					batch = append(batch, span)
+					if len(batch) >= MaxBatchSpans {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// Close exports the spans the Tracer has queued, and stops it. Spans ended
+// afterward are dropped.
+func (t *Tracer) Close() error {
+	if t == nil {
+		return nil
+	}
+	t.once.Do(func() { close(t.closed) })
+	<-t.done
+	return nil
+}
+
+var defaultTracer struct {
+	sync.RWMutex
+	tracer *Tracer
+}
+
+// SetDefault sets the Tracer returned by Default, which may be nil to stop
+// tracing.
+func SetDefault(t *Tracer) {
+	defaultTracer.Lock()
+	defer defaultTracer.Unlock()
+	defaultTracer.tracer = t
+}
+
+// Default returns the application's Tracer, which is nil unless it was set
+// by SetDefault.
+func Default() *Tracer {
+	defaultTracer.RLock()
+	defer defaultTracer.RUnlock()
+	return defaultTracer.tracer
+}
+
+// Start starts a span with the Default Tracer - see Tracer.Start.
+func Start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	return Default().Start(ctx, name, kind)
+}
@@ -0,0 +1,138 @@
+package tracing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// recorder is an Exporter which keeps the spans it exports.
+type recorder struct {
+	mutex sync.Mutex
+	spans []*Span
+}
+
+func (r *recorder) Export(spans []*Span) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.spans = append(r.spans, spans...)
+	return nil
+}
+
+func TestParseTraceParent(t *testing.T) {
+	const tp = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	c, err := ParseTraceParent(tp)
+	if err != nil {
+		t.Fatalf("unexpected error parsing %q: %v", tp, err)
+	}
+	if c.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || c.SpanID.String() != "00f067aa0ba902b7" || !c.Sampled {
+		t.Errorf("expected the trace, span, and sampled flag of %q, actual: %+v", tp, c)
+	}
+	if c.TraceParent() != tp {
+		t.Errorf("expected the span context to format as %q, actual: %q", tp, c.TraceParent())
+	}
+
+	for _, invalid := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"00-4bf92f3577b34da6a3ce929d0e0e473g-00f067aa0ba902b7-01",
+	} {
+		if _, err := ParseTraceParent(invalid); err == nil {
+			t.Errorf("expected an error parsing %q", invalid)
+		}
+	}
+	if _, err := ParseTraceParent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-extra"); err != nil {
+		t.Errorf("expected a later version with extra fields to be accepted, got: %v", err)
+	}
+}
+
+func TestTracer(t *testing.T) {
+	rec := &recorder{}
+	tracer := NewTracer(rec, 1)
+
+	header := http.Header{}
+	header.Set(TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	remote, ok := Extract(header)
+	if !ok {
+		t.Fatal("expected to extract the remote parent")
+	}
+	ctx, server := tracer.Start(ContextWithRemoteParent(context.Background(), remote), "server", SpanKindServer)
+	childCtx, child := tracer.Start(ctx, "child", SpanKindInternal)
+	out := http.Header{}
+	Inject(childCtx, out)
+	child.Finish()
+	child.Finish()
+	server.Finish()
+	tracer.Close()
+
+	if server.Context.TraceID != remote.TraceID || server.Parent != remote.SpanID {
+		t.Errorf("expected the server span to continue the remote trace, actual: %+v", server.Context)
+	}
+	if child.Context.TraceID != remote.TraceID || child.Parent != server.Context.SpanID {
+		t.Errorf("expected the child span to be a child of the server span, actual parent: %s", child.Parent)
+	}
+	if out.Get(TraceParentHeader) != child.Context.TraceParent() {
+		t.Errorf("expected the child span to be injected, actual: %q", out.Get(TraceParentHeader))
+	}
+	if len(rec.spans) != 2 || rec.spans[0] != child || rec.spans[1] != server {
+		t.Errorf("expected each span to be exported once when it ended, actual: %v", rec.spans)
+	}
+}
+
+func TestTracerSampling(t *testing.T) {
+	rec := &recorder{}
+	tracer := NewTracer(rec, 0)
+	ctx, root := tracer.Start(context.Background(), "root", SpanKindServer)
+	_, child := tracer.Start(ctx, "child", SpanKindInternal)
+	if root.Context.Sampled || child.Context.Sampled {
+		t.Error("expected no traces to be sampled with a ratio of 0")
+	}
+	child.Finish()
+	root.Finish()
+
+	// A sampled remote parent is respected regardless of the ratio.
+	remote := SpanContext{TraceID: TraceID{1}, SpanID: SpanID{1}, Sampled: true}
+	_, span := tracer.Start(ContextWithRemoteParent(context.Background(), remote), "server", SpanKindServer)
+	span.Finish()
+	tracer.Close()
+	if len(rec.spans) != 1 || rec.spans[0] != span {
+		t.Errorf("expected only the span of the sampled trace to be exported, actual: %v", rec.spans)
+	}
+}
+
+func TestNilTracer(t *testing.T) {
+	var tracer *Tracer
+	ctx, span := tracer.Start(context.Background(), "span", SpanKindInternal)
+	if span != nil || ctx != context.Background() {
+		t.Error("expected a nil Tracer to start no span")
+	}
+	span.SetAttribute("key", "value")
+	span.Finish()
+	if err := tracer.Close(); err != nil {
+		t.Errorf("unexpected error closing a nil Tracer: %v", err)
+	}
+}
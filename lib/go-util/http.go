@@ -20,6 +20,9 @@ package util
  */
 
 import (
+	"bufio"
+	"errors"
+	"net"
 	"net/http"
 )
 
@@ -56,6 +59,29 @@ func (i *Interceptor) Header() http.Header {
 	return i.W.Header()
 }
 
+// Flush implements http.Flusher, flushing Interceptor's internal
+// ResponseWriter if it supports flushing.
+func (i *Interceptor) Flush() {
+	if f, ok := i.W.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker, hijacking Interceptor's internal
+// ResponseWriter if it supports hijacking. The tracked code becomes 101
+// Switching Protocols, because hijacking is used to switch protocols.
+func (i *Interceptor) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := i.W.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	conn, brw, err := hj.Hijack()
+	if err == nil {
+		i.Code = http.StatusSwitchingProtocols
+	}
+	return conn, brw, err
+}
+
 // BodyInterceptor fulfills the Writer interface, but records the body and doesn't actually write. This allows performing operations on the entire body written by a handler, for example, compressing or hashing. To actually write, call `RealWrite()`. Note this means `len(b)` and `nil` are always returned by `Write()`, any real write errors will be returned by `RealWrite()`.
 type BodyInterceptor struct {
 	W         http.ResponseWriter
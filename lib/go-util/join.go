@@ -20,7 +20,6 @@ package util
  */
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
 )
@@ -48,26 +47,48 @@ func JoinErrs(errs []error) error {
 	return JoinErrsSep(errs, "")
 }
 
+// JoinedErrors is an error made up of other errors, as returned by JoinErrs
+// and JoinErrsSep. Its message is the messages of its errors joined by its
+// separator, but unlike that message the errors themselves - and so any
+// additional information they carry - remain available through Errors.
+type JoinedErrors struct {
+	errs      []error
+	separator string
+}
+
+// Error implements the error interface.
+func (e JoinedErrors) Error() string {
+	msgs := make([]string, 0, len(e.errs))
+	for _, err := range e.errs {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, e.separator)
+}
+
+// Errors returns the errors that were joined.
+func (e JoinedErrors) Errors() []error {
+	return e.errs
+}
+
+// JoinErrsSep joins the non-nil errors in errs with the given separator -
+// which defaults to ", " if empty - into a JoinedErrors. If there are no
+// non-nil errors, it returns nil.
 func JoinErrsSep(errs []error, separator string) error {
 	if separator == "" {
 		separator = ", "
 	}
 
-	joinedErrors := ""
-
+	joined := JoinedErrors{separator: separator}
 	for _, err := range errs {
 		if err != nil {
-			joinedErrors += err.Error() + separator
+			joined.errs = append(joined.errs, err)
 		}
 	}
 
-	if len(joinedErrors) == 0 {
+	if len(joined.errs) == 0 {
 		return nil
 	}
-
-	joinedErrors = joinedErrors[:len(joinedErrors)-len(separator)] // strip trailing separator
-
-	return fmt.Errorf("%s", joinedErrors)
+	return joined
 }
 
 func CamelToSnakeCase(s string) string {
@@ -199,21 +199,8 @@ func GetConfig() (Cfg, error, bool) {
 		return Cfg{}, errors.New("initializing loggers: " + err.Error() + "\n"), false
 	}
 
-	cf := util.ConfigFile{
-		Filename:       configFile,
-		LastModifyTime: 0,
-	}
-
-	cfg := Cfg{
-		HealthClientConfigFile: cf,
-		CredentialFile:         util.ConfigFile{},
-	}
-
-	if _, err = LoadConfig(&cfg); err != nil {
-		return Cfg{}, errors.New(err.Error() + "\n"), false
-	}
-
-	if err = ReadCredentials(&cfg, false); err != nil {
+	cfg, err := ReadConfig(configFile)
+	if err != nil {
 		return cfg, err, false
 	}
 
@@ -228,6 +215,28 @@ func GetConfig() (Cfg, error, bool) {
 	return cfg, nil, false
 }
 
+// ReadConfig reads the config file at the given path, and the credentials it
+// names. Callers should then get the Traffic Monitors with
+// GetTrafficMonitors.
+func ReadConfig(configFile string) (Cfg, error) {
+	cfg := Cfg{
+		HealthClientConfigFile: util.ConfigFile{
+			Filename:       configFile,
+			LastModifyTime: 0,
+		},
+		CredentialFile: util.ConfigFile{},
+	}
+
+	if _, err := LoadConfig(&cfg); err != nil {
+		return Cfg{}, errors.New(err.Error() + "\n")
+	}
+
+	if err := ReadCredentials(&cfg, false); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
 func GetTrafficMonitors(cfg *Cfg) error {
 	qry := &url.Values{}
 	qry.Add("type", "RASCAL")
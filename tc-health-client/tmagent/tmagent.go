@@ -33,6 +33,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-log"
@@ -71,6 +72,11 @@ type ParentInfo struct {
 	TrafficServerConfigDir string
 	Parents                map[string]ParentStatus
 	Cfg                    config.Cfg
+	// Lock, if not nil, is held while the parents are read from the
+	// trafficserver config and marked, so that whatever else manages
+	// trafficserver, such as the t3c-agent running t3c, doesn't change its
+	// config meanwhile.
+	Lock sync.Locker
 }
 
 // when reading the 'strategies.yaml', these fields are used to help
@@ -316,12 +322,15 @@ func (c *ParentInfo) PollAndUpdateCacheStatus() {
 		// check for parent and strategies config file updates, and trafficserver
 		// host status changes.  If an error is encountered reading data the current
 		// parents lists and hoststatus remains unchanged.
+		c.lock()
 		if err := c.UpdateParentInfo(); err != nil {
 			log.Errorf("could not load new ATS parent info: %s\n", err.Error())
 		} else {
 			log.Debugf("updated parent info, total number of parents: %d\n", len(c.Parents))
 		}
 
+		c.unlock()
+
 		// read traffic manager cache statuses.
 		_c, err := c.GetCacheStatuses()
 
@@ -349,6 +358,7 @@ func (c *ParentInfo) PollAndUpdateCacheStatus() {
 			continue
 		}
 
+		c.lock()
 		for k, v := range caches {
 			hostName := string(k)
 			cs, ok := c.Parents[hostName]
@@ -379,6 +389,8 @@ func (c *ParentInfo) PollAndUpdateCacheStatus() {
 			}
 		}
 
+		c.unlock()
+
 		// periodically update the TrafficMonitor list and statuses
 		if toLoginDispersion <= 0 {
 			toLoginDispersion = config.GetTOLoginDispersion(c.Cfg.TOLoginDispersionFactor)
@@ -403,6 +415,18 @@ func (c *ParentInfo) PollAndUpdateCacheStatus() {
 	}
 }
 
+func (c *ParentInfo) lock() {
+	if c.Lock != nil {
+		c.Lock.Lock()
+	}
+}
+
+func (c *ParentInfo) unlock() {
+	if c.Lock != nil {
+		c.Lock.Unlock()
+	}
+}
+
 // Used by the polling function to update the parents list from
 // changes to 'parent.config' and 'strategies.yaml'.  The parents
 // availability is also updated to reflect the current state from
@@ -0,0 +1,187 @@
+// Package config loads the configuration of tc-zone-transfer.
+package config
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	DefaultConfigFile          = "/etc/trafficcontrol/tc-zone-transfer.json"
+	DefaultListen              = ":53"
+	DefaultPollIntervalSeconds = 60
+	DefaultTORequestTimeout    = 30
+	DefaultHistory             = 10
+)
+
+// TSIGKey is a key with which secondary name servers authenticate their
+// zone transfer requests, as described by RFC 8945.
+type TSIGKey struct {
+	// Name is the name of the key, which must be the same as that used by
+	// the secondary name servers.
+	Name string `json:"name"`
+	// Algorithm is the name of the HMAC algorithm used with the key, e.g.
+	// "hmac-sha256".
+	Algorithm string `json:"algorithm"`
+	// Secret is the base64-encoded secret of the key.
+	Secret string `json:"secret"`
+}
+
+// Cfg is the configuration of tc-zone-transfer.
+type Cfg struct {
+	CDNName                 string        `json:"cdn-name"`
+	TOURL                   string        `json:"to-url"`
+	TOUser                  string        `json:"to-user"`
+	TOPass                  string        `json:"to-pass"`
+	TOInsecure              bool          `json:"to-insecure"`
+	TORequestTimeoutSeconds int           `json:"to-request-timeout-seconds"`
+	PollIntervalSeconds     int           `json:"poll-interval-seconds"`
+	Listen                  string        `json:"listen"`
+	History                 int           `json:"history"`
+	TSIGKeys                []TSIGKey     `json:"tsig-keys"`
+	LogLocationError        string        `json:"log-location-error"`
+	LogLocationWarning      string        `json:"log-location-warning"`
+	LogLocationInfo         string        `json:"log-location-info"`
+	LogLocationDebug        string        `json:"log-location-debug"`
+	LogLocationEvent        string        `json:"log-location-event"`
+	TORequestTimeout        time.Duration `json:"-"`
+	PollInterval            time.Duration `json:"-"`
+}
+
+func (c Cfg) ErrorLog() log.LogLocation   { return log.LogLocation(c.LogLocationError) }
+func (c Cfg) WarningLog() log.LogLocation { return log.LogLocation(c.LogLocationWarning) }
+func (c Cfg) InfoLog() log.LogLocation    { return log.LogLocation(c.LogLocationInfo) }
+func (c Cfg) DebugLog() log.LogLocation   { return log.LogLocation(c.LogLocationDebug) }
+func (c Cfg) EventLog() log.LogLocation   { return log.LogLocation(c.LogLocationEvent) }
+
+// TSIGSecrets returns the secrets of the configured TSIG keys, by their fully
+// qualified, lower-case names, as used by DNS servers.
+func (c Cfg) TSIGSecrets() map[string]string {
+	secrets := make(map[string]string, len(c.TSIGKeys))
+	for _, key := range c.TSIGKeys {
+		secrets[key.Name] = key.Secret
+	}
+	return secrets
+}
+
+// TSIGAlgorithms returns the algorithms of the configured TSIG keys, by
+// their fully qualified, lower-case names.
+func (c Cfg) TSIGAlgorithms() map[string]string {
+	algorithms := make(map[string]string, len(c.TSIGKeys))
+	for _, key := range c.TSIGKeys {
+		algorithms[key.Name] = key.Algorithm
+	}
+	return algorithms
+}
+
+// supportedAlgorithms are the TSIG algorithms that can be configured.
+var supportedAlgorithms = map[string]struct{}{
+	dns.HmacSHA1:   {},
+	dns.HmacSHA224: {},
+	dns.HmacSHA256: {},
+	dns.HmacSHA384: {},
+	dns.HmacSHA512: {},
+}
+
+// Load reads, validates, and fills in the defaults of the configuration in
+// the file with the given path.
+func Load(path string) (Cfg, error) {
+	bts, err := os.ReadFile(path)
+	if err != nil {
+		return Cfg{}, fmt.Errorf("reading config file '%s': %w", path, err)
+	}
+	cfg := Cfg{
+		TORequestTimeoutSeconds: DefaultTORequestTimeout,
+		PollIntervalSeconds:     DefaultPollIntervalSeconds,
+		Listen:                  DefaultListen,
+		History:                 DefaultHistory,
+		LogLocationError:        log.LogLocationStderr,
+		LogLocationWarning:      log.LogLocationStderr,
+		LogLocationInfo:         log.LogLocationNull,
+		LogLocationDebug:        log.LogLocationNull,
+		LogLocationEvent:        log.LogLocationNull,
+	}
+	if err := json.Unmarshal(bts, &cfg); err != nil {
+		return Cfg{}, fmt.Errorf("parsing config file '%s': %w", path, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return Cfg{}, fmt.Errorf("invalid config file '%s': %w", path, err)
+	}
+	cfg.TORequestTimeout = time.Duration(cfg.TORequestTimeoutSeconds) * time.Second
+	cfg.PollInterval = time.Duration(cfg.PollIntervalSeconds) * time.Second
+	return cfg, nil
+}
+
+// validate checks the configuration, normalizing the names of the TSIG keys
+// and their algorithms.
+func (c *Cfg) validate() error {
+	errs := []string{}
+	if c.CDNName == "" {
+		errs = append(errs, "cdn-name is required")
+	}
+	if c.TOURL == "" || c.TOUser == "" || c.TOPass == "" {
+		errs = append(errs, "to-url, to-user, and to-pass are required")
+	}
+	if c.PollIntervalSeconds <= 0 {
+		errs = append(errs, "poll-interval-seconds must be positive")
+	}
+	if c.TORequestTimeoutSeconds <= 0 {
+		errs = append(errs, "to-request-timeout-seconds must be positive")
+	}
+	if c.History < 0 {
+		errs = append(errs, "history may not be negative")
+	}
+	if len(c.TSIGKeys) == 0 {
+		errs = append(errs, "at least one TSIG key is required")
+	}
+	names := map[string]struct{}{}
+	for i := range c.TSIGKeys {
+		key := &c.TSIGKeys[i]
+		key.Name = dns.CanonicalName(key.Name)
+		key.Algorithm = dns.CanonicalName(key.Algorithm)
+		if key.Name == "." {
+			errs = append(errs, fmt.Sprintf("tsig-keys[%d]: name is required", i))
+		} else if _, ok := names[key.Name]; ok {
+			errs = append(errs, fmt.Sprintf("tsig-keys[%d]: duplicate key name '%s'", i, key.Name))
+		}
+		names[key.Name] = struct{}{}
+		if _, ok := supportedAlgorithms[key.Algorithm]; !ok {
+			errs = append(errs, fmt.Sprintf("tsig-keys[%d]: unsupported algorithm '%s'", i, key.Algorithm))
+		}
+		if secret, err := base64.StdEncoding.DecodeString(key.Secret); err != nil || len(secret) == 0 {
+			errs = append(errs, fmt.Sprintf("tsig-keys[%d]: secret must be non-empty and base64-encoded", i))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package config
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+const testConfigFile = "test_files/tc-zone-transfer.json"
+
+func TestLoad(t *testing.T) {
+	cfg, err := Load(testConfigFile)
+	if err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+	if cfg.PollInterval != time.Minute {
+		t.Errorf("expected poll interval of 1m, got %v", cfg.PollInterval)
+	}
+	if secret, ok := cfg.TSIGSecrets()["transfer.example.com."]; !ok || secret != "c2VjcmV0LXNlY3JldC1zZWNyZXQ=" {
+		t.Errorf("expected TSIG key names to be fully qualified, got %v", cfg.TSIGSecrets())
+	}
+	if algorithm := cfg.TSIGAlgorithms()["transfer.example.com."]; algorithm != dns.HmacSHA256 {
+		t.Errorf("expected algorithm '%s', got '%s'", dns.HmacSHA256, algorithm)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := Cfg{
+		CDNName:                 "mycdn",
+		TOURL:                   "https://trafficops.example.com",
+		TOUser:                  "user",
+		TOPass:                  "pass",
+		TORequestTimeoutSeconds: DefaultTORequestTimeout,
+		PollIntervalSeconds:     DefaultPollIntervalSeconds,
+		TSIGKeys:                []TSIGKey{{Name: "Key", Algorithm: "HMAC-SHA512", Secret: "c2VjcmV0"}},
+	}
+	if err := cfg.validate(); err != nil {
+		t.Fatalf("unexpected error validating valid config: %v", err)
+	}
+	if cfg.TSIGKeys[0].Name != "key." || cfg.TSIGKeys[0].Algorithm != dns.HmacSHA512 {
+		t.Errorf("expected TSIG key to be canonicalized, got %+v", cfg.TSIGKeys[0])
+	}
+
+	invalid := []TSIGKey{
+		{Name: "key", Algorithm: "hmac-md5.sig-alg.reg.int", Secret: "c2VjcmV0"},
+		{Name: "key", Algorithm: "hmac-sha256", Secret: "not base64!"},
+		{Name: "", Algorithm: "hmac-sha256", Secret: "c2VjcmV0"},
+	}
+	for _, key := range invalid {
+		cfg.TSIGKeys = []TSIGKey{key}
+		if err := cfg.validate(); err == nil {
+			t.Errorf("expected an error validating TSIG key %+v, but didn't get one", key)
+		}
+	}
+	cfg.TSIGKeys = nil
+	if err := cfg.validate(); err == nil {
+		t.Error("expected an error validating a config without TSIG keys, but didn't get one")
+	}
+}
@@ -0,0 +1,107 @@
+// Package poller keeps a zone Store up to date with the Snapshot of a CDN in
+// Traffic Ops.
+package poller
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/tc-zone-transfer/config"
+	"github.com/apache/trafficcontrol/tc-zone-transfer/zone"
+	toclient "github.com/apache/trafficcontrol/traffic_ops/v4-client"
+)
+
+// UserAgent is the User-Agent with which tc-zone-transfer makes requests to
+// Traffic Ops.
+const UserAgent = "tc-zone-transfer/1.0"
+
+// Poller periodically fetches the Snapshot of a CDN from Traffic Ops, and
+// updates a Store with the zones built from it.
+type Poller struct {
+	cfg     config.Cfg
+	store   *zone.Store
+	session *toclient.Session
+	// lastSnapshot is the time of the last Snapshot the Store was updated
+	// with, in seconds since the Unix epoch.
+	lastSnapshot int64
+}
+
+// New returns a Poller that updates the given Store.
+func New(cfg config.Cfg, store *zone.Store) *Poller {
+	return &Poller{cfg: cfg, store: store}
+}
+
+// Run polls Traffic Ops until the given channel is closed. Errors are logged,
+// and the Store continues to serve the zones built from the last Snapshot
+// successfully fetched.
+func (p *Poller) Run(done <-chan struct{}) {
+	ticker := time.NewTicker(p.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := p.Poll(); err != nil {
+			log.Errorf("updating zones from the Snapshot of CDN '%s': %v", p.cfg.CDNName, err)
+		}
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Poll fetches the Snapshot of the CDN, and updates the Store if it has been
+// taken since the last time it was fetched.
+func (p *Poller) Poll() error {
+	if p.session == nil {
+		session, _, err := toclient.LoginWithAgent(p.cfg.TOURL, p.cfg.TOUser, p.cfg.TOPass, p.cfg.TOInsecure, UserAgent, false, p.cfg.TORequestTimeout)
+		if err != nil {
+			return fmt.Errorf("logging in to Traffic Ops: %w", err)
+		}
+		p.session = session
+	}
+
+	resp, _, err := p.session.GetCRConfig(p.cfg.CDNName, toclient.RequestOptions{})
+	if err != nil {
+		// The session may have expired, so log in again next time.
+		p.session = nil
+		return fmt.Errorf("getting Snapshot: %w", err)
+	}
+	crc := resp.Response
+	if crc.Stats.DateUnixSeconds == nil {
+		return errors.New("Snapshot has no date")
+	}
+	date := *crc.Stats.DateUnixSeconds
+	if date == p.lastSnapshot {
+		return nil
+	}
+
+	zones, err := zone.Build(&crc)
+	if err != nil {
+		return fmt.Errorf("building zones: %w", err)
+	}
+	changed := p.store.Update(zones, time.Unix(date, 0))
+	p.lastSnapshot = date
+	log.Infof("updated zones from the Snapshot of CDN '%s' taken at %s: %d zones, %d changed %v", p.cfg.CDNName, time.Unix(date, 0).UTC().Format(time.RFC3339), len(zones), len(changed), changed)
+	return nil
+}
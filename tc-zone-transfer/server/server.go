@@ -0,0 +1,177 @@
+// Package server serves transfers of the zones in a zone Store to secondary
+// name servers.
+package server
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/tc-zone-transfer/zone"
+
+	"github.com/miekg/dns"
+)
+
+// recordsPerMessage is the number of records sent in each message of a zone
+// transfer, which keeps messages well within the 64KiB limit of DNS messages
+// sent over TCP.
+const recordsPerMessage = 256
+
+// Handler serves zone transfers of the zones in a Store, along with queries
+// for their SOA records, which secondary name servers make to check for
+// changes. Transfers must be authenticated with TSIG.
+type Handler struct {
+	Store *zone.Store
+	// TSIGAlgorithms are the algorithms of the TSIG keys with which
+	// transfers may be authenticated, by key name. The Server that uses
+	// the Handler must have their secrets.
+	TSIGAlgorithms map[string]string
+}
+
+// ServeDNS implements dns.Handler.
+func (h *Handler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
+	if r.Opcode != dns.OpcodeQuery || len(r.Question) != 1 {
+		h.reply(w, r, dns.RcodeFormatError)
+		return
+	}
+	q := r.Question[0]
+	z, ok := h.Store.Get(q.Name)
+	if !ok || q.Qclass != dns.ClassINET {
+		h.reply(w, r, dns.RcodeRefused)
+		return
+	}
+
+	switch q.Qtype {
+	case dns.TypeSOA:
+		m := new(dns.Msg)
+		m.SetReply(r)
+		m.Authoritative = true
+		m.Answer = []dns.RR{z.SOA}
+		h.write(w, r, m)
+	case dns.TypeAXFR, dns.TypeIXFR:
+		if rcode := h.authenticate(w, r); rcode != dns.RcodeSuccess {
+			log.Warnf("refusing %s of %s to %s: %s", dns.TypeToString[q.Qtype], q.Name, w.RemoteAddr(), dns.RcodeToString[rcode])
+			h.reply(w, r, rcode)
+			return
+		}
+		h.transfer(w, r, z)
+	default:
+		h.reply(w, r, dns.RcodeRefused)
+	}
+}
+
+// authenticate returns the response code with which a zone transfer request
+// must be answered if it isn't authenticated, or RcodeSuccess if it is.
+func (h *Handler) authenticate(w dns.ResponseWriter, r *dns.Msg) int {
+	tsig := r.IsTsig()
+	if tsig == nil {
+		return dns.RcodeRefused
+	}
+	if w.TsigStatus() != nil {
+		return dns.RcodeNotAuth
+	}
+	if algorithm, ok := h.TSIGAlgorithms[dns.CanonicalName(tsig.Hdr.Name)]; !ok || algorithm != dns.CanonicalName(tsig.Algorithm) {
+		return dns.RcodeNotAuth
+	}
+	return dns.RcodeSuccess
+}
+
+// transfer transfers the zone in response to an authenticated AXFR or IXFR
+// request. Incremental transfers are made when the Store has the version of
+// the zone the secondary has, and the whole zone is transferred otherwise.
+func (h *Handler) transfer(w dns.ResponseWriter, r *dns.Msg, z *zone.Zone) {
+	q := r.Question[0]
+	var records []dns.RR
+	if q.Qtype == dns.TypeIXFR {
+		if serial, ok := ixfrSerial(r); ok {
+			records, _ = h.Store.Incremental(z.Name, serial)
+		}
+		if _, udp := w.RemoteAddr().(*net.UDPAddr); udp && len(records) != 1 {
+			// Incremental transfers that may not fit in a UDP message are
+			// answered with the current SOA record, which tells the
+			// secondary to retry over TCP (RFC 1995, section 2).
+			m := new(dns.Msg)
+			m.SetReply(r)
+			m.Authoritative = true
+			m.Answer = []dns.RR{z.SOA}
+			h.write(w, r, m)
+			return
+		}
+	} else if _, udp := w.RemoteAddr().(*net.UDPAddr); udp {
+		h.reply(w, r, dns.RcodeRefused)
+		return
+	}
+	if records == nil {
+		records = append([]dns.RR{z.SOA}, z.Records...)
+		records = append(records, z.SOA)
+	}
+
+	ch := make(chan *dns.Envelope)
+	go func() {
+		defer close(ch)
+		for len(records) > 0 {
+			n := recordsPerMessage
+			if n > len(records) {
+				n = len(records)
+			}
+			ch <- &dns.Envelope{RR: records[:n]}
+			records = records[n:]
+		}
+	}()
+	tr := new(dns.Transfer)
+	if err := tr.Out(w, r, ch); err != nil {
+		log.Errorf("transferring %s to %s: %v", z.Name, w.RemoteAddr(), err)
+		for range ch {
+		}
+		return
+	}
+	log.Infof("transferred %s (serial %d) to %s by %s", z.Name, z.Serial(), w.RemoteAddr(), dns.TypeToString[q.Qtype])
+}
+
+// ixfrSerial returns the serial number of the version of the zone that the
+// secondary making an IXFR request has, from the SOA record in the
+// request's authority section.
+func ixfrSerial(r *dns.Msg) (uint32, bool) {
+	for _, rr := range r.Ns {
+		if soa, ok := rr.(*dns.SOA); ok {
+			return soa.Serial, true
+		}
+	}
+	return 0, false
+}
+
+func (h *Handler) reply(w dns.ResponseWriter, r *dns.Msg, rcode int) {
+	m := new(dns.Msg)
+	m.SetRcode(r, rcode)
+	h.write(w, r, m)
+}
+
+// write writes a response, signing it if the request was signed with a
+// valid key.
+func (h *Handler) write(w dns.ResponseWriter, r *dns.Msg, m *dns.Msg) {
+	if tsig := r.IsTsig(); tsig != nil && w.TsigStatus() == nil {
+		m.SetTsig(tsig.Hdr.Name, tsig.Algorithm, tsig.Fudge, time.Now().Unix())
+	}
+	if err := w.WriteMsg(m); err != nil {
+		log.Errorf("writing response to %s: %v", w.RemoteAddr(), err)
+	}
+}
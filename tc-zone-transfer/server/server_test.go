@@ -0,0 +1,156 @@
+package server
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/tc-zone-transfer/zone"
+
+	"github.com/miekg/dns"
+)
+
+const (
+	keyName   = "transfer.example.com."
+	keySecret = "c2VjcmV0LXNlY3JldC1zZWNyZXQ="
+	zoneName  = "example.com."
+)
+
+func testZone(t *testing.T, records ...string) map[string]*zone.Zone {
+	t.Helper()
+	soa, err := dns.NewRR(zoneName + " 86400 IN SOA ns.example.com. admin.example.com. 0 28800 7200 604800 60")
+	if err != nil {
+		t.Fatalf("parsing SOA record: %v", err)
+	}
+	z := &zone.Zone{Name: zoneName, SOA: soa.(*dns.SOA)}
+	for _, record := range records {
+		rr, err := dns.NewRR(record)
+		if err != nil {
+			t.Fatalf("parsing record '%s': %v", record, err)
+		}
+		z.Records = append(z.Records, rr)
+	}
+	return map[string]*zone.Zone{zoneName: z}
+}
+
+// serve starts a TCP server for the given Store, returning its address.
+func serve(t *testing.T, store *zone.Store) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	srv := &dns.Server{
+		Listener:   l,
+		Handler:    &Handler{Store: store, TSIGAlgorithms: map[string]string{keyName: dns.HmacSHA256}},
+		TsigSecret: map[string]string{keyName: keySecret},
+	}
+	go srv.ActivateAndServe()
+	t.Cleanup(func() { srv.Shutdown() })
+	return l.Addr().String()
+}
+
+func transfer(t *testing.T, addr string, m *dns.Msg) ([]dns.RR, error) {
+	t.Helper()
+	tr := &dns.Transfer{TsigSecret: map[string]string{keyName: keySecret}}
+	ch, err := tr.In(m, addr)
+	if err != nil {
+		return nil, err
+	}
+	records := []dns.RR{}
+	for env := range ch {
+		if env.Error != nil {
+			return nil, env.Error
+		}
+		records = append(records, env.RR...)
+	}
+	return records, nil
+}
+
+func TestAXFR(t *testing.T) {
+	store := zone.NewStore(1)
+	store.Update(testZone(t, "a.example.com. 60 IN A 192.0.2.1", "b.example.com. 60 IN A 192.0.2.2"), time.Unix(100, 0))
+	addr := serve(t, store)
+
+	m := new(dns.Msg)
+	m.SetAxfr(zoneName)
+	m.SetTsig(keyName, dns.HmacSHA256, 300, time.Now().Unix())
+	records, err := transfer(t, addr, m)
+	if err != nil {
+		t.Fatalf("unexpected error transferring zone: %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("expected 4 records (SOA, 2 A, SOA), got %d: %v", len(records), records)
+	}
+	if soa, ok := records[0].(*dns.SOA); !ok || soa.Serial != 100 {
+		t.Errorf("expected first record to be the SOA record with serial 100, got %v", records[0])
+	}
+
+	m = new(dns.Msg)
+	m.SetAxfr(zoneName)
+	if _, err := transfer(t, addr, m); err == nil {
+		t.Error("expected an error transferring a zone without TSIG, but didn't get one")
+	}
+
+	m = new(dns.Msg)
+	m.SetAxfr("other.example.com.")
+	m.SetTsig(keyName, dns.HmacSHA256, 300, time.Now().Unix())
+	if _, err := transfer(t, addr, m); err == nil {
+		t.Error("expected an error transferring an unknown zone, but didn't get one")
+	}
+}
+
+func TestIXFR(t *testing.T) {
+	store := zone.NewStore(1)
+	store.Update(testZone(t, "a.example.com. 60 IN A 192.0.2.1"), time.Unix(100, 0))
+	store.Update(testZone(t, "b.example.com. 60 IN A 192.0.2.2"), time.Unix(200, 0))
+	addr := serve(t, store)
+
+	m := new(dns.Msg)
+	m.SetIxfr(zoneName, 100, "ns.example.com.", "admin.example.com.")
+	m.SetTsig(keyName, dns.HmacSHA256, 300, time.Now().Unix())
+	records, err := transfer(t, addr, m)
+	if err != nil {
+		t.Fatalf("unexpected error transferring zone: %v", err)
+	}
+	// current SOA, old SOA, deleted A, new SOA, added A, current SOA
+	if len(records) != 6 {
+		t.Fatalf("expected 6 records, got %d: %v", len(records), records)
+	}
+	if a, ok := records[2].(*dns.A); !ok || a.A.String() != "192.0.2.1" {
+		t.Errorf("expected the deleted record to be 'a.example.com. A 192.0.2.1', got %v", records[2])
+	}
+	if a, ok := records[4].(*dns.A); !ok || a.A.String() != "192.0.2.2" {
+		t.Errorf("expected the added record to be 'b.example.com. A 192.0.2.2', got %v", records[4])
+	}
+
+	m = new(dns.Msg)
+	m.SetIxfr(zoneName, 50, "ns.example.com.", "admin.example.com.")
+	m.SetTsig(keyName, dns.HmacSHA256, 300, time.Now().Unix())
+	records, err = transfer(t, addr, m)
+	if err != nil {
+		t.Fatalf("unexpected error transferring zone: %v", err)
+	}
+	if len(records) != 3 {
+		t.Errorf("expected a full transfer (SOA, A, SOA) from an unknown serial, got %d records: %v", len(records), records)
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/tc-zone-transfer/config"
+	"github.com/apache/trafficcontrol/tc-zone-transfer/poller"
+	"github.com/apache/trafficcontrol/tc-zone-transfer/server"
+	"github.com/apache/trafficcontrol/tc-zone-transfer/zone"
+
+	"github.com/miekg/dns"
+	"github.com/pborman/getopt/v2"
+)
+
+const (
+	Success      = 0
+	ConfigError  = 166
+	RunTimeError = 167
+)
+
+// the BuildTimestamp and Version are set via ld flags
+// when the RPM is built.
+var (
+	BuildTimestamp = ""
+	Version        = ""
+)
+
+func main() {
+	configFile := getopt.StringLong("config-file", 'f', config.DefaultConfigFile, "full path to the json config file")
+	help := getopt.BoolLong("help", 'h', "Print usage information and exit")
+	getopt.Parse()
+	if *help {
+		getopt.PrintUsage(os.Stdout)
+		os.Exit(Success)
+	}
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(ConfigError)
+	}
+	if err := log.InitCfg(cfg); err != nil {
+		fmt.Fprintf(os.Stderr, "initializing loggers: %v\n", err)
+		os.Exit(ConfigError)
+	}
+
+	store := zone.NewStore(cfg.History)
+	handler := &server.Handler{Store: store, TSIGAlgorithms: cfg.TSIGAlgorithms()}
+	errs := make(chan error, 2)
+	servers := []*dns.Server{}
+	for _, network := range []string{"tcp", "udp"} {
+		srv := &dns.Server{Addr: cfg.Listen, Net: network, Handler: handler, TsigSecret: cfg.TSIGSecrets()}
+		servers = append(servers, srv)
+		go func() {
+			errs <- srv.ListenAndServe()
+		}()
+	}
+
+	done := make(chan struct{})
+	go poller.New(cfg, store).Run(done)
+	log.Infof("startup complete, version: %s, built: %s, listening on %s\n", Version, BuildTimestamp, cfg.Listen)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	exitCode := Success
+	select {
+	case sig := <-signals:
+		log.Infof("received %v, shutting down\n", sig)
+	case err := <-errs:
+		log.Errorf("serving DNS: %v\n", err)
+		exitCode = RunTimeError
+	}
+	close(done)
+	for _, srv := range servers {
+		srv.Shutdown()
+	}
+	os.Exit(exitCode)
+}
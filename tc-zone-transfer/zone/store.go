@@ -0,0 +1,179 @@
+package zone
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+// A Store holds the current versions of zones, along with a number of their
+// previous versions, from which incremental zone transfers are served. It's
+// safe for concurrent use.
+type Store struct {
+	m sync.RWMutex
+	// versions are the versions of each zone, by name, oldest first.
+	versions map[string][]*Zone
+	history  int
+}
+
+// NewStore returns a Store that keeps the given number of previous versions
+// of each zone.
+func NewStore(history int) *Store {
+	if history < 0 {
+		history = 0
+	}
+	return &Store{versions: map[string][]*Zone{}, history: history}
+}
+
+// Update replaces the zones in the Store with the given zones, built from a
+// Snapshot taken at the given time. Zones whose content hasn't changed keep
+// their serial numbers; those of the rest are the time of the Snapshot in
+// seconds since the Unix epoch, or one more than their previous serial
+// numbers if that's greater. It returns the names of the zones that changed.
+func (s *Store) Update(zones map[string]*Zone, snapshotTime time.Time) []string {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	changed := []string{}
+	for name := range s.versions {
+		if _, ok := zones[name]; !ok {
+			delete(s.versions, name)
+			changed = append(changed, name)
+		}
+	}
+
+	for name, z := range zones {
+		versions := s.versions[name]
+		serial := uint32(snapshotTime.Unix())
+		if len(versions) > 0 {
+			current := versions[len(versions)-1]
+			if sameContent(current, z) {
+				continue
+			}
+			if !serialGreater(serial, current.Serial()) {
+				serial = current.Serial() + 1
+			}
+		}
+		soa := *z.SOA
+		soa.Serial = serial
+		z = &Zone{Name: z.Name, SOA: &soa, Records: z.Records}
+
+		versions = append(versions, z)
+		if len(versions) > s.history+1 {
+			versions = versions[len(versions)-s.history-1:]
+		}
+		s.versions[name] = versions
+		changed = append(changed, name)
+	}
+	return changed
+}
+
+// Get returns the current version of the zone with the given name, and
+// whether the Store has it.
+func (s *Store) Get(name string) (*Zone, bool) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	versions := s.versions[strings.ToLower(name)]
+	if len(versions) == 0 {
+		return nil, false
+	}
+	return versions[len(versions)-1], true
+}
+
+// Incremental returns the records of an incremental zone transfer of the
+// zone with the given name from the version with the given serial number to
+// the current version, as described by RFC 1995: the current SOA record,
+// followed by the difference between each pair of successive versions as the
+// SOA record of the older version, the records it deleted, the SOA record of
+// the newer version, and the records it added, then the current SOA record
+// again. If the version with the given serial number is the current version,
+// only the current SOA record is returned. If the Store doesn't have the
+// version with the given serial number, it returns false, in which case the
+// whole zone must be transferred.
+func (s *Store) Incremental(name string, serial uint32) ([]dns.RR, bool) {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	versions := s.versions[strings.ToLower(name)]
+	from := -1
+	for i, v := range versions {
+		if v.Serial() == serial {
+			from = i
+			break
+		}
+	}
+	if from < 0 {
+		return nil, false
+	}
+
+	current := versions[len(versions)-1]
+	records := []dns.RR{current.SOA}
+	if from == len(versions)-1 {
+		return records, true
+	}
+	for i := from; i < len(versions)-1; i++ {
+		older, newer := versions[i], versions[i+1]
+		records = append(records, older.SOA)
+		records = append(records, difference(older.Records, newer.Records)...)
+		records = append(records, newer.SOA)
+		records = append(records, difference(newer.Records, older.Records)...)
+	}
+	return append(records, current.SOA), true
+}
+
+// sameContent returns whether two versions of a zone have the same content,
+// disregarding their serial numbers.
+func sameContent(a, b *Zone) bool {
+	soaA, soaB := *a.SOA, *b.SOA
+	soaA.Serial, soaB.Serial = 0, 0
+	if soaA.String() != soaB.String() || len(a.Records) != len(b.Records) {
+		return false
+	}
+	for i := range a.Records {
+		if a.Records[i].String() != b.Records[i].String() {
+			return false
+		}
+	}
+	return true
+}
+
+// difference returns the records in a that aren't in b.
+func difference(a, b []dns.RR) []dns.RR {
+	inB := make(map[string]struct{}, len(b))
+	for _, rr := range b {
+		inB[rr.String()] = struct{}{}
+	}
+	diff := []dns.RR{}
+	for _, rr := range a {
+		if _, ok := inB[rr.String()]; !ok {
+			diff = append(diff, rr)
+		}
+	}
+	return diff
+}
+
+// serialGreater returns whether serial number a is greater than b, according
+// to the serial number arithmetic of RFC 1982.
+func serialGreater(a, b uint32) bool {
+	return a != b && a-b < 1<<31
+}
@@ -0,0 +1,136 @@
+package zone
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+)
+
+func testZone(t *testing.T, records ...string) map[string]*Zone {
+	t.Helper()
+	soa, err := dns.NewRR("example.com. 86400 IN SOA ns.example.com. admin.example.com. 0 28800 7200 604800 60")
+	if err != nil {
+		t.Fatalf("parsing SOA record: %v", err)
+	}
+	z := &Zone{Name: "example.com.", SOA: soa.(*dns.SOA)}
+	for _, record := range records {
+		rr, err := dns.NewRR(record)
+		if err != nil {
+			t.Fatalf("parsing record '%s': %v", record, err)
+		}
+		z.Records = append(z.Records, rr)
+	}
+	return map[string]*Zone{z.Name: z}
+}
+
+func TestStoreUpdate(t *testing.T) {
+	s := NewStore(1)
+	snapshotTime := time.Unix(1650000000, 0)
+
+	changed := s.Update(testZone(t, "a.example.com. 60 IN A 192.0.2.1"), snapshotTime)
+	if len(changed) != 1 {
+		t.Errorf("expected 1 changed zone, got %v", changed)
+	}
+	z, ok := s.Get("EXAMPLE.com.")
+	if !ok {
+		t.Fatal("expected the Store to have zone 'example.com.', but it didn't")
+	}
+	if z.Serial() != 1650000000 {
+		t.Errorf("expected serial 1650000000, got %d", z.Serial())
+	}
+
+	if changed := s.Update(testZone(t, "a.example.com. 60 IN A 192.0.2.1"), snapshotTime.Add(time.Hour)); len(changed) != 0 {
+		t.Errorf("expected no changed zones when content is unchanged, got %v", changed)
+	}
+	if z, _ := s.Get("example.com."); z.Serial() != 1650000000 {
+		t.Errorf("expected unchanged zone to keep serial 1650000000, got %d", z.Serial())
+	}
+
+	// A Snapshot taken at the same time must still increase the serial.
+	s.Update(testZone(t, "a.example.com. 60 IN A 192.0.2.2"), snapshotTime)
+	if z, _ := s.Get("example.com."); z.Serial() != 1650000001 {
+		t.Errorf("expected serial 1650000001, got %d", z.Serial())
+	}
+
+	if changed := s.Update(map[string]*Zone{}, snapshotTime.Add(time.Hour)); len(changed) != 1 {
+		t.Errorf("expected removed zone to be changed, got %v", changed)
+	}
+	if _, ok := s.Get("example.com."); ok {
+		t.Error("expected removed zone not to be in the Store, but it was")
+	}
+}
+
+func TestStoreIncremental(t *testing.T) {
+	s := NewStore(1)
+	s.Update(testZone(t, "a.example.com. 60 IN A 192.0.2.1", "b.example.com. 60 IN A 192.0.2.2"), time.Unix(100, 0))
+	s.Update(testZone(t, "a.example.com. 60 IN A 192.0.2.1", "c.example.com. 60 IN A 192.0.2.3"), time.Unix(200, 0))
+
+	records, ok := s.Incremental("example.com.", 100)
+	if !ok {
+		t.Fatal("expected an incremental transfer from serial 100, but didn't get one")
+	}
+	expected := []string{
+		"example.com.\t86400\tIN\tSOA\tns.example.com. admin.example.com. 200 28800 7200 604800 60",
+		"example.com.\t86400\tIN\tSOA\tns.example.com. admin.example.com. 100 28800 7200 604800 60",
+		"b.example.com.\t60\tIN\tA\t192.0.2.2",
+		"example.com.\t86400\tIN\tSOA\tns.example.com. admin.example.com. 200 28800 7200 604800 60",
+		"c.example.com.\t60\tIN\tA\t192.0.2.3",
+		"example.com.\t86400\tIN\tSOA\tns.example.com. admin.example.com. 200 28800 7200 604800 60",
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("expected %d records, got %d: %v", len(expected), len(records), records)
+	}
+	for i, rr := range records {
+		if rr.String() != expected[i] {
+			t.Errorf("incorrect record #%d; expected: '%s', actual: '%s'", i, expected[i], rr.String())
+		}
+	}
+
+	if records, ok := s.Incremental("example.com.", 200); !ok || len(records) != 1 {
+		t.Errorf("expected only the SOA record for the current serial, got %v (%t)", records, ok)
+	}
+
+	// With a history of 1, the third version drops the first.
+	s.Update(testZone(t, "d.example.com. 60 IN A 192.0.2.4"), time.Unix(300, 0))
+	if _, ok := s.Incremental("example.com.", 100); ok {
+		t.Error("expected no incremental transfer from a version no longer kept, but got one")
+	}
+}
+
+func TestSerialGreater(t *testing.T) {
+	tests := []struct {
+		a, b     uint32
+		expected bool
+	}{
+		{2, 1, true},
+		{1, 2, false},
+		{1, 1, false},
+		{0, 1<<32 - 1, true},
+		{1<<32 - 1, 0, false},
+	}
+	for _, test := range tests {
+		if actual := serialGreater(test.a, test.b); actual != test.expected {
+			t.Errorf("serialGreater(%d, %d): expected %t, got %t", test.a, test.b, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,444 @@
+// Package zone builds the static content of the zones Traffic Router is
+// authoritative for from CDN Snapshots, and keeps recent versions of them so
+// that incremental zone transfers can be served.
+package zone
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	"github.com/miekg/dns"
+)
+
+// These are the defaults Traffic Router uses for the timers of SOA records
+// and the TTLs of records, in seconds.
+const (
+	DefaultSOATTL     = 86400
+	DefaultRefresh    = 28800
+	DefaultRetry      = 7200
+	DefaultExpire     = 604800
+	DefaultMinimum    = 60
+	DefaultRecordTTL  = 60
+	DefaultAdminLabel = "traffic_ops"
+)
+
+// A Zone is the static content of a zone: everything Traffic Router serves
+// from it except the records it generates for each client - those of the
+// routing names of DNS-routed Delivery Services - and DNSSEC records.
+type Zone struct {
+	// Name is the fully qualified, lower-case name of the zone.
+	Name string
+	// SOA is the zone's SOA record.
+	SOA *dns.SOA
+	// Records are the zone's other records, sorted by their presentation
+	// format.
+	Records []dns.RR
+}
+
+// Serial returns the serial number of the zone.
+func (z *Zone) Serial() uint32 {
+	return z.SOA.Serial
+}
+
+// ttls are the TTLs of the records of a zone, by record type.
+type ttls map[string]uint32
+
+func (t ttls) get(rrType string, def uint32) uint32 {
+	if ttl, ok := t[rrType]; ok {
+		return ttl
+	}
+	return def
+}
+
+// soaParams are the parameters of the SOA record of a zone.
+type soaParams map[string]string
+
+func (s soaParams) get(key string, def uint32) uint32 {
+	if v, ok := s[key]; ok {
+		if i, err := strconv.ParseUint(v, 10, 32); err == nil {
+			return uint32(i)
+		}
+	}
+	return def
+}
+
+// builder accumulates the records of the zones of a CDN.
+type builder struct {
+	crc     *tc.CRConfig
+	tld     string
+	routers []string
+	zones   map[string][]dns.RR
+	dses    map[string]*tc.CRConfigDeliveryService
+	// edgeHTTPRouting is whether Traffic Router routes HTTP Delivery
+	// Services' clients to edge Traffic Routers, in which case the
+	// records of their routing names are generated for each client.
+	edgeHTTPRouting bool
+}
+
+// Build builds the zones of a CDN from its Snapshot, by name. Their serial
+// numbers are 0; it's up to the Store to which they're added to number them.
+func Build(crc *tc.CRConfig) (map[string]*Zone, error) {
+	tld, ok := crc.Config["domain_name"].(string)
+	if !ok || tld == "" {
+		return nil, errors.New("snapshot has no domain_name")
+	}
+
+	b := builder{
+		crc:             crc,
+		tld:             strings.ToLower(strings.TrimSuffix(tld, ".")),
+		zones:           map[string][]dns.RR{},
+		dses:            map[string]*tc.CRConfigDeliveryService{},
+		edgeHTTPRouting: configString(crc.Config, "edge.http.routing") == "true",
+	}
+	for name, router := range crc.ContentRouters {
+		if router.ServerStatus == nil || *router.ServerStatus == tc.CRConfigRouterStatus(tc.CacheStatusOffline) || *router.ServerStatus == tc.CRConfigRouterStatus(tc.CacheStatusAdminDown) {
+			continue
+		}
+		b.routers = append(b.routers, name)
+	}
+	sort.Strings(b.routers)
+
+	b.addDeliveryServiceDomains()
+	superDomains := b.addCaches()
+
+	zones := make(map[string]*Zone, len(b.zones)+len(superDomains))
+	for domain := range b.zones {
+		zones[dns.Fqdn(domain)] = b.zone(domain, b.dses[domain])
+	}
+	for domain := range superDomains {
+		if _, ok := zones[dns.Fqdn(domain)]; ok {
+			continue
+		}
+		zones[dns.Fqdn(domain)] = b.zone(domain, nil)
+	}
+	return zones, nil
+}
+
+// addDeliveryServiceDomains creates the zones of the domains of the Delivery
+// Services within the CDN's domain.
+func (b *builder) addDeliveryServiceDomains() {
+	for xmlID := range b.crc.DeliveryServices {
+		ds := b.crc.DeliveryServices[xmlID]
+		if len(ds.Domains) == 0 {
+			continue
+		}
+		domain := strings.ToLower(ds.Domains[0])
+		if strings.HasSuffix(domain, "+") {
+			domain = strings.TrimSuffix(domain, "+") + "." + b.tld
+		}
+		if !strings.HasSuffix(domain, b.tld) {
+			continue
+		}
+		b.dses[domain] = &ds
+		if _, ok := b.zones[domain]; !ok {
+			b.zones[domain] = []dns.RR{}
+		}
+	}
+}
+
+// addCaches adds the records of the cache servers assigned to Delivery
+// Services to the zones of their domains, returning the domains of which
+// those zones are subdomains.
+func (b *builder) addCaches() map[string]struct{} {
+	superDomains := map[string]struct{}{}
+	for cacheName, cache := range b.crc.ContentServers {
+		for xmlID, fqdns := range cache.DeliveryServices {
+			ds, ok := b.crc.DeliveryServices[xmlID]
+			if !ok {
+				log.Warnf("cache server %s is assigned to Delivery Service %s, which isn't in the snapshot; skipping", cacheName, xmlID)
+				continue
+			}
+			for _, fqdn := range fqdns {
+				host, domain, ok := strings.Cut(strings.ToLower(strings.TrimSuffix(fqdn, ".")), ".")
+				if !ok {
+					continue
+				}
+				if _, ok := b.dses[domain]; !ok {
+					b.dses[domain] = &ds
+				}
+				if _, ok := b.zones[domain]; !ok {
+					b.zones[domain] = []dns.RR{}
+				}
+				if _, superDomain, ok := strings.Cut(domain, "."); ok {
+					superDomains[superDomain] = struct{}{}
+				}
+				if isDNS(ds) && ds.RoutingName != nil && strings.EqualFold(host, *ds.RoutingName) {
+					continue
+				}
+
+				dsTTLs := deliveryServiceTTLs(ds)
+				if cache.Ip != nil && *cache.Ip != "" {
+					if rr := addressRecord(fqdn, dsTTLs, *cache.Ip); rr != nil {
+						b.zones[domain] = append(b.zones[domain], rr)
+					}
+				}
+				if cache.Ip6 != nil && *cache.Ip6 != "" && ds.IP6RoutingEnabled != nil && *ds.IP6RoutingEnabled {
+					if rr := addressRecord(fqdn, dsTTLs, *cache.Ip6); rr != nil {
+						b.zones[domain] = append(b.zones[domain], rr)
+					}
+				}
+			}
+		}
+	}
+	return superDomains
+}
+
+// zone returns the zone of the given domain, of which the given Delivery
+// Service - if any - is the Delivery Service. Zones without Delivery Services
+// are the parents of those of Delivery Services, such as the CDN's domain.
+func (b *builder) zone(domain string, ds *tc.CRConfigDeliveryService) *Zone {
+	zoneTTLs := configTTLs(b.crc.Config)
+	soa := configSOA(b.crc.Config)
+	ip6 := true
+	if ds != nil {
+		zoneTTLs = deliveryServiceTTLs(*ds)
+		soa = deliveryServiceSOA(*ds)
+		ip6 = ds.IP6RoutingEnabled != nil && *ds.IP6RoutingEnabled
+	}
+
+	name := dns.Fqdn(domain)
+	records := append([]dns.RR{}, b.zones[domain]...)
+	for _, routerName := range b.routers {
+		router := b.crc.ContentRouters[routerName]
+		records = append(records, &dns.NS{
+			Hdr: header(name, dns.TypeNS, zoneTTLs.get("NS", DefaultRecordTTL)),
+			Ns:  b.glueName(domain, routerName, ds),
+		})
+		records = append(records, b.routerRecords(routerName+"."+domain, router, zoneTTLs, ip6)...)
+		if ds != nil && !isDNS(*ds) && !b.edgeHTTPRouting && ds.RoutingName != nil {
+			records = append(records, b.routerRecords(*ds.RoutingName+"."+domain, router, zoneTTLs, ip6)...)
+		}
+	}
+	if ds != nil {
+		records = append(records, staticRecords(domain, *ds)...)
+	}
+	records = dns.Dedup(records, nil)
+	sort.Slice(records, func(i, j int) bool { return records[i].String() < records[j].String() })
+
+	mname := name
+	if len(b.routers) > 0 {
+		mname = b.glueName(domain, b.routers[0], ds)
+	}
+	return &Zone{
+		Name: name,
+		SOA: &dns.SOA{
+			Hdr:     header(name, dns.TypeSOA, zoneTTLs.get("SOA", DefaultSOATTL)),
+			Ns:      mname,
+			Mbox:    adminMailbox(soa, domain),
+			Refresh: soa.get("refresh", DefaultRefresh),
+			Retry:   soa.get("retry", DefaultRetry),
+			Expire:  soa.get("expire", DefaultExpire),
+			Minttl:  soa.get("minimum", DefaultMinimum),
+		},
+		Records: records,
+	}
+}
+
+// glueName returns the name of a Traffic Router as a name server of the zone
+// of the given domain: its own FQDN for zones without Delivery Services, and
+// its name within the parent domain otherwise, as Traffic Router names
+// itself.
+func (b *builder) glueName(domain, routerName string, ds *tc.CRConfigDeliveryService) string {
+	router := b.crc.ContentRouters[routerName]
+	if ds == nil && router.FQDN != nil && *router.FQDN != "" {
+		return dns.Fqdn(strings.ToLower(*router.FQDN))
+	}
+	if _, superDomain, ok := strings.Cut(domain, "."); ok {
+		return dns.Fqdn(routerName + "." + superDomain)
+	}
+	return dns.Fqdn(routerName + "." + domain)
+}
+
+// routerRecords returns the address records of a Traffic Router with the
+// given name.
+func (b *builder) routerRecords(name string, router tc.CRConfigRouter, zoneTTLs ttls, ip6 bool) []dns.RR {
+	records := []dns.RR{}
+	if router.IP != nil && *router.IP != "" {
+		if rr := addressRecord(name, zoneTTLs, *router.IP); rr != nil {
+			records = append(records, rr)
+		}
+	}
+	if ip6 && router.IP6 != nil && *router.IP6 != "" {
+		if rr := addressRecord(name, zoneTTLs, *router.IP6); rr != nil {
+			records = append(records, rr)
+		}
+	}
+	return records
+}
+
+// staticRecords returns the records of the static DNS entries of a Delivery
+// Service within the given domain.
+func staticRecords(domain string, ds tc.CRConfigDeliveryService) []dns.RR {
+	dsTTLs := deliveryServiceTTLs(ds)
+	records := []dns.RR{}
+	for _, entry := range ds.StaticDNSEntries {
+		name := dns.Fqdn(strings.ToLower(entry.Name + "." + domain))
+		rrType := strings.ToUpper(entry.Type)
+		ttl := uint32(entry.TTL)
+		if ttl == 0 {
+			ttl = dsTTLs.get(rrType, DefaultRecordTTL)
+		}
+		switch rrType {
+		case "A", "AAAA":
+			if rr := addressRecord(name, ttls{rrType: ttl}, entry.Value); rr != nil {
+				records = append(records, rr)
+			}
+		case "CNAME":
+			records = append(records, &dns.CNAME{Hdr: header(name, dns.TypeCNAME, ttl), Target: dns.Fqdn(entry.Value)})
+		case "TXT":
+			records = append(records, &dns.TXT{Hdr: header(name, dns.TypeTXT, ttl), Txt: []string{entry.Value}})
+		default:
+			log.Warnf("static DNS entry %s of Delivery Service in %s has unsupported type %s; skipping", entry.Name, domain, entry.Type)
+		}
+	}
+	return records
+}
+
+// addressRecord returns an A or AAAA record - depending on the address - of
+// the given name, or nil if the address is invalid. IPv6 addresses may have
+// prefix lengths, which are ignored.
+func addressRecord(name string, zoneTTLs ttls, address string) dns.RR {
+	address, _, _ = strings.Cut(address, "/")
+	ip := net.ParseIP(address)
+	if ip == nil {
+		log.Warnf("invalid address '%s' for %s; skipping", address, name)
+		return nil
+	}
+	name = dns.Fqdn(strings.ToLower(name))
+	if ip4 := ip.To4(); ip4 != nil {
+		return &dns.A{Hdr: header(name, dns.TypeA, zoneTTLs.get("A", DefaultRecordTTL)), A: ip4}
+	}
+	return &dns.AAAA{Hdr: header(name, dns.TypeAAAA, zoneTTLs.get("AAAA", DefaultRecordTTL)), AAAA: ip}
+}
+
+func header(name string, rrType uint16, ttl uint32) dns.RR_Header {
+	return dns.RR_Header{Name: name, Rrtype: rrType, Class: dns.ClassINET, Ttl: ttl}
+}
+
+// adminMailbox returns the mailbox of the administrator of the zone of the
+// given domain, as Traffic Router derives it.
+func adminMailbox(soa soaParams, domain string) string {
+	admin, ok := soa["admin"]
+	if !ok || admin == "" {
+		return dns.Fqdn(DefaultAdminLabel + "." + domain)
+	}
+	if strings.Contains(admin, "@") {
+		return dns.Fqdn(strings.Replace(admin, "@", ".", -1))
+	}
+	return dns.Fqdn(admin + "." + domain)
+}
+
+// isDNS returns whether clients of the Delivery Service are routed by DNS.
+func isDNS(ds tc.CRConfigDeliveryService) bool {
+	for _, matchSet := range ds.MatchSets {
+		if matchSet != nil && matchSet.Protocol == "DNS" {
+			return true
+		}
+	}
+	return false
+}
+
+func deliveryServiceTTLs(ds tc.CRConfigDeliveryService) ttls {
+	t := ttls{}
+	if ds.TTLs == nil {
+		return t
+	}
+	for rrType, v := range map[string]*string{
+		"A":    ds.TTLs.ASeconds,
+		"AAAA": ds.TTLs.AAAASeconds,
+		"NS":   ds.TTLs.NSSeconds,
+		"SOA":  ds.TTLs.SOASeconds,
+	} {
+		if v == nil {
+			continue
+		}
+		if i, err := strconv.ParseUint(*v, 10, 32); err == nil {
+			t[rrType] = uint32(i)
+		}
+	}
+	return t
+}
+
+func deliveryServiceSOA(ds tc.CRConfigDeliveryService) soaParams {
+	s := soaParams{}
+	if ds.Soa == nil {
+		return s
+	}
+	for key, v := range map[string]*string{
+		"admin":   ds.Soa.Admin,
+		"expire":  ds.Soa.ExpireSeconds,
+		"minimum": ds.Soa.MinimumSeconds,
+		"refresh": ds.Soa.RefreshSeconds,
+		"retry":   ds.Soa.RetrySeconds,
+	} {
+		if v != nil {
+			s[key] = *v
+		}
+	}
+	return s
+}
+
+// configTTLs returns the TTLs in the "ttls" map of a Snapshot's config.
+func configTTLs(config map[string]interface{}) ttls {
+	t := ttls{}
+	for rrType, v := range configMap(config, "ttls") {
+		if i, err := strconv.ParseUint(v, 10, 32); err == nil {
+			t[rrType] = uint32(i)
+		}
+	}
+	return t
+}
+
+// configSOA returns the parameters in the "soa" map of a Snapshot's config.
+func configSOA(config map[string]interface{}) soaParams {
+	return soaParams(configMap(config, "soa"))
+}
+
+// configMap returns the string values of the map with the given key in a
+// Snapshot's config, which aren't guaranteed to be anything in particular.
+func configMap(config map[string]interface{}, key string) map[string]string {
+	m := map[string]string{}
+	values, ok := config[key].(map[string]interface{})
+	if !ok {
+		return m
+	}
+	for k, v := range values {
+		switch v := v.(type) {
+		case string:
+			m[k] = v
+		case float64:
+			m[k] = strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+	return m
+}
+
+func configString(config map[string]interface{}, key string) string {
+	v, _ := config[key].(string)
+	return v
+}
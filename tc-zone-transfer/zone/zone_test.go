@@ -0,0 +1,145 @@
+package zone
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+const testSnapshot = `{
+	"config": {
+		"domain_name": "mycdn.example.com",
+		"soa": {"admin": "admin@example.com"}
+	},
+	"contentRouters": {
+		"tr1": {"fqdn": "tr1.example.com", "ip": "192.0.2.1", "ip6": "2001:db8::1/64", "status": "ONLINE"},
+		"tr2": {"fqdn": "tr2.example.com", "ip": "192.0.2.2", "status": "OFFLINE"}
+	},
+	"contentServers": {
+		"edge1": {
+			"ip": "192.0.2.10",
+			"ip6": "2001:db8::10",
+			"deliveryServices": {
+				"http-ds": ["edge1.http-ds.mycdn.example.com"],
+				"dns-ds": ["edge.dns-ds.mycdn.example.com", "edge1.dns-ds.mycdn.example.com"]
+			}
+		}
+	},
+	"deliveryServices": {
+		"http-ds": {
+			"domains": ["http-ds.mycdn.example.com"],
+			"routingName": "cdn",
+			"ip6RoutingEnabled": "true",
+			"matchsets": [{"protocol": "HTTP"}],
+			"ttls": {"A": "30", "AAAA": "30", "NS": "3600", "SOA": "86400"},
+			"soa": {"admin": "hostmaster", "expire": "604800", "minimum": "30", "refresh": "28800", "retry": "7200"},
+			"staticDnsEntries": [
+				{"name": "www", "ttl": 0, "type": "CNAME", "value": "origin.example.com"},
+				{"name": "txt", "ttl": 10, "type": "TXT", "value": "hello"}
+			]
+		},
+		"dns-ds": {
+			"domains": ["dns-ds.mycdn.example.com"],
+			"routingName": "edge",
+			"ip6RoutingEnabled": "false",
+			"matchsets": [{"protocol": "DNS"}]
+		}
+	},
+	"stats": {"date": 1650000000}
+}`
+
+func TestBuild(t *testing.T) {
+	var crc tc.CRConfig
+	if err := json.Unmarshal([]byte(testSnapshot), &crc); err != nil {
+		t.Fatalf("unmarshalling test Snapshot: %v", err)
+	}
+	zones, err := Build(&crc)
+	if err != nil {
+		t.Fatalf("unexpected error building zones: %v", err)
+	}
+
+	expected := map[string]struct {
+		soa     string
+		records []string
+	}{
+		"http-ds.mycdn.example.com.": {
+			soa: "http-ds.mycdn.example.com.\t86400\tIN\tSOA\ttr1.mycdn.example.com. hostmaster.http-ds.mycdn.example.com. 0 28800 7200 604800 30",
+			records: []string{
+				"cdn.http-ds.mycdn.example.com.\t30\tIN\tA\t192.0.2.1",
+				"cdn.http-ds.mycdn.example.com.\t30\tIN\tAAAA\t2001:db8::1",
+				"edge1.http-ds.mycdn.example.com.\t30\tIN\tA\t192.0.2.10",
+				"edge1.http-ds.mycdn.example.com.\t30\tIN\tAAAA\t2001:db8::10",
+				"http-ds.mycdn.example.com.\t3600\tIN\tNS\ttr1.mycdn.example.com.",
+				"tr1.http-ds.mycdn.example.com.\t30\tIN\tA\t192.0.2.1",
+				"tr1.http-ds.mycdn.example.com.\t30\tIN\tAAAA\t2001:db8::1",
+				"txt.http-ds.mycdn.example.com.\t10\tIN\tTXT\t\"hello\"",
+				"www.http-ds.mycdn.example.com.\t60\tIN\tCNAME\torigin.example.com.",
+			},
+		},
+		"dns-ds.mycdn.example.com.": {
+			soa: "dns-ds.mycdn.example.com.\t86400\tIN\tSOA\ttr1.mycdn.example.com. traffic_ops.dns-ds.mycdn.example.com. 0 28800 7200 604800 60",
+			records: []string{
+				"dns-ds.mycdn.example.com.\t60\tIN\tNS\ttr1.mycdn.example.com.",
+				"edge1.dns-ds.mycdn.example.com.\t60\tIN\tA\t192.0.2.10",
+				"tr1.dns-ds.mycdn.example.com.\t60\tIN\tA\t192.0.2.1",
+			},
+		},
+		"mycdn.example.com.": {
+			soa: "mycdn.example.com.\t86400\tIN\tSOA\ttr1.example.com. admin.example.com. 0 28800 7200 604800 60",
+			records: []string{
+				"mycdn.example.com.\t60\tIN\tNS\ttr1.example.com.",
+				"tr1.mycdn.example.com.\t60\tIN\tA\t192.0.2.1",
+				"tr1.mycdn.example.com.\t60\tIN\tAAAA\t2001:db8::1",
+			},
+		},
+	}
+
+	if len(zones) != len(expected) {
+		t.Errorf("expected %d zones, got %d", len(expected), len(zones))
+	}
+	for name, exp := range expected {
+		z, ok := zones[name]
+		if !ok {
+			t.Errorf("expected a zone named '%s', but it wasn't built", name)
+			continue
+		}
+		if z.SOA.String() != exp.soa {
+			t.Errorf("incorrect SOA record for zone '%s'; expected: '%s', actual: '%s'", name, exp.soa, z.SOA.String())
+		}
+		if len(z.Records) != len(exp.records) {
+			t.Errorf("expected zone '%s' to have %d records, got %d: %v", name, len(exp.records), len(z.Records), z.Records)
+			continue
+		}
+		for i, rr := range z.Records {
+			if rr.String() != exp.records[i] {
+				t.Errorf("incorrect record #%d of zone '%s'; expected: '%s', actual: '%s'", i, name, exp.records[i], rr.String())
+			}
+		}
+	}
+}
+
+func TestBuildNoDomain(t *testing.T) {
+	if _, err := Build(&tc.CRConfig{}); err == nil {
+		t.Error("expected an error building the zones of a Snapshot with no domain_name, but didn't get one")
+	}
+}
@@ -0,0 +1,327 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/netip"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// Config is the configuration of the Coverage Zone File to build: the route
+// data of each POP, from which the networks of its zone are taken.
+type Config struct {
+	// CustomerName is the customerName of the Coverage Zone File.
+	CustomerName string `json:"customerName"`
+	// Zones are the zones of the Coverage Zone File, by the names of their
+	// Cache Groups.
+	Zones map[string]ZoneConfig `json:"zones"`
+}
+
+// ZoneConfig is the configuration of one zone of a Coverage Zone File.
+type ZoneConfig struct {
+	// Sources are the files of route data of the zone's POP.
+	Sources []SourceConfig `json:"sources"`
+	// Networks are networks added to the zone regardless of its sources.
+	Networks []string `json:"networks"`
+	// Exclude are networks which are left out of the zone, along with all
+	// of the networks within them.
+	Exclude []string `json:"exclude"`
+	// Coordinates are where Traffic Router locates clients in the zone, if
+	// not at its Cache Group.
+	Coordinates *tc.CoverageZoneCoordinates `json:"coordinates"`
+}
+
+// SourceConfig is a file of route data.
+type SourceConfig struct {
+	// Path is the path of the file.
+	Path string `json:"path"`
+	// Format is the format of the file - FormatBGPDump, FormatRPSL, or
+	// FormatPrefixes.
+	Format string `json:"format"`
+	// OriginASNs, if given, are the only origin ASes whose routes are used.
+	// They must be empty for FormatPrefixes, whose routes have no origins.
+	OriginASNs []uint32 `json:"originAsns"`
+}
+
+// loadConfig reads the configuration at the given path.
+func loadConfig(path string) (Config, error) {
+	cfg := Config{}
+	bts, err := ioutil.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(bts, &cfg); err != nil {
+		return cfg, err
+	}
+	if len(cfg.Zones) == 0 {
+		return cfg, fmt.Errorf("zones: cannot be empty")
+	}
+	for name, zone := range cfg.Zones {
+		if len(zone.Sources) == 0 && len(zone.Networks) == 0 {
+			return cfg, fmt.Errorf("zones.%s: must have sources or networks", name)
+		}
+		for i, src := range zone.Sources {
+			if src.Path == "" {
+				return cfg, fmt.Errorf("zones.%s.sources[%d].path: required", name, i)
+			}
+			if src.Format == FormatPrefixes && len(src.OriginASNs) > 0 {
+				return cfg, fmt.Errorf("zones.%s.sources[%d].originAsns: cannot be used with format '%s'", name, i, FormatPrefixes)
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// readZoneNetworks returns the networks of the zone read from its sources,
+// along with its configured networks, less those excluded.
+func readZoneNetworks(zone ZoneConfig) ([]netip.Prefix, error) {
+	networks := []netip.Prefix{}
+	for _, network := range zone.Networks {
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network '%s'", network)
+		}
+		networks = append(networks, prefix.Masked())
+	}
+	for _, src := range zone.Sources {
+		f, err := os.Open(src.Path)
+		if err != nil {
+			return nil, err
+		}
+		routes, err := parseRoutes(f, src.Format)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", src.Path, err)
+		}
+		networks = append(networks, filterOrigins(routes, src.OriginASNs)...)
+	}
+
+	excluded := make([]netip.Prefix, 0, len(zone.Exclude))
+	for _, network := range zone.Exclude {
+		prefix, err := netip.ParsePrefix(network)
+		if err != nil {
+			return nil, fmt.Errorf("invalid excluded network '%s'", network)
+		}
+		excluded = append(excluded, prefix.Masked())
+	}
+	kept := networks[:0]
+	for _, network := range networks {
+		// a default route would cover every client
+		if network.Bits() == 0 || coveredBy(network, excluded) {
+			continue
+		}
+		kept = append(kept, network)
+	}
+	return kept, nil
+}
+
+// filterOrigins returns the networks of those of the given routes originated
+// by one of the given ASes, or of all of them if none are given.
+func filterOrigins(routes []route, origins []uint32) []netip.Prefix {
+	allowed := make(map[uint32]struct{}, len(origins))
+	for _, asn := range origins {
+		allowed[asn] = struct{}{}
+	}
+	networks := make([]netip.Prefix, 0, len(routes))
+	for _, r := range routes {
+		if _, ok := allowed[r.origin]; ok || len(origins) == 0 {
+			networks = append(networks, r.prefix)
+		}
+	}
+	return networks
+}
+
+func coveredBy(network netip.Prefix, covers []netip.Prefix) bool {
+	for _, cover := range covers {
+		if cover.Bits() <= network.Bits() && cover.Contains(network.Addr()) {
+			return true
+		}
+	}
+	return false
+}
+
+// build returns the Coverage Zone File of the given networks of each zone,
+// and warnings about the networks left out of it. Networks in more than one
+// zone are left out of all of them, since Traffic Router can't choose between
+// them. The networks of each zone are then aggregated, without changing the
+// zone to which Traffic Router maps any address: networks within a larger
+// network of the same zone - and no more specific network of another zone -
+// are removed, and pairs of adjacent networks which make up a larger one are
+// merged.
+func build(cfg Config, zoneNetworks map[string][]netip.Prefix) (tc.CoverageZoneFile, []string) {
+	warnings := []string{}
+	owner := map[netip.Prefix]string{}
+	conflicts := map[netip.Prefix][]string{}
+	for _, zone := range sortedKeys(zoneNetworks) {
+		for _, network := range zoneNetworks[zone] {
+			if other, ok := owner[network]; ok && other != zone {
+				conflicts[network] = append(conflicts[network], zone)
+				continue
+			}
+			owner[network] = zone
+		}
+	}
+	for network, zones := range conflicts {
+		warnings = append(warnings, fmt.Sprintf("%s is in zones %s and %s; it's left out of all of them", network, owner[network], strings.Join(zones, " and ")))
+		delete(owner, network)
+	}
+	sort.Strings(warnings)
+
+	aggregate(owner)
+
+	file := tc.CoverageZoneFile{
+		CustomerName:  cfg.CustomerName,
+		CoverageZones: map[string]tc.CoverageZoneLocation{},
+	}
+	byZone := map[string][]netip.Prefix{}
+	for network, zone := range owner {
+		byZone[zone] = append(byZone[zone], network)
+	}
+	zones := make([]string, 0, len(cfg.Zones))
+	for zone := range cfg.Zones {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	for _, zone := range zones {
+		networks := byZone[zone]
+		if len(networks) == 0 {
+			warnings = append(warnings, "zone "+zone+" has no networks; it's left out")
+			continue
+		}
+		tc.SortPrefixes(networks)
+		location := tc.CoverageZoneLocation{Coordinates: cfg.Zones[zone].Coordinates}
+		for _, network := range networks {
+			if network.Addr().Is4() {
+				location.Network = append(location.Network, network.String())
+			} else {
+				location.Network6 = append(location.Network6, network.String())
+			}
+		}
+		file.CoverageZones[zone] = location
+	}
+	return file, warnings
+}
+
+// aggregate removes redundant networks from, and merges adjacent networks
+// of, the given map of networks to the zones they're in, until it can't be
+// aggregated any further.
+func aggregate(owner map[netip.Prefix]string) {
+	for changed := true; changed; {
+		changed = false
+		networks := make([]netip.Prefix, 0, len(owner))
+		for network := range owner {
+			networks = append(networks, network)
+		}
+		// longest first, so that merged networks are merged again
+		sort.Slice(networks, func(i, j int) bool {
+			if networks[i].Bits() != networks[j].Bits() {
+				return networks[i].Bits() > networks[j].Bits()
+			}
+			return networks[i].Addr().Less(networks[j].Addr())
+		})
+		for _, network := range networks {
+			zone, ok := owner[network]
+			if !ok {
+				continue
+			}
+			if container, ok := longestContainer(owner, network); ok && owner[container] == zone {
+				delete(owner, network)
+				changed = true
+				continue
+			}
+			sibling, parent := siblingOf(network)
+			if network.Bits() == 0 || owner[sibling] != zone {
+				continue
+			}
+			if _, ok := owner[parent]; ok {
+				continue
+			}
+			delete(owner, network)
+			delete(owner, sibling)
+			owner[parent] = zone
+			changed = true
+		}
+	}
+}
+
+// longestContainer returns the longest of the networks of owner which
+// contains - but isn't - the given network, if there is one.
+func longestContainer(owner map[netip.Prefix]string, network netip.Prefix) (netip.Prefix, bool) {
+	for bits := network.Bits() - 1; bits >= 0; bits-- {
+		container, _ := network.Addr().Prefix(bits)
+		if _, ok := owner[container]; ok {
+			return container, true
+		}
+	}
+	return netip.Prefix{}, false
+}
+
+// siblingOf returns the network which, with the given network, makes up the
+// network one bit shorter, and that network.
+func siblingOf(network netip.Prefix) (netip.Prefix, netip.Prefix) {
+	if network.Bits() == 0 {
+		return network, network
+	}
+	bytes := network.Addr().AsSlice()
+	bit := network.Bits() - 1
+	bytes[bit/8] ^= 0x80 >> (bit % 8)
+	addr, _ := netip.AddrFromSlice(bytes)
+	parent, _ := network.Addr().Prefix(bit)
+	return netip.PrefixFrom(addr, network.Bits()), parent
+}
+
+func sortedKeys(m map[string][]netip.Prefix) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatDiffs returns the given differences between Coverage Zone Files in a
+// human-readable form.
+func formatDiffs(diffs []tc.CoverageZoneDiff) string {
+	b := strings.Builder{}
+	for _, diff := range diffs {
+		action := "changed"
+		if diff.Added {
+			action = "added"
+		} else if diff.Removed {
+			action = "removed"
+		}
+		fmt.Fprintf(&b, "%s: %s\n", diff.Zone, action)
+		for _, network := range diff.AddedNetworks {
+			fmt.Fprintf(&b, "\t+ %s\n", network)
+		}
+		for _, network := range diff.RemovedNetworks {
+			fmt.Fprintf(&b, "\t- %s\n", network)
+		}
+		if diff.CoordinatesChanged {
+			b.WriteString("\tcoordinates changed\n")
+		}
+	}
+	return b.String()
+}
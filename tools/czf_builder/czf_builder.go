@@ -0,0 +1,212 @@
+/*
+
+Name
+	czf_builder
+
+Synopsis
+	czf_builder --config value [--current value] [--output value] [--to-url value --to-user value --cdn value [--apply]] [--insecure] [--timeout value]
+
+Description
+  The czf_builder app builds a Coverage Zone File from the BGP routes or
+  Internet Routing Registry data of each POP, so that the file follows the
+  network instead of drifting from it between manual updates. It prints the
+  differences between the built file and the current one - read from a file,
+  or from the CDN's Coverage Zone File in Traffic Ops - and uploads the built
+  file to Traffic Ops if asked.
+
+  The password of the Traffic Ops user is read from the TO_PASSWORD
+  environment variable.
+
+Options
+	--config
+        The path of the configuration of the zones and the route data of
+        their POPs.
+
+	--current
+        The path of the current Coverage Zone File, to which the built file is
+        compared.
+
+	--output
+        The path to which the built Coverage Zone File is written, or "-" for
+        standard output.
+
+	--to-url, --to-user
+        The URL of Traffic Ops, and the user as whom to log in to it.
+
+	--cdn
+        The name of the CDN whose Coverage Zone File in Traffic Ops the built
+        file is compared to and replaces.
+
+	--apply
+        Upload the built file to Traffic Ops, if it differs from the CDN's
+        current one. Without this, the differences are only printed.
+
+	--insecure
+        Don't verify the TLS certificate of Traffic Ops.
+
+	--timeout
+        The timeout of requests to Traffic Ops. Default is 30s.
+
+*/
+
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/netip"
+	"os"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	client "github.com/apache/trafficcontrol/traffic_ops/v5-client"
+)
+
+const userAgent = "czf_builder"
+
+func main() {
+	configFile := flag.String("config", "", "The path of the configuration of the zones and the route data of their POPs.")
+	currentFile := flag.String("current", "", "(Optional) The path of the current Coverage Zone File, to which the built file is compared.")
+	outputFile := flag.String("output", "", "(Optional) The path to which the built Coverage Zone File is written, or - for standard output.")
+	toURL := flag.String("to-url", "", "(Optional) The URL of Traffic Ops.")
+	toUser := flag.String("to-user", "", "(Optional) The user as whom to log in to Traffic Ops. The password is read from the TO_PASSWORD environment variable.")
+	cdn := flag.String("cdn", "", "(Optional) The name of the CDN whose Coverage Zone File in Traffic Ops the built file is compared to and replaces.")
+	apply := flag.Bool("apply", false, "(Optional) Upload the built file to Traffic Ops, if it differs from the CDN's current one. Without this, the differences are only printed.")
+	insecure := flag.Bool("insecure", false, "(Optional) Don't verify the TLS certificate of Traffic Ops.")
+	timeout := flag.Duration("timeout", 30*time.Second, "(Optional) The timeout of requests to Traffic Ops.")
+	help := flag.Bool("help", false, "(Optional) Print usage information and exit.")
+	flag.Parse()
+
+	if *help {
+		flag.Usage()
+		os.Exit(0)
+	}
+	if *configFile == "" {
+		die("the --config option is required")
+	}
+	useTO := *toURL != "" || *toUser != "" || *cdn != ""
+	if useTO && (*toURL == "" || *toUser == "" || *cdn == "") {
+		die("the --to-url, --to-user and --cdn options must be used together")
+	}
+	if useTO && *currentFile != "" {
+		die("the --current option can't be used with Traffic Ops")
+	}
+	if *apply && !useTO {
+		die("the --apply option requires --to-url, --to-user and --cdn")
+	}
+
+	cfg, err := loadConfig(*configFile)
+	if err != nil {
+		die("reading config '" + *configFile + "': " + err.Error())
+	}
+	zoneNetworks := make(map[string][]netip.Prefix, len(cfg.Zones))
+	for name, zone := range cfg.Zones {
+		if zoneNetworks[name], err = readZoneNetworks(zone); err != nil {
+			die("zone " + name + ": " + err.Error())
+		}
+	}
+	built, warnings := build(cfg, zoneNetworks)
+	for _, warning := range warnings {
+		fmt.Fprintln(os.Stderr, "WARNING: "+warning)
+	}
+	built.Revision = time.Now().UTC().Format(time.RFC3339)
+	if err := built.Validate(); err != nil {
+		die("built an invalid Coverage Zone File: " + err.Error())
+	}
+
+	if *outputFile != "" {
+		if err := writeFile(*outputFile, built); err != nil {
+			die("writing Coverage Zone File: " + err.Error())
+		}
+	}
+
+	current := tc.CoverageZoneFile{}
+	var session *client.Session
+	switch {
+	case *currentFile != "":
+		if current, err = readFile(*currentFile); err != nil {
+			die("reading current Coverage Zone File '" + *currentFile + "': " + err.Error())
+		}
+	case useTO:
+		if session, _, err = client.LoginWithAgent(*toURL, *toUser, os.Getenv("TO_PASSWORD"), *insecure, userAgent, false, *timeout); err != nil {
+			die("logging in to Traffic Ops: " + err.Error())
+		}
+		resp, reqInf, err := session.GetCDNCoverageZones(*cdn, client.NewRequestOptions())
+		if err != nil && reqInf.StatusCode != http.StatusNotFound {
+			die("getting the Coverage Zone File of CDN '" + *cdn + "': " + err.Error())
+		}
+		current = resp.Response.File
+	default:
+		return
+	}
+	if err := current.Validate(); err != nil && len(current.CoverageZones) > 0 {
+		fmt.Fprintln(os.Stderr, "WARNING: the current Coverage Zone File is invalid: "+err.Error())
+	}
+
+	diffs := tc.DiffCoverageZoneFiles(current, built)
+	if len(diffs) == 0 {
+		fmt.Println("no changes")
+		return
+	}
+	fmt.Print(formatDiffs(diffs))
+	if !*apply {
+		return
+	}
+	resp, _, err := session.UpdateCDNCoverageZones(*cdn, built, client.NewRequestOptions())
+	if err != nil {
+		die("uploading the Coverage Zone File of CDN '" + *cdn + "': " + err.Error())
+	}
+	for _, alert := range resp.Alerts.Alerts {
+		fmt.Println(alert.Text)
+	}
+}
+
+// readFile reads the Coverage Zone File at the given path.
+func readFile(path string) (tc.CoverageZoneFile, error) {
+	file := tc.CoverageZoneFile{}
+	bts, err := ioutil.ReadFile(path)
+	if err != nil {
+		return file, err
+	}
+	return file, json.Unmarshal(bts, &file)
+}
+
+// writeFile writes the Coverage Zone File to the given path, or to standard
+// output if it's "-".
+func writeFile(path string, file tc.CoverageZoneFile) error {
+	bts, err := json.MarshalIndent(file, "", "\t")
+	if err != nil {
+		return err
+	}
+	bts = append(bts, '\n')
+	if path == "-" {
+		_, err = os.Stdout.Write(bts)
+		return err
+	}
+	return ioutil.WriteFile(path, bts, 0644)
+}
+
+func die(message string) {
+	fmt.Fprintln(os.Stderr, message)
+	os.Exit(1)
+}
@@ -0,0 +1,163 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+import (
+	"net/netip"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseBGPDump(t *testing.T) {
+	dump := `TABLE_DUMP2|1617235200|B|192.0.2.1|64496|198.51.100.0/24|64496 64511|IGP|192.0.2.1|0|0||NAG||
+TABLE_DUMP2|1617235200|B|192.0.2.1|64496|203.0.113.0/25|64496 64512|IGP|192.0.2.1|0|0||NAG||
+BGP4MP|1617235201|W|192.0.2.1|64496|203.0.113.0/25
+BGP4MP|1617235202|A|192.0.2.1|64496|2001:db8::/32|64496 {64513,64514}|IGP|192.0.2.1|0|0||NAG||
+`
+	routes, err := parseRoutes(strings.NewReader(dump), FormatBGPDump)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []route{
+		{prefix: netip.MustParsePrefix("198.51.100.0/24"), origin: 64511},
+		{prefix: netip.MustParsePrefix("2001:db8::/32"), origin: 0},
+	}
+	if !reflect.DeepEqual(routes, expected) {
+		t.Errorf("expected routes %v, got %v", expected, routes)
+	}
+
+	if _, err := parseRoutes(strings.NewReader("TABLE_DUMP2|1617235200|X|192.0.2.1|64496|198.51.100.0/24"), FormatBGPDump); err == nil {
+		t.Error("expected an error for an unknown entry type, got none")
+	}
+}
+
+func TestParseRPSL(t *testing.T) {
+	rpsl := `% comment
+route:          198.51.100.0/24
+descr:          POP one,
+                continued
+origin:         AS64511
+source:         TEST
+
+route6:         2001:db8::/32
+origin:         as64512 # comment
+
+route:          203.0.113.0/24
+`
+	routes, err := parseRoutes(strings.NewReader(rpsl), FormatRPSL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []route{
+		{prefix: netip.MustParsePrefix("198.51.100.0/24"), origin: 64511},
+		{prefix: netip.MustParsePrefix("2001:db8::/32"), origin: 64512},
+		{prefix: netip.MustParsePrefix("203.0.113.0/24"), origin: 0},
+	}
+	if !reflect.DeepEqual(routes, expected) {
+		t.Errorf("expected routes %v, got %v", expected, routes)
+	}
+}
+
+func TestParsePrefixes(t *testing.T) {
+	routes, err := parseRoutes(strings.NewReader("# comment\n198.51.100.1/24\n\n2001:db8::/32\n"), FormatPrefixes)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []route{
+		{prefix: netip.MustParsePrefix("198.51.100.0/24")},
+		{prefix: netip.MustParsePrefix("2001:db8::/32")},
+	}
+	if !reflect.DeepEqual(routes, expected) {
+		t.Errorf("expected routes %v, got %v", expected, routes)
+	}
+
+	if _, err := parseRoutes(strings.NewReader("not a prefix\n"), FormatPrefixes); err == nil {
+		t.Error("expected an error for an invalid prefix, got none")
+	}
+	if _, err := parseRoutes(strings.NewReader(""), "mrt"); err == nil {
+		t.Error("expected an error for an unknown format, got none")
+	}
+}
+
+func prefixes(networks ...string) []netip.Prefix {
+	parsed := make([]netip.Prefix, 0, len(networks))
+	for _, network := range networks {
+		parsed = append(parsed, netip.MustParsePrefix(network))
+	}
+	return parsed
+}
+
+func TestBuild(t *testing.T) {
+	cfg := Config{
+		CustomerName: "Kabletown",
+		Zones: map[string]ZoneConfig{
+			"pop-a": {},
+			"pop-b": {},
+			"pop-c": {},
+		},
+	}
+	zoneNetworks := map[string][]netip.Prefix{
+		// the /25s merge, and the /26 is covered by the result
+		"pop-a": prefixes("198.51.100.0/25", "198.51.100.128/25", "198.51.100.64/26", "192.0.2.0/24", "2001:db8::/33", "2001:db8:8000::/33"),
+		// more specific than a network of pop-a, so it stays
+		"pop-b": prefixes("198.51.100.0/28", "192.0.2.0/24"),
+		"pop-c": prefixes("192.0.2.0/24"),
+	}
+
+	file, warnings := build(cfg, zoneNetworks)
+	if file.CustomerName != cfg.CustomerName {
+		t.Errorf("expected customer name '%s', got '%s'", cfg.CustomerName, file.CustomerName)
+	}
+	expectedWarnings := []string{
+		"192.0.2.0/24 is in zones pop-a and pop-b and pop-c; it's left out of all of them",
+		"zone pop-c has no networks; it's left out",
+	}
+	if !reflect.DeepEqual(warnings, expectedWarnings) {
+		t.Errorf("expected warnings %v, got %v", expectedWarnings, warnings)
+	}
+	if len(file.CoverageZones) != 2 {
+		t.Fatalf("expected 2 zones, got %d: %v", len(file.CoverageZones), file.CoverageZones)
+	}
+	a := file.CoverageZones["pop-a"]
+	if !reflect.DeepEqual(a.Network, []string{"198.51.100.0/24"}) || !reflect.DeepEqual(a.Network6, []string{"2001:db8::/32"}) {
+		t.Errorf("expected pop-a networks [198.51.100.0/24] and [2001:db8::/32], got %v and %v", a.Network, a.Network6)
+	}
+	b := file.CoverageZones["pop-b"]
+	if !reflect.DeepEqual(b.Network, []string{"198.51.100.0/28"}) || len(b.Network6) != 0 {
+		t.Errorf("expected pop-b networks [198.51.100.0/28] and [], got %v and %v", b.Network, b.Network6)
+	}
+	if err := file.Validate(); err != nil {
+		t.Errorf("expected a valid Coverage Zone File, got: %v", err)
+	}
+}
+
+func TestAggregateDoesNotMergeAcrossZones(t *testing.T) {
+	owner := map[netip.Prefix]string{
+		netip.MustParsePrefix("198.51.100.0/25"):   "pop-a",
+		netip.MustParsePrefix("198.51.100.128/25"): "pop-b",
+	}
+	expected := map[netip.Prefix]string{}
+	for network, zone := range owner {
+		expected[network] = zone
+	}
+	aggregate(owner)
+	if !reflect.DeepEqual(owner, expected) {
+		t.Errorf("expected networks %v, got %v", expected, owner)
+	}
+}
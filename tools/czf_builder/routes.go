@@ -0,0 +1,233 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// Source formats.
+const (
+	// FormatBGPDump is the one-line-per-route format of "bgpdump -m", as
+	// made from MRT RIB dumps and update files.
+	FormatBGPDump = "bgpdump"
+	// FormatRPSL is the Routing Policy Specification Language of Internet
+	// Routing Registries, of which only route and route6 objects are read.
+	FormatRPSL = "rpsl"
+	// FormatPrefixes is a list of CIDR-notation networks, one per line, as
+	// made by e.g. bgpq4 -F "%n/%l\n".
+	FormatPrefixes = "prefixes"
+)
+
+// route is a network and the AS which originates it, which is 0 if it's
+// unknown.
+type route struct {
+	prefix netip.Prefix
+	origin uint32
+}
+
+// parseRoutes returns the routes read from r in the given format.
+func parseRoutes(r io.Reader, format string) ([]route, error) {
+	switch format {
+	case FormatBGPDump:
+		return parseBGPDump(r)
+	case FormatRPSL:
+		return parseRPSL(r)
+	case FormatPrefixes:
+		return parsePrefixes(r)
+	}
+	return nil, fmt.Errorf("unknown format '%s' - must be one of '%s', '%s', or '%s'", format, FormatBGPDump, FormatRPSL, FormatPrefixes)
+}
+
+// parseBGPDump returns the routes of the given "bgpdump -m" output, whose
+// lines are like:
+//
+//	TABLE_DUMP2|1617235200|B|192.0.2.1|64496|198.51.100.0/24|64496 64511|IGP|192.0.2.1|0|0||NAG||
+//
+// Announcements (A) and RIB entries (B) add routes, and withdrawals (W)
+// remove them, in the order they're read.
+func parseBGPDump(r io.Reader) ([]route, error) {
+	routes := map[netip.Prefix]uint32{}
+	order := []netip.Prefix{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Split(strings.TrimSpace(scanner.Text()), "|")
+		if len(fields) < 6 {
+			if len(fields) > 1 {
+				return nil, fmt.Errorf("line %d: expected at least 6 fields, got %d", line, len(fields))
+			}
+			continue
+		}
+		prefix, err := netip.ParsePrefix(fields[5])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid prefix '%s'", line, fields[5])
+		}
+		prefix = prefix.Masked()
+		switch fields[2] {
+		case "A", "B":
+			var origin uint32
+			if len(fields) > 6 {
+				origin = originOfPath(fields[6])
+			}
+			if _, ok := routes[prefix]; !ok {
+				order = append(order, prefix)
+			}
+			routes[prefix] = origin
+		case "W":
+			delete(routes, prefix)
+		default:
+			return nil, fmt.Errorf("line %d: unknown entry type '%s'", line, fields[2])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	parsed := make([]route, 0, len(routes))
+	for _, prefix := range order {
+		if origin, ok := routes[prefix]; ok {
+			parsed = append(parsed, route{prefix: prefix, origin: origin})
+			delete(routes, prefix)
+		}
+	}
+	return parsed, nil
+}
+
+// originOfPath returns the origin AS of the given AS path - its last AS - or
+// 0 if it ends in an AS set, whose origin is ambiguous.
+func originOfPath(path string) uint32 {
+	asns := strings.Fields(path)
+	if len(asns) == 0 {
+		return 0
+	}
+	origin, err := strconv.ParseUint(asns[len(asns)-1], 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint32(origin)
+}
+
+// parseRPSL returns the routes of the route and route6 objects of the given
+// RPSL, e.g. an IRR database dump or the output of a whois query.
+func parseRPSL(r io.Reader) ([]route, error) {
+	parsed := []route{}
+	var prefix, origin string
+	var inRoute bool
+	var key string
+	finish := func() error {
+		if inRoute {
+			p, err := netip.ParsePrefix(prefix)
+			if err != nil {
+				return fmt.Errorf("invalid route '%s'", prefix)
+			}
+			parsed = append(parsed, route{prefix: p.Masked(), origin: parseASN(origin)})
+		}
+		prefix, origin, inRoute, key = "", "", false, ""
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.HasPrefix(text, "%") || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if strings.TrimSpace(text) == "" {
+			if err := finish(); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			continue
+		}
+		value := text
+		if text[0] == ' ' || text[0] == '\t' || text[0] == '+' {
+			// a continuation of the previous attribute
+			value = strings.TrimLeft(text, " \t+")
+		} else {
+			colon := strings.Index(text, ":")
+			if colon < 0 {
+				return nil, fmt.Errorf("line %d: expected an attribute", line)
+			}
+			key = strings.ToLower(strings.TrimSpace(text[:colon]))
+			value = text[colon+1:]
+			if key == "route" || key == "route6" {
+				inRoute = true
+			}
+		}
+		if comment := strings.Index(value, "#"); comment >= 0 {
+			value = value[:comment]
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "route", "route6":
+			prefix += value
+		case "origin":
+			origin += value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// parseASN returns the AS number of the given "AS"-prefixed AS, or 0 if it
+// isn't one.
+func parseASN(as string) uint32 {
+	as = strings.TrimSpace(as)
+	if len(as) < 3 || !strings.EqualFold(as[:2], "AS") {
+		return 0
+	}
+	asn, err := strconv.ParseUint(as[2:], 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint32(asn)
+}
+
+// parsePrefixes returns the routes of the given list of networks, whose
+// origins are unknown. Blank lines and comments starting with "#" are
+// ignored.
+func parsePrefixes(r io.Reader) ([]route, error) {
+	parsed := []route{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if comment := strings.Index(text, "#"); comment >= 0 {
+			text = text[:comment]
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		prefix, err := netip.ParsePrefix(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid network '%s'", line, text)
+		}
+		parsed = append(parsed, route{prefix: prefix.Masked()})
+	}
+	return parsed, scanner.Err()
+}
@@ -0,0 +1,181 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// Mapping holds the rules by which the environment-specific values of the
+// configuration of the source Traffic Ops are mapped to those of the target.
+type Mapping struct {
+	// CDNs maps the names of CDNs in the source to their names in the
+	// target.
+	CDNs map[string]string `json:"cdns"`
+	// Profiles maps the names of Profiles in the source to their names in
+	// the target.
+	Profiles map[string]string `json:"profiles"`
+	// Tenants maps the names of Tenants in the source to their names in the
+	// target.
+	Tenants map[string]string `json:"tenants"`
+	// Topologies maps the names of Topologies in the source to their names
+	// in the target.
+	Topologies map[string]string `json:"topologies"`
+	// Replacements are applied, in order, to every other string in the
+	// configuration - such as origin and bypass FQDNs, and raw remap text.
+	Replacements []Replacement `json:"replacements"`
+	// IgnoreFields are the names of the fields of Delivery Services, as in
+	// the Traffic Ops API, which are neither compared nor promoted.
+	IgnoreFields []string `json:"ignoreFields"`
+}
+
+// Replacement replaces all matches of a regular expression.
+type Replacement struct {
+	// Pattern is the regular expression to match, in the syntax of Go's
+	// regexp package.
+	Pattern string `json:"pattern"`
+	// Replacement is the text with which matches are replaced, in which $1
+	// and so on refer to the submatches of the pattern.
+	Replacement string `json:"replacement"`
+
+	regexp *regexp.Regexp
+}
+
+// nameFields are the fields of Delivery Services which refer to other objects
+// by name, which are mapped by name rather than by Replacements.
+var nameFields = map[string]struct{}{
+	"xmlId":       {},
+	"cdnName":     {},
+	"profileName": {},
+	"tenant":      {},
+	"topology":    {},
+	"type":        {},
+}
+
+// loadMapping reads a Mapping from the file at the given path.
+func loadMapping(path string) (Mapping, error) {
+	m := Mapping{}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return m, err
+	}
+	if err := json.Unmarshal(data, &m); err != nil {
+		return m, err
+	}
+	if err := m.compile(); err != nil {
+		return m, err
+	}
+	return m, nil
+}
+
+func (m *Mapping) compile() error {
+	for i, r := range m.Replacements {
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return fmt.Errorf("replacement %d: invalid pattern '%s': %w", i, r.Pattern, err)
+		}
+		m.Replacements[i].regexp = re
+	}
+	return nil
+}
+
+// mapName returns the name to which the given name is mapped by names, or
+// the name itself if it isn't mapped.
+func mapName(names map[string]string, name *string) *string {
+	if name == nil {
+		return nil
+	}
+	if mapped, ok := names[*name]; ok {
+		return &mapped
+	}
+	return name
+}
+
+// apply returns the given Delivery Service of the source with its
+// environment-specific values mapped to those of the target.
+func (m Mapping) apply(ds tc.DeliveryServiceV4) (tc.DeliveryServiceV4, error) {
+	ds.CDNName = mapName(m.CDNs, ds.CDNName)
+	ds.ProfileName = mapName(m.Profiles, ds.ProfileName)
+	ds.Tenant = mapName(m.Tenants, ds.Tenant)
+	ds.Topology = mapName(m.Topologies, ds.Topology)
+	if len(m.Replacements) == 0 {
+		return ds, nil
+	}
+
+	fields, err := toFields(ds)
+	if err != nil {
+		return ds, err
+	}
+	for field, value := range fields {
+		if _, ok := nameFields[field]; ok {
+			continue
+		}
+		fields[field] = m.replace(value)
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return ds, fmt.Errorf("encoding mapped Delivery Service: %w", err)
+	}
+	mapped := tc.DeliveryServiceV4{}
+	if err := json.Unmarshal(data, &mapped); err != nil {
+		return ds, fmt.Errorf("decoding mapped Delivery Service: %w", err)
+	}
+	return mapped, nil
+}
+
+// replace applies the Replacements to every string in the given JSON value.
+func (m Mapping) replace(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		for _, r := range m.Replacements {
+			v = r.regexp.ReplaceAllString(v, r.Replacement)
+		}
+		return v
+	case []interface{}:
+		for i := range v {
+			v[i] = m.replace(v[i])
+		}
+		return v
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = m.replace(v[k])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// toFields returns the fields of the Delivery Service as they're encoded in
+// the Traffic Ops API.
+func toFields(ds tc.DeliveryServiceV4) (map[string]interface{}, error) {
+	data, err := json.Marshal(ds)
+	if err != nil {
+		return nil, fmt.Errorf("encoding Delivery Service: %w", err)
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("decoding Delivery Service: %w", err)
+	}
+	return fields, nil
+}
@@ -0,0 +1,327 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	client "github.com/apache/trafficcontrol/traffic_ops/v5-client"
+)
+
+// readOnlyFields are the fields of Delivery Services which are set by Traffic
+// Ops, or which refer to other objects by ID or are otherwise unique to each
+// environment, so they're never compared.
+var readOnlyFields = map[string]struct{}{
+	"id":                 {},
+	"lastUpdated":        {},
+	"exampleURLs":        {},
+	"matchList":          {},
+	"cdnId":              {},
+	"profileId":          {},
+	"profileDescription": {},
+	"tenantId":           {},
+	"typeId":             {},
+	"sslKeyVersion":      {},
+}
+
+// action is what must be done to promote a Delivery Service to the target.
+type action string
+
+const (
+	actionCreate    action = "create"
+	actionUpdate    action = "update"
+	actionUnchanged action = "unchanged"
+)
+
+// fieldDiff is a difference in a single field of a Delivery Service between
+// the target and the promoted configuration.
+type fieldDiff struct {
+	field    string
+	target   interface{}
+	promoted interface{}
+}
+
+// change is the promotion of a single Delivery Service.
+type change struct {
+	xmlID  string
+	action action
+	diffs  []fieldDiff
+	// ds is the promoted Delivery Service, with the IDs of the target.
+	ds tc.DeliveryServiceV4
+}
+
+func (c change) String() string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "%s: %s\n", c.xmlID, c.action)
+	for _, d := range c.diffs {
+		target, _ := json.Marshal(d.target)
+		promoted, _ := json.Marshal(d.promoted)
+		fmt.Fprintf(&b, "\t%s: %s => %s\n", d.field, target, promoted)
+	}
+	return b.String()
+}
+
+// diffDeliveryServices returns the differences between the fields of the
+// Delivery Service in the target and the promoted Delivery Service, except
+// for read-only fields and the given ignored fields, ordered by field.
+func diffDeliveryServices(target, promoted tc.DeliveryServiceV4, ignore map[string]struct{}) ([]fieldDiff, error) {
+	targetFields, err := toFields(target)
+	if err != nil {
+		return nil, err
+	}
+	promotedFields, err := toFields(promoted)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]struct{}, len(promotedFields))
+	for field := range targetFields {
+		fields[field] = struct{}{}
+	}
+	for field := range promotedFields {
+		fields[field] = struct{}{}
+	}
+
+	diffs := []fieldDiff{}
+	for field := range fields {
+		if _, ok := readOnlyFields[field]; ok {
+			continue
+		}
+		if _, ok := ignore[field]; ok {
+			continue
+		}
+		if !reflect.DeepEqual(targetFields[field], promotedFields[field]) {
+			diffs = append(diffs, fieldDiff{field: field, target: targetFields[field], promoted: promotedFields[field]})
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].field < diffs[j].field })
+	return diffs, nil
+}
+
+// promoter promotes Delivery Services to the target Traffic Ops.
+type promoter struct {
+	target  *client.Session
+	mapping Mapping
+	ignore  map[string]struct{}
+	// ids caches the IDs of objects of the target, by kind and name.
+	ids map[string]map[string]int
+}
+
+func newPromoter(target *client.Session, mapping Mapping) *promoter {
+	ignore := make(map[string]struct{}, len(mapping.IgnoreFields))
+	for _, field := range mapping.IgnoreFields {
+		ignore[field] = struct{}{}
+	}
+	return &promoter{
+		target:  target,
+		mapping: mapping,
+		ignore:  ignore,
+		ids:     map[string]map[string]int{},
+	}
+}
+
+// plan returns the change which promotes the given Delivery Service of the
+// source to the target.
+func (p *promoter) plan(src tc.DeliveryServiceV4) (change, error) {
+	if src.XMLID == nil {
+		return change{}, errors.New("Delivery Service has no XMLID")
+	}
+	c := change{xmlID: *src.XMLID}
+
+	promoted, err := p.mapping.apply(src)
+	if err != nil {
+		return c, err
+	}
+	if err := p.resolveIDs(&promoted); err != nil {
+		return c, err
+	}
+	promoted.LastUpdated = nil
+
+	opts := client.NewRequestOptions()
+	opts.QueryParameters.Set("xmlId", c.xmlID)
+	resp, _, err := p.target.GetDeliveryServices(opts)
+	if err != nil {
+		return c, fmt.Errorf("getting Delivery Service from target: %w", err)
+	}
+	if len(resp.Response) == 0 {
+		promoted.ID = nil
+		c.action = actionCreate
+		c.ds = promoted
+		return c, nil
+	}
+
+	existing := resp.Response[0]
+	promoted.ID = existing.ID
+	// ignored fields keep their values in the target; they're only
+	// promoted with new Delivery Services
+	for field := range p.ignore {
+		if err := copyField(&promoted, existing, field); err != nil {
+			return c, err
+		}
+	}
+	if c.diffs, err = diffDeliveryServices(existing, promoted, p.ignore); err != nil {
+		return c, err
+	}
+	c.ds = promoted
+	c.action = actionUpdate
+	if len(c.diffs) == 0 {
+		c.action = actionUnchanged
+	}
+	return c, nil
+}
+
+// apply makes the change in the target.
+func (p *promoter) apply(c change) error {
+	switch c.action {
+	case actionCreate:
+		if _, _, err := p.target.CreateDeliveryService(c.ds, client.NewRequestOptions()); err != nil {
+			return fmt.Errorf("creating Delivery Service in target: %w", err)
+		}
+	case actionUpdate:
+		if _, _, err := p.target.UpdateDeliveryService(*c.ds.ID, c.ds, client.NewRequestOptions()); err != nil {
+			return fmt.Errorf("updating Delivery Service in target: %w", err)
+		}
+	}
+	return nil
+}
+
+// resolveIDs sets the IDs by which the Delivery Service refers to other
+// objects to those of the objects of the same names in the target.
+func (p *promoter) resolveIDs(ds *tc.DeliveryServiceV4) error {
+	if ds.CDNName == nil || ds.Tenant == nil || ds.Type == nil {
+		return errors.New("Delivery Service has no CDN, Tenant or Type")
+	}
+	cdnID, err := p.id("cdn", *ds.CDNName)
+	if err != nil {
+		return err
+	}
+	tenantID, err := p.id("tenant", *ds.Tenant)
+	if err != nil {
+		return err
+	}
+	typeID, err := p.id("type", ds.Type.String())
+	if err != nil {
+		return err
+	}
+	ds.CDNID = &cdnID
+	ds.TenantID = &tenantID
+	ds.TypeID = &typeID
+
+	ds.ProfileID = nil
+	if ds.ProfileName != nil && *ds.ProfileName != "" {
+		profileID, err := p.id("profile", *ds.ProfileName)
+		if err != nil {
+			return err
+		}
+		ds.ProfileID = &profileID
+	}
+	return nil
+}
+
+// id returns the ID of the object of the given kind and name in the target.
+func (p *promoter) id(kind, name string) (int, error) {
+	if id, ok := p.ids[kind][name]; ok {
+		return id, nil
+	}
+
+	opts := client.NewRequestOptions()
+	opts.QueryParameters.Set("name", name)
+	ids := []int{}
+	var err error
+	switch kind {
+	case "cdn":
+		var resp tc.CDNsResponse
+		if resp, _, err = p.target.GetCDNs(opts); err == nil {
+			for _, cdn := range resp.Response {
+				ids = append(ids, cdn.ID)
+			}
+		}
+	case "profile":
+		var resp tc.ProfilesResponse
+		if resp, _, err = p.target.GetProfiles(opts); err == nil {
+			for _, profile := range resp.Response {
+				ids = append(ids, profile.ID)
+			}
+		}
+	case "tenant":
+		var resp tc.GetTenantsResponse
+		if resp, _, err = p.target.GetTenants(opts); err == nil {
+			for _, tenant := range resp.Response {
+				ids = append(ids, tenant.ID)
+			}
+		}
+	case "type":
+		var resp tc.TypesResponse
+		if resp, _, err = p.target.GetTypes(opts); err == nil {
+			for _, typ := range resp.Response {
+				ids = append(ids, typ.ID)
+			}
+		}
+	default:
+		return 0, fmt.Errorf("unknown kind of object '%s'", kind)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("getting %s '%s' from target: %w", kind, name, err)
+	}
+	if len(ids) != 1 {
+		return 0, fmt.Errorf("no %s named '%s' in target; add it, or map it to another in the mapping file", kind, name)
+	}
+
+	if p.ids[kind] == nil {
+		p.ids[kind] = map[string]int{}
+	}
+	p.ids[kind][name] = ids[0]
+	return ids[0], nil
+}
+
+// copyField sets the given field of the Delivery Service, named as in the
+// Traffic Ops API, to its value in from.
+func copyField(ds *tc.DeliveryServiceV4, from tc.DeliveryServiceV4, field string) error {
+	fields, err := toFields(from)
+	if err != nil {
+		return err
+	}
+	return setField(ds, field, fields[field])
+}
+
+func setField(ds *tc.DeliveryServiceV4, field string, value interface{}) error {
+	fields, err := toFields(*ds)
+	if err != nil {
+		return err
+	}
+	if _, ok := fields[field]; !ok {
+		return fmt.Errorf("Delivery Services have no field '%s'", field)
+	}
+	fields[field] = value
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return fmt.Errorf("encoding Delivery Service: %w", err)
+	}
+	updated := tc.DeliveryServiceV4{}
+	if err := json.Unmarshal(data, &updated); err != nil {
+		return fmt.Errorf("decoding Delivery Service: %w", err)
+	}
+	*ds = updated
+	return nil
+}
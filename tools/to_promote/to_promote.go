@@ -0,0 +1,201 @@
+/*
+
+Name
+	to_promote
+
+Synopsis
+	to_promote --source-url value --source-user value --target-url value --target-user value (--ds value | --cdn value) [--mapping value] [--apply] [--insecure] [--timeout value]
+
+Description
+  The to_promote app promotes the configuration of Delivery Services from one
+  Traffic Ops instance to another - e.g. from staging to production. It
+  prints the differences between the Delivery Services of the source and
+  those of the target, after mapping the environment-specific values of the
+  source to those of the target, and applies them if asked.
+
+  The passwords of the source and target users are read from the
+  TO_SOURCE_PASSWORD and TO_TARGET_PASSWORD environment variables.
+
+Options
+	--source-url, --target-url
+        The URLs of the source and target Traffic Ops instances.
+
+	--source-user, --target-user
+        The users as whom to log in to the source and target.
+
+	--ds
+        A comma-separated list of the XMLIDs of the Delivery Services to
+        promote.
+
+	--cdn
+        The name of a CDN of the source, all of whose Delivery Services are
+        promoted.
+
+	--mapping
+        The path of a file of rules mapping the environment-specific values of
+        the source to those of the target.
+
+	--apply
+        Apply the differences to the target. Without this, they're only
+        printed.
+
+	--insecure
+        Don't verify the TLS certificates of the source and target.
+
+	--timeout
+        The timeout of requests to Traffic Ops. Default is 30s.
+
+*/
+
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	client "github.com/apache/trafficcontrol/traffic_ops/v5-client"
+)
+
+const userAgent = "to_promote"
+
+func main() {
+	sourceURL := flag.String("source-url", "", "The URL of the source Traffic Ops.")
+	sourceUser := flag.String("source-user", "", "The user as whom to log in to the source Traffic Ops. The password is read from the TO_SOURCE_PASSWORD environment variable.")
+	targetURL := flag.String("target-url", "", "The URL of the target Traffic Ops.")
+	targetUser := flag.String("target-user", "", "The user as whom to log in to the target Traffic Ops. The password is read from the TO_TARGET_PASSWORD environment variable.")
+	dses := flag.String("ds", "", "A comma-separated list of the XMLIDs of the Delivery Services to promote.")
+	cdn := flag.String("cdn", "", "The name of a CDN of the source, all of whose Delivery Services are promoted.")
+	mappingFile := flag.String("mapping", "", "(Optional) The path of a file of rules mapping the environment-specific values of the source to those of the target.")
+	apply := flag.Bool("apply", false, "(Optional) Apply the differences to the target. Without this, they're only printed.")
+	insecure := flag.Bool("insecure", false, "(Optional) Don't verify the TLS certificates of the source and target.")
+	timeout := flag.Duration("timeout", 30*time.Second, "(Optional) The timeout of requests to Traffic Ops.")
+	help := flag.Bool("help", false, "(Optional) Print usage information and exit.")
+	flag.Parse()
+
+	if *help {
+		flag.Usage()
+		os.Exit(0)
+	}
+	if *sourceURL == "" || *sourceUser == "" || *targetURL == "" || *targetUser == "" {
+		die("the --source-url, --source-user, --target-url and --target-user options are required")
+	}
+	if (*dses == "") == (*cdn == "") {
+		die("exactly one of the --ds and --cdn options is required")
+	}
+
+	mapping := Mapping{}
+	if *mappingFile != "" {
+		var err error
+		if mapping, err = loadMapping(*mappingFile); err != nil {
+			die("reading mapping file '" + *mappingFile + "': " + err.Error())
+		}
+	}
+
+	source, _, err := client.LoginWithAgent(*sourceURL, *sourceUser, os.Getenv("TO_SOURCE_PASSWORD"), *insecure, userAgent, false, *timeout)
+	if err != nil {
+		die("logging in to source Traffic Ops: " + err.Error())
+	}
+	target, _, err := client.LoginWithAgent(*targetURL, *targetUser, os.Getenv("TO_TARGET_PASSWORD"), *insecure, userAgent, false, *timeout)
+	if err != nil {
+		die("logging in to target Traffic Ops: " + err.Error())
+	}
+
+	var srcDSes []tc.DeliveryServiceV4
+	if *cdn != "" {
+		srcDSes, err = getCDNDeliveryServices(source, *cdn)
+	} else {
+		srcDSes, err = getDeliveryServices(source, strings.Split(*dses, ","))
+	}
+	if err != nil {
+		die("getting Delivery Services from source Traffic Ops: " + err.Error())
+	}
+
+	p := newPromoter(target, mapping)
+	failed := false
+	for _, ds := range srcDSes {
+		c, err := p.plan(ds)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", c.xmlID, err)
+			failed = true
+			continue
+		}
+		fmt.Print(c)
+		if !*apply {
+			continue
+		}
+		if err := p.apply(c); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", c.xmlID, err)
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// getDeliveryServices returns the Delivery Services with the given XMLIDs.
+func getDeliveryServices(to *client.Session, xmlIDs []string) ([]tc.DeliveryServiceV4, error) {
+	dses := make([]tc.DeliveryServiceV4, 0, len(xmlIDs))
+	for _, xmlID := range xmlIDs {
+		opts := client.NewRequestOptions()
+		opts.QueryParameters.Set("xmlId", strings.TrimSpace(xmlID))
+		resp, _, err := to.GetDeliveryServices(opts)
+		if err != nil {
+			return nil, fmt.Errorf("getting Delivery Service '%s': %w", xmlID, err)
+		}
+		if len(resp.Response) != 1 {
+			return nil, fmt.Errorf("no Delivery Service '%s'", xmlID)
+		}
+		dses = append(dses, resp.Response[0])
+	}
+	return dses, nil
+}
+
+// getCDNDeliveryServices returns the Delivery Services of the CDN with the
+// given name.
+func getCDNDeliveryServices(to *client.Session, cdn string) ([]tc.DeliveryServiceV4, error) {
+	opts := client.NewRequestOptions()
+	opts.QueryParameters.Set("name", cdn)
+	cdns, _, err := to.GetCDNs(opts)
+	if err != nil {
+		return nil, fmt.Errorf("getting CDN '%s': %w", cdn, err)
+	}
+	if len(cdns.Response) != 1 {
+		return nil, fmt.Errorf("no CDN '%s'", cdn)
+	}
+
+	opts = client.NewRequestOptions()
+	opts.QueryParameters.Set("cdn", fmt.Sprint(cdns.Response[0].ID))
+	opts.QueryParameters.Set("orderby", "xml_id")
+	resp, _, err := to.GetDeliveryServices(opts)
+	if err != nil {
+		return nil, fmt.Errorf("getting Delivery Services of CDN '%s': %w", cdn, err)
+	}
+	return resp.Response, nil
+}
+
+func die(message string) {
+	fmt.Fprintln(os.Stderr, message)
+	os.Exit(1)
+}
@@ -0,0 +1,130 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func testDeliveryService() tc.DeliveryServiceV4 {
+	ds := tc.DeliveryServiceV4{}
+	ds.ID = util.IntPtr(1)
+	ds.XMLID = util.StrPtr("demo1-staging")
+	ds.CDNID = util.IntPtr(2)
+	ds.CDNName = util.StrPtr("staging")
+	ds.ProfileID = util.IntPtr(3)
+	ds.ProfileName = util.StrPtr("STAGING_DS")
+	ds.Tenant = util.StrPtr("root")
+	ds.OrgServerFQDN = util.StrPtr("http://origin.staging.example.com")
+	ds.ConsistentHashQueryParams = []string{"staging"}
+	ds.RemapText = util.StrPtr("@plugin=header_rewrite.so @pparam=staging.config")
+	ds.DisplayName = util.StrPtr("Demo")
+	return ds
+}
+
+func TestMappingApply(t *testing.T) {
+	m := Mapping{
+		CDNs:     map[string]string{"staging": "production"},
+		Profiles: map[string]string{"STAGING_DS": "PRODUCTION_DS"},
+		Replacements: []Replacement{
+			{Pattern: `\bstaging\.example\.com`, Replacement: "example.com"},
+			{Pattern: `staging`, Replacement: "production"},
+		},
+	}
+	if err := m.compile(); err != nil {
+		t.Fatalf("unexpected error compiling mapping: %v", err)
+	}
+
+	ds, err := m.apply(testDeliveryService())
+	if err != nil {
+		t.Fatalf("unexpected error applying mapping: %v", err)
+	}
+	if ds.XMLID == nil || *ds.XMLID != "demo1-staging" {
+		t.Errorf("expected XMLID not to be replaced, got %v", ds.XMLID)
+	}
+	if ds.CDNName == nil || *ds.CDNName != "production" {
+		t.Errorf("expected CDN to be mapped to 'production', got %v", ds.CDNName)
+	}
+	if ds.ProfileName == nil || *ds.ProfileName != "PRODUCTION_DS" {
+		t.Errorf("expected Profile to be mapped to 'PRODUCTION_DS', got %v", ds.ProfileName)
+	}
+	if ds.Tenant == nil || *ds.Tenant != "root" {
+		t.Errorf("expected unmapped Tenant to be kept, got %v", ds.Tenant)
+	}
+	if ds.OrgServerFQDN == nil || *ds.OrgServerFQDN != "http://origin.example.com" {
+		t.Errorf("expected origin to be replaced with 'http://origin.example.com', got %v", ds.OrgServerFQDN)
+	}
+	if ds.RemapText == nil || *ds.RemapText != "@plugin=header_rewrite.so @pparam=production.config" {
+		t.Errorf("expected remap text to be replaced, got %v", ds.RemapText)
+	}
+	if len(ds.ConsistentHashQueryParams) != 1 || ds.ConsistentHashQueryParams[0] != "production" {
+		t.Errorf("expected strings in arrays to be replaced, got %v", ds.ConsistentHashQueryParams)
+	}
+	if ds.ID == nil || *ds.ID != 1 {
+		t.Errorf("expected non-string fields to be kept, got ID %v", ds.ID)
+	}
+}
+
+func TestMappingCompile(t *testing.T) {
+	m := Mapping{Replacements: []Replacement{{Pattern: `(`, Replacement: ""}}}
+	if err := m.compile(); err == nil {
+		t.Error("expected an error compiling an invalid pattern, got nil")
+	}
+}
+
+func TestDiffDeliveryServices(t *testing.T) {
+	target := testDeliveryService()
+	promoted := testDeliveryService()
+	promoted.ID = util.IntPtr(10)
+	promoted.CDNID = util.IntPtr(20)
+	promoted.DisplayName = util.StrPtr("Demo 1")
+	promoted.OrgServerFQDN = util.StrPtr("http://origin.example.com")
+
+	diffs, err := diffDeliveryServices(target, promoted, map[string]struct{}{"orgServerFqdn": {}})
+	if err != nil {
+		t.Fatalf("unexpected error diffing Delivery Services: %v", err)
+	}
+	if len(diffs) != 1 {
+		t.Fatalf("expected 1 difference, got %d: %+v", len(diffs), diffs)
+	}
+	if diffs[0].field != "displayName" || diffs[0].target != "Demo" || diffs[0].promoted != "Demo 1" {
+		t.Errorf("unexpected difference: %+v", diffs[0])
+	}
+
+	if diffs, err := diffDeliveryServices(target, target, nil); err != nil || len(diffs) != 0 {
+		t.Errorf("expected no differences between identical Delivery Services, got %+v (error: %v)", diffs, err)
+	}
+}
+
+func TestCopyField(t *testing.T) {
+	ds := testDeliveryService()
+	from := testDeliveryService()
+	from.OrgServerFQDN = util.StrPtr("http://other.example.com")
+	if err := copyField(&ds, from, "orgServerFqdn"); err != nil {
+		t.Fatalf("unexpected error copying field: %v", err)
+	}
+	if ds.OrgServerFQDN == nil || *ds.OrgServerFQDN != "http://other.example.com" {
+		t.Errorf("expected copied origin 'http://other.example.com', got %v", ds.OrgServerFQDN)
+	}
+	if err := copyField(&ds, from, "noSuchField"); err == nil {
+		t.Error("expected an error copying a field Delivery Services don't have, got nil")
+	}
+}
@@ -0,0 +1,226 @@
+package alerting
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// AlertState is the state of an Alert.
+type AlertState string
+
+const (
+	// AlertStatePending alerts satisfy their Rule, but haven't for the Rule's
+	// full duration.
+	AlertStatePending = AlertState("pending")
+	// AlertStateFiring alerts have satisfied their Rule for its full duration,
+	// and notifications have been sent.
+	AlertStateFiring = AlertState("firing")
+	// AlertStateResolved alerts were firing, but no longer satisfy their Rule.
+	AlertStateResolved = AlertState("resolved")
+)
+
+// Alert is a Rule which is satisfied for a particular target.
+type Alert struct {
+	Rule      string     `json:"rule"`
+	Scope     Scope      `json:"scope"`
+	Target    string     `json:"target"`
+	Metric    string     `json:"metric"`
+	Operator  Operator   `json:"operator"`
+	Threshold float64    `json:"threshold"`
+	Value     float64    `json:"value"`
+	Severity  string     `json:"severity"`
+	State     AlertState `json:"state"`
+	// Since is when the target started satisfying the Rule.
+	Since time.Time `json:"since"`
+	// FiredAt is when the Alert started firing; it is nil for pending
+	// Alerts.
+	FiredAt *time.Time `json:"firedAt,omitempty"`
+	// Annotations are the Traffic Ops annotations, such as maintenance
+	// windows, in effect for the target when the Alert was last evaluated.
+	Annotations []tc.Annotation `json:"annotations,omitempty"`
+}
+
+// Key uniquely identifies the Alert, for deduplicating notifications.
+func (a Alert) Key() string {
+	return a.Rule + "/" + string(a.Scope) + "/" + a.Target
+}
+
+// Event is a notification that an Alert started firing or was resolved.
+type Event struct {
+	Alert
+	// Source is the name of the Traffic Monitor which sent the Event.
+	Source string    `json:"source"`
+	Time   time.Time `json:"time"`
+}
+
+// Annotator returns the annotations in effect at the given time for the
+// given target.
+type Annotator func(scope Scope, target string, now time.Time) []tc.Annotation
+
+// Engine evaluates Rules and tracks their Alerts. It is safe for use by
+// multiple goroutines, but Evaluate MUST NOT be called concurrently.
+type Engine struct {
+	rules     Rules
+	source    string
+	notifiers map[string]Notifier
+	alerts    map[string]Alert
+	annotate  Annotator
+	m         *sync.RWMutex
+}
+
+// NewEngine creates a new Engine for the given Rules. The source is included
+// in each Event, to identify the sending Traffic Monitor.
+func NewEngine(rules Rules, source string) *Engine {
+	notifiers := make(map[string]Notifier, len(rules.Notifiers))
+	for name, cfg := range rules.Notifiers {
+		notifiers[name] = NewNotifier(cfg)
+	}
+	return &Engine{
+		rules:     rules,
+		source:    source,
+		notifiers: notifiers,
+		alerts:    map[string]Alert{},
+		m:         &sync.RWMutex{},
+	}
+}
+
+// SetAnnotator sets the Annotator with which the Engine annotates its Alerts.
+// Without one, Alerts have no annotations.
+func (e *Engine) SetAnnotator(annotate Annotator) {
+	e.m.Lock()
+	defer e.m.Unlock()
+	e.annotate = annotate
+}
+
+// Interval returns the interval on which the Engine's Rules should be
+// evaluated.
+func (e *Engine) Interval() time.Duration {
+	return e.rules.EvaluationInterval
+}
+
+// Active returns the pending and firing Alerts, sorted by Rule and target.
+func (e *Engine) Active() []Alert {
+	e.m.RLock()
+	alerts := make([]Alert, 0, len(e.alerts))
+	for _, alert := range e.alerts {
+		alerts = append(alerts, alert)
+	}
+	e.m.RUnlock()
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Rule != alerts[j].Rule {
+			return alerts[i].Rule < alerts[j].Rule
+		}
+		return alerts[i].Target < alerts[j].Target
+	})
+	return alerts
+}
+
+// Evaluate evaluates every Rule against the given Delivery Service and cache
+// samples, and returns an Event for each Alert which started firing or was
+// resolved. Alerts for targets which no longer have samples are resolved.
+func (e *Engine) Evaluate(now time.Time, dses map[string]Sample, caches map[string]Sample) []Event {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	events := []Event{}
+	seen := make(map[string]struct{}, len(e.alerts))
+	for _, rule := range e.rules.Rules {
+		samples := dses
+		if rule.Scope == ScopeCache {
+			samples = caches
+		}
+		metric := Metrics[rule.Metric]
+		for target, sample := range samples {
+			if !rule.AppliesTo(target) {
+				continue
+			}
+			value := metric(sample)
+			if !rule.Operator.Compare(value, rule.Threshold) {
+				continue
+			}
+
+			alert := Alert{
+				Rule:      rule.Name,
+				Scope:     rule.Scope,
+				Target:    target,
+				Metric:    rule.Metric,
+				Operator:  rule.Operator,
+				Threshold: rule.Threshold,
+				Severity:  rule.Severity,
+				State:     AlertStatePending,
+				Since:     now,
+			}
+			key := alert.Key()
+			seen[key] = struct{}{}
+			if prev, ok := e.alerts[key]; ok {
+				alert.State = prev.State
+				alert.Since = prev.Since
+				alert.FiredAt = prev.FiredAt
+			}
+			alert.Value = value
+			if e.annotate != nil {
+				alert.Annotations = e.annotate(alert.Scope, alert.Target, now)
+			}
+			if alert.State == AlertStatePending && now.Sub(alert.Since) >= rule.For {
+				alert.State = AlertStateFiring
+				alert.FiredAt = &now
+				events = append(events, Event{Alert: alert, Source: e.source, Time: now})
+			}
+			e.alerts[key] = alert
+		}
+	}
+
+	for key, alert := range e.alerts {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		delete(e.alerts, key)
+		if alert.State == AlertStateFiring {
+			alert.State = AlertStateResolved
+			if e.annotate != nil {
+				alert.Annotations = e.annotate(alert.Scope, alert.Target, now)
+			}
+			events = append(events, Event{Alert: alert, Source: e.source, Time: now})
+		}
+	}
+	return events
+}
+
+// Notify sends the given Event to each notifier of its Rule. Errors are
+// logged, not returned, since there is nothing the caller could do about them.
+func (e *Engine) Notify(event Event) {
+	for _, rule := range e.rules.Rules {
+		if rule.Name != event.Rule {
+			continue
+		}
+		for _, name := range rule.Notifiers {
+			if err := e.notifiers[name].Notify(event); err != nil {
+				log.Errorf("alerting: sending %s alert '%s' to notifier '%s': %v\n", event.State, event.Key(), name, err)
+			}
+		}
+		return
+	}
+}
@@ -0,0 +1,128 @@
+package alerting
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func testEngine() *Engine {
+	return NewEngine(Rules{
+		EvaluationInterval: time.Second,
+		Rules: []Rule{
+			{Name: "ds-5xx", Scope: ScopeDeliveryService, Metric: "tps_5xx_percent", Operator: OperatorGreater, Threshold: 1, For: time.Minute, Severity: "critical"},
+			{Name: "cache-idle", Scope: ScopeCache, Targets: []string{"edge1"}, Metric: "kbps", Operator: OperatorLess, Threshold: 1, Severity: "info"},
+		},
+	}, "tm1")
+}
+
+func TestEngineEvaluate(t *testing.T) {
+	e := testEngine()
+	start := time.Now()
+	bad := Sample{Tps2xx: 90, Tps5xx: 10}
+	good := Sample{Tps2xx: 100}
+
+	events := e.Evaluate(start, map[string]Sample{"ds1": bad, "ds2": good}, nil)
+	if len(events) != 0 {
+		t.Fatalf("expected no events before the rule duration elapsed, actual: %+v", events)
+	}
+	active := e.Active()
+	if len(active) != 1 || active[0].Target != "ds1" || active[0].State != AlertStatePending {
+		t.Fatalf("expected a pending alert for ds1, actual: %+v", active)
+	}
+
+	events = e.Evaluate(start.Add(time.Minute), map[string]Sample{"ds1": bad, "ds2": good}, nil)
+	if len(events) != 1 || events[0].State != AlertStateFiring || events[0].Target != "ds1" {
+		t.Fatalf("expected a firing event for ds1, actual: %+v", events)
+	}
+	if events[0].Source != "tm1" || events[0].Value != 10 {
+		t.Errorf("expected event from tm1 with value 10, actual: %+v", events[0])
+	}
+
+	events = e.Evaluate(start.Add(2*time.Minute), map[string]Sample{"ds1": bad, "ds2": good}, nil)
+	if len(events) != 0 {
+		t.Fatalf("expected no repeated events for a firing alert, actual: %+v", events)
+	}
+
+	events = e.Evaluate(start.Add(3*time.Minute), map[string]Sample{"ds1": good, "ds2": good}, nil)
+	if len(events) != 1 || events[0].State != AlertStateResolved {
+		t.Fatalf("expected a resolved event, actual: %+v", events)
+	}
+	if active := e.Active(); len(active) != 0 {
+		t.Errorf("expected no active alerts, actual: %+v", active)
+	}
+}
+
+func TestEngineEvaluatePendingReset(t *testing.T) {
+	e := testEngine()
+	start := time.Now()
+	bad := Sample{Tps5xx: 10}
+
+	e.Evaluate(start, map[string]Sample{"ds1": bad}, nil)
+	if events := e.Evaluate(start.Add(30*time.Second), map[string]Sample{"ds1": {Tps2xx: 1}}, nil); len(events) != 0 {
+		t.Fatalf("expected no events when a pending alert stops matching, actual: %+v", events)
+	}
+	e.Evaluate(start.Add(45*time.Second), map[string]Sample{"ds1": bad}, nil)
+	if events := e.Evaluate(start.Add(90*time.Second), map[string]Sample{"ds1": bad}, nil); len(events) != 0 {
+		t.Fatalf("expected the rule duration to restart after the alert stopped matching, actual: %+v", events)
+	}
+}
+
+func TestEngineEvaluateTargets(t *testing.T) {
+	e := testEngine()
+	now := time.Now()
+	events := e.Evaluate(now, nil, map[string]Sample{"edge1": {}, "edge2": {}})
+	if len(events) != 1 || events[0].Target != "edge1" || events[0].Scope != ScopeCache {
+		t.Fatalf("expected a single firing event for edge1, actual: %+v", events)
+	}
+
+	events = e.Evaluate(now.Add(time.Second), nil, map[string]Sample{"edge2": {}})
+	if len(events) != 1 || events[0].Target != "edge1" || events[0].State != AlertStateResolved {
+		t.Fatalf("expected edge1 to resolve when it has no samples, actual: %+v", events)
+	}
+}
+
+func TestEngineAnnotator(t *testing.T) {
+	e := testEngine()
+	now := time.Now()
+	maintenance := tc.Annotation{ID: 1, Description: "fiber maintenance", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)}
+	e.SetAnnotator(func(scope Scope, target string, at time.Time) []tc.Annotation {
+		if scope == ScopeCache && target == "edge1" && maintenance.ActiveAt(at) {
+			return []tc.Annotation{maintenance}
+		}
+		return nil
+	})
+
+	events := e.Evaluate(now, nil, map[string]Sample{"edge1": {}})
+	if len(events) != 1 || len(events[0].Annotations) != 1 || events[0].Annotations[0].ID != 1 {
+		t.Fatalf("expected a firing event annotated with the maintenance, actual: %+v", events)
+	}
+	if active := e.Active(); len(active) != 1 || len(active[0].Annotations) != 1 {
+		t.Errorf("expected the active alert to be annotated, actual: %+v", active)
+	}
+
+	events = e.Evaluate(now.Add(2*time.Hour), nil, nil)
+	if len(events) != 1 || events[0].State != AlertStateResolved || len(events[0].Annotations) != 0 {
+		t.Errorf("expected an unannotated resolved event after the maintenance ended, actual: %+v", events)
+	}
+}
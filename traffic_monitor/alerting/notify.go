@@ -0,0 +1,206 @@
+package alerting
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	NotifierTypeWebhook   = "webhook"
+	NotifierTypePagerDuty = "pagerduty"
+	NotifierTypeEmail     = "email"
+)
+
+// PagerDutyEventsURL is the PagerDuty Events API v2 endpoint used when a
+// pagerduty notifier doesn't specify a URL.
+const PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// NotifyTimeout is the timeout for sending a single notification over HTTP.
+const NotifyTimeout = 10 * time.Second
+
+// Notifier sends alert Events somewhere a human will see them.
+type Notifier interface {
+	Notify(Event) error
+}
+
+// NewNotifier creates a Notifier from the given configuration, which must
+// already have been validated.
+func NewNotifier(cfg NotifierConfig) Notifier {
+	client := &http.Client{Timeout: NotifyTimeout}
+	switch cfg.Type {
+	case NotifierTypePagerDuty:
+		url := cfg.URL
+		if url == "" {
+			url = PagerDutyEventsURL
+		}
+		return &PagerDutyNotifier{URL: url, RoutingKey: cfg.RoutingKey, Client: client}
+	case NotifierTypeEmail:
+		return &EmailNotifier{SMTPServer: cfg.SMTPServer, Username: cfg.Username, Password: cfg.Password, From: cfg.From, To: cfg.To}
+	default:
+		return &WebhookNotifier{URL: cfg.URL, Headers: cfg.Headers, Client: client}
+	}
+}
+
+// WebhookNotifier POSTs each Event as JSON to a URL.
+type WebhookNotifier struct {
+	URL     string
+	Headers map[string]string
+	Client  *http.Client
+}
+
+// Notify implements Notifier.
+func (n *WebhookNotifier) Notify(event Event) error {
+	return postJSON(n.Client, n.URL, n.Headers, event)
+}
+
+// PagerDutyNotifier sends Events to the PagerDuty Events API v2, triggering an
+// incident when an Alert fires and resolving it when the Alert resolves.
+type PagerDutyNotifier struct {
+	URL        string
+	RoutingKey string
+	Client     *http.Client
+}
+
+// PagerDutyEvent is the request body of the PagerDuty Events API v2.
+type PagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *PagerDutyPayload `json:"payload,omitempty"`
+}
+
+// PagerDutyPayload is the details of a triggered PagerDuty event.
+type PagerDutyPayload struct {
+	Summary       string `json:"summary"`
+	Source        string `json:"source"`
+	Severity      string `json:"severity"`
+	Timestamp     string `json:"timestamp"`
+	Component     string `json:"component"`
+	CustomDetails Alert  `json:"custom_details"`
+}
+
+// Notify implements Notifier.
+func (n *PagerDutyNotifier) Notify(event Event) error {
+	pdEvent := PagerDutyEvent{
+		RoutingKey:  n.RoutingKey,
+		EventAction: "resolve",
+		DedupKey:    event.Key(),
+	}
+	if event.State == AlertStateFiring {
+		pdEvent.EventAction = "trigger"
+		pdEvent.Payload = &PagerDutyPayload{
+			Summary:       Summary(event),
+			Source:        event.Source,
+			Severity:      event.Severity,
+			Timestamp:     event.Time.Format(time.RFC3339),
+			Component:     event.Target,
+			CustomDetails: event.Alert,
+		}
+	}
+	return postJSON(n.Client, n.URL, nil, pdEvent)
+}
+
+// EmailNotifier sends each Event as an email via SMTP.
+type EmailNotifier struct {
+	SMTPServer string
+	Username   string
+	Password   string
+	From       string
+	To         []string
+}
+
+// Notify implements Notifier.
+func (n *EmailNotifier) Notify(event Event) error {
+	var auth smtp.Auth
+	if n.Username != "" {
+		host, _, err := net.SplitHostPort(n.SMTPServer)
+		if err != nil {
+			return errors.New("parsing smtp_server: " + err.Error())
+		}
+		auth = smtp.PlainAuth("", n.Username, n.Password, host)
+	}
+	return smtp.SendMail(n.SMTPServer, auth, n.From, n.To, emailMessage(n.From, n.To, event))
+}
+
+// emailMessage returns the RFC 822 message for the given Event.
+func emailMessage(from string, to []string, event Event) []byte {
+	buf := &bytes.Buffer{}
+	fmt.Fprintf(buf, "From: %s\r\n", from)
+	fmt.Fprintf(buf, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(buf, "Subject: [%s] %s\r\n", strings.ToUpper(string(event.State)), Summary(event))
+	fmt.Fprintf(buf, "Date: %s\r\n", event.Time.Format(time.RFC1123Z))
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(buf, "Rule: %s\r\n", event.Rule)
+	fmt.Fprintf(buf, "Severity: %s\r\n", event.Severity)
+	fmt.Fprintf(buf, "Target: %s %s\r\n", event.Scope, event.Target)
+	fmt.Fprintf(buf, "Condition: %s %s %v\r\n", event.Metric, event.Operator, event.Threshold)
+	fmt.Fprintf(buf, "Last value: %v\r\n", event.Value)
+	fmt.Fprintf(buf, "Since: %s\r\n", event.Since.Format(time.RFC3339))
+	fmt.Fprintf(buf, "Source: %s\r\n", event.Source)
+	for _, annotation := range event.Annotations {
+		fmt.Fprintf(buf, "Annotation: %s (%s - %s)\r\n", annotation.Description, annotation.StartTime.Format(time.RFC3339), annotation.EndTime.Format(time.RFC3339))
+	}
+	return buf.Bytes()
+}
+
+// Summary returns a one-line human-readable description of the Event.
+func Summary(event Event) string {
+	return fmt.Sprintf("%s: %s %s %s is %v (%s %v)", event.Rule, event.Scope, event.Target, event.Metric, event.Value, event.Operator, event.Threshold)
+}
+
+// postJSON POSTs obj as JSON to the given URL, returning an error if the
+// request fails or the response isn't a 2xx.
+func postJSON(client *http.Client, url string, headers map[string]string, obj interface{}) error {
+	json := jsoniter.ConfigFastest
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return errors.New("marshalling: " + err.Error())
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.New("creating request: " + err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.New("sending request: " + err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("received status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
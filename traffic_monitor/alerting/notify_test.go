@@ -0,0 +1,77 @@
+package alerting
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+func TestPagerDutyNotifier(t *testing.T) {
+	received := []PagerDutyEvent{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event := PagerDutyEvent{}
+		if err := jsoniter.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		received = append(received, event)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	n := NewNotifier(NotifierConfig{Type: NotifierTypePagerDuty, URL: srv.URL, RoutingKey: "key"})
+	alert := Alert{Rule: "ds-5xx", Scope: ScopeDeliveryService, Target: "ds1", Severity: "critical", State: AlertStateFiring}
+	if err := n.Notify(Event{Alert: alert, Source: "tm1", Time: time.Now()}); err != nil {
+		t.Fatalf("expected no error, actual: %v", err)
+	}
+	alert.State = AlertStateResolved
+	if err := n.Notify(Event{Alert: alert, Source: "tm1", Time: time.Now()}); err != nil {
+		t.Fatalf("expected no error, actual: %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("expected 2 requests, actual: %d", len(received))
+	}
+	if received[0].EventAction != "trigger" || received[0].Payload == nil || received[0].Payload.Severity != "critical" || received[0].RoutingKey != "key" {
+		t.Errorf("expected a critical trigger event, actual: %+v", received[0])
+	}
+	if received[1].EventAction != "resolve" || received[1].DedupKey != received[0].DedupKey {
+		t.Errorf("expected a resolve event with the trigger's dedup key, actual: %+v", received[1])
+	}
+}
+
+func TestWebhookNotifierError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Token") != "secret" {
+			t.Errorf("expected configured header, actual: '%s'", r.Header.Get("X-Token"))
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	n := NewNotifier(NotifierConfig{Type: NotifierTypeWebhook, URL: srv.URL, Headers: map[string]string{"X-Token": "secret"}})
+	if err := n.Notify(Event{}); err == nil {
+		t.Error("expected error for a 500 response, actual: nil")
+	}
+}
@@ -0,0 +1,287 @@
+// Package alerting evaluates operator-defined threshold rules over Delivery
+// Service and cache server stats, and sends notifications when they start and
+// stop firing.
+package alerting
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// DefaultEvaluationInterval is the interval on which rules are evaluated, if
+// the rules file does not specify one.
+const DefaultEvaluationInterval = 10 * time.Second
+
+// Scope is the kind of object a Rule is evaluated against.
+type Scope string
+
+const (
+	// ScopeDeliveryService rules are evaluated against the total stats of
+	// each Delivery Service.
+	ScopeDeliveryService = Scope("deliveryService")
+	// ScopeCache rules are evaluated against the stats of each cache server.
+	ScopeCache = Scope("cache")
+)
+
+// Operator is a comparison between a metric value and a Rule's threshold.
+type Operator string
+
+const (
+	OperatorGreater      = Operator(">")
+	OperatorGreaterEqual = Operator(">=")
+	OperatorLess         = Operator("<")
+	OperatorLessEqual    = Operator("<=")
+)
+
+// Compare returns whether the given value satisfies the operator against the
+// threshold.
+func (o Operator) Compare(value, threshold float64) bool {
+	switch o {
+	case OperatorGreater:
+		return value > threshold
+	case OperatorGreaterEqual:
+		return value >= threshold
+	case OperatorLess:
+		return value < threshold
+	case OperatorLessEqual:
+		return value <= threshold
+	}
+	return false
+}
+
+// Severities are the valid Rule severities. They match the PagerDuty Events
+// API severities, so rules can be routed there unchanged.
+var Severities = map[string]struct{}{
+	"critical": {},
+	"error":    {},
+	"warning":  {},
+	"info":     {},
+}
+
+// DefaultSeverity is the severity of a Rule which doesn't specify one.
+const DefaultSeverity = "warning"
+
+// Rule is a single threshold rule. The Rule fires for a target once Metric has
+// satisfied Operator against Threshold continuously for For, and resolves as
+// soon as it no longer does.
+type Rule struct {
+	Name      string        `json:"name"`
+	Scope     Scope         `json:"scope"`
+	Targets   []string      `json:"targets"`
+	Metric    string        `json:"metric"`
+	Operator  Operator      `json:"operator"`
+	Threshold float64       `json:"threshold"`
+	For       time.Duration `json:"-"`
+	Severity  string        `json:"severity"`
+	Notifiers []string      `json:"notifiers"`
+}
+
+// MarshalJSON marshals the Rule, with its For duration in milliseconds.
+func (r Rule) MarshalJSON() ([]byte, error) {
+	type Alias Rule
+	json := jsoniter.ConfigFastest
+	return json.Marshal(&struct {
+		ForMs uint64 `json:"for_ms"`
+		Alias
+	}{
+		ForMs: uint64(r.For / time.Millisecond),
+		Alias: Alias(r),
+	})
+}
+
+// UnmarshalJSON unmarshals the Rule, reading its For duration from for_ms.
+func (r *Rule) UnmarshalJSON(data []byte) error {
+	type Alias Rule
+	aux := &struct {
+		ForMs uint64 `json:"for_ms"`
+		*Alias
+	}{
+		Alias: (*Alias)(r),
+	}
+	json := jsoniter.ConfigFastest
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	r.For = time.Duration(aux.ForMs) * time.Millisecond
+	return nil
+}
+
+// AppliesTo returns whether the Rule should be evaluated for the given target.
+// A Rule with no Targets applies to every object in its Scope.
+func (r Rule) AppliesTo(target string) bool {
+	if len(r.Targets) == 0 {
+		return true
+	}
+	for _, t := range r.Targets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
+// NotifierConfig is the configuration of a single named notifier. Which fields
+// are used depends on the Type.
+type NotifierConfig struct {
+	// Type is one of "webhook", "pagerduty", or "email".
+	Type string `json:"type"`
+
+	// URL is the webhook URL, or an override of the PagerDuty Events API URL.
+	URL string `json:"url"`
+	// Headers are extra headers sent with each webhook request.
+	Headers map[string]string `json:"headers"`
+
+	// RoutingKey is the PagerDuty integration key.
+	RoutingKey string `json:"routing_key"`
+
+	// SMTPServer is the host:port of the SMTP server used to send email.
+	SMTPServer string   `json:"smtp_server"`
+	Username   string   `json:"username"`
+	Password   string   `json:"password"`
+	From       string   `json:"from"`
+	To         []string `json:"to"`
+}
+
+// Validate returns an error if the NotifierConfig is missing fields required
+// by its Type, or nil if it is valid.
+func (n NotifierConfig) Validate() error {
+	switch n.Type {
+	case NotifierTypeWebhook:
+		if n.URL == "" {
+			return errors.New("webhook requires a url")
+		}
+	case NotifierTypePagerDuty:
+		if n.RoutingKey == "" {
+			return errors.New("pagerduty requires a routing_key")
+		}
+	case NotifierTypeEmail:
+		if n.SMTPServer == "" || n.From == "" || len(n.To) == 0 {
+			return errors.New("email requires smtp_server, from, and to")
+		}
+	default:
+		return fmt.Errorf("unknown type '%s'", n.Type)
+	}
+	return nil
+}
+
+// Rules is the contents of the alert rules file.
+type Rules struct {
+	EvaluationInterval time.Duration             `json:"-"`
+	Notifiers          map[string]NotifierConfig `json:"notifiers"`
+	Rules              []Rule                    `json:"rules"`
+}
+
+// UnmarshalJSON unmarshals the Rules, reading the evaluation interval from
+// evaluation_interval_ms.
+func (r *Rules) UnmarshalJSON(data []byte) error {
+	type Alias Rules
+	aux := &struct {
+		EvaluationIntervalMs *uint64 `json:"evaluation_interval_ms"`
+		*Alias
+	}{
+		Alias: (*Alias)(r),
+	}
+	json := jsoniter.ConfigFastest
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	r.EvaluationInterval = DefaultEvaluationInterval
+	if aux.EvaluationIntervalMs != nil {
+		r.EvaluationInterval = time.Duration(*aux.EvaluationIntervalMs) * time.Millisecond
+	}
+	return nil
+}
+
+// LoadRules reads and validates the alert rules file at the given path.
+func LoadRules(fileName string) (Rules, error) {
+	bts, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return Rules{}, err
+	}
+	return LoadRulesBytes(bts)
+}
+
+// LoadRulesBytes parses and validates the given alert rules file contents.
+func LoadRulesBytes(bts []byte) (Rules, error) {
+	rules := Rules{}
+	json := jsoniter.ConfigFastest
+	if err := json.Unmarshal(bts, &rules); err != nil {
+		return Rules{}, errors.New("parsing alert rules: " + err.Error())
+	}
+	for i, rule := range rules.Rules {
+		if rule.Severity == "" {
+			rules.Rules[i].Severity = DefaultSeverity
+		}
+	}
+	if err := rules.Validate(); err != nil {
+		return Rules{}, err
+	}
+	return rules, nil
+}
+
+// Validate returns an error describing the first problem found with the Rules,
+// or nil if they are valid.
+func (r Rules) Validate() error {
+	if r.EvaluationInterval <= 0 {
+		return errors.New("evaluation_interval_ms must be greater than 0")
+	}
+	for name, n := range r.Notifiers {
+		if err := n.Validate(); err != nil {
+			return fmt.Errorf("notifier '%s': %v", name, err)
+		}
+	}
+
+	names := map[string]struct{}{}
+	for i, rule := range r.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d: missing name", i)
+		}
+		if _, ok := names[rule.Name]; ok {
+			return fmt.Errorf("rule '%s': duplicate name", rule.Name)
+		}
+		names[rule.Name] = struct{}{}
+		if rule.Scope != ScopeDeliveryService && rule.Scope != ScopeCache {
+			return fmt.Errorf("rule '%s': scope must be '%s' or '%s'", rule.Name, ScopeDeliveryService, ScopeCache)
+		}
+		if _, ok := Metrics[rule.Metric]; !ok {
+			return fmt.Errorf("rule '%s': unknown metric '%s'", rule.Name, rule.Metric)
+		}
+		switch rule.Operator {
+		case OperatorGreater, OperatorGreaterEqual, OperatorLess, OperatorLessEqual:
+		default:
+			return fmt.Errorf("rule '%s': unknown operator '%s'", rule.Name, rule.Operator)
+		}
+		if _, ok := Severities[rule.Severity]; !ok {
+			return fmt.Errorf("rule '%s': unknown severity '%s'", rule.Name, rule.Severity)
+		}
+		for _, n := range rule.Notifiers {
+			if _, ok := r.Notifiers[n]; !ok {
+				return fmt.Errorf("rule '%s': unknown notifier '%s'", rule.Name, n)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package alerting
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadRulesBytes(t *testing.T) {
+	rules, err := LoadRulesBytes([]byte(`{
+	"notifiers": {
+		"oncall": {"type": "pagerduty", "routing_key": "abc"},
+		"hook": {"type": "webhook", "url": "http://example.test/alerts"}
+	},
+	"rules": [{
+		"name": "ds-5xx",
+		"scope": "deliveryService",
+		"metric": "tps_5xx_percent",
+		"operator": ">",
+		"threshold": 1,
+		"for_ms": 300000,
+		"notifiers": ["oncall", "hook"]
+	}]
+}`))
+	if err != nil {
+		t.Fatalf("expected no error, actual: %v", err)
+	}
+	if rules.EvaluationInterval != DefaultEvaluationInterval {
+		t.Errorf("expected default evaluation interval %v, actual: %v", DefaultEvaluationInterval, rules.EvaluationInterval)
+	}
+	if len(rules.Rules) != 1 {
+		t.Fatalf("expected 1 rule, actual: %d", len(rules.Rules))
+	}
+	rule := rules.Rules[0]
+	if rule.For != 5*time.Minute {
+		t.Errorf("expected for 5m, actual: %v", rule.For)
+	}
+	if rule.Severity != DefaultSeverity {
+		t.Errorf("expected default severity %s, actual: %s", DefaultSeverity, rule.Severity)
+	}
+}
+
+func TestLoadRulesBytesInvalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown metric":   `{"rules": [{"name": "r", "scope": "cache", "metric": "nope", "operator": ">"}]}`,
+		"unknown scope":    `{"rules": [{"name": "r", "scope": "server", "metric": "kbps", "operator": ">"}]}`,
+		"unknown operator": `{"rules": [{"name": "r", "scope": "cache", "metric": "kbps", "operator": "=="}]}`,
+		"unknown notifier": `{"rules": [{"name": "r", "scope": "cache", "metric": "kbps", "operator": ">", "notifiers": ["x"]}]}`,
+		"unknown severity": `{"rules": [{"name": "r", "scope": "cache", "metric": "kbps", "operator": ">", "severity": "bad"}]}`,
+		"duplicate name":   `{"rules": [{"name": "r", "scope": "cache", "metric": "kbps", "operator": ">"}, {"name": "r", "scope": "cache", "metric": "kbps", "operator": ">"}]}`,
+		"zero interval":    `{"evaluation_interval_ms": 0}`,
+		"webhook no url":   `{"notifiers": {"n": {"type": "webhook"}}}`,
+		"email no to":      `{"notifiers": {"n": {"type": "email", "smtp_server": "localhost:25", "from": "tm@example.test"}}}`,
+		"unknown type":     `{"notifiers": {"n": {"type": "carrier-pigeon"}}}`,
+	}
+	for name, input := range tests {
+		if _, err := LoadRulesBytes([]byte(input)); err == nil {
+			t.Errorf("%s: expected error, actual: nil", name)
+		}
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	s := Sample{Kbps: 100, Tps2xx: 90, Tps3xx: 5, Tps4xx: 3, Tps5xx: 2}
+	expected := map[string]float64{
+		"kbps":            100,
+		"tps_total":       100,
+		"tps_5xx":         2,
+		"tps_5xx_percent": 2,
+		"tps_4xx_percent": 3,
+	}
+	for name, val := range expected {
+		if actual := Metrics[name](s); actual != val {
+			t.Errorf("metric %s: expected %v, actual %v", name, val, actual)
+		}
+	}
+	if actual := Metrics["tps_5xx_percent"](Sample{}); actual != 0 {
+		t.Errorf("expected 5xx percent with no traffic to be 0, actual %v", actual)
+	}
+}
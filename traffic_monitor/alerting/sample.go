@@ -0,0 +1,58 @@
+package alerting
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// Sample is the stats of a single Delivery Service or cache server at the time
+// of an evaluation.
+type Sample struct {
+	Kbps   float64
+	Tps2xx float64
+	Tps3xx float64
+	Tps4xx float64
+	Tps5xx float64
+}
+
+// TpsTotal returns the total transactions per second of the Sample.
+func (s Sample) TpsTotal() float64 {
+	return s.Tps2xx + s.Tps3xx + s.Tps4xx + s.Tps5xx
+}
+
+// percentOfTotal returns tps as a percentage of the Sample's total
+// transactions per second. A Sample with no traffic has no errors.
+func (s Sample) percentOfTotal(tps float64) float64 {
+	total := s.TpsTotal()
+	if total == 0 {
+		return 0
+	}
+	return tps / total * 100
+}
+
+// Metrics maps the metric names a Rule may use to functions which compute
+// them from a Sample.
+var Metrics = map[string]func(Sample) float64{
+	"kbps":            func(s Sample) float64 { return s.Kbps },
+	"tps_total":       Sample.TpsTotal,
+	"tps_2xx":         func(s Sample) float64 { return s.Tps2xx },
+	"tps_3xx":         func(s Sample) float64 { return s.Tps3xx },
+	"tps_4xx":         func(s Sample) float64 { return s.Tps4xx },
+	"tps_5xx":         func(s Sample) float64 { return s.Tps5xx },
+	"tps_4xx_percent": func(s Sample) float64 { return s.percentOfTotal(s.Tps4xx) },
+	"tps_5xx_percent": func(s Sample) float64 { return s.percentOfTotal(s.Tps5xx) },
+}
@@ -103,6 +103,7 @@ func astatsParse(cacheName string, rdr io.Reader, pollCTX interface{}) (Statisti
 		}
 
 		stats.NotAvailable = astats.System.NotAvailable
+		stats.Storage = parseStorage(astats.Ats)
 
 		// TODO: what's using these?? Can we get rid of them?
 		astats.Ats["system.astatsLoad"] = float64(astats.System.AstatsLoad)
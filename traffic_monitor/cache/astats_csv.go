@@ -128,5 +128,7 @@ func astatsCsvParseCsv(cacheName string, data io.Reader) (Statistics, map[string
 		return stats, nil, fmt.Errorf("cache '%s' had no interfaces", cacheName)
 	}
 
+	stats.Storage = parseStorage(statMap)
+
 	return stats, statMap, nil
 }
@@ -142,6 +142,12 @@ type Vitals struct {
 	BytesIn    uint64
 	KbpsOut    int64
 	MaxKbpsOut int64
+	// Errors is the total number of network interface errors.
+	Errors uint64
+	// ErrorPolls is the number of consecutive polls in which the number of
+	// errors on a network interface grew. For a cache server, rather than a
+	// single interface, it is the greatest of its monitored interfaces'.
+	ErrorPolls uint64
 }
 
 // Stat is a generic stat, including the untyped value and the time the stat was
@@ -190,6 +196,9 @@ func ComputedStats() map[string]StatComputeFunc {
 		tc.StatNameMaxKBPS: func(info ResultInfo, _ tc.TrafficServer, _ tc.TMProfile, _ tc.IsAvailable) interface{} {
 			return info.Vitals.MaxKbpsOut
 		},
+		tc.StatNameLinkErrorPolls: func(info ResultInfo, _ tc.TrafficServer, _ tc.TMProfile, _ tc.IsAvailable) interface{} {
+			return info.Vitals.ErrorPolls
+		},
 		"loadavg": func(info ResultInfo, _ tc.TrafficServer, _ tc.TMProfile, _ tc.IsAvailable) interface{} {
 			return info.Vitals.LoadAvg
 		},
@@ -147,6 +147,9 @@ type Interface struct {
 	BytesOut uint64
 	// BytesIn is the total number of bytes received by this interface.
 	BytesIn uint64
+	// Errors is the total number of receive and transmit errors on this
+	// interface, or zero if they aren't reported.
+	Errors uint64
 }
 
 // Statistics is a structure containing, most generally, the statistics of a
@@ -157,6 +160,8 @@ type Statistics struct {
 	// Interfaces is a map of network interface names to statistic data about
 	// those interfaces.
 	Interfaces map[string]Interface
+	// Storage contains the cache storage utilization of the cache server.
+	Storage Storage
 	// NotAvailable reports whether or not the cache server is unavailable.
 	// Sometimes caches can directly report this, but it's not supported by
 	// stats_over_http (afaik), so it always just uses ``false''
@@ -194,6 +199,17 @@ func (s *Statistics) AddInterfaceFromRawLine(line string) error {
 	if iface.BytesOut, err = strconv.ParseUint(parts[8], 10, 64); err != nil {
 		return fmt.Errorf("Error parsing BytesOut: %v", err)
 	}
+	if len(parts) > 10 {
+		rxErrs, err := strconv.ParseUint(parts[2], 10, 64)
+		if err != nil {
+			return fmt.Errorf("Error parsing receive errors: %v", err)
+		}
+		txErrs, err := strconv.ParseUint(parts[10], 10, 64)
+		if err != nil {
+			return fmt.Errorf("Error parsing transmit errors: %v", err)
+		}
+		iface.Errors = rxErrs + txErrs
+	}
 
 	if s.Interfaces == nil {
 		s.Interfaces = map[string]Interface{
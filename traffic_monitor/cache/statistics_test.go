@@ -31,7 +31,7 @@ func ExampleLoadavgFromRawLine() {
 
 func ExampleStatistics_AddInterfaceFromRawLine() {
 	var s Statistics
-	raw := "eth0:47907832129 14601260    0    0    0     0          0   790726 728207677726 10210700052    0    0    0     0       0          0"
+	raw := "eth0:47907832129 14601260    3    0    0     0          0   790726 728207677726 10210700052    2    0    0     0       0          0"
 
 	if err := s.AddInterfaceFromRawLine(raw); err != nil {
 		fmt.Println(err)
@@ -43,6 +43,6 @@ func ExampleStatistics_AddInterfaceFromRawLine() {
 		fmt.Printf("Error, no 'eth0' interface!\n%+v", s.Interfaces)
 		return
 	}
-	fmt.Printf("eth0: {BytesOut: %d, BytesIn: %d}", iface.BytesOut, iface.BytesIn)
-	// Output: eth0: {BytesOut: 728207677726, BytesIn: 47907832129}
+	fmt.Printf("eth0: {BytesOut: %d, BytesIn: %d, Errors: %d}", iface.BytesOut, iface.BytesIn, iface.Errors)
+	// Output: eth0: {BytesOut: 728207677726, BytesIn: 47907832129, Errors: 5}
 }
@@ -104,6 +104,8 @@ func statsOverHTTPParse(cacheName string, data io.Reader, pollCTX interface{}) (
 		return stats, nil, fmt.Errorf("cache '%s' had no interfaces", cacheName)
 	}
 
+	stats.Storage = parseStorage(statMap)
+
 	return stats, statMap, nil
 }
 
@@ -315,6 +317,15 @@ func parseInterfaces(stats map[string]interface{}) map[string]Interface {
 				tmp := ifaces[statParts[0]]
 				tmp.Speed = speed
 				ifaces[statParts[0]] = tmp
+			case "rx_errors", "tx_errors":
+				errs, err := parseNumericStat(value)
+				if err != nil {
+					log.Warnf("%s for interface '%s' could not be parsed: %v", statParts[1], statParts[0], err)
+					continue
+				}
+				tmp := ifaces[statParts[0]]
+				tmp.Errors += errs
+				ifaces[statParts[0]] = tmp
 			}
 		}
 	}
@@ -85,6 +85,9 @@ func TestStatsOverHTTPParse(t *testing.T) {
 		if iface.BytesOut != 237634637 {
 			t.Errorf("Incorrect interface tx_bytes, expceted 237634637, got %d", iface.BytesOut)
 		}
+		if iface.Errors != 3 {
+			t.Errorf("Incorrect interface errors, expected 3, got %d", iface.Errors)
+		}
 	}
 	if !found {
 		t.Error("Didn't find the expected 'docker0' network interface")
@@ -149,6 +152,9 @@ func TestStatsOverHTTPParseCSV(t *testing.T) {
 		if iface.BytesOut != 237634637 {
 			t.Errorf("Incorrect interface tx_bytes, expceted 237634637, got %d", iface.BytesOut)
 		}
+		if iface.Errors != 3 {
+			t.Errorf("Incorrect interface errors, expected 3, got %d", iface.Errors)
+		}
 	}
 	if !found {
 		t.Error("Didn't find the expected 'docker0' network interface")
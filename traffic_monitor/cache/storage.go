@@ -0,0 +1,103 @@
+package cache
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"math"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// Storage contains the cache storage utilization of a polled cache server.
+type Storage struct {
+	// Reported is whether the cache server reported any cache storage
+	// statistics. Health polls, for example, may exclude them.
+	Reported bool
+	// RAMBytesUsed is the number of bytes used by the RAM cache.
+	RAMBytesUsed uint64
+	// RAMBytesTotal is the size of the RAM cache, in bytes.
+	RAMBytesTotal uint64
+	// DiskBytesUsed is the number of bytes used by the disk cache.
+	DiskBytesUsed uint64
+	// DiskBytesTotal is the size of the disk cache, in bytes.
+	DiskBytesTotal uint64
+	// Objects is the number of objects in the cache.
+	Objects uint64
+	// ObjectsTotal is the number of objects the cache can hold.
+	ObjectsTotal uint64
+	// WriteFailures is the number of failed cache writes since ATS started.
+	WriteFailures uint64
+	// SpansFailing is the number of cache spans (disks) that are failing.
+	SpansFailing uint64
+	// SpansOffline is the number of cache spans (disks) that are offline.
+	SpansOffline uint64
+}
+
+// usedPercent returns the percentage of total that is used, and false if the
+// total is zero.
+func usedPercent(used, total uint64) (float64, bool) {
+	if total == 0 {
+		return 0, false
+	}
+	return float64(used) / float64(total) * 100, true
+}
+
+// parseStorage parses the cache storage utilization of a cache server from
+// its ATS stats, as they appear in both astats and stats_over_http payloads,
+// and adds the utilization percentages it computes from them to those stats
+// so that they are recorded - and can have thresholds - like any other.
+//
+// Stats that are missing or aren't non-negative numbers are treated as zero.
+func parseStorage(stats map[string]interface{}) Storage {
+	var storage Storage
+	fields := []struct {
+		stat  string
+		field *uint64
+	}{
+		{tc.ATSStatCacheRAMBytesUsed, &storage.RAMBytesUsed},
+		{tc.ATSStatCacheRAMBytesTotal, &storage.RAMBytesTotal},
+		{tc.ATSStatCacheDiskBytesUsed, &storage.DiskBytesUsed},
+		{tc.ATSStatCacheDiskBytesTotal, &storage.DiskBytesTotal},
+		{tc.ATSStatCacheObjects, &storage.Objects},
+		{tc.ATSStatCacheObjectsTotal, &storage.ObjectsTotal},
+		{tc.ATSStatCacheWriteFailures, &storage.WriteFailures},
+		{tc.ATSStatCacheSpansFailing, &storage.SpansFailing},
+		{tc.ATSStatCacheSpansOffline, &storage.SpansOffline},
+	}
+	for _, f := range fields {
+		stat, ok := stats[f.stat]
+		if !ok {
+			continue
+		}
+		storage.Reported = true
+		if val, ok := util.ToNumeric(stat); ok && val >= 0 && val <= math.MaxUint64 {
+			*f.field = uint64(val)
+		}
+	}
+
+	if pct, ok := usedPercent(storage.RAMBytesUsed, storage.RAMBytesTotal); ok {
+		stats[tc.StatNameCacheRAMUsedPercent] = pct
+	}
+	if pct, ok := usedPercent(storage.DiskBytesUsed, storage.DiskBytesTotal); ok {
+		stats[tc.StatNameCacheDiskUsedPercent] = pct
+	}
+	return storage
+}
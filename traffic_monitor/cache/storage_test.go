@@ -0,0 +1,99 @@
+package cache
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/poller"
+)
+
+func TestParseStorage(t *testing.T) {
+	stats := map[string]interface{}{
+		tc.ATSStatCacheRAMBytesUsed:   float64(25),
+		tc.ATSStatCacheRAMBytesTotal:  float64(100),
+		tc.ATSStatCacheDiskBytesUsed:  "900",
+		tc.ATSStatCacheDiskBytesTotal: float64(1000),
+		tc.ATSStatCacheObjects:        float64(10),
+		tc.ATSStatCacheWriteFailures:  float64(-1),
+		tc.ATSStatCacheSpansFailing:   float64(1),
+	}
+	storage := parseStorage(stats)
+	if !storage.Reported {
+		t.Error("expected storage to be reported")
+	}
+	if storage.RAMBytesUsed != 25 || storage.RAMBytesTotal != 100 {
+		t.Errorf("expected 25 of 100 RAM cache bytes used, got %d of %d", storage.RAMBytesUsed, storage.RAMBytesTotal)
+	}
+	if storage.DiskBytesUsed != 900 || storage.DiskBytesTotal != 1000 {
+		t.Errorf("expected 900 of 1000 disk cache bytes used, got %d of %d", storage.DiskBytesUsed, storage.DiskBytesTotal)
+	}
+	if storage.Objects != 10 || storage.ObjectsTotal != 0 {
+		t.Errorf("expected 10 objects of an unreported total, got %d of %d", storage.Objects, storage.ObjectsTotal)
+	}
+	if storage.WriteFailures != 0 {
+		t.Errorf("expected a negative write failure count to be treated as zero, got %d", storage.WriteFailures)
+	}
+	if storage.SpansFailing != 1 {
+		t.Errorf("expected 1 failing span, got %d", storage.SpansFailing)
+	}
+	if pct := stats[tc.StatNameCacheRAMUsedPercent]; pct != float64(25) {
+		t.Errorf("expected stat %s to be 25, got %v", tc.StatNameCacheRAMUsedPercent, pct)
+	}
+	if pct := stats[tc.StatNameCacheDiskUsedPercent]; pct != float64(90) {
+		t.Errorf("expected stat %s to be 90, got %v", tc.StatNameCacheDiskUsedPercent, pct)
+	}
+
+	stats = map[string]interface{}{"proxy.process.http.current_client_connections": float64(1)}
+	if storage := parseStorage(stats); storage.Reported {
+		t.Errorf("expected no storage to be reported without cache storage stats, got %+v", storage)
+	}
+	if _, ok := stats[tc.StatNameCacheDiskUsedPercent]; ok {
+		t.Errorf("expected no stat %s without cache storage stats", tc.StatNameCacheDiskUsedPercent)
+	}
+}
+
+func TestStatsOverHTTPParseStorage(t *testing.T) {
+	fd, err := os.Open("stats_over_http.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fd.Close()
+
+	ctx := &poller.HTTPPollCtx{HTTPHeader: http.Header{}}
+	ctx.HTTPHeader.Set("Content-Type", "text/json")
+
+	stats, misc, err := statsOverHTTPParse("test", fd, ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stats.Storage.Reported {
+		t.Fatal("expected storage to be reported")
+	}
+	if stats.Storage.DiskBytesUsed != 22600777272700 || stats.Storage.Objects != 2072290 {
+		t.Errorf("unexpected storage: %+v", stats.Storage)
+	}
+	if _, ok := misc[tc.StatNameCacheDiskUsedPercent]; !ok {
+		t.Errorf("expected stat %s", tc.StatNameCacheDiskUsedPercent)
+	}
+}
@@ -100,14 +100,28 @@ func (t *PollingProtocol) UnmarshalJSON(b []byte) error {
 // Config is the configuration for the application. It includes myriad data,
 // such as polling intervals and log locations.
 type Config struct {
+	// A path to a file defining alerting rules and their notifiers. If empty,
+	// alerting is disabled.
+	AlertRulesFile string `json:"alert_rules_file"`
+	// Configures authentication of, and access control for, requests to the
+	// Traffic Monitor API. If nil, the API is open to anyone who can reach it.
+	APIAuth *APIAuth `json:"api_auth"`
 	// Sets the Internet Protocol version used for polling cache servers.
 	CachePollingProtocol PollingProtocol `json:"cache_polling_protocol"`
+	// A path to a file containing the public configuration signing key of
+	// this TM's CDN. If not empty, the signatures of all responses from
+	// Traffic Ops are verified with it, and unsigned or tampered responses are
+	// rejected.
+	CDNSigningKeyFile string `json:"cdn_signing_key_file"`
 	// A path to a file where CDN Snapshot backups are written.
 	CRConfigBackupFile string `json:"crconfig_backup_file"`
 	// The number of historical CDN Snapshots to store.
 	CRConfigHistoryCount uint64 `json:"crconfig_history_count"`
 	// Controls whether Distributed Polling is enabled.
 	DistributedPolling bool `json:"distributed_polling"`
+	// The durations of the sliding windows over which Delivery Service
+	// throughput is averaged.
+	DSThroughputWindows []time.Duration `json:"-"`
 	// Defines an interval on which Traffic Monitor will flush its collected
 	// health data such that it is made available through the API.
 	HealthFlushInterval time.Duration `json:"-"`
@@ -168,6 +182,40 @@ type Config struct {
 	TrafficOpsMinRetryInterval time.Duration `json:"-"`
 }
 
+// APIAuth configures authentication of, and access control for, requests to
+// the Traffic Monitor API.
+type APIAuth struct {
+	// Tokens maps the names of API clients to the shared secret tokens with
+	// which they authenticate, sent in an "Authorization: Bearer" header.
+	Tokens map[string]string `json:"tokens"`
+	// A path to a PEM file of Certificate Authorities. If not empty, clients
+	// of an HTTPS API may authenticate with a certificate signed by one of
+	// them, whose Subject Common Name is the client's name.
+	ClientCAFile string `json:"client_ca_file"`
+	// The access control rules of API endpoints. A request is checked against
+	// the rule with the longest path matching its own; requests no rule
+	// matches are allowed for any authenticated client.
+	ACL []APIACLRule `json:"acl"`
+}
+
+// APIACLRule is the access control rule of the Traffic Monitor API endpoints
+// under a path.
+type APIACLRule struct {
+	// The path to which the rule applies. A path ending in "/" applies to
+	// every path under it, otherwise it applies to the path itself and every
+	// path under it, e.g. "/publish/CrStates" applies to
+	// "/publish/CrStates/foo" but not "/publish/CrStatesFoo".
+	Path string `json:"path"`
+	// The names of the clients allowed. If empty, any authenticated client is
+	// allowed.
+	Clients []string `json:"clients"`
+	// CIDR networks from which requests are allowed without authentication,
+	// e.g. for peer Traffic Monitors and Traffic Routers.
+	Networks []string `json:"networks"`
+	// Whether requests are allowed without authentication from anywhere.
+	Anonymous bool `json:"anonymous"`
+}
+
 func (c Config) ErrorLog() log.LogLocation   { return log.LogLocation(c.LogLocationError) }
 func (c Config) WarningLog() log.LogLocation { return log.LogLocation(c.LogLocationWarning) }
 func (c Config) InfoLog() log.LogLocation    { return log.LogLocation(c.LogLocationInfo) }
@@ -187,9 +235,11 @@ func GetAccessLogWriter(cfg Config) (io.WriteCloser, error) {
 
 // DefaultConfig is the default configuration for the application, if no configuration file is given, or if a given config setting doesn't exist in the config file.
 var DefaultConfig = Config{
+	AlertRulesFile:               "",
 	CachePollingProtocol:         Both,
 	CRConfigBackupFile:           CRConfigBackupFile,
 	CRConfigHistoryCount:         100,
+	DSThroughputWindows:          []time.Duration{10 * time.Second, time.Minute, 5 * time.Minute},
 	HealthFlushInterval:          200 * time.Millisecond,
 	HTTPPollingFormat:            HTTPPollingFormat,
 	HTTPTimeout:                  2 * time.Second,
@@ -220,13 +270,14 @@ func (c *Config) MarshalJSON() ([]byte, error) {
 	type Alias Config
 	json := jsoniter.ConfigFastest // TODO make configurable?
 	return json.Marshal(&struct {
-		MonitorConfigPollingIntervalMs uint64 `json:"monitor_config_polling_interval_ms"`
-		HTTPTimeoutMS                  uint64 `json:"http_timeout_ms"`
-		HealthFlushIntervalMs          uint64 `json:"health_flush_interval_ms"`
-		StatFlushIntervalMs            uint64 `json:"stat_flush_interval_ms"`
-		StatBufferIntervalMs           uint64 `json:"stat_buffer_interval_ms"`
-		ServeReadTimeoutMs             uint64 `json:"serve_read_timeout_ms"`
-		ServeWriteTimeoutMs            uint64 `json:"serve_write_timeout_ms"`
+		MonitorConfigPollingIntervalMs uint64   `json:"monitor_config_polling_interval_ms"`
+		HTTPTimeoutMS                  uint64   `json:"http_timeout_ms"`
+		HealthFlushIntervalMs          uint64   `json:"health_flush_interval_ms"`
+		StatFlushIntervalMs            uint64   `json:"stat_flush_interval_ms"`
+		StatBufferIntervalMs           uint64   `json:"stat_buffer_interval_ms"`
+		ServeReadTimeoutMs             uint64   `json:"serve_read_timeout_ms"`
+		ServeWriteTimeoutMs            uint64   `json:"serve_write_timeout_ms"`
+		DSThroughputWindowsMs          []uint64 `json:"ds_throughput_windows_ms"`
 		*Alias
 	}{
 		MonitorConfigPollingIntervalMs: uint64(c.MonitorConfigPollingInterval / time.Millisecond),
@@ -234,23 +285,33 @@ func (c *Config) MarshalJSON() ([]byte, error) {
 		HealthFlushIntervalMs:          uint64(c.HealthFlushInterval / time.Millisecond),
 		StatFlushIntervalMs:            uint64(c.StatFlushInterval / time.Millisecond),
 		StatBufferIntervalMs:           uint64(c.StatBufferInterval / time.Millisecond),
+		DSThroughputWindowsMs:          durationsToMs(c.DSThroughputWindows),
 		Alias:                          (*Alias)(c),
 	})
 }
 
+func durationsToMs(ds []time.Duration) []uint64 {
+	ms := make([]uint64, 0, len(ds))
+	for _, d := range ds {
+		ms = append(ms, uint64(d/time.Millisecond))
+	}
+	return ms
+}
+
 // UnmarshalJSON populates this config object from given JSON bytes.
 func (c *Config) UnmarshalJSON(data []byte) error {
 	type Alias Config
 	aux := &struct {
-		MonitorConfigPollingIntervalMs *uint64 `json:"monitor_config_polling_interval_ms"`
-		HTTPTimeoutMS                  *uint64 `json:"http_timeout_ms"`
-		HealthFlushIntervalMs          *uint64 `json:"health_flush_interval_ms"`
-		StatFlushIntervalMs            *uint64 `json:"stat_flush_interval_ms"`
-		StatBufferIntervalMs           *uint64 `json:"stat_buffer_interval_ms"`
-		ServeReadTimeoutMs             *uint64 `json:"serve_read_timeout_ms"`
-		ServeWriteTimeoutMs            *uint64 `json:"serve_write_timeout_ms"`
-		TrafficOpsMinRetryIntervalMs   *uint64 `json:"traffic_ops_min_retry_interval_ms"`
-		TrafficOpsMaxRetryIntervalMs   *uint64 `json:"traffic_ops_max_retry_interval_ms"`
+		MonitorConfigPollingIntervalMs *uint64  `json:"monitor_config_polling_interval_ms"`
+		HTTPTimeoutMS                  *uint64  `json:"http_timeout_ms"`
+		HealthFlushIntervalMs          *uint64  `json:"health_flush_interval_ms"`
+		StatFlushIntervalMs            *uint64  `json:"stat_flush_interval_ms"`
+		StatBufferIntervalMs           *uint64  `json:"stat_buffer_interval_ms"`
+		ServeReadTimeoutMs             *uint64  `json:"serve_read_timeout_ms"`
+		ServeWriteTimeoutMs            *uint64  `json:"serve_write_timeout_ms"`
+		TrafficOpsMinRetryIntervalMs   *uint64  `json:"traffic_ops_min_retry_interval_ms"`
+		TrafficOpsMaxRetryIntervalMs   *uint64  `json:"traffic_ops_max_retry_interval_ms"`
+		DSThroughputWindowsMs          []uint64 `json:"ds_throughput_windows_ms"`
 		*Alias
 	}{
 		Alias: (*Alias)(c),
@@ -287,6 +348,15 @@ func (c *Config) UnmarshalJSON(data []byte) error {
 	if aux.TrafficOpsMaxRetryIntervalMs != nil {
 		c.TrafficOpsMaxRetryInterval = time.Duration(*aux.TrafficOpsMaxRetryIntervalMs) * time.Millisecond
 	}
+	if aux.DSThroughputWindowsMs != nil {
+		c.DSThroughputWindows = make([]time.Duration, 0, len(aux.DSThroughputWindowsMs))
+		for _, ms := range aux.DSThroughputWindowsMs {
+			if ms == 0 {
+				return errors.New("invalid configuration: ds_throughput_windows_ms cannot contain 0")
+			}
+			c.DSThroughputWindows = append(c.DSThroughputWindows, time.Duration(ms)*time.Millisecond)
+		}
+	}
 	if c.StatPolling && c.DistributedPolling {
 		return errors.New("invalid configuration: stat_polling cannot be enabled if distributed_polling is also enabled")
 	}
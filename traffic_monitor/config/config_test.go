@@ -21,6 +21,7 @@ package config
 
 import (
 	"testing"
+	"time"
 )
 
 const exampleTMConfig = `
@@ -47,7 +48,8 @@ const exampleTMConfig = `
 	"crconfig_backup_file": "crconfig.asdf",
 	"tmconfig_backup_file": "tmconfig.asdf",
 	"http_polling_format": "thisformatdoesnotexist",
-	"static_file_dir": "static/"
+	"static_file_dir": "static/",
+	"ds_throughput_windows_ms": [30000, 600000]
 }
 `
 
@@ -123,6 +125,9 @@ func TestConfigLoad(t *testing.T) {
 	if c.HTTPPollingFormat != "thisformatdoesnotexist" {
 		t.Errorf("HTTPPollingFormat - expected: thisformatdoesnotexist, actual: %s", c.HTTPPollingFormat)
 	}
+	if len(c.DSThroughputWindows) != 2 || c.DSThroughputWindows[0] != 30*time.Second || c.DSThroughputWindows[1] != 10*time.Minute {
+		t.Errorf("DSThroughputWindows - expected: [30s 10m0s], actual: %v", c.DSThroughputWindows)
+	}
 }
 
 func TestBadConfigLoad(t *testing.T) {
@@ -143,4 +148,7 @@ func TestConfigLoadDefaults(t *testing.T) {
 	if c.DistributedPolling != false {
 		t.Errorf("DistributedPolling default - expected: false, actual: %t", c.DistributedPolling)
 	}
+	if len(c.DSThroughputWindows) != 3 {
+		t.Errorf("DSThroughputWindows default - expected: 3 windows, actual: %v", c.DSThroughputWindows)
+	}
 }
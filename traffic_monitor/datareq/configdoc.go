@@ -27,10 +27,13 @@ import (
 
 func srvConfigDoc(opsConfig threadsafe.OpsConfig) ([]byte, error) {
 	opsConfigCopy := opsConfig.Get()
-	// if the password is blank, leave it blank, so callers can see it's missing.
+	// if the password or token is blank, leave it blank, so callers can see it's missing.
 	if opsConfigCopy.Password != "" {
 		opsConfigCopy.Password = "*****"
 	}
+	if opsConfigCopy.Token != "" {
+		opsConfigCopy.Token = "*****"
+	}
 	json := jsoniter.ConfigFastest
 	return json.Marshal(opsConfigCopy)
 }
@@ -21,19 +21,37 @@ package datareq
 
 import (
 	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc/signing"
 	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
 	"github.com/apache/trafficcontrol/traffic_monitor/towrap"
 )
 
-func srvTRConfig(opsConfig threadsafe.OpsConfig, toSession towrap.TrafficOpsSessionThreadsafe) ([]byte, time.Time, error) {
+func srvTRConfig(opsConfig threadsafe.OpsConfig, toSession towrap.TrafficOpsSessionThreadsafe) ([]byte, string, time.Time, error) {
 	cdnName := opsConfig.Get().CdnName
 	if !toSession.Initialized() {
-		return nil, time.Time{}, fmt.Errorf("Unable to connect to Traffic Ops")
+		return nil, "", time.Time{}, fmt.Errorf("Unable to connect to Traffic Ops")
 	}
 	if cdnName == "" {
-		return nil, time.Time{}, fmt.Errorf("No CDN Configured")
+		return nil, "", time.Time{}, fmt.Errorf("No CDN Configured")
+	}
+	return toSession.LastSignedCRConfig(cdnName)
+}
+
+// srvTRConfigHandler serves the CRConfig, along with its signature by Traffic
+// Ops, if Traffic Monitor verifies signatures, so Traffic Router can verify
+// it too.
+func srvTRConfigHandler(errorCount threadsafe.Uint, opsConfig threadsafe.OpsConfig, toSession towrap.TrafficOpsSessionThreadsafe) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		bytes, signature, crConfigTime, err := srvTRConfig(opsConfig, toSession)
+		if err == nil && signature != "" {
+			w.Header().Set(signing.ResponseHeader, signature)
+		}
+		WrapAgeErr(errorCount, func() ([]byte, time.Time, error) {
+			return bytes, crConfigTime, err
+		}, rfc.ApplicationJSON)(w, r)
 	}
-	return toSession.LastCRConfig(cdnName)
 }
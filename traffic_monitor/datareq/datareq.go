@@ -32,6 +32,7 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-rfc"
 	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_monitor/alerting"
 	"github.com/apache/trafficcontrol/traffic_monitor/config"
 	"github.com/apache/trafficcontrol/traffic_monitor/health"
 	"github.com/apache/trafficcontrol/traffic_monitor/peer"
@@ -53,6 +54,8 @@ func MakeDispatchMap(
 	statMaxKbpses threadsafe.CacheKbpses,
 	healthHistory threadsafe.ResultHistory,
 	dsStats threadsafe.DSStatsReader,
+	dsThroughput threadsafe.DSThroughputReader,
+	alerts *alerting.Engine,
 	events health.ThreadsafeEvents,
 	staticAppData config.StaticAppData,
 	healthPollInterval time.Duration,
@@ -80,9 +83,7 @@ func MakeDispatchMap(
 	}
 
 	dispatchMap := map[string]http.HandlerFunc{
-		"/publish/CrConfig": wrap(WrapAgeErr(errorCount, func() ([]byte, time.Time, error) {
-			return srvTRConfig(opsConfig, toSession)
-		}, rfc.ApplicationJSON)),
+		"/publish/CrConfig": wrap(srvTRConfigHandler(errorCount, opsConfig, toSession)),
 		"/publish/CrStates": wrap(WrapParams(func(params url.Values, path string) ([]byte, int) {
 			bytes, statusCode, err := srvTRState(params, localStates, combinedStates, peerStates, distributedPollingEnabled)
 			return WrapErrStatusCode(errorCount, path, bytes, statusCode, err)
@@ -96,6 +97,9 @@ func MakeDispatchMap(
 		"/publish/DsStats": wrap(WrapParams(func(params url.Values, path string) ([]byte, int) {
 			return srvDSStats(params, errorCount, path, toData, dsStats)
 		}, rfc.ApplicationJSON)),
+		"/publish/DsThroughput": wrap(WrapParams(func(params url.Values, path string) ([]byte, int) {
+			return srvDSThroughput(params, errorCount, path, dsThroughput)
+		}, rfc.ApplicationJSON)),
 		"/publish/EventLog": wrap(WrapErr(errorCount, func() ([]byte, error) {
 			return srvEventLog(events)
 		}, rfc.ApplicationJSON)),
@@ -141,9 +145,16 @@ func MakeDispatchMap(
 		"/api/monitor-config": wrap(WrapErr(errorCount, func() ([]byte, error) {
 			return srvMonitorConfig(monitorConfig)
 		}, rfc.ApplicationJSON)),
+		"/api/alerts": wrap(WrapErr(errorCount, func() ([]byte, error) {
+			return srvAPIAlerts(alerts)
+		}, rfc.ApplicationJSON)),
 		"/api/crconfig-history": wrap(WrapErr(errorCount, func() ([]byte, error) {
 			return srvAPICRConfigHist(toSession)
 		}, rfc.ApplicationJSON)),
+		"/federate": wrap(WrapParams(func(params url.Values, path string) ([]byte, int) {
+			return srvPrometheusFederate(params, errorCount, path, toData, dsStats, statResultHistory, combinedStates)
+		}, PrometheusContentType)),
+		"/api/v1/read": wrap(srvPrometheusRemoteRead(errorCount, toData, dsStats, statResultHistory, combinedStates)),
 	}
 	return addTrailingSlashEndpoints(dispatchMap)
 }
@@ -0,0 +1,124 @@
+package datareq
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/dsdata"
+	"github.com/apache/trafficcontrol/traffic_monitor/srvhttp"
+	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// DSThroughputResponse is the JSON representation of the /publish/DsThroughput endpoint. It maps delivery service names to window durations to the average throughput over that window.
+type DSThroughputResponse struct {
+	Windows          []string                                                      `json:"windows"`
+	DeliveryServices map[tc.DeliveryServiceName]map[string]dsdata.WindowThroughput `json:"deliveryServices"`
+	tc.CommonAPIData
+}
+
+// srvDSThroughput serves the average throughput of delivery services over the configured sliding windows.
+//
+// The delivery services may be limited by a path argument, as with /publish/DsStats/{ds}, or a comma-delimited `ds` parameter. The windows may be limited by a comma-delimited `window` parameter of Go durations, e.g. '10s,5m'. Per-cachegroup throughput is included if the `cachegroups` parameter is true.
+func srvDSThroughput(params url.Values, errorCount threadsafe.Uint, path string, dsThroughput threadsafe.DSThroughputReader) ([]byte, int) {
+	dses := map[tc.DeliveryServiceName]struct{}{}
+	if ds := getPathArgument(path); ds != "" {
+		dses[tc.DeliveryServiceName(ds)] = struct{}{}
+	}
+	if dsParam, ok := params["ds"]; ok && len(dsParam) > 0 {
+		for _, ds := range strings.Split(dsParam[0], ",") {
+			dses[tc.DeliveryServiceName(ds)] = struct{}{}
+		}
+	}
+
+	windows := dsThroughput.Windows()
+	if windowParam, ok := params["window"]; ok && len(windowParam) > 0 {
+		requested := []time.Duration{}
+		for _, w := range strings.Split(windowParam[0], ",") {
+			d, err := time.ParseDuration(w)
+			if err != nil {
+				err = fmt.Errorf("invalid window '%s': %v", w, err)
+				HandleErr(errorCount, path, err)
+				return []byte(err.Error()), http.StatusBadRequest
+			}
+			if !containsDuration(windows, d) {
+				err = fmt.Errorf("window '%s' is not configured; available windows are %v", w, windows)
+				HandleErr(errorCount, path, err)
+				return []byte(err.Error()), http.StatusBadRequest
+			}
+			requested = append(requested, d)
+		}
+		windows = requested
+	}
+
+	withCacheGroups := false
+	if cgParam, ok := params["cachegroups"]; ok && len(cgParam) > 0 {
+		b, err := strconv.ParseBool(cgParam[0])
+		if err != nil {
+			err = fmt.Errorf("invalid cachegroups parameter '%s': %v", cgParam[0], err)
+			HandleErr(errorCount, path, err)
+			return []byte(err.Error()), http.StatusBadRequest
+		}
+		withCacheGroups = b
+	}
+
+	resp := DSThroughputResponse{
+		Windows:          make([]string, 0, len(windows)),
+		DeliveryServices: map[tc.DeliveryServiceName]map[string]dsdata.WindowThroughput{},
+		CommonAPIData:    srvhttp.GetCommonAPIData(params, time.Now()),
+	}
+	for _, w := range windows {
+		resp.Windows = append(resp.Windows, w.String())
+	}
+
+	for _, ds := range dsThroughput.DeliveryServiceNames() {
+		if _, ok := dses[ds]; len(dses) > 0 && !ok {
+			continue
+		}
+		dsWindows := make(map[string]dsdata.WindowThroughput, len(windows))
+		for _, w := range windows {
+			if wt, ok := dsThroughput.Get(ds, w, withCacheGroups); ok {
+				dsWindows[w.String()] = wt
+			}
+		}
+		resp.DeliveryServices[ds] = dsWindows
+	}
+
+	json := jsoniter.ConfigFastest
+	bytes, err := json.Marshal(resp)
+	return WrapErrCode(errorCount, path, bytes, err)
+}
+
+func containsDuration(ds []time.Duration, d time.Duration) bool {
+	for _, o := range ds {
+		if o == d {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,294 @@
+package datareq
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/dsdata"
+	"github.com/apache/trafficcontrol/traffic_monitor/peer"
+	"github.com/apache/trafficcontrol/traffic_monitor/prometheus"
+	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
+	"github.com/apache/trafficcontrol/traffic_monitor/todata"
+)
+
+// PrometheusContentType is the Content-Type of the Prometheus text exposition
+// format served by the federation endpoint.
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// maxRemoteReadBytes is the largest remote-read request body which will be
+// read. Real requests are tiny - a handful of label matchers per query.
+const maxRemoteReadBytes = 1 << 20
+
+// The parameter used by the federation endpoint to select series, as in
+// Prometheus.
+const federateMatchParam = "match[]"
+
+// promSeries builds Prometheus time series from Traffic Monitor's current
+// delivery service stats, cache availability, and the polled cache stat
+// history.
+func promSeries(
+	toData todata.TOData,
+	dsStats dsdata.StatsReadonly,
+	statResultHistory threadsafe.ResultStatHistory,
+	combinedStates tc.CRStates,
+) []prometheus.TimeSeries {
+	series := []prometheus.TimeSeries{}
+	now := time.Now().UnixMilli()
+
+	for cacheName, avail := range combinedStates.Caches {
+		ts := prometheus.NewTimeSeries(prometheus.MetricPrefix+"cache_available",
+			"cache", string(cacheName),
+			"cachegroup", string(toData.ServerCachegroups[cacheName]),
+			"type", string(toData.ServerTypes[cacheName]),
+			"status", avail.Status,
+		)
+		t := now
+		if !avail.LastPoll.IsZero() {
+			t = avail.LastPoll.UnixMilli()
+		}
+		v, _ := prometheus.ToFloat(avail.IsAvailable)
+		ts.Samples = []prometheus.Sample{{Value: v, TimestampMS: t}}
+		series = append(series, ts)
+	}
+
+	for dsName, avail := range combinedStates.DeliveryService {
+		ts := prometheus.NewTimeSeries(prometheus.MetricPrefix+"ds_available", "deliveryservice", string(dsName))
+		v, _ := prometheus.ToFloat(avail.IsAvailable)
+		ts.Samples = []prometheus.Sample{{Value: v, TimestampMS: now}}
+		series = append(series, ts)
+	}
+
+	for routerName, router := range combinedStates.Routers {
+		t := now
+		if !router.LastPoll.IsZero() {
+			t = router.LastPoll.UnixMilli()
+		}
+		v, _ := prometheus.ToFloat(router.IsAvailable)
+		ts := prometheus.NewTimeSeries(prometheus.MetricPrefix+"router_available", "router", routerName)
+		ts.Samples = []prometheus.Sample{{Value: v, TimestampMS: t}}
+		series = append(series, ts)
+		ts = prometheus.NewTimeSeries(prometheus.MetricPrefix+"router_dns_queries_per_second", "router", routerName)
+		ts.Samples = []prometheus.Sample{{Value: router.DNSQueriesPerSecond, TimestampMS: t}}
+		series = append(series, ts)
+		ts = prometheus.NewTimeSeries(prometheus.MetricPrefix+"router_http_queries_per_second", "router", routerName)
+		ts.Samples = []prometheus.Sample{{Value: router.HTTPQueriesPerSecond, TimestampMS: t}}
+		series = append(series, ts)
+	}
+
+	dsTime := now
+	if t := dsStats.Timestamp(); !t.IsZero() {
+		dsTime = t.UnixMilli()
+	}
+	for _, dsName := range dsStats.DeliveryServiceNames() {
+		stat, ok := dsStats.Get(dsName)
+		if !ok {
+			continue
+		}
+		series = appendDSCacheStats(series, stat.Total(), dsTime, "deliveryservice", string(dsName))
+		statCopy := stat.Copy()
+		for cgName, cgStats := range statCopy.CacheGroups {
+			series = appendDSCacheStats(series, cgStats, dsTime, "deliveryservice", string(dsName), "cachegroup", string(cgName))
+		}
+		for cacheType, typeStats := range statCopy.Types {
+			series = appendDSCacheStats(series, typeStats, dsTime, "deliveryservice", string(dsName), "cache_type", cacheType.String())
+		}
+		common := stat.Common()
+		ts := prometheus.NewTimeSeries(prometheus.MetricPrefix+"ds_caches_available", "deliveryservice", string(dsName))
+		ts.Samples = []prometheus.Sample{{Value: float64(common.CachesAvailable().Value), TimestampMS: dsTime}}
+		series = append(series, ts)
+		ts = prometheus.NewTimeSeries(prometheus.MetricPrefix+"ds_caches_configured", "deliveryservice", string(dsName))
+		ts.Samples = []prometheus.Sample{{Value: float64(common.CachesConfigured().Value), TimestampMS: dsTime}}
+		series = append(series, ts)
+	}
+
+	statResultHistory.Range(func(cacheName string, history threadsafe.CacheStatHistory) bool {
+		cache := tc.CacheName(cacheName)
+		labels := []string{
+			"cache", cacheName,
+			"cachegroup", string(toData.ServerCachegroups[cache]),
+			"type", string(toData.ServerTypes[cache]),
+		}
+		history.Stats.Range(func(stat string, vals []tc.ResultStatVal) bool {
+			if ts, ok := statValSeries(prometheus.MetricPrefix+"cache_"+prometheus.SanitizeName(stat), vals, labels...); ok {
+				series = append(series, ts)
+			}
+			return true
+		})
+		for interfaceName, interfaceHistory := range history.Interfaces {
+			interfaceLabels := append([]string{"interface", interfaceName}, labels...)
+			interfaceHistory.Range(func(stat string, vals []tc.ResultStatVal) bool {
+				if ts, ok := statValSeries(prometheus.MetricPrefix+"cache_interface_"+prometheus.SanitizeName(stat), vals, interfaceLabels...); ok {
+					series = append(series, ts)
+				}
+				return true
+			})
+		}
+		return true
+	})
+
+	return series
+}
+
+// appendDSCacheStats appends a series for each numeric member of the given
+// aggregated delivery service stats.
+func appendDSCacheStats(series []prometheus.TimeSeries, stats *dsdata.StatCacheStats, t int64, labels ...string) []prometheus.TimeSeries {
+	if stats == nil {
+		return series
+	}
+	values := map[string]float64{
+		"kbps":       stats.Kbps.Value,
+		"tps_total":  stats.TpsTotal.Value,
+		"tps_2xx":    stats.Tps2xx.Value,
+		"tps_3xx":    stats.Tps3xx.Value,
+		"tps_4xx":    stats.Tps4xx.Value,
+		"tps_5xx":    stats.Tps5xx.Value,
+		"in_bytes":   stats.InBytes.Value,
+		"out_bytes":  float64(stats.OutBytes.Value),
+		"status_2xx": float64(stats.Status2xx.Value),
+		"status_3xx": float64(stats.Status3xx.Value),
+		"status_4xx": float64(stats.Status4xx.Value),
+		"status_5xx": float64(stats.Status5xx.Value),
+	}
+	for name, value := range values {
+		ts := prometheus.NewTimeSeries(prometheus.MetricPrefix+"ds_"+name, labels...)
+		ts.Samples = []prometheus.Sample{{Value: value, TimestampMS: t}}
+		series = append(series, ts)
+	}
+	return series
+}
+
+// statValSeries converts a cache stat history into a series. The second
+// returned value is false if the stat is not numeric.
+//
+// Stat histories are stored newest-first, and consecutive identical values
+// are collapsed into a single entry; this returns one Sample per entry, in
+// the ascending order Prometheus requires.
+func statValSeries(name string, vals []tc.ResultStatVal, labels ...string) (prometheus.TimeSeries, bool) {
+	ts := prometheus.NewTimeSeries(name, labels...)
+	for _, val := range vals {
+		f, ok := prometheus.ToFloat(val.Val)
+		if !ok {
+			return ts, false
+		}
+		ts.Samples = append(ts.Samples, prometheus.Sample{Value: f, TimestampMS: val.Time.UnixMilli()})
+	}
+	if len(ts.Samples) == 0 {
+		return ts, false
+	}
+	sort.Slice(ts.Samples, func(i, j int) bool { return ts.Samples[i].TimestampMS < ts.Samples[j].TimestampMS })
+	return ts, true
+}
+
+// srvPrometheusFederate serves the latest value of every series matching any
+// of the request's 'match[]' selectors, in the Prometheus text exposition
+// format. If no selectors are given, all series are served.
+func srvPrometheusFederate(
+	params url.Values,
+	errorCount threadsafe.Uint,
+	path string,
+	toData todata.TODataThreadsafe,
+	dsStats threadsafe.DSStatsReader,
+	statResultHistory threadsafe.ResultStatHistory,
+	combinedStates peer.CRStatesThreadsafe,
+) ([]byte, int) {
+	selectors := [][]prometheus.Matcher{}
+	for _, selector := range params[federateMatchParam] {
+		matchers, err := prometheus.ParseSelector(selector)
+		if err != nil {
+			HandleErr(errorCount, path, err)
+			return []byte(err.Error()), http.StatusBadRequest
+		}
+		selectors = append(selectors, matchers)
+	}
+
+	all := promSeries(toData.Get(), dsStats.Get(), statResultHistory, combinedStates.Get())
+	series := all
+	if len(selectors) > 0 {
+		series = make([]prometheus.TimeSeries, 0, len(all))
+		for _, ts := range all {
+			for _, matchers := range selectors {
+				if ts.MatchesAll(matchers) {
+					series = append(series, ts)
+					break
+				}
+			}
+		}
+	}
+
+	buf := bytes.Buffer{}
+	err := prometheus.WriteText(&buf, series)
+	return WrapErrCode(errorCount, path, buf.Bytes(), err)
+}
+
+// srvPrometheusRemoteRead returns a handler implementing the Prometheus
+// remote-read protocol over the same series as the federation endpoint,
+// including the full polled history of each cache stat.
+func srvPrometheusRemoteRead(
+	errorCount threadsafe.Uint,
+	toData todata.TODataThreadsafe,
+	dsStats threadsafe.DSStatsReader,
+	statResultHistory threadsafe.ResultStatHistory,
+	combinedStates peer.CRStatesThreadsafe,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := r.URL.EscapedPath()
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			log.Write(w, []byte(http.StatusText(http.StatusMethodNotAllowed)), path)
+			return
+		}
+
+		body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxRemoteReadBytes))
+		if err != nil {
+			HandleErr(errorCount, path, fmt.Errorf("reading request body: %w", err))
+			w.WriteHeader(http.StatusBadRequest)
+			log.Write(w, []byte(http.StatusText(http.StatusBadRequest)), path)
+			return
+		}
+		req, err := prometheus.DecodeReadRequest(body)
+		if err != nil {
+			HandleErr(errorCount, path, fmt.Errorf("decoding remote read request: %w", err))
+			w.WriteHeader(http.StatusBadRequest)
+			log.Write(w, []byte(err.Error()), path)
+			return
+		}
+
+		all := promSeries(toData.Get(), dsStats.Get(), statResultHistory, combinedStates.Get())
+		resp := prometheus.ReadResponse{Results: make([]prometheus.QueryResult, 0, len(req.Queries))}
+		for _, q := range req.Queries {
+			resp.Results = append(resp.Results, prometheus.QueryResult{TimeSeries: prometheus.SelectRange(all, q)})
+		}
+
+		w.Header().Set(rfc.ContentType, prometheus.ContentTypeProtobuf)
+		w.Header().Set(rfc.ContentEncoding, prometheus.ContentEncodingSnappy)
+		log.Write(w, prometheus.EncodeReadResponse(resp), path)
+	}
+}
@@ -59,6 +59,8 @@ type StatsOld struct {
 type StatsReadonly interface {
 	Get(tc.DeliveryServiceName) (StatReadonly, bool)
 	JSON(Filter, url.Values) StatsOld
+	DeliveryServiceNames() []tc.DeliveryServiceName
+	Timestamp() time.Time
 }
 
 // StatReadonly is a read-only interface for a delivery service Stat, designed to be passed to multiple goroutine readers.
@@ -309,6 +311,20 @@ func (s Stats) Get(name tc.DeliveryServiceName) (StatReadonly, bool) {
 	return ds, ok
 }
 
+// DeliveryServiceNames returns the names of all delivery services which have stats. It is part of the StatsReadonly interface.
+func (s Stats) DeliveryServiceNames() []tc.DeliveryServiceName {
+	names := make([]tc.DeliveryServiceName, 0, len(s.DeliveryService))
+	for name := range s.DeliveryService {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Timestamp returns the time the stats were computed. It is part of the StatsReadonly interface.
+func (s Stats) Timestamp() time.Time {
+	return s.Time
+}
+
 // JSON returns an object formatted as expected to be serialized to JSON and served.
 func (s Stats) JSON(filter Filter, params url.Values) StatsOld {
 	// TODO fix to be the time calculated, not the time requested
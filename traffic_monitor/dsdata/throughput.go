@@ -0,0 +1,247 @@
+package dsdata
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"sort"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// Throughput is a delivery service's average bandwidth and transactions per
+// second over some window.
+type Throughput struct {
+	Kbps float64 `json:"kbps"`
+	Tps  float64 `json:"tps"`
+}
+
+func (t Throughput) add(o Throughput) Throughput {
+	return Throughput{Kbps: t.Kbps + o.Kbps, Tps: t.Tps + o.Tps}
+}
+
+func (t Throughput) sub(o Throughput) Throughput {
+	return Throughput{Kbps: t.Kbps - o.Kbps, Tps: t.Tps - o.Tps}
+}
+
+func (t Throughput) div(n int) Throughput {
+	if n == 0 {
+		return Throughput{}
+	}
+	return Throughput{Kbps: t.Kbps / float64(n), Tps: t.Tps / float64(n)}
+}
+
+// WindowThroughput is a delivery service's throughput averaged over a single
+// sliding window.
+type WindowThroughput struct {
+	Throughput
+	// Samples is the number of stat computations averaged.
+	Samples int `json:"samples"`
+	// CacheGroups is the average throughput of each Cache Group serving the
+	// delivery service, over the samples in which that Cache Group reported.
+	CacheGroups map[tc.CacheGroupName]Throughput `json:"cacheGroups,omitempty"`
+}
+
+// throughputSample is a single delivery service stat computation.
+type throughputSample struct {
+	time        time.Time
+	total       Throughput
+	cacheGroups map[tc.CacheGroupName]Throughput
+}
+
+// throughputWindow maintains running sums over the samples within a window,
+// so averages may be computed without iterating over the samples.
+type throughputWindow struct {
+	duration time.Duration
+	// start is the index of the oldest sample in the window.
+	start   int
+	count   int
+	total   Throughput
+	cgSums  map[tc.CacheGroupName]Throughput
+	cgCount map[tc.CacheGroupName]int
+}
+
+func (w *throughputWindow) add(s throughputSample) {
+	w.count++
+	w.total = w.total.add(s.total)
+	for cg, t := range s.cacheGroups {
+		w.cgSums[cg] = w.cgSums[cg].add(t)
+		w.cgCount[cg]++
+	}
+}
+
+func (w *throughputWindow) remove(s throughputSample) {
+	w.count--
+	w.total = w.total.sub(s.total)
+	if w.count == 0 {
+		w.total = Throughput{} // don't accumulate floating point error across idle periods
+	}
+	for cg, t := range s.cacheGroups {
+		w.cgCount[cg]--
+		if w.cgCount[cg] <= 0 {
+			delete(w.cgCount, cg)
+			delete(w.cgSums, cg)
+			continue
+		}
+		w.cgSums[cg] = w.cgSums[cg].sub(t)
+	}
+}
+
+// dsThroughput is the sliding window data of a single delivery service.
+type dsThroughput struct {
+	// samples are ordered oldest-first, and contain every sample within the
+	// largest window.
+	samples []throughputSample
+	windows []*throughputWindow
+}
+
+// ThroughputWindows computes the average throughput of every delivery
+// service over a set of sliding windows. Each window keeps running sums which
+// are updated as samples enter and leave it, so adding a sample costs time
+// proportional to the number of Cache Groups in it, regardless of the
+// window sizes.
+//
+// ThroughputWindows is not safe for use by multiple goroutines.
+type ThroughputWindows struct {
+	durations []time.Duration
+	dses      map[tc.DeliveryServiceName]*dsThroughput
+}
+
+// NewThroughputWindows creates a new ThroughputWindows computing averages
+// over the given window durations. Non-positive durations are ignored.
+func NewThroughputWindows(durations []time.Duration) *ThroughputWindows {
+	ds := make([]time.Duration, 0, len(durations))
+	for _, d := range durations {
+		if d > 0 {
+			ds = append(ds, d)
+		}
+	}
+	sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+	return &ThroughputWindows{durations: ds, dses: map[tc.DeliveryServiceName]*dsThroughput{}}
+}
+
+// Durations returns the window durations, in ascending order.
+func (tw *ThroughputWindows) Durations() []time.Duration {
+	return tw.durations
+}
+
+// Add adds a new delivery service stat computation to every window, and
+// evicts samples which have aged out of each. Delivery services absent from
+// the given stats stop receiving samples, and are removed once they have no
+// samples left in any window.
+func (tw *ThroughputWindows) Add(stats Stats) {
+	if len(tw.durations) == 0 {
+		return
+	}
+	now := stats.Time
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	for dsName, stat := range stats.DeliveryService {
+		d, ok := tw.dses[dsName]
+		if !ok {
+			d = &dsThroughput{windows: make([]*throughputWindow, 0, len(tw.durations))}
+			for _, duration := range tw.durations {
+				d.windows = append(d.windows, &throughputWindow{
+					duration: duration,
+					cgSums:   map[tc.CacheGroupName]Throughput{},
+					cgCount:  map[tc.CacheGroupName]int{},
+				})
+			}
+			tw.dses[dsName] = d
+		}
+
+		s := throughputSample{
+			time:        now,
+			total:       Throughput{Kbps: stat.TotalStats.Kbps.Value, Tps: stat.TotalStats.TpsTotal.Value},
+			cacheGroups: make(map[tc.CacheGroupName]Throughput, len(stat.CacheGroups)),
+		}
+		for cg, cgStat := range stat.CacheGroups {
+			s.cacheGroups[cg] = Throughput{Kbps: cgStat.Kbps.Value, Tps: cgStat.TpsTotal.Value}
+		}
+		d.samples = append(d.samples, s)
+		for _, w := range d.windows {
+			w.add(s)
+		}
+	}
+
+	for dsName, d := range tw.dses {
+		d.evict(now)
+		if len(d.samples) == 0 {
+			delete(tw.dses, dsName)
+		}
+	}
+}
+
+// evict removes samples older than each window's duration from that window,
+// and drops samples which have left every window.
+func (d *dsThroughput) evict(now time.Time) {
+	for _, w := range d.windows {
+		for w.start < len(d.samples) && now.Sub(d.samples[w.start].time) > w.duration {
+			w.remove(d.samples[w.start])
+			w.start++
+		}
+	}
+
+	// windows are sorted by duration, so the last window is the largest and has the oldest start.
+	drop := d.windows[len(d.windows)-1].start
+	if drop == 0 {
+		return
+	}
+	d.samples = append(d.samples[:0], d.samples[drop:]...)
+	for _, w := range d.windows {
+		w.start -= drop
+	}
+}
+
+// Get returns the average throughput of the given delivery service over the
+// window of the given duration, and whether that delivery service and window
+// exist.
+func (tw *ThroughputWindows) Get(dsName tc.DeliveryServiceName, duration time.Duration, withCacheGroups bool) (WindowThroughput, bool) {
+	d, ok := tw.dses[dsName]
+	if !ok {
+		return WindowThroughput{}, false
+	}
+	for _, w := range d.windows {
+		if w.duration != duration {
+			continue
+		}
+		wt := WindowThroughput{Throughput: w.total.div(w.count), Samples: w.count}
+		if withCacheGroups {
+			wt.CacheGroups = make(map[tc.CacheGroupName]Throughput, len(w.cgSums))
+			for cg, sum := range w.cgSums {
+				wt.CacheGroups[cg] = sum.div(w.cgCount[cg])
+			}
+		}
+		return wt, true
+	}
+	return WindowThroughput{}, false
+}
+
+// DeliveryServiceNames returns the names of all delivery services with
+// samples in any window.
+func (tw *ThroughputWindows) DeliveryServiceNames() []tc.DeliveryServiceName {
+	names := make([]tc.DeliveryServiceName, 0, len(tw.dses))
+	for name := range tw.dses {
+		names = append(names, name)
+	}
+	return names
+}
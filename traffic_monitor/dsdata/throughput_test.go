@@ -0,0 +1,90 @@
+package dsdata
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func makeThroughputStats(t time.Time, kbps float64, cacheGroups map[tc.CacheGroupName]float64) Stats {
+	stat := NewStat()
+	stat.TotalStats.Kbps.Value = kbps
+	stat.TotalStats.TpsTotal.Value = kbps / 10
+	for cg, cgKbps := range cacheGroups {
+		stat.CacheGroups[cg] = &StatCacheStats{Kbps: StatFloat{Value: cgKbps}, TpsTotal: StatFloat{Value: cgKbps / 10}}
+	}
+	stats := NewStats(1)
+	stats.DeliveryService["ds0"] = stat
+	stats.Time = t
+	return *stats
+}
+
+func TestThroughputWindows(t *testing.T) {
+	start := time.Now()
+	tw := NewThroughputWindows([]time.Duration{time.Minute, 10 * time.Second, 0})
+	if len(tw.Durations()) != 2 || tw.Durations()[0] != 10*time.Second {
+		t.Fatalf("expected non-positive windows to be dropped and the rest sorted, got %v", tw.Durations())
+	}
+
+	// one sample per 5 seconds, for a minute; kbps is the number of seconds since start.
+	for i := 0; i <= 12; i++ {
+		secs := float64(i * 5)
+		cgs := map[tc.CacheGroupName]float64{"cg0": secs}
+		if i%2 == 0 {
+			cgs["cg1"] = 1
+		}
+		tw.Add(makeThroughputStats(start.Add(time.Duration(i*5)*time.Second), secs, cgs))
+	}
+
+	// 10s window: samples at 50, 55, 60 seconds.
+	wt, ok := tw.Get("ds0", 10*time.Second, true)
+	if !ok {
+		t.Fatal("expected 10s window to exist")
+	}
+	if wt.Samples != 3 || math.Abs(wt.Kbps-55) > 0.0001 || math.Abs(wt.Tps-5.5) > 0.0001 {
+		t.Errorf("10s window - expected 3 samples averaging 55 kbps and 5.5 tps, actual: %+v", wt)
+	}
+	if cg1 := wt.CacheGroups["cg1"]; math.Abs(cg1.Kbps-1) > 0.0001 {
+		t.Errorf("10s window - expected cg1 to average 1 kbps over the samples it reported in, actual: %+v", cg1)
+	}
+
+	// 1m window: all 13 samples, 0..60 seconds.
+	wt, ok = tw.Get("ds0", time.Minute, false)
+	if !ok {
+		t.Fatal("expected 1m window to exist")
+	}
+	if wt.Samples != 13 || math.Abs(wt.Kbps-30) > 0.0001 || wt.CacheGroups != nil {
+		t.Errorf("1m window - expected 13 samples averaging 30 kbps without cachegroups, actual: %+v", wt)
+	}
+
+	if _, ok := tw.Get("ds0", 5*time.Minute, false); ok {
+		t.Error("expected unconfigured window not to exist")
+	}
+
+	// once the delivery service stops reporting, it ages out of every window.
+	tw.Add(Stats{DeliveryService: map[tc.DeliveryServiceName]*Stat{}, Time: start.Add(10 * time.Minute)})
+	if _, ok := tw.Get("ds0", time.Minute, false); ok {
+		t.Error("expected delivery service with no samples in any window to be removed")
+	}
+}
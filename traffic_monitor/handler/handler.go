@@ -38,6 +38,12 @@ type OpsConfig struct {
 	// The path to an SSL certificate to use with KeyFile to provide HTTP
 	// encryption for the TM API and web UI.
 	CertFile string `json:"certFile"`
+	// The path to a TLS client certificate with which to authenticate with
+	// Traffic Ops, as the user named by its Subject Common Name. Ignored if
+	// Token is set.
+	ClientCertFile string `json:"clientCertFile"`
+	// The path to the private key of ClientCertFile.
+	ClientKeyFile string `json:"clientKeyFile"`
 	// The address on which to listen for HTTP requests.
 	HttpListener string `json:"httpListener"`
 	// The address on which to listen for HTTPS requests. If not set, TM serves
@@ -52,6 +58,9 @@ type OpsConfig struct {
 	KeyFile string `json:"keyFile"`
 	// The password of the user identified by Username.
 	Password string `json:"password"`
+	// An authentication token with which to authenticate with Traffic Ops. If
+	// set, Username and Password are ignored.
+	Token string `json:"token"`
 	// The URL at which Traffic Ops may be reached.
 	Url string `json:"url"`
 	// The username of the user as whom to authenticate with Traffic Ops.
@@ -0,0 +1,331 @@
+package health
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_monitor/cache"
+	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
+)
+
+// These are the names of the health algorithms, as given by the
+// tc.HealthAlgorithmParameterName Parameter of a Profile.
+const (
+	AlgorithmThreshold = "threshold"
+	AlgorithmEWMA      = "ewma"
+	AlgorithmErrorRate = "error-rate"
+	AlgorithmComposite = "composite"
+)
+
+// DefaultAlgorithm is the health algorithm used for Profiles that don't
+// select one.
+const DefaultAlgorithm = AlgorithmThreshold
+
+// These are the names of the settings of the health algorithms, as they
+// follow the tc.HealthAlgorithmPrefix in the Names of their Parameters.
+const (
+	ParamEWMAAlpha               = "ewma.alpha"
+	ParamEWMAMaxResponseTimeMS   = "ewma.maxResponseTimeMs"
+	ParamErrorRateWindow         = "errorRate.window"
+	ParamErrorRateMax            = "errorRate.max"
+	ParamCompositeMinScore       = "composite.minScore"
+	ParamCompositeWeightPrefix   = "composite.weight."
+	DefaultEWMAAlpha             = 0.3
+	DefaultEWMAMaxResponseTimeMS = 1000
+	DefaultErrorRateWindow       = 10
+	DefaultErrorRateMax          = 0.5
+	DefaultCompositeMinScore     = 0.5
+)
+
+// Algorithm decides whether a cache server is healthy from the results of
+// polling it. The server's Status has already been taken into account: Eval is
+// only called for servers whose Status doesn't decide their availability on
+// its own.
+type Algorithm interface {
+	// Eval returns whether the cache server that produced result is healthy,
+	// a description of why, and the stat whose threshold made it unhealthy,
+	// if any. resultStats may be nil, for pollers which don't poll stats, and
+	// history is what's remembered of the server's polls by the poller that
+	// produced result, including result itself.
+	Eval(result cache.ResultInfo, resultStats *threadsafe.ResultStatValHistory, serverInfo tc.TrafficServer, profile tc.TMProfile, history *PollHistory) (bool, string, string)
+}
+
+var algorithms = map[string]Algorithm{
+	AlgorithmThreshold: thresholdAlgorithm{},
+	AlgorithmEWMA:      ewmaAlgorithm{},
+	AlgorithmErrorRate: errorRateAlgorithm{},
+	AlgorithmComposite: compositeAlgorithm{},
+}
+
+// IsAlgorithm returns whether name is the name of a health algorithm. The
+// empty string is, because it selects the DefaultAlgorithm.
+func IsAlgorithm(name string) bool {
+	if name == "" {
+		return true
+	}
+	_, ok := algorithms[name]
+	return ok
+}
+
+// GetAlgorithm returns the health algorithm with the given name. The
+// DefaultAlgorithm is returned if there's no such algorithm, so callers which
+// want to report invalid names must check them with IsAlgorithm.
+func GetAlgorithm(name string) Algorithm {
+	if alg, ok := algorithms[name]; ok {
+		return alg
+	}
+	return algorithms[DefaultAlgorithm]
+}
+
+// PollHistory is what's remembered of one poller's polls of a cache server,
+// for the health algorithms which consider more than the latest result.
+type PollHistory struct {
+	// ResponseTimeEWMA is the exponentially weighted moving average of the
+	// request times of the server's successful polls.
+	ResponseTimeEWMA time.Duration
+	// Succeeded is whether any poll of the server has succeeded, i.e. whether
+	// ResponseTimeEWMA is meaningful.
+	Succeeded bool
+	// errors holds whether each of the most recent polls failed, oldest
+	// first.
+	errors []bool
+}
+
+// PollHistories are the PollHistory of each cache server polled by one
+// poller, by the server's name. It isn't safe for concurrent use, and must
+// only be used by the goroutine processing the poller's results.
+type PollHistories map[string]*PollHistory
+
+// NewPollHistories returns a new, empty PollHistories.
+func NewPollHistories() PollHistories {
+	return PollHistories{}
+}
+
+// Get returns the PollHistory of the named cache server, creating it if it
+// doesn't exist yet.
+func (hs PollHistories) Get(name string) *PollHistory {
+	h, ok := hs[name]
+	if !ok {
+		h = &PollHistory{}
+		hs[name] = h
+	}
+	return h
+}
+
+// Add records result in the history, with the ewma.alpha and errorRate.window
+// settings of the given Profile.
+func (h *PollHistory) Add(result cache.ResultInfo, profile tc.TMProfile) {
+	if result.Error == nil {
+		if !h.Succeeded {
+			h.ResponseTimeEWMA = result.RequestTime
+			h.Succeeded = true
+		} else {
+			alpha := math.Min(algorithmParam(profile, ParamEWMAAlpha, DefaultEWMAAlpha), 1)
+			h.ResponseTimeEWMA = time.Duration(alpha*float64(result.RequestTime) + (1-alpha)*float64(h.ResponseTimeEWMA))
+		}
+	}
+
+	window := int(algorithmParam(profile, ParamErrorRateWindow, DefaultErrorRateWindow))
+	if window < 1 {
+		window = 1
+	}
+	h.errors = append(h.errors, result.Error != nil)
+	if len(h.errors) > window {
+		h.errors = h.errors[len(h.errors)-window:]
+	}
+}
+
+// ErrorRate returns the fraction of the polls in the history which failed.
+func (h *PollHistory) ErrorRate() float64 {
+	if len(h.errors) == 0 {
+		return 0
+	}
+	failed := 0
+	for _, e := range h.errors {
+		if e {
+			failed++
+		}
+	}
+	return float64(failed) / float64(len(h.errors))
+}
+
+// algorithmParam returns the named setting of the Profile's health algorithm,
+// or def if it isn't set or isn't positive.
+func algorithmParam(profile tc.TMProfile, name string, def float64) float64 {
+	if v, ok := profile.Parameters.HealthAlgorithmParameters[name]; ok && v > 0 {
+		return v
+	}
+	return def
+}
+
+// limitScore scores val against a limit it mustn't exceed, from 1 for zero,
+// through 0.5 at the limit, to 0 at twice the limit or more.
+func limitScore(val, limit float64) float64 {
+	return math.Max(0, math.Min(1, 1-val/(2*limit)))
+}
+
+// thresholdAlgorithm is the DefaultAlgorithm: a server is unhealthy if it
+// couldn't be polled, reports itself unavailable, or any of its stats exceeds
+// its Profile's threshold.
+type thresholdAlgorithm struct{}
+
+func (thresholdAlgorithm) Eval(result cache.ResultInfo, resultStats *threadsafe.ResultStatValHistory, serverInfo tc.TrafficServer, profile tc.TMProfile, _ *PollHistory) (bool, string, string) {
+	status := tc.CacheStatusFromString(serverInfo.ServerStatus)
+	avail, eventDescVal, eventMsg := EvalCacheWithStatusInfo(result, nil, status, serverInfo.ServerStatus)
+	if !avail {
+		return avail, eventDescVal, eventMsg
+	}
+
+	computedStats := cache.ComputedStats()
+
+	for stat, threshold := range profile.Parameters.Thresholds {
+		resultStat := interface{}(nil)
+		computedStatF, ok := computedStats[stat]
+		if !ok {
+			if resultStats == nil {
+				continue
+			}
+			resultStatHistory := resultStats.Load(stat)
+			if len(resultStatHistory) == 0 {
+				continue
+			}
+			resultStat = resultStatHistory[0].Val
+		} else {
+			resultStat = computedStatF(result, serverInfo, profile, dummyCombinedState)
+		}
+
+		resultStatNum, ok := util.ToNumeric(resultStat)
+		if !ok {
+			log.Errorf("health.EvalCache threshold stat %s was not a number: %v", stat, resultStat)
+			continue
+		}
+
+		if !inThreshold(threshold, resultStatNum) {
+			return false, eventDesc(status, exceedsThresholdMsg(stat, threshold, resultStatNum)), stat
+		}
+	}
+
+	return avail, eventDescVal, eventMsg
+}
+
+// ewmaAlgorithm marks a server unhealthy if it couldn't be polled, reports
+// itself unavailable, or the moving average of its response times exceeds the
+// ewma.maxResponseTimeMs setting, so that a single slow response doesn't
+// make it unavailable, but a sustained slowdown does.
+type ewmaAlgorithm struct{}
+
+func (ewmaAlgorithm) Eval(result cache.ResultInfo, _ *threadsafe.ResultStatValHistory, serverInfo tc.TrafficServer, profile tc.TMProfile, history *PollHistory) (bool, string, string) {
+	status := tc.CacheStatusFromString(serverInfo.ServerStatus)
+	avail, eventDescVal, eventMsg := EvalCacheWithStatusInfo(result, nil, status, serverInfo.ServerStatus)
+	if !avail || history == nil || !history.Succeeded {
+		return avail, eventDescVal, eventMsg
+	}
+	ewmaMS := float64(history.ResponseTimeEWMA) / float64(time.Millisecond)
+	maxMS := algorithmParam(profile, ParamEWMAMaxResponseTimeMS, DefaultEWMAMaxResponseTimeMS)
+	if ewmaMS > maxMS {
+		return false, eventDesc(status, fmt.Sprintf("response time average too high (%.2fms > %.2fms)", ewmaMS, maxMS)), ""
+	}
+	return avail, eventDescVal, eventMsg
+}
+
+// errorRateAlgorithm marks a server unhealthy if it reports itself
+// unavailable, or more than the errorRate.max fraction of its last
+// errorRate.window polls failed, so that occasional failed polls don't make
+// it unavailable.
+type errorRateAlgorithm struct{}
+
+func (errorRateAlgorithm) Eval(result cache.ResultInfo, _ *threadsafe.ResultStatValHistory, serverInfo tc.TrafficServer, profile tc.TMProfile, history *PollHistory) (bool, string, string) {
+	status := tc.CacheStatusFromString(serverInfo.ServerStatus)
+	if history == nil || (result.Error == nil && result.Statistics.NotAvailable) {
+		return EvalCacheWithStatusInfo(result, nil, status, serverInfo.ServerStatus)
+	}
+	rate := history.ErrorRate()
+	maxRate := algorithmParam(profile, ParamErrorRateMax, DefaultErrorRateMax)
+	if rate > maxRate {
+		return false, eventDesc(status, fmt.Sprintf("error rate too high (%.2f > %.2f)", rate, maxRate)), ""
+	}
+	return true, eventDesc(status, AvailableStr), ""
+}
+
+// compositeAlgorithm marks a server unhealthy if it reports itself
+// unavailable, or the weighted average of its scores from the other
+// algorithms is less than the composite.minScore setting. Each score is 1 for
+// a perfect server, and 0.5 for one at the limit of the algorithm it's from;
+// the threshold algorithm's score is 1 or 0. The weight of each algorithm is
+// its composite.weight.<name> setting, which defaults to 1; a weight of 0
+// leaves the algorithm out.
+type compositeAlgorithm struct{}
+
+func (compositeAlgorithm) Eval(result cache.ResultInfo, resultStats *threadsafe.ResultStatValHistory, serverInfo tc.TrafficServer, profile tc.TMProfile, history *PollHistory) (bool, string, string) {
+	status := tc.CacheStatusFromString(serverInfo.ServerStatus)
+	if result.Error == nil && result.Statistics.NotAvailable {
+		return EvalCacheWithStatusInfo(result, nil, status, serverInfo.ServerStatus)
+	}
+
+	scores := map[string]float64{}
+	thresholdAvail, thresholdDesc, thresholdStat := thresholdAlgorithm{}.Eval(result, resultStats, serverInfo, profile, history)
+	if thresholdAvail {
+		scores[AlgorithmThreshold] = 1
+	} else {
+		scores[AlgorithmThreshold] = 0
+	}
+	if history != nil {
+		if history.Succeeded {
+			ewmaMS := float64(history.ResponseTimeEWMA) / float64(time.Millisecond)
+			scores[AlgorithmEWMA] = limitScore(ewmaMS, algorithmParam(profile, ParamEWMAMaxResponseTimeMS, DefaultEWMAMaxResponseTimeMS))
+		}
+		scores[AlgorithmErrorRate] = limitScore(history.ErrorRate(), algorithmParam(profile, ParamErrorRateMax, DefaultErrorRateMax))
+	}
+
+	weighted := 0.0
+	totalWeight := 0.0
+	for name, score := range scores {
+		weight, ok := profile.Parameters.HealthAlgorithmParameters[ParamCompositeWeightPrefix+name]
+		if !ok {
+			weight = 1
+		}
+		if weight <= 0 {
+			continue
+		}
+		weighted += weight * score
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		return true, eventDesc(status, AvailableStr), ""
+	}
+
+	score := weighted / totalWeight
+	minScore := algorithmParam(profile, ParamCompositeMinScore, DefaultCompositeMinScore)
+	if score < minScore {
+		msg := fmt.Sprintf("health score too low (%.2f < %.2f)", score, minScore)
+		if !thresholdAvail {
+			// The threshold algorithm's description includes the status.
+			return false, thresholdDesc + "; " + msg, thresholdStat
+		}
+		return false, eventDesc(status, msg), ""
+	}
+	return true, eventDesc(status, AvailableStr), ""
+}
@@ -0,0 +1,207 @@
+package health
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/cache"
+)
+
+const algorithmTestCache = "algorithm-test-cache"
+
+func algorithmTestConfig(algorithm string, params map[string]float64) *tc.TrafficMonitorConfigMap {
+	return &tc.TrafficMonitorConfigMap{
+		TrafficServer: map[string]tc.TrafficServer{
+			algorithmTestCache: {
+				ServerStatus: string(tc.CacheStatusReported),
+				Profile:      "algorithm-test-profile",
+			},
+		},
+		Profile: map[string]tc.TMProfile{
+			"algorithm-test-profile": {
+				Parameters: tc.TMParameters{
+					HealthAlgorithm:           algorithm,
+					HealthAlgorithmParameters: params,
+				},
+			},
+		},
+	}
+}
+
+func algorithmTestResult(requestTime time.Duration, err error) cache.ResultInfo {
+	return cache.ResultInfo{
+		Available:   err == nil,
+		Error:       err,
+		ID:          algorithmTestCache,
+		RequestTime: requestTime,
+	}
+}
+
+func TestPollHistory(t *testing.T) {
+	profile := tc.TMProfile{Parameters: tc.TMParameters{HealthAlgorithmParameters: map[string]float64{
+		ParamEWMAAlpha:       0.5,
+		ParamErrorRateWindow: 4,
+	}}}
+
+	h := PollHistory{}
+	h.Add(algorithmTestResult(0, errors.New("timeout")), profile)
+	if h.Succeeded {
+		t.Error("expected history with only a failed poll not to have succeeded")
+	}
+	h.Add(algorithmTestResult(100*time.Millisecond, nil), profile)
+	if h.ResponseTimeEWMA != 100*time.Millisecond {
+		t.Errorf("expected the first successful poll to start the average, actual: %v", h.ResponseTimeEWMA)
+	}
+	h.Add(algorithmTestResult(200*time.Millisecond, nil), profile)
+	if h.ResponseTimeEWMA != 150*time.Millisecond {
+		t.Errorf("expected average 150ms, actual: %v", h.ResponseTimeEWMA)
+	}
+	h.Add(algorithmTestResult(0, errors.New("timeout")), profile)
+	if h.ResponseTimeEWMA != 150*time.Millisecond {
+		t.Errorf("expected failed polls not to change the average, actual: %v", h.ResponseTimeEWMA)
+	}
+	if rate := h.ErrorRate(); rate != 0.5 {
+		t.Errorf("expected error rate 0.5, actual: %v", rate)
+	}
+	h.Add(algorithmTestResult(100*time.Millisecond, nil), profile)
+	if rate := h.ErrorRate(); rate != 0.25 {
+		t.Errorf("expected the oldest poll to leave the window of 4, giving error rate 0.25, actual: %v", rate)
+	}
+}
+
+func TestEvalAggregateEWMA(t *testing.T) {
+	mc := algorithmTestConfig(AlgorithmEWMA, map[string]float64{
+		ParamEWMAAlpha:             0.5,
+		ParamEWMAMaxResponseTimeMS: 100,
+	})
+	h := &PollHistory{}
+
+	if avail, why, _ := EvalAggregate(algorithmTestResult(50*time.Millisecond, nil), nil, mc, h); !avail {
+		t.Errorf("expected fast server to be available, actual: %s", why)
+	}
+	// The average is 125ms, but one slow poll mustn't be enough.
+	if avail, why, _ := EvalAggregate(algorithmTestResult(200*time.Millisecond, nil), nil, mc, h); avail {
+		t.Errorf("expected average of 125ms to exceed maximum of 100ms, actual: available: %s", why)
+	}
+	h = &PollHistory{}
+	EvalAggregate(algorithmTestResult(50*time.Millisecond, nil), nil, mc, h)
+	EvalAggregate(algorithmTestResult(50*time.Millisecond, nil), nil, mc, h)
+	if avail, why, _ := EvalAggregate(algorithmTestResult(140*time.Millisecond, nil), nil, mc, h); !avail {
+		t.Errorf("expected average of 95ms after a single slow poll to be available, actual: %s", why)
+	}
+	if avail, _, _ := EvalAggregate(algorithmTestResult(0, errors.New("timeout")), nil, mc, h); avail {
+		t.Error("expected failed poll to make the server unavailable")
+	}
+}
+
+func TestEvalAggregateErrorRate(t *testing.T) {
+	mc := algorithmTestConfig(AlgorithmErrorRate, map[string]float64{
+		ParamErrorRateWindow: 4,
+		ParamErrorRateMax:    0.25,
+	})
+	h := &PollHistory{}
+
+	for i := 0; i < 3; i++ {
+		EvalAggregate(algorithmTestResult(time.Millisecond, nil), nil, mc, h)
+	}
+	if avail, why, _ := EvalAggregate(algorithmTestResult(0, errors.New("timeout")), nil, mc, h); !avail {
+		t.Errorf("expected one failed poll in four to be tolerated, actual: %s", why)
+	}
+	avail, why, _ := EvalAggregate(algorithmTestResult(0, errors.New("timeout")), nil, mc, h)
+	if avail {
+		t.Error("expected two failed polls in four to make the server unavailable")
+	} else if !strings.Contains(why, "error rate") {
+		t.Errorf("expected reason to mention the error rate, actual: %s", why)
+	}
+
+	notAvail := algorithmTestResult(time.Millisecond, nil)
+	notAvail.Statistics.NotAvailable = true
+	if avail, _, _ := EvalAggregate(notAvail, nil, mc, &PollHistory{}); avail {
+		t.Error("expected a server reporting itself unavailable to be unavailable")
+	}
+}
+
+func TestEvalAggregateComposite(t *testing.T) {
+	mc := algorithmTestConfig(AlgorithmComposite, map[string]float64{
+		ParamEWMAMaxResponseTimeMS:                      100,
+		ParamErrorRateWindow:                            4,
+		ParamErrorRateMax:                               0.5,
+		ParamCompositeWeightPrefix + AlgorithmThreshold: 1,
+		ParamCompositeWeightPrefix + AlgorithmEWMA:      2,
+		ParamCompositeWeightPrefix + AlgorithmErrorRate: 1,
+	})
+	h := &PollHistory{}
+
+	// Scores: threshold 1, EWMA 0.5, error rate 1: 3/4.
+	if avail, why, _ := EvalAggregate(algorithmTestResult(100*time.Millisecond, nil), nil, mc, h); !avail {
+		t.Errorf("expected score of 0.75 to be available, actual: %s", why)
+	}
+	// Scores: threshold 0, EWMA 0.5, error rate 0.5: 1.5/4.
+	avail, why, _ := EvalAggregate(algorithmTestResult(0, errors.New("timeout")), nil, mc, h)
+	if avail {
+		t.Error("expected score of 0.375 to be unavailable")
+	} else if !strings.Contains(why, "health score too low") || !strings.Contains(why, "timeout") {
+		t.Errorf("expected reason to give the score and the poll error, actual: %s", why)
+	}
+
+	mc.Profile["algorithm-test-profile"].Parameters.HealthAlgorithmParameters[ParamCompositeWeightPrefix+AlgorithmThreshold] = 0
+	h = &PollHistory{}
+	EvalAggregate(algorithmTestResult(100*time.Millisecond, nil), nil, mc, h)
+	// Scores: EWMA 0.5, error rate 0.5: 1.5/3.
+	if avail, why, _ := EvalAggregate(algorithmTestResult(0, errors.New("timeout")), nil, mc, h); !avail {
+		t.Errorf("expected score of 0.5 without the threshold algorithm to be available, actual: %s", why)
+	}
+}
+
+func TestEvalAggregateAlgorithmStatus(t *testing.T) {
+	for _, alg := range []string{AlgorithmThreshold, AlgorithmEWMA, AlgorithmErrorRate, AlgorithmComposite} {
+		mc := algorithmTestConfig(alg, nil)
+
+		srv := mc.TrafficServer[algorithmTestCache]
+		srv.ServerStatus = string(tc.CacheStatusAdminDown)
+		mc.TrafficServer[algorithmTestCache] = srv
+		if avail, _, _ := EvalAggregate(algorithmTestResult(time.Millisecond, nil), nil, mc, &PollHistory{}); avail {
+			t.Errorf("algorithm %s: expected ADMIN_DOWN server to be unavailable", alg)
+		}
+
+		srv.ServerStatus = string(tc.CacheStatusOnline)
+		mc.TrafficServer[algorithmTestCache] = srv
+		if avail, _, _ := EvalAggregate(algorithmTestResult(0, errors.New("timeout")), nil, mc, &PollHistory{}); !avail {
+			t.Errorf("algorithm %s: expected ONLINE server to be available", alg)
+		}
+	}
+}
+
+func TestGetAlgorithm(t *testing.T) {
+	if !IsAlgorithm("") || !IsAlgorithm(AlgorithmEWMA) {
+		t.Error("expected empty and known algorithm names to be valid")
+	}
+	if IsAlgorithm("bogus") {
+		t.Error("expected unknown algorithm name to be invalid")
+	}
+	if _, ok := GetAlgorithm("bogus").(thresholdAlgorithm); !ok {
+		t.Errorf("expected unknown algorithm to fall back to %s", DefaultAlgorithm)
+	}
+}
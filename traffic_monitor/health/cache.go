@@ -26,7 +26,6 @@ import (
 
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-tc"
-	"github.com/apache/trafficcontrol/lib/go-util"
 	"github.com/apache/trafficcontrol/traffic_monitor/cache"
 	"github.com/apache/trafficcontrol/traffic_monitor/config"
 	"github.com/apache/trafficcontrol/traffic_monitor/peer"
@@ -100,6 +99,19 @@ func GetVitals(newResult *cache.Result, prevResult *cache.Result, mc *tc.Traffic
 			BytesIn:    iface.BytesIn,
 			BytesOut:   iface.BytesOut,
 			MaxKbpsOut: iface.Speed * 1000,
+			Errors:     iface.Errors,
+		}
+
+		// Errors on a link with a failing optic keep growing from poll to
+		// poll, while one-off errors don't, so count consecutive polls with
+		// new errors.
+		if prevResult != nil && prevResult.InterfaceVitals != nil {
+			if prevVitals, ok := prevResult.InterfaceVitals[ifaceName]; ok && ifaceVitals.Errors > prevVitals.Errors {
+				ifaceVitals.ErrorPolls = prevVitals.ErrorPolls + 1
+			}
+		}
+		if ifaceVitals.ErrorPolls > newResult.Vitals.ErrorPolls {
+			newResult.Vitals.ErrorPolls = ifaceVitals.ErrorPolls
 		}
 
 		if prevResult != nil && prevResult.InterfaceVitals != nil && prevResult.InterfaceVitals[ifaceName].BytesOut != 0 {
@@ -111,6 +123,7 @@ func GetVitals(newResult *cache.Result, prevResult *cache.Result, mc *tc.Traffic
 		// Overflow possible
 		newResult.Vitals.BytesOut += iface.BytesOut
 		newResult.Vitals.BytesIn += iface.BytesIn
+		newResult.Vitals.Errors += iface.Errors
 		// TODO JvD: Should we really be running this code every second for every cache polled????? I don't think so.
 		newResult.Vitals.MaxKbpsOut += iface.Speed * 1000
 	}
@@ -182,13 +195,21 @@ func EvalInterface(infVitals map[string]cache.Vitals, inf tc.ServerInterfaceInfo
 }
 
 // EvalAggregate calculates the availability of a cache server as an aggregate
-// of server metrics and metrics of its network interfaces.
-func EvalAggregate(result cache.ResultInfo, resultStats *threadsafe.ResultStatValHistory, mc *tc.TrafficMonitorConfigMap) (bool, string, string) {
+// of server metrics and metrics of its network interfaces, with the health
+// algorithm selected by its Profile. history is what's remembered of the
+// server's previous polls by the poller that produced result, to which result
+// is added; it may be nil, in which case algorithms that use it judge the
+// server by result alone.
+func EvalAggregate(result cache.ResultInfo, resultStats *threadsafe.ResultStatValHistory, mc *tc.TrafficMonitorConfigMap, history *PollHistory) (bool, string, string) {
 	serverInfo, ok := mc.TrafficServer[string(result.ID)]
 	if !ok {
 		log.Errorf("Cache %v missing from from Traffic Ops Monitor Config - treating as OFFLINE\n", result.ID)
 		return false, "ERROR - server missing in Traffic Ops monitor config", ""
 	}
+	if history != nil {
+		// Added regardless of status, so it's up to date if the status changes.
+		history.Add(result, mc.Profile[serverInfo.Profile])
+	}
 	status := tc.CacheStatusFromString(serverInfo.ServerStatus)
 	if status == tc.CacheStatusOnline {
 		// return here first, even though EvalCacheWithStatus checks online, because we later assume that if EvalCacheWithStatus returns true, to return false if thresholds are exceeded; but, if the cache is ONLINE, we don't want to check thresholds.
@@ -201,41 +222,12 @@ func EvalAggregate(result cache.ResultInfo, resultStats *threadsafe.ResultStatVa
 		return false, "ERROR - server profile missing in Traffic Ops monitor config", ""
 	}
 
-	avail, eventDescVal, eventMsg := EvalCacheWithStatusInfo(result, mc, status, serverInfo.ServerStatus)
-	if !avail {
-		return avail, eventDescVal, eventMsg
+	switch status {
+	case tc.CacheStatusInvalid, tc.CacheStatusAdminDown, tc.CacheStatusOffline:
+		return EvalCacheWithStatusInfo(result, mc, status, serverInfo.ServerStatus)
 	}
 
-	computedStats := cache.ComputedStats()
-
-	for stat, threshold := range profile.Parameters.Thresholds {
-		resultStat := interface{}(nil)
-		computedStatF, ok := computedStats[stat]
-		if !ok {
-			if resultStats == nil {
-				continue
-			}
-			resultStatHistory := resultStats.Load(stat)
-			if len(resultStatHistory) == 0 {
-				continue
-			}
-			resultStat = resultStatHistory[0].Val
-		} else {
-			resultStat = computedStatF(result, serverInfo, profile, dummyCombinedState)
-		}
-
-		resultStatNum, ok := util.ToNumeric(resultStat)
-		if !ok {
-			log.Errorf("health.EvalCache threshold stat %s was not a number: %v", stat, resultStat)
-			continue
-		}
-
-		if !inThreshold(threshold, resultStatNum) {
-			return false, eventDesc(status, exceedsThresholdMsg(stat, threshold, resultStatNum)), stat
-		}
-	}
-
-	return avail, eventDescVal, eventMsg
+	return GetAlgorithm(profile.Parameters.HealthAlgorithm).Eval(result, resultStats, serverInfo, profile, history)
 }
 
 // getProcessAvailableTuple gets a function to process an availability tuple
@@ -267,11 +259,13 @@ func getProcessAvailableTuple(protocol config.PollingProtocol) func(cache.Availa
 
 // CalcAvailability calculates the availability of each cache in results.
 // statResultHistory may be nil, in which case stats won't be used to calculate
-// availability.
+// availability. pollHistories must be the poller's own, and may be nil, in
+// which case no history is kept.
 func CalcAvailability(
 	results []cache.Result,
 	pollerName string,
 	statResultHistory *threadsafe.ResultStatHistory,
+	pollHistories PollHistories,
 	mc tc.TrafficMonitorConfigMap,
 	toData todata.TOData,
 	localCacheStatusThreadsafe threadsafe.CacheAvailableStatus,
@@ -334,10 +328,15 @@ func CalcAvailability(
 		var aggWhyAvailable string
 		var aggUnavailableStat string
 
+		var pollHistory *PollHistory
+		if pollHistories != nil {
+			pollHistory = pollHistories.Get(result.ID)
+		}
+
 		if statResultsVal != nil {
-			aggIsAvailable, aggWhyAvailable, aggUnavailableStat = EvalAggregate(cache.ToInfo(result), &statResultsVal.Stats, &mc)
+			aggIsAvailable, aggWhyAvailable, aggUnavailableStat = EvalAggregate(cache.ToInfo(result), &statResultsVal.Stats, &mc, pollHistory)
 		} else {
-			aggIsAvailable, aggWhyAvailable, aggUnavailableStat = EvalAggregate(cache.ToInfo(result), nil, &mc)
+			aggIsAvailable, aggWhyAvailable, aggUnavailableStat = EvalAggregate(cache.ToInfo(result), nil, &mc, pollHistory)
 		}
 
 		if result.UsingIPv4 {
@@ -312,6 +312,75 @@ func TestDualHomingMonitoredInterfacesGetVitals(t *testing.T) {
 	}
 }
 
+// TestLinkErrorPollsGetVitals ensures that cache servers count the
+// consecutive polls in which errors grew on any monitored interface.
+func TestLinkErrorPollsGetVitals(t *testing.T) {
+	serverID := "flapping"
+	fakeRequestTime := time.Now()
+
+	tmcm := tc.TrafficMonitorConfigMap{
+		TrafficServer: map[string]tc.TrafficServer{
+			serverID: {
+				Interfaces: []tc.ServerInterfaceInfo{
+					{
+						Name:    "bond0",
+						Monitor: true,
+					},
+					{
+						Name:    "bond1",
+						Monitor: true,
+					},
+					{
+						Name:    "lo",
+						Monitor: false,
+					},
+				},
+			},
+		},
+	}
+
+	polls := []struct {
+		bond0Errors        uint64
+		bond1Errors        uint64
+		loErrors           uint64
+		expectedErrors     uint64
+		expectedErrorPolls uint64
+	}{
+		{bond0Errors: 5, bond1Errors: 0, loErrors: 0, expectedErrors: 5, expectedErrorPolls: 0},
+		{bond0Errors: 7, bond1Errors: 0, loErrors: 0, expectedErrors: 7, expectedErrorPolls: 1},
+		{bond0Errors: 9, bond1Errors: 1, loErrors: 0, expectedErrors: 10, expectedErrorPolls: 2},
+		{bond0Errors: 9, bond1Errors: 2, loErrors: 0, expectedErrors: 11, expectedErrorPolls: 2},
+		{bond0Errors: 9, bond1Errors: 2, loErrors: 100, expectedErrors: 11, expectedErrorPolls: 0},
+	}
+
+	var prevResult *cache.Result
+	for i, poll := range polls {
+		result := cache.Result{
+			ID:            serverID,
+			Miscellaneous: map[string]interface{}{},
+			Statistics: cache.Statistics{
+				Interfaces: map[string]cache.Interface{
+					"bond0": {Speed: 100000, BytesOut: uint64(i + 1), Errors: poll.bond0Errors},
+					"bond1": {Speed: 100000, BytesOut: uint64(i + 1), Errors: poll.bond1Errors},
+					"lo":    {Speed: 0, BytesOut: uint64(i + 1), Errors: poll.loErrors},
+				},
+			},
+			Time:         fakeRequestTime.Add(time.Duration(i) * time.Second),
+			PollFinished: make(chan uint64, 1),
+			Available:    true,
+		}
+		GetVitals(&result, prevResult, &tmcm)
+
+		if result.Vitals.Errors != poll.expectedErrors {
+			t.Errorf("poll %d: expected %d errors, actual: %d", i, poll.expectedErrors, result.Vitals.Errors)
+		}
+		if result.Vitals.ErrorPolls != poll.expectedErrorPolls {
+			t.Errorf("poll %d: expected %d consecutive polls with errors, actual: %d", i, poll.expectedErrorPolls, result.Vitals.ErrorPolls)
+		}
+		prevResult = &result
+	}
+}
+
 func TestCalcAvailabilityThresholds(t *testing.T) {
 
 	resultID := "myCacheName"
@@ -428,10 +497,10 @@ func TestCalcAvailabilityThresholds(t *testing.T) {
 	original := results[0].Statistics.Interfaces
 	statResultHistory := (*threadsafe.ResultStatHistory)(nil)
 	results[0].Statistics.Interfaces = make(map[string]cache.Interface)
-	CalcAvailability(results, pollerName, statResultHistory, mc, toData, localCacheStatusThreadsafe, localStates, events, config.Both)
+	CalcAvailability(results, pollerName, statResultHistory, NewPollHistories(), mc, toData, localCacheStatusThreadsafe, localStates, events, config.Both)
 	results[0].Statistics.Interfaces = original
 
-	CalcAvailability(results, pollerName, statResultHistory, mc, toData, localCacheStatusThreadsafe, localStates, events, config.Both)
+	CalcAvailability(results, pollerName, statResultHistory, NewPollHistories(), mc, toData, localCacheStatusThreadsafe, localStates, events, config.Both)
 
 	// ensure that the DisabledLocations is an empty, non-nil slice
 	for _, ds := range localStates.GetDeliveryServices() {
@@ -478,7 +547,7 @@ func TestCalcAvailabilityThresholds(t *testing.T) {
 	GetVitals(&healthResult, &result, nil)
 	healthPollerName := "health"
 	healthResults := []cache.Result{healthResult}
-	CalcAvailability(healthResults, healthPollerName, nil, mc, toData, localCacheStatusThreadsafe, localStates, events, config.Both)
+	CalcAvailability(healthResults, healthPollerName, nil, NewPollHistories(), mc, toData, localCacheStatusThreadsafe, localStates, events, config.Both)
 
 	localCacheStatuses = localCacheStatusThreadsafe.Get()
 	if _, ok := localCacheStatuses[result.ID]; !ok {
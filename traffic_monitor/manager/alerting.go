@@ -0,0 +1,123 @@
+package manager
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"time"
+
+	"github.com/apache/trafficcontrol/traffic_monitor/alerting"
+	"github.com/apache/trafficcontrol/traffic_monitor/config"
+	"github.com/apache/trafficcontrol/traffic_monitor/ds"
+	"github.com/apache/trafficcontrol/traffic_monitor/dsdata"
+	"github.com/apache/trafficcontrol/traffic_monitor/handler"
+	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
+	"github.com/apache/trafficcontrol/traffic_monitor/todata"
+	"github.com/apache/trafficcontrol/traffic_monitor/towrap"
+)
+
+// StartAlertManager loads the alert rules file from the given config, and
+// starts the goroutine which evaluates the rules against the latest Delivery
+// Service and cache stats, sending notifications as alerts fire and resolve.
+//
+// Alerts are annotated with the active Traffic Ops annotations of their
+// targets, fetched using the sessions and ops configs received on the given
+// channels.
+//
+// If no alert rules file is configured, the returned Engine has no rules, no
+// evaluation goroutine is started, and annotations aren't fetched.
+func StartAlertManager(
+	cfg config.Config,
+	appData config.StaticAppData,
+	dsStats threadsafe.DSStatsReader,
+	lastStats threadsafe.LastStats,
+	toData todata.TODataThreadsafe,
+	opsConfigChan <-chan handler.OpsConfig,
+	sessionChan <-chan towrap.TrafficOpsSessionThreadsafe,
+) (*alerting.Engine, error) {
+	if cfg.AlertRulesFile == "" {
+		engine := alerting.NewEngine(alerting.Rules{EvaluationInterval: alerting.DefaultEvaluationInterval}, appData.Hostname)
+		startAnnotationPoller(engine, false, toData, opsConfigChan, sessionChan)
+		return engine, nil
+	}
+	rules, err := alerting.LoadRules(cfg.AlertRulesFile)
+	if err != nil {
+		return nil, errors.New("loading alert rules file '" + cfg.AlertRulesFile + "': " + err.Error())
+	}
+	engine := alerting.NewEngine(rules, appData.Hostname)
+	startAnnotationPoller(engine, true, toData, opsConfigChan, sessionChan)
+	go func() {
+		ticker := time.NewTicker(engine.Interval())
+		defer ticker.Stop()
+		for now := range ticker.C {
+			events := engine.Evaluate(now, dsAlertSamples(dsStats.Get()), cacheAlertSamples(lastStats.Get()))
+			for _, event := range events {
+				go engine.Notify(event)
+			}
+		}
+	}()
+	return engine, nil
+}
+
+// dsAlertSamples returns the alert samples of each Delivery Service, from its
+// total stats.
+func dsAlertSamples(stats dsdata.StatsReadonly) map[string]alerting.Sample {
+	names := stats.DeliveryServiceNames()
+	samples := make(map[string]alerting.Sample, len(names))
+	for _, name := range names {
+		stat, ok := stats.Get(name)
+		if !ok {
+			continue
+		}
+		total := stat.Total()
+		samples[string(name)] = alerting.Sample{
+			Kbps:   total.Kbps.Value,
+			Tps2xx: total.Tps2xx.Value,
+			Tps3xx: total.Tps3xx.Value,
+			Tps4xx: total.Tps4xx.Value,
+			Tps5xx: total.Tps5xx.Value,
+		}
+	}
+	return samples
+}
+
+// cacheAlertSamples returns the alert samples of each cache server. Cache
+// servers only track their total bytes, so their transactions per second are
+// summed from each Delivery Service they serve.
+func cacheAlertSamples(lastStats dsdata.LastStats) map[string]alerting.Sample {
+	samples := make(map[string]alerting.Sample, len(lastStats.Caches))
+	for name, stat := range lastStats.Caches {
+		samples[string(name)] = alerting.Sample{Kbps: stat.Bytes.PerSec / ds.BytesPerKilobit}
+	}
+	for _, dsStat := range lastStats.DeliveryServices {
+		for name, stat := range dsStat.Caches {
+			sample, ok := samples[string(name)]
+			if !ok {
+				continue
+			}
+			sample.Tps2xx += stat.Status2xx.PerSec
+			sample.Tps3xx += stat.Status3xx.PerSec
+			sample.Tps4xx += stat.Status4xx.PerSec
+			sample.Tps5xx += stat.Status5xx.PerSec
+			samples[string(name)] = sample
+		}
+	}
+	return samples
+}
@@ -0,0 +1,139 @@
+package manager
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/alerting"
+	"github.com/apache/trafficcontrol/traffic_monitor/handler"
+	"github.com/apache/trafficcontrol/traffic_monitor/todata"
+	"github.com/apache/trafficcontrol/traffic_monitor/towrap"
+)
+
+// annotationPollingInterval is how often the active annotations are fetched
+// from Traffic Ops, to annotate alerts.
+const annotationPollingInterval = time.Minute
+
+// annotationsThreadsafe holds the latest active annotations, and the CDN of
+// this Traffic Monitor. It is safe for multiple goroutines.
+type annotationsThreadsafe struct {
+	cdn         string
+	annotations []tc.Annotation
+	m           *sync.RWMutex
+}
+
+func (a *annotationsThreadsafe) set(cdn string, annotations []tc.Annotation) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	a.cdn = cdn
+	a.annotations = annotations
+}
+
+// annotator returns an alerting.Annotator which annotates an alert with the
+// annotations of this Traffic Monitor's CDN, and of the alert's Delivery
+// Service or the Cache Group of its cache server.
+func (a *annotationsThreadsafe) annotator(toData todata.TODataThreadsafe) alerting.Annotator {
+	return func(scope alerting.Scope, target string, now time.Time) []tc.Annotation {
+		cachegroup := ""
+		if scope == alerting.ScopeCache {
+			cachegroup = string(toData.Get().ServerCachegroups[tc.CacheName(target)])
+		}
+
+		a.m.RLock()
+		defer a.m.RUnlock()
+		matched := []tc.Annotation(nil)
+		for _, annotation := range a.annotations {
+			if !annotation.ActiveAt(now) {
+				continue
+			}
+			switch {
+			case annotation.CDN != nil:
+				if *annotation.CDN != a.cdn {
+					continue
+				}
+			case annotation.Cachegroup != nil:
+				if cachegroup == "" || *annotation.Cachegroup != cachegroup {
+					continue
+				}
+			case annotation.DeliveryService != nil:
+				if scope != alerting.ScopeDeliveryService || *annotation.DeliveryService != target {
+					continue
+				}
+			default:
+				continue
+			}
+			matched = append(matched, annotation)
+		}
+		return matched
+	}
+}
+
+// startAnnotationPoller starts the goroutine which periodically fetches the
+// active annotations from Traffic Ops, and sets the given Engine to annotate
+// its alerts with them. If poll is false, the ops config and session
+// channels are drained, but annotations are never fetched.
+func startAnnotationPoller(
+	engine *alerting.Engine,
+	poll bool,
+	toData todata.TODataThreadsafe,
+	opsConfigChan <-chan handler.OpsConfig,
+	sessionChan <-chan towrap.TrafficOpsSessionThreadsafe,
+) {
+	annotations := &annotationsThreadsafe{m: &sync.RWMutex{}}
+	if poll {
+		engine.SetAnnotator(annotations.annotator(toData))
+	}
+
+	go func() {
+		var cdn string
+		var session *towrap.TrafficOpsSessionThreadsafe
+		ticker := time.NewTicker(annotationPollingInterval)
+		defer ticker.Stop()
+
+		fetch := func() {
+			if !poll || session == nil || !session.Initialized() {
+				return
+			}
+			active, err := session.ActiveAnnotations()
+			if err != nil {
+				log.Errorf("fetching annotations for alerts: %v\n", err)
+				return
+			}
+			annotations.set(cdn, active)
+		}
+
+		for {
+			select {
+			case opsConfig := <-opsConfigChan:
+				cdn = opsConfig.CdnName
+				fetch()
+			case s := <-sessionChan:
+				session = &s
+				fetch()
+			case <-ticker.C:
+				fetch()
+			}
+		}
+	}()
+}
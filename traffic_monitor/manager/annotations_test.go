@@ -0,0 +1,63 @@
+package manager
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_monitor/alerting"
+	"github.com/apache/trafficcontrol/traffic_monitor/todata"
+)
+
+func TestAnnotator(t *testing.T) {
+	now := time.Now()
+	start, end := now.Add(-time.Hour), now.Add(time.Hour)
+	annotations := &annotationsThreadsafe{m: &sync.RWMutex{}}
+	annotations.set("cdn1", []tc.Annotation{
+		{ID: 1, StartTime: start, EndTime: end, CDN: util.StrPtr("cdn1")},
+		{ID: 2, StartTime: start, EndTime: end, CDN: util.StrPtr("cdn2")},
+		{ID: 3, StartTime: start, EndTime: end, DeliveryService: util.StrPtr("ds1")},
+		{ID: 4, StartTime: now.Add(time.Hour), EndTime: now.Add(2 * time.Hour), DeliveryService: util.StrPtr("ds1")},
+		{ID: 5, StartTime: start, EndTime: end, Cachegroup: util.StrPtr("cg1")},
+	})
+	annotate := annotations.annotator(todata.NewThreadsafe())
+
+	ids := func(annotations []tc.Annotation) []int {
+		ids := []int{}
+		for _, annotation := range annotations {
+			ids = append(ids, annotation.ID)
+		}
+		return ids
+	}
+
+	if actual := ids(annotate(alerting.ScopeDeliveryService, "ds1", now)); len(actual) != 2 || actual[0] != 1 || actual[1] != 3 {
+		t.Errorf("expected ds1 to be annotated by annotations 1 and 3, actual: %v", actual)
+	}
+	if actual := ids(annotate(alerting.ScopeDeliveryService, "ds2", now)); len(actual) != 1 || actual[0] != 1 {
+		t.Errorf("expected ds2 to be annotated by annotation 1 only, actual: %v", actual)
+	}
+	if actual := ids(annotate(alerting.ScopeCache, "ds1", now)); len(actual) != 1 || actual[0] != 1 {
+		t.Errorf("expected a cache with an unknown Cache Group to be annotated by annotation 1 only, actual: %v", actual)
+	}
+}
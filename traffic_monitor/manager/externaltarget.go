@@ -0,0 +1,141 @@
+package manager
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/config"
+	"github.com/apache/trafficcontrol/traffic_monitor/health"
+	"github.com/apache/trafficcontrol/traffic_monitor/peer"
+	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
+)
+
+// ExternalTargetPollIntervalParameter is the name of the Traffic Monitor
+// Parameter which sets how often, in milliseconds, the health check URLs of
+// steering external targets are polled.
+const ExternalTargetPollIntervalParameter = "external.polling.interval"
+
+// DefaultExternalTargetPollInterval is how often the health check URLs of
+// steering external targets are polled, if the Traffic Monitor Parameter
+// ExternalTargetPollIntervalParameter isn't set.
+const DefaultExternalTargetPollInterval = 10 * time.Second
+
+// ExternalTargetType is the type of the health events of steering external
+// targets.
+const ExternalTargetType = "EXTERNAL_TARGET"
+
+// StartExternalTargetManager starts the goroutine which polls the health check
+// URLs of the steering external targets in the monitor config, and sets their
+// availability in localStates.
+func StartExternalTargetManager(
+	monitorConfig threadsafe.TrafficMonitorConfigMap,
+	localStates peer.CRStatesThreadsafe,
+	events health.ThreadsafeEvents,
+	cfg config.Config,
+	appData config.StaticAppData,
+	combineState func(),
+) {
+	client := &http.Client{Timeout: cfg.HTTPTimeout}
+	go func() {
+		for {
+			mc := monitorConfig.Get()
+			pollExternalTargets(client, appData.UserAgent, mc.ExternalTarget, localStates, events)
+			combineState()
+			time.Sleep(getExternalTargetPollInterval(mc))
+		}
+	}()
+}
+
+// getExternalTargetPollInterval returns the external target poll interval
+// configured in the given monitor config, or the default.
+func getExternalTargetPollInterval(mc tc.TrafficMonitorConfigMap) time.Duration {
+	intervalI, ok := mc.Config[ExternalTargetPollIntervalParameter]
+	if !ok {
+		return DefaultExternalTargetPollInterval
+	}
+	interval, ok := intervalI.(float64)
+	if !ok || interval <= 0 {
+		log.Warnf("Traffic Ops Monitor config '%s' value '%v' type %T is not a positive integer, using default '%v'", ExternalTargetPollIntervalParameter, intervalI, intervalI, DefaultExternalTargetPollInterval)
+		return DefaultExternalTargetPollInterval
+	}
+	return time.Duration(interval) * time.Millisecond
+}
+
+// pollExternalTargets polls each of the given external targets once, sets
+// their availability in localStates, and removes any external targets from
+// localStates which are no longer configured.
+func pollExternalTargets(
+	client *http.Client,
+	userAgent string,
+	targets map[string]tc.TMExternalTarget,
+	localStates peer.CRStatesThreadsafe,
+	events health.ThreadsafeEvents,
+) {
+	oldTargets := localStates.GetExternalTargets()
+	for name := range oldTargets {
+		if _, ok := targets[name]; !ok {
+			localStates.DeleteExternalTarget(name)
+		}
+	}
+
+	for name, target := range targets {
+		err := checkExternalTarget(client, userAgent, target.HealthCheckURL)
+		available := err == nil
+		if old, ok := oldTargets[name]; !ok || old.IsAvailable != available {
+			description := "External target is healthy"
+			if err != nil {
+				description = err.Error()
+			}
+			events.Add(health.Event{
+				Time:        health.Time(time.Now()),
+				Description: description,
+				Name:        name,
+				Hostname:    name,
+				Type:        ExternalTargetType,
+				Available:   available,
+			})
+		}
+		localStates.SetExternalTarget(name, tc.CRStatesExternalTarget{IsAvailable: available, LastPoll: time.Now()})
+	}
+}
+
+// checkExternalTarget requests the given health check URL, and returns an
+// error if the request fails or the response status isn't 2XX.
+func checkExternalTarget(client *http.Client, userAgent string, url string) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("creating health check request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("health check request failed: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("health check returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package manager
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/health"
+	"github.com/apache/trafficcontrol/traffic_monitor/peer"
+)
+
+func TestPollExternalTargets(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unhealthy.Close()
+
+	localStates := peer.NewCRStatesThreadsafe()
+	localStates.SetExternalTarget("removed", tc.CRStatesExternalTarget{IsAvailable: true})
+	events := health.NewThreadsafeEvents(10)
+	targets := map[string]tc.TMExternalTarget{
+		"healthy":   {Name: "healthy", HealthCheckURL: healthy.URL},
+		"unhealthy": {Name: "unhealthy", HealthCheckURL: unhealthy.URL},
+	}
+
+	pollExternalTargets(&http.Client{Timeout: time.Second}, "test", targets, localStates, events)
+
+	states := localStates.GetExternalTargets()
+	if len(states) != 2 {
+		t.Fatalf("expected 2 external targets, got %d: %+v", len(states), states)
+	}
+	if !states["healthy"].IsAvailable {
+		t.Error("expected external target with a 2XX health check to be available")
+	}
+	if states["unhealthy"].IsAvailable {
+		t.Error("expected external target with a 503 health check to be unavailable")
+	}
+	if len(events.Get()) != 2 {
+		t.Errorf("expected 2 health events for newly polled external targets, got %d", len(events.Get()))
+	}
+}
+
+func TestGetExternalTargetPollInterval(t *testing.T) {
+	mc := tc.TrafficMonitorConfigMap{Config: map[string]interface{}{}}
+	if interval := getExternalTargetPollInterval(mc); interval != DefaultExternalTargetPollInterval {
+		t.Errorf("expected default interval %v, got %v", DefaultExternalTargetPollInterval, interval)
+	}
+	mc.Config[ExternalTargetPollIntervalParameter] = float64(5000)
+	if interval := getExternalTargetPollInterval(mc); interval != 5*time.Second {
+		t.Errorf("expected interval 5s, got %v", interval)
+	}
+}
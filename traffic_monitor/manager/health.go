@@ -95,6 +95,7 @@ func healthResultManagerListen(
 	}
 
 	lastHealthEndTimes := map[tc.CacheName]time.Time{}
+	pollHistories := health.NewPollHistories()
 	// This reads at least 1 value from the cacheHealthChan. Then, we loop, and try to read from the channel some more. If there's nothing to read, we hit `default` and process. If there is stuff to read, we read it, then inner-loop trying to read more. If we're continuously reading and the channel is never empty, and we hit the tick time, process anyway even though the channel isn't empty, to prevent never processing (starvation).
 	var ticker *time.Ticker
 
@@ -113,6 +114,7 @@ func healthResultManagerListen(
 			localCacheStatus,
 			lastHealthEndTimes,
 			healthHistory,
+			pollHistories,
 			results,
 			cfg,
 			combineStates,
@@ -158,6 +160,7 @@ func processHealthResult(
 	localCacheStatusThreadsafe threadsafe.CacheAvailableStatus,
 	lastHealthEndTimes map[tc.CacheName]time.Time,
 	healthHistory threadsafe.ResultHistory,
+	pollHistories health.PollHistories,
 	results []cache.Result,
 	cfg config.Config,
 	combineStates func(),
@@ -199,7 +202,7 @@ func processHealthResult(
 
 	pollerName := "health"
 	statResultHistoryNil := (*threadsafe.ResultStatHistory)(nil) // health poller doesn't have stats
-	health.CalcAvailability(results, pollerName, statResultHistoryNil, monitorConfigCopy, toDataCopy, localCacheStatusThreadsafe, localStates, events, cfg.CachePollingProtocol)
+	health.CalcAvailability(results, pollerName, statResultHistoryNil, pollHistories, monitorConfigCopy, toDataCopy, localCacheStatusThreadsafe, localStates, events, cfg.CachePollingProtocol)
 	combineStates()
 
 	healthHistory.Set(healthHistoryCopy)
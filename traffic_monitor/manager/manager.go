@@ -29,6 +29,8 @@ import (
 	"golang.org/x/sys/unix"
 
 	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-tc/signing"
 	"github.com/apache/trafficcontrol/traffic_monitor/cache"
 	"github.com/apache/trafficcontrol/traffic_monitor/config"
 	"github.com/apache/trafficcontrol/traffic_monitor/handler"
@@ -40,9 +42,58 @@ import (
 	"github.com/apache/trafficcontrol/traffic_monitor/towrap"
 )
 
-// Start starts the poller and handler goroutines
+// Start starts the poller and handler goroutines of the Traffic Monitor's own
+// CDN, and of each CDN it monitors besides. Does not return unless they fail
+// to start.
 func Start(opsConfigFile string, cfg config.Config, appData config.StaticAppData, trafficMonitorConfigFileName string) error {
-	toSession := towrap.NewTrafficOpsSessionThreadsafe(nil, nil, cfg.CRConfigHistoryCount, cfg)
+	var verifier *signing.Verifier
+	if cfg.CDNSigningKeyFile != "" {
+		var err error
+		if verifier, err = signing.LoadVerifier(cfg.CDNSigningKeyFile); err != nil {
+			return fmt.Errorf("loading CDN signing key: %v", err)
+		}
+	}
+
+	toSession, err := startCDN(opsConfigFile, cfg, appData, verifier, nil)
+	if err != nil {
+		return err
+	}
+	startMonitoredCDNs(opsConfigFile, cfg, appData, verifier, toSession)
+
+	if err := startMonitorConfigFilePoller(trafficMonitorConfigFileName); err != nil {
+		return fmt.Errorf("starting monitor config file poller: %v", err)
+	}
+	select {}
+}
+
+// startMonitoredCDNs starts monitoring each CDN the Traffic Monitor monitors
+// besides its own, according to Traffic Ops. Each has its own pollers, health
+// state, and HTTP server, on the port given for it; they're only read from
+// Traffic Ops at startup.
+func startMonitoredCDNs(opsConfigFile string, cfg config.Config, appData config.StaticAppData, verifier *signing.Verifier, toSession towrap.TrafficOpsSessionThreadsafe) {
+	cdns, err := toSession.MonitoredCDNs(appData.Hostname)
+	if err != nil {
+		log.Errorf("only monitoring own CDN: %v", err)
+		return
+	}
+	for _, cdn := range cdns {
+		cdn := cdn
+		cdnCfg := cfg
+		cdnCfg.CRConfigBackupFile = cfg.CRConfigBackupFile + "." + string(cdn.CDNName)
+		cdnCfg.TMConfigBackupFile = cfg.TMConfigBackupFile + "." + string(cdn.CDNName)
+		if _, err := startCDN(opsConfigFile, cdnCfg, appData, verifier, &cdn); err != nil {
+			log.Errorf("starting monitoring of CDN '%s': %v", cdn.CDNName, err)
+			continue
+		}
+		log.Infof("monitoring CDN '%s' on port %d", cdn.CDNName, cdn.Port)
+	}
+}
+
+// startCDN starts the poller and handler goroutines of a CDN, returning its
+// Traffic Ops session. If monitoredCDN is nil, the CDN is the Traffic
+// Monitor's own; otherwise, it's a CDN it monitors besides.
+func startCDN(opsConfigFile string, cfg config.Config, appData config.StaticAppData, verifier *signing.Verifier, monitoredCDN *tc.MonitoredCDN) (towrap.TrafficOpsSessionThreadsafe, error) {
+	toSession := towrap.NewTrafficOpsSessionThreadsafe(nil, nil, cfg.CRConfigHistoryCount, cfg, verifier)
 
 	localStates := peer.NewCRStatesThreadsafe() // this is the local state as discoverer by this traffic_monitor
 	fetchCount := threadsafe.NewUint()          // note this is the number of individual caches fetched from, not the number of times all the caches were polled.
@@ -105,6 +156,25 @@ func Start(opsConfigFile string, cfg config.Config, appData config.StaticAppData
 
 	combinedStates, combineStateFunc := StartStateCombiner(events, peerStates, localStates, toData)
 
+	StartExternalTargetManager(
+		monitorConfig,
+		localStates,
+		events,
+		cfg,
+		appData,
+		combineStateFunc,
+	)
+
+	StartRouterManager(
+		monitorConfig,
+		toData,
+		localStates,
+		events,
+		cfg,
+		appData,
+		combineStateFunc,
+	)
+
 	StartPeerManager(
 		peerHandler.ResultChannel,
 		peerStates,
@@ -112,7 +182,7 @@ func Start(opsConfigFile string, cfg config.Config, appData config.StaticAppData
 		combineStateFunc,
 	)
 
-	statInfoHistory, statResultHistory, statMaxKbpses, _, lastKbpsStats, dsStats, statUnpolledCaches, localCacheStatus := StartStatHistoryManager(
+	statInfoHistory, statResultHistory, statMaxKbpses, _, lastKbpsStats, dsStats, dsThroughput, statUnpolledCaches, localCacheStatus := StartStatHistoryManager(
 		cacheStatHandler.ResultChan(),
 		localStates,
 		combinedStates,
@@ -137,6 +207,13 @@ func Start(opsConfigFile string, cfg config.Config, appData config.StaticAppData
 		combineStateFunc,
 	)
 
+	alertOpsConfigChan := make(chan handler.OpsConfig)
+	alertSessionChan := make(chan towrap.TrafficOpsSessionThreadsafe)
+	alerts, err := StartAlertManager(cfg, appData, dsStats, lastKbpsStats, toData, alertOpsConfigChan, alertSessionChan)
+	if err != nil {
+		return toSession, fmt.Errorf("starting alert manager: %v", err)
+	}
+
 	StartDistributedPeerManager(
 		distributedPeerHandler.ResultChannel,
 		localStates,
@@ -149,8 +226,8 @@ func Start(opsConfigFile string, cfg config.Config, appData config.StaticAppData
 		opsConfigFile,
 		toSession,
 		toData,
-		[]chan<- handler.OpsConfig{monitorConfigPoller.OpsConfigChannel},
-		[]chan<- towrap.TrafficOpsSessionThreadsafe{monitorConfigPoller.SessionChannel},
+		[]chan<- handler.OpsConfig{monitorConfigPoller.OpsConfigChannel, alertOpsConfigChan},
+		[]chan<- towrap.TrafficOpsSessionThreadsafe{monitorConfigPoller.SessionChannel, alertSessionChan},
 		localStates,
 		peerStates,
 		distributedPeerStates,
@@ -161,6 +238,8 @@ func Start(opsConfigFile string, cfg config.Config, appData config.StaticAppData
 		healthHistory,
 		lastKbpsStats,
 		dsStats,
+		dsThroughput,
+		alerts,
 		events,
 		appData,
 		cacheHealthPoller.Config.Interval,
@@ -173,16 +252,13 @@ func Start(opsConfigFile string, cfg config.Config, appData config.StaticAppData
 		healthUnpolledCaches,
 		monitorConfig,
 		cfg,
+		monitoredCDN,
 	); err != nil {
-		return fmt.Errorf("starting ops config manager: %v", err)
+		return toSession, fmt.Errorf("starting ops config manager: %v", err)
 	}
 
-	if err := startMonitorConfigFilePoller(trafficMonitorConfigFileName); err != nil {
-		return fmt.Errorf("starting monitor config file poller: %v", err)
-	}
-
-	healthTickListener(cacheHealthPoller.TickChan, healthIteration)
-	return nil
+	go healthTickListener(cacheHealthPoller.TickChan, healthIteration)
+	return toSession, nil
 }
 
 // healthTickListener listens for health ticks, and writes to the health iteration variable. Does not return.
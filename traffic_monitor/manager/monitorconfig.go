@@ -33,6 +33,7 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_monitor/cache"
 	"github.com/apache/trafficcontrol/traffic_monitor/config"
+	"github.com/apache/trafficcontrol/traffic_monitor/health"
 	"github.com/apache/trafficcontrol/traffic_monitor/peer"
 	"github.com/apache/trafficcontrol/traffic_monitor/poller"
 	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
@@ -299,6 +300,10 @@ func monitorConfigListen(
 				log.Warnln("profile " + srv.Profile + " health.connection.timeout Parameter is missing or zero, using default " + DefaultHealthConnectionTimeout.String())
 			}
 
+			if alg := monitorConfig.Profile[srv.Profile].Parameters.HealthAlgorithm; !health.IsAlgorithm(alg) {
+				log.Errorf("profile '%s' %s Parameter '%s' is not a health algorithm, using default '%s'", srv.Profile, tc.HealthAlgorithmParameterName, alg, health.DefaultAlgorithm)
+			}
+
 			healthURLs[srv.HostName] = poller.PollConfig{URL: pollURL4Str, URLv6: pollURL6Str, Host: srv.FQDN, Timeout: connTimeout, Format: format, PollType: pollType}
 
 			statURL4 := createServerStatPollURL(pollURL4Str)
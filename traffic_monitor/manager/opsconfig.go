@@ -20,15 +20,19 @@ package manager
  */
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net"
+	"strconv"
 	"time"
 
 	"golang.org/x/sys/unix"
 
 	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_monitor/alerting"
 	"github.com/apache/trafficcontrol/traffic_monitor/config"
 	"github.com/apache/trafficcontrol/traffic_monitor/datareq"
 	"github.com/apache/trafficcontrol/traffic_monitor/handler"
@@ -44,6 +48,7 @@ import (
 
 // StartOpsConfigManager starts the ops config manager goroutine, returning the (threadsafe) variables which it sets.
 // Note the OpsConfigManager is in charge of the httpServer, because ops config changes trigger server changes. If other things needed to trigger server restarts, the server could be put in its own goroutine with signal channels
+// If monitoredCDN isn't nil, the ops config is that of a CDN monitored besides the Traffic Monitor's own: its CDN is monitoredCDN's, and it's served over HTTP on monitoredCDN's port.
 func StartOpsConfigManager(
 	opsConfigFile string,
 	toSession towrap.TrafficOpsSessionThreadsafe,
@@ -60,6 +65,8 @@ func StartOpsConfigManager(
 	healthHistory threadsafe.ResultHistory,
 	lastStats threadsafe.LastStats,
 	dsStats threadsafe.DSStatsReader,
+	dsThroughput threadsafe.DSThroughputReader,
+	alerts *alerting.Engine,
 	events health.ThreadsafeEvents,
 	staticAppData config.StaticAppData,
 	healthPollInterval time.Duration,
@@ -72,6 +79,7 @@ func StartOpsConfigManager(
 	healthUnpolledCaches threadsafe.UnpolledCaches,
 	monitorConfig threadsafe.TrafficMonitorConfigMap,
 	cfg config.Config,
+	monitoredCDN *tc.MonitoredCDN,
 ) (threadsafe.OpsConfig, error) {
 
 	handleErr := func(err error) {
@@ -79,6 +87,11 @@ func StartOpsConfigManager(
 		log.Errorf("OpsConfigManager: %v\n", err)
 	}
 
+	accessControl, err := srvhttp.NewAccessControl(cfg.APIAuth)
+	if err != nil {
+		return threadsafe.OpsConfig{}, errors.New("creating API access control: " + err.Error())
+	}
+
 	httpServer := srvhttp.Server{}
 	httpsServer := srvhttp.Server{}
 	opsConfig := threadsafe.NewOpsConfig()
@@ -96,6 +109,11 @@ func StartOpsConfigManager(
 			handleErr(fmt.Errorf("Could not unmarshal Ops Config JSON: %s\n", err))
 			return
 		}
+		if monitoredCDN != nil {
+			newOpsConfig.CdnName = string(monitoredCDN.CDNName)
+			newOpsConfig.HttpListener = ":" + strconv.Itoa(monitoredCDN.Port)
+			newOpsConfig.HttpsListener = ""
+		}
 
 		opsConfig.Set(newOpsConfig)
 
@@ -117,6 +135,8 @@ func StartOpsConfigManager(
 			statMaxKbpses,
 			healthHistory,
 			dsStats,
+			dsThroughput,
+			alerts,
 			events,
 			staticAppData,
 			healthPollInterval,
@@ -142,13 +162,13 @@ func StartOpsConfigManager(
 				handleErr(fmt.Errorf("MonitorConfigPoller: error creating HTTP server: %s\n", err))
 				return
 			}
-			err = httpsServer.Run(endpoints, httpsListenAddress, cfg.ServeReadTimeout, cfg.ServeWriteTimeout, cfg.StaticFileDir, true, newOpsConfig.CertFile, newOpsConfig.KeyFile)
+			err = httpsServer.Run(endpoints, httpsListenAddress, cfg.ServeReadTimeout, cfg.ServeWriteTimeout, cfg.StaticFileDir, true, newOpsConfig.CertFile, newOpsConfig.KeyFile, accessControl)
 			if err != nil {
 				handleErr(fmt.Errorf("MonitorConfigPoller: error creating HTTPS server: %s\n", err))
 				return
 			}
 		} else {
-			err = httpServer.Run(endpoints, listenAddress, cfg.ServeReadTimeout, cfg.ServeWriteTimeout, cfg.StaticFileDir, false, "", "")
+			err = httpServer.Run(endpoints, listenAddress, cfg.ServeReadTimeout, cfg.ServeWriteTimeout, cfg.StaticFileDir, false, "", "", accessControl)
 			if err != nil {
 				handleErr(fmt.Errorf("MonitorConfigPoller: error creating HTTP server: %s\n", err))
 				return
@@ -170,7 +190,13 @@ func StartOpsConfigManager(
 			backoff = util.NewConstantBackoff(util.ConstantBackoffDuration)
 		}
 		for {
-			err = toSession.Update(newOpsConfig.Url, newOpsConfig.Username, newOpsConfig.Password, newOpsConfig.Insecure, staticAppData.UserAgent, useCache, trafficOpsRequestTimeout)
+			err = toSession.Update(newOpsConfig.Url, towrap.Credentials{
+				Username:       newOpsConfig.Username,
+				Password:       newOpsConfig.Password,
+				Token:          newOpsConfig.Token,
+				ClientCertFile: newOpsConfig.ClientCertFile,
+				ClientKeyFile:  newOpsConfig.ClientKeyFile,
+			}, newOpsConfig.Insecure, staticAppData.UserAgent, useCache, trafficOpsRequestTimeout)
 			if err != nil {
 				handleErr(fmt.Errorf("MonitorConfigPoller: error instantiating Session with traffic_ops (%v): %s\n", toAddr, err))
 				duration := backoff.BackoffDuration()
@@ -192,13 +218,17 @@ func StartOpsConfigManager(
 		}
 		opsConfig.Set(newOpsConfig)
 
-		if cdn, err := toSession.MonitorCDN(staticAppData.Hostname); err != nil {
-			handleErr(fmt.Errorf("getting CDN name from Traffic Ops, using config CDN '%s': %s\n", newOpsConfig.CdnName, err))
-		} else {
-			if newOpsConfig.CdnName != "" && newOpsConfig.CdnName != cdn {
-				log.Warnf("%s Traffic Ops CDN '%s' doesn't match config CDN '%s' - using Traffic Ops CDN\n", staticAppData.Hostname, cdn, newOpsConfig.CdnName)
+		// The CDN of a monitored CDN's ops config was given by the Traffic
+		// Monitor's own Profile.
+		if monitoredCDN == nil {
+			if cdn, err := toSession.MonitorCDN(staticAppData.Hostname); err != nil {
+				handleErr(fmt.Errorf("getting CDN name from Traffic Ops, using config CDN '%s': %s\n", newOpsConfig.CdnName, err))
+			} else {
+				if newOpsConfig.CdnName != "" && newOpsConfig.CdnName != cdn {
+					log.Warnf("%s Traffic Ops CDN '%s' doesn't match config CDN '%s' - using Traffic Ops CDN\n", staticAppData.Hostname, cdn, newOpsConfig.CdnName)
+				}
+				newOpsConfig.CdnName = cdn
 			}
-			newOpsConfig.CdnName = cdn
 		}
 
 		// These must be in a goroutine, because the monitorConfigPoller tick sends to a channel this select listens for. Thus, if we block on sends to the monitorConfigPoller, we have a livelock race condition.
@@ -0,0 +1,198 @@
+package manager
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/config"
+	"github.com/apache/trafficcontrol/traffic_monitor/health"
+	"github.com/apache/trafficcontrol/traffic_monitor/peer"
+	"github.com/apache/trafficcontrol/traffic_monitor/threadsafe"
+	"github.com/apache/trafficcontrol/traffic_monitor/todata"
+)
+
+// RouterPollIntervalParameter is the name of the Traffic Monitor Parameter
+// which sets how often, in milliseconds, the stats endpoints of the CDN's
+// Traffic Routers are polled.
+const RouterPollIntervalParameter = "router.polling.interval"
+
+// DefaultRouterPollInterval is how often the stats endpoints of the CDN's
+// Traffic Routers are polled, if the Traffic Monitor Parameter
+// RouterPollIntervalParameter isn't set.
+const DefaultRouterPollInterval = 10 * time.Second
+
+// routerStatsPath is the path of the Traffic Router API endpoint which is
+// polled for the Traffic Router's health and query counts.
+const routerStatsPath = "/crs/stats"
+
+// routerSample is the total number of DNS and HTTP queries a Traffic Router
+// reported at a given time, from which query rates are computed.
+type routerSample struct {
+	DNS  uint64
+	HTTP uint64
+	Time time.Time
+}
+
+// StartRouterManager starts the goroutine which polls the stats endpoints of
+// the Traffic Routers in the CDN's CRConfig, and sets their availability and
+// query rates in localStates.
+func StartRouterManager(
+	monitorConfig threadsafe.TrafficMonitorConfigMap,
+	toData todata.TODataThreadsafe,
+	localStates peer.CRStatesThreadsafe,
+	events health.ThreadsafeEvents,
+	cfg config.Config,
+	appData config.StaticAppData,
+	combineState func(),
+) {
+	client := &http.Client{Timeout: cfg.HTTPTimeout}
+	go func() {
+		samples := map[string]routerSample{}
+		for {
+			pollRouters(client, appData.UserAgent, toData.Get().TrafficRouters, samples, localStates, events)
+			combineState()
+			time.Sleep(getRouterPollInterval(monitorConfig.Get()))
+		}
+	}()
+}
+
+// getRouterPollInterval returns the Traffic Router poll interval configured
+// in the given monitor config, or the default.
+func getRouterPollInterval(mc tc.TrafficMonitorConfigMap) time.Duration {
+	intervalI, ok := mc.Config[RouterPollIntervalParameter]
+	if !ok {
+		return DefaultRouterPollInterval
+	}
+	interval, ok := intervalI.(float64)
+	if !ok || interval <= 0 {
+		log.Warnf("Traffic Ops Monitor config '%s' value '%v' type %T is not a positive integer, using default '%v'", RouterPollIntervalParameter, intervalI, intervalI, DefaultRouterPollInterval)
+		return DefaultRouterPollInterval
+	}
+	return time.Duration(interval) * time.Millisecond
+}
+
+// pollRouters polls each of the given Traffic Routers once, sets their
+// availability and query rates in localStates, and removes any Traffic
+// Routers from localStates which are no longer in the CRConfig.
+//
+// samples holds the query totals of the previous poll of each Traffic Router,
+// and is updated with the totals of this poll.
+func pollRouters(
+	client *http.Client,
+	userAgent string,
+	routers map[string]todata.TrafficRouter,
+	samples map[string]routerSample,
+	localStates peer.CRStatesThreadsafe,
+	events health.ThreadsafeEvents,
+) {
+	oldRouters := localStates.GetRouters()
+	for name := range oldRouters {
+		if _, ok := routers[name]; !ok {
+			localStates.DeleteRouter(name)
+			delete(samples, name)
+		}
+	}
+
+	for name, router := range routers {
+		state := tc.CRStatesRouter{}
+		stats, err := getRouterStats(client, userAgent, router)
+		now := time.Now()
+		if err == nil {
+			state.IsAvailable = true
+			sample := routerSample{Time: now}
+			for _, stat := range stats.Stats.DNSMap {
+				sample.DNS += stat.Total()
+			}
+			for _, stat := range stats.Stats.HTTPMap {
+				sample.HTTP += stat.Total()
+			}
+			if prev, ok := samples[name]; ok {
+				state.DNSQueriesPerSecond = queryRate(prev.DNS, sample.DNS, now.Sub(prev.Time))
+				state.HTTPQueriesPerSecond = queryRate(prev.HTTP, sample.HTTP, now.Sub(prev.Time))
+			}
+			samples[name] = sample
+		} else {
+			delete(samples, name)
+		}
+		state.LastPoll = now
+
+		if old, ok := oldRouters[name]; !ok || old.IsAvailable != state.IsAvailable {
+			description := "Traffic Router is healthy"
+			if err != nil {
+				description = err.Error()
+			}
+			events.Add(health.Event{
+				Time:        health.Time(now),
+				Description: description,
+				Name:        name,
+				Hostname:    name,
+				Type:        tc.RouterTypeName,
+				Available:   state.IsAvailable,
+			})
+		}
+		localStates.SetRouter(name, state)
+	}
+}
+
+// queryRate returns the number of queries per second between the given
+// previous and current query totals, which were counted the given duration
+// apart. If the total decreased, e.g. because the Traffic Router restarted,
+// the rate is unknown and 0 is returned.
+func queryRate(prev uint64, cur uint64, elapsed time.Duration) float64 {
+	if cur < prev || elapsed <= 0 {
+		return 0
+	}
+	return float64(cur-prev) / elapsed.Seconds()
+}
+
+// getRouterStats requests the stats of the given Traffic Router, and returns
+// an error if the request fails, the response status isn't 2XX, or the body
+// can't be decoded.
+func getRouterStats(client *http.Client, userAgent string, router todata.TrafficRouter) (tc.CRSStats, error) {
+	stats := tc.CRSStats{}
+	host := router.FQDN
+	if router.APIPort != "" {
+		host = net.JoinHostPort(host, router.APIPort)
+	}
+	req, err := http.NewRequest(http.MethodGet, "http://"+host+routerStatsPath, nil)
+	if err != nil {
+		return stats, fmt.Errorf("creating stats request: %w", err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	resp, err := client.Do(req)
+	if err != nil {
+		return stats, fmt.Errorf("stats request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return stats, fmt.Errorf("stats request returned status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return stats, fmt.Errorf("decoding stats: %w", err)
+	}
+	return stats, nil
+}
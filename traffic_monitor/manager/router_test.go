@@ -0,0 +1,120 @@
+package manager
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/health"
+	"github.com/apache/trafficcontrol/traffic_monitor/peer"
+	"github.com/apache/trafficcontrol/traffic_monitor/todata"
+)
+
+func TestPollRouters(t *testing.T) {
+	dnsCount := uint64(100)
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != routerStatsPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		stats := tc.CRSStats{Stats: tc.CRSStatsStats{
+			DNSMap:  map[string]tc.CRSStatsStat{"ds.example.net": {CZCount: dnsCount}},
+			HTTPMap: map[string]tc.CRSStatsStat{},
+		}}
+		json.NewEncoder(w).Encode(stats)
+	}))
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unhealthy.Close()
+
+	localStates := peer.NewCRStatesThreadsafe()
+	localStates.SetRouter("removed", tc.CRStatesRouter{IsAvailable: true})
+	events := health.NewThreadsafeEvents(10)
+	routers := map[string]todata.TrafficRouter{
+		"healthy":   {FQDN: strings.TrimPrefix(healthy.URL, "http://")},
+		"unhealthy": {FQDN: strings.TrimPrefix(unhealthy.URL, "http://")},
+	}
+	samples := map[string]routerSample{}
+	client := &http.Client{Timeout: time.Second}
+
+	pollRouters(client, "test", routers, samples, localStates, events)
+
+	states := localStates.GetRouters()
+	if len(states) != 2 {
+		t.Fatalf("expected 2 Traffic Routers, got %d: %+v", len(states), states)
+	}
+	if !states["healthy"].IsAvailable {
+		t.Error("expected Traffic Router with a valid stats response to be available")
+	}
+	if states["healthy"].DNSQueriesPerSecond != 0 {
+		t.Errorf("expected Traffic Router's first poll to have no query rate, got %v", states["healthy"].DNSQueriesPerSecond)
+	}
+	if states["unhealthy"].IsAvailable {
+		t.Error("expected Traffic Router with a 503 stats response to be unavailable")
+	}
+	if len(events.Get()) != 2 {
+		t.Errorf("expected 2 health events for newly polled Traffic Routers, got %d", len(events.Get()))
+	}
+
+	dnsCount = 200
+	sample := samples["healthy"]
+	sample.Time = sample.Time.Add(-10 * time.Second)
+	samples["healthy"] = sample
+	pollRouters(client, "test", routers, samples, localStates, events)
+
+	rate := localStates.GetRouters()["healthy"].DNSQueriesPerSecond
+	if rate < 9 || rate > 10 {
+		t.Errorf("expected Traffic Router DNS query rate of about 10/s, got %v", rate)
+	}
+	if len(events.Get()) != 2 {
+		t.Errorf("expected no new health events for Traffic Routers with unchanged availability, got %d events", len(events.Get()))
+	}
+}
+
+func TestQueryRate(t *testing.T) {
+	if rate := queryRate(100, 300, 10*time.Second); rate != 20 {
+		t.Errorf("expected rate 20, got %v", rate)
+	}
+	if rate := queryRate(300, 100, 10*time.Second); rate != 0 {
+		t.Errorf("expected rate 0 for a decreased total, got %v", rate)
+	}
+	if rate := queryRate(100, 300, 0); rate != 0 {
+		t.Errorf("expected rate 0 for no elapsed time, got %v", rate)
+	}
+}
+
+func TestGetRouterPollInterval(t *testing.T) {
+	mc := tc.TrafficMonitorConfigMap{Config: map[string]interface{}{}}
+	if interval := getRouterPollInterval(mc); interval != DefaultRouterPollInterval {
+		t.Errorf("expected default interval %v, got %v", DefaultRouterPollInterval, interval)
+	}
+	mc.Config[RouterPollIntervalParameter] = float64(5000)
+	if interval := getRouterPollInterval(mc); interval != 5*time.Second {
+		t.Errorf("expected interval 5s, got %v", interval)
+	}
+}
@@ -57,7 +57,7 @@ func getNewCaches(localStates peer.CRStatesThreadsafe, monitorConfigTS threadsaf
 
 // StartStatHistoryManager fetches the full statistics data from ATS Astats. This includes everything needed for all calculations, such as Delivery Services. This is expensive, though, and may be hard on ATS, so it should poll less often.
 // For a fast 'is it alive' poll, use the Health Result Manager poll.
-// Returns the stat history, the duration between the stat poll for each cache, the last Kbps data, the calculated Delivery Service stats, and the unpolled caches list.
+// Returns the stat history, the duration between the stat poll for each cache, the last Kbps data, the calculated Delivery Service stats, the Delivery Service throughput windows, and the unpolled caches list.
 func StartStatHistoryManager(
 	cacheStatChan <-chan cache.Result,
 	localStates peer.CRStatesThreadsafe,
@@ -68,7 +68,7 @@ func StartStatHistoryManager(
 	monitorConfig threadsafe.TrafficMonitorConfigMap,
 	events health.ThreadsafeEvents,
 	combineState func(),
-) (threadsafe.ResultInfoHistory, threadsafe.ResultStatHistory, threadsafe.CacheKbpses, threadsafe.DurationMap, threadsafe.LastStats, threadsafe.DSStatsReader, threadsafe.UnpolledCaches, threadsafe.CacheAvailableStatus) {
+) (threadsafe.ResultInfoHistory, threadsafe.ResultStatHistory, threadsafe.CacheKbpses, threadsafe.DurationMap, threadsafe.LastStats, threadsafe.DSStatsReader, threadsafe.DSThroughputReader, threadsafe.UnpolledCaches, threadsafe.CacheAvailableStatus) {
 	statInfoHistory := threadsafe.NewResultInfoHistory()
 	statResultHistory := threadsafe.NewResultStatHistory()
 	statMaxKbpses := threadsafe.NewCacheKbpses()
@@ -76,6 +76,7 @@ func StartStatHistoryManager(
 	lastStatEndTimes := map[tc.CacheName]time.Time{}
 	lastStats := threadsafe.NewLastStats()
 	dsStats := threadsafe.NewDSStats()
+	dsThroughput := threadsafe.NewDSThroughput(cfg.DSThroughputWindows)
 	statUnpolledCaches := threadsafe.NewUnpolledCaches()
 	localCacheStatus := threadsafe.NewCacheAvailableStatus()
 
@@ -83,6 +84,8 @@ func StartStatHistoryManager(
 
 	lastResults := map[tc.CacheName]cache.Result{}
 
+	pollHistories := health.NewPollHistories()
+
 	haveCachesChanged := func() bool {
 		select {
 		case <-cachesChanged:
@@ -96,7 +99,7 @@ func StartStatHistoryManager(
 		if haveCachesChanged() {
 			statUnpolledCaches.SetNewCaches(getNewCaches(localStates, monitorConfig))
 		}
-		processStatResults(results, statInfoHistory, statResultHistory, statMaxKbpses, combinedStates, lastStats, toData.Get(), dsStats, lastStatEndTimes, lastStatDurations, statUnpolledCaches, monitorConfig.Get(), precomputedData, lastResults, localStates, events, localCacheStatus, combineState, cfg.CachePollingProtocol)
+		processStatResults(results, statInfoHistory, statResultHistory, statMaxKbpses, combinedStates, lastStats, toData.Get(), dsStats, dsThroughput, lastStatEndTimes, lastStatDurations, statUnpolledCaches, monitorConfig.Get(), precomputedData, lastResults, pollHistories, localStates, events, localCacheStatus, combineState, cfg.CachePollingProtocol)
 	}
 
 	go func() {
@@ -216,7 +219,7 @@ func StartStatHistoryManager(
 			}
 		}
 	}()
-	return statInfoHistory, statResultHistory, statMaxKbpses, lastStatDurations, lastStats, &dsStats, statUnpolledCaches, localCacheStatus
+	return statInfoHistory, statResultHistory, statMaxKbpses, lastStatDurations, lastStats, &dsStats, dsThroughput, statUnpolledCaches, localCacheStatus
 }
 
 func stacktrace() []byte {
@@ -241,12 +244,14 @@ func processStatResults(
 	lastStats threadsafe.LastStats,
 	toData todata.TOData,
 	dsStats threadsafe.DSStats,
+	dsThroughput threadsafe.DSThroughput,
 	lastStatEndTimes map[tc.CacheName]time.Time,
 	lastStatDurationsThreadsafe threadsafe.DurationMap,
 	statUnpolledCaches threadsafe.UnpolledCaches,
 	mc tc.TrafficMonitorConfigMap,
 	precomputedData map[tc.CacheName]cache.PrecomputedData,
 	lastResults map[tc.CacheName]cache.Result,
+	pollHistories health.PollHistories,
 	localStates peer.CRStatesThreadsafe,
 	events health.ThreadsafeEvents,
 	localCacheStatusThreadsafe threadsafe.CacheAvailableStatus,
@@ -308,10 +313,11 @@ func processStatResults(
 	newDsStats := ds.CreateStats(precomputedData, toData, combinedStates, lastStatsCopy, mc, events, localStates)
 
 	dsStats.Set(*newDsStats)
+	dsThroughput.Add(*newDsStats)
 	lastStats.Set(*lastStatsCopy)
 
 	pollerName := "stat"
-	health.CalcAvailability(results, pollerName, &statResultHistoryThreadsafe, mc, toData, localCacheStatusThreadsafe, localStates, events, pollingProtocol)
+	health.CalcAvailability(results, pollerName, &statResultHistoryThreadsafe, pollHistories, mc, toData, localCacheStatusThreadsafe, localStates, events, pollingProtocol)
 	combineState()
 
 	endTime := time.Now()
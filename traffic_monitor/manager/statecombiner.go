@@ -185,6 +185,73 @@ func pruneCombinedDSState(combinedStates peer.CRStatesThreadsafe, localStates tc
 	}
 }
 
+// combineExternalTargetState optimistically combines the availability of a
+// steering external target: it is available if it is available locally or on
+// any available peer.
+func combineExternalTargetState(
+	name string,
+	localTarget tc.CRStatesExternalTarget,
+	peerCrStatesInfo peer.CRStatesPeersInfo,
+	combinedStates peer.CRStatesThreadsafe,
+) {
+	target := localTarget
+	if !target.IsAvailable {
+		for peerName, peerStates := range peerCrStatesInfo.GetCrStates() {
+			if !peerCrStatesInfo.GetPeerAvailability(peerName) {
+				continue
+			}
+			if peerTarget, ok := peerStates.ExternalTargets[name]; ok && peerTarget.IsAvailable {
+				target.IsAvailable = true
+				break
+			}
+		}
+	}
+	combinedStates.SetExternalTarget(name, target)
+}
+
+// pruneCombinedExternalTargets deletes steering external targets in combined states which have been removed from localStates.
+func pruneCombinedExternalTargets(combinedStates peer.CRStatesThreadsafe, localStates tc.CRStates) {
+	for name := range combinedStates.GetExternalTargets() {
+		if _, ok := localStates.ExternalTargets[name]; !ok {
+			combinedStates.DeleteExternalTarget(name)
+		}
+	}
+}
+
+// combineRouterState optimistically combines the availability of a Traffic
+// Router: it is available if it is available locally or on any available
+// peer. Query rates are those measured locally, unless the Traffic Router is
+// only available from a peer, in which case the peer's are used.
+func combineRouterState(
+	name string,
+	localRouter tc.CRStatesRouter,
+	peerCrStatesInfo peer.CRStatesPeersInfo,
+	combinedStates peer.CRStatesThreadsafe,
+) {
+	router := localRouter
+	if !router.IsAvailable {
+		for peerName, peerStates := range peerCrStatesInfo.GetCrStates() {
+			if !peerCrStatesInfo.GetPeerAvailability(peerName) {
+				continue
+			}
+			if peerRouter, ok := peerStates.Routers[name]; ok && peerRouter.IsAvailable {
+				router = peerRouter
+				break
+			}
+		}
+	}
+	combinedStates.SetRouter(name, router)
+}
+
+// pruneCombinedRouters deletes Traffic Routers in combined states which have been removed from localStates.
+func pruneCombinedRouters(combinedStates peer.CRStatesThreadsafe, localStates tc.CRStates) {
+	for name := range combinedStates.GetRouters() {
+		if _, ok := localStates.Routers[name]; !ok {
+			combinedStates.DeleteRouter(name)
+		}
+	}
+}
+
 // pruneCombinedCaches deletes caches in combined states which have been removed from localStates.
 func pruneCombinedCaches(combinedStates peer.CRStatesThreadsafe, localStates tc.CRStates) {
 	combinedCaches := combinedStates.GetCaches()
@@ -204,8 +271,18 @@ func combineCrStates(events health.ThreadsafeEvents, peerOptimistic bool, peerCr
 		combineDSState(deliveryServiceName, localDeliveryService, peerCrStatesInfo, combinedStates)
 	}
 
+	for name, localTarget := range localStates.ExternalTargets {
+		combineExternalTargetState(name, localTarget, peerCrStatesInfo, combinedStates)
+	}
+
+	for name, localRouter := range localStates.Routers {
+		combineRouterState(name, localRouter, peerCrStatesInfo, combinedStates)
+	}
+
 	pruneCombinedDSState(combinedStates, localStates, peerCrStatesInfo)
 	pruneCombinedCaches(combinedStates, localStates)
+	pruneCombinedExternalTargets(combinedStates, localStates)
+	pruneCombinedRouters(combinedStates, localStates)
 }
 
 // CacheNameSlice is a slice of cache names, which fulfills the `sort.Interface` interface.
@@ -155,3 +155,92 @@ func TestCombineCacheStateCacheDown(t *testing.T) {
 		t.Fatalf("cache IPv6 is unavailable and should be available")
 	}
 }
+
+func TestCombineExternalTargetState(t *testing.T) {
+	peerStates := peer.NewCRStatesPeersThreadsafe(1)
+	peerStates.SetTimeout(time.Duration(rand.Int63()))
+	peerStates.Set(peer.Result{
+		ID:        tc.TrafficMonitorName("TestTM-01"),
+		Available: true,
+		PeerStates: tc.CRStates{
+			ExternalTargets: map[string]tc.CRStatesExternalTarget{
+				"up-on-peer":   {IsAvailable: true},
+				"down-on-peer": {IsAvailable: false},
+			},
+		},
+		Time: time.Now(),
+	})
+	peerStates.SetPeers(map[tc.TrafficMonitorName]struct{}{
+		tc.TrafficMonitorName("TestTM-01"): {},
+	})
+	peerStates.SetTimeout(time.Duration(rand.Int()))
+
+	combinedStates := peer.NewCRStatesThreadsafe()
+	combineExternalTargetState("up-on-peer", tc.CRStatesExternalTarget{IsAvailable: false}, peerStates.GetCRStatesPeersInfo(), combinedStates)
+	combineExternalTargetState("down-on-peer", tc.CRStatesExternalTarget{IsAvailable: false}, peerStates.GetCRStatesPeersInfo(), combinedStates)
+	combineExternalTargetState("up-locally", tc.CRStatesExternalTarget{IsAvailable: true}, peerStates.GetCRStatesPeersInfo(), combinedStates)
+
+	targets := combinedStates.GetExternalTargets()
+	if !targets["up-on-peer"].IsAvailable {
+		t.Error("external target available on a peer should be available")
+	}
+	if targets["down-on-peer"].IsAvailable {
+		t.Error("external target unavailable locally and on all peers should be unavailable")
+	}
+	if !targets["up-locally"].IsAvailable {
+		t.Error("external target available locally should be available")
+	}
+
+	pruneCombinedExternalTargets(combinedStates, tc.CRStates{ExternalTargets: map[string]tc.CRStatesExternalTarget{"up-locally": {}}})
+	if targets := combinedStates.GetExternalTargets(); len(targets) != 1 {
+		t.Errorf("expected 1 external target after pruning, got %d", len(targets))
+	}
+}
+
+func TestCombineRouterState(t *testing.T) {
+	peerStates := peer.NewCRStatesPeersThreadsafe(1)
+	peerStates.SetTimeout(time.Duration(rand.Int63()))
+	peerStates.Set(peer.Result{
+		ID:        tc.TrafficMonitorName("TestTM-01"),
+		Available: true,
+		PeerStates: tc.CRStates{
+			Routers: map[string]tc.CRStatesRouter{
+				"up-on-peer":   {IsAvailable: true, DNSQueriesPerSecond: 5},
+				"down-on-peer": {IsAvailable: false},
+				"up-locally":   {IsAvailable: true, DNSQueriesPerSecond: 5},
+			},
+		},
+		Time: time.Now(),
+	})
+	peerStates.SetPeers(map[tc.TrafficMonitorName]struct{}{
+		tc.TrafficMonitorName("TestTM-01"): {},
+	})
+	peerStates.SetTimeout(time.Duration(rand.Int()))
+
+	combinedStates := peer.NewCRStatesThreadsafe()
+	combineRouterState("up-on-peer", tc.CRStatesRouter{IsAvailable: false}, peerStates.GetCRStatesPeersInfo(), combinedStates)
+	combineRouterState("down-on-peer", tc.CRStatesRouter{IsAvailable: false}, peerStates.GetCRStatesPeersInfo(), combinedStates)
+	combineRouterState("up-locally", tc.CRStatesRouter{IsAvailable: true, DNSQueriesPerSecond: 10}, peerStates.GetCRStatesPeersInfo(), combinedStates)
+
+	routers := combinedStates.GetRouters()
+	if !routers["up-on-peer"].IsAvailable {
+		t.Error("Traffic Router available on a peer should be available")
+	}
+	if routers["up-on-peer"].DNSQueriesPerSecond != 5 {
+		t.Errorf("Traffic Router only available on a peer should have the peer's query rate 5, got %v", routers["up-on-peer"].DNSQueriesPerSecond)
+	}
+	if routers["down-on-peer"].IsAvailable {
+		t.Error("Traffic Router unavailable locally and on all peers should be unavailable")
+	}
+	if !routers["up-locally"].IsAvailable {
+		t.Error("Traffic Router available locally should be available")
+	}
+	if routers["up-locally"].DNSQueriesPerSecond != 10 {
+		t.Errorf("Traffic Router available locally should have the local query rate 10, got %v", routers["up-locally"].DNSQueriesPerSecond)
+	}
+
+	pruneCombinedRouters(combinedStates, tc.CRStates{Routers: map[string]tc.CRStatesRouter{"up-locally": {}}})
+	if routers := combinedStates.GetRouters(); len(routers) != 1 {
+		t.Errorf("expected 1 Traffic Router after pruning, got %d", len(routers))
+	}
+}
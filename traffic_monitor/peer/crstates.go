@@ -116,6 +116,56 @@ func (t *CRStatesThreadsafe) DeleteDeliveryService(name tc.DeliveryServiceName)
 	t.m.Unlock()
 }
 
+// GetExternalTargets returns the availability data of all steering external targets. This does not mutate, and is thus safe for multiple goroutines to call.
+func (t *CRStatesThreadsafe) GetExternalTargets() map[string]tc.CRStatesExternalTarget {
+	t.m.RLock()
+	defer t.m.RUnlock()
+	targets := make(map[string]tc.CRStatesExternalTarget, len(t.crStates.ExternalTargets))
+	for name, target := range t.crStates.ExternalTargets {
+		targets[name] = target
+	}
+	return targets
+}
+
+// SetExternalTarget sets the availability data for the given steering external target.
+func (t *CRStatesThreadsafe) SetExternalTarget(name string, target tc.CRStatesExternalTarget) {
+	t.m.Lock()
+	t.crStates.ExternalTargets[name] = target
+	t.m.Unlock()
+}
+
+// DeleteExternalTarget deletes the given steering external target from the internal data.
+func (t *CRStatesThreadsafe) DeleteExternalTarget(name string) {
+	t.m.Lock()
+	delete(t.crStates.ExternalTargets, name)
+	t.m.Unlock()
+}
+
+// GetRouters returns the availability and query rate data of all Traffic Routers. This does not mutate, and is thus safe for multiple goroutines to call.
+func (t *CRStatesThreadsafe) GetRouters() map[string]tc.CRStatesRouter {
+	t.m.RLock()
+	defer t.m.RUnlock()
+	routers := make(map[string]tc.CRStatesRouter, len(t.crStates.Routers))
+	for name, router := range t.crStates.Routers {
+		routers[name] = router
+	}
+	return routers
+}
+
+// SetRouter sets the availability and query rate data for the given Traffic Router.
+func (t *CRStatesThreadsafe) SetRouter(name string, router tc.CRStatesRouter) {
+	t.m.Lock()
+	t.crStates.Routers[name] = router
+	t.m.Unlock()
+}
+
+// DeleteRouter deletes the given Traffic Router from the internal data.
+func (t *CRStatesThreadsafe) DeleteRouter(name string) {
+	t.m.Lock()
+	delete(t.crStates.Routers, name)
+	t.m.Unlock()
+}
+
 // CRStatesPeersThreadsafe provides safe access for multiple goroutines to read a map of Traffic Monitor peers to their returned Crstates, with a single goroutine writer.
 // This could be made lock-free, if the performance was necessary
 type CRStatesPeersThreadsafe struct {
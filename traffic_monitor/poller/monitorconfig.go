@@ -72,6 +72,9 @@ func (p MonitorConfigPoller) writeConfig(cfg MonitorCfg) {
 func (p MonitorConfigPoller) Poll() {
 	tick := time.NewTicker(p.Interval)
 	defer tick.Stop()
+	// written is the CDN whose config was last written, which needn't be
+	// written again until it changes.
+	written := ""
 	defer func() {
 		if err := recover(); err != nil {
 			log.Errorf("MonitorConfigPoller panic: %v\n", err)
@@ -107,17 +110,23 @@ func (p MonitorConfigPoller) Poll() {
 				log.Warnln("MonitorConfigPoller: skipping this iteration, Session is nil")
 				continue
 			}
-			monitorConfig, err := p.Session.TrafficMonitorConfigMap(p.OpsConfig.CdnName)
+			monitorConfig, changed, err := p.Session.TrafficMonitorConfigMap(p.OpsConfig.CdnName)
 			if err != nil {
 				log.Errorf("MonitorConfigPoller: %s\n %v\n", err, monitorConfig)
 				continue
 			}
+			// An unchanged TMConfig means the CRConfig is unchanged too.
+			if !changed && written == p.OpsConfig.CdnName {
+				continue
+			}
+			written = ""
 			// poll the CRConfig so that it is synchronized with the TMConfig
 			if _, err := p.Session.CRConfigRaw(p.OpsConfig.CdnName); err != nil {
 				log.Errorf("MonitorConfigPoller: error getting CRConfig: %v", err)
 				continue
 			}
 			p.writeConfig(MonitorCfg{CDN: p.OpsConfig.CdnName, Cfg: *monitorConfig})
+			written = p.OpsConfig.CdnName
 		}
 	}
 }
@@ -0,0 +1,391 @@
+// Package prometheus contains the types and encodings needed for Traffic
+// Monitor to act as a Prometheus data source, via both the federation
+// (text exposition) endpoint and the remote-read protocol.
+//
+// This intentionally implements only the small subset of the Prometheus data
+// model Traffic Monitor needs, rather than importing the Prometheus client
+// libraries and their large dependency tree.
+package prometheus
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MetricNameLabel is the reserved label name Prometheus uses for the name of
+// a metric.
+const MetricNameLabel = "__name__"
+
+// MetricPrefix is prepended to the names of all metrics exported by Traffic
+// Monitor.
+const MetricPrefix = "tm_"
+
+// Label is a single name/value pair identifying a time series.
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample is a single value of a time series at a given time.
+type Sample struct {
+	Value float64
+	// TimestampMS is the time of the Sample, in milliseconds since the Unix
+	// epoch.
+	TimestampMS int64
+}
+
+// TimeSeries is a set of Samples identified by a set of Labels, one of which
+// must be the MetricNameLabel.
+type TimeSeries struct {
+	Labels  []Label
+	Samples []Sample
+}
+
+// Name returns the value of the TimeSeries's MetricNameLabel, or an empty
+// string if it has none.
+func (ts TimeSeries) Name() string {
+	return ts.Label(MetricNameLabel)
+}
+
+// Label returns the value of the TimeSeries's label with the given name, or
+// an empty string if it has none.
+func (ts TimeSeries) Label(name string) string {
+	for _, l := range ts.Labels {
+		if l.Name == name {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+// NewTimeSeries constructs a TimeSeries with the given metric name and
+// labels. The labels are given as name/value pairs, and must therefore be
+// even in number. Labels with empty values are omitted, per the Prometheus
+// data model.
+func NewTimeSeries(name string, labelPairs ...string) TimeSeries {
+	ts := TimeSeries{Labels: make([]Label, 0, 1+len(labelPairs)/2)}
+	ts.Labels = append(ts.Labels, Label{Name: MetricNameLabel, Value: name})
+	for i := 0; i+1 < len(labelPairs); i += 2 {
+		if labelPairs[i+1] == "" {
+			continue
+		}
+		ts.Labels = append(ts.Labels, Label{Name: labelPairs[i], Value: labelPairs[i+1]})
+	}
+	sort.Slice(ts.Labels, func(i, j int) bool { return ts.Labels[i].Name < ts.Labels[j].Name })
+	return ts
+}
+
+// MatchType is the kind of comparison a Matcher performs.
+type MatchType int
+
+// These are the possible MatchTypes. Their values are those of the
+// LabelMatcher.Type enumeration of the Prometheus remote-read protocol.
+const (
+	MatchEqual MatchType = iota
+	MatchNotEqual
+	MatchRegexp
+	MatchNotRegexp
+)
+
+// String implements fmt.Stringer, returning the PromQL operator of the
+// MatchType.
+func (t MatchType) String() string {
+	switch t {
+	case MatchEqual:
+		return "="
+	case MatchNotEqual:
+		return "!="
+	case MatchRegexp:
+		return "=~"
+	case MatchNotRegexp:
+		return "!~"
+	}
+	return "INVALID"
+}
+
+// Matcher selects time series by the value of one of their labels.
+type Matcher struct {
+	Type  MatchType
+	Name  string
+	Value string
+	re    *regexp.Regexp
+}
+
+// NewMatcher constructs a new Matcher, compiling its Value if it is a
+// regular expression. As in Prometheus, regular expressions are fully
+// anchored.
+func NewMatcher(t MatchType, name, value string) (Matcher, error) {
+	m := Matcher{Type: t, Name: name, Value: value}
+	switch t {
+	case MatchEqual, MatchNotEqual:
+	case MatchRegexp, MatchNotRegexp:
+		re, err := regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return m, fmt.Errorf("compiling regular expression for label '%s': %w", name, err)
+		}
+		m.re = re
+	default:
+		return m, fmt.Errorf("unknown match type %d", t)
+	}
+	return m, nil
+}
+
+// Matches returns whether the given label value satisfies the Matcher. A
+// missing label is treated as an empty value.
+func (m Matcher) Matches(v string) bool {
+	switch m.Type {
+	case MatchEqual:
+		return v == m.Value
+	case MatchNotEqual:
+		return v != m.Value
+	case MatchRegexp:
+		return m.re != nil && m.re.MatchString(v)
+	case MatchNotRegexp:
+		return m.re != nil && !m.re.MatchString(v)
+	}
+	return false
+}
+
+// MatchesAll returns whether the given TimeSeries satisfies every one of the
+// given Matchers.
+func (ts TimeSeries) MatchesAll(matchers []Matcher) bool {
+	for _, m := range matchers {
+		if !m.Matches(ts.Label(m.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// ErrEmptySelector is returned by ParseSelector when given a selector with
+// neither a metric name nor any label matchers.
+var ErrEmptySelector = errors.New("empty series selector")
+
+// ParseSelector parses a PromQL instant vector selector, e.g.
+// 'tm_ds_kbps{deliveryservice=~"demo.*",cachegroup!="mid"}', into Matchers.
+// Range selectors, offsets, and functions are not supported.
+func ParseSelector(s string) ([]Matcher, error) {
+	s = strings.TrimSpace(s)
+	matchers := []Matcher{}
+
+	name := s
+	labels := ""
+	if i := strings.IndexByte(s, '{'); i >= 0 {
+		if !strings.HasSuffix(s, "}") {
+			return nil, fmt.Errorf("selector '%s': missing closing brace", s)
+		}
+		name = strings.TrimSpace(s[:i])
+		labels = s[i+1 : len(s)-1]
+	}
+
+	if name != "" {
+		if !validMetricName(name) {
+			return nil, fmt.Errorf("selector '%s': invalid metric name '%s'", s, name)
+		}
+		m, _ := NewMatcher(MatchEqual, MetricNameLabel, name)
+		matchers = append(matchers, m)
+	}
+
+	for labels = strings.TrimSpace(labels); labels != ""; labels = strings.TrimSpace(labels) {
+		opStart := strings.IndexAny(labels, "=!")
+		if opStart <= 0 {
+			return nil, fmt.Errorf("selector '%s': malformed label matcher '%s'", s, labels)
+		}
+		labelName := strings.TrimSpace(labels[:opStart])
+		rest := labels[opStart:]
+
+		var t MatchType
+		switch {
+		case strings.HasPrefix(rest, "=~"):
+			t = MatchRegexp
+		case strings.HasPrefix(rest, "!~"):
+			t = MatchNotRegexp
+		case strings.HasPrefix(rest, "!="):
+			t = MatchNotEqual
+		case strings.HasPrefix(rest, "="):
+			t = MatchEqual
+		default:
+			return nil, fmt.Errorf("selector '%s': malformed operator for label '%s'", s, labelName)
+		}
+		rest = strings.TrimSpace(rest[len(t.String()):])
+
+		quoted, err := strconv.QuotedPrefix(rest)
+		if err != nil {
+			return nil, fmt.Errorf("selector '%s': value for label '%s' must be a quoted string", s, labelName)
+		}
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			return nil, fmt.Errorf("selector '%s': unquoting value for label '%s': %w", s, labelName, err)
+		}
+
+		m, err := NewMatcher(t, labelName, value)
+		if err != nil {
+			return nil, fmt.Errorf("selector '%s': %w", s, err)
+		}
+		matchers = append(matchers, m)
+
+		labels = strings.TrimSpace(rest[len(quoted):])
+		if strings.HasPrefix(labels, ",") {
+			labels = labels[1:]
+		} else if labels != "" {
+			return nil, fmt.Errorf("selector '%s': expected ',' after matcher for label '%s'", s, labelName)
+		}
+	}
+
+	if len(matchers) == 0 {
+		return nil, ErrEmptySelector
+	}
+	return matchers, nil
+}
+
+func validMetricName(s string) bool {
+	for i, r := range s {
+		if r == '_' || r == ':' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return false
+	}
+	return s != ""
+}
+
+// SanitizeName converts an arbitrary string - typically a cache stat name
+// like 'ats.proxy.process.http.current_client_connections' - into a valid
+// Prometheus metric or label name, by replacing every invalid character with
+// an underscore.
+func SanitizeName(s string) string {
+	b := strings.Builder{}
+	b.Grow(len(s))
+	for i, r := range s {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// ToFloat converts a stat value as stored by Traffic Monitor into a float64
+// suitable for a Sample. Booleans are converted to 0 or 1. The second
+// returned value is false if the value is not numeric, e.g. a string.
+func ToFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case bool:
+		if n {
+			return 1, true
+		}
+		return 0, true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+// WriteText writes the given series to w in the Prometheus text exposition
+// format, as served by the federation endpoint. Only the latest Sample of
+// each series is written; series with no Samples are skipped. Series are
+// grouped by metric name, which is required by the format.
+func WriteText(w io.Writer, series []TimeSeries) error {
+	sorted := make([]TimeSeries, 0, len(series))
+	for _, ts := range series {
+		if len(ts.Samples) > 0 {
+			sorted = append(sorted, ts)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name() < sorted[j].Name() })
+
+	lastName := ""
+	for _, ts := range sorted {
+		name := ts.Name()
+		if name != lastName {
+			if _, err := fmt.Fprintf(w, "# TYPE %s untyped\n", name); err != nil {
+				return err
+			}
+			lastName = name
+		}
+
+		b := strings.Builder{}
+		b.WriteString(name)
+		first := true
+		for _, l := range ts.Labels {
+			if l.Name == MetricNameLabel {
+				continue
+			}
+			if first {
+				b.WriteByte('{')
+				first = false
+			} else {
+				b.WriteByte(',')
+			}
+			b.WriteString(l.Name)
+			b.WriteString(`="`)
+			b.WriteString(labelValueEscaper.Replace(l.Value))
+			b.WriteByte('"')
+		}
+		if !first {
+			b.WriteByte('}')
+		}
+
+		sample := ts.Samples[len(ts.Samples)-1]
+		if _, err := fmt.Fprintf(w, "%s %s %d\n", b.String(), formatFloat(sample.Value), sample.TimestampMS); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+package prometheus
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestParseSelector(t *testing.T) {
+	matchers, err := ParseSelector(`tm_ds_kbps{deliveryservice=~"demo.*", cachegroup!="mid", cache_type="EDGE"}`)
+	if err != nil {
+		t.Fatalf("unexpected error parsing selector: %v", err)
+	}
+	if len(matchers) != 4 {
+		t.Fatalf("expected 4 matchers, got %d: %+v", len(matchers), matchers)
+	}
+
+	ts := NewTimeSeries("tm_ds_kbps", "deliveryservice", "demo1", "cachegroup", "edge", "cache_type", "EDGE")
+	if !ts.MatchesAll(matchers) {
+		t.Errorf("expected series %+v to match selector", ts.Labels)
+	}
+	ts = NewTimeSeries("tm_ds_kbps", "deliveryservice", "xdemo1", "cachegroup", "edge", "cache_type", "EDGE")
+	if ts.MatchesAll(matchers) {
+		t.Errorf("expected regular expression to be anchored, but series %+v matched", ts.Labels)
+	}
+	ts = NewTimeSeries("tm_ds_kbps", "deliveryservice", "demo1", "cachegroup", "mid", "cache_type", "EDGE")
+	if ts.MatchesAll(matchers) {
+		t.Errorf("expected series %+v not to match a != matcher", ts.Labels)
+	}
+
+	matchers, err = ParseSelector(`{__name__=~"tm_cache_.*"}`)
+	if err != nil {
+		t.Fatalf("unexpected error parsing name-less selector: %v", err)
+	}
+	if !NewTimeSeries("tm_cache_available").MatchesAll(matchers) {
+		t.Error("expected name-less selector to match by __name__")
+	}
+
+	for _, bad := range []string{"", "{}", `tm{a=b}`, `tm{a="b"`, `9tm`, `tm{a=~"("}`, `tm{a="b" c="d"}`} {
+		if _, err := ParseSelector(bad); err == nil {
+			t.Errorf("expected error parsing selector '%s', got nil", bad)
+		}
+	}
+}
+
+func TestSanitizeName(t *testing.T) {
+	expected := map[string]string{
+		"proxy.process.http.current_client_connections": "proxy_process_http_current_client_connections",
+		"bandwidth":  "bandwidth",
+		"2xx":        "_xx",
+		"a-b/c d":    "a_b_c_d",
+		"system.inf": "system_inf",
+	}
+	for in, out := range expected {
+		if actual := SanitizeName(in); actual != out {
+			t.Errorf("expected '%s' to sanitize to '%s', got '%s'", in, out, actual)
+		}
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	a := NewTimeSeries("tm_b", "cache", `we"ird`)
+	a.Samples = []Sample{{Value: 1, TimestampMS: 10}, {Value: 2.5, TimestampMS: 20}}
+	b := NewTimeSeries("tm_a")
+	b.Samples = []Sample{{Value: math.Inf(1), TimestampMS: 5}}
+	empty := NewTimeSeries("tm_c")
+
+	buf := bytes.Buffer{}
+	if err := WriteText(&buf, []TimeSeries{a, b, empty}); err != nil {
+		t.Fatalf("unexpected error writing text: %v", err)
+	}
+	expected := "# TYPE tm_a untyped\ntm_a +Inf 5\n# TYPE tm_b untyped\ntm_b{cache=\"we\\\"ird\"} 2.5 20\n"
+	if buf.String() != expected {
+		t.Errorf("expected text exposition:\n%s\nactual:\n%s", expected, buf.String())
+	}
+}
+
+func TestRemoteReadRoundTrip(t *testing.T) {
+	matcher := protowire.AppendTag(nil, labelMatcherType, protowire.VarintType)
+	matcher = protowire.AppendVarint(matcher, uint64(MatchRegexp))
+	matcher = protowire.AppendTag(matcher, labelMatcherName, protowire.BytesType)
+	matcher = protowire.AppendString(matcher, MetricNameLabel)
+	matcher = protowire.AppendTag(matcher, labelMatcherValue, protowire.BytesType)
+	matcher = protowire.AppendString(matcher, "tm_ds_.*")
+
+	query := protowire.AppendTag(nil, queryStartTimestampMS, protowire.VarintType)
+	query = protowire.AppendVarint(query, 100)
+	query = protowire.AppendTag(query, queryEndTimestampMS, protowire.VarintType)
+	query = protowire.AppendVarint(query, 200)
+	query = protowire.AppendTag(query, queryMatchers, protowire.BytesType)
+	query = protowire.AppendBytes(query, matcher)
+
+	msg := protowire.AppendTag(nil, readRequestQueries, protowire.BytesType)
+	msg = protowire.AppendBytes(msg, query)
+	msg = protowire.AppendTag(msg, 2, protowire.VarintType) // accepted_response_types, ignored
+	msg = protowire.AppendVarint(msg, 1)
+
+	req, err := DecodeReadRequest(snappy.Encode(nil, msg))
+	if err != nil {
+		t.Fatalf("unexpected error decoding request: %v", err)
+	}
+	if len(req.Queries) != 1 {
+		t.Fatalf("expected 1 query, got %d", len(req.Queries))
+	}
+	q := req.Queries[0]
+	if q.StartTimestampMS != 100 || q.EndTimestampMS != 200 || len(q.Matchers) != 1 {
+		t.Fatalf("unexpected decoded query: %+v", q)
+	}
+
+	in := NewTimeSeries("tm_ds_kbps", "deliveryservice", "demo1")
+	in.Samples = []Sample{{Value: 1, TimestampMS: 50}, {Value: 2, TimestampMS: 150}, {Value: 3, TimestampMS: 250}}
+	other := NewTimeSeries("tm_cache_available", "cache", "edge")
+	other.Samples = []Sample{{Value: 1, TimestampMS: 150}}
+
+	selected := SelectRange([]TimeSeries{in, other}, q)
+	if len(selected) != 1 || len(selected[0].Samples) != 1 || selected[0].Samples[0].Value != 2 {
+		t.Fatalf("expected one series with the single in-range sample, got %+v", selected)
+	}
+
+	encoded, err := snappy.Decode(nil, EncodeReadResponse(ReadResponse{Results: []QueryResult{{TimeSeries: selected}}}))
+	if err != nil {
+		t.Fatalf("unexpected error decompressing response: %v", err)
+	}
+	labels := 0
+	samples := 0
+	err = consumeMessage(encoded, func(_ protowire.Number, _ protowire.Type, result []byte, _ uint64) error {
+		return consumeMessage(result, func(_ protowire.Number, _ protowire.Type, series []byte, _ uint64) error {
+			return consumeMessage(series, func(num protowire.Number, _ protowire.Type, _ []byte, _ uint64) error {
+				switch num {
+				case timeSeriesLabels:
+					labels++
+				case timeSeriesSamples:
+					samples++
+				}
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error decoding response: %v", err)
+	}
+	if labels != 2 || samples != 1 {
+		t.Errorf("expected response to contain 2 labels and 1 sample, got %d labels and %d samples", labels, samples)
+	}
+}
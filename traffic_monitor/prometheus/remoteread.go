@@ -0,0 +1,253 @@
+package prometheus
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/golang/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The remote-read protocol is a snappy-compressed (block format) protobuf
+// message; see prometheus/prompb/remote.proto and types.proto upstream. Only
+// the SAMPLES response type is supported, which every Prometheus server
+// accepts.
+
+// ContentEncodingSnappy is the Content-Encoding of remote-read requests and
+// responses.
+const ContentEncodingSnappy = "snappy"
+
+// ContentTypeProtobuf is the Content-Type of remote-read requests and
+// responses.
+const ContentTypeProtobuf = "application/x-protobuf"
+
+// Query is a single query of a remote-read ReadRequest.
+type Query struct {
+	StartTimestampMS int64
+	EndTimestampMS   int64
+	Matchers         []Matcher
+}
+
+// ReadRequest is a Prometheus remote-read request.
+type ReadRequest struct {
+	Queries []Query
+}
+
+// QueryResult is the response to a single Query; Results in a ReadResponse
+// are in the same order as the Queries of the ReadRequest.
+type QueryResult struct {
+	TimeSeries []TimeSeries
+}
+
+// ReadResponse is a Prometheus remote-read response.
+type ReadResponse struct {
+	Results []QueryResult
+}
+
+// Field numbers from the Prometheus protobuf definitions.
+const (
+	readRequestQueries protowire.Number = 1
+
+	queryStartTimestampMS protowire.Number = 1
+	queryEndTimestampMS   protowire.Number = 2
+	queryMatchers         protowire.Number = 3
+
+	labelMatcherType  protowire.Number = 1
+	labelMatcherName  protowire.Number = 2
+	labelMatcherValue protowire.Number = 3
+
+	readResponseResults protowire.Number = 1
+
+	queryResultTimeSeries protowire.Number = 1
+
+	timeSeriesLabels  protowire.Number = 1
+	timeSeriesSamples protowire.Number = 2
+
+	labelName  protowire.Number = 1
+	labelValue protowire.Number = 2
+
+	sampleValue     protowire.Number = 1
+	sampleTimestamp protowire.Number = 2
+)
+
+// DecodeReadRequest decodes a snappy-compressed, protobuf-encoded remote-read
+// request body.
+func DecodeReadRequest(body []byte) (ReadRequest, error) {
+	req := ReadRequest{}
+	b, err := snappy.Decode(nil, body)
+	if err != nil {
+		return req, fmt.Errorf("decompressing request: %w", err)
+	}
+
+	err = consumeMessage(b, func(num protowire.Number, typ protowire.Type, v []byte, _ uint64) error {
+		if num != readRequestQueries || typ != protowire.BytesType {
+			return nil // e.g. accepted_response_types, which we ignore; SAMPLES is always acceptable.
+		}
+		q, err := decodeQuery(v)
+		if err != nil {
+			return fmt.Errorf("decoding query: %w", err)
+		}
+		req.Queries = append(req.Queries, q)
+		return nil
+	})
+	return req, err
+}
+
+func decodeQuery(b []byte) (Query, error) {
+	q := Query{}
+	err := consumeMessage(b, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		switch {
+		case num == queryStartTimestampMS && typ == protowire.VarintType:
+			q.StartTimestampMS = int64(n)
+		case num == queryEndTimestampMS && typ == protowire.VarintType:
+			q.EndTimestampMS = int64(n)
+		case num == queryMatchers && typ == protowire.BytesType:
+			m, err := decodeLabelMatcher(v)
+			if err != nil {
+				return fmt.Errorf("decoding label matcher: %w", err)
+			}
+			q.Matchers = append(q.Matchers, m)
+		}
+		return nil
+	})
+	return q, err
+}
+
+func decodeLabelMatcher(b []byte) (Matcher, error) {
+	t := MatchEqual
+	name := ""
+	value := ""
+	err := consumeMessage(b, func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error {
+		switch {
+		case num == labelMatcherType && typ == protowire.VarintType:
+			t = MatchType(n)
+		case num == labelMatcherName && typ == protowire.BytesType:
+			name = string(v)
+		case num == labelMatcherValue && typ == protowire.BytesType:
+			value = string(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return Matcher{}, err
+	}
+	return NewMatcher(t, name, value)
+}
+
+// consumeMessage calls f for each field of the protobuf message b. For
+// length-delimited fields, v is the field's bytes; for varint and fixed
+// fields, n is the field's value.
+func consumeMessage(b []byte, f func(num protowire.Number, typ protowire.Type, v []byte, n uint64) error) error {
+	for len(b) > 0 {
+		num, typ, l := protowire.ConsumeTag(b)
+		if l < 0 {
+			return protowire.ParseError(l)
+		}
+		b = b[l:]
+
+		var v []byte
+		var n uint64
+		switch typ {
+		case protowire.VarintType:
+			n, l = protowire.ConsumeVarint(b)
+		case protowire.Fixed64Type:
+			n, l = protowire.ConsumeFixed64(b)
+		case protowire.Fixed32Type:
+			var n32 uint32
+			n32, l = protowire.ConsumeFixed32(b)
+			n = uint64(n32)
+		case protowire.BytesType:
+			v, l = protowire.ConsumeBytes(b)
+		default:
+			l = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if l < 0 {
+			return protowire.ParseError(l)
+		}
+		b = b[l:]
+
+		if err := f(num, typ, v, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EncodeReadResponse encodes the given remote-read response as a protobuf
+// message, compressed with snappy.
+func EncodeReadResponse(resp ReadResponse) []byte {
+	b := []byte{}
+	for _, result := range resp.Results {
+		r := []byte{}
+		for _, ts := range result.TimeSeries {
+			r = protowire.AppendTag(r, queryResultTimeSeries, protowire.BytesType)
+			r = protowire.AppendBytes(r, encodeTimeSeries(ts))
+		}
+		b = protowire.AppendTag(b, readResponseResults, protowire.BytesType)
+		b = protowire.AppendBytes(b, r)
+	}
+	return snappy.Encode(nil, b)
+}
+
+func encodeTimeSeries(ts TimeSeries) []byte {
+	b := []byte{}
+	for _, l := range ts.Labels {
+		lb := protowire.AppendTag(nil, labelName, protowire.BytesType)
+		lb = protowire.AppendString(lb, l.Name)
+		lb = protowire.AppendTag(lb, labelValue, protowire.BytesType)
+		lb = protowire.AppendString(lb, l.Value)
+		b = protowire.AppendTag(b, timeSeriesLabels, protowire.BytesType)
+		b = protowire.AppendBytes(b, lb)
+	}
+	for _, s := range ts.Samples {
+		sb := protowire.AppendTag(nil, sampleValue, protowire.Fixed64Type)
+		sb = protowire.AppendFixed64(sb, math.Float64bits(s.Value))
+		sb = protowire.AppendTag(sb, sampleTimestamp, protowire.VarintType)
+		sb = protowire.AppendVarint(sb, uint64(s.TimestampMS))
+		b = protowire.AppendTag(b, timeSeriesSamples, protowire.BytesType)
+		b = protowire.AppendBytes(b, sb)
+	}
+	return b
+}
+
+// SelectRange returns the subset of the given series matching the given
+// Query: those satisfying all of its Matchers, limited to Samples within its
+// time range. Series left with no Samples are omitted.
+func SelectRange(series []TimeSeries, q Query) []TimeSeries {
+	selected := []TimeSeries{}
+	for _, ts := range series {
+		if !ts.MatchesAll(q.Matchers) {
+			continue
+		}
+		samples := make([]Sample, 0, len(ts.Samples))
+		for _, s := range ts.Samples {
+			if s.TimestampMS >= q.StartTimestampMS && s.TimestampMS <= q.EndTimestampMS {
+				samples = append(samples, s)
+			}
+		}
+		if len(samples) == 0 {
+			continue
+		}
+		selected = append(selected, TimeSeries{Labels: ts.Labels, Samples: samples})
+	}
+	return selected
+}
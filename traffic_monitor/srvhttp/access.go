@@ -0,0 +1,232 @@
+package srvhttp
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/traffic_monitor/config"
+)
+
+const bearerPrefix = "Bearer "
+
+// AccessControl authenticates requests to the Traffic Monitor API, and
+// authorizes them with the access control rule of the endpoint requested.
+//
+// A nil *AccessControl allows every request.
+type AccessControl struct {
+	tokens    []accessToken
+	clientCAs *x509.CertPool
+	// rules is sorted by descending path length, so the first match is the
+	// longest.
+	rules []accessRule
+}
+
+type accessToken struct {
+	client string
+	token  []byte
+}
+
+type accessRule struct {
+	path      string
+	clients   map[string]struct{}
+	networks  []*net.IPNet
+	anonymous bool
+}
+
+// NewAccessControl returns the AccessControl of the given configuration,
+// which is nil if cfg is.
+func NewAccessControl(cfg *config.APIAuth) (*AccessControl, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	ac := &AccessControl{}
+
+	seen := map[string]string{}
+	for client, token := range cfg.Tokens {
+		if client == "" {
+			return nil, errors.New("api_auth tokens: client name cannot be empty")
+		}
+		if token == "" {
+			return nil, fmt.Errorf("api_auth tokens: client '%s' token cannot be empty", client)
+		}
+		if other, ok := seen[token]; ok {
+			return nil, fmt.Errorf("api_auth tokens: clients '%s' and '%s' cannot have the same token", other, client)
+		}
+		seen[token] = client
+		ac.tokens = append(ac.tokens, accessToken{client: client, token: []byte(token)})
+	}
+
+	if cfg.ClientCAFile != "" {
+		pemBytes, err := ioutil.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, errors.New("api_auth reading client_ca_file: " + err.Error())
+		}
+		ac.clientCAs = x509.NewCertPool()
+		if !ac.clientCAs.AppendCertsFromPEM(pemBytes) {
+			return nil, fmt.Errorf("api_auth client_ca_file '%s' has no PEM certificates", cfg.ClientCAFile)
+		}
+	}
+
+	paths := map[string]struct{}{}
+	for _, cfgRule := range cfg.ACL {
+		if !strings.HasPrefix(cfgRule.Path, "/") {
+			return nil, fmt.Errorf("api_auth acl: path '%s' must start with '/'", cfgRule.Path)
+		}
+		if _, ok := paths[cfgRule.Path]; ok {
+			return nil, fmt.Errorf("api_auth acl: path '%s' has more than one rule", cfgRule.Path)
+		}
+		paths[cfgRule.Path] = struct{}{}
+
+		rule := accessRule{path: cfgRule.Path, anonymous: cfgRule.Anonymous}
+		if len(cfgRule.Clients) > 0 {
+			rule.clients = make(map[string]struct{}, len(cfgRule.Clients))
+			for _, client := range cfgRule.Clients {
+				rule.clients[client] = struct{}{}
+			}
+		}
+		for _, cidr := range cfgRule.Networks {
+			_, network, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("api_auth acl: path '%s' network '%s': %v", cfgRule.Path, cidr, err)
+			}
+			rule.networks = append(rule.networks, network)
+		}
+		ac.rules = append(ac.rules, rule)
+	}
+	sort.SliceStable(ac.rules, func(i, j int) bool { return len(ac.rules[i].path) > len(ac.rules[j].path) })
+
+	return ac, nil
+}
+
+// Wrap returns a handler which serves requests allowed by the AccessControl
+// with h, and rejects the others.
+func (ac *AccessControl) Wrap(h http.Handler) http.Handler {
+	if ac == nil {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client, authenticated, err := ac.authenticate(r)
+		if err != nil {
+			log.Infof("API request %s %s from %s: authenticating: %v\n", r.Method, r.URL.Path, r.RemoteAddr, err)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="traffic_monitor"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		if ac.authorize(r, client, authenticated) {
+			h.ServeHTTP(w, r)
+			return
+		}
+		if !authenticated {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="traffic_monitor"`)
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		log.Infof("API request %s %s from %s: client '%s' is not allowed\n", r.Method, r.URL.Path, r.RemoteAddr, client)
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	})
+}
+
+// tlsConfig returns the TLS configuration with which to serve HTTPS, which is
+// nil if the default should be used.
+func (ac *AccessControl) tlsConfig() *tls.Config {
+	if ac == nil || ac.clientCAs == nil {
+		return nil
+	}
+	return &tls.Config{
+		ClientCAs:  ac.clientCAs,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+	}
+}
+
+// authenticate returns the name of the client which made the request, and
+// whether it was authenticated. Requests without credentials aren't, and
+// requests with invalid credentials return an error.
+func (ac *AccessControl) authenticate(r *http.Request) (string, bool, error) {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		if !strings.HasPrefix(auth, bearerPrefix) {
+			return "", false, errors.New("unsupported authorization scheme")
+		}
+		token := []byte(strings.TrimSpace(auth[len(bearerPrefix):]))
+		for _, t := range ac.tokens {
+			if subtle.ConstantTimeCompare(token, t.token) == 1 {
+				return t.client, true, nil
+			}
+		}
+		return "", false, errors.New("unknown token")
+	}
+	// Certificates are only verified when a client CA is configured.
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+		return r.TLS.VerifiedChains[0][0].Subject.CommonName, true, nil
+	}
+	return "", false, nil
+}
+
+// authorize returns whether the request, made by the given client, is
+// allowed by the access control rule of its path.
+func (ac *AccessControl) authorize(r *http.Request, client string, authenticated bool) bool {
+	rule := ac.rule(r.URL.Path)
+	if rule == nil {
+		return authenticated
+	}
+	if rule.anonymous {
+		return true
+	}
+	if len(rule.networks) > 0 {
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			if ip := net.ParseIP(host); ip != nil {
+				for _, network := range rule.networks {
+					if network.Contains(ip) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	if !authenticated {
+		return false
+	}
+	if rule.clients == nil {
+		return true
+	}
+	_, ok := rule.clients[client]
+	return ok
+}
+
+// rule returns the access control rule with the longest path matching the
+// given path, or nil if none does.
+func (ac *AccessControl) rule(path string) *accessRule {
+	for i, rule := range ac.rules {
+		if path == rule.path || strings.HasPrefix(path, strings.TrimSuffix(rule.path, "/")+"/") {
+			return &ac.rules[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package srvhttp
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/trafficcontrol/traffic_monitor/config"
+)
+
+func TestAccessControl(t *testing.T) {
+	ac, err := NewAccessControl(&config.APIAuth{
+		Tokens: map[string]string{
+			"grafana": "grafana-token",
+			"ops":     "ops-token",
+		},
+		ACL: []config.APIACLRule{
+			{Path: "/", Anonymous: true},
+			{Path: "/api/", Clients: []string{"ops"}},
+			{Path: "/api/cache-count"},
+			{Path: "/publish/CrStates", Networks: []string{"192.0.2.0/24"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating access control: %v", err)
+	}
+	h := ac.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	tests := []struct {
+		path       string
+		remoteAddr string
+		token      string
+		cn         string
+		expected   int
+	}{
+		{path: "/", expected: http.StatusOK},
+		{path: "/publish/DsStats", expected: http.StatusOK},
+		{path: "/api/version", expected: http.StatusUnauthorized},
+		{path: "/api/version", token: "bogus", expected: http.StatusUnauthorized},
+		{path: "/api/version", token: "grafana-token", expected: http.StatusForbidden},
+		{path: "/api/version", token: "ops-token", expected: http.StatusOK},
+		{path: "/api/version", cn: "ops", expected: http.StatusOK},
+		{path: "/api/cache-count", token: "grafana-token", expected: http.StatusOK},
+		{path: "/api/cache-count", expected: http.StatusUnauthorized},
+		{path: "/publish/CrStates", remoteAddr: "192.0.2.10:1234", expected: http.StatusOK},
+		{path: "/publish/CrStates", remoteAddr: "198.51.100.10:1234", expected: http.StatusUnauthorized},
+		{path: "/publish/CrStates", remoteAddr: "198.51.100.10:1234", token: "grafana-token", expected: http.StatusOK},
+		{path: "/publish/CrStatesFoo", remoteAddr: "198.51.100.10:1234", expected: http.StatusOK},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodGet, test.path, nil)
+		if test.remoteAddr != "" {
+			r.RemoteAddr = test.remoteAddr
+		}
+		if test.token != "" {
+			r.Header.Set("Authorization", "Bearer "+test.token)
+		}
+		if test.cn != "" {
+			r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: test.cn}}}}}
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != test.expected {
+			t.Errorf("request for '%s' from '%s' with token '%s' and certificate '%s': expected status %d, actual: %d", test.path, r.RemoteAddr, test.token, test.cn, test.expected, w.Code)
+		}
+	}
+}
+
+func TestAccessControlDefault(t *testing.T) {
+	ac, err := NewAccessControl(&config.APIAuth{Tokens: map[string]string{"ops": "ops-token"}})
+	if err != nil {
+		t.Fatalf("unexpected error creating access control: %v", err)
+	}
+	h := ac.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/publish/CrStates", nil))
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected request without a token and without rules to be unauthorized, actual status: %d", w.Code)
+	}
+
+	ac, err = NewAccessControl(nil)
+	if err != nil || ac != nil {
+		t.Fatalf("expected no access control and no error without configuration, actual: %v, %v", ac, err)
+	}
+	w = httptest.NewRecorder()
+	ac.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/publish/CrStates", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected request without access control to be allowed, actual status: %d", w.Code)
+	}
+}
+
+func TestNewAccessControlInvalid(t *testing.T) {
+	for name, cfg := range map[string]config.APIAuth{
+		"empty token":     {Tokens: map[string]string{"ops": ""}},
+		"shared token":    {Tokens: map[string]string{"ops": "token", "grafana": "token"}},
+		"relative path":   {ACL: []config.APIACLRule{{Path: "api/"}}},
+		"duplicate path":  {ACL: []config.APIACLRule{{Path: "/api/"}, {Path: "/api/"}}},
+		"invalid network": {ACL: []config.APIACLRule{{Path: "/api/", Networks: []string{"192.0.2.0"}}}},
+		"missing CA file": {ClientCAFile: "/nonexistent/ca.pem"},
+	} {
+		cfg := cfg
+		if _, err := NewAccessControl(&cfg); err == nil {
+			t.Errorf("%s: expected error, actual: nil", name)
+		}
+	}
+}
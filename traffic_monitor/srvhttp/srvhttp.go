@@ -79,9 +79,10 @@ func (s *Server) registerEndpoints(sm *http.ServeMux, endpoints map[string]http.
 }
 
 // Run runs a new HTTP service at the given addr, making data requests to the given c.
+// Requests are authenticated and authorized by the given accessControl, which may be nil to allow all requests.
 // Run may be called repeatedly, and each time, will shut down any existing service first.
 // Run is NOT threadsafe, and MUST NOT be called concurrently by multiple goroutines.
-func (s *Server) Run(endpoints map[string]http.HandlerFunc, addr string, readTimeout time.Duration, writeTimeout time.Duration, staticFileDir string, tls bool, certFile string, keyFile string) error {
+func (s *Server) Run(endpoints map[string]http.HandlerFunc, addr string, readTimeout time.Duration, writeTimeout time.Duration, staticFileDir string, tls bool, certFile string, keyFile string, accessControl *AccessControl) error {
 	if s.stoppableListener != nil {
 		log.Infof("Stopping Web Server\n")
 		s.stoppableListener.Stop()
@@ -105,7 +106,7 @@ func (s *Server) Run(endpoints map[string]http.HandlerFunc, addr string, readTim
 	}
 	server := &http.Server{
 		Addr:           addr,
-		Handler:        sm,
+		Handler:        accessControl.Wrap(sm),
 		ReadTimeout:    readTimeout,
 		WriteTimeout:   writeTimeout,
 		MaxHeaderBytes: 1 << 20,
@@ -116,6 +117,7 @@ func (s *Server) Run(endpoints map[string]http.HandlerFunc, addr string, readTim
 	go func() {
 		defer s.stoppableListenerWaitGroup.Done()
 		if tls {
+			server.TLSConfig = accessControl.tlsConfig()
 			err = server.ServeTLS(s.stoppableListener, certFile, keyFile)
 			if err != stoppableListener.StoppedError {
 				log.Warnf("HTTP server stopped with error: %v\n", err)
@@ -0,0 +1,72 @@
+package threadsafe
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_monitor/dsdata"
+)
+
+// DSThroughput wraps a dsdata.ThroughputWindows object to be safe for multiple reader goroutines and a single writer.
+type DSThroughput struct {
+	windows *dsdata.ThroughputWindows
+	m       *sync.RWMutex
+}
+
+// DSThroughputReader permits reading of the delivery service throughput windows, but not writing.
+type DSThroughputReader interface {
+	Get(dsName tc.DeliveryServiceName, window time.Duration, withCacheGroups bool) (dsdata.WindowThroughput, bool)
+	DeliveryServiceNames() []tc.DeliveryServiceName
+	Windows() []time.Duration
+}
+
+// NewDSThroughput returns a new DSThroughput computing averages over the given window durations.
+func NewDSThroughput(windows []time.Duration) DSThroughput {
+	return DSThroughput{m: &sync.RWMutex{}, windows: dsdata.NewThroughputWindows(windows)}
+}
+
+// Get returns the average throughput of the given delivery service over the given window, and whether both exist.
+func (o DSThroughput) Get(dsName tc.DeliveryServiceName, window time.Duration, withCacheGroups bool) (dsdata.WindowThroughput, bool) {
+	o.m.RLock()
+	defer o.m.RUnlock()
+	return o.windows.Get(dsName, window, withCacheGroups)
+}
+
+// DeliveryServiceNames returns the names of all delivery services with throughput data.
+func (o DSThroughput) DeliveryServiceNames() []tc.DeliveryServiceName {
+	o.m.RLock()
+	defer o.m.RUnlock()
+	return o.windows.DeliveryServiceNames()
+}
+
+// Windows returns the window durations, in ascending order. The returned slice MUST NOT be modified.
+func (o DSThroughput) Windows() []time.Duration {
+	return o.windows.Durations()
+}
+
+// Add adds a new delivery service stat computation to the windows. This MUST NOT be called by multiple goroutines.
+func (o DSThroughput) Add(stats dsdata.Stats) {
+	o.m.Lock()
+	o.windows.Add(stats)
+	o.m.Unlock()
+}
@@ -78,6 +78,14 @@ type TOData struct {
 	ServerCachegroups      map[tc.CacheName]tc.CacheGroupName
 	ServerDeliveryServices map[tc.CacheName][]tc.DeliveryServiceName
 	ServerTypes            map[tc.CacheName]tc.CacheType
+	TrafficRouters         map[string]TrafficRouter
+}
+
+// TrafficRouter is the data of a Traffic Router in the CDN needed to poll it,
+// as given in the CRConfig.
+type TrafficRouter struct {
+	FQDN    string
+	APIPort string
 }
 
 // New returns a new empty TOData object, initializing pointer members.
@@ -89,6 +97,7 @@ func New() *TOData {
 		DeliveryServiceTypes:   map[tc.DeliveryServiceName]tc.DSTypeCategory{},
 		DeliveryServiceRegexes: NewRegexes(),
 		ServerCachegroups:      map[tc.CacheName]tc.CacheGroupName{},
+		TrafficRouters:         map[string]TrafficRouter{},
 	}
 }
 
@@ -121,6 +130,10 @@ func (d TODataThreadsafe) set(newTOData TOData) {
 // CRConfig is the CrConfig data needed by TOData. Note this is not all data in the CRConfig.
 // TODO change strings to type?
 type CRConfig struct {
+	ContentRouters map[string]struct {
+		FQDN    string `json:"fqdn"`
+		APIPort string `json:"api.port"`
+	} `json:"contentRouters"`
 	ContentServers map[tc.CacheName]struct {
 		DeliveryServices map[tc.DeliveryServiceName][]string `json:"deliveryServices"`
 		CacheGroup       string                              `json:"cacheGroup"`
@@ -173,6 +186,7 @@ func (d TODataThreadsafe) Update(to towrap.TrafficOpsSessionThreadsafe, cdn stri
 	}
 
 	newTOData.ServerCachegroups = getServerCachegroups(mc)
+	newTOData.TrafficRouters = getTrafficRouters(crConfig)
 
 	newTOData.ServerTypes, err = getServerTypes(mc)
 	if err != nil {
@@ -184,6 +198,16 @@ func (d TODataThreadsafe) Update(to towrap.TrafficOpsSessionThreadsafe, cdn stri
 }
 
 // getDeliveryServiceServers gets the servers on each delivery services, for the given CDN, from Traffic Ops.
+// getTrafficRouters returns the Traffic Routers in the given CRConfig, keyed by
+// hostname.
+func getTrafficRouters(crc CRConfig) map[string]TrafficRouter {
+	routers := make(map[string]TrafficRouter, len(crc.ContentRouters))
+	for name, router := range crc.ContentRouters {
+		routers[name] = TrafficRouter{FQDN: router.FQDN, APIPort: router.APIPort}
+	}
+	return routers
+}
+
 func getDeliveryServiceServers(crc CRConfig, mc tc.TrafficMonitorConfigMap) (map[tc.DeliveryServiceName][]tc.CacheName, map[tc.CacheName][]tc.DeliveryServiceName) {
 	dsServers := map[tc.DeliveryServiceName][]tc.CacheName{}
 	serverDses := map[tc.CacheName][]tc.DeliveryServiceName{}
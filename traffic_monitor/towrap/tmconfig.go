@@ -0,0 +1,134 @@
+// Package towrap wraps two versions of Traffic Ops clients to give up-to-date
+// information, possibly using legacy API versions.
+package towrap
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	client "github.com/apache/trafficcontrol/traffic_ops/v4-client"
+)
+
+// VersionedTMConfig is a monitoring config with the version of the Snapshot
+// it's from, to which the changes since that version are applied.
+type VersionedTMConfig struct {
+	Config    tc.TrafficMonitorConfig
+	ConfigMap *tc.TrafficMonitorConfigMap
+	Version   int64
+}
+
+// TMConfigCache is a thread-access-safe map of CDN names to their last
+// versioned monitoring configs.
+type TMConfigCache struct {
+	cache *map[string]VersionedTMConfig
+	m     *sync.RWMutex
+}
+
+// NewTMConfigCache constructs a new, empty TMConfigCache.
+func NewTMConfigCache() TMConfigCache {
+	return TMConfigCache{m: &sync.RWMutex{}, cache: &map[string]VersionedTMConfig{}}
+}
+
+// Set sets the monitoring config of the given CDN.
+func (c TMConfigCache) Set(cdn string, cfg VersionedTMConfig) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	(*c.cache)[cdn] = cfg
+}
+
+// Get returns the monitoring config of the given CDN, and whether there is
+// one.
+func (c TMConfigCache) Get(cdn string) (VersionedTMConfig, bool) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	cfg, ok := (*c.cache)[cdn]
+	return cfg, ok
+}
+
+// Clear removes the monitoring configs of all CDNs, so that they're next
+// requested whole.
+func (c TMConfigCache) Clear() {
+	c.m.Lock()
+	defer c.m.Unlock()
+	*c.cache = map[string]VersionedTMConfig{}
+}
+
+// applyTMConfigDelta returns the monitoring config resulting from applying
+// the given delta to the last one, and whether it changed. With no last
+// config, the delta must have every section.
+func applyTMConfigDelta(last VersionedTMConfig, delta tc.TrafficMonitorConfigDelta) (VersionedTMConfig, bool, error) {
+	if delta.Version == last.Version && last.ConfigMap != nil {
+		return last, false, nil
+	}
+	cfg := last.Config
+	if last.ConfigMap == nil {
+		cfg = tc.TrafficMonitorConfig{}
+	}
+	if err := delta.Apply(&cfg); err != nil {
+		return last, false, errors.New("applying monitoring config delta: " + err.Error())
+	}
+	configMap, err := tc.TrafficMonitorTransformToMap(&cfg)
+	if err != nil {
+		return last, false, err
+	}
+	return VersionedTMConfig{Config: cfg, ConfigMap: configMap, Version: delta.Version}, true, nil
+}
+
+// fetchTMConfigDelta gets the monitoring config of the given CDN by applying
+// the changes to it since the last one, and returns whether it changed. If
+// Traffic Ops can't tell the changes since the last config, they're
+// requested since version 0 - that is, the whole config. An error is
+// returned if Traffic Ops doesn't serve versioned configs, in which case the
+// unversioned config must be requested.
+func (s TrafficOpsSessionThreadsafe) fetchTMConfigDelta(cdn string) (VersionedTMConfig, bool, error) {
+	ss := s.get()
+	if ss == nil {
+		return VersionedTMConfig{}, false, ErrNilSession
+	}
+	last, _ := s.lastTMConfig.Get(cdn)
+	resp, reqInf, err := ss.GetTrafficMonitorConfigDelta(cdn, last.Version, client.NewRequestOptions())
+	if err != nil && reqInf.StatusCode == http.StatusGone && last.Version != 0 {
+		log.Infof("Traffic Ops can't tell the changes to the monitoring config of CDN %s since version %d; requesting it whole\n", cdn, last.Version)
+		last = VersionedTMConfig{}
+		resp, _, err = ss.GetTrafficMonitorConfigDelta(cdn, 0, client.NewRequestOptions())
+	}
+	if err != nil {
+		return VersionedTMConfig{}, false, err
+	}
+	// Traffic Ops versions that don't version Snapshots ignore the version
+	// and serve the whole config, which doesn't parse as a delta.
+	if resp.Response.Version == 0 {
+		return VersionedTMConfig{}, false, errors.New("Traffic Ops doesn't serve versioned monitoring configs")
+	}
+
+	cfg, changed, err := applyTMConfigDelta(last, resp.Response)
+	if err != nil {
+		return VersionedTMConfig{}, false, err
+	}
+	if changed {
+		s.lastTMConfig.Set(cdn, cfg)
+	}
+	return cfg, changed, nil
+}
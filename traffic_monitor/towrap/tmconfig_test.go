@@ -0,0 +1,102 @@
+package towrap
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func fullTMConfigDelta(version int64) tc.TrafficMonitorConfigDelta {
+	return tc.TrafficMonitorConfigDelta{
+		Version: version,
+		Sections: map[string]json.RawMessage{
+			"trafficServers":   json.RawMessage(`[{"hostName":"edge","profile":"EDGE","status":"REPORTED","type":"EDGE"}]`),
+			"cacheGroups":      json.RawMessage(`[{"name":"cg"}]`),
+			"config":           json.RawMessage(`{"peers.polling.interval":1000,"health.polling.interval":1000}`),
+			"trafficMonitors":  json.RawMessage(`[{"hostName":"tm","status":"ONLINE"}]`),
+			"deliveryServices": json.RawMessage(`[{"xmlId":"ds","status":"REPORTED"}]`),
+			"profiles":         json.RawMessage(`[{"name":"EDGE","type":"ATS_PROFILE"}]`),
+		},
+	}
+}
+
+func TestApplyTMConfigDelta(t *testing.T) {
+	cfg, changed, err := applyTMConfigDelta(VersionedTMConfig{}, fullTMConfigDelta(5))
+	if err != nil {
+		t.Fatalf("unexpected error applying full delta: %v", err)
+	}
+	if !changed || cfg.Version != 5 || cfg.ConfigMap == nil {
+		t.Fatalf("expected changed config of version 5, actual changed %t, %+v", changed, cfg)
+	}
+
+	unchanged, changed, err := applyTMConfigDelta(cfg, tc.TrafficMonitorConfigDelta{Version: 5, Since: 5})
+	if err != nil || changed || unchanged.ConfigMap != cfg.ConfigMap {
+		t.Errorf("expected the same config for an empty delta, actual changed %t, error %v", changed, err)
+	}
+
+	delta := tc.TrafficMonitorConfigDelta{
+		Version:  7,
+		Since:    5,
+		Sections: map[string]json.RawMessage{"cacheGroups": json.RawMessage(`[{"name":"cg2"}]`)},
+	}
+	next, changed, err := applyTMConfigDelta(cfg, delta)
+	if err != nil {
+		t.Fatalf("unexpected error applying delta: %v", err)
+	}
+	if !changed || next.Version != 7 {
+		t.Errorf("expected changed config of version 7, actual changed %t, version %d", changed, next.Version)
+	}
+	if _, ok := next.ConfigMap.CacheGroup["cg2"]; !ok || len(next.ConfigMap.CacheGroup) != 1 {
+		t.Errorf("expected changed cache groups, actual %+v", next.ConfigMap.CacheGroup)
+	}
+	if _, ok := next.ConfigMap.TrafficServer["edge"]; !ok {
+		t.Errorf("expected unchanged traffic servers to be kept, actual %+v", next.ConfigMap.TrafficServer)
+	}
+	if _, ok := cfg.ConfigMap.CacheGroup["cg"]; !ok {
+		t.Error("expected applying a delta not to change the last config")
+	}
+
+	delta = tc.TrafficMonitorConfigDelta{
+		Version:  8,
+		Since:    7,
+		Sections: map[string]json.RawMessage{"trafficServers": json.RawMessage(`[]`)},
+	}
+	if _, _, err := applyTMConfigDelta(next, delta); err == nil {
+		t.Error("expected an error for a delta resulting in an invalid config, actual nil")
+	}
+}
+
+func TestTMConfigCache(t *testing.T) {
+	c := NewTMConfigCache()
+	if _, ok := c.Get("cdn"); ok {
+		t.Error("expected no config in a new cache")
+	}
+	c.Set("cdn", VersionedTMConfig{Version: 3})
+	if cfg, ok := c.Get("cdn"); !ok || cfg.Version != 3 {
+		t.Errorf("expected config of version 3, actual %+v, %t", cfg, ok)
+	}
+	c.Clear()
+	if _, ok := c.Get("cdn"); ok {
+		t.Error("expected no config in a cleared cache")
+	}
+}
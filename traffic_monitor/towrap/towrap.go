@@ -36,7 +36,9 @@ import (
 
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-tc/signing"
 	"github.com/apache/trafficcontrol/traffic_monitor/config"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
 	legacyClient "github.com/apache/trafficcontrol/traffic_ops/v3-client"
 	client "github.com/apache/trafficcontrol/traffic_ops/v4-client"
 
@@ -50,11 +52,13 @@ const localHostIP = "127.0.0.1"
 var ErrNilSession = errors.New("nil session")
 
 // ByteTime is a structure for associating a set of raw data with some CDN
-// Snapshot statistics, and a certain time.
+// Snapshot statistics, its signature by Traffic Ops (if any), and a certain
+// time.
 type ByteTime struct {
-	bytes []byte
-	time  time.Time
-	stats *tc.CRConfigStats
+	bytes     []byte
+	signature string
+	time      time.Time
+	stats     *tc.CRConfigStats
 }
 
 // ByteMapCache is a thread-access-safe map of cache server hostnames to
@@ -70,11 +74,12 @@ func NewByteMapCache() ByteMapCache {
 }
 
 // Set sets the entry given by 'key' to a new ByteTime structure with the given
-// raw data ('newBytes') and the given statistics ('stats') at the current time.
-func (c ByteMapCache) Set(key string, newBytes []byte, stats *tc.CRConfigStats) {
+// raw data ('newBytes'), its signature ('signature', which may be empty), and
+// the given statistics ('stats') at the current time.
+func (c ByteMapCache) Set(key string, newBytes []byte, signature string, stats *tc.CRConfigStats) {
 	c.m.Lock()
 	defer c.m.Unlock()
-	(*c.cache)[key] = ByteTime{bytes: newBytes, stats: stats, time: time.Now()}
+	(*c.cache)[key] = ByteTime{bytes: newBytes, signature: signature, stats: stats, time: time.Now()}
 }
 
 // Get retrieves the raw data, associated time, and statistics of the entry
@@ -89,6 +94,15 @@ func (c ByteMapCache) Get(key string) ([]byte, time.Time, *tc.CRConfigStats) {
 	}
 }
 
+// GetSigned retrieves the raw data, its signature, and the associated time of
+// the entry given by 'key'.
+func (c ByteMapCache) GetSigned(key string) ([]byte, string, time.Time) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	byteTime := (*c.cache)[key]
+	return byteTime.bytes, byteTime.signature, byteTime.time
+}
+
 func (s TrafficOpsSessionThreadsafe) BackupFileExists() bool {
 	if _, err := os.Stat(s.CRConfigBackupFile); !os.IsNotExist(err) {
 		if _, err = os.Stat(s.TMConfigBackupFile); !os.IsNotExist(err) {
@@ -196,25 +210,45 @@ type TrafficOpsSessionThreadsafe struct {
 	legacySession      **legacyClient.Session
 	m                  *sync.Mutex
 	lastCRConfig       ByteMapCache
+	lastTMConfig       TMConfigCache
 	crConfigHist       CRConfigHistoryThreadsafe
 	CRConfigBackupFile string
 	TMConfigBackupFile string
+	// verifier, if not nil, verifies the signatures of everything requested
+	// from Traffic Ops.
+	verifier *signing.Verifier
 }
 
 // NewTrafficOpsSessionThreadsafe returns a new threadsafe
-// TrafficOpsSessionThreadsafe wrapping the given `Session`.
-func NewTrafficOpsSessionThreadsafe(s *client.Session, ls *legacyClient.Session, histLimit uint64, cfg config.Config) TrafficOpsSessionThreadsafe {
+// TrafficOpsSessionThreadsafe wrapping the given `Session`. If verifier is not
+// nil, the signatures of all responses from Traffic Ops are verified with it,
+// and responses with missing or invalid signatures are rejected.
+func NewTrafficOpsSessionThreadsafe(s *client.Session, ls *legacyClient.Session, histLimit uint64, cfg config.Config, verifier *signing.Verifier) TrafficOpsSessionThreadsafe {
 	return TrafficOpsSessionThreadsafe{
 		CRConfigBackupFile: cfg.CRConfigBackupFile,
 		crConfigHist:       NewCRConfigHistoryThreadsafe(histLimit),
 		lastCRConfig:       NewByteMapCache(),
+		lastTMConfig:       NewTMConfigCache(),
 		m:                  &sync.Mutex{},
 		session:            &s,
 		legacySession:      &ls,
 		TMConfigBackupFile: cfg.TMConfigBackupFile,
+		verifier:           verifier,
 	}
 }
 
+// Credentials are the means by which a TrafficOpsSessionThreadsafe
+// authenticates with Traffic Ops. If Token is set, it is used instead of the
+// Username and Password. Otherwise, if ClientCertFile is set, the client
+// certificate it contains - with the private key in ClientKeyFile - is used.
+type Credentials struct {
+	Username       string
+	Password       string
+	Token          string
+	ClientCertFile string
+	ClientKeyFile  string
+}
+
 // Initialized tells whether or not the TrafficOpsSessionThreadsafe has been
 // properly initialized with non-nil sessions.
 func (s TrafficOpsSessionThreadsafe) Initialized() bool {
@@ -226,8 +260,7 @@ func (s TrafficOpsSessionThreadsafe) Initialized() bool {
 // aware that they will race.
 func (s *TrafficOpsSessionThreadsafe) Update(
 	url string,
-	username string,
-	password string,
+	creds Credentials,
 	insecure bool,
 	userAgent string,
 	useCache bool,
@@ -239,30 +272,78 @@ func (s *TrafficOpsSessionThreadsafe) Update(
 	s.m.Lock()
 	defer s.m.Unlock()
 
+	// Snapshot versions are only meaningful to the Traffic Ops they're from.
+	s.lastTMConfig.Clear()
+
 	// always set unauthenticated sessions first which can eventually authenticate themselves when attempting requests
-	if err := s.setSession(url, username, password, insecure, userAgent, useCache, timeout); err != nil {
+	if err := s.setSession(url, creds.Username, creds.Password, insecure, userAgent, useCache, timeout); err != nil {
 		return err
 	}
-	if err := s.setLegacySession(url, username, password, insecure, userAgent, useCache, timeout); err != nil {
+	if err := s.setLegacySession(url, creds.Username, creds.Password, insecure, userAgent, useCache, timeout); err != nil {
 		return err
 	}
 
-	session, _, err := client.LoginWithAgent(url, username, password, insecure, userAgent, useCache, timeout)
+	session, err := login(url, creds, insecure, userAgent, useCache, timeout)
 	if err != nil {
 		log.Errorf("logging in using up-to-date client: %v", err)
-		legacySession, _, err := legacyClient.LoginWithAgent(url, username, password, insecure, userAgent, useCache, timeout)
+		legacySession, err := legacyLogin(url, creds, insecure, userAgent, useCache, timeout)
 		if err != nil || legacySession == nil {
 			err = fmt.Errorf("logging in using legacy client: %v", err)
 			return err
 		}
+		legacySession.Client.Transport = s.transport(legacySession.Client.Transport)
 		*s.legacySession = legacySession
 	} else {
+		session.Client.Transport = s.transport(session.Client.Transport)
 		*s.session = session
 	}
 
 	return nil
 }
 
+// login returns a session of the up-to-date client, logged in with the given
+// credentials.
+func login(url string, creds Credentials, insecure bool, userAgent string, useCache bool, timeout time.Duration) (*client.Session, error) {
+	if creds.Token != "" {
+		session, _, err := client.LoginWithToken(url, creds.Token, insecure, userAgent, useCache, timeout)
+		return session, err
+	}
+	if creds.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(creds.ClientCertFile, creds.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		session, _, err := client.LoginWithCertificate(url, cert, insecure, userAgent, useCache, timeout)
+		return session, err
+	}
+	session, _, err := client.LoginWithAgent(url, creds.Username, creds.Password, insecure, userAgent, useCache, timeout)
+	return session, err
+}
+
+// legacyLogin returns a session of the legacy client, logged in with the given
+// credentials. The legacy client does not support client certificate
+// authentication.
+func legacyLogin(url string, creds Credentials, insecure bool, userAgent string, useCache bool, timeout time.Duration) (*legacyClient.Session, error) {
+	if creds.Token != "" {
+		session, _, err := legacyClient.LoginWithToken(url, creds.Token, insecure, userAgent, useCache, timeout)
+		return session, err
+	}
+	if creds.ClientCertFile != "" {
+		return nil, errors.New("client certificate authentication is not supported by the legacy client")
+	}
+	session, _, err := legacyClient.LoginWithAgent(url, creds.Username, creds.Password, insecure, userAgent, useCache, timeout)
+	return session, err
+}
+
+// transport returns the given http.RoundTripper, wrapped to verify the
+// signatures of responses if signature verification is enabled.
+func (s *TrafficOpsSessionThreadsafe) transport(base http.RoundTripper) http.RoundTripper {
+	if s.verifier == nil {
+		return base
+	}
+	return signing.NewTransport(base, *s.verifier)
+}
+
 // setSession sets the session for the up-to-date client without logging in.
 func (s *TrafficOpsSessionThreadsafe) setSession(url, username, password string, insecure bool, userAgent string, useCache bool, timeout time.Duration) error {
 	options := cookiejar.Options{
@@ -274,9 +355,9 @@ func (s *TrafficOpsSessionThreadsafe) setSession(url, username, password string,
 	}
 	to := client.NewSession(username, password, url, userAgent, &http.Client{
 		Timeout: timeout,
-		Transport: &http.Transport{
+		Transport: s.transport(&http.Transport{
 			TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
-		},
+		}),
 		Jar: jar,
 	}, useCache)
 	*s.session = to
@@ -294,9 +375,9 @@ func (s *TrafficOpsSessionThreadsafe) setLegacySession(url, username, password s
 	}
 	to := legacyClient.NewSession(username, password, url, userAgent, &http.Client{
 		Timeout: timeout,
-		Transport: &http.Transport{
+		Transport: s.transport(&http.Transport{
 			TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
-		},
+		}),
 		Jar: jar,
 	}, useCache)
 	*s.legacySession = to
@@ -380,35 +461,47 @@ func (s TrafficOpsSessionThreadsafe) CRConfigRaw(cdn string) ([]byte, error) {
 	var err error
 	var crConfig *tc.CRConfig
 	var configBytes []byte
+	var signature string
 	json := jsoniter.ConfigFastest
 
 	ss := s.get()
 	if ss == nil {
 		return nil, ErrNilSession
 	}
-	response, reqInf, err := ss.GetCRConfig(cdn, client.RequestOptions{})
-	if reqInf.RemoteAddr != nil {
-		remoteAddr = reqInf.RemoteAddr.String()
-	}
-	if err != nil {
-		log.Warnln("getting CRConfig from Traffic Ops using up-to-date client: " + err.Error() + ". Retrying with legacy client")
-		ls := s.getLegacy()
-		if ls == nil {
-			return nil, ErrNilSession
+	if s.verifier != nil {
+		// The signature only applies to the exact bytes Traffic Ops sent, so
+		// they're passed along unmodified rather than being re-marshalled.
+		configBytes, signature, remoteAddr, err = s.fetchSignedCRConfig(ss, cdn)
+		if err != nil {
+			log.Errorln("getting signed CRConfig from Traffic Ops: " + err.Error() + ". Checking for backup")
 		}
-		configBytes, reqInf, err = ls.GetCRConfig(cdn)
+	} else {
+		var response tc.SnapshotResponse
+		var reqInf toclientlib.ReqInf
+		response, reqInf, err = ss.GetCRConfig(cdn, client.RequestOptions{})
 		if reqInf.RemoteAddr != nil {
 			remoteAddr = reqInf.RemoteAddr.String()
 		}
 		if err != nil {
-			log.Errorln("getting CRConfig from Traffic Ops using legacy client: " + err.Error() + ". Checking for backup")
-		}
-	} else {
-		crConfig = &response.Response
-		configBytes, err = json.Marshal(crConfig)
-		if err != nil {
-			crConfig = nil
-			log.Warnln("failed to marshal CRConfig using up-to-date client: " + err.Error())
+			log.Warnln("getting CRConfig from Traffic Ops using up-to-date client: " + err.Error() + ". Retrying with legacy client")
+			ls := s.getLegacy()
+			if ls == nil {
+				return nil, ErrNilSession
+			}
+			configBytes, reqInf, err = ls.GetCRConfig(cdn)
+			if reqInf.RemoteAddr != nil {
+				remoteAddr = reqInf.RemoteAddr.String()
+			}
+			if err != nil {
+				log.Errorln("getting CRConfig from Traffic Ops using legacy client: " + err.Error() + ". Checking for backup")
+			}
+		} else {
+			crConfig = &response.Response
+			configBytes, err = json.Marshal(crConfig)
+			if err != nil {
+				crConfig = nil
+				log.Warnln("failed to marshal CRConfig using up-to-date client: " + err.Error())
+			}
 		}
 	}
 
@@ -417,12 +510,17 @@ func (s TrafficOpsSessionThreadsafe) CRConfigRaw(cdn string) ([]byte, error) {
 		if wErr := ioutil.WriteFile(s.CRConfigBackupFile, configBytes, 0644); wErr != nil {
 			log.Errorf("failed to write CRConfig backup file: %v", wErr)
 		}
+		if signature != "" {
+			if wErr := ioutil.WriteFile(s.CRConfigBackupFile+signatureFileSuffix, []byte(signature), 0644); wErr != nil {
+				log.Errorf("failed to write CRConfig backup signature file: %v", wErr)
+			}
+		}
 	} else {
 		if s.BackupFileExists() {
 			log.Errorln("using backup file for CRConfig snapshot due to error fetching CRConfig snapshot from Traffic Ops: " + err.Error())
-			configBytes, err = ioutil.ReadFile(s.CRConfigBackupFile)
+			configBytes, signature, err = s.readCRConfigBackup()
 			if err != nil {
-				return nil, fmt.Errorf("reading CRConfig backup file: %v", err)
+				return nil, err
 			}
 			remoteAddr = localHostIP
 			err = nil
@@ -440,6 +538,7 @@ func (s TrafficOpsSessionThreadsafe) CRConfigRaw(cdn string) ([]byte, error) {
 	defer s.crConfigHist.Add(hist)
 
 	if crConfig == nil {
+		crConfig = &tc.CRConfig{}
 		if err = json.Unmarshal(configBytes, crConfig); err != nil {
 			err = errors.New("invalid JSON: " + err.Error())
 			hist.Err = err
@@ -454,10 +553,62 @@ func (s TrafficOpsSessionThreadsafe) CRConfigRaw(cdn string) ([]byte, error) {
 		return configBytes, err
 	}
 
-	s.lastCRConfig.Set(cdn, configBytes, &crConfig.Stats)
+	s.lastCRConfig.Set(cdn, configBytes, signature, &crConfig.Stats)
 	return configBytes, nil
 }
 
+// signatureFileSuffix is appended to the name of a backup file to get the
+// name of the file holding the signature of its contents.
+const signatureFileSuffix = ".sig"
+
+// fetchSignedCRConfig requests the CRConfig of the given CDN from Traffic Ops,
+// returning the exact bytes of it which were signed, the signature, and the
+// address of the Traffic Ops instance that served it.
+func (s TrafficOpsSessionThreadsafe) fetchSignedCRConfig(ss *client.Session, cdn string) ([]byte, string, string, error) {
+	resp, remoteAddr, err := ss.RawRequestWithHdr(http.MethodGet, ss.APIBase()+"/cdns/"+url.PathEscape(cdn)+"/snapshot", nil, nil)
+	addr := ""
+	if remoteAddr != nil {
+		addr = remoteAddr.String()
+	}
+	if err != nil {
+		return nil, "", addr, err
+	}
+	defer log.Close(resp.Body, "closing CRConfig response body")
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", addr, fmt.Errorf("Traffic Ops returned status code %d", resp.StatusCode)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", addr, fmt.Errorf("reading response body: %v", err)
+	}
+	signature := resp.Header.Get(signing.ResponseHeader)
+	configBytes, err := s.verifier.VerifyResponse(body, signature)
+	if err != nil {
+		return nil, "", addr, fmt.Errorf("verifying signature: %v", err)
+	}
+	return configBytes, signature, addr, nil
+}
+
+// readCRConfigBackup reads the CRConfig backup file, returning its contents
+// and - if signatures are verified - its signature, which is verified.
+func (s TrafficOpsSessionThreadsafe) readCRConfigBackup() ([]byte, string, error) {
+	configBytes, err := ioutil.ReadFile(s.CRConfigBackupFile)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading CRConfig backup file: %v", err)
+	}
+	if s.verifier == nil {
+		return configBytes, "", nil
+	}
+	signature, err := ioutil.ReadFile(s.CRConfigBackupFile + signatureFileSuffix)
+	if err != nil {
+		return nil, "", fmt.Errorf("reading CRConfig backup signature file: %v", err)
+	}
+	if err := signing.Verify(s.verifier.Key, configBytes, string(signature)); err != nil {
+		return nil, "", fmt.Errorf("verifying CRConfig backup file: %v", err)
+	}
+	return configBytes, string(signature), nil
+}
+
 // LastCRConfig returns the last CRConfig requested from CRConfigRaw, and the
 // time it was returned. This is designed to be used in conjunction with a
 // poller which regularly calls CRConfigRaw. If no last CRConfig exists, because
@@ -472,6 +623,19 @@ func (s TrafficOpsSessionThreadsafe) LastCRConfig(cdn string) ([]byte, time.Time
 	return crConfig, crConfigTime, nil
 }
 
+// LastSignedCRConfig is like LastCRConfig, but also returns the signature of
+// the CRConfig by Traffic Ops, which is empty if signatures aren't verified.
+func (s TrafficOpsSessionThreadsafe) LastSignedCRConfig(cdn string) ([]byte, string, time.Time, error) {
+	crConfig, signature, crConfigTime := s.lastCRConfig.GetSigned(cdn)
+	if len(crConfig) == 0 {
+		if _, err := s.CRConfigRaw(cdn); err != nil {
+			return nil, "", time.Now(), err
+		}
+		crConfig, signature, crConfigTime = s.lastCRConfig.GetSigned(cdn)
+	}
+	return crConfig, signature, crConfigTime, nil
+}
+
 func (s TrafficOpsSessionThreadsafe) fetchTMConfig(cdn string) (*tc.TrafficMonitorConfig, error) {
 	ss := s.get()
 	if ss == nil {
@@ -496,14 +660,23 @@ func (s TrafficOpsSessionThreadsafe) fetchLegacyTMConfig(cdn string) (*tc.Traffi
 }
 
 // trafficMonitorConfigMapRaw returns the Traffic Monitor config map from the
-// Traffic Ops, directly from the monitoring endpoint. This is not usually
-// what is needed, rather monitoring needs the snapshotted CRConfig data, which
-// is filled in by `LegacyTrafficMonitorConfigMap`. This is safe for multiple
-// goroutines.
-func (s TrafficOpsSessionThreadsafe) trafficMonitorConfigMapRaw(cdn string) (*tc.TrafficMonitorConfigMap, error) {
+// Traffic Ops, directly from the monitoring endpoint, and whether it changed
+// since the last call. This is not usually what is needed, rather monitoring
+// needs the snapshotted CRConfig data, which is filled in by
+// `LegacyTrafficMonitorConfigMap`. This is safe for multiple goroutines.
+func (s TrafficOpsSessionThreadsafe) trafficMonitorConfigMapRaw(cdn string) (*tc.TrafficMonitorConfigMap, bool, error) {
+	versioned, changed, err := s.fetchTMConfigDelta(cdn)
+	if err == nil {
+		if changed {
+			log.Infoln("successfully got Traffic Monitor config changes from Traffic Ops")
+			s.writeTMConfigBackup(&versioned.Config)
+		}
+		return versioned.ConfigMap, changed, nil
+	}
+	log.Warnln("getting Traffic Monitor config changes from Traffic Ops: " + err.Error() + ". Requesting the whole config")
+
 	var config *tc.TrafficMonitorConfig
 	var configMap *tc.TrafficMonitorConfigMap
-	var err error
 
 	config, err = s.fetchTMConfig(cdn)
 	if err != nil {
@@ -517,7 +690,7 @@ func (s TrafficOpsSessionThreadsafe) trafficMonitorConfigMapRaw(cdn string) (*tc
 	if err == nil {
 		log.Infoln("successfully got Traffic Monitor config from Traffic Ops")
 		if config == nil {
-			return nil, fmt.Errorf("nil Traffic Monitor config after successful fetch")
+			return nil, false, fmt.Errorf("nil Traffic Monitor config after successful fetch")
 		}
 		configMap, err = tc.TrafficMonitorTransformToMap(config)
 	}
@@ -525,42 +698,51 @@ func (s TrafficOpsSessionThreadsafe) trafficMonitorConfigMapRaw(cdn string) (*tc
 	if err != nil {
 		// Default error case, no backup file exists
 		if !s.BackupFileExists() {
-			return nil, err
+			return nil, false, err
 		}
 		log.Errorln("using backup file for monitoring config snapshot due to invalid monitoring config snapshot from Traffic Ops: " + err.Error())
 
 		b, err := ioutil.ReadFile(s.TMConfigBackupFile)
 		if err != nil {
-			return nil, errors.New("reading TMConfigBackupFile: " + err.Error())
+			return nil, false, errors.New("reading TMConfigBackupFile: " + err.Error())
 		}
 
 		json := jsoniter.ConfigFastest
 		var tmConfig tc.TrafficMonitorConfig
 		if err := json.Unmarshal(b, &tmConfig); err != nil {
-			return nil, errors.New("unmarshalling backup file monitoring.json: " + err.Error())
+			return nil, false, errors.New("unmarshalling backup file monitoring.json: " + err.Error())
 		}
-		return tc.TrafficMonitorTransformToMap(&tmConfig)
+		configMap, err = tc.TrafficMonitorTransformToMap(&tmConfig)
+		return configMap, true, err
 	}
 
+	s.writeTMConfigBackup(config)
+	return configMap, true, nil
+}
+
+// writeTMConfigBackup writes the given monitoring config to the backup file.
+func (s TrafficOpsSessionThreadsafe) writeTMConfigBackup(config *tc.TrafficMonitorConfig) {
 	json := jsoniter.ConfigFastest
 	data, err := json.Marshal(*config)
-	if err == nil {
-		if wErr := ioutil.WriteFile(s.TMConfigBackupFile, data, 0644); wErr != nil {
-			log.Errorf("failed to write TM config backup file: %v", wErr)
-		}
+	if err != nil {
+		log.Errorf("failed to marshal TM config backup: %v", err)
+		return
+	}
+	if wErr := ioutil.WriteFile(s.TMConfigBackupFile, data, 0644); wErr != nil {
+		log.Errorf("failed to write TM config backup file: %v", wErr)
 	}
-
-	return configMap, err
 }
 
 // TrafficMonitorConfigMap returns the Traffic Monitor config map from the
-// Traffic Ops. This is safe for multiple goroutines.
-func (s TrafficOpsSessionThreadsafe) TrafficMonitorConfigMap(cdn string) (*tc.TrafficMonitorConfigMap, error) {
-	mc, err := s.trafficMonitorConfigMapRaw(cdn)
+// Traffic Ops, and whether it changed since the last call for the same CDN.
+// If it didn't, neither did the CDN's CRConfig. This is safe for multiple
+// goroutines.
+func (s TrafficOpsSessionThreadsafe) TrafficMonitorConfigMap(cdn string) (*tc.TrafficMonitorConfigMap, bool, error) {
+	mc, changed, err := s.trafficMonitorConfigMapRaw(cdn)
 	if err != nil {
-		return nil, fmt.Errorf("getting monitor config map: %v", err)
+		return nil, false, fmt.Errorf("getting monitor config map: %v", err)
 	}
-	return mc, nil
+	return mc, changed, nil
 }
 
 func (s TrafficOpsSessionThreadsafe) fetchServerByHostname(hostName string) (tc.ServerV40, error) {
@@ -670,3 +852,59 @@ func (s TrafficOpsSessionThreadsafe) MonitorCDN(hostName string) (string, error)
 	// return an error in that case
 	return *server.CDNName, nil
 }
+
+// MonitoredCDNs returns the CDNs which the Traffic Monitor with the given
+// hostName monitors besides its own, as given by the
+// tc.MonitoredCDNParameterName Parameters of its (first) Profile. Invalid
+// Parameters are logged and ignored.
+func (s TrafficOpsSessionThreadsafe) MonitoredCDNs(hostName string) ([]tc.MonitoredCDN, error) {
+	ss := s.get()
+	if ss == nil {
+		return nil, ErrNilSession
+	}
+	server, err := s.fetchServerByHostname(hostName)
+	if err != nil {
+		return nil, fmt.Errorf("getting monitored CDNs: %v", err)
+	}
+	if len(server.ProfileNames) == 0 {
+		return nil, fmt.Errorf("getting monitored CDNs: server '%s' has no profile", hostName)
+	}
+
+	resp, _, err := ss.GetParametersByProfileName(server.ProfileNames[0], client.NewRequestOptions())
+	if err != nil {
+		return nil, fmt.Errorf("getting monitored CDNs: fetching parameters of profile '%s': %v", server.ProfileNames[0], err)
+	}
+	cdns := []tc.MonitoredCDN{}
+	for _, param := range resp.Response {
+		if param.Name != tc.MonitoredCDNParameterName || param.ConfigFile != tc.MonitoredCDNConfigFile {
+			continue
+		}
+		cdn, err := tc.ParseMonitoredCDN(param.Value)
+		if err != nil {
+			log.Errorf("ignoring %s parameter of profile '%s': %v", tc.MonitoredCDNParameterName, server.ProfileNames[0], err)
+			continue
+		}
+		if server.CDNName != nil && string(cdn.CDNName) == *server.CDNName {
+			continue
+		}
+		cdns = append(cdns, cdn)
+	}
+	return cdns, nil
+}
+
+// ActiveAnnotations returns the annotations in Traffic Ops whose time ranges
+// contain the current time.
+func (s TrafficOpsSessionThreadsafe) ActiveAnnotations() ([]tc.Annotation, error) {
+	ss := s.get()
+	if ss == nil {
+		return nil, ErrNilSession
+	}
+
+	opts := client.NewRequestOptions()
+	opts.QueryParameters.Set(tc.AnnotationActiveQueryParam, "true")
+	resp, _, err := ss.GetAnnotations(opts)
+	if err != nil {
+		return nil, fmt.Errorf("getting active annotations: %v", err)
+	}
+	return resp.Response, nil
+}
@@ -20,18 +20,66 @@ package towrap
  */
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/apache/trafficcontrol/lib/go-tc/signing"
 	"github.com/apache/trafficcontrol/traffic_monitor/config"
 )
 
 func TestTrafficOpsSessionThreadsafeUpdateSetsNonNilSessions(t *testing.T) {
-	s := NewTrafficOpsSessionThreadsafe(nil, nil, 5, config.Config{})
-	err := s.Update("", "", "", true, "", false, 10*time.Second)
+	s := NewTrafficOpsSessionThreadsafe(nil, nil, 5, config.Config{}, nil)
+	err := s.Update("", Credentials{}, true, "", false, 10*time.Second)
 	if err == nil {
 		t.Error("expected an error, got nil")
 	} else if s.session == nil || *s.session == nil || s.legacySession == nil || *s.legacySession == nil {
 		t.Errorf("expected non-nil sessions after getting error from Update()")
 	}
 }
+
+func TestReadSignedCRConfigBackup(t *testing.T) {
+	privatePEM, publicPEM, err := signing.GenerateKey("cdn1")
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	key, err := signing.ParsePrivateKey(privatePEM)
+	if err != nil {
+		t.Fatalf("parsing private key: %v", err)
+	}
+	cdn, publicKey, err := signing.ParsePublicKey([]byte(publicPEM))
+	if err != nil {
+		t.Fatalf("parsing public key: %v", err)
+	}
+
+	backupFile := filepath.Join(t.TempDir(), "crconfig.backup")
+	s := NewTrafficOpsSessionThreadsafe(nil, nil, 5, config.Config{CRConfigBackupFile: backupFile}, &signing.Verifier{CDN: cdn, Key: publicKey})
+
+	crConfig := []byte(`{"stats":{"CDN_name":"cdn1"}}`)
+	signature, err := signing.Sign(key, crConfig)
+	if err != nil {
+		t.Fatalf("signing: %v", err)
+	}
+	if err := ioutil.WriteFile(backupFile, crConfig, 0644); err != nil {
+		t.Fatalf("writing backup file: %v", err)
+	}
+	if err := ioutil.WriteFile(backupFile+signatureFileSuffix, []byte(signature), 0644); err != nil {
+		t.Fatalf("writing backup signature file: %v", err)
+	}
+
+	b, sig, err := s.readCRConfigBackup()
+	if err != nil {
+		t.Fatalf("expected signed backup to be read, actual error: %v", err)
+	}
+	if string(b) != string(crConfig) || sig != signature {
+		t.Errorf("expected backup '%s' with signature '%s', actual: '%s' with signature '%s'", crConfig, signature, b, sig)
+	}
+
+	if err := ioutil.WriteFile(backupFile, []byte(`{"stats":{"CDN_name":"cdn2"}}`), 0644); err != nil {
+		t.Fatalf("writing backup file: %v", err)
+	}
+	if _, _, err := s.readCRConfigBackup(); err == nil {
+		t.Error("expected tampered backup to fail verification")
+	}
+}
@@ -0,0 +1,245 @@
+/*
+
+Name
+	config_import
+
+Synopsis
+	config_import --file value [--truncate] [--cfg value]
+
+Description
+  The config_import app imports a configuration export, as returned by the
+  Traffic Ops /system/export endpoint, into a Traffic Ops Database - to
+  restore it, or to clone the configuration of another environment.
+
+Options
+	--file
+        The path of the configuration export to import.
+
+	--truncate
+        Delete all the existing rows of the exported tables before importing.
+        Without this, rows which conflict with existing rows are skipped.
+
+	--cfg
+        The path for the configuration file. Default is `./config_import.conf`.
+
+*/
+
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	"github.com/lib/pq"
+)
+
+const PROPERTIES_FILE = "./config_import.conf"
+
+func main() {
+	file := flag.String("file", "", "The path of the configuration export to import.")
+	truncate := flag.Bool("truncate", false, "(Optional) Delete all the existing rows of the exported tables before importing.")
+	cfg := flag.String("cfg", PROPERTIES_FILE, "(Optional) The path for the configuration file. Default is "+PROPERTIES_FILE+".")
+	help := flag.Bool("help", false, "(Optional) Print usage information and exit.")
+	flag.Parse()
+
+	if *help {
+		flag.Usage()
+		os.Exit(0)
+	}
+	if *file == "" {
+		die("the --file option is required")
+	}
+
+	exportBytes, err := ioutil.ReadFile(*file)
+	if err != nil {
+		die("reading configuration export '" + *file + "': " + err.Error())
+	}
+	export, err := parseExport(exportBytes)
+	if err != nil {
+		die("parsing configuration export '" + *file + "': " + err.Error())
+	}
+
+	dbConfBytes, err := ioutil.ReadFile(*cfg)
+	if err != nil {
+		die("reading db conf '" + *cfg + "': " + err.Error())
+	}
+
+	pgCfg := Config{}
+	err = json.Unmarshal(dbConfBytes, &pgCfg)
+	if err != nil {
+		die("unmarshalling '" + *cfg + "': " + err.Error())
+	}
+
+	sslStr := "require"
+	if !pgCfg.SSL {
+		sslStr = "disable"
+	}
+	db, err := sql.Open("postgres", fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s&fallback_application_name=config_import", pgCfg.User, pgCfg.Password, pgCfg.Hostname, pgCfg.Port, pgCfg.DBName, sslStr))
+	if err != nil {
+		die("opening database: " + err.Error())
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		die("beginning transaction: " + err.Error())
+	}
+	if err := importConfig(tx, export, *truncate); err != nil {
+		tx.Rollback()
+		die("importing configuration: " + err.Error())
+	}
+	if err := tx.Commit(); err != nil {
+		die("committing transaction: " + err.Error())
+	}
+
+	fmt.Printf("Successfully imported the configuration exported by %s at %s.\n", export.ExportedBy, export.Timestamp)
+	if !export.IncludesSecrets {
+		fmt.Println("The export did not include secrets; user passwords, server ILO and XMPP passwords, log shipping credentials and the values of secure parameters must be set again.")
+	}
+	if len(export.VaultReferences) > 0 {
+		fmt.Printf("The configuration refers to %d secrets in Traffic Vault, which must be restored separately.\n", len(export.VaultReferences))
+	}
+}
+
+type Config struct {
+	DBName   string `json:"dbname"`
+	Hostname string `json:"hostname"`
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Port     int    `json:"port"`
+	SSL      bool   `json:"ssl"`
+}
+
+// tableNameRegex matches the names of the tables which may be imported,
+// which are interpolated into queries.
+var tableNameRegex = regexp.MustCompile(`^[a-z][a-z_]*$`)
+
+// parseExport parses a configuration export, either as the response of the
+// /system/export endpoint or as the export itself.
+func parseExport(data []byte) (tc.ConfigExport, error) {
+	resp := struct {
+		Response *tc.ConfigExport `json:"response"`
+	}{}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return tc.ConfigExport{}, err
+	}
+	export := tc.ConfigExport{}
+	if resp.Response != nil {
+		export = *resp.Response
+	} else if err := json.Unmarshal(data, &export); err != nil {
+		return tc.ConfigExport{}, err
+	}
+
+	if len(export.Tables) == 0 {
+		return tc.ConfigExport{}, errors.New("the export has no tables")
+	}
+	for _, table := range export.Tables {
+		if !tableNameRegex.MatchString(table.Name) {
+			return tc.ConfigExport{}, fmt.Errorf("invalid table name '%s'", table.Name)
+		}
+	}
+	return export, nil
+}
+
+func importConfig(tx *sql.Tx, export tc.ConfigExport, truncate bool) error {
+	var version int64
+	var dirty bool
+	if err := tx.QueryRow(`SELECT version, dirty FROM schema_migrations`).Scan(&version, &dirty); err != nil {
+		return fmt.Errorf("getting schema version: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("the database schema is dirty at version %d", version)
+	}
+	if version != export.SchemaVersion {
+		return fmt.Errorf("the configuration was exported from schema version %d, but the database is at version %d; migrate the databases to the same version first", export.SchemaVersion, version)
+	}
+
+	tables := make([]string, 0, len(export.Tables))
+	for _, table := range export.Tables {
+		tables = append(tables, pq.QuoteIdentifier(table.Name))
+	}
+	if truncate {
+		if _, err := tx.Exec(`TRUNCATE TABLE ` + strings.Join(tables, ", ") + ` CASCADE`); err != nil {
+			return fmt.Errorf("truncating tables: %w", err)
+		}
+	}
+
+	for i, table := range export.Tables {
+		res, err := tx.Exec(`INSERT INTO `+tables[i]+` SELECT * FROM jsonb_populate_recordset(NULL::`+tables[i]+`, $1::jsonb) ON CONFLICT DO NOTHING`, string(table.Rows))
+		if err != nil {
+			return fmt.Errorf("importing table %s: %w", table.Name, err)
+		}
+		imported, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("getting rows imported into table %s: %w", table.Name, err)
+		}
+		fmt.Printf("%s: imported %d rows\n", table.Name, imported)
+		if err := resetSequences(tx, table.Name); err != nil {
+			return fmt.Errorf("resetting sequences of table %s: %w", table.Name, err)
+		}
+	}
+	return nil
+}
+
+// resetSequences sets the sequences of the serial columns of the table past
+// the largest values imported, so that rows created afterward don't conflict.
+func resetSequences(tx *sql.Tx, table string) error {
+	rows, err := tx.Query(`SELECT column_name FROM information_schema.columns WHERE table_schema = 'public' AND table_name = $1 AND column_default LIKE 'nextval(%'`, table)
+	if err != nil {
+		return fmt.Errorf("querying serial columns: %w", err)
+	}
+	defer rows.Close()
+	columns := []string{}
+	for rows.Next() {
+		column := ""
+		if err := rows.Scan(&column); err != nil {
+			return fmt.Errorf("scanning serial column: %w", err)
+		}
+		columns = append(columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating over serial columns: %w", err)
+	}
+	rows.Close()
+
+	for _, column := range columns {
+		query := `SELECT setval(pg_get_serial_sequence($1, $2), COALESCE((SELECT MAX(` + pq.QuoteIdentifier(column) + `) FROM ` + pq.QuoteIdentifier(table) + `), 0) + 1, false)`
+		if _, err := tx.Exec(query, table, column); err != nil {
+			return fmt.Errorf("resetting sequence of column %s: %w", column, err)
+		}
+	}
+	return nil
+}
+
+func die(message string) {
+	fmt.Fprintln(os.Stderr, message)
+	os.Exit(1)
+}
@@ -0,0 +1,127 @@
+package main
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+const testExport = `{
+	"schemaVersion": 2022061912000000,
+	"timestamp": "2022-06-19T12:00:00Z",
+	"exportedBy": "admin",
+	"tenant": "root",
+	"includesSecrets": false,
+	"tables": [
+		{"name": "cdn", "rows": [{"id": 1, "name": "ALL", "domain_name": "-", "dnssec_enabled": false}]},
+		{"name": "type", "rows": []}
+	]
+}`
+
+func TestParseExport(t *testing.T) {
+	for name, data := range map[string]string{
+		"export":   testExport,
+		"response": `{"response": ` + testExport + `}`,
+	} {
+		export, err := parseExport([]byte(data))
+		if err != nil {
+			t.Errorf("unexpected error parsing %s: %v", name, err)
+			continue
+		}
+		if export.SchemaVersion != 2022061912000000 {
+			t.Errorf("expected %s to have schema version 2022061912000000, got %d", name, export.SchemaVersion)
+		}
+		if len(export.Tables) != 2 || export.Tables[0].Name != "cdn" || export.Tables[1].Name != "type" {
+			t.Errorf("expected %s to have tables cdn and type, got %+v", name, export.Tables)
+		}
+	}
+
+	for name, data := range map[string]string{
+		"no tables":     `{"schemaVersion": 1, "tables": []}`,
+		"invalid table": `{"schemaVersion": 1, "tables": [{"name": "cdn; DROP TABLE cdn", "rows": []}]}`,
+		"not JSON":      `schemaVersion: 1`,
+	} {
+		if _, err := parseExport([]byte(data)); err == nil {
+			t.Errorf("expected an error parsing an export with %s, got nil", name)
+		}
+	}
+}
+
+func TestImportConfig(t *testing.T) {
+	export, err := parseExport([]byte(testExport))
+	if err != nil {
+		t.Fatalf("unexpected error parsing export: %v", err)
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT version, dirty FROM schema_migrations").WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(2022061912000000, false))
+	mock.ExpectExec(`TRUNCATE TABLE "cdn", "type" CASCADE`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO "cdn"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("information_schema.columns").WithArgs("cdn").WillReturnRows(sqlmock.NewRows([]string{"column_name"}).AddRow("id"))
+	mock.ExpectExec("setval").WithArgs("cdn", "id").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO "type"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("information_schema.columns").WithArgs("type").WillReturnRows(sqlmock.NewRows([]string{"column_name"}))
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("unexpected error beginning transaction: %v", err)
+	}
+	if err := importConfig(tx, export, true); err != nil {
+		t.Errorf("unexpected error importing configuration: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
+
+func TestImportConfigSchemaMismatch(t *testing.T) {
+	export, err := parseExport([]byte(testExport))
+	if err != nil {
+		t.Fatalf("unexpected error parsing export: %v", err)
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT version, dirty FROM schema_migrations").WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(2022061812000000, false))
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("unexpected error beginning transaction: %v", err)
+	}
+	if err := importConfig(tx, export, false); err == nil {
+		t.Error("expected an error importing configuration exported from a different schema version, got nil")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+DO $$
+DECLARE
+    table_name TEXT;
+BEGIN
+    FOREACH table_name IN ARRAY ARRAY[
+        'asn', 'cachegroup', 'cachegroup_parameter', 'cdn', 'coordinate',
+        'deliveryservice', 'deliveryservice_server',
+        'deliveryservices_required_capability', 'division', 'federation',
+        'interface', 'ip_address', 'job', 'origin', 'parameter', 'phys_location',
+        'profile', 'profile_parameter', 'region', 'server',
+        'server_server_capability', 'service_category', 'staticdnsentry',
+        'status', 'steering_target', 'tenant', 'topology', 'topology_cachegroup',
+        'type'
+    ]
+    LOOP
+        EXECUTE FORMAT('DROP TRIGGER IF EXISTS record_change_event ON %I', table_name);
+    END LOOP;
+END
+$$;
+
+DROP TRIGGER IF EXISTS attribute_change_events ON public.log;
+DROP FUNCTION IF EXISTS public.attribute_change_events();
+DROP FUNCTION IF EXISTS public.record_change_event();
+DROP TABLE IF EXISTS public.change_event;
+DROP SEQUENCE IF EXISTS public.change_event_sequence;
@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+CREATE SEQUENCE IF NOT EXISTS public.change_event_sequence;
+
+-- Change events are assigned a sequence number only when Traffic Ops
+-- publishes them, after the transactions that recorded them have committed,
+-- so that sequence numbers become visible in order.
+CREATE TABLE IF NOT EXISTS public.change_event (
+    id bigserial PRIMARY KEY,
+    "sequence" bigint UNIQUE,
+    object_type text NOT NULL,
+    object_id text NOT NULL,
+    "action" text NOT NULL CHECK ("action" IN ('create', 'update', 'delete')),
+    changed_by text,
+    txid bigint NOT NULL DEFAULT txid_current(),
+    occurred timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS change_event_unpublished_idx ON public.change_event (txid) WHERE "sequence" IS NULL;
+CREATE INDEX IF NOT EXISTS change_event_occurred_idx ON public.change_event (occurred);
+
+-- record_change_event records the change to a row as an event on the object
+-- of type TG_ARGV[0] identified by the row's TG_ARGV[1] column (default
+-- 'id'), with the action TG_ARGV[2] if given (otherwise that of the
+-- statement). Only one event is recorded for each object in a transaction
+-- unless it's created or deleted.
+CREATE OR REPLACE FUNCTION public.record_change_event()
+    RETURNS trigger
+AS $$
+DECLARE
+    obj_id TEXT;
+    act TEXT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        obj_id := to_jsonb(OLD) ->> COALESCE(TG_ARGV[1], 'id');
+    ELSE
+        obj_id := to_jsonb(NEW) ->> COALESCE(TG_ARGV[1], 'id');
+    END IF;
+    act := COALESCE(TG_ARGV[2], CASE TG_OP WHEN 'INSERT' THEN 'create' WHEN 'UPDATE' THEN 'update' ELSE 'delete' END);
+
+    IF obj_id IS NULL OR EXISTS (
+        SELECT 1 FROM change_event
+        WHERE txid = txid_current()
+        AND "sequence" IS NULL
+        AND object_type = TG_ARGV[0]
+        AND object_id = obj_id
+        AND ("action" = act OR act = 'update')
+    ) THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO change_event (object_type, object_id, "action", changed_by)
+    VALUES (TG_ARGV[0], obj_id, act, NULLIF(current_setting('trafficops.changed_by', true), ''));
+    RETURN NULL;
+END;
+$$
+LANGUAGE plpgsql;
+
+-- attribute_change_events attributes the changes made in a transaction to
+-- the user whose change log entry is being inserted, since Traffic Ops logs
+-- every change it makes in the same transaction as the change.
+CREATE OR REPLACE FUNCTION public.attribute_change_events()
+    RETURNS trigger
+AS $$
+DECLARE
+    username TEXT;
+BEGIN
+    SELECT u.username INTO username FROM tm_user u WHERE u.id = NEW.tm_user;
+    IF username IS NULL THEN
+        RETURN NULL;
+    END IF;
+    PERFORM set_config('trafficops.changed_by', username, true);
+    UPDATE change_event SET changed_by = username
+    WHERE txid = txid_current()
+    AND "sequence" IS NULL
+    AND changed_by IS NULL;
+    RETURN NULL;
+END;
+$$
+LANGUAGE plpgsql;
+
+CREATE TRIGGER attribute_change_events
+    AFTER INSERT ON public.log
+    FOR EACH ROW EXECUTE PROCEDURE attribute_change_events();
+
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.asn FOR EACH ROW EXECUTE PROCEDURE record_change_event('asn');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.cachegroup FOR EACH ROW EXECUTE PROCEDURE record_change_event('cachegroup');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.cachegroup_parameter FOR EACH ROW EXECUTE PROCEDURE record_change_event('cachegroup', 'cachegroup', 'update');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.cdn FOR EACH ROW EXECUTE PROCEDURE record_change_event('cdn');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.coordinate FOR EACH ROW EXECUTE PROCEDURE record_change_event('coordinate');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.deliveryservice FOR EACH ROW EXECUTE PROCEDURE record_change_event('deliveryservice');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.deliveryservice_server FOR EACH ROW EXECUTE PROCEDURE record_change_event('deliveryservice', 'deliveryservice', 'update');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.deliveryservices_required_capability FOR EACH ROW EXECUTE PROCEDURE record_change_event('deliveryservice', 'deliveryservice_id', 'update');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.division FOR EACH ROW EXECUTE PROCEDURE record_change_event('division');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.federation FOR EACH ROW EXECUTE PROCEDURE record_change_event('federation');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.interface FOR EACH ROW EXECUTE PROCEDURE record_change_event('server', 'server', 'update');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.ip_address FOR EACH ROW EXECUTE PROCEDURE record_change_event('server', 'server', 'update');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.job FOR EACH ROW EXECUTE PROCEDURE record_change_event('job');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.origin FOR EACH ROW EXECUTE PROCEDURE record_change_event('origin');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.parameter FOR EACH ROW EXECUTE PROCEDURE record_change_event('parameter');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.phys_location FOR EACH ROW EXECUTE PROCEDURE record_change_event('phys_location');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.profile FOR EACH ROW EXECUTE PROCEDURE record_change_event('profile');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.profile_parameter FOR EACH ROW EXECUTE PROCEDURE record_change_event('profile', 'profile', 'update');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.region FOR EACH ROW EXECUTE PROCEDURE record_change_event('region');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.server FOR EACH ROW EXECUTE PROCEDURE record_change_event('server');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.server_server_capability FOR EACH ROW EXECUTE PROCEDURE record_change_event('server', 'server', 'update');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.service_category FOR EACH ROW EXECUTE PROCEDURE record_change_event('service_category', 'name');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.staticdnsentry FOR EACH ROW EXECUTE PROCEDURE record_change_event('staticdnsentry');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.status FOR EACH ROW EXECUTE PROCEDURE record_change_event('status');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.steering_target FOR EACH ROW EXECUTE PROCEDURE record_change_event('deliveryservice', 'deliveryservice', 'update');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.tenant FOR EACH ROW EXECUTE PROCEDURE record_change_event('tenant');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.topology FOR EACH ROW EXECUTE PROCEDURE record_change_event('topology', 'name');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.topology_cachegroup FOR EACH ROW EXECUTE PROCEDURE record_change_event('topology', 'topology', 'update');
+CREATE TRIGGER record_change_event AFTER INSERT OR UPDATE OR DELETE ON public.type FOR EACH ROW EXECUTE PROCEDURE record_change_event('type');
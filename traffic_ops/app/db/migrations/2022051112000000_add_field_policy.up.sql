@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- Each field policy protects a field of the objects of a type from
+-- modification by the users of a Role.
+CREATE TABLE IF NOT EXISTS public.field_policy (
+    id bigserial PRIMARY KEY,
+    "role" bigint NOT NULL REFERENCES public."role" (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    object_type text NOT NULL CHECK (object_type IN ('deliveryservice', 'server')),
+    field text NOT NULL,
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE ("role", object_type, field)
+);
+
+CREATE INDEX IF NOT EXISTS field_policy_last_updated_idx ON public.field_policy (last_updated DESC NULLS LAST);
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.field_policy
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+CREATE TRIGGER on_delete_current_timestamp
+AFTER DELETE ON public.field_policy
+FOR EACH ROW EXECUTE PROCEDURE on_delete_current_timestamp_last_updated('field_policy');
+
+INSERT INTO public.last_deleted (table_name) VALUES ('field_policy') ON CONFLICT (table_name) DO NOTHING;
@@ -0,0 +1,40 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- Each rewrite rule is one of an ordered list of match/replace rules applied
+-- to the request URLs of a Delivery Service by its edge-tier cache servers.
+CREATE TABLE IF NOT EXISTS public.deliveryservice_rewrite_rule (
+    deliveryservice bigint NOT NULL REFERENCES public.deliveryservice (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    "position" integer NOT NULL CHECK ("position" >= 0),
+    "match" text NOT NULL CHECK ("match" <> ''),
+    "replace" text NOT NULL CHECK ("replace" <> ''),
+    flags text[] NOT NULL DEFAULT '{}',
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (deliveryservice, "position")
+);
+
+CREATE INDEX IF NOT EXISTS deliveryservice_rewrite_rule_last_updated_idx ON public.deliveryservice_rewrite_rule (last_updated DESC NULLS LAST);
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.deliveryservice_rewrite_rule
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+CREATE TRIGGER on_delete_current_timestamp
+AFTER DELETE ON public.deliveryservice_rewrite_rule
+FOR EACH ROW EXECUTE PROCEDURE on_delete_current_timestamp_last_updated('deliveryservice_rewrite_rule');
+
+INSERT INTO public.last_deleted (table_name) VALUES ('deliveryservice_rewrite_rule') ON CONFLICT (table_name) DO NOTHING;
@@ -0,0 +1,53 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- The generation of a type of object is incremented whenever an object of
+-- that type changes, so that Traffic Ops instances can tell when the objects
+-- they've cached are stale.
+CREATE TABLE IF NOT EXISTS public.cache_generation (
+    object_type text PRIMARY KEY,
+    generation bigint NOT NULL DEFAULT 1,
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- bump_cache_generation increments the generation of the type of object
+-- TG_ARGV[0], or if not given, that of the change event being recorded, and
+-- notifies listening Traffic Ops instances once the transaction commits.
+CREATE OR REPLACE FUNCTION public.bump_cache_generation()
+    RETURNS trigger
+AS $$
+DECLARE
+    obj_type TEXT;
+BEGIN
+    obj_type := COALESCE(TG_ARGV[0], to_jsonb(NEW) ->> 'object_type');
+    IF obj_type IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO cache_generation (object_type)
+    VALUES (obj_type)
+    ON CONFLICT (object_type) DO UPDATE
+    SET generation = cache_generation.generation + 1,
+        last_updated = now();
+    PERFORM pg_notify('cache_generation', obj_type);
+    RETURN NULL;
+END;
+$$
+LANGUAGE plpgsql;
+
+CREATE TRIGGER bump_cache_generation AFTER INSERT ON public.change_event FOR EACH ROW EXECUTE PROCEDURE bump_cache_generation();
+CREATE TRIGGER bump_cache_generation AFTER INSERT OR UPDATE OR DELETE ON public.snapshot FOR EACH ROW EXECUTE PROCEDURE bump_cache_generation('snapshot');
@@ -0,0 +1,26 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- The coordinates of a Steering Target, when set, are used to order targets
+-- closest-first relative to the client, overriding the coordinates of the
+-- target's primary origin. The countries, when set, limit the target to
+-- clients geo-located in one of them.
+ALTER TABLE public.steering_target
+    ADD COLUMN IF NOT EXISTS latitude numeric CHECK (latitude >= -90 AND latitude <= 90),
+    ADD COLUMN IF NOT EXISTS longitude numeric CHECK (longitude >= -180 AND longitude <= 180),
+    ADD COLUMN IF NOT EXISTS geo_limit_countries text[] NOT NULL DEFAULT '{}',
+    ADD CONSTRAINT steering_target_coordinates_check CHECK ((latitude IS NULL) = (longitude IS NULL));
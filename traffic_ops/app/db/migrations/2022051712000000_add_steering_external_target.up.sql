@@ -0,0 +1,32 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- Each external target is an endpoint of a CDN outside of Traffic Control
+-- which is a target of a CLIENT_STEERING Delivery Service. Names are unique
+-- across all Delivery Services because Traffic Monitor reports the health of
+-- external targets by name.
+CREATE TABLE IF NOT EXISTS public.steering_external_target (
+    "name" text PRIMARY KEY CHECK ("name" <> ''),
+    deliveryservice bigint NOT NULL REFERENCES public.deliveryservice (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    fqdn text NOT NULL CHECK (fqdn <> ''),
+    health_check_url text NOT NULL CHECK (health_check_url <> ''),
+    "type" bigint NOT NULL REFERENCES public."type" (id),
+    "value" bigint NOT NULL CHECK ("value" >= 0),
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS steering_external_target_deliveryservice_idx ON public.steering_external_target (deliveryservice);
@@ -0,0 +1,34 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- The token authentication settings of a url_sig-signed Delivery Service.
+-- Its keys are kept in Traffic Vault; current_key is the index of the key
+-- signers should use, which is the one most recently rotated.
+CREATE TABLE IF NOT EXISTS public.deliveryservice_token_auth (
+    deliveryservice bigint PRIMARY KEY REFERENCES public.deliveryservice (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    algorithm text NOT NULL CHECK (algorithm IN ('HMAC-SHA1', 'HMAC-MD5')),
+    key_rotation_days bigint NOT NULL DEFAULT 0 CHECK (key_rotation_days >= 0),
+    clock_skew bigint NOT NULL DEFAULT 0 CHECK (clock_skew >= 0 AND clock_skew <= 3600),
+    exempt_paths text[] NOT NULL DEFAULT '{}',
+    current_key bigint NOT NULL DEFAULT 0 CHECK (current_key >= 0 AND current_key < 16),
+    last_key_rotation timestamp with time zone,
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.deliveryservice_token_auth
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
@@ -0,0 +1,34 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- The configuration with which cache servers ship the access logs of a
+-- Delivery Service to its tenant. scrub_rules is an array of objects with
+-- "field" and "action" properties.
+CREATE TABLE IF NOT EXISTS public.deliveryservice_log_shipping (
+    deliveryservice bigint PRIMARY KEY REFERENCES public.deliveryservice (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    destination_type text NOT NULL CHECK (destination_type IN ('S3', 'HTTPS', 'KAFKA')),
+    destination text NOT NULL,
+    credentials text,
+    format text NOT NULL DEFAULT 'json' CHECK (format IN ('json', 'squid')),
+    sample_rate double precision NOT NULL DEFAULT 1 CHECK (sample_rate > 0 AND sample_rate <= 1),
+    scrub_rules jsonb NOT NULL DEFAULT '[]',
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.deliveryservice_log_shipping
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
@@ -0,0 +1,26 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- Versions of the monitoring configuration Snapshots, from which Traffic
+-- Monitors request only the sections that changed since the version they
+-- have. monitoring_section_versions maps the name of each top-level section
+-- of the monitoring configuration to the version at which it last changed.
+CREATE SEQUENCE IF NOT EXISTS public.snapshot_monitoring_version_seq;
+
+ALTER TABLE public.snapshot
+    ADD COLUMN IF NOT EXISTS monitoring_version bigint NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS monitoring_section_versions jsonb NOT NULL DEFAULT '{}';
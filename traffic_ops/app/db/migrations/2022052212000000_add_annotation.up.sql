@@ -0,0 +1,54 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- Annotations are time-ranged notes, such as planned maintenance, attached to
+-- exactly one CDN, Cache Group, or Delivery Service.
+CREATE TABLE IF NOT EXISTS public.annotation (
+    id bigserial PRIMARY KEY,
+    description text NOT NULL CHECK (description <> ''),
+    start_time timestamp with time zone NOT NULL,
+    end_time timestamp with time zone NOT NULL,
+    cdn bigint REFERENCES public.cdn (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    cachegroup bigint REFERENCES public.cachegroup (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    deliveryservice bigint REFERENCES public.deliveryservice (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    created_by text NOT NULL,
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT annotation_time_range CHECK (end_time > start_time),
+    CONSTRAINT annotation_one_target CHECK (num_nonnulls(cdn, cachegroup, deliveryservice) = 1)
+);
+
+CREATE INDEX IF NOT EXISTS annotation_end_time_idx ON public.annotation (end_time);
+
+INSERT INTO public.role_capability
+SELECT id, perm FROM public.role
+CROSS JOIN (
+	VALUES
+		('ANNOTATION:READ')
+) AS perms(perm)
+WHERE priv_level >= 10
+ON CONFLICT DO NOTHING;
+
+INSERT INTO public.role_capability
+SELECT id, perm FROM public.role
+CROSS JOIN (
+	VALUES
+		('ANNOTATION:CREATE'),
+		('ANNOTATION:UPDATE'),
+		('ANNOTATION:DELETE')
+) AS perms(perm)
+WHERE priv_level >= 20
+ON CONFLICT DO NOTHING;
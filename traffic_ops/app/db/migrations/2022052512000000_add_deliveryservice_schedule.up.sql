@@ -0,0 +1,23 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+-- A Delivery Service with an active_at time is made active at that time, and
+-- one with an inactive_at time is made inactive at that time, after which the
+-- time is cleared.
+ALTER TABLE public.deliveryservice
+    ADD COLUMN IF NOT EXISTS active_at timestamp with time zone,
+    ADD COLUMN IF NOT EXISTS inactive_at timestamp with time zone,
+    ADD CONSTRAINT deliveryservice_schedule_check CHECK (active_at IS NULL OR inactive_at IS NULL OR inactive_at > active_at);
@@ -0,0 +1,41 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- A CDN's bounds on the TTLs of its Delivery Services' Static DNS Entries. A
+-- NULL bound leaves the TTLs unbounded in that direction.
+CREATE TABLE IF NOT EXISTS public.cdn_static_dns_ttl_policy (
+    cdn bigint PRIMARY KEY REFERENCES public.cdn (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    min_ttl bigint CHECK (min_ttl >= 0),
+    max_ttl bigint CHECK (max_ttl >= 0),
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+    CHECK (min_ttl IS NULL OR max_ttl IS NULL OR min_ttl <= max_ttl)
+);
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.cdn_static_dns_ttl_policy
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+INSERT INTO public.role_capability
+SELECT id, perm FROM public.role
+CROSS JOIN (
+	VALUES
+		('STATIC-DNS-TTL-POLICY:UPDATE'),
+		('STATIC-DNS-TTL-POLICY:DELETE'),
+		('STATIC-DNS-ENTRY:OVERRIDE-TTL-POLICY')
+) AS perms(perm)
+WHERE priv_level >= 30
+ON CONFLICT DO NOTHING;
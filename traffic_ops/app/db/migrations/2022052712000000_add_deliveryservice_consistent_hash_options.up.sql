@@ -0,0 +1,26 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+-- consistent_hash_include_path controls whether the request path is part of
+-- the key Traffic Router consistently hashes requests on,
+-- consistent_hash_headers lists the request headers that are part of it, and
+-- consistent_hash_replicas, if set, overrides the number of points each cache
+-- server has on the hash ring for the Delivery Service.
+ALTER TABLE public.deliveryservice
+    ADD COLUMN IF NOT EXISTS consistent_hash_include_path boolean NOT NULL DEFAULT TRUE,
+    ADD COLUMN IF NOT EXISTS consistent_hash_headers text[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS consistent_hash_replicas integer,
+    ADD CONSTRAINT deliveryservice_consistent_hash_replicas_check CHECK (consistent_hash_replicas IS NULL OR consistent_hash_replicas > 0);
@@ -0,0 +1,67 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- A rollout releases queued updates to the cache servers of a CDN a
+-- percentage of each Cache Group's servers at a time.
+CREATE TABLE IF NOT EXISTS public.rollout (
+    id bigserial PRIMARY KEY,
+    cdn bigint NOT NULL REFERENCES public.cdn (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    step_percent integer NOT NULL CHECK (step_percent > 0 AND step_percent <= 100),
+    soak_seconds integer NOT NULL DEFAULT 300 CHECK (soak_seconds >= 0),
+    step_timeout_seconds integer NOT NULL DEFAULT 1800 CHECK (step_timeout_seconds > 0),
+    status text NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'paused', 'halted', 'aborted', 'completed')),
+    current_step integer NOT NULL DEFAULT 0 CHECK (current_step >= 0),
+    step_count integer NOT NULL CHECK (step_count > 0),
+    step_started_at timestamp with time zone,
+    step_applied_at timestamp with time zone,
+    halt_reason text,
+    created_by text NOT NULL,
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT rollout_current_step_check CHECK (current_step <= step_count)
+);
+
+-- Only one rollout of a CDN may be in progress at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS rollout_cdn_in_progress_idx ON public.rollout (cdn)
+WHERE status IN ('running', 'paused', 'halted');
+
+-- The servers of a rollout, and the step in which each is released updates.
+CREATE TABLE IF NOT EXISTS public.rollout_server (
+    rollout bigint NOT NULL REFERENCES public.rollout (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    server bigint NOT NULL REFERENCES public.server (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    step integer NOT NULL CHECK (step > 0),
+    released_at timestamp with time zone,
+    PRIMARY KEY (rollout, server)
+);
+
+INSERT INTO public.role_capability
+SELECT id, perm FROM public.role
+CROSS JOIN (
+	VALUES
+		('ROLLOUT:READ')
+) AS perms(perm)
+WHERE priv_level >= 10
+ON CONFLICT DO NOTHING;
+
+INSERT INTO public.role_capability
+SELECT id, perm FROM public.role
+CROSS JOIN (
+	VALUES
+		('ROLLOUT:CREATE'),
+		('ROLLOUT:UPDATE')
+) AS perms(perm)
+WHERE priv_level >= 20
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,30 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- The outcome of the last t3c-apply run reported by each cache server.
+CREATE TABLE IF NOT EXISTS public.server_config_run (
+    server bigint PRIMARY KEY REFERENCES public.server (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    succeeded boolean NOT NULL,
+    config_update_time timestamp with time zone,
+    start_time timestamp with time zone NOT NULL,
+    duration_ms bigint NOT NULL CHECK (duration_ms >= 0),
+    files_changed text[] NOT NULL DEFAULT '{}',
+    errors text[] NOT NULL DEFAULT '{}',
+    t3c_version text NOT NULL DEFAULT '',
+    last_success timestamp with time zone,
+    reported_at timestamp with time zone NOT NULL DEFAULT now()
+);
@@ -0,0 +1,27 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+-- origin_tls_verify controls whether cache servers verify the certificates of
+-- a Delivery Service's origin, origin_tls_pinned_spki_hashes restricts the
+-- trusted certificates of its CA bundle to those with the given (base64
+-- SHA-256) public key hashes, and origin_tls_ca_bundle is the name of the
+-- GLOBAL Parameter holding that CA bundle.
+ALTER TABLE public.deliveryservice
+    ADD COLUMN IF NOT EXISTS origin_tls_verify boolean NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS origin_tls_pinned_spki_hashes text[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS origin_tls_ca_bundle text,
+    ADD CONSTRAINT deliveryservice_origin_tls_pins_check CHECK (origin_tls_verify OR cardinality(origin_tls_pinned_spki_hashes) = 0),
+    ADD CONSTRAINT deliveryservice_origin_tls_ca_bundle_check CHECK (origin_tls_verify OR origin_tls_ca_bundle IS NULL);
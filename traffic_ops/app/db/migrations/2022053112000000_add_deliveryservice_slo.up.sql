@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- The service level objectives of a Delivery Service, each of which is
+-- evaluated over the rolling window of the last window_hours hours. The
+-- availability and error rate targets are percentages.
+CREATE TABLE IF NOT EXISTS public.deliveryservice_slo (
+    deliveryservice bigint PRIMARY KEY REFERENCES public.deliveryservice (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    availability_target double precision CHECK (availability_target > 0 AND availability_target <= 100),
+    ttfb_p99_target_ms integer CHECK (ttfb_p99_target_ms > 0),
+    error_rate_target double precision CHECK (error_rate_target >= 0 AND error_rate_target <= 100),
+    window_hours integer NOT NULL DEFAULT 720 CHECK (window_hours > 0 AND window_hours <= 720),
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT deliveryservice_slo_has_target CHECK (availability_target IS NOT NULL OR ttfb_p99_target_ms IS NOT NULL OR error_rate_target IS NOT NULL)
+);
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.deliveryservice_slo
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+-- Whether Traffic Monitor reported a Delivery Service with service level
+-- objectives available, sampled each time they're evaluated.
+CREATE TABLE IF NOT EXISTS public.deliveryservice_slo_sample (
+    deliveryservice bigint NOT NULL REFERENCES public.deliveryservice (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    sampled_at timestamp with time zone NOT NULL,
+    available boolean NOT NULL,
+    PRIMARY KEY (deliveryservice, sampled_at)
+);
+
+-- The latest evaluation of the compliance of a Delivery Service with its
+-- service level objectives. objectives is an array of objects with
+-- "objective", "target", "actual", and "compliant" properties.
+CREATE TABLE IF NOT EXISTS public.deliveryservice_slo_compliance (
+    deliveryservice bigint PRIMARY KEY REFERENCES public.deliveryservice (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    evaluated_at timestamp with time zone NOT NULL,
+    window_start timestamp with time zone NOT NULL,
+    objectives jsonb NOT NULL DEFAULT '[]'
+);
+
+-- The periods in which a Delivery Service didn't meet one of its service
+-- level objectives. A violation that's ongoing has no end.
+CREATE TABLE IF NOT EXISTS public.deliveryservice_slo_violation (
+    id bigserial PRIMARY KEY,
+    deliveryservice bigint NOT NULL REFERENCES public.deliveryservice (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    objective text NOT NULL CHECK (objective IN ('availability', 'ttfbP99', 'errorRate')),
+    target double precision NOT NULL,
+    actual double precision NOT NULL,
+    started_at timestamp with time zone NOT NULL DEFAULT now(),
+    ended_at timestamp with time zone,
+    CONSTRAINT deliveryservice_slo_violation_ended_check CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+-- A Delivery Service may only have one ongoing violation of each objective.
+CREATE UNIQUE INDEX IF NOT EXISTS deliveryservice_slo_violation_ongoing_idx ON public.deliveryservice_slo_violation (deliveryservice, objective)
+WHERE ended_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS deliveryservice_slo_violation_started_at_idx ON public.deliveryservice_slo_violation (deliveryservice, started_at);
@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- The read-only view that the users of a Tenant, and of its descendants, have
+-- of the Delivery Services of the Tenants in
+-- tenant_read_view_tenant - and of their descendants - beyond those of their
+-- own Tenancy. The named fields are removed from those Delivery Services, and
+-- from all servers, when they're read by those users.
+CREATE TABLE IF NOT EXISTS public.tenant_read_view (
+    tenant bigint PRIMARY KEY REFERENCES public.tenant (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    redacted_deliveryservice_fields text[] NOT NULL DEFAULT '{}',
+    redacted_server_fields text[] NOT NULL DEFAULT '{}',
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.tenant_read_view
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+CREATE TABLE IF NOT EXISTS public.tenant_read_view_tenant (
+    tenant bigint NOT NULL REFERENCES public.tenant_read_view (tenant) ON UPDATE CASCADE ON DELETE CASCADE,
+    viewed_tenant bigint NOT NULL REFERENCES public.tenant (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY (tenant, viewed_tenant),
+    CONSTRAINT tenant_read_view_tenant_not_self CHECK (tenant <> viewed_tenant)
+);
@@ -0,0 +1,65 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- Comments are threaded notes, such as operational context, attached to
+-- exactly one Delivery Service, server, or Cache Group. Replies belong to the
+-- same object as the comments to which they reply.
+CREATE TABLE IF NOT EXISTS public.comment (
+    id bigserial PRIMARY KEY,
+    parent bigint REFERENCES public.comment (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    deliveryservice bigint REFERENCES public.deliveryservice (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    server bigint REFERENCES public.server (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    cachegroup bigint REFERENCES public.cachegroup (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    author text NOT NULL,
+    value text NOT NULL CHECK (value <> ''),
+    created timestamp with time zone NOT NULL DEFAULT now(),
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT comment_one_target CHECK (num_nonnulls(deliveryservice, server, cachegroup) = 1)
+);
+
+CREATE INDEX IF NOT EXISTS comment_deliveryservice_idx ON public.comment (deliveryservice);
+CREATE INDEX IF NOT EXISTS comment_server_idx ON public.comment (server);
+CREATE INDEX IF NOT EXISTS comment_cachegroup_idx ON public.comment (cachegroup);
+
+-- The users mentioned in comments, by "@username".
+CREATE TABLE IF NOT EXISTS public.comment_mention (
+    comment bigint NOT NULL REFERENCES public.comment (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    tm_user bigint NOT NULL REFERENCES public.tm_user (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY (comment, tm_user)
+);
+
+CREATE INDEX IF NOT EXISTS comment_mention_tm_user_idx ON public.comment_mention (tm_user);
+
+INSERT INTO public.role_capability
+SELECT id, perm FROM public.role
+CROSS JOIN (
+	VALUES
+		('COMMENT:READ')
+) AS perms(perm)
+WHERE priv_level >= 10
+ON CONFLICT DO NOTHING;
+
+INSERT INTO public.role_capability
+SELECT id, perm FROM public.role
+CROSS JOIN (
+	VALUES
+		('COMMENT:CREATE'),
+		('COMMENT:UPDATE'),
+		('COMMENT:DELETE')
+) AS perms(perm)
+WHERE priv_level >= 15
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,31 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- The deletions of orphaned objects found by /reports/orphans, staged for
+-- review before they're carried out. The objects aren't referenced by foreign
+-- keys, because they're of several tables; deletions of objects which no
+-- longer exist, or are no longer orphaned, are skipped when applied.
+CREATE TABLE IF NOT EXISTS public.orphan_deletion (
+    id bigserial PRIMARY KEY,
+    object_type text NOT NULL CHECK (object_type IN ('parameter', 'profile', 'staticdnsentry', 'cachegroup', 'regex')),
+    object_id bigint NOT NULL,
+    object_name text NOT NULL,
+    reason text NOT NULL,
+    staged_by text NOT NULL,
+    created timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT orphan_deletion_object_unique UNIQUE (object_type, object_id)
+);
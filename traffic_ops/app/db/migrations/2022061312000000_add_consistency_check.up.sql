@@ -0,0 +1,44 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- The single row of consistency_check_run records when the consistency
+-- checker last ran; it's locked by the Traffic Ops instance running it.
+CREATE TABLE IF NOT EXISTS public.consistency_check_run (
+    id integer PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    checked_at timestamp with time zone
+);
+
+INSERT INTO public.consistency_check_run (id, checked_at) VALUES (1, NULL) ON CONFLICT DO NOTHING;
+
+-- The violations found by the last run of the consistency checker.
+CREATE TABLE IF NOT EXISTS public.consistency_violation (
+    check_name text NOT NULL,
+    subject text NOT NULL,
+    message text NOT NULL,
+    first_detected timestamp with time zone NOT NULL,
+    last_detected timestamp with time zone NOT NULL,
+    PRIMARY KEY (check_name, subject)
+);
+
+INSERT INTO public.role_capability
+SELECT id, perm FROM public.role
+CROSS JOIN (
+	VALUES
+		('CONSISTENCY:READ')
+) AS perms(perm)
+WHERE priv_level >= 20
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,26 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+-- compress_gzip and compress_brotli control whether edge-tier cache servers
+-- compress a Delivery Service's responses with each algorithm,
+-- compress_content_types restricts compression to responses with the given
+-- MIME types, and compress_min_size is the minimum size, in bytes, of the
+-- responses that are compressed.
+ALTER TABLE public.deliveryservice
+    ADD COLUMN IF NOT EXISTS compress_gzip boolean NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS compress_brotli boolean NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS compress_content_types text[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS compress_min_size integer CHECK (compress_min_size >= 0);
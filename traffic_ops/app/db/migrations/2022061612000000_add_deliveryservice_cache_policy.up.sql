@@ -0,0 +1,45 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+-- A cache policy overrides the caching instructions of a Delivery Service's
+-- origin. A NULL honor_vary means that all of the request headers in the
+-- origin's Vary response header are honored.
+CREATE TABLE IF NOT EXISTS public.deliveryservice_cache_policy (
+    deliveryservice bigint PRIMARY KEY REFERENCES public.deliveryservice (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    ignore_origin_no_cache boolean NOT NULL DEFAULT FALSE,
+    max_ttl integer CHECK (max_ttl > 0),
+    honor_vary text[],
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- Each default TTL is the time, in seconds, for which responses with a status
+-- code are cached when they don't have a Cache-Control header.
+CREATE TABLE IF NOT EXISTS public.deliveryservice_cache_policy_ttl (
+    deliveryservice bigint NOT NULL REFERENCES public.deliveryservice_cache_policy (deliveryservice) ON UPDATE CASCADE ON DELETE CASCADE,
+    status_code integer NOT NULL CHECK (status_code BETWEEN 200 AND 599),
+    ttl integer NOT NULL CHECK (ttl >= 0),
+    PRIMARY KEY (deliveryservice, status_code)
+);
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.deliveryservice_cache_policy
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+CREATE TRIGGER on_delete_current_timestamp
+AFTER DELETE ON public.deliveryservice_cache_policy
+FOR EACH ROW EXECUTE PROCEDURE on_delete_current_timestamp_last_updated('deliveryservice_cache_policy');
+
+INSERT INTO public.last_deleted (table_name) VALUES ('deliveryservice_cache_policy') ON CONFLICT (table_name) DO NOTHING;
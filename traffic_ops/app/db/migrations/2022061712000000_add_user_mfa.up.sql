@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+-- A user's TOTP secret is only used to log in once it's been confirmed with a
+-- code generated from it. A pending secret is one which is being enrolled, and
+-- replaces the confirmed secret when confirmed. last_used_step is the time step
+-- of the last code used to log in, which can't be used again.
+CREATE TABLE IF NOT EXISTS public.user_mfa_totp (
+    user_id bigint PRIMARY KEY REFERENCES public.tm_user (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    secret text,
+    pending_secret text,
+    last_used_step bigint NOT NULL DEFAULT 0,
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- Recovery codes are stored as their SHA-256 hashes, and each is deleted once
+-- it's been used to log in.
+CREATE TABLE IF NOT EXISTS public.user_mfa_recovery_code (
+    user_id bigint NOT NULL REFERENCES public.tm_user (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    code_hash text NOT NULL,
+    PRIMARY KEY (user_id, code_hash)
+);
+
+-- public_key is the uncompressed P-256 point of an ES256 WebAuthn credential.
+CREATE TABLE IF NOT EXISTS public.user_webauthn_credential (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL REFERENCES public.tm_user (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    credential_id text NOT NULL UNIQUE,
+    name text NOT NULL,
+    public_key bytea NOT NULL,
+    sign_count bigint NOT NULL DEFAULT 0,
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE (user_id, name)
+);
+
+-- Failed attempts to give a second factor, after too many of which a user
+-- can't try again for a while.
+CREATE TABLE IF NOT EXISTS public.user_mfa_attempt (
+    user_id bigint PRIMARY KEY REFERENCES public.tm_user (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    failed_attempts integer NOT NULL DEFAULT 0,
+    last_failure timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.user_mfa_totp
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.user_webauthn_credential
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+CREATE TABLE IF NOT EXISTS public.api_usage (
+	"day" date NOT NULL,
+	username text NOT NULL,
+	credential text NOT NULL,
+	route_id bigint NOT NULL,
+	route text NOT NULL,
+	calls bigint NOT NULL DEFAULT 0,
+	bytes_in bigint NOT NULL DEFAULT 0,
+	bytes_out bigint NOT NULL DEFAULT 0,
+	last_call timestamp with time zone NOT NULL,
+	PRIMARY KEY ("day", username, credential, route_id)
+);
+
+CREATE INDEX IF NOT EXISTS api_usage_username_idx ON public.api_usage (username);
+
+INSERT INTO public.role_capability
+SELECT id, perm FROM public.role
+CROSS JOIN (
+	VALUES
+		('API-USAGE:READ')
+) AS perms(perm)
+WHERE priv_level >= 30
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,23 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+-- cert_status is the status of the certificate Traffic Ops obtains for the
+-- HTTPS Delivery Service of a request when it's completed, and
+-- cert_async_status_id identifies the asynchronous job that obtains it.
+ALTER TABLE public.deliveryservice_request
+    ADD COLUMN IF NOT EXISTS cert_status text,
+    ADD COLUMN IF NOT EXISTS cert_async_status_id bigint REFERENCES public.async_status (id) ON DELETE SET NULL,
+    ADD CONSTRAINT deliveryservice_request_cert_status_check CHECK (cert_status IS NULL OR cert_status IN ('issuing', 'issued', 'failed'));
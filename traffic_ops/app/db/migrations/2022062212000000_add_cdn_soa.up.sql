@@ -0,0 +1,48 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- The SOA of a CDN's zones and the TTLs of their SOA and NS records, which
+-- take the place of the tld.soa.* and tld.ttls.SOA/NS Parameters in the CDN's
+-- snapshots. serial is only used under the 'increment' serial policy.
+CREATE TABLE IF NOT EXISTS public.cdn_soa (
+    cdn bigint PRIMARY KEY REFERENCES public.cdn (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    admin text NOT NULL,
+    serial_policy text NOT NULL DEFAULT 'date' CHECK (serial_policy IN ('date', 'increment')),
+    serial bigint NOT NULL DEFAULT 0 CHECK (serial >= 0),
+    refresh bigint NOT NULL CHECK (refresh > 0),
+    retry bigint NOT NULL CHECK (retry > 0),
+    expire bigint NOT NULL CHECK (expire > 0),
+    negative_ttl bigint NOT NULL CHECK (negative_ttl >= 0),
+    soa_ttl bigint NOT NULL CHECK (soa_ttl > 0),
+    ns_ttl bigint NOT NULL CHECK (ns_ttl > 0),
+    nameservers text[] NOT NULL DEFAULT '{}',
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.cdn_soa
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+INSERT INTO public.role_capability
+SELECT id, perm FROM public.role
+CROSS JOIN (
+	VALUES
+		('CDN-SOA:UPDATE'),
+		('CDN-SOA:DELETE')
+) AS perms(perm)
+WHERE priv_level >= 30
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,43 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+DROP TRIGGER IF EXISTS record_config_history ON public.deliveryservice;
+DROP TRIGGER IF EXISTS record_config_history ON public.parameter;
+DROP TRIGGER IF EXISTS record_config_history ON public.server;
+DROP FUNCTION IF EXISTS public.record_config_history();
+
+CREATE OR REPLACE FUNCTION public.attribute_change_events()
+    RETURNS trigger
+AS $$
+DECLARE
+    username TEXT;
+BEGIN
+    SELECT u.username INTO username FROM tm_user u WHERE u.id = NEW.tm_user;
+    IF username IS NULL THEN
+        RETURN NULL;
+    END IF;
+    PERFORM set_config('trafficops.changed_by', username, true);
+    UPDATE change_event SET changed_by = username
+    WHERE txid = txid_current()
+    AND "sequence" IS NULL
+    AND changed_by IS NULL;
+    RETURN NULL;
+END;
+$$
+LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS public.config_history;
@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- config_history holds the versions of Delivery Services, servers and
+-- Parameters that have been changed or deleted, so that they can be read as
+-- they were at some time in the past. Each version is the whole row, as
+-- JSON, which was in effect from valid_from - the row's last_updated - until
+-- valid_to, when it was changed or deleted. The current version of each row
+-- is in its own table.
+CREATE TABLE IF NOT EXISTS public.config_history (
+    id bigserial PRIMARY KEY,
+    table_name text NOT NULL,
+    row_id bigint NOT NULL,
+    valid_from timestamp with time zone NOT NULL,
+    valid_to timestamp with time zone NOT NULL DEFAULT now(),
+    changed_by text,
+    txid bigint NOT NULL DEFAULT txid_current(),
+    row_data jsonb NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS config_history_table_name_valid_idx ON public.config_history (table_name, valid_to, valid_from);
+CREATE INDEX IF NOT EXISTS config_history_table_name_row_id_idx ON public.config_history (table_name, row_id);
+
+-- record_config_history records the version of a row that's being changed or
+-- deleted.
+CREATE OR REPLACE FUNCTION public.record_config_history()
+    RETURNS trigger
+AS $$
+BEGIN
+    INSERT INTO config_history (table_name, row_id, valid_from, changed_by, row_data)
+    VALUES (TG_TABLE_NAME, OLD.id, OLD.last_updated, NULLIF(current_setting('trafficops.changed_by', true), ''), to_jsonb(OLD));
+    RETURN NULL;
+END;
+$$
+LANGUAGE plpgsql;
+
+-- Traffic Ops logs changes after making them, so versions are attributed to
+-- users the same way as change events.
+CREATE OR REPLACE FUNCTION public.attribute_change_events()
+    RETURNS trigger
+AS $$
+DECLARE
+    username TEXT;
+BEGIN
+    SELECT u.username INTO username FROM tm_user u WHERE u.id = NEW.tm_user;
+    IF username IS NULL THEN
+        RETURN NULL;
+    END IF;
+    PERFORM set_config('trafficops.changed_by', username, true);
+    UPDATE change_event SET changed_by = username
+    WHERE txid = txid_current()
+    AND "sequence" IS NULL
+    AND changed_by IS NULL;
+    UPDATE config_history SET changed_by = username
+    WHERE txid = txid_current()
+    AND changed_by IS NULL;
+    RETURN NULL;
+END;
+$$
+LANGUAGE plpgsql;
+
+CREATE TRIGGER record_config_history AFTER UPDATE OR DELETE ON public.deliveryservice FOR EACH ROW EXECUTE PROCEDURE record_config_history();
+CREATE TRIGGER record_config_history AFTER UPDATE OR DELETE ON public.parameter FOR EACH ROW EXECUTE PROCEDURE record_config_history();
+CREATE TRIGGER record_config_history AFTER UPDATE OR DELETE ON public.server FOR EACH ROW EXECUTE PROCEDURE record_config_history();
@@ -0,0 +1,47 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- A CDN's policy for taking Snapshots automatically, on behalf of the user who
+-- last set it, when changes have been pending for interval seconds since the
+-- last Snapshot, or none have been made for quiet_period seconds. More than
+-- max_changes pending changes abort the Snapshot instead.
+CREATE TABLE IF NOT EXISTS public.cdn_snapshot_policy (
+    cdn bigint PRIMARY KEY REFERENCES public.cdn (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    "interval" bigint CHECK ("interval" >= 60),
+    quiet_period bigint CHECK (quiet_period >= 60),
+    max_changes bigint CHECK (max_changes > 0),
+    notify boolean NOT NULL DEFAULT FALSE,
+    tm_user bigint REFERENCES public.tm_user (id) ON UPDATE CASCADE ON DELETE SET NULL,
+    last_auto_snapshot timestamp with time zone,
+    last_aborted timestamp with time zone,
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+    CHECK ("interval" IS NOT NULL OR quiet_period IS NOT NULL)
+);
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.cdn_snapshot_policy
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+INSERT INTO public.role_capability
+SELECT id, perm FROM public.role
+CROSS JOIN (
+	VALUES
+		('SNAPSHOT-POLICY:UPDATE'),
+		('SNAPSHOT-POLICY:DELETE')
+) AS perms(perm)
+WHERE priv_level >= 30
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,24 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+-- trace_header_policy is how cache servers treat the request header named
+-- trace_header_name, which carries the identifier used to trace a request for
+-- a Delivery Service through the CDN: 'generate' gives requests without one a
+-- new identifier, 'propagate' passes on those that clients send, 'strip'
+-- removes it, and 'none' - like NULL - treats it as any other header.
+ALTER TABLE public.deliveryservice
+    ADD COLUMN IF NOT EXISTS trace_header_policy text CHECK (trace_header_policy IN ('none', 'generate', 'propagate', 'strip')),
+    ADD COLUMN IF NOT EXISTS trace_header_name text;
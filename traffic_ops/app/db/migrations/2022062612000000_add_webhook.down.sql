@@ -0,0 +1,61 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+DELETE FROM public.role_capability
+WHERE cap_name IN (
+	VALUES
+		('WEBHOOK:READ'),
+		('WEBHOOK:CREATE'),
+		('WEBHOOK:UPDATE'),
+		('WEBHOOK:DELETE')
+);
+
+DROP TABLE IF EXISTS public.webhook;
+
+CREATE OR REPLACE FUNCTION public.record_change_event()
+    RETURNS trigger
+AS $$
+DECLARE
+    obj_id TEXT;
+    act TEXT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        obj_id := to_jsonb(OLD) ->> COALESCE(TG_ARGV[1], 'id');
+    ELSE
+        obj_id := to_jsonb(NEW) ->> COALESCE(TG_ARGV[1], 'id');
+    END IF;
+    act := COALESCE(TG_ARGV[2], CASE TG_OP WHEN 'INSERT' THEN 'create' WHEN 'UPDATE' THEN 'update' ELSE 'delete' END);
+
+    IF obj_id IS NULL OR EXISTS (
+        SELECT 1 FROM change_event
+        WHERE txid = txid_current()
+        AND "sequence" IS NULL
+        AND object_type = TG_ARGV[0]
+        AND object_id = obj_id
+        AND ("action" = act OR act = 'update')
+    ) THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO change_event (object_type, object_id, "action", changed_by)
+    VALUES (TG_ARGV[0], obj_id, act, NULLIF(current_setting('trafficops.changed_by', true), ''));
+    RETURN NULL;
+END;
+$$
+LANGUAGE plpgsql;
+
+ALTER TABLE public.change_event DROP COLUMN IF EXISTS cdn;
@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- The name of the CDN of the changed object, if it has one, so that change
+-- events can be filtered by CDN. Because it's recorded with the event, it's
+-- known even after the object is deleted.
+ALTER TABLE public.change_event ADD COLUMN IF NOT EXISTS cdn text;
+
+CREATE OR REPLACE FUNCTION public.record_change_event()
+    RETURNS trigger
+AS $$
+DECLARE
+    obj JSONB;
+    obj_id TEXT;
+    act TEXT;
+    obj_cdn BIGINT;
+    cdn_name TEXT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        obj := to_jsonb(OLD);
+    ELSE
+        obj := to_jsonb(NEW);
+    END IF;
+    obj_id := obj ->> COALESCE(TG_ARGV[1], 'id');
+    act := COALESCE(TG_ARGV[2], CASE TG_OP WHEN 'INSERT' THEN 'create' WHEN 'UPDATE' THEN 'update' ELSE 'delete' END);
+
+    IF obj_id IS NULL OR EXISTS (
+        SELECT 1 FROM change_event
+        WHERE txid = txid_current()
+        AND "sequence" IS NULL
+        AND object_type = TG_ARGV[0]
+        AND object_id = obj_id
+        AND ("action" = act OR act = 'update')
+    ) THEN
+        RETURN NULL;
+    END IF;
+
+    -- Rows of the objects' own tables are preferred to looking the objects
+    -- up, because deleted objects can't be looked up.
+    IF TG_ARGV[0] = 'cdn' THEN
+        cdn_name := obj ->> 'name';
+    ELSE
+        obj_cdn := CASE
+            WHEN obj ? 'cdn_id' THEN (obj ->> 'cdn_id')::bigint
+            WHEN TG_ARGV[0] = 'profile' AND obj ? 'cdn' THEN (obj ->> 'cdn')::bigint
+            WHEN TG_ARGV[0] = 'profile' THEN (SELECT p.cdn FROM profile AS p WHERE p.id = obj_id::bigint)
+            WHEN TG_ARGV[0] = 'server' THEN (SELECT s.cdn_id FROM server AS s WHERE s.id = obj_id::bigint)
+            WHEN TG_ARGV[0] = 'deliveryservice' THEN (SELECT ds.cdn_id FROM deliveryservice AS ds WHERE ds.id = obj_id::bigint)
+            WHEN TG_ARGV[0] IN ('staticdnsentry', 'origin') THEN (SELECT ds.cdn_id FROM deliveryservice AS ds WHERE ds.id = (obj ->> 'deliveryservice')::bigint)
+            WHEN TG_ARGV[0] = 'job' THEN (SELECT ds.cdn_id FROM deliveryservice AS ds WHERE ds.id = (obj ->> 'job_deliveryservice')::bigint)
+        END;
+        IF obj_cdn IS NOT NULL THEN
+            SELECT c.name INTO cdn_name FROM cdn AS c WHERE c.id = obj_cdn;
+        END IF;
+    END IF;
+
+    INSERT INTO change_event (object_type, object_id, "action", changed_by, cdn)
+    VALUES (TG_ARGV[0], obj_id, act, NULLIF(current_setting('trafficops.changed_by', true), ''), cdn_name);
+    RETURN NULL;
+END;
+$$
+LANGUAGE plpgsql;
+
+-- Webhooks are URLs to which Traffic Ops posts the change events matching
+-- their filters - empty filters match every event - signed with their
+-- secrets. Each is sent the events following last_sequence in order; an
+-- event which can't be delivered is retried at next_attempt, with a backoff
+-- depending on the number of failed_attempts.
+CREATE TABLE IF NOT EXISTS public.webhook (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL UNIQUE CHECK (name <> ''),
+    url text NOT NULL CHECK (url <> ''),
+    secret text NOT NULL CHECK (secret <> ''),
+    object_types text[] NOT NULL DEFAULT '{}',
+    cdns text[] NOT NULL DEFAULT '{}',
+    actions text[] NOT NULL DEFAULT '{}' CHECK (actions <@ ARRAY['create', 'update', 'delete']),
+    active boolean NOT NULL DEFAULT TRUE,
+    last_sequence bigint NOT NULL DEFAULT 0,
+    failed_attempts integer NOT NULL DEFAULT 0,
+    next_attempt timestamp with time zone,
+    last_error text,
+    last_delivered timestamp with time zone,
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+INSERT INTO public.role_capability
+SELECT id, perm FROM public.role
+CROSS JOIN (
+	VALUES
+		('WEBHOOK:READ'),
+		('WEBHOOK:CREATE'),
+		('WEBHOOK:UPDATE'),
+		('WEBHOOK:DELETE')
+) AS perms(perm)
+WHERE priv_level >= 30
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,31 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- notify_change_event notifies listening Traffic Ops instances that change
+-- events were recorded, once the transaction that recorded them commits, so
+-- that they're published and streamed right away.
+CREATE OR REPLACE FUNCTION public.notify_change_event()
+    RETURNS trigger
+AS $$
+BEGIN
+    PERFORM pg_notify('change_event', 'recorded');
+    RETURN NULL;
+END;
+$$
+LANGUAGE plpgsql;
+
+CREATE TRIGGER notify_change_event AFTER INSERT ON public.change_event FOR EACH STATEMENT EXECUTE PROCEDURE notify_change_event();
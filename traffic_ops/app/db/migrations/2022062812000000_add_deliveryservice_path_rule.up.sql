@@ -0,0 +1,39 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+-- A path rule routes the requests for a Delivery Service whose paths begin
+-- with its path prefix to a different origin, and/or caches their responses
+-- differently. A NULL origin_url means the Delivery Service's own origin.
+CREATE TABLE IF NOT EXISTS public.deliveryservice_path_rule (
+    deliveryservice bigint NOT NULL REFERENCES public.deliveryservice (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    path_prefix text NOT NULL CHECK (path_prefix ~ '^/\S+/$'),
+    origin_url text,
+    no_cache boolean NOT NULL DEFAULT FALSE,
+    max_ttl integer CHECK (max_ttl > 0),
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (deliveryservice, path_prefix),
+    CHECK (NOT (no_cache AND max_ttl IS NOT NULL))
+);
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.deliveryservice_path_rule
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+CREATE TRIGGER on_delete_current_timestamp
+AFTER DELETE ON public.deliveryservice_path_rule
+FOR EACH ROW EXECUTE PROCEDURE on_delete_current_timestamp_last_updated('deliveryservice_path_rule');
+
+INSERT INTO public.last_deleted (table_name) VALUES ('deliveryservice_path_rule') ON CONFLICT (table_name) DO NOTHING;
@@ -0,0 +1,57 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+-- Changesets are groups of edits to objects which are staged to be reviewed
+-- before they're committed together in a single transaction, or discarded.
+CREATE TABLE IF NOT EXISTS public.changeset (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL CHECK (name <> ''),
+    description text NOT NULL DEFAULT '',
+    status text NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'committed', 'discarded')),
+    author text REFERENCES public.tm_user (username) ON UPDATE CASCADE ON DELETE SET NULL,
+    closed_by text REFERENCES public.tm_user (username) ON UPDATE CASCADE ON DELETE SET NULL,
+    closed timestamp with time zone,
+    created timestamp with time zone NOT NULL DEFAULT now(),
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+-- The edits of a changeset are applied in the order of their IDs. Objects are
+-- created and updated from bodies exactly like those of the requests that
+-- would create or update them directly.
+CREATE TABLE IF NOT EXISTS public.changeset_edit (
+    id bigserial PRIMARY KEY,
+    changeset bigint NOT NULL REFERENCES public.changeset (id) ON DELETE CASCADE,
+    object_type text NOT NULL,
+    "action" text NOT NULL CHECK ("action" IN ('create', 'update', 'delete')),
+    object_id bigint CHECK ((object_id IS NULL) = ("action" = 'create')),
+    body jsonb CHECK ((body IS NULL) = ("action" = 'delete')),
+    created timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS changeset_edit_changeset_idx ON public.changeset_edit (changeset);
+
+INSERT INTO public.role_capability
+SELECT id, perm FROM public.role
+CROSS JOIN (
+	VALUES
+		('CHANGESET:READ'),
+		('CHANGESET:CREATE'),
+		('CHANGESET:UPDATE'),
+		('CHANGESET:DELETE'),
+		('CHANGESET:COMMIT')
+) AS perms(perm)
+WHERE priv_level >= 20
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,29 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+-- The limits on the numbers of Delivery Services and users a Tenant may have,
+-- not counting those of its descendants. A NULL limit is no limit.
+CREATE TABLE IF NOT EXISTS public.tenant_quota (
+    tenant bigint PRIMARY KEY REFERENCES public.tenant (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    max_deliveryservices bigint CHECK (max_deliveryservices >= 0),
+    max_users bigint CHECK (max_users >= 0),
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT tenant_quota_limits CHECK (max_deliveryservices IS NOT NULL OR max_users IS NOT NULL)
+);
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.tenant_quota
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
@@ -0,0 +1,28 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- The Coverage Zone File of a CDN, which its Traffic Routers can poll from
+-- Traffic Ops in place of one hosted elsewhere.
+CREATE TABLE IF NOT EXISTS public.coverage_zone_file (
+    cdn bigint PRIMARY KEY REFERENCES public.cdn (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    file jsonb NOT NULL,
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.coverage_zone_file
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
@@ -0,0 +1,42 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- The cache hits and misses of a Delivery Service on a cache server in a
+-- window of time, collected from the server's t3c-log-agent. The tier is that
+-- of the server when they were collected. miss_samples is an array of objects
+-- with "url" and "count" properties, of the most missed URLs.
+CREATE TABLE IF NOT EXISTS public.deliveryservice_cache_hits (
+    deliveryservice bigint NOT NULL REFERENCES public.deliveryservice (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    server bigint NOT NULL REFERENCES public.server (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    tier text NOT NULL CHECK (tier IN ('EDGE', 'MID')),
+    window_start timestamp with time zone NOT NULL,
+    window_end timestamp with time zone NOT NULL,
+    hits bigint NOT NULL CHECK (hits >= 0),
+    misses bigint NOT NULL CHECK (misses >= 0),
+    miss_samples jsonb NOT NULL DEFAULT '[]',
+    PRIMARY KEY (deliveryservice, server, window_start),
+    CONSTRAINT deliveryservice_cache_hits_window_check CHECK (window_end > window_start)
+);
+
+CREATE INDEX IF NOT EXISTS deliveryservice_cache_hits_window_end_idx ON public.deliveryservice_cache_hits (window_end);
+
+-- The end of the last window of cache hits and misses collected from the
+-- t3c-log-agent of each cache server.
+CREATE TABLE IF NOT EXISTS public.cache_hits_collection (
+    server bigint PRIMARY KEY REFERENCES public.server (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    collected_until timestamp with time zone NOT NULL
+);
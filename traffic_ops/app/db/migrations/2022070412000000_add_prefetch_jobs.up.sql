@@ -0,0 +1,49 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- A job which fetches the content of a Delivery Service into the cache
+-- servers of Cache Groups ahead of its being requested. manifest_urls are
+-- expanded by the cache servers into the URLs they list.
+CREATE TABLE IF NOT EXISTS public.prefetch_job (
+    id bigserial PRIMARY KEY,
+    deliveryservice bigint NOT NULL REFERENCES public.deliveryservice (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    urls text[] NOT NULL DEFAULT '{}',
+    manifest_urls text[] NOT NULL DEFAULT '{}',
+    start_time timestamp with time zone NOT NULL DEFAULT now(),
+    created_by bigint REFERENCES public.tm_user (id) ON UPDATE CASCADE ON DELETE SET NULL,
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS public.prefetch_job_cachegroup (
+    job bigint NOT NULL REFERENCES public.prefetch_job (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    cachegroup bigint NOT NULL REFERENCES public.cachegroup (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    PRIMARY KEY (job, cachegroup)
+);
+
+-- The progress of a cache server on a prefetch job, as last reported by it.
+-- failed_urls is a sample of the URLs it failed to fetch.
+CREATE TABLE IF NOT EXISTS public.prefetch_job_progress (
+    job bigint NOT NULL REFERENCES public.prefetch_job (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    server bigint NOT NULL REFERENCES public.server (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    total bigint NOT NULL CHECK (total >= 0),
+    fetched bigint NOT NULL CHECK (fetched >= 0),
+    failed bigint NOT NULL CHECK (failed >= 0),
+    failed_urls text[] NOT NULL DEFAULT '{}',
+    done boolean NOT NULL DEFAULT FALSE,
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (job, server)
+);
@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- A named script which transforms the requests and/or responses of the
+-- Delivery Services to which it's attached. Its content is kept in immutable
+-- versions.
+CREATE TABLE IF NOT EXISTS public.transform_script (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL UNIQUE CHECK (name ~ '^[A-Za-z0-9_-]+$'),
+    language text NOT NULL CHECK (language = 'lua'),
+    description text NOT NULL DEFAULT '',
+    last_updated timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS public.transform_script_version (
+    script bigint NOT NULL REFERENCES public.transform_script (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    version integer NOT NULL CHECK (version > 0),
+    content text NOT NULL,
+    sha256 text NOT NULL,
+    comment text NOT NULL DEFAULT '',
+    created_by bigint REFERENCES public.tm_user (id) ON UPDATE CASCADE ON DELETE SET NULL,
+    created timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (script, version)
+);
+
+-- A transformation script attached to a Delivery Service. Scripts are run in
+-- the order of their positions. rollout_version, if not NULL, is run by
+-- rollout_percent percent of the Delivery Service's cache servers instead of
+-- version. Scripts and versions in use can't be deleted.
+CREATE TABLE IF NOT EXISTS public.deliveryservice_transform_script (
+    deliveryservice bigint NOT NULL REFERENCES public.deliveryservice (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    script bigint NOT NULL REFERENCES public.transform_script (id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    position integer NOT NULL CHECK (position >= 0),
+    version integer NOT NULL,
+    rollout_version integer,
+    rollout_percent integer NOT NULL DEFAULT 0 CHECK (rollout_percent BETWEEN 0 AND 100),
+    last_updated timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (deliveryservice, script),
+    UNIQUE (deliveryservice, position),
+    FOREIGN KEY (script, version) REFERENCES public.transform_script_version (script, version) ON UPDATE CASCADE ON DELETE RESTRICT,
+    FOREIGN KEY (script, rollout_version) REFERENCES public.transform_script_version (script, version) ON UPDATE CASCADE ON DELETE RESTRICT,
+    CHECK ((rollout_version IS NULL) = (rollout_percent = 0))
+);
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.transform_script
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+CREATE TRIGGER on_update_current_timestamp
+BEFORE UPDATE ON public.deliveryservice_transform_script
+FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
+CREATE TRIGGER on_delete_current_timestamp
+AFTER DELETE ON public.deliveryservice_transform_script
+FOR EACH ROW EXECUTE PROCEDURE on_delete_current_timestamp_last_updated('deliveryservice_transform_script');
+
+INSERT INTO public.last_deleted (table_name) VALUES ('deliveryservice_transform_script') ON CONFLICT (table_name) DO NOTHING;
@@ -0,0 +1,25 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+-- websocket and grpc are whether cache servers pass WebSocket connections and
+-- gRPC requests for a Delivery Service through to its origin;
+-- stream_idle_timeout and stream_active_timeout, in seconds, bound how long
+-- such streams may go without data and stay open, respectively.
+ALTER TABLE public.deliveryservice
+    ADD COLUMN IF NOT EXISTS websocket boolean NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS grpc boolean NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS stream_idle_timeout bigint CHECK (stream_idle_timeout > 0),
+    ADD COLUMN IF NOT EXISTS stream_active_timeout bigint CHECK (stream_active_timeout > 0);
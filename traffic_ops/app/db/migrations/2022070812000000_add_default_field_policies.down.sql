@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+DELETE FROM public.field_policy
+WHERE object_type = 'deliveryservice'
+AND "role" = (SELECT id FROM public."role" WHERE "name" = 'portal')
+AND field = ANY(ARRAY[
+    'active',
+    'cdnId',
+    'consistentHashQueryParams',
+    'consistentHashRegex',
+    'dnsBypassCname',
+    'dnsBypassIp',
+    'dnsBypassIp6',
+    'edgeHeaderRewrite',
+    'firstHeaderRewrite',
+    'geoLimit',
+    'geoLimitCountries',
+    'geoProvider',
+    'httpBypassFqdn',
+    'innerHeaderRewrite',
+    'ipv6RoutingEnabled',
+    'lastHeaderRewrite',
+    'maxOriginConnections',
+    'midHeaderRewrite',
+    'multiSiteOrigin',
+    'orgServerFqdn',
+    'originShield',
+    'profileId',
+    'protocol',
+    'qstringIgnore',
+    'rangeRequestHandling',
+    'regexRemap',
+    'remapText',
+    'routingName',
+    'signingAlgorithm',
+    'tenantId',
+    'tlsVersions',
+    'topology',
+    'trRequestHeaders',
+    'trResponseHeaders',
+    'typeId',
+    'xmlId'
+]);
@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with this
+ * work for additional information regarding copyright ownership.  The ASF
+ * licenses this file to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+ * License for the specific language governing permissions and limitations under
+ * the License.
+ */
+
+-- Protects the Delivery Service fields which affect how content is routed,
+-- fetched, and served from the built-in Role of tenant users, so that their
+-- changes to them are submitted for review or rejected.
+INSERT INTO public.field_policy ("role", object_type, field)
+SELECT r.id, 'deliveryservice', f.field
+FROM public."role" r
+CROSS JOIN unnest(ARRAY[
+    'active',
+    'cdnId',
+    'consistentHashQueryParams',
+    'consistentHashRegex',
+    'dnsBypassCname',
+    'dnsBypassIp',
+    'dnsBypassIp6',
+    'edgeHeaderRewrite',
+    'firstHeaderRewrite',
+    'geoLimit',
+    'geoLimitCountries',
+    'geoProvider',
+    'httpBypassFqdn',
+    'innerHeaderRewrite',
+    'ipv6RoutingEnabled',
+    'lastHeaderRewrite',
+    'maxOriginConnections',
+    'midHeaderRewrite',
+    'multiSiteOrigin',
+    'orgServerFqdn',
+    'originShield',
+    'profileId',
+    'protocol',
+    'qstringIgnore',
+    'rangeRequestHandling',
+    'regexRemap',
+    'remapText',
+    'routingName',
+    'signingAlgorithm',
+    'tenantId',
+    'tlsVersions',
+    'topology',
+    'trRequestHeaders',
+    'trResponseHeaders',
+    'typeId',
+    'xmlId'
+]) AS f (field)
+WHERE r."name" = 'portal'
+ON CONFLICT DO NOTHING;
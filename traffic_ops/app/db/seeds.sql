@@ -79,9 +79,59 @@ INSERT INTO public.role ("name", "description", priv_level) VALUES ('admin', 'Ha
 INSERT INTO public.role ("name", "description", priv_level) VALUES ('operations', 'Has all reads and most write capabilities', 20) ON CONFLICT ("name") DO NOTHING;
 INSERT INTO public.role ("name", "description", priv_level) VALUES ('read-only', 'Has access to all read capabilities', 10) ON CONFLICT ("name") DO NOTHING;
 INSERT INTO public.role ("name", "description", priv_level) VALUES ('disallowed', 'Block all access', 0) ON CONFLICT ("name") DO NOTHING;
-INSERT INTO public.role ("name", "description", priv_level) VALUES ('portal','Portal User', 2) ON CONFLICT DO NOTHING;
+-- The portal Role is created with field policies protecting the Delivery
+-- Service fields which affect how content is routed, fetched, and served. They
+-- are only added when the Role is created, so that seeding the database again
+-- doesn't undo their removal.
+WITH portal AS (
+    INSERT INTO public.role ("name", "description", priv_level) VALUES ('portal','Portal User', 2) ON CONFLICT DO NOTHING
+    RETURNING id
+)
+INSERT INTO public.field_policy ("role", object_type, field)
+SELECT portal.id, 'deliveryservice', f.field
+FROM portal
+CROSS JOIN unnest(ARRAY[
+    'active',
+    'cdnId',
+    'consistentHashQueryParams',
+    'consistentHashRegex',
+    'dnsBypassCname',
+    'dnsBypassIp',
+    'dnsBypassIp6',
+    'edgeHeaderRewrite',
+    'firstHeaderRewrite',
+    'geoLimit',
+    'geoLimitCountries',
+    'geoProvider',
+    'httpBypassFqdn',
+    'innerHeaderRewrite',
+    'ipv6RoutingEnabled',
+    'lastHeaderRewrite',
+    'maxOriginConnections',
+    'midHeaderRewrite',
+    'multiSiteOrigin',
+    'orgServerFqdn',
+    'originShield',
+    'profileId',
+    'protocol',
+    'qstringIgnore',
+    'rangeRequestHandling',
+    'regexRemap',
+    'remapText',
+    'routingName',
+    'signingAlgorithm',
+    'tenantId',
+    'tlsVersions',
+    'topology',
+    'trRequestHeaders',
+    'trResponseHeaders',
+    'typeId',
+    'xmlId'
+]) AS f (field);
 INSERT INTO public.role ("name", "description", priv_level) VALUES ('steering','Steering User', 15) ON CONFLICT DO NOTHING;
 INSERT INTO public.role ("name", "description", priv_level) VALUES ('federation','Role for Secondary CZF', 15) ON CONFLICT DO NOTHING;
+INSERT INTO public.role ("name", "description", priv_level) VALUES ('monitor-agent', 'Built-in Role with exactly the Permissions Traffic Monitor needs', 10) ON CONFLICT DO NOTHING;
+INSERT INTO public.role ("name", "description", priv_level) VALUES ('cache-agent', 'Built-in Role with exactly the Permissions t3c needs', 20) ON CONFLICT DO NOTHING;
 
 -- roles_capabilities
 -- out of the box, the admin role has ALL capabilities
@@ -253,6 +303,91 @@ CROSS JOIN ( VALUES
 WHERE "name" = 'operations'
 ON CONFLICT DO NOTHING;
 
+-- The 'monitor-agent' and 'cache-agent' Roles have exactly the Permissions
+-- Traffic Monitor and t3c need, respectively, so any they've been given beyond
+-- those are taken away.
+DELETE FROM public.role_capability
+WHERE role_id = (SELECT id FROM public.role WHERE "name" = 'monitor-agent')
+AND cap_name NOT IN (
+	'ANNOTATION:READ',
+	'CACHE-GROUP:READ',
+	'CDN-SNAPSHOT:READ',
+	'CDN:READ',
+	'DELIVERY-SERVICE:READ',
+	'MONITOR-CONFIG:READ',
+	'PHYSICAL-LOCATION:READ',
+	'PROFILE:READ',
+	'SERVER:READ',
+	'TYPE:READ'
+);
+
+-- Using role 'monitor-agent'
+INSERT INTO public.role_capability
+SELECT id, perm
+FROM public.role
+CROSS JOIN ( VALUES
+	('ANNOTATION:READ'),
+	('CACHE-GROUP:READ'),
+	('CDN-SNAPSHOT:READ'),
+	('CDN:READ'),
+	('DELIVERY-SERVICE:READ'),
+	('MONITOR-CONFIG:READ'),
+	('PHYSICAL-LOCATION:READ'),
+	('PROFILE:READ'),
+	('SERVER:READ'),
+	('TYPE:READ')
+) AS perms(perm)
+WHERE "name" = 'monitor-agent'
+ON CONFLICT DO NOTHING;
+
+DELETE FROM public.role_capability
+WHERE role_id = (SELECT id FROM public.role WHERE "name" = 'cache-agent')
+AND cap_name NOT IN (
+	'CACHE-GROUP:READ',
+	'CDN:READ',
+	'DELIVERY-SERVICE:READ',
+	'DS-SECURITY-KEY:READ',
+	'JOB:READ',
+	'JOB:UPDATE',
+	'PARAMETER:READ',
+	'PHYSICAL-LOCATION:READ',
+	'PROFILE:READ',
+	'SERVER-CAPABILITY:READ',
+	'SERVER-CHECK:CREATE',
+	'SERVER-CHECK:READ',
+	'SERVER:READ',
+	'SERVER:UPDATE',
+	'STATUS:READ',
+	'TOPOLOGY:READ',
+	'TYPE:READ'
+);
+
+-- Using role 'cache-agent'
+INSERT INTO public.role_capability
+SELECT id, perm
+FROM public.role
+CROSS JOIN ( VALUES
+	('CACHE-GROUP:READ'),
+	('CDN:READ'),
+	('DELIVERY-SERVICE:READ'),
+	('DS-SECURITY-KEY:READ'),
+	('JOB:READ'),
+	('JOB:UPDATE'),
+	('PARAMETER:READ'),
+	('PHYSICAL-LOCATION:READ'),
+	('PROFILE:READ'),
+	('SERVER-CAPABILITY:READ'),
+	('SERVER-CHECK:CREATE'),
+	('SERVER-CHECK:READ'),
+	('SERVER:READ'),
+	('SERVER:UPDATE'),
+	('STATUS:READ'),
+	('TOPOLOGY:READ'),
+	('TYPE:READ')
+) AS perms(perm)
+WHERE "name" = 'cache-agent'
+ON CONFLICT DO NOTHING;
+
 -- types
 
 -- delivery service types
@@ -299,6 +434,7 @@ INSERT INTO public.type ("name", "description", use_in_table) VALUES ('STATISTIC
 INSERT INTO public.type ("name", "description", use_in_table) VALUES ('HOST_REGEXP', 'Host header regular expression', 'regex') ON CONFLICT ("name") DO NOTHING;
 INSERT INTO public.type ("name", "description", use_in_table) VALUES ('HEADER_REGEXP', 'HTTP header regular expression', 'regex') ON CONFLICT ("name") DO NOTHING;
 INSERT INTO public.type ("name", "description", use_in_table) VALUES ('PATH_REGEXP', 'URL path regular expression', 'regex') ON CONFLICT ("name") DO NOTHING;
+INSERT INTO public.type ("name", "description", use_in_table) VALUES ('PATH_AND_QUERY_REGEXP', 'URL path and normalized query string regular expression', 'regex') ON CONFLICT ("name") DO NOTHING;
 INSERT INTO public.type ("name", "description", use_in_table) VALUES ('STEERING_REGEXP', 'Steering target filter regular expression', 'regex') ON CONFLICT ("name") DO NOTHING;
 
 -- federation types
@@ -310,6 +446,8 @@ INSERT INTO public.type ("name", "description", use_in_table) VALUES ('A_RECORD'
 INSERT INTO public.type ("name", "description", use_in_table) VALUES ('AAAA_RECORD', 'Static DNS AAAA entry', 'staticdnsentry') ON CONFLICT ("name") DO NOTHING;
 INSERT INTO public.type ("name", "description", use_in_table) VALUES ('CNAME_RECORD', 'Static DNS CNAME entry', 'staticdnsentry') ON CONFLICT ("name") DO NOTHING;
 INSERT INTO public.type ("name", "description", use_in_table) VALUES ('TXT_RECORD', 'Static DNS TXT entry', 'staticdnsentry') ON CONFLICT ("name") DO NOTHING;
+INSERT INTO public.type ("name", "description", use_in_table) VALUES ('SRV_RECORD', 'Static DNS SRV entry', 'staticdnsentry') ON CONFLICT ("name") DO NOTHING;
+INSERT INTO public.type ("name", "description", use_in_table) VALUES ('MX_RECORD', 'Static DNS MX entry', 'staticdnsentry') ON CONFLICT ("name") DO NOTHING;
 
 --steering_target types
 INSERT INTO public.type ("name", "description", use_in_table) VALUES ('STEERING_WEIGHT', 'Weighted steering target', 'steering_target') ON CONFLICT ("name") DO NOTHING;
@@ -423,6 +561,7 @@ INSERT INTO public.last_deleted (table_name) VALUES ('federation_deliveryservice
 INSERT INTO public.last_deleted (table_name) VALUES ('federation_federation_resolver') ON CONFLICT (table_name) DO NOTHING;
 INSERT INTO public.last_deleted (table_name) VALUES ('federation_resolver') ON CONFLICT (table_name) DO NOTHING;
 INSERT INTO public.last_deleted (table_name) VALUES ('federation_tmuser') ON CONFLICT (table_name) DO NOTHING;
+INSERT INTO public.last_deleted (table_name) VALUES ('field_policy') ON CONFLICT (table_name) DO NOTHING;
 INSERT INTO public.last_deleted (table_name) VALUES ('hwinfo') ON CONFLICT (table_name) DO NOTHING;
 INSERT INTO public.last_deleted (table_name) VALUES ('job') ON CONFLICT (table_name) DO NOTHING;
 INSERT INTO public.last_deleted (table_name) VALUES ('log') ON CONFLICT (table_name) DO NOTHING;
@@ -54,6 +54,19 @@ ALTER FUNCTION on_update_current_timestamp_last_updated() OWNER TO traffic_vault
 
 SET default_tablespace = '';
 
+--
+-- Name: cdn_signing_key; Type: TABLE; Schema: public; Owner: traffic_vault
+--
+
+CREATE TABLE IF NOT EXISTS cdn_signing_key (
+    cdn text NOT NULL,
+    data bytea NOT NULL,
+    last_updated timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+ALTER TABLE cdn_signing_key OWNER TO traffic_vault;
+
 --
 -- Name: dnssec; Type: TABLE; Schema: public; Owner: traffic_vault
 --
@@ -113,6 +126,15 @@ CREATE TABLE IF NOT EXISTS url_sig_key (
 ALTER TABLE url_sig_key OWNER TO traffic_vault;
 
 DO $$ BEGIN
+IF NOT EXISTS (SELECT FROM information_schema.table_constraints WHERE constraint_name = 'cdn_signing_key_pkey' AND table_name = 'cdn_signing_key') THEN
+    --
+    -- Name: cdn_signing_key cdn_signing_key_pkey; Type: CONSTRAINT; Schema: public; Owner: traffic_vault
+    --
+
+    ALTER TABLE ONLY cdn_signing_key
+        ADD CONSTRAINT cdn_signing_key_pkey PRIMARY KEY (cdn);
+END IF;
+
 IF NOT EXISTS (SELECT FROM information_schema.table_constraints WHERE constraint_name = 'dnssec_pkey' AND table_name = 'dnssec') THEN
     --
     -- Name: dnssec dnssec_pkey; Type: CONSTRAINT; Schema: public; Owner: traffic_vault
@@ -177,6 +199,14 @@ END IF;
 END$$;
 
 
+--
+-- Name: cdn_signing_key cdn_signing_key_last_updated; Type: TRIGGER; Schema: public; Owner: traffic_vault
+--
+DROP TRIGGER IF EXISTS cdn_signing_key_last_updated ON cdn_signing_key;
+CREATE TRIGGER cdn_signing_key_last_updated
+    BEFORE UPDATE ON cdn_signing_key
+    FOR EACH ROW EXECUTE PROCEDURE on_update_current_timestamp_last_updated();
+
 --
 -- Name: dnssec dnssec_last_updated; Type: TRIGGER; Schema: public; Owner: traffic_vault
 --
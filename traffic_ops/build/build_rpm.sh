@@ -90,6 +90,11 @@ initBuildArea() {
 	go build -v -o traffic_vault_migrate || \
 								{ echo "Could not build traffic_vault_migrate binary"; return 1;})
 
+	# compile db/config_import
+		(cd app/db/config_import
+	go build -v -o config_import || \
+								{ echo "Could not build config_import binary"; return 1;})
+
 	# compile TO profile converter
 	(cd install/bin/convert_profile
 	go build -v -gcflags "$gcflags" -ldflags "$ldflags" -tags="$tags" || \
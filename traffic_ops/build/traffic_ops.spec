@@ -102,6 +102,13 @@ tvm_dir=src/github.com/apache/trafficcontrol/traffic_ops/app/db/traffic_vault_mi
 	cp "$TC_DIR"/traffic_ops/app/db/traffic_vault_migrate/traffic_vault_migrate .
 ) || { echo "Could not copy go db traffic_vault_migrate at $(pwd): $!"; exit 1; };
 
+# copy config import
+config_import_dir=src/github.com/apache/trafficcontrol/traffic_ops/app/db/config_import
+( mkdir -p "$config_import_dir" && \
+	cd "$config_import_dir" && \
+	cp "$TC_DIR"/traffic_ops/app/db/config_import/config_import .
+) || { echo "Could not copy go db config_import at $(pwd): $!"; exit 1; };
+
 # copy TO profile converter
 convert_dir=src/github.com/apache/trafficcontrol/traffic_ops/install/bin/convert_profile
 ( mkdir -p "$convert_dir" && \
@@ -153,6 +160,10 @@ tv_migrate_src=src/github.com/apache/trafficcontrol/traffic_ops/app/db/traffic_v
 %__cp -p  "$tv_migrate_src"/traffic_vault_migrate           "${RPM_BUILD_ROOT}"/opt/traffic_ops/app/db/traffic_vault_migrate/traffic_vault_migrate
 %__rm $RPM_BUILD_ROOT/%{PACKAGEDIR}/app/db/traffic_vault_migrate/*.go
 
+config_import_src=src/github.com/apache/trafficcontrol/traffic_ops/app/db/config_import
+%__cp -p  "$config_import_src"/config_import           "${RPM_BUILD_ROOT}"/opt/traffic_ops/app/db/config_import/config_import
+%__rm $RPM_BUILD_ROOT/%{PACKAGEDIR}/app/db/config_import/*.go
+
 convert_profile_src=src/github.com/apache/trafficcontrol/traffic_ops/install/bin/convert_profile
 %__cp -p  "$convert_profile_src"/convert_profile           "${RPM_BUILD_ROOT}"/opt/traffic_ops/install/bin/convert_profile
 %__rm $RPM_BUILD_ROOT/%{PACKAGEDIR}/install/bin/convert_profile/*.go
@@ -254,6 +265,7 @@ fi
 %attr(755, %{TRAFFIC_OPS_USER},%{TRAFFIC_OPS_GROUP}) %{PACKAGEDIR}/app/bin/checks/DnssecRefresh/ToDnssecRefresh
 %attr(755, %{TRAFFIC_OPS_USER},%{TRAFFIC_OPS_GROUP}) %{PACKAGEDIR}/app/db/reencrypt/reencrypt
 %attr(755, %{TRAFFIC_OPS_USER},%{TRAFFIC_OPS_GROUP}) %{PACKAGEDIR}/app/db/traffic_vault_migrate/traffic_vault_migrate
+%attr(755, %{TRAFFIC_OPS_USER},%{TRAFFIC_OPS_GROUP}) %{PACKAGEDIR}/app/db/config_import/config_import
 %{PACKAGEDIR}/etc
 %{PACKAGEDIR}/app/bin/checks
 %{PACKAGEDIR}/app/bin/tests
@@ -156,6 +156,40 @@ func LoadConfig(confPath string) (Config, error) {
 	return cfg, nil
 }
 
+// LoadSmokeConfig reads the config file into the Config struct for the
+// read-only smoke tests, which only need the Traffic Ops URL and the
+// credentials of its "readOnly" user - the database and other users are never
+// used, so needn't be configured.
+func LoadSmokeConfig(confPath string) (Config, error) {
+	var cfg Config
+
+	confBytes, err := ioutil.ReadFile(confPath)
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read CDN configuration: %v", err)
+	}
+
+	if err := json.Unmarshal(confBytes, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse configuration from '%s': %v", confPath, err)
+	}
+
+	if err := envconfig.Process("traffic-ops-client-tests", &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse configuration from environment: %v", err)
+	}
+
+	var errs multiError
+	if cfg.TrafficOps.URL == "" {
+		errs = append(errs, fmt.Errorf("'trafficOps.URL' must be configured in %s", confPath))
+	}
+	if cfg.TrafficOps.Users.ReadOnly == "" {
+		errs = append(errs, fmt.Errorf("'trafficOps.users.readOnly' must be configured in %s", confPath))
+	}
+	if len(errs) > 0 {
+		return cfg, fmt.Errorf("failed to validate configuration:\n%v", errs)
+	}
+
+	return cfg, nil
+}
+
 type multiError []error
 
 func (me multiError) Error() string {
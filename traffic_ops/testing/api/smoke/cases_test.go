@@ -0,0 +1,73 @@
+//go:build smoke
+
+package smoke
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	client "github.com/apache/trafficcontrol/traffic_ops/v5-client"
+)
+
+// smokeCases maps the paths requested by the smoke tests to constructors of
+// the response types against which their responses are checked. Only
+// endpoints which don't change anything when requested with GET and are
+// readable by the "read-only" Role belong here.
+var smokeCases = map[string]func() interface{}{
+	"asns":                                   func() interface{} { return new(tc.ASNsResponse) },
+	"cachegroups":                            func() interface{} { return new(tc.CacheGroupsNullableResponse) },
+	"cdn_locks":                              func() interface{} { return new(tc.CDNLocksGetResponse) },
+	"cdn_notifications":                      func() interface{} { return new(tc.CDNNotificationsResponse) },
+	"cdns":                                   func() interface{} { return new(tc.CDNsResponse) },
+	"cdns/domains":                           func() interface{} { return new(tc.DomainsResponse) },
+	"coordinates":                            func() interface{} { return new(tc.CoordinatesResponse) },
+	"deliveryservice_requests":               func() interface{} { return new(tc.DeliveryServiceRequestsResponseV4) },
+	"deliveryservices":                       func() interface{} { return new(tc.DeliveryServicesResponseV4) },
+	"deliveryservices_required_capabilities": func() interface{} { return new(tc.DeliveryServicesRequiredCapabilitiesResponse) },
+	"deliveryserviceserver":                  func() interface{} { return new(tc.DeliveryServiceServerResponse) },
+	"divisions":                              func() interface{} { return new(tc.DivisionsResponse) },
+	"federation_resolvers":                   func() interface{} { return new(tc.FederationResolversResponse) },
+	"jobs":                                   func() interface{} { return new(tc.InvalidationJobsResponseV4) },
+	"origins":                                func() interface{} { return new(tc.OriginsResponse) },
+	"osversions":                             func() interface{} { return new(tc.OSVersionsAPIResponse) },
+	"parameters":                             func() interface{} { return new(tc.ParametersResponse) },
+	"phys_locations":                         func() interface{} { return new(tc.PhysLocationsResponse) },
+	"profileparameters":                      func() interface{} { return new(tc.ProfileParametersAPIResponse) },
+	"profiles":                               func() interface{} { return new(tc.ProfilesResponse) },
+	"regions":                                func() interface{} { return new(tc.RegionsResponse) },
+	"roles":                                  func() interface{} { return new(tc.RolesResponseV4) },
+	"server_capabilities":                    func() interface{} { return new(tc.ServerCapabilitiesResponse) },
+	"server_server_capabilities":             func() interface{} { return new(tc.ServerServerCapabilitiesResponse) },
+	"servers":                                func() interface{} { return new(tc.ServersV4Response) },
+	"service_categories":                     func() interface{} { return new(tc.ServiceCategoriesResponse) },
+	"staticdnsentries":                       func() interface{} { return new(tc.StaticDNSEntriesResponse) },
+	"statuses":                               func() interface{} { return new(tc.StatusesResponse) },
+	"tenants":                                func() interface{} { return new(tc.GetTenantsResponse) },
+	"topologies":                             func() interface{} { return new(tc.TopologiesResponse) },
+	"types":                                  func() interface{} { return new(tc.TypesResponse) },
+	"user/current":                           func() interface{} { return new(client.UserCurrentResponseV4) },
+	"users":                                  func() interface{} { return new(tc.UsersResponseV4) },
+}
+
+func TestSmoke(t *testing.T) {
+	for path, newResponse := range smokeCases {
+		t.Run(path, func(t *testing.T) {
+			smokeGet(t, path, newResponse())
+		})
+	}
+}
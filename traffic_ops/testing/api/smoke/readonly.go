@@ -0,0 +1,62 @@
+// Package smoke provides read-only "smoke" tests of the Traffic Ops API,
+// which may be run against a live production Traffic Ops - for example to
+// validate an upgrade against real data.
+//
+// Unlike the integration tests of each API version, the smoke tests never
+// connect to the Traffic Ops database, never create fixtures, and only make
+// GET requests (and the POST request needed to log in); any other request is
+// refused before it's sent. They're excluded from builds unless the "smoke"
+// build tag is given, e.g.
+//
+//	go test -tags smoke ./smoke -cfg=../conf/traffic-ops-smoke.conf
+package smoke
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// loginPathSuffix is the end of the path of the only request which the smoke
+// tests may make with a method other than GET or HEAD.
+const loginPathSuffix = "/user/login"
+
+// readOnlyTransport is an http.RoundTripper which refuses to send any request
+// that could change data in Traffic Ops, so that a mistaken test can't write
+// to a production instance.
+type readOnlyTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t readOnlyTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPost:
+		if !strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), loginPathSuffix) {
+			return nil, fmt.Errorf("smoke tests are read-only: refusing to send %s %s", r.Method, r.URL.Path)
+		}
+	default:
+		return nil, fmt.Errorf("smoke tests are read-only: refusing to send %s %s", r.Method, r.URL.Path)
+	}
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return next.RoundTrip(r)
+}
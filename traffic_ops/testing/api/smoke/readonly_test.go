@@ -0,0 +1,73 @@
+package smoke
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReadOnlyTransport(t *testing.T) {
+	received := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received++
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: readOnlyTransport{}}
+	tests := []struct {
+		method  string
+		path    string
+		allowed bool
+	}{
+		{http.MethodGet, "/api/5.0/cdns", true},
+		{http.MethodHead, "/api/5.0/cdns", true},
+		{http.MethodPost, "/api/5.0/user/login", true},
+		{http.MethodPost, "/api/5.0/user/login/", true},
+		{http.MethodPost, "/api/5.0/cdns", false},
+		{http.MethodPost, "/api/5.0/user/login/token", false},
+		{http.MethodPut, "/api/5.0/cdns/1", false},
+		{http.MethodPatch, "/api/5.0/cdns/1", false},
+		{http.MethodDelete, "/api/5.0/cdns/1", false},
+	}
+	for _, test := range tests {
+		before := received
+		req, err := http.NewRequest(test.method, srv.URL+test.path, strings.NewReader(""))
+		if err != nil {
+			t.Fatalf("creating request: %v", err)
+		}
+		resp, err := client.Do(req)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		if test.allowed {
+			if err != nil {
+				t.Errorf("expected %s %s to be sent, got error: %v", test.method, test.path, err)
+			} else if received != before+1 {
+				t.Errorf("expected %s %s to reach the server", test.method, test.path)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("expected %s %s to be refused, but it was sent", test.method, test.path)
+		}
+		if received != before {
+			t.Errorf("expected %s %s not to reach the server", test.method, test.path)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+//go:build smoke
+
+package smoke
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/testing/api/assert"
+	"github.com/apache/trafficcontrol/traffic_ops/testing/api/config"
+	client "github.com/apache/trafficcontrol/traffic_ops/v5-client"
+)
+
+// apiBase is the path prefix of the API version the smoke tests check.
+const apiBase = "/api/5.0/"
+
+var (
+	Config        config.Config
+	SmokeSession  *client.Session
+	strictSchemas bool
+)
+
+func TestMain(m *testing.M) {
+	configFileName := flag.String("cfg", "../conf/traffic-ops-smoke.conf", "The config file path")
+	cliStrictSchemas := flag.Bool("strict", true, "Whether response fields unknown to the client fail the tests, which catches API changes the client doesn't know about")
+	flag.Parse()
+
+	if f := flag.Lookup("test.list"); f != nil {
+		if f.Value.String() != "" {
+			os.Exit(m.Run())
+		}
+	}
+
+	var err error
+	if Config, err = config.LoadSmokeConfig(*configFileName); err != nil {
+		fmt.Printf("Error Loading Config: %v\n", err)
+		os.Exit(1)
+	}
+	strictSchemas = *cliStrictSchemas
+
+	if err = log.InitCfg(Config); err != nil {
+		fmt.Printf("Error initializing loggers: %v\n", err)
+		os.Exit(1)
+	}
+
+	log.Infof(`Using Config values:
+			   TO Config File:       %s
+			   TO URL:               %s
+			   TO User:              %s
+			   TO Session Timeout In Secs:  %d
+			   Strict Schemas:       %t`, *configFileName, Config.TrafficOps.URL, Config.TrafficOps.Users.ReadOnly, Config.Default.Session.TimeoutInSecs, strictSchemas)
+
+	toReqTimeout := time.Second * time.Duration(Config.Default.Session.TimeoutInSecs)
+	SmokeSession, _, err = client.LoginWithAgent(Config.TrafficOps.URL, Config.TrafficOps.Users.ReadOnly, Config.TrafficOps.UserPassword, Config.TrafficOps.Insecure, "to-api-smoke-tests", false, toReqTimeout)
+	if err != nil {
+		fmt.Printf("\nError creating session to %s - %s, %v\n", Config.TrafficOps.URL, Config.TrafficOps.Users.ReadOnly, err)
+		os.Exit(1)
+	}
+	SmokeSession.Client.Transport = readOnlyTransport{next: SmokeSession.Client.Transport}
+
+	os.Exit(m.Run())
+}
+
+// smokeGet requests the given API version-relative path and checks that the
+// response is successful, is JSON, has no error-level Alerts, and - if strict
+// schemas are enabled - has no fields unknown to the type of the given
+// response reference, into which it's decoded.
+func smokeGet(t *testing.T, path string, response interface{}) {
+	t.Helper()
+	resp, _, err := SmokeSession.RawRequestWithHdr(http.MethodGet, apiBase+strings.TrimPrefix(path, "/"), nil, nil)
+	assert.RequireNoError(t, err, "Unexpected error requesting %s: %v", path, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.RequireNoError(t, err, "Unexpected error reading response body of %s: %v", path, err)
+	assert.RequireEqual(t, http.StatusOK, resp.StatusCode, "Expected status code 200 from %s, got %d: %s", path, resp.StatusCode, string(body))
+	assert.Equal(t, true, strings.HasPrefix(resp.Header.Get("Content-Type"), "application/json"), "Expected a JSON Content-Type from %s, got '%s'", path, resp.Header.Get("Content-Type"))
+
+	var alerts tc.Alerts
+	assert.RequireNoError(t, json.Unmarshal(body, &alerts), "Expected the response from %s to be a JSON object", path)
+	for _, alert := range alerts.Alerts {
+		assert.Equal(t, false, alert.Level == tc.ErrorLevel.String(), "Unexpected error-level alert from %s: %s", path, alert.Text)
+	}
+
+	var envelope map[string]json.RawMessage
+	assert.RequireNoError(t, json.Unmarshal(body, &envelope), "Expected the response from %s to be a JSON object", path)
+	_, ok := envelope["response"]
+	assert.Equal(t, true, ok, "Expected the response from %s to have a 'response' property", path)
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	if strictSchemas {
+		decoder.DisallowUnknownFields()
+	}
+	assert.NoError(t, decoder.Decode(response), "Expected the response from %s to match the schema of %T", path, response)
+}
@@ -20,6 +20,8 @@ import (
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -203,6 +205,42 @@ func TestStaticDNSEntries(t *testing.T) {
 	})
 }
 
+func TestStaticDNSEntriesExportDeliveryService(t *testing.T) {
+	WithObjs(t, []TCObj{CDNs, Types, Tenants, Parameters, Profiles, Statuses, Divisions, Regions, PhysLocations, CacheGroups, Servers, Topologies, ServiceCategories, DeliveryServices, StaticDNSEntries}, func() {
+		dsID := GetDeliveryServiceId(t, "ds1")()
+		opts := client.NewRequestOptions()
+		opts.QueryParameters.Set("deliveryserviceId", strconv.Itoa(dsID))
+		entries, _, err := TOSession.GetStaticDNSEntries(opts)
+		assert.RequireNoError(t, err, "Error getting Static DNS Entries: %v - alerts: %+v", err, entries.Alerts)
+
+		opts = client.NewRequestOptions()
+		opts.QueryParameters.Set("format", "bind")
+		export, reqInf, err := TOSession.ExportDeliveryServiceStaticDNSEntries(dsID, opts)
+		assert.RequireNoError(t, err, "Unexpected error exporting Static DNS Entries: %v", err)
+		assert.Equal(t, http.StatusOK, reqInf.StatusCode, "Expected status code 200, got: %d", reqInf.StatusCode)
+		assert.Equal(t, len(entries.Response), len(export.Records), "Expected a record for each of %d Static DNS Entries, got: %d - export: %s", len(entries.Response), len(export.Records), export.Text)
+		for _, entry := range entries.Response {
+			found := false
+			for _, record := range export.Records {
+				if strings.HasPrefix(record.Name, entry.Host+".") && record.Data == entry.Address && record.TTL == entry.TTL {
+					found = true
+					break
+				}
+			}
+			assert.Equal(t, true, found, "Expected Static DNS Entry '%s' (%s) in the export, got: %s", entry.Host, entry.Address, export.Text)
+		}
+
+		opts.QueryParameters.Set("format", "csv")
+		_, reqInf, err = TOSession.ExportDeliveryServiceStaticDNSEntries(dsID, opts)
+		assert.Error(t, err, "Expected an error exporting Static DNS Entries in an unsupported format")
+		assert.Equal(t, http.StatusBadRequest, reqInf.StatusCode, "Expected status code 400, got: %d", reqInf.StatusCode)
+
+		_, reqInf, err = TOSession.ExportDeliveryServiceStaticDNSEntries(10000000, client.RequestOptions{})
+		assert.Error(t, err, "Expected an error exporting the Static DNS Entries of a nonexistent Delivery Service")
+		assert.Equal(t, http.StatusNotFound, reqInf.StatusCode, "Expected status code 404, got: %d", reqInf.StatusCode)
+	})
+}
+
 func validateStaticDNSEntriesFields(expectedResp map[string]interface{}) utils.CkReqFunc {
 	return func(t *testing.T, _ toclientlib.ReqInf, resp interface{}, _ tc.Alerts, _ error) {
 		assert.RequireNotNil(t, resp, "Expected Static DNS Entries response to not be nil.")
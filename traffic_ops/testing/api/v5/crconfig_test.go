@@ -16,6 +16,7 @@ package v5
 */
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 	"strings"
@@ -36,6 +37,7 @@ func TestCRConfig(t *testing.T) {
 		SnapshotTestCDNbyInvalidName(t)
 		SnapshotTestCDNbyID(t)
 		SnapshotTestCDNbyInvalidID(t)
+		SnapshotTestCDNAsync(t)
 		SnapshotWithReadOnlyUser(t)
 	})
 }
@@ -354,3 +356,32 @@ func SnapshotTestCDNbyInvalidID(t *testing.T) {
 		t.Errorf("snapshot occurred on (presumed) invalid CDN #%d: %v - alerts: %+v", invalidCDNID, err, alert.Alerts)
 	}
 }
+
+func SnapshotTestCDNAsync(t *testing.T) {
+	if len(testData.CDNs) < 1 {
+		t.Fatal("Need at least one CDN to test taking CDN Snapshot asynchronously")
+	}
+	firstCDN := testData.CDNs[0].Name
+	opts := client.NewRequestOptions()
+	opts.QueryParameters.Set("cdn", firstCDN)
+	resp, reqInf, err := TOSession.SnapshotCRConfigAsync(opts)
+	if err != nil {
+		t.Fatalf("failed to start asynchronous snapshot of CDN '%s': %v - alerts: %+v", firstCDN, err, resp.Alerts)
+	}
+	if reqInf.StatusCode != http.StatusAccepted {
+		t.Errorf("Expected a %d response starting an asynchronous snapshot, got: %d", http.StatusAccepted, reqInf.StatusCode)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	status, _, err := TOSession.WaitForAsyncJob(ctx, resp.Response.JobID, time.Second, client.RequestOptions{})
+	if err != nil {
+		t.Fatalf("asynchronous snapshot of CDN '%s' didn't succeed: %v", firstCDN, err)
+	}
+	if status.Status != tc.AsyncStatusSucceeded {
+		t.Errorf("Expected asynchronous snapshot job to have status '%s', got: '%s'", tc.AsyncStatusSucceeded, status.Status)
+	}
+	if status.Progress != 100 {
+		t.Errorf("Expected finished asynchronous snapshot job to have progress 100, got: %d", status.Progress)
+	}
+}
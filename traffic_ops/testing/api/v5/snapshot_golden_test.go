@@ -0,0 +1,84 @@
+package v5
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/test"
+	client "github.com/apache/trafficcontrol/traffic_ops/v5-client"
+)
+
+// snapshotGoldenDir is the directory holding the golden CRConfigs and
+// monitoring configurations of the fixture CDNs, one directory per CDN.
+const snapshotGoldenDir = "testdata/golden/snapshots"
+
+// TestSnapshotGolden snapshots each of the fixture CDNs and compares the
+// resulting CRConfig and monitoring configuration to the golden files in
+// testdata/golden/snapshots/{{CDN}}, so that changes to what Traffic Router
+// and Traffic Monitor are given can't go unnoticed. Intended changes must be
+// "blessed" by running the test with -update and committing the golden files
+// it rewrites.
+func TestSnapshotGolden(t *testing.T) {
+	WithObjs(t, []TCObj{CDNs, Types, Tenants, Parameters, Profiles, ProfileParameters, Statuses, Divisions, Regions, PhysLocations, CacheGroups, Servers, Topologies, ServiceCategories, DeliveryServices, DeliveryServiceServerAssignments, StaticDNSEntries}, func() {
+		for _, cdn := range testData.CDNs {
+			t.Run(cdn.Name, func(t *testing.T) {
+				dir := filepath.Join(snapshotGoldenDir, cdn.Name)
+				if _, err := os.Stat(dir); os.IsNotExist(err) && !*test.UpdateGolden {
+					t.Skipf("no golden files for CDN '%s'; run the test with -update to create them", cdn.Name)
+				}
+
+				opts := client.NewRequestOptions()
+				opts.QueryParameters.Set("cdn", cdn.Name)
+				if resp, _, err := TOSession.SnapshotCRConfig(opts); err != nil {
+					t.Fatalf("Unexpected error making Snapshot for CDN '%s': %v - alerts: %+v", cdn.Name, err, resp.Alerts)
+				}
+
+				crc, _, err := TOSession.GetCRConfig(cdn.Name, client.RequestOptions{})
+				if err != nil {
+					t.Fatalf("Unexpected error fetching CRConfig for CDN '%s': %v - alerts: %+v", cdn.Name, err, crc.Alerts)
+				}
+				test.GoldenJSON(t, filepath.Join(dir, "crconfig.json"), normalizeGoldenCRConfig(crc.Response))
+
+				tmConfig, _, err := TOSession.GetTrafficMonitorConfig(cdn.Name, client.RequestOptions{})
+				if err != nil {
+					t.Fatalf("Unexpected error fetching Traffic Monitor Config for CDN '%s': %v - alerts: %+v", cdn.Name, err, tmConfig.Alerts)
+				}
+				// The map form doesn't depend on the order in which servers,
+				// Delivery Services etc. happened to come out of the database.
+				tmMap, err := tc.TrafficMonitorTransformToMap(&tmConfig.Response)
+				if err != nil {
+					t.Fatalf("Unexpected error converting Traffic Monitor Config for CDN '%s' to a map: %v", cdn.Name, err)
+				}
+				test.GoldenJSON(t, filepath.Join(dir, "monitoring.json"), tmMap)
+			})
+		}
+	})
+}
+
+// normalizeGoldenCRConfig removes the parts of a CRConfig's stats which
+// depend on when and by whom it was made, rather than on the CDN.
+func normalizeGoldenCRConfig(crc tc.CRConfig) tc.CRConfig {
+	crc.Stats.DateUnixSeconds = nil
+	crc.Stats.TMHost = nil
+	crc.Stats.TMPath = nil
+	crc.Stats.TMUser = nil
+	crc.Stats.TMVersion = nil
+	return crc
+}
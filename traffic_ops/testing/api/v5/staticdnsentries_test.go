@@ -25,6 +25,7 @@ import (
 
 	"github.com/apache/trafficcontrol/lib/go-rfc"
 	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
 	"github.com/apache/trafficcontrol/traffic_ops/testing/api/assert"
 	"github.com/apache/trafficcontrol/traffic_ops/testing/api/utils"
 	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
@@ -57,6 +58,77 @@ func TestStaticDNSEntries(t *testing.T) {
 						validateStaticDNSEntriesFields(map[string]interface{}{"Host": "host1"})),
 				},
 			},
+			"POST": {
+				"OK when VALID TXT_RECORD": {
+					ClientSession: TOSession,
+					RequestBody: map[string]interface{}{
+						"address":         "v=spf1 -all",
+						"deliveryservice": "ds1",
+						"host":            "txt-test",
+						"type":            "TXT_RECORD",
+						"ttl":             10,
+					},
+					Expectations: utils.CkRequest(utils.NoError(), utils.HasStatus(http.StatusOK),
+						validateStaticDNSEntriesUpdateCreateFields("txt-test", map[string]interface{}{"Address": "v=spf1 -all"})),
+				},
+				"OK when VALID SRV_RECORD": {
+					ClientSession: TOSession,
+					RequestBody: map[string]interface{}{
+						"address":         "10 60 5060 sip.example.com.",
+						"deliveryservice": "ds1",
+						"host":            "_sip._tcp",
+						"type":            "SRV_RECORD",
+						"ttl":             10,
+					},
+					Expectations: utils.CkRequest(utils.NoError(), utils.HasStatus(http.StatusOK),
+						validateStaticDNSEntriesUpdateCreateFields("_sip._tcp", map[string]interface{}{"Address": "10 60 5060 sip.example.com."})),
+				},
+				"OK when VALID MX_RECORD": {
+					ClientSession: TOSession,
+					RequestBody: map[string]interface{}{
+						"address":         "10 mail.example.com.",
+						"deliveryservice": "ds1",
+						"host":            "mx-test",
+						"type":            "MX_RECORD",
+						"ttl":             10,
+					},
+					Expectations: utils.CkRequest(utils.NoError(), utils.HasStatus(http.StatusOK),
+						validateStaticDNSEntriesUpdateCreateFields("mx-test", map[string]interface{}{"Address": "10 mail.example.com."})),
+				},
+				"BAD REQUEST when SRV_RECORD HOST has NO SERVICE and PROTOCOL": {
+					ClientSession: TOSession,
+					RequestBody: map[string]interface{}{
+						"address":         "10 60 5060 sip.example.com.",
+						"deliveryservice": "ds1",
+						"host":            "srv-test",
+						"type":            "SRV_RECORD",
+						"ttl":             10,
+					},
+					Expectations: utils.CkRequest(utils.HasError(), utils.HasStatus(http.StatusBadRequest)),
+				},
+				"BAD REQUEST when INVALID PORT for SRV_RECORD": {
+					ClientSession: TOSession,
+					RequestBody: map[string]interface{}{
+						"address":         "10 60 65536 sip.example.com.",
+						"deliveryservice": "ds1",
+						"host":            "_sips._tcp",
+						"type":            "SRV_RECORD",
+						"ttl":             10,
+					},
+					Expectations: utils.CkRequest(utils.HasError(), utils.HasStatus(http.StatusBadRequest)),
+				},
+				"BAD REQUEST when MISSING PREFERENCE for MX_RECORD": {
+					ClientSession: TOSession,
+					RequestBody: map[string]interface{}{
+						"address":         "mail.example.com.",
+						"deliveryservice": "ds1",
+						"host":            "mx-bad-test",
+						"type":            "MX_RECORD",
+						"ttl":             10,
+					},
+					Expectations: utils.CkRequest(utils.HasError(), utils.HasStatus(http.StatusBadRequest)),
+				},
+			},
 			"PUT": {
 				"OK when VALID request": {
 					EndpointId:    GetStaticDNSEntryID(t, "host2"),
@@ -203,6 +275,125 @@ func TestStaticDNSEntries(t *testing.T) {
 	})
 }
 
+func TestStaticDNSEntriesBulk(t *testing.T) {
+	WithObjs(t, []TCObj{CDNs, Types, Tenants, Parameters, Profiles, Statuses, Divisions, Regions, PhysLocations, CacheGroups, Servers, Topologies, ServiceCategories, DeliveryServices, StaticDNSEntries}, func() {
+		dsID := GetDeliveryServiceId(t, "ds1")()
+		aRecordTypeID := GetTypeId(t, "A_RECORD")
+		host3ID := GetStaticDNSEntryID(t, "host3")()
+		host2ID := GetStaticDNSEntryID(t, "host2")()
+
+		ops := []tc.StaticDNSEntryBulkOperation{
+			{
+				Action: tc.StaticDNSEntryBulkCreate,
+				Entry: tc.StaticDNSEntryNullable{
+					Address:           util.StrPtr("192.0.2.10"),
+					DeliveryServiceID: util.IntPtr(dsID),
+					Host:              util.StrPtr("bulk-test"),
+					TTL:               util.Int64Ptr(10),
+					TypeID:            aRecordTypeID,
+				},
+			},
+			{
+				Action: tc.StaticDNSEntryBulkUpdate,
+				Entry: tc.StaticDNSEntryNullable{
+					ID:                util.IntPtr(host3ID),
+					Address:           util.StrPtr("192.0.2.11"),
+					DeliveryServiceID: util.IntPtr(dsID),
+					Host:              util.StrPtr("host3"),
+					TTL:               util.Int64Ptr(10),
+					TypeID:            aRecordTypeID,
+				},
+			},
+			{
+				Action: tc.StaticDNSEntryBulkDelete,
+				Entry:  tc.StaticDNSEntryNullable{ID: util.IntPtr(host2ID)},
+			},
+		}
+		resp, reqInf, err := TOSession.BulkStaticDNSEntries(ops, client.RequestOptions{})
+		assert.RequireNoError(t, err, "Unexpected error making bulk Static DNS Entry changes: %v - alerts: %+v", err, resp.Alerts)
+		assert.Equal(t, http.StatusOK, reqInf.StatusCode, "Expected status code 200, got: %d", reqInf.StatusCode)
+		assert.RequireEqual(t, len(ops), len(resp.Response), "Expected a result for each of %d operations, got: %d", len(ops), len(resp.Response))
+		assert.RequireNotNil(t, resp.Response[0].Entry.ID, "Expected the created Static DNS Entry to have an ID")
+		validateStaticDNSEntriesUpdateCreateFields("bulk-test", map[string]interface{}{"Address": "192.0.2.10"})(t, reqInf, nil, tc.Alerts{}, nil)
+		validateStaticDNSEntriesUpdateCreateFields("host3", map[string]interface{}{"Address": "192.0.2.11"})(t, reqInf, nil, tc.Alerts{}, nil)
+		opts := client.NewRequestOptions()
+		opts.QueryParameters.Set("host", "host2")
+		entries, _, err := TOSession.GetStaticDNSEntries(opts)
+		assert.RequireNoError(t, err, "Error getting Static DNS Entries: %v - alerts: %+v", err, entries.Alerts)
+		assert.Equal(t, 0, len(entries.Response), "Expected the bulk deleted Static DNS Entry to be deleted, but it was found")
+
+		// If any operation fails, none of them are made.
+		ops = []tc.StaticDNSEntryBulkOperation{
+			{
+				Action: tc.StaticDNSEntryBulkCreate,
+				Entry: tc.StaticDNSEntryNullable{
+					Address:           util.StrPtr("192.0.2.12"),
+					DeliveryServiceID: util.IntPtr(dsID),
+					Host:              util.StrPtr("bulk-rollback-test"),
+					TTL:               util.Int64Ptr(10),
+					TypeID:            aRecordTypeID,
+				},
+			},
+			{
+				Action: tc.StaticDNSEntryBulkUpdate,
+				Entry: tc.StaticDNSEntryNullable{
+					Address:           util.StrPtr("192.0.2.13"),
+					DeliveryServiceID: util.IntPtr(dsID),
+					Host:              util.StrPtr("bulk-test"),
+					TTL:               util.Int64Ptr(10),
+					TypeID:            aRecordTypeID,
+				},
+			},
+			{
+				Action: tc.StaticDNSEntryBulkDelete,
+				Entry:  tc.StaticDNSEntryNullable{ID: util.IntPtr(host3ID)},
+			},
+		}
+		resp, reqInf, err = TOSession.BulkStaticDNSEntries(ops, client.RequestOptions{})
+		assert.Error(t, err, "Expected an error making bulk Static DNS Entry changes including an update without an ID")
+		assert.Equal(t, http.StatusBadRequest, reqInf.StatusCode, "Expected status code 400, got: %d", reqInf.StatusCode)
+		assert.RequireEqual(t, len(ops), len(resp.Response), "Expected a result for each of %d operations, got: %d", len(ops), len(resp.Response))
+		assert.Equal(t, 1, len(resp.Response[1].ErrorCodes()), "Expected an error for the failed operation, got alerts: %+v", resp.Response[1].Alerts)
+		opts.QueryParameters.Set("host", "bulk-rollback-test")
+		entries, _, err = TOSession.GetStaticDNSEntries(opts)
+		assert.RequireNoError(t, err, "Error getting Static DNS Entries: %v - alerts: %+v", err, entries.Alerts)
+		assert.Equal(t, 0, len(entries.Response), "Expected the Static DNS Entry created before a failed operation not to be, but it was found")
+		GetStaticDNSEntryID(t, "host3")()
+	})
+}
+
+func TestStaticDNSEntriesImport(t *testing.T) {
+	WithObjs(t, []TCObj{CDNs, Types, Tenants, Parameters, Profiles, Statuses, Divisions, Regions, PhysLocations, CacheGroups, Servers, Topologies, ServiceCategories, DeliveryServices, StaticDNSEntries}, func() {
+		req := tc.StaticDNSEntryImportRequest{
+			DeliveryServiceID: GetDeliveryServiceId(t, "ds1")(),
+			ZoneFile:          "$TTL 60\nimport-test\tIN\tA\t192.0.2.20\nhost2\t10\tIN\tA\t192.168.0.1\n",
+			DryRun:            true,
+		}
+		resp, reqInf, err := TOSession.ImportStaticDNSEntries(req, client.RequestOptions{})
+		assert.RequireNoError(t, err, "Unexpected error making a dry run of a Static DNS Entry import: %v - alerts: %+v", err, resp.Alerts)
+		assert.Equal(t, http.StatusOK, reqInf.StatusCode, "Expected status code 200, got: %d", reqInf.StatusCode)
+		assert.RequireEqual(t, 1, len(resp.Response), "Expected a result only for the record that needs a change, got: %d", len(resp.Response))
+		assert.Equal(t, 2, resp.Response[0].Line, "Expected the result for the record on line 2, got: %d", resp.Response[0].Line)
+		assert.Equal(t, tc.StaticDNSEntryBulkCreate, resp.Response[0].Action, "Expected the record to be created, got: %s", resp.Response[0].Action)
+		opts := client.NewRequestOptions()
+		opts.QueryParameters.Set("host", "import-test")
+		entries, _, err := TOSession.GetStaticDNSEntries(opts)
+		assert.RequireNoError(t, err, "Error getting Static DNS Entries: %v - alerts: %+v", err, entries.Alerts)
+		assert.Equal(t, 0, len(entries.Response), "Expected a dry run not to create a Static DNS Entry, but it was found")
+
+		req.DryRun = false
+		resp, reqInf, err = TOSession.ImportStaticDNSEntries(req, client.RequestOptions{})
+		assert.RequireNoError(t, err, "Unexpected error importing Static DNS Entries: %v - alerts: %+v", err, resp.Alerts)
+		assert.Equal(t, http.StatusOK, reqInf.StatusCode, "Expected status code 200, got: %d", reqInf.StatusCode)
+		validateStaticDNSEntriesUpdateCreateFields("import-test", map[string]interface{}{"Address": "192.0.2.20"})(t, reqInf, nil, tc.Alerts{}, nil)
+
+		req.ZoneFile = "other.example.com.\t60\tIN\tA\t192.0.2.21\n"
+		_, reqInf, err = TOSession.ImportStaticDNSEntries(req, client.RequestOptions{})
+		assert.Error(t, err, "Expected an error importing a record outside of the Delivery Service's domain")
+		assert.Equal(t, http.StatusBadRequest, reqInf.StatusCode, "Expected status code 400, got: %d", reqInf.StatusCode)
+	})
+}
+
 func validateStaticDNSEntriesFields(expectedResp map[string]interface{}) utils.CkReqFunc {
 	return func(t *testing.T, _ toclientlib.ReqInf, resp interface{}, _ tc.Alerts, _ error) {
 		assert.RequireNotNil(t, resp, "Expected Static DNS Entries response to not be nil.")
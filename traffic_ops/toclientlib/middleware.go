@@ -0,0 +1,287 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package toclientlib
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tracing"
+)
+
+// A RoundTripFunc sends a single HTTP request and returns its response, like
+// the Do method of an http.Client.
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// A Middleware wraps the RoundTripFunc that sends a TOClient's requests,
+// which allows a caller to log, measure, or modify requests and their
+// responses without replacing the client's http.Client. A Middleware is
+// expected to call next exactly once unless it returns an error - or a
+// response of its own - instead.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// composeMiddlewares returns do wrapped in the given Middlewares, such that
+// the first Middleware is the first to see each request, and the last to see
+// its response.
+func composeMiddlewares(do RoundTripFunc, middlewares []Middleware) RoundTripFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		do = middlewares[i](do)
+	}
+	return do
+}
+
+// do sends req with the client's http.Client, through its Middlewares.
+func (to *TOClient) do(req *http.Request) (*http.Response, error) {
+	return composeMiddlewares(to.Client.Do, to.Middlewares)(req)
+}
+
+// LogRequests returns a Middleware which logs the method, URL, response
+// status, and duration of each request using logf, or the go-log Info logger
+// if logf is nil. Headers and bodies are never logged, since they contain
+// the client's credentials.
+func LogRequests(logf func(format string, v ...interface{})) Middleware {
+	if logf == nil {
+		logf = log.Infof
+	}
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			elapsed := time.Since(start)
+			// The query string is left out, because it may contain secrets
+			// like tokens.
+			u := *req.URL
+			u.RawQuery = ""
+			u.User = nil
+			if err != nil {
+				logf("Traffic Ops request %s %s failed after %v: %v", req.Method, u.String(), elapsed, err)
+			} else {
+				logf("Traffic Ops request %s %s returned %d in %v", req.Method, u.String(), resp.StatusCode, elapsed)
+			}
+			return resp, err
+		}
+	}
+}
+
+// TraceRequests returns a Middleware which records a client span of each
+// request with tracer, as a child of the span in the request's context - e.g.
+// the Context of the v5 client's RequestOptions - and propagates it to
+// Traffic Ops in the request's traceparent header, so the request's spans in
+// Traffic Ops are part of the same trace. If tracer is nil, the span in the
+// request's context, if any, is propagated without recording a span of the
+// request.
+func TraceRequests(tracer *tracing.Tracer) Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			ctx, span := tracer.Start(req.Context(), "HTTP "+req.Method, tracing.SpanKindClient)
+			if span != nil {
+				req = req.WithContext(ctx)
+				u := *req.URL
+				u.RawQuery = ""
+				u.User = nil
+				span.SetAttribute("http.method", req.Method)
+				span.SetAttribute("http.url", u.String())
+			}
+			tracing.Inject(ctx, req.Header)
+			resp, err := next(req)
+			if err != nil {
+				span.SetError(err)
+			} else {
+				span.SetAttribute("http.status_code", strconv.Itoa(resp.StatusCode))
+				if resp.StatusCode >= http.StatusInternalServerError {
+					span.SetError(fmt.Errorf("Traffic Ops returned %d", resp.StatusCode))
+				}
+			}
+			span.Finish()
+			return resp, err
+		}
+	}
+}
+
+// DefaultRequestDurationBuckets are the default upper bounds, in seconds, of
+// the buckets of a RequestDurationHistogram.
+var DefaultRequestDurationBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30}
+
+// A RequestDurationHistogram is a Prometheus histogram of the durations of a
+// TOClient's requests, labeled by their method, path, and response status
+// code. Requests which fail without a response have the code "error".
+//
+// It serves the histogram in the Prometheus text exposition format as an
+// http.Handler, so it may be added to an application's existing metrics
+// endpoint.
+type RequestDurationHistogram struct {
+	name    string
+	help    string
+	buckets []float64
+
+	mutex  sync.Mutex
+	series map[requestLabels]*histogramSeries
+}
+
+type requestLabels struct {
+	method string
+	path   string
+	code   string
+}
+
+type histogramSeries struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewRequestDurationHistogram returns a RequestDurationHistogram with the
+// given metric name and bucket upper bounds in seconds. If name is empty,
+// "traffic_ops_client_request_duration_seconds" is used, and if buckets is
+// empty, DefaultRequestDurationBuckets are used.
+func NewRequestDurationHistogram(name string, buckets []float64) *RequestDurationHistogram {
+	if name == "" {
+		name = "traffic_ops_client_request_duration_seconds"
+	}
+	if len(buckets) == 0 {
+		buckets = DefaultRequestDurationBuckets
+	}
+	sorted := make([]float64, len(buckets))
+	copy(sorted, buckets)
+	sort.Float64s(sorted)
+	return &RequestDurationHistogram{
+		name:    name,
+		help:    "Duration of Traffic Ops API requests in seconds.",
+		buckets: sorted,
+		series:  map[requestLabels]*histogramSeries{},
+	}
+}
+
+// Middleware returns a Middleware which observes the duration of each request
+// in the histogram.
+func (h *RequestDurationHistogram) Middleware() Middleware {
+	return func(next RoundTripFunc) RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+			code := "error"
+			if err == nil {
+				code = strconv.Itoa(resp.StatusCode)
+			}
+			h.Observe(req.Method, req.URL.Path, code, time.Since(start))
+			return resp, err
+		}
+	}
+}
+
+// Observe records a request with the given method, URL path, and response
+// code which took the given duration.
+func (h *RequestDurationHistogram) Observe(method, path, code string, duration time.Duration) {
+	labels := requestLabels{method: method, path: RequestPathLabel(path), code: code}
+	seconds := duration.Seconds()
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	s, ok := h.series[labels]
+	if !ok {
+		s = &histogramSeries{counts: make([]uint64, len(h.buckets))}
+		h.series[labels] = s
+	}
+	for i, le := range h.buckets {
+		if seconds <= le {
+			s.counts[i]++
+		}
+	}
+	s.count++
+	s.sum += seconds
+}
+
+var apiPathPrefix = regexp.MustCompile(`^/api/[0-9]+\.[0-9]+`)
+
+// RequestPathLabel returns the value of the path label of requests to the
+// given URL path, which has the API version removed and its numeric
+// segments replaced with "{id}", so that the label has a limited number of
+// values - e.g. "/api/4.1/servers/12" is labeled "/servers/{id}".
+func RequestPathLabel(path string) string {
+	path = apiPathPrefix.ReplaceAllString(path, "")
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if _, err := strconv.ParseUint(segment, 10, 64); err == nil {
+			segments[i] = "{id}"
+		}
+	}
+	path = strings.Join(segments, "/")
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func formatBound(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// WriteTo writes the histogram to w in the Prometheus text exposition format.
+func (h *RequestDurationHistogram) WriteTo(w io.Writer) (int64, error) {
+	h.mutex.Lock()
+	labels := make([]requestLabels, 0, len(h.series))
+	series := make(map[requestLabels]histogramSeries, len(h.series))
+	for l, s := range h.series {
+		labels = append(labels, l)
+		series[l] = histogramSeries{counts: append([]uint64(nil), s.counts...), count: s.count, sum: s.sum}
+	}
+	h.mutex.Unlock()
+
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].path != labels[j].path {
+			return labels[i].path < labels[j].path
+		}
+		if labels[i].method != labels[j].method {
+			return labels[i].method < labels[j].method
+		}
+		return labels[i].code < labels[j].code
+	})
+
+	buf := bytes.Buffer{}
+	fmt.Fprintf(&buf, "# HELP %s %s\n", h.name, h.help)
+	fmt.Fprintf(&buf, "# TYPE %s histogram\n", h.name)
+	for _, l := range labels {
+		s := series[l]
+		lbls := fmt.Sprintf(`method="%s",path="%s",code="%s"`, labelValueEscaper.Replace(l.method), labelValueEscaper.Replace(l.path), labelValueEscaper.Replace(l.code))
+		for i, le := range h.buckets {
+			fmt.Fprintf(&buf, "%s_bucket{%s,le=\"%s\"} %d\n", h.name, lbls, formatBound(le), s.counts[i])
+		}
+		fmt.Fprintf(&buf, "%s_bucket{%s,le=\"+Inf\"} %d\n", h.name, lbls, s.count)
+		fmt.Fprintf(&buf, "%s_sum{%s} %s\n", h.name, lbls, formatBound(s.sum))
+		fmt.Fprintf(&buf, "%s_count{%s} %d\n", h.name, lbls, s.count)
+	}
+	return buf.WriteTo(w)
+}
+
+// ServeHTTP implements http.Handler by writing the histogram in the
+// Prometheus text exposition format.
+func (h *RequestDurationHistogram) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if _, err := h.WriteTo(w); err != nil {
+		log.Errorf("writing Traffic Ops client request duration histogram: %v", err)
+	}
+}
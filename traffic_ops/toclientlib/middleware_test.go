@@ -0,0 +1,192 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package toclientlib
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tracing"
+)
+
+func TestMiddlewares(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"response": "` + r.Header.Get("X-Test") + `"}`))
+	}))
+	defer srv.Close()
+
+	var order []string
+	named := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				order = append(order, name+" request")
+				req.Header.Set("X-Test", req.Header.Get("X-Test")+name)
+				resp, err := next(req)
+				order = append(order, name+" response")
+				return resp, err
+			}
+		}
+	}
+
+	var logged []string
+	to := NewClient("user", "pass", srv.URL, "test", srv.Client(), []string{"4.1"})
+	to.Middlewares = []Middleware{
+		named("a"),
+		named("b"),
+		LogRequests(func(format string, v ...interface{}) { logged = append(logged, fmt.Sprintf(format, v...)) }),
+	}
+
+	var resp struct {
+		Response string `json:"response"`
+	}
+	if _, err := to.Req(http.MethodGet, "/things?token=secret", nil, nil, &resp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Response != "ab" {
+		t.Errorf("expected the request to be modified by both middlewares in order, actual header: %q", resp.Response)
+	}
+	expected := []string{"a request", "b request", "b response", "a response"}
+	if strings.Join(order, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected middlewares to be called in the order %v, actual: %v", expected, order)
+	}
+	if len(logged) != 1 {
+		t.Fatalf("expected one logged request, actual: %v", logged)
+	}
+	if !strings.Contains(logged[0], "GET "+srv.URL+"/api/4.1/things returned 200") || strings.Contains(logged[0], "secret") {
+		t.Errorf("expected the request to be logged without its query string, actual: %s", logged[0])
+	}
+}
+
+func TestRequestPathLabel(t *testing.T) {
+	for path, expected := range map[string]string{
+		"/api/4.1/servers":              "/servers",
+		"/api/5.0/servers/12":           "/servers/{id}",
+		"/api/4.1/servers/12/status":    "/servers/{id}/status",
+		"/api/4.1/deliveryservices/ab1": "/deliveryservices/ab1",
+		"/api/4.1":                      "/",
+	} {
+		if actual := RequestPathLabel(path); actual != expected {
+			t.Errorf("expected the label of %q to be %q, actual: %q", path, expected, actual)
+		}
+	}
+}
+
+func TestRequestDurationHistogram(t *testing.T) {
+	h := NewRequestDurationHistogram("", []float64{1, 0.1})
+	h.Observe(http.MethodGet, "/api/4.1/servers/1", "200", 50*time.Millisecond)
+	h.Observe(http.MethodGet, "/api/4.1/servers/2", "200", 500*time.Millisecond)
+	h.Observe(http.MethodGet, "/api/4.1/servers/3", "200", 2*time.Second)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	expected := `# HELP traffic_ops_client_request_duration_seconds Duration of Traffic Ops API requests in seconds.
+# TYPE traffic_ops_client_request_duration_seconds histogram
+traffic_ops_client_request_duration_seconds_bucket{method="GET",path="/servers/{id}",code="200",le="0.1"} 1
+traffic_ops_client_request_duration_seconds_bucket{method="GET",path="/servers/{id}",code="200",le="1"} 2
+traffic_ops_client_request_duration_seconds_bucket{method="GET",path="/servers/{id}",code="200",le="+Inf"} 3
+traffic_ops_client_request_duration_seconds_sum{method="GET",path="/servers/{id}",code="200"} 2.55
+traffic_ops_client_request_duration_seconds_count{method="GET",path="/servers/{id}",code="200"} 3
+`
+	if actual := w.Body.String(); actual != expected {
+		t.Errorf("expected histogram:\n%s\nactual:\n%s", expected, actual)
+	}
+}
+
+func TestRequestDurationHistogramMiddleware(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	h := NewRequestDurationHistogram("to_requests", nil)
+	to := NewClient("user", "pass", srv.URL, "test", srv.Client(), []string{"4.1"})
+	to.Middlewares = []Middleware{h.Middleware()}
+	resp, _, err := to.RawRequestWithHdr(http.MethodDelete, "/api/4.1/servers/7", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	out := strings.Builder{}
+	if _, err := h.WriteTo(&out); err != nil {
+		t.Fatalf("unexpected error writing the histogram: %v", err)
+	}
+	if !strings.Contains(out.String(), `to_requests_count{method="DELETE",path="/servers/{id}",code="404"} 1`) {
+		t.Errorf("expected the request to be observed, actual:\n%s", out.String())
+	}
+}
+
+type spanRecorder struct {
+	spans []*tracing.Span
+}
+
+func (r *spanRecorder) Export(spans []*tracing.Span) error {
+	r.spans = append(r.spans, spans...)
+	return nil
+}
+
+func TestTraceRequests(t *testing.T) {
+	var traceParent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceParent = r.Header.Get(tracing.TraceParentHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	rec := &spanRecorder{}
+	tracer := tracing.NewTracer(rec, 1)
+	ctx, parent := tracer.Start(context.Background(), "sync", tracing.SpanKindInternal)
+
+	to := NewClient("user", "pass", srv.URL, "test", srv.Client(), []string{"4.1"})
+	to.Middlewares = []Middleware{TraceRequests(tracer)}
+	resp, _, err := to.RawRequestWithContext(ctx, http.MethodGet, "/api/4.1/servers?hostName=x", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	parent.Finish()
+	tracer.Close()
+
+	if len(rec.spans) != 2 {
+		t.Fatalf("expected the request and parent spans, actual: %v", rec.spans)
+	}
+	span := rec.spans[0]
+	if span.Parent != parent.Context.SpanID || span.Kind != tracing.SpanKindClient {
+		t.Errorf("expected a client span which is a child of the parent span, actual: %+v", span)
+	}
+	if span.Attributes["http.url"] != srv.URL+"/api/4.1/servers" || span.Attributes["http.status_code"] != "204" {
+		t.Errorf("expected the request's URL without its query and status, actual: %v", span.Attributes)
+	}
+	if traceParent != span.Context.TraceParent() {
+		t.Errorf("expected the request span to be propagated, actual traceparent: %q", traceParent)
+	}
+
+	// Without a tracer, the caller's span is still propagated.
+	to.Middlewares = []Middleware{TraceRequests(nil)}
+	resp, _, err = to.RawRequestWithContext(ctx, http.MethodGet, "/api/4.1/servers", nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if traceParent != parent.Context.TraceParent() {
+		t.Errorf("expected the parent span to be propagated, actual traceparent: %q", traceParent)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package toclientlib
+
+import (
+	"net/http"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// Paginated is a response to a request for a collection that supports cursor
+// pagination, e.g. tc.InvalidationJobsResponseV4.
+type Paginated interface {
+	Cursors() *tc.Pagination
+}
+
+// EachPage requests each page of a collection in turn with get - which is
+// given the cursor of the page to request, empty for the first page - passing
+// each response to fn, until there are no more pages or fn returns false.
+//
+// Requesting a page stops the paging if it fails or the page wasn't
+// modified. The returned ReqInf is that of the last request made.
+func EachPage[R Paginated](get func(cursor string) (R, ReqInf, error), fn func(R) bool) (ReqInf, error) {
+	cursor := ""
+	for {
+		resp, reqInf, err := get(cursor)
+		if err != nil || reqInf.StatusCode == http.StatusNotModified {
+			return reqInf, err
+		}
+		if !fn(resp) {
+			return reqInf, nil
+		}
+		cursors := resp.Cursors()
+		if cursors == nil || cursors.Next == "" {
+			return reqInf, nil
+		}
+		cursor = cursors.Next
+	}
+}
+
+// AllPages requests each page of a collection in turn with get, as EachPage
+// does, and returns all of the objects in them, in order, as given by items.
+// If requesting a page fails, the objects of the pages before it are returned
+// along with the error.
+func AllPages[R Paginated, T any](get func(cursor string) (R, ReqInf, error), items func(R) []T) ([]T, ReqInf, error) {
+	all := []T{}
+	reqInf, err := EachPage(get, func(resp R) bool {
+		all = append(all, items(resp)...)
+		return true
+	})
+	return all, reqInf, err
+}
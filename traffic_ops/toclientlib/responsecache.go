@@ -0,0 +1,115 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package toclientlib
+
+import (
+	"container/list"
+	"net/http"
+	"sync"
+)
+
+// A CachedResponse is a response to a GET request kept by a ResponseCache,
+// along with the validators with which Traffic Ops is asked whether it's
+// changed.
+type CachedResponse struct {
+	// Body is the body of the response.
+	Body []byte
+	// Header is the header of the response.
+	Header http.Header
+	// ETag is the entity tag of the response, sent in the If-None-Match
+	// header of later requests.
+	ETag string
+	// LastModified is the Last-Modified header of the response, sent in the
+	// If-Modified-Since header of later requests.
+	LastModified string
+}
+
+// A ResponseCache keeps the responses to a TOClient's GET requests, by their
+// URLs (including their query strings), so that the client requests them
+// again only if they've changed. Implementations must be safe for concurrent
+// use.
+//
+// The responses a TOClient caches are only those its user is allowed to see,
+// so a ResponseCache should not be shared by clients of different users.
+type ResponseCache interface {
+	// Get returns the response cached for the given key, and whether there
+	// is one.
+	Get(key string) (CachedResponse, bool)
+	// Set caches the given response for the given key.
+	Set(key string, resp CachedResponse)
+}
+
+// memoryResponseCache is a ResponseCache which keeps a limited number of
+// responses in memory, evicting those least recently used.
+type memoryResponseCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type memoryResponseCacheEntry struct {
+	key  string
+	resp CachedResponse
+}
+
+// NewMemoryResponseCache returns a ResponseCache which keeps up to
+// maxEntries responses in memory, evicting those least recently used. If
+// maxEntries is not positive, DefaultResponseCacheEntries is used.
+func NewMemoryResponseCache(maxEntries int) ResponseCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultResponseCacheEntries
+	}
+	return &memoryResponseCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    map[string]*list.Element{},
+	}
+}
+
+// DefaultResponseCacheEntries is the default number of responses kept by a
+// ResponseCache returned by NewMemoryResponseCache.
+const DefaultResponseCacheEntries = 1000
+
+// Get implements the ResponseCache interface.
+func (c *memoryResponseCache) Get(key string) (CachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return CachedResponse{}, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*memoryResponseCacheEntry).resp, true
+}
+
+// Set implements the ResponseCache interface.
+func (c *memoryResponseCache) Set(key string, resp CachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*memoryResponseCacheEntry).resp = resp
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&memoryResponseCacheEntry{key: key, resp: resp})
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryResponseCacheEntry).key)
+	}
+}
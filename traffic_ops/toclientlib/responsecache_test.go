@@ -0,0 +1,132 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package toclientlib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+)
+
+func TestMemoryResponseCache(t *testing.T) {
+	c := NewMemoryResponseCache(2)
+	c.Set("a", CachedResponse{ETag: `"a"`})
+	c.Set("b", CachedResponse{ETag: `"b"`})
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected a cached response for 'a'")
+	}
+	c.Set("c", CachedResponse{ETag: `"c"`})
+	if _, ok := c.Get("b"); ok {
+		t.Error("expected the least recently used response to be evicted")
+	}
+	if resp, ok := c.Get("a"); !ok || resp.ETag != `"a"` {
+		t.Errorf("expected the recently used response to be kept, actual: %+v %t", resp, ok)
+	}
+	c.Set("a", CachedResponse{ETag: `"a2"`})
+	if resp, _ := c.Get("a"); resp.ETag != `"a2"` {
+		t.Errorf("expected the cached response to be replaced, actual: %+v", resp)
+	}
+}
+
+func TestReqResponseCache(t *testing.T) {
+	var requests, notModified int32
+	body := `{"response": "ok"}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.Header.Get(rfc.IfNoneMatch) == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set(rfc.ETagHeader, `"v1"`)
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	to := NewClient("user", "pass", srv.URL, "test", srv.Client(), []string{"4.1"})
+	to.ResponseCache = NewMemoryResponseCache(0)
+
+	for i := 0; i < 2; i++ {
+		var resp struct {
+			Response string `json:"response"`
+		}
+		inf, err := to.Req(http.MethodGet, "/things?x=1", nil, nil, &resp)
+		if err != nil {
+			t.Fatalf("request #%d: unexpected error: %v", i+1, err)
+		}
+		if inf.StatusCode != http.StatusOK || resp.Response != "ok" || inf.ETag != `"v1"` {
+			t.Errorf("request #%d: expected the response with its ETag, actual: %d %+v %s", i+1, inf.StatusCode, resp, inf.ETag)
+		}
+		if inf.FromCache != (i == 1) {
+			t.Errorf("request #%d: expected FromCache to be %t, actual: %t", i+1, i == 1, inf.FromCache)
+		}
+	}
+	if n := atomic.LoadInt32(&notModified); n != 1 {
+		t.Errorf("expected the second request to be answered by 304 Not Modified, actual 304s: %d", n)
+	}
+
+	hdr := http.Header{}
+	hdr.Set(rfc.IfNoneMatch, `"v1"`)
+	var resp interface{}
+	inf, err := to.Req(http.MethodGet, "/things?x=1", nil, hdr, &resp)
+	if err != nil || inf.StatusCode != http.StatusNotModified || inf.FromCache {
+		t.Errorf("expected a request the caller made conditional to get the 304, actual: %d %t %v", inf.StatusCode, inf.FromCache, err)
+	}
+
+	var other interface{}
+	if inf, err := to.Req(http.MethodGet, "/things?x=2", nil, nil, &other); err != nil || inf.FromCache {
+		t.Errorf("expected a request with other query parameters not to use the cached response, actual: %t %v", inf.FromCache, err)
+	}
+}
+
+func TestReqResponseCacheCopiesBody(t *testing.T) {
+	body := `{"response": "ok"}`
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(rfc.IfNoneMatch) == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set(rfc.ETagHeader, `"v1"`)
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	to := NewClient("user", "pass", srv.URL, "test", srv.Client(), []string{"4.1"})
+	to.ResponseCache = NewMemoryResponseCache(0)
+
+	// The first response is cached, and the others taken from the cache;
+	// modifying any of them mustn't modify the cached response.
+	for i := 0; i < 3; i++ {
+		var bts []byte
+		inf, err := to.Req(http.MethodGet, "/things", nil, nil, &bts)
+		if err != nil {
+			t.Fatalf("request #%d: unexpected error: %v", i+1, err)
+		}
+		if string(bts) != body || inf.RespHeaders.Get(rfc.ETagHeader) != `"v1"` {
+			t.Fatalf("request #%d: expected the unmodified response, actual: '%s' %v", i+1, string(bts), inf.RespHeaders)
+		}
+		if inf.FromCache != (i > 0) {
+			t.Errorf("request #%d: expected FromCache to be %t, actual: %t", i+1, i > 0, inf.FromCache)
+		}
+		for j := range bts {
+			bts[j] = 'x'
+		}
+		inf.RespHeaders.Set(rfc.ETagHeader, `"modified"`)
+	}
+}
@@ -18,6 +18,7 @@ package toclientlib
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
@@ -32,6 +33,7 @@ import (
 	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"golang.org/x/net/publicsuffix"
 )
@@ -75,8 +77,10 @@ func Login(url, user, pass string, opts ClientOpts, apiVersions []string) (*TOCl
 
 	to.forceLatestAPI = opts.ForceLatestAPI
 	to.apiVerCheckInterval = opts.APIVersionCheckInterval
+	to.ResponseCache = opts.ResponseCache
+	to.Middlewares = opts.Middlewares
 
-	reqInf, err := to.login()
+	reqInf, err := to.login(context.Background())
 	if err != nil {
 		log.Errorf("DEBUG toclientlib.Login err reqInf %+v\n", reqInf)
 		return nil, reqInf, errors.New("logging in: " + err.Error())
@@ -126,6 +130,16 @@ type ClientOpts struct {
 	//
 	// This has no effect if ForceLatestAPI is true.
 	APIVersionCheckInterval time.Duration
+
+	// ResponseCache, if not nil, keeps the responses to the client's GET
+	// requests, which are then made conditional on them, so that Traffic Ops
+	// only sends responses that have changed - e.g.
+	// NewMemoryResponseCache(0).
+	ResponseCache ResponseCache
+
+	// Middlewares wrap every HTTP request the client sends, including the
+	// login request - see Middleware. The first Middleware is the outermost.
+	Middlewares []Middleware
 }
 
 // TOClient is a Traffic Ops client, with generic functions to be used by any specific client.
@@ -135,6 +149,11 @@ type TOClient struct {
 	URL          string
 	Client       *http.Client
 	UserAgentStr string
+	// ResponseCache, if not nil, keeps the responses to the client's GET
+	// requests, which are then made conditional on them - see ResponseCache.
+	ResponseCache ResponseCache
+	// Middlewares wrap every HTTP request the client sends - see Middleware.
+	Middlewares []Middleware
 
 	latestSupportedAPI string
 	// forceLatestAPI is whether to forcibly always use the latest API version known to this client.
@@ -150,6 +169,14 @@ type TOClient struct {
 	// apiVersions is the list of support Traffic Ops versions.
 	// This must be provided on construction, typically by the client wrapping this lib.
 	apiVersions []string
+
+	// token is the authentication token with which the client logs in, if
+	// it was created by LoginWithToken.
+	token string
+	// certificateLogin is whether the client logs in with the client
+	// certificate of its transport, as when it was created by
+	// LoginWithCertificate.
+	certificateLogin bool
 }
 
 // NewClient returns a reference to a TOClient instance with the given settings.
@@ -187,6 +214,53 @@ func (e *HTTPError) Error() string {
 	return fmt.Sprintf("%s[%d] - Error requesting Traffic Ops %s %s", e.HTTPStatus, e.HTTPStatusCode, e.URL, e.Body)
 }
 
+// AlertsError is the error returned by requests to which Traffic Ops responds
+// with an error and error-level Alerts. Unlike the text of the error, the
+// Codes and Fields of the Alerts are stable, so automation should use them -
+// with errors.As - to react to specific errors.
+type AlertsError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Alerts are the error-level Alerts of the response.
+	Alerts []tc.Alert
+	err    error
+}
+
+// Error implements the error interface.
+func (e *AlertsError) Error() string {
+	return fmt.Sprintf("%v - error-level alerts: %s", e.err, tc.Alerts{Alerts: e.Alerts}.ErrorString())
+}
+
+// Unwrap returns the error of the request itself.
+func (e *AlertsError) Unwrap() error {
+	return e.err
+}
+
+// HasCode returns whether any of the Alerts has the given Code.
+func (e *AlertsError) HasCode(code string) bool {
+	for _, alert := range e.Alerts {
+		if alert.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// newAlertsError returns an *AlertsError wrapping err for the error-level
+// Alerts among the given Alerts, or err itself if there are none.
+func newAlertsError(statusCode int, err error, alerts tc.Alerts) error {
+	errAlerts := []tc.Alert{}
+	for _, alert := range alerts.Alerts {
+		if alert.Level == tc.ErrorLevel.String() {
+			errAlerts = append(errAlerts, alert)
+		}
+	}
+	if len(errAlerts) == 0 {
+		return err
+	}
+	return &AlertsError{StatusCode: statusCode, Alerts: errAlerts, err: err}
+}
+
 // loginCreds gathers login credentials for Traffic Ops.
 func loginCreds(toUser string, toPasswd string) ([]byte, error) {
 	credentials := tc.UserCredentials{
@@ -217,15 +291,26 @@ func loginToken(token string) ([]byte, error) {
 }
 
 // login tries to log in to Traffic Ops, and set the auth cookie in the Client. Returns the IP address of the remote Traffic Ops.
-func (to *TOClient) login() (ReqInf, error) {
+//
+// The client logs in the same way it originally did, so that expired sessions
+// of clients created by LoginWithToken or LoginWithCertificate are renewed with
+// their token or client certificate, respectively.
+func (to *TOClient) login(ctx context.Context) (ReqInf, error) {
 	path := "/user/login"
-	body := tc.UserCredentials{Username: to.UserName, Password: to.Password}
+	var body interface{} = tc.UserCredentials{Username: to.UserName, Password: to.Password}
+	if to.token != "" {
+		path = "/user/login/token"
+		body = tc.UserToken{Token: to.token}
+	} else if to.certificateLogin {
+		path = "/user/login/certificate"
+		body = nil
+	}
 	alerts := tc.Alerts{}
 
 	// Can't use req() because it retries login failures, which would be an infinite loop.
 	reqF := composeReqFuncs(makeRequestWithHeader, []MidReqF{reqTryLatest, reqFallback, reqAPI})
 
-	reqInf, err := reqF(to, http.MethodPost, path, body, nil, &alerts, true)
+	reqInf, err := reqF(ctx, to, http.MethodPost, path, body, nil, &alerts, true)
 	if err != nil {
 		return reqInf, fmt.Errorf("Login error %w, alerts string: %+v", err, alerts)
 	}
@@ -362,7 +447,7 @@ func LoginWithAgent(
 		Jar: jar,
 	}, apiVersions)
 
-	reqInf, err := to.login()
+	reqInf, err := to.login(context.Background())
 	if err != nil {
 		return nil, reqInf.RemoteAddr, errors.New("logging in: " + err.Error())
 	}
@@ -413,6 +498,7 @@ func LoginWithToken(
 	}
 
 	to := NewClient("", "", toURL, userAgent, &client, apiVersions)
+	to.token = token
 	tBts, err := loginToken(token)
 	if err != nil {
 		return nil, nil, fmt.Errorf("encoding login token: %w", err)
@@ -425,6 +511,63 @@ func LoginWithToken(
 	return to, remoteAddr, nil
 }
 
+// LoginWithCertificate returns an authenticated TOClient, using the given TLS
+// client certificate for said authentication. Traffic Ops logs in the user
+// named by the Common Name of the certificate's Subject, and only if it was
+// issued by one of Traffic Ops's configured client certificate authorities.
+//
+// Start with
+//
+//	toURL := "https://trafficops.example"
+//	apiVers := []string{"4.1", "5.0"}
+//	cert, err := tls.LoadX509KeyPair("client.crt", "client.key")
+//	to := LoginWithCertificate(toURL, cert, true, "myapp/1.0", DefaultTimeout, apiVers)
+//
+// subsequent calls like to.GetData("datadeliveryservice") will be authenticated.
+//
+// Returns the logged in client, the remote IP address of Traffic Ops to which
+// the given URL was resolved and used to authenticate, and any error that
+// occurred. If the error is not nil, the remote address may or may not be nil,
+// depending whether the error occurred before the login request.
+//
+// apiVersions is the list of API versions supported in this client. This
+// should generally be provided by the client package wrapping this package.
+func LoginWithCertificate(
+	toURL string,
+	cert tls.Certificate,
+	insecure bool,
+	userAgent string,
+	requestTimeout time.Duration,
+	apiVersions []string,
+) (*TOClient, net.Addr, error) {
+	options := cookiejar.Options{
+		PublicSuffixList: publicsuffix.List,
+	}
+
+	jar, err := cookiejar.New(&options)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	to := NewClient("", "", toURL, userAgent, &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: insecure,
+				Certificates:       []tls.Certificate{cert},
+			},
+		},
+		Jar: jar,
+	}, apiVersions)
+	to.certificateLogin = true
+
+	reqInf, err := to.login(context.Background())
+	if err != nil {
+		return nil, reqInf.RemoteAddr, fmt.Errorf("logging in: %w", err)
+	}
+	return to, reqInf.RemoteAddr, nil
+}
+
 // LogoutWithAgent creates a new TOClient, authenticates that client with
 // Traffic Ops, then immediately logs out before returning the TOClient. As a
 // result, the returned TOClient is *not* authenticated, but it is verified
@@ -542,9 +685,10 @@ func (to *TOClient) getURL(path string) string {
 }
 
 // A ReqF is a function that can produce a ReqInf and any occurring error from
-// a TOClient, a request method and path, an optional request bod, an HTTP
-// header, and optionally decode the response into a provided reference.
-type ReqF func(to *TOClient, method string, path string, body interface{}, header http.Header, response interface{}, raw bool) (ReqInf, error)
+// a Context, a TOClient, a request method and path, an optional request body,
+// an HTTP header, and optionally decode the response into a provided
+// reference. The request is canceled when the Context is done.
+type ReqF func(ctx context.Context, to *TOClient, method string, path string, body interface{}, header http.Header, response interface{}, raw bool) (ReqInf, error)
 
 // A MidReqF is a middleware that operates on a ReqF to return a ReqF with some
 // additional behavior added.
@@ -563,7 +707,7 @@ func composeReqFuncs(reqF ReqF, middleware []MidReqF) ReqF {
 // reqTryLatest will re-set to.latestSupportedAPI to the latest, if it's less than the latest and to.apiVerCheckInterval has passed.
 // This does not fallback, so it should generally be composed with reqFallback.
 func reqTryLatest(reqF ReqF) ReqF {
-	return func(to *TOClient, method string, path string, body interface{}, header http.Header, response interface{}, raw bool) (ReqInf, error) {
+	return func(ctx context.Context, to *TOClient, method string, path string, body interface{}, header http.Header, response interface{}, raw bool) (ReqInf, error) {
 		if to.apiVerCheckInterval == 0 {
 			// Client could have been default-initialized rather than created with a func, so we need to check here, not just in login funcs.
 			to.apiVerCheckInterval = DefaultAPIVersionCheckInterval
@@ -581,7 +725,7 @@ func reqTryLatest(reqF ReqF) ReqF {
 			to.lastAPIVerCheck = time.Now().Add(time.Hour * 24 * 365)
 			defer func() { to.lastAPIVerCheck = time.Now() }()
 		}
-		return reqF(to, method, path, body, header, response, raw)
+		return reqF(ctx, to, method, path, body, header, response, raw)
 	}
 }
 
@@ -591,15 +735,15 @@ func reqTryLatest(reqF ReqF) ReqF {
 // This is designed to handle expired sessions, when the time between requests is longer than the session expiration;
 // it does not do perpetual retry.
 func reqLogin(reqF ReqF) ReqF {
-	return func(to *TOClient, method string, path string, body interface{}, header http.Header, response interface{}, raw bool) (ReqInf, error) {
-		inf, err := reqF(to, method, path, body, header, response, raw)
+	return func(ctx context.Context, to *TOClient, method string, path string, body interface{}, header http.Header, response interface{}, raw bool) (ReqInf, error) {
+		inf, err := reqF(ctx, to, method, path, body, header, response, raw)
 		if inf.StatusCode != http.StatusUnauthorized && inf.StatusCode != http.StatusForbidden {
 			return inf, err
 		}
-		if _, lerr := to.login(); lerr != nil {
+		if _, lerr := to.login(ctx); lerr != nil {
 			return inf, err
 		}
-		return reqF(to, method, path, body, header, response, raw)
+		return reqF(ctx, to, method, path, body, header, response, raw)
 	}
 }
 
@@ -607,9 +751,9 @@ func reqLogin(reqF ReqF) ReqF {
 // falls back to the previous and retries, recursively.
 // If all supported versions fail, the last response error is returned.
 func reqFallback(reqF ReqF) ReqF {
-	var fallbackFunc func(to *TOClient, method string, path string, body interface{}, header http.Header, response interface{}, raw bool) (ReqInf, error)
-	fallbackFunc = func(to *TOClient, method string, path string, body interface{}, header http.Header, response interface{}, raw bool) (ReqInf, error) {
-		inf, err := reqF(to, method, path, body, header, response, raw)
+	var fallbackFunc func(ctx context.Context, to *TOClient, method string, path string, body interface{}, header http.Header, response interface{}, raw bool) (ReqInf, error)
+	fallbackFunc = func(ctx context.Context, to *TOClient, method string, path string, body interface{}, header http.Header, response interface{}, raw bool) (ReqInf, error) {
+		inf, err := reqF(ctx, to, method, path, body, header, response, raw)
 		if err == nil {
 			return inf, nil
 		}
@@ -632,7 +776,7 @@ func reqFallback(reqF ReqF) ReqF {
 			return inf, err // we're already on the oldest minor supported, and the server doesn't support it.
 		}
 		to.latestSupportedAPI = apiVersions[nextAPIVerI]
-		return fallbackFunc(to, method, path, body, header, response, raw)
+		return fallbackFunc(ctx, to, method, path, body, header, response, raw)
 	}
 	return fallbackFunc
 }
@@ -643,9 +787,9 @@ func reqFallback(reqF ReqF) ReqF {
 // For example, path should be like '/deliveryservices'
 // and this will request '/api/3.1/deliveryservices'.
 func reqAPI(reqF ReqF) ReqF {
-	return func(to *TOClient, method string, path string, body interface{}, header http.Header, response interface{}, raw bool) (ReqInf, error) {
+	return func(ctx context.Context, to *TOClient, method string, path string, body interface{}, header http.Header, response interface{}, raw bool) (ReqInf, error) {
 		path = strings.TrimSuffix(to.APIBase(), "/") + "/" + strings.TrimPrefix(path, "/")
-		return reqF(to, method, path, body, header, response, raw)
+		return reqF(ctx, to, method, path, body, header, response, raw)
 	}
 }
 
@@ -653,7 +797,11 @@ func reqAPI(reqF ReqF) ReqF {
 // and decodes the response into the given response pointer.
 //
 // Note processing on the following codes:
-// 304 http.StatusNotModified  - Will return the 304 in ReqInf, a nil error, and a nil response.
+// 304 http.StatusNotModified  - Will return the 304 in ReqInf, a nil error, and a nil response -
+//
+//	unless the client has a ResponseCache, and the request was made
+//	conditional on the response it cached, in which case the cached
+//	response is returned as a 200.
 //
 // 401 http.StatusUnauthorized - Via to.request(), Same as 403 Forbidden.
 // 403 http.StatusForbidden    - Via to.request()
@@ -662,7 +810,7 @@ func reqAPI(reqF ReqF) ReqF {
 //	The second request is returned, even if it fails.
 //
 // To request the bytes without deserializing, pass a *[]byte response.
-func makeRequestWithHeader(to *TOClient, method, path string, body interface{}, header http.Header, response interface{}, raw bool) (ReqInf, error) {
+func makeRequestWithHeader(ctx context.Context, to *TOClient, method, path string, body interface{}, header http.Header, response interface{}, raw bool) (ReqInf, error) {
 	var remoteAddr net.Addr
 	var resp *http.Response
 	var err error
@@ -674,17 +822,47 @@ func makeRequestWithHeader(to *TOClient, method, path string, body interface{},
 			return reqInf, errors.New("marshalling request body: " + err.Error())
 		}
 	}
+
+	// Requests the caller made conditional themselves aren't cached, so
+	// that they get the 304s they asked for.
+	cacheKey := ""
+	var cached CachedResponse
+	haveCached := false
+	if to.ResponseCache != nil && method == http.MethodGet && header.Get(rfc.IfNoneMatch) == "" && header.Get(rfc.IfModifiedSince) == "" {
+		cacheKey = to.getURL(path)
+		if cached, haveCached = to.ResponseCache.Get(cacheKey); haveCached {
+			header = header.Clone()
+			if header == nil {
+				header = http.Header{}
+			}
+			if cached.ETag != "" {
+				header.Set(rfc.IfNoneMatch, cached.ETag)
+			}
+			if cached.LastModified != "" {
+				header.Set(rfc.IfModifiedSince, cached.LastModified)
+			}
+		}
+	}
 	if raw {
-		resp, remoteAddr, err = to.RawRequestWithHdr(method, path, reqBody, header)
+		resp, remoteAddr, err = to.RawRequestWithContext(ctx, method, path, reqBody, header)
 	} else {
-		resp, remoteAddr, err = to.request(method, path, reqBody, header)
+		resp, remoteAddr, err = to.request(ctx, method, path, reqBody, header)
 	}
 	reqInf.RemoteAddr = remoteAddr
 	if resp != nil {
 		reqInf.RespHeaders = resp.Header.Clone()
+		reqInf.ETag = resp.Header.Get(rfc.ETagHeader)
 		reqInf.StatusCode = resp.StatusCode
 		if reqInf.StatusCode == http.StatusNotModified {
-			return reqInf, nil
+			log.Close(resp.Body, "unable to close response body")
+			if !haveCached {
+				return reqInf, nil
+			}
+			reqInf.StatusCode = http.StatusOK
+			reqInf.RespHeaders = cached.Header.Clone()
+			reqInf.ETag = cached.ETag
+			reqInf.FromCache = true
+			return reqInf, decodeResponse(cached.Body, response, nil)
 		}
 		defer log.Close(resp.Body, "unable to close response body")
 		bts, readErr := ioutil.ReadAll(resp.Body)
@@ -704,20 +882,22 @@ func makeRequestWithHeader(to *TOClient, method, path string, body interface{},
 			// ignore errors; some responses may not be regularly-formed, and if
 			// it's a problem later steps will uncover it.
 			if e := json.Unmarshal(bts, &alerts); e == nil {
-				errStr := alerts.ErrorString()
-				if errStr != "" {
-					err = fmt.Errorf("%w - error-level alerts: %s", err, errStr)
-				}
+				err = newAlertsError(resp.StatusCode, err, alerts)
 			}
 		}
 
-		if btsPtr, isBytes := response.(*[]byte); isBytes {
-			*btsPtr = bts
-		} else if decodeErr := json.Unmarshal(bts, response); decodeErr != nil {
-			if err != nil {
-				err = fmt.Errorf("failed to decode response body (%v) after request error: %w", decodeErr, err)
-			} else {
-				err = errors.New("decoding response body: " + decodeErr.Error())
+		err = decodeResponse(bts, response, err)
+		if err == nil && cacheKey != "" && resp.StatusCode == http.StatusOK {
+			lastModified := resp.Header.Get(rfc.LastModified)
+			if reqInf.ETag != "" || lastModified != "" {
+				// The body given to the caller is a copy, but the headers
+				// aren't.
+				to.ResponseCache.Set(cacheKey, CachedResponse{
+					Body:         bts,
+					Header:       reqInf.RespHeaders.Clone(),
+					ETag:         reqInf.ETag,
+					LastModified: lastModified,
+				})
 			}
 		}
 	}
@@ -725,13 +905,39 @@ func makeRequestWithHeader(to *TOClient, method, path string, body interface{},
 	return reqInf, err
 }
 
+// decodeResponse decodes the given response body into the given reference -
+// or copies it, if that's a *[]byte - returning the given error of the
+// request, if any, wrapped with any error decoding it.
+//
+// The body is copied rather than given to the caller itself because it may
+// be shared with a ResponseCache, which callers mustn't be able to modify.
+func decodeResponse(bts []byte, response interface{}, err error) error {
+	if btsPtr, isBytes := response.(*[]byte); isBytes {
+		*btsPtr = make([]byte, len(bts))
+		copy(*btsPtr, bts)
+	} else if decodeErr := json.Unmarshal(bts, response); decodeErr != nil {
+		if err != nil {
+			return fmt.Errorf("failed to decode response body (%v) after request error: %w", decodeErr, err)
+		}
+		return errors.New("decoding response body: " + decodeErr.Error())
+	}
+	return err
+}
+
 // Req makes a request using the given HTTP request method, request path (which
 // should include any needed query string), optionally a request body, any
 // additional HTTP headers to send, and optionally a reference into which to
 // place a decoded response.
 func (to *TOClient) Req(method string, path string, body interface{}, header http.Header, response interface{}) (ReqInf, error) {
+	return to.ReqWithContext(context.Background(), method, path, body, header, response)
+}
+
+// ReqWithContext is like Req, but the request - along with any API version
+// fallback and logging in again that it does - is canceled when ctx is done,
+// in which case the returned error wraps ctx's error.
+func (to *TOClient) ReqWithContext(ctx context.Context, method string, path string, body interface{}, header http.Header, response interface{}) (ReqInf, error) {
 	reqF := composeReqFuncs(makeRequestWithHeader, []MidReqF{reqTryLatest, reqFallback, reqAPI, reqLogin})
-	return reqF(to, method, path, body, header, response, false)
+	return reqF(ctx, to, method, path, body, header, response, false)
 }
 
 // request performs the HTTP request to Traffic Ops, trying to refresh the
@@ -748,8 +954,8 @@ func (to *TOClient) Req(method string, path string, body interface{}, header htt
 // error messages. This violates the Go idiom that a non-nil error implies all
 // other values are undefined, but it's more straightforward than alternatives
 // like typecasting.
-func (to *TOClient) request(method, path string, body []byte, header http.Header) (*http.Response, net.Addr, error) {
-	r, remoteAddr, err := to.RawRequestWithHdr(method, path, body, header)
+func (to *TOClient) request(ctx context.Context, method, path string, body []byte, header http.Header) (*http.Response, net.Addr, error) {
+	r, remoteAddr, err := to.RawRequestWithContext(ctx, method, path, body, header)
 	if err != nil {
 		return r, remoteAddr, err
 	}
@@ -757,13 +963,13 @@ func (to *TOClient) request(method, path string, body []byte, header http.Header
 		err = to.errorFromStatusCode(r, err, path)
 		return r, remoteAddr, err
 	}
-	if _, lerr := to.login(); lerr != nil {
+	if _, lerr := to.login(ctx); lerr != nil {
 		err = to.errorFromStatusCode(r, err, path) // if re-logging-in fails, return the original request's response
 		return r, remoteAddr, err
 	}
 
 	// return second request, even if it's another Unauthorized or Forbidden.
-	r, remoteAddr, err = to.RawRequestWithHdr(method, path, body, header)
+	r, remoteAddr, err = to.RawRequestWithContext(ctx, method, path, body, header)
 	err = to.errorFromStatusCode(r, err, path)
 	return r, remoteAddr, err
 }
@@ -775,6 +981,13 @@ func (to *TOClient) request(method, path string, body []byte, header http.Header
 // meaning that authentication is not retried and API version fallback is not
 // done.
 func (to *TOClient) RawRequestWithHdr(method, path string, body []byte, header http.Header) (*http.Response, net.Addr, error) {
+	return to.RawRequestWithContext(context.Background(), method, path, body, header)
+}
+
+// RawRequestWithContext is like RawRequestWithHdr, but the request is
+// canceled when ctx is done - including while its response body is read,
+// which is useful for streamed responses.
+func (to *TOClient) RawRequestWithContext(ctx context.Context, method, path string, body []byte, header http.Header) (*http.Response, net.Addr, error) {
 	url := to.getURL(path)
 
 	var req *http.Request
@@ -805,9 +1018,9 @@ func (to *TOClient) RawRequestWithHdr(method, path string, body []byte, header h
 			remoteAddr = connInfo.Conn.RemoteAddr()
 		},
 	}
-	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+	req = req.WithContext(httptrace.WithClientTrace(ctx, trace))
 	req.Header.Set("User-Agent", to.UserAgentStr)
-	resp, err := to.Client.Do(req)
+	resp, err := to.do(req)
 	return resp, remoteAddr, err
 }
 
@@ -827,6 +1040,13 @@ type ReqInf struct {
 	RemoteAddr     net.Addr
 	StatusCode     int
 	RespHeaders    http.Header
+	// ETag is the entity tag of the response, which may be sent in an
+	// If-None-Match header to read the same objects again only if they've
+	// changed, or in an If-Match header to change them only if they haven't.
+	ETag string
+	// FromCache is whether the response is the one kept by the client's
+	// ResponseCache, which Traffic Ops reported hadn't changed.
+	FromCache bool
 }
 
 // CacheHitStatus is deprecated and will be removed in the next major version.
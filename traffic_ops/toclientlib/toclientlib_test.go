@@ -0,0 +1,68 @@
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package toclientlib
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReqWithContext(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Path == "/api/4.1/slow" {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte(`{"response": "ok"}`))
+	}))
+	defer srv.Close()
+
+	to := NewClient("user", "pass", srv.URL, "test", srv.Client(), []string{"4.1"})
+
+	var resp struct {
+		Response string `json:"response"`
+	}
+	inf, err := to.ReqWithContext(context.Background(), http.MethodGet, "/fast", nil, nil, &resp)
+	if err != nil {
+		t.Fatalf("unexpected error requesting with a background context: %v", err)
+	}
+	if inf.StatusCode != http.StatusOK || resp.Response != "ok" {
+		t.Errorf("expected a decoded 200 OK response, actual: %d %+v", inf.StatusCode, resp)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	atomic.StoreInt32(&requests, 0)
+	if _, err := to.ReqWithContext(ctx, http.MethodGet, "/slow", nil, nil, &resp); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request to end at the context's deadline, actual error: %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("expected a request ended by its context not to be retried, actual requests: %d", n)
+	}
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := to.ReqWithContext(canceled, http.MethodGet, "/fast", nil, nil, &resp); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a request with a canceled context not to be made, actual error: %v", err)
+	}
+}
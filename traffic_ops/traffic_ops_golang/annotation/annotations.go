@@ -0,0 +1,375 @@
+package annotation
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+const readQuery = `
+SELECT a.id,
+	a.description,
+	a.start_time,
+	a.end_time,
+	cdn.name,
+	a.cdn,
+	cg.name,
+	a.cachegroup,
+	ds.xml_id,
+	a.deliveryservice,
+	a.created_by,
+	a.last_updated
+FROM annotation AS a
+LEFT JOIN cdn ON cdn.id = a.cdn
+LEFT JOIN cachegroup AS cg ON cg.id = a.cachegroup
+LEFT JOIN deliveryservice AS ds ON ds.id = a.deliveryservice
+`
+
+const insertQuery = `
+INSERT INTO annotation (description, start_time, end_time, cdn, cachegroup, deliveryservice, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id
+`
+
+const updateQuery = `
+UPDATE annotation SET
+	description = $1,
+	start_time = $2,
+	end_time = $3,
+	cdn = $4,
+	cachegroup = $5,
+	deliveryservice = $6
+WHERE id = $7
+`
+
+const deleteQuery = `
+DELETE FROM annotation
+WHERE id = $1
+`
+
+// tenancyCondition hides the annotations of Delivery Services outside the
+// user's tenancy; annotations of CDNs and Cache Groups aren't tenanted.
+const tenancyCondition = ` (a.deliveryservice IS NULL OR ds.tenant_id = ANY(:tenants)) `
+
+// Read is the handler for GET requests to /annotations.
+func Read(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	queryParamsToQueryCols := map[string]dbhelpers.WhereColumnInfo{
+		"id":              dbhelpers.WhereColumnInfo{Column: "a.id", Checker: api.IsInt},
+		"cdn":             dbhelpers.WhereColumnInfo{Column: "cdn.name"},
+		"cachegroup":      dbhelpers.WhereColumnInfo{Column: "cg.name"},
+		"deliveryService": dbhelpers.WhereColumnInfo{Column: "ds.xml_id"},
+		"startTime":       dbhelpers.WhereColumnInfo{Column: "a.start_time"},
+		"endTime":         dbhelpers.WhereColumnInfo{Column: "a.end_time"},
+	}
+
+	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(inf.Params, queryParamsToQueryCols)
+	if len(errs) > 0 {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, util.JoinErrs(errs), nil)
+		return
+	}
+
+	conditions, userErr := timeConditions(inf.Params, queryValues)
+	if userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+
+	tenants, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting accessible tenants for user: %v", err))
+		return
+	}
+	queryValues["tenants"] = pq.Array(tenants)
+	conditions += tenancyCondition
+
+	if where == "" {
+		where = dbhelpers.BaseWhere + conditions
+	} else {
+		where += " AND" + conditions
+	}
+	if orderBy == "" {
+		orderBy = "\nORDER BY a.start_time, a.id"
+	}
+
+	rows, err := inf.Tx.NamedQuery(readQuery+where+orderBy+pagination, queryValues)
+	if err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		if sysErr != nil {
+			sysErr = fmt.Errorf("annotation read query: %v", sysErr)
+		}
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer rows.Close()
+
+	annotations := []tc.Annotation{}
+	for rows.Next() {
+		var a tc.Annotation
+		if err = scan(rows, &a); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("scanning annotations: "+err.Error()))
+			return
+		}
+		annotations = append(annotations, a)
+	}
+
+	api.WriteResp(w, r, annotations)
+}
+
+// timeConditions builds the SQL conditions for the "active", "from", and "to"
+// query parameters, adding their values to queryValues. The returned string
+// is either empty, or a series of conditions each followed by "AND".
+func timeConditions(params map[string]string, queryValues map[string]interface{}) (string, error) {
+	conditions := ""
+	if active, ok := params[tc.AnnotationActiveQueryParam]; ok {
+		isActive, err := strconv.ParseBool(active)
+		if err != nil {
+			return "", fmt.Errorf("'%s' must be a boolean", tc.AnnotationActiveQueryParam)
+		}
+		if isActive {
+			conditions += " a.start_time <= now() AND a.end_time > now() AND"
+		} else {
+			conditions += " (a.start_time > now() OR a.end_time <= now()) AND"
+		}
+	}
+	if from, ok := params[tc.AnnotationFromQueryParam]; ok {
+		t, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return "", fmt.Errorf("'%s' must be an RFC3339 time", tc.AnnotationFromQueryParam)
+		}
+		queryValues["from"] = t
+		conditions += " a.end_time > :from AND"
+	}
+	if to, ok := params[tc.AnnotationToQueryParam]; ok {
+		t, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return "", fmt.Errorf("'%s' must be an RFC3339 time", tc.AnnotationToQueryParam)
+		}
+		queryValues["to"] = t
+		conditions += " a.start_time < :to AND"
+	}
+	return conditions, nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(row scanner, a *tc.Annotation) error {
+	return row.Scan(
+		&a.ID,
+		&a.Description,
+		&a.StartTime,
+		&a.EndTime,
+		&a.CDN,
+		&a.CDNID,
+		&a.Cachegroup,
+		&a.CachegroupID,
+		&a.DeliveryService,
+		&a.DeliveryServiceID,
+		&a.CreatedBy,
+		&a.LastUpdated,
+	)
+}
+
+// getAnnotation returns the Annotation with the given ID, and whether or not
+// it exists.
+func getAnnotation(tx *sql.Tx, id int) (tc.Annotation, bool, error) {
+	var a tc.Annotation
+	if err := scan(tx.QueryRow(readQuery+"WHERE a.id = $1", id), &a); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return a, false, nil
+		}
+		return a, false, fmt.Errorf("querying annotation #%d: %w", id, err)
+	}
+	return a, true, nil
+}
+
+// checkTenancy checks that the user may modify annotations of the given
+// Delivery Service, if any.
+func checkTenancy(inf *api.APIInfo, dsID *int) (error, error, int) {
+	if dsID == nil {
+		return nil, nil, http.StatusOK
+	}
+	return tenant.CheckID(inf.Tx.Tx, inf.User, *dsID)
+}
+
+// describeTarget describes the object to which an Annotation is attached,
+// for change logs and alerts.
+func describeTarget(a tc.Annotation) string {
+	switch {
+	case a.CDN != nil:
+		return "CDN: " + *a.CDN
+	case a.Cachegroup != nil:
+		return "CACHEGROUP: " + *a.Cachegroup
+	case a.DeliveryService != nil:
+		return "DS: " + *a.DeliveryService
+	}
+	return "unknown target"
+}
+
+// Create is the handler for POST requests to /annotations.
+func Create(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	var req tc.AnnotationRequest
+	if userErr = api.Parse(r.Body, tx, &req); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	if userErr, sysErr, errCode = checkTenancy(inf, req.DeliveryServiceID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var id int
+	err := tx.QueryRow(insertQuery, req.Description, req.StartTime, req.EndTime, req.CDNID, req.CachegroupID, req.DeliveryServiceID, inf.User.UserName).Scan(&id)
+	if err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	resp, _, err := getAnnotation(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	changeLogMsg := fmt.Sprintf("ANNOTATION: %d, %s, ACTION: Created", resp.ID, describeTarget(resp))
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+
+	alerts := tc.CreateAlerts(tc.SuccessLevel, "Annotation created for "+describeTarget(resp))
+	api.WriteAlertsObj(w, r, http.StatusCreated, alerts, resp)
+}
+
+// Update is the handler for PUT requests to /annotations/{id}.
+func Update(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	id := inf.IntParams["id"]
+	existing, ok, err := getAnnotation(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no annotation exists by ID %d", id), nil)
+		return
+	}
+	if userErr, sysErr, errCode = checkTenancy(inf, existing.DeliveryServiceID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var req tc.AnnotationRequest
+	if userErr = api.Parse(r.Body, tx, &req); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	if userErr, sysErr, errCode = checkTenancy(inf, req.DeliveryServiceID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	if _, err := tx.Exec(updateQuery, req.Description, req.StartTime, req.EndTime, req.CDNID, req.CachegroupID, req.DeliveryServiceID, id); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	resp, _, err := getAnnotation(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	changeLogMsg := fmt.Sprintf("ANNOTATION: %d, %s, ACTION: Updated", resp.ID, describeTarget(resp))
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Annotation updated for "+describeTarget(resp), resp)
+}
+
+// Delete is the handler for DELETE requests to /annotations/{id}.
+func Delete(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	id := inf.IntParams["id"]
+	existing, ok, err := getAnnotation(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no annotation exists by ID %d", id), nil)
+		return
+	}
+	if userErr, sysErr, errCode = checkTenancy(inf, existing.DeliveryServiceID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	if _, err := tx.Exec(deleteQuery, id); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	changeLogMsg := fmt.Sprintf("ANNOTATION: %d, %s, ACTION: Deleted", existing.ID, describeTarget(existing))
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Annotation deleted for "+describeTarget(existing), existing)
+}
@@ -0,0 +1,67 @@
+package annotation
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestTimeConditions(t *testing.T) {
+	queryValues := map[string]interface{}{}
+	params := map[string]string{
+		tc.AnnotationActiveQueryParam: "true",
+		tc.AnnotationFromQueryParam:   "2022-05-22T02:00:00Z",
+		tc.AnnotationToQueryParam:     "2022-05-22T06:00:00Z",
+	}
+	conditions, err := timeConditions(params, queryValues)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{"a.end_time > now()", "a.end_time > :from", "a.start_time < :to"} {
+		if !strings.Contains(conditions, expected) {
+			t.Errorf("expected conditions to contain '%s', got: %s", expected, conditions)
+		}
+	}
+	if !strings.HasSuffix(conditions, "AND") {
+		t.Errorf("expected conditions to end with 'AND', got: %s", conditions)
+	}
+	if from, ok := queryValues["from"].(time.Time); !ok || !from.Equal(time.Date(2022, 5, 22, 2, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected 'from' query value to be parsed, got: %v", queryValues["from"])
+	}
+
+	conditions, err = timeConditions(map[string]string{}, queryValues)
+	if err != nil || conditions != "" {
+		t.Errorf("expected no conditions or error without parameters, got: '%s', %v", conditions, err)
+	}
+
+	for _, bad := range []map[string]string{
+		{tc.AnnotationActiveQueryParam: "maybe"},
+		{tc.AnnotationFromQueryParam: "yesterday"},
+		{tc.AnnotationToQueryParam: "2022-05-22"},
+	} {
+		if _, err := timeConditions(bad, map[string]interface{}{}); err == nil {
+			t.Errorf("expected error for parameters %v", bad)
+		}
+	}
+}
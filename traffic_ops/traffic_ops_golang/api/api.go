@@ -43,6 +43,9 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/messages"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/requeststats"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tocookie"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
@@ -109,6 +112,7 @@ type APIResponseWithSummary struct {
 	Summary  struct {
 		Count uint64 `json:"count"`
 	} `json:"summary"`
+	Pagination *tc.Pagination `json:"pagination,omitempty"`
 }
 
 // GoneHandler is an http.Handler function that just writes a 410 Gone response
@@ -168,6 +172,18 @@ func WriteRespWithSummary(w http.ResponseWriter, r *http.Request, v interface{},
 	WriteRespRaw(w, r, resp)
 }
 
+// WriteRespWithSummaryPaginated acts like WriteRespWithSummary, but also
+// provides a "pagination" section to the response object that contains the
+// given cursors, if they aren't nil.
+func WriteRespWithSummaryPaginated(w http.ResponseWriter, r *http.Request, v interface{}, count uint64, pagination *tc.Pagination) {
+	var resp APIResponseWithSummary
+	resp.Response = v
+	resp.Summary.Count = count
+	resp.Pagination = pagination
+
+	WriteRespRaw(w, r, resp)
+}
+
 // WriteRespVals is like WriteResp, but also takes a map of root-level values to write. The API most commonly needs these for meta-parameters, like size, limit, and orderby.
 // This is a helper for the common case; not using this in unusual cases is perfectly acceptable.
 func WriteRespVals(w http.ResponseWriter, r *http.Request, v interface{}, vals map[string]interface{}) {
@@ -188,6 +204,17 @@ func WriteRespVals(w http.ResponseWriter, r *http.Request, v interface{}, vals m
 	WriteAndLogErr(w, r, append(respBts, '\n'))
 }
 
+// WriteRespPaginated acts like WriteResp, but also provides a "pagination"
+// section to the response object that contains the given cursors, if they
+// aren't nil.
+func WriteRespPaginated(w http.ResponseWriter, r *http.Request, v interface{}, pagination *tc.Pagination) {
+	if pagination == nil {
+		WriteResp(w, r, v)
+		return
+	}
+	WriteRespVals(w, r, v, map[string]interface{}{"pagination": pagination})
+}
+
 // WriteIMSHitResp writes a response to 'w' for an IMS request "hit", using the
 // passed time as the Last-Modified date.
 func WriteIMSHitResp(w http.ResponseWriter, r *http.Request, t time.Time) {
@@ -251,7 +278,7 @@ func HandleDeprecatedErr(w http.ResponseWriter, r *http.Request, tx *sql.Tx, sta
 	alerts := CreateDeprecationAlerts(alternative)
 
 	userErr = LogErr(r, statusCode, userErr, sysErr)
-	alerts.AddAlerts(tc.CreateErrorAlerts(userErr))
+	alerts.AddAlerts(tc.CreateCodedErrorAlerts(statusCode, userErr))
 	WriteAlerts(w, r, statusCode, alerts)
 }
 
@@ -275,7 +302,9 @@ func LogErr(r *http.Request, errCode int, userErr error, sysErr error) error {
 func handleSimpleErr(w http.ResponseWriter, r *http.Request, statusCode int, userErr error, sysErr error) {
 	userErr = LogErr(r, statusCode, userErr, sysErr)
 
-	respBts, err := json.Marshal(tc.CreateErrorAlerts(userErr))
+	alerts := tc.CreateCodedErrorAlerts(statusCode, userErr)
+	localizeAlerts(w, r, &alerts)
+	respBts, err := json.Marshal(alerts)
 	if err != nil {
 		log.Errorln("marshalling error: " + err.Error())
 		WriteAndLogErr(w, r, append([]byte(http.StatusText(http.StatusInternalServerError)), '\n'))
@@ -383,6 +412,10 @@ func WriteAlerts(w http.ResponseWriter, r *http.Request, code int, alerts tc.Ale
 	setRespWritten(r)
 
 	w.Header().Set(rfc.ContentType, rfc.ApplicationJSON)
+	if code >= http.StatusBadRequest {
+		alerts.SetErrorCodes(code)
+		localizeAlerts(w, r, &alerts)
+	}
 	w.WriteHeader(code)
 	if alerts.HasAlerts() {
 		respBts, err := json.Marshal(alerts)
@@ -394,6 +427,18 @@ func WriteAlerts(w http.ResponseWriter, r *http.Request, code int, alerts tc.Ale
 	}
 }
 
+// localizeAlerts localizes the messages of the given Alerts in the language
+// the request accepts, and sets the response headers that describe that
+// language, if any message catalogs are loaded.
+func localizeAlerts(w http.ResponseWriter, r *http.Request, alerts *tc.Alerts) {
+	lang := messages.Localize(r, alerts)
+	if lang == "" {
+		return
+	}
+	w.Header().Set(rfc.ContentLanguage, lang)
+	w.Header().Add(rfc.Vary, rfc.AcceptLanguage)
+}
+
 func WriteAlertsObj(w http.ResponseWriter, r *http.Request, code int, alerts tc.Alerts, obj interface{}) {
 	if !alerts.HasAlerts() {
 		w.WriteHeader(code)
@@ -502,7 +547,7 @@ func Parse(r io.Reader, tx *sql.Tx, v ParseValidator) error {
 		return fmt.Errorf("decoding: %v", err)
 	}
 	if err := v.Validate(tx); err != nil {
-		return fmt.Errorf("validating: %v", err)
+		return fmt.Errorf("validating: %w", err)
 	}
 	return nil
 }
@@ -517,7 +562,11 @@ type APIInfo struct {
 	CancelTx  context.CancelFunc
 	Vault     trafficvault.TrafficVault
 	Config    *config.Config
-	request   *http.Request
+	// Pagination holds the cursors of the pages adjacent to the one being
+	// read, which are written into the response envelope. Readers set it
+	// using BuildCursorPagination and dbhelpers.CursorPagination.
+	Pagination *tc.Pagination
+	request    *http.Request
 }
 
 // NewInfo get and returns the context info needed by handlers. It also returns any user error, any system error, and the status code which should be returned to the client if an error occurred.
@@ -610,7 +659,7 @@ INSERT INTO log (
 // CreateChangeLog creates a new changelog message at the APICHANGE level for
 // the current user.
 func (inf APIInfo) CreateChangeLog(msg string) {
-	_, err := inf.Tx.Tx.Exec(createChangeLogQuery, ApiChange, msg, inf.User.ID)
+	_, err := inf.Tx.Tx.Exec(createChangeLogQuery, ApiChange, inf.User.AuditMessage(msg), inf.User.ID)
 	if err != nil {
 		log.Errorf("Inserting chage log level '%s' message '%s' for user '%s': %v", ApiChange, msg, inf.User.UserName, err)
 	}
@@ -674,6 +723,18 @@ func (inf APIInfo) CheckPrecondition(query string, args ...interface{}) (int, er
 	return http.StatusOK, nil, nil
 }
 
+// BuildCursorPagination applies cursor pagination to the clauses of a read
+// built by dbhelpers.BuildWhereAndOrderByAndPagination from the request's
+// parameters, for API versions 5 and later; see
+// dbhelpers.BuildCursorPagination. The returned error is safe to show to the
+// client.
+func (inf *APIInfo) BuildCursorPagination(queryParamsToSQLCols map[string]dbhelpers.WhereColumnInfo, where, orderBy, pagination string, queryValues map[string]interface{}) (string, string, string, *dbhelpers.CursorPage, error) {
+	if inf.Version == nil || inf.Version.Major < 5 {
+		return where, orderBy, pagination, nil, nil
+	}
+	return dbhelpers.BuildCursorPagination(inf.Params, queryParamsToSQLCols, where, orderBy, pagination, queryValues)
+}
+
 // Close implements the io.Closer interface. It should be called in a defer immediately after NewInfo().
 //
 // Close will commit the transaction, if it hasn't been rolled back.
@@ -727,7 +788,15 @@ func SendMail(to rfc.EmailAddress, msg []byte, cfg *config.Config) (int, error,
 // If Influx connections are not enabled, this will return `nil` - but also no error. It is expected
 // that the caller will handle this situation appropriately.
 func (inf *APIInfo) CreateInfluxClient() (*influx.Client, error) {
-	if !inf.Config.InfluxEnabled {
+	return CreateInfluxClient(inf.Tx.Tx, inf.Config)
+}
+
+// CreateInfluxClient constructs and returns an InfluxDB HTTP client for the
+// first ONLINE InfluxDB server in the database, if Influx connections are
+// enabled by the given configuration. It exists for the use of things other
+// than request handlers, which should use APIInfo.CreateInfluxClient.
+func CreateInfluxClient(tx *sql.Tx, cfg *config.Config) (*influx.Client, error) {
+	if !cfg.InfluxEnabled {
 		return nil, nil
 	}
 
@@ -735,13 +804,13 @@ func (inf *APIInfo) CreateInfluxClient() (*influx.Client, error) {
 	var tcpPort uint
 	var httpsPort sql.NullInt64 // this is the only one that's optional
 
-	row := inf.Tx.Tx.QueryRow(influxServersQuery)
+	row := tx.QueryRow(influxServersQuery)
 	if e := row.Scan(&fqdn, &tcpPort, &httpsPort); e != nil {
 		return nil, fmt.Errorf("Failed to create influx client: %v", e)
 	}
 
 	host := "http%s://%s:%d"
-	if inf.Config.ConfigInflux != nil && *inf.Config.ConfigInflux.Secure {
+	if cfg.ConfigInflux != nil && *cfg.ConfigInflux.Secure {
 		if !httpsPort.Valid {
 			log.Warnf("INFLUXDB Server %s has no secure ports, assuming default of 8086!", fqdn)
 			httpsPort = sql.NullInt64{Int64: 8086, Valid: true}
@@ -767,10 +836,10 @@ func (inf *APIInfo) CreateInfluxClient() (*influx.Client, error) {
 
 	config := influx.HTTPConfig{
 		Addr:      host,
-		Username:  inf.Config.ConfigInflux.User,
-		Password:  inf.Config.ConfigInflux.Password,
-		UserAgent: fmt.Sprintf("TrafficOps/%s (Go)", inf.Config.Version),
-		Timeout:   time.Duration(float64(inf.Config.ReadTimeout)/2.1) * time.Second,
+		Username:  cfg.ConfigInflux.User,
+		Password:  cfg.ConfigInflux.Password,
+		UserAgent: fmt.Sprintf("TrafficOps/%s (Go)", cfg.Version),
+		Timeout:   time.Duration(float64(cfg.ReadTimeout)/2.1) * time.Second,
 	}
 
 	var client influx.Client
@@ -1113,6 +1182,13 @@ func GetUserFromReq(w http.ResponseWriter, r *http.Request, secret string) (auth
 		return auth.CurrentUser{}, userErr, sysErr, code
 	}
 
+	if oldCookie.ImpersonatedBy != "" {
+		// impersonation sessions end when their cookies expire, so they're
+		// never renewed
+		user.ImpersonatedBy = oldCookie.ImpersonatedBy
+		return user, nil, nil, http.StatusOK
+	}
+
 	duration := tocookie.DefaultDuration
 	newCookie := tocookie.GetCookie(oldCookie.AuthData, duration, secret)
 	http.SetCookie(w, newCookie)
@@ -1169,6 +1245,7 @@ func getCookieFromAccessToken(bearerToken string, secret string) (*http.Cookie,
 
 func AddUserToReq(r *http.Request, u auth.CurrentUser) {
 	ctx := r.Context()
+	requeststats.FromContext(ctx).SetUser(u.UserName)
 	ctx = context.WithValue(ctx, auth.CurrentUserKey, u)
 	*r = *r.WithContext(ctx)
 }
@@ -20,27 +20,57 @@ package api
  */
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
 	"github.com/apache/trafficcontrol/lib/go-tc"
 
 	"github.com/jmoiron/sqlx"
 )
 
 const (
-	AsyncSucceeded = "SUCCEEDED"
-	AsyncFailed    = "FAILED"
-	AsyncPending   = "PENDING"
+	AsyncSucceeded = tc.AsyncStatusSucceeded
+	AsyncFailed    = tc.AsyncStatusFailed
+	AsyncPending   = tc.AsyncStatusPending
 )
 
 const CurrentAsyncEndpoint = "/api/4.0/async_status/"
 
-const selectAsyncStatusQuery = `SELECT id, status, message, start_time, end_time from async_status WHERE id = $1`
+// AsyncJobTimeout is the longest an asynchronous job started by StartAsyncJob
+// may run before its context is cancelled.
+const AsyncJobTimeout = 30 * time.Minute
+
+const selectAsyncStatusQuery = `SELECT id, status, message, start_time, end_time, progress from async_status WHERE id = $1`
 const insertAsyncStatusQuery = `INSERT INTO async_status (status, message) VALUES ($1, $2) RETURNING id`
-const updateAsyncStatusEndTimeQuery = `UPDATE async_status SET status = $1, message = $2, end_time = now() WHERE id = $3`
+const updateAsyncStatusEndTimeQuery = `UPDATE async_status SET status = $1, message = $2, end_time = now(), progress = CASE WHEN $1 = 'SUCCEEDED' THEN 100 ELSE progress END WHERE id = $3`
 const updateAsyncStatusQuery = `UPDATE async_status SET status = $1, message = $2 WHERE id = $3`
+const updateAsyncStatusProgressQuery = `UPDATE async_status SET progress = $1, message = $2 WHERE id = $3 AND end_time IS NULL`
+
+// AsyncParam is the query parameter with which clients request that endpoints
+// supporting it run as asynchronous jobs.
+const AsyncParam = "async"
+
+// AsyncJob is the work of an asynchronous job started by StartAsyncJob. It may
+// report its progress with UpdateAsyncStatusProgress, and returns the message
+// with which the job's status is updated when it finishes. Errors are only
+// logged, so the message should describe a failure fit for users.
+type AsyncJob func(ctx context.Context, db *sqlx.DB, asyncStatusId int) (string, error)
+
+// AsyncJobEndpoint returns the path of the /async_jobs/{id} endpoint of the
+// given API version for the asynchronous job with the given ID.
+func AsyncJobEndpoint(version *Version, asyncStatusId int) string {
+	if version == nil {
+		return CurrentAsyncEndpoint + strconv.Itoa(asyncStatusId)
+	}
+	return fmt.Sprintf("/api/%d.%d/async_jobs/%d", version.Major, version.Minor, asyncStatusId)
+}
 
 // GetAsyncStatus returns the status of an asynchronous job.
 func GetAsyncStatus(w http.ResponseWriter, r *http.Request) {
@@ -64,7 +94,7 @@ func GetAsyncStatus(w http.ResponseWriter, r *http.Request) {
 	rowCount := 0
 	for rows.Next() {
 		rowCount++
-		err := rows.Scan(&asyncStatus.Id, &asyncStatus.Status, &asyncStatus.Message, &asyncStatus.StartTime, &asyncStatus.EndTime)
+		err := rows.Scan(&asyncStatus.Id, &asyncStatus.Status, &asyncStatus.Message, &asyncStatus.StartTime, &asyncStatus.EndTime, &asyncStatus.Progress)
 		if err != nil {
 			HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
 			return
@@ -130,3 +160,85 @@ func UpdateAsyncStatus(db *sqlx.DB, newStatus string, newMessage string, asyncSt
 
 	return nil
 }
+
+// IsAsyncRequested returns whether the client requested, with the "async"
+// query parameter, that the endpoint run as an asynchronous job. That's only
+// supported by API version 4.1 and later; earlier versions ignore it.
+func IsAsyncRequested(inf *APIInfo) (bool, error) {
+	param, ok := inf.Params[AsyncParam]
+	if !ok || inf.Version == nil || inf.Version.Major < 4 || (inf.Version.Major == 4 && inf.Version.Minor < 1) {
+		return false, nil
+	}
+	async, err := strconv.ParseBool(param)
+	if err != nil {
+		return false, errors.New("'" + AsyncParam + "' must be a boolean")
+	}
+	return async, nil
+}
+
+// UpdateAsyncStatusProgress updates the progress, as a percentage, and message
+// of an asynchronous job which hasn't finished.
+func UpdateAsyncStatusProgress(db *sqlx.DB, asyncStatusId int, progress int, newMessage string) error {
+	if asyncStatusId == 0 {
+		return nil
+	}
+	if progress < 0 {
+		progress = 0
+	} else if progress > 100 {
+		progress = 100
+	}
+	_, err := db.Exec(updateAsyncStatusProgressQuery, progress, newMessage, asyncStatusId)
+	return err
+}
+
+// StartAsyncJob records a new pending asynchronous job, runs it in the
+// background, and responds with 202 Accepted, the job's ID, and its location.
+//
+// Inserting the job's status commits the APIInfo's transaction, so everything
+// the job relies upon - and the user's authorization to do it - must be
+// checked beforehand. The job must not use the request or its transaction.
+func StartAsyncJob(w http.ResponseWriter, r *http.Request, inf *APIInfo, message string, job AsyncJob) {
+	db, err := GetDB(r.Context())
+	if err != nil {
+		HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("starting asynchronous job: getting db from context: "+err.Error()))
+		return
+	}
+
+	asyncStatusId, errCode, userErr, sysErr := InsertAsyncStatus(inf.Tx.Tx, message)
+	if userErr != nil || sysErr != nil {
+		HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+
+	go runAsyncJob(db, asyncStatusId, job)
+
+	location := AsyncJobEndpoint(inf.Version, asyncStatusId)
+	alerts := tc.CreateAlerts(tc.SuccessLevel, message+" Its status can be found at "+location)
+	w.Header().Set(rfc.Location, location)
+	WriteAlertsObj(w, r, http.StatusAccepted, alerts, tc.AsyncJob{JobID: asyncStatusId})
+}
+
+// runAsyncJob runs the job, and updates its status when it's done.
+func runAsyncJob(db *sqlx.DB, asyncStatusId int, job AsyncJob) {
+	ctx, cancel := context.WithTimeout(context.Background(), AsyncJobTimeout)
+	defer cancel()
+
+	status, message := AsyncFailed, ""
+	defer func() {
+		if rec := recover(); rec != nil {
+			log.Errorf("asynchronous job #%d panicked: %v", asyncStatusId, rec)
+			status, message = AsyncFailed, "Job failed."
+		}
+		if err := UpdateAsyncStatus(db, status, message, asyncStatusId, true); err != nil {
+			log.Errorf("updating status of asynchronous job #%d: %v", asyncStatusId, err)
+		}
+	}()
+
+	msg, err := job(ctx, db, asyncStatusId)
+	if err != nil {
+		log.Errorf("asynchronous job #%d: %v", asyncStatusId, err)
+		message = msg
+		return
+	}
+	status, message = AsyncSucceeded, msg
+}
@@ -112,3 +112,67 @@ func TestUpdateAsyncStatusFinished(t *testing.T) {
 		return
 	}
 }
+
+func TestUpdateAsyncStatusProgress(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	expectedMessage := "halfway there"
+	mock.ExpectExec("UPDATE").WithArgs(50, expectedMessage, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE").WithArgs(100, expectedMessage, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+
+	if err := UpdateAsyncStatusProgress(db, 1, 50, expectedMessage); err != nil {
+		t.Fatalf("expected no error updating progress, got: %v", err)
+	}
+	if err := UpdateAsyncStatusProgress(db, 1, 150, expectedMessage); err != nil {
+		t.Fatalf("expected no error updating progress beyond 100%%, got: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
+
+func TestIsAsyncRequested(t *testing.T) {
+	tests := []struct {
+		version  Version
+		params   map[string]string
+		expected bool
+		err      bool
+	}{
+		{Version{Major: 4, Minor: 1}, map[string]string{}, false, false},
+		{Version{Major: 4, Minor: 1}, map[string]string{"async": "true"}, true, false},
+		{Version{Major: 5, Minor: 0}, map[string]string{"async": "false"}, false, false},
+		{Version{Major: 5, Minor: 0}, map[string]string{"async": "soon"}, false, true},
+		{Version{Major: 4, Minor: 0}, map[string]string{"async": "true"}, false, false},
+	}
+	for _, test := range tests {
+		version := test.version
+		async, err := IsAsyncRequested(&APIInfo{Params: test.params, Version: &version})
+		if test.err {
+			if err == nil {
+				t.Errorf("expected an error for version %d.%d and params %v, got none", version.Major, version.Minor, test.params)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for version %d.%d and params %v: %v", version.Major, version.Minor, test.params, err)
+		} else if async != test.expected {
+			t.Errorf("expected async to be %t for version %d.%d and params %v, got %t", test.expected, version.Major, version.Minor, test.params, async)
+		}
+	}
+}
+
+func TestAsyncJobEndpoint(t *testing.T) {
+	if actual := AsyncJobEndpoint(&Version{Major: 4, Minor: 1}, 7); actual != "/api/4.1/async_jobs/7" {
+		t.Errorf("expected '/api/4.1/async_jobs/7', got '%s'", actual)
+	}
+	if actual := AsyncJobEndpoint(nil, 7); actual != CurrentAsyncEndpoint+"7" {
+		t.Errorf("expected '%s7' without a version, got '%s'", CurrentAsyncEndpoint, actual)
+	}
+}
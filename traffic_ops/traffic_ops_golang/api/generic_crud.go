@@ -195,6 +195,10 @@ func GenericRead(h http.Header, val GenericReader, useIMS bool) ([]interface{},
 	if len(errs) > 0 {
 		return nil, util.JoinErrs(errs), nil, http.StatusBadRequest, nil
 	}
+	where, orderBy, pagination, page, err := val.APIInfo().BuildCursorPagination(val.ParamColumns(), where, orderBy, pagination, queryValues)
+	if err != nil {
+		return nil, err, nil, http.StatusBadRequest, nil
+	}
 	if useIMS {
 		runSecond, maxTime = TryIfModifiedSinceQuery(val, h, where, orderBy, pagination, queryValues)
 		if !runSecond {
@@ -221,6 +225,7 @@ func GenericRead(h http.Header, val GenericReader, useIMS bool) ([]interface{},
 		}
 		vals = append(vals, v)
 	}
+	val.APIInfo().Pagination = dbhelpers.CursorPagination(page, vals)
 	return vals, nil, nil, code, &maxTime
 }
 
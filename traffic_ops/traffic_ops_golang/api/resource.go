@@ -0,0 +1,461 @@
+package api
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/util/ims"
+)
+
+// ResourceObject is the constraint on the objects handled by a Resource. It is
+// satisfied by a pointer to the object type, and gives the Resource access to
+// the fields that every resource it handles has.
+type ResourceObject[T any] interface {
+	*T
+	// GetID returns the object's integral, unique identifier, and whether
+	// it has one.
+	GetID() (int, bool)
+	SetID(int)
+	SetLastUpdated(tc.TimeNoMod)
+	// GetAuditName returns the name by which the object is identified in
+	// change log entries.
+	GetAuditName() string
+}
+
+// A Resource declares how a resource identified by an integral "id" is stored,
+// and provides type-safe handlers that create, read, update, and delete it.
+// Unlike the Creator, Reader, Updater, and Deleter interfaces, nothing about
+// the resource is discovered by reflection; the object type T need only have
+// "db" struct tags for its columns and "json" struct tags for its
+// representation.
+//
+// Reads are filtered by the Filters, sorted, and paginated according to the
+// request's query parameters. When If-Modified-Since support is enabled in
+// the configuration, they also carry Last-Modified headers, and conditional
+// reads with an If-Modified-Since header are answered with 304 Not Modified
+// if nothing matching them has changed.
+// Updates honor If-Unmodified-Since and If-Match headers. Every change is
+// recorded in the change log, and every success is reported with the same
+// alerts.
+type Resource[T any, PT ResourceObject[T]] struct {
+	// Type is the name of the resource in alerts and change log entries,
+	// e.g. "coordinate".
+	Type string
+	// Table is the database table in which the resource is stored.
+	Table string
+
+	// Columns is the list of columns selected when reading the resource.
+	Columns string
+	// From is the FROM clause of reads, including any joins. The WHERE,
+	// ORDER BY, and pagination clauses built from the query parameters are
+	// appended to it.
+	From string
+	// LastUpdatedColumn is the column in From holding the time at which
+	// each row was last updated.
+	LastUpdatedColumn string
+	// Filters are the query parameters by which reads may be filtered and
+	// sorted, and the columns in From to which they correspond.
+	Filters map[string]dbhelpers.WhereColumnInfo
+	// DefaultSort is the query parameter by which reads are sorted, unless
+	// the client asks otherwise.
+	DefaultSort string
+
+	// InsertQuery is a named query creating the resource from an object, which
+	// must return its "id" and "last_updated" columns.
+	InsertQuery string
+	// UpdateQuery is a named query updating the resource from an object, which
+	// must return its "last_updated" column.
+	UpdateQuery string
+	// DeleteQuery is a named query deleting the resource by ":id".
+	DeleteQuery string
+
+	// Validate checks an object decoded from a request body, returning an
+	// error to show the client if it isn't valid, and an error to log if it
+	// couldn't be checked.
+	Validate func(inf *APIInfo, obj PT) (error, error)
+	// Authorize, if not nil, is called before an object is created, updated,
+	// or deleted, and returns errors and a status code if the user may not
+	// do so. For deletes, only the object's ID is set.
+	Authorize func(inf *APIInfo, obj PT) (error, error, int)
+}
+
+// ReadHandler returns a handler for GET requests for the resource.
+func (c *Resource[T, PT]) ReadHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		inf, userErr, sysErr, errCode := NewInfo(r, nil, nil)
+		if userErr != nil || sysErr != nil {
+			HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+			return
+		}
+		defer inf.Close()
+
+		useIMS := inf.Config != nil && inf.Config.UseIMS
+		objs, maxTime, userErr, sysErr, errCode := c.Read(inf, r.Header, useIMS)
+		if userErr != nil || sysErr != nil {
+			HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+			return
+		}
+		if maxTime != nil {
+			if errCode == http.StatusNotModified {
+				WriteIMSHitResp(w, r, *maxTime)
+				return
+			}
+			w.Header().Set(rfc.LastModified, maxTime.Format(rfc.LastModifiedFormat))
+		}
+		WriteRespPaginated(w, r, objs, inf.Pagination)
+	}
+}
+
+// CreateHandler returns a handler for POST requests for the resource.
+func (c *Resource[T, PT]) CreateHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		inf, userErr, sysErr, errCode := NewInfo(r, nil, nil)
+		if userErr != nil || sysErr != nil {
+			HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+			return
+		}
+		defer inf.Close()
+
+		obj, userErr, sysErr, errCode := c.decode(inf, r)
+		if userErr != nil || sysErr != nil {
+			HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+			return
+		}
+		if userErr, sysErr, errCode = c.Create(inf, obj); userErr != nil || sysErr != nil {
+			HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+			return
+		}
+		WriteAlertsObj(w, r, http.StatusOK, tc.CreateAlerts(tc.SuccessLevel, c.Type+" was created."), obj)
+	}
+}
+
+// UpdateHandler returns a handler for PUT requests for the resource. The
+// "id" of the object to update is taken from the path or query parameters,
+// overriding any given in the request body.
+func (c *Resource[T, PT]) UpdateHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		inf, userErr, sysErr, errCode := NewInfo(r, nil, nil)
+		if userErr != nil || sysErr != nil {
+			HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+			return
+		}
+		defer inf.Close()
+
+		id, userErr := idParam(inf.Params)
+		if userErr != nil {
+			HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, userErr, nil)
+			return
+		}
+		obj, userErr, sysErr, errCode := c.decode(inf, r)
+		if userErr != nil || sysErr != nil {
+			HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+			return
+		}
+		obj.SetID(id)
+		if userErr, sysErr, errCode = c.Update(inf, r.Header, obj); userErr != nil || sysErr != nil {
+			HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+			return
+		}
+		WriteAlertsObj(w, r, http.StatusOK, tc.CreateAlerts(tc.SuccessLevel, c.Type+" was updated."), obj)
+	}
+}
+
+// DeleteHandler returns a handler for DELETE requests for the resource. The
+// "id" of the object to delete is taken from the path or query parameters.
+func (c *Resource[T, PT]) DeleteHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		inf, userErr, sysErr, errCode := NewInfo(r, nil, nil)
+		if userErr != nil || sysErr != nil {
+			HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+			return
+		}
+		defer inf.Close()
+
+		id, userErr := idParam(inf.Params)
+		if userErr != nil {
+			HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, userErr, nil)
+			return
+		}
+		obj := PT(new(T))
+		obj.SetID(id)
+		if userErr, sysErr, errCode = c.Delete(inf, obj); userErr != nil || sysErr != nil {
+			HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+			return
+		}
+		WriteRespAlert(w, r, tc.SuccessLevel, c.Type+" was deleted.")
+	}
+}
+
+// idParam returns the required "id" parameter of a request.
+func idParam(params map[string]string) (int, error) {
+	idStr, ok := params["id"]
+	if !ok || idStr == "" {
+		return 0, errors.New("missing key: id")
+	}
+	id, err := GetIntKey(idStr)
+	if err != nil {
+		return 0, errors.New("failed to parse key: id")
+	}
+	return id.(int), nil
+}
+
+// decode decodes and validates an object from a request body.
+func (c *Resource[T, PT]) decode(inf *APIInfo, r *http.Request) (PT, error, error, int) {
+	defer r.Body.Close()
+	obj := PT(new(T))
+	if err := json.NewDecoder(r.Body).Decode(obj); err != nil {
+		return nil, errors.New("parsing " + c.Type + ": " + err.Error()), nil, http.StatusBadRequest
+	}
+	if c.Validate == nil {
+		return obj, nil, nil, http.StatusOK
+	}
+	if userErr, sysErr := c.Validate(inf, obj); userErr != nil || sysErr != nil {
+		if sysErr != nil {
+			return nil, nil, fmt.Errorf("validating %s: %w", c.Type, sysErr), http.StatusInternalServerError
+		}
+		tovalidate.SetObject(userErr, strings.ToLower(c.Type))
+		return nil, userErr, nil, http.StatusBadRequest
+	}
+	return obj, nil, nil, http.StatusOK
+}
+
+func (c *Resource[T, PT]) authorize(inf *APIInfo, obj PT) (error, error, int) {
+	if c.Authorize == nil {
+		return nil, nil, http.StatusOK
+	}
+	return c.Authorize(inf, obj)
+}
+
+func (c *Resource[T, PT]) changeLog(inf *APIInfo, action string, obj PT) error {
+	id, _ := obj.GetID()
+	return CreateChangeLogBuildMsg(ApiChange, action, inf.User, inf.Tx.Tx, c.Type, obj.GetAuditName(), map[string]interface{}{"id": id})
+}
+
+// Read returns the objects matching the request parameters, along with the
+// time at which any of them was last modified if it was checked. If the
+// request was conditional and nothing has been modified, the returned code is
+// http.StatusNotModified and no objects are returned. When cursor pagination
+// is used, the cursors of the adjacent pages are set in inf.Pagination.
+func (c *Resource[T, PT]) Read(inf *APIInfo, h http.Header, useIMS bool) ([]T, *time.Time, error, error, int) {
+	if c.DefaultSort != "" {
+		DefaultSort(inf, c.DefaultSort)
+	}
+	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(inf.Params, c.Filters)
+	if len(errs) > 0 {
+		return nil, nil, util.JoinErrs(errs), nil, http.StatusBadRequest
+	}
+	where, orderBy, pagination, page, err := inf.BuildCursorPagination(c.Filters, where, orderBy, pagination, queryValues)
+	if err != nil {
+		return nil, nil, err, nil, http.StatusBadRequest
+	}
+
+	var maxTime *time.Time
+	if useIMS {
+		if maxTime, err = c.lastModified(inf, where, queryValues); err != nil {
+			return nil, nil, nil, err, http.StatusInternalServerError
+		}
+		if maxTime != nil && !modifiedSince(h, *maxTime) {
+			log.Debugln("IMS HIT")
+			return nil, maxTime, nil, nil, http.StatusNotModified
+		}
+		log.Debugln("IMS MISS")
+	}
+
+	query := "SELECT\n" + c.Columns + "\n" + c.From + where + orderBy + pagination
+	rows, err := inf.Tx.NamedQuery(query, queryValues)
+	if err != nil {
+		return nil, nil, nil, errors.New("querying " + c.Type + ": " + err.Error()), http.StatusInternalServerError
+	}
+	defer log.Close(rows, "closing "+c.Type+" rows")
+
+	objs := []T{}
+	for rows.Next() {
+		var obj T
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, nil, nil, errors.New("scanning " + c.Type + ": " + err.Error()), http.StatusInternalServerError
+		}
+		objs = append(objs, obj)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, nil, errors.New("iterating over " + c.Type + " rows: " + err.Error()), http.StatusInternalServerError
+	}
+	inf.Pagination = dbhelpers.CursorPagination(page, objs)
+	return objs, maxTime, nil, nil, http.StatusOK
+}
+
+// lastModified returns the latest time at which any object matching the
+// given WHERE clause was updated or any object was deleted, or nil if there
+// are no such times.
+func (c *Resource[T, PT]) lastModified(inf *APIInfo, where string, queryValues map[string]interface{}) (*time.Time, error) {
+	query := `SELECT max(t) FROM (
+SELECT max(` + c.LastUpdatedColumn + `) AS t
+` + c.From + where + `
+UNION ALL
+SELECT max(last_updated) AS t FROM last_deleted l WHERE l.table_name='` + c.Table + `'
+) AS res`
+	rows, err := inf.Tx.NamedQuery(query, queryValues)
+	if err != nil {
+		return nil, errors.New("querying " + c.Type + " last modified time: " + err.Error())
+	}
+	defer log.Close(rows, "closing "+c.Type+" last modified time rows")
+
+	latest := ims.LatestTimestamp{}
+	if rows.Next() {
+		if err := rows.StructScan(&latest); err != nil {
+			return nil, errors.New("scanning " + c.Type + " last modified time: " + err.Error())
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over " + c.Type + " last modified time rows: " + err.Error())
+	}
+	if latest.LatestTime == nil {
+		return nil, nil
+	}
+	return &latest.LatestTime.Time, nil
+}
+
+// modifiedSince returns whether a resource last modified at the given time
+// has been modified since the version the client has, according to its
+// If-Modified-Since header. It returns true for unconditional requests.
+func modifiedSince(h http.Header, lastModified time.Time) bool {
+	if h == nil {
+		return true
+	}
+	if imsHdr := h.Get(rfc.IfModifiedSince); imsHdr != "" {
+		if imsDate, ok := rfc.ParseHTTPDate(imsHdr); ok {
+			return !imsDate.After(lastModified)
+		}
+		log.Warnf("IMS request header date '%s' not parsable", imsHdr)
+	}
+	return true
+}
+
+// Create inserts the given, already validated object, setting its ID and
+// last updated time.
+func (c *Resource[T, PT]) Create(inf *APIInfo, obj PT) (error, error, int) {
+	if userErr, sysErr, errCode := c.authorize(inf, obj); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+
+	rows, err := inf.Tx.NamedQuery(c.InsertQuery, obj)
+	if err != nil {
+		return ParseDBError(err)
+	}
+	defer log.Close(rows, "closing "+c.Type+" insert rows")
+
+	if !rows.Next() {
+		return nil, errors.New(c.Type + " create: no " + c.Type + " was inserted, no id was returned"), http.StatusInternalServerError
+	}
+	id := 0
+	lastUpdated := tc.TimeNoMod{}
+	if err := rows.Scan(&id, &lastUpdated); err != nil {
+		return nil, errors.New(c.Type + " create scanning: " + err.Error()), http.StatusInternalServerError
+	}
+	if rows.Next() {
+		return nil, errors.New("too many ids returned from " + c.Type + " insert"), http.StatusInternalServerError
+	}
+	obj.SetID(id)
+	obj.SetLastUpdated(lastUpdated)
+
+	if err := c.changeLog(inf, Created, obj); err != nil {
+		return nil, fmt.Errorf("inserting changelog: %w", err), http.StatusInternalServerError
+	}
+	return nil, nil, http.StatusOK
+}
+
+// Update updates the object with the given object's ID to match it, setting
+// its last updated time, provided the client's copy of it is current
+// according to the given request headers.
+func (c *Resource[T, PT]) Update(inf *APIInfo, h http.Header, obj PT) (error, error, int) {
+	if userErr, sysErr, errCode := c.authorize(inf, obj); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+
+	id, _ := obj.GetID()
+	existingLastUpdated, found, err := GetLastUpdated(inf.Tx, id, c.Table)
+	if err != nil {
+		return nil, err, http.StatusInternalServerError
+	}
+	if !found {
+		return errors.New("no " + c.Type + " found with this id"), nil, http.StatusNotFound
+	}
+	if !IsUnmodified(h, *existingLastUpdated) {
+		return ResourceModifiedError, nil, http.StatusPreconditionFailed
+	}
+
+	rows, err := inf.Tx.NamedQuery(c.UpdateQuery, obj)
+	if err != nil {
+		return ParseDBError(err)
+	}
+	defer log.Close(rows, "closing "+c.Type+" update rows")
+
+	if !rows.Next() {
+		return errors.New("no " + c.Type + " found with this id"), nil, http.StatusNotFound
+	}
+	lastUpdated := tc.TimeNoMod{}
+	if err := rows.Scan(&lastUpdated); err != nil {
+		return nil, errors.New("scanning lastUpdated from " + c.Type + " update: " + err.Error()), http.StatusInternalServerError
+	}
+	if rows.Next() {
+		return nil, errors.New(c.Type + " update affected too many rows: >1"), http.StatusInternalServerError
+	}
+	obj.SetLastUpdated(lastUpdated)
+
+	if err := c.changeLog(inf, Updated, obj); err != nil {
+		return nil, fmt.Errorf("inserting changelog: %w", err), http.StatusInternalServerError
+	}
+	return nil, nil, http.StatusOK
+}
+
+// Delete deletes the object with the given object's ID.
+func (c *Resource[T, PT]) Delete(inf *APIInfo, obj PT) (error, error, int) {
+	if userErr, sysErr, errCode := c.authorize(inf, obj); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+
+	result, err := inf.Tx.NamedExec(c.DeleteQuery, obj)
+	if err != nil {
+		return ParseDBError(err)
+	}
+	if rowsAffected, err := result.RowsAffected(); err != nil {
+		return nil, errors.New("deleting " + c.Type + ": getting rows affected: " + err.Error()), http.StatusInternalServerError
+	} else if rowsAffected < 1 {
+		return errors.New("no " + c.Type + " with that key found"), nil, http.StatusNotFound
+	} else if rowsAffected > 1 {
+		return nil, fmt.Errorf(c.Type+" delete affected too many rows: %d", rowsAffected), http.StatusInternalServerError
+	}
+
+	if err := c.changeLog(inf, Deleted, obj); err != nil {
+		return nil, fmt.Errorf("inserting changelog: %w", err), http.StatusInternalServerError
+	}
+	return nil, nil, http.StatusOK
+}
@@ -0,0 +1,295 @@
+package api
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault/backends/disabled"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/jmoiron/sqlx"
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+type widget struct {
+	ID          *int          `json:"id" db:"id"`
+	Name        *string       `json:"name" db:"name"`
+	LastUpdated *tc.TimeNoMod `json:"lastUpdated" db:"last_updated"`
+}
+
+func (w *widget) GetID() (int, bool) {
+	if w.ID == nil {
+		return 0, false
+	}
+	return *w.ID, true
+}
+
+func (w *widget) SetID(id int)                  { w.ID = &id }
+func (w *widget) SetLastUpdated(t tc.TimeNoMod) { w.LastUpdated = &t }
+func (w *widget) GetAuditName() string {
+	if w.Name == nil {
+		return "unknown"
+	}
+	return *w.Name
+}
+
+var widgets = Resource[widget, *widget]{
+	Type:              "widget",
+	Table:             "widget",
+	Columns:           "id, name, last_updated",
+	From:              "FROM widget",
+	LastUpdatedColumn: "last_updated",
+	Filters: map[string]dbhelpers.WhereColumnInfo{
+		"id":   {Column: "id", Checker: IsInt},
+		"name": {Column: "name"},
+	},
+	DefaultSort: "name",
+	InsertQuery: "INSERT INTO widget (name) VALUES (:name) RETURNING id, last_updated",
+	UpdateQuery: "UPDATE widget SET name=:name WHERE id=:id RETURNING last_updated",
+	DeleteQuery: "DELETE FROM widget WHERE id=:id",
+	Validate: func(_ *APIInfo, w *widget) (error, error) {
+		errs := validation.Errors{
+			"name": validation.Validate(w.Name, validation.Required),
+		}
+		return util.JoinErrs(tovalidate.ToErrors(errs)), nil
+	},
+}
+
+func newResourceRequest(t *testing.T, db *sqlx.DB, method string, body io.Reader, params map[string]string) *http.Request {
+	r, err := http.NewRequest(method, "", body)
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+	ctx := r.Context()
+	ctx = context.WithValue(ctx, auth.CurrentUserKey, auth.CurrentUser{UserName: "username", ID: 1, PrivLevel: auth.PrivLevelAdmin})
+	ctx = context.WithValue(ctx, PathParamsKey, params)
+	ctx = context.WithValue(ctx, DBContextKey, db)
+	ctx = context.WithValue(ctx, ConfigContextKey, &cfg)
+	ctx = context.WithValue(ctx, ReqIDContextKey, uint64(0))
+	var tv trafficvault.TrafficVault = &disabled.Disabled{}
+	ctx = context.WithValue(ctx, TrafficVaultContextKey, tv)
+	return r.WithContext(ctx)
+}
+
+func TestResourceRead(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT\s+id, name, last_updated\s+FROM widget\s+WHERE name=.\s+ORDER BY name`).
+		WithArgs("foo").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "last_updated"}).AddRow(1, "foo", now))
+
+	inf := APIInfo{Tx: db.MustBegin(), Params: map[string]string{"name": "foo"}}
+	objs, maxTime, userErr, sysErr, code := widgets.Read(&inf, nil, false)
+	if userErr != nil || sysErr != nil {
+		t.Fatalf("expected no errors, got: %v, %v (%d)", userErr, sysErr, code)
+	}
+	if maxTime != nil {
+		t.Errorf("expected no last modified time when not using IMS, got: %v", *maxTime)
+	}
+	if len(objs) != 1 || objs[0].ID == nil || *objs[0].ID != 1 || objs[0].Name == nil || *objs[0].Name != "foo" {
+		t.Errorf("expected one widget #1 named 'foo', got: %+v", objs)
+	}
+
+	inf = APIInfo{Tx: inf.Tx, Params: map[string]string{"id": "one"}}
+	if _, _, userErr, _, code = widgets.Read(&inf, nil, false); userErr == nil || code != http.StatusBadRequest {
+		t.Errorf("expected a bad request for a non-integral id, got: %v (%d)", userErr, code)
+	}
+}
+
+func TestResourceReadHandlerNotModified(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	lastModified := time.Now().Add(-time.Hour)
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT max\(t\) FROM`).WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(lastModified))
+	mock.ExpectCommit()
+
+	r := newResourceRequest(t, db, http.MethodGet, nil, map[string]string{})
+	r.Header.Set(rfc.IfModifiedSince, rfc.FormatHTTPDate(lastModified.Add(time.Minute)))
+	w := httptest.NewRecorder()
+	widgets.ReadHandler()(w, r)
+
+	if w.Code != http.StatusNotModified {
+		t.Errorf("expected a 304 Not Modified response, got: %d", w.Code)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("expected no body, got: %s", w.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expected the widgets not to be read: %v", err)
+	}
+}
+
+func TestResourceCreateHandler(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO widget").WillReturnRows(sqlmock.NewRows([]string{"id", "last_updated"}).AddRow(3, time.Now()))
+	mock.ExpectExec("INSERT INTO log").WithArgs(ApiChange, "WIDGET: foo, ID: 3, ACTION: Created widget, keys: { id:3 }", 1).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	r := newResourceRequest(t, db, http.MethodPost, strings.NewReader(`{"name":"foo"}`), map[string]string{})
+	w := httptest.NewRecorder()
+	widgets.CreateHandler()(w, r)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected a 200 OK response, got: %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"text":"widget was created."`) || !strings.Contains(w.Body.String(), `"id":3`) {
+		t.Errorf("expected a creation alert and the created widget, got: %s", w.Body.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
+
+func TestResourceCreateHandlerInvalid(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	r := newResourceRequest(t, db, http.MethodPost, strings.NewReader(`{}`), map[string]string{})
+	w := httptest.NewRecorder()
+	widgets.CreateHandler()(w, r)
+
+	if code, _ := r.Context().Value(tc.StatusKey).(int); code != http.StatusBadRequest {
+		t.Errorf("expected a 400 Bad Request response, got: %d", code)
+	}
+	if !strings.Contains(w.Body.String(), "'name' cannot be blank") {
+		t.Errorf("expected the validation error in the response, got: %s", w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"code":"widget.name.required"`) || !strings.Contains(w.Body.String(), `"field":"name"`) {
+		t.Errorf("expected the code and field of the validation error in the response, got: %s", w.Body.String())
+	}
+}
+
+func TestResourceUpdatePreconditionFailed(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	lastUpdated := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectQuery("select last_updated").WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"last_updated"}).AddRow(lastUpdated))
+
+	h := http.Header{}
+	h.Set(rfc.IfMatch, rfc.ETag(lastUpdated.Add(-time.Minute)))
+	inf := APIInfo{Tx: db.MustBegin(), User: &auth.CurrentUser{ID: 1}}
+	id := 3
+	name := "foo"
+	userErr, sysErr, code := widgets.Update(&inf, h, &widget{ID: &id, Name: &name})
+	if sysErr != nil || userErr != ResourceModifiedError || code != http.StatusPreconditionFailed {
+		t.Errorf("expected a precondition failure, got: %v, %v (%d)", userErr, sysErr, code)
+	}
+}
+
+func TestResourceDeleteNotFound(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM widget").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	inf := APIInfo{Tx: db.MustBegin(), User: &auth.CurrentUser{ID: 1}}
+	id := 3
+	userErr, sysErr, code := widgets.Delete(&inf, &widget{ID: &id})
+	if sysErr != nil || userErr == nil || code != http.StatusNotFound {
+		t.Errorf("expected a not found error, got: %v, %v (%d)", userErr, sysErr, code)
+	}
+}
+
+func TestModifiedSince(t *testing.T) {
+	lastModified := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name     string
+		header   string
+		value    string
+		expected bool
+	}{
+		{"unconditional", "", "", true},
+		{"later IMS", rfc.IfModifiedSince, rfc.FormatHTTPDate(lastModified.Add(time.Minute)), false},
+		{"earlier IMS", rfc.IfModifiedSince, rfc.FormatHTTPDate(lastModified.Add(-time.Minute)), true},
+	}
+	for _, test := range tests {
+		h := http.Header{}
+		if test.header != "" {
+			h.Set(test.header, test.value)
+		}
+		if actual := modifiedSince(h, lastModified); actual != test.expected {
+			t.Errorf("%s: expected modified: %t, got: %t", test.name, test.expected, actual)
+		}
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-rfc"
 	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
 )
 
 type KeyFieldInfo struct {
@@ -109,7 +110,11 @@ func decodeAndValidateRequestBody(r *http.Request, v Validator) (error, error) {
 	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
 		return err, nil
 	}
-	return v.Validate()
+	userErr, sysErr := v.Validate()
+	if identifier, ok := v.(Identifier); ok {
+		tovalidate.SetObject(userErr, strings.ToLower(identifier.GetType()))
+	}
+	return userErr, sysErr
 }
 
 func checkIfOptionsDeleter(obj interface{}, params map[string]string) (bool, error, error, int) {
@@ -140,7 +145,7 @@ func SetLastModifiedHeader(r *http.Request, useIMS bool) bool {
 }
 
 type errWriterFunc func(w http.ResponseWriter, r *http.Request, tx *sql.Tx, statusCode int, userErr error, sysErr error)
-type readSuccessWriterFunc func(w http.ResponseWriter, r *http.Request, statusCode int, results interface{})
+type readSuccessWriterFunc func(w http.ResponseWriter, r *http.Request, statusCode int, results interface{}, pagination *tc.Pagination)
 type deleteSuccessWriterFunc func(w http.ResponseWriter, r *http.Request, message string)
 
 // ReadHandler creates a handler function from the pointer to a struct implementing the Reader interface
@@ -153,9 +158,9 @@ func ReadHandler(reader Reader) http.HandlerFunc {
 	return readHandlerHelper(
 		reader,
 		HandleErr,
-		func(w http.ResponseWriter, r *http.Request, statusCode int, results interface{}) {
+		func(w http.ResponseWriter, r *http.Request, statusCode int, results interface{}, pagination *tc.Pagination) {
 			w.WriteHeader(statusCode)
-			WriteResp(w, r, results)
+			WriteRespPaginated(w, r, results, pagination)
 		},
 	)
 }
@@ -168,7 +173,7 @@ func DeprecatedReadHandler(reader Reader, alternative *string) http.HandlerFunc
 		func(w http.ResponseWriter, r *http.Request, tx *sql.Tx, statusCode int, userErr error, sysErr error) {
 			HandleDeprecatedErr(w, r, tx, statusCode, userErr, sysErr, alternative)
 		},
-		func(w http.ResponseWriter, r *http.Request, statusCode int, results interface{}) {
+		func(w http.ResponseWriter, r *http.Request, statusCode int, results interface{}, _ *tc.Pagination) {
 			alerts := CreateDeprecationAlerts(alternative)
 			WriteAlertsObj(w, r, statusCode, alerts, results)
 		},
@@ -214,7 +219,7 @@ func readHandlerHelper(reader Reader, errHandler errWriterFunc, successHandler r
 			date := maxTime.Format(rfc.LastModifiedFormat)
 			w.Header().Add(rfc.LastModified, date)
 		}
-		successHandler(w, r, errCode, results)
+		successHandler(w, r, errCode, results, inf.Pagination)
 	}
 }
 
@@ -505,6 +510,7 @@ func CreateHandler(creator Creator) http.HandlerFunc {
 				objElem := reflect.ValueOf(objElemInt).Interface().(Creator)
 
 				userErr, sysErr = objElem.Validate()
+				tovalidate.SetObject(userErr, strings.ToLower(objElem.GetType()))
 				if userErr != nil || sysErr != nil {
 					code := http.StatusBadRequest
 					if sysErr != nil {
@@ -0,0 +1,202 @@
+package apitenant
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// onboard.go defines the handler for the api/.../tenants/onboard endpoint.
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/login"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+)
+
+const onboardTenantQuery = `
+INSERT INTO tenant (name, active, parent_id)
+VALUES ($1, TRUE, $2)
+RETURNING id, last_updated
+`
+
+// Onboard is the handler for POST requests to /tenants/onboard. It creates a
+// Tenant from the configured onboarding template - with its quota and starter
+// Delivery Services - and invites its users, all in one transaction, so that
+// if any step fails, nothing is created.
+func Onboard(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	if inf.Config.TenantOnboarding == nil {
+		api.HandleErr(w, r, tx, http.StatusServiceUnavailable, errors.New("tenant onboarding is not configured"), nil)
+		return
+	}
+	tmpl := inf.Config.TenantOnboarding.Template
+
+	var req tc.TenantOnboardingRequest
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	roleIDs, userErr, sysErr, errCode := checkInvitationRoles(inf, tmpl, req.Invitations)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	dses, err := tmpl.RenderDeliveryServices(tc.TenantOnboardingTemplateData{Tenant: req.Name, Variables: req.Variables})
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("expanding the onboarding template's Delivery Services: %w", err), nil)
+		return
+	}
+
+	var parentID int
+	if err := tx.QueryRow(`SELECT id FROM tenant WHERE name = $1`, tmpl.Parent).Scan(&parentID); err == sql.ErrNoRows {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("the parent Tenant '%s' of the onboarding template doesn't exist", tmpl.Parent))
+		return
+	} else if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting onboarding parent tenant: "+err.Error()))
+		return
+	}
+	if ok, err := tenant.IsResourceAuthorizedToUserTx(parentID, inf.User, tx); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("checking tenant: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("not authorized on the parent Tenant of onboarded Tenants"), nil)
+		return
+	}
+
+	resp := tc.TenantOnboarding{
+		Tenant: tc.TenantNullable{
+			Name:       util.StrPtr(req.Name),
+			Active:     util.BoolPtr(true),
+			ParentID:   util.IntPtr(parentID),
+			ParentName: util.StrPtr(tmpl.Parent),
+		},
+		DeliveryServices: make([]tc.DeliveryServiceV4, 0, len(dses)),
+		Invitations:      req.Invitations,
+	}
+	var id int
+	var lastUpdated tc.TimeNoMod
+	if err := tx.QueryRow(onboardTenantQuery, req.Name, parentID).Scan(&id, &lastUpdated); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	resp.Tenant.ID = &id
+	resp.Tenant.LastUpdated = &lastUpdated
+
+	if tmpl.Quota != nil {
+		if _, err := tx.Exec(upsertQuotaQuery, id, tmpl.Quota.MaxDeliveryServices, tmpl.Quota.MaxUsers); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("inserting onboarded tenant quota: "+err.Error()))
+			return
+		}
+	}
+
+	for _, ds := range dses {
+		ds.TenantID = util.IntPtr(id)
+		created, errCode, userErr, sysErr := deliveryservice.Create(r, inf, ds)
+		if userErr != nil || sysErr != nil {
+			if userErr != nil && ds.XMLID != nil {
+				userErr = fmt.Errorf("Delivery Service '%s': %w", *ds.XMLID, userErr)
+			}
+			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+			return
+		}
+		resp.DeliveryServices = append(resp.DeliveryServices, *created)
+	}
+
+	// Invitations are sent last, so that they aren't sent if anything else
+	// fails.
+	for i, invitation := range req.Invitations {
+		if userErr, sysErr, errCode := login.Invite(inf, invitation.Email, roleIDs[i], uint(id)); userErr != nil || sysErr != nil {
+			if userErr != nil {
+				userErr = fmt.Errorf("invitation of %s: %w", invitation.Email.Address.Address, userErr)
+			}
+			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+			return
+		}
+	}
+
+	if tmpl.Quota != nil {
+		quota, _, err := tenant.GetQuota(tx, id)
+		if err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+			return
+		}
+		resp.Quota = &quota
+	}
+
+	changeLogMsg := fmt.Sprintf("TENANT: %s, ID: %d, ACTION: Onboarded with %d Delivery Services and %d invited users", req.Name, id, len(resp.DeliveryServices), len(resp.Invitations))
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+	alerts := tc.CreateAlerts(tc.SuccessLevel, "Tenant '"+req.Name+"' onboarded")
+	w.Header().Set("Location", fmt.Sprintf("/api/%d.%d/tenants?id=%d", inf.Version.Major, inf.Version.Minor, id))
+	api.WriteAlertsObj(w, r, http.StatusCreated, alerts, resp)
+}
+
+// checkInvitationRoles sets the Roles of the invitations that have none to the
+// template's default, checks that they're Roles the template allows and that
+// the user could give users themselves, and returns their IDs, along with a
+// user error, system error, and status code.
+func checkInvitationRoles(inf *api.APIInfo, tmpl tc.TenantOnboardingTemplate, invitations []tc.TenantOnboardingInvitation) ([]uint, error, error, int) {
+	tx := inf.Tx.Tx
+	roleIDs := make([]uint, 0, len(invitations))
+	for i := range invitations {
+		if invitations[i].Role == "" {
+			invitations[i].Role = tmpl.DefaultRole
+		}
+		role := invitations[i].Role
+		if !tmpl.RoleAllowed(role) {
+			return nil, fmt.Errorf("invitations: users of onboarded Tenants can't be given the Role '%s'", role), nil, http.StatusBadRequest
+		}
+		roleID, ok, err := dbhelpers.GetRoleIDFromName(tx, role)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting ID of role '%s': %w", role, err), http.StatusInternalServerError
+		} else if !ok {
+			return nil, nil, fmt.Errorf("the Role '%s' of the onboarding template doesn't exist", role), http.StatusInternalServerError
+		}
+		privLevel, _, err := dbhelpers.GetPrivLevelFromRole(tx, role)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting privilege level of role '%s': %w", role, err), http.StatusInternalServerError
+		}
+		if privLevel > inf.User.PrivLevel {
+			return nil, fmt.Errorf("invitations: cannot invite users with the Role '%s', which is more privileged than yours", role), nil, http.StatusForbidden
+		}
+		caps, err := dbhelpers.GetCapabilitiesFromRoleName(tx, role)
+		if err != nil {
+			return nil, nil, fmt.Errorf("getting permissions of role '%s': %w", role, err), http.StatusInternalServerError
+		}
+		if missing := inf.User.MissingPermissions(caps...); len(missing) != 0 {
+			return nil, fmt.Errorf("invitations: cannot invite users with the Role '%s', which has permissions you lack: %v", role, missing), nil, http.StatusForbidden
+		}
+		roleIDs = append(roleIDs, uint(roleID))
+	}
+	return roleIDs, nil, nil, http.StatusOK
+}
@@ -0,0 +1,84 @@
+package apitenant
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestCheckInvitationRoles(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	tmpl := tc.TenantOnboardingTemplate{Parent: "customers", DefaultRole: "portal", Roles: []string{"operations"}}
+	expectRole := func(id, privLevel int) {
+		mock.ExpectQuery("SELECT id FROM role").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+		mock.ExpectQuery("SELECT priv_level FROM role").WillReturnRows(sqlmock.NewRows([]string{"priv_level"}).AddRow(privLevel))
+		mock.ExpectQuery("SELECT cap_name FROM role_capability").WillReturnRows(sqlmock.NewRows([]string{"cap_name"}).AddRow("DELIVERY-SERVICE:READ"))
+	}
+
+	mock.ExpectBegin()
+	expectRole(4, 15)
+	expectRole(3, 20)
+	inf := &api.APIInfo{Tx: db.MustBegin(), User: &auth.CurrentUser{RoleName: tc.AdminRoleName, PrivLevel: auth.PrivLevelAdmin}}
+	invitations := []tc.TenantOnboardingInvitation{{}, {Role: "operations"}}
+	roleIDs, userErr, sysErr, _ := checkInvitationRoles(inf, tmpl, invitations)
+	if userErr != nil || sysErr != nil {
+		t.Fatalf("unexpected errors: %v, %v", userErr, sysErr)
+	}
+	if len(roleIDs) != 2 || roleIDs[0] != 4 || roleIDs[1] != 3 {
+		t.Errorf("expected role IDs [4 3], got %v", roleIDs)
+	}
+	if invitations[0].Role != "portal" {
+		t.Errorf("expected an invitation without a role to be given the default role 'portal', got '%s'", invitations[0].Role)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+
+	_, userErr, _, code := checkInvitationRoles(inf, tmpl, []tc.TenantOnboardingInvitation{{Role: "admin"}})
+	if userErr == nil || code != http.StatusBadRequest {
+		t.Errorf("expected a Bad Request error for a role the template doesn't allow, got %d: %v", code, userErr)
+	}
+
+	mock.ExpectQuery("SELECT id FROM role").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectQuery("SELECT priv_level FROM role").WillReturnRows(sqlmock.NewRows([]string{"priv_level"}).AddRow(20))
+	inf.User = &auth.CurrentUser{RoleName: "portal", PrivLevel: 15}
+	_, userErr, _, code = checkInvitationRoles(inf, tmpl, []tc.TenantOnboardingInvitation{{Role: "operations"}})
+	if userErr == nil || code != http.StatusForbidden {
+		t.Errorf("expected a Forbidden error for a role more privileged than the user's, got %d: %v", code, userErr)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+package apitenant
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// quota.go defines the handlers for the api/.../tenants/{id}/quota endpoint.
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+)
+
+const upsertQuotaQuery = `
+INSERT INTO tenant_quota (tenant, max_deliveryservices, max_users)
+VALUES ($1, $2, $3)
+ON CONFLICT (tenant) DO UPDATE SET
+max_deliveryservices = EXCLUDED.max_deliveryservices,
+max_users = EXCLUDED.max_users
+`
+
+const deleteQuotaQuery = `
+DELETE FROM tenant_quota
+WHERE tenant = $1
+`
+
+// GetQuota is the handler for GET requests to /tenants/{{ID}}/quota.
+func GetQuota(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+
+	name, userErr, sysErr, errCode := checkTenant(inf, id)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	quota, ok, err := tenant.GetQuota(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("Tenant '%s' has no quota", name), nil)
+		return
+	}
+	api.WriteResp(w, r, quota)
+}
+
+// UpdateQuota is the handler for PUT requests to /tenants/{{ID}}/quota, which
+// sets the quota of a Tenant.
+func UpdateQuota(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+
+	name, userErr, sysErr, errCode := checkTenant(inf, id)
+	if userErr == nil && sysErr == nil {
+		userErr, errCode = checkOwnQuota(inf, id)
+	}
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var req tc.TenantQuotaRequest
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	if _, err := tx.Exec(upsertQuotaQuery, id, req.MaxDeliveryServices, req.MaxUsers); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	quota, _, err := tenant.GetQuota(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "TENANT: "+name+", ID: "+strconv.Itoa(id)+", ACTION: Updated quota", inf.User, tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, fmt.Sprintf("Tenant '%s' quota updated", name), quota)
+}
+
+// DeleteQuota is the handler for DELETE requests to /tenants/{{ID}}/quota,
+// which removes the quota of a Tenant.
+func DeleteQuota(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+
+	name, userErr, sysErr, errCode := checkTenant(inf, id)
+	if userErr == nil && sysErr == nil {
+		userErr, errCode = checkOwnQuota(inf, id)
+	}
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	result, err := tx.Exec(deleteQuotaQuery, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deleting tenant quota: "+err.Error()))
+		return
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting rows affected by deleting tenant quota: "+err.Error()))
+		return
+	} else if rows == 0 {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("Tenant '%s' has no quota", name), nil)
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "TENANT: "+name+", ID: "+strconv.Itoa(id)+", ACTION: Deleted quota", inf.User, tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, fmt.Sprintf("Tenant '%s' quota deleted", name))
+}
+
+// checkOwnQuota returns a Forbidden error if the Tenant with the given ID is
+// the user's own, because otherwise users could lift the limits they're
+// subject to. Admins may modify the quota of their own Tenant.
+func checkOwnQuota(inf *api.APIInfo, id int) (error, int) {
+	if id == inf.User.TenantID && inf.User.RoleName != tc.AdminRoleName {
+		return errors.New("users may not modify the quota of their own Tenant"), http.StatusForbidden
+	}
+	return nil, http.StatusOK
+}
@@ -0,0 +1,305 @@
+package apitenant
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// readview.go defines the handlers for the api/.../tenants/{id}/read-view
+// endpoint.
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/policy"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+const selectReadViewQuery = `
+SELECT
+	t.id,
+	t.name,
+	ARRAY(SELECT vt.viewed_tenant FROM tenant_read_view_tenant vt WHERE vt.tenant = v.tenant ORDER BY vt.viewed_tenant),
+	v.redacted_deliveryservice_fields,
+	v.redacted_server_fields,
+	v.last_updated
+FROM tenant_read_view v
+JOIN tenant t ON t.id = v.tenant
+WHERE v.tenant = $1
+`
+
+const upsertReadViewQuery = `
+INSERT INTO tenant_read_view (tenant, redacted_deliveryservice_fields, redacted_server_fields)
+VALUES ($1, $2, $3)
+ON CONFLICT (tenant) DO UPDATE SET
+redacted_deliveryservice_fields = EXCLUDED.redacted_deliveryservice_fields,
+redacted_server_fields = EXCLUDED.redacted_server_fields
+`
+
+const deleteViewedTenantsQuery = `
+DELETE FROM tenant_read_view_tenant
+WHERE tenant = $1
+`
+
+const insertViewedTenantsQuery = `
+INSERT INTO tenant_read_view_tenant (tenant, viewed_tenant)
+SELECT $1, UNNEST($2::bigint[])
+`
+
+const deleteReadViewQuery = `
+DELETE FROM tenant_read_view
+WHERE tenant = $1
+`
+
+const existingTenantsQuery = `
+SELECT COALESCE(ARRAY_AGG(id), '{}'::bigint[])
+FROM tenant
+WHERE id = ANY($1::bigint[])
+`
+
+// GetReadView is the handler for GET requests to /tenants/{{ID}}/read-view.
+func GetReadView(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+
+	name, userErr, sysErr, errCode := checkTenant(inf, id)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	view, ok, err := getReadView(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("Tenant '%s' has no read view", name), nil)
+		return
+	}
+	api.WriteResp(w, r, view)
+}
+
+// UpdateReadView is the handler for PUT requests to /tenants/{{ID}}/read-view,
+// which sets the read view of a Tenant.
+func UpdateReadView(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+
+	name, userErr, sysErr, errCode := checkTenant(inf, id)
+	if userErr == nil && sysErr == nil {
+		userErr, errCode = checkOwnReadView(inf, id)
+	}
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var req tc.TenantReadViewRequest
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	if err := validateRedactedFields(req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	if userErr, sysErr, errCode := checkViewedTenants(inf, id, req.ViewedTenantIDs); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	if _, err := tx.Exec(upsertReadViewQuery, id, pq.Array(req.RedactedDeliveryServiceFields), pq.Array(req.RedactedServerFields)); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if _, err := tx.Exec(deleteViewedTenantsQuery, id); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deleting viewed tenants: "+err.Error()))
+		return
+	}
+	if _, err := tx.Exec(insertViewedTenantsQuery, id, pq.Array(req.ViewedTenantIDs)); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	view, _, err := getReadView(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "TENANT: "+name+", ID: "+strconv.Itoa(id)+", ACTION: Updated read view", inf.User, tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, fmt.Sprintf("Tenant '%s' read view updated", name), view)
+}
+
+// DeleteReadView is the handler for DELETE requests to
+// /tenants/{{ID}}/read-view, which removes the read view of a Tenant.
+func DeleteReadView(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+
+	name, userErr, sysErr, errCode := checkTenant(inf, id)
+	if userErr == nil && sysErr == nil {
+		userErr, errCode = checkOwnReadView(inf, id)
+	}
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	result, err := tx.Exec(deleteReadViewQuery, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deleting tenant read view: "+err.Error()))
+		return
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting rows affected by deleting tenant read view: "+err.Error()))
+		return
+	} else if rows == 0 {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("Tenant '%s' has no read view", name), nil)
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "TENANT: "+name+", ID: "+strconv.Itoa(id)+", ACTION: Deleted read view", inf.User, tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, fmt.Sprintf("Tenant '%s' read view deleted", name))
+}
+
+// checkTenant checks that the Tenant with the given ID exists and
+// that the user may see it. It returns the Tenant's name, along with a user
+// error, system error, and status code.
+func checkTenant(inf *api.APIInfo, id int) (string, error, error, int) {
+	var name string
+	if err := inf.Tx.Tx.QueryRow(`SELECT name FROM tenant WHERE id = $1`, id).Scan(&name); err == sql.ErrNoRows {
+		return "", fmt.Errorf("no Tenant exists by ID '%d'", id), nil, http.StatusNotFound
+	} else if err != nil {
+		return "", nil, errors.New("getting tenant name: " + err.Error()), http.StatusInternalServerError
+	}
+	if ok, err := tenant.IsResourceAuthorizedToUserTx(id, inf.User, inf.Tx.Tx); err != nil {
+		return "", nil, errors.New("checking tenant: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return "", errors.New("not authorized on this tenant"), nil, http.StatusForbidden
+	}
+	return name, nil, nil, http.StatusOK
+}
+
+// checkOwnReadView returns a Forbidden error if the Tenant with the given ID
+// is the user's own, because otherwise users could lift the redaction they're
+// subject to. Admins may modify the read view of their own Tenant.
+func checkOwnReadView(inf *api.APIInfo, id int) (error, int) {
+	if id == inf.User.TenantID && inf.User.RoleName != tc.AdminRoleName {
+		return errors.New("users may not modify the read view of their own Tenant"), http.StatusForbidden
+	}
+	return nil, http.StatusOK
+}
+
+// validateRedactedFields checks that the redacted fields of the request are
+// fields of Delivery Services and servers that can be redacted.
+func validateRedactedFields(req tc.TenantReadViewRequest) error {
+	errs := []error{}
+	for _, field := range req.RedactedDeliveryServiceFields {
+		if !policy.RedactableField(tc.FieldPolicyObjectTypeDeliveryService, field) {
+			errs = append(errs, fmt.Errorf("redactedDeliveryServiceFields: '%s' is not a field of Delivery Services that can be redacted", field))
+		}
+	}
+	for _, field := range req.RedactedServerFields {
+		if !policy.RedactableField(tc.FieldPolicyObjectTypeServer, field) {
+			errs = append(errs, fmt.Errorf("redactedServerFields: '%s' is not a field of servers that can be redacted", field))
+		}
+	}
+	return util.JoinErrs(errs)
+}
+
+// checkViewedTenants checks that the viewed Tenants exist, aren't the Tenant
+// whose read view it is, and are all Tenants the user may see themselves, so
+// that users can't grant views of Delivery Services they couldn't read.
+func checkViewedTenants(inf *api.APIInfo, id int, viewedTenantIDs []int) (error, error, int) {
+	if len(viewedTenantIDs) == 0 {
+		return nil, nil, http.StatusOK
+	}
+	existing := []int64{}
+	if err := inf.Tx.Tx.QueryRow(existingTenantsQuery, pq.Array(viewedTenantIDs)).Scan(pq.Array(&existing)); err != nil {
+		return nil, errors.New("querying viewed tenants: " + err.Error()), http.StatusInternalServerError
+	}
+	exists := make(map[int]struct{}, len(existing))
+	for _, e := range existing {
+		exists[int(e)] = struct{}{}
+	}
+	for _, viewed := range viewedTenantIDs {
+		if viewed == id {
+			return errors.New("viewedTenantIds: a Tenant can't view itself"), nil, http.StatusBadRequest
+		}
+		if _, ok := exists[viewed]; !ok {
+			return fmt.Errorf("viewedTenantIds: no Tenant exists by ID '%d'", viewed), nil, http.StatusBadRequest
+		}
+		if ok, err := tenant.IsResourceAuthorizedToUserTx(viewed, inf.User, inf.Tx.Tx); err != nil {
+			return nil, errors.New("checking viewed tenant: " + err.Error()), http.StatusInternalServerError
+		} else if !ok {
+			return fmt.Errorf("viewedTenantIds: not authorized on Tenant #%d", viewed), nil, http.StatusForbidden
+		}
+	}
+	return nil, nil, http.StatusOK
+}
+
+// getReadView returns the read view of the Tenant with the given ID, and
+// whether it has one.
+func getReadView(tx *sql.Tx, id int) (tc.TenantReadView, bool, error) {
+	var view tc.TenantReadView
+	var viewed []int64
+	err := tx.QueryRow(selectReadViewQuery, id).Scan(&view.TenantID, &view.TenantName, pq.Array(&viewed), pq.Array(&view.RedactedDeliveryServiceFields), pq.Array(&view.RedactedServerFields), &view.LastUpdated)
+	if err == sql.ErrNoRows {
+		return tc.TenantReadView{}, false, nil
+	} else if err != nil {
+		return tc.TenantReadView{}, false, fmt.Errorf("querying read view of tenant #%d: %w", id, err)
+	}
+	view.ViewedTenantIDs = make([]int, 0, len(viewed))
+	for _, v := range viewed {
+		view.ViewedTenantIDs = append(view.ViewedTenantIDs, int(v))
+	}
+	if view.RedactedDeliveryServiceFields == nil {
+		view.RedactedDeliveryServiceFields = []string{}
+	}
+	if view.RedactedServerFields == nil {
+		view.RedactedServerFields = []string{}
+	}
+	return view, true, nil
+}
@@ -0,0 +1,158 @@
+package apiusage
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+
+	"github.com/lib/pq"
+)
+
+const upsertUsageQuery = `
+INSERT INTO api_usage AS u (
+	"day",
+	username,
+	credential,
+	route_id,
+	route,
+	calls,
+	bytes_in,
+	bytes_out,
+	last_call
+)
+SELECT * FROM UNNEST(
+	$1::date[],
+	$2::text[],
+	$3::text[],
+	$4::bigint[],
+	$5::text[],
+	$6::bigint[],
+	$7::bigint[],
+	$8::bigint[],
+	$9::timestamptz[]
+)
+ON CONFLICT ("day", username, credential, route_id) DO UPDATE SET
+	route = EXCLUDED.route,
+	calls = u.calls + EXCLUDED.calls,
+	bytes_in = u.bytes_in + EXCLUDED.bytes_in,
+	bytes_out = u.bytes_out + EXCLUDED.bytes_out,
+	last_call = GREATEST(u.last_call, EXCLUDED.last_call)
+`
+
+const pruneUsageQuery = `
+DELETE FROM api_usage
+WHERE "day" < CURRENT_DATE - $1::integer
+`
+
+// InitFlusher starts recording API usage in Default, and writing it to the
+// database every interval, deleting usage older than retentionDays days. If
+// retentionDays isn't positive, usage is kept forever. If interval isn't
+// positive, API usage isn't recorded.
+//
+// Flushing is safe with any number of Traffic Ops instances writing to the
+// same database.
+func InitFlusher(interval time.Duration, db *sql.DB, timeout time.Duration, retentionDays int) {
+	if interval <= 0 {
+		log.Infoln("API usage flush interval is negative, API usage will not be recorded")
+		return
+	}
+	Default = NewRecorder()
+	go func() {
+		for {
+			time.Sleep(interval)
+			if err := flush(db, timeout, Default, retentionDays); err != nil {
+				log.Errorf("writing API usage: %v", err)
+			}
+		}
+	}()
+}
+
+// flush writes the usage recorded by the Recorder to the database, and
+// deletes usage older than retentionDays days. Usage which can't be written
+// is kept by the Recorder, to be written next time.
+func flush(db *sql.DB, timeout time.Duration, rec *Recorder, retentionDays int) error {
+	usage := rec.take()
+	if err := writeUsage(db, timeout, usage, retentionDays); err != nil {
+		rec.restore(usage)
+		return err
+	}
+	return nil
+}
+
+func writeUsage(db *sql.DB, timeout time.Duration, usage map[usageKey]*usageTotals, retentionDays int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	commit := false
+	defer func() {
+		if !commit {
+			if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+				log.Errorf("rolling back API usage transaction: %v", err)
+			}
+		}
+	}()
+
+	if len(usage) > 0 {
+		days := make([]string, 0, len(usage))
+		users := make([]string, 0, len(usage))
+		credentials := make([]string, 0, len(usage))
+		routeIDs := make([]int64, 0, len(usage))
+		routes := make([]string, 0, len(usage))
+		calls := make([]int64, 0, len(usage))
+		bytesIn := make([]int64, 0, len(usage))
+		bytesOut := make([]int64, 0, len(usage))
+		// timestamps are given as text, which pq.Array supports
+		lastCalls := make([]string, 0, len(usage))
+		for key, totals := range usage {
+			days = append(days, key.day)
+			users = append(users, key.user)
+			credentials = append(credentials, key.credential)
+			routeIDs = append(routeIDs, int64(key.routeID))
+			routes = append(routes, key.route)
+			calls = append(calls, totals.calls)
+			bytesIn = append(bytesIn, totals.bytesIn)
+			bytesOut = append(bytesOut, totals.bytesOut)
+			lastCalls = append(lastCalls, totals.lastCall.Format(time.RFC3339Nano))
+		}
+		if _, err := tx.Exec(upsertUsageQuery, pq.Array(days), pq.Array(users), pq.Array(credentials), pq.Array(routeIDs), pq.Array(routes), pq.Array(calls), pq.Array(bytesIn), pq.Array(bytesOut), pq.Array(lastCalls)); err != nil {
+			return fmt.Errorf("upserting API usage: %w", err)
+		}
+	}
+	if retentionDays > 0 {
+		if _, err := tx.Exec(pruneUsageQuery, retentionDays); err != nil {
+			return fmt.Errorf("deleting old API usage: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	commit = true
+	return nil
+}
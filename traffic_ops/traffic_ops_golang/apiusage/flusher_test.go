@@ -0,0 +1,79 @@
+package apiusage
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestFlush(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rec := NewRecorder()
+	rec.Record(time.Now(), Usage{RouteID: 1, Route: "GET servers/?$", User: "bob", Credential: "cookie"})
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO api_usage").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("DELETE FROM api_usage").WithArgs(90).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	if err := flush(db, time.Second, rec, 90); err != nil {
+		t.Fatalf("unexpected error flushing API usage: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+	if usage := rec.take(); len(usage) != 0 {
+		t.Errorf("expected flushed usage to be removed from the Recorder, got %+v", usage)
+	}
+}
+
+func TestFlushFailure(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	rec := NewRecorder()
+	rec.Record(time.Now(), Usage{RouteID: 1, Route: "GET servers/?$", User: "bob", Credential: "cookie"})
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO api_usage").WillReturnError(errors.New("database is down"))
+	mock.ExpectRollback()
+
+	if err := flush(db, time.Second, rec, 0); err == nil {
+		t.Fatal("expected an error flushing API usage, got nil")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+	if usage := rec.take(); len(usage) != 1 {
+		t.Errorf("expected usage which couldn't be written to be kept by the Recorder, got %+v", usage)
+	}
+}
@@ -0,0 +1,154 @@
+// Package apiusage records the usage of the Traffic Ops API by each user, so
+// that abandoned automation and heavy users can be found. Usage is combined
+// in memory and periodically written to the database, where it's kept for a
+// configurable number of days.
+package apiusage
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+)
+
+// dayLayout is the layout of the days by which usage is combined.
+const dayLayout = "2006-01-02"
+
+// Default is the Recorder of the usage of the Traffic Ops API. It's nil, and
+// records nothing, unless InitFlusher has been called.
+var Default *Recorder
+
+// Usage is the usage of a single request.
+type Usage struct {
+	RouteID    int
+	Route      string
+	User       string
+	Credential string
+	BytesIn    int64
+	BytesOut   int64
+}
+
+type usageKey struct {
+	day        string
+	user       string
+	credential string
+	routeID    int
+	route      string
+}
+
+type usageTotals struct {
+	calls    int64
+	bytesIn  int64
+	bytesOut int64
+	lastCall time.Time
+}
+
+func (t *usageTotals) add(o usageTotals) {
+	t.calls += o.calls
+	t.bytesIn += o.bytesIn
+	t.bytesOut += o.bytesOut
+	if o.lastCall.After(t.lastCall) {
+		t.lastCall = o.lastCall
+	}
+}
+
+// Recorder combines the usage of each user of each route on each day, until
+// it's written to the database. It's safe for concurrent use, and all its
+// methods may be called on a nil Recorder, which does nothing.
+type Recorder struct {
+	m     sync.Mutex
+	usage map[usageKey]*usageTotals
+}
+
+// NewRecorder returns a new, empty Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{usage: map[usageKey]*usageTotals{}}
+}
+
+// Record adds the usage of a request made at the given time. Unauthenticated
+// requests, which have no user, aren't recorded.
+func (rec *Recorder) Record(t time.Time, u Usage) {
+	if rec == nil || u.User == "" {
+		return
+	}
+	key := usageKey{
+		day:        t.UTC().Format(dayLayout),
+		user:       u.User,
+		credential: u.Credential,
+		routeID:    u.RouteID,
+		route:      u.Route,
+	}
+	rec.m.Lock()
+	defer rec.m.Unlock()
+	rec.addLocked(key, usageTotals{calls: 1, bytesIn: u.BytesIn, bytesOut: u.BytesOut, lastCall: t})
+}
+
+func (rec *Recorder) addLocked(key usageKey, totals usageTotals) {
+	existing, ok := rec.usage[key]
+	if !ok {
+		existing = &usageTotals{}
+		rec.usage[key] = existing
+	}
+	existing.add(totals)
+}
+
+// take returns the usage recorded since it was last called, and empties the
+// Recorder.
+func (rec *Recorder) take() map[usageKey]*usageTotals {
+	if rec == nil {
+		return nil
+	}
+	rec.m.Lock()
+	defer rec.m.Unlock()
+	usage := rec.usage
+	rec.usage = map[usageKey]*usageTotals{}
+	return usage
+}
+
+// restore adds usage returned by take back to the Recorder, for when it
+// couldn't be written to the database.
+func (rec *Recorder) restore(usage map[usageKey]*usageTotals) {
+	if rec == nil {
+		return
+	}
+	rec.m.Lock()
+	defer rec.m.Unlock()
+	for key, totals := range usage {
+		rec.addLocked(key, *totals)
+	}
+}
+
+// Credential returns the kind of credential with which the request was made,
+// as one of the tc.APIUsageCredential constants.
+func Credential(r *http.Request) string {
+	if strings.Contains(r.Header.Get(rfc.Authorization), "Bearer") {
+		return tc.APIUsageCredentialBearer
+	}
+	if _, err := r.Cookie(api.AccessToken); err == nil {
+		return tc.APIUsageCredentialAccessToken
+	}
+	return tc.APIUsageCredentialCookie
+}
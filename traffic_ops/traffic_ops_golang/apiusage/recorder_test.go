@@ -0,0 +1,95 @@
+package apiusage
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+)
+
+func TestRecorderRecord(t *testing.T) {
+	rec := NewRecorder()
+	first := time.Date(2022, time.June, 18, 12, 0, 0, 0, time.UTC)
+	last := first.Add(time.Hour)
+	u := Usage{RouteID: 1, Route: "GET servers/?$", User: "bob", Credential: tc.APIUsageCredentialCookie, BytesIn: 10, BytesOut: 100}
+
+	rec.Record(last, u)
+	rec.Record(first, u)
+	rec.Record(first.AddDate(0, 0, 1), u)
+	rec.Record(first, Usage{RouteID: 1, Route: "GET servers/?$", User: "bob", Credential: tc.APIUsageCredentialBearer})
+	rec.Record(first, Usage{RouteID: 1, Route: "GET servers/?$"})
+
+	usage := rec.take()
+	if len(usage) != 3 {
+		t.Fatalf("expected usage of 3 days/credentials, got %d: %+v", len(usage), usage)
+	}
+	key := usageKey{day: "2022-06-18", user: "bob", credential: tc.APIUsageCredentialCookie, routeID: 1, route: "GET servers/?$"}
+	totals, ok := usage[key]
+	if !ok {
+		t.Fatalf("expected usage for %+v, got %+v", key, usage)
+	}
+	expected := usageTotals{calls: 2, bytesIn: 20, bytesOut: 200, lastCall: last}
+	if *totals != expected {
+		t.Errorf("expected totals %+v, got %+v", expected, *totals)
+	}
+
+	if usage := rec.take(); len(usage) != 0 {
+		t.Errorf("expected no usage after it was taken, got %+v", usage)
+	}
+
+	rec.Record(first, u)
+	rec.restore(map[usageKey]*usageTotals{key: totals})
+	usage = rec.take()
+	expected = usageTotals{calls: 3, bytesIn: 30, bytesOut: 300, lastCall: last}
+	if totals, ok := usage[key]; !ok || *totals != expected {
+		t.Errorf("expected restored totals %+v, got %+v", expected, usage[key])
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	var rec *Recorder
+	rec.Record(time.Now(), Usage{User: "bob"})
+	rec.restore(map[usageKey]*usageTotals{{user: "bob"}: {calls: 1}})
+	if usage := rec.take(); usage != nil {
+		t.Errorf("expected a nil Recorder to record nothing, got %+v", usage)
+	}
+}
+
+func TestCredential(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/api/5.0/servers", nil)
+	if cred := Credential(r); cred != tc.APIUsageCredentialCookie {
+		t.Errorf("expected credential '%s' for a request without a token, got '%s'", tc.APIUsageCredentialCookie, cred)
+	}
+
+	r.AddCookie(&http.Cookie{Name: api.AccessToken, Value: "token"})
+	if cred := Credential(r); cred != tc.APIUsageCredentialAccessToken {
+		t.Errorf("expected credential '%s' for a request with an access token cookie, got '%s'", tc.APIUsageCredentialAccessToken, cred)
+	}
+
+	r.Header.Set("Authorization", "Bearer token")
+	if cred := Credential(r); cred != tc.APIUsageCredentialBearer {
+		t.Errorf("expected credential '%s' for a request with a bearer token, got '%s'", tc.APIUsageCredentialBearer, cred)
+	}
+}
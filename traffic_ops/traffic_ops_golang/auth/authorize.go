@@ -45,9 +45,20 @@ type CurrentUser struct {
 	RoleName     string         `json:"roleName" db:"role_name"`
 	Capabilities pq.StringArray `json:"capabilities" db:"capabilities"`
 	UCDN         string         `json:"ucdn" db:"ucdn"`
-	perms        map[string]struct{}
+	MFAEnrolled  bool           `json:"mfaEnrolled" db:"mfa_enrolled"`
+	// ImpersonatedBy is the username of the user who is impersonating this
+	// user, if any.
+	ImpersonatedBy string `json:"impersonatedBy,omitempty" db:"-"`
+	perms          map[string]struct{}
 }
 
+// MFAEnrolledSelect is a SQL expression which is whether or not the tm_user
+// AS u has a confirmed second authentication factor.
+const MFAEnrolledSelect = `(
+  EXISTS (SELECT 1 FROM user_mfa_totp AS t WHERE t.user_id = u.id AND t.secret IS NOT NULL)
+  OR EXISTS (SELECT 1 FROM user_webauthn_credential AS w WHERE w.user_id = u.id)
+)`
+
 // Can returns whether or not the user has the specified Permission, i.e.
 // whether or not they "can" do something.
 func (cu CurrentUser) Can(permission string) bool {
@@ -58,6 +69,16 @@ func (cu CurrentUser) Can(permission string) bool {
 	return ok
 }
 
+// AuditMessage returns the given change log message of an action taken by the
+// user, marked as having been taken by their impersonator if they're being
+// impersonated.
+func (cu CurrentUser) AuditMessage(msg string) string {
+	if cu.ImpersonatedBy == "" {
+		return msg
+	}
+	return "[IMPERSONATED BY " + cu.ImpersonatedBy + "] " + msg
+}
+
 // MissingPermissions returns all of the passed Permissions that the user does
 // not have.
 func (cu CurrentUser) MissingPermissions(permissions ...string) []string {
@@ -106,7 +127,7 @@ const CurrentUserKey key = iota
 
 // GetCurrentUserFromDB  - returns the id and privilege level of the given user along with the username, or -1 as the id, - as the userName and PrivLevelInvalid if the user doesn't exist, along with a user facing error, a system error to log, and an error code to return
 func GetCurrentUserFromDB(DB *sqlx.DB, user string, timeout time.Duration) (CurrentUser, error, error, int) {
-	invalidUser := CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, "", []string{}, "", nil}
+	invalidUser := CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, "", []string{}, "", false, "", nil}
 	if usersCacheIsEnabled() {
 		u, exists := getUserFromCache(user)
 		if !exists {
@@ -123,7 +144,8 @@ SELECT
   u.username,
   u.tenant_id,
   ARRAY(SELECT rc.cap_name FROM role_capability AS rc WHERE rc.role_id=r.id) AS capabilities,
-  u.ucdn
+  u.ucdn,
+  ` + MFAEnrolledSelect + ` AS mfa_enrolled
 FROM
   tm_user AS u
 JOIN
@@ -134,7 +156,7 @@ WHERE
 
 	var currentUserInfo CurrentUser
 	if DB == nil {
-		return CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, "", []string{}, "", nil}, nil, errors.New("no db provided to GetCurrentUserFromDB"), http.StatusInternalServerError
+		return CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, "", []string{}, "", false, "", nil}, nil, errors.New("no db provided to GetCurrentUserFromDB"), http.StatusInternalServerError
 	}
 	dbCtx, dbClose := context.WithTimeout(context.Background(), timeout)
 	defer dbClose()
@@ -167,7 +189,7 @@ func GetCurrentUser(ctx context.Context) (*CurrentUser, error) {
 			return nil, fmt.Errorf("CurrentUser found with bad type: %T", v)
 		}
 	}
-	return &CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, "", []string{}, "", nil}, errors.New("No user found in Context")
+	return &CurrentUser{"-", -1, PrivLevelInvalid, TenantIDInvalid, -1, "", []string{}, "", false, "", nil}, errors.New("No user found in Context")
 }
 
 func CheckLocalUserIsAllowed(form PasswordForm, db *sqlx.DB, ctx context.Context) (bool, error, error) {
@@ -60,3 +60,16 @@ func TestCurrentUser_MissingPermissions(t *testing.T) {
 		t.Errorf("Expected user to be missing 'do-something-else' Permission, actually missing: %s", missing[0])
 	}
 }
+
+func ExampleCurrentUser_AuditMessage() {
+	cu := CurrentUser{UserName: "user", ImpersonatedBy: "admin"}
+	fmt.Println(cu.AuditMessage("did something"))
+	// Output: [IMPERSONATED BY admin] did something
+}
+
+func TestCurrentUser_AuditMessage(t *testing.T) {
+	cu := CurrentUser{UserName: "user"}
+	if msg := cu.AuditMessage("did something"); msg != "did something" {
+		t.Errorf("Expected the change log message of a user who isn't being impersonated to be unchanged, actual: %s", msg)
+	}
+}
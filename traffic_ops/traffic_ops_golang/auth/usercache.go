@@ -40,7 +40,8 @@ const (
 			u.tenant_id,
 			u.token,
 			u.ucdn,
-			u.username
+			u.username,
+			` + MFAEnrolledSelect + ` AS mfa_enrolled
 		FROM
 			tm_user AS u
 	`
@@ -189,7 +190,7 @@ func getUsers(db *sql.DB, timeout time.Duration) (map[string]user, error) {
 	defer log.Close(rows, "closing users rows")
 	for rows.Next() {
 		u := user{}
-		if err := rows.Scan(&u.ID, &u.LocalPasswd, &u.Role, &u.TenantID, &u.Token, &u.UCDN, &u.UserName); err != nil {
+		if err := rows.Scan(&u.ID, &u.LocalPasswd, &u.Role, &u.TenantID, &u.Token, &u.UCDN, &u.UserName, &u.MFAEnrolled); err != nil {
 			return nil, errors.New("scanning users: " + err.Error())
 		}
 		r := roles[u.Role]
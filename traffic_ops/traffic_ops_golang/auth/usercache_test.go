@@ -65,13 +65,13 @@ func TestGetUsers(t *testing.T) {
 		},
 	}
 	roleRows := sqlmock.NewRows([]string{"capabilities", "role", "role_name", "priv_level"})
-	userRows := sqlmock.NewRows([]string{"id", "local_passwd", "role", "tenant_id", "token", "ucdn", "username"})
+	userRows := sqlmock.NewRows([]string{"id", "local_passwd", "role", "tenant_id", "token", "ucdn", "username", "mfa_enrolled"})
 
 	for _, r := range expectedRoles {
 		roleRows.AddRow("{"+strings.Join(r.Capabilities, ",")+"}", r.ID, r.Name, r.PrivLevel)
 	}
 	for _, u := range expectedUsers {
-		userRows.AddRow(u.ID, u.LocalPasswd, u.Role, u.TenantID, u.Token, u.UCDN, u.UserName)
+		userRows.AddRow(u.ID, u.LocalPasswd, u.Role, u.TenantID, u.Token, u.UCDN, u.UserName, u.MFAEnrolled)
 	}
 	mock.ExpectBegin()
 	mock.ExpectQuery("SELECT.+").WillReturnRows(roleRows)
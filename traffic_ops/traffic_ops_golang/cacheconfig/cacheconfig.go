@@ -0,0 +1,333 @@
+// Package cacheconfig implements the /cache-config/{server} endpoint, which
+// bundles all the Traffic Ops data a cache server needs to generate its
+// configuration into a single response.
+package cacheconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+
+	"github.com/lib/pq"
+)
+
+// maxConcurrentRequests is the maximum number of API requests made
+// concurrently to assemble a single bundle. Each uses its own database
+// transaction, so this bounds how many connections one bundle may hold.
+const maxConcurrentRequests = 4
+
+// configFiles are the config files whose Parameters cache servers request by
+// config file name.
+var configFiles = []string{"cachekey.config", "remap.config", "parent.config"}
+
+// Handler returns the handler for GET requests to /cache-config/{server},
+// which assembles the bundle by making the API requests a cache server would
+// itself make to the given handler, ordinarily the Traffic Ops router.
+//
+// The requests are made with the credentials of the client, so the bundle
+// only includes data the client is itself allowed to read.
+func Handler(h http.Handler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		get(h, w, r)
+	}
+}
+
+func get(h http.Handler, w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"server"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	hostName := inf.Params["server"]
+	servers, err := dbhelpers.GetServerInfosFromHostNames(inf.Tx.Tx, []string{hostName})
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting server: "+err.Error()))
+		return
+	}
+	if len(servers) == 0 {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no such server: "+hostName), nil)
+		return
+	}
+	server := servers[0]
+
+	cdnName, ok, err := dbhelpers.GetCDNNameFromID(inf.Tx.Tx, int64(server.CDNID))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting server CDN: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, fmt.Errorf("server '%s' CDN %d does not exist", hostName, server.CDNID))
+		return
+	}
+
+	profiles, err := getServerProfileNames(inf.Tx.Tx, server.ID)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting server profiles: "+err.Error()))
+		return
+	}
+
+	// Every request made to assemble the bundle uses its own transaction, so
+	// this one mustn't be held open while they're made.
+	inf.Close()
+
+	sub := &subRequester{
+		h:      h,
+		r:      r,
+		prefix: fmt.Sprintf("/api/%d.%d/", inf.Version.Major, inf.Version.Minor),
+	}
+	bundle := tc.CacheConfig{
+		Server:    hostName,
+		Responses: assemble(sub, string(cdnName), server.CDNID, profiles),
+	}
+
+	body, err := json.Marshal(tc.CacheConfigResponse{Response: bundle})
+	if err != nil {
+		api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, errors.New("marshalling cache config: "+err.Error()))
+		return
+	}
+
+	// The bundle's ETag, and its conditional requests, are handled by the
+	// routing middleware like any other response's.
+	w.Header().Set(rfc.CacheControl, "private, no-cache")
+	w.Header().Set(rfc.ContentType, rfc.ApplicationJSON)
+	api.WriteAndLogErr(w, r, append(body, '\n'))
+}
+
+// getServerProfileNames returns the names of the profiles of the server with
+// the given ID, in priority order.
+func getServerProfileNames(tx *sql.Tx, serverID int) ([]string, error) {
+	profiles := []string{}
+	if err := tx.QueryRow(`SELECT COALESCE(ARRAY_AGG(profile_name ORDER BY priority ASC), '{}') FROM server_profile WHERE server = $1`, serverID).Scan(pq.Array(&profiles)); err != nil {
+		return nil, errors.New("querying: " + err.Error())
+	}
+	return profiles, nil
+}
+
+// assemble makes the requests a cache server on the given CDN with the given
+// profiles makes to generate its configuration, and returns their successful
+// responses.
+//
+// Requests which fail are left out of the bundle, and the cache server will
+// make them itself, getting the same failure.
+func assemble(sub *subRequester, cdnName string, cdnID int, profiles []string) []tc.CacheConfigEntry {
+	cdnQry := url.Values{"cdn": {cdnName}}
+	dsReq := subRequest{Path: "deliveryservices", Query: url.Values{"cdn": {strconv.Itoa(cdnID)}}}
+	reqs := []subRequest{
+		{Path: "profiles/name/" + url.PathEscape(tc.GlobalProfileName) + "/parameters"},
+		{Path: "servers"},
+		{Path: "cachegroups"},
+		{Path: "topologies"},
+		{Path: "cdns", Query: url.Values{"name": {cdnName}}},
+		{Path: "cdns/name/" + url.PathEscape(cdnName) + "/sslkeys"},
+		dsReq,
+		{Path: "deliveryserviceserver", Query: url.Values{"cdn": {cdnName}, "limit": {"999999"}, "orderby": {""}}},
+		{Path: "deliveryservices/rewrite-rules", Query: cdnQry},
+		{Path: "deliveryservices/token-auth", Query: cdnQry},
+		{Path: "deliveryservices/log-shipping", Query: cdnQry},
+		{Path: "deliveryservices_regexes"},
+		{Path: "deliveryservices_required_capabilities"},
+		{Path: "server_server_capabilities"},
+		{Path: "jobs", Query: url.Values{"cdn": {cdnName}, "maxRevalDurationDays": {""}}},
+	}
+	for _, profile := range profiles {
+		reqs = append(reqs, subRequest{Path: "profiles/name/" + url.PathEscape(profile) + "/parameters"})
+	}
+	for _, configFile := range configFiles {
+		reqs = append(reqs, subRequest{Path: "parameters", Query: url.Values{"configFile": {configFile}}})
+	}
+
+	entries := sub.getAll(reqs)
+
+	// The signing keys needed depend on the Delivery Services, so they can
+	// only be requested once those are known.
+	dsPath := tc.CacheConfigPath(dsReq.Path, dsReq.Query)
+	for _, entry := range entries {
+		if entry.Path == dsPath {
+			entries = append(entries, sub.getAll(signingKeyRequests(entry.Body))...)
+			break
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+	return entries
+}
+
+// signingKeyRequests returns the requests for the URL signing and URI signing
+// keys of the Delivery Services in the given /deliveryservices response body.
+func signingKeyRequests(dsBody []byte) []subRequest {
+	dses := struct {
+		Response []struct {
+			XMLID            *string `json:"xmlId"`
+			SigningAlgorithm *string `json:"signingAlgorithm"`
+		} `json:"response"`
+	}{}
+	if err := json.Unmarshal(dsBody, &dses); err != nil {
+		log.Errorln("cache config: decoding delivery services: " + err.Error())
+		return nil
+	}
+	reqs := []subRequest{}
+	for _, ds := range dses.Response {
+		if ds.XMLID == nil || ds.SigningAlgorithm == nil {
+			continue
+		}
+		switch *ds.SigningAlgorithm {
+		case tc.SigningAlgorithmURLSig:
+			reqs = append(reqs, subRequest{Path: "deliveryservices/xmlId/" + url.PathEscape(*ds.XMLID) + "/urlkeys"})
+		case tc.SigningAlgorithmURISigning:
+			reqs = append(reqs, subRequest{Path: "deliveryservices/" + url.PathEscape(*ds.XMLID) + "/urisignkeys"})
+		}
+	}
+	return reqs
+}
+
+// subRequest is an API request made to assemble a bundle.
+type subRequest struct {
+	// Path is the escaped request path, relative to the API version.
+	Path  string
+	Query url.Values
+}
+
+// subRequester makes API requests to h on behalf of the client which made
+// the request r.
+type subRequester struct {
+	h http.Handler
+	r *http.Request
+	// prefix is the path of the API version of r, e.g. "/api/5.0/".
+	prefix string
+}
+
+// getAll makes the given requests concurrently, and returns the entries of
+// those which succeeded.
+func (s *subRequester) getAll(reqs []subRequest) []tc.CacheConfigEntry {
+	entries := []tc.CacheConfigEntry{}
+	m := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	sem := make(chan struct{}, maxConcurrentRequests)
+	for _, req := range reqs {
+		wg.Add(1)
+		go func(req subRequest) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			entry, ok := s.get(req)
+			if !ok {
+				return
+			}
+			m.Lock()
+			entries = append(entries, entry)
+			m.Unlock()
+		}(req)
+	}
+	wg.Wait()
+	return entries
+}
+
+// get makes the given request, returning its entry and whether it succeeded.
+func (s *subRequester) get(req subRequest) (tc.CacheConfigEntry, bool) {
+	path := tc.CacheConfigPath(req.Path, req.Query)
+	subReq, err := http.NewRequestWithContext(s.context(), http.MethodGet, s.prefix+path, nil)
+	if err != nil {
+		log.Errorf("cache config: creating request for '%s': %v", path, err)
+		return tc.CacheConfigEntry{}, false
+	}
+	subReq.RemoteAddr = s.r.RemoteAddr
+	subReq.Host = s.r.Host
+	for _, hdr := range []string{"Cookie", rfc.Authorization, rfc.UserAgent} {
+		if vals, ok := s.r.Header[hdr]; ok {
+			subReq.Header[hdr] = vals
+		}
+	}
+
+	resp := &responseBuffer{hdr: http.Header{}}
+	s.h.ServeHTTP(resp, subReq)
+	if resp.code != http.StatusOK {
+		log.Infof("cache config: request for '%s' returned %d, leaving it out", path, resp.code)
+		return tc.CacheConfigEntry{}, false
+	}
+	if !json.Valid(resp.body.Bytes()) {
+		log.Errorf("cache config: request for '%s' returned invalid JSON, leaving it out", path)
+		return tc.CacheConfigEntry{}, false
+	}
+	return tc.CacheConfigEntry{
+		Path:         path,
+		LastModified: resp.hdr.Get(rfc.LastModified),
+		Body:         json.RawMessage(bytes.TrimSpace(resp.body.Bytes())),
+	}, true
+}
+
+// context returns the context of the client's request, without the values
+// the Traffic Ops router stored in it, which must not leak into the
+// requests made on its behalf.
+func (s *subRequester) context() context.Context {
+	return requestContext{Context: context.Background(), parent: s.r.Context()}
+}
+
+// requestContext is a context with no values, which is cancelled with its
+// parent.
+type requestContext struct {
+	context.Context
+	parent context.Context
+}
+
+func (c requestContext) Deadline() (deadline time.Time, ok bool) { return c.parent.Deadline() }
+func (c requestContext) Done() <-chan struct{}                   { return c.parent.Done() }
+func (c requestContext) Err() error                              { return c.parent.Err() }
+
+// responseBuffer is an http.ResponseWriter which buffers the response.
+type responseBuffer struct {
+	hdr  http.Header
+	code int
+	body bytes.Buffer
+}
+
+func (b *responseBuffer) Header() http.Header {
+	return b.hdr
+}
+
+func (b *responseBuffer) WriteHeader(code int) {
+	if b.code == 0 {
+		b.code = code
+	}
+}
+
+func (b *responseBuffer) Write(p []byte) (int, error) {
+	if b.code == 0 {
+		b.code = http.StatusOK
+	}
+	return b.body.Write(p)
+}
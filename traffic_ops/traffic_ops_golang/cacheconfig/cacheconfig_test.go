@@ -0,0 +1,113 @@
+package cacheconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+)
+
+type ctxKey struct{}
+
+func TestAssemble(t *testing.T) {
+	m := sync.Mutex{}
+	requested := map[string]struct{}{}
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		m.Lock()
+		requested[r.URL.RequestURI()] = struct{}{}
+		m.Unlock()
+
+		if r.Header.Get("Cookie") != "mojolicious=foo" {
+			t.Errorf("expected request for '%s' to have the client's cookie, actual: '%s'", r.URL.RequestURI(), r.Header.Get("Cookie"))
+		}
+		if r.Context().Value(ctxKey{}) != nil {
+			t.Errorf("expected request for '%s' not to have the client's context values", r.URL.RequestURI())
+		}
+
+		switch r.URL.Path {
+		case "/api/4.1/deliveryservices":
+			w.Header().Set(rfc.LastModified, "Mon, 02 Jan 2006 15:04:05 GMT")
+			w.Write([]byte(`{"response": [
+				{"xmlId": "ds-sig", "signingAlgorithm": "url_sig"},
+				{"xmlId": "ds-uri", "signingAlgorithm": "uri_signing"},
+				{"xmlId": "ds-none", "signingAlgorithm": null}
+			]}` + "\n"))
+		case "/api/4.1/deliveryservices/ds-uri/urisignkeys":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"alerts": []}`))
+		case "/api/4.1/topologies":
+			w.Write([]byte(`not json`))
+		default:
+			w.Write([]byte(`{"response": []}`))
+		}
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/api/4.1/cache-config/edge", nil)
+	r = r.WithContext(context.WithValue(r.Context(), ctxKey{}, "foo"))
+	r.Header.Set("Cookie", "mojolicious=foo")
+	sub := &subRequester{h: h, r: r, prefix: "/api/4.1/"}
+
+	entries := assemble(sub, "cdn1", 1, []string{"EDGE1", "EDGE2"})
+
+	for _, uri := range []string{
+		"/api/4.1/profiles/name/GLOBAL/parameters",
+		"/api/4.1/profiles/name/EDGE1/parameters",
+		"/api/4.1/profiles/name/EDGE2/parameters",
+		"/api/4.1/deliveryservices?cdn=1",
+		"/api/4.1/deliveryserviceserver?cdn=cdn1&limit=999999&orderby=",
+		"/api/4.1/jobs?cdn=cdn1&maxRevalDurationDays=",
+		"/api/4.1/parameters?configFile=parent.config",
+		"/api/4.1/deliveryservices/xmlId/ds-sig/urlkeys",
+		"/api/4.1/deliveryservices/ds-uri/urisignkeys",
+	} {
+		if _, ok := requested[uri]; !ok {
+			t.Errorf("expected '%s' to be requested, actual: not requested", uri)
+		}
+	}
+
+	paths := map[string]string{}
+	for _, entry := range entries {
+		paths[entry.Path] = entry.LastModified
+	}
+	if len(entries) != len(requested)-2 {
+		t.Errorf("expected all successful responses except the 403 and invalid JSON to be bundled, actual: %d of %d", len(entries), len(requested))
+	}
+	if _, ok := paths["deliveryservices/ds-uri/urisignkeys"]; ok {
+		t.Error("expected the failed request not to be bundled")
+	}
+	if _, ok := paths["topologies"]; ok {
+		t.Error("expected the invalid JSON response not to be bundled")
+	}
+	if lm := paths["deliveryservices?cdn=1"]; lm != "Mon, 02 Jan 2006 15:04:05 GMT" {
+		t.Errorf("expected the bundled response to keep its Last-Modified header, actual: '%s'", lm)
+	}
+	if _, ok := paths["deliveryservices/xmlId/ds-sig/urlkeys"]; !ok {
+		t.Error("expected the URL signing keys to be bundled")
+	}
+	if !sort.SliceIsSorted(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path }) {
+		t.Error("expected the bundled responses to be sorted by path")
+	}
+}
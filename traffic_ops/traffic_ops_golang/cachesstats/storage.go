@@ -0,0 +1,226 @@
+package cachesstats
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/util/monitorhlp"
+)
+
+// atsStatPrefix is the prefix Traffic Monitor gives the names of the ATS
+// stats of cache servers in its CacheStats.
+const atsStatPrefix = "ats."
+
+// GetStorage is the handler for GET requests to /caches/storage, which returns
+// the cache storage utilization of each cache server, as polled by the
+// Traffic Monitors of its CDN.
+func GetStorage(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdn, filtered := inf.Params["cdn"]
+	if filtered {
+		if ok, err := dbhelpers.CDNExists(cdn, inf.Tx.Tx); err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("checking CDN existence: "+err.Error()))
+			return
+		} else if !ok {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no such CDN: "+cdn), nil)
+			return
+		}
+	}
+
+	api.RespWriter(w, r, inf.Tx.Tx)(getCachesStorage(inf.Tx.Tx, tc.CDNName(cdn), filtered))
+}
+
+func getCachesStorage(tx *sql.Tx, cdn tc.CDNName, filtered bool) ([]tc.CacheStorage, error) {
+	monitors, err := monitorhlp.GetURLs(tx)
+	if err != nil {
+		return nil, errors.New("getting monitors: " + err.Error())
+	}
+
+	client, err := monitorhlp.GetClient(tx)
+	if err != nil {
+		return nil, errors.New("getting monitor client: " + err.Error())
+	}
+
+	storage, err := getCacheStorageData(tx, cdn, filtered)
+	if err != nil {
+		return nil, errors.New("getting cache storage data: " + err.Error())
+	}
+
+	stats := make([]string, 0, len(storageStats))
+	for stat := range storageStats {
+		stats = append(stats, atsStatPrefix+stat)
+	}
+
+	for cdnName, monitorFQDNs := range monitors {
+		if filtered && cdnName != cdn {
+			continue
+		}
+		if len(monitorFQDNs) == 0 {
+			log.Warnln("getCachesStorage: cdn '" + string(cdnName) + "' has no online monitors, skipping!")
+			continue
+		}
+
+		success := false
+		errs := []error{}
+		for _, monitorFQDN := range monitorFQDNs {
+			crStates, err := monitorhlp.GetCRStates(monitorFQDN, client)
+			if err != nil {
+				errs = append(errs, errors.New("getting CRStates for CDN '"+string(cdnName)+"' monitor '"+monitorFQDN+"': "+err.Error()))
+				continue
+			}
+
+			cacheStats, _, err := monitorhlp.GetCacheStats(monitorFQDN, client, stats)
+			if err != nil {
+				legacyCacheStats, _, err := monitorhlp.GetLegacyCacheStats(monitorFQDN, client, stats)
+				if err != nil {
+					errs = append(errs, errors.New("getting CacheStats for CDN '"+string(cdnName)+"' monitor '"+monitorFQDN+"': "+err.Error()))
+					continue
+				}
+				cacheStats = monitorhlp.UpgradeLegacyStats(legacyCacheStats)
+			}
+
+			storage = addStorage(storage, cdnName, crStates, cacheStats)
+			success = true
+			break
+		}
+
+		if !success {
+			return nil, errors.New("getting cache stats from all monitors failed for cdn '" + string(cdnName) + "': " + util.JoinErrs(errs).Error())
+		}
+
+		// if we succeeded, log the monitor failures but don't return them
+		for _, err := range errs {
+			log.Errorln(err.Error())
+		}
+	}
+	return storage, nil
+}
+
+// storageStats maps the names of the ATS cache storage stats to the fields of
+// a tc.CacheStorage in which they are reported.
+var storageStats = map[string]func(*tc.CacheStorage) **uint64{
+	tc.ATSStatCacheRAMBytesUsed:   func(s *tc.CacheStorage) **uint64 { return &s.RAMBytesUsed },
+	tc.ATSStatCacheRAMBytesTotal:  func(s *tc.CacheStorage) **uint64 { return &s.RAMBytesTotal },
+	tc.ATSStatCacheDiskBytesUsed:  func(s *tc.CacheStorage) **uint64 { return &s.DiskBytesUsed },
+	tc.ATSStatCacheDiskBytesTotal: func(s *tc.CacheStorage) **uint64 { return &s.DiskBytesTotal },
+	tc.ATSStatCacheObjects:        func(s *tc.CacheStorage) **uint64 { return &s.Objects },
+	tc.ATSStatCacheObjectsTotal:   func(s *tc.CacheStorage) **uint64 { return &s.ObjectsTotal },
+	tc.ATSStatCacheWriteFailures:  func(s *tc.CacheStorage) **uint64 { return &s.WriteFailures },
+	tc.ATSStatCacheSpansFailing:   func(s *tc.CacheStorage) **uint64 { return &s.SpansFailing },
+	tc.ATSStatCacheSpansOffline:   func(s *tc.CacheStorage) **uint64 { return &s.SpansOffline },
+}
+
+// usedPercent returns the percentage of total that is used, or nil if either
+// is unknown or the total is zero.
+func usedPercent(used, total *uint64) *float64 {
+	if used == nil || total == nil || *total == 0 {
+		return nil
+	}
+	return util.FloatPtr(float64(*used) / float64(*total) * 100)
+}
+
+// addStorage sets the health and cache storage stats of the cache servers of
+// the given CDN from the given Traffic Monitor data.
+func addStorage(storage []tc.CacheStorage, cdn tc.CDNName, crStates tc.CRStates, stats tc.Stats) []tc.CacheStorage {
+	for i, cache := range storage {
+		if cache.CDN != string(cdn) {
+			continue
+		}
+		if crsCache, ok := crStates.Caches[tc.CacheName(cache.HostName)]; ok {
+			cache.Healthy = crsCache.IsAvailable
+		}
+		stat, ok := stats.Caches[cache.HostName]
+		if !ok {
+			storage[i] = cache
+			continue
+		}
+		for name, field := range storageStats {
+			vals, ok := stat.Stats[atsStatPrefix+name]
+			if !ok || len(vals) == 0 {
+				continue
+			}
+			val, ok := util.ToNumeric(vals[0].Val)
+			if !ok || val < 0 || val > math.MaxUint64 {
+				log.Warnf("stat '%s' of cache %s couldn't be converted into uint64: %v", name, cache.HostName, vals[0].Val)
+				continue
+			}
+			v := uint64(val)
+			*field(&cache) = &v
+		}
+		cache.RAMUsedPercent = usedPercent(cache.RAMBytesUsed, cache.RAMBytesTotal)
+		cache.DiskUsedPercent = usedPercent(cache.DiskBytesUsed, cache.DiskBytesTotal)
+		storage[i] = cache
+	}
+	return storage
+}
+
+// getCacheStorageData gets the cache servers from the servers table, of only
+// the given CDN if filtered. Note this only gets from the database, and thus
+// does not set the Healthy member or any stats.
+func getCacheStorageData(tx *sql.Tx, cdn tc.CDNName, filtered bool) ([]tc.CacheStorage, error) {
+	qry := `
+SELECT
+  s.host_name,
+  cg.name as cachegroup,
+  c.name as cdn,
+  p.name as profile,
+  st.name as status
+FROM
+  server s
+  JOIN cachegroup cg ON s.cachegroup = cg.id
+  JOIN cdn c ON s.cdn_id = c.id
+  JOIN profile p ON s.profile = p.id
+  JOIN status st ON s.status = st.id
+  JOIN type t ON s.type = t.id
+WHERE
+  (t.name LIKE '` + tc.CacheTypeEdge.String() + `%' OR t.name LIKE '` + tc.CacheTypeMid.String() + `%')
+  AND (NOT $1 OR c.name = $2)
+ORDER BY s.host_name
+`
+	rows, err := tx.Query(qry, filtered, cdn)
+	if err != nil {
+		return nil, errors.New("querying cache storage data: " + err.Error())
+	}
+	defer log.Close(rows, "closing cache storage data rows")
+	data := []tc.CacheStorage{}
+	for rows.Next() {
+		d := tc.CacheStorage{}
+		if err := rows.Scan(&d.HostName, &d.CacheGroup, &d.CDN, &d.Profile, &d.Status); err != nil {
+			return nil, errors.New("scanning cache storage data: " + err.Error())
+		}
+		data = append(data, d)
+	}
+	return data, rows.Err()
+}
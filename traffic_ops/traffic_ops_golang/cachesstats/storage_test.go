@@ -0,0 +1,93 @@
+package cachesstats
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestAddStorage(t *testing.T) {
+	storage := []tc.CacheStorage{
+		{HostName: "edge", CDN: "cdn1"},
+		{HostName: "mid", CDN: "cdn1"},
+		{HostName: "other", CDN: "cdn2"},
+	}
+	crStates := tc.CRStates{
+		Caches: map[tc.CacheName]tc.IsAvailable{
+			"edge":  {IsAvailable: true},
+			"mid":   {IsAvailable: true},
+			"other": {IsAvailable: true},
+		},
+	}
+	val := func(v string) []tc.ResultStatVal {
+		return []tc.ResultStatVal{{Time: time.Now(), Val: v}}
+	}
+	stats := tc.Stats{
+		Caches: map[string]tc.ServerStats{
+			"edge": {
+				Stats: map[string][]tc.ResultStatVal{
+					atsStatPrefix + tc.ATSStatCacheDiskBytesUsed:  val("2.1591297110528e+13"),
+					atsStatPrefix + tc.ATSStatCacheDiskBytesTotal: val("21655715577856"),
+					atsStatPrefix + tc.ATSStatCacheRAMBytesUsed:   val("50"),
+					atsStatPrefix + tc.ATSStatCacheRAMBytesTotal:  val("0"),
+					atsStatPrefix + tc.ATSStatCacheSpansFailing:   val("1"),
+					atsStatPrefix + tc.ATSStatCacheWriteFailures:  val("not a number"),
+				},
+			},
+			"other": {
+				Stats: map[string][]tc.ResultStatVal{
+					atsStatPrefix + tc.ATSStatCacheObjects: val("10"),
+				},
+			},
+		},
+	}
+
+	storage = addStorage(storage, "cdn1", crStates, stats)
+
+	edge := storage[0]
+	if !edge.Healthy {
+		t.Error("expected 'edge' to be healthy")
+	}
+	if edge.DiskBytesUsed == nil || *edge.DiskBytesUsed != 21591297110528 {
+		t.Errorf("expected 'edge' disk bytes used 21591297110528, got: %v", edge.DiskBytesUsed)
+	}
+	if edge.DiskUsedPercent == nil || *edge.DiskUsedPercent < 99.7 || *edge.DiskUsedPercent > 99.71 {
+		t.Errorf("expected 'edge' disk used percent about 99.7, got: %v", edge.DiskUsedPercent)
+	}
+	if edge.RAMBytesUsed == nil || *edge.RAMBytesUsed != 50 || edge.RAMUsedPercent != nil {
+		t.Errorf("expected 'edge' RAM bytes used 50 with no percentage of a zero total, got: %v %v", edge.RAMBytesUsed, edge.RAMUsedPercent)
+	}
+	if edge.SpansFailing == nil || *edge.SpansFailing != 1 {
+		t.Errorf("expected 'edge' to have 1 failing span, got: %v", edge.SpansFailing)
+	}
+	if edge.WriteFailures != nil || edge.Objects != nil {
+		t.Errorf("expected unparsable and unreported stats to be nil, got: %v %v", edge.WriteFailures, edge.Objects)
+	}
+
+	if mid := storage[1]; !mid.Healthy || mid.DiskBytesUsed != nil {
+		t.Errorf("expected 'mid' to be healthy with no stats, got: %+v", mid)
+	}
+	if other := storage[2]; other.Healthy || other.Objects != nil {
+		t.Errorf("expected 'other' of another CDN to be unchanged, got: %+v", other)
+	}
+}
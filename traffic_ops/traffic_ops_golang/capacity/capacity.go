@@ -0,0 +1,463 @@
+// Package capacity implements the /capacity Traffic Ops API endpoint, which
+// models the throughput and connection ceilings of cache servers and reports
+// their current utilization and headroom.
+package capacity
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/util/monitorhlp"
+
+	"github.com/lib/pq"
+)
+
+// The query parameters accepted by the /capacity endpoint.
+const (
+	CDNQueryParam        = "cdn"
+	CacheGroupQueryParam = "cachegroup"
+	DSQueryParam         = "ds"
+)
+
+// healthThresholdBandwidthParameterName is the Traffic Monitor health
+// threshold which reserves bandwidth on a cache server; it is subtracted from
+// the server's ceiling, as it is by the /cdns/capacity endpoint.
+const healthThresholdBandwidthParameterName = tc.ThresholdPrefix + "availableBandwidthInKbps"
+
+const selectServersQuery = `
+SELECT
+	s.host_name,
+	c.name AS cdn,
+	cg.name AS cachegroup,
+	st.name AS status,
+	(
+		SELECT SUM(i.max_bandwidth)
+		FROM interface i
+		WHERE i.server = s.id AND i.monitor
+	) AS max_bandwidth,
+	ARRAY(
+		SELECT sp.profile_name
+		FROM server_profile sp
+		WHERE sp.server = s.id
+		ORDER BY sp.priority
+	) AS profiles
+FROM server s
+JOIN type t ON t.id = s.type
+JOIN status st ON st.id = s.status
+JOIN cdn c ON c.id = s.cdn_id
+JOIN cachegroup cg ON cg.id = s.cachegroup
+WHERE t.name LIKE '` + tc.EdgeTypePrefix + `%'
+AND st.name = ANY($1::text[])
+AND ($2 = '' OR c.name = $2)
+AND ($3 = '' OR cg.name = $3)
+`
+
+const selectProfileParametersQuery = `
+SELECT pr.name, pa.name, pa.value
+FROM parameter pa
+JOIN profile_parameter pp ON pp.parameter = pa.id
+JOIN profile pr ON pr.id = pp.profile
+WHERE pr.name = ANY($1::text[])
+AND (
+	(pa.config_file = '` + tc.CapacityParameterConfigFile + `' AND pa.name = ANY($2::text[]))
+	OR (pa.config_file = 'rascal-config.txt' AND pa.name = '` + healthThresholdBandwidthParameterName + `')
+)
+`
+
+// server is the data about a single cache server from which its capacity is
+// modeled.
+type server struct {
+	HostName   string
+	CDN        tc.CDNName
+	CacheGroup string
+	Status     tc.CacheStatus
+	// MaxBandwidth is the sum of the max bandwidth of the server's monitored
+	// interfaces, in kbps; nil if none of them has one.
+	MaxBandwidth *int64
+	// Profiles are ordered by priority, highest first.
+	Profiles []string
+}
+
+// profileParams are the capacity Parameters of a single Profile. Nil values
+// are unset.
+type profileParams struct {
+	MaxKbps            *float64
+	MaxConnections     *int64
+	ThresholdBandwidth *float64
+}
+
+// cacheUsage is the current utilization of a single cache server, as reported
+// by Traffic Monitor.
+type cacheUsage struct {
+	Kbps        float64
+	Connections int64
+	Available   bool
+}
+
+// Get handles GET requests to /capacity.
+func Get(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	cdn := tc.CDNName(inf.Params[CDNQueryParam])
+	cacheGroup := inf.Params[CacheGroupQueryParam]
+	ds := tc.DeliveryServiceName(inf.Params[DSQueryParam])
+
+	if ds != "" {
+		dsID, dsCDN, ok, err := dbhelpers.GetDSIDAndCDNFromName(tx, string(ds))
+		if err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting Delivery Service '%s': %w", ds, err))
+			return
+		}
+		if !ok {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no such Delivery Service: %s", ds), nil)
+			return
+		}
+		if userErr, sysErr, errCode := tenant.CheckID(tx, inf.User, dsID); userErr != nil || sysErr != nil {
+			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+			return
+		}
+		if cdn != "" && cdn != dsCDN {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("Delivery Service '%s' is not in CDN '%s'", ds, cdn), nil)
+			return
+		}
+		cdn = dsCDN
+	}
+
+	if cdn != "" {
+		if _, ok, err := dbhelpers.GetCDNIDFromName(tx, cdn); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("checking existence of CDN '%s': %w", cdn, err))
+			return
+		} else if !ok {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no such CDN: %s", cdn), nil)
+			return
+		}
+	}
+
+	servers, err := getServers(tx, cdn, cacheGroup)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	params, err := getProfileParams(tx, servers)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	usage, dsServers, err := getUsage(tx, servers, ds)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadGateway, nil, fmt.Errorf("getting cache server usage from Traffic Monitor: %w", err))
+		return
+	}
+
+	if ds != "" {
+		filtered := make([]server, 0, len(servers))
+		for _, s := range servers {
+			if _, ok := dsServers[s.HostName]; ok {
+				filtered = append(filtered, s)
+			}
+		}
+		servers = filtered
+	}
+
+	api.WriteResp(w, r, computeCapacity(servers, params, usage))
+}
+
+func getServers(tx *sql.Tx, cdn tc.CDNName, cacheGroup string) ([]server, error) {
+	statuses := []string{string(tc.CacheStatusOnline), string(tc.CacheStatusReported), string(tc.CacheStatusAdminDown)}
+	rows, err := tx.Query(selectServersQuery, pq.Array(statuses), cdn, cacheGroup)
+	if err != nil {
+		return nil, fmt.Errorf("querying servers: %w", err)
+	}
+	defer log.Close(rows, "closing capacity server rows")
+
+	servers := []server{}
+	for rows.Next() {
+		s := server{}
+		maxBandwidth := sql.NullInt64{}
+		if err := rows.Scan(&s.HostName, &s.CDN, &s.CacheGroup, &s.Status, &maxBandwidth, pq.Array(&s.Profiles)); err != nil {
+			return nil, fmt.Errorf("scanning servers: %w", err)
+		}
+		if maxBandwidth.Valid {
+			s.MaxBandwidth = util.Int64Ptr(maxBandwidth.Int64)
+		}
+		servers = append(servers, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over servers: %w", err)
+	}
+	return servers, nil
+}
+
+func getProfileParams(tx *sql.Tx, servers []server) (map[string]profileParams, error) {
+	profileSet := map[string]struct{}{}
+	for _, s := range servers {
+		for _, p := range s.Profiles {
+			profileSet[p] = struct{}{}
+		}
+	}
+	profiles := make([]string, 0, len(profileSet))
+	for p := range profileSet {
+		profiles = append(profiles, p)
+	}
+
+	names := []string{tc.CapacityMaxKbpsParameterName, tc.CapacityMaxConnectionsParameterName}
+	rows, err := tx.Query(selectProfileParametersQuery, pq.Array(profiles), pq.Array(names))
+	if err != nil {
+		return nil, fmt.Errorf("querying capacity parameters: %w", err)
+	}
+	defer log.Close(rows, "closing capacity parameter rows")
+
+	params := map[string]profileParams{}
+	for rows.Next() {
+		profile := ""
+		name := ""
+		value := ""
+		if err := rows.Scan(&profile, &name, &value); err != nil {
+			return nil, fmt.Errorf("scanning capacity parameters: %w", err)
+		}
+		p := params[profile]
+		switch name {
+		case tc.CapacityMaxKbpsParameterName:
+			f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				log.Warnf("capacity: Profile '%s' Parameter '%s' value '%s' is not a number, ignoring", profile, name, value)
+				continue
+			}
+			p.MaxKbps = &f
+		case tc.CapacityMaxConnectionsParameterName:
+			i, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				log.Warnf("capacity: Profile '%s' Parameter '%s' value '%s' is not an integer, ignoring", profile, name, value)
+				continue
+			}
+			p.MaxConnections = &i
+		case healthThresholdBandwidthParameterName:
+			f, err := strconv.ParseFloat(strings.TrimPrefix(strings.TrimSpace(value), ">"), 64)
+			if err != nil {
+				log.Warnf("capacity: Profile '%s' Parameter '%s' value '%s' is not a number, ignoring", profile, name, value)
+				continue
+			}
+			p.ThresholdBandwidth = &f
+		}
+		params[profile] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over capacity parameters: %w", err)
+	}
+	return params, nil
+}
+
+// getUsage gets the current utilization of the given servers from the
+// Traffic Monitors of their CDNs. If ds is not empty, it also returns the set
+// of the host names of the cache servers assigned to it, according to the
+// monitors' CDN Snapshots.
+//
+// As with the other capacity endpoints, each CDN's monitors are tried in turn
+// until one returns all of the needed data.
+func getUsage(tx *sql.Tx, servers []server, ds tc.DeliveryServiceName) (map[string]cacheUsage, map[string]struct{}, error) {
+	usage := map[string]cacheUsage{}
+	dsServers := map[string]struct{}{}
+
+	cdns := map[tc.CDNName]struct{}{}
+	for _, s := range servers {
+		cdns[s.CDN] = struct{}{}
+	}
+	if len(cdns) == 0 {
+		return usage, dsServers, nil
+	}
+
+	monitors, err := monitorhlp.GetURLs(tx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting monitors: %w", err)
+	}
+	client, err := monitorhlp.GetClient(tx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting monitor client: %w", err)
+	}
+
+	statsToFetch := []string{tc.StatNameKBPS, tc.StatNameCurrentClientConnections}
+	for cdn := range cdns {
+		monitorFQDNs, ok := monitors[cdn]
+		if !ok {
+			log.Warnf("capacity: CDN '%s' has no online monitors, reporting no utilization", cdn)
+			continue
+		}
+		errs := []error{}
+		succeeded := false
+		for _, monitorFQDN := range monitorFQDNs {
+			crStates, err := monitorhlp.GetCRStates(monitorFQDN, client)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			cacheStats, _, err := monitorhlp.GetCacheStats(monitorFQDN, client, statsToFetch)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if ds != "" {
+				crConfig, err := monitorhlp.GetCRConfig(monitorFQDN, client)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				for name, cs := range crConfig.ContentServers {
+					if _, ok := cs.DeliveryServices[string(ds)]; ok {
+						dsServers[name] = struct{}{}
+					}
+				}
+			}
+			for name, stats := range cacheStats.Caches {
+				usage[name] = cacheUsage{
+					Kbps:        latestNumeric(stats.Stats[tc.StatNameKBPS]),
+					Connections: int64(latestNumeric(stats.Stats[tc.StatNameCurrentClientConnections])),
+					Available:   crStates.Caches[tc.CacheName(name)].IsAvailable,
+				}
+			}
+			for name, avail := range crStates.Caches {
+				if _, ok := usage[string(name)]; !ok {
+					usage[string(name)] = cacheUsage{Available: avail.IsAvailable}
+				}
+			}
+			succeeded = true
+			break
+		}
+		if !succeeded {
+			return nil, nil, fmt.Errorf("CDN '%s': %w", cdn, util.JoinErrs(errs))
+		}
+	}
+	return usage, dsServers, nil
+}
+
+func latestNumeric(vals []tc.ResultStatVal) float64 {
+	if len(vals) == 0 {
+		return 0
+	}
+	f, _ := util.ToNumeric(vals[0].Val)
+	return f
+}
+
+// modelServer returns the throughput ceiling of the given server, and its
+// connection ceiling if it has one. Each Parameter is taken from the highest
+// priority Profile which has it.
+func modelServer(s server, params map[string]profileParams) (float64, *int64) {
+	var maxKbps *float64
+	var maxConns *int64
+	var threshold *float64
+	for _, profile := range s.Profiles {
+		p := params[profile]
+		if maxKbps == nil {
+			maxKbps = p.MaxKbps
+		}
+		if maxConns == nil {
+			maxConns = p.MaxConnections
+		}
+		if threshold == nil {
+			threshold = p.ThresholdBandwidth
+		}
+	}
+
+	ceiling := float64(0)
+	if maxKbps != nil {
+		ceiling = *maxKbps
+	} else if s.MaxBandwidth != nil {
+		ceiling = float64(*s.MaxBandwidth)
+	}
+	if threshold != nil {
+		ceiling -= *threshold
+	}
+	if ceiling < 0 {
+		ceiling = 0
+	}
+	return ceiling, maxConns
+}
+
+func addServer(m tc.CapacityMetrics, s server, ceiling float64, maxConns *int64, u cacheUsage) tc.CapacityMetrics {
+	m.Servers++
+	m.MaxKbps += ceiling
+	m.UtilizedKbps += u.Kbps
+	m.Connections += u.Connections
+	if u.Available && s.Status != tc.CacheStatusAdminDown {
+		m.AvailableServers++
+		m.AvailableMaxKbps += ceiling
+	}
+	if maxConns != nil {
+		if m.MaxConnections == nil {
+			m.MaxConnections = util.Int64Ptr(0)
+		}
+		*m.MaxConnections += *maxConns
+		// ConnectionsPercent temporarily holds the connections of servers with a ceiling; see finishMetrics.
+		if m.ConnectionsPercent == nil {
+			m.ConnectionsPercent = util.FloatPtr(0)
+		}
+		*m.ConnectionsPercent += float64(u.Connections)
+	}
+	return m
+}
+
+func finishMetrics(m tc.CapacityMetrics) tc.CapacityMetrics {
+	m.HeadroomKbps = m.MaxKbps - m.UtilizedKbps
+	m.ProjectedHeadroomKbps = m.AvailableMaxKbps - m.UtilizedKbps
+	if m.MaxKbps > 0 {
+		m.UtilizedPercent = m.UtilizedKbps * 100 / m.MaxKbps
+	}
+	if m.ConnectionsPercent != nil {
+		if m.MaxConnections != nil && *m.MaxConnections > 0 {
+			*m.ConnectionsPercent = *m.ConnectionsPercent * 100 / float64(*m.MaxConnections)
+		} else {
+			m.ConnectionsPercent = nil
+		}
+	}
+	return m
+}
+
+// computeCapacity models the capacity of each of the given servers, and
+// aggregates them with their current usage in total and per-Cache Group.
+func computeCapacity(servers []server, params map[string]profileParams, usage map[string]cacheUsage) tc.Capacity {
+	c := tc.Capacity{CacheGroups: map[string]tc.CapacityMetrics{}}
+	for _, s := range servers {
+		ceiling, maxConns := modelServer(s, params)
+		u := usage[s.HostName]
+		c.Total = addServer(c.Total, s, ceiling, maxConns, u)
+		c.CacheGroups[s.CacheGroup] = addServer(c.CacheGroups[s.CacheGroup], s, ceiling, maxConns, u)
+	}
+	c.Total = finishMetrics(c.Total)
+	for name, m := range c.CacheGroups {
+		c.CacheGroups[name] = finishMetrics(m)
+	}
+	return c
+}
@@ -0,0 +1,114 @@
+package capacity
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestModelServer(t *testing.T) {
+	params := map[string]profileParams{
+		"high": {MaxKbps: util.FloatPtr(1000)},
+		"low":  {MaxKbps: util.FloatPtr(5000), MaxConnections: util.Int64Ptr(200), ThresholdBandwidth: util.FloatPtr(100)},
+	}
+
+	ceiling, conns := modelServer(server{Profiles: []string{"high", "low"}, MaxBandwidth: util.Int64Ptr(10000)}, params)
+	if ceiling != 900 {
+		t.Errorf("Expected the highest priority maxKbps less the threshold (900), got: %v", ceiling)
+	}
+	if conns == nil || *conns != 200 {
+		t.Errorf("Expected maxConnections to fall back to a lower priority Profile (200), got: %v", conns)
+	}
+
+	ceiling, conns = modelServer(server{Profiles: []string{"none"}, MaxBandwidth: util.Int64Ptr(10000)}, params)
+	if ceiling != 10000 {
+		t.Errorf("Expected the interface bandwidth to be used without a maxKbps Parameter (10000), got: %v", ceiling)
+	}
+	if conns != nil {
+		t.Errorf("Expected no connection ceiling, got: %d", *conns)
+	}
+
+	ceiling, _ = modelServer(server{Profiles: []string{"none"}}, params)
+	if ceiling != 0 {
+		t.Errorf("Expected no ceiling for a server without a maxKbps Parameter or interface bandwidth, got: %v", ceiling)
+	}
+}
+
+func TestComputeCapacity(t *testing.T) {
+	params := map[string]profileParams{
+		"edge": {MaxKbps: util.FloatPtr(1000), MaxConnections: util.Int64Ptr(100)},
+	}
+	servers := []server{
+		{HostName: "a", CacheGroup: "cg1", Status: tc.CacheStatusReported, Profiles: []string{"edge"}},
+		{HostName: "b", CacheGroup: "cg1", Status: tc.CacheStatusAdminDown, Profiles: []string{"edge"}},
+		{HostName: "c", CacheGroup: "cg2", Status: tc.CacheStatusOnline, MaxBandwidth: util.Int64Ptr(2000)},
+	}
+	usage := map[string]cacheUsage{
+		"a": {Kbps: 400, Connections: 50, Available: true},
+		"b": {Kbps: 100, Connections: 10, Available: true},
+		"c": {Kbps: 500, Connections: 70, Available: false},
+	}
+
+	c := computeCapacity(servers, params, usage)
+
+	total := c.Total
+	if total.Servers != 3 || total.AvailableServers != 1 {
+		t.Errorf("Expected 3 servers of which 1 is available, got: %d of which %d are available", total.Servers, total.AvailableServers)
+	}
+	if total.MaxKbps != 4000 || total.AvailableMaxKbps != 1000 {
+		t.Errorf("Expected max 4000kbps, available max 1000kbps; got: %v, %v", total.MaxKbps, total.AvailableMaxKbps)
+	}
+	if total.UtilizedKbps != 1000 || total.UtilizedPercent != 25 {
+		t.Errorf("Expected 1000kbps (25%%) utilized, got: %vkbps (%v%%)", total.UtilizedKbps, total.UtilizedPercent)
+	}
+	if total.HeadroomKbps != 3000 || total.ProjectedHeadroomKbps != 0 {
+		t.Errorf("Expected headroom 3000kbps, projected headroom 0kbps; got: %v, %v", total.HeadroomKbps, total.ProjectedHeadroomKbps)
+	}
+	if total.Connections != 130 {
+		t.Errorf("Expected 130 connections, got: %d", total.Connections)
+	}
+	if total.MaxConnections == nil || *total.MaxConnections != 200 {
+		t.Errorf("Expected max connections of 200, got: %v", total.MaxConnections)
+	}
+	if total.ConnectionsPercent == nil || *total.ConnectionsPercent != 30 {
+		t.Errorf("Expected connections percent of 30 (counting only servers with a ceiling), got: %v", total.ConnectionsPercent)
+	}
+
+	if len(c.CacheGroups) != 2 {
+		t.Fatalf("Expected 2 Cache Groups, got: %d", len(c.CacheGroups))
+	}
+	cg2 := c.CacheGroups["cg2"]
+	if cg2.MaxKbps != 2000 || cg2.ProjectedHeadroomKbps != -500 {
+		t.Errorf("Expected cg2 max 2000kbps and projected headroom -500kbps; got: %v, %v", cg2.MaxKbps, cg2.ProjectedHeadroomKbps)
+	}
+	if cg2.MaxConnections != nil || cg2.ConnectionsPercent != nil {
+		t.Errorf("Expected cg2 to have no connection ceiling, got: %v, %v", cg2.MaxConnections, cg2.ConnectionsPercent)
+	}
+}
+
+func TestComputeCapacityEmpty(t *testing.T) {
+	c := computeCapacity(nil, nil, nil)
+	if c.Total.Servers != 0 || c.Total.UtilizedPercent != 0 || c.CacheGroups == nil {
+		t.Errorf("Expected an empty, non-nil capacity, got: %+v", c)
+	}
+}
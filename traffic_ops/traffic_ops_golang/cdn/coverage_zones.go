@@ -0,0 +1,279 @@
+package cdn
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+
+	"github.com/lib/pq"
+)
+
+const selectCoverageZonesQuery = `
+SELECT z.file, z.last_updated
+FROM coverage_zone_file AS z
+JOIN cdn AS c ON c.id = z.cdn
+WHERE c.name = $1
+`
+
+const upsertCoverageZonesQuery = `
+INSERT INTO coverage_zone_file (cdn, file)
+VALUES ($1, $2)
+ON CONFLICT (cdn) DO UPDATE SET file = EXCLUDED.file
+RETURNING last_updated
+`
+
+const deleteCoverageZonesQuery = `
+DELETE FROM coverage_zone_file
+WHERE cdn = $1
+`
+
+// unknownCacheGroupsQuery returns those of the given names which aren't the
+// names of Cache Groups.
+const unknownCacheGroupsQuery = `
+SELECT n.name
+FROM UNNEST($1::text[]) AS n(name)
+WHERE NOT EXISTS (SELECT 1 FROM cachegroup AS cg WHERE cg.name = n.name)
+ORDER BY n.name
+`
+
+// getCoverageZones returns the Coverage Zone File of the CDN with the given
+// name, and whether it has one.
+func getCoverageZones(ctx context.Context, db interface {
+	QueryRowContext(context.Context, string, ...interface{}) *sql.Row
+}, cdnName string) (tc.CDNCoverageZones, bool, error) {
+	zones := tc.CDNCoverageZones{CDNName: cdnName}
+	var file []byte
+	var lastUpdated time.Time
+	if err := db.QueryRowContext(ctx, selectCoverageZonesQuery, cdnName).Scan(&file, &lastUpdated); err != nil {
+		if err == sql.ErrNoRows {
+			return zones, false, nil
+		}
+		return zones, false, fmt.Errorf("querying Coverage Zone File: %w", err)
+	}
+	if err := json.Unmarshal(file, &zones.File); err != nil {
+		return zones, false, fmt.Errorf("unmarshalling Coverage Zone File: %w", err)
+	}
+	zones.LastUpdated = &lastUpdated
+	return zones, true, nil
+}
+
+// GetCoverageZones is the handler for GET requests to
+// /cdns/{name}/coverage_zones, which returns the CDN's Coverage Zone File.
+func GetCoverageZones(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdnName := inf.Params["name"]
+	if _, ok, err := getCDNIDFromName(inf.Tx.Tx, tc.CDNName(cdnName)); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting cdn id: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no such CDN: "+cdnName), nil)
+		return
+	}
+
+	zones, ok, err := getCoverageZones(r.Context(), inf.Tx.Tx, cdnName)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("CDN "+cdnName+" has no Coverage Zone File"), nil)
+		return
+	}
+	api.WriteResp(w, r, zones)
+}
+
+// GetCoverageZoneFile is the handler for GET requests to
+// /cdns/{name}/coverage_zones/file, which returns the CDN's Coverage Zone File
+// itself, for Traffic Routers to poll. Like the files Traffic Ops used to
+// serve, it requires no authentication, and honors If-Modified-Since.
+func GetCoverageZoneFile(w http.ResponseWriter, r *http.Request) {
+	db, err := api.GetDB(r.Context())
+	if err != nil {
+		api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, errors.New("getting db from context: "+err.Error()))
+		return
+	}
+	cfg, err := api.GetConfig(r.Context())
+	if err != nil {
+		api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, errors.New("getting config from context: "+err.Error()))
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second)
+	defer cancel()
+
+	params, err := api.GetPathParams(r.Context())
+	if err != nil {
+		api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, errors.New("getting path parameters: "+err.Error()))
+		return
+	}
+	cdnName := params["name"]
+	zones, ok, err := getCoverageZones(ctx, db, cdnName)
+	if err != nil {
+		api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, nil, http.StatusNotFound, errors.New("CDN "+cdnName+" has no Coverage Zone File"), nil)
+		return
+	}
+
+	lastModified := zones.LastUpdated.Truncate(time.Second)
+	if since, ok := rfc.ParseHTTPDate(r.Header.Get(rfc.IfModifiedSince)); ok && !lastModified.After(since) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	file, err := json.Marshal(zones.File)
+	if err != nil {
+		api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, errors.New("marshalling Coverage Zone File: "+err.Error()))
+		return
+	}
+	w.Header().Set(rfc.ContentType, rfc.ApplicationJSON)
+	w.Header().Set(rfc.LastModified, lastModified.UTC().Format(http.TimeFormat))
+	api.WriteAndLogErr(w, r, file)
+}
+
+// UpdateCoverageZones is the handler for PUT requests to
+// /cdns/{name}/coverage_zones, which creates or replaces the CDN's Coverage
+// Zone File. The names of its zones must be the names of Cache Groups.
+func UpdateCoverageZones(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdnName := inf.Params["name"]
+	cdnID, ok, err := getCDNIDFromName(inf.Tx.Tx, tc.CDNName(cdnName))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting cdn id: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no such CDN: "+cdnName), nil)
+		return
+	}
+	userErr, sysErr, statusCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(inf.Tx.Tx, cdnName, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
+		return
+	}
+
+	var file tc.CoverageZoneFile
+	if err := json.NewDecoder(r.Body).Decode(&file); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("parsing Coverage Zone File: "+err.Error()), nil)
+		return
+	}
+	if err := file.Validate(); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	var unknown []string
+	if err := inf.Tx.Tx.QueryRow(`SELECT ARRAY(`+unknownCacheGroupsQuery+`)`, pq.Array(file.ZoneNames())).Scan(pq.Array(&unknown)); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("checking coverage zone Cache Groups: "+err.Error()))
+		return
+	}
+	if len(unknown) > 0 {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("coverageZones: no such Cache Groups: "+strings.Join(unknown, ", ")), nil)
+		return
+	}
+
+	current, _, err := getCoverageZones(r.Context(), inf.Tx.Tx, cdnName)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	fileBytes, err := json.Marshal(file)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("marshalling Coverage Zone File: "+err.Error()))
+		return
+	}
+	zones := tc.CDNCoverageZones{CDNName: cdnName, File: file, LastUpdated: new(time.Time)}
+	if err := inf.Tx.Tx.QueryRow(upsertCoverageZonesQuery, cdnID, fileBytes).Scan(zones.LastUpdated); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+
+	added, removed := 0, 0
+	for _, diff := range tc.DiffCoverageZoneFiles(current.File, file) {
+		added += len(diff.AddedNetworks)
+		removed += len(diff.RemovedNetworks)
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+cdnName+", ID: "+strconv.Itoa(cdnID)+", ACTION: Set Coverage Zone File with "+strconv.Itoa(len(file.CoverageZones))+" zones, adding "+strconv.Itoa(added)+" and removing "+strconv.Itoa(removed)+" networks", inf.User, inf.Tx.Tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Coverage Zone File for CDN "+cdnName+" was updated", zones)
+}
+
+// DeleteCoverageZones is the handler for DELETE requests to
+// /cdns/{name}/coverage_zones, which removes the CDN's Coverage Zone File.
+func DeleteCoverageZones(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdnName := inf.Params["name"]
+	cdnID, ok, err := getCDNIDFromName(inf.Tx.Tx, tc.CDNName(cdnName))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting cdn id: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no such CDN: "+cdnName), nil)
+		return
+	}
+	userErr, sysErr, statusCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(inf.Tx.Tx, cdnName, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
+		return
+	}
+
+	result, err := inf.Tx.Tx.Exec(deleteCoverageZonesQuery, cdnID)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deleting Coverage Zone File: "+err.Error()))
+		return
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting rows affected deleting Coverage Zone File: "+err.Error()))
+		return
+	} else if rows == 0 {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("CDN "+cdnName+" has no Coverage Zone File"), nil)
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+cdnName+", ID: "+strconv.Itoa(cdnID)+", ACTION: Deleted Coverage Zone File", inf.User, inf.Tx.Tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, "Coverage Zone File for CDN "+cdnName+" was deleted")
+}
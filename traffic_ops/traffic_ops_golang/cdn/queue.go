@@ -20,6 +20,7 @@ package cdn
  */
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -137,13 +138,45 @@ SET config_update_time = config_apply_time`
 		query = query + where
 	}
 
+	changeLogMsg := func(rowsAffected int64) string {
+		return "CDN: " + string(cdnName) + ", ID: " + strconv.Itoa(inf.IntParams["id"]) + str + ", ACTION: server updates " + reqObj.Action + "d on " + strconv.Itoa(int(rowsAffected)) + " servers"
+	}
+
+	async, err := api.IsAsyncRequested(inf)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	if async {
+		user := *inf.User
+		action := reqObj.Action
+		api.StartAsyncJob(w, r, inf, "Server update "+action+" job for CDN '"+string(cdnName)+"' has started.", func(ctx context.Context, db *sqlx.DB, jobID int) (string, error) {
+			failed := "Server update " + action + " job for CDN '" + string(cdnName) + "' failed."
+			tx, err := db.BeginTxx(ctx, nil)
+			if err != nil {
+				return failed, fmt.Errorf("beginning transaction: %w", err)
+			}
+			rowsAffected, err := queueUpdates(tx, query, queryValues)
+			if err != nil {
+				tx.Rollback()
+				return failed, fmt.Errorf("queueing updates: %w", err)
+			}
+			api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg(rowsAffected), &user, tx.Tx)
+			if err := tx.Commit(); err != nil {
+				return failed, fmt.Errorf("committing transaction: %w", err)
+			}
+			return fmt.Sprintf("Server updates %sd on %d servers of CDN '%s'.", action, rowsAffected, cdnName), nil
+		})
+		return
+	}
+
 	rowsAffected, err := queueUpdates(inf.Tx, query, queryValues)
 	if err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, fmt.Errorf("queueing updates: %v", err))
 		return
 	}
 
-	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+string(cdnName)+", ID: "+strconv.Itoa(inf.IntParams["id"])+str+", ACTION: server updates "+reqObj.Action+"d on "+strconv.Itoa(int(rowsAffected))+" servers", inf.User, inf.Tx.Tx)
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg(rowsAffected), inf.User, inf.Tx.Tx)
 	api.WriteResp(w, r, tc.CDNQueueUpdateResponse{Action: reqObj.Action, CDNID: int64(inf.IntParams["id"])})
 }
 
@@ -0,0 +1,177 @@
+package cdn
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+)
+
+const selectSnapshotPolicyQuery = `
+SELECT p."interval", p.quiet_period, p.max_changes, p.notify, u.username, p.last_auto_snapshot, p.last_aborted, p.last_updated
+FROM cdn_snapshot_policy AS p
+LEFT JOIN tm_user AS u ON u.id = p.tm_user
+WHERE p.cdn = $1
+`
+
+const upsertSnapshotPolicyQuery = `
+INSERT INTO cdn_snapshot_policy (cdn, "interval", quiet_period, max_changes, notify, tm_user)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (cdn) DO UPDATE SET
+"interval" = EXCLUDED."interval",
+quiet_period = EXCLUDED.quiet_period,
+max_changes = EXCLUDED.max_changes,
+notify = EXCLUDED.notify,
+tm_user = EXCLUDED.tm_user
+RETURNING last_auto_snapshot, last_aborted, last_updated
+`
+
+const deleteSnapshotPolicyQuery = `
+DELETE FROM cdn_snapshot_policy
+WHERE cdn = $1
+`
+
+// GetSnapshotPolicy is the handler for GET requests to
+// /cdns/{name}/snapshot_policy, which returns the CDN's policy for taking
+// snapshots automatically.
+func GetSnapshotPolicy(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdnName := inf.Params["name"]
+	cdnID, ok, err := getCDNIDFromName(inf.Tx.Tx, tc.CDNName(cdnName))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting cdn id: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no such CDN: "+cdnName), nil)
+		return
+	}
+
+	policy := tc.CDNSnapshotPolicy{CDNName: cdnName}
+	err = inf.Tx.Tx.QueryRow(selectSnapshotPolicyQuery, cdnID).Scan(&policy.IntervalSeconds, &policy.QuietPeriodSeconds, &policy.MaxChanges, &policy.Notify, &policy.User, &policy.LastAutoSnapshot, &policy.LastAborted, &policy.LastUpdated)
+	if errors.Is(err, sql.ErrNoRows) {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("CDN "+cdnName+" has no snapshot policy"), nil)
+		return
+	}
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting snapshot policy: "+err.Error()))
+		return
+	}
+	api.WriteResp(w, r, policy)
+}
+
+// UpdateSnapshotPolicy is the handler for PUT requests to
+// /cdns/{name}/snapshot_policy, which creates or replaces the CDN's policy
+// for taking snapshots automatically. Snapshots will be taken on behalf of
+// the user making the request.
+func UpdateSnapshotPolicy(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdnName := inf.Params["name"]
+	cdnID, ok, err := getCDNIDFromName(inf.Tx.Tx, tc.CDNName(cdnName))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting cdn id: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no such CDN: "+cdnName), nil)
+		return
+	}
+	userErr, sysErr, statusCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(inf.Tx.Tx, cdnName, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
+		return
+	}
+
+	var policy tc.CDNSnapshotPolicy
+	if err := api.Parse(r.Body, inf.Tx.Tx, &policy); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	policy.CDNName = cdnName
+	policy.User = &inf.User.UserName
+
+	if err := inf.Tx.Tx.QueryRow(upsertSnapshotPolicyQuery, cdnID, policy.IntervalSeconds, policy.QuietPeriodSeconds, policy.MaxChanges, policy.Notify, inf.User.ID).Scan(&policy.LastAutoSnapshot, &policy.LastAborted, &policy.LastUpdated); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+cdnName+", ID: "+strconv.Itoa(cdnID)+", ACTION: Set snapshot policy", inf.User, inf.Tx.Tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Snapshot policy for CDN "+cdnName+" was updated; snapshots will be taken on behalf of "+inf.User.UserName, policy)
+}
+
+// DeleteSnapshotPolicy is the handler for DELETE requests to
+// /cdns/{name}/snapshot_policy, which stops snapshots of the CDN from being
+// taken automatically.
+func DeleteSnapshotPolicy(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdnName := inf.Params["name"]
+	cdnID, ok, err := getCDNIDFromName(inf.Tx.Tx, tc.CDNName(cdnName))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting cdn id: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no such CDN: "+cdnName), nil)
+		return
+	}
+	userErr, sysErr, statusCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(inf.Tx.Tx, cdnName, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
+		return
+	}
+
+	result, err := inf.Tx.Tx.Exec(deleteSnapshotPolicyQuery, cdnID)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deleting snapshot policy: "+err.Error()))
+		return
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting rows affected deleting snapshot policy: "+err.Error()))
+		return
+	} else if rows == 0 {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("CDN "+cdnName+" has no snapshot policy"), nil)
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+cdnName+", ID: "+strconv.Itoa(cdnID)+", ACTION: Deleted snapshot policy", inf.User, inf.Tx.Tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, "Snapshot policy for CDN "+cdnName+" was deleted")
+}
@@ -0,0 +1,184 @@
+package cdn
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+
+	"github.com/lib/pq"
+)
+
+// upsertSOAQuery creates or replaces a CDN's SOA. The serial number is kept
+// when the SOA is replaced, so that changing other fields doesn't make it go
+// backwards.
+const upsertSOAQuery = `
+INSERT INTO cdn_soa (cdn, admin, serial_policy, refresh, retry, expire, negative_ttl, soa_ttl, ns_ttl, nameservers)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (cdn) DO UPDATE SET
+	admin = EXCLUDED.admin,
+	serial_policy = EXCLUDED.serial_policy,
+	refresh = EXCLUDED.refresh,
+	retry = EXCLUDED.retry,
+	expire = EXCLUDED.expire,
+	negative_ttl = EXCLUDED.negative_ttl,
+	soa_ttl = EXCLUDED.soa_ttl,
+	ns_ttl = EXCLUDED.ns_ttl,
+	nameservers = EXCLUDED.nameservers
+RETURNING serial, last_updated
+`
+
+const deleteSOAQuery = `
+DELETE FROM cdn_soa
+WHERE cdn = $1
+`
+
+// GetSOA is the handler for GET requests to /cdns/{name}/soa, which returns
+// the SOA of the CDN's zones.
+func GetSOA(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdnName := inf.Params["name"]
+	if _, ok, err := getCDNIDFromName(inf.Tx.Tx, tc.CDNName(cdnName)); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting cdn id: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no such CDN: "+cdnName), nil)
+		return
+	}
+
+	soa, ok, err := dbhelpers.GetCDNSOA(inf.Tx.Tx, tc.CDNName(cdnName))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("CDN "+cdnName+" has no SOA; its snapshots use its Parameters"), nil)
+		return
+	}
+	api.WriteResp(w, r, soa)
+}
+
+// UpdateSOA is the handler for PUT requests to /cdns/{name}/soa, which
+// creates or replaces the SOA of the CDN's zones. The change takes effect at
+// the CDN's next snapshot.
+func UpdateSOA(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdnName := inf.Params["name"]
+	cdnID, ok, err := getCDNIDFromName(inf.Tx.Tx, tc.CDNName(cdnName))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting cdn id: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no such CDN: "+cdnName), nil)
+		return
+	}
+	userErr, sysErr, statusCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(inf.Tx.Tx, cdnName, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
+		return
+	}
+
+	var soa tc.CDNSOA
+	if err := api.Parse(r.Body, inf.Tx.Tx, &soa); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	soa.CDNName = cdnName
+
+	err = inf.Tx.Tx.QueryRow(upsertSOAQuery,
+		cdnID,
+		soa.Admin,
+		soa.SerialPolicy,
+		soa.RefreshSeconds,
+		soa.RetrySeconds,
+		soa.ExpireSeconds,
+		soa.NegativeTTLSeconds,
+		soa.SOATTLSeconds,
+		soa.NSTTLSeconds,
+		pq.Array(soa.Nameservers),
+	).Scan(&soa.Serial, &soa.LastUpdated)
+	if err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+cdnName+", ID: "+strconv.Itoa(cdnID)+", ACTION: Set SOA with negative TTL "+strconv.FormatInt(soa.NegativeTTLSeconds, 10)+" seconds and serial policy "+string(soa.SerialPolicy), inf.User, inf.Tx.Tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "SOA for CDN "+cdnName+" was updated; it will take effect at the CDN's next snapshot", soa)
+}
+
+// DeleteSOA is the handler for DELETE requests to /cdns/{name}/soa, which
+// removes the SOA of the CDN's zones, so that its snapshots go back to using
+// its Parameters.
+func DeleteSOA(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdnName := inf.Params["name"]
+	cdnID, ok, err := getCDNIDFromName(inf.Tx.Tx, tc.CDNName(cdnName))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting cdn id: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no such CDN: "+cdnName), nil)
+		return
+	}
+	userErr, sysErr, statusCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(inf.Tx.Tx, cdnName, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
+		return
+	}
+
+	result, err := inf.Tx.Tx.Exec(deleteSOAQuery, cdnID)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deleting CDN SOA: "+err.Error()))
+		return
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting rows affected deleting CDN SOA: "+err.Error()))
+		return
+	} else if rows == 0 {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("CDN "+cdnName+" has no SOA"), nil)
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+cdnName+", ID: "+strconv.Itoa(cdnID)+", ACTION: Deleted SOA", inf.User, inf.Tx.Tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, "SOA for CDN "+cdnName+" was deleted; its snapshots will use its Parameters")
+}
@@ -0,0 +1,191 @@
+package cdn
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+)
+
+const selectStaticDNSTTLPolicyQuery = `
+SELECT min_ttl, max_ttl, last_updated
+FROM cdn_static_dns_ttl_policy
+WHERE cdn = $1
+`
+
+const upsertStaticDNSTTLPolicyQuery = `
+INSERT INTO cdn_static_dns_ttl_policy (cdn, min_ttl, max_ttl)
+VALUES ($1, $2, $3)
+ON CONFLICT (cdn) DO UPDATE SET min_ttl = EXCLUDED.min_ttl, max_ttl = EXCLUDED.max_ttl
+RETURNING last_updated
+`
+
+const deleteStaticDNSTTLPolicyQuery = `
+DELETE FROM cdn_static_dns_ttl_policy
+WHERE cdn = $1
+`
+
+// nonConformingStaticDNSEntriesQuery counts the Static DNS Entries of the
+// CDN's Delivery Services whose TTLs are outside the given bounds.
+const nonConformingStaticDNSEntriesQuery = `
+SELECT COUNT(*)
+FROM staticdnsentry AS sde
+JOIN deliveryservice AS ds ON ds.id = sde.deliveryservice
+WHERE ds.cdn_id = $1
+AND (sde.ttl < $2 OR sde.ttl > $3)
+`
+
+// GetStaticDNSEntryTTLPolicy is the handler for GET requests to
+// /cdns/{name}/static_dns_ttl_policy, which returns the bounds the CDN places
+// on the TTLs of its Static DNS Entries.
+func GetStaticDNSEntryTTLPolicy(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdnName := inf.Params["name"]
+	cdnID, ok, err := getCDNIDFromName(inf.Tx.Tx, tc.CDNName(cdnName))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting cdn id: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no such CDN: "+cdnName), nil)
+		return
+	}
+
+	policy := tc.StaticDNSEntryTTLPolicy{CDNName: cdnName}
+	err = inf.Tx.Tx.QueryRow(selectStaticDNSTTLPolicyQuery, cdnID).Scan(&policy.MinTTL, &policy.MaxTTL, &policy.LastUpdated)
+	if errors.Is(err, sql.ErrNoRows) {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("CDN "+cdnName+" has no static DNS entry TTL policy"), nil)
+		return
+	}
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting static DNS entry TTL policy: "+err.Error()))
+		return
+	}
+	api.WriteResp(w, r, policy)
+}
+
+// UpdateStaticDNSEntryTTLPolicy is the handler for PUT requests to
+// /cdns/{name}/static_dns_ttl_policy, which creates or replaces the bounds
+// the CDN places on the TTLs of its Static DNS Entries. Existing entries
+// aren't changed; the response warns of any that are outside the new bounds.
+func UpdateStaticDNSEntryTTLPolicy(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdnName := inf.Params["name"]
+	cdnID, ok, err := getCDNIDFromName(inf.Tx.Tx, tc.CDNName(cdnName))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting cdn id: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no such CDN: "+cdnName), nil)
+		return
+	}
+	userErr, sysErr, statusCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(inf.Tx.Tx, cdnName, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
+		return
+	}
+
+	var policy tc.StaticDNSEntryTTLPolicy
+	if err := api.Parse(r.Body, inf.Tx.Tx, &policy); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	policy.CDNName = cdnName
+
+	if err := inf.Tx.Tx.QueryRow(upsertStaticDNSTTLPolicyQuery, cdnID, policy.MinTTL, policy.MaxTTL).Scan(&policy.LastUpdated); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var nonConforming int
+	if err := inf.Tx.Tx.QueryRow(nonConformingStaticDNSEntriesQuery, cdnID, policy.MinTTL, policy.MaxTTL).Scan(&nonConforming); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("counting static DNS entries outside the TTL policy: "+err.Error()))
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+cdnName+", ID: "+strconv.Itoa(cdnID)+", ACTION: Set static DNS entry TTL policy to "+policy.BoundsString(), inf.User, inf.Tx.Tx)
+	alerts := tc.CreateAlerts(tc.SuccessLevel, "Static DNS entry TTL policy for CDN "+cdnName+" was updated")
+	if nonConforming > 0 {
+		alerts.AddNewAlert(tc.WarnLevel, fmt.Sprintf("%d existing static DNS entries of CDN %s have TTLs outside the policy; they are unchanged, but can't be updated without correcting their TTLs", nonConforming, cdnName))
+	}
+	api.WriteAlertsObj(w, r, http.StatusOK, alerts, policy)
+}
+
+// DeleteStaticDNSEntryTTLPolicy is the handler for DELETE requests to
+// /cdns/{name}/static_dns_ttl_policy, which removes the bounds the CDN places
+// on the TTLs of its Static DNS Entries.
+func DeleteStaticDNSEntryTTLPolicy(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdnName := inf.Params["name"]
+	cdnID, ok, err := getCDNIDFromName(inf.Tx.Tx, tc.CDNName(cdnName))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting cdn id: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no such CDN: "+cdnName), nil)
+		return
+	}
+	userErr, sysErr, statusCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(inf.Tx.Tx, cdnName, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
+		return
+	}
+
+	result, err := inf.Tx.Tx.Exec(deleteStaticDNSTTLPolicyQuery, cdnID)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deleting static DNS entry TTL policy: "+err.Error()))
+		return
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting rows affected deleting static DNS entry TTL policy: "+err.Error()))
+		return
+	} else if rows == 0 {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("CDN "+cdnName+" has no static DNS entry TTL policy"), nil)
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+cdnName+", ID: "+strconv.Itoa(cdnID)+", ACTION: Deleted static DNS entry TTL policy", inf.User, inf.Tx.Tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, "Static DNS entry TTL policy for CDN "+cdnName+" was deleted")
+}
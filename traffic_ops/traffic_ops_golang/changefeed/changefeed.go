@@ -0,0 +1,282 @@
+// Package changefeed implements the /changes/stream Traffic Ops API endpoint,
+// also served as /changefeed, which streams lightweight events describing the
+// changes made to objects in Traffic Ops, so that clients can keep their
+// copies of those objects current without polling for them.
+package changefeed
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// LastEventIDHeader is the header with which Server-Sent Events clients
+// give the ID of the last event they received when they reconnect.
+const LastEventIDHeader = "Last-Event-ID"
+
+// ContentTypeEventStream is the media type of Server-Sent Events streams.
+const ContentTypeEventStream = "text/event-stream"
+
+// keepAliveInterval is how often a comment is sent on an idle stream, so
+// that proxies don't close it.
+const keepAliveInterval = 15 * time.Second
+
+// batchSize is the number of change events read from the database at once.
+const batchSize = 500
+
+// maxStreamDuration is the longest a stream is kept open. Clients are
+// expected to reconnect when a stream ends, resuming it from the last event
+// they received.
+const maxStreamDuration = time.Hour
+
+// streamEndMargin is how long before the server's write timeout a stream is
+// ended, so that it ends cleanly.
+const streamEndMargin = 5 * time.Second
+
+const boundsQuery = `
+SELECT
+	COALESCE(MAX("sequence"), 0),
+	COALESCE(MIN("sequence"), 0)
+FROM change_event
+WHERE "sequence" IS NOT NULL
+`
+
+const readQuery = `
+SELECT
+	"sequence",
+	object_type,
+	object_id,
+	"action",
+	cdn,
+	changed_by,
+	occurred
+FROM change_event
+WHERE "sequence" > $1
+AND (CARDINALITY($2::text[]) = 0 OR object_type = ANY($2::text[]))
+ORDER BY "sequence"
+LIMIT $3
+`
+
+// Get is the handler for GET requests to /changes/stream and /changefeed. It
+// streams the change events following the one given by the Last-Event-ID
+// header or the "since" query parameter - or, if neither is given, those made
+// after the request - until the client disconnects or the stream times out.
+func Get(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("response writer does not support streaming"))
+		return
+	}
+	since, err := parseSince(inf.Params, r.Header)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	types := parseTypes(inf.Params)
+
+	var head, oldest int64
+	if err := inf.Tx.QueryRow(boundsQuery).Scan(&head, &oldest); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("querying change event sequence numbers: "+err.Error()))
+		return
+	}
+	reset := since != nil && isExpired(*since, head, oldest)
+	fromHead := since == nil
+	if fromHead || reset {
+		since = &head
+	}
+	db, err := api.GetDB(r.Context())
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting database: "+err.Error()))
+		return
+	}
+	showChangedBy := !inf.Config.RoleBasedPermissions || inf.User.Can("LOG:READ")
+	queryTimeout := time.Duration(inf.Config.DBQueryTimeoutSeconds) * time.Second
+	duration := streamDuration(inf.Config.WriteTimeout)
+	// The request's transaction mustn't be held for the life of the stream.
+	inf.Close()
+
+	w.Header().Set(rfc.ContentType, ContentTypeEventStream)
+	w.Header().Set(rfc.CacheControl, "no-cache")
+	w.WriteHeader(http.StatusOK)
+	// Tells EventSource clients how many milliseconds to wait before
+	// reconnecting when the stream ends.
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", pollInterval/time.Millisecond); err != nil {
+		return
+	}
+	if reset {
+		if err := writeEvent(w, tc.ChangeFeedEventReset, head, tc.ChangeFeedReset{Sequence: head}); err != nil {
+			return
+		}
+	} else if fromHead {
+		// An event without data isn't dispatched to clients, but it gives
+		// them the sequence number from which to resume the stream, even if
+		// it ends before there are any changes.
+		if _, err := fmt.Fprintf(w, "id: %d\n\n", head); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	updates, unsubscribe := getFeed(db).subscribe()
+	defer unsubscribe()
+	end := time.NewTimer(duration)
+	defer end.Stop()
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	last := *since
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-end.C:
+			return
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case <-updates:
+			ctx, cancel := context.WithTimeout(r.Context(), queryTimeout)
+			last, err = sendEvents(ctx, db, w, last, types, showChangedBy)
+			cancel()
+			if err != nil {
+				if r.Context().Err() == nil {
+					log.Errorln("sending change events: " + err.Error())
+				}
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// parseSince returns the sequence number of the last change event the client
+// received, if given, preferring the Last-Event-ID header sent by clients
+// reconnecting to a stream to the "since" query parameter of the original
+// request.
+func parseSince(params map[string]string, h http.Header) (*int64, error) {
+	name := LastEventIDHeader
+	str := h.Get(LastEventIDHeader)
+	if str == "" {
+		name = tc.ChangeFeedSinceQueryParam
+		str = params[tc.ChangeFeedSinceQueryParam]
+	}
+	if str == "" {
+		return nil, nil
+	}
+	since, err := strconv.ParseInt(str, 10, 64)
+	if err != nil || since < 0 {
+		return nil, fmt.Errorf("%s must be a non-negative integer", name)
+	}
+	return &since, nil
+}
+
+// parseTypes returns the object types to which the client restricted the
+// feed, or an empty slice if it wasn't restricted.
+func parseTypes(params map[string]string) []string {
+	types := []string{}
+	for _, t := range strings.Split(params[tc.ChangeFeedTypeQueryParam], ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// isExpired returns whether some of the change events after the given
+// sequence number are no longer retained, given the latest and oldest
+// retained sequence numbers (both 0 if there are none), so that the client
+// can't be sent all of the changes it missed.
+func isExpired(since, head, oldest int64) bool {
+	return since > head || (oldest > 0 && since < oldest-1)
+}
+
+// streamDuration returns how long a stream can be kept open, given the
+// server's write timeout in seconds.
+func streamDuration(writeTimeout int) time.Duration {
+	d := maxStreamDuration
+	if writeTimeout > 0 {
+		if limit := time.Duration(writeTimeout)*time.Second - streamEndMargin; limit < d {
+			d = limit
+		}
+	}
+	if d < time.Second {
+		d = time.Second
+	}
+	return d
+}
+
+// sendEvents writes the change events following the one with the given
+// sequence number to w, and returns the sequence number of the last one
+// written.
+func sendEvents(ctx context.Context, db *sqlx.DB, w io.Writer, since int64, types []string, showChangedBy bool) (int64, error) {
+	for {
+		events := []tc.ChangeEvent{}
+		if err := db.SelectContext(ctx, &events, readQuery, since, pq.Array(types), batchSize); err != nil {
+			return since, errors.New("querying change events: " + err.Error())
+		}
+		for _, e := range events {
+			if !showChangedBy {
+				e.ChangedBy = nil
+			}
+			if err := writeEvent(w, tc.ChangeFeedEventChange, e.Sequence, e); err != nil {
+				return since, errors.New("writing change event: " + err.Error())
+			}
+			since = e.Sequence
+		}
+		if len(events) < batchSize {
+			return since, nil
+		}
+	}
+}
+
+// writeEvent writes a Server-Sent Event with the given name, ID, and
+// JSON-encoded data to w.
+func writeEvent(w io.Writer, name string, id int64, data interface{}) error {
+	bts, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, name, bts)
+	return err
+}
@@ -0,0 +1,215 @@
+package changefeed
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestParseSince(t *testing.T) {
+	since, err := parseSince(map[string]string{}, http.Header{})
+	if err != nil || since != nil {
+		t.Errorf("expected no sequence number when none is given, got %v, %v", since, err)
+	}
+
+	since, err = parseSince(map[string]string{tc.ChangeFeedSinceQueryParam: "10"}, http.Header{})
+	if err != nil || since == nil || *since != 10 {
+		t.Errorf("expected sequence number 10 from the query parameter, got %v, %v", since, err)
+	}
+
+	h := http.Header{}
+	h.Set(LastEventIDHeader, "12")
+	since, err = parseSince(map[string]string{tc.ChangeFeedSinceQueryParam: "10"}, h)
+	if err != nil || since == nil || *since != 12 {
+		t.Errorf("expected the Last-Event-ID header to take precedence, got %v, %v", since, err)
+	}
+
+	for _, invalid := range []string{"-1", "ten", "1.5"} {
+		if _, err := parseSince(map[string]string{tc.ChangeFeedSinceQueryParam: invalid}, http.Header{}); err == nil {
+			t.Errorf("expected an error for sequence number '%s'", invalid)
+		}
+	}
+}
+
+func TestParseTypes(t *testing.T) {
+	if types := parseTypes(map[string]string{}); len(types) != 0 {
+		t.Errorf("expected no types when none are given, got %v", types)
+	}
+	types := parseTypes(map[string]string{tc.ChangeFeedTypeQueryParam: "server, deliveryservice,,"})
+	if expected := []string{"server", "deliveryservice"}; !reflect.DeepEqual(types, expected) {
+		t.Errorf("expected types %v, got %v", expected, types)
+	}
+}
+
+func TestIsExpired(t *testing.T) {
+	tests := []struct {
+		since, head, oldest int64
+		expired             bool
+	}{
+		{since: 0, head: 0, oldest: 0, expired: false},
+		{since: 5, head: 10, oldest: 1, expired: false},
+		{since: 10, head: 10, oldest: 1, expired: false},
+		{since: 4, head: 10, oldest: 5, expired: false},
+		{since: 3, head: 10, oldest: 5, expired: true},
+		{since: 11, head: 10, oldest: 1, expired: true},
+		{since: 3, head: 0, oldest: 0, expired: true},
+	}
+	for _, test := range tests {
+		if expired := isExpired(test.since, test.head, test.oldest); expired != test.expired {
+			t.Errorf("isExpired(%d, %d, %d): expected %t, got %t", test.since, test.head, test.oldest, test.expired, expired)
+		}
+	}
+}
+
+func TestStreamDuration(t *testing.T) {
+	if d := streamDuration(0); d != maxStreamDuration {
+		t.Errorf("expected %v without a write timeout, got %v", maxStreamDuration, d)
+	}
+	if d := streamDuration(60); d != 60*time.Second-streamEndMargin {
+		t.Errorf("expected the stream to end before the write timeout, got %v", d)
+	}
+	if d := streamDuration(1); d != time.Second {
+		t.Errorf("expected a minimum duration of one second, got %v", d)
+	}
+}
+
+func TestSendEvents(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	occurred := time.Date(2022, 5, 10, 12, 0, 0, 0, time.UTC)
+	cols := []string{"sequence", "object_type", "object_id", "action", "changed_by", "occurred"}
+	rows := sqlmock.NewRows(cols).
+		AddRow(4, "server", "1", tc.ChangeActionUpdate, "admin", occurred).
+		AddRow(6, "server", "2", tc.ChangeActionDelete, nil, occurred)
+	mock.ExpectQuery("SELECT").WithArgs(3, sqlmock.AnyArg(), batchSize).WillReturnRows(rows)
+
+	buf := bytes.Buffer{}
+	last, err := sendEvents(context.Background(), db, &buf, 3, []string{"server"}, false)
+	if err != nil {
+		t.Fatalf("unexpected error sending events: %v", err)
+	}
+	if last != 6 {
+		t.Errorf("expected the last sequence number sent to be 6, got %d", last)
+	}
+	expected := `id: 4
+event: change
+data: {"sequence":4,"type":"server","id":"1","action":"update","changedBy":null,"time":"2022-05-10T12:00:00Z"}
+
+id: 6
+event: change
+data: {"sequence":6,"type":"server","id":"2","action":"delete","changedBy":null,"time":"2022-05-10T12:00:00Z"}
+
+`
+	if buf.String() != expected {
+		t.Errorf("expected events:\n%s\ngot:\n%s", expected, buf.String())
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
+
+func TestPublish(t *testing.T) {
+	for _, locked := range []bool{true, false} {
+		mockDB, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		db := sqlx.NewDb(mockDB, "sqlmock")
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("pg_try_advisory_xact_lock").WithArgs(publishLockID).WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(locked))
+		if locked {
+			mock.ExpectExec("UPDATE change_event").WillReturnResult(sqlmock.NewResult(0, 2))
+			mock.ExpectExec("pg_notify").WithArgs(notifyChannel, publishedPayload).WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectExec("DELETE FROM change_event").WillReturnResult(sqlmock.NewResult(0, 0))
+		}
+		mock.ExpectQuery("MAX").WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(7))
+		mock.ExpectCommit()
+
+		head, err := publish(db)
+		if err != nil {
+			t.Errorf("unexpected error publishing events (locked: %t): %v", locked, err)
+		}
+		if head != 7 {
+			t.Errorf("expected latest sequence number 7 (locked: %t), got %d", locked, head)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("expectations were not met (locked: %t): %v", locked, err)
+		}
+		mockDB.Close()
+	}
+}
+
+func TestFeedSubscribe(t *testing.T) {
+	f := &feed{subscribers: map[chan struct{}]struct{}{}}
+	// Subscribing would otherwise start polling the (nil) database.
+	f.subscribers[make(chan struct{}, 1)] = struct{}{}
+
+	updates, unsubscribe := f.subscribe()
+	select {
+	case <-updates:
+	default:
+		t.Fatal("expected subscribers to be notified initially")
+	}
+
+	f.notify()
+	f.notify()
+	select {
+	case <-updates:
+	default:
+		t.Fatal("expected subscribers to be notified")
+	}
+	select {
+	case <-updates:
+		t.Error("expected notifications not to queue up")
+	default:
+	}
+
+	unsubscribe()
+	if len(f.subscribers) != 1 {
+		t.Errorf("expected unsubscribing to remove the subscriber, got %d subscribers", len(f.subscribers))
+	}
+}
+
+func TestWriteEvent(t *testing.T) {
+	buf := strings.Builder{}
+	if err := writeEvent(&buf, tc.ChangeFeedEventReset, 9, tc.ChangeFeedReset{Sequence: 9}); err != nil {
+		t.Fatalf("unexpected error writing event: %v", err)
+	}
+	if expected := "id: 9\nevent: reset\ndata: {\"sequence\":9}\n\n"; buf.String() != expected {
+		t.Errorf("expected event %q, got %q", expected, buf.String())
+	}
+}
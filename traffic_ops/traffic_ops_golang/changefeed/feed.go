@@ -0,0 +1,274 @@
+package changefeed
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// publishLockID is the ID of the Postgres advisory lock held while change
+// events are published, so that only one Traffic Ops instance publishes
+// events at a time.
+const publishLockID = 5711036288
+
+// pollInterval is how often new change events are published and
+// subscribers are notified of them when the database can't be listened to
+// for notifications of them.
+const pollInterval = time.Second
+
+// listenPollInterval is how often new change events are published while the
+// database is listened to, in case a notification is lost.
+const listenPollInterval = 30 * time.Second
+
+// notifyChannel is the channel on which the database notifies listeners
+// that change events were recorded, or published, once the transactions
+// that did so commit.
+const notifyChannel = "change_event"
+
+// publishedPayload is the payload of the notifications sent when change
+// events are published, which tell the Traffic Ops instances that couldn't
+// publish them that they can notify their subscribers.
+const publishedPayload = "published"
+
+// listenConnStr is the connection string with which the database is listened
+// to for notifications of change events. If it's empty, the database is
+// polled for them instead.
+var listenConnStr string
+
+// Init sets the connection string with which the database is listened to for
+// notifications of change events, so that they're streamed as soon as
+// they're made rather than when the database is next polled.
+func Init(dbConnStr string) {
+	listenConnStr = dbConnStr
+}
+
+// retention is how long change events are kept after they're published.
+const retention = 7 * 24 * time.Hour
+
+// The change events recorded by the triggers on the tables of the objects
+// they're on are given a sequence number in the order in which they were
+// recorded, once the transactions that recorded them have committed; only
+// committed events are visible to the query. Because events are published
+// by only one transaction at a time, sequence numbers become visible in
+// ascending order, so that subscribers never miss an event by reading past
+// one with a lower sequence number that is yet to be published.
+const publishQuery = `
+UPDATE change_event ce
+SET "sequence" = p."sequence"
+FROM (
+	SELECT u.id, nextval('change_event_sequence') AS "sequence"
+	FROM (
+		SELECT id
+		FROM change_event
+		WHERE "sequence" IS NULL
+		ORDER BY id
+	) AS u
+) AS p
+WHERE ce.id = p.id
+`
+
+const pruneQuery = `
+DELETE FROM change_event
+WHERE "sequence" IS NOT NULL
+AND occurred < now() - $1 * interval '1 second'
+`
+
+const headQuery = `
+SELECT COALESCE(MAX("sequence"), 0)
+FROM change_event
+`
+
+// feed publishes change events periodically while it has subscribers, and
+// notifies them when there are new ones.
+type feed struct {
+	db          *sqlx.DB
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+	stop        chan struct{}
+}
+
+var (
+	theFeed     *feed
+	theFeedOnce sync.Once
+)
+
+// getFeed returns the change feed of the given database. Traffic Ops has only
+// one database, so the first one given is always used.
+func getFeed(db *sqlx.DB) *feed {
+	theFeedOnce.Do(func() {
+		theFeed = &feed{db: db, subscribers: map[chan struct{}]struct{}{}}
+	})
+	return theFeed
+}
+
+// Subscribe returns a channel on which a value is sent when there may be new
+// change events in the given database, and a function to call to
+// unsubscribe. Change events are published - given sequence numbers - only
+// while the feed has subscribers.
+func Subscribe(db *sqlx.DB) (<-chan struct{}, func()) {
+	return getFeed(db).subscribe()
+}
+
+// subscribe returns a channel on which a value is sent when there may be new
+// change events - initially, and at most once per poll after that - and a
+// function to call to unsubscribe.
+func (f *feed) subscribe() (<-chan struct{}, func()) {
+	c := make(chan struct{}, 1)
+	c <- struct{}{}
+
+	f.mu.Lock()
+	f.subscribers[c] = struct{}{}
+	if len(f.subscribers) == 1 {
+		f.stop = make(chan struct{})
+		go f.run(f.stop)
+	}
+	f.mu.Unlock()
+
+	return c, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.subscribers, c)
+		if len(f.subscribers) == 0 {
+			close(f.stop)
+		}
+	}
+}
+
+// run publishes change events, and notifies subscribers when there are new
+// ones, whenever the database notifies that change events were recorded or
+// published, and periodically in case a notification is lost - or only
+// periodically, more often, if the database can't be listened to.
+func (f *feed) run(stop <-chan struct{}) {
+	var notifications <-chan *pq.Notification
+	interval := pollInterval
+	if listenConnStr != "" {
+		listener := pq.NewListener(listenConnStr, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+			switch event {
+			case pq.ListenerEventDisconnected:
+				log.Errorln("change feed lost its connection to the database: " + err.Error())
+			case pq.ListenerEventConnectionAttemptFailed:
+				log.Errorln("change feed failed to connect to the database: " + err.Error())
+			}
+		})
+		if err := listener.Listen(notifyChannel); err != nil {
+			log.Errorln("listening for change events, polling for them instead: " + err.Error())
+			if err := listener.Close(); err != nil {
+				log.Errorln("closing change event listener: " + err.Error())
+			}
+		} else {
+			defer log.Close(listener, "closing change event listener")
+			notifications = listener.Notify
+			interval = listenPollInterval
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	head := int64(0)
+	for {
+		latest, err := publish(f.db)
+		if err != nil {
+			log.Errorln("publishing change events: " + err.Error())
+		} else if latest > head {
+			head = latest
+			f.notify()
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-notifications:
+			// A nil notification is sent on reconnecting, which is as
+			// good a reason as any to publish. Notifications that arrived
+			// in the meantime are handled by the same publication.
+			for len(notifications) > 0 {
+				<-notifications
+			}
+		case <-ticker.C:
+		}
+	}
+}
+
+func (f *feed) notify() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for c := range f.subscribers {
+		select {
+		case c <- struct{}{}:
+		default: // already notified
+		}
+	}
+}
+
+// publish assigns sequence numbers to the change events recorded since it
+// last ran and removes expired events, unless another Traffic Ops instance
+// is doing so, and returns the latest sequence number.
+func publish(db *sqlx.DB) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*pollInterval)
+	defer cancel()
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, errors.New("beginning transaction: " + err.Error())
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Errorln("rolling back change event transaction: " + err.Error())
+		}
+	}()
+
+	locked := false
+	if err := tx.QueryRow(`SELECT pg_try_advisory_xact_lock($1)`, publishLockID).Scan(&locked); err != nil {
+		return 0, errors.New("acquiring lock: " + err.Error())
+	}
+	if locked {
+		result, err := tx.Exec(publishQuery)
+		if err != nil {
+			return 0, errors.New("assigning sequence numbers: " + err.Error())
+		}
+		if published, err := result.RowsAffected(); err != nil {
+			return 0, errors.New("getting number of published events: " + err.Error())
+		} else if published > 0 {
+			if _, err := tx.Exec(`SELECT pg_notify($1, $2)`, notifyChannel, publishedPayload); err != nil {
+				return 0, errors.New("notifying of published events: " + err.Error())
+			}
+		}
+		if _, err := tx.Exec(pruneQuery, int64(retention/time.Second)); err != nil {
+			return 0, errors.New("removing expired events: " + err.Error())
+		}
+	}
+
+	head := int64(0)
+	if err := tx.QueryRow(headQuery).Scan(&head); err != nil {
+		return 0, errors.New("querying latest sequence number: " + err.Error())
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, errors.New("committing: " + err.Error())
+	}
+	return head, nil
+}
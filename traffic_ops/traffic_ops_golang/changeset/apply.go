@@ -0,0 +1,352 @@
+package changeset
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/apitenant"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/asn"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/cachegroup"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/cdn"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/division"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/origin"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/parameter"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/physlocation"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/profile"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/region"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/status"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/types"
+)
+
+// objectType is a type of object that Changesets can edit. Its edits are
+// applied by the same CRUDer that handles requests to edit it directly.
+type objectType struct {
+	// crud returns a new CRUDer of objects of the type.
+	crud func() api.CRUDer
+	// permission is the prefix of the Permissions required to edit objects
+	// of the type, e.g. "CDN" for "CDN:CREATE".
+	permission string
+	// extraPermissions are Permissions required to edit objects of the type
+	// in addition to those named by permission, as required by its
+	// endpoints.
+	extraPermissions []string
+}
+
+// permissions returns the Permissions required to apply an edit with the
+// given action to an object of the type.
+func (t objectType) permissions(action string) []string {
+	perm := t.permission + ":UPDATE"
+	switch action {
+	case tc.ChangeActionCreate:
+		perm = t.permission + ":CREATE"
+	case tc.ChangeActionDelete:
+		perm = t.permission + ":DELETE"
+	}
+	return append([]string{perm, t.permission + ":READ"}, t.extraPermissions...)
+}
+
+// objectTypes are the types of objects Changesets can edit, keyed by the
+// names in tc.ChangesetObjectTypes.
+var objectTypes = map[string]objectType{
+	"asn":           {func() api.CRUDer { return &asn.TOASNV11{} }, "ASN", []string{"CACHE-GROUP:READ", "CACHE-GROUP:UPDATE"}},
+	"cachegroup":    {func() api.CRUDer { return &cachegroup.TOCacheGroup{} }, "CACHE-GROUP", []string{"TYPE:READ"}},
+	"cdn":           {func() api.CRUDer { return &cdn.TOCDN{} }, "CDN", nil},
+	"division":      {func() api.CRUDer { return &division.TODivision{} }, "DIVISION", nil},
+	"origin":        {func() api.CRUDer { return &origin.TOOrigin{} }, "ORIGIN", []string{"DELIVERY-SERVICE:READ", "DELIVERY-SERVICE:UPDATE"}},
+	"parameter":     {func() api.CRUDer { return &parameter.TOParameter{} }, "PARAMETER", nil},
+	"phys_location": {func() api.CRUDer { return &physlocation.TOPhysLocation{} }, "PHYSICAL-LOCATION", nil},
+	"profile":       {func() api.CRUDer { return &profile.TOProfile{} }, "PROFILE", nil},
+	"region":        {func() api.CRUDer { return &region.TORegion{} }, "REGION", nil},
+	"status":        {func() api.CRUDer { return &status.TOStatus{} }, "STATUS", nil},
+	"tenant":        {func() api.CRUDer { return &apitenant.TOTenant{} }, "TENANT", nil},
+	"type":          {func() api.CRUDer { return &types.TOType{} }, "TYPE", nil},
+}
+
+// objectInfo returns a copy of the request's APIInfo for operating on the
+// object with the given ID, if it has one. The request's own parameters
+// aren't those of the object, so they're replaced.
+func objectInfo(inf *api.APIInfo, key string, id *int) *api.APIInfo {
+	objInf := *inf
+	objInf.Params = map[string]string{}
+	objInf.IntParams = map[string]int{}
+	if id != nil {
+		objInf.Params[key] = strconv.Itoa(*id)
+		objInf.IntParams[key] = *id
+	}
+	return &objInf
+}
+
+// keyField returns the name of the field that identifies objects of the
+// given CRUDer's type.
+func keyField(obj api.CRUDer) (string, error) {
+	fields := obj.GetKeyFieldsInfo()
+	if len(fields) != 1 {
+		return "", fmt.Errorf("%s objects have %d key fields; only one is supported", obj.GetType(), len(fields))
+	}
+	return fields[0].Field, nil
+}
+
+// readObject returns the properties of the object of the given type with the
+// given ID, or nil if it doesn't exist.
+func readObject(inf *api.APIInfo, t objectType, id int) (map[string]json.RawMessage, error, error, int) {
+	obj := t.crud()
+	key, err := keyField(obj)
+	if err != nil {
+		return nil, nil, err, http.StatusInternalServerError
+	}
+	obj.SetInfo(objectInfo(inf, key, &id))
+	objs, userErr, sysErr, errCode, _ := obj.Read(nil, false)
+	if userErr != nil || sysErr != nil {
+		return nil, userErr, sysErr, errCode
+	}
+	if len(objs) == 0 {
+		return nil, nil, nil, http.StatusOK
+	}
+	bts, err := json.Marshal(objs[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding %s #%d: %w", obj.GetType(), id, err), http.StatusInternalServerError
+	}
+	props := map[string]json.RawMessage{}
+	if err := json.Unmarshal(bts, &props); err != nil {
+		return nil, nil, fmt.Errorf("decoding %s #%d: %w", obj.GetType(), id, err), http.StatusInternalServerError
+	}
+	return props, nil, nil, http.StatusOK
+}
+
+// applyEdit applies the edit in the request's transaction, exactly as a
+// request to make it directly would, and returns the changes it made. It
+// returns any user error, any system error, and the HTTP status code to
+// respond with if there was an error.
+func applyEdit(inf *api.APIInfo, edit tc.ChangesetEdit) (tc.ChangesetEditPreview, error, error, int) {
+	preview := tc.ChangesetEditPreview{
+		EditID:     edit.ID,
+		ObjectType: edit.ObjectType,
+		Action:     edit.Action,
+		ObjectID:   edit.ObjectID,
+		Changes:    []tc.ChangesetFieldChange{},
+	}
+	t, ok := objectTypes[edit.ObjectType]
+	if !ok {
+		return preview, fmt.Errorf("changesets cannot edit objects of type '%s'", edit.ObjectType), nil, http.StatusBadRequest
+	}
+	if inf.Config.RoleBasedPermissions {
+		if missing := inf.User.MissingPermissions(t.permissions(edit.Action)...); len(missing) > 0 {
+			return preview, fmt.Errorf("missing permissions: %v", missing), nil, http.StatusForbidden
+		}
+	}
+
+	obj := t.crud()
+	key, err := keyField(obj)
+	if err != nil {
+		return preview, nil, err, http.StatusInternalServerError
+	}
+
+	var before map[string]json.RawMessage
+	if edit.ObjectID != nil {
+		var userErr, sysErr error
+		var errCode int
+		before, userErr, sysErr, errCode = readObject(inf, t, *edit.ObjectID)
+		if userErr != nil || sysErr != nil {
+			return preview, userErr, sysErr, errCode
+		}
+		if before == nil {
+			return preview, fmt.Errorf("no %s exists by ID %d", edit.ObjectType, *edit.ObjectID), nil, http.StatusNotFound
+		}
+	}
+
+	obj.SetInfo(objectInfo(inf, key, edit.ObjectID))
+	if edit.Action != tc.ChangeActionDelete {
+		if err := json.Unmarshal(edit.Body, obj); err != nil {
+			return preview, fmt.Errorf("decoding body: %v", err), nil, http.StatusBadRequest
+		}
+	}
+	if edit.ObjectID != nil {
+		obj.SetKeys(map[string]interface{}{key: *edit.ObjectID})
+	}
+	if edit.Action != tc.ChangeActionDelete {
+		if userErr, sysErr := obj.Validate(); userErr != nil || sysErr != nil {
+			if sysErr != nil {
+				return preview, nil, sysErr, http.StatusInternalServerError
+			}
+			return preview, userErr, nil, http.StatusBadRequest
+		}
+	}
+	if tenantable, ok := obj.(api.Tenantable); ok {
+		authorized, err := tenantable.IsTenantAuthorized(inf.User)
+		if err != nil {
+			return preview, nil, errors.New("checking tenant authorized: " + err.Error()), http.StatusInternalServerError
+		}
+		if !authorized {
+			return preview, errors.New("not authorized on this tenant"), nil, http.StatusForbidden
+		}
+	}
+
+	var userErr, sysErr error
+	errCode := http.StatusOK
+	changeLogAction := api.Updated
+	switch edit.Action {
+	case tc.ChangeActionCreate:
+		userErr, sysErr, errCode = obj.Create()
+		changeLogAction = api.Created
+	case tc.ChangeActionUpdate:
+		userErr, sysErr, errCode = obj.Update(http.Header{})
+	case tc.ChangeActionDelete:
+		userErr, sysErr, errCode = obj.Delete()
+		changeLogAction = api.Deleted
+	default:
+		return preview, fmt.Errorf("unknown action '%s'", edit.Action), nil, http.StatusBadRequest
+	}
+	if userErr != nil || sysErr != nil {
+		return preview, userErr, sysErr, errCode
+	}
+	if err := api.CreateChangeLog(api.ApiChange, changeLogAction, obj, inf.User, inf.Tx.Tx); err != nil {
+		return preview, nil, fmt.Errorf("inserting changelog: %w", err), http.StatusInternalServerError
+	}
+
+	var after map[string]json.RawMessage
+	if edit.Action != tc.ChangeActionDelete {
+		keys, _ := obj.GetKeys()
+		id, ok := keys[key].(int)
+		if !ok {
+			return preview, nil, fmt.Errorf("%s key '%s' is %T, not an integer", edit.ObjectType, key, keys[key]), http.StatusInternalServerError
+		}
+		preview.ObjectID = &id
+		after, userErr, sysErr, errCode = readObject(inf, t, id)
+		if userErr != nil || sysErr != nil {
+			return preview, userErr, sysErr, errCode
+		}
+	}
+	preview.Changes = diffObjects(before, after)
+	return preview, nil, nil, http.StatusOK
+}
+
+// equalJSON returns whether two JSON documents encode the same value,
+// regardless of formatting and the order of object properties.
+func equalJSON(a, b []byte) bool {
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return string(a) == string(b)
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// diffObjects returns the changes to the properties of an object between
+// before and after, either of which is nil if the object doesn't exist.
+// The time of the object's last update always changes, so it's ignored.
+func diffObjects(before, after map[string]json.RawMessage) []tc.ChangesetFieldChange {
+	fields := make([]string, 0, len(before)+len(after))
+	for field := range before {
+		fields = append(fields, field)
+	}
+	for field := range after {
+		if _, ok := before[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := []tc.ChangesetFieldChange{}
+	for _, field := range fields {
+		if field == "lastUpdated" {
+			continue
+		}
+		oldVal, hadOld := before[field]
+		newVal, hasNew := after[field]
+		if hadOld && hasNew && equalJSON(oldVal, newVal) {
+			continue
+		}
+		changes = append(changes, tc.ChangesetFieldChange{Field: field, Old: oldVal, New: newVal})
+	}
+	return changes
+}
+
+// snapshotDelta returns the differences between a CDN's current CRConfig,
+// which is nil if it has none, and its projected CRConfig. The "stats"
+// section describes when and by whom the CRConfig was generated, which is
+// always different, so it's ignored.
+func snapshotDelta(cdnName string, current []byte, projected []byte) (tc.ChangesetSnapshotDelta, error) {
+	delta := tc.ChangesetSnapshotDelta{CDN: cdnName, Snapshotted: current != nil, Sections: map[string]tc.CRConfigSectionDelta{}}
+	currentSections := map[string]json.RawMessage{}
+	if current != nil {
+		if err := json.Unmarshal(current, &currentSections); err != nil {
+			return delta, fmt.Errorf("decoding current CRConfig: %w", err)
+		}
+	}
+	projectedSections := map[string]json.RawMessage{}
+	if err := json.Unmarshal(projected, &projectedSections); err != nil {
+		return delta, fmt.Errorf("decoding projected CRConfig: %w", err)
+	}
+
+	names := map[string]struct{}{}
+	for name := range currentSections {
+		names[name] = struct{}{}
+	}
+	for name := range projectedSections {
+		names[name] = struct{}{}
+	}
+	for name := range names {
+		if name == "stats" {
+			continue
+		}
+		oldEntries := map[string]json.RawMessage{}
+		if bts, ok := currentSections[name]; ok && string(bts) != "null" {
+			if err := json.Unmarshal(bts, &oldEntries); err != nil {
+				return delta, fmt.Errorf("decoding current CRConfig section '%s': %w", name, err)
+			}
+		}
+		newEntries := map[string]json.RawMessage{}
+		if bts, ok := projectedSections[name]; ok && string(bts) != "null" {
+			if err := json.Unmarshal(bts, &newEntries); err != nil {
+				return delta, fmt.Errorf("decoding projected CRConfig section '%s': %w", name, err)
+			}
+		}
+
+		section := tc.CRConfigSectionDelta{Added: []string{}, Removed: []string{}, Changed: []string{}}
+		for key, val := range newEntries {
+			if oldVal, ok := oldEntries[key]; !ok {
+				section.Added = append(section.Added, key)
+			} else if !equalJSON(oldVal, val) {
+				section.Changed = append(section.Changed, key)
+			}
+		}
+		for key := range oldEntries {
+			if _, ok := newEntries[key]; !ok {
+				section.Removed = append(section.Removed, key)
+			}
+		}
+		if section.Empty() {
+			continue
+		}
+		sort.Strings(section.Added)
+		sort.Strings(section.Removed)
+		sort.Strings(section.Changed)
+		delta.Sections[name] = section
+	}
+	return delta, nil
+}
@@ -0,0 +1,167 @@
+package changeset
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestObjectTypes(t *testing.T) {
+	names := make([]string, 0, len(objectTypes))
+	for name, typ := range objectTypes {
+		names = append(names, name)
+		obj := typ.crud()
+		if _, err := keyField(obj); err != nil {
+			t.Errorf("Unexpected error getting the key field of %s objects: %v", name, err)
+		}
+	}
+	sort.Strings(names)
+	expected := append([]string{}, tc.ChangesetObjectTypes...)
+	sort.Strings(expected)
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected Changesets to be able to edit exactly %v, got: %v", expected, names)
+	}
+}
+
+func TestPermissions(t *testing.T) {
+	typ := objectTypes["origin"]
+	tests := map[string][]string{
+		tc.ChangeActionCreate: {"ORIGIN:CREATE", "ORIGIN:READ", "DELIVERY-SERVICE:READ", "DELIVERY-SERVICE:UPDATE"},
+		tc.ChangeActionUpdate: {"ORIGIN:UPDATE", "ORIGIN:READ", "DELIVERY-SERVICE:READ", "DELIVERY-SERVICE:UPDATE"},
+		tc.ChangeActionDelete: {"ORIGIN:DELETE", "ORIGIN:READ", "DELIVERY-SERVICE:READ", "DELIVERY-SERVICE:UPDATE"},
+	}
+	for action, expected := range tests {
+		if perms := typ.permissions(action); !reflect.DeepEqual(perms, expected) {
+			t.Errorf("Expected %s permissions %v, got: %v", action, expected, perms)
+		}
+	}
+}
+
+func props(t *testing.T, obj string) map[string]json.RawMessage {
+	t.Helper()
+	if obj == "" {
+		return nil
+	}
+	m := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(obj), &m); err != nil {
+		t.Fatalf("Unexpected error decoding test object: %v", err)
+	}
+	return m
+}
+
+func TestDiffObjects(t *testing.T) {
+	type testCase struct {
+		before   string
+		after    string
+		expected []string
+	}
+	tests := map[string]testCase{
+		"update": {
+			before:   `{"id": 1, "name": "a", "tags": ["x", "y"], "lastUpdated": "2022-06-30 12:00:00+00", "old": true}`,
+			after:    `{"id": 1, "name": "b", "tags": ["x","y"], "lastUpdated": "2022-06-30 13:00:00+00", "new": 1}`,
+			expected: []string{"name", "new", "old"},
+		},
+		"create": {
+			after:    `{"id": 2, "name": "c", "lastUpdated": "2022-06-30 13:00:00+00"}`,
+			expected: []string{"id", "name"},
+		},
+		"delete": {
+			before:   `{"id": 3, "name": "d"}`,
+			expected: []string{"id", "name"},
+		},
+		"no-op": {
+			before:   `{"id": 4, "obj": {"a": 1, "b": 2}}`,
+			after:    `{"obj": {"b": 2, "a": 1}, "id": 4}`,
+			expected: []string{},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			changes := diffObjects(props(t, test.before), props(t, test.after))
+			fields := []string{}
+			for _, change := range changes {
+				fields = append(fields, change.Field)
+			}
+			if !reflect.DeepEqual(fields, test.expected) {
+				t.Errorf("Expected changed fields %v, got: %v", test.expected, fields)
+			}
+		})
+	}
+
+	changes := diffObjects(props(t, `{"name": "a"}`), props(t, `{"name": "b"}`))
+	if len(changes) != 1 || string(changes[0].Old) != `"a"` || string(changes[0].New) != `"b"` {
+		t.Errorf("Expected name to change from \"a\" to \"b\", got: %+v", changes)
+	}
+	changes = diffObjects(nil, props(t, `{"name": "a"}`))
+	if len(changes) != 1 || changes[0].Old != nil {
+		t.Errorf("Expected created object's properties to have no old values, got: %+v", changes)
+	}
+}
+
+func TestSnapshotDelta(t *testing.T) {
+	current := []byte(`{
+		"config": {"domain_name": "test", "soa": {"admin": "a"}},
+		"contentServers": {"edge1": {"status": "REPORTED"}, "edge2": {"status": "REPORTED"}},
+		"deliveryServices": {"ds1": {"protocol": {"acceptHttp": true}}},
+		"stats": {"date": 1}
+	}`)
+	projected := []byte(`{
+		"config": {"soa": {"admin": "a"}, "domain_name": "test"},
+		"contentServers": {"edge1": {"status": "ADMIN_DOWN"}, "edge3": {"status": "REPORTED"}},
+		"deliveryServices": {"ds1": {"protocol": {"acceptHttp": true}}},
+		"monitors": {"mon1": {}},
+		"stats": {"date": 2}
+	}`)
+
+	delta, err := snapshotDelta("cdn", current, projected)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if delta.CDN != "cdn" || !delta.Snapshotted {
+		t.Errorf("Expected delta of snapshotted CDN 'cdn', got: %+v", delta)
+	}
+	expected := map[string]tc.CRConfigSectionDelta{
+		"contentServers": {Added: []string{"edge3"}, Removed: []string{"edge2"}, Changed: []string{"edge1"}},
+		"monitors":       {Added: []string{"mon1"}, Removed: []string{}, Changed: []string{}},
+	}
+	if !reflect.DeepEqual(delta.Sections, expected) {
+		t.Errorf("Expected sections %+v, got: %+v", expected, delta.Sections)
+	}
+
+	delta, err = snapshotDelta("cdn", nil, projected)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if delta.Snapshotted {
+		t.Error("Expected delta of a CDN without a snapshot not to be snapshotted")
+	}
+	if len(delta.Sections) != 4 {
+		t.Errorf("Expected every section but stats of a CDN without a snapshot to be added, got: %+v", delta.Sections)
+	}
+
+	if _, err := snapshotDelta("cdn", []byte(`not JSON`), projected); err == nil {
+		t.Error("Expected an error decoding an invalid current CRConfig, got none")
+	}
+}
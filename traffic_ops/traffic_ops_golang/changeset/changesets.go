@@ -0,0 +1,623 @@
+// Package changeset implements Changesets: groups of edits to Traffic Ops
+// objects which are staged together, previewed, and then committed in a
+// single transaction or discarded.
+package changeset
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/crconfig"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+
+	"github.com/lib/pq"
+)
+
+const readQuery = `
+SELECT c.id,
+	c.name,
+	c.description,
+	c.status,
+	c.author,
+	c.closed_by,
+	c.closed,
+	c.created,
+	c.last_updated
+FROM changeset AS c
+`
+
+const readEditsQuery = `
+SELECT e.id,
+	e.changeset,
+	e.object_type,
+	e."action",
+	e.object_id,
+	e.body,
+	e.created
+FROM changeset_edit AS e
+WHERE e.changeset = ANY($1)
+ORDER BY e.id
+`
+
+const insertQuery = `
+INSERT INTO changeset (name, description, author)
+VALUES ($1, $2, $3)
+RETURNING id
+`
+
+// Locking the Changeset serializes changes to its edits with committing or
+// discarding it.
+const lockQuery = `
+SELECT status
+FROM changeset
+WHERE id = $1
+FOR UPDATE
+`
+
+const insertEditQuery = `
+INSERT INTO changeset_edit (changeset, object_type, "action", object_id, body)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+const deleteEditQuery = `
+DELETE FROM changeset_edit
+WHERE id = $1 AND changeset = $2
+`
+
+const touchQuery = `
+UPDATE changeset SET last_updated = now()
+WHERE id = $1
+`
+
+const closeQuery = `
+UPDATE changeset SET
+	status = $1,
+	closed_by = $2,
+	closed = now(),
+	last_updated = now()
+WHERE id = $3
+`
+
+// affectedCDNsQuery selects the CDNs of the objects changed so far in the
+// current transaction, from the change events recorded by triggers.
+const affectedCDNsQuery = `
+SELECT DISTINCT ce.cdn
+FROM change_event AS ce
+WHERE ce.txid = txid_current()
+AND ce."sequence" IS NULL
+AND ce.cdn IS NOT NULL
+AND EXISTS (SELECT 1 FROM cdn WHERE cdn.name = ce.cdn)
+ORDER BY ce.cdn
+`
+
+// Read is the handler for GET requests to /changesets.
+func Read(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	queryParamsToQueryCols := map[string]dbhelpers.WhereColumnInfo{
+		"id":     dbhelpers.WhereColumnInfo{Column: "c.id", Checker: api.IsInt},
+		"name":   dbhelpers.WhereColumnInfo{Column: "c.name"},
+		"status": dbhelpers.WhereColumnInfo{Column: "c.status"},
+		"author": dbhelpers.WhereColumnInfo{Column: "c.author"},
+	}
+	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(inf.Params, queryParamsToQueryCols)
+	if len(errs) > 0 {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, util.JoinErrs(errs), nil)
+		return
+	}
+	if orderBy == "" {
+		orderBy = "\nORDER BY c.id"
+	}
+
+	rows, err := inf.Tx.NamedQuery(readQuery+where+orderBy+pagination, queryValues)
+	if err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		if sysErr != nil {
+			sysErr = fmt.Errorf("changeset read query: %v", sysErr)
+		}
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer rows.Close()
+
+	changesets := []tc.Changeset{}
+	for rows.Next() {
+		var changeset tc.Changeset
+		if err = scan(rows, &changeset); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("scanning changesets: "+err.Error()))
+			return
+		}
+		changesets = append(changesets, changeset)
+	}
+	if err := addEdits(tx, changesets); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	api.WriteResp(w, r, changesets)
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(row scanner, changeset *tc.Changeset) error {
+	return row.Scan(
+		&changeset.ID,
+		&changeset.Name,
+		&changeset.Description,
+		&changeset.Status,
+		&changeset.Author,
+		&changeset.ClosedBy,
+		&changeset.Closed,
+		&changeset.Created,
+		&changeset.LastUpdated,
+	)
+}
+
+// addEdits sets the edits of each of the given Changesets.
+func addEdits(tx *sql.Tx, changesets []tc.Changeset) error {
+	if len(changesets) == 0 {
+		return nil
+	}
+	ids := make([]int64, 0, len(changesets))
+	byID := make(map[int]*tc.Changeset, len(changesets))
+	for i := range changesets {
+		changesets[i].Edits = []tc.ChangesetEdit{}
+		ids = append(ids, int64(changesets[i].ID))
+		byID[changesets[i].ID] = &changesets[i]
+	}
+
+	rows, err := tx.Query(readEditsQuery, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("querying changeset edits: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var edit tc.ChangesetEdit
+		var changesetID int
+		var body []byte
+		if err := rows.Scan(&edit.ID, &changesetID, &edit.ObjectType, &edit.Action, &edit.ObjectID, &body, &edit.Created); err != nil {
+			return fmt.Errorf("scanning changeset edits: %w", err)
+		}
+		edit.Body = body
+		if changeset, ok := byID[changesetID]; ok {
+			changeset.Edits = append(changeset.Edits, edit)
+		}
+	}
+	return rows.Err()
+}
+
+// getChangeset returns the Changeset with the given ID, with its edits, and
+// whether or not it exists.
+func getChangeset(tx *sql.Tx, id int) (tc.Changeset, bool, error) {
+	var changeset tc.Changeset
+	if err := scan(tx.QueryRow(readQuery+"WHERE c.id = $1", id), &changeset); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return changeset, false, nil
+		}
+		return changeset, false, fmt.Errorf("querying changeset #%d: %w", id, err)
+	}
+	changesets := []tc.Changeset{changeset}
+	if err := addEdits(tx, changesets); err != nil {
+		return changeset, true, err
+	}
+	return changesets[0], true, nil
+}
+
+// Create is the handler for POST requests to /changesets. New Changesets
+// are open, and have no edits.
+func Create(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	var req tc.ChangesetRequest
+	if userErr = api.Parse(r.Body, tx, &req); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+
+	var id int
+	if err := tx.QueryRow(insertQuery, req.Name, req.Description, inf.User.UserName).Scan(&id); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	resp, _, err := getChangeset(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	changeLogMsg := fmt.Sprintf("CHANGESET: %s, ID: %d, ACTION: Created", resp.Name, resp.ID)
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+
+	alerts := tc.CreateAlerts(tc.SuccessLevel, "Changeset '"+resp.Name+"' created")
+	api.WriteAlertsObj(w, r, http.StatusCreated, alerts, resp)
+}
+
+// lockOpen locks the Changeset identified by the request's "id" path
+// parameter, which must be open to be changed. If the returned HTTP status
+// code isn't OK, the request has been handled.
+func lockOpen(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, action string) int {
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+	var status string
+	if err := tx.QueryRow(lockQuery, id).Scan(&status); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no changeset exists by ID %d", id), nil)
+			return http.StatusNotFound
+		}
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("locking changeset #%d: %w", id, err))
+		return http.StatusInternalServerError
+	}
+	if status != tc.ChangesetStatusOpen {
+		api.HandleErr(w, r, tx, http.StatusConflict, fmt.Errorf("a %s changeset cannot be %s", status, action), nil)
+		return http.StatusConflict
+	}
+	return http.StatusOK
+}
+
+// AddEdit is the handler for POST requests to /changesets/{id}/edits, which
+// adds an edit to the end of an open Changeset. Edits are checked only
+// superficially when they're added; they're fully validated when they're
+// previewed or committed, against the objects as they are then.
+func AddEdit(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	if code := lockOpen(w, r, inf, "edited"); code != http.StatusOK {
+		return
+	}
+	var req tc.ChangesetEditRequest
+	if userErr = api.Parse(r.Body, tx, &req); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	var body interface{}
+	if req.Action != tc.ChangeActionDelete {
+		body = string(req.Body)
+	}
+
+	id := inf.IntParams["id"]
+	if _, err := tx.Exec(insertEditQuery, id, req.ObjectType, req.Action, req.ObjectID, body); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	respondEdited(w, r, inf, "Edit added to")
+}
+
+// DeleteEdit is the handler for DELETE requests to
+// /changesets/{id}/edits/{editID}, which removes an edit from an open
+// Changeset.
+func DeleteEdit(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id", "editID"}, []string{"id", "editID"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	if code := lockOpen(w, r, inf, "edited"); code != http.StatusOK {
+		return
+	}
+	id := inf.IntParams["id"]
+	editID := inf.IntParams["editID"]
+	result, err := tx.Exec(deleteEditQuery, editID, id)
+	if err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting rows affected by deleting changeset edit: %w", err))
+		return
+	} else if rows == 0 {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("changeset #%d has no edit by ID %d", id, editID), nil)
+		return
+	}
+	respondEdited(w, r, inf, "Edit removed from")
+}
+
+// respondEdited responds to a request that changed the edits of the
+// Changeset identified by the request's "id" path parameter with the
+// Changeset.
+func respondEdited(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, what string) {
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+	if _, err := tx.Exec(touchQuery, id); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("updating changeset #%d: %w", id, err))
+		return
+	}
+	resp, _, err := getChangeset(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	changeLogMsg := fmt.Sprintf("CHANGESET: %s, ID: %d, ACTION: %s changeset", resp.Name, resp.ID, what)
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, what+" changeset '"+resp.Name+"'", resp)
+}
+
+// applyEdits applies all of the Changeset's edits in the request's
+// transaction, in order, and returns the changes each made. It stops at the
+// first edit that can't be applied, returning any user error, any system
+// error, and the HTTP status code to respond with; the transaction must not
+// be committed then.
+func applyEdits(inf *api.APIInfo, changeset tc.Changeset) ([]tc.ChangesetEditPreview, error, error, int) {
+	previews := make([]tc.ChangesetEditPreview, 0, len(changeset.Edits))
+	for i, edit := range changeset.Edits {
+		preview, userErr, sysErr, errCode := applyEdit(inf, edit)
+		if userErr != nil || sysErr != nil {
+			if userErr != nil {
+				userErr = fmt.Errorf("edit %d (#%d, %s %s): %w", i+1, edit.ID, edit.Action, edit.ObjectType, userErr)
+			}
+			if sysErr != nil {
+				sysErr = fmt.Errorf("applying changeset #%d edit #%d: %w", changeset.ID, edit.ID, sysErr)
+			}
+			return append(previews, preview), userErr, sysErr, errCode
+		}
+		previews = append(previews, preview)
+	}
+	return previews, nil, nil, http.StatusOK
+}
+
+// affectedCDNs returns the names of the CDNs of the objects changed so far in
+// the transaction.
+func affectedCDNs(tx *sql.Tx) ([]string, error) {
+	rows, err := tx.Query(affectedCDNsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("querying CDNs affected by changeset: %w", err)
+	}
+	defer rows.Close()
+	cdns := []string{}
+	for rows.Next() {
+		var cdnName string
+		if err := rows.Scan(&cdnName); err != nil {
+			return nil, fmt.Errorf("scanning CDNs affected by changeset: %w", err)
+		}
+		cdns = append(cdns, cdnName)
+	}
+	return cdns, rows.Err()
+}
+
+// Preview is the handler for GET requests to /changesets/{id}/preview. It
+// applies the Changeset's edits in a transaction that's rolled back, so
+// that nothing is changed, and responds with the changes they made and the
+// differences they made to the CRConfigs of the CDNs they affected.
+func Preview(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	id := inf.IntParams["id"]
+	changeset, ok, err := getChangeset(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no changeset exists by ID %d", id), nil)
+		return
+	}
+	if changeset.Status != tc.ChangesetStatusOpen {
+		api.HandleErr(w, r, tx, http.StatusConflict, fmt.Errorf("a %s changeset cannot be previewed", changeset.Status), nil)
+		return
+	}
+
+	preview := tc.ChangesetPreview{ChangesetID: id, Valid: true, SnapshotDeltas: []tc.ChangesetSnapshotDelta{}}
+	var editErr error
+	preview.Edits, userErr, sysErr, errCode = applyEdits(inf, changeset)
+	if sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, nil, sysErr)
+		return
+	}
+	if userErr != nil {
+		// An edit that can't be applied makes the Changeset invalid, rather
+		// than the request.
+		preview.Valid = false
+		msg := userErr.Error()
+		preview.Edits[len(preview.Edits)-1].Error = &msg
+		editErr = userErr
+	}
+
+	// Failed statements abort the transaction, so the projected Snapshots
+	// can only be generated if every edit was applied.
+	if editErr == nil {
+		cdns, err := affectedCDNs(tx)
+		if err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+			return
+		}
+		for _, cdnName := range cdns {
+			delta, err := projectSnapshotDelta(r, inf, cdnName)
+			if err != nil {
+				api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+				return
+			}
+			if len(delta.Sections) > 0 {
+				preview.SnapshotDeltas = append(preview.SnapshotDeltas, delta)
+			}
+		}
+	}
+
+	if err := tx.Rollback(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("rolling back changeset preview: %w", err))
+		return
+	}
+	if editErr != nil {
+		api.WriteRespAlertObj(w, r, tc.WarnLevel, "Changeset '"+changeset.Name+"' cannot be committed: "+editErr.Error(), preview)
+		return
+	}
+	api.WriteResp(w, r, preview)
+}
+
+// projectSnapshotDelta returns the differences between the CDN's current
+// CRConfig and the one generated in the request's transaction.
+func projectSnapshotDelta(r *http.Request, inf *api.APIInfo, cdnName string) (tc.ChangesetSnapshotDelta, error) {
+	tx := inf.Tx.Tx
+	current, snapshotted, err := crconfig.GetSnapshot(tx, cdnName)
+	if err != nil {
+		return tc.ChangesetSnapshotDelta{}, fmt.Errorf("getting snapshot of CDN '%s': %w", cdnName, err)
+	}
+	// The CRConfig must be generated in the transaction itself - not
+	// concurrently, in transactions sharing its snapshot - to see the
+	// uncommitted edits.
+	emulate := inf.Config.CRConfigEmulateOldPath || inf.Version.Major < 4
+	projected, err := crconfig.Make(r.Context(), nil, tx, cdnName, inf.User.UserName, r.Host, inf.Config.Version, inf.Config.CRConfigUseRequestHost, emulate)
+	if err != nil {
+		return tc.ChangesetSnapshotDelta{}, fmt.Errorf("generating projected CRConfig of CDN '%s': %w", cdnName, err)
+	}
+	projectedJSON, err := json.Marshal(projected)
+	if err != nil {
+		return tc.ChangesetSnapshotDelta{}, fmt.Errorf("encoding projected CRConfig of CDN '%s': %w", cdnName, err)
+	}
+	var currentJSON []byte
+	if snapshotted {
+		currentJSON = []byte(current)
+	}
+	return snapshotDelta(cdnName, currentJSON, projectedJSON)
+}
+
+// Commit is the handler for POST requests to /changesets/{id}/commit. It
+// applies all of the Changeset's edits in a single transaction, so either
+// all of them are made or, if any can't be, none are.
+func Commit(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	if code := lockOpen(w, r, inf, "committed"); code != http.StatusOK {
+		return
+	}
+	id := inf.IntParams["id"]
+	changeset, _, err := getChangeset(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if len(changeset.Edits) == 0 {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("a changeset without edits cannot be committed"), nil)
+		return
+	}
+
+	if _, userErr, sysErr, errCode = applyEdits(inf, changeset); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	cdns, err := affectedCDNs(tx)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if _, err := tx.Exec(closeQuery, tc.ChangesetStatusCommitted, inf.User.UserName, id); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("closing changeset #%d: %w", id, err))
+		return
+	}
+	resp, _, err := getChangeset(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	changeLogMsg := fmt.Sprintf("CHANGESET: %s, ID: %d, ACTION: Committed %d edits", resp.Name, resp.ID, len(resp.Edits))
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+	if err := tx.Commit(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("committing changeset #%d: %w", id, err))
+		return
+	}
+
+	alerts := tc.CreateAlerts(tc.SuccessLevel, fmt.Sprintf("Changeset '%s' committed: %d edits applied", resp.Name, len(resp.Edits)))
+	if len(cdns) > 0 {
+		sort.Strings(cdns)
+		alerts.AddNewAlert(tc.InfoLevel, "Perform a snapshot of, then queue updates on, the CDNs affected by the changeset for its changes to take effect: "+strings.Join(cdns, ", "))
+	}
+	api.WriteAlertsObj(w, r, http.StatusOK, alerts, resp)
+}
+
+// Discard is the handler for POST requests to /changesets/{id}/discard,
+// which closes a Changeset without applying its edits. Discarded
+// Changesets are kept, for reference.
+func Discard(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	if code := lockOpen(w, r, inf, "discarded"); code != http.StatusOK {
+		return
+	}
+	id := inf.IntParams["id"]
+	if _, err := tx.Exec(closeQuery, tc.ChangesetStatusDiscarded, inf.User.UserName, id); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("closing changeset #%d: %w", id, err))
+		return
+	}
+	resp, _, err := getChangeset(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	changeLogMsg := fmt.Sprintf("CHANGESET: %s, ID: %d, ACTION: Discarded", resp.Name, resp.ID)
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Changeset '"+resp.Name+"' discarded", resp)
+}
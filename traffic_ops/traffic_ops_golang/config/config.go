@@ -21,10 +21,12 @@ package config
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -33,6 +35,7 @@ import (
 
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/lib/go-util"
 )
 
@@ -84,17 +87,37 @@ type Config struct {
 	ConfigLDAP                                *ConfigLDAP
 	UserCacheRefreshIntervalSec               int `json:"user_cache_refresh_interval_sec"`
 	ServerUpdateStatusCacheRefreshIntervalSec int `json:"server_update_status_cache_refresh_interval_sec"`
+	DeliveryServiceScheduleIntervalSec        int `json:"delivery_service_schedule_interval_sec"`
+	RolloutIntervalSec                        int `json:"rollout_interval_sec"`
+	SLOEvaluationIntervalSec                  int `json:"slo_evaluation_interval_sec"`
+	ConsistencyCheckIntervalSec               int `json:"consistency_check_interval_sec"`
+	ConsistencyMaxSnapshotChanges             int `json:"consistency_max_snapshot_changes"`
+	AutoSnapshotIntervalSec                   int `json:"auto_snapshot_interval_sec"`
+	WebhookRetryIntervalSec                   int `json:"webhook_retry_interval_sec"`
+	APIUsageFlushIntervalSec                  int `json:"api_usage_flush_interval_sec"`
+	APIUsageRetentionDays                     int `json:"api_usage_retention_days"`
 	LDAPEnabled                               bool
 	LDAPConfPath                              string `json:"ldap_conf_location"`
 	ConfigInflux                              *ConfigInflux
 	InfluxEnabled                             bool
 	InfluxDBConfPath                          string `json:"influxdb_conf_path"`
 	Version                                   string
-	DisableAutoCertDeletion                   bool                    `json:"disable_auto_cert_deletion"`
-	UseIMS                                    bool                    `json:"use_ims"`
-	RoleBasedPermissions                      bool                    `json:"role_based_permissions"`
-	DefaultCertificateInfo                    *DefaultCertificateInfo `json:"default_certificate_info"`
-	Cdni                                      *CdniConf               `json:"cdni"`
+	DisableAutoCertDeletion                   bool                         `json:"disable_auto_cert_deletion"`
+	UseIMS                                    bool                         `json:"use_ims"`
+	RoleBasedPermissions                      bool                         `json:"role_based_permissions"`
+	DefaultCertificateInfo                    *DefaultCertificateInfo      `json:"default_certificate_info"`
+	Cdni                                      *CdniConf                    `json:"cdni"`
+	DeliveryServiceReview                     *ConfigDeliveryServiceReview `json:"delivery_service_review"`
+	DSRCertificates                           *ConfigDSRCertificates       `json:"dsr_certificates"`
+	Billing                                   *ConfigBilling               `json:"billing"`
+	ObjectCache                               *ConfigObjectCache           `json:"object_cache"`
+	LogTail                                   *ConfigLogTail               `json:"log_tail"`
+	Messages                                  *ConfigMessages              `json:"messages"`
+	CORS                                      *ConfigCORS                  `json:"cors"`
+	MFA                                       *ConfigMFA                   `json:"mfa"`
+	TenantOnboarding                          *ConfigTenantOnboarding      `json:"tenant_onboarding"`
+	GRPC                                      *ConfigGRPC                  `json:"grpc"`
+	Tracing                                   *ConfigTracing               `json:"tracing"`
 }
 
 // ConfigHypnotoad carries http setting for hypnotoad (mojolicious) server
@@ -142,6 +165,16 @@ type ConfigTrafficOpsGolang struct {
 	TrafficVaultBackend  string          `json:"traffic_vault_backend"`
 	TrafficVaultConfig   json.RawMessage `json:"traffic_vault_config"`
 
+	// SlowRequestDBTimeMilliseconds is the time a request may spend querying
+	// the database before it's logged as slow. Zero disables the log.
+	SlowRequestDBTimeMilliseconds int `json:"slow_request_db_time_milliseconds"`
+
+	// ClientCertificateAuthority is the path to a PEM file of the
+	// certificates of the authorities whose client certificates users may log
+	// in with, as the users named by their Subjects' Common Names. If empty,
+	// users can't log in with client certificates.
+	ClientCertificateAuthority string `json:"client_certificate_authority"`
+
 	// CRConfigUseRequestHost is whether to use the client request host header in the CRConfig. If false, uses the tm.url parameter.
 	// This defaults to false. Traffic Ops used to always use the host header, setting this true will resume that legacy behavior.
 	// See https://github.com/apache/trafficcontrol/issues/2224
@@ -184,6 +217,24 @@ type ConfigSMTP struct {
 	User     string `json:"user"`
 }
 
+// ClientCertificatePool returns the pool of the certificates of the
+// authorities whose client certificates users may log in with, or nil if
+// there are none.
+func (c ConfigTrafficOpsGolang) ClientCertificatePool() (*x509.CertPool, error) {
+	if c.ClientCertificateAuthority == "" {
+		return nil, nil
+	}
+	pemBytes, err := ioutil.ReadFile(c.ClientCertificateAuthority)
+	if err != nil {
+		return nil, fmt.Errorf("reading client_certificate_authority: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemBytes) {
+		return nil, fmt.Errorf("client_certificate_authority '%s' contains no PEM certificates", c.ClientCertificateAuthority)
+	}
+	return pool, nil
+}
+
 // ConfigLetsEncrypt contains configuration information for integration with the Let's Encrypt certificate authority.
 type ConfigLetsEncrypt struct {
 	Email                     string `json:"user_email,omitempty"`
@@ -208,6 +259,521 @@ type ConfigAcmeAccount struct {
 	HmacEncoded  string `json:"hmac_encoded"`
 }
 
+// ConfigDeliveryServiceReview configures which users' changes to protected
+// Delivery Service fields are subject to review. Traffic Ops submits Delivery
+// Service Requests for those changes instead of making them.
+type ConfigDeliveryServiceReview struct {
+	// Roles are the names of the Roles whose users' changes require review.
+	Roles []string `json:"roles"`
+	// Tenants are the names of the Tenants whose users' changes require
+	// review, along with those of the users of their descendants.
+	Tenants []string `json:"tenants"`
+}
+
+// ConfigDSRCertificates configures the certificates Traffic Ops obtains for
+// HTTPS Delivery Services that have none when Delivery Service Requests for
+// them are completed. If this is nil, no certificates are obtained, and such
+// requests are completed right away.
+type ConfigDSRCertificates struct {
+	// AcmeProvider is the ACME provider from which certificates are obtained:
+	// either Let's Encrypt, or the provider of one of the AcmeAccounts.
+	AcmeProvider string `json:"acme_provider"`
+}
+
+// Validate returns an error if the configuration isn't valid, given the
+// configured ACME accounts.
+func (c *ConfigDSRCertificates) Validate(accounts []ConfigAcmeAccount) error {
+	if c.AcmeProvider == "" {
+		return errors.New("dsr_certificates acme_provider is required")
+	}
+	if c.AcmeProvider == tc.LetsEncryptAuthType {
+		return nil
+	}
+	for _, account := range accounts {
+		if account.AcmeProvider == c.AcmeProvider {
+			return nil
+		}
+	}
+	return fmt.Errorf("dsr_certificates acme_provider '%s' is neither '%s' nor the provider of any of the acme_accounts", c.AcmeProvider, tc.LetsEncryptAuthType)
+}
+
+// ConfigBilling configures the costs in Traffic Ops billing reports.
+type ConfigBilling struct {
+	// RateCards are the rate cards by which Tenants may be billed, by name.
+	RateCards map[string]ConfigRateCard `json:"rate_cards"`
+	// TenantRateCards maps the names of Tenants to the names of the rate
+	// cards by which they, and those of their descendants with no rate card
+	// of their own, are billed. Tenants with no rate card of their own or of
+	// an ancestor are billed by the rate card named DefaultRateCard, if there
+	// is one.
+	TenantRateCards map[string]string `json:"tenant_rate_cards"`
+}
+
+// DefaultRateCard is the name of the rate card by which Tenants are billed if
+// neither they nor their ancestors have a rate card.
+const DefaultRateCard = "default"
+
+// ConfigRateCard is the prices by which a Tenant is billed for the usage of
+// its Delivery Services.
+type ConfigRateCard struct {
+	// Currency is the currency of the prices, e.g. "USD".
+	Currency string `json:"currency"`
+	// PerTB is the price per terabyte served.
+	PerTB float64 `json:"per_tb"`
+	// PerP95Gbps is the price per gigabit per second of 95th percentile
+	// bandwidth.
+	PerP95Gbps float64 `json:"per_p95_gbps"`
+	// MonthlyMinimum is the least a Tenant is billed for a month in which
+	// its Delivery Services have any usage.
+	MonthlyMinimum float64 `json:"monthly_minimum"`
+}
+
+// Validate returns an error if any rate card has a negative price, or any
+// Tenant's rate card doesn't exist.
+func (b *ConfigBilling) Validate() error {
+	for name, card := range b.RateCards {
+		if card.PerTB < 0 || card.PerP95Gbps < 0 || card.MonthlyMinimum < 0 {
+			return fmt.Errorf("billing rate card '%s' has a negative price", name)
+		}
+	}
+	for tenant, card := range b.TenantRateCards {
+		if _, ok := b.RateCards[card]; !ok {
+			return fmt.Errorf("billing rate card '%s' of tenant '%s' does not exist", card, tenant)
+		}
+	}
+	return nil
+}
+
+// ConfigObjectCache configures the cache of objects Traffic Ops serves often,
+// like CDN Snapshots and servers.
+type ConfigObjectCache struct {
+	// Backend is where objects are cached: "memory" for the memory of each
+	// Traffic Ops instance, or "redis" for a Redis server shared by every
+	// instance. If empty, objects aren't cached.
+	Backend string `json:"backend"`
+	// TTLSeconds is how long an object is cached for, at most. Objects are
+	// evicted as soon as what they depend on changes, so this is just a
+	// limit on how long they take up space.
+	TTLSeconds int `json:"ttl_seconds"`
+	// RefreshIntervalSeconds is how often the generations of objects are
+	// read from the database even if no change has been notified.
+	RefreshIntervalSeconds int `json:"refresh_interval_seconds"`
+	// MaxEntries is the most objects the memory backend caches at once.
+	MaxEntries int                     `json:"max_entries"`
+	Redis      *ConfigObjectCacheRedis `json:"redis"`
+}
+
+// ConfigObjectCacheRedis configures the Redis server in which objects are
+// cached.
+type ConfigObjectCacheRedis struct {
+	// Address is the host and port of the Redis server.
+	Address  string `json:"address"`
+	Password string `json:"password"`
+	// DB is the number of the Redis database to use.
+	DB  int  `json:"db"`
+	TLS bool `json:"tls"`
+	// TimeoutMilliseconds is how long a request to Redis may take before
+	// the object is read from the database instead.
+	TimeoutMilliseconds int `json:"timeout_ms"`
+	MaxIdleConnections  int `json:"max_idle_connections"`
+}
+
+// Validate returns an error if the backend is unknown, or the Redis backend
+// is used without an address.
+func (c *ConfigObjectCache) Validate() error {
+	switch c.Backend {
+	case "", "memory":
+	case "redis":
+		if c.Redis == nil || c.Redis.Address == "" {
+			return errors.New("object cache backend 'redis' requires redis.address")
+		}
+	default:
+		return fmt.Errorf("unknown object cache backend '%s'", c.Backend)
+	}
+	if c.TTLSeconds < 0 || c.RefreshIntervalSeconds < 0 || c.MaxEntries < 0 {
+		return errors.New("object cache settings must not be negative")
+	}
+	return nil
+}
+
+// ConfigLogTail configures tailing the logs of cache servers, which are
+// streamed to Traffic Ops by t3c-log-agent.
+type ConfigLogTail struct {
+	// AgentPort is the port on which t3c-log-agent listens. If 0,
+	// tc.DefaultLogAgentPort is used.
+	AgentPort int `json:"agent_port"`
+	// AgentSecret is the secret with which Traffic Ops authenticates to
+	// t3c-log-agent.
+	AgentSecret string `json:"agent_secret"`
+	// AgentHTTPS is whether t3c-log-agent is served over HTTPS.
+	AgentHTTPS bool `json:"agent_https"`
+	// AgentInsecure is whether to skip verifying the certificates of
+	// t3c-log-agent.
+	AgentInsecure bool `json:"agent_insecure"`
+	// MaxSeconds is the longest a log may be tailed. If 0,
+	// tc.DefaultServerLogTailMaxSeconds is used.
+	MaxSeconds int `json:"max_seconds"`
+	// AllowedOrigins are the origins of web pages, besides Traffic Ops
+	// itself, allowed to tail logs, e.g. that of Traffic Portal.
+	AllowedOrigins []string `json:"allowed_origins"`
+	// CacheHitsCollectionIntervalSec is how often the cache hits and misses
+	// counted by t3c-log-agent are collected from cache servers. If 0, they
+	// aren't collected.
+	CacheHitsCollectionIntervalSec int `json:"cache_hits_collection_interval_sec"`
+	// CacheHitsRetentionDays is how many days collected cache hits and
+	// misses are kept. If 0, CacheHitsRetentionDaysDefault is used.
+	CacheHitsRetentionDays int `json:"cache_hits_retention_days"`
+}
+
+// CacheHitsRetentionDaysDefault is how many days collected cache hits and
+// misses are kept, unless configured otherwise.
+const CacheHitsRetentionDaysDefault = 30
+
+// Validate returns an error if the agent secret is missing, or the port,
+// maximum duration, or cache hit collection settings are invalid.
+func (c *ConfigLogTail) Validate() error {
+	if c.AgentSecret == "" {
+		return errors.New("log tail requires agent_secret")
+	}
+	if c.AgentPort < 0 || c.AgentPort > 65535 {
+		return fmt.Errorf("log tail agent_port %d is not a valid port", c.AgentPort)
+	}
+	if c.MaxSeconds < 0 {
+		return errors.New("log tail max_seconds must not be negative")
+	}
+	if c.CacheHitsCollectionIntervalSec < 0 || c.CacheHitsRetentionDays < 0 {
+		return errors.New("log tail cache_hits_collection_interval_sec and cache_hits_retention_days must not be negative")
+	}
+	return nil
+}
+
+// ConfigMessages configures the catalogs of the messages of the error-level
+// Alerts that Traffic Ops returns, by which they're translated into the
+// languages accepted by clients, or customized.
+type ConfigMessages struct {
+	// CatalogDir is a directory of message catalogs, each a JSON file named
+	// for its language, e.g. "fr.json", that maps Alert codes to messages.
+	CatalogDir string `json:"catalog_dir"`
+	// DefaultLanguage is the language of the messages of responses to
+	// requests that accept none of the languages of the catalogs. If empty,
+	// those messages are in English.
+	DefaultLanguage string `json:"default_language"`
+	// Overrides maps languages to Alert codes to messages that take
+	// precedence over those of the catalogs, with which a site customizes
+	// messages without editing catalogs.
+	Overrides map[string]map[string]string `json:"overrides"`
+}
+
+// DefaultCORSAllowedMethods are the methods allowed by CORS policies which
+// don't list any.
+var DefaultCORSAllowedMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete}
+
+// DefaultCORSAllowedHeaders are the request headers allowed by CORS policies
+// which don't list any.
+var DefaultCORSAllowedHeaders = []string{"Origin", "X-Requested-With", "Content-Type", "Accept", "Set-Cookie", "Cookie"}
+
+// ConfigCORS configures the Cross-Origin Resource Sharing (CORS) policies by
+// which browsers allow web pages of other origins to call the API. If it
+// isn't configured, every origin may call the API, with credentials.
+type ConfigCORS struct {
+	// Policies are tried in order, and the first whose Routes match the
+	// requested route applies. Requests to routes no policy matches aren't
+	// allowed from any other origin.
+	Policies []ConfigCORSPolicy `json:"policies"`
+}
+
+// ConfigCORSPolicy is the CORS policy of a group of API routes.
+type ConfigCORSPolicy struct {
+	// Routes are the API version-relative paths of the routes to which the
+	// policy applies, e.g. "deliveryservices", which also matches the paths
+	// beneath them, e.g. "deliveryservices/1/health". If empty, the policy
+	// applies to every route.
+	Routes []string `json:"routes"`
+	// AllowedOrigins are the origins allowed to call the routes, e.g.
+	// "https://tools.example.test", or "*" for any origin.
+	AllowedOrigins []string `json:"allowed_origins"`
+	// AllowCredentials is whether browsers send cookies with requests, and
+	// so whether requests are authenticated by them.
+	AllowCredentials bool `json:"allow_credentials"`
+	// AllowedMethods are the methods allowed in requests. If empty,
+	// DefaultCORSAllowedMethods are allowed.
+	AllowedMethods []string `json:"allowed_methods"`
+	// AllowedHeaders are the headers allowed in requests. If empty,
+	// DefaultCORSAllowedHeaders are allowed.
+	AllowedHeaders []string `json:"allowed_headers"`
+	// ExposedHeaders are the response headers, besides those which are
+	// always exposed, that pages may read.
+	ExposedHeaders []string `json:"exposed_headers"`
+	// MaxAgeSeconds is how long browsers may cache the responses to
+	// preflight requests. If 0, browsers use their default.
+	MaxAgeSeconds int `json:"max_age_seconds"`
+}
+
+// Validate returns an error if a policy allows no origin, an origin isn't
+// "*" or a scheme and host, a policy allows credentials from any origin, or
+// a maximum age is negative.
+func (c *ConfigCORS) Validate() error {
+	for i, policy := range c.Policies {
+		if len(policy.AllowedOrigins) == 0 {
+			return fmt.Errorf("cors policy %d allows no origins", i)
+		}
+		for _, origin := range policy.AllowedOrigins {
+			if origin == "*" {
+				if policy.AllowCredentials {
+					return fmt.Errorf("cors policy %d cannot allow credentials from any origin", i)
+				}
+				continue
+			}
+			u, err := url.Parse(origin)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+				return fmt.Errorf("cors policy %d origin '%s' is not '*' or a scheme and host, like 'https://tools.example.test'", i, origin)
+			}
+		}
+		if policy.MaxAgeSeconds < 0 {
+			return fmt.Errorf("cors policy %d max_age_seconds must not be negative", i)
+		}
+	}
+	return nil
+}
+
+// Policy returns the policy of the route with the given API version-relative
+// path, or nil if no policy matches it.
+func (c *ConfigCORS) Policy(routePath string) *ConfigCORSPolicy {
+	routePath = strings.Trim(routePath, "/")
+	for i, policy := range c.Policies {
+		if len(policy.Routes) == 0 {
+			return &c.Policies[i]
+		}
+		for _, route := range policy.Routes {
+			route = strings.Trim(route, "/")
+			if routePath == route || strings.HasPrefix(routePath, route+"/") {
+				return &c.Policies[i]
+			}
+		}
+	}
+	return nil
+}
+
+// AllowsOrigin returns whether or not the policy allows the given origin.
+func (p *ConfigCORSPolicy) AllowsOrigin(origin string) bool {
+	origin = strings.TrimSuffix(origin, "/")
+	for _, allowed := range p.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsAnyOrigin returns whether or not the policy allows every origin.
+func (p *ConfigCORSPolicy) AllowsAnyOrigin() bool {
+	for _, allowed := range p.AllowedOrigins {
+		if allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Methods returns the methods the policy allows.
+func (p *ConfigCORSPolicy) Methods() []string {
+	if len(p.AllowedMethods) == 0 {
+		return DefaultCORSAllowedMethods
+	}
+	return p.AllowedMethods
+}
+
+// AllowsMethod returns whether or not the policy allows the given method.
+func (p *ConfigCORSPolicy) AllowsMethod(method string) bool {
+	for _, allowed := range p.Methods() {
+		if strings.EqualFold(allowed, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// Headers returns the request headers the policy allows.
+func (p *ConfigCORSPolicy) Headers() []string {
+	if len(p.AllowedHeaders) == 0 {
+		return DefaultCORSAllowedHeaders
+	}
+	return p.AllowedHeaders
+}
+
+// DefaultMFAIssuer is the issuer of TOTP secrets when none is configured.
+const DefaultMFAIssuer = "Traffic Ops"
+
+// ConfigMFA configures the second authentication factors with which users log
+// in with their passwords. If this is nil, second factors are disabled.
+type ConfigMFA struct {
+	// Issuer is the issuer shown beside TOTP secrets in authenticator apps. If
+	// empty, DefaultMFAIssuer is used.
+	Issuer string `json:"issuer"`
+	// RequiredRoles are the names of the Roles whose users must use a second
+	// factor to log in.
+	RequiredRoles []string `json:"required_roles"`
+	// WebAuthn configures WebAuthn security keys. If this is nil, they can't
+	// be used.
+	WebAuthn *ConfigWebAuthn `json:"webauthn"`
+}
+
+// ConfigWebAuthn configures the WebAuthn Relying Party of Traffic Ops.
+type ConfigWebAuthn struct {
+	// RPID is the Relying Party ID, which is the domain of the clients with
+	// which users log in, like "example.test".
+	RPID string `json:"rp_id"`
+	// RPName is the name of the Relying Party shown to users.
+	RPName string `json:"rp_name"`
+	// Origins are the origins of the clients with which users log in, like
+	// "https://tp.example.test". Their hosts must be the RPID or one of its
+	// subdomains.
+	Origins []string `json:"origins"`
+}
+
+// Validate returns an error if the configuration isn't valid.
+func (c *ConfigMFA) Validate() error {
+	if c.WebAuthn == nil {
+		return nil
+	}
+	if c.WebAuthn.RPID == "" {
+		return errors.New("mfa webauthn rp_id is required")
+	}
+	if len(c.WebAuthn.Origins) == 0 {
+		return errors.New("mfa webauthn origins are required")
+	}
+	for _, origin := range c.WebAuthn.Origins {
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme != "https" || u.Host == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+			return fmt.Errorf("mfa webauthn origin '%s' is not an https scheme and host, like 'https://tp.example.test'", origin)
+		}
+		host := u.Hostname()
+		if host != c.WebAuthn.RPID && !strings.HasSuffix(host, "."+c.WebAuthn.RPID) {
+			return fmt.Errorf("mfa webauthn origin '%s' is not in the domain of rp_id '%s'", origin, c.WebAuthn.RPID)
+		}
+	}
+	return nil
+}
+
+// IssuerName returns the issuer of TOTP secrets.
+func (c *ConfigMFA) IssuerName() string {
+	if c.Issuer == "" {
+		return DefaultMFAIssuer
+	}
+	return c.Issuer
+}
+
+// RoleRequiresMFA returns whether or not users with the Role of the given
+// name must use a second factor to log in.
+func (c *ConfigMFA) RoleRequiresMFA(roleName string) bool {
+	for _, role := range c.RequiredRoles {
+		if role == roleName {
+			return true
+		}
+	}
+	return false
+}
+
+// ConfigTenantOnboarding configures the onboarding of new Tenants through
+// /tenants/onboard. If this is nil, Tenants can't be onboarded.
+type ConfigTenantOnboarding struct {
+	// TemplatePath is the path to the JSON file of the template from which
+	// Tenants are onboarded.
+	TemplatePath string `json:"template"`
+	// Template is the template loaded from TemplatePath.
+	Template tc.TenantOnboardingTemplate `json:"-"`
+}
+
+// Load loads and validates the template from TemplatePath.
+func (c *ConfigTenantOnboarding) Load() error {
+	if c.TemplatePath == "" {
+		return errors.New("tenant_onboarding: template: required")
+	}
+	templateBytes, err := ioutil.ReadFile(c.TemplatePath)
+	if err != nil {
+		return fmt.Errorf("reading tenant onboarding template '%s': %w", c.TemplatePath, err)
+	}
+	if err := json.Unmarshal(templateBytes, &c.Template); err != nil {
+		return fmt.Errorf("unmarshalling tenant onboarding template '%s': %w", c.TemplatePath, err)
+	}
+	if err := c.Template.Validate(); err != nil {
+		return fmt.Errorf("invalid tenant onboarding template '%s': %w", c.TemplatePath, err)
+	}
+	return nil
+}
+
+// ConfigGRPC configures the read-only gRPC service served alongside the API,
+// with the same certificate and key. If this is nil, it isn't served.
+type ConfigGRPC struct {
+	// Port is the port on which the gRPC service listens.
+	Port int `json:"port"`
+}
+
+// Validate returns an error if the gRPC configuration is invalid.
+func (c *ConfigGRPC) Validate() error {
+	if c.Port < 1 || c.Port > 65535 {
+		return fmt.Errorf("grpc: port: must be between 1 and 65535, got %d", c.Port)
+	}
+	return nil
+}
+
+// ConfigTracing configures tracing requests to the API, and the database
+// queries they make, with OpenTelemetry. If this is nil, requests aren't
+// traced.
+type ConfigTracing struct {
+	// Exporter is how spans are exported: TracingExporterOTLP sends them to
+	// an OpenTelemetry collector, TracingExporterLog writes them to LogPath.
+	Exporter string `json:"exporter"`
+	// Endpoint is the URL of the OTLP/HTTP traces endpoint of the collector,
+	// e.g. http://collector.example:4318/v1/traces.
+	Endpoint string `json:"endpoint"`
+	// Headers are added to the requests to the collector, e.g. for
+	// authentication.
+	Headers map[string]string `json:"headers"`
+	// LogPath is the file to which the log exporter appends spans.
+	LogPath string `json:"log_path"`
+	// ServiceName is the name of the service in exported spans. If empty,
+	// TracingServiceNameDefault is used.
+	ServiceName string `json:"service_name"`
+	// SampleRatio is the ratio, between 0 and 1, of the traces of requests
+	// which aren't continued from their clients that are sampled. If nil,
+	// all are.
+	SampleRatio *float64 `json:"sample_ratio"`
+}
+
+// The exporters of spans of traced requests.
+const (
+	TracingExporterOTLP = "otlp"
+	TracingExporterLog  = "log"
+)
+
+// TracingServiceNameDefault is the name of the service in the spans of
+// traced requests, unless configured otherwise.
+const TracingServiceNameDefault = "traffic_ops"
+
+// Validate returns an error if the tracing configuration is invalid.
+func (c *ConfigTracing) Validate() error {
+	switch c.Exporter {
+	case TracingExporterOTLP:
+		if c.Endpoint == "" {
+			return errors.New("tracing: endpoint: required by the otlp exporter")
+		}
+		if u, err := url.Parse(c.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("tracing: endpoint: must be an http or https URL, got '%s'", c.Endpoint)
+		}
+	case TracingExporterLog:
+		if c.LogPath == "" {
+			return errors.New("tracing: log_path: required by the log exporter")
+		}
+	default:
+		return fmt.Errorf("tracing: exporter: must be '%s' or '%s', got '%s'", TracingExporterOTLP, TracingExporterLog, c.Exporter)
+	}
+	if c.SampleRatio != nil && (*c.SampleRatio < 0 || *c.SampleRatio > 1) {
+		return fmt.Errorf("tracing: sample_ratio: must be between 0 and 1, got %v", *c.SampleRatio)
+	}
+	return nil
+}
+
 type DefaultCertificateInfo struct {
 	BusinessUnit string `json:"business_unit"`
 	City         string `json:"city"`
@@ -408,6 +974,23 @@ func LoadConfig(cdnConfPath string, dbConfPath string, appVersion string) (Confi
 		}
 	}
 
+	if cfg.TenantOnboarding != nil {
+		if err := cfg.TenantOnboarding.Load(); err != nil {
+			return cfg, []error{err}, BlockStartup
+		}
+	}
+
+	if cfg.GRPC != nil {
+		if err := cfg.GRPC.Validate(); err != nil {
+			return cfg, []error{err}, BlockStartup
+		}
+	}
+	if cfg.Tracing != nil {
+		if err := cfg.Tracing.Validate(); err != nil {
+			return cfg, []error{err}, BlockStartup
+		}
+	}
+
 	idbPath := cfg.InfluxDBConfPath
 	if idbPath == "" {
 		mojoMode := os.Getenv("MOJO_MODE")
@@ -453,6 +1036,36 @@ func (c Config) GetKeyPath() string {
 const (
 	DBMaxIdleConnectionsDefault     = 10 // if this is higher than MaxDBConnections it will be automatically adjusted below it by the db/sql library
 	DBConnMaxLifetimeSecondsDefault = 60
+	// DeliveryServiceScheduleIntervalSecDefault is how often Delivery
+	// Services' scheduled activations and deactivations are applied, if
+	// not configured.
+	DeliveryServiceScheduleIntervalSecDefault = 60
+	// RolloutIntervalSecDefault is how often the progress of running
+	// Rollouts is checked, if not configured.
+	RolloutIntervalSecDefault = 30
+	// SLOEvaluationIntervalSecDefault is how often the compliance of
+	// Delivery Services with their service level objectives is evaluated,
+	// if not configured.
+	SLOEvaluationIntervalSecDefault = 300
+	// ConsistencyCheckIntervalSecDefault is how often the consistency of
+	// the data in Traffic Ops is checked, if not configured.
+	ConsistencyCheckIntervalSecDefault = 900
+	// ConsistencyMaxSnapshotChangesDefault is the number of changes by which
+	// a CDN's Snapshot may be out of date before it's a consistency
+	// violation, if not configured.
+	ConsistencyMaxSnapshotChangesDefault = 100
+	// AutoSnapshotIntervalSecDefault is how often CDNs' snapshot policies
+	// are applied, if not configured.
+	AutoSnapshotIntervalSecDefault = 60
+	// WebhookRetryIntervalSecDefault is how often changes whose delivery to
+	// Webhooks failed are retried when they're due, if not configured.
+	WebhookRetryIntervalSecDefault = 10
+	// APIUsageFlushIntervalSecDefault is how often the API usage recorded
+	// in memory is written to the database, if not configured.
+	APIUsageFlushIntervalSecDefault = 60
+	// APIUsageRetentionDaysDefault is the number of days for which API
+	// usage is kept, if not configured.
+	APIUsageRetentionDaysDefault = 90
 )
 
 // ParseConfig validates required fields, and parses non-JSON types
@@ -494,6 +1107,33 @@ func ParseConfig(cfg Config) (Config, error) {
 	if cfg.ServerUpdateStatusCacheRefreshIntervalSec < 0 {
 		cfg.ServerUpdateStatusCacheRefreshIntervalSec = 0
 	}
+	if cfg.DeliveryServiceScheduleIntervalSec == 0 {
+		cfg.DeliveryServiceScheduleIntervalSec = DeliveryServiceScheduleIntervalSecDefault
+	}
+	if cfg.RolloutIntervalSec == 0 {
+		cfg.RolloutIntervalSec = RolloutIntervalSecDefault
+	}
+	if cfg.SLOEvaluationIntervalSec == 0 {
+		cfg.SLOEvaluationIntervalSec = SLOEvaluationIntervalSecDefault
+	}
+	if cfg.ConsistencyCheckIntervalSec == 0 {
+		cfg.ConsistencyCheckIntervalSec = ConsistencyCheckIntervalSecDefault
+	}
+	if cfg.ConsistencyMaxSnapshotChanges == 0 {
+		cfg.ConsistencyMaxSnapshotChanges = ConsistencyMaxSnapshotChangesDefault
+	}
+	if cfg.AutoSnapshotIntervalSec == 0 {
+		cfg.AutoSnapshotIntervalSec = AutoSnapshotIntervalSecDefault
+	}
+	if cfg.WebhookRetryIntervalSec == 0 {
+		cfg.WebhookRetryIntervalSec = WebhookRetryIntervalSecDefault
+	}
+	if cfg.APIUsageFlushIntervalSec == 0 {
+		cfg.APIUsageFlushIntervalSec = APIUsageFlushIntervalSecDefault
+	}
+	if cfg.APIUsageRetentionDays == 0 {
+		cfg.APIUsageRetentionDays = APIUsageRetentionDaysDefault
+	}
 
 	invalidTOURLStr := ""
 	var err error
@@ -542,6 +1182,37 @@ func ParseConfig(cfg Config) (Config, error) {
 		return Config{}, err
 	}
 
+	if cfg.Billing != nil {
+		if err := cfg.Billing.Validate(); err != nil {
+			return Config{}, err
+		}
+	}
+	if cfg.DSRCertificates != nil {
+		if err := cfg.DSRCertificates.Validate(cfg.AcmeAccounts); err != nil {
+			return Config{}, err
+		}
+	}
+	if cfg.ObjectCache != nil {
+		if err := cfg.ObjectCache.Validate(); err != nil {
+			return Config{}, err
+		}
+	}
+	if cfg.LogTail != nil {
+		if err := cfg.LogTail.Validate(); err != nil {
+			return Config{}, err
+		}
+	}
+	if cfg.CORS != nil {
+		if err := cfg.CORS.Validate(); err != nil {
+			return Config{}, err
+		}
+	}
+	if cfg.MFA != nil {
+		if err := cfg.MFA.Validate(); err != nil {
+			return Config{}, err
+		}
+	}
+
 	return cfg, nil
 }
 
@@ -24,6 +24,7 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
@@ -318,3 +319,411 @@ func TestValidateRoutingBlacklist(t *testing.T) {
 		}
 	}
 }
+
+func TestConfigBillingValidate(t *testing.T) {
+	testCases := []struct {
+		Input     ConfigBilling
+		ExpectErr bool
+	}{
+		{
+			Input:     ConfigBilling{},
+			ExpectErr: false,
+		},
+		{
+			Input: ConfigBilling{
+				RateCards:       map[string]ConfigRateCard{"standard": {Currency: "USD", PerTB: 10}},
+				TenantRateCards: map[string]string{"tenant": "standard"},
+			},
+			ExpectErr: false,
+		},
+		{
+			Input: ConfigBilling{
+				RateCards:       map[string]ConfigRateCard{"standard": {Currency: "USD", PerTB: 10}},
+				TenantRateCards: map[string]string{"tenant": "gold"},
+			},
+			ExpectErr: true,
+		},
+		{
+			Input: ConfigBilling{
+				RateCards: map[string]ConfigRateCard{"standard": {Currency: "USD", PerP95Gbps: -1}},
+			},
+			ExpectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		if err := tc.Input.Validate(); err != nil && !tc.ExpectErr {
+			t.Errorf("Expected: no error, actual: %v", err)
+		} else if err == nil && tc.ExpectErr {
+			t.Errorf("Expected: non-nil error, actual: nil")
+		}
+	}
+}
+
+func TestConfigObjectCacheValidate(t *testing.T) {
+	testCases := []struct {
+		Input     ConfigObjectCache
+		ExpectErr bool
+	}{
+		{
+			Input:     ConfigObjectCache{},
+			ExpectErr: false,
+		},
+		{
+			Input:     ConfigObjectCache{Backend: "memory", TTLSeconds: 60, MaxEntries: 100},
+			ExpectErr: false,
+		},
+		{
+			Input:     ConfigObjectCache{Backend: "redis", Redis: &ConfigObjectCacheRedis{Address: "localhost:6379"}},
+			ExpectErr: false,
+		},
+		{
+			Input:     ConfigObjectCache{Backend: "redis"},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigObjectCache{Backend: "memcached"},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigObjectCache{Backend: "memory", TTLSeconds: -1},
+			ExpectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		if err := tc.Input.Validate(); err != nil && !tc.ExpectErr {
+			t.Errorf("Expected: no error, actual: %v", err)
+		} else if err == nil && tc.ExpectErr {
+			t.Errorf("Expected: non-nil error, actual: nil")
+		}
+	}
+}
+
+func TestConfigLogTailValidate(t *testing.T) {
+	testCases := []struct {
+		Input     ConfigLogTail
+		ExpectErr bool
+	}{
+		{
+			Input:     ConfigLogTail{AgentSecret: "secret"},
+			ExpectErr: false,
+		},
+		{
+			Input:     ConfigLogTail{AgentSecret: "secret", AgentPort: 8443, AgentHTTPS: true, MaxSeconds: 600},
+			ExpectErr: false,
+		},
+		{
+			Input:     ConfigLogTail{},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigLogTail{AgentSecret: "secret", AgentPort: 70000},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigLogTail{AgentSecret: "secret", MaxSeconds: -1},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigLogTail{AgentSecret: "secret", CacheHitsCollectionIntervalSec: 300, CacheHitsRetentionDays: 7},
+			ExpectErr: false,
+		},
+		{
+			Input:     ConfigLogTail{AgentSecret: "secret", CacheHitsCollectionIntervalSec: -1},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigLogTail{AgentSecret: "secret", CacheHitsRetentionDays: -1},
+			ExpectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		if err := tc.Input.Validate(); err != nil && !tc.ExpectErr {
+			t.Errorf("Expected: no error, actual: %v", err)
+		} else if err == nil && tc.ExpectErr {
+			t.Errorf("Expected: non-nil error, actual: nil")
+		}
+	}
+}
+
+func TestConfigCORSValidate(t *testing.T) {
+	testCases := []struct {
+		Input     ConfigCORS
+		ExpectErr bool
+	}{
+		{
+			Input:     ConfigCORS{},
+			ExpectErr: false,
+		},
+		{
+			Input:     ConfigCORS{Policies: []ConfigCORSPolicy{{AllowedOrigins: []string{"https://tools.example.test", "http://localhost:8080"}, AllowCredentials: true, MaxAgeSeconds: 600}}},
+			ExpectErr: false,
+		},
+		{
+			Input:     ConfigCORS{Policies: []ConfigCORSPolicy{{Routes: []string{"cdns"}, AllowedOrigins: []string{"*"}}}},
+			ExpectErr: false,
+		},
+		{
+			Input:     ConfigCORS{Policies: []ConfigCORSPolicy{{Routes: []string{"cdns"}}}},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigCORS{Policies: []ConfigCORSPolicy{{AllowedOrigins: []string{"*"}, AllowCredentials: true}}},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigCORS{Policies: []ConfigCORSPolicy{{AllowedOrigins: []string{"tools.example.test"}}}},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigCORS{Policies: []ConfigCORSPolicy{{AllowedOrigins: []string{"https://tools.example.test/app"}}}},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigCORS{Policies: []ConfigCORSPolicy{{AllowedOrigins: []string{"https://tools.example.test"}, MaxAgeSeconds: -1}}},
+			ExpectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		if err := tc.Input.Validate(); err != nil && !tc.ExpectErr {
+			t.Errorf("Expected: no error, actual: %v", err)
+		} else if err == nil && tc.ExpectErr {
+			t.Errorf("Expected: non-nil error, actual: nil")
+		}
+	}
+}
+
+func TestConfigCORSPolicy(t *testing.T) {
+	cors := ConfigCORS{
+		Policies: []ConfigCORSPolicy{
+			{Routes: []string{"deliveryservices", "/cdns/"}, AllowedOrigins: []string{"https://tools.example.test"}},
+			{AllowedOrigins: []string{"*"}},
+		},
+	}
+
+	testCases := map[string]int{
+		"deliveryservices":           0,
+		"deliveryservices/1/health":  0,
+		"cdns/name/foo/snapshot":     0,
+		"deliveryservicesfoo":        1,
+		"servers":                    1,
+		"deliveryservice_requests/1": 1,
+	}
+	for path, expected := range testCases {
+		policy := cors.Policy(path)
+		if policy != &cors.Policies[expected] {
+			t.Errorf("Expected: policy %d for route '%s', actual: %+v", expected, path, policy)
+		}
+	}
+
+	if !cors.Policies[0].AllowsOrigin("https://TOOLS.example.test") {
+		t.Error("Expected: origins to be compared case-insensitively, actual: not allowed")
+	}
+	if cors.Policies[0].AllowsOrigin("https://evil.example.test") {
+		t.Error("Expected: other origins not to be allowed, actual: allowed")
+	}
+	if !cors.Policies[1].AllowsOrigin("https://evil.example.test") {
+		t.Error("Expected: any origin to be allowed by '*', actual: not allowed")
+	}
+	if !cors.Policies[0].AllowsMethod("put") || cors.Policies[0].AllowsMethod(http.MethodPatch) {
+		t.Errorf("Expected: default methods %v to be allowed, actual: %v", DefaultCORSAllowedMethods, cors.Policies[0].Methods())
+	}
+
+	if (&ConfigCORS{Policies: []ConfigCORSPolicy{{Routes: []string{"cdns"}}}}).Policy("servers") != nil {
+		t.Error("Expected: no policy for routes no policy matches, actual: a policy")
+	}
+}
+
+func TestConfigMFAValidate(t *testing.T) {
+	testCases := []struct {
+		Input     ConfigMFA
+		ExpectErr bool
+	}{
+		{
+			Input:     ConfigMFA{},
+			ExpectErr: false,
+		},
+		{
+			Input:     ConfigMFA{RequiredRoles: []string{"admin"}, WebAuthn: &ConfigWebAuthn{RPID: "example.test", Origins: []string{"https://example.test", "https://tp.example.test:8443"}}},
+			ExpectErr: false,
+		},
+		{
+			Input:     ConfigMFA{WebAuthn: &ConfigWebAuthn{Origins: []string{"https://tp.example.test"}}},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigMFA{WebAuthn: &ConfigWebAuthn{RPID: "example.test"}},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigMFA{WebAuthn: &ConfigWebAuthn{RPID: "example.test", Origins: []string{"http://tp.example.test"}}},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigMFA{WebAuthn: &ConfigWebAuthn{RPID: "example.test", Origins: []string{"https://tp.badexample.test"}}},
+			ExpectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		if err := tc.Input.Validate(); err != nil && !tc.ExpectErr {
+			t.Errorf("Expected: no error, actual: %v", err)
+		} else if err == nil && tc.ExpectErr {
+			t.Errorf("Expected: non-nil error, actual: nil")
+		}
+	}
+
+	mfa := ConfigMFA{RequiredRoles: []string{"admin", "operations"}}
+	if !mfa.RoleRequiresMFA("operations") || mfa.RoleRequiresMFA("read-only") {
+		t.Errorf("Expected: only roles %v to require MFA", mfa.RequiredRoles)
+	}
+	if mfa.IssuerName() != DefaultMFAIssuer {
+		t.Errorf("Expected: default issuer '%s', actual: '%s'", DefaultMFAIssuer, mfa.IssuerName())
+	}
+}
+
+func TestConfigDSRCertificatesValidate(t *testing.T) {
+	accounts := []ConfigAcmeAccount{{AcmeProvider: "Example CA", AcmeUrl: "https://acme.example.test/directory"}}
+	testCases := []struct {
+		Input     ConfigDSRCertificates
+		ExpectErr bool
+	}{
+		{
+			Input:     ConfigDSRCertificates{AcmeProvider: "Lets Encrypt"},
+			ExpectErr: false,
+		},
+		{
+			Input:     ConfigDSRCertificates{AcmeProvider: "Example CA"},
+			ExpectErr: false,
+		},
+		{
+			Input:     ConfigDSRCertificates{},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigDSRCertificates{AcmeProvider: "Unknown CA"},
+			ExpectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		if err := tc.Input.Validate(accounts); err != nil && !tc.ExpectErr {
+			t.Errorf("Expected: no error, actual: %v", err)
+		} else if err == nil && tc.ExpectErr {
+			t.Errorf("Expected: non-nil error, actual: nil")
+		}
+	}
+}
+
+func TestConfigTenantOnboardingLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, contents string) string {
+		path := dir + "/" + name
+		if err := ioutil.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatalf("writing %s: %v", path, err)
+		}
+		return path
+	}
+
+	valid := write("valid.json", `{
+		"parent": "customers",
+		"defaultRole": "portal",
+		"roles": ["read-only"],
+		"quota": {"maxDeliveryServices": 5, "maxUsers": 10},
+		"deliveryServices": [{"xmlId": "{{.Tenant}}-video", "cdnId": 2}]
+	}`)
+	onboarding := ConfigTenantOnboarding{TemplatePath: valid}
+	if err := onboarding.Load(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if onboarding.Template.Parent != "customers" || len(onboarding.Template.DeliveryServices) != 1 {
+		t.Errorf("expected template to be loaded, got %+v", onboarding.Template)
+	}
+	if !onboarding.Template.RoleAllowed("portal") || !onboarding.Template.RoleAllowed("read-only") || onboarding.Template.RoleAllowed("admin") {
+		t.Error("expected only the default and listed roles to be allowed")
+	}
+
+	invalid := map[string]string{
+		"no path":          "",
+		"missing file":     dir + "/missing.json",
+		"malformed":        write("malformed.json", `{"parent": `),
+		"invalid template": write("invalid.json", `{"parent": "customers"}`),
+	}
+	for name, path := range invalid {
+		t.Run(name, func(t *testing.T) {
+			onboarding := ConfigTenantOnboarding{TemplatePath: path}
+			if err := onboarding.Load(); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestConfigGRPCValidate(t *testing.T) {
+	testCases := []struct {
+		Input     ConfigGRPC
+		ExpectErr bool
+	}{
+		{
+			Input:     ConfigGRPC{Port: 8443},
+			ExpectErr: false,
+		},
+		{
+			Input:     ConfigGRPC{},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigGRPC{Port: 70000},
+			ExpectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		if err := tc.Input.Validate(); err != nil && !tc.ExpectErr {
+			t.Errorf("Expected: no error, actual: %v", err)
+		} else if err == nil && tc.ExpectErr {
+			t.Errorf("Expected: non-nil error, actual: nil")
+		}
+	}
+}
+
+func TestConfigTracingValidate(t *testing.T) {
+	half := 0.5
+	tooMuch := 1.5
+	testCases := []struct {
+		Input     ConfigTracing
+		ExpectErr bool
+	}{
+		{
+			Input:     ConfigTracing{Exporter: TracingExporterOTLP, Endpoint: "http://collector.example:4318/v1/traces", SampleRatio: &half},
+			ExpectErr: false,
+		},
+		{
+			Input:     ConfigTracing{Exporter: TracingExporterLog, LogPath: "/var/log/traffic_ops/traces.log"},
+			ExpectErr: false,
+		},
+		{
+			Input:     ConfigTracing{Exporter: TracingExporterOTLP},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigTracing{Exporter: TracingExporterOTLP, Endpoint: "collector.example:4318"},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigTracing{Exporter: TracingExporterLog},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigTracing{Exporter: "jaeger", Endpoint: "http://collector.example:4318/v1/traces"},
+			ExpectErr: true,
+		},
+		{
+			Input:     ConfigTracing{Exporter: TracingExporterLog, LogPath: "traces.log", SampleRatio: &tooMuch},
+			ExpectErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		if err := tc.Input.Validate(); err != nil && !tc.ExpectErr {
+			t.Errorf("Expected: no error, actual: %v", err)
+		} else if err == nil && tc.ExpectErr {
+			t.Errorf("Expected: non-nil error, actual: nil")
+		}
+	}
+}
@@ -0,0 +1,452 @@
+package consistency
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// checker.go defines the consistency checker, which regularly checks
+// invariants across the data in Traffic Ops which foreign keys can't express.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/lib/pq"
+)
+
+// lockRunQuery locks the consistency checker's run, returning when it last
+// ran, unless another Traffic Ops instance is running it.
+const lockRunQuery = `
+SELECT checked_at
+FROM consistency_check_run
+WHERE id = 1
+FOR UPDATE SKIP LOCKED
+`
+
+const updateRunQuery = `
+UPDATE consistency_check_run
+SET checked_at = $1
+WHERE id = 1
+`
+
+// upsertViolationQuery records a violation, returning whether it's newly
+// detected.
+const upsertViolationQuery = `
+INSERT INTO consistency_violation (check_name, subject, message, first_detected, last_detected)
+VALUES ($1, $2, $3, $4, $4)
+ON CONFLICT (check_name, subject) DO UPDATE SET
+message = EXCLUDED.message,
+last_detected = EXCLUDED.last_detected
+RETURNING (xmax = 0)
+`
+
+const deleteResolvedViolationsQuery = `
+DELETE FROM consistency_violation
+WHERE last_detected < $1
+RETURNING check_name, subject
+`
+
+// topologyNodesQuery selects each Cache Group of each Topology used by
+// Delivery Services, in each of their CDNs, with whether it's in the edge
+// tier - which it is unless it's the parent of another - and the numbers of
+// its EDGE- and MID-type servers in the CDN.
+const topologyNodesQuery = `
+WITH ds_topology AS (
+	SELECT DISTINCT ds.topology, ds.cdn_id
+	FROM deliveryservice AS ds
+	WHERE ds.topology IS NOT NULL
+)
+SELECT
+	n.topology,
+	n.cachegroup,
+	c.name,
+	NOT EXISTS (SELECT 1 FROM topology_cachegroup_parents AS p WHERE p.parent = n.id),
+	(
+		SELECT COUNT(*)
+		FROM server AS s
+		JOIN cachegroup AS cg ON cg.id = s.cachegroup
+		JOIN type AS t ON t.id = s.type
+		WHERE cg.name = n.cachegroup
+		AND s.cdn_id = dt.cdn_id
+		AND t.name LIKE $1 || '%'
+	),
+	(
+		SELECT COUNT(*)
+		FROM server AS s
+		JOIN cachegroup AS cg ON cg.id = s.cachegroup
+		JOIN type AS t ON t.id = s.type
+		WHERE cg.name = n.cachegroup
+		AND s.cdn_id = dt.cdn_id
+		AND t.name LIKE $2 || '%'
+	)
+FROM topology_cachegroup AS n
+JOIN ds_topology AS dt ON dt.topology = n.topology
+JOIN cdn AS c ON c.id = dt.cdn_id
+ORDER BY n.topology, n.cachegroup, c.name
+`
+
+// unsatisfiedTopologyCapabilitiesQuery selects the edge-tier Cache Groups of
+// the Topologies of Delivery Services with required capabilities which have
+// EDGE-type servers in the Delivery Services' CDNs, but none with all of
+// those capabilities.
+const unsatisfiedTopologyCapabilitiesQuery = `
+SELECT ds.xml_id, n.cachegroup
+FROM deliveryservice AS ds
+JOIN topology_cachegroup AS n ON n.topology = ds.topology
+WHERE EXISTS (SELECT 1 FROM deliveryservices_required_capability AS rc WHERE rc.deliveryservice_id = ds.id)
+AND NOT EXISTS (SELECT 1 FROM topology_cachegroup_parents AS p WHERE p.parent = n.id)
+AND EXISTS (
+	SELECT 1
+	FROM server AS s
+	JOIN cachegroup AS cg ON cg.id = s.cachegroup
+	JOIN type AS t ON t.id = s.type
+	WHERE cg.name = n.cachegroup
+	AND s.cdn_id = ds.cdn_id
+	AND t.name LIKE $1 || '%'
+)
+AND NOT EXISTS (
+	SELECT 1
+	FROM server AS s
+	JOIN cachegroup AS cg ON cg.id = s.cachegroup
+	JOIN type AS t ON t.id = s.type
+	WHERE cg.name = n.cachegroup
+	AND s.cdn_id = ds.cdn_id
+	AND t.name LIKE $1 || '%'
+	AND NOT EXISTS (
+		SELECT rc.required_capability
+		FROM deliveryservices_required_capability AS rc
+		WHERE rc.deliveryservice_id = ds.id
+		EXCEPT
+		SELECT ssc.server_capability
+		FROM server_server_capability AS ssc
+		WHERE ssc.server = s.id
+	)
+)
+ORDER BY ds.xml_id, n.cachegroup
+`
+
+// unsatisfiedAssignedCapabilitiesQuery selects the edge-tier servers assigned
+// to Delivery Services without Topologies which lack some of the Delivery
+// Services' required capabilities, with those they lack.
+const unsatisfiedAssignedCapabilitiesQuery = `
+SELECT ds.xml_id, s.host_name, missing.capabilities
+FROM deliveryservice_server AS dss
+JOIN deliveryservice AS ds ON ds.id = dss.deliveryservice
+JOIN server AS s ON s.id = dss.server
+JOIN type AS t ON t.id = s.type
+CROSS JOIN LATERAL (
+	SELECT ARRAY(
+		SELECT rc.required_capability
+		FROM deliveryservices_required_capability AS rc
+		WHERE rc.deliveryservice_id = ds.id
+		EXCEPT
+		SELECT ssc.server_capability
+		FROM server_server_capability AS ssc
+		WHERE ssc.server = s.id
+		ORDER BY 1
+	) AS capabilities
+) AS missing
+WHERE ds.topology IS NULL
+AND t.name LIKE $1 || '%'
+AND cardinality(missing.capabilities) > 0
+ORDER BY ds.xml_id, s.host_name
+`
+
+// snapshotChangesQuery selects each CDN with Delivery Services or servers,
+// with whether it has a Snapshot and the number of its Delivery Services,
+// servers, Static DNS Entries, regular expressions, and Delivery Service
+// server assignments created or modified since its Snapshot was taken.
+const snapshotChangesQuery = `
+SELECT
+	c.name,
+	sn.last_updated IS NOT NULL,
+	(SELECT COUNT(*) FROM deliveryservice AS ds WHERE ds.cdn_id = c.id AND ds.last_updated > sn.last_updated)
+	+ (SELECT COUNT(*) FROM server AS s WHERE s.cdn_id = c.id AND s.last_updated > sn.last_updated)
+	+ (
+		SELECT COUNT(*)
+		FROM staticdnsentry AS e
+		JOIN deliveryservice AS ds ON ds.id = e.deliveryservice
+		WHERE ds.cdn_id = c.id AND e.last_updated > sn.last_updated
+	)
+	+ (
+		SELECT COUNT(*)
+		FROM deliveryservice_regex AS dr
+		JOIN deliveryservice AS ds ON ds.id = dr.deliveryservice
+		WHERE ds.cdn_id = c.id AND dr.last_updated > sn.last_updated
+	)
+	+ (
+		SELECT COUNT(*)
+		FROM deliveryservice_server AS dss
+		JOIN deliveryservice AS ds ON ds.id = dss.deliveryservice
+		WHERE ds.cdn_id = c.id AND dss.last_updated > sn.last_updated
+	)
+FROM cdn AS c
+LEFT JOIN snapshot AS sn ON sn.cdn = c.name
+WHERE EXISTS (SELECT 1 FROM deliveryservice AS ds WHERE ds.cdn_id = c.id)
+OR EXISTS (SELECT 1 FROM server AS s WHERE s.cdn_id = c.id)
+ORDER BY c.name
+`
+
+// InitChecker starts checking, every interval, the invariants across the
+// data in Traffic Ops which foreign keys can't express, recording the
+// violations found for the /system/consistency endpoint, and logging them as
+// warnings when they're first found. The Snapshot of a CDN is in violation
+// when it's older than maxSnapshotChanges changes; if that isn't positive,
+// Snapshots aren't checked. If interval isn't positive, nothing is checked.
+//
+// Checking is safe with any number of Traffic Ops instances running the
+// checker against the same database.
+func InitChecker(interval time.Duration, db *sql.DB, timeout time.Duration, maxSnapshotChanges int) {
+	if interval <= 0 {
+		log.Infoln("consistency check interval is negative, consistency will not be checked")
+		return
+	}
+	go func() {
+		for {
+			if err := check(db, timeout, interval, maxSnapshotChanges); err != nil {
+				log.Errorf("checking consistency: %v", err)
+			}
+			time.Sleep(interval)
+		}
+	}()
+}
+
+func check(db *sql.DB, timeout time.Duration, interval time.Duration, maxSnapshotChanges int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	commit := false
+	defer func() {
+		if !commit {
+			if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+				log.Errorf("rolling back consistency check transaction: %v", err)
+			}
+		}
+	}()
+
+	var checkedAt *time.Time
+	if err := tx.QueryRow(lockRunQuery).Scan(&checkedAt); err == sql.ErrNoRows {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("locking consistency check run: %w", err)
+	}
+	now := time.Now()
+	// Another instance checked recently.
+	if checkedAt != nil && now.Sub(*checkedAt) < interval/2 {
+		return nil
+	}
+
+	violations := []tc.ConsistencyViolation{}
+	errs := []error{}
+	for name, check := range map[tc.ConsistencyCheck]func(*sql.Tx) ([]tc.ConsistencyViolation, error){
+		tc.ConsistencyCheckTopologyServerTypes:  checkTopologyServerTypes,
+		tc.ConsistencyCheckRequiredCapabilities: checkRequiredCapabilities,
+		tc.ConsistencyCheckSnapshotStaleness: func(tx *sql.Tx) ([]tc.ConsistencyViolation, error) {
+			return checkSnapshotStaleness(tx, maxSnapshotChanges)
+		},
+	} {
+		found, err := check(tx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("checking %s: %w", name, err))
+			continue
+		}
+		violations = append(violations, found...)
+	}
+	if len(errs) > 0 {
+		// Without the results of every check, violations can't be resolved.
+		return util.JoinErrs(errs)
+	}
+
+	for _, v := range violations {
+		var isNew bool
+		if err := tx.QueryRow(upsertViolationQuery, v.Check, v.Subject, v.Message, now).Scan(&isNew); err != nil {
+			return fmt.Errorf("recording %s violation of %s: %w", v.Check, v.Subject, err)
+		}
+		if isNew {
+			log.Warnf("consistency check %s found violation of %s: %s", v.Check, v.Subject, v.Message)
+		}
+	}
+	rows, err := tx.Query(deleteResolvedViolationsQuery, now)
+	if err != nil {
+		return fmt.Errorf("deleting resolved violations: %w", err)
+	}
+	defer log.Close(rows, "closing resolved violations rows")
+	for rows.Next() {
+		var name, subject string
+		if err := rows.Scan(&name, &subject); err != nil {
+			return fmt.Errorf("scanning resolved violations: %w", err)
+		}
+		log.Infof("consistency check %s violation of %s resolved", name, subject)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating resolved violations: %w", err)
+	}
+
+	if _, err := tx.Exec(updateRunQuery, now); err != nil {
+		return fmt.Errorf("updating consistency check run: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	commit = true
+	return nil
+}
+
+// topologyNode is a Cache Group of a Topology, in one of the CDNs of the
+// Delivery Services which use the Topology.
+type topologyNode struct {
+	topology   string
+	cachegroup string
+	cdn        string
+	edgeTier   bool
+	edges      int
+	mids       int
+}
+
+// topologyNodeViolation returns the violation of the Topology Cache Group, if
+// it doesn't have servers of the right types.
+func topologyNodeViolation(n topologyNode) *tc.ConsistencyViolation {
+	var msg string
+	if n.edgeTier && n.edges == 0 {
+		msg = fmt.Sprintf("edge-tier Cache Group has no %s-type servers in the CDN (it has %d %s-type servers)", tc.EdgeTypePrefix, n.mids, tc.MidTypePrefix)
+	} else if !n.edgeTier && n.edges+n.mids == 0 {
+		msg = fmt.Sprintf("parent Cache Group has no %s- or %s-type servers in the CDN", tc.EdgeTypePrefix, tc.MidTypePrefix)
+	} else {
+		return nil
+	}
+	return &tc.ConsistencyViolation{
+		Check:   tc.ConsistencyCheckTopologyServerTypes,
+		Subject: fmt.Sprintf("Topology: %s, Cache Group: %s, CDN: %s", n.topology, n.cachegroup, n.cdn),
+		Message: msg,
+	}
+}
+
+func checkTopologyServerTypes(tx *sql.Tx) ([]tc.ConsistencyViolation, error) {
+	rows, err := tx.Query(topologyNodesQuery, tc.EdgeTypePrefix, tc.MidTypePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("querying topology nodes: %w", err)
+	}
+	defer log.Close(rows, "closing topology nodes rows")
+
+	violations := []tc.ConsistencyViolation{}
+	for rows.Next() {
+		var n topologyNode
+		if err := rows.Scan(&n.topology, &n.cachegroup, &n.cdn, &n.edgeTier, &n.edges, &n.mids); err != nil {
+			return nil, fmt.Errorf("scanning topology nodes: %w", err)
+		}
+		if v := topologyNodeViolation(n); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	return violations, rows.Err()
+}
+
+func checkRequiredCapabilities(tx *sql.Tx) ([]tc.ConsistencyViolation, error) {
+	violations := []tc.ConsistencyViolation{}
+
+	rows, err := tx.Query(unsatisfiedTopologyCapabilitiesQuery, tc.EdgeTypePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("querying unsatisfied topology capabilities: %w", err)
+	}
+	defer log.Close(rows, "closing unsatisfied topology capabilities rows")
+	for rows.Next() {
+		var xmlID, cachegroup string
+		if err := rows.Scan(&xmlID, &cachegroup); err != nil {
+			return nil, fmt.Errorf("scanning unsatisfied topology capabilities: %w", err)
+		}
+		violations = append(violations, tc.ConsistencyViolation{
+			Check:   tc.ConsistencyCheckRequiredCapabilities,
+			Subject: fmt.Sprintf("Delivery Service: %s, Cache Group: %s", xmlID, cachegroup),
+			Message: "no server of the edge-tier Cache Group in the Delivery Service's CDN has all of its required capabilities",
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating unsatisfied topology capabilities: %w", err)
+	}
+
+	rows, err = tx.Query(unsatisfiedAssignedCapabilitiesQuery, tc.EdgeTypePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("querying unsatisfied assigned server capabilities: %w", err)
+	}
+	defer log.Close(rows, "closing unsatisfied assigned server capabilities rows")
+	for rows.Next() {
+		var xmlID, hostName string
+		var missing []string
+		if err := rows.Scan(&xmlID, &hostName, pq.Array(&missing)); err != nil {
+			return nil, fmt.Errorf("scanning unsatisfied assigned server capabilities: %w", err)
+		}
+		violations = append(violations, tc.ConsistencyViolation{
+			Check:   tc.ConsistencyCheckRequiredCapabilities,
+			Subject: fmt.Sprintf("Delivery Service: %s, server: %s", xmlID, hostName),
+			Message: fmt.Sprintf("assigned server lacks required capabilities %v", missing),
+		})
+	}
+	return violations, rows.Err()
+}
+
+// snapshotViolation returns the violation of the CDN's Snapshot, if the CDN
+// has none, or if it's older than more than maxChanges changes.
+func snapshotViolation(cdn string, snapshotted bool, changes int, maxChanges int) *tc.ConsistencyViolation {
+	var msg string
+	if !snapshotted {
+		msg = "CDN has Delivery Services or servers, but no Snapshot"
+	} else if changes > maxChanges {
+		msg = fmt.Sprintf("Snapshot is %d changes old, more than the maximum of %d", changes, maxChanges)
+	} else {
+		return nil
+	}
+	return &tc.ConsistencyViolation{
+		Check:   tc.ConsistencyCheckSnapshotStaleness,
+		Subject: "CDN: " + cdn,
+		Message: msg,
+	}
+}
+
+func checkSnapshotStaleness(tx *sql.Tx, maxChanges int) ([]tc.ConsistencyViolation, error) {
+	violations := []tc.ConsistencyViolation{}
+	if maxChanges <= 0 {
+		return violations, nil
+	}
+	rows, err := tx.Query(snapshotChangesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("querying changes since snapshots: %w", err)
+	}
+	defer log.Close(rows, "closing changes since snapshots rows")
+	for rows.Next() {
+		var cdn string
+		var snapshotted bool
+		var changes sql.NullInt64
+		if err := rows.Scan(&cdn, &snapshotted, &changes); err != nil {
+			return nil, fmt.Errorf("scanning changes since snapshots: %w", err)
+		}
+		if v := snapshotViolation(cdn, snapshotted, int(changes.Int64), maxChanges); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+	return violations, rows.Err()
+}
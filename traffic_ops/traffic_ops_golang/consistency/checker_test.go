@@ -0,0 +1,71 @@
+package consistency
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestTopologyNodeViolation(t *testing.T) {
+	type testCase struct {
+		node      topologyNode
+		violation bool
+	}
+	testCases := []testCase{
+		{topologyNode{edgeTier: true, edges: 1}, false},
+		{topologyNode{edgeTier: true, mids: 2}, true},
+		{topologyNode{edgeTier: true}, true},
+		{topologyNode{edgeTier: false, mids: 1}, false},
+		{topologyNode{edgeTier: false, edges: 1}, false},
+		{topologyNode{edgeTier: false}, true},
+	}
+	for _, c := range testCases {
+		c.node.topology = "top"
+		c.node.cachegroup = "cg"
+		c.node.cdn = "cdn"
+		v := topologyNodeViolation(c.node)
+		if c.violation && v == nil {
+			t.Errorf("expected violation for %+v, got none", c.node)
+		} else if !c.violation && v != nil {
+			t.Errorf("expected no violation for %+v, got: %s", c.node, v.Message)
+		}
+		if v != nil && v.Subject != "Topology: top, Cache Group: cg, CDN: cdn" {
+			t.Errorf("unexpected violation subject: %s", v.Subject)
+		}
+	}
+}
+
+func TestSnapshotViolation(t *testing.T) {
+	if v := snapshotViolation("cdn", true, 100, 100); v != nil {
+		t.Errorf("expected no violation at the maximum number of changes, got: %s", v.Message)
+	}
+	v := snapshotViolation("cdn", true, 101, 100)
+	if v == nil {
+		t.Fatal("expected violation beyond the maximum number of changes, got none")
+	}
+	if v.Check != tc.ConsistencyCheckSnapshotStaleness || v.Subject != "CDN: cdn" {
+		t.Errorf("unexpected violation: %+v", *v)
+	}
+	if v := snapshotViolation("cdn", false, 0, 100); v == nil {
+		t.Error("expected violation for a CDN without a Snapshot, got none")
+	}
+}
@@ -0,0 +1,108 @@
+package consistency
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// consistency.go defines the handler of the /system/consistency endpoint.
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+)
+
+const selectCheckedAtQuery = `
+SELECT checked_at
+FROM consistency_check_run
+WHERE id = 1
+`
+
+const selectViolationsQuery = `
+SELECT check_name, subject, message, first_detected, last_detected
+FROM consistency_violation
+WHERE $1 = '' OR check_name = $1
+ORDER BY check_name, subject
+`
+
+// checks are the names of the consistency checks.
+var checks = map[tc.ConsistencyCheck]struct{}{
+	tc.ConsistencyCheckTopologyServerTypes:  {},
+	tc.ConsistencyCheckRequiredCapabilities: {},
+	tc.ConsistencyCheckSnapshotStaleness:    {},
+}
+
+// Get handles GET requests to /system/consistency, returning the violations
+// found by the consistency checker when it last ran, each with a warning
+// alert.
+func Get(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	check := tc.ConsistencyCheck(inf.Params[tc.ConsistencyCheckQueryParam])
+	if _, ok := checks[check]; check != "" && !ok {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("'%s' must be one of '%s', '%s', or '%s'", tc.ConsistencyCheckQueryParam, tc.ConsistencyCheckTopologyServerTypes, tc.ConsistencyCheckRequiredCapabilities, tc.ConsistencyCheckSnapshotStaleness), nil)
+		return
+	}
+
+	report := tc.ConsistencyReport{Violations: []tc.ConsistencyViolation{}}
+	var checkedAt *time.Time
+	if err := tx.QueryRow(selectCheckedAtQuery).Scan(&checkedAt); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("querying consistency check run: %w", err))
+		return
+	}
+	report.CheckedAt = checkedAt
+
+	rows, err := tx.Query(selectViolationsQuery, check)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("querying consistency violations: %w", err))
+		return
+	}
+	defer log.Close(rows, "closing consistency violations rows")
+	for rows.Next() {
+		var v tc.ConsistencyViolation
+		if err := rows.Scan(&v.Check, &v.Subject, &v.Message, &v.FirstDetected, &v.LastDetected); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("scanning consistency violations: %w", err))
+			return
+		}
+		report.Violations = append(report.Violations, v)
+	}
+	if err := rows.Err(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("iterating consistency violations: %w", err))
+		return
+	}
+
+	if len(report.Violations) == 0 {
+		api.WriteResp(w, r, report)
+		return
+	}
+	alerts := tc.Alerts{}
+	for _, v := range report.Violations {
+		alerts.AddNewAlert(tc.WarnLevel, fmt.Sprintf("consistency check %s: %s: %s", v.Check, v.Subject, v.Message))
+	}
+	api.WriteAlertsObj(w, r, http.StatusOK, alerts, report)
+}
@@ -20,10 +20,8 @@ package coordinate
  */
 
 import (
-	"net/http"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
@@ -34,60 +32,48 @@ import (
 	validation "github.com/go-ozzo/ozzo-validation"
 )
 
-// we need a type alias to define functions on
-type TOCoordinate struct {
-	api.APIInfoImpl `json:"-"`
-	tc.CoordinateNullable
-}
-
-func (v *TOCoordinate) SetLastUpdated(t tc.TimeNoMod) { v.LastUpdated = &t }
-func (v *TOCoordinate) InsertQuery() string           { return insertQuery() }
-func (v *TOCoordinate) NewReadObj() interface{}       { return &tc.CoordinateNullable{} }
-func (v *TOCoordinate) SelectQuery() string           { return selectQuery() }
-func (v *TOCoordinate) ParamColumns() map[string]dbhelpers.WhereColumnInfo {
-	return map[string]dbhelpers.WhereColumnInfo{
+// Resource handles the /coordinates endpoint.
+var Resource = api.Resource[coordinate, *coordinate]{
+	Type:              "coordinate",
+	Table:             "coordinate",
+	Columns:           columns,
+	From:              "FROM coordinate c",
+	LastUpdatedColumn: "last_updated",
+	Filters: map[string]dbhelpers.WhereColumnInfo{
 		"id":   dbhelpers.WhereColumnInfo{Column: "id", Checker: api.IsInt},
 		"name": dbhelpers.WhereColumnInfo{Column: "name"},
-	}
-}
-
-func (v *TOCoordinate) GetLastUpdated() (*time.Time, bool, error) {
-	return api.GetLastUpdated(v.APIInfo().Tx, *v.ID, "coordinate")
+	},
+	DefaultSort: "name",
+	InsertQuery: insertQuery,
+	UpdateQuery: updateQuery,
+	DeleteQuery: deleteQuery,
+	Validate:    validate,
 }
 
-func (v *TOCoordinate) UpdateQuery() string { return updateQuery() }
-func (v *TOCoordinate) DeleteQuery() string { return deleteQuery() }
-func (coordinate TOCoordinate) GetKeyFieldsInfo() []api.KeyFieldInfo {
-	return []api.KeyFieldInfo{{Field: "id", Func: api.GetIntKey}}
+type coordinate struct {
+	tc.CoordinateNullable
 }
 
-// Implementation of the Identifier, Validator interface functions
-func (coordinate TOCoordinate) GetKeys() (map[string]interface{}, bool) {
-	if coordinate.ID == nil {
-		return map[string]interface{}{"id": 0}, false
+func (c *coordinate) GetID() (int, bool) {
+	if c.ID == nil {
+		return 0, false
 	}
-	return map[string]interface{}{"id": *coordinate.ID}, true
+	return *c.ID, true
 }
 
-func (coordinate TOCoordinate) GetAuditName() string {
-	if coordinate.Name != nil {
-		return *coordinate.Name
+func (c *coordinate) SetID(id int)                  { c.ID = &id }
+func (c *coordinate) SetLastUpdated(t tc.TimeNoMod) { c.LastUpdated = &t }
+
+func (c *coordinate) GetAuditName() string {
+	if c.Name != nil {
+		return *c.Name
 	}
-	if coordinate.ID != nil {
-		return strconv.Itoa(*coordinate.ID)
+	if c.ID != nil {
+		return strconv.Itoa(*c.ID)
 	}
 	return "0"
 }
 
-func (coordinate TOCoordinate) GetType() string {
-	return "coordinate"
-}
-
-func (coordinate *TOCoordinate) SetKeys(keys map[string]interface{}) {
-	i, _ := keys["id"].(int) //this utilizes the non panicking type assertion, if the thrown away ok variable is false i will be the zero of the type, 0 here.
-	coordinate.ID = &i
-}
-
 func isValidCoordinateChar(r rune) bool {
 	if r >= 'a' && r <= 'z' {
 		return true
@@ -110,69 +96,38 @@ func IsValidCoordinateName(str string) bool {
 	return i == -1
 }
 
-// Validate fulfills the api.Validator interface.
-func (coordinate TOCoordinate) Validate() (error, error) {
+// validate checks a Coordinate decoded from a request body.
+func validate(_ *api.APIInfo, c *coordinate) (error, error) {
 	validName := validation.NewStringRule(IsValidCoordinateName, "invalid characters found - Use alphanumeric . or - or _ .")
 	latitudeErr := "Must be a floating point number within the range +-90"
 	longitudeErr := "Must be a floating point number within the range +-180"
 	errs := validation.Errors{
-		"name":      validation.Validate(coordinate.Name, validation.Required, validName),
-		"latitude":  validation.Validate(coordinate.Latitude, validation.Min(-90.0).Error(latitudeErr), validation.Max(90.0).Error(latitudeErr)),
-		"longitude": validation.Validate(coordinate.Longitude, validation.Min(-180.0).Error(longitudeErr), validation.Max(180.0).Error(longitudeErr)),
+		"name":      validation.Validate(c.Name, validation.Required, validName),
+		"latitude":  validation.Validate(c.Latitude, validation.Min(-90.0).Error(latitudeErr), validation.Max(90.0).Error(latitudeErr)),
+		"longitude": validation.Validate(c.Longitude, validation.Min(-180.0).Error(longitudeErr), validation.Max(180.0).Error(longitudeErr)),
 	}
 	return util.JoinErrs(tovalidate.ToErrors(errs)), nil
 }
 
-func (coord *TOCoordinate) Create() (error, error, int) { return api.GenericCreate(coord) }
-func (coord *TOCoordinate) Read(h http.Header, useIMS bool) ([]interface{}, error, error, int, *time.Time) {
-	api.DefaultSort(coord.APIInfo(), "name")
-	return api.GenericRead(h, coord, useIMS)
-}
-func (v *TOCoordinate) SelectMaxLastUpdatedQuery(where, orderBy, pagination, tableName string) string {
-	return `SELECT max(t) from (
-		SELECT max(last_updated) as t from ` + tableName + ` c ` + where + orderBy + pagination +
-		` UNION ALL
-	select max(last_updated) as t from last_deleted l where l.table_name='` + tableName + `') as res`
-}
-
-func (coord *TOCoordinate) Update(h http.Header) (error, error, int) {
-	return api.GenericUpdate(h, coord)
-}
-func (coord *TOCoordinate) Delete() (error, error, int) { return api.GenericDelete(coord) }
-
-func selectQuery() string {
-	query := `SELECT
-id,
+const columns = `id,
 latitude,
 longitude,
 last_updated,
-name
+name`
 
-FROM coordinate c`
-	return query
-}
-
-func updateQuery() string {
-	query := `UPDATE
+const updateQuery = `UPDATE
 coordinate SET
 latitude=:latitude,
 longitude=:longitude,
 name=:name
 WHERE id=:id RETURNING last_updated`
-	return query
-}
 
-func insertQuery() string {
-	query := `INSERT INTO coordinate (
+const insertQuery = `INSERT INTO coordinate (
 latitude,
 longitude,
 name) VALUES (
 :latitude,
 :longitude,
 :name) RETURNING id,last_updated`
-	return query
-}
 
-func deleteQuery() string {
-	return `DELETE FROM coordinate WHERE id = :id`
-}
+const deleteQuery = `DELETE FROM coordinate WHERE id = :id`
@@ -86,11 +86,7 @@ func TestReadCoordinates(t *testing.T) {
 	mock.ExpectCommit()
 
 	reqInfo := api.APIInfo{Tx: db.MustBegin(), Params: map[string]string{"id": "1"}}
-	obj := TOCoordinate{
-		api.APIInfoImpl{ReqInfo: &reqInfo},
-		tc.CoordinateNullable{},
-	}
-	coordinates, userErr, sysErr, _, _ := obj.Read(nil, false)
+	coordinates, _, userErr, sysErr, _ := Resource.Read(&reqInfo, nil, false)
 	if userErr != nil || sysErr != nil {
 		t.Errorf("Read expected: no errors, actual: %v %v", userErr, sysErr)
 	}
@@ -99,42 +95,18 @@ func TestReadCoordinates(t *testing.T) {
 	}
 }
 
-func TestFuncs(t *testing.T) {
-	if strings.Index(selectQuery(), "SELECT") != 0 {
-		t.Errorf("expected selectQuery to start with SELECT")
-	}
-	if strings.Index(insertQuery(), "INSERT") != 0 {
+func TestQueries(t *testing.T) {
+	if strings.Index(insertQuery, "INSERT") != 0 {
 		t.Errorf("expected insertQuery to start with INSERT")
 	}
-	if strings.Index(updateQuery(), "UPDATE") != 0 {
+	if strings.Index(updateQuery, "UPDATE") != 0 {
 		t.Errorf("expected updateQuery to start with UPDATE")
 	}
-	if strings.Index(deleteQuery(), "DELETE") != 0 {
+	if strings.Index(deleteQuery, "DELETE") != 0 {
 		t.Errorf("expected deleteQuery to start with DELETE")
 	}
 }
 
-func TestInterfaces(t *testing.T) {
-	var i interface{}
-	i = &TOCoordinate{}
-
-	if _, ok := i.(api.Creator); !ok {
-		t.Errorf("coordinate must be creator")
-	}
-	if _, ok := i.(api.Reader); !ok {
-		t.Errorf("coordinate must be reader")
-	}
-	if _, ok := i.(api.Updater); !ok {
-		t.Errorf("coordinate must be updater")
-	}
-	if _, ok := i.(api.Deleter); !ok {
-		t.Errorf("coordinate must be deleter")
-	}
-	if _, ok := i.(api.Identifier); !ok {
-		t.Errorf("coordinate must be Identifier")
-	}
-}
-
 func TestValidate(t *testing.T) {
 	// invalid name, latitude, and longitude
 	id := 1
@@ -142,13 +114,13 @@ func TestValidate(t *testing.T) {
 	la := -190.0
 	lo := -190.0
 	lu := tc.TimeNoMod{Time: time.Now()}
-	c := TOCoordinate{CoordinateNullable: tc.CoordinateNullable{ID: &id,
+	c := coordinate{CoordinateNullable: tc.CoordinateNullable{ID: &id,
 		Name:        &nm,
 		Latitude:    &la,
 		Longitude:   &lo,
 		LastUpdated: &lu,
 	}}
-	err, _ := c.Validate()
+	err, _ := validate(nil, &c)
 	errs := util.JoinErrsStr(test.SortErrors(test.SplitErrors(err)))
 
 	expectedErrs := util.JoinErrsStr([]error{
@@ -165,13 +137,13 @@ func TestValidate(t *testing.T) {
 	nm = "This.is.2.a-Valid---Coordinate."
 	la = 90.0
 	lo = 90.0
-	c = TOCoordinate{CoordinateNullable: tc.CoordinateNullable{ID: &id,
+	c = coordinate{CoordinateNullable: tc.CoordinateNullable{ID: &id,
 		Name:        &nm,
 		Latitude:    &la,
 		Longitude:   &lo,
 		LastUpdated: &lu,
 	}}
-	err, _ = c.Validate()
+	err, _ = validate(nil, &c)
 	if err != nil {
 		t.Errorf("expected nil, got %s", err)
 	}
@@ -0,0 +1,302 @@
+package crconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// section is an independently generated part of a CRConfig. Sections are
+// assembled concurrently, and each is only regenerated when the tables from
+// which it is generated have changed since it was last generated for the
+// same CDN.
+type section struct {
+	Name string
+	// Tables are all of the tables the section is generated from. A table
+	// missing from this list means changes to it won't be reflected in
+	// snapshots until some other listed table changes.
+	Tables []string
+	// Build generates the section, setting only its own fields of the
+	// given CRConfig.
+	Build func(tx *sql.Tx, crc *tc.CRConfig) error
+}
+
+// txSource provides transactions which all see the same data, so that the
+// parts of a snapshot may be read concurrently without losing the
+// consistency of reading them all from a single transaction.
+//
+// This is done by exporting the snapshot of the original transaction and
+// importing it into each new transaction; see
+// https://www.postgresql.org/docs/current/functions-admin.html#FUNCTIONS-SNAPSHOT-SYNCHRONIZATION.
+// Note that the new transactions cannot see any uncommitted writes made by
+// the original transaction.
+type txSource struct {
+	ctx        context.Context
+	db         *sql.DB
+	tx         *sql.Tx
+	snapshotID string
+}
+
+// snapshotIDRegex matches the identifiers returned by pg_export_snapshot,
+// which must be interpolated into a query because SET TRANSACTION doesn't
+// accept bind parameters.
+var snapshotIDRegex = regexp.MustCompile(`^[0-9A-Fa-f-]+$`)
+
+// newTxSource returns a txSource sharing the snapshot of tx. If db is nil,
+// the returned txSource will run everything sequentially in tx itself.
+func newTxSource(ctx context.Context, db *sql.DB, tx *sql.Tx) (*txSource, error) {
+	src := &txSource{ctx: ctx, db: db, tx: tx}
+	if db == nil {
+		return src, nil
+	}
+	if err := tx.QueryRowContext(ctx, `SELECT pg_export_snapshot()`).Scan(&src.snapshotID); err != nil {
+		return nil, errors.New("exporting transaction snapshot: " + err.Error())
+	}
+	if !snapshotIDRegex.MatchString(src.snapshotID) {
+		return nil, fmt.Errorf("exporting transaction snapshot: malformed snapshot ID '%s'", src.snapshotID)
+	}
+	return src, nil
+}
+
+// run calls each of the given functions with a transaction sharing the
+// snapshot of the txSource's original transaction - concurrently, if the
+// txSource has a database from which to open new transactions - and returns
+// the errors of all those that failed.
+func (src *txSource) run(fns ...func(*sql.Tx) error) error {
+	if src.db == nil {
+		errs := []error{}
+		for _, fn := range fns {
+			if err := fn(src.tx); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return util.JoinErrs(errs)
+	}
+
+	errs := make([]error, len(fns))
+	wg := sync.WaitGroup{}
+	for i, fn := range fns {
+		wg.Add(1)
+		go func(i int, fn func(*sql.Tx) error) {
+			defer wg.Done()
+			errs[i] = src.runOne(fn)
+		}(i, fn)
+	}
+	wg.Wait()
+	return util.JoinErrs(errs)
+}
+
+func (src *txSource) runOne(fn func(*sql.Tx) error) error {
+	tx, err := src.db.BeginTx(src.ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return errors.New("beginning transaction: " + err.Error())
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Errorln("rolling back snapshot section transaction: " + err.Error())
+		}
+	}()
+	if _, err := tx.ExecContext(src.ctx, `SET TRANSACTION SNAPSHOT '`+src.snapshotID+`'`); err != nil {
+		return errors.New("importing transaction snapshot: " + err.Error())
+	}
+	return fn(tx)
+}
+
+// cachedSection is a generated section, along with the fingerprint of the
+// tables it was generated from.
+type cachedSection struct {
+	Fingerprint string
+	// JSON is the encoded section. Storing it encoded rather than as the
+	// CRConfig itself guarantees that nothing can modify the cached section
+	// through a CRConfig returned by Make.
+	JSON []byte
+}
+
+// sectionCache holds the most recently generated sections of each CDN, keyed
+// by CDN name and then section name.
+var sectionCache = struct {
+	sync.Mutex
+	sections map[string]map[string]cachedSection
+}{sections: map[string]map[string]cachedSection{}}
+
+func getCachedSection(cdn, name, fingerprint string) ([]byte, bool) {
+	sectionCache.Lock()
+	defer sectionCache.Unlock()
+	cached, ok := sectionCache.sections[cdn][name]
+	if !ok || cached.Fingerprint != fingerprint {
+		return nil, false
+	}
+	return cached.JSON, true
+}
+
+func setCachedSection(cdn, name, fingerprint string, bts []byte) {
+	sectionCache.Lock()
+	defer sectionCache.Unlock()
+	if _, ok := sectionCache.sections[cdn]; !ok {
+		sectionCache.sections[cdn] = map[string]cachedSection{}
+	}
+	sectionCache.sections[cdn][name] = cachedSection{Fingerprint: fingerprint, JSON: bts}
+}
+
+// tableFingerprints returns a value for each of the given tables which
+// changes whenever any row of the table is inserted, updated, or deleted: a
+// digest of the contents of all of its rows.
+//
+// This hashes the contents of every row rather than relying on last_updated
+// columns, both because not every table has one and because a row's
+// last_updated time is the start of the transaction which changed it, which
+// may be older than the latest last_updated time already seen when that
+// transaction commits.
+//
+// The table names must be trusted, as they are interpolated into the query.
+func tableFingerprints(tx *sql.Tx, tables []string) (map[string]string, error) {
+	if len(tables) == 0 {
+		return map[string]string{}, nil
+	}
+	queries := make([]string, 0, len(tables))
+	for _, table := range tables {
+		queries = append(queries, `SELECT '`+table+`', (SELECT md5(COALESCE(string_agg(t::text, ',' ORDER BY t::text), '')) FROM `+table+` AS t)`)
+	}
+	rows, err := tx.Query(strings.Join(queries, "\nUNION ALL\n"))
+	if err != nil {
+		return nil, errors.New("querying table fingerprints: " + err.Error())
+	}
+	defer log.Close(rows, "closing table fingerprint rows")
+
+	fingerprints := make(map[string]string, len(tables))
+	for rows.Next() {
+		table := ""
+		digest := ""
+		if err := rows.Scan(&table, &digest); err != nil {
+			return nil, errors.New("scanning table fingerprints: " + err.Error())
+		}
+		fingerprints[table] = digest
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over table fingerprint rows: " + err.Error())
+	}
+	return fingerprints, nil
+}
+
+// sectionFingerprint returns the fingerprint of all of the tables from which
+// the given section is generated.
+func sectionFingerprint(tx *sql.Tx, s section) (string, error) {
+	fingerprints, err := tableFingerprints(tx, s.Tables)
+	if err != nil {
+		return "", err
+	}
+	parts := make([]string, 0, len(s.Tables))
+	for _, table := range s.Tables {
+		parts = append(parts, table+"="+fingerprints[table])
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// buildSection sets the given section's fields of crc, either from the cache
+// if none of its tables have changed, or by generating it.
+func buildSection(tx *sql.Tx, cdn string, s section, crc *tc.CRConfig) error {
+	fingerprint, err := sectionFingerprint(tx, s)
+	if err != nil {
+		return err
+	}
+	if bts, ok := getCachedSection(cdn, s.Name, fingerprint); ok {
+		if err := json.Unmarshal(bts, crc); err != nil {
+			return errors.New("decoding cached section: " + err.Error())
+		}
+		log.Debugf("CRConfig for CDN '%s': section '%s' unchanged, using cached copy", cdn, s.Name)
+		return nil
+	}
+
+	start := time.Now()
+	if err := s.Build(tx, crc); err != nil {
+		return err
+	}
+	log.Infof("CRConfig for CDN '%s': generated section '%s' in %v", cdn, s.Name, time.Since(start))
+
+	bts, err := json.Marshal(crc)
+	if err != nil {
+		return errors.New("encoding section for caching: " + err.Error())
+	}
+	setCachedSection(cdn, s.Name, fingerprint, bts)
+	return nil
+}
+
+// assembleSections builds each of the given sections in its own transaction
+// from src, and merges them into crc.
+func assembleSections(src *txSource, cdn string, sections []section, crc *tc.CRConfig) error {
+	parts := make([]tc.CRConfig, len(sections))
+	fns := make([]func(*sql.Tx) error, 0, len(sections))
+	for i, s := range sections {
+		i, s := i, s
+		fns = append(fns, func(tx *sql.Tx) error {
+			if err := buildSection(tx, cdn, s, &parts[i]); err != nil {
+				return fmt.Errorf("section '%s': %w", s.Name, err)
+			}
+			return nil
+		})
+	}
+	if err := src.run(fns...); err != nil {
+		return err
+	}
+	for _, part := range parts {
+		mergeSection(crc, part)
+	}
+	return nil
+}
+
+// mergeSection copies all of the fields set by a section into crc.
+func mergeSection(crc *tc.CRConfig, part tc.CRConfig) {
+	if part.Config != nil {
+		crc.Config = part.Config
+	}
+	if part.ContentServers != nil {
+		crc.ContentServers = part.ContentServers
+	}
+	if part.ContentRouters != nil {
+		crc.ContentRouters = part.ContentRouters
+	}
+	if part.DeliveryServices != nil {
+		crc.DeliveryServices = part.DeliveryServices
+	}
+	if part.EdgeLocations != nil {
+		crc.EdgeLocations = part.EdgeLocations
+	}
+	if part.RouterLocations != nil {
+		crc.RouterLocations = part.RouterLocations
+	}
+	if part.Monitors != nil {
+		crc.Monitors = part.Monitors
+	}
+	if part.Topologies != nil {
+		crc.Topologies = part.Topologies
+	}
+}
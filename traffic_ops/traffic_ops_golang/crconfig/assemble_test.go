@@ -0,0 +1,229 @@
+package crconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"crypto/md5"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+// serverSnapshots are the rows of the server table, as text, as seen by
+// successive snapshots. Between them, a transaction which started before the
+// latest change seen by the first snapshot - and so set an older last_updated
+// time - committed a change, leaving the table's latest last_updated time the
+// same.
+var serverSnapshots = [][]string{
+	{`(1,edge,"2022-07-07 12:00:02+00")`, `(2,mid,"2022-07-07 12:00:00+00")`},
+	{`(1,edge,"2022-07-07 12:00:02+00")`, `(2,mid-renamed,"2022-07-07 12:00:01+00")`},
+}
+
+// tableDigest returns the digest which the fingerprint query computes of a
+// table with the given rows.
+func tableDigest(rows ...string) string {
+	sorted := append([]string(nil), rows...)
+	sort.Strings(sorted)
+	return fmt.Sprintf("%x", md5.Sum([]byte(strings.Join(sorted, ","))))
+}
+
+// mockTableFingerprints expects the query of the fingerprints of the cdn and
+// server tables, in which the server table has the given rows.
+func mockTableFingerprints(mock sqlmock.Sqlmock, serverRows []string) {
+	rows := sqlmock.NewRows([]string{"table", "md5"})
+	rows = rows.AddRow("cdn", tableDigest(`(1,cdn1)`))
+	rows = rows.AddRow("server", tableDigest(serverRows...))
+	mock.ExpectQuery("SELECT 'cdn', \\(SELECT md5\\(COALESCE\\(string_agg\\(t::text, ',' ORDER BY t::text\\), ''\\)\\) FROM cdn AS t\\)").WillReturnRows(rows)
+}
+
+func TestTableFingerprintsLateCommit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	for _, serverRows := range serverSnapshots {
+		rows := sqlmock.NewRows([]string{"table", "md5"})
+		rows = rows.AddRow("server", tableDigest(serverRows...))
+		rows = rows.AddRow("cdn_soa", tableDigest())
+		mock.ExpectQuery("md5").WillReturnRows(rows)
+	}
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("creating transaction: %v", err)
+	}
+	before, err := tableFingerprints(tx, []string{"server", "cdn_soa"})
+	if err != nil {
+		t.Fatalf("getting table fingerprints: %v", err)
+	}
+	after, err := tableFingerprints(tx, []string{"server", "cdn_soa"})
+	if err != nil {
+		t.Fatalf("getting table fingerprints: %v", err)
+	}
+	if before["server"] == after["server"] {
+		t.Errorf("expected a change committed with an older last_updated time to change the fingerprint of a table, actual: %s", after["server"])
+	}
+	if before["cdn_soa"] != after["cdn_soa"] {
+		t.Errorf("expected the fingerprint of an unchanged empty table to stay the same, actual: %s, %s", before["cdn_soa"], after["cdn_soa"])
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Errorf("committing transaction: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestBuildSectionCaching(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mockTableFingerprints(mock, serverSnapshots[0])
+	mockTableFingerprints(mock, serverSnapshots[0])
+	mockTableFingerprints(mock, serverSnapshots[1])
+	mock.ExpectCommit()
+
+	dbCtx, cancelTx := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancelTx()
+	tx, err := db.BeginTx(dbCtx, nil)
+	if err != nil {
+		t.Fatalf("creating transaction: %v", err)
+	}
+
+	builds := 0
+	s := section{
+		Name:   "test",
+		Tables: []string{"cdn", "server"},
+		Build: func(tx *sql.Tx, crc *tc.CRConfig) error {
+			builds++
+			crc.Monitors = map[string]tc.CRConfigMonitor{"monitor": {Profile: new(string)}}
+			return nil
+		},
+	}
+	cdn := "TestBuildSectionCaching"
+
+	crc := tc.CRConfig{}
+	if err := buildSection(tx, cdn, s, &crc); err != nil {
+		t.Fatalf("building section: %v", err)
+	}
+	if builds != 1 || len(crc.Monitors) != 1 {
+		t.Fatalf("expected the section to be built once, actual builds: %d, monitors: %v", builds, crc.Monitors)
+	}
+
+	crc = tc.CRConfig{}
+	if err := buildSection(tx, cdn, s, &crc); err != nil {
+		t.Fatalf("building section: %v", err)
+	}
+	if builds != 1 {
+		t.Errorf("expected an unchanged section to be taken from the cache, actual builds: %d", builds)
+	}
+	if _, ok := crc.Monitors["monitor"]; !ok {
+		t.Errorf("expected the cached section to be decoded, actual: %v", crc.Monitors)
+	}
+
+	crc = tc.CRConfig{}
+	if err := buildSection(tx, cdn, s, &crc); err != nil {
+		t.Fatalf("building section: %v", err)
+	}
+	if builds != 2 {
+		t.Errorf("expected a section whose tables changed to be rebuilt, actual builds: %d", builds)
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Errorf("committing transaction: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestAssembleSectionsSequential(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mockTableFingerprints(mock, serverSnapshots[0])
+	mockTableFingerprints(mock, serverSnapshots[0])
+	mock.ExpectCommit()
+
+	dbCtx, cancelTx := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancelTx()
+	tx, err := db.BeginTx(dbCtx, nil)
+	if err != nil {
+		t.Fatalf("creating transaction: %v", err)
+	}
+
+	src, err := newTxSource(dbCtx, nil, tx)
+	if err != nil {
+		t.Fatalf("creating transaction source: %v", err)
+	}
+	sections := []section{
+		{
+			Name:   "routers",
+			Tables: []string{"cdn", "server"},
+			Build: func(tx *sql.Tx, crc *tc.CRConfig) error {
+				crc.ContentRouters = map[string]tc.CRConfigRouter{"router": {}}
+				return nil
+			},
+		},
+		{
+			Name:   "topologies",
+			Tables: []string{"cdn", "server"},
+			Build: func(tx *sql.Tx, crc *tc.CRConfig) error {
+				crc.Topologies = map[string]tc.CRConfigTopology{"topology": {}}
+				return nil
+			},
+		},
+	}
+
+	crc := tc.CRConfig{}
+	if err := assembleSections(src, "TestAssembleSectionsSequential", sections, &crc); err != nil {
+		t.Fatalf("assembling sections: %v", err)
+	}
+	if len(crc.ContentRouters) != 1 || len(crc.Topologies) != 1 {
+		t.Errorf("expected both sections to be merged, actual routers: %v, topologies: %v", crc.ContentRouters, crc.Topologies)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Errorf("committing transaction: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
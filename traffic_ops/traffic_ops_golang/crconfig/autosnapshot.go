@@ -0,0 +1,276 @@
+package crconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+)
+
+const selectSnapshotPolicyCDNsQuery = `
+SELECT cdn FROM cdn_snapshot_policy
+`
+
+// lockSnapshotPolicyQuery locks the snapshot policy of the CDN with the given
+// ID, returning it with the CDN's name and its last snapshot, unless another
+// Traffic Ops instance is applying it.
+const lockSnapshotPolicyQuery = `
+SELECT c.name, p."interval", p.quiet_period, p.max_changes, p.notify, u.id, u.username, p.last_aborted, sn.last_updated
+FROM cdn_snapshot_policy AS p
+JOIN cdn AS c ON c.id = p.cdn
+LEFT JOIN tm_user AS u ON u.id = p.tm_user
+LEFT JOIN snapshot AS sn ON sn.cdn = c.name
+WHERE p.cdn = $1
+FOR UPDATE OF p SKIP LOCKED
+`
+
+// pendingSnapshotChangesQuery selects the number of the CDN's Delivery
+// Services, servers, Static DNS Entries, regular expressions, and Delivery
+// Service server assignments created or modified since the given time, and
+// when the latest of them was.
+const pendingSnapshotChangesQuery = `
+WITH changes AS (
+	SELECT ds.last_updated FROM deliveryservice AS ds WHERE ds.cdn_id = $1 AND ds.last_updated > $2
+	UNION ALL
+	SELECT s.last_updated FROM server AS s WHERE s.cdn_id = $1 AND s.last_updated > $2
+	UNION ALL
+	SELECT e.last_updated
+	FROM staticdnsentry AS e
+	JOIN deliveryservice AS ds ON ds.id = e.deliveryservice
+	WHERE ds.cdn_id = $1 AND e.last_updated > $2
+	UNION ALL
+	SELECT dr.last_updated
+	FROM deliveryservice_regex AS dr
+	JOIN deliveryservice AS ds ON ds.id = dr.deliveryservice
+	WHERE ds.cdn_id = $1 AND dr.last_updated > $2
+	UNION ALL
+	SELECT dss.last_updated
+	FROM deliveryservice_server AS dss
+	JOIN deliveryservice AS ds ON ds.id = dss.deliveryservice
+	WHERE ds.cdn_id = $1 AND dss.last_updated > $2
+)
+SELECT COUNT(*), MAX(last_updated) FROM changes
+`
+
+const updateAutoSnapshotQuery = `
+UPDATE cdn_snapshot_policy
+SET last_auto_snapshot = $2, last_aborted = NULL
+WHERE cdn = $1
+`
+
+const updateAutoSnapshotAbortedQuery = `
+UPDATE cdn_snapshot_policy
+SET last_aborted = $2
+WHERE cdn = $1
+`
+
+const insertAutoSnapshotNotificationQuery = `
+INSERT INTO cdn_notification (cdn, "user", notification)
+VALUES ($1, $2, $3)
+`
+
+// InitAutoSnapshotter starts applying, every interval, the snapshot policies
+// of CDNs: taking a snapshot of each CDN whose policy says one is due, or, if
+// it has more changes pending than its policy allows, posting a CDN
+// notification asking that they be reviewed and snapshotted by hand. If
+// interval is not positive, snapshots are never taken automatically.
+//
+// Taking snapshots automatically is safe with any number of Traffic Ops
+// instances running the snapshotter against the same database.
+func InitAutoSnapshotter(interval time.Duration, db *sql.DB, timeout time.Duration, cfg *config.Config) {
+	if interval <= 0 {
+		log.Infoln("auto snapshot interval is negative, snapshots will not be taken automatically")
+		return
+	}
+	go func() {
+		for {
+			if err := autoSnapshot(db, timeout, cfg); err != nil {
+				log.Errorf("taking automatic snapshots: %v", err)
+			}
+			time.Sleep(interval)
+		}
+	}()
+}
+
+func autoSnapshot(db *sql.DB, timeout time.Duration, cfg *config.Config) error {
+	cdnIDs, err := getSnapshotPolicyCDNs(db, timeout)
+	if err != nil {
+		return err
+	}
+	// Each CDN is snapshotted in its own transaction, so that one slow or
+	// failing snapshot doesn't hold up the others.
+	errs := []error{}
+	for _, cdnID := range cdnIDs {
+		if err := applySnapshotPolicy(db, timeout, cfg, cdnID); err != nil {
+			errs = append(errs, fmt.Errorf("CDN #%d: %w", cdnID, err))
+		}
+	}
+	return util.JoinErrs(errs)
+}
+
+func getSnapshotPolicyCDNs(db *sql.DB, timeout time.Duration) ([]int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	rows, err := db.QueryContext(ctx, selectSnapshotPolicyCDNsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("querying snapshot policies: %w", err)
+	}
+	defer log.Close(rows, "closing snapshot policy rows")
+	cdnIDs := []int{}
+	for rows.Next() {
+		var cdnID int
+		if err := rows.Scan(&cdnID); err != nil {
+			return nil, fmt.Errorf("scanning snapshot policies: %w", err)
+		}
+		cdnIDs = append(cdnIDs, cdnID)
+	}
+	return cdnIDs, rows.Err()
+}
+
+// applySnapshotPolicy takes a snapshot of the CDN with the given ID, if its
+// snapshot policy says one is due and there aren't too many changes pending.
+func applySnapshotPolicy(db *sql.DB, timeout time.Duration, cfg *config.Config, cdnID int) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	commit := false
+	defer func() {
+		if !commit {
+			if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+				log.Errorf("rolling back automatic snapshot transaction: %v", err)
+			}
+		}
+	}()
+
+	var policy tc.CDNSnapshotPolicy
+	var userID *int
+	var lastAborted, lastSnapshot *time.Time
+	err = tx.QueryRow(lockSnapshotPolicyQuery, cdnID).Scan(&policy.CDNName, &policy.IntervalSeconds, &policy.QuietPeriodSeconds, &policy.MaxChanges, &policy.Notify, &userID, &policy.User, &lastAborted, &lastSnapshot)
+	if err == sql.ErrNoRows {
+		// Another instance is applying it, or it was deleted.
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("locking snapshot policy: %w", err)
+	}
+
+	// A CDN that's never been snapshotted has every change pending.
+	since := time.Time{}
+	if lastSnapshot != nil {
+		since = *lastSnapshot
+	}
+	var changes int64
+	var lastChange *time.Time
+	if err := tx.QueryRow(pendingSnapshotChangesQuery, cdnID, since).Scan(&changes, &lastChange); err != nil {
+		return fmt.Errorf("counting changes pending snapshot: %w", err)
+	}
+	now := time.Now()
+	if lastChange == nil || !policy.SnapshotDue(now, lastSnapshot, changes, *lastChange) {
+		return nil
+	}
+
+	cdn := policy.CDNName
+	if userID == nil || policy.User == nil {
+		log.Warnf("snapshot of CDN '%s' is due, but the user who set its snapshot policy no longer exists; it must be set again before snapshots are taken automatically", cdn)
+		return nil
+	}
+	user := auth.CurrentUser{ID: *userID, UserName: *policy.User}
+
+	if policy.TooManyChanges(changes) {
+		// Changes that keep accumulating are only reported once, rather
+		// than on every run, until a snapshot is taken.
+		if lastAborted != nil && (lastSnapshot == nil || lastAborted.After(*lastSnapshot)) {
+			return nil
+		}
+		log.Warnf("automatic snapshot of CDN '%s' aborted: %d changes are pending, more than its snapshot policy's maximum of %d", cdn, changes, *policy.MaxChanges)
+		msg := fmt.Sprintf("Automatic snapshot aborted: %d changes are pending, more than the snapshot policy's maximum of %d. Review them and take a snapshot manually.", changes, *policy.MaxChanges)
+		if _, err := tx.Exec(insertAutoSnapshotNotificationQuery, cdn, user.UserName, msg); err != nil {
+			return fmt.Errorf("posting aborted automatic snapshot notification: %w", err)
+		}
+		if _, err := tx.Exec(updateAutoSnapshotAbortedQuery, cdnID, now); err != nil {
+			return fmt.Errorf("recording aborted automatic snapshot: %w", err)
+		}
+		api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+cdn+", ID: "+strconv.Itoa(cdnID)+", ACTION: Aborted automatic snapshot of "+strconv.FormatInt(changes, 10)+" changes", &user, tx)
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("committing transaction: %w", err)
+		}
+		commit = true
+		return nil
+	}
+
+	// Changes to a locked CDN are presumably in progress, so it's only
+	// snapshotted by the lock holder.
+	userErr, sysErr, _ := dbhelpers.CheckIfCurrentUserHasCdnLock(tx, cdn, user.UserName)
+	if sysErr != nil {
+		return fmt.Errorf("checking CDN lock: %w", sysErr)
+	} else if userErr != nil {
+		log.Infof("automatic snapshot of CDN '%s' is due, but skipped while it's locked: %v", cdn, userErr)
+		return nil
+	}
+
+	src, err := newTxSource(ctx, db, tx)
+	if err != nil {
+		return fmt.Errorf("snapshotting CRConfig and Monitoring: %w", err)
+	}
+	// There's no request host to use, so the tm.url Parameter always is.
+	crConfig, monitoringJSON, err := makeSnapshot(src, cdn, user.UserName, "", cfg.Version, false)
+	if err != nil {
+		return fmt.Errorf("snapshotting CRConfig and Monitoring: %w", err)
+	}
+	if err := Snapshot(tx, crConfig, monitoringJSON); err != nil {
+		return fmt.Errorf("snapshotting CRConfig and Monitoring: %w", err)
+	}
+	if err := updateSOASerial(tx, crConfig); err != nil {
+		return fmt.Errorf("snapshotting CRConfig and Monitoring: %w", err)
+	}
+	// Old certificates are deleted by the next manual snapshot; that needs
+	// Traffic Vault, which isn't available outside of requests.
+	if _, err := tx.Exec(updateAutoSnapshotQuery, cdnID, now); err != nil {
+		return fmt.Errorf("recording automatic snapshot: %w", err)
+	}
+	if policy.Notify {
+		msg := fmt.Sprintf("Automatic snapshot taken of %d changes.", changes)
+		if _, err := tx.Exec(insertAutoSnapshotNotificationQuery, cdn, user.UserName, msg); err != nil {
+			return fmt.Errorf("posting automatic snapshot notification: %w", err)
+		}
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+cdn+", ID: "+strconv.Itoa(cdnID)+", ACTION: Automatic snapshot of CRConfig and Monitor", &user, tx)
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	commit = true
+	log.Infof("automatic snapshot taken of CDN '%s' with %d changes pending", cdn, changes)
+	return nil
+}
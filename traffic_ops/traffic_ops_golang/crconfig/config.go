@@ -24,9 +24,14 @@ import (
 	"errors"
 	"strconv"
 	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
 )
 
-func makeCRConfigConfig(cdn string, tx *sql.Tx, dnssecEnabled bool, domain string) (map[string]interface{}, error) {
+// makeCRConfigConfig returns the "config" section of the CRConfig. If cdnSOA
+// is not nil, it takes the place of the CDN's tld.soa.* Parameters and its
+// tld.ttls.SOA and tld.ttls.NS Parameters.
+func makeCRConfigConfig(cdn string, tx *sql.Tx, dnssecEnabled bool, domain string, cdnSOA *tc.CDNSOA) (map[string]interface{}, error) {
 	configParams, err := getConfigParams(cdn, tx)
 	if err != nil {
 		return nil, errors.New("Error getting router params: " + err.Error())
@@ -64,6 +69,14 @@ func makeCRConfigConfig(cdn string, tx *sql.Tx, dnssecEnabled bool, domain strin
 		}
 	}
 	crConfigConfig["domain_name"] = domain
+	if cdnSOA != nil {
+		soa = soaConfig(cdnSOA.SOA())
+		ttl["SOA"] = strconv.FormatInt(cdnSOA.SOATTLSeconds, 10)
+		ttl["NS"] = strconv.FormatInt(cdnSOA.NSTTLSeconds, 10)
+		if len(cdnSOA.Nameservers) > 0 {
+			crConfigConfig["nameservers"] = cdnSOA.Nameservers
+		}
+	}
 	if len(soa) > 0 {
 		crConfigConfig["soa"] = soa
 	}
@@ -82,6 +95,25 @@ func makeCRConfigConfig(cdn string, tx *sql.Tx, dnssecEnabled bool, domain strin
 	return crConfigConfig, nil
 }
 
+// soaConfig returns the given SOA as the map of tld.soa.* Parameter names -
+// without the prefix - to values that the "config" section uses.
+func soaConfig(soa tc.SOA) map[string]string {
+	m := map[string]string{}
+	for k, v := range map[string]*string{
+		"admin":   soa.Admin,
+		"expire":  soa.ExpireSeconds,
+		"minimum": soa.MinimumSeconds,
+		"refresh": soa.RefreshSeconds,
+		"retry":   soa.RetrySeconds,
+		"serial":  soa.Serial,
+	} {
+		if v != nil {
+			m[k] = *v
+		}
+	}
+	return m
+}
+
 type CRConfigConfigParameter struct {
 	Name  string
 	Value string
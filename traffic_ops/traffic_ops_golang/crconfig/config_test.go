@@ -26,6 +26,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/test"
 
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
@@ -135,7 +136,7 @@ func TestMakeCRConfigConfig(t *testing.T) {
 	}
 	defer tx.Commit()
 
-	actual, err := makeCRConfigConfig(cdn, tx, dnssecEnabled, domain)
+	actual, err := makeCRConfigConfig(cdn, tx, dnssecEnabled, domain, nil)
 
 	if err != nil {
 		t.Fatalf("makeCRConfigConfig err expected: nil, actual: %v", err)
@@ -145,3 +146,67 @@ func TestMakeCRConfigConfig(t *testing.T) {
 		t.Errorf("makeCRConfigConfig expected: %+v, actual: %+v", expected, actual)
 	}
 }
+
+func TestMakeCRConfigConfigCDNSOA(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	cdn := "mycdn"
+	domain := "mycdn.invalid"
+	cdnSOA := &tc.CDNSOA{
+		Admin:              "hostmaster@example.com",
+		SerialPolicy:       tc.CDNSOASerialPolicyIncrement,
+		Serial:             41,
+		RefreshSeconds:     28800,
+		RetrySeconds:       7200,
+		ExpireSeconds:      604800,
+		NegativeTTLSeconds: 30,
+		SOATTLSeconds:      86400,
+		NSTTLSeconds:       3600,
+		Nameservers:        []string{"ns1.example.com"},
+	}
+
+	mock.ExpectBegin()
+	MockGetConfigParams(mock, []CRConfigConfigParameter{
+		{"tld.soa.minimum", "300"},
+		{"tld.soa.admin", "traffic_ops"},
+		{"tld.ttls.NS", "60"},
+		{"tld.ttls.A", "30"},
+	}, cdn)
+	mock.ExpectCommit()
+
+	dbCtx, cancelTx := context.WithTimeout(context.TODO(), 10*time.Second)
+	defer cancelTx()
+	tx, err := db.BeginTx(dbCtx, nil)
+	if err != nil {
+		t.Fatalf("creating transaction: %v", err)
+	}
+	defer tx.Commit()
+
+	actual, err := makeCRConfigConfig(cdn, tx, false, domain, cdnSOA)
+	if err != nil {
+		t.Fatalf("makeCRConfigConfig err expected: nil, actual: %v", err)
+	}
+
+	expectedSOA := map[string]string{
+		"admin":   "hostmaster@example.com",
+		"expire":  "604800",
+		"minimum": "30",
+		"refresh": "28800",
+		"retry":   "7200",
+		"serial":  "42",
+	}
+	if !reflect.DeepEqual(expectedSOA, actual["soa"]) {
+		t.Errorf("makeCRConfigConfig soa expected: %+v, actual: %+v", expectedSOA, actual["soa"])
+	}
+	expectedTTLs := map[string]string{"A": "30", "NS": "3600", "SOA": "86400"}
+	if !reflect.DeepEqual(expectedTTLs, actual["ttls"]) {
+		t.Errorf("makeCRConfigConfig ttls expected: %+v, actual: %+v", expectedTTLs, actual["ttls"])
+	}
+	if !reflect.DeepEqual(cdnSOA.Nameservers, actual["nameservers"]) {
+		t.Errorf("makeCRConfigConfig nameservers expected: %+v, actual: %+v", cdnSOA.Nameservers, actual["nameservers"])
+	}
+}
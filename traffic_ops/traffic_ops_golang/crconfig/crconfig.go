@@ -20,6 +20,7 @@ package crconfig
  */
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"net/url"
@@ -27,34 +28,146 @@ import (
 
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/topology"
 )
 
+// The tables from which each section of the CRConfig is generated.
+var (
+	configSectionTables  = []string{"cdn", "cdn_soa", "server", "profile_parameter", "parameter"}
+	serversSectionTables = []string{
+		"cdn",
+		"server",
+		"interface",
+		"ip_address",
+		"cachegroup",
+		"type",
+		"profile",
+		"status",
+		"profile_parameter",
+		"parameter",
+		"server_server_capability",
+		"deliveryservice_server",
+		"deliveryservice",
+		"deliveryservice_regex",
+		"regex",
+	}
+	locationsSectionTables = []string{
+		"cdn",
+		"server",
+		"type",
+		"status",
+		"cachegroup",
+		"cachegroup_fallbacks",
+		"cachegroup_localization_method",
+		"coordinate",
+	}
+	deliveryServicesSectionTables = []string{
+		"cdn",
+		"cdn_soa",
+		"deliveryservice",
+		"deliveryservice_consistent_hash_query_param",
+		"deliveryservices_required_capability",
+		"deliveryservice_regex",
+		"regex",
+		"type",
+		"profile",
+		"profile_parameter",
+		"parameter",
+		"server",
+		"staticdnsentry",
+	}
+	topologiesSectionTables = []string{"topology", "topology_cachegroup", "cachegroup", "type"}
+)
+
 // Make creates and returns the CRConfig from the database.
-func Make(tx *sql.Tx, cdn, user, toHost, toVersion string, useClientReqHost bool, emulateOldPath bool) (*tc.CRConfig, error) {
+//
+// If db is not nil, the sections of the CRConfig are generated concurrently,
+// each in its own transaction which sees the same data as tx. Sections whose
+// source tables haven't changed since they were last generated for the same
+// CDN are not regenerated.
+func Make(ctx context.Context, db *sql.DB, tx *sql.Tx, cdn, user, toHost, toVersion string, useClientReqHost bool, emulateOldPath bool) (*tc.CRConfig, error) {
+	src, err := newTxSource(ctx, db, tx)
+	if err != nil {
+		return nil, err
+	}
+	return makeFromSource(src, cdn, user, toHost, toVersion, useClientReqHost, emulateOldPath)
+}
+
+func makeFromSource(src *txSource, cdn, user, toHost, toVersion string, useClientReqHost bool, emulateOldPath bool) (*tc.CRConfig, error) {
 	crc := tc.CRConfig{}
-	err := error(nil)
+	tx := src.tx
 
 	cdnDomain, dnssecEnabled, err := getCDNInfo(cdn, tx)
 	if err != nil {
 		return nil, errors.New("Error getting CDN info: " + err.Error())
 	}
-
-	if crc.Config, err = makeCRConfigConfig(cdn, tx, dnssecEnabled, cdnDomain); err != nil {
-		return nil, errors.New("Error getting Config: " + err.Error())
+	var cdnSOA *tc.CDNSOA
+	if soa, ok, err := dbhelpers.GetCDNSOA(tx, tc.CDNName(cdn)); err != nil {
+		return nil, errors.New("getting CDN SOA: " + err.Error())
+	} else if ok {
+		cdnSOA = &soa
 	}
 
-	if crc.ContentServers, crc.ContentRouters, crc.Monitors, err = makeCRConfigServers(cdn, tx, cdnDomain); err != nil {
-		return nil, errors.New("Error getting Servers: " + err.Error())
-	}
-	if crc.EdgeLocations, crc.RouterLocations, err = makeLocations(cdn, tx); err != nil {
-		return nil, errors.New("Error getting Edge Locations: " + err.Error())
-	}
-	if crc.DeliveryServices, err = makeDSes(cdn, cdnDomain, tx); err != nil {
-		return nil, errors.New("Error getting Delivery Services: " + err.Error())
+	sections := []section{
+		{
+			Name:   "config",
+			Tables: configSectionTables,
+			Build: func(tx *sql.Tx, crc *tc.CRConfig) error {
+				var err error
+				if crc.Config, err = makeCRConfigConfig(cdn, tx, dnssecEnabled, cdnDomain, cdnSOA); err != nil {
+					return errors.New("Error getting Config: " + err.Error())
+				}
+				return nil
+			},
+		},
+		{
+			Name:   "servers",
+			Tables: serversSectionTables,
+			Build: func(tx *sql.Tx, crc *tc.CRConfig) error {
+				var err error
+				if crc.ContentServers, crc.ContentRouters, crc.Monitors, err = makeCRConfigServers(cdn, tx, cdnDomain); err != nil {
+					return errors.New("Error getting Servers: " + err.Error())
+				}
+				return nil
+			},
+		},
+		{
+			Name:   "locations",
+			Tables: locationsSectionTables,
+			Build: func(tx *sql.Tx, crc *tc.CRConfig) error {
+				var err error
+				if crc.EdgeLocations, crc.RouterLocations, err = makeLocations(cdn, tx); err != nil {
+					return errors.New("Error getting Edge Locations: " + err.Error())
+				}
+				return nil
+			},
+		},
+		{
+			Name:   "deliveryServices",
+			Tables: deliveryServicesSectionTables,
+			Build: func(tx *sql.Tx, crc *tc.CRConfig) error {
+				var err error
+				if crc.DeliveryServices, err = makeDSes(cdn, cdnDomain, tx, cdnSOA); err != nil {
+					return errors.New("Error getting Delivery Services: " + err.Error())
+				}
+				return nil
+			},
+		},
+		{
+			Name:   "topologies",
+			Tables: topologiesSectionTables,
+			Build: func(tx *sql.Tx, crc *tc.CRConfig) error {
+				var err error
+				if crc.Topologies, err = topology.MakeTopologies(tx); err != nil {
+					return errors.New("Error getting Topologies: " + err.Error())
+				}
+				return nil
+			},
+		},
 	}
-	if crc.Topologies, err = topology.MakeTopologies(tx); err != nil {
-		return nil, errors.New("Error getting Topologies: " + err.Error())
+	if err := assembleSections(src, cdn, sections, &crc); err != nil {
+		return nil, err
 	}
 
 	if !useClientReqHost {
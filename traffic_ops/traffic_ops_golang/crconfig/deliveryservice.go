@@ -43,7 +43,11 @@ const DefaultTLDTTLNS = 3600 * time.Second
 const GeoProviderMaxmindStr = "maxmindGeolocationService"
 const GeoProviderNeustarStr = "neustarGeolocationService"
 
-func makeDSes(cdn string, domain string, tx *sql.Tx) (map[string]tc.CRConfigDeliveryService, error) {
+// makeDSes returns the "deliveryServices" section of the CRConfig. If cdnSOA
+// is not nil, it's used as every Delivery Service's SOA, and its SOA and NS
+// record TTLs are used for Delivery Services whose Profiles don't override
+// them with tld.ttls.SOA and tld.ttls.NS Parameters.
+func makeDSes(cdn string, domain string, tx *sql.Tx, cdnSOA *tc.CDNSOA) (map[string]tc.CRConfigDeliveryService, error) {
 	dses := map[string]tc.CRConfigDeliveryService{}
 
 	admin := CDNSOAAdmin
@@ -51,13 +55,21 @@ func makeDSes(cdn string, domain string, tx *sql.Tx) (map[string]tc.CRConfigDeli
 	minimumSecondsStr := strconv.Itoa(int(CDNSOAMinimum / time.Second))
 	refreshSecondsStr := strconv.Itoa(int(CDNSOARefresh / time.Second))
 	retrySecondsStr := strconv.Itoa(int(CDNSOARetry / time.Second))
-	cdnSOA := &tc.SOA{
+	dsSOA := &tc.SOA{
 		Admin:          &admin,
 		ExpireSeconds:  &expireSecondsStr,
 		MinimumSeconds: &minimumSecondsStr,
 		RefreshSeconds: &refreshSecondsStr,
 		RetrySeconds:   &retrySecondsStr,
 	}
+	defaultNSSeconds := DefaultTLDTTLNS
+	defaultSOASeconds := DefaultTLDTTLSOA
+	if cdnSOA != nil {
+		soa := cdnSOA.SOA()
+		dsSOA = &soa
+		defaultNSSeconds = time.Duration(cdnSOA.NSTTLSeconds) * time.Second
+		defaultSOASeconds = time.Duration(cdnSOA.SOATTLSeconds) * time.Second
+	}
 
 	// Note the CRConfig omits acceptHTTP if it's true
 	falsePtr := false
@@ -100,9 +112,7 @@ SELECT d.anonymous_blocking_enabled,
        d.dns_bypass_ip,
        d.dns_bypass_ip6,
        d.dns_bypass_ttl,
-       (SELECT ARRAY_AGG(name ORDER BY name)
-                         FROM deliveryservice_consistent_hash_query_param
-                         WHERE deliveryservice_id = d.id) AS query_keys,
+       qk.query_keys,
        d.routing_name,
        d.ccr_dns_ttl AS ttl,
        d.ecs_enabled,
@@ -118,20 +128,36 @@ SELECT d.anonymous_blocking_enabled,
        d.miss_long,
        p.name AS profile,
        d.protocol,
-       (SELECT ARRAY_AGG(required_capability ORDER BY required_capability)
-                         FROM deliveryservices_required_capability
-                         WHERE deliveryservice_id = d.id) AS required_capabilities,
+       rc.required_capabilities,
        d.topology,
        d.tr_request_headers,
        d.tr_response_headers,
        d.tr_response_headers,
        t.name AS type,
-       d.xml_id
+       d.xml_id,
+       d.active,
+       d.active_at,
+       d.inactive_at,
+       d.consistent_hash_include_path,
+       d.consistent_hash_headers,
+       d.consistent_hash_replicas,
+       d.trace_header_policy,
+       d.trace_header_name
 FROM deliveryservice AS d
 INNER JOIN type AS t ON t.id = d.type
 LEFT OUTER JOIN profile AS p ON p.id = d.profile
+LEFT OUTER JOIN (
+	SELECT deliveryservice_id, ARRAY_AGG(name ORDER BY name) AS query_keys
+	FROM deliveryservice_consistent_hash_query_param
+	GROUP BY deliveryservice_id
+) AS qk ON qk.deliveryservice_id = d.id
+LEFT OUTER JOIN (
+	SELECT deliveryservice_id, ARRAY_AGG(required_capability ORDER BY required_capability) AS required_capabilities
+	FROM deliveryservices_required_capability
+	GROUP BY deliveryservice_id
+) AS rc ON rc.deliveryservice_id = d.id
 WHERE d.cdn_id = (select id FROM cdn WHERE name = $1)
-AND d.active = true
+AND (d.active = true OR d.active_at IS NOT NULL)
 `
 	q += fmt.Sprintf(" and t.name != '%s'", tc.DSTypeAnyMap)
 	rows, err := tx.Query(q, cdn)
@@ -145,7 +171,7 @@ AND d.active = true
 			ConsistentHashQueryParams: []string{},
 			Protocol:                  &tc.CRConfigDeliveryServiceProtocol{},
 			ResponseHeaders:           map[string]string{},
-			Soa:                       cdnSOA,
+			Soa:                       dsSOA,
 			TTLs:                      &tc.CRConfigTTL{},
 		}
 
@@ -176,6 +202,14 @@ AND d.active = true
 		trResponseHeaders := sql.NullString{}
 		anonymousBlocking := false
 		consistentHashRegex := sql.NullString{}
+		active := false
+		activeAt := (*time.Time)(nil)
+		inactiveAt := (*time.Time)(nil)
+		consistentHashIncludePath := true
+		consistentHashHeaders := []string{}
+		consistentHashReplicas := sql.NullInt64{}
+		traceHeaderPolicy := sql.NullString{}
+		traceHeaderName := sql.NullString{}
 		err := rows.Scan(
 			&anonymousBlocking,
 			&consistentHashRegex,
@@ -208,6 +242,14 @@ AND d.active = true
 			&trResponseHeaders,
 			&ttype,
 			&xmlID,
+			&active,
+			&activeAt,
+			&inactiveAt,
+			&consistentHashIncludePath,
+			pq.Array(&consistentHashHeaders),
+			&consistentHashReplicas,
+			&traceHeaderPolicy,
+			&traceHeaderName,
 		)
 		if err != nil {
 			return nil, errors.New("scanning deliveryservice: " + err.Error())
@@ -225,6 +267,14 @@ AND d.active = true
 			ttl := int(ttl.Int64)
 			ds.TTL = &ttl
 		}
+		if !active && activeAt != nil {
+			at := activeAt.Unix()
+			ds.ActiveAt = &at
+		}
+		if inactiveAt != nil {
+			at := inactiveAt.Unix()
+			ds.InactiveAt = &at
+		}
 
 		protocolStr := tc.GetDSTypeCategory(ttype)
 
@@ -295,8 +345,8 @@ AND d.active = true
 			}
 		}
 
-		nsSeconds := DefaultTLDTTLNS
-		soaSeconds := DefaultTLDTTLSOA
+		nsSeconds := defaultNSSeconds
+		soaSeconds := defaultSOASeconds
 		if profile.Valid {
 			if sval, ok := dsParams["tld.ttls.SOA"]; ok {
 				if val, err := strconv.Atoi(sval); err == nil {
@@ -382,6 +432,17 @@ AND d.active = true
 		if consistentHashRegex.Valid && consistentHashRegex.String != "" {
 			ds.ConsistentHashRegex = &consistentHashRegex.String
 		}
+		// Traffic Router hashes on the request path unless told otherwise.
+		if !consistentHashIncludePath {
+			ds.ConsistentHashIncludePath = &consistentHashIncludePath
+		}
+		if len(consistentHashHeaders) > 0 {
+			ds.ConsistentHashHeaders = consistentHashHeaders
+		}
+		if consistentHashReplicas.Valid {
+			replicas := int(consistentHashReplicas.Int64)
+			ds.ConsistentHashReplicas = &replicas
+		}
 
 		ds.IP6RoutingEnabled = &ip6RoutingEnabled.Bool // No Valid check, false if null
 		ds.EcsEnabled = &ecsEnabled.Bool               // No Valid check, false if null
@@ -410,6 +471,18 @@ AND d.active = true
 			}
 		}
 
+		// Traffic Router logs the trace header, so that a request can be
+		// followed from it through the cache servers that will carry it.
+		if policy := tc.TraceHeaderPolicy(traceHeaderPolicy.String); policy == tc.TraceHeaderPolicyGenerate || policy == tc.TraceHeaderPolicyPropagate {
+			name := tc.DefaultTraceHeaderName
+			if traceHeaderName.Valid && traceHeaderName.String != "" {
+				name = traceHeaderName.String
+			}
+			if !containsHeader(ds.RequestHeaders, name) {
+				ds.RequestHeaders = append(ds.RequestHeaders, name)
+			}
+		}
+
 		ds.StaticDNSEntries = staticDNSEntries[tc.DeliveryServiceName(xmlID)]
 
 		dses[xmlID] = ds
@@ -431,7 +504,7 @@ from staticdnsentry as e
 inner join deliveryservice as d on d.id = e.deliveryservice
 inner join type as t on t.id = e.type
 where d.cdn_id = (select id from cdn where name = $1)
-and d.active = true
+and (d.active = true OR d.active_at IS NOT NULL)
 `
 	rows, err := tx.Query(q, cdn)
 	if err != nil {
@@ -466,7 +539,7 @@ inner join deliveryservice as d on d.id = dr.deliveryservice
 inner join type as t on t.id = r.type
 inner join type as dt on dt.id = d.type
 where d.cdn_id = (select id from cdn where name = $1)
-and d.active = true
+and (d.active = true OR d.active_at IS NOT NULL)
 order by dr.set_number asc
 `
 	rows, err := tx.Query(q, cdn)
@@ -501,6 +574,8 @@ order by dr.set_number asc
 			matchType = "HOST"
 		case "PATH_REGEXP":
 			matchType = "PATH"
+		case "PATH_AND_QUERY_REGEXP":
+			matchType = "PATH_AND_QUERY"
 		case "HEADER_REGEXP":
 			matchType = "HEADER"
 		default:
@@ -592,3 +667,14 @@ where profile.id in (select profile from server where server.cdn_id = (select id
 	}
 	return params, nil
 }
+
+// containsHeader returns whether or not the given header names contain the
+// given name, case-insensitively.
+func containsHeader(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
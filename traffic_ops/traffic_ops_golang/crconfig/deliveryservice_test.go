@@ -119,9 +119,14 @@ func randDS() tc.CRConfigDeliveryService {
 }
 
 func ExpectedMakeDSes() map[string]tc.CRConfigDeliveryService {
+	ds2 := randDS()
+	ds2.ConsistentHashHeaders = []string{"X-Device-Class"}
+	ds2.ConsistentHashIncludePath = util.BoolPtr(false)
+	ds2.ConsistentHashReplicas = util.IntPtr(500)
+	ds2.RequestHeaders = []string{tc.DefaultTraceHeaderName}
 	return map[string]tc.CRConfigDeliveryService{
 		"ds1": randDS(),
-		"ds2": randDS(),
+		"ds2": ds2,
 	}
 }
 
@@ -161,10 +166,27 @@ func MockMakeDSes(mock sqlmock.Sqlmock, expected map[string]tc.CRConfigDeliveryS
 		"tr_response_headers",
 		"tr_response_headers",
 		"type",
-		"xml_id"})
+		"xml_id",
+		"active",
+		"active_at",
+		"inactive_at",
+		"consistent_hash_include_path",
+		"consistent_hash_headers",
+		"consistent_hash_replicas",
+		"trace_header_policy",
+		"trace_header_name"})
 
 	for dsName, ds := range expected {
 		queryParams := "{" + strings.Join(ds.ConsistentHashQueryParams, ",") + "}"
+		includePath := ds.ConsistentHashIncludePath == nil || *ds.ConsistentHashIncludePath
+		var replicas interface{}
+		if ds.ConsistentHashReplicas != nil {
+			replicas = *ds.ConsistentHashReplicas
+		}
+		var traceHeaderPolicy interface{}
+		if len(ds.RequestHeaders) > 0 {
+			traceHeaderPolicy = string(tc.TraceHeaderPolicyGenerate)
+		}
 		rows = rows.AddRow(
 			false,
 			"",
@@ -196,7 +218,15 @@ func MockMakeDSes(mock sqlmock.Sqlmock, expected map[string]tc.CRConfigDeliveryS
 			"",
 			"",
 			"HTTP",
-			dsName)
+			dsName,
+			true,
+			nil,
+			nil,
+			includePath,
+			"{"+strings.Join(ds.ConsistentHashHeaders, ",")+"}",
+			replicas,
+			traceHeaderPolicy,
+			nil)
 	}
 	mock.ExpectQuery("select").WithArgs(cdn).WillReturnRows(rows)
 }
@@ -245,7 +275,7 @@ func TestMakeDSesGeoLimit(t *testing.T) {
 	}
 	defer tx.Commit()
 
-	actual, err := makeDSes(cdn, domain, tx)
+	actual, err := makeDSes(cdn, domain, tx, nil)
 	if err != nil {
 		t.Fatalf("makeDSes expected: nil error, actual: %v", err)
 	}
@@ -305,7 +335,7 @@ func TestMakeDSes(t *testing.T) {
 	}
 	defer tx.Commit()
 
-	actual, err := makeDSes(cdn, domain, tx)
+	actual, err := makeDSes(cdn, domain, tx, nil)
 	if err != nil {
 		t.Fatalf("makeDSes expected: nil error, actual: %v", err)
 	}
@@ -482,6 +512,49 @@ func TestGetDSRegexesDomains(t *testing.T) {
 	}
 }
 
+func TestGetDSRegexesDomainsPathAndQuery(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	cdn := "mycdn"
+	domain := "mycdn.invalid"
+
+	rows := sqlmock.NewRows([]string{"pattern", "type", "dstype", "set_number", "xml_id"})
+	rows = rows.AddRow(`.*\.ds1\..*`, "HOST_REGEXP", "HTTP", 0, "ds1")
+	rows = rows.AddRow(`/video/.*\?token=[0-9a-f]+`, "PATH_AND_QUERY_REGEXP", "HTTP", 1, "ds1")
+	mock.ExpectBegin()
+	mock.ExpectQuery("select").WithArgs(cdn).WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("creating transaction: %v", err)
+	}
+	defer tx.Commit()
+
+	actualMatchsets, actualDomains, err := getDSRegexesDomains(cdn, domain, tx)
+	if err != nil {
+		t.Fatalf("getDSRegexesDomains expected: nil error, actual: %v", err)
+	}
+
+	expectedMatchsets := map[string][]*tc.MatchSet{
+		"ds1": {
+			{Protocol: "HTTP", MatchList: []tc.MatchList{{MatchType: "HOST", Regex: `.*\.ds1\..*`}}},
+			{Protocol: "HTTP", MatchList: []tc.MatchList{{MatchType: "PATH_AND_QUERY", Regex: `/video/.*\?token=[0-9a-f]+`}}},
+		},
+	}
+	if !reflect.DeepEqual(expectedMatchsets, actualMatchsets) {
+		t.Errorf("getDSRegexesDomains expected: %+v, actual: %+v", expectedMatchsets, actualMatchsets)
+	}
+	expectedDomains := map[string][]string{"ds1": {"ds1." + domain}}
+	if !reflect.DeepEqual(expectedDomains, actualDomains) {
+		t.Errorf("getDSRegexesDomains expected domains: %+v, actual: %+v", expectedDomains, actualDomains)
+	}
+}
+
 func ExpectedGetStaticDNSEntries(expectedMakeDSes map[string]tc.CRConfigDeliveryService) map[tc.DeliveryServiceName][]tc.CRConfigStaticDNSEntry {
 	expected := map[tc.DeliveryServiceName][]tc.CRConfigStaticDNSEntry{}
 	for dsName, ds := range expectedMakeDSes {
@@ -68,18 +68,26 @@ func makeLocations(cdn string, tx *sql.Tx) (map[string]tc.CRConfigLatitudeLongit
 		return nil, nil, err
 	}
 
-	// TODO test whether it's faster to do a single query, joining lat/lon into servers
+	// Each Cache Group is selected once per type of its servers, rather than
+	// once per server.
 	q := `
-select cg.name, cg.id, t.name as type, co.latitude, co.longitude, COALESCE(cg.fallback_to_closest, TRUE),
-(SELECT array_agg(method::text) as localization_methods FROM cachegroup_localization_method WHERE cachegroup = cg.id)
-from cachegroup as cg
-left join coordinate as co on co.id = cg.coordinate
-inner join server as s on s.cachegroup = cg.id
-inner join type as t on t.id = s.type
-inner join status as st ON st.id = s.status
-where s.cdn_id = (select id from cdn where name = $1)
-and (t.name like 'EDGE%' or t.name = 'CCR')
-and (st.name = 'REPORTED' or st.name = 'ONLINE' or st.name = 'ADMIN_DOWN')
+SELECT cg.name, cg.id, cgt.type, co.latitude, co.longitude, COALESCE(cg.fallback_to_closest, TRUE), lm.localization_methods
+FROM cachegroup AS cg
+INNER JOIN (
+	SELECT DISTINCT s.cachegroup, t.name AS type
+	FROM server AS s
+	INNER JOIN type AS t ON t.id = s.type
+	INNER JOIN status AS st ON st.id = s.status
+	WHERE s.cdn_id = (SELECT id FROM cdn WHERE name = $1)
+	AND (t.name LIKE 'EDGE%' OR t.name = 'CCR')
+	AND (st.name = 'REPORTED' OR st.name = 'ONLINE' OR st.name = 'ADMIN_DOWN')
+) AS cgt ON cgt.cachegroup = cg.id
+LEFT JOIN coordinate AS co ON co.id = cg.coordinate
+LEFT JOIN (
+	SELECT cachegroup, ARRAY_AGG(method::text) AS localization_methods
+	FROM cachegroup_localization_method
+	GROUP BY cachegroup
+) AS lm ON lm.cachegroup = cg.id
 `
 	// TODO pass edge type prefix, router type name
 	rows, err := tx.Query(q, cdn)
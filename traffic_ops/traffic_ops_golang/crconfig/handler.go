@@ -20,6 +20,8 @@ package crconfig
  */
 
 import (
+	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -31,9 +33,13 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-rfc"
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
-	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/monitoring"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
+
+	"github.com/jmoiron/sqlx"
 )
 
 // Handler creates and serves the CRConfig from the raw SQL data.
@@ -46,9 +52,15 @@ func Handler(w http.ResponseWriter, r *http.Request) {
 	}
 	defer inf.Close()
 
+	db, err := api.GetDB(r.Context())
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting db from context: "+err.Error()))
+		return
+	}
+
 	start := time.Now()
 	emulate := inf.Config.CRConfigEmulateOldPath || inf.Version.Major < 4
-	crConfig, err := Make(inf.Tx.Tx, inf.Params["cdn"], inf.User.UserName, r.Host, inf.Config.Version, inf.Config.CRConfigUseRequestHost, emulate)
+	crConfig, err := Make(r.Context(), db.DB, inf.Tx.Tx, inf.Params["cdn"], inf.User.UserName, r.Host, inf.Config.Version, inf.Config.CRConfigUseRequestHost, emulate)
 	if err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
 		return
@@ -66,7 +78,7 @@ func SnapshotGetHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	defer inf.Close()
 
-	snapshot, cdnExists, err := GetSnapshot(inf.Tx.Tx, inf.Params["cdn"])
+	snapshot, cdnExists, err := GetCachedSnapshot(r.Context(), inf.Tx.Tx, inf.Params["cdn"])
 	if err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting snapshot: "+err.Error()))
 		return
@@ -89,7 +101,9 @@ func SnapshotGetHandler(w http.ResponseWriter, r *http.Request) {
 	api.WriteResp(w, r, decoded)
 }
 
-// SnapshotGetMonitoringHandler gets and serves the CRConfig from the snapshot table.
+// SnapshotGetMonitoringHandler gets and serves the monitoring config from the
+// snapshot table - or, if the "since" query parameter gives an earlier version
+// of it, only its sections that changed since then.
 func SnapshotGetMonitoringHandler(w http.ResponseWriter, r *http.Request) {
 	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"cdn"}, nil)
 	if userErr != nil || sysErr != nil {
@@ -98,7 +112,30 @@ func SnapshotGetMonitoringHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	defer inf.Close()
 
-	snapshot, cdnExists, err := GetSnapshotMonitoring(inf.Tx.Tx, inf.Params["cdn"])
+	if sinceStr, ok := inf.Params[tc.TrafficMonitorConfigSinceQueryParam]; ok && (inf.Version.Major > 4 || (inf.Version.Major == 4 && inf.Version.Minor >= 1)) {
+		since, err := strconv.ParseInt(sinceStr, 10, 64)
+		if err != nil || since < 0 {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New(tc.TrafficMonitorConfigSinceQueryParam+" must be a non-negative integer"), nil)
+			return
+		}
+		delta, cdnExists, err := GetCachedSnapshotMonitoringDelta(r.Context(), inf.Tx.Tx, inf.Params["cdn"], since)
+		if err == errMonitoringVersionGap {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusGone, err, nil)
+			return
+		}
+		if err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting snapshot delta: "+err.Error()))
+			return
+		}
+		if !cdnExists {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("CDN not found"), nil)
+			return
+		}
+		api.WriteResp(w, r, delta)
+		return
+	}
+
+	snapshot, cdnExists, err := GetCachedSnapshotMonitoring(r.Context(), inf.Tx.Tx, inf.Params["cdn"])
 	if err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting snapshot: "+err.Error()))
 		return
@@ -162,28 +199,85 @@ func SnapshotHandler(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
 		return
 	}
-	// We never store tm_path, even though low API versions show it in responses.
-	crConfig, err := Make(inf.Tx.Tx, cdn, inf.User.UserName, r.Host, inf.Config.Version, inf.Config.CRConfigUseRequestHost, false)
+	async, err := api.IsAsyncRequested(inf)
 	if err != nil {
-		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, err, nil)
 		return
 	}
-	monitoringJSON, err := monitoring.GetMonitoringJSON(inf.Tx.Tx, cdn)
-	if err != nil {
-		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New(r.RemoteAddr+" getting monitoring.json data: "+err.Error()))
+	if async {
+		user := *inf.User
+		host := r.Host
+		cfg := inf.Config
+		tv := inf.Vault
+		api.StartAsyncJob(w, r, inf, "Snapshot of CDN '"+cdn+"' has started.", func(ctx context.Context, db *sqlx.DB, jobID int) (string, error) {
+			failed := "Snapshot of CDN '" + cdn + "' failed."
+			tx, err := db.BeginTx(ctx, nil)
+			if err != nil {
+				return failed, errors.New("beginning transaction: " + err.Error())
+			}
+			commit := false
+			defer func() {
+				if !commit {
+					tx.Rollback()
+				}
+			}()
+			progress := func(percent int, step string) {
+				if err := api.UpdateAsyncStatusProgress(db, jobID, percent, step); err != nil {
+					log.Errorf("updating progress of snapshot job #%d: %v", jobID, err)
+				}
+			}
+			if err := snapshotCDN(ctx, db.DB, tx, cdn, id, &user, host, cfg, tv, progress); err != nil {
+				return failed, errors.New("snapshotting CRConfig and Monitoring: " + err.Error())
+			}
+			if err := tx.Commit(); err != nil {
+				return failed, errors.New("committing transaction: " + err.Error())
+			}
+			commit = true
+			return "Snapshot of CDN '" + cdn + "' completed.", nil
+		})
 		return
 	}
 
-	if err := Snapshot(inf.Tx.Tx, crConfig, monitoringJSON); err != nil {
-		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New(r.RemoteAddr+" snaphsotting CRConfig and Monitoring: "+err.Error()))
+	if err := snapshotCDN(r.Context(), db.DB, inf.Tx.Tx, cdn, id, inf.User, r.Host, inf.Config, inf.Vault, nil); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New(r.RemoteAddr+" snapshotting CRConfig and Monitoring: "+err.Error()))
 		return
 	}
+	api.WriteResp(w, r, "SUCCESS")
+}
 
-	if err := deliveryservice.DeleteOldCerts(db.DB, inf.Tx.Tx, inf.Config, tc.CDNName(cdn), inf.Vault); err != nil {
-		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New(r.RemoteAddr+" snapshotting CRConfig and Monitoring: starting old certificate deletion job: "+err.Error()))
-		return
+// snapshotCDN creates the CRConfig and Monitoring config of the CDN and writes
+// them to the snapshot table with the given transaction, which it doesn't
+// commit. If progress isn't nil, it's called as each step starts.
+func snapshotCDN(ctx context.Context, db *sql.DB, tx *sql.Tx, cdn string, cdnID int, user *auth.CurrentUser, host string, cfg *config.Config, tv trafficvault.TrafficVault, progress func(percent int, step string)) error {
+	if progress == nil {
+		progress = func(int, string) {}
+	}
+	progress(5, "Reading the CDN's configuration.")
+	start := time.Now()
+	src, err := newTxSource(ctx, db, tx)
+	if err != nil {
+		return err
 	}
+	progress(25, "Generating CRConfig and Monitoring configuration.")
+	crConfig, monitoringJSON, err := makeSnapshot(src, cdn, user.UserName, host, cfg.Version, cfg.CRConfigUseRequestHost)
+	if err != nil {
+		return err
+	}
+	log.Infof("CRConfig and Monitoring for CDN '%s' time to generate: %v", cdn, time.Since(start))
 
-	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+cdn+", ID: "+strconv.Itoa(id)+", ACTION: Snapshot of CRConfig and Monitor", inf.User, inf.Tx.Tx)
-	api.WriteResp(w, r, "SUCCESS")
+	progress(75, "Writing the snapshot.")
+	if err := Snapshot(tx, crConfig, monitoringJSON); err != nil {
+		return err
+	}
+	if err := updateSOASerial(tx, crConfig); err != nil {
+		return err
+	}
+
+	progress(90, "Deleting old certificates.")
+	if err := deliveryservice.DeleteOldCerts(db, tx, cfg, tc.CDNName(cdn), tv); err != nil {
+		return errors.New("starting old certificate deletion job: " + err.Error())
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "CDN: "+cdn+", ID: "+strconv.Itoa(cdnID)+", ACTION: Snapshot of CRConfig and Monitor", user, tx)
+	return nil
 }
@@ -0,0 +1,157 @@
+package crconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/objectcache"
+)
+
+// errMonitoringVersionGap is returned when the changes to a monitoring
+// config Snapshot since a version can't be told, because the version is
+// newer than the Snapshot, or the Snapshot was taken before Snapshots were
+// versioned. Clients must then request the whole monitoring config.
+var errMonitoringVersionGap = errors.New("changes since the requested version are unknown; the whole monitoring configuration must be requested")
+
+// monitoringVersions returns the version of a new monitoring config Snapshot
+// of the given CDN, and the JSON object of the versions at which each of its
+// sections last changed, which are those of the CDN's current Snapshot for
+// the sections that are unchanged.
+func monitoringVersions(tx *sql.Tx, cdn string, monitoringJSON []byte) (int64, []byte, error) {
+	var oldMonitoring, oldVersionsJSON []byte
+	q := `SELECT monitoring, monitoring_section_versions FROM snapshot WHERE cdn = $1 FOR UPDATE`
+	if err := tx.QueryRow(q, cdn).Scan(&oldMonitoring, &oldVersionsJSON); err != nil && err != sql.ErrNoRows {
+		return 0, nil, errors.New("querying current monitoring snapshot versions: " + err.Error())
+	}
+
+	version := int64(0)
+	if err := tx.QueryRow(`SELECT nextval('snapshot_monitoring_version_seq')`).Scan(&version); err != nil {
+		return 0, nil, errors.New("getting next monitoring snapshot version: " + err.Error())
+	}
+
+	sections := map[string]json.RawMessage{}
+	if err := json.Unmarshal(monitoringJSON, &sections); err != nil {
+		return 0, nil, errors.New("splitting monitoring snapshot into sections: " + err.Error())
+	}
+	oldSections := map[string]json.RawMessage{}
+	oldVersions := map[string]int64{}
+	if len(oldMonitoring) > 0 && len(oldVersionsJSON) > 0 {
+		if err := json.Unmarshal(oldMonitoring, &oldSections); err != nil {
+			log.Warnf("splitting current monitoring snapshot of CDN '%s' into sections, treating all sections as changed: %v\n", cdn, err)
+		} else if err := json.Unmarshal(oldVersionsJSON, &oldVersions); err != nil {
+			log.Warnf("parsing current monitoring snapshot section versions of CDN '%s', treating all sections as changed: %v\n", cdn, err)
+			oldVersions = map[string]int64{}
+		}
+	}
+
+	versions := make(map[string]int64, len(sections))
+	for name, section := range sections {
+		if oldVersion := oldVersions[name]; oldVersion > 0 && bytes.Equal(section, oldSections[name]) {
+			versions[name] = oldVersion
+		} else {
+			versions[name] = version
+		}
+	}
+	versionsJSON, err := json.Marshal(versions)
+	if err != nil {
+		return 0, nil, errors.New("marshalling monitoring snapshot section versions: " + err.Error())
+	}
+	return version, versionsJSON, nil
+}
+
+// storedMonitoringVersions is a stored monitoring config Snapshot with its
+// versions, as it's kept in the object cache.
+type storedMonitoringVersions struct {
+	Snapshot        sql.NullString   `json:"snapshot"`
+	Version         int64            `json:"version"`
+	SectionVersions map[string]int64 `json:"sectionVersions"`
+	CDNExists       bool             `json:"cdnExists"`
+}
+
+// getStoredMonitoringVersions gets the monitoring config Snapshot of the
+// given CDN with its versions, and whether the CDN exists.
+func getStoredMonitoringVersions(tx *sql.Tx, cdn string) (storedMonitoringVersions, error) {
+	stored := storedMonitoringVersions{}
+	versionsJSON := []byte(nil)
+	// cdn left join snapshot, so we get a row with null if the CDN exists but the snapshot doesn't, and no rows if the CDN doesn't exist.
+	q := `
+SELECT s.monitoring, COALESCE(s.monitoring_version, 0), COALESCE(s.monitoring_section_versions, '{}')
+FROM cdn AS c
+LEFT JOIN snapshot AS s ON s.cdn = c.name
+WHERE c.name = $1
+`
+	if err := tx.QueryRow(q, cdn).Scan(&stored.Snapshot, &stored.Version, &versionsJSON); err != nil {
+		if err == sql.ErrNoRows {
+			return stored, nil
+		}
+		return stored, errors.New("querying monitor snapshot versions: " + err.Error())
+	}
+	stored.CDNExists = true
+	if err := json.Unmarshal(versionsJSON, &stored.SectionVersions); err != nil {
+		return stored, errors.New("parsing monitor snapshot section versions: " + err.Error())
+	}
+	return stored, nil
+}
+
+// GetCachedSnapshotMonitoringDelta returns the sections of the monitoring
+// config Snapshot of the given CDN that changed after the given version, and
+// whether the CDN exists. If the changes can't be told, errMonitoringVersionGap
+// is returned.
+func GetCachedSnapshotMonitoringDelta(ctx context.Context, tx *sql.Tx, cdn string, since int64) (tc.TrafficMonitorConfigDelta, bool, error) {
+	stored := storedMonitoringVersions{}
+	err := objectcache.Load(ctx, "snapshot/monitoring-versions/"+cdn, snapshotCacheTypes, &stored, func() error {
+		var err error
+		stored, err = getStoredMonitoringVersions(tx, cdn)
+		return err
+	})
+	if err != nil || !stored.CDNExists {
+		return tc.TrafficMonitorConfigDelta{}, stored.CDNExists, err
+	}
+	if !stored.Snapshot.Valid || stored.Version == 0 || since > stored.Version {
+		return tc.TrafficMonitorConfigDelta{}, true, errMonitoringVersionGap
+	}
+
+	delta := tc.TrafficMonitorConfigDelta{
+		Version:  stored.Version,
+		Since:    since,
+		Sections: map[string]json.RawMessage{},
+	}
+	if since == stored.Version {
+		return delta, true, nil
+	}
+	sections := map[string]json.RawMessage{}
+	if err := json.Unmarshal([]byte(stored.Snapshot.String), &sections); err != nil {
+		return tc.TrafficMonitorConfigDelta{}, true, errors.New("splitting monitor snapshot into sections: " + err.Error())
+	}
+	for name, section := range sections {
+		// Sections without versions can't be told not to have changed.
+		if version, ok := stored.SectionVersions[name]; !ok || version > since {
+			delta.Sections[name] = section
+		}
+	}
+	return delta, true, nil
+}
@@ -0,0 +1,127 @@
+package crconfig
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestMonitoringVersions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	old := `{"config":{"a":1},"profiles":[],"trafficServers":[{"hostname":"old"}]}`
+	oldVersions := `{"config":3,"profiles":5,"trafficServers":5}`
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT monitoring").WithArgs("mycdn").WillReturnRows(sqlmock.NewRows([]string{"monitoring", "monitoring_section_versions"}).AddRow(old, oldVersions))
+	mock.ExpectQuery("nextval").WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(8))
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatalf("creating transaction: %v", err)
+	}
+
+	current := `{"config":{"a":1},"profiles":[],"trafficServers":[{"hostname":"new"}],"topologies":{}}`
+	version, versionsJSON, err := monitoringVersions(tx, "mycdn", []byte(current))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != 8 {
+		t.Errorf("expected version 8, actual %d", version)
+	}
+	versions := map[string]int64{}
+	if err := json.Unmarshal(versionsJSON, &versions); err != nil {
+		t.Fatalf("parsing section versions: %v", err)
+	}
+	expected := map[string]int64{"config": 3, "profiles": 5, "trafficServers": 8, "topologies": 8}
+	if !reflect.DeepEqual(versions, expected) {
+		t.Errorf("expected section versions %v, actual %v", expected, versions)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestGetCachedSnapshotMonitoringDelta(t *testing.T) {
+	snapshot := `{"config":{"a":1},"profiles":[],"trafficServers":[{"hostname":"new"}]}`
+	versions := `{"config":3,"profiles":5,"trafficServers":8}`
+
+	tests := []struct {
+		name     string
+		version  int64
+		since    int64
+		sections []string
+		err      error
+	}{
+		{name: "all sections", version: 8, since: 0, sections: []string{"config", "profiles", "trafficServers"}},
+		{name: "changed sections", version: 8, since: 4, sections: []string{"profiles", "trafficServers"}},
+		{name: "snapshot without changed sections", version: 9, since: 8, sections: []string{}},
+		{name: "current", version: 8, since: 8, sections: []string{}},
+		{name: "newer than snapshot", version: 8, since: 9, err: errMonitoringVersionGap},
+		{name: "unversioned snapshot", version: 0, since: 0, err: errMonitoringVersionGap},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT").WithArgs("mycdn").WillReturnRows(sqlmock.NewRows([]string{"monitoring", "monitoring_version", "monitoring_section_versions"}).AddRow(snapshot, test.version, versions))
+			tx, err := db.Begin()
+			if err != nil {
+				t.Fatalf("creating transaction: %v", err)
+			}
+
+			delta, cdnExists, err := GetCachedSnapshotMonitoringDelta(context.Background(), tx, "mycdn", test.since)
+			if err != test.err {
+				t.Fatalf("expected error %v, actual %v", test.err, err)
+			}
+			if !cdnExists {
+				t.Error("expected CDN to exist")
+			}
+			if err != nil {
+				return
+			}
+			if delta.Version != test.version || delta.Since != test.since {
+				t.Errorf("expected version %d since %d, actual %d since %d", test.version, test.since, delta.Version, delta.Since)
+			}
+			sections := map[string]struct{}{}
+			for name := range delta.Sections {
+				sections[name] = struct{}{}
+			}
+			expected := map[string]struct{}{}
+			for _, name := range test.sections {
+				expected[name] = struct{}{}
+			}
+			if !reflect.DeepEqual(sections, expected) {
+				t.Errorf("expected sections %v, actual %v", test.sections, delta.Sections)
+			}
+		})
+	}
+}
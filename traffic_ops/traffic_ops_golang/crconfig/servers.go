@@ -49,6 +49,11 @@ func makeCRConfigServers(cdn string, tx *sql.Tx, cdnDomain string) (
 		return nil, nil, nil, errors.New("getting server deliveryservices: " + err.Error())
 	}
 
+	dsRoutingInterfaces, err := getDSRoutingInterfaces(cdn, tx)
+	if err != nil {
+		return nil, nil, nil, errors.New("getting deliveryservice routing interfaces: " + err.Error())
+	}
+
 	servers := map[string]tc.CRConfigTrafficOpsServer{}
 	routers := map[string]tc.CRConfigRouter{}
 	monitors := map[string]tc.CRConfigMonitor{}
@@ -82,6 +87,7 @@ func makeCRConfigServers(cdn string, tx *sql.Tx, cdnDomain string) (
 		case strings.HasPrefix(*s.ServerType, tc.EdgeTypePrefix) || strings.HasPrefix(*s.ServerType, tc.MidTypePrefix):
 			if s.RoutingDisabled == 0 {
 				s.CRConfigTrafficOpsServer.DeliveryServices = serverDSes[tc.CacheName(host)]
+				s.CRConfigTrafficOpsServer.DeliveryServiceAddresses = makeDeliveryServiceAddresses(s, dsRoutingInterfaces)
 			}
 			servers[host] = s.CRConfigTrafficOpsServer
 		}
@@ -94,6 +100,7 @@ type ServerUnion struct {
 	tc.CRConfigTrafficOpsServer
 	APIPort       *string
 	SecureAPIPort *string
+	Interfaces    []tc.ServerInterfaceInfoV40
 }
 
 type ServerAndHost struct {
@@ -110,7 +117,6 @@ func getAllServers(cdn string, tx *sql.Tx) (map[string]ServerUnion, error) {
 		return nil, errors.New("Error getting server params: " + err.Error())
 	}
 
-	// TODO select deliveryservices as array?
 	q := `
 	SELECT
 		s.id,
@@ -118,21 +124,25 @@ func getAllServers(cdn string, tx *sql.Tx) (map[string]ServerUnion, error) {
 		cg.name as cachegroup,
 		concat(s.host_name, '.', s.domain_name) AS fqdn,
 		s.xmpp_id AS hashid,
-		s.https_port,
-		s.tcp_port,
+		CASE WHEN mc.port IS NULL THEN s.https_port END AS https_port,
+		COALESCE(mc.port, s.tcp_port) AS tcp_port,
 		p.name AS profile_name,
 		cast(p.routing_disabled AS int),
 		st.name AS status,
 		t.name AS type,
-		(SELECT ARRAY_AGG(server_capability ORDER BY server_capability)
-			FROM server_server_capability
-			WHERE server = s.id) AS capabilities
+		ssc.capabilities
 	FROM server AS s
 	INNER JOIN cachegroup AS cg ON cg.id = s.cachegroup
 	INNER JOIN type AS t on t.id = s.type
 	INNER JOIN profile AS p ON p.id = s.profile
 	INNER JOIN status AS st ON st.id = s.status
-	WHERE cdn_id = (SELECT id FROM cdn WHERE name = $1)
+	LEFT JOIN (
+		SELECT server, ARRAY_AGG(server_capability ORDER BY server_capability) AS capabilities
+		FROM server_server_capability
+		GROUP BY server
+	) AS ssc ON ssc.server = s.id
+	LEFT JOIN LATERAL (` + dbhelpers.MonitoredCDNPortQuery("s", "$1") + `) AS mc ON TRUE
+	WHERE (cdn_id = (SELECT id FROM cdn WHERE name = $1) OR (t.name = '` + tc.MonitorTypeName + `' AND mc.port IS NOT NULL))
 	AND (st.name = 'REPORTED' OR st.name = 'ONLINE' OR st.name = 'ADMIN_DOWN')
 	`
 	rows, err := tx.Query(q, cdn)
@@ -226,6 +236,8 @@ func getAllServers(cdn string, tx *sql.Tx) (map[string]ServerUnion, error) {
 			infs = append(infs, inf)
 		}
 
+		server.Server.Interfaces = infs
+
 		legacyNet, err := tc.V4InterfaceInfoToLegacyInterfaces(infs)
 		if err != nil {
 			return nil, fmt.Errorf("Error converting interfaces to legacy data for server '%s' (#%d): %v", server.Host, id, err)
@@ -253,6 +265,74 @@ func getAllServers(cdn string, tx *sql.Tx) (map[string]ServerUnion, error) {
 	return hostToServerMap, nil
 }
 
+const dsRoutingInterfacesQuery = `
+SELECT xml_id, routing_interfaces
+FROM deliveryservice
+WHERE cdn_id = (SELECT id FROM cdn WHERE name = $1)
+AND cardinality(routing_interfaces) > 0
+`
+
+// getDSRoutingInterfaces returns the routing interfaces of the Delivery
+// Services of the CDN which have any, by XMLID.
+func getDSRoutingInterfaces(cdn string, tx *sql.Tx) (map[string][]string, error) {
+	rows, err := tx.Query(dsRoutingInterfacesQuery, cdn)
+	if err != nil {
+		return nil, errors.New("querying: " + err.Error())
+	}
+	defer log.Close(rows, "closing deliveryservice routing interfaces rows")
+
+	dsRoutingInterfaces := map[string][]string{}
+	for rows.Next() {
+		xmlID := ""
+		interfaces := []string{}
+		if err := rows.Scan(&xmlID, pq.Array(&interfaces)); err != nil {
+			return nil, errors.New("scanning: " + err.Error())
+		}
+		dsRoutingInterfaces[xmlID] = interfaces
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating: " + err.Error())
+	}
+	return dsRoutingInterfaces, nil
+}
+
+// makeDeliveryServiceAddresses returns the addresses of the server to which
+// clients are routed for each of its Delivery Services whose routing
+// interfaces select addresses other than its service addresses, or nil if
+// there are none.
+func makeDeliveryServiceAddresses(s ServerUnion, dsRoutingInterfaces map[string][]string) map[string]tc.CRConfigServerAddresses {
+	var addresses map[string]tc.CRConfigServerAddresses
+	defaultIP := ""
+	if s.Ip != nil {
+		defaultIP = *s.Ip
+	}
+	defaultIP6 := ""
+	if s.Ip6 != nil {
+		defaultIP6 = strings.SplitN(*s.Ip6, "/", 2)[0]
+	}
+	for ds := range s.DeliveryServices {
+		interfaces, ok := dsRoutingInterfaces[ds]
+		if !ok {
+			continue
+		}
+		ip, ip6 := tc.GetPreferredAddresses(s.Interfaces, interfaces)
+		if ip == "" {
+			ip = defaultIP
+		}
+		if ip6 == "" {
+			ip6 = defaultIP6
+		}
+		if ip == defaultIP && ip6 == defaultIP6 {
+			continue
+		}
+		if addresses == nil {
+			addresses = map[string]tc.CRConfigServerAddresses{}
+		}
+		addresses[ds] = tc.CRConfigServerAddresses{IP: ip, IP6: ip6}
+	}
+	return addresses
+}
+
 type DSRouteInfo struct {
 	IsDNS bool
 	IsRaw bool
@@ -274,7 +354,7 @@ inner join deliveryservice_regex as dsr on dsr.regex = r.id
 inner join deliveryservice as ds on ds.id = dsr.deliveryservice
 inner join type as dt on dt.id = ds.type
 where ds.cdn_id = (select id from cdn where name = $1)
-and ds.active = true` +
+and (ds.active = true OR ds.active_at IS NOT NULL)` +
 		fmt.Sprintf(" and dt.name != '%s' ", tc.DSTypeAnyMap) + `
 and rt.name = 'HOST_REGEXP'
 order by dsr.set_number asc
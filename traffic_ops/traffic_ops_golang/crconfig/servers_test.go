@@ -629,3 +629,53 @@ func TestGetCDNNameFromID(t *testing.T) {
 		t.Errorf("getCDNNameFromID expected: %v, actual: %v", expected, actual)
 	}
 }
+
+func TestMakeDeliveryServiceAddresses(t *testing.T) {
+	s := ServerUnion{
+		CRConfigTrafficOpsServer: tc.CRConfigTrafficOpsServer{
+			Ip:  util.StrPtr("192.0.2.1"),
+			Ip6: util.StrPtr("2001:db8::1/64"),
+			DeliveryServices: map[string][]string{
+				"ds1": {"edge.ds1.mycdn.ciab.test"},
+				"ds2": {"edge.ds2.mycdn.ciab.test"},
+				"ds3": {"edge.ds3.mycdn.ciab.test"},
+			},
+		},
+		Interfaces: []tc.ServerInterfaceInfoV40{
+			{
+				ServerInterfaceInfo: tc.ServerInterfaceInfo{
+					Name: "eth0",
+					IPAddresses: []tc.ServerIPAddress{
+						{Address: "192.0.2.1", ServiceAddress: true},
+						{Address: "2001:db8::1/64", ServiceAddress: true},
+					},
+				},
+			},
+			{
+				ServerInterfaceInfo: tc.ServerInterfaceInfo{
+					Name: "v6only",
+					IPAddresses: []tc.ServerIPAddress{
+						{Address: "2001:db8:1::1/64"},
+					},
+				},
+			},
+		},
+	}
+	dsRoutingInterfaces := map[string][]string{
+		"ds1":   {"v6only"},
+		"ds2":   {"eth0"},
+		"other": {"v6only"},
+	}
+
+	addresses := makeDeliveryServiceAddresses(s, dsRoutingInterfaces)
+	expected := map[string]tc.CRConfigServerAddresses{
+		"ds1": {IP: "192.0.2.1", IP6: "2001:db8:1::1"},
+	}
+	if !reflect.DeepEqual(addresses, expected) {
+		t.Errorf("expected Delivery Service addresses %+v, actual: %+v", expected, addresses)
+	}
+
+	if addresses := makeDeliveryServiceAddresses(s, map[string][]string{}); addresses != nil {
+		t.Errorf("expected no Delivery Service addresses without routing interfaces, actual: %+v", addresses)
+	}
+}
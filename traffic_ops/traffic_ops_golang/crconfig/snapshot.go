@@ -20,14 +20,18 @@ package crconfig
  */
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/monitoring"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/objectcache"
 )
 
 // Snapshot takes the CRConfig JSON-serializable object (which may be generated via crconfig.Make), and writes it to the snapshot table.
@@ -48,14 +52,85 @@ func Snapshot(tx *sql.Tx, crc *tc.CRConfig, monitoringJSON *monitoring.Monitorin
 		return errors.New("marshalling JSON: " + err.Error())
 	}
 
+	if crc.Stats.CDNName == nil {
+		return errors.New("CRConfig has no CDN name")
+	}
+	version, sectionVersions, err := monitoringVersions(tx, *crc.Stats.CDNName, btstm)
+	if err != nil {
+		return errors.New("versioning monitoring snapshot: " + err.Error())
+	}
+
 	log.Debugf("calling Snapshot, writing %+v\n", date)
-	q := `insert into snapshot (cdn, crconfig, last_updated, monitoring) values ($1, $2, $3, $4) on conflict(cdn) do update set crconfig=$2, last_updated=$3, monitoring=$4`
-	if _, err := tx.Exec(q, crc.Stats.CDNName, bts, date, btstm); err != nil {
+	q := `insert into snapshot (cdn, crconfig, last_updated, monitoring, monitoring_version, monitoring_section_versions) values ($1, $2, $3, $4, $5, $6) on conflict(cdn) do update set crconfig=$2, last_updated=$3, monitoring=$4, monitoring_version=$5, monitoring_section_versions=$6`
+	if _, err := tx.Exec(q, crc.Stats.CDNName, bts, date, btstm, version, sectionVersions); err != nil {
 		return errors.New("Error inserting the crconfig and monitoring snapshot into database: " + err.Error())
 	}
 	return nil
 }
 
+// updateSOASerial records the SOA serial number used by the given CRConfig as
+// the latest serial number of its CDN, so that the next snapshot of a CDN
+// with the "increment" serial policy uses the next number. It does nothing if
+// the CRConfig has no serial number, i.e. the CDN's serial numbers are
+// date-based.
+func updateSOASerial(tx *sql.Tx, crc *tc.CRConfig) error {
+	soa, ok := crc.Config["soa"].(map[string]string)
+	if !ok || soa["serial"] == "" || crc.Stats.CDNName == nil {
+		return nil
+	}
+	serial, err := strconv.ParseInt(soa["serial"], 10, 64)
+	if err != nil {
+		return fmt.Errorf("parsing SOA serial '%s': %v", soa["serial"], err)
+	}
+	q := `
+UPDATE cdn_soa SET serial = $2
+WHERE cdn = (SELECT id FROM cdn WHERE name = $1)
+AND serial_policy = $3
+AND serial < $2
+`
+	if _, err := tx.Exec(q, *crc.Stats.CDNName, serial, tc.CDNSOASerialPolicyIncrement); err != nil {
+		return errors.New("updating SOA serial: " + err.Error())
+	}
+	return nil
+}
+
+// makeSnapshot generates the CRConfig and monitoring config of the given CDN
+// to be written by Snapshot. If src has a database from which to open
+// transactions, they are generated concurrently.
+func makeSnapshot(src *txSource, cdn, user, toHost, toVersion string, useClientReqHost bool) (*tc.CRConfig, *monitoring.Monitoring, error) {
+	if src.db == nil {
+		// We never store tm_path, even though low API versions show it in responses.
+		crc, err := makeFromSource(src, cdn, user, toHost, toVersion, useClientReqHost, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		monitoringJSON, err := monitoring.GetMonitoringJSON(src.tx, cdn)
+		if err != nil {
+			return nil, nil, errors.New("getting monitoring.json data: " + err.Error())
+		}
+		return crc, monitoringJSON, nil
+	}
+
+	var monitoringJSON *monitoring.Monitoring
+	monitoringErr := make(chan error, 1)
+	go func() {
+		monitoringErr <- src.runOne(func(tx *sql.Tx) error {
+			var err error
+			monitoringJSON, err = monitoring.GetMonitoringJSON(tx, cdn)
+			return err
+		})
+	}()
+
+	crc, err := makeFromSource(src, cdn, user, toHost, toVersion, useClientReqHost, false)
+	if mErr := <-monitoringErr; mErr != nil {
+		return nil, nil, errors.New("getting monitoring.json data: " + mErr.Error())
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return crc, monitoringJSON, nil
+}
+
 // GetSnapshot gets the snapshot for the given CDN.
 // If the CDN does not exist, false is returned.
 // If the CDN exists, but the snapshot does not, the string for an empty JSON object "{}" is returned.
@@ -94,6 +169,17 @@ WHERE c.name = $1
 func GetSnapshotMonitoring(tx *sql.Tx, cdn string) (string, bool, error) {
 	log.Debugln("calling GetSnapshotMonitoring")
 
+	monitorSnapshot, cdnExists, err := getStoredSnapshotMonitoring(tx, cdn)
+	if err != nil || !cdnExists {
+		return "", cdnExists, err
+	}
+	return snapshotMonitoringOrGenerate(tx, cdn, monitorSnapshot)
+}
+
+// getStoredSnapshotMonitoring gets the monitor snapshot for the given CDN as
+// it's stored in the snapshot table, and whether the CDN exists. If the CDN
+// exists, but the snapshot does not, the returned snapshot is not Valid.
+func getStoredSnapshotMonitoring(tx *sql.Tx, cdn string) (sql.NullString, bool, error) {
 	monitorSnapshot := sql.NullString{}
 	// cdn left join snapshot, so we get a row with null if the CDN exists but the snapshot doesn't, and no rows if the CDN doesn't exist.
 	q := `
@@ -105,10 +191,16 @@ WHERE c.name = $1
 	if err := tx.QueryRow(q, cdn).Scan(&monitorSnapshot); err != nil {
 		if err == sql.ErrNoRows {
 			// CDN doesn't exist
-			return "", false, nil
+			return monitorSnapshot, false, nil
 		}
-		return "", false, errors.New("Error querying monitor snapshot: " + err.Error())
+		return monitorSnapshot, false, errors.New("Error querying monitor snapshot: " + err.Error())
 	}
+	return monitorSnapshot, true, nil
+}
+
+// snapshotMonitoringOrGenerate returns the given stored monitor snapshot of
+// the given CDN, or generates one on-the-fly if none was stored.
+func snapshotMonitoringOrGenerate(tx *sql.Tx, cdn string, monitorSnapshot sql.NullString) (string, bool, error) {
 	if !monitorSnapshot.Valid || monitorSnapshot.String == "{}" {
 		log.Errorln("Monitoring Snapshot didn't exist! Generating on-the-fly! This will cause race conditions in Traffic Monitor until a Snapshot is created!")
 		monitoringJSON, err := monitoring.GetMonitoringJSON(tx, cdn)
@@ -123,3 +215,42 @@ WHERE c.name = $1
 	}
 	return monitorSnapshot.String, true, nil
 }
+
+// snapshotCacheTypes are the types of objects that stored Snapshots depend
+// on, for the purposes of the object cache.
+var snapshotCacheTypes = []string{"snapshot", "cdn"}
+
+// cachedSnapshot is a stored Snapshot, as it's kept in the object cache.
+type cachedSnapshot struct {
+	Snapshot  sql.NullString `json:"snapshot"`
+	CDNExists bool           `json:"cdnExists"`
+}
+
+// GetCachedSnapshot is like GetSnapshot, but gets the snapshot from the
+// object cache if it's there.
+func GetCachedSnapshot(ctx context.Context, tx *sql.Tx, cdn string) (string, bool, error) {
+	cached := cachedSnapshot{}
+	err := objectcache.Load(ctx, "snapshot/crconfig/"+cdn, snapshotCacheTypes, &cached, func() error {
+		snapshot, cdnExists, err := GetSnapshot(tx, cdn)
+		cached = cachedSnapshot{Snapshot: sql.NullString{String: snapshot, Valid: true}, CDNExists: cdnExists}
+		return err
+	})
+	return cached.Snapshot.String, cached.CDNExists, err
+}
+
+// GetCachedSnapshotMonitoring is like GetSnapshotMonitoring, but gets the
+// monitor snapshot from the object cache if it's there. Monitor snapshots
+// generated on-the-fly because none was stored aren't cached, since they
+// depend on much more than the snapshot table.
+func GetCachedSnapshotMonitoring(ctx context.Context, tx *sql.Tx, cdn string) (string, bool, error) {
+	cached := cachedSnapshot{}
+	err := objectcache.Load(ctx, "snapshot/monitoring/"+cdn, snapshotCacheTypes, &cached, func() error {
+		var err error
+		cached.Snapshot, cached.CDNExists, err = getStoredSnapshotMonitoring(tx, cdn)
+		return err
+	})
+	if err != nil || !cached.CDNExists {
+		return "", cached.CDNExists, err
+	}
+	return snapshotMonitoringOrGenerate(tx, cdn, cached.Snapshot)
+}
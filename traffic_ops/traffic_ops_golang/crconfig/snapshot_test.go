@@ -106,7 +106,9 @@ func (a Any) Match(v driver.Value) bool {
 }
 
 func MockSnapshot(mock sqlmock.Sqlmock, expected []byte, expectedtm []byte, cdn string) {
-	mock.ExpectExec("insert").WithArgs(cdn, expected, AnyTime{}, expectedtm).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery("SELECT monitoring").WithArgs(cdn).WillReturnRows(sqlmock.NewRows([]string{"monitoring", "monitoring_section_versions"}))
+	mock.ExpectQuery("nextval").WillReturnRows(sqlmock.NewRows([]string{"nextval"}).AddRow(1))
+	mock.ExpectExec("insert").WithArgs(cdn, expected, AnyTime{}, expectedtm, 1, []byte(`{}`)).WillReturnResult(sqlmock.NewResult(1, 1))
 }
 
 func TestSnapshot(t *testing.T) {
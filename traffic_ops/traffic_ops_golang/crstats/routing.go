@@ -57,21 +57,9 @@ const (
 )
 
 func getRoutersRouting(tx *sql.Tx, routers map[tc.CDNName][]string, statType *string, hostRegexs []string) (tc.Routing, error) {
-	forwardProxy, forwardProxyExists, err := dbhelpers.GetGlobalParam(tx, RouterProxyParameter)
+	client, err := getRouterClient(tx)
 	if err != nil {
-		return tc.Routing{}, errors.New("getting global router proxy parameter: " + err.Error())
-	}
-	client := &http.Client{Timeout: RouterRequestTimeout}
-	if forwardProxyExists {
-		proxyURI, err := url.Parse(forwardProxy)
-		if err != nil {
-			return tc.Routing{}, errors.New("router forward proxy '" + forwardProxy + "' in parameter '" + RouterProxyParameter + "' not a URI: " + err.Error())
-		}
-		clientTransport := &http.Transport{Proxy: http.ProxyURL(proxyURI)}
-		// Disable HTTP/2. Go Transport Proxy does not support H2 Servers, and if the server does support it, the client will fail.
-		// See https://github.com/golang/go/issues/26479 "We only support http1 proxies currently."
-		clientTransport.TLSNextProto = make(map[string]func(authority string, c *tls.Conn) http.RoundTripper)
-		client = &http.Client{Timeout: RouterRequestTimeout, Transport: clientTransport}
+		return tc.Routing{}, err
 	}
 
 	var hostRegex *regexp.Regexp
@@ -141,7 +129,7 @@ func addCRSStats(d RouterData, stats tc.CRSStats, statType *string, hostRegex *r
 		for host, stat := range stats.Stats.DNSMap {
 			if matchingHost(host) {
 				d.StatTotal = sumCRSStat(d.StatTotal, stat)
-				d.Total += totalCRSStat(stat)
+				d.Total += stat.Total()
 			}
 		}
 	}
@@ -151,26 +139,13 @@ func addCRSStats(d RouterData, stats tc.CRSStats, statType *string, hostRegex *r
 		for host, stat := range stats.Stats.HTTPMap {
 			if matchingHost(host) {
 				d.StatTotal = sumCRSStat(d.StatTotal, stat)
-				d.Total += totalCRSStat(stat)
+				d.Total += stat.Total()
 			}
 		}
 	}
 	return d
 }
 
-func totalCRSStat(s tc.CRSStatsStat) uint64 {
-	return s.CZCount +
-		s.GeoCount +
-		s.DeepCZCount +
-		s.MissCount +
-		s.DSRCount +
-		s.ErrCount +
-		s.StaticRouteCount +
-		s.FedCount +
-		s.RegionalDeniedCount +
-		s.RegionalAlternateCount
-}
-
 func sumCRSStat(a, b tc.CRSStatsStat) tc.CRSStatsStat {
 	return tc.CRSStatsStat{
 		CZCount:                a.CZCount + b.CZCount,
@@ -186,6 +161,28 @@ func sumCRSStat(a, b tc.CRSStatsStat) tc.CRSStatsStat {
 	}
 }
 
+// getRouterClient returns an HTTP client for requests to Traffic Routers,
+// which uses the forward proxy in the RouterProxyParameter global Parameter,
+// if it exists.
+func getRouterClient(tx *sql.Tx) (*http.Client, error) {
+	forwardProxy, forwardProxyExists, err := dbhelpers.GetGlobalParam(tx, RouterProxyParameter)
+	if err != nil {
+		return nil, errors.New("getting global router proxy parameter: " + err.Error())
+	}
+	if !forwardProxyExists {
+		return &http.Client{Timeout: RouterRequestTimeout}, nil
+	}
+	proxyURI, err := url.Parse(forwardProxy)
+	if err != nil {
+		return nil, errors.New("router forward proxy '" + forwardProxy + "' in parameter '" + RouterProxyParameter + "' not a URI: " + err.Error())
+	}
+	clientTransport := &http.Transport{Proxy: http.ProxyURL(proxyURI)}
+	// Disable HTTP/2. Go Transport Proxy does not support H2 Servers, and if the server does support it, the client will fail.
+	// See https://github.com/golang/go/issues/26479 "We only support http1 proxies currently."
+	clientTransport.TLSNextProto = make(map[string]func(authority string, c *tls.Conn) http.RoundTripper)
+	return &http.Client{Timeout: RouterRequestTimeout, Transport: clientTransport}, nil
+}
+
 func getCRSStats(respond chan<- RouterResp, wg *sync.WaitGroup, routerFQDN, cdn string, client *http.Client) {
 	defer wg.Done()
 	r := RouterResp{}
@@ -0,0 +1,278 @@
+package crstats
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+)
+
+// routerTraceGeolocation is a location, as given by Traffic Router's
+// /crs/routing/trace endpoint.
+type routerTraceGeolocation struct {
+	Latitude        float64 `json:"latitude"`
+	Longitude       float64 `json:"longitude"`
+	CountryCode     *string `json:"countryCode"`
+	City            *string `json:"city"`
+	PostalCode      *string `json:"postalCode"`
+	DefaultLocation bool    `json:"defaultLocation"`
+}
+
+// routerTrace is the response of Traffic Router's /crs/routing/trace
+// endpoint.
+type routerTrace struct {
+	ClientIP             string                  `json:"clientIp"`
+	Host                 string                  `json:"host"`
+	Path                 string                  `json:"path"`
+	Secure               bool                    `json:"secure"`
+	Geolocation          *routerTraceGeolocation `json:"geolocation"`
+	CoverageZone         *string                 `json:"coverageZone"`
+	DeepCoverageZone     *string                 `json:"deepCoverageZone"`
+	DeliveryService      *string                 `json:"deliveryService"`
+	Result               *string                 `json:"result"`
+	ResultDetails        *string                 `json:"resultDetails"`
+	ResultLocation       *routerTraceGeolocation `json:"resultLocation"`
+	FromBackupCacheGroup bool                    `json:"fromBackupCacheGroup"`
+	RegionalGeoResult    *string                 `json:"regionalGeoResult"`
+	URLs                 []string                `json:"urls"`
+	ResponseCode         *int                    `json:"responseCode"`
+	Error                *string                 `json:"error"`
+}
+
+// noResultDetails is the result details Traffic Router gives when it has
+// nothing to add to the result.
+const noResultDetails = "NO_DETAILS"
+
+// GetRoutingTrace is the handler for GET requests to
+// /cdns/{name}/routing/trace, which asks one of the CDN's Traffic Routers how
+// it would route an HTTP request from the client IP address to the host and
+// path given in the query string.
+func GetRoutingTrace(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"name", "ip", "host"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	cdn := inf.Params["name"]
+	if net.ParseIP(inf.Params["ip"]) == nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("ip: must be an IPv4 or IPv6 address"), nil)
+		return
+	}
+	secure := false
+	if secureStr, ok := inf.Params["secure"]; ok {
+		var err error
+		if secure, err = strconv.ParseBool(secureStr); err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("secure: must be a boolean"), nil)
+			return
+		}
+	}
+
+	if ok, err := dbhelpers.CDNExists(cdn, inf.Tx.Tx); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("checking CDN existence: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no such CDN: "+cdn), nil)
+		return
+	}
+
+	routers, err := getCDNRouterFQDNs(inf.Tx.Tx, &cdn)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting routers: "+err.Error()))
+		return
+	}
+	router, ok := selectRouter(routers[tc.CDNName(cdn)], inf.Params["router"])
+	if !ok {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("no "+RouterOnlineStatus+" Traffic Router found for CDN "+cdn), nil)
+		return
+	}
+
+	client, err := getRouterClient(inf.Tx.Tx)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	query := url.Values{}
+	query.Set("ip", inf.Params["ip"])
+	query.Set("host", inf.Params["host"])
+	if path, ok := inf.Params["path"]; ok {
+		query.Set("path", path)
+	}
+	query.Set("secure", strconv.FormatBool(secure))
+	trTrace, userErr, sysErr := getRouterTrace(client, router, query)
+	if userErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	if sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, sysErr)
+		return
+	}
+
+	trace := normalizeRouterTrace(router, trTrace)
+	if host := cacheHostFromURLs(trace.URLs); host != "" {
+		var hostName string
+		err := inf.Tx.Tx.QueryRow(cdnServerHostNameQuery, host, cdn).Scan(&hostName)
+		if err == nil {
+			trace.Cache = &hostName
+		} else if !errors.Is(err, sql.ErrNoRows) {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting cache server from routing trace: "+err.Error()))
+			return
+		}
+	}
+	api.WriteResp(w, r, trace)
+}
+
+// cdnServerHostNameQuery checks that a host name is that of a server in a
+// CDN.
+const cdnServerHostNameQuery = `
+SELECT s.host_name
+FROM server AS s
+JOIN cdn AS c ON c.id = s.cdn_id
+WHERE s.host_name = $1
+AND c.name = $2
+`
+
+// selectRouter returns the router FQDN, from those given, whose host name is
+// name, or a random one if name is empty. The returned boolean is false if
+// there is no such router.
+func selectRouter(routers []string, name string) (string, bool) {
+	if name == "" {
+		if len(routers) == 0 {
+			return "", false
+		}
+		return routers[rand.Intn(len(routers))], true
+	}
+	for _, router := range routers {
+		if strings.SplitN(router, ".", 2)[0] == name {
+			return router, true
+		}
+	}
+	return "", false
+}
+
+// getRouterTrace requests a routing trace from the Traffic Router with the
+// given FQDN (including its API port).
+func getRouterTrace(client *http.Client, router string, query url.Values) (routerTrace, error, error) {
+	trace := routerTrace{}
+	resp, err := client.Get("http://" + router + "/crs/routing/trace?" + query.Encode())
+	if err != nil {
+		return trace, nil, fmt.Errorf("requesting routing trace from router %s: %w", router, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return trace, nil, fmt.Errorf("reading routing trace from router %s: %w", router, err)
+	}
+	if resp.StatusCode == http.StatusBadRequest {
+		trErr := struct {
+			Error string `json:"error"`
+		}{}
+		if err := json.Unmarshal(body, &trErr); err == nil && trErr.Error != "" {
+			return trace, errors.New(trErr.Error), nil
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return trace, nil, fmt.Errorf("router %s returned %d tracing routing: %s", router, resp.StatusCode, string(body))
+	}
+	if err := json.Unmarshal(body, &trace); err != nil {
+		return trace, nil, fmt.Errorf("decoding routing trace from router %s: %w", router, err)
+	}
+	return trace, nil, nil
+}
+
+// normalizeRouterTrace converts a routing trace given by the Traffic Router
+// with the given FQDN into its Traffic Ops API form.
+func normalizeRouterTrace(router string, t routerTrace) tc.RoutingTrace {
+	trace := tc.RoutingTrace{
+		Router:               strings.SplitN(router, ":", 2)[0],
+		ClientIP:             t.ClientIP,
+		Host:                 t.Host,
+		Path:                 t.Path,
+		Secure:               t.Secure,
+		Geolocation:          normalizeRouterTraceGeolocation(t.Geolocation),
+		CoverageZone:         t.CoverageZone,
+		DeepCoverageZone:     t.DeepCoverageZone,
+		DeliveryService:      t.DeliveryService,
+		URLs:                 t.URLs,
+		ReasonCodes:          []string{},
+		ResultLocation:       normalizeRouterTraceGeolocation(t.ResultLocation),
+		FromBackupCacheGroup: t.FromBackupCacheGroup,
+		ResponseCode:         t.ResponseCode,
+		Error:                t.Error,
+	}
+	if trace.URLs == nil {
+		trace.URLs = []string{}
+	}
+	if t.Result != nil {
+		trace.Result = *t.Result
+		trace.ReasonCodes = append(trace.ReasonCodes, *t.Result)
+	}
+	if t.ResultDetails != nil && *t.ResultDetails != noResultDetails {
+		trace.ReasonCodes = append(trace.ReasonCodes, *t.ResultDetails)
+	}
+	if t.RegionalGeoResult != nil {
+		trace.ReasonCodes = append(trace.ReasonCodes, "REGIONAL_GEO_"+*t.RegionalGeoResult)
+	}
+	return trace
+}
+
+func normalizeRouterTraceGeolocation(g *routerTraceGeolocation) *tc.RoutingTraceGeolocation {
+	if g == nil {
+		return nil
+	}
+	return &tc.RoutingTraceGeolocation{
+		Latitude:        g.Latitude,
+		Longitude:       g.Longitude,
+		CountryCode:     g.CountryCode,
+		City:            g.City,
+		PostalCode:      g.PostalCode,
+		DefaultLocation: g.DefaultLocation,
+	}
+}
+
+// cacheHostFromURLs returns the host name of the cache server to which the
+// first of the given routing URLs points, which is the first label of its
+// host, or an empty string if there are no URLs.
+func cacheHostFromURLs(urls []string) string {
+	if len(urls) == 0 {
+		return ""
+	}
+	u, err := url.Parse(urls[0])
+	if err != nil {
+		return ""
+	}
+	return strings.SplitN(u.Hostname(), ".", 2)[0]
+}
@@ -0,0 +1,117 @@
+package crstats
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGetRouterTrace(t *testing.T) {
+	var gotQuery url.Values
+	router := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/crs/routing/trace" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotQuery = r.URL.Query()
+		w.Write([]byte(`{
+			"clientIp": "192.0.2.1",
+			"host": "video.demo1.mycdn.ciab.test",
+			"path": "/index.m3u8",
+			"secure": false,
+			"geolocation": {"latitude": 38.9, "longitude": -77.0, "countryCode": "US", "city": null, "postalCode": null, "defaultLocation": false},
+			"coverageZone": "CDN_in_a_Box_Edge",
+			"deepCoverageZone": null,
+			"deliveryService": "demo1",
+			"result": "CZ",
+			"resultDetails": "NO_DETAILS",
+			"resultLocation": {"latitude": 38.9, "longitude": -77.0, "countryCode": null, "city": null, "postalCode": null, "defaultLocation": false},
+			"fromBackupCacheGroup": false,
+			"regionalGeoResult": null,
+			"urls": ["http://edge.demo1.mycdn.ciab.test/index.m3u8"],
+			"responseCode": null
+		}`))
+	}))
+	defer router.Close()
+
+	fqdn := strings.TrimPrefix(router.URL, "http://")
+	query := url.Values{"ip": {"192.0.2.1"}, "host": {"video.demo1.mycdn.ciab.test"}, "path": {"/index.m3u8"}}
+	trTrace, userErr, sysErr := getRouterTrace(router.Client(), fqdn, query)
+	if userErr != nil || sysErr != nil {
+		t.Fatalf("unexpected error getting router trace: %v %v", userErr, sysErr)
+	}
+	if !reflect.DeepEqual(gotQuery, query) {
+		t.Errorf("expected router to be queried with %v, got %v", query, gotQuery)
+	}
+
+	trace := normalizeRouterTrace(fqdn, trTrace)
+	if trace.Router != "127.0.0.1" {
+		t.Errorf("expected router '127.0.0.1', got '%s'", trace.Router)
+	}
+	if trace.DeliveryService == nil || *trace.DeliveryService != "demo1" {
+		t.Errorf("expected delivery service 'demo1', got %v", trace.DeliveryService)
+	}
+	if trace.Result != "CZ" || !reflect.DeepEqual(trace.ReasonCodes, []string{"CZ"}) {
+		t.Errorf("expected result 'CZ' with reason codes [CZ], got '%s' with %v", trace.Result, trace.ReasonCodes)
+	}
+	if trace.Geolocation == nil || trace.Geolocation.CountryCode == nil || *trace.Geolocation.CountryCode != "US" {
+		t.Errorf("expected client geolocation in US, got %+v", trace.Geolocation)
+	}
+	if host := cacheHostFromURLs(trace.URLs); host != "edge" {
+		t.Errorf("expected cache host 'edge', got '%s'", host)
+	}
+}
+
+func TestGetRouterTraceBadRequest(t *testing.T) {
+	router := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "invalid host or path"}`))
+	}))
+	defer router.Close()
+
+	_, userErr, sysErr := getRouterTrace(router.Client(), strings.TrimPrefix(router.URL, "http://"), url.Values{})
+	if sysErr != nil {
+		t.Errorf("unexpected system error: %v", sysErr)
+	}
+	if userErr == nil || userErr.Error() != "invalid host or path" {
+		t.Errorf("expected Traffic Router's error as a user error, got %v", userErr)
+	}
+}
+
+func TestSelectRouter(t *testing.T) {
+	routers := []string{"tr1.example.test:3333", "tr2.example.test:3333"}
+	if router, ok := selectRouter(routers, "tr2"); !ok || router != "tr2.example.test:3333" {
+		t.Errorf("expected to select tr2.example.test:3333, got '%s' (%t)", router, ok)
+	}
+	if _, ok := selectRouter(routers, "tr3"); ok {
+		t.Error("expected no router named tr3")
+	}
+	if router, ok := selectRouter(routers, ""); !ok || (router != routers[0] && router != routers[1]) {
+		t.Errorf("expected to select one of %v, got '%s' (%t)", routers, router, ok)
+	}
+	if _, ok := selectRouter(nil, ""); ok {
+		t.Error("expected no router to be selected from none")
+	}
+}
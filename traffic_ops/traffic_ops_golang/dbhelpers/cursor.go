@@ -0,0 +1,279 @@
+package dbhelpers
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// cursor is the position in a collection from which a page starts,
+// encoded into the opaque cursors given to clients.
+type cursor struct {
+	// OrderBy is the query parameter by which the collection is sorted, if
+	// any, in addition to "id".
+	OrderBy string `json:"o,omitempty"`
+	Desc    bool   `json:"d,omitempty"`
+	// Value is the JSON-encoded value of the OrderBy field of the object at
+	// the position.
+	Value json.RawMessage `json:"v,omitempty"`
+	// ID is the JSON-encoded ID of the object at the position.
+	ID json.RawMessage `json:"i"`
+	// Before is whether the page is the one before the position, rather
+	// than after it.
+	Before bool `json:"b,omitempty"`
+}
+
+func (c cursor) encode() string {
+	bts, _ := json.Marshal(c) // can't fail
+	return base64.RawURLEncoding.EncodeToString(bts)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	c := cursor{}
+	bts, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, errors.New("malformed cursor")
+	}
+	if err := json.Unmarshal(bts, &c); err != nil || len(c.ID) == 0 {
+		return c, errors.New("malformed cursor")
+	}
+	return c, nil
+}
+
+// CursorPage is a page of a collection requested with cursor pagination.
+type CursorPage struct {
+	orderBy string
+	desc    bool
+	limit   int
+	// from is the position the page starts from, or nil for the first page.
+	from *cursor
+}
+
+// BuildCursorPagination replaces the ORDER BY and pagination clauses built
+// by BuildWhereAndOrderByAndPagination with those for cursor pagination,
+// adding the position of the requested cursor, if any, to the WHERE clause.
+// Unlike offset pagination, this is as fast for the last page of a large
+// collection as for the first, and doesn't skip or repeat objects when
+// others are created or deleted between requests.
+//
+// Cursor pagination is used when the "limit" query parameter is given
+// without "offset" or "page", and the collection can be filtered by "id",
+// which is used to break ties between objects equal in the sorted column.
+// Otherwise, the given clauses are returned unchanged along with a nil
+// CursorPage, and an error if a cursor was nevertheless given.
+//
+// When the returned CursorPage isn't nil, the results of the query must be
+// passed to CursorPagination.
+func BuildCursorPagination(parameters map[string]string, queryParamsToSQLCols map[string]WhereColumnInfo, where, orderBy, pagination string, queryValues map[string]interface{}) (string, string, string, *CursorPage, error) {
+	cursorStr, hasCursor := parameters[tc.CursorQueryParam]
+	_, hasOffset := parameters["offset"]
+	_, hasPage := parameters["page"]
+	idCol, hasID := queryParamsToSQLCols["id"]
+	limit, err := strconv.Atoi(parameters["limit"])
+	if err != nil || limit < 1 || hasOffset || hasPage || !hasID {
+		if hasCursor {
+			return "", "", "", nil, errors.New("the cursor parameter requires a positive limit, and cannot be used with offset or page")
+		}
+		return where, orderBy, pagination, nil, nil
+	}
+
+	page := CursorPage{limit: limit}
+	var sortCol string
+	if ob, ok := parameters["orderby"]; ok && ob != "id" {
+		if colInfo, ok := queryParamsToSQLCols[ob]; ok {
+			page.orderBy = ob
+			sortCol = colInfo.Column
+		}
+	}
+	page.desc = parameters["sortOrder"] == "desc"
+
+	if hasCursor && cursorStr != "" {
+		c, err := decodeCursor(cursorStr)
+		if err != nil {
+			return "", "", "", nil, err
+		}
+		if c.OrderBy != page.orderBy || c.Desc != page.desc {
+			return "", "", "", nil, errors.New("the cursor does not match the requested orderby and sortOrder")
+		}
+		page.from = &c
+	}
+
+	// The query is in reverse order when reading the page before a cursor;
+	// CursorPagination puts the results back in order.
+	desc := page.desc
+	if page.from != nil && page.from.Before {
+		desc = !desc
+	}
+	dir, op := "", ">"
+	if desc {
+		dir, op = " DESC", "<"
+	}
+
+	if page.from != nil {
+		cond, err := cursorCondition(sortCol, idCol.Column, op, desc, *page.from, queryValues)
+		if err != nil {
+			return "", "", "", nil, err
+		}
+		if where == "" {
+			where = BaseWhere + " " + cond
+		} else {
+			where += " AND " + cond
+		}
+	}
+
+	orderBy = BaseOrderBy + " "
+	if sortCol != "" {
+		orderBy += sortCol + dir + ", "
+	}
+	orderBy += idCol.Column + dir
+	pagination = BaseLimit + " " + strconv.Itoa(limit)
+	return where, orderBy, pagination, &page, nil
+}
+
+// cursorCondition returns the condition selecting the objects after the
+// given position in the order of the query. NULLs are sorted after all other
+// values in ascending order, and before them in descending order.
+func cursorCondition(sortCol, idCol, op string, desc bool, from cursor, queryValues map[string]interface{}) (string, error) {
+	id, err := cursorValue(from.ID)
+	if err != nil || id == nil {
+		return "", errors.New("malformed cursor")
+	}
+	queryValues["cursor_id"] = id
+	idCond := idCol + " " + op + " :cursor_id"
+	if sortCol == "" {
+		return idCond, nil
+	}
+
+	val, err := cursorValue(from.Value)
+	if err != nil {
+		return "", errors.New("malformed cursor")
+	}
+	if val == nil {
+		if desc {
+			return "(" + sortCol + " IS NOT NULL OR " + idCond + ")", nil
+		}
+		return "(" + sortCol + " IS NULL AND " + idCond + ")", nil
+	}
+	queryValues["cursor_value"] = val
+	cond := "(" + sortCol + " " + op + " :cursor_value OR (" + sortCol + " = :cursor_value AND " + idCond + ")"
+	if !desc {
+		cond += " OR " + sortCol + " IS NULL"
+	}
+	return cond + ")", nil
+}
+
+// cursorValue returns the given JSON value in a form that can be bound to a
+// query parameter, or nil for JSON null. Numbers are returned as strings so
+// that they are compared as whatever type the column has.
+func cursorValue(raw json.RawMessage) (interface{}, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	switch v := v.(type) {
+	case nil, string, bool:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	}
+	return nil, errors.New("unsupported cursor value")
+}
+
+// CursorPagination returns the cursors of the pages adjacent to the given
+// page of results, the results of a query built by BuildCursorPagination,
+// putting them in order if necessary. The cursors hold the JSON-encoded
+// "id" and sorted field of the objects at the edges of the page, so the
+// query parameter by which the collection is sorted must be the same as the
+// name of that field.
+//
+// Note that some types - tc.TimeNoMod, for instance - are encoded with less
+// precision than they have in the database, which may cause objects to
+// appear on two pages when the collection is sorted by fields of those
+// types.
+func CursorPagination[T any](page *CursorPage, results []T) *tc.Pagination {
+	if page == nil {
+		return nil
+	}
+	before := page.from != nil && page.from.Before
+	if before {
+		for i, j := 0, len(results)-1; i < j; i, j = i+1, j-1 {
+			results[i], results[j] = results[j], results[i]
+		}
+	}
+	if len(results) == 0 {
+		return &tc.Pagination{}
+	}
+
+	pagination := tc.Pagination{}
+	full := len(results) == page.limit
+	if (!before && full) || (before && page.from != nil) {
+		c, err := page.position(results[len(results)-1])
+		if err != nil {
+			log.Warnln("building next page cursor: " + err.Error())
+			return nil
+		}
+		pagination.Next = c.encode()
+	}
+	if (before && full) || (!before && page.from != nil) {
+		c, err := page.position(results[0])
+		if err != nil {
+			log.Warnln("building previous page cursor: " + err.Error())
+			return nil
+		}
+		c.Before = true
+		pagination.Prev = c.encode()
+	}
+	return &pagination
+}
+
+// position returns the cursor of the position of the given object.
+func (page *CursorPage) position(obj interface{}) (cursor, error) {
+	c := cursor{OrderBy: page.orderBy, Desc: page.desc}
+	bts, err := json.Marshal(obj)
+	if err != nil {
+		return c, errors.New("encoding object: " + err.Error())
+	}
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(bts, &fields); err != nil {
+		return c, errors.New("decoding object fields: " + err.Error())
+	}
+	var ok bool
+	if c.ID, ok = fields["id"]; !ok {
+		return c, errors.New("object has no 'id' field")
+	}
+	if page.orderBy != "" {
+		if c.Value, ok = fields[page.orderBy]; !ok {
+			return c, errors.New("object has no '" + page.orderBy + "' field")
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,176 @@
+package dbhelpers
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+type cursorTestObj struct {
+	ID   int     `json:"id"`
+	Name *string `json:"name"`
+}
+
+var cursorTestCols = map[string]WhereColumnInfo{
+	"id":   {Column: "t.id"},
+	"name": {Column: "t.name"},
+}
+
+func buildCursorTest(t *testing.T, params map[string]string) (string, string, string, map[string]interface{}, *CursorPage) {
+	t.Helper()
+	where, orderBy, pagination, queryValues, errs := BuildWhereAndOrderByAndPagination(params, cursorTestCols)
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors building query: %v", errs)
+	}
+	where, orderBy, pagination, page, err := BuildCursorPagination(params, cursorTestCols, where, orderBy, pagination, queryValues)
+	if err != nil {
+		t.Fatalf("unexpected error building cursor pagination: %v", err)
+	}
+	return where, orderBy, pagination, queryValues, page
+}
+
+func TestBuildCursorPaginationNotUsed(t *testing.T) {
+	for _, params := range []map[string]string{
+		{},
+		{"limit": "2", "offset": "2"},
+		{"limit": "2", "page": "2"},
+		{"limit": "-1"},
+	} {
+		where, orderBy, pagination, _, errs := BuildWhereAndOrderByAndPagination(params, cursorTestCols)
+		if len(errs) > 0 {
+			t.Fatalf("unexpected errors building query for %v: %v", params, errs)
+		}
+		w, o, p, page, err := BuildCursorPagination(params, cursorTestCols, where, orderBy, pagination, map[string]interface{}{})
+		if err != nil {
+			t.Errorf("unexpected error for %v: %v", params, err)
+		}
+		if page != nil {
+			t.Errorf("expected no cursor pagination for %v", params)
+		}
+		if w != where || o != orderBy || p != pagination {
+			t.Errorf("expected clauses for %v to be unchanged, got '%s', '%s', '%s'", params, w, o, p)
+		}
+
+		params[tc.CursorQueryParam] = cursor{ID: []byte("1")}.encode()
+		if _, _, _, _, err := BuildCursorPagination(params, cursorTestCols, where, orderBy, pagination, map[string]interface{}{}); err == nil {
+			t.Errorf("expected an error for a cursor with %v", params)
+		}
+	}
+}
+
+func TestBuildCursorPaginationErrors(t *testing.T) {
+	sorted := cursor{OrderBy: "name", Value: []byte(`"a"`), ID: []byte("1")}.encode()
+	for name, params := range map[string]map[string]string{
+		"malformed":            {"limit": "2", tc.CursorQueryParam: "not a cursor"},
+		"different orderby":    {"limit": "2", tc.CursorQueryParam: sorted},
+		"different sort order": {"limit": "2", "orderby": "name", "sortOrder": "desc", tc.CursorQueryParam: sorted},
+		"non-scalar value":     {"limit": "2", "orderby": "name", tc.CursorQueryParam: cursor{OrderBy: "name", Value: []byte("{}"), ID: []byte("1")}.encode()},
+		"null id":              {"limit": "2", tc.CursorQueryParam: cursor{ID: []byte("null")}.encode()},
+	} {
+		if _, _, _, _, err := BuildCursorPagination(params, cursorTestCols, "", "", "", map[string]interface{}{}); err == nil {
+			t.Errorf("expected an error for a %s cursor", name)
+		}
+	}
+}
+
+func TestCursorPagination(t *testing.T) {
+	params := map[string]string{"limit": "2", "orderby": "name"}
+	where, orderBy, pagination, _, page := buildCursorTest(t, params)
+	if where != "" {
+		t.Errorf("expected no WHERE clause for the first page, got '%s'", where)
+	}
+	if expected := BaseOrderBy + " t.name, t.id"; orderBy != expected {
+		t.Errorf("expected ORDER BY clause '%s', got '%s'", expected, orderBy)
+	}
+	if expected := BaseLimit + " 2"; pagination != expected {
+		t.Errorf("expected pagination clause '%s', got '%s'", expected, pagination)
+	}
+
+	first := []cursorTestObj{{ID: 3, Name: util.StrPtr("a")}, {ID: 1, Name: util.StrPtr("b")}}
+	cursors := CursorPagination(page, first)
+	if cursors == nil || cursors.Next == "" || cursors.Prev != "" {
+		t.Fatalf("expected only a next page cursor for the first page, got %+v", cursors)
+	}
+
+	params[tc.CursorQueryParam] = cursors.Next
+	where, orderBy, _, queryValues, page := buildCursorTest(t, params)
+	if expected := BaseWhere + " (t.name > :cursor_value OR (t.name = :cursor_value AND t.id > :cursor_id) OR t.name IS NULL)"; where != expected {
+		t.Errorf("expected WHERE clause '%s', got '%s'", expected, where)
+	}
+	if queryValues["cursor_value"] != "b" || queryValues["cursor_id"] != "1" {
+		t.Errorf("expected the cursor's position in the query values, got %v", queryValues)
+	}
+
+	second := []cursorTestObj{{ID: 2, Name: nil}}
+	cursors = CursorPagination(page, second)
+	if cursors == nil || cursors.Next != "" || cursors.Prev == "" {
+		t.Fatalf("expected only a previous page cursor for the last page, got %+v", cursors)
+	}
+
+	params[tc.CursorQueryParam] = cursors.Prev
+	where, orderBy, _, queryValues, page = buildCursorTest(t, params)
+	if expected := BaseWhere + " (t.name IS NOT NULL OR t.id < :cursor_id)"; where != expected {
+		t.Errorf("expected WHERE clause '%s', got '%s'", expected, where)
+	}
+	if expected := BaseOrderBy + " t.name DESC, t.id DESC"; orderBy != expected {
+		t.Errorf("expected the previous page to be read in reverse, got ORDER BY clause '%s'", orderBy)
+	}
+	if _, ok := queryValues["cursor_value"]; ok {
+		t.Errorf("expected no value to be bound for a null cursor value, got %v", queryValues["cursor_value"])
+	}
+
+	// The page before the last, read in reverse order.
+	reversed := []cursorTestObj{first[1], first[0]}
+	cursors = CursorPagination(page, reversed)
+	if reversed[0].ID != 3 || reversed[1].ID != 1 {
+		t.Errorf("expected the previous page to be put back in order, got %+v", reversed)
+	}
+	if cursors == nil || cursors.Next == "" || cursors.Prev == "" {
+		t.Fatalf("expected both cursors for a full previous page, got %+v", cursors)
+	}
+	next, err := decodeCursor(cursors.Next)
+	if err != nil {
+		t.Fatalf("unexpected error decoding next page cursor: %v", err)
+	}
+	if next.Before || string(next.ID) != "1" || string(next.Value) != `"b"` {
+		t.Errorf("expected the next page cursor to follow the last object, got %+v", next)
+	}
+}
+
+func TestCursorPaginationDescending(t *testing.T) {
+	params := map[string]string{"limit": "1", "sortOrder": "desc", tc.CursorQueryParam: cursor{Desc: true, ID: []byte("5")}.encode()}
+	where, orderBy, _, queryValues, page := buildCursorTest(t, params)
+	if expected := BaseWhere + " t.id < :cursor_id"; where != expected {
+		t.Errorf("expected WHERE clause '%s', got '%s'", expected, where)
+	}
+	if expected := BaseOrderBy + " t.id DESC"; orderBy != expected {
+		t.Errorf("expected ORDER BY clause '%s', got '%s'", expected, orderBy)
+	}
+	if queryValues["cursor_id"] != "5" {
+		t.Errorf("expected cursor ID '5', got %v", queryValues["cursor_id"])
+	}
+
+	if cursors := CursorPagination(page, []cursorTestObj{}); cursors == nil || *cursors != (tc.Pagination{}) {
+		t.Errorf("expected empty cursors for an empty page, got %+v", cursors)
+	}
+}
@@ -605,8 +605,8 @@ func GetDSTenantIDFromXMLID(tx *sql.Tx, xmlid string) (int, bool, error) {
 }
 
 // GetServerDSNamesByCDN returns a map of ONLINE/REPORTED/ADMIN_DOWN cache names to slice of
-// strings which are the XML IDs of the active, non-ANYMAP delivery services to which the cache
-// is assigned in the given CDN.
+// strings which are the XML IDs of the active (or scheduled to become active), non-ANYMAP
+// delivery services to which the cache is assigned in the given CDN.
 func GetServerDSNamesByCDN(tx *sql.Tx, cdn string) (map[tc.CacheName][]string, error) {
 	q := `
 SELECT s.host_name, ds.xml_id
@@ -617,7 +617,7 @@ INNER JOIN type AS dt ON dt.id = ds.type
 INNER JOIN profile AS p ON p.id = s.profile
 INNER JOIN status AS st ON st.id = s.status
 WHERE ds.cdn_id = (SELECT id FROM cdn WHERE name = $1)
-AND ds.active = true
+AND (ds.active = true OR ds.active_at IS NOT NULL)
 AND dt.name != '` + tc.DSTypeAnyMap.String() + `'
 AND p.routing_disabled = false
 AND (st.name = '` + tc.CacheStatusOnline.String() + `' OR st.name = '` + tc.CacheStatusReported.String() + `' OR st.name = '` + tc.CacheStatusAdminDown.String() + `')
@@ -1001,6 +1001,25 @@ func GetCDNDomainFromName(tx *sql.Tx, cdnName tc.CDNName) (string, bool, error)
 	return domain, true, nil
 }
 
+// GetCDNSOA returns the SOA of the CDN with the given name, whether the CDN
+// has one, and any error.
+func GetCDNSOA(tx *sql.Tx, cdnName tc.CDNName) (tc.CDNSOA, bool, error) {
+	qry := `
+SELECT s.admin, s.serial_policy, s.serial, s.refresh, s.retry, s.expire, s.negative_ttl, s.soa_ttl, s.ns_ttl, s.nameservers, s.last_updated
+FROM cdn_soa AS s
+JOIN cdn ON cdn.id = s.cdn
+WHERE cdn.name = $1
+`
+	soa := tc.CDNSOA{CDNName: string(cdnName), Nameservers: []string{}}
+	if err := tx.QueryRow(qry, cdnName).Scan(&soa.Admin, &soa.SerialPolicy, &soa.Serial, &soa.RefreshSeconds, &soa.RetrySeconds, &soa.ExpireSeconds, &soa.NegativeTTLSeconds, &soa.SOATTLSeconds, &soa.NSTTLSeconds, pq.Array(&soa.Nameservers), &soa.LastUpdated); err != nil {
+		if err == sql.ErrNoRows {
+			return soa, false, nil
+		}
+		return soa, false, errors.New("querying CDN SOA: " + err.Error())
+	}
+	return soa, true, nil
+}
+
 // GetServerInterfaces, given the IDs of one or more servers, returns all of their network
 // interfaces mapped by their ids, or an error if one occurs during retrieval.
 func GetServersInterfaces(ids []int, tx *sql.Tx) (map[int]map[string]tc.ServerInterfaceInfoV40, error) {
@@ -1784,7 +1803,7 @@ func GetDSIDFromStaticDNSEntry(tx *sql.Tx, staticDNSEntryID int) (int, error) {
 	var dsID int
 	query := `SELECT deliveryservice FROM staticdnsentry WHERE id = $1`
 	if err := tx.QueryRow(query, staticDNSEntryID).Scan(&dsID); err != nil {
-		return -1, errors.New("querying DS ID from static dns entry: " + err.Error())
+		return -1, fmt.Errorf("querying DS ID from static dns entry: %w", err)
 	}
 	return dsID, nil
 }
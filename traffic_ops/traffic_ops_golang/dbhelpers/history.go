@@ -0,0 +1,66 @@
+package dbhelpers
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"time"
+)
+
+// AsOfQueryParam is the query parameter with which a client asks for objects
+// as they were at some time in the past, rather than as they are now.
+const AsOfQueryParam = "asOf"
+
+// ParseAsOf returns the time given by the AsOfQueryParam in params, or nil if
+// there isn't one. The returned error, if not nil, is safe to show to the
+// client.
+func ParseAsOf(params map[string]string) (*time.Time, error) {
+	asOfStr, ok := params[AsOfQueryParam]
+	if !ok {
+		return nil, nil
+	}
+	asOf, err := time.Parse(time.RFC3339, asOfStr)
+	if err != nil {
+		return nil, errors.New(AsOfQueryParam + " must be an RFC3339 date and time, e.g. 2022-06-21T03:12:00Z")
+	}
+	return &asOf, nil
+}
+
+// TableAsOf returns a sub-query selecting the rows of the given table as they
+// were at the time bound to the named query parameter AsOfQueryParam, for use
+// in place of the table in a FROM clause. The table must have its changes
+// recorded in the config_history table.
+//
+// A row is as it was at that time if it hasn't been changed since; otherwise
+// the version that was in effect then is read from its history. Rows that
+// were created afterwards, or that were deleted before then, aren't selected.
+// Because the versions are stored as JSON, columns added to the table since a
+// version was recorded are NULL in it.
+func TableAsOf(table string) string {
+	return `(
+SELECT * FROM ` + table + ` WHERE last_updated <= :` + AsOfQueryParam + `
+UNION ALL
+SELECT (jsonb_populate_record(CAST(NULL AS ` + table + `), h.row_data)).*
+FROM config_history AS h
+WHERE h.table_name = '` + table + `'
+AND h.valid_from <= :` + AsOfQueryParam + `
+AND h.valid_to > :` + AsOfQueryParam + `
+)`
+}
@@ -0,0 +1,65 @@
+package dbhelpers
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseAsOf(t *testing.T) {
+	asOf, err := ParseAsOf(map[string]string{})
+	if err != nil || asOf != nil {
+		t.Errorf("expected no time and no error without %s, got %v, %v", AsOfQueryParam, asOf, err)
+	}
+
+	asOf, err = ParseAsOf(map[string]string{AsOfQueryParam: "2022-06-21T03:12:00-06:00"})
+	if err != nil {
+		t.Fatalf("unexpected error parsing an RFC3339 time: %v", err)
+	}
+	if expected := time.Date(2022, time.June, 21, 9, 12, 0, 0, time.UTC); asOf == nil || !asOf.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, asOf)
+	}
+
+	for _, v := range []string{"", "yesterday", "2022-06-21", "1655781120"} {
+		if _, err := ParseAsOf(map[string]string{AsOfQueryParam: v}); err == nil {
+			t.Errorf("expected an error parsing '%s'", v)
+		}
+	}
+}
+
+func TestTableAsOf(t *testing.T) {
+	q := TableAsOf("server")
+	for _, expected := range []string{
+		"FROM server WHERE last_updated <= :asOf",
+		"CAST(NULL AS server)",
+		"h.table_name = 'server'",
+		"h.valid_from <= :asOf",
+		"h.valid_to > :asOf",
+	} {
+		if !strings.Contains(q, expected) {
+			t.Errorf("expected sub-query to contain '%s', got: %s", expected, q)
+		}
+	}
+	if !strings.HasPrefix(q, "(") || !strings.HasSuffix(q, ")") {
+		t.Errorf("expected a parenthesized sub-query, got: %s", q)
+	}
+}
@@ -0,0 +1,63 @@
+package dbhelpers
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// MonitoredCDNsQuery returns a sub-query selecting the names of the CDNs
+// which the server aliased as serverAlias monitors besides its own, as
+// "cdn", with the ports on which it serves their monitoring data, as "port",
+// for use in a LATERAL join. They're given by the tc.MonitoredCDNParameterName
+// Parameters of its Profile.
+func MonitoredCDNsQuery(serverAlias string) string {
+	return `
+SELECT monitored.name AS cdn, CAST(substring(pa.value FROM ':([0-9]{1,5})$') AS bigint) AS port
+FROM profile_parameter AS pp
+JOIN parameter AS pa ON pa.id = pp.parameter
+JOIN cdn AS monitored ON monitored.name = substring(pa.value FROM '^(.+):[0-9]{1,5}$')
+WHERE pp.profile = ` + serverAlias + `.profile
+AND pa.name = '` + tc.MonitoredCDNParameterName + `'
+AND pa.config_file = '` + tc.MonitoredCDNConfigFile + `'
+AND monitored.id <> ` + serverAlias + `.cdn_id
+`
+}
+
+// MonitoredCDNPortQuery returns a sub-query selecting the port on which the
+// server aliased as serverAlias serves the monitoring data of the CDN named
+// by the bind parameter cdnParam, if it monitors that CDN besides its own,
+// for use in a LATERAL join.
+func MonitoredCDNPortQuery(serverAlias, cdnParam string) string {
+	return `SELECT m.port FROM (` + MonitoredCDNsQuery(serverAlias) + `) AS m WHERE m.cdn = ` + cdnParam + ` LIMIT 1`
+}
+
+// OtherCDNMonitorsQuery returns a query selecting the IDs of the Traffic
+// Monitors of other CDNs which also monitor the CDN named by the bind
+// parameter cdnParam.
+func OtherCDNMonitorsQuery(cdnParam string) string {
+	return `
+SELECT mon.id
+FROM server AS mon
+JOIN type AS mon_type ON mon_type.id = mon.type
+CROSS JOIN LATERAL (` + MonitoredCDNPortQuery("mon", cdnParam) + `) AS mc
+WHERE mon_type.name = '` + tc.MonitorTypeName + `'
+`
+}
@@ -0,0 +1,191 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+)
+
+// selectCacheHitsQuery selects the cache hits and misses of a Delivery
+// Service by tier, in the windows within a time range.
+const selectCacheHitsQuery = `
+SELECT tier, COALESCE(SUM(hits), 0), COALESCE(SUM(misses), 0)
+FROM deliveryservice_cache_hits
+WHERE deliveryservice = $1
+AND window_start >= $2
+AND window_end <= $3
+GROUP BY tier
+`
+
+// selectCacheMissesQuery selects the URLs most often sampled missing cache
+// servers of a tier for a Delivery Service, in the windows within a time
+// range.
+const selectCacheMissesQuery = `
+SELECT sample->>'url' AS url, SUM((sample->>'count')::bigint) AS count
+FROM deliveryservice_cache_hits AS h
+CROSS JOIN LATERAL jsonb_array_elements(h.miss_samples) AS sample
+WHERE h.deliveryservice = $1
+AND h.window_start >= $2
+AND h.window_end <= $3
+AND h.tier = $4
+GROUP BY url
+ORDER BY count DESC, url
+LIMIT $5
+`
+
+// GetCacheHitRatio is the handler for GET requests to
+// /deliveryservices/{{ID}}/cache_hit_ratio, which returns the ratios of a
+// Delivery Service's requests which hit the cache on its edge-tier and
+// mid-tier cache servers, and the URLs which most often missed it, between
+// the times given by the 'start' and 'end' query parameters - the last 24
+// hours by default.
+func GetCacheHitRatio(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	dsName, userErr, sysErr, errCode := checkSLODS(tx, inf, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	start, end, topMisses, err := parseCacheHitRatioParams(inf.Params, time.Now())
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	rows, err := tx.Query(selectCacheHitsQuery, dsID, start, end)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("querying cache hits: "+err.Error()))
+		return
+	}
+	defer log.Close(rows, "closing cache hits rows")
+	hits := map[tc.CacheType]uint64{}
+	misses := map[tc.CacheType]uint64{}
+	for rows.Next() {
+		var tier string
+		var h, m uint64
+		if err := rows.Scan(&tier, &h, &m); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("scanning cache hits: "+err.Error()))
+			return
+		}
+		hits[tc.CacheType(tier)] = h
+		misses[tc.CacheType(tier)] = m
+	}
+	if err := rows.Err(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("iterating over cache hits: "+err.Error()))
+		return
+	}
+
+	ratio := tc.DeliveryServiceCacheHitRatio{
+		DeliveryServiceID: dsID,
+		XMLID:             dsName,
+		Start:             start,
+		End:               end,
+	}
+	for _, tier := range []tc.CacheType{tc.CacheTypeEdge, tc.CacheTypeMid} {
+		samples, err := getTopCacheMisses(inf, dsID, start, end, tier, topMisses)
+		if err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+			return
+		}
+		t := tc.NewCacheHitTier(hits[tier], misses[tier], samples)
+		if tier == tc.CacheTypeEdge {
+			ratio.Edge = t
+		} else {
+			ratio.Mid = t
+		}
+	}
+	api.WriteResp(w, r, ratio)
+}
+
+// getTopCacheMisses returns the given number of URLs most often sampled
+// missing the cache servers of the given tier for a Delivery Service.
+func getTopCacheMisses(inf *api.APIInfo, dsID int, start, end time.Time, tier tc.CacheType, limit int) ([]tc.CacheMissSample, error) {
+	samples := []tc.CacheMissSample{}
+	if limit == 0 {
+		return samples, nil
+	}
+	rows, err := inf.Tx.Tx.Query(selectCacheMissesQuery, dsID, start, end, string(tier), limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying %s cache misses: %w", tier, err)
+	}
+	defer log.Close(rows, "closing cache miss rows")
+	for rows.Next() {
+		var s tc.CacheMissSample
+		if err := rows.Scan(&s.URL, &s.Count); err != nil {
+			return nil, fmt.Errorf("scanning %s cache miss: %w", tier, err)
+		}
+		samples = append(samples, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over %s cache misses: %w", tier, err)
+	}
+	return samples, nil
+}
+
+// parseCacheHitRatioParams returns the start and end of the time range, and
+// the number of top misses, given by the query parameters of a request for a
+// cache hit ratio at the given time.
+func parseCacheHitRatioParams(params map[string]string, now time.Time) (time.Time, time.Time, int, error) {
+	end := now
+	if e, ok := params[tc.CacheHitRatioEndQueryParam]; ok {
+		t, err := time.Parse(time.RFC3339, e)
+		if err != nil {
+			return time.Time{}, time.Time{}, 0, errors.New(tc.CacheHitRatioEndQueryParam + ": must be an RFC3339 date/time")
+		}
+		end = t
+	}
+	start := end.Add(-tc.DefaultCacheHitRatioHours * time.Hour)
+	if s, ok := params[tc.CacheHitRatioStartQueryParam]; ok {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return time.Time{}, time.Time{}, 0, errors.New(tc.CacheHitRatioStartQueryParam + ": must be an RFC3339 date/time")
+		}
+		start = t
+	}
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("%s: must be before %s", tc.CacheHitRatioStartQueryParam, tc.CacheHitRatioEndQueryParam)
+	}
+	topMisses := tc.DefaultCacheHitRatioTopMisses
+	if m, ok := params[tc.CacheHitRatioTopMissesQueryParam]; ok {
+		n, err := strconv.Atoi(m)
+		if err != nil || n < 0 || n > tc.MaxCacheMissSamples {
+			return time.Time{}, time.Time{}, 0, fmt.Errorf("%s: must be an integer from 0 to %d", tc.CacheHitRatioTopMissesQueryParam, tc.MaxCacheMissSamples)
+		}
+		topMisses = n
+	}
+	return start, end, topMisses, nil
+}
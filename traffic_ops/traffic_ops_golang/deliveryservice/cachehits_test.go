@@ -0,0 +1,59 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestParseCacheHitRatioParams(t *testing.T) {
+	now := time.Date(2022, 7, 3, 12, 0, 0, 0, time.UTC)
+	start, end, topMisses, err := parseCacheHitRatioParams(map[string]string{}, now)
+	if err != nil {
+		t.Fatalf("unexpected error parsing params: %v", err)
+	}
+	if !end.Equal(now) || !start.Equal(now.Add(-tc.DefaultCacheHitRatioHours*time.Hour)) || topMisses != tc.DefaultCacheHitRatioTopMisses {
+		t.Errorf("expected the default range and top misses, actual: %v - %v, %d", start, end, topMisses)
+	}
+
+	start, end, topMisses, err = parseCacheHitRatioParams(map[string]string{"start": "2022-07-01T00:00:00Z", "end": "2022-07-02T00:00:00Z", "topMisses": "0"}, now)
+	if err != nil {
+		t.Fatalf("unexpected error parsing params: %v", err)
+	}
+	if start.Day() != 1 || end.Day() != 2 || topMisses != 0 {
+		t.Errorf("expected the given range and top misses, actual: %v - %v, %d", start, end, topMisses)
+	}
+
+	invalid := []map[string]string{
+		{"start": "yesterday"},
+		{"end": "2022-07-03"},
+		{"start": "2022-07-03T12:00:00Z"},
+		{"topMisses": "-1"},
+		{"topMisses": "101"},
+	}
+	for _, params := range invalid {
+		if _, _, _, err := parseCacheHitRatioParams(params, now); err == nil {
+			t.Errorf("expected an error parsing params %v, got nil", params)
+		}
+	}
+}
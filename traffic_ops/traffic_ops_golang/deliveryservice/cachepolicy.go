@@ -0,0 +1,319 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+const selectCachePoliciesQuery = `
+SELECT ds.id, ds.xml_id, p.ignore_origin_no_cache, p.max_ttl, p.honor_vary, p.last_updated,
+	COALESCE((
+		SELECT json_agg(json_build_object('statusCode', t.status_code, 'ttl', t.ttl) ORDER BY t.status_code)
+		FROM deliveryservice_cache_policy_ttl AS t
+		WHERE t.deliveryservice = p.deliveryservice
+	), '[]') AS default_ttls
+FROM deliveryservice_cache_policy AS p
+JOIN deliveryservice AS ds ON ds.id = p.deliveryservice
+`
+
+const upsertCachePolicyQuery = `
+INSERT INTO deliveryservice_cache_policy (deliveryservice, ignore_origin_no_cache, max_ttl, honor_vary)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (deliveryservice) DO UPDATE SET
+	ignore_origin_no_cache = EXCLUDED.ignore_origin_no_cache,
+	max_ttl = EXCLUDED.max_ttl,
+	honor_vary = EXCLUDED.honor_vary
+`
+
+const deleteCachePolicyTTLsQuery = `
+DELETE FROM deliveryservice_cache_policy_ttl
+WHERE deliveryservice = $1
+`
+
+const insertCachePolicyTTLQuery = `
+INSERT INTO deliveryservice_cache_policy_ttl (deliveryservice, status_code, ttl)
+VALUES ($1, $2, $3)
+`
+
+const deleteCachePolicyQuery = `
+DELETE FROM deliveryservice_cache_policy
+WHERE deliveryservice = $1
+`
+
+// GetCachePolicy is the handler for GET requests to
+// /deliveryservices/{{ID}}/cache-policy.
+func GetCachePolicy(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	userErr, sysErr, errCode = tenant.CheckID(tx, inf.User, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	policy, userErr, sysErr, errCode := getDSCachePolicy(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	api.WriteResp(w, r, policy)
+}
+
+// GetCDNCachePolicies is the handler for GET requests to
+// /deliveryservices/cache-policies, which returns the cache policies of every
+// Delivery Service with one, optionally only those in the CDN named by the
+// 'cdn' query parameter. It exists so that cache configuration generation
+// doesn't need a request per Delivery Service.
+func GetCDNCachePolicies(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	tenants, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting accessible tenants for user: %w", err))
+		return
+	}
+
+	query := selectCachePoliciesQuery + `WHERE ds.tenant_id = ANY($1)`
+	args := []interface{}{pq.Array(tenants)}
+	if cdn, ok := inf.Params["cdn"]; ok {
+		if _, ok, err := dbhelpers.GetCDNIDFromName(tx, tc.CDNName(cdn)); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("checking CDN existence: %w", err))
+			return
+		} else if !ok {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no such CDN: %s", cdn), nil)
+			return
+		}
+		query += ` AND ds.cdn_id = (SELECT id FROM cdn WHERE name = $2)`
+		args = append(args, cdn)
+	}
+
+	policies, err := readCachePolicies(tx, query+` ORDER BY ds.xml_id`, args...)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, policies)
+}
+
+// UpdateCachePolicy is the handler for PUT requests to
+// /deliveryservices/{{ID}}/cache-policy, which replaces a Delivery Service's
+// cache policy.
+func UpdateCachePolicy(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	dsName, userErr, sysErr, errCode := checkDSModifiable(tx, inf, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	dsType, _, _, err := dbhelpers.GetDeliveryServiceTypeAndCDNName(dsID, tx)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting Delivery Service type: %w", err))
+		return
+	}
+	if (!dsType.IsHTTP() && !dsType.IsDNS()) || dsType == tc.DSTypeHTTPNoCache {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("cache policies are not allowed for '%s' Delivery Services", dsType), nil)
+		return
+	}
+
+	var req tc.DeliveryServiceCachePolicyRequest
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	honorVary := interface{}(nil)
+	if req.HonorVary != nil {
+		honorVary = pq.Array(req.HonorVary)
+	}
+	if _, err := tx.Exec(upsertCachePolicyQuery, dsID, req.IgnoreOriginNoCache, req.MaxTTL, honorVary); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("upserting Delivery Service cache policy: "+err.Error()))
+		return
+	}
+	if _, err := tx.Exec(deleteCachePolicyTTLsQuery, dsID); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deleting Delivery Service cache policy default TTLs: "+err.Error()))
+		return
+	}
+	for _, ttl := range req.DefaultTTLs {
+		if _, err := tx.Exec(insertCachePolicyTTLQuery, dsID, ttl.StatusCode, ttl.TTL); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("inserting Delivery Service cache policy default TTL for status %d: %w", ttl.StatusCode, err))
+			return
+		}
+	}
+
+	policy, userErr, sysErr, errCode := getDSCachePolicy(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	msg := fmt.Sprintf("Delivery Service '%s' cache policy updated", dsName)
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+string(dsName)+", ID: "+strconv.Itoa(dsID)+", ACTION: Updated cache policy", inf.User, tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, msg+"; queue updates on the CDN to apply it", policy)
+}
+
+// DeleteCachePolicy is the handler for DELETE requests to
+// /deliveryservices/{{ID}}/cache-policy, which removes a Delivery Service's
+// cache policy, so that its origin's caching instructions are followed.
+func DeleteCachePolicy(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	dsName, userErr, sysErr, errCode := checkDSModifiable(tx, inf, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	result, err := tx.Exec(deleteCachePolicyQuery, dsID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deleting Delivery Service cache policy: "+err.Error()))
+		return
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting rows affected deleting Delivery Service cache policy: "+err.Error()))
+		return
+	} else if rows == 0 {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("Delivery Service '%s' has no cache policy", dsName), nil)
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+string(dsName)+", ID: "+strconv.Itoa(dsID)+", ACTION: Deleted cache policy", inf.User, tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, fmt.Sprintf("Delivery Service '%s' cache policy deleted; queue updates on the CDN to apply it", dsName))
+}
+
+// checkDSModifiable checks that the current user may modify the Delivery
+// Service with the given ID, e.g. its cache policy or path rules, and returns
+// its name, along with a user error, system error, and status code.
+func checkDSModifiable(tx *sql.Tx, inf *api.APIInfo, dsID int) (tc.DeliveryServiceName, error, error, int) {
+	userErr, sysErr, errCode := tenant.CheckID(tx, inf.User, dsID)
+	if userErr != nil || sysErr != nil {
+		return "", userErr, sysErr, errCode
+	}
+
+	dsName, cdn, ok, err := dbhelpers.GetDSNameAndCDNFromID(tx, dsID)
+	if err != nil {
+		return "", nil, errors.New("getting Delivery Service name and CDN: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return "", fmt.Errorf("no Delivery Service exists by ID '%d'", dsID), nil, http.StatusNotFound
+	}
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyCDN(tx, string(cdn), inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		return "", userErr, sysErr, errCode
+	}
+	return dsName, nil, nil, http.StatusOK
+}
+
+// getDSCachePolicy returns the cache policy of the Delivery Service with the
+// given ID, along with a user error, system error, and status code. A
+// Delivery Service without a cache policy has an empty one, which doesn't
+// override anything.
+func getDSCachePolicy(tx *sql.Tx, dsID int) (tc.DeliveryServiceCachePolicy, error, error, int) {
+	dsName, _, ok, err := dbhelpers.GetDSNameAndCDNFromID(tx, dsID)
+	if err != nil {
+		return tc.DeliveryServiceCachePolicy{}, nil, errors.New("getting Delivery Service name: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return tc.DeliveryServiceCachePolicy{}, fmt.Errorf("no Delivery Service exists by ID '%d'", dsID), nil, http.StatusNotFound
+	}
+
+	all, err := readCachePolicies(tx, selectCachePoliciesQuery+`WHERE ds.id = $1`, dsID)
+	if err != nil {
+		return tc.DeliveryServiceCachePolicy{}, nil, err, http.StatusInternalServerError
+	}
+	if len(all) == 0 {
+		return tc.DeliveryServiceCachePolicy{
+			DeliveryServiceID: dsID,
+			XMLID:             string(dsName),
+			DefaultTTLs:       []tc.CachePolicyDefaultTTL{},
+		}, nil, nil, http.StatusOK
+	}
+	return all[0], nil, nil, http.StatusOK
+}
+
+// readCachePolicies reads the cache policies selected by the given query,
+// which must select the columns of selectCachePoliciesQuery.
+func readCachePolicies(tx *sql.Tx, query string, args ...interface{}) ([]tc.DeliveryServiceCachePolicy, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, errors.New("querying Delivery Service cache policies: " + err.Error())
+	}
+	defer log.Close(rows, "closing Delivery Service cache policies rows")
+
+	all := []tc.DeliveryServiceCachePolicy{}
+	for rows.Next() {
+		var policy tc.DeliveryServiceCachePolicy
+		var lastUpdated time.Time
+		var defaultTTLs []byte
+		if err := rows.Scan(&policy.DeliveryServiceID, &policy.XMLID, &policy.IgnoreOriginNoCache, &policy.MaxTTL, pq.Array(&policy.HonorVary), &lastUpdated, &defaultTTLs); err != nil {
+			return nil, errors.New("scanning Delivery Service cache policies: " + err.Error())
+		}
+		if err := json.Unmarshal(defaultTTLs, &policy.DefaultTTLs); err != nil {
+			return nil, fmt.Errorf("decoding default TTLs of Delivery Service '%s' cache policy: %w", policy.XMLID, err)
+		}
+		policy.LastUpdated = &lastUpdated
+		all = append(all, policy)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over Delivery Service cache policies: " + err.Error())
+	}
+	return all, nil
+}
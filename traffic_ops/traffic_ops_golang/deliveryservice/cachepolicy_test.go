@@ -0,0 +1,80 @@
+package deliveryservice
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestReadCachePolicies(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	lastUpdated := time.Date(2022, 6, 16, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	rows := sqlmock.NewRows([]string{"id", "xml_id", "ignore_origin_no_cache", "max_ttl", "honor_vary", "last_updated", "default_ttls"}).
+		AddRow(1, "ds1", true, 86400, "{Accept-Encoding}", lastUpdated, []byte(`[{"statusCode":200,"ttl":3600},{"statusCode":404,"ttl":60}]`)).
+		AddRow(2, "ds2", false, nil, nil, lastUpdated, []byte(`[]`))
+	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	tx := db.MustBegin().Tx
+	all, err := readCachePolicies(tx, selectCachePoliciesQuery)
+	if err != nil {
+		t.Fatalf("unexpected error reading cache policies: %v", err)
+	}
+	tx.Commit()
+
+	if len(all) != 2 {
+		t.Fatalf("expected cache policies of 2 delivery services, actual: %d", len(all))
+	}
+	ds1 := all[0]
+	if ds1.XMLID != "ds1" || !ds1.IgnoreOriginNoCache || ds1.MaxTTL == nil || *ds1.MaxTTL != 86400 {
+		t.Errorf("expected delivery service 'ds1' to ignore no-cache with a max TTL of 86400, actual: %+v", ds1)
+	}
+	if len(ds1.DefaultTTLs) != 2 || ds1.DefaultTTLs[1].StatusCode != 404 || ds1.DefaultTTLs[1].TTL != 60 {
+		t.Errorf("expected 2 default TTLs of delivery service 'ds1', actual: %+v", ds1.DefaultTTLs)
+	}
+	if len(ds1.HonorVary) != 1 || ds1.HonorVary[0] != "Accept-Encoding" {
+		t.Errorf("expected delivery service 'ds1' to honor Vary on [Accept-Encoding], actual: %v", ds1.HonorVary)
+	}
+	if ds1.LastUpdated == nil || !ds1.LastUpdated.Equal(lastUpdated) {
+		t.Errorf("expected lastUpdated %v, actual: %v", lastUpdated, ds1.LastUpdated)
+	}
+	ds2 := all[1]
+	if ds2.HonorVary != nil {
+		t.Errorf("expected delivery service 'ds2' to honor all of Vary, actual: %v", ds2.HonorVary)
+	}
+	if ds2.DefaultTTLs == nil || len(ds2.DefaultTTLs) != 0 {
+		t.Errorf("expected no default TTLs of delivery service 'ds2', actual: %v", ds2.DefaultTTLs)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -43,6 +43,15 @@ type TRConsistentHashRequest struct {
 	ConsistentHashRegex string `json:"regex"`
 	RequestPath         string `json:"requestPath"`
 	CdnID               int64  `json:"cdnId"`
+	// IncludePath is whether or not the request path is part of the
+	// consistent hash key; if not given, it is.
+	IncludePath *bool `json:"includePath"`
+	// QueryParams are the names of the query string parameters that are part
+	// of the consistent hash key, when RequestPath has a query string.
+	QueryParams []string `json:"queryParams"`
+	// Headers maps the names of the request headers that are part of the
+	// consistent hash key to their values in the request being tested.
+	Headers map[string]string `json:"headers"`
 }
 
 // Post is the handler for POST requests to /consistenthash.
@@ -60,7 +69,7 @@ func Post(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	responseFromTR, err := getPatternBasedConsistentHash(inf.Tx.Tx, req.ConsistentHashRegex, req.RequestPath, req.CdnID)
+	responseFromTR, err := getPatternBasedConsistentHash(inf.Tx.Tx, req)
 	if err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting pattern based consistent hash from Traffic Router: "+err.Error()))
 		return
@@ -74,10 +83,29 @@ func Post(w http.ResponseWriter, r *http.Request) {
 
 const RouterRequestTimeout = time.Second * 10
 
+// trQuery returns the query string parameters of the Traffic Router API
+// request for the given consistent hash test request.
+func trQuery(req TRConsistentHashRequest) url.Values {
+	query := url.Values{}
+	query.Set("regex", req.ConsistentHashRegex)
+	query.Set("requestPath", req.RequestPath)
+	if req.IncludePath != nil {
+		query.Set("includePath", strconv.FormatBool(*req.IncludePath))
+	}
+	for _, param := range req.QueryParams {
+		query.Add("queryParam", param)
+	}
+	for name, value := range req.Headers {
+		query.Add("header", name+":"+value)
+	}
+	return query
+}
+
 // queries database for active Traffic Router on the CDN specified by cdnId
-// passes regex and requestPath to the Traffic Router via API request,
-// and returns the response
-func getPatternBasedConsistentHash(tx *sql.Tx, regex string, requestPath string, cdnId int64) ([]byte, error) {
+// passes the consistent hash options of the request to the Traffic Router via
+// API request, and returns the response
+func getPatternBasedConsistentHash(tx *sql.Tx, req TRConsistentHashRequest) ([]byte, error) {
+	cdnId := req.CdnID
 	q := `
 SELECT concat(server.host_name, '.', server.domain_name) AS fqdn,
    parameter.value AS apiport
@@ -107,7 +135,7 @@ LIMIT 1
 		return nil, errors.New("no parameter 'api.port' found for pattern based consistent hashing with cdn Id: " + strconv.FormatInt(cdnId, 10))
 	}
 
-	trafficRouterAPI := "http://" + trafficRouter + ":" + apiPort + "/crs/consistenthash/patternbased/regex?regex=" + url.QueryEscape(regex) + "&requestPath=" + url.QueryEscape(requestPath)
+	trafficRouterAPI := "http://" + trafficRouter + ":" + apiPort + "/crs/consistenthash/patternbased/regex?" + trQuery(req).Encode()
 
 	trClient := &http.Client{
 		Timeout: RouterRequestTimeout,
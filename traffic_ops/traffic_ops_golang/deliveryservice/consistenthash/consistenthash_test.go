@@ -0,0 +1,54 @@
+package consistenthash
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestTRQuery(t *testing.T) {
+	query := trQuery(TRConsistentHashRequest{
+		ConsistentHashRegex: "/.*?(/.*)",
+		RequestPath:         "/path/file.ts?lang=en",
+	})
+	if query.Get("regex") != "/.*?(/.*)" || query.Get("requestPath") != "/path/file.ts?lang=en" {
+		t.Errorf("Expected regex and requestPath in query, got: %v", query)
+	}
+	if _, ok := query["includePath"]; ok {
+		t.Errorf("Expected no includePath in query when not given, got: %v", query)
+	}
+
+	query = trQuery(TRConsistentHashRequest{
+		IncludePath: util.BoolPtr(false),
+		QueryParams: []string{"lang", "format"},
+		Headers:     map[string]string{"X-Device-Class": "tv"},
+	})
+	if query.Get("includePath") != "false" {
+		t.Errorf("Expected includePath 'false', got: '%s'", query.Get("includePath"))
+	}
+	if params := query["queryParam"]; len(params) != 2 || params[0] != "lang" || params[1] != "format" {
+		t.Errorf("Expected queryParams [lang format], got: %v", params)
+	}
+	if headers := query["header"]; len(headers) != 1 || headers[0] != "X-Device-Class:tv" {
+		t.Errorf("Expected header [X-Device-Class:tv], got: %v", headers)
+	}
+}
@@ -20,7 +20,9 @@ package deliveryservice
  */
 
 import (
+	"crypto/sha256"
 	"database/sql"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -37,6 +39,7 @@ import (
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/policy"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/util/ims"
 
@@ -128,6 +131,116 @@ func GetDSTLSVersions(dsID int, tx *sql.Tx) ([]string, error) {
 	return vers, err
 }
 
+const getConsistentHashOptionsQuery = `
+SELECT consistent_hash_include_path, consistent_hash_headers, consistent_hash_replicas
+FROM deliveryservice
+WHERE id = $1
+`
+
+// GetDSConsistentHashOptions retrieves whether the request path is part of
+// the consistent hash key of a Delivery Service, the request headers that are
+// part of it, and its number of consistent hash ring replicas.
+func GetDSConsistentHashOptions(dsID int, tx *sql.Tx) (*bool, []string, *int, error) {
+	var includePath *bool
+	var headers []string
+	var replicas *int
+	if err := tx.QueryRow(getConsistentHashOptionsQuery, dsID).Scan(&includePath, pq.Array(&headers), &replicas); err != nil {
+		return nil, nil, nil, fmt.Errorf("querying: %w", err)
+	}
+	return includePath, headers, replicas, nil
+}
+
+const getOriginTLSOptionsQuery = `
+SELECT origin_tls_verify, origin_tls_pinned_spki_hashes, origin_tls_ca_bundle
+FROM deliveryservice
+WHERE id = $1
+`
+
+// GetDSOriginTLSOptions retrieves whether cache servers verify the TLS
+// certificate of a Delivery Service's origin, the SPKI hashes pinned for it,
+// and the name of the CA bundle used to verify it.
+func GetDSOriginTLSOptions(dsID int, tx *sql.Tx) (*bool, []string, *string, error) {
+	var verify *bool
+	var pins []string
+	var caBundle *string
+	if err := tx.QueryRow(getOriginTLSOptionsQuery, dsID).Scan(&verify, pq.Array(&pins), &caBundle); err != nil {
+		return nil, nil, nil, fmt.Errorf("querying: %w", err)
+	}
+	return verify, pins, caBundle, nil
+}
+
+const getRoutingInterfacesQuery = `
+SELECT routing_interfaces
+FROM deliveryservice
+WHERE id = $1
+`
+
+// GetDSRoutingInterfaces retrieves the names of the server interfaces, in
+// order of preference, whose addresses are used to route to the cache servers
+// of a Delivery Service.
+func GetDSRoutingInterfaces(dsID int, tx *sql.Tx) ([]string, error) {
+	var interfaces []string
+	if err := tx.QueryRow(getRoutingInterfacesQuery, dsID).Scan(pq.Array(&interfaces)); err != nil {
+		return nil, fmt.Errorf("querying: %w", err)
+	}
+	return interfaces, nil
+}
+
+const getCompressionOptionsQuery = `
+SELECT compress_gzip, compress_brotli, compress_content_types, compress_min_size
+FROM deliveryservice
+WHERE id = $1
+`
+
+const getTraceHeaderQuery = `
+SELECT trace_header_policy, trace_header_name
+FROM deliveryservice
+WHERE id = $1
+`
+
+// GetDSTraceHeader retrieves how cache servers treat the trace header of
+// requests for a Delivery Service, and the name of that header.
+func GetDSTraceHeader(dsID int, tx *sql.Tx) (*tc.TraceHeaderPolicy, *string, error) {
+	var policy *tc.TraceHeaderPolicy
+	var name *string
+	if err := tx.QueryRow(getTraceHeaderQuery, dsID).Scan(&policy, &name); err != nil {
+		return nil, nil, fmt.Errorf("querying: %w", err)
+	}
+	return policy, name, nil
+}
+
+const getStreamingQuery = `
+SELECT websocket, grpc, stream_idle_timeout, stream_active_timeout
+FROM deliveryservice
+WHERE id = $1
+`
+
+// GetDSStreaming retrieves whether cache servers pass WebSocket connections
+// and gRPC requests for a Delivery Service through to its origin, and the idle
+// and active timeouts of those streams.
+func GetDSStreaming(dsID int, tx *sql.Tx) (*bool, *bool, *int, *int, error) {
+	var webSocket, grpc *bool
+	var idleTimeout, activeTimeout *int
+	if err := tx.QueryRow(getStreamingQuery, dsID).Scan(&webSocket, &grpc, &idleTimeout, &activeTimeout); err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("querying: %w", err)
+	}
+	return webSocket, grpc, idleTimeout, activeTimeout, nil
+}
+
+// GetDSCompressionOptions retrieves whether cache servers compress responses
+// for a Delivery Service with gzip and with brotli, the MIME types of the
+// responses they compress, and the minimum size of those responses.
+func GetDSCompressionOptions(dsID int, tx *sql.Tx) (*bool, *bool, []string, *int, error) {
+	var gzip *bool
+	var brotli *bool
+	var contentTypes []string
+	var minSize *int
+	if err := tx.QueryRow(getCompressionOptionsQuery, dsID).Scan(&gzip, &brotli, pq.Array(&contentTypes), &minSize); err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("querying: %w", err)
+	}
+	return gzip, brotli, contentTypes, minSize, nil
+}
+
 func CreateV30(w http.ResponseWriter, r *http.Request) {
 	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
 	if userErr != nil || sysErr != nil {
@@ -141,6 +254,9 @@ func CreateV30(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("decoding: "+err.Error()), nil)
 		return
 	}
+	if rejectRestricted(w, r, inf) {
+		return
+	}
 
 	res, status, userErr, sysErr := createV30(w, r, inf, ds)
 	if userErr != nil || sysErr != nil {
@@ -162,6 +278,9 @@ func CreateV31(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("decoding: "+err.Error()), nil)
 		return
 	}
+	if rejectRestricted(w, r, inf) {
+		return
+	}
 
 	res, status, userErr, sysErr := createV31(w, r, inf, ds)
 	if userErr != nil || sysErr != nil {
@@ -183,6 +302,9 @@ func CreateV40(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("decoding: "+err.Error()), nil)
 		return
 	}
+	if enforceFieldPolicy(w, r, inf, tc.DSRChangeTypeCreate, tc.DeliveryServiceV4(ds)) {
+		return
+	}
 	res, status, userErr, sysErr := createV40(w, r, inf, ds, true)
 	if userErr != nil || sysErr != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, status, userErr, sysErr)
@@ -261,6 +383,13 @@ func recreateTLSVersions(versions []string, dsid int, tx *sql.Tx) error {
 }
 
 // create creates the given ds in the database, and returns the DS with its id and other fields created on insert set. On error, the HTTP status code, user error, and system error are returned. The status code SHOULD NOT be used, if both errors are nil.
+// Create creates the given Delivery Service in the request's transaction, as
+// a request to create it through version 4 of the API would, and returns it
+// along with the status code, user error, and system error.
+func Create(r *http.Request, inf *api.APIInfo, ds tc.DeliveryServiceV4) (*tc.DeliveryServiceV4, int, error, error) {
+	return createV40(nil, r, inf, tc.DeliveryServiceV40(ds), true)
+}
+
 func createV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV40 tc.DeliveryServiceV40, omitExtraLongDescFields bool) (*tc.DeliveryServiceV40, int, error, error) {
 	user := inf.User
 	tx := inf.Tx.Tx
@@ -276,6 +405,11 @@ func createV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV40 t
 	} else if !authorized {
 		return nil, http.StatusForbidden, errors.New("not authorized on this tenant"), nil
 	}
+	if ds.TenantID != nil {
+		if userErr, sysErr, errCode := tenant.CheckDeliveryServiceQuota(tx, *ds.TenantID); userErr != nil || sysErr != nil {
+			return nil, errCode, userErr, sysErr
+		}
+	}
 
 	// TODO change DeepCachingType to implement sql.Valuer and sql.Scanner, so sqlx struct scan can be used.
 	deepCachingType := tc.DeepCachingType("").String()
@@ -356,6 +490,25 @@ func createV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV40 t
 			&ds.LastHeaderRewrite,
 			&ds.ServiceCategory,
 			&ds.MaxRequestHeaderBytes,
+			&ds.ActiveAt,
+			&ds.InactiveAt,
+			&ds.ConsistentHashIncludePath,
+			pq.Array(ds.ConsistentHashHeaders),
+			&ds.ConsistentHashReplicas,
+			&ds.OriginTLSVerify,
+			pq.Array(ds.OriginTLSPinnedSPKIHashes),
+			&ds.OriginTLSCABundle,
+			pq.Array(ds.RoutingInterfaces),
+			&ds.CompressGzip,
+			&ds.CompressBrotli,
+			pq.Array(ds.CompressContentTypes),
+			&ds.CompressMinSize,
+			&ds.TraceHeaderPolicy,
+			&ds.TraceHeaderName,
+			&ds.WebSocket,
+			&ds.GRPC,
+			&ds.StreamIdleTimeout,
+			&ds.StreamActiveTimeout,
 		)
 	} else {
 		resultRows, err = tx.Query(insertQuery(),
@@ -418,6 +571,25 @@ func createV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV40 t
 			&ds.LastHeaderRewrite,
 			&ds.ServiceCategory,
 			&ds.MaxRequestHeaderBytes,
+			&ds.ActiveAt,
+			&ds.InactiveAt,
+			&ds.ConsistentHashIncludePath,
+			pq.Array(ds.ConsistentHashHeaders),
+			&ds.ConsistentHashReplicas,
+			&ds.OriginTLSVerify,
+			pq.Array(ds.OriginTLSPinnedSPKIHashes),
+			&ds.OriginTLSCABundle,
+			pq.Array(ds.RoutingInterfaces),
+			&ds.CompressGzip,
+			&ds.CompressBrotli,
+			pq.Array(ds.CompressContentTypes),
+			&ds.CompressMinSize,
+			&ds.TraceHeaderPolicy,
+			&ds.TraceHeaderName,
+			&ds.WebSocket,
+			&ds.GRPC,
+			&ds.StreamIdleTimeout,
+			&ds.StreamActiveTimeout,
 		)
 	}
 
@@ -557,8 +729,21 @@ func (ds *TODeliveryService) Read(h http.Header, useIMS bool) ([]interface{}, er
 		return nil, nil, errors.New("TODeliveryService.Read called with nil API version"), http.StatusInternalServerError, nil
 	}
 
+	viewedTenantIDs, redactedFields, err := getReadView(ds.APIInfo().Tx.Tx, ds.APIInfo().User)
+	if err != nil {
+		return nil, nil, err, http.StatusInternalServerError, nil
+	}
+
+	var asOf *time.Time
+	if version.Major >= 5 {
+		asOf, err = dbhelpers.ParseAsOf(ds.APIInfo().Params)
+		if err != nil {
+			return nil, err, nil, http.StatusBadRequest, nil
+		}
+	}
+
 	returnable := []interface{}{}
-	dses, userErr, sysErr, errCode, maxTime := readGetDeliveryServices(h, ds.APIInfo().Params, ds.APIInfo().Tx, ds.APIInfo().User, useIMS)
+	dses, userErr, sysErr, errCode, maxTime := readGetDeliveryServices(h, ds.APIInfo().Params, ds.APIInfo().Tx, ds.APIInfo().User, useIMS, viewedTenantIDs, asOf)
 	if sysErr != nil {
 		sysErr = errors.New("reading dses: " + sysErr.Error())
 		errCode = http.StatusInternalServerError
@@ -567,22 +752,87 @@ func (ds *TODeliveryService) Read(h http.Header, useIMS bool) ([]interface{}, er
 		return nil, userErr, sysErr, errCode, nil
 	}
 
+	viewed := make(map[int]struct{}, len(viewedTenantIDs))
+	for _, id := range viewedTenantIDs {
+		viewed[id] = struct{}{}
+	}
 	for _, ds := range dses {
+		var versioned interface{}
 		switch {
 		// NOTE: it's required to handle minor version cases in a descending >= manner
 		case version.Major > 3:
-			returnable = append(returnable, ds.RemoveLD1AndLD2())
+			versioned = ds.RemoveLD1AndLD2()
 		case version.Major >= 3 && version.Minor >= 1:
-			returnable = append(returnable, ds.DowngradeToV31())
+			versioned = ds.DowngradeToV31()
 		case version.Major >= 3:
-			returnable = append(returnable, ds.DowngradeToV31().DeliveryServiceV30)
+			versioned = ds.DowngradeToV31().DeliveryServiceV30
 		default:
 			return nil, nil, fmt.Errorf("TODeliveryService.Read called with invalid API version: %d.%d", version.Major, version.Minor), http.StatusInternalServerError, nil
 		}
+		if ds.TenantID != nil {
+			if _, ok := viewed[*ds.TenantID]; ok {
+				if versioned, err = policy.Redact(versioned, redactedFields); err != nil {
+					return nil, nil, fmt.Errorf("redacting Delivery Service '%s': %w", *ds.XMLID, err), http.StatusInternalServerError, nil
+				}
+			}
+		}
+		returnable = append(returnable, versioned)
 	}
 	return returnable, nil, nil, errCode, maxTime
 }
 
+// GetForUser returns the Delivery Services with the given query parameters,
+// as they're served to the given user by the latest version of GET
+// /deliveryservices - including those of the Tenants viewed by the user's
+// read view, with its redacted fields removed - for the representations of
+// Delivery Services other than that of the API.
+func GetForUser(params map[string]string, tx *sqlx.Tx, user *auth.CurrentUser) ([]tc.DeliveryServiceV4, error, error, int) {
+	viewedTenantIDs, redactedFields, err := getReadView(tx.Tx, user)
+	if err != nil {
+		return nil, nil, err, http.StatusInternalServerError
+	}
+	dses, userErr, sysErr, errCode, _ := readGetDeliveryServices(http.Header{}, params, tx, user, false, viewedTenantIDs, nil)
+	if userErr != nil || sysErr != nil {
+		return nil, userErr, sysErr, errCode
+	}
+
+	viewed := make(map[int]struct{}, len(viewedTenantIDs))
+	for _, id := range viewedTenantIDs {
+		viewed[id] = struct{}{}
+	}
+	for i, ds := range dses {
+		dses[i] = ds.RemoveLD1AndLD2()
+		if ds.TenantID == nil {
+			continue
+		}
+		if _, ok := viewed[*ds.TenantID]; ok {
+			if err := policy.RedactInPlace(&dses[i], redactedFields); err != nil {
+				return nil, nil, fmt.Errorf("redacting Delivery Service '%s': %w", *ds.XMLID, err), http.StatusInternalServerError
+			}
+		}
+	}
+	return dses, nil, nil, http.StatusOK
+}
+
+// getReadView returns the IDs of the Tenants whose Delivery Services the user
+// may read through their Tenant's read view beyond those of their own
+// Tenancy, and the fields redacted from those Delivery Services.
+func getReadView(tx *sql.Tx, user *auth.CurrentUser) ([]int, []string, error) {
+	view, err := tenant.GetUserReadView(tx, user.TenantID)
+	if err != nil || view == nil {
+		return nil, nil, err
+	}
+	ownTenantIDs, err := tenant.GetUserTenantIDListTx(tx, user.TenantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("getting user's tenants: %w", err)
+	}
+	viewedTenantIDs, err := tenant.GetViewedTenantIDs(tx, view, ownTenantIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return viewedTenantIDs, view.RedactedDeliveryServiceFields, nil
+}
+
 func UpdateV30(w http.ResponseWriter, r *http.Request) {
 	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, []string{"id"})
 	if userErr != nil || sysErr != nil {
@@ -599,6 +849,9 @@ func UpdateV30(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	ds.ID = &id
+	if rejectRestricted(w, r, inf) {
+		return
+	}
 
 	res, status, userErr, sysErr := updateV30(w, r, inf, &ds)
 	if userErr != nil || sysErr != nil {
@@ -623,6 +876,9 @@ func UpdateV31(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	ds.ID = &id
+	if rejectRestricted(w, r, inf) {
+		return
+	}
 	res, status, userErr, sysErr := updateV31(w, r, inf, &ds)
 	if userErr != nil || sysErr != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, status, userErr, sysErr)
@@ -655,6 +911,9 @@ func UpdateV40(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, inf.Tx.Tx, statusCode, userErr, sysErr)
 		return
 	}
+	if enforceFieldPolicy(w, r, inf, tc.DSRChangeTypeUpdate, tc.DeliveryServiceV4(ds)) {
+		return
+	}
 	res, status, userErr, sysErr := updateV40(w, r, inf, &ds, true)
 	if userErr != nil || sysErr != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, status, userErr, sysErr)
@@ -703,6 +962,27 @@ func updateV31(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV31 *
 	if dsV40.TLSVersions, sysErr = GetDSTLSVersions(*dsV40.ID, tx); sysErr != nil {
 		return nil, http.StatusInternalServerError, nil, fmt.Errorf("getting TLS versions for DS #%d in API version < 4.0: %w", *dsV40.ID, sysErr)
 	}
+	if dsV40.ActiveAt, dsV40.InactiveAt, sysErr = GetDSSchedule(*dsV40.ID, tx); sysErr != nil {
+		return nil, http.StatusInternalServerError, nil, fmt.Errorf("getting schedule for DS #%d in API version < 4.0: %w", *dsV40.ID, sysErr)
+	}
+	if dsV40.ConsistentHashIncludePath, dsV40.ConsistentHashHeaders, dsV40.ConsistentHashReplicas, sysErr = GetDSConsistentHashOptions(*dsV40.ID, tx); sysErr != nil {
+		return nil, http.StatusInternalServerError, nil, fmt.Errorf("getting consistent hash options for DS #%d in API version < 4.0: %w", *dsV40.ID, sysErr)
+	}
+	if dsV40.OriginTLSVerify, dsV40.OriginTLSPinnedSPKIHashes, dsV40.OriginTLSCABundle, sysErr = GetDSOriginTLSOptions(*dsV40.ID, tx); sysErr != nil {
+		return nil, http.StatusInternalServerError, nil, fmt.Errorf("getting origin TLS options for DS #%d in API version < 4.0: %w", *dsV40.ID, sysErr)
+	}
+	if dsV40.RoutingInterfaces, sysErr = GetDSRoutingInterfaces(*dsV40.ID, tx); sysErr != nil {
+		return nil, http.StatusInternalServerError, nil, fmt.Errorf("getting routing interfaces for DS #%d in API version < 4.0: %w", *dsV40.ID, sysErr)
+	}
+	if dsV40.CompressGzip, dsV40.CompressBrotli, dsV40.CompressContentTypes, dsV40.CompressMinSize, sysErr = GetDSCompressionOptions(*dsV40.ID, tx); sysErr != nil {
+		return nil, http.StatusInternalServerError, nil, fmt.Errorf("getting compression options for DS #%d in API version < 4.0: %w", *dsV40.ID, sysErr)
+	}
+	if dsV40.TraceHeaderPolicy, dsV40.TraceHeaderName, sysErr = GetDSTraceHeader(*dsV40.ID, tx); sysErr != nil {
+		return nil, http.StatusInternalServerError, nil, fmt.Errorf("getting trace header for DS #%d in API version < 4.0: %w", *dsV40.ID, sysErr)
+	}
+	if dsV40.WebSocket, dsV40.GRPC, dsV40.StreamIdleTimeout, dsV40.StreamActiveTimeout, sysErr = GetDSStreaming(*dsV40.ID, tx); sysErr != nil {
+		return nil, http.StatusInternalServerError, nil, fmt.Errorf("getting streaming options for DS #%d in API version < 4.0: %w", *dsV40.ID, sysErr)
+	}
 
 	res, status, usrErr, sysErr := updateV40(w, r, inf, &dsV40, false)
 	if res == nil || usrErr != nil || sysErr != nil {
@@ -746,6 +1026,17 @@ func updateV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV40 *
 	if ds.ID == nil {
 		return nil, http.StatusBadRequest, errors.New("missing id"), nil
 	}
+	if ds.TenantID != nil {
+		oldTenantID, exists, err := tenant.GetDSTenantIDByIDTx(tx, *ds.ID)
+		if err != nil {
+			return nil, http.StatusInternalServerError, nil, fmt.Errorf("getting Tenant of Delivery Service #%d: %w", *ds.ID, err)
+		}
+		if exists && (oldTenantID == nil || *oldTenantID != *ds.TenantID) {
+			if userErr, sysErr, errCode := tenant.CheckDeliveryServiceQuota(tx, *ds.TenantID); userErr != nil || sysErr != nil {
+				return nil, errCode, userErr, sysErr
+			}
+		}
+	}
 
 	dsType, ok, err := getDSType(tx, *ds.XMLID)
 	if !ok {
@@ -888,6 +1179,25 @@ func updateV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV40 *
 			&ds.LastHeaderRewrite,
 			&ds.ServiceCategory,
 			&ds.MaxRequestHeaderBytes,
+			&ds.ActiveAt,
+			&ds.InactiveAt,
+			&ds.ConsistentHashIncludePath,
+			pq.Array(ds.ConsistentHashHeaders),
+			&ds.ConsistentHashReplicas,
+			&ds.OriginTLSVerify,
+			pq.Array(ds.OriginTLSPinnedSPKIHashes),
+			&ds.OriginTLSCABundle,
+			pq.Array(ds.RoutingInterfaces),
+			&ds.CompressGzip,
+			&ds.CompressBrotli,
+			pq.Array(ds.CompressContentTypes),
+			&ds.CompressMinSize,
+			&ds.TraceHeaderPolicy,
+			&ds.TraceHeaderName,
+			&ds.WebSocket,
+			&ds.GRPC,
+			&ds.StreamIdleTimeout,
+			&ds.StreamActiveTimeout,
 			&ds.ID)
 	} else {
 		resultRows, err = tx.Query(updateDSQuery(),
@@ -950,6 +1260,25 @@ func updateV40(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, dsV40 *
 			&ds.LastHeaderRewrite,
 			&ds.ServiceCategory,
 			&ds.MaxRequestHeaderBytes,
+			&ds.ActiveAt,
+			&ds.InactiveAt,
+			&ds.ConsistentHashIncludePath,
+			pq.Array(ds.ConsistentHashHeaders),
+			&ds.ConsistentHashReplicas,
+			&ds.OriginTLSVerify,
+			pq.Array(ds.OriginTLSPinnedSPKIHashes),
+			&ds.OriginTLSCABundle,
+			pq.Array(ds.RoutingInterfaces),
+			&ds.CompressGzip,
+			&ds.CompressBrotli,
+			pq.Array(ds.CompressContentTypes),
+			&ds.CompressMinSize,
+			&ds.TraceHeaderPolicy,
+			&ds.TraceHeaderName,
+			&ds.WebSocket,
+			&ds.GRPC,
+			&ds.StreamIdleTimeout,
+			&ds.StreamActiveTimeout,
 			&ds.ID)
 	}
 
@@ -1122,7 +1451,11 @@ func (v *TODeliveryService) DeleteQuery() string {
 	return `DELETE FROM deliveryservice WHERE id = :id`
 }
 
-func readGetDeliveryServices(h http.Header, params map[string]string, tx *sqlx.Tx, user *auth.CurrentUser, useIMS bool) ([]tc.DeliveryServiceV4, error, error, int, *time.Time) {
+// readGetDeliveryServices returns the Delivery Services the user may read,
+// which are those of their Tenancy and of the Tenants with the given
+// viewedTenantIDs. If asOf isn't nil, the Delivery Services are returned as
+// they were at that time.
+func readGetDeliveryServices(h http.Header, params map[string]string, tx *sqlx.Tx, user *auth.CurrentUser, useIMS bool, viewedTenantIDs []int, asOf *time.Time) ([]tc.DeliveryServiceV4, error, error, int, *time.Time) {
 	if tx == nil {
 		return nil, nil, errors.New("nil transaction passed to readGetDeliveryServices"), http.StatusInternalServerError, nil
 	}
@@ -1163,6 +1496,14 @@ func readGetDeliveryServices(h http.Header, params map[string]string, tx *sqlx.T
 	if len(errs) > 0 {
 		return nil, util.JoinErrs(errs), nil, http.StatusBadRequest, nil
 	}
+	query := SelectDeliveryServicesQuery
+	if asOf != nil {
+		// the history of a Delivery Service doesn't change, so there's nothing
+		// for If-Modified-Since to check
+		useIMS = false
+		queryValues[dbhelpers.AsOfQueryParam] = *asOf
+		query = strings.Replace(query, "FROM deliveryservice AS ds", "FROM "+dbhelpers.TableAsOf("deliveryservice")+" AS ds", 1)
+	}
 	if useIMS {
 		runSecond, maxTime = ims.TryIfModifiedSinceQuery(tx, h, queryValues, selectMaxLastUpdatedQuery(where))
 		if !runSecond {
@@ -1180,7 +1521,7 @@ func readGetDeliveryServices(h http.Header, params map[string]string, tx *sqlx.T
 		return nil, nil, err, http.StatusInternalServerError, &maxTime
 	}
 
-	where, queryValues = dbhelpers.AddTenancyCheck(where, queryValues, "ds.tenant_id", tenantIDs)
+	where, queryValues = dbhelpers.AddTenancyCheck(where, queryValues, "ds.tenant_id", append(tenantIDs, viewedTenantIDs...))
 
 	if accessibleTo, ok := params["accessibleTo"]; ok {
 		if err := api.IsInt(accessibleTo); err != nil {
@@ -1195,7 +1536,7 @@ func readGetDeliveryServices(h http.Header, params map[string]string, tx *sqlx.T
 		where += " AND ds.tenant_id = ANY(CAST(:accessibleTo AS bigint[])) "
 		queryValues["accessibleTo"] = pq.Array(accessibleTenants)
 	}
-	query := SelectDeliveryServicesQuery + where + orderBy + pagination
+	query += where + orderBy + pagination
 	log.Debugln("generated deliveryServices query: " + query)
 	log.Debugf("executing with values: %++v\n", queryValues)
 
@@ -1238,8 +1579,38 @@ func requiredIfMatchesTypeName(patterns []string, typeName string) func(interfac
 	}
 }
 
+// streamTimeoutRule returns a validation rule for the named stream timeout of
+// the given Delivery Service, which must be positive and is only meaningful if
+// the Delivery Service passes WebSocket connections or gRPC requests through.
+func streamTimeoutRule(ds *tc.DeliveryServiceV4, name string) func(interface{}) error {
+	return func(value interface{}) error {
+		timeout, ok := value.(*int)
+		if !ok {
+			return fmt.Errorf("validation failure: unknown type %T", value)
+		}
+		if timeout == nil {
+			return nil
+		}
+		if *timeout <= 0 {
+			return errors.New("must be greater than zero")
+		}
+		if !ds.StreamingEnabled() {
+			return fmt.Errorf("%s requires websocket or grpc", name)
+		}
+		return nil
+	}
+}
+
 var validTLSVersionPattern = regexp.MustCompile(`^\d+\.\d+$`)
 
+// validHeaderNamePattern matches the "token" grammar of RFC 7230, to which
+// HTTP header names must conform.
+var validHeaderNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+// validCompressContentTypePattern matches MIME types (without parameters) of
+// which the subtype may be a '*' wildcard, e.g. "text/*".
+var validCompressContentTypePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9!#$&^_.+-]*/(\*|[a-z0-9][a-z0-9!#$&^_.+-]*)$`)
+
 func Validate(tx *sql.Tx, ds *tc.DeliveryServiceV4) error {
 	sanitize(ds)
 	neverOrAlways := validation.NewStringRule(tovalidate.IsOneOfStringICase("NEVER", "ALWAYS"),
@@ -1289,6 +1660,9 @@ func Validate(tx *sql.Tx, ds *tc.DeliveryServiceV4) error {
 	if err := validateTopologyFields(ds); err != nil {
 		errs = append(errs, err)
 	}
+	if err := validateSchedule(ds); err != nil {
+		errs = append(errs, err)
+	}
 	if err := validateTypeFields(tx, ds); err != nil {
 		errs = append(errs, errors.New("type fields: "+err.Error()))
 	}
@@ -1312,6 +1686,104 @@ func validateGeoLimitCountries(ds *tc.DeliveryServiceV4) error {
 	return nil
 }
 
+// validateSchedule checks that a Delivery Service isn't scheduled to become
+// inactive before (or at the same time as) it becomes active, which would make
+// the activation meaningless.
+func validateSchedule(ds *tc.DeliveryServiceV4) error {
+	if ds.ActiveAt != nil && ds.InactiveAt != nil && !ds.InactiveAt.After(*ds.ActiveAt) {
+		return errors.New("inactiveAt must be after activeAt")
+	}
+	return nil
+}
+
+// validateConsistentHashHeaders checks that each of the given consistent hash
+// header names is a valid HTTP header name, and that none is given more than
+// once - header names being case-insensitive.
+func validateConsistentHashHeaders(headers []string) error {
+	seen := make(map[string]struct{}, len(headers))
+	for _, header := range headers {
+		if !validHeaderNamePattern.MatchString(header) {
+			return fmt.Errorf("invalid header name '%s'", header)
+		}
+		key := strings.ToLower(header)
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("duplicate header '%s'", header)
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+// validateSPKIHashes checks that each of the given pinned SPKI hashes is the
+// base64 encoding of a SHA-256 digest, and that none is given more than once.
+func validateSPKIHashes(hashes []string) error {
+	seen := make(map[string]struct{}, len(hashes))
+	for _, hash := range hashes {
+		digest, err := base64.StdEncoding.DecodeString(hash)
+		if err != nil || len(digest) != sha256.Size {
+			return fmt.Errorf("'%s' is not a base64-encoded SHA-256 hash", hash)
+		}
+		if _, ok := seen[hash]; ok {
+			return fmt.Errorf("duplicate hash '%s'", hash)
+		}
+		seen[hash] = struct{}{}
+	}
+	return nil
+}
+
+const originCABundleExistsQuery = `
+SELECT EXISTS(
+	SELECT 1
+	FROM parameter p
+	JOIN profile_parameter pp ON pp.parameter = p.id
+	JOIN profile pr ON pr.id = pp.profile
+	WHERE pr.name = $1
+	AND p.config_file = $2
+	AND p.name = $3
+)
+`
+
+// originCABundleExists returns whether a CA bundle Parameter with the given
+// name is assigned to the GLOBAL Profile.
+func originCABundleExists(tx *sql.Tx, name string) (bool, error) {
+	exists := false
+	err := tx.QueryRow(originCABundleExistsQuery, tc.GlobalProfileName, tc.OriginCABundleConfigFile, name).Scan(&exists)
+	return exists, err
+}
+
+// validateRoutingInterfaces checks that none of the given routing interface
+// names is blank, and that none is given more than once.
+func validateRoutingInterfaces(interfaces []string) error {
+	seen := make(map[string]struct{}, len(interfaces))
+	for _, name := range interfaces {
+		if name == "" {
+			return errors.New("interface names cannot be blank")
+		}
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("duplicate interface '%s'", name)
+		}
+		seen[name] = struct{}{}
+	}
+	return nil
+}
+
+// validateCompressContentTypes checks that each of the given compressible
+// content types is a MIME type - of which the subtype may be a '*' wildcard -
+// and that none is given more than once.
+func validateCompressContentTypes(contentTypes []string) error {
+	seen := make(map[string]struct{}, len(contentTypes))
+	for _, contentType := range contentTypes {
+		if !validCompressContentTypePattern.MatchString(contentType) {
+			return fmt.Errorf("'%s' is not a MIME type", contentType)
+		}
+		if _, ok := seen[contentType]; ok {
+			return fmt.Errorf("duplicate content type '%s'", contentType)
+		}
+		seen[contentType] = struct{}{}
+	}
+	return nil
+}
+
 func validateTopologyFields(ds *tc.DeliveryServiceV4) error {
 	if ds.Topology != nil && (ds.EdgeHeaderRewrite != nil || ds.MidHeaderRewrite != nil) {
 		return errors.New("cannot set edgeHeaderRewrite or midHeaderRewrite while a Topology is assigned. Use firstHeaderRewrite, innerHeaderRewrite, and/or lastHeaderRewrite instead")
@@ -1374,6 +1846,178 @@ func validateTypeFields(tx *sql.Tx, ds *tc.DeliveryServiceV4) error {
 				}
 				return fmt.Errorf("consistentHashQueryParams not allowed for '%s' deliveryservice type", typeName)
 			})),
+		"consistentHashHeaders": validation.Validate(ds,
+			validation.By(func(dsi interface{}) error {
+				ds := dsi.(*tc.DeliveryServiceV4)
+				if len(ds.ConsistentHashHeaders) == 0 {
+					return nil
+				}
+				if !tc.DSType(typeName).IsHTTP() {
+					return fmt.Errorf("consistentHashHeaders not allowed for '%s' deliveryservice type", typeName)
+				}
+				return validateConsistentHashHeaders(ds.ConsistentHashHeaders)
+			})),
+		"consistentHashReplicas": validation.Validate(ds,
+			validation.By(func(dsi interface{}) error {
+				ds := dsi.(*tc.DeliveryServiceV4)
+				if ds.ConsistentHashReplicas == nil {
+					return nil
+				}
+				if !tc.DSType(typeName).IsHTTP() {
+					return fmt.Errorf("consistentHashReplicas not allowed for '%s' deliveryservice type", typeName)
+				}
+				if *ds.ConsistentHashReplicas < 1 {
+					return errors.New("consistentHashReplicas must be a positive integer")
+				}
+				return nil
+			})),
+		"originTLSVerify": validation.Validate(ds,
+			validation.By(func(dsi interface{}) error {
+				ds := dsi.(*tc.DeliveryServiceV4)
+				if ds.OriginTLSVerify == nil || !*ds.OriginTLSVerify {
+					return nil
+				}
+				if dsType := tc.DSType(typeName); !dsType.IsHTTP() && !dsType.IsDNS() {
+					return fmt.Errorf("originTLSVerify not allowed for '%s' deliveryservice type", typeName)
+				}
+				if ds.OrgServerFQDN == nil || !strings.HasPrefix(*ds.OrgServerFQDN, "https://") {
+					return errors.New("originTLSVerify requires an orgServerFqdn using HTTPS")
+				}
+				return nil
+			})),
+		"originTLSPinnedSPKIHashes": validation.Validate(ds,
+			validation.By(func(dsi interface{}) error {
+				ds := dsi.(*tc.DeliveryServiceV4)
+				if len(ds.OriginTLSPinnedSPKIHashes) == 0 {
+					return nil
+				}
+				if ds.OriginTLSVerify == nil || !*ds.OriginTLSVerify || ds.OriginTLSCABundle == nil {
+					return errors.New("originTLSPinnedSPKIHashes requires originTLSVerify and an originTLSCABundle")
+				}
+				return validateSPKIHashes(ds.OriginTLSPinnedSPKIHashes)
+			})),
+		"originTLSCABundle": validation.Validate(ds,
+			validation.By(func(dsi interface{}) error {
+				ds := dsi.(*tc.DeliveryServiceV4)
+				if ds.OriginTLSCABundle == nil {
+					return nil
+				}
+				if ds.OriginTLSVerify == nil || !*ds.OriginTLSVerify {
+					return errors.New("originTLSCABundle requires originTLSVerify")
+				}
+				exists, err := originCABundleExists(tx, *ds.OriginTLSCABundle)
+				if err != nil {
+					log.Errorf("checking existence of origin CA bundle '%s': %v", *ds.OriginTLSCABundle, err)
+					return errors.New("unable to check the existence of the CA bundle")
+				}
+				if !exists {
+					return fmt.Errorf("no '%s' Parameter named '%s' exists on the %s Profile", tc.OriginCABundleConfigFile, *ds.OriginTLSCABundle, tc.GlobalProfileName)
+				}
+				return nil
+			})),
+		"routingInterfaces": validation.Validate(ds,
+			validation.By(func(dsi interface{}) error {
+				ds := dsi.(*tc.DeliveryServiceV4)
+				if len(ds.RoutingInterfaces) == 0 {
+					return nil
+				}
+				if dsType := tc.DSType(typeName); !dsType.IsHTTP() && !dsType.IsDNS() {
+					return fmt.Errorf("routingInterfaces not allowed for '%s' deliveryservice type", typeName)
+				}
+				return validateRoutingInterfaces(ds.RoutingInterfaces)
+			})),
+		"compressGzip": validation.Validate(ds,
+			validation.By(func(dsi interface{}) error {
+				ds := dsi.(*tc.DeliveryServiceV4)
+				if !ds.CompressionEnabled() {
+					return nil
+				}
+				if dsType := tc.DSType(typeName); !dsType.IsHTTP() && !dsType.IsDNS() {
+					return fmt.Errorf("compression not allowed for '%s' deliveryservice type", typeName)
+				}
+				return nil
+			})),
+		"compressContentTypes": validation.Validate(ds,
+			validation.By(func(dsi interface{}) error {
+				ds := dsi.(*tc.DeliveryServiceV4)
+				if len(ds.CompressContentTypes) == 0 {
+					return nil
+				}
+				if !ds.CompressionEnabled() {
+					return errors.New("compressContentTypes requires compressGzip or compressBrotli")
+				}
+				return validateCompressContentTypes(ds.CompressContentTypes)
+			})),
+		"compressMinSize": validation.Validate(ds,
+			validation.By(func(dsi interface{}) error {
+				ds := dsi.(*tc.DeliveryServiceV4)
+				if ds.CompressMinSize == nil {
+					return nil
+				}
+				if !ds.CompressionEnabled() {
+					return errors.New("compressMinSize requires compressGzip or compressBrotli")
+				}
+				if *ds.CompressMinSize < 0 {
+					return errors.New("cannot be negative")
+				}
+				return nil
+			})),
+		"traceHeaderPolicy": validation.Validate(ds.TraceHeaderPolicy,
+			validation.By(func(policy interface{}) error {
+				p := policy.(*tc.TraceHeaderPolicy)
+				if p == nil {
+					return nil
+				}
+				_, err := tc.TraceHeaderPolicyFromString(string(*p))
+				return err
+			})),
+		"traceHeaderName": validation.Validate(ds,
+			validation.By(func(dsi interface{}) error {
+				ds := dsi.(*tc.DeliveryServiceV4)
+				if ds.TraceHeaderName == nil {
+					return nil
+				}
+				if !ds.TraceHeaderEnabled() {
+					return errors.New("traceHeaderName requires a traceHeaderPolicy other than 'none'")
+				}
+				if !validHeaderNamePattern.MatchString(*ds.TraceHeaderName) {
+					return fmt.Errorf("invalid header name '%s'", *ds.TraceHeaderName)
+				}
+				return nil
+			})),
+		"websocket": validation.Validate(ds.WebSocket,
+			validation.By(func(webSocket interface{}) error {
+				if !ds.WebSocketEnabled() || tc.DSType(typeName).IsHTTP() {
+					return nil
+				}
+				return fmt.Errorf("websocket not allowed for '%s' deliveryservice type", typeName)
+			})),
+		"grpc": validation.Validate(ds,
+			validation.By(func(dsi interface{}) error {
+				ds := dsi.(*tc.DeliveryServiceV4)
+				if !ds.GRPCEnabled() {
+					return nil
+				}
+				if !tc.DSType(typeName).IsHTTP() {
+					return fmt.Errorf("grpc not allowed for '%s' deliveryservice type", typeName)
+				}
+				// cache servers only speak HTTP/1.1 to each other, so gRPC has
+				// to go straight from the edge to the origin
+				if tc.DSType(typeName).UsesMidCache() || (ds.Topology != nil && *ds.Topology != "") {
+					return errors.New("grpc requires a deliveryservice type that doesn't use mid-tier cache servers, and no topology")
+				}
+				if ds.Protocol == nil || *ds.Protocol == tc.DSProtocolHTTP {
+					return errors.New("grpc requires a protocol that serves HTTPS")
+				}
+				if ds.OrgServerFQDN == nil || !strings.HasPrefix(*ds.OrgServerFQDN, "https://") {
+					return errors.New("grpc requires an https:// orgServerFqdn")
+				}
+				return nil
+			})),
+		"streamIdleTimeout": validation.Validate(ds.StreamIdleTimeout,
+			validation.By(streamTimeoutRule(ds, "streamIdleTimeout"))),
+		"streamActiveTimeout": validation.Validate(ds.StreamActiveTimeout,
+			validation.By(streamTimeoutRule(ds, "streamActiveTimeout"))),
 		"initialDispersion": validation.Validate(ds.InitialDispersion,
 			validation.By(requiredIfMatchesTypeName([]string{HTTPRegexType}, typeName)),
 			validation.By(tovalidate.IsGreaterThanZero)),
@@ -1585,12 +2229,20 @@ func GetDeliveryServices(query string, queryValues map[string]interface{}, tx *s
 		ds := tc.DeliveryServiceV4{}
 		cdnDomain := ""
 		err := rows.Scan(&ds.Active,
+			&ds.ActiveAt,
 			&ds.AnonymousBlockingEnabled,
 			&ds.CCRDNSTTL,
 			&ds.CDNID,
 			&ds.CDNName,
 			&ds.CheckPath,
+			&ds.CompressBrotli,
+			pq.Array(&ds.CompressContentTypes),
+			&ds.CompressGzip,
+			&ds.CompressMinSize,
+			pq.Array(&ds.ConsistentHashHeaders),
+			&ds.ConsistentHashIncludePath,
 			&ds.ConsistentHashRegex,
+			&ds.ConsistentHashReplicas,
 			&ds.DeepCachingType,
 			&ds.DisplayName,
 			&ds.DNSBypassCNAME,
@@ -1610,6 +2262,7 @@ func GetDeliveryServices(query string, queryValues map[string]interface{}, tx *s
 			&ds.FQPacingRate,
 			&ds.HTTPBypassFQDN,
 			&ds.ID,
+			&ds.InactiveAt,
 			&ds.InfoURL,
 			&ds.InitialDispersion,
 			&ds.InnerHeaderRewrite,
@@ -1629,6 +2282,9 @@ func GetDeliveryServices(query string, queryValues map[string]interface{}, tx *s
 			&ds.MultiSiteOrigin,
 			&ds.OrgServerFQDN,
 			&ds.OriginShield,
+			&ds.OriginTLSCABundle,
+			pq.Array(&ds.OriginTLSPinnedSPKIHashes),
+			&ds.OriginTLSVerify,
 			&ds.ProfileID,
 			&ds.ProfileName,
 			&ds.ProfileDesc,
@@ -1639,6 +2295,7 @@ func GetDeliveryServices(query string, queryValues map[string]interface{}, tx *s
 			&ds.RegexRemap,
 			&ds.RegionalGeoBlocking,
 			&ds.RemapText,
+			pq.Array(&ds.RoutingInterfaces),
 			&ds.RoutingName,
 			&ds.ServiceCategory,
 			&ds.SigningAlgorithm,
@@ -1650,6 +2307,12 @@ func GetDeliveryServices(query string, queryValues map[string]interface{}, tx *s
 			&ds.Topology,
 			&ds.TRRequestHeaders,
 			&ds.TRResponseHeaders,
+			&ds.TraceHeaderName,
+			&ds.TraceHeaderPolicy,
+			&ds.WebSocket,
+			&ds.GRPC,
+			&ds.StreamIdleTimeout,
+			&ds.StreamActiveTimeout,
 			&ds.Type,
 			&ds.TypeID,
 			&ds.XMLID,
@@ -2080,12 +2743,57 @@ func sanitize(ds *tc.DeliveryServiceV4) {
 	if ds.ProfileID != nil && *ds.ProfileID == -1 {
 		ds.ProfileID = nil
 	}
+	if ds.ConsistentHashIncludePath == nil {
+		ds.ConsistentHashIncludePath = util.BoolPtr(true)
+	}
+	if ds.ConsistentHashHeaders == nil {
+		ds.ConsistentHashHeaders = []string{}
+	}
+	for i, header := range ds.ConsistentHashHeaders {
+		ds.ConsistentHashHeaders[i] = strings.TrimSpace(header)
+	}
+	if ds.OriginTLSVerify == nil {
+		ds.OriginTLSVerify = util.BoolPtr(false)
+	}
+	if ds.OriginTLSPinnedSPKIHashes == nil {
+		ds.OriginTLSPinnedSPKIHashes = []string{}
+	}
+	for i, hash := range ds.OriginTLSPinnedSPKIHashes {
+		ds.OriginTLSPinnedSPKIHashes[i] = strings.TrimSpace(hash)
+	}
+	if ds.RoutingInterfaces == nil {
+		ds.RoutingInterfaces = []string{}
+	}
+	for i, name := range ds.RoutingInterfaces {
+		ds.RoutingInterfaces[i] = strings.TrimSpace(name)
+	}
+	if ds.CompressGzip == nil {
+		ds.CompressGzip = util.BoolPtr(false)
+	}
+	if ds.CompressBrotli == nil {
+		ds.CompressBrotli = util.BoolPtr(false)
+	}
+	if ds.CompressContentTypes == nil {
+		ds.CompressContentTypes = []string{}
+	}
+	for i, contentType := range ds.CompressContentTypes {
+		ds.CompressContentTypes[i] = strings.ToLower(strings.TrimSpace(contentType))
+	}
+	if ds.TraceHeaderPolicy != nil {
+		policy := tc.TraceHeaderPolicy(strings.ToLower(strings.TrimSpace(string(*ds.TraceHeaderPolicy))))
+		ds.TraceHeaderPolicy = &policy
+	}
+	if ds.TraceHeaderName != nil {
+		*ds.TraceHeaderName = strings.TrimSpace(*ds.TraceHeaderName)
+	}
 	setNilIfEmpty(
 		&ds.EdgeHeaderRewrite,
 		&ds.MidHeaderRewrite,
 		&ds.FirstHeaderRewrite,
 		&ds.InnerHeaderRewrite,
 		&ds.LastHeaderRewrite,
+		&ds.OriginTLSCABundle,
+		&ds.TraceHeaderName,
 	)
 	if ds.RoutingName == nil || *ds.RoutingName == "" {
 		ds.RoutingName = util.StrPtr(tc.DefaultRoutingName)
@@ -2118,12 +2826,20 @@ func sanitize(ds *tc.DeliveryServiceV4) {
 const SelectDeliveryServicesQuery = `
 SELECT
 ds.active,
+	ds.active_at,
 	ds.anonymous_blocking_enabled,
 	ds.ccr_dns_ttl,
 	ds.cdn_id,
 	cdn.name AS cdnName,
 	ds.check_path,
+	ds.compress_brotli,
+	ds.compress_content_types,
+	ds.compress_gzip,
+	ds.compress_min_size,
+	ds.consistent_hash_headers,
+	ds.consistent_hash_include_path,
 	ds.consistent_hash_regex,
+	ds.consistent_hash_replicas,
 	CAST(ds.deep_caching_type AS text) AS deep_caching_type,
 	ds.display_name,
 	ds.dns_bypass_cname,
@@ -2143,6 +2859,7 @@ ds.active,
 	ds.fq_pacing_rate,
 	ds.http_bypass_fqdn,
 	ds.id,
+	ds.inactive_at,
 	ds.info_url,
 	ds.initial_dispersion,
 	ds.inner_header_rewrite,
@@ -2165,6 +2882,9 @@ ds.active,
 		WHERE o.deliveryservice = ds.id
 		AND o.is_primary) AS org_server_fqdn,
 	ds.origin_shield,
+	ds.origin_tls_ca_bundle,
+	ds.origin_tls_pinned_spki_hashes,
+	ds.origin_tls_verify,
 	ds.profile AS profileID,
 	profile.name AS profile_name,
 	profile.description  AS profile_description,
@@ -2177,6 +2897,7 @@ ds.active,
 	ds.regex_remap,
 	ds.regional_geo_blocking,
 	ds.remap_text,
+	ds.routing_interfaces,
 	ds.routing_name,
 	ds.service_category,
 	ds.signing_algorithm,
@@ -2188,6 +2909,12 @@ ds.active,
 	ds.topology,
 	ds.tr_request_headers,
 	ds.tr_response_headers,
+	ds.trace_header_name,
+	ds.trace_header_policy,
+	ds.websocket,
+	ds.grpc,
+	ds.stream_idle_timeout,
+	ds.stream_active_timeout,
 	type.name,
 	ds.type AS type_id,
 	ds.xml_id,
@@ -2261,8 +2988,27 @@ first_header_rewrite=$55,
 inner_header_rewrite=$56,
 last_header_rewrite=$57,
 service_category=$58,
-max_request_header_bytes=$59
-WHERE id=$60
+max_request_header_bytes=$59,
+active_at=$60,
+inactive_at=$61,
+consistent_hash_include_path=$62,
+consistent_hash_headers=$63,
+consistent_hash_replicas=$64,
+origin_tls_verify=$65,
+origin_tls_pinned_spki_hashes=$66,
+origin_tls_ca_bundle=$67,
+routing_interfaces=$68,
+compress_gzip=$69,
+compress_brotli=$70,
+compress_content_types=$71,
+compress_min_size=$72,
+trace_header_policy=$73,
+trace_header_name=$74,
+websocket=$75,
+grpc=$76,
+stream_idle_timeout=$77,
+stream_active_timeout=$78
+WHERE id=$79
 RETURNING last_updated
 `
 }
@@ -2327,8 +3073,27 @@ first_header_rewrite=$53,
 inner_header_rewrite=$54,
 last_header_rewrite=$55,
 service_category=$56,
-max_request_header_bytes=$57
-WHERE id=$58
+max_request_header_bytes=$57,
+active_at=$58,
+inactive_at=$59,
+consistent_hash_include_path=$60,
+consistent_hash_headers=$61,
+consistent_hash_replicas=$62,
+origin_tls_verify=$63,
+origin_tls_pinned_spki_hashes=$64,
+origin_tls_ca_bundle=$65,
+routing_interfaces=$66,
+compress_gzip=$67,
+compress_brotli=$68,
+compress_content_types=$69,
+compress_min_size=$70,
+trace_header_policy=$71,
+trace_header_name=$72,
+websocket=$73,
+grpc=$74,
+stream_idle_timeout=$75,
+stream_active_timeout=$76
+WHERE id=$77
 RETURNING last_updated
 `
 }
@@ -2394,9 +3159,28 @@ first_header_rewrite,
 inner_header_rewrite,
 last_header_rewrite,
 service_category,
-max_request_header_bytes
+max_request_header_bytes,
+active_at,
+inactive_at,
+consistent_hash_include_path,
+consistent_hash_headers,
+consistent_hash_replicas,
+origin_tls_verify,
+origin_tls_pinned_spki_hashes,
+origin_tls_ca_bundle,
+routing_interfaces,
+compress_gzip,
+compress_brotli,
+compress_content_types,
+compress_min_size,
+trace_header_policy,
+trace_header_name,
+websocket,
+grpc,
+stream_idle_timeout,
+stream_active_timeout
 )
-VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24,$25,$26,$27,$28,$29,$30,$31,$32,$33,$34,$35,$36,$37,$38,$39,$40,$41,$42,$43,$44,$45,$46,$47,$48,$49,$50,$51,$52,$53,$54,$55,$56,$57,$58,$59)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24,$25,$26,$27,$28,$29,$30,$31,$32,$33,$34,$35,$36,$37,$38,$39,$40,$41,$42,$43,$44,$45,$46,$47,$48,$49,$50,$51,$52,$53,$54,$55,$56,$57,$58,$59,$60,$61,$62,$63,$64,$65,$66,$67,$68,$69,$70,$71,$72,$73,$74,$75,$76,$77,$78)
 RETURNING id, last_updated
 `
 }
@@ -2460,9 +3244,28 @@ first_header_rewrite,
 inner_header_rewrite,
 last_header_rewrite,
 service_category,
-max_request_header_bytes
+max_request_header_bytes,
+active_at,
+inactive_at,
+consistent_hash_include_path,
+consistent_hash_headers,
+consistent_hash_replicas,
+origin_tls_verify,
+origin_tls_pinned_spki_hashes,
+origin_tls_ca_bundle,
+routing_interfaces,
+compress_gzip,
+compress_brotli,
+compress_content_types,
+compress_min_size,
+trace_header_policy,
+trace_header_name,
+websocket,
+grpc,
+stream_idle_timeout,
+stream_active_timeout
 )
-VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24,$25,$26,$27,$28,$29,$30,$31,$32,$33,$34,$35,$36,$37,$38,$39,$40,$41,$42,$43,$44,$45,$46,$47,$48,$49,$50,$51,$52,$53,$54,$55,$56,$57)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24,$25,$26,$27,$28,$29,$30,$31,$32,$33,$34,$35,$36,$37,$38,$39,$40,$41,$42,$43,$44,$45,$46,$47,$48,$49,$50,$51,$52,$53,$54,$55,$56,$57,$58,$59,$60,$61,$62,$63,$64,$65,$66,$67,$68,$69,$70,$71,$72,$73,$74,$75,$76)
 RETURNING id, last_updated
 `
 }
@@ -265,12 +265,20 @@ func TestReadGetDeliveryServices(t *testing.T) {
 	mock.ExpectQuery("WITH RECURSIVE").WillReturnRows(tenantRows)
 	dsRows := sqlmock.NewRows([]string{
 		"active",
+		"active_at",
 		"anonymous_blocking_enabled",
 		"ccr_dns_ttl",
 		"cdn_id",
 		"cdnName",
 		"check_path",
+		"compress_brotli",
+		"compress_content_types",
+		"compress_gzip",
+		"compress_min_size",
+		"consistent_hash_headers",
+		"consistent_hash_include_path",
 		"consistent_hash_regex",
+		"consistent_hash_replicas",
 		"deep_caching_type",
 		"display_name",
 		"dns_bypass_cname",
@@ -290,6 +298,7 @@ func TestReadGetDeliveryServices(t *testing.T) {
 		"fq_pacing_rate",
 		"http_bypass_fqdn",
 		"id",
+		"inactive_at",
 		"info_url",
 		"initial_dispersion",
 		"inner_header_rewrite",
@@ -309,6 +318,9 @@ func TestReadGetDeliveryServices(t *testing.T) {
 		"multi_site_origin",
 		"org_server_fqdn",
 		"origin_shield",
+		"origin_tls_ca_bundle",
+		"origin_tls_pinned_spki_hashes",
+		"origin_tls_verify",
 		"profileID",
 		"profile_name",
 		"profile_description",
@@ -319,6 +331,7 @@ func TestReadGetDeliveryServices(t *testing.T) {
 		"regex_remap",
 		"regional_geo_blocking",
 		"remap_text",
+		"routing_interfaces",
 		"routing_name",
 		"service_category",
 		"signing_algorithm",
@@ -330,6 +343,12 @@ func TestReadGetDeliveryServices(t *testing.T) {
 		"topology",
 		"tr_request_headers",
 		"tr_response_headers",
+		"trace_header_name",
+		"trace_header_policy",
+		"websocket",
+		"grpc",
+		"stream_idle_timeout",
+		"stream_active_timeout",
 		"name",
 		"type_id",
 		"xml_id",
@@ -337,12 +356,20 @@ func TestReadGetDeliveryServices(t *testing.T) {
 	})
 	dsRows.AddRow(
 		true,
+		nil,
 		false,
 		nil,
 		1,
 		"test",
 		"",
+		false,
+		"{}",
+		false,
+		nil,
+		"{}",
+		true,
 		"",
+		nil,
 		"NEVER",
 		"Demo 1",
 		nil,
@@ -363,6 +390,7 @@ func TestReadGetDeliveryServices(t *testing.T) {
 		nil,
 		1,
 		nil,
+		nil,
 		1,
 		nil,
 		true,
@@ -382,6 +410,9 @@ func TestReadGetDeliveryServices(t *testing.T) {
 		"origin.infra.ciab.test",
 		nil,
 		nil,
+		"{}",
+		false,
+		nil,
 		nil,
 		nil,
 		0,
@@ -391,6 +422,7 @@ func TestReadGetDeliveryServices(t *testing.T) {
 		nil,
 		false,
 		nil,
+		"{}",
 		"video",
 		nil,
 		nil,
@@ -402,6 +434,12 @@ func TestReadGetDeliveryServices(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
+		false,
+		false,
+		nil,
+		nil,
 		"test",
 		1,
 		"demo1",
@@ -412,7 +450,7 @@ func TestReadGetDeliveryServices(t *testing.T) {
 	regexRows.AddRow("demo1", "hostregexp", "", 0)
 	mock.ExpectQuery("SELECT ds\\.xml_id as ds_name, t\\.name as type, r\\.pattern, COALESCE\\(dsr\\.set_number, 0\\) FROM regex").WillReturnRows(regexRows)
 
-	_, userErr, sysErr, _, _ := readGetDeliveryServices(nil, nil, db.MustBegin(), &u, false)
+	_, userErr, sysErr, _, _ := readGetDeliveryServices(nil, nil, db.MustBegin(), &u, false, nil, nil)
 	if userErr != nil {
 		t.Errorf("Unexpected user error reading Delivery Services: %v", userErr)
 	}
@@ -420,3 +458,60 @@ func TestReadGetDeliveryServices(t *testing.T) {
 		t.Errorf("Unexpected system error reading Delivery Services: %v", sysErr)
 	}
 }
+
+func TestValidateConsistentHashHeaders(t *testing.T) {
+	if err := validateConsistentHashHeaders([]string{"X-Device-Class", "Accept-Language"}); err != nil {
+		t.Errorf("Unexpected error validating valid consistent hash headers: %v", err)
+	}
+	if err := validateConsistentHashHeaders([]string{"X-Device-Class", "x-device-class"}); err == nil {
+		t.Error("Expected an error validating consistent hash headers that differ only in case, got none")
+	}
+	if err := validateConsistentHashHeaders([]string{"Bad Header"}); err == nil {
+		t.Error("Expected an error validating a consistent hash header with a space in its name, got none")
+	}
+	if err := validateConsistentHashHeaders([]string{"X-Header:"}); err == nil {
+		t.Error("Expected an error validating a consistent hash header with a colon in its name, got none")
+	}
+}
+
+func TestValidateSPKIHashes(t *testing.T) {
+	if err := validateSPKIHashes([]string{"47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=", "LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ="}); err != nil {
+		t.Errorf("Unexpected error validating valid SPKI hashes: %v", err)
+	}
+	if err := validateSPKIHashes([]string{"47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=", "47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="}); err == nil {
+		t.Error("Expected an error validating duplicate SPKI hashes, got none")
+	}
+	if err := validateSPKIHashes([]string{"not base64!"}); err == nil {
+		t.Error("Expected an error validating an SPKI hash that isn't base64-encoded, got none")
+	}
+	if err := validateSPKIHashes([]string{"dGVzdA=="}); err == nil {
+		t.Error("Expected an error validating an SPKI hash that isn't a SHA-256 digest, got none")
+	}
+}
+
+func TestValidateRoutingInterfaces(t *testing.T) {
+	if err := validateRoutingInterfaces([]string{"vlan100", "eth1"}); err != nil {
+		t.Errorf("Unexpected error validating valid routing interfaces: %v", err)
+	}
+	if err := validateRoutingInterfaces([]string{"vlan100", "vlan100"}); err == nil {
+		t.Error("Expected an error validating duplicate routing interfaces, got none")
+	}
+	if err := validateRoutingInterfaces([]string{""}); err == nil {
+		t.Error("Expected an error validating a blank routing interface, got none")
+	}
+}
+
+func TestValidateCompressContentTypes(t *testing.T) {
+	if err := validateCompressContentTypes([]string{"text/*", "application/json", "image/svg+xml"}); err != nil {
+		t.Errorf("Unexpected error validating valid compressible content types: %v", err)
+	}
+	if err := validateCompressContentTypes([]string{"text/html", "text/html"}); err == nil {
+		t.Error("Expected an error validating duplicate compressible content types, got none")
+	}
+	if err := validateCompressContentTypes([]string{"*/*"}); err == nil {
+		t.Error("Expected an error validating a compressible content type with a wildcard type, got none")
+	}
+	if err := validateCompressContentTypes([]string{"text/html; charset=utf-8"}); err == nil {
+		t.Error("Expected an error validating a compressible content type with parameters, got none")
+	}
+}
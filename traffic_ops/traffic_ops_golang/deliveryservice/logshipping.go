@@ -0,0 +1,322 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+const selectLogShippingQuery = `
+SELECT ds.id, ds.xml_id, l.destination_type, l.destination, l.credentials, l.format, l.sample_rate, l.scrub_rules, l.last_updated
+FROM deliveryservice_log_shipping AS l
+JOIN deliveryservice AS ds ON ds.id = l.deliveryservice
+`
+
+const upsertLogShippingQuery = `
+INSERT INTO deliveryservice_log_shipping (deliveryservice, destination_type, destination, credentials, format, sample_rate, scrub_rules)
+VALUES ($1, $2, $3, $4, $5, $6, $7::jsonb)
+ON CONFLICT (deliveryservice) DO UPDATE SET
+destination_type = EXCLUDED.destination_type,
+destination = EXCLUDED.destination,
+credentials = EXCLUDED.credentials,
+format = EXCLUDED.format,
+sample_rate = EXCLUDED.sample_rate,
+scrub_rules = EXCLUDED.scrub_rules
+`
+
+const deleteLogShippingQuery = `
+DELETE FROM deliveryservice_log_shipping
+WHERE deliveryservice = $1
+`
+
+// GetLogShipping is the handler for GET requests to
+// /deliveryservices/{{ID}}/log-shipping.
+func GetLogShipping(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	userErr, sysErr, errCode = tenant.CheckID(tx, inf.User, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	shipping, userErr, sysErr, errCode := getDSLogShipping(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if !canSeeLogShippingCredentials(inf) {
+		hideLogShippingCredentials(&shipping)
+	}
+	api.WriteResp(w, r, shipping)
+}
+
+// GetCDNLogShipping is the handler for GET requests to
+// /deliveryservices/log-shipping, which returns the log shipping
+// configuration of every Delivery Service with any, optionally only those in
+// the CDN named by the 'cdn' query parameter. It exists so that cache
+// configuration generation doesn't need a request per Delivery Service.
+func GetCDNLogShipping(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	tenants, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting accessible tenants for user: %w", err))
+		return
+	}
+
+	query := selectLogShippingQuery + `WHERE ds.tenant_id = ANY($1)`
+	args := []interface{}{pq.Array(tenants)}
+	if cdn, ok := inf.Params["cdn"]; ok {
+		if _, ok, err := dbhelpers.GetCDNIDFromName(tx, tc.CDNName(cdn)); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("checking CDN existence: %w", err))
+			return
+		} else if !ok {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no such CDN: %s", cdn), nil)
+			return
+		}
+		query += ` AND ds.cdn_id = (SELECT id FROM cdn WHERE name = $2)`
+		args = append(args, cdn)
+	}
+
+	all, err := readLogShipping(tx, query+` ORDER BY ds.xml_id`, args...)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if !canSeeLogShippingCredentials(inf) {
+		for i := range all {
+			hideLogShippingCredentials(&all[i])
+		}
+	}
+	api.WriteResp(w, r, all)
+}
+
+// UpdateLogShipping is the handler for PUT requests to
+// /deliveryservices/{{ID}}/log-shipping, which sets the log shipping
+// configuration of a Delivery Service.
+func UpdateLogShipping(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	dsName, userErr, sysErr, errCode := checkLogShippingDS(tx, inf, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var req tc.DeliveryServiceLogShippingRequest
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	if req.ScrubRules == nil {
+		req.ScrubRules = []tc.LogShippingScrubRule{}
+	}
+	if req.Credentials != nil && *req.Credentials == tc.HiddenLogShippingCredentials {
+		current, userErr, sysErr, _ := getDSLogShipping(tx, dsID)
+		if sysErr != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
+			return
+		}
+		if userErr != nil || current.Credentials == nil {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("credentials: Delivery Service has no current credentials to keep"), nil)
+			return
+		}
+		req.Credentials = current.Credentials
+	} else if req.Credentials != nil && *req.Credentials == "" {
+		req.Credentials = nil
+	}
+
+	scrubRules, err := json.Marshal(req.ScrubRules)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("encoding scrub rules: "+err.Error()))
+		return
+	}
+	if _, err := tx.Exec(upsertLogShippingQuery, dsID, req.DestinationType, req.Destination, req.Credentials, req.Format, *req.SampleRate, string(scrubRules)); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	shipping, userErr, sysErr, errCode := getDSLogShipping(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if !canSeeLogShippingCredentials(inf) {
+		hideLogShippingCredentials(&shipping)
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+dsName+", ID: "+strconv.Itoa(dsID)+", ACTION: Updated log shipping to "+req.DestinationType+" destination "+req.Destination, inf.User, tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, fmt.Sprintf("Delivery Service '%s' log shipping updated; queue updates on the CDN to apply it", dsName), shipping)
+}
+
+// DeleteLogShipping is the handler for DELETE requests to
+// /deliveryservices/{{ID}}/log-shipping, which stops the shipping of a
+// Delivery Service's access logs.
+func DeleteLogShipping(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	dsName, userErr, sysErr, errCode := checkLogShippingDS(tx, inf, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	result, err := tx.Exec(deleteLogShippingQuery, dsID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deleting Delivery Service log shipping: "+err.Error()))
+		return
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting rows affected by deleting log shipping: "+err.Error()))
+		return
+	} else if rows == 0 {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("Delivery Service '%s' has no log shipping", dsName), nil)
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+dsName+", ID: "+strconv.Itoa(dsID)+", ACTION: Deleted log shipping", inf.User, tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, fmt.Sprintf("Delivery Service '%s' log shipping deleted; queue updates on the CDN to apply it", dsName))
+}
+
+// checkLogShippingDS checks that the user may modify the log shipping of the
+// Delivery Service with the given ID. It returns the Delivery Service's
+// XMLID, along with a user error, system error, and status code.
+func checkLogShippingDS(tx *sql.Tx, inf *api.APIInfo, dsID int) (string, error, error, int) {
+	userErr, sysErr, errCode := tenant.CheckID(tx, inf.User, dsID)
+	if userErr != nil || sysErr != nil {
+		return "", userErr, sysErr, errCode
+	}
+	dsName, cdn, ok, err := dbhelpers.GetDSNameAndCDNFromID(tx, dsID)
+	if err != nil {
+		return "", nil, errors.New("getting Delivery Service name and CDN: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return "", fmt.Errorf("no Delivery Service exists by ID '%d'", dsID), nil, http.StatusNotFound
+	}
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyCDN(tx, string(cdn), inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		return "", userErr, sysErr, errCode
+	}
+	return string(dsName), nil, nil, http.StatusOK
+}
+
+// canSeeLogShippingCredentials returns whether the user may see the
+// credentials of log shipping destinations, which are as sensitive as
+// Delivery Service security keys.
+func canSeeLogShippingCredentials(inf *api.APIInfo) bool {
+	if inf.Config.RoleBasedPermissions {
+		return inf.User.Can("DS-SECURITY-KEY:READ")
+	}
+	return inf.User.PrivLevel >= auth.PrivLevelOperations
+}
+
+// hideLogShippingCredentials replaces the credentials of the given log
+// shipping, if it has any, with tc.HiddenLogShippingCredentials.
+func hideLogShippingCredentials(shipping *tc.DeliveryServiceLogShipping) {
+	if shipping.Credentials != nil {
+		hidden := tc.HiddenLogShippingCredentials
+		shipping.Credentials = &hidden
+	}
+}
+
+// getDSLogShipping returns the log shipping of the Delivery Service with the
+// given ID, along with a user error, system error, and status code.
+func getDSLogShipping(tx *sql.Tx, dsID int) (tc.DeliveryServiceLogShipping, error, error, int) {
+	all, err := readLogShipping(tx, selectLogShippingQuery+`WHERE ds.id = $1`, dsID)
+	if err != nil {
+		return tc.DeliveryServiceLogShipping{}, nil, err, http.StatusInternalServerError
+	}
+	if len(all) == 0 {
+		return tc.DeliveryServiceLogShipping{}, fmt.Errorf("Delivery Service #%d has no log shipping", dsID), nil, http.StatusNotFound
+	}
+	return all[0], nil, nil, http.StatusOK
+}
+
+// readLogShipping reads the rows of the given query, which must select the
+// columns of selectLogShippingQuery.
+func readLogShipping(tx *sql.Tx, query string, args ...interface{}) ([]tc.DeliveryServiceLogShipping, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, errors.New("querying Delivery Service log shipping: " + err.Error())
+	}
+	defer log.Close(rows, "closing Delivery Service log shipping rows")
+
+	all := []tc.DeliveryServiceLogShipping{}
+	for rows.Next() {
+		var shipping tc.DeliveryServiceLogShipping
+		var scrubRules []byte
+		if err := rows.Scan(&shipping.DeliveryServiceID, &shipping.XMLID, &shipping.DestinationType, &shipping.Destination, &shipping.Credentials, &shipping.Format, &shipping.SampleRate, &scrubRules, &shipping.LastUpdated); err != nil {
+			return nil, errors.New("scanning Delivery Service log shipping: " + err.Error())
+		}
+		if err := json.Unmarshal(scrubRules, &shipping.ScrubRules); err != nil {
+			return nil, fmt.Errorf("decoding scrub rules of Delivery Service '%s': %w", shipping.XMLID, err)
+		}
+		if shipping.ScrubRules == nil {
+			shipping.ScrubRules = []tc.LogShippingScrubRule{}
+		}
+		all = append(all, shipping)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over Delivery Service log shipping: " + err.Error())
+	}
+	return all, nil
+}
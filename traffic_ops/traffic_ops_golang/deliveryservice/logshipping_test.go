@@ -0,0 +1,80 @@
+package deliveryservice
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	"github.com/jmoiron/sqlx"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestReadLogShipping(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	updated := time.Date(2022, 5, 20, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	rows := sqlmock.NewRows([]string{"id", "xml_id", "destination_type", "destination", "credentials", "format", "sample_rate", "scrub_rules", "last_updated"}).
+		AddRow(1, "ds1", "S3", "s3://logs/ds1?region=us-east-1", "AKID:secret", "json", 0.5, []byte(`[{"field":"clientIp","action":"mask"}]`), updated).
+		AddRow(2, "ds2", "HTTPS", "https://logs.example.com/", nil, "squid", 1.0, []byte(`[]`), updated)
+	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	tx := db.MustBegin().Tx
+	all, err := readLogShipping(tx, selectLogShippingQuery)
+	if err != nil {
+		t.Fatalf("unexpected error reading log shipping: %v", err)
+	}
+	tx.Commit()
+
+	if len(all) != 2 {
+		t.Fatalf("expected log shipping of 2 delivery services, actual: %d", len(all))
+	}
+	if all[0].XMLID != "ds1" || all[0].SampleRate != 0.5 || all[0].Credentials == nil || *all[0].Credentials != "AKID:secret" {
+		t.Errorf("expected log shipping of 'ds1' at half sample rate with credentials, actual: %+v", all[0])
+	}
+	if len(all[0].ScrubRules) != 1 || all[0].ScrubRules[0].Field != tc.LogShippingFieldClientIP || all[0].ScrubRules[0].Action != tc.LogShippingScrubMask {
+		t.Errorf("expected 'ds1' to mask client IPs, actual: %+v", all[0].ScrubRules)
+	}
+	if all[1].Credentials != nil || all[1].ScrubRules == nil || len(all[1].ScrubRules) != 0 {
+		t.Errorf("expected 'ds2' to have no credentials and empty, non-nil scrub rules, actual: %+v", all[1])
+	}
+
+	hideLogShippingCredentials(&all[0])
+	hideLogShippingCredentials(&all[1])
+	if all[0].Credentials == nil || *all[0].Credentials != tc.HiddenLogShippingCredentials {
+		t.Errorf("expected hidden credentials of 'ds1', actual: %v", all[0].Credentials)
+	}
+	if all[1].Credentials != nil {
+		t.Errorf("expected hiding absent credentials to leave them absent, actual: %v", *all[1].Credentials)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
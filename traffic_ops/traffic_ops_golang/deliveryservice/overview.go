@@ -0,0 +1,286 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/util/monitorhlp"
+
+	"github.com/lib/pq"
+)
+
+const selectOverviewsQuery = `
+SELECT ds.id, ds.xml_id, ds.display_name, ds.active, cdn.name, type.name, tenant.name, ds.topology, ds.protocol,
+	(
+		SELECT COUNT(*)
+		FROM deliveryservice_request AS r
+		WHERE r.status IN ('draft', 'submitted', 'pending')
+		AND COALESCE(r.deliveryservice->>'xmlId', r.original->>'xmlId') = ds.xml_id
+	) AS pending_requests
+FROM deliveryservice AS ds
+JOIN cdn ON cdn.id = ds.cdn_id
+JOIN type ON type.id = ds.type
+JOIN tenant ON tenant.id = ds.tenant_id
+WHERE ds.tenant_id = ANY($1)
+`
+
+// GetOverview is the handler for GET requests to /ui/deliveryservices-overview,
+// which returns a summary of every Delivery Service the user can see,
+// optionally only those in the CDN named by the 'cdn' query parameter. It
+// combines the Delivery Services with their health, certificate expiration
+// and pending Delivery Service Requests, which user interfaces would
+// otherwise need several requests per Delivery Service to get.
+//
+// Information which can't be fetched, like the health of Delivery Services
+// in a CDN whose Traffic Monitors can't be reached, is left out with a
+// warning, rather than failing the whole request.
+func GetOverview(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	tenants, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting accessible tenants for user: %w", err))
+		return
+	}
+
+	query := selectOverviewsQuery
+	args := []interface{}{pq.Array(tenants)}
+	if cdn, ok := inf.Params["cdn"]; ok {
+		if _, ok, err := dbhelpers.GetCDNIDFromName(tx, tc.CDNName(cdn)); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("checking CDN existence: %w", err))
+			return
+		} else if !ok {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no such CDN: %s", cdn), nil)
+			return
+		}
+		query += ` AND cdn.name = $2`
+		args = append(args, cdn)
+	}
+
+	overviews, err := readOverviews(tx, query+` ORDER BY ds.xml_id`, args...)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	if len(overviews) == 0 {
+		api.WriteResp(w, r, overviews)
+		return
+	}
+
+	alerts := tc.Alerts{}
+	healths, warnings, err := getOverviewHealths(tx, overviews)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	for _, warning := range warnings {
+		alerts.AddNewAlert(tc.WarnLevel, warning)
+	}
+	for i, overview := range overviews {
+		if health, ok := healths[overview.XMLID]; ok {
+			overviews[i].Health = &health
+		}
+	}
+
+	if inf.Config.TrafficVaultEnabled && canReadSSLKeyExpirations(inf) {
+		expirations, err := inf.Vault.GetExpirationInformation(tx, r.Context(), 0)
+		if err != nil {
+			log.Errorf("getting SSL keys expiration information from Traffic Vault for Delivery Services overview: %v", err)
+			alerts.AddNewAlert(tc.WarnLevel, "certificate expirations are unavailable: could not get them from Traffic Vault")
+		} else {
+			addOverviewCertificateExpirations(overviews, expirations)
+		}
+	}
+
+	if len(alerts.Alerts) == 0 {
+		api.WriteResp(w, r, overviews)
+		return
+	}
+	api.WriteAlertsObj(w, r, http.StatusOK, alerts, overviews)
+}
+
+// canReadSSLKeyExpirations returns whether or not the user of the given
+// request may see the expiration information of SSL keys, as with the
+// /sslkey_expirations endpoint.
+func canReadSSLKeyExpirations(inf *api.APIInfo) bool {
+	if inf.Config.RoleBasedPermissions {
+		return inf.User.Can("SSL-KEY-EXPIRATION:READ")
+	}
+	return inf.User.PrivLevel >= auth.PrivLevelAdmin
+}
+
+// readOverviews reads the Delivery Service overviews selected by the given
+// query, which must select the columns of selectOverviewsQuery.
+func readOverviews(tx *sql.Tx, query string, args ...interface{}) ([]tc.DeliveryServiceOverview, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, errors.New("querying Delivery Service overviews: " + err.Error())
+	}
+	defer log.Close(rows, "closing Delivery Service overview rows")
+
+	overviews := []tc.DeliveryServiceOverview{}
+	for rows.Next() {
+		var o tc.DeliveryServiceOverview
+		if err := rows.Scan(&o.ID, &o.XMLID, &o.DisplayName, &o.Active, &o.CDNName, &o.Type, &o.Tenant, &o.Topology, &o.Protocol, &o.PendingRequests); err != nil {
+			return nil, errors.New("scanning Delivery Service overviews: " + err.Error())
+		}
+		overviews = append(overviews, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over Delivery Service overviews: " + err.Error())
+	}
+	return overviews, nil
+}
+
+// getOverviewHealths returns the health of the given Delivery Services, keyed
+// by XMLID, along with any warnings and an error. Each CDN's Traffic Monitors
+// are asked for its cache server states and Snapshot once, concurrently,
+// rather than once per Delivery Service. Delivery Services whose health
+// can't be determined are left out of the returned health.
+func getOverviewHealths(tx *sql.Tx, overviews []tc.DeliveryServiceOverview) (map[string]tc.DeliveryServiceOverviewHealth, []string, error) {
+	monitorURLs, err := monitorhlp.GetURLs(tx)
+	if err != nil {
+		return nil, nil, errors.New("getting monitors: " + err.Error())
+	}
+	client, err := monitorhlp.GetClient(tx)
+	if err != nil {
+		return nil, nil, errors.New("getting monitor client: " + err.Error())
+	}
+
+	cdnDSes := map[tc.CDNName][]tc.DeliveryServiceName{}
+	for _, overview := range overviews {
+		cdn := tc.CDNName(overview.CDNName)
+		cdnDSes[cdn] = append(cdnDSes[cdn], tc.DeliveryServiceName(overview.XMLID))
+	}
+
+	type cdnResult struct {
+		healths map[string]tc.DeliveryServiceOverviewHealth
+		warning string
+	}
+	results := make(map[tc.CDNName]cdnResult, len(cdnDSes))
+	resultsM := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for cdn, dses := range cdnDSes {
+		monitors, ok := monitorURLs[cdn]
+		if !ok {
+			continue // CDNs without online monitors have no health, as with /deliveryservices/{{ID}}/health
+		}
+		wg.Add(1)
+		go func(cdn tc.CDNName, dses []tc.DeliveryServiceName, monitors []string) {
+			defer wg.Done()
+			result := cdnResult{}
+			crStates, crConfig, err := getCDNMonitorStates(client, monitors)
+			if err != nil {
+				log.Errorf("getting monitor health of CDN '%s' for Delivery Services overview: %v", cdn, err)
+				result.warning = "health of Delivery Services in CDN '" + string(cdn) + "' is unavailable: could not get it from its Traffic Monitors"
+			} else {
+				result.healths = makeOverviewHealths(dses, crStates, crConfig)
+			}
+			resultsM.Lock()
+			results[cdn] = result
+			resultsM.Unlock()
+		}(cdn, dses, monitors)
+	}
+	wg.Wait()
+
+	healths := map[string]tc.DeliveryServiceOverviewHealth{}
+	warnings := []string{}
+	for _, result := range results {
+		for ds, health := range result.healths {
+			healths[ds] = health
+		}
+		if result.warning != "" {
+			warnings = append(warnings, result.warning)
+		}
+	}
+	sort.Strings(warnings)
+	return healths, warnings, nil
+}
+
+// getCDNMonitorStates returns the cache server states and Snapshot of a CDN
+// from the first of its given Traffic Monitors which returns both.
+func getCDNMonitorStates(client *http.Client, monitorFQDNs []string) (tc.CRStates, tc.CRConfig, error) {
+	errs := []error{}
+	for _, monitorFQDN := range monitorFQDNs {
+		crStates, err := monitorhlp.GetCRStates(monitorFQDN, client)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		crConfig, err := monitorhlp.GetCRConfig(monitorFQDN, client)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return crStates, crConfig, nil
+	}
+	return tc.CRStates{}, tc.CRConfig{}, util.JoinErrs(errs)
+}
+
+// makeOverviewHealths returns the health of the given Delivery Services of a
+// CDN with the given cache server states and Snapshot, keyed by XMLID.
+// Delivery Services which aren't in the Snapshot are left out.
+func makeOverviewHealths(dses []tc.DeliveryServiceName, crStates tc.CRStates, crConfig tc.CRConfig) map[string]tc.DeliveryServiceOverviewHealth {
+	healths := make(map[string]tc.DeliveryServiceOverviewHealth, len(dses))
+	for _, ds := range dses {
+		_, online, offline, err := addHealth(ds, map[tc.CacheGroupName]tc.HealthDataCacheGroup{}, 0, 0, crStates, crConfig)
+		if err != nil {
+			continue
+		}
+		healths[string(ds)] = tc.DeliveryServiceOverviewHealth{TotalOnline: online, TotalOffline: offline}
+	}
+	return healths
+}
+
+// addOverviewCertificateExpirations sets the certificate expirations of the
+// given Delivery Service overviews from the given SSL key expiration
+// information.
+func addOverviewCertificateExpirations(overviews []tc.DeliveryServiceOverview, expirations []tc.SSLKeyExpirationInformation) {
+	dsExpirations := make(map[string]time.Time, len(expirations))
+	for _, expiration := range expirations {
+		dsExpirations[expiration.DeliveryService] = expiration.Expiration
+	}
+	for i, overview := range overviews {
+		if expiration, ok := dsExpirations[overview.XMLID]; ok {
+			overviews[i].CertificateExpiration = &expiration
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package deliveryservice
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/jmoiron/sqlx"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestReadOverviews(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	rows := sqlmock.NewRows([]string{"id", "xml_id", "display_name", "active", "cdn", "type", "tenant", "topology", "protocol", "pending_requests"}).
+		AddRow(1, "ds1", "DS 1", true, "cdn1", "HTTP", "root", "mytopology", 2, 3).
+		AddRow(2, "ds2", "DS 2", false, "cdn1", "DNS", "root", nil, nil, 0)
+	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	tx := db.MustBegin().Tx
+	overviews, err := readOverviews(tx, selectOverviewsQuery)
+	if err != nil {
+		t.Fatalf("unexpected error reading overviews: %v", err)
+	}
+	tx.Commit()
+
+	if len(overviews) != 2 {
+		t.Fatalf("expected overviews of 2 delivery services, actual: %d", len(overviews))
+	}
+	ds1 := overviews[0]
+	if ds1.XMLID != "ds1" || !ds1.Active || ds1.Topology == nil || *ds1.Topology != "mytopology" || ds1.PendingRequests != 3 {
+		t.Errorf("expected active delivery service 'ds1' with topology 'mytopology' and 3 pending requests, actual: %+v", ds1)
+	}
+	ds2 := overviews[1]
+	if ds2.Topology != nil || ds2.Protocol != nil || ds2.Health != nil || ds2.CertificateExpiration != nil {
+		t.Errorf("expected delivery service 'ds2' without topology, protocol, health or certificate expiration, actual: %+v", ds2)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestMakeOverviewHealths(t *testing.T) {
+	crStates := tc.CRStates{
+		Caches: map[tc.CacheName]tc.IsAvailable{
+			"cache1": {IsAvailable: true},
+			"cache2": {IsAvailable: false},
+		},
+	}
+	status := tc.CRConfigServerStatus("REPORTED")
+	crConfig := tc.CRConfig{
+		ContentServers: map[string]tc.CRConfigTrafficOpsServer{
+			"cache1": {
+				CacheGroup:       util.StrPtr("cg1"),
+				ServerStatus:     &status,
+				ServerType:       util.StrPtr("EDGE"),
+				DeliveryServices: map[string][]string{"ds1": {"edge.ds1.test.net"}},
+			},
+			"cache2": {
+				CacheGroup:       util.StrPtr("cg1"),
+				ServerStatus:     &status,
+				ServerType:       util.StrPtr("EDGE"),
+				DeliveryServices: map[string][]string{"ds1": {"edge.ds1.test.net"}},
+			},
+		},
+		DeliveryServices: map[string]tc.CRConfigDeliveryService{
+			"ds1": {},
+		},
+	}
+
+	healths := makeOverviewHealths([]tc.DeliveryServiceName{"ds1", "not-snapshotted"}, crStates, crConfig)
+	if len(healths) != 1 {
+		t.Fatalf("expected health of only the delivery service in the snapshot, actual: %+v", healths)
+	}
+	if health := healths["ds1"]; health.TotalOnline != 1 || health.TotalOffline != 1 {
+		t.Errorf("expected 1 online and 1 offline cache server of 'ds1', actual: %+v", health)
+	}
+}
+
+func TestAddOverviewCertificateExpirations(t *testing.T) {
+	expiration := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	overviews := []tc.DeliveryServiceOverview{{XMLID: "ds1"}, {XMLID: "ds2"}}
+	addOverviewCertificateExpirations(overviews, []tc.SSLKeyExpirationInformation{{DeliveryService: "ds1", Expiration: expiration}})
+
+	if overviews[0].CertificateExpiration == nil || !overviews[0].CertificateExpiration.Equal(expiration) {
+		t.Errorf("expected certificate expiration %v of 'ds1', actual: %v", expiration, overviews[0].CertificateExpiration)
+	}
+	if overviews[1].CertificateExpiration != nil {
+		t.Errorf("expected no certificate expiration of 'ds2', actual: %v", *overviews[1].CertificateExpiration)
+	}
+}
@@ -0,0 +1,265 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+const selectPathRulesQuery = `
+SELECT ds.id, ds.xml_id, MAX(r.last_updated) AS last_updated,
+	json_agg(json_build_object(
+		'pathPrefix', r.path_prefix,
+		'originUrl', r.origin_url,
+		'noCache', r.no_cache,
+		'maxTTL', r.max_ttl
+	) ORDER BY r.path_prefix) AS rules
+FROM deliveryservice_path_rule AS r
+JOIN deliveryservice AS ds ON ds.id = r.deliveryservice
+`
+
+const groupPathRulesQuery = `
+GROUP BY ds.id, ds.xml_id
+ORDER BY ds.xml_id
+`
+
+const selectDSMultiSiteOriginQuery = `
+SELECT COALESCE(multi_site_origin, FALSE)
+FROM deliveryservice
+WHERE id = $1
+`
+
+const deletePathRulesQuery = `
+DELETE FROM deliveryservice_path_rule
+WHERE deliveryservice = $1
+`
+
+const insertPathRuleQuery = `
+INSERT INTO deliveryservice_path_rule (deliveryservice, path_prefix, origin_url, no_cache, max_ttl)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+// GetPathRules is the handler for GET requests to
+// /deliveryservices/{{ID}}/path-rules.
+func GetPathRules(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	userErr, sysErr, errCode = tenant.CheckID(tx, inf.User, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	rules, userErr, sysErr, errCode := getDSPathRules(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	api.WriteResp(w, r, rules)
+}
+
+// GetCDNPathRules is the handler for GET requests to
+// /deliveryservices/path-rules, which returns the path rules of every
+// Delivery Service with any, optionally only those in the CDN named by the
+// 'cdn' query parameter. It exists so that cache configuration generation
+// doesn't need a request per Delivery Service.
+func GetCDNPathRules(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	tenants, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting accessible tenants for user: %w", err))
+		return
+	}
+
+	query := selectPathRulesQuery + `WHERE ds.tenant_id = ANY($1)`
+	args := []interface{}{pq.Array(tenants)}
+	if cdn, ok := inf.Params["cdn"]; ok {
+		if _, ok, err := dbhelpers.GetCDNIDFromName(tx, tc.CDNName(cdn)); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("checking CDN existence: %w", err))
+			return
+		} else if !ok {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no such CDN: %s", cdn), nil)
+			return
+		}
+		query += ` AND ds.cdn_id = (SELECT id FROM cdn WHERE name = $2)`
+		args = append(args, cdn)
+	}
+
+	all, err := readPathRules(tx, query+groupPathRulesQuery, args...)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, all)
+}
+
+// UpdatePathRules is the handler for PUT requests to
+// /deliveryservices/{{ID}}/path-rules, which replaces all of a Delivery
+// Service's path rules.
+func UpdatePathRules(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	dsName, userErr, sysErr, errCode := checkDSModifiable(tx, inf, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	dsType, _, _, err := dbhelpers.GetDeliveryServiceTypeAndCDNName(dsID, tx)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting Delivery Service type: %w", err))
+		return
+	}
+	if !dsType.IsHTTP() && !dsType.IsDNS() {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("path rules are not allowed for '%s' Delivery Services", dsType), nil)
+		return
+	}
+
+	var req tc.DeliveryServicePathRulesRequest
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	var mso bool
+	if err := tx.QueryRow(selectDSMultiSiteOriginQuery, dsID).Scan(&mso); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting Delivery Service multi-site origin: "+err.Error()))
+		return
+	}
+	for _, rule := range req.Rules {
+		if mso && rule.OriginURL != nil {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("path rule '%s' cannot have an origin URL, because multi-site origin Delivery Services can only use their own origin", rule.PathPrefix), nil)
+			return
+		}
+		if dsType == tc.DSTypeHTTPNoCache && (rule.NoCache || rule.MaxTTL != nil) {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("path rule '%s' cannot set caching, because '%s' Delivery Services never cache", rule.PathPrefix, dsType), nil)
+			return
+		}
+	}
+
+	if _, err := tx.Exec(deletePathRulesQuery, dsID); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deleting Delivery Service path rules: "+err.Error()))
+		return
+	}
+	for _, rule := range req.Rules {
+		if _, err := tx.Exec(insertPathRuleQuery, dsID, rule.PathPrefix, rule.OriginURL, rule.NoCache, rule.MaxTTL); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("inserting Delivery Service path rule '%s': %w", rule.PathPrefix, err))
+			return
+		}
+	}
+
+	rules, userErr, sysErr, errCode := getDSPathRules(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	msg := fmt.Sprintf("Delivery Service '%s' path rules updated", dsName)
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+string(dsName)+", ID: "+strconv.Itoa(dsID)+", ACTION: Updated path rules", inf.User, tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, msg+"; queue updates on the CDN to apply them", rules)
+}
+
+// getDSPathRules returns the path rules of the Delivery Service with the
+// given ID, along with a user error, system error, and status code.
+func getDSPathRules(tx *sql.Tx, dsID int) (tc.DeliveryServicePathRules, error, error, int) {
+	dsName, _, ok, err := dbhelpers.GetDSNameAndCDNFromID(tx, dsID)
+	if err != nil {
+		return tc.DeliveryServicePathRules{}, nil, errors.New("getting Delivery Service name: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return tc.DeliveryServicePathRules{}, fmt.Errorf("no Delivery Service exists by ID '%d'", dsID), nil, http.StatusNotFound
+	}
+
+	all, err := readPathRules(tx, selectPathRulesQuery+`WHERE ds.id = $1`+groupPathRulesQuery, dsID)
+	if err != nil {
+		return tc.DeliveryServicePathRules{}, nil, err, http.StatusInternalServerError
+	}
+	if len(all) == 0 {
+		return tc.DeliveryServicePathRules{
+			DeliveryServiceID: dsID,
+			XMLID:             string(dsName),
+			Rules:             []tc.DeliveryServicePathRule{},
+		}, nil, nil, http.StatusOK
+	}
+	return all[0], nil, nil, http.StatusOK
+}
+
+// readPathRules reads the path rules selected by the given query, which must
+// select the columns of selectPathRulesQuery.
+func readPathRules(tx *sql.Tx, query string, args ...interface{}) ([]tc.DeliveryServicePathRules, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, errors.New("querying Delivery Service path rules: " + err.Error())
+	}
+	defer log.Close(rows, "closing Delivery Service path rules rows")
+
+	all := []tc.DeliveryServicePathRules{}
+	for rows.Next() {
+		var dsRules tc.DeliveryServicePathRules
+		var lastUpdated time.Time
+		var rules []byte
+		if err := rows.Scan(&dsRules.DeliveryServiceID, &dsRules.XMLID, &lastUpdated, &rules); err != nil {
+			return nil, errors.New("scanning Delivery Service path rules: " + err.Error())
+		}
+		if err := json.Unmarshal(rules, &dsRules.Rules); err != nil {
+			return nil, fmt.Errorf("decoding path rules of Delivery Service '%s': %w", dsRules.XMLID, err)
+		}
+		dsRules.LastUpdated = &lastUpdated
+		all = append(all, dsRules)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over Delivery Service path rules: " + err.Error())
+	}
+	return all, nil
+}
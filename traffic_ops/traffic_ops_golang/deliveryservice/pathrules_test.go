@@ -0,0 +1,74 @@
+package deliveryservice
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestReadPathRules(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	lastUpdated := time.Date(2022, 6, 28, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	rows := sqlmock.NewRows([]string{"id", "xml_id", "last_updated", "rules"}).
+		AddRow(1, "ds1", lastUpdated, []byte(`[{"pathPrefix":"/live/","originUrl":"http://live.origin.example","noCache":false,"maxTTL":2},{"pathPrefix":"/vod/","originUrl":null,"noCache":true,"maxTTL":null}]`))
+	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	tx := db.MustBegin().Tx
+	all, err := readPathRules(tx, selectPathRulesQuery+groupPathRulesQuery)
+	if err != nil {
+		t.Fatalf("unexpected error reading path rules: %v", err)
+	}
+	tx.Commit()
+
+	if len(all) != 1 {
+		t.Fatalf("expected path rules of 1 delivery service, actual: %d", len(all))
+	}
+	ds1 := all[0]
+	if ds1.XMLID != "ds1" || len(ds1.Rules) != 2 {
+		t.Fatalf("expected 2 path rules of delivery service 'ds1', actual: %+v", ds1)
+	}
+	live := ds1.Rules[0]
+	if live.PathPrefix != "/live/" || live.OriginURL == nil || *live.OriginURL != "http://live.origin.example" || live.MaxTTL == nil || *live.MaxTTL != 2 || live.NoCache {
+		t.Errorf("expected rule '/live/' to route to 'http://live.origin.example' with a max TTL of 2, actual: %+v", live)
+	}
+	vod := ds1.Rules[1]
+	if vod.PathPrefix != "/vod/" || vod.OriginURL != nil || vod.MaxTTL != nil || !vod.NoCache {
+		t.Errorf("expected rule '/vod/' to not cache, actual: %+v", vod)
+	}
+	if ds1.LastUpdated == nil || !ds1.LastUpdated.Equal(lastUpdated) {
+		t.Errorf("expected lastUpdated %v, actual: %v", lastUpdated, ds1.LastUpdated)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,187 @@
+package request
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
+)
+
+const updateCertificateStatusQuery = `
+UPDATE deliveryservice_request
+SET cert_status = $1, cert_async_status_id = $2
+WHERE id = $3
+`
+
+// finishCertificateQuery records the final status of the certificate obtained
+// by the given job and, if it was issued, completes the DSR - unless it's no
+// longer pending or a later job has since been started for it.
+const finishCertificateQuery = `
+UPDATE deliveryservice_request
+SET
+	cert_status = $1,
+	status = CASE WHEN $1 = 'issued' AND status = 'pending' THEN 'complete' ELSE status END
+WHERE id = $2 AND cert_async_status_id = $3
+RETURNING status
+`
+
+// wantsCertificate returns whether or not Traffic Ops is configured to obtain a
+// certificate for the Delivery Service of the given DSR when it's completed,
+// which it does for HTTPS Delivery Services that are being created or
+// updated.
+func wantsCertificate(cfg *config.Config, dsr tc.DeliveryServiceRequestV4) bool {
+	if cfg == nil || cfg.DSRCertificates == nil || !cfg.TrafficVaultEnabled {
+		return false
+	}
+	if dsr.ChangeType == tc.DSRChangeTypeDelete || dsr.Requested == nil {
+		return false
+	}
+	return dsr.Requested.Protocol != nil && *dsr.Requested.Protocol != tc.DSProtocolHTTP
+}
+
+// newCertificateRequest builds the request for a certificate for the given
+// Delivery Service from the given ACME provider. The certificate is for the
+// host of its first HTTPS example URL, and is the next version of its keys.
+func newCertificateRequest(ds tc.DeliveryServiceV4, provider string) (tc.DeliveryServiceAcmeSSLKeysReq, error) {
+	if ds.XMLID == nil || ds.CDNName == nil {
+		return tc.DeliveryServiceAcmeSSLKeysReq{}, errors.New("delivery service has no XMLID or CDN name")
+	}
+	hostName := ""
+	for _, exampleURL := range ds.ExampleURLs {
+		u, err := url.Parse(exampleURL)
+		if err == nil && u.Scheme == "https" && u.Host != "" {
+			hostName = u.Host
+			break
+		}
+	}
+	if hostName == "" {
+		return tc.DeliveryServiceAcmeSSLKeysReq{}, fmt.Errorf("delivery service '%s' has no HTTPS example URL", *ds.XMLID)
+	}
+
+	version := util.JSONIntStr(1)
+	if ds.SSLKeyVersion != nil {
+		version = util.JSONIntStr(*ds.SSLKeyVersion + 1)
+	}
+	return tc.DeliveryServiceAcmeSSLKeysReq{
+		DeliveryServiceSSLKeysReq: tc.DeliveryServiceSSLKeysReq{
+			HostName:        util.StrPtr(hostName),
+			DeliveryService: util.StrPtr(*ds.XMLID),
+			CDN:             util.StrPtr(*ds.CDNName),
+			Version:         &version,
+			AuthType:        util.StrPtr(provider),
+			Key:             util.StrPtr(*ds.XMLID),
+		},
+	}, nil
+}
+
+// certificateRequest returns the request for the certificate Traffic Ops
+// obtains for the Delivery Service of the given DSR before completing it, or
+// nil if it obtains none - because it isn't configured to, or the Delivery
+// Service doesn't exist or already has keys.
+func certificateRequest(inf *api.APIInfo, dsr tc.DeliveryServiceRequestV4, ctx context.Context) (*tc.DeliveryServiceAcmeSSLKeysReq, int, error, error) {
+	if !wantsCertificate(inf.Config, dsr) {
+		return nil, http.StatusOK, nil, nil
+	}
+
+	query := deliveryservice.SelectDeliveryServicesQuery + " WHERE ds.xml_id = :xmlid"
+	dses, userErr, sysErr, errCode := deliveryservice.GetDeliveryServices(query, map[string]interface{}{"xmlid": dsr.XMLID}, inf.Tx)
+	if userErr != nil || sysErr != nil {
+		return nil, errCode, userErr, sysErr
+	}
+	if len(dses) != 1 {
+		return nil, http.StatusOK, nil, nil
+	}
+	ds := dses[0]
+
+	if _, ok, err := inf.Vault.GetDeliveryServiceSSLKeys(dsr.XMLID, "", inf.Tx.Tx, ctx); err != nil {
+		return nil, http.StatusInternalServerError, nil, fmt.Errorf("getting SSL keys of delivery service '%s': %w", dsr.XMLID, err)
+	} else if ok {
+		return nil, http.StatusOK, nil, nil
+	}
+
+	req, err := newCertificateRequest(ds, inf.Config.DSRCertificates.AcmeProvider)
+	if err != nil {
+		return nil, http.StatusInternalServerError, nil, err
+	}
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyCDN(inf.Tx.Tx, *req.CDN, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		return nil, errCode, userErr, sysErr
+	}
+	return &req, http.StatusOK, nil, nil
+}
+
+// obtainCertificate obtains the requested certificate using the job with the
+// given async status ID, then completes the identified DSR if it was issued.
+// If it wasn't, the DSR remains pending; completing it again tries again.
+func obtainCertificate(cfg *config.Config, req tc.DeliveryServiceAcmeSSLKeysReq, ctx context.Context, cancelTx context.CancelFunc, user *auth.CurrentUser, dsrID int, asyncStatusID int, tv trafficvault.TrafficVault) {
+	certStatus := tc.DSRCertificateStatusIssued
+	if err := deliveryservice.GetAcmeCertificates(cfg, req, ctx, cancelTx, true, user, asyncStatusID, tv); err != nil {
+		log.Errorf("obtaining certificate for Delivery Service Request #%d: %v", dsrID, err)
+		certStatus = tc.DSRCertificateStatusFailed
+	}
+
+	db, err := api.GetDB(ctx)
+	if err != nil {
+		log.Errorf("getting db to finish Delivery Service Request #%d: %v", dsrID, err)
+		return
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		log.Errorf("beginning transaction to finish Delivery Service Request #%d: %v", dsrID, err)
+		return
+	}
+
+	var status tc.RequestStatus
+	if err := tx.QueryRow(finishCertificateQuery, string(certStatus), dsrID, asyncStatusID).Scan(&status); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			log.Infof("certificate job %d is no longer that of Delivery Service Request #%d", asyncStatusID, dsrID)
+			return
+		}
+		log.Errorf("finishing Delivery Service Request #%d: %v", dsrID, err)
+		return
+	}
+
+	var message string
+	if status == tc.RequestStatusComplete {
+		message = fmt.Sprintf("Changed status of '%s' Delivery Service Request from '%s' to '%s' after obtaining a certificate for it", *req.DeliveryService, tc.RequestStatusPending, tc.RequestStatusComplete)
+	} else {
+		message = fmt.Sprintf("Failed to obtain a certificate for '%s' Delivery Service Request; it remains '%s'", *req.DeliveryService, status)
+	}
+	message = fmt.Sprintf("Delivery Service Request: %d, ID: %d, ACTION: %s deliveryservice_request, keys: {id:%d }", dsrID, dsrID, message, dsrID)
+	api.CreateChangeLogRawTx(api.ApiChange, message, user, tx)
+	if err := tx.Commit(); err != nil {
+		log.Errorf("committing Delivery Service Request #%d: %v", dsrID, err)
+	}
+}
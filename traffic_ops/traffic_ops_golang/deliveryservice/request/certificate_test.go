@@ -0,0 +1,93 @@
+package request
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+func TestWantsCertificate(t *testing.T) {
+	cfg := &config.Config{
+		TrafficVaultEnabled: true,
+		DSRCertificates:     &config.ConfigDSRCertificates{AcmeProvider: tc.LetsEncryptAuthType},
+	}
+	https := tc.DeliveryServiceRequestV4{
+		ChangeType: tc.DSRChangeTypeCreate,
+		Requested:  &tc.DeliveryServiceV4{},
+	}
+	https.Requested.Protocol = util.IntPtr(tc.DSProtocolHTTPToHTTPS)
+	if !wantsCertificate(cfg, https) {
+		t.Error("Expected: a certificate for an HTTPS Delivery Service, actual: none")
+	}
+
+	http := https
+	http.Requested = &tc.DeliveryServiceV4{}
+	http.Requested.Protocol = util.IntPtr(tc.DSProtocolHTTP)
+	if wantsCertificate(cfg, http) {
+		t.Error("Expected: no certificate for an HTTP Delivery Service, actual: a certificate")
+	}
+
+	del := https
+	del.ChangeType = tc.DSRChangeTypeDelete
+	if wantsCertificate(cfg, del) {
+		t.Error("Expected: no certificate for a deleted Delivery Service, actual: a certificate")
+	}
+
+	if wantsCertificate(&config.Config{TrafficVaultEnabled: true}, https) {
+		t.Error("Expected: no certificate when dsr_certificates isn't configured, actual: a certificate")
+	}
+	if wantsCertificate(&config.Config{DSRCertificates: cfg.DSRCertificates}, https) {
+		t.Error("Expected: no certificate when Traffic Vault isn't enabled, actual: a certificate")
+	}
+}
+
+func TestNewCertificateRequest(t *testing.T) {
+	ds := tc.DeliveryServiceV4{}
+	ds.XMLID = util.StrPtr("demo1")
+	ds.CDNName = util.StrPtr("cdn1")
+	ds.SSLKeyVersion = util.IntPtr(2)
+	ds.ExampleURLs = []string{"http://cdn.demo1.example.test", "https://cdn.demo1.example.test"}
+
+	req, err := newCertificateRequest(ds, tc.LetsEncryptAuthType)
+	if err != nil {
+		t.Fatalf("Expected: no error, actual: %v", err)
+	}
+	if *req.HostName != "cdn.demo1.example.test" {
+		t.Errorf("Expected: host name 'cdn.demo1.example.test', actual: '%s'", *req.HostName)
+	}
+	if *req.DeliveryService != "demo1" || *req.Key != "demo1" || *req.CDN != "cdn1" {
+		t.Errorf("Expected: Delivery Service and key 'demo1' in CDN 'cdn1', actual: '%s' and '%s' in '%s'", *req.DeliveryService, *req.Key, *req.CDN)
+	}
+	if req.Version.ToInt64() != 3 {
+		t.Errorf("Expected: version 3, actual: %d", req.Version.ToInt64())
+	}
+	if *req.AuthType != tc.LetsEncryptAuthType {
+		t.Errorf("Expected: auth type '%s', actual: '%s'", tc.LetsEncryptAuthType, *req.AuthType)
+	}
+
+	ds.ExampleURLs = []string{"http://cdn.demo1.example.test"}
+	if _, err := newCertificateRequest(ds, tc.LetsEncryptAuthType); err == nil {
+		t.Error("Expected: an error for a Delivery Service with no HTTPS example URL, actual: nil")
+	}
+}
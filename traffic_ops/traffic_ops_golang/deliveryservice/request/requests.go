@@ -51,6 +51,8 @@ SELECT
 	s.username AS assignee,
 	r.assignee_id,
 	r.author_id,
+	r.cert_async_status_id,
+	r.cert_status,
 	r.change_type,
 	r.created_at,
 	r.id,
@@ -20,11 +20,14 @@ package request
  */
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
+	"github.com/apache/trafficcontrol/lib/go-rfc"
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
@@ -166,13 +169,33 @@ func PutStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// HTTPS Delivery Services without certificates are only completed once
+	// Traffic Ops has obtained certificates for them, if it's configured to;
+	// until then, their requests are pending.
+	status := req.Status
+	var certReq *tc.DeliveryServiceAcmeSSLKeysReq
+	if req.Status == tc.RequestStatusComplete {
+		if dsr.CertificateStatus != nil && *dsr.CertificateStatus == tc.DSRCertificateStatusIssuing {
+			api.HandleErr(w, r, tx, http.StatusConflict, errors.New("a certificate is still being obtained for this Delivery Service Request"), nil)
+			return
+		}
+		certReq, errCode, userErr, sysErr = certificateRequest(inf, dsr, r.Context())
+		if userErr != nil || sysErr != nil {
+			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+			return
+		}
+		if certReq != nil {
+			status = tc.RequestStatusPending
+		}
+	}
+
 	dsr.LastEditedBy = inf.User.UserName
 	dsr.LastEditedByID = new(int)
 	*dsr.LastEditedByID = inf.User.ID
 
 	// store the current original DS if the DSR is being closed
 	// (and isn't a "create" request)
-	if dsr.IsOpen() && req.Status != tc.RequestStatusDraft && req.Status != tc.RequestStatusSubmitted && dsr.ChangeType != tc.DSRChangeTypeCreate {
+	if dsr.IsOpen() && status != tc.RequestStatusDraft && status != tc.RequestStatusSubmitted && dsr.ChangeType != tc.DSRChangeTypeCreate {
 		if dsr.ChangeType == tc.DSRChangeTypeUpdate && dsr.Requested != nil && dsr.Requested.ID != nil {
 			errCode, userErr, sysErr = getOriginals([]int{*dsr.Requested.ID}, inf.Tx, map[int][]*tc.DeliveryServiceRequestV4{*dsr.Requested.ID: {&dsr}}, omitExtraLongDescFields)
 			if userErr != nil || sysErr != nil {
@@ -198,13 +221,13 @@ func PutStatus(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		err := tx.QueryRow(updateStatusAndOriginalQuery, dsr.Original, req.Status, dsr.LastEditedByID, dsrID).Scan(&dsr.LastUpdated)
+		err := tx.QueryRow(updateStatusAndOriginalQuery, dsr.Original, status, dsr.LastEditedByID, dsrID).Scan(&dsr.LastUpdated)
 		if err != nil {
 			sysErr = fmt.Errorf("updating original for dsr #%d: %v", dsrID, err)
 			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
 			return
 		}
-	} else if err := tx.QueryRow(updateStatusQuery, req.Status, dsr.LastEditedByID, *dsr.ID).Scan(&dsr.LastUpdated); err == nil {
+	} else if err := tx.QueryRow(updateStatusQuery, status, dsr.LastEditedByID, *dsr.ID).Scan(&dsr.LastUpdated); err == nil {
 		if dsr.IsOpen() && dsr.ChangeType != tc.DSRChangeTypeCreate {
 			query := deliveryservice.SelectDeliveryServicesQuery + " WHERE ds.xml_id = :xmlid"
 			original, userErr, sysErr, errCode := deliveryservice.GetDeliveryServices(query, map[string]interface{}{"xmlid": dsr.XMLID}, inf.Tx)
@@ -225,8 +248,26 @@ func PutStatus(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	message := fmt.Sprintf("Changed status of '%s' Delivery Service Request from '%s' to '%s'", dsr.XMLID, dsr.Status, req.Status)
-	dsr.Status = req.Status
+	message := fmt.Sprintf("Changed status of '%s' Delivery Service Request from '%s' to '%s'", dsr.XMLID, dsr.Status, status)
+	dsr.Status = status
+
+	asyncStatusID := 0
+	if certReq != nil {
+		asyncStatusID, errCode, userErr, sysErr = api.InsertAsyncStatus(tx, "ACME async job has started.")
+		if userErr != nil || sysErr != nil {
+			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+			return
+		}
+		certStatus := tc.DSRCertificateStatusIssuing
+		if _, err := tx.Exec(updateCertificateStatusQuery, string(certStatus), asyncStatusID, dsrID); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("updating certificate status of dsr #%d: %v", dsrID, err))
+			return
+		}
+		dsr.CertificateStatus = &certStatus
+		dsr.CertificateAsyncStatusID = &asyncStatusID
+		message += fmt.Sprintf(" until a certificate has been obtained for it using %s. This may take a few minutes. Status updates can be found here: %s%d", *certReq.AuthType, api.CurrentAsyncEndpoint, asyncStatusID)
+		w.Header().Add(rfc.Location, api.CurrentAsyncEndpoint+strconv.Itoa(asyncStatusID))
+	}
 
 	var resp interface{}
 	if inf.Version.Major >= 4 {
@@ -244,4 +285,9 @@ func PutStatus(w http.ResponseWriter, r *http.Request) {
 	api.WriteRespAlertObj(w, r, tc.SuccessLevel, message, resp)
 	message = fmt.Sprintf("Delivery Service Request: %d, ID: %d, ACTION: %s deliveryservice_request, keys: {id:%d }", *dsr.ID, *dsr.ID, message, *dsr.ID)
 	inf.CreateChangeLog(message)
+
+	if certReq != nil {
+		ctx, cancelTx := context.WithTimeout(r.Context(), deliveryservice.AcmeTimeout)
+		go obtainCertificate(inf.Config, *certReq, ctx, cancelTx, inf.User, dsrID, asyncStatusID, inf.Vault)
+	}
 }
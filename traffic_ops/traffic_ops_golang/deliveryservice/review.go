@@ -0,0 +1,174 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/policy"
+)
+
+const insertReviewRequestQuery = `
+INSERT INTO deliveryservice_request (
+	author_id,
+	change_type,
+	last_edited_by_id,
+	deliveryservice,
+	status
+) VALUES (
+	$1,
+	$2,
+	$1,
+	$3,
+	$4
+)
+RETURNING
+	id,
+	last_updated,
+	created_at
+`
+
+// rejectRestricted responds to requests made with API versions that don't
+// support field policies with a Forbidden error when the user's Role may not
+// modify some Delivery Service fields, returning whether it did so.
+func rejectRestricted(w http.ResponseWriter, r *http.Request, inf *api.APIInfo) bool {
+	protected, err := policy.ProtectedFields(inf.Tx.Tx, inf.User, tc.FieldPolicyObjectTypeDeliveryService)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return true
+	}
+	if len(protected) > 0 {
+		userErr := errors.New("the user's Role may not modify some Delivery Service fields; use API version 4.0 or later to change Delivery Services")
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusForbidden, userErr, nil)
+		return true
+	}
+	return false
+}
+
+// enforceFieldPolicy enforces the field policy of the user's Role on the
+// requested creation of, or update to, a Delivery Service. If the request
+// changes fields the Role may not modify, it is submitted for review as a
+// Delivery Service Request if the user's changes require review, and rejected
+// otherwise. It returns whether it handled - and responded to - the request,
+// in which case the Delivery Service must not be changed.
+func enforceFieldPolicy(w http.ResponseWriter, r *http.Request, inf *api.APIInfo, changeType tc.DSRChangeType, requested tc.DeliveryServiceV4) bool {
+	tx := inf.Tx.Tx
+	protected, err := policy.ProtectedFields(tx, inf.User, tc.FieldPolicyObjectTypeDeliveryService)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return true
+	}
+	if len(protected) == 0 {
+		return false
+	}
+
+	if err := Validate(tx, &requested); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("invalid request: "+err.Error()), nil)
+		return true
+	}
+	if authorized, err := isTenantAuthorized(inf, &requested); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("checking tenant: "+err.Error()))
+		return true
+	} else if !authorized {
+		api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("not authorized on this tenant"), nil)
+		return true
+	}
+	if requested.LongDesc1 != nil || requested.LongDesc2 != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("the longDesc1 and longDesc2 fields are no longer supported in API 4.0 onwards"), nil)
+		return true
+	}
+
+	var original *tc.DeliveryServiceV4
+	if changeType == tc.DSRChangeTypeUpdate {
+		originals, userErr, sysErr, errCode := GetDeliveryServices(SelectDeliveryServicesQuery+`WHERE ds.id = :id`, map[string]interface{}{"id": *requested.ID}, inf.Tx)
+		if userErr != nil || sysErr != nil {
+			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+			return true
+		}
+		if len(originals) != 1 {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("delivery service ID %d not found", *requested.ID), nil)
+			return true
+		}
+		original = new(tc.DeliveryServiceV4)
+		*original = originals[0].RemoveLD1AndLD2()
+	}
+	// The fields set when creating a Delivery Service are those changed from
+	// the zero value.
+	base := tc.DeliveryServiceV4{}
+	if original != nil {
+		base = *original
+	}
+	changed, err := policy.ChangedFields(protected, base, requested)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return true
+	}
+	if len(changed) == 0 {
+		return false
+	}
+
+	reviewRequired, err := policy.ReviewRequired(inf)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return true
+	}
+	if !reviewRequired {
+		api.HandleErr(w, r, tx, http.StatusForbidden, policy.ProtectedFieldsError(tc.FieldPolicyObjectTypeDeliveryService, changed), nil)
+		return true
+	}
+	msg := "Changes to " + strings.Join(changed, ", ") + " require review"
+
+	dsr := tc.DeliveryServiceRequestV4{
+		Author:       inf.User.UserName,
+		ChangeType:   changeType,
+		ID:           new(int),
+		LastEditedBy: inf.User.UserName,
+		Original:     original,
+		Requested:    &requested,
+		Status:       tc.RequestStatusSubmitted,
+	}
+	dsr.SetXMLID()
+	if exists, err := dbhelpers.DSRExistsWithXMLID(dsr.XMLID, tx); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("checking for existence of DSR with xmlid '%s': %w", dsr.XMLID, err))
+		return true
+	} else if exists {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("changes to Delivery Service '%s' require review, but an open Delivery Service Request for it already exists", dsr.XMLID), nil)
+		return true
+	}
+	// Like those created through /deliveryservice_requests, the original is
+	// only stored once the request is completed.
+	if err := tx.QueryRow(insertReviewRequestQuery, inf.User.ID, dsr.ChangeType, dsr.Requested, dsr.Status).Scan(dsr.ID, &dsr.LastUpdated, &dsr.CreatedAt); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return true
+	}
+
+	inf.CreateChangeLog(fmt.Sprintf("Created Delivery Service Request of type %s for Delivery Service '%s': %s", dsr.ChangeType, dsr.XMLID, msg))
+	alerts := tc.CreateAlerts(tc.InfoLevel, msg+"; a Delivery Service Request has been submitted for review")
+	w.Header().Set("Location", fmt.Sprintf("/api/%d.%d/deliveryservice_requests/%d", inf.Version.Major, inf.Version.Minor, *dsr.ID))
+	api.WriteAlertsObj(w, r, http.StatusAccepted, alerts, dsr)
+	return true
+}
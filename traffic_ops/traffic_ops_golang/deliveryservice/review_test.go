@@ -0,0 +1,164 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func reviewTestDeliveryService() tc.DeliveryServiceV4 {
+	ds := tc.DeliveryServiceV4{}
+	ds.Active = util.BoolPtr(true)
+	ds.CDNID = util.IntPtr(1)
+	ds.DisplayName = util.StrPtr("DS 1")
+	ds.DSCP = util.IntPtr(0)
+	ds.GeoLimit = util.IntPtr(0)
+	ds.GeoProvider = util.IntPtr(0)
+	ds.LogsEnabled = util.BoolPtr(false)
+	ds.RegionalGeoBlocking = util.BoolPtr(false)
+	ds.TenantID = util.IntPtr(1)
+	ds.TypeID = util.IntPtr(8)
+	ds.XMLID = util.StrPtr("ds1")
+	return ds
+}
+
+// expectReviewTestValidation sets up the queries made to validate the
+// Delivery Service returned by reviewTestDeliveryService, and to check the
+// user's Tenant's access to it.
+func expectReviewTestValidation(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery("SELECT name, use_in_table from type").WithArgs(8).WillReturnRows(sqlmock.NewRows([]string{"name", "use_in_table"}).AddRow("ANY_MAP", "deliveryservice"))
+	mock.ExpectQuery("SELECT tenant_id FROM deliveryservice").WithArgs("ds1").WillReturnRows(sqlmock.NewRows([]string{"tenant_id"}))
+	mock.ExpectQuery("WITH RECURSIVE").WithArgs(1, 1).WillReturnRows(sqlmock.NewRows([]string{"id", "active"}).AddRow(1, true))
+}
+
+func TestEnforceFieldPolicy(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	newInf := func(cfg *config.ConfigDeliveryServiceReview) *api.APIInfo {
+		mock.ExpectBegin()
+		return &api.APIInfo{
+			Config:  &config.Config{DeliveryServiceReview: cfg},
+			Tx:      db.MustBegin(),
+			User:    &auth.CurrentUser{ID: 2, Role: 3, RoleName: "operations", TenantID: 1, UserName: "op"},
+			Version: &api.Version{Major: 4, Minor: 0},
+		}
+	}
+	policyRows := func(fields string) *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"fields"}).AddRow(fields)
+	}
+
+	t.Run("allow", func(t *testing.T) {
+		inf := newInf(nil)
+		mock.ExpectQuery("field_policy").WithArgs(3, tc.FieldPolicyObjectTypeDeliveryService).WillReturnRows(policyRows("{}"))
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/api/4.0/deliveryservices", nil)
+		if enforceFieldPolicy(w, r, inf, tc.DSRChangeTypeCreate, reviewTestDeliveryService()) {
+			t.Errorf("expected a user without field policies or review not to be restricted, got response %d: %s", w.Code, w.Body.String())
+		}
+
+		inf = newInf(&config.ConfigDeliveryServiceReview{Roles: []string{"operations"}})
+		mock.ExpectQuery("field_policy").WithArgs(3, tc.FieldPolicyObjectTypeDeliveryService).WillReturnRows(policyRows("{}"))
+		if enforceFieldPolicy(w, r, inf, tc.DSRChangeTypeCreate, reviewTestDeliveryService()) {
+			t.Errorf("expected a user whose changes require review but whose Role has no field policies not to be restricted, got response %d: %s", w.Code, w.Body.String())
+		}
+
+		inf = newInf(nil)
+		mock.ExpectQuery("field_policy").WithArgs(3, tc.FieldPolicyObjectTypeDeliveryService).WillReturnRows(policyRows("{orgServerFqdn}"))
+		expectReviewTestValidation(mock)
+		if enforceFieldPolicy(w, r, inf, tc.DSRChangeTypeCreate, reviewTestDeliveryService()) {
+			t.Errorf("expected a Delivery Service that doesn't set protected fields to be allowed, got response %d: %s", w.Code, w.Body.String())
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("expectations were not met: %v", err)
+		}
+	})
+
+	t.Run("reject", func(t *testing.T) {
+		inf := newInf(nil)
+		mock.ExpectQuery("field_policy").WithArgs(3, tc.FieldPolicyObjectTypeDeliveryService).WillReturnRows(policyRows("{xmlId}"))
+		expectReviewTestValidation(mock)
+		mock.ExpectRollback()
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/api/4.0/deliveryservices", nil)
+		if !enforceFieldPolicy(w, r, inf, tc.DSRChangeTypeCreate, reviewTestDeliveryService()) {
+			t.Fatal("expected a change to a protected field to be handled")
+		}
+		var alerts tc.Alerts
+		if err := json.Unmarshal(w.Body.Bytes(), &alerts); err != nil {
+			t.Fatalf("decoding response: %v", err)
+		}
+		if len(alerts.Alerts) != 1 || alerts.Alerts[0].Code != tc.AlertCodeForStatus(http.StatusForbidden) {
+			t.Errorf("expected a change to a protected field to be forbidden, got %s", w.Body.String())
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("expectations were not met: %v", err)
+		}
+	})
+
+	t.Run("review", func(t *testing.T) {
+		inf := newInf(&config.ConfigDeliveryServiceReview{Roles: []string{"operations"}})
+		mock.ExpectQuery("field_policy").WithArgs(3, tc.FieldPolicyObjectTypeDeliveryService).WillReturnRows(policyRows("{xmlId}"))
+		expectReviewTestValidation(mock)
+		mock.ExpectQuery("SELECT EXISTS").WithArgs("ds1").WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+		mock.ExpectQuery("INSERT INTO deliveryservice_request").WithArgs(2, tc.DSRChangeTypeCreate, sqlmock.AnyArg(), tc.RequestStatusSubmitted).WillReturnRows(sqlmock.NewRows([]string{"id", "last_updated", "created_at"}).AddRow(5, time.Now(), time.Now()))
+		mock.ExpectExec("INSERT INTO log").WillReturnResult(sqlmock.NewResult(1, 1))
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/api/4.0/deliveryservices", nil)
+		if !enforceFieldPolicy(w, r, inf, tc.DSRChangeTypeCreate, reviewTestDeliveryService()) {
+			t.Fatal("expected a change to a protected field to be handled")
+		}
+		if w.Code != http.StatusAccepted {
+			t.Fatalf("expected a change requiring review to be accepted with %d, got %d: %s", http.StatusAccepted, w.Code, w.Body.String())
+		}
+		if location := w.Header().Get("Location"); location != "/api/4.0/deliveryservice_requests/5" {
+			t.Errorf("expected the Location of the submitted Delivery Service Request, got '%s'", location)
+		}
+		var resp struct {
+			Response tc.DeliveryServiceRequestV4 `json:"response"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("decoding response: %v", err)
+		}
+		if resp.Response.ChangeType != tc.DSRChangeTypeCreate || resp.Response.Requested == nil || resp.Response.Requested.XMLID == nil || *resp.Response.Requested.XMLID != "ds1" {
+			t.Errorf("expected a Delivery Service Request to create 'ds1', got %+v", resp.Response)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("expectations were not met: %v", err)
+		}
+	})
+}
@@ -0,0 +1,248 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+const selectRewriteRulesQuery = `
+SELECT ds.id, ds.xml_id, r."match", r."replace", r.flags, r.last_updated
+FROM deliveryservice_rewrite_rule AS r
+JOIN deliveryservice AS ds ON ds.id = r.deliveryservice
+`
+
+const deleteRewriteRulesQuery = `
+DELETE FROM deliveryservice_rewrite_rule
+WHERE deliveryservice = $1
+`
+
+const insertRewriteRulesQuery = `
+INSERT INTO deliveryservice_rewrite_rule (deliveryservice, "position", "match", "replace", flags)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+// GetRewriteRules is the handler for GET requests to
+// /deliveryservices/{{ID}}/rewrite-rules.
+func GetRewriteRules(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	userErr, sysErr, errCode = tenant.CheckID(tx, inf.User, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	rules, userErr, sysErr, errCode := getDSRewriteRules(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	api.WriteResp(w, r, rules)
+}
+
+// GetCDNRewriteRules is the handler for GET requests to
+// /deliveryservices/rewrite-rules, which returns the rewrite rules of every
+// Delivery Service with any, optionally only those in the CDN named by the
+// 'cdn' query parameter. It exists so that cache configuration generation
+// doesn't need a request per Delivery Service.
+func GetCDNRewriteRules(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	tenants, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting accessible tenants for user: %w", err))
+		return
+	}
+
+	query := selectRewriteRulesQuery + `WHERE ds.tenant_id = ANY($1)`
+	args := []interface{}{pq.Array(tenants)}
+	if cdn, ok := inf.Params["cdn"]; ok {
+		if _, ok, err := dbhelpers.GetCDNIDFromName(tx, tc.CDNName(cdn)); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("checking CDN existence: %w", err))
+			return
+		} else if !ok {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no such CDN: %s", cdn), nil)
+			return
+		}
+		query += ` AND ds.cdn_id = (SELECT id FROM cdn WHERE name = $2)`
+		args = append(args, cdn)
+	}
+
+	rules, err := readRewriteRules(tx, query+` ORDER BY ds.xml_id, r."position"`, args...)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, rules)
+}
+
+// UpdateRewriteRules is the handler for PUT requests to
+// /deliveryservices/{{ID}}/rewrite-rules, which replaces all of a Delivery
+// Service's rewrite rules.
+func UpdateRewriteRules(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	userErr, sysErr, errCode = tenant.CheckID(tx, inf.User, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	dsName, cdn, ok, err := dbhelpers.GetDSNameAndCDNFromID(tx, dsID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting Delivery Service name and CDN: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no Delivery Service exists by ID '%d'", dsID), nil)
+		return
+	}
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyCDN(tx, string(cdn), inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var req tc.DeliveryServiceRewriteRulesRequest
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	if _, err := tx.Exec(deleteRewriteRulesQuery, dsID); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deleting Delivery Service rewrite rules: "+err.Error()))
+		return
+	}
+	for i, rule := range req.Rules {
+		if rule.Flags == nil {
+			rule.Flags = []string{}
+		}
+		if _, err := tx.Exec(insertRewriteRulesQuery, dsID, i, rule.Match, rule.Replace, pq.Array(rule.Flags)); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("inserting Delivery Service rewrite rule %d: %w", i, err))
+			return
+		}
+	}
+
+	rules, userErr, sysErr, errCode := getDSRewriteRules(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	msg := fmt.Sprintf("Delivery Service '%s' rewrite rules replaced with %d rules", dsName, len(req.Rules))
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+string(dsName)+", ID: "+strconv.Itoa(dsID)+", ACTION: Replaced rewrite rules with "+strconv.Itoa(len(req.Rules))+" rules", inf.User, tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, msg+"; queue updates on the CDN to apply them", rules)
+}
+
+// getDSRewriteRules returns the rewrite rules of the Delivery Service with the
+// given ID, along with a user error, system error, and status code.
+func getDSRewriteRules(tx *sql.Tx, dsID int) (tc.DeliveryServiceRewriteRules, error, error, int) {
+	dsName, _, ok, err := dbhelpers.GetDSNameAndCDNFromID(tx, dsID)
+	if err != nil {
+		return tc.DeliveryServiceRewriteRules{}, nil, errors.New("getting Delivery Service name: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return tc.DeliveryServiceRewriteRules{}, fmt.Errorf("no Delivery Service exists by ID '%d'", dsID), nil, http.StatusNotFound
+	}
+
+	all, err := readRewriteRules(tx, selectRewriteRulesQuery+`WHERE ds.id = $1 ORDER BY r."position"`, dsID)
+	if err != nil {
+		return tc.DeliveryServiceRewriteRules{}, nil, err, http.StatusInternalServerError
+	}
+	if len(all) == 0 {
+		return tc.DeliveryServiceRewriteRules{
+			DeliveryServiceID: dsID,
+			XMLID:             string(dsName),
+			Rules:             []tc.DeliveryServiceRewriteRule{},
+		}, nil, nil, http.StatusOK
+	}
+	return all[0], nil, nil, http.StatusOK
+}
+
+// readRewriteRules reads the rows of the given query, which must select the
+// columns of selectRewriteRulesQuery ordered by Delivery Service and then by
+// position, and groups them by Delivery Service. Delivery Services with no
+// rules are not included.
+func readRewriteRules(tx *sql.Tx, query string, args ...interface{}) ([]tc.DeliveryServiceRewriteRules, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, errors.New("querying Delivery Service rewrite rules: " + err.Error())
+	}
+	defer log.Close(rows, "closing Delivery Service rewrite rules rows")
+
+	all := []tc.DeliveryServiceRewriteRules{}
+	for rows.Next() {
+		var dsID int
+		var xmlID string
+		var rule tc.DeliveryServiceRewriteRule
+		var lastUpdated time.Time
+		if err := rows.Scan(&dsID, &xmlID, &rule.Match, &rule.Replace, pq.Array(&rule.Flags), &lastUpdated); err != nil {
+			return nil, errors.New("scanning Delivery Service rewrite rules: " + err.Error())
+		}
+		if rule.Flags == nil {
+			rule.Flags = []string{}
+		}
+		if len(all) == 0 || all[len(all)-1].DeliveryServiceID != dsID {
+			all = append(all, tc.DeliveryServiceRewriteRules{DeliveryServiceID: dsID, XMLID: xmlID})
+		}
+		rules := &all[len(all)-1]
+		rules.Rules = append(rules.Rules, rule)
+		if rules.LastUpdated == nil || lastUpdated.After(*rules.LastUpdated) {
+			lu := lastUpdated
+			rules.LastUpdated = &lu
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over Delivery Service rewrite rules: " + err.Error())
+	}
+	return all, nil
+}
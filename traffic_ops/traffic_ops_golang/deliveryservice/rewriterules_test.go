@@ -0,0 +1,74 @@
+package deliveryservice
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestReadRewriteRules(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	earlier := time.Date(2022, 5, 14, 12, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	mock.ExpectBegin()
+	rows := sqlmock.NewRows([]string{"id", "xml_id", "match", "replace", "flags", "last_updated"}).
+		AddRow(1, "ds1", "^/a/", "/b/", "{internal}", earlier).
+		AddRow(1, "ds1", "^/c/(.*)", "https://example.net/$1", "{redirect,last}", later).
+		AddRow(2, "ds2", "^/d/", "/e/", "{internal}", earlier)
+	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	tx := db.MustBegin().Tx
+	all, err := readRewriteRules(tx, selectRewriteRulesQuery)
+	if err != nil {
+		t.Fatalf("unexpected error reading rewrite rules: %v", err)
+	}
+	tx.Commit()
+
+	if len(all) != 2 {
+		t.Fatalf("expected rules of 2 delivery services, actual: %d", len(all))
+	}
+	if all[0].DeliveryServiceID != 1 || all[0].XMLID != "ds1" || len(all[0].Rules) != 2 {
+		t.Errorf("expected 2 rules of delivery service 'ds1', actual: %+v", all[0])
+	}
+	if all[0].LastUpdated == nil || !all[0].LastUpdated.Equal(later) {
+		t.Errorf("expected lastUpdated of 'ds1' to be its latest rule's, %v, actual: %v", later, all[0].LastUpdated)
+	}
+	if flags := all[0].Rules[1].Flags; len(flags) != 2 || flags[0] != "redirect" || flags[1] != "last" {
+		t.Errorf("expected flags [redirect last], actual: %v", flags)
+	}
+	if all[1].DeliveryServiceID != 2 || len(all[1].Rules) != 1 || all[1].Rules[0].Replace != "/e/" {
+		t.Errorf("expected 1 rule of delivery service 'ds2', actual: %+v", all[1])
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
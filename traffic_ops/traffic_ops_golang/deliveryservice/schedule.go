@@ -0,0 +1,188 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+)
+
+const getScheduleQuery = `
+SELECT active_at, inactive_at
+FROM deliveryservice
+WHERE id = $1
+`
+
+// GetDSSchedule retrieves the times at which the Delivery Service identified
+// by dsID is scheduled to become active and inactive, either or both of which
+// may be nil. This will panic if handed a nil transaction.
+func GetDSSchedule(dsID int, tx *sql.Tx) (*time.Time, *time.Time, error) {
+	var activeAt, inactiveAt *time.Time
+	if err := tx.QueryRow(getScheduleQuery, dsID).Scan(&activeAt, &inactiveAt); err != nil {
+		return nil, nil, fmt.Errorf("querying: %w", err)
+	}
+	return activeAt, inactiveAt, nil
+}
+
+// applySchedulesQuery activates and deactivates every Delivery Service whose
+// scheduled time has passed, clearing the times which have passed. Since an
+// inactiveAt is always after an activeAt, a Delivery Service for which both
+// have passed ends up inactive.
+const applySchedulesQuery = `
+UPDATE deliveryservice
+SET active = (COALESCE(active_at <= now(), FALSE) AND COALESCE(inactive_at > now(), TRUE)),
+	active_at = CASE WHEN active_at <= now() THEN NULL ELSE active_at END,
+	inactive_at = CASE WHEN inactive_at <= now() THEN NULL ELSE inactive_at END
+WHERE active_at <= now()
+OR inactive_at <= now()
+RETURNING id, xml_id, cdn_id, topology, active
+`
+
+// queueDSServersUpdateQuery queues updates on the cache servers assigned to a
+// non-Topology-based Delivery Service, and on the servers in the parent Cache
+// Groups of those servers.
+const queueDSServersUpdateQuery = `
+UPDATE server
+SET config_update_time = now()
+WHERE server.cdn_id = $2
+AND (
+	server.id IN (
+		SELECT dss.server
+		FROM deliveryservice_server AS dss
+		WHERE dss.deliveryservice = $1
+	) OR server.cachegroup IN (
+		SELECT cg.parent_cachegroup_id
+		FROM cachegroup AS cg
+		JOIN server AS s ON s.cachegroup = cg.id
+		JOIN deliveryservice_server AS dss ON dss.server = s.id
+		WHERE dss.deliveryservice = $1
+		AND cg.parent_cachegroup_id IS NOT NULL
+	)
+)
+`
+
+type scheduledDS struct {
+	id       int
+	xmlID    string
+	cdnID    int64
+	topology *string
+	active   bool
+}
+
+// InitScheduler starts checking, every interval, for Delivery Services whose
+// scheduled activation or deactivation time has passed, making them active or
+// inactive and queuing updates on their cache servers so that they are added
+// to, or removed from, the servers' configuration. If interval is not
+// positive, Delivery Service schedules are never applied.
+//
+// Applying the schedules is safe with any number of Traffic Ops instances
+// running the scheduler against the same database.
+func InitScheduler(interval time.Duration, db *sql.DB, timeout time.Duration) {
+	if interval <= 0 {
+		log.Infoln("delivery service schedule interval is negative, delivery service schedules will not be applied")
+		return
+	}
+	go func() {
+		for {
+			if err := applySchedules(db, timeout); err != nil {
+				log.Errorf("applying delivery service schedules: %v", err)
+			}
+			time.Sleep(interval)
+		}
+	}()
+}
+
+func applySchedules(db *sql.DB, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	commit := false
+	defer func() {
+		if !commit {
+			if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+				log.Errorf("rolling back delivery service schedule transaction: %v", err)
+			}
+		}
+	}()
+
+	dses, err := applySchedulesTx(tx)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	commit = true
+
+	for _, ds := range dses {
+		state := "inactive"
+		if ds.active {
+			state = "active"
+		}
+		log.Infof("delivery service '%s' (#%d) was made %s by its schedule, queued updates on its servers", ds.xmlID, ds.id, state)
+	}
+	return nil
+}
+
+// applySchedulesTx applies the Delivery Service schedules which have come due,
+// and queues updates on the servers of each affected Delivery Service,
+// returning the affected Delivery Services.
+func applySchedulesTx(tx *sql.Tx) ([]scheduledDS, error) {
+	rows, err := tx.Query(applySchedulesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("applying schedules: %w", err)
+	}
+	defer log.Close(rows, "closing delivery service schedule rows")
+
+	dses := []scheduledDS{}
+	for rows.Next() {
+		ds := scheduledDS{}
+		if err := rows.Scan(&ds.id, &ds.xmlID, &ds.cdnID, &ds.topology, &ds.active); err != nil {
+			return nil, fmt.Errorf("scanning scheduled delivery service: %w", err)
+		}
+		dses = append(dses, ds)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over scheduled delivery services: %w", err)
+	}
+
+	for _, ds := range dses {
+		if ds.topology != nil {
+			if err := dbhelpers.QueueUpdateForServerWithTopologyCDN(tx, tc.TopologyName(*ds.topology), ds.cdnID); err != nil {
+				return nil, fmt.Errorf("delivery service '%s': %w", ds.xmlID, err)
+			}
+			continue
+		}
+		if _, err := tx.Exec(queueDSServersUpdateQuery, ds.id, ds.cdnID); err != nil {
+			return nil, fmt.Errorf("queueing updates for delivery service '%s' servers: %w", ds.xmlID, err)
+		}
+	}
+	return dses, nil
+}
@@ -0,0 +1,92 @@
+package deliveryservice
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestValidateSchedule(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+	cases := []struct {
+		activeAt   *time.Time
+		inactiveAt *time.Time
+		valid      bool
+	}{
+		{nil, nil, true},
+		{&now, nil, true},
+		{nil, &now, true},
+		{&now, &later, true},
+		{&later, &now, false},
+		{&now, &now, false},
+	}
+	for i, c := range cases {
+		ds := tc.DeliveryServiceV4{ActiveAt: c.activeAt, InactiveAt: c.inactiveAt}
+		if err := validateSchedule(&ds); c.valid && err != nil {
+			t.Errorf("case %d: expected valid schedule, got error: %v", i, err)
+		} else if !c.valid && err == nil {
+			t.Errorf("case %d: expected invalid schedule, got no error", i)
+		}
+	}
+}
+
+func TestApplySchedulesTx(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	mock.ExpectBegin()
+	rows := sqlmock.NewRows([]string{"id", "xml_id", "cdn_id", "topology", "active"})
+	rows.AddRow(1, "launch", 2, nil, true)
+	rows.AddRow(3, "takedown", 2, "mso-topology", false)
+	mock.ExpectQuery("UPDATE deliveryservice").WillReturnRows(rows)
+	mock.ExpectExec("UPDATE server").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec("UPDATE public.server").WithArgs("mso-topology", 2).WillReturnResult(sqlmock.NewResult(0, 6))
+
+	tx, err := mockDB.Begin()
+	if err != nil {
+		t.Fatalf("beginning transaction: %v", err)
+	}
+	dses, err := applySchedulesTx(tx)
+	if err != nil {
+		t.Fatalf("unexpected error applying schedules: %v", err)
+	}
+	expected := []scheduledDS{
+		{id: 1, xmlID: "launch", cdnID: 2, active: true},
+		{id: 3, xmlID: "takedown", cdnID: 2, topology: util.StrPtr("mso-topology")},
+	}
+	if len(dses) != len(expected) {
+		t.Fatalf("expected %d scheduled delivery services, got %d", len(expected), len(dses))
+	}
+	for i, ds := range dses {
+		exp := expected[i]
+		if ds.id != exp.id || ds.xmlID != exp.xmlID || ds.cdnID != exp.cdnID || ds.active != exp.active || (ds.topology == nil) != (exp.topology == nil) {
+			t.Errorf("expected scheduled delivery service %+v, got %+v", exp, ds)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
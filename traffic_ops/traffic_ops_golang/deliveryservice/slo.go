@@ -0,0 +1,328 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+)
+
+const selectSLOQuery = `
+SELECT ds.id, ds.xml_id, s.availability_target, s.ttfb_p99_target_ms, s.error_rate_target, s.window_hours, s.last_updated
+FROM deliveryservice_slo AS s
+JOIN deliveryservice AS ds ON ds.id = s.deliveryservice
+WHERE ds.id = $1
+`
+
+const upsertSLOQuery = `
+INSERT INTO deliveryservice_slo (deliveryservice, availability_target, ttfb_p99_target_ms, error_rate_target, window_hours)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (deliveryservice) DO UPDATE SET
+availability_target = EXCLUDED.availability_target,
+ttfb_p99_target_ms = EXCLUDED.ttfb_p99_target_ms,
+error_rate_target = EXCLUDED.error_rate_target,
+window_hours = EXCLUDED.window_hours
+`
+
+const deleteSLOQuery = `
+DELETE FROM deliveryservice_slo
+WHERE deliveryservice = $1
+`
+
+// endSLOViolationsQuery ends the ongoing violations of a Delivery Service's
+// objectives, for when it no longer has them.
+const endSLOViolationsQuery = `
+UPDATE deliveryservice_slo_violation
+SET ended_at = now()
+WHERE deliveryservice = $1
+AND ended_at IS NULL
+`
+
+const deleteSLOComplianceQuery = `
+DELETE FROM deliveryservice_slo_compliance
+WHERE deliveryservice = $1
+`
+
+const selectSLOComplianceQuery = `
+SELECT ds.id, ds.xml_id, c.evaluated_at, c.window_start, c.objectives
+FROM deliveryservice_slo_compliance AS c
+JOIN deliveryservice AS ds ON ds.id = c.deliveryservice
+WHERE ds.id = $1
+`
+
+const selectSLOViolationsQuery = `
+SELECT v.id, ds.id, ds.xml_id, v.objective, v.target, v.actual, v.started_at, v.ended_at
+FROM deliveryservice_slo_violation AS v
+JOIN deliveryservice AS ds ON ds.id = v.deliveryservice
+WHERE ds.id = $1
+AND (v.ended_at IS NULL OR v.ended_at >= $2)
+AND ($3 = FALSE OR v.ended_at IS NULL)
+ORDER BY v.started_at DESC, v.id DESC
+`
+
+// GetSLO is the handler for GET requests to /deliveryservices/{{ID}}/slo.
+func GetSLO(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	if _, userErr, sysErr, errCode := checkSLODS(tx, inf, inf.IntParams["id"]); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	slo, userErr, sysErr, errCode := getDSSLO(tx, inf.IntParams["id"])
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	api.WriteResp(w, r, slo)
+}
+
+// UpdateSLO is the handler for PUT requests to /deliveryservices/{{ID}}/slo,
+// which sets the service level objectives of a Delivery Service.
+func UpdateSLO(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	dsName, userErr, sysErr, errCode := checkSLODS(tx, inf, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var req tc.DeliveryServiceSLORequest
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	if _, err := tx.Exec(upsertSLOQuery, dsID, req.AvailabilityTarget, req.TTFBP99TargetMS, req.ErrorRateTarget, *req.WindowHours); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	slo, userErr, sysErr, errCode := getDSSLO(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+dsName+", ID: "+strconv.Itoa(dsID)+", ACTION: Updated service level objectives", inf.User, tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, fmt.Sprintf("Delivery Service '%s' service level objectives updated", dsName), slo)
+}
+
+// DeleteSLO is the handler for DELETE requests to
+// /deliveryservices/{{ID}}/slo, which removes the service level objectives of
+// a Delivery Service, ending any ongoing violations of them.
+func DeleteSLO(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	dsName, userErr, sysErr, errCode := checkSLODS(tx, inf, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	result, err := tx.Exec(deleteSLOQuery, dsID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deleting Delivery Service service level objectives: "+err.Error()))
+		return
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting rows affected by deleting service level objectives: "+err.Error()))
+		return
+	} else if rows == 0 {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("Delivery Service '%s' has no service level objectives", dsName), nil)
+		return
+	}
+	if _, err := tx.Exec(endSLOViolationsQuery, dsID); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("ending service level objective violations: "+err.Error()))
+		return
+	}
+	if _, err := tx.Exec(deleteSLOComplianceQuery, dsID); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deleting service level objective compliance: "+err.Error()))
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+dsName+", ID: "+strconv.Itoa(dsID)+", ACTION: Deleted service level objectives", inf.User, tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, fmt.Sprintf("Delivery Service '%s' service level objectives deleted", dsName))
+}
+
+// GetSLOCompliance is the handler for GET requests to
+// /deliveryservices/{{ID}}/slo/compliance, which returns the latest
+// evaluation of a Delivery Service's compliance with its service level
+// objectives.
+func GetSLOCompliance(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	dsName, userErr, sysErr, errCode := checkSLODS(tx, inf, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var compliance tc.DeliveryServiceSLOCompliance
+	var objectives []byte
+	err := tx.QueryRow(selectSLOComplianceQuery, dsID).Scan(&compliance.DeliveryServiceID, &compliance.XMLID, &compliance.EvaluatedAt, &compliance.WindowStart, &objectives)
+	if err == sql.ErrNoRows {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("compliance of Delivery Service '%s' with its service level objectives has not been evaluated", dsName), nil)
+		return
+	} else if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("querying service level objective compliance: "+err.Error()))
+		return
+	}
+	if err := json.Unmarshal(objectives, &compliance.Objectives); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("decoding service level objective compliance of Delivery Service '%s': %w", dsName, err))
+		return
+	}
+	if compliance.Objectives == nil {
+		compliance.Objectives = []tc.SLOObjectiveCompliance{}
+	}
+	compliance.Compliant = tc.SLOCompliant(compliance.Objectives)
+	api.WriteResp(w, r, compliance)
+}
+
+// GetSLOViolations is the handler for GET requests to
+// /deliveryservices/{{ID}}/slo/violations, which returns the violations of a
+// Delivery Service's service level objectives, most recent first - those
+// ongoing or which ended at or after the time given by the 'since' query
+// parameter, or in the last 30 days by default, and only those ongoing if the
+// 'ongoing' query parameter is true.
+func GetSLOViolations(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	if _, userErr, sysErr, errCode := checkSLODS(tx, inf, dsID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	since := time.Now().Add(-tc.DefaultSLOWindowHours * time.Hour)
+	if s, ok := inf.Params["since"]; ok {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("since: must be an RFC3339 date/time"), nil)
+			return
+		}
+		since = t
+	}
+	ongoing := false
+	if o, ok := inf.Params["ongoing"]; ok {
+		b, err := strconv.ParseBool(o)
+		if err != nil {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("ongoing: must be 'true' or 'false'"), nil)
+			return
+		}
+		ongoing = b
+	}
+
+	rows, err := tx.Query(selectSLOViolationsQuery, dsID, since, ongoing)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("querying service level objective violations: "+err.Error()))
+		return
+	}
+	defer log.Close(rows, "closing service level objective violation rows")
+
+	violations := []tc.DeliveryServiceSLOViolation{}
+	for rows.Next() {
+		var v tc.DeliveryServiceSLOViolation
+		if err := rows.Scan(&v.ID, &v.DeliveryServiceID, &v.XMLID, &v.Objective, &v.Target, &v.Actual, &v.StartedAt, &v.EndedAt); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("scanning service level objective violation: "+err.Error()))
+			return
+		}
+		violations = append(violations, v)
+	}
+	if err := rows.Err(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("iterating over service level objective violations: "+err.Error()))
+		return
+	}
+	api.WriteResp(w, r, violations)
+}
+
+// checkSLODS checks that the Delivery Service with the given ID exists and
+// that the user may see it. It returns the Delivery Service's XMLID, along
+// with a user error, system error, and status code.
+func checkSLODS(tx *sql.Tx, inf *api.APIInfo, dsID int) (string, error, error, int) {
+	userErr, sysErr, errCode := tenant.CheckID(tx, inf.User, dsID)
+	if userErr != nil || sysErr != nil {
+		return "", userErr, sysErr, errCode
+	}
+	dsName, ok, err := dbhelpers.GetDSNameFromID(tx, dsID)
+	if err != nil {
+		return "", nil, errors.New("getting Delivery Service name: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return "", fmt.Errorf("no Delivery Service exists by ID '%d'", dsID), nil, http.StatusNotFound
+	}
+	return string(dsName), nil, nil, http.StatusOK
+}
+
+// getDSSLO returns the service level objectives of the Delivery Service with
+// the given ID, along with a user error, system error, and status code.
+func getDSSLO(tx *sql.Tx, dsID int) (tc.DeliveryServiceSLO, error, error, int) {
+	var slo tc.DeliveryServiceSLO
+	err := tx.QueryRow(selectSLOQuery, dsID).Scan(&slo.DeliveryServiceID, &slo.XMLID, &slo.AvailabilityTarget, &slo.TTFBP99TargetMS, &slo.ErrorRateTarget, &slo.WindowHours, &slo.LastUpdated)
+	if err == sql.ErrNoRows {
+		return tc.DeliveryServiceSLO{}, fmt.Errorf("Delivery Service #%d has no service level objectives", dsID), nil, http.StatusNotFound
+	} else if err != nil {
+		return tc.DeliveryServiceSLO{}, nil, errors.New("querying Delivery Service service level objectives: " + err.Error()), http.StatusInternalServerError
+	}
+	return slo, nil, nil, http.StatusOK
+}
@@ -0,0 +1,455 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/util/monitorhlp"
+
+	influx "github.com/influxdata/influxdb/client/v2"
+	"github.com/lib/pq"
+)
+
+// lockDueSLOsQuery locks the service level objectives whose compliance wasn't
+// evaluated within the number of seconds given by $1, skipping those another
+// Traffic Ops instance is evaluating.
+const lockDueSLOsQuery = `
+SELECT ds.id, ds.xml_id, c.name, s.availability_target, s.ttfb_p99_target_ms, s.error_rate_target, s.window_hours
+FROM deliveryservice_slo AS s
+JOIN deliveryservice AS ds ON ds.id = s.deliveryservice
+JOIN cdn AS c ON c.id = ds.cdn_id
+LEFT JOIN deliveryservice_slo_compliance AS sc ON sc.deliveryservice = s.deliveryservice
+WHERE sc.evaluated_at IS NULL
+OR sc.evaluated_at < now() - $1 * interval '1 second'
+FOR UPDATE OF s SKIP LOCKED
+`
+
+const insertSLOSampleQuery = `
+INSERT INTO deliveryservice_slo_sample (deliveryservice, sampled_at, available)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING
+`
+
+const sloAvailabilityQuery = `
+SELECT COUNT(*) FILTER (WHERE available), COUNT(*)
+FROM deliveryservice_slo_sample
+WHERE deliveryservice = $1
+AND sampled_at >= $2
+`
+
+const upsertSLOComplianceQuery = `
+INSERT INTO deliveryservice_slo_compliance (deliveryservice, evaluated_at, window_start, objectives)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (deliveryservice) DO UPDATE SET
+evaluated_at = EXCLUDED.evaluated_at,
+window_start = EXCLUDED.window_start,
+objectives = EXCLUDED.objectives
+`
+
+// upsertSLOViolationQuery starts a violation of an objective, or updates the
+// ongoing one, returning whether it was started.
+const upsertSLOViolationQuery = `
+INSERT INTO deliveryservice_slo_violation (deliveryservice, objective, target, actual, started_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (deliveryservice, objective) WHERE ended_at IS NULL DO UPDATE SET
+target = EXCLUDED.target,
+actual = EXCLUDED.actual
+RETURNING (xmax = 0)
+`
+
+const endSLOObjectiveViolationsQuery = `
+UPDATE deliveryservice_slo_violation
+SET ended_at = $3
+WHERE deliveryservice = $1
+AND objective = ANY($2)
+AND ended_at IS NULL
+RETURNING objective
+`
+
+const pruneSLOSamplesQuery = `
+DELETE FROM deliveryservice_slo_sample
+WHERE sampled_at < $1
+`
+
+// These are the Traffic Stats measurements from which the objectives are
+// evaluated. Traffic Stats writes the measurements of 5xx and total
+// transactions per second; the time to first byte, in milliseconds, must be
+// written by an external collector for the 'ttfbP99' objective to be
+// evaluated.
+const (
+	sloErrorQuery = `SELECT sum(value) FROM "%s"."monthly"."tps_5xx.ds.1min" WHERE time >= $start AND time <= $end AND cachegroup = 'total' AND deliveryservice = $xmlid`
+	sloTotalQuery = `SELECT sum(value) FROM "%s"."monthly"."tps_total.ds.1min" WHERE time >= $start AND time <= $end AND cachegroup = 'total' AND deliveryservice = $xmlid`
+	sloTTFBQuery  = `SELECT percentile(value, 99) FROM "%s"."monthly"."ttfb.ds.1min" WHERE time >= $start AND time <= $end AND cachegroup = 'total' AND deliveryservice = $xmlid`
+)
+
+// InitSLOEvaluator starts evaluating, every interval, the compliance of
+// Delivery Services with their service level objectives over their rolling
+// windows, sampling their availability from Traffic Monitor and their error
+// rates and times to first byte from Traffic Stats, and recording when each
+// objective starts and stops being violated. If interval is not positive,
+// service level objectives are never evaluated.
+//
+// Evaluating service level objectives is safe with any number of Traffic Ops
+// instances running the evaluator against the same database.
+func InitSLOEvaluator(interval time.Duration, db *sql.DB, timeout time.Duration, cfg *config.Config) {
+	if interval <= 0 {
+		log.Infoln("delivery service SLO evaluation interval is negative, delivery service SLOs will not be evaluated")
+		return
+	}
+	go func() {
+		for {
+			if err := evaluateSLOs(db, timeout, interval, cfg); err != nil {
+				log.Errorf("evaluating delivery service SLOs: %v", err)
+			}
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// dueSLO is a Delivery Service's service level objectives, due to be
+// evaluated.
+type dueSLO struct {
+	tc.DeliveryServiceSLO
+	cdn tc.CDNName
+}
+
+func evaluateSLOs(db *sql.DB, timeout time.Duration, interval time.Duration, cfg *config.Config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	commit := false
+	defer func() {
+		if !commit {
+			if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+				log.Errorf("rolling back delivery service SLO transaction: %v", err)
+			}
+		}
+	}()
+
+	// Other instances evaluating at the same interval are skipped for half
+	// of it, so that each sample is only taken once.
+	slos, err := getDueSLOs(tx, interval/2)
+	if err != nil {
+		return err
+	}
+	if len(slos) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	if err := sampleSLOAvailability(tx, slos, now.Truncate(interval)); err != nil {
+		// without availability samples, the availability is evaluated
+		// from those already taken
+		log.Errorf("sampling delivery service availability: %v", err)
+	}
+
+	var client *influx.Client
+	if cfg != nil && cfg.InfluxEnabled && cfg.ConfigInflux != nil {
+		if client, err = api.CreateInfluxClient(tx, cfg); err != nil {
+			log.Errorf("delivery service SLO error rates and times to first byte will not be evaluated: %v", err)
+		} else if client != nil {
+			defer log.Close(*client, "closing InfluxDB client")
+		}
+	}
+
+	errs := []error{}
+	for _, slo := range slos {
+		if err := evaluateSLO(tx, client, cfg, slo, now); err != nil {
+			errs = append(errs, fmt.Errorf("delivery service '%s': %w", slo.XMLID, err))
+		}
+	}
+	if _, err := tx.Exec(pruneSLOSamplesQuery, now.Add(-tc.MaxSLOWindowHours*time.Hour)); err != nil {
+		errs = append(errs, fmt.Errorf("pruning availability samples: %w", err))
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	commit = true
+	return util.JoinErrs(errs)
+}
+
+func getDueSLOs(tx *sql.Tx, since time.Duration) ([]dueSLO, error) {
+	rows, err := tx.Query(lockDueSLOsQuery, int(since.Seconds()))
+	if err != nil {
+		return nil, fmt.Errorf("querying delivery service SLOs: %w", err)
+	}
+	defer log.Close(rows, "closing delivery service SLO rows")
+
+	slos := []dueSLO{}
+	for rows.Next() {
+		var slo dueSLO
+		if err := rows.Scan(&slo.DeliveryServiceID, &slo.XMLID, &slo.cdn, &slo.AvailabilityTarget, &slo.TTFBP99TargetMS, &slo.ErrorRateTarget, &slo.WindowHours); err != nil {
+			return nil, fmt.Errorf("scanning delivery service SLO: %w", err)
+		}
+		slos = append(slos, slo)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over delivery service SLOs: %w", err)
+	}
+	return slos, nil
+}
+
+// sampleSLOAvailability records whether Traffic Monitor reports each of the
+// Delivery Services with an availability objective as available. Delivery
+// Services on CDNs whose Traffic Monitors can't be reached, or which Traffic
+// Monitor doesn't report on, aren't sampled.
+func sampleSLOAvailability(tx *sql.Tx, slos []dueSLO, sampledAt time.Time) error {
+	byCDN := map[tc.CDNName][]dueSLO{}
+	for _, slo := range slos {
+		if slo.AvailabilityTarget != nil {
+			byCDN[slo.cdn] = append(byCDN[slo.cdn], slo)
+		}
+	}
+	if len(byCDN) == 0 {
+		return nil
+	}
+
+	monitors, err := monitorhlp.GetURLs(tx)
+	if err != nil {
+		return fmt.Errorf("getting monitors: %w", err)
+	}
+	client, err := monitorhlp.GetClient(tx)
+	if err != nil {
+		return fmt.Errorf("getting monitor client: %w", err)
+	}
+
+	errs := []error{}
+	for cdn, cdnSLOs := range byCDN {
+		crStates, err := getCDNCRStates(monitors[cdn], client)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("CDN '%s': %w", cdn, err))
+			continue
+		}
+		for _, slo := range cdnSLOs {
+			state, ok := crStates.DeliveryService[tc.DeliveryServiceName(slo.XMLID)]
+			if !ok {
+				continue
+			}
+			if _, err := tx.Exec(insertSLOSampleQuery, slo.DeliveryServiceID, sampledAt, state.IsAvailable); err != nil {
+				return fmt.Errorf("inserting availability sample of delivery service '%s': %w", slo.XMLID, err)
+			}
+		}
+	}
+	return util.JoinErrs(errs)
+}
+
+// getCDNCRStates returns the CRStates of the first of the given Traffic
+// Monitors that responds.
+func getCDNCRStates(monitors []string, client *http.Client) (tc.CRStates, error) {
+	if len(monitors) == 0 {
+		return tc.CRStates{}, errors.New("no online monitors")
+	}
+	errs := []error{}
+	for _, monitor := range monitors {
+		crStates, err := monitorhlp.GetCRStates(monitor, client)
+		if err == nil {
+			return crStates, nil
+		}
+		errs = append(errs, err)
+	}
+	return tc.CRStates{}, util.JoinErrs(errs)
+}
+
+// evaluateSLO evaluates and records the compliance of a Delivery Service with
+// its service level objectives over the window ending at the given time,
+// starting and ending violations of them as necessary.
+func evaluateSLO(tx *sql.Tx, client *influx.Client, cfg *config.Config, slo dueSLO, now time.Time) error {
+	windowStart := now.Add(-time.Duration(slo.WindowHours) * time.Hour)
+
+	var availability, ttfb, errorRate *float64
+	if slo.AvailabilityTarget != nil {
+		var available, total int
+		if err := tx.QueryRow(sloAvailabilityQuery, slo.DeliveryServiceID, windowStart).Scan(&available, &total); err != nil {
+			return fmt.Errorf("querying availability: %w", err)
+		}
+		if total > 0 {
+			availability = util.FloatPtr(100 * float64(available) / float64(total))
+		}
+	}
+	if client != nil {
+		params := map[string]interface{}{
+			"xmlid": slo.XMLID,
+			"start": windowStart.Format(time.RFC3339),
+			"end":   now.Format(time.RFC3339),
+		}
+		db := cfg.ConfigInflux.DSDBName
+		if slo.ErrorRateTarget != nil {
+			errorRate = getSLOErrorRate(client, db, params)
+		}
+		if slo.TTFBP99TargetMS != nil {
+			ttfb = getSLOInfluxValue(client, db, sloTTFBQuery, params)
+		}
+	}
+
+	objectives := sloObjectives(slo.DeliveryServiceSLO, availability, ttfb, errorRate)
+	objectivesJSON, err := json.Marshal(objectives)
+	if err != nil {
+		return fmt.Errorf("encoding objective compliance: %w", err)
+	}
+	if _, err := tx.Exec(upsertSLOComplianceQuery, slo.DeliveryServiceID, now, windowStart, objectivesJSON); err != nil {
+		return fmt.Errorf("recording compliance: %w", err)
+	}
+
+	for _, o := range objectives {
+		if o.Compliant == nil || *o.Compliant {
+			continue
+		}
+		started := false
+		if err := tx.QueryRow(upsertSLOViolationQuery, slo.DeliveryServiceID, o.Objective, o.Target, *o.Actual, now).Scan(&started); err != nil {
+			return fmt.Errorf("recording violation of objective '%s': %w", o.Objective, err)
+		}
+		if started {
+			log.Warnf("delivery service '%s' (#%d) is violating its '%s' SLO: target %v, actual %v", slo.XMLID, slo.DeliveryServiceID, o.Objective, o.Target, *o.Actual)
+		}
+	}
+
+	rows, err := tx.Query(endSLOObjectiveViolationsQuery, slo.DeliveryServiceID, pq.Array(sloMetObjectives(objectives)), now)
+	if err != nil {
+		return fmt.Errorf("ending violations: %w", err)
+	}
+	defer log.Close(rows, "closing ended delivery service SLO violation rows")
+	for rows.Next() {
+		var objective tc.SLOObjective
+		if err := rows.Scan(&objective); err != nil {
+			return fmt.Errorf("scanning ended violation: %w", err)
+		}
+		log.Infof("delivery service '%s' (#%d) is no longer violating its '%s' SLO", slo.XMLID, slo.DeliveryServiceID, objective)
+	}
+	return rows.Err()
+}
+
+// sloObjectives returns the compliance with each of the given service level
+// objectives of the given measurements, any of which may be nil if there was
+// no data from which to measure it.
+func sloObjectives(slo tc.DeliveryServiceSLO, availability, ttfb, errorRate *float64) []tc.SLOObjectiveCompliance {
+	objectives := []tc.SLOObjectiveCompliance{}
+	compliant := func(actual *float64, met func(float64) bool) *bool {
+		if actual == nil {
+			return nil
+		}
+		return util.BoolPtr(met(*actual))
+	}
+	if slo.AvailabilityTarget != nil {
+		target := *slo.AvailabilityTarget
+		objectives = append(objectives, tc.SLOObjectiveCompliance{
+			Objective: tc.SLOObjectiveAvailability,
+			Target:    target,
+			Actual:    availability,
+			Compliant: compliant(availability, func(a float64) bool { return a >= target }),
+		})
+	}
+	if slo.TTFBP99TargetMS != nil {
+		target := float64(*slo.TTFBP99TargetMS)
+		objectives = append(objectives, tc.SLOObjectiveCompliance{
+			Objective: tc.SLOObjectiveTTFBP99,
+			Target:    target,
+			Actual:    ttfb,
+			Compliant: compliant(ttfb, func(a float64) bool { return a <= target }),
+		})
+	}
+	if slo.ErrorRateTarget != nil {
+		target := *slo.ErrorRateTarget
+		objectives = append(objectives, tc.SLOObjectiveCompliance{
+			Objective: tc.SLOObjectiveErrorRate,
+			Target:    target,
+			Actual:    errorRate,
+			Compliant: compliant(errorRate, func(a float64) bool { return a <= target }),
+		})
+	}
+	return objectives
+}
+
+// sloMetObjectives returns the kinds of objectives whose ongoing violations
+// have ended: those which were met, and those the Delivery Service no longer
+// has. Objectives whose compliance is unknown are left as they were.
+func sloMetObjectives(objectives []tc.SLOObjectiveCompliance) []string {
+	unmet := map[tc.SLOObjective]struct{}{}
+	for _, o := range objectives {
+		if o.Compliant == nil || !*o.Compliant {
+			unmet[o.Objective] = struct{}{}
+		}
+	}
+	met := []string{}
+	for _, o := range []tc.SLOObjective{tc.SLOObjectiveAvailability, tc.SLOObjectiveTTFBP99, tc.SLOObjectiveErrorRate} {
+		if _, ok := unmet[o]; !ok {
+			met = append(met, string(o))
+		}
+	}
+	return met
+}
+
+// getSLOErrorRate returns the percentage of the Delivery Service's
+// transactions that were answered with 5xx responses, or nil if it can't be
+// known.
+func getSLOErrorRate(client *influx.Client, db string, params map[string]interface{}) *float64 {
+	serverErrors := getSLOInfluxValue(client, db, sloErrorQuery, params)
+	total := getSLOInfluxValue(client, db, sloTotalQuery, params)
+	if serverErrors == nil || total == nil || *total <= 0 {
+		return nil
+	}
+	return util.FloatPtr(100 * *serverErrors / *total)
+}
+
+// getSLOInfluxValue returns the single value returned by the given query, or
+// nil if there's none. Errors are logged rather than returned, because an
+// objective that can't be measured is reported as having unknown compliance.
+func getSLOInfluxValue(client *influx.Client, db string, query string, params map[string]interface{}) *float64 {
+	q := influx.NewQueryWithParameters(fmt.Sprintf(query, db), db, "rfc3339", params)
+	resp, err := (*client).Query(q)
+	if err == nil {
+		err = resp.Error()
+	}
+	if err != nil {
+		log.Errorf("querying InfluxDB for delivery service '%v' SLO: %v", params["xmlid"], err)
+		return nil
+	}
+	if len(resp.Results) != 1 || len(resp.Results[0].Series) != 1 || len(resp.Results[0].Series[0].Values) != 1 {
+		return nil
+	}
+	vals := resp.Results[0].Series[0].Values[0]
+	if len(vals) != 2 {
+		return nil
+	}
+	n, ok := vals[1].(json.Number)
+	if !ok {
+		return nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return nil
+	}
+	return &f
+}
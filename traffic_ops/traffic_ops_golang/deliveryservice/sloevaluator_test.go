@@ -0,0 +1,79 @@
+package deliveryservice
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestSLOObjectives(t *testing.T) {
+	slo := tc.DeliveryServiceSLO{
+		AvailabilityTarget: util.FloatPtr(99.9),
+		TTFBP99TargetMS:    util.IntPtr(200),
+		ErrorRateTarget:    util.FloatPtr(0.5),
+	}
+
+	objectives := sloObjectives(slo, util.FloatPtr(99.95), util.FloatPtr(250), nil)
+	if len(objectives) != 3 {
+		t.Fatalf("expected 3 objectives, got %d", len(objectives))
+	}
+	expected := []*bool{util.BoolPtr(true), util.BoolPtr(false), nil}
+	for i, o := range objectives {
+		if !reflect.DeepEqual(o.Compliant, expected[i]) {
+			t.Errorf("objective '%s': expected compliance %v, got %v", o.Objective, expected[i], o.Compliant)
+		}
+	}
+	if objectives[1].Target != 200 {
+		t.Errorf("expected ttfbP99 target 200, got %v", objectives[1].Target)
+	}
+
+	// targets are inclusive
+	objectives = sloObjectives(slo, util.FloatPtr(99.9), util.FloatPtr(200), util.FloatPtr(0.5))
+	for _, o := range objectives {
+		if o.Compliant == nil || !*o.Compliant {
+			t.Errorf("objective '%s': expected actual value equal to target to be compliant", o.Objective)
+		}
+	}
+
+	objectives = sloObjectives(tc.DeliveryServiceSLO{ErrorRateTarget: util.FloatPtr(1)}, util.FloatPtr(50), nil, util.FloatPtr(2))
+	if len(objectives) != 1 || objectives[0].Objective != tc.SLOObjectiveErrorRate {
+		t.Fatalf("expected only the errorRate objective, got %+v", objectives)
+	}
+	if objectives[0].Compliant == nil || *objectives[0].Compliant {
+		t.Error("expected error rate above target to be non-compliant")
+	}
+}
+
+func TestSLOMetObjectives(t *testing.T) {
+	objectives := []tc.SLOObjectiveCompliance{
+		{Objective: tc.SLOObjectiveAvailability, Compliant: util.BoolPtr(true)},
+		{Objective: tc.SLOObjectiveTTFBP99, Compliant: nil},
+	}
+	expected := []string{string(tc.SLOObjectiveAvailability), string(tc.SLOObjectiveErrorRate)}
+	if met := sloMetObjectives(objectives); !reflect.DeepEqual(met, expected) {
+		t.Errorf("expected met objectives %v, got %v", expected, met)
+	}
+
+	objectives[0].Compliant = util.BoolPtr(false)
+	expected = []string{string(tc.SLOObjectiveErrorRate)}
+	if met := sloMetObjectives(objectives); !reflect.DeepEqual(met, expected) {
+		t.Errorf("expected met objectives %v, got %v", expected, met)
+	}
+}
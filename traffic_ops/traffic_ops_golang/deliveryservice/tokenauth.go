@@ -0,0 +1,476 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+const selectTokenAuthQuery = `
+SELECT ds.id, ds.xml_id, a.algorithm, a.key_rotation_days, a.clock_skew, a.exempt_paths, a.current_key, a.last_key_rotation, a.last_updated
+FROM deliveryservice_token_auth AS a
+JOIN deliveryservice AS ds ON ds.id = a.deliveryservice
+`
+
+const upsertTokenAuthQuery = `
+INSERT INTO deliveryservice_token_auth (deliveryservice, algorithm, key_rotation_days, clock_skew, exempt_paths)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (deliveryservice) DO UPDATE SET
+algorithm = EXCLUDED.algorithm,
+key_rotation_days = EXCLUDED.key_rotation_days,
+clock_skew = EXCLUDED.clock_skew,
+exempt_paths = EXCLUDED.exempt_paths
+`
+
+const updateTokenAuthKeyQuery = `
+UPDATE deliveryservice_token_auth
+SET current_key = $1, last_key_rotation = now()
+WHERE deliveryservice = $2
+`
+
+const deleteTokenAuthQuery = `
+DELETE FROM deliveryservice_token_auth
+WHERE deliveryservice = $1
+`
+
+// GetTokenAuth is the handler for GET requests to
+// /deliveryservices/{{ID}}/token-auth.
+func GetTokenAuth(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	userErr, sysErr, errCode = tenant.CheckID(tx, inf.User, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	auth, userErr, sysErr, errCode := getDSTokenAuth(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	api.WriteResp(w, r, auth)
+}
+
+// GetCDNTokenAuth is the handler for GET requests to
+// /deliveryservices/token-auth, which returns the token authentication
+// settings of every Delivery Service with any, optionally only those in the
+// CDN named by the 'cdn' query parameter. It exists so that cache
+// configuration generation doesn't need a request per Delivery Service.
+func GetCDNTokenAuth(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	tenants, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting accessible tenants for user: %w", err))
+		return
+	}
+
+	query := selectTokenAuthQuery + `WHERE ds.tenant_id = ANY($1)`
+	args := []interface{}{pq.Array(tenants)}
+	if cdn, ok := inf.Params["cdn"]; ok {
+		if _, ok, err := dbhelpers.GetCDNIDFromName(tx, tc.CDNName(cdn)); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("checking CDN existence: %w", err))
+			return
+		} else if !ok {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no such CDN: %s", cdn), nil)
+			return
+		}
+		query += ` AND ds.cdn_id = (SELECT id FROM cdn WHERE name = $2)`
+		args = append(args, cdn)
+	}
+
+	auths, err := readTokenAuth(tx, query+` ORDER BY ds.xml_id`, args...)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, auths)
+}
+
+// UpdateTokenAuth is the handler for PUT requests to
+// /deliveryservices/{{ID}}/token-auth, which sets the token authentication
+// settings of a url_sig Delivery Service. If the Delivery Service has no URL
+// sig keys yet, they are generated.
+func UpdateTokenAuth(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	if !inf.Config.TrafficVaultEnabled {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deliveryservice.UpdateTokenAuth: Traffic Vault is not configured"))
+		return
+	}
+
+	dsName, userErr, sysErr, errCode := checkTokenAuthDS(tx, inf, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var req tc.DeliveryServiceTokenAuthRequest
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	if req.ExemptPaths == nil {
+		req.ExemptPaths = []string{}
+	}
+
+	if _, err := tx.Exec(upsertTokenAuthQuery, dsID, req.Algorithm, req.KeyRotationDays, req.ClockSkew, pq.Array(req.ExemptPaths)); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	_, ok, err := inf.Vault.GetURLSigKeys(dsName, tx, r.Context())
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting URL sig keys for '%s': %w", dsName, err))
+		return
+	}
+	msg := fmt.Sprintf("Delivery Service '%s' token authentication settings updated", dsName)
+	if !ok {
+		if err := putNewURLSigKeys(tx, inf, r.Context(), dsID, dsName); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+			return
+		}
+		msg += " and keys generated"
+	}
+
+	auth, userErr, sysErr, errCode := getDSTokenAuth(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+dsName+", ID: "+strconv.Itoa(dsID)+", ACTION: Updated token authentication settings", inf.User, tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, msg+"; queue updates on the CDN to apply them", auth)
+}
+
+// DeleteTokenAuth is the handler for DELETE requests to
+// /deliveryservices/{{ID}}/token-auth. The Delivery Service's keys are not
+// removed from Traffic Vault.
+func DeleteTokenAuth(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	userErr, sysErr, errCode = tenant.CheckID(tx, inf.User, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	dsName, cdn, ok, err := dbhelpers.GetDSNameAndCDNFromID(tx, dsID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting Delivery Service name and CDN: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no Delivery Service exists by ID '%d'", dsID), nil)
+		return
+	}
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyCDN(tx, string(cdn), inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	result, err := tx.Exec(deleteTokenAuthQuery, dsID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deleting Delivery Service token authentication settings: "+err.Error()))
+		return
+	}
+	if rows, err := result.RowsAffected(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting rows affected by deleting token authentication settings: "+err.Error()))
+		return
+	} else if rows == 0 {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("Delivery Service '%s' has no token authentication settings", dsName), nil)
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+string(dsName)+", ID: "+strconv.Itoa(dsID)+", ACTION: Deleted token authentication settings", inf.User, tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, fmt.Sprintf("Delivery Service '%s' token authentication settings deleted", dsName))
+}
+
+// RotateTokenAuthKey is the handler for POST requests to
+// /deliveryservices/{{ID}}/token-auth/rotate, which rotates a key of the
+// Delivery Service immediately, regardless of its rotation schedule.
+func RotateTokenAuthKey(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	if !inf.Config.TrafficVaultEnabled {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deliveryservice.RotateTokenAuthKey: Traffic Vault is not configured"))
+		return
+	}
+
+	if _, userErr, sysErr, errCode := checkTokenAuthDS(tx, inf, dsID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	auth, userErr, sysErr, errCode := getDSTokenAuth(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if err := rotateURLSigKey(tx, inf, r.Context(), auth); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	auth, userErr, sysErr, errCode = getDSTokenAuth(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+auth.XMLID+", ID: "+strconv.Itoa(dsID)+", ACTION: Rotated URL sig key; current key is now key"+strconv.Itoa(auth.CurrentKey), inf.User, tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, fmt.Sprintf("Delivery Service '%s' URL sig key rotated; signers should now use key%d, and updates should be queued on the CDN", auth.XMLID, auth.CurrentKey), auth)
+}
+
+// RotateDueTokenAuthKeys is the handler for POST requests to
+// /deliveryservices/token-auth/rotate, which rotates a key of every Delivery
+// Service the user can see whose key rotation is due. It's meant to be
+// requested periodically, e.g. by cron.
+func RotateDueTokenAuthKeys(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	if !inf.Config.TrafficVaultEnabled {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deliveryservice.RotateDueTokenAuthKeys: Traffic Vault is not configured"))
+		return
+	}
+
+	tenants, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting accessible tenants for user: %w", err))
+		return
+	}
+	auths, err := readTokenAuth(tx, selectTokenAuthQuery+`WHERE ds.tenant_id = ANY($1) AND ds.signing_algorithm = $2 ORDER BY ds.xml_id`, pq.Array(tenants), tc.SigningAlgorithmURLSig)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	now := time.Now()
+	alerts := tc.Alerts{}
+	rotated := []tc.DeliveryServiceTokenAuth{}
+	for _, auth := range auths {
+		if auth.NextKeyRotation == nil || auth.NextKeyRotation.After(now) {
+			continue
+		}
+		_, cdn, _, err := dbhelpers.GetDSNameAndCDNFromID(tx, auth.DeliveryServiceID)
+		if err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting Delivery Service CDN: "+err.Error()))
+			return
+		}
+		if userErr, sysErr, _ := dbhelpers.CheckIfCurrentUserCanModifyCDN(tx, string(cdn), inf.User.UserName); sysErr != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
+			return
+		} else if userErr != nil {
+			alerts.AddNewAlert(tc.WarnLevel, fmt.Sprintf("not rotating the due key of Delivery Service '%s': %v", auth.XMLID, userErr))
+			continue
+		}
+		if err := rotateURLSigKey(tx, inf, r.Context(), auth); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+			return
+		}
+		updated, userErr, sysErr, errCode := getDSTokenAuth(tx, auth.DeliveryServiceID)
+		if userErr != nil || sysErr != nil {
+			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+			return
+		}
+		api.CreateChangeLogRawTx(api.ApiChange, "DS: "+auth.XMLID+", ID: "+strconv.Itoa(auth.DeliveryServiceID)+", ACTION: Rotated due URL sig key; current key is now key"+strconv.Itoa(updated.CurrentKey), inf.User, tx)
+		rotated = append(rotated, updated)
+	}
+
+	alerts.AddNewAlert(tc.SuccessLevel, fmt.Sprintf("rotated the keys of %d Delivery Services; queue updates on their CDNs to apply them", len(rotated)))
+	api.WriteAlertsObj(w, r, http.StatusOK, alerts, rotated)
+}
+
+// checkTokenAuthDS checks that the user may modify the token authentication
+// settings of the Delivery Service with the given ID, and that it uses
+// url_sig signing. It returns the Delivery Service's XMLID, along with a user
+// error, system error, and status code.
+func checkTokenAuthDS(tx *sql.Tx, inf *api.APIInfo, dsID int) (string, error, error, int) {
+	userErr, sysErr, errCode := tenant.CheckID(tx, inf.User, dsID)
+	if userErr != nil || sysErr != nil {
+		return "", userErr, sysErr, errCode
+	}
+
+	var dsName, cdn string
+	var signingAlgorithm *string
+	err := tx.QueryRow(`SELECT ds.xml_id, cdn.name, ds.signing_algorithm FROM deliveryservice AS ds JOIN cdn ON cdn.id = ds.cdn_id WHERE ds.id = $1`, dsID).Scan(&dsName, &cdn, &signingAlgorithm)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("no Delivery Service exists by ID '%d'", dsID), nil, http.StatusNotFound
+	} else if err != nil {
+		return "", nil, errors.New("getting Delivery Service name, CDN, and signing algorithm: " + err.Error()), http.StatusInternalServerError
+	}
+	if signingAlgorithm == nil || *signingAlgorithm != tc.SigningAlgorithmURLSig {
+		return "", fmt.Errorf("Delivery Service '%s' must use the '%s' signing algorithm to have token authentication settings", dsName, tc.SigningAlgorithmURLSig), nil, http.StatusBadRequest
+	}
+
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyCDN(tx, cdn, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		return "", userErr, sysErr, errCode
+	}
+	return dsName, nil, nil, http.StatusOK
+}
+
+// putNewURLSigKeys generates a full set of URL sig keys for the Delivery
+// Service and stores them in Traffic Vault, making key0 its current key.
+func putNewURLSigKeys(tx *sql.Tx, inf *api.APIInfo, ctx context.Context, dsID int, dsName string) error {
+	keys, err := GenerateURLSigKeys()
+	if err != nil {
+		return errors.New("generating URL sig keys: " + err.Error())
+	}
+	if err := inf.Vault.PutURLSigKeys(dsName, keys, tx, ctx); err != nil {
+		return fmt.Errorf("setting URL sig keys for '%s': %w", dsName, err)
+	}
+	if _, err := tx.Exec(updateTokenAuthKeyQuery, 0, dsID); err != nil {
+		return errors.New("setting Delivery Service current URL sig key: " + err.Error())
+	}
+	return nil
+}
+
+// rotateURLSigKey rotates a URL sig key of the Delivery Service with the given
+// token authentication settings. Keys are published one rotation ahead of
+// their use: the key after the current one - which was generated by the
+// previous rotation, and so is already on cache servers - becomes current, and
+// the key after that is replaced with a new one. If the Delivery Service has
+// no keys, a full set is generated instead.
+func rotateURLSigKey(tx *sql.Tx, inf *api.APIInfo, ctx context.Context, auth tc.DeliveryServiceTokenAuth) error {
+	keys, ok, err := inf.Vault.GetURLSigKeys(auth.XMLID, tx, ctx)
+	if err != nil {
+		return fmt.Errorf("getting URL sig keys for '%s': %w", auth.XMLID, err)
+	}
+	if !ok || len(keys) < tc.URLSigKeyCount {
+		return putNewURLSigKeys(tx, inf, ctx, auth.DeliveryServiceID, auth.XMLID)
+	}
+
+	current, replaced := nextURLSigKeys(auth.CurrentKey)
+	key, err := generateURLSigKey()
+	if err != nil {
+		return errors.New("generating URL sig key: " + err.Error())
+	}
+	keys["key"+strconv.Itoa(replaced)] = key
+	if err := inf.Vault.PutURLSigKeys(auth.XMLID, keys, tx, ctx); err != nil {
+		return fmt.Errorf("setting URL sig keys for '%s': %w", auth.XMLID, err)
+	}
+	if _, err := tx.Exec(updateTokenAuthKeyQuery, current, auth.DeliveryServiceID); err != nil {
+		return errors.New("setting Delivery Service current URL sig key: " + err.Error())
+	}
+	return nil
+}
+
+// nextURLSigKeys returns the index of the key which becomes current when the
+// key with index current is rotated, and the index of the key which is
+// replaced.
+func nextURLSigKeys(current int) (int, int) {
+	return (current + 1) % tc.URLSigKeyCount, (current + 2) % tc.URLSigKeyCount
+}
+
+// getDSTokenAuth returns the token authentication settings of the Delivery
+// Service with the given ID, along with a user error, system error, and status
+// code.
+func getDSTokenAuth(tx *sql.Tx, dsID int) (tc.DeliveryServiceTokenAuth, error, error, int) {
+	auths, err := readTokenAuth(tx, selectTokenAuthQuery+`WHERE ds.id = $1`, dsID)
+	if err != nil {
+		return tc.DeliveryServiceTokenAuth{}, nil, err, http.StatusInternalServerError
+	}
+	if len(auths) == 0 {
+		return tc.DeliveryServiceTokenAuth{}, fmt.Errorf("Delivery Service #%d has no token authentication settings", dsID), nil, http.StatusNotFound
+	}
+	return auths[0], nil, nil, http.StatusOK
+}
+
+// readTokenAuth reads the rows of the given query, which must select the
+// columns of selectTokenAuthQuery.
+func readTokenAuth(tx *sql.Tx, query string, args ...interface{}) ([]tc.DeliveryServiceTokenAuth, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, errors.New("querying Delivery Service token authentication settings: " + err.Error())
+	}
+	defer log.Close(rows, "closing Delivery Service token authentication rows")
+
+	auths := []tc.DeliveryServiceTokenAuth{}
+	for rows.Next() {
+		var auth tc.DeliveryServiceTokenAuth
+		if err := rows.Scan(&auth.DeliveryServiceID, &auth.XMLID, &auth.Algorithm, &auth.KeyRotationDays, &auth.ClockSkew, pq.Array(&auth.ExemptPaths), &auth.CurrentKey, &auth.LastKeyRotation, &auth.LastUpdated); err != nil {
+			return nil, errors.New("scanning Delivery Service token authentication settings: " + err.Error())
+		}
+		if auth.ExemptPaths == nil {
+			auth.ExemptPaths = []string{}
+		}
+		auth.SetNextKeyRotation()
+		auths = append(auths, auth)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over Delivery Service token authentication settings: " + err.Error())
+	}
+	return auths, nil
+}
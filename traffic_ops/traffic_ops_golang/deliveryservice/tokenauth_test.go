@@ -0,0 +1,82 @@
+package deliveryservice
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestReadTokenAuth(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	rotated := time.Date(2022, 5, 18, 12, 0, 0, 0, time.UTC)
+	updated := rotated.Add(-time.Hour)
+
+	mock.ExpectBegin()
+	rows := sqlmock.NewRows([]string{"id", "xml_id", "algorithm", "key_rotation_days", "clock_skew", "exempt_paths", "current_key", "last_key_rotation", "last_updated"}).
+		AddRow(1, "ds1", "HMAC-SHA1", 7, 30, "{/health,/robots.txt}", 3, rotated, updated).
+		AddRow(2, "ds2", "HMAC-MD5", 0, 0, "{}", 0, nil, updated)
+	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	tx := db.MustBegin().Tx
+	all, err := readTokenAuth(tx, selectTokenAuthQuery)
+	if err != nil {
+		t.Fatalf("unexpected error reading token authentication settings: %v", err)
+	}
+	tx.Commit()
+
+	if len(all) != 2 {
+		t.Fatalf("expected settings of 2 delivery services, actual: %d", len(all))
+	}
+	if all[0].XMLID != "ds1" || all[0].CurrentKey != 3 || len(all[0].ExemptPaths) != 2 || all[0].ExemptPaths[1] != "/robots.txt" {
+		t.Errorf("expected settings of 'ds1' with key 3 and 2 exempt paths, actual: %+v", all[0])
+	}
+	if expected := rotated.AddDate(0, 0, 7); all[0].NextKeyRotation == nil || !all[0].NextKeyRotation.Equal(expected) {
+		t.Errorf("expected next key rotation of 'ds1' to be %v, actual: %v", expected, all[0].NextKeyRotation)
+	}
+	if all[1].ExemptPaths == nil || len(all[1].ExemptPaths) != 0 {
+		t.Errorf("expected empty, non-nil exempt paths of 'ds2', actual: %#v", all[1].ExemptPaths)
+	}
+	if all[1].NextKeyRotation != nil {
+		t.Errorf("expected no next key rotation of 'ds2', actual: %v", all[1].NextKeyRotation)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestNextURLSigKeys(t *testing.T) {
+	for current, expected := range map[int][2]int{0: {1, 2}, 7: {8, 9}, 14: {15, 0}, 15: {0, 1}} {
+		next, replaced := nextURLSigKeys(current)
+		if next != expected[0] || replaced != expected[1] {
+			t.Errorf("expected rotating key%d to make key%d current and replace key%d, actual: key%d and key%d", current, expected[0], expected[1], next, replaced)
+		}
+	}
+}
@@ -0,0 +1,330 @@
+package deliveryservice
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+// selectTransformScriptsQuery selects the transformation scripts of Delivery
+// Services, without their content.
+const selectTransformScriptsQuery = `
+SELECT ds.id, ds.xml_id, MAX(dts.last_updated) AS last_updated,
+	json_agg(json_build_object(
+		'script', s.name,
+		'language', s.language,
+		'version', dts.version,
+		'rolloutVersion', dts.rollout_version,
+		'rolloutPercent', dts.rollout_percent
+	) ORDER BY dts.position) AS scripts
+FROM deliveryservice_transform_script AS dts
+JOIN transform_script AS s ON s.id = dts.script
+JOIN deliveryservice AS ds ON ds.id = dts.deliveryservice
+`
+
+// selectTransformScriptContentsQuery selects the transformation scripts of
+// Delivery Services, with the content of their versions.
+const selectTransformScriptContentsQuery = `
+SELECT ds.id, ds.xml_id, MAX(dts.last_updated) AS last_updated,
+	json_agg(json_build_object(
+		'script', s.name,
+		'language', s.language,
+		'version', dts.version,
+		'rolloutVersion', dts.rollout_version,
+		'rolloutPercent', dts.rollout_percent,
+		'content', v.content,
+		'rolloutContent', rv.content
+	) ORDER BY dts.position) AS scripts
+FROM deliveryservice_transform_script AS dts
+JOIN transform_script AS s ON s.id = dts.script
+JOIN transform_script_version AS v ON v.script = dts.script AND v.version = dts.version
+LEFT JOIN transform_script_version AS rv ON rv.script = dts.script AND rv.version = dts.rollout_version
+JOIN deliveryservice AS ds ON ds.id = dts.deliveryservice
+`
+
+const groupTransformScriptsQuery = `
+GROUP BY ds.id, ds.xml_id
+ORDER BY ds.xml_id
+`
+
+// selectTransformScriptVersionsQuery returns the IDs and latest versions of
+// the transformation scripts with the given names. Versions are never deleted
+// individually, so every version up to the latest exists.
+const selectTransformScriptVersionsQuery = `
+SELECT s.name, s.id, COALESCE(MAX(v.version), 0)
+FROM transform_script AS s
+LEFT JOIN transform_script_version AS v ON v.script = s.id
+WHERE s.name = ANY($1)
+GROUP BY s.id
+`
+
+const deleteTransformScriptsQuery = `
+DELETE FROM deliveryservice_transform_script
+WHERE deliveryservice = $1
+`
+
+const insertTransformScriptQuery = `
+INSERT INTO deliveryservice_transform_script (deliveryservice, script, position, version, rollout_version, rollout_percent)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+// GetTransformScripts is the handler for GET requests to
+// /deliveryservices/{{ID}}/transform-scripts.
+func GetTransformScripts(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	userErr, sysErr, errCode = tenant.CheckID(tx, inf.User, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	scripts, userErr, sysErr, errCode := getDSTransformScripts(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	api.WriteResp(w, r, scripts)
+}
+
+// GetCDNTransformScripts is the handler for GET requests to
+// /deliveryservices/transform-scripts, which returns the transformation
+// scripts of every Delivery Service with any, with the content of the versions
+// they run, optionally only those in the CDN named by the 'cdn' query
+// parameter. It exists so that cache configuration generation doesn't need a
+// request per Delivery Service.
+func GetCDNTransformScripts(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	tenants, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting accessible tenants for user: %w", err))
+		return
+	}
+
+	query := selectTransformScriptContentsQuery + `WHERE ds.tenant_id = ANY($1)`
+	args := []interface{}{pq.Array(tenants)}
+	if cdn, ok := inf.Params["cdn"]; ok {
+		if _, ok, err := dbhelpers.GetCDNIDFromName(tx, tc.CDNName(cdn)); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("checking CDN existence: %w", err))
+			return
+		} else if !ok {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no such CDN: %s", cdn), nil)
+			return
+		}
+		query += ` AND ds.cdn_id = (SELECT id FROM cdn WHERE name = $2)`
+		args = append(args, cdn)
+	}
+
+	all, err := readTransformScripts(tx, query+groupTransformScriptsQuery, args...)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, all)
+}
+
+// UpdateTransformScripts is the handler for PUT requests to
+// /deliveryservices/{{ID}}/transform-scripts, which replaces all of the
+// transformation scripts attached to a Delivery Service.
+func UpdateTransformScripts(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	dsName, userErr, sysErr, errCode := checkDSModifiable(tx, inf, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	dsType, _, _, err := dbhelpers.GetDeliveryServiceTypeAndCDNName(dsID, tx)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting Delivery Service type: %w", err))
+		return
+	}
+	if !dsType.IsHTTP() && !dsType.IsDNS() {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("transformation scripts are not allowed for '%s' Delivery Services", dsType), nil)
+		return
+	}
+
+	var req tc.DeliveryServiceTransformScriptsRequest
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	scriptIDs, userErr, sysErr, errCode := getTransformScriptIDs(tx, req.Scripts)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	if _, err := tx.Exec(deleteTransformScriptsQuery, dsID); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deleting Delivery Service transformation scripts: "+err.Error()))
+		return
+	}
+	for i, script := range req.Scripts {
+		if _, err := tx.Exec(insertTransformScriptQuery, dsID, scriptIDs[script.Script], i, script.Version, script.RolloutVersion, script.RolloutPercent); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("inserting Delivery Service transformation script '%s': %w", script.Script, err))
+			return
+		}
+	}
+
+	scripts, userErr, sysErr, errCode := getDSTransformScripts(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	msg := fmt.Sprintf("Delivery Service '%s' transformation scripts updated", dsName)
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+string(dsName)+", ID: "+strconv.Itoa(dsID)+", ACTION: Updated transformation scripts", inf.User, tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, msg+"; queue updates on the CDN to apply them", scripts)
+}
+
+// getTransformScriptIDs returns the IDs of the given Delivery Service
+// transformation scripts, by name, along with a user error, system error, and
+// status code. It's a user error for a script or any of its versions not to
+// exist.
+func getTransformScriptIDs(tx *sql.Tx, scripts []tc.DeliveryServiceTransformScript) (map[string]int, error, error, int) {
+	names := make([]string, 0, len(scripts))
+	for _, script := range scripts {
+		names = append(names, script.Script)
+	}
+	rows, err := tx.Query(selectTransformScriptVersionsQuery, pq.Array(names))
+	if err != nil {
+		return nil, nil, errors.New("querying transformation scripts: " + err.Error()), http.StatusInternalServerError
+	}
+	defer log.Close(rows, "closing transformation script rows")
+
+	ids := map[string]int{}
+	latest := map[string]int{}
+	for rows.Next() {
+		var name string
+		var id, version int
+		if err := rows.Scan(&name, &id, &version); err != nil {
+			return nil, nil, errors.New("scanning transformation script: " + err.Error()), http.StatusInternalServerError
+		}
+		ids[name] = id
+		latest[name] = version
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, errors.New("iterating over transformation scripts: " + err.Error()), http.StatusInternalServerError
+	}
+
+	for i, script := range scripts {
+		if _, ok := ids[script.Script]; !ok {
+			return nil, fmt.Errorf("scripts[%d].script: no transformation script exists by name '%s'", i, script.Script), nil, http.StatusBadRequest
+		}
+		if script.Version > latest[script.Script] {
+			return nil, fmt.Errorf("scripts[%d].version: transformation script '%s' has no version %d", i, script.Script, script.Version), nil, http.StatusBadRequest
+		}
+		if script.RolloutVersion != nil && *script.RolloutVersion > latest[script.Script] {
+			return nil, fmt.Errorf("scripts[%d].rolloutVersion: transformation script '%s' has no version %d", i, script.Script, *script.RolloutVersion), nil, http.StatusBadRequest
+		}
+	}
+	return ids, nil, nil, http.StatusOK
+}
+
+// getDSTransformScripts returns the transformation scripts of the Delivery
+// Service with the given ID, without their content, along with a user error,
+// system error, and status code.
+func getDSTransformScripts(tx *sql.Tx, dsID int) (tc.DeliveryServiceTransformScripts, error, error, int) {
+	dsName, _, ok, err := dbhelpers.GetDSNameAndCDNFromID(tx, dsID)
+	if err != nil {
+		return tc.DeliveryServiceTransformScripts{}, nil, errors.New("getting Delivery Service name: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return tc.DeliveryServiceTransformScripts{}, fmt.Errorf("no Delivery Service exists by ID '%d'", dsID), nil, http.StatusNotFound
+	}
+
+	all, err := readTransformScripts(tx, selectTransformScriptsQuery+`WHERE ds.id = $1`+groupTransformScriptsQuery, dsID)
+	if err != nil {
+		return tc.DeliveryServiceTransformScripts{}, nil, err, http.StatusInternalServerError
+	}
+	if len(all) == 0 {
+		return tc.DeliveryServiceTransformScripts{
+			DeliveryServiceID: dsID,
+			XMLID:             string(dsName),
+			Scripts:           []tc.DeliveryServiceTransformScript{},
+		}, nil, nil, http.StatusOK
+	}
+	return all[0], nil, nil, http.StatusOK
+}
+
+// readTransformScripts reads the transformation scripts selected by the given
+// query, which must select the columns of selectTransformScriptsQuery.
+func readTransformScripts(tx *sql.Tx, query string, args ...interface{}) ([]tc.DeliveryServiceTransformScripts, error) {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		return nil, errors.New("querying Delivery Service transformation scripts: " + err.Error())
+	}
+	defer log.Close(rows, "closing Delivery Service transformation scripts rows")
+
+	all := []tc.DeliveryServiceTransformScripts{}
+	for rows.Next() {
+		var dsScripts tc.DeliveryServiceTransformScripts
+		var lastUpdated time.Time
+		var scripts []byte
+		if err := rows.Scan(&dsScripts.DeliveryServiceID, &dsScripts.XMLID, &lastUpdated, &scripts); err != nil {
+			return nil, errors.New("scanning Delivery Service transformation scripts: " + err.Error())
+		}
+		if err := json.Unmarshal(scripts, &dsScripts.Scripts); err != nil {
+			return nil, fmt.Errorf("decoding transformation scripts of Delivery Service '%s': %w", dsScripts.XMLID, err)
+		}
+		dsScripts.LastUpdated = &lastUpdated
+		all = append(all, dsScripts)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over Delivery Service transformation scripts: " + err.Error())
+	}
+	return all, nil
+}
@@ -0,0 +1,74 @@
+package deliveryservice
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestReadTransformScripts(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	lastUpdated := time.Date(2022, 7, 5, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	rows := sqlmock.NewRows([]string{"id", "xml_id", "last_updated", "scripts"}).
+		AddRow(1, "ds1", lastUpdated, []byte(`[{"script":"cors","language":"lua","version":2,"rolloutVersion":null,"rolloutPercent":0,"content":"return 0","rolloutContent":null},{"script":"auth","language":"lua","version":1,"rolloutVersion":3,"rolloutPercent":25,"content":"return 1","rolloutContent":"return 3"}]`))
+	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	tx := db.MustBegin().Tx
+	all, err := readTransformScripts(tx, selectTransformScriptContentsQuery+groupTransformScriptsQuery)
+	if err != nil {
+		t.Fatalf("unexpected error reading transformation scripts: %v", err)
+	}
+	tx.Commit()
+
+	if len(all) != 1 {
+		t.Fatalf("expected transformation scripts of 1 delivery service, actual: %d", len(all))
+	}
+	ds1 := all[0]
+	if ds1.XMLID != "ds1" || len(ds1.Scripts) != 2 {
+		t.Fatalf("expected 2 transformation scripts of delivery service 'ds1', actual: %+v", ds1)
+	}
+	cors := ds1.Scripts[0]
+	if cors.Script != "cors" || cors.Version != 2 || cors.RolloutVersion != nil || cors.Content != "return 0" || cors.RolloutContent != "" {
+		t.Errorf("expected script 'cors' to run version 2 without a rollout, actual: %+v", cors)
+	}
+	auth := ds1.Scripts[1]
+	if auth.Script != "auth" || auth.RolloutVersion == nil || *auth.RolloutVersion != 3 || auth.RolloutPercent != 25 || auth.RolloutContent != "return 3" {
+		t.Errorf("expected script 'auth' to roll out version 3 to 25%%, actual: %+v", auth)
+	}
+	if ds1.LastUpdated == nil || !ds1.LastUpdated.Equal(lastUpdated) {
+		t.Errorf("expected lastUpdated %v, actual: %v", lastUpdated, ds1.LastUpdated)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -289,25 +289,11 @@ func GenerateURLKeys(w http.ResponseWriter, r *http.Request) {
 
 // GenerateURLSigKeys generates new URL sig keys.
 func GenerateURLSigKeys() (tc.URLSigKeys, error) {
-	chars := `abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_`
-	numKeys := 16
-	numChars := 32
 	keys := map[string]string{}
-	for i := 0; i < numKeys; i++ {
-		v := ""
-		for i := 0; i < numChars; i++ {
-			bi, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
-			if err != nil {
-				return nil, errors.New("generating crypto rand int: " + err.Error())
-			}
-			if !bi.IsInt64() {
-				return nil, fmt.Errorf("crypto rand int returned non-int64")
-			}
-			i := bi.Int64()
-			if i >= int64(len(chars)) {
-				return nil, fmt.Errorf("crypto rand int returned a number larger than requested")
-			}
-			v += string(chars[int(i)])
+	for i := 0; i < tc.URLSigKeyCount; i++ {
+		v, err := generateURLSigKey()
+		if err != nil {
+			return nil, err
 		}
 		key := "key" + strconv.Itoa(i)
 		keys[key] = v
@@ -315,6 +301,28 @@ func GenerateURLSigKeys() (tc.URLSigKeys, error) {
 	return keys, nil
 }
 
+// generateURLSigKey generates the value of a single new URL sig key.
+func generateURLSigKey() (string, error) {
+	chars := `abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_`
+	numChars := 32
+	v := ""
+	for i := 0; i < numChars; i++ {
+		bi, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+		if err != nil {
+			return "", errors.New("generating crypto rand int: " + err.Error())
+		}
+		if !bi.IsInt64() {
+			return "", fmt.Errorf("crypto rand int returned non-int64")
+		}
+		i := bi.Int64()
+		if i >= int64(len(chars)) {
+			return "", fmt.Errorf("crypto rand int returned a number larger than requested")
+		}
+		v += string(chars[int(i)])
+	}
+	return v, nil
+}
+
 // DeleteURLKeysByID deletes the URL sig keys for the delivery service identified by the id in the path parameter.
 func DeleteURLKeysByID(w http.ResponseWriter, r *http.Request) {
 	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
@@ -87,6 +87,11 @@ func Create(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
 		return
 	}
+	// The address was validated by Parse; it's stored in its canonical form
+	// so that the same address is always stored the same way.
+	if addr, err := tc.CanonicalIPOrCIDR(*fr.IPAddress); err == nil {
+		fr.IPAddress = &addr
+	}
 
 	err := tx.QueryRow(insertFederationResolverQuery, fr.IPAddress, fr.TypeID).Scan(&fr.ID, &fr.IPAddress, &fr.Type, &fr.TypeID)
 	if err != nil {
@@ -29,6 +29,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-rfc"
@@ -104,7 +105,10 @@ INSERT INTO job (
 	entered_time,
 	job_user,
 	job_deliveryservice,
-	invalidation_type)
+	invalidation_type,
+	serve_stale,
+	ttl_override_minutes,
+	surrogate_keys)
 VALUES (
 	$1,
 	(
@@ -117,7 +121,10 @@ VALUES (
 	$5,
 	$6,
 	$7,
-	$8
+	$8,
+	$9,
+	$10,
+	$11
 )
 RETURNING
 	id,
@@ -132,7 +139,10 @@ RETURNING
 		WHERE deliveryservice.id=job_deliveryservice) AS deliveryServiceXML,
 	ttl_hr as ttlHrs,
 	invalidation_type as invalidationType,
-	start_time as startTime
+	start_time as startTime,
+	serve_stale,
+	ttl_override_minutes,
+	surrogate_keys
 `
 
 const queueUpdateOrRevalQuery = `
@@ -189,8 +199,11 @@ UPDATE job
 SET asset_url=$1,
 	ttl_hr=$2,
 	start_time=$3,
-	invalidation_type=$4
-WHERE job.id=$5
+	invalidation_type=$4,
+	serve_stale=$5,
+	ttl_override_minutes=$6,
+	surrogate_keys=$7
+WHERE job.id=$8
 RETURNING asset_url,
 	(
 		SELECT tm_user.username
@@ -205,7 +218,10 @@ RETURNING asset_url,
 	job.id,
 	ttl_hr,
 	start_time,
-	invalidation_type
+	invalidation_type,
+	serve_stale,
+	ttl_override_minutes,
+	surrogate_keys
 `
 
 // Deprecated, only to be used with versions below 4.0
@@ -237,6 +253,9 @@ SELECT job.id AS id,
 	job.ttl_hr AS ttlhrs,
 	job.start_time AS start_time,
 	job.invalidation_type as invalidationType,
+	job.serve_stale,
+	job.ttl_override_minutes,
+	job.surrogate_keys,
 	origin.protocol || '://' || origin.fqdn || rtrim(concat(':', origin.port), ':') AS OFQDN
 FROM job
 INNER JOIN origin ON origin.deliveryservice=job.job_deliveryservice AND origin.is_primary
@@ -287,7 +306,10 @@ RETURNING
 	) AS deliveryservice,
 	ttl_hr,
 	job.invalidation_type,
-	job.start_time
+	job.start_time,
+	job.serve_stale,
+	job.ttl_override_minutes,
+	job.surrogate_keys
 `
 
 type apiResponse struct {
@@ -296,8 +318,18 @@ type apiResponse struct {
 }
 
 type apiResponseV4 struct {
-	Alerts   []tc.Alert           `json:"alerts,omitempty"`
-	Response tc.InvalidationJobV4 `json:"response,omitempty"`
+	Alerts   []tc.Alert  `json:"alerts,omitempty"`
+	Response interface{} `json:"response,omitempty"`
+}
+
+// jobForVersion returns the representation of a content invalidation job in
+// the given version of the API: without the fields added in API 4.1 for
+// version 4.0.
+func jobForVersion(job tc.InvalidationJobV4, version *api.Version) interface{} {
+	if version != nil && version.Major == 4 && version.Minor == 0 {
+		return tc.InvalidationJobV41(job).Downgrade()
+	}
+	return job
 }
 
 func selectMaxLastUpdatedQuery(where string) string {
@@ -323,6 +355,11 @@ JOIN tm_user u ON job.job_user = u.id
 JOIN deliveryservice ds ON job.job_deliveryservice = ds.id
 `
 
+// withoutSurrogateKeys restricts a read of content invalidation jobs to
+// those that aren't surrogate key jobs, for API versions that can't represent
+// them.
+const withoutSurrogateKeys = ` AND job.surrogate_keys IS NULL `
+
 // Almost the same as readQuery, but returns appropriate values for API 4.0+
 const readQueryV4 = `
 SELECT job.id,
@@ -331,7 +368,10 @@ SELECT job.id,
 	ds.xml_id,
 	ttl_hr,
 	invalidation_type,
-	start_time
+	start_time,
+	serve_stale,
+	ttl_override_minutes,
+	surrogate_keys
 FROM job
 JOIN tm_user u ON job.job_user = u.id
 JOIN deliveryservice ds ON job.job_deliveryservice = ds.id
@@ -357,6 +397,10 @@ func (job *InvalidationJobV4) Read(h http.Header, useIMS bool) ([]interface{}, e
 	if len(errs) > 0 {
 		return nil, util.JoinErrs(errs), nil, http.StatusBadRequest, nil
 	}
+	where, orderBy, pagination, page, err := job.APIInfo().BuildCursorPagination(queryParamsToSQLCols, where, orderBy, pagination, queryValues)
+	if err != nil {
+		return nil, err, nil, http.StatusBadRequest, nil
+	}
 
 	accessibleTenants, err := tenant.GetUserTenantIDListTx(job.APIInfo().Tx.Tx, job.APIInfo().User.TenantID)
 	if err != nil {
@@ -380,6 +424,11 @@ func (job *InvalidationJobV4) Read(h http.Header, useIMS bool) ([]interface{}, e
                                                                        '90'))
                                                        || ' days' AS INTERVAL) `
 	}
+	if version := job.APIInfo().Version; version != nil && version.Major == 4 && version.Minor == 0 {
+		// Clients of API version 4.0 would mistake surrogate key jobs for
+		// jobs that invalidate all of a Delivery Service's content.
+		cdn += withoutSurrogateKeys
+	}
 	if len(where) > 0 {
 		where += " AND ds.tenant_id = ANY(:tenants) " + maxDays + cdn
 	} else {
@@ -409,6 +458,7 @@ func (job *InvalidationJobV4) Read(h http.Header, useIMS bool) ([]interface{}, e
 	}
 	defer rows.Close()
 
+	version := job.APIInfo().Version
 	for rows.Next() {
 		job := tc.InvalidationJobV4{}
 		if err := rows.Scan(&job.ID,
@@ -417,16 +467,20 @@ func (job *InvalidationJobV4) Read(h http.Header, useIMS bool) ([]interface{}, e
 			&job.DeliveryService,
 			&job.TTLHours,
 			&job.InvalidationType,
-			&job.StartTime); err != nil {
+			&job.StartTime,
+			&job.ServeStale,
+			&job.TTLOverrideMinutes,
+			pq.Array(&job.SurrogateKeys)); err != nil {
 			return nil, nil, fmt.Errorf("parsing db response: %v", err), http.StatusInternalServerError, nil
 		}
 
-		returnable = append(returnable, job)
+		returnable = append(returnable, jobForVersion(job, version))
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, nil, fmt.Errorf("Parsing db responses: %v", err), http.StatusInternalServerError, nil
 	}
+	job.APIInfo().Pagination = dbhelpers.CursorPagination(page, returnable)
 
 	return returnable, nil, nil, http.StatusOK, &maxTime
 }
@@ -477,9 +531,9 @@ func (job *InvalidationJob) Read(h http.Header, useIMS bool) ([]interface{}, err
                                                        || ' days' AS INTERVAL) `
 	}
 	if len(where) > 0 {
-		where += " AND ds.tenant_id = ANY(:tenants) " + maxDays + cdn
+		where += " AND ds.tenant_id = ANY(:tenants) " + maxDays + cdn + withoutSurrogateKeys
 	} else {
-		where = dbhelpers.BaseWhere + " ds.tenant_id = ANY(:tenants) " + maxDays + cdn
+		where = dbhelpers.BaseWhere + " ds.tenant_id = ANY(:tenants) " + maxDays + cdn + withoutSurrogateKeys
 	}
 	queryValues["tenants"] = pq.Array(accessibleTenants)
 
@@ -603,7 +657,10 @@ func CreateV40(w http.ResponseWriter, r *http.Request) {
 		time.Now(),
 		inf.User.ID,
 		dsid,
-		job.InvalidationType) // Defaults for all api versions below 4.0
+		job.InvalidationType, // Defaults for all api versions below 4.0
+		job.ServeStale,
+		job.TTLOverrideMinutes,
+		surrogateKeysArray(job.SurrogateKeys))
 
 	result := tc.InvalidationJobV4{}
 	err = row.Scan(
@@ -613,7 +670,10 @@ func CreateV40(w http.ResponseWriter, r *http.Request) {
 		&result.DeliveryService,
 		&result.TTLHours,
 		&result.InvalidationType,
-		&result.StartTime)
+		&result.StartTime,
+		&result.ServeStale,
+		&result.TTLOverrideMinutes,
+		pq.Array(&result.SurrogateKeys))
 	if err != nil {
 		userErr, sysErr, errCode = api.ParseDBError(err)
 		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
@@ -625,10 +685,18 @@ func CreateV40(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	conflicts := tc.ValidateJobUniqueness(inf.Tx.Tx, uint(dsid), result.StartTime, result.AssetURL, result.TTLHours)
+	var conflicts []string
+	if len(result.SurrogateKeys) > 0 {
+		if err := queueSurrogateKeyUpdates(uint(dsid), inf.Tx.Tx); err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, fmt.Errorf("queueing updates for surrogate key purging: %v", err))
+			return
+		}
+	} else {
+		conflicts = tc.ValidateJobUniqueness(inf.Tx.Tx, uint(dsid), result.StartTime, result.AssetURL, result.TTLHours)
+	}
 	response := apiResponseV4{
 		make([]tc.Alert, len(conflicts)+1),
-		result,
+		jobForVersion(result, inf.Version),
 	}
 	for i, conflict := range conflicts {
 		response.Alerts[i] = tc.Alert{
@@ -639,9 +707,9 @@ func CreateV40(w http.ResponseWriter, r *http.Request) {
 	response.Alerts[len(conflicts)] = tc.Alert{
 		Text: fmt.Sprintf("Invalidation (%s) request created for %v, start:%v end %v",
 			result.InvalidationType,
-			result.AssetURL,
+			jobTarget(result),
 			result.StartTime,
-			result.StartTime.Add(time.Hour*time.Duration(job.TTLHours))),
+			result.StartTime.Add(jobTTL(result))),
 		Level: tc.SuccessLevel.String(),
 	}
 	resp, err := json.Marshal(response)
@@ -674,7 +742,7 @@ func CreateV40(w http.ResponseWriter, r *http.Request) {
 		duplicate,
 		result.ID,
 		result.DeliveryService,
-		result.AssetURL,
+		jobTarget(result),
 		result.TTLHours,
 		result.InvalidationType,
 	)
@@ -858,6 +926,9 @@ func UpdateV40(w http.ResponseWriter, r *http.Request) {
 		&job.TTLHours,
 		&job.StartTime,
 		&job.InvalidationType,
+		&job.ServeStale,
+		&job.TTLOverrideMinutes,
+		pq.Array(&job.SurrogateKeys),
 		&oFQDN)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -904,6 +975,13 @@ func UpdateV40(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if inf.Version != nil && inf.Version.Major == 4 && inf.Version.Minor == 0 {
+		// These can't be set through API version 4.0, so they're left alone.
+		input.ServeStale = job.ServeStale
+		input.TTLOverrideMinutes = job.TTLOverrideMinutes
+		input.SurrogateKeys = job.SurrogateKeys
+	}
+
 	if err := validateInvalidationJobV4(input); err != nil {
 		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, err, nil)
 		return
@@ -916,6 +994,24 @@ func UpdateV40(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if len(input.SurrogateKeys) > 0 {
+		if input.AssetURL != oFQDN {
+			userErr = fmt.Errorf("The asset URL of a surrogate key job must be the Delivery Service origin URL: %s", oFQDN)
+			errCode = http.StatusBadRequest
+			api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, nil)
+			return
+		}
+		if enabled, err := surrogateKeyPurgeEnabled(job.DeliveryService, inf.Tx.Tx); err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+			return
+		} else if !enabled {
+			userErr = fmt.Errorf("Delivery Service '%s' does not have surrogate key purging enabled", job.DeliveryService)
+			errCode = http.StatusBadRequest
+			api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, nil)
+			return
+		}
+	}
+
 	if job.StartTime.Before(time.Now()) {
 		userErr = errors.New("Cannot modify a job that has already started!")
 		errCode = http.StatusMethodNotAllowed
@@ -959,6 +1055,9 @@ func UpdateV40(w http.ResponseWriter, r *http.Request) {
 		input.TTLHours,
 		input.StartTime,
 		input.InvalidationType,
+		input.ServeStale,
+		input.TTLOverrideMinutes,
+		surrogateKeysArray(input.SurrogateKeys),
 		job.ID)
 	err = row.Scan(&job.AssetURL,
 		&job.CreatedBy,
@@ -966,7 +1065,10 @@ func UpdateV40(w http.ResponseWriter, r *http.Request) {
 		&job.ID,
 		&job.TTLHours,
 		&job.StartTime,
-		&job.InvalidationType)
+		&job.InvalidationType,
+		&job.ServeStale,
+		&job.TTLOverrideMinutes,
+		pq.Array(&job.SurrogateKeys))
 	if err != nil {
 		sysErr = fmt.Errorf("Updating a job: %v", err)
 		errCode = http.StatusInternalServerError
@@ -979,10 +1081,18 @@ func UpdateV40(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	conflicts := tc.ValidateJobUniqueness(inf.Tx.Tx, dsid, input.StartTime, input.AssetURL, input.TTLHours)
+	var conflicts []string
+	if len(job.SurrogateKeys) > 0 {
+		if err := queueSurrogateKeyUpdates(dsid, inf.Tx.Tx); err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, fmt.Errorf("Queueing updates for surrogate key purging: %v", err))
+			return
+		}
+	} else {
+		conflicts = tc.ValidateJobUniqueness(inf.Tx.Tx, dsid, input.StartTime, input.AssetURL, input.TTLHours)
+	}
 	response := apiResponseV4{
 		make([]tc.Alert, len(conflicts)+1),
-		job,
+		jobForVersion(job, inf.Version),
 	}
 	for i, conflict := range conflicts {
 		response.Alerts[i] = tc.Alert{
@@ -992,9 +1102,9 @@ func UpdateV40(w http.ResponseWriter, r *http.Request) {
 	}
 	response.Alerts[len(conflicts)] = tc.Alert{
 		Text: fmt.Sprintf("Invalidation request created for %s, start: %v end: %v invalidation type: %v",
-			job.AssetURL,
+			jobTarget(job),
 			job.StartTime,
-			job.StartTime.Add(time.Hour*time.Duration(job.TTLHours)),
+			job.StartTime.Add(jobTTL(job)),
 			job.InvalidationType),
 		Level: tc.SuccessLevel.String(),
 	}
@@ -1014,7 +1124,7 @@ func UpdateV40(w http.ResponseWriter, r *http.Request) {
 		api.Updated,
 		input.ID,
 		input.DeliveryService,
-		input.AssetURL,
+		jobTarget(input),
 		input.TTLHours,
 		input.InvalidationType,
 	)
@@ -1260,7 +1370,10 @@ func DeleteV40(w http.ResponseWriter, r *http.Request) {
 		&result.DeliveryService,
 		&result.TTLHours,
 		&result.InvalidationType,
-		&result.StartTime)
+		&result.StartTime,
+		&result.ServeStale,
+		&result.TTLOverrideMinutes,
+		pq.Array(&result.SurrogateKeys))
 	if err != nil {
 		sysErr = fmt.Errorf("deleting job #%s: %v", inf.Params["id"], err)
 		errCode = http.StatusInternalServerError
@@ -1275,10 +1388,19 @@ func DeleteV40(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if len(result.SurrogateKeys) > 0 {
+		if err = queueSurrogateKeyUpdates(dsid, inf.Tx.Tx); err != nil {
+			sysErr = fmt.Errorf("queueing updates after deleting surrogate key job #%s: %v", inf.Params["id"], err)
+			errCode = http.StatusInternalServerError
+			api.HandleErr(w, r, inf.Tx.Tx, errCode, nil, sysErr)
+			return
+		}
+	}
+
 	response := apiResponseV4{[]tc.Alert{
 		{Text: "Content invalidation job was deleted", Level: tc.SuccessLevel.String()},
 	},
-		result,
+		jobForVersion(result, inf.Version),
 	}
 	resp, err := json.Marshal(response)
 	if err != nil {
@@ -1408,11 +1530,20 @@ func Delete(w http.ResponseWriter, r *http.Request) {
 // are ultimately returned to the user
 func validateJobCreateV4(job tc.InvalidationJobCreateV4, tx *sql.Tx) error {
 	errs := []string{}
+	regexRules := []validation.Rule{
+		validation.Required,
+		validation.NewStringRule(func(s string) bool {
+			return strings.HasPrefix(s, `\/`) || strings.HasPrefix(s, "/")
+		}, `must start with '/' (or '\/')`),
+	}
+	if len(job.SurrogateKeys) > 0 {
+		regexRules = []validation.Rule{validation.NewStringRule(func(s string) bool {
+			return s == ""
+		}, "must be empty for surrogate key jobs")}
+	}
 	err := validation.ValidateStruct(&job,
 		validation.Field(&job.DeliveryService, validation.Required),
-		validation.Field(&job.Regex, validation.Required, validation.NewStringRule(func(s string) bool {
-			return strings.HasPrefix(s, `\/`) || strings.HasPrefix(s, "/")
-		}, `must start with '/' (or '\/')`)),
+		validation.Field(&job.Regex, regexRules...),
 		validation.Field(&job.StartTime, validation.Required),
 		validation.Field(&job.TTLHours, validation.Required),
 		validation.Field(&job.InvalidationType, validation.Required, validation.NewStringRule(func(s string) bool {
@@ -1443,10 +1574,32 @@ func validateJobCreateV4(job tc.InvalidationJobCreateV4, tx *sql.Tx) error {
 		}
 	}
 
+	if job.TTLOverrideMinutes != nil {
+		if valid, err := validateTTLOverrideMinutes(*job.TTLOverrideMinutes, tx); !valid {
+			if err != nil {
+				errs = append(errs, "TTL override is invalid: "+err.Error())
+			} else {
+				errs = append(errs, "TTL override is invalid")
+			}
+		}
+	}
+
 	if job.InvalidationType == tc.REFETCH && !refetchAllowed(tx) {
 		errs = append(errs, "InvalidationType is invalid")
 	}
 
+	if len(job.SurrogateKeys) > 0 {
+		if err := validateSurrogateKeys(job.SurrogateKeys); err != nil {
+			errs = append(errs, err.Error())
+		}
+		if enabled, err := surrogateKeyPurgeEnabled(job.DeliveryService, tx); err != nil {
+			log.Errorf("checking whether Delivery Service '%s' has surrogate key purging enabled: %v", job.DeliveryService, err)
+			errs = append(errs, "surrogateKeys: could not check whether the Delivery Service has surrogate key purging enabled")
+		} else if !enabled {
+			errs = append(errs, "surrogateKeys: the Delivery Service does not have surrogate key purging enabled")
+		}
+	}
+
 	if len(errs) > 0 {
 		return errors.New(strings.Join(errs, ", "))
 	}
@@ -1482,6 +1635,16 @@ func validateInvalidationJobV4(job tc.InvalidationJobV4) error {
 		errs = append(errs, "startTime: cannot be in the past")
 	}
 
+	if job.TTLOverrideMinutes != nil && *job.TTLOverrideMinutes < 1 {
+		errs = append(errs, "ttlOverrideMinutes: must be at least 1")
+	}
+
+	if len(job.SurrogateKeys) > 0 {
+		if err := validateSurrogateKeys(job.SurrogateKeys); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
 	if len(errs) > 0 {
 		return errors.New(strings.Join(errs, ", "))
 	}
@@ -1489,6 +1652,110 @@ func validateInvalidationJobV4(job tc.InvalidationJobV4) error {
 	return nil
 }
 
+// jobTTL returns the duration for which a content invalidation job is in
+// effect: its TTL override, if it has one, or else its TTL in hours.
+func jobTTL(job tc.InvalidationJobV4) time.Duration {
+	if job.TTLOverrideMinutes != nil {
+		return time.Duration(*job.TTLOverrideMinutes) * time.Minute
+	}
+	return time.Duration(job.TTLHours) * time.Hour
+}
+
+// jobTarget describes the content a content invalidation job invalidates, for
+// alerts and change logs: its surrogate keys, if it has any, or else its
+// asset URL.
+func jobTarget(job tc.InvalidationJobV4) string {
+	if len(job.SurrogateKeys) > 0 {
+		return "surrogate keys " + strings.Join(job.SurrogateKeys, " ")
+	}
+	return job.AssetURL
+}
+
+// maxSurrogateKeys is the most surrogate keys a single content invalidation
+// job may purge.
+const maxSurrogateKeys = 100
+
+// validateSurrogateKeys ensures the supplied surrogate keys can be matched
+// against the space-separated surrogate keys of content.
+func validateSurrogateKeys(keys []string) error {
+	if len(keys) > maxSurrogateKeys {
+		return fmt.Errorf("surrogateKeys: cannot have more than %d keys", maxSurrogateKeys)
+	}
+	for _, key := range keys {
+		if key == "" || strings.IndexFunc(key, unicode.IsSpace) >= 0 {
+			return fmt.Errorf("surrogateKeys: '%s' is not a valid surrogate key - keys must be non-empty and contain no whitespace", key)
+		}
+	}
+	return nil
+}
+
+// surrogateKeysArray returns the SQL value of a content invalidation job's
+// surrogate keys: NULL for jobs that don't have any.
+func surrogateKeysArray(keys []string) interface{} {
+	if len(keys) == 0 {
+		return nil
+	}
+	return pq.Array(keys)
+}
+
+// surrogateKeyPurgeEnabled returns whether the Delivery Service with the given
+// XMLID has surrogate key purging enabled by its Profile.
+func surrogateKeyPurgeEnabled(xmlID string, tx *sql.Tx) (bool, error) {
+	enabled := false
+	err := tx.QueryRow(surrogateKeyPurgeEnabledQuery, xmlID, tc.SurrogateKeyHeaderParameterName, tc.SurrogateKeyPurgeConfigFile).Scan(&enabled)
+	if err != nil {
+		return false, fmt.Errorf("querying surrogate key Parameter of Delivery Service '%s': %w", xmlID, err)
+	}
+	return enabled, nil
+}
+
+const surrogateKeyPurgeEnabledQuery = `
+SELECT EXISTS(
+	SELECT 1
+	FROM deliveryservice ds
+	JOIN profile_parameter pp ON pp.profile = ds.profile
+	JOIN parameter p ON p.id = pp.parameter
+	WHERE ds.xml_id = $1
+	AND p.name = $2
+	AND p.config_file = $3
+)
+`
+
+// queueSurrogateKeyUpdates queues configuration updates on the Grove cache
+// servers in the CDN of the Delivery Service with the given ID, which purge
+// content by surrogate key.
+func queueSurrogateKeyUpdates(dsID uint, tx *sql.Tx) error {
+	if _, err := tx.Exec(queueSurrogateKeyUpdatesQuery, dsID, tc.GroveProfileType); err != nil {
+		return fmt.Errorf("queueing updates on Grove servers of Delivery Service #%d: %w", dsID, err)
+	}
+	return nil
+}
+
+const queueSurrogateKeyUpdatesQuery = `
+UPDATE public.server
+SET config_update_time = now()
+WHERE server.cdn_id = (
+		SELECT deliveryservice.cdn_id
+		FROM deliveryservice
+		WHERE deliveryservice.id = $1
+		)
+	AND server.profile IN (
+		SELECT profile.id
+		FROM profile
+		WHERE profile.type = $2
+		)
+`
+
+// validateTTLOverrideMinutes ensures the supplied TTL override is within
+// acceptable limits.
+func validateTTLOverrideMinutes(minutes uint32, tx *sql.Tx) (bool, error) {
+	if minutes < 1 {
+		return false, errors.New("must be at least 1")
+	}
+	hours := (minutes + 59) / 60
+	return validateTLLHours(hours, tx)
+}
+
 // validateTLLHours ensures the supplied TTL hours is within acceptable limits
 func validateTLLHours(ttlHours uint32, tx *sql.Tx) (bool, error) {
 	var maxDays uint
@@ -0,0 +1,89 @@
+package login
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// certificateUsername returns the username of the user who made a request
+// over the connection with the given state: the Common Name of the Subject of
+// its client certificate, which the TLS handshake verified was issued by one
+// of the client certificate authorities.
+func certificateUsername(state *tls.ConnectionState) (string, error) {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return "", errors.New("a client certificate issued by a trusted authority is required")
+	}
+	username := state.VerifiedChains[0][0].Subject.CommonName
+	if username == "" {
+		return "", errors.New("the client certificate has no Subject Common Name")
+	}
+	return username, nil
+}
+
+// CertificateLoginHandler logs in the user named by the client certificate
+// with which the request was made.
+func CertificateLoginHandler(db *sqlx.DB, cfg config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if cfg.ClientCertificateAuthority == "" {
+			api.HandleErr(w, r, nil, http.StatusServiceUnavailable, errors.New("client certificate authorities are not configured"), nil)
+			return
+		}
+		username, err := certificateUsername(r.TLS)
+		if err != nil {
+			api.HandleErr(w, r, nil, http.StatusUnauthorized, err, nil)
+			return
+		}
+
+		dbCtx, cancelTx := context.WithTimeout(r.Context(), time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second)
+		defer cancelTx()
+		userAllowed, err, blockingErr := auth.CheckLocalUserIsAllowed(auth.PasswordForm{Username: username}, db, dbCtx)
+		if blockingErr != nil {
+			api.HandleErr(w, r, nil, http.StatusServiceUnavailable, nil, fmt.Errorf("checking local user: %w", blockingErr))
+			return
+		}
+		if err != nil {
+			log.Errorf("checking local user '%s': %v", username, err)
+		}
+		if !userAllowed {
+			api.HandleErr(w, r, nil, http.StatusUnauthorized, errors.New("Invalid client certificate."), nil)
+			return
+		}
+
+		alerts, ok := startSession(w, r, db, dbCtx, cfg, username)
+		if !ok {
+			return
+		}
+		api.WriteAlerts(w, r, http.StatusOK, alerts)
+	}
+}
@@ -0,0 +1,51 @@
+package login
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+)
+
+func TestCertificateUsername(t *testing.T) {
+	if _, err := certificateUsername(nil); err == nil {
+		t.Error("Expected an error for a request not made over TLS")
+	}
+	if _, err := certificateUsername(&tls.ConnectionState{}); err == nil {
+		t.Error("Expected an error for a request without a verified client certificate")
+	}
+
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "monitor"}}
+	state := &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}
+	username, err := certificateUsername(state)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if username != "monitor" {
+		t.Errorf("Expected username 'monitor', got '%s'", username)
+	}
+
+	cert.Subject.CommonName = ""
+	if _, err := certificateUsername(state); err == nil {
+		t.Error("Expected an error for a client certificate without a Common Name")
+	}
+}
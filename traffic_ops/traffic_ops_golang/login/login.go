@@ -152,71 +152,25 @@ func LoginHandler(db *sqlx.DB, cfg config.Config) http.HandlerFunc {
 					}
 				}
 			}
-			if authenticated {
-				httpCookie := tocookie.GetCookie(form.Username, defaultCookieDuration, cfg.Secrets[0])
-				http.SetCookie(w, httpCookie)
-
-				var jwtToken jwt.Token
-				var jwtSigned []byte
-				jwtBuilder := jwt.NewBuilder()
-
-				emptyConf := config.CdniConf{}
-				if cfg.Cdni != nil && *cfg.Cdni != emptyConf {
-					ucdn, err := auth.GetUserUcdn(form, db, dbCtx)
-					if err != nil {
-						// log but do not error out since this is optional in the JWT for CDNi integration
-						log.Errorf("getting ucdn for user %s: %v", form.Username, err)
-					}
-					jwtBuilder.Claim("iss", ucdn)
-					jwtBuilder.Claim("aud", cfg.Cdni.DCdnId)
-				}
-
-				jwtBuilder.Claim("exp", httpCookie.Expires.Unix())
-				jwtBuilder.Claim(api.MojoCookie, httpCookie.Value)
-				jwtToken, err = jwtBuilder.Build()
+			if authenticated && cfg.MFA != nil {
+				challenge, err := getMFALoginChallenge(db, dbCtx, cfg, form.Username)
 				if err != nil {
-					api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, fmt.Errorf("building token: %s", err))
+					api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, fmt.Errorf("getting second factor login challenge: %w", err))
 					return
 				}
-
-				jwtSigned, err = jwt.Sign(jwtToken, jwa.HS256, []byte(cfg.Secrets[0]))
-				if err != nil {
-					api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, err)
+				if challenge != nil {
+					api.WriteAlertsObj(w, r, http.StatusUnauthorized, tc.CreateAlerts(tc.InfoLevel, "A second authentication factor is required."), *challenge)
 					return
 				}
-
-				http.SetCookie(w, &http.Cookie{
-					Name:     api.AccessToken,
-					Value:    string(jwtSigned),
-					Path:     "/",
-					MaxAge:   httpCookie.MaxAge,
-					Expires:  httpCookie.Expires,
-					HttpOnly: true, // prevents the cookie being accessed by Javascript. DO NOT remove, security vulnerability
-				})
-
-				// If all's well until here, then update last authenticated time
-				tx, txErr := db.BeginTx(dbCtx, nil)
-				if txErr != nil {
-					api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("beginning transaction: %w", txErr))
+			}
+			if authenticated {
+				alerts, ok := startSession(w, r, db, dbCtx, cfg, form.Username)
+				if !ok {
 					return
 				}
-				defer func() {
-					if err := tx.Commit(); err != nil && err != sql.ErrTxDone {
-						log.Errorln("committing transaction: " + err.Error())
-					}
-				}()
-				_, dbErr := tx.Exec(UpdateLoginTimeQuery, form.Username)
-				if dbErr != nil {
-					log.Errorf("unable to update authentication time for a given user: %s\n", dbErr.Error())
-					resp = struct {
-						tc.Alerts
-					}{tc.CreateAlerts(tc.ErrorLevel, "Unable to update authentication time for a given user")}
-				} else {
-					resp = struct {
-						tc.Alerts
-					}{tc.CreateAlerts(tc.SuccessLevel, "Successfully logged in.")}
-				}
-
+				resp = struct {
+					tc.Alerts
+				}{alerts}
 			} else {
 				resp = struct {
 					tc.Alerts
@@ -240,6 +194,69 @@ func LoginHandler(db *sqlx.DB, cfg config.Config) http.HandlerFunc {
 	}
 }
 
+// startSession sets the session cookies of the user with the given username,
+// who has authenticated, and records the time at which they logged in. It
+// returns the alerts of the response, or false if it wrote an error to the
+// client.
+func startSession(w http.ResponseWriter, r *http.Request, db *sqlx.DB, dbCtx context.Context, cfg config.Config, username string) (tc.Alerts, bool) {
+	httpCookie := tocookie.GetCookie(username, defaultCookieDuration, cfg.Secrets[0])
+	http.SetCookie(w, httpCookie)
+
+	jwtBuilder := jwt.NewBuilder()
+
+	emptyConf := config.CdniConf{}
+	if cfg.Cdni != nil && *cfg.Cdni != emptyConf {
+		ucdn, err := auth.GetUserUcdn(auth.PasswordForm{Username: username}, db, dbCtx)
+		if err != nil {
+			// log but do not error out since this is optional in the JWT for CDNi integration
+			log.Errorf("getting ucdn for user %s: %v", username, err)
+		}
+		jwtBuilder.Claim("iss", ucdn)
+		jwtBuilder.Claim("aud", cfg.Cdni.DCdnId)
+	}
+
+	jwtBuilder.Claim("exp", httpCookie.Expires.Unix())
+	jwtBuilder.Claim(api.MojoCookie, httpCookie.Value)
+	jwtToken, err := jwtBuilder.Build()
+	if err != nil {
+		api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, fmt.Errorf("building token: %s", err))
+		return tc.Alerts{}, false
+	}
+
+	jwtSigned, err := jwt.Sign(jwtToken, jwa.HS256, []byte(cfg.Secrets[0]))
+	if err != nil {
+		api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, err)
+		return tc.Alerts{}, false
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     api.AccessToken,
+		Value:    string(jwtSigned),
+		Path:     "/",
+		MaxAge:   httpCookie.MaxAge,
+		Expires:  httpCookie.Expires,
+		HttpOnly: true, // prevents the cookie being accessed by Javascript. DO NOT remove, security vulnerability
+	})
+
+	// If all's well until here, then update last authenticated time
+	tx, txErr := db.BeginTx(dbCtx, nil)
+	if txErr != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("beginning transaction: %w", txErr))
+		return tc.Alerts{}, false
+	}
+	defer func() {
+		if err := tx.Commit(); err != nil && err != sql.ErrTxDone {
+			log.Errorln("committing transaction: " + err.Error())
+		}
+	}()
+	_, dbErr := tx.Exec(UpdateLoginTimeQuery, username)
+	if dbErr != nil {
+		log.Errorf("unable to update authentication time for a given user: %s\n", dbErr.Error())
+		return tc.CreateAlerts(tc.ErrorLevel, "Unable to update authentication time for a given user"), true
+	}
+	return tc.CreateAlerts(tc.SuccessLevel, "Successfully logged in."), true
+}
+
 func TokenLoginHandler(db *sqlx.DB, cfg config.Config) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		defer r.Body.Close()
@@ -0,0 +1,105 @@
+package login
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/mfa"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// getMFALoginChallenge returns the challenge with which the user with the
+// given username must give a second factor to log in, or nil if they don't
+// have one.
+func getMFALoginChallenge(db *sqlx.DB, dbCtx context.Context, cfg config.Config, username string) (*tc.MFALoginChallenge, error) {
+	tx, err := db.BeginTx(dbCtx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() {
+		if err := tx.Commit(); err != nil && err != sql.ErrTxDone {
+			log.Errorln("committing transaction: " + err.Error())
+		}
+	}()
+	return mfa.LoginChallenge(tx, cfg.MFA, cfg.Secrets[0], username)
+}
+
+// MFALoginHandler finishes the login of a user who gave their password to
+// LoginHandler, and was asked for a second authentication factor.
+func MFALoginHandler(db *sqlx.DB, cfg config.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		if cfg.MFA == nil {
+			api.HandleErr(w, r, nil, http.StatusServiceUnavailable, errors.New("second authentication factors are not configured"), nil)
+			return
+		}
+		req := tc.MFALoginRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			api.HandleErr(w, r, nil, http.StatusBadRequest, err, nil)
+			return
+		}
+		if err := req.Validate(); err != nil {
+			api.HandleErr(w, r, nil, http.StatusBadRequest, err, nil)
+			return
+		}
+		dbCtx, cancelTx := context.WithTimeout(r.Context(), time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second)
+		defer cancelTx()
+
+		tx, err := db.BeginTx(dbCtx, nil)
+		if err != nil {
+			api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, fmt.Errorf("beginning transaction: %w", err))
+			return
+		}
+		// failed attempts are recorded, so this is committed even if the second
+		// factor isn't valid
+		username, userErr, sysErr, errCode := mfa.VerifyLogin(tx, cfg.MFA, cfg.Secrets[0], req)
+		if sysErr != nil {
+			tx.Rollback()
+			api.HandleErr(w, r, nil, errCode, userErr, sysErr)
+			return
+		}
+		if err := tx.Commit(); err != nil {
+			api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, fmt.Errorf("committing transaction: %w", err))
+			return
+		}
+		if userErr != nil {
+			api.HandleErr(w, r, nil, errCode, userErr, nil)
+			return
+		}
+
+		alerts, ok := startSession(w, r, db, dbCtx, cfg, username)
+		if !ok {
+			return
+		}
+		api.WriteAlerts(w, r, http.StatusOK, alerts)
+	}
+}
@@ -36,6 +36,7 @@ import "github.com/apache/trafficcontrol/lib/go-tc"
 import "github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
 import "github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
 import "github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+import "github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
 
 type registrationEmailFormatter struct {
 	From         rfc.EmailAddress
@@ -253,6 +254,10 @@ func RegisterUser(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, tx, errCode, nil, sysErr)
 		return
 	}
+	if userErr, sysErr, errCode = checkUserQuota(tx, tenantID, user, exists); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
 	if exists {
 		if user.NewUser == nil || !*user.NewUser {
 			userErr = errors.New("User already exists and has completed registration.")
@@ -297,6 +302,49 @@ func RegisterUser(w http.ResponseWriter, r *http.Request) {
 	api.CreateChangeLogRawTx(api.ApiChange, changeLog, inf.User, tx)
 }
 
+// Invite registers a new user with the given email address, Role, and Tenant
+// and sends them a registration email, like a request to /users/register
+// does, except that it's an error if a user with the email address already
+// exists. It returns a user error, system error, and status code.
+func Invite(inf *api.APIInfo, email rfc.EmailAddress, roleID uint, tenantID uint) (error, error, int) {
+	tx := inf.Tx.Tx
+	user, exists, err := dbhelpers.GetUserByEmail(email.Address.Address, tx)
+	if err != nil {
+		return nil, fmt.Errorf("checking for existing user with email %s: %w", email, err), http.StatusInternalServerError
+	}
+	if exists {
+		return fmt.Errorf("a user with email %s already exists", email.Address.Address), nil, http.StatusConflict
+	}
+	if userErr, sysErr, errCode := checkUserQuota(tx, tenantID, user, exists); userErr != nil || sysErr != nil {
+		return userErr, sysErr, errCode
+	}
+
+	t, err := generateToken()
+	if err != nil {
+		return nil, fmt.Errorf("generating registration token: %w", err), http.StatusInternalServerError
+	}
+	req := tc.UserRegistrationRequest{Email: email, Role: roleID, TenantID: tenantID}
+	if _, _, err := newRegistration(tx, req, t); err != nil {
+		return api.ParseDBError(err)
+	}
+	msg, err := createRegistrationMsg(email, t, tx, inf.Config.ConfigPortal)
+	if err != nil {
+		return nil, fmt.Errorf("creating registration email message: %w", err), http.StatusInternalServerError
+	}
+	log.Debugf("Sending registration email to %s", email)
+	errCode, userErr, sysErr := inf.SendMail(email, msg)
+	return userErr, sysErr, errCode
+}
+
+// checkUserQuota checks that the quota of the Tenant with the given ID leaves
+// room for the registered user, unless they're already in it.
+func checkUserQuota(tx *sql.Tx, tenantID uint, u tc.User, exists bool) (error, error, int) {
+	if exists && u.TenantID != nil && *u.TenantID == int(tenantID) {
+		return nil, nil, http.StatusOK
+	}
+	return tenant.CheckUserQuota(tx, int(tenantID))
+}
+
 func renewRegistration(tx *sql.Tx, req tc.UserRegistrationRequest, t string, u tc.User) (string, string, error) {
 	var role string
 	var tenant string
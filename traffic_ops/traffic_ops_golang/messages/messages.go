@@ -0,0 +1,230 @@
+// Package messages translates the messages of the error-level Alerts that
+// Traffic Ops returns into the languages its clients accept, and lets sites
+// customize those messages.
+//
+// Messages are kept in catalogs - one per language - that map the codes of
+// Alerts (see tc.CreateCodedErrorAlerts) to messages. The code of an Alert
+// for an invalid field is looked up as a whole, e.g.
+// "staticdnsentry.address.invalid_ipv4", and then by its reason alone, e.g.
+// "invalid_ipv4", so that a catalog may give one message for a reason
+// regardless of the object and field. In a message, "{field}" is replaced by
+// the Alert's field, and "{text}" by its original text. Alerts with no code,
+// or with a code for which the catalog has no message, are left as they are.
+package messages
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync/atomic"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+// BuiltinLanguage is the language of the messages that Traffic Ops itself
+// writes, which is available whether or not there's a catalog for it.
+const BuiltinLanguage = "en"
+
+// CatalogExt is the extension of the files of message catalogs, which are
+// named for their languages.
+const CatalogExt = ".json"
+
+// These are replaced in messages by the field and original text of the Alert.
+const (
+	FieldPlaceholder = "{field}"
+	TextPlaceholder  = "{text}"
+)
+
+// languageTagRegexp matches lower-case language tags, per RFC5646§2.1.
+var languageTagRegexp = regexp.MustCompile(`^[a-z]{1,8}(-[a-z0-9]{1,8})*$`)
+
+// Catalog holds the messages of each language.
+type Catalog struct {
+	languages       map[string]map[string]string
+	defaultLanguage string
+}
+
+// theCatalog holds the *Catalog loaded by Load.
+var theCatalog atomic.Value
+
+// Load loads the message catalogs with the given configuration, replacing
+// any that were loaded before. It's safe to call while requests are being
+// served, so that catalogs may be reloaded without a restart.
+func Load(cfg *config.ConfigMessages) error {
+	c, err := NewCatalog(cfg)
+	if err != nil {
+		return err
+	}
+	theCatalog.Store(c)
+	if len(c.languages) > 0 {
+		log.Infof("loaded message catalogs for %d languages, defaulting to '%s'", len(c.languages), c.defaultLanguage)
+	}
+	return nil
+}
+
+// NewCatalog reads the catalogs in the configured catalog directory, and
+// applies the configured overrides on top of them. If cfg is nil, the
+// returned Catalog has no messages.
+func NewCatalog(cfg *config.ConfigMessages) (*Catalog, error) {
+	c := &Catalog{languages: map[string]map[string]string{}, defaultLanguage: BuiltinLanguage}
+	if cfg == nil {
+		return c, nil
+	}
+
+	if cfg.CatalogDir != "" {
+		entries, err := os.ReadDir(cfg.CatalogDir)
+		if err != nil {
+			return nil, fmt.Errorf("reading message catalog directory: %w", err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != CatalogExt {
+				continue
+			}
+			lang := strings.ToLower(strings.TrimSuffix(entry.Name(), CatalogExt))
+			if !languageTagRegexp.MatchString(lang) {
+				return nil, fmt.Errorf("message catalog '%s' isn't named for a language", entry.Name())
+			}
+			bts, err := os.ReadFile(filepath.Join(cfg.CatalogDir, entry.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("reading message catalog '%s': %w", entry.Name(), err)
+			}
+			msgs := map[string]string{}
+			if err := json.Unmarshal(bts, &msgs); err != nil {
+				return nil, fmt.Errorf("parsing message catalog '%s': %w", entry.Name(), err)
+			}
+			c.add(lang, msgs)
+		}
+	}
+
+	for lang, msgs := range cfg.Overrides {
+		lang = strings.ToLower(lang)
+		if !languageTagRegexp.MatchString(lang) {
+			return nil, fmt.Errorf("message overrides language '%s' isn't a language tag", lang)
+		}
+		c.add(lang, msgs)
+	}
+
+	if cfg.DefaultLanguage != "" {
+		lang := strings.ToLower(cfg.DefaultLanguage)
+		if !c.has(lang) {
+			return nil, fmt.Errorf("default language '%s' has no message catalog", cfg.DefaultLanguage)
+		}
+		c.defaultLanguage = lang
+	}
+	return c, nil
+}
+
+// add adds the given messages to those of the given language, replacing any
+// messages of the same codes.
+func (c *Catalog) add(lang string, msgs map[string]string) {
+	if c.languages[lang] == nil {
+		c.languages[lang] = map[string]string{}
+	}
+	for code, msg := range msgs {
+		c.languages[lang][code] = msg
+	}
+}
+
+// has returns whether there are messages in the given language.
+func (c *Catalog) has(lang string) bool {
+	_, ok := c.languages[lang]
+	return ok || lang == BuiltinLanguage
+}
+
+// Negotiate returns the language of the Catalog that best matches the given
+// language ranges, which are in order of preference as returned by
+// rfc.AcceptedLanguages, per the "lookup" scheme of RFC4647§3.4: each range is
+// tried, and then tried again with its last subtag removed until there are no
+// more subtags. If no range matches, the default language is returned.
+func (c *Catalog) Negotiate(ranges []string) string {
+	for _, lang := range ranges {
+		if lang == "*" {
+			return c.defaultLanguage
+		}
+		for {
+			if c.has(lang) {
+				return lang
+			}
+			i := strings.LastIndex(lang, "-")
+			if i < 0 {
+				break
+			}
+			lang = lang[:i]
+		}
+	}
+	return c.defaultLanguage
+}
+
+// Message returns the message for the given Alert in the given language, and
+// whether there is one.
+func (c *Catalog) Message(lang string, alert tc.Alert) (string, bool) {
+	if alert.Code == "" {
+		return "", false
+	}
+	msgs := c.languages[lang]
+	msg, ok := msgs[alert.Code]
+	if !ok && alert.Field != "" {
+		msg, ok = msgs[alert.Code[strings.LastIndex(alert.Code, ".")+1:]]
+	}
+	if !ok {
+		return "", false
+	}
+	return strings.NewReplacer(FieldPlaceholder, alert.Field, TextPlaceholder, alert.Text).Replace(msg), true
+}
+
+// Localize replaces the texts of the given Alerts with their messages in the
+// language that best matches the given language ranges, and returns the
+// language of the messages - which is BuiltinLanguage if none of them were
+// replaced.
+func (c *Catalog) Localize(ranges []string, alerts *tc.Alerts) string {
+	lang := c.Negotiate(ranges)
+	localized := false
+	for i, alert := range alerts.Alerts {
+		if msg, ok := c.Message(lang, alert); ok {
+			alerts.Alerts[i].Text = msg
+			localized = true
+		}
+	}
+	if !localized {
+		return BuiltinLanguage
+	}
+	return lang
+}
+
+// Localize localizes the given Alerts with the loaded catalogs for the
+// languages accepted by the given request, and returns the language of their
+// messages. If no catalogs are loaded, the Alerts are left as they are, and
+// an empty string is returned.
+func Localize(r *http.Request, alerts *tc.Alerts) string {
+	c, _ := theCatalog.Load().(*Catalog)
+	if c == nil || len(c.languages) == 0 {
+		return ""
+	}
+	return c.Localize(rfc.AcceptedLanguages(r.Header), alerts)
+}
@@ -0,0 +1,218 @@
+package messages
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+func writeCatalog(t *testing.T, dir string, name string, contents string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0644); err != nil {
+		t.Fatalf("writing catalog '%s': %v", name, err)
+	}
+}
+
+func TestNewCatalog(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, dir, "fr.json", `{"not_found": "Introuvable : {text}", "required": "'{field}' est obligatoire"}`)
+	writeCatalog(t, dir, "pt-BR.json", `{"required": "'{field}' é obrigatório"}`)
+	writeCatalog(t, dir, "README", `not a catalog`)
+
+	c, err := NewCatalog(&config.ConfigMessages{
+		CatalogDir:      dir,
+		DefaultLanguage: "FR",
+		Overrides: map[string]map[string]string{
+			"fr": {"not_found": "Ressource introuvable"},
+			"en": {"forbidden": "Ask the NOC for access"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.defaultLanguage != "fr" {
+		t.Errorf("expected default language 'fr', got '%s'", c.defaultLanguage)
+	}
+	if len(c.languages) != 3 {
+		t.Errorf("expected 3 languages, got %d: %v", len(c.languages), c.languages)
+	}
+	if msg := c.languages["fr"]["not_found"]; msg != "Ressource introuvable" {
+		t.Errorf("expected override to take precedence over catalog, got '%s'", msg)
+	}
+	if msg := c.languages["fr"]["required"]; msg != "'{field}' est obligatoire" {
+		t.Errorf("expected catalog message to be kept, got '%s'", msg)
+	}
+	if _, ok := c.languages["pt-br"]; !ok {
+		t.Error("expected catalog language to be lower-cased")
+	}
+
+	c, err = NewCatalog(nil)
+	if err != nil {
+		t.Fatalf("unexpected error with no configuration: %v", err)
+	}
+	if len(c.languages) != 0 || c.defaultLanguage != BuiltinLanguage {
+		t.Errorf("expected empty catalog in the builtin language, got %+v", c)
+	}
+}
+
+func TestNewCatalogErrors(t *testing.T) {
+	badName := t.TempDir()
+	writeCatalog(t, badName, "not_a_language.json", `{}`)
+	badJSON := t.TempDir()
+	writeCatalog(t, badJSON, "de.json", `{"required": 1}`)
+
+	cfgs := map[string]config.ConfigMessages{
+		"missing directory":        {CatalogDir: filepath.Join(badName, "missing")},
+		"badly named catalog":      {CatalogDir: badName},
+		"malformed catalog":        {CatalogDir: badJSON},
+		"bad override language":    {Overrides: map[string]map[string]string{"en_US": {}}},
+		"unknown default":          {DefaultLanguage: "ja"},
+		"malformed default":        {DefaultLanguage: "*"},
+		"default without override": {DefaultLanguage: "es", Overrides: map[string]map[string]string{"it": {}}},
+	}
+	for name, cfg := range cfgs {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewCatalog(&cfg); err == nil {
+				t.Error("expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	c := &Catalog{
+		languages: map[string]map[string]string{
+			"fr":      {},
+			"zh-hant": {},
+		},
+		defaultLanguage: "fr",
+	}
+	tests := map[string]struct {
+		ranges   []string
+		expected string
+	}{
+		"no ranges":            {ranges: nil, expected: "fr"},
+		"exact":                {ranges: []string{"zh-hant"}, expected: "zh-hant"},
+		"truncated":            {ranges: []string{"zh-hant-tw"}, expected: "zh-hant"},
+		"builtin":              {ranges: []string{"en-gb", "fr"}, expected: "en"},
+		"preference order":     {ranges: []string{"de", "zh-hant", "fr"}, expected: "zh-hant"},
+		"wildcard":             {ranges: []string{"de", "*", "zh-hant"}, expected: "fr"},
+		"no match":             {ranges: []string{"de", "ja"}, expected: "fr"},
+		"no prefix matching":   {ranges: []string{"zh"}, expected: "fr"},
+		"truncated to builtin": {ranges: []string{"en-us"}, expected: "en"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := c.Negotiate(test.ranges); actual != test.expected {
+				t.Errorf("expected '%s', got '%s'", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	c := &Catalog{
+		languages: map[string]map[string]string{
+			"fr": {
+				"not_found":                           "Introuvable : {text}",
+				"required":                            "'{field}' est obligatoire",
+				"staticdnsentry.address.invalid_ipv4": "'{field}' doit être une adresse IPv4",
+			},
+		},
+		defaultLanguage: BuiltinLanguage,
+	}
+
+	alerts := tc.Alerts{Alerts: []tc.Alert{
+		{Text: "'ttl' cannot be blank", Level: tc.ErrorLevel.String(), Code: "staticdnsentry.ttl.required", Field: "ttl"},
+		{Text: "'address' must be a valid IPv4 address", Level: tc.ErrorLevel.String(), Code: "staticdnsentry.address.invalid_ipv4", Field: "address"},
+		{Text: "'host' must be a valid hostname", Level: tc.ErrorLevel.String(), Code: "staticdnsentry.host.invalid_hostname", Field: "host"},
+		{Text: "this endpoint is deprecated", Level: tc.WarnLevel.String()},
+	}}
+	if lang := c.Localize([]string{"fr-ca"}, &alerts); lang != "fr" {
+		t.Errorf("expected language 'fr', got '%s'", lang)
+	}
+	expected := []string{
+		"'ttl' est obligatoire",
+		"'address' doit être une adresse IPv4",
+		"'host' must be a valid hostname",
+		"this endpoint is deprecated",
+	}
+	for i, alert := range alerts.Alerts {
+		if alert.Text != expected[i] {
+			t.Errorf("expected alert #%d to have text '%s', got '%s'", i, expected[i], alert.Text)
+		}
+	}
+
+	alerts = tc.CreateCodedErrorAlerts(http.StatusNotFound, errors.New("no such server"))
+	if lang := c.Localize([]string{"fr"}, &alerts); lang != "fr" {
+		t.Errorf("expected language 'fr', got '%s'", lang)
+	}
+	if alerts.Alerts[0].Text != "Introuvable : no such server" {
+		t.Errorf("expected original text to be substituted, got '%s'", alerts.Alerts[0].Text)
+	}
+
+	alerts = tc.CreateCodedErrorAlerts(http.StatusForbidden, errors.New("forbidden"))
+	if lang := c.Localize([]string{"fr"}, &alerts); lang != BuiltinLanguage {
+		t.Errorf("expected builtin language when no message was localized, got '%s'", lang)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	defer theCatalog.Store(&Catalog{})
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(rfc.AcceptLanguage, "de;q=0.5, fr")
+	alerts := tc.CreateCodedErrorAlerts(http.StatusNotFound, errors.New("not found"))
+
+	if err := Load(nil); err != nil {
+		t.Fatalf("unexpected error loading no catalogs: %v", err)
+	}
+	if lang := Localize(r, &alerts); lang != "" {
+		t.Errorf("expected no language with no catalogs, got '%s'", lang)
+	}
+
+	err := Load(&config.ConfigMessages{Overrides: map[string]map[string]string{"de": {"not_found": "Nicht gefunden"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lang := Localize(r, &alerts); lang != "de" {
+		t.Errorf("expected language 'de', got '%s'", lang)
+	}
+	if alerts.Alerts[0].Text != "Nicht gefunden" {
+		t.Errorf("expected localized text, got '%s'", alerts.Alerts[0].Text)
+	}
+
+	if err := Load(&config.ConfigMessages{DefaultLanguage: "ja"}); err == nil {
+		t.Error("expected an error loading a catalog with an unknown default language")
+	}
+	alerts = tc.CreateCodedErrorAlerts(http.StatusNotFound, errors.New("not found"))
+	if lang := Localize(r, &alerts); lang != "de" {
+		t.Errorf("expected previously loaded catalogs to be kept after an error, got language '%s'", lang)
+	}
+}
@@ -0,0 +1,405 @@
+package mfa
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+
+	"github.com/lib/pq"
+)
+
+const upsertPendingTOTPQuery = `
+INSERT INTO user_mfa_totp (user_id, pending_secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET pending_secret = EXCLUDED.pending_secret
+`
+
+const selectPendingTOTPForUpdateQuery = `
+SELECT pending_secret
+FROM user_mfa_totp
+WHERE user_id = $1 AND pending_secret IS NOT NULL
+FOR UPDATE
+`
+
+const confirmTOTPQuery = `
+UPDATE user_mfa_totp
+SET secret = pending_secret, pending_secret = NULL, last_used_step = $2
+WHERE user_id = $1
+`
+
+const deleteRecoveryCodesQuery = `
+DELETE FROM user_mfa_recovery_code WHERE user_id = $1
+`
+
+const insertRecoveryCodesQuery = `
+INSERT INTO user_mfa_recovery_code (user_id, code_hash)
+SELECT $1, UNNEST($2::text[])
+`
+
+const insertWebAuthnCredentialQuery = `
+INSERT INTO user_webauthn_credential (user_id, credential_id, name, public_key, sign_count)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, name, credential_id, sign_count, last_updated
+`
+
+const deleteWebAuthnCredentialQuery = `
+DELETE FROM user_webauthn_credential WHERE user_id = $1 AND id = $2 RETURNING name
+`
+
+const deleteTOTPQuery = `
+DELETE FROM user_mfa_totp WHERE user_id = $1
+`
+
+const deleteWebAuthnCredentialsQuery = `
+DELETE FROM user_webauthn_credential WHERE user_id = $1
+`
+
+// errNotConfigured is returned to users when second factors aren't
+// configured.
+var errNotConfigured = errors.New("second authentication factors are not configured")
+
+// getConfig returns the second factor configuration, writing an error to the
+// client and returning nil if there isn't one.
+func getConfig(w http.ResponseWriter, r *http.Request, inf *api.APIInfo) *config.ConfigMFA {
+	if inf.Config.MFA == nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusServiceUnavailable, errNotConfigured, nil)
+		return nil
+	}
+	return inf.Config.MFA
+}
+
+// Get is the handler for GET requests to /user/mfa.
+func Get(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	cfg := getConfig(w, r, inf)
+	if cfg == nil {
+		return
+	}
+
+	mfa, err := getMFA(inf.Tx.Tx, inf.User.ID, cfg.RoleRequiresMFA(inf.User.RoleName))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, mfa)
+}
+
+// Delete is the handler for DELETE requests to /user/mfa, which removes all of
+// the current user's second factors.
+func Delete(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	if getConfig(w, r, inf) == nil {
+		return
+	}
+	tx := inf.Tx.Tx
+
+	for _, qry := range []string{deleteTOTPQuery, deleteWebAuthnCredentialsQuery, deleteRecoveryCodesQuery} {
+		if _, err := tx.Exec(qry, inf.User.ID); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("deleting second factors: %w", err))
+			return
+		}
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "USER: "+inf.User.UserName+", ID: "+strconv.Itoa(inf.User.ID)+", ACTION: Removed all second authentication factors", inf.User, tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, "Second authentication factors were removed.")
+}
+
+// EnrollTOTP is the handler for POST requests to /user/mfa/totp, which gives
+// the current user a new pending TOTP secret.
+func EnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	cfg := getConfig(w, r, inf)
+	if cfg == nil {
+		return
+	}
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if _, err := inf.Tx.Tx.Exec(upsertPendingTOTPQuery, inf.User.ID, secret); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, fmt.Errorf("saving pending TOTP secret: %w", err))
+		return
+	}
+	enrollment := tc.TOTPEnrollment{
+		Secret: secret,
+		URI:    TOTPURI(cfg.IssuerName(), inf.User.UserName, secret),
+	}
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "TOTP secret created; confirm it with a code generated from it to use it.", enrollment)
+}
+
+// ConfirmTOTP is the handler for PUT requests to /user/mfa/totp, which
+// confirms the current user's pending TOTP secret, replacing any confirmed
+// secret they had. If the user had no recovery codes, they are given new ones.
+func ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	if getConfig(w, r, inf) == nil {
+		return
+	}
+	tx := inf.Tx.Tx
+
+	req := tc.TOTPConfirmationRequest{}
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	secret := ""
+	if err := tx.QueryRow(selectPendingTOTPForUpdateQuery, inf.User.ID).Scan(&secret); err != nil {
+		if err == sql.ErrNoRows {
+			api.HandleErr(w, r, tx, http.StatusConflict, errors.New("no TOTP secret is waiting to be confirmed"), nil)
+			return
+		}
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting pending TOTP secret: %w", err))
+		return
+	}
+	step, ok := VerifyTOTP(secret, req.Code, time.Now(), 0)
+	if !ok {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("code: invalid"), nil)
+		return
+	}
+	if _, err := tx.Exec(confirmTOTPQuery, inf.User.ID, step); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("confirming TOTP secret: %w", err))
+		return
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "USER: "+inf.User.UserName+", ID: "+strconv.Itoa(inf.User.ID)+", ACTION: Enabled TOTP", inf.User, tx)
+
+	mfa, err := getMFA(tx, inf.User.ID, false)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if mfa.RecoveryCodesRemaining > 0 {
+		api.WriteRespAlert(w, r, tc.SuccessLevel, "TOTP was enabled.")
+		return
+	}
+	codes, err := replaceRecoveryCodes(tx, inf.User.ID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "TOTP was enabled. Store these recovery codes somewhere safe; they won't be shown again.", tc.MFARecoveryCodes{Codes: codes})
+}
+
+// RegenerateRecoveryCodes is the handler for POST requests to
+// /user/mfa/recovery-codes, which replaces the current user's recovery codes.
+func RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	if getConfig(w, r, inf) == nil {
+		return
+	}
+	tx := inf.Tx.Tx
+
+	codes, err := replaceRecoveryCodes(tx, inf.User.ID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "USER: "+inf.User.UserName+", ID: "+strconv.Itoa(inf.User.ID)+", ACTION: Regenerated recovery codes", inf.User, tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Store these recovery codes somewhere safe; they won't be shown again.", tc.MFARecoveryCodes{Codes: codes})
+}
+
+// replaceRecoveryCodes replaces the recovery codes of the user with the given
+// ID with new ones, and returns them.
+func replaceRecoveryCodes(tx *sql.Tx, userID int) ([]string, error) {
+	codes, err := GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+	if _, err := tx.Exec(deleteRecoveryCodesQuery, userID); err != nil {
+		return nil, fmt.Errorf("deleting recovery codes: %w", err)
+	}
+	if _, err := tx.Exec(insertRecoveryCodesQuery, userID, pq.Array(hashes)); err != nil {
+		return nil, fmt.Errorf("inserting recovery codes: %w", err)
+	}
+	return codes, nil
+}
+
+// getWebAuthnConfig returns the WebAuthn configuration, writing an error to the
+// client and returning nil if there isn't one.
+func getWebAuthnConfig(w http.ResponseWriter, r *http.Request, inf *api.APIInfo) *config.ConfigWebAuthn {
+	cfg := getConfig(w, r, inf)
+	if cfg == nil {
+		return nil
+	}
+	if cfg.WebAuthn == nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusServiceUnavailable, errors.New("WebAuthn is not configured"), nil)
+		return nil
+	}
+	return cfg.WebAuthn
+}
+
+// GetWebAuthnRegistrationOptions is the handler for GET requests to
+// /user/mfa/webauthn, which returns the options with which the current user's
+// client creates a new credential.
+func GetWebAuthnRegistrationOptions(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	cfg := getWebAuthnConfig(w, r, inf)
+	if cfg == nil {
+		return
+	}
+
+	token, err := NewToken(inf.Config.Secrets[0], TokenPurposeWebAuthnRegistration, inf.User.UserName)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	mfa, err := getMFA(inf.Tx.Tx, inf.User.ID, false)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	opts := tc.WebAuthnRegistrationOptions{
+		Challenge:          Challenge(token),
+		RPID:               cfg.RPID,
+		RPName:             cfg.RPName,
+		UserID:             base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(inf.User.ID))),
+		UserName:           inf.User.UserName,
+		Algorithms:         []int{COSEAlgorithmES256},
+		ExcludeCredentials: make([]string, 0, len(mfa.WebAuthnCredentials)),
+		Timeout:            ChallengeTimeout,
+	}
+	if opts.RPName == "" {
+		opts.RPName = inf.Config.MFA.IssuerName()
+	}
+	for _, cred := range mfa.WebAuthnCredentials {
+		opts.ExcludeCredentials = append(opts.ExcludeCredentials, cred.CredentialID)
+	}
+	api.WriteResp(w, r, opts)
+}
+
+// RegisterWebAuthnCredential is the handler for POST requests to
+// /user/mfa/webauthn, which registers a new credential of the current user.
+func RegisterWebAuthnCredential(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	cfg := getWebAuthnConfig(w, r, inf)
+	if cfg == nil {
+		return
+	}
+	tx := inf.Tx.Tx
+
+	req := tc.WebAuthnRegistrationRequest{}
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	clientDataJSON, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(req.ClientDataJSON, "="))
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("clientDataJSON: not base64url-encoded"), nil)
+		return
+	}
+	attestationObject, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(req.AttestationObject, "="))
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("attestationObject: not base64url-encoded"), nil)
+		return
+	}
+	newCred, err := VerifyRegistration(cfg, inf.Config.Secrets[0], inf.User.UserName, clientDataJSON, attestationObject)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("invalid WebAuthn credential: %w", err), nil)
+		return
+	}
+
+	cred := tc.WebAuthnCredential{}
+	err = tx.QueryRow(insertWebAuthnCredentialQuery, inf.User.ID, base64.RawURLEncoding.EncodeToString(newCred.ID), strings.TrimSpace(req.Name), newCred.PublicKey, newCred.SignCount).Scan(&cred.ID, &cred.Name, &cred.CredentialID, &cred.SignCount, &cred.LastUpdated)
+	if err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "USER: "+inf.User.UserName+", ID: "+strconv.Itoa(inf.User.ID)+", ACTION: Registered WebAuthn credential '"+cred.Name+"'", inf.User, tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "WebAuthn credential was registered.", cred)
+}
+
+// DeleteWebAuthnCredential is the handler for DELETE requests to
+// /user/mfa/webauthn/{{ID}}, which removes a credential of the current user.
+func DeleteWebAuthnCredential(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	if getConfig(w, r, inf) == nil {
+		return
+	}
+	tx := inf.Tx.Tx
+
+	name := ""
+	if err := tx.QueryRow(deleteWebAuthnCredentialQuery, inf.User.ID, inf.IntParams["id"]).Scan(&name); err != nil {
+		if err == sql.ErrNoRows {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no WebAuthn credential exists by id #%d", inf.IntParams["id"]), nil)
+			return
+		}
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("deleting WebAuthn credential: %w", err))
+		return
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, "USER: "+inf.User.UserName+", ID: "+strconv.Itoa(inf.User.ID)+", ACTION: Removed WebAuthn credential '"+name+"'", inf.User, tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, "WebAuthn credential was removed.")
+}
@@ -0,0 +1,296 @@
+// Package mfa provides the second authentication factors with which users log
+// in with their passwords: TOTP codes, WebAuthn security keys, and recovery
+// codes.
+package mfa
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+// MaxFailedAttempts is the number of consecutive failed attempts to give a
+// second factor after which a user is locked out for LockoutDuration.
+const MaxFailedAttempts = 5
+
+// LockoutDuration is the time for which a user who has made MaxFailedAttempts
+// failed attempts to give a second factor can't try again.
+const LockoutDuration = 15 * time.Minute
+
+const selectUserQuery = `
+SELECT u.id, r.name
+FROM tm_user AS u
+JOIN role AS r ON u.role = r.id
+WHERE u.username = $1
+`
+
+const selectMFAQuery = `
+SELECT
+  COALESCE((SELECT t.secret IS NOT NULL FROM user_mfa_totp AS t WHERE t.user_id = $1), FALSE),
+  COALESCE((SELECT t.pending_secret IS NOT NULL FROM user_mfa_totp AS t WHERE t.user_id = $1), FALSE),
+  (SELECT COUNT(*) FROM user_mfa_recovery_code AS c WHERE c.user_id = $1)
+`
+
+const selectWebAuthnCredentialsQuery = `
+SELECT id, name, credential_id, sign_count, last_updated
+FROM user_webauthn_credential
+WHERE user_id = $1
+ORDER BY id
+`
+
+const selectTOTPForUpdateQuery = `
+SELECT secret, last_used_step
+FROM user_mfa_totp
+WHERE user_id = $1 AND secret IS NOT NULL
+FOR UPDATE
+`
+
+const updateTOTPLastUsedStepQuery = `
+UPDATE user_mfa_totp SET last_used_step = $2 WHERE user_id = $1
+`
+
+const deleteRecoveryCodeQuery = `
+DELETE FROM user_mfa_recovery_code WHERE user_id = $1 AND code_hash = $2
+`
+
+const selectWebAuthnCredentialForUpdateQuery = `
+SELECT public_key, sign_count
+FROM user_webauthn_credential
+WHERE user_id = $1 AND credential_id = $2
+FOR UPDATE
+`
+
+const updateWebAuthnSignCountQuery = `
+UPDATE user_webauthn_credential SET sign_count = $3 WHERE user_id = $1 AND credential_id = $2
+`
+
+const selectFailedAttemptsQuery = `
+SELECT failed_attempts
+FROM user_mfa_attempt
+WHERE user_id = $1 AND last_failure > now() - $2::interval
+`
+
+const upsertFailedAttemptQuery = `
+INSERT INTO user_mfa_attempt (user_id, failed_attempts, last_failure)
+VALUES ($1, 1, now())
+ON CONFLICT (user_id) DO UPDATE SET
+  failed_attempts = CASE
+    WHEN user_mfa_attempt.last_failure > now() - $2::interval THEN user_mfa_attempt.failed_attempts + 1
+    ELSE 1
+  END,
+  last_failure = now()
+`
+
+const deleteFailedAttemptsQuery = `
+DELETE FROM user_mfa_attempt WHERE user_id = $1
+`
+
+// getUser returns the ID and Role name of the user with the given username.
+func getUser(tx *sql.Tx, username string) (int, string, error) {
+	id := 0
+	roleName := ""
+	if err := tx.QueryRow(selectUserQuery, username).Scan(&id, &roleName); err != nil {
+		return 0, "", fmt.Errorf("getting user '%s': %w", username, err)
+	}
+	return id, roleName, nil
+}
+
+// getMFA returns the state of the second factors of the user with the given
+// ID.
+func getMFA(tx *sql.Tx, userID int, required bool) (tc.UserMFA, error) {
+	mfa := tc.UserMFA{Required: required, WebAuthnCredentials: []tc.WebAuthnCredential{}}
+	if err := tx.QueryRow(selectMFAQuery, userID).Scan(&mfa.TOTP, &mfa.TOTPPending, &mfa.RecoveryCodesRemaining); err != nil {
+		return tc.UserMFA{}, fmt.Errorf("getting second factors: %w", err)
+	}
+	rows, err := tx.Query(selectWebAuthnCredentialsQuery, userID)
+	if err != nil {
+		return tc.UserMFA{}, fmt.Errorf("getting WebAuthn credentials: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		cred := tc.WebAuthnCredential{}
+		if err := rows.Scan(&cred.ID, &cred.Name, &cred.CredentialID, &cred.SignCount, &cred.LastUpdated); err != nil {
+			return tc.UserMFA{}, fmt.Errorf("scanning WebAuthn credential: %w", err)
+		}
+		mfa.WebAuthnCredentials = append(mfa.WebAuthnCredentials, cred)
+	}
+	if err := rows.Err(); err != nil {
+		return tc.UserMFA{}, fmt.Errorf("iterating over WebAuthn credentials: %w", err)
+	}
+	mfa.Enabled = mfa.TOTP || len(mfa.WebAuthnCredentials) > 0
+	return mfa, nil
+}
+
+// LoginChallenge returns the challenge with which the user with the given
+// username, who has given their password, must give a second factor to log
+// in, or nil if they don't have one.
+func LoginChallenge(tx *sql.Tx, cfg *config.ConfigMFA, secret string, username string) (*tc.MFALoginChallenge, error) {
+	userID, _, err := getUser(tx, username)
+	if errors.Is(err, sql.ErrNoRows) {
+		// LDAP users need not have been added to Traffic Ops
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	mfa, err := getMFA(tx, userID, false)
+	if err != nil {
+		return nil, err
+	}
+	if !mfa.Enabled {
+		return nil, nil
+	}
+	token, err := NewToken(secret, TokenPurposeLogin, username)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := &tc.MFALoginChallenge{Token: token, Methods: []string{}}
+	if mfa.TOTP {
+		challenge.Methods = append(challenge.Methods, tc.MFAMethodTOTP)
+	}
+	if len(mfa.WebAuthnCredentials) > 0 && cfg.WebAuthn != nil {
+		challenge.Methods = append(challenge.Methods, tc.MFAMethodWebAuthn)
+		challenge.WebAuthn = &tc.WebAuthnAssertionOptions{
+			Challenge:        Challenge(token),
+			RPID:             cfg.WebAuthn.RPID,
+			AllowCredentials: make([]string, 0, len(mfa.WebAuthnCredentials)),
+			Timeout:          ChallengeTimeout,
+		}
+		for _, cred := range mfa.WebAuthnCredentials {
+			challenge.WebAuthn.AllowCredentials = append(challenge.WebAuthn.AllowCredentials, cred.CredentialID)
+		}
+	}
+	if mfa.RecoveryCodesRemaining > 0 {
+		challenge.Methods = append(challenge.Methods, tc.MFAMethodRecoveryCode)
+	}
+	return challenge, nil
+}
+
+// VerifyLogin verifies the second factor given to finish a login, and returns
+// the username of the user logging in. Failed attempts are recorded in the
+// given transaction, which must be committed even if this returns an error.
+func VerifyLogin(tx *sql.Tx, cfg *config.ConfigMFA, secret string, req tc.MFALoginRequest) (string, error, error, int) {
+	username, err := ParseToken(secret, TokenPurposeLogin, req.Token)
+	if err != nil {
+		return "", errors.New("invalid or expired login token, please log in again"), nil, http.StatusUnauthorized
+	}
+	userID, _, err := getUser(tx, username)
+	if err != nil {
+		return "", nil, err, http.StatusInternalServerError
+	}
+
+	lockout := fmt.Sprintf("%d seconds", int(LockoutDuration/time.Second))
+	failedAttempts := 0
+	if err := tx.QueryRow(selectFailedAttemptsQuery, userID, lockout).Scan(&failedAttempts); err != nil && err != sql.ErrNoRows {
+		return "", nil, fmt.Errorf("getting failed second factor attempts: %w", err), http.StatusInternalServerError
+	}
+	if failedAttempts >= MaxFailedAttempts {
+		return "", errors.New("too many failed attempts, please try again later"), nil, http.StatusTooManyRequests
+	}
+
+	ok, sysErr := verifySecondFactor(tx, cfg, req, userID)
+	if sysErr != nil {
+		return "", nil, sysErr, http.StatusInternalServerError
+	}
+	if !ok {
+		if _, err := tx.Exec(upsertFailedAttemptQuery, userID, lockout); err != nil {
+			return "", nil, fmt.Errorf("recording failed second factor attempt: %w", err), http.StatusInternalServerError
+		}
+		return "", errors.New("invalid second factor"), nil, http.StatusUnauthorized
+	}
+	if _, err := tx.Exec(deleteFailedAttemptsQuery, userID); err != nil {
+		return "", nil, fmt.Errorf("clearing failed second factor attempts: %w", err), http.StatusInternalServerError
+	}
+	return username, nil, nil, http.StatusOK
+}
+
+// verifySecondFactor returns whether or not the second factor of the given
+// request is valid for the user with the given ID, consuming it if it can only
+// be used once.
+func verifySecondFactor(tx *sql.Tx, cfg *config.ConfigMFA, req tc.MFALoginRequest, userID int) (bool, error) {
+	switch {
+	case req.TOTP != nil:
+		secret := ""
+		lastStep := int64(0)
+		if err := tx.QueryRow(selectTOTPForUpdateQuery, userID).Scan(&secret, &lastStep); err != nil {
+			if err == sql.ErrNoRows {
+				return false, nil
+			}
+			return false, fmt.Errorf("getting TOTP secret: %w", err)
+		}
+		step, ok := VerifyTOTP(secret, *req.TOTP, time.Now(), lastStep)
+		if !ok {
+			return false, nil
+		}
+		if _, err := tx.Exec(updateTOTPLastUsedStepQuery, userID, step); err != nil {
+			return false, fmt.Errorf("updating TOTP last used step: %w", err)
+		}
+		return true, nil
+
+	case req.RecoveryCode != nil:
+		result, err := tx.Exec(deleteRecoveryCodeQuery, userID, HashRecoveryCode(*req.RecoveryCode))
+		if err != nil {
+			return false, fmt.Errorf("deleting recovery code: %w", err)
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return false, fmt.Errorf("getting recovery codes deleted: %w", err)
+		}
+		return rowsAffected == 1, nil
+
+	case req.WebAuthn != nil:
+		if cfg.WebAuthn == nil {
+			return false, nil
+		}
+		credID := strings.TrimRight(req.WebAuthn.CredentialID, "=")
+		clientDataJSON, err1 := base64.RawURLEncoding.DecodeString(strings.TrimRight(req.WebAuthn.ClientDataJSON, "="))
+		authData, err2 := base64.RawURLEncoding.DecodeString(strings.TrimRight(req.WebAuthn.AuthenticatorData, "="))
+		signature, err3 := base64.RawURLEncoding.DecodeString(strings.TrimRight(req.WebAuthn.Signature, "="))
+		if err1 != nil || err2 != nil || err3 != nil {
+			return false, nil
+		}
+		publicKey := []byte{}
+		signCount := int64(0)
+		if err := tx.QueryRow(selectWebAuthnCredentialForUpdateQuery, userID, credID).Scan(&publicKey, &signCount); err != nil {
+			if err == sql.ErrNoRows {
+				return false, nil
+			}
+			return false, fmt.Errorf("getting WebAuthn credential: %w", err)
+		}
+		newSignCount, err := VerifyAssertion(cfg.WebAuthn, req.Token, publicKey, signCount, clientDataJSON, authData, signature)
+		if err != nil {
+			return false, nil
+		}
+		if _, err := tx.Exec(updateWebAuthnSignCountQuery, userID, credID, newSignCount); err != nil {
+			return false, fmt.Errorf("updating WebAuthn signature counter: %w", err)
+		}
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,69 @@
+package mfa
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// RecoveryCodeCount is the number of recovery codes each user is given.
+const RecoveryCodeCount = 10
+
+// recoveryCodeAlphabet is the alphabet of recovery codes, which leaves out
+// characters which are easily mistaken for each other.
+const recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+// recoveryCodeLength is the number of characters in a recovery code, not
+// counting the separator in its middle.
+const recoveryCodeLength = 10
+
+// GenerateRecoveryCodes returns RecoveryCodeCount new random recovery codes.
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, 0, RecoveryCodeCount)
+	buf := make([]byte, recoveryCodeLength)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("generating recovery code: %w", err)
+		}
+		code := make([]byte, 0, recoveryCodeLength+1)
+		for j, b := range buf {
+			if j == recoveryCodeLength/2 {
+				code = append(code, '-')
+			}
+			// the alphabet is short enough that the modulo bias is negligible
+			code = append(code, recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)])
+		}
+		codes = append(codes, string(code))
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode returns the hash of the given recovery code, as it's
+// stored. Case, whitespace, and separators are ignored.
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.Join(strings.Fields(code), ""))
+	code = strings.ReplaceAll(code, "-", "")
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
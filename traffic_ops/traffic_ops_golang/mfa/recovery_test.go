@@ -0,0 +1,53 @@
+package mfa
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("Unexpected error generating recovery codes: %v", err)
+	}
+	if len(codes) != RecoveryCodeCount {
+		t.Fatalf("Expected: %d codes, actual: %d", RecoveryCodeCount, len(codes))
+	}
+	format := regexp.MustCompile("^[" + recoveryCodeAlphabet + "]{5}-[" + recoveryCodeAlphabet + "]{5}$")
+	seen := map[string]struct{}{}
+	for _, code := range codes {
+		if !format.MatchString(code) {
+			t.Errorf("Expected: codes like 'abcde-fghjk', actual: '%s'", code)
+		}
+		if _, ok := seen[code]; ok {
+			t.Errorf("Expected: unique codes, actual: '%s' more than once", code)
+		}
+		seen[code] = struct{}{}
+	}
+
+	if HashRecoveryCode("abcde-fghjk") != HashRecoveryCode(" ABCDE FGHJK ") {
+		t.Error("Expected: hashes to ignore case, whitespace, and separators, actual: different hashes")
+	}
+	if HashRecoveryCode("abcde-fghjk") == HashRecoveryCode("abcde-fghjm") {
+		t.Error("Expected: different codes to have different hashes, actual: the same hash")
+	}
+}
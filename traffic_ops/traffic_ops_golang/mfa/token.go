@@ -0,0 +1,114 @@
+package mfa
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// These are the purposes of the tokens of the mfa package. A token is only
+// valid for the purpose with which it was made.
+const (
+	// TokenPurposeLogin is the purpose of tokens which identify login
+	// attempts waiting for a second factor. These are also the challenges of
+	// WebAuthn assertions.
+	TokenPurposeLogin = "mfa-login"
+	// TokenPurposeWebAuthnRegistration is the purpose of the challenges of
+	// WebAuthn credential registrations.
+	TokenPurposeWebAuthnRegistration = "webauthn-registration"
+)
+
+// TokenTTL is the time for which tokens are valid.
+const TokenTTL = 5 * time.Minute
+
+type tokenClaims struct {
+	Purpose  string `json:"p"`
+	Username string `json:"u"`
+	Expires  int64  `json:"e"`
+	Nonce    string `json:"n"`
+}
+
+// NewToken returns a new token for the given purpose and user, signed with the
+// given secret. Tokens are not session cookies, and can't be used as such.
+func NewToken(secret string, purpose string, username string) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("generating token nonce: %w", err)
+	}
+	claims := tokenClaims{
+		Purpose:  purpose,
+		Username: username,
+		Expires:  time.Now().Add(TokenTTL).Unix(),
+		Nonce:    base64.RawURLEncoding.EncodeToString(nonce),
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("encoding token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(signToken(secret, payload)), nil
+}
+
+// ParseToken returns the username of the given token, or an error if it isn't
+// a valid, unexpired token for the given purpose signed with the given secret.
+func ParseToken(secret string, purpose string, token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return "", errors.New("malformed token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return "", errors.New("malformed token")
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", errors.New("malformed token")
+	}
+	if !hmac.Equal(mac, signToken(secret, payload)) {
+		return "", errors.New("invalid token signature")
+	}
+	claims := tokenClaims{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", errors.New("malformed token")
+	}
+	if claims.Purpose != purpose {
+		return "", errors.New("token is not for " + purpose)
+	}
+	if time.Now().Unix() > claims.Expires {
+		return "", errors.New("token has expired")
+	}
+	if claims.Username == "" {
+		return "", errors.New("token has no user")
+	}
+	return claims.Username, nil
+}
+
+func signToken(secret string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,59 @@
+package mfa
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestToken(t *testing.T) {
+	token, err := NewToken("secret", TokenPurposeLogin, "admin")
+	if err != nil {
+		t.Fatalf("Unexpected error making token: %v", err)
+	}
+	if username, err := ParseToken("secret", TokenPurposeLogin, token); err != nil || username != "admin" {
+		t.Errorf("Expected: username 'admin', actual: '%s' (%v)", username, err)
+	}
+	if _, err := ParseToken("other secret", TokenPurposeLogin, token); err == nil {
+		t.Error("Expected: an error parsing a token with the wrong secret, actual: nil")
+	}
+	if _, err := ParseToken("secret", TokenPurposeWebAuthnRegistration, token); err == nil {
+		t.Error("Expected: an error parsing a token for another purpose, actual: nil")
+	}
+	parts := strings.Split(token, ".")
+	if _, err := ParseToken("secret", TokenPurposeLogin, parts[0]+"x."+parts[1]); err == nil {
+		t.Error("Expected: an error parsing a tampered token, actual: nil")
+	}
+
+	payload, err := json.Marshal(tokenClaims{Purpose: TokenPurposeLogin, Username: "admin", Expires: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error encoding token: %v", err)
+	}
+	if _, err := ParseToken("secret", TokenPurposeLogin, encodeTestToken("secret", payload)); err == nil {
+		t.Error("Expected: an error parsing an expired token, actual: nil")
+	}
+}
+
+func encodeTestToken(secret string, payload []byte) string {
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(signToken(secret, payload))
+}
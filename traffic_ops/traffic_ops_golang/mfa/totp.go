@@ -0,0 +1,127 @@
+package mfa
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// These are the parameters of the TOTP codes of Traffic Ops, which are those
+// which authenticator apps assume by default.
+const (
+	// TOTPPeriod is the time for which each code is valid.
+	TOTPPeriod = 30 * time.Second
+	// TOTPDigits is the number of digits in each code.
+	TOTPDigits = 6
+)
+
+// totpSecretLength is the length, in bytes, of TOTP secrets, which is the
+// length recommended for HMAC-SHA1 keys by RFC 4226.
+const totpSecretLength = 20
+
+// totpSkew is the number of time steps before and after the current one of
+// which codes are accepted, to allow for clock drift and slow users.
+const totpSkew = 1
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret returns a new random, base32-encoded TOTP secret.
+func GenerateTOTPSecret() (string, error) {
+	key := make([]byte, totpSecretLength)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("generating TOTP secret: %w", err)
+	}
+	return totpEncoding.EncodeToString(key), nil
+}
+
+// TOTPURI returns the otpauth:// URI of the given secret of the given user,
+// which authenticator apps read from QR codes.
+func TOTPURI(issuer string, username string, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", strconv.Itoa(TOTPDigits))
+	params.Set("period", strconv.Itoa(int(TOTPPeriod/time.Second)))
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + username,
+		RawQuery: params.Encode(),
+	}
+	return u.String()
+}
+
+// TOTPStep returns the TOTP time step of the given time.
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / int64(TOTPPeriod/time.Second)
+}
+
+// VerifyTOTP returns the time step of the given code, and whether or not it's
+// a valid code of the given base32-encoded secret at the given time. Codes of
+// time steps no later than lastStep are never valid, so that each code can
+// only be used once.
+func VerifyTOTP(secret string, code string, t time.Time, lastStep int64) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return 0, false
+	}
+	code = strings.Join(strings.Fields(code), "")
+	if len(code) != TOTPDigits {
+		return 0, false
+	}
+	now := TOTPStep(t)
+	for step := now - totpSkew; step <= now+totpSkew; step++ {
+		if step <= lastStep {
+			continue
+		}
+		if hmac.Equal([]byte(totpCode(key, step, TOTPDigits)), []byte(code)) {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// totpCode returns the HOTP code, as defined by RFC 4226, of the given key and
+// counter, which for TOTP is the time step.
+func totpCode(key []byte, step int64, digits int) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
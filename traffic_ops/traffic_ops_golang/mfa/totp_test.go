@@ -0,0 +1,90 @@
+package mfa
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTOTPCode(t *testing.T) {
+	// the SHA1 test vectors of RFC 6238, Appendix B
+	key := []byte("12345678901234567890")
+	vectors := map[int64]string{
+		59:          "94287082",
+		1111111109:  "07081804",
+		1111111111:  "14050471",
+		1234567890:  "89005924",
+		2000000000:  "69279037",
+		20000000000: "65353130",
+	}
+	for unix, expected := range vectors {
+		step := TOTPStep(time.Unix(unix, 0))
+		if actual := totpCode(key, step, 8); actual != expected {
+			t.Errorf("Expected: code '%s' at %d, actual: '%s'", expected, unix, actual)
+		}
+		if actual := totpCode(key, step, TOTPDigits); actual != expected[len(expected)-TOTPDigits:] {
+			t.Errorf("Expected: code '%s' at %d, actual: '%s'", expected[len(expected)-TOTPDigits:], unix, actual)
+		}
+	}
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	key := []byte("12345678901234567890")
+	secret := base32.StdEncoding.EncodeToString(key)
+	now := time.Unix(1234567890, 0)
+	step := TOTPStep(now)
+
+	actualStep, ok := VerifyTOTP(secret, totpCode(key, step, TOTPDigits), now, 0)
+	if !ok || actualStep != step {
+		t.Errorf("Expected: the current code to be valid at step %d, actual: %t at step %d", step, ok, actualStep)
+	}
+	code := totpCode(key, step-1, TOTPDigits)
+	if _, ok := VerifyTOTP(strings.ToLower(secret), code[:3]+" "+code[3:], now, 0); !ok {
+		t.Error("Expected: the previous code to be valid regardless of case and whitespace, actual: invalid")
+	}
+	if _, ok := VerifyTOTP(secret, totpCode(key, step-2, TOTPDigits), now, 0); ok {
+		t.Error("Expected: codes outside of the allowed skew to be invalid, actual: valid")
+	}
+	if _, ok := VerifyTOTP(secret, totpCode(key, step, TOTPDigits), now, step); ok {
+		t.Error("Expected: codes which have been used to be invalid, actual: valid")
+	}
+	if _, ok := VerifyTOTP("not base32!", "123456", now, 0); ok {
+		t.Error("Expected: codes of malformed secrets to be invalid, actual: valid")
+	}
+
+	generated, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("Unexpected error generating TOTP secret: %v", err)
+	}
+	if key, err := totpEncoding.DecodeString(generated); err != nil || len(key) != totpSecretLength {
+		t.Errorf("Expected: a base32-encoded secret of %d bytes, actual: '%s' (%v)", totpSecretLength, generated, err)
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("Traffic Ops", "admin", "ABCDEFGH")
+	expected := "otpauth://totp/Traffic%20Ops:admin?algorithm=SHA1&digits=6&issuer=Traffic+Ops&period=30&secret=ABCDEFGH"
+	if uri != expected {
+		t.Errorf("Expected: '%s', actual: '%s'", expected, uri)
+	}
+}
@@ -0,0 +1,294 @@
+package mfa
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+// COSEAlgorithmES256 is the COSE identifier of ECDSA with P-256 and SHA-256,
+// which is the only WebAuthn public key algorithm supported.
+const COSEAlgorithmES256 = -7
+
+// These are the COSE key parameters of ES256 public keys.
+const (
+	coseKeyType      = 1
+	coseKeyAlgorithm = 3
+	coseKeyCurve     = -1
+	coseKeyX         = -2
+	coseKeyY         = -3
+
+	coseKeyTypeEC2   = 2
+	coseCurveP256    = 1
+	p256CoordinateSz = 32
+)
+
+// These are the flags of authenticator data.
+const (
+	flagUserPresent            = 0x01
+	flagAttestedCredentialData = 0x40
+)
+
+// ChallengeTimeout is the time, in milliseconds, for which WebAuthn
+// challenges are valid.
+const ChallengeTimeout = int(TokenTTL / time.Millisecond)
+
+// NewCredential is a WebAuthn credential whose registration has been verified.
+type NewCredential struct {
+	// ID is the credential ID given by the authenticator.
+	ID []byte
+	// PublicKey is the uncompressed P-256 point of the credential's public
+	// key.
+	PublicKey []byte
+	// SignCount is the authenticator's signature counter.
+	SignCount uint32
+}
+
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+type authenticatorData struct {
+	RPIDHash  []byte
+	Flags     byte
+	SignCount uint32
+	// Rest is the data after the signature counter, which is the attested
+	// credential data if the flagAttestedCredentialData flag is set.
+	Rest []byte
+}
+
+// Challenge returns the WebAuthn challenge of the given token.
+func Challenge(token string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(token))
+}
+
+// VerifyRegistration verifies the response of an authenticator to a request to
+// create a credential for the given user, and returns the new credential.
+//
+// Attestation statements aren't verified - Traffic Ops asks for "none"
+// attestation - so this doesn't prove which kind of authenticator created the
+// credential.
+func VerifyRegistration(cfg *config.ConfigWebAuthn, secret string, username string, clientDataJSON []byte, attestationObject []byte) (NewCredential, error) {
+	cd, err := parseClientData(cfg, clientDataJSON, "webauthn.create")
+	if err != nil {
+		return NewCredential{}, err
+	}
+	token, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(cd.Challenge, "="))
+	if err != nil {
+		return NewCredential{}, errors.New("malformed challenge")
+	}
+	tokenUser, err := ParseToken(secret, TokenPurposeWebAuthnRegistration, string(token))
+	if err != nil {
+		return NewCredential{}, fmt.Errorf("invalid challenge: %w", err)
+	}
+	if tokenUser != username {
+		return NewCredential{}, errors.New("invalid challenge: not issued to this user")
+	}
+
+	obj, _, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return NewCredential{}, fmt.Errorf("decoding attestation object: %w", err)
+	}
+	objMap, ok := obj.(map[interface{}]interface{})
+	if !ok {
+		return NewCredential{}, errors.New("attestation object is not a map")
+	}
+	rawAuthData, ok := objMap["authData"].([]byte)
+	if !ok {
+		return NewCredential{}, errors.New("attestation object has no authData")
+	}
+	authData, err := parseAuthenticatorData(cfg, rawAuthData)
+	if err != nil {
+		return NewCredential{}, err
+	}
+	if authData.Flags&flagAttestedCredentialData == 0 {
+		return NewCredential{}, errors.New("authenticator data has no attested credential data")
+	}
+
+	// attested credential data is a 16-byte AAGUID, a 2-byte credential ID
+	// length, the credential ID, and the COSE public key
+	rest := authData.Rest
+	if len(rest) < 18 {
+		return NewCredential{}, errors.New("attested credential data is too short")
+	}
+	idLen := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if idLen == 0 || len(rest) < idLen {
+		return NewCredential{}, errors.New("attested credential data has a malformed credential ID")
+	}
+	credID := append([]byte(nil), rest[:idLen]...)
+	coseKey, _, err := decodeCBOR(rest[idLen:])
+	if err != nil {
+		return NewCredential{}, fmt.Errorf("decoding credential public key: %w", err)
+	}
+	pub, err := parseCOSEKey(coseKey)
+	if err != nil {
+		return NewCredential{}, err
+	}
+
+	return NewCredential{
+		ID:        credID,
+		PublicKey: marshalPublicKey(pub),
+		SignCount: authData.SignCount,
+	}, nil
+}
+
+// VerifyAssertion verifies the response of an authenticator to a request to
+// sign the challenge of the given login token with the credential with the
+// given public key and stored signature counter, and returns the new
+// signature counter.
+func VerifyAssertion(cfg *config.ConfigWebAuthn, token string, publicKey []byte, signCount int64, clientDataJSON []byte, rawAuthData []byte, signature []byte) (uint32, error) {
+	cd, err := parseClientData(cfg, clientDataJSON, "webauthn.get")
+	if err != nil {
+		return 0, err
+	}
+	if strings.TrimRight(cd.Challenge, "=") != Challenge(token) {
+		return 0, errors.New("challenge does not match")
+	}
+	authData, err := parseAuthenticatorData(cfg, rawAuthData)
+	if err != nil {
+		return 0, err
+	}
+	pub, err := unmarshalPublicKey(publicKey)
+	if err != nil {
+		return 0, err
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := sha256.Sum256(append(append([]byte(nil), rawAuthData...), clientDataHash[:]...))
+	if !ecdsa.VerifyASN1(pub, signed[:], signature) {
+		return 0, errors.New("invalid signature")
+	}
+	// authenticators which don't count signatures always give 0; otherwise,
+	// a counter which didn't increase means the credential has been cloned
+	if (authData.SignCount != 0 || signCount != 0) && int64(authData.SignCount) <= signCount {
+		return 0, errors.New("signature counter did not increase, the credential may have been cloned")
+	}
+	return authData.SignCount, nil
+}
+
+// parseClientData parses the given client data, and returns an error if it
+// isn't of the given type, or doesn't come from one of the configured origins.
+func parseClientData(cfg *config.ConfigWebAuthn, clientDataJSON []byte, typ string) (clientData, error) {
+	cd := clientData{}
+	if err := json.Unmarshal(clientDataJSON, &cd); err != nil {
+		return cd, fmt.Errorf("decoding client data: %w", err)
+	}
+	if cd.Type != typ {
+		return cd, fmt.Errorf("client data type is '%s', expected '%s'", cd.Type, typ)
+	}
+	for _, origin := range cfg.Origins {
+		if strings.EqualFold(strings.TrimSuffix(origin, "/"), cd.Origin) {
+			return cd, nil
+		}
+	}
+	return cd, fmt.Errorf("origin '%s' is not allowed", cd.Origin)
+}
+
+// parseAuthenticatorData parses the given authenticator data, and returns an
+// error if it isn't for the configured Relying Party, or the user wasn't
+// present.
+func parseAuthenticatorData(cfg *config.ConfigWebAuthn, data []byte) (authenticatorData, error) {
+	if len(data) < 37 {
+		return authenticatorData{}, errors.New("authenticator data is too short")
+	}
+	authData := authenticatorData{
+		RPIDHash:  data[:32],
+		Flags:     data[32],
+		SignCount: binary.BigEndian.Uint32(data[33:37]),
+		Rest:      data[37:],
+	}
+	rpIDHash := sha256.Sum256([]byte(cfg.RPID))
+	if !bytes.Equal(authData.RPIDHash, rpIDHash[:]) {
+		return authenticatorData{}, errors.New("authenticator data is not for this relying party")
+	}
+	if authData.Flags&flagUserPresent == 0 {
+		return authenticatorData{}, errors.New("user was not present")
+	}
+	return authData, nil
+}
+
+// parseCOSEKey returns the ES256 public key of the given decoded COSE key.
+func parseCOSEKey(key interface{}) (*ecdsa.PublicKey, error) {
+	m, ok := key.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.New("credential public key is not a map")
+	}
+	if kty, _ := m[int64(coseKeyType)].(int64); kty != coseKeyTypeEC2 {
+		return nil, errors.New("credential public key is not an elliptic curve key")
+	}
+	if alg, _ := m[int64(coseKeyAlgorithm)].(int64); alg != COSEAlgorithmES256 {
+		return nil, fmt.Errorf("credential public key algorithm is not ES256 (%d)", COSEAlgorithmES256)
+	}
+	if crv, _ := m[int64(coseKeyCurve)].(int64); crv != coseCurveP256 {
+		return nil, errors.New("credential public key curve is not P-256")
+	}
+	x, _ := m[int64(coseKeyX)].([]byte)
+	y, _ := m[int64(coseKeyY)].([]byte)
+	if len(x) != p256CoordinateSz || len(y) != p256CoordinateSz {
+		return nil, errors.New("credential public key has malformed coordinates")
+	}
+	return newP256PublicKey(x, y)
+}
+
+// marshalPublicKey returns the uncompressed point of the given P-256 public
+// key.
+func marshalPublicKey(pub *ecdsa.PublicKey) []byte {
+	point := make([]byte, 1+2*p256CoordinateSz)
+	point[0] = 4
+	pub.X.FillBytes(point[1 : 1+p256CoordinateSz])
+	pub.Y.FillBytes(point[1+p256CoordinateSz:])
+	return point
+}
+
+// unmarshalPublicKey returns the P-256 public key of the given uncompressed
+// point.
+func unmarshalPublicKey(point []byte) (*ecdsa.PublicKey, error) {
+	if len(point) != 1+2*p256CoordinateSz || point[0] != 4 {
+		return nil, errors.New("malformed stored public key")
+	}
+	return newP256PublicKey(point[1:1+p256CoordinateSz], point[1+p256CoordinateSz:])
+}
+
+func newP256PublicKey(x []byte, y []byte) (*ecdsa.PublicKey, error) {
+	pub := &ecdsa.PublicKey{
+		Curve: elliptic.P256(),
+		X:     new(big.Int).SetBytes(x),
+		Y:     new(big.Int).SetBytes(y),
+	}
+	if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+		return nil, errors.New("public key is not on the P-256 curve")
+	}
+	return pub, nil
+}
@@ -0,0 +1,191 @@
+package mfa
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+// cborHead returns the head of a CBOR data item of the given major type and
+// argument.
+func cborHead(major byte, arg int) []byte {
+	switch {
+	case arg < 24:
+		return []byte{major<<5 | byte(arg)}
+	case arg < 256:
+		return []byte{major<<5 | 24, byte(arg)}
+	default:
+		head := []byte{major<<5 | 25, 0, 0}
+		binary.BigEndian.PutUint16(head[1:], uint16(arg))
+		return head
+	}
+}
+
+func cborInt(i int) []byte {
+	if i < 0 {
+		return cborHead(1, -1-i)
+	}
+	return cborHead(0, i)
+}
+
+func cborBytes(b []byte) []byte {
+	return append(cborHead(2, len(b)), b...)
+}
+
+func cborText(s string) []byte {
+	return append(cborHead(3, len(s)), s...)
+}
+
+func cborCOSEKey(pub *ecdsa.PublicKey) []byte {
+	point := marshalPublicKey(pub)
+	key := cborHead(5, 5)
+	key = append(append(key, cborInt(coseKeyType)...), cborInt(coseKeyTypeEC2)...)
+	key = append(append(key, cborInt(coseKeyAlgorithm)...), cborInt(COSEAlgorithmES256)...)
+	key = append(append(key, cborInt(coseKeyCurve)...), cborInt(coseCurveP256)...)
+	key = append(append(key, cborInt(coseKeyX)...), cborBytes(point[1:33])...)
+	key = append(append(key, cborInt(coseKeyY)...), cborBytes(point[33:])...)
+	return key
+}
+
+func testAuthData(rpID string, flags byte, signCount uint32) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append(rpIDHash[:], flags, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(data[33:], signCount)
+	return data
+}
+
+func testClientData(t *testing.T, typ string, challenge string, origin string) []byte {
+	cd, err := json.Marshal(clientData{Type: typ, Challenge: challenge, Origin: origin})
+	if err != nil {
+		t.Fatalf("Unexpected error encoding client data: %v", err)
+	}
+	return cd
+}
+
+func TestWebAuthn(t *testing.T) {
+	cfg := &config.ConfigWebAuthn{RPID: "example.test", Origins: []string{"https://tp.example.test"}}
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error generating key: %v", err)
+	}
+	credID := []byte("credential-id")
+
+	regToken, err := NewToken("secret", TokenPurposeWebAuthnRegistration, "admin")
+	if err != nil {
+		t.Fatalf("Unexpected error making token: %v", err)
+	}
+	authData := testAuthData(cfg.RPID, flagUserPresent|flagAttestedCredentialData, 0)
+	authData = append(authData, make([]byte, 16)...)
+	authData = append(authData, byte(len(credID)>>8), byte(len(credID)))
+	authData = append(authData, credID...)
+	authData = append(authData, cborCOSEKey(&priv.PublicKey)...)
+	attestationObject := cborHead(5, 3)
+	attestationObject = append(append(attestationObject, cborText("fmt")...), cborText("none")...)
+	attestationObject = append(append(attestationObject, cborText("attStmt")...), cborHead(5, 0)...)
+	attestationObject = append(append(attestationObject, cborText("authData")...), cborBytes(authData)...)
+
+	clientDataJSON := testClientData(t, "webauthn.create", Challenge(regToken), "https://tp.example.test")
+	cred, err := VerifyRegistration(cfg, "secret", "admin", clientDataJSON, attestationObject)
+	if err != nil {
+		t.Fatalf("Unexpected error verifying registration: %v", err)
+	}
+	if !reflect.DeepEqual(cred.ID, credID) || !reflect.DeepEqual(cred.PublicKey, marshalPublicKey(&priv.PublicKey)) {
+		t.Errorf("Expected: credential '%s' with the generated key, actual: %+v", credID, cred)
+	}
+	if _, err := VerifyRegistration(cfg, "secret", "other", clientDataJSON, attestationObject); err == nil {
+		t.Error("Expected: an error verifying a registration for another user's challenge, actual: nil")
+	}
+	badOrigin := testClientData(t, "webauthn.create", Challenge(regToken), "https://evil.test")
+	if _, err := VerifyRegistration(cfg, "secret", "admin", badOrigin, attestationObject); err == nil {
+		t.Error("Expected: an error verifying a registration from another origin, actual: nil")
+	}
+
+	loginToken, err := NewToken("secret", TokenPurposeLogin, "admin")
+	if err != nil {
+		t.Fatalf("Unexpected error making token: %v", err)
+	}
+	assert := func(typ string, token string, signCount uint32) ([]byte, []byte, []byte) {
+		authData := testAuthData(cfg.RPID, flagUserPresent, signCount)
+		clientDataJSON := testClientData(t, typ, Challenge(token), "https://tp.example.test")
+		clientDataHash := sha256.Sum256(clientDataJSON)
+		signed := sha256.Sum256(append(append([]byte(nil), authData...), clientDataHash[:]...))
+		sig, err := ecdsa.SignASN1(rand.Reader, priv, signed[:])
+		if err != nil {
+			t.Fatalf("Unexpected error signing assertion: %v", err)
+		}
+		return clientDataJSON, authData, sig
+	}
+
+	cd, ad, sig := assert("webauthn.get", loginToken, 5)
+	if signCount, err := VerifyAssertion(cfg, loginToken, cred.PublicKey, 4, cd, ad, sig); err != nil || signCount != 5 {
+		t.Errorf("Expected: a valid assertion with signature counter 5, actual: %d (%v)", signCount, err)
+	}
+	if _, err := VerifyAssertion(cfg, loginToken, cred.PublicKey, 5, cd, ad, sig); err == nil {
+		t.Error("Expected: an error verifying an assertion whose signature counter didn't increase, actual: nil")
+	}
+	if _, err := VerifyAssertion(cfg, regToken, cred.PublicKey, 0, cd, ad, sig); err == nil {
+		t.Error("Expected: an error verifying an assertion of another challenge, actual: nil")
+	}
+	cd, ad, sig = assert("webauthn.create", loginToken, 0)
+	if _, err := VerifyAssertion(cfg, loginToken, cred.PublicKey, 0, cd, ad, sig); err == nil {
+		t.Error("Expected: an error verifying an assertion of the wrong type, actual: nil")
+	}
+	cd, ad, sig = assert("webauthn.get", loginToken, 0)
+	sig[len(sig)-1] ^= 0xff
+	if _, err := VerifyAssertion(cfg, loginToken, cred.PublicKey, 0, cd, ad, sig); err == nil {
+		t.Error("Expected: an error verifying an assertion with a bad signature, actual: nil")
+	}
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	data := append(cborHead(4, 3), cborInt(-500)...)
+	data = append(data, cborText("foo")...)
+	data = append(data, 0xf5)
+	data = append(data, 0xff)
+	item, rest, err := decodeCBOR(data)
+	if err != nil {
+		t.Fatalf("Unexpected error decoding CBOR: %v", err)
+	}
+	expected := []interface{}{int64(-500), "foo", true}
+	if !reflect.DeepEqual(item, expected) || !reflect.DeepEqual(rest, []byte{0xff}) {
+		t.Errorf("Expected: %v and rest [255], actual: %v and rest %v", expected, item, rest)
+	}
+
+	for _, bad := range [][]byte{
+		{},
+		{0x9f, 0x01, 0xff},             // indefinite-length array
+		{0x43, 0x01},                   // truncated byte string
+		{0xa1, 0x41, 0x01, 1},          // byte string map key
+		{0xfb, 0, 0, 0, 0, 0, 0, 0, 0}, // float
+	} {
+		if _, _, err := decodeCBOR(bad); err == nil {
+			t.Errorf("Expected: an error decoding %v, actual: nil", bad)
+		}
+	}
+}
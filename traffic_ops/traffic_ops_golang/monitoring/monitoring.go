@@ -113,6 +113,7 @@ type Monitoring struct {
 	DeliveryServices []DeliveryService              `json:"deliveryServices"`
 	Config           map[string]interface{}         `json:"config"`
 	Topologies       map[string]tc.CRConfigTopology `json:"topologies"`
+	ExternalTargets  []tc.TMExternalTarget          `json:"externalTargets"`
 }
 
 // LegacyMonitoringResponse represents MontiroingResponse for ATC versions before 5.0.
@@ -168,6 +169,10 @@ func GetMonitoringJSON(tx *sql.Tx, cdnName string) (*Monitoring, error) {
 	if err != nil {
 		return nil, fmt.Errorf("getting topologies: %w", err)
 	}
+	externalTargets, err := getExternalTargets(tx, cdnName)
+	if err != nil {
+		return nil, fmt.Errorf("getting steering external targets: %w", err)
+	}
 
 	return &Monitoring{
 		TrafficServers:   caches,
@@ -177,6 +182,7 @@ func GetMonitoringJSON(tx *sql.Tx, cdnName string) (*Monitoring, error) {
 		DeliveryServices: deliveryServices,
 		Config:           config,
 		Topologies:       topologies,
+		ExternalTargets:  externalTargets,
 	}, nil
 }
 
@@ -187,7 +193,7 @@ SELECT
 	CONCAT(me.host_name, '.', me.domain_name) as fqdn,
 	status.name as status,
 	cachegroup.name as cachegroup,
-	me.tcp_port as port,
+	COALESCE(mc.port, me.tcp_port) as port,
 	profile.name as profile,
 	type.name as type,
 	me.xmpp_id as hashID,
@@ -198,7 +204,9 @@ JOIN status status ON status.id = me.status
 JOIN cachegroup cachegroup ON cachegroup.id = me.cachegroup
 JOIN profile profile ON profile.id = me.profile
 JOIN cdn cdn ON cdn.id = me.cdn_id
+LEFT JOIN LATERAL (` + dbhelpers.MonitoredCDNPortQuery("me", "$1") + `) AS mc ON TRUE
 WHERE cdn.name = $1
+OR (type.name = '` + tc.MonitorTypeName + `' AND mc.port IS NOT NULL)
 `
 
 	interfacesQuery := `
@@ -212,6 +220,8 @@ WHERE i.server in (
 	JOIN cdn c
 		on c.id = s.cdn_id
 	WHERE c.name = $1
+	UNION
+	` + dbhelpers.OtherCDNMonitorsQuery("$1") + `
 )`
 
 	ipAddressQuery := `
@@ -224,7 +234,7 @@ JOIN cdn cdn
 	ON cdn.id = s.cdn_id
 WHERE ip.server = ANY($1)
 AND ip.interface = ANY($2)
-AND cdn.name = $3
+AND (cdn.name = $3 OR s.id IN (` + dbhelpers.OtherCDNMonitorsQuery("$3") + `))
 `
 
 	interfaceRows, err := tx.Query(interfacesQuery, cdn)
@@ -502,7 +512,7 @@ func getDeliveryServices(tx *sql.Tx, cdnName string) ([]DeliveryService, error)
 	JOIN cdn ON cdn.id = ds.cdn_id
 	JOIN deliveryservice_regex dsr ON dsr.deliveryservice = ds.id
 	JOIN regex r ON r.id = dsr.regex
-	WHERE ds.active = true
+	WHERE (ds.active = true OR ds.active_at IS NOT NULL)
 	AND cdn.name=$1
 	AND r.type = (SELECT id FROM type WHERE name = 'HOST_REGEXP')
 	GROUP BY ds.xml_id, ds.global_max_tps, ds.xml_id, ds.global_max_mbps, t.name, ds.topology
@@ -538,6 +548,36 @@ func getDeliveryServices(tx *sql.Tx, cdnName string) ([]DeliveryService, error)
 	return dses, nil
 }
 
+// getExternalTargets returns the external targets of the active (or scheduled
+// to become active) steering Delivery Services in the given CDN, which Traffic
+// Monitors of the CDN poll.
+func getExternalTargets(tx *sql.Tx, cdnName string) ([]tc.TMExternalTarget, error) {
+	query := `
+	SELECT et.name, et.health_check_url
+	FROM steering_external_target et
+	JOIN deliveryservice ds ON ds.id = et.deliveryservice
+	JOIN cdn ON cdn.id = ds.cdn_id
+	WHERE (ds.active = true OR ds.active_at IS NOT NULL)
+	AND cdn.name = $1
+	ORDER BY et.name
+	`
+	rows, err := tx.Query(query, cdnName)
+	if err != nil {
+		return nil, err
+	}
+	defer log.Close(rows, "closing steering external target rows")
+
+	targets := []tc.TMExternalTarget{}
+	for rows.Next() {
+		target := tc.TMExternalTarget{}
+		if err := rows.Scan(&target.Name, &target.HealthCheckURL); err != nil {
+			return nil, err
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
 func getConfig(tx *sql.Tx, cdnName string) (map[string]interface{}, error) {
 	// TODO remove 'like' in query? Slow?
 	query := `
@@ -567,6 +567,21 @@ func TestGetMonitoringJSON(t *testing.T) {
 		mock.ExpectQuery("SELECT").WillReturnRows(rows)
 		resp.Response.Topologies = topologies
 	}
+	{
+		//
+		// external targets
+		//
+		externalTargets := []tc.TMExternalTarget{
+			{Name: "other-cdn", HealthCheckURL: "https://health.other.cdn.test/check"},
+		}
+
+		rows := sqlmock.NewRows([]string{"name", "health_check_url"})
+		for _, target := range externalTargets {
+			rows = rows.AddRow(target.Name, target.HealthCheckURL)
+		}
+		mock.ExpectQuery("SELECT").WithArgs(cdn).WillReturnRows(rows)
+		resp.Response.ExternalTargets = externalTargets
+	}
 
 	dbCtx, f := context.WithTimeout(context.TODO(), time.Duration(10)*time.Second)
 	defer f()
@@ -596,6 +611,9 @@ func TestGetMonitoringJSON(t *testing.T) {
 	resp.Response.DeliveryServices = sortDeliveryServices(resp.Response.DeliveryServices)
 	sqlResp.DeliveryServices = sortDeliveryServices(sqlResp.DeliveryServices)
 
+	if !reflect.DeepEqual(sqlResp.ExternalTargets, resp.Response.ExternalTargets) {
+		t.Errorf("GetMonitoringJSON expected ExternalTargets: %+v actual: %+v", resp.Response.ExternalTargets, sqlResp.ExternalTargets)
+	}
 	if !reflect.DeepEqual(sqlResp.TrafficServers, resp.Response.TrafficServers) {
 		t.Errorf("GetMonitoringJSON expected TrafficServers: %+v actual: %+v", resp.Response.TrafficServers, sqlResp.TrafficServers)
 	}
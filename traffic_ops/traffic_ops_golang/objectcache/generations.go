@@ -0,0 +1,179 @@
+package objectcache
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+
+	"github.com/lib/pq"
+)
+
+// notifyChannel is the channel on which the database notifies listeners
+// that a generation changed, with the type of object as the payload.
+const notifyChannel = "cache_generation"
+
+const generationsQuery = `
+SELECT object_type, generation
+FROM cache_generation
+`
+
+// generations are the generations of each type of object, as last read from
+// the database. They're only known while listening for notifications of
+// their changes, since otherwise there's no telling how stale they are.
+type generations struct {
+	mu        sync.RWMutex
+	gens      map[string]int64
+	loaded    bool
+	listening bool
+}
+
+func newGenerations() *generations {
+	return &generations{gens: map[string]int64{}}
+}
+
+// key returns the part of a cache key that identifies the current
+// generations of the given types of objects, and whether the generations are
+// known. Types whose objects have never changed have the generation 0.
+func (g *generations) key(types []string) (string, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	if !g.loaded {
+		return "", false
+	}
+	sorted := make([]string, len(types))
+	copy(sorted, types)
+	sort.Strings(sorted)
+	parts := make([]string, 0, len(sorted))
+	for _, t := range sorted {
+		parts = append(parts, t+"="+strconv.FormatInt(g.gens[t], 10))
+	}
+	return strings.Join(parts, ","), true
+}
+
+func (g *generations) set(gens map[string]int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.gens = gens
+	g.loaded = g.listening
+}
+
+// unset forgets the generations, so that nothing is read from the cache
+// until they're next refreshed.
+func (g *generations) unset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.loaded = false
+}
+
+func (g *generations) setListening(listening bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.listening = listening
+	if !listening {
+		g.loaded = false
+	}
+}
+
+func (g *generations) refresh(db *sql.DB, timeout time.Duration) {
+	gens, err := getGenerations(db, timeout)
+	if err != nil {
+		log.Errorln("refreshing object cache generations: " + err.Error())
+		g.unset()
+		return
+	}
+	g.set(gens)
+	log.Debugf("refreshed object cache generations (len = %d)", len(gens))
+}
+
+func getGenerations(db *sql.DB, timeout time.Duration) (map[string]int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	rows, err := db.QueryContext(ctx, generationsQuery)
+	if err != nil {
+		return nil, errors.New("querying: " + err.Error())
+	}
+	defer log.Close(rows, "closing object cache generation rows")
+
+	gens := map[string]int64{}
+	for rows.Next() {
+		objType := ""
+		gen := int64(0)
+		if err := rows.Scan(&objType, &gen); err != nil {
+			return nil, errors.New("scanning: " + err.Error())
+		}
+		gens[objType] = gen
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over rows: " + err.Error())
+	}
+	return gens, nil
+}
+
+// listen refreshes the generations whenever the database notifies that
+// they've changed, as well as periodically in case a notification is lost.
+// While disconnected from the database, no generations are known, because
+// notifications sent in the meantime are lost.
+func (g *generations) listen(dbConnStr string, db *sql.DB, timeout time.Duration, refreshInterval time.Duration) {
+	listener := pq.NewListener(dbConnStr, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		switch event {
+		case pq.ListenerEventConnected, pq.ListenerEventReconnected:
+			g.setListening(true)
+		case pq.ListenerEventDisconnected:
+			log.Errorln("object cache lost its connection to the database, caching is paused: " + err.Error())
+			g.setListening(false)
+		case pq.ListenerEventConnectionAttemptFailed:
+			log.Errorln("object cache failed to connect to the database: " + err.Error())
+		}
+	})
+	// Listen blocks until it's connected.
+	if err := listener.Listen(notifyChannel); err != nil {
+		log.Errorln("listening for object cache generation changes, caching is disabled: " + err.Error())
+		return
+	}
+	// The Connected event isn't necessarily emitted by the time Listen
+	// returns.
+	g.setListening(true)
+	g.refresh(db, timeout)
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-listener.Notify:
+			// A nil notification is sent on reconnecting, which is as
+			// good a reason as any to refresh. Notifications that arrived
+			// in the meantime are handled by the same refresh.
+			for len(listener.Notify) > 0 {
+				<-listener.Notify
+			}
+		case <-ticker.C:
+		}
+		g.refresh(db, timeout)
+	}
+}
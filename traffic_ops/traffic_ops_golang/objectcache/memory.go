@@ -0,0 +1,80 @@
+package objectcache
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryBackend is a Backend that stores cached objects in the memory of the
+// Traffic Ops instance.
+type memoryBackend struct {
+	mu         sync.Mutex
+	entries    map[string]memoryEntry
+	maxEntries int
+}
+
+type memoryEntry struct {
+	val     []byte
+	expires time.Time
+}
+
+func newMemoryBackend(maxEntries int) *memoryBackend {
+	return &memoryBackend{entries: map[string]memoryEntry{}, maxEntries: maxEntries}
+}
+
+func (b *memoryBackend) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	entry, ok := b.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if time.Now().After(entry.expires) {
+		delete(b.entries, key)
+		return nil, false, nil
+	}
+	return entry.val, true, nil
+}
+
+// Set stores the given value. If the backend is full, expired entries are
+// removed to make room, and if there are none, an arbitrary entry is.
+func (b *memoryBackend) Set(ctx context.Context, key string, val []byte, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.entries[key]; !ok && len(b.entries) >= b.maxEntries {
+		now := time.Now()
+		for k, entry := range b.entries {
+			if now.After(entry.expires) {
+				delete(b.entries, k)
+			}
+		}
+		for k := range b.entries {
+			if len(b.entries) < b.maxEntries {
+				break
+			}
+			delete(b.entries, k)
+		}
+	}
+	b.entries[key] = memoryEntry{val: val, expires: time.Now().Add(ttl)}
+	return nil
+}
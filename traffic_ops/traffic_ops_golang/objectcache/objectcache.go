@@ -0,0 +1,261 @@
+// Package objectcache caches objects that Traffic Ops serves often and that
+// change rarely - like CDN Snapshots and the list of servers - so that
+// clients polling for them don't make Traffic Ops run the same queries over
+// and over again.
+//
+// Objects are cached in a Backend, which is either local to each Traffic Ops
+// instance or shared between them. Each cached object depends on one or more
+// types of objects, and is cached under a key that includes the current
+// "generation" of each of those types. Whenever an object of a type changes,
+// the database increments that type's generation and notifies every Traffic
+// Ops instance, so that no instance uses the now-stale cached objects that
+// depend on it.
+package objectcache
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+// Backend stores cached objects.
+type Backend interface {
+	// Get returns the value stored with the given key, and whether there
+	// is one.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores the given value with the given key, to expire after the
+	// given duration.
+	Set(ctx context.Context, key string, val []byte, ttl time.Duration) error
+}
+
+// The names of the available backends.
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+// Defaults for the settings of the object cache that aren't configured.
+const (
+	DefaultTTL             = 5 * time.Minute
+	DefaultRefreshInterval = time.Minute
+	DefaultMaxEntries      = 1000
+	DefaultRedisTimeout    = time.Second
+)
+
+// keyPrefix is prefixed to every key, so that a shared backend may be used
+// for other things, and so that the format of cached objects can be changed
+// without reading those cached by older versions of Traffic Ops.
+const keyPrefix = "trafficops:objectcache:v1:"
+
+type objectCache struct {
+	backend     Backend
+	ttl         time.Duration
+	timeout     time.Duration
+	generations *generations
+	loads       *loadGroup
+}
+
+var (
+	theCache *objectCache
+	once     = sync.Once{}
+)
+
+// Init initializes the object cache with the given configuration, if it's
+// enabled, and starts listening for changes to the generations of objects in
+// the database at the given connection string.
+func Init(cfg *config.ConfigObjectCache, dbConnStr string, db *sql.DB, dbTimeout time.Duration) error {
+	var err error
+	once.Do(func() {
+		if cfg == nil || cfg.Backend == "" {
+			return
+		}
+		var c *objectCache
+		c, err = newObjectCache(*cfg)
+		if err != nil {
+			return
+		}
+
+		refreshInterval := DefaultRefreshInterval
+		if cfg.RefreshIntervalSeconds > 0 {
+			refreshInterval = time.Duration(cfg.RefreshIntervalSeconds) * time.Second
+		}
+		go c.generations.listen(dbConnStr, db, dbTimeout, refreshInterval)
+		theCache = c
+		log.Infof("object cache enabled with backend '%s'", cfg.Backend)
+	})
+	return err
+}
+
+func newObjectCache(cfg config.ConfigObjectCache) (*objectCache, error) {
+	c := &objectCache{
+		ttl:         DefaultTTL,
+		timeout:     DefaultRedisTimeout,
+		generations: newGenerations(),
+		loads:       newLoadGroup(),
+	}
+	if cfg.TTLSeconds > 0 {
+		c.ttl = time.Duration(cfg.TTLSeconds) * time.Second
+	}
+
+	switch cfg.Backend {
+	case BackendMemory:
+		maxEntries := DefaultMaxEntries
+		if cfg.MaxEntries > 0 {
+			maxEntries = cfg.MaxEntries
+		}
+		c.backend = newMemoryBackend(maxEntries)
+	case BackendRedis:
+		if cfg.Redis == nil || cfg.Redis.Address == "" {
+			return nil, errors.New("the redis object cache backend requires an address")
+		}
+		if cfg.Redis.TimeoutMilliseconds > 0 {
+			c.timeout = time.Duration(cfg.Redis.TimeoutMilliseconds) * time.Millisecond
+		}
+		c.backend = newRedisBackend(*cfg.Redis, c.timeout)
+	default:
+		return nil, errors.New("unknown object cache backend '" + cfg.Backend + "'")
+	}
+	return c, nil
+}
+
+// Enabled returns whether the object cache is enabled.
+func Enabled() bool {
+	return theCache != nil
+}
+
+// Load sets val, which must be a pointer to a value that can be encoded as
+// JSON, to the object cached with the given key that depends on objects of
+// the given types. If no such object is cached, or the object cache is
+// disabled, load is called to set val from the database, and val is cached
+// unless load returns an error, which is returned.
+//
+// Keys must identify everything that val depends on other than the objects
+// of the given types, e.g. the API version and query parameters of the
+// request.
+//
+// If a value is being loaded for the same key in response to another
+// request, Load waits for it rather than calling load.
+func Load(ctx context.Context, key string, types []string, val interface{}, load func() error) error {
+	c := theCache
+	if c == nil {
+		return load()
+	}
+	return c.load(ctx, key, types, val, load)
+}
+
+func (c *objectCache) load(ctx context.Context, key string, types []string, val interface{}, load func() error) error {
+	gens, ok := c.generations.key(types)
+	if !ok {
+		// Without generations, there's no telling whether a cached object
+		// is stale.
+		return load()
+	}
+	key = keyPrefix + key + "@" + gens
+
+	backendCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	bts, ok, err := c.backend.Get(backendCtx, key)
+	cancel()
+	if err != nil {
+		log.Warnln("getting cached object '" + key + "': " + err.Error())
+	} else if ok {
+		err := json.Unmarshal(bts, val)
+		if err == nil {
+			return nil
+		}
+		log.Warnln("decoding cached object '" + key + "': " + err.Error())
+	}
+
+	bts, leader, err := c.loads.do(key, func() ([]byte, error) {
+		if err := load(); err != nil {
+			return nil, err
+		}
+		bts, err := json.Marshal(val)
+		if err != nil {
+			return nil, errors.New("encoding object to cache: " + err.Error())
+		}
+		backendCtx, cancel := context.WithTimeout(context.Background(), c.timeout)
+		defer cancel()
+		if err := c.backend.Set(backendCtx, key, bts, c.ttl); err != nil {
+			log.Warnln("caching object '" + key + "': " + err.Error())
+		}
+		return bts, nil
+	})
+	if leader {
+		return err
+	}
+	// The errors of other requests' loads aren't necessarily this one's.
+	if err != nil {
+		return load()
+	}
+	if err := json.Unmarshal(bts, val); err != nil {
+		log.Warnln("decoding loaded object '" + key + "': " + err.Error())
+		return load()
+	}
+	return nil
+}
+
+// loadGroup ensures that only one object is loaded at a time for any key.
+type loadGroup struct {
+	mu    sync.Mutex
+	calls map[string]*loadCall
+}
+
+type loadCall struct {
+	wg  sync.WaitGroup
+	val []byte
+	err error
+}
+
+func newLoadGroup() *loadGroup {
+	return &loadGroup{calls: map[string]*loadCall{}}
+}
+
+// do calls fn and returns its results, unless it's already being called for
+// the given key, in which case it waits for that call and returns its
+// results. It also returns whether it called fn itself.
+func (g *loadGroup) do(key string, fn func() ([]byte, error)) ([]byte, bool, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.val, false, call.err
+	}
+	call := &loadCall{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		call.wg.Done()
+	}()
+	call.val, call.err = fn()
+	return call.val, true, call.err
+}
@@ -0,0 +1,284 @@
+package objectcache
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+type testObject struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func newTestCache(t *testing.T) *objectCache {
+	c, err := newObjectCache(config.ConfigObjectCache{Backend: BackendMemory})
+	if err != nil {
+		t.Fatalf("unexpected error creating object cache: %v", err)
+	}
+	c.generations.setListening(true)
+	c.generations.set(map[string]int64{"server": 1})
+	return c
+}
+
+func TestNewObjectCache(t *testing.T) {
+	if _, err := newObjectCache(config.ConfigObjectCache{Backend: "memcached"}); err == nil {
+		t.Error("expected an error creating an object cache with an unknown backend")
+	}
+	if _, err := newObjectCache(config.ConfigObjectCache{Backend: BackendRedis}); err == nil {
+		t.Error("expected an error creating a redis object cache without an address")
+	}
+	c, err := newObjectCache(config.ConfigObjectCache{Backend: BackendRedis, TTLSeconds: 30, Redis: &config.ConfigObjectCacheRedis{Address: "localhost:6379", TimeoutMilliseconds: 200}})
+	if err != nil {
+		t.Fatalf("unexpected error creating a redis object cache: %v", err)
+	}
+	if c.ttl != 30*time.Second {
+		t.Errorf("expected TTL 30s, actual: %v", c.ttl)
+	}
+	if c.timeout != 200*time.Millisecond {
+		t.Errorf("expected timeout 200ms, actual: %v", c.timeout)
+	}
+	if _, ok := c.backend.(*redisBackend); !ok {
+		t.Errorf("expected a redis backend, actual: %T", c.backend)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	c := newTestCache(t)
+	types := []string{"server", "cdn"}
+	loads := 0
+	load := func(obj *testObject) func() error {
+		return func() error {
+			loads++
+			*obj = testObject{Name: "foo", Count: loads}
+			return nil
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		obj := testObject{}
+		if err := c.load(context.Background(), "foo", types, &obj, load(&obj)); err != nil {
+			t.Fatalf("unexpected error loading object: %v", err)
+		}
+		if obj.Name != "foo" || obj.Count != 1 {
+			t.Errorf("expected the object loaded first, actual: %+v", obj)
+		}
+	}
+	if loads != 1 {
+		t.Errorf("expected the object to be loaded once, actual: %d", loads)
+	}
+
+	c.generations.set(map[string]int64{"server": 2})
+	obj := testObject{}
+	if err := c.load(context.Background(), "foo", types, &obj, load(&obj)); err != nil {
+		t.Fatalf("unexpected error loading object: %v", err)
+	}
+	if obj.Count != 2 {
+		t.Errorf("expected the object to be loaded again after its generation changed, actual: %+v", obj)
+	}
+
+	c.generations.setListening(false)
+	obj = testObject{}
+	if err := c.load(context.Background(), "foo", types, &obj, load(&obj)); err != nil {
+		t.Fatalf("unexpected error loading object: %v", err)
+	}
+	if obj.Count != 3 {
+		t.Errorf("expected the object to be loaded while the generations are unknown, actual: %+v", obj)
+	}
+}
+
+func TestLoadError(t *testing.T) {
+	c := newTestCache(t)
+	expected := errors.New("loading failed")
+	obj := testObject{}
+	err := c.load(context.Background(), "foo", []string{"server"}, &obj, func() error { return expected })
+	if err != expected {
+		t.Errorf("expected the error of loading the object, actual: %v", err)
+	}
+
+	loaded := false
+	err = c.load(context.Background(), "foo", []string{"server"}, &obj, func() error {
+		loaded = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error loading object: %v", err)
+	}
+	if !loaded {
+		t.Error("expected an object that failed to load not to be cached")
+	}
+}
+
+func TestLoadDisabled(t *testing.T) {
+	loaded := false
+	if err := Load(context.Background(), "foo", []string{"server"}, &testObject{}, func() error {
+		loaded = true
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error loading object: %v", err)
+	}
+	if !loaded {
+		t.Error("expected the object to be loaded while the object cache is disabled")
+	}
+}
+
+func TestLoadGroup(t *testing.T) {
+	g := newLoadGroup()
+	release := make(chan struct{})
+	started := make(chan struct{})
+	calls := 0
+
+	wg := sync.WaitGroup{}
+	results := make([]string, 5)
+	leaders := make([]bool, 5)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		val, leader, _ := g.do("foo", func() ([]byte, error) {
+			calls++
+			close(started)
+			<-release
+			return []byte("bar"), nil
+		})
+		results[0], leaders[0] = string(val), leader
+	}()
+	<-started
+	for i := 1; i < len(results); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			val, leader, _ := g.do("foo", func() ([]byte, error) {
+				return []byte("baz" + strconv.Itoa(i)), nil
+			})
+			results[i], leaders[i] = string(val), leader
+		}(i)
+	}
+	// Give the waiters a chance to start waiting; the ones that don't in
+	// time just call their own function.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if calls != 1 || !leaders[0] || results[0] != "bar" {
+		t.Errorf("expected the first call to be made once and return 'bar', actual: calls %d, leader %t, result '%s'", calls, leaders[0], results[0])
+	}
+	for i := 1; i < len(results); i++ {
+		if !leaders[i] && results[i] != "bar" {
+			t.Errorf("expected waiting call %d to return the first call's result 'bar', actual: '%s'", i, results[i])
+		}
+	}
+}
+
+func TestMemoryBackend(t *testing.T) {
+	ctx := context.Background()
+	b := newMemoryBackend(2)
+	if _, ok, _ := b.Get(ctx, "foo"); ok {
+		t.Error("expected no value before one was set")
+	}
+	b.Set(ctx, "foo", []byte("foo"), time.Minute)
+	if val, ok, _ := b.Get(ctx, "foo"); !ok || string(val) != "foo" {
+		t.Errorf("expected value 'foo', actual: '%s' (exists: %t)", val, ok)
+	}
+
+	b.Set(ctx, "expired", []byte("expired"), -time.Second)
+	if _, ok, _ := b.Get(ctx, "expired"); ok {
+		t.Error("expected no value after it expired")
+	}
+
+	b.Set(ctx, "bar", []byte("bar"), -time.Second)
+	b.Set(ctx, "baz", []byte("baz"), time.Minute)
+	if len(b.entries) != 2 {
+		t.Errorf("expected the backend to keep at most 2 entries, actual: %d", len(b.entries))
+	}
+	if _, ok := b.entries["bar"]; ok {
+		t.Error("expected an expired entry to be removed to make room for another")
+	}
+	b.Set(ctx, "qux", []byte("qux"), time.Minute)
+	if len(b.entries) != 2 {
+		t.Errorf("expected the backend to keep at most 2 entries, actual: %d", len(b.entries))
+	}
+	if val, ok, _ := b.Get(ctx, "qux"); !ok || string(val) != "qux" {
+		t.Errorf("expected value 'qux', actual: '%s' (exists: %t)", val, ok)
+	}
+}
+
+func TestGenerationsKey(t *testing.T) {
+	g := newGenerations()
+	if _, ok := g.key([]string{"server"}); ok {
+		t.Error("expected no key before the generations were loaded")
+	}
+	g.set(map[string]int64{"server": 3, "cdn": 7})
+	if _, ok := g.key([]string{"server"}); ok {
+		t.Error("expected no key while not listening for changes")
+	}
+	g.setListening(true)
+	g.set(map[string]int64{"server": 3, "cdn": 7})
+	key, ok := g.key([]string{"server", "cdn", "type"})
+	if !ok {
+		t.Fatal("expected a key after the generations were loaded")
+	}
+	if expected := "cdn=7,server=3,type=0"; key != expected {
+		t.Errorf("expected key '%s', actual: '%s'", expected, key)
+	}
+	g.setListening(false)
+	if _, ok := g.key([]string{"server"}); ok {
+		t.Error("expected no key after no longer listening for changes")
+	}
+}
+
+func TestRefreshGenerations(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("creating new sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	rows := sqlmock.NewRows([]string{"object_type", "generation"}).AddRow("server", 4).AddRow("cdn", 2)
+	mock.ExpectQuery("SELECT object_type, generation").WillReturnRows(rows)
+	mock.ExpectQuery("SELECT object_type, generation").WillReturnError(errors.New("connection refused"))
+
+	g := newGenerations()
+	g.setListening(true)
+	g.refresh(db, time.Second)
+	key, ok := g.key([]string{"cdn", "server"})
+	if !ok {
+		t.Fatal("expected a key after refreshing the generations")
+	}
+	if expected := "cdn=2,server=4"; key != expected {
+		t.Errorf("expected key '%s', actual: '%s'", expected, key)
+	}
+
+	g.refresh(db, time.Second)
+	if _, ok := g.key([]string{"server"}); ok {
+		t.Error("expected no key after failing to refresh the generations")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet sqlmock expectations: %v", err)
+	}
+}
@@ -0,0 +1,213 @@
+package objectcache
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+// DefaultRedisMaxIdleConnections is the number of idle connections to Redis
+// kept open if not configured.
+const DefaultRedisMaxIdleConnections = 8
+
+// redisBackend is a Backend that stores cached objects in Redis, so that they
+// may be shared by every Traffic Ops instance. It speaks just enough of the
+// Redis protocol (RESP) to get and set values.
+type redisBackend struct {
+	address  string
+	password string
+	db       int
+	useTLS   bool
+	timeout  time.Duration
+	idle     chan *redisConn
+}
+
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// redisError is an error reply from Redis. Unlike other errors, it doesn't
+// mean the connection is broken.
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+func newRedisBackend(cfg config.ConfigObjectCacheRedis, timeout time.Duration) *redisBackend {
+	maxIdle := DefaultRedisMaxIdleConnections
+	if cfg.MaxIdleConnections > 0 {
+		maxIdle = cfg.MaxIdleConnections
+	}
+	return &redisBackend{
+		address:  cfg.Address,
+		password: cfg.Password,
+		db:       cfg.DB,
+		useTLS:   cfg.TLS,
+		timeout:  timeout,
+		idle:     make(chan *redisConn, maxIdle),
+	}
+}
+
+func (b *redisBackend) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := b.do(ctx, "GET", key)
+	if err != nil {
+		return nil, false, err
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+	val, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("redis: unexpected GET reply %v", reply)
+	}
+	return val, true, nil
+}
+
+func (b *redisBackend) Set(ctx context.Context, key string, val []byte, ttl time.Duration) error {
+	_, err := b.do(ctx, "SET", key, string(val), "PX", strconv.FormatInt(int64(ttl/time.Millisecond), 10))
+	return err
+}
+
+// do sends the given command to Redis and returns its reply, which is nil,
+// a string, an int64, or a []byte.
+func (b *redisBackend) do(ctx context.Context, args ...string) (interface{}, error) {
+	conn, err := b.getConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reply, err := conn.do(ctx, args...)
+	if _, ok := err.(redisError); err != nil && !ok {
+		conn.conn.Close()
+		return nil, err
+	}
+	b.putConn(conn)
+	return reply, err
+}
+
+func (b *redisBackend) getConn(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-b.idle:
+		return conn, nil
+	default:
+	}
+
+	dialer := &net.Dialer{Timeout: b.timeout}
+	var conn net.Conn
+	var err error
+	if b.useTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer}).DialContext(ctx, "tcp", b.address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", b.address)
+	}
+	if err != nil {
+		return nil, errors.New("connecting to redis: " + err.Error())
+	}
+	rc := &redisConn{conn: conn, r: bufio.NewReader(conn)}
+	if b.password != "" {
+		if _, err := rc.do(ctx, "AUTH", b.password); err != nil {
+			conn.Close()
+			return nil, errors.New("authenticating with redis: " + err.Error())
+		}
+	}
+	if b.db != 0 {
+		if _, err := rc.do(ctx, "SELECT", strconv.Itoa(b.db)); err != nil {
+			conn.Close()
+			return nil, errors.New("selecting redis database: " + err.Error())
+		}
+	}
+	return rc, nil
+}
+
+func (b *redisBackend) putConn(conn *redisConn) {
+	select {
+	case b.idle <- conn:
+	default:
+		conn.conn.Close()
+	}
+}
+
+func (c *redisConn) do(ctx context.Context, args ...string) (interface{}, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := c.conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	} else if err := c.conn.SetDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+
+	buf := bytes.Buffer{}
+	buf.WriteString("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		buf.WriteString("$" + strconv.Itoa(len(arg)) + "\r\n" + arg + "\r\n")
+	}
+	if _, err := c.conn.Write(buf.Bytes()); err != nil {
+		return nil, errors.New("writing to redis: " + err.Error())
+	}
+	return readRedisReply(c.r)
+}
+
+func readRedisReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, errors.New("reading from redis: " + err.Error())
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply line %q", line)
+	}
+	line = line[:len(line)-2]
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		i, err := strconv.ParseInt(line[1:], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed integer reply %q", line)
+		}
+		return i, nil
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed bulk string reply %q", line)
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		val := make([]byte, size+2)
+		if _, err := io.ReadFull(r, val); err != nil {
+			return nil, errors.New("reading from redis: " + err.Error())
+		}
+		return val[:size], nil
+	}
+	return nil, fmt.Errorf("redis: unsupported reply %q", line)
+}
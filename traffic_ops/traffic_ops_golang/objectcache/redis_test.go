@@ -0,0 +1,178 @@
+package objectcache
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+// fakeRedis is a Redis server that supports just enough commands to test
+// redisBackend.
+type fakeRedis struct {
+	listener net.Listener
+	mu       sync.Mutex
+	vals     map[string]string
+	commands []string
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("can't listen on localhost: %v", err)
+	}
+	r := &fakeRedis{listener: listener, vals: map[string]string{}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go r.serve(conn)
+		}
+	}()
+	return r
+}
+
+func (r *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	rd := bufio.NewReader(conn)
+	for {
+		line, err := rd.ReadString('\n')
+		if err != nil {
+			return
+		}
+		n, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		args := make([]string, 0, n)
+		for i := 0; i < n; i++ {
+			sizeLine, err := rd.ReadString('\n')
+			if err != nil {
+				return
+			}
+			size, _ := strconv.Atoi(strings.TrimSpace(sizeLine[1:]))
+			arg := make([]byte, size+2)
+			if _, err := io.ReadFull(rd, arg); err != nil {
+				return
+			}
+			args = append(args, string(arg[:size]))
+		}
+
+		r.mu.Lock()
+		r.commands = append(r.commands, args[0])
+		reply := "-ERR unknown command\r\n"
+		switch args[0] {
+		case "AUTH":
+			if args[1] == "secret" {
+				reply = "+OK\r\n"
+			} else {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case "SELECT":
+			reply = "+OK\r\n"
+		case "GET":
+			if val, ok := r.vals[args[1]]; ok {
+				reply = "$" + strconv.Itoa(len(val)) + "\r\n" + val + "\r\n"
+			} else {
+				reply = "$-1\r\n"
+			}
+		case "SET":
+			r.vals[args[1]] = args[2]
+			reply = "+OK\r\n"
+		}
+		r.mu.Unlock()
+		if _, err := conn.Write([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+func TestRedisBackend(t *testing.T) {
+	r := newFakeRedis(t)
+	defer r.listener.Close()
+
+	ctx := context.Background()
+	b := newRedisBackend(config.ConfigObjectCacheRedis{Address: r.listener.Addr().String(), Password: "secret", DB: 2}, time.Second)
+	if _, ok, err := b.Get(ctx, "foo"); err != nil || ok {
+		t.Errorf("expected no value before one was set, actual: exists %t, error %v", ok, err)
+	}
+	if err := b.Set(ctx, "foo", []byte("bar\r\nbaz"), time.Minute); err != nil {
+		t.Fatalf("unexpected error setting value: %v", err)
+	}
+	val, ok, err := b.Get(ctx, "foo")
+	if err != nil || !ok || string(val) != "bar\r\nbaz" {
+		t.Errorf("expected value 'bar\\r\\nbaz', actual: '%s' (exists: %t, error: %v)", val, ok, err)
+	}
+
+	r.mu.Lock()
+	commands := strings.Join(r.commands, ",")
+	r.mu.Unlock()
+	if expected := "AUTH,SELECT,GET,SET,GET"; commands != expected {
+		t.Errorf("expected a single connection to send commands %s, actual: %s", expected, commands)
+	}
+
+	b = newRedisBackend(config.ConfigObjectCacheRedis{Address: r.listener.Addr().String(), Password: "wrong"}, time.Second)
+	if _, _, err := b.Get(ctx, "foo"); err == nil {
+		t.Error("expected an error authenticating with the wrong password")
+	}
+}
+
+func TestReadRedisReply(t *testing.T) {
+	tests := []struct {
+		reply    string
+		expected interface{}
+		isErr    bool
+	}{
+		{reply: "+OK\r\n", expected: "OK"},
+		{reply: ":42\r\n", expected: int64(42)},
+		{reply: "$3\r\nfoo\r\n", expected: "foo"},
+		{reply: "$-1\r\n", expected: nil},
+		{reply: "-ERR oops\r\n", isErr: true},
+		{reply: "*1\r\n", isErr: true},
+		{reply: "+OK\n", isErr: true},
+	}
+	for _, test := range tests {
+		actual, err := readRedisReply(bufio.NewReader(strings.NewReader(test.reply)))
+		if test.isErr {
+			if err == nil {
+				t.Errorf("expected an error reading reply %q", test.reply)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error reading reply %q: %v", test.reply, err)
+			continue
+		}
+		if bts, ok := actual.([]byte); ok {
+			actual = string(bts)
+		}
+		if actual != test.expected {
+			t.Errorf("expected reply %q to be read as %v, actual: %v", test.reply, test.expected, actual)
+		}
+	}
+}
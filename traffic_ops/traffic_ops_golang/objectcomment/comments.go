@@ -0,0 +1,457 @@
+package objectcomment
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// comments.go defines the handlers of the threaded comments attached to
+// Delivery Services, servers, and Cache Groups. The comments of Delivery
+// Service Requests are handled by the deliveryservice/request/comment
+// package.
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+const readQuery = `
+SELECT c.id,
+	c.parent,
+	ds.xml_id,
+	c.deliveryservice,
+	s.host_name,
+	c.server,
+	cg.name,
+	c.cachegroup,
+	c.author,
+	c.value,
+	ARRAY(
+		SELECT u.username
+		FROM comment_mention AS m
+		JOIN tm_user AS u ON u.id = m.tm_user
+		WHERE m.comment = c.id
+		ORDER BY u.username
+	),
+	c.created,
+	c.last_updated
+FROM comment AS c
+LEFT JOIN deliveryservice AS ds ON ds.id = c.deliveryservice
+LEFT JOIN server AS s ON s.id = c.server
+LEFT JOIN cachegroup AS cg ON cg.id = c.cachegroup
+`
+
+const insertQuery = `
+INSERT INTO comment (parent, deliveryservice, server, cachegroup, author, value)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id
+`
+
+const updateQuery = `
+UPDATE comment SET
+	value = $1
+WHERE id = $2
+`
+
+const deleteQuery = `
+DELETE FROM comment
+WHERE id = $1
+`
+
+const deleteMentionsQuery = `
+DELETE FROM comment_mention
+WHERE comment = $1
+`
+
+// Mentions of usernames that don't exist are ignored.
+const insertMentionsQuery = `
+INSERT INTO comment_mention (comment, tm_user)
+SELECT $1, u.id
+FROM tm_user AS u
+WHERE u.username = ANY($2)
+ON CONFLICT DO NOTHING
+`
+
+// tenancyCondition hides the comments of Delivery Services outside the
+// user's tenancy; comments of servers and Cache Groups aren't tenanted.
+const tenancyCondition = ` (c.deliveryservice IS NULL OR ds.tenant_id = ANY(:tenants)) `
+
+const mentionedCondition = ` EXISTS (
+	SELECT 1
+	FROM comment_mention AS m
+	JOIN tm_user AS u ON u.id = m.tm_user
+	WHERE m.comment = c.id AND u.username = :mentioned
+) AND`
+
+// mentionPattern matches mentions of users: "@" followed by a username, at
+// the start of the text or after a character which can't be part of an email
+// address, so that addresses like "ops@example.com" aren't mentions.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.@-])@([\w.-]+)`)
+
+// parseMentions returns the usernames mentioned in the given comment text, in
+// the order in which they're first mentioned. Periods ending mentions are
+// taken to end sentences, rather than to be part of the usernames.
+func parseMentions(value string) []string {
+	mentions := []string{}
+	seen := map[string]struct{}{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(value, -1) {
+		username := strings.TrimRight(match[1], ".")
+		if username == "" {
+			continue
+		}
+		if _, ok := seen[username]; ok {
+			continue
+		}
+		seen[username] = struct{}{}
+		mentions = append(mentions, username)
+	}
+	return mentions
+}
+
+// setMentions replaces the recorded mentions of the Comment with the given ID
+// with those in its text.
+func setMentions(tx *sql.Tx, id int, value string) error {
+	if _, err := tx.Exec(deleteMentionsQuery, id); err != nil {
+		return fmt.Errorf("deleting mentions of comment #%d: %w", id, err)
+	}
+	if _, err := tx.Exec(insertMentionsQuery, id, pq.Array(parseMentions(value))); err != nil {
+		return fmt.Errorf("inserting mentions of comment #%d: %w", id, err)
+	}
+	return nil
+}
+
+// Read is the handler for GET requests to /comments.
+func Read(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	queryParamsToQueryCols := map[string]dbhelpers.WhereColumnInfo{
+		"id":                dbhelpers.WhereColumnInfo{Column: "c.id", Checker: api.IsInt},
+		"parentId":          dbhelpers.WhereColumnInfo{Column: "c.parent", Checker: api.IsInt},
+		"author":            dbhelpers.WhereColumnInfo{Column: "c.author"},
+		"deliveryService":   dbhelpers.WhereColumnInfo{Column: "ds.xml_id"},
+		"deliveryServiceId": dbhelpers.WhereColumnInfo{Column: "c.deliveryservice", Checker: api.IsInt},
+		"server":            dbhelpers.WhereColumnInfo{Column: "s.host_name"},
+		"serverId":          dbhelpers.WhereColumnInfo{Column: "c.server", Checker: api.IsInt},
+		"cachegroup":        dbhelpers.WhereColumnInfo{Column: "cg.name"},
+		"cachegroupId":      dbhelpers.WhereColumnInfo{Column: "c.cachegroup", Checker: api.IsInt},
+		"created":           dbhelpers.WhereColumnInfo{Column: "c.created"},
+	}
+
+	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(inf.Params, queryParamsToQueryCols)
+	if len(errs) > 0 {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, util.JoinErrs(errs), nil)
+		return
+	}
+
+	conditions := ""
+	if mentioned, ok := inf.Params[tc.CommentMentionedQueryParam]; ok {
+		queryValues["mentioned"] = mentioned
+		conditions += mentionedCondition
+	}
+
+	tenants, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting accessible tenants for user: %v", err))
+		return
+	}
+	queryValues["tenants"] = pq.Array(tenants)
+	conditions += tenancyCondition
+
+	if where == "" {
+		where = dbhelpers.BaseWhere + conditions
+	} else {
+		where += " AND" + conditions
+	}
+	if orderBy == "" {
+		orderBy = "\nORDER BY c.created, c.id"
+	}
+
+	rows, err := inf.Tx.NamedQuery(readQuery+where+orderBy+pagination, queryValues)
+	if err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		if sysErr != nil {
+			sysErr = fmt.Errorf("comment read query: %v", sysErr)
+		}
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer rows.Close()
+
+	comments := []tc.Comment{}
+	for rows.Next() {
+		var c tc.Comment
+		if err = scan(rows, &c); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("scanning comments: "+err.Error()))
+			return
+		}
+		comments = append(comments, c)
+	}
+
+	api.WriteResp(w, r, comments)
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(row scanner, c *tc.Comment) error {
+	return row.Scan(
+		&c.ID,
+		&c.ParentID,
+		&c.DeliveryService,
+		&c.DeliveryServiceID,
+		&c.Server,
+		&c.ServerID,
+		&c.Cachegroup,
+		&c.CachegroupID,
+		&c.Author,
+		&c.Value,
+		pq.Array(&c.Mentions),
+		&c.Created,
+		&c.LastUpdated,
+	)
+}
+
+// getComment returns the Comment with the given ID, and whether or not it
+// exists.
+func getComment(tx *sql.Tx, id int) (tc.Comment, bool, error) {
+	var c tc.Comment
+	if err := scan(tx.QueryRow(readQuery+"WHERE c.id = $1", id), &c); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c, false, nil
+		}
+		return c, false, fmt.Errorf("querying comment #%d: %w", id, err)
+	}
+	return c, true, nil
+}
+
+// checkTenancy checks that the user may comment on the given Delivery
+// Service, if any.
+func checkTenancy(inf *api.APIInfo, dsID *int) (error, error, int) {
+	if dsID == nil {
+		return nil, nil, http.StatusOK
+	}
+	return tenant.CheckID(inf.Tx.Tx, inf.User, *dsID)
+}
+
+// sameTarget returns whether the Comment is of the object identified in the
+// request.
+func sameTarget(c tc.Comment, req tc.CommentRequest) bool {
+	return intPtrsEqual(c.DeliveryServiceID, req.DeliveryServiceID) &&
+		intPtrsEqual(c.ServerID, req.ServerID) &&
+		intPtrsEqual(c.CachegroupID, req.CachegroupID)
+}
+
+func intPtrsEqual(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// describeTarget describes the object to which a Comment is attached, for
+// change logs and alerts.
+func describeTarget(c tc.Comment) string {
+	switch {
+	case c.DeliveryService != nil:
+		return "DS: " + *c.DeliveryService
+	case c.Server != nil:
+		return "SERVER: " + *c.Server
+	case c.Cachegroup != nil:
+		return "CACHEGROUP: " + *c.Cachegroup
+	}
+	return "unknown target"
+}
+
+// Create is the handler for POST requests to /comments.
+func Create(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	var req tc.CommentRequest
+	if userErr = api.Parse(r.Body, tx, &req); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	if userErr, sysErr, errCode = checkTenancy(inf, req.DeliveryServiceID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if req.ParentID != nil {
+		parent, ok, err := getComment(tx, *req.ParentID)
+		if err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+			return
+		}
+		if !ok {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("no comment exists by ID %d", *req.ParentID), nil)
+			return
+		}
+		if !sameTarget(parent, req) {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("a reply must be of the same object as the comment to which it replies"), nil)
+			return
+		}
+	}
+
+	var id int
+	err := tx.QueryRow(insertQuery, req.ParentID, req.DeliveryServiceID, req.ServerID, req.CachegroupID, inf.User.UserName, req.Value).Scan(&id)
+	if err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if err := setMentions(tx, id, req.Value); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	resp, _, err := getComment(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	changeLogMsg := fmt.Sprintf("COMMENT: %d, %s, ACTION: Created", resp.ID, describeTarget(resp))
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+
+	alerts := tc.CreateAlerts(tc.SuccessLevel, "Comment created for "+describeTarget(resp))
+	api.WriteAlertsObj(w, r, http.StatusCreated, alerts, resp)
+}
+
+// getExisting gets the Comment identified by the request's "id" path
+// parameter, and checks that the user may see it. If the returned HTTP
+// status code isn't OK, the request has been handled.
+func getExisting(w http.ResponseWriter, r *http.Request, inf *api.APIInfo) (tc.Comment, int) {
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+	existing, ok, err := getComment(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return existing, http.StatusInternalServerError
+	}
+	if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no comment exists by ID %d", id), nil)
+		return existing, http.StatusNotFound
+	}
+	if userErr, sysErr, errCode := checkTenancy(inf, existing.DeliveryServiceID); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return existing, errCode
+	}
+	return existing, http.StatusOK
+}
+
+// Update is the handler for PUT requests to /comments/{id}. Only the author
+// of a Comment may modify it.
+func Update(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	existing, code := getExisting(w, r, inf)
+	if code != http.StatusOK {
+		return
+	}
+	if existing.Author != inf.User.UserName {
+		api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("comments can only be modified by their authors"), nil)
+		return
+	}
+
+	var req tc.CommentUpdateRequest
+	if userErr = api.Parse(r.Body, tx, &req); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+
+	if _, err := tx.Exec(updateQuery, req.Value, existing.ID); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if err := setMentions(tx, existing.ID, req.Value); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	resp, _, err := getComment(tx, existing.ID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	changeLogMsg := fmt.Sprintf("COMMENT: %d, %s, ACTION: Updated", resp.ID, describeTarget(resp))
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Comment updated for "+describeTarget(resp), resp)
+}
+
+// Delete is the handler for DELETE requests to /comments/{id}. Only the
+// author of a Comment, or a user with the "admin" Role, may delete it, and
+// its replies are deleted with it.
+func Delete(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	existing, code := getExisting(w, r, inf)
+	if code != http.StatusOK {
+		return
+	}
+	if existing.Author != inf.User.UserName && inf.User.RoleName != tc.AdminRoleName {
+		api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("comments can only be deleted by their authors"), nil)
+		return
+	}
+
+	if _, err := tx.Exec(deleteQuery, existing.ID); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	changeLogMsg := fmt.Sprintf("COMMENT: %d, %s, ACTION: Deleted", existing.ID, describeTarget(existing))
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Comment deleted for "+describeTarget(existing), existing)
+}
@@ -0,0 +1,57 @@
+package objectcomment
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestParseMentions(t *testing.T) {
+	cases := map[string][]string{
+		"don't reboot, flaky RAID controller":             {},
+		"@jdoe don't reboot, flaky RAID controller":       {"jdoe"},
+		"ask @jdoe or @ops.lead.":                         {"jdoe", "ops.lead"},
+		"@jdoe, see above (cc @first-last), thanks @jdoe": {"jdoe", "first-last"},
+		"mail ops@example.com, not @":                     {},
+		"@@jdoe":                                          {},
+	}
+	for value, expected := range cases {
+		if mentions := parseMentions(value); !reflect.DeepEqual(mentions, expected) {
+			t.Errorf("parseMentions(%q): expected %v, got %v", value, expected, mentions)
+		}
+	}
+}
+
+func TestSameTarget(t *testing.T) {
+	parent := tc.Comment{ServerID: util.IntPtr(4)}
+	if !sameTarget(parent, tc.CommentRequest{ServerID: util.IntPtr(4)}) {
+		t.Error("expected a reply of the same server to have the same target")
+	}
+	if sameTarget(parent, tc.CommentRequest{ServerID: util.IntPtr(5)}) {
+		t.Error("expected a reply of a different server to have a different target")
+	}
+	if sameTarget(parent, tc.CommentRequest{CachegroupID: util.IntPtr(4)}) {
+		t.Error("expected a reply of a Cache Group to have a different target than a comment of a server")
+	}
+}
@@ -0,0 +1,467 @@
+// Package openapi generates OpenAPI 3 specifications of the Traffic Ops API
+// from descriptions of its routes and the Go types of their request and
+// response bodies.
+//
+// The types' schemas are derived from their JSON encodings, so they describe
+// what's really sent and received, rather than what was documented.
+package openapi
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// Version is the version of the OpenAPI Specification to which generated
+// documents conform.
+const Version = "3.0.3"
+
+// Document is an OpenAPI document, as described by
+// https://spec.openapis.org/oas/v3.0.3#openapi-object. Only the parts of it
+// used to describe the Traffic Ops API are represented.
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Servers    []Server                        `json:"servers,omitempty"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+// Info is the metadata of a Document.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Server is a server that serves the API described by a Document.
+type Server struct {
+	URL string `json:"url"`
+}
+
+// Operation is a single API operation, i.e. a method of a path.
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security"`
+	// RouteID is the ID of the Traffic Ops route that serves the operation,
+	// which is used to disable it.
+	RouteID int `json:"x-route-id"`
+	// PrivLevel is the privilege level required of users' Roles to use the
+	// operation.
+	PrivLevel int `json:"x-priv-level,omitempty"`
+	// Permissions are the Permissions required of users' Roles to use the
+	// operation.
+	Permissions []string `json:"x-permissions,omitempty"`
+}
+
+// Parameter is a parameter of an Operation.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is the body of requests of an Operation.
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is a response of an Operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType is the schema of a request or response body of some media type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the schemas which other parts of a Document reference,
+// and the security schemes its Operations may require.
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme is a way in which clients may be authenticated.
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	In          string `json:"in,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Schema is a JSON Schema of a value, as extended by
+// https://spec.openapis.org/oas/v3.0.3#schema-object.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+// Route describes a route of the Traffic Ops API, and the Go types of its
+// request and response bodies. Request and Response may be nil, if they're
+// unknown.
+type Route struct {
+	Method        string
+	Path          string
+	ID            int
+	Authenticated bool
+	PrivLevel     int
+	Permissions   []string
+	// Request is a value of the type of the route's request body.
+	Request interface{}
+	// Response is a value of the type of the "response" property of the
+	// route's response bodies.
+	Response interface{}
+}
+
+// Body is the Go types of the request and response bodies of a route.
+// Response is the type of the "response" property of the route's response
+// bodies. Either may be nil, if the route has no such body, or its body isn't
+// a Traffic Ops API response.
+type Body struct {
+	Request  interface{}
+	Response interface{}
+}
+
+// CookieSecurityScheme is the name of the SecurityScheme with which clients
+// are authenticated with the cookie set by the /user/login endpoints.
+const CookieSecurityScheme = "cookieAuth"
+
+// Generate returns an OpenAPI document describing the given routes of the
+// given version of the Traffic Ops API. Routes' paths are the patterns with
+// which they're routed, relative to the version's base path.
+func Generate(version string, routes []Route) *Document {
+	g := newGenerator()
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       "Traffic Ops API",
+			Description: "The API of Apache Traffic Control's Traffic Ops, generated from its routes.",
+			Version:     version,
+		},
+		Servers: []Server{{URL: "/api/" + version}},
+		Paths:   map[string]map[string]Operation{},
+		Components: Components{
+			SecuritySchemes: map[string]SecurityScheme{
+				CookieSecurityScheme: {
+					Type:        "apiKey",
+					In:          "cookie",
+					Name:        "mojolicious",
+					Description: "The cookie set in the response to a request to one of the /user/login endpoints.",
+				},
+			},
+		},
+	}
+
+	alerts := g.schema(reflect.TypeOf(tc.Alerts{}))
+	alertList := g.components["Alerts"].Properties["alerts"]
+	operationIDs := map[string]struct{}{}
+	for _, r := range routes {
+		path, params := Path(r.Path)
+		method := strings.ToLower(r.Method)
+		op := Operation{
+			OperationID: operationID(method, path),
+			Summary:     strings.ToUpper(method) + " " + path,
+			Parameters:  params,
+			Responses: map[string]Response{
+				"default": {
+					Description: "An error, described by the alerts.",
+					Content:     jsonContent(alerts),
+				},
+			},
+			Security:    []map[string][]string{},
+			RouteID:     r.ID,
+			Permissions: r.Permissions,
+		}
+		if _, ok := operationIDs[op.OperationID]; ok {
+			op.OperationID = fmt.Sprintf("%s%d", op.OperationID, r.ID)
+		}
+		operationIDs[op.OperationID] = struct{}{}
+		if r.Authenticated {
+			op.Security = []map[string][]string{{CookieSecurityScheme: {}}}
+			op.PrivLevel = r.PrivLevel
+		}
+		if r.Method == "GET" {
+			op.Parameters = append(op.Parameters, Parameter{
+				Name:        "fields",
+				In:          "query",
+				Description: "A comma-separated list of the fields of the objects in the response to return, omitting the rest.",
+				Schema:      &Schema{Type: "string"},
+			})
+		}
+		if r.Request != nil {
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  jsonContent(g.schema(reflect.TypeOf(r.Request))),
+			}
+		}
+
+		var resp *Schema
+		if r.Response != nil {
+			resp = g.schema(reflect.TypeOf(r.Response))
+		} else {
+			resp = &Schema{}
+		}
+		op.Responses["200"] = Response{
+			Description: "Success.",
+			Content: jsonContent(&Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"alerts":   alertList,
+					"response": resp,
+				},
+			}),
+		}
+
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]Operation{}
+		}
+		doc.Paths[path][method] = op
+	}
+	doc.Components.Schemas = g.components
+	return doc
+}
+
+func jsonContent(s *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: s}}
+}
+
+// routeParamPattern matches the parameters in the paths of routes.
+var routeParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+// Path returns the OpenAPI path of the given route pattern, with a leading
+// slash and without the regular expression syntax that ends most of them,
+// and its path parameters.
+func Path(pattern string) (string, []Parameter) {
+	path := strings.TrimSuffix(pattern, "$")
+	path = strings.TrimSuffix(path, "?")
+	path = strings.TrimSuffix(path, "/")
+	params := []Parameter{}
+	for _, match := range routeParamPattern.FindAllStringSubmatch(path, -1) {
+		name := match[1]
+		schema := &Schema{Type: "string"}
+		if lower := strings.ToLower(name); (lower == "id" || strings.HasSuffix(lower, "id")) && !strings.HasPrefix(lower, "xml") {
+			schema = &Schema{Type: "integer"}
+		}
+		params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: schema})
+	}
+	return "/" + path, params
+}
+
+// operationID returns an identifier of the operation of the given method and
+// path in camel case, e.g. "getCdnsIdQueueUpdate".
+func operationID(method, path string) string {
+	id := strings.Builder{}
+	id.WriteString(method)
+	upper := true
+	for _, r := range path {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		id.WriteRune(r)
+	}
+	return id.String()
+}
+
+// generator generates the schemas of types, collecting the schemas of named
+// struct types as components which the others reference.
+type generator struct {
+	components map[string]*Schema
+	names      map[reflect.Type]string
+}
+
+func newGenerator() *generator {
+	return &generator{components: map[string]*Schema{}, names: map[reflect.Type]string{}}
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	tcTimeType  = reflect.TypeOf(tc.Time{})
+	tcTimeNoMod = reflect.TypeOf(tc.TimeNoMod{})
+	rawJSONType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schema returns the schema of the JSON encoding of the given type.
+func (g *generator) schema(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case tcTimeType, tcTimeNoMod:
+		return &Schema{Type: "string", Description: "A time, in a format like 2006-01-02 15:04:05-07."}
+	case rawJSONType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := g.schema(t.Elem())
+		if s.Ref != "" {
+			return s
+		}
+		nullable := *s
+		nullable.Nullable = true
+		return &nullable
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := 0
+		return &Schema{Type: "integer", Minimum: &zero}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return g.ref(t)
+	default:
+		// Interfaces, and anything else, could be anything.
+		return &Schema{}
+	}
+}
+
+// ref returns a reference to the component schema of the given named struct
+// type, generating it if it hasn't been.
+func (g *generator) ref(t reflect.Type) *Schema {
+	name, ok := g.names[t]
+	if !ok {
+		name = t.Name()
+		if _, taken := g.components[name]; taken {
+			pkg := t.PkgPath()
+			name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + name
+		}
+		g.names[t] = name
+		// The component is added before it's generated, so that types which
+		// contain themselves refer to it instead of recursing infinitely.
+		s := &Schema{}
+		g.components[name] = s
+		*s = *g.structSchema(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// structSchema returns the schema of the JSON encoding of the given struct
+// type, including the fields of the structs it embeds.
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		opts := strings.Split(tag, ",")
+		name := opts[0]
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				inner := g.structSchema(embedded)
+				for prop, schema := range inner.Properties {
+					if _, ok := s.Properties[prop]; !ok {
+						s.Properties[prop] = schema
+					}
+				}
+				s.Required = append(s.Required, inner.Required...)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		s.Properties[name] = g.schema(f.Type)
+		omitEmpty := false
+		for _, opt := range opts[1:] {
+			if opt == "omitempty" {
+				omitEmpty = true
+			}
+		}
+		if !omitEmpty && f.Type.Kind() != reflect.Ptr && f.Type.Kind() != reflect.Interface {
+			s.Required = append(s.Required, name)
+		}
+	}
+	sort.Strings(s.Required)
+	s.Required = dedupe(s.Required)
+	return s
+}
+
+// dedupe removes adjacent duplicates from the given sorted strings.
+func dedupe(strs []string) []string {
+	if len(strs) == 0 {
+		return nil
+	}
+	deduped := strs[:1]
+	for _, s := range strs[1:] {
+		if s != deduped[len(deduped)-1] {
+			deduped = append(deduped, s)
+		}
+	}
+	return deduped
+}
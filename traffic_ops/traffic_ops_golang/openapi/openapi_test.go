@@ -0,0 +1,215 @@
+package openapi
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+type testBase struct {
+	ID          *int      `json:"id" db:"id"`
+	LastUpdated time.Time `json:"lastUpdated"`
+}
+
+type testObject struct {
+	testBase
+	Name     string           `json:"name"`
+	Tags     []string         `json:"tags,omitempty"`
+	Data     []byte           `json:"data"`
+	Labels   map[string]int64 `json:"labels"`
+	Extra    interface{}      `json:"extra"`
+	Raw      json.RawMessage  `json:"raw"`
+	Children []testObject     `json:"children"`
+	Ignored  string           `json:"-"`
+	Untagged bool
+	private  string
+	Anon     struct{ A float64 }    `json:"anon"`
+	Time     *tc.TimeNoMod          `json:"time"`
+	Ptr      *map[string]testObject `json:"ptr"`
+}
+
+func TestGenerateSchemas(t *testing.T) {
+	doc := Generate("4.1", []Route{{
+		Method:        "GET",
+		Path:          "objects/?$",
+		ID:            1,
+		Authenticated: true,
+		PrivLevel:     10,
+		Permissions:   []string{"OBJECT:READ"},
+		Response:      []testObject{},
+	}})
+
+	s, ok := doc.Components.Schemas["testObject"]
+	if !ok {
+		t.Fatalf("Expected a component schema named 'testObject', got: %v", doc.Components.Schemas)
+	}
+	expectedTypes := map[string]string{
+		"id":          "integer",
+		"lastUpdated": "string",
+		"name":        "string",
+		"tags":        "array",
+		"data":        "string",
+		"labels":      "object",
+		"extra":       "",
+		"raw":         "",
+		"children":    "array",
+		"Untagged":    "boolean",
+		"anon":        "object",
+		"time":        "string",
+		"ptr":         "object",
+	}
+	if len(s.Properties) != len(expectedTypes) {
+		t.Errorf("Expected %d properties, got %d: %v", len(expectedTypes), len(s.Properties), s.Properties)
+	}
+	for name, typ := range expectedTypes {
+		prop, ok := s.Properties[name]
+		if !ok {
+			t.Errorf("Expected a property named '%s', but there wasn't one", name)
+			continue
+		}
+		if prop.Type != typ {
+			t.Errorf("Expected property '%s' to be of type '%s', got: '%s'", name, typ, prop.Type)
+		}
+	}
+	if !s.Properties["id"].Nullable {
+		t.Error("Expected pointer property 'id' to be nullable")
+	}
+	if s.Properties["data"].Format != "byte" {
+		t.Errorf("Expected byte slice property 'data' to have the 'byte' format, got: '%s'", s.Properties["data"].Format)
+	}
+	if s.Properties["children"].Items == nil || s.Properties["children"].Items.Ref != "#/components/schemas/testObject" {
+		t.Errorf("Expected property 'children' to be an array of references to the testObject schema, got: %+v", s.Properties["children"].Items)
+	}
+	if s.Properties["labels"].AdditionalProperties == nil || s.Properties["labels"].AdditionalProperties.Format != "int64" {
+		t.Errorf("Expected property 'labels' to be a map of int64s, got: %+v", s.Properties["labels"].AdditionalProperties)
+	}
+
+	expectedRequired := []string{"Untagged", "anon", "children", "data", "labels", "lastUpdated", "name", "raw"}
+	if !reflect.DeepEqual(s.Required, expectedRequired) {
+		t.Errorf("Expected required properties %v, got: %v", expectedRequired, s.Required)
+	}
+}
+
+func TestGenerateOperations(t *testing.T) {
+	doc := Generate("4.1", []Route{
+		{
+			Method:        "GET",
+			Path:          "objects/{id}/?$",
+			ID:            1,
+			Authenticated: true,
+			PrivLevel:     10,
+			Permissions:   []string{"OBJECT:READ"},
+			Response:      []testObject{},
+		},
+		{
+			Method:   "POST",
+			Path:     "objects/{name}$",
+			ID:       2,
+			Request:  testObject{},
+			Response: testObject{},
+		},
+		{
+			Method: "PUT",
+			Path:   "objects/{name}/?$",
+			ID:     3,
+		},
+	})
+
+	if doc.OpenAPI != Version {
+		t.Errorf("Expected OpenAPI version '%s', got: '%s'", Version, doc.OpenAPI)
+	}
+	if len(doc.Paths) != 2 {
+		t.Fatalf("Expected 2 paths, got %d: %v", len(doc.Paths), doc.Paths)
+	}
+
+	get, ok := doc.Paths["/objects/{id}"]["get"]
+	if !ok {
+		t.Fatalf("Expected a GET operation of path '/objects/{id}', got: %v", doc.Paths)
+	}
+	if get.OperationID != "getObjectsId" {
+		t.Errorf("Expected operation ID 'getObjectsId', got: '%s'", get.OperationID)
+	}
+	if len(get.Security) != 1 || get.PrivLevel != 10 || len(get.Permissions) != 1 {
+		t.Errorf("Expected authenticated operation requiring privilege level 10 and one Permission, got: %+v", get)
+	}
+	if len(get.Parameters) != 2 {
+		t.Fatalf("Expected 2 parameters, got %d: %+v", len(get.Parameters), get.Parameters)
+	}
+	if p := get.Parameters[0]; p.Name != "id" || p.In != "path" || !p.Required || p.Schema.Type != "integer" {
+		t.Errorf("Expected required integral path parameter 'id', got: %+v", p)
+	}
+	if p := get.Parameters[1]; p.Name != "fields" || p.In != "query" {
+		t.Errorf("Expected query parameter 'fields', got: %+v", p)
+	}
+	resp := get.Responses["200"].Content["application/json"].Schema
+	if resp == nil || resp.Properties["response"] == nil || resp.Properties["response"].Type != "array" || resp.Properties["alerts"] == nil {
+		t.Errorf("Expected a response containing alerts and an array, got: %+v", resp)
+	}
+	if _, ok := get.Responses["default"]; !ok {
+		t.Error("Expected a default response")
+	}
+
+	post := doc.Paths["/objects/{name}"]["post"]
+	if post.RouteID != 2 || len(post.Security) != 0 || post.RequestBody == nil {
+		t.Errorf("Expected unauthenticated operation with a request body and route ID 2, got: %+v", post)
+	}
+	if p := post.Parameters[0]; p.Name != "name" || p.Schema.Type != "string" {
+		t.Errorf("Expected string path parameter 'name', got: %+v", p)
+	}
+	put := doc.Paths["/objects/{name}"]["put"]
+	if put.RequestBody != nil {
+		t.Errorf("Expected no request body for an operation without a known request type, got: %+v", put.RequestBody)
+	}
+
+	if _, err := json.Marshal(doc); err != nil {
+		t.Errorf("Unexpected error encoding document: %v", err)
+	}
+}
+
+func TestPath(t *testing.T) {
+	tests := map[string]string{
+		"cdns/?$":                        "/cdns",
+		"cdns/{id}$":                     "/cdns/{id}",
+		"servers/{id}/update_status$":    "/servers/{id}/update_status",
+		"user/login/oauth":               "/user/login/oauth",
+		"cdns/{name}/dnsseckeys/?$":      "/cdns/{name}/dnsseckeys",
+		"deliveryservices/xmlId/{xmlid}": "/deliveryservices/xmlId/{xmlid}",
+	}
+	for pattern, expected := range tests {
+		if path, _ := Path(pattern); path != expected {
+			t.Errorf("Expected pattern '%s' to give path '%s', got: '%s'", pattern, expected, path)
+		}
+	}
+
+	_, params := Path("deliveryservices/{dsid}/xmlId/{xmlid}/{name}")
+	if len(params) != 3 {
+		t.Fatalf("Expected 3 parameters, got %d: %+v", len(params), params)
+	}
+	for i, typ := range []string{"integer", "string", "string"} {
+		if params[i].Schema.Type != typ {
+			t.Errorf("Expected parameter '%s' to be of type '%s', got: '%s'", params[i].Name, typ, params[i].Schema.Type)
+		}
+	}
+}
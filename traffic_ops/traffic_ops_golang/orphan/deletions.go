@@ -0,0 +1,286 @@
+package orphan
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// deletions.go defines the handlers of the deletions of orphaned objects,
+// which are staged for review before they're carried out.
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+const readDeletionsQuery = `
+SELECT id, object_type, object_id, object_name, reason, staged_by, created
+FROM orphan_deletion
+`
+
+const stageDeletionQuery = `
+INSERT INTO orphan_deletion (object_type, object_id, object_name, reason, staged_by)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (object_type, object_id) DO NOTHING
+RETURNING id, created
+`
+
+const deleteDeletionQuery = `
+DELETE FROM orphan_deletion
+WHERE id = $1
+`
+
+// deleteObjectQueries are the queries which delete orphaned objects of each
+// type, by ID.
+var deleteObjectQueries = map[tc.OrphanType]string{
+	tc.OrphanTypeParameter:      `DELETE FROM parameter WHERE id = $1`,
+	tc.OrphanTypeProfile:        `DELETE FROM profile WHERE id = $1`,
+	tc.OrphanTypeStaticDNSEntry: `DELETE FROM staticdnsentry WHERE id = $1`,
+	tc.OrphanTypeCachegroup:     `DELETE FROM cachegroup WHERE id = $1`,
+	tc.OrphanTypeRegex:          `DELETE FROM regex WHERE id = $1`,
+}
+
+func scanDeletions(rows *sql.Rows) ([]tc.OrphanDeletion, error) {
+	defer rows.Close()
+	deletions := []tc.OrphanDeletion{}
+	for rows.Next() {
+		var d tc.OrphanDeletion
+		if err := rows.Scan(&d.ID, &d.Type, &d.ObjectID, &d.Name, &d.Reason, &d.StagedBy, &d.Created); err != nil {
+			return nil, fmt.Errorf("scanning staged orphan deletions: %w", err)
+		}
+		deletions = append(deletions, d)
+	}
+	return deletions, rows.Err()
+}
+
+// GetDeletions is the handler for GET requests to /reports/orphans/deletions.
+func GetDeletions(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	rows, err := tx.Query(readDeletionsQuery + "ORDER BY id")
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("querying staged orphan deletions: %w", err))
+		return
+	}
+	deletions, err := scanDeletions(rows)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, deletions)
+}
+
+// StageDeletions is the handler for POST requests to
+// /reports/orphans/deletions, which stages the deletions of the orphaned
+// objects of the requested types. Objects whose deletions are already staged
+// are skipped.
+func StageDeletions(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	var req tc.OrphanDeletionsRequest
+	if userErr = api.Parse(r.Body, tx, &req); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	tenantIDs, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting accessible tenants for user: %w", err))
+		return
+	}
+	orphans, err := getOrphans(tx, req.Types, tenantIDs)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	staged := []tc.OrphanDeletion{}
+	for _, o := range orphans {
+		d := tc.OrphanDeletion{Type: o.Type, ObjectID: o.ID, Name: o.Name, Reason: o.Reason, StagedBy: inf.User.UserName}
+		err := tx.QueryRow(stageDeletionQuery, d.Type, d.ObjectID, d.Name, d.Reason, d.StagedBy).Scan(&d.ID, &d.Created)
+		if err == sql.ErrNoRows {
+			continue
+		} else if err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("staging deletion of %s #%d: %w", o.Type, o.ID, err))
+			return
+		}
+		staged = append(staged, d)
+	}
+
+	changeLogMsg := fmt.Sprintf("ORPHANS: ACTION: Staged %d deletions of orphaned objects", len(staged))
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+
+	alerts := tc.CreateAlerts(tc.SuccessLevel, fmt.Sprintf("%d deletions of orphaned objects staged for review", len(staged)))
+	api.WriteAlertsObj(w, r, http.StatusCreated, alerts, staged)
+}
+
+// DiscardDeletion is the handler for DELETE requests to
+// /reports/orphans/deletions/{id}, which discards a staged deletion without
+// carrying it out.
+func DiscardDeletion(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	id := inf.IntParams["id"]
+	rows, err := tx.Query(readDeletionsQuery+"WHERE id = $1", id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("querying staged orphan deletion #%d: %w", id, err))
+		return
+	}
+	deletions, err := scanDeletions(rows)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if len(deletions) == 0 {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no staged orphan deletion exists by ID %d", id), nil)
+		return
+	}
+	if _, err := tx.Exec(deleteDeletionQuery, id); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("discarding staged orphan deletion #%d: %w", id, err))
+		return
+	}
+
+	d := deletions[0]
+	changeLogMsg := fmt.Sprintf("ORPHANS: %s %s, ID: %d, ACTION: Discarded staged deletion", strings.ToUpper(string(d.Type)), d.Name, d.ObjectID)
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, fmt.Sprintf("Staged deletion of %s '%s' discarded", d.Type, d.Name), d)
+}
+
+// ApplyDeletions is the handler for POST requests to
+// /reports/orphans/deletions/apply, which carries out the requested staged
+// deletions. Objects which no longer exist, or are no longer orphaned, aren't
+// deleted, but their staged deletions are discarded all the same.
+func ApplyDeletions(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	var req tc.OrphanDeletionsApplyRequest
+	if userErr = api.Parse(r.Body, tx, &req); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+
+	rows, err := tx.Query(readDeletionsQuery+"WHERE id = ANY($1) ORDER BY id", pq.Array(req.IDs))
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("querying staged orphan deletions: %w", err))
+		return
+	}
+	deletions, err := scanDeletions(rows)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if missing := missingIDs(req.IDs, deletions); len(missing) > 0 {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no staged orphan deletions exist by IDs %v", missing), nil)
+		return
+	}
+
+	tenantIDs, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting accessible tenants for user: %w", err))
+		return
+	}
+	orphans, err := getOrphans(tx, tc.OrphanTypes, tenantIDs)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	stillOrphaned := make(map[tc.OrphanType]map[int]struct{}, len(tc.OrphanTypes))
+	for _, o := range orphans {
+		if stillOrphaned[o.Type] == nil {
+			stillOrphaned[o.Type] = map[int]struct{}{}
+		}
+		stillOrphaned[o.Type][o.ID] = struct{}{}
+	}
+
+	applied := []tc.OrphanDeletion{}
+	skipped := []string{}
+	for _, d := range deletions {
+		if _, err := tx.Exec(deleteDeletionQuery, d.ID); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("removing staged orphan deletion #%d: %w", d.ID, err))
+			return
+		}
+		if _, ok := stillOrphaned[d.Type][d.ObjectID]; !ok {
+			skipped = append(skipped, fmt.Sprintf("%s '%s'", d.Type, d.Name))
+			continue
+		}
+		if _, err := tx.Exec(deleteObjectQueries[d.Type], d.ObjectID); err != nil {
+			userErr, sysErr, errCode = api.ParseDBError(err)
+			if sysErr != nil {
+				sysErr = fmt.Errorf("deleting orphaned %s #%d: %w", d.Type, d.ObjectID, sysErr)
+			}
+			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+			return
+		}
+		changeLogMsg := fmt.Sprintf("ORPHANS: %s %s, ID: %d, ACTION: Deleted orphaned object", strings.ToUpper(string(d.Type)), d.Name, d.ObjectID)
+		api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+		applied = append(applied, d)
+	}
+
+	alerts := tc.CreateAlerts(tc.SuccessLevel, fmt.Sprintf("%d orphaned objects deleted", len(applied)))
+	if len(skipped) > 0 {
+		alerts.AddNewAlert(tc.WarnLevel, "no longer orphaned, so not deleted: "+strings.Join(skipped, ", "))
+	}
+	api.WriteAlertsObj(w, r, http.StatusOK, alerts, applied)
+}
+
+// missingIDs returns those of the given IDs which aren't the IDs of any of the
+// given staged deletions.
+func missingIDs(ids []int, deletions []tc.OrphanDeletion) []int {
+	found := make(map[int]struct{}, len(deletions))
+	for _, d := range deletions {
+		found[d.ID] = struct{}{}
+	}
+	missing := []int{}
+	for _, id := range ids {
+		if _, ok := found[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
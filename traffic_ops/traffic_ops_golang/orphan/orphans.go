@@ -0,0 +1,320 @@
+package orphan
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// orphans.go defines the report of unused, or orphaned, objects, such as
+// Parameters which aren't assigned to any Profile.
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+const orphanedParametersQuery = `
+SELECT p.id, p.name, p.config_file
+FROM parameter AS p
+WHERE NOT EXISTS (SELECT 1 FROM profile_parameter AS pp WHERE pp.parameter = p.id)
+AND NOT EXISTS (SELECT 1 FROM cachegroup_parameter AS cgp WHERE cgp.parameter = p.id)
+ORDER BY p.id
+`
+
+// The GLOBAL Profile is never assigned to anything, but is used throughout
+// ATC.
+const orphanedProfilesQuery = `
+SELECT p.id, p.name
+FROM profile AS p
+WHERE p.name <> $1
+AND NOT EXISTS (SELECT 1 FROM server_profile AS sp WHERE sp.profile_name = p.name)
+AND NOT EXISTS (SELECT 1 FROM server AS s WHERE s.profile = p.id)
+AND NOT EXISTS (SELECT 1 FROM deliveryservice AS ds WHERE ds.profile = p.id)
+AND NOT EXISTS (SELECT 1 FROM origin AS o WHERE o.profile = p.id)
+ORDER BY p.id
+`
+
+// Static DNS Entries are only included in CDN Snapshots for Delivery Services
+// which are active, or scheduled for activation.
+const orphanedStaticDNSEntriesQuery = `
+SELECT e.id, e.host, ds.xml_id
+FROM staticdnsentry AS e
+JOIN deliveryservice AS ds ON ds.id = e.deliveryservice
+WHERE NOT ds.active
+AND ds.active_at IS NULL
+AND ds.tenant_id = ANY($1)
+ORDER BY e.id
+`
+
+const orphanedCachegroupsQuery = `
+SELECT cg.id, cg.name
+FROM cachegroup AS cg
+WHERE NOT EXISTS (SELECT 1 FROM server AS s WHERE s.cachegroup = cg.id)
+AND NOT EXISTS (SELECT 1 FROM topology_cachegroup AS tcg WHERE tcg.cachegroup = cg.name)
+AND NOT EXISTS (
+	SELECT 1
+	FROM cachegroup AS child
+	WHERE child.parent_cachegroup_id = cg.id
+	OR child.secondary_parent_cachegroup_id = cg.id
+)
+ORDER BY cg.id
+`
+
+// Regular expressions are checked for validity in Go, so only those of
+// Delivery Services in the user's Tenancy - and those of none - are
+// selected.
+const regexesQuery = `
+SELECT r.id, r.pattern, ds.xml_id
+FROM regex AS r
+LEFT JOIN deliveryservice_regex AS dsr ON dsr.regex = r.id
+LEFT JOIN deliveryservice AS ds ON ds.id = dsr.deliveryservice
+WHERE ds.id IS NULL OR ds.tenant_id = ANY($1)
+ORDER BY r.id
+`
+
+// invalidPatternErrors are the codes of the errors in parsing regular
+// expressions which are errors in Java - which Traffic Router uses - as well
+// as Go. Others, such as those of lookarounds and backreferences, may only be
+// unsupported by Go.
+var invalidPatternErrors = map[syntax.ErrorCode]struct{}{
+	syntax.ErrInvalidCharRange:      {},
+	syntax.ErrMissingBracket:        {},
+	syntax.ErrMissingParen:          {},
+	syntax.ErrMissingRepeatArgument: {},
+	syntax.ErrTrailingBackslash:     {},
+	syntax.ErrUnexpectedParen:       {},
+}
+
+// invalidPattern returns an error if the regular expression pattern is
+// certainly invalid, and so can never match. Patterns which may only be
+// unsupported by Go are assumed to be valid.
+func invalidPattern(pattern string) error {
+	_, err := syntax.Parse(pattern, syntax.Perl)
+	if err == nil {
+		return nil
+	}
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) {
+		if _, ok := invalidPatternErrors[syntaxErr.Code]; !ok {
+			return nil
+		}
+	}
+	return err
+}
+
+// getOrphans returns the orphaned objects of the given types. Static DNS
+// Entries and regular expressions of Delivery Services outside of the given
+// Tenants aren't included.
+func getOrphans(tx *sql.Tx, types []tc.OrphanType, tenantIDs []int) ([]tc.Orphan, error) {
+	orphans := []tc.Orphan{}
+	for _, t := range tc.OrphanTypes {
+		if !hasType(types, t) {
+			continue
+		}
+		var found []tc.Orphan
+		var err error
+		switch t {
+		case tc.OrphanTypeParameter:
+			found, err = getOrphanedParameters(tx)
+		case tc.OrphanTypeProfile:
+			found, err = getOrphanedProfiles(tx)
+		case tc.OrphanTypeStaticDNSEntry:
+			found, err = getOrphanedStaticDNSEntries(tx, tenantIDs)
+		case tc.OrphanTypeCachegroup:
+			found, err = getOrphanedCachegroups(tx)
+		case tc.OrphanTypeRegex:
+			found, err = getOrphanedRegexes(tx, tenantIDs)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("getting orphaned objects of type '%s': %w", t, err)
+		}
+		orphans = append(orphans, found...)
+	}
+	return orphans, nil
+}
+
+func hasType(types []tc.OrphanType, t tc.OrphanType) bool {
+	for _, typ := range types {
+		if typ == t {
+			return true
+		}
+	}
+	return false
+}
+
+func getOrphanedParameters(tx *sql.Tx) ([]tc.Orphan, error) {
+	rows, err := tx.Query(orphanedParametersQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orphans := []tc.Orphan{}
+	for rows.Next() {
+		o := tc.Orphan{Type: tc.OrphanTypeParameter}
+		var configFile string
+		if err := rows.Scan(&o.ID, &o.Name, &configFile); err != nil {
+			return nil, err
+		}
+		o.Reason = fmt.Sprintf("Parameter of config file '%s' is not assigned to any Profile or Cache Group", configFile)
+		orphans = append(orphans, o)
+	}
+	return orphans, rows.Err()
+}
+
+func getOrphanedProfiles(tx *sql.Tx) ([]tc.Orphan, error) {
+	rows, err := tx.Query(orphanedProfilesQuery, tc.GlobalProfileName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orphans := []tc.Orphan{}
+	for rows.Next() {
+		o := tc.Orphan{Type: tc.OrphanTypeProfile, Reason: "Profile is not assigned to any server, Delivery Service, or Origin"}
+		if err := rows.Scan(&o.ID, &o.Name); err != nil {
+			return nil, err
+		}
+		orphans = append(orphans, o)
+	}
+	return orphans, rows.Err()
+}
+
+func getOrphanedStaticDNSEntries(tx *sql.Tx, tenantIDs []int) ([]tc.Orphan, error) {
+	rows, err := tx.Query(orphanedStaticDNSEntriesQuery, pq.Array(tenantIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orphans := []tc.Orphan{}
+	for rows.Next() {
+		o := tc.Orphan{Type: tc.OrphanTypeStaticDNSEntry}
+		var xmlID string
+		if err := rows.Scan(&o.ID, &o.Name, &xmlID); err != nil {
+			return nil, err
+		}
+		o.Reason = fmt.Sprintf("Delivery Service '%s' is inactive, and not scheduled for activation", xmlID)
+		orphans = append(orphans, o)
+	}
+	return orphans, rows.Err()
+}
+
+func getOrphanedCachegroups(tx *sql.Tx) ([]tc.Orphan, error) {
+	rows, err := tx.Query(orphanedCachegroupsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orphans := []tc.Orphan{}
+	for rows.Next() {
+		o := tc.Orphan{Type: tc.OrphanTypeCachegroup, Reason: "Cache Group has no servers, and is not used by any Topology or as the parent of any Cache Group"}
+		if err := rows.Scan(&o.ID, &o.Name); err != nil {
+			return nil, err
+		}
+		orphans = append(orphans, o)
+	}
+	return orphans, rows.Err()
+}
+
+func getOrphanedRegexes(tx *sql.Tx, tenantIDs []int) ([]tc.Orphan, error) {
+	rows, err := tx.Query(regexesQuery, pq.Array(tenantIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orphans := []tc.Orphan{}
+	seen := map[int]struct{}{}
+	for rows.Next() {
+		o := tc.Orphan{Type: tc.OrphanTypeRegex}
+		var xmlID *string
+		if err := rows.Scan(&o.ID, &o.Name, &xmlID); err != nil {
+			return nil, err
+		}
+		if _, ok := seen[o.ID]; ok {
+			continue
+		}
+		if xmlID == nil {
+			o.Reason = "regular expression is not used by any Delivery Service"
+		} else if err := invalidPattern(o.Name); err != nil {
+			o.Reason = fmt.Sprintf("regular expression of Delivery Service '%s' is invalid: %v", *xmlID, err)
+		} else {
+			continue
+		}
+		seen[o.ID] = struct{}{}
+		orphans = append(orphans, o)
+	}
+	return orphans, rows.Err()
+}
+
+// parseTypes parses the comma-separated types of orphaned objects of the
+// "types" query parameter, which are all types if it's not given.
+func parseTypes(params map[string]string) ([]tc.OrphanType, error) {
+	param, ok := params[tc.OrphanTypesQueryParam]
+	if !ok {
+		return tc.OrphanTypes, nil
+	}
+	types := []tc.OrphanType{}
+	for _, s := range strings.Split(param, ",") {
+		t := tc.OrphanType(strings.TrimSpace(s))
+		if err := t.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", tc.OrphanTypesQueryParam, err)
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+// Get is the handler for GET requests to /reports/orphans.
+func Get(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	types, err := parseTypes(inf.Params)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	tenantIDs, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting accessible tenants for user: %w", err))
+		return
+	}
+	orphans, err := getOrphans(tx, types, tenantIDs)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, orphans)
+}
@@ -0,0 +1,81 @@
+package orphan
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestInvalidPattern(t *testing.T) {
+	for _, valid := range []string{
+		`.*\.demo1\..*`,
+		`/path/.*`,
+		`^(?!/private/).*$`,
+		`(\w+)\1`,
+		`a{1001}`,
+		"",
+	} {
+		if err := invalidPattern(valid); err != nil {
+			t.Errorf("expected pattern '%s' to be treated as valid, got: %v", valid, err)
+		}
+	}
+	for _, invalid := range []string{
+		`.*\.demo1\.(.*`,
+		`[a-z`,
+		`*.demo1.*`,
+		`[z-a]`,
+		`demo1)`,
+		`demo1\`,
+	} {
+		if err := invalidPattern(invalid); err == nil {
+			t.Errorf("expected pattern '%s' to be invalid", invalid)
+		}
+	}
+}
+
+func TestParseTypes(t *testing.T) {
+	types, err := parseTypes(map[string]string{})
+	if err != nil || !reflect.DeepEqual(types, tc.OrphanTypes) {
+		t.Errorf("expected all types without the query parameter, got: %v, %v", types, err)
+	}
+
+	types, err = parseTypes(map[string]string{tc.OrphanTypesQueryParam: "profile, regex"})
+	expected := []tc.OrphanType{tc.OrphanTypeProfile, tc.OrphanTypeRegex}
+	if err != nil || !reflect.DeepEqual(types, expected) {
+		t.Errorf("expected types %v, got: %v, %v", expected, types, err)
+	}
+
+	if _, err = parseTypes(map[string]string{tc.OrphanTypesQueryParam: "profile,server"}); err == nil {
+		t.Error("expected an error for an invalid type")
+	}
+}
+
+func TestMissingIDs(t *testing.T) {
+	deletions := []tc.OrphanDeletion{{ID: 1}, {ID: 3}}
+	if missing := missingIDs([]int{1, 2, 3, 4}, deletions); !reflect.DeepEqual(missing, []int{2, 4}) {
+		t.Errorf("expected missing IDs [2 4], got %v", missing)
+	}
+	if missing := missingIDs([]int{3}, deletions); len(missing) != 0 {
+		t.Errorf("expected no missing IDs, got %v", missing)
+	}
+}
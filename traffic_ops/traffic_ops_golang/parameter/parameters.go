@@ -23,6 +23,7 @@ import (
 	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-atscfg"
@@ -152,6 +153,23 @@ func (param *TOParameter) Read(h http.Header, useIMS bool) ([]interface{}, error
 	if len(errs) > 0 {
 		return nil, util.JoinErrs(errs), nil, http.StatusBadRequest, nil
 	}
+	where, orderBy, pagination, page, err := param.APIInfo().BuildCursorPagination(queryParamsToQueryCols, where, orderBy, pagination, queryValues)
+	if err != nil {
+		return nil, err, nil, http.StatusBadRequest, nil
+	}
+	query := selectQuery()
+	if version := param.APIInfo().Version; version != nil && version.Major >= 5 {
+		asOf, err := dbhelpers.ParseAsOf(param.APIInfo().Params)
+		if err != nil {
+			return nil, err, nil, http.StatusBadRequest, nil
+		}
+		if asOf != nil {
+			// the past doesn't change, so there's nothing to compare to If-Modified-Since
+			useIMS = false
+			queryValues[dbhelpers.AsOfQueryParam] = *asOf
+			query = strings.Replace(query, "FROM parameter p", "FROM "+dbhelpers.TableAsOf("parameter")+" AS p", 1)
+		}
+	}
 	if useIMS {
 		runSecond, maxTime = ims.TryIfModifiedSinceQuery(param.APIInfo().Tx, h, queryValues, param.SelectMaxLastUpdatedQuery(where, orderBy, pagination, "parameter"))
 		if !runSecond {
@@ -162,7 +180,7 @@ func (param *TOParameter) Read(h http.Header, useIMS bool) ([]interface{}, error
 	} else {
 		log.Debugln("Non IMS request")
 	}
-	query := selectQuery() + where + ParametersGroupBy() + orderBy + pagination
+	query += where + ParametersGroupBy() + orderBy + pagination
 	rows, err := param.ReqInfo.Tx.NamedQuery(query, queryValues)
 	if err != nil {
 		return nil, nil, errors.New("querying " + param.GetType() + ": " + err.Error()), http.StatusInternalServerError, nil
@@ -186,6 +204,7 @@ func (param *TOParameter) Read(h http.Header, useIMS bool) ([]interface{}, error
 		}
 		params = append(params, p)
 	}
+	param.APIInfo().Pagination = dbhelpers.CursorPagination(page, params)
 
 	return params, nil, nil, code, &maxTime
 }
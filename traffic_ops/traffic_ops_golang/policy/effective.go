@@ -0,0 +1,115 @@
+package policy
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+
+	"github.com/lib/pq"
+)
+
+// The user's Tenant is in a reviewed Tenant if it or any of its ancestors is.
+const reviewedTenantQuery = `
+WITH RECURSIVE ancestors AS (
+	SELECT id, name, parent_id
+	FROM tenant
+	WHERE id = $1
+UNION ALL
+	SELECT t.id, t.name, t.parent_id
+	FROM tenant t
+	JOIN ancestors a ON t.id = a.parent_id
+)
+SELECT EXISTS(SELECT 1 FROM ancestors WHERE name = ANY($2))
+`
+
+// ReviewRequired returns whether the changes the requesting user makes to
+// protected Delivery Service fields are submitted for review as Delivery
+// Service Requests rather than rejected, according to the
+// delivery_service_review section of the Traffic Ops configuration.
+func ReviewRequired(inf *api.APIInfo) (bool, error) {
+	if inf.Config == nil || inf.Config.DeliveryServiceReview == nil {
+		return false, nil
+	}
+	cfg := inf.Config.DeliveryServiceReview
+	for _, role := range cfg.Roles {
+		if role == inf.User.RoleName {
+			return true, nil
+		}
+	}
+	if len(cfg.Tenants) == 0 {
+		return false, nil
+	}
+	reviewed := false
+	if err := inf.Tx.Tx.QueryRow(reviewedTenantQuery, inf.User.TenantID, pq.Array(cfg.Tenants)).Scan(&reviewed); err != nil {
+		return false, fmt.Errorf("checking whether Tenant #%d is reviewed: %w", inf.User.TenantID, err)
+	}
+	return reviewed, nil
+}
+
+// GetEffective is the handler for GET requests to /policies/effective. It
+// responds with the fields of each type of object that can be protected that
+// the requesting user may and may not modify.
+func GetEffective(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	reviewRequired, err := ReviewRequired(inf)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	effective := []tc.EffectiveFieldPolicy{}
+	for _, objectType := range []string{tc.FieldPolicyObjectTypeDeliveryService, tc.FieldPolicyObjectTypeServer} {
+		protected, err := ProtectedFields(inf.Tx.Tx, inf.User, objectType)
+		if err != nil {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+			return
+		}
+		effective = append(effective, tc.EffectiveFieldPolicy{
+			ObjectType:       objectType,
+			ModifiableFields: modifiableFields(Fields(objectType), protected),
+			ProtectedFields:  protected,
+			ReviewRequired:   reviewRequired && objectType == tc.FieldPolicyObjectTypeDeliveryService,
+		})
+	}
+	api.WriteResp(w, r, effective)
+}
+
+// modifiableFields returns the given sorted fields that aren't among the given
+// sorted protected fields.
+func modifiableFields(fields, protected []string) []string {
+	modifiable := []string{}
+	for _, field := range fields {
+		if i := sort.SearchStrings(protected, field); i >= len(protected) || protected[i] != field {
+			modifiable = append(modifiable, field)
+		}
+	}
+	return modifiable
+}
@@ -0,0 +1,282 @@
+// Package policy implements field policies, which protect fields of Delivery
+// Services and servers from modification by the users of Roles, along with
+// the /policies Traffic Ops API endpoints through which they're managed, and
+// the redaction of fields of Delivery Services and servers from the users of
+// Tenants with read views.
+package policy
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+
+	validation "github.com/go-ozzo/ozzo-validation"
+	"github.com/lib/pq"
+)
+
+// Resource handles the /policies endpoint.
+var Resource = api.Resource[policy, *policy]{
+	Type:              "field policy",
+	Table:             "field_policy",
+	Columns:           columns,
+	From:              from,
+	LastUpdatedColumn: "fp.last_updated",
+	Filters: map[string]dbhelpers.WhereColumnInfo{
+		"field":      dbhelpers.WhereColumnInfo{Column: "fp.field"},
+		"id":         dbhelpers.WhereColumnInfo{Column: "fp.id", Checker: api.IsInt},
+		"objectType": dbhelpers.WhereColumnInfo{Column: "fp.object_type"},
+		"role":       dbhelpers.WhereColumnInfo{Column: "r.name"},
+	},
+	DefaultSort: "role",
+	InsertQuery: insertQuery,
+	UpdateQuery: updateQuery,
+	DeleteQuery: deleteQuery,
+	Validate:    validate,
+}
+
+type policy struct {
+	tc.FieldPolicy
+}
+
+func (p *policy) GetID() (int, bool) {
+	if p.ID == nil {
+		return 0, false
+	}
+	return *p.ID, true
+}
+
+func (p *policy) SetID(id int)                  { p.ID = &id }
+func (p *policy) SetLastUpdated(t tc.TimeNoMod) { p.LastUpdated = &t }
+
+func (p *policy) GetAuditName() string {
+	if p.Role != nil && p.ObjectType != nil && p.Field != nil {
+		return *p.Role + " " + *p.ObjectType + "." + *p.Field
+	}
+	if p.ID != nil {
+		return strconv.Itoa(*p.ID)
+	}
+	return "0"
+}
+
+// readOnlyFields are, for each type of object that can be protected, the
+// fields of its API representation that are set by Traffic Ops rather than
+// modified by users, which can't be protected.
+var readOnlyFields = map[string][]string{
+	tc.FieldPolicyObjectTypeDeliveryService: {
+		"cdnName",
+		"exampleURLs",
+		"id",
+		"lastUpdated",
+		"matchList",
+		"profileDescription",
+		"profileName",
+		"tenant",
+		"type",
+	},
+	tc.FieldPolicyObjectTypeServer: {
+		"cachegroup",
+		"cdnName",
+		"configApplyTime",
+		"configUpdateTime",
+		"id",
+		"lastUpdated",
+		"physLocation",
+		"revalApplyTime",
+		"revalPending",
+		"revalUpdateTime",
+		"status",
+		"statusLastUpdated",
+		"type",
+		"updPending",
+		"xmppId",
+	},
+}
+
+// objectTypes are the representations of the types of objects that can be
+// protected.
+var objectTypes = map[string]interface{}{
+	tc.FieldPolicyObjectTypeDeliveryService: tc.DeliveryServiceV4{},
+	tc.FieldPolicyObjectTypeServer:          tc.ServerV40{},
+}
+
+// Fields returns the sorted names of the fields of the given type of object
+// that can be protected, or nil if it can't be protected.
+func Fields(objectType string) []string {
+	obj, ok := objectTypes[objectType]
+	if !ok {
+		return nil
+	}
+	values, err := fieldValues(obj)
+	if err != nil {
+		return nil
+	}
+	for _, field := range readOnlyFields[objectType] {
+		delete(values, field)
+	}
+	fields := make([]string, 0, len(values))
+	for field := range values {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func validate(inf *api.APIInfo, p *policy) (error, error) {
+	errs := validation.Errors{
+		"role":       validation.Validate(p.Role, validation.Required),
+		"objectType": validation.Validate(p.ObjectType, validation.Required, validation.In(tc.FieldPolicyObjectTypeDeliveryService, tc.FieldPolicyObjectTypeServer)),
+		"field":      validation.Validate(p.Field, validation.Required),
+	}
+	if err := util.JoinErrs(tovalidate.ToErrors(errs)); err != nil {
+		return err, nil
+	}
+
+	fields := Fields(*p.ObjectType)
+	if i := sort.SearchStrings(fields, *p.Field); i >= len(fields) || fields[i] != *p.Field {
+		return fmt.Errorf("field: '%s' is not a field of %s objects that can be protected", *p.Field, *p.ObjectType), nil
+	}
+	if *p.Role == tc.AdminRoleName {
+		return errors.New("role: the fields the admin Role may modify can't be restricted"), nil
+	}
+	if _, ok, err := dbhelpers.GetRoleIDFromName(inf.Tx.Tx, *p.Role); err != nil {
+		return nil, fmt.Errorf("checking existence of Role '%s': %w", *p.Role, err)
+	} else if !ok {
+		return fmt.Errorf("role: no such Role '%s'", *p.Role), nil
+	}
+	return nil, nil
+}
+
+// ProtectedFields returns the sorted names of the fields of the given type of
+// object that the given user may not modify.
+func ProtectedFields(tx *sql.Tx, user *auth.CurrentUser, objectType string) ([]string, error) {
+	if user.RoleName == tc.AdminRoleName {
+		return []string{}, nil
+	}
+	fields := []string{}
+	if err := tx.QueryRow(protectedFieldsQuery, user.Role, objectType).Scan(pq.Array(&fields)); err != nil {
+		return nil, fmt.Errorf("querying %s fields protected from Role #%d: %w", objectType, user.Role, err)
+	}
+	// The database's collation may not order them the same way.
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// ChangedFields returns the names of the given fields which differ between
+// the original and requested objects, which must be representations of the
+// same type of object. Fields that are null, empty strings, and empty arrays
+// are considered to be equal. To check the fields set in a request to create
+// an object, use the object type's zero value as the original.
+func ChangedFields(fields []string, original, requested interface{}) ([]string, error) {
+	orig, err := fieldValues(original)
+	if err != nil {
+		return nil, fmt.Errorf("encoding original object: %w", err)
+	}
+	req, err := fieldValues(requested)
+	if err != nil {
+		return nil, fmt.Errorf("encoding requested object: %w", err)
+	}
+	changed := []string{}
+	for _, field := range fields {
+		if !reflect.DeepEqual(orig[field], req[field]) {
+			changed = append(changed, field)
+		}
+	}
+	return changed, nil
+}
+
+// ProtectedFieldsError returns the error shown to users whose changes to the
+// given protected fields are rejected.
+func ProtectedFieldsError(objectType string, changed []string) error {
+	return fmt.Errorf("the user's Role may not modify these fields of %s objects: %s", objectType, strings.Join(changed, ", "))
+}
+
+// fieldValues returns the values of the fields of an object's API
+// representation, by name, with null, empty strings, and empty arrays all
+// represented by nil.
+func fieldValues(obj interface{}) (map[string]interface{}, error) {
+	bts, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]interface{}{}
+	if err := json.Unmarshal(bts, &values); err != nil {
+		return nil, err
+	}
+	for name, value := range values {
+		switch v := value.(type) {
+		case string:
+			if v == "" {
+				values[name] = nil
+			}
+		case []interface{}:
+			if len(v) == 0 {
+				values[name] = nil
+			}
+		}
+	}
+	return values, nil
+}
+
+const columns = `fp.id,
+r.name AS role_name,
+fp.object_type,
+fp.field,
+fp.last_updated`
+
+const from = `FROM field_policy fp
+JOIN "role" r ON r.id = fp."role"`
+
+const insertQuery = `INSERT INTO field_policy (
+"role",
+object_type,
+field) VALUES (
+(SELECT id FROM "role" WHERE name = :role_name),
+:object_type,
+:field) RETURNING id,last_updated`
+
+const updateQuery = `UPDATE
+field_policy SET
+"role"=(SELECT id FROM "role" WHERE name = :role_name),
+object_type=:object_type,
+field=:field
+WHERE id=:id RETURNING last_updated`
+
+const deleteQuery = `DELETE FROM field_policy WHERE id = :id`
+
+const protectedFieldsQuery = `
+SELECT COALESCE(ARRAY_AGG(field), '{}'::text[])
+FROM field_policy
+WHERE "role" = $1
+AND object_type = $2
+`
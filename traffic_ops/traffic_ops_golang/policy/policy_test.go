@@ -0,0 +1,208 @@
+package policy
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestFields(t *testing.T) {
+	for _, objectType := range []string{tc.FieldPolicyObjectTypeDeliveryService, tc.FieldPolicyObjectTypeServer} {
+		fields := Fields(objectType)
+		if len(fields) == 0 {
+			t.Errorf("expected %s fields that can be protected", objectType)
+		}
+		if !sort.StringsAreSorted(fields) {
+			t.Errorf("expected %s fields to be sorted, got %v", objectType, fields)
+		}
+		for _, field := range fields {
+			for _, readOnly := range readOnlyFields[objectType] {
+				if field == readOnly {
+					t.Errorf("expected read-only %s field '%s' not to be protectable", objectType, field)
+				}
+			}
+		}
+	}
+	if fields := Fields("cdn"); fields != nil {
+		t.Errorf("expected no fields for an unknown object type, got %v", fields)
+	}
+}
+
+func TestChangedFields(t *testing.T) {
+	original := tc.DeliveryServiceV4{}
+	original.XMLID = util.StrPtr("ds1")
+	original.DisplayName = util.StrPtr("DS 1")
+	original.OrgServerFQDN = util.StrPtr("http://origin.example")
+	original.TLSVersions = []string{}
+
+	requested := original
+	requested.DisplayName = util.StrPtr("Delivery Service 1")
+	requested.RegexRemap = util.StrPtr("")
+	requested.TLSVersions = nil
+
+	protected := []string{"orgServerFqdn", "regexRemap", "tlsVersions", "xmlId"}
+	changed, err := ChangedFields(protected, original, requested)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changed) != 0 {
+		t.Errorf("expected no protected fields to have changed, got %v", changed)
+	}
+
+	requested.OrgServerFQDN = util.StrPtr("http://other-origin.example")
+	requested.TLSVersions = []string{"1.3"}
+	changed, err = ChangedFields(protected, original, requested)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"orgServerFqdn", "tlsVersions"}; !reflect.DeepEqual(changed, expected) {
+		t.Errorf("expected changed protected fields %v, got %v", expected, changed)
+	}
+
+	changed, err = ChangedFields(protected, tc.DeliveryServiceV4{}, requested)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"orgServerFqdn", "tlsVersions", "xmlId"}; !reflect.DeepEqual(changed, expected) {
+		t.Errorf("expected the protected fields set on creation to be %v, got %v", expected, changed)
+	}
+}
+
+func TestModifiableFields(t *testing.T) {
+	modifiable := modifiableFields([]string{"a", "b", "c", "d"}, []string{"b", "d"})
+	if expected := []string{"a", "c"}; !reflect.DeepEqual(modifiable, expected) {
+		t.Errorf("expected modifiable fields %v, got %v", expected, modifiable)
+	}
+}
+
+func TestProtectedFields(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	mock.ExpectBegin()
+	tx := db.MustBegin().Tx
+
+	admin := auth.CurrentUser{RoleName: tc.AdminRoleName, Role: 1}
+	if fields, err := ProtectedFields(tx, &admin, tc.FieldPolicyObjectTypeServer); err != nil || len(fields) != 0 {
+		t.Errorf("expected no fields to be protected from the admin Role, got %v, %v", fields, err)
+	}
+
+	mock.ExpectQuery("field_policy").WithArgs(2, tc.FieldPolicyObjectTypeServer).WillReturnRows(sqlmock.NewRows([]string{"fields"}).AddRow("{typeId,cdnId}"))
+	user := auth.CurrentUser{RoleName: "tenant", Role: 2}
+	fields, err := ProtectedFields(tx, &user, tc.FieldPolicyObjectTypeServer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{"cdnId", "typeId"}; !reflect.DeepEqual(fields, expected) {
+		t.Errorf("expected protected fields %v, got %v", expected, fields)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	mock.ExpectBegin()
+	inf := api.APIInfo{Tx: db.MustBegin()}
+
+	invalid := []tc.FieldPolicy{
+		{Role: util.StrPtr("tenant"), ObjectType: util.StrPtr("cdn"), Field: util.StrPtr("name")},
+		{Role: util.StrPtr("tenant"), ObjectType: util.StrPtr(tc.FieldPolicyObjectTypeServer), Field: util.StrPtr("nope")},
+		{Role: util.StrPtr("tenant"), ObjectType: util.StrPtr(tc.FieldPolicyObjectTypeServer), Field: util.StrPtr("updPending")},
+		{Role: util.StrPtr(tc.AdminRoleName), ObjectType: util.StrPtr(tc.FieldPolicyObjectTypeServer), Field: util.StrPtr("cdnId")},
+		{ObjectType: util.StrPtr(tc.FieldPolicyObjectTypeServer), Field: util.StrPtr("cdnId")},
+	}
+	for _, p := range invalid {
+		if userErr, sysErr := validate(&inf, &policy{p}); userErr == nil || sysErr != nil {
+			t.Errorf("expected a user error for policy %+v, got %v, %v", p, userErr, sysErr)
+		}
+	}
+
+	mock.ExpectQuery("role").WithArgs("tenant").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	valid := tc.FieldPolicy{Role: util.StrPtr("tenant"), ObjectType: util.StrPtr(tc.FieldPolicyObjectTypeDeliveryService), Field: util.StrPtr("typeId")}
+	if userErr, sysErr := validate(&inf, &policy{valid}); userErr != nil || sysErr != nil {
+		t.Errorf("expected policy %+v to be valid, got %v, %v", valid, userErr, sysErr)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
+
+func TestReviewRequired(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+	db := sqlx.NewDb(mockDB, "sqlmock")
+
+	mock.ExpectBegin()
+	inf := api.APIInfo{
+		Config: &config.Config{},
+		Tx:     db.MustBegin(),
+		User:   &auth.CurrentUser{RoleName: "operations", TenantID: 3},
+	}
+
+	if required, err := ReviewRequired(&inf); err != nil || required {
+		t.Errorf("expected no review without configuration, got %t, %v", required, err)
+	}
+
+	inf.Config.DeliveryServiceReview = &config.ConfigDeliveryServiceReview{Roles: []string{"operations"}}
+	if required, err := ReviewRequired(&inf); err != nil || !required {
+		t.Errorf("expected review for a configured Role, got %t, %v", required, err)
+	}
+
+	inf.Config.DeliveryServiceReview = &config.ConfigDeliveryServiceReview{Roles: []string{"read-only"}, Tenants: []string{"customer"}}
+	mock.ExpectQuery("WITH RECURSIVE ancestors").WithArgs(3, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	if required, err := ReviewRequired(&inf); err != nil || !required {
+		t.Errorf("expected review for a user of a configured Tenant, got %t, %v", required, err)
+	}
+
+	mock.ExpectQuery("WITH RECURSIVE ancestors").WithArgs(3, sqlmock.AnyArg()).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	if required, err := ReviewRequired(&inf); err != nil || required {
+		t.Errorf("expected no review for a user of another Tenant, got %t, %v", required, err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
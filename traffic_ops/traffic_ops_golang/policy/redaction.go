@@ -0,0 +1,153 @@
+package policy
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// redactableTypes are the latest representations of the types of objects
+// whose fields can be redacted.
+var redactableTypes = map[string]reflect.Type{
+	tc.FieldPolicyObjectTypeDeliveryService: reflect.TypeOf(tc.DeliveryServiceV4{}),
+	tc.FieldPolicyObjectTypeServer:          reflect.TypeOf(tc.ServerV41{}),
+}
+
+// RedactableField returns whether the given field is a field of the API
+// representation of the given type of object, and so can be redacted. The
+// fields of objects within it - or of the objects in arrays within it - are
+// named by their paths, separated by periods, e.g. "interfaces.ipAddresses".
+// Identifying fields can't be redacted.
+func RedactableField(objectType, field string) bool {
+	t, ok := redactableTypes[objectType]
+	if !ok || field == "id" {
+		return false
+	}
+	if objectType == tc.FieldPolicyObjectTypeDeliveryService && field == "xmlId" {
+		return false
+	}
+	if objectType == tc.FieldPolicyObjectTypeServer && field == "hostName" {
+		return false
+	}
+	return hasJSONField(t, strings.Split(field, "."))
+}
+
+// hasJSONField returns whether the JSON encoding of the given type has the
+// field at the given path.
+func hasJSONField(t reflect.Type, path []string) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if len(path) == 0 {
+		return true
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			if hasJSONField(f.Type, path) {
+				return true
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if name == path[0] {
+			return hasJSONField(f.Type, path[1:])
+		}
+	}
+	return false
+}
+
+// Redact returns the API representation of the given object - or array of
+// objects - with the given fields removed, as described by RedactableField.
+// If there are no fields to remove, the object is returned as it is.
+func Redact(obj interface{}, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return obj, nil
+	}
+	bts, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var redacted interface{}
+	dec := json.NewDecoder(bytes.NewReader(bts))
+	dec.UseNumber()
+	if err := dec.Decode(&redacted); err != nil {
+		return nil, err
+	}
+	for _, field := range fields {
+		removeField(redacted, strings.Split(field, "."))
+	}
+	return redacted, nil
+}
+
+// RedactInPlace is like Redact, but removes the given fields from the object
+// - or array of objects - to which the given pointer points, by setting them
+// to their zero values. This is for representations of objects other than
+// their JSON API representations, which can't omit fields.
+func RedactInPlace(ptr interface{}, fields []string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	redacted, err := Redact(ptr, fields)
+	if err != nil {
+		return err
+	}
+	bts, err := json.Marshal(redacted)
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(ptr).Elem()
+	v.Set(reflect.Zero(v.Type()))
+	return json.Unmarshal(bts, ptr)
+}
+
+// removeField removes the field at the given path from the given decoded
+// JSON value, and from each element of arrays along the path.
+func removeField(v interface{}, path []string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if len(path) == 1 {
+			delete(v, path[0])
+			return
+		}
+		removeField(v[path[0]], path[1:])
+	case []interface{}:
+		for _, elem := range v {
+			removeField(elem, path)
+		}
+	}
+}
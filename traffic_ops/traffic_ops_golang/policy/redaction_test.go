@@ -0,0 +1,147 @@
+package policy
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+func TestRedactableField(t *testing.T) {
+	tests := []struct {
+		objectType string
+		field      string
+		expected   bool
+	}{
+		{tc.FieldPolicyObjectTypeDeliveryService, "orgServerFqdn", true},
+		{tc.FieldPolicyObjectTypeDeliveryService, "remapText", true},
+		{tc.FieldPolicyObjectTypeDeliveryService, "tenantId", true},
+		{tc.FieldPolicyObjectTypeDeliveryService, "xmlId", false},
+		{tc.FieldPolicyObjectTypeDeliveryService, "id", false},
+		{tc.FieldPolicyObjectTypeDeliveryService, "notAField", false},
+		{tc.FieldPolicyObjectTypeServer, "interfaces", true},
+		{tc.FieldPolicyObjectTypeServer, "interfaces.ipAddresses", true},
+		{tc.FieldPolicyObjectTypeServer, "interfaces.ipAddresses.address", true},
+		{tc.FieldPolicyObjectTypeServer, "interfaces.notAField", false},
+		{tc.FieldPolicyObjectTypeServer, "iloIpAddress", true},
+		{tc.FieldPolicyObjectTypeServer, "hostName", false},
+		{tc.FieldPolicyObjectTypeServer, "lastUpdated.wall", false},
+		{"cdn", "name", false},
+	}
+	for _, test := range tests {
+		if actual := RedactableField(test.objectType, test.field); actual != test.expected {
+			t.Errorf("expected RedactableField(%q, %q) to be %t", test.objectType, test.field, test.expected)
+		}
+	}
+}
+
+func TestRedact(t *testing.T) {
+	server := tc.ServerV41{}
+	server.ID = util.IntPtr(1)
+	server.HostName = util.StrPtr("edge")
+	server.ILOIPAddress = util.StrPtr("192.0.2.10")
+	server.Interfaces = []tc.ServerInterfaceInfoV40{
+		{ServerInterfaceInfo: tc.ServerInterfaceInfo{
+			Name:        "eth0",
+			IPAddresses: []tc.ServerIPAddress{{Address: "192.0.2.1", ServiceAddress: true}},
+		}},
+		{ServerInterfaceInfo: tc.ServerInterfaceInfo{
+			Name:        "eth1",
+			IPAddresses: []tc.ServerIPAddress{{Address: "192.0.2.2"}},
+		}},
+	}
+
+	redacted, err := Redact([]tc.ServerV41{server}, []string{"iloIpAddress", "interfaces.ipAddresses"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bts, err := json.Marshal(redacted)
+	if err != nil {
+		t.Fatalf("encoding redacted servers: %v", err)
+	}
+	var servers []map[string]interface{}
+	if err := json.Unmarshal(bts, &servers); err != nil {
+		t.Fatalf("decoding redacted servers: %v", err)
+	}
+	if len(servers) != 1 {
+		t.Fatalf("expected 1 server, got %d", len(servers))
+	}
+	if _, ok := servers[0]["iloIpAddress"]; ok {
+		t.Error("expected iloIpAddress to be removed")
+	}
+	if servers[0]["hostName"] != "edge" {
+		t.Errorf("expected hostName to be kept, got %v", servers[0]["hostName"])
+	}
+	interfaces, ok := servers[0]["interfaces"].([]interface{})
+	if !ok || len(interfaces) != 2 {
+		t.Fatalf("expected 2 interfaces to be kept, got %v", servers[0]["interfaces"])
+	}
+	for _, inf := range interfaces {
+		inf := inf.(map[string]interface{})
+		if _, ok := inf["ipAddresses"]; ok {
+			t.Errorf("expected ipAddresses to be removed from interface %v", inf["name"])
+		}
+		if _, ok := inf["name"]; !ok {
+			t.Error("expected interface name to be kept")
+		}
+	}
+
+	if obj, err := Redact(server, nil); err != nil {
+		t.Errorf("unexpected error with no fields to redact: %v", err)
+	} else if _, ok := obj.(tc.ServerV41); !ok {
+		t.Errorf("expected object to be returned as it is with no fields to redact, got %T", obj)
+	}
+}
+
+func TestRedactInPlace(t *testing.T) {
+	server := tc.ServerV41{}
+	server.ID = util.IntPtr(1)
+	server.HostName = util.StrPtr("edge")
+	server.ILOIPAddress = util.StrPtr("192.0.2.10")
+	server.Interfaces = []tc.ServerInterfaceInfoV40{
+		{ServerInterfaceInfo: tc.ServerInterfaceInfo{
+			Name:        "eth0",
+			IPAddresses: []tc.ServerIPAddress{{Address: "192.0.2.1", ServiceAddress: true}},
+		}},
+	}
+	servers := []tc.ServerV41{server}
+
+	if err := RedactInPlace(&servers, []string{"iloIpAddress", "interfaces.ipAddresses"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(servers) != 1 {
+		t.Fatalf("expected 1 server, got %d", len(servers))
+	}
+	if servers[0].ILOIPAddress != nil {
+		t.Errorf("expected iloIpAddress to be removed, got %s", *servers[0].ILOIPAddress)
+	}
+	if servers[0].HostName == nil || *servers[0].HostName != "edge" {
+		t.Errorf("expected hostName to be kept, got %v", servers[0].HostName)
+	}
+	if len(servers[0].Interfaces) != 1 || servers[0].Interfaces[0].Name != "eth0" {
+		t.Fatalf("expected interface eth0 to be kept, got %+v", servers[0].Interfaces)
+	}
+	if len(servers[0].Interfaces[0].IPAddresses) != 0 {
+		t.Errorf("expected ipAddresses to be removed, got %+v", servers[0].Interfaces[0].IPAddresses)
+	}
+}
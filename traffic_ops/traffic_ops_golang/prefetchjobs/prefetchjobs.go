@@ -0,0 +1,518 @@
+// Package prefetchjobs handles prefetch jobs, which fetch the content of
+// Delivery Services into the cache servers of Cache Groups ahead of its being
+// requested - e.g. ahead of a launch. The jobs are executed by t3c-agent on
+// each cache server, which reports its progress back to Traffic Ops.
+package prefetchjobs
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+// prefetchServersQuery selects the cache servers which fetch the content of
+// the prefetch job j, of the Delivery Service ds.
+const prefetchServersQuery = `
+SELECT s.id
+FROM server AS s
+JOIN type AS t ON t.id = s.type
+JOIN status AS st ON st.id = s.status
+WHERE s.cdn_id = ds.cdn_id
+AND s.cachegroup IN (SELECT jc.cachegroup FROM prefetch_job_cachegroup AS jc WHERE jc.job = j.id)
+AND (t.name LIKE '` + tc.EdgeTypePrefix + `%' OR t.name LIKE '` + tc.MidTypePrefix + `%')
+AND st.name IN ('` + string(tc.CacheStatusOnline) + `', '` + string(tc.CacheStatusReported) + `')
+`
+
+// selectJobsQuery selects the prefetch jobs of Delivery Services of the
+// given tenants, optionally only the one with the given ID, those of the
+// Delivery Service with the given ID, or those the cache server with the
+// given ID has yet to finish.
+const selectJobsQuery = `
+SELECT j.id, j.deliveryservice, ds.xml_id, j.urls, j.manifest_urls,
+	ARRAY(
+		SELECT cg.name
+		FROM prefetch_job_cachegroup AS jc
+		JOIN cachegroup AS cg ON cg.id = jc.cachegroup
+		WHERE jc.job = j.id
+		ORDER BY cg.name
+	),
+	j.start_time, COALESCE(u.username, ''),
+	(SELECT COUNT(*) FROM (` + prefetchServersQuery + `) AS servers),
+	j.last_updated
+FROM prefetch_job AS j
+JOIN deliveryservice AS ds ON ds.id = j.deliveryservice
+LEFT JOIN tm_user AS u ON u.id = j.created_by
+WHERE ds.tenant_id = ANY($1::bigint[])
+AND ($2::bigint IS NULL OR j.id = $2)
+AND ($3::bigint IS NULL OR j.deliveryservice = $3)
+AND ($4::bigint IS NULL OR (
+	j.start_time <= now()
+	AND $4 IN (` + prefetchServersQuery + `)
+	AND NOT EXISTS (SELECT 1 FROM prefetch_job_progress AS p WHERE p.job = j.id AND p.server = $4 AND p.done)
+))
+ORDER BY j.id
+`
+
+const selectProgressQuery = `
+SELECT p.job, p.server, s.host_name, p.total, p.fetched, p.failed, p.failed_urls, p.done, p.last_updated
+FROM prefetch_job_progress AS p
+JOIN server AS s ON s.id = p.server
+WHERE p.job = ANY($1::bigint[])
+ORDER BY p.job, s.host_name
+`
+
+const selectDSQuery = `
+SELECT ds.xml_id, ds.tenant_id, c.name, t.name,
+	ARRAY(
+		SELECT r.pattern
+		FROM deliveryservice_regex AS dsr
+		JOIN regex AS r ON r.id = dsr.regex
+		JOIN type AS rt ON rt.id = r.type
+		WHERE dsr.deliveryservice = ds.id
+		AND rt.name = '` + string(tc.DSMatchTypeHostRegex) + `'
+	)
+FROM deliveryservice AS ds
+JOIN cdn AS c ON c.id = ds.cdn_id
+JOIN type AS t ON t.id = ds.type
+WHERE ds.id = $1
+`
+
+// selectCacheGroupIDsQuery returns the IDs of the Cache Groups with the given
+// names.
+const selectCacheGroupIDsQuery = `
+SELECT ARRAY(SELECT cg.id FROM cachegroup AS cg WHERE cg.name = ANY($1::text[]))
+`
+
+// unknownCacheGroupsQuery returns those of the given names which aren't the
+// names of Cache Groups.
+const unknownCacheGroupsQuery = `
+SELECT ARRAY(
+	SELECT n.name
+	FROM UNNEST($1::text[]) AS n(name)
+	WHERE NOT EXISTS (SELECT 1 FROM cachegroup AS cg WHERE cg.name = n.name)
+	ORDER BY n.name
+)
+`
+
+const insertJobQuery = `
+INSERT INTO prefetch_job (deliveryservice, urls, manifest_urls, start_time, created_by)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id
+`
+
+const insertJobCacheGroupsQuery = `
+INSERT INTO prefetch_job_cachegroup (job, cachegroup)
+SELECT $1, UNNEST($2::bigint[])
+`
+
+const deleteJobQuery = `
+DELETE FROM prefetch_job
+WHERE id = $1
+`
+
+// upsertProgressQuery records the progress of a cache server on a prefetch
+// job - which, once done, stays done.
+const upsertProgressQuery = `
+INSERT INTO prefetch_job_progress (job, server, total, fetched, failed, failed_urls, done)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (job, server) DO UPDATE SET
+	total = EXCLUDED.total,
+	fetched = EXCLUDED.fetched,
+	failed = EXCLUDED.failed,
+	failed_urls = EXCLUDED.failed_urls,
+	done = prefetch_job_progress.done OR EXCLUDED.done,
+	last_updated = now()
+`
+
+// jobServerQuery returns whether the given cache server fetches the content
+// of the given prefetch job.
+const jobServerQuery = `
+SELECT EXISTS (
+	SELECT 1
+	FROM prefetch_job AS j
+	JOIN deliveryservice AS ds ON ds.id = j.deliveryservice
+	WHERE j.id = $1
+	AND $2 IN (` + prefetchServersQuery + `)
+)
+`
+
+// Get is the handler for GET requests to /jobs/prefetch, which returns the
+// prefetch jobs of the Delivery Services of the user's Tenants. They may be
+// filtered by 'id' and 'deliveryServiceId', and, with 'serverId', to those
+// which have started that the cache server with that ID has yet to finish -
+// which is how t3c-agent finds the jobs it has to do.
+func Get(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, []string{"id", "deliveryServiceId", tc.PrefetchJobServerIDQueryParam})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	tenantIDs, err := tenant.GetUserTenantIDListTx(inf.Tx.Tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting user tenants: "+err.Error()))
+		return
+	}
+	jobs, err := getJobs(inf.Tx.Tx, tenantIDs, optionalParam(inf.IntParams, "id"), optionalParam(inf.IntParams, "deliveryServiceId"), optionalParam(inf.IntParams, tc.PrefetchJobServerIDQueryParam))
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, jobs)
+}
+
+// optionalParam returns the integral query parameter with the given name, or
+// nil if it wasn't given.
+func optionalParam(params map[string]int, name string) interface{} {
+	if v, ok := params[name]; ok {
+		return v
+	}
+	return nil
+}
+
+// getJobs returns the prefetch jobs of the Delivery Services of the given
+// Tenants, with their progress, filtered as given.
+func getJobs(tx *sql.Tx, tenantIDs []int, id, dsID, serverID interface{}) ([]tc.PrefetchJob, error) {
+	rows, err := tx.Query(selectJobsQuery, pq.Array(tenantIDs), id, dsID, serverID)
+	if err != nil {
+		return nil, errors.New("querying prefetch jobs: " + err.Error())
+	}
+	defer log.Close(rows, "closing prefetch job rows")
+	jobs := []tc.PrefetchJob{}
+	ids := []int{}
+	for rows.Next() {
+		var job tc.PrefetchJob
+		if err := rows.Scan(&job.ID, &job.DeliveryServiceID, &job.DeliveryService, pq.Array(&job.URLs), pq.Array(&job.ManifestURLs), pq.Array(&job.CacheGroups), &job.StartTime, &job.CreatedBy, &job.Servers, &job.LastUpdated); err != nil {
+			return nil, errors.New("scanning prefetch job: " + err.Error())
+		}
+		job.Progress = []tc.PrefetchJobProgress{}
+		jobs = append(jobs, job)
+		ids = append(ids, job.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over prefetch jobs: " + err.Error())
+	}
+	if len(jobs) == 0 {
+		return jobs, nil
+	}
+
+	progress, err := getProgress(tx, ids)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for i := range jobs {
+		jobs[i].Progress = append(jobs[i].Progress, progress[jobs[i].ID]...)
+		done := 0
+		for _, p := range jobs[i].Progress {
+			if p.Done {
+				done++
+			}
+		}
+		jobs[i].Status = tc.PrefetchJobStatus(jobs[i].StartTime, now, jobs[i].Servers, len(jobs[i].Progress), done)
+	}
+	return jobs, nil
+}
+
+// getProgress returns the progress of cache servers on the prefetch jobs with
+// the given IDs, by job.
+func getProgress(tx *sql.Tx, jobIDs []int) (map[int][]tc.PrefetchJobProgress, error) {
+	rows, err := tx.Query(selectProgressQuery, pq.Array(jobIDs))
+	if err != nil {
+		return nil, errors.New("querying prefetch job progress: " + err.Error())
+	}
+	defer log.Close(rows, "closing prefetch job progress rows")
+	progress := map[int][]tc.PrefetchJobProgress{}
+	for rows.Next() {
+		var job int
+		var p tc.PrefetchJobProgress
+		if err := rows.Scan(&job, &p.ServerID, &p.HostName, &p.Total, &p.Fetched, &p.Failed, pq.Array(&p.FailedURLs), &p.Done, &p.LastUpdated); err != nil {
+			return nil, errors.New("scanning prefetch job progress: " + err.Error())
+		}
+		progress[job] = append(progress[job], p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over prefetch job progress: " + err.Error())
+	}
+	return progress, nil
+}
+
+// Create is the handler for POST requests to /jobs/prefetch, which creates a
+// prefetch job. The hosts of its URLs must match the Delivery Service's host
+// regular expressions, so that the cache servers will serve them.
+func Create(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	var req tc.PrefetchJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("parsing prefetch job: "+err.Error()), nil)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	var xmlID, cdnName, dsType string
+	var tenantID int
+	var patterns []string
+	if err := tx.QueryRow(selectDSQuery, req.DeliveryServiceID).Scan(&xmlID, &tenantID, &cdnName, &dsType, pq.Array(&patterns)); err == sql.ErrNoRows {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("deliveryServiceId: no Delivery Service exists by ID '%d'", req.DeliveryServiceID), nil)
+		return
+	} else if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("querying prefetch job Delivery Service: "+err.Error()))
+		return
+	}
+	if ok, err := tenant.IsResourceAuthorizedToUserTx(tenantID, inf.User, tx); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("checking tenant: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("not authorized on this tenant"), nil)
+		return
+	}
+	if t := tc.DSTypeFromString(dsType); !t.IsHTTP() && !t.IsDNS() {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("deliveryServiceId: the content of Delivery Services of type "+dsType+" cannot be prefetched"), nil)
+		return
+	}
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyCDN(tx, cdnName, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	unmatched, err := unmatchedHosts(tc.PrefetchURLHosts(req.URLs, req.ManifestURLs), patterns)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("matching hosts of Delivery Service %s: %w", xmlID, err))
+		return
+	}
+	if len(unmatched) > 0 {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("urls, manifestUrls: hosts are not those of Delivery Service "+xmlID+": "+strings.Join(unmatched, ", ")), nil)
+		return
+	}
+
+	var unknown []string
+	if err := tx.QueryRow(unknownCacheGroupsQuery, pq.Array(req.CacheGroups)).Scan(pq.Array(&unknown)); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("checking prefetch job Cache Groups: "+err.Error()))
+		return
+	}
+	if len(unknown) > 0 {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("cacheGroups: no such Cache Groups: "+strings.Join(unknown, ", ")), nil)
+		return
+	}
+	var cacheGroupIDs []int64
+	if err := tx.QueryRow(selectCacheGroupIDsQuery, pq.Array(req.CacheGroups)).Scan(pq.Array(&cacheGroupIDs)); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting prefetch job Cache Group IDs: "+err.Error()))
+		return
+	}
+
+	startTime := time.Now()
+	if req.StartTime != nil {
+		startTime = *req.StartTime
+	}
+	var id int
+	if err := tx.QueryRow(insertJobQuery, req.DeliveryServiceID, pq.Array(req.URLs), pq.Array(req.ManifestURLs), startTime, inf.User.ID).Scan(&id); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if _, err := tx.Exec(insertJobCacheGroupsQuery, id, pq.Array(cacheGroupIDs)); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	tenantIDs, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting user tenants: "+err.Error()))
+		return
+	}
+	jobs, err := getJobs(tx, tenantIDs, id, nil, nil)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if len(jobs) != 1 {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting created prefetch job #%d: expected 1 job, got %d", id, len(jobs)))
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, fmt.Sprintf("%s prefetch job - ID: %d DS: %s URLs: %d MANIFESTS: %d CACHEGROUPS: %s", api.Created, id, xmlID, len(req.URLs), len(req.ManifestURLs), strings.Join(jobs[0].CacheGroups, ", ")), inf.User, tx)
+	w.Header().Set("Location", fmt.Sprintf("/api/%d.%d/jobs/prefetch?id=%d", inf.Version.Major, inf.Version.Minor, id))
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Prefetch job was created", jobs[0])
+}
+
+// unmatchedHosts returns those of the given hosts which match none of the
+// given host regular expressions of a Delivery Service.
+func unmatchedHosts(hosts []string, patterns []string) ([]string, error) {
+	regexes := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("compiling host regular expression '%s': %w", pattern, err)
+		}
+		regexes = append(regexes, re)
+	}
+	unmatched := []string{}
+	for _, host := range hosts {
+		matched := false
+		for _, re := range regexes {
+			if re.MatchString(host) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, host)
+		}
+	}
+	return unmatched, nil
+}
+
+// getJobDS returns the ID and tenant of the Delivery Service of the prefetch
+// job with the given ID, along with a user error, system error, and status
+// code.
+func getJobDS(inf *api.APIInfo, id int) (int, error, error, int) {
+	var dsID int
+	if err := inf.Tx.Tx.QueryRow(`SELECT deliveryservice FROM prefetch_job WHERE id = $1`, id).Scan(&dsID); err == sql.ErrNoRows {
+		return 0, fmt.Errorf("no prefetch job exists by ID '%d'", id), nil, http.StatusNotFound
+	} else if err != nil {
+		return 0, nil, errors.New("getting prefetch job Delivery Service: " + err.Error()), http.StatusInternalServerError
+	}
+	userErr, sysErr, errCode := tenant.CheckID(inf.Tx.Tx, inf.User, dsID)
+	if userErr != nil || sysErr != nil {
+		return 0, userErr, sysErr, errCode
+	}
+	return dsID, nil, nil, http.StatusOK
+}
+
+// Delete is the handler for DELETE requests to /jobs/prefetch/{id}, which
+// deletes a prefetch job - whereupon cache servers stop doing it the next
+// time they report their progress.
+func Delete(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	id := inf.IntParams["id"]
+
+	dsID, userErr, sysErr, errCode := getJobDS(inf, id)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	xmlID, cdnName, _, err := dbhelpers.GetDSNameAndCDNFromID(inf.Tx.Tx, dsID)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("getting Delivery Service and CDN name from ID: "+err.Error()))
+		return
+	}
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyCDN(inf.Tx.Tx, string(cdnName), inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+
+	if _, err := inf.Tx.Tx.Exec(deleteJobQuery, id); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, errors.New("deleting prefetch job: "+err.Error()))
+		return
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, fmt.Sprintf("%s prefetch job - ID: %d DS: %s", api.Deleted, id, xmlID), inf.User, inf.Tx.Tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, "Prefetch job was deleted")
+}
+
+// UpdateProgress is the handler for PUT requests to
+// /jobs/prefetch/{id}/progress, by which t3c-agent reports the progress of
+// its cache server on a prefetch job. The server must be one of those which
+// fetch the job's content.
+func UpdateProgress(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+
+	var progress tc.PrefetchJobProgress
+	if err := json.NewDecoder(r.Body).Decode(&progress); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("parsing prefetch job progress: "+err.Error()), nil)
+		return
+	}
+	if err := progress.Validate(); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	if _, userErr, sysErr, errCode := getJobDS(inf, id); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	var ok bool
+	if err := tx.QueryRow(jobServerQuery, id, progress.ServerID).Scan(&ok); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("checking prefetch job server: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("serverId: server #"+strconv.Itoa(progress.ServerID)+" is not one of those which fetch the content of prefetch job #"+strconv.Itoa(id)), nil)
+		return
+	}
+
+	if _, err := tx.Exec(upsertProgressQuery, id, progress.ServerID, progress.Total, progress.Fetched, progress.Failed, pq.Array(progress.FailedURLs), progress.Done); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	tenantIDs, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting user tenants: "+err.Error()))
+		return
+	}
+	jobs, err := getJobs(tx, tenantIDs, id, nil, nil)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if len(jobs) != 1 {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting prefetch job #%d: expected 1 job, got %d", id, len(jobs)))
+		return
+	}
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Prefetch job progress was updated", jobs[0])
+}
@@ -0,0 +1,55 @@
+package prefetchjobs
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnmatchedHosts(t *testing.T) {
+	hosts := []string{"cdn.demo1.mycdn.ciab.test", "demo1.example.com", "other.example.com"}
+	unmatched, err := unmatchedHosts(hosts, []string{`.*\.demo1\..*`, `demo1\.example\.com`})
+	if err != nil {
+		t.Fatalf("unexpected error matching hosts: %v", err)
+	}
+	if expected := []string{"other.example.com"}; !reflect.DeepEqual(unmatched, expected) {
+		t.Errorf("expected unmatched hosts %v, got %v", expected, unmatched)
+	}
+
+	// patterns must match whole hosts
+	if unmatched, _ := unmatchedHosts([]string{"demo1.example.com.evil.test"}, []string{`demo1\.example\.com`}); len(unmatched) != 1 {
+		t.Errorf("expected a host merely containing a pattern not to match it, got unmatched hosts %v", unmatched)
+	}
+
+	if _, err := unmatchedHosts(hosts, []string{`(`}); err == nil {
+		t.Error("expected an error matching an invalid pattern, got nil")
+	}
+}
+
+func TestOptionalParam(t *testing.T) {
+	params := map[string]int{"id": 3}
+	if v := optionalParam(params, "id"); v != 3 {
+		t.Errorf("expected 3, got %v", v)
+	}
+	if v := optionalParam(params, "serverId"); v != nil {
+		t.Errorf("expected nil for a parameter not given, got %v", v)
+	}
+}
@@ -154,6 +154,10 @@ func (prof *TOProfile) Read(h http.Header, useIMS bool) ([]interface{}, error, e
 	if len(errs) > 0 {
 		return nil, util.JoinErrs(errs), nil, http.StatusBadRequest, nil
 	}
+	where, orderBy, pagination, page, err := prof.APIInfo().BuildCursorPagination(queryParamsToQueryCols, where, orderBy, pagination, queryValues)
+	if err != nil {
+		return nil, err, nil, http.StatusBadRequest, nil
+	}
 
 	if useIMS {
 		runSecond, maxTime = ims.TryIfModifiedSinceQuery(prof.APIInfo().Tx, h, queryValues, selectMaxLastUpdatedQuery(where))
@@ -196,6 +200,7 @@ func (prof *TOProfile) Read(h http.Header, useIMS bool) ([]interface{}, error, e
 		}
 		profileInterfaces = append(profileInterfaces, profile)
 	}
+	prof.APIInfo().Pagination = dbhelpers.CursorPagination(page, profileInterfaces)
 
 	return profileInterfaces, nil, nil, http.StatusOK, &maxTime
 
@@ -0,0 +1,108 @@
+// Package requeststats tracks the cost of Traffic Ops API requests - the time
+// they spend querying the database, the rows they read, and the bytes they
+// return - to find the routes and users putting the most load on the
+// database.
+package requeststats
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type contextKey struct{}
+
+// Cost is the cost of a single request. It's safe for concurrent use, and
+// all its methods may be called on a nil Cost, which does nothing.
+type Cost struct {
+	dbTime int64 // nanoseconds, accessed atomically
+	rows   int64 // accessed atomically
+
+	m    sync.Mutex
+	user string
+}
+
+// NewContext returns a copy of ctx carrying a new Cost, and the Cost.
+func NewContext(ctx context.Context) (context.Context, *Cost) {
+	cost := &Cost{}
+	return context.WithValue(ctx, contextKey{}, cost), cost
+}
+
+// FromContext returns the Cost carried by ctx, or nil if it has none.
+func FromContext(ctx context.Context) *Cost {
+	if ctx == nil {
+		return nil
+	}
+	cost, _ := ctx.Value(contextKey{}).(*Cost)
+	return cost
+}
+
+// AddDBTime adds d to the time the request spent querying the database.
+func (c *Cost) AddDBTime(d time.Duration) {
+	if c != nil {
+		atomic.AddInt64(&c.dbTime, int64(d))
+	}
+}
+
+// AddRows adds n to the number of database rows the request read.
+func (c *Cost) AddRows(n int64) {
+	if c != nil {
+		atomic.AddInt64(&c.rows, n)
+	}
+}
+
+// SetUser sets the name of the user who made the request.
+func (c *Cost) SetUser(user string) {
+	if c != nil {
+		c.m.Lock()
+		c.user = user
+		c.m.Unlock()
+	}
+}
+
+// DBTime returns the time the request spent querying the database.
+func (c *Cost) DBTime() time.Duration {
+	if c == nil {
+		return 0
+	}
+	return time.Duration(atomic.LoadInt64(&c.dbTime))
+}
+
+// Rows returns the number of database rows the request read.
+func (c *Cost) Rows() int64 {
+	if c == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&c.rows)
+}
+
+// User returns the name of the user who made the request, or an empty string
+// if it isn't known.
+func (c *Cost) User() string {
+	if c == nil {
+		return ""
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.user
+}
@@ -0,0 +1,231 @@
+package requeststats
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+)
+
+// NewConnector returns a driver.Connector which connects with base, and adds
+// the time spent and rows read by queries to the Cost of the request making
+// them.
+//
+// Queries are attributed to the Cost in their context or, for queries in a
+// transaction, the Cost in the context the transaction began with.
+func NewConnector(base driver.Connector) driver.Connector {
+	return connector{base: base}
+}
+
+type connector struct {
+	base driver.Connector
+}
+
+func (c connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.base.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cc, ok := conn.(ctxConn)
+	if !ok {
+		log.Warnf("database driver connection %T doesn't support contexts, request database costs will not be tracked", conn)
+		return conn, nil
+	}
+	return &costConn{ctxConn: cc}, nil
+}
+
+func (c connector) Driver() driver.Driver {
+	return c.base.Driver()
+}
+
+// ctxConn is a driver.Conn supporting contexts, as the PostgreSQL driver's
+// connections do.
+type ctxConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.QueryerContext
+	driver.ExecerContext
+	driver.Pinger
+}
+
+// costConn is a connection which adds the cost of its queries to the Cost of
+// the request making them.
+//
+// The database/sql package never uses a connection concurrently, so it needs
+// no locking.
+type costConn struct {
+	ctxConn
+	// txCost is the Cost of the request whose transaction is using the
+	// connection, if any.
+	txCost *Cost
+}
+
+// cost returns the Cost of the request making a query with the given
+// context.
+func (c *costConn) cost(ctx context.Context) *Cost {
+	if cost := FromContext(ctx); cost != nil {
+		return cost
+	}
+	return c.txCost
+}
+
+func (c *costConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	cost := FromContext(ctx)
+	start := time.Now()
+	tx, err := c.ctxConn.BeginTx(ctx, opts)
+	cost.AddDBTime(time.Since(start))
+	if err != nil {
+		return nil, err
+	}
+	c.txCost = cost
+	return &costTx{Tx: tx, conn: c}, nil
+}
+
+func (c *costConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	cost := c.cost(ctx)
+	start := time.Now()
+	rows, err := c.ctxConn.QueryContext(ctx, query, args)
+	cost.AddDBTime(time.Since(start))
+	if err != nil {
+		return nil, err
+	}
+	return wrapRows(rows, cost), nil
+}
+
+func (c *costConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	cost := c.cost(ctx)
+	start := time.Now()
+	res, err := c.ctxConn.ExecContext(ctx, query, args)
+	cost.AddDBTime(time.Since(start))
+	return res, err
+}
+
+func (c *costConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	cost := c.cost(ctx)
+	start := time.Now()
+	stmt, err := c.ctxConn.PrepareContext(ctx, query)
+	cost.AddDBTime(time.Since(start))
+	if err != nil {
+		return nil, err
+	}
+	if cs, ok := stmt.(ctxStmt); ok {
+		return &costStmt{ctxStmt: cs, conn: c}, nil
+	}
+	return stmt, nil
+}
+
+// costTx is a transaction which stops attributing the queries of its
+// connection to its request once it ends.
+type costTx struct {
+	driver.Tx
+	conn *costConn
+}
+
+func (t *costTx) Commit() error {
+	return t.end(t.Tx.Commit)
+}
+
+func (t *costTx) Rollback() error {
+	return t.end(t.Tx.Rollback)
+}
+
+func (t *costTx) end(f func() error) error {
+	start := time.Now()
+	err := f()
+	t.conn.txCost.AddDBTime(time.Since(start))
+	t.conn.txCost = nil
+	return err
+}
+
+// ctxStmt is a driver.Stmt supporting contexts, as the PostgreSQL driver's
+// statements do.
+type ctxStmt interface {
+	driver.Stmt
+	driver.StmtQueryContext
+	driver.StmtExecContext
+}
+
+// costStmt is a prepared statement which adds the cost of its queries to the
+// Cost of the request making them.
+type costStmt struct {
+	ctxStmt
+	conn *costConn
+}
+
+func (s *costStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	cost := s.conn.cost(ctx)
+	start := time.Now()
+	rows, err := s.ctxStmt.QueryContext(ctx, args)
+	cost.AddDBTime(time.Since(start))
+	if err != nil {
+		return nil, err
+	}
+	return wrapRows(rows, cost), nil
+}
+
+func (s *costStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	cost := s.conn.cost(ctx)
+	start := time.Now()
+	res, err := s.ctxStmt.ExecContext(ctx, args)
+	cost.AddDBTime(time.Since(start))
+	return res, err
+}
+
+// columnTypeRows is a driver.Rows with all the optional column type and
+// result set methods, as the PostgreSQL driver's rows have.
+type columnTypeRows interface {
+	driver.Rows
+	driver.RowsNextResultSet
+	driver.RowsColumnTypeScanType
+	driver.RowsColumnTypeDatabaseTypeName
+	driver.RowsColumnTypeLength
+	driver.RowsColumnTypePrecisionScale
+}
+
+// wrapRows returns rows which add the time spent reading them and the number
+// read to cost.
+func wrapRows(rows driver.Rows, cost *Cost) driver.Rows {
+	if cost == nil {
+		return rows
+	}
+	if ctr, ok := rows.(columnTypeRows); ok {
+		return &costRows{columnTypeRows: ctr, cost: cost}
+	}
+	return rows
+}
+
+type costRows struct {
+	columnTypeRows
+	cost *Cost
+}
+
+func (r *costRows) Next(dest []driver.Value) error {
+	start := time.Now()
+	err := r.columnTypeRows.Next(dest)
+	r.cost.AddDBTime(time.Since(start))
+	if err == nil {
+		r.cost.AddRows(1)
+	}
+	return err
+}
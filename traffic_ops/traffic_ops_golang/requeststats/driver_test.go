@@ -0,0 +1,138 @@
+package requeststats
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// The fake driver's queries each take queryTime and return numRows rows.
+const (
+	queryTime = 10 * time.Millisecond
+	numRows   = 3
+)
+
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn{}, nil }
+func (fakeConnector) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{}, nil }
+func (fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return fakeTx{}, nil
+}
+func (fakeConn) PrepareContext(context.Context, string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+func (fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	time.Sleep(queryTime)
+	return &fakeRows{}, nil
+}
+func (fakeConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	time.Sleep(queryTime)
+	return driver.RowsAffected(1), nil
+}
+func (fakeConn) Ping(context.Context) error { return nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct{ n int }
+
+func (*fakeRows) Columns() []string { return []string{"id"} }
+func (*fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.n == numRows {
+		return io.EOF
+	}
+	r.n++
+	dest[0] = int64(r.n)
+	return nil
+}
+func (*fakeRows) HasNextResultSet() bool                            { return false }
+func (*fakeRows) NextResultSet() error                              { return io.EOF }
+func (*fakeRows) ColumnTypeScanType(int) reflect.Type               { return reflect.TypeOf(int64(0)) }
+func (*fakeRows) ColumnTypeDatabaseTypeName(int) string             { return "INT8" }
+func (*fakeRows) ColumnTypeLength(int) (int64, bool)                { return 0, false }
+func (*fakeRows) ColumnTypePrecisionScale(int) (int64, int64, bool) { return 0, 0, false }
+
+func TestConnector(t *testing.T) {
+	db := sql.OpenDB(NewConnector(fakeConnector{}))
+	defer db.Close()
+
+	ctx, cost := NewContext(context.Background())
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("beginning transaction: %v", err)
+	}
+	rows, err := tx.Query("SELECT id FROM server") // no context, so attributed to the transaction's
+	if err != nil {
+		t.Fatalf("querying: %v", err)
+	}
+	for rows.Next() {
+	}
+	rows.Close()
+	if _, err := tx.Exec("UPDATE server SET id = id"); err != nil {
+		t.Fatalf("executing: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("committing: %v", err)
+	}
+
+	if cost.Rows() != numRows {
+		t.Errorf("expected %d rows, actual: %d", numRows, cost.Rows())
+	}
+	if cost.DBTime() < 2*queryTime {
+		t.Errorf("expected database time of at least %v, actual: %v", 2*queryTime, cost.DBTime())
+	}
+
+	// the connection is reused for a query by another request
+	otherCtx, otherCost := NewContext(context.Background())
+	rows, err = db.QueryContext(otherCtx, "SELECT id FROM server")
+	if err != nil {
+		t.Fatalf("querying: %v", err)
+	}
+	for rows.Next() {
+	}
+	rows.Close()
+	if cost.Rows() != numRows {
+		t.Errorf("expected queries after the transaction not to be attributed to its request, actual: %d rows", cost.Rows())
+	}
+	if otherCost.Rows() != numRows {
+		t.Errorf("expected %d rows for the other request, actual: %d", numRows, otherCost.Rows())
+	}
+
+	// queries by no request are fine
+	if _, err := db.Exec("UPDATE server SET id = id"); err != nil {
+		t.Errorf("executing without a request: %v", err)
+	}
+}
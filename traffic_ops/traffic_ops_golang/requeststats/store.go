@@ -0,0 +1,202 @@
+package requeststats
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+// MaxWindow is the longest time over which request costs are kept.
+const MaxWindow = 24 * time.Hour
+
+// bucketDuration is the duration of the time buckets request costs are
+// combined into. Windows are rounded to it.
+const bucketDuration = time.Minute
+
+// These are the ways slow requests may be ordered.
+const (
+	OrderByDBTime   = "dbTime"
+	OrderByRows     = "rows"
+	OrderByBytes    = "bytes"
+	OrderByDuration = "duration"
+	OrderByCount    = "count"
+)
+
+// Default is the Store of the requests handled by Traffic Ops.
+var Default = NewStore()
+
+// Request is the cost of a single handled request.
+type Request struct {
+	RouteID  int
+	Route    string
+	User     string
+	DBTime   time.Duration
+	Rows     int64
+	Bytes    int64
+	Duration time.Duration
+}
+
+type requestKey struct {
+	routeID int
+	route   string
+	user    string
+}
+
+type requestTotals struct {
+	count     int64
+	dbTime    time.Duration
+	maxDBTime time.Duration
+	rows      int64
+	bytes     int64
+	duration  time.Duration
+}
+
+func (t *requestTotals) add(o requestTotals) {
+	t.count += o.count
+	t.dbTime += o.dbTime
+	if o.maxDBTime > t.maxDBTime {
+		t.maxDBTime = o.maxDBTime
+	}
+	t.rows += o.rows
+	t.bytes += o.bytes
+	t.duration += o.duration
+}
+
+// Store keeps the total cost of the requests made by each user to each route
+// over the last MaxWindow. It's safe for concurrent use.
+type Store struct {
+	m sync.Mutex
+	// buckets are the totals of the requests handled in each bucketDuration,
+	// by the Unix time of its start.
+	buckets map[int64]map[requestKey]*requestTotals
+}
+
+// NewStore returns a new, empty Store.
+func NewStore() *Store {
+	return &Store{buckets: map[int64]map[requestKey]*requestTotals{}}
+}
+
+// Record adds the cost of a request handled at the given time.
+func (s *Store) Record(t time.Time, req Request) {
+	bucketStart := t.Truncate(bucketDuration).Unix()
+	key := requestKey{routeID: req.RouteID, route: req.Route, user: req.User}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+	bucket, ok := s.buckets[bucketStart]
+	if !ok {
+		bucket = map[requestKey]*requestTotals{}
+		s.buckets[bucketStart] = bucket
+		s.prune(t)
+	}
+	totals, ok := bucket[key]
+	if !ok {
+		totals = &requestTotals{}
+		bucket[key] = totals
+	}
+	totals.add(requestTotals{
+		count:     1,
+		dbTime:    req.DBTime,
+		maxDBTime: req.DBTime,
+		rows:      req.Rows,
+		bytes:     req.Bytes,
+		duration:  req.Duration,
+	})
+}
+
+// prune removes the buckets older than MaxWindow before now. It must be
+// called with s.m held.
+func (s *Store) prune(now time.Time) {
+	oldest := now.Add(-MaxWindow).Truncate(bucketDuration).Unix()
+	for start := range s.buckets {
+		if start < oldest {
+			delete(s.buckets, start)
+		}
+	}
+}
+
+// Top returns the total costs of the requests made by each user to each
+// route within the given window before now, up to limit of those with the
+// highest cost by orderBy, which must be one of the OrderBy constants.
+func (s *Store) Top(now time.Time, window time.Duration, orderBy string, limit int) []tc.SlowRequest {
+	oldest := now.Add(-window).Truncate(bucketDuration).Unix()
+	all := map[requestKey]*requestTotals{}
+
+	s.m.Lock()
+	for start, bucket := range s.buckets {
+		if start < oldest {
+			continue
+		}
+		for key, totals := range bucket {
+			if _, ok := all[key]; !ok {
+				all[key] = &requestTotals{}
+			}
+			all[key].add(*totals)
+		}
+	}
+	s.m.Unlock()
+
+	reqs := make([]tc.SlowRequest, 0, len(all))
+	for key, totals := range all {
+		reqs = append(reqs, tc.SlowRequest{
+			RouteID:               key.routeID,
+			Route:                 key.route,
+			User:                  key.user,
+			Count:                 totals.count,
+			DBTimeMilliseconds:    totals.dbTime.Milliseconds(),
+			MaxDBTimeMilliseconds: totals.maxDBTime.Milliseconds(),
+			Rows:                  totals.rows,
+			Bytes:                 totals.bytes,
+			DurationMilliseconds:  totals.duration.Milliseconds(),
+		})
+	}
+
+	cost := func(req tc.SlowRequest) int64 {
+		switch orderBy {
+		case OrderByRows:
+			return req.Rows
+		case OrderByBytes:
+			return req.Bytes
+		case OrderByDuration:
+			return req.DurationMilliseconds
+		case OrderByCount:
+			return req.Count
+		default:
+			return req.DBTimeMilliseconds
+		}
+	}
+	sort.Slice(reqs, func(i, j int) bool {
+		if ci, cj := cost(reqs[i]), cost(reqs[j]); ci != cj {
+			return ci > cj
+		}
+		if reqs[i].RouteID != reqs[j].RouteID {
+			return reqs[i].RouteID < reqs[j].RouteID
+		}
+		return reqs[i].User < reqs[j].User
+	})
+	if limit > 0 && len(reqs) > limit {
+		reqs = reqs[:limit]
+	}
+	return reqs
+}
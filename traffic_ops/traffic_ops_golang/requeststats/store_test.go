@@ -0,0 +1,62 @@
+package requeststats
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStoreTop(t *testing.T) {
+	s := NewStore()
+	now := time.Date(2022, 5, 24, 12, 0, 30, 0, time.UTC)
+
+	s.Record(now, Request{RouteID: 1, Route: "GET servers/?$", User: "bot", DBTime: 3 * time.Second, Rows: 1000, Bytes: 50000, Duration: 4 * time.Second})
+	s.Record(now.Add(-time.Minute), Request{RouteID: 1, Route: "GET servers/?$", User: "bot", DBTime: 2 * time.Second, Rows: 1000, Bytes: 50000, Duration: 3 * time.Second})
+	s.Record(now, Request{RouteID: 1, Route: "GET servers/?$", User: "admin", DBTime: time.Second, Rows: 1000, Bytes: 50000, Duration: time.Second})
+	s.Record(now, Request{RouteID: 2, Route: "GET cdns/?$", User: "admin", DBTime: 10 * time.Millisecond, Rows: 5, Bytes: 900000, Duration: 20 * time.Millisecond})
+	s.Record(now.Add(-2*time.Hour), Request{RouteID: 3, Route: "GET jobs/?$", User: "bot", DBTime: time.Minute, Rows: 1, Bytes: 1, Duration: time.Minute})
+
+	top := s.Top(now, time.Hour, OrderByDBTime, 10)
+	if len(top) != 3 {
+		t.Fatalf("expected 3 route/user pairs within the window, actual: %+v", top)
+	}
+	if top[0].User != "bot" || top[0].RouteID != 1 {
+		t.Errorf("expected bot's server requests to have the most database time, actual: %+v", top[0])
+	}
+	if top[0].Count != 2 || top[0].DBTimeMilliseconds != 5000 || top[0].MaxDBTimeMilliseconds != 3000 || top[0].Rows != 2000 {
+		t.Errorf("expected bot's server requests to be combined, actual: %+v", top[0])
+	}
+
+	top = s.Top(now, time.Hour, OrderByBytes, 1)
+	if len(top) != 1 || top[0].RouteID != 2 {
+		t.Errorf("expected only the CDN requests, with the most bytes, actual: %+v", top)
+	}
+
+	top = s.Top(now, 3*time.Hour, OrderByDBTime, 10)
+	if len(top) != 4 || top[0].RouteID != 3 {
+		t.Errorf("expected the older job requests in a longer window, actual: %+v", top)
+	}
+
+	s.Record(now.Add(MaxWindow+time.Hour), Request{RouteID: 2, Route: "GET cdns/?$", User: "admin"})
+	if top := s.Top(now.Add(MaxWindow+time.Hour), MaxWindow, OrderByDBTime, 10); len(top) != 1 || top[0].Count != 1 {
+		t.Errorf("expected requests older than the max window to be pruned, actual: %+v", top)
+	}
+}
@@ -0,0 +1,391 @@
+package rollout
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/util/monitorhlp"
+)
+
+// releaseStepQuery queues updates on the servers of a step of a Rollout,
+// recording when they were released so that it can be told when they have
+// applied them.
+const releaseStepQuery = `
+WITH released AS (
+	UPDATE rollout_server
+	SET released_at = now()
+	WHERE rollout = $1
+	AND step = $2
+	RETURNING server
+)
+UPDATE server
+SET config_update_time = now()
+WHERE id IN (SELECT server FROM released)
+`
+
+const setStepQuery = `
+UPDATE rollout SET
+	current_step = $2,
+	step_started_at = now(),
+	step_applied_at = NULL,
+	last_updated = now()
+WHERE id = $1
+`
+
+// lockRunningQuery selects the running Rollouts, skipping any being advanced
+// by another Traffic Ops instance.
+const lockRunningQuery = `
+SELECT r.id,
+	cdn.name,
+	r.current_step,
+	r.step_count,
+	r.soak_seconds,
+	r.step_timeout_seconds,
+	r.step_started_at,
+	r.step_applied_at
+FROM rollout AS r
+JOIN cdn ON cdn.id = r.cdn
+WHERE r.status = 'running'
+FOR UPDATE OF r SKIP LOCKED
+`
+
+const stepProgressQuery = `
+SELECT COUNT(*),
+	COUNT(*) FILTER (WHERE s.config_apply_time >= rs.released_at)
+FROM rollout_server AS rs
+JOIN server AS s ON s.id = rs.server
+WHERE rs.rollout = $1
+AND rs.step = $2
+`
+
+const releasedServersQuery = `
+SELECT s.host_name
+FROM rollout_server AS rs
+JOIN server AS s ON s.id = rs.server
+WHERE rs.rollout = $1
+AND rs.released_at IS NOT NULL
+`
+
+const setStepAppliedQuery = `
+UPDATE rollout SET
+	step_applied_at = now(),
+	last_updated = now()
+WHERE id = $1
+`
+
+const haltQuery = `
+UPDATE rollout SET
+	status = 'halted',
+	halt_reason = $2,
+	last_updated = now()
+WHERE id = $1
+`
+
+const completeQuery = `
+UPDATE rollout SET
+	status = 'completed',
+	last_updated = now()
+WHERE id = $1
+`
+
+// releaseStep releases updates to the servers of the given step of the
+// Rollout identified by id, making it the current step.
+func releaseStep(tx *sql.Tx, id int, step int) error {
+	if _, err := tx.Exec(releaseStepQuery, id, step); err != nil {
+		return fmt.Errorf("releasing step %d of rollout #%d: %w", step, id, err)
+	}
+	if _, err := tx.Exec(setStepQuery, id, step); err != nil {
+		return fmt.Errorf("setting current step of rollout #%d to %d: %w", id, step, err)
+	}
+	return nil
+}
+
+// InitController starts checking, every interval, the progress of the
+// running Rollouts, releasing the next step of each whose current step's
+// servers all applied their updates and stayed healthy for its soak time,
+// and halting each whose servers didn't apply their updates in time or became
+// unavailable in Traffic Monitor. If interval is not positive, Rollouts never
+// progress beyond their first step.
+//
+// Advancing Rollouts is safe with any number of Traffic Ops instances running
+// the controller against the same database.
+func InitController(interval time.Duration, db *sql.DB, timeout time.Duration) {
+	if interval <= 0 {
+		log.Infoln("rollout interval is negative, rollouts will not progress beyond their first step")
+		return
+	}
+	go func() {
+		for {
+			if err := advanceRollouts(db, timeout); err != nil {
+				log.Errorf("advancing rollouts: %v", err)
+			}
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// runningRollout is the state of a running Rollout needed to advance it.
+type runningRollout struct {
+	id            int
+	cdn           tc.CDNName
+	step          int
+	stepCount     int
+	soak          time.Duration
+	timeout       time.Duration
+	stepStartedAt *time.Time
+	stepAppliedAt *time.Time
+}
+
+func advanceRollouts(db *sql.DB, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	commit := false
+	defer func() {
+		if !commit {
+			if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+				log.Errorf("rolling back rollout transaction: %v", err)
+			}
+		}
+	}()
+
+	rollouts, err := getRunningRollouts(tx)
+	if err != nil {
+		return err
+	}
+	if len(rollouts) == 0 {
+		return nil
+	}
+
+	errs := []error{}
+	for _, ro := range rollouts {
+		if err := advanceRollout(tx, ro, time.Now()); err != nil {
+			errs = append(errs, fmt.Errorf("rollout #%d of CDN '%s': %w", ro.id, ro.cdn, err))
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	commit = true
+	return util.JoinErrs(errs)
+}
+
+func getRunningRollouts(tx *sql.Tx) ([]runningRollout, error) {
+	rows, err := tx.Query(lockRunningQuery)
+	if err != nil {
+		return nil, fmt.Errorf("querying running rollouts: %w", err)
+	}
+	defer log.Close(rows, "closing running rollout rows")
+
+	rollouts := []runningRollout{}
+	for rows.Next() {
+		var ro runningRollout
+		var soak, timeout int
+		if err := rows.Scan(&ro.id, &ro.cdn, &ro.step, &ro.stepCount, &soak, &timeout, &ro.stepStartedAt, &ro.stepAppliedAt); err != nil {
+			return nil, fmt.Errorf("scanning running rollout: %w", err)
+		}
+		ro.soak = time.Duration(soak) * time.Second
+		ro.timeout = time.Duration(timeout) * time.Second
+		rollouts = append(rollouts, ro)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over running rollouts: %w", err)
+	}
+	return rollouts, nil
+}
+
+// advanceRollout takes whatever action the progress of the Rollout calls for.
+func advanceRollout(tx *sql.Tx, ro runningRollout, now time.Time) error {
+	if ro.step == 0 {
+		return releaseStep(tx, ro.id, 1)
+	}
+
+	p := progress{runningRollout: ro}
+	if err := tx.QueryRow(stepProgressQuery, ro.id, ro.step).Scan(&p.servers, &p.applied); err != nil {
+		return fmt.Errorf("querying step progress: %w", err)
+	}
+	unhealthy, err := getUnhealthyServers(tx, ro)
+	if err != nil {
+		// without health data, it can't be told whether it's safe to
+		// proceed, nor whether to halt.
+		return fmt.Errorf("getting server health, not advancing: %w", err)
+	}
+	p.unhealthy = unhealthy
+
+	d := decide(p, now)
+	if d.markApplied {
+		if _, err := tx.Exec(setStepAppliedQuery, ro.id); err != nil {
+			return fmt.Errorf("setting step applied time: %w", err)
+		}
+	}
+	switch d.action {
+	case actionRelease:
+		if err := releaseStep(tx, ro.id, ro.step+1); err != nil {
+			return err
+		}
+		log.Infof("rollout #%d of CDN '%s': step %d applied and healthy, released step %d of %d", ro.id, ro.cdn, ro.step, ro.step+1, ro.stepCount)
+	case actionComplete:
+		if _, err := tx.Exec(completeQuery, ro.id); err != nil {
+			return fmt.Errorf("completing: %w", err)
+		}
+		log.Infof("rollout #%d of CDN '%s': completed", ro.id, ro.cdn)
+	case actionHalt:
+		if _, err := tx.Exec(haltQuery, ro.id, d.reason); err != nil {
+			return fmt.Errorf("halting: %w", err)
+		}
+		log.Warnf("rollout #%d of CDN '%s': halted: %s", ro.id, ro.cdn, d.reason)
+	}
+	return nil
+}
+
+// getUnhealthyServers returns the host names of the servers released updates
+// by the Rollout which are unavailable according to the Traffic Monitors of
+// its CDN. Servers Traffic Monitor doesn't monitor are considered healthy.
+func getUnhealthyServers(tx *sql.Tx, ro runningRollout) ([]string, error) {
+	monitors, err := monitorhlp.GetURLs(tx)
+	if err != nil {
+		return nil, errors.New("getting monitors: " + err.Error())
+	}
+	monitorFQDNs, ok := monitors[ro.cdn]
+	if !ok {
+		return nil, errors.New("no online Traffic Monitors")
+	}
+	client, err := monitorhlp.GetClient(tx)
+	if err != nil {
+		return nil, errors.New("getting monitor client: " + err.Error())
+	}
+
+	var crStates *tc.CRStates
+	errs := []error{}
+	for _, fqdn := range monitorFQDNs {
+		states, err := monitorhlp.GetCRStates(fqdn, client)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		crStates = &states
+		break
+	}
+	if crStates == nil {
+		return nil, util.JoinErrs(errs)
+	}
+
+	rows, err := tx.Query(releasedServersQuery, ro.id)
+	if err != nil {
+		return nil, fmt.Errorf("querying released servers: %w", err)
+	}
+	defer log.Close(rows, "closing released server rows")
+
+	unhealthy := []string{}
+	for rows.Next() {
+		var hostName string
+		if err := rows.Scan(&hostName); err != nil {
+			return nil, fmt.Errorf("scanning released server: %w", err)
+		}
+		if state, ok := crStates.Caches[tc.CacheName(hostName)]; ok && !state.IsAvailable {
+			unhealthy = append(unhealthy, hostName)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over released servers: %w", err)
+	}
+	sort.Strings(unhealthy)
+	return unhealthy, nil
+}
+
+// progress is the progress of the current step of a running Rollout.
+type progress struct {
+	runningRollout
+	// servers is the number of servers in the current step.
+	servers int
+	// applied is the number of servers in the current step which applied
+	// their updates.
+	applied int
+	// unhealthy are the host names of the servers released updates in any
+	// step so far which are unavailable.
+	unhealthy []string
+}
+
+type action int
+
+const (
+	actionWait action = iota
+	actionRelease
+	actionComplete
+	actionHalt
+)
+
+type decision struct {
+	action action
+	// reason is why the Rollout halts, for actionHalt.
+	reason string
+	// markApplied is whether the current step's servers have just all been
+	// seen to have applied their updates, starting its soak time.
+	markApplied bool
+}
+
+// decide decides what to do with a running Rollout, given the progress of
+// its current step at now.
+func decide(p progress, now time.Time) decision {
+	if len(p.unhealthy) > 0 {
+		return decision{action: actionHalt, reason: "servers unavailable in Traffic Monitor: " + strings.Join(p.unhealthy, ", ")}
+	}
+
+	if p.applied < p.servers {
+		if p.stepStartedAt != nil && now.Sub(*p.stepStartedAt) > p.timeout {
+			return decision{
+				action: actionHalt,
+				reason: fmt.Sprintf("step %d: %d of %d servers did not apply their updates within %v", p.step, p.servers-p.applied, p.servers, p.timeout),
+			}
+		}
+		return decision{action: actionWait}
+	}
+
+	d := decision{action: actionWait}
+	appliedAt := now
+	if p.stepAppliedAt == nil {
+		d.markApplied = true
+	} else {
+		appliedAt = *p.stepAppliedAt
+	}
+	if now.Sub(appliedAt) < p.soak {
+		return d
+	}
+	if p.step >= p.stepCount {
+		d.action = actionComplete
+	} else {
+		d.action = actionRelease
+	}
+	return d
+}
@@ -0,0 +1,110 @@
+package rollout
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAssignSteps(t *testing.T) {
+	servers := []cacheServer{
+		{id: 4, cachegroup: 1},
+		{id: 2, cachegroup: 1},
+		{id: 3, cachegroup: 1},
+		{id: 1, cachegroup: 1},
+		{id: 10, cachegroup: 2},
+	}
+
+	steps, stepCount := assignSteps(servers, 25)
+	if stepCount != 4 {
+		t.Errorf("expected 4 steps, got %d", stepCount)
+	}
+	expected := map[int]int{1: 1, 2: 2, 3: 3, 4: 4, 10: 1}
+	for id, step := range expected {
+		if steps[id] != step {
+			t.Errorf("expected server #%d in step %d, got %d", id, step, steps[id])
+		}
+	}
+
+	// 30% of 4 servers rounds up to 2 per step
+	steps, stepCount = assignSteps(servers, 30)
+	if stepCount != 2 {
+		t.Errorf("expected 2 steps, got %d", stepCount)
+	}
+	expected = map[int]int{1: 1, 2: 1, 3: 2, 4: 2, 10: 1}
+	for id, step := range expected {
+		if steps[id] != step {
+			t.Errorf("expected server #%d in step %d, got %d", id, step, steps[id])
+		}
+	}
+
+	if _, stepCount = assignSteps(servers, 100); stepCount != 1 {
+		t.Errorf("expected 1 step at 100%%, got %d", stepCount)
+	}
+}
+
+func TestDecide(t *testing.T) {
+	now := time.Now()
+	started := now.Add(-time.Minute)
+	longAgo := now.Add(-time.Hour)
+	recently := now.Add(-time.Second)
+	ro := runningRollout{
+		step:          1,
+		stepCount:     2,
+		soak:          30 * time.Second,
+		timeout:       10 * time.Minute,
+		stepStartedAt: &started,
+	}
+
+	cases := []struct {
+		name        string
+		modify      func(*progress)
+		action      action
+		markApplied bool
+		reason      string
+	}{
+		{"waiting for servers to apply", func(p *progress) { p.applied = 1 }, actionWait, false, ""},
+		{"step timed out", func(p *progress) { p.applied = 1; p.stepStartedAt = &longAgo }, actionHalt, false, "did not apply"},
+		{"unhealthy server", func(p *progress) { p.unhealthy = []string{"edge1", "edge2"} }, actionHalt, false, "edge1, edge2"},
+		{"just applied", func(p *progress) {}, actionWait, true, ""},
+		{"soaking", func(p *progress) { p.stepAppliedAt = &recently }, actionWait, false, ""},
+		{"soaked", func(p *progress) { p.stepAppliedAt = &longAgo }, actionRelease, false, ""},
+		{"last step soaked", func(p *progress) { p.stepAppliedAt = &longAgo; p.step = 2 }, actionComplete, false, ""},
+		{"no soak", func(p *progress) { p.soak = 0 }, actionRelease, true, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			p := progress{runningRollout: ro, servers: 2, applied: 2}
+			c.modify(&p)
+			d := decide(p, now)
+			if d.action != c.action {
+				t.Errorf("expected action %d, got %d", c.action, d.action)
+			}
+			if d.markApplied != c.markApplied {
+				t.Errorf("expected markApplied %t, got %t", c.markApplied, d.markApplied)
+			}
+			if !strings.Contains(d.reason, c.reason) {
+				t.Errorf("expected reason containing '%s', got '%s'", c.reason, d.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,441 @@
+package rollout
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+
+	"github.com/lib/pq"
+)
+
+const readQuery = `
+SELECT r.id,
+	cdn.name,
+	r.cdn,
+	r.step_percent,
+	r.soak_seconds,
+	r.step_timeout_seconds,
+	r.status,
+	r.current_step,
+	r.step_count,
+	r.step_started_at,
+	r.step_applied_at,
+	r.halt_reason,
+	(SELECT COUNT(*) FROM rollout_server AS rs WHERE rs.rollout = r.id) AS server_count,
+	(SELECT COUNT(*) FROM rollout_server AS rs WHERE rs.rollout = r.id AND rs.released_at IS NOT NULL) AS released_server_count,
+	(
+		SELECT COUNT(*)
+		FROM rollout_server AS rs
+		JOIN server AS s ON s.id = rs.server
+		WHERE rs.rollout = r.id
+		AND s.config_apply_time >= rs.released_at
+	) AS applied_server_count,
+	r.created_by,
+	r.last_updated
+FROM rollout AS r
+JOIN cdn ON cdn.id = r.cdn
+`
+
+const inProgressQuery = `
+SELECT EXISTS (
+	SELECT 1
+	FROM rollout
+	WHERE cdn = $1
+	AND status IN ('running', 'paused', 'halted')
+)
+`
+
+// cacheServersQuery selects the cache servers of a CDN, and their Cache
+// Groups.
+const cacheServersQuery = `
+SELECT s.id, s.cachegroup
+FROM server AS s
+JOIN type AS t ON t.id = s.type
+WHERE s.cdn_id = $1
+AND (t.name LIKE '` + tc.EdgeTypePrefix + `%' OR t.name LIKE '` + tc.MidTypePrefix + `%')
+`
+
+const insertQuery = `
+INSERT INTO rollout (cdn, step_percent, soak_seconds, step_timeout_seconds, step_count, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id
+`
+
+const insertServersQuery = `
+INSERT INTO rollout_server (rollout, server, step)
+SELECT $1, UNNEST($2::bigint[]), UNNEST($3::integer[])
+`
+
+const setStatusQuery = `
+UPDATE rollout SET
+	status = $1,
+	halt_reason = NULL,
+	last_updated = now()
+WHERE id = $2
+`
+
+// resumeQuery restarts the current step's timeout, since the servers of a
+// Rollout which was paused or halted may have been waiting for any length of
+// time.
+const resumeQuery = `
+UPDATE rollout SET
+	status = 'running',
+	halt_reason = NULL,
+	step_started_at = CASE WHEN current_step > 0 THEN now() ELSE NULL END,
+	last_updated = now()
+WHERE id = $1
+`
+
+// Read is the handler for GET requests to /rollouts.
+func Read(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	queryParamsToQueryCols := map[string]dbhelpers.WhereColumnInfo{
+		"id":     dbhelpers.WhereColumnInfo{Column: "r.id", Checker: api.IsInt},
+		"cdn":    dbhelpers.WhereColumnInfo{Column: "cdn.name"},
+		"cdnId":  dbhelpers.WhereColumnInfo{Column: "r.cdn", Checker: api.IsInt},
+		"status": dbhelpers.WhereColumnInfo{Column: "r.status"},
+	}
+
+	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(inf.Params, queryParamsToQueryCols)
+	if len(errs) > 0 {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, util.JoinErrs(errs), nil)
+		return
+	}
+	if orderBy == "" {
+		orderBy = "\nORDER BY r.id"
+	}
+
+	rows, err := inf.Tx.NamedQuery(readQuery+where+orderBy+pagination, queryValues)
+	if err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		if sysErr != nil {
+			sysErr = fmt.Errorf("rollout read query: %v", sysErr)
+		}
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer rows.Close()
+
+	rollouts := []tc.Rollout{}
+	for rows.Next() {
+		var ro tc.Rollout
+		if err = scan(rows, &ro); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("scanning rollouts: "+err.Error()))
+			return
+		}
+		rollouts = append(rollouts, ro)
+	}
+
+	api.WriteResp(w, r, rollouts)
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(row scanner, ro *tc.Rollout) error {
+	return row.Scan(
+		&ro.ID,
+		&ro.CDN,
+		&ro.CDNID,
+		&ro.StepPercent,
+		&ro.SoakSeconds,
+		&ro.StepTimeoutSeconds,
+		&ro.Status,
+		&ro.CurrentStep,
+		&ro.StepCount,
+		&ro.StepStartedAt,
+		&ro.StepAppliedAt,
+		&ro.HaltReason,
+		&ro.ServerCount,
+		&ro.ReleasedServerCount,
+		&ro.AppliedServerCount,
+		&ro.CreatedBy,
+		&ro.LastUpdated,
+	)
+}
+
+// getRollout returns the Rollout with the given ID, and whether or not it
+// exists.
+func getRollout(tx *sql.Tx, id int) (tc.Rollout, bool, error) {
+	var ro tc.Rollout
+	if err := scan(tx.QueryRow(readQuery+"WHERE r.id = $1", id), &ro); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ro, false, nil
+		}
+		return ro, false, fmt.Errorf("querying rollout #%d: %w", id, err)
+	}
+	return ro, true, nil
+}
+
+// cacheServer is a cache server of a CDN being rolled out to.
+type cacheServer struct {
+	id         int
+	cachegroup int
+}
+
+// assignSteps assigns each server to the step of a Rollout in which it is
+// released updates, such that each step releases stepPercent percent -
+// rounded up - of the servers of each Cache Group. It returns the step of
+// each server, by ID, and the number of steps.
+//
+// The servers of each Cache Group are released in order of ID, so that
+// assigning the same servers always gives the same steps.
+func assignSteps(servers []cacheServer, stepPercent int) (map[int]int, int) {
+	byCachegroup := map[int][]int{}
+	for _, s := range servers {
+		byCachegroup[s.cachegroup] = append(byCachegroup[s.cachegroup], s.id)
+	}
+
+	steps := make(map[int]int, len(servers))
+	stepCount := 0
+	for _, ids := range byCachegroup {
+		sort.Ints(ids)
+		perStep := (len(ids)*stepPercent + 99) / 100
+		if perStep < 1 {
+			perStep = 1
+		}
+		for i, id := range ids {
+			step := i/perStep + 1
+			steps[id] = step
+			if step > stepCount {
+				stepCount = step
+			}
+		}
+	}
+	return steps, stepCount
+}
+
+func getCacheServers(tx *sql.Tx, cdnID int) ([]cacheServer, error) {
+	rows, err := tx.Query(cacheServersQuery, cdnID)
+	if err != nil {
+		return nil, fmt.Errorf("querying cache servers: %w", err)
+	}
+	defer log.Close(rows, "closing cache server rows")
+
+	servers := []cacheServer{}
+	for rows.Next() {
+		var s cacheServer
+		if err := rows.Scan(&s.id, &s.cachegroup); err != nil {
+			return nil, fmt.Errorf("scanning cache server: %w", err)
+		}
+		servers = append(servers, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over cache servers: %w", err)
+	}
+	return servers, nil
+}
+
+// Create is the handler for POST requests to /rollouts. The first step of
+// the new Rollout is released immediately.
+func Create(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	var req tc.RolloutRequest
+	if userErr = api.Parse(r.Body, tx, &req); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+
+	cdnName, ok, err := dbhelpers.GetCDNNameFromID(tx, int64(req.CDNID))
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting CDN name from ID %d: %w", req.CDNID, err))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("no CDN exists by ID %d", req.CDNID), nil)
+		return
+	}
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserHasCdnLock(tx, string(cdnName), inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var inProgress bool
+	if err := tx.QueryRow(inProgressQuery, req.CDNID).Scan(&inProgress); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("checking for rollouts in progress: %w", err))
+		return
+	}
+	if inProgress {
+		api.HandleErr(w, r, tx, http.StatusConflict, fmt.Errorf("a rollout of CDN '%s' is already in progress; it must be aborted or completed first", cdnName), nil)
+		return
+	}
+
+	servers, err := getCacheServers(tx, req.CDNID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if len(servers) == 0 {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("CDN '%s' has no cache servers to roll out to", cdnName), nil)
+		return
+	}
+	steps, stepCount := assignSteps(servers, req.StepPercent)
+
+	soak := tc.RolloutDefaultSoakSeconds
+	if req.SoakSeconds != nil {
+		soak = *req.SoakSeconds
+	}
+	timeout := tc.RolloutDefaultStepTimeoutSeconds
+	if req.StepTimeoutSeconds != nil {
+		timeout = *req.StepTimeoutSeconds
+	}
+
+	var id int
+	err = tx.QueryRow(insertQuery, req.CDNID, req.StepPercent, soak, timeout, stepCount, inf.User.UserName).Scan(&id)
+	if err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	serverIDs := make([]int64, 0, len(steps))
+	serverSteps := make([]int64, 0, len(steps))
+	for serverID, step := range steps {
+		serverIDs = append(serverIDs, int64(serverID))
+		serverSteps = append(serverSteps, int64(step))
+	}
+	if _, err := tx.Exec(insertServersQuery, id, pq.Array(serverIDs), pq.Array(serverSteps)); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("inserting rollout servers: %w", err))
+		return
+	}
+	if err := releaseStep(tx, id, 1); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	resp, _, err := getRollout(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	changeLogMsg := fmt.Sprintf("ROLLOUT: %d, CDN: %s, ACTION: Created, %d%% of each cache group per step, %d steps, released step 1", resp.ID, resp.CDN, resp.StepPercent, resp.StepCount)
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+
+	alerts := tc.CreateAlerts(tc.SuccessLevel, fmt.Sprintf("Rollout created for CDN '%s', updates released to step 1 of %d", resp.CDN, resp.StepCount))
+	api.WriteAlertsObj(w, r, http.StatusCreated, alerts, resp)
+}
+
+// Pause is the handler for POST requests to /rollouts/{id}/pause.
+func Pause(w http.ResponseWriter, r *http.Request) {
+	changeStatus(w, r, "paused", []tc.RolloutStatus{tc.RolloutStatusRunning}, func(tx *sql.Tx, id int) error {
+		_, err := tx.Exec(setStatusQuery, tc.RolloutStatusPaused, id)
+		return err
+	})
+}
+
+// Resume is the handler for POST requests to /rollouts/{id}/resume.
+func Resume(w http.ResponseWriter, r *http.Request) {
+	changeStatus(w, r, "resumed", []tc.RolloutStatus{tc.RolloutStatusPaused, tc.RolloutStatusHalted}, func(tx *sql.Tx, id int) error {
+		_, err := tx.Exec(resumeQuery, id)
+		return err
+	})
+}
+
+// Abort is the handler for POST requests to /rollouts/{id}/abort. Servers
+// which were not yet released updates by the Rollout are left alone.
+func Abort(w http.ResponseWriter, r *http.Request) {
+	changeStatus(w, r, "aborted", []tc.RolloutStatus{tc.RolloutStatusRunning, tc.RolloutStatusPaused, tc.RolloutStatusHalted}, func(tx *sql.Tx, id int) error {
+		_, err := tx.Exec(setStatusQuery, tc.RolloutStatusAborted, id)
+		return err
+	})
+}
+
+// changeStatus is the common handling of the requests which change the
+// status of a Rollout, which may only be done to a Rollout with one of the
+// given statuses by a user holding the lock of its CDN, if it's locked.
+func changeStatus(w http.ResponseWriter, r *http.Request, action string, from []tc.RolloutStatus, change func(*sql.Tx, int) error) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	id := inf.IntParams["id"]
+	existing, ok, err := getRollout(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no rollout exists by ID %d", id), nil)
+		return
+	}
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserHasCdnLock(tx, existing.CDN, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	allowed := false
+	for _, status := range from {
+		if existing.Status == status {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		api.HandleErr(w, r, tx, http.StatusConflict, fmt.Errorf("a %s rollout cannot be %s", existing.Status, action), nil)
+		return
+	}
+
+	if err := change(tx, id); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	resp, _, err := getRollout(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	changeLogMsg := fmt.Sprintf("ROLLOUT: %d, CDN: %s, ACTION: %s at step %d of %d", resp.ID, resp.CDN, action, resp.CurrentStep, resp.StepCount)
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, fmt.Sprintf("Rollout of CDN '%s' %s", resp.CDN, action), resp)
+}
@@ -0,0 +1,131 @@
+package middleware
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+// These are the headers of the Cross-Origin Resource Sharing (CORS) protocol.
+const (
+	AccessControlAllowCredentials = "Access-Control-Allow-Credentials"
+	AccessControlAllowHeaders     = "Access-Control-Allow-Headers"
+	AccessControlAllowMethods     = "Access-Control-Allow-Methods"
+	AccessControlAllowOrigin      = "Access-Control-Allow-Origin"
+	AccessControlExposeHeaders    = "Access-Control-Expose-Headers"
+	AccessControlMaxAge           = "Access-Control-Max-Age"
+	AccessControlRequestMethod    = "Access-Control-Request-Method"
+	Origin                        = "Origin"
+)
+
+// setCORSHeaders sets the CORS headers of the response to the given request.
+// If no CORS policies are configured, every origin is allowed, as Traffic Ops
+// always has; otherwise the headers only allow the request's origin if the
+// policy of the requested route does.
+func setCORSHeaders(w http.ResponseWriter, r *http.Request) {
+	cfg, err := api.GetConfig(r.Context())
+	if err != nil || cfg.CORS == nil {
+		w.Header().Set(AccessControlAllowCredentials, "true")
+		w.Header().Set(AccessControlAllowHeaders, strings.Join(config.DefaultCORSAllowedHeaders, ", "))
+		w.Header().Set(AccessControlAllowMethods, "POST,GET,OPTIONS,PUT,DELETE")
+		w.Header().Set(AccessControlAllowOrigin, "*")
+		return
+	}
+
+	w.Header().Add(rfc.Vary, Origin)
+	origin := r.Header.Get(Origin)
+	if origin == "" {
+		return
+	}
+	policy := cfg.CORS.Policy(apiRoutePath(r.URL.Path))
+	if policy == nil || !policy.AllowsOrigin(origin) {
+		return
+	}
+	setCORSPolicyHeaders(w, policy, origin)
+	if len(policy.ExposedHeaders) > 0 {
+		w.Header().Set(AccessControlExposeHeaders, strings.Join(policy.ExposedHeaders, ", "))
+	}
+}
+
+// setCORSPolicyHeaders sets the headers of the given policy, which allows the
+// given origin, that are common to preflight and actual responses.
+func setCORSPolicyHeaders(w http.ResponseWriter, policy *config.ConfigCORSPolicy, origin string) {
+	if policy.AllowsAnyOrigin() {
+		w.Header().Set(AccessControlAllowOrigin, "*")
+	} else {
+		w.Header().Set(AccessControlAllowOrigin, origin)
+	}
+	if policy.AllowCredentials {
+		w.Header().Set(AccessControlAllowCredentials, "true")
+	}
+}
+
+// IsCORSPreflight returns whether or not the given request is a CORS
+// preflight request, which a browser sends before a cross-origin request to
+// ask whether it's allowed.
+func IsCORSPreflight(r *http.Request) bool {
+	return r.Method == http.MethodOptions && r.Header.Get(Origin) != "" && r.Header.Get(AccessControlRequestMethod) != ""
+}
+
+// CORSPreflightHandler returns a handler which answers CORS preflight
+// requests by the given policies. Requests the policy of the requested route
+// allows get an empty response with its CORS headers, and others a
+// Forbidden response without them.
+func CORSPreflightHandler(cors *config.ConfigCORS) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add(rfc.Vary, Origin)
+		w.Header().Set("X-Server-Name", ServerName)
+		origin := r.Header.Get(Origin)
+		policy := cors.Policy(apiRoutePath(r.URL.Path))
+		if policy == nil || !policy.AllowsOrigin(origin) || !policy.AllowsMethod(r.Header.Get(AccessControlRequestMethod)) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		setCORSPolicyHeaders(w, policy, origin)
+		w.Header().Set(AccessControlAllowMethods, strings.Join(policy.Methods(), ", "))
+		w.Header().Set(AccessControlAllowHeaders, strings.Join(policy.Headers(), ", "))
+		if policy.MaxAgeSeconds > 0 {
+			w.Header().Set(AccessControlMaxAge, strconv.Itoa(policy.MaxAgeSeconds))
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// apiRoutePath returns the API version-relative path of the route requested
+// at the given path, e.g. "deliveryservices/1" for
+// "/api/4.1/deliveryservices/1". Paths outside the API are returned without
+// their leading slash.
+func apiRoutePath(path string) string {
+	path = strings.TrimPrefix(path, "/")
+	if !strings.HasPrefix(path, "api/") {
+		return path
+	}
+	path = strings.TrimPrefix(path, "api/")
+	if i := strings.Index(path, "/"); i >= 0 {
+		return path[i+1:]
+	}
+	return ""
+}
@@ -0,0 +1,152 @@
+package middleware
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+func testCORSConfig() *config.Config {
+	return &config.Config{
+		CORS: &config.ConfigCORS{
+			Policies: []config.ConfigCORSPolicy{
+				{
+					Routes:           []string{"deliveryservices"},
+					AllowedOrigins:   []string{"https://tools.example.test"},
+					AllowCredentials: true,
+					AllowedMethods:   []string{http.MethodGet},
+					ExposedHeaders:   []string{"Whole-Content-Sha512"},
+					MaxAgeSeconds:    600,
+				},
+				{
+					Routes:         []string{"ping"},
+					AllowedOrigins: []string{"*"},
+				},
+			},
+		},
+	}
+}
+
+func TestWrapHeadersCORSPolicies(t *testing.T) {
+	f := WrapHeaders(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	testCases := []struct {
+		Path             string
+		Origin           string
+		AllowOrigin      string
+		AllowCredentials string
+	}{
+		{Path: "/api/4.1/deliveryservices/1", Origin: "https://tools.example.test", AllowOrigin: "https://tools.example.test", AllowCredentials: "true"},
+		{Path: "/api/4.1/deliveryservices", Origin: "https://evil.example.test"},
+		{Path: "/api/4.1/servers", Origin: "https://tools.example.test"},
+		{Path: "/api/4.1/ping", Origin: "https://evil.example.test", AllowOrigin: "*"},
+		{Path: "/api/4.1/deliveryservices"},
+	}
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, tc.Path, nil)
+		if tc.Origin != "" {
+			r.Header.Set(Origin, tc.Origin)
+		}
+		r = r.WithContext(context.WithValue(r.Context(), api.ConfigContextKey, testCORSConfig()))
+		f(w, r)
+
+		if actual := w.Header().Get(AccessControlAllowOrigin); actual != tc.AllowOrigin {
+			t.Errorf("expected %s for origin '%s' of path '%s' to be '%s', actual: '%s'", AccessControlAllowOrigin, tc.Origin, tc.Path, tc.AllowOrigin, actual)
+		}
+		if actual := w.Header().Get(AccessControlAllowCredentials); actual != tc.AllowCredentials {
+			t.Errorf("expected %s for origin '%s' of path '%s' to be '%s', actual: '%s'", AccessControlAllowCredentials, tc.Origin, tc.Path, tc.AllowCredentials, actual)
+		}
+		if expected := []string{rfc.AcceptEncoding, Origin}; !reflect.DeepEqual(w.Header()[rfc.Vary], expected) {
+			t.Errorf("expected %s to be %v, actual: %v", rfc.Vary, expected, w.Header()[rfc.Vary])
+		}
+	}
+}
+
+func TestCORSPreflightHandler(t *testing.T) {
+	h := CORSPreflightHandler(testCORSConfig().CORS)
+
+	r := httptest.NewRequest(http.MethodOptions, "/api/4.1/deliveryservices", nil)
+	r.Header.Set(Origin, "https://tools.example.test")
+	r.Header.Set(AccessControlRequestMethod, http.MethodGet)
+	if !IsCORSPreflight(r) {
+		t.Fatal("expected an OPTIONS request with an origin and requested method to be a preflight request")
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected status %d for an allowed preflight request, actual: %d", http.StatusNoContent, w.Code)
+	}
+	expected := map[string]string{
+		AccessControlAllowOrigin:      "https://tools.example.test",
+		AccessControlAllowCredentials: "true",
+		AccessControlAllowMethods:     http.MethodGet,
+		AccessControlMaxAge:           "600",
+	}
+	for header, value := range expected {
+		if actual := w.Header().Get(header); actual != value {
+			t.Errorf("expected %s to be '%s', actual: '%s'", header, value, actual)
+		}
+	}
+	if w.Header().Get(AccessControlAllowHeaders) == "" {
+		t.Errorf("expected default %s, actual: none", AccessControlAllowHeaders)
+	}
+
+	r.Header.Set(AccessControlRequestMethod, http.MethodDelete)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden || w.Header().Get(AccessControlAllowOrigin) != "" {
+		t.Errorf("expected a forbidden preflight response without CORS headers for a disallowed method, actual: %d %v", w.Code, w.Header())
+	}
+
+	r = httptest.NewRequest(http.MethodOptions, "/api/4.1/servers", nil)
+	r.Header.Set(Origin, "https://tools.example.test")
+	r.Header.Set(AccessControlRequestMethod, http.MethodGet)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected a forbidden preflight response for a route without a policy, actual: %d", w.Code)
+	}
+}
+
+func TestAPIRoutePath(t *testing.T) {
+	testCases := map[string]string{
+		"/api/4.1/deliveryservices/1": "deliveryservices/1",
+		"/api/5.0/ping":               "ping",
+		"/api/4.1":                    "",
+		"/internal/api/1.0/ping":      "internal/api/1.0/ping",
+		"/":                           "",
+	}
+	for path, expected := range testCases {
+		if actual := apiRoutePath(path); actual != expected {
+			t.Errorf("expected route path of '%s' to be '%s', actual: '%s'", path, expected, actual)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package middleware
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+)
+
+// lastUpdatedKey is the start of every lastUpdated property of an object in
+// a JSON response.
+var lastUpdatedKey = []byte(`"lastUpdated":"`)
+
+// RepresentationKey is the context key of the handler - if any - with which
+// WrapETags serves a GET request to the target of a write, to get the
+// target's current ETag. It's set by the router, because a route's
+// Middlewares know nothing of the other routes for the same path.
+const RepresentationKey = "Representation"
+
+// WrapETags is a Middleware which handles entity tags uniformly for every
+// route. It specifically:
+//   - Adds a strong ETag header to every successful response to a GET request,
+//     which encodes the latest lastUpdated time of the objects in the response
+//     and a hash of the response itself.
+//   - Answers GET requests with 304 Not Modified if their If-None-Match
+//     header matches that ETag.
+//   - Rejects writes with 412 Precondition Failed if their If-Match header
+//     has no valid ETag, so a precondition is never silently ignored.
+//   - Rejects writes with 412 Precondition Failed if their If-Match header
+//     doesn't match the current ETag of their target - the ETag of the
+//     response to a GET request for the same path - by strong comparison, so
+//     weak ETags never match, or if their target doesn't exist. Targets which
+//     can't be read with GET requests are left to the handlers, which check
+//     the ETags against the objects they write.
+func WrapETags(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			serveETag(h, w, r)
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			if im := strings.TrimSpace(r.Header.Get(rfc.IfMatch)); im != "" {
+				if im != "*" {
+					if _, ok := rfc.ParseETags(strings.Split(im, ",")); !ok {
+						api.HandleErr(w, r, nil, http.StatusPreconditionFailed, errors.New("the If-Match header has no valid ETags"), nil)
+						return
+					}
+				}
+				if get, ok := r.Context().Value(RepresentationKey).(http.HandlerFunc); ok {
+					etag, code := currentETag(get, r)
+					if code == http.StatusNotFound {
+						api.HandleErr(w, r, nil, http.StatusPreconditionFailed, errors.New("the If-Match header can't match, because the target doesn't exist"), nil)
+						return
+					}
+					if code == http.StatusOK && !rfc.ETagsMatchStrong(im, etag) {
+						api.HandleErr(w, r, nil, http.StatusPreconditionFailed, errors.New("the If-Match header doesn't match the current ETag of the target"), nil)
+						return
+					}
+				}
+			}
+			h(w, r)
+		default:
+			h(w, r)
+		}
+	}
+}
+
+// currentETag returns the ETag with which a GET request for the target of
+// the write r would be answered, by serving one with get, along with the
+// status code of its response. There is no ETag unless that's 200 OK.
+func currentETag(get http.HandlerFunc, r *http.Request) (string, int) {
+	gr := r.Clone(r.Context())
+	gr.Method = http.MethodGet
+	gr.Body = http.NoBody
+	gr.ContentLength = 0
+	for _, h := range []string{rfc.IfMatch, rfc.IfNoneMatch, rfc.IfModifiedSince, rfc.IfUnmodifiedSince} {
+		gr.Header.Del(h)
+	}
+
+	ew := &etagWriter{}
+	get(ew, gr)
+	code := ew.status(gr)
+	if code != http.StatusOK {
+		return "", code
+	}
+	return rfc.StrongETag(latestLastUpdated(ew.body), ew.body), code
+}
+
+// serveETag serves a GET request with h, adding the response's ETag, or
+// answering it with 304 Not Modified if it matches the request's
+// If-None-Match header.
+func serveETag(h http.HandlerFunc, w http.ResponseWriter, r *http.Request) {
+	inm := r.Header.Get(rfc.IfNoneMatch)
+	if inm != "" {
+		// The handler mustn't answer the request by its own conditions, because
+		// it's evaluated against the whole response here; and If-None-Match
+		// takes precedence over If-Modified-Since, per RFC7232§6.
+		ims := r.Header.Get(rfc.IfModifiedSince)
+		r.Header.Del(rfc.IfNoneMatch)
+		r.Header.Del(rfc.IfModifiedSince)
+		defer func() {
+			r.Header.Set(rfc.IfNoneMatch, inm)
+			if ims != "" {
+				r.Header.Set(rfc.IfModifiedSince, ims)
+			}
+		}()
+	}
+
+	ew := &etagWriter{w: w}
+	h(ew, r)
+
+	if ew.status(r) == http.StatusOK {
+		etag := rfc.StrongETag(latestLastUpdated(ew.body), ew.body)
+		w.Header().Set(rfc.ETagHeader, etag)
+		if inm != "" && rfc.ETagsMatch(inm, etag) {
+			w.Header().Del(rfc.ContentType)
+			*r = *r.WithContext(context.WithValue(r.Context(), tc.StatusKey, http.StatusNotModified))
+			return
+		}
+	}
+	if ew.code != 0 {
+		w.WriteHeader(ew.code)
+	}
+	api.WriteAndLogErr(w, r, ew.body)
+}
+
+// latestLastUpdated returns the latest lastUpdated time of any object in the
+// given JSON response, or the zero time if it has none. Times without
+// fractional seconds are rounded up to the next second, like Last-Modified
+// headers, because the objects' real times may be later within that second.
+func latestLastUpdated(body []byte) time.Time {
+	latest := time.Time{}
+	for {
+		i := bytes.Index(body, lastUpdatedKey)
+		if i < 0 {
+			return latest
+		}
+		body = body[i+len(lastUpdatedKey):]
+		end := bytes.IndexByte(body, '"')
+		if end < 0 {
+			return latest
+		}
+		val := string(body[:end])
+		body = body[end:]
+
+		t, err := time.Parse(time.RFC3339Nano, val)
+		if err != nil {
+			if t, err = time.Parse(tc.TimeLayout, val); err != nil {
+				continue
+			}
+			t = t.Add(time.Second)
+		}
+		if t.After(latest) {
+			latest = t
+		}
+	}
+}
+
+// etagWriter records the status code and body written by a handler, so that
+// they can be replaced by a 304 Not Modified response - or, by WrapFields,
+// with the fields the client selected. Without a ResponseWriter to write the
+// headers to, they're discarded.
+type etagWriter struct {
+	w      http.ResponseWriter
+	header http.Header
+	code   int
+	body   []byte
+}
+
+func (e *etagWriter) Header() http.Header {
+	if e.w == nil {
+		if e.header == nil {
+			e.header = http.Header{}
+		}
+		return e.header
+	}
+	return e.w.Header()
+}
+
+// status returns the status code of the response to r, which handlers that
+// don't write it themselves set in its context.
+func (e *etagWriter) status(r *http.Request) int {
+	if e.code != 0 {
+		return e.code
+	}
+	if status, ok := r.Context().Value(tc.StatusKey).(int); ok {
+		return status
+	}
+	return http.StatusOK
+}
+
+func (e *etagWriter) WriteHeader(code int) {
+	if e.code == 0 {
+		e.code = code
+	}
+}
+
+func (e *etagWriter) Write(b []byte) (int, error) {
+	e.body = append(e.body, b...)
+	return len(b), nil
+}
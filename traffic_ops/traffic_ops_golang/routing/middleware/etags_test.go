@@ -0,0 +1,186 @@
+package middleware
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+)
+
+func TestWrapETags(t *testing.T) {
+	body := `{"response":[{"id":1,"lastUpdated":"2022-06-01 10:00:00+00"},{"id":2,"lastUpdated":"2022-06-02 10:00:00+00"}]}`
+	handlerIMS := ""
+	h := Use(func(w http.ResponseWriter, r *http.Request) {
+		handlerIMS = r.Header.Get(rfc.IfModifiedSince)
+		w.Write([]byte(body))
+	}, []Middleware{WrapHeaders, WrapETags})
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/api/5.0/widgets", nil))
+	etag := w.Header().Get(rfc.ETagHeader)
+	if w.Code != http.StatusOK || w.Body.String() != body {
+		t.Fatalf("expected unconditional request to be answered with the handler's response, actual: %d %s", w.Code, w.Body.String())
+	}
+	lastUpdated, err := rfc.ParseETag(etag)
+	if err != nil {
+		t.Fatalf("expected a valid ETag, actual: '%s': %v", etag, err)
+	}
+	if expected := time.Date(2022, 6, 2, 10, 0, 1, 0, time.UTC); !lastUpdated.Equal(expected) {
+		t.Errorf("expected ETag to encode the latest lastUpdated rounded up to the next second %v, actual: %v", expected, lastUpdated)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/api/5.0/widgets", nil)
+	r.Header.Set(rfc.IfNoneMatch, etag)
+	r.Header.Set(rfc.IfModifiedSince, rfc.FormatHTTPDate(time.Now()))
+	w = httptest.NewRecorder()
+	h(w, r)
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("expected request with a matching If-None-Match to be answered with an empty 304, actual: %d %s", w.Code, w.Body.String())
+	}
+	if handlerIMS != "" {
+		t.Errorf("expected If-Modified-Since to be ignored with If-None-Match, actual: handler was given '%s'", handlerIMS)
+	}
+	if w.Header().Get(rfc.ETagHeader) != etag {
+		t.Errorf("expected 304 response to have ETag %s, actual: %s", etag, w.Header().Get(rfc.ETagHeader))
+	}
+
+	body = `{"response":[{"id":1,"lastUpdated":"2022-06-01 10:00:00+00"}]}`
+	r = httptest.NewRequest(http.MethodGet, "/api/5.0/widgets", nil)
+	r.Header.Set(rfc.IfNoneMatch, etag)
+	w = httptest.NewRecorder()
+	h(w, r)
+	if w.Code != http.StatusOK || w.Body.String() != body {
+		t.Errorf("expected request after a deletion to be answered with the new response, actual: %d %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get(rfc.ETagHeader) == etag {
+		t.Error("expected the ETag to change after a deletion that didn't change the latest lastUpdated")
+	}
+}
+
+func TestWrapETagsErrors(t *testing.T) {
+	h := Use(func(w http.ResponseWriter, r *http.Request) {
+		api.HandleErr(w, r, nil, http.StatusNotFound, nil, nil)
+	}, []Middleware{WrapHeaders, WrapETags})
+
+	r := httptest.NewRequest(http.MethodGet, "/api/5.0/widgets", nil)
+	r.Header.Set(rfc.IfNoneMatch, "*")
+	w := httptest.NewRecorder()
+	h(w, r)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected the handler's error status, actual: %d", w.Code)
+	}
+	if etag := w.Header().Get(rfc.ETagHeader); etag != "" {
+		t.Errorf("expected errors not to have an ETag, actual: %s", etag)
+	}
+}
+
+func TestWrapETagsIfMatch(t *testing.T) {
+	called := false
+	h := Use(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		api.WriteResp(w, r, tc.Alerts{})
+	}, []Middleware{WrapHeaders, WrapETags})
+
+	tests := []struct {
+		ifMatch string
+		allowed bool
+	}{
+		{ifMatch: "", allowed: true},
+		{ifMatch: "*", allowed: true},
+		{ifMatch: rfc.ETag(time.Now()), allowed: true},
+		{ifMatch: rfc.StrongETag(time.Now(), []byte("{}")), allowed: true},
+		{ifMatch: `"foo"`, allowed: false},
+		{ifMatch: rfc.StrongETag(time.Time{}, []byte("{}")), allowed: false},
+	}
+	for _, test := range tests {
+		called = false
+		r := httptest.NewRequest(http.MethodPut, "/api/5.0/widgets/1", nil)
+		if test.ifMatch != "" {
+			r.Header.Set(rfc.IfMatch, test.ifMatch)
+		}
+		w := httptest.NewRecorder()
+		h(w, r)
+		if test.allowed && (!called || w.Code != http.StatusOK) {
+			t.Errorf("If-Match '%s': expected the write to be handled, actual: %d", test.ifMatch, w.Code)
+		} else if !test.allowed && (called || w.Code != http.StatusPreconditionFailed) {
+			t.Errorf("If-Match '%s': expected the write to fail its precondition, actual: %d", test.ifMatch, w.Code)
+		}
+	}
+}
+
+func TestWrapETagsIfMatchCurrent(t *testing.T) {
+	body := `{"response":[{"id":1,"lastUpdated":"2022-06-01 10:00:00+00"}]}`
+	found := true
+	get := func(w http.ResponseWriter, r *http.Request) {
+		if !found {
+			api.HandleErr(w, r, nil, http.StatusNotFound, nil, nil)
+			return
+		}
+		w.Write([]byte(body))
+	}
+	h := Use(get, []Middleware{WrapHeaders, WrapETags})
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/api/5.0/widgets/1", nil))
+	etag := w.Header().Get(rfc.ETagHeader)
+
+	called := false
+	put := Use(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		api.WriteResp(w, r, tc.Alerts{})
+	}, []Middleware{WrapHeaders, WrapETags})
+	write := func(ifMatch string) *httptest.ResponseRecorder {
+		called = false
+		r := httptest.NewRequest(http.MethodPut, "/api/5.0/widgets/1", nil)
+		r.Header.Set(rfc.IfMatch, ifMatch)
+		r = r.WithContext(context.WithValue(r.Context(), RepresentationKey, http.HandlerFunc(get)))
+		w := httptest.NewRecorder()
+		put(w, r)
+		return w
+	}
+
+	if w := write(etag); !called || w.Code != http.StatusOK {
+		t.Errorf("expected a write with the current ETag to be handled, actual: %d %s", w.Code, w.Body.String())
+	}
+	for _, weak := range []string{"W/" + etag, rfc.ETag(time.Unix(0, 0)) + ", W/" + etag} {
+		if w := write(weak); called || w.Code != http.StatusPreconditionFailed {
+			t.Errorf("expected a write with If-Match '%s' to fail its precondition, because weak ETags never match, actual: %d %s", weak, w.Code, w.Body.String())
+		}
+	}
+
+	body = `{"response":[{"id":1,"lastUpdated":"2022-06-02 10:00:00+00"}]}`
+	if w := write(etag); called || w.Code != http.StatusPreconditionFailed {
+		t.Errorf("expected a write with a stale ETag to fail its precondition, actual: %d %s", w.Code, w.Body.String())
+	}
+	if w := write("*"); !called || w.Code != http.StatusOK {
+		t.Errorf("expected a write with If-Match '*' to an existing target to be handled, actual: %d %s", w.Code, w.Body.String())
+	}
+
+	found = false
+	if w := write("*"); called || w.Code != http.StatusPreconditionFailed {
+		t.Errorf("expected a write with If-Match '*' to a missing target to fail its precondition, actual: %d %s", w.Code, w.Body.String())
+	}
+}
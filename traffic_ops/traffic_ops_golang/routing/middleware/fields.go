@@ -0,0 +1,189 @@
+package middleware
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+)
+
+// FieldsParam is the query parameter with which clients select the fields of
+// the objects in the responses to GET requests.
+const FieldsParam = "fields"
+
+// fieldSet is a set of selected fields, each of which maps to the selected
+// fields of the objects within it, or to nil if the whole field is selected.
+type fieldSet map[string]fieldSet
+
+// parseFields parses the comma-separated field names of the given values of
+// the fields query parameter into a fieldSet. The fields of objects within
+// objects - or of the objects in arrays within them - are named by their
+// paths, separated by periods, e.g. "interfaces.name".
+func parseFields(vals []string) (fieldSet, error) {
+	fields := fieldSet{}
+	for _, val := range vals {
+		for _, field := range strings.Split(val, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			set := fields
+			path := strings.Split(field, ".")
+			for i, name := range path {
+				if name == "" {
+					return nil, errors.New("'" + field + "' is not a valid field name")
+				}
+				sub, ok := set[name]
+				if i == len(path)-1 {
+					// A whole field includes every field within it.
+					set[name] = nil
+					break
+				}
+				if ok && sub == nil {
+					break
+				}
+				if sub == nil {
+					sub = fieldSet{}
+					set[name] = sub
+				}
+				set = sub
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("'" + FieldsParam + "' must name at least one field")
+	}
+	return fields, nil
+}
+
+// WrapFields is a Middleware which implements "sparse fieldsets" uniformly for
+// every route: the objects in the response to a GET request with a fields
+// query parameter - which is a comma-separated list of field names - only
+// have the fields it names. Names which an object doesn't have are ignored,
+// because objects omit fields which have no value. Responses which aren't
+// JSON are left as they are.
+func WrapFields(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vals, ok := r.URL.Query()[FieldsParam]
+		if r.Method != http.MethodGet || !ok {
+			h(w, r)
+			return
+		}
+		fields, err := parseFields(vals)
+		if err != nil {
+			api.HandleErr(w, r, nil, http.StatusBadRequest, err, nil)
+			return
+		}
+
+		fw := &etagWriter{w: w}
+		h(fw, r)
+
+		body := fw.body
+		if fw.status(r) == http.StatusOK && len(body) > 0 && strings.HasPrefix(w.Header().Get(rfc.ContentType), rfc.ApplicationJSON) {
+			if selected, err := selectResponseFields(body, fields); err != nil {
+				log.Warnf("selecting fields of the response to %s: %v", r.URL.Path, err)
+			} else {
+				body = selected
+			}
+		}
+		if fw.code != 0 {
+			w.WriteHeader(fw.code)
+		}
+		api.WriteAndLogErr(w, r, body)
+	}
+}
+
+// selectResponseFields returns the given JSON response with only the selected
+// fields of the object or objects in its "response" property. Its other
+// properties, like "alerts" and "summary", are left as they are.
+func selectResponseFields(body []byte, fields fieldSet) ([]byte, error) {
+	var resp map[string]json.RawMessage
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+	obj, ok := resp["response"]
+	if !ok {
+		return body, nil
+	}
+	selected, err := selectFields(obj, fields)
+	if err != nil {
+		return nil, err
+	}
+	resp["response"] = selected
+	bts, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return append(bts, '\n'), nil
+}
+
+// selectFields returns the given JSON object with only the selected fields,
+// or the given JSON array with only the selected fields of each object in it.
+// Other values are returned as they are.
+func selectFields(v json.RawMessage, fields fieldSet) (json.RawMessage, error) {
+	v = bytes.TrimSpace(v)
+	if len(v) == 0 {
+		return v, nil
+	}
+	switch v[0] {
+	case '[':
+		var arr []json.RawMessage
+		if err := json.Unmarshal(v, &arr); err != nil {
+			return nil, err
+		}
+		for i, elem := range arr {
+			selected, err := selectFields(elem, fields)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = selected
+		}
+		return json.Marshal(arr)
+	case '{':
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(v, &obj); err != nil {
+			return nil, err
+		}
+		selected := make(map[string]json.RawMessage, len(fields))
+		for name, sub := range fields {
+			val, ok := obj[name]
+			if !ok {
+				continue
+			}
+			if sub != nil {
+				var err error
+				if val, err = selectFields(val, sub); err != nil {
+					return nil, err
+				}
+			}
+			selected[name] = val
+		}
+		return json.Marshal(selected)
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,82 @@
+package middleware
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+)
+
+func TestWrapFields(t *testing.T) {
+	body := `{"alerts":[{"text":"deprecated","level":"warning"}],"response":[{"hostName":"edge","status":"ONLINE","cachegroup":"cg","interfaces":[{"name":"eth0","mtu":1500,"ipAddresses":[{"address":"192.0.2.1"}]}],"id":1},{"hostName":"mid","id":2}],"summary":{"count":2}}`
+	h := Use(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(rfc.ContentType, rfc.ApplicationJSON)
+		w.Write([]byte(body))
+	}, []Middleware{WrapHeaders, WrapETags, WrapFields})
+
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"", body},
+		{"?fields=hostName,status", `{"alerts":[{"text":"deprecated","level":"warning"}],"response":[{"hostName":"edge","status":"ONLINE"},{"hostName":"mid"}],"summary":{"count":2}}` + "\n"},
+		{"?fields=id&fields=interfaces.name", `{"alerts":[{"text":"deprecated","level":"warning"}],"response":[{"id":1,"interfaces":[{"name":"eth0"}]},{"id":2}],"summary":{"count":2}}` + "\n"},
+		{"?fields=interfaces.name,interfaces", `{"alerts":[{"text":"deprecated","level":"warning"}],"response":[{"interfaces":[{"name":"eth0","mtu":1500,"ipAddresses":[{"address":"192.0.2.1"}]}]},{}],"summary":{"count":2}}` + "\n"},
+	}
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodGet, "/api/5.0/servers"+test.query, nil))
+		if w.Code != http.StatusOK {
+			t.Errorf("expected request with query '%s' to succeed, actual: %d %s", test.query, w.Code, w.Body.String())
+		} else if w.Body.String() != test.expected {
+			t.Errorf("expected response to request with query '%s' to be %s, actual: %s", test.query, test.expected, w.Body.String())
+		}
+	}
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodPut, "/api/5.0/servers?fields=id", nil))
+	if w.Body.String() != body {
+		t.Errorf("expected fields of responses to requests other than GET not to be selected, actual: %s", w.Body.String())
+	}
+}
+
+func TestWrapFieldsErrors(t *testing.T) {
+	h := Use(func(w http.ResponseWriter, r *http.Request) {
+		api.HandleErr(w, r, nil, http.StatusNotFound, nil, nil)
+	}, []Middleware{WrapHeaders, WrapETags, WrapFields})
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/api/5.0/servers?fields=id", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected the handler's error response to be returned as it is, actual: %d %s", w.Code, w.Body.String())
+	}
+
+	for _, query := range []string{"?fields=", "?fields=,", "?fields=interfaces..name"} {
+		w = httptest.NewRecorder()
+		h(w, httptest.NewRequest(http.MethodGet, "/api/5.0/servers"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("expected request with query '%s' to fail with %d, actual: %d", query, http.StatusBadRequest, w.Code)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package middleware
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/apiusage"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/requeststats"
+)
+
+// countingReader is an io.ReadCloser which counts the bytes read from it.
+type countingReader struct {
+	io.ReadCloser
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.ReadCloser.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// WrapRequestStats returns a Middleware which records the cost of each
+// request to the route with the given ID and method and path in
+// requeststats.Default and its user's usage of the API in apiusage.Default,
+// and logs a warning for requests which spend longer querying the database
+// than the configured slow request time.
+//
+// It should wrap all other Middleware, so the bytes it counts are those
+// actually sent to the client.
+func WrapRequestStats(routeID int, route string) Middleware {
+	return func(h http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			ctx, cost := requeststats.NewContext(r.Context())
+			iw := &util.Interceptor{W: w}
+			body := &countingReader{ReadCloser: http.NoBody}
+			if r.Body != nil {
+				body.ReadCloser = r.Body
+			}
+			r.Body = body
+			start := time.Now()
+			h(iw, r.WithContext(ctx))
+
+			req := requeststats.Request{
+				RouteID:  routeID,
+				Route:    route,
+				User:     cost.User(),
+				DBTime:   cost.DBTime(),
+				Rows:     cost.Rows(),
+				Bytes:    int64(iw.ByteCount),
+				Duration: time.Since(start),
+			}
+			requeststats.Default.Record(time.Now(), req)
+			apiusage.Default.Record(time.Now(), apiusage.Usage{
+				RouteID:    routeID,
+				Route:      route,
+				User:       req.User,
+				Credential: apiusage.Credential(r),
+				BytesIn:    body.n,
+				BytesOut:   req.Bytes,
+			})
+
+			cfg, err := api.GetConfig(ctx)
+			if err != nil || cfg.SlowRequestDBTimeMilliseconds <= 0 {
+				return
+			}
+			if req.DBTime >= time.Duration(cfg.SlowRequestDBTimeMilliseconds)*time.Millisecond {
+				log.Warnf("slow request: route %d '%s' %s?%s user '%s' spent %v querying the database, read %d rows, returned %d bytes, took %v", routeID, route, r.URL.Path, r.URL.RawQuery, req.User, req.DBTime, req.Rows, req.Bytes, req.Duration)
+			}
+		}
+	}
+}
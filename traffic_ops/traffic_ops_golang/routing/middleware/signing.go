@@ -0,0 +1,143 @@
+package middleware
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc/signing"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+)
+
+// SigningKeyCacheTTL is how long CDN signing keys are cached by Traffic Ops
+// for signing responses, and thus how long it may take for a new or deleted
+// key to take effect on every Traffic Ops instance.
+const SigningKeyCacheTTL = time.Minute
+
+type cachedSigningKey struct {
+	key     *ecdsa.PrivateKey
+	expires time.Time
+}
+
+var signingKeys = struct {
+	m map[string]cachedSigningKey
+	sync.Mutex
+}{m: map[string]cachedSigningKey{}}
+
+// InvalidateSigningKey removes the signing key of the named CDN from the
+// cache used to sign responses, so changes to it take effect immediately on
+// this Traffic Ops instance.
+func InvalidateSigningKey(cdn string) {
+	signingKeys.Lock()
+	defer signingKeys.Unlock()
+	delete(signingKeys.m, cdn)
+}
+
+// getSigningKey returns the private signing key of the named CDN from Traffic
+// Vault, or nil if it has none. This is a variable so it may be replaced in
+// tests.
+var getSigningKey = func(ctx context.Context, cdn string) (*ecdsa.PrivateKey, error) {
+	cfg, err := api.GetConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting configuration from request context: %w", err)
+	}
+	if !cfg.TrafficVaultEnabled {
+		return nil, errors.New("Traffic Vault is not configured")
+	}
+	tv, err := api.GetTrafficVault(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting Traffic Vault from request context: %w", err)
+	}
+	db, err := api.GetDB(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting database from request context: %w", err)
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	tvKey, ok, err := tv.GetCDNSigningKey(cdn, tx, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting signing key from Traffic Vault: %w", err)
+	}
+	if !ok {
+		return nil, nil
+	}
+	key, err := signing.ParsePrivateKey(tvKey.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("parsing signing key: %w", err)
+	}
+	return key, nil
+}
+
+func getCachedSigningKey(ctx context.Context, cdn string) (*ecdsa.PrivateKey, error) {
+	signingKeys.Lock()
+	defer signingKeys.Unlock()
+	if cached, ok := signingKeys.m[cdn]; ok && time.Now().Before(cached.expires) {
+		return cached.key, nil
+	}
+	key, err := getSigningKey(ctx, cdn)
+	if err != nil {
+		return nil, err
+	}
+	signingKeys.m[cdn] = cachedSigningKey{key: key, expires: time.Now().Add(SigningKeyCacheTTL)}
+	return key, nil
+}
+
+// signResponse sets the signing.ResponseHeader header of the response to
+// a GET request which asked for it to be signed with a CDN's key, by naming
+// the CDN in its signing.RequestHeader header. Responses which can't be
+// signed are sent without a signature, which clients verifying signatures
+// will reject.
+func signResponse(w http.ResponseWriter, r *http.Request, body []byte) {
+	cdn := r.Header.Get(signing.RequestHeader)
+	if cdn == "" || r.Method != http.MethodGet || len(body) == 0 {
+		return
+	}
+	key, err := getCachedSigningKey(r.Context(), cdn)
+	if err != nil {
+		log.Errorf("signing response to %s %s with the key of CDN '%s': %v", r.Method, r.URL.Path, cdn, err)
+		return
+	}
+	if key == nil {
+		log.Warnf("signing response to %s %s: CDN '%s' has no signing key", r.Method, r.URL.Path, cdn)
+		return
+	}
+	data, err := signing.SignedData(body)
+	if err != nil {
+		log.Errorf("signing response to %s %s: %v", r.Method, r.URL.Path, err)
+		return
+	}
+	sig, err := signing.Sign(key, data)
+	if err != nil {
+		log.Errorf("signing response to %s %s: %v", r.Method, r.URL.Path, err)
+		return
+	}
+	w.Header().Set(signing.ResponseHeader, sig)
+}
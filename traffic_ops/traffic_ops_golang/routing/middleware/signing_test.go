@@ -0,0 +1,83 @@
+package middleware
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc/signing"
+)
+
+func TestWrapHeadersSigning(t *testing.T) {
+	privatePEM, publicPEM, err := signing.GenerateKey("cdn1")
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	key, err := signing.ParsePrivateKey(privatePEM)
+	if err != nil {
+		t.Fatalf("parsing private key: %v", err)
+	}
+	cdn, publicKey, err := signing.ParsePublicKey([]byte(publicPEM))
+	if err != nil {
+		t.Fatalf("parsing public key: %v", err)
+	}
+
+	oldGetSigningKey := getSigningKey
+	defer func() { getSigningKey = oldGetSigningKey }()
+	getSigningKey = func(ctx context.Context, cdn string) (*ecdsa.PrivateKey, error) {
+		if cdn != "cdn1" {
+			return nil, nil
+		}
+		return key, nil
+	}
+
+	body := `{"response":{"foo":"bar"}}`
+	f := WrapHeaders(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/api/5.0/foo", nil)
+	f(w, r)
+	if sig := w.Header().Get(signing.ResponseHeader); sig != "" {
+		t.Errorf("expected no signature of a response to a request not naming a CDN, actual: %s", sig)
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/api/5.0/foo", nil)
+	r.Header.Set(signing.RequestHeader, "cdn1")
+	f(w, r)
+	verifier := signing.Verifier{CDN: cdn, Key: publicKey}
+	if _, err := verifier.VerifyResponse(w.Body.Bytes(), w.Header().Get(signing.ResponseHeader)); err != nil {
+		t.Errorf("expected response signed with the CDN's key, actual error verifying it: %v", err)
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/api/5.0/foo", nil)
+	r.Header.Set(signing.RequestHeader, "cdn2")
+	f(w, r)
+	if sig := w.Header().Get(signing.ResponseHeader); sig != "" {
+		t.Errorf("expected no signature of a response for a CDN without a key, actual: %s", sig)
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tracing"
+	"github.com/apache/trafficcontrol/lib/go-util"
+)
+
+// WrapTracing returns a Middleware which records a server span of each
+// request to the route with the given ID and method and path with
+// tracing.Default, continuing the trace of the request's traceparent header
+// if it has one. The span is the parent of the spans of the database queries
+// made with the request's context.
+//
+// It should wrap all other Middleware, so its span covers the whole request.
+// If there's no default Tracer, requests aren't traced.
+func WrapTracing(routeID int, route string) Middleware {
+	return func(h http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			tracer := tracing.Default()
+			if tracer == nil {
+				h(w, r)
+				return
+			}
+			ctx := r.Context()
+			if parent, ok := tracing.Extract(r.Header); ok {
+				ctx = tracing.ContextWithRemoteParent(ctx, parent)
+			}
+			ctx, span := tracer.Start(ctx, route, tracing.SpanKindServer)
+			defer span.Finish()
+			span.SetAttribute("http.method", r.Method)
+			span.SetAttribute("http.target", r.URL.Path)
+			span.SetAttribute("http.route", route)
+			span.SetAttribute("traffic_ops.route_id", strconv.Itoa(routeID))
+
+			iw := &util.Interceptor{W: w}
+			h(iw, r.WithContext(ctx))
+
+			code := iw.Code
+			if code == 0 {
+				code = http.StatusOK
+			}
+			span.SetAttribute("http.status_code", strconv.Itoa(code))
+			if code >= http.StatusInternalServerError {
+				span.SetError(fmt.Errorf("responded %d", code))
+			}
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package middleware
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tracing"
+)
+
+type spanRecorder struct {
+	spans []*tracing.Span
+}
+
+func (r *spanRecorder) Export(spans []*tracing.Span) error {
+	r.spans = append(r.spans, spans...)
+	return nil
+}
+
+func TestWrapTracing(t *testing.T) {
+	var handlerSpan *tracing.Span
+	handler := WrapTracing(42, "GET servers")(func(w http.ResponseWriter, r *http.Request) {
+		handlerSpan = tracing.SpanFromContext(r.Context())
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	// Without a default Tracer, requests aren't traced.
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/4.1/servers", nil))
+	if handlerSpan != nil {
+		t.Fatal("expected no span without a default Tracer")
+	}
+
+	rec := &spanRecorder{}
+	tracer := tracing.NewTracer(rec, 1)
+	tracing.SetDefault(tracer)
+	defer tracing.SetDefault(nil)
+
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	r := httptest.NewRequest(http.MethodGet, "/api/4.1/servers?hostName=edge", nil)
+	r.Header.Set(tracing.TraceParentHeader, traceParent)
+	handler(httptest.NewRecorder(), r)
+	tracer.Close()
+
+	if len(rec.spans) != 1 || rec.spans[0] != handlerSpan {
+		t.Fatalf("expected the request's span to be exported, actual: %v", rec.spans)
+	}
+	span := rec.spans[0]
+	if span.Context.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || span.Parent.String() != "00f067aa0ba902b7" {
+		t.Errorf("expected the span to continue the client's trace, actual: %s parent %s", span.Context.TraceID, span.Parent)
+	}
+	if span.Name != "GET servers" || span.Kind != tracing.SpanKindServer {
+		t.Errorf("expected a server span named for the route, actual: %s %d", span.Name, span.Kind)
+	}
+	if span.Attributes["http.target"] != "/api/4.1/servers" || span.Attributes["http.status_code"] != "503" || span.Attributes["traffic_ops.route_id"] != "42" {
+		t.Errorf("expected the request's path, route ID, and status code attributes, actual: %v", span.Attributes)
+	}
+	if span.Error == "" {
+		t.Error("expected a server error response to mark the span as failed")
+	}
+}
@@ -21,12 +21,14 @@ package middleware
  */
 
 import (
+	"bufio"
 	"bytes"
 	"compress/gzip"
 	"crypto/sha512"
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -67,9 +69,24 @@ var ServerName = "traffic_ops_golang" + "/" + about.About.Version
 type Middleware func(handlerFunc http.HandlerFunc) http.HandlerFunc
 
 // GetDefault returns the default middleware for Traffic Ops.
-// This includes writing to the access log, a request timeout, default headers such as CORS, and compression.
+// This includes writing to the access log, a request timeout, default headers such as CORS, compression, and entity tags.
 func GetDefault(secret string, requestTimeout time.Duration) []Middleware {
-	return []Middleware{GetWrapAccessLog(secret), TimeOutWrapper(requestTimeout), WrapHeaders, WrapPanicRecover}
+	return append([]Middleware{GetWrapAccessLog(secret), TimeOutWrapper(requestTimeout), WrapHeaders, WrapETags}, GetRepresentation()...)
+}
+
+// GetRepresentation returns the default middleware for Traffic Ops which is
+// applied inside WrapETags, and so affects the representations from which
+// ETags are computed.
+func GetRepresentation() []Middleware {
+	return []Middleware{WrapFields, WrapPanicRecover}
+}
+
+// GetStreaming returns the middleware for Traffic Ops routes that stream
+// their responses. This is the same as the default middleware, except that
+// responses are neither buffered, checksummed, nor compressed, and there is
+// no request timeout; handlers must end their streams themselves.
+func GetStreaming(secret string) []Middleware {
+	return []Middleware{GetWrapAccessLog(secret), WrapStreamHeaders, WrapPanicRecover}
 }
 
 // Use takes a slice of middlewares, and applies them in reverse order (which is the intuitive behavior) to the given HandlerFunc h.
@@ -123,12 +140,29 @@ func (a AuthBase) GetWrapper(privLevelRequired int) Middleware {
 					return
 				}
 			}
+			if user.ImpersonatedBy != "" && isMFAEnrollmentRoute(r.URL.Path) && r.Method != http.MethodGet {
+				api.HandleErr(w, r, nil, http.StatusForbidden, errors.New("a user's account and authentication factors cannot be changed while impersonating them"), nil)
+				return
+			}
+			// the impersonator has already authenticated themselves
+			if user.ImpersonatedBy == "" && cfg.MFA != nil && !user.MFAEnrolled && cfg.MFA.RoleRequiresMFA(user.RoleName) && !isMFAEnrollmentRoute(r.URL.Path) {
+				api.HandleErr(w, r, nil, http.StatusForbidden, errors.New("your Role requires a second authentication factor, which must be added with the /user/mfa endpoints before anything else can be done"), nil)
+				return
+			}
 			api.AddUserToReq(r, user)
 			handlerFunc(w, r)
 		}
 	}
 }
 
+// isMFAEnrollmentRoute returns whether or not the route requested at the given
+// path is one which users who must add a second authentication factor may use
+// before they have. Routes' paths match with or without a trailing slash.
+func isMFAEnrollmentRoute(path string) bool {
+	routePath := strings.Trim(apiRoutePath(path), "/")
+	return routePath == "user/current" || routePath == "user/mfa" || strings.HasPrefix(routePath, "user/mfa/")
+}
+
 // TimeOutWrapper is a Middleware which adds the given timeout to the request.
 // This causes the request to abort and return an error to the user if the handler takes longer than the timeout to execute.
 func TimeOutWrapper(timeout time.Duration) Middleware {
@@ -140,30 +174,85 @@ func TimeOutWrapper(timeout time.Duration) Middleware {
 }
 
 // WrapHeaders is a Middleware which adds common headers and behavior to the handler. It specifically:
-//   - Adds default CORS headers to the response.
+//   - Adds CORS headers to the response, by the configured CORS policies.
 //   - Adds the Whole-Content-SHA512 checksum header to the response.
+//   - Signs the response with a CDN's signing key, if the client named the CDN in a Response-Signature-CDN header.
 //   - Gzips the response and sets the Content-Encoding header, if the client sent an Accept-Encoding: gzip header.
 //   - Adds the Vary: Accept-Encoding header to the response
 func WrapHeaders(h http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Credentials", "true")
-		w.Header().Set("Access-Control-Allow-Headers", "Origin, X-Requested-With, Content-Type, Accept, Set-Cookie, Cookie")
-		w.Header().Set("Access-Control-Allow-Methods", "POST,GET,OPTIONS,PUT,DELETE")
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set(rfc.Vary, rfc.AcceptEncoding)
-		w.Header().Set("X-Server-Name", ServerName)
-		w.Header().Set(rfc.PermissionsPolicy, "interest-cohort=()")
+		setCommonHeaders(w, r)
 		iw := &util.BodyInterceptor{W: w}
 		h(iw, r)
 
 		sha := sha512.Sum512(iw.Body())
 		w.Header().Set("Whole-Content-SHA512", base64.StdEncoding.EncodeToString(sha[:]))
+		signResponse(w, r, iw.Body())
 
 		GzipResponse(w, r, iw.Body())
 
 	}
 }
 
+// WrapStreamHeaders is a Middleware like WrapHeaders for handlers that
+// stream their responses, which adds the same CORS and informational headers
+// but writes the response as it's written by the handler.
+func WrapStreamHeaders(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		setCommonHeaders(w, r)
+		h(&streamWriter{ResponseWriter: w, r: r}, r)
+	}
+}
+
+func setCommonHeaders(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(rfc.Vary, rfc.AcceptEncoding)
+	setCORSHeaders(w, r)
+	w.Header().Set("X-Server-Name", ServerName)
+	w.Header().Set(rfc.PermissionsPolicy, "interest-cohort=()")
+}
+
+// streamWriter writes the status code api.HandleErr stores in the request
+// context - which WrapHeaders would otherwise write - if the handler doesn't
+// write one itself, and flushes its underlying ResponseWriter on request.
+type streamWriter struct {
+	http.ResponseWriter
+	r           *http.Request
+	wroteHeader bool
+}
+
+func (s *streamWriter) WriteHeader(code int) {
+	s.wroteHeader = true
+	s.ResponseWriter.WriteHeader(code)
+}
+
+func (s *streamWriter) Write(b []byte) (int, error) {
+	if !s.wroteHeader {
+		if status, ok := s.r.Context().Value(tc.StatusKey).(int); ok {
+			s.WriteHeader(status)
+		}
+		s.wroteHeader = true
+	}
+	return s.ResponseWriter.Write(b)
+}
+
+// Flush implements http.Flusher.
+func (s *streamWriter) Flush() {
+	if f, ok := s.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker, so that handlers can open WebSocket
+// connections.
+func (s *streamWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := s.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	s.wroteHeader = true
+	return hj.Hijack()
+}
+
 // WrapPanicRecover is a Middleware which adds a panic recover call to the given HandlerFunc h.
 // If h throws an unhandled panic, an error is logged and an Internal Server Error is returned to the client.
 func WrapPanicRecover(h http.HandlerFunc) http.HandlerFunc {
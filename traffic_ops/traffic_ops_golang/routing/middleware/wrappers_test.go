@@ -92,6 +92,53 @@ func TestWrapHeaders(t *testing.T) {
 }
 
 // TestWrapPanicRecover checks that a recovered panic returns a 500
+func TestWrapStreamHeaders(t *testing.T) {
+	f := WrapStreamHeaders(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("data: 1\n\n"))
+		f, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("expected the response writer to support flushing")
+		}
+		f.Flush()
+		w.Write([]byte("data: 2\n\n"))
+	})
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	f(w, r)
+	if !w.Flushed {
+		t.Error("expected the response to be flushed")
+	}
+	if expected := "data: 1\n\ndata: 2\n\n"; w.Body.String() != expected {
+		t.Errorf("expected body %q, got %q", expected, w.Body.String())
+	}
+	if w.Header().Get("X-Server-Name") == "" || w.Header().Get("Access-Control-Allow-Origin") == "" {
+		t.Errorf("expected the common headers to be set, got %v", w.Header())
+	}
+	if w.Header().Get("Whole-Content-Sha512") != "" || w.Header().Get(rfc.ContentEncoding) != "" {
+		t.Error("expected a streamed response not to be checksummed or compressed")
+	}
+
+	f = WrapStreamHeaders(func(w http.ResponseWriter, r *http.Request) {
+		api.HandleErr(w, r, nil, http.StatusBadRequest, fmt.Errorf("bad request"), nil)
+	})
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	f(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected the status code of an error to be written, got %d", w.Code)
+	}
+
+	hijackable := false
+	f = WrapAccessLog("secret", WrapStreamHeaders(func(w http.ResponseWriter, r *http.Request) {
+		_, hijackable = w.(http.Hijacker)
+	}))
+	f(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if !hijackable {
+		t.Error("expected the response writer of a streamed response to support hijacking")
+	}
+}
+
 func TestWrapPanicRecover(t *testing.T) {
 	f := WrapPanicRecover(func(w http.ResponseWriter, r *http.Request) {
 		var foo *string
@@ -234,7 +281,7 @@ func TestWrapAuth(t *testing.T) {
 
 	f(w, r)
 
-	expectedError := `{"alerts":[{"text":"unauthorized, please log in.","level":"error"}]}` + "\n"
+	expectedError := `{"alerts":[{"text":"unauthorized, please log in.","level":"error","code":"unauthorized"}]}` + "\n"
 
 	if *debugLogging {
 		fmt.Printf("received: %s\n expected: %s\n", w.Body.Bytes(), expectedError)
@@ -245,6 +292,118 @@ func TestWrapAuth(t *testing.T) {
 	}
 }
 
+func TestWrapAuthMFARequired(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	userName := "user1"
+	secret := "secret"
+	cfg := &config.Config{
+		ConfigTrafficOpsGolang: config.ConfigTrafficOpsGolang{DBQueryTimeoutSeconds: 20},
+		MFA:                    &config.ConfigMFA{RequiredRoles: []string{"operations"}},
+	}
+	cookie := tocookie.GetCookie(userName, time.Minute, secret)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+	f := AuthBase{secret, nil}.GetWrapper(15)(handler)
+
+	testCases := []struct {
+		path      string
+		enrolled  bool
+		forbidden bool
+	}{
+		{"/api/4.1/servers", false, true},
+		{"/api/4.1/user/mfa/totp", false, false},
+		{"/api/4.1/user/current", false, false},
+		{"/api/4.1/user/current/", false, false},
+		{"/api/4.1/user/mfa/", false, false},
+		{"/api/4.1/servers", true, false},
+	}
+	for _, tc := range testCases {
+		rows := sqlmock.NewRows([]string{"priv_level", "username", "id", "tenant_id", "role_name", "mfa_enrolled"})
+		rows.AddRow(30, userName, 1, 1, "operations", tc.enrolled)
+		mock.ExpectQuery("SELECT").WithArgs(userName).WillReturnRows(rows)
+
+		w, r := newRWPair(t, cookie)
+		r.URL.Path = tc.path
+		r = r.WithContext(context.WithValue(context.Background(), api.DBContextKey, db))
+		r = r.WithContext(context.WithValue(r.Context(), api.ConfigContextKey, cfg))
+		f(w, r)
+		forbidden := bytes.Contains(w.Body.Bytes(), []byte(`"code":"forbidden"`))
+		if forbidden != tc.forbidden || (!forbidden && w.Code != http.StatusNoContent) {
+			t.Errorf("Expected: forbidden %t for %s (enrolled: %t), actual: %t (%d %s)", tc.forbidden, tc.path, tc.enrolled, forbidden, w.Code, w.Body.Bytes())
+		}
+	}
+}
+
+func TestWrapAuthImpersonation(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	userName := "user1"
+	secret := "secret"
+	cfg := &config.Config{
+		ConfigTrafficOpsGolang: config.ConfigTrafficOpsGolang{DBQueryTimeoutSeconds: 20},
+		MFA:                    &config.ConfigMFA{RequiredRoles: []string{"operations"}},
+	}
+	cookie := tocookie.GetImpersonationCookie(userName, "admin", time.Minute, secret)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		user, err := auth.GetCurrentUser(r.Context())
+		if err != nil {
+			t.Errorf("Expected the user to be in the request context: %v", err)
+		} else if user.ImpersonatedBy != "admin" {
+			t.Errorf("Expected user to be impersonated by 'admin', actual: '%s'", user.ImpersonatedBy)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+	f := AuthBase{secret, nil}.GetWrapper(15)(handler)
+
+	testCases := []struct {
+		method    string
+		path      string
+		forbidden bool
+	}{
+		{http.MethodGet, "/api/4.1/servers", false},
+		{http.MethodGet, "/api/4.1/user/current", false},
+		{http.MethodPut, "/api/4.1/user/current", true},
+		{http.MethodPut, "/api/4.1/user/current/", true},
+		{http.MethodPost, "/api/4.1/user/mfa/totp", true},
+		{http.MethodPost, "/api/4.1/user/mfa/totp/", true},
+	}
+	for _, tc := range testCases {
+		rows := sqlmock.NewRows([]string{"priv_level", "username", "id", "tenant_id", "role_name", "mfa_enrolled"})
+		rows.AddRow(30, userName, 1, 1, "operations", false)
+		mock.ExpectQuery("SELECT").WithArgs(userName).WillReturnRows(rows)
+
+		w, r := newRWPair(t, cookie)
+		r.Method = tc.method
+		r.URL.Path = tc.path
+		r = r.WithContext(context.WithValue(context.Background(), api.DBContextKey, db))
+		r = r.WithContext(context.WithValue(r.Context(), api.ConfigContextKey, cfg))
+		f(w, r)
+		forbidden := bytes.Contains(w.Body.Bytes(), []byte(`"code":"forbidden"`))
+		if forbidden != tc.forbidden || (!forbidden && w.Code != http.StatusNoContent) {
+			t.Errorf("Expected: forbidden %t for %s %s, actual: %t (%d %s)", tc.forbidden, tc.method, tc.path, forbidden, w.Code, w.Body.Bytes())
+		}
+		if len(w.Result().Cookies()) != 0 {
+			t.Errorf("Expected impersonation session not to be renewed for %s %s, but cookies were set", tc.method, tc.path)
+		}
+	}
+}
+
 func TestRequiredPermissionsMiddleware(t *testing.T) {
 	mockDB, mock, err := sqlmock.New()
 	if err != nil {
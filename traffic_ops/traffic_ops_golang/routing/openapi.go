@@ -0,0 +1,109 @@
+package routing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/openapi"
+)
+
+// openAPIVersion is the version of the API described by the OpenAPI
+// specification served at /openapi.json.
+var openAPIVersion = api.Version{Major: 4, Minor: 1}
+
+// openAPIRoutes returns descriptions of the routes served by the given
+// version of the API that aren't disabled, for its OpenAPI specification.
+// Routes of earlier minor versions of the same major version are included,
+// since they're served by it too, unless a later one has the same method and
+// path.
+func openAPIRoutes(routes []Route, version api.Version, disabledRoutes map[int]struct{}) []openapi.Route {
+	latest := map[string]Route{}
+	order := []string{}
+	for _, r := range routes {
+		if r.Version.Major != version.Major || r.Version.Minor > version.Minor {
+			continue
+		}
+		if _, disabled := disabledRoutes[r.ID]; disabled {
+			continue
+		}
+		key := r.Method + " " + r.Path
+		if prev, ok := latest[key]; ok {
+			if prev.Version.Minor < r.Version.Minor {
+				latest[key] = r
+			}
+			continue
+		}
+		latest[key] = r
+		order = append(order, key)
+	}
+
+	described := make([]openapi.Route, 0, len(order))
+	for _, key := range order {
+		r := latest[key]
+		body := r.Body
+		if body == nil {
+			body = &openapi.Body{}
+		}
+		described = append(described, openapi.Route{
+			Method:        r.Method,
+			Path:          r.Path,
+			ID:            r.ID,
+			Authenticated: r.Authenticated,
+			PrivLevel:     r.RequiredPrivLevel,
+			Permissions:   r.RequiredPermissions,
+			Request:       body.Request,
+			Response:      body.Response,
+		})
+	}
+	return described
+}
+
+// openAPIHandler serves an OpenAPI specification of the routes it's given.
+// The specification is generated the first time it's requested, since the
+// routes don't change while Traffic Ops runs.
+type openAPIHandler struct {
+	routes         []Route
+	disabledRoutes map[int]struct{}
+
+	once sync.Once
+	spec []byte
+	err  error
+}
+
+// ServeHTTP serves the OpenAPI specification, as the whole response body,
+// rather than in the "response" property of a Traffic Ops API response,
+// so that tools which consume OpenAPI specifications can use it directly.
+func (h *openAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.once.Do(func() {
+		version := fmt.Sprintf("%d.%d", openAPIVersion.Major, openAPIVersion.Minor)
+		h.spec, h.err = json.Marshal(openapi.Generate(version, openAPIRoutes(h.routes, openAPIVersion, h.disabledRoutes)))
+	})
+	if h.err != nil {
+		api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, fmt.Errorf("generating OpenAPI specification: %w", h.err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	api.WriteAndLogErr(w, r, h.spec)
+}
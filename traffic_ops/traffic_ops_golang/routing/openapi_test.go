@@ -0,0 +1,100 @@
+package routing
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/openapi"
+)
+
+func TestOpenAPIRoutesHaveBodies(t *testing.T) {
+	routes, _, err := Routes(ServerData{Config: config.NewFakeConfig()})
+	if err != nil {
+		t.Fatalf("expected: no error getting Routes, actual: %v", err)
+	}
+	for _, r := range routes {
+		if r.Version.Major != openAPIVersion.Major || r.Version.Minor > openAPIVersion.Minor {
+			continue
+		}
+		if r.Body == nil {
+			t.Errorf("expected: route %d (%s %d.%d %s) to have OpenAPI body types, actual: it doesn't", r.ID, r.Method, r.Version.Major, r.Version.Minor, r.Path)
+		}
+	}
+}
+
+func TestOpenAPIRoutes(t *testing.T) {
+	routes := []Route{
+		{Version: api.Version{Major: 3, Minor: 1}, Method: http.MethodGet, Path: `cdns/?$`, ID: 1},
+		{Version: api.Version{Major: 4, Minor: 0}, Method: http.MethodGet, Path: `cdns/?$`, ID: 2},
+		{Version: api.Version{Major: 4, Minor: 1}, Method: http.MethodGet, Path: `cdns/?$`, ID: 3, Body: &openapi.Body{Response: []tc.CDN{}}},
+		{Version: api.Version{Major: 4, Minor: 0}, Method: http.MethodPost, Path: `cdns/?$`, ID: 4},
+		{Version: api.Version{Major: 4, Minor: 2}, Method: http.MethodPut, Path: `cdns/{id}$`, ID: 5},
+		{Version: api.Version{Major: 4, Minor: 0}, Method: http.MethodDelete, Path: `cdns/{id}$`, ID: 6},
+	}
+	described := openAPIRoutes(routes, api.Version{Major: 4, Minor: 1}, map[int]struct{}{6: {}})
+	if len(described) != 2 {
+		t.Fatalf("expected: 2 routes, actual: %d - %+v", len(described), described)
+	}
+	if described[0].ID != 3 {
+		t.Errorf("expected: the 4.1 route to replace the 4.0 route of the same method and path, actual: route %d", described[0].ID)
+	}
+	if described[1].ID != 4 {
+		t.Errorf("expected: route 4, actual: route %d", described[1].ID)
+	}
+	if described[0].Response == nil {
+		t.Error("expected: the response type of GET cdns/?$ to be known, actual: it's not")
+	}
+}
+
+func TestOpenAPIHandler(t *testing.T) {
+	routes, _, err := Routes(ServerData{Config: config.NewFakeConfig()})
+	if err != nil {
+		t.Fatalf("expected: no error getting Routes, actual: %v", err)
+	}
+	h := &openAPIHandler{routes: routes}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/4.1/openapi.json", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected: %d response, actual: %d - %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	doc := openapi.Document{}
+	if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("expected: a JSON OpenAPI document, actual: %v", err)
+	}
+	if doc.OpenAPI != openapi.Version {
+		t.Errorf("expected: OpenAPI version %s, actual: %s", openapi.Version, doc.OpenAPI)
+	}
+	for _, path := range []string{"/cdns", "/servers/{id}", "/openapi.json"} {
+		if _, ok := doc.Paths[path]; !ok {
+			t.Errorf("expected: the path '%s' to be described, actual: it's not", path)
+		}
+	}
+	if _, ok := doc.Components.Schemas["ServerV41"]; !ok {
+		t.Error("expected: a schema of servers, actual: there isn't one")
+	}
+}
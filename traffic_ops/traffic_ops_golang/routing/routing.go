@@ -38,9 +38,11 @@ import (
 	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/openapi"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/plugin"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/routing/middleware"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
@@ -86,6 +88,10 @@ type Route struct {
 	Authenticated       bool
 	Middlewares         []middleware.Middleware
 	ID                  int // unique ID for referencing this Route
+	// Body is the types of the Route's request and response bodies, for its
+	// OpenAPI specification. Every Route of a version of the API described by
+	// the specification must have one.
+	Body *openapi.Body
 }
 
 func (r Route) String() string {
@@ -103,6 +109,27 @@ func (r *Route) SetMiddleware(authBase middleware.AuthBase, requestTimeout time.
 		r.Middlewares = append(r.Middlewares, authWrapper)
 	}
 	r.Middlewares = append(r.Middlewares, middleware.RequiredPermissionsMiddleware(r.RequiredPermissions))
+	r.Middlewares = append([]middleware.Middleware{
+		middleware.WrapTracing(r.ID, r.Method+" "+r.Path),
+		middleware.WrapRequestStats(r.ID, r.Method+" "+r.Path),
+	}, r.Middlewares...)
+}
+
+// representationHandler returns the handler with which a GET Route serves the
+// representations that WrapETags checks the If-Match headers of writes to the
+// same path against: its Handler with only authentication and the default
+// Middlewares applied inside WrapETags. Routes that aren't GET routes, or that
+// have their own Middlewares - and so no ETags - have none.
+func (r Route) representationHandler(authBase middleware.AuthBase) http.HandlerFunc {
+	if r.Method != http.MethodGet || r.Middlewares != nil {
+		return nil
+	}
+	middlewares := middleware.GetRepresentation()
+	if r.Authenticated {
+		middlewares = append(middlewares, authBase.GetWrapper(r.RequiredPrivLevel))
+	}
+	middlewares = append(middlewares, middleware.RequiredPermissionsMiddleware(r.RequiredPermissions))
+	return middleware.Use(r.Handler, middlewares)
 }
 
 // ServerData ...
@@ -118,9 +145,12 @@ type ServerData struct {
 // CompiledRoute ...
 type CompiledRoute struct {
 	Handler http.HandlerFunc
-	Regex   *regexp.Regexp
-	Params  []string
-	ID      int
+	// Representation is the handler of GET routes that serves the
+	// representations of their targets for WrapETags; see PathHandler.
+	Representation http.HandlerFunc
+	Regex          *regexp.Regexp
+	Params         []string
+	ID             int
 }
 
 func getSortedRouteVersions(rs []Route) []api.Version {
@@ -176,7 +206,12 @@ func indexOfApiVersion(versions []api.Version, desiredVersion api.Version) int {
 type PathHandler struct {
 	Path    string
 	Handler http.HandlerFunc
-	ID      int
+	// Representation is the handler with which WrapETags serves GET requests
+	// to the path, to check the If-Match headers of writes to it against the
+	// ETag of its current representation. It's nil for anything but GET
+	// routes with ETags.
+	Representation http.HandlerFunc
+	ID             int
 }
 
 // CreateRouteMap returns a map of methods to a slice of paths and handlers; wrapping the handlers in the appropriate middleware. Uses Semantic Versioning: routes are added to every subsequent minor version, but not subsequent major versions. For example, a 1.2 route is added to 1.3 but not 2.1. Also truncates '2.0' to '2', creating succinct major versions.
@@ -194,6 +229,7 @@ func CreateRouteMap(rs []Route, disabledRouteIDs []int, perlHandler http.Handler
 		versionI := indexOfApiVersion(versions, r.Version)
 		nextMajorVer := r.Version.Major + 1
 		_, isDisabledRoute := disabledRoutes[r.ID]
+		representation := r.representationHandler(authBase)
 		r.SetMiddleware(authBase, requestTimeout)
 		for _, version := range versions[versionI:] {
 			if version.Major >= nextMajorVer {
@@ -205,7 +241,7 @@ func CreateRouteMap(rs []Route, disabledRouteIDs []int, perlHandler http.Handler
 			if isDisabledRoute {
 				m[r.Method] = append(m[r.Method], PathHandler{Path: path, Handler: middleware.WrapAccessLog(authBase.Secret, middleware.DisabledRouteHandler()), ID: r.ID})
 			} else {
-				m[r.Method] = append(m[r.Method], PathHandler{Path: path, Handler: middleware.Use(r.Handler, r.Middlewares), ID: r.ID})
+				m[r.Method] = append(m[r.Method], PathHandler{Path: path, Handler: middleware.Use(r.Handler, r.Middlewares), Representation: representation, ID: r.ID})
 			}
 			log.Infof("adding route %v %v\n", r.Method, path)
 		}
@@ -225,6 +261,7 @@ func CompileRoutes(routes map[string][]PathHandler) map[string][]CompiledRoute {
 		for _, pathHandler := range mRoutes {
 			route := pathHandler.Path
 			handler := pathHandler.Handler
+			representation := pathHandler.Representation
 			var params []string
 			for open := strings.Index(route, "{"); open > 0; open = strings.Index(route, "{") {
 				close := strings.Index(route, "}")
@@ -238,7 +275,7 @@ func CompileRoutes(routes map[string][]PathHandler) map[string][]CompiledRoute {
 			}
 			regex := regexp.MustCompile(route)
 			id := pathHandler.ID
-			compiledRoutes[method] = append(compiledRoutes[method], CompiledRoute{Handler: handler, Regex: regex, Params: params, ID: id})
+			compiledRoutes[method] = append(compiledRoutes[method], CompiledRoute{Handler: handler, Representation: representation, Regex: regex, Params: params, ID: id})
 		}
 	}
 	return compiledRoutes
@@ -281,6 +318,12 @@ func Handler(
 		return
 	}
 
+	if cfg.CORS != nil && middleware.IsCORSPreflight(r) {
+		h := middleware.WrapAccessLog(cfg.Secrets[0], middleware.CORSPreflightHandler(cfg.CORS))
+		h.ServeHTTP(w, r)
+		return
+	}
+
 	requested := r.URL.Path[1:]
 	mRoutes, ok := routes[r.Method]
 	if !ok {
@@ -299,6 +342,11 @@ func Handler(
 
 		routeCtx := context.WithValue(ctx, api.PathParamsKey, params)
 		routeCtx = context.WithValue(routeCtx, middleware.RouteID, compiledRoute.ID)
+		if r.Method != http.MethodGet && r.Header.Get(rfc.IfMatch) != "" {
+			if get := getRepresentationHandler(routes, requested); get != nil {
+				routeCtx = context.WithValue(routeCtx, middleware.RepresentationKey, get)
+			}
+		}
 		r = r.WithContext(routeCtx)
 		compiledRoute.Handler(w, r)
 		return
@@ -373,6 +421,33 @@ func Handler(
 	}
 }
 
+// getRepresentationHandler returns the handler with which the GET route - if
+// any - for the requested path serves its current representation, with that
+// route's path parameters, or nil if there's no such route.
+func getRepresentationHandler(routes map[string][]CompiledRoute, requested string) http.HandlerFunc {
+	for _, compiledRoute := range routes[http.MethodGet] {
+		match := compiledRoute.Regex.FindStringSubmatch(requested)
+		if len(match) == 0 {
+			continue
+		}
+		if compiledRoute.Representation == nil {
+			return nil
+		}
+		params := map[string]string{}
+		for i, v := range compiledRoute.Params {
+			params[v] = match[i+1]
+		}
+		handler := compiledRoute.Representation
+		id := compiledRoute.ID
+		return func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), api.PathParamsKey, params)
+			ctx = context.WithValue(ctx, middleware.RouteID, id)
+			handler(w, r.WithContext(ctx))
+		}
+	}
+	return nil
+}
+
 // HandleBackendRoute does all the pre processing for the backend routes.
 func HandleBackendRoute(cfg *config.Config, route config.BackendRoute, w http.ResponseWriter, r *http.Request) (error, error, int) {
 	var userErr, sysErr error
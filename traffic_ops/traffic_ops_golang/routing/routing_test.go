@@ -23,10 +23,12 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"reflect"
+	"regexp"
 	"testing"
 	"time"
 
@@ -201,6 +203,71 @@ func TestRoutes(t *testing.T) {
 	}
 }
 
+// seededRolePermissions returns the Permissions the database seeds grant to
+// the given Role, and those which they don't take away from it.
+func seededRolePermissions(t *testing.T, role string) (map[string]struct{}, map[string]struct{}) {
+	seeds, err := ioutil.ReadFile("../../app/db/seeds.sql")
+	if err != nil {
+		t.Fatalf("reading database seeds: %v", err)
+	}
+	perm := regexp.MustCompile(`'([A-Z-]+:[A-Z-]+)'`)
+	parse := func(section string) map[string]struct{} {
+		perms := map[string]struct{}{}
+		for _, match := range perm.FindAllStringSubmatch(section, -1) {
+			perms[match[1]] = struct{}{}
+		}
+		return perms
+	}
+
+	quoted := regexp.QuoteMeta(role)
+	granted := regexp.MustCompile(`(?s)CROSS JOIN \( VALUES([^;]*?)\) AS perms\(perm\)\s*WHERE "name" = '` + quoted + `'`).FindSubmatch(seeds)
+	kept := regexp.MustCompile(`(?s)WHERE "name" = '` + quoted + `'\)\s*AND cap_name NOT IN \(([^;]*?)\);`).FindSubmatch(seeds)
+	if granted == nil || kept == nil {
+		t.Fatalf("expected the database seeds to grant Permissions to Role '%s' and take away any others", role)
+	}
+	return parse(string(granted[1])), parse(string(kept[1]))
+}
+
+func TestCacheAgentRole(t *testing.T) {
+	routes, _, err := Routes(ServerData{Config: config.NewFakeConfig()})
+	if err != nil {
+		t.Fatalf("expected: no error getting Routes, actual: %v", err)
+	}
+	granted, kept := seededRolePermissions(t, "cache-agent")
+
+	// These are the API 5.0 routes t3c-agent requests.
+	agentRoutes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, `changefeed/?$`},
+		{http.MethodGet, `jobs/prefetch/?$`},
+		{http.MethodPut, `jobs/prefetch/{id}/progress/?$`},
+		{http.MethodPost, `servercheck/?$`},
+		{http.MethodGet, `servers/?$`},
+	}
+	for _, agentRoute := range agentRoutes {
+		found := false
+		for _, route := range routes {
+			if route.Version != (api.Version{Major: 5, Minor: 0}) || route.Method != agentRoute.method || route.Path != agentRoute.path {
+				continue
+			}
+			found = true
+			for _, perm := range route.RequiredPermissions {
+				if _, ok := granted[perm]; !ok {
+					t.Errorf("%s %s: expected the cache-agent Role to be granted Permission %s", agentRoute.method, agentRoute.path, perm)
+				}
+				if _, ok := kept[perm]; !ok {
+					t.Errorf("%s %s: expected the cache-agent Role to keep Permission %s", agentRoute.method, agentRoute.path, perm)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("expected a route for %s %s", agentRoute.method, agentRoute.path)
+		}
+	}
+}
+
 func TestCreateRouteMap(t *testing.T) {
 	authBase := middleware.AuthBase{Secret: "secret", Override: func(handlerFunc http.HandlerFunc) http.HandlerFunc {
 		return func(w http.ResponseWriter, r *http.Request) {
@@ -239,11 +306,11 @@ func TestCreateRouteMap(t *testing.T) {
 	}
 
 	routes := []Route{
-		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path1`, PathOneHandler, auth.PrivLevelReadOnly, nil, true, nil, 0},
-		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path2`, PathTwoHandler, 0, nil, false, nil, 1},
-		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path3`, PathThreeHandler, 0, nil, false, []middleware.Middleware{}, 2},
-		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path4`, PathFourHandler, 0, nil, false, []middleware.Middleware{}, 3},
-		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path5`, PathFiveHandler, 0, nil, false, []middleware.Middleware{}, 4},
+		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path1`, PathOneHandler, auth.PrivLevelReadOnly, nil, true, nil, 0, nil},
+		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path2`, PathTwoHandler, 0, nil, false, nil, 1, nil},
+		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path3`, PathThreeHandler, 0, nil, false, []middleware.Middleware{}, 2, nil},
+		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path4`, PathFourHandler, 0, nil, false, []middleware.Middleware{}, 3, nil},
+		{api.Version{Major: 1, Minor: 2}, http.MethodGet, `path5`, PathFiveHandler, 0, nil, false, []middleware.Middleware{}, 4, nil},
 	}
 
 	disabledRoutesIDs := []int{4}
@@ -316,16 +383,49 @@ func getAuthWasCalled(ctx context.Context) string {
 	return "false"
 }
 
+func TestGetRepresentationHandler(t *testing.T) {
+	get := func(w http.ResponseWriter, r *http.Request) {
+		params, _ := r.Context().Value(api.PathParamsKey).(map[string]string)
+		fmt.Fprintf(w, "widget %s", params["id"])
+	}
+	write := func(w http.ResponseWriter, r *http.Request) {}
+	routes := []Route{
+		{api.Version{Major: 5, Minor: 0}, http.MethodGet, `widgets/{id}/?$`, get, 0, nil, false, nil, 0, nil},
+		{api.Version{Major: 5, Minor: 0}, http.MethodPut, `widgets/{id}/?$`, write, 0, nil, false, nil, 1, nil},
+		{api.Version{Major: 5, Minor: 0}, http.MethodGet, `streams/{id}/?$`, get, 0, nil, false, []middleware.Middleware{}, 2, nil},
+		{api.Version{Major: 5, Minor: 0}, http.MethodPut, `widgets/{id}/status/?$`, write, 0, nil, false, nil, 3, nil},
+	}
+	routeMap, _ := CreateRouteMap(routes, nil, nil, middleware.AuthBase{Secret: "secret"}, 60)
+	compiled := CompileRoutes(routeMap)
+
+	h := getRepresentationHandler(compiled, "api/5.0/widgets/7")
+	if h == nil {
+		t.Fatal("expected a representation handler for a path with a GET route")
+	}
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/api/5.0/widgets/7", nil))
+	if w.Body.String() != "widget 7" {
+		t.Errorf("expected the representation to be served with the GET route's path parameters, actual: %s", w.Body.String())
+	}
+
+	if h := getRepresentationHandler(compiled, "api/5.0/widgets/7/status"); h != nil {
+		t.Error("expected no representation handler for a path without a GET route")
+	}
+	if h := getRepresentationHandler(compiled, "api/5.0/streams/7"); h != nil {
+		t.Error("expected no representation handler for a GET route without ETags")
+	}
+}
+
 func TestRoute_SetMiddlewares(t *testing.T) {
 	r := Route{}
 	r.SetMiddleware(middleware.AuthBase{Secret: "secret"}, 600*time.Second)
 	preLen := len(r.Middlewares)
-	if preLen != 5 {
-		t.Errorf("Unauthenticated routes should have 5 middlewares by default, actual default: %d", preLen)
+	if preLen != 9 {
+		t.Errorf("Unauthenticated routes should have 9 middlewares by default, actual default: %d", preLen)
 	}
 	r.Authenticated = true
 	r.SetMiddleware(middleware.AuthBase{Secret: "secret", Override: nil}, 600*time.Second)
-	if len(r.Middlewares) != preLen+2 {
-		t.Errorf("Authenticated routes that start with %d middlewares should wind up with %d after setting up defaults, actual amount: %d", preLen, preLen+2, len(r.Middlewares))
+	if len(r.Middlewares) != preLen+4 {
+		t.Errorf("Authenticated routes that start with %d middlewares should wind up with %d after setting up defaults, actual amount: %d", preLen, preLen+4, len(r.Middlewares))
 	}
 }
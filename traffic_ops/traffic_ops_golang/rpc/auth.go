@@ -0,0 +1,112 @@
+package rpc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// methodPermissions are the Permissions required to call each method of the
+// service, which are those of the API endpoints they mirror.
+var methodPermissions = map[string][]string{
+	"/trafficcontrol.trafficops.TrafficOps/GetServers":            {"SERVER:READ", "DELIVERY-SERVICE:READ", "CDN:READ", "PHYSICAL-LOCATION:READ", "CACHE-GROUP:READ", "TYPE:READ", "PROFILE:READ"},
+	"/trafficcontrol.trafficops.TrafficOps/GetDeliveryServices":   {"DELIVERY-SERVICE:READ", "CDN:READ", "TYPE:READ"},
+	"/trafficcontrol.trafficops.TrafficOps/GetSnapshot":           {"CDN-SNAPSHOT:READ"},
+	"/trafficcontrol.trafficops.TrafficOps/GetMonitoringSnapshot": {"MONITOR-CONFIG:READ"},
+}
+
+// headerWriter is an http.ResponseWriter that only keeps the headers written
+// to it, which is all authentication writes.
+type headerWriter http.Header
+
+func (w headerWriter) Header() http.Header         { return http.Header(w) }
+func (w headerWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w headerWriter) WriteHeader(int)             {}
+
+// Authenticate is a gRPC unary interceptor which authenticates callers by the
+// same cookies and bearer tokens as the API, sent as "cookie" and
+// "authorization" metadata, and checks that they may call the method like the
+// API's authentication and Permissions middleware does. Renewed cookies are
+// sent back as "set-cookie" header metadata.
+func (s *Server) Authenticate(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	perms, ok := methodPermissions[info.FullMethod]
+	if !ok {
+		return nil, statusErr(http.StatusInternalServerError, nil, fmt.Errorf("no Permissions are defined for gRPC method %s", info.FullMethod))
+	}
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+	if err != nil {
+		return nil, statusErr(http.StatusInternalServerError, nil, fmt.Errorf("building authentication request: %w", err))
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if cookies := md.Get("cookie"); len(cookies) > 0 {
+		r.Header.Set("Cookie", strings.Join(cookies, "; "))
+	}
+	if authorization := md.Get("authorization"); len(authorization) > 0 {
+		r.Header.Set(rfc.Authorization, authorization[0])
+	}
+	r = r.WithContext(context.WithValue(context.WithValue(ctx, api.DBContextKey, s.DB), api.ConfigContextKey, s.Config))
+
+	w := headerWriter{}
+	user, userErr, sysErr, errCode := api.GetUserFromReq(w, r, s.Config.Secrets[0])
+	if userErr != nil || sysErr != nil {
+		return nil, statusErr(errCode, userErr, sysErr)
+	}
+	if setCookies := http.Header(w).Values("Set-Cookie"); len(setCookies) > 0 {
+		if err := grpc.SetHeader(ctx, metadata.MD{"set-cookie": setCookies}); err != nil {
+			log.Warnf("gRPC: sending renewed cookies: %v", err)
+		}
+	}
+
+	if err := s.authorize(user, perms); err != nil {
+		return nil, err
+	}
+	return handler(context.WithValue(ctx, auth.CurrentUserKey, user), req)
+}
+
+// authorize returns an error if the given user may not call a method that
+// requires the given Permissions.
+func (s *Server) authorize(user auth.CurrentUser, perms []string) error {
+	if !s.Config.RoleBasedPermissions && user.PrivLevel < auth.PrivLevelReadOnly {
+		return statusErr(http.StatusForbidden, errors.New("Forbidden."), nil)
+	}
+	// the impersonator has already authenticated themselves
+	if user.ImpersonatedBy == "" && s.Config.MFA != nil && !user.MFAEnrolled && s.Config.MFA.RoleRequiresMFA(user.RoleName) {
+		return statusErr(http.StatusForbidden, errors.New("your Role requires a second authentication factor, which must be added with the /user/mfa endpoints before anything else can be done"), nil)
+	}
+	if s.Config.RoleBasedPermissions {
+		if missingPerms := user.MissingPermissions(perms...); len(missingPerms) > 0 {
+			return statusErr(http.StatusForbidden, fmt.Errorf("missing required Permissions: %s", strings.Join(missingPerms, ", ")), nil)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+// Package rpc implements the read-only gRPC service of Traffic Ops, which
+// serves servers, Delivery Services, and CDN Snapshots as protocol buffers for
+// clients that poll them frequently enough for JSON to be a burden.
+package rpc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-tc/tcpb"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/crconfig"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/server"
+
+	"github.com/jmoiron/sqlx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// Server is the gRPC service of Traffic Ops. Its methods are served to the
+// same users, with the same Permissions, as the GET requests of the API they
+// mirror.
+type Server struct {
+	tcpb.UnimplementedTrafficOpsServer
+	DB     *sqlx.DB
+	Config *config.Config
+}
+
+// NewGRPCServer returns a gRPC server of the Traffic Ops service, which uses
+// the API's certificate and key, and authenticates its callers in the same
+// way as the API.
+func NewGRPCServer(db *sqlx.DB, cfg *config.Config) (*grpc.Server, error) {
+	creds, err := credentials.NewServerTLSFromFile(cfg.CertPath, cfg.KeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("loading TLS certificate: %w", err)
+	}
+	s := &Server{DB: db, Config: cfg}
+	grpcServer := grpc.NewServer(grpc.Creds(creds), grpc.UnaryInterceptor(s.Authenticate))
+	tcpb.RegisterTrafficOpsServer(grpcServer, s)
+	return grpcServer, nil
+}
+
+// GetServers implements tcpb.TrafficOpsServer.
+func (s *Server) GetServers(ctx context.Context, req *tcpb.GetServersRequest) (*tcpb.GetServersResponse, error) {
+	params := map[string]string{}
+	setInt(params, "id", req.Id)
+	setString(params, "hostName", req.HostName)
+	setInt(params, "cdn", req.CdnId)
+	setInt(params, "cachegroup", req.CachegroupId)
+	setString(params, "cachegroupName", req.CachegroupName)
+	setString(params, "type", req.Type)
+	setString(params, "status", req.Status)
+	setString(params, "profileName", req.ProfileName)
+	setString(params, "topology", req.Topology)
+	setInt(params, "dsId", req.DeliveryServiceId)
+
+	resp := &tcpb.GetServersResponse{}
+	err := s.withTx(ctx, func(tx *sqlx.Tx, user *auth.CurrentUser) error {
+		servers, userErr, sysErr, errCode := server.GetForUser(ctx, params, tx, user)
+		if userErr != nil || sysErr != nil {
+			return statusErr(errCode, userErr, sysErr)
+		}
+		resp.Servers = make([]*tcpb.Server, 0, len(servers))
+		for _, srv := range servers {
+			resp.Servers = append(resp.Servers, tcpb.NewServer(srv))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetDeliveryServices implements tcpb.TrafficOpsServer.
+func (s *Server) GetDeliveryServices(ctx context.Context, req *tcpb.GetDeliveryServicesRequest) (*tcpb.GetDeliveryServicesResponse, error) {
+	params := map[string]string{}
+	setInt(params, "id", req.Id)
+	setString(params, "xmlId", req.XmlId)
+	setInt(params, "cdn", req.CdnId)
+	setInt(params, "type", req.TypeId)
+	setInt(params, "tenant", req.TenantId)
+	setString(params, "topology", req.Topology)
+	if req.Active != nil {
+		params["active"] = strconv.FormatBool(*req.Active)
+	}
+
+	resp := &tcpb.GetDeliveryServicesResponse{}
+	err := s.withTx(ctx, func(tx *sqlx.Tx, user *auth.CurrentUser) error {
+		dses, userErr, sysErr, errCode := deliveryservice.GetForUser(params, tx, user)
+		if userErr != nil || sysErr != nil {
+			return statusErr(errCode, userErr, sysErr)
+		}
+		resp.DeliveryServices = make([]*tcpb.DeliveryService, 0, len(dses))
+		for _, ds := range dses {
+			resp.DeliveryServices = append(resp.DeliveryServices, tcpb.NewDeliveryService(ds))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetSnapshot implements tcpb.TrafficOpsServer.
+func (s *Server) GetSnapshot(ctx context.Context, req *tcpb.GetSnapshotRequest) (*tcpb.CRConfig, error) {
+	if req.Cdn == "" {
+		return nil, status.Error(codes.InvalidArgument, "cdn is required")
+	}
+	var resp *tcpb.CRConfig
+	err := s.withTx(ctx, func(tx *sqlx.Tx, _ *auth.CurrentUser) error {
+		snapshot, cdnExists, err := crconfig.GetCachedSnapshot(ctx, tx.Tx, req.Cdn)
+		if err != nil {
+			return statusErr(http.StatusInternalServerError, nil, errors.New("getting snapshot: "+err.Error()))
+		}
+		if !cdnExists {
+			return statusErr(http.StatusNotFound, errors.New("CDN not found"), nil)
+		}
+		crc := tc.CRConfig{}
+		if err := json.Unmarshal([]byte(snapshot), &crc); err != nil {
+			return statusErr(http.StatusInternalServerError, nil, errors.New("unmarshalling snapshot: "+err.Error()))
+		}
+		if resp, err = tcpb.NewCRConfig(crc); err != nil {
+			return statusErr(http.StatusInternalServerError, nil, errors.New("converting snapshot: "+err.Error()))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetMonitoringSnapshot implements tcpb.TrafficOpsServer.
+func (s *Server) GetMonitoringSnapshot(ctx context.Context, req *tcpb.GetSnapshotRequest) (*tcpb.TrafficMonitorConfig, error) {
+	if req.Cdn == "" {
+		return nil, status.Error(codes.InvalidArgument, "cdn is required")
+	}
+	var resp *tcpb.TrafficMonitorConfig
+	err := s.withTx(ctx, func(tx *sqlx.Tx, _ *auth.CurrentUser) error {
+		snapshot, cdnExists, err := crconfig.GetCachedSnapshotMonitoring(ctx, tx.Tx, req.Cdn)
+		if err != nil {
+			return statusErr(http.StatusInternalServerError, nil, errors.New("getting monitoring snapshot: "+err.Error()))
+		}
+		if !cdnExists {
+			return statusErr(http.StatusNotFound, errors.New("CDN not found"), nil)
+		}
+		tmc := tc.TrafficMonitorConfig{}
+		if err := json.Unmarshal([]byte(snapshot), &tmc); err != nil {
+			return statusErr(http.StatusInternalServerError, nil, errors.New("unmarshalling monitoring snapshot: "+err.Error()))
+		}
+		if resp, err = tcpb.NewTrafficMonitorConfig(tmc); err != nil {
+			return statusErr(http.StatusInternalServerError, nil, errors.New("converting monitoring snapshot: "+err.Error()))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// withTx calls f with a transaction that's committed once it returns, and
+// the user authenticated by Authenticate.
+func (s *Server) withTx(ctx context.Context, f func(tx *sqlx.Tx, user *auth.CurrentUser) error) error {
+	user, err := auth.GetCurrentUser(ctx)
+	if err != nil {
+		return statusErr(http.StatusInternalServerError, nil, errors.New("getting user: "+err.Error()))
+	}
+	dbCtx, cancelTx := context.WithTimeout(ctx, time.Duration(s.Config.DBQueryTimeoutSeconds)*time.Second)
+	defer cancelTx()
+	tx, err := s.DB.BeginTxx(dbCtx, nil)
+	if err != nil {
+		return statusErr(http.StatusInternalServerError, nil, errors.New("could not begin transaction: "+err.Error()))
+	}
+	defer func() {
+		if err := tx.Commit(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			log.Errorln("committing transaction: " + err.Error())
+		}
+	}()
+	return f(tx, user)
+}
+
+// statusErr returns the gRPC status of the given API error, logging its
+// system error, if any. Like the API, callers are only told the user error,
+// or the meaning of the code if there isn't one.
+func statusErr(errCode int, userErr error, sysErr error) error {
+	if sysErr != nil {
+		log.Errorf("gRPC: %v", sysErr)
+	}
+	msg := http.StatusText(errCode)
+	if userErr != nil {
+		msg = userErr.Error()
+	}
+	return status.Error(grpcCode(errCode), msg)
+}
+
+// grpcCode returns the gRPC status code equivalent to the given HTTP status
+// code.
+func grpcCode(httpCode int) codes.Code {
+	switch httpCode {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	}
+	return codes.Internal
+}
+
+func setInt(params map[string]string, name string, val *int64) {
+	if val != nil {
+		params[name] = strconv.FormatInt(*val, 10)
+	}
+}
+
+func setString(params map[string]string, name string, val string) {
+	if val != "" {
+		params[name] = val
+	}
+}
@@ -0,0 +1,140 @@
+package rpc
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatusErr(t *testing.T) {
+	testCases := []struct {
+		Code    int
+		UserErr error
+		SysErr  error
+		Expect  codes.Code
+		Message string
+	}{
+		{http.StatusBadRequest, errors.New("bad"), nil, codes.InvalidArgument, "bad"},
+		{http.StatusUnauthorized, errors.New("unauthorized, please log in."), nil, codes.Unauthenticated, "unauthorized, please log in."},
+		{http.StatusForbidden, errors.New("Forbidden."), nil, codes.PermissionDenied, "Forbidden."},
+		{http.StatusNotFound, errors.New("CDN not found"), nil, codes.NotFound, "CDN not found"},
+		{http.StatusInternalServerError, nil, errors.New("secret"), codes.Internal, http.StatusText(http.StatusInternalServerError)},
+		{http.StatusTeapot, nil, errors.New("secret"), codes.Internal, http.StatusText(http.StatusTeapot)},
+	}
+	for _, testCase := range testCases {
+		st, ok := status.FromError(statusErr(testCase.Code, testCase.UserErr, testCase.SysErr))
+		if !ok {
+			t.Fatalf("expected a gRPC status for HTTP code %d", testCase.Code)
+		}
+		if st.Code() != testCase.Expect {
+			t.Errorf("HTTP code %d: expected gRPC code %s, got %s", testCase.Code, testCase.Expect, st.Code())
+		}
+		if st.Message() != testCase.Message {
+			t.Errorf("HTTP code %d: expected message '%s', got '%s'", testCase.Code, testCase.Message, st.Message())
+		}
+	}
+}
+
+func TestAuthenticateUnauthenticated(t *testing.T) {
+	s := &Server{Config: &config.Config{Secrets: []string{"secret"}}}
+	called := false
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		called = true
+		return nil, nil
+	}
+
+	_, err := s.Authenticate(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/trafficcontrol.trafficops.TrafficOps/GetServers"}, handler)
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("expected code %s without credentials, got %v", codes.Unauthenticated, err)
+	}
+
+	_, err = s.Authenticate(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/trafficcontrol.trafficops.TrafficOps/UpdateServer"}, handler)
+	if status.Code(err) != codes.Internal {
+		t.Errorf("expected code %s for a method without Permissions, got %v", codes.Internal, err)
+	}
+
+	if called {
+		t.Error("expected the handler not to be called")
+	}
+}
+
+func TestAuthorize(t *testing.T) {
+	perms := methodPermissions["/trafficcontrol.trafficops.TrafficOps/GetSnapshot"]
+	testCases := []struct {
+		Name   string
+		Config config.Config
+		User   auth.CurrentUser
+		Expect codes.Code
+	}{
+		{
+			Name:   "privilege level",
+			User:   auth.CurrentUser{PrivLevel: auth.PrivLevelReadOnly},
+			Expect: codes.OK,
+		},
+		{
+			Name:   "insufficient privilege level",
+			User:   auth.CurrentUser{PrivLevel: auth.PrivLevelInvalid},
+			Expect: codes.PermissionDenied,
+		},
+		{
+			Name:   "admin Permissions",
+			Config: config.Config{RoleBasedPermissions: true},
+			User:   auth.CurrentUser{RoleName: tc.AdminRoleName},
+			Expect: codes.OK,
+		},
+		{
+			Name:   "missing Permissions",
+			Config: config.Config{RoleBasedPermissions: true},
+			User:   auth.CurrentUser{PrivLevel: auth.PrivLevelAdmin, RoleName: "operations"},
+			Expect: codes.PermissionDenied,
+		},
+		{
+			Name:   "second factor required",
+			Config: config.Config{MFA: &config.ConfigMFA{RequiredRoles: []string{"operations"}}},
+			User:   auth.CurrentUser{PrivLevel: auth.PrivLevelOperations, RoleName: "operations"},
+			Expect: codes.PermissionDenied,
+		},
+		{
+			Name:   "second factor enrolled",
+			Config: config.Config{MFA: &config.ConfigMFA{RequiredRoles: []string{"operations"}}},
+			User:   auth.CurrentUser{PrivLevel: auth.PrivLevelOperations, RoleName: "operations", MFAEnrolled: true},
+			Expect: codes.OK,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.Name, func(t *testing.T) {
+			s := &Server{Config: &testCase.Config}
+			if code := status.Code(s.authorize(testCase.User, perms)); code != testCase.Expect {
+				t.Errorf("expected code %s, got %s", testCase.Expect, code)
+			}
+		})
+	}
+}
@@ -0,0 +1,450 @@
+package server
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+
+	"github.com/lib/pq"
+)
+
+// cacheHitsLockID is the ID of the Postgres advisory lock held while cache
+// hits are collected, so that only one Traffic Ops instance collects them at
+// a time.
+const cacheHitsLockID = 5711036289
+
+// cacheHitsAgentTimeout is how long t3c-log-agent may take to respond with
+// the cache hits it has counted.
+const cacheHitsAgentTimeout = 10 * time.Second
+
+// cacheHitsConcurrency is how many cache servers cache hits are collected
+// from at once.
+const cacheHitsConcurrency = 16
+
+// maxCacheHitsResponseBytes is the largest response accepted from
+// t3c-log-agent.
+const maxCacheHitsResponseBytes = 32 * 1024 * 1024
+
+// selectCacheHitsServersQuery selects the cache servers from which cache
+// hits are collected, and the end of the last window collected from each.
+const selectCacheHitsServersQuery = `
+SELECT s.id, s.host_name, s.domain_name, t.name, s.cdn_id, c.collected_until
+FROM server AS s
+JOIN type AS t ON s.type = t.id
+JOIN status AS st ON s.status = st.id
+LEFT JOIN cache_hits_collection AS c ON c.server = s.id
+WHERE (t.name LIKE 'EDGE%' OR t.name LIKE 'MID%')
+AND st.name IN ('ONLINE', 'REPORTED')
+ORDER BY s.id
+`
+
+// selectCacheHitsDeliveryServicesQuery selects the HOST_REGEXP regular
+// expressions and origin FQDNs of every Delivery Service, by which the hosts
+// requested of cache servers are attributed to them.
+const selectCacheHitsDeliveryServicesQuery = `
+SELECT ds.id, ds.cdn_id,
+ARRAY(
+	SELECT r.pattern
+	FROM deliveryservice_regex AS dsr
+	JOIN regex AS r ON dsr.regex = r.id
+	JOIN type AS rt ON r.type = rt.id
+	WHERE dsr.deliveryservice = ds.id
+	AND rt.name = 'HOST_REGEXP'
+	ORDER BY dsr.set_number
+),
+ARRAY(SELECT lower(o.fqdn) FROM origin AS o WHERE o.deliveryservice = ds.id)
+FROM deliveryservice AS ds
+ORDER BY ds.id
+`
+
+const upsertCacheHitsQuery = `
+INSERT INTO deliveryservice_cache_hits (deliveryservice, server, tier, window_start, window_end, hits, misses, miss_samples)
+SELECT h.deliveryservice, $1, $2, h.window_start, h.window_end, h.hits, h.misses, h.miss_samples::jsonb
+FROM UNNEST($3::bigint[], $4::timestamptz[], $5::timestamptz[], $6::bigint[], $7::bigint[], $8::text[])
+AS h(deliveryservice, window_start, window_end, hits, misses, miss_samples)
+ON CONFLICT (deliveryservice, server, window_start) DO UPDATE SET
+tier = EXCLUDED.tier,
+window_end = EXCLUDED.window_end,
+hits = EXCLUDED.hits,
+misses = EXCLUDED.misses,
+miss_samples = EXCLUDED.miss_samples
+`
+
+const upsertCacheHitsCollectionQuery = `
+INSERT INTO cache_hits_collection (server, collected_until)
+VALUES ($1, $2)
+ON CONFLICT (server) DO UPDATE SET
+collected_until = GREATEST(cache_hits_collection.collected_until, EXCLUDED.collected_until)
+`
+
+const pruneCacheHitsQuery = `
+DELETE FROM deliveryservice_cache_hits
+WHERE window_end < $1
+`
+
+// InitCacheHitsCollector starts collecting, every interval, the cache hits
+// and misses counted by the t3c-log-agents of cache servers, attributing the
+// hosts requested of each to Delivery Services - by their HOST_REGEXP regular
+// expressions on edge-tier servers, and by the FQDNs of their origins on
+// mid-tier servers. Each round of collection must finish within the
+// interval. If interval is not positive, or cfg is nil, cache hits are never
+// collected.
+//
+// Collecting cache hits is safe with any number of Traffic Ops instances
+// running the collector against the same database.
+func InitCacheHitsCollector(interval time.Duration, db *sql.DB, cfg *config.ConfigLogTail) {
+	if interval <= 0 || cfg == nil {
+		log.Infoln("cache hits collection interval is not positive, cache hits will not be collected")
+		return
+	}
+	retention := time.Duration(config.CacheHitsRetentionDaysDefault) * 24 * time.Hour
+	if cfg.CacheHitsRetentionDays > 0 {
+		retention = time.Duration(cfg.CacheHitsRetentionDays) * 24 * time.Hour
+	}
+	go func() {
+		for {
+			if err := collectCacheHits(db, interval, cfg, retention); err != nil {
+				log.Errorf("collecting cache hits: %v", err)
+			}
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// cacheHitsServer is a cache server from which cache hits are collected.
+type cacheHitsServer struct {
+	id             int
+	hostName       string
+	fqdn           string
+	tier           tc.CacheType
+	cdnID          int
+	collectedUntil *time.Time
+}
+
+// cacheHitsDeliveryService is how the hosts requested of cache servers are
+// attributed to a Delivery Service.
+type cacheHitsDeliveryService struct {
+	id          int
+	hostRegexes []*regexp.Regexp
+	originFQDNs []string
+}
+
+// cacheHitsRow is the cache hits and misses of a Delivery Service on a cache
+// server in a window.
+type cacheHitsRow struct {
+	ds     int
+	start  time.Time
+	end    time.Time
+	counts tc.CacheHitCounts
+}
+
+// collectCacheHits collects the cache hits of every cache server, unless
+// another Traffic Ops instance is collecting them, and deletes those older
+// than the retention. Collection must finish within the interval.
+func collectCacheHits(db *sql.DB, interval time.Duration, cfg *config.ConfigLogTail, retention time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), interval)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	commit := false
+	defer func() {
+		if !commit {
+			if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+				log.Errorf("rolling back cache hits transaction: %v", err)
+			}
+		}
+	}()
+
+	locked := false
+	if err := tx.QueryRow(`SELECT pg_try_advisory_xact_lock($1)`, cacheHitsLockID).Scan(&locked); err != nil {
+		return fmt.Errorf("acquiring lock: %w", err)
+	}
+	if !locked {
+		return nil
+	}
+
+	servers, err := getCacheHitsServers(tx)
+	if err != nil {
+		return err
+	}
+	dses, err := getCacheHitsDeliveryServices(tx)
+	if err != nil {
+		return err
+	}
+
+	windows := fetchCacheHits(ctx, cfg, servers)
+	errs := []error{}
+	for i, server := range servers {
+		if len(windows[i]) == 0 {
+			continue
+		}
+		rows := attributeCacheHits(windows[i], func(host string) []int {
+			return matchCacheHitsHost(dses[server.cdnID], server.tier, host)
+		})
+		if err := writeCacheHits(tx, server, rows, windows[i][len(windows[i])-1].End); err != nil {
+			errs = append(errs, fmt.Errorf("server '%s': %w", server.hostName, err))
+		}
+	}
+	if _, err := tx.Exec(pruneCacheHitsQuery, time.Now().Add(-retention)); err != nil {
+		errs = append(errs, fmt.Errorf("pruning cache hits: %w", err))
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+	commit = true
+	return util.JoinErrs(errs)
+}
+
+func getCacheHitsServers(tx *sql.Tx) ([]cacheHitsServer, error) {
+	rows, err := tx.Query(selectCacheHitsServersQuery)
+	if err != nil {
+		return nil, fmt.Errorf("querying cache servers: %w", err)
+	}
+	defer log.Close(rows, "closing cache server rows")
+
+	servers := []cacheHitsServer{}
+	for rows.Next() {
+		var s cacheHitsServer
+		var domainName, typeName string
+		if err := rows.Scan(&s.id, &s.hostName, &domainName, &typeName, &s.cdnID, &s.collectedUntil); err != nil {
+			return nil, fmt.Errorf("scanning cache server: %w", err)
+		}
+		s.fqdn = s.hostName + "." + domainName
+		s.tier = tc.CacheTypeFromString(typeName)
+		servers = append(servers, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over cache servers: %w", err)
+	}
+	return servers, nil
+}
+
+// getCacheHitsDeliveryServices returns how the hosts requested of cache
+// servers are attributed to Delivery Services, by the IDs of their CDNs.
+func getCacheHitsDeliveryServices(tx *sql.Tx) (map[int][]cacheHitsDeliveryService, error) {
+	rows, err := tx.Query(selectCacheHitsDeliveryServicesQuery)
+	if err != nil {
+		return nil, fmt.Errorf("querying Delivery Services: %w", err)
+	}
+	defer log.Close(rows, "closing Delivery Service rows")
+
+	dses := map[int][]cacheHitsDeliveryService{}
+	for rows.Next() {
+		var ds cacheHitsDeliveryService
+		var cdnID int
+		var patterns []string
+		if err := rows.Scan(&ds.id, &cdnID, pq.Array(&patterns), pq.Array(&ds.originFQDNs)); err != nil {
+			return nil, fmt.Errorf("scanning Delivery Service: %w", err)
+		}
+		for _, pattern := range patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				log.Warnf("Delivery Service #%d host regex '%s' is invalid, skipping: %v", ds.id, pattern, err)
+				continue
+			}
+			ds.hostRegexes = append(ds.hostRegexes, re)
+		}
+		dses[cdnID] = append(dses[cdnID], ds)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over Delivery Services: %w", err)
+	}
+	return dses, nil
+}
+
+// fetchCacheHits returns the windows of cache hits the t3c-log-agent of each
+// of the given servers has counted since they were last collected, in the
+// order of the servers. Servers whose agents can't be reached, or don't
+// count cache hits, have no windows.
+func fetchCacheHits(ctx context.Context, cfg *config.ConfigLogTail, servers []cacheHitsServer) [][]tc.CacheHitWindow {
+	client := newAgentClient(cfg, cacheHitsAgentTimeout)
+	windows := make([][]tc.CacheHitWindow, len(servers))
+	sem := make(chan struct{}, cacheHitsConcurrency)
+	wg := sync.WaitGroup{}
+	for i := range servers {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			w, err := fetchServerCacheHits(ctx, cfg, client, servers[i])
+			if err != nil {
+				log.Warnf("collecting cache hits of server '%s': %v", servers[i].hostName, err)
+				return
+			}
+			windows[i] = w
+		}(i)
+	}
+	wg.Wait()
+	return windows
+}
+
+func fetchServerCacheHits(ctx context.Context, cfg *config.ConfigLogTail, client *http.Client, server cacheHitsServer) ([]tc.CacheHitWindow, error) {
+	q := url.Values{}
+	if server.collectedUntil != nil {
+		q.Set(tc.LogAgentCacheHitsSinceQueryParam, server.collectedUntil.UTC().Format(time.RFC3339))
+	}
+	ctx, cancel := context.WithTimeout(ctx, cacheHitsAgentTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, agentURL(cfg, server.fqdn, tc.LogAgentCacheHitsPath, q), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+cfg.AgentSecret)
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting cache hits from agent: %w", err)
+	}
+	defer log.Close(resp.Body, "closing log agent response body")
+	if resp.StatusCode == http.StatusNotFound {
+		// the agent doesn't count cache hits
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("log agent returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	windows := []tc.CacheHitWindow{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxCacheHitsResponseBytes)).Decode(&windows); err != nil {
+		return nil, fmt.Errorf("decoding cache hits from agent: %w", err)
+	}
+	return windows, nil
+}
+
+// attributeCacheHits returns the cache hits and misses of the given windows
+// of a cache server by Delivery Service and window, attributing the hosts of
+// each window to the Delivery Services returned by match. Hosts of no
+// Delivery Service are left out.
+func attributeCacheHits(windows []tc.CacheHitWindow, match func(host string) []int) []cacheHitsRow {
+	rows := []cacheHitsRow{}
+	for _, window := range windows {
+		byDS := map[int]*cacheHitsRow{}
+		order := []int{}
+		for _, host := range sortedHosts(window.Hosts) {
+			counts := window.Hosts[host]
+			for _, ds := range match(host) {
+				row, ok := byDS[ds]
+				if !ok {
+					row = &cacheHitsRow{ds: ds, start: window.Start, end: window.End}
+					byDS[ds] = row
+					order = append(order, ds)
+				}
+				row.counts.Hits += counts.Hits
+				row.counts.Misses += counts.Misses
+				row.counts.MissSamples = tc.MergeCacheMissSamples(tc.MaxCacheMissSamples, row.counts.MissSamples, counts.MissSamples)
+			}
+		}
+		for _, ds := range order {
+			rows = append(rows, *byDS[ds])
+		}
+	}
+	return rows
+}
+
+// matchCacheHitsHost returns the IDs of the Delivery Services, of the given
+// Delivery Services of a cache server's CDN, whose requests are for the given
+// host on a server of the given tier. On edge-tier servers, that's the first
+// whose HOST_REGEXP regular expressions match it. On mid-tier servers, it's
+// every Delivery Service with an origin of that FQDN, since mid-tier servers
+// can't tell apart the requests of Delivery Services which share origins.
+func matchCacheHitsHost(dses []cacheHitsDeliveryService, tier tc.CacheType, host string) []int {
+	host = strings.ToLower(host)
+	if tier == tc.CacheTypeMid {
+		ids := []int{}
+		for _, ds := range dses {
+			for _, fqdn := range ds.originFQDNs {
+				if fqdn == host {
+					ids = append(ids, ds.id)
+					break
+				}
+			}
+		}
+		return ids
+	}
+	for _, ds := range dses {
+		for _, re := range ds.hostRegexes {
+			if re.MatchString(host) {
+				return []int{ds.id}
+			}
+		}
+	}
+	return nil
+}
+
+// writeCacheHits writes the cache hits and misses of a server, and that they
+// were collected until the given time.
+func writeCacheHits(tx *sql.Tx, server cacheHitsServer, rows []cacheHitsRow, until time.Time) error {
+	if len(rows) > 0 {
+		dses := make([]int64, 0, len(rows))
+		starts := make([]time.Time, 0, len(rows))
+		ends := make([]time.Time, 0, len(rows))
+		hits := make([]int64, 0, len(rows))
+		misses := make([]int64, 0, len(rows))
+		samples := make([]string, 0, len(rows))
+		for _, row := range rows {
+			bts, err := json.Marshal(row.counts.MissSamples)
+			if err != nil {
+				return fmt.Errorf("encoding miss samples: %w", err)
+			}
+			dses = append(dses, int64(row.ds))
+			starts = append(starts, row.start)
+			ends = append(ends, row.end)
+			hits = append(hits, int64(row.counts.Hits))
+			misses = append(misses, int64(row.counts.Misses))
+			samples = append(samples, string(bts))
+		}
+		if _, err := tx.Exec(upsertCacheHitsQuery, server.id, string(server.tier), pq.Array(dses), pq.Array(starts), pq.Array(ends), pq.Array(hits), pq.Array(misses), pq.Array(samples)); err != nil {
+			return fmt.Errorf("writing cache hits: %w", err)
+		}
+	}
+	if _, err := tx.Exec(upsertCacheHitsCollectionQuery, server.id, until); err != nil {
+		return fmt.Errorf("writing cache hits collection time: %w", err)
+	}
+	return nil
+}
+
+func sortedHosts(hosts map[string]tc.CacheHitCounts) []string {
+	sorted := make([]string, 0, len(hosts))
+	for host := range hosts {
+		sorted = append(sorted, host)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,97 @@
+package server
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestMatchCacheHitsHost(t *testing.T) {
+	dses := []cacheHitsDeliveryService{
+		{id: 1, hostRegexes: []*regexp.Regexp{regexp.MustCompile(`.*\.demo1\..*`)}, originFQDNs: []string{"origin.example.com"}},
+		{id: 2, hostRegexes: []*regexp.Regexp{regexp.MustCompile(`.*\.demo2\..*`)}, originFQDNs: []string{"origin.example.com"}},
+		{id: 3, hostRegexes: []*regexp.Regexp{regexp.MustCompile(`.*\.demo\d\..*`)}, originFQDNs: []string{"other.example.com"}},
+	}
+
+	if ids := matchCacheHitsHost(dses, tc.CacheTypeEdge, "cdn.demo2.example.com"); !reflect.DeepEqual(ids, []int{2}) {
+		t.Errorf("expected edge host to match Delivery Service #2 only, actual: %v", ids)
+	}
+	if ids := matchCacheHitsHost(dses, tc.CacheTypeEdge, "cdn.demo3.example.com"); !reflect.DeepEqual(ids, []int{3}) {
+		t.Errorf("expected edge host to match Delivery Service #3, actual: %v", ids)
+	}
+	if ids := matchCacheHitsHost(dses, tc.CacheTypeEdge, "unknown.example.com"); len(ids) != 0 {
+		t.Errorf("expected unknown edge host to match no Delivery Services, actual: %v", ids)
+	}
+	if ids := matchCacheHitsHost(dses, tc.CacheTypeMid, "Origin.Example.com"); !reflect.DeepEqual(ids, []int{1, 2}) {
+		t.Errorf("expected mid host to match every Delivery Service with that origin, actual: %v", ids)
+	}
+	if ids := matchCacheHitsHost(dses, tc.CacheTypeMid, "cdn.demo1.example.com"); len(ids) != 0 {
+		t.Errorf("expected mid host not to be matched by host regexes, actual: %v", ids)
+	}
+}
+
+func TestAttributeCacheHits(t *testing.T) {
+	start := time.Date(2022, 7, 3, 12, 0, 0, 0, time.UTC)
+	windows := []tc.CacheHitWindow{
+		{
+			Start: start,
+			End:   start.Add(time.Minute),
+			Hosts: map[string]tc.CacheHitCounts{
+				"a.example.com": {Hits: 8, Misses: 2, MissSamples: []tc.CacheMissSample{{URL: "/x", Count: 2}}},
+				"b.example.com": {Hits: 1, Misses: 3, MissSamples: []tc.CacheMissSample{{URL: "/x", Count: 1}, {URL: "/y", Count: 2}}},
+				"c.example.com": {Hits: 5},
+			},
+		},
+		{
+			Start: start.Add(time.Minute),
+			End:   start.Add(2 * time.Minute),
+			Hosts: map[string]tc.CacheHitCounts{
+				"a.example.com": {Hits: 4},
+			},
+		},
+	}
+	match := func(host string) []int {
+		switch host {
+		case "a.example.com", "b.example.com":
+			return []int{1}
+		}
+		return nil
+	}
+
+	rows := attributeCacheHits(windows, match)
+	if len(rows) != 2 {
+		t.Fatalf("expected a row for each window of Delivery Service #1, actual: %+v", rows)
+	}
+	if rows[0].ds != 1 || !rows[0].start.Equal(start) || rows[0].counts.Hits != 9 || rows[0].counts.Misses != 5 {
+		t.Errorf("expected the hosts of the first window to be summed, actual: %+v", rows[0])
+	}
+	expected := []tc.CacheMissSample{{URL: "/x", Count: 3}, {URL: "/y", Count: 2}}
+	if !reflect.DeepEqual(rows[0].counts.MissSamples, expected) {
+		t.Errorf("expected merged miss samples %+v, actual: %+v", expected, rows[0].counts.MissSamples)
+	}
+	if rows[1].counts.Hits != 4 || rows[1].counts.Misses != 0 {
+		t.Errorf("expected the second window's counts, actual: %+v", rows[1])
+	}
+}
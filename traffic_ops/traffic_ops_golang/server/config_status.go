@@ -0,0 +1,256 @@
+package server
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+
+	"github.com/lib/pq"
+)
+
+// applyStatusColumn is the state of a server's configuration, as of the last
+// t3c-apply run it reported.
+const applyStatusColumn = `
+	CASE
+		WHEN scr.server IS NULL THEN '` + string(tc.ServerConfigApplyStatusUnknown) + `'
+		WHEN NOT scr.succeeded THEN '` + string(tc.ServerConfigApplyStatusFailed) + `'
+		WHEN s.config_update_time > s.config_apply_time THEN '` + string(tc.ServerConfigApplyStatusPending) + `'
+		ELSE '` + string(tc.ServerConfigApplyStatusApplied) + `'
+	END`
+
+const configStatusQuery = `
+SELECT s.id,
+	s.host_name,
+	cdn.name,
+	cg.name,
+	st.name,` + applyStatusColumn + ` AS apply_status,
+	s.config_update_time,
+	s.config_apply_time,
+	scr.last_success,
+	scr.server IS NOT NULL,
+	COALESCE(scr.succeeded, FALSE),
+	scr.config_update_time,
+	scr.start_time,
+	COALESCE(scr.duration_ms, 0),
+	COALESCE(scr.files_changed, '{}'),
+	COALESCE(scr.errors, '{}'),
+	COALESCE(scr.t3c_version, ''),
+	scr.reported_at
+FROM server AS s
+JOIN cdn ON cdn.id = s.cdn_id
+JOIN cachegroup AS cg ON cg.id = s.cachegroup
+JOIN status AS st ON st.id = s.status
+JOIN type AS t ON t.id = s.type
+LEFT JOIN server_config_run AS scr ON scr.server = s.id
+`
+
+// cacheServerCondition limits the servers in the config status to the cache
+// servers, which are the only ones t3c runs on.
+const cacheServerCondition = ` (t.name LIKE '` + tc.EdgeTypePrefix + `%' OR t.name LIKE '` + tc.MidTypePrefix + `%')`
+
+const upsertConfigRunQuery = `
+INSERT INTO server_config_run (
+	server,
+	succeeded,
+	config_update_time,
+	start_time,
+	duration_ms,
+	files_changed,
+	errors,
+	t3c_version,
+	last_success
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, CASE WHEN $2 THEN now() ELSE NULL END)
+ON CONFLICT (server) DO UPDATE SET
+	succeeded = EXCLUDED.succeeded,
+	config_update_time = EXCLUDED.config_update_time,
+	start_time = EXCLUDED.start_time,
+	duration_ms = EXCLUDED.duration_ms,
+	files_changed = EXCLUDED.files_changed,
+	errors = EXCLUDED.errors,
+	t3c_version = EXCLUDED.t3c_version,
+	last_success = COALESCE(EXCLUDED.last_success, server_config_run.last_success),
+	reported_at = now()
+`
+
+// GetConfigStatus is the handler for GET requests to /servers/config-status,
+// which returns the state of the configuration of every cache server.
+func GetConfigStatus(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	queryParamsToQueryCols := map[string]dbhelpers.WhereColumnInfo{
+		"cdn":         dbhelpers.WhereColumnInfo{Column: "cdn.name"},
+		"cdnId":       dbhelpers.WhereColumnInfo{Column: "s.cdn_id", Checker: api.IsInt},
+		"cachegroup":  dbhelpers.WhereColumnInfo{Column: "cg.name"},
+		"hostName":    dbhelpers.WhereColumnInfo{Column: "s.host_name"},
+		"status":      dbhelpers.WhereColumnInfo{Column: "st.name"},
+		"applyStatus": dbhelpers.WhereColumnInfo{Column: applyStatusColumn},
+	}
+
+	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(inf.Params, queryParamsToQueryCols)
+	if len(errs) > 0 {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, util.JoinErrs(errs), nil)
+		return
+	}
+	if where == "" {
+		where = dbhelpers.BaseWhere + cacheServerCondition
+	} else {
+		where += " AND" + cacheServerCondition
+	}
+	if orderBy == "" {
+		orderBy = "\nORDER BY s.host_name"
+	}
+
+	rows, err := inf.Tx.NamedQuery(configStatusQuery+where+orderBy+pagination, queryValues)
+	if err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		if sysErr != nil {
+			sysErr = fmt.Errorf("server config status read query: %v", sysErr)
+		}
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer rows.Close()
+
+	statuses := []tc.ServerConfigStatus{}
+	for rows.Next() {
+		var st tc.ServerConfigStatus
+		var hasRun bool
+		var run tc.ServerConfigRun
+		var startTime, reportedAt *time.Time
+		err := rows.Scan(
+			&st.ServerID,
+			&st.HostName,
+			&st.CDN,
+			&st.Cachegroup,
+			&st.Status,
+			&st.ApplyStatus,
+			&st.ConfigUpdateTime,
+			&st.ConfigApplyTime,
+			&st.LastSuccessTime,
+			&hasRun,
+			&run.Succeeded,
+			&run.ConfigUpdateTime,
+			&startTime,
+			&run.DurationMS,
+			pq.Array(&run.FilesChanged),
+			pq.Array(&run.Errors),
+			&run.T3CVersion,
+			&reportedAt,
+		)
+		if err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("scanning server config statuses: "+err.Error()))
+			return
+		}
+		if hasRun && startTime != nil && reportedAt != nil {
+			run.StartTime = *startTime
+			run.ReportedAt = *reportedAt
+			st.LastRun = &run
+		}
+		statuses = append(statuses, st)
+	}
+
+	api.WriteResp(w, r, statuses)
+}
+
+// ReportConfigRun is the handler for POST requests to
+// /servers/{{ID or host name}}/config-status, with which t3c reports the
+// outcome of each t3c-apply run.
+func ReportConfigRun(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id-or-name"}, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	idOrName := inf.Params["id-or-name"]
+	serverID, errCode, userErr, sysErr := getServerIDFromIDOrName(tx, idOrName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var run tc.ServerConfigRunRequest
+	if err := api.Parse(r.Body, tx, &run); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	if run.FilesChanged == nil {
+		run.FilesChanged = []string{}
+	}
+	if run.Errors == nil {
+		run.Errors = []string{}
+	}
+
+	_, err := tx.Exec(upsertConfigRunQuery,
+		serverID,
+		run.Succeeded,
+		run.ConfigUpdateTime,
+		run.StartTime,
+		run.DurationMS,
+		pq.Array(run.FilesChanged),
+		pq.Array(run.Errors),
+		run.T3CVersion,
+	)
+	if err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	outcome := "successful"
+	if !run.Succeeded {
+		outcome = "failed"
+	}
+	api.WriteAlerts(w, r, http.StatusOK, tc.CreateAlerts(tc.SuccessLevel, "recorded "+outcome+" config run for server "+idOrName))
+}
+
+// getServerIDFromIDOrName returns the ID of the server identified by
+// idOrName, which may be its ID or its host name, along with the status code,
+// user error, and system error to return if it can't be found.
+func getServerIDFromIDOrName(tx *sql.Tx, idOrName string) (int64, int, error, error) {
+	if serverID, err := strconv.ParseInt(idOrName, 10, 64); err == nil {
+		return serverID, http.StatusOK, nil, nil
+	}
+	id, ok, err := dbhelpers.GetServerIDFromName(idOrName, tx)
+	if err != nil {
+		return 0, http.StatusInternalServerError, nil, fmt.Errorf("getting server id from name '%v': %w", idOrName, err)
+	} else if !ok {
+		return 0, http.StatusNotFound, errors.New("server name '" + idOrName + "' not found"), nil
+	}
+	return int64(id), http.StatusOK, nil, nil
+}
@@ -0,0 +1,63 @@
+package server
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"testing"
+
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestGetServerIDFromIDOrName(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT id FROM server").WithArgs("edge").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+	mock.ExpectQuery("SELECT id FROM server").WithArgs("missing").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	tx, err := mockDB.Begin()
+	if err != nil {
+		t.Fatalf("creating transaction: %v", err)
+	}
+
+	id, code, userErr, sysErr := getServerIDFromIDOrName(tx, "42")
+	if id != 42 || code != http.StatusOK || userErr != nil || sysErr != nil {
+		t.Errorf("expected ID 42 without querying, got %d (%d, %v, %v)", id, code, userErr, sysErr)
+	}
+
+	id, code, userErr, sysErr = getServerIDFromIDOrName(tx, "edge")
+	if id != 7 || code != http.StatusOK || userErr != nil || sysErr != nil {
+		t.Errorf("expected ID 7 for host name 'edge', got %d (%d, %v, %v)", id, code, userErr, sysErr)
+	}
+
+	_, code, userErr, sysErr = getServerIDFromIDOrName(tx, "missing")
+	if code != http.StatusNotFound || userErr == nil || sysErr != nil {
+		t.Errorf("expected not found for host name 'missing', got %d (%v, %v)", code, userErr, sysErr)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
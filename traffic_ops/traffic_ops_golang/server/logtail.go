@@ -0,0 +1,330 @@
+package server
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+)
+
+// logTailAgentTimeout is how long t3c-log-agent may take to accept a
+// connection and start streaming a log.
+const logTailAgentTimeout = 10 * time.Second
+
+// logTailEndMargin is how long after the requested duration a tail is ended
+// by Traffic Ops, if t3c-log-agent hasn't ended it.
+const logTailEndMargin = 10 * time.Second
+
+const selectHostRegexesQuery = `
+SELECT r.pattern
+FROM deliveryservice_regex AS dsr
+JOIN regex AS r ON dsr.regex = r.id
+JOIN type AS t ON r.type = t.id
+WHERE t.name = 'HOST_REGEXP'
+AND dsr.deliveryservice = $1
+ORDER BY dsr.set_number
+`
+
+// logTailParams are the parsed query parameters of a request to tail a log.
+type logTailParams struct {
+	log     string
+	seconds int
+	dsID    *int
+}
+
+// TailLog is the handler for GET requests to /servers/{{ID}}/logs/tail. It
+// opens a WebSocket connection over which it relays the lines appended to a
+// log of the cache server, as streamed by the server's t3c-log-agent, for
+// the requested time, optionally only those of a Delivery Service.
+//
+// Errors are returned as normal responses, and so are only possible before
+// the connection is opened.
+func TailLog(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	cfg := inf.Config.LogTail
+	if cfg == nil {
+		api.HandleErr(w, r, tx, http.StatusServiceUnavailable, errors.New("tailing the logs of cache servers is not configured"), nil)
+		return
+	}
+	params, err := parseLogTailParams(inf.Params, cfg.MaxSeconds)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	if err := rfc.CheckWebSocketUpgrade(r); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	if !logTailOriginAllowed(r.Header.Get("Origin"), r.Host, cfg.AllowedOrigins) {
+		api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("WebSocket connections from this origin are not allowed"), nil)
+		return
+	}
+
+	server, userErr, sysErr, errCode := getAgentServer(tx, inf.IntParams["id"])
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	matches := []string{}
+	if params.dsID != nil {
+		userErr, sysErr, errCode = tenant.CheckID(tx, inf.User, *params.dsID)
+		if userErr != nil || sysErr != nil {
+			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+			return
+		}
+		if matches, err = getHostRegexes(tx, *params.dsID); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+			return
+		}
+		if len(matches) == 0 {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("Delivery Service #%d has no HOST_REGEXP regular expressions by which to match its requests", *params.dsID), nil)
+			return
+		}
+	}
+	agentURL := logTailAgentURL(cfg, server.HostName+"."+server.DomainName, params, matches)
+	log.Infof("user '%s' tailing %s log of server '%s' for %d seconds\n", inf.User.UserName, params.log, server.HostName, params.seconds)
+	// The request's transaction mustn't be held for the life of the tail.
+	inf.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(params.seconds)*time.Second+logTailEndMargin)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, agentURL, nil)
+	if err != nil {
+		api.HandleErr(w, r, nil, http.StatusInternalServerError, nil, errors.New("creating log agent request: "+err.Error()))
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+cfg.AgentSecret)
+	resp, err := newAgentClient(cfg, logTailAgentTimeout).Do(req)
+	if err != nil {
+		api.HandleErr(w, r, nil, http.StatusBadGateway, fmt.Errorf("could not reach the log agent of server '%s'", server.HostName), errors.New("requesting log tail from agent: "+err.Error()))
+		return
+	}
+	defer log.Close(resp.Body, "closing log agent response body")
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		api.HandleErr(w, r, nil, http.StatusBadGateway, fmt.Errorf("the log agent of server '%s' returned %s", server.HostName, resp.Status), fmt.Errorf("log agent returned %s: %s", resp.Status, strings.TrimSpace(string(body))))
+		return
+	}
+
+	conn, err := rfc.UpgradeWebSocket(w, r)
+	if err != nil {
+		log.Errorln("opening WebSocket connection to tail log: " + err.Error())
+		return
+	}
+	relayLogTail(ctx, cancel, conn, resp.Body)
+}
+
+// relayLogTail writes each line read from the log agent's response body to
+// the WebSocket connection as a text message, until the body ends or the
+// client closes the connection, which cancels the agent request.
+func relayLogTail(ctx context.Context, cancel context.CancelFunc, conn *rfc.WebSocketConn, body io.Reader) {
+	go func() {
+		// Clients aren't expected to send anything but pings and closes,
+		// which ReadMessage answers, so other messages are ignored.
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				break
+			}
+		}
+		cancel()
+	}()
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), rfc.MaxWebSocketMessageBytes)
+	for scanner.Scan() {
+		if err := conn.WriteText(scanner.Text()); err != nil {
+			cancel()
+			conn.Close(rfc.WebSocketCloseInternalError, "")
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			conn.Close(rfc.WebSocketCloseNormal, "log tail ended")
+			return
+		}
+		if ctx.Err() == nil {
+			log.Errorln("reading log tail from agent: " + err.Error())
+			conn.Close(rfc.WebSocketCloseInternalError, "reading the log from the cache server failed")
+			return
+		}
+		conn.Close(rfc.WebSocketCloseNormal, "")
+		return
+	}
+	conn.Close(rfc.WebSocketCloseNormal, "log tail ended")
+}
+
+// parseLogTailParams parses and validates the query parameters of a request
+// to tail a log. maxSeconds is the configured longest duration, or 0 for the
+// default.
+func parseLogTailParams(params map[string]string, maxSeconds int) (logTailParams, error) {
+	if maxSeconds <= 0 {
+		maxSeconds = tc.DefaultServerLogTailMaxSeconds
+	}
+	p := logTailParams{log: params[tc.ServerLogTailLogQueryParam], seconds: tc.DefaultServerLogTailSeconds}
+	if p.log != tc.ServerLogAccess && p.log != tc.ServerLogDiags {
+		return p, fmt.Errorf("%s must be '%s' or '%s'", tc.ServerLogTailLogQueryParam, tc.ServerLogAccess, tc.ServerLogDiags)
+	}
+	if str, ok := params[tc.ServerLogTailSecondsQueryParam]; ok {
+		seconds, err := strconv.Atoi(str)
+		if err != nil || seconds < 1 || seconds > maxSeconds {
+			return p, fmt.Errorf("%s must be an integer from 1 to %d", tc.ServerLogTailSecondsQueryParam, maxSeconds)
+		}
+		p.seconds = seconds
+	} else if p.seconds > maxSeconds {
+		p.seconds = maxSeconds
+	}
+	if str, ok := params[tc.ServerLogTailDeliveryServiceQueryParam]; ok {
+		dsID, err := strconv.Atoi(str)
+		if err != nil {
+			return p, fmt.Errorf("%s must be an integer", tc.ServerLogTailDeliveryServiceQueryParam)
+		}
+		p.dsID = &dsID
+	}
+	return p, nil
+}
+
+// logTailOriginAllowed returns whether a WebSocket connection may be opened
+// by a request with the given Origin header to the given host. Browsers send
+// cookies with WebSocket requests from any origin, so only those from
+// Traffic Ops itself and the configured origins are allowed. Requests
+// without an Origin, which don't come from browsers, are always allowed.
+func logTailOriginAllowed(origin string, host string, allowed []string) bool {
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, host) {
+		return true
+	}
+	for _, a := range allowed {
+		if strings.EqualFold(strings.TrimSuffix(a, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// logTailAgentURL returns the URL of the t3c-log-agent request which streams
+// the requested log of the cache server with the given FQDN.
+func logTailAgentURL(cfg *config.ConfigLogTail, fqdn string, p logTailParams, matches []string) string {
+	q := url.Values{}
+	q.Set(tc.ServerLogTailSecondsQueryParam, strconv.Itoa(p.seconds))
+	for _, m := range matches {
+		q.Add(tc.LogAgentMatchQueryParam, m)
+	}
+	return agentURL(cfg, fqdn, tc.LogAgentLogsPath+p.log, q)
+}
+
+// agentURL returns the URL of the t3c-log-agent endpoint at the given path on
+// the cache server with the given FQDN.
+func agentURL(cfg *config.ConfigLogTail, fqdn string, path string, q url.Values) string {
+	scheme := "http"
+	if cfg.AgentHTTPS {
+		scheme = "https"
+	}
+	port := cfg.AgentPort
+	if port == 0 {
+		port = tc.DefaultLogAgentPort
+	}
+	u := url.URL{
+		Scheme:   scheme,
+		Host:     net.JoinHostPort(fqdn, strconv.Itoa(port)),
+		Path:     path,
+		RawQuery: q.Encode(),
+	}
+	return u.String()
+}
+
+// newAgentClient returns a client for requests to t3c-log-agent, which must
+// connect and respond with headers within the given timeout.
+func newAgentClient(cfg *config.ConfigLogTail, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:           (&net.Dialer{Timeout: timeout}).DialContext,
+			TLSClientConfig:       &tls.Config{InsecureSkipVerify: cfg.AgentInsecure},
+			TLSHandshakeTimeout:   timeout,
+			ResponseHeaderTimeout: timeout,
+		},
+	}
+}
+
+// getAgentServer returns the server with the given ID, which must be a cache
+// server, since only cache servers run t3c-log-agent.
+func getAgentServer(tx *sql.Tx, id int) (tc.ServerInfo, error, error, int) {
+	server, ok, err := dbhelpers.GetServerInfo(id, tx)
+	if err != nil {
+		return server, nil, err, http.StatusInternalServerError
+	} else if !ok {
+		return server, fmt.Errorf("no server exists by ID '%d'", id), nil, http.StatusNotFound
+	}
+	if !strings.HasPrefix(server.Type, tc.EdgeTypePrefix) && !strings.HasPrefix(server.Type, tc.MidTypePrefix) {
+		return server, fmt.Errorf("server '%s' has type '%s', but only cache servers run t3c-log-agent", server.HostName, server.Type), nil, http.StatusBadRequest
+	}
+	return server, nil, nil, http.StatusOK
+}
+
+// getHostRegexes returns the HOST_REGEXP regular expressions of a Delivery
+// Service.
+func getHostRegexes(tx *sql.Tx, dsID int) ([]string, error) {
+	rows, err := tx.Query(selectHostRegexesQuery, dsID)
+	if err != nil {
+		return nil, errors.New("querying Delivery Service host regexes: " + err.Error())
+	}
+	defer log.Close(rows, "closing Delivery Service host regex rows")
+	patterns := []string{}
+	for rows.Next() {
+		pattern := ""
+		if err := rows.Scan(&pattern); err != nil {
+			return nil, errors.New("scanning Delivery Service host regexes: " + err.Error())
+		}
+		patterns = append(patterns, pattern)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating Delivery Service host regexes: " + err.Error())
+	}
+	return patterns, nil
+}
@@ -0,0 +1,143 @@
+package server
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+func TestParseLogTailParams(t *testing.T) {
+	p, err := parseLogTailParams(map[string]string{"log": "access"}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error parsing params: %v", err)
+	}
+	if p.log != tc.ServerLogAccess || p.seconds != tc.DefaultServerLogTailSeconds || p.dsID != nil {
+		t.Errorf("expected the access log with the default duration, actual: %+v", p)
+	}
+
+	p, err = parseLogTailParams(map[string]string{"log": "diags", "seconds": "120", "deliveryServiceId": "3"}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error parsing params: %v", err)
+	}
+	if p.seconds != 120 || p.dsID == nil || *p.dsID != 3 {
+		t.Errorf("expected 120 seconds of Delivery Service #3, actual: %+v", p)
+	}
+
+	if p, err = parseLogTailParams(map[string]string{"log": "access"}, 30); err != nil || p.seconds != 30 {
+		t.Errorf("expected the default duration to be limited to the maximum, actual: %+v %v", p, err)
+	}
+
+	invalid := []map[string]string{
+		{},
+		{"log": "/etc/passwd"},
+		{"log": "access", "seconds": "0"},
+		{"log": "access", "seconds": "301"},
+		{"log": "access", "deliveryServiceId": "demo1"},
+	}
+	for _, params := range invalid {
+		if _, err := parseLogTailParams(params, 0); err == nil {
+			t.Errorf("expected an error parsing params %v, got none", params)
+		}
+	}
+}
+
+func TestLogTailOriginAllowed(t *testing.T) {
+	allowed := []string{"https://tp.example.net/"}
+	cases := []struct {
+		origin   string
+		expected bool
+	}{
+		{"", true},
+		{"https://to.example.net", true},
+		{"https://tp.example.net", true},
+		{"https://evil.example.com", false},
+	}
+	for _, c := range cases {
+		if actual := logTailOriginAllowed(c.origin, "to.example.net", allowed); actual != c.expected {
+			t.Errorf("expected origin '%s' allowed: %v, actual: %v", c.origin, c.expected, actual)
+		}
+	}
+}
+
+func TestLogTailAgentURL(t *testing.T) {
+	p := logTailParams{log: tc.ServerLogAccess, seconds: 30}
+	actual := logTailAgentURL(&config.ConfigLogTail{}, "edge1.example.net", p, []string{`.*\.demo1\..*`})
+	expected := `http://edge1.example.net:8099/logs/access?match=.%2A%5C.demo1%5C..%2A&seconds=30`
+	if actual != expected {
+		t.Errorf("expected agent URL '%s', actual: '%s'", expected, actual)
+	}
+
+	actual = logTailAgentURL(&config.ConfigLogTail{AgentHTTPS: true, AgentPort: 8443}, "edge1.example.net", p, nil)
+	if !strings.HasPrefix(actual, "https://edge1.example.net:8443/logs/access?") {
+		t.Errorf("expected an HTTPS agent URL with the configured port, actual: '%s'", actual)
+	}
+}
+
+func TestRelayLogTail(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := rfc.UpgradeWebSocket(w, r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		relayLogTail(ctx, cancel, conn, strings.NewReader("line 1\nline 2\n"))
+	}))
+	defer srv.Close()
+
+	key, _ := rfc.NewWebSocketKey()
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set(rfc.SecWebSocketVersion, rfc.WebSocketVersion)
+	req.Header.Set(rfc.SecWebSocketKey, key)
+	resp, err := srv.Client().Do(req)
+	if err != nil || resp.StatusCode != http.StatusSwitchingProtocols {
+		t.Fatalf("expected the WebSocket connection to open, actual: %v %v", resp, err)
+	}
+	conn := rfc.NewWebSocketClientConn(resp.Body.(io.ReadWriteCloser))
+	defer conn.Close(rfc.WebSocketCloseNormal, "")
+
+	for _, expected := range []string{"line 1", "line 2"} {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("unexpected error reading: %v", err)
+		}
+		if string(msg) != expected {
+			t.Errorf("expected message '%s', actual: '%s'", expected, msg)
+		}
+	}
+	_, _, err = conn.ReadMessage()
+	closeErr := &rfc.WebSocketCloseError{}
+	if !errors.As(err, &closeErr) || closeErr.Code != rfc.WebSocketCloseNormal {
+		t.Errorf("expected the connection to be closed normally once the log ends, actual: %v", err)
+	}
+}
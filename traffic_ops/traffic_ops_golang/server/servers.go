@@ -22,17 +22,20 @@ package server
  */
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
 	"github.com/apache/trafficcontrol/lib/go-util"
@@ -40,6 +43,8 @@ import (
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/objectcache"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/policy"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/routing/middleware"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/topology/topology_validation"
@@ -172,6 +177,51 @@ WHERE t.name = :cache_type_mid AND s.cachegroup IN (
 		WHERE d.id = :ds_id) IS NULL
 `
 
+// The query parameters of GET requests to /servers which aren't simple
+// filters on server properties. Both are only supported in API version 5 and
+// later.
+const (
+	// AfterIDQueryParam selects only servers with IDs greater than its value
+	// (or less than, if sortOrder is "desc"), for keyset pagination.
+	AfterIDQueryParam = "afterId"
+	// OmitInterfacesQueryParam, if true, causes servers to be returned
+	// without their network interfaces.
+	OmitInterfacesQueryParam = "omitInterfaces"
+)
+
+// interfacesQuery selects the interfaces of the given servers, with their IP
+// addresses, aggregated into a single JSON array per server that can be
+// decoded directly into a []tc.ServerInterfaceInfoV40.
+const interfacesQuery = `
+SELECT
+	i.server,
+	JSON_AGG(JSON_BUILD_OBJECT(
+		'ipAddresses', COALESCE(ip.addresses, '[]'::json),
+		'maxBandwidth', i.max_bandwidth,
+		'monitor', i.monitor,
+		'mtu', i.mtu,
+		'name', i.name,
+		'routerHostName', i.router_host_name,
+		'routerPortName', i.router_port_name
+	) ORDER BY i.name) AS interfaces
+FROM interface AS i
+LEFT JOIN (
+	SELECT
+		server,
+		interface,
+		JSON_AGG(JSON_BUILD_OBJECT(
+			'address', address,
+			'gateway', gateway,
+			'serviceAddress', service_address
+		) ORDER BY address) AS addresses
+	FROM ip_address
+	WHERE server = ANY($1)
+	GROUP BY server, interface
+) AS ip ON ip.server = i.server AND ip.interface = i.name
+WHERE i.server = ANY($1)
+GROUP BY i.server
+`
+
 const insertQueryV3 = `
 INSERT INTO server (
 	cachegroup,
@@ -460,10 +510,22 @@ func validateMTU(mtu interface{}) error {
 	return nil
 }
 
+// normalizeAddresses replaces the given IP addresses and gateways of an
+// interface with their canonical forms, so that the same address is always
+// stored the same way.
+func normalizeAddresses(addrs []tc.ServerIPAddress) {
+	for i := range addrs {
+		addrs[i].Normalize()
+	}
+}
+
 func validateV4(s *tc.ServerV40, tx *sql.Tx) (string, error, error) {
 	if len(s.Interfaces) == 0 {
 		return "", errors.New("a server must have at least one interface"), nil
 	}
+	for i := range s.Interfaces {
+		normalizeAddresses(s.Interfaces[i].IPAddresses)
+	}
 	var errs []error
 	var serviceAddrV4Found bool
 	var ipv4 string
@@ -556,7 +618,7 @@ WHERE (profiles = $1::text[])
 			err = rows.Scan(&id, &ipaddress)
 			if err != nil {
 				errs = append(errs, errors.New("unable to determine service address uniqueness"))
-			} else if (ipaddress == ipv4 || ipaddress == ipv6) && (s.ID == nil || *s.ID != id) {
+			} else if (tc.SameIP(ipaddress, ipv4) || tc.SameIP(ipaddress, ipv6)) && (s.ID == nil || *s.ID != id) {
 				errs = append(errs, fmt.Errorf("there exists a server with id %v on the same profile that has the same service address %s", id, ipaddress))
 			}
 		}
@@ -570,6 +632,9 @@ func validateV3(s *tc.ServerV30, tx *sql.Tx) (string, error, error) {
 	if len(s.Interfaces) == 0 {
 		return "", errors.New("a server must have at least one interface"), nil
 	}
+	for i := range s.Interfaces {
+		normalizeAddresses(s.Interfaces[i].IPAddresses)
+	}
 	var errs []error
 	var serviceAddrV4Found bool
 	var ipv4 string
@@ -662,7 +727,7 @@ and p.id = $1
 			err = rows.Scan(&id, &ipaddress)
 			if err != nil {
 				return serviceInterface, util.JoinErrs(errs), fmt.Errorf("unable to determine service address uniqueness: scanning: %w", err)
-			} else if (ipaddress == ipv4 || ipaddress == ipv6) && (s.ID == nil || *s.ID != id) {
+			} else if (tc.SameIP(ipaddress, ipv4) || tc.SameIP(ipaddress, ipv6)) && (s.ID == nil || *s.ID != id) {
 				errs = append(errs, fmt.Errorf("there exists a server with id %v on the same profile that has the same service address %s", id, ipaddress))
 			}
 		}
@@ -699,7 +764,8 @@ func Read(w http.ResponseWriter, r *http.Request) {
 		log.Warnf("Couldn't get config %v", e)
 	}
 
-	servers, serverCount, userErr, sysErr, errCode, maxTime = getServers(r.Header, inf.Params, inf.Tx, inf.User, useIMS, *version)
+	var pagination *tc.Pagination
+	servers, serverCount, pagination, userErr, sysErr, errCode, maxTime = getCachedServers(r.Context(), r.Header, inf.Params, inf.Tx, inf.User, useIMS, *version)
 	if maxTime != nil && api.SetLastModifiedHeader(r, useIMS) {
 		api.AddLastModifiedHdr(w, *maxTime)
 	}
@@ -712,16 +778,35 @@ func Read(w http.ResponseWriter, r *http.Request) {
 		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
 		return
 	}
+
+	// Servers don't belong to Tenants, so the fields redacted by the
+	// user's read view are removed from all of them.
+	var redactedFields []string
+	if view, err := tenant.GetUserReadView(tx, inf.User.TenantID); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if view != nil {
+		redactedFields = view.RedactedServerFields
+	}
+	writeServers := func(servers interface{}) {
+		redacted, err := policy.Redact(servers, redactedFields)
+		if err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("redacting servers: %w", err))
+			return
+		}
+		api.WriteRespWithSummaryPaginated(w, r, redacted, serverCount, pagination)
+	}
+
 	if version.Major >= 4 {
 		if version.Minor >= 1 {
-			api.WriteRespWithSummary(w, r, servers, serverCount)
+			writeServers(servers)
 			return
 		}
 		v40Servers := make([]tc.ServerV40, 0)
 		for _, server := range servers {
 			v40Servers = append(v40Servers, server.ServerV40)
 		}
-		api.WriteRespWithSummary(w, r, v40Servers, serverCount)
+		writeServers(v40Servers)
 		return
 	}
 	v3Servers := make([]tc.ServerV30, 0)
@@ -738,8 +823,79 @@ func Read(w http.ResponseWriter, r *http.Request) {
 		}
 		v3Servers = append(v3Servers, v3Server)
 	}
-	api.WriteRespWithSummary(w, r, v3Servers, serverCount)
-	return
+	writeServers(v3Servers)
+}
+
+// GetForUser returns the servers with the given query parameters, as they're
+// served to the given user by the latest version of GET /servers - including
+// the removal of the fields redacted by the user's read view - for the
+// representations of servers other than that of the API.
+func GetForUser(ctx context.Context, params map[string]string, tx *sqlx.Tx, user *auth.CurrentUser) ([]tc.ServerV41, error, error, int) {
+	servers, _, _, userErr, sysErr, errCode, _ := getCachedServers(ctx, http.Header{}, params, tx, user, false, api.Version{Major: 5, Minor: 0})
+	if userErr != nil || sysErr != nil {
+		return nil, userErr, sysErr, errCode
+	}
+	view, err := tenant.GetUserReadView(tx.Tx, user.TenantID)
+	if err != nil {
+		return nil, nil, err, http.StatusInternalServerError
+	}
+	if view != nil {
+		if err := policy.RedactInPlace(&servers, view.RedactedServerFields); err != nil {
+			return nil, nil, fmt.Errorf("redacting servers: %w", err), http.StatusInternalServerError
+		}
+	}
+	return servers, nil, nil, http.StatusOK
+}
+
+// serversCacheTypes are the types of objects that lists of servers depend
+// on, for the purposes of the object cache.
+var serversCacheTypes = []string{"asn", "cachegroup", "cdn", "phys_location", "profile", "server", "status", "topology", "type"}
+
+// cachedServers is a list of servers, as it's kept in the object cache.
+type cachedServers struct {
+	Servers    []tc.ServerV41 `json:"servers"`
+	Count      uint64         `json:"count"`
+	Pagination *tc.Pagination `json:"pagination"`
+	MaxTime    *time.Time     `json:"maxTime"`
+}
+
+// errServersNotLoaded is returned to the object cache when servers couldn't
+// be loaded, so that nothing is cached; the actual errors are returned
+// separately.
+var errServersNotLoaded = errors.New("servers not loaded")
+
+// getCachedServers is like getServers, but gets the servers from the object
+// cache if they're there. Requests for the servers of a Delivery Service and
+// conditional requests aren't cached, because the former depend on the user's
+// Tenancy and the latter on the time of the request.
+func getCachedServers(ctx context.Context, h http.Header, params map[string]string, tx *sqlx.Tx, user *auth.CurrentUser, useIMS bool, version api.Version) ([]tc.ServerV41, uint64, *tc.Pagination, error, error, int, *time.Time) {
+	_, forDS := params["dsId"]
+	conditional := useIMS && h.Get(rfc.IfModifiedSince) != ""
+	if forDS || conditional || !objectcache.Enabled() {
+		return getServers(h, params, tx, user, useIMS, version)
+	}
+
+	query := url.Values{}
+	for k, v := range params {
+		query.Set(k, v)
+	}
+	// Users below the Operations Permission Level see redacted passwords.
+	key := fmt.Sprintf("servers/%d.%d/%t?%s", version.Major, version.Minor, user.PrivLevel >= auth.PrivLevelOperations, query.Encode())
+
+	var userErr, sysErr error
+	errCode := http.StatusOK
+	cached := cachedServers{}
+	err := objectcache.Load(ctx, key, serversCacheTypes, &cached, func() error {
+		cached.Servers, cached.Count, cached.Pagination, userErr, sysErr, errCode, cached.MaxTime = getServers(h, params, tx, user, useIMS, version)
+		if userErr != nil || sysErr != nil || errCode != http.StatusOK {
+			return errServersNotLoaded
+		}
+		return nil
+	})
+	if err != nil && err != errServersNotLoaded {
+		return nil, 0, nil, nil, err, http.StatusInternalServerError, nil
+	}
+	return cached.Servers, cached.Count, cached.Pagination, userErr, sysErr, errCode, cached.MaxTime
 }
 
 func selectMaxLastUpdatedQuery(queryAddition string, where string) string {
@@ -768,7 +924,7 @@ func getServerCount(tx *sqlx.Tx, query string, queryValues map[string]interface{
 	return serverCount, nil
 }
 
-func getServers(h http.Header, params map[string]string, tx *sqlx.Tx, user *auth.CurrentUser, useIMS bool, version api.Version) ([]tc.ServerV41, uint64, error, error, int, *time.Time) {
+func getServers(h http.Header, params map[string]string, tx *sqlx.Tx, user *auth.CurrentUser, useIMS bool, version api.Version) ([]tc.ServerV41, uint64, *tc.Pagination, error, error, int, *time.Time) {
 	var maxTime time.Time
 	var runSecond bool
 	// Query Parameters to Database Query column mappings
@@ -819,22 +975,22 @@ func getServers(h http.Header, params map[string]string, tx *sqlx.Tx, user *auth
 		// don't allow query on ds outside user's tenant
 		dsID, err = strconv.Atoi(dsIDStr)
 		if err != nil {
-			return nil, 0, errors.New("dsId must be an integer"), nil, http.StatusNotFound, nil
+			return nil, 0, nil, errors.New("dsId must be an integer"), nil, http.StatusNotFound, nil
 		}
 		cdnID, _, err = dbhelpers.GetDSCDNIdFromID(tx.Tx, dsID)
 		if err != nil {
-			return nil, 0, nil, err, http.StatusInternalServerError, nil
+			return nil, 0, nil, nil, err, http.StatusInternalServerError, nil
 		}
 
 		userErr, sysErr, _ := tenant.CheckID(tx.Tx, user, dsID)
 		if userErr != nil || sysErr != nil {
-			return nil, 0, errors.New("Forbidden"), sysErr, http.StatusForbidden, nil
+			return nil, 0, nil, errors.New("Forbidden"), sysErr, http.StatusForbidden, nil
 		}
 
 		var joinSubQuery string
 		if err = tx.QueryRow(deliveryservice.HasRequiredCapabilitiesQuery, dsID).Scan(&dsHasRequiredCapabilities); err != nil {
 			err = fmt.Errorf("unable to get required capabilities for deliveryservice %d: %s", dsID, err)
-			return nil, 0, nil, err, http.StatusInternalServerError, nil
+			return nil, 0, nil, nil, err, http.StatusInternalServerError, nil
 		}
 		joinSubQuery = dssTopologiesJoinSubquery
 		// only if dsId is part of params: add join on deliveryservice_server table
@@ -843,7 +999,7 @@ func getServers(h http.Header, params map[string]string, tx *sqlx.Tx, user *auth
 		// depending on ds type, also need to add mids
 		dsType, _, _, err := dbhelpers.GetDeliveryServiceTypeAndCDNName(dsID, tx.Tx)
 		if err != nil {
-			return nil, 0, nil, err, http.StatusInternalServerError, nil
+			return nil, 0, nil, nil, err, http.StatusInternalServerError, nil
 		}
 		usesMids = dsType.UsesMidCache()
 		log.Debugf("Servers for ds %d; uses mids? %v\n", dsID, usesMids)
@@ -861,7 +1017,34 @@ func getServers(h http.Header, params map[string]string, tx *sqlx.Tx, user *auth
 		where += requiredCapabilitiesCondition
 	}
 	if len(errs) > 0 {
-		return nil, 0, util.JoinErrs(errs), nil, http.StatusBadRequest, nil
+		return nil, 0, nil, util.JoinErrs(errs), nil, http.StatusBadRequest, nil
+	}
+
+	omitInterfaces := false
+	pageWhere := where
+	var page *dbhelpers.CursorPage
+	var asOf *time.Time
+	if version.Major >= 5 {
+		if asOf, err = dbhelpers.ParseAsOf(params); err != nil {
+			return nil, 0, nil, err, nil, http.StatusBadRequest, nil
+		}
+		if omitStr, ok := params[OmitInterfacesQueryParam]; ok {
+			if omitInterfaces, err = strconv.ParseBool(omitStr); err != nil {
+				return nil, 0, nil, fmt.Errorf("%s must be a boolean", OmitInterfacesQueryParam), nil, http.StatusBadRequest, nil
+			}
+		}
+		if afterIDStr, ok := params[AfterIDQueryParam]; ok {
+			var userErr error
+			pageWhere, orderBy, userErr = keysetPagination(params, afterIDStr, where, queryValues)
+			if userErr != nil {
+				return nil, 0, nil, userErr, nil, http.StatusBadRequest, nil
+			}
+		}
+		var userErr error
+		pageWhere, orderBy, pagination, page, userErr = cursorPagination(params, queryParamsToSQLCols, pageWhere, orderBy, pagination, queryValues)
+		if userErr != nil {
+			return nil, 0, nil, userErr, nil, http.StatusBadRequest, nil
+		}
 	}
 
 	var queryString, countQueryString string
@@ -886,6 +1069,15 @@ JOIN server_profile sp ON s.id = sp.server`
 			countQueryString = countQueryString + ` ` + joinProfileV4
 		}
 	}
+	if asOf != nil {
+		// the history of a server doesn't change, so there's nothing for
+		// If-Modified-Since to check
+		useIMS = false
+		queryValues[dbhelpers.AsOfQueryParam] = *asOf
+		serversAsOf := "FROM " + dbhelpers.TableAsOf("server") + " AS s\n"
+		queryString = strings.Replace(queryString, "FROM server AS s\n", serversAsOf, 1)
+		countQueryString = strings.Replace(countQueryString, "FROM server AS s\n", serversAsOf, 1)
+	}
 	countQuery := countQueryString + queryAddition + where
 	// If we are querying for a DS that has reqd capabilities, we need to make sure that we also include all the ORG servers directly assigned to this DS
 	if _, ok := params["dsId"]; ok && dsHasRequiredCapabilities {
@@ -893,7 +1085,7 @@ JOIN server_profile sp ON s.id = sp.server`
 	}
 	serverCount, err = getServerCount(tx, countQuery, queryValues)
 	if err != nil {
-		return nil, 0, nil, fmt.Errorf("failed to get servers count: %v", err), http.StatusInternalServerError, nil
+		return nil, 0, nil, nil, fmt.Errorf("failed to get servers count: %v", err), http.StatusInternalServerError, nil
 	}
 
 	serversList := []tc.ServerV41{}
@@ -901,14 +1093,14 @@ JOIN server_profile sp ON s.id = sp.server`
 		runSecond, maxTime = ims.TryIfModifiedSinceQuery(tx, h, queryValues, selectMaxLastUpdatedQuery(queryAddition, where))
 		if !runSecond {
 			log.Debugln("IMS HIT")
-			return serversList, 0, nil, nil, http.StatusNotModified, &maxTime
+			return serversList, 0, nil, nil, nil, http.StatusNotModified, &maxTime
 		}
 		log.Debugln("IMS MISS")
 	} else {
 		log.Debugln("Non IMS request")
 	}
 
-	query := queryString + queryAddition + where + orderBy + pagination
+	query := queryString + queryAddition + pageWhere + orderBy + pagination
 	// If you're looking to get the servers for a particular delivery service, make sure you're also querying the ORG servers from the deliveryservice_server table
 	if _, ok := params[`dsId`]; ok {
 		query = `(` + queryString + queryAddition + where + orderBy + pagination + `) UNION ` + queryString + originServerQuery
@@ -917,7 +1109,7 @@ JOIN server_profile sp ON s.id = sp.server`
 	log.Debugln("Query is ", query)
 	rows, err := tx.NamedQuery(query, queryValues)
 	if err != nil {
-		return nil, serverCount, nil, errors.New("querying: " + err.Error()), http.StatusInternalServerError, nil
+		return nil, serverCount, nil, nil, errors.New("querying: " + err.Error()), http.StatusInternalServerError, nil
 	}
 	defer rows.Close()
 
@@ -966,7 +1158,7 @@ JOIN server_profile sp ON s.id = sp.server`
 			&s.StatusLastUpdated,
 			pq.Array(&s.ASNs))
 		if err != nil {
-			return nil, serverCount, nil, errors.New("getting servers: " + err.Error()), http.StatusInternalServerError, nil
+			return nil, serverCount, nil, nil, errors.New("getting servers: " + err.Error()), http.StatusInternalServerError, nil
 		}
 		if user.PrivLevel < auth.PrivLevelOperations {
 			s.ILOPassword = &HiddenField
@@ -974,10 +1166,10 @@ JOIN server_profile sp ON s.id = sp.server`
 		}
 
 		if s.ID == nil {
-			return nil, serverCount, nil, errors.New("found server with nil ID"), http.StatusInternalServerError, nil
+			return nil, serverCount, nil, nil, errors.New("found server with nil ID"), http.StatusInternalServerError, nil
 		}
 		if _, ok := servers[*s.ID]; ok {
-			return nil, serverCount, nil, fmt.Errorf("found more than one server with ID #%d", *s.ID), http.StatusInternalServerError, nil
+			return nil, serverCount, nil, nil, fmt.Errorf("found more than one server with ID #%d", *s.ID), http.StatusInternalServerError, nil
 		}
 		servers[*s.ID] = s
 		ids = append(ids, *s.ID)
@@ -991,94 +1183,126 @@ JOIN server_profile sp ON s.id = sp.server`
 
 		serverCount = serverCount + uint64(len(midIDs))
 		if userErr != nil || sysErr != nil {
-			return nil, serverCount, userErr, sysErr, errCode, nil
+			return nil, serverCount, nil, userErr, sysErr, errCode, nil
 		}
 		ids = append(ids, midIDs...)
 	}
 
 	if len(ids) < 1 {
-		return []tc.ServerV41{}, serverCount, nil, nil, http.StatusOK, nil
+		return []tc.ServerV41{}, serverCount, dbhelpers.CursorPagination(page, []tc.ServerV41{}), nil, nil, http.StatusOK, nil
 	}
 
-	query, args, err := sqlx.In(`SELECT max_bandwidth, monitor, mtu, name, server, router_host_name, router_port_name FROM interface WHERE server IN (?)`, ids)
-	if err != nil {
-		return nil, serverCount, nil, fmt.Errorf("building interfaces query: %v", err), http.StatusInternalServerError, nil
+	if omitInterfaces {
+		returnable := make([]tc.ServerV41, 0, len(ids))
+		for _, id := range ids {
+			returnable = append(returnable, servers[id])
+		}
+		return returnable, serverCount, dbhelpers.CursorPagination(page, returnable), nil, nil, http.StatusOK, &maxTime
 	}
-	query = tx.Rebind(query)
-	interfaces := map[int]map[string]tc.ServerInterfaceInfoV40{}
-	interfaceRows, err := tx.Queryx(query, args...)
+
+	interfaces, err := getInterfaces(tx.Tx, ids)
 	if err != nil {
-		return nil, serverCount, nil, fmt.Errorf("querying for interfaces: %v", err), http.StatusInternalServerError, nil
+		return nil, serverCount, nil, nil, err, http.StatusInternalServerError, nil
 	}
-	defer interfaceRows.Close()
 
-	for interfaceRows.Next() {
-		iface := tc.ServerInterfaceInfoV40{
-			ServerInterfaceInfo: tc.ServerInterfaceInfo{
-				IPAddresses: []tc.ServerIPAddress{},
-			},
-		}
-		var server int
-		var routerHostName string
-		var routerPort string
-		if err = interfaceRows.Scan(&iface.MaxBandwidth, &iface.Monitor, &iface.MTU, &iface.Name, &server, &routerHostName, &routerPort); err != nil {
-			return nil, serverCount, nil, fmt.Errorf("getting server interfaces: %v", err), http.StatusInternalServerError, nil
-		}
+	returnable := make([]tc.ServerV41, 0, len(ids))
+	for _, id := range ids {
+		server := servers[id]
+		server.Interfaces = interfaces[id]
+		returnable = append(returnable, server)
+	}
 
-		if _, ok := servers[server]; !ok {
-			continue
-		}
+	return returnable, serverCount, dbhelpers.CursorPagination(page, returnable), nil, nil, http.StatusOK, &maxTime
+}
 
-		if _, ok := interfaces[server]; !ok {
-			interfaces[server] = map[string]tc.ServerInterfaceInfoV40{}
+// cursorOrderBys are the query parameters by which servers can be sorted when
+// they're paginated with cursors: those whose values are the same as those of
+// the fields with the same names in the servers' representations.
+var cursorOrderBys = map[string]bool{"id": true, "hostName": true, "status": true, "type": true}
+
+// cursorPagination applies cursor pagination to the clauses of a read of
+// servers; see dbhelpers.BuildCursorPagination. Reads of the servers of a
+// Delivery Service - which include its origins, from a separate query -, of
+// keyset pages, or sorted by a query parameter not in cursorOrderBys can't be
+// paginated with cursors, so their clauses are returned unchanged, or an
+// error if a cursor was given.
+func cursorPagination(params map[string]string, queryParamsToSQLCols map[string]dbhelpers.WhereColumnInfo, where, orderBy, pagination string, queryValues map[string]interface{}) (string, string, string, *dbhelpers.CursorPage, error) {
+	_, forDS := params["dsId"]
+	_, afterID := params[AfterIDQueryParam]
+	orderby, sorted := params["orderby"]
+	if forDS || afterID || (sorted && !cursorOrderBys[orderby]) {
+		if _, ok := params[tc.CursorQueryParam]; ok {
+			return "", "", "", nil, fmt.Errorf("%s cannot be used with dsId or %s, or when sorting by anything but id, hostName, status, or type", tc.CursorQueryParam, AfterIDQueryParam)
+		}
+		return where, orderBy, pagination, nil, nil
+	}
+	return dbhelpers.BuildCursorPagination(params, queryParamsToSQLCols, where, orderBy, pagination, queryValues)
+}
+
+// keysetPagination returns the WHERE and ORDER BY clauses which select the
+// page of servers following the server with the given ID, adding the ID to
+// queryValues. The where clause given should be that built from the request's
+// other query parameters.
+//
+// Keyset pagination requires results be ordered by ID and a limit be given,
+// and can't be combined with offset pagination.
+func keysetPagination(params map[string]string, afterIDStr string, where string, queryValues map[string]interface{}) (string, string, error) {
+	afterID, err := strconv.Atoi(afterIDStr)
+	if err != nil {
+		return "", "", fmt.Errorf("%s must be an integer", AfterIDQueryParam)
+	}
+	if _, ok := params["limit"]; !ok {
+		return "", "", fmt.Errorf("%s requires limit", AfterIDQueryParam)
+	}
+	for _, p := range []string{"offset", "page", "dsId"} {
+		if _, ok := params[p]; ok {
+			return "", "", fmt.Errorf("%s cannot be used with %s", AfterIDQueryParam, p)
 		}
-		iface.RouterHostName = routerHostName
-		iface.RouterPortName = routerPort
-		interfaces[server][iface.Name] = iface
+	}
+	if orderby, ok := params["orderby"]; ok && orderby != "id" {
+		return "", "", fmt.Errorf("%s can only be used when ordering by id", AfterIDQueryParam)
 	}
 
-	query, args, err = sqlx.In(`SELECT address, gateway, service_address, server, interface FROM ip_address WHERE server IN (?)`, ids)
-	if err != nil {
-		return nil, serverCount, nil, fmt.Errorf("building IP addresses query: %v", err), http.StatusInternalServerError, nil
+	op := ">"
+	orderBy := dbhelpers.BaseOrderBy + " s.id"
+	if params["sortOrder"] == "desc" {
+		op = "<"
+		orderBy += " DESC"
 	}
-	query = tx.Rebind(query)
-	ipRows, err := tx.Tx.Query(query, args...)
+	queryValues["after_id"] = afterID
+	condition := " s.id " + op + " :after_id"
+	if where == "" {
+		return dbhelpers.BaseWhere + condition, orderBy, nil
+	}
+	return where + " AND" + condition, orderBy, nil
+}
+
+// getInterfaces returns the interfaces of each of the servers with the given
+// IDs, with their IP addresses, ordered by name.
+func getInterfaces(tx *sql.Tx, ids []int) (map[int][]tc.ServerInterfaceInfoV40, error) {
+	rows, err := tx.Query(interfacesQuery, pq.Array(ids))
 	if err != nil {
-		return nil, serverCount, nil, fmt.Errorf("querying for IP addresses: %v", err), http.StatusInternalServerError, nil
+		return nil, fmt.Errorf("querying for interfaces: %w", err)
 	}
-	defer ipRows.Close()
+	defer log.Close(rows, "closing server interface rows")
 
-	for ipRows.Next() {
-		var ip tc.ServerIPAddress
+	interfaces := make(map[int][]tc.ServerInterfaceInfoV40, len(ids))
+	for rows.Next() {
 		var server int
-		var iface string
-
-		if err = ipRows.Scan(&ip.Address, &ip.Gateway, &ip.ServiceAddress, &server, &iface); err != nil {
-			return nil, serverCount, nil, fmt.Errorf("getting server IP addresses: %v", err), http.StatusInternalServerError, nil
+		var ifacesJSON []byte
+		if err := rows.Scan(&server, &ifacesJSON); err != nil {
+			return nil, fmt.Errorf("scanning server interfaces: %w", err)
 		}
-
-		if _, ok := interfaces[server]; !ok {
-			continue
-		}
-		if i, ok := interfaces[server][iface]; !ok {
-			log.Warnf("IP addresses query returned addresses for an interface that was not found in interfaces query: %s", iface)
-		} else {
-			i.IPAddresses = append(i.IPAddresses, ip)
-			interfaces[server][iface] = i
+		ifaces := []tc.ServerInterfaceInfoV40{}
+		if err := json.Unmarshal(ifacesJSON, &ifaces); err != nil {
+			return nil, fmt.Errorf("decoding interfaces of server #%d: %w", server, err)
 		}
+		interfaces[server] = ifaces
 	}
-
-	returnable := make([]tc.ServerV41, 0, len(ids))
-
-	for _, id := range ids {
-		server := servers[id]
-		for _, iface := range interfaces[id] {
-			server.Interfaces = append(server.Interfaces, iface)
-		}
-		returnable = append(returnable, server)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over server interface rows: %w", err)
 	}
-
-	return returnable, serverCount, nil, nil, http.StatusOK, &maxTime
+	return interfaces, nil
 }
 
 // getMidServers gets the mids used by the edges provided with an option to filter for a given cdn
@@ -1335,7 +1559,7 @@ func Update(w http.ResponseWriter, r *http.Request) {
 	id := inf.IntParams["id"]
 
 	// Get original server
-	originals, _, userErr, sysErr, errCode, _ := getServers(r.Header, inf.Params, inf.Tx, inf.User, false, *version)
+	originals, _, _, userErr, sysErr, errCode, _ := getServers(r.Header, inf.Params, inf.Tx, inf.User, false, *version)
 	if userErr != nil || sysErr != nil {
 		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
 		return
@@ -1511,6 +1735,11 @@ func Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if userErr, sysErr, errCode = checkFieldPolicy(tx, inf.User, original, server); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
 	if server.XMPPID != nil && *server.XMPPID != "" && originalXMPPID != "" && *server.XMPPID != originalXMPPID {
 		api.WriteAlerts(w, r, http.StatusBadRequest, tc.CreateAlerts(tc.ErrorLevel, fmt.Sprintf("server cannot be updated due to requested XMPPID change. XMPIDD is immutable")))
 		return
@@ -1766,6 +1995,14 @@ func createV3(inf *api.APIInfo, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if protected, err := policy.ProtectedFields(inf.Tx.Tx, inf.User, tc.FieldPolicyObjectTypeServer); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if len(protected) > 0 {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusForbidden, errors.New("the user's Role may not modify some server fields; use API version 4.0 or later to create servers"), nil)
+		return
+	}
+
 	currentTime := time.Now()
 	server.StatusLastUpdated = &currentTime
 
@@ -1880,6 +2117,27 @@ func createV3(inf *api.APIInfo, w http.ResponseWriter, r *http.Request) {
 	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, inf.Tx.Tx)
 }
 
+// checkFieldPolicy returns a Forbidden error if the requested server changes
+// fields that the user's Role may not modify from those of the original - or,
+// when creating a server, from the zero value.
+func checkFieldPolicy(tx *sql.Tx, user *auth.CurrentUser, original, requested tc.ServerV40) (error, error, int) {
+	protected, err := policy.ProtectedFields(tx, user, tc.FieldPolicyObjectTypeServer)
+	if err != nil {
+		return nil, err, http.StatusInternalServerError
+	}
+	if len(protected) == 0 {
+		return nil, nil, http.StatusOK
+	}
+	changed, err := policy.ChangedFields(protected, original, requested)
+	if err != nil {
+		return nil, err, http.StatusInternalServerError
+	}
+	if len(changed) > 0 {
+		return policy.ProtectedFieldsError(tc.FieldPolicyObjectTypeServer, changed), nil, http.StatusForbidden
+	}
+	return nil, nil, http.StatusOK
+}
+
 func createV4(inf *api.APIInfo, w http.ResponseWriter, r *http.Request) {
 	var server tc.ServerV40
 
@@ -1913,6 +2171,11 @@ func createV4(inf *api.APIInfo, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if userErr, sysErr, errCode := checkFieldPolicy(inf.Tx.Tx, inf.User, tc.ServerV40{}, server); userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+
 	currentTime := time.Now()
 	server.StatusLastUpdated = &currentTime
 
@@ -2213,7 +2476,7 @@ func Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var servers []tc.ServerV41
-	servers, _, userErr, sysErr, errCode, _ = getServers(r.Header, map[string]string{"id": inf.Params["id"]}, inf.Tx, inf.User, false, *version)
+	servers, _, _, userErr, sysErr, errCode, _ = getServers(r.Header, map[string]string{"id": inf.Params["id"]}, inf.Tx, inf.User, false, *version)
 	if userErr != nil || sysErr != nil {
 		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
 		return
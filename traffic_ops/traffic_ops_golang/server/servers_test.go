@@ -20,6 +20,7 @@ package server
  */
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
@@ -31,6 +32,7 @@ import (
 
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
@@ -124,6 +126,41 @@ func getTestServers() []ServerAndInterfaces {
 }
 
 // Test to make sure that updating the "cdn" of a server already assigned to a DS fails
+// mockInterfaceRows returns the rows of the interfaces query for the given
+// servers, each of which has the single interface it's paired with.
+func mockInterfaceRows(t testing.TB, servers []ServerAndInterfaces) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"server", "interfaces"})
+	for _, srv := range servers {
+		bts, err := json.Marshal([]tc.ServerInterfaceInfoV40{srv.Interface})
+		if err != nil {
+			t.Fatalf("encoding interfaces of server #%d: %v", *srv.Server.ID, err)
+		}
+		rows = rows.AddRow(*srv.Server.ID, bts)
+	}
+	return rows
+}
+
+// mockServerRows returns the rows of the servers query for the given servers.
+func mockServerRows(servers []ServerAndInterfaces) *sqlmock.Rows {
+	cols := []string{"cachegroup", "cachegroup_id", "cdn_id", "cdn_name", "domain_name", "guid", "host_name",
+		"https_port", "id", "ilo_ip_address", "ilo_ip_gateway", "ilo_ip_netmask", "ilo_password", "ilo_username",
+		"last_updated", "mgmt_ip_address", "mgmt_ip_gateway", "mgmt_ip_netmask", "offline_reason", "phys_location",
+		"phys_location_id", "profile_name", "rack", "reval_pending", "revalidate_update_time", "revalidate_apply_time",
+		"status", "status_id", "tcp_port", "server_type", "server_type_id", "upd_pending", "config_update_time",
+		"config_apply_time", "xmpp_id", "xmpp_passwd", "status_last_updated", "asns"}
+	rows := sqlmock.NewRows(cols)
+	for _, srv := range servers {
+		ts := srv.Server
+		rows = rows.AddRow(*ts.Cachegroup, *ts.CachegroupID, *ts.CDNID, *ts.CDNName, *ts.DomainName, *ts.GUID, *ts.HostName,
+			*ts.HTTPSPort, *ts.ID, *ts.ILOIPAddress, *ts.ILOIPGateway, *ts.ILOIPNetmask, *ts.ILOPassword, *ts.ILOUsername,
+			*ts.LastUpdated, *ts.MgmtIPAddress, *ts.MgmtIPGateway, *ts.MgmtIPNetmask, *ts.OfflineReason, *ts.PhysLocation,
+			*ts.PhysLocationID, fmt.Sprintf("{%s}", strings.Join(ts.ProfileNames, ",")), *ts.Rack, *ts.RevalPending, *ts.RevalUpdateTime, *ts.RevalApplyTime,
+			*ts.Status, *ts.StatusID, *ts.TCPPort, ts.Type, *ts.TypeID, *ts.UpdPending, *ts.ConfigUpdateTime,
+			*ts.ConfigApplyTime, *ts.XMPPID, *ts.XMPPPasswd, *ts.StatusLastUpdated, []byte(`{1,2}`))
+	}
+	return rows
+}
+
 func TestUpdateServer(t *testing.T) {
 	mockDB, mock, err := sqlmock.New()
 	if err != nil {
@@ -184,12 +221,8 @@ func TestGetServersByCachegroup(t *testing.T) {
 		"phys_location_id", "profile_name", "rack", "reval_pending", "revalidate_update_time", "revalidate_apply_time",
 		"status", "status_id", "tcp_port", "server_type", "server_type_id", "upd_pending", "config_update_time",
 		"config_apply_time", "xmpp_id", "xmpp_passwd", "status_last_updated", "asns"}
-	interfaceCols := []string{"max_bandwidth", "monitor", "mtu", "name", "server", "router_host_name", "router_port_name"}
 	rows := sqlmock.NewRows(cols)
-	interfaceRows := sqlmock.NewRows(interfaceCols)
-
-	ipCols := []string{"address", "gateway", "service_address", "server", "interface"}
-	ipRows := sqlmock.NewRows(ipCols)
+	interfaceRows := mockInterfaceRows(t, testServers)
 
 	//TODO: drichardson - build helper to add these Rows from the struct values
 	//                    or by CSV if types get in the way
@@ -235,25 +268,6 @@ func TestGetServersByCachegroup(t *testing.T) {
 			*ts.StatusLastUpdated,
 			[]byte(`{1,2}`),
 		)
-		interfaceRows = interfaceRows.AddRow(
-			srv.Interface.MaxBandwidth,
-			srv.Interface.Monitor,
-			srv.Interface.MTU,
-			srv.Interface.Name,
-			*ts.ID,
-			srv.Interface.RouterHostName,
-			srv.Interface.RouterPortName,
-		)
-
-		for _, ip := range srv.Interface.IPAddresses {
-			ipRows = ipRows.AddRow(
-				ip.Address,
-				ip.Gateway,
-				ip.ServiceAddress,
-				*ts.ID,
-				srv.Interface.Name,
-			)
-		}
 	}
 
 	mock.ExpectBegin()
@@ -262,7 +276,6 @@ func TestGetServersByCachegroup(t *testing.T) {
 	mock.ExpectQuery("SELECT COUNT\\(s.id\\) FROM s").WillReturnRows(unfilteredRows)
 	mock.ExpectQuery("SELECT").WillReturnRows(rows)
 	mock.ExpectQuery("SELECT").WillReturnRows(interfaceRows)
-	mock.ExpectQuery("SELECT").WillReturnRows(ipRows)
 
 	v := map[string]string{"cachegroup": "2"}
 
@@ -270,13 +283,18 @@ func TestGetServersByCachegroup(t *testing.T) {
 
 	version := api.Version{Major: 4, Minor: 0}
 
-	servers, _, userErr, sysErr, errCode, _ := getServers(nil, v, db.MustBegin(), &user, false, version)
+	servers, _, _, userErr, sysErr, errCode, _ := getServers(nil, v, db.MustBegin(), &user, false, version)
 	if userErr != nil || sysErr != nil {
 		t.Errorf("getServers expected: no errors, actual: %v %v with status: %s", userErr, sysErr, http.StatusText(errCode))
 	}
 
 	if len(servers) != 3 {
-		t.Errorf("getServers expected: len(servers) == 3, actual: %v", len(servers))
+		t.Fatalf("getServers expected: len(servers) == 3, actual: %v", len(servers))
+	}
+	for _, srv := range servers {
+		if len(srv.Interfaces) != 1 || len(srv.Interfaces[0].IPAddresses) != 1 {
+			t.Errorf("getServers expected: server #%d to have one interface with one IP address, actual: %+v", *srv.ID, srv.Interfaces)
+		}
 	}
 }
 
@@ -306,12 +324,8 @@ func TestGetMidServers(t *testing.T) {
 		"phys_location_id", "profile_name", "rack", "reval_pending", "revalidate_update_time", "revalidate_apply_time",
 		"status", "status_id", "tcp_port", "server_type", "server_type_id", "upd_pending", "config_update_time",
 		"config_apply_time", "xmpp_id", "xmpp_passwd", "status_last_updated", "asns"}
-	interfaceCols := []string{"max_bandwidth", "monitor", "mtu", "name", "server", "router_host_name", "router_port_name"}
 	rows := sqlmock.NewRows(cols)
-	interfaceRows := sqlmock.NewRows(interfaceCols)
-
-	ipCols := []string{"address", "gateway", "service_address", "server", "interface"}
-	ipRows := sqlmock.NewRows(ipCols)
+	interfaceRows := mockInterfaceRows(t, testServers)
 
 	for _, srv := range testServers {
 		ts := srv.Server
@@ -355,25 +369,6 @@ func TestGetMidServers(t *testing.T) {
 			*ts.StatusLastUpdated,
 			[]byte(`{1,2}`),
 		)
-		interfaceRows = interfaceRows.AddRow(
-			srv.Interface.MaxBandwidth,
-			srv.Interface.Monitor,
-			srv.Interface.MTU,
-			srv.Interface.Name,
-			*ts.ID,
-			srv.Interface.RouterHostName,
-			srv.Interface.RouterPortName,
-		)
-
-		for _, ip := range srv.Interface.IPAddresses {
-			ipRows = ipRows.AddRow(
-				ip.Address,
-				ip.Gateway,
-				ip.ServiceAddress,
-				*ts.ID,
-				srv.Interface.Name,
-			)
-		}
 	}
 	mock.ExpectBegin()
 	mock.ExpectPrepare("SELECT COUNT\\(s.id\\) FROM s")
@@ -381,12 +376,11 @@ func TestGetMidServers(t *testing.T) {
 	mock.ExpectQuery("SELECT COUNT\\(s.id\\) FROM s").WillReturnRows(unfilteredRows)
 	mock.ExpectQuery("SELECT").WillReturnRows(rows)
 	mock.ExpectQuery("SELECT").WillReturnRows(interfaceRows)
-	mock.ExpectQuery("SELECT").WillReturnRows(ipRows)
 	v := map[string]string{}
 
 	user := auth.CurrentUser{}
 	version := api.Version{Major: 4, Minor: 0}
-	servers, _, userErr, sysErr, errCode, _ := getServers(nil, v, db.MustBegin(), &user, false, version)
+	servers, _, _, userErr, sysErr, errCode, _ := getServers(nil, v, db.MustBegin(), &user, false, version)
 
 	if userErr != nil || sysErr != nil {
 		t.Errorf("getServers expected: no errors, actual: %v %v with status: %s", userErr, sysErr, http.StatusText(errCode))
@@ -730,3 +724,168 @@ func (s SortableServers) Swap(i, j int) {
 func (s SortableServers) Less(i, j int) bool {
 	return s[i].HostName < s[j].HostName
 }
+
+func TestGetServersOmitInterfaces(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	testServers := getTestServers()
+
+	mock.ExpectBegin()
+	mock.ExpectPrepare("SELECT COUNT\\(s.id\\) FROM s")
+	mock.ExpectPrepare("SELECT COUNT\\(s.id\\) FROM s")
+	mock.ExpectQuery("SELECT COUNT\\(s.id\\) FROM s").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(len(testServers)))
+	mock.ExpectQuery("SELECT").WillReturnRows(mockServerRows(testServers))
+
+	v := map[string]string{OmitInterfacesQueryParam: "true"}
+	user := auth.CurrentUser{}
+	version := api.Version{Major: 5, Minor: 0}
+	servers, _, _, userErr, sysErr, errCode, _ := getServers(nil, v, db.MustBegin(), &user, false, version)
+	if userErr != nil || sysErr != nil {
+		t.Fatalf("getServers expected: no errors, actual: %v %v with status: %s", userErr, sysErr, http.StatusText(errCode))
+	}
+	if len(servers) != len(testServers) {
+		t.Fatalf("getServers expected: len(servers) == %d, actual: %d", len(testServers), len(servers))
+	}
+	for _, srv := range servers {
+		if srv.Interfaces != nil {
+			t.Errorf("getServers expected: no interfaces for server #%d, actual: %+v", *srv.ID, srv.Interfaces)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expected no interfaces query, actual: %v", err)
+	}
+}
+
+func TestKeysetPagination(t *testing.T) {
+	queryValues := map[string]interface{}{}
+	where, orderBy, err := keysetPagination(map[string]string{"limit": "10"}, "5", "", queryValues)
+	if err != nil {
+		t.Fatalf("expected no error, actual: %v", err)
+	}
+	if where != "\nWHERE s.id > :after_id" || orderBy != "\nORDER BY s.id" || queryValues["after_id"] != 5 {
+		t.Errorf("unexpected keyset pagination clauses: '%s', '%s', values: %v", where, orderBy, queryValues)
+	}
+
+	where, orderBy, err = keysetPagination(map[string]string{"limit": "10", "orderby": "id", "sortOrder": "desc"}, "5", "\nWHERE s.cdn_id=:cdn", queryValues)
+	if err != nil {
+		t.Fatalf("expected no error, actual: %v", err)
+	}
+	if where != "\nWHERE s.cdn_id=:cdn AND s.id < :after_id" || orderBy != "\nORDER BY s.id DESC" {
+		t.Errorf("unexpected descending keyset pagination clauses: '%s', '%s'", where, orderBy)
+	}
+
+	invalid := []map[string]string{
+		{},
+		{"limit": "10", "offset": "2"},
+		{"limit": "10", "page": "2"},
+		{"limit": "10", "dsId": "2"},
+		{"limit": "10", "orderby": "hostName"},
+	}
+	for _, params := range invalid {
+		if _, _, err := keysetPagination(params, "5", "", map[string]interface{}{}); err == nil {
+			t.Errorf("expected an error for parameters %v, actual: nil", params)
+		}
+	}
+	if _, _, err := keysetPagination(map[string]string{"limit": "10"}, "five", "", map[string]interface{}{}); err == nil {
+		t.Error("expected an error for a non-integer ID, actual: nil")
+	}
+}
+
+func TestCursorPagination(t *testing.T) {
+	cols := map[string]dbhelpers.WhereColumnInfo{
+		"id":         {Column: "s.id"},
+		"hostName":   {Column: "s.host_name"},
+		"cachegroup": {Column: "s.cachegroup"},
+	}
+
+	_, orderBy, pagination, page, err := cursorPagination(map[string]string{"limit": "10", "orderby": "hostName"}, cols, "", "", "\nLIMIT 10", map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("expected no error, actual: %v", err)
+	}
+	if page == nil || orderBy != "\nORDER BY s.host_name, s.id" || pagination != "\nLIMIT 10" {
+		t.Errorf("unexpected cursor pagination clauses: '%s', '%s', page: %v", orderBy, pagination, page)
+	}
+
+	unpaged := []map[string]string{
+		{"limit": "10", "orderby": "cachegroup"},
+		{"limit": "10", "dsId": "2"},
+		{"limit": "10", AfterIDQueryParam: "5"},
+	}
+	for _, params := range unpaged {
+		where, orderBy, pagination, page, err := cursorPagination(params, cols, "\nWHERE s.cdn_id=:cdn", "\nORDER BY s.cachegroup", "\nLIMIT 10", map[string]interface{}{})
+		if err != nil {
+			t.Errorf("expected no error for parameters %v, actual: %v", params, err)
+		} else if page != nil || where != "\nWHERE s.cdn_id=:cdn" || orderBy != "\nORDER BY s.cachegroup" || pagination != "\nLIMIT 10" {
+			t.Errorf("expected clauses to be unchanged for parameters %v, actual: '%s', '%s', '%s', page: %v", params, where, orderBy, pagination, page)
+		}
+
+		params[tc.CursorQueryParam] = "eyJpIjozfQ"
+		if _, _, _, _, err := cursorPagination(params, cols, "", "", "", map[string]interface{}{}); err == nil {
+			t.Errorf("expected an error for a cursor with parameters %v, actual: nil", params)
+		}
+	}
+}
+
+// benchmarkGetServers measures reading the given number of servers, each
+// with a single interface, excluding the time taken to set up the mock
+// database.
+func benchmarkGetServers(b *testing.B, count int, omitInterfaces bool) {
+	template := getTestServers()[0]
+	testServers := make([]ServerAndInterfaces, 0, count)
+	for i := 1; i <= count; i++ {
+		srv := template
+		srv.Server.ID = util.IntPtr(i)
+		srv.Server.HostName = util.StrPtr(fmt.Sprintf("server%d", i))
+		testServers = append(testServers, srv)
+	}
+	params := map[string]string{}
+	if omitInterfaces {
+		params[OmitInterfacesQueryParam] = "true"
+	}
+	user := auth.CurrentUser{}
+	version := api.Version{Major: 5, Minor: 0}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		mockDB, mock, err := sqlmock.New()
+		if err != nil {
+			b.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+		}
+		db := sqlx.NewDb(mockDB, "sqlmock")
+		mock.ExpectBegin()
+		mock.ExpectPrepare("SELECT COUNT\\(s.id\\) FROM s")
+		mock.ExpectPrepare("SELECT COUNT\\(s.id\\) FROM s")
+		mock.ExpectQuery("SELECT COUNT\\(s.id\\) FROM s").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+		mock.ExpectQuery("SELECT").WillReturnRows(mockServerRows(testServers))
+		if !omitInterfaces {
+			mock.ExpectQuery("SELECT").WillReturnRows(mockInterfaceRows(b, testServers))
+		}
+		tx := db.MustBegin()
+		b.StartTimer()
+
+		_, _, _, userErr, sysErr, _, _ := getServers(nil, params, tx, &user, false, version)
+
+		b.StopTimer()
+		if userErr != nil || sysErr != nil {
+			b.Fatalf("getServers expected: no errors, actual: %v %v", userErr, sysErr)
+		}
+		db.Close()
+		b.StartTimer()
+	}
+}
+
+func BenchmarkGetServers1000(b *testing.B) {
+	benchmarkGetServers(b, 1000, false)
+}
+
+func BenchmarkGetServers1000OmitInterfaces(b *testing.B) {
+	benchmarkGetServers(b, 1000, true)
+}
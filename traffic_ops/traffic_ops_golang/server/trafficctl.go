@@ -0,0 +1,134 @@
+package server
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+// trafficCtlTimeout is how long t3c-log-agent may take to run a traffic_ctl
+// command and respond.
+const trafficCtlTimeout = time.Minute
+
+// RunTrafficCtl is the handler for POST requests to
+// /servers/{{ID}}/traffic_ctl. It runs a whitelisted traffic_ctl command on
+// the cache server through its t3c-log-agent, and responds with the result.
+// Every command run, or attempted, is recorded in the change log.
+func RunTrafficCtl(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	cfg := inf.Config.LogTail
+	if cfg == nil {
+		api.HandleErr(w, r, tx, http.StatusServiceUnavailable, errors.New("the agents of cache servers are not configured"), nil)
+		return
+	}
+	req := tc.TrafficCtlRequest{}
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	// Reloading the configuration of a cache server changes it, unlike the
+	// other commands, which only read its state.
+	if req.Command == tc.TrafficCtlConfigReload && inf.Config.RoleBasedPermissions && !inf.User.Can("SERVER:UPDATE") {
+		api.HandleErr(w, r, tx, http.StatusForbidden, fmt.Errorf("running '%s' requires the SERVER:UPDATE Permission", req), nil)
+		return
+	}
+
+	id := inf.IntParams["id"]
+	server, userErr, sysErr, errCode := getAgentServer(tx, id)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	result, userErr, sysErr := runAgentTrafficCtl(r.Context(), cfg, server.HostName+"."+server.DomainName, req)
+	if userErr != nil || sysErr != nil {
+		reason := "internal error"
+		if userErr != nil {
+			reason = userErr.Error()
+		}
+		// Attempts are recorded too, so the transaction isn't rolled back.
+		api.CreateChangeLogRawTx(api.ApiChange, fmt.Sprintf("SERVER: %s, ID: %d, ACTION: Failed to run '%s': %s", server.HostName, id, req, reason), inf.User, tx)
+		api.HandleErr(w, r, nil, http.StatusBadGateway, userErr, sysErr)
+		return
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, fmt.Sprintf("SERVER: %s, ID: %d, ACTION: Ran '%s', exit code %d", server.HostName, id, req, result.ExitCode), inf.User, tx)
+
+	msg := fmt.Sprintf("Ran '%s' on server '%s'", req, server.HostName)
+	if result.ExitCode != 0 {
+		api.WriteAlertsObj(w, r, http.StatusOK, tc.CreateAlerts(tc.WarnLevel, fmt.Sprintf("%s, which exited with code %d", msg, result.ExitCode)), result)
+		return
+	}
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, msg, result)
+}
+
+// runAgentTrafficCtl runs the traffic_ctl command of the request through the
+// t3c-log-agent of the cache server with the given FQDN.
+func runAgentTrafficCtl(ctx context.Context, cfg *config.ConfigLogTail, fqdn string, req tc.TrafficCtlRequest) (tc.TrafficCtlResult, error, error) {
+	result := tc.TrafficCtlResult{}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return result, nil, errors.New("encoding traffic_ctl request: " + err.Error())
+	}
+	ctx, cancel := context.WithTimeout(ctx, trafficCtlTimeout)
+	defer cancel()
+	agentReq, err := http.NewRequestWithContext(ctx, http.MethodPost, agentURL(cfg, fqdn, tc.LogAgentTrafficCtlPath, nil), bytes.NewReader(body))
+	if err != nil {
+		return result, nil, errors.New("creating agent request: " + err.Error())
+	}
+	agentReq.Header.Set("Authorization", "Bearer "+cfg.AgentSecret)
+	agentReq.Header.Set("Content-Type", "application/json")
+	resp, err := newAgentClient(cfg, trafficCtlTimeout).Do(agentReq)
+	if err != nil {
+		return result, errors.New("could not reach the agent of the server"), errors.New("requesting traffic_ctl from agent: " + err.Error())
+	}
+	defer log.Close(resp.Body, "closing agent response body")
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return result, errors.New("the agent of the server does not allow traffic_ctl commands"), nil
+	default:
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return result, fmt.Errorf("the agent of the server returned %s", resp.Status), fmt.Errorf("agent returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return result, errors.New("the agent of the server returned a malformed result"), errors.New("decoding traffic_ctl result from agent: " + err.Error())
+	}
+	return result, nil, nil
+}
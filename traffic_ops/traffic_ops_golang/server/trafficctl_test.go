@@ -0,0 +1,75 @@
+package server
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+func TestRunAgentTrafficCtl(t *testing.T) {
+	var status int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != tc.LogAgentTrafficCtlPath || r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("unexpected agent request: %s %s with authorization '%s'", r.Method, r.URL.Path, r.Header.Get("Authorization"))
+		}
+		req := tc.TrafficCtlRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding agent request: %v", err)
+		}
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		json.NewEncoder(w).Encode(tc.TrafficCtlResult{Command: req.Command, Args: req.Args, ExitCode: 0, Output: "proxy.process.http.incoming_requests 42\n"})
+	}))
+	defer srv.Close()
+	host, port, _ := net.SplitHostPort(srv.Listener.Addr().String())
+	cfg := &config.ConfigLogTail{AgentSecret: "secret"}
+	cfg.AgentPort, _ = strconv.Atoi(port)
+	req := tc.TrafficCtlRequest{Command: tc.TrafficCtlMetricGet, Args: []string{"proxy.process.http.incoming_requests"}}
+
+	status = http.StatusOK
+	result, userErr, sysErr := runAgentTrafficCtl(context.Background(), cfg, host, req)
+	if userErr != nil || sysErr != nil {
+		t.Fatalf("unexpected error running traffic_ctl: %v %v", userErr, sysErr)
+	}
+	if result.Command != req.Command || result.Output != "proxy.process.http.incoming_requests 42\n" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	status = http.StatusNotFound
+	if _, userErr, _ := runAgentTrafficCtl(context.Background(), cfg, host, req); userErr == nil {
+		t.Error("expected an error when the agent doesn't allow traffic_ctl commands, got none")
+	}
+
+	status = http.StatusInternalServerError
+	if _, userErr, sysErr := runAgentTrafficCtl(context.Background(), cfg, host, req); userErr == nil || sysErr == nil {
+		t.Errorf("expected user and system errors when the agent fails, got %v and %v", userErr, sysErr)
+	}
+}
@@ -278,17 +278,10 @@ func UpdateHandlerV4(w http.ResponseWriter, r *http.Request) {
 	defer inf.Close()
 
 	idOrName := inf.Params["id-or-name"]
-	serverID, err := strconv.ParseInt(idOrName, 10, 64)
-	if err != nil {
-		id, ok, err := dbhelpers.GetServerIDFromName(idOrName, inf.Tx.Tx)
-		if err != nil {
-			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, fmt.Errorf("getting server id from name '%v': %w", idOrName, err))
-			return
-		} else if !ok {
-			api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, errors.New("server name '"+idOrName+"' not found"), nil)
-			return
-		}
-		serverID = int64(id)
+	serverID, errCode, userErr, sysErr := getServerIDFromIDOrName(inf.Tx.Tx, idOrName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
 	}
 
 	cdnName, err := dbhelpers.GetCDNNameFromServerID(inf.Tx.Tx, serverID)
@@ -0,0 +1,168 @@
+package staticdnsentry
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+)
+
+// pastTense is how the success of each bulk action is described.
+var pastTense = map[tc.StaticDNSEntryBulkAction]string{
+	tc.StaticDNSEntryBulkCreate: "created",
+	tc.StaticDNSEntryBulkUpdate: "updated",
+	tc.StaticDNSEntryBulkDelete: "deleted",
+}
+
+// Bulk is the handler for POST requests to /staticdnsentries/bulk. It makes
+// each of the requested creations, updates, and deletions in turn, in a single
+// transaction, and responds with the result of each. If any of them fails,
+// none of them are made; the ones after it aren't attempted.
+func Bulk(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	ops := []tc.StaticDNSEntryBulkOperation{}
+	if err := json.NewDecoder(r.Body).Decode(&ops); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("parsing static DNS entry operations: "+err.Error()), nil)
+		return
+	}
+	if len(ops) == 0 {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("at least one static DNS entry operation is required"), nil)
+		return
+	}
+
+	results, failed, failedCode, sysErr := applyBulkOperations(inf, ops)
+	if sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, failedCode, nil, sysErr)
+		return
+	}
+	if failed >= 0 {
+		if err := inf.Tx.Tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Errorln("rolling back transaction: " + err.Error())
+		}
+		alerts := tc.CreateAlerts(tc.ErrorLevel, fmt.Sprintf("static DNS entry operation #%d failed, so no static DNS entries were changed", failed))
+		alerts.SetErrorCodes(failedCode)
+		api.WriteAlertsObj(w, r, failedCode, alerts, results)
+		return
+	}
+	counts := countBulkActions(results)
+	msg := fmt.Sprintf("%d static DNS entries were created, %d updated, and %d deleted.", counts[tc.StaticDNSEntryBulkCreate], counts[tc.StaticDNSEntryBulkUpdate], counts[tc.StaticDNSEntryBulkDelete])
+	api.WriteAlertsObj(w, r, http.StatusOK, tc.CreateAlerts(tc.SuccessLevel, msg), results)
+}
+
+// applyBulkOperations makes each of the given operations in turn, returning
+// the result of each. If one of them fails, the ones after it aren't
+// attempted, and its index and the HTTP status code of its failure are
+// returned - otherwise, the index is -1 - but the ones before it aren't
+// undone; the caller must roll back the transaction. The status code is also
+// returned with a system error.
+func applyBulkOperations(inf *api.APIInfo, ops []tc.StaticDNSEntryBulkOperation) ([]tc.StaticDNSEntryBulkResult, int, int, error) {
+	results := make([]tc.StaticDNSEntryBulkResult, len(ops))
+	failed := -1
+	failedCode := http.StatusOK
+	for i, op := range ops {
+		results[i] = tc.StaticDNSEntryBulkResult{Action: op.Action, Entry: op.Entry}
+		if failed >= 0 {
+			results[i].Alerts = tc.CreateAlerts(tc.InfoLevel, "not attempted, because an earlier operation failed")
+			continue
+		}
+
+		en := &entry{op.Entry}
+		userErr, sysErr, errCode := bulkOperation(inf, op.Action, en)
+		if sysErr != nil {
+			return nil, -1, errCode, fmt.Errorf("static DNS entry operation #%d: %w", i, sysErr)
+		}
+		if userErr != nil {
+			failed = i
+			failedCode = errCode
+			results[i].Alerts = tc.CreateCodedErrorAlerts(errCode, userErr)
+			continue
+		}
+		results[i].Entry = en.StaticDNSEntryNullable
+		results[i].Alerts = tc.CreateAlerts(tc.SuccessLevel, resourceType+" was "+pastTense[op.Action]+".")
+	}
+	return results, failed, failedCode, nil
+}
+
+// countBulkActions returns the number of the given results of each action.
+func countBulkActions(results []tc.StaticDNSEntryBulkResult) map[tc.StaticDNSEntryBulkAction]int {
+	counts := map[tc.StaticDNSEntryBulkAction]int{}
+	for _, res := range results {
+		counts[res.Action]++
+	}
+	return counts
+}
+
+// bulkOperation validates and makes one of the changes requested of the bulk
+// endpoint, updating en to match the stored Static DNS Entry.
+func bulkOperation(inf *api.APIInfo, action tc.StaticDNSEntryBulkAction, en *entry) (error, error, int) {
+	switch action {
+	case tc.StaticDNSEntryBulkCreate:
+		en.ID = nil
+		if userErr, sysErr, errCode := bulkValidate(inf, en, -1); userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+		return Resource.Create(inf, en)
+	case tc.StaticDNSEntryBulkUpdate:
+		if en.ID == nil {
+			return errors.New("id: required to update a static DNS entry"), nil, http.StatusBadRequest
+		}
+		if userErr, sysErr, errCode := bulkValidate(inf, en, *en.ID); userErr != nil || sysErr != nil {
+			return userErr, sysErr, errCode
+		}
+		// Preconditions in the request's headers can't apply to every
+		// entry, so they're ignored.
+		return Resource.Update(inf, nil, en)
+	case tc.StaticDNSEntryBulkDelete:
+		if en.ID == nil {
+			return errors.New("id: required to delete a static DNS entry"), nil, http.StatusBadRequest
+		}
+		id := *en.ID
+		en.StaticDNSEntryNullable = tc.StaticDNSEntryNullable{ID: &id}
+		return Resource.Delete(inf, en)
+	}
+	return fmt.Errorf("action: must be one of '%s', '%s', or '%s'", tc.StaticDNSEntryBulkCreate, tc.StaticDNSEntryBulkUpdate, tc.StaticDNSEntryBulkDelete), nil, http.StatusBadRequest
+}
+
+func bulkValidate(inf *api.APIInfo, en *entry, id int) (error, error, int) {
+	userErr, sysErr := validateEntry(inf, en, id)
+	if sysErr != nil {
+		return nil, fmt.Errorf("validating %s: %w", resourceType, sysErr), http.StatusInternalServerError
+	}
+	if userErr != nil {
+		tovalidate.SetObject(userErr, strings.ToLower(resourceType))
+		return userErr, nil, http.StatusBadRequest
+	}
+	return nil, nil, http.StatusOK
+}
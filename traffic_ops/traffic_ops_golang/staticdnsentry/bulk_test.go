@@ -0,0 +1,43 @@
+package staticdnsentry
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+)
+
+func TestBulkOperationInvalid(t *testing.T) {
+	for _, action := range []tc.StaticDNSEntryBulkAction{"", "upsert", tc.StaticDNSEntryBulkUpdate, tc.StaticDNSEntryBulkDelete} {
+		userErr, sysErr, code := bulkOperation(&api.APIInfo{}, action, &entry{})
+		if sysErr != nil {
+			t.Errorf("action '%s': unexpected system error: %v", action, sysErr)
+		}
+		if userErr == nil {
+			t.Errorf("action '%s': expected an error for an entry without an ID or an invalid action", action)
+		}
+		if code != http.StatusBadRequest {
+			t.Errorf("action '%s': expected status code %d, got: %d", action, http.StatusBadRequest, code)
+		}
+	}
+}
@@ -0,0 +1,358 @@
+package staticdnsentry
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+)
+
+// ExportFormatZoneFile is the format of static DNS entry exports as RFC 1035
+// zone file fragments.
+const ExportFormatZoneFile = "zonefile"
+
+// ExportFormatBIND is another name for ExportFormatZoneFile - the zone files
+// of BIND use the RFC 1035 format.
+const ExportFormatBIND = "bind"
+
+// exportEntry is a static DNS entry being exported.
+type exportEntry struct {
+	DeliveryService string
+	// HostRegex is the first host regular expression of the Delivery
+	// Service, from which its domain is derived; empty if it has none.
+	HostRegex string
+	Host      string
+	TTL       int64
+	Address   string
+	Type      string
+}
+
+// hostRegexReplacer derives the subdomain of a Delivery Service from its host
+// regular expression, as Traffic Router does.
+var hostRegexReplacer = strings.NewReplacer(`\`, ``, `.*`, ``, `.`, ``)
+
+// txtEscaper escapes the text of TXT records for use in quoted strings.
+var txtEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// Export is the handler for GET requests to /staticdnsentries/export. It
+// responds with the static DNS entries that Traffic Router serves for the
+// active Delivery Services of a CDN, as RFC 1035 zone file fragments - one
+// for each Delivery Service domain.
+func Export(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"cdn"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	if err := checkExportFormat(inf.Params); err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	cdn := inf.Params["cdn"]
+	domain := ""
+	if err := inf.Tx.Tx.QueryRow(`SELECT domain_name FROM cdn WHERE name = $1`, cdn).Scan(&domain); err == sql.ErrNoRows {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusNotFound, fmt.Errorf("no such CDN: '%s'", cdn), nil)
+		return
+	} else if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, fmt.Errorf("getting domain of CDN '%s': %w", cdn, err))
+		return
+	}
+
+	entries, err := getExportEntries(inf.Tx.Tx, cdn)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	w.Header().Set(rfc.ContentType, rfc.ContentTypeTextPlain)
+	w.WriteHeader(http.StatusOK)
+	api.WriteAndLogErr(w, r, []byte(zoneFile(cdn, domain, entries)))
+}
+
+// ExportDeliveryService is the handler for GET requests to
+// /deliveryservices/{id}/staticdnsentries/export. It responds with all of the
+// static DNS entries of a Delivery Service as an RFC 1035 zone file fragment.
+func ExportDeliveryService(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["id"]
+
+	if err := checkExportFormat(inf.Params); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	ds := dsExport{}
+	var hostRegex sql.NullString
+	err := tx.QueryRow(dsExportQuery, dsID).Scan(&ds.XMLID, &ds.Active, &ds.CDN, &ds.CDNDomain, &hostRegex)
+	if errors.Is(err, sql.ErrNoRows) {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no Delivery Service with ID %d", dsID), nil)
+		return
+	} else if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting Delivery Service #%d: %w", dsID, err))
+		return
+	}
+	ds.HostRegex = hostRegex.String
+
+	userErr, sysErr, errCode = tenant.CheckID(tx, inf.User, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	entries, err := getDSExportEntries(tx, dsID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	w.Header().Set(rfc.ContentType, rfc.ContentTypeTextPlain)
+	w.WriteHeader(http.StatusOK)
+	api.WriteAndLogErr(w, r, []byte(dsZoneFile(ds, entries)))
+}
+
+// checkExportFormat checks that the export format requested by the 'format'
+// query parameter, if any, is supported. The returned error is safe to show
+// to the client.
+func checkExportFormat(params map[string]string) error {
+	format, ok := params["format"]
+	if !ok || format == ExportFormatZoneFile || format == ExportFormatBIND {
+		return nil
+	}
+	return fmt.Errorf("unsupported format '%s' - the supported formats are '%s' and '%s'", format, ExportFormatZoneFile, ExportFormatBIND)
+}
+
+func getExportEntries(tx *sql.Tx, cdn string) ([]exportEntry, error) {
+	rows, err := tx.Query(exportQuery, cdn)
+	if err != nil {
+		return nil, errors.New("querying static DNS entries: " + err.Error())
+	}
+	defer rows.Close()
+
+	entries := []exportEntry{}
+	for rows.Next() {
+		e := exportEntry{}
+		var hostRegex sql.NullString
+		if err := rows.Scan(&e.DeliveryService, &hostRegex, &e.Host, &e.TTL, &e.Address, &e.Type); err != nil {
+			return nil, errors.New("scanning static DNS entries: " + err.Error())
+		}
+		e.HostRegex = hostRegex.String
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over static DNS entries: " + err.Error())
+	}
+	return entries, nil
+}
+
+// dsExport is the Delivery Service whose static DNS entries are being
+// exported.
+type dsExport struct {
+	XMLID     string
+	Active    bool
+	CDN       string
+	CDNDomain string
+	// HostRegex is the first host regular expression of the Delivery
+	// Service; empty if it has none.
+	HostRegex string
+}
+
+func getDSExportEntries(tx *sql.Tx, dsID int) ([]exportEntry, error) {
+	rows, err := tx.Query(dsExportEntriesQuery, dsID)
+	if err != nil {
+		return nil, errors.New("querying static DNS entries: " + err.Error())
+	}
+	defer rows.Close()
+
+	entries := []exportEntry{}
+	for rows.Next() {
+		e := exportEntry{}
+		if err := rows.Scan(&e.Host, &e.TTL, &e.Address, &e.Type); err != nil {
+			return nil, errors.New("scanning static DNS entries: " + err.Error())
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over static DNS entries: " + err.Error())
+	}
+	return entries, nil
+}
+
+// dsDomain returns the domain of a Delivery Service with the given host
+// regular expression in the CDN with the given domain.
+func dsDomain(hostRegex, cdnDomain string) string {
+	return hostRegexReplacer.Replace(hostRegex) + "." + cdnDomain
+}
+
+// dsZoneFile returns the given static DNS entries of a Delivery Service as a
+// zone file fragment. Unlike the export of a CDN, it includes the entries of
+// an inactive Delivery Service, noting that Traffic Router doesn't serve
+// them. The entries of a Delivery Service without a host regular expression
+// have no domain, so they're only counted in a comment.
+func dsZoneFile(ds dsExport, entries []exportEntry) string {
+	b := strings.Builder{}
+	b.WriteString("; Static DNS entries of Delivery Service " + ds.XMLID + " of CDN " + ds.CDN + "\n")
+	if ds.HostRegex == "" {
+		if len(entries) > 0 {
+			fmt.Fprintf(&b, "; Omitted %d entries, because the Delivery Service has no host regular expression\n", len(entries))
+		}
+		return b.String()
+	}
+	if !ds.Active {
+		b.WriteString("; The Delivery Service is not active, so Traffic Router doesn't serve these entries\n")
+	}
+	b.WriteString("$ORIGIN " + dsDomain(ds.HostRegex, ds.CDNDomain) + ".\n")
+	for _, e := range entries {
+		writeRecord(&b, e)
+	}
+	return b.String()
+}
+
+// zoneFile returns the given static DNS entries of the CDN with the given
+// name and domain as zone file fragments, in order of domain. Entries of
+// Delivery Services without host regular expressions aren't served by
+// Traffic Router, so they're only noted in comments.
+func zoneFile(cdn, domain string, entries []exportEntry) string {
+	byDomain := map[string][]exportEntry{}
+	unrouted := map[string]struct{}{}
+	for _, e := range entries {
+		if e.HostRegex == "" {
+			unrouted[e.DeliveryService] = struct{}{}
+			continue
+		}
+		d := dsDomain(e.HostRegex, domain)
+		byDomain[d] = append(byDomain[d], e)
+	}
+	domains := make([]string, 0, len(byDomain))
+	for d := range byDomain {
+		domains = append(domains, d)
+	}
+	sort.Strings(domains)
+
+	b := strings.Builder{}
+	b.WriteString("; Static DNS entries of CDN " + cdn + "\n")
+	for _, d := range domains {
+		b.WriteString("\n")
+		dses := []string{}
+		for _, e := range byDomain[d] {
+			if len(dses) == 0 || dses[len(dses)-1] != e.DeliveryService {
+				dses = append(dses, e.DeliveryService)
+			}
+		}
+		b.WriteString("; Delivery Service: " + strings.Join(dses, ", ") + "\n")
+		b.WriteString("$ORIGIN " + d + ".\n")
+		for _, e := range byDomain[d] {
+			writeRecord(&b, e)
+		}
+	}
+	if len(unrouted) > 0 {
+		dses := make([]string, 0, len(unrouted))
+		for ds := range unrouted {
+			dses = append(dses, ds)
+		}
+		sort.Strings(dses)
+		b.WriteString("\n; Omitted the entries of Delivery Services without host regular expressions: " + strings.Join(dses, ", ") + "\n")
+	}
+	return b.String()
+}
+
+// writeRecord writes the resource record of a static DNS entry, relative to
+// the origin of its Delivery Service's domain.
+func writeRecord(w io.Writer, e exportEntry) {
+	rrType := strings.TrimSuffix(e.Type, "_RECORD")
+	rdata := e.Address
+	if rrType == "TXT" {
+		rdata = `"` + txtEscaper.Replace(rdata) + `"`
+	}
+	fmt.Fprintf(w, "%s\t%d\tIN\t%s\t%s\n", e.Host, e.TTL, rrType, rdata)
+}
+
+// hostRegexQuery selects the first host regular expression of the Delivery
+// Service ds.
+const hostRegexQuery = `(
+		SELECT r.pattern
+		FROM deliveryservice_regex AS dsr
+		JOIN regex AS r ON r.id = dsr.regex
+		JOIN type AS rt ON rt.id = r.type
+		WHERE dsr.deliveryservice = ds.id
+		AND dsr.set_number = 0
+		AND rt.name = 'HOST_REGEXP'
+		ORDER BY r.id
+		LIMIT 1
+	)`
+
+// Like the CRConfig, the export includes only the entries of active Delivery
+// Services.
+const exportQuery = `
+SELECT
+	ds.xml_id,
+	` + hostRegexQuery + ` AS host_regex,
+	sde.host,
+	sde.ttl,
+	sde.address,
+	tp.name
+FROM staticdnsentry AS sde
+JOIN deliveryservice AS ds ON ds.id = sde.deliveryservice
+JOIN type AS tp ON tp.id = sde.type
+WHERE ds.cdn_id = (SELECT id FROM cdn WHERE name = $1)
+AND ds.active = true
+ORDER BY ds.xml_id, sde.host, tp.name, sde.address
+`
+
+const dsExportQuery = `
+SELECT
+	ds.xml_id,
+	ds.active,
+	c.name,
+	c.domain_name,
+	` + hostRegexQuery + ` AS host_regex
+FROM deliveryservice AS ds
+JOIN cdn AS c ON c.id = ds.cdn_id
+WHERE ds.id = $1
+`
+
+const dsExportEntriesQuery = `
+SELECT
+	sde.host,
+	sde.ttl,
+	sde.address,
+	tp.name
+FROM staticdnsentry AS sde
+JOIN type AS tp ON tp.id = sde.type
+WHERE sde.deliveryservice = $1
+ORDER BY sde.host, tp.name, sde.address
+`
@@ -0,0 +1,94 @@
+package staticdnsentry
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestZoneFile(t *testing.T) {
+	entries := []exportEntry{
+		{DeliveryService: "demo1", HostRegex: `.*\.demo1\..*`, Host: "cname", TTL: 300, Address: "target.example.com.", Type: "CNAME_RECORD"},
+		{DeliveryService: "demo1", HostRegex: `.*\.demo1\..*`, Host: "txt", TTL: 60, Address: `say "hi"`, Type: "TXT_RECORD"},
+		{DeliveryService: "alpha", HostRegex: `.*\.alpha\..*`, Host: "www", TTL: 3600, Address: "192.0.2.1", Type: "A_RECORD"},
+		{DeliveryService: "alpha", HostRegex: `.*\.alpha\..*`, Host: "www", TTL: 3600, Address: "2001:db8::1", Type: "AAAA_RECORD"},
+		{DeliveryService: "unrouted", Host: "www", TTL: 3600, Address: "192.0.2.2", Type: "A_RECORD"},
+	}
+	expected := `; Static DNS entries of CDN cdn1
+
+; Delivery Service: alpha
+$ORIGIN alpha.mycdn.test.
+www	3600	IN	A	192.0.2.1
+www	3600	IN	AAAA	2001:db8::1
+
+; Delivery Service: demo1
+$ORIGIN demo1.mycdn.test.
+cname	300	IN	CNAME	target.example.com.
+txt	60	IN	TXT	"say \"hi\""
+
+; Omitted the entries of Delivery Services without host regular expressions: unrouted
+`
+	if actual := zoneFile("cdn1", "mycdn.test", entries); actual != expected {
+		t.Errorf("Expected zone file:\n%s\nActual:\n%s", expected, actual)
+	}
+
+	expected = "; Static DNS entries of CDN cdn1\n"
+	if actual := zoneFile("cdn1", "mycdn.test", nil); actual != expected {
+		t.Errorf("Expected zone file without entries to be %q, actual: %q", expected, actual)
+	}
+}
+
+func TestDSZoneFile(t *testing.T) {
+	ds := dsExport{XMLID: "demo1", Active: true, CDN: "cdn1", CDNDomain: "mycdn.test", HostRegex: `.*\.demo1\..*`}
+	entries := []exportEntry{
+		{Host: "_sip._tcp", TTL: 60, Address: "10 60 5060 sip.example.com.", Type: "SRV_RECORD"},
+		{Host: "txt", TTL: 60, Address: `say "hi"`, Type: "TXT_RECORD"},
+	}
+	expected := `; Static DNS entries of Delivery Service demo1 of CDN cdn1
+$ORIGIN demo1.mycdn.test.
+_sip._tcp	60	IN	SRV	10 60 5060 sip.example.com.
+txt	60	IN	TXT	"say \"hi\""
+`
+	actual := dsZoneFile(ds, entries)
+	if actual != expected {
+		t.Errorf("Expected zone file:\n%s\nActual:\n%s", expected, actual)
+	}
+	records, err := tc.ParseZoneFile(actual, "")
+	if err != nil {
+		t.Fatalf("Unexpected error parsing exported zone file: %v", err)
+	}
+	if len(records) != 2 || records[1].Name != "txt.demo1.mycdn.test." || records[1].Data != entries[1].Address {
+		t.Errorf("Expected exported zone file to parse to the entries, actual: %+v", records)
+	}
+
+	ds.Active = false
+	if actual := dsZoneFile(ds, entries); !strings.Contains(actual, "not active") {
+		t.Errorf("Expected zone file of an inactive Delivery Service to say so, actual:\n%s", actual)
+	}
+
+	ds.HostRegex = ""
+	expected = "; Static DNS entries of Delivery Service demo1 of CDN cdn1\n; Omitted 2 entries, because the Delivery Service has no host regular expression\n"
+	if actual := dsZoneFile(ds, entries); actual != expected {
+		t.Errorf("Expected zone file of a Delivery Service without a host regular expression to be %q, actual: %q", expected, actual)
+	}
+}
@@ -0,0 +1,317 @@
+package staticdnsentry
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+)
+
+// importSkippedTypes are the types of records that are ignored when importing
+// a zone file, because Traffic Router generates its own records of them.
+var importSkippedTypes = map[string]struct{}{"SOA": {}, "NS": {}}
+
+// existingEntry is a static DNS entry of the Delivery Service to which a zone
+// file is imported.
+type existingEntry struct {
+	ID           int
+	Host         string
+	Type         string
+	Address      string
+	TTL          int64
+	CacheGroupID *int
+	// matched is whether a record of the zone file has been matched to the
+	// entry.
+	matched bool
+}
+
+// importOperation is the change to make to the static DNS entries for one of
+// the records of an imported zone file.
+type importOperation struct {
+	Line int
+	tc.StaticDNSEntryBulkOperation
+}
+
+// Import is the handler for POST requests to /staticdnsentries/import. It
+// creates or updates the static DNS entries of a Delivery Service to match
+// the records of a zone file, in a single transaction, and responds with the
+// result of each change. If any of them fails, none of them are made. Entries
+// that aren't in the zone file are left as they are.
+func Import(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	req := tc.StaticDNSEntryImportRequest{}
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	ds := dsExport{}
+	var hostRegex sql.NullString
+	err := tx.QueryRow(dsExportQuery, req.DeliveryServiceID).Scan(&ds.XMLID, &ds.Active, &ds.CDN, &ds.CDNDomain, &hostRegex)
+	if errors.Is(err, sql.ErrNoRows) {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("deliveryServiceId: no Delivery Service with ID %d", req.DeliveryServiceID), nil)
+		return
+	} else if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting Delivery Service #%d: %w", req.DeliveryServiceID, err))
+		return
+	}
+	userErr, sysErr, errCode = tenant.CheckID(tx, inf.User, req.DeliveryServiceID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	if !hostRegex.Valid {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("Delivery Service %s has no host regular expression, so its static DNS entries have no domain", ds.XMLID), nil)
+		return
+	}
+	domain := dsDomain(hostRegex.String, ds.CDNDomain) + "."
+
+	records, err := tc.ParseZoneFile(req.ZoneFile, domain)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("zoneFile: "+err.Error()), nil)
+		return
+	}
+	existing, err := getExistingEntries(tx, req.DeliveryServiceID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	typeIDs, err := getTypeIDs(tx)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	ops, unchanged, skipped, userErr := importOperations(req.DeliveryServiceID, domain, records, existing, typeIDs)
+	if userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("zoneFile: "+userErr.Error()), nil)
+		return
+	}
+	bulkOps := make([]tc.StaticDNSEntryBulkOperation, 0, len(ops))
+	for _, op := range ops {
+		bulkOps = append(bulkOps, op.StaticDNSEntryBulkOperation)
+	}
+	bulkResults, failed, failedCode, sysErr := applyBulkOperations(inf, bulkOps)
+	if sysErr != nil {
+		api.HandleErr(w, r, tx, failedCode, nil, sysErr)
+		return
+	}
+	results := make([]tc.StaticDNSEntryImportResult, 0, len(ops))
+	for i, res := range bulkResults {
+		results = append(results, tc.StaticDNSEntryImportResult{Line: ops[i].Line, StaticDNSEntryBulkResult: res})
+	}
+
+	if failed >= 0 || req.DryRun {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			log.Errorln("rolling back transaction: " + err.Error())
+		}
+	}
+	if failed >= 0 {
+		alerts := tc.CreateAlerts(tc.ErrorLevel, fmt.Sprintf("the static DNS entry of the record on line %d couldn't be changed, so no static DNS entries were changed", ops[failed].Line))
+		alerts.SetErrorCodes(failedCode)
+		api.WriteAlertsObj(w, r, failedCode, alerts, results)
+		return
+	}
+
+	counts := countBulkActions(bulkResults)
+	var alerts tc.Alerts
+	if req.DryRun {
+		alerts.AddNewAlert(tc.SuccessLevel, fmt.Sprintf("Dry run: %d static DNS entries would be created, and %d updated; %d are unchanged. No changes were made.", counts[tc.StaticDNSEntryBulkCreate], counts[tc.StaticDNSEntryBulkUpdate], unchanged))
+	} else {
+		alerts.AddNewAlert(tc.SuccessLevel, fmt.Sprintf("%d static DNS entries were created, and %d updated; %d were unchanged.", counts[tc.StaticDNSEntryBulkCreate], counts[tc.StaticDNSEntryBulkUpdate], unchanged))
+	}
+	if len(skipped) > 0 {
+		alerts.AddNewAlert(tc.InfoLevel, "Skipped the records that Traffic Router generates itself, on lines: "+strings.Join(skipped, ", "))
+	}
+	api.WriteAlertsObj(w, r, http.StatusOK, alerts, results)
+}
+
+// importOperations returns the changes to make to the existing static DNS
+// entries of the Delivery Service identified by dsID, whose domain is domain,
+// to import the given records, along with the number of records that already
+// have matching entries and the lines of the records that were skipped. The
+// returned error, if any, is safe to show to the client.
+//
+// A record matches an existing entry with the same host, type, and address -
+// or, for CNAME records, which can't share their host, just the same host and
+// type - whose TTL is updated if it differs. Records without a matching entry
+// are created.
+func importOperations(dsID int, domain string, records []tc.StaticDNSEntryZoneRecord, existing []existingEntry, typeIDs map[string]int) ([]importOperation, int, []string, error) {
+	ops := []importOperation{}
+	unchanged := 0
+	skipped := []string{}
+	errs := []error{}
+	for _, rec := range records {
+		if _, ok := importSkippedTypes[rec.Type]; ok {
+			skipped = append(skipped, fmt.Sprintf("%d (%s)", rec.Line, rec.Type))
+			continue
+		}
+		if rec.Class != "IN" {
+			errs = append(errs, fmt.Errorf("line %d: unsupported class '%s' - only 'IN' records can be imported", rec.Line, rec.Class))
+			continue
+		}
+		typeName := rec.Type + "_RECORD"
+		typeID, ok := typeIDs[typeName]
+		if !ok {
+			errs = append(errs, fmt.Errorf("line %d: unsupported record type '%s'", rec.Line, rec.Type))
+			continue
+		}
+		if strings.EqualFold(rec.Name, domain) {
+			errs = append(errs, fmt.Errorf("line %d: the Delivery Service's domain '%s' itself can't have static DNS entries", rec.Line, domain))
+			continue
+		}
+		host := strings.TrimSuffix(strings.ToLower(rec.Name), "."+strings.ToLower(domain))
+		if host == strings.ToLower(rec.Name) {
+			errs = append(errs, fmt.Errorf("line %d: name '%s' is not within the Delivery Service's domain '%s'", rec.Line, rec.Name, domain))
+			continue
+		}
+		address := rec.Data
+		if typeName != txtRecordType {
+			address = strings.Join(strings.Fields(address), " ")
+		}
+
+		op := importOperation{Line: rec.Line}
+		op.Action = tc.StaticDNSEntryBulkCreate
+		op.Entry = tc.StaticDNSEntryNullable{
+			Address:           util.StrPtr(address),
+			DeliveryServiceID: util.IntPtr(dsID),
+			Host:              util.StrPtr(host),
+			TTL:               util.Int64Ptr(rec.TTL),
+			Type:              util.StrPtr(typeName),
+			TypeID:            typeID,
+		}
+		if match := matchEntry(existing, host, typeName, address); match != nil {
+			match.matched = true
+			if sameAddress(typeName, match.Address, address) && match.TTL == rec.TTL {
+				unchanged++
+				continue
+			}
+			op.Action = tc.StaticDNSEntryBulkUpdate
+			op.Entry.ID = util.IntPtr(match.ID)
+			op.Entry.CacheGroupID = match.CacheGroupID
+		}
+		ops = append(ops, op)
+	}
+	return ops, unchanged, skipped, util.JoinErrs(errs)
+}
+
+// matchEntry returns the first existing entry that hasn't been matched yet
+// that a record with the given host, type, and address matches, or nil if
+// there isn't one.
+func matchEntry(existing []existingEntry, host, typeName, address string) *existingEntry {
+	for i := range existing {
+		e := &existing[i]
+		if e.matched || !strings.EqualFold(e.Host, host) || e.Type != typeName {
+			continue
+		}
+		if typeName == cnameRecordType || sameAddress(typeName, e.Address, address) {
+			return e
+		}
+	}
+	return nil
+}
+
+// sameAddress returns whether two addresses of static DNS entries of the given
+// type are the same. Only the text of TXT records is sensitive to whitespace,
+// and the IP addresses of A and AAAA records are the same however they're
+// written.
+func sameAddress(typeName, a, b string) bool {
+	switch typeName {
+	case txtRecordType:
+		return a == b
+	case "A_RECORD", "AAAA_RECORD":
+		return tc.SameIP(a, b)
+	}
+	return strings.Join(strings.Fields(a), " ") == strings.Join(strings.Fields(b), " ")
+}
+
+func getExistingEntries(tx *sql.Tx, dsID int) ([]existingEntry, error) {
+	rows, err := tx.Query(existingEntriesQuery, dsID)
+	if err != nil {
+		return nil, errors.New("querying static DNS entries: " + err.Error())
+	}
+	defer log.Close(rows, "closing static DNS entry rows")
+
+	entries := []existingEntry{}
+	for rows.Next() {
+		e := existingEntry{}
+		if err := rows.Scan(&e.ID, &e.Host, &e.Type, &e.Address, &e.TTL, &e.CacheGroupID); err != nil {
+			return nil, errors.New("scanning static DNS entries: " + err.Error())
+		}
+		entries = append(entries, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over static DNS entries: " + err.Error())
+	}
+	return entries, nil
+}
+
+// getTypeIDs returns the IDs of the types of static DNS entries, by name.
+func getTypeIDs(tx *sql.Tx) (map[string]int, error) {
+	rows, err := tx.Query(`SELECT id, name FROM type WHERE use_in_table = 'staticdnsentry'`)
+	if err != nil {
+		return nil, errors.New("querying static DNS entry types: " + err.Error())
+	}
+	defer log.Close(rows, "closing static DNS entry type rows")
+
+	typeIDs := map[string]int{}
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, errors.New("scanning static DNS entry types: " + err.Error())
+		}
+		typeIDs[name] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over static DNS entry types: " + err.Error())
+	}
+	return typeIDs, nil
+}
+
+const existingEntriesQuery = `
+SELECT
+	sde.id,
+	sde.host,
+	tp.name,
+	sde.address,
+	sde.ttl,
+	sde.cachegroup
+FROM staticdnsentry AS sde
+JOIN type AS tp ON tp.id = sde.type
+WHERE sde.deliveryservice = $1
+ORDER BY sde.id
+`
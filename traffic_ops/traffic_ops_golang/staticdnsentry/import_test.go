@@ -0,0 +1,104 @@
+package staticdnsentry
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestImportOperations(t *testing.T) {
+	domain := "demo1.mycdn.test."
+	typeIDs := map[string]int{"A_RECORD": 1, "CNAME_RECORD": 2, "TXT_RECORD": 3, "MX_RECORD": 4, "AAAA_RECORD": 5}
+	existing := []existingEntry{
+		{ID: 10, Host: "www", Type: "A_RECORD", Address: "192.0.2.1", TTL: 60},
+		{ID: 11, Host: "www", Type: "A_RECORD", Address: "192.0.2.2", TTL: 60},
+		{ID: 12, Host: "alias", Type: "CNAME_RECORD", Address: "old.example.com.", TTL: 60},
+		{ID: 13, Host: "mx", Type: "MX_RECORD", Address: "10  mail.example.com.", TTL: 60},
+		{ID: 14, Host: "v6", Type: "AAAA_RECORD", Address: "2001:db8::1", TTL: 60},
+	}
+	records, err := tc.ParseZoneFile(`$TTL 60
+@	IN	SOA	ns1.example.com. admin.example.com. 1 3600 600 86400 60
+www	A	192.0.2.1
+www	300	A	192.0.2.2
+www	A	192.0.2.3
+ALIAS	CNAME	new.example.com.
+mx	MX	10 mail.example.com.
+txt	TXT	"hello"
+v6	AAAA	2001:DB8:0:0::1
+`, domain)
+	if err != nil {
+		t.Fatalf("Unexpected error parsing zone file: %v", err)
+	}
+
+	ops, unchanged, skipped, err := importOperations(1, domain, records, existing, typeIDs)
+	if err != nil {
+		t.Fatalf("Unexpected error importing records: %v", err)
+	}
+	if unchanged != 3 {
+		t.Errorf("Expected 3 unchanged records, actual: %d", unchanged)
+	}
+	if len(skipped) != 1 || !strings.HasPrefix(skipped[0], "2 ") {
+		t.Errorf("Expected the SOA record on line 2 to be skipped, actual: %v", skipped)
+	}
+	type expected struct {
+		line    int
+		action  tc.StaticDNSEntryBulkAction
+		id      int
+		host    string
+		address string
+		ttl     int64
+	}
+	expectedOps := []expected{
+		{line: 4, action: tc.StaticDNSEntryBulkUpdate, id: 11, host: "www", address: "192.0.2.2", ttl: 300},
+		{line: 5, action: tc.StaticDNSEntryBulkCreate, host: "www", address: "192.0.2.3", ttl: 60},
+		{line: 6, action: tc.StaticDNSEntryBulkUpdate, id: 12, host: "alias", address: "new.example.com.", ttl: 60},
+		{line: 8, action: tc.StaticDNSEntryBulkCreate, host: "txt", address: "hello", ttl: 60},
+	}
+	if len(ops) != len(expectedOps) {
+		t.Fatalf("Expected %d operations, actual: %d (%+v)", len(expectedOps), len(ops), ops)
+	}
+	for i, exp := range expectedOps {
+		op := ops[i]
+		if op.Line != exp.line || op.Action != exp.action || *op.Entry.Host != exp.host || *op.Entry.Address != exp.address || *op.Entry.TTL != exp.ttl || *op.Entry.DeliveryServiceID != 1 {
+			t.Errorf("Expected operation #%d to be %+v, actual: line %d, %s %s %s %d", i, exp, op.Line, op.Action, *op.Entry.Host, *op.Entry.Address, *op.Entry.TTL)
+		}
+		if exp.id == 0 && op.Entry.ID != nil || exp.id != 0 && (op.Entry.ID == nil || *op.Entry.ID != exp.id) {
+			t.Errorf("Expected operation #%d to have ID %d, actual: %v", i, exp.id, op.Entry.ID)
+		}
+	}
+
+	for _, invalid := range []string{
+		"other.example.com.\t60\tA\t192.0.2.1\n",
+		"@\t60\tA\t192.0.2.1\n",
+		"www\t60\tPTR\thost.example.com.\n",
+		"www\t60\tCH\tA\t192.0.2.1\n",
+	} {
+		records, err := tc.ParseZoneFile(invalid, domain)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing zone file %q: %v", invalid, err)
+		}
+		if _, _, _, err := importOperations(1, domain, records, existing, typeIDs); err == nil {
+			t.Errorf("Expected an error importing %q", invalid)
+		}
+	}
+}
@@ -20,36 +20,33 @@ package staticdnsentry
  */
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strconv"
-	"time"
+	"strings"
 
+	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/lib/go-tc/tovalidate"
 	"github.com/apache/trafficcontrol/lib/go-util"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
 	validation "github.com/go-ozzo/ozzo-validation"
 	"github.com/go-ozzo/ozzo-validation/is"
 )
 
-type TOStaticDNSEntry struct {
-	api.APIInfoImpl `json:"-"`
-	tc.StaticDNSEntryNullable
-}
-
-func (v *TOStaticDNSEntry) GetLastUpdated() (*time.Time, bool, error) {
-	return api.GetLastUpdated(v.APIInfo().Tx, *v.ID, "staticdnsentry")
-}
-
-func (v *TOStaticDNSEntry) SetLastUpdated(t tc.TimeNoMod) { v.LastUpdated = &t }
-func (v *TOStaticDNSEntry) InsertQuery() string           { return insertQuery() }
-func (v *TOStaticDNSEntry) NewReadObj() interface{}       { return &tc.StaticDNSEntryNullable{} }
-func (v *TOStaticDNSEntry) SelectQuery() string           { return selectQuery() }
-func (v *TOStaticDNSEntry) ParamColumns() map[string]dbhelpers.WhereColumnInfo {
-	return map[string]dbhelpers.WhereColumnInfo{
+// Resource handles the /staticdnsentries endpoint.
+var Resource = api.Resource[entry, *entry]{
+	Type:              resourceType,
+	Table:             "staticdnsentry",
+	Columns:           columns,
+	From:              from,
+	LastUpdatedColumn: "sde.last_updated",
+	Filters: map[string]dbhelpers.WhereColumnInfo{
 		"address":           dbhelpers.WhereColumnInfo{Column: "sde.address"},
 		"cachegroup":        dbhelpers.WhereColumnInfo{Column: "cg.name"},
 		"cachegroupId":      dbhelpers.WhereColumnInfo{Column: "cg.id"},
@@ -60,43 +57,52 @@ func (v *TOStaticDNSEntry) ParamColumns() map[string]dbhelpers.WhereColumnInfo {
 		"ttl":               dbhelpers.WhereColumnInfo{Column: "sde.ttl"},
 		"type":              dbhelpers.WhereColumnInfo{Column: "tp.name"},
 		"typeId":            dbhelpers.WhereColumnInfo{Column: "tp.id"},
-	}
+	},
+	DefaultSort: "host",
+	InsertQuery: insertQuery,
+	UpdateQuery: updateQuery,
+	DeleteQuery: deleteQuery,
+	Validate:    validate,
+	Authorize:   authorize,
 }
-func (v *TOStaticDNSEntry) UpdateQuery() string { return updateQuery() }
-func (v *TOStaticDNSEntry) DeleteQuery() string { return deleteQuery() }
 
-func (staticDNSEntry TOStaticDNSEntry) GetKeyFieldsInfo() []api.KeyFieldInfo {
-	return []api.KeyFieldInfo{{Field: "id", Func: api.GetIntKey}}
+type entry struct {
+	tc.StaticDNSEntryNullable
 }
 
-// Implementation of the Identifier, Validator interface functions
-func (staticDNSEntry TOStaticDNSEntry) GetKeys() (map[string]interface{}, bool) {
-	if staticDNSEntry.ID == nil {
-		return map[string]interface{}{"id": 0}, false
+func (e *entry) GetID() (int, bool) {
+	if e.ID == nil {
+		return 0, false
 	}
-	return map[string]interface{}{"id": *staticDNSEntry.ID}, true
+	return *e.ID, true
 }
 
-func (staticDNSEntry TOStaticDNSEntry) GetAuditName() string {
-	if staticDNSEntry.Host != nil {
-		return *staticDNSEntry.Host
+func (e *entry) SetID(id int)                  { e.ID = &id }
+func (e *entry) SetLastUpdated(t tc.TimeNoMod) { e.LastUpdated = &t }
+
+func (e *entry) GetAuditName() string {
+	if e.Host != nil {
+		return *e.Host
 	}
-	if staticDNSEntry.ID != nil {
-		return strconv.Itoa(*staticDNSEntry.ID)
+	if e.ID != nil {
+		return strconv.Itoa(*e.ID)
 	}
 	return "0"
 }
 
-func (staticDNSEntry TOStaticDNSEntry) GetType() string { return "staticDNSEntry" }
-
-func (staticDNSEntry *TOStaticDNSEntry) SetKeys(keys map[string]interface{}) {
-	i, _ := keys["id"].(int) //this utilizes the non panicking type assertion, if the thrown away ok variable is false i will be the zero of the type, 0 here.
-	staticDNSEntry.ID = &i
+func validate(inf *api.APIInfo, staticDNSEntry *entry) (error, error) {
+	// when updating, the entry's existing record doesn't conflict with itself
+	id := -1
+	if idParam, err := strconv.Atoi(inf.Params["id"]); err == nil {
+		id = idParam
+	}
+	return validateEntry(inf, staticDNSEntry, id)
 }
 
-// Validate fulfills the api.Validator interface.
-func (staticDNSEntry TOStaticDNSEntry) Validate() (error, error) {
-	typeStr, err := tc.ValidateTypeID(staticDNSEntry.ReqInfo.Tx.Tx, &staticDNSEntry.TypeID, "staticdnsentry")
+// validateEntry validates a Static DNS Entry that will have the given ID once
+// it's created or updated, or -1 if it isn't known yet.
+func validateEntry(inf *api.APIInfo, staticDNSEntry *entry, id int) (error, error) {
+	typeStr, err := tc.ValidateTypeID(inf.Tx.Tx, &staticDNSEntry.TypeID, "staticdnsentry")
 	if err != nil {
 		return err, nil
 	}
@@ -105,9 +111,11 @@ func (staticDNSEntry TOStaticDNSEntry) Validate() (error, error) {
 	switch typeStr {
 	case "A_RECORD":
 		addressErr = validation.Validate(staticDNSEntry.Address, validation.Required, is.IPv4)
+		normalizeAddress(staticDNSEntry, addressErr)
 	case "AAAA_RECORD":
 		addressErr = validation.Validate(staticDNSEntry.Address, validation.Required, is.IPv6)
-	case "CNAME_RECORD":
+		normalizeAddress(staticDNSEntry, addressErr)
+	case cnameRecordType:
 		addressErr = validation.Validate(staticDNSEntry.Address, validation.Required, is.DNSName)
 		address := *staticDNSEntry.Address
 		if addressErr == nil {
@@ -116,6 +124,12 @@ func (staticDNSEntry TOStaticDNSEntry) Validate() (error, error) {
 				addressErr = fmt.Errorf("for type: CNAME_RECORD must have a trailing period")
 			}
 		}
+	case txtRecordType:
+		addressErr = validation.Validate(staticDNSEntry.Address, validation.Required, validation.By(validateTXT))
+	case srvRecordType:
+		addressErr = validation.Validate(staticDNSEntry.Address, validation.Required, validation.By(validateSRV))
+	case mxRecordType:
+		addressErr = validation.Validate(staticDNSEntry.Address, validation.Required, validation.By(validateMX))
 	default:
 		addressErr = validation.Validate(staticDNSEntry.Address, validation.Required)
 	}
@@ -128,8 +142,28 @@ func (staticDNSEntry TOStaticDNSEntry) Validate() (error, error) {
 		ttlErr = validation.Validate(staticDNSEntry.TTL, validation.Required)
 	}
 
+	hostErr := validation.Validate(staticDNSEntry.Host, validation.Required, validation.By(validateHost))
+	if hostErr == nil && typeStr == srvRecordType {
+		hostErr = validateSRVHost(*staticDNSEntry.Host)
+	}
+	if hostErr == nil && staticDNSEntry.DeliveryServiceID != nil {
+		var sysErr error
+		hostErr, sysErr = checkHostConflicts(inf, *staticDNSEntry.DeliveryServiceID, *staticDNSEntry.Host, typeStr, id)
+		if sysErr != nil {
+			return nil, sysErr
+		}
+	}
+
+	if ttlErr == nil && staticDNSEntry.DeliveryServiceID != nil {
+		var sysErr error
+		ttlErr, sysErr = checkTTLPolicy(inf, *staticDNSEntry.DeliveryServiceID, *staticDNSEntry.TTL)
+		if sysErr != nil {
+			return nil, sysErr
+		}
+	}
+
 	errs := validation.Errors{
-		"host":              validation.Validate(staticDNSEntry.Host, validation.Required, is.DNSName),
+		"host":              hostErr,
 		"address":           addressErr,
 		"deliveryserviceId": validation.Validate(staticDNSEntry.DeliveryServiceID, validation.Required),
 		"ttl":               ttlErr,
@@ -138,74 +172,233 @@ func (staticDNSEntry TOStaticDNSEntry) Validate() (error, error) {
 	return util.JoinErrs(tovalidate.ToErrors(errs)), nil
 }
 
-func (en *TOStaticDNSEntry) Read(h http.Header, useIMS bool) ([]interface{}, error, error, int, *time.Time) {
-	api.DefaultSort(en.APIInfo(), "host")
-	return api.GenericRead(h, en, useIMS)
+// normalizeAddress replaces the IP address of an A or AAAA record with its
+// canonical form if it's valid - as indicated by the given validation error -
+// so that the same address is always stored the same way.
+func normalizeAddress(staticDNSEntry *entry, addressErr error) {
+	if addressErr != nil {
+		return
+	}
+	if addr, err := tc.CanonicalIP(*staticDNSEntry.Address); err == nil {
+		staticDNSEntry.Address = &addr
+	}
 }
-func (en *TOStaticDNSEntry) Create() (error, error, int) {
-	var cdnName tc.CDNName
-	var err error
-	if en.DeliveryServiceID != nil {
-		_, cdnName, _, err = dbhelpers.GetDSNameAndCDNFromID(en.ReqInfo.Tx.Tx, *en.DeliveryServiceID)
-		if err != nil {
-			return nil, err, http.StatusInternalServerError
-		}
-		userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(en.ReqInfo.Tx.Tx, string(cdnName), en.ReqInfo.User.UserName)
-		if userErr != nil || sysErr != nil {
-			return userErr, sysErr, errCode
+
+// validateHost checks that a host is a DNS name, optionally with a wildcard
+// ("*") as its leftmost label, e.g. "*.assets".
+func validateHost(value interface{}) error {
+	host, ok := value.(*string)
+	if !ok || host == nil {
+		return nil
+	}
+	name := *host
+	if name == wildcardLabel {
+		return nil
+	}
+	name = strings.TrimPrefix(name, wildcardLabel+".")
+	if strings.Contains(name, wildcardLabel) {
+		return errors.New("may only contain a wildcard as its leftmost label")
+	}
+	return is.DNSName.Validate(name)
+}
+
+// validateSRVHost checks that the host of an SRV record starts with the
+// service and protocol labels, e.g. "_sip._tcp" or "_sip._tcp.voice".
+func validateSRVHost(host string) error {
+	labels := strings.Split(host, ".")
+	if len(labels) < 2 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+		return errors.New("for type: SRV_RECORD must start with the service and protocol, e.g. '_sip._tcp'")
+	}
+	return nil
+}
+
+// validateTXT checks that the address of a TXT record is printable ASCII.
+// Traffic Router splits values too long for a single DNS character-string
+// into several.
+func validateTXT(value interface{}) error {
+	address, ok := value.(*string)
+	if !ok || address == nil {
+		return nil
+	}
+	for _, r := range *address {
+		if r < ' ' || r > '~' {
+			return errors.New("for type: TXT_RECORD must only contain printable ASCII characters")
 		}
 	}
-	return api.GenericCreate(en)
+	return nil
 }
-func (en *TOStaticDNSEntry) Update(h http.Header) (error, error, int) {
-	var cdnName tc.CDNName
-	var err error
-	if en.DeliveryServiceID != nil {
-		_, cdnName, _, err = dbhelpers.GetDSNameAndCDNFromID(en.ReqInfo.Tx.Tx, *en.DeliveryServiceID)
-		if err != nil {
-			return nil, err, http.StatusInternalServerError
+
+// validateSRV checks that the address of an SRV record is its priority,
+// weight, port, and target, separated by spaces, e.g.
+// "10 60 5060 sip.example.com.".
+func validateSRV(value interface{}) error {
+	address, ok := value.(*string)
+	if !ok || address == nil {
+		return nil
+	}
+	fields := strings.Fields(*address)
+	if len(fields) != 4 {
+		return errors.New("for type: SRV_RECORD must be '{{priority}} {{weight}} {{port}} {{target}}'")
+	}
+	for i, name := range []string{"priority", "weight", "port"} {
+		if err := validateUint16(fields[i]); err != nil {
+			return fmt.Errorf("for type: SRV_RECORD %s %w", name, err)
 		}
-		userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(en.ReqInfo.Tx.Tx, string(cdnName), en.ReqInfo.User.UserName)
-		if userErr != nil || sysErr != nil {
-			return userErr, sysErr, errCode
+	}
+	// a target of "." means the service isn't available at the domain
+	if fields[3] == "." {
+		return nil
+	}
+	if err := validateTarget(fields[3]); err != nil {
+		return fmt.Errorf("for type: SRV_RECORD target %w", err)
+	}
+	return nil
+}
+
+// validateMX checks that the address of an MX record is its preference and
+// mail exchange, separated by a space, e.g. "10 mail.example.com.".
+func validateMX(value interface{}) error {
+	address, ok := value.(*string)
+	if !ok || address == nil {
+		return nil
+	}
+	fields := strings.Fields(*address)
+	if len(fields) != 2 {
+		return errors.New("for type: MX_RECORD must be '{{preference}} {{exchange}}'")
+	}
+	if err := validateUint16(fields[0]); err != nil {
+		return fmt.Errorf("for type: MX_RECORD preference %w", err)
+	}
+	if err := validateTarget(fields[1]); err != nil {
+		return fmt.Errorf("for type: MX_RECORD exchange %w", err)
+	}
+	return nil
+}
+
+func validateUint16(field string) error {
+	if _, err := strconv.ParseUint(field, 10, 16); err != nil {
+		return fmt.Errorf("must be an integer from 0 to 65535, got '%s'", field)
+	}
+	return nil
+}
+
+// validateTarget checks that the name an SRV or MX record points to is a
+// fully qualified domain name - like the address of a CNAME record, it must
+// have a trailing period.
+func validateTarget(target string) error {
+	if err := is.DNSName.Validate(target); err != nil {
+		return fmt.Errorf("must be a valid DNS name: %w", err)
+	}
+	if !strings.HasSuffix(target, ".") {
+		return errors.New("must have a trailing period")
+	}
+	if net.ParseIP(strings.TrimSuffix(target, ".")) != nil {
+		return errors.New("must be a DNS name, not an IP address")
+	}
+	return nil
+}
+
+// checkHostConflicts checks that a Static DNS Entry with the given host and
+// type can be added to the Delivery Service identified by dsID: it must not
+// shadow the Delivery Service's routing name, and it must not break the rule
+// that a CNAME record can't share its name with any other record. Other
+// records may share a host and type, in which case Traffic Router serves all
+// of them, in a random order. The entry with the given ID, if any, is the one
+// being checked, which doesn't conflict with itself.
+func checkHostConflicts(inf *api.APIInfo, dsID int, host string, typeStr string, id int) (error, error) {
+	var routingName string
+	if err := inf.Tx.Tx.QueryRow(routingNameQuery, dsID).Scan(&routingName); errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("getting routing name of Delivery Service #%d: %w", dsID, err)
+	}
+	if host == wildcardLabel || strings.EqualFold(host, routingName) {
+		return fmt.Errorf("conflicts with the Delivery Service's routing name '%s'", routingName), nil
+	}
+
+	rows, err := inf.Tx.Tx.Query(sameHostTypesQuery, dsID, host, id)
+	if err != nil {
+		return nil, fmt.Errorf("getting types of static DNS entries with host '%s': %w", host, err)
+	}
+	defer log.Close(rows, "closing static DNS entry host rows")
+	others := 0
+	hasCNAME := false
+	for rows.Next() {
+		var otherType string
+		if err := rows.Scan(&otherType); err != nil {
+			return nil, fmt.Errorf("scanning static DNS entry type: %w", err)
 		}
+		others++
+		hasCNAME = hasCNAME || otherType == cnameRecordType
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over static DNS entry types: %w", err)
+	}
+	if hasCNAME {
+		return errors.New("already has a CNAME_RECORD entry, which can't share its host with other entries"), nil
+	}
+	if typeStr == cnameRecordType && others > 0 {
+		return errors.New("a CNAME_RECORD entry can't share its host with other entries"), nil
+	}
+	return nil, nil
+}
+
+// checkTTLPolicy checks that ttl is within the bounds of the Static DNS Entry
+// TTL policy of the CDN of the Delivery Service identified by dsID, if it has
+// one. Users with the STATIC-DNS-ENTRY:OVERRIDE-TTL-POLICY Permission may
+// bypass the policy by passing the overrideTTLPolicy query parameter.
+func checkTTLPolicy(inf *api.APIInfo, dsID int, ttl int64) (error, error) {
+	var policy tc.StaticDNSEntryTTLPolicy
+	var hasPolicy bool
+	err := inf.Tx.Tx.QueryRow(ttlPolicyQuery, dsID).Scan(&policy.CDNName, &hasPolicy, &policy.MinTTL, &policy.MaxTTL)
+	if errors.Is(err, sql.ErrNoRows) {
+		// the Delivery Service doesn't exist; the insert or update will fail
+		// on the foreign key.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("getting static DNS entry TTL policy for Delivery Service #%d: %w", dsID, err)
 	}
-	return api.GenericUpdate(h, en)
+	if !hasPolicy || policy.Permits(ttl) {
+		return nil, nil
+	}
+	if override, _ := strconv.ParseBool(inf.Params[overrideTTLPolicyParam]); override && canOverrideTTLPolicy(inf) {
+		return nil, nil
+	}
+	return fmt.Errorf("must be %s for CDN %s", policy.BoundsString(), policy.CDNName), nil
 }
-func (en *TOStaticDNSEntry) Delete() (error, error, int) {
-	var cdnName tc.CDNName
-	var err error
+
+func canOverrideTTLPolicy(inf *api.APIInfo) bool {
+	if inf.Config != nil && inf.Config.RoleBasedPermissions {
+		return inf.User.Can("STATIC-DNS-ENTRY:OVERRIDE-TTL-POLICY")
+	}
+	return inf.User.PrivLevel >= auth.PrivLevelAdmin
+}
+
+// authorize checks that the user may modify the CDN of the Delivery Service
+// to which the entry belongs - or, for deletions, to which it belonged.
+func authorize(inf *api.APIInfo, en *entry) (error, error, int) {
 	var dsID int
 	if en.DeliveryServiceID != nil {
 		dsID = *en.DeliveryServiceID
 	} else if en.ID != nil {
-		dsID, err = dbhelpers.GetDSIDFromStaticDNSEntry(en.ReqInfo.Tx.Tx, *en.ID)
+		var err error
+		dsID, err = dbhelpers.GetDSIDFromStaticDNSEntry(inf.Tx.Tx, *en.ID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return errors.New("no " + resourceType + " with that key found"), nil, http.StatusNotFound
+		}
 		if err != nil {
 			return nil, errors.New("couldn't get DS ID from static dns entry ID: " + err.Error()), http.StatusInternalServerError
 		}
 	}
-	_, cdnName, _, err = dbhelpers.GetDSNameAndCDNFromID(en.ReqInfo.Tx.Tx, dsID)
+	_, cdnName, _, err := dbhelpers.GetDSNameAndCDNFromID(inf.Tx.Tx, dsID)
 	if err != nil {
 		return nil, err, http.StatusInternalServerError
 	}
-	userErr, sysErr, errCode := dbhelpers.CheckIfCurrentUserCanModifyCDN(en.ReqInfo.Tx.Tx, string(cdnName), en.ReqInfo.User.UserName)
-	if userErr != nil || sysErr != nil {
-		return userErr, sysErr, errCode
-	}
-	return api.GenericDelete(en)
-}
-func (v *TOStaticDNSEntry) SelectMaxLastUpdatedQuery(where, orderBy, pagination, tableName string) string {
-	return `SELECT max(t) from (
-		SELECT max(sde.last_updated) as t FROM staticdnsentry as sde
-JOIN type as tp on sde.type = tp.id
-LEFT JOIN cachegroup as cg ON sde.cachegroup = cg.id
-JOIN deliveryservice as ds on sde.deliveryservice = ds.id ` + where + orderBy + pagination +
-		` UNION ALL
-	select max(last_updated) as t from last_deleted l where l.table_name='staticdnsentry') as res`
+	return dbhelpers.CheckIfCurrentUserCanModifyCDN(inf.Tx.Tx, string(cdnName), inf.User.UserName)
 }
 
-func insertQuery() string {
-	query := `INSERT INTO staticdnsentry (
+const insertQuery = `INSERT INTO staticdnsentry (
 address,
 deliveryservice,
 cachegroup,
@@ -218,11 +411,8 @@ ttl) VALUES (
 :host,
 :type_id,
 :ttl) RETURNING id,last_updated`
-	return query
-}
 
-func updateQuery() string {
-	query := `UPDATE
+const updateQuery = `UPDATE
 staticdnsentry SET
 id=:id,
 address=:address,
@@ -232,12 +422,8 @@ host=:host,
 type=:type_id,
 ttl=:ttl
 WHERE id=:id RETURNING last_updated`
-	return query
-}
 
-func selectQuery() string {
-	return `SELECT
-ds.xml_id as dsname,
+const columns = `ds.xml_id as dsname,
 sde.host,
 sde.id as id,
 sde.deliveryservice as deliveryservice_id,
@@ -247,16 +433,49 @@ sde.last_updated,
 tp.id as type_id,
 tp.name as type,
 cg.id as cachegroup_id,
-cg.name as cachegroup
-FROM staticdnsentry as sde
+cg.name as cachegroup`
+
+const from = `FROM staticdnsentry as sde
 JOIN type as tp on sde.type = tp.id
 LEFT JOIN cachegroup as cg ON sde.cachegroup = cg.id
 JOIN deliveryservice as ds on sde.deliveryservice = ds.id
 `
-}
 
-func deleteQuery() string {
-	query := `DELETE FROM staticdnsentry
+// resourceType is the name of Static DNS Entries in alerts and change log
+// entries.
+const resourceType = "staticDNSEntry"
+
+const wildcardLabel = "*"
+
+const cnameRecordType = "CNAME_RECORD"
+const txtRecordType = "TXT_RECORD"
+const srvRecordType = "SRV_RECORD"
+const mxRecordType = "MX_RECORD"
+
+const routingNameQuery = `
+SELECT routing_name
+FROM deliveryservice
+WHERE id = $1
+`
+
+const sameHostTypesQuery = `
+SELECT tp.name
+FROM staticdnsentry AS sde
+JOIN type AS tp ON tp.id = sde.type
+WHERE sde.deliveryservice = $1
+AND lower(sde.host) = lower($2)
+AND sde.id <> $3
+`
+
+const overrideTTLPolicyParam = "overrideTTLPolicy"
+
+const ttlPolicyQuery = `
+SELECT c.name, p.cdn IS NOT NULL, p.min_ttl, p.max_ttl
+FROM deliveryservice AS ds
+JOIN cdn AS c ON c.id = ds.cdn_id
+LEFT JOIN cdn_static_dns_ttl_policy AS p ON p.cdn = c.id
+WHERE ds.id = $1
+`
+
+const deleteQuery = `DELETE FROM staticdnsentry
 WHERE id=:id`
-	return query
-}
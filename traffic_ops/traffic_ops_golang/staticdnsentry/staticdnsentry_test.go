@@ -27,45 +27,24 @@ import (
 
 	util "github.com/apache/trafficcontrol/lib/go-util"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/test"
 	"github.com/jmoiron/sqlx"
 	"gopkg.in/DATA-DOG/go-sqlmock.v1"
 )
 
-func TestFuncs(t *testing.T) {
-	if strings.Index(selectQuery(), "SELECT") != 0 {
-		t.Errorf("expected selectQuery to start with SELECT")
-	}
-	if strings.Index(insertQuery(), "INSERT") != 0 {
+func TestQueries(t *testing.T) {
+	if strings.Index(insertQuery, "INSERT") != 0 {
 		t.Errorf("expected insertQuery to start with INSERT")
 	}
-	if strings.Index(updateQuery(), "UPDATE") != 0 {
+	if strings.Index(updateQuery, "UPDATE") != 0 {
 		t.Errorf("expected updateQuery to start with UPDATE")
 	}
-	if strings.Index(deleteQuery(), "DELETE") != 0 {
+	if strings.Index(deleteQuery, "DELETE") != 0 {
 		t.Errorf("expected deleteQuery to start with DELETE")
 	}
-
-}
-
-func TestInterfaces(t *testing.T) {
-	var i interface{}
-	i = &TOStaticDNSEntry{}
-
-	if _, ok := i.(api.Creator); !ok {
-		t.Errorf("staticDNSEntry must be creator")
-	}
-	if _, ok := i.(api.Reader); !ok {
-		t.Errorf("staticDNSEntry must be reader")
-	}
-	if _, ok := i.(api.Updater); !ok {
-		t.Errorf("staticDNSEntry must be updater")
-	}
-	if _, ok := i.(api.Deleter); !ok {
-		t.Errorf("staticDNSEntry must be deleter")
-	}
-	if _, ok := i.(api.Identifier); !ok {
-		t.Errorf("staticDNSEntry must be Identifier")
+	if strings.Index(from, "FROM") != 0 {
+		t.Errorf("expected from to start with FROM")
 	}
 }
 
@@ -88,8 +67,7 @@ func TestValidate(t *testing.T) {
 
 	reqInfo := api.APIInfo{Tx: tx}
 	// invalid name, empty domainname
-	ts := TOStaticDNSEntry{APIInfoImpl: api.APIInfoImpl{ReqInfo: &reqInfo}}
-	err, _ = ts.Validate()
+	err, _ = validate(&reqInfo, &entry{})
 	errs := util.JoinErrsStr(test.SortErrors(test.SplitErrors(err)))
 
 	expectedErrs := util.JoinErrsStr([]error{
@@ -104,3 +82,184 @@ func TestValidate(t *testing.T) {
 		t.Errorf("expected %s, GOT %s", expectedErrs, errs)
 	}
 }
+
+func TestCheckTTLPolicy(t *testing.T) {
+	min := int64(30)
+	max := int64(3600)
+	tests := []struct {
+		name      string
+		ttl       int64
+		hasPolicy bool
+		override  string
+		privLevel int
+		expectErr bool
+	}{
+		{name: "no policy", ttl: 0},
+		{name: "within bounds", ttl: 300, hasPolicy: true},
+		{name: "below minimum", ttl: 0, hasPolicy: true, expectErr: true},
+		{name: "above maximum", ttl: 86400, hasPolicy: true, expectErr: true},
+		{name: "override by admin", ttl: 0, hasPolicy: true, override: "true", privLevel: auth.PrivLevelAdmin},
+		{name: "override by operator", ttl: 0, hasPolicy: true, override: "true", privLevel: auth.PrivLevelOperations, expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"name", "has_policy", "min_ttl", "max_ttl"})
+			if tt.hasPolicy {
+				rows.AddRow("cdn1", true, min, max)
+			} else {
+				rows.AddRow("cdn1", false, nil, nil)
+			}
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT").WithArgs(1).WillReturnRows(rows)
+			tx := db.MustBegin()
+
+			inf := api.APIInfo{
+				Tx:     tx,
+				Params: map[string]string{overrideTTLPolicyParam: tt.override},
+				User:   &auth.CurrentUser{PrivLevel: tt.privLevel},
+			}
+			userErr, sysErr := checkTTLPolicy(&inf, 1, tt.ttl)
+			if sysErr != nil {
+				t.Fatalf("unexpected system error: %v", sysErr)
+			}
+			if tt.expectErr && userErr == nil {
+				t.Error("expected a user error, got none")
+			} else if !tt.expectErr && userErr != nil {
+				t.Errorf("unexpected user error: %v", userErr)
+			}
+		})
+	}
+}
+
+func TestValidateHost(t *testing.T) {
+	valid := []string{"assets", "*", "*.assets", "a.b.c"}
+	for _, host := range valid {
+		host := host
+		if err := validateHost(&host); err != nil {
+			t.Errorf("expected host '%s' to be valid, got error: %v", host, err)
+		}
+	}
+	invalid := []string{"a.*.b", "*assets", "assets.*", "**.assets", "as_sets!"}
+	for _, host := range invalid {
+		host := host
+		if err := validateHost(&host); err == nil {
+			t.Errorf("expected host '%s' to be invalid, got no error", host)
+		}
+	}
+}
+
+func TestValidateRecordAddresses(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(interface{}) error
+		valid    []string
+		invalid  []string
+	}{
+		{
+			name:     txtRecordType,
+			validate: validateTXT,
+			valid:    []string{"v=spf1 -all", "_acme-challenge token", strings.Repeat("a", 300)},
+			invalid:  []string{"tab\there", "caf\u00e9"},
+		},
+		{
+			name:     srvRecordType,
+			validate: validateSRV,
+			valid:    []string{"10 60 5060 sip.example.com.", "0 0 0 .", "65535 65535 65535 a."},
+			invalid:  []string{"10 60 5060", "10 60 5060 sip.example.com", "10 60 65536 sip.example.com.", "-1 60 5060 sip.example.com.", "10 60 5060 192.0.2.1.", "a b c d."},
+		},
+		{
+			name:     mxRecordType,
+			validate: validateMX,
+			valid:    []string{"10 mail.example.com.", "0 mx."},
+			invalid:  []string{"mail.example.com.", "10 mail.example.com", "10 .", "10 192.0.2.1.", "70000 mail.example.com.", "10 mail.example.com. extra"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, address := range tt.valid {
+				address := address
+				if err := tt.validate(&address); err != nil {
+					t.Errorf("expected address '%s' to be valid, got error: %v", address, err)
+				}
+			}
+			for _, address := range tt.invalid {
+				address := address
+				if err := tt.validate(&address); err == nil {
+					t.Errorf("expected address '%s' to be invalid, got no error", address)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateSRVHost(t *testing.T) {
+	for _, host := range []string{"_sip._tcp", "_sip._udp.voice"} {
+		if err := validateSRVHost(host); err != nil {
+			t.Errorf("expected SRV host '%s' to be valid, got error: %v", host, err)
+		}
+	}
+	for _, host := range []string{"sip", "_sip", "sip._tcp", "_sip.tcp", "*._tcp"} {
+		if err := validateSRVHost(host); err == nil {
+			t.Errorf("expected SRV host '%s' to be invalid, got no error", host)
+		}
+	}
+}
+
+func TestCheckHostConflicts(t *testing.T) {
+	tests := []struct {
+		name       string
+		host       string
+		typeStr    string
+		otherTypes []string
+		expectErr  bool
+	}{
+		{name: "no other entries", host: "assets", typeStr: "A_RECORD"},
+		{name: "round-robin A records", host: "assets", typeStr: "A_RECORD", otherTypes: []string{"A_RECORD", "AAAA_RECORD"}},
+		{name: "wildcard", host: "*.assets", typeStr: "A_RECORD"},
+		{name: "routing name", host: "CDN", typeStr: "A_RECORD", expectErr: true},
+		{name: "bare wildcard", host: "*", typeStr: "A_RECORD", expectErr: true},
+		{name: "CNAME with other entries", host: "assets", typeStr: cnameRecordType, otherTypes: []string{"A_RECORD"}, expectErr: true},
+		{name: "entry beside CNAME", host: "assets", typeStr: "A_RECORD", otherTypes: []string{cnameRecordType}, expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer mockDB.Close()
+
+			db := sqlx.NewDb(mockDB, "sqlmock")
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT routing_name").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"routing_name"}).AddRow("cdn"))
+			rows := sqlmock.NewRows([]string{"name"})
+			for _, otherType := range tt.otherTypes {
+				rows.AddRow(otherType)
+			}
+			mock.ExpectQuery("SELECT tp.name").WithArgs(1, tt.host, 7).WillReturnRows(rows)
+			tx := db.MustBegin()
+
+			inf := api.APIInfo{Tx: tx}
+			userErr, sysErr := checkHostConflicts(&inf, 1, tt.host, tt.typeStr, 7)
+			if sysErr != nil {
+				t.Fatalf("unexpected system error: %v", sysErr)
+			}
+			if tt.expectErr && userErr == nil {
+				t.Error("expected a user error, got none")
+			} else if !tt.expectErr && userErr != nil {
+				t.Errorf("unexpected user error: %v", userErr)
+			}
+		})
+	}
+}
@@ -96,10 +96,44 @@ func findSteering(tx *sql.Tx) ([]tc.Steering, error) {
 			target.Latitude = util.FloatPtr(primaryOriginCoords[data.TargetID].Lat)
 			target.Longitude = util.FloatPtr(primaryOriginCoords[data.TargetID].Lon)
 		}
+		if data.Latitude != nil && data.Longitude != nil {
+			// Coordinates set on the target itself take precedence over its
+			// primary origin's, and enable geo-ordering for any target type.
+			target.Latitude = util.FloatPtr(*data.Latitude)
+			target.Longitude = util.FloatPtr(*data.Longitude)
+		}
+		if len(data.GeoLimitCountries) > 0 {
+			target.GeoLimitCountries = data.GeoLimitCountries
+		}
 		steering.Targets = append(steering.Targets, target)
 		steerings[data.DeliveryService] = steering
 	}
 
+	externalTargets, err := getExternalTargets(tx)
+	if err != nil {
+		return nil, err
+	}
+	for _, et := range externalTargets {
+		steering, ok := steerings[et.DeliveryService]
+		if !ok {
+			steering = tc.Steering{
+				DeliveryService: et.DeliveryService,
+				ClientSteering:  et.DSType == tc.DSTypeClientSteering,
+				Filters:         []tc.SteeringFilter{},
+				Targets:         []tc.SteeringSteeringTarget{},
+			}
+		}
+		target := tc.SteeringSteeringTarget{DeliveryService: tc.DeliveryServiceName(et.Name), ExternalFQDN: et.FQDN}
+		switch et.Type {
+		case tc.SteeringTypeOrder:
+			target.Order = int32(et.Value)
+		case tc.SteeringTypeWeight:
+			target.Weight = int32(et.Value)
+		}
+		steering.Targets = append(steering.Targets, target)
+		steerings[et.DeliveryService] = steering
+	}
+
 	arr := []tc.Steering{}
 	for _, steering := range steerings {
 		arr = append(arr, steering)
@@ -113,13 +147,16 @@ func findSteering(tx *sql.Tx) ([]tc.Steering, error) {
 }
 
 type SteeringData struct {
-	DeliveryService tc.DeliveryServiceName
-	SteeringID      int
-	TargetName      tc.DeliveryServiceName
-	TargetID        int
-	Value           int
-	Type            tc.SteeringType
-	DSType          tc.DSType
+	DeliveryService   tc.DeliveryServiceName
+	SteeringID        int
+	TargetName        tc.DeliveryServiceName
+	TargetID          int
+	Value             int
+	Type              tc.SteeringType
+	DSType            tc.DSType
+	Latitude          *float64
+	Longitude         *float64
+	GeoLimitCountries []string
 }
 
 func steeringDataTargetIDs(data []SteeringData) []int {
@@ -139,7 +176,10 @@ SELECT
   t.id as target_id,
   st.value,
   tp.name as steering_type,
-  dt.name as ds_type
+  dt.name as ds_type,
+  st.latitude,
+  st.longitude,
+  st.geo_limit_countries
 FROM
   steering_target st
   JOIN deliveryservice ds on ds.id = st.deliveryservice
@@ -158,7 +198,7 @@ ORDER BY
 	data := []SteeringData{}
 	for rows.Next() {
 		sd := SteeringData{}
-		if err := rows.Scan(&sd.DeliveryService, &sd.SteeringID, &sd.TargetName, &sd.TargetID, &sd.Value, &sd.Type, &sd.DSType); err != nil {
+		if err := rows.Scan(&sd.DeliveryService, &sd.SteeringID, &sd.TargetName, &sd.TargetID, &sd.Value, &sd.Type, &sd.DSType, &sd.Latitude, &sd.Longitude, pq.Array(&sd.GeoLimitCountries)); err != nil {
 			return nil, errors.New("get steering data scanning: " + err.Error())
 		}
 		data = append(data, sd)
@@ -166,6 +206,51 @@ ORDER BY
 	return data, nil
 }
 
+// ExternalTarget is a steering external target, along with the XMLID and Type
+// of the Delivery Service it's a target of.
+type ExternalTarget struct {
+	DeliveryService tc.DeliveryServiceName
+	DSType          tc.DSType
+	Name            string
+	FQDN            string
+	Type            tc.SteeringType
+	Value           int
+}
+
+func getExternalTargets(tx *sql.Tx) ([]ExternalTarget, error) {
+	qry := `
+SELECT
+  ds.xml_id,
+  dt.name,
+  et.name,
+  et.fqdn,
+  tp.name,
+  et.value
+FROM
+  steering_external_target et
+  JOIN deliveryservice ds ON ds.id = et.deliveryservice
+  JOIN type tp ON tp.id = et.type
+  JOIN type dt ON dt.id = ds.type
+ORDER BY
+  ds.xml_id,
+  et.name
+`
+	rows, err := tx.Query(qry)
+	if err != nil {
+		return nil, errors.New("querying steering external targets: " + err.Error())
+	}
+	defer rows.Close()
+	targets := []ExternalTarget{}
+	for rows.Next() {
+		et := ExternalTarget{}
+		if err := rows.Scan(&et.DeliveryService, &et.DSType, &et.Name, &et.FQDN, &et.Type, &et.Value); err != nil {
+			return nil, errors.New("scanning steering external targets: " + err.Error())
+		}
+		targets = append(targets, et)
+	}
+	return targets, nil
+}
+
 // getSteeringFilters takes a slice of ds ids, and returns a map of delivery service ids to patterns and delivery service names.
 func getSteeringFilters(tx *sql.Tx, dsIDs []int) (map[int][]tc.SteeringFilter, error) {
 	qry := `
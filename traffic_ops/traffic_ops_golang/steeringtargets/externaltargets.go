@@ -0,0 +1,184 @@
+package steeringtargets
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+)
+
+const selectExternalTargetsQuery = `
+SELECT et."name", et.fqdn, et.health_check_url, tp."name", et."type", et."value", et.last_updated
+FROM steering_external_target AS et
+JOIN "type" AS tp ON tp.id = et."type"
+WHERE et.deliveryservice = $1
+ORDER BY et."name"
+`
+
+const deleteExternalTargetsQuery = `
+DELETE FROM steering_external_target
+WHERE deliveryservice = $1
+`
+
+const insertExternalTargetQuery = `
+INSERT INTO steering_external_target ("name", deliveryservice, fqdn, health_check_url, "type", "value")
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+// GetExternalTargets is the handler for GET requests to
+// /steering/{{deliveryservice}}/external-targets.
+func GetExternalTargets(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"deliveryservice"}, []string{"deliveryservice"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["deliveryservice"]
+
+	userErr, sysErr, errCode = tenant.CheckID(tx, inf.User, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	targets, userErr, sysErr, errCode := getExternalTargets(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	api.WriteResp(w, r, targets)
+}
+
+// UpdateExternalTargets is the handler for PUT requests to
+// /steering/{{deliveryservice}}/external-targets, which replaces all of a
+// CLIENT_STEERING Delivery Service's external targets.
+func UpdateExternalTargets(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"deliveryservice"}, []string{"deliveryservice"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	dsID := inf.IntParams["deliveryservice"]
+
+	userErr, sysErr, errCode = tenant.CheckID(tx, inf.User, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	dsType, cdn, ok, err := dbhelpers.GetDeliveryServiceTypeAndCDNName(dsID, tx)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting Delivery Service Type and CDN: "+err.Error()))
+		return
+	} else if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no Delivery Service exists by ID '%d'", dsID), nil)
+		return
+	}
+	if dsType != tc.DSTypeClientSteering {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, fmt.Errorf("only %s Delivery Services can have external targets", tc.DSTypeClientSteering), nil)
+		return
+	}
+	userErr, sysErr, errCode = dbhelpers.CheckIfCurrentUserCanModifyCDN(tx, cdn, inf.User.UserName)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var req tc.SteeringExternalTargetsRequest
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	if _, err := tx.Exec(deleteExternalTargetsQuery, dsID); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("deleting steering external targets: "+err.Error()))
+		return
+	}
+	for _, target := range req.Targets {
+		if _, err := tx.Exec(insertExternalTargetQuery, target.Name, dsID, target.FQDN, target.HealthCheckURL, target.TypeID, target.Value); err != nil {
+			userErr, sysErr, errCode := api.ParseDBError(err)
+			api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+			return
+		}
+	}
+
+	targets, userErr, sysErr, errCode := getExternalTargets(tx, dsID)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	msg := fmt.Sprintf("Delivery Service '%s' external targets replaced with %d targets", targets.XMLID, len(req.Targets))
+	api.CreateChangeLogRawTx(api.ApiChange, "DS: "+targets.XMLID+", ID: "+strconv.Itoa(dsID)+", ACTION: Replaced steering external targets with "+strconv.Itoa(len(req.Targets))+" targets", inf.User, tx)
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, msg+"; perform a CDN Snapshot to have Traffic Monitor start polling them", targets)
+}
+
+// getExternalTargets returns the external targets of the Delivery Service with
+// the given ID, along with a user error, system error, and status code.
+func getExternalTargets(tx *sql.Tx, dsID int) (tc.SteeringExternalTargets, error, error, int) {
+	dsName, _, ok, err := dbhelpers.GetDSNameAndCDNFromID(tx, dsID)
+	if err != nil {
+		return tc.SteeringExternalTargets{}, nil, errors.New("getting Delivery Service name: " + err.Error()), http.StatusInternalServerError
+	} else if !ok {
+		return tc.SteeringExternalTargets{}, fmt.Errorf("no Delivery Service exists by ID '%d'", dsID), nil, http.StatusNotFound
+	}
+
+	rows, err := tx.Query(selectExternalTargetsQuery, dsID)
+	if err != nil {
+		return tc.SteeringExternalTargets{}, nil, errors.New("querying steering external targets: " + err.Error()), http.StatusInternalServerError
+	}
+	defer log.Close(rows, "closing steering external targets rows")
+
+	targets := tc.SteeringExternalTargets{
+		DeliveryServiceID: dsID,
+		XMLID:             string(dsName),
+		Targets:           []tc.SteeringExternalTarget{},
+	}
+	for rows.Next() {
+		var target tc.SteeringExternalTarget
+		var lastUpdated time.Time
+		if err := rows.Scan(&target.Name, &target.FQDN, &target.HealthCheckURL, &target.Type, &target.TypeID, &target.Value, &lastUpdated); err != nil {
+			return tc.SteeringExternalTargets{}, nil, errors.New("scanning steering external targets: " + err.Error()), http.StatusInternalServerError
+		}
+		targets.Targets = append(targets.Targets, target)
+		if targets.LastUpdated == nil || lastUpdated.After(*targets.LastUpdated) {
+			lu := lastUpdated
+			targets.LastUpdated = &lu
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return tc.SteeringExternalTargets{}, nil, errors.New("iterating over steering external targets: " + err.Error()), http.StatusInternalServerError
+	}
+	return targets, nil, nil, http.StatusOK
+}
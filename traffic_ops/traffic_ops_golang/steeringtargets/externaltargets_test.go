@@ -0,0 +1,98 @@
+package steeringtargets
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestGetExternalTargets(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	earlier := time.Date(2022, 5, 17, 12, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT ds.xml_id, cdn.name").WithArgs(2).WillReturnRows(sqlmock.NewRows([]string{"xml_id", "name"}).AddRow("steering", "cdn1"))
+	rows := sqlmock.NewRows([]string{"name", "fqdn", "health_check_url", "type_name", "type", "value", "last_updated"}).
+		AddRow("other-cdn", "example.other-cdn.net", "https://example.other-cdn.net/health", "STEERING_ORDER", 40, 1, later).
+		AddRow("third-cdn", "example.third-cdn.net", "http://example.third-cdn.net/ping", "STEERING_ORDER", 40, 2, earlier)
+	mock.ExpectQuery("SELECT").WithArgs(2).WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	tx := db.MustBegin().Tx
+	targets, userErr, sysErr, code := getExternalTargets(tx, 2)
+	if userErr != nil || sysErr != nil {
+		t.Fatalf("unexpected error getting external targets: user error: %v, system error: %v", userErr, sysErr)
+	}
+	tx.Commit()
+
+	if code != http.StatusOK {
+		t.Errorf("expected status code %d, actual: %d", http.StatusOK, code)
+	}
+	if targets.DeliveryServiceID != 2 || targets.XMLID != "steering" || len(targets.Targets) != 2 {
+		t.Fatalf("expected 2 external targets of delivery service 'steering', actual: %+v", targets)
+	}
+	if target := targets.Targets[0]; target.Name != "other-cdn" || target.FQDN != "example.other-cdn.net" || target.Type != "STEERING_ORDER" || target.TypeID != 40 || target.Value != 1 {
+		t.Errorf("unexpected first external target: %+v", target)
+	}
+	if targets.LastUpdated == nil || !targets.LastUpdated.Equal(later) {
+		t.Errorf("expected lastUpdated to be its latest target's, %v, actual: %v", later, targets.LastUpdated)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetExternalTargetsNoDeliveryService(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT ds.xml_id, cdn.name").WithArgs(3).WillReturnRows(sqlmock.NewRows([]string{"xml_id", "name"}))
+	mock.ExpectCommit()
+
+	tx := db.MustBegin().Tx
+	_, userErr, sysErr, code := getExternalTargets(tx, 3)
+	tx.Commit()
+	if userErr == nil || sysErr != nil || code != http.StatusNotFound {
+		t.Errorf("expected a user error with status code %d, actual: user error: %v, system error: %v, status code: %d", http.StatusNotFound, userErr, sysErr, code)
+	}
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type TOSteeringTargetV11 struct {
@@ -44,6 +45,30 @@ type TOSteeringTargetV11 struct {
 	tc.SteeringTargetNullable
 	DSTenantID  *int          `json:"-" db:"tenant"`
 	LastUpdated *tc.TimeNoMod `json:"-" db:"last_updated"`
+	// GeoLimitCountriesArray is the database representation of the embedded
+	// GeoLimitCountries.
+	GeoLimitCountriesArray pq.StringArray `json:"-" db:"geo_limit_countries"`
+}
+
+// toNullable returns the Target's embedded SteeringTargetNullable, with its
+// GeoLimitCountries set from those read from the database.
+func (st TOSteeringTargetV11) toNullable() tc.SteeringTargetNullable {
+	target := st.SteeringTargetNullable
+	target.GeoLimitCountries = []string(st.GeoLimitCountriesArray)
+	if target.GeoLimitCountries == nil {
+		target.GeoLimitCountries = []string{}
+	}
+	return target
+}
+
+// geoLimitCountriesArray returns the database representation of the given
+// countries, which - unlike NULL - satisfies the column's NOT NULL constraint
+// when there are none.
+func geoLimitCountriesArray(countries []string) pq.StringArray {
+	if countries == nil {
+		return pq.StringArray{}
+	}
+	return pq.StringArray(countries)
 }
 
 func (st TOSteeringTargetV11) GetKeyFieldsInfo() []api.KeyFieldInfo {
@@ -166,11 +191,11 @@ func read(h http.Header, tx *sqlx.Tx, parameters map[string]string, user *auth.C
 	filteredTargets := []tc.SteeringTargetNullable{}
 	for _, tr := range steeringTargets {
 		if tr.DSTenantID == nil {
-			filteredTargets = append(filteredTargets, tr.SteeringTargetNullable)
+			filteredTargets = append(filteredTargets, tr.toNullable())
 			continue
 		}
 		if _, ok := tenantMap[int(*tr.DSTenantID)]; ok {
-			filteredTargets = append(filteredTargets, tr.SteeringTargetNullable)
+			filteredTargets = append(filteredTargets, tr.toNullable())
 			continue
 		}
 	}
@@ -212,6 +237,7 @@ func (st *TOSteeringTargetV11) Create() (error, error, int) {
 		return userErr, sysErr, errCode
 	}
 
+	st.GeoLimitCountriesArray = geoLimitCountriesArray(st.GeoLimitCountries)
 	rows, err := st.ReqInfo.Tx.NamedQuery(insertQuery(), st)
 	if err != nil {
 		return api.ParseDBError(err)
@@ -225,6 +251,7 @@ func (st *TOSteeringTargetV11) Create() (error, error, int) {
 			return nil, errors.New("steering target create scanning: " + err.Error()), http.StatusInternalServerError
 		}
 	}
+	st.SteeringTargetNullable = st.toNullable()
 	if rowsAffected == 0 {
 		return nil, errors.New("no " + st.GetType() + " was inserted, no id was returned"), http.StatusInternalServerError
 	} else if rowsAffected > 1 {
@@ -273,6 +300,7 @@ func (st *TOSteeringTargetV11) Update(h http.Header) (error, error, int) {
 		return errors.New("resource was modified"), nil, http.StatusPreconditionFailed
 	}
 
+	st.GeoLimitCountriesArray = geoLimitCountriesArray(st.GeoLimitCountries)
 	rows, err := st.ReqInfo.Tx.NamedQuery(updateQuery(), st)
 	if err != nil {
 		return api.ParseDBError(err)
@@ -287,6 +315,7 @@ func (st *TOSteeringTargetV11) Update(h http.Header) (error, error, int) {
 			return nil, errors.New("steering target update scanning: " + err.Error()), http.StatusInternalServerError
 		}
 	}
+	st.SteeringTargetNullable = st.toNullable()
 	st.LastUpdated = &lastUpdated
 	if rowsAffected != 1 {
 		if rowsAffected < 1 {
@@ -354,7 +383,10 @@ SELECT
   dst.xml_id AS target_name,
   st.type as type_id,
   tp.name as type_name,
-  st.value
+  st.value,
+  st.latitude,
+  st.longitude,
+  st.geo_limit_countries
 FROM steering_target AS st
 JOIN deliveryservice AS ds ON st.deliveryservice = ds.id
 JOIN deliveryservice AS dst ON st.target = dst.id
@@ -365,9 +397,9 @@ JOIN type AS tp ON tp.id = st.type
 func insertQuery() string {
 	return `
 WITH st AS (
-  INSERT INTO steering_target (deliveryservice, target, value, type)
-  VALUES (:deliveryservice, :target, :value, :type_id)
-  RETURNING deliveryservice, target, value, type
+  INSERT INTO steering_target (deliveryservice, target, value, type, latitude, longitude, geo_limit_countries)
+  VALUES (:deliveryservice, :target, :value, :type_id, :latitude, :longitude, :geo_limit_countries)
+  RETURNING deliveryservice, target, value, type, latitude, longitude, geo_limit_countries
 )
 SELECT
   st.deliveryservice,
@@ -376,7 +408,10 @@ SELECT
   dst.xml_id AS target_name,
   st.type as type_id,
   tp.name as type_name,
-  st.value
+  st.value,
+  st.latitude,
+  st.longitude,
+  st.geo_limit_countries
 FROM st
 JOIN deliveryservice AS ds ON st.deliveryservice = ds.id
 JOIN deliveryservice AS dst ON st.target = dst.id
@@ -389,9 +424,12 @@ func updateQuery() string {
 WITH st as (
   UPDATE steering_target SET
     value = :value,
-    type = :type_id
+    type = :type_id,
+    latitude = :latitude,
+    longitude = :longitude,
+    geo_limit_countries = :geo_limit_countries
   WHERE deliveryservice = :deliveryservice AND target = :target
-  RETURNING deliveryservice, target, value, type, last_updated
+  RETURNING deliveryservice, target, value, type, latitude, longitude, geo_limit_countries, last_updated
 )
 SELECT
   st.deliveryservice,
@@ -401,6 +439,9 @@ SELECT
   st.type as type_id,
   tp.name as type_name,
   st.value,
+  st.latitude,
+  st.longitude,
+  st.geo_limit_countries,
   st.last_updated
 FROM st
 JOIN deliveryservice AS ds ON st.deliveryservice = ds.id
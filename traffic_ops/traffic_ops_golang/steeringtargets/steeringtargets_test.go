@@ -91,3 +91,49 @@ func TestInvalidSteeringTargetType(t *testing.T) {
 		t.Errorf("Expected error details %v, got %v", expected, err.Error())
 	}
 }
+
+func TestSteeringTargetGeoValidation(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"name", "use_in_table"}).AddRow("STEERING_ORDER", "steering_target"))
+	tx := db.MustBegin()
+
+	typeID := 1
+	val := util.JSONIntStr(100)
+	lat := 91.0
+	st := tc.SteeringTargetNullable{
+		TypeID:            &typeID,
+		Value:             &val,
+		Latitude:          &lat,
+		GeoLimitCountries: []string{"US", "gb"},
+	}
+
+	expected := `latitude and longitude must be given together; latitude must be between -90 and 90; geoLimitCountries must contain only two-letter upper-case country codes, got 'gb'`
+	err, _ = st.Validate(tx.Tx)
+	if err == nil {
+		t.Fatal("expected user error for invalid geo fields, got no error instead")
+	}
+	if err.Error() != expected {
+		t.Errorf("Expected error details %v, got %v", expected, err.Error())
+	}
+}
+
+func TestSteeringTargetToNullable(t *testing.T) {
+	st := TOSteeringTargetV11{GeoLimitCountriesArray: []string{"US", "CA"}}
+	target := st.toNullable()
+	if len(target.GeoLimitCountries) != 2 || target.GeoLimitCountries[0] != "US" || target.GeoLimitCountries[1] != "CA" {
+		t.Errorf("expected geoLimitCountries [US CA], actual: %v", target.GeoLimitCountries)
+	}
+
+	st.GeoLimitCountriesArray = nil
+	if target = st.toNullable(); target.GeoLimitCountries == nil || len(target.GeoLimitCountries) != 0 {
+		t.Errorf("expected empty non-nil geoLimitCountries, actual: %#v", target.GeoLimitCountries)
+	}
+}
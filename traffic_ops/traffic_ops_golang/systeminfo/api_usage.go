@@ -0,0 +1,207 @@
+package systeminfo
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+)
+
+// defaultAPIUsageDays and defaultAPIUsageLimit are the number of days and
+// limit of API usage returned when the client doesn't give them.
+const (
+	defaultAPIUsageDays  = 30
+	defaultAPIUsageLimit = 100
+)
+
+// apiUsageDateLayout is the layout of the dates by which API usage is
+// selected.
+const apiUsageDateLayout = "2006-01-02"
+
+// apiUsageOrderBy maps the allowed values of the orderby query parameter of
+// /system/api-usage to the columns of the API usage queries.
+var apiUsageOrderBy = map[string]string{
+	"calls":    "calls",
+	"bytesIn":  "bytes_in",
+	"bytesOut": "bytes_out",
+	"lastCall": "last_call",
+}
+
+const apiUsageFilter = `
+WHERE "day" >= $1::date
+AND "day" <= $2::date
+AND ($3::text IS NULL OR username = $3::text)
+AND ($4::text IS NULL OR credential = $4::text)
+AND ($5::bigint IS NULL OR route_id = $5::bigint)
+`
+
+const selectAPIUsageByRouteQuery = `
+SELECT
+	username,
+	credential,
+	route_id,
+	(ARRAY_AGG(route ORDER BY "day" DESC))[1] AS route,
+	SUM(calls) AS calls,
+	SUM(bytes_in) AS bytes_in,
+	SUM(bytes_out) AS bytes_out,
+	COUNT(DISTINCT "day") AS active_days,
+	MAX(last_call) AS last_call
+FROM api_usage` + apiUsageFilter + `
+GROUP BY username, credential, route_id
+ORDER BY %s %s, username, credential, route_id
+LIMIT $6
+`
+
+const selectAPIUsageByUserQuery = `
+SELECT
+	username,
+	credential,
+	NULL::bigint AS route_id,
+	NULL::text AS route,
+	SUM(calls) AS calls,
+	SUM(bytes_in) AS bytes_in,
+	SUM(bytes_out) AS bytes_out,
+	COUNT(DISTINCT "day") AS active_days,
+	MAX(last_call) AS last_call
+FROM api_usage` + apiUsageFilter + `
+GROUP BY username, credential
+ORDER BY %s %s, username, credential
+LIMIT $6
+`
+
+// GetAPIUsage is the handler for GET requests to /system/api-usage, which
+// returns the usage of the API by each user, with each kind of credential,
+// of each route - or of all routes - over a range of days.
+func GetAPIUsage(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, []string{"limit", "routeId"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	end := time.Now().UTC()
+	if endStr, ok := inf.Params["endDate"]; ok {
+		var err error
+		if end, err = time.Parse(apiUsageDateLayout, endStr); err != nil {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("endDate must be a date in the format YYYY-MM-DD"), nil)
+			return
+		}
+	}
+	start := end.AddDate(0, 0, 1-defaultAPIUsageDays)
+	if startStr, ok := inf.Params["startDate"]; ok {
+		var err error
+		if start, err = time.Parse(apiUsageDateLayout, startStr); err != nil {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("startDate must be a date in the format YYYY-MM-DD"), nil)
+			return
+		}
+	}
+	if start.After(end) {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("startDate must not be after endDate"), nil)
+		return
+	}
+
+	query := selectAPIUsageByRouteQuery
+	if groupBy, ok := inf.Params["groupBy"]; ok {
+		switch groupBy {
+		case tc.APIUsageGroupByRoute:
+		case tc.APIUsageGroupByUser:
+			query = selectAPIUsageByUserQuery
+		default:
+			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("groupBy must be one of '"+tc.APIUsageGroupByRoute+"' or '"+tc.APIUsageGroupByUser+"'"), nil)
+			return
+		}
+	}
+
+	orderBy := apiUsageOrderBy["calls"]
+	if orderByStr, ok := inf.Params["orderby"]; ok {
+		if orderBy, ok = apiUsageOrderBy[orderByStr]; !ok {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("orderby must be one of 'calls', 'bytesIn', 'bytesOut', or 'lastCall'"), nil)
+			return
+		}
+	}
+	sortOrder := "DESC"
+	if sortOrderStr, ok := inf.Params["sortOrder"]; ok {
+		switch sortOrderStr {
+		case "asc":
+			sortOrder = "ASC"
+		case "desc":
+		default:
+			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("sortOrder must be one of 'asc' or 'desc'"), nil)
+			return
+		}
+	}
+
+	limit := defaultAPIUsageLimit
+	if l, ok := inf.IntParams["limit"]; ok {
+		if l < 1 {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("limit must be a positive integer"), nil)
+			return
+		}
+		limit = l
+	}
+
+	var username, credential *string
+	if u, ok := inf.Params["username"]; ok {
+		username = &u
+	}
+	if c, ok := inf.Params["credential"]; ok {
+		credential = &c
+	}
+	var routeID *int
+	if id, ok := inf.IntParams["routeId"]; ok {
+		routeID = &id
+	}
+
+	usage, err := getAPIUsage(tx, fmt.Sprintf(query, orderBy, sortOrder), start, end, username, credential, routeID, limit)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, usage)
+}
+
+func getAPIUsage(tx *sql.Tx, query string, start, end time.Time, username, credential *string, routeID *int, limit int) ([]tc.APIUsage, error) {
+	rows, err := tx.Query(query, start.Format(apiUsageDateLayout), end.Format(apiUsageDateLayout), username, credential, routeID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querying API usage: %w", err)
+	}
+	defer rows.Close()
+
+	usage := []tc.APIUsage{}
+	for rows.Next() {
+		u := tc.APIUsage{}
+		if err := rows.Scan(&u.Username, &u.Credential, &u.RouteID, &u.Route, &u.Calls, &u.BytesIn, &u.BytesOut, &u.ActiveDays, &u.LastCall); err != nil {
+			return nil, fmt.Errorf("scanning API usage: %w", err)
+		}
+		usage = append(usage, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over API usage: %w", err)
+	}
+	return usage, nil
+}
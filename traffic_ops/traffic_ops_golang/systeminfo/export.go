@@ -0,0 +1,277 @@
+package systeminfo
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+// These are filters of exported tables to the rows belonging to the Tenants
+// with the IDs given as $1.
+const (
+	exportedDeliveryServices = `(SELECT id FROM deliveryservice WHERE tenant_id = ANY($1::bigint[]))`
+	exportedUsers            = `(SELECT id FROM tm_user WHERE tenant_id = ANY($1::bigint[]))`
+	exportedFederations      = `(SELECT federation FROM federation_deliveryservice WHERE deliveryservice IN ` + exportedDeliveryServices + `)`
+)
+
+// exportTable is a table of the Traffic Ops Database which is exported by
+// /system/export.
+type exportTable struct {
+	name string
+	// orderBy are the columns by which rows are ordered, so that exports of
+	// the same configuration are identical.
+	orderBy string
+	// where, if not empty, restricts the exported rows to those belonging to
+	// the Tenants with the IDs given as $1.
+	where string
+	// redacted, if not empty, is an expression of a row t as JSON without
+	// its secrets, which is exported instead of the row unless secrets are
+	// requested.
+	redacted string
+}
+
+// exportTables are the configuration tables exported by /system/export, in
+// an order in which they can be imported without violating foreign keys.
+// Operational data - such as logs, jobs, locks, statistics and the keys of
+// Traffic Vault - is not exported.
+var exportTables = []exportTable{
+	{name: "cdn", orderBy: "id"},
+	{name: "type", orderBy: "id"},
+	{name: "status", orderBy: "id"},
+	{name: "division", orderBy: "id"},
+	{name: "region", orderBy: "id"},
+	{name: "phys_location", orderBy: "id"},
+	{name: "coordinate", orderBy: "id"},
+	{name: "cachegroup", orderBy: "id"},
+	{name: "cachegroup_fallbacks", orderBy: "primary_cg, set_order"},
+	{name: "cachegroup_localization_method", orderBy: "cachegroup, method"},
+	{name: "asn", orderBy: "id"},
+	{name: "parameter", orderBy: "id", redacted: `CASE WHEN t.secure THEN jsonb_set(to_jsonb(t), '{value}', '""') ELSE to_jsonb(t) END`},
+	{name: "profile", orderBy: "id"},
+	{name: "profile_parameter", orderBy: "profile, parameter"},
+	{name: "cachegroup_parameter", orderBy: "cachegroup, parameter"},
+	{name: "server_capability", orderBy: "name"},
+	{name: "service_category", orderBy: "name"},
+	{name: "tenant", orderBy: "id", where: `t.id = ANY($1::bigint[])`},
+	{name: "role", orderBy: "id"},
+	{name: "role_capability", orderBy: "role_id, cap_name"},
+	{name: "field_policy", orderBy: "id"},
+	{name: "tm_user", orderBy: "id", where: `t.tenant_id = ANY($1::bigint[])`, redacted: `to_jsonb(t) - 'local_passwd' - 'confirm_local_passwd' - 'token'`},
+	{name: "server", orderBy: "id", redacted: `to_jsonb(t) - 'ilo_password' - 'xmpp_passwd'`},
+	{name: "server_profile", orderBy: "server, priority"},
+	{name: "interface", orderBy: "server, name"},
+	{name: "ip_address", orderBy: "server, interface, address"},
+	{name: "server_server_capability", orderBy: "server, server_capability"},
+	{name: "topology", orderBy: "name"},
+	{name: "topology_cachegroup", orderBy: "id"},
+	{name: "topology_cachegroup_parents", orderBy: "child, parent"},
+	{name: "cdn_static_dns_ttl_policy", orderBy: "cdn"},
+	{name: "cdn_soa", orderBy: "cdn"},
+	{name: "coverage_zone_file", orderBy: "cdn"},
+	{name: "deliveryservice", orderBy: "id", where: `t.tenant_id = ANY($1::bigint[])`},
+	{name: "deliveryservice_tls_version", orderBy: "deliveryservice, tls_version", where: `t.deliveryservice IN ` + exportedDeliveryServices},
+	{name: "deliveryservice_consistent_hash_query_param", orderBy: "deliveryservice_id, name", where: `t.deliveryservice_id IN ` + exportedDeliveryServices},
+	{name: "deliveryservices_required_capability", orderBy: "deliveryservice_id, required_capability", where: `t.deliveryservice_id IN ` + exportedDeliveryServices},
+	{name: "regex", orderBy: "id", where: `t.id IN (SELECT regex FROM deliveryservice_regex WHERE deliveryservice IN ` + exportedDeliveryServices + `)`},
+	{name: "deliveryservice_regex", orderBy: "deliveryservice, regex", where: `t.deliveryservice IN ` + exportedDeliveryServices},
+	{name: "deliveryservice_server", orderBy: "deliveryservice, server", where: `t.deliveryservice IN ` + exportedDeliveryServices},
+	{name: "deliveryservice_tmuser", orderBy: "deliveryservice, tm_user_id", where: `t.deliveryservice IN ` + exportedDeliveryServices + ` AND t.tm_user_id IN ` + exportedUsers},
+	{name: "steering_target", orderBy: "deliveryservice, target", where: `t.deliveryservice IN ` + exportedDeliveryServices + ` AND t.target IN ` + exportedDeliveryServices},
+	{name: "steering_external_target", orderBy: "name", where: `t.deliveryservice IN ` + exportedDeliveryServices},
+	{name: "origin", orderBy: "id", where: `t.deliveryservice IN ` + exportedDeliveryServices},
+	{name: "staticdnsentry", orderBy: "id", where: `t.deliveryservice IN ` + exportedDeliveryServices},
+	{name: "deliveryservice_token_auth", orderBy: "deliveryservice", where: `t.deliveryservice IN ` + exportedDeliveryServices},
+	{name: "deliveryservice_log_shipping", orderBy: "deliveryservice", where: `t.deliveryservice IN ` + exportedDeliveryServices, redacted: `to_jsonb(t) - 'credentials'`},
+	{name: "deliveryservice_cache_policy", orderBy: "deliveryservice", where: `t.deliveryservice IN ` + exportedDeliveryServices},
+	{name: "deliveryservice_cache_policy_ttl", orderBy: "deliveryservice, status_code", where: `t.deliveryservice IN ` + exportedDeliveryServices},
+	{name: "deliveryservice_rewrite_rule", orderBy: `deliveryservice, "position"`, where: `t.deliveryservice IN ` + exportedDeliveryServices},
+	{name: "deliveryservice_slo", orderBy: "deliveryservice", where: `t.deliveryservice IN ` + exportedDeliveryServices},
+	{name: "federation", orderBy: "id", where: `t.id IN ` + exportedFederations},
+	{name: "federation_resolver", orderBy: "id", where: `t.id IN (SELECT federation_resolver FROM federation_federation_resolver WHERE federation IN ` + exportedFederations + `)`},
+	{name: "federation_deliveryservice", orderBy: "federation, deliveryservice", where: `t.deliveryservice IN ` + exportedDeliveryServices},
+	{name: "federation_federation_resolver", orderBy: "federation, federation_resolver", where: `t.federation IN ` + exportedFederations},
+	{name: "federation_tmuser", orderBy: "federation, tm_user", where: `t.federation IN ` + exportedFederations + ` AND t.tm_user IN ` + exportedUsers},
+	{name: "tenant_read_view", orderBy: "tenant", where: `t.tenant = ANY($1::bigint[])`},
+	{name: "tenant_read_view_tenant", orderBy: "tenant, viewed_tenant", where: `t.tenant = ANY($1::bigint[]) AND t.viewed_tenant = ANY($1::bigint[])`},
+}
+
+// query returns the query for the rows of the table, as a JSON array.
+func (t exportTable) query(includeSecrets bool) string {
+	row := `to_jsonb(t)`
+	if t.redacted != "" && !includeSecrets {
+		row = t.redacted
+	}
+	query := `SELECT COALESCE(jsonb_agg(` + row + ` ORDER BY ` + t.orderBy + `), '[]') FROM "` + t.name + `" AS t`
+	if t.where != "" {
+		query += ` WHERE ` + t.where
+	}
+	return query
+}
+
+const selectSchemaVersionQuery = `SELECT version FROM schema_migrations`
+
+const selectTenantNameQuery = `SELECT name FROM tenant WHERE id = $1`
+
+const selectVaultReferencesQuery = `
+SELECT 'ssl' AS type, ds.xml_id, NULL::text AS cdn, ds.ssl_key_version
+FROM deliveryservice AS ds
+WHERE ds.tenant_id = ANY($1::bigint[]) AND ds.ssl_key_version > 0
+UNION ALL
+SELECT 'url_sig', ds.xml_id, NULL, NULL
+FROM deliveryservice AS ds
+WHERE ds.tenant_id = ANY($1::bigint[])
+AND (
+	ds.signing_algorithm = 'url_sig'
+	OR EXISTS (SELECT 1 FROM deliveryservice_token_auth AS a WHERE a.deliveryservice = ds.id)
+)
+UNION ALL
+SELECT 'uri_signing', ds.xml_id, NULL, NULL
+FROM deliveryservice AS ds
+WHERE ds.tenant_id = ANY($1::bigint[]) AND ds.signing_algorithm = 'uri_signing'
+UNION ALL
+SELECT 'dnssec', NULL, c.name, NULL
+FROM cdn AS c
+WHERE c.dnssec_enabled
+ORDER BY 1, 2, 3
+`
+
+// GetExport is the handler for GET requests to /system/export, which returns
+// a consistent, logical export of the configuration of the Tenant of the
+// user and its descendants, from which another Traffic Ops Database may be
+// restored or cloned with the config_import tool. Secrets are only exported
+// if the includeSecrets query parameter is true; those stored in Traffic
+// Vault are never exported, but references to them are if the
+// vaultReferences query parameter is true.
+func GetExport(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	// must be the first statement of the transaction
+	if _, err := tx.Exec(`SET TRANSACTION ISOLATION LEVEL REPEATABLE READ`); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("setting transaction isolation level: %w", err))
+		return
+	}
+
+	includeSecrets := false
+	if s, ok := inf.Params["includeSecrets"]; ok {
+		var err error
+		if includeSecrets, err = strconv.ParseBool(s); err != nil {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("includeSecrets must be a boolean"), nil)
+			return
+		}
+	}
+	vaultReferences := false
+	if s, ok := inf.Params["vaultReferences"]; ok {
+		var err error
+		if vaultReferences, err = strconv.ParseBool(s); err != nil {
+			api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("vaultReferences must be a boolean"), nil)
+			return
+		}
+	}
+	if includeSecrets && inf.Config.RoleBasedPermissions && !inf.User.Can("PARAMETER-SECURE:READ") {
+		api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("exporting secrets requires the PARAMETER-SECURE:READ permission"), nil)
+		return
+	}
+
+	tenantIDs, err := tenant.GetUserTenantIDListTx(tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting user tenants: %w", err))
+		return
+	}
+
+	export, err := exportConfig(tx, inf.User.TenantID, tenantIDs, includeSecrets, vaultReferences)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	export.ExportedBy = inf.User.UserName
+	export.Timestamp = time.Now()
+
+	msg := "Exported configuration"
+	if includeSecrets {
+		msg += " including secrets"
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, msg, inf.User, tx)
+	api.WriteResp(w, r, export)
+}
+
+func exportConfig(tx *sql.Tx, tenantID int, tenantIDs []int, includeSecrets bool, vaultReferences bool) (tc.ConfigExport, error) {
+	export := tc.ConfigExport{
+		IncludesSecrets: includeSecrets,
+		Tables:          make([]tc.ConfigExportTable, 0, len(exportTables)),
+	}
+	if err := tx.QueryRow(selectSchemaVersionQuery).Scan(&export.SchemaVersion); err != nil {
+		return export, fmt.Errorf("getting schema version: %w", err)
+	}
+	if err := tx.QueryRow(selectTenantNameQuery, tenantID).Scan(&export.Tenant); err != nil {
+		return export, fmt.Errorf("getting user tenant name: %w", err)
+	}
+
+	ids := pq.Array(tenantIDs)
+	for _, t := range exportTables {
+		args := []interface{}{}
+		if t.where != "" {
+			args = append(args, ids)
+		}
+		rows := []byte{}
+		if err := tx.QueryRow(t.query(includeSecrets), args...).Scan(&rows); err != nil {
+			return export, fmt.Errorf("exporting table %s: %w", t.name, err)
+		}
+		export.Tables = append(export.Tables, tc.ConfigExportTable{Name: t.name, Rows: json.RawMessage(rows)})
+	}
+
+	if !vaultReferences {
+		return export, nil
+	}
+	rows, err := tx.Query(selectVaultReferencesQuery, ids)
+	if err != nil {
+		return export, fmt.Errorf("querying Traffic Vault references: %w", err)
+	}
+	defer rows.Close()
+	export.VaultReferences = []tc.ConfigExportVaultReference{}
+	for rows.Next() {
+		ref := tc.ConfigExportVaultReference{}
+		if err := rows.Scan(&ref.Type, &ref.DeliveryService, &ref.CDN, &ref.Version); err != nil {
+			return export, fmt.Errorf("scanning Traffic Vault reference: %w", err)
+		}
+		export.VaultReferences = append(export.VaultReferences, ref)
+	}
+	if err := rows.Err(); err != nil {
+		return export, fmt.Errorf("iterating over Traffic Vault references: %w", err)
+	}
+	return export, nil
+}
@@ -0,0 +1,98 @@
+package systeminfo
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestExportTableQuery(t *testing.T) {
+	for _, table := range exportTables {
+		if table.name == "tm_user" {
+			if q := table.query(false); !strings.Contains(q, "- 'local_passwd'") {
+				t.Errorf("expected users to be exported without passwords unless secrets are requested, got query: %s", q)
+			}
+			if q := table.query(true); strings.Contains(q, "local_passwd") {
+				t.Errorf("expected users to be exported with passwords when secrets are requested, got query: %s", q)
+			}
+		}
+		if table.where != "" && !strings.Contains(table.query(false), "$1") {
+			t.Errorf("expected the query of tenant-filtered table %s to use the tenant IDs", table.name)
+		}
+	}
+}
+
+func TestExportConfig(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT version FROM schema_migrations").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2022061912000000))
+	mock.ExpectQuery("SELECT name FROM tenant").WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("root"))
+	for _, table := range exportTables {
+		q := mock.ExpectQuery(regexp.QuoteMeta(`FROM "` + table.name + `" AS t`))
+		if table.where == "" {
+			q.WithArgs()
+		}
+		q.WillReturnRows(sqlmock.NewRows([]string{"rows"}).AddRow([]byte(`[]`)))
+	}
+	mock.ExpectQuery("SELECT 'ssl'").WillReturnRows(sqlmock.NewRows([]string{"type", "xml_id", "cdn", "ssl_key_version"}).
+		AddRow(tc.ConfigExportVaultSSLKeys, "demo1", nil, 2).
+		AddRow(tc.ConfigExportVaultDNSSECKeys, nil, "cdn1", nil))
+
+	tx, err := mockDB.Begin()
+	if err != nil {
+		t.Fatalf("unexpected error beginning transaction: %v", err)
+	}
+	export, err := exportConfig(tx, 1, []int{1, 2}, false, true)
+	if err != nil {
+		t.Fatalf("unexpected error exporting configuration: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+
+	if export.SchemaVersion != 2022061912000000 || export.Tenant != "root" || export.IncludesSecrets {
+		t.Errorf("unexpected export: %+v", export)
+	}
+	if len(export.Tables) != len(exportTables) {
+		t.Errorf("expected %d exported tables, got %d", len(exportTables), len(export.Tables))
+	}
+	if len(export.VaultReferences) != 2 {
+		t.Fatalf("expected 2 Traffic Vault references, got %d", len(export.VaultReferences))
+	}
+	ssl := export.VaultReferences[0]
+	if ssl.Type != tc.ConfigExportVaultSSLKeys || ssl.DeliveryService == nil || *ssl.DeliveryService != "demo1" || ssl.CDN != nil || ssl.Version == nil || *ssl.Version != 2 {
+		t.Errorf("unexpected SSL keys reference: %+v", ssl)
+	}
+	dnssec := export.VaultReferences[1]
+	if dnssec.Type != tc.ConfigExportVaultDNSSECKeys || dnssec.CDN == nil || *dnssec.CDN != "cdn1" || dnssec.DeliveryService != nil {
+		t.Errorf("unexpected DNSSEC keys reference: %+v", dnssec)
+	}
+}
@@ -0,0 +1,79 @@
+package systeminfo
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/requeststats"
+)
+
+// defaultSlowRequestsWindow and defaultSlowRequestsLimit are the window and
+// limit of slow requests returned when the client doesn't give them.
+const (
+	defaultSlowRequestsWindow = time.Hour
+	defaultSlowRequestsLimit  = 10
+)
+
+// GetSlowRequests is the handler for GET requests to /system/slow-requests,
+// which returns the routes and users whose requests have cost the most over
+// a window of time.
+func GetSlowRequests(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, []string{"limit"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	window := defaultSlowRequestsWindow
+	if windowStr, ok := inf.Params["window"]; ok {
+		var err error
+		if window, err = time.ParseDuration(windowStr); err != nil || window <= 0 || window > requeststats.MaxWindow {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("window must be a positive duration no longer than "+requeststats.MaxWindow.String()+", e.g. '15m'"), nil)
+			return
+		}
+	}
+
+	orderBy := requeststats.OrderByDBTime
+	if orderByStr, ok := inf.Params["orderby"]; ok {
+		switch orderByStr {
+		case requeststats.OrderByDBTime, requeststats.OrderByRows, requeststats.OrderByBytes, requeststats.OrderByDuration, requeststats.OrderByCount:
+			orderBy = orderByStr
+		default:
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("orderby must be one of '"+requeststats.OrderByDBTime+"', '"+requeststats.OrderByRows+"', '"+requeststats.OrderByBytes+"', '"+requeststats.OrderByDuration+"', or '"+requeststats.OrderByCount+"'"), nil)
+			return
+		}
+	}
+
+	limit := defaultSlowRequestsLimit
+	if l, ok := inf.IntParams["limit"]; ok {
+		if l < 1 {
+			api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("limit must be a positive integer"), nil)
+			return
+		}
+		limit = l
+	}
+
+	api.WriteResp(w, r, requeststats.Default.Top(time.Now(), window, orderBy, limit))
+}
@@ -0,0 +1,104 @@
+package tenant
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// quota.go defines functions to get and enforce the quotas of Tenants.
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+const quotaQuery = `
+SELECT
+	t.id,
+	t.name,
+	q.max_deliveryservices,
+	q.max_users,
+	(SELECT COUNT(*) FROM deliveryservice ds WHERE ds.tenant_id = t.id),
+	(SELECT COUNT(*) FROM tm_user u WHERE u.tenant_id = t.id),
+	q.last_updated
+FROM tenant_quota q
+JOIN tenant t ON t.id = q.tenant
+WHERE q.tenant = $1
+`
+
+// Locking the quota serializes the additions to a Tenant, so that concurrent
+// ones can't both fit under its quota when only one does.
+const lockQuotaQuery = `
+SELECT tenant
+FROM tenant_quota
+WHERE tenant = $1
+FOR UPDATE
+`
+
+// GetQuota returns the quota of the Tenant with the given ID, with its current
+// usage, and whether it has one.
+func GetQuota(tx *sql.Tx, tenantID int) (tc.TenantQuota, bool, error) {
+	var quota tc.TenantQuota
+	err := tx.QueryRow(quotaQuery, tenantID).Scan(&quota.TenantID, &quota.TenantName, &quota.MaxDeliveryServices, &quota.MaxUsers, &quota.DeliveryServices, &quota.Users, &quota.LastUpdated)
+	if err == sql.ErrNoRows {
+		return tc.TenantQuota{}, false, nil
+	} else if err != nil {
+		return tc.TenantQuota{}, false, fmt.Errorf("querying quota of Tenant #%d: %w", tenantID, err)
+	}
+	return quota, true, nil
+}
+
+// CheckDeliveryServiceQuota checks that the quota of the Tenant with the given
+// ID, if it has one, leaves room for another Delivery Service. It returns a
+// user error, system error, and status code.
+func CheckDeliveryServiceQuota(tx *sql.Tx, tenantID int) (error, error, int) {
+	quota, ok, err := lockQuota(tx, tenantID)
+	if err != nil {
+		return nil, err, http.StatusInternalServerError
+	}
+	if ok && quota.MaxDeliveryServices != nil && quota.DeliveryServices >= *quota.MaxDeliveryServices {
+		return fmt.Errorf("Tenant '%s' has reached its quota of %d Delivery Services", quota.TenantName, *quota.MaxDeliveryServices), nil, http.StatusConflict
+	}
+	return nil, nil, http.StatusOK
+}
+
+// CheckUserQuota checks that the quota of the Tenant with the given ID, if it
+// has one, leaves room for another user. It returns a user error, system
+// error, and status code.
+func CheckUserQuota(tx *sql.Tx, tenantID int) (error, error, int) {
+	quota, ok, err := lockQuota(tx, tenantID)
+	if err != nil {
+		return nil, err, http.StatusInternalServerError
+	}
+	if ok && quota.MaxUsers != nil && quota.Users >= *quota.MaxUsers {
+		return fmt.Errorf("Tenant '%s' has reached its quota of %d users", quota.TenantName, *quota.MaxUsers), nil, http.StatusConflict
+	}
+	return nil, nil, http.StatusOK
+}
+
+func lockQuota(tx *sql.Tx, tenantID int) (tc.TenantQuota, bool, error) {
+	var id int
+	if err := tx.QueryRow(lockQuotaQuery, tenantID).Scan(&id); err == sql.ErrNoRows {
+		return tc.TenantQuota{}, false, nil
+	} else if err != nil {
+		return tc.TenantQuota{}, false, fmt.Errorf("locking quota of Tenant #%d: %w", tenantID, err)
+	}
+	return GetQuota(tx, tenantID)
+}
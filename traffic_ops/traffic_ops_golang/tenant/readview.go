@@ -0,0 +1,111 @@
+package tenant
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+// readview.go defines functions to determine the read views of users.
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+)
+
+// The read view of a user is that of their Tenant or, if it has none, that of
+// its nearest ancestor which has one.
+const userReadViewQuery = `
+WITH RECURSIVE ancestors AS (
+	SELECT id, parent_id, 0 AS depth
+	FROM tenant
+	WHERE id = $1
+UNION ALL
+	SELECT t.id, t.parent_id, a.depth + 1
+	FROM tenant t
+	JOIN ancestors a ON t.id = a.parent_id
+)
+SELECT
+	v.tenant,
+	ARRAY(SELECT vt.viewed_tenant FROM tenant_read_view_tenant vt WHERE vt.tenant = v.tenant ORDER BY vt.viewed_tenant),
+	v.redacted_deliveryservice_fields,
+	v.redacted_server_fields
+FROM ancestors a
+JOIN tenant_read_view v ON v.tenant = a.id
+ORDER BY a.depth
+LIMIT 1
+`
+
+// ReadView is the read-only view of Delivery Services and servers that a
+// user has through their Tenant.
+type ReadView struct {
+	// TenantID is the ID of the Tenant which has the view.
+	TenantID                      int
+	ViewedTenantIDs               []int
+	RedactedDeliveryServiceFields []string
+	RedactedServerFields          []string
+}
+
+// GetUserReadView returns the read view of the users of the Tenant with the
+// given ID - that of the Tenant or of its nearest ancestor which has one - or
+// nil if they have none.
+func GetUserReadView(tx *sql.Tx, userTenantID int) (*ReadView, error) {
+	var view ReadView
+	var viewed []int64
+	err := tx.QueryRow(userReadViewQuery, userTenantID).Scan(&view.TenantID, pq.Array(&viewed), pq.Array(&view.RedactedDeliveryServiceFields), pq.Array(&view.RedactedServerFields))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("querying read view of Tenant #%d: %w", userTenantID, err)
+	}
+	view.ViewedTenantIDs = make([]int, 0, len(viewed))
+	for _, id := range viewed {
+		view.ViewedTenantIDs = append(view.ViewedTenantIDs, int(id))
+	}
+	return &view, nil
+}
+
+// GetViewedTenantIDs returns the IDs of the Tenants whose Delivery Services
+// the read view lets its users read beyond those of their own Tenancy, which
+// is given by ownTenantIDs: the viewed Tenants and their descendants, except
+// any in ownTenantIDs. Like GetUserTenantIDListTx, inactive Tenants and their
+// descendants are excluded, and if the user's own Tenancy is empty - because
+// their Tenant is inactive - nothing is viewed.
+func GetViewedTenantIDs(tx *sql.Tx, view *ReadView, ownTenantIDs []int) ([]int, error) {
+	if view == nil || len(ownTenantIDs) == 0 {
+		return []int{}, nil
+	}
+	seen := make(map[int]struct{}, len(ownTenantIDs))
+	for _, id := range ownTenantIDs {
+		seen[id] = struct{}{}
+	}
+	viewed := []int{}
+	for _, viewedTenantID := range view.ViewedTenantIDs {
+		ids, err := GetUserTenantIDListTx(tx, viewedTenantID)
+		if err != nil {
+			return nil, fmt.Errorf("getting descendants of viewed Tenant #%d: %w", viewedTenantID, err)
+		}
+		for _, id := range ids {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				viewed = append(viewed, id)
+			}
+		}
+	}
+	return viewed, nil
+}
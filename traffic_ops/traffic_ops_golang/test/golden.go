@@ -0,0 +1,126 @@
+package test
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// UpdateGolden is whether golden files should be (re)written with the output
+// of the code under test, rather than compared to it. Set it by running the
+// tests with -update, after checking that every change to the output is
+// intended; the rewritten golden files are then "blessed" by committing them.
+var UpdateGolden = flag.Bool("update", false, "rewrite golden files with the actual output instead of comparing to them")
+
+// Golden compares actual to the contents of the golden file at path, failing t
+// if they differ. When tests are run with -update, the golden file is written
+// with actual instead, creating it and any missing parent directories if
+// necessary.
+func Golden(t *testing.T, path string, actual []byte) {
+	t.Helper()
+	if *UpdateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("creating golden file directory: %v", err)
+		}
+		if err := ioutil.WriteFile(path, actual, 0644); err != nil {
+			t.Fatalf("writing golden file '%s': %v", path, err)
+		}
+		return
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Errorf("no golden file at '%s'; run the test with -update to create it", path)
+		return
+	} else if err != nil {
+		t.Fatalf("reading golden file '%s': %v", path, err)
+	}
+	if !bytes.Equal(expected, actual) {
+		t.Errorf("output differs from golden file '%s' (if the change is intended, run the test with -update and commit the result): %s", path, firstDifference(string(expected), string(actual)))
+	}
+}
+
+// GoldenJSON is like Golden, but compares the JSON encoding of actual, in a
+// canonical form that's independent of the order of object keys in the
+// original encoding. actual may be a value to encode, or already-encoded
+// JSON as a []byte or json.RawMessage.
+func GoldenJSON(t *testing.T, path string, actual interface{}) {
+	t.Helper()
+	bts, err := CanonicalJSON(actual)
+	if err != nil {
+		t.Fatalf("canonicalizing JSON for golden file '%s': %v", path, err)
+	}
+	Golden(t, path, bts)
+}
+
+// CanonicalJSON returns the JSON encoding of v with object keys sorted and
+// indented by one tab per level, ending with a newline. If v is a []byte or
+// json.RawMessage it's treated as already-encoded JSON.
+func CanonicalJSON(v interface{}) ([]byte, error) {
+	var raw []byte
+	switch v := v.(type) {
+	case []byte:
+		raw = v
+	case json.RawMessage:
+		raw = v
+	default:
+		bts, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		raw = bts
+	}
+
+	var generic interface{}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	bts, err := json.MarshalIndent(generic, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return append(bts, '\n'), nil
+}
+
+// firstDifference describes the first line at which expected and actual
+// differ, which is usually enough to find the cause without dumping both.
+func firstDifference(expected, actual string) string {
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+	for i := 0; i < len(expectedLines) && i < len(actualLines); i++ {
+		if expectedLines[i] != actualLines[i] {
+			return "line " + strconv.Itoa(i+1) + ": expected '" + expectedLines[i] + "', actual '" + actualLines[i] + "'"
+		}
+	}
+	if len(expectedLines) > len(actualLines) {
+		return "actual output ends at line " + strconv.Itoa(len(actualLines)) + ", expected '" + expectedLines[len(actualLines)] + "'"
+	}
+	return "actual output continues past line " + strconv.Itoa(len(expectedLines)) + " with '" + actualLines[len(expectedLines)] + "'"
+}
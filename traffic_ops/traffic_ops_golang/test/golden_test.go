@@ -0,0 +1,78 @@
+package test
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	a, err := CanonicalJSON([]byte(`{"b": [2, 1], "a": {"d": 1.50, "c": null}}`))
+	if err != nil {
+		t.Fatalf("unexpected error canonicalizing JSON: %v", err)
+	}
+	b, err := CanonicalJSON(map[string]interface{}{
+		"a": map[string]interface{}{"c": nil, "d": 1.5},
+		"b": []int{2, 1},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error canonicalizing value: %v", err)
+	}
+	expected := "{\n\t\"a\": {\n\t\t\"c\": null,\n\t\t\"d\": 1.50\n\t},\n\t\"b\": [\n\t\t2,\n\t\t1\n\t]\n}\n"
+	if string(a) != expected {
+		t.Errorf("expected canonical JSON:\n%s\nactual:\n%s", expected, a)
+	}
+	if strings.Replace(string(a), "1.50", "1.5", 1) != string(b) {
+		t.Errorf("expected encoded and unencoded JSON to canonicalize alike, actual:\n%s\n%s", a, b)
+	}
+
+	if _, err := CanonicalJSON([]byte(`{"a":`)); err == nil {
+		t.Error("expected an error canonicalizing invalid JSON, actual: nil")
+	}
+}
+
+func TestGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "golden.txt")
+
+	update := *UpdateGolden
+	defer func() { *UpdateGolden = update }()
+
+	*UpdateGolden = true
+	Golden(t, path, []byte("a\nb\n"))
+	*UpdateGolden = false
+
+	Golden(t, path, []byte("a\nb\n"))
+}
+
+func TestFirstDifference(t *testing.T) {
+	tests := map[string][2]string{
+		"line 2: expected 'b', actual 'c'":                   {"a\nb\n", "a\nc\n"},
+		"actual output ends at line 2, expected 'c'":         {"a\nb\nc", "a\nb"},
+		"actual output continues past line 2 with 'c'":       {"a\nb", "a\nb\nc"},
+		"line 1: expected '{\"a\": 1}', actual '{\"a\": 2}'": {`{"a": 1}`, `{"a": 2}`},
+	}
+	for expected, in := range tests {
+		if actual := firstDifference(in[0], in[1]); actual != expected {
+			t.Errorf("expected difference \"%s\", actual: \"%s\"", expected, actual)
+		}
+	}
+}
@@ -32,6 +32,9 @@ type Cookie struct {
 	AuthData    string `json:"auth_data"`
 	ExpiresUnix int64  `json:"expires"`
 	By          string `json:"by"`
+	// ImpersonatedBy is the username of the user who is impersonating the
+	// user of AuthData with the cookie, if any.
+	ImpersonatedBy string `json:"impersonated_by,omitempty"`
 }
 
 func checkHmac(message, messageMAC, key []byte) bool {
@@ -95,9 +98,20 @@ func NewRawMsg(msg, key []byte) string {
 }
 
 func GetCookie(authData string, duration time.Duration, secret string) *http.Cookie {
+	return makeCookie(Cookie{By: GeneratedByStr, AuthData: authData}, duration, secret)
+}
+
+// GetImpersonationCookie returns a cookie with which the user with the
+// username impersonatedBy acts as the user with the username authData, for
+// the given duration.
+func GetImpersonationCookie(authData string, impersonatedBy string, duration time.Duration, secret string) *http.Cookie {
+	return makeCookie(Cookie{By: GeneratedByStr, AuthData: authData, ImpersonatedBy: impersonatedBy}, duration, secret)
+}
+
+func makeCookie(c Cookie, duration time.Duration, secret string) *http.Cookie {
 	expiry := time.Now().Add(duration)
 	maxAge := int(duration.Seconds())
-	c := Cookie{By: GeneratedByStr, AuthData: authData, ExpiresUnix: expiry.Unix()}
+	c.ExpiresUnix = expiry.Unix()
 	m, _ := json.Marshal(c)
 	msg := NewRawMsg(m, []byte(secret))
 	httpCookie := http.Cookie{Name: "mojolicious", Value: msg, Path: "/", Expires: expiry, MaxAge: maxAge, HttpOnly: true}
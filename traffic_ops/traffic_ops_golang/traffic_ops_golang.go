@@ -21,34 +21,50 @@ package main
 
 import (
 	"crypto/tls"
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
 	_ "net/http/pprof"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"runtime/pprof"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tracing"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/about"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/apiusage"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/changefeed"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/consistency"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/crconfig"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/deliveryservice"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/messages"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/objectcache"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/plugin"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/requeststats"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/rollout"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/routing"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/rpc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/server"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault"
 	_ "github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault/backends" // init traffic vault backends
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault/backends/disabled"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/trafficvault/backends/riaksvc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/webhook"
 
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"golang.org/x/sys/unix"
 )
 
@@ -135,11 +151,27 @@ func main() {
 		sslStr = "disable"
 	}
 
-	db, err := sqlx.Open("postgres", fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s&fallback_application_name=trafficops", cfg.DB.User, cfg.DB.Password, cfg.DB.Hostname, cfg.DB.Port, cfg.DB.DBName, sslStr))
+	dbConnStr := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s&fallback_application_name=trafficops", cfg.DB.User, cfg.DB.Password, cfg.DB.Hostname, cfg.DB.Port, cfg.DB.DBName, sslStr)
+	pqConnector, err := pq.NewConnector(dbConnStr)
 	if err != nil {
 		log.Errorf("opening database: %v\n", err)
 		os.Exit(1)
 	}
+	dbConnector := driver.Connector(pqConnector)
+	if cfg.Tracing != nil {
+		tracer, err := newTracer(cfg.Tracing)
+		if err != nil {
+			log.Errorf("creating tracer: %v\n", err)
+			os.Exit(1)
+		}
+		defer tracer.Close()
+		tracing.SetDefault(tracer)
+		// queries are traced as part of the requests making them
+		dbConnector = tracing.NewConnector(dbConnector, tracer)
+		log.Infof("tracing requests with the %s exporter", cfg.Tracing.Exporter)
+	}
+	// the connector tracks the database cost of each request, for the slow request log and endpoint
+	db := sqlx.NewDb(sql.OpenDB(requeststats.NewConnector(dbConnector)), "postgres")
 	defer db.Close()
 
 	db.SetMaxOpenConns(cfg.MaxDBConnections)
@@ -148,6 +180,25 @@ func main() {
 
 	auth.InitUsersCache(time.Duration(cfg.UserCacheRefreshIntervalSec)*time.Second, db.DB, time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second)
 	server.InitServerUpdateStatusCache(time.Duration(cfg.ServerUpdateStatusCacheRefreshIntervalSec)*time.Second, db.DB, time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second)
+	deliveryservice.InitScheduler(time.Duration(cfg.DeliveryServiceScheduleIntervalSec)*time.Second, db.DB, time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second)
+	rollout.InitController(time.Duration(cfg.RolloutIntervalSec)*time.Second, db.DB, time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second)
+	deliveryservice.InitSLOEvaluator(time.Duration(cfg.SLOEvaluationIntervalSec)*time.Second, db.DB, time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second, &cfg)
+	apiusage.InitFlusher(time.Duration(cfg.APIUsageFlushIntervalSec)*time.Second, db.DB, time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second, cfg.APIUsageRetentionDays)
+	consistency.InitChecker(time.Duration(cfg.ConsistencyCheckIntervalSec)*time.Second, db.DB, time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second, cfg.ConsistencyMaxSnapshotChanges)
+	crconfig.InitAutoSnapshotter(time.Duration(cfg.AutoSnapshotIntervalSec)*time.Second, db.DB, time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second, &cfg)
+	if cfg.LogTail != nil {
+		server.InitCacheHitsCollector(time.Duration(cfg.LogTail.CacheHitsCollectionIntervalSec)*time.Second, db.DB, cfg.LogTail)
+	}
+	changefeed.Init(dbConnStr)
+	webhook.InitDispatcher(time.Duration(cfg.WebhookRetryIntervalSec)*time.Second, db, time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second)
+	if err := objectcache.Init(cfg.ObjectCache, dbConnStr, db.DB, time.Duration(cfg.DBQueryTimeoutSeconds)*time.Second); err != nil {
+		log.Errorf("initializing object cache: %v\n", err)
+		os.Exit(1)
+	}
+	if err := messages.Load(cfg.Messages); err != nil {
+		log.Errorf("loading message catalogs: %v\n", err)
+		os.Exit(1)
+	}
 
 	trafficVault := setupTrafficVault(*riakConfigFileName, &cfg)
 
@@ -204,6 +255,16 @@ func main() {
 	httpServer.TLSConfig.InsecureSkipVerify = cfg.Insecure
 	// end deprecated block
 
+	clientCAs, err := cfg.ClientCertificatePool()
+	if err != nil {
+		log.Errorf("loading client certificate authorities: %v", err)
+		os.Exit(1)
+	}
+	if clientCAs != nil {
+		httpServer.TLSConfig.ClientCAs = clientCAs
+		httpServer.TLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
 	go func() {
 		if cfg.KeyPath == "" {
 			log.Errorf("key cannot be blank in %s", cfg.ConfigHypnotoad.Listen)
@@ -235,6 +296,26 @@ func main() {
 		}
 	}()
 
+	if cfg.GRPC != nil {
+		grpcServer, err := rpc.NewGRPCServer(db, &cfg)
+		if err != nil {
+			log.Errorf("creating gRPC server: %v\n", err)
+			os.Exit(1)
+		}
+		listener, err := net.Listen("tcp", ":"+strconv.Itoa(cfg.GRPC.Port))
+		if err != nil {
+			log.Errorf("listening for gRPC: %v\n", err)
+			os.Exit(1)
+		}
+		log.Infof("gRPC listening on %d", cfg.GRPC.Port)
+		go func() {
+			if err := grpcServer.Serve(listener); err != nil {
+				log.Errorf("stopping gRPC server: %v\n", err)
+				os.Exit(1)
+			}
+		}()
+	}
+
 	profilingLocation, err := getProcessedProfilingLocation(cfg.ProfilingLocation, cfg.LogLocationError)
 	if err != nil {
 		log.Errorln("unable to determine profiling location: " + err.Error())
@@ -255,6 +336,7 @@ func main() {
 		} else {
 			routing.SetBackendConfig(backendConfig)
 		}
+		reloadMessageCatalogs(*configFileName)
 	}
 	signalReloader(unix.SIGHUP, reloadProfilingAndBackendConfig)
 }
@@ -347,6 +429,32 @@ func setNewProfilingInfo(configFileName string, currentProfilingEnabled *bool, c
 	}
 }
 
+// newTracer returns the Tracer of requests configured by cfg.
+func newTracer(cfg *config.ConfigTracing) (*tracing.Tracer, error) {
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = config.TracingServiceNameDefault
+	}
+	sampleRatio := 1.0
+	if cfg.SampleRatio != nil {
+		sampleRatio = *cfg.SampleRatio
+	}
+	var exporter tracing.Exporter
+	switch cfg.Exporter {
+	case config.TracingExporterLog:
+		f, err := os.OpenFile(cfg.LogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("opening trace log: %w", err)
+		}
+		exporter = tracing.NewLogExporter(f, serviceName)
+	default:
+		otlp := tracing.NewOTLPExporter(cfg.Endpoint, serviceName)
+		otlp.Headers = cfg.Headers
+		exporter = otlp
+	}
+	return tracing.NewTracer(exporter, sampleRatio), nil
+}
+
 func getProcessedProfilingLocation(rawProfilingLocation string, errorLogLocation string) (string, error) {
 	profilingLocation := os.TempDir()
 
@@ -384,6 +492,19 @@ func reloadProfilingInfo(configFileName string) (bool, string, error) {
 	return cfg.ProfilingEnabled, profilingLocation, nil
 }
 
+// reloadMessageCatalogs reloads the message catalogs with the configuration
+// in the given cdn.conf file, keeping those already loaded if it can't.
+func reloadMessageCatalogs(configFileName string) {
+	cfg, err := config.LoadCdnConfig(configFileName)
+	if err != nil {
+		log.Errorf("could not reload message catalogs: %v", err)
+		return
+	}
+	if err := messages.Load(cfg.Messages); err != nil {
+		log.Errorf("could not reload message catalogs: %v", err)
+	}
+}
+
 func continuousProfile(profiling *bool, profilingDir *string, version string) {
 	if *profiling && *profilingDir != "" {
 		go func() {
@@ -0,0 +1,142 @@
+package trafficstats
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+)
+
+// A Backend is a time-series database from which the Traffic Stats endpoints
+// read their data. Backends do all aggregation - grouping, percentiles and
+// downsampling - themselves, so that Traffic Ops only ever handles data
+// that's already been aggregated.
+type Backend interface {
+	// Summary returns summary statistics of the data matching the Query, or
+	// nil if there is none. The Query's Aggregate, GroupBy, Interval, Limit,
+	// Offset and OrderBy are ignored.
+	Summary(q Query) (*tc.TrafficStatsSummary, error)
+	// Series returns the data matching the Query, aggregated into one value
+	// per Interval, with one series for each distinct combination of the
+	// values of the tags in GroupBy.
+	Series(q Query) ([]tc.TrafficStatsSeries, error)
+	// Close releases the resources used by the Backend.
+	Close() error
+}
+
+// Query is a request for data from a Backend.
+type Query struct {
+	// Database is the name of the database that holds the data.
+	Database string
+	// Measurement is the name of the measurement that holds the data, e.g.
+	// "kbps.ds.1min".
+	Measurement string
+	// Tags are the values which the data's tags must have.
+	Tags map[string]string
+	// GroupBy are the names of the tags by which the data is split into
+	// series.
+	GroupBy []string
+	// Aggregate is the aggregation applied to the values in each Interval.
+	// It must match tc.TrafficStatsAggregatePattern.
+	Aggregate string
+	Start     time.Time
+	End       time.Time
+	Interval  string
+	// IntervalOffset is the offset of the start of each interval from the
+	// Unix Epoch.
+	IntervalOffset string
+	Limit          *uint64
+	Offset         *uint64
+	OrderBy        *tc.TrafficStatsOrderable
+}
+
+// newQuery returns a Query of the data in the given measurement of the given
+// database for the Traffic Stats request configuration.
+func newQuery(db string, measurement string, c tc.TrafficStatsConfig) Query {
+	aggregate := c.Aggregate
+	if aggregate == "" {
+		aggregate = tc.TrafficStatsDefaultAggregate
+	}
+	return Query{
+		Database:       db,
+		Measurement:    measurement,
+		Tags:           map[string]string{},
+		Aggregate:      aggregate,
+		Start:          c.Start,
+		End:            c.End,
+		Interval:       c.Interval,
+		IntervalOffset: c.OffsetString(),
+		Limit:          c.Limit,
+		Offset:         c.Offset,
+		OrderBy:        c.OrderBy,
+	}
+}
+
+// getBackend returns the Backend configured for Traffic Ops, or nil if there
+// isn't one.
+//
+// InfluxDB is currently the only available Backend; others implement the
+// Backend interface and are chosen here according to the configuration.
+func getBackend(inf *api.APIInfo) (Backend, error) {
+	client, err := inf.CreateInfluxClient()
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, nil
+	}
+	return influxBackend{client: *client}, nil
+}
+
+// summaryAndSeries concurrently gets the summary and series of data from the
+// Backend, either of which may be skipped by passing a nil Query.
+func summaryAndSeries(b Backend, summaryQuery *Query, seriesQuery *Query) (*tc.TrafficStatsSummary, []tc.TrafficStatsSeries, error) {
+	type summaryResult struct {
+		summary *tc.TrafficStatsSummary
+		err     error
+	}
+	summaryChan := make(chan summaryResult, 1)
+	if summaryQuery != nil {
+		go func() {
+			summary, err := b.Summary(*summaryQuery)
+			summaryChan <- summaryResult{summary, err}
+		}()
+	} else {
+		summaryChan <- summaryResult{}
+	}
+
+	var series []tc.TrafficStatsSeries
+	var seriesErr error
+	if seriesQuery != nil {
+		series, seriesErr = b.Series(*seriesQuery)
+	}
+
+	result := <-summaryChan
+	if result.err != nil {
+		return nil, nil, errors.New("getting summary: " + result.err.Error())
+	}
+	if seriesErr != nil {
+		return nil, nil, errors.New("getting series: " + seriesErr.Error())
+	}
+	return result.summary, series, nil
+}
@@ -0,0 +1,317 @@
+package trafficstats
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+
+	"github.com/lib/pq"
+)
+
+const billingStatsQuery = `
+SELECT
+	ds.id,
+	ds.xml_id,
+	t.id,
+	t.name,
+	ss.stat_name,
+	ss.stat_value,
+	ss.stat_date
+FROM stats_summary ss
+JOIN deliveryservice ds ON ds.xml_id = ss.deliveryservice_name
+JOIN tenant t ON t.id = ds.tenant_id
+WHERE ss.stat_name = ANY($1)
+AND ss.stat_date >= $2
+AND ss.stat_date < $3
+AND ds.tenant_id = ANY($4)
+ORDER BY t.name, ds.xml_id, ss.stat_date
+`
+
+const billingTenantsQuery = `
+SELECT id, name, parent_id
+FROM tenant
+`
+
+// billingStat is a daily stats summary of a Delivery Service, for a billing
+// report.
+type billingStat struct {
+	DSID     int
+	XMLID    string
+	TenantID int
+	Tenant   string
+	StatName string
+	Value    float64
+	Date     time.Time
+}
+
+// billingTenant is a Tenant, as needed to find its rate card.
+type billingTenant struct {
+	Name     string
+	ParentID *int
+}
+
+// GetBillingReport is the handler for GET requests to /reports/billing.
+func GetBillingReport(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"month"}, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	month, err := time.Parse(tc.BillingReportMonthFormat, inf.Params["month"])
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, fmt.Errorf("month must be in the format YYYY-MM, e.g. %s", time.Now().Format(tc.BillingReportMonthFormat)), nil)
+		return
+	}
+	format := inf.Params["format"]
+	if format != "" && format != "json" && format != "csv" {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusBadRequest, errors.New("format must be one of 'json' or 'csv'"), nil)
+		return
+	}
+
+	tenantIDs, err := tenant.GetUserTenantIDListTx(inf.Tx.Tx, inf.User.TenantID)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, fmt.Errorf("getting user tenants: %w", err))
+		return
+	}
+	stats, err := getBillingStats(inf.Tx.Tx, month, tenantIDs)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	tenants, err := getBillingTenants(inf.Tx.Tx)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	var billing *config.ConfigBilling
+	if inf.Config != nil {
+		billing = inf.Config.Billing
+	}
+	report := buildBillingReport(month, stats, tenants, billing)
+
+	if format == "csv" {
+		w.Header().Set(rfc.ContentType, "text/csv")
+		w.Header().Set(rfc.ContentDisposition, fmt.Sprintf(`attachment; filename="billing-%s.csv"`, report.Month))
+		if err := writeBillingReportCSV(w, report); err != nil {
+			log.Errorf("writing billing report CSV: %v", err)
+		}
+		return
+	}
+	api.WriteResp(w, r, report)
+}
+
+func getBillingStats(tx *sql.Tx, month time.Time, tenantIDs []int) ([]billingStat, error) {
+	statNames := []string{tc.StatNameDailyDSBytesServed, tc.StatNameDailyDS95thGbps}
+	rows, err := tx.Query(billingStatsQuery, pq.Array(statNames), month, month.AddDate(0, 1, 0), pq.Array(tenantIDs))
+	if err != nil {
+		return nil, fmt.Errorf("querying billing stats summaries: %w", err)
+	}
+	defer log.Close(rows, "closing billing stats summary rows")
+
+	stats := []billingStat{}
+	for rows.Next() {
+		s := billingStat{}
+		if err := rows.Scan(&s.DSID, &s.XMLID, &s.TenantID, &s.Tenant, &s.StatName, &s.Value, &s.Date); err != nil {
+			return nil, fmt.Errorf("scanning billing stats summary: %w", err)
+		}
+		stats = append(stats, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating billing stats summaries: %w", err)
+	}
+	return stats, nil
+}
+
+func getBillingTenants(tx *sql.Tx) (map[int]billingTenant, error) {
+	rows, err := tx.Query(billingTenantsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("querying tenants: %w", err)
+	}
+	defer log.Close(rows, "closing tenant rows")
+
+	tenants := map[int]billingTenant{}
+	for rows.Next() {
+		id := 0
+		t := billingTenant{}
+		if err := rows.Scan(&id, &t.Name, &t.ParentID); err != nil {
+			return nil, fmt.Errorf("scanning tenant: %w", err)
+		}
+		tenants[id] = t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating tenants: %w", err)
+	}
+	return tenants, nil
+}
+
+// getRateCard returns the name of the rate card by which the Tenant with the
+// given ID is billed - its own, else its nearest ancestor's, else the default
+// - and false if there is none.
+func getRateCard(tenantID int, tenants map[int]billingTenant, billing *config.ConfigBilling) (string, config.ConfigRateCard, bool) {
+	if billing == nil {
+		return "", config.ConfigRateCard{}, false
+	}
+	seen := map[int]struct{}{}
+	for id := &tenantID; id != nil; {
+		if _, ok := seen[*id]; ok {
+			break
+		}
+		seen[*id] = struct{}{}
+		t, ok := tenants[*id]
+		if !ok {
+			break
+		}
+		if name, ok := billing.TenantRateCards[t.Name]; ok {
+			card, ok := billing.RateCards[name]
+			return name, card, ok
+		}
+		id = t.ParentID
+	}
+	card, ok := billing.RateCards[config.DefaultRateCard]
+	return config.DefaultRateCard, card, ok
+}
+
+// percentile95 returns the nearest-rank 95th percentile of the given values,
+// or 0 if there are none.
+func percentile95(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	return sorted[int(math.Ceil(0.95*float64(len(sorted))))-1]
+}
+
+// buildBillingReport aggregates the given daily stats summaries, which must be
+// ordered by Tenant, into the billing report of the given month.
+func buildBillingReport(month time.Time, stats []billingStat, tenants map[int]billingTenant, billing *config.ConfigBilling) tc.BillingReport {
+	report := tc.BillingReport{
+		Month:   month.Format(tc.BillingReportMonthFormat),
+		Tenants: []tc.TenantBillingReport{},
+	}
+
+	type dsUsage struct {
+		report tc.DeliveryServiceBillingReport
+		days   map[string]struct{}
+		p95s   []float64
+	}
+	tenantIdx := map[int]int{}
+	dsUsages := map[int]*dsUsage{}
+	dsOrder := map[int][]int{}
+	for _, s := range stats {
+		if _, ok := tenantIdx[s.TenantID]; !ok {
+			tenantIdx[s.TenantID] = len(report.Tenants)
+			report.Tenants = append(report.Tenants, tc.TenantBillingReport{TenantID: s.TenantID, Tenant: s.Tenant})
+		}
+		u, ok := dsUsages[s.DSID]
+		if !ok {
+			u = &dsUsage{
+				report: tc.DeliveryServiceBillingReport{ID: s.DSID, XMLID: s.XMLID},
+				days:   map[string]struct{}{},
+			}
+			dsUsages[s.DSID] = u
+			dsOrder[s.TenantID] = append(dsOrder[s.TenantID], s.DSID)
+		}
+		u.days[s.Date.Format("2006-01-02")] = struct{}{}
+		switch s.StatName {
+		case tc.StatNameDailyDSBytesServed:
+			u.report.BytesServedTB += s.Value
+		case tc.StatNameDailyDS95thGbps:
+			u.p95s = append(u.p95s, s.Value)
+		}
+	}
+
+	for i := range report.Tenants {
+		t := &report.Tenants[i]
+		name, card, hasCard := getRateCard(t.TenantID, tenants, billing)
+		if hasCard {
+			t.RateCard = util.StrPtr(name)
+			t.Currency = util.StrPtr(card.Currency)
+			t.Cost = util.FloatPtr(0)
+		}
+		t.DeliveryServices = []tc.DeliveryServiceBillingReport{}
+		for _, dsID := range dsOrder[t.TenantID] {
+			u := dsUsages[dsID]
+			u.report.Days = len(u.days)
+			u.report.P95Gbps = percentile95(u.p95s)
+			if hasCard {
+				u.report.Cost = util.FloatPtr(u.report.BytesServedTB*card.PerTB + u.report.P95Gbps*card.PerP95Gbps)
+				*t.Cost += *u.report.Cost
+			}
+			t.BytesServedTB += u.report.BytesServedTB
+			t.P95Gbps += u.report.P95Gbps
+			t.DeliveryServices = append(t.DeliveryServices, u.report)
+		}
+		if hasCard && *t.Cost < card.MonthlyMinimum {
+			*t.Cost = card.MonthlyMinimum
+		}
+	}
+	return report
+}
+
+// writeBillingReportCSV writes the given billing report as CSV, with a row for
+// each Tenant's totals followed by a row for each of its Delivery Services.
+func writeBillingReportCSV(w http.ResponseWriter, report tc.BillingReport) error {
+	fmtFloat := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	fmtCost := func(f *float64) string {
+		if f == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*f, 'f', 2, 64)
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"month", "tenant", "delivery_service", "rate_card", "currency", "days", "bytes_served_tb", "p95_gbps", "cost"})
+	for _, t := range report.Tenants {
+		rateCard := ""
+		if t.RateCard != nil {
+			rateCard = *t.RateCard
+		}
+		currency := ""
+		if t.Currency != nil {
+			currency = *t.Currency
+		}
+		cw.Write([]string{report.Month, t.Tenant, "", rateCard, currency, "", fmtFloat(t.BytesServedTB), fmtFloat(t.P95Gbps), fmtCost(t.Cost)})
+		for _, ds := range t.DeliveryServices {
+			cw.Write([]string{report.Month, t.Tenant, ds.XMLID, rateCard, currency, strconv.Itoa(ds.Days), fmtFloat(ds.BytesServedTB), fmtFloat(ds.P95Gbps), fmtCost(ds.Cost)})
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
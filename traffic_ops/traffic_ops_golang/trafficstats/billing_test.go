@@ -0,0 +1,178 @@
+package trafficstats
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"math"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/config"
+)
+
+func floatsEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestPercentile95(t *testing.T) {
+	if p := percentile95(nil); p != 0 {
+		t.Errorf("expected the 95th percentile of no values to be 0, got: %v", p)
+	}
+	values := []float64{}
+	for i := 20; i > 0; i-- {
+		values = append(values, float64(i))
+	}
+	if p := percentile95(values); p != 19 {
+		t.Errorf("expected the 95th percentile of 1-20 to be 19, got: %v", p)
+	}
+	if values[0] != 20 {
+		t.Error("expected percentile95 not to modify its argument")
+	}
+	if p := percentile95([]float64{3}); p != 3 {
+		t.Errorf("expected the 95th percentile of a single value to be that value, got: %v", p)
+	}
+}
+
+func TestGetRateCard(t *testing.T) {
+	root := 1
+	child := 2
+	tenants := map[int]billingTenant{
+		root:  {Name: "root"},
+		child: {Name: "child", ParentID: &root},
+		3:     {Name: "grandchild", ParentID: &child},
+		4:     {Name: "other", ParentID: &root},
+	}
+	billing := &config.ConfigBilling{
+		RateCards: map[string]config.ConfigRateCard{
+			"gold":                 {Currency: "USD", PerTB: 5},
+			config.DefaultRateCard: {Currency: "USD", PerTB: 10},
+		},
+		TenantRateCards: map[string]string{"child": "gold"},
+	}
+
+	if _, _, ok := getRateCard(3, tenants, nil); ok {
+		t.Error("expected no rate card without billing configuration")
+	}
+	if name, card, ok := getRateCard(3, tenants, billing); !ok || name != "gold" || card.PerTB != 5 {
+		t.Errorf("expected a grandchild Tenant to inherit its parent's rate card 'gold', got: %s %+v %t", name, card, ok)
+	}
+	if name, _, ok := getRateCard(4, tenants, billing); !ok || name != config.DefaultRateCard {
+		t.Errorf("expected a Tenant with no rate card of its own or of an ancestor to use the default, got: %s %t", name, ok)
+	}
+	delete(billing.RateCards, config.DefaultRateCard)
+	if name, _, ok := getRateCard(4, tenants, billing); ok {
+		t.Errorf("expected no rate card without a default, got: %s", name)
+	}
+}
+
+func TestBuildBillingReport(t *testing.T) {
+	month := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)
+	day1 := month
+	day2 := month.AddDate(0, 0, 1)
+	stats := []billingStat{
+		{DSID: 1, XMLID: "ds1", TenantID: 1, Tenant: "t1", StatName: tc.StatNameDailyDSBytesServed, Value: 2, Date: day1},
+		{DSID: 1, XMLID: "ds1", TenantID: 1, Tenant: "t1", StatName: tc.StatNameDailyDS95thGbps, Value: 1, Date: day1},
+		{DSID: 1, XMLID: "ds1", TenantID: 1, Tenant: "t1", StatName: tc.StatNameDailyDSBytesServed, Value: 3, Date: day2},
+		{DSID: 1, XMLID: "ds1", TenantID: 1, Tenant: "t1", StatName: tc.StatNameDailyDS95thGbps, Value: 4, Date: day2},
+		{DSID: 2, XMLID: "ds2", TenantID: 1, Tenant: "t1", StatName: tc.StatNameDailyDSBytesServed, Value: 1, Date: day1},
+		{DSID: 3, XMLID: "ds3", TenantID: 2, Tenant: "t2", StatName: tc.StatNameDailyDSBytesServed, Value: 0.1, Date: day1},
+	}
+	tenants := map[int]billingTenant{1: {Name: "t1"}, 2: {Name: "t2"}}
+	billing := &config.ConfigBilling{
+		RateCards: map[string]config.ConfigRateCard{
+			"standard": {Currency: "USD", PerTB: 10, PerP95Gbps: 100, MonthlyMinimum: 50},
+		},
+		TenantRateCards: map[string]string{"t2": "standard"},
+	}
+
+	report := buildBillingReport(month, stats, tenants, billing)
+	if report.Month != "2022-05" {
+		t.Errorf("expected month '2022-05', got: %s", report.Month)
+	}
+	if len(report.Tenants) != 2 {
+		t.Fatalf("expected 2 Tenants, got: %d", len(report.Tenants))
+	}
+
+	t1 := report.Tenants[0]
+	if t1.Tenant != "t1" || t1.RateCard != nil || t1.Currency != nil || t1.Cost != nil {
+		t.Errorf("expected Tenant 't1' with no rate card or cost, got: %+v", t1)
+	}
+	if len(t1.DeliveryServices) != 2 {
+		t.Fatalf("expected 't1' to have 2 Delivery Services, got: %d", len(t1.DeliveryServices))
+	}
+	ds1 := t1.DeliveryServices[0]
+	if ds1.XMLID != "ds1" || ds1.Days != 2 || !floatsEqual(ds1.BytesServedTB, 5) || !floatsEqual(ds1.P95Gbps, 4) || ds1.Cost != nil {
+		t.Errorf("unexpected usage of 'ds1': %+v", ds1)
+	}
+	if !floatsEqual(t1.BytesServedTB, 6) || !floatsEqual(t1.P95Gbps, 4) {
+		t.Errorf("expected 't1' to have served 6TB at 4Gbps, got: %vTB at %vGbps", t1.BytesServedTB, t1.P95Gbps)
+	}
+
+	t2 := report.Tenants[1]
+	if t2.RateCard == nil || *t2.RateCard != "standard" || t2.Currency == nil || *t2.Currency != "USD" {
+		t.Errorf("expected Tenant 't2' to be billed in USD by rate card 'standard', got: %+v", t2)
+	}
+	if len(t2.DeliveryServices) != 1 || t2.DeliveryServices[0].Cost == nil || !floatsEqual(*t2.DeliveryServices[0].Cost, 1) {
+		t.Errorf("expected 'ds3' to cost 1, got: %+v", t2.DeliveryServices)
+	}
+	if t2.Cost == nil || !floatsEqual(*t2.Cost, 50) {
+		t.Errorf("expected 't2' to be billed its monthly minimum of 50, got: %v", t2.Cost)
+	}
+
+	if report := buildBillingReport(month, nil, tenants, billing); report.Tenants == nil || len(report.Tenants) != 0 {
+		t.Errorf("expected an empty report with no stats, got: %+v", report.Tenants)
+	}
+}
+
+func TestWriteBillingReportCSV(t *testing.T) {
+	report := tc.BillingReport{
+		Month: "2022-05",
+		Tenants: []tc.TenantBillingReport{
+			{
+				Tenant:        "t1",
+				RateCard:      util.StrPtr("standard"),
+				Currency:      util.StrPtr("USD"),
+				BytesServedTB: 1.5,
+				P95Gbps:       2,
+				Cost:          util.FloatPtr(215),
+				DeliveryServices: []tc.DeliveryServiceBillingReport{
+					{XMLID: "ds1", Days: 31, BytesServedTB: 1.5, P95Gbps: 2, Cost: util.FloatPtr(215)},
+				},
+			},
+		},
+	}
+	w := httptest.NewRecorder()
+	if err := writeBillingReportCSV(w, report); err != nil {
+		t.Fatalf("unexpected error writing billing report CSV: %v", err)
+	}
+	expected := strings.Join([]string{
+		"month,tenant,delivery_service,rate_card,currency,days,bytes_served_tb,p95_gbps,cost",
+		"2022-05,t1,,standard,USD,,1.5,2,215.00",
+		"2022-05,t1,ds1,standard,USD,31,1.5,2,215.00",
+		"",
+	}, "\n")
+	if actual := w.Body.String(); actual != expected {
+		t.Errorf("expected CSV:\n%s\ngot:\n%s", expected, actual)
+	}
+}
@@ -27,10 +27,10 @@ import (
 
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
 
+	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-rfc"
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
-	influx "github.com/influxdata/influxdb/client/v2"
 )
 
 var (
@@ -41,28 +41,9 @@ var (
 	}
 )
 
-const (
-	cSummaryQuery = `
-SELECT mean(value) AS "average",
-	percentile(value, 5) AS "fifthPercentile",
-	percentile(value, 95) AS "ninetyFifthPercentile",
-	percentile(value, 98) AS "ninetyEighthPercentile",
-	min(value) AS "min",
-	max(value) AS "max",
-	count(value) AS "count"
-FROM "%s"."monthly"."%s.cdn.1min"
-WHERE cdn = $cdn_name
-AND time < $end
-AND time > $start`
-
-	cSeriesQuery = `
-SELECT sum(value)/count(value)
-FROM "%s"."monthly"."%s.cdn.1min"
-WHERE cdn = $cdn_name
-AND time > $start
-AND time < $end
-GROUP BY time(%s, %s), cdn%s`
-)
+// cGroupByType is the value of the groupBy query parameter which splits cache
+// stats by cache server type.
+const cGroupByType = "type"
 
 func cacheConfigFromRequest(r *http.Request, i *api.APIInfo) (tc.TrafficCacheStatsConfig, int, error) {
 	c := tc.TrafficCacheStatsConfig{}
@@ -77,6 +58,12 @@ func cacheConfigFromRequest(r *http.Request, i *api.APIInfo) (tc.TrafficCacheSta
 		return c, http.StatusBadRequest, e
 	}
 
+	// Traffic Stats only keeps the per-type data of bandwidth and connections
+	if c.GroupBy != "" && (c.GroupBy != cGroupByType || c.MetricType == "maxkbps") {
+		e = errors.New("groupBy: only 'type' is supported, and only for the 'bandwidth' and 'connections' metricTypes")
+		return c, http.StatusBadRequest, e
+	}
+
 	var ok bool
 	if c.CDN, ok = i.Params["cdnName"]; !ok {
 		e = errors.New("you must specify cdnName")
@@ -114,16 +101,32 @@ func GetCacheStats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	client, err := inf.CreateInfluxClient()
+	backend, err := getBackend(inf)
 	if err != nil {
 		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
 		return
-	} else if client == nil {
+	} else if backend == nil {
 		sysErr = errors.New("Traffic Stats is not configured, but Cache stats were requested")
 		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
 		return
 	}
-	defer (*client).Close()
+	defer log.Close(backend, "closing Traffic Stats backend")
+
+	var summaryQuery, seriesQuery *Query
+	if !c.ExcludeSummary {
+		q := cacheSummaryQuery(&c, inf.Config.ConfigInflux.CacheDBName)
+		summaryQuery = &q
+	}
+	if !c.ExcludeSeries {
+		q := cacheSeriesQuery(&c, inf.Config.ConfigInflux.CacheDBName)
+		seriesQuery = &q
+	}
+	summary, series, err := summaryAndSeries(backend, summaryQuery, seriesQuery)
+	if err != nil {
+		sysErr = fmt.Errorf("Getting cache stats from Traffic Stats: %v", err)
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
+		return
+	}
 
 	resp := struct {
 		Response tc.TrafficStatsResponse `json:"response"`
@@ -135,14 +138,6 @@ func GetCacheStats(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !c.ExcludeSummary {
-		summary, err := getCacheSummary(client, &c, inf.Config.ConfigInflux.CacheDBName)
-
-		if err != nil {
-			sysErr = fmt.Errorf("Getting summary response from Influx: %v", err)
-			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
-			return
-		}
-
 		// match Perl implementation and set summary to zero values if no data
 		if summary != nil {
 			resp.Response.Summary = summary
@@ -152,22 +147,12 @@ func GetCacheStats(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if !c.ExcludeSeries {
-		series, err := getCacheSeries(client, &c, inf.Config.ConfigInflux.CacheDBName)
-
+		resp.Response.Series, resp.Response.Groups, err = seriesOrGroups(series, c.TrafficStatsConfig)
 		if err != nil {
-			sysErr = fmt.Errorf("Getting summary response from Influx: %v", err)
+			sysErr = fmt.Errorf("Getting series response from Traffic Stats: %v", err)
 			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, sysErr)
 			return
 		}
-
-		// match Perl implementation and omit series if no data
-		if series != nil {
-			if !c.Unix {
-				series.FormatTimestamps()
-			}
-
-			resp.Response.Series = series
-		}
 	}
 
 	respBts, err := json.Marshal(resp)
@@ -187,30 +172,25 @@ func GetCacheStats(w http.ResponseWriter, r *http.Request) {
 	api.WriteAndLogErr(w, r, append(respBts, '\n'))
 }
 
-func getCacheSummary(client *influx.Client, conf *tc.TrafficCacheStatsConfig, db string) (*tc.TrafficStatsSummary, error) {
-	qStr := fmt.Sprintf(cSummaryQuery, db, conf.MetricType)
-	q := influx.NewQueryWithParameters(qStr,
-		db,
-		"rfc3339",
-		map[string]interface{}{
-			"cdn_name": conf.CDN,
-			"start":    conf.Start,
-			"end":      conf.End,
-		})
-	return getSummary(db, q, client)
+// cacheSummaryQuery returns the Query of the summary of the requested cache
+// stats, which is always of all of the CDN's cache servers.
+func cacheSummaryQuery(conf *tc.TrafficCacheStatsConfig, db string) Query {
+	q := newQuery(db, conf.MetricType+".cdn.1min", conf.TrafficStatsConfig)
+	q.Tags["cdn"] = conf.CDN
+	return q
 }
 
-func getCacheSeries(client *influx.Client, conf *tc.TrafficCacheStatsConfig, db string) (*tc.TrafficStatsSeries, error) {
-	extraClauses := buildExtraClauses(&conf.TrafficStatsConfig)
-	qStr := fmt.Sprintf(cSeriesQuery, db, conf.MetricType, conf.Interval, conf.TrafficStatsConfig.OffsetString(), extraClauses)
-	q := influx.NewQueryWithParameters(qStr,
-		db,
-		"rfc3339",
-		map[string]interface{}{
-			"cdn_name": conf.CDN,
-			"start":    conf.Start,
-			"end":      conf.End,
-		})
-
-	return getSeries(db, q, client)
+// cacheSeriesQuery returns the Query of the series of the requested cache
+// stats - of all of the CDN's cache servers, or split by their type.
+func cacheSeriesQuery(conf *tc.TrafficCacheStatsConfig, db string) Query {
+	if conf.GroupBy == cGroupByType {
+		q := newQuery(db, conf.MetricType+".cdn.type.1min", conf.TrafficStatsConfig)
+		q.Tags["cdn"] = conf.CDN
+		q.GroupBy = []string{"cdn", "type"}
+		return q
+	}
+	q := newQuery(db, conf.MetricType+".cdn.1min", conf.TrafficStatsConfig)
+	q.Tags["cdn"] = conf.CDN
+	q.GroupBy = []string{"cdn"}
+	return q
 }
@@ -34,8 +34,6 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-rfc"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
 	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
-
-	influx "github.com/influxdata/influxdb/client/v2"
 )
 
 const (
@@ -49,30 +47,9 @@ const (
 		FROM deliveryservice
 		WHERE id = $1`
 
-	// TODO: Pretty sure all of this could actually be calculated using the fetched data (assuming an
-	// interval is given). Check to see if that's faster than doing another synchronous HTTP request.
-	dsSummaryQuery = `
-		SELECT mean(value) AS "average",
-		       percentile(value, 5) AS "fifthPercentile",
-		       percentile(value, 95) AS "ninetyFifthPercentile",
-		       percentile(value, 98) AS "ninetyEighthPercentile",
-		       min(value) AS "min",
-		       max(value) AS "max",
-		       count(value) AS "count"
-		FROM "%s"."monthly"."%s.ds.1min"
-		WHERE time >= $start
-		AND time <= $end
-		AND cachegroup = 'total'
-		AND deliveryservice = $xmlid`
-
-	dsSeriesQuery = `
-		SELECT mean(value)
-		FROM "%s"."monthly"."%s.ds.1min"
-		WHERE cachegroup = 'total'
-		AND deliveryservice = $xmlid
-		AND time >= $start
-		AND time <= $end
-		GROUP BY time(%s, %s), cachegroup%s`
+	// dsGroupByCacheGroup is the value of the groupBy query parameter which
+	// splits Delivery Service stats by Cache Group.
+	dsGroupByCacheGroup = "cachegroup"
 )
 
 func dsConfigFromRequest(r *http.Request, i *api.APIInfo) (tc.TrafficDSStatsConfig, int, error) {
@@ -88,6 +65,12 @@ func dsConfigFromRequest(r *http.Request, i *api.APIInfo) (tc.TrafficDSStatsConf
 		return c, http.StatusBadRequest, e
 	}
 
+	// Traffic Stats only keeps the per-Cache Group data of kbps
+	if c.GroupBy != "" && (c.GroupBy != dsGroupByCacheGroup || c.MetricType != "kbps") {
+		e = errors.New("groupBy: only 'cachegroup' is supported, and only for the 'kbps' metricType")
+		return c, http.StatusBadRequest, e
+	}
+
 	var ok bool
 	if c.DeliveryService, ok = i.Params["deliveryServiceName"]; !ok {
 		if c.DeliveryService, ok = i.Params["deliveryService"]; !ok {
@@ -131,19 +114,19 @@ func GetDSStats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	client, err := inf.CreateInfluxClient()
+	backend, err := getBackend(inf)
 	if err != nil {
 		errCode = http.StatusInternalServerError
 		sysErr = err
 		api.HandleErr(w, r, tx, errCode, nil, sysErr)
 		return
-	} else if client == nil {
+	} else if backend == nil {
 		sysErr = errors.New("Traffic Stats is not configured, but DS stats were requested")
 		errCode = http.StatusInternalServerError
 		api.HandleErr(w, r, tx, errCode, nil, sysErr)
 		return
 	}
-	defer (*client).Close()
+	defer log.Close(backend, "closing Traffic Stats backend")
 
 	exists, dsTenant, err := dsTenantIDFromXMLID(c.DeliveryService, tx)
 	if err != nil {
@@ -175,25 +158,31 @@ func GetDSStats(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	handleRequest(w, r, client, c, inf)
+	handleRequest(w, r, backend, c, inf)
 }
 
-func handleRequest(w http.ResponseWriter, r *http.Request, client *influx.Client, cfg tc.TrafficDSStatsConfig, inf *api.APIInfo) {
-	// TODO: as above, this could be done on TO itself, thus sending only one synchronous request
-	// per hit on this endpoint, rather than the current two. Not sure if that's worth it for large
-	// data sets, though.
-	var resp tc.TrafficDSStatsResponse
+func handleRequest(w http.ResponseWriter, r *http.Request, backend Backend, cfg tc.TrafficDSStatsConfig, inf *api.APIInfo) {
+	var summaryQuery, seriesQuery *Query
 	if !cfg.ExcludeSummary {
-		summary, kBs, txns, err := getDSSummary(client, &cfg, inf.Config.ConfigInflux.DSDBName)
-
-		if err != nil {
-			sysErr := fmt.Errorf("Getting summary response from Influx: %v", err)
-			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, sysErr)
-			return
-		}
+		q := dsSummaryQuery(&cfg, inf.Config.ConfigInflux.DSDBName)
+		summaryQuery = &q
+	}
+	if !cfg.ExcludeSeries {
+		q := dsSeriesQuery(&cfg, inf.Config.ConfigInflux.DSDBName)
+		seriesQuery = &q
+	}
+	summary, series, err := summaryAndSeries(backend, summaryQuery, seriesQuery)
+	if err != nil {
+		sysErr := fmt.Errorf("Getting DS stats from Traffic Stats: %v", err)
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, sysErr)
+		return
+	}
 
+	var resp tc.TrafficDSStatsResponse
+	if !cfg.ExcludeSummary {
 		// match Perl implementation and set summary to zero values if no data
 		if summary != nil {
+			kBs, txns := dsSummaryTotals(summary, cfg.MetricType)
 			resp.Summary = &tc.TrafficDSStatsSummary{
 				TrafficStatsSummary: *summary,
 				TotalKiloBytes:      kBs,
@@ -202,26 +191,15 @@ func handleRequest(w http.ResponseWriter, r *http.Request, client *influx.Client
 		} else {
 			resp.Summary = &tc.TrafficDSStatsSummary{}
 		}
-
 	}
 
 	if !cfg.ExcludeSeries {
-		series, err := getDSSeries(client, &cfg, inf.Config.ConfigInflux.DSDBName)
-
+		resp.Series, resp.Groups, err = seriesOrGroups(series, cfg.TrafficStatsConfig)
 		if err != nil {
-			sysErr := fmt.Errorf("Getting summary response from Influx: %v", err)
+			sysErr := fmt.Errorf("Getting series response from Traffic Stats: %v", err)
 			api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, sysErr)
 			return
 		}
-
-		// match Perl implementation and omit series if no data
-		if series != nil {
-			if !cfg.Unix {
-				series.FormatTimestamps()
-			}
-
-			resp.Series = series
-		}
 	}
 
 	var respObj struct {
@@ -246,34 +224,29 @@ func handleRequest(w http.ResponseWriter, r *http.Request, client *influx.Client
 	api.WriteAndLogErr(w, r, append(respBts, '\n'))
 }
 
-func getDSSummary(client *influx.Client, conf *tc.TrafficDSStatsConfig, db string) (*tc.TrafficStatsSummary, *float64, *float64, error) {
-	qStr := fmt.Sprintf(dsSummaryQuery, db, conf.MetricType)
-	q := influx.NewQueryWithParameters(qStr,
-		db,
-		"rfc3339", //this doesn't actually seem to have any effect...
-		map[string]interface{}{
-			"xmlid":    conf.DeliveryService,
-			"start":    conf.Start,
-			"end":      conf.End,
-			"interval": string(conf.Interval),
-		})
-	ts, err := getSummary(db, q, client)
-	if err != nil || ts == nil {
-		return nil, nil, nil, err
-	}
+// dsSummaryQuery returns the Query of the summary of the requested Delivery
+// Service stats, which is always of all of the Delivery Service's traffic.
+func dsSummaryQuery(conf *tc.TrafficDSStatsConfig, db string) Query {
+	q := newQuery(db, conf.MetricType+".ds.1min", conf.TrafficStatsConfig)
+	q.Tags["cachegroup"] = "total"
+	q.Tags["deliveryservice"] = conf.DeliveryService
+	return q
+}
 
+// dsSummaryTotals returns the total kilobytes or transactions - whichever the
+// metric type measures - served in the time summarized by the summary.
+func dsSummaryTotals(ts *tc.TrafficStatsSummary, metricType string) (*float64, *float64) {
 	var totalKB *float64
 	var totalTXN *float64
 	value := float64(ts.Count*60) * ts.Average
-	if strings.HasPrefix(conf.MetricType, "kbps") {
+	if strings.HasPrefix(metricType, "kbps") {
 		// TotalBytes is actually in units of kB....
 		value /= 8
 		totalKB = &value
 	} else {
 		totalTXN = &value
 	}
-
-	return ts, totalKB, totalTXN, nil
+	return totalKB, totalTXN
 }
 
 func dsTenantIDFromXMLID(xmlid string, tx *sql.Tx) (bool, uint, error) {
@@ -296,18 +269,21 @@ func getXMLIDFromID(id uint64, tx *sql.Tx) (bool, string, error) {
 	return true, xmlid, err
 }
 
-func getDSSeries(client *influx.Client, conf *tc.TrafficDSStatsConfig, db string) (*tc.TrafficStatsSeries, error) {
-	extraClauses := buildExtraClauses(&conf.TrafficStatsConfig)
-	qStr := fmt.Sprintf(dsSeriesQuery, db, conf.MetricType, conf.Interval, conf.TrafficStatsConfig.OffsetString(), extraClauses)
-	q := influx.NewQueryWithParameters(qStr,
-		db,
-		"rfc3339", // this doesn't seem to do anything...
-		map[string]interface{}{
-			"xmlid": conf.DeliveryService,
-			"start": conf.Start,
-			"end":   conf.End,
-		})
-	return getSeries(db, q, client)
+// dsSeriesQuery returns the Query of the series of the requested Delivery
+// Service stats - of all of the Delivery Service's traffic, or split by Cache
+// Group.
+func dsSeriesQuery(conf *tc.TrafficDSStatsConfig, db string) Query {
+	if conf.GroupBy == dsGroupByCacheGroup {
+		q := newQuery(db, conf.MetricType+".cg.1min", conf.TrafficStatsConfig)
+		q.Tags["deliveryservice"] = conf.DeliveryService
+		q.GroupBy = []string{"cachegroup"}
+		return q
+	}
+	q := newQuery(db, conf.MetricType+".ds.1min", conf.TrafficStatsConfig)
+	q.Tags["cachegroup"] = "total"
+	q.Tags["deliveryservice"] = conf.DeliveryService
+	q.GroupBy = []string{"cachegroup"}
+	return q
 }
 
 func findMetric(slice []string, val string) (int, bool) {
@@ -0,0 +1,208 @@
+package trafficstats
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	influx "github.com/influxdata/influxdb/client/v2"
+)
+
+// influxChunkSize is the number of data points InfluxDB sends in each chunk of
+// a series, which lets Traffic Ops read long series without InfluxDB
+// buffering them whole.
+const influxChunkSize = 10000
+
+const influxSummarySelect = `
+SELECT mean(value) AS "average",
+	percentile(value, 5) AS "fifthPercentile",
+	percentile(value, 95) AS "ninetyFifthPercentile",
+	percentile(value, 98) AS "ninetyEighthPercentile",
+	min(value) AS "min",
+	max(value) AS "max",
+	count(value) AS "count"`
+
+// influxBackend is a Backend that reads data from InfluxDB, as written by
+// Traffic Stats.
+type influxBackend struct {
+	client influx.Client
+}
+
+// Close implements the Backend interface.
+func (b influxBackend) Close() error {
+	return b.client.Close()
+}
+
+// Summary implements the Backend interface.
+func (b influxBackend) Summary(q Query) (*tc.TrafficStatsSummary, error) {
+	qStr := influxSummarySelect + influxFromWhere(q)
+	return getSummary(q.Database, influx.NewQueryWithParameters(qStr, q.Database, "rfc3339", influxParameters(q)), &b.client)
+}
+
+// Series implements the Backend interface.
+func (b influxBackend) Series(q Query) ([]tc.TrafficStatsSeries, error) {
+	qStr, err := influxSeriesQuery(q)
+	if err != nil {
+		return nil, err
+	}
+	iq := influx.NewQueryWithParameters(qStr, q.Database, "rfc3339", influxParameters(q))
+	iq.Chunked = true
+	iq.ChunkSize = influxChunkSize
+	log.Debugf("InfluxDB series query: %+v", iq)
+
+	resp, err := b.client.QueryAsChunk(iq)
+	if err != nil {
+		return nil, err
+	}
+	defer log.Close(resp, "closing InfluxDB series response")
+
+	series := []tc.TrafficStatsSeries{}
+	for {
+		r, err := resp.NextResponse()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if err := r.Error(); err != nil {
+			return nil, err
+		}
+		for _, result := range r.Results {
+			for _, m := range result.Messages {
+				if m != nil {
+					log.Debugf("Message from series query: %s: %s", m.Level, m.Text)
+				}
+			}
+			for _, row := range result.Series {
+				series = appendChunk(series, tc.TrafficStatsSeries{
+					Name:    row.Name,
+					Tags:    row.Tags,
+					Values:  row.Values,
+					Columns: row.Columns,
+				})
+			}
+		}
+	}
+	for i := range series {
+		series[i].Count = uint(len(series[i].Values))
+	}
+	return series, nil
+}
+
+// appendChunk appends a chunk of a series to the series read so far,
+// continuing the last of them if the chunk belongs to it.
+func appendChunk(series []tc.TrafficStatsSeries, chunk tc.TrafficStatsSeries) []tc.TrafficStatsSeries {
+	if len(series) > 0 {
+		last := &series[len(series)-1]
+		if last.Name == chunk.Name && sameTags(last.Tags, chunk.Tags) {
+			last.Values = append(last.Values, chunk.Values...)
+			return series
+		}
+	}
+	return append(series, chunk)
+}
+
+func sameTags(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// influxIdent returns the given name quoted as an InfluxQL identifier.
+func influxIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `\"`) + `"`
+}
+
+// sortedTagKeys returns the keys of the Query's Tags, sorted so that queries
+// are built deterministically.
+func sortedTagKeys(q Query) []string {
+	keys := make([]string, 0, len(q.Tags))
+	for k := range q.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func influxParameters(q Query) map[string]interface{} {
+	params := map[string]interface{}{
+		"start": q.Start,
+		"end":   q.End,
+	}
+	for i, k := range sortedTagKeys(q) {
+		params[fmt.Sprintf("tag%d", i)] = q.Tags[k]
+	}
+	return params
+}
+
+func influxFromWhere(q Query) string {
+	b := strings.Builder{}
+	b.WriteString("\nFROM ")
+	b.WriteString(influxIdent(q.Database) + `."monthly".` + influxIdent(q.Measurement))
+	b.WriteString("\nWHERE time >= $start\nAND time <= $end")
+	for i, k := range sortedTagKeys(q) {
+		b.WriteString(fmt.Sprintf("\nAND %s = $tag%d", influxIdent(k), i))
+	}
+	return b.String()
+}
+
+// influxAggregate returns the InfluxQL selector of the given aggregation of
+// data points' values.
+func influxAggregate(aggregate string) (string, error) {
+	if !tc.TrafficStatsAggregatePattern.MatchString(aggregate) {
+		return "", fmt.Errorf("unsupported aggregation: %s", aggregate)
+	}
+	if strings.HasPrefix(aggregate, "p") {
+		return fmt.Sprintf(`percentile(value, %s) AS "%s"`, aggregate[1:], aggregate), nil
+	}
+	return fmt.Sprintf(`%s(value) AS "%s"`, aggregate, aggregate), nil
+}
+
+func influxSeriesQuery(q Query) (string, error) {
+	if q.Interval == "" {
+		return "", errors.New("no interval given")
+	}
+	selector, err := influxAggregate(q.Aggregate)
+	if err != nil {
+		return "", err
+	}
+	b := strings.Builder{}
+	b.WriteString("SELECT " + selector)
+	b.WriteString(influxFromWhere(q))
+	b.WriteString(fmt.Sprintf("\nGROUP BY time(%s, %s)", q.Interval, q.IntervalOffset))
+	for _, tag := range q.GroupBy {
+		b.WriteString(", " + influxIdent(tag))
+	}
+	b.WriteString(buildExtraClauses(&tc.TrafficStatsConfig{Limit: q.Limit, Offset: q.Offset, OrderBy: q.OrderBy}))
+	return b.String(), nil
+}
@@ -0,0 +1,102 @@
+package trafficstats
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+)
+
+func TestInfluxSeriesQuery(t *testing.T) {
+	limit := uint64(10)
+	q := Query{
+		Database:       "deliveryservice_stats",
+		Measurement:    "kbps.cg.1min",
+		Tags:           map[string]string{"deliveryservice": "demo1"},
+		GroupBy:        []string{"cachegroup"},
+		Aggregate:      "p95",
+		Start:          time.Unix(0, 0),
+		End:            time.Unix(3600, 0),
+		Interval:       "5m",
+		IntervalOffset: "0s",
+		Limit:          &limit,
+	}
+	expected := `SELECT percentile(value, 95) AS "p95"
+FROM "deliveryservice_stats"."monthly"."kbps.cg.1min"
+WHERE time >= $start
+AND time <= $end
+AND "deliveryservice" = $tag0
+GROUP BY time(5m, 0s), "cachegroup" LIMIT 10`
+	actual, err := influxSeriesQuery(q)
+	if err != nil {
+		t.Fatalf("Unexpected error building series query: %v", err)
+	}
+	if actual != expected {
+		t.Errorf("Incorrect series query; expected:\n%s\nactual:\n%s", expected, actual)
+	}
+	params := influxParameters(q)
+	if params["tag0"] != "demo1" {
+		t.Errorf("Expected parameter 'tag0' to be 'demo1', actual: %v", params["tag0"])
+	}
+
+	q.Aggregate = "mode"
+	if _, err := influxSeriesQuery(q); err == nil {
+		t.Error("Expected an error building a series query with an unsupported aggregation, but got none")
+	}
+}
+
+func TestAppendChunk(t *testing.T) {
+	series := []tc.TrafficStatsSeries{}
+	series = appendChunk(series, tc.TrafficStatsSeries{Name: "kbps.cg.1min", Tags: map[string]string{"cachegroup": "a"}, Values: [][]interface{}{{"t0", 1.0}}})
+	series = appendChunk(series, tc.TrafficStatsSeries{Name: "kbps.cg.1min", Tags: map[string]string{"cachegroup": "a"}, Values: [][]interface{}{{"t1", 2.0}}})
+	series = appendChunk(series, tc.TrafficStatsSeries{Name: "kbps.cg.1min", Tags: map[string]string{"cachegroup": "b"}, Values: [][]interface{}{{"t0", 3.0}}})
+	if len(series) != 2 {
+		t.Fatalf("Expected chunks of two groups to make two series, actual: %d", len(series))
+	}
+	if len(series[0].Values) != 2 {
+		t.Errorf("Expected the chunks of the first group to be joined into two values, actual: %d", len(series[0].Values))
+	}
+	if len(series[1].Values) != 1 {
+		t.Errorf("Expected the second group to have one value, actual: %d", len(series[1].Values))
+	}
+}
+
+func TestSeriesOrGroups(t *testing.T) {
+	series := []tc.TrafficStatsSeries{
+		{Name: "a", Values: [][]interface{}{}},
+		{Name: "b", Values: [][]interface{}{}},
+	}
+	if _, _, err := seriesOrGroups(series, tc.TrafficStatsConfig{Unix: true}); err == nil {
+		t.Error("Expected an error for more than one series without grouping, but got none")
+	}
+	s, groups, err := seriesOrGroups(series, tc.TrafficStatsConfig{Unix: true, GroupBy: "cachegroup"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s != nil || len(groups) != 2 {
+		t.Errorf("Expected two groups and no series with grouping, actual: %d groups, series %v", len(groups), s)
+	}
+	s, _, err = seriesOrGroups(nil, tc.TrafficStatsConfig{Unix: true})
+	if err != nil || s != nil {
+		t.Errorf("Expected no series and no error for no data, actual: %v, %v", s, err)
+	}
+}
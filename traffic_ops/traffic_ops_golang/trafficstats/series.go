@@ -82,3 +82,28 @@ func getSeries(db string, q influx.Query, client *influx.Client) (*tc.TrafficSta
 
 	return &s, nil
 }
+
+// seriesOrGroups returns the series read from a Backend as either the series
+// or the groups of a response - according to whether or not grouping was
+// requested - with their timestamps formatted as requested. Without grouping,
+// there must be no more than one series; the returned series is nil if there
+// is none, matching the Perl implementation.
+func seriesOrGroups(series []tc.TrafficStatsSeries, c tc.TrafficStatsConfig) (*tc.TrafficStatsSeries, []tc.TrafficStatsSeries, error) {
+	if !c.Unix {
+		for _, s := range series {
+			if err := s.FormatTimestamps(); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if c.GroupBy != "" {
+		return nil, series, nil
+	}
+	switch len(series) {
+	case 0:
+		return nil, nil, nil
+	case 1:
+		return &series[0], nil, nil
+	}
+	return nil, nil, fmt.Errorf("Improper number of series: %d", len(series))
+}
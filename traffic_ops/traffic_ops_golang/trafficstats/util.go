@@ -127,6 +127,29 @@ func tsConfigFromRequest(r *http.Request, i *api.APIInfo) (tc.TrafficStatsConfig
 		c.Interval = interval
 	}
 
+	if maxPoints, ok := i.Params["maxPoints"]; ok {
+		max, err := strconv.ParseUint(maxPoints, 10, 64)
+		if err != nil || max == 0 {
+			e = errors.New("maxPoints: must be a positive integer")
+			return c, http.StatusBadRequest, e
+		}
+		c.MaxPoints = &max
+		if err := c.Downsample(); err != nil {
+			return c, http.StatusBadRequest, err
+		}
+	}
+
+	if aggregate, ok := i.Params["aggregate"]; !ok {
+		c.Aggregate = tc.TrafficStatsDefaultAggregate
+	} else if !tc.TrafficStatsAggregatePattern.MatchString(aggregate) {
+		e = errors.New("aggregate: must be one of 'mean', 'min', 'max', 'sum', 'median', or 'p' followed by a percentile from 1 to 99")
+		return c, http.StatusBadRequest, e
+	} else {
+		c.Aggregate = aggregate
+	}
+
+	c.GroupBy = i.Params["groupBy"]
+
 	if ex, ok := i.Params["exclude"]; ok {
 		switch tc.ExcludeFromString(ex) {
 		case tc.ExcludeSummary:
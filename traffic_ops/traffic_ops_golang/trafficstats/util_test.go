@@ -100,3 +100,52 @@ func TestTSConfigFromRequest(t *testing.T) {
 		t.Errorf("Expected Unix to not be set without MIME parameter, but it was")
 	}
 }
+
+func TestTSConfigFromRequestAggregation(t *testing.T) {
+	inf := api.APIInfo{
+		Params: map[string]string{
+			"startDate": "2022-05-01T00:00:00Z",
+			"endDate":   "2022-05-31T00:00:00Z",
+			"aggregate": "p95",
+			"groupBy":   "cachegroup",
+			"maxPoints": "720",
+		},
+	}
+	r, err := http.NewRequest(http.MethodGet, "https://example.test/api/5.0/deliveryservice_stats", nil)
+	if err != nil {
+		t.Fatalf("Failed to build test request: %v", err)
+	}
+
+	cfg, code, err := tsConfigFromRequest(r, &inf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if code != http.StatusOK {
+		t.Errorf("Expected OK status, but was %d", code)
+	}
+	if cfg.Aggregate != "p95" {
+		t.Errorf("Expected aggregate to be 'p95', but it was '%s'", cfg.Aggregate)
+	}
+	if cfg.GroupBy != "cachegroup" {
+		t.Errorf("Expected groupBy to be 'cachegroup', but it was '%s'", cfg.GroupBy)
+	}
+	// 30 days in 720 points is one point per hour
+	if cfg.Interval != "60m" {
+		t.Errorf("Expected interval to be widened to '60m', but it was '%s'", cfg.Interval)
+	}
+
+	inf.Params["aggregate"] = "p100"
+	if _, code, err = tsConfigFromRequest(r, &inf); err == nil {
+		t.Error("Expected an error for an invalid aggregate, but got none")
+	} else if code != http.StatusBadRequest {
+		t.Errorf("Expected Bad Request status for an invalid aggregate, but was %d", code)
+	}
+
+	inf.Params["aggregate"] = "max"
+	inf.Params["maxPoints"] = "0"
+	if _, code, err = tsConfigFromRequest(r, &inf); err == nil {
+		t.Error("Expected an error for zero maxPoints, but got none")
+	} else if code != http.StatusBadRequest {
+		t.Errorf("Expected Bad Request status for zero maxPoints, but was %d", code)
+	}
+}
@@ -100,6 +100,19 @@ func (d *Disabled) PutURISigningKeys(xmlID string, keysJson []byte, tx *sql.Tx,
 func (d *Disabled) DeleteURISigningKeys(xmlID string, tx *sql.Tx, ctx context.Context) error {
 	return disabledErr
 }
+
+func (d *Disabled) GetCDNSigningKey(cdnName string, tx *sql.Tx, ctx context.Context) (tc.CDNSigningKey, bool, error) {
+	return tc.CDNSigningKey{}, false, disabledErr
+}
+
+func (d *Disabled) PutCDNSigningKey(cdnName string, key tc.CDNSigningKey, tx *sql.Tx, ctx context.Context) error {
+	return disabledErr
+}
+
+func (d *Disabled) DeleteCDNSigningKey(cdnName string, tx *sql.Tx, ctx context.Context) error {
+	return disabledErr
+}
+
 func (d *Disabled) Ping(tx *sql.Tx, ctx context.Context) (tc.TrafficVaultPing, error) {
 	return tc.TrafficVaultPing{}, disabledErr
 }
@@ -0,0 +1,91 @@
+package postgres
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func getCDNSigningKey(cdnName string, tvTx *sqlx.Tx, ctx context.Context, aesKey []byte) (tc.CDNSigningKey, bool, error) {
+	var encryptedKey []byte
+	if err := tvTx.QueryRow("SELECT data FROM cdn_signing_key WHERE cdn = $1", cdnName).Scan(&encryptedKey); err != nil {
+		if err == sql.ErrNoRows {
+			return tc.CDNSigningKey{}, false, nil
+		}
+		e := checkErrWithContext("Traffic Vault PostgreSQL: executing SELECT CDN signing key query", err, ctx.Err())
+		return tc.CDNSigningKey{}, false, e
+	}
+
+	keyJSON, err := util.AESDecrypt(encryptedKey, aesKey)
+	if err != nil {
+		return tc.CDNSigningKey{}, false, err
+	}
+
+	key := tc.CDNSigningKey{}
+	if err := json.Unmarshal(keyJSON, &key); err != nil {
+		return tc.CDNSigningKey{}, false, errors.New("unmarshalling CDN signing key: " + err.Error())
+	}
+	return key, true, nil
+}
+
+func putCDNSigningKey(cdnName string, tvTx *sqlx.Tx, key tc.CDNSigningKey, ctx context.Context, aesKey []byte) error {
+	keyJSON, err := json.Marshal(&key)
+	if err != nil {
+		return errors.New("marshalling CDN signing key: " + err.Error())
+	}
+
+	// Delete the old key first if it exists
+	if err := deleteCDNSigningKey(cdnName, tvTx, ctx); err != nil {
+		return err
+	}
+
+	encryptedKey, err := util.AESEncrypt(keyJSON, aesKey)
+	if err != nil {
+		return errors.New("encrypting CDN signing key: " + err.Error())
+	}
+
+	res, err := tvTx.Exec("INSERT INTO cdn_signing_key (cdn, data) VALUES ($1, $2)", cdnName, encryptedKey)
+	if err != nil {
+		e := checkErrWithContext("Traffic Vault PostgreSQL: executing INSERT CDN signing key query", err, ctx.Err())
+		return e
+	}
+	if rowsAffected, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rowsAffected == 0 {
+		return errors.New("CDN signing key: no key was inserted")
+	}
+	return nil
+}
+
+func deleteCDNSigningKey(cdnName string, tvTx *sqlx.Tx, ctx context.Context) error {
+	if _, err := tvTx.Exec("DELETE FROM cdn_signing_key WHERE cdn = $1", cdnName); err != nil {
+		e := checkErrWithContext("Traffic Vault PostgreSQL: executing DELETE CDN signing key query", err, ctx.Err())
+		return e
+	}
+	return nil
+}
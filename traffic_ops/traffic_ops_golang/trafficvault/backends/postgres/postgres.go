@@ -495,6 +495,33 @@ func (p *Postgres) DeleteURISigningKeys(xmlID string, tx *sql.Tx, ctx context.Co
 	return deleteURISigningKeys(xmlID, tvTx, ctx)
 }
 
+func (p *Postgres) GetCDNSigningKey(cdnName string, tx *sql.Tx, ctx context.Context) (tc.CDNSigningKey, bool, error) {
+	tvTx, dbCtx, cancelFunc, err := p.beginTransaction(ctx)
+	if err != nil {
+		return tc.CDNSigningKey{}, false, err
+	}
+	defer p.commitTransaction(tvTx, dbCtx, cancelFunc)
+	return getCDNSigningKey(cdnName, tvTx, ctx, p.aesKey)
+}
+
+func (p *Postgres) PutCDNSigningKey(cdnName string, key tc.CDNSigningKey, tx *sql.Tx, ctx context.Context) error {
+	tvTx, dbCtx, cancelFunc, err := p.beginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer p.commitTransaction(tvTx, dbCtx, cancelFunc)
+	return putCDNSigningKey(cdnName, tvTx, key, ctx, p.aesKey)
+}
+
+func (p *Postgres) DeleteCDNSigningKey(cdnName string, tx *sql.Tx, ctx context.Context) error {
+	tvTx, dbCtx, cancelFunc, err := p.beginTransaction(ctx)
+	if err != nil {
+		return err
+	}
+	defer p.commitTransaction(tvTx, dbCtx, cancelFunc)
+	return deleteCDNSigningKey(cdnName, tvTx, ctx)
+}
+
 func (p *Postgres) Ping(tx *sql.Tx, ctx context.Context) (tc.TrafficVaultPing, error) {
 	tvTx, dbCtx, cancelFunc, err := p.beginTransaction(ctx)
 	if err != nil {
@@ -101,6 +101,18 @@ func (r *Riak) DeleteURISigningKeys(xmlID string, tx *sql.Tx, ctx context.Contex
 	return deleteURISigningKeys(tx, &r.cfg.AuthOptions, &r.cfg.Port, xmlID)
 }
 
+func (r *Riak) GetCDNSigningKey(cdnName string, tx *sql.Tx, ctx context.Context) (tc.CDNSigningKey, bool, error) {
+	return tc.CDNSigningKey{}, false, errors.New("Not implemented for this Traffic Vault backend.")
+}
+
+func (r *Riak) PutCDNSigningKey(cdnName string, key tc.CDNSigningKey, tx *sql.Tx, ctx context.Context) error {
+	return errors.New("Not implemented for this Traffic Vault backend.")
+}
+
+func (r *Riak) DeleteCDNSigningKey(cdnName string, tx *sql.Tx, ctx context.Context) error {
+	return errors.New("Not implemented for this Traffic Vault backend.")
+}
+
 func (r *Riak) Ping(tx *sql.Tx, ctx context.Context) (tc.TrafficVaultPing, error) {
 	resp, err := ping(tx, &r.cfg.AuthOptions, &r.cfg.Port)
 	return tc.TrafficVaultPing(resp), err
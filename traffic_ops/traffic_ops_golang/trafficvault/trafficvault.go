@@ -83,6 +83,15 @@ type TrafficVault interface {
 	// DeleteURISigningKeys removes the URI signing keys for the delivery service identified by
 	// the given xmlID.
 	DeleteURISigningKeys(xmlID string, tx *sql.Tx, ctx context.Context) error
+	// GetCDNSigningKey retrieves the key used to sign the configuration of the
+	// CDN identified by the given cdnName.
+	GetCDNSigningKey(cdnName string, tx *sql.Tx, ctx context.Context) (tc.CDNSigningKey, bool, error)
+	// PutCDNSigningKey stores the given key used to sign the configuration of
+	// the CDN identified by the given cdnName.
+	PutCDNSigningKey(cdnName string, key tc.CDNSigningKey, tx *sql.Tx, ctx context.Context) error
+	// DeleteCDNSigningKey removes the key used to sign the configuration of the
+	// CDN identified by the given cdnName.
+	DeleteCDNSigningKey(cdnName string, tx *sql.Tx, ctx context.Context) error
 	// Ping simply checks the health of the Traffic Vault backend, returning a status and which
 	// server hostname the status was returned by.
 	Ping(tx *sql.Tx, ctx context.Context) (tc.TrafficVaultPing, error)
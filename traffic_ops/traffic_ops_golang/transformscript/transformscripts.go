@@ -0,0 +1,339 @@
+// Package transformscript handles transformation scripts, which are named,
+// versioned scripts that transform the requests and/or responses of the
+// Delivery Services to which they're attached. They're distributed to cache
+// servers by t3c, and run by the ATS ts_lua plugin.
+package transformscript
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+
+	"github.com/lib/pq"
+)
+
+const selectScriptsQuery = `
+SELECT s.id, s.name, s.language, s.description,
+	COALESCE(MAX(v.version), 0) AS latest_version,
+	GREATEST(s.last_updated, MAX(v.created)) AS last_updated
+FROM transform_script AS s
+LEFT JOIN transform_script_version AS v ON v.script = s.id
+WHERE ($1::bigint IS NULL OR s.id = $1)
+AND ($2::text IS NULL OR s.name = $2)
+GROUP BY s.id
+ORDER BY s.name
+`
+
+const insertScriptQuery = `
+INSERT INTO transform_script (name, language, description)
+VALUES ($1, $2, $3)
+RETURNING id
+`
+
+// lockScriptQuery locks a transformation script, so that versions can't be
+// added to it concurrently, and returns its name.
+const lockScriptQuery = `
+SELECT name
+FROM transform_script
+WHERE id = $1
+FOR UPDATE
+`
+
+const insertVersionQuery = `
+INSERT INTO transform_script_version (script, version, content, sha256, comment, created_by)
+SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4, $5
+FROM transform_script_version
+WHERE script = $1
+RETURNING version
+`
+
+const selectVersionsQuery = `
+SELECT v.script, v.version, v.content, v.sha256, v.comment, u.username, v.created
+FROM transform_script_version AS v
+LEFT JOIN tm_user AS u ON u.id = v.created_by
+WHERE v.script = $1
+AND ($2::integer IS NULL OR v.version = $2)
+ORDER BY v.version DESC
+`
+
+// scriptUsersQuery returns the XMLIDs of the Delivery Services to which a
+// transformation script is attached.
+const scriptUsersQuery = `
+SELECT COALESCE(ARRAY_AGG(ds.xml_id ORDER BY ds.xml_id), '{}')
+FROM deliveryservice_transform_script AS dts
+JOIN deliveryservice AS ds ON ds.id = dts.deliveryservice
+WHERE dts.script = $1
+`
+
+const deleteScriptQuery = `
+DELETE FROM transform_script
+WHERE id = $1
+`
+
+// Get is the handler for GET requests to /transform-scripts, which returns all
+// transformation scripts, optionally filtered by 'id' or 'name'. Their
+// content is only returned by their versions.
+func Get(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	var id, name interface{}
+	if v, ok := inf.IntParams["id"]; ok {
+		id = v
+	}
+	if v, ok := inf.Params["name"]; ok {
+		name = v
+	}
+	scripts, err := getScripts(inf.Tx.Tx, id, name)
+	if err != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, scripts)
+}
+
+// getScripts returns the transformation scripts with the given ID and/or
+// name, or all of them if both are nil.
+func getScripts(tx *sql.Tx, id, name interface{}) ([]tc.TransformScript, error) {
+	rows, err := tx.Query(selectScriptsQuery, id, name)
+	if err != nil {
+		return nil, errors.New("querying transformation scripts: " + err.Error())
+	}
+	defer log.Close(rows, "closing transformation script rows")
+	scripts := []tc.TransformScript{}
+	for rows.Next() {
+		var s tc.TransformScript
+		if err := rows.Scan(&s.ID, &s.Name, &s.Language, &s.Description, &s.LatestVersion, &s.LastUpdated); err != nil {
+			return nil, errors.New("scanning transformation script: " + err.Error())
+		}
+		scripts = append(scripts, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over transformation scripts: " + err.Error())
+	}
+	return scripts, nil
+}
+
+// Create is the handler for POST requests to /transform-scripts, which creates
+// a transformation script along with its first version.
+func Create(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	var req tc.TransformScriptRequest
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	var id int
+	if err := tx.QueryRow(insertScriptQuery, req.Name, req.Language, req.Description).Scan(&id); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	var version int
+	if err := tx.QueryRow(insertVersionQuery, id, req.Content, tc.TransformScriptSHA256(req.Content), req.Comment, inf.User.ID).Scan(&version); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	scripts, err := getScripts(tx, id, nil)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if len(scripts) != 1 {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting created transformation script #%d: expected 1 script, got %d", id, len(scripts)))
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, fmt.Sprintf("%s transformation script - ID: %d NAME: %s", api.Created, id, req.Name), inf.User, tx)
+	w.Header().Set("Location", fmt.Sprintf("/api/%d.%d/transform-scripts?id=%d", inf.Version.Major, inf.Version.Minor, id))
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Transformation script was created", scripts[0])
+}
+
+// Delete is the handler for DELETE requests to /transform-scripts/{id}, which
+// deletes a transformation script and all of its versions. Scripts attached
+// to any Delivery Service can't be deleted.
+func Delete(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+
+	name, userErr, sysErr, errCode := lockScript(tx, id)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	var users []string
+	if err := tx.QueryRow(scriptUsersQuery, id).Scan(pq.Array(&users)); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("getting transformation script Delivery Services: "+err.Error()))
+		return
+	}
+	if len(users) > 0 {
+		api.HandleErr(w, r, tx, http.StatusConflict, fmt.Errorf("transformation script '%s' is attached to Delivery Services: %s", name, strings.Join(users, ", ")), nil)
+		return
+	}
+
+	if _, err := tx.Exec(deleteScriptQuery, id); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	api.CreateChangeLogRawTx(api.ApiChange, fmt.Sprintf("%s transformation script - ID: %d NAME: %s", api.Deleted, id, name), inf.User, tx)
+	api.WriteRespAlert(w, r, tc.SuccessLevel, "Transformation script was deleted")
+}
+
+// lockScript locks the transformation script with the given ID for the rest
+// of the transaction, and returns its name, along with a user error, system
+// error, and status code.
+func lockScript(tx *sql.Tx, id int) (string, error, error, int) {
+	var name string
+	if err := tx.QueryRow(lockScriptQuery, id).Scan(&name); err == sql.ErrNoRows {
+		return "", fmt.Errorf("no transformation script exists by ID '%d'", id), nil, http.StatusNotFound
+	} else if err != nil {
+		return "", nil, errors.New("locking transformation script: " + err.Error()), http.StatusInternalServerError
+	}
+	return name, nil, nil, http.StatusOK
+}
+
+// GetVersions is the handler for GET requests to
+// /transform-scripts/{id}/versions, which returns the versions of a
+// transformation script, newest first, optionally only that given by
+// 'version'.
+func GetVersions(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id", "version"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+
+	if scripts, err := getScripts(tx, id, nil); err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if len(scripts) == 0 {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no transformation script exists by ID '%d'", id), nil)
+		return
+	}
+
+	var version interface{}
+	if v, ok := inf.IntParams["version"]; ok {
+		version = v
+	}
+	versions, err := getVersions(tx, id, version)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+	api.WriteResp(w, r, versions)
+}
+
+// getVersions returns the versions of the transformation script with the
+// given ID, newest first, or only the given version if it isn't nil.
+func getVersions(tx *sql.Tx, id int, version interface{}) ([]tc.TransformScriptVersion, error) {
+	rows, err := tx.Query(selectVersionsQuery, id, version)
+	if err != nil {
+		return nil, errors.New("querying transformation script versions: " + err.Error())
+	}
+	defer log.Close(rows, "closing transformation script version rows")
+	versions := []tc.TransformScriptVersion{}
+	for rows.Next() {
+		var v tc.TransformScriptVersion
+		if err := rows.Scan(&v.ScriptID, &v.Version, &v.Content, &v.SHA256, &v.Comment, &v.CreatedBy, &v.Created); err != nil {
+			return nil, errors.New("scanning transformation script version: " + err.Error())
+		}
+		versions = append(versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.New("iterating over transformation script versions: " + err.Error())
+	}
+	return versions, nil
+}
+
+// CreateVersion is the handler for POST requests to
+// /transform-scripts/{id}/versions, which adds a version to a transformation
+// script. Delivery Services keep running the versions they're set to until
+// they're changed to run the new version, or roll it out.
+func CreateVersion(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+
+	var req tc.TransformScriptVersionRequest
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+	name, userErr, sysErr, errCode := lockScript(tx, id)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	var version int
+	if err := tx.QueryRow(insertVersionQuery, id, req.Content, tc.TransformScriptSHA256(req.Content), req.Comment, inf.User.ID).Scan(&version); err != nil {
+		userErr, sysErr, errCode := api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	versions, err := getVersions(tx, id, version)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	} else if len(versions) != 1 {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting created version %d of transformation script #%d: expected 1 version, got %d", version, id, len(versions)))
+		return
+	}
+
+	api.CreateChangeLogRawTx(api.ApiChange, fmt.Sprintf("%s transformation script version - ID: %d NAME: %s VERSION: %d", api.Created, id, name, version), inf.User, tx)
+	w.Header().Set("Location", fmt.Sprintf("/api/%d.%d/transform-scripts/%d/versions?version=%d", inf.Version.Major, inf.Version.Minor, id, version))
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, fmt.Sprintf("Version %d of transformation script '%s' was created", version, name), versions[0])
+}
@@ -0,0 +1,70 @@
+package transformscript
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestGetVersions(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer mockDB.Close()
+
+	db := sqlx.NewDb(mockDB, "sqlmock")
+	defer db.Close()
+
+	created := time.Date(2022, 7, 5, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	rows := sqlmock.NewRows([]string{"script", "version", "content", "sha256", "comment", "username", "created"}).
+		AddRow(1, 2, "return 0", "abc", "second", "admin", created).
+		AddRow(1, 1, "return 1", "def", "", nil, created)
+	mock.ExpectQuery("SELECT").WithArgs(1, nil).WillReturnRows(rows)
+	mock.ExpectCommit()
+
+	tx := db.MustBegin().Tx
+	versions, err := getVersions(tx, 1, nil)
+	if err != nil {
+		t.Fatalf("unexpected error getting versions: %v", err)
+	}
+	tx.Commit()
+
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, actual: %d", len(versions))
+	}
+	if v := versions[0]; v.Version != 2 || v.Content != "return 0" || v.CreatedBy == nil || *v.CreatedBy != "admin" || !v.Created.Equal(created) {
+		t.Errorf("expected version 2 created by 'admin', actual: %+v", v)
+	}
+	if v := versions[1]; v.Version != 1 || v.CreatedBy != nil {
+		t.Errorf("expected version 1 created by a deleted user, actual: %+v", v)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
@@ -0,0 +1,154 @@
+package user
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tenant"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/tocookie"
+
+	"github.com/lib/pq"
+)
+
+const selectImpersonationTargetQuery = `
+SELECT
+	u.id,
+	u.tenant_id,
+	r.name,
+	r.priv_level,
+	ARRAY(SELECT rc.cap_name FROM role_capability AS rc WHERE rc.role_id=r.id)
+FROM tm_user AS u
+JOIN role AS r ON u.role = r.id
+WHERE u.username = $1
+`
+
+type impersonationTarget struct {
+	ID           int
+	TenantID     int
+	RoleName     string
+	PrivLevel    int
+	Capabilities pq.StringArray
+}
+
+// checkImpersonation returns an error explaining why the given user may not
+// impersonate the given target, or nil if they may. Users may only
+// impersonate users who can't do anything they can't do themselves.
+func checkImpersonation(user auth.CurrentUser, target impersonationTarget) error {
+	if target.ID == user.ID {
+		return errors.New("users cannot impersonate themselves")
+	}
+	if user.RoleName == tc.AdminRoleName {
+		return nil
+	}
+	if target.RoleName == tc.AdminRoleName {
+		return errors.New("only users with the admin Role may impersonate users with the admin Role")
+	}
+	if target.PrivLevel > user.PrivLevel {
+		return errors.New("users cannot impersonate users with a higher privilege level")
+	}
+	if missing := user.MissingPermissions(target.Capabilities...); len(missing) > 0 {
+		return fmt.Errorf("users cannot impersonate users with Permissions they don't have, and the requested user has: %v", missing)
+	}
+	return nil
+}
+
+// Impersonate is the handler for POST requests to /user/impersonate, which
+// starts a time-limited session in which the current user acts as the
+// requested user. Everything done in the session is marked in the change log
+// as having been done by the impersonating user, and the session cannot be
+// renewed; it ends when it expires or the user logs out.
+func Impersonate(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, inf.Tx.Tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+	tx := inf.Tx.Tx
+
+	if inf.User.ImpersonatedBy != "" {
+		api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("users cannot be impersonated from an impersonation session"), nil)
+		return
+	}
+	if inf.Config == nil || len(inf.Config.Secrets) < 1 {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("no secret is configured for signing cookies"))
+		return
+	}
+
+	req := tc.UserImpersonationRequest{}
+	if err := api.Parse(r.Body, tx, &req); err != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, err, nil)
+		return
+	}
+
+	target := impersonationTarget{}
+	err := tx.QueryRow(selectImpersonationTargetQuery, req.Username).Scan(&target.ID, &target.TenantID, &target.RoleName, &target.PrivLevel, &target.Capabilities)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no such user: %s", req.Username), nil)
+			return
+		}
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("getting user to impersonate: %w", err))
+		return
+	}
+	if err := checkImpersonation(*inf.User, target); err != nil {
+		api.HandleErr(w, r, tx, http.StatusForbidden, err, nil)
+		return
+	}
+	authorized, err := tenant.IsResourceAuthorizedToUserTx(target.TenantID, inf.User, tx)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, fmt.Errorf("checking tenancy of user to impersonate: %w", err))
+		return
+	}
+	if !authorized {
+		api.HandleErr(w, r, tx, http.StatusForbidden, errors.New("not authorized on this tenant"), nil)
+		return
+	}
+
+	cookie := tocookie.GetImpersonationCookie(req.Username, inf.User.UserName, req.Duration(), inf.Config.Secrets[0])
+	http.SetCookie(w, cookie)
+	// the impersonating user's own access_token would otherwise be used in
+	// place of the impersonation cookie
+	http.SetCookie(w, &http.Cookie{
+		Name:     api.AccessToken,
+		Value:    "",
+		Path:     "/",
+		Expires:  time.Now(),
+		MaxAge:   -1,
+		HttpOnly: true, // prevents the cookie being accessed by Javascript. DO NOT remove, security vulnerability
+	})
+
+	api.CreateChangeLogRawTx(api.ApiChange, fmt.Sprintf("USER: %s, ID: %d, ACTION: Started impersonation session as this user until %s", req.Username, target.ID, cookie.Expires.UTC().Format(time.RFC3339)), inf.User, tx)
+
+	impersonation := tc.UserImpersonation{
+		Username:       req.Username,
+		ImpersonatedBy: inf.User.UserName,
+		Expires:        cookie.Expires,
+	}
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Now impersonating "+req.Username+"; log out to end the session.", impersonation)
+}
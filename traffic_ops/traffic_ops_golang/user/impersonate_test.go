@@ -0,0 +1,57 @@
+package user
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"testing"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/auth"
+)
+
+func TestCheckImpersonation(t *testing.T) {
+	admin := auth.CurrentUser{ID: 1, RoleName: tc.AdminRoleName, PrivLevel: auth.PrivLevelAdmin}
+	operator := auth.CurrentUser{ID: 2, RoleName: "operations", PrivLevel: auth.PrivLevelOperations}
+
+	testCases := []struct {
+		name    string
+		user    auth.CurrentUser
+		target  impersonationTarget
+		allowed bool
+	}{
+		{"admin impersonating a read-only user", admin, impersonationTarget{ID: 3, RoleName: "read-only", PrivLevel: auth.PrivLevelReadOnly}, true},
+		{"admin impersonating another admin", admin, impersonationTarget{ID: 4, RoleName: tc.AdminRoleName, PrivLevel: auth.PrivLevelAdmin}, true},
+		{"admin impersonating themselves", admin, impersonationTarget{ID: 1, RoleName: tc.AdminRoleName, PrivLevel: auth.PrivLevelAdmin}, false},
+		{"operator impersonating an admin", operator, impersonationTarget{ID: 1, RoleName: tc.AdminRoleName, PrivLevel: auth.PrivLevelAdmin}, false},
+		{"operator impersonating a user with a higher privilege level", operator, impersonationTarget{ID: 5, RoleName: "other", PrivLevel: auth.PrivLevelAdmin}, false},
+		{"operator impersonating a user with more Permissions", operator, impersonationTarget{ID: 3, RoleName: "read-only", PrivLevel: auth.PrivLevelReadOnly, Capabilities: []string{"SERVER:READ"}}, false},
+		{"operator impersonating a user with no Permissions", operator, impersonationTarget{ID: 3, RoleName: "read-only", PrivLevel: auth.PrivLevelReadOnly}, true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := checkImpersonation(testCase.user, testCase.target)
+			if testCase.allowed && err != nil {
+				t.Errorf("Expected impersonation to be allowed, got: %v", err)
+			} else if !testCase.allowed && err == nil {
+				t.Error("Expected impersonation not to be allowed, but it was")
+			}
+		})
+	}
+}
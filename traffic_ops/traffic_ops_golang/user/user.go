@@ -145,6 +145,9 @@ func (user *TOUser) Create() (error, error, int) {
 	if usrErr, sysErr, code := user.privCheck(); code != http.StatusOK {
 		return usrErr, sysErr, code
 	}
+	if usrErr, sysErr, code := user.quotaCheck(); code != http.StatusOK {
+		return usrErr, sysErr, code
+	}
 	var caps []string
 	if user.Role != nil {
 		caps, err = dbhelpers.GetCapabilitiesFromRoleID(user.ReqInfo.Tx.Tx, *user.Role)
@@ -286,6 +289,27 @@ func selectMaxLastUpdatedQuery(where string) string {
 	select max(last_updated) as t from last_deleted l where l.table_name='tm_user') as res`
 }
 
+// quotaCheck checks that the quota of the user's Tenant leaves room for them,
+// if they're being created or moved to it from another Tenant.
+func (user *TOUser) quotaCheck() (error, error, int) {
+	if user.TenantID == nil {
+		return nil, nil, http.StatusOK
+	}
+	if user.ID != nil {
+		var oldTenantID *int
+		err := user.ReqInfo.Tx.Tx.QueryRow(`SELECT tenant_id FROM tm_user WHERE id = $1`, *user.ID).Scan(&oldTenantID)
+		if err == sql.ErrNoRows {
+			return nil, nil, http.StatusOK
+		} else if err != nil {
+			return nil, fmt.Errorf("getting Tenant of user #%d: %w", *user.ID, err), http.StatusInternalServerError
+		}
+		if oldTenantID != nil && *oldTenantID == *user.TenantID {
+			return nil, nil, http.StatusOK
+		}
+	}
+	return tenant.CheckUserQuota(user.ReqInfo.Tx.Tx, *user.TenantID)
+}
+
 func (user *TOUser) privCheck() (error, error, int) {
 	var requestedPrivLevel int
 	var err error
@@ -316,6 +340,9 @@ func (user *TOUser) Update(h http.Header) (error, error, int) {
 	if usrErr, sysErr, code := user.privCheck(); code != http.StatusOK {
 		return usrErr, sysErr, code
 	}
+	if usrErr, sysErr, code := user.quotaCheck(); code != http.StatusOK {
+		return usrErr, sysErr, code
+	}
 
 	var caps []string
 	var err error
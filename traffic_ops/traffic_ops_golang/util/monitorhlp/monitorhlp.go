@@ -68,19 +68,26 @@ func GetClient(tx *sql.Tx) (*http.Client, error) {
 
 // GetURLs returns a slice of Traffic Monitor FQDNs (including port numbers) of
 // ONLINE monitors for each CDN. If a CDN has no online monitors, that CDN will
-// not have an entry in the map.
+// not have an entry in the map. Monitors which monitor other CDNs besides
+// their own are included for those CDNs too, with the ports on which they
+// serve them.
 func GetURLs(tx *sql.Tx) (map[tc.CDNName][]string, error) {
 	qry := `
 SELECT
   s.host_name,
   s.domain_name,
-  s.tcp_port,
-  c.name as cdn
+  mc.port,
+  mc.cdn
 FROM
   server s
   JOIN type t ON s.type = t.id
   JOIN status st ON st.id = s.status
   JOIN cdn c ON c.id = s.cdn_id
+  CROSS JOIN LATERAL (
+    SELECT c.name AS cdn, s.tcp_port AS port
+    UNION ALL
+    ` + dbhelpers.MonitoredCDNsQuery("s") + `
+  ) AS mc
 WHERE
   t.name = '` + tc.MonitorTypeName + `'
   AND st.name = (SELECT COALESCE(
@@ -0,0 +1,364 @@
+package webhook
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/changefeed"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// deliveryTimeout is how long a Webhook has to respond to a change posted
+// to it.
+const deliveryTimeout = 10 * time.Second
+
+// maxDeliveriesPerRound is the most changes posted to a Webhook at once,
+// before the other Webhooks are given their turns.
+const maxDeliveriesPerRound = 20
+
+// claimDuration is how long a Webhook is claimed by the Traffic Ops instance
+// delivering changes to it; it must be longer than maxDeliveriesPerRound
+// deliveries can take. If the instance stops before it's done, another
+// delivers the changes once the claim expires.
+const claimDuration = 5 * time.Minute
+
+// eventBatchSize is the number of change events read from the database at
+// once.
+const eventBatchSize = 500
+
+// The backoff before a failed delivery is retried doubles with each failed
+// attempt, from minRetryBackoff up to maxRetryBackoff.
+const (
+	minRetryBackoff = 10 * time.Second
+	maxRetryBackoff = time.Hour
+)
+
+const hasActiveWebhooksQuery = `
+SELECT EXISTS(SELECT 1 FROM webhook WHERE active)
+`
+
+const dueWebhooksQuery = `
+SELECT id
+FROM webhook
+WHERE active
+AND (next_attempt IS NULL OR next_attempt <= now())
+ORDER BY id
+`
+
+// claimQuery claims the Webhook with the given ID by setting its next
+// attempt to the end of the claim, unless it isn't due - including if it's
+// been claimed by another Traffic Ops instance.
+const claimQuery = `
+UPDATE webhook
+SET next_attempt = now() + $2 * interval '1 second'
+WHERE id = $1
+AND active
+AND (next_attempt IS NULL OR next_attempt <= now())
+RETURNING name, url, secret, object_types, cdns, actions, last_sequence, failed_attempts
+`
+
+const eventsQuery = `
+SELECT
+	"sequence",
+	object_type,
+	object_id,
+	"action",
+	cdn,
+	changed_by,
+	occurred
+FROM change_event
+WHERE "sequence" > $1
+ORDER BY "sequence"
+LIMIT $2
+`
+
+// releaseQuery records the progress of the deliveries to the Webhook with
+// the given ID, and releases its claim.
+const releaseQuery = `
+UPDATE webhook SET
+	last_sequence = $2,
+	failed_attempts = $3,
+	next_attempt = $4,
+	last_error = COALESCE($5, last_error),
+	last_delivered = COALESCE($6, last_delivered)
+WHERE id = $1
+`
+
+// InitDispatcher starts delivering the changes made to objects in Traffic
+// Ops to the Webhooks whose filters they match, as they're made. Failed
+// deliveries are retried when they're due, checked every interval. If
+// interval is not positive, changes are never delivered to Webhooks.
+//
+// Delivering changes is safe with any number of Traffic Ops instances
+// running the dispatcher against the same database; each change is posted to
+// each Webhook by only one of them.
+func InitDispatcher(interval time.Duration, db *sqlx.DB, timeout time.Duration) {
+	if interval <= 0 {
+		log.Infoln("webhook retry interval is negative, changes will not be delivered to webhooks")
+		return
+	}
+	go runDispatcher(interval, db, timeout)
+}
+
+// runDispatcher subscribes to the change feed while there are active
+// Webhooks, because change events are only published while the feed has
+// subscribers, and delivers changes whenever there are new ones or retries
+// may be due.
+func runDispatcher(interval time.Duration, db *sqlx.DB, timeout time.Duration) {
+	client := &http.Client{Timeout: deliveryTimeout}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var updates <-chan struct{}
+	var unsubscribe func()
+	for {
+		active, err := hasActiveWebhooks(db.DB, timeout)
+		if err != nil {
+			log.Errorln("checking for active webhooks: " + err.Error())
+		} else if active && unsubscribe == nil {
+			updates, unsubscribe = changefeed.Subscribe(db)
+		} else if !active && unsubscribe != nil {
+			unsubscribe()
+			updates, unsubscribe = nil, nil
+		}
+
+		for more := active; more; {
+			if more, err = dispatch(db.DB, timeout, client); err != nil {
+				log.Errorln("delivering changes to webhooks: " + err.Error())
+			}
+		}
+
+		select {
+		case <-updates:
+		case <-ticker.C:
+		}
+	}
+}
+
+func hasActiveWebhooks(db *sql.DB, timeout time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	active := false
+	err := db.QueryRowContext(ctx, hasActiveWebhooksQuery).Scan(&active)
+	return active, err
+}
+
+// dispatch delivers changes to each Webhook that's due, and returns whether
+// any has more changes to be delivered right away.
+func dispatch(db *sql.DB, timeout time.Duration, client *http.Client) (bool, error) {
+	ids, err := getDueWebhooks(db, timeout)
+	if err != nil {
+		return false, err
+	}
+	more := false
+	errs := []error{}
+	for _, id := range ids {
+		hookMore, err := deliver(db, timeout, client, id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("webhook #%d: %w", id, err))
+		}
+		more = more || hookMore
+	}
+	return more, util.JoinErrs(errs)
+}
+
+func getDueWebhooks(db *sql.DB, timeout time.Duration) ([]int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	rows, err := db.QueryContext(ctx, dueWebhooksQuery)
+	if err != nil {
+		return nil, errors.New("querying due webhooks: " + err.Error())
+	}
+	defer log.Close(rows, "closing due webhook rows")
+	ids := []int{}
+	for rows.Next() {
+		id := 0
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.New("scanning due webhooks: " + err.Error())
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// deliveryState is the progress of the deliveries to a Webhook.
+type deliveryState struct {
+	lastSequence   int64
+	failedAttempts int
+	nextAttempt    *time.Time
+	lastError      *string
+	lastDelivered  *time.Time
+}
+
+// deliver claims the Webhook with the given ID, if it's still due, posts to
+// it the changes it's yet to be sent that match its filters, in order, and
+// returns whether it has more changes to be delivered right away. It stops
+// at the first failed delivery, which is retried after a backoff, unless
+// it's been attempted tc.MaxWebhookAttempts times, in which case the change
+// is skipped.
+func deliver(db *sql.DB, timeout time.Duration, client *http.Client, id int) (bool, error) {
+	var hook tc.Webhook
+	var secret string
+	state := deliveryState{}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	err := db.QueryRowContext(ctx, claimQuery, id, int64(claimDuration/time.Second)).Scan(
+		&hook.Name,
+		&hook.URL,
+		&secret,
+		pq.Array(&hook.ObjectTypes),
+		pq.Array(&hook.CDNs),
+		pq.Array(&hook.Actions),
+		&state.lastSequence,
+		&state.failedAttempts,
+	)
+	cancel()
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.New("claiming: " + err.Error())
+	}
+
+	events, err := getEvents(db, timeout, state.lastSequence)
+	if err != nil {
+		// The claim is left to expire, after which the changes are retried.
+		return false, err
+	}
+	more := len(events) == eventBatchSize
+	delivered := 0
+	for _, e := range events {
+		if !hook.Matches(e) {
+			state.lastSequence = e.Sequence
+			continue
+		}
+		if delivered == maxDeliveriesPerRound {
+			more = true
+			break
+		}
+		delivered++
+		err := post(client, hook.Name, hook.URL, secret, e)
+		now := time.Now()
+		if err == nil {
+			state.lastSequence = e.Sequence
+			state.failedAttempts = 0
+			state.lastDelivered = &now
+			continue
+		}
+
+		state.failedAttempts++
+		if state.failedAttempts >= tc.MaxWebhookAttempts {
+			log.Errorf("webhook '%s': skipping change #%d after %d failed attempts: %v", hook.Name, e.Sequence, state.failedAttempts, err)
+			state.lastError = util.StrPtr(fmt.Sprintf("skipped change #%d after %d failed attempts: %v", e.Sequence, state.failedAttempts, err))
+			state.lastSequence = e.Sequence
+			state.failedAttempts = 0
+			continue
+		}
+		log.Warnf("webhook '%s': delivering change #%d (attempt %d): %v", hook.Name, e.Sequence, state.failedAttempts, err)
+		next := now.Add(retryBackoff(state.failedAttempts))
+		state.nextAttempt = &next
+		state.lastError = util.StrPtr(fmt.Sprintf("delivering change #%d: %v", e.Sequence, err))
+		more = false
+		break
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if _, err := db.ExecContext(ctx, releaseQuery, id, state.lastSequence, state.failedAttempts, state.nextAttempt, state.lastError, state.lastDelivered); err != nil {
+		return false, errors.New("recording deliveries: " + err.Error())
+	}
+	return more, nil
+}
+
+func getEvents(db *sql.DB, timeout time.Duration, since int64) ([]tc.ChangeEvent, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	rows, err := db.QueryContext(ctx, eventsQuery, since, eventBatchSize)
+	if err != nil {
+		return nil, errors.New("querying change events: " + err.Error())
+	}
+	defer log.Close(rows, "closing change event rows")
+	events := []tc.ChangeEvent{}
+	for rows.Next() {
+		var e tc.ChangeEvent
+		if err := rows.Scan(&e.Sequence, &e.Type, &e.ID, &e.Action, &e.CDN, &e.ChangedBy, &e.Time); err != nil {
+			return nil, errors.New("scanning change events: " + err.Error())
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// retryBackoff returns how long after the given number of failed attempts to
+// deliver a change it's retried.
+func retryBackoff(failedAttempts int) time.Duration {
+	backoff := minRetryBackoff
+	for i := 1; i < failedAttempts && backoff < maxRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryBackoff {
+		backoff = maxRetryBackoff
+	}
+	return backoff
+}
+
+// post posts the change to the Webhook with the given name and URL, signed
+// with its secret. Only 2xx responses are successful deliveries.
+func post(client *http.Client, name, url, secret string, e tc.ChangeEvent) error {
+	body, err := json.Marshal(tc.WebhookPayload{Webhook: name, Event: e})
+	if err != nil {
+		return errors.New("encoding payload: " + err.Error())
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.New("creating request: " + err.Error())
+	}
+	req.Header.Set(rfc.ContentType, rfc.ApplicationJSON)
+	req.Header.Set(tc.WebhookSignatureHeader, tc.WebhookSignature(secret, body))
+	req.Header.Set(tc.WebhookEventHeader, e.Type+"."+e.Action)
+	req.Header.Set(tc.WebhookDeliveryHeader, strconv.FormatInt(e.Sequence, 10))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer log.Close(resp.Body, "closing webhook response body")
+	// The body is read so that the connection can be reused.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("responded with %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	return nil
+}
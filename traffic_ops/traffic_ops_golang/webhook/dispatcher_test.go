@@ -0,0 +1,117 @@
+package webhook
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+const testSecret = "0123456789abcdef"
+
+func TestRetryBackoff(t *testing.T) {
+	tests := map[int]time.Duration{
+		1:  minRetryBackoff,
+		2:  2 * minRetryBackoff,
+		4:  8 * minRetryBackoff,
+		50: maxRetryBackoff,
+	}
+	for attempts, expected := range tests {
+		if backoff := retryBackoff(attempts); backoff != expected {
+			t.Errorf("expected backoff after %d failed attempts to be %v, got: %v", attempts, expected, backoff)
+		}
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	received := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !tc.VerifyWebhookSignature(testSecret, body, r.Header.Get(tc.WebhookSignatureHeader)) {
+			t.Errorf("expected a valid signature of %s, got: %s", body, r.Header.Get(tc.WebhookSignatureHeader))
+		}
+		received = append(received, r.Header.Get(tc.WebhookDeliveryHeader)+" "+r.Header.Get(tc.WebhookEventHeader))
+		if r.Header.Get(tc.WebhookDeliveryHeader) == "4" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("UPDATE webhook").WithArgs(1, sqlmock.AnyArg()).WillReturnRows(
+		sqlmock.NewRows([]string{"name", "url", "secret", "object_types", "cdns", "actions", "last_sequence", "failed_attempts"}).
+			AddRow("ops", srv.URL, testSecret, "{server}", "{}", "{}", 1, 2),
+	)
+	now := time.Now()
+	mock.ExpectQuery("SELECT").WithArgs(1, eventBatchSize).WillReturnRows(
+		sqlmock.NewRows([]string{"sequence", "object_type", "object_id", "action", "cdn", "changed_by", "occurred"}).
+			AddRow(2, "server", "10", "update", "cdn1", "admin", now).
+			AddRow(3, "deliveryservice", "20", "update", "cdn1", "admin", now).
+			AddRow(4, "server", "11", "delete", "cdn1", "admin", now).
+			AddRow(5, "server", "12", "create", "cdn1", "admin", now),
+	)
+	// Change #2 is delivered, #3 doesn't match, and #4 fails, so #5 waits.
+	mock.ExpectExec("UPDATE webhook").WithArgs(1, 3, 1, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	more, err := deliver(db, time.Second, srv.Client(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error delivering changes: %v", err)
+	}
+	if more {
+		t.Error("expected no more changes to be delivered right away after a failed delivery")
+	}
+	if expected := []string{"2 server.update", "4 server.delete"}; len(received) != len(expected) || received[0] != expected[0] || received[1] != expected[1] {
+		t.Errorf("expected changes %v to be posted, got: %v", expected, received)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
+
+func TestDeliverNotDue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("UPDATE webhook").WithArgs(1, sqlmock.AnyArg()).WillReturnRows(
+		sqlmock.NewRows([]string{"name", "url", "secret", "object_types", "cdns", "actions", "last_sequence", "failed_attempts"}),
+	)
+	more, err := deliver(db, time.Second, http.DefaultClient, 1)
+	if err != nil || more {
+		t.Errorf("expected a webhook claimed by another instance to be skipped, got: %t, %v", more, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("expectations were not met: %v", err)
+	}
+}
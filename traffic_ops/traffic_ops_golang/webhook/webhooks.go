@@ -0,0 +1,304 @@
+// Package webhook implements the /webhooks Traffic Ops API endpoints, with
+// which administrators register URLs to which the changes made to objects in
+// Traffic Ops are posted, and the dispatcher that posts them.
+package webhook
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one
+ * or more contributor license agreements.  See the NOTICE file
+ * distributed with this work for additional information
+ * regarding copyright ownership.  The ASF licenses this file
+ * to you under the Apache License, Version 2.0 (the
+ * "License"); you may not use this file except in compliance
+ * with the License.  You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing,
+ * software distributed under the License is distributed on an
+ * "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+ * KIND, either express or implied.  See the License for the
+ * specific language governing permissions and limitations
+ * under the License.
+ */
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/api"
+	"github.com/apache/trafficcontrol/traffic_ops/traffic_ops_golang/dbhelpers"
+
+	"github.com/lib/pq"
+)
+
+const readQuery = `
+SELECT w.id,
+	w.name,
+	w.url,
+	w.object_types,
+	w.cdns,
+	w.actions,
+	w.active,
+	w.last_sequence,
+	w.failed_attempts,
+	w.next_attempt,
+	w.last_error,
+	w.last_delivered,
+	w.last_updated
+FROM webhook AS w
+`
+
+// New Webhooks are sent only the changes made after they're created.
+const insertQuery = `
+INSERT INTO webhook (name, url, secret, object_types, cdns, actions, active, last_sequence)
+VALUES ($1, $2, $3, $4, $5, $6, $7, (SELECT COALESCE(MAX("sequence"), 0) FROM change_event))
+RETURNING id
+`
+
+const updateQuery = `
+UPDATE webhook SET
+	name = $1,
+	url = $2,
+	secret = COALESCE($3, secret),
+	object_types = $4,
+	cdns = $5,
+	actions = $6,
+	active = $7,
+	last_updated = now()
+WHERE id = $8
+`
+
+const deleteQuery = `
+DELETE FROM webhook
+WHERE id = $1
+`
+
+// Read is the handler for GET requests to /webhooks. The secrets of Webhooks
+// are never returned.
+func Read(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	queryParamsToQueryCols := map[string]dbhelpers.WhereColumnInfo{
+		"id":     dbhelpers.WhereColumnInfo{Column: "w.id", Checker: api.IsInt},
+		"name":   dbhelpers.WhereColumnInfo{Column: "w.name"},
+		"active": dbhelpers.WhereColumnInfo{Column: "w.active", Checker: api.IsBool},
+	}
+	where, orderBy, pagination, queryValues, errs := dbhelpers.BuildWhereAndOrderByAndPagination(inf.Params, queryParamsToQueryCols)
+	if len(errs) > 0 {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, util.JoinErrs(errs), nil)
+		return
+	}
+	if orderBy == "" {
+		orderBy = "\nORDER BY w.name"
+	}
+
+	rows, err := inf.Tx.NamedQuery(readQuery+where+orderBy+pagination, queryValues)
+	if err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		if sysErr != nil {
+			sysErr = fmt.Errorf("webhook read query: %v", sysErr)
+		}
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer rows.Close()
+
+	webhooks := []tc.Webhook{}
+	for rows.Next() {
+		var hook tc.Webhook
+		if err = scan(rows, &hook); err != nil {
+			api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, errors.New("scanning webhooks: "+err.Error()))
+			return
+		}
+		webhooks = append(webhooks, hook)
+	}
+
+	api.WriteResp(w, r, webhooks)
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scan(row scanner, hook *tc.Webhook) error {
+	return row.Scan(
+		&hook.ID,
+		&hook.Name,
+		&hook.URL,
+		pq.Array(&hook.ObjectTypes),
+		pq.Array(&hook.CDNs),
+		pq.Array(&hook.Actions),
+		&hook.Active,
+		&hook.LastSequence,
+		&hook.FailedAttempts,
+		&hook.NextAttempt,
+		&hook.LastError,
+		&hook.LastDelivered,
+		&hook.LastUpdated,
+	)
+}
+
+// getWebhook returns the Webhook with the given ID, and whether or not it
+// exists.
+func getWebhook(tx *sql.Tx, id int) (tc.Webhook, bool, error) {
+	var hook tc.Webhook
+	if err := scan(tx.QueryRow(readQuery+"WHERE w.id = $1", id), &hook); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return hook, false, nil
+		}
+		return hook, false, fmt.Errorf("querying webhook #%d: %w", id, err)
+	}
+	return hook, true, nil
+}
+
+// filters returns the filters of the request, with empty filters as empty
+// arrays rather than NULL.
+func filters(req tc.WebhookRequest) (interface{}, interface{}, interface{}) {
+	for _, f := range []*[]string{&req.ObjectTypes, &req.CDNs, &req.Actions} {
+		if *f == nil {
+			*f = []string{}
+		}
+	}
+	return pq.Array(req.ObjectTypes), pq.Array(req.CDNs), pq.Array(req.Actions)
+}
+
+// Create is the handler for POST requests to /webhooks.
+func Create(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, nil, nil)
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	var req tc.WebhookRequest
+	if userErr = api.Parse(r.Body, tx, &req); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	if req.Secret == nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, errors.New("secret: cannot be blank"), nil)
+		return
+	}
+	objectTypes, cdns, actions := filters(req)
+
+	var id int
+	err := tx.QueryRow(insertQuery, req.Name, req.URL, *req.Secret, objectTypes, cdns, actions, req.Active == nil || *req.Active).Scan(&id)
+	if err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	resp, _, err := getWebhook(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	changeLogMsg := fmt.Sprintf("WEBHOOK: %s, ID: %d, ACTION: Created", resp.Name, resp.ID)
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+
+	alerts := tc.CreateAlerts(tc.SuccessLevel, "Webhook '"+resp.Name+"' created")
+	api.WriteAlertsObj(w, r, http.StatusCreated, alerts, resp)
+}
+
+// getExisting gets the Webhook identified by the request's "id" path
+// parameter. If the returned HTTP status code isn't OK, the request has been
+// handled.
+func getExisting(w http.ResponseWriter, r *http.Request, inf *api.APIInfo) (tc.Webhook, int) {
+	tx := inf.Tx.Tx
+	id := inf.IntParams["id"]
+	existing, ok, err := getWebhook(tx, id)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return existing, http.StatusInternalServerError
+	}
+	if !ok {
+		api.HandleErr(w, r, tx, http.StatusNotFound, fmt.Errorf("no webhook exists by ID %d", id), nil)
+		return existing, http.StatusNotFound
+	}
+	return existing, http.StatusOK
+}
+
+// Update is the handler for PUT requests to /webhooks/{id}. The Webhook's
+// secret is left as it was if none is given.
+func Update(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	existing, code := getExisting(w, r, inf)
+	if code != http.StatusOK {
+		return
+	}
+	if !api.IsUnmodified(r.Header, existing.LastUpdated) {
+		api.HandleErr(w, r, tx, http.StatusPreconditionFailed, api.ResourceModifiedError, nil)
+		return
+	}
+
+	var req tc.WebhookRequest
+	if userErr = api.Parse(r.Body, tx, &req); userErr != nil {
+		api.HandleErr(w, r, tx, http.StatusBadRequest, userErr, nil)
+		return
+	}
+	objectTypes, cdns, actions := filters(req)
+
+	if _, err := tx.Exec(updateQuery, req.Name, req.URL, req.Secret, objectTypes, cdns, actions, req.Active == nil || *req.Active, existing.ID); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	resp, _, err := getWebhook(tx, existing.ID)
+	if err != nil {
+		api.HandleErr(w, r, tx, http.StatusInternalServerError, nil, err)
+		return
+	}
+
+	changeLogMsg := fmt.Sprintf("WEBHOOK: %s, ID: %d, ACTION: Updated", resp.Name, resp.ID)
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Webhook '"+resp.Name+"' updated", resp)
+}
+
+// Delete is the handler for DELETE requests to /webhooks/{id}.
+func Delete(w http.ResponseWriter, r *http.Request) {
+	inf, userErr, sysErr, errCode := api.NewInfo(r, []string{"id"}, []string{"id"})
+	tx := inf.Tx.Tx
+	if userErr != nil || sysErr != nil {
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+	defer inf.Close()
+
+	existing, code := getExisting(w, r, inf)
+	if code != http.StatusOK {
+		return
+	}
+
+	if _, err := tx.Exec(deleteQuery, existing.ID); err != nil {
+		userErr, sysErr, errCode = api.ParseDBError(err)
+		api.HandleErr(w, r, tx, errCode, userErr, sysErr)
+		return
+	}
+
+	changeLogMsg := fmt.Sprintf("WEBHOOK: %s, ID: %d, ACTION: Deleted", existing.Name, existing.ID)
+	api.CreateChangeLogRawTx(api.ApiChange, changeLogMsg, inf.User, tx)
+
+	api.WriteRespAlertObj(w, r, tc.SuccessLevel, "Webhook '"+existing.Name+"' deleted", existing)
+}
@@ -0,0 +1,34 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiSystemAPIUsage is the API version-relative path to the
+// /system/api-usage endpoint.
+const apiSystemAPIUsage = "/system/api-usage"
+
+// GetAPIUsage retrieves the usage of the Traffic Ops API by each user. The
+// range of days, grouping, and ordering of the usage may be given in the
+// query parameters of opts.
+func (to *Session) GetAPIUsage(opts RequestOptions) (tc.APIUsageResponse, toclientlib.ReqInf, error) {
+	var data tc.APIUsageResponse
+	reqInf, err := to.get(apiSystemAPIUsage, opts, &data)
+	return data, reqInf, err
+}
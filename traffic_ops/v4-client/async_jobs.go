@@ -0,0 +1,90 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiAsyncJobs is the API version-relative path for the /async_jobs/{id} API
+// endpoint.
+const apiAsyncJobs = "/async_jobs/%d"
+
+// DefaultAsyncJobPollInterval is the interval at which WaitForAsyncJob polls
+// the status of an asynchronous job if it's given no interval.
+const DefaultAsyncJobPollInterval = 5 * time.Second
+
+// withAsync returns a copy of the request options with which the endpoint is
+// requested to run as an asynchronous job.
+func withAsync(opts RequestOptions) RequestOptions {
+	params := url.Values{}
+	for k, v := range opts.QueryParameters {
+		params[k] = v
+	}
+	params.Set("async", "true")
+	opts.QueryParameters = params
+	return opts
+}
+
+// GetAsyncJob gets the status and progress of the asynchronous job with the
+// given ID.
+func (to *Session) GetAsyncJob(id int, opts RequestOptions) (tc.AsyncStatusResponse, toclientlib.ReqInf, error) {
+	route := fmt.Sprintf(apiAsyncJobs, id)
+	var data tc.AsyncStatusResponse
+	reqInf, err := to.get(route, opts, &data)
+	return data, reqInf, err
+}
+
+// WaitForAsyncJob polls the status of the asynchronous job with the given ID
+// at the given interval until it finishes, returning its final status. An
+// error is returned if the job fails, if its status can't be requested, or if
+// the context is cancelled or its deadline passes before the job finishes.
+func (to *Session) WaitForAsyncJob(ctx context.Context, id int, pollInterval time.Duration, opts RequestOptions) (tc.AsyncStatus, toclientlib.ReqInf, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultAsyncJobPollInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		resp, reqInf, err := to.GetAsyncJob(id, opts)
+		if err != nil {
+			return resp.Response, reqInf, fmt.Errorf("getting status of asynchronous job #%d: %w", id, err)
+		}
+		if resp.Response.Status == tc.AsyncStatusFailed {
+			msg := ""
+			if resp.Response.Message != nil {
+				msg = *resp.Response.Message
+			}
+			return resp.Response, reqInf, fmt.Errorf("asynchronous job #%d failed: %s", id, msg)
+		}
+		if resp.Response.Finished() {
+			return resp.Response, reqInf, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return resp.Response, reqInf, fmt.Errorf("waiting for asynchronous job #%d: %w", id, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiCacheConfig is the API version-relative path to the
+// /cache-config/{{server}} API endpoint. It is intended to be used with
+// fmt.Sprintf to insert the host name of the server of interest.
+const apiCacheConfig = "/cache-config/%s"
+
+// GetCacheConfig returns the bundle of data the cache server with the given
+// host name needs to generate its configuration.
+func (to *Session) GetCacheConfig(hostName string, opts RequestOptions) (tc.CacheConfigResponse, toclientlib.ReqInf, error) {
+	var resp tc.CacheConfigResponse
+	reqInf, err := to.get(fmt.Sprintf(apiCacheConfig, url.PathEscape(hostName)), opts, &resp)
+	return resp, reqInf, err
+}
@@ -30,7 +30,7 @@ const apiCachegroups = "/cachegroups"
 func (to *Session) CreateCacheGroup(cachegroup tc.CacheGroupNullable, opts RequestOptions) (tc.CacheGroupDetailResponse, toclientlib.ReqInf, error) {
 	var resp tc.CacheGroupDetailResponse
 	if cachegroup.TypeID == nil && cachegroup.Type != nil {
-		opts := NewRequestOptions()
+		opts := newRequestOptionsFrom(opts)
 		opts.QueryParameters.Set("name", *cachegroup.Type)
 		ty, _, err := to.GetTypes(opts)
 		if err != nil {
@@ -43,7 +43,7 @@ func (to *Session) CreateCacheGroup(cachegroup tc.CacheGroupNullable, opts Reque
 	}
 
 	if cachegroup.ParentCachegroupID == nil && cachegroup.ParentName != nil {
-		opts := NewRequestOptions()
+		opts := newRequestOptionsFrom(opts)
 		opts.QueryParameters.Set("name", *cachegroup.ParentName)
 		p, _, err := to.GetCacheGroups(opts)
 		if err != nil {
@@ -58,7 +58,7 @@ func (to *Session) CreateCacheGroup(cachegroup tc.CacheGroupNullable, opts Reque
 	}
 
 	if cachegroup.SecondaryParentCachegroupID == nil && cachegroup.SecondaryParentName != nil {
-		opts := NewRequestOptions()
+		opts := newRequestOptionsFrom(opts)
 		opts.QueryParameters.Set("name", *cachegroup.SecondaryParentName)
 		p, _, err := to.GetCacheGroups(opts)
 		if err != nil {
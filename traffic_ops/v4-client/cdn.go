@@ -76,3 +76,14 @@ func (to *Session) QueueUpdatesForCDN(cdnID int, queueUpdate bool, opts RequestO
 	reqInf, err := to.post(path, opts, req, &resp)
 	return resp, reqInf, err
 }
+
+// QueueUpdatesForCDNAsync starts an asynchronous job that does what
+// QueueUpdatesForCDN does, returning the job's ID, which may be given to
+// WaitForAsyncJob.
+func (to *Session) QueueUpdatesForCDNAsync(cdnID int, queueUpdate bool, opts RequestOptions) (tc.AsyncJobResponse, toclientlib.ReqInf, error) {
+	req := tc.CDNQueueUpdateRequest{Action: queueUpdateActions[queueUpdate]}
+	var resp tc.AsyncJobResponse
+	path := fmt.Sprintf("/cdns/%d/queue_update", cdnID)
+	reqInf, err := to.post(path, withAsync(opts), req, &resp)
+	return resp, reqInf, err
+}
@@ -0,0 +1,53 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiCDNCoverageZones is the API version-relative path to the
+// /cdns/{{name}}/coverage_zones API endpoint. It is intended to be used with
+// fmt.Sprintf to insert the name of the CDN of interest.
+const apiCDNCoverageZones = "/cdns/%s/coverage_zones"
+
+// GetCDNCoverageZones returns the Coverage Zone File of the CDN with the
+// given name.
+func (to *Session) GetCDNCoverageZones(name string, opts RequestOptions) (tc.CDNCoverageZonesResponse, toclientlib.ReqInf, error) {
+	var resp tc.CDNCoverageZonesResponse
+	reqInf, err := to.get(fmt.Sprintf(apiCDNCoverageZones, url.PathEscape(name)), opts, &resp)
+	return resp, reqInf, err
+}
+
+// UpdateCDNCoverageZones creates or replaces the Coverage Zone File of the
+// CDN with the given name.
+func (to *Session) UpdateCDNCoverageZones(name string, file tc.CoverageZoneFile, opts RequestOptions) (tc.CDNCoverageZonesResponse, toclientlib.ReqInf, error) {
+	var resp tc.CDNCoverageZonesResponse
+	reqInf, err := to.put(fmt.Sprintf(apiCDNCoverageZones, url.PathEscape(name)), opts, file, &resp)
+	return resp, reqInf, err
+}
+
+// DeleteCDNCoverageZones removes the Coverage Zone File of the CDN with the
+// given name.
+func (to *Session) DeleteCDNCoverageZones(name string, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	var resp tc.Alerts
+	reqInf, err := to.del(fmt.Sprintf(apiCDNCoverageZones, url.PathEscape(name)), opts, &resp)
+	return resp, reqInf, err
+}
@@ -0,0 +1,52 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiCDNSOA is the API version-relative path to the /cdns/{{name}}/soa API
+// endpoint. It is intended to be used with fmt.Sprintf to insert the name of
+// the CDN of interest.
+const apiCDNSOA = "/cdns/%s/soa"
+
+// GetCDNSOA returns the SOA of the zones of the CDN with the given name.
+func (to *Session) GetCDNSOA(name string, opts RequestOptions) (tc.CDNSOAResponse, toclientlib.ReqInf, error) {
+	var resp tc.CDNSOAResponse
+	reqInf, err := to.get(fmt.Sprintf(apiCDNSOA, url.PathEscape(name)), opts, &resp)
+	return resp, reqInf, err
+}
+
+// UpdateCDNSOA creates or replaces the SOA of the zones of the CDN with the
+// given name.
+func (to *Session) UpdateCDNSOA(name string, soa tc.CDNSOA, opts RequestOptions) (tc.CDNSOAResponse, toclientlib.ReqInf, error) {
+	var resp tc.CDNSOAResponse
+	reqInf, err := to.put(fmt.Sprintf(apiCDNSOA, url.PathEscape(name)), opts, soa, &resp)
+	return resp, reqInf, err
+}
+
+// DeleteCDNSOA removes the SOA of the zones of the CDN with the given name,
+// so that its snapshots go back to using its Parameters.
+func (to *Session) DeleteCDNSOA(name string, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	var resp tc.Alerts
+	reqInf, err := to.del(fmt.Sprintf(apiCDNSOA, url.PathEscape(name)), opts, &resp)
+	return resp, reqInf, err
+}
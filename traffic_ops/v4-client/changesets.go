@@ -0,0 +1,107 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// apiChangesets is the API version-relative path to the /changesets API
+	// endpoint.
+	apiChangesets = "/changesets"
+
+	// apiChangesetEdits is the API version-relative path to the
+	// /changesets/{{ID}}/edits API endpoint. It is intended to be used with
+	// fmt.Sprintf to insert the ID of the Changeset of interest.
+	apiChangesetEdits = apiChangesets + "/%d/edits"
+
+	// apiChangesetEditID is the API version-relative path to the
+	// /changesets/{{ID}}/edits/{{edit ID}} API endpoint. It is intended to be
+	// used with fmt.Sprintf to insert the IDs of the Changeset and edit of
+	// interest.
+	apiChangesetEditID = apiChangesetEdits + "/%d"
+
+	// apiChangesetPreview is the API version-relative path to the
+	// /changesets/{{ID}}/preview API endpoint.
+	apiChangesetPreview = apiChangesets + "/%d/preview"
+
+	// apiChangesetCommit is the API version-relative path to the
+	// /changesets/{{ID}}/commit API endpoint.
+	apiChangesetCommit = apiChangesets + "/%d/commit"
+
+	// apiChangesetDiscard is the API version-relative path to the
+	// /changesets/{{ID}}/discard API endpoint.
+	apiChangesetDiscard = apiChangesets + "/%d/discard"
+)
+
+// GetChangesets returns a list of Changesets, with their edits.
+func (to *Session) GetChangesets(opts RequestOptions) (tc.ChangesetsResponse, toclientlib.ReqInf, error) {
+	var data tc.ChangesetsResponse
+	reqInf, err := to.get(apiChangesets, opts, &data)
+	return data, reqInf, err
+}
+
+// CreateChangeset creates a new, empty, open Changeset.
+func (to *Session) CreateChangeset(changeset tc.ChangesetRequest, opts RequestOptions) (tc.ChangesetResponse, toclientlib.ReqInf, error) {
+	var data tc.ChangesetResponse
+	reqInf, err := to.post(apiChangesets, opts, changeset, &data)
+	return data, reqInf, err
+}
+
+// AddChangesetEdit stages an edit in the open Changeset identified by 'id'.
+// Nothing is changed until the Changeset is committed.
+func (to *Session) AddChangesetEdit(id int, edit tc.ChangesetEditRequest, opts RequestOptions) (tc.ChangesetResponse, toclientlib.ReqInf, error) {
+	var data tc.ChangesetResponse
+	reqInf, err := to.post(fmt.Sprintf(apiChangesetEdits, id), opts, edit, &data)
+	return data, reqInf, err
+}
+
+// DeleteChangesetEdit removes the edit identified by 'editID' from the open
+// Changeset identified by 'id'.
+func (to *Session) DeleteChangesetEdit(id, editID int, opts RequestOptions) (tc.ChangesetResponse, toclientlib.ReqInf, error) {
+	var data tc.ChangesetResponse
+	reqInf, err := to.del(fmt.Sprintf(apiChangesetEditID, id, editID), opts, &data)
+	return data, reqInf, err
+}
+
+// PreviewChangeset returns the combined diff of the edits of the Changeset
+// identified by 'id', and how they'd change the Snapshots of the CDNs they
+// affect, without applying them.
+func (to *Session) PreviewChangeset(id int, opts RequestOptions) (tc.ChangesetPreviewResponse, toclientlib.ReqInf, error) {
+	var data tc.ChangesetPreviewResponse
+	reqInf, err := to.get(fmt.Sprintf(apiChangesetPreview, id), opts, &data)
+	return data, reqInf, err
+}
+
+// CommitChangeset applies all of the edits of the Changeset identified by
+// 'id' at once; if any of them fails, none are applied.
+func (to *Session) CommitChangeset(id int, opts RequestOptions) (tc.ChangesetResponse, toclientlib.ReqInf, error) {
+	var data tc.ChangesetResponse
+	reqInf, err := to.post(fmt.Sprintf(apiChangesetCommit, id), opts, nil, &data)
+	return data, reqInf, err
+}
+
+// DiscardChangeset closes the Changeset identified by 'id' without applying
+// its edits.
+func (to *Session) DiscardChangeset(id int, opts RequestOptions) (tc.ChangesetResponse, toclientlib.ReqInf, error) {
+	var data tc.ChangesetResponse
+	reqInf, err := to.post(fmt.Sprintf(apiChangesetDiscard, id), opts, nil, &data)
+	return data, reqInf, err
+}
@@ -51,6 +51,18 @@ func (to *Session) SnapshotCRConfig(opts RequestOptions) (tc.PutSnapshotResponse
 	return resp, reqInf, err
 }
 
+// SnapshotCRConfigAsync starts an asynchronous job that creates a new Snapshot
+// for the CDN with the given Name, returning the job's ID, which may be given
+// to WaitForAsyncJob.
+func (to *Session) SnapshotCRConfigAsync(opts RequestOptions) (tc.AsyncJobResponse, toclientlib.ReqInf, error) {
+	var resp tc.AsyncJobResponse
+	if opts.QueryParameters == nil || (opts.QueryParameters.Get("cdn") == "" && opts.QueryParameters.Get("cdnID") == "") {
+		return resp, toclientlib.ReqInf{}, errors.New("cannot take Snapshot of unidentified CDN - set 'cdn' or 'cdnID' query parameter")
+	}
+	reqInf, err := to.put(apiSnapshot, withAsync(opts), nil, &resp)
+	return resp, reqInf, err
+}
+
 // GetCRConfigNew returns the *new* Snapshot for the given CDN from Traffic
 // Ops.
 func (to *Session) GetCRConfigNew(cdn string, opts RequestOptions) (tc.SnapshotResponse, toclientlib.ReqInf, error) {
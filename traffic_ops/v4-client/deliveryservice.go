@@ -135,7 +135,7 @@ func (to *Session) CreateDeliveryService(ds tc.DeliveryServiceV4, opts RequestOp
 	var reqInf toclientlib.ReqInf
 	var resp tc.DeliveryServicesResponseV4
 	if ds.TypeID == nil && ds.Type != nil {
-		typeOpts := NewRequestOptions()
+		typeOpts := newRequestOptionsFrom(opts)
 		typeOpts.QueryParameters.Set("name", ds.Type.String())
 		ty, _, err := to.GetTypes(typeOpts)
 		if err != nil {
@@ -148,7 +148,7 @@ func (to *Session) CreateDeliveryService(ds tc.DeliveryServiceV4, opts RequestOp
 	}
 
 	if ds.CDNID == nil && ds.CDNName != nil {
-		cdnOpts := NewRequestOptions()
+		cdnOpts := newRequestOptionsFrom(opts)
 		cdnOpts.QueryParameters.Set("name", *ds.CDNName)
 		cdns, _, err := to.GetCDNs(cdnOpts)
 		if err != nil {
@@ -162,7 +162,7 @@ func (to *Session) CreateDeliveryService(ds tc.DeliveryServiceV4, opts RequestOp
 	}
 
 	if ds.ProfileID == nil && ds.ProfileName != nil {
-		profileOpts := NewRequestOptions()
+		profileOpts := newRequestOptionsFrom(opts)
 		profileOpts.QueryParameters.Set("name", *ds.ProfileName)
 		profiles, _, err := to.GetProfiles(profileOpts)
 		if err != nil {
@@ -175,7 +175,7 @@ func (to *Session) CreateDeliveryService(ds tc.DeliveryServiceV4, opts RequestOp
 	}
 
 	if ds.TenantID == nil && ds.Tenant != nil {
-		tenantOpts := NewRequestOptions()
+		tenantOpts := newRequestOptionsFrom(opts)
 		tenantOpts.QueryParameters.Set("name", *ds.Tenant)
 		ten, _, err := to.GetTenants(tenantOpts)
 		if err != nil {
@@ -187,7 +187,7 @@ func (to *Session) CreateDeliveryService(ds tc.DeliveryServiceV4, opts RequestOp
 		ds.TenantID = &ten.Response[0].ID
 	}
 
-	reqInf, err := to.post(apiDeliveryServices, RequestOptions{Header: opts.Header}, ds, &resp)
+	reqInf, err := to.post(apiDeliveryServices, RequestOptions{Header: opts.Header, Context: opts.Context}, ds, &resp)
 	if err != nil {
 		return resp, reqInf, err
 	}
@@ -0,0 +1,69 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// apiDeliveryServicesCachePolicies is the API version-relative route to
+	// the /deliveryservices/cache-policies endpoint.
+	apiDeliveryServicesCachePolicies = apiDeliveryServices + "/cache-policies"
+
+	// apiDeliveryServiceCachePolicy is the API path on which Traffic Ops
+	// serves the cache policy of a specific Delivery Service identified by an
+	// integral, unique identifier. It is intended to be used with fmt.Sprintf
+	// to insert its required path parameter (namely the ID of the Delivery
+	// Service of interest).
+	apiDeliveryServiceCachePolicy = apiDeliveryServiceID + "/cache-policy"
+)
+
+// GetDeliveryServiceCachePolicy gets the cache policy of the Delivery Service
+// identified by the integral, unique identifier 'id'.
+func (to *Session) GetDeliveryServiceCachePolicy(id int, opts RequestOptions) (tc.DeliveryServiceCachePolicyResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceCachePolicyResponse
+	reqInf, err := to.get(fmt.Sprintf(apiDeliveryServiceCachePolicy, id), opts, &data)
+	return data, reqInf, err
+}
+
+// GetAllDeliveryServiceCachePolicies gets the cache policies of every
+// Delivery Service which has one. Pass the "cdn" query parameter in opts to
+// get only those of the Delivery Services in a CDN.
+func (to *Session) GetAllDeliveryServiceCachePolicies(opts RequestOptions) (tc.CDNDeliveryServiceCachePoliciesResponse, toclientlib.ReqInf, error) {
+	var data tc.CDNDeliveryServiceCachePoliciesResponse
+	reqInf, err := to.get(apiDeliveryServicesCachePolicies, opts, &data)
+	return data, reqInf, err
+}
+
+// UpdateDeliveryServiceCachePolicy replaces the cache policy of the Delivery
+// Service identified by the integral, unique identifier 'id'.
+func (to *Session) UpdateDeliveryServiceCachePolicy(id int, policy tc.DeliveryServiceCachePolicyRequest, opts RequestOptions) (tc.DeliveryServiceCachePolicyResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceCachePolicyResponse
+	reqInf, err := to.put(fmt.Sprintf(apiDeliveryServiceCachePolicy, id), opts, policy, &data)
+	return data, reqInf, err
+}
+
+// DeleteDeliveryServiceCachePolicy removes the cache policy of the Delivery
+// Service identified by the integral, unique identifier 'id'.
+func (to *Session) DeleteDeliveryServiceCachePolicy(id int, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	reqInf, err := to.del(fmt.Sprintf(apiDeliveryServiceCachePolicy, id), opts, &alerts)
+	return alerts, reqInf, err
+}
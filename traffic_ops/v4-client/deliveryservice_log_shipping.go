@@ -0,0 +1,69 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// apiDeliveryServicesLogShipping is the API version-relative route to
+	// the /deliveryservices/log-shipping endpoint.
+	apiDeliveryServicesLogShipping = apiDeliveryServices + "/log-shipping"
+
+	// apiDeliveryServiceLogShipping is the API path on which Traffic Ops
+	// serves the log shipping configuration of a specific Delivery Service
+	// identified by an integral, unique identifier. It is intended to be used
+	// with fmt.Sprintf to insert its required path parameter (namely the ID
+	// of the Delivery Service of interest).
+	apiDeliveryServiceLogShipping = apiDeliveryServiceID + "/log-shipping"
+)
+
+// GetDeliveryServiceLogShipping gets the log shipping configuration of the
+// Delivery Service identified by the integral, unique identifier 'id'.
+func (to *Session) GetDeliveryServiceLogShipping(id int, opts RequestOptions) (tc.DeliveryServiceLogShippingResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceLogShippingResponse
+	reqInf, err := to.get(fmt.Sprintf(apiDeliveryServiceLogShipping, id), opts, &data)
+	return data, reqInf, err
+}
+
+// GetAllDeliveryServiceLogShipping gets the log shipping configuration of
+// every Delivery Service which has any. Pass the "cdn" query parameter in
+// opts to get only those of the Delivery Services in a CDN.
+func (to *Session) GetAllDeliveryServiceLogShipping(opts RequestOptions) (tc.CDNDeliveryServiceLogShippingResponse, toclientlib.ReqInf, error) {
+	var data tc.CDNDeliveryServiceLogShippingResponse
+	reqInf, err := to.get(apiDeliveryServicesLogShipping, opts, &data)
+	return data, reqInf, err
+}
+
+// UpdateDeliveryServiceLogShipping sets the log shipping configuration of the
+// Delivery Service identified by the integral, unique identifier 'id'.
+func (to *Session) UpdateDeliveryServiceLogShipping(id int, shipping tc.DeliveryServiceLogShippingRequest, opts RequestOptions) (tc.DeliveryServiceLogShippingResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceLogShippingResponse
+	reqInf, err := to.put(fmt.Sprintf(apiDeliveryServiceLogShipping, id), opts, shipping, &data)
+	return data, reqInf, err
+}
+
+// DeleteDeliveryServiceLogShipping deletes the log shipping configuration of
+// the Delivery Service identified by the integral, unique identifier 'id'.
+func (to *Session) DeleteDeliveryServiceLogShipping(id int, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	reqInf, err := to.del(fmt.Sprintf(apiDeliveryServiceLogShipping, id), opts, &alerts)
+	return alerts, reqInf, err
+}
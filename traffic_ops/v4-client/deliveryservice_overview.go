@@ -0,0 +1,35 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiDeliveryServicesOverview is the API version-relative route to the
+// /ui/deliveryservices-overview endpoint.
+const apiDeliveryServicesOverview = "/ui/deliveryservices-overview"
+
+// GetDeliveryServicesOverview gets a summary of every Delivery Service the
+// user can see, including its health, certificate expiration and pending
+// Delivery Service Requests. Pass the "cdn" query parameter in opts to get
+// only those of the Delivery Services in a CDN.
+func (to *Session) GetDeliveryServicesOverview(opts RequestOptions) (tc.DeliveryServicesOverviewResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServicesOverviewResponse
+	reqInf, err := to.get(apiDeliveryServicesOverview, opts, &data)
+	return data, reqInf, err
+}
@@ -0,0 +1,61 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// apiDeliveryServicesPathRules is the API version-relative route to the
+	// /deliveryservices/path-rules endpoint.
+	apiDeliveryServicesPathRules = apiDeliveryServices + "/path-rules"
+
+	// apiDeliveryServicePathRules is the API path on which Traffic Ops serves
+	// the path rules of a specific Delivery Service identified by an
+	// integral, unique identifier. It is intended to be used with fmt.Sprintf
+	// to insert its required path parameter (namely the ID of the Delivery
+	// Service of interest).
+	apiDeliveryServicePathRules = apiDeliveryServiceID + "/path-rules"
+)
+
+// GetDeliveryServicePathRules gets the path rules of the Delivery Service
+// identified by the integral, unique identifier 'id'.
+func (to *Session) GetDeliveryServicePathRules(id int, opts RequestOptions) (tc.DeliveryServicePathRulesResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServicePathRulesResponse
+	reqInf, err := to.get(fmt.Sprintf(apiDeliveryServicePathRules, id), opts, &data)
+	return data, reqInf, err
+}
+
+// GetAllDeliveryServicePathRules gets the path rules of every Delivery
+// Service which has any. Pass the "cdn" query parameter in opts to get only
+// those of the Delivery Services in a CDN.
+func (to *Session) GetAllDeliveryServicePathRules(opts RequestOptions) (tc.CDNDeliveryServicePathRulesResponse, toclientlib.ReqInf, error) {
+	var data tc.CDNDeliveryServicePathRulesResponse
+	reqInf, err := to.get(apiDeliveryServicesPathRules, opts, &data)
+	return data, reqInf, err
+}
+
+// UpdateDeliveryServicePathRules replaces all of the path rules of the
+// Delivery Service identified by the integral, unique identifier 'id'.
+func (to *Session) UpdateDeliveryServicePathRules(id int, rules tc.DeliveryServicePathRulesRequest, opts RequestOptions) (tc.DeliveryServicePathRulesResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServicePathRulesResponse
+	reqInf, err := to.put(fmt.Sprintf(apiDeliveryServicePathRules, id), opts, rules, &data)
+	return data, reqInf, err
+}
@@ -0,0 +1,66 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// apiDeliveryServicesRewriteRules is the API version-relative route to
+	// the /deliveryservices/rewrite-rules endpoint.
+	apiDeliveryServicesRewriteRules = apiDeliveryServices + "/rewrite-rules"
+
+	// apiDeliveryServiceRewriteRules is the API path on which Traffic Ops
+	// serves the rewrite rules of a specific Delivery Service identified by
+	// an integral, unique identifier. It is intended to be used with
+	// fmt.Sprintf to insert its required path parameter (namely the ID of the
+	// Delivery Service of interest).
+	apiDeliveryServiceRewriteRules = apiDeliveryServiceID + "/rewrite-rules"
+)
+
+// GetDeliveryServiceRewriteRules gets the rewrite rules of the Delivery
+// Service identified by the integral, unique identifier 'id'.
+func (to *Session) GetDeliveryServiceRewriteRules(id int, opts RequestOptions) (tc.DeliveryServiceRewriteRulesResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceRewriteRulesResponse
+	reqInf, err := to.get(fmt.Sprintf(apiDeliveryServiceRewriteRules, id), opts, &data)
+	return data, reqInf, err
+}
+
+// GetAllDeliveryServiceRewriteRules gets the rewrite rules of every Delivery
+// Service which has any. Pass the "cdn" query parameter in opts to get only
+// those of the Delivery Services in a CDN.
+func (to *Session) GetAllDeliveryServiceRewriteRules(opts RequestOptions) (tc.CDNDeliveryServiceRewriteRulesResponse, toclientlib.ReqInf, error) {
+	var data tc.CDNDeliveryServiceRewriteRulesResponse
+	reqInf, err := to.get(apiDeliveryServicesRewriteRules, opts, &data)
+	return data, reqInf, err
+}
+
+// UpdateDeliveryServiceRewriteRules replaces all of the rewrite rules of the
+// Delivery Service identified by the integral, unique identifier 'id' with
+// the given rules, in order.
+func (to *Session) UpdateDeliveryServiceRewriteRules(id int, rules []tc.DeliveryServiceRewriteRule, opts RequestOptions) (tc.DeliveryServiceRewriteRulesResponse, toclientlib.ReqInf, error) {
+	if rules == nil {
+		rules = []tc.DeliveryServiceRewriteRule{}
+	}
+	req := tc.DeliveryServiceRewriteRulesRequest{Rules: rules}
+	var data tc.DeliveryServiceRewriteRulesResponse
+	reqInf, err := to.put(fmt.Sprintf(apiDeliveryServiceRewriteRules, id), opts, req, &data)
+	return data, reqInf, err
+}
@@ -29,12 +29,12 @@ import (
 // apiOrigins is the full path to the /origins API route.
 const apiOrigins = "/origins"
 
-func (to *Session) originIDs(origin *tc.Origin) error {
+func (to *Session) originIDs(origin *tc.Origin, reqOpts RequestOptions) error {
 	if origin == nil {
 		return errors.New("invalid call to originIDs; nil origin")
 	}
 
-	opts := NewRequestOptions()
+	opts := newRequestOptionsFrom(reqOpts)
 	if origin.CachegroupID == nil && origin.Cachegroup != nil {
 		opts.QueryParameters.Set("name", *origin.Cachegroup)
 		p, _, err := to.GetCacheGroups(opts)
@@ -106,7 +106,7 @@ func (to *Session) CreateOrigin(origin tc.Origin, opts RequestOptions) (tc.Origi
 	var remoteAddr net.Addr
 	reqInf := toclientlib.ReqInf{CacheHitStatus: toclientlib.CacheHitStatusMiss, RemoteAddr: remoteAddr}
 
-	err := to.originIDs(&origin)
+	err := to.originIDs(&origin, opts)
 	if err != nil {
 		return originResp, reqInf, err
 	}
@@ -120,7 +120,7 @@ func (to *Session) UpdateOrigin(id int, origin tc.Origin, opts RequestOptions) (
 	var remoteAddr net.Addr
 	reqInf := toclientlib.ReqInf{CacheHitStatus: toclientlib.CacheHitStatusMiss, RemoteAddr: remoteAddr}
 
-	err := to.originIDs(&origin)
+	err := to.originIDs(&origin, opts)
 	if err != nil {
 		return originResp, reqInf, err
 	}
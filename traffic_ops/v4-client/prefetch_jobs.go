@@ -0,0 +1,83 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiPrefetchJobs is the API path on which Traffic Ops serves prefetch jobs.
+const apiPrefetchJobs = apiJobs + "/prefetch"
+
+// apiPrefetchJobID is the API path on which Traffic Ops serves a specific
+// prefetch job identified by an integral, unique identifier. It is intended
+// to be used with fmt.Sprintf to insert its required path parameter (namely
+// the ID of the prefetch job of interest).
+const apiPrefetchJobID = apiPrefetchJobs + "/%d"
+
+// apiPrefetchJobProgress is the API path on which cache servers report their
+// progress on a specific prefetch job identified by an integral, unique
+// identifier. It is intended to be used with fmt.Sprintf to insert its
+// required path parameter (namely the ID of the prefetch job of interest).
+const apiPrefetchJobProgress = apiPrefetchJobID + "/progress"
+
+// GetPrefetchJobs returns prefetch jobs, which may be filtered by the 'id'
+// and 'deliveryServiceId' query parameters of opts.
+func (to *Session) GetPrefetchJobs(opts RequestOptions) (tc.PrefetchJobsResponse, toclientlib.ReqInf, error) {
+	var data tc.PrefetchJobsResponse
+	reqInf, err := to.get(apiPrefetchJobs, opts, &data)
+	return data, reqInf, err
+}
+
+// GetPendingPrefetchJobs returns the prefetch jobs which have started that
+// the cache server identified by the integral, unique identifier 'serverID'
+// has yet to finish.
+func (to *Session) GetPendingPrefetchJobs(serverID int, opts RequestOptions) (tc.PrefetchJobsResponse, toclientlib.ReqInf, error) {
+	if opts.QueryParameters == nil {
+		opts.QueryParameters = url.Values{}
+	}
+	opts.QueryParameters.Set(tc.PrefetchJobServerIDQueryParam, strconv.Itoa(serverID))
+	return to.GetPrefetchJobs(opts)
+}
+
+// CreatePrefetchJob creates a prefetch job, which fetches content into the
+// cache servers of Cache Groups ahead of its being requested.
+func (to *Session) CreatePrefetchJob(job tc.PrefetchJobRequest, opts RequestOptions) (tc.PrefetchJobResponse, toclientlib.ReqInf, error) {
+	var data tc.PrefetchJobResponse
+	reqInf, err := to.post(apiPrefetchJobs, opts, job, &data)
+	return data, reqInf, err
+}
+
+// DeletePrefetchJob deletes the prefetch job identified by the integral,
+// unique identifier 'id'.
+func (to *Session) DeletePrefetchJob(id int, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	reqInf, err := to.del(fmt.Sprintf(apiPrefetchJobID, id), opts, &alerts)
+	return alerts, reqInf, err
+}
+
+// UpdatePrefetchJobProgress reports the progress of a cache server on the
+// prefetch job identified by the integral, unique identifier 'id'.
+func (to *Session) UpdatePrefetchJobProgress(id int, progress tc.PrefetchJobProgress, opts RequestOptions) (tc.PrefetchJobResponse, toclientlib.ReqInf, error) {
+	var data tc.PrefetchJobResponse
+	reqInf, err := to.put(fmt.Sprintf(apiPrefetchJobProgress, id), opts, progress, &data)
+	return data, reqInf, err
+}
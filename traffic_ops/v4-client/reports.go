@@ -0,0 +1,96 @@
+package client
+
+/*
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// apiBillingReport is the full path to the /reports/billing API endpoint.
+	apiBillingReport = "/reports/billing"
+
+	// apiOrphansReport is the full path to the /reports/orphans API endpoint.
+	apiOrphansReport = "/reports/orphans"
+
+	// apiOrphanDeletions is the full path to the /reports/orphans/deletions
+	// API endpoint.
+	apiOrphanDeletions = apiOrphansReport + "/deletions"
+
+	// apiOrphanDeletionsApply is the full path to the
+	// /reports/orphans/deletions/apply API endpoint.
+	apiOrphanDeletionsApply = apiOrphanDeletions + "/apply"
+
+	// apiOrphanDeletionID is the full path to the
+	// /reports/orphans/deletions/{{ID}} API endpoint. It is intended to be
+	// used with fmt.Sprintf to insert the ID of the staged deletion of
+	// interest.
+	apiOrphanDeletionID = apiOrphanDeletions + "/%d"
+)
+
+// GetBillingReport gets the billing report of the given month, which must be
+// in tc.BillingReportMonthFormat (e.g. "2022-05").
+func (to *Session) GetBillingReport(month string, opts RequestOptions) (tc.BillingReportResponse, toclientlib.ReqInf, error) {
+	if opts.QueryParameters == nil {
+		opts.QueryParameters = url.Values{}
+	}
+	opts.QueryParameters.Set("month", month)
+
+	var resp tc.BillingReportResponse
+	reqInf, err := to.get(apiBillingReport, opts, &resp)
+	return resp, reqInf, err
+}
+
+// GetOrphansReport gets the report of unused, or orphaned, objects. Pass the
+// "types" query parameter in opts to restrict it to some types of objects.
+func (to *Session) GetOrphansReport(opts RequestOptions) (tc.OrphansResponse, toclientlib.ReqInf, error) {
+	var resp tc.OrphansResponse
+	reqInf, err := to.get(apiOrphansReport, opts, &resp)
+	return resp, reqInf, err
+}
+
+// GetOrphanDeletions gets the deletions of orphaned objects staged for
+// review.
+func (to *Session) GetOrphanDeletions(opts RequestOptions) (tc.OrphanDeletionsResponse, toclientlib.ReqInf, error) {
+	var resp tc.OrphanDeletionsResponse
+	reqInf, err := to.get(apiOrphanDeletions, opts, &resp)
+	return resp, reqInf, err
+}
+
+// StageOrphanDeletions stages the deletions of the orphaned objects of the
+// requested types for review, returning those newly staged.
+func (to *Session) StageOrphanDeletions(req tc.OrphanDeletionsRequest, opts RequestOptions) (tc.OrphanDeletionsResponse, toclientlib.ReqInf, error) {
+	var resp tc.OrphanDeletionsResponse
+	reqInf, err := to.post(apiOrphanDeletions, opts, req, &resp)
+	return resp, reqInf, err
+}
+
+// ApplyOrphanDeletions carries out the requested staged deletions, returning
+// those which were carried out.
+func (to *Session) ApplyOrphanDeletions(req tc.OrphanDeletionsApplyRequest, opts RequestOptions) (tc.OrphanDeletionsResponse, toclientlib.ReqInf, error) {
+	var resp tc.OrphanDeletionsResponse
+	reqInf, err := to.post(apiOrphanDeletionsApply, opts, req, &resp)
+	return resp, reqInf, err
+}
+
+// DiscardOrphanDeletion discards the staged deletion identified by 'id'
+// without carrying it out.
+func (to *Session) DiscardOrphanDeletion(id int, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	reqInf, err := to.del(fmt.Sprintf(apiOrphanDeletionID, id), opts, &alerts)
+	return alerts, reqInf, err
+}
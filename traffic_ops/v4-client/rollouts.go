@@ -0,0 +1,70 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// apiRollouts is the API version-relative path to the /rollouts API
+	// endpoint.
+	apiRollouts = "/rollouts"
+
+	// apiRolloutAction is the API version-relative path to the
+	// /rollouts/{{ID}}/{{action}} API endpoints. It is intended to be used
+	// with fmt.Sprintf to insert the ID of the Rollout of interest and the
+	// action to take on it.
+	apiRolloutAction = apiRollouts + "/%d/%s"
+)
+
+// GetRollouts returns a list of Rollouts.
+func (to *Session) GetRollouts(opts RequestOptions) (tc.RolloutsResponse, toclientlib.ReqInf, error) {
+	var data tc.RolloutsResponse
+	reqInf, err := to.get(apiRollouts, opts, &data)
+	return data, reqInf, err
+}
+
+// CreateRollout starts a Rollout of the queued updates of a CDN.
+func (to *Session) CreateRollout(rollout tc.RolloutRequest, opts RequestOptions) (tc.RolloutResponse, toclientlib.ReqInf, error) {
+	var data tc.RolloutResponse
+	reqInf, err := to.post(apiRollouts, opts, rollout, &data)
+	return data, reqInf, err
+}
+
+// PauseRollout pauses the running Rollout identified by 'id'.
+func (to *Session) PauseRollout(id int, opts RequestOptions) (tc.RolloutResponse, toclientlib.ReqInf, error) {
+	return to.rolloutAction(id, "pause", opts)
+}
+
+// ResumeRollout resumes the paused or halted Rollout identified by 'id'.
+func (to *Session) ResumeRollout(id int, opts RequestOptions) (tc.RolloutResponse, toclientlib.ReqInf, error) {
+	return to.rolloutAction(id, "resume", opts)
+}
+
+// AbortRollout aborts the Rollout identified by 'id'.
+func (to *Session) AbortRollout(id int, opts RequestOptions) (tc.RolloutResponse, toclientlib.ReqInf, error) {
+	return to.rolloutAction(id, "abort", opts)
+}
+
+func (to *Session) rolloutAction(id int, action string, opts RequestOptions) (tc.RolloutResponse, toclientlib.ReqInf, error) {
+	var data tc.RolloutResponse
+	reqInf, err := to.post(fmt.Sprintf(apiRolloutAction, id, action), opts, nil, &data)
+	return data, reqInf, err
+}
@@ -0,0 +1,44 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiCDNRoutingTrace is the API version-relative path to the
+// /cdns/{{name}}/routing/trace API endpoint. It is intended to be used with
+// fmt.Sprintf to insert the name of the CDN of interest.
+const apiCDNRoutingTrace = "/cdns/%s/routing/trace"
+
+// GetRoutingTrace asks one of the Traffic Routers of the CDN with the given
+// name how it would route an HTTP request from the client IP address ip to
+// the given host. The request path, and the Traffic Router to ask, may be
+// given in the "path" and "router" query parameters of opts.
+func (to *Session) GetRoutingTrace(cdn, ip, host string, opts RequestOptions) (tc.RoutingTraceResponse, toclientlib.ReqInf, error) {
+	if opts.QueryParameters == nil {
+		opts.QueryParameters = url.Values{}
+	}
+	opts.QueryParameters.Set("ip", ip)
+	opts.QueryParameters.Set("host", host)
+	var resp tc.RoutingTraceResponse
+	reqInf, err := to.get(fmt.Sprintf(apiCDNRoutingTrace, url.PathEscape(cdn)), opts, &resp)
+	return resp, reqInf, err
+}
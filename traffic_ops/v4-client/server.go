@@ -42,7 +42,7 @@ func (to *Session) CreateServer(server tc.ServerV4, opts RequestOptions) (tc.Ale
 	reqInf := toclientlib.ReqInf{CacheHitStatus: toclientlib.CacheHitStatusMiss, RemoteAddr: remoteAddr}
 
 	if needAndCanFetch(server.CachegroupID, server.Cachegroup) {
-		innerOpts := NewRequestOptions()
+		innerOpts := newRequestOptionsFrom(opts)
 		innerOpts.QueryParameters.Set("name", *server.Cachegroup)
 		cg, reqInf, err := to.GetCacheGroups(innerOpts)
 		if err != nil {
@@ -57,7 +57,7 @@ func (to *Session) CreateServer(server tc.ServerV4, opts RequestOptions) (tc.Ale
 		server.CachegroupID = cg.Response[0].ID
 	}
 	if needAndCanFetch(server.CDNID, server.CDNName) {
-		innerOpts := NewRequestOptions()
+		innerOpts := newRequestOptionsFrom(opts)
 		innerOpts.QueryParameters.Set("name", *server.CDNName)
 		c, reqInf, err := to.GetCDNs(innerOpts)
 		if err != nil {
@@ -69,7 +69,7 @@ func (to *Session) CreateServer(server tc.ServerV4, opts RequestOptions) (tc.Ale
 		server.CDNID = &c.Response[0].ID
 	}
 	if needAndCanFetch(server.PhysLocationID, server.PhysLocation) {
-		innerOpts := NewRequestOptions()
+		innerOpts := newRequestOptionsFrom(opts)
 		innerOpts.QueryParameters.Set("name", *server.PhysLocation)
 		ph, reqInf, err := to.GetPhysLocations(innerOpts)
 		if err != nil {
@@ -81,7 +81,7 @@ func (to *Session) CreateServer(server tc.ServerV4, opts RequestOptions) (tc.Ale
 		server.PhysLocationID = &ph.Response[0].ID
 	}
 	if needAndCanFetch(server.StatusID, server.Status) {
-		innerOpts := NewRequestOptions()
+		innerOpts := newRequestOptionsFrom(opts)
 		innerOpts.QueryParameters.Set("name", *server.Status)
 		st, reqInf, err := to.GetStatuses(innerOpts)
 		if err != nil {
@@ -93,7 +93,7 @@ func (to *Session) CreateServer(server tc.ServerV4, opts RequestOptions) (tc.Ale
 		server.StatusID = &st.Response[0].ID
 	}
 	if (server.TypeID == nil || *server.TypeID == 0) && server.Type != "" {
-		innerOpts := NewRequestOptions()
+		innerOpts := newRequestOptionsFrom(opts)
 		innerOpts.QueryParameters.Set("name", server.Type)
 		ty, _, err := to.GetTypes(innerOpts)
 		if err != nil {
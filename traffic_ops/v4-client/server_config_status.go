@@ -0,0 +1,44 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiServersConfigStatus is the API version-relative path to the
+// /servers/config-status API endpoint.
+const apiServersConfigStatus = apiServers + "/config-status"
+
+// GetServersConfigStatus returns the state of the configuration of each
+// cache server, as of the last t3c-apply run it reported.
+func (to *Session) GetServersConfigStatus(opts RequestOptions) (tc.ServerConfigStatusesResponse, toclientlib.ReqInf, error) {
+	var data tc.ServerConfigStatusesResponse
+	reqInf, err := to.get(apiServersConfigStatus, opts, &data)
+	return data, reqInf, err
+}
+
+// SetServerConfigRun reports the outcome of a t3c-apply run on the server
+// identified by 'serverName'.
+func (to *Session) SetServerConfigRun(serverName string, run tc.ServerConfigRunRequest, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	path := apiServers + `/` + url.PathEscape(serverName) + `/config-status`
+	reqInf, err := to.post(path, opts, run, &alerts)
+	return alerts, reqInf, err
+}
@@ -0,0 +1,38 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiServerTrafficCtl is the API path on which Traffic Ops runs traffic_ctl
+// commands on a specific cache server identified by an integral, unique
+// identifier. It is intended to be used with fmt.Sprintf to insert its
+// required path parameter (namely the ID of the server of interest).
+const apiServerTrafficCtl = apiServers + "/%d/traffic_ctl"
+
+// RunServerTrafficCtl runs a whitelisted traffic_ctl command on the cache
+// server identified by the integral, unique identifier 'id'. A command which
+// runs but fails is not an error; its exit code is in the response.
+func (to *Session) RunServerTrafficCtl(id int, req tc.TrafficCtlRequest, opts RequestOptions) (tc.TrafficCtlResponse, toclientlib.ReqInf, error) {
+	var data tc.TrafficCtlResponse
+	reqInf, err := to.post(fmt.Sprintf(apiServerTrafficCtl, id), opts, req, &data)
+	return data, reqInf, err
+}
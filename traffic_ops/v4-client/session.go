@@ -17,6 +17,8 @@
 package client
 
 import (
+	"context"
+	"crypto/tls"
 	"net"
 	"net/http"
 	"net/url"
@@ -32,6 +34,10 @@ type RequestOptions struct {
 	Header http.Header
 	// Any and all query parameters to pass in the request.
 	QueryParameters url.Values
+	// Context, if not nil, bounds the request - which is canceled when it's
+	// done, e.g. at its deadline. This includes any requests a method makes
+	// on the request's behalf, such as to look up IDs by names.
+	Context context.Context
 }
 
 // NewRequestOptions returns a RequestOptions object with initialized, empty Header
@@ -43,6 +49,24 @@ func NewRequestOptions() RequestOptions {
 	}
 }
 
+// context returns the Context of the RequestOptions, or the background
+// Context if it has none.
+func (opts RequestOptions) context() context.Context {
+	if opts.Context == nil {
+		return context.Background()
+	}
+	return opts.Context
+}
+
+// newRequestOptionsFrom returns a RequestOptions object with initialized,
+// empty Header and QueryParameters, and the Context of opts - for requests
+// made on behalf of a request with opts.
+func newRequestOptionsFrom(opts RequestOptions) RequestOptions {
+	inner := NewRequestOptions()
+	inner.Context = opts.Context
+	return inner
+}
+
 // Login authenticates with Traffic Ops and returns the client object.
 //
 // Returns the logged in client, the remote address of Traffic Ops which was translated and used to log in, and any error. If the error is not nil, the remote address may or may not be nil, depending whether the error occurred before the login request.
@@ -106,6 +130,17 @@ func LoginWithToken(toURL string, token string, insecure bool, userAgent string,
 	return &Session{TOClient: *cl}, ip, err
 }
 
+// LoginWithCertificate functions identically to LoginWithAgent, but
+// authenticates as the user named by the given TLS client certificate rather
+// than with a username/password pair.
+func LoginWithCertificate(toURL string, cert tls.Certificate, insecure bool, userAgent string, useCache bool, requestTimeout time.Duration) (*Session, net.Addr, error) {
+	cl, ip, err := toclientlib.LoginWithCertificate(toURL, cert, insecure, userAgent, requestTimeout, apiVersions())
+	if err != nil {
+		return nil, nil, err
+	}
+	return &Session{TOClient: *cl}, ip, err
+}
+
 // LogoutWithAgent constructs an authenticated Session - exactly like
 // LoginWithAgent - and then immediately calls the '/logout' API endpoint to
 // end the session.
@@ -143,5 +178,5 @@ func (to *Session) req(method, path string, opts RequestOptions, body, response
 	if len(opts.QueryParameters) > 0 {
 		path += "?" + opts.QueryParameters.Encode()
 	}
-	return to.TOClient.Req(method, path, body, opts.Header, response)
+	return to.TOClient.ReqWithContext(opts.context(), method, path, body, opts.Header, response)
 }
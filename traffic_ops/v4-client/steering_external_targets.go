@@ -0,0 +1,50 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiSteeringExternalTargets is the API path on which Traffic Ops serves the
+// external CDN targets of a specific Steering Delivery Service identified by
+// an integral, unique identifier. It is intended to be used with fmt.Sprintf
+// to insert its required path parameter (namely the ID of the Delivery
+// Service of interest).
+const apiSteeringExternalTargets = "/steering/%d/external-targets"
+
+// GetSteeringExternalTargets retrieves the external CDN targets of the
+// Steering Delivery Service with the given ID.
+func (to *Session) GetSteeringExternalTargets(dsID int, opts RequestOptions) (tc.SteeringExternalTargetsResponse, toclientlib.ReqInf, error) {
+	var data tc.SteeringExternalTargetsResponse
+	reqInf, err := to.get(fmt.Sprintf(apiSteeringExternalTargets, dsID), opts, &data)
+	return data, reqInf, err
+}
+
+// UpdateSteeringExternalTargets replaces all of the external CDN targets of
+// the Steering Delivery Service with the given ID with the given targets.
+func (to *Session) UpdateSteeringExternalTargets(dsID int, targets []tc.SteeringExternalTarget, opts RequestOptions) (tc.SteeringExternalTargetsResponse, toclientlib.ReqInf, error) {
+	if targets == nil {
+		targets = []tc.SteeringExternalTarget{}
+	}
+	req := tc.SteeringExternalTargetsRequest{Targets: targets}
+	var data tc.SteeringExternalTargetsResponse
+	reqInf, err := to.put(fmt.Sprintf(apiSteeringExternalTargets, dsID), opts, req, &data)
+	return data, reqInf, err
+}
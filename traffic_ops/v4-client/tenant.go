@@ -38,7 +38,7 @@ func (to *Session) GetTenants(opts RequestOptions) (tc.GetTenantsResponse, tocli
 // CreateTenant creates the Tenant it's passed.
 func (to *Session) CreateTenant(t tc.Tenant, opts RequestOptions) (tc.TenantResponse, toclientlib.ReqInf, error) {
 	if t.ParentID == 0 && t.ParentName != "" {
-		parentOpts := NewRequestOptions()
+		parentOpts := newRequestOptionsFrom(opts)
 		parentOpts.QueryParameters.Set("name", t.ParentName)
 		tenant, reqInf, err := to.GetTenants(parentOpts)
 		if err != nil {
@@ -0,0 +1,53 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiTenantQuota is the API path on which Traffic Ops serves the quota of a
+// specific Tenant identified by an integral, unique identifier. It is intended
+// to be used with fmt.Sprintf to insert its required path parameter (namely
+// the ID of the Tenant of interest).
+const apiTenantQuota = apiTenantID + "/quota"
+
+// GetTenantQuota gets the quota - and current usage - of the Tenant
+// identified by the integral, unique identifier 'id'.
+func (to *Session) GetTenantQuota(id int, opts RequestOptions) (tc.TenantQuotaResponse, toclientlib.ReqInf, error) {
+	var data tc.TenantQuotaResponse
+	reqInf, err := to.get(fmt.Sprintf(apiTenantQuota, id), opts, &data)
+	return data, reqInf, err
+}
+
+// UpdateTenantQuota sets the quota of the Tenant identified by the integral,
+// unique identifier 'id'.
+func (to *Session) UpdateTenantQuota(id int, quota tc.TenantQuotaRequest, opts RequestOptions) (tc.TenantQuotaResponse, toclientlib.ReqInf, error) {
+	var data tc.TenantQuotaResponse
+	reqInf, err := to.put(fmt.Sprintf(apiTenantQuota, id), opts, quota, &data)
+	return data, reqInf, err
+}
+
+// DeleteTenantQuota removes the quota of the Tenant identified by the
+// integral, unique identifier 'id'.
+func (to *Session) DeleteTenantQuota(id int, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	reqInf, err := to.del(fmt.Sprintf(apiTenantQuota, id), opts, &alerts)
+	return alerts, reqInf, err
+}
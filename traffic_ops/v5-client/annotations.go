@@ -0,0 +1,64 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// apiAnnotations is the API version-relative path to the /annotations
+	// API endpoint.
+	apiAnnotations = "/annotations"
+
+	// apiAnnotationID is the API version-relative path to the
+	// /annotations/{{ID}} API endpoint. It is intended to be used with
+	// fmt.Sprintf to insert the ID of the Annotation of interest.
+	apiAnnotationID = apiAnnotations + "/%d"
+)
+
+// GetAnnotations returns a list of Annotations. Pass the "active" query
+// parameter in opts to get only the Annotations in effect now.
+func (to *Session) GetAnnotations(opts RequestOptions) (tc.AnnotationsResponse, toclientlib.ReqInf, error) {
+	var data tc.AnnotationsResponse
+	reqInf, err := to.get(apiAnnotations, opts, &data)
+	return data, reqInf, err
+}
+
+// CreateAnnotation creates an Annotation.
+func (to *Session) CreateAnnotation(annotation tc.AnnotationRequest, opts RequestOptions) (tc.AnnotationResponse, toclientlib.ReqInf, error) {
+	var data tc.AnnotationResponse
+	reqInf, err := to.post(apiAnnotations, opts, annotation, &data)
+	return data, reqInf, err
+}
+
+// UpdateAnnotation replaces the Annotation identified by 'id' with the one
+// provided.
+func (to *Session) UpdateAnnotation(id int, annotation tc.AnnotationRequest, opts RequestOptions) (tc.AnnotationResponse, toclientlib.ReqInf, error) {
+	var data tc.AnnotationResponse
+	reqInf, err := to.put(fmt.Sprintf(apiAnnotationID, id), opts, annotation, &data)
+	return data, reqInf, err
+}
+
+// DeleteAnnotation deletes the Annotation identified by 'id'.
+func (to *Session) DeleteAnnotation(id int, opts RequestOptions) (tc.AnnotationResponse, toclientlib.ReqInf, error) {
+	var data tc.AnnotationResponse
+	reqInf, err := to.del(fmt.Sprintf(apiAnnotationID, id), opts, &data)
+	return data, reqInf, err
+}
@@ -0,0 +1,30 @@
+package client
+
+/*
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiCachesStorage is the full path to the /caches/storage API endpoint.
+const apiCachesStorage = "/caches/storage"
+
+// GetCachesStorage gets the cache storage utilization of cache servers, as
+// polled by Traffic Monitor. The 'cdn' query parameter limits it to the cache
+// servers of a CDN.
+func (to *Session) GetCachesStorage(opts RequestOptions) (tc.CacheStorageResponse, toclientlib.ReqInf, error) {
+	var resp tc.CacheStorageResponse
+	reqInf, err := to.get(apiCachesStorage, opts, &resp)
+	return resp, reqInf, err
+}
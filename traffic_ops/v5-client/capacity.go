@@ -0,0 +1,33 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiCapacity is the API version-relative path to the /capacity API endpoint.
+const apiCapacity = "/capacity"
+
+// GetCapacity retrieves the modeled capacity, utilization, and headroom of
+// cache servers, optionally filtered by the 'cdn', 'cachegroup', and 'ds'
+// query parameters.
+func (to *Session) GetCapacity(opts RequestOptions) (tc.CapacityResponse, toclientlib.ReqInf, error) {
+	var data tc.CapacityResponse
+	reqInf, err := to.get(apiCapacity, opts, &data)
+	return data, reqInf, err
+}
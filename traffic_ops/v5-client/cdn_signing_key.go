@@ -0,0 +1,54 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiCDNSigningKey is the API version-relative path to the
+// /cdns/{{name}}/signing_key API endpoint. It is intended to be used with
+// fmt.Sprintf to insert the name of the CDN of interest.
+const apiCDNSigningKey = "/cdns/%s/signing_key"
+
+// GetCDNSigningKey returns the public part of the configuration signing key of
+// the CDN with the given name.
+func (to *Session) GetCDNSigningKey(name string, opts RequestOptions) (tc.CDNSigningPublicKeyResponse, toclientlib.ReqInf, error) {
+	var resp tc.CDNSigningPublicKeyResponse
+	reqInf, err := to.get(fmt.Sprintf(apiCDNSigningKey, url.PathEscape(name)), opts, &resp)
+	return resp, reqInf, err
+}
+
+// GenerateCDNSigningKey generates a new configuration signing key for the CDN
+// with the given name, replacing any existing one, and returns its public
+// part.
+func (to *Session) GenerateCDNSigningKey(name string, opts RequestOptions) (tc.CDNSigningPublicKeyResponse, toclientlib.ReqInf, error) {
+	var resp tc.CDNSigningPublicKeyResponse
+	reqInf, err := to.post(fmt.Sprintf(apiCDNSigningKey, url.PathEscape(name)), opts, nil, &resp)
+	return resp, reqInf, err
+}
+
+// DeleteCDNSigningKey deletes the configuration signing key of the CDN with
+// the given name.
+func (to *Session) DeleteCDNSigningKey(name string, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	var resp tc.Alerts
+	reqInf, err := to.del(fmt.Sprintf(apiCDNSigningKey, url.PathEscape(name)), opts, &resp)
+	return resp, reqInf, err
+}
@@ -0,0 +1,54 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiCDNSnapshotPolicy is the API version-relative path to the
+// /cdns/{{name}}/snapshot_policy API endpoint. It is intended to be used with
+// fmt.Sprintf to insert the name of the CDN of interest.
+const apiCDNSnapshotPolicy = "/cdns/%s/snapshot_policy"
+
+// GetCDNSnapshotPolicy returns the policy by which snapshots of the CDN with
+// the given name are taken automatically.
+func (to *Session) GetCDNSnapshotPolicy(name string, opts RequestOptions) (tc.CDNSnapshotPolicyResponse, toclientlib.ReqInf, error) {
+	var resp tc.CDNSnapshotPolicyResponse
+	reqInf, err := to.get(fmt.Sprintf(apiCDNSnapshotPolicy, url.PathEscape(name)), opts, &resp)
+	return resp, reqInf, err
+}
+
+// UpdateCDNSnapshotPolicy creates or replaces the policy by which snapshots of
+// the CDN with the given name are taken automatically. They'll be taken on
+// behalf of the authenticated user.
+func (to *Session) UpdateCDNSnapshotPolicy(name string, policy tc.CDNSnapshotPolicy, opts RequestOptions) (tc.CDNSnapshotPolicyResponse, toclientlib.ReqInf, error) {
+	var resp tc.CDNSnapshotPolicyResponse
+	reqInf, err := to.put(fmt.Sprintf(apiCDNSnapshotPolicy, url.PathEscape(name)), opts, policy, &resp)
+	return resp, reqInf, err
+}
+
+// DeleteCDNSnapshotPolicy stops snapshots of the CDN with the given name from
+// being taken automatically.
+func (to *Session) DeleteCDNSnapshotPolicy(name string, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	var resp tc.Alerts
+	reqInf, err := to.del(fmt.Sprintf(apiCDNSnapshotPolicy, url.PathEscape(name)), opts, &resp)
+	return resp, reqInf, err
+}
@@ -0,0 +1,53 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiCDNStaticDNSTTLPolicy is the API version-relative path to the
+// /cdns/{{name}}/static_dns_ttl_policy API endpoint. It is intended to be used
+// with fmt.Sprintf to insert the name of the CDN of interest.
+const apiCDNStaticDNSTTLPolicy = "/cdns/%s/static_dns_ttl_policy"
+
+// GetCDNStaticDNSEntryTTLPolicy returns the bounds the CDN with the given name
+// places on the TTLs of its Static DNS Entries.
+func (to *Session) GetCDNStaticDNSEntryTTLPolicy(name string, opts RequestOptions) (tc.StaticDNSEntryTTLPolicyResponse, toclientlib.ReqInf, error) {
+	var resp tc.StaticDNSEntryTTLPolicyResponse
+	reqInf, err := to.get(fmt.Sprintf(apiCDNStaticDNSTTLPolicy, url.PathEscape(name)), opts, &resp)
+	return resp, reqInf, err
+}
+
+// UpdateCDNStaticDNSEntryTTLPolicy creates or replaces the bounds the CDN with
+// the given name places on the TTLs of its Static DNS Entries.
+func (to *Session) UpdateCDNStaticDNSEntryTTLPolicy(name string, policy tc.StaticDNSEntryTTLPolicy, opts RequestOptions) (tc.StaticDNSEntryTTLPolicyResponse, toclientlib.ReqInf, error) {
+	var resp tc.StaticDNSEntryTTLPolicyResponse
+	reqInf, err := to.put(fmt.Sprintf(apiCDNStaticDNSTTLPolicy, url.PathEscape(name)), opts, policy, &resp)
+	return resp, reqInf, err
+}
+
+// DeleteCDNStaticDNSEntryTTLPolicy removes the bounds the CDN with the given
+// name places on the TTLs of its Static DNS Entries.
+func (to *Session) DeleteCDNStaticDNSEntryTTLPolicy(name string, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	var resp tc.Alerts
+	reqInf, err := to.del(fmt.Sprintf(apiCDNStaticDNSTTLPolicy, url.PathEscape(name)), opts, &resp)
+	return resp, reqInf, err
+}
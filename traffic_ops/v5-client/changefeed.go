@@ -0,0 +1,231 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiChangesStream is the API version-relative path to the /changes/stream
+// API endpoint.
+const apiChangesStream = "/changes/stream"
+
+// The backoff before FollowChangeFeed reconnects after a failure doubles with
+// each consecutive failure, from minFollowBackoff up to maxFollowBackoff.
+const (
+	minFollowBackoff = time.Second
+	maxFollowBackoff = 30 * time.Second
+)
+
+// ChangeFeedHandlers are the functions called with the events received from
+// the Traffic Ops change feed. Returning false from either ends the stream.
+type ChangeFeedHandlers struct {
+	// Change is called with each change event.
+	Change func(tc.ChangeEvent) bool
+	// Reset, if not nil, is called when the changes made since the requested
+	// sequence number are no longer retained by Traffic Ops, after which
+	// the feed continues from the latest change. Clients should reload
+	// whatever they keep current using the feed.
+	Reset func(tc.ChangeFeedReset) bool
+}
+
+// StreamChangeFeed streams events from the Traffic Ops change feed, passing
+// them to the given handlers, until a handler returns false, Traffic Ops ends
+// the stream, or the Context of opts is done. The "since" query parameter of
+// opts gives the sequence number of the last change already received; if it
+// isn't given, only changes made after the request are received.
+//
+// Traffic Ops ends streams periodically, as does the client's request
+// timeout, so callers should request the feed again, giving the returned
+// sequence number - that of the last event received, or of the latest change
+// when the stream started if none was, or 0 if neither was received - as
+// "since" if it isn't 0. FollowChangeFeed does so.
+func (to *Session) StreamChangeFeed(opts RequestOptions, handlers ChangeFeedHandlers) (int64, toclientlib.ReqInf, error) {
+	last, _, reqInf, err := to.streamChangeFeed(opts.context(), opts, handlers)
+	return last, reqInf, err
+}
+
+// FollowChangeFeed streams events from the Traffic Ops change feed, passing
+// them to the given handlers, until a handler returns false or ctx is done.
+// Whenever the stream ends or fails, it reconnects - after a backoff if it
+// failed - and resumes the feed after the last event received, so that no
+// change is missed. since is the sequence number of the last change already
+// received, or 0 to receive only the changes made after the call.
+//
+// It returns the sequence number with which the feed can be resumed later,
+// and nil if a handler returned false, ctx's error if it's done, or the error
+// of a request that won't succeed by being retried, such as one that's
+// unauthorized.
+func (to *Session) FollowChangeFeed(ctx context.Context, since int64, opts RequestOptions, handlers ChangeFeedHandlers) (int64, error) {
+	failures := 0
+	for {
+		streamOpts := RequestOptions{Header: opts.Header, QueryParameters: url.Values{}}
+		for k, v := range opts.QueryParameters {
+			streamOpts.QueryParameters[k] = v
+		}
+		if since > 0 {
+			streamOpts.QueryParameters.Set(tc.ChangeFeedSinceQueryParam, strconv.FormatInt(since, 10))
+		}
+
+		last, stopped, reqInf, err := to.streamChangeFeed(ctx, streamOpts, handlers)
+		if last > 0 {
+			since = last
+		}
+		if stopped {
+			return since, nil
+		}
+		if ctx.Err() != nil {
+			return since, ctx.Err()
+		}
+		if err != nil && !retryableChangeFeedStatus(reqInf.StatusCode) {
+			return since, err
+		}
+
+		wait := time.Duration(0)
+		if err != nil {
+			failures++
+			wait = followBackoff(failures)
+			log.Warnf("change feed stream failed, reconnecting in %v: %v", wait, err)
+		} else {
+			failures = 0
+		}
+		select {
+		case <-ctx.Done():
+			return since, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// retryableChangeFeedStatus returns whether a change feed request which
+// failed with a response with the given status code - 0 if there was no
+// response, or 200 if the stream failed - may succeed if it's retried.
+func retryableChangeFeedStatus(code int) bool {
+	return code == 0 || code == http.StatusOK || code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// followBackoff returns how long to wait before reconnecting after the given
+// number of consecutive failures.
+func followBackoff(failures int) time.Duration {
+	backoff := minFollowBackoff
+	for i := 1; i < failures && backoff < maxFollowBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxFollowBackoff {
+		backoff = maxFollowBackoff
+	}
+	return backoff
+}
+
+// streamChangeFeed streams the change feed like StreamChangeFeed, and also
+// returns whether it ended because a handler returned false.
+func (to *Session) streamChangeFeed(ctx context.Context, opts RequestOptions, handlers ChangeFeedHandlers) (int64, bool, toclientlib.ReqInf, error) {
+	path := strings.TrimSuffix(to.APIBase(), "/") + apiChangesStream
+	if len(opts.QueryParameters) > 0 {
+		path += "?" + opts.QueryParameters.Encode()
+	}
+	resp, remoteAddr, err := to.RawRequestWithContext(ctx, http.MethodGet, path, nil, opts.Header)
+	reqInf := toclientlib.ReqInf{CacheHitStatus: toclientlib.CacheHitStatusMiss, RemoteAddr: remoteAddr}
+	if err != nil {
+		return 0, false, reqInf, err
+	}
+	defer log.Close(resp.Body, "unable to close change feed response body")
+	reqInf.StatusCode = resp.StatusCode
+	reqInf.RespHeaders = resp.Header.Clone()
+	if resp.StatusCode != http.StatusOK {
+		return 0, false, reqInf, fmt.Errorf("error requesting Traffic Ops change feed: %s", resp.Status)
+	}
+
+	last := int64(0)
+	var id, event string
+	data := strings.Builder{}
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line != "" {
+			field, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+			switch field {
+			case "id":
+				id = value
+			case "event":
+				event = value
+			case "data":
+				data.WriteString(value)
+			}
+			continue
+		}
+
+		// A blank line ends an event. One without data isn't dispatched,
+		// but its ID is still the last one received - Traffic Ops sends
+		// the latest sequence number that way when the stream starts.
+		if data.Len() > 0 {
+			cont, err := dispatchChangeFeedEvent(event, data.String(), handlers)
+			if err != nil {
+				return last, false, reqInf, err
+			}
+			if !cont {
+				if seq, err := strconv.ParseInt(id, 10, 64); err == nil {
+					last = seq
+				}
+				return last, true, reqInf, nil
+			}
+		}
+		if seq, err := strconv.ParseInt(id, 10, 64); err == nil {
+			last = seq
+		}
+		id, event = "", ""
+		data.Reset()
+	}
+	if err := scanner.Err(); err != nil {
+		return last, false, reqInf, errors.New("reading change feed: " + err.Error())
+	}
+	return last, false, reqInf, nil
+}
+
+func dispatchChangeFeedEvent(event, data string, handlers ChangeFeedHandlers) (bool, error) {
+	switch event {
+	case tc.ChangeFeedEventChange:
+		var change tc.ChangeEvent
+		if err := json.Unmarshal([]byte(data), &change); err != nil {
+			return false, errors.New("decoding change event: " + err.Error())
+		}
+		return handlers.Change(change), nil
+	case tc.ChangeFeedEventReset:
+		if handlers.Reset == nil {
+			return true, nil
+		}
+		var reset tc.ChangeFeedReset
+		if err := json.Unmarshal([]byte(data), &reset); err != nil {
+			return false, errors.New("decoding change feed reset: " + err.Error())
+		}
+		return handlers.Reset(reset), nil
+	}
+	return true, nil
+}
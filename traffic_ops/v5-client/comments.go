@@ -0,0 +1,67 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// apiComments is the API version-relative path to the /comments API
+	// endpoint.
+	apiComments = "/comments"
+
+	// apiCommentID is the API version-relative path to the /comments/{{ID}}
+	// API endpoint. It is intended to be used with fmt.Sprintf to insert the
+	// ID of the Comment of interest.
+	apiCommentID = apiComments + "/%d"
+)
+
+// GetComments returns a list of Comments of Delivery Services, servers, and
+// Cache Groups. Pass e.g. the "serverId" query parameter in opts to get the
+// Comments of a single server, or the "mentioned" query parameter to get
+// those which mention a user.
+func (to *Session) GetComments(opts RequestOptions) (tc.CommentsResponse, toclientlib.ReqInf, error) {
+	var data tc.CommentsResponse
+	reqInf, err := to.get(apiComments, opts, &data)
+	return data, reqInf, err
+}
+
+// CreateComment creates a Comment, or a reply to one if the request has a
+// parent ID.
+func (to *Session) CreateComment(comment tc.CommentRequest, opts RequestOptions) (tc.CommentResponse, toclientlib.ReqInf, error) {
+	var data tc.CommentResponse
+	reqInf, err := to.post(apiComments, opts, comment, &data)
+	return data, reqInf, err
+}
+
+// UpdateComment replaces the text of the Comment identified by 'id'.
+func (to *Session) UpdateComment(id int, comment tc.CommentUpdateRequest, opts RequestOptions) (tc.CommentResponse, toclientlib.ReqInf, error) {
+	var data tc.CommentResponse
+	reqInf, err := to.put(fmt.Sprintf(apiCommentID, id), opts, comment, &data)
+	return data, reqInf, err
+}
+
+// DeleteComment deletes the Comment identified by 'id', along with its
+// replies.
+func (to *Session) DeleteComment(id int, opts RequestOptions) (tc.CommentResponse, toclientlib.ReqInf, error) {
+	var data tc.CommentResponse
+	reqInf, err := to.del(fmt.Sprintf(apiCommentID, id), opts, &data)
+	return data, reqInf, err
+}
@@ -0,0 +1,35 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiSystemExport is the API version-relative path to the /system/export
+// endpoint.
+const apiSystemExport = "/system/export"
+
+// GetConfigExport retrieves a logical export of the configuration of the
+// CDNs managed by Traffic Ops, for the Tenant of the Session's user and its
+// descendants. Secrets and Traffic Vault references may be requested with the
+// "includeSecrets" and "vaultReferences" query parameters of opts.
+func (to *Session) GetConfigExport(opts RequestOptions) (tc.ConfigExportResponse, toclientlib.ReqInf, error) {
+	var data tc.ConfigExportResponse
+	reqInf, err := to.get(apiSystemExport, opts, &data)
+	return data, reqInf, err
+}
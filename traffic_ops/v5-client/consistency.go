@@ -0,0 +1,30 @@
+package client
+
+/*
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiConsistency is the full path to the /system/consistency API endpoint.
+const apiConsistency = "/system/consistency"
+
+// GetConsistencyReport gets the violations of data consistency found when
+// Traffic Ops last checked it. Pass the "check" query parameter in opts to
+// restrict them to those found by one check.
+func (to *Session) GetConsistencyReport(opts RequestOptions) (tc.ConsistencyReportResponse, toclientlib.ReqInf, error) {
+	var resp tc.ConsistencyReportResponse
+	reqInf, err := to.get(apiConsistency, opts, &resp)
+	return resp, reqInf, err
+}
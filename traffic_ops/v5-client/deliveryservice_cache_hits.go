@@ -0,0 +1,42 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiDeliveryServiceCacheHitRatio is the API path on which Traffic Ops serves
+// the cache hit ratio of a specific Delivery Service identified by an
+// integral, unique identifier. It is intended to be used with fmt.Sprintf to
+// insert its required path parameter (namely the ID of the Delivery Service
+// of interest).
+const apiDeliveryServiceCacheHitRatio = apiDeliveryServiceID + "/cache_hit_ratio"
+
+// GetDeliveryServiceCacheHitRatio gets the ratios of requests which hit the
+// cache on the edge-tier and mid-tier cache servers of the Delivery Service
+// identified by the integral, unique identifier 'id', and the URLs which most
+// often missed it. The time range and number of URLs may be given by the
+// tc.CacheHitRatioStartQueryParam, tc.CacheHitRatioEndQueryParam, and
+// tc.CacheHitRatioTopMissesQueryParam query parameters of opts.
+func (to *Session) GetDeliveryServiceCacheHitRatio(id int, opts RequestOptions) (tc.DeliveryServiceCacheHitRatioResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceCacheHitRatioResponse
+	reqInf, err := to.get(fmt.Sprintf(apiDeliveryServiceCacheHitRatio, id), opts, &data)
+	return data, reqInf, err
+}
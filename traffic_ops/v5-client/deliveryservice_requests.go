@@ -33,7 +33,7 @@ const apiDSRequests = "/deliveryservice_requests"
 func (to *Session) CreateDeliveryServiceRequest(dsr tc.DeliveryServiceRequestV4, opts RequestOptions) (tc.DeliveryServiceRequestResponseV4, toclientlib.ReqInf, error) {
 	var resp tc.DeliveryServiceRequestResponseV4
 	if dsr.AssigneeID == nil && dsr.Assignee != nil {
-		assigneeOpts := NewRequestOptions()
+		assigneeOpts := newRequestOptionsFrom(opts)
 		assigneeOpts.QueryParameters.Set("username", *dsr.Assignee)
 		res, reqInf, err := to.GetUsers(assigneeOpts)
 		if err != nil {
@@ -46,7 +46,7 @@ func (to *Session) CreateDeliveryServiceRequest(dsr tc.DeliveryServiceRequestV4,
 	}
 
 	if dsr.AuthorID == nil && dsr.Author != "" {
-		authorOpts := NewRequestOptions()
+		authorOpts := newRequestOptionsFrom(opts)
 		authorOpts.QueryParameters.Set("username", dsr.Author)
 		res, reqInf, err := to.GetUsers(authorOpts)
 		if err != nil {
@@ -66,7 +66,7 @@ func (to *Session) CreateDeliveryServiceRequest(dsr tc.DeliveryServiceRequestV4,
 	}
 
 	if ds.TypeID == nil && ds.Type.String() != "" {
-		typeOpts := NewRequestOptions()
+		typeOpts := newRequestOptionsFrom(opts)
 		typeOpts.QueryParameters.Set("name", ds.Type.String())
 		ty, reqInf, err := to.GetTypes(typeOpts)
 		if err != nil || len(ty.Response) == 0 {
@@ -76,7 +76,7 @@ func (to *Session) CreateDeliveryServiceRequest(dsr tc.DeliveryServiceRequestV4,
 	}
 
 	if ds.CDNID == nil && ds.CDNName != nil {
-		cdnOpts := NewRequestOptions()
+		cdnOpts := newRequestOptionsFrom(opts)
 		cdnOpts.QueryParameters.Set("name", *ds.CDNName)
 		cdns, reqInf, err := to.GetCDNs(cdnOpts)
 		if err != nil || len(cdns.Response) == 0 {
@@ -86,7 +86,7 @@ func (to *Session) CreateDeliveryServiceRequest(dsr tc.DeliveryServiceRequestV4,
 	}
 
 	if ds.ProfileID == nil && ds.ProfileName != nil {
-		profileOpts := NewRequestOptions()
+		profileOpts := newRequestOptionsFrom(opts)
 		profileOpts.QueryParameters.Set("name", *ds.ProfileName)
 		profiles, reqInf, err := to.GetProfiles(profileOpts)
 		if err != nil || len(profiles.Response) == 0 {
@@ -96,7 +96,7 @@ func (to *Session) CreateDeliveryServiceRequest(dsr tc.DeliveryServiceRequestV4,
 	}
 
 	if ds.TenantID == nil && ds.Tenant != nil {
-		tenantOpts := NewRequestOptions()
+		tenantOpts := newRequestOptionsFrom(opts)
 		tenantOpts.QueryParameters.Set("name", *ds.Tenant)
 		ten, reqInf, err := to.GetTenants(tenantOpts)
 		if err != nil || len(ten.Response) == 0 {
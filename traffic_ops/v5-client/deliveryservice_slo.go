@@ -0,0 +1,87 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// apiDeliveryServiceSLO is the API path on which Traffic Ops serves the
+	// service level objectives of a specific Delivery Service identified by
+	// an integral, unique identifier. It is intended to be used with
+	// fmt.Sprintf to insert its required path parameter (namely the ID of the
+	// Delivery Service of interest).
+	apiDeliveryServiceSLO = apiDeliveryServiceID + "/slo"
+
+	// apiDeliveryServiceSLOCompliance is the API path on which Traffic Ops
+	// serves the compliance of a specific Delivery Service with its service
+	// level objectives. It is intended to be used with fmt.Sprintf like
+	// apiDeliveryServiceSLO.
+	apiDeliveryServiceSLOCompliance = apiDeliveryServiceSLO + "/compliance"
+
+	// apiDeliveryServiceSLOViolations is the API path on which Traffic Ops
+	// serves the violations of a specific Delivery Service's service level
+	// objectives. It is intended to be used with fmt.Sprintf like
+	// apiDeliveryServiceSLO.
+	apiDeliveryServiceSLOViolations = apiDeliveryServiceSLO + "/violations"
+)
+
+// GetDeliveryServiceSLO gets the service level objectives of the Delivery
+// Service identified by the integral, unique identifier 'id'.
+func (to *Session) GetDeliveryServiceSLO(id int, opts RequestOptions) (tc.DeliveryServiceSLOResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceSLOResponse
+	reqInf, err := to.get(fmt.Sprintf(apiDeliveryServiceSLO, id), opts, &data)
+	return data, reqInf, err
+}
+
+// UpdateDeliveryServiceSLO sets the service level objectives of the Delivery
+// Service identified by the integral, unique identifier 'id'.
+func (to *Session) UpdateDeliveryServiceSLO(id int, slo tc.DeliveryServiceSLORequest, opts RequestOptions) (tc.DeliveryServiceSLOResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceSLOResponse
+	reqInf, err := to.put(fmt.Sprintf(apiDeliveryServiceSLO, id), opts, slo, &data)
+	return data, reqInf, err
+}
+
+// DeleteDeliveryServiceSLO deletes the service level objectives of the
+// Delivery Service identified by the integral, unique identifier 'id'.
+func (to *Session) DeleteDeliveryServiceSLO(id int, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	reqInf, err := to.del(fmt.Sprintf(apiDeliveryServiceSLO, id), opts, &alerts)
+	return alerts, reqInf, err
+}
+
+// GetDeliveryServiceSLOCompliance gets the latest evaluation of the compliance
+// of the Delivery Service identified by the integral, unique identifier 'id'
+// with its service level objectives.
+func (to *Session) GetDeliveryServiceSLOCompliance(id int, opts RequestOptions) (tc.DeliveryServiceSLOComplianceResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceSLOComplianceResponse
+	reqInf, err := to.get(fmt.Sprintf(apiDeliveryServiceSLOCompliance, id), opts, &data)
+	return data, reqInf, err
+}
+
+// GetDeliveryServiceSLOViolations gets the violations of the service level
+// objectives of the Delivery Service identified by the integral, unique
+// identifier 'id'. Pass the "since" and "ongoing" query parameters in opts to
+// choose which violations are returned.
+func (to *Session) GetDeliveryServiceSLOViolations(id int, opts RequestOptions) (tc.DeliveryServiceSLOViolationsResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceSLOViolationsResponse
+	reqInf, err := to.get(fmt.Sprintf(apiDeliveryServiceSLOViolations, id), opts, &data)
+	return data, reqInf, err
+}
@@ -0,0 +1,98 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// apiDeliveryServicesTokenAuth is the API version-relative route to the
+	// /deliveryservices/token-auth endpoint.
+	apiDeliveryServicesTokenAuth = apiDeliveryServices + "/token-auth"
+
+	// apiDeliveryServicesTokenAuthRotate is the API version-relative route
+	// to the /deliveryservices/token-auth/rotate endpoint.
+	apiDeliveryServicesTokenAuthRotate = apiDeliveryServicesTokenAuth + "/rotate"
+
+	// apiDeliveryServiceTokenAuth is the API path on which Traffic Ops serves
+	// the token authentication settings of a specific Delivery Service
+	// identified by an integral, unique identifier. It is intended to be used
+	// with fmt.Sprintf to insert its required path parameter (namely the ID
+	// of the Delivery Service of interest).
+	apiDeliveryServiceTokenAuth = apiDeliveryServiceID + "/token-auth"
+
+	// apiDeliveryServiceTokenAuthRotate is the API path on which Traffic Ops
+	// rotates a URL sig key of a specific Delivery Service identified by an
+	// integral, unique identifier. It is intended to be used with fmt.Sprintf
+	// to insert its required path parameter.
+	apiDeliveryServiceTokenAuthRotate = apiDeliveryServiceTokenAuth + "/rotate"
+)
+
+// GetDeliveryServiceTokenAuth gets the token authentication settings of the
+// Delivery Service identified by the integral, unique identifier 'id'.
+func (to *Session) GetDeliveryServiceTokenAuth(id int, opts RequestOptions) (tc.DeliveryServiceTokenAuthResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceTokenAuthResponse
+	reqInf, err := to.get(fmt.Sprintf(apiDeliveryServiceTokenAuth, id), opts, &data)
+	return data, reqInf, err
+}
+
+// GetAllDeliveryServiceTokenAuth gets the token authentication settings of
+// every Delivery Service which has any. Pass the "cdn" query parameter in
+// opts to get only those of the Delivery Services in a CDN.
+func (to *Session) GetAllDeliveryServiceTokenAuth(opts RequestOptions) (tc.CDNDeliveryServiceTokenAuthResponse, toclientlib.ReqInf, error) {
+	var data tc.CDNDeliveryServiceTokenAuthResponse
+	reqInf, err := to.get(apiDeliveryServicesTokenAuth, opts, &data)
+	return data, reqInf, err
+}
+
+// UpdateDeliveryServiceTokenAuth sets the token authentication settings of
+// the Delivery Service identified by the integral, unique identifier 'id',
+// generating its URL sig keys if it has none.
+func (to *Session) UpdateDeliveryServiceTokenAuth(id int, auth tc.DeliveryServiceTokenAuthRequest, opts RequestOptions) (tc.DeliveryServiceTokenAuthResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceTokenAuthResponse
+	reqInf, err := to.put(fmt.Sprintf(apiDeliveryServiceTokenAuth, id), opts, auth, &data)
+	return data, reqInf, err
+}
+
+// DeleteDeliveryServiceTokenAuth deletes the token authentication settings of
+// the Delivery Service identified by the integral, unique identifier 'id'.
+func (to *Session) DeleteDeliveryServiceTokenAuth(id int, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	reqInf, err := to.del(fmt.Sprintf(apiDeliveryServiceTokenAuth, id), opts, &alerts)
+	return alerts, reqInf, err
+}
+
+// RotateDeliveryServiceTokenAuthKey rotates a URL sig key of the Delivery
+// Service identified by the integral, unique identifier 'id', regardless of
+// its key rotation schedule.
+func (to *Session) RotateDeliveryServiceTokenAuthKey(id int, opts RequestOptions) (tc.DeliveryServiceTokenAuthResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceTokenAuthResponse
+	reqInf, err := to.post(fmt.Sprintf(apiDeliveryServiceTokenAuthRotate, id), opts, nil, &data)
+	return data, reqInf, err
+}
+
+// RotateDueDeliveryServiceTokenAuthKeys rotates a URL sig key of every
+// Delivery Service whose key rotation is due, returning the settings of those
+// which were rotated.
+func (to *Session) RotateDueDeliveryServiceTokenAuthKeys(opts RequestOptions) (tc.CDNDeliveryServiceTokenAuthResponse, toclientlib.ReqInf, error) {
+	var data tc.CDNDeliveryServiceTokenAuthResponse
+	reqInf, err := to.post(apiDeliveryServicesTokenAuthRotate, opts, nil, &data)
+	return data, reqInf, err
+}
@@ -0,0 +1,77 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+import (
+	"net/url"
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiFieldPolicies is the API version-relative path for the /policies API
+// endpoint.
+const apiFieldPolicies = "/policies"
+
+// apiEffectiveFieldPolicies is the API version-relative path for the
+// /policies/effective API endpoint.
+const apiEffectiveFieldPolicies = apiFieldPolicies + "/effective"
+
+// CreateFieldPolicy creates the given field policy.
+func (to *Session) CreateFieldPolicy(policy tc.FieldPolicy, opts RequestOptions) (tc.FieldPolicyResponse, toclientlib.ReqInf, error) {
+	var resp tc.FieldPolicyResponse
+	reqInf, err := to.post(apiFieldPolicies, opts, policy, &resp)
+	return resp, reqInf, err
+}
+
+// UpdateFieldPolicy replaces the field policy with the given ID with the one
+// provided.
+func (to *Session) UpdateFieldPolicy(id int, policy tc.FieldPolicy, opts RequestOptions) (tc.FieldPolicyResponse, toclientlib.ReqInf, error) {
+	if opts.QueryParameters == nil {
+		opts.QueryParameters = url.Values{}
+	}
+	opts.QueryParameters.Set("id", strconv.Itoa(id))
+	var resp tc.FieldPolicyResponse
+	reqInf, err := to.put(apiFieldPolicies, opts, policy, &resp)
+	return resp, reqInf, err
+}
+
+// GetFieldPolicies returns all field policies in Traffic Ops.
+func (to *Session) GetFieldPolicies(opts RequestOptions) (tc.FieldPoliciesResponse, toclientlib.ReqInf, error) {
+	var data tc.FieldPoliciesResponse
+	reqInf, err := to.get(apiFieldPolicies, opts, &data)
+	return data, reqInf, err
+}
+
+// DeleteFieldPolicy deletes the field policy with the given ID.
+func (to *Session) DeleteFieldPolicy(id int, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	if opts.QueryParameters == nil {
+		opts.QueryParameters = url.Values{}
+	}
+	opts.QueryParameters.Set("id", strconv.Itoa(id))
+	var alerts tc.Alerts
+	reqInf, err := to.del(apiFieldPolicies, opts, &alerts)
+	return alerts, reqInf, err
+}
+
+// GetEffectiveFieldPolicies returns the fields of each type of object that
+// can be protected by field policies which the authenticated user may and may
+// not modify.
+func (to *Session) GetEffectiveFieldPolicies(opts RequestOptions) (tc.EffectiveFieldPoliciesResponse, toclientlib.ReqInf, error) {
+	var data tc.EffectiveFieldPoliciesResponse
+	reqInf, err := to.get(apiEffectiveFieldPolicies, opts, &data)
+	return data, reqInf, err
+}
@@ -18,6 +18,7 @@ package client
 import (
 	"net/url"
 	"strconv"
+	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
@@ -33,6 +34,21 @@ func (to *Session) CreateInvalidationJob(job tc.InvalidationJobCreateV4, opts Re
 	return alerts, reqInf, err
 }
 
+// PurgeSurrogateKeys creates a REFRESH Content Invalidation Job for the
+// content of the Delivery Service with the given XMLID which its origin
+// tagged with any of the given surrogate keys. The Delivery Service must have
+// surrogate key purging enabled.
+func (to *Session) PurgeSurrogateKeys(deliveryService string, keys []string, startTime time.Time, ttlHours uint32, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	job := tc.InvalidationJobCreateV4{
+		DeliveryService:  deliveryService,
+		StartTime:        startTime,
+		TTLHours:         ttlHours,
+		InvalidationType: tc.REFRESH,
+		SurrogateKeys:    keys,
+	}
+	return to.CreateInvalidationJob(job, opts)
+}
+
 // DeleteInvalidationJob deletes the Content Invalidation Job identified by
 // 'jobID'.
 func (to *Session) DeleteInvalidationJob(jobID uint64, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
@@ -0,0 +1,79 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// Paginated is a response to a request for a collection that supports cursor
+// pagination, e.g. tc.InvalidationJobsResponseV4.
+type Paginated = toclientlib.Paginated
+
+// EachPage requests each page of a collection in turn with the given method
+// of a Session - (*Session).GetInvalidationJobs, for instance - passing each
+// response to fn, until there are no more pages or fn returns false.
+//
+// The "limit" query parameter of opts sets the size of the pages, and must
+// be given. Objects created or deleted while the pages are being requested
+// don't cause others to be skipped or repeated, unlike when paging through a
+// collection with the "offset" or "page" query parameters.
+//
+// The returned ReqInf is that of the last request made.
+func EachPage[R Paginated](opts RequestOptions, get func(RequestOptions) (R, toclientlib.ReqInf, error), fn func(R) bool) (toclientlib.ReqInf, error) {
+	pageGet, err := pageGetter(opts, get)
+	if err != nil {
+		return toclientlib.ReqInf{CacheHitStatus: toclientlib.CacheHitStatusMiss}, err
+	}
+	return toclientlib.EachPage(pageGet, fn)
+}
+
+// AllPages requests each page of a collection in turn, as EachPage does, and
+// returns all of the objects in them, as given by items - for instance:
+//
+//	servers, reqInf, err := AllPages(opts, session.GetServers, func(resp tc.ServersV4Response) []tc.ServerV41 { return resp.Response })
+//
+// If requesting a page fails, the objects of the pages before it are returned
+// along with the error.
+func AllPages[R Paginated, T any](opts RequestOptions, get func(RequestOptions) (R, toclientlib.ReqInf, error), items func(R) []T) ([]T, toclientlib.ReqInf, error) {
+	pageGet, err := pageGetter(opts, get)
+	if err != nil {
+		return nil, toclientlib.ReqInf{CacheHitStatus: toclientlib.CacheHitStatusMiss}, err
+	}
+	return toclientlib.AllPages(pageGet, items)
+}
+
+// pageGetter returns a function which requests the page of a collection with
+// a given cursor using get, with the given options.
+func pageGetter[R Paginated](opts RequestOptions, get func(RequestOptions) (R, toclientlib.ReqInf, error)) (func(string) (R, toclientlib.ReqInf, error), error) {
+	if opts.QueryParameters.Get("limit") == "" {
+		return nil, errors.New("paging through a collection requires a limit")
+	}
+	return func(cursor string) (R, toclientlib.ReqInf, error) {
+		pageOpts := RequestOptions{Header: opts.Header, QueryParameters: url.Values{}, Context: opts.Context}
+		for k, v := range opts.QueryParameters {
+			pageOpts.QueryParameters[k] = v
+		}
+		if cursor != "" {
+			pageOpts.QueryParameters.Set(tc.CursorQueryParam, cursor)
+		}
+		return get(pageOpts)
+	}, nil
+}
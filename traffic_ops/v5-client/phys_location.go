@@ -28,7 +28,7 @@ const apiPhysLocations = "/phys_locations"
 // CreatePhysLocation creates the passed Physical Location.
 func (to *Session) CreatePhysLocation(pl tc.PhysLocation, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
 	if pl.RegionID == 0 && pl.RegionName != "" {
-		regionOpts := NewRequestOptions()
+		regionOpts := newRequestOptionsFrom(opts)
 		regionOpts.QueryParameters.Set("name", pl.RegionName)
 		regions, reqInf, err := to.GetRegions(regionOpts)
 		if err != nil {
@@ -34,7 +34,7 @@ const (
 // CreateProfile creates the passed Profile.
 func (to *Session) CreateProfile(pl tc.Profile, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
 	if pl.CDNID == 0 && pl.CDNName != "" {
-		cdnOpts := NewRequestOptions()
+		cdnOpts := newRequestOptionsFrom(opts)
 		cdnOpts.QueryParameters.Set("name", pl.CDNName)
 		cdns, _, err := to.GetCDNs(cdnOpts)
 		if err != nil {
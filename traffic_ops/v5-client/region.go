@@ -30,7 +30,7 @@ const apiRegions = "/regions"
 // CreateRegion creates the given Region.
 func (to *Session) CreateRegion(region tc.Region, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
 	if region.Division == 0 && region.DivisionName != "" {
-		divisionOpts := NewRequestOptions()
+		divisionOpts := newRequestOptionsFrom(opts)
 		divisionOpts.QueryParameters.Set("name", region.DivisionName)
 		divisions, reqInf, err := to.GetDivisions(divisionOpts)
 		if err != nil {
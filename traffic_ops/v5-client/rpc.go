@@ -0,0 +1,42 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/apache/trafficcontrol/lib/go-tc/tcpb"
+)
+
+// RPCCredentials returns the credentials with which calls to the Traffic Ops
+// gRPC service are authenticated as the Session's user, which are the cookies
+// of the Session.
+func (to *Session) RPCCredentials() (tcpb.CookieCredentials, error) {
+	if to.Client == nil || to.Client.Jar == nil {
+		return tcpb.CookieCredentials{}, errors.New("session has no cookie jar")
+	}
+	u, err := url.Parse(to.URL)
+	if err != nil {
+		return tcpb.CookieCredentials{}, fmt.Errorf("parsing Traffic Ops URL: %w", err)
+	}
+	cookies := to.Client.Jar.Cookies(u)
+	if len(cookies) == 0 {
+		return tcpb.CookieCredentials{}, errors.New("session has no cookies - it must be logged in first")
+	}
+	return tcpb.CookieCredentials{Cookies: cookies}, nil
+}
@@ -0,0 +1,96 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/apache/trafficcontrol/lib/go-log"
+	"github.com/apache/trafficcontrol/lib/go-rfc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// TailServerLog tails a log of the cache server with the given ID, passing
+// each line to the handler, until the handler returns false, Traffic Ops
+// ends the tail, or the Context of opts is done. The log, Delivery Service,
+// and duration of the tail are given as the "log", "deliveryServiceId", and
+// "seconds" query parameters of opts.
+//
+// The client's request timeout applies to the whole tail, so it must be
+// longer than the tail's duration.
+func (to *Session) TailServerLog(id int, opts RequestOptions, handler func(string) bool) (toclientlib.ReqInf, error) {
+	path := fmt.Sprintf("%s%s/%d/logs/tail", strings.TrimSuffix(to.APIBase(), "/"), apiServers, id)
+	if len(opts.QueryParameters) > 0 {
+		path += "?" + opts.QueryParameters.Encode()
+	}
+	key, err := rfc.NewWebSocketKey()
+	reqInf := toclientlib.ReqInf{CacheHitStatus: toclientlib.CacheHitStatusMiss}
+	if err != nil {
+		return reqInf, errors.New("generating WebSocket key: " + err.Error())
+	}
+	hdr := opts.Header.Clone()
+	if hdr == nil {
+		hdr = http.Header{}
+	}
+	hdr.Set("Connection", "Upgrade")
+	hdr.Set("Upgrade", "websocket")
+	hdr.Set(rfc.SecWebSocketVersion, rfc.WebSocketVersion)
+	hdr.Set(rfc.SecWebSocketKey, key)
+
+	resp, remoteAddr, err := to.RawRequestWithContext(opts.context(), http.MethodGet, path, nil, hdr)
+	reqInf.RemoteAddr = remoteAddr
+	if err != nil {
+		return reqInf, err
+	}
+	reqInf.StatusCode = resp.StatusCode
+	reqInf.RespHeaders = resp.Header.Clone()
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		log.Close(resp.Body, "unable to close server log tail response body")
+		return reqInf, fmt.Errorf("error requesting server log tail: %s", resp.Status)
+	}
+	if resp.Header.Get(rfc.SecWebSocketAccept) != rfc.WebSocketAccept(key) {
+		log.Close(resp.Body, "unable to close server log tail response body")
+		return reqInf, errors.New("server log tail response has an invalid " + rfc.SecWebSocketAccept + " header")
+	}
+	rwc, ok := resp.Body.(io.ReadWriteCloser)
+	if !ok {
+		log.Close(resp.Body, "unable to close server log tail response body")
+		return reqInf, errors.New("server log tail response body is not a connection")
+	}
+
+	conn := rfc.NewWebSocketClientConn(rwc)
+	for {
+		opcode, msg, err := conn.ReadMessage()
+		if err != nil {
+			closeErr := (*rfc.WebSocketCloseError)(nil)
+			if errors.As(err, &closeErr) && (closeErr.Code == rfc.WebSocketCloseNormal || closeErr.Code == rfc.WebSocketCloseGoingAway) {
+				return reqInf, nil
+			}
+			conn.Close(rfc.WebSocketCloseProtocolError, "")
+			return reqInf, errors.New("reading server log tail: " + err.Error())
+		}
+		if opcode != rfc.WebSocketOpText {
+			continue
+		}
+		if !handler(string(msg)) {
+			return reqInf, conn.Close(rfc.WebSocketCloseNormal, "")
+		}
+	}
+}
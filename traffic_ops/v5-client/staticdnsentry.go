@@ -16,10 +16,16 @@ package client
 */
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 
+	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
 )
@@ -28,12 +34,28 @@ import (
 // endpoint.
 const apiStaticDNSEntries = "/staticdnsentries"
 
-func staticDNSEntryIDs(to *Session, sdns *tc.StaticDNSEntry) error {
+// apiStaticDNSEntriesBulk is the API version-relative path to the
+// /staticdnsentries/bulk API endpoint.
+const apiStaticDNSEntriesBulk = apiStaticDNSEntries + "/bulk"
+
+// apiStaticDNSEntriesImport is the API version-relative path to the
+// /staticdnsentries/import API endpoint.
+const apiStaticDNSEntriesImport = apiStaticDNSEntries + "/import"
+
+// apiStaticDNSEntriesExport is the API version-relative path to the
+// /staticdnsentries/export API endpoint.
+const apiStaticDNSEntriesExport = apiStaticDNSEntries + "/export"
+
+// apiDeliveryServiceStaticDNSEntriesExport is the API version-relative path to
+// the /deliveryservices/{{ID}}/staticdnsentries/export API endpoint.
+const apiDeliveryServiceStaticDNSEntriesExport = apiDeliveryServiceID + apiStaticDNSEntriesExport
+
+func staticDNSEntryIDs(to *Session, sdns *tc.StaticDNSEntry, reqOpts RequestOptions) error {
 	if sdns == nil {
 		return errors.New("cannot resolve names to IDs for nil StaticDNSEntry")
 	}
 	if sdns.CacheGroupID == 0 && sdns.CacheGroupName != "" {
-		opts := NewRequestOptions()
+		opts := newRequestOptionsFrom(reqOpts)
 		opts.QueryParameters.Set("name", sdns.CacheGroupName)
 		p, _, err := to.GetCacheGroups(opts)
 		if err != nil {
@@ -49,7 +71,7 @@ func staticDNSEntryIDs(to *Session, sdns *tc.StaticDNSEntry) error {
 	}
 
 	if sdns.DeliveryServiceID == 0 && sdns.DeliveryService != "" {
-		opts := NewRequestOptions()
+		opts := newRequestOptionsFrom(reqOpts)
 		opts.QueryParameters.Set("xmlId", sdns.DeliveryService)
 		dses, _, err := to.GetDeliveryServices(opts)
 		if err != nil {
@@ -65,7 +87,7 @@ func staticDNSEntryIDs(to *Session, sdns *tc.StaticDNSEntry) error {
 	}
 
 	if sdns.TypeID == 0 && sdns.Type != "" {
-		opts := NewRequestOptions()
+		opts := newRequestOptionsFrom(reqOpts)
 		opts.QueryParameters.Set("name", sdns.Type)
 		types, _, err := to.GetTypes(opts)
 		if err != nil {
@@ -84,7 +106,7 @@ func staticDNSEntryIDs(to *Session, sdns *tc.StaticDNSEntry) error {
 func (to *Session) CreateStaticDNSEntry(sdns tc.StaticDNSEntry, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
 	// fill in missing IDs from names
 	var alerts tc.Alerts
-	err := staticDNSEntryIDs(to, &sdns)
+	err := staticDNSEntryIDs(to, &sdns, opts)
 	if err != nil {
 		return alerts, toclientlib.ReqInf{CacheHitStatus: toclientlib.CacheHitStatusMiss}, err
 	}
@@ -97,7 +119,7 @@ func (to *Session) CreateStaticDNSEntry(sdns tc.StaticDNSEntry, opts RequestOpti
 func (to *Session) UpdateStaticDNSEntry(id int, sdns tc.StaticDNSEntry, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
 	// fill in missing IDs from names
 	var alerts tc.Alerts
-	err := staticDNSEntryIDs(to, &sdns)
+	err := staticDNSEntryIDs(to, &sdns, opts)
 	if err != nil {
 		return alerts, toclientlib.ReqInf{CacheHitStatus: toclientlib.CacheHitStatusMiss}, err
 	}
@@ -116,6 +138,25 @@ func (to *Session) GetStaticDNSEntries(opts RequestOptions) (tc.StaticDNSEntries
 	return data, reqInf, err
 }
 
+// BulkStaticDNSEntries creates, updates, and deletes Static DNS Entries as
+// given by ops, in order. Either all of the operations succeed, or none of
+// their changes are made; either way, the response has the result of each.
+// Unlike CreateStaticDNSEntry, this doesn't fill in missing IDs from names.
+func (to *Session) BulkStaticDNSEntries(ops []tc.StaticDNSEntryBulkOperation, opts RequestOptions) (tc.StaticDNSEntryBulkResponse, toclientlib.ReqInf, error) {
+	var resp tc.StaticDNSEntryBulkResponse
+	reqInf, err := to.post(apiStaticDNSEntriesBulk, opts, ops, &resp)
+	return resp, reqInf, err
+}
+
+// ImportStaticDNSEntries creates or updates the Static DNS Entries of a
+// Delivery Service to match the records of a zone file, or - for a dry run -
+// only reports the changes that would be made.
+func (to *Session) ImportStaticDNSEntries(req tc.StaticDNSEntryImportRequest, opts RequestOptions) (tc.StaticDNSEntryImportResponse, toclientlib.ReqInf, error) {
+	var resp tc.StaticDNSEntryImportResponse
+	reqInf, err := to.post(apiStaticDNSEntriesImport, opts, req, &resp)
+	return resp, reqInf, err
+}
+
 // DeleteStaticDNSEntry deletes the Static DNS Entry with the given ID.
 func (to *Session) DeleteStaticDNSEntry(id int, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
 	if opts.QueryParameters == nil {
@@ -126,3 +167,63 @@ func (to *Session) DeleteStaticDNSEntry(id int, opts RequestOptions) (tc.Alerts,
 	reqInf, err := to.del(apiStaticDNSEntries, opts, &alerts)
 	return alerts, reqInf, err
 }
+
+// ExportStaticDNSEntries returns the static DNS entries of the active Delivery
+// Services of the CDN with the given name as RFC 1035 zone file fragments,
+// one for each Delivery Service domain.
+// Note that unlike most methods, this only returns alerts in its error.
+func (to *Session) ExportStaticDNSEntries(cdn string, opts RequestOptions) ([]byte, toclientlib.ReqInf, error) {
+	if opts.QueryParameters == nil {
+		opts.QueryParameters = url.Values{}
+	}
+	opts.QueryParameters.Set("cdn", cdn)
+	return to.getStaticDNSEntriesExport(apiStaticDNSEntriesExport, opts)
+}
+
+// ExportDeliveryServiceStaticDNSEntries returns all of the static DNS entries
+// of the Delivery Service with the given ID as a BIND (RFC 1035) zone file
+// fragment, both as text and as the records parsed from it.
+// Note that unlike most methods, this only returns alerts in its error.
+func (to *Session) ExportDeliveryServiceStaticDNSEntries(dsID int, opts RequestOptions) (tc.StaticDNSEntryZoneExport, toclientlib.ReqInf, error) {
+	var export tc.StaticDNSEntryZoneExport
+	body, reqInf, err := to.getStaticDNSEntriesExport(fmt.Sprintf(apiDeliveryServiceStaticDNSEntriesExport, dsID), opts)
+	if err != nil {
+		return export, reqInf, err
+	}
+	export.Text = string(body)
+	export.Records, err = tc.ParseZoneFile(export.Text, "")
+	if err != nil {
+		return export, reqInf, errors.New("parsing static DNS entries export: " + err.Error())
+	}
+	return export, reqInf, nil
+}
+
+// getStaticDNSEntriesExport returns the body of a successful response to a
+// request for an export of static DNS entries, which isn't JSON.
+func (to *Session) getStaticDNSEntriesExport(endpoint string, opts RequestOptions) ([]byte, toclientlib.ReqInf, error) {
+	path := strings.TrimSuffix(to.APIBase(), "/") + endpoint
+	if len(opts.QueryParameters) > 0 {
+		path += "?" + opts.QueryParameters.Encode()
+	}
+	resp, remoteAddr, err := to.RawRequestWithContext(opts.context(), http.MethodGet, path, nil, opts.Header)
+	reqInf := toclientlib.ReqInf{CacheHitStatus: toclientlib.CacheHitStatusMiss, RemoteAddr: remoteAddr}
+	if err != nil {
+		return nil, reqInf, err
+	}
+	defer log.Close(resp.Body, "unable to close static DNS entries export response body")
+	reqInf.StatusCode = resp.StatusCode
+	reqInf.RespHeaders = resp.Header.Clone()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, reqInf, errors.New("reading static DNS entries export: " + err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		var alerts tc.Alerts
+		if err := json.Unmarshal(body, &alerts); err == nil && len(alerts.Alerts) > 0 {
+			return nil, reqInf, fmt.Errorf("error exporting static DNS entries: %s: %s", resp.Status, alerts.ErrorString())
+		}
+		return nil, reqInf, fmt.Errorf("error exporting static DNS entries: %s", resp.Status)
+	}
+	return body, reqInf, nil
+}
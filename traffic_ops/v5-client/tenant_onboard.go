@@ -0,0 +1,34 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiTenantOnboard is the API version-relative path to the /tenants/onboard
+// API endpoint.
+const apiTenantOnboard = apiTenants + "/onboard"
+
+// OnboardTenant creates a new Tenant from the site's tenant onboarding
+// template, along with its quota, starter Delivery Services, and user
+// invitations.
+func (to *Session) OnboardTenant(req tc.TenantOnboardingRequest, opts RequestOptions) (tc.TenantOnboardingResponse, toclientlib.ReqInf, error) {
+	var data tc.TenantOnboardingResponse
+	reqInf, err := to.post(apiTenantOnboard, opts, req, &data)
+	return data, reqInf, err
+}
@@ -22,6 +22,7 @@ package client
 import (
 	"fmt"
 	"net/url"
+	"strconv"
 
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
@@ -40,3 +41,20 @@ func (to *Session) GetTrafficMonitorConfig(cdn string, opts RequestOptions) (tc.
 	reqInf, err := to.get(route, opts, &data)
 	return data, reqInf, err
 }
+
+// GetTrafficMonitorConfigDelta returns the sections of the monitoring
+// configuration for the CDN named by 'cdn' that changed since the given
+// version. If Traffic Ops can't tell what changed, the request fails with
+// status 410 Gone, and the whole configuration must be requested with
+// GetTrafficMonitorConfig. The version since which to get changes overrides
+// any given in opts.
+func (to *Session) GetTrafficMonitorConfigDelta(cdn string, since int64, opts RequestOptions) (tc.TrafficMonitorConfigDeltaResponse, toclientlib.ReqInf, error) {
+	if opts.QueryParameters == nil {
+		opts.QueryParameters = url.Values{}
+	}
+	opts.QueryParameters.Set(tc.TrafficMonitorConfigSinceQueryParam, strconv.FormatInt(since, 10))
+	route := fmt.Sprintf(apiCDNMonitoringConfig, url.PathEscape(cdn))
+	var data tc.TrafficMonitorConfigDeltaResponse
+	reqInf, err := to.get(route, opts, &data)
+	return data, reqInf, err
+}
@@ -0,0 +1,120 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// apiTransformScripts is the API path on which Traffic Ops serves
+	// transformation scripts.
+	apiTransformScripts = "/transform-scripts"
+
+	// apiTransformScriptID is the API path on which Traffic Ops serves a
+	// specific transformation script identified by an integral, unique
+	// identifier. It is intended to be used with fmt.Sprintf to insert its
+	// required path parameter (namely the ID of the script of interest).
+	apiTransformScriptID = apiTransformScripts + "/%d"
+
+	// apiTransformScriptVersions is the API path on which Traffic Ops serves
+	// the versions of a specific transformation script identified by an
+	// integral, unique identifier. It is intended to be used with
+	// fmt.Sprintf to insert its required path parameter (namely the ID of
+	// the script of interest).
+	apiTransformScriptVersions = apiTransformScriptID + "/versions"
+
+	// apiDeliveryServicesTransformScripts is the API version-relative route
+	// to the /deliveryservices/transform-scripts endpoint.
+	apiDeliveryServicesTransformScripts = apiDeliveryServices + "/transform-scripts"
+
+	// apiDeliveryServiceTransformScripts is the API path on which Traffic Ops
+	// serves the transformation scripts of a specific Delivery Service
+	// identified by an integral, unique identifier. It is intended to be used
+	// with fmt.Sprintf to insert its required path parameter (namely the ID
+	// of the Delivery Service of interest).
+	apiDeliveryServiceTransformScripts = apiDeliveryServiceID + "/transform-scripts"
+)
+
+// GetTransformScripts returns transformation scripts, which may be filtered
+// by the 'id' and 'name' query parameters of opts.
+func (to *Session) GetTransformScripts(opts RequestOptions) (tc.TransformScriptsResponse, toclientlib.ReqInf, error) {
+	var data tc.TransformScriptsResponse
+	reqInf, err := to.get(apiTransformScripts, opts, &data)
+	return data, reqInf, err
+}
+
+// CreateTransformScript creates a transformation script, along with its first
+// version.
+func (to *Session) CreateTransformScript(script tc.TransformScriptRequest, opts RequestOptions) (tc.TransformScriptResponse, toclientlib.ReqInf, error) {
+	var data tc.TransformScriptResponse
+	reqInf, err := to.post(apiTransformScripts, opts, script, &data)
+	return data, reqInf, err
+}
+
+// DeleteTransformScript deletes the transformation script identified by the
+// integral, unique identifier 'id', and all of its versions.
+func (to *Session) DeleteTransformScript(id int, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	reqInf, err := to.del(fmt.Sprintf(apiTransformScriptID, id), opts, &alerts)
+	return alerts, reqInf, err
+}
+
+// GetTransformScriptVersions returns the versions of the transformation
+// script identified by the integral, unique identifier 'id', newest first.
+// Pass the "version" query parameter in opts to get only that version.
+func (to *Session) GetTransformScriptVersions(id int, opts RequestOptions) (tc.TransformScriptVersionsResponse, toclientlib.ReqInf, error) {
+	var data tc.TransformScriptVersionsResponse
+	reqInf, err := to.get(fmt.Sprintf(apiTransformScriptVersions, id), opts, &data)
+	return data, reqInf, err
+}
+
+// CreateTransformScriptVersion adds a version to the transformation script
+// identified by the integral, unique identifier 'id'.
+func (to *Session) CreateTransformScriptVersion(id int, version tc.TransformScriptVersionRequest, opts RequestOptions) (tc.TransformScriptVersionResponse, toclientlib.ReqInf, error) {
+	var data tc.TransformScriptVersionResponse
+	reqInf, err := to.post(fmt.Sprintf(apiTransformScriptVersions, id), opts, version, &data)
+	return data, reqInf, err
+}
+
+// GetDeliveryServiceTransformScripts gets the transformation scripts of the
+// Delivery Service identified by the integral, unique identifier 'id'.
+func (to *Session) GetDeliveryServiceTransformScripts(id int, opts RequestOptions) (tc.DeliveryServiceTransformScriptsResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceTransformScriptsResponse
+	reqInf, err := to.get(fmt.Sprintf(apiDeliveryServiceTransformScripts, id), opts, &data)
+	return data, reqInf, err
+}
+
+// GetAllDeliveryServiceTransformScripts gets the transformation scripts of
+// every Delivery Service which has any, with the content of the versions they
+// run. Pass the "cdn" query parameter in opts to get only those of the
+// Delivery Services in a CDN.
+func (to *Session) GetAllDeliveryServiceTransformScripts(opts RequestOptions) (tc.CDNDeliveryServiceTransformScriptsResponse, toclientlib.ReqInf, error) {
+	var data tc.CDNDeliveryServiceTransformScriptsResponse
+	reqInf, err := to.get(apiDeliveryServicesTransformScripts, opts, &data)
+	return data, reqInf, err
+}
+
+// UpdateDeliveryServiceTransformScripts replaces all of the transformation
+// scripts of the Delivery Service identified by the integral, unique
+// identifier 'id'.
+func (to *Session) UpdateDeliveryServiceTransformScripts(id int, scripts tc.DeliveryServiceTransformScriptsRequest, opts RequestOptions) (tc.DeliveryServiceTransformScriptsResponse, toclientlib.ReqInf, error) {
+	var data tc.DeliveryServiceTransformScriptsResponse
+	reqInf, err := to.put(fmt.Sprintf(apiDeliveryServiceTransformScripts, id), opts, scripts, &data)
+	return data, reqInf, err
+}
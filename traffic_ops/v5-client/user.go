@@ -53,7 +53,7 @@ func (to *Session) UpdateCurrentUser(u tc.UserV4, opts RequestOptions) (tc.Updat
 // CreateUser creates the given user.
 func (to *Session) CreateUser(user tc.UserV4, opts RequestOptions) (tc.CreateUserResponseV4, toclientlib.ReqInf, error) {
 	if user.Tenant != nil {
-		innerOpts := NewRequestOptions()
+		innerOpts := newRequestOptionsFrom(opts)
 		innerOpts.QueryParameters.Set("name", *user.Tenant)
 		tenant, _, err := to.GetTenants(innerOpts)
 		if err != nil {
@@ -66,7 +66,7 @@ func (to *Session) CreateUser(user tc.UserV4, opts RequestOptions) (tc.CreateUse
 	}
 
 	if user.Role != "" {
-		innerOpts := NewRequestOptions()
+		innerOpts := newRequestOptionsFrom(opts)
 		innerOpts.QueryParameters.Set("name", user.Role)
 		roles, _, err := to.GetRoles(innerOpts)
 		if err != nil {
@@ -0,0 +1,34 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiUserImpersonate is the API version-relative route to the
+// /user/impersonate endpoint.
+const apiUserImpersonate = "/user/impersonate"
+
+// ImpersonateUser starts a time-limited session in which the Session acts as
+// the requested user. The session ends when it expires, or when the Session
+// logs out.
+func (to *Session) ImpersonateUser(req tc.UserImpersonationRequest, opts RequestOptions) (tc.UserImpersonationResponse, toclientlib.ReqInf, error) {
+	var data tc.UserImpersonationResponse
+	reqInf, err := to.post(apiUserImpersonate, opts, req, &data)
+	return data, reqInf, err
+}
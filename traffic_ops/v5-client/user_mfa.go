@@ -0,0 +1,103 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"strconv"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+// apiUserMFA is the API version-relative route to the /user/mfa endpoint.
+const apiUserMFA = "/user/mfa"
+
+// apiUserMFATOTP is the API version-relative route to the /user/mfa/totp
+// endpoint.
+const apiUserMFATOTP = apiUserMFA + "/totp"
+
+// apiUserMFARecoveryCodes is the API version-relative route to the
+// /user/mfa/recovery-codes endpoint.
+const apiUserMFARecoveryCodes = apiUserMFA + "/recovery-codes"
+
+// apiUserMFAWebAuthn is the API version-relative route to the
+// /user/mfa/webauthn endpoint.
+const apiUserMFAWebAuthn = apiUserMFA + "/webauthn"
+
+// GetUserMFA gets the state of the current user's second authentication
+// factors.
+func (to *Session) GetUserMFA(opts RequestOptions) (tc.UserMFAResponse, toclientlib.ReqInf, error) {
+	var data tc.UserMFAResponse
+	reqInf, err := to.get(apiUserMFA, opts, &data)
+	return data, reqInf, err
+}
+
+// DeleteUserMFA removes all of the current user's second authentication
+// factors and recovery codes.
+func (to *Session) DeleteUserMFA(opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	reqInf, err := to.del(apiUserMFA, opts, &alerts)
+	return alerts, reqInf, err
+}
+
+// EnrollUserTOTP creates a new pending TOTP secret for the current user, which
+// must be confirmed with ConfirmUserTOTP before it can be used to log in.
+func (to *Session) EnrollUserTOTP(opts RequestOptions) (tc.TOTPEnrollmentResponse, toclientlib.ReqInf, error) {
+	var data tc.TOTPEnrollmentResponse
+	reqInf, err := to.post(apiUserMFATOTP, opts, nil, &data)
+	return data, reqInf, err
+}
+
+// ConfirmUserTOTP confirms the current user's pending TOTP secret with a code
+// generated from it. If the user had no recovery codes, the response contains
+// new ones.
+func (to *Session) ConfirmUserTOTP(req tc.TOTPConfirmationRequest, opts RequestOptions) (tc.MFARecoveryCodesResponse, toclientlib.ReqInf, error) {
+	var data tc.MFARecoveryCodesResponse
+	reqInf, err := to.put(apiUserMFATOTP, opts, req, &data)
+	return data, reqInf, err
+}
+
+// RegenerateUserRecoveryCodes replaces the current user's recovery codes with
+// new ones.
+func (to *Session) RegenerateUserRecoveryCodes(opts RequestOptions) (tc.MFARecoveryCodesResponse, toclientlib.ReqInf, error) {
+	var data tc.MFARecoveryCodesResponse
+	reqInf, err := to.post(apiUserMFARecoveryCodes, opts, nil, &data)
+	return data, reqInf, err
+}
+
+// GetWebAuthnRegistrationOptions gets the options with which to create a new
+// WebAuthn credential for the current user.
+func (to *Session) GetWebAuthnRegistrationOptions(opts RequestOptions) (tc.WebAuthnRegistrationOptionsResponse, toclientlib.ReqInf, error) {
+	var data tc.WebAuthnRegistrationOptionsResponse
+	reqInf, err := to.get(apiUserMFAWebAuthn, opts, &data)
+	return data, reqInf, err
+}
+
+// RegisterWebAuthnCredential registers a new WebAuthn credential of the
+// current user.
+func (to *Session) RegisterWebAuthnCredential(req tc.WebAuthnRegistrationRequest, opts RequestOptions) (tc.WebAuthnCredentialResponse, toclientlib.ReqInf, error) {
+	var data tc.WebAuthnCredentialResponse
+	reqInf, err := to.post(apiUserMFAWebAuthn, opts, req, &data)
+	return data, reqInf, err
+}
+
+// DeleteWebAuthnCredential removes the current user's WebAuthn credential with
+// the given ID.
+func (to *Session) DeleteWebAuthnCredential(id int, opts RequestOptions) (tc.Alerts, toclientlib.ReqInf, error) {
+	var alerts tc.Alerts
+	reqInf, err := to.del(apiUserMFAWebAuthn+"/"+strconv.Itoa(id), opts, &alerts)
+	return alerts, reqInf, err
+}
@@ -0,0 +1,64 @@
+package client
+
+/*
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+import (
+	"fmt"
+
+	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/traffic_ops/toclientlib"
+)
+
+const (
+	// apiWebhooks is the API version-relative path to the /webhooks API
+	// endpoint.
+	apiWebhooks = "/webhooks"
+
+	// apiWebhookID is the API version-relative path to the /webhooks/{{ID}}
+	// API endpoint. It is intended to be used with fmt.Sprintf to insert the
+	// ID of the Webhook of interest.
+	apiWebhookID = apiWebhooks + "/%d"
+)
+
+// GetWebhooks returns a list of Webhooks. Their secrets aren't included.
+func (to *Session) GetWebhooks(opts RequestOptions) (tc.WebhooksResponse, toclientlib.ReqInf, error) {
+	var data tc.WebhooksResponse
+	reqInf, err := to.get(apiWebhooks, opts, &data)
+	return data, reqInf, err
+}
+
+// CreateWebhook creates a Webhook, which is sent only the changes made after
+// it's created.
+func (to *Session) CreateWebhook(webhook tc.WebhookRequest, opts RequestOptions) (tc.WebhookResponse, toclientlib.ReqInf, error) {
+	var data tc.WebhookResponse
+	reqInf, err := to.post(apiWebhooks, opts, webhook, &data)
+	return data, reqInf, err
+}
+
+// UpdateWebhook replaces the Webhook identified by 'id'. Its secret is left as
+// it was if the request has none.
+func (to *Session) UpdateWebhook(id int, webhook tc.WebhookRequest, opts RequestOptions) (tc.WebhookResponse, toclientlib.ReqInf, error) {
+	var data tc.WebhookResponse
+	reqInf, err := to.put(fmt.Sprintf(apiWebhookID, id), opts, webhook, &data)
+	return data, reqInf, err
+}
+
+// DeleteWebhook deletes the Webhook identified by 'id'.
+func (to *Session) DeleteWebhook(id int, opts RequestOptions) (tc.WebhookResponse, toclientlib.ReqInf, error) {
+	var data tc.WebhookResponse
+	reqInf, err := to.del(fmt.Sprintf(apiWebhookID, id), opts, &data)
+	return data, reqInf, err
+}
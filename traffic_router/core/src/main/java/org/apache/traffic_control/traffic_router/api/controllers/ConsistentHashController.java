@@ -27,7 +27,10 @@ import org.springframework.web.bind.annotation.RequestParam;
 import org.springframework.web.bind.annotation.ResponseBody;
 
 import java.util.HashMap;
+import java.util.HashSet;
+import java.util.List;
 import java.util.Map;
+import java.util.Set;
 
 @Controller
 @RequestMapping("/consistenthash")
@@ -101,7 +104,10 @@ public class ConsistentHashController {
 	@RequestMapping(value = "/patternbased/regex")
 	public @ResponseBody
 	ResponseEntity<Map<String, String>> testPatternBasedRegex(@RequestParam(name = "regex") final String regex,
-										 @RequestParam(name = REQUEST_PATH) final String requestPath) {
+										 @RequestParam(name = REQUEST_PATH) final String requestPath,
+										 @RequestParam(name = "includePath", defaultValue = "true") final boolean includePath,
+										 @RequestParam(name = "queryParam", required = false) final List<String> queryParams,
+										 @RequestParam(name = "header", required = false) final List<String> headers) {
 
 		// limit length of requestPath to protect against evil regexes
 		if (requestPath != null && requestPath.length() > MAX_REQUEST_PATH_LENGTH) {
@@ -110,7 +116,26 @@ public class ConsistentHashController {
 			return ResponseEntity.status(HttpStatus.BAD_REQUEST).body(map);
 		}
 
-		final String pathToHash = trafficRouterManager.getTrafficRouter().buildPatternBasedHashString(regex, requestPath);
+		final Set<String> queryParamSet = new HashSet<String>();
+		if (queryParams != null) {
+			queryParamSet.addAll(queryParams);
+		}
+
+		// headers are given as "Name:value"
+		final Map<String, String> headerMap = new HashMap<String, String>();
+		if (headers != null) {
+			for (final String header : headers) {
+				final int sep = header.indexOf(':');
+				if (sep <= 0) {
+					final Map<String, String> map = new HashMap<String, String>();
+					map.put("Bad Input", "Headers must be given as 'Name:value', got '" + header + "'");
+					return ResponseEntity.status(HttpStatus.BAD_REQUEST).body(map);
+				}
+				headerMap.put(header.substring(0, sep).trim(), header.substring(sep + 1).trim());
+			}
+		}
+
+		final String pathToHash = trafficRouterManager.getTrafficRouter().buildConsistentHashString(regex, includePath, queryParamSet, headerMap, requestPath);
 
 		if (pathToHash == null) {
 			return ResponseEntity.status(HttpStatus.NOT_FOUND).body(null);
@@ -0,0 +1,157 @@
+/*
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package org.apache.traffic_control.traffic_router.api.controllers;
+
+import org.apache.traffic_control.traffic_router.core.ds.DeliveryService;
+import org.apache.traffic_control.traffic_router.core.loc.NetworkNode;
+import org.apache.traffic_control.traffic_router.core.loc.NetworkNodeException;
+import org.apache.traffic_control.traffic_router.core.loc.RegionalGeoResult;
+import org.apache.traffic_control.traffic_router.core.request.HTTPRequest;
+import org.apache.traffic_control.traffic_router.core.router.HTTPRouteResult;
+import org.apache.traffic_control.traffic_router.core.router.StatTracker.Track;
+import org.apache.traffic_control.traffic_router.core.router.TrafficRouter;
+import org.apache.traffic_control.traffic_router.core.router.TrafficRouterManager;
+import org.apache.traffic_control.traffic_router.geolocation.Geolocation;
+import org.apache.logging.log4j.LogManager;
+import org.apache.logging.log4j.Logger;
+import org.springframework.beans.factory.annotation.Autowired;
+import org.springframework.http.ResponseEntity;
+import org.springframework.stereotype.Controller;
+import org.springframework.web.bind.annotation.RequestMapping;
+import org.springframework.web.bind.annotation.RequestParam;
+import org.springframework.web.bind.annotation.ResponseBody;
+
+import java.net.URL;
+import java.util.ArrayList;
+import java.util.HashMap;
+import java.util.LinkedHashMap;
+import java.util.List;
+import java.util.Map;
+
+/**
+ * Traces how Traffic Router would route an HTTP request, without serving it
+ * or counting it in the routing statistics.
+ */
+@Controller
+@RequestMapping("/routing")
+public class RoutingController {
+	private static final Logger LOGGER = LogManager.getLogger(RoutingController.class);
+
+	@Autowired
+	TrafficRouterManager trafficRouterManager;
+
+	@RequestMapping(value = "/trace")
+	public @ResponseBody
+	ResponseEntity<Map<String, Object>> trace(@RequestParam(name = "ip") final String ip,
+	                                          @RequestParam(name = "host") final String host,
+	                                          @RequestParam(name = "path", defaultValue = "/") final String path,
+	                                          @RequestParam(name = "secure", defaultValue = "false") final boolean secure) {
+		final URL url;
+		try {
+			url = new URL(secure ? "https" : "http", host, path.startsWith("/") ? path : "/" + path);
+		} catch (Exception e) {
+			final Map<String, Object> error = new HashMap<>();
+			error.put("error", "invalid host or path: " + e.getMessage());
+			return ResponseEntity.badRequest().body(error);
+		}
+
+		final HTTPRequest request = new HTTPRequest(url);
+		request.setClientIP(ip);
+		request.setUri(url.getPath());
+		request.setSecure(secure);
+		request.setHeaders(new HashMap<>());
+
+		final TrafficRouter trafficRouter = trafficRouterManager.getTrafficRouter();
+		final Map<String, Object> trace = new LinkedHashMap<>();
+		trace.put("clientIp", ip);
+		trace.put("host", host);
+		trace.put("path", url.getPath());
+		trace.put("secure", secure);
+		trace.put("geolocation", geolocation(trafficRouter, ip));
+		trace.put("coverageZone", networkLocation(NetworkNode.getInstance(), ip));
+		trace.put("deepCoverageZone", networkLocation(NetworkNode.getDeepInstance(), ip));
+
+		final Track track = new Track();
+		HTTPRouteResult result = null;
+		try {
+			result = trafficRouter.route(request, track);
+		} catch (Exception e) {
+			LOGGER.warn("Tracing route for " + url + " from " + ip + ": " + e.getMessage(), e);
+			trace.put("error", e.getMessage());
+		}
+
+		DeliveryService deliveryService = result == null ? null : result.getDeliveryService();
+		if (deliveryService == null) {
+			deliveryService = trafficRouter.getCacheRegister().getDeliveryService(request);
+		}
+		trace.put("deliveryService", deliveryService == null ? null : deliveryService.getId());
+		trace.put("result", track.getResult() == null ? null : track.getResult().name());
+		trace.put("resultDetails", track.getResultDetails() == null ? null : track.getResultDetails().name());
+		trace.put("resultLocation", geolocationMap(track.getResultLocation()));
+		trace.put("fromBackupCacheGroup", track.isFromBackupCzGroup());
+
+		final RegionalGeoResult regionalGeoResult = track.getRegionalGeoResult();
+		trace.put("regionalGeoResult", regionalGeoResult == null || regionalGeoResult.getType() == null ? null : regionalGeoResult.getType().name());
+
+		final List<String> urls = new ArrayList<>();
+		if (result != null && result.getUrls() != null) {
+			for (final URL u : result.getUrls()) {
+				if (u != null) {
+					urls.add(u.toString());
+				}
+			}
+		}
+		trace.put("urls", urls);
+		trace.put("responseCode", result == null || result.getResponseCode() == 0 ? null : result.getResponseCode());
+
+		return ResponseEntity.ok(trace);
+	}
+
+	private static Map<String, Object> geolocation(final TrafficRouter trafficRouter, final String ip) {
+		try {
+			return geolocationMap(trafficRouter.getLocation(ip));
+		} catch (Exception e) {
+			LOGGER.warn("Geolocating " + ip + ": " + e.getMessage());
+			return null;
+		}
+	}
+
+	private static Map<String, Object> geolocationMap(final Geolocation geolocation) {
+		if (geolocation == null) {
+			return null;
+		}
+		final Map<String, Object> map = new LinkedHashMap<>();
+		map.put("latitude", geolocation.getLatitude());
+		map.put("longitude", geolocation.getLongitude());
+		map.put("countryCode", geolocation.getCountryCode());
+		map.put("city", geolocation.getCity());
+		map.put("postalCode", geolocation.getPostalCode());
+		map.put("defaultLocation", geolocation.isDefaultLocation());
+		return map;
+	}
+
+	private static String networkLocation(final NetworkNode networkNode, final String ip) {
+		if (networkNode == null) {
+			return null;
+		}
+		try {
+			final NetworkNode match = networkNode.getNetwork(ip);
+			return match == null ? null : match.getLoc();
+		} catch (NetworkNodeException e) {
+			return null;
+		}
+	}
+}
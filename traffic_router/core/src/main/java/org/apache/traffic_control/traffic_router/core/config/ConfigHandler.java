@@ -68,6 +68,7 @@ import org.apache.traffic_control.traffic_router.core.util.TrafficOpsUtils;
 import org.apache.traffic_control.traffic_router.core.router.StatTracker;
 import org.apache.traffic_control.traffic_router.geolocation.Geolocation;
 import org.apache.traffic_control.traffic_router.core.request.HTTPRequest;
+import org.apache.traffic_control.traffic_router.core.request.PathAndQueryNormalizer;
 import org.apache.traffic_control.traffic_router.core.loc.AnonymousIp;
 import org.apache.traffic_control.traffic_router.core.loc.AnonymousIpConfigUpdater;
 import org.apache.traffic_control.traffic_router.core.loc.AnonymousIpDatabaseUpdater;
@@ -389,6 +390,22 @@ public class ConfigHandler {
 					LOGGER.warn(e + " : " + ip);
 				}
 
+				if (jo.has("deliveryServiceAddresses")) {
+					final JsonNode addressesJo = jo.get("deliveryServiceAddresses");
+					final Iterator<String> dsIter = addressesJo.fieldNames();
+					while (dsIter.hasNext()) {
+						final String ds = dsIter.next();
+						final JsonNode dsAddresses = addressesJo.get(ds);
+						final String dsIp = JsonUtils.optString(dsAddresses, "ip");
+						final String dsIp6 = JsonUtils.optString(dsAddresses, "ip6");
+						try {
+							cache.setDeliveryServiceIpAddress(ds, dsIp, dsIp6);
+						} catch (UnknownHostException e) {
+							LOGGER.warn(e + " : " + dsIp + ", " + dsIp6 + " for delivery service " + ds);
+						}
+					}
+				}
+
 				if (jo.has(deliveryServicesKey)) {
 					final List<DeliveryServiceReference> references = new ArrayList<Cache.DeliveryServiceReference>();
 					final JsonNode dsJos = jo.get(deliveryServicesKey);
@@ -532,6 +549,7 @@ public class ConfigHandler {
 		final TreeSet<DeliveryServiceMatcher> deliveryServiceMatchers = new TreeSet<>();
 		final JsonNode config = cacheRegister.getConfig();
 		final boolean regexSuperhackEnabled = JsonUtils.optBoolean(config, "confighandler.regex.superhack.enabled", true);
+		final PathAndQueryNormalizer pathAndQueryNormalizer = PathAndQueryNormalizer.fromConfig(config);
 
 		final Iterator<String> deliveryServiceIds = allDeliveryServices.fieldNames();
 		while (deliveryServiceIds.hasNext()) {
@@ -557,7 +575,7 @@ public class ConfigHandler {
 						regex = regex.replaceFirst("^\\.\\*\\\\\\.", "(.*\\\\.|^)");
 					}
 
-					deliveryServiceMatcher.addMatch(type, regex, target);
+					deliveryServiceMatcher.addMatch(type, regex, target, pathAndQueryNormalizer);
 				}
 
 			}
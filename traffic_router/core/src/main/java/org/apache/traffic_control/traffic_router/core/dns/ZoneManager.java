@@ -57,6 +57,7 @@ import org.xbill.DNS.AAAARecord;
 import org.xbill.DNS.ARecord;
 import org.xbill.DNS.CNAMERecord;
 import org.xbill.DNS.DClass;
+import org.xbill.DNS.MXRecord;
 import org.xbill.DNS.NSECRecord;
 import org.xbill.DNS.NSRecord;
 import org.xbill.DNS.Name;
@@ -64,6 +65,7 @@ import org.xbill.DNS.RRSIGRecord;
 import org.xbill.DNS.RRset;
 import org.xbill.DNS.Record;
 import org.xbill.DNS.SOARecord;
+import org.xbill.DNS.SRVRecord;
 import org.xbill.DNS.SetResponse;
 import org.xbill.DNS.TextParseException;
 import org.xbill.DNS.TXTRecord;
@@ -116,6 +118,7 @@ public class ZoneManager extends Resolver {
 
 	private static Name topLevelDomain;
 	private static final String AAAA = "AAAA";
+	private static final int MAX_TXT_STRING_LENGTH = 255;
 
 	protected enum ZoneCacheType {
 		DYNAMIC, STATIC
@@ -487,6 +490,7 @@ public class ZoneManager extends Resolver {
 				ZoneUtils.getLong(soa, "expire", 604800),
 				ZoneUtils.getLong(soa, "minimum", 60)));
 		addTrafficRouters(list, trafficRouters, name, ttl, domain, ds, tr);
+		addNameservers(list, config.get("nameservers"), name, ttl);
 		addStaticDnsEntries(list, ds, domain);
 
 		final List<Record> records = new ArrayList<Record>();
@@ -713,16 +717,55 @@ public class ZoneManager extends Resolver {
 							list.add(new CNAMERecord(name, DClass.IN, ttl, new Name(value)));
 							break;
 						case "TXT":
-							list.add(new TXTRecord(name, DClass.IN, ttl, value));
+							list.add(new TXTRecord(name, DClass.IN, ttl, splitTxtValue(value)));
+							break;
+						case "SRV":
+							list.add(newSrvRecord(name, ttl, value));
+							break;
+						case "MX":
+							list.add(newMxRecord(name, ttl, value));
 							break;
 					}
 				} catch (JsonUtilsException ex) {
 					LOGGER.error(ex);
+				} catch (IllegalArgumentException ex) {
+					LOGGER.error("invalid static DNS entry for delivery service " + ds.getId() + ": " + ex.getMessage());
 				}
 			}
 		}
 	}
 
+	// a TXT record's text is one or more character-strings of at most 255 bytes each
+	private static List<String> splitTxtValue(final String value) {
+		final List<String> strings = new ArrayList<>();
+		for (int i = 0; i < value.length(); i += MAX_TXT_STRING_LENGTH) {
+			strings.add(value.substring(i, Math.min(value.length(), i + MAX_TXT_STRING_LENGTH)));
+		}
+		if (strings.isEmpty()) {
+			strings.add("");
+		}
+		return strings;
+	}
+
+	// the value of a static SRV entry is "{priority} {weight} {port} {target}"
+	private static SRVRecord newSrvRecord(final Name name, final long ttl, final String value) throws TextParseException {
+		final String[] fields = value.trim().split("\\s+");
+		if (fields.length != 4) {
+			throw new IllegalArgumentException("SRV value '" + value + "' must be '{priority} {weight} {port} {target}'");
+		}
+		return new SRVRecord(name, DClass.IN, ttl, Integer.parseInt(fields[0]), Integer.parseInt(fields[1]),
+				Integer.parseInt(fields[2]), new Name(fields[3]));
+	}
+
+	// the value of a static MX entry is "{preference} {exchange}"
+	private static MXRecord newMxRecord(final Name name, final long ttl, final String value) throws TextParseException {
+		final String[] fields = value.trim().split("\\s+");
+		if (fields.length != 2) {
+			throw new IllegalArgumentException("MX value '" + value + "' must be '{preference} {exchange}'");
+		}
+		return new MXRecord(name, DClass.IN, ttl, Integer.parseInt(fields[0]), new Name(fields[1]));
+	}
+
 	@SuppressWarnings({"PMD.CyclomaticComplexity", "PMD.NPathComplexity"})
 	private static void addTrafficRouters(final List<Record> list, final JsonNode trafficRouters, final Name name,
 			final JsonNode ttl, final String domain, final DeliveryService ds, final TrafficRouter tr) throws TextParseException, UnknownHostException {
@@ -763,6 +806,23 @@ public class ZoneManager extends Resolver {
 		}
 	}
 
+	// adds NS records for any name servers configured for the CDN in addition to its Traffic Routers
+	private static void addNameservers(final List<Record> list, final JsonNode nameservers, final Name name, final JsonNode ttl) throws TextParseException {
+		if (nameservers == null || !nameservers.isArray()) {
+			return;
+		}
+
+		for (final JsonNode nameserver : nameservers) {
+			final String target = nameserver.asText();
+
+			if (target.isEmpty()) {
+				continue;
+			}
+
+			list.add(new NSRecord(name, DClass.IN, ZoneUtils.getLong(ttl, "NS", 60), newName(target)));
+		}
+	}
+
 	private static void addHttpRoutingRecords(final List<Record> list, final String routingName, final String domain, final JsonNode trJo, final JsonNode ttl, final boolean addTrafficRoutersAAAA)
 					throws TextParseException, UnknownHostException {
 		final Name trName = newName(routingName, domain);
@@ -839,7 +899,7 @@ public class ZoneManager extends Resolver {
 					final Name name = newName(fqdn);
 					final JsonNode ttl = ds.getTtls();
 
-					final InetAddress ip4 = c.getIp4();
+					final InetAddress ip4 = c.getIp4(ds.getId());
 					if (ip4 != null) {
 						try {
 							zholder.add(new ARecord(name, DClass.IN, ZoneUtils.getLong(ttl, "A", 60), ip4));
@@ -848,7 +908,7 @@ public class ZoneManager extends Resolver {
 						}
 					}
 
-					final InetAddress ip6 = c.getIp6();
+					final InetAddress ip6 = c.getIp6(ds.getId());
 
 					if (ip6 != null && ds.isIp6RoutingEnabled()) {
 					    try {
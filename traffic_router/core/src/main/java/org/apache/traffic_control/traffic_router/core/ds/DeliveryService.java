@@ -32,7 +32,9 @@ import java.util.HashSet;
 import java.util.List;
 import java.util.Map;
 import java.util.Set;
+import java.util.SortedMap;
 import java.util.SortedSet;
+import java.util.TreeMap;
 import java.util.TreeSet;
 import java.util.Iterator;
 import java.util.concurrent.atomic.AtomicInteger;
@@ -112,7 +114,15 @@ public class DeliveryService {
 	private final DeepCachingType deepCache;
 	private String consistentHashRegex;
 	private final Set<String> consistentHashQueryParams;
+	private final boolean consistentHashIncludePath;
+	// the lower-cased names of the request headers which are part of the consistent hash key
+	private final Set<String> consistentHashHeaders;
+	// the number of points each cache has on the consistent hash ring for this Delivery Service; 0 if not overridden
+	private final int consistentHashReplicas;
 	private boolean ecsEnabled;
+	// the times, in milliseconds since the epoch, at which the Delivery Service is scheduled to become active and inactive; 0 if not scheduled
+	private final long activeAt;
+	private final long inactiveAt;
 
 	public enum DeepCachingType {
 		NEVER,
@@ -152,6 +162,10 @@ public class DeliveryService {
 
 		this.consistentHashQueryParams = new HashSet<>();
 		initConsistentHashQueryParams(dsJo);
+		this.consistentHashHeaders = new HashSet<>();
+		initConsistentHashHeaders(dsJo);
+		this.consistentHashIncludePath = JsonUtils.optBoolean(dsJo, "consistentHashIncludePath", true);
+		this.consistentHashReplicas = JsonUtils.optInt(dsJo, "consistentHashReplicas");
 
 		// missLocation: {lat: , long: }
 		final JsonNode mlJo = dsJo.get("missLocation");
@@ -171,6 +185,8 @@ public class DeliveryService {
 		sslEnabled = JsonUtils.optBoolean(dsJo, "sslEnabled");
 		this.anonymousIpEnabled = JsonUtils.optBoolean(dsJo, "anonymousBlockingEnabled");
 		this.consistentHashRegex = JsonUtils.optString(dsJo, "consistentHashRegex");
+		this.activeAt = JsonUtils.optLong(dsJo, "activeAt") * 1000;
+		this.inactiveAt = JsonUtils.optLong(dsJo, "inactiveAt") * 1000;
 
 		final JsonNode protocol = dsJo.get("protocol");
 		acceptHttp = JsonUtils.optBoolean(protocol, "acceptHttp", true);
@@ -219,6 +235,22 @@ public class DeliveryService {
 		}
 	}
 
+	private void initConsistentHashHeaders(final JsonNode dsJo) {
+		if (dsJo.has("consistentHashHeaders")) {
+			final JsonNode chhNode = dsJo.get("consistentHashHeaders");
+			if (!chhNode.isArray()) {
+				LOGGER.error("Delivery Service '" + id + "' has malformed consistentHashHeaders. Disregarding.");
+			} else {
+				for (final JsonNode n : chhNode) {
+					final String s = n.asText();
+					if (!s.isEmpty()) {
+						this.consistentHashHeaders.add(s.toLowerCase());
+					}
+				}
+			}
+		}
+	}
+
 	private void initTopology(final JsonNode dsJo) {
 		if (dsJo.has("topology")) {
 			this.topology = JsonUtils.optString(dsJo, "topology");
@@ -246,6 +278,18 @@ public class DeliveryService {
 		return this.consistentHashQueryParams;
 	}
 
+	public Set<String> getConsistentHashHeaders() {
+		return this.consistentHashHeaders;
+	}
+
+	public boolean isConsistentHashIncludePath() {
+		return this.consistentHashIncludePath;
+	}
+
+	public int getConsistentHashReplicas() {
+		return this.consistentHashReplicas;
+	}
+
 	public String getId() {
 		return id;
 	}
@@ -606,7 +650,19 @@ public class DeliveryService {
 	}
 
 	public boolean isAvailable() {
-		return isAvailable;
+		return isAvailable && isScheduledActive(System.currentTimeMillis());
+	}
+
+	/**
+	 * Returns whether the given time is within the Delivery Service's schedule, i.e. it's neither
+	 * before the Delivery Service is scheduled to become active nor after it's scheduled to become
+	 * inactive. A Delivery Service without a schedule is always within it.
+	 *
+	 * @param timeMillis the time, in milliseconds since the epoch
+	 * @return whether the Delivery Service may be routed at the given time
+	 */
+	public boolean isScheduledActive(final long timeMillis) {
+		return (activeAt == 0 || timeMillis >= activeAt) && (inactiveAt == 0 || timeMillis < inactiveAt);
 	}
 
 	public boolean isLocationAvailable(final Location cl) {
@@ -766,7 +822,21 @@ public class DeliveryService {
 	 *	a blank string instead.
 	 */
 	public String extractSignificantQueryParams(final HTTPRequest r) {
-		if (r.getQueryString() == null || r.getQueryString().isEmpty() || this.getConsistentHashQueryParams().isEmpty()) {
+		return extractSignificantQueryParams(r, this.getConsistentHashQueryParams());
+	}
+
+	/**
+	 * Extracts the significant parts of a request's query string based on the
+	 * given Consistent Hashing Query Parameters
+	 * @param r The request from which to extract query parameters
+	 * @param queryParams The names of the query parameters relevant to
+	 *	consistent hashing
+	 * @return The parts of the request's query string relevant to consistent
+	 *	hashing. The result is URI-decoded - if decoding fails it will return
+	 *	a blank string instead.
+	 */
+	public static String extractSignificantQueryParams(final HTTPRequest r, final Set<String> queryParams) {
+		if (r.getQueryString() == null || r.getQueryString().isEmpty() || queryParams.isEmpty()) {
 			return "";
 		}
 
@@ -782,7 +852,7 @@ public class DeliveryService {
 					parts[i] = URLDecoder.decode(parts[i], "UTF-8");
 				}
 
-				if (this.getConsistentHashQueryParams().contains(parts[0])) {
+				if (queryParams.contains(parts[0])) {
 					qparams.add(String.join("=", parts));
 				}
 			}
@@ -790,7 +860,7 @@ public class DeliveryService {
 		} catch (UnsupportedEncodingException e) {
 			final StringBuffer err = new StringBuffer();
 			err.append("Error decoding query parameters - ");
-			err.append(this.toString());
+			err.append(r.getQueryString());
 			err.append(" - Exception: ");
 			err.append(e.toString());
 			LOGGER.error(err.toString());
@@ -805,6 +875,49 @@ public class DeliveryService {
 		return s.toString();
 	}
 
+	/**
+	 * Extracts the significant headers of a request based on this Delivery
+	 * Service's Consistent Hashing Headers
+	 * @param r The request from which to extract headers
+	 * @return The names and values of the request's headers relevant to
+	 *	consistent hashing, ordered by name
+	 */
+	public String extractSignificantHeaders(final HTTPRequest r) {
+		return extractSignificantHeaders(r, this.getConsistentHashHeaders());
+	}
+
+	/**
+	 * Extracts the significant headers of a request based on the given
+	 * Consistent Hashing Headers
+	 * @param r The request from which to extract headers
+	 * @param headers The lower-cased names of the headers relevant to
+	 *	consistent hashing
+	 * @return The names and values of the request's headers relevant to
+	 *	consistent hashing, ordered by name
+	 */
+	public static String extractSignificantHeaders(final HTTPRequest r, final Set<String> headers) {
+		if (r.getHeaders() == null || r.getHeaders().isEmpty() || headers.isEmpty()) {
+			return "";
+		}
+
+		final SortedMap<String, String> significant = new TreeMap<String, String>();
+		for (final Map.Entry<String, String> header : r.getHeaders().entrySet()) {
+			final String name = header.getKey().toLowerCase();
+			if (headers.contains(name)) {
+				significant.put(name, header.getValue());
+			}
+		}
+
+		final StringBuilder s = new StringBuilder();
+		for (final Map.Entry<String, String> header : significant.entrySet()) {
+			s.append(header.getKey());
+			s.append(':');
+			s.append(header.getValue());
+		}
+
+		return s.toString();
+	}
+
 	public boolean isEcsEnabled() {
 		return ecsEnabled;
 	}
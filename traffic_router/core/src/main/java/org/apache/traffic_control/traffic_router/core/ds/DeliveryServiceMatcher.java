@@ -21,12 +21,13 @@ import java.util.List;
 import java.util.Set;
 import java.util.TreeMap;
 
+import org.apache.traffic_control.traffic_router.core.request.PathAndQueryNormalizer;
 import org.apache.traffic_control.traffic_router.core.request.Request;
 import org.apache.traffic_control.traffic_router.core.request.RequestMatcher;
 
 public class DeliveryServiceMatcher implements Comparable<DeliveryServiceMatcher> {
 	public enum Type {
-		HOST, HEADER, PATH
+		HOST, HEADER, PATH, PATH_AND_QUERY
 	}
 
 	private DeliveryService deliveryService;
@@ -48,6 +49,10 @@ public class DeliveryServiceMatcher implements Comparable<DeliveryServiceMatcher
 		requestMatchers.add(new RequestMatcher(type, string, target));
 	}
 
+	public void addMatch(final Type type, final String string, final String target, final PathAndQueryNormalizer pathAndQueryNormalizer) {
+		requestMatchers.add(new RequestMatcher(type, string, target, pathAndQueryNormalizer));
+	}
+
 	public List<RequestMatcher> getRequestMatchers() {
 		return new ArrayList<>(this.requestMatchers);
 	}
@@ -33,8 +33,9 @@ public class SteeringGeolocationComparator implements Comparator<SteeringResult>
         final Geolocation originGeo1 = result1.getSteeringTarget().getGeolocation();
         final Geolocation originGeo2 = result2.getSteeringTarget().getGeolocation();
 
-        final Geolocation cacheGeo1 = result1.getCache().getGeolocation();
-        final Geolocation cacheGeo2 = result2.getCache().getGeolocation();
+        // external targets have no cache, so the client goes straight to their "origin"
+        final Geolocation cacheGeo1 = result1.getCache() != null ? result1.getCache().getGeolocation() : clientLocation;
+        final Geolocation cacheGeo2 = result2.getCache() != null ? result2.getCache().getGeolocation() : clientLocation;
 
         // null origin geolocations are considered greater than (i.e. farther away) than non-null origin geolocations
         if (originGeo1 != null && originGeo2 == null) {
@@ -35,6 +35,14 @@ public class SteeringResult {
         this.steeringTarget = steeringTarget;
     }
 
+    /**
+     * Checks whether this result is an external CDN target, which has no Delivery Service or cache.
+     * @return {@code true} if the steering target is external.
+     */
+    public boolean isExternal() {
+        return steeringTarget != null && steeringTarget.isExternal();
+    }
+
     public DeliveryService getDeliveryService() {
         return deliveryService;
     }
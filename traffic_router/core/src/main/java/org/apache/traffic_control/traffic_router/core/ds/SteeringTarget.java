@@ -17,8 +17,11 @@ package org.apache.traffic_control.traffic_router.core.ds;
 
 import com.fasterxml.jackson.annotation.JsonProperty;
 import org.apache.traffic_control.traffic_router.core.hash.DefaultHashable;
+import org.apache.traffic_control.traffic_router.core.request.HTTPRequest;
 import org.apache.traffic_control.traffic_router.geolocation.Geolocation;
 
+import java.util.ArrayList;
+import java.util.List;
 import java.util.Objects;
 
 public class SteeringTarget extends DefaultHashable {
@@ -38,6 +41,10 @@ public class SteeringTarget extends DefaultHashable {
 	private double latitude = DEFAULT_LAT;
 	@JsonProperty
 	private double longitude = DEFAULT_LON;
+	@JsonProperty
+	private List<String> geoLimitCountries = new ArrayList<>();
+	@JsonProperty
+	private String externalFqdn;
 
 	private Geolocation geolocation;
 
@@ -93,6 +100,62 @@ public class SteeringTarget extends DefaultHashable {
 		return longitude;
 	}
 
+	public void setGeoLimitCountries(final List<String> geoLimitCountries) {
+		this.geoLimitCountries = geoLimitCountries != null ? geoLimitCountries : new ArrayList<>();
+	}
+
+	public List<String> getGeoLimitCountries() {
+		return geoLimitCountries;
+	}
+
+	public void setExternalFqdn(final String externalFqdn) {
+		this.externalFqdn = externalFqdn;
+	}
+
+	public String getExternalFqdn() {
+		return externalFqdn;
+	}
+
+	/**
+	 * Checks whether this target is an external CDN rather than a Delivery Service.
+	 * @return {@code true} if clients steered to this target are redirected to {@link #getExternalFqdn()}.
+	 */
+	public boolean isExternal() {
+		return externalFqdn != null && !externalFqdn.isEmpty();
+	}
+
+	/**
+	 * Creates the URL that clients steered to this external target are redirected to.
+	 * @param request The client's HTTP request.
+	 * @return The client's requested path and query string on {@link #getExternalFqdn()}, using
+	 * the scheme of the client's request.
+	 */
+	public String createURIString(final HTTPRequest request) {
+		final StringBuilder uri = new StringBuilder(request.isSecure() ? "https://" : "http://");
+		uri.append(externalFqdn);
+		uri.append(request.getUri());
+		if (request.getQueryString() != null) {
+			uri.append('?').append(request.getQueryString());
+		}
+		return uri.toString();
+	}
+
+	/**
+	 * Checks whether clients in a country may be steered to this target.
+	 * @param countryCode The client's ISO 3166-1 alpha-2 country code, or {@code null} if it's unknown.
+	 * @return {@code true} if the target isn't limited to any countries, or if it's limited to
+	 * countries including {@code countryCode}.
+	 */
+	public boolean isCountryAllowed(final String countryCode) {
+		if (geoLimitCountries.isEmpty()) {
+			return true;
+		}
+		if (countryCode == null) {
+			return false;
+		}
+		return geoLimitCountries.stream().anyMatch(countryCode::equalsIgnoreCase);
+	}
+
 	public Geolocation getGeolocation() {
 		if (geolocation != null) {
 			return geolocation;
@@ -119,7 +182,9 @@ public class SteeringTarget extends DefaultHashable {
 				geoOrder != target.geoOrder ||
 				latitude != target.latitude ||
 				longitude != target.longitude) return false;
-		return Objects.equals(deliveryService, target.deliveryService);
+		return Objects.equals(deliveryService, target.deliveryService) &&
+				Objects.equals(geoLimitCountries, target.geoLimitCountries) &&
+				Objects.equals(externalFqdn, target.externalFqdn);
 
 	}
 
@@ -131,6 +196,8 @@ public class SteeringTarget extends DefaultHashable {
 		result = 31 * result + geoOrder;
 		result = 31 * result + (int) latitude;
 		result = 31 * result + (int) longitude;
+		result = 31 * result + geoLimitCountries.hashCode();
+		result = 31 * result + (externalFqdn != null ? externalFqdn.hashCode() : 0);
 		return result;
 	}
 }
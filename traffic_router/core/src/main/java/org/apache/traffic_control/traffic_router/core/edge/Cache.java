@@ -16,8 +16,13 @@
 
 package org.apache.traffic_control.traffic_router.core.edge;
 
+import java.net.Inet6Address;
+import java.net.InetAddress;
+import java.net.UnknownHostException;
+import java.util.ArrayList;
 import java.util.Collection;
 import java.util.HashMap;
+import java.util.List;
 import java.util.Map;
 
 import org.apache.traffic_control.traffic_router.geolocation.Geolocation;
@@ -26,9 +31,12 @@ import org.apache.commons.lang3.builder.EqualsBuilder;
 import org.apache.commons.lang3.builder.HashCodeBuilder;
 
 import org.apache.traffic_control.traffic_router.core.config.ParseException;
+import org.apache.traffic_control.traffic_router.core.util.JsonUtils;
+import com.fasterxml.jackson.databind.JsonNode;
 
 public class Cache extends Node {
 	private final Map<String, DeliveryServiceReference> deliveryServices = new HashMap<String, DeliveryServiceReference>();
+	private final Map<String, DeliveryServiceAddresses> deliveryServiceAddresses = new HashMap<String, DeliveryServiceAddresses>();
 	private final Geolocation geolocation;
 
 	public Cache(final String id, final String hashId, final int hashCount, final Geolocation geolocation) {
@@ -79,11 +87,61 @@ public class Cache extends Node {
 		return deliveryServices.containsKey(deliveryServiceId);
 	}
 
+	/**
+	 * Sets the addresses to which clients are routed for a Delivery Service, instead of the
+	 * cache's own, as selected by the Delivery Service's routing interfaces. Either may be empty.
+	 */
+	public void setDeliveryServiceIpAddress(final String deliveryServiceId, final String ip, final String ip6) throws UnknownHostException {
+		final InetAddress dsIp4 = (ip == null || ip.isEmpty()) ? null : InetAddress.getByName(ip);
+		final InetAddress dsIp6 = (ip6 == null || ip6.isEmpty()) ? null : Inet6Address.getByName(ip6.replaceAll("/.*", ""));
+		deliveryServiceAddresses.put(deliveryServiceId, new DeliveryServiceAddresses(dsIp4, dsIp6));
+	}
+
+	public InetAddress getIp4(final String deliveryServiceId) {
+		final DeliveryServiceAddresses addresses = deliveryServiceAddresses.get(deliveryServiceId);
+		return addresses == null ? getIp4() : addresses.ip4;
+	}
+
+	public InetAddress getIp6(final String deliveryServiceId) {
+		final DeliveryServiceAddresses addresses = deliveryServiceAddresses.get(deliveryServiceId);
+		return addresses == null ? getIp6() : addresses.ip6;
+	}
+
+	/**
+	 * Like {@link Node#getIpAddresses(JsonNode, boolean)}, but returns the addresses to which
+	 * clients are routed for the given Delivery Service.
+	 */
+	public List<InetRecord> getIpAddresses(final String deliveryServiceId, final JsonNode ttls, final boolean ip6RoutingEnabled) {
+		final DeliveryServiceAddresses addresses = deliveryServiceAddresses.get(deliveryServiceId);
+		if (addresses == null) {
+			return getIpAddresses(ttls, ip6RoutingEnabled);
+		}
+
+		final List<InetRecord> ret = new ArrayList<InetRecord>();
+		if (addresses.ip4 != null) {
+			ret.add(new InetRecord(addresses.ip4, ttls == null ? -1 : JsonUtils.optLong(ttls, "A")));
+		}
+		if (addresses.ip6 != null && ip6RoutingEnabled) {
+			ret.add(new InetRecord(addresses.ip6, ttls == null ? -1 : JsonUtils.optLong(ttls, "AAAA")));
+		}
+		return ret;
+	}
+
 	@Override
 	public String toString() {
 		return "Cache [id=" + id + "] ";
 	}
 
+	private static class DeliveryServiceAddresses {
+		private final InetAddress ip4;
+		private final InetAddress ip6;
+
+		DeliveryServiceAddresses(final InetAddress ip4, final InetAddress ip6) {
+			this.ip4 = ip4;
+			this.ip6 = ip6;
+		}
+	}
+
 	/**
 	 * Contains a reference to a DeliveryService ID and the FQDN that should be used if this Cache
 	 * is used when supporting the DeliveryService.
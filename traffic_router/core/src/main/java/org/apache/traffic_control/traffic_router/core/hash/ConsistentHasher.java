@@ -28,7 +28,11 @@ public class ConsistentHasher {
 	final private MD5HashFunction hashFunction = new MD5HashFunction();
 
 	public <T extends Hashable> T selectHashable(final List<T> hashables, final Dispersion dispersion, final String s) {
-		final List<T> selectedHashables = selectHashables(hashables, dispersion, s);
+		return selectHashable(hashables, dispersion, s, 0);
+	}
+
+	public <T extends Hashable> T selectHashable(final List<T> hashables, final Dispersion dispersion, final String s, final int replicas) {
+		final List<T> selectedHashables = selectHashables(hashables, dispersion, s, replicas);
 		return !selectedHashables.isEmpty() ? selectedHashables.get(0) : null;
 	}
 
@@ -37,7 +41,20 @@ public class ConsistentHasher {
 	}
 
 	public <T extends Hashable> List<T> selectHashables(final List<T> hashables, final Dispersion dispersion, final String s) {
-		final SortedMap<Double, T> sortedHashables = sortHashables(hashables, s);
+		return selectHashables(hashables, dispersion, s, 0);
+	}
+
+	/**
+	 * Selects hashables by consistent hashing.
+	 * @param hashables The hashables from which to select
+	 * @param dispersion Limits the number of hashables selected, and whether they're shuffled; may be null
+	 * @param s The string to hash
+	 * @param replicas The number of points each hashable has on the hash ring; if not positive,
+	 * each hashable's own hash count is used
+	 * @return The selected hashables, closest first unless shuffled
+	 */
+	public <T extends Hashable> List<T> selectHashables(final List<T> hashables, final Dispersion dispersion, final String s, final int replicas) {
+		final SortedMap<Double, T> sortedHashables = sortHashables(hashables, s, replicas);
 		final List<T> selectedHashables = new ArrayList<T>();
 
 		for (final T hashable : sortedHashables.values()) {
@@ -56,7 +73,7 @@ public class ConsistentHasher {
 	}
 
 	@SuppressWarnings("PMD.EmptyCatchBlock")
-	private <T extends Hashable> SortedMap<Double, T> sortHashables(final List<T> hashables, final String s) {
+	private <T extends Hashable> SortedMap<Double, T> sortHashables(final List<T> hashables, final String s, final int replicas) {
 		final double hash = hashFunction.hash(s);
 		final SortedMap<Double, T> hashableMap = new TreeMap<Double, T>();
 		final List<T> zeroHashes = new ArrayList<T>();
@@ -67,7 +84,7 @@ public class ConsistentHasher {
 				continue;
 			}
 
-			final double closestHash = hashable.getClosestHash(hash);
+			final double closestHash = hashable.getClosestHash(hash, replicas);
 			final double hashDelta = getSafePositiveHash(hashableMap, Math.abs(hash - closestHash));
 
 			hashableMap.put(hashDelta, hashable);
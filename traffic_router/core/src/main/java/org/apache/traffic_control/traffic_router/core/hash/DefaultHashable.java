@@ -17,10 +17,15 @@ package org.apache.traffic_control.traffic_router.core.hash;
 
 import java.util.Arrays;
 import java.util.List;
+import java.util.Map;
 import java.util.TreeSet;
+import java.util.concurrent.ConcurrentHashMap;
 
 public class DefaultHashable implements Hashable<DefaultHashable>, Comparable<DefaultHashable> {
 	private Double[] hashes;
+	private String hashId;
+	// hashes generated for a number of replicas other than the hash count, keyed by that number
+	private final Map<Integer, Double[]> replicaHashes = new ConcurrentHashMap<>();
 	private int order = 0;
 
 	@Override
@@ -43,8 +48,31 @@ public class DefaultHashable implements Hashable<DefaultHashable>, Comparable<De
 		return hashes[NumberSearcher.findClosest(hashes, hash)];
 	}
 
+	/**
+	 * Gets the closest hash to the given hash, out of the given number of replicas of this
+	 * hashable's hash ID rather than its hash count. Hashables without hashes are unaffected.
+	 * @param hash The hash to which to find the closest hash
+	 * @param replicas The number of replicas; if not positive, the hash count is used
+	 * @return The closest hash
+	 */
+	@Override
+	public double getClosestHash(final double hash, final int replicas) {
+		if (replicas <= 0 || !hasHashes()) {
+			return getClosestHash(hash);
+		}
+		final Double[] replicated = replicaHashes.computeIfAbsent(replicas, r -> createHashes(hashId, r));
+		return replicated[NumberSearcher.findClosest(replicated, hash)];
+	}
+
 	@Override
 	public DefaultHashable generateHashes(final String hashId, final int hashCount) {
+		this.hashId = hashId;
+		replicaHashes.clear();
+		hashes = createHashes(hashId, hashCount);
+		return this;
+	}
+
+	private static Double[] createHashes(final String hashId, final int hashCount) {
 		final TreeSet<Double> hashSet = new TreeSet<Double>();
 		final MD5HashFunction hashFunction = new MD5HashFunction();
 
@@ -52,9 +80,9 @@ public class DefaultHashable implements Hashable<DefaultHashable>, Comparable<De
 			hashSet.add(hashFunction.hash(hashId + "--" + i));
 		}
 
-		hashes = new Double[hashSet.size()];
-		System.arraycopy(hashSet.toArray(),0,hashes,0,hashSet.size());
-		return this;
+		final Double[] created = new Double[hashSet.size()];
+		System.arraycopy(hashSet.toArray(),0,created,0,hashSet.size());
+		return created;
 	}
 
 	@Override
@@ -20,6 +20,7 @@ import java.util.List;
 public interface Hashable <E> extends Comparable<E> {
 	Hashable<E> generateHashes(String hashId, int hashCount);
 	double getClosestHash(double hash);
+	double getClosestHash(double hash, int replicas);
 	List<Double> getHashValues();
 	boolean hasHashes();
 	int getOrder();
@@ -19,6 +19,7 @@ import java.io.File;
 import java.io.FileInputStream;
 import java.io.IOException;
 import java.net.URI;
+import java.security.GeneralSecurityException;
 import java.net.UnknownHostException;
 import java.nio.file.Path;
 import java.util.ArrayList;
@@ -36,6 +37,7 @@ import org.apache.traffic_control.traffic_router.core.router.TrafficRouterManage
 import org.apache.traffic_control.traffic_router.core.util.AbstractUpdatable;
 import org.apache.traffic_control.traffic_router.core.util.JsonUtilsException;
 import org.apache.traffic_control.traffic_router.core.util.PeriodicResourceUpdater;
+import org.apache.traffic_control.traffic_router.core.util.SignatureVerifier;
 import org.springframework.context.ApplicationListener;
 import org.springframework.context.event.ApplicationContextEvent;
 import org.springframework.context.event.ContextClosedEvent;
@@ -58,6 +60,7 @@ public class TrafficMonitorWatcher implements ApplicationListener<ApplicationCon
 	private int statusRefreshPeriod;
 	private String configFile;
 	private int configRefreshPeriod;
+	private String cdnSigningKeyFile;
 
 	private String monitorProperties;
 	private static boolean bootstrapped = false;
@@ -151,6 +154,13 @@ public class TrafficMonitorWatcher implements ApplicationListener<ApplicationCon
 		processConfig();
 
 		crUpdater = new PeriodicResourceUpdater(crHandler, new TrafficMonitorResourceUrl(this, configUrl), databasesDirectory.resolve(configFile).toString(), configRefreshPeriod, true);
+		if (cdnSigningKeyFile != null && !cdnSigningKeyFile.isEmpty()) {
+			try {
+				crUpdater.setSignatureVerifier(SignatureVerifier.fromFile(cdnSigningKeyFile));
+			} catch (IOException | GeneralSecurityException e) {
+				throw new IllegalStateException("failed to load CDN signing key file " + cdnSigningKeyFile, e);
+			}
+		}
 		crUpdater.init();
 
 		stateUpdater = new PeriodicResourceUpdater(stateHandler, new TrafficMonitorResourceUrl(this, stateUrl), databasesDirectory.resolve(statusFile).toString(), statusRefreshPeriod, true);
@@ -215,6 +225,12 @@ public class TrafficMonitorWatcher implements ApplicationListener<ApplicationCon
 	public void setConfigRefreshPeriod(final int configRefreshPeriod) {
 		this.configRefreshPeriod = configRefreshPeriod;
 	}
+	public String getCdnSigningKeyFile() {
+		return cdnSigningKeyFile;
+	}
+	public void setCdnSigningKeyFile(final String cdnSigningKeyFile) {
+		this.cdnSigningKeyFile = cdnSigningKeyFile;
+	}
 	public TrafficRouterManager getTrafficRouterManager() {
 		return trafficRouterManager;
 	}
@@ -187,4 +187,8 @@ public class HTTPRequest extends Request {
     public boolean isSecure() {
         return secure;
     }
+
+    public void setSecure(final boolean secure) {
+        this.secure = secure;
+    }
 }
@@ -0,0 +1,186 @@
+/*
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package org.apache.traffic_control.traffic_router.core.request;
+
+import java.util.ArrayList;
+import java.util.Comparator;
+import java.util.List;
+import java.util.Locale;
+
+import com.fasterxml.jackson.databind.JsonNode;
+import org.apache.traffic_control.traffic_router.core.util.JsonUtils;
+
+/**
+ * Normalizes the path and query string of requests before they're matched against
+ * {@code PATH_AND_QUERY} Delivery Service regular expressions, so that equivalent requests
+ * match the same way regardless of how clients order and encode their query parameters.
+ */
+public class PathAndQueryNormalizer {
+	public static final String DECODE_PERCENT_ENCODING = "pathAndQueryRegex.decodePercent";
+	public static final String SORT_QUERY_PARAMETERS = "pathAndQueryRegex.sortParams";
+	public static final String LOWERCASE_QUERY_PARAMETER_NAMES = "pathAndQueryRegex.lowercaseParamNames";
+	public static final String DROP_EMPTY_QUERY_PARAMETERS = "pathAndQueryRegex.dropEmptyParams";
+
+	public static final PathAndQueryNormalizer DEFAULT = new PathAndQueryNormalizer(true, true, false, false);
+
+	private static final String UNRESERVED_SYMBOLS = "-._~";
+
+	private final boolean decodePercentEncoding;
+	private final boolean sortQueryParameters;
+	private final boolean lowercaseQueryParameterNames;
+	private final boolean dropEmptyQueryParameters;
+
+	public PathAndQueryNormalizer(final boolean decodePercentEncoding, final boolean sortQueryParameters,
+			final boolean lowercaseQueryParameterNames, final boolean dropEmptyQueryParameters) {
+		this.decodePercentEncoding = decodePercentEncoding;
+		this.sortQueryParameters = sortQueryParameters;
+		this.lowercaseQueryParameterNames = lowercaseQueryParameterNames;
+		this.dropEmptyQueryParameters = dropEmptyQueryParameters;
+	}
+
+	/**
+	 * Creates a normalizer with the options set in the given CRConfig {@code config} section,
+	 * using the options of {@link #DEFAULT} for those that aren't set.
+	 *
+	 * @param config The {@code config} section of a CRConfig
+	 * @return The normalizer
+	 */
+	public static PathAndQueryNormalizer fromConfig(final JsonNode config) {
+		return new PathAndQueryNormalizer(
+			JsonUtils.optBoolean(config, DECODE_PERCENT_ENCODING, DEFAULT.decodePercentEncoding),
+			JsonUtils.optBoolean(config, SORT_QUERY_PARAMETERS, DEFAULT.sortQueryParameters),
+			JsonUtils.optBoolean(config, LOWERCASE_QUERY_PARAMETER_NAMES, DEFAULT.lowercaseQueryParameterNames),
+			JsonUtils.optBoolean(config, DROP_EMPTY_QUERY_PARAMETERS, DEFAULT.dropEmptyQueryParameters)
+		);
+	}
+
+	/**
+	 * Normalizes a request's path and query string.
+	 *
+	 * @param path The request path - e.g. {@code /some/path}
+	 * @param queryString The query string, without the leading '?', or {@code null} if there is none
+	 * @return The normalized path, followed by '?' and the normalized query string if any query
+	 * parameters remain
+	 */
+	@SuppressWarnings({"PMD.CyclomaticComplexity", "PMD.NPathComplexity"})
+	public String normalize(final String path, final String queryString) {
+		String normalizedPath = path == null ? "" : path;
+		if (decodePercentEncoding) {
+			normalizedPath = decodeUnreserved(normalizedPath);
+		}
+
+		if (queryString == null || queryString.isEmpty()) {
+			return normalizedPath;
+		}
+
+		final List<String> names = new ArrayList<>();
+		final List<String> params = new ArrayList<>();
+		for (final String param : queryString.split("&")) {
+			if (param.isEmpty()) {
+				continue;
+			}
+
+			final int eq = param.indexOf('=');
+			String name = eq < 0 ? param : param.substring(0, eq);
+			String value = eq < 0 ? "" : param.substring(eq + 1);
+			if (dropEmptyQueryParameters && value.isEmpty()) {
+				continue;
+			}
+			if (decodePercentEncoding) {
+				name = decodeUnreserved(name);
+				value = decodeUnreserved(value);
+			}
+			if (lowercaseQueryParameterNames) {
+				name = name.toLowerCase(Locale.ROOT);
+			}
+
+			names.add(name);
+			params.add(eq < 0 ? name : name + "=" + value);
+		}
+
+		if (params.isEmpty()) {
+			return normalizedPath;
+		}
+
+		final List<Integer> order = new ArrayList<>();
+		for (int i = 0; i < params.size(); i++) {
+			order.add(i);
+		}
+		if (sortQueryParameters) {
+			// The sort is stable, so parameters with the same name keep their order.
+			order.sort(Comparator.comparing(names::get));
+		}
+
+		final StringBuilder sb = new StringBuilder(normalizedPath).append('?');
+		for (int i = 0; i < order.size(); i++) {
+			if (i > 0) {
+				sb.append('&');
+			}
+			sb.append(params.get(order.get(i)));
+		}
+		return sb.toString();
+	}
+
+	/**
+	 * Decodes the percent-encoded octets of {@code s} that represent unreserved characters, and
+	 * upper-cases the hexadecimal digits of the rest, as described in RFC 3986 section 6.2.2.
+	 */
+	@SuppressWarnings("PMD.CyclomaticComplexity")
+	static String decodeUnreserved(final String s) {
+		if (s.indexOf('%') < 0) {
+			return s;
+		}
+
+		final StringBuilder sb = new StringBuilder(s.length());
+		for (int i = 0; i < s.length(); i++) {
+			final char c = s.charAt(i);
+			if (c != '%' || i + 2 >= s.length()) {
+				sb.append(c);
+				continue;
+			}
+
+			final int hi = Character.digit(s.charAt(i + 1), 16);
+			final int lo = Character.digit(s.charAt(i + 2), 16);
+			if (hi < 0 || lo < 0) {
+				sb.append(c);
+				continue;
+			}
+
+			final char decoded = (char) (hi << 4 | lo);
+			if (isUnreserved(decoded)) {
+				sb.append(decoded);
+			} else {
+				sb.append('%').append(Character.toUpperCase(s.charAt(i + 1))).append(Character.toUpperCase(s.charAt(i + 2)));
+			}
+			i += 2;
+		}
+		return sb.toString();
+	}
+
+	private static boolean isUnreserved(final char c) {
+		return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || UNRESERVED_SYMBOLS.indexOf(c) >= 0;
+	}
+
+	@Override
+	public String toString() {
+		return "PathAndQueryNormalizer{" +
+			"decodePercentEncoding=" + decodePercentEncoding +
+			", sortQueryParameters=" + sortQueryParameters +
+			", lowercaseQueryParameterNames=" + lowercaseQueryParameterNames +
+			", dropEmptyQueryParameters=" + dropEmptyQueryParameters +
+			'}';
+	}
+}
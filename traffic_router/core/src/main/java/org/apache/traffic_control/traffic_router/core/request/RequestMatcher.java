@@ -30,14 +30,20 @@ public class RequestMatcher implements Comparable<RequestMatcher> {
 	private final Pattern pattern;
 	private String requestHeader = "";
 	private final ComparableStringByLength comparableRegex;
+	private final PathAndQueryNormalizer pathAndQueryNormalizer;
 
 	public RequestMatcher(final Type type, final String regex, final String requestHeader) {
+		this(type, regex, requestHeader, PathAndQueryNormalizer.DEFAULT);
+	}
+
+	public RequestMatcher(final Type type, final String regex, final String requestHeader, final PathAndQueryNormalizer pathAndQueryNormalizer) {
 		if (type == Type.HEADER && (requestHeader == null || requestHeader.isEmpty())) {
 			throw new IllegalArgumentException("Request Header name must be supplied for type HEADER");
 		}
 
 		this.type = type;
 		this.requestHeader = requestHeader;
+		this.pathAndQueryNormalizer = pathAndQueryNormalizer;
 		pattern = Pattern.compile(regex, Pattern.CASE_INSENSITIVE);
 
 		final Matcher matcher = metaPattern.matcher(regex);
@@ -92,6 +98,10 @@ public class RequestMatcher implements Comparable<RequestMatcher> {
 			return httpRequest.getPath() + "?" + httpRequest.getQueryString();
 		}
 
+		if (type == Type.PATH_AND_QUERY) {
+			return pathAndQueryNormalizer.normalize(httpRequest.getPath(), httpRequest.getQueryString());
+		}
+
 		return null;
 	}
 
@@ -133,6 +133,7 @@ public class TrafficRouter {
 
 	private final ConsistentHasher consistentHasher = new ConsistentHasher();
 	private SteeringRegistry steeringRegistry;
+	private volatile Map<String, Boolean> externalTargetStates = new HashMap<>();
 
 	private final Map<String, Geolocation> defaultGeolocationsOverride = new HashMap<String, Geolocation>();
 
@@ -252,9 +253,38 @@ public class TrafficRouter {
 	boolean setState(final JsonNode states) throws UnknownHostException {
 		setCacheStates(states.get("caches"));
 		setDsStates(states.get("deliveryServices"));
+		setExternalTargetStates(states.get("externalTargets"));
 		return true;
 	}
 
+	/**
+	 * Sets the availability of steering external targets based on the input JSON.
+	 * <p>
+	 * External targets missing from the input are considered available, so that clients are
+	 * still steered to them until Traffic Monitor starts polling them.
+	 * </p>
+	 * @param externalTargetStates The input JSON object. Expected to be a map of external target
+	 * names to objects with an "isAvailable" boolean.
+	 * @return {@code false} iff externalTargetStates was {@code null}, otherwise {@code true}.
+	 */
+	private boolean setExternalTargetStates(final JsonNode externalTargetStates) {
+		final Map<String, Boolean> states = new HashMap<>();
+		if (externalTargetStates != null) {
+			externalTargetStates.fields().forEachRemaining(entry -> states.put(entry.getKey(), entry.getValue().path("isAvailable").asBoolean(true)));
+		}
+		this.externalTargetStates = states;
+		return externalTargetStates != null;
+	}
+
+	/**
+	 * Checks whether a steering external target is available.
+	 * @param name The name of the external target.
+	 * @return {@code false} iff Traffic Monitor reported the external target as unavailable.
+	 */
+	public boolean isExternalTargetAvailable(final String name) {
+		return externalTargetStates.getOrDefault(name, true);
+	}
+
 	/**
 	 * Sets Delivery Service states based on the input JSON.
 	 * <p>
@@ -878,7 +908,7 @@ public class TrafficRouter {
 		}
 
 		for (final Cache cache : selectedCaches) {
-			addresses.addAll(cache.getIpAddresses(ds.getTtls(), ds.isIp6RoutingEnabled()));
+			addresses.addAll(cache.getIpAddresses(ds.getId(), ds.getTtls(), ds.isIp6RoutingEnabled()));
 		}
 
 		return addresses;
@@ -1030,6 +1060,8 @@ public class TrafficRouter {
 			return null;
 		}
 
+		filterSteeringResultsByCountry(steeringResults, request.getClientIP(), entryDeliveryService);
+
 		final HTTPRouteResult routeResult = new HTTPRouteResult(true);
 		routeResult.setDeliveryService(entryDeliveryService);
 
@@ -1047,6 +1079,9 @@ public class TrafficRouter {
 		// Pattern based consistent hashing - use consistentHashRegex from steering DS instead of targets
 		final String steeringHash = buildPatternBasedHashString(entryDeliveryService.getConsistentHashRegex(), request.getPath());
 		for (final SteeringResult steeringResult : steeringResults) {
+			if (steeringResult.isExternal()) {
+				continue;
+			}
 			final DeliveryService ds = steeringResult.getDeliveryService();
 			List<Cache> caches = selectCaches(request, ds, track);
 
@@ -1067,7 +1102,7 @@ public class TrafficRouter {
 						caches = tryCaches;
 					}
 				}
-				final Cache cache = consistentHasher.selectHashable(caches, ds.getDispersion(), pathToHash);
+				final Cache cache = consistentHasher.selectHashable(caches, ds.getDispersion(), pathToHash, ds.getConsistentHashReplicas());
 				steeringResult.setCache(cache);
 				selectedCaches.add(cache);
 			} else {
@@ -1079,6 +1114,10 @@ public class TrafficRouter {
 		geoSortSteeringResults(steeringResults, request.getClientIP(), entryDeliveryService);
 
 		for (final SteeringResult steeringResult: steeringResults) {
+			if (steeringResult.isExternal()) {
+				routeResult.addUrl(new URL(steeringResult.getSteeringTarget().createURIString(request)));
+				continue;
+			}
 			routeResult.addUrl(new URL(steeringResult.getDeliveryService().createURIString(request, steeringResult.getCache())));
 			routeResult.addDeliveryService(steeringResult.getDeliveryService());
 		}
@@ -1101,15 +1140,42 @@ public class TrafficRouter {
 	 * @param request An {@link HTTPRequest} representing the client's request.
 	 * @return A string appropriate to use for consistent hashing to service the request
 	*/
-	@SuppressWarnings({"PMD.CyclomaticComplexity"})
 	public String buildPatternBasedHashString(final DeliveryService deliveryService, final HTTPRequest request) {
+		return buildConsistentHashString(
+			deliveryService.getConsistentHashRegex(),
+			deliveryService.isConsistentHashIncludePath(),
+			deliveryService.getConsistentHashQueryParams(),
+			deliveryService.getConsistentHashHeaders(),
+			request
+		);
+	}
+
+	/**
+	 * Creates a string to be used in consistent hashing from the given consistent hashing options.
+	 *<p>
+	 * This is the part of the request path matched by {@code regex} - unless {@code includePath}
+	 * is {@code false} - followed by the values of any of the {@code queryParams} in the request's
+	 * query string, followed by the values of any of the {@code headers} in the request's headers.
+	 *</p>
+	 * @param regex A regular expression matched against the client's request path to extract
+	 * information important to consistent hashing
+	 * @param includePath Whether or not the request path is part of the string
+	 * @param queryParams The names of the query parameters that are part of the string
+	 * @param headers The lower-cased names of the request headers that are part of the string
+	 * @param request An {@link HTTPRequest} representing the client's request.
+	 * @return A string appropriate to use for consistent hashing to service the request
+	 */
+	@SuppressWarnings({"PMD.CyclomaticComplexity"})
+	public String buildConsistentHashString(final String regex, final boolean includePath, final Set<String> queryParams,
+			final Set<String> headers, final HTTPRequest request) {
 		final String requestPath = request.getPath();
 		final StringBuilder hashString = new StringBuilder("");
-		if (deliveryService.getConsistentHashRegex() != null && !requestPath.isEmpty()) {
-			hashString.append(buildPatternBasedHashString(deliveryService.getConsistentHashRegex(), requestPath));
+		if (includePath && regex != null && requestPath != null && !requestPath.isEmpty()) {
+			hashString.append(buildPatternBasedHashString(regex, requestPath));
 		}
 
-		hashString.append(deliveryService.extractSignificantQueryParams(request));
+		hashString.append(DeliveryService.extractSignificantQueryParams(request, queryParams));
+		hashString.append(DeliveryService.extractSignificantHeaders(request, headers));
 
 		return hashString.toString();
 	}
@@ -1230,7 +1296,7 @@ public class TrafficRouter {
 
 		// Pattern based consistent hashing
 		final String pathToHash = buildPatternBasedHashString(deliveryService, request);
-		final Cache cache = consistentHasher.selectHashable(caches, deliveryService.getDispersion(), pathToHash);
+		final Cache cache = consistentHasher.selectHashable(caches, deliveryService.getDispersion(), pathToHash, deliveryService.getConsistentHashReplicas());
 
 		// Enforce anonymous IP blocking if a DS has anonymous blocking enabled
 		// and the feature is enabled
@@ -1259,7 +1325,7 @@ public class TrafficRouter {
 	 * @param entryDeliveryService The steering Delivery Service being served.
 	 * @return All of the possible steering results for routing request through entryDeliveryService.
 	 */
-	@SuppressWarnings({"PMD.NPathComplexity"})
+	@SuppressWarnings({"PMD.CyclomaticComplexity", "PMD.NPathComplexity"})
 	private List<SteeringResult> getSteeringResults(final HTTPRequest request, final Track track, final DeliveryService entryDeliveryService) {
 
 		if (isTlsMismatch(request, entryDeliveryService)) {
@@ -1278,6 +1344,9 @@ public class TrafficRouter {
 
 		final List<SteeringResult> toBeRemoved = new ArrayList<>();
 		for (final SteeringResult steeringResult : steeringResults) {
+			if (steeringResult.isExternal()) {
+				continue;
+			}
 			final DeliveryService ds = steeringResult.getDeliveryService();
 			if (isTlsMismatch(request, ds)) {
 				track.setResult(ResultType.ERROR);
@@ -1508,6 +1577,26 @@ public class TrafficRouter {
 		return consistentHashForCoverageZone(ip, deliveryServiceId, requestPath, false);
 	}
 
+	/**
+	 * Creates a request for the consistent hashing API from a requested path, which may include a
+	 * query string - e.g. {@code /request/path?token=abc}.
+	 *
+	 * @param requestPath The requested path, optionally followed by '?' and a query string
+	 * @return A request with the path and query string of requestPath
+	 */
+	private static HTTPRequest requestForPath(final String requestPath) {
+		final HTTPRequest r = new HTTPRequest();
+		final int queryStart = requestPath == null ? -1 : requestPath.indexOf('?');
+		if (queryStart < 0) {
+			r.setPath(requestPath);
+			r.setQueryString("");
+		} else {
+			r.setPath(requestPath.substring(0, queryStart));
+			r.setQueryString(requestPath.substring(queryStart + 1));
+		}
+		return r;
+	}
+
 	/**
 	 * Chooses a cache for a Delivery Service based on the Coverage Zone File or Deep Coverage Zone
 	 * File given a client's IP and request *path*.
@@ -1520,9 +1609,7 @@ public class TrafficRouter {
 	 * @return A {@link Cache} object chosen to serve the client's request
 	 */
 	public Cache consistentHashForCoverageZone(final String ip, final String deliveryServiceId, final String requestPath, final boolean useDeep) {
-		final HTTPRequest r = new HTTPRequest();
-		r.setPath(requestPath);
-		r.setQueryString("");
+		final HTTPRequest r = requestForPath(requestPath);
 		return consistentHashForCoverageZone(ip, deliveryServiceId, r, useDeep);
 	}
 
@@ -1552,7 +1639,7 @@ public class TrafficRouter {
 		}
 
 		final String pathToHash = buildPatternBasedHashString(deliveryService, request);
-		return consistentHasher.selectHashable(caches, deliveryService.getDispersion(), pathToHash);
+		return consistentHasher.selectHashable(caches, deliveryService.getDispersion(), pathToHash, deliveryService.getConsistentHashReplicas());
 	}
 
 	/**
@@ -1565,9 +1652,7 @@ public class TrafficRouter {
 	 * @return A cache object chosen to serve the client's request
 	 */
 	public Cache consistentHashForGeolocation(final String ip, final String deliveryServiceId, final String requestPath) {
-		final HTTPRequest r = new HTTPRequest();
-		r.setPath(requestPath);
-		r.setQueryString("");
+		final HTTPRequest r = requestForPath(requestPath);
 		return consistentHashForGeolocation(ip, deliveryServiceId, r);
 	}
 
@@ -1606,7 +1691,7 @@ public class TrafficRouter {
 		}
 
 		final String pathToHash = buildPatternBasedHashString(deliveryService, request);
-		return consistentHasher.selectHashable(caches, deliveryService.getDispersion(), pathToHash);
+		return consistentHasher.selectHashable(caches, deliveryService.getDispersion(), pathToHash, deliveryService.getConsistentHashReplicas());
 	}
 
 	/**
@@ -1617,12 +1702,34 @@ public class TrafficRouter {
 	 * @return A string suitable for using in consistent hashing.
 	 */
 	public String buildPatternBasedHashStringDeliveryService(final String deliveryServiceId, final String requestPath) {
-		final HTTPRequest r = new HTTPRequest();
-		r.setPath(requestPath);
-		r.setQueryString("");
+		final HTTPRequest r = requestForPath(requestPath);
 		return buildPatternBasedHashString(cacheRegister.getDeliveryService(deliveryServiceId), r);
 	}
 
+	/**
+	 * Builds a string to be used for consistent hashing based on a client's request *path*, query
+	 * string, and headers, using the given consistent hashing options rather than those of a
+	 * Delivery Service.
+	 * @param regex A regular expression matched against the client's request path to extract
+	 * information important to consistent hashing
+	 * @param includePath Whether or not the request path is part of the string
+	 * @param queryParams The names of the query parameters that are part of the string
+	 * @param headers The request headers that are part of the string, mapped to their values in the
+	 * client's request
+	 * @param requestPath The client's requested path, optionally with a query string.
+	 * @return A string suitable for using in consistent hashing.
+	 */
+	public String buildConsistentHashString(final String regex, final boolean includePath, final Set<String> queryParams,
+			final Map<String, String> headers, final String requestPath) {
+		final HTTPRequest r = requestForPath(requestPath);
+		r.setHeaders(headers);
+		final Set<String> headerNames = new HashSet<>();
+		for (final String name : headers.keySet()) {
+			headerNames.add(name.toLowerCase());
+		}
+		return buildConsistentHashString(regex, includePath, queryParams, headerNames, r);
+	}
+
 	/**
 	 * Returns whether or not the given Delivery Service is of the STEERING or CLIENT_STEERING type.
 	 */
@@ -1691,6 +1798,36 @@ public class TrafficRouter {
 		}
 	}
 
+	/**
+	 * Removes the steering results whose targets are limited to countries other than the client's.
+	 * Clients that can't be geo-located are only steered to targets without country limits.
+	 * @param steeringResults The results to be filtered. They are filtered "in place" - this
+	 * modifies the list directly.
+	 * @param clientIP The client's IP address as a string. This is used to look up their country.
+	 * @param deliveryService The Delivery Service being served. This determines the geolocation
+	 * provider used to look up the client's country.
+	 */
+	protected void filterSteeringResultsByCountry(final List<SteeringResult> steeringResults, final String clientIP, final DeliveryService deliveryService) {
+		if (steeringResults.stream().allMatch(s -> s.getSteeringTarget() == null || s.getSteeringTarget().getGeoLimitCountries().isEmpty())) {
+			return;
+		}
+
+		String countryCode = null;
+		if (clientIP != null && !clientIP.isEmpty()) {
+			try {
+				final Geolocation clientLocation = getLocation(clientIP, deliveryService);
+				if (clientLocation != null) {
+					countryCode = clientLocation.getCountryCode();
+				}
+			} catch (GeolocationException e) {
+				LOGGER.warn("Failed looking up the country of client " + clientIP + " for steering: " + e.getMessage());
+			}
+		}
+
+		final String clientCountryCode = countryCode;
+		steeringResults.removeIf(s -> s.getSteeringTarget() != null && !s.getSteeringTarget().isCountryAllowed(clientCountryCode));
+	}
+
 	public List<SteeringResult> consistentHashMultiDeliveryService(final DeliveryService deliveryService, final HTTPRequest request) {
 		if (deliveryService == null) {
 			return null;
@@ -1710,6 +1847,12 @@ public class TrafficRouter {
 		final List<SteeringTarget> steeringTargets = consistentHasher.selectHashables(steering.getTargets(), pathToHash);
 
 		for (final SteeringTarget steeringTarget : steeringTargets) {
+			if (steeringTarget.isExternal()) {
+				if (isExternalTargetAvailable(steeringTarget.getDeliveryService())) {
+					steeringResults.add(new SteeringResult(steeringTarget, null));
+				}
+				continue;
+			}
 			final DeliveryService target = cacheRegister.getDeliveryService(steeringTarget.getDeliveryService());
 
 			if (target != null) { // target might not be in CRConfig yet
@@ -1730,9 +1873,7 @@ public class TrafficRouter {
 	 * @return A cache object chosen to serve the client's request
 	 */
 	public Cache consistentHashSteeringForCoverageZone(final String ip, final String deliveryServiceId, final String requestPath) {
-		final HTTPRequest r = new HTTPRequest();
-		r.setPath(requestPath);
-		r.setQueryString("");
+		final HTTPRequest r = requestForPath(requestPath);
 		return consistentHashSteeringForCoverageZone(ip, deliveryServiceId, r);
 	}
 
@@ -1760,7 +1901,7 @@ public class TrafficRouter {
 		}
 
 		final String pathToHash = buildPatternBasedHashString(deliveryService, request);
-		return consistentHasher.selectHashable(caches, deliveryService.getDispersion(), pathToHash);
+		return consistentHasher.selectHashable(caches, deliveryService.getDispersion(), pathToHash, deliveryService.getConsistentHashReplicas());
 	}
 
 	/**
@@ -1772,9 +1913,7 @@ public class TrafficRouter {
 	 * @return The chosen target Delivery Service, or null if one could not be determined.
 	*/
 	public DeliveryService consistentHashDeliveryService(final String deliveryServiceId, final String requestPath) {
-		final HTTPRequest r = new HTTPRequest();
-		r.setPath(requestPath);
-		r.setQueryString("");
+		final HTTPRequest r = requestForPath(requestPath);
 		return consistentHashDeliveryService(deliveryServiceId, r);
 	}
 
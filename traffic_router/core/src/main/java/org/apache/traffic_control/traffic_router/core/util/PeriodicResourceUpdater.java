@@ -26,6 +26,7 @@ import java.io.InputStreamReader;
 import java.io.StringReader;
 import java.net.URI;
 import java.net.URISyntaxException;
+import java.nio.charset.StandardCharsets;
 import java.nio.channels.FileLock;
 import java.util.concurrent.Executors;
 import java.util.concurrent.ScheduledExecutorService;
@@ -64,6 +65,8 @@ public class PeriodicResourceUpdater {
 
 	protected ScheduledFuture<?> scheduledService;
 
+	private SignatureVerifier signatureVerifier;
+
 	public PeriodicResourceUpdater(final AbstractUpdatable listener, final ResourceUrl urls, final String location, final int interval, final boolean pauseTilLoaded) {
 		this.listener = listener;
 		this.urls = urls;
@@ -84,6 +87,17 @@ public class PeriodicResourceUpdater {
 		}
 	}
 
+	/**
+	 * Sets the verifier of the signatures of fetched resources. If set,
+	 * resources without a valid signature are rejected.
+	 *
+	 * @param signatureVerifier
+	 *            the signatureVerifier to set
+	 */
+	public void setSignatureVerifier(final SignatureVerifier signatureVerifier) {
+		this.signatureVerifier = signatureVerifier;
+	}
+
 	/**
 	 * Gets pollingInterval.
 	 * 
@@ -280,7 +294,22 @@ public class PeriodicResourceUpdater {
 			}
 
 			final String responseBody;
-			if (GZIP_ENCODING_STRING.equals(response.getHeader("Content-Encoding"))) {
+			if (signatureVerifier != null) {
+				// signatures cover the exact bytes of the resource, so it can't be read line-by-line
+				final byte[] bodyBytes;
+				if (GZIP_ENCODING_STRING.equals(response.getHeader("Content-Encoding"))) {
+					try (GZIPInputStream zippedInputStream = new GZIPInputStream(response.getResponseBodyAsStream())) {
+						bodyBytes = IOUtils.toByteArray(zippedInputStream);
+					}
+				} else {
+					bodyBytes = response.getResponseBodyAsBytes();
+				}
+				if (!signatureVerifier.verify(bodyBytes, response.getHeader(SignatureVerifier.RESPONSE_HEADER))) {
+					LOGGER.error("rejecting " + response.getUri() + " - missing or invalid signature for CDN " + signatureVerifier.getCdn());
+					return code;
+				}
+				responseBody = new String(bodyBytes, StandardCharsets.UTF_8);
+			} else if (GZIP_ENCODING_STRING.equals(response.getHeader("Content-Encoding"))) {
 				final StringBuilder stringBuilder = new StringBuilder();
 				try (GZIPInputStream zippedInputStream =  new GZIPInputStream(response.getResponseBodyAsStream());
 					 BufferedReader r = new BufferedReader(new InputStreamReader(zippedInputStream))) {
@@ -0,0 +1,90 @@
+/*
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package org.apache.traffic_control.traffic_router.core.util;
+
+import java.io.IOException;
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.nio.file.Paths;
+import java.security.GeneralSecurityException;
+import java.security.KeyFactory;
+import java.security.PublicKey;
+import java.security.Signature;
+import java.security.spec.X509EncodedKeySpec;
+import java.util.Base64;
+
+/**
+ * Verifies the signatures Traffic Ops makes of the configuration of a CDN with
+ * the CDN's signing key, which Traffic Monitor passes along in the
+ * Response-Signature header.
+ */
+public class SignatureVerifier {
+	public static final String RESPONSE_HEADER = "Response-Signature";
+
+	private static final String CDN_PEM_HEADER = "CDN:";
+
+	private final String cdn;
+	private final PublicKey publicKey;
+
+	public SignatureVerifier(final String cdn, final PublicKey publicKey) {
+		this.cdn = cdn;
+		this.publicKey = publicKey;
+	}
+
+	/**
+	 * Loads the PEM-encoded public signing key of a CDN, as served by the
+	 * Traffic Ops API, from the given file.
+	 */
+	public static SignatureVerifier fromFile(final String path) throws IOException, GeneralSecurityException {
+		String cdn = null;
+		final StringBuilder encoded = new StringBuilder();
+		for (final String line : Files.readAllLines(Paths.get(path), StandardCharsets.UTF_8)) {
+			final String trimmed = line.trim();
+			if (trimmed.startsWith(CDN_PEM_HEADER)) {
+				cdn = trimmed.substring(CDN_PEM_HEADER.length()).trim();
+			} else if (!trimmed.isEmpty() && !trimmed.startsWith("-----") && !trimmed.contains(":")) {
+				encoded.append(trimmed);
+			}
+		}
+		if (cdn == null || cdn.isEmpty()) {
+			throw new GeneralSecurityException("public key file " + path + " has no CDN header");
+		}
+		final X509EncodedKeySpec spec = new X509EncodedKeySpec(Base64.getDecoder().decode(encoded.toString()));
+		return new SignatureVerifier(cdn, KeyFactory.getInstance("EC").generatePublic(spec));
+	}
+
+	public String getCdn() {
+		return cdn;
+	}
+
+	/**
+	 * Returns whether the given base64-encoded signature is a valid signature
+	 * of the given data by this verifier's key.
+	 */
+	public boolean verify(final byte[] data, final String signature) {
+		if (signature == null || signature.isEmpty()) {
+			return false;
+		}
+		try {
+			final Signature verifier = Signature.getInstance("SHA256withECDSA");
+			verifier.initVerify(publicKey);
+			verifier.update(data);
+			return verifier.verify(Base64.getDecoder().decode(signature));
+		} catch (GeneralSecurityException | IllegalArgumentException e) {
+			return false;
+		}
+	}
+}
@@ -21,6 +21,9 @@ import com.fasterxml.jackson.databind.ObjectMapper;
 import org.junit.Test;
 import org.powermock.reflect.Whitebox;
 
+import java.util.HashMap;
+import java.util.Map;
+
 import static org.hamcrest.MatcherAssert.assertThat;
 import static org.hamcrest.Matchers.containsInAnyOrder;
 import static org.hamcrest.Matchers.equalTo;
@@ -65,6 +68,29 @@ public class DeliveryServiceTest {
         assert (new DeliveryService("test", json)).extractSignificantQueryParams(r).equals("quest=oth ervaluetest=value");
     }
 
+    @Test
+    public void itDefaultsConsistentHashOptions() throws Exception {
+        final JsonNode json = (new ObjectMapper()).readTree("{\"routingName\":\"edge\",\"coverageZoneOnly\":false}");
+        final DeliveryService d = new DeliveryService("test", json);
+        assert d.isConsistentHashIncludePath();
+        assert d.getConsistentHashHeaders() != null;
+        assert d.getConsistentHashHeaders().isEmpty();
+        assert d.getConsistentHashReplicas() == 0;
+    }
+
+    @Test
+    public void itExtractsHeaders() throws Exception {
+        final JsonNode json = (new ObjectMapper()).readTree("{\"routingName\":\"edge\",\"coverageZoneOnly\":false,\"consistentHashHeaders\":[\"X-Device-Class\", \"Accept-Language\"]}");
+        final HTTPRequest r = new HTTPRequest();
+        r.setPath("/path1234/some_stream_name1234/some_other_info.m3u8");
+        final Map<String, String> headers = new HashMap<>();
+        headers.put("X-Device-Class", "tv");
+        headers.put("accept-language", "en-US");
+        headers.put("User-Agent", "player/1.0");
+        r.setHeaders(headers);
+        assert (new DeliveryService("test", json)).extractSignificantHeaders(r).equals("accept-language:en-USx-device-class:tv");
+    }
+
     @Test
     public void itConfiguresRequestHeadersFromJSON() throws Exception {
         final ObjectMapper mapper = new ObjectMapper();
@@ -84,4 +110,20 @@ public class DeliveryServiceTest {
 
         assertThat(Whitebox.getInternalState(deliveryService, "requiredCapabilities"), containsInAnyOrder("all-read", "all-write", "cdn-read"));
     }
+
+    @Test
+    public void itHonorsItsSchedule() throws Exception {
+        final ObjectMapper mapper = new ObjectMapper();
+        final JsonNode json = mapper.readTree("{\"routingName\":\"edge\",\"coverageZoneOnly\":false,\"activeAt\":1000,\"inactiveAt\":2000}");
+        final DeliveryService deliveryService = new DeliveryService("scheduled", json);
+
+        assertThat(deliveryService.isScheduledActive(999999L), equalTo(false));
+        assertThat(deliveryService.isScheduledActive(1000000L), equalTo(true));
+        assertThat(deliveryService.isScheduledActive(1999999L), equalTo(true));
+        assertThat(deliveryService.isScheduledActive(2000000L), equalTo(false));
+
+        final DeliveryService unscheduled = new DeliveryService("unscheduled", mapper.readTree("{\"routingName\":\"edge\",\"coverageZoneOnly\":false}"));
+        assertThat(unscheduled.isScheduledActive(0L), equalTo(true));
+        assertThat(unscheduled.isAvailable(), equalTo(true));
+    }
 }
@@ -148,4 +148,16 @@ public class SteeringGeolocationComparatorTest {
         assertEquals(1, seattleComparator.compare(seattleResult, bostonResult));
     }
 
+    @Test
+    public void testExternalTargetWithoutCache() {
+        final SteeringTarget externalTarget = new SteeringTarget();
+        externalTarget.setExternalFqdn("cdn.external.test");
+        externalTarget.setGeolocation(denverGeolocation);
+        final SteeringResult externalResult = new SteeringResult(externalTarget, null);
+        // seattle -> denver || seattle -> boston
+        assertEquals(-1, seattleComparator.compare(externalResult, bostonResult));
+        // boston -> denver || boston
+        assertEquals(1, bostonComparator.compare(externalResult, bostonResult));
+    }
+
 }
@@ -0,0 +1,56 @@
+/*
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package org.apache.traffic_control.traffic_router.core.edge;
+
+import org.junit.Before;
+import org.junit.Test;
+
+import java.net.InetAddress;
+import java.util.List;
+
+import static org.hamcrest.MatcherAssert.assertThat;
+import static org.hamcrest.Matchers.equalTo;
+import static org.hamcrest.Matchers.nullValue;
+
+public class CacheTest {
+	private Cache cache;
+
+	@Before
+	public void before() throws Exception {
+		cache = new Cache("edge", "edge", 1);
+		cache.setIpAddress("192.0.2.1", "2001:db8::1/64", 0);
+		cache.setDeliveryServiceIpAddress("ds1", "198.51.100.1", "");
+	}
+
+	@Test
+	public void itUsesDeliveryServiceAddresses() throws Exception {
+		assertThat(cache.getIp4("ds1"), equalTo(InetAddress.getByName("198.51.100.1")));
+		assertThat(cache.getIp6("ds1"), nullValue());
+
+		final List<InetRecord> records = cache.getIpAddresses("ds1", null, true);
+		assertThat(records.size(), equalTo(1));
+		assertThat(records.get(0).getAddress(), equalTo(InetAddress.getByName("198.51.100.1")));
+	}
+
+	@Test
+	public void itFallsBackToCacheAddresses() throws Exception {
+		assertThat(cache.getIp4("ds2"), equalTo(InetAddress.getByName("192.0.2.1")));
+		assertThat(cache.getIp6("ds2"), equalTo(InetAddress.getByName("2001:db8::1")));
+		assertThat(cache.getIpAddresses("ds2", null, true).size(), equalTo(2));
+		assertThat(cache.getIpAddresses("ds2", null, false).size(), equalTo(1));
+	}
+}
@@ -45,6 +45,8 @@ import static org.hamcrest.Matchers.lessThan;
 import static org.hamcrest.Matchers.notNullValue;
 import static org.hamcrest.core.IsEqual.equalTo;
 import static org.junit.Assert.assertThat;
+import static org.mockito.ArgumentMatchers.anyBoolean;
+import static org.mockito.ArgumentMatchers.anySet;
 import static org.mockito.ArgumentMatchers.anyString;
 import static org.mockito.ArgumentMatchers.any;
 import static org.mockito.Mockito.mock;
@@ -86,6 +88,7 @@ public class ConsistentHasherTest {
 		trafficRouter = mock(TrafficRouter.class);
 		when(trafficRouter.buildPatternBasedHashString(anyString(), anyString())).thenCallRealMethod();
 		when(trafficRouter.buildPatternBasedHashString(any(DeliveryService.class), any(HTTPRequest.class))).thenCallRealMethod();
+		when(trafficRouter.buildConsistentHashString(anyString(), anyBoolean(), anySet(), anySet(), any(HTTPRequest.class))).thenCallRealMethod();
 
 		MockitoAnnotations.openMocks(this);
 	}
@@ -228,6 +231,63 @@ public class ConsistentHasherTest {
 		assert !p1.equals(p2);
 	}
 
+	@Test
+	public void itHashesHeaders() throws Exception {
+		final JsonNode j = (new ObjectMapper()).readTree("{\"routingName\":\"edge\",\"coverageZoneOnly\":false,\"consistentHashHeaders\":[\"X-Device-Class\"]}");
+		final DeliveryService d = new DeliveryService("test", j);
+
+		final HTTPRequest r1 = new HTTPRequest();
+		r1.setPath("/path1234/some_stream_name1234/some_other_info.m3u8");
+		final Map<String, String> h1 = new HashMap<>();
+		h1.put("x-device-class", "tv");
+		h1.put("User-Agent", "player/1.0");
+		r1.setHeaders(h1);
+
+		final HTTPRequest r2 = new HTTPRequest();
+		r2.setPath(r1.getPath());
+		final Map<String, String> h2 = new HashMap<>();
+		h2.put("X-Device-Class", "mobile");
+		h2.put("User-Agent", "player/1.0");
+		r2.setHeaders(h2);
+
+		final HTTPRequest r3 = new HTTPRequest();
+		r3.setPath(r1.getPath());
+		final Map<String, String> h3 = new HashMap<>();
+		h3.put("X-Device-Class", "tv");
+		h3.put("User-Agent", "player/2.0");
+		r3.setHeaders(h3);
+
+		final String p1 = trafficRouter.buildPatternBasedHashString(d, r1);
+		assertThat(p1, equalTo(r1.getPath() + "x-device-class:tv"));
+		assert !p1.equals(trafficRouter.buildPatternBasedHashString(d, r2));
+		assertThat(trafficRouter.buildPatternBasedHashString(d, r3), equalTo(p1));
+	}
+
+	@Test
+	public void itExcludesThePathWhenConfigured() throws Exception {
+		final JsonNode j = (new ObjectMapper()).readTree("{\"routingName\":\"edge\",\"coverageZoneOnly\":false,\"consistentHashIncludePath\":false,\"consistentHashQueryParams\":[\"test\"]}");
+		final DeliveryService d = new DeliveryService("test", j);
+
+		final HTTPRequest r1 = new HTTPRequest();
+		r1.setPath("/path1234/some_stream_name1234/some_other_info.m3u8");
+		r1.setQueryString("test=value");
+
+		final HTTPRequest r2 = new HTTPRequest();
+		r2.setPath("/path5678/some_stream_name5678/some_other_info.m3u8");
+		r2.setQueryString("test=value");
+
+		assertThat(trafficRouter.buildPatternBasedHashString(d, r1), equalTo("test=value"));
+		assertThat(trafficRouter.buildPatternBasedHashString(d, r2), equalTo("test=value"));
+	}
+
+	@Test
+	public void itHashesWithReplicas() {
+		final DefaultHashable selected = consistentHasher.selectHashable(hashables, null, "some-string", 500);
+		assertThat(selected, notNullValue());
+		assertThat(consistentHasher.selectHashable(hashables, null, "some-string", 500), equalTo(selected));
+		assertThat(consistentHasher.selectHashable(hashables, null, "some-string", 0), equalTo(consistentHasher.selectHashable(hashables, null, "some-string")));
+	}
+
 	String alphanumericCharacters = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWZYZ";
 	String exampleValidPathCharacters = alphanumericCharacters + "/=;()-.";
 
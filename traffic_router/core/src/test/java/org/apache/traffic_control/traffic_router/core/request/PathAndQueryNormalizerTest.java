@@ -0,0 +1,60 @@
+/*
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package org.apache.traffic_control.traffic_router.core.request;
+
+import static org.hamcrest.MatcherAssert.assertThat;
+import static org.hamcrest.Matchers.equalTo;
+
+import com.fasterxml.jackson.databind.ObjectMapper;
+import org.junit.Test;
+
+public class PathAndQueryNormalizerTest {
+
+	@Test
+	public void itNormalizesByDefault() {
+		PathAndQueryNormalizer normalizer = PathAndQueryNormalizer.DEFAULT;
+
+		assertThat(normalizer.normalize("/foo/bar", null), equalTo("/foo/bar"));
+		assertThat(normalizer.normalize("/foo/bar", ""), equalTo("/foo/bar"));
+		assertThat(normalizer.normalize("/f%6Fo/b%2fr", "b=2&a=1&&c"), equalTo("/foo/b%2Fr?a=1&b=2&c"));
+		assertThat(normalizer.normalize("/foo", "b=2&a=3&b=1"), equalTo("/foo?a=3&b=2&b=1"));
+		assertThat(normalizer.normalize("/foo", "token=%7eabc%3D&B=1"), equalTo("/foo?B=1&token=~abc%3D"));
+	}
+
+	@Test
+	public void itNormalizesWithOptions() {
+		PathAndQueryNormalizer normalizer = new PathAndQueryNormalizer(false, false, true, true);
+
+		assertThat(normalizer.normalize("/f%6Fo", "B=2&empty=&A=%31&flag"), equalTo("/f%6Fo?b=2&a=%31"));
+		assertThat(normalizer.normalize("/foo", "empty="), equalTo("/foo"));
+	}
+
+	@Test
+	public void itReadsOptionsFromConfig() throws Exception {
+		String config = "{\"pathAndQueryRegex.sortParams\": \"false\", \"pathAndQueryRegex.dropEmptyParams\": \"true\"}";
+		PathAndQueryNormalizer normalizer = PathAndQueryNormalizer.fromConfig(new ObjectMapper().readTree(config));
+
+		assertThat(normalizer.normalize("/f%6Fo", "b=2&a=&A=1"), equalTo("/foo?b=2&A=1"));
+	}
+
+	@Test
+	public void itLeavesMalformedPercentEncoding() {
+		assertThat(PathAndQueryNormalizer.decodeUnreserved("100%"), equalTo("100%"));
+		assertThat(PathAndQueryNormalizer.decodeUnreserved("%zz%4"), equalTo("%zz%4"));
+		assertThat(PathAndQueryNormalizer.decodeUnreserved("%41%2f"), equalTo("A%2F"));
+	}
+}
@@ -17,6 +17,7 @@ package org.apache.traffic_control.traffic_router.core.request;
 
 import static org.apache.traffic_control.traffic_router.core.ds.DeliveryServiceMatcher.Type.HOST;
 import static org.apache.traffic_control.traffic_router.core.ds.DeliveryServiceMatcher.Type.PATH;
+import static org.apache.traffic_control.traffic_router.core.ds.DeliveryServiceMatcher.Type.PATH_AND_QUERY;
 import static org.apache.traffic_control.traffic_router.core.ds.DeliveryServiceMatcher.Type.HEADER;
 import static org.hamcrest.MatcherAssert.assertThat;
 import static org.hamcrest.Matchers.equalTo;
@@ -101,6 +102,38 @@ public class RequestMatcherTest {
 		assertThat(requestMatcher.matches(httpRequest), equalTo(true));
 	}
 
+	@Test
+	public void itMatchesByNormalizedPathAndQuery() {
+		RequestMatcher requestMatcher = new RequestMatcher(PATH_AND_QUERY, "\\/foo\\/path\\/bar\\?a=1&token=[a-z]+", "");
+		Request request = new Request();
+
+		assertThat(requestMatcher.matches(request), equalTo(false));
+
+		HTTPRequest httpRequest = new HTTPRequest();
+		httpRequest.setPath("/foo/p%61th/bar");
+		httpRequest.setQueryString("token=abc&a=1");
+
+		assertThat(requestMatcher.matches(httpRequest), equalTo(true));
+
+		httpRequest.setQueryString("a=1");
+		assertThat(requestMatcher.matches(httpRequest), equalTo(false));
+	}
+
+	@Test
+	public void itMatchesByPathAndQueryWithGivenNormalization() {
+		PathAndQueryNormalizer normalizer = new PathAndQueryNormalizer(false, false, true, true);
+		RequestMatcher requestMatcher = new RequestMatcher(PATH_AND_QUERY, "\\/foo\\?token=abc&a=1", "", normalizer);
+
+		HTTPRequest httpRequest = new HTTPRequest();
+		httpRequest.setPath("/foo");
+		httpRequest.setQueryString("TOKEN=abc&empty=&A=1");
+
+		assertThat(requestMatcher.matches(httpRequest), equalTo(true));
+
+		httpRequest.setQueryString("A=1&TOKEN=abc");
+		assertThat(requestMatcher.matches(httpRequest), equalTo(false));
+	}
+
 	@Test
 	public void itMatchesByRequestHeader() {
 		RequestMatcher requestMatcher = new RequestMatcher(HEADER, ".*kabletown.*", "Host");
@@ -22,6 +22,7 @@ import org.apache.traffic_control.traffic_router.core.ds.SteeringTarget;
 import org.apache.traffic_control.traffic_router.geolocation.Geolocation;
 
 import java.util.ArrayList;
+import java.util.Arrays;
 import java.util.Collections;
 import java.util.List;
 
@@ -61,6 +62,7 @@ public class GeoSortSteeringResultsTest {
         deliveryService = mock(DeliveryService.class);
         doCallRealMethod().when(trafficRouter).geoSortSteeringResults(anyList(), anyString(), any(DeliveryService.class));
         when(trafficRouter.getClientLocationByCoverageZoneOrGeo(anyString(), any(DeliveryService.class))).thenReturn(clientLocation);
+        doCallRealMethod().when(trafficRouter).filterSteeringResultsByCountry(anyList(), any(), any(DeliveryService.class));
     }
 
     @Test
@@ -132,5 +134,50 @@ public class GeoSortSteeringResultsTest {
         assertEquals(resultNoGeoPositiveOrder, steeringResults.get(3));
     }
 
-}
+    @Test
+    public void testFilterByCountry() throws Exception {
+        clientLocation.setCountryCode("US");
+        when(trafficRouter.getLocation(anyString(), any(DeliveryService.class))).thenReturn(clientLocation);
+
+        SteeringTarget target = new SteeringTarget();
+        SteeringResult resultUnlimited = new SteeringResult(target, deliveryService);
+        steeringResults.add(resultUnlimited);
+
+        target = new SteeringTarget();
+        target.setGeoLimitCountries(Arrays.asList("CA", "us"));
+        SteeringResult resultIncluded = new SteeringResult(target, deliveryService);
+        steeringResults.add(resultIncluded);
+
+        target = new SteeringTarget();
+        target.setGeoLimitCountries(Collections.singletonList("GB"));
+        steeringResults.add(new SteeringResult(target, deliveryService));
+
+        trafficRouter.filterSteeringResultsByCountry(steeringResults, "::1", deliveryService);
+
+        assertEquals(Arrays.asList(resultUnlimited, resultIncluded), steeringResults);
+    }
+
+    @Test
+    public void testFilterByCountryUnknownClientCountry() {
+        SteeringTarget target = new SteeringTarget();
+        SteeringResult resultUnlimited = new SteeringResult(target, deliveryService);
+        steeringResults.add(resultUnlimited);
+
+        target = new SteeringTarget();
+        target.setGeoLimitCountries(Collections.singletonList("US"));
+        steeringResults.add(new SteeringResult(target, deliveryService));
+
+        trafficRouter.filterSteeringResultsByCountry(steeringResults, null, deliveryService);
+
+        assertEquals(Collections.singletonList(resultUnlimited), steeringResults);
+    }
 
+    @Test
+    public void testNoSteeringTargetsHaveCountryLimits() throws Exception {
+        steeringResults.add(new SteeringResult(new SteeringTarget(), deliveryService));
+        trafficRouter.filterSteeringResultsByCountry(steeringResults, "::1", deliveryService);
+        verify(trafficRouter, never()).getLocation("::1", deliveryService);
+        assertEquals(1, steeringResults.size());
+    }
+
+}
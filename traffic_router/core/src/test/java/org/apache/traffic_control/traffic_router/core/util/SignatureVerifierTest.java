@@ -0,0 +1,65 @@
+/*
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package org.apache.traffic_control.traffic_router.core.util;
+
+import org.junit.Rule;
+import org.junit.Test;
+import org.junit.rules.TemporaryFolder;
+
+import java.io.File;
+import java.nio.charset.StandardCharsets;
+import java.nio.file.Files;
+import java.security.KeyPair;
+import java.security.KeyPairGenerator;
+import java.security.Signature;
+import java.security.spec.ECGenParameterSpec;
+import java.util.Base64;
+
+import static org.hamcrest.MatcherAssert.assertThat;
+import static org.hamcrest.Matchers.equalTo;
+
+public class SignatureVerifierTest {
+	@Rule
+	public TemporaryFolder folder = new TemporaryFolder();
+
+	@Test
+	public void itVerifiesSignaturesWithKeyFromFile() throws Exception {
+		final KeyPairGenerator generator = KeyPairGenerator.getInstance("EC");
+		generator.initialize(new ECGenParameterSpec("secp256r1"));
+		final KeyPair keyPair = generator.generateKeyPair();
+
+		final File keyFile = folder.newFile("cdn_signing_key.pem");
+		final String pem = "-----BEGIN PUBLIC KEY-----\n" +
+			"CDN: cdn1\n" +
+			"\n" +
+			Base64.getMimeEncoder(64, "\n".getBytes(StandardCharsets.UTF_8)).encodeToString(keyPair.getPublic().getEncoded()) + "\n" +
+			"-----END PUBLIC KEY-----\n";
+		Files.write(keyFile.toPath(), pem.getBytes(StandardCharsets.UTF_8));
+
+		final byte[] data = "{\"stats\":{\"CDN_name\":\"cdn1\"}}".getBytes(StandardCharsets.UTF_8);
+		final Signature signer = Signature.getInstance("SHA256withECDSA");
+		signer.initSign(keyPair.getPrivate());
+		signer.update(data);
+		final String signature = Base64.getEncoder().encodeToString(signer.sign());
+
+		final SignatureVerifier verifier = SignatureVerifier.fromFile(keyFile.getAbsolutePath());
+		assertThat(verifier.getCdn(), equalTo("cdn1"));
+		assertThat(verifier.verify(data, signature), equalTo(true));
+		assertThat(verifier.verify("{\"stats\":{\"CDN_name\":\"cdn2\"}}".getBytes(StandardCharsets.UTF_8), signature), equalTo(false));
+		assertThat(verifier.verify(data, null), equalTo(false));
+		assertThat(verifier.verify(data, "not a signature"), equalTo(false));
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package anomaly
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/trafficcontrol/traffic_monitor/alerting"
+)
+
+// Source provides the history of Delivery Service measurements.
+type Source interface {
+	// Series returns the points of the given measurement between start and
+	// end for each Delivery Service, averaged over buckets of the given
+	// width. If the width is 0, there is a single point per Delivery
+	// Service, averaged over the whole range.
+	Series(measurement string, start, end time.Time, bucket time.Duration) (map[string][]Point, error)
+}
+
+// Monitor checks Delivery Services for anomalies, and tracks which are
+// ongoing so notifications are only sent when they start and end.
+type Monitor struct {
+	cfg       Config
+	source    string
+	notifiers map[string]alerting.Notifier
+	alerts    map[string]alerting.Alert
+	m         *sync.Mutex
+}
+
+// New creates a new Monitor with the given Config, which must have been
+// validated. The source is included in each Event, to identify the sender.
+func New(cfg Config, source string) *Monitor {
+	notifiers := make(map[string]alerting.Notifier, len(cfg.Notifiers))
+	for name, n := range cfg.Notifiers {
+		notifiers[name] = alerting.NewNotifier(n)
+	}
+	return &Monitor{
+		cfg:       cfg,
+		source:    source,
+		notifiers: notifiers,
+		alerts:    map[string]alerting.Alert{},
+		m:         &sync.Mutex{},
+	}
+}
+
+// Run checks every Delivery Service against every detector as of now, and
+// returns an Event for each anomaly which started or ended. Runs are
+// serialized; if a detector fails, the others are still checked, and its
+// ongoing anomalies are left as they were.
+func (m *Monitor) Run(src Source, now time.Time) ([]alerting.Event, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	events := []alerting.Event{}
+	errs := []string{}
+	for _, d := range m.cfg.Detectors {
+		detectorEvents, err := m.runDetector(src, d, now)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("detector '%s': %v", d.Name, err))
+			continue
+		}
+		events = append(events, detectorEvents...)
+	}
+	if len(errs) > 0 {
+		return events, errors.New(strings.Join(errs, "; "))
+	}
+	return events, nil
+}
+
+func (m *Monitor) runDetector(src Source, d Detector, now time.Time) ([]alerting.Event, error) {
+	history, err := m.series(src, d.Metric, now.Add(-m.cfg.Lookback()), now, m.cfg.Bucket())
+	if err != nil {
+		return nil, fmt.Errorf("getting history: %v", err)
+	}
+	current, err := m.series(src, d.Metric, now.Add(-m.cfg.Window()), now, 0)
+	if err != nil {
+		return nil, fmt.Errorf("getting current values: %v", err)
+	}
+
+	operator := alerting.OperatorGreater
+	if d.Direction == DirectionDrop {
+		operator = alerting.OperatorLess
+	}
+
+	events := []alerting.Event{}
+	anomalous := map[string]struct{}{}
+	for ds, points := range current {
+		if len(points) == 0 {
+			continue
+		}
+		value := points[0].Value
+		baseline := Baseline(history[ds], now, m.cfg.Bucket())
+		if baseline.Count < m.cfg.MinBaselineDays || !d.Anomalous(value, baseline) {
+			continue
+		}
+
+		alert := alerting.Alert{
+			Rule:      d.Name,
+			Scope:     alerting.ScopeDeliveryService,
+			Target:    ds,
+			Metric:    d.Metric,
+			Operator:  operator,
+			Threshold: d.Threshold(baseline),
+			Value:     value,
+			Severity:  d.Severity,
+			State:     alerting.AlertStateFiring,
+			Since:     now,
+			FiredAt:   &now,
+		}
+		key := alert.Key()
+		anomalous[key] = struct{}{}
+		if prev, ok := m.alerts[key]; ok {
+			alert.Since = prev.Since
+			alert.FiredAt = prev.FiredAt
+		} else {
+			events = append(events, alerting.Event{Alert: alert, Source: m.source, Time: now})
+		}
+		m.alerts[key] = alert
+	}
+
+	for key, alert := range m.alerts {
+		if alert.Rule != d.Name {
+			continue
+		}
+		if _, ok := anomalous[key]; ok {
+			continue
+		}
+		delete(m.alerts, key)
+		alert.State = alerting.AlertStateResolved
+		events = append(events, alerting.Event{Alert: alert, Source: m.source, Time: now})
+	}
+	return events, nil
+}
+
+// series returns the points of the given metric, computing percentages from
+// their measurements if necessary.
+func (m *Monitor) series(src Source, metricName string, start, end time.Time, bucket time.Duration) (map[string][]Point, error) {
+	metric := Metrics[metricName]
+	series, err := src.Series(metric.Measurement, start, end, bucket)
+	if err != nil || metric.Denominator == "" {
+		return series, err
+	}
+	denominators, err := src.Series(metric.Denominator, start, end, bucket)
+	if err != nil {
+		return nil, err
+	}
+	for ds, points := range series {
+		series[ds] = Percent(points, denominators[ds])
+	}
+	return series, nil
+}
+
+// Notify sends the given Event to each notifier of its detector, returning an
+// error describing any which failed.
+func (m *Monitor) Notify(event alerting.Event) error {
+	errs := []string{}
+	for _, d := range m.cfg.Detectors {
+		if d.Name != event.Rule {
+			continue
+		}
+		for _, name := range d.Notifiers {
+			if err := m.notifiers[name].Notify(event); err != nil {
+				errs = append(errs, fmt.Sprintf("notifier '%s': %v", name, err))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
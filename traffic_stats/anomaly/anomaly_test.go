@@ -0,0 +1,164 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package anomaly
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/apache/trafficcontrol/traffic_monitor/alerting"
+
+	influx "github.com/influxdata/influxdb/client/v2"
+	"github.com/influxdata/influxdb/models"
+)
+
+// fakeSource serves a constant history per measurement and Delivery Service,
+// one point per day at the time of day being checked, and a settable current
+// value.
+type fakeSource struct {
+	history map[string]map[string][]float64
+	current map[string]map[string]float64
+}
+
+func (s fakeSource) Series(measurement string, start, end time.Time, bucket time.Duration) (map[string][]Point, error) {
+	series := map[string][]Point{}
+	if bucket == 0 {
+		for ds, v := range s.current[measurement] {
+			series[ds] = []Point{{Time: start, Value: v}}
+		}
+		return series, nil
+	}
+	for ds, values := range s.history[measurement] {
+		for i, v := range values {
+			series[ds] = append(series[ds], Point{Time: end.Truncate(bucket).AddDate(0, 0, -(i + 1)), Value: v})
+		}
+	}
+	return series, nil
+}
+
+func testConfig() Config {
+	cfg := Config{Enable: true}
+	cfg.SetDefaults()
+	return cfg
+}
+
+func TestConfigDefaults(t *testing.T) {
+	cfg := Config{Notifiers: map[string]alerting.NotifierConfig{"hook": {Type: alerting.NotifierTypeWebhook, URL: "http://example.test"}}}
+	cfg.SetDefaults()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("expected defaults to be valid, actual: %v", err)
+	}
+	if len(cfg.Detectors) != len(DefaultDetectors) {
+		t.Fatalf("expected default detectors, actual: %+v", cfg.Detectors)
+	}
+	if len(cfg.Detectors[0].Notifiers) != 1 || cfg.Detectors[0].Notifiers[0] != "hook" {
+		t.Errorf("expected default detectors to use every notifier, actual: %+v", cfg.Detectors[0].Notifiers)
+	}
+
+	invalid := []Config{
+		{BucketMinutes: 7},
+		{MinBaselineDays: 30},
+		{Detectors: []Detector{{Name: "d", Metric: "nope", Direction: DirectionDrop}}},
+		{Detectors: []Detector{{Name: "d", Metric: "kbps", Direction: "sideways"}}},
+		{Detectors: []Detector{{Name: "d", Metric: "kbps", Direction: DirectionDrop, Notifiers: []string{"x"}}}},
+		{Notifiers: map[string]alerting.NotifierConfig{"x": {Type: alerting.NotifierTypePagerDuty}}},
+	}
+	for i, cfg := range invalid {
+		cfg.SetDefaults()
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("config %d: expected error, actual: nil", i)
+		}
+	}
+}
+
+func TestMonitorRun(t *testing.T) {
+	src := fakeSource{
+		history: map[string]map[string][]float64{
+			"tps_total.ds.1min": {"ds1": {100, 110, 90, 100}, "new": {100}},
+			"tps_5xx.ds.1min":   {"ds1": {0.1, 0.1, 0.1, 0.1}, "new": {0}},
+		},
+		current: map[string]map[string]float64{
+			"tps_total.ds.1min": {"ds1": 10, "new": 0},
+			"tps_5xx.ds.1min":   {"ds1": 0, "new": 0},
+		},
+	}
+	m := New(testConfig(), "ts1")
+	now := time.Now()
+
+	events, err := m.Run(src, now)
+	if err != nil {
+		t.Fatalf("expected no error, actual: %v", err)
+	}
+	if len(events) != 1 || events[0].Rule != "traffic-cliff" || events[0].Target != "ds1" || events[0].State != alerting.AlertStateFiring {
+		t.Fatalf("expected a traffic cliff on ds1 only, actual: %+v", events)
+	}
+	if events[0].Source != "ts1" || events[0].Operator != alerting.OperatorLess || events[0].Value != 10 {
+		t.Errorf("unexpected event: %+v", events[0])
+	}
+
+	if events, _ := m.Run(src, now.Add(time.Minute)); len(events) != 0 {
+		t.Fatalf("expected no repeated events for an ongoing anomaly, actual: %+v", events)
+	}
+
+	src.current["tps_total.ds.1min"]["ds1"] = 100
+	src.current["tps_5xx.ds.1min"]["ds1"] = 10
+	events, _ = m.Run(src, now.Add(2*time.Minute))
+	if len(events) != 2 {
+		t.Fatalf("expected the cliff to resolve and a 5xx surge to start, actual: %+v", events)
+	}
+	for _, e := range events {
+		switch e.Rule {
+		case "traffic-cliff":
+			if e.State != alerting.AlertStateResolved {
+				t.Errorf("expected the traffic cliff to resolve, actual: %+v", e)
+			}
+		case "5xx-surge":
+			if e.State != alerting.AlertStateFiring || e.Value != 10 {
+				t.Errorf("expected a 10%% 5xx surge, actual: %+v", e)
+			}
+		default:
+			t.Errorf("unexpected event: %+v", e)
+		}
+	}
+}
+
+func TestParseSeries(t *testing.T) {
+	results := []influx.Result{{Series: []models.Row{{
+		Tags:    map[string]string{"deliveryservice": "ds1"},
+		Columns: []string{"time", "mean"},
+		Values: [][]interface{}{
+			{"2022-05-24T18:00:00Z", json.Number("12.5")},
+			{"2022-05-24T18:15:00Z", nil},
+		},
+	}}}}
+	series, err := parseSeries(results, time.Time{})
+	if err != nil {
+		t.Fatalf("expected no error, actual: %v", err)
+	}
+	points := series["ds1"]
+	if len(points) != 1 || points[0].Value != 12.5 || !points[0].Time.Equal(time.Date(2022, 5, 24, 18, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected a single point of 12.5 at 18:00, actual: %+v", points)
+	}
+
+	if _, err := parseSeries([]influx.Result{{Err: "boom"}}, time.Time{}); err == nil {
+		t.Error("expected an error for a failed result, actual: nil")
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package anomaly
+
+import (
+	"math"
+	"time"
+)
+
+// RecentExclusion is how recent a point may be and still be part of a
+// baseline. It excludes the current day, so an ongoing anomaly can't pull its
+// own baseline towards it.
+const RecentExclusion = 12 * time.Hour
+
+// Point is a single value of a metric at a time.
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Metric describes how to compute a metric from the Delivery Service
+// measurements Traffic Stats stores in its "monthly" retention policy. If
+// Denominator is set, the metric is the percentage Measurement is of it.
+type Metric struct {
+	Measurement string
+	Denominator string
+}
+
+// Metrics are the metrics detectors may use.
+var Metrics = map[string]Metric{
+	"kbps":            {Measurement: "kbps.ds.1min"},
+	"tps_total":       {Measurement: "tps_total.ds.1min"},
+	"tps_2xx":         {Measurement: "tps_2xx.ds.1min"},
+	"tps_3xx":         {Measurement: "tps_3xx.ds.1min"},
+	"tps_4xx":         {Measurement: "tps_4xx.ds.1min"},
+	"tps_5xx":         {Measurement: "tps_5xx.ds.1min"},
+	"tps_4xx_percent": {Measurement: "tps_4xx.ds.1min", Denominator: "tps_total.ds.1min"},
+	"tps_5xx_percent": {Measurement: "tps_5xx.ds.1min", Denominator: "tps_total.ds.1min"},
+}
+
+// Percent returns each numerator point as a percentage of the denominator
+// point at the same time. Points with no denominator are dropped, and points
+// with a zero denominator are 0, since no traffic has no errors.
+func Percent(numerator, denominator []Point) []Point {
+	totals := make(map[int64]float64, len(denominator))
+	for _, p := range denominator {
+		totals[p.Time.UnixNano()] = p.Value
+	}
+	points := make([]Point, 0, len(numerator))
+	for _, p := range numerator {
+		total, ok := totals[p.Time.UnixNano()]
+		if !ok {
+			continue
+		}
+		pct := 0.0
+		if total != 0 {
+			pct = p.Value / total * 100
+		}
+		points = append(points, Point{Time: p.Time, Value: pct})
+	}
+	return points
+}
+
+// Stats is the baseline of a metric at a time of day.
+type Stats struct {
+	Mean   float64
+	StdDev float64
+	// Count is the number of points the baseline was learned from; normally
+	// one per previous day.
+	Count int
+}
+
+// slot returns the index of the time-of-day slot of the given width which t
+// is in.
+func slot(t time.Time, bucket time.Duration) int {
+	t = t.UTC()
+	sinceMidnight := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+	return int(sinceMidnight / bucket)
+}
+
+// Baseline returns the baseline of the given points at the time of day of now.
+// The points should be averages over buckets of the given width; only those
+// in the same time-of-day bucket as now, and older than RecentExclusion, are
+// used.
+func Baseline(points []Point, now time.Time, bucket time.Duration) Stats {
+	nowSlot := slot(now, bucket)
+	cutoff := now.Add(-RecentExclusion)
+	values := []float64{}
+	for _, p := range points {
+		if p.Time.After(cutoff) || slot(p.Time, bucket) != nowSlot {
+			continue
+		}
+		values = append(values, p.Value)
+	}
+	if len(values) == 0 {
+		return Stats{}
+	}
+
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(values))
+	return Stats{Mean: mean, StdDev: math.Sqrt(variance), Count: len(values)}
+}
+
+// Threshold returns the value beyond which the metric is anomalous, given its
+// baseline: the further from the mean of the Detector's standard deviations
+// and minimum change.
+func (d Detector) Threshold(baseline Stats) float64 {
+	deviation := d.Deviations * baseline.StdDev
+	change := baseline.Mean * d.MinChangePercent / 100
+	if change > deviation {
+		deviation = change
+	}
+	if d.Direction == DirectionDrop {
+		return baseline.Mean - deviation
+	}
+	return baseline.Mean + deviation
+}
+
+// Anomalous returns whether the given value of the Detector's metric is
+// anomalous, given its baseline.
+func (d Detector) Anomalous(value float64, baseline Stats) bool {
+	if value < d.MinValue && baseline.Mean < d.MinValue {
+		return false
+	}
+	if d.Direction == DirectionDrop {
+		return value < d.Threshold(baseline)
+	}
+	return value > d.Threshold(baseline)
+}
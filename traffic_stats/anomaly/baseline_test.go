@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package anomaly
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBaseline(t *testing.T) {
+	now := time.Date(2022, 5, 24, 18, 7, 0, 0, time.UTC)
+	bucket := 15 * time.Minute
+	points := []Point{
+		{Time: time.Date(2022, 5, 21, 18, 0, 0, 0, time.UTC), Value: 90},
+		{Time: time.Date(2022, 5, 22, 18, 0, 0, 0, time.UTC), Value: 100},
+		{Time: time.Date(2022, 5, 23, 18, 0, 0, 0, time.UTC), Value: 110},
+		// a different time of day
+		{Time: time.Date(2022, 5, 23, 18, 15, 0, 0, time.UTC), Value: 1000},
+		// the current day, which is excluded
+		{Time: time.Date(2022, 5, 24, 18, 0, 0, 0, time.UTC), Value: 0},
+	}
+
+	stats := Baseline(points, now, bucket)
+	if stats.Count != 3 {
+		t.Fatalf("expected a baseline of 3 points, actual: %d", stats.Count)
+	}
+	if stats.Mean != 100 {
+		t.Errorf("expected mean 100, actual: %v", stats.Mean)
+	}
+	if expected := math.Sqrt(200.0 / 3.0); math.Abs(stats.StdDev-expected) > 0.0001 {
+		t.Errorf("expected standard deviation %v, actual: %v", expected, stats.StdDev)
+	}
+
+	if stats := Baseline(nil, now, bucket); stats.Count != 0 {
+		t.Errorf("expected an empty baseline with no points, actual: %+v", stats)
+	}
+}
+
+func TestPercent(t *testing.T) {
+	t0 := time.Now()
+	t1 := t0.Add(time.Minute)
+	t2 := t1.Add(time.Minute)
+	points := Percent(
+		[]Point{{Time: t0, Value: 5}, {Time: t1, Value: 1}, {Time: t2, Value: 1}},
+		[]Point{{Time: t0, Value: 100}, {Time: t1, Value: 0}},
+	)
+	if len(points) != 2 {
+		t.Fatalf("expected 2 points, actual: %+v", points)
+	}
+	if points[0].Value != 5 || points[1].Value != 0 {
+		t.Errorf("expected values 5 and 0, actual: %+v", points)
+	}
+}
+
+func TestDetectorAnomalous(t *testing.T) {
+	drop := Detector{Direction: DirectionDrop, Deviations: 3, MinChangePercent: 50, MinValue: 10}
+	baseline := Stats{Mean: 100, StdDev: 5, Count: 7}
+	if threshold := drop.Threshold(baseline); threshold != 50 {
+		t.Errorf("expected the minimum change to dominate a small deviation, threshold 50, actual: %v", threshold)
+	}
+	if drop.Anomalous(60, baseline) {
+		t.Error("expected a 40% drop not to be anomalous")
+	}
+	if !drop.Anomalous(40, baseline) {
+		t.Error("expected a 60% drop to be anomalous")
+	}
+
+	noisy := Stats{Mean: 100, StdDev: 25, Count: 7}
+	if threshold := drop.Threshold(noisy); threshold != 25 {
+		t.Errorf("expected the deviation to dominate a noisy baseline, threshold 25, actual: %v", threshold)
+	}
+	if drop.Anomalous(30, noisy) {
+		t.Error("expected a drop within 3 deviations of a noisy baseline not to be anomalous")
+	}
+
+	spike := Detector{Direction: DirectionSpike, Deviations: 3, MinChangePercent: 100, MinValue: 1}
+	quiet := Stats{Mean: 0.1, StdDev: 0.05, Count: 7}
+	if spike.Anomalous(0.5, quiet) {
+		t.Error("expected a spike below the noise floor not to be anomalous")
+	}
+	if !spike.Anomalous(2, quiet) {
+		t.Error("expected a spike above the noise floor to be anomalous")
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+// Package anomaly detects Delivery Service traffic which deviates significantly
+// from its usual level at the same time of day, as learned from the stats
+// Traffic Stats stores in InfluxDB.
+package anomaly
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apache/trafficcontrol/traffic_monitor/alerting"
+)
+
+const (
+	DefaultInterval        = 5 * 60 // seconds
+	DefaultLookbackDays    = 14
+	DefaultBucketMinutes   = 15
+	DefaultWindowMinutes   = 15
+	DefaultMinBaselineDays = 3
+	DefaultDeviations      = 3.0
+)
+
+// Direction is the direction in which a metric must deviate from its baseline
+// to be anomalous.
+type Direction string
+
+const (
+	// DirectionDrop detectors flag metrics which fall below their baseline.
+	DirectionDrop = Direction("drop")
+	// DirectionSpike detectors flag metrics which rise above their baseline.
+	DirectionSpike = Direction("spike")
+)
+
+// Config is the "anomalyDetection" section of the Traffic Stats configuration
+// file.
+type Config struct {
+	Enable bool `json:"enable"`
+	// Interval is how often, in seconds, Delivery Services are checked.
+	Interval int `json:"interval"`
+	// LookbackDays is how many days of history baselines are learned from.
+	// It must not exceed the retention of the "monthly" retention policy.
+	LookbackDays int `json:"lookbackDays"`
+	// BucketMinutes is the width of the time-of-day slots of a baseline.
+	BucketMinutes int `json:"bucketMinutes"`
+	// WindowMinutes is the width of the recent window whose average is
+	// compared to the baseline.
+	WindowMinutes int `json:"windowMinutes"`
+	// MinBaselineDays is how many previous days must have data for a time of
+	// day before a Delivery Service is checked against it.
+	MinBaselineDays int                                `json:"minBaselineDays"`
+	Detectors       []Detector                         `json:"detectors"`
+	Notifiers       map[string]alerting.NotifierConfig `json:"notifiers"`
+}
+
+// Detector is a single kind of anomaly, checked for every Delivery Service.
+type Detector struct {
+	Name string `json:"name"`
+	// Metric is one of the keys of Metrics.
+	Metric    string    `json:"metric"`
+	Direction Direction `json:"direction"`
+	// Deviations is how many standard deviations from the baseline mean the
+	// metric must be to be anomalous.
+	Deviations float64 `json:"deviations"`
+	// MinChangePercent is how far, as a percentage of the baseline mean, the
+	// metric must be from the mean to be anomalous. This keeps Delivery
+	// Services with very stable baselines from flagging insignificant changes.
+	MinChangePercent float64 `json:"minChangePercent"`
+	// MinValue is the noise floor: if neither the metric nor its baseline mean
+	// reach it, the Delivery Service is not checked.
+	MinValue  float64  `json:"minValue"`
+	Severity  string   `json:"severity"`
+	Notifiers []string `json:"notifiers"`
+}
+
+// DefaultDetectors are the detectors used if none are configured.
+var DefaultDetectors = []Detector{
+	{
+		Name:             "traffic-cliff",
+		Metric:           "tps_total",
+		Direction:        DirectionDrop,
+		Deviations:       DefaultDeviations,
+		MinChangePercent: 50,
+		MinValue:         10,
+		Severity:         "critical",
+	},
+	{
+		Name:             "5xx-surge",
+		Metric:           "tps_5xx_percent",
+		Direction:        DirectionSpike,
+		Deviations:       DefaultDeviations,
+		MinChangePercent: 100,
+		MinValue:         1,
+		Severity:         "error",
+	},
+}
+
+// SetDefaults sets the default value of each unset field. If no Detectors are
+// configured, the DefaultDetectors are used, sent to every notifier.
+func (c *Config) SetDefaults() {
+	if c.Interval == 0 {
+		c.Interval = DefaultInterval
+	}
+	if c.LookbackDays == 0 {
+		c.LookbackDays = DefaultLookbackDays
+	}
+	if c.BucketMinutes == 0 {
+		c.BucketMinutes = DefaultBucketMinutes
+	}
+	if c.WindowMinutes == 0 {
+		c.WindowMinutes = DefaultWindowMinutes
+	}
+	if c.MinBaselineDays == 0 {
+		c.MinBaselineDays = DefaultMinBaselineDays
+	}
+	if len(c.Detectors) == 0 {
+		notifiers := make([]string, 0, len(c.Notifiers))
+		for name := range c.Notifiers {
+			notifiers = append(notifiers, name)
+		}
+		for _, d := range DefaultDetectors {
+			d.Notifiers = notifiers
+			c.Detectors = append(c.Detectors, d)
+		}
+	}
+	for i := range c.Detectors {
+		if c.Detectors[i].Deviations == 0 {
+			c.Detectors[i].Deviations = DefaultDeviations
+		}
+		if c.Detectors[i].Severity == "" {
+			c.Detectors[i].Severity = alerting.DefaultSeverity
+		}
+	}
+}
+
+// Validate returns an error describing the first problem found with the
+// Config, or nil if it is valid. It should be called after SetDefaults.
+func (c Config) Validate() error {
+	if c.Interval < 0 || c.LookbackDays < 0 || c.WindowMinutes < 0 || c.MinBaselineDays < 0 {
+		return errors.New("interval, lookbackDays, windowMinutes, and minBaselineDays must not be negative")
+	}
+	if c.BucketMinutes <= 0 || (24*60)%c.BucketMinutes != 0 {
+		return errors.New("bucketMinutes must evenly divide a day")
+	}
+	if c.MinBaselineDays > c.LookbackDays {
+		return errors.New("minBaselineDays must not be greater than lookbackDays")
+	}
+	for name, n := range c.Notifiers {
+		if err := n.Validate(); err != nil {
+			return fmt.Errorf("notifier '%s': %v", name, err)
+		}
+	}
+	names := map[string]struct{}{}
+	for i, d := range c.Detectors {
+		if d.Name == "" {
+			return fmt.Errorf("detector %d: missing name", i)
+		}
+		if _, ok := names[d.Name]; ok {
+			return fmt.Errorf("detector '%s': duplicate name", d.Name)
+		}
+		names[d.Name] = struct{}{}
+		if _, ok := Metrics[d.Metric]; !ok {
+			return fmt.Errorf("detector '%s': unknown metric '%s'", d.Name, d.Metric)
+		}
+		if d.Direction != DirectionDrop && d.Direction != DirectionSpike {
+			return fmt.Errorf("detector '%s': direction must be '%s' or '%s'", d.Name, DirectionDrop, DirectionSpike)
+		}
+		if d.Deviations < 0 || d.MinChangePercent < 0 {
+			return fmt.Errorf("detector '%s': deviations and minChangePercent must not be negative", d.Name)
+		}
+		if _, ok := alerting.Severities[d.Severity]; !ok {
+			return fmt.Errorf("detector '%s': unknown severity '%s'", d.Name, d.Severity)
+		}
+		for _, n := range d.Notifiers {
+			if _, ok := c.Notifiers[n]; !ok {
+				return fmt.Errorf("detector '%s': unknown notifier '%s'", d.Name, n)
+			}
+		}
+	}
+	return nil
+}
+
+// Window returns the width of the recent window compared to the baseline.
+func (c Config) Window() time.Duration {
+	return time.Duration(c.WindowMinutes) * time.Minute
+}
+
+// Bucket returns the width of the baseline's time-of-day slots.
+func (c Config) Bucket() time.Duration {
+	return time.Duration(c.BucketMinutes) * time.Minute
+}
+
+// Lookback returns how far back baselines are learned from.
+func (c Config) Lookback() time.Duration {
+	return time.Duration(c.LookbackDays) * 24 * time.Hour
+}
@@ -0,0 +1,97 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one
+or more contributor license agreements.  See the NOTICE file
+distributed with this work for additional information
+regarding copyright ownership.  The ASF licenses this file
+to you under the Apache License, Version 2.0 (the
+"License"); you may not use this file except in compliance
+with the License.  You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing,
+software distributed under the License is distributed on an
+"AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations
+under the License.
+*/
+
+package anomaly
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/apache/trafficcontrol/traffic_stats/influxdb"
+
+	influx "github.com/influxdata/influxdb/client/v2"
+)
+
+// Database is the InfluxDB database of Delivery Service stats.
+const Database = "deliveryservice_stats"
+
+// InfluxSource is a Source which queries the per-minute Delivery Service
+// aggregates in the "monthly" retention policy of Traffic Stats' InfluxDB.
+type InfluxSource struct {
+	Client influx.Client
+}
+
+// Series implements Source.
+func (s InfluxSource) Series(measurement string, start, end time.Time, bucket time.Duration) (map[string][]Point, error) {
+	groupBy := "deliveryservice"
+	if bucket > 0 {
+		groupBy = fmt.Sprintf("time(%ds), deliveryservice fill(none)", int64(bucket/time.Second))
+	}
+	query := fmt.Sprintf(`SELECT mean(value) FROM "monthly"."%s" WHERE time >= '%s' AND time < '%s' GROUP BY %s`, measurement, start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339), groupBy)
+	results, err := influxdb.QueryDB(s.Client, query, Database)
+	if err != nil {
+		return nil, err
+	}
+	return parseSeries(results, start)
+}
+
+// parseSeries returns the points of each Delivery Service in the given query
+// results. Points without a time, as from a query with no time grouping, are
+// given the default time.
+func parseSeries(results []influx.Result, defaultTime time.Time) (map[string][]Point, error) {
+	series := map[string][]Point{}
+	for _, result := range results {
+		if result.Err != "" {
+			return nil, errors.New(result.Err)
+		}
+		for _, row := range result.Series {
+			ds := row.Tags["deliveryservice"]
+			for _, values := range row.Values {
+				if len(values) < 2 || values[1] == nil {
+					continue
+				}
+				t := defaultTime
+				if ts, ok := values[0].(string); ok {
+					parsed, err := time.Parse(time.RFC3339, ts)
+					if err != nil {
+						return nil, fmt.Errorf("parsing time '%s': %v", ts, err)
+					}
+					t = parsed
+				}
+				var value float64
+				switch v := values[1].(type) {
+				case json.Number:
+					f, err := v.Float64()
+					if err != nil {
+						return nil, fmt.Errorf("parsing value '%s': %v", v, err)
+					}
+					value = f
+				case float64:
+					value = v
+				default:
+					return nil, fmt.Errorf("unexpected value type %T", values[1])
+				}
+				series[ds] = append(series[ds], Point{Time: t, Value: value})
+			}
+		}
+	}
+	return series, nil
+}
@@ -40,7 +40,10 @@ import (
 	"github.com/apache/trafficcontrol/lib/go-log"
 	"github.com/apache/trafficcontrol/lib/go-tc"
 	"github.com/apache/trafficcontrol/lib/go-util"
+	"github.com/apache/trafficcontrol/traffic_monitor/alerting"
 	client "github.com/apache/trafficcontrol/traffic_ops/v3-client"
+	toclient "github.com/apache/trafficcontrol/traffic_ops/v4-client"
+	"github.com/apache/trafficcontrol/traffic_stats/anomaly"
 
 	"github.com/Shopify/sarama"
 	"github.com/cihub/seelog"
@@ -48,6 +51,10 @@ import (
 )
 
 const UserAgent = "traffic-stats"
+
+// annotationsMeasurement is the measurement of the daily_stats database to
+// which Traffic Ops annotations are written, for Grafana to overlay on graphs.
+const annotationsMeasurement = "annotations"
 const TrafficOpsRequestTimeout = time.Second * time.Duration(10)
 
 const (
@@ -112,7 +119,8 @@ type StartupConfig struct {
 	DailySummaryRetentionPolicy string   `json:"dailySummaryRetentionPolicy"`
 	BpsChan                     chan influx.BatchPoints
 	InfluxDBs                   []*InfluxDBProps
-	KafkaConfig                 KafkaConfig `json:"kafkaConfig"`
+	KafkaConfig                 KafkaConfig    `json:"kafkaConfig"`
+	AnomalyDetection            anomaly.Config `json:"anomalyDetection"`
 }
 
 type KafkaConfig struct {
@@ -178,6 +186,7 @@ type Timers struct {
 	DailySummary <-chan time.Time
 	Publish      <-chan time.Time
 	Config       <-chan time.Time
+	Anomaly      <-chan time.Time
 }
 
 func info(args ...interface{}) {
@@ -266,10 +275,12 @@ func main() {
 	configChan := make(chan RunningConfig)
 	go getToData(config, true, configChan)
 	runningConfig := <-configChan
+	go syncAnnotations(config)
 
 	c := newKakfaCluster(config.KafkaConfig)
 
 	tickers = setTimers(config)
+	anomalyMonitor := newAnomalyMonitor(config)
 
 	termChan := make(chan os.Signal, 1)
 	signal.Notify(termChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
@@ -298,6 +309,7 @@ func main() {
 			} else {
 				config = newConfig
 				tickers = setTimers(config)
+				anomalyMonitor = newAnomalyMonitor(config)
 			}
 		case <-termChan:
 			info("Shutdown Request Received - Sending stored metrics then quitting")
@@ -318,6 +330,7 @@ func main() {
 		case runningConfig = <-configChan:
 		case <-tickers.Config:
 			go getToData(config, false, configChan)
+			go syncAnnotations(config)
 		case <-tickers.Poll:
 			for cdnName, urls := range runningConfig.HealthUrls {
 				for _, u := range urls {
@@ -327,6 +340,8 @@ func main() {
 			}
 		case now := <-tickers.DailySummary:
 			go calcDailySummary(now, config, runningConfig)
+		case now := <-tickers.Anomaly:
+			go detectAnomalies(now, config, anomalyMonitor)
 		case batchPoints := <-config.BpsChan:
 			debug("Received ", len(batchPoints.Points()), " stats")
 			key := fmt.Sprintf("%s%s", batchPoints.Database(), batchPoints.RetentionPolicy())
@@ -477,6 +492,9 @@ func setTimers(config StartupConfig) Timers {
 	timers.DailySummary = time.Tick(time.Duration(config.DailySummaryPollingInterval) * time.Second)
 	timers.Publish = time.Tick(time.Duration(config.PublishingInterval) * time.Second)
 	timers.Config = time.Tick(time.Duration(config.ConfigInterval) * time.Second)
+	if config.AnomalyDetection.Enable {
+		timers.Anomaly = time.Tick(time.Duration(config.AnomalyDetection.Interval) * time.Second)
+	}
 
 	return timers
 }
@@ -538,6 +556,16 @@ func loadStartupConfig(configFile string, oldConfig StartupConfig) (StartupConfi
 		warn("No logging configuration found in configuration file - default logging to stderr will be used")
 	}
 
+	if config.AnomalyDetection.Enable {
+		if config.DisableInflux {
+			return config, errors.New("anomalyDetection requires InfluxDB, but disableInflux is true")
+		}
+		config.AnomalyDetection.SetDefaults()
+		if err := config.AnomalyDetection.Validate(); err != nil {
+			return config, fmt.Errorf("invalid anomalyDetection: %w", err)
+		}
+	}
+
 	if config.DisableInflux {
 		return config, nil
 	}
@@ -590,10 +618,124 @@ func calcDailySummary(now time.Time, config StartupConfig, runningConfig Running
 
 		calcDailyMaxGbps(influxClient, bp, startTime, endTime, config)
 		calcDailyBytesServed(influxClient, bp, startTime, endTime, config)
+		calcDailyDSStats(influxClient, startTime, endTime, config)
 		info("Collected daily stats @ ", now)
 	}
 }
 
+// newAnomalyMonitor returns the anomaly monitor for the given config, or nil if
+// anomaly detection is disabled.
+func newAnomalyMonitor(config StartupConfig) *anomaly.Monitor {
+	if !config.AnomalyDetection.Enable {
+		return nil
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		warnf("getting hostname for anomaly notifications: %v", err)
+		hostname = "traffic_stats"
+	}
+	return anomaly.New(config.AnomalyDetection, hostname)
+}
+
+// detectAnomalies checks every Delivery Service for anomalies against its
+// baselines, and notifies about any which started or ended.
+func detectAnomalies(now time.Time, config StartupConfig, monitor *anomaly.Monitor) {
+	influxClient, err := influxConnect(config)
+	if err != nil {
+		errorf("could not connect to InfluxDb to detect anomalies: %v", err)
+		return
+	}
+	events, err := monitor.Run(anomaly.InfluxSource{Client: influxClient}, now)
+	if err != nil {
+		errorf("detecting anomalies: %v", err)
+	}
+	for _, event := range events {
+		warnf("anomaly %s: %s", event.State, alerting.Summary(event))
+		go func(event alerting.Event) {
+			if err := monitor.Notify(event); err != nil {
+				errorf("sending anomaly notification for %s: %v", event.Key(), err)
+			}
+		}(event)
+	}
+}
+
+// syncAnnotations replaces the annotations in InfluxDB with those in Traffic
+// Ops, so that annotations which were changed or deleted are too.
+func syncAnnotations(config StartupConfig) {
+	if config.DisableInflux {
+		return
+	}
+	to, _, err := toclient.LoginWithAgent(config.ToURL, config.ToUser, config.ToPasswd, true, UserAgent, false, TrafficOpsRequestTimeout)
+	if err != nil {
+		errorf("could not sync annotations! Error logging in to %v: %v", config.ToURL, err)
+		return
+	}
+	resp, _, err := to.GetAnnotations(toclient.NewRequestOptions())
+	if err != nil {
+		errorf("could not get annotations from %v: %v", config.ToURL, err)
+		return
+	}
+
+	bp, err := annotationPoints(resp.Response, config.DailySummaryRetentionPolicy)
+	if err != nil {
+		errorf("could not create annotation points: %v", err)
+		return
+	}
+
+	influxClient, err := influxConnect(config)
+	if err != nil {
+		errorf("could not connect to InfluxDb to sync annotations: %v", err)
+		return
+	}
+	defer influxClient.Close()
+	if _, err := queryDB(influxClient, `DELETE FROM "`+annotationsMeasurement+`"`, "daily_stats"); err != nil {
+		errorf("could not delete old annotations: %v", err)
+		return
+	}
+	if err := influxClient.Write(bp); err != nil {
+		errorf("could not write annotations: %v", err)
+		return
+	}
+	infof("synced %d annotations", len(resp.Response))
+}
+
+// annotationPoints returns a point for each of the given annotations, at its
+// start time. Each is tagged by its ID and target, and has a "description"
+// field and an "end_time" field in milliseconds since the epoch, which
+// Grafana can use as the annotation's text and end.
+func annotationPoints(annotations []tc.Annotation, retentionPolicy string) (influx.BatchPoints, error) {
+	bp, err := influx.NewBatchPoints(influx.BatchPointsConfig{
+		Database:        "daily_stats",
+		Precision:       "ms",
+		RetentionPolicy: retentionPolicy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, annotation := range annotations {
+		tags := map[string]string{"id": strconv.Itoa(annotation.ID)}
+		if annotation.CDN != nil {
+			tags["cdn"] = *annotation.CDN
+		}
+		if annotation.Cachegroup != nil {
+			tags["cachegroup"] = *annotation.Cachegroup
+		}
+		if annotation.DeliveryService != nil {
+			tags["deliveryservice"] = *annotation.DeliveryService
+		}
+		fields := map[string]interface{}{
+			"description": annotation.Description,
+			"end_time":    annotation.EndTime.UnixNano() / int64(time.Millisecond),
+		}
+		pt, err := influx.NewPoint(annotationsMeasurement, tags, fields, annotation.StartTime)
+		if err != nil {
+			return nil, fmt.Errorf("creating point for annotation #%d: %w", annotation.ID, err)
+		}
+		bp.AddPoint(pt)
+	}
+	return bp, nil
+}
+
 func calcDailyMaxGbps(client influx.Client, bp influx.BatchPoints, startTime time.Time, endTime time.Time, config StartupConfig) {
 	kilobitsToGigabits := 1000000.00
 	queryString := fmt.Sprintf(`select time, cdn, max(value) from "monthly"."bandwidth.cdn.1min" where time > '%s' and time < '%s' group by cdn`, startTime.Format(time.RFC3339), endTime.Format(time.RFC3339))
@@ -706,6 +848,101 @@ func calcDailyBytesServed(client influx.Client, bp influx.BatchPoints, startTime
 	}
 }
 
+// calcDailyDSStats summarizes the bytes served and 95th percentile bandwidth
+// of each Delivery Service for the day from startTime to endTime, for
+// Traffic Ops billing reports.
+func calcDailyDSStats(client influx.Client, startTime time.Time, endTime time.Time, config StartupConfig) {
+	kilobytesToTerabytes := 1000000000.00
+	kilobitsToGigabits := 1000000.00
+	sampleTimeSecs := 60.00
+	bitsTobytes := 8.00
+
+	bp, err := influx.NewBatchPoints(influx.BatchPointsConfig{
+		Database:        "daily_stats",
+		Precision:       "s",
+		RetentionPolicy: config.DailySummaryRetentionPolicy,
+	})
+	if err != nil {
+		errorf("creating batch points for daily delivery service stats: %v", err)
+		return
+	}
+
+	where := fmt.Sprintf(`time >= '%s' and time < '%s'`, startTime.Format(time.RFC3339), endTime.Format(time.RFC3339))
+	queries := []struct {
+		statName string
+		query    string
+		toValue  func(float64) float64
+	}{
+		{
+			statName: tc.StatNameDailyDSBytesServed,
+			query:    `select sum(value) from "monthly"."kbps.ds.1min" where ` + where + ` group by deliveryservice, cdn`,
+			toValue:  func(kbpsSum float64) float64 { return kbpsSum * sampleTimeSecs / bitsTobytes / kilobytesToTerabytes },
+		},
+		{
+			statName: tc.StatNameDailyDS95thGbps,
+			query:    `select percentile(value, 95) from (select mean(value) as value from "monthly"."kbps.ds.1min" where ` + where + ` group by time(5m), deliveryservice, cdn) group by deliveryservice, cdn`,
+			toValue:  func(kbps float64) float64 { return kbps / kilobitsToGigabits },
+		},
+	}
+
+	for _, q := range queries {
+		infof("queryString = %s", q.query)
+		res, err := queryDB(client, q.query, "deliveryservice_stats")
+		if err != nil {
+			errorf("An error occured getting daily delivery service stat %s: %v", q.statName, err)
+			continue
+		}
+		if len(res) == 0 {
+			continue
+		}
+		for _, row := range res[0].Series {
+			ds := row.Tags["deliveryservice"]
+			cdn := row.Tags["cdn"]
+			if ds == "" || len(row.Values) == 0 || len(row.Values[0]) < 2 || row.Values[0][1] == nil {
+				continue
+			}
+			num, ok := row.Values[0][1].(json.Number)
+			if !ok {
+				errorf("Couldn't parse value from record %v", row.Values[0])
+				continue
+			}
+			value, err := num.Float64()
+			if err != nil {
+				errorf("Couldn't parse value from record %v", row.Values[0])
+				continue
+			}
+			value = q.toValue(value)
+			infof("%s for ds %v = %v", q.statName, ds, value)
+
+			var statsSummary tc.StatsSummary
+			statsSummary.CDNName = util.StrPtr(cdn)
+			statsSummary.DeliveryService = util.StrPtr(ds)
+			statsSummary.StatName = util.StrPtr(q.statName)
+			statsSummary.StatValue = util.FloatPtr(value)
+			statsSummary.SummaryTime = time.Now()
+			statsSummary.StatDate = &startTime
+			go writeSummaryStats(config, statsSummary)
+
+			tags := map[string]string{"cdn": cdn, "deliveryservice": ds}
+			fields := map[string]interface{}{
+				"value": value,
+			}
+			pt, err := influx.NewPoint(
+				q.statName,
+				tags,
+				fields,
+				startTime,
+			)
+			if err != nil {
+				errorf("error creating data point for %s: %v", q.statName, err)
+				continue
+			}
+			bp.AddPoint(pt)
+		}
+	}
+	config.BpsChan <- bp
+}
+
 func queryDB(con influx.Client, cmd string, database string) (res []influx.Result, err error) {
 	q := influx.Query{
 		Command:  cmd,
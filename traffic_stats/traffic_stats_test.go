@@ -27,6 +27,7 @@ import (
 	"time"
 
 	"github.com/apache/trafficcontrol/lib/go-tc"
+	"github.com/apache/trafficcontrol/lib/go-util"
 	influx "github.com/influxdata/influxdb/client/v2"
 )
 
@@ -258,3 +259,35 @@ func TestSetHealthURLs(t *testing.T) {
 		t.Errorf("expected: %+v, actual: %+v", expected, runningCfg.HealthUrls)
 	}
 }
+
+func TestAnnotationPoints(t *testing.T) {
+	start := time.Date(2022, 5, 22, 2, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Hour)
+	bp, err := annotationPoints([]tc.Annotation{
+		{ID: 1, Description: "fiber maintenance in DEN", StartTime: start, EndTime: end, Cachegroup: util.StrPtr("den")},
+	}, "indefinite")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bp.Database() != "daily_stats" || bp.RetentionPolicy() != "indefinite" {
+		t.Errorf("expected points for daily_stats.indefinite, got %s.%s", bp.Database(), bp.RetentionPolicy())
+	}
+	if len(bp.Points()) != 1 {
+		t.Fatalf("expected 1 point, got %d", len(bp.Points()))
+	}
+	pt := bp.Points()[0]
+	if pt.Name() != annotationsMeasurement || !pt.Time().Equal(start) {
+		t.Errorf("expected annotation point at %v, got %s at %v", start, pt.Name(), pt.Time())
+	}
+	expectedTags := map[string]string{"id": "1", "cachegroup": "den"}
+	if !reflect.DeepEqual(pt.Tags(), expectedTags) {
+		t.Errorf("expected tags %v, got %v", expectedTags, pt.Tags())
+	}
+	fields, err := pt.Fields()
+	if err != nil {
+		t.Fatalf("unexpected error getting fields: %v", err)
+	}
+	if fields["description"] != "fiber maintenance in DEN" || fields["end_time"] != end.UnixNano()/int64(time.Millisecond) {
+		t.Errorf("unexpected fields: %v", fields)
+	}
+}